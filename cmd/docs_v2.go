@@ -0,0 +1,23 @@
+package main
+
+// /api/v2のAPIドキュメント定義
+// swag init -g cmd/docs_v2.go -o docs/v2 --instanceName v2 --tags tasks-v2 で生成する
+// v1のドキュメントは swag init -g cmd/main.go -o docs --tags '!tasks-v2' --templateDelims '[[,]]' でv2のエンドポイントを除外して生成する
+
+// @title           Yotei+ Task Management API v2
+// @version         2.0
+// @description     Yotei+ REST APIのバージョン2です。
+// @description
+// @description     ## v1からの変更点
+// @description     - エラーは `{"error": {"code", "message", "details", "request_id"}}` 形式で返します
+// @description     - 成功レスポンスは `success`・`message` を持たず、`data` にリソースを格納します
+// @description     - クエリパラメータの不正な値は無視せず400を返します
+// @description     - タスクの `assignee_id` を廃止し、見積もりを `estimate` にまとめました
+
+// @host      localhost:8080
+// @BasePath  /api/v2
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT認証トークン。値の形式: "Bearer {token}"
@@ -16,6 +16,7 @@ import (
 
 	// Swagger関連のimport
 	_ "github.com/hryt430/Yotei+/docs" // swag initで自動生成されるドキュメント
+	_ "github.com/hryt430/Yotei+/docs/v2"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -70,6 +71,14 @@ import (
 // @tag.description 通知管理関連のAPI
 
 func main() {
+	// サブコマンド
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := runSeed(os.Args[2:]); err != nil {
+			log.Fatalf("Seed failed: %v", err)
+		}
+		return
+	}
+
 	// 設定の読み込み
 	cfg, err := config.LoadConfig(".")
 	if err != nil {
@@ -90,9 +99,10 @@ func main() {
 		logger.Fatal("Failed to initialize dependencies", appLogger.Error(err))
 	}
 
-	// バックグラウンドサービスの開始
-	// server.StartBackgroundServices(deps)
-	// defer server.StopBackgroundServices(deps)
+	// バックグラウンドサービスの開始（停止はHTTPサーバーの停止後に行う）
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	server.StartBackgroundServices(backgroundCtx, deps)
 
 	// ルーターの設定
 	router := server.SetupRouter(deps)
@@ -101,6 +111,7 @@ func main() {
 	if !cfg.IsProduction() {
 		// Swagger UIエンドポイント
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		router.GET("/swagger-v2/*any", ginSwagger.WrapHandler(swaggerFiles.NewHandler(), ginSwagger.InstanceName("v2")))
 
 		// API仕様書への直接アクセス
 		router.GET("/api-docs", func(c *gin.Context) {
@@ -133,20 +144,36 @@ func main() {
 		}
 	}()
 
+	// 設定ファイル・シークレットの変更の反映（SIGHUPでも即時に読み直す）
+	reloadCtx, stopReload := context.WithCancel(context.Background())
+	defer stopReload()
+	reloader := server.NewReloader(deps, server.ConfigFile)
+	reloader.Start(reloadCtx)
+
 	// Graceful shutdown の設定
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := <-quit; sig == syscall.SIGHUP; sig = <-quit {
+		logger.Info("Received SIGHUP, reloading config")
+		reloader.Reload(reloadCtx)
+	}
+	stopReload()
 
 	logger.Info("Shutting down server...")
 
-	// 30秒のタイムアウトでサーバーを停止
+	// 30秒のタイムアウトでサーバーを停止（処理中のリクエストの完了を待つ）
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		logger.Fatal("Server forced to shutdown", appLogger.Error(err))
+		logger.Error("Server forced to shutdown", appLogger.Error(err))
+	}
+
+	// バックグラウンドサービスを停止（残りの時間内でサービスごとに待ち時間を設けて停止する）
+	if err := server.StopBackgroundServices(ctx, deps); err != nil {
+		logger.Error("Some background services did not stop cleanly", appLogger.Error(err))
 	}
+	stopBackground()
 
 	logger.Info("Server exited")
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/config"
+	commonDB "github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+	authDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/database"
+	authDatabase "github.com/hryt430/Yotei+/internal/modules/auth/interface/database"
+	groupDatabase "github.com/hryt430/Yotei+/internal/modules/group/interface/database"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	taskDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
+	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
+	"github.com/hryt430/Yotei+/internal/seed"
+	"github.com/hryt430/Yotei+/internal/server"
+)
+
+// runSeed はseedサブコマンドを実行する
+//
+//	go run ./cmd seed -profile dev
+//	go run ./cmd seed -profile load -users 5000 -reset
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	profileName := flags.String("profile", "dev", "シードプロファイル（"+strings.Join(seed.ProfileNames(), ", ")+"）")
+	users := flags.Int("users", -1, "ユーザー数（省略時はプロファイルの値）")
+	friends := flags.Int("friends", -1, "1ユーザーあたりの友達申請数（省略時はプロファイルの値）")
+	groups := flags.Int("groups", -1, "グループ数（省略時はプロファイルの値）")
+	members := flags.Int("members", -1, "1グループあたりのメンバー数（省略時はプロファイルの値）")
+	tasks := flags.Int("tasks", -1, "1ユーザーあたりの単発タスク数（省略時はプロファイルの値）")
+	password := flags.String("password", "password123", "シードユーザーのパスワード")
+	reset := flags.Bool("reset", false, "投入前にプロファイルのデータを削除する（日付を現在基準で作り直す場合に使用）")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	profile, err := seed.LookupProfile(*profileName)
+	if err != nil {
+		return err
+	}
+	override(&profile.Users, *users)
+	override(&profile.FriendsPerUser, *friends)
+	override(&profile.Groups, *groups)
+	override(&profile.MembersPerGroup, *members)
+	override(&profile.TasksPerUser, *tasks)
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.IsProduction() {
+		return errors.New("seed is not allowed in production")
+	}
+
+	log := server.NewLogger(cfg)
+	db, err := commonDB.NewConnection(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if *reset {
+		deleted, err := seed.Reset(ctx, db, profile)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d seed users of profile %q\n", deleted, profile.Name)
+	}
+
+	taskSqlHandler := &taskDatabaseInfra.SqlHandler{Conn: db}
+	seeder := seed.NewSeeder(
+		&authDatabase.IUserRepository{SqlHandler: &authDatabaseInfra.SqlHandler{Conn: db}},
+		socialDatabase.NewFriendshipRepository(db, *log),
+		groupDatabase.NewGroupRepository(db, *log),
+		taskDatabase.NewTaskRepository(taskSqlHandler, *log),
+		log,
+	)
+
+	result, err := seeder.Run(ctx, profile, *password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Seed profile %q loaded\n", profile.Name)
+	fmt.Printf("  users:       %d created, %d skipped\n", result.Users.Created, result.Users.Skipped)
+	fmt.Printf("  friendships: %d created, %d skipped\n", result.Friendships.Created, result.Friendships.Skipped)
+	fmt.Printf("  groups:      %d created, %d skipped\n", result.Groups.Created, result.Groups.Skipped)
+	fmt.Printf("  tasks:       %d created, %d skipped\n", result.Tasks.Created, result.Tasks.Skipped)
+	fmt.Printf("Log in as seed-%s-0000@example.com / %s\n", profile.Name, *password)
+	return nil
+}
+
+// override はフラグが指定された場合にプロファイルの値を上書きする
+func override(value *int, flagValue int) {
+	if flagValue >= 0 {
+		*value = flagValue
+	}
+}
@@ -6,21 +6,40 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config はアプリケーション設定を格納する構造体
 type Config struct {
-	Environment string   `mapstructure:"ENVIRONMENT"`
-	Server      Server   `mapstructure:",squash"`
-	Database    Database `mapstructure:",squash"`
-	Redis       Redis    `mapstructure:",squash"`
-	JWT         JWT      `mapstructure:",squash"`
-	CORS        CORS     `mapstructure:",squash"`
-	Security    Security `mapstructure:",squash"`
-	Log         Log      `mapstructure:",squash"`
-	External    External `mapstructure:",squash"`
+	Environment  string       `mapstructure:"ENVIRONMENT"`
+	Profile      string       `mapstructure:"APP_PROFILE"` // 既定値の組み合わせ（standalone: SQLiteに保存し、不要なワーカーを起動しない）
+	Server       Server       `mapstructure:",squash"`
+	Database     Database     `mapstructure:",squash"`
+	Storage      Storage      `mapstructure:",squash"`
+	Redis        Redis        `mapstructure:",squash"`
+	JWT          JWT          `mapstructure:",squash"`
+	CORS         CORS         `mapstructure:",squash"`
+	Security     Security     `mapstructure:",squash"`
+	Log          Log          `mapstructure:",squash"`
+	Notification Notification `mapstructure:",squash"`
+	Social       Social       `mapstructure:",squash"`
+	External     External     `mapstructure:",squash"`
+	API          API          `mapstructure:",squash"`
+	Features     Features     `mapstructure:",squash"`
+	Quota        Quota        `mapstructure:",squash"`
+	Billing      Billing      `mapstructure:",squash"`
+	Analytics    Analytics    `mapstructure:",squash"`
+	Sync         Sync         `mapstructure:",squash"`
+	LinkPreview  LinkPreview  `mapstructure:",squash"`
+	Calendar     Calendar     `mapstructure:",squash"`
+	ErrorTracker ErrorTracker `mapstructure:",squash"`
+	Workers      Workers      `mapstructure:",squash"`
+	Secrets      Secrets      `mapstructure:",squash"`
+
+	// 取得元から読み込んだ秘密情報（環境変数の場合は nil）
+	secrets *SecretStore
 }
 
 // Server はサーバー設定
@@ -30,6 +49,14 @@ type Server struct {
 	MaxRequestSize int64  `mapstructure:"MAX_REQUEST_SIZE"`
 	ReadTimeout    int    `mapstructure:"READ_TIMEOUT"`
 	WriteTimeout   int    `mapstructure:"WRITE_TIMEOUT"`
+	// タスク削除・友達削除・メンバー削除を取り消せる時間（例: "10s"、0で取り消しを無効にし即時に実行する）
+	UndoWindow string `mapstructure:"UNDO_WINDOW"`
+	// リクエストの制限時間（例: "30s"、0で制限しない）
+	RequestTimeout string `mapstructure:"REQUEST_TIMEOUT"`
+	// ルートごとの制限時間（例: "/api/v1/tasks/export=5m,/api/v1/admin=1m"、ルートの定義のプレフィックスで指定する）
+	RouteTimeouts string `mapstructure:"ROUTE_TIMEOUTS"`
+	// 短縮URLのベースURL（このサーバーの公開URL、{base}/s/{slug} を発行する。空の場合は招待・共有リンクを短縮しない）
+	ShortLinkBaseURL string `mapstructure:"SHORT_LINK_BASE_URL"`
 }
 
 // Database はデータベース設定
@@ -43,13 +70,59 @@ type Database struct {
 	TimeZone string `mapstructure:"DB_TIMEZONE"`
 }
 
+// PostgresSchema はPostgreSQLでテーブルを作成するスキーマ（MySQLのデータベース名と同じ）
+const PostgresSchema = "Yotei-Plus"
+
+// ストレージドライバー
+const (
+	StorageDriverMySQL    = "mysql"
+	StorageDriverPostgres = "postgres"
+	StorageDriverSQLite   = "sqlite"
+	StorageDriverMemory   = "memory"
+)
+
+// ProfileStandalone は1人で使うためにデータベースのファイルと単一のバイナリだけで動かすプロファイル
+const ProfileStandalone = "standalone"
+
+// standaloneDisabledWorkers はstandaloneプロファイルで起動しないワーカー
+// グループのエスカレーション・友達申請の整理・週次レポートのメールは1人で使う場合は不要なため
+const standaloneDisabledWorkers = "escalation-worker,social-cleanup-worker,weekly-report-worker"
+
+// Storage はリポジトリの保存先設定
+type Storage struct {
+	// mysql・postgres・sqlite または memory（memoryの場合はデータベース・Redisに接続せず、データはプロセス終了時に消える）
+	Driver string `mapstructure:"STORAGE_DRIVER"`
+	// sqliteの場合のデータベースファイルのパス（存在しない場合は作成する）
+	SQLitePath string `mapstructure:"SQLITE_PATH"`
+	// ユーザー情報（ユーザー名・メールアドレス）をキャッシュする期間（例: "30s"、0でキャッシュしない）
+	UserInfoCacheTTL string `mapstructure:"USER_INFO_CACHE_TTL"`
+	// 添付ファイルとサムネイルを保存するディレクトリ（memoryの場合は使用せず、メモリに保存する）
+	FileDir string `mapstructure:"FILE_STORAGE_DIR"`
+	// 添付ファイル1件あたりの最大サイズ（バイト）
+	AttachmentMaxBytes int64 `mapstructure:"ATTACHMENT_MAX_BYTES"`
+	// データのエクスポート（POST /me/export）のZIPをダウンロードできる期間（例: "168h"、期間が過ぎたファイルは削除する）
+	DataExportRetention string `mapstructure:"DATA_EXPORT_RETENTION"`
+}
+
+// Redisの接続先
+const (
+	RedisDriverServer   = "redis"
+	RedisDriverEmbedded = "embedded"
+)
+
 // Redis はRedis設定
 type Redis struct {
+	// redis または embedded（embeddedの場合はRedisに接続せず、プロセス内のストアを使う）
+	Driver   string `mapstructure:"REDIS_DRIVER"`
 	Host     string `mapstructure:"REDIS_HOST"`
 	Port     string `mapstructure:"REDIS_PORT"`
 	Password string `mapstructure:"REDIS_PASSWORD"`
 	DB       string `mapstructure:"REDIS_DB"`
 	URL      string `mapstructure:"REDIS_URL"`
+	// embeddedの場合にストアを書き出すファイル（空の場合は書き出さず、再起動で消える）
+	EmbeddedPath string `mapstructure:"REDIS_EMBEDDED_PATH"`
+	// embeddedの場合にファイルに書き出す間隔（例: "30s"）
+	EmbeddedSaveInterval string `mapstructure:"REDIS_EMBEDDED_SAVE_INTERVAL"`
 }
 
 // JWT はJWT設定
@@ -58,20 +131,57 @@ type JWT struct {
 	AccessTokenDuration  string `mapstructure:"JWT_ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration string `mapstructure:"JWT_REFRESH_TOKEN_DURATION"`
 	Issuer               string `mapstructure:"JWT_ISSUER"`
+	// 失効したアクセストークンの確認結果をプロセス内にキャッシュする件数と、失効していない結果をキャッシュする期間（例: "5s"、0でキャッシュしない）
+	// 他のインスタンスで失効させたトークンは、この期間の間は受け付けることがある
+	BlacklistCacheSize   int    `mapstructure:"TOKEN_BLACKLIST_CACHE_SIZE"`
+	BlacklistNegativeTTL string `mapstructure:"TOKEN_BLACKLIST_NEGATIVE_TTL"`
 }
 
 // CORS はCORS設定
 type CORS struct {
+	// カンマ区切り。「https://*.example.com」のようなワイルドカードも指定可能
+	// 未設定の場合、開発・テスト環境ではローカルホストを許可し、本番環境ではクロスオリジンを許可しない
 	AllowedOrigins string `mapstructure:"CORS_ALLOWED_ORIGINS"`
 	AllowedMethods string `mapstructure:"CORS_ALLOWED_METHODS"`
 	AllowedHeaders string `mapstructure:"CORS_ALLOWED_HEADERS"`
+	// プリフライトの結果をキャッシュする秒数
+	MaxAge int `mapstructure:"CORS_MAX_AGE"`
+	// ブラウザの拡張機能向けAPI（/clip）で許可する拡張機能のオリジン（カンマ区切り、例: chrome-extension://<拡張機能ID>）
+	// chrome-extension・moz-extension・safari-web-extension以外のオリジンは無視する
+	ClipAllowedOrigins string `mapstructure:"CORS_CLIP_ALLOWED_ORIGINS"`
 }
 
 // Security はセキュリティ設定
 type Security struct {
-	EnableCSRF    bool   `mapstructure:"ENABLE_CSRF"`
-	RateLimitRPS  int    `mapstructure:"RATE_LIMIT_RPS"`
+	EnableCSRF   bool `mapstructure:"ENABLE_CSRF"`
+	RateLimitRPS int  `mapstructure:"RATE_LIMIT_RPS"`
+	// 確認コードでログインした端末を記憶するCookieの署名鍵
 	SessionSecret string `mapstructure:"SESSION_SECRET"`
+	// HSTSのmax-age（秒、本番環境のみ送信。0で送信しない）
+	HSTSMaxAge            int  `mapstructure:"SECURITY_HSTS_MAX_AGE"`
+	HSTSIncludeSubdomains bool `mapstructure:"SECURITY_HSTS_INCLUDE_SUBDOMAINS"`
+	// X-Frame-Optionsの値（DENY または SAMEORIGIN）
+	FrameOptions string `mapstructure:"SECURITY_FRAME_OPTIONS"`
+	// 認証イベントの監査ログを保存する期間（例: "2160h"）
+	AuditRetention string `mapstructure:"SECURITY_AUDIT_RETENTION"`
+	// IdPに登録するSSOのコールバックURL（例: https://api.example.com/api/v1/auth/sso/callback、空の場合はSSOを利用しない）
+	SSORedirectURL string `mapstructure:"SSO_REDIRECT_URL"`
+	// SSOでのログイン後にリダイレクトするフロントエンドのURL（空の場合はトークンをJSONで返す）
+	SSOSuccessURL string `mapstructure:"SSO_SUCCESS_URL"`
+	// 認証済みのユーザー（役割・有効かどうか）をキャッシュする期間（例: "30s"、0の場合はユーザーを取得せずトークンのクレームだけを使う）
+	AuthUserCacheTTL string `mapstructure:"AUTH_USER_CACHE_TTL"`
+	// ユーザー名を変更してから次に変更できるまでの期間（例: "720h"、0の場合は制限しない）
+	UsernameChangeCooldown string `mapstructure:"USERNAME_CHANGE_COOLDOWN"`
+	// 変更前のユーザー名を他のユーザーが使えない期間（例: "2160h"）
+	UsernameHoldPeriod string `mapstructure:"USERNAME_HOLD_PERIOD"`
+	// 既定の予約語に加えて登録・変更で使えないユーザー名（カンマ区切り）
+	ReservedUsernames string `mapstructure:"RESERVED_USERNAMES"`
+	// ショートカット向けAPI（/quick）のAPIキーごとの1分あたりのリクエスト数の上限（0で制限しない）
+	QuickRateLimit int `mapstructure:"QUICK_RATE_LIMIT"`
+	// ブラウザの拡張機能向けAPI（/clip）のトークンごとの1分あたりのリクエスト数の上限（0で制限しない）
+	ClipRateLimit int `mapstructure:"CLIP_RATE_LIMIT"`
+	// グループのスケジュールカードの共有リンクの署名鍵（空の場合はSESSION_SECRETを使用する）
+	ScheduleCardSecret string `mapstructure:"SCHEDULE_CARD_SECRET"`
 }
 
 // Log はログ設定
@@ -81,12 +191,200 @@ type Log struct {
 	Output string `mapstructure:"LOG_OUTPUT"`
 }
 
+// Notification は通知設定
+type Notification struct {
+	// イベント種別ごとの通知まとめウィンドウ（例: "task_updated=5m,task_mentioned=5m"、0でまとめない）
+	GroupWindows string `mapstructure:"NOTIFICATION_GROUP_WINDOWS"`
+	GroupMaxSize int    `mapstructure:"NOTIFICATION_GROUP_MAX_SIZE"`
+	// チャネルごとの送信の試行回数と、再試行の間隔（試行ごとに2倍にする）
+	DeliveryAttempts int    `mapstructure:"NOTIFICATION_DELIVERY_ATTEMPTS"`
+	RetryBackoff     string `mapstructure:"NOTIFICATION_RETRY_BACKOFF"`
+	// 配信不能の割合（%、0で知らせない）が期間内に超えた場合に管理者へ知らせる（送信数が最小件数未満の場合は判定しない）
+	DeadLetterAlertPercent       int    `mapstructure:"NOTIFICATION_DEAD_LETTER_ALERT_PERCENT"`
+	DeadLetterAlertMinDeliveries int    `mapstructure:"NOTIFICATION_DEAD_LETTER_ALERT_MIN_DELIVERIES"`
+	DeadLetterAlertWindow        string `mapstructure:"NOTIFICATION_DEAD_LETTER_ALERT_WINDOW"`
+}
+
+// Social はソーシャル機能の設定
+type Social struct {
+	// 承認待ちの友達申請を削除するまでの期間（例: "720h"、0で削除しない）
+	FriendRequestTTL string `mapstructure:"SOCIAL_FRIEND_REQUEST_TTL"`
+	// 削除前のリマインダーを送るタイミング（削除予定のどれだけ前か）
+	FriendRequestReminder string `mapstructure:"SOCIAL_FRIEND_REQUEST_REMINDER"`
+	// クリーンアップの実行間隔
+	CleanupInterval string `mapstructure:"SOCIAL_CLEANUP_INTERVAL"`
+	// 未対応の友達申請・グループ招待のリマインダーを送るか確認する間隔（送る頻度はユーザーの通知の設定に従う）
+	DigestInterval string `mapstructure:"SOCIAL_DIGEST_INTERVAL"`
+	// リマインダーに含める申請・招待の経過時間（届いてからこの時間が過ぎたものだけを含める）
+	DigestMinPendingAge string `mapstructure:"SOCIAL_DIGEST_MIN_PENDING_AGE"`
+	// ハートビートが途絶えてからオフラインとみなすまでの時間（WebSocketのPing間隔54秒より長くする）
+	PresenceTimeout string `mapstructure:"SOCIAL_PRESENCE_TIMEOUT"`
+	// 招待URLのベースURL（フロントエンド、{base}/invite/{code} をブラウザで開く）
+	InviteWebURL string `mapstructure:"SOCIAL_INVITE_WEB_URL"`
+	// 招待をアプリで直接開くカスタムスキーム（例: "yoteiplus"、空の場合はディープリンクを生成しない）
+	InviteAppScheme string `mapstructure:"SOCIAL_INVITE_APP_SCHEME"`
+	// Universal Links・App LinksのベースURL（例: https://app.yotei-plus.com、空の場合は生成しない）
+	// ドメインに apple-app-site-association・assetlinks.json を配置しておくこと
+	InviteUniversalLinkURL string `mapstructure:"SOCIAL_INVITE_UNIVERSAL_LINK_URL"`
+}
+
 // External は外部サービス設定
 type External struct {
 	LineChannelToken  string `mapstructure:"LINE_CHANNEL_TOKEN"`
 	LineChannelSecret string `mapstructure:"LINE_CHANNEL_SECRET"`
 	WebhookURL        string `mapstructure:"WEBHOOK_URL"`
 	WebhookSecret     string `mapstructure:"WEBHOOK_SECRET"`
+	// 招待メール・不審なログインのメール送信用SMTP（SMTP_HOST未設定の場合はログ出力のみ）
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     string `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	// ログイン元の国・位置を推定するGeoIPサービスのURL（{ip}をIPアドレスに置き換える、空の場合は国と移動の判定を行わない）
+	GeoIPURL string `mapstructure:"GEOIP_URL"`
+	// タスクとIssue・Pull Requestの関連付けに使うGitHub APIのURLと、Webhookの署名を検証するシークレット（空の場合はWebhookを受け付けない）
+	GitHubAPIURL        string `mapstructure:"GITHUB_API_URL"`
+	GitHubWebhookSecret string `mapstructure:"GITHUB_WEBHOOK_SECRET"`
+	// メールからのタスク作成で受信するドメイン（ユーザーごとのアドレスは トークン@ドメイン）と、MailgunのWebhookの署名を検証するキー（いずれかが空の場合は受け付けない）
+	InboundEmailDomain       string `mapstructure:"INBOUND_EMAIL_DOMAIN"`
+	MailgunWebhookSigningKey string `mapstructure:"MAILGUN_WEBHOOK_SIGNING_KEY"`
+	// ユーザーごとに1時間にメールから作成できるタスクの数
+	InboundEmailMaxPerHour int `mapstructure:"INBOUND_EMAIL_MAX_PER_HOUR"`
+	// 外部サービス（SMTP・LINE・Discord・Teams・GitHub）の1回の呼び出しの制限時間（例: "10s"）
+	ExternalCallTimeout string `mapstructure:"EXTERNAL_CALL_TIMEOUT"`
+	// 連続して失敗した場合に呼び出しをやめるサーキットブレーカー（失敗回数と、再度試すまでの期間）
+	CircuitBreakerThreshold int    `mapstructure:"CIRCUIT_BREAKER_THRESHOLD"`
+	CircuitBreakerCooldown  string `mapstructure:"CIRCUIT_BREAKER_COOLDOWN"`
+}
+
+// API はAPIバージョニング設定
+type API struct {
+	// v1のタスクエンドポイントを非推奨にした日（YYYY-MM-DD、空の場合は非推奨ヘッダーを送信しない）
+	V1TasksDeprecatedAt string `mapstructure:"API_V1_TASKS_DEPRECATED_AT"`
+	// v1のタスクエンドポイントの廃止予定日（YYYY-MM-DD、空の場合はSunsetヘッダーを送信しない）
+	V1TasksSunset string `mapstructure:"API_V1_TASKS_SUNSET"`
+}
+
+// Features は機能フラグ設定
+type Features struct {
+	// フラグの既定値（例: "task_history=on,new_editor=25%,beta=off"、管理者が変更した値が優先される）
+	Flags string `mapstructure:"FEATURE_FLAGS"`
+	// 管理者が変更したフラグをキャッシュする期間（例: "30s"、0でキャッシュしない）
+	CacheTTL string `mapstructure:"FEATURE_FLAG_CACHE_TTL"`
+}
+
+// Quota は使用量の上限設定
+// 形式は "open_tasks=500,groups=20,attachment_bytes=1073741824,invitations_per_day=50"（省略または0のリソースは無制限）
+type Quota struct {
+	// ユーザーごとの上限
+	UserLimits string `mapstructure:"QUOTA_USER_LIMITS"`
+	// グループ（組織）ごとの上限（open_tasks・invitations_per_day）
+	GroupLimits string `mapstructure:"QUOTA_GROUP_LIMITS"`
+}
+
+// Billing は課金（Stripe）設定
+type Billing struct {
+	// Stripeのシークレットキー（空の場合は課金を利用せず、プランの上限・機能の制限も適用しない）
+	StripeSecretKey string `mapstructure:"STRIPE_SECRET_KEY"`
+	// Webhookの署名シークレット（whsec_...）
+	StripeWebhookSecret string `mapstructure:"STRIPE_WEBHOOK_SECRET"`
+	// Pro・TeamプランのStripeの価格ID（空のプランは購入できない）
+	StripePricePro  string `mapstructure:"STRIPE_PRICE_PRO"`
+	StripePriceTeam string `mapstructure:"STRIPE_PRICE_TEAM"`
+	// 支払い完了・中止後にリダイレクトするフロントエンドのURL
+	SuccessURL string `mapstructure:"BILLING_SUCCESS_URL"`
+	CancelURL  string `mapstructure:"BILLING_CANCEL_URL"`
+}
+
+// Analytics は利用状況の分析イベントの設定
+type Analytics struct {
+	// イベントの書き出し先（file・s3・bigquery、空の場合はイベントを記録しない）
+	Sink string `mapstructure:"ANALYTICS_SINK"`
+	// イベントのサンプリング率（0〜1または"10%"）と、イベントごとの上書き（例: "session_started=1,feature_used=10%"）
+	SampleRate  string `mapstructure:"ANALYTICS_SAMPLE_RATE"`
+	SampleRates string `mapstructure:"ANALYTICS_SAMPLE_RATES"`
+	// ユーザーIDの仮名化に使用する鍵（空の場合はSESSION_SECRETを使用する）
+	IDKey string `mapstructure:"ANALYTICS_ID_KEY"`
+	// 書き出し待ちのイベントを保持する件数と書き出す間隔（例: "10s"）
+	BufferSize    int    `mapstructure:"ANALYTICS_BUFFER_SIZE"`
+	FlushInterval string `mapstructure:"ANALYTICS_FLUSH_INTERVAL"`
+	// fileの場合の書き出し先のディレクトリ
+	FileDir string `mapstructure:"ANALYTICS_FILE_DIR"`
+	// s3の場合のバケット・リージョン・キーの接頭辞・S3互換ストレージのエンドポイント（認証情報はAWS_ACCESS_KEY_IDなどを使用する）
+	S3Bucket   string `mapstructure:"ANALYTICS_S3_BUCKET"`
+	S3Region   string `mapstructure:"ANALYTICS_S3_REGION"`
+	S3Prefix   string `mapstructure:"ANALYTICS_S3_PREFIX"`
+	S3Endpoint string `mapstructure:"ANALYTICS_S3_ENDPOINT"`
+	// bigqueryの場合のテーブルとサービスアカウントの鍵（JSON）のファイルパス
+	BigQueryProject     string `mapstructure:"ANALYTICS_BIGQUERY_PROJECT"`
+	BigQueryDataset     string `mapstructure:"ANALYTICS_BIGQUERY_DATASET"`
+	BigQueryTable       string `mapstructure:"ANALYTICS_BIGQUERY_TABLE"`
+	BigQueryCredentials string `mapstructure:"ANALYTICS_BIGQUERY_CREDENTIALS"`
+}
+
+// 分析イベントの書き出し先
+const (
+	AnalyticsSinkFile     = "file"
+	AnalyticsSinkS3       = "s3"
+	AnalyticsSinkBigQuery = "bigquery"
+)
+
+// Sync はオフライン同期用の変更フィードの設定
+type Sync struct {
+	// 変更と送信された変更の結果を保持する期間（例: "2160h"、0で削除しない）。これより古いカーソルでの取得は全件の再取得が必要になる
+	ChangeRetention string `mapstructure:"SYNC_CHANGE_RETENTION"`
+}
+
+// LinkPreview はタスクの説明・コメント中のリンクのプレビュー（OpenGraph）の取得設定
+type LinkPreview struct {
+	// falseの場合はリンク先を取得しない
+	Enabled bool `mapstructure:"LINK_PREVIEW_ENABLED"`
+	// 取得を許可するドメイン（カンマ区切り、サブドメインを含む。空の場合は拒否するドメイン以外を全て許可する）
+	AllowedDomains string `mapstructure:"LINK_PREVIEW_ALLOWED_DOMAINS"`
+	// 取得しないドメイン（カンマ区切り、サブドメインを含む）
+	DeniedDomains string `mapstructure:"LINK_PREVIEW_DENIED_DOMAINS"`
+	// 取得したプレビューを再取得するまでの期間（例: "24h"、0で再取得しない）
+	CacheTTL string `mapstructure:"LINK_PREVIEW_CACHE_TTL"`
+}
+
+// Calendar は期限・予定の日付の扱いの設定
+type Calendar struct {
+	// 祝日の国（"JP" は日本の国民の祝日、"NONE" は祝日を扱わない）
+	HolidayCountry string `mapstructure:"HOLIDAY_COUNTRY"`
+}
+
+// ErrorTracker はパニック・エラーの報告先（Sentry）の設定
+type ErrorTracker struct {
+	// SentryのDSN（空の場合は報告せず、ログにのみ記録する）
+	SentryDSN string `mapstructure:"SENTRY_DSN"`
+	// 報告に付けるリリース名（例: "yotei-plus@1.4.0"）
+	Release string `mapstructure:"SENTRY_RELEASE"`
+}
+
+// Workers はバックグラウンドのワーカーの設定
+type Workers struct {
+	// 起動しないワーカーの名前（カンマ区切り、例: "escalation-worker,weekly-report-worker"）
+	Disabled string `mapstructure:"DISABLED_WORKERS"`
+}
+
+// Secrets は秘密情報（DB・JWTの認証情報）の取得元の設定
+type Secrets struct {
+	// env・file・vault・aws（env以外の場合、DB_USER・DB_PASSWORD・JWT_SECRET_KEYは取得元の値が優先される）
+	Source string `mapstructure:"SECRETS_SOURCE"`
+	// 取得元から再取得する間隔（例: "5m"、0で再取得しない）。変更された認証情報は再起動せずに反映される
+	RefreshInterval string `mapstructure:"SECRETS_REFRESH_INTERVAL"`
+	// fileの場合にキー名のファイルを置くディレクトリ
+	FileDir string `mapstructure:"SECRETS_FILE_DIR"`
+	// vaultの場合のアドレス・トークン・シークレットのパス（KV v2は "secret/data/yotei-plus" のようにdataを含める）
+	VaultAddr  string `mapstructure:"VAULT_ADDR"`
+	VaultToken string `mapstructure:"VAULT_TOKEN"`
+	VaultPath  string `mapstructure:"VAULT_SECRET_PATH"`
+	// awsの場合のリージョン・シークレットID・認証情報
+	AWSRegion          string `mapstructure:"AWS_REGION"`
+	AWSSecretID        string `mapstructure:"AWS_SECRET_ID"`
+	AWSAccessKeyID     string `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	AWSSessionToken    string `mapstructure:"AWS_SESSION_TOKEN"`
 }
 
 // LoadConfig は設定を環境変数から読み込みます
@@ -98,63 +396,274 @@ func LoadConfig(path string) (*Config, error) {
 		}
 	}
 
+	// standaloneプロファイルではSQLiteを既定の保存先、プロセス内のストアを既定のRedisにし、
+	// 不要なワーカーを起動しない（環境変数で個別に上書きできる）
+	profile := getEnv("APP_PROFILE", "")
+	storageDriver := getEnv("STORAGE_DRIVER", StorageDriverMySQL)
+	redisDriver := getEnv("REDIS_DRIVER", RedisDriverServer)
+	disabledWorkers := getEnv("DISABLED_WORKERS", "")
+	if strings.ToLower(profile) == ProfileStandalone {
+		storageDriver = getEnv("STORAGE_DRIVER", StorageDriverSQLite)
+		redisDriver = getEnv("REDIS_DRIVER", RedisDriverEmbedded)
+		disabledWorkers = getEnv("DISABLED_WORKERS", standaloneDisabledWorkers)
+	}
+
 	config := &Config{
 		Environment: getEnv("ENVIRONMENT", "development"),
+		Profile:     profile,
 		Server: Server{
 			Host:           getEnv("SERVER_HOST", "0.0.0.0"),
 			Port:           getEnv("SERVER_PORT", "8080"),
 			MaxRequestSize: getEnvAsInt64("MAX_REQUEST_SIZE", 10<<20), // 10MB
 			ReadTimeout:    getEnvAsInt("READ_TIMEOUT", 30),
 			WriteTimeout:   getEnvAsInt("WRITE_TIMEOUT", 30),
+			UndoWindow:     getEnv("UNDO_WINDOW", "10s"),
+			RequestTimeout: getEnv("REQUEST_TIMEOUT", "30s"),
+			RouteTimeouts:  getEnv("ROUTE_TIMEOUTS", ""),
+
+			ShortLinkBaseURL: getEnv("SHORT_LINK_BASE_URL", ""),
 		},
 		Database: Database{
 			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
+			Port:     getEnv("DB_PORT", defaultDatabasePort(storageDriver)),
 			User:     getEnv("DB_USER", "root"),
 			Password: getEnv("DB_PASSWORD", ""),
 			Name:     getEnv("DB_NAME", "task_management"),
 			SSL:      getEnvAsBool("DB_SSL", false),
 			TimeZone: getEnv("DB_TIMEZONE", "Asia/Tokyo"),
 		},
+		Storage: Storage{
+			Driver:              storageDriver,
+			SQLitePath:          getEnv("SQLITE_PATH", "./data/yotei-plus.db"),
+			UserInfoCacheTTL:    getEnv("USER_INFO_CACHE_TTL", "30s"),
+			FileDir:             getEnv("FILE_STORAGE_DIR", "./data/files"),
+			AttachmentMaxBytes:  getEnvAsInt64("ATTACHMENT_MAX_BYTES", 10<<20), // 10MB
+			DataExportRetention: getEnv("DATA_EXPORT_RETENTION", "168h"),
+		},
 		Redis: Redis{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnv("REDIS_PORT", "6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       getEnv("REDIS_DB", "0"),
-			URL:      getEnv("REDIS_URL", ""),
+			Driver:               redisDriver,
+			Host:                 getEnv("REDIS_HOST", "localhost"),
+			Port:                 getEnv("REDIS_PORT", "6379"),
+			Password:             getEnv("REDIS_PASSWORD", ""),
+			DB:                   getEnv("REDIS_DB", "0"),
+			URL:                  getEnv("REDIS_URL", ""),
+			EmbeddedPath:         getEnv("REDIS_EMBEDDED_PATH", "./data/redis.json"),
+			EmbeddedSaveInterval: getEnv("REDIS_EMBEDDED_SAVE_INTERVAL", "30s"),
 		},
 		JWT: JWT{
 			SecretKey:            getEnv("JWT_SECRET_KEY", "your-secret-key"),
 			AccessTokenDuration:  getEnv("JWT_ACCESS_TOKEN_DURATION", "1h"),
 			RefreshTokenDuration: getEnv("JWT_REFRESH_TOKEN_DURATION", "168h"),
 			Issuer:               getEnv("JWT_ISSUER", "app"),
+			BlacklistCacheSize:   getEnvAsInt("TOKEN_BLACKLIST_CACHE_SIZE", 10000),
+			BlacklistNegativeTTL: getEnv("TOKEN_BLACKLIST_NEGATIVE_TTL", "5s"),
 		},
 		CORS: CORS{
-			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:3001"),
-			AllowedMethods: getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"),
-			AllowedHeaders: getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,X-CSRF-Token"),
+			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", ""),
+			AllowedMethods: getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+			AllowedHeaders: getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,X-CSRF-Token,X-Requested-With,X-Share-Password,X-Request-ID"),
+			MaxAge:         getEnvAsInt("CORS_MAX_AGE", 86400),
+
+			ClipAllowedOrigins: getEnv("CORS_CLIP_ALLOWED_ORIGINS", ""),
 		},
 		Security: Security{
-			EnableCSRF:    getEnvAsBool("ENABLE_CSRF", false),
-			RateLimitRPS:  getEnvAsInt("RATE_LIMIT_RPS", 100),
-			SessionSecret: getEnv("SESSION_SECRET", "session-secret"),
+			EnableCSRF:             getEnvAsBool("ENABLE_CSRF", false),
+			RateLimitRPS:           getEnvAsInt("RATE_LIMIT_RPS", 100),
+			SessionSecret:          getEnv("SESSION_SECRET", "session-secret"),
+			HSTSMaxAge:             getEnvAsInt("SECURITY_HSTS_MAX_AGE", 31536000),
+			HSTSIncludeSubdomains:  getEnvAsBool("SECURITY_HSTS_INCLUDE_SUBDOMAINS", true),
+			FrameOptions:           getEnv("SECURITY_FRAME_OPTIONS", "DENY"),
+			AuditRetention:         getEnv("SECURITY_AUDIT_RETENTION", "2160h"),
+			SSORedirectURL:         getEnv("SSO_REDIRECT_URL", ""),
+			SSOSuccessURL:          getEnv("SSO_SUCCESS_URL", ""),
+			AuthUserCacheTTL:       getEnv("AUTH_USER_CACHE_TTL", "30s"),
+			UsernameChangeCooldown: getEnv("USERNAME_CHANGE_COOLDOWN", "720h"),
+			UsernameHoldPeriod:     getEnv("USERNAME_HOLD_PERIOD", "2160h"),
+			ReservedUsernames:      getEnv("RESERVED_USERNAMES", ""),
+			QuickRateLimit:         getEnvAsInt("QUICK_RATE_LIMIT", 10),
+			ClipRateLimit:          getEnvAsInt("CLIP_RATE_LIMIT", 30),
+			ScheduleCardSecret:     getEnv("SCHEDULE_CARD_SECRET", ""),
 		},
 		Log: Log{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 			Output: getEnv("LOG_OUTPUT", "stdout"),
 		},
+		Notification: Notification{
+			GroupWindows: getEnv("NOTIFICATION_GROUP_WINDOWS", ""),
+			GroupMaxSize: getEnvAsInt("NOTIFICATION_GROUP_MAX_SIZE", 50),
+
+			DeliveryAttempts:             getEnvAsInt("NOTIFICATION_DELIVERY_ATTEMPTS", 3),
+			RetryBackoff:                 getEnv("NOTIFICATION_RETRY_BACKOFF", "500ms"),
+			DeadLetterAlertPercent:       getEnvAsInt("NOTIFICATION_DEAD_LETTER_ALERT_PERCENT", 10),
+			DeadLetterAlertMinDeliveries: getEnvAsInt("NOTIFICATION_DEAD_LETTER_ALERT_MIN_DELIVERIES", 20),
+			DeadLetterAlertWindow:        getEnv("NOTIFICATION_DEAD_LETTER_ALERT_WINDOW", "15m"),
+		},
+		Social: Social{
+			FriendRequestTTL:       getEnv("SOCIAL_FRIEND_REQUEST_TTL", "720h"),
+			FriendRequestReminder:  getEnv("SOCIAL_FRIEND_REQUEST_REMINDER", "168h"),
+			CleanupInterval:        getEnv("SOCIAL_CLEANUP_INTERVAL", "1h"),
+			DigestInterval:         getEnv("SOCIAL_DIGEST_INTERVAL", "1h"),
+			DigestMinPendingAge:    getEnv("SOCIAL_DIGEST_MIN_PENDING_AGE", "24h"),
+			PresenceTimeout:        getEnv("SOCIAL_PRESENCE_TIMEOUT", "90s"),
+			InviteWebURL:           getEnv("SOCIAL_INVITE_WEB_URL", "http://localhost:3000"),
+			InviteAppScheme:        getEnv("SOCIAL_INVITE_APP_SCHEME", ""),
+			InviteUniversalLinkURL: getEnv("SOCIAL_INVITE_UNIVERSAL_LINK_URL", ""),
+		},
 		External: External{
 			LineChannelToken:  getEnv("LINE_CHANNEL_TOKEN", ""),
 			LineChannelSecret: getEnv("LINE_CHANNEL_SECRET", ""),
 			WebhookURL:        getEnv("WEBHOOK_URL", ""),
 			WebhookSecret:     getEnv("WEBHOOK_SECRET", ""),
+			SMTPHost:          getEnv("SMTP_HOST", ""),
+			SMTPPort:          getEnv("SMTP_PORT", "587"),
+			SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+			SMTPFrom:          getEnv("SMTP_FROM", "Yotei+ <no-reply@yotei-plus.com>"),
+			GeoIPURL:          getEnv("GEOIP_URL", ""),
+
+			GitHubAPIURL:        getEnv("GITHUB_API_URL", "https://api.github.com"),
+			GitHubWebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
+
+			InboundEmailDomain:       getEnv("INBOUND_EMAIL_DOMAIN", ""),
+			MailgunWebhookSigningKey: getEnv("MAILGUN_WEBHOOK_SIGNING_KEY", ""),
+			InboundEmailMaxPerHour:   getEnvAsInt("INBOUND_EMAIL_MAX_PER_HOUR", 20),
+
+			ExternalCallTimeout:     getEnv("EXTERNAL_CALL_TIMEOUT", "10s"),
+			CircuitBreakerThreshold: getEnvAsInt("CIRCUIT_BREAKER_THRESHOLD", 5),
+			CircuitBreakerCooldown:  getEnv("CIRCUIT_BREAKER_COOLDOWN", "30s"),
+		},
+		API: API{
+			V1TasksDeprecatedAt: getEnv("API_V1_TASKS_DEPRECATED_AT", "2026-10-16"),
+			V1TasksSunset:       getEnv("API_V1_TASKS_SUNSET", "2027-04-30"),
+		},
+		Features: Features{
+			Flags:    getEnv("FEATURE_FLAGS", "task_history=on"),
+			CacheTTL: getEnv("FEATURE_FLAG_CACHE_TTL", "30s"),
+		},
+		Quota: Quota{
+			UserLimits:  getEnv("QUOTA_USER_LIMITS", ""),
+			GroupLimits: getEnv("QUOTA_GROUP_LIMITS", ""),
+		},
+		Billing: Billing{
+			StripeSecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
+			StripeWebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			StripePricePro:      getEnv("STRIPE_PRICE_PRO", ""),
+			StripePriceTeam:     getEnv("STRIPE_PRICE_TEAM", ""),
+			SuccessURL:          getEnv("BILLING_SUCCESS_URL", "http://localhost:3000/settings/billing?checkout=success"),
+			CancelURL:           getEnv("BILLING_CANCEL_URL", "http://localhost:3000/settings/billing?checkout=canceled"),
+		},
+		Analytics: Analytics{
+			Sink:                getEnv("ANALYTICS_SINK", ""),
+			SampleRate:          getEnv("ANALYTICS_SAMPLE_RATE", "1"),
+			SampleRates:         getEnv("ANALYTICS_SAMPLE_RATES", ""),
+			IDKey:               getEnv("ANALYTICS_ID_KEY", ""),
+			BufferSize:          getEnvAsInt("ANALYTICS_BUFFER_SIZE", 10000),
+			FlushInterval:       getEnv("ANALYTICS_FLUSH_INTERVAL", "10s"),
+			FileDir:             getEnv("ANALYTICS_FILE_DIR", "./data/analytics"),
+			S3Bucket:            getEnv("ANALYTICS_S3_BUCKET", ""),
+			S3Region:            getEnv("ANALYTICS_S3_REGION", getEnv("AWS_REGION", "")),
+			S3Prefix:            getEnv("ANALYTICS_S3_PREFIX", "events/"),
+			S3Endpoint:          getEnv("ANALYTICS_S3_ENDPOINT", ""),
+			BigQueryProject:     getEnv("ANALYTICS_BIGQUERY_PROJECT", ""),
+			BigQueryDataset:     getEnv("ANALYTICS_BIGQUERY_DATASET", ""),
+			BigQueryTable:       getEnv("ANALYTICS_BIGQUERY_TABLE", "events"),
+			BigQueryCredentials: getEnv("ANALYTICS_BIGQUERY_CREDENTIALS", ""),
+		},
+		Sync: Sync{
+			ChangeRetention: getEnv("SYNC_CHANGE_RETENTION", "2160h"),
+		},
+		LinkPreview: LinkPreview{
+			Enabled:        getEnvAsBool("LINK_PREVIEW_ENABLED", true),
+			AllowedDomains: getEnv("LINK_PREVIEW_ALLOWED_DOMAINS", ""),
+			DeniedDomains:  getEnv("LINK_PREVIEW_DENIED_DOMAINS", ""),
+			CacheTTL:       getEnv("LINK_PREVIEW_CACHE_TTL", "24h"),
+		},
+		Calendar: Calendar{
+			HolidayCountry: getEnv("HOLIDAY_COUNTRY", "JP"),
+		},
+		ErrorTracker: ErrorTracker{
+			SentryDSN: getEnv("SENTRY_DSN", ""),
+			Release:   getEnv("SENTRY_RELEASE", ""),
+		},
+		Workers: Workers{
+			Disabled: disabledWorkers,
+		},
+		Secrets: Secrets{
+			Source:             getEnv("SECRETS_SOURCE", SecretsSourceEnv),
+			RefreshInterval:    getEnv("SECRETS_REFRESH_INTERVAL", "5m"),
+			FileDir:            getEnv("SECRETS_FILE_DIR", "/run/secrets"),
+			VaultAddr:          getEnv("VAULT_ADDR", ""),
+			VaultToken:         getEnv("VAULT_TOKEN", ""),
+			VaultPath:          getEnv("VAULT_SECRET_PATH", ""),
+			AWSRegion:          getEnv("AWS_REGION", ""),
+			AWSSecretID:        getEnv("AWS_SECRET_ID", ""),
+			AWSAccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
+			AWSSecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
+			AWSSessionToken:    getEnv("AWS_SESSION_TOKEN", ""),
 		},
 	}
 
+	// 秘密情報の取得元から認証情報を読み込む
+	secrets, err := loadSecretStore(config.Secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load secrets: %w", err)
+	}
+	if secrets != nil {
+		config.secrets = secrets
+		if value, ok := secrets.Get(SecretDBUser); ok {
+			config.Database.User = value
+		}
+		if value, ok := secrets.Get(SecretDBPassword); ok {
+			config.Database.Password = value
+		}
+		if value, ok := secrets.Get(SecretJWTKey); ok {
+			config.JWT.SecretKey = value
+		}
+	}
+
 	return config, nil
 }
 
+// SecretStore は秘密情報の取得元を返す（環境変数の場合は nil）
+func (c *Config) SecretStore() *SecretStore {
+	return c.secrets
+}
+
+// GetSecretsRefreshInterval は秘密情報を再取得する間隔を取得します（不正な場合は5分、0で再取得しない）
+func (c *Config) GetSecretsRefreshInterval() time.Duration {
+	d, err := time.ParseDuration(c.Secrets.RefreshInterval)
+	if err != nil || d < 0 {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// UsesMemoryStorage はインメモリのリポジトリを使用するかどうかを判定します
+func (c *Config) UsesMemoryStorage() bool {
+	return strings.ToLower(c.Storage.Driver) == StorageDriverMemory
+}
+
+// BillingEnabled はStripeによる課金を利用するかどうかを判定します
+func (c *Config) BillingEnabled() bool {
+	return c.Billing.StripeSecretKey != ""
+}
+
+// DatabaseCredentials はデータベースの現在のユーザー名とパスワードを取得します
+// 秘密情報の取得元を使う場合は、ローテーションされた最新の値を返します
+func (c *Config) DatabaseCredentials() (user, password string) {
+	user, password = c.Database.User, c.Database.Password
+	if c.secrets != nil {
+		if value, ok := c.secrets.Get(SecretDBUser); ok {
+			user = value
+		}
+		if value, ok := c.secrets.Get(SecretDBPassword); ok {
+			password = value
+		}
+	}
+	return user, password
+}
+
 // GetDSN はデータベース接続文字列を取得します
 func (c *Config) GetDSN() string {
 	ssl := "false"
@@ -162,8 +671,9 @@ func (c *Config) GetDSN() string {
 		ssl = "true"
 	}
 
-	user := url.QueryEscape(c.Database.User)
-	pass := url.QueryEscape(c.Database.Password)
+	dbUser, dbPassword := c.DatabaseCredentials()
+	user := url.QueryEscape(dbUser)
+	pass := url.QueryEscape(dbPassword)
 	name := url.QueryEscape(c.Database.Name)
 	tz := url.QueryEscape(c.Database.TimeZone) // "Asia/Tokyo" → "Asia%2FTokyo"
 
@@ -177,6 +687,55 @@ func (c *Config) GetDSN() string {
 	)
 }
 
+// GetPostgresDSN はPostgreSQLの接続文字列を取得します
+// テーブルはMySQLと同じ名前の Yotei-Plus スキーマに作成するため、search_path で既定のスキーマにする
+func (c *Config) GetPostgresDSN() string {
+	sslMode := "disable"
+	if c.Database.SSL {
+		sslMode = "require"
+	}
+
+	dbUser, dbPassword := c.DatabaseCredentials()
+	params := url.Values{}
+	params.Set("sslmode", sslMode)
+	params.Set("search_path", `"`+PostgresSchema+`"`)
+	params.Set("timezone", c.Database.TimeZone)
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(dbUser, dbPassword),
+		Host:     c.Database.Host + ":" + c.Database.Port,
+		Path:     "/" + c.Database.Name,
+		RawQuery: params.Encode(),
+	}
+	return dsn.String()
+}
+
+// UsesPostgres はPostgreSQLのリポジトリを使用するかどうかを判定します
+func (c *Config) UsesPostgres() bool {
+	return strings.ToLower(c.Storage.Driver) == StorageDriverPostgres
+}
+
+// UsesSQLite はSQLiteのリポジトリを使用するかどうかを判定します
+func (c *Config) UsesSQLite() bool {
+	return strings.ToLower(c.Storage.Driver) == StorageDriverSQLite
+}
+
+// UsesEmbeddedRedis はRedisの代わりにプロセス内のストアを使用するかどうかを判定します
+func (c *Config) UsesEmbeddedRedis() bool {
+	return strings.ToLower(c.Redis.Driver) == RedisDriverEmbedded
+}
+
+// IsStandalone はstandaloneプロファイルかどうかを判定します
+func (c *Config) IsStandalone() bool {
+	return strings.ToLower(c.Profile) == ProfileStandalone
+}
+
+// GetDisabledWorkers は起動しないワーカーの名前のリストを取得します
+func (c *Config) GetDisabledWorkers() []string {
+	return splitList(c.Workers.Disabled)
+}
+
 // IsProduction は本番環境かどうかを判定します
 func (c *Config) IsProduction() bool {
 	return strings.ToLower(c.Environment) == "production" || strings.ToLower(c.Environment) == "prod"
@@ -204,26 +763,84 @@ func (c *Config) EnableCSRF() bool {
 }
 
 // GetAllowedOrigins は許可されたCORSオリジンのリストを取得します
+// 本番環境では全オリジンの許可（"*"）は無視します（Cookie認証と併用できないため）
 func (c *Config) GetAllowedOrigins() []string {
 	if c.CORS.AllowedOrigins == "" {
-		// デフォルトは開発用のローカルホスト
+		if c.IsProduction() {
+			return nil
+		}
+		// 開発・テスト環境のデフォルトはローカルホストの全ポート
 		return []string{
-			"http://localhost:3000",
-			"http://localhost:3001",
-			"http://127.0.0.1:3000",
-			"http://127.0.0.1:3001",
+			"http://localhost:*",
+			"http://127.0.0.1:*",
 		}
 	}
 
-	// カンマ区切りの文字列を配列に変換
-	origins := strings.Split(c.CORS.AllowedOrigins, ",")
-	for i, origin := range origins {
-		origins[i] = strings.TrimSpace(origin)
+	var origins []string
+	for _, origin := range splitList(c.CORS.AllowedOrigins) {
+		if origin == "*" && c.IsProduction() {
+			continue
+		}
+		origins = append(origins, origin)
 	}
 
 	return origins
 }
 
+// GetClipAllowedOrigins はブラウザの拡張機能向けAPIで許可する拡張機能のオリジンのリストを取得します
+// 拡張機能のスキーム以外のオリジン（"*" を含む）は無視します
+func (c *Config) GetClipAllowedOrigins() []string {
+	var origins []string
+	for _, origin := range splitList(c.CORS.ClipAllowedOrigins) {
+		for _, scheme := range []string{"chrome-extension://", "moz-extension://", "safari-web-extension://"} {
+			if strings.HasPrefix(origin, scheme) && len(origin) > len(scheme) {
+				origins = append(origins, origin)
+				break
+			}
+		}
+	}
+	return origins
+}
+
+// GetCORSAllowedMethods はCORSで許可するメソッドのリストを取得します
+func (c *Config) GetCORSAllowedMethods() []string {
+	return splitList(c.CORS.AllowedMethods)
+}
+
+// GetCORSAllowedHeaders はCORSで許可するリクエストヘッダーのリストを取得します
+func (c *Config) GetCORSAllowedHeaders() []string {
+	return splitList(c.CORS.AllowedHeaders)
+}
+
+// GetLinkPreviewDomains はリンクのプレビューの取得を許可・拒否するドメインのリストを取得します
+func (c *Config) GetLinkPreviewDomains() (allowed, denied []string) {
+	return splitList(c.LinkPreview.AllowedDomains), splitList(c.LinkPreview.DeniedDomains)
+}
+
+// splitList はカンマ区切りの文字列を空要素を除いた配列に変換します
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetV1TasksDeprecation はv1タスクエンドポイントの非推奨日と廃止予定日を取得します
+// 非推奨日が未設定または不正な場合はokがfalseになります（廃止予定日が不正な場合はゼロ値）
+func (c *Config) GetV1TasksDeprecation() (since, sunset time.Time, ok bool) {
+	since, err := time.Parse("2006-01-02", c.API.V1TasksDeprecatedAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if t, err := time.Parse("2006-01-02", c.API.V1TasksSunset); err == nil {
+		sunset = t
+	}
+	return since, sunset, true
+}
+
 // GetJWTAccessTokenDuration はアクセストークンの有効期限を取得します
 func (c *Config) GetJWTAccessTokenDuration() string {
 	if c.JWT.AccessTokenDuration == "" {
@@ -264,26 +881,66 @@ func (c *Config) GetServerAddress() string {
 
 // Validate は設定の妥当性をチェックします
 func (c *Config) Validate() error {
-	// 必須設定のチェック
-	if c.Database.Host == "" {
-		return fmt.Errorf("database host is required")
+	switch strings.ToLower(c.Storage.Driver) {
+	case "", StorageDriverMySQL, StorageDriverPostgres:
+		// 必須設定のチェック
+		if c.Database.Host == "" {
+			return fmt.Errorf("database host is required")
+		}
+
+		if c.Database.Name == "" {
+			return fmt.Errorf("database name is required")
+		}
+	case StorageDriverSQLite:
+		if c.Storage.SQLitePath == "" {
+			return fmt.Errorf("sqlite path is required")
+		}
+	case StorageDriverMemory:
+		// データが永続化されないため本番環境では使用できない
+		if c.IsProduction() {
+			return fmt.Errorf("memory storage cannot be used in production")
+		}
+	default:
+		return fmt.Errorf("unknown storage driver: %s", c.Storage.Driver)
 	}
 
-	if c.Database.Name == "" {
-		return fmt.Errorf("database name is required")
+	switch strings.ToLower(c.Redis.Driver) {
+	case "", RedisDriverServer, RedisDriverEmbedded:
+	default:
+		return fmt.Errorf("unknown redis driver: %s", c.Redis.Driver)
 	}
 
 	if c.JWT.SecretKey == "" {
 		return fmt.Errorf("JWT secret key is required")
 	}
 
+	// 署名を検証できないWebhookで契約が変更されないようにする
+	if c.BillingEnabled() && c.Billing.StripeWebhookSecret == "" {
+		return fmt.Errorf("stripe webhook secret is required when billing is enabled")
+	}
+
+	switch c.Analytics.Sink {
+	case "", AnalyticsSinkFile:
+	case AnalyticsSinkS3:
+		if c.Analytics.S3Bucket == "" || c.Analytics.S3Region == "" {
+			return fmt.Errorf("analytics s3 bucket and region are required")
+		}
+	case AnalyticsSinkBigQuery:
+		if c.Analytics.BigQueryProject == "" || c.Analytics.BigQueryDataset == "" || c.Analytics.BigQueryCredentials == "" {
+			return fmt.Errorf("analytics bigquery project, dataset and credentials are required")
+		}
+	default:
+		return fmt.Errorf("unknown analytics sink: %s", c.Analytics.Sink)
+	}
+
 	// 本番環境での追加チェック
 	if c.IsProduction() {
 		if c.JWT.SecretKey == "your-secret-key" {
 			return fmt.Errorf("default JWT secret key cannot be used in production")
 		}
 
-		if !c.Database.SSL {
+		// データベースサーバーに接続しない場合（SQLite・インメモリ）は対象外
+		if !c.Database.SSL && !c.UsesMemoryStorage() && !c.UsesSQLite() {
 			return fmt.Errorf("SSL must be enabled for database connection in production")
 		}
 	}
@@ -291,6 +948,14 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// defaultDatabasePort はストレージドライバーの既定のポートを返す
+func defaultDatabasePort(driver string) string {
+	if strings.ToLower(driver) == StorageDriverPostgres {
+		return "5432"
+	}
+	return "3306"
+}
+
 // getEnv は環境変数を取得し、デフォルト値を返します
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
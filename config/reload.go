@@ -0,0 +1,158 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/joho/godotenv"
+)
+
+// Tunables は再起動せずに変更できる設定
+type Tunables struct {
+	LogLevel     string
+	RateLimitRPS int
+	FeatureFlags string
+	QuotaUser    string
+	QuotaGroup   string
+}
+
+// Tunables は現在の設定のうち再起動せずに変更できるものを返します
+func (c *Config) Tunables() Tunables {
+	return Tunables{
+		LogLevel:     c.Log.Level,
+		RateLimitRPS: c.Security.RateLimitRPS,
+		FeatureFlags: c.Features.Flags,
+		QuotaUser:    c.Quota.UserLimits,
+		QuotaGroup:   c.Quota.GroupLimits,
+	}
+}
+
+// ReadTunables は設定ファイルから再起動せずに変更できる設定を読み込みます
+// ファイルにないキーは起動時の値（current）のままにします
+func ReadTunables(file string, current Tunables) (Tunables, error) {
+	values, err := godotenv.Read(file)
+	if err != nil {
+		return current, err
+	}
+
+	tunables := current
+	if value, ok := values["LOG_LEVEL"]; ok {
+		tunables.LogLevel = value
+	}
+	if value, ok := values["RATE_LIMIT_RPS"]; ok {
+		rps, err := strconv.Atoi(value)
+		if err != nil || rps < 0 {
+			return current, errors.New("RATE_LIMIT_RPS must be a non-negative integer")
+		}
+		tunables.RateLimitRPS = rps
+	}
+	if value, ok := values["FEATURE_FLAGS"]; ok {
+		tunables.FeatureFlags = value
+	}
+	if value, ok := values["QUOTA_USER_LIMITS"]; ok {
+		tunables.QuotaUser = value
+	}
+	if value, ok := values["QUOTA_GROUP_LIMITS"]; ok {
+		tunables.QuotaGroup = value
+	}
+	return tunables, nil
+}
+
+// reloadDebounce はエディタの保存などで続けて発生する変更をまとめる時間
+const reloadDebounce = 200 * time.Millisecond
+
+// Watcher は設定ファイルを監視し、再起動せずに変更できる設定が変わったときに通知します
+type Watcher struct {
+	file string
+
+	mu        sync.Mutex
+	current   Tunables
+	listeners []func(Tunables)
+}
+
+// NewWatcher は新しいWatcherを作成します（currentは起動時の設定）
+func NewWatcher(file string, current Tunables) *Watcher {
+	return &Watcher{
+		file:    file,
+		current: current,
+	}
+}
+
+// OnChange は設定が変わったときに呼ばれる関数を登録します
+func (w *Watcher) OnChange(fn func(Tunables)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, fn)
+}
+
+// Reload は設定ファイルを読み直し、変わっていれば登録された関数に通知します
+// ファイルが不正な場合は現在の設定のままにしてエラーを返します
+func (w *Watcher) Reload() (bool, error) {
+	w.mu.Lock()
+	tunables, err := ReadTunables(w.file, w.current)
+	if err != nil || tunables == w.current {
+		w.mu.Unlock()
+		return false, err
+	}
+	w.current = tunables
+	listeners := append([]func(Tunables){}, w.listeners...)
+	w.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(tunables)
+	}
+	return true, nil
+}
+
+// Run は設定ファイルを監視し、変更されるたびにReloadします（ctxが終了するまで）
+// エディタやKubernetesのConfigMapはファイルを置き換えるため、ディレクトリを監視します
+func (w *Watcher) Run(ctx context.Context, onReload func(changed bool, err error)) error {
+	if _, err := os.Stat(w.file); err != nil {
+		return err
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fsWatcher.Close()
+
+	if err := fsWatcher.Add(filepath.Dir(w.file)); err != nil {
+		return err
+	}
+
+	target := filepath.Clean(w.file)
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == target && event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			if onReload != nil {
+				onReload(false, err)
+			}
+		case <-debounce:
+			debounce = nil
+			changed, err := w.Reload()
+			if onReload != nil {
+				onReload(changed, err)
+			}
+		}
+	}
+}
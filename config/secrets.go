@@ -0,0 +1,316 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 秘密情報の取得元
+const (
+	SecretsSourceEnv   = "env"   // 環境変数（既定）
+	SecretsSourceFile  = "file"  // ディレクトリ内のキー名のファイル（Docker・Kubernetesのシークレットのマウント）
+	SecretsSourceVault = "vault" // HashiCorp VaultのKVシークレット
+	SecretsSourceAWS   = "aws"   // AWS Secrets ManagerのJSONシークレット
+)
+
+// 秘密情報のキー（取得元ではこの名前で保存する）
+const (
+	SecretDBUser     = "DB_USER"
+	SecretDBPassword = "DB_PASSWORD"
+	SecretJWTKey     = "JWT_SECRET_KEY"
+)
+
+// secretKeys は取得元から読み込む秘密情報のキー
+var secretKeys = []string{SecretDBUser, SecretDBPassword, SecretJWTKey}
+
+// SecretSource は秘密情報の取得元のインターフェース
+type SecretSource interface {
+	Name() string
+	// Fetch は管理している秘密情報を取得する（取得元にないキーは含めない）
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// NewSecretSource は設定に応じた秘密情報の取得元を作成する
+func NewSecretSource(s Secrets) (SecretSource, error) {
+	switch strings.ToLower(s.Source) {
+	case "", SecretsSourceEnv:
+		return envSecretSource{}, nil
+	case SecretsSourceFile:
+		return fileSecretSource{dir: s.FileDir}, nil
+	case SecretsSourceVault:
+		if s.VaultAddr == "" || s.VaultPath == "" {
+			return nil, errors.New("VAULT_ADDR and VAULT_SECRET_PATH are required for the vault secrets source")
+		}
+		return &vaultSecretSource{
+			addr:   strings.TrimSuffix(s.VaultAddr, "/"),
+			token:  s.VaultToken,
+			path:   strings.Trim(s.VaultPath, "/"),
+			client: &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	case SecretsSourceAWS:
+		if s.AWSRegion == "" || s.AWSSecretID == "" {
+			return nil, errors.New("AWS_REGION and AWS_SECRET_ID are required for the aws secrets source")
+		}
+		return &awsSecretSource{
+			region:       s.AWSRegion,
+			secretID:     s.AWSSecretID,
+			accessKey:    s.AWSAccessKeyID,
+			secretKey:    s.AWSSecretAccessKey,
+			sessionToken: s.AWSSessionToken,
+			endpoint:     "https://secretsmanager." + s.AWSRegion + ".amazonaws.com/",
+			client:       &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets source: %q", s.Source)
+	}
+}
+
+// envSecretSource は環境変数から秘密情報を取得する
+type envSecretSource struct{}
+
+func (envSecretSource) Name() string { return SecretsSourceEnv }
+
+func (envSecretSource) Fetch(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, key := range secretKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// fileSecretSource はディレクトリ内のキー名のファイルから秘密情報を取得する（末尾の改行は除く）
+type fileSecretSource struct {
+	dir string
+}
+
+func (fileSecretSource) Name() string { return SecretsSourceFile }
+
+func (s fileSecretSource) Fetch(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, key := range secretKeys {
+		data, err := os.ReadFile(filepath.Join(s.dir, key))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret %s: %w", key, err)
+		}
+		values[key] = strings.TrimRight(string(data), "\r\n")
+	}
+	return values, nil
+}
+
+// vaultSecretSource はVaultのKVシークレット（v1・v2）から秘密情報を取得する
+type vaultSecretSource struct {
+	addr   string
+	token  string
+	path   string // KV v2の場合は "secret/data/yotei-plus" のようにdataを含める
+	client *http.Client
+}
+
+func (*vaultSecretSource) Name() string { return SecretsSourceVault }
+
+func (s *vaultSecretSource) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.addr+"/v1/"+s.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+
+	body, err := doSecretRequest(s.client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret: %w", err)
+	}
+
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode vault secret: %w", err)
+	}
+
+	// KV v2は data.data に値を持つ
+	data := resp.Data
+	if nested, ok := resp.Data["data"]; ok {
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode vault secret: %w", err)
+		}
+	}
+	return pickSecrets(data)
+}
+
+// pickSecrets はJSONの値から管理している秘密情報を取り出す
+func pickSecrets(data map[string]json.RawMessage) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, key := range secretKeys {
+		raw, ok := data[key]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("secret %s must be a string", key)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// doSecretRequest はリクエストを送り、成功した場合はレスポンスボディを返す
+func doSecretRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// SecretStore は取得元から読み込んだ秘密情報を保持し、定期的に再取得して変更を通知する
+// 取得に失敗した場合は前回の値を使い続ける
+type SecretStore struct {
+	source SecretSource
+
+	mu        sync.RWMutex
+	values    map[string]string
+	listeners map[string][]func(value string)
+}
+
+// NewSecretStore は新しいSecretStoreを作成する
+func NewSecretStore(source SecretSource) *SecretStore {
+	return &SecretStore{
+		source:    source,
+		values:    make(map[string]string),
+		listeners: make(map[string][]func(value string)),
+	}
+}
+
+// Source は取得元の名前を返す
+func (s *SecretStore) Source() string {
+	return s.source.Name()
+}
+
+// Get は秘密情報の現在の値を返す
+func (s *SecretStore) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// OnChange は秘密情報が変更されたときに呼ばれる関数を登録する
+func (s *SecretStore) OnChange(key string, fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners[key] = append(s.listeners[key], fn)
+}
+
+// Refresh は取得元から再取得し、変更された秘密情報のキーを返す
+// 取得元から消えたキーは前回の値を使い続ける（設定ミスで認証情報が空にならないようにする）
+func (s *SecretStore) Refresh(ctx context.Context) ([]string, error) {
+	values, err := s.source.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secrets from %s: %w", s.source.Name(), err)
+	}
+
+	var changed []string
+	var notify []func()
+	s.mu.Lock()
+	for _, key := range secretKeys {
+		value, ok := values[key]
+		if !ok || s.values[key] == value {
+			continue
+		}
+		_, existed := s.values[key]
+		s.values[key] = value
+		if !existed {
+			continue
+		}
+		changed = append(changed, key)
+		for _, fn := range s.listeners[key] {
+			fn := fn
+			notify = append(notify, func() { fn(value) })
+		}
+	}
+	s.mu.Unlock()
+
+	for _, fn := range notify {
+		fn()
+	}
+	return changed, nil
+}
+
+// Run はintervalごとに再取得する（ctxが終了するまで）
+func (s *SecretStore) Run(ctx context.Context, interval time.Duration, onRefresh func(changed []string, err error)) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.Refresh(ctx)
+			if onRefresh != nil {
+				onRefresh(changed, err)
+			}
+		}
+	}
+}
+
+var (
+	sharedSecretsMu sync.Mutex
+	sharedSecrets   *SecretStore
+)
+
+// loadSecretStore はプロセスで共有するSecretStoreを返す（初回のみ取得元から読み込む）
+// LoadConfigはモジュールごとに呼ばれるため、取得元への問い合わせと変更の通知を1つにまとめる
+// 環境変数の場合は設定の値をそのまま使うため nil を返す
+func loadSecretStore(s Secrets) (*SecretStore, error) {
+	if s.Source == "" || strings.EqualFold(s.Source, SecretsSourceEnv) {
+		return nil, nil
+	}
+
+	sharedSecretsMu.Lock()
+	defer sharedSecretsMu.Unlock()
+
+	if sharedSecrets != nil {
+		return sharedSecrets, nil
+	}
+
+	source, err := NewSecretSource(s)
+	if err != nil {
+		return nil, err
+	}
+	store := NewSecretStore(source)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if _, err := store.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	sharedSecrets = store
+	return store, nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// awsSecretSource はAWS Secrets ManagerのJSONシークレット（キーごとの文字列）から秘密情報を取得する
+// SDKを使わず、GetSecretValueをSignature Version 4で署名して呼び出す
+type awsSecretSource struct {
+	region       string
+	secretID     string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	endpoint     string
+	client       *http.Client
+	now          func() time.Time
+}
+
+func (*awsSecretSource) Name() string { return SecretsSourceAWS }
+
+func (s *awsSecretSource) Fetch(ctx context.Context) (map[string]string, error) {
+	if s.accessKey == "" || s.secretKey == "" {
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for the aws secrets source")
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": s.secretID})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if err := s.sign(req, payload); err != nil {
+		return nil, err
+	}
+
+	body, err := doSecretRequest(s.client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aws secret: %w", err)
+	}
+
+	var resp struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode aws secret: %w", err)
+	}
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(resp.SecretString), &data); err != nil {
+		return nil, fmt.Errorf("aws secret must be a JSON object: %w", err)
+	}
+	return pickSecrets(data)
+}
+
+// sign はリクエストにSignature Version 4の署名を付与する
+func (s *awsSecretSource) sign(req *http.Request, payload []byte) error {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	t := now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	u, err := url.Parse(s.endpoint)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	// 署名するヘッダー（名前の順）
+	headers := [][2]string{
+		{"content-type", req.Header.Get("Content-Type")},
+		{"host", u.Host},
+		{"x-amz-date", amzDate},
+	}
+	if s.sessionToken != "" {
+		headers = append(headers, [2]string{"x-amz-security-token", s.sessionToken})
+	}
+	headers = append(headers, [2]string{"x-amz-target", req.Header.Get("X-Amz-Target")})
+
+	var canonicalHeaders, signedHeaders string
+	for i, h := range headers {
+		canonicalHeaders += h[0] + ":" + h[1] + "\n"
+		if i > 0 {
+			signedHeaders += ";"
+		}
+		signedHeaders += h[0]
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := http.MethodPost + "\n" + path + "\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + sha256Hex(payload)
+
+	scope := date + "/" + s.region + "/secretsmanager/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "secretsmanager")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
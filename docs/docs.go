@@ -4,11 +4,11 @@ package docs
 import "github.com/swaggo/swag"
 
 const docTemplate = `{
-    "schemes": {{ marshal .Schemes }},
+    "schemes": [[ marshal .Schemes ]],
     "swagger": "2.0",
     "info": {
-        "description": "{{escape .Description}}",
-        "title": "{{.Title}}",
+        "description": "[[escape .Description]]",
+        "title": "[[.Title]]",
         "termsOfService": "https://yotei-plus.example.com/terms",
         "contact": {
             "name": "Yotei+ API Support",
@@ -19,14 +19,19 @@ const docTemplate = `{
             "name": "MIT",
             "url": "https://opensource.org/licenses/MIT"
         },
-        "version": "{{.Version}}"
+        "version": "[[.Version]]"
     },
-    "host": "{{.Host}}",
-    "basePath": "{{.BasePath}}",
+    "host": "[[.Host]]",
+    "basePath": "[[.BasePath]]",
     "paths": {
-        "/auth/login": {
+        "/admin/accounts/merge": {
             "post": {
-                "description": "メールアドレスとパスワードでログインし、アクセストークンとリフレッシュトークンを取得します",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "二重に登録されたアカウントを統合します。統合元（secondary）のタスク・友達・グループのメンバー・通知・SSOの紐付けを1つのトランザクションで統合先（primary）に付け替え、統合元のアカウントを無効化します。` + "`" + `dry_run` + "`" + `の場合は何も変更せず、付け替える件数を返します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,56 +39,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "ユーザーログイン",
+                "summary": "アカウントの統合",
                 "parameters": [
                     {
-                        "description": "ログイン情報",
+                        "description": "統合するアカウント",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/LoginRequest"
+                            "$ref": "#/definitions/AdminMergeAccountsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ログイン成功",
+                        "description": "統合成功（dry_runの場合はプレビュー）",
                         "schema": {
-                            "$ref": "#/definitions/LoginResponse"
+                            "$ref": "#/definitions/AdminAccountMergeResponse"
                         }
                     },
                     "400": {
                         "description": "リクエストが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "認証情報が無効",
+                        "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/AdminErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "アカウントが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/dashboard": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "現在のセッションを終了し、トークンを無効化します",
+                "description": "ユーザー数の推移・DAU/WAU/MAU・日ごとのタスク作成数・通知の失敗率・ストレージ使用量をまとめて取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -91,55 +108,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "ユーザーログアウト",
+                "summary": "管理者ダッシュボード",
                 "parameters": [
                     {
-                        "description": "リフレッシュトークン（Cookieからも取得可能）",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/LogoutRequest"
-                        }
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "集計日数（今日を含む）",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ログアウト成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/LogoutResponse"
+                            "$ref": "#/definitions/AdminDashboardResponse"
                         }
                     },
                     "400": {
-                        "description": "リクエストが無効",
+                        "description": "パラメータが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/me": {
+        "/admin/features": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "認証済みユーザーの詳細情報を取得します",
+                "description": "環境変数の既定値と管理者が変更した値を合わせた機能フラグの一覧を取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -147,34 +171,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "features"
                 ],
-                "summary": "現在のユーザー情報取得",
+                "summary": "機能フラグ一覧",
                 "responses": {
                     "200": {
-                        "description": "ユーザー情報取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/MeResponse"
+                            "$ref": "#/definitions/FeatureFlagListResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/refresh-token": {
-            "post": {
-                "description": "リフレッシュトークンを使用して新しいアクセストークンを取得します",
+        "/admin/features/{key}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "機能フラグの有効・無効、段階的公開の割合、公開するユーザーを変更します。存在しないキーを指定した場合は新しいフラグを作成します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -182,50 +217,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "features"
                 ],
-                "summary": "アクセストークン更新",
+                "summary": "機能フラグの変更",
                 "parameters": [
                     {
-                        "description": "リフレッシュトークン（Cookieからも取得可能）",
+                        "type": "string",
+                        "description": "フラグのキー",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "変更内容",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/RefreshTokenRequest"
+                            "$ref": "#/definitions/UpdateFeatureFlagRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "トークン更新成功",
+                        "description": "変更成功",
                         "schema": {
-                            "$ref": "#/definitions/RefreshTokenResponse"
+                            "$ref": "#/definitions/FeatureFlagResponse"
                         }
                     },
                     "400": {
                         "description": "リクエストが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "トークンが無効または期限切れ",
+                        "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "新しいユーザーアカウントを作成します",
+        "/admin/notification-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "登録済みの通知テンプレート（イベント種別・言語ごと）を取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,41 +287,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "ユーザー登録",
-                "parameters": [
-                    {
-                        "description": "ユーザー登録情報",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/RegisterRequest"
-                        }
-                    }
+                    "notifications"
                 ],
+                "summary": "通知テンプレート一覧取得",
                 "responses": {
-                    "201": {
-                        "description": "ユーザー登録成功",
-                        "schema": {
-                            "$ref": "#/definitions/RegisterResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "リクエストが無効",
+                    "200": {
+                        "description": "テンプレート一覧取得成功",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ListTemplatesResponse"
                         }
                     },
-                    "409": {
-                        "description": "ユーザーが既に存在",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "内部サーバーエラー",
+                    "403": {
+                        "description": "管理者権限が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -275,14 +312,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups": {
+        "/admin/notification-templates/preview": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "新しいグループを作成します（プロジェクト管理用または予定共有用）",
+                "description": "指定した変数で通知テンプレートを描画します。変数を省略した場合はサンプル変数を使用します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -290,25 +327,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "notifications"
                 ],
-                "summary": "グループ作成",
+                "summary": "通知テンプレートプレビュー",
                 "parameters": [
                     {
-                        "description": "グループ作成情報",
+                        "description": "プレビュー内容",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateGroupRequest"
+                            "$ref": "#/definitions/PreviewTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "グループ作成成功",
+                    "200": {
+                        "description": "プレビュー成功",
                         "schema": {
-                            "$ref": "#/definitions/GroupResponse"
+                            "$ref": "#/definitions/PreviewTemplateResponse"
                         }
                     },
                     "400": {
@@ -323,8 +360,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "内部サーバーエラー",
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "テンプレートが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -332,54 +375,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/my": {
+        "/admin/notifications/dead-letter": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "自分が所属しているグループの一覧を取得します（ページング対応）",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "再試行しても送信できなかった通知（チャネルごと）を失敗の理由とともに新しい順に取得します。再送済みのものは含みません（管理者のみ）",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "notifications"
                 ],
-                "summary": "自分のグループ一覧取得",
+                "summary": "配信不能の通知一覧取得",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "グループタイプでフィルタ",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
+                        "maximum": 100,
                         "minimum": 1,
                         "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
+                        "default": 20,
+                        "description": "取得数の上限",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
-                        "maximum": 100,
-                        "minimum": 1,
+                        "minimum": 0,
                         "type": "integer",
-                        "default": 10,
-                        "description": "ページサイズ",
-                        "name": "page_size",
+                        "default": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "グループ一覧取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/GroupListResponse"
+                            "$ref": "#/definitions/ListDeadLettersResponse"
                         }
                     },
                     "401": {
@@ -388,6 +422,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -397,71 +437,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/search": {
-            "get": {
+        "/admin/notifications/dead-letter/{id}/redrive": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "キーワードでグループを検索します（ページング対応）",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "配信不能の通知を記録したチャネルに再送します。再送に失敗した場合は失敗の理由を更新して一覧に残します（管理者のみ）",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "notifications"
                 ],
-                "summary": "グループ検索",
+                "summary": "配信不能の通知の再送",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "検索クエリ",
-                        "name": "q",
-                        "in": "query",
+                        "description": "配信不能の記録ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "グループタイプでフィルタ",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "グループ検索成功",
+                        "description": "再送成功",
                         "schema": {
-                            "$ref": "#/definitions/GroupListResponse"
+                            "$ref": "#/definitions/DeadLetterResponse"
                         }
                     },
-                    "400": {
-                        "description": "検索クエリが必要",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "認証が必要",
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記録が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "再送済み",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -471,77 +497,82 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "再送しても送信できなかった",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/groups/{groupId}": {
+        "/admin/short-links/{slug}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDのグループの詳細情報とメンバー一覧を取得します",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
+                "description": "短縮リンクの転送先・発行元・期限とクリック数を取得します。クリックとしては数えません（管理者のみ）",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "short-links"
                 ],
-                "summary": "グループ詳細取得",
+                "summary": "短縮リンクの取得",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
+                        "description": "スラッグ",
+                        "name": "slug",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "グループ詳細取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/GroupWithMembersResponse"
+                            "$ref": "#/definitions/ShortLinkResponse"
                         }
                     },
-                    "400": {
-                        "description": "グループIDが無効",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ShortLinkErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "認証が必要",
+                    "403": {
+                        "description": "管理者権限が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ShortLinkErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "グループが見つからない",
+                        "description": "短縮リンクが見つからない",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ShortLinkErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/ShortLinkErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/stats/active-users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDのグループ情報を更新します（管理者のみ）",
+                "description": "直近24時間・7日間・30日間にログインしたユーザー数（DAU/WAU/MAU）を取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -549,73 +580,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
-                ],
-                "summary": "グループ更新",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "グループ更新情報",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateGroupRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "アクティブユーザー数",
                 "responses": {
                     "200": {
-                        "description": "グループ更新成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/GroupResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "リクエストが無効",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminActiveUsersResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "権限不足",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "グループが見つからない",
+                        "description": "管理者権限が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/stats/notifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDのグループを削除します（オーナーのみ）",
+                "description": "期間中に作成された通知の配信済み・失敗・未送信の件数と失敗率を取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -623,66 +626,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "admin"
                 ],
-                "summary": "グループ削除",
+                "summary": "通知の配信状況",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
-                        "in": "path",
-                        "required": true
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "集計日数（今日を含む）",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "グループ削除成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/AdminNotificationDeliveryResponse"
                         }
                     },
                     "400": {
-                        "description": "グループIDが無効",
+                        "description": "パラメータが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "権限不足（オーナーのみ削除可能）",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "グループが見つからない",
+                        "description": "管理者権限が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupId}/members": {
+        "/admin/stats/storage": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたグループのメンバー一覧を取得します（ページング対応）",
+                "description": "テーブルごとのデータベース使用量と添付ファイルの合計サイズを取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -690,81 +689,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
-                ],
-                "summary": "メンバー一覧取得",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "ストレージ使用量",
                 "responses": {
                     "200": {
-                        "description": "メンバー一覧取得成功",
-                        "schema": {
-                            "$ref": "#/definitions/MemberListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "グループIDが無効",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminStorageUsageResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "グループへのアクセス権限なし",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "グループが見つからない",
+                        "description": "管理者権限が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/stats/tasks": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたグループにメンバーを追加します（管理者のみ）",
+                "description": "日ごとのタスク作成数と期間中の合計を取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -772,81 +735,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "admin"
                 ],
-                "summary": "メンバー追加",
+                "summary": "タスク作成数",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "メンバー追加情報",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/AddMemberRequest"
-                        }
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "集計日数（今日を含む）",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "メンバー追加成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/AdminTaskCreationResponse"
                         }
                     },
                     "400": {
-                        "description": "リクエストが無効",
+                        "description": "パラメータが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "権限不足",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "グループまたはユーザーが見つからない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "既にメンバー",
+                        "description": "管理者権限が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupId}/members/{userId}": {
-            "delete": {
+        "/admin/stats/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたグループからメンバーを削除します（管理者のみ、または自分自身の脱退）",
+                "description": "ユーザー数と日ごとの新規登録数を取得します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -854,73 +798,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "admin"
                 ],
-                "summary": "メンバー削除",
+                "summary": "ユーザー数の推移",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "削除するユーザーID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "集計日数（今日を含む）",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "メンバー削除成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/AdminUserGrowthResponse"
                         }
                     },
                     "400": {
-                        "description": "IDが無効",
+                        "description": "パラメータが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "権限不足",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "グループまたはユーザーが見つからない",
+                        "description": "管理者権限が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AdminErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupId}/members/{userId}/role": {
-            "put": {
+        "/analytics/preference": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたメンバーの権限を変更します（管理者のみ）",
+                "description": "ログイン中のユーザーの利用状況の記録（製品改善のための分析）の設定を取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -928,112 +861,115 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "analytics"
                 ],
-                "summary": "メンバー権限変更",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "権限変更するユーザーID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                "summary": "分析の設定",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/AnalyticsPreferenceResponse"
+                        }
                     },
-                    {
-                        "description": "権限変更情報",
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/AnalyticsErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/AnalyticsErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "利用状況の記録をオプトアウト（opted_out=true）またはオプトインします。オプトアウト後のイベントは記録されません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "分析の設定の更新",
+                "parameters": [
+                    {
+                        "description": "設定",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateMemberRoleRequest"
+                            "$ref": "#/definitions/AnalyticsPreferenceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "権限変更成功",
+                        "description": "更新成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/AnalyticsPreferenceResponse"
                         }
                     },
                     "400": {
                         "description": "リクエストが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AnalyticsErrorResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "権限不足",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "グループまたはユーザーが見つからない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AnalyticsErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/AnalyticsErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/groups/{groupId}/stats": {
+        "/attachments/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたグループの統計情報（メンバー数、タスク数など）を取得します",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "添付ファイルの本体をダウンロードします",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "groups"
+                    "tasks"
                 ],
-                "summary": "グループ統計取得",
+                "summary": "添付ファイルのダウンロード",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "グループID",
-                        "name": "groupId",
+                        "description": "添付ファイルID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "統計情報取得成功",
-                        "schema": {
-                            "$ref": "#/definitions/GroupStatsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "グループIDが無効",
+                        "description": "添付ファイル",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "type": "file"
                         }
                     },
                     "401": {
@@ -1043,13 +979,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "グループへのアクセス権限なし",
+                        "description": "権限がない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "グループが見つからない",
+                        "description": "添付ファイルが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1061,16 +997,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notifications": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "新しい通知を作成します",
+                "description": "添付ファイルとそのサムネイルを削除します（アップロードしたユーザーとタスクの作成者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -1078,35 +1012,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "tasks"
                 ],
-                "summary": "通知作成",
+                "summary": "添付ファイルの削除",
                 "parameters": [
                     {
-                        "description": "通知作成情報",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/CreateNotificationRequest"
-                        }
+                        "type": "string",
+                        "description": "添付ファイルID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "通知作成成功",
+                    "200": {
+                        "description": "削除成功",
                         "schema": {
-                            "$ref": "#/definitions/CreateNotificationResponse"
+                            "$ref": "#/definitions/TaskDeleteResponse"
                         }
                     },
-                    "400": {
-                        "description": "リクエストが無効",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "認証が必要",
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "添付ファイルが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1120,59 +1058,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/user/{user_id}": {
+        "/attachments/{id}/thumbnail": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーの通知一覧を取得します（ページング対応）",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "画像の添付ファイルのサムネイルを取得します（small: 128px・medium: 320px・large: 640px、縦横比を保ち、元の画像より大きくはしません）。サムネイルは変更されないため、ETag とブラウザのキャッシュ（Cache-Control）を利用できます。生成待ちの場合は 202 と Retry-After を返します",
                 "produces": [
-                    "application/json"
+                    "image/png",
+                    "image/jpeg"
                 ],
                 "tags": [
-                    "notifications"
+                    "tasks"
                 ],
-                "summary": "ユーザーの通知一覧取得",
+                "summary": "添付ファイルのサムネイル",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ユーザーID",
-                        "name": "user_id",
+                        "description": "添付ファイルID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "取得数の上限",
-                        "name": "limit",
+                        "enum": [
+                            "small",
+                            "medium",
+                            "large"
+                        ],
+                        "type": "string",
+                        "description": "サムネイルの大きさ（省略時は medium）",
+                        "name": "size",
                         "in": "query"
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "default": 0,
-                        "description": "取得開始位置",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "前回取得したサムネイルのETag",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "通知一覧取得成功",
+                        "description": "サムネイル",
                         "schema": {
-                            "$ref": "#/definitions/GetUserNotificationsResponse"
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "サムネイルの生成待ち",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "304": {
+                        "description": "変更なし"
+                    },
                     "400": {
-                        "description": "ユーザーIDが必要",
+                        "description": "大きさが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1183,6 +1128,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "添付ファイルが見つからない、または画像ではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -1192,14 +1149,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/user/{user_id}/read-all": {
-            "put": {
+        "/auth/admin/api-keys": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーの全ての未読通知を既読としてマークします",
+                "description": "無効化したものを含むAPIキーを新しい順に取得します。キーは先頭部分（display_prefix）のみ返します",
                 "consumes": [
                     "application/json"
                 ],
@@ -1207,29 +1164,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
-                ],
-                "summary": "全通知既読マーク",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ユーザーID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "APIキー一覧（管理者）",
                 "responses": {
                     "200": {
-                        "description": "全既読マーク成功",
-                        "schema": {
-                            "$ref": "#/definitions/MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "ユーザーIDが必要",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/APIKeysResponse"
                         }
                     },
                     "401": {
@@ -1239,7 +1181,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "権限がない",
+                        "description": "管理者権限が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1251,16 +1193,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notifications/user/{user_id}/unread/count": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーの未読通知数を取得します",
+                "description": "外部システム向けのAPIキーを発行します。キーはレスポンスでのみ返し、再表示できません。scimスコープのキーはSCIM 2.0のプロビジョニングAPI（/scim/v2）に使用し、SCIMで作成したグループは発行した管理者がオーナーになります",
                 "consumes": [
                     "application/json"
                 ],
@@ -1268,27 +1208,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "auth"
                 ],
-                "summary": "未読通知数取得",
+                "summary": "APIキーの発行（管理者）",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ユーザーID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "APIキーの名前とスコープ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateAPIKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "未読通知数取得成功",
+                    "201": {
+                        "description": "発行成功",
                         "schema": {
-                            "$ref": "#/definitions/UnreadCountResponse"
+                            "$ref": "#/definitions/CreateAPIKeyResponse"
                         }
                     },
                     "400": {
-                        "description": "ユーザーIDが必要",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1299,6 +1241,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -1308,9 +1256,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/webhook": {
-            "post": {
-                "description": "外部サービスからのWebhookリクエストを処理します",
+        "/auth/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "APIキーを無効化します。無効化したキーでのリクエストは401になります",
                 "consumes": [
                     "application/json"
                 ],
@@ -1318,29 +1271,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "auth"
                 ],
-                "summary": "Webhook処理",
+                "summary": "APIキーの無効化（管理者）",
                 "parameters": [
                     {
-                        "description": "Webhookペイロード",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "APIキーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Webhook受信成功",
+                    "204": {
+                        "description": "無効化成功"
+                    },
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/MessageResponse"
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "リクエストが無効",
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "APIキーが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1354,14 +1314,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/{id}": {
+        "/auth/admin/security-events": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDの通知を取得します",
+                "description": "全ユーザーのセキュリティイベントを新しい順に取得します。ユーザーを特定できない失敗（存在しないメールアドレスでのログインなど）も含みます",
                 "consumes": [
                     "application/json"
                 ],
@@ -1369,27 +1329,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "auth"
                 ],
-                "summary": "通知取得",
+                "summary": "セキュリティイベント一覧（管理者用）",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "通知ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "login_succeeded",
+                            "login_failed",
+                            "token_refreshed",
+                            "token_refresh_failed",
+                            "password_changed",
+                            "permission_denied",
+                            "suspicious_login",
+                            "account_deactivated",
+                            "account_reactivated",
+                            "username_changed"
+                        ],
+                        "type": "string",
+                        "description": "イベントの種類",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "この日時より前のイベントを取得（RFC3339、ページング用）",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "取得件数（既定50、最大200）",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "通知取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/GetNotificationResponse"
+                            "$ref": "#/definitions/SecurityEventsResponse"
                         }
                     },
                     "400": {
-                        "description": "通知IDが必要",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1400,8 +1389,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "通知が見つからない",
+                    "403": {
+                        "description": "管理者権限が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1415,14 +1404,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/{id}/read": {
-            "put": {
+        "/auth/admin/sso-connections": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定された通知を既読としてマークします",
+                "description": "SSOの接続設定を名前順に取得します。client_secretは返しません",
                 "consumes": [
                     "application/json"
                 ],
@@ -1430,29 +1419,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
-                ],
-                "summary": "通知既読マーク",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "通知ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "SSO接続設定一覧（管理者）",
                 "responses": {
                     "200": {
-                        "description": "既読マーク成功",
-                        "schema": {
-                            "$ref": "#/definitions/MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "通知IDが必要",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/SSOConnectionsResponse"
                         }
                     },
                     "401": {
@@ -1461,8 +1435,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "通知が見つからない",
+                    "403": {
+                        "description": "管理者権限が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1474,16 +1448,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notifications/{id}/send": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定された通知を即座に送信します",
+                "description": "企業テナントのOIDCの接続設定を作成します。IdPにはSSO_REDIRECT_URLをコールバックURLとして登録してください。enforcedを有効にすると対象ドメインのユーザー（管理者を除く）はパスワードでログインできなくなり（403 SSO_REQUIRED）、jit_provisioningを有効にすると初回ログイン時にユーザーを作成します",
                 "consumes": [
                     "application/json"
                 ],
@@ -1491,27 +1463,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "auth"
                 ],
-                "summary": "通知送信",
+                "summary": "SSO接続設定の作成（管理者）",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "通知ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "接続設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SSOConnectionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "通知送信成功",
+                    "201": {
+                        "description": "作成成功",
                         "schema": {
-                            "$ref": "#/definitions/MessageResponse"
+                            "$ref": "#/definitions/SSOConnectionResponse"
                         }
                     },
                     "400": {
-                        "description": "通知IDが必要",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1522,8 +1496,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "通知が見つからない",
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "ドメインが他の接続設定に使われている",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1537,14 +1517,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/friends": {
-            "get": {
+        "/auth/admin/sso-connections/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "自分の友達一覧を取得します（ページング対応）",
+                "description": "SSOの接続設定を更新します。client_secretを省略した場合は変更しません",
                 "consumes": [
                     "application/json"
                 ],
@@ -1552,33 +1532,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "友達一覧取得",
+                "summary": "SSO接続設定の更新（管理者）",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "接続設定ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "接続設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SSOConnectionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "友達一覧取得成功",
+                        "description": "更新成功",
                         "schema": {
-                            "$ref": "#/definitions/FriendsListResponse"
+                            "$ref": "#/definitions/SSOConnectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -1587,6 +1572,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "管理者権限が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "接続設定が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "ドメインが他の接続設定に使われている",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -1594,16 +1597,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/social/friends/requests": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーに友達申請を送信します",
+                "description": "SSOの接続設定とIdPのユーザーの紐付けを削除します。対象ドメインのユーザーは再びパスワードでログインできます",
                 "consumes": [
                     "application/json"
                 ],
@@ -1611,32 +1612,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "友達申請送信",
+                "summary": "SSO接続設定の削除（管理者）",
                 "parameters": [
                     {
-                        "description": "友達申請情報",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/SendFriendRequestRequest"
-                        }
+                        "type": "string",
+                        "description": "接続設定ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "友達申請送信成功",
-                        "schema": {
-                            "$ref": "#/definitions/FriendshipResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "リクエストが無効（自分自身への申請など）",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    "204": {
+                        "description": "削除成功"
                     },
                     "401": {
                         "description": "認証が必要",
@@ -1644,14 +1634,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "ユーザーが見つからない",
+                    "403": {
+                        "description": "管理者権限が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "既に友達または申請済み",
+                    "404": {
+                        "description": "接続設定が見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1665,14 +1655,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/friends/requests/received": {
+        "/auth/devices": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "自分宛の友達申請一覧を取得します（ページング対応）",
+                "description": "自分のアカウントにログインした端末を最後にログインした順に取得します。記憶した端末はtrusted_untilに期限を返します。確認コードの入力待ちの端末も含みます（last_seen_atがゼロ値）",
                 "consumes": [
                     "application/json"
                 ],
@@ -1680,33 +1670,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
-                ],
-                "summary": "受信した友達申請取得",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "ログイン端末一覧",
                 "responses": {
                     "200": {
-                        "description": "友達申請一覧取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/dto.PendingRequestsResponse"
+                            "$ref": "#/definitions/LoginDevicesResponse"
                         }
                     },
                     "401": {
@@ -1724,14 +1695,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/friends/requests/sent": {
-            "get": {
+        "/auth/devices/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "自分が送信した友達申請一覧を取得します（ページング対応）",
+                "description": "端末を信頼済みにする、または信頼を取り消します。信頼済みの端末からの不審なログインには確認コードを求めません。falseを指定すると、確認コードでのログイン時に記憶した端末（device_trust Cookie）も取り消します",
                 "consumes": [
                     "application/json"
                 ],
@@ -1739,33 +1710,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "送信した友達申請取得",
+                "summary": "ログイン端末の信頼設定",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "端末ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "信頼設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateDeviceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "送信済み申請一覧取得成功",
+                        "description": "更新成功",
                         "schema": {
-                            "$ref": "#/definitions/dto.SentRequestsResponse"
+                            "$ref": "#/definitions/LoginDeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -1774,6 +1750,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "端末が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -1781,16 +1763,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/social/friends/requests/{friendshipId}/accept": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "受信した友達申請を承認します",
+                "description": "端末を一覧から削除します（記憶した端末のCookieも無効になります）。次にその端末からログインした場合は初めての端末として扱います",
                 "consumes": [
                     "application/json"
                 ],
@@ -1798,30 +1778,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "友達申請承認",
+                "summary": "ログイン端末の削除",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "友達申請ID",
-                        "name": "friendshipId",
+                        "description": "端末ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "友達申請承認成功",
-                        "schema": {
-                            "$ref": "#/definitions/FriendshipResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "友達申請IDが無効",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    "204": {
+                        "description": "削除成功"
                     },
                     "401": {
                         "description": "認証が必要",
@@ -1829,14 +1800,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "この申請を承認する権限がない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "友達申請が見つからない",
+                        "description": "端末が見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -1850,14 +1815,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/friends/requests/{friendshipId}/decline": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "受信した友達申請を拒否します",
+        "/auth/login": {
+            "post": {
+                "description": "メールアドレスとパスワードでログインし、アクセストークンとリフレッシュトークンを取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -1865,47 +1825,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "友達申請拒否",
+                "summary": "ユーザーログイン",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "友達申請ID",
-                        "name": "friendshipId",
-                        "in": "path",
-                        "required": true
+                        "description": "ログイン情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "友達申請拒否成功",
+                        "description": "ログイン成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "友達申請IDが無効",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "認証が必要",
+                        "description": "認証情報が無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "この申請を拒否する権限がない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "友達申請が見つからない",
+                        "description": "不審なログインのため確認コードの入力が必要（メールで送信済み）、またはSSOでのログインが必要（SSO_REQUIRED）",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/LoginVerificationRequiredResponse"
                         }
                     },
                     "500": {
@@ -1917,14 +1873,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/friends/{userId}": {
-            "delete": {
+        "/auth/login-alerts": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "友達関係を解除します",
+                "description": "初めての端末・国からのログインや不可能な移動を検知した場合の通知設定を取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -1932,29 +1888,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
-                ],
-                "summary": "友達削除",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "削除する友達のユーザーID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "不審なログインの通知設定",
                 "responses": {
                     "200": {
-                        "description": "友達削除成功",
-                        "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "ユーザーIDが無効",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/LoginAlertSettingsResponse"
                         }
                     },
                     "401": {
@@ -1963,12 +1904,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "友達関係が見つからない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -1976,16 +1911,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/social/friends/{userId}/mutual": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーとの共通の友達を取得します",
+                "description": "通知の有無と、信頼済みでない端末からの不審なログインに確認コードを求めるかを変更します",
                 "consumes": [
                     "application/json"
                 ],
@@ -1993,35 +1926,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "共通の友達取得",
+                "summary": "不審なログインの通知設定の更新",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "対象ユーザーID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                        "description": "通知設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateLoginAlertSettingsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "共通の友達取得成功",
+                        "description": "更新成功",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "array",
-                                    "items": {
-                                        "$ref": "#/definitions/FriendWithUserInfoResponse"
-                                    }
-                                }
-                            }
+                            "$ref": "#/definitions/LoginAlertSettingsResponse"
                         }
                     },
                     "400": {
-                        "description": "ユーザーIDが無効",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2032,12 +1959,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "ユーザーが見つからない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -2047,14 +1968,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations": {
+        "/auth/login/verify": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "友達招待またはグループ招待を作成します",
+                "description": "ログインが VERIFICATION_REQUIRED で拒否された場合に、メールで届いた確認コードを入力してログインを完了します。remember_device を指定すると端末を30日間記憶し（device_trust Cookie）、期間中は確認コードを求めません",
                 "consumes": [
                     "application/json"
                 ],
@@ -2062,25 +1978,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待作成",
+                "summary": "不審なログインの確認",
                 "parameters": [
                     {
-                        "description": "招待作成情報",
+                        "description": "確認コード",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateInvitationRequest"
+                            "$ref": "#/definitions/VerifyLoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "招待作成成功",
+                    "200": {
+                        "description": "ログイン成功",
                         "schema": {
-                            "$ref": "#/definitions/InvitationResponse"
+                            "$ref": "#/definitions/LoginResponse"
                         }
                     },
                     "400": {
@@ -2090,7 +2006,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "認証が必要",
+                        "description": "確認コードが無効・期限切れ、または入力回数の上限を超えた",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2104,9 +2020,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/code/{code}": {
-            "get": {
-                "description": "招待コードを使用して招待情報を取得します（パブリック情報のみ）",
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在のセッションを終了し、トークンを無効化します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2114,67 +2035,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待コードから招待取得",
+                "summary": "ユーザーログアウト",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "招待コード",
-                        "name": "code",
-                        "in": "path",
-                        "required": true
+                        "description": "リフレッシュトークン（Cookieからも取得可能）",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/LogoutRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "招待情報取得成功",
+                        "description": "ログアウト成功",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "created_at": {
-                                            "type": "string"
-                                        },
-                                        "expires_at": {
-                                            "type": "string"
-                                        },
-                                        "id": {
-                                            "type": "string"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        },
-                                        "method": {
-                                            "type": "string"
-                                        },
-                                        "status": {
-                                            "type": "string"
-                                        },
-                                        "type": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            }
+                            "$ref": "#/definitions/LogoutResponse"
                         }
                     },
                     "400": {
-                        "description": "招待コードが必要",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "有効な招待が見つからない",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "410": {
-                        "description": "招待の有効期限が切れている",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2188,14 +2076,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/received": {
+        "/auth/me": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "自分が受信した招待一覧を取得します（ページング対応）",
+                "description": "認証済みユーザーの詳細情報を取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2203,33 +2091,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
-                ],
-                "summary": "受信した招待一覧取得",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "現在のユーザー情報取得",
                 "responses": {
                     "200": {
-                        "description": "受信済み招待一覧取得成功",
+                        "description": "ユーザー情報取得成功",
                         "schema": {
-                            "$ref": "#/definitions/dto.InvitationsListResponse"
+                            "$ref": "#/definitions/MeResponse"
                         }
                     },
                     "401": {
@@ -2247,14 +2116,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/sent": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "自分が送信した招待一覧を取得します（ページング対応）",
+        "/auth/refresh-token": {
+            "post": {
+                "description": "リフレッシュトークンを使用して新しいアクセストークンを取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2262,37 +2126,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "送信した招待一覧取得",
+                "summary": "アクセストークン更新",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "リフレッシュトークン（Cookieからも取得可能）",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "送信済み招待一覧取得成功",
+                        "description": "トークン更新成功",
                         "schema": {
-                            "$ref": "#/definitions/dto.InvitationsListResponse"
+                            "$ref": "#/definitions/RefreshTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "認証が必要",
+                        "description": "トークンが無効または期限切れ",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2306,14 +2167,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/{code}/accept": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "招待コードを使用して招待を受諾します",
+                "description": "新しいユーザーアカウントを作成します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2321,45 +2177,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待受諾",
+                "summary": "ユーザー登録",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "招待コード",
-                        "name": "code",
-                        "in": "path",
-                        "required": true
+                        "description": "ユーザー登録情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "招待受諾成功",
+                    "201": {
+                        "description": "ユーザー登録成功",
                         "schema": {
-                            "$ref": "#/definitions/InvitationResultResponse"
+                            "$ref": "#/definitions/RegisterResponse"
                         }
                     },
                     "400": {
-                        "description": "招待コードが必要",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "認証が必要",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "有効な招待が見つからない",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "410": {
-                        "description": "招待の有効期限が切れている",
+                    "409": {
+                        "description": "ユーザーが既に存在",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2373,14 +2219,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/{invitationId}": {
+        "/auth/security-events": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "招待の詳細情報を取得します（権限チェック付き）",
+                "description": "ログインの成功・失敗、不審なログイン、トークンの更新、パスワードの変更、権限エラーなど、自分のアカウントのセキュリティイベントを新しい順に取得します（保存期間内のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -2388,27 +2234,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待詳細取得",
+                "summary": "自分のセキュリティイベント一覧",
                 "parameters": [
+                    {
+                        "enum": [
+                            "login_succeeded",
+                            "login_failed",
+                            "token_refreshed",
+                            "token_refresh_failed",
+                            "password_changed",
+                            "permission_denied",
+                            "suspicious_login",
+                            "account_deactivated",
+                            "account_reactivated",
+                            "username_changed"
+                        ],
+                        "type": "string",
+                        "description": "イベントの種類",
+                        "name": "type",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "招待ID",
-                        "name": "invitationId",
-                        "in": "path",
-                        "required": true
+                        "description": "この日時より前のイベントを取得（RFC3339、ページング用）",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "取得件数（既定50、最大200）",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "招待詳細取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/InvitationResponse"
+                            "$ref": "#/definitions/SecurityEventsResponse"
                         }
                     },
                     "400": {
-                        "description": "招待IDが無効",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2419,14 +2288,42 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "この招待を閲覧する権限がない",
+                    "500": {
+                        "description": "内部サーバーエラー",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/shortcut-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーが発行したショートカット用のAPIキー・ブラウザの拡張機能用のトークンを、無効化したものを含めて新しい順に取得します。キーは先頭部分（display_prefix）のみ返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "ショートカット用のAPIキー一覧",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/APIKeysResponse"
+                        }
                     },
-                    "404": {
-                        "description": "招待が見つからない",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2439,13 +2336,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "送信した招待をキャンセルします",
+                "description": "Siriのショートカット・Googleアシスタントなどから使うquickスコープのAPIキーを発行します。キーはレスポンスでのみ返し、再表示できません。キーは /quick のエンドポイントに「Authorization: Bearer \u003ckey\u003e」で指定し、発行したユーザーとして操作します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2453,27 +2350,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待キャンセル",
+                "summary": "ショートカット用のAPIキーの発行",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "招待ID",
-                        "name": "invitationId",
-                        "in": "path",
-                        "required": true
+                        "description": "APIキーの名前",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateShortcutKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "招待キャンセル成功",
+                    "201": {
+                        "description": "発行成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/CreateAPIKeyResponse"
                         }
                     },
                     "400": {
-                        "description": "招待IDが無効",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2484,18 +2383,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "この招待をキャンセルする権限がない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "招待が見つからない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -2505,14 +2392,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/{invitationId}/decline": {
-            "put": {
+        "/auth/shortcut-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "受信した招待を拒否します",
+                "description": "ログイン中のユーザーが発行したショートカット用のAPIキー・ブラウザの拡張機能用のトークンを無効化します。無効化したキーでのリクエストは401になります",
                 "consumes": [
                     "application/json"
                 ],
@@ -2520,30 +2407,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待拒否",
+                "summary": "ショートカット用のAPIキーの無効化",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "招待ID",
-                        "name": "invitationId",
+                        "description": "APIキーID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "招待拒否成功",
-                        "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "招待IDが無効",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    "204": {
+                        "description": "無効化成功"
                     },
                     "401": {
                         "description": "認証が必要",
@@ -2551,14 +2429,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "この招待を拒否する権限がない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "招待が見つからない",
+                        "description": "APIキーが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2572,66 +2444,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/invitations/{invitationId}/url": {
+        "/auth/sso/callback": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "指定された招待のURLを生成します",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "IdPからの認可コードを検証してログインを完了します。ユーザーはIdPのユーザーと紐付け済みのユーザー、同じメールアドレスのユーザー、JITで作成したユーザーの順に対応付け、role_claimを設定した場合は役割を同期します。SSO_SUCCESS_URLを設定した場合はトークンをCookieに設定してリダイレクトし、未設定の場合はログインと同じJSONを返します",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "招待URL生成",
+                "summary": "SSOのコールバック",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "招待ID",
-                        "name": "invitationId",
-                        "in": "path",
+                        "description": "認可コード",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "招待URL生成成功",
+                        "description": "ログイン成功",
                         "schema": {
-                            "$ref": "#/definitions/InviteURLResponse"
+                            "$ref": "#/definitions/LoginResponse"
                         }
                     },
-                    "400": {
-                        "description": "招待IDが無効",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    "302": {
+                        "description": "ログイン後の画面にリダイレクト"
                     },
                     "401": {
-                        "description": "認証が必要",
+                        "description": "ログイン状態が無効、またはIdPのユーザーを対応付けられない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "この招待のURLを生成する権限がない",
+                        "description": "アカウントが無効化されている",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "招待が見つからない",
+                    "502": {
+                        "description": "IdPとの通信に失敗",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "内部サーバーエラー",
+                    "503": {
+                        "description": "SSOが設定されていない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2639,14 +2507,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/relationships/{userId}": {
+        "/auth/sso/discover": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "指定されたユーザーとの関係性（友達、ブロック、申請状況）を取得します",
+                "description": "メールアドレスのドメインにSSOの接続設定があるかを返します。ログイン画面でパスワードの入力欄を出すかSSOに誘導するかの判定に使用します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2654,45 +2517,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "auth"
                 ],
-                "summary": "ユーザー間関係取得",
+                "summary": "SSO設定の確認",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "対象ユーザーID",
-                        "name": "userId",
-                        "in": "path",
+                        "description": "メールアドレス",
+                        "name": "email",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "関係性取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/UserRelationshipResponse"
+                            "$ref": "#/definitions/SSODiscoveryResponse"
                         }
                     },
-                    "400": {
-                        "description": "ユーザーIDが無効",
+                    "500": {
+                        "description": "内部サーバーエラー",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/sso/login": {
+            "get": {
+                "description": "メールアドレスのドメイン（またはconnection_id）の接続設定のIdPにリダイレクトします。ログイン状態はsso_state Cookie（10分間）に保存し、コールバックで照合します。return_toにはログイン後に表示するパス（/から始まる同じオリジンのパス）を指定できます",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "SSOでのログイン開始",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "メールアドレス",
+                        "name": "email",
+                        "in": "query"
                     },
-                    "401": {
-                        "description": "認証が必要",
+                    {
+                        "type": "string",
+                        "description": "接続設定ID",
+                        "name": "connection_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ログイン後に表示するパス",
+                        "name": "return_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "IdPの認可エンドポイントにリダイレクト"
+                    },
+                    "404": {
+                        "description": "接続設定が見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "ユーザーが見つからない",
+                    "502": {
+                        "description": "IdPに接続できない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "内部サーバーエラー",
+                    "503": {
+                        "description": "SSOが設定されていない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2700,14 +2597,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/social/users/{userId}/block": {
+        "/billing/checkout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーをブロックします",
+                "description": "有料プランを購入するStripeのチェックアウトを作成します。返されたURLにリダイレクトして支払いを行い、契約はWebhookで反映されます",
                 "consumes": [
                     "application/json"
                 ],
@@ -2715,58 +2612,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
+                    "billing"
                 ],
-                "summary": "ユーザーブロック",
+                "summary": "チェックアウトの作成",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ブロックするユーザーID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                        "description": "購入するプラン",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/BillingCheckoutRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "ユーザーブロック成功",
+                    "201": {
+                        "description": "作成成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
+                            "$ref": "#/definitions/BillingCheckoutResponse"
                         }
                     },
                     "400": {
-                        "description": "ユーザーIDが無効",
+                        "description": "リクエストが無効、または購入できないプラン",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "ユーザーが見つからない",
+                    "409": {
+                        "description": "有料プランを契約中",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "課金が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/billing/plans": {
+            "get": {
+                "description": "Free・Pro・Teamの各プランの上限と利用できる機能を取得します（limitsに含まれないリソースは無制限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "billing"
+                ],
+                "summary": "プラン一覧",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/BillingPlanListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/billing/subscription": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーのブロックを解除します",
+                "description": "ログイン中のユーザーが利用できるプランと有料プランの契約の状態を取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2774,60 +2704,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "social"
-                ],
-                "summary": "ブロック解除",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ブロック解除するユーザーID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "billing"
                 ],
+                "summary": "契約の状態",
                 "responses": {
                     "200": {
-                        "description": "ブロック解除成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "ユーザーIDが無効",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingSubscriptionResponse"
                         }
                     },
                     "401": {
                         "description": "認証が必要",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "ブロック関係が見つからない",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/tasks": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "フィルタリング、ページング、ソート機能付きでタスク一覧を取得します",
+        "/billing/webhook": {
+            "post": {
+                "description": "Stripeからのイベント（チェックアウトの完了、サブスクリプションの変更・解約）を受信して契約に反映します。Stripe-Signatureヘッダーの署名を検証します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2835,148 +2739,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "billing"
                 ],
-                "summary": "タスク一覧取得",
+                "summary": "StripeのWebhook",
                 "parameters": [
                     {
-                        "enum": [
-                            "TODO",
-                            "IN_PROGRESS",
-                            "DONE"
-                        ],
-                        "type": "string",
-                        "description": "ステータスフィルタ",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "LOW",
-                            "MEDIUM",
-                            "HIGH"
-                        ],
-                        "type": "string",
-                        "description": "優先度フィルタ",
-                        "name": "priority",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "WORK",
-                            "PERSONAL",
-                            "STUDY",
-                            "HEALTH",
-                            "SHOPPING",
-                            "OTHER"
-                        ],
-                        "type": "string",
-                        "description": "カテゴリフィルタ",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "担当者IDフィルタ",
-                        "name": "assignee_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "作成者IDフィルタ",
-                        "name": "created_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "期限日FROM",
-                        "name": "due_date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "期限日TO",
-                        "name": "due_date_to",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "ページ番号",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "ページサイズ",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "created_at",
-                            "updated_at",
-                            "title",
-                            "priority",
-                            "status",
-                            "due_date"
-                        ],
-                        "type": "string",
-                        "default": "created_at",
-                        "description": "ソートフィールド",
-                        "name": "sort_field",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "ASC",
-                            "DESC"
-                        ],
                         "type": "string",
-                        "default": "DESC",
-                        "description": "ソート方向",
-                        "name": "sort_direction",
-                        "in": "query"
+                        "description": "Stripeの署名",
+                        "name": "Stripe-Signature",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "タスク一覧取得成功",
+                        "description": "受信成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskListResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     },
                     "400": {
-                        "description": "リクエストが無効",
+                        "description": "署名またはペイロードが無効",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "認証が必要",
+                    "500": {
+                        "description": "内部サーバーエラー（Stripeが再送する）",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "内部サーバーエラー",
+                    "503": {
+                        "description": "課金が設定されていない",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/BillingErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/clip": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "新しいタスクを作成します",
+                "description": "ブラウザの拡張機能から、閲覧中のページのタイトルをタイトル、メモと選択範囲（Markdownの引用）を説明としてタスクを作成し、ページのURLをリンクとして追加します。GitHubのIssue・Pull RequestのページはIssue・Pull Requestとして関連付けます。clipスコープのトークン（/clip/token で発行）で認証し、トークンごとにレート制限します。CORSは拡張機能のオリジン（CORS_CLIP_ALLOWED_ORIGINS）のみ許可します",
                 "consumes": [
                     "application/json"
                 ],
@@ -2984,25 +2797,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "clip"
                 ],
-                "summary": "タスク作成",
+                "summary": "ページのクリップ",
                 "parameters": [
                     {
-                        "description": "タスク作成情報",
+                        "description": "クリップするページ",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/TaskRequest"
+                            "$ref": "#/definitions/ClipRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "タスク作成成功",
+                        "description": "作成成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskCreateResponse"
+                            "$ref": "#/definitions/ClipResponse"
                         }
                     },
                     "400": {
@@ -3012,7 +2825,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "認証が必要",
+                        "description": "トークンが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエストが多すぎる・タスクの上限に達した",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3026,14 +2845,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/my": {
-            "get": {
+        "/clip/token": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "現在認証されているユーザーに割り当てられたタスクを取得します",
+                "description": "ログイン中のセッション（アクセストークン）と引き換えに、ブラウザの拡張機能から使うclipスコープの長期間有効なトークン（APIキー）を発行します。トークンはレスポンスでのみ返し、再表示できません。トークンは POST /clip に「Authorization: Bearer \u003ctoken\u003e」で指定し、発行したユーザーとして操作します。一覧・無効化は /auth/shortcut-keys で行います",
                 "consumes": [
                     "application/json"
                 ],
@@ -3041,14 +2860,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "clip"
+                ],
+                "summary": "ブラウザの拡張機能用のトークンの発行",
+                "parameters": [
+                    {
+                        "description": "トークンの名前",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateClipTokenRequest"
+                        }
+                    }
                 ],
-                "summary": "自分のタスク取得",
                 "responses": {
-                    "200": {
-                        "description": "自分のタスク取得成功",
+                    "201": {
+                        "description": "発行成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskListResponse"
+                            "$ref": "#/definitions/CreateAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -3066,14 +2902,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/overdue": {
+        "/geofences": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "期限が過ぎているタスクの一覧を取得します",
+                "description": "自分が登録したジオフェンスをタスクの場所とともに登録した順に取得します（端末で監視する領域の同期用）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3083,12 +2919,12 @@ const docTemplate = `{
                 "tags": [
                     "tasks"
                 ],
-                "summary": "期限切れタスク取得",
+                "summary": "ジオフェンス一覧",
                 "responses": {
                     "200": {
-                        "description": "期限切れタスク取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskListResponse"
+                            "$ref": "#/definitions/GeofenceListResponse"
                         }
                     },
                     "401": {
@@ -3106,14 +2942,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/search": {
-            "get": {
+        "/geofences/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "キーワードでタスクを検索します（タイトルと説明が対象）",
+                "description": "自分が登録したジオフェンスを削除します",
                 "consumes": [
                     "application/json"
                 ],
@@ -3123,40 +2959,31 @@ const docTemplate = `{
                 "tags": [
                     "tasks"
                 ],
-                "summary": "タスク検索",
+                "summary": "ジオフェンスの削除",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "検索クエリ",
-                        "name": "q",
-                        "in": "query",
+                        "description": "ジオフェンスID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "結果の最大数",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "タスク検索成功",
+                        "description": "削除成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskListResponse"
+                            "$ref": "#/definitions/TaskDeleteResponse"
                         }
                     },
-                    "400": {
-                        "description": "検索クエリが必要",
+                    "401": {
+                        "description": "認証が必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "認証が必要",
+                    "404": {
+                        "description": "ジオフェンスが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3170,14 +2997,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/stats/category-breakdown": {
-            "get": {
+        "/geofences/{id}/enter": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "タスクのカテゴリ別内訳統計を取得します",
+                "description": "モバイル端末がジオフェンスに入ったことを報告し、タスクのリマインダーを通知します。緯度・経度を指定した場合は範囲内（誤差100mを含む）かを確認します。完了済みのタスク・前回のリマインダーから1時間以内・範囲外の場合は通知せず triggered が false になります",
                 "consumes": [
                     "application/json"
                 ],
@@ -3185,30 +3012,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "tasks"
+                ],
+                "summary": "ジオフェンスに入ったことの報告",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ジオフェンスID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "端末の位置",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/GeofenceEnterRequest"
+                        }
+                    }
                 ],
-                "summary": "カテゴリ別統計取得",
                 "responses": {
                     "200": {
-                        "description": "カテゴリ別統計取得成功",
+                        "description": "報告成功",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "PERSONAL": {
-                                            "$ref": "#/definitions/CategoryBreakdownData"
-                                        },
-                                        "WORK": {
-                                            "$ref": "#/definitions/CategoryBreakdownData"
-                                        }
-                                    }
-                                },
-                                "success": {
-                                    "type": "boolean"
-                                }
-                            }
+                            "$ref": "#/definitions/GeofenceEnterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -3217,6 +3051,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "タスクを参照する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ジオフェンスが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -3226,14 +3072,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/stats/daily/{date}": {
-            "get": {
+        "/groups": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定された日付のタスク統計情報を取得します",
+                "description": "新しいグループを作成します（プロジェクト管理用または予定共有用）\nparent_group_id を指定すると、そのプロジェクトグループのサブチームとして作成します（親グループのオーナー・管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3241,27 +3087,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
                 ],
-                "summary": "指定日の統計取得",
+                "summary": "グループ作成",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "日付(YYYY-MM-DD形式)",
-                        "name": "date",
-                        "in": "path",
-                        "required": true
+                        "description": "グループ作成情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateGroupRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "日次統計取得成功",
+                    "201": {
+                        "description": "グループ作成成功",
                         "schema": {
-                            "$ref": "#/definitions/DailyStatsResponse"
+                            "$ref": "#/definitions/GroupResponse"
                         }
                     },
                     "400": {
-                        "description": "日付形式が無効",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3272,6 +3120,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "親グループにサブチームを作成する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "グループ数が上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -3281,14 +3141,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/stats/dashboard": {
+        "/groups/my": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ダッシュボード表示用の包括的な統計情報を取得します",
+                "description": "自分が所属しているグループの一覧を取得します（ページング対応）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3296,14 +3156,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
+                ],
+                "summary": "自分のグループ一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループタイプでフィルタ",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "ダッシュボード統計取得",
                 "responses": {
                     "200": {
-                        "description": "ダッシュボード統計取得成功",
+                        "description": "グループ一覧取得成功",
                         "schema": {
-                            "$ref": "#/definitions/DashboardStatsResponse"
+                            "$ref": "#/definitions/GroupListResponse"
                         }
                     },
                     "401": {
@@ -3321,14 +3206,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/stats/monthly": {
+        "/groups/search": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定された月のタスク統計情報を取得します",
+                "description": "キーワードでグループを検索します（ページング対応）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3336,38 +3221,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
                 ],
-                "summary": "月次統計取得",
+                "summary": "グループ検索",
                 "parameters": [
                     {
-                        "maximum": 3000,
-                        "minimum": 2000,
-                        "type": "integer",
-                        "default": 2024,
-                        "description": "年",
-                        "name": "year",
+                        "type": "string",
+                        "description": "検索クエリ",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "グループタイプでフィルタ",
+                        "name": "type",
                         "in": "query"
                     },
                     {
-                        "maximum": 12,
                         "minimum": 1,
                         "type": "integer",
                         "default": 1,
-                        "description": "月",
-                        "name": "month",
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "月次統計取得成功",
+                        "description": "グループ検索成功",
                         "schema": {
-                            "$ref": "#/definitions/WeeklyStatsResponse"
+                            "$ref": "#/definitions/GroupListResponse"
                         }
                     },
                     "400": {
-                        "description": "パラメータが無効",
+                        "description": "検索クエリが必要",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3387,14 +3284,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/stats/priority-breakdown": {
+        "/groups/{groupId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "タスクの優先度別内訳統計を取得します",
+                "description": "指定されたIDのグループの詳細情報とメンバー一覧を取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -3402,33 +3299,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
+                ],
+                "summary": "グループ詳細取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "優先度別統計取得",
                 "responses": {
                     "200": {
-                        "description": "優先度別統計取得成功",
+                        "description": "グループ詳細取得成功",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "HIGH": {
-                                            "$ref": "#/definitions/PriorityBreakdownData"
-                                        },
-                                        "LOW": {
-                                            "$ref": "#/definitions/PriorityBreakdownData"
-                                        },
-                                        "MEDIUM": {
-                                            "$ref": "#/definitions/PriorityBreakdownData"
-                                        }
-                                    }
-                                },
-                                "success": {
-                                    "type": "boolean"
-                                }
-                            }
+                            "$ref": "#/definitions/GroupWithMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -3437,6 +3330,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -3444,16 +3343,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/stats/progress-level": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "完了率に基づく進捗レベル情報を取得します",
+                "description": "指定されたIDのグループ情報を更新します（管理者のみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3461,27 +3358,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
                 ],
-                "summary": "進捗レベル取得",
+                "summary": "グループ更新",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "完了率(0-100)",
-                        "name": "rate",
-                        "in": "query",
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "グループ更新情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateGroupRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "進捗レベル取得成功",
+                        "description": "グループ更新成功",
                         "schema": {
-                            "$ref": "#/definitions/ProgressLevelResponse"
+                            "$ref": "#/definitions/GroupResponse"
                         }
                     },
                     "400": {
-                        "description": "完了率パラメータが無効",
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3493,16 +3417,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/stats/progress-summary": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定された日数分の進捗サマリーを取得します",
+                "description": "指定されたIDのグループを削除します（オーナーのみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3510,29 +3432,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
                 ],
-                "summary": "進捗サマリー取得",
+                "summary": "グループ削除",
                 "parameters": [
                     {
-                        "maximum": 365,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 7,
-                        "description": "対象日数",
-                        "name": "days",
-                        "in": "query"
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "進捗サマリー取得成功",
+                        "description": "グループ削除成功",
                         "schema": {
-                            "$ref": "#/definitions/ProgressSummaryResponse"
+                            "$ref": "#/definitions/SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "パラメータが無効",
+                        "description": "グループIDが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3543,6 +3463,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "権限不足（オーナーのみ削除可能）",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -3552,14 +3484,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/stats/today": {
+        "/groups/{groupId}/assignment-policy": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "本日のタスク統計情報を取得します",
+                "description": "担当者を指定せずに作成したグループタスクの自動割り当て方式を取得します（メンバーのみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3567,14 +3499,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
+                ],
+                "summary": "タスク自動割り当て設定取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "今日の統計取得",
                 "responses": {
                     "200": {
-                        "description": "今日の統計取得成功",
+                        "description": "自動割り当て設定取得成功",
                         "schema": {
-                            "$ref": "#/definitions/DailyStatsResponse"
+                            "$ref": "#/definitions/AssignmentSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -3583,6 +3530,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -3590,16 +3543,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/stats/weekly": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定された週のタスク統計情報を取得します",
+                "description": "担当者を指定せずに作成したグループタスクの自動割り当て方式を変更します（グループ設定の編集権限が必要）\nNONE: 自動割り当てしない、ROUND_ROBIN: メンバーに順番に割り当てる、LEAST_LOADED: 未完了タスクが最も少ないメンバーに割り当てる。ゲストには割り当てません",
                 "consumes": [
                     "application/json"
                 ],
@@ -3607,26 +3558,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "groups"
                 ],
-                "summary": "週次統計取得",
+                "summary": "タスク自動割り当て設定変更",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "基準日付(YYYY-MM-DD形式)",
-                        "name": "date",
-                        "in": "query"
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "自動割り当て方式",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateAssignmentPolicyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "週次統計取得成功",
+                        "description": "自動割り当て設定変更成功",
                         "schema": {
-                            "$ref": "#/definitions/WeeklyStatsResponse"
+                            "$ref": "#/definitions/AssignmentSettingsResponse"
                         }
                     },
                     "400": {
-                        "description": "日付形式が無効",
+                        "description": "リクエストが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3637,6 +3598,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "内部サーバーエラー",
                         "schema": {
@@ -3646,38 +3613,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/user/{user_id}": {
+        "/groups/{groupId}/events": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたユーザーに割り当てられたタスクを取得します",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "予定共有グループの期間内の予定を開始日時の順に取得します（予定の閲覧権限が必要、最大92日）。繰り返しの予定は回ごとに展開し、中止した回は含めず、日時を変更した回は変更後の日時で返します\nformat=ics の場合はカレンダーアプリに取り込めるiCalendar形式で返します。from・toは暦日としてtimezone（省略時はUTC）で解釈し、toの日を含みます",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "groups"
                 ],
-                "summary": "特定ユーザーのタスク取得",
+                "summary": "グループの予定一覧取得",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ユーザーID",
-                        "name": "user_id",
+                        "description": "グループID",
+                        "name": "groupId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日 (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "終了日 (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "日の区切りに使うタイムゾーン（省略時はUTC）",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "ics"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（省略時は json）",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ユーザータスク取得成功",
+                        "description": "取得成功（format=ics の場合はiCalendar）",
                         "schema": {
-                            "$ref": "#/definitions/TaskListResponse"
+                            "$ref": "#/definitions/EventScheduleResponse"
                         }
                     },
                     "400": {
@@ -3692,8 +3686,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "ユーザーが見つからない",
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3707,42 +3701,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}": {
+        "/groups/{groupId}/events/{eventId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDのタスクを取得します",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "予定と、関連付けたタスク（予定を作業時間とするタスク・予定から作成したフォローアップのタスク）を取得します（予定の閲覧権限が必要）。繰り返しの予定は回のIDで指定します",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "groups"
                 ],
-                "summary": "タスク取得",
+                "summary": "予定の詳細取得",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "タスクID",
-                        "name": "id",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）または回のID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "タスク取得成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskGetResponse"
+                            "$ref": "#/definitions/EventDetailResponse"
                         }
                     },
                     "400": {
-                        "description": "リクエストが無効",
+                        "description": "IDが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3753,8 +3751,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "タスクが見つからない",
+                        "description": "予定が見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3766,14 +3770,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/groups/{groupId}/events/{eventId}/attendance": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDのタスクを更新します",
+                "description": "メンバーが予定に出席したかを記録します（予定の作成者またはタスクの編集権限を持つメンバーのみ、予定の開始後）\n記録した出席はグループ統計のメンバーごとの出席率に反映されます",
                 "consumes": [
                     "application/json"
                 ],
@@ -3781,32 +3787,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "groups"
                 ],
-                "summary": "タスク更新",
+                "summary": "予定の出席の記録",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "タスクID",
-                        "name": "id",
+                        "description": "グループID",
+                        "name": "groupId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "タスク更新情報",
+                        "type": "string",
+                        "description": "予定（タスク）のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "出席の記録",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/TaskRequest"
+                            "$ref": "#/definitions/RecordAttendanceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "タスク更新成功",
+                        "description": "出席の記録成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskUpdateResponse"
+                            "$ref": "#/definitions/EventRSVPResponse"
                         }
                     },
                     "400": {
@@ -3821,8 +3834,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "タスクが見つからない",
+                        "description": "予定が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "予定が開始していない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3834,14 +3859,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/groups/{groupId}/events/{eventId}/attendees": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたIDのタスクを削除します",
+                "description": "予定共有グループの予定について、メンバー全員の出欠（未回答は NO_RESPONSE）と出席の記録を取得します（メンバーのみ）",
                 "consumes": [
                     "application/json"
                 ],
@@ -3849,27 +3876,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "groups"
                 ],
-                "summary": "タスク削除",
+                "summary": "予定の出欠一覧取得",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "タスクID",
-                        "name": "id",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）のID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "タスク削除成功",
+                        "description": "出欠一覧取得成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskDeleteResponse"
+                            "$ref": "#/definitions/EventAttendeesResponse"
                         }
                     },
                     "400": {
-                        "description": "リクエストが無効",
+                        "description": "IDが無効",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3880,8 +3914,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "タスクが見つからない",
+                        "description": "予定が見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3895,14 +3935,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}/assign": {
-            "put": {
+        "/groups/{groupId}/events/{eventId}/follow-ups": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたタスクをユーザーに割り当てます",
+                "description": "予定からフォローアップのグループタスクを作成し、予定と関連付けます（タスクの作成権限が必要）。繰り返しの予定は回のIDで指定します\nタイトルを省略した場合は「予定のタイトル」のフォローアップ、担当者を省略した場合は作成したメンバーとし、説明には予定へのリンクを追記します",
                 "consumes": [
                     "application/json"
                 ],
@@ -3910,32 +3950,194 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "groups"
                 ],
-                "summary": "タスク割り当て",
+                "summary": "予定のフォローアップのタスク作成",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "タスクID",
-                        "name": "id",
+                        "description": "グループID",
+                        "name": "groupId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "割り当て情報",
+                        "type": "string",
+                        "description": "予定（タスク）または回のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "フォローアップのタスク",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/AssignTaskRequest"
+                            "$ref": "#/definitions/CreateFollowUpTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventTaskLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "予定が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/groups/{groupId}/events/{eventId}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "予定の議事録を作成日時の古い順に取得します（予定の閲覧権限が必要）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "予定の議事録一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）または回のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "タスク割り当て成功",
+                        "description": "取得成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskUpdateResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/EventNoteResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "予定が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "予定に議事録（Markdown）を付けます（タスクの作成権限が必要）。繰り返しの予定は回のIDで指定します\n本文の未完了のタスクリストの行（\"- [ ] 内容\"）ごとにグループタスクを作成し、行で@usernameでメンションした最初のメンバーに割り当てます（メンバーがいない場合は担当者なし）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "予定の議事録作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）または回のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "議事録",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EventNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventNoteResponse"
                         }
                     },
                     "400": {
@@ -3950,8 +4152,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "タスクまたはユーザーが見つからない",
+                        "description": "予定が見つからない",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -3965,14 +4173,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}/status": {
+        "/groups/{groupId}/events/{eventId}/override": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "指定されたタスクのステータスを変更します",
+                "description": "繰り返しの予定の1回分を中止するか、開始日時を変更します（予定の作成者またはタスクの編集権限を持つメンバーのみ、その回の開始前まで）。日時を変更した回の出欠は引き継ぎます",
                 "consumes": [
                     "application/json"
                 ],
@@ -3980,32 +4188,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "groups"
                 ],
-                "summary": "タスクステータス変更",
+                "summary": "繰り返しの予定の回の変更",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "タスクID",
-                        "name": "id",
+                        "description": "グループID",
+                        "name": "groupId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "ステータス変更情報",
+                        "type": "string",
+                        "description": "回のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "回の変更",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ChangeStatusRequest"
+                            "$ref": "#/definitions/ChangeOccurrenceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ステータス変更成功",
+                        "description": "変更成功",
                         "schema": {
-                            "$ref": "#/definitions/TaskUpdateResponse"
+                            "$ref": "#/definitions/EventExceptionResponse"
                         }
                     },
                     "400": {
@@ -4020,8 +4235,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "タスクが見つからない",
+                        "description": "回が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "回が開始済み",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -4033,83 +4260,18736 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "AddMemberRequest": {
-            "type": "object",
-            "required": [
-                "user_id"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "繰り返しの予定の1回分の中止・日時の変更を取り消し、元の日時に戻します（予定の作成者またはタスクの編集権限を持つメンバーのみ）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "繰り返しの予定の回の変更の取り消し",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "回のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取り消し成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "回が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/events/{eventId}/recurrence": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "予定に設定した繰り返しを取得します（予定の閲覧権限が必要）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "予定の繰り返し取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventRecurrenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "予定または繰り返しが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "予定（開始日時のあるグループタスク）を繰り返しの予定にします（予定の作成者またはタスクの編集権限を持つメンバーのみ）。予定の開始日時を最初の回とし、timezoneの同じ時刻で繰り返します\n繰り返しの予定の出欠・出席・リマインダーは回ごとに扱い、回は予定の一覧で返す回のID（タスクIDと元の開始日時）で指定します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "予定の繰り返し設定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "繰り返し",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetEventRecurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "設定成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventRecurrenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "予定が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "予定の繰り返しと回ごとの変更を削除し、1回だけの予定に戻します（予定の作成者またはタスクの編集権限を持つメンバーのみ）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "予定の繰り返し削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "予定または繰り返しが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/events/{eventId}/rsvp": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "予定共有グループの予定（開始日時のあるグループタスク）に自分の出欠を回答します（メンバーのみ、予定の開始前まで）\nYES: 参加する、NO: 参加しない、MAYBE: 未定。回答は開始前なら何度でも変更できます。未回答のメンバーには開始の24時間前にリマインダーを通知します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "予定への出欠の回答",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "予定（タスク）のID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "出欠",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetRSVPRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "出欠の回答成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventRSVPResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループのメンバーではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "予定が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "予定が開始済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/integrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのチャット連携（Discord・Teams）の設定を取得します（グループ設定の編集権限が必要）。WebhookのURLは末尾のトークンを伏せて返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループのチャット連携一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ChatIntegrationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/integrations/{platform}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのタスクの完了・担当者の割り当て・期限のリマインダー・1日のまとめをチャットのチャンネル（Discord・Teams）に投稿する連携を設定します（グループ設定の編集権限が必要）。省略した項目は変更しません\n新しく連携する場合はWebhookのURLが必須です。Teamsには見出しと項目を添えたAdaptive Cardで投稿します。テンプレートはGoのtext/template形式で、{{.group_name}} {{.task_title}} {{.assignees}} {{.due_date}}（1日のまとめは {{.date}} {{.completed}} {{.due_today}} {{.overdue}} と各件数）を使えます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループのチャット連携の設定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "discord",
+                            "teams"
+                        ],
+                        "type": "string",
+                        "description": "連携先",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "連携の設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SaveChatIntegrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "設定成功",
+                        "schema": {
+                            "$ref": "#/definitions/ChatIntegrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのチャット連携を解除します（グループ設定の編集権限が必要）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループのチャット連携の解除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "discord",
+                            "teams"
+                        ],
+                        "type": "string",
+                        "description": "連携先",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解除成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "連携が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/integrations/{platform}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのチャット連携にテストのメッセージを投稿します（グループ設定の編集権限が必要）。無効にした連携にも投稿します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループのチャット連携のテスト",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "discord",
+                            "teams"
+                        ],
+                        "type": "string",
+                        "description": "連携先",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "投稿成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "連携が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "連携先への投稿に失敗",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループタスクの週ごとの完了数・期限内の完了率・連続達成日数のランキングを取得します（メンバーのみ、グループで有効にしている場合のみ）\n集計は週（設定したタイムゾーンの月曜日0時）ごとにリセットされます。非公開のメンバーは含まれず、匿名のメンバーは本人以外には名前とユーザーIDを伏せて返します。結果は数分間キャッシュされます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループのリーダーボード取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "current",
+                            "previous"
+                        ],
+                        "type": "string",
+                        "description": "集計する週（省略時は current）",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "リーダーボード取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "リーダーボードが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/leaderboard/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのリーダーボードの有効・無効、週の区切りのタイムゾーン、自分の公開範囲を取得します（メンバーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "リーダーボード設定取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "リーダーボード設定取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/LeaderboardSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのリーダーボードの有効・無効と週の区切りのタイムゾーンを変更します（グループ設定の編集権限が必要）。省略した項目は変更しません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "リーダーボード設定変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "リーダーボード設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateLeaderboardSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "リーダーボード設定変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/LeaderboardSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/leaderboard/visibility": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分をリーダーボードにどう表示するかを変更します（メンバーのみ）\nVISIBLE: 名前を表示する（デフォルト）、ANONYMOUS: 名前を伏せて順位に参加する、HIDDEN: リーダーボードに参加しない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "リーダーボードの公開範囲変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "公開範囲",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateLeaderboardVisibilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "公開範囲変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/LeaderboardVisibilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループのメンバーではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたグループのメンバー一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "メンバー一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "メンバー一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/MemberListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたグループにメンバーを追加します（管理者のみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "メンバー追加",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "メンバー追加情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "メンバー追加成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足、またはブロック関係にあるユーザー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループまたはユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "既にメンバー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/members/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたグループに複数のユーザーを一括で追加します（最大100人）。ユーザーごとの結果（ADDED / ALREADY_MEMBER / NOT_FOUND / BLOCKED）を返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "メンバー一括追加",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "一括追加するユーザー",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/BulkAddMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ユーザーごとの追加結果",
+                        "schema": {
+                            "$ref": "#/definitions/BulkAddMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたグループからメンバーを削除します（管理者のみ、または自分自身の脱退）\n削除は取り消し期間（既定10秒）の経過後に実行され、その間はundo.undo_tokenをPOST /undo/{token}に送ると取り消せます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "メンバー削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "削除するユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "メンバー削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/MemberRemovedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループまたはユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/members/{userId}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたメンバーの権限を変更します（管理者のみ）\nOWNER・ADMIN・MEMBER・GUEST（閲覧のみ）のほか、グループで定義したカスタムロールのIDを指定できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "メンバー権限変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "権限変更するユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "権限変更情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "権限変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループまたはユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/notes/{noteId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "議事録と、アクションアイテムから作成したタスクを取得します（予定の閲覧権限が必要）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "議事録取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "議事録ID",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventNoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "議事録が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "議事録のタイトルと本文を更新します（議事録の作成者またはタスクの編集権限を持つメンバーのみ）\n追加した未完了のタスクリストの行からタスクを作成します。本文から消した行のタスクは削除しません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "議事録更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "議事録ID",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "議事録",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EventNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventNoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "議事録が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "議事録を削除します（議事録の作成者またはタスクの編集権限を持つメンバーのみ）。議事録から作成したタスクは削除しません",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "議事録削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "議事録ID",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "議事録が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アクションごとに許可されているロールを取得します（オーナーは常に全てのアクションを実行できます）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループの権限設定取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "権限設定取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/GroupPermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アクションごとに許可するロール（ADMIN・MEMBER）を変更します（オーナーのみ）\n変更できるのはグループ設定の編集・メンバーの招待と削除・タスクの作成・編集・削除・閲覧・予定の閲覧です。指定しなかったアクションはデフォルトに戻ります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループの権限設定変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "権限設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateGroupPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "権限設定変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/GroupPermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足（オーナーのみ変更可能）",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループで定義したカスタムロールを作成日時の古い順に取得します（メンバーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "カスタムロール一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "カスタムロール一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/CustomRolesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アクションの組み合わせに名前を付けたカスタムロールを作成します（ロール管理の権限が必要）\n付与できるのは権限設定で変更できるアクションのみで、グループの閲覧は常に許可されます。1グループ20件まで作成できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "カスタムロール作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "カスタムロール",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CustomRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "カスタムロール作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/CustomRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/roles/{roleId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "カスタムロールの名前と権限を変更します（ロール管理の権限が必要）。割り当て済みのメンバーにもすぐに反映されます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "カスタムロール更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "カスタムロールID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "カスタムロール",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CustomRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "カスタムロール更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/CustomRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "カスタムロールが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "カスタムロールを削除します（ロール管理の権限が必要）。割り当てていたメンバーは MEMBER に戻ります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "カスタムロール削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "カスタムロールID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "カスタムロール削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "カスタムロールが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/schedule-card/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "今日から1週間の予定のスケジュールカード（画像・PDF）の署名付きの共有リンクを発行します（予定の閲覧権限が必要、スケジュールカードを有効にしたグループのみ）\nリンクは14日間有効で、認証なしで表示できるためチャットに貼り付けて共有できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "スケジュールカードの共有リンク発行",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "共有リンク発行成功",
+                        "schema": {
+                            "$ref": "#/definitions/ScheduleCardLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "予定の閲覧権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "スケジュールカードが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/schedule-card/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのスケジュールカード（1週間の予定の共有用の画像・PDF）の有効・無効、日付の区切りのタイムゾーン、タイトルの表示を取得します（メンバーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "スケジュールカード設定取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "スケジュールカード設定取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/ScheduleCardSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのスケジュールカードの有効・無効、日付の区切りのタイムゾーン、タイトルの表示を変更します（グループ設定の編集権限が必要）。省略した項目は変更しません。無効にすると発行済みのリンクでもカードを表示できなくなります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "スケジュールカード設定変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "スケジュールカード設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateScheduleCardSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "スケジュールカード設定変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/ScheduleCardSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたグループの統計情報（メンバー数、タスク数など）を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループ統計取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "統計情報取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/GroupStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/tasks/{taskId}/note": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "議事録のアクションアイテムから作成したグループタスクの、元の議事録を取得します（予定の閲覧権限が必要）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "タスクの元の議事録取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "taskId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventNoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "議事録から作成したタスクでない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/tasks/{taskId}/schedule-block": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループタスクの作業時間として予定を作成し、タスクと関連付けます（予定共有グループ、タスクの作成権限が必要）\n予定は作成したメンバーに割り当てます。長さを省略した場合はタスクの見積もり時間とします（見積もりがない場合は必須）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "タスクの作業時間の予定作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "taskId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "開始日時と長さ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ScheduleTaskBlockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/EventDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限不足",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ガントチャート表示用に、グループタスクの開始日・期限・依存関係・マイルストーンと、サーバー側で計算したクリティカルパスを取得します（グループのメンバーのみ、タスクは500件まで）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "グループのタイムライン取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タイムライン取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TimelineResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{groupId}/tree": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループとそのサブチームを階層で取得します。各グループの統計と、サブチームを含めた統計（メンバーは重複を除いて数える）を返します\n上位グループのオーナー・管理者はサブチームでも管理者と同じ権限を持ちます。閲覧できない非公開のサブチームは含まれません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "サブチームの階層取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "階層取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/GroupTreeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "グループIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへのアクセス権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/email": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "メールを送るとタスクを作成できる自分専用のメールアドレスと、許可した差出人を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク作成用のメールアドレス",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/InboundAddressResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "メールアドレスを作成していない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分専用のメールアドレスを作成し、許可する差出人を設定します。作成済みの場合はアドレスを変えずに差出人だけを置き換えます。許可した差出人からSPF・DKIMで確認できたメールだけを受け付け、件名をタイトル、本文を説明としてタスクを作成し、添付ファイル（10件まで）をタスクに添付します。説明に収まらない本文は message.txt として添付します。スパムと判定されたメール・同じMessage-IDのメールは無視し、1時間に作成できるタスクの数には上限があります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク作成用のメールアドレスの作成・差出人の変更",
+                "parameters": [
+                    {
+                        "description": "許可する差出人",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InboundAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "作成・変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/InboundAddressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "メールの受信が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "メールアドレスを削除します。作成済みのタスクはそのまま残ります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク作成用のメールアドレスの削除",
+                "responses": {
+                    "200": {
+                        "description": "削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "メールアドレスを作成していない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/email/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "メールアドレスを新しいものに変えます。以前のアドレス宛てのメールは受け付けなくなります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク作成用のメールアドレスの再発行",
+                "responses": {
+                    "200": {
+                        "description": "再発行成功",
+                        "schema": {
+                            "$ref": "#/definitions/InboundAddressResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "メールアドレスを作成していない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "メールの受信が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/github": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue・Pull Requestの取得に使うGitHubのアカウントを取得します。トークンは末尾4文字だけを表示します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "登録したGitHubのアカウント",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/GitHubAccountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "GitHubのトークンが登録されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "非公開のリポジトリのIssue・Pull Requestを関連付けるためのGitHubのトークン（Fine-grainedトークンの場合はIssues・Pull requestsの読み取り権限）を登録します。GitHubでトークンを確認してから保存し、登録済みの場合は置き換えます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "GitHubのトークンの登録",
+                "parameters": [
+                    {
+                        "description": "GitHubのトークン",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GitHubAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登録成功",
+                        "schema": {
+                            "$ref": "#/definitions/GitHubAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効、またはトークンが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "GitHubに接続できない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "登録したGitHubのトークンを削除します。関連付けたIssue・Pull RequestとWebhookによる状態の同期はそのまま残ります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "GitHubのトークンの削除",
+                "responses": {
+                    "200": {
+                        "description": "削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "GitHubのトークンが登録されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーのエクスポートを新しい順に最大20件取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "データのエクスポートの一覧",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーの全データ（タスク・コメント・添付ファイルの情報・グループ・友達・統計）のZIP（各データのJSONとCSV）の作成を依頼します。\nZIPはバックグラウンドで作成し、ダウンロードの準備ができたら通知します。作成待ち・作成中のエクスポートがある場合は新しく作成せず、そのエクスポートを返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "データのエクスポートの依頼",
+                "responses": {
+                    "202": {
+                        "description": "受付済み",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/export/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "エクスポートの状態（PENDING・PROCESSING・READY・FAILED・EXPIRED）とダウンロードの期限を取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "データのエクスポートの状態",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "エクスポートID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "エクスポートが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/export/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "作成済みのエクスポートのZIPをダウンロードします。作成待ち・作成中の場合は 409、ダウンロードの期限が過ぎた場合は 410 を返します（もう一度依頼してください）",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "データのエクスポートのダウンロード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "エクスポートID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "エクスポートのZIP",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "エクスポートが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "作成が完了していない",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "ダウンロードの期限切れ",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/features": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "全ての機能フラグについて、ログイン中のユーザーに公開されているかを取得します（クライアントの表示切り替え用）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "features"
+                ],
+                "summary": "自分に公開されている機能",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/UserFeaturesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/FeatureFlagErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "別のYotei+のサーバーでエクスポートしたZIP（POST /me/export で作成したもの）から、プロフィールと勤務時間の設定・所有していたグループ・タスクを復元します。\nタスク・グループは新しいIDで作成し、作成者・所有者はログイン中のユーザーになります。担当者はエクスポートしたユーザーが担当していた場合のみ引き継ぎます。コメント・添付ファイル・友達は復元しません。\n復元したレコードは記録しているため、同じZIPを何度インポートしても重複して作成しません（途中で失敗した場合はもう一度インポートしてください）",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "データのインポート",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "エクスポートのZIP（最大100MB）",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "インポート成功（セクションごとの件数）",
+                        "schema": {
+                            "$ref": "#/definitions/DataImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ZIPが不正",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "インポートを実行中",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "ファイルが大きすぎる",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "対応していない形式のバージョン",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "タスク・グループ数の上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/DataExportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーの通知の設定を取得します。social_digest は未対応の友達申請・グループ招待をまとめて知らせるリマインダーの頻度です（既定は DAILY）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "通知の設定取得",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/NotificationPreferencesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーの通知の設定を変更します。social_digest に OFF を指定すると未対応の友達申請・グループ招待のリマインダーを送りません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "通知の設定変更",
+                "parameters": [
+                    {
+                        "description": "変更する設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateNotificationPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/NotificationPreferencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ホーム画面用に、今日が期限のタスク・前日までに期限を過ぎた未完了のタスク（30日前まで）・今日着手予定のタスクと未読通知数をまとめて取得します。日の区切りはtimezone、省略時は勤務時間設定のタイムゾーンです",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "今日の予定取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TodayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しい通知を作成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "通知作成",
+                "parameters": [
+                    {
+                        "description": "通知作成情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "通知作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/CreateNotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーの通知一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "ユーザーの通知一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "取得数の上限",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "通知一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/GetUserNotificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/user/{user_id}/read-all": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーの全ての未読通知を既読としてマークします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "全通知既読マーク",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "全既読マーク成功",
+                        "schema": {
+                            "$ref": "#/definitions/MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/user/{user_id}/unread/count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーの未読通知数を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "未読通知数取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "未読通知数取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/UnreadCountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/webhook": {
+            "post": {
+                "description": "外部サービスからのWebhookリクエストを処理します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Webhook処理",
+                "parameters": [
+                    {
+                        "description": "Webhookペイロード",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook受信成功",
+                        "schema": {
+                            "$ref": "#/definitions/MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDの通知を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "通知取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "通知ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "通知取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/GetNotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "通知IDが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "通知が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された通知を既読としてマークします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "通知既読マーク",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "通知ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "既読マーク成功",
+                        "schema": {
+                            "$ref": "#/definitions/MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "通知IDが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "通知が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/send": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された通知を即座に送信します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "通知送信",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "通知ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "通知送信成功",
+                        "schema": {
+                            "$ref": "#/definitions/MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "通知IDが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "通知が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/groups/{groupId}/schedule-card": {
+            "get": {
+                "description": "署名付きの共有リンクのスケジュールカードを認証なしで出力します。svgは1200x630の画像（チャットのプレビュー向け）、pdfはA4の一覧です\n出力は5分間キャッシュします。リンクの有効期限が切れた場合・グループがスケジュールカードを無効にした場合は表示できません",
+                "produces": [
+                    "image/svg+xml",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "スケジュールカード表示",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "カードの開始日（YYYY-MM-DD）",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "有効期限（Unix秒）",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "署名",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "svg",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "default": "svg",
+                        "description": "出力形式",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "スケジュールカード",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "署名が無効・有効期限切れ",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "スケジュールカードが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/shares/{token}": {
+            "get": {
+                "description": "共有リンクのタスクまたはタスク一覧を認証なしで取得します。パスワード付きのリンクはX-Share-Passwordヘッダーでパスワードを指定してください",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "公開リンク閲覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "共有トークン",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "共有リンクのパスワード",
+                        "name": "X-Share-Password",
+                        "in": "header"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号（一覧の場合）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ（一覧の場合）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/PublicShareResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "パスワードが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "パスワードが違う",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "共有リンクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "共有リンクの有効期限切れ",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "パスワードを続けて間違えたため一時的に閲覧できない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quick/task": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "音声アシスタント・ショートカット向けに、プレーンテキストの本文をクイック追加と同じ書式（「明日15時 資料作成 #仕事 !高」など）で解析してタスクを作成し、読み上げ用の短い文を返します。quickスコープのAPIキー（/auth/shortcut-keys で発行）で認証し、キーごとにレート制限します。エラーも短いプレーンテキストで返します",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "quick"
+                ],
+                "summary": "ショートカットからのタスクの追加",
+                "parameters": [
+                    {
+                        "description": "タスクの内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "相対日付のタイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "「資料作成」を追加しました。期限は6月4日 15:00です。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "内容を読み取れませんでした。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "キーが無効です。アプリでキーを確認してください。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "内容が長すぎます。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエストが多すぎます。少し待ってからお試しください。（タスクの上限に達した場合も429）",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "エラーが発生しました。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quick/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "音声アシスタント・ショートカット向けに、今日が期限・期限切れ・今日着手予定の未完了のタスクと未読通知数を読み上げ用の短いプレーンテキストで返します（タスクは5件まで）。quickスコープのAPIキーで認証し、キーごとにレート制限します",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "quick"
+                ],
+                "summary": "ショートカット向けの今日の予定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "今日が期限のタスクが1件あります。\\n・資料作成（18:00まで）",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "内容を読み取れませんでした。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "キーが無効です。アプリでキーを確認してください。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエストが多すぎます。少し待ってからお試しください。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "エラーが発生しました。",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotas/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーのリソースごとの使用量と上限を取得します（limitがnullのリソースは無制限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "自分の使用量",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaUsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotas/usage/groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループのリソースごとの使用量と上限を取得します（グループのメンバーのみ、limitがnullのリソースは無制限）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "グループの使用量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaUsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループのメンバーではない",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/QuotaErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "APIキーを発行した管理者がオーナーのグループを取得します。filterはdisplayName・externalIdのeqに対応します。グループのオーナーはmembersに含めません",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "グループ一覧（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "displayName eq \"Engineering\"",
+                        "description": "フィルター",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "開始位置（1始まり）",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "件数（上限1000）",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "フィルターが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "APIキーを発行した管理者をオーナーとするプロジェクトグループを作成し、membersのユーザーをメンバーとして追加します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "グループの作成（SCIM）",
+                "parameters": [
+                    {
+                        "description": "グループ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SCIMGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "409": {
+                        "description": "displayNameが使用済み",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "グループの取得（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMGroup"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループ名を変更し、オーナー以外のメンバーをmembersと一致させます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "グループの置き換え（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "グループ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SCIMGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "409": {
+                        "description": "displayNameが使用済み",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "グループの削除（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "displayNameの変更と、メンバーの追加（add members）・削除（remove members[value eq \"id\"]）・置き換え（replace members）を行います",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "グループの部分更新（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "PATCHの操作",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SCIMPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "グループが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "409": {
+                        "description": "displayNameが使用済み",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/ServiceProviderConfig": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "SCIM 2.0の対応機能を返します（PATCH・eqのフィルターに対応し、bulk・sort・etagには対応しません）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "SCIMの対応機能",
+                "responses": {
+                    "200": {
+                        "description": "対応機能",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ユーザーを取得します。filterはuserName・emails.value・externalIdのeqに対応します（externalIdは保存しないため常に0件）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "ユーザー一覧（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "userName eq \"taro\"",
+                        "description": "フィルター",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "開始位置（1始まり）",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "件数（上限1000）",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "フィルターが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "メールアドレスを確認済みのユーザーを作成します。メールアドレスはemailsのプライマリ（なければメールアドレス形式のuserName）を使います。passwordを省略した場合はランダムなパスワードを設定します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "ユーザーの作成（SCIM）",
+                "parameters": [
+                    {
+                        "description": "ユーザー",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SCIMUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMUser"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "409": {
+                        "description": "userNameまたはメールアドレスが使用済み",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "ユーザーの取得（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMUser"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "userNameとメールアドレスを置き換えます。active=falseでアカウントを無効化し、activeを省略した場合は変更しません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "ユーザーの置き換え（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ユーザー",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SCIMUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMUser"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "409": {
+                        "description": "userNameまたはメールアドレスが使用済み",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アカウントを無効化します。タスクなどのデータを残すためユーザーは削除せず、以降はactive=falseで返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "ユーザーの無効化（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "無効化成功"
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "active・userName・emailsを更新します。active=falseでアカウントを無効化します（ログイン・トークンの更新ができなくなります）。保存しない属性（nameなど）の操作は無視します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim"
+                ],
+                "summary": "ユーザーの部分更新（SCIM）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "PATCHの操作",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SCIMPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMUser"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "401": {
+                        "description": "APIキーが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "409": {
+                        "description": "userNameまたはメールアドレスが使用済み",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMError"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/blocks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分がブロックしたユーザーの一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "ブロックしたユーザー一覧取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ブロック一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/BlockedUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分の友達一覧を取得します（ページング・並び替え対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "友達一覧取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "recently_added",
+                            "username",
+                            "recently_active"
+                        ],
+                        "type": "string",
+                        "default": "recently_added",
+                        "description": "並び順（友達になった日時・ユーザー名・最終ログイン）",
+                        "name": "sort_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "友達一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/FriendsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "並び順が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/presence": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "友達のオンライン状態（ONLINE/AWAY/OFFLINE）と最終接続日時を取得します。user_idsを指定した場合は、友達・同じグループのメンバーのうち指定したユーザーのみを返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "友達のオンライン状態一括取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID（カンマ区切り、最大200件）",
+                        "name": "user_ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "オンライン状態取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/FriendsPresenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "オンライン状態が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/requests": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーに友達申請を送信します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "友達申請送信",
+                "parameters": [
+                    {
+                        "description": "友達申請情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SendFriendRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "友達申請送信成功",
+                        "schema": {
+                            "$ref": "#/definitions/FriendshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効（自分自身への申請など）",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ブロック関係にあるユーザー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "既に友達または申請済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/requests/received": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分宛の友達申請一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "受信した友達申請取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "友達申請一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PendingRequestsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/requests/sent": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が送信した友達申請一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "送信した友達申請取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "送信済み申請一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SentRequestsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/requests/{friendshipId}/accept": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "受信した友達申請を承認します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "友達申請承認",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "友達申請ID",
+                        "name": "friendshipId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "友達申請承認成功",
+                        "schema": {
+                            "$ref": "#/definitions/FriendshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "友達申請IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この申請を承認する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "友達申請が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/requests/{friendshipId}/decline": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "受信した友達申請を拒否します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "友達申請拒否",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "友達申請ID",
+                        "name": "friendshipId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "友達申請拒否成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "友達申請IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この申請を拒否する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "友達申請が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "友達関係を解除します。解除は取り消し期間（既定10秒）の経過後に実行され、その間はundo.undo_tokenをPOST /undo/{token}に送ると取り消せます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "友達削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "削除する友達のユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "友達削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/FriendRemovedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "友達関係が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/friends/{userId}/mutual": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーとの共通の友達を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "共通の友達取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "対象ユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "共通の友達取得成功",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/FriendWithUserInfoResponse"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "友達招待またはグループ招待を作成します。\ninvitee_emailを指定したグループ招待は、そのメールアドレスで新規登録したユーザーを自動的にグループに参加させます（招待できるのはグループの管理者のみ）\nグループ招待でgroup_role=GUESTを指定すると、受諾したユーザーはタスク・予定・統計の閲覧のみできるゲストとして参加します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待作成",
+                "parameters": [
+                    {
+                        "description": "招待作成情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "招待作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/InvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループへの招待権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "1日あたりの招待数が上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/code/{code}": {
+            "get": {
+                "description": "招待コードを使用して招待情報を取得します（パブリック情報のみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待コードから招待取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待コード",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待情報取得成功",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "created_at": {
+                                            "type": "string"
+                                        },
+                                        "expires_at": {
+                                            "type": "string"
+                                        },
+                                        "id": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        },
+                                        "method": {
+                                            "type": "string"
+                                        },
+                                        "status": {
+                                            "type": "string"
+                                        },
+                                        "type": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "招待コードが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "有効な招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "招待の有効期限が切れている",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/received": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が受信した招待一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "受信した招待一覧取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "受信済み招待一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvitationsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/sent": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が送信した招待一覧を取得します（ページング対応）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "送信した招待一覧取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "送信済み招待一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvitationsListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/{code}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "招待コードを使用して招待を受諾します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待受諾",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待コード",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待受諾成功",
+                        "schema": {
+                            "$ref": "#/definitions/InvitationResultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "招待コードが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "招待者とブロック関係にある",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "有効な招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "招待の有効期限が切れている",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/{invitationId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "招待の詳細情報を取得します（権限チェック付き）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待詳細取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待詳細取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/InvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "招待IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この招待を閲覧する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "送信した招待をキャンセルします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待キャンセル",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待キャンセル成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "招待IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この招待をキャンセルする権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/{invitationId}/decline": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "受信した招待を拒否します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待拒否",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待拒否成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "招待IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この招待を拒否する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/{invitationId}/qr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "招待URLをQRコード画像（PNG/SVG）で取得します。対面で招待を共有する場合に使用します（招待者のみ）",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待QRコード取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "画像形式",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 256,
+                        "description": "画像サイズ（ピクセル、64〜1024）",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QRコード画像",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "招待ID・形式・サイズが無効、または招待コードがない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この招待のQRコードを取得する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/{invitationId}/resend": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "送信した招待のメールを再送します。前回の送信から一定時間が経過するまで再送できません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待メール再送",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待メール再送成功",
+                        "schema": {
+                            "$ref": "#/definitions/InvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "招待IDが無効、メールアドレス未設定、送信上限到達、または招待が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この招待を再送する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "再送の待ち時間中",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "招待メールが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/invitations/{invitationId}/url": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された招待のURLを生成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待URL生成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "招待ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "招待URL生成成功",
+                        "schema": {
+                            "$ref": "#/definitions/InviteURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "招待IDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この招待のURLを生成する権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分の公開プロフィールの内容と公開範囲（PUBLIC: 全員 / FRIENDS: 友達のみ / PRIVATE: 自分のみ）を取得します。設定していない場合は全員に公開・統計は非公開です",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "公開プロフィールの設定取得",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/ProfileSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分の公開プロフィールの内容と公開範囲を変更します。省略した項目は変更しません。アイコンはhttpsのURLのみ指定できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "公開プロフィールの設定変更",
+                "parameters": [
+                    {
+                        "description": "変更内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProfileSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/ProfileSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/relationships/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーとの関係性（友達、ブロック、申請状況）を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "ユーザー間関係取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "対象ユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "関係性取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/UserRelationshipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/social/users/{userId}/block": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーをブロックします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "ユーザーブロック",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ブロックするユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ユーザーブロック成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーのブロックを解除します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "ブロック解除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ブロック解除するユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ブロック解除成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ブロック関係が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/changes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "カーソル（since）以降にログイン中のユーザーが参照できるタスク・友達関係・グループ・通知の変更を通し番号順に返します。\n削除や参照できなくなったエンティティは op=delete（tombstone）として返します。同じページ内で複数回変更されたエンティティは最後の変更だけを返します。\nsince を省略すると最初から、since=now を指定すると変更を返さずに最新のカーソルだけを返します。\nhas_more が true の間は next_cursor を since に指定して続きを取得してください。410 が返された場合は全件を取得し直してください",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "変更フィード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "前回のレスポンスの next_cursor、または now",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "取得件数（既定100、最大500）",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/SyncChangesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "カーソルが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "カーソルの保持期間切れ",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/push": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "オフライン中に行った変更（現在は entity_type=task の create・update・delete）をまとめて送信し、送信した順に適用します。最大100件です。\n作成時の entity_id はクライアントが生成したUUIDv7、更新・削除時の base_version は変更の元にした変更フィードの version を指定します。\nbase_version がサーバーの現在のバージョンと一致しない場合は適用せず、status=conflict とサーバーの現在の状態（data）を返します。\n内容が不正な変更や権限のない変更は status=rejected を返します。結果は client_mutation_id ごとに保持されるため、通信エラーの場合は同じ内容を再送してください",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "クライアントの変更の送信",
+                "parameters": [
+                    {
+                        "description": "クライアントの変更",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SyncPushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更ごとの適用結果",
+                        "schema": {
+                            "$ref": "#/definitions/SyncPushResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/SyncErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "フィルタリング、ページング、ソート機能付きでタスク一覧を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク一覧取得",
+                "parameters": [
+                    {
+                        "enum": [
+                            "TODO",
+                            "IN_PROGRESS",
+                            "DONE"
+                        ],
+                        "type": "string",
+                        "description": "ステータスフィルタ",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "LOW",
+                            "MEDIUM",
+                            "HIGH"
+                        ],
+                        "type": "string",
+                        "description": "優先度フィルタ",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "WORK",
+                            "PERSONAL",
+                            "STUDY",
+                            "HEALTH",
+                            "SHOPPING",
+                            "OTHER"
+                        ],
+                        "type": "string",
+                        "description": "カテゴリフィルタ",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "担当者IDフィルタ（いずれかの担当者に一致）",
+                        "name": "assignee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "assignee_idで指定した担当者の完了状態フィルタ",
+                        "name": "assignee_completed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "作成者IDフィルタ",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限日FROM",
+                        "name": "due_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限日TO",
+                        "name": "due_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "着手予定日FROM",
+                        "name": "start_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "着手予定日TO",
+                        "name": "start_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "this_week"
+                        ],
+                        "type": "string",
+                        "description": "着手予定日の期間指定（this_week: 今週（月曜開始）に着手予定）",
+                        "name": "starting",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "title",
+                            "priority",
+                            "status",
+                            "due_date",
+                            "start_date"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "ソートフィールド",
+                        "name": "sort_field",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "ソート方向",
+                        "name": "sort_direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しいタスクを作成します\ngroup_id を指定するとグループタスクとして作成します（グループでタスク作成の権限が必要）。assignee_id を省略した場合はグループの自動割り当て方式で担当者を割り当て、skip_auto_assign で無効にできます\nid にクライアントが生成したUUIDv7を指定すると、そのIDでタスクを作成します。同じIDで再送した場合は作成済みのタスクを返します\n直近14日間に作成したタスク（group_id 指定時はグループタスク）にタイトルが似ている未完了のタスクがある場合は作成せず、409 で候補を返します。force=true で作成します（id 指定時は確認しません）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク作成",
+                "parameters": [
+                    {
+                        "description": "タスク作成情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "タスク作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループでタスクを作成する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "タイトルが似ているタスクがある（指定したIDを別のユーザーが使用している場合は ErrorResponse）",
+                        "schema": {
+                            "$ref": "#/definitions/DuplicateTaskResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "未完了のタスク数が上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が作成・担当するタスクの着手予定日時と期限を、指定期間の日ごとにまとめて取得します（最大62日）。日の区切りはtimezone、省略時は勤務時間設定のタイムゾーンです",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "カレンダー取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "開始日 (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "終了日 (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "タイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/CalendarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/check-duplicate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "作成しようとしているタスクとタイトルが似ている最近の未完了のタスクを、類似度の高い順に最大5件返します。タスクは作成しません\ngroup_id を指定するとグループタスクから、省略すると自分が作成したタスクから探します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "重複タスクの確認",
+                "parameters": [
+                    {
+                        "description": "確認するタスク",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CheckDuplicateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "確認結果",
+                        "schema": {
+                            "$ref": "#/definitions/CheckDuplicateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループのメンバーでない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/classify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タイトル・説明に含まれるキーワードからタスクのカテゴリと優先度を推定します。タスクは作成しません（入力中のプレビュー用）\n推定できなかった場合は OTHER・MEDIUM を返し、確信度（category_confidence・priority_confidence）は0になります。クイック追加でも #タグ・!優先度を省略した場合に同じ推定を使用します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "カテゴリ・優先度の推定",
+                "parameters": [
+                    {
+                        "description": "推定するタスク",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ClassifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "推定結果",
+                        "schema": {
+                            "$ref": "#/definitions/ClassifyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/escalation-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分のエスカレーションルール、またはgroup_id指定時はグループのルールを取得します（グループはOWNER/ADMINのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "エスカレーションルール一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "group_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ルール一覧取得成功",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/EscalationRuleResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "期限切れタスクのエスカレーションルールを作成します。group_idを指定するとグループタスク向けのルールになります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "エスカレーションルール作成",
+                "parameters": [
+                    {
+                        "description": "ルール情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EscalationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ルール作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/EscalationRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/escalation-rules/{rule_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "エスカレーションルールを更新します（適用範囲は変更できません）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "エスカレーションルール更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ルールID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ルール情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EscalationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ルール更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/EscalationRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ルールが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "エスカレーションルールを削除します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "エスカレーションルール削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ルールID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ルール削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ルールが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が作成または担当するタスクを作成日時順にNDJSON（1行に1件のタスク、形式はタスク取得と同じ）で全件ダウンロードします。件数に関わらず逐次書き出します。途中でエラーになった場合は最終行に ExportErrorLine を出力します",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクのエクスポート",
+                "responses": {
+                    "200": {
+                        "description": "エクスポート成功（1行に1件）",
+                        "schema": {
+                            "$ref": "#/definitions/TaskResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/mentions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの説明・コメントで自分がメンションされた記録を新しい順に取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "自分へのメンション一覧取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/MentionListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/milestones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクトグループのマイルストーンを並び順で進捗（完了タスク数/タスク数）とともに取得します（グループのメンバーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "マイルストーン一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "グループID",
+                        "name": "group_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "マイルストーン一覧取得成功",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MilestoneResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "プロジェクトグループにマイルストーンを作成します（グループ編集の権限が必要、並び順は最後）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "マイルストーン作成",
+                "parameters": [
+                    {
+                        "description": "マイルストーン情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "マイルストーン作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/milestones/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループの全てのマイルストーンIDを新しい順序で指定して並べ替えます（グループ編集の権限が必要）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "マイルストーン並べ替え",
+                "parameters": [
+                    {
+                        "description": "並び順",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "並べ替え成功",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MilestoneResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/milestones/{milestone_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "マイルストーンを進捗とともに取得します（グループのメンバーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "マイルストーン取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "マイルストーンID",
+                        "name": "milestone_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "マイルストーン取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "マイルストーンが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "マイルストーンの名前・期限を更新します（グループ編集の権限が必要、group_idは無視されます）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "マイルストーン更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "マイルストーンID",
+                        "name": "milestone_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "マイルストーン情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "マイルストーン更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "マイルストーンが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "マイルストーンを削除します（グループ編集の権限が必要、紐付いていたタスクは削除されません）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "マイルストーン削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "マイルストーンID",
+                        "name": "milestone_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "マイルストーン削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "マイルストーンが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在認証されているユーザーに割り当てられたタスクを取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "自分のタスク取得",
+                "responses": {
+                    "200": {
+                        "description": "自分のタスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskCollectionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/nearby": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定した地点から半径内に場所がある未完了のタスク（自分が参照できるもの）を近い順に最大50件取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "近くのタスク",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "緯度",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "経度",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "半径（メートル、省略時は1000、最大50000）",
+                        "name": "radius",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/NearbyTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在認証されているユーザーが作成または担当する、期限が過ぎているタスクの一覧を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "期限切れタスク取得",
+                "responses": {
+                    "200": {
+                        "description": "期限切れタスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskCollectionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/quick-add": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "「レポート提出 明日 15時 #work !high」のような文字列を解析してタスクを作成します。preview=true の場合は解析結果のみを返します\n#タグ・!優先度を省略した場合はタイトルのキーワードからカテゴリ・優先度を推定し、category_suggested・priority_suggested で示します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "クイック追加",
+                "parameters": [
+                    {
+                        "description": "クイック追加情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/QuickAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解析結果（preview=true）",
+                        "schema": {
+                            "$ref": "#/definitions/QuickAddPreviewResult"
+                        }
+                    },
+                    "201": {
+                        "description": "タスク作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/QuickAddTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "未完了のタスク数が上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "キーワードでタスクを検索します（タイトルと説明が対象）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク検索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "検索クエリ",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "結果の最大数",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク検索成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "検索クエリが必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/share-links": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が作成した共有リンク（無効化・期限切れを含む）を新しい順に取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "共有リンク一覧取得",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/ShareLinkListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "条件で絞り込んだタスク一覧の読み取り専用公開リンクを作成します。対象は自分が作成したタスク（assigned_to_meがtrueの場合は自分が担当するタスク）に限られます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク一覧共有リンク作成",
+                "parameters": [
+                    {
+                        "description": "共有設定と絞り込み条件",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskListShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/ShareLinkCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "契約プランで共有リンクを利用できない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/share-links/{link_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が作成した共有リンクを無効化します。無効化後はリンクから閲覧できなくなります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "共有リンク無効化",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "共有リンクID",
+                        "name": "link_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "無効化成功",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "共有リンクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/burndown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "期間内の日ごとの未完了タスク数（その日に追加・完了した数と理想線を含む）を取得します。タスクの変更履歴から当時のステータスを再生して集計するため、過去の日の値は後の変更の影響を受けません。scope=userは自分が作成・担当するタスク、scope=groupはグループタスク（メンバーのみ）が対象です",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "バーンダウン取得",
+                "parameters": [
+                    {
+                        "enum": [
+                            "user",
+                            "group"
+                        ],
+                        "type": "string",
+                        "description": "対象（省略時は user）",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "グループID（scope=group の場合は必須）",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期間（日数または週数、省略時は 30d）",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/FlowBurndownResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが不正",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループのメンバーではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/category-breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクのカテゴリ別内訳統計を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "カテゴリ別統計取得",
+                "responses": {
+                    "200": {
+                        "description": "カテゴリ別統計取得成功",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "PERSONAL": {
+                                            "$ref": "#/definitions/CategoryBreakdownData"
+                                        },
+                                        "WORK": {
+                                            "$ref": "#/definitions/CategoryBreakdownData"
+                                        }
+                                    }
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/compare": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "今日・今週・今月の実績（完了数、作成数、期限切れ数、完了率）を、offset期間前の同じ経過時点までの実績と比較し、差と変化率を取得します。比較対象が0の場合、変化率はnullです",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "期間比較取得",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "week",
+                        "description": "比較の単位",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 52,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "何期間前と比較するか",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "期間比較取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/StatsComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/cumulative-flow": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "期間内の日ごとのステータス別タスク数（その日の終わり時点）を取得します。タスクの変更履歴から当時のステータスを再生して集計します。パラメータはバーンダウンと同じです",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "累積フロー図取得",
+                "parameters": [
+                    {
+                        "enum": [
+                            "user",
+                            "group"
+                        ],
+                        "type": "string",
+                        "description": "対象（省略時は user）",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "グループID（scope=group の場合は必須）",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期間（日数または週数、省略時は 30d）",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/CumulativeFlowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが不正",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "グループのメンバーではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/daily/{date}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された日付のタスク統計情報を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "指定日の統計取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "日付(YYYY-MM-DD形式)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "日次統計取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/DailyStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "日付形式が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ダッシュボード表示用の包括的な統計情報を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "ダッシュボード統計取得",
+                "responses": {
+                    "200": {
+                        "description": "ダッシュボード統計取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/DashboardStatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分が作成または担当するタスクを1行1件の集計用の項目（ステータス・期限切れ・期限後の完了・リードタイムなど）としてNDJSONで全件ダウンロードします。件数に関わらず逐次書き出します。途中でエラーになった場合は最終行に ExportErrorLine を出力します",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "統計のエクスポート",
+                "responses": {
+                    "200": {
+                        "description": "エクスポート成功（1行に1件）",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TaskStatsRecord"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/milestones/{milestone_id}/burndown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "マイルストーンの作成日から期限日（期限を過ぎている場合は今日）までの日ごとの未完了タスク数と理想線を取得します（グループのメンバーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "マイルストーンのバーンダウン取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "マイルストーンID",
+                        "name": "milestone_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "バーンダウン取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/MilestoneBurndownResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "マイルストーンが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/monthly": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された月のタスク統計情報を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "月次統計取得",
+                "parameters": [
+                    {
+                        "maximum": 3000,
+                        "minimum": 2000,
+                        "type": "integer",
+                        "default": 2024,
+                        "description": "年",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 12,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "月",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "月次統計取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/WeeklyStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/priority-breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの優先度別内訳統計を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "優先度別統計取得",
+                "responses": {
+                    "200": {
+                        "description": "優先度別統計取得成功",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "HIGH": {
+                                            "$ref": "#/definitions/PriorityBreakdownData"
+                                        },
+                                        "LOW": {
+                                            "$ref": "#/definitions/PriorityBreakdownData"
+                                        },
+                                        "MEDIUM": {
+                                            "$ref": "#/definitions/PriorityBreakdownData"
+                                        }
+                                    }
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/progress-level": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "完了率に基づく進捗レベル情報を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "進捗レベル取得",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "完了率(0-100)",
+                        "name": "rate",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "進捗レベル取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/ProgressLevelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "完了率パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/progress-summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された日数分の進捗サマリーを取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "進捗サマリー取得",
+                "parameters": [
+                    {
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 7,
+                        "description": "対象日数",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "進捗サマリー取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/ProgressSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/reports/subscription": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "週次レポートのメール配信の購読状況を取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "週次レポートの配信購読取得",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/WeeklyReportSubscriptionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "購読していない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "毎週月曜日8時（timezone）以降に前週のレポートをメールで受け取ります。メールにはPDFが添付されます。購読済みの場合はタイムゾーンを更新します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "週次レポートの配信購読",
+                "parameters": [
+                    {
+                        "description": "配信のタイムゾーン",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/WeeklyReportSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "購読成功",
+                        "schema": {
+                            "$ref": "#/definitions/WeeklyReportSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "タイムゾーンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "週次レポートのメール配信を解除します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "週次レポートの配信解除",
+                "responses": {
+                    "200": {
+                        "description": "解除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "購読していない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/reports/weekly/{week}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定したISO週（月曜日〜日曜日）の完了したタスク、遅れたタスク、作業時間、よく取り組んだカテゴリをまとめたレポートを取得します。formatでJSON・HTML・PDFを選べます（PDFはダウンロード用）。週の区切りはtimezone、省略時は勤務時間設定のタイムゾーンです",
+                "produces": [
+                    "application/json",
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "週次レポート取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO週 (YYYY-Www)",
+                        "name": "week",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "html",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（省略時は json）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タイムゾーン（省略時は勤務時間設定のタイムゾーン）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功（format=html・pdf の場合はレポートのHTML・PDF）",
+                        "schema": {
+                            "$ref": "#/definitions/WeeklyReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "週の形式が不正、または未来の週",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "任意の期間の実績（完了数、作成数、期限のあるタスク数、期限切れ数、完了率）を日・週・月ごとに取得します。期間は区間の境界（週は月曜日、月は1日）に広げ、タスクのない区間も0で返します。rollingを指定すると完了数・作成数の移動平均（期間より前の区間も含めた直近rolling区間の平均）を付けます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "時系列統計取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "開始日（YYYY-MM-DD）",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "終了日（YYYY-MM-DD、この日を含む）",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "集計単位",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 30,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "移動平均の区間数（0は付けない）",
+                        "name": "rolling",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "区間の区切りに使うタイムゾーン（省略時は UTC）",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "時系列統計取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/StatsSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "本日のタスク統計情報を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "今日の統計取得",
+                "responses": {
+                    "200": {
+                        "description": "今日の統計取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/DailyStatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/velocity": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "直近の週ごと・カテゴリごとの完了タスク数、完了ポイント、見積もり精度を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "ベロシティレポート取得",
+                "parameters": [
+                    {
+                        "maximum": 52,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 8,
+                        "description": "対象週数（今週を含む）",
+                        "name": "weeks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ベロシティレポート取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/VelocityReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/stats/weekly": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定された週のタスク統計情報を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "週次統計取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "基準日付(YYYY-MM-DD形式)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "週次統計取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/WeeklyStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "日付形式が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーに割り当てられたタスクを取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "特定ユーザーのタスク取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ユーザータスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/workload": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "担当中の未完了タスクの見積もり工数を日ごとに集計し、キャパシティ超過日を返します。他ユーザーの作業量はグループ管理者がgroup_idを指定した場合のみ参照できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "作業量取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "対象ユーザーID（省略時は自分）",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "グループID（他ユーザー参照時に必須）",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "開始日 (YYYY-MM-DD、省略時は今日)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "終了日 (YYYY-MM-DD、省略時は開始日から6日後)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/WorkloadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/workload/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分の勤務時間・キャパシティ設定を取得します（未設定の場合は平日9:00-18:00）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "勤務時間設定取得",
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/WorkingHoursResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "自分の勤務時間・キャパシティ設定を更新します。daily_capacity_minutesが0の場合は勤務時間から算出します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "勤務時間設定更新",
+                "parameters": [
+                    {
+                        "description": "勤務時間設定",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WorkingHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/WorkingHoursResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのタスクを取得します。format=html を指定すると、Markdownの説明を安全なHTMLに変換した description_html を追加します。説明中のリンクは取得済みのプレビュー（OpenGraph）を link_previews で返します（未取得のリンクはバックグラウンドで取得します）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "説明の形式（html で description_html を追加）",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskGetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのタスクを更新します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "タスク更新情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのタスクを削除します。削除は取り消し期間（既定10秒）の経過後に実行され、その間はundo.undo_tokenをPOST /undo/{token}に送ると取り消せます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeletedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを削除する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assign": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたタスクに担当者を追加します（assignee_id・assignee_idsのいずれかまたは両方を指定）。require_all_assigneesをtrueにすると全担当者の完了でタスクが完了になります。担当者が期限日にキャパシティ超過となる場合はwarningsを返します（割り当ては行われます）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク割り当て",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "割り当て情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AssignTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク割り当て成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskAssignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクまたはユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "既に割り当て済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assignees/me/completion": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログインユーザーの担当分を完了または未完了にします。全員完了が必要なタスクは全担当者の完了でタスクが完了になり、それ以外は最初の完了でタスクが完了になります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "自分の担当分の完了状態変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "完了状態",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AssignmentCompletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "完了状態変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない、または担当者ではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assignees/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーをタスクの担当者から外します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク担当者の解除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "担当者ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "担当者解除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクまたは担当者が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの添付ファイルをアップロードした順に取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "添付ファイル一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/AttachmentListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクにファイルを添付します（multipart/form-data の file）。ファイルの形式は内容から判定し、画像（JPEG・PNG・GIF）の場合はサムネイルを非同期に生成します（thumbnail_status が READY になると取得できます）。タスクの作成者・担当者と、グループタスクの場合はグループのメンバーが添付できます",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "添付ファイルのアップロード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "添付するファイル",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "アップロード成功",
+                        "schema": {
+                            "$ref": "#/definitions/AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "ファイルが大きすぎる",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "添付ファイルの合計サイズの上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクのコメントを投稿順に取得します。format=html を指定すると、Markdownのコメントを安全なHTMLに変換した comment_html を追加します。コメント中のリンクは取得済みのプレビューを link_previews で返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "コメント一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "コメントの形式（html で comment_html を追加）",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/CommentListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを閲覧する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクにコメントを投稿します。本文中の@usernameは、友達または同じグループのメンバーであればメンションとして記録され通知されます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "コメント投稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "コメント",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "投稿成功",
+                        "schema": {
+                            "$ref": "#/definitions/CommentCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/dependencies": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクがdepends_on_idのタスクの完了後に開始する依存関係を追加します。2つのタスクは同じグループのグループタスクである必要があります（グループでタスク編集の権限が必要）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの依存関係追加",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "依存先のタスク",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskDependencyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "依存関係追加成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "依存関係が循環する",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/dependencies/{depends_on_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの依存関係を削除します（グループでタスク編集の権限が必要）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの依存関係削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "依存先のタスクID",
+                        "name": "depends_on_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "依存関係削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "依存関係が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/estimate": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの見積もり（分・ポイント）と実績工数（分）を更新します。指定したフィールドのみ更新されます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク見積もり・実績更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "見積もり・実績",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskEstimateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/geofences": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの場所の周囲にジオフェンスを登録します（半径100〜5000m、1ユーザーあたり20件まで、同じタスクには1つ）。モバイル端末は GET /geofences で取得した領域を監視し、範囲に入ったら POST /geofences/{id}/enter で報告します。タスクに場所が設定されている必要があります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "ジオフェンスの登録",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ジオフェンスの半径",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/GeofenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "登録成功",
+                        "schema": {
+                            "$ref": "#/definitions/GeofenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効、または登録数の上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない、または場所が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "タスクにジオフェンスを登録済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの変更イベントを再生し、指定した時点のタスクの状態と、その状態にした最後の変更（変更者・日時・変更したフィールド）を取得します。タスクの作成者・担当者と、グループタスクの場合はグループのメンバーが参照できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの変更履歴の再生",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "再生する時点（RFC3339、省略時は現在）",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスク・指定した時点の履歴が見つからない、または機能が公開されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/links": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ユーザーが追加したリンク、関連付けたGitHubのIssue・Pull Request、グループの予定（アプリ内のパス）を追加・関連付けた順に取得します。連携のリンクは managed がtrueで、stateに連携先の状態が入ります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクのリンク一覧",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskLinkListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクにhttp・httpsのURLを種類（LINK・DOCUMENT・DESIGN）とタイトル付きで追加します（1タスクにつき50件まで）。GitHubのIssue・Pull RequestのURL（または \"owner/name#123\"）は、typeを省略するかGITHUB_ISSUE・GITHUB_PULL_REQUESTを指定するとGitHubから取得して関連付け（1タスクにつき20件まで）、complete_on_closeがtrueの場合はPull Requestのマージ・Issueの完了でタスクが完了になります。登録したGitHubのトークンで取得するため、トークンで参照できる非公開のリポジトリも指定できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクへのリンクの追加",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "リンク",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "追加成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効、GitHubのトークンが無効、または上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスク、またはIssue・Pull Requestが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "追加・関連付け済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "GitHubに接続できない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/links/{linkId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ユーザーが追加したリンクを削除します。GitHubのIssue・Pull Requestの場合は関連付けを解除します。予定のリンクは予定の画面から解除してください",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクのリンクの削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "リンクのID",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスク、またはリンクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ユーザーが追加したリンクの種類・URL・タイトルを変更します。GitHub・予定の連携のリンクは変更できません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクのリンクの変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "リンクのID",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "変更する項目",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskLinkUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスク、またはリンクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "同じURLのリンクを追加済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/location": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクに設定した場所（名前と緯度・経度）を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの場所",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskLocationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない、または場所が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクに場所（名前と緯度・経度）を設定します。設定済みの場合は置き換え、登録済みのジオフェンスの中心も新しい場所に移ります。グループタスクの場所はグループの予定の場所として表示されます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの場所の設定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "場所",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "設定成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskLocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの場所を削除します。タスクに登録されたジオフェンスもすべて削除されます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの場所の削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "削除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない、または場所が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/milestone": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "グループタスクをそのグループのマイルストーンに紐付けます。milestone_idにnullを指定すると解除します（グループでタスク編集の権限が必要）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクのマイルストーン設定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "マイルストーンID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskMilestoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "設定成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクまたはマイルストーンが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの着手予定日時と期限を更新します。指定したフィールドのみ更新され、clear_start_dateで着手予定日時を消去できます。着手予定日時は期限以前である必要があります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクの着手予定日時・期限更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "着手予定日時・期限",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/share-links": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの読み取り専用公開リンクを作成します（作成者・担当者のみ）。有効期限とパスワードを任意で設定できます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスク共有リンク作成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "共有設定",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/ShareLinkCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "契約プランで共有リンクを利用できない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたタスクのステータスを変更します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "タスクステータス変更",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ステータス変更情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangeStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ステータス変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/undo/{token}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスク削除・友達削除・グループメンバー削除で返された取り消し用のトークンを送ると、取り消し期間中であれば操作を取り消します（操作したユーザーのみ）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "undo"
+                ],
+                "summary": "操作の取り消し",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "取り消し用のトークン",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取り消し成功",
+                        "schema": {
+                            "$ref": "#/definitions/UndoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/UndoErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "トークンが見つからない・取り消し期間が過ぎた",
+                        "schema": {
+                            "$ref": "#/definitions/UndoErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/public": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ユーザー名の公開プロフィール（表示名・アイコン・自己紹介・公開している場合は連続達成日数などの統計）を取得します。ログインしていなくても取得できます。公開範囲外の場合はユーザー名のみを返し、restrictedをtrueにします。ログインしている場合はviewerに閲覧者との関係と友達申請を送れるかを含めます",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "公開プロフィール取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザー名",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/PublicProfileResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/email/bounces": {
+            "post": {
+                "description": "メール配信サービスからのバウンス通知を受け取り、招待を配信不能（UNDELIVERABLE）にします。X-Webhook-Secretヘッダーで認証します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "social"
+                ],
+                "summary": "招待メールのバウンス通知",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhookシークレット",
+                        "name": "X-Webhook-Secret",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "バウンス情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InvitationEmailBounceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "バウンス処理成功",
+                        "schema": {
+                            "$ref": "#/definitions/SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Webhookシークレットが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "招待が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Webhookが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/email/inbound": {
+            "post": {
+                "description": "MailgunのInbound Routes（forward）で転送されたメールを受信し、宛先のメールアドレスのユーザーのタスクを作成します。timestamp・token・signatureの署名を検証します。宛先・差出人・スパム判定・重複・上限で受け付けなかったメールも、再送されないように200でstatusを返します",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "メールの受信のWebhook",
+                "responses": {
+                    "200": {
+                        "description": "受信成功",
+                        "schema": {
+                            "$ref": "#/definitions/InboundEmailWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ペイロードが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "署名が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "メールの受信が設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/github": {
+            "post": {
+                "description": "GitHubのissues・pull_requestイベントを受信し、関連付けたタスクにIssue・Pull Requestの状態を反映します。Pull Requestのマージ・Issueの完了で、complete_on_closeの関連付けのタスクを完了にします。X-Hub-Signature-256ヘッダーの署名を検証します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "GitHubのWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "イベントの種類（issues, pull_request, ping）",
+                        "name": "X-GitHub-Event",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhookシークレットによる署名",
+                        "name": "X-Hub-Signature-256",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "受信成功",
+                        "schema": {
+                            "$ref": "#/definitions/GitHubWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ペイロードが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "署名が無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Webhookが設定されていない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "created_by": {
+                    "description": "キーを発行したユーザー（SCIMで作成するグループのオーナー、quick・clipスコープで操作するユーザーになる）",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "display_prefix": {
+                    "description": "キーの先頭部分（どのキーか見分けるために表示する）",
+                    "type": "string",
+                    "example": "yp_AbCdEfG"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Okta provisioning"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "scim"
+                    ]
+                }
+            }
+        },
+        "APIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/APIKey"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AddMemberRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "OWNER",
+                        "ADMIN",
+                        "MEMBER"
+                    ],
+                    "example": "MEMBER"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "AdminAccountMerge": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "$ref": "#/definitions/AdminMergeCounts"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "merged_at": {
+                    "type": "string"
+                },
+                "primary": {
+                    "$ref": "#/definitions/AdminMergeAccount"
+                },
+                "secondary": {
+                    "$ref": "#/definitions/AdminMergeAccount"
+                }
+            }
+        },
+        "AdminAccountMergeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminAccountMerge"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AdminActiveUsers": {
+            "type": "object",
+            "properties": {
+                "dau": {
+                    "description": "直近24時間にログインしたユーザー数",
+                    "type": "integer",
+                    "example": 320
+                },
+                "mau": {
+                    "description": "直近30日間にログインしたユーザー数",
+                    "type": "integer",
+                    "example": 1300
+                },
+                "wau": {
+                    "description": "直近7日間にログインしたユーザー数",
+                    "type": "integer",
+                    "example": 910
+                }
+            }
+        },
+        "AdminActiveUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminActiveUsers"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AdminDailyCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-06-01"
+                }
+            }
+        },
+        "AdminDashboard": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "$ref": "#/definitions/AdminActiveUsers"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-05-03"
+                },
+                "notifications": {
+                    "$ref": "#/definitions/AdminNotificationDelivery"
+                },
+                "storage": {
+                    "$ref": "#/definitions/AdminStorageUsage"
+                },
+                "tasks": {
+                    "$ref": "#/definitions/AdminTaskCreation"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-06-01"
+                },
+                "users": {
+                    "$ref": "#/definitions/AdminUserGrowth"
+                }
+            }
+        },
+        "AdminDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminDashboard"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AdminErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "REQUEST_ERROR"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "days must be between 1 and 365"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "AdminMergeAccount": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "email": {
+                    "type": "string",
+                    "example": "taro@example.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6f1c2a7e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "taro"
+                }
+            }
+        },
+        "AdminMergeAccountsRequest": {
+            "type": "object",
+            "required": [
+                "primary_user_id",
+                "secondary_user_id"
+            ],
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "primary_user_id": {
+                    "type": "string",
+                    "example": "6f1c2a7e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+                },
+                "secondary_user_id": {
+                    "type": "string",
+                    "example": "0a9b8c7d-6e5f-4a3b-2c1d-0e9f8a7b6c5d"
+                }
+            }
+        },
+        "AdminMergeCounts": {
+            "type": "object",
+            "properties": {
+                "assigned_tasks": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "created_tasks": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "duplicate_friendships": {
+                    "description": "統合先との友達関係を含む",
+                    "type": "integer",
+                    "example": 2
+                },
+                "duplicate_group_memberships": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "duplicate_task_assignees": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "friendships": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "group_memberships": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "notifications": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "owned_groups": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sso_identities": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "task_assignees": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "AdminNotificationDelivery": {
+            "type": "object",
+            "properties": {
+                "delivered": {
+                    "description": "SENT・READ",
+                    "type": "integer",
+                    "example": 9800
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "failure_rate": {
+                    "description": "配信を試みた通知のうち失敗した割合",
+                    "type": "number",
+                    "example": 0.015
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 10000
+                }
+            }
+        },
+        "AdminNotificationDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminNotificationDelivery"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AdminStorageUsage": {
+            "type": "object",
+            "properties": {
+                "attachment_bytes": {
+                    "type": "integer",
+                    "example": 104857600
+                },
+                "database_bytes": {
+                    "type": "integer",
+                    "example": 20480000
+                },
+                "tables": {
+                    "description": "使用量の多い順",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminTableUsage"
+                    }
+                }
+            }
+        },
+        "AdminStorageUsageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminStorageUsage"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AdminTableUsage": {
+            "type": "object",
+            "properties": {
+                "data_bytes": {
+                    "type": "integer",
+                    "example": 16384000
+                },
+                "index_bytes": {
+                    "type": "integer",
+                    "example": 4096000
+                },
+                "rows": {
+                    "description": "概算（データベースの統計情報）",
+                    "type": "integer",
+                    "example": 52000
+                },
+                "table": {
+                    "type": "string",
+                    "example": "tasks"
+                }
+            }
+        },
+        "AdminTaskCreation": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminDailyCount"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 4210
+                }
+            }
+        },
+        "AdminTaskCreationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminTaskCreation"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AdminUserGrowth": {
+            "type": "object",
+            "properties": {
+                "new_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminDailyCount"
+                    }
+                },
+                "total_users": {
+                    "type": "integer",
+                    "example": 1520
+                }
+            }
+        },
+        "AdminUserGrowthResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AdminUserGrowth"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AnalyticsErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "REQUEST_ERROR"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "AnalyticsPreference": {
+            "type": "object",
+            "properties": {
+                "opted_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "AnalyticsPreferenceRequest": {
+            "type": "object",
+            "required": [
+                "opted_out"
+            ],
+            "properties": {
+                "opted_out": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AnalyticsPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/AnalyticsPreference"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AssignTaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "assignee_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "require_all_assignees": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AssignmentCompletionRequest": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AssignmentSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_assignee_id": {
+                    "description": "直前に自動割り当てしたメンバー",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "policy": {
+                    "type": "string",
+                    "example": "ROUND_ROBIN"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "AttachmentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Attachment"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Attachment"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "BillingCheckoutRequest": {
+            "type": "object",
+            "required": [
+                "plan_id"
+            ],
+            "properties": {
+                "plan_id": {
+                    "type": "string",
+                    "example": "pro"
+                }
+            }
+        },
+        "BillingCheckoutResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/BillingCheckoutSession"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "BillingCheckoutSession": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "cs_test_a1b2c3"
+                },
+                "url": {
+                    "description": "支払い画面のURL（フロントエンドはこのURLにリダイレクトする）",
+                    "type": "string",
+                    "example": "https://checkout.stripe.com/c/pay/cs_test_a1b2c3"
+                }
+            }
+        },
+        "BillingErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "ALREADY_SUBSCRIBED"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "already subscribed to a paid plan"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "BillingPlan": {
+            "type": "object",
+            "properties": {
+                "entitlements": {
+                    "description": "利用できる機能",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "recurring_tasks",
+                        "share_links"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "pro"
+                },
+                "limits": {
+                    "description": "リソースごとの上限（含まれないリソースは無制限）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pro"
+                },
+                "purchasable": {
+                    "description": "チェックアウトで購入できるか（Stripeの価格が設定された有料プラン）",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "BillingPlanListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BillingPlan"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "BillingSubscription": {
+            "type": "object",
+            "properties": {
+                "cancel_at_period_end": {
+                    "description": "trueの場合、現在の期間の終了時に解約される",
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "current_period_end": {
+                    "type": "string",
+                    "example": "2024-07-01T00:00:00Z"
+                },
+                "plan_id": {
+                    "type": "string",
+                    "example": "pro"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "BillingSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/BillingSubscriptionState"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "BillingSubscriptionState": {
+            "type": "object",
+            "properties": {
+                "plan": {
+                    "$ref": "#/definitions/BillingPlan"
+                },
+                "subscription": {
+                    "description": "有料プランの契約（契約したことがない場合はnull）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/BillingSubscription"
+                        }
+                    ]
+                }
+            }
+        },
+        "BlockedUsersResponse": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FriendWithUserInfoResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/PaginationInfo"
+                }
+            }
+        },
+        "BulkAddMemberResult": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ADDED",
+                        "ALREADY_MEMBER",
+                        "NOT_FOUND",
+                        "BLOCKED"
+                    ],
+                    "example": "ADDED"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "BulkAddMembersRequest": {
+            "type": "object",
+            "required": [
+                "user_ids"
+            ],
+            "properties": {
+                "role": {
+                    "description": "ADMIN・MEMBER・GUEST またはカスタムロールのID（省略時は MEMBER）",
+                    "type": "string",
+                    "example": "MEMBER"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174000"
+                    ]
+                }
+            }
+        },
+        "BulkAddMembersResponse": {
+            "type": "object",
+            "properties": {
+                "added_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BulkAddMemberResult"
+                    }
+                }
+            }
+        },
+        "CalendarResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Calendar"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CategoryBreakdownData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#3b82f6"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "仕事"
+                }
+            }
+        },
+        "ChangeOccurrenceRequest": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "description": "true の場合はこの回を中止する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
+                    "description": "変更後の開始日時（中止しない場合は必須）",
+                    "type": "string",
+                    "example": "2024-01-09T10:00:00Z"
+                }
+            }
+        },
+        "ChangeStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "TODO",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "IN_PROGRESS"
+                }
+            }
+        },
+        "ChatIntegrationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "due_reminder_hours": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "TASK_COMPLETED",
+                        "TASK_ASSIGNED",
+                        "TASK_DUE_SOON",
+                        "DAILY_SUMMARY"
+                    ]
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_summary_date": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "DISCORD",
+                        "TEAMS"
+                    ],
+                    "example": "DISCORD"
+                },
+                "summary_hour": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "templates": {
+                    "description": "出来事ごとのメッセージのテンプレート（デフォルトを含む）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "webhook_url": {
+                    "description": "末尾のトークンを伏せたURL",
+                    "type": "string",
+                    "example": "https://discord.com/api/webhooks/123456789/****"
+                }
+            }
+        },
+        "CheckDuplicateRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "days": {
+                    "description": "候補とするタスクの作成日の期間（省略時は14日）",
+                    "type": "integer",
+                    "maximum": 90,
+                    "minimum": 1,
+                    "example": 14
+                },
+                "group_id": {
+                    "description": "指定するとグループタスクから探す",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "週次レポートを提出する"
+                }
+            }
+        },
+        "CheckDuplicateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "duplicates": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/DuplicateCandidate"
+                            }
+                        },
+                        "has_duplicates": {
+                            "type": "boolean",
+                            "example": true
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "text": {
+                    "type": "string",
+                    "example": "資料を印刷する"
+                }
+            }
+        },
+        "Classification": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Category"
+                        }
+                    ],
+                    "example": "WORK"
+                },
+                "category_confidence": {
+                    "description": "0〜1（0の場合は推定なし）",
+                    "type": "number",
+                    "example": 0.75
+                },
+                "keywords": {
+                    "description": "推定の根拠になったキーワード",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "会議",
+                        "至急"
+                    ]
+                },
+                "priority": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Priority"
+                        }
+                    ],
+                    "example": "HIGH"
+                },
+                "priority_confidence": {
+                    "description": "0〜1（0の場合は推定なし）",
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "ClassifyRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "来週の会議までに"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "至急 取引先への見積を送る"
+                }
+            }
+        },
+        "ClassifyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/Classification"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ClipData": {
+            "type": "object",
+            "properties": {
+                "link": {
+                    "description": "リンクの追加に失敗した場合は含めない（タスクは作成済み）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/TaskLink"
+                        }
+                    ]
+                },
+                "task": {
+                    "$ref": "#/definitions/TaskResponse"
+                }
+            }
+        },
+        "ClipRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "note": {
+                    "description": "添えるメモ（1000バイトまで、説明の先頭に入れる）",
+                    "type": "string",
+                    "example": "金曜までに読む"
+                },
+                "selection": {
+                    "description": "ページで選択していたテキスト（10000バイトまで、Markdownの引用として説明に入れる）",
+                    "type": "string",
+                    "example": "あとで確認したい一文"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "記事のタイトル"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/articles/123"
+                }
+            }
+        },
+        "ClipResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/ClipData"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CommentCreateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "comment": {
+                            "$ref": "#/definitions/domain.TaskComment"
+                        },
+                        "mentions": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Mention"
+                            }
+                        }
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Comment added successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CommentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "comments": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CommentResponse"
+                            }
+                        },
+                        "page": {
+                            "type": "integer",
+                            "example": 1
+                        },
+                        "page_size": {
+                            "type": "integer",
+                            "example": 10
+                        },
+                        "total_count": {
+                            "type": "integer",
+                            "example": 5
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CommentRequest": {
+            "type": "object",
+            "required": [
+                "comment"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "@alice レビューをお願いします"
+                }
+            }
+        },
+        "CommentResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "comment_html": {
+                    "description": "format=html を指定した場合のみ設定する",
+                    "type": "string",
+                    "example": "\u003cp\u003e\u003cstrong\u003e確認\u003c/strong\u003eしました\u003c/p\u003e"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "link_previews": {
+                    "description": "コメント中のリンクの取得済みのプレビュー",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LinkPreview"
+                    }
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Okta provisioning"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "scim"
+                    ]
+                }
+            }
+        },
+        "CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/CreatedAPIKey"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CreateClipTokenRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Chrome（仕事用PC）"
+                }
+            }
+        },
+        "CreateFollowUpTaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "description": "省略時は作成したメンバー",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "description": {
+                    "description": "予定へのリンクを追記する",
+                    "type": "string",
+                    "maxLength": 1500,
+                    "example": "決定事項をまとめて共有する"
+                },
+                "title": {
+                    "description": "省略時は「予定のタイトル」のフォローアップ",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "議事録を共有する"
+                }
+            }
+        },
+        "CreateGroupRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "新製品開発プロジェクトのチーム"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "プロジェクトチーム"
+                },
+                "parent_group_id": {
+                    "description": "サブチームとして作成する場合の親グループ",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.GroupSettings"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "PROJECT",
+                        "SCHEDULE"
+                    ],
+                    "example": "PROJECT"
+                }
+            }
+        },
+        "CreateInvitationRequest": {
+            "type": "object",
+            "required": [
+                "method",
+                "type"
+            ],
+            "properties": {
+                "expires_hours": {
+                    "type": "integer",
+                    "maximum": 168,
+                    "minimum": 1,
+                    "example": 168
+                },
+                "group_role": {
+                    "description": "グループ招待で参加するときのロール（GUESTは閲覧のみ、省略時はMEMBER）",
+                    "type": "string",
+                    "enum": [
+                        "MEMBER",
+                        "GUEST"
+                    ],
+                    "example": "GUEST"
+                },
+                "invitee_email": {
+                    "type": "string",
+                    "example": "friend@example.com"
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "一緒にYotei+を使いませんか？"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "IN_APP",
+                        "CODE",
+                        "URL"
+                    ],
+                    "example": "CODE"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "FRIEND",
+                        "GROUP"
+                    ],
+                    "example": "FRIEND"
+                }
+            }
+        },
+        "CreateNotificationRequest": {
+            "type": "object",
+            "required": [
+                "channels",
+                "message",
+                "title",
+                "type",
+                "user_id"
+            ],
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"app\"",
+                        "\"line\"]"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "「重要なプロジェクト」タスクが割り当てられました"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "\"priority\"": "\"HIGH\"}",
+                        "{\"task_id\"": "\"task-123\""
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "新しいタスクが割り当てられました"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "TASK_ASSIGNED"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "CreateNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/NotificationResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CreateShortcutKeyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "iPhoneのショートカット"
+                }
+            }
+        },
+        "CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "created_by": {
+                    "description": "キーを発行したユーザー（SCIMで作成するグループのオーナー、quick・clipスコープで操作するユーザーになる）",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "display_prefix": {
+                    "description": "キーの先頭部分（どのキーか見分けるために表示する）",
+                    "type": "string",
+                    "example": "yp_AbCdEfG"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "yp_AbCdEfGhIjKlMnOpQrStUvWxYz0123456789abcdefg"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Okta provisioning"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "scim"
+                    ]
+                }
+            }
+        },
+        "CumulativeFlowResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.CumulativeFlow"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "CustomRoleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "タスク管理者"
+                },
+                "permissions": {
+                    "description": "付与するアクション（権限設定で変更できるもののみ）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CREATE_TASKS",
+                        "EDIT_TASKS"
+                    ]
+                }
+            }
+        },
+        "CustomRoleResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "メンバーに割り当てるときのロール値",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "タスク管理者"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CREATE_TASKS",
+                        "EDIT_TASKS"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "CustomRolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CustomRoleResponse"
+                    }
+                }
+            }
+        },
+        "DailyPreviewData": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "has_overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "task_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "DailyStatsData": {
+            "type": "object",
+            "properties": {
+                "completed_tasks": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "completion_rate": {
+                    "type": "number",
+                    "example": 70
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "in_progress_tasks": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "overdue_tasks": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "todo_tasks": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_tasks": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "DailyStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/DailyStatsData"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "DashboardStatsData": {
+            "type": "object",
+            "properties": {
+                "category_breakdown": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "overdue_tasks_count": {
+                    "type": "integer"
+                },
+                "priority_breakdown": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "recent_completions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TaskSummary"
+                    }
+                },
+                "today_stats": {
+                    "$ref": "#/definitions/DailyStatsData"
+                },
+                "upcoming_week_tasks": {
+                    "$ref": "#/definitions/WeeklyPreviewData"
+                },
+                "weekly_overview": {
+                    "$ref": "#/definitions/WeeklyStatsData"
+                }
+            }
+        },
+        "DashboardStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/DashboardStatsData"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "DataExportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "EXPORT_NOT_READY"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "export is not ready"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "DataExportListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Export"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "DataExportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Export"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "DataImportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/DataImportResult"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "DataImportResult": {
+            "type": "object",
+            "properties": {
+                "exported_at": {
+                    "type": "string"
+                },
+                "format_version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DataImportSectionResult"
+                    }
+                },
+                "source_user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "DataImportSectionResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "description": "新しく復元した件数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "invalid": {
+                    "description": "内容が不正で復元できなかった件数",
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "tasks"
+                },
+                "skipped": {
+                    "description": "復元済み・復元の対象外の件数",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "DeadLetterResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.DeadLetter"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "similarity": {
+                    "description": "タイトルの類似度（0〜1）",
+                    "type": "number",
+                    "example": 0.82
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TaskStatus"
+                        }
+                    ],
+                    "example": "TODO"
+                },
+                "task_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "週次レポートを提出する"
+                }
+            }
+        },
+        "DuplicateTaskResponse": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DuplicateCandidate"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "DUPLICATE_TASK"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Similar tasks already exist"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "リクエストが無効です"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "EscalationRuleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "24時間超過で優先度を上げる"
+                },
+                "notify_assignee": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notify_group_admins": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "overdue_hours": {
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 0,
+                    "example": 24
+                },
+                "raise_priority": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reassign_to": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "EscalationRuleResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "24時間超過で優先度を上げる"
+                },
+                "notify_assignee": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notify_group_admins": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "overdue_hours": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "owner_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "raise_priority": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reassign_to": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "USER"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "EventAttendeeResponse": {
+            "type": "object",
+            "properties": {
+                "attended": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "responded_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "NO_RESPONSE"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "EventAttendeesResponse": {
+            "type": "object",
+            "properties": {
+                "attendees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/EventAttendeeResponse"
+                    }
+                },
+                "event": {
+                    "$ref": "#/definitions/GroupEventResponse"
+                },
+                "maybe": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "no": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "no_response": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "yes": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "EventDetailResponse": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/GroupEventResponse"
+                },
+                "links": {
+                    "description": "関連付けたタスク（作成日時の古い順）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/EventTaskLinkResponse"
+                    }
+                }
+            }
+        },
+        "EventExceptionResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "original_starts_at": {
+                    "type": "string",
+                    "example": "2024-01-08T10:00:00Z"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-09T10:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "EventLocationResponse": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "example": 35.681236
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 139.767125
+                },
+                "name": {
+                    "type": "string",
+                    "example": "東京駅"
+                }
+            }
+        },
+        "EventNoteRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Markdown、未完了のタスクリストの行からタスクを作成する",
+                    "type": "string",
+                    "maxLength": 20000,
+                    "example": "## 決定事項\n- [ ] 資料を共有する @alice"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "定例会の議事録"
+                }
+            }
+        },
+        "EventNoteResponse": {
+            "type": "object",
+            "properties": {
+                "action_items": {
+                    "description": "本文のアクションアイテムから作成したタスク",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/NoteActionItemResponse"
+                    }
+                },
+                "content": {
+                    "type": "string",
+                    "example": "## 決定事項\n- [ ] 資料を共有する @alice"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "定例会の議事録"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "EventRSVPResponse": {
+            "type": "object",
+            "properties": {
+                "attended": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "responded_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "description": "YES・NO・MAYBE、回答せずに出席だけ記録した場合は NO_RESPONSE",
+                    "type": "string",
+                    "example": "YES"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "EventRecurrenceResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "WEEKLY"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "holiday_policy": {
+                    "description": "回が祝日になった場合の扱い（SKIP・NEXT_BUSINESS_DAY、そのまま行う場合は省略）",
+                    "type": "string",
+                    "example": "NEXT_BUSINESS_DAY"
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "until": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "MO",
+                        "WE"
+                    ]
+                }
+            }
+        },
+        "EventScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GroupEventResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "group_name": {
+                    "type": "string",
+                    "example": "家族"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                }
+            }
+        },
+        "EventTaskLinkResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "kind": {
+                    "description": "BLOCK: 予定がタスクの作業時間、FOLLOW_UP: タスクが予定のフォローアップ",
+                    "type": "string",
+                    "enum": [
+                        "BLOCK",
+                        "FOLLOW_UP"
+                    ],
+                    "example": "BLOCK"
+                },
+                "task": {
+                    "$ref": "#/definitions/LinkedTaskResponse"
+                },
+                "task_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "FeatureFlag": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "タスクの変更履歴の再生"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "key": {
+                    "type": "string",
+                    "example": "task_history"
+                },
+                "percentage": {
+                    "description": "段階的公開の割合（0〜100）",
+                    "type": "integer",
+                    "example": 25
+                },
+                "source": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "users": {
+                    "description": "割合に関係なく公開するユーザー",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174000"
+                    ]
+                }
+            }
+        },
+        "FeatureFlagErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "REQUEST_ERROR"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "rollout percentage must be between 0 and 100"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "FeatureFlagListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FeatureFlag"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "FeatureFlagResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/FeatureFlag"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "FlowBurndownResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.FlowBurndown"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "FriendRemovedResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "友達を削除しました"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "undo": {
+                    "$ref": "#/definitions/UndoToken"
+                }
+            }
+        },
+        "FriendWithUserInfoResponse": {
+            "type": "object",
+            "properties": {
+                "friendship": {
+                    "$ref": "#/definitions/FriendshipResponse"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/UserInfo"
+                }
+            }
+        },
+        "FriendsListResponse": {
+            "type": "object",
+            "properties": {
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FriendWithUserInfoResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/PaginationInfo"
+                }
+            }
+        },
+        "FriendsPresenceResponse": {
+            "type": "object",
+            "properties": {
+                "presences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PresenceResponse"
+                    }
+                }
+            }
+        },
+        "FriendshipResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string",
+                    "example": "2024-01-01T01:00:00Z"
+                },
+                "addressee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "blocked_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "requester_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "GeoLocation": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string",
+                    "example": "JP"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 35.68
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 139.76
+                }
+            }
+        },
+        "Geofence": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_triggered_at": {
+                    "type": "string"
+                },
+                "location": {
+                    "description": "タスクの場所（取得時に設定する）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Location"
+                        }
+                    ]
+                },
+                "radius_meters": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GeofenceEnterRequest": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "example": 35.6813
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 139.7671
+                }
+            }
+        },
+        "GeofenceEnterResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "triggered": {
+                    "description": "リマインダーを送ったか",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GeofenceListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Geofence"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GeofenceRequest": {
+            "type": "object",
+            "properties": {
+                "radius_meters": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "GeofenceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/Geofence"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GetNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/NotificationResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GetUserNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/NotificationResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GitHubAccountData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "login": {
+                    "type": "string",
+                    "example": "octocat"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "********abcd"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "GitHubAccountRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "github_pat_xxxxxxxx"
+                }
+            }
+        },
+        "GitHubAccountResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/GitHubAccountData"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GitHubWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "completed_tasks": {
+                    "description": "完了にしたタスクの数",
+                    "type": "integer",
+                    "example": 1
+                },
+                "received": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GroupEventResponse": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "description": "タスクID（繰り返しの予定は回のID）",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "location": {
+                    "description": "予定のタスクに設定した場所",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/EventLocationResponse"
+                        }
+                    ]
+                },
+                "moved": {
+                    "description": "日時を変更した回か",
+                    "type": "boolean",
+                    "example": false
+                },
+                "original_starts_at": {
+                    "description": "繰り返しの予定の回の元の開始日時",
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "series_id": {
+                    "description": "繰り返しの予定の最初の回の予定（タスク）のID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-01T10:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "定例ミーティング"
+                }
+            }
+        },
+        "GroupListResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GroupResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/PaginationInfo"
+                }
+            }
+        },
+        "GroupPermission": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "CREATE_TASKS"
+                },
+                "configurable": {
+                    "description": "グループごとに変更できるか",
+                    "type": "boolean",
+                    "example": true
+                },
+                "roles": {
+                    "description": "オーナーは常に許可される",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ADMIN",
+                        "MEMBER"
+                    ]
+                }
+            }
+        },
+        "GroupPermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GroupPermission"
+                    }
+                }
+            }
+        },
+        "GroupResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "新製品開発プロジェクトのチーム"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "プロジェクトチーム"
+                },
+                "owner_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "parent_group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.GroupSettings"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "PROJECT"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "GroupStatsResponse": {
+            "type": "object",
+            "properties": {
+                "active_members": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "attendance": {
+                    "description": "予定共有グループの場合",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberAttendanceResponse"
+                    }
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "schedule_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "task_count": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "GroupWithMembersResponse": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "$ref": "#/definitions/GroupResponse"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberWithUserResponse"
+                    }
+                },
+                "my_role": {
+                    "type": "string",
+                    "example": "ADMIN"
+                }
+            }
+        },
+        "InboundAddressData": {
+            "type": "object",
+            "properties": {
+                "allowed_senders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user@example.com",
+                        "@example.co.jp"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "k3q7xw2m9p4r8t6v1y5z0abc@in.example.com"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "InboundAddressRequest": {
+            "type": "object",
+            "properties": {
+                "allowed_senders": {
+                    "description": "許可する差出人（メールアドレス、または \"@example.com\" のドメイン、最大20件）。空の場合はアカウントのメールアドレス",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user@example.com",
+                        "@example.co.jp"
+                    ]
+                }
+            }
+        },
+        "InboundAddressResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/InboundAddressData"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "InboundEmailWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "received": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "enum": [
+                        "CREATED",
+                        "DUPLICATE",
+                        "UNKNOWN_RECIPIENT",
+                        "SENDER_NOT_ALLOWED",
+                        "SPAM",
+                        "RATE_LIMITED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.InboundEmailStatus"
+                        }
+                    ],
+                    "example": "CREATED"
+                },
+                "task_id": {
+                    "description": "作成したタスクのID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "InvitationEmailBounceRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "invitation_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "friend@example.com"
+                },
+                "invitation_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "550 5.1.1 User unknown"
+                }
+            }
+        },
+        "InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string",
+                    "example": "2024-01-01T01:00:00Z"
+                },
+                "bounce_reason": {
+                    "type": "string",
+                    "example": "550 5.1.1 User unknown"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deep_link": {
+                    "type": "string",
+                    "example": "yoteiplus://invite/abc123def456"
+                },
+                "email_send_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "email_sent_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-08T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "invitee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "invitee_info": {
+                    "$ref": "#/definitions/domain.InviteeInfo"
+                },
+                "inviter_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "一緒にYotei+を使いませんか？"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "example": "CODE"
+                },
+                "qr_code_url": {
+                    "type": "string",
+                    "example": "/api/v1/social/invitations/123e4567-e89b-12d3-a456-426614174000/qr"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "FRIEND"
+                },
+                "universal_link": {
+                    "type": "string",
+                    "example": "https://app.yotei-plus.com/invite/abc123def456"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://yotei-plus.com/invite/abc123def456"
+                }
+            }
+        },
+        "InvitationResultResponse": {
+            "type": "object",
+            "properties": {
+                "friendship": {
+                    "$ref": "#/definitions/FriendshipResponse"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "招待を受諾しました"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "InviteURLResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "deep_link": {
+                    "type": "string",
+                    "example": "yoteiplus://invite/abc123def456"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-08T00:00:00Z"
+                },
+                "universal_link": {
+                    "type": "string",
+                    "example": "https://app.yotei-plus.com/invite/abc123def456"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://yotei-plus.com/invite/abc123def456"
+                }
+            }
+        },
+        "LeaderboardEntryResponse": {
+            "type": "object",
+            "properties": {
+                "anonymous": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "completed_tasks": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "current_streak": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "user123"
+                },
+                "due_tasks": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "is_me": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "on_time_rate": {
+                    "description": "期限のあるタスクがない場合はnull",
+                    "type": "number",
+                    "example": 90
+                },
+                "on_time_tasks": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "description": "匿名のメンバーは本人以外には返さない",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LeaderboardEntryResponse"
+                    }
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2024-01-03T12:00:00Z"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "week_end": {
+                    "description": "この時刻に集計がリセットされる",
+                    "type": "string",
+                    "example": "2024-01-08T00:00:00+09:00"
+                },
+                "week_start": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00+09:00"
+                }
+            }
+        },
+        "LeaderboardSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "my_visibility": {
+                    "type": "string",
+                    "example": "VISIBLE"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "LeaderboardVisibilityResponse": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "visibility": {
+                    "type": "string",
+                    "example": "ANONYMOUS"
+                }
+            }
+        },
+        "LinkPreview": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "記事の概要"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/ogp.png"
+                },
+                "site_name": {
+                    "type": "string",
+                    "example": "Example"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "記事のタイトル"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/article"
+                }
+            }
+        },
+        "LinkedTaskResponse": {
+            "type": "object",
+            "properties": {
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "TODO"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "資料を作成する"
+                }
+            }
+        },
+        "ListDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DeadLetter"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ListTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/NotificationTemplateResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "Location": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "LoginAlertSettings": {
+            "type": "object",
+            "properties": {
+                "alerts_enabled": {
+                    "description": "不審なログインを通知・メールで知らせる",
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_verification": {
+                    "description": "信頼済みでない端末からの不審なログインに、メールで送る確認コードの入力を求める",
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                }
+            }
+        },
+        "LoginAlertSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/LoginAlertSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "LoginDevice": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_ip": {
+                    "type": "string",
+                    "example": "203.0.113.10"
+                },
+                "last_location": {
+                    "$ref": "#/definitions/GeoLocation"
+                },
+                "last_seen_at": {
+                    "description": "ログインが完了した最後の日時（確認コードの入力待ちで一度もログインしていない端末はゼロ値）",
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "trusted": {
+                    "description": "信頼済みの端末（最初の端末、ユーザーが信頼した端末）は確認コードを求めない",
+                    "type": "boolean",
+                    "example": true
+                },
+                "trusted_until": {
+                    "description": "確認コードでログインした際に記憶した端末の期限（期限内にCookieを提示した場合は確認コードを求めない）",
+                    "type": "string",
+                    "example": "2024-07-01T09:00:00Z"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "LoginDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/LoginDevice"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "LoginDevicesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LoginDevice"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "access_token": {
+                            "type": "string",
+                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                        },
+                        "refresh_token": {
+                            "type": "string",
+                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                        },
+                        "token_type": {
+                            "type": "string",
+                            "example": "Bearer"
+                        }
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Login successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "LoginVerificationRequiredResponse": {
+            "type": "object",
+            "properties": {
+                "challenge_id": {
+                    "description": "POST /auth/login/verify に確認コードと一緒に指定する",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "VERIFICATION_REQUIRED"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "A verification code has been sent to your email"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Logged out successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "MeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "email": {
+                            "type": "string",
+                            "example": "user@example.com"
+                        },
+                        "role": {
+                            "type": "string",
+                            "example": "user"
+                        },
+                        "user_id": {
+                            "type": "string",
+                            "example": "123e4567-e89b-12d3-a456-426614174000"
+                        },
+                        "username": {
+                            "type": "string",
+                            "example": "johndoe"
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "MemberAttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "absent": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "attendance_rate": {
+                    "description": "出席・欠席を記録した予定のうち出席した割合、記録がない場合はnull",
+                    "type": "number",
+                    "example": 83.3
+                },
+                "attended": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "events": {
+                    "description": "集計した開始済みの予定の数",
+                    "type": "integer",
+                    "example": 8
+                },
+                "maybe": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "no": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "no_response": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "yes": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "MemberListResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberWithUserResponse"
+                    }
+                }
+            }
+        },
+        "MemberRemovedResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "メンバーを削除しました"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "undo": {
+                    "$ref": "#/definitions/UndoToken"
+                }
+            }
+        },
+        "MemberWithUserResponse": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "joined_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "MEMBER"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/UserInfo"
+                }
+            }
+        },
+        "MentionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "mentions": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Mention"
+                            }
+                        },
+                        "page": {
+                            "type": "integer",
+                            "example": 1
+                        },
+                        "page_size": {
+                            "type": "integer",
+                            "example": 10
+                        },
+                        "total_count": {
+                            "type": "integer",
+                            "example": 5
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "操作が正常に完了しました"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "MilestoneBurndownResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.MilestoneBurndown"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "MilestoneOrderRequest": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "milestone_ids"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "milestone_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "MilestoneRequest": {
+            "type": "object",
+            "required": [
+                "due_date",
+                "name"
+            ],
+            "properties": {
+                "due_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "ベータ版リリース"
+                }
+            }
+        },
+        "MilestoneResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ベータ版リリース"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "progress": {
+                    "$ref": "#/definitions/domain.MilestoneProgress"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "NearbyTaskItem": {
+            "type": "object",
+            "properties": {
+                "distance_meters": {
+                    "type": "number",
+                    "example": 120.5
+                },
+                "location": {
+                    "$ref": "#/definitions/Location"
+                },
+                "task": {
+                    "$ref": "#/definitions/TaskResponse"
+                }
+            }
+        },
+        "NearbyTasksResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/NearbyTaskItem"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "NoteActionItemResponse": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "task_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "資料を共有する @alice"
+                }
+            }
+        },
+        "NotificationPreferences": {
+            "type": "object",
+            "properties": {
+                "social_digest": {
+                    "description": "SocialDigest は未対応の友達申請・グループ招待のリマインダーの頻度",
+                    "enum": [
+                        "OFF",
+                        "DAILY",
+                        "WEEKLY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DigestFrequency"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "NotificationPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/NotificationPreferences"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "「重要なプロジェクト」タスクが割り当てられました"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "\"priority\"": "\"HIGH\"}",
+                        "{\"task_id\"": "\"task-123\""
+                    }
+                },
+                "sent_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:05:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SENT"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "新しいタスクが割り当てられました"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "TASK_ASSIGNED"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "NotificationTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "タスク「{{.task_title}}」があなたに割り当てられました。"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "task_assigned"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ja"
+                },
+                "sample": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "新しいタスクが割り当てられました"
+                }
+            }
+        },
+        "PaginationInfo": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "PresenceResponse": {
+            "type": "object",
+            "properties": {
+                "last_seen_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ONLINE",
+                        "AWAY",
+                        "OFFLINE"
+                    ],
+                    "example": "ONLINE"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "PreviewTemplateRequest": {
+            "type": "object",
+            "required": [
+                "event_type"
+            ],
+            "properties": {
+                "event_type": {
+                    "type": "string",
+                    "example": "task_assigned"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ja"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "\"priority\"": "\"HIGH\"}",
+                        "{\"task_title\"": "\"資料作成\""
+                    }
+                }
+            }
+        },
+        "PreviewTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.RenderedTemplate"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "PriorityBreakdownData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#dc2626"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "高"
+                }
+            }
+        },
+        "ProfileSettings": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "空の場合はユーザー名を表示する",
+                    "type": "string"
+                },
+                "show_stats": {
+                    "description": "連続達成日数などの統計を公開プロフィールに含める",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "$ref": "#/definitions/domain.ProfileVisibility"
+                }
+            }
+        },
+        "ProfileSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/ProfileSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ProfileStats": {
+            "type": "object",
+            "properties": {
+                "completed_tasks": {
+                    "description": "直近30日間に完了したタスク数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "current_streak": {
+                    "description": "今日（今日の完了がない場合は昨日）まで毎日タスクを完了した日数",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "ProfileViewer": {
+            "type": "object",
+            "properties": {
+                "can_send_friend_request": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "relationship": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ProfileRelationship"
+                        }
+                    ],
+                    "example": "NONE"
+                }
+            }
+        },
+        "ProgressLevelData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#22c55e"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "良好"
+                },
+                "percentage": {
+                    "type": "integer",
+                    "example": 85
+                }
+            }
+        },
+        "ProgressLevelResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/ProgressLevelData"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ProgressSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DailyStatsData"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "PublicProfile": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/avatars/user123.png"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "山田 太郎"
+                },
+                "restricted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "stats": {
+                    "$ref": "#/definitions/ProfileStats"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "user123"
+                },
+                "viewer": {
+                    "$ref": "#/definitions/ProfileViewer"
+                }
+            }
+        },
+        "PublicProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/PublicProfile"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "PublicShareResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "expires_at": {
+                            "type": "string",
+                            "example": "2024-12-31T23:59:59Z"
+                        },
+                        "page": {
+                            "type": "integer",
+                            "example": 1
+                        },
+                        "page_size": {
+                            "type": "integer",
+                            "example": 10
+                        },
+                        "target_type": {
+                            "type": "string",
+                            "example": "TASK"
+                        },
+                        "task": {
+                            "$ref": "#/definitions/PublicTaskResponse"
+                        },
+                        "tasks": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PublicTaskResponse"
+                            }
+                        },
+                        "total_count": {
+                            "type": "integer",
+                            "example": 5
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "PublicTaskResponse": {
+            "type": "object",
+            "properties": {
+                "assignee_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "category": {
+                    "type": "string",
+                    "example": "WORK"
+                },
+                "completed_assignees": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-01-02T18:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "タスクの詳細説明"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "is_overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "HIGH"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "TODO"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "重要なタスク"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "QuickAddPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "WORK"
+                },
+                "category_suggested": {
+                    "description": "#タグ・!優先度で指定せず、タイトルから推定したか",
+                    "type": "boolean",
+                    "example": false
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2024-12-02T15:00:00+09:00"
+                },
+                "has_time": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "HIGH"
+                },
+                "priority_suggested": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "title": {
+                    "type": "string",
+                    "example": "レポート提出"
+                },
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parsing.Token"
+                    }
+                }
+            }
+        },
+        "QuickAddPreviewResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/QuickAddPreviewResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "QuickAddRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "preview": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "レポート提出 明日 15時 #work !high"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
+        "QuickAddTaskResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Task created successfully"
+                },
+                "parsed": {
+                    "$ref": "#/definitions/QuickAddPreviewResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "QuotaErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "FORBIDDEN"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "not a group member"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "上限（nullの場合は無制限）",
+                    "type": "integer",
+                    "example": 500
+                },
+                "remaining": {
+                    "description": "残り（nullの場合は無制限）",
+                    "type": "integer",
+                    "example": 380
+                },
+                "resource": {
+                    "type": "string",
+                    "example": "open_tasks"
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "QuotaUsageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/QuotaUsage"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "RecordAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "attended",
+                "user_id"
+            ],
+            "properties": {
+                "attended": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "access_token": {
+                            "type": "string",
+                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                        },
+                        "refresh_token": {
+                            "type": "string",
+                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                        },
+                        "token_type": {
+                            "type": "string",
+                            "example": "Bearer"
+                        }
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Token refreshed successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "password123"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 3,
+                    "example": "johndoe"
+                }
+            }
+        },
+        "RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "email": {
+                            "type": "string",
+                            "example": "user@example.com"
+                        },
+                        "user_id": {
+                            "type": "string",
+                            "example": "123e4567-e89b-12d3-a456-426614174000"
+                        },
+                        "username": {
+                            "type": "string",
+                            "example": "johndoe"
+                        }
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User registered successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SCIMEmail": {
+            "type": "object",
+            "properties": {
+                "primary": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "example": "work"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "taro@example.com"
+                }
+            }
+        },
+        "SCIMError": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "userName already exists"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scimType": {
+                    "type": "string",
+                    "example": "uniqueness"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "409"
+                }
+            }
+        },
+        "SCIMGroup": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "externalId": {
+                    "type": "string",
+                    "example": "00g1abcd"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SCIMMember"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/SCIMMeta"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "SCIMGroupRef": {
+            "type": "object",
+            "properties": {
+                "display": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "SCIMListResponse": {
+            "type": "object",
+            "properties": {
+                "Resources": {},
+                "itemsPerPage": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startIndex": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "totalResults": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "SCIMMember": {
+            "type": "object",
+            "properties": {
+                "display": {
+                    "type": "string",
+                    "example": "taro"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "SCIMMeta": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "lastModified": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "/scim/v2/Users/123e4567-e89b-12d3-a456-426614174000"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "User"
+                }
+            }
+        },
+        "SCIMName": {
+            "type": "object",
+            "properties": {
+                "familyName": {
+                    "type": "string"
+                },
+                "formatted": {
+                    "type": "string"
+                },
+                "givenName": {
+                    "type": "string"
+                }
+            }
+        },
+        "SCIMPatchOperation": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string",
+                    "example": "replace"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "value": {
+                    "type": "object"
+                }
+            }
+        },
+        "SCIMPatchRequest": {
+            "type": "object",
+            "properties": {
+                "Operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SCIMPatchOperation"
+                    }
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "SCIMUser": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "省略した場合は有効（作成時）・変更しない（PUT）",
+                    "type": "boolean",
+                    "example": true
+                },
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SCIMEmail"
+                    }
+                },
+                "externalId": {
+                    "type": "string",
+                    "example": "00u1abcd"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SCIMGroupRef"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "meta": {
+                    "$ref": "#/definitions/SCIMMeta"
+                },
+                "name": {
+                    "$ref": "#/definitions/SCIMName"
+                },
+                "password": {
+                    "description": "作成時のみ使用する（省略した場合はランダムなパスワードを設定する）。レスポンスには返さない",
+                    "type": "string"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "taro"
+                }
+            }
+        },
+        "SSOConnection": {
+            "type": "object",
+            "properties": {
+                "admin_values": {
+                    "description": "RoleClaimにいずれかの値が含まれる場合は管理者、含まれない場合は一般ユーザーにする",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "yotei-admins"
+                    ]
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "0oa1abcd"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "domains": {
+                    "description": "対象のメールアドレスのドメイン（小文字）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "example.com"
+                    ]
+                },
+                "enforced": {
+                    "description": "trueの場合、対象ドメインのユーザー（管理者を除く）はパスワードでログインできない",
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "https://example.okta.com"
+                },
+                "jit_provisioning": {
+                    "description": "trueの場合、存在しないユーザーを初回ログイン時に作成する",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Example Inc."
+                },
+                "role_claim": {
+                    "description": "役割を判定するIDトークンのクレーム（例: groups、空の場合は役割を変更しない）",
+                    "type": "string",
+                    "example": "groups"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                }
+            }
+        },
+        "SSOConnectionRequest": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "domains",
+                "issuer",
+                "name"
+            ],
+            "properties": {
+                "admin_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "yotei-admins"
+                    ]
+                },
+                "client_id": {
+                    "description": "IdPに登録したクライアント（更新時にclient_secretを省略した場合は変更しない）",
+                    "type": "string",
+                    "example": "0oa1abcd"
+                },
+                "client_secret": {
+                    "type": "string",
+                    "example": "secret"
+                },
+                "domains": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "example.com"
+                    ]
+                },
+                "enforced": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "https://example.okta.com"
+                },
+                "jit_provisioning": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Example Inc."
+                },
+                "role_claim": {
+                    "type": "string",
+                    "example": "groups"
+                }
+            }
+        },
+        "SSOConnectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/SSOConnection"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SSOConnectionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SSOConnection"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SSODiscovery": {
+            "type": "object",
+            "properties": {
+                "connection_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "enforced": {
+                    "description": "trueの場合はパスワードでログインできない（管理者を除く）",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Example Inc."
+                },
+                "sso": {
+                    "description": "SSOでログインできるか",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SSODiscoveryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/SSODiscovery"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SaveChatIntegrationRequest": {
+            "type": "object",
+            "properties": {
+                "due_reminder_hours": {
+                    "description": "期限の何時間前にリマインダーを投稿するか（省略時は24時間）",
+                    "type": "integer",
+                    "maximum": 168,
+                    "minimum": 1,
+                    "example": 24
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "description": "投稿する出来事（省略時は変更しない。新しく連携する場合はすべて）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "TASK_COMPLETED",
+                        "TASK_ASSIGNED",
+                        "TASK_DUE_SOON",
+                        "DAILY_SUMMARY"
+                    ]
+                },
+                "summary_hour": {
+                    "description": "1日のまとめを投稿する時（省略時は9時）",
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0,
+                    "example": 9
+                },
+                "templates": {
+                    "description": "出来事ごとのメッセージのテンプレート（空文字列でデフォルトに戻す）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "description": "1日のまとめの時刻・期限の表示のタイムゾーン（省略時はUTC）",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "webhook_url": {
+                    "description": "新しく連携する場合は必須",
+                    "type": "string",
+                    "example": "https://discord.com/api/webhooks/123456789/abcdef"
+                }
+            }
+        },
+        "ScheduleCardLinkResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-06-17T00:00:00Z"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://api.example.com/api/v1/public/groups/123e4567-e89b-12d3-a456-426614174000/schedule-card?expires=1718582400\u0026format=svg\u0026signature=...\u0026start=2024-06-03"
+                },
+                "pdf_url": {
+                    "type": "string",
+                    "example": "https://api.example.com/api/v1/public/groups/123e4567-e89b-12d3-a456-426614174000/schedule-card?expires=1718582400\u0026format=pdf\u0026signature=...\u0026start=2024-06-03"
+                },
+                "start_date": {
+                    "description": "カードの開始日（この日から1週間）",
+                    "type": "string",
+                    "example": "2024-06-03"
+                }
+            }
+        },
+        "ScheduleCardSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "show_titles": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "ScheduleTaskBlockRequest": {
+            "type": "object",
+            "required": [
+                "starts_at"
+            ],
+            "properties": {
+                "duration_minutes": {
+                    "description": "省略時はタスクの見積もり時間",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-09T10:00:00Z"
+                }
+            }
+        },
+        "SecurityEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "detail": {
+                    "description": "失敗の理由・拒否されたルートなど",
+                    "type": "string",
+                    "example": "invalid password"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.10"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "login_failed"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                },
+                "user_id": {
+                    "description": "ユーザーを特定できない場合は空",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "SecurityEventsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SecurityEvent"
+                    }
+                },
+                "next_before": {
+                    "description": "次のページを取得する場合にbeforeに指定する日時（次のページがない場合は空）",
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SendFriendRequestRequest": {
+            "type": "object",
+            "required": [
+                "addressee_id"
+            ],
+            "properties": {
+                "addressee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "友達になりませんか？"
+                }
+            }
+        },
+        "SetEventRecurrenceRequest": {
+            "type": "object",
+            "required": [
+                "frequency"
+            ],
+            "properties": {
+                "count": {
+                    "description": "繰り返す回数（最初の回を含む）、省略時は無制限",
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1,
+                    "example": 10
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "DAILY",
+                        "WEEKLY",
+                        "MONTHLY"
+                    ],
+                    "example": "WEEKLY"
+                },
+                "holiday_policy": {
+                    "description": "回が祝日になった場合の扱い（SKIP: 行わない、NEXT_BUSINESS_DAY: 次の営業日に移動）、省略時はそのまま行う",
+                    "type": "string",
+                    "enum": [
+                        "SKIP",
+                        "NEXT_BUSINESS_DAY"
+                    ],
+                    "example": "NEXT_BUSINESS_DAY"
+                },
+                "interval": {
+                    "description": "省略時は1",
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "timezone": {
+                    "description": "繰り返す時刻のタイムゾーン、省略時は UTC",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "until": {
+                    "description": "最後の回の開始日時の上限（count と同時に指定できない）",
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "weekdays": {
+                    "description": "WEEKLY の曜日（MO〜SU）、省略時は最初の回の曜日",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "MO",
+                        "WE"
+                    ]
+                }
+            }
+        },
+        "SetRSVPRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "YES",
+                        "NO",
+                        "MAYBE"
+                    ],
+                    "example": "YES"
+                }
+            }
+        },
+        "ShareLinkCreateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/ShareLinkResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Share link created successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ShareLinkListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ShareLinkResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ShareLinkRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "ShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "filter": {
+                    "$ref": "#/definitions/domain.ListFilter"
+                },
+                "has_password": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/public/shares/q2Xb1m8rQ0c4fX1sX9w2g3yM3w0s8nT1Q6o5vK7a9dE"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "description": "短縮URL（設定されている場合・閲覧可能な場合のみ）",
+                    "type": "string",
+                    "example": "https://yt.plus/s/aB3dE5f"
+                },
+                "target_type": {
+                    "type": "string",
+                    "example": "TASK"
+                },
+                "task_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "q2Xb1m8rQ0c4fX1sX9w2g3yM3w0s8nT1Q6o5vK7a9dE"
+                }
+            }
+        },
+        "ShortLink": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "invitation"
+                },
+                "last_clicked_at": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "aZ3kP9q"
+                },
+                "target_url": {
+                    "type": "string",
+                    "example": "https://app.yotei-plus.com/invite/abc123def456"
+                }
+            }
+        },
+        "ShortLinkErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "SHORT_LINK_NOT_FOUND"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "short link not found"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "ShortLinkResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/ShortLink"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://yt.plus/s/aB3dE5f"
+                }
+            }
+        },
+        "StatsComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.StatsComparison"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "StatsSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.StatsSeries"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "操作が正常に完了しました"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SyncChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "task"
+                },
+                "op": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Operation"
+                        }
+                    ],
+                    "example": "upsert"
+                },
+                "version": {
+                    "description": "変更後のバージョン（POST /sync/push の base_version に指定する）",
+                    "type": "integer",
+                    "example": 128
+                }
+            }
+        },
+        "SyncChangesPage": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SyncChange"
+                    }
+                },
+                "has_more": {
+                    "description": "trueの場合は続きの変更がある",
+                    "type": "boolean",
+                    "example": false
+                },
+                "next_cursor": {
+                    "description": "次の取得で since に指定するカーソル",
+                    "type": "string",
+                    "example": "djE6MTI4"
+                }
+            }
+        },
+        "SyncChangesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/SyncChangesPage"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SyncErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "CURSOR_EXPIRED"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "sync cursor expired"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "SyncMutation": {
+            "type": "object",
+            "properties": {
+                "base_version": {
+                    "description": "変更の元にしたエンティティのバージョン（変更フィードの version、作成時は不要）",
+                    "type": "integer",
+                    "example": 128
+                },
+                "client_mutation_id": {
+                    "description": "クライアントが変更ごとに生成するID（再送時に同じ変更を二重に適用しないために使用）",
+                    "type": "string",
+                    "example": "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "entity_id": {
+                    "description": "作成時はクライアントが生成したID",
+                    "type": "string",
+                    "example": "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "task"
+                },
+                "op": {
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MutationOp"
+                        }
+                    ],
+                    "example": "update"
+                }
+            }
+        },
+        "SyncMutationResult": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "client_mutation_id": {
+                    "type": "string",
+                    "example": "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+                },
+                "data": {
+                    "description": "accepted の場合は適用後、conflict の場合はサーバーの現在のエンティティ（削除済みの場合は省略）",
+                    "type": "object"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "task"
+                },
+                "reason": {
+                    "description": "conflict の場合は version_mismatch・already_exists・deleted のいずれか、rejected の場合はエラーの内容",
+                    "type": "string",
+                    "example": "version_mismatch"
+                },
+                "status": {
+                    "enum": [
+                        "accepted",
+                        "conflict",
+                        "rejected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MutationStatus"
+                        }
+                    ],
+                    "example": "accepted"
+                },
+                "version": {
+                    "description": "accepted の場合は適用後、conflict の場合はサーバーの現在のエンティティのバージョン",
+                    "type": "integer",
+                    "example": 129
+                }
+            }
+        },
+        "SyncPushRequest": {
+            "type": "object",
+            "required": [
+                "mutations"
+            ],
+            "properties": {
+                "mutations": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/SyncMutation"
+                    }
+                }
+            }
+        },
+        "SyncPushResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/SyncPushResult"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "SyncPushResult": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SyncMutationResult"
+                    }
+                }
+            }
+        },
+        "TaskAssignResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Task assigned successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "warning": {
+                    "$ref": "#/definitions/domain.WorkloadWarning"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WorkloadWarning"
+                    }
+                }
+            }
+        },
+        "TaskCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "count": {
+                            "type": "integer",
+                            "example": 5
+                        },
+                        "tasks": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TaskResponse"
+                            }
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskCreateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Task created successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Task deleted successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskDeletedResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Task deleted successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "undo": {
+                    "$ref": "#/definitions/UndoToken"
+                }
+            }
+        },
+        "TaskDependencyRequest": {
+            "type": "object",
+            "required": [
+                "depends_on_id"
+            ],
+            "properties": {
+                "depends_on_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "TaskEstimateRequest": {
+            "type": "object",
+            "properties": {
+                "actual_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "estimate_points": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "TaskEvent": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "変更したユーザー（システムによる変更の場合は空）",
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "タスクごとの1始まりの連番",
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.TaskEventType"
+                }
+            }
+        },
+        "TaskEventLink": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "予定のタスクID（繰り返しの予定は回のID）",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "BLOCK",
+                        "FOLLOW_UP"
+                    ],
+                    "example": "BLOCK"
+                },
+                "task_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "TaskGetResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskHistory": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "last_event": {
+                    "description": "最後に適用したイベント（誰がいつこの状態にしたか）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/TaskEvent"
+                        }
+                    ]
+                },
+                "sequence": {
+                    "description": "適用した最後のイベントの連番",
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/TaskState"
+                },
+                "task_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "TaskHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskHistory"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskLink": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174002"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "managed": {
+                    "description": "Managed は連携が管理するリンクか（変更できず、予定のリンクは予定の画面から解除する）",
+                    "type": "boolean",
+                    "example": false
+                },
+                "state": {
+                    "description": "State は連携先の状態（GitHubのIssue・Pull Requestの OPEN・MERGED など、予定の BLOCK・FOLLOW_UP）",
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "task_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "仕様書"
+                },
+                "type": {
+                    "enum": [
+                        "LINK",
+                        "DOCUMENT",
+                        "DESIGN",
+                        "GITHUB_ISSUE",
+                        "GITHUB_PULL_REQUEST",
+                        "CALENDAR_EVENT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TaskLinkType"
+                        }
+                    ],
+                    "example": "DOCUMENT"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL は外部のURL（予定の場合はアプリ内のパス）",
+                    "type": "string",
+                    "example": "https://docs.example.com/spec"
+                }
+            }
+        },
+        "TaskLinkListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TaskLink"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskLinkRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "complete_on_close": {
+                    "description": "GitHubのIssue・Pull Requestのマージ・完了でタスクを完了にするか（省略した場合は完了にする）",
+                    "type": "boolean",
+                    "example": true
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "タスクの検索を追加"
+                },
+                "type": {
+                    "enum": [
+                        "LINK",
+                        "DOCUMENT",
+                        "DESIGN",
+                        "GITHUB_ISSUE",
+                        "GITHUB_PULL_REQUEST"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TaskLinkType"
+                        }
+                    ],
+                    "example": "GITHUB_PULL_REQUEST"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://github.com/hryt430/yotei-plus/pull/42"
+                }
+            }
+        },
+        "TaskLinkResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskLink"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskLinkUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "仕様書"
+                },
+                "type": {
+                    "enum": [
+                        "LINK",
+                        "DOCUMENT",
+                        "DESIGN"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TaskLinkType"
+                        }
+                    ],
+                    "example": "DOCUMENT"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://docs.example.com/spec"
+                }
+            }
+        },
+        "TaskListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "page": {
+                            "type": "integer",
+                            "example": 1
+                        },
+                        "page_size": {
+                            "type": "integer",
+                            "example": 10
+                        },
+                        "tasks": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TaskResponse"
+                            }
+                        },
+                        "total_count": {
+                            "type": "integer",
+                            "example": 50
+                        }
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskListShareLinkRequest": {
+            "type": "object",
+            "properties": {
+                "assigned_to_me": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "WORK",
+                        "PERSONAL",
+                        "STUDY",
+                        "HEALTH",
+                        "SHOPPING",
+                        "OTHER"
+                    ],
+                    "example": "WORK"
+                },
+                "due_date_from": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "due_date_to": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "LOW",
+                        "MEDIUM",
+                        "HIGH"
+                    ],
+                    "example": "HIGH"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "TODO",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "TODO"
+                }
+            }
+        },
+        "TaskLocation": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "$ref": "#/definitions/Location"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "TaskLocationRequest": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "name"
             ],
             "properties": {
-                "role": {
+                "latitude": {
+                    "type": "number",
+                    "example": 35.681236
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 139.767125
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "東京駅"
+                }
+            }
+        },
+        "TaskLocationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskLocation"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TaskMilestoneRequest": {
+            "type": "object",
+            "properties": {
+                "milestone_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "TaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "WORK",
+                        "PERSONAL",
+                        "STUDY",
+                        "HEALTH",
+                        "SHOPPING",
+                        "OTHER"
+                    ],
+                    "example": "WORK"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "タスクの詳細説明"
+                },
+                "due_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "force": {
+                    "description": "作成時のみ: タイトルが似ている最近のタスクがあっても作成する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "group_id": {
+                    "description": "作成時のみ: グループタスクとして作成する。assignee_id を省略するとグループの自動割り当て方式で担当者を割り当てる",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "description": "作成時のみ: クライアントが生成したUUIDv7のID。オフライン中に作成したタスクを同期前から参照するために使用し、同じIDで再送すると作成済みのタスクを返す",
+                    "type": "string",
+                    "example": "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "LOW",
+                        "MEDIUM",
+                        "HIGH"
+                    ],
+                    "example": "HIGH"
+                },
+                "skip_auto_assign": {
+                    "description": "作成時のみ: 自動割り当てせずに担当者なしで作成する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-20T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "TODO",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "TODO"
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "重要なタスク"
+                }
+            }
+        },
+        "TaskResponse": {
+            "type": "object",
+            "properties": {
+                "actual_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "assignee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TaskAssignee"
+                    }
+                },
+                "category": {
+                    "type": "string",
+                    "example": "WORK"
+                },
+                "checklist": {
+                    "description": "説明のMarkdownから抽出したチェックリスト",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ChecklistItem"
+                    }
+                },
+                "checklist_completed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "completed_assignees": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-01-02T18:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "タスクの詳細説明"
+                },
+                "description_html": {
+                    "description": "format=html を指定した場合のみ設定する",
+                    "type": "string",
+                    "example": "\u003cp\u003eタスクの\u003cstrong\u003e詳細\u003c/strong\u003e説明\u003c/p\u003e"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "estimate_points": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "event_links": {
+                    "description": "グループの予定との関連付け（タスク取得時のみ設定する）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TaskEventLink"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "is_overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "link_previews": {
+                    "description": "説明中のリンクの取得済みのプレビュー（タスク取得時のみ設定する）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LinkPreview"
+                    }
+                },
+                "links": {
+                    "description": "ユーザーが追加したリンクとGitHub・予定の連携のリンク（タスク取得時のみ設定する）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TaskLink"
+                    }
+                },
+                "location": {
+                    "description": "タスクの場所（タスク取得時のみ設定する）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Location"
+                        }
+                    ]
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "HIGH"
+                },
+                "require_all_assignees": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2024-12-20T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "TODO"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "重要なタスク"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "TaskScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "clear_start_date": {
+                    "description": "着手予定日時を消去する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "due_date": {
                     "type": "string",
-                    "enum": [
-                        "OWNER",
-                        "ADMIN",
-                        "MEMBER"
-                    ],
-                    "example": "MEMBER"
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
                 },
-                "user_id": {
+                "start_date": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "format": "date-time",
+                    "example": "2024-12-20T09:00:00Z"
                 }
             }
         },
-        "AssignTaskRequest": {
+        "TaskState": {
             "type": "object",
-            "required": [
-                "assignee_id"
-            ],
             "properties": {
-                "assignee_id": {
+                "actual_minutes": {
+                    "type": "integer"
+                },
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TaskAssignee"
+                    }
+                },
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "estimate_minutes": {
+                    "type": "integer"
+                },
+                "estimate_points": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "$ref": "#/definitions/domain.Priority"
+                },
+                "require_all_assignees": {
+                    "type": "boolean"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.TaskStatus"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "TaskSummary": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "id": {
                     "type": "string",
                     "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "DONE"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "重要なタスク"
                 }
             }
         },
-        "CategoryBreakdownData": {
+        "TaskUpdateResponse": {
             "type": "object",
             "properties": {
-                "color": {
+                "data": {
+                    "$ref": "#/definitions/TaskResponse"
+                },
+                "message": {
                     "type": "string",
-                    "example": "#3b82f6"
+                    "example": "Task updated successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TimelineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Timeline"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "TodayResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Today"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "UndoErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "UNDO_EXPIRED"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "undo token not found or expired"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "UndoResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "task.delete"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Operation undone successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "UndoToken": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "task.delete"
                 },
+                "undo_expires_at": {
+                    "type": "string"
+                },
+                "undo_token": {
+                    "type": "string",
+                    "example": "0f8fad5b-d9cb-469f-a165-70867728950e"
+                }
+            }
+        },
+        "UnreadCountResponse": {
+            "type": "object",
+            "properties": {
                 "count": {
                     "type": "integer",
-                    "example": 15
+                    "example": 5
                 },
-                "display_name": {
-                    "type": "string",
-                    "example": "仕事"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "ChangeStatusRequest": {
+        "UpdateAssignmentPolicyRequest": {
             "type": "object",
             "required": [
-                "status"
+                "policy"
             ],
             "properties": {
-                "status": {
+                "policy": {
                     "type": "string",
                     "enum": [
-                        "TODO",
-                        "IN_PROGRESS",
-                        "DONE"
+                        "NONE",
+                        "ROUND_ROBIN",
+                        "LEAST_LOADED"
                     ],
-                    "example": "IN_PROGRESS"
+                    "example": "ROUND_ROBIN"
                 }
             }
         },
-        "CreateGroupRequest": {
+        "UpdateDeviceRequest": {
             "type": "object",
             "required": [
-                "name",
-                "type"
+                "trusted"
+            ],
+            "properties": {
+                "trusted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "UpdateFeatureFlagRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "タスクの変更履歴の再生"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "percentage": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "users": {
+                    "description": "空の配列でユーザーの指定を解除する",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174000"
+                    ]
+                }
+            }
+        },
+        "UpdateGroupPermissionsRequest": {
+            "type": "object",
+            "required": [
+                "permissions"
             ],
+            "properties": {
+                "permissions": {
+                    "description": "アクションごとに許可するロール（ADMIN・MEMBER）。指定しなかったアクションはデフォルトに戻る",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "UpdateGroupRequest": {
+            "type": "object",
             "properties": {
                 "description": {
                     "type": "string",
@@ -4123,115 +23003,145 @@ const docTemplate = `{
                 },
                 "settings": {
                     "$ref": "#/definitions/domain.GroupSettings"
+                }
+            }
+        },
+        "UpdateLeaderboardSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "type": {
+                "timezone": {
+                    "description": "週の区切り（月曜日0時）に使うタイムゾーン",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
+        "UpdateLeaderboardVisibilityRequest": {
+            "type": "object",
+            "required": [
+                "visibility"
+            ],
+            "properties": {
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "VISIBLE",
+                        "ANONYMOUS",
+                        "HIDDEN"
+                    ],
+                    "example": "ANONYMOUS"
+                }
+            }
+        },
+        "UpdateLoginAlertSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "alerts_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "require_verification": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "UpdateMemberRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
                     "type": "string",
                     "enum": [
-                        "PROJECT",
-                        "SCHEDULE"
+                        "OWNER",
+                        "ADMIN",
+                        "MEMBER"
+                    ],
+                    "example": "ADMIN"
+                }
+            }
+        },
+        "UpdateNotificationPreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "social_digest": {
+                    "enum": [
+                        "OFF",
+                        "DAILY",
+                        "WEEKLY"
                     ],
-                    "example": "PROJECT"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DigestFrequency"
+                        }
+                    ],
+                    "example": "WEEKLY"
                 }
             }
         },
-        "CreateInvitationRequest": {
+        "UpdateProfileSettingsRequest": {
             "type": "object",
-            "required": [
-                "method",
-                "type"
-            ],
             "properties": {
-                "expires_hours": {
-                    "type": "integer",
-                    "maximum": 168,
-                    "minimum": 1,
-                    "example": 168
-                },
-                "invitee_email": {
+                "avatar_url": {
                     "type": "string",
-                    "example": "friend@example.com"
+                    "example": "https://cdn.example.com/avatars/user123.png"
                 },
-                "message": {
+                "bio": {
                     "type": "string",
-                    "maxLength": 500,
-                    "example": "一緒にYotei+を使いませんか？"
+                    "example": "毎朝のランニングを続けています"
                 },
-                "method": {
+                "display_name": {
                     "type": "string",
-                    "enum": [
-                        "IN_APP",
-                        "CODE",
-                        "URL"
-                    ],
-                    "example": "CODE"
+                    "example": "山田 太郎"
                 },
-                "target_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "show_stats": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "type": {
+                "visibility": {
                     "type": "string",
                     "enum": [
-                        "FRIEND",
-                        "GROUP"
+                        "PUBLIC",
+                        "FRIENDS",
+                        "PRIVATE"
                     ],
-                    "example": "FRIEND"
+                    "example": "FRIENDS"
                 }
             }
         },
-        "CreateNotificationRequest": {
+        "UpdateScheduleCardSettingsRequest": {
             "type": "object",
-            "required": [
-                "channels",
-                "message",
-                "title",
-                "type",
-                "user_id"
-            ],
             "properties": {
-                "channels": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"app\"",
-                        "\"line\"]"
-                    ]
-                },
-                "message": {
-                    "type": "string",
-                    "example": "「重要なプロジェクト」タスクが割り当てられました"
-                },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    },
-                    "example": {
-                        "\"priority\"": "\"HIGH\"}",
-                        "{\"task_id\"": "\"task-123\""
-                    }
-                },
-                "title": {
-                    "type": "string",
-                    "example": "新しいタスクが割り当てられました"
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "type": {
-                    "type": "string",
-                    "example": "TASK_ASSIGNED"
+                "show_titles": {
+                    "description": "falseの場合は予定のタイトルを伏せる",
+                    "type": "boolean",
+                    "example": false
                 },
-                "user_id": {
+                "timezone": {
+                    "description": "日付の区切りに使うタイムゾーン",
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "Asia/Tokyo"
                 }
             }
         },
-        "CreateNotificationResponse": {
+        "UserFeaturesResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/NotificationResponse"
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
                 },
                 "success": {
                     "type": "boolean",
@@ -4239,61 +23149,49 @@ const docTemplate = `{
                 }
             }
         },
-        "DailyPreviewData": {
+        "UserInfo": {
             "type": "object",
             "properties": {
-                "date": {
+                "email": {
                     "type": "string",
-                    "example": "2024-01-01"
+                    "example": "user@example.com"
                 },
-                "has_overdue": {
-                    "type": "boolean",
-                    "example": false
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "task_count": {
-                    "type": "integer",
-                    "example": 3
+                "username": {
+                    "type": "string",
+                    "example": "user123"
                 }
             }
         },
-        "DailyStatsData": {
+        "UserRelationshipResponse": {
             "type": "object",
             "properties": {
-                "completed_tasks": {
-                    "type": "integer",
-                    "example": 7
-                },
-                "completion_rate": {
-                    "type": "number",
-                    "example": 70
-                },
-                "date": {
-                    "type": "string",
-                    "example": "2024-01-01"
-                },
-                "in_progress_tasks": {
-                    "type": "integer",
-                    "example": 2
+                "is_blocked": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "overdue_tasks": {
-                    "type": "integer",
-                    "example": 0
+                "is_friend": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "todo_tasks": {
-                    "type": "integer",
-                    "example": 1
+                "request_received": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "total_tasks": {
-                    "type": "integer",
-                    "example": 10
+                "request_sent": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "DailyStatsResponse": {
+        "VelocityReportResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/DailyStatsData"
+                    "$ref": "#/definitions/domain.VelocityReport"
                 },
                 "success": {
                     "type": "boolean",
@@ -4301,46 +23199,56 @@ const docTemplate = `{
                 }
             }
         },
-        "DashboardStatsData": {
+        "VerifyLoginRequest": {
             "type": "object",
+            "required": [
+                "challenge_id",
+                "code"
+            ],
             "properties": {
-                "category_breakdown": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
-                    }
+                "challenge_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "overdue_tasks_count": {
-                    "type": "integer"
+                "code": {
+                    "type": "string",
+                    "example": "123456"
                 },
-                "priority_breakdown": {
+                "remember_device": {
+                    "description": "この端末を30日間記憶し、期間中の不審なログインに確認コードを求めない",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "WeeklyPreviewData": {
+            "type": "object",
+            "properties": {
+                "daily_preview": {
                     "type": "object",
                     "additionalProperties": {
-                        "type": "integer"
-                    }
-                },
-                "recent_completions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/TaskSummary"
+                        "$ref": "#/definitions/DailyPreviewData"
                     }
                 },
-                "today_stats": {
-                    "$ref": "#/definitions/DailyStatsData"
+                "total_tasks": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "upcoming_week_tasks": {
-                    "$ref": "#/definitions/WeeklyPreviewData"
+                "week_end": {
+                    "type": "string",
+                    "example": "2024-01-07"
                 },
-                "weekly_overview": {
-                    "$ref": "#/definitions/WeeklyStatsData"
+                "week_start": {
+                    "type": "string",
+                    "example": "2024-01-01"
                 }
             }
         },
-        "DashboardStatsResponse": {
+        "WeeklyReportResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/DashboardStatsData"
+                    "$ref": "#/definitions/domain.WeeklyReport"
                 },
                 "success": {
                     "type": "boolean",
@@ -4348,89 +23256,122 @@ const docTemplate = `{
                 }
             }
         },
-        "ErrorResponse": {
+        "WeeklyReportSubscriptionRequest": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "INVALID_REQUEST"
-                },
-                "message": {
+                "timezone": {
+                    "description": "省略時は勤務時間設定のタイムゾーン",
                     "type": "string",
-                    "example": "リクエストが無効です"
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
+        "WeeklyReportSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.WeeklyReportSubscription"
                 },
                 "success": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 }
             }
         },
-        "FriendWithUserInfoResponse": {
+        "WeeklyStatsData": {
             "type": "object",
             "properties": {
-                "friendship": {
-                    "$ref": "#/definitions/FriendshipResponse"
+                "completed_tasks": {
+                    "type": "integer",
+                    "example": 35
                 },
-                "user_info": {
-                    "$ref": "#/definitions/UserInfo"
+                "completion_rate": {
+                    "type": "number",
+                    "example": 70
+                },
+                "daily_stats": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/DailyStatsData"
+                    }
+                },
+                "total_tasks": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "week_end": {
+                    "type": "string",
+                    "example": "2024-01-07"
+                },
+                "week_start": {
+                    "type": "string",
+                    "example": "2024-01-01"
                 }
             }
         },
-        "FriendsListResponse": {
+        "WeeklyStatsResponse": {
             "type": "object",
             "properties": {
-                "friends": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/FriendWithUserInfoResponse"
-                    }
+                "data": {
+                    "$ref": "#/definitions/WeeklyStatsData"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/PaginationInfo"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "FriendshipResponse": {
+        "WorkingHoursRequest": {
             "type": "object",
             "properties": {
-                "accepted_at": {
-                    "type": "string",
-                    "example": "2024-01-01T01:00:00Z"
+                "daily_capacity_minutes": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0,
+                    "example": 420
                 },
-                "addressee_id": {
+                "end_time": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "blocked_at": {
-                    "type": "string"
+                    "example": "18:00"
                 },
-                "created_at": {
+                "holiday_policy": {
+                    "description": "期限が祝日・勤務日以外になった場合の扱い（KEEP・NEXT_BUSINESS_DAY・PREVIOUS_BUSINESS_DAY、省略時はKEEP）",
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "NEXT_BUSINESS_DAY"
                 },
-                "id": {
+                "reminder_timing": {
+                    "description": "リマインダー・エスカレーションの通知を送る時間帯（WORKING_HOURS・ANYTIME、省略時はWORKING_HOURS）\nWORKING_HOURSの場合、勤務時間外・祝日の通知は次の勤務時間の開始時にまとめて送る",
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "WORKING_HOURS"
                 },
-                "requester_id": {
+                "start_time": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "09:00"
                 },
-                "status": {
+                "timezone": {
                     "type": "string",
-                    "example": "PENDING"
+                    "example": "Asia/Tokyo"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "work_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ]
                 }
             }
         },
-        "GetNotificationResponse": {
+        "WorkingHoursResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/NotificationResponse"
+                    "$ref": "#/definitions/domain.WorkingHours"
                 },
                 "success": {
                     "type": "boolean",
@@ -4438,14 +23379,11 @@ const docTemplate = `{
                 }
             }
         },
-        "GetUserNotificationsResponse": {
+        "WorkloadResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/NotificationResponse"
-                    }
+                    "$ref": "#/definitions/domain.Workload"
                 },
                 "success": {
                     "type": "boolean",
@@ -4453,1065 +23391,1691 @@ const docTemplate = `{
                 }
             }
         },
-        "GroupListResponse": {
-            "type": "object",
-            "properties": {
-                "groups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/GroupResponse"
-                    }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/PaginationInfo"
-                }
-            }
-        },
-        "GroupResponse": {
+        "domain.Attachment": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "新製品開発プロジェクトのチーム"
+                "filename": {
+                    "type": "string"
                 },
                 "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "type": "string"
                 },
-                "member_count": {
-                    "type": "integer",
-                    "example": 5
+                "mime_type": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "プロジェクトチーム"
+                "size": {
+                    "type": "integer"
                 },
-                "owner_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "task_id": {
+                    "type": "string"
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.GroupSettings"
+                "thumbnail_status": {
+                    "$ref": "#/definitions/domain.ThumbnailStatus"
                 },
-                "type": {
-                    "type": "string",
-                    "example": "PROJECT"
+                "uploaded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BurndownPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "ideal": {
+                    "type": "number"
                 },
-                "version": {
-                    "type": "integer",
-                    "example": 1
+                "remaining": {
+                    "type": "integer"
                 }
             }
         },
-        "GroupStatsResponse": {
+        "domain.Calendar": {
             "type": "object",
             "properties": {
-                "active_members": {
-                    "type": "integer",
-                    "example": 4
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CalendarDay"
+                    }
                 },
-                "member_count": {
-                    "type": "integer",
-                    "example": 5
+                "from": {
+                    "type": "string"
                 },
-                "schedule_count": {
-                    "type": "integer",
-                    "example": 3
+                "timezone": {
+                    "type": "string"
                 },
-                "task_count": {
-                    "type": "integer",
-                    "example": 10
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "GroupWithMembersResponse": {
+        "domain.CalendarDay": {
             "type": "object",
             "properties": {
-                "group": {
-                    "$ref": "#/definitions/GroupResponse"
+                "date": {
+                    "description": "\"2006-01-02\"",
+                    "type": "string"
                 },
-                "members": {
+                "entries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/MemberWithUserResponse"
+                        "$ref": "#/definitions/domain.CalendarEntry"
                     }
                 },
-                "my_role": {
-                    "type": "string",
-                    "example": "ADMIN"
+                "holiday": {
+                    "description": "祝日の名前（祝日でない場合は省略）",
+                    "type": "string"
                 }
             }
         },
-        "InvitationResponse": {
+        "domain.CalendarEntry": {
             "type": "object",
             "properties": {
-                "accepted_at": {
-                    "type": "string",
-                    "example": "2024-01-01T01:00:00Z"
-                },
-                "code": {
-                    "type": "string",
-                    "example": "abc123def456"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
-                },
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-01-08T00:00:00Z"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "invitee_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "invitee_info": {
-                    "$ref": "#/definitions/domain.InviteeInfo"
+                "at": {
+                    "description": "カレンダーのタイムゾーンでの日時",
+                    "type": "string"
                 },
-                "inviter_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "kind": {
+                    "$ref": "#/definitions/domain.CalendarEntryKind"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "一緒にYotei+を使いませんか？"
+                "task": {
+                    "$ref": "#/definitions/domain.Task"
+                }
+            }
+        },
+        "domain.CalendarEntryKind": {
+            "type": "string",
+            "enum": [
+                "START",
+                "DUE"
+            ],
+            "x-enum-comments": {
+                "CalendarEntryDue": "期限",
+                "CalendarEntryStart": "着手予定日時"
+            },
+            "x-enum-varnames": [
+                "CalendarEntryStart",
+                "CalendarEntryDue"
+            ]
+        },
+        "domain.Category": {
+            "type": "string",
+            "enum": [
+                "WORK",
+                "PERSONAL",
+                "STUDY",
+                "HEALTH",
+                "SHOPPING",
+                "OTHER"
+            ],
+            "x-enum-comments": {
+                "CategoryHealth": "健康",
+                "CategoryOther": "その他",
+                "CategoryPersonal": "個人",
+                "CategoryShopping": "買い物",
+                "CategoryStudy": "学習",
+                "CategoryWork": "仕事"
+            },
+            "x-enum-varnames": [
+                "CategoryWork",
+                "CategoryPersonal",
+                "CategoryStudy",
+                "CategoryHealth",
+                "CategoryShopping",
+                "CategoryOther"
+            ]
+        },
+        "domain.CategoryVelocity": {
+            "type": "object",
+            "properties": {
+                "accuracy_rate": {
+                    "description": "見積もり精度（0-100）",
+                    "type": "number"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "actual_minutes": {
+                    "description": "見積もりと実績の両方があるタスクの実績合計",
+                    "type": "integer"
                 },
-                "method": {
-                    "type": "string",
-                    "example": "CODE"
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
                 },
-                "status": {
-                    "type": "string",
-                    "example": "PENDING"
+                "completed_points": {
+                    "type": "integer"
                 },
-                "target_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "completed_tasks": {
+                    "type": "integer"
                 },
-                "type": {
-                    "type": "string",
-                    "example": "FRIEND"
+                "estimate_ratio": {
+                    "description": "実績/見積もり（1より大きいと見積もり不足）",
+                    "type": "number"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "estimated_minutes": {
+                    "description": "見積もり（分）が設定された完了タスクの見積もり合計",
+                    "type": "integer"
                 },
-                "url": {
-                    "type": "string",
-                    "example": "https://yotei-plus.com/invite/abc123def456"
+                "measured_tasks": {
+                    "description": "見積もりと実績の両方があるタスク数",
+                    "type": "integer"
                 }
             }
         },
-        "InvitationResultResponse": {
+        "domain.ChannelType": {
+            "type": "string",
+            "enum": [
+                "APP_INTERNAL",
+                "LINE"
+            ],
+            "x-enum-comments": {
+                "AppInternal": "アプリ内通知",
+                "LineMessage": "LINE通知"
+            },
+            "x-enum-varnames": [
+                "AppInternal",
+                "LineMessage"
+            ]
+        },
+        "domain.ComparisonChanges": {
             "type": "object",
             "properties": {
-                "friendship": {
-                    "$ref": "#/definitions/FriendshipResponse"
+                "completed": {
+                    "$ref": "#/definitions/domain.MetricChange"
                 },
-                "group_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "completion_rate": {
+                    "$ref": "#/definitions/domain.MetricChange"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "招待を受諾しました"
+                "created": {
+                    "$ref": "#/definitions/domain.MetricChange"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "overdue": {
+                    "description": "減るほど良い",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MetricChange"
+                        }
+                    ]
                 }
             }
         },
-        "InviteURLResponse": {
+        "domain.ComparisonPeriod": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-comments": {
+                "ComparisonPeriodWeek": "月曜日始まり"
+            },
+            "x-enum-varnames": [
+                "ComparisonPeriodDay",
+                "ComparisonPeriodWeek",
+                "ComparisonPeriodMonth"
+            ]
+        },
+        "domain.CumulativeFlow": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "abc123def456"
+                "from": {
+                    "type": "string"
                 },
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-01-08T00:00:00Z"
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CumulativeFlowPoint"
+                    }
+                },
+                "scope": {
+                    "$ref": "#/definitions/domain.FlowScope"
+                },
+                "scope_id": {
+                    "type": "string"
                 },
-                "url": {
-                    "type": "string",
-                    "example": "https://yotei-plus.com/invite/abc123def456"
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "LoginRequest": {
+        "domain.CumulativeFlowPoint": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "date": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
+                "done": {
+                    "type": "integer"
+                },
+                "in_progress": {
+                    "type": "integer"
+                },
+                "todo": {
+                    "type": "integer"
                 }
             }
         },
-        "LoginResponse": {
+        "domain.DailyWorkload": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "access_token": {
-                            "type": "string",
-                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                        },
-                        "refresh_token": {
-                            "type": "string",
-                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                        },
-                        "token_type": {
-                            "type": "string",
-                            "example": "Bearer"
-                        }
-                    }
+                "capacity_minutes": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Login successful"
+                "date": {
+                    "description": "\"2006-01-02\"",
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "over_capacity": {
+                    "type": "boolean"
+                },
+                "planned_minutes": {
+                    "type": "integer"
+                },
+                "task_count": {
+                    "type": "integer"
+                },
+                "task_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "LogoutRequest": {
+        "domain.DeadLetter": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "attempts": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "$ref": "#/definitions/domain.ChannelType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notification_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "最後の送信の失敗の理由",
+                    "type": "string"
+                },
+                "redriven_at": {
+                    "type": "string"
+                },
+                "target": {
+                    "description": "送信先（アプリ内通知の場合はユーザーID、LINEの場合はLINEユーザーID）",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "LogoutResponse": {
+        "domain.DigestFrequency": {
+            "type": "string",
+            "enum": [
+                "OFF",
+                "DAILY",
+                "WEEKLY"
+            ],
+            "x-enum-comments": {
+                "DigestDaily": "1日に1回まで",
+                "DigestOff": "送らない",
+                "DigestWeekly": "1週間に1回まで"
+            },
+            "x-enum-varnames": [
+                "DigestOff",
+                "DigestDaily",
+                "DigestWeekly"
+            ]
+        },
+        "domain.Export": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Logged out successfully"
+                "completed_at": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ExportStatus"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "MeResponse": {
+        "domain.ExportStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "PROCESSING",
+                "READY",
+                "FAILED",
+                "EXPIRED"
+            ],
+            "x-enum-comments": {
+                "ExportExpired": "保存期間が過ぎてファイルを削除した",
+                "ExportFailed": "作成に失敗した",
+                "ExportPending": "作成待ち",
+                "ExportProcessing": "作成中",
+                "ExportReady": "ダウンロード可能"
+            },
+            "x-enum-varnames": [
+                "ExportPending",
+                "ExportProcessing",
+                "ExportReady",
+                "ExportFailed",
+                "ExportExpired"
+            ]
+        },
+        "domain.FlowBurndown": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "email": {
-                            "type": "string",
-                            "example": "user@example.com"
-                        },
-                        "role": {
-                            "type": "string",
-                            "example": "user"
-                        },
-                        "user_id": {
-                            "type": "string",
-                            "example": "123e4567-e89b-12d3-a456-426614174000"
-                        },
-                        "username": {
-                            "type": "string",
-                            "example": "johndoe"
-                        }
+                "from": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FlowBurndownPoint"
                     }
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "scope": {
+                    "$ref": "#/definitions/domain.FlowScope"
+                },
+                "scope_id": {
+                    "description": "ユーザーIDまたはグループID",
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "MemberListResponse": {
+        "domain.FlowBurndownPoint": {
             "type": "object",
             "properties": {
-                "members": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/MemberWithUserResponse"
-                    }
+                "added": {
+                    "type": "integer"
+                },
+                "completed": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "ideal": {
+                    "type": "number"
+                },
+                "remaining": {
+                    "type": "integer"
                 }
             }
         },
-        "MemberWithUserResponse": {
+        "domain.FlowScope": {
+            "type": "string",
+            "enum": [
+                "user",
+                "group"
+            ],
+            "x-enum-comments": {
+                "FlowScopeGroup": "グループタスク",
+                "FlowScopeUser": "自分が作成・担当するタスク"
+            },
+            "x-enum-varnames": [
+                "FlowScopeUser",
+                "FlowScopeGroup"
+            ]
+        },
+        "domain.GroupSettings": {
             "type": "object",
             "properties": {
-                "group_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "allow_member_invite": {
+                    "description": "メンバーの招待許可",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "allow_schedule_details": {
+                    "type": "boolean"
                 },
-                "joined_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "default_privacy_level": {
+                    "description": "予定共有グループ用",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.PrivacyLevel"
+                        }
+                    ]
                 },
-                "role": {
-                    "type": "string",
-                    "example": "MEMBER"
+                "enable_gantt_chart": {
+                    "description": "プロジェクトグループ用",
+                    "type": "boolean"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "enable_notifications": {
+                    "description": "通知有効",
+                    "type": "boolean"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/UserInfo"
+                "enable_task_dependency": {
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "description": "公開/非公開",
+                    "type": "boolean"
+                },
+                "require_approval": {
+                    "description": "参加承認制",
+                    "type": "boolean"
                 }
             }
         },
-        "MessageResponse": {
+        "domain.HolidayPolicy": {
+            "type": "string",
+            "enum": [
+                "KEEP",
+                "NEXT_BUSINESS_DAY",
+                "PREVIOUS_BUSINESS_DAY"
+            ],
+            "x-enum-varnames": [
+                "HolidayPolicyKeep",
+                "HolidayPolicyNextBusinessDay",
+                "HolidayPolicyPreviousBusinessDay"
+            ]
+        },
+        "domain.InboundEmailStatus": {
+            "type": "string",
+            "enum": [
+                "CREATED",
+                "DUPLICATE",
+                "UNKNOWN_RECIPIENT",
+                "SENDER_NOT_ALLOWED",
+                "SPAM",
+                "RATE_LIMITED"
+            ],
+            "x-enum-varnames": [
+                "InboundEmailCreated",
+                "InboundEmailDuplicate",
+                "InboundEmailUnknownRecipient",
+                "InboundEmailSenderNotAllowed",
+                "InboundEmailSpam",
+                "InboundEmailRateLimited"
+            ]
+        },
+        "domain.InviteeInfo": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "操作が正常に完了しました"
+                "email": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "phone": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "NotificationResponse": {
+        "domain.ListFilter": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "assignee_completed": {
+                    "description": "AssigneeIDと併用し、その担当者の完了状態で絞り込む",
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "assignee_id": {
+                    "description": "いずれかの担当者に一致",
+                    "type": "string"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "「重要なプロジェクト」タスクが割り当てられました"
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    },
-                    "example": {
-                        "\"priority\"": "\"HIGH\"}",
-                        "{\"task_id\"": "\"task-123\""
-                    }
+                "created_by": {
+                    "type": "string"
                 },
-                "sent_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:05:00Z"
+                "due_date_from": {
+                    "type": "string"
                 },
-                "status": {
-                    "type": "string",
-                    "example": "SENT"
+                "due_date_to": {
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "新しいタスクが割り当てられました"
+                "priority": {
+                    "$ref": "#/definitions/domain.Priority"
                 },
-                "type": {
-                    "type": "string",
-                    "example": "TASK_ASSIGNED"
+                "start_date_from": {
+                    "type": "string"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "start_date_to": {
+                    "type": "string"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "status": {
+                    "$ref": "#/definitions/domain.TaskStatus"
                 }
             }
         },
-        "PaginationInfo": {
+        "domain.Mention": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer",
-                    "example": 1
+                "author_id": {
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer",
-                    "example": 20
+                "created_at": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 100
+                "id": {
+                    "type": "string"
                 },
-                "total_pages": {
-                    "type": "integer",
-                    "example": 5
-                }
-            }
-        },
-        "PriorityBreakdownData": {
-            "type": "object",
-            "properties": {
-                "color": {
-                    "type": "string",
-                    "example": "#dc2626"
+                "mentioned_user_id": {
+                    "type": "string"
                 },
-                "count": {
-                    "type": "integer",
-                    "example": 8
+                "source_id": {
+                    "type": "string"
                 },
-                "display_name": {
-                    "type": "string",
-                    "example": "高"
+                "source_type": {
+                    "$ref": "#/definitions/domain.MentionSourceType"
+                },
+                "task_id": {
+                    "type": "string"
                 }
             }
         },
-        "ProgressLevelData": {
+        "domain.MentionSourceType": {
+            "type": "string",
+            "enum": [
+                "DESCRIPTION",
+                "COMMENT"
+            ],
+            "x-enum-varnames": [
+                "MentionSourceDescription",
+                "MentionSourceComment"
+            ]
+        },
+        "domain.MetricChange": {
             "type": "object",
             "properties": {
-                "color": {
-                    "type": "string",
-                    "example": "#22c55e"
-                },
-                "label": {
-                    "type": "string",
-                    "example": "良好"
+                "delta": {
+                    "description": "差（完了率はポイント）",
+                    "type": "number"
                 },
-                "percentage": {
-                    "type": "integer",
-                    "example": 85
+                "percent_change": {
+                    "description": "変化率（%）、比較対象が0の場合はnull",
+                    "type": "number"
                 }
             }
         },
-        "ProgressLevelResponse": {
+        "domain.Milestone": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/ProgressLevelData"
+                "created_at": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "created_by": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "ProgressSummaryResponse": {
+        "domain.MilestoneBurndown": {
             "type": "object",
             "properties": {
-                "data": {
+                "from": {
+                    "type": "string"
+                },
+                "milestone_id": {
+                    "type": "string"
+                },
+                "points": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/DailyStatsData"
+                        "$ref": "#/definitions/domain.BurndownPoint"
                     }
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "to": {
+                    "type": "string"
+                },
+                "total_tasks": {
+                    "type": "integer"
                 }
             }
         },
-        "RefreshTokenRequest": {
+        "domain.MilestoneProgress": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "completed_tasks": {
+                    "type": "integer"
+                },
+                "completion_rate": {
+                    "description": "完了率（0-100）",
+                    "type": "number"
+                },
+                "total_tasks": {
+                    "type": "integer"
                 }
             }
         },
-        "RefreshTokenResponse": {
+        "domain.MutationOp": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "MutationCreate",
+                "MutationUpdate",
+                "MutationDelete"
+            ]
+        },
+        "domain.MutationStatus": {
+            "type": "string",
+            "enum": [
+                "accepted",
+                "conflict",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "MutationAccepted",
+                "MutationConflict",
+                "MutationRejected"
+            ]
+        },
+        "domain.Operation": {
+            "type": "string",
+            "enum": [
+                "upsert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "OperationUpsert",
+                "OperationDelete"
+            ]
+        },
+        "domain.PeriodMetrics": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "access_token": {
-                            "type": "string",
-                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                        },
-                        "refresh_token": {
-                            "type": "string",
-                            "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                        },
-                        "token_type": {
-                            "type": "string",
-                            "example": "Bearer"
-                        }
-                    }
+                "completed": {
+                    "description": "期間内に完了したタスク数",
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Token refreshed successfully"
+                "completion_rate": {
+                    "description": "期限が期間内のタスクのうち期間の終わりまでに完了した割合（0-100）",
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "created": {
+                    "description": "期間内に作成されたタスク数",
+                    "type": "integer"
+                },
+                "due": {
+                    "description": "期限が期間内（期間の終わりまで）のタスク数",
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "description": "期限が期間内のタスクのうち期限までに完了しなかったタスク数",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "RegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
+        "domain.Priority": {
+            "type": "string",
+            "enum": [
+                "LOW",
+                "MEDIUM",
+                "HIGH"
+            ],
+            "x-enum-varnames": [
+                "PriorityLow",
+                "PriorityMedium",
+                "PriorityHigh"
+            ]
+        },
+        "domain.PrivacyLevel": {
+            "type": "string",
+            "enum": [
+                "NONE",
+                "BUSY",
+                "TITLE",
+                "DETAILS"
+            ],
+            "x-enum-comments": {
+                "PrivacyLevelBusy": "予定ありのみ",
+                "PrivacyLevelDetails": "詳細まで",
+                "PrivacyLevelNone": "非表示",
+                "PrivacyLevelTitle": "タイトルまで"
+            },
+            "x-enum-varnames": [
+                "PrivacyLevelNone",
+                "PrivacyLevelBusy",
+                "PrivacyLevelTitle",
+                "PrivacyLevelDetails"
+            ]
+        },
+        "domain.ProfileRelationship": {
+            "type": "string",
+            "enum": [
+                "SELF",
+                "FRIEND",
+                "REQUEST_SENT",
+                "REQUEST_RECEIVED",
+                "NONE",
+                "ANONYMOUS"
+            ],
+            "x-enum-comments": {
+                "ProfileRelationshipAnonymous": "ログインしていない閲覧者",
+                "ProfileRelationshipFriend": "友達",
+                "ProfileRelationshipNone": "関係なし",
+                "ProfileRelationshipRequestReceived": "閲覧者が友達申請を受信済み",
+                "ProfileRelationshipRequestSent": "閲覧者が友達申請を送信済み",
+                "ProfileRelationshipSelf": "本人"
+            },
+            "x-enum-varnames": [
+                "ProfileRelationshipSelf",
+                "ProfileRelationshipFriend",
+                "ProfileRelationshipRequestSent",
+                "ProfileRelationshipRequestReceived",
+                "ProfileRelationshipNone",
+                "ProfileRelationshipAnonymous"
+            ]
+        },
+        "domain.ProfileVisibility": {
+            "type": "string",
+            "enum": [
+                "PUBLIC",
+                "FRIENDS",
+                "PRIVATE"
+            ],
+            "x-enum-comments": {
+                "ProfileVisibilityFriends": "友達のみ",
+                "ProfileVisibilityPrivate": "本人のみ",
+                "ProfileVisibilityPublic": "ログインしていない人を含む全員（デフォルト）"
+            },
+            "x-enum-varnames": [
+                "ProfileVisibilityPublic",
+                "ProfileVisibilityFriends",
+                "ProfileVisibilityPrivate"
+            ]
+        },
+        "domain.ReminderTiming": {
+            "type": "string",
+            "enum": [
+                "WORKING_HOURS",
+                "ANYTIME"
             ],
+            "x-enum-varnames": [
+                "ReminderTimingWorkingHours",
+                "ReminderTimingAnytime"
+            ]
+        },
+        "domain.RenderedTemplate": {
+            "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "event_type": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8,
-                    "example": "password123"
+                "locale": {
+                    "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 3,
-                    "example": "johndoe"
+                "message": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "RegisterResponse": {
+        "domain.SeriesGranularity": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-comments": {
+                "SeriesGranularityWeek": "月曜日始まり"
+            },
+            "x-enum-varnames": [
+                "SeriesGranularityDay",
+                "SeriesGranularityWeek",
+                "SeriesGranularityMonth"
+            ]
+        },
+        "domain.SeriesPoint": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "email": {
-                            "type": "string",
-                            "example": "user@example.com"
-                        },
-                        "user_id": {
-                            "type": "string",
-                            "example": "123e4567-e89b-12d3-a456-426614174000"
-                        },
-                        "username": {
-                            "type": "string",
-                            "example": "johndoe"
-                        }
-                    }
+                "completed": {
+                    "description": "期間内に完了したタスク数",
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "User registered successfully"
+                "completion_rate": {
+                    "description": "期限が期間内のタスクのうち期間の終わりまでに完了した割合（0-100）",
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "created": {
+                    "description": "期間内に作成されたタスク数",
+                    "type": "integer"
+                },
+                "due": {
+                    "description": "期限が期間内（期間の終わりまで）のタスク数",
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "description": "期限が期間内のタスクのうち期限までに完了しなかったタスク数",
+                    "type": "integer"
+                },
+                "rolling_average": {
+                    "description": "移動平均を指定した場合のみ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SeriesRollingAverage"
+                        }
+                    ]
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "SendFriendRequestRequest": {
+        "domain.SeriesRollingAverage": {
             "type": "object",
-            "required": [
-                "addressee_id"
-            ],
             "properties": {
-                "addressee_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "completed": {
+                    "type": "number"
                 },
-                "message": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "example": "友達になりませんか？"
+                "created": {
+                    "type": "number"
                 }
             }
         },
-        "SuccessResponse": {
+        "domain.StatsComparison": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "操作が正常に完了しました"
+                "changes": {
+                    "$ref": "#/definitions/domain.ComparisonChanges"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "current": {
+                    "$ref": "#/definitions/domain.PeriodMetrics"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "period": {
+                    "$ref": "#/definitions/domain.ComparisonPeriod"
+                },
+                "previous": {
+                    "$ref": "#/definitions/domain.PeriodMetrics"
                 }
             }
         },
-        "TaskCreateResponse": {
+        "domain.StatsSeries": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/TaskResponse"
+                "from": {
+                    "type": "string"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Task created successfully"
+                "granularity": {
+                    "$ref": "#/definitions/domain.SeriesGranularity"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SeriesPoint"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "window": {
+                    "type": "integer"
                 }
             }
         },
-        "TaskDeleteResponse": {
+        "domain.Task": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Task deleted successfully"
+                "actual_minutes": {
+                    "description": "実績工数（分）",
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "assignee_id": {
+                    "description": "主担当者（最初の担当者）",
+                    "type": "string"
+                },
+                "assignees": {
+                    "description": "複数担当者",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TaskAssignee"
+                    }
+                },
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
+                },
+                "checklist": {
+                    "description": "説明から抽出したチェックリスト",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ChecklistItem"
+                    }
+                },
+                "completed_at": {
+                    "description": "完了日時",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "estimate_minutes": {
+                    "description": "見積もりと実績",
+                    "type": "integer"
+                },
+                "estimate_points": {
+                    "description": "見積もりポイント",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_overdue": {
+                    "type": "boolean"
+                },
+                "priority": {
+                    "$ref": "#/definitions/domain.Priority"
+                },
+                "require_all_assignees": {
+                    "description": "全担当者の完了でタスク完了とするか",
+                    "type": "boolean"
+                },
+                "start_date": {
+                    "description": "着手予定日時",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.TaskStatus"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "TaskGetResponse": {
+        "domain.TaskAssignee": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/TaskResponse"
+                "assigned_at": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "completed_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "TaskListResponse": {
+        "domain.TaskComment": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "page": {
-                            "type": "integer",
-                            "example": 1
-                        },
-                        "page_size": {
-                            "type": "integer",
-                            "example": 10
-                        },
-                        "tasks": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/TaskResponse"
-                            }
-                        },
-                        "total_count": {
-                            "type": "integer",
-                            "example": 50
-                        }
-                    }
+                "comment": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "TaskRequest": {
+        "domain.TaskEventType": {
+            "type": "string",
+            "enum": [
+                "CREATED",
+                "FIELD_CHANGED",
+                "STATUS_CHANGED"
+            ],
+            "x-enum-comments": {
+                "TaskEventCreated": "作成（全フィールドを含む）",
+                "TaskEventFieldChanged": "ステータス以外のフィールドの変更",
+                "TaskEventStatusChanged": "ステータスの変更（付随するフィールドの変更を含む）"
+            },
+            "x-enum-varnames": [
+                "TaskEventCreated",
+                "TaskEventFieldChanged",
+                "TaskEventStatusChanged"
+            ]
+        },
+        "domain.TaskLinkType": {
+            "type": "string",
+            "enum": [
+                "LINK",
+                "DOCUMENT",
+                "DESIGN",
+                "GITHUB_ISSUE",
+                "GITHUB_PULL_REQUEST",
+                "CALENDAR_EVENT"
+            ],
+            "x-enum-varnames": [
+                "TaskLinkTypeLink",
+                "TaskLinkTypeDocument",
+                "TaskLinkTypeDesign",
+                "TaskLinkTypeGitHubIssue",
+                "TaskLinkTypeGitHubPullRequest",
+                "TaskLinkTypeCalendarEvent"
+            ]
+        },
+        "domain.TaskStatsRecord": {
             "type": "object",
-            "properties": {
-                "assignee_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+            "properties": {
+                "actual_minutes": {
+                    "type": "integer"
+                },
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "category": {
-                    "type": "string",
-                    "enum": [
-                        "WORK",
-                        "PERSONAL",
-                        "STUDY",
-                        "HEALTH",
-                        "SHOPPING",
-                        "OTHER"
-                    ],
-                    "example": "WORK"
+                    "$ref": "#/definitions/domain.Category"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "タスクの詳細説明"
+                "completed_at": {
+                    "type": "string"
+                },
+                "completed_late": {
+                    "description": "期限を過ぎて完了",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
                 },
                 "due_date": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2024-12-31T23:59:59Z"
+                    "type": "string"
+                },
+                "estimate_minutes": {
+                    "type": "integer"
+                },
+                "estimate_points": {
+                    "type": "integer"
+                },
+                "is_overdue": {
+                    "description": "nowの時点で期限を過ぎて未完了",
+                    "type": "boolean"
+                },
+                "lead_time_hours": {
+                    "description": "作成から完了までの時間（完了したタスクのみ）",
+                    "type": "number"
                 },
                 "priority": {
-                    "type": "string",
-                    "enum": [
-                        "LOW",
-                        "MEDIUM",
-                        "HIGH"
-                    ],
-                    "example": "HIGH"
+                    "$ref": "#/definitions/domain.Priority"
                 },
                 "status": {
-                    "type": "string",
-                    "enum": [
-                        "TODO",
-                        "IN_PROGRESS",
-                        "DONE"
-                    ],
-                    "example": "TODO"
+                    "$ref": "#/definitions/domain.TaskStatus"
                 },
-                "title": {
-                    "type": "string",
-                    "minLength": 1,
-                    "example": "重要なタスク"
+                "task_id": {
+                    "type": "string"
                 }
             }
         },
-        "TaskResponse": {
+        "domain.TaskStatus": {
+            "type": "string",
+            "enum": [
+                "TODO",
+                "IN_PROGRESS",
+                "DONE"
+            ],
+            "x-enum-varnames": [
+                "TaskStatusTodo",
+                "TaskStatusInProgress",
+                "TaskStatusDone"
+            ]
+        },
+        "domain.ThumbnailStatus": {
+            "type": "string",
+            "enum": [
+                "NONE",
+                "PENDING",
+                "READY",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "ThumbnailNone",
+                "ThumbnailPending",
+                "ThumbnailReady",
+                "ThumbnailFailed"
+            ]
+        },
+        "domain.Timeline": {
             "type": "object",
             "properties": {
-                "assignee_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "critical_path": {
+                    "description": "クリティカルパス上のタスクID（最早開始順）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "category": {
-                    "type": "string",
-                    "example": "WORK"
+                "from": {
+                    "type": "string"
                 },
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "group_id": {
+                    "type": "string"
                 },
-                "created_by": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Milestone"
+                    }
                 },
-                "description": {
-                    "type": "string",
-                    "example": "タスクの詳細説明"
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TimelineTask"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "タスク数が上限を超えたため一部のみ返しているか",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.TimelineTask": {
+            "type": "object",
+            "properties": {
+                "assignee_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "critical": {
+                    "description": "クリティカルパス上のタスクか",
+                    "type": "boolean"
+                },
+                "depends_on": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "due_date": {
-                    "type": "string",
-                    "example": "2024-12-31T23:59:59Z"
+                    "type": "string"
                 },
                 "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "type": "string"
                 },
-                "is_overdue": {
-                    "type": "boolean",
-                    "example": false
+                "milestone_id": {
+                    "type": "string"
                 },
                 "priority": {
-                    "type": "string",
-                    "example": "HIGH"
+                    "$ref": "#/definitions/domain.Priority"
+                },
+                "slack_hours": {
+                    "description": "全体の完了を遅らせずに遅延できる時間",
+                    "type": "number"
+                },
+                "start_date": {
+                    "type": "string"
                 },
                 "status": {
-                    "type": "string",
-                    "example": "TODO"
+                    "$ref": "#/definitions/domain.TaskStatus"
                 },
                 "title": {
-                    "type": "string",
-                    "example": "重要なタスク"
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "type": "string"
                 }
             }
         },
-        "TaskSummary": {
+        "domain.Today": {
             "type": "object",
             "properties": {
-                "completed_at": {
-                    "type": "string",
-                    "example": "2024-01-01T12:00:00Z"
+                "date": {
+                    "description": "\"2006-01-02\"",
+                    "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "due_today": {
+                    "description": "今日が期限のタスク（完了済みを含む）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Task"
+                    }
                 },
-                "status": {
-                    "type": "string",
-                    "example": "DONE"
+                "overdue": {
+                    "description": "前日までに期限を過ぎた未完了のタスク",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Task"
+                    }
                 },
-                "title": {
-                    "type": "string",
-                    "example": "重要なタスク"
+                "starting_today": {
+                    "description": "今日着手予定の未完了のタスク",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Task"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "unread_notification_count": {
+                    "type": "integer"
                 }
             }
         },
-        "TaskUpdateResponse": {
+        "domain.VelocityReport": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/TaskResponse"
+                "average_points_per_week": {
+                    "type": "number"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Task updated successfully"
+                "average_tasks_per_week": {
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CategoryVelocity"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/domain.VelocityStats"
+                },
+                "weeks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WeeklyVelocity"
+                    }
                 }
             }
         },
-        "UnreadCountResponse": {
+        "domain.VelocityStats": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer",
-                    "example": 5
+                "accuracy_rate": {
+                    "description": "見積もり精度（0-100）",
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "actual_minutes": {
+                    "description": "見積もりと実績の両方があるタスクの実績合計",
+                    "type": "integer"
+                },
+                "completed_points": {
+                    "type": "integer"
+                },
+                "completed_tasks": {
+                    "type": "integer"
+                },
+                "estimate_ratio": {
+                    "description": "実績/見積もり（1より大きいと見積もり不足）",
+                    "type": "number"
+                },
+                "estimated_minutes": {
+                    "description": "見積もり（分）が設定された完了タスクの見積もり合計",
+                    "type": "integer"
+                },
+                "measured_tasks": {
+                    "description": "見積もりと実績の両方があるタスク数",
+                    "type": "integer"
                 }
             }
         },
-        "UpdateGroupRequest": {
+        "domain.WeeklyReport": {
             "type": "object",
             "properties": {
-                "description": {
+                "completed": {
+                    "description": "完了日時の順（最大件数まで）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WeeklyReportTask"
+                    }
+                },
+                "completed_count": {
+                    "description": "週内に完了したタスク数",
+                    "type": "integer"
+                },
+                "estimated_minutes": {
+                    "description": "完了したタスクの見積もり工数の合計（分）",
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "on_time_count": {
+                    "description": "期限が週内のタスクのうち期限までに完了した数",
+                    "type": "integer"
+                },
+                "slipped": {
+                    "description": "遅れの大きい順（最大件数まで）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WeeklyReportTask"
+                    }
+                },
+                "slipped_count": {
+                    "description": "期限が週内のタスクのうち期限を過ぎて完了した、または週末時点で未完了の数",
+                    "type": "integer"
+                },
+                "timezone": {
                     "type": "string",
-                    "maxLength": 500,
-                    "example": "新製品開発プロジェクトのチーム"
+                    "example": "Asia/Tokyo"
                 },
-                "name": {
+                "top_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WeeklyReportCategory"
+                    }
+                },
+                "tracked_minutes": {
+                    "description": "完了したタスクの実績工数の合計（分）",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "week": {
+                    "description": "ISO週",
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "プロジェクトチーム"
+                    "example": "2024-W23"
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.GroupSettings"
+                "week_end": {
+                    "type": "string"
+                },
+                "week_start": {
+                    "type": "string"
                 }
             }
         },
-        "UpdateMemberRoleRequest": {
+        "domain.WeeklyReportCategory": {
             "type": "object",
-            "required": [
-                "role"
-            ],
             "properties": {
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "OWNER",
-                        "ADMIN",
-                        "MEMBER"
-                    ],
-                    "example": "ADMIN"
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
+                },
+                "completed_count": {
+                    "type": "integer"
+                },
+                "tracked_minutes": {
+                    "type": "integer"
                 }
             }
         },
-        "UserInfo": {
+        "domain.WeeklyReportSubscription": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "created_at": {
+                    "type": "string"
                 },
-                "id": {
+                "last_sent_week": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "2024-W23"
                 },
-                "username": {
+                "timezone": {
+                    "description": "週の区切りと配信時刻のタイムゾーン",
                     "type": "string",
-                    "example": "user123"
+                    "example": "Asia/Tokyo"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "UserRelationshipResponse": {
+        "domain.WeeklyReportTask": {
             "type": "object",
             "properties": {
-                "is_blocked": {
-                    "type": "boolean",
-                    "example": false
+                "actual_minutes": {
+                    "type": "integer"
                 },
-                "is_friend": {
-                    "type": "boolean",
-                    "example": true
+                "category": {
+                    "$ref": "#/definitions/domain.Category"
                 },
-                "request_received": {
-                    "type": "boolean",
-                    "example": false
+                "completed_at": {
+                    "type": "string"
                 },
-                "request_sent": {
-                    "type": "boolean",
-                    "example": false
-                }
-            }
-        },
-        "WeeklyPreviewData": {
-            "type": "object",
-            "properties": {
-                "daily_preview": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/DailyPreviewData"
-                    }
+                "due_date": {
+                    "type": "string"
                 },
-                "total_tasks": {
-                    "type": "integer",
-                    "example": 15
+                "id": {
+                    "type": "string"
                 },
-                "week_end": {
-                    "type": "string",
-                    "example": "2024-01-07"
+                "priority": {
+                    "$ref": "#/definitions/domain.Priority"
                 },
-                "week_start": {
-                    "type": "string",
-                    "example": "2024-01-01"
+                "slip_hours": {
+                    "description": "期限からの遅れ（時間、未完了の場合は週末時点）",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "WeeklyStatsData": {
+        "domain.WeeklyVelocity": {
             "type": "object",
             "properties": {
+                "accuracy_rate": {
+                    "description": "見積もり精度（0-100）",
+                    "type": "number"
+                },
+                "actual_minutes": {
+                    "description": "見積もりと実績の両方があるタスクの実績合計",
+                    "type": "integer"
+                },
+                "completed_points": {
+                    "type": "integer"
+                },
                 "completed_tasks": {
-                    "type": "integer",
-                    "example": 35
+                    "type": "integer"
                 },
-                "completion_rate": {
-                    "type": "number",
-                    "example": 70
+                "estimate_ratio": {
+                    "description": "実績/見積もり（1より大きいと見積もり不足）",
+                    "type": "number"
                 },
-                "daily_stats": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/DailyStatsData"
-                    }
+                "estimated_minutes": {
+                    "description": "見積もり（分）が設定された完了タスクの見積もり合計",
+                    "type": "integer"
                 },
-                "total_tasks": {
-                    "type": "integer",
-                    "example": 50
+                "measured_tasks": {
+                    "description": "見積もりと実績の両方があるタスク数",
+                    "type": "integer"
                 },
                 "week_end": {
-                    "type": "string",
-                    "example": "2024-01-07"
+                    "type": "string"
                 },
                 "week_start": {
-                    "type": "string",
-                    "example": "2024-01-01"
+                    "type": "string"
                 }
             }
         },
-        "WeeklyStatsResponse": {
+        "domain.WorkingHours": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/WeeklyStatsData"
+                "daily_capacity_minutes": {
+                    "description": "0の場合は勤務時間から算出",
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "domain.GroupSettings": {
-            "type": "object",
-            "properties": {
-                "allow_member_invite": {
-                    "description": "メンバーの招待許可",
-                    "type": "boolean"
+                "end_time": {
+                    "description": "\"HH:MM\"",
+                    "type": "string"
                 },
-                "allow_schedule_details": {
-                    "type": "boolean"
+                "holiday_policy": {
+                    "description": "期限が祝日・勤務日以外になった場合の扱い",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.HolidayPolicy"
+                        }
+                    ]
                 },
-                "default_privacy_level": {
-                    "description": "予定共有グループ用",
+                "reminder_timing": {
+                    "description": "リマインダー・エスカレーションの通知を送る時間帯",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.PrivacyLevel"
+                            "$ref": "#/definitions/domain.ReminderTiming"
                         }
                     ]
                 },
-                "enable_gantt_chart": {
-                    "description": "プロジェクトグループ用",
-                    "type": "boolean"
+                "start_time": {
+                    "description": "\"HH:MM\"",
+                    "type": "string"
                 },
-                "enable_notifications": {
-                    "description": "通知有効",
-                    "type": "boolean"
+                "timezone": {
+                    "type": "string"
                 },
-                "enable_task_dependency": {
-                    "type": "boolean"
+                "updated_at": {
+                    "type": "string"
                 },
-                "is_public": {
-                    "description": "公開/非公開",
-                    "type": "boolean"
+                "user_id": {
+                    "type": "string"
                 },
-                "require_approval": {
-                    "description": "参加承認制",
-                    "type": "boolean"
+                "work_days": {
+                    "description": "勤務曜日（0=日曜）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "domain.InviteeInfo": {
+        "domain.Workload": {
             "type": "object",
             "properties": {
-                "email": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DailyWorkload"
+                    }
+                },
+                "from": {
                     "type": "string"
                 },
-                "phone": {
+                "over_capacity_days": {
+                    "type": "integer"
+                },
+                "timezone": {
                     "type": "string"
                 },
-                "username": {
+                "to": {
+                    "type": "string"
+                },
+                "total_capacity_minutes": {
+                    "type": "integer"
+                },
+                "total_planned_minutes": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.PrivacyLevel": {
-            "type": "string",
-            "enum": [
-                "NONE",
-                "BUSY",
-                "TITLE",
-                "DETAILS"
-            ],
-            "x-enum-comments": {
-                "PrivacyLevelBusy": "予定ありのみ",
-                "PrivacyLevelDetails": "詳細まで",
-                "PrivacyLevelNone": "非表示",
-                "PrivacyLevelTitle": "タイトルまで"
-            },
-            "x-enum-varnames": [
-                "PrivacyLevelNone",
-                "PrivacyLevelBusy",
-                "PrivacyLevelTitle",
-                "PrivacyLevelDetails"
-            ]
+        "domain.WorkloadWarning": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string"
+                },
+                "capacity_minutes": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "planned_minutes": {
+                    "type": "integer"
+                }
+            }
         },
         "dto.FriendshipResponse": {
             "type": "object",
@@ -5553,18 +25117,55 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.GroupTreeResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GroupTreeResponse"
+                    }
+                },
+                "group": {
+                    "$ref": "#/definitions/GroupResponse"
+                },
+                "stats": {
+                    "$ref": "#/definitions/GroupStatsResponse"
+                },
+                "total": {
+                    "description": "サブチームを含めた統計",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/GroupStatsResponse"
+                        }
+                    ]
+                }
+            }
+        },
         "dto.InvitationResponse": {
             "type": "object",
             "properties": {
                 "accepted_at": {
                     "type": "string"
                 },
+                "bounce_reason": {
+                    "type": "string"
+                },
                 "code": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "deep_link": {
+                    "type": "string"
+                },
+                "email_send_count": {
+                    "type": "integer"
+                },
+                "email_sent_at": {
+                    "type": "string"
+                },
                 "expires_at": {
                     "type": "string"
                 },
@@ -5592,6 +25193,9 @@ const docTemplate = `{
                 "method": {
                     "type": "string"
                 },
+                "qr_code_url": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -5601,6 +25205,9 @@ const docTemplate = `{
                 "type": {
                     "type": "string"
                 },
+                "universal_link": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -5667,6 +25274,41 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "parsing.Token": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/parsing.TokenKind"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "parsing.TokenKind": {
+            "type": "string",
+            "enum": [
+                "TEXT",
+                "DATE",
+                "TIME",
+                "CATEGORY",
+                "PRIORITY"
+            ],
+            "x-enum-comments": {
+                "TokenCategory": "#work などのカテゴリ指定",
+                "TokenDate": "日付表現（明日、tomorrow、12/25 など）",
+                "TokenPriority": "!high などの優先度指定",
+                "TokenText": "タイトルの一部",
+                "TokenTime": "時刻表現（15時、3pm など）"
+            },
+            "x-enum-varnames": [
+                "TokenText",
+                "TokenDate",
+                "TokenTime",
+                "TokenCategory",
+                "TokenPriority"
+            ]
         }
     },
     "securityDefinitions": {
@@ -5711,8 +25353,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "高機能タスク管理システムのREST API\n\n## 概要\nYotei+は、個人およびチーム向けの包括的なタスク管理システムです。\n\n## 主要機能\n- 🔐 **認証・認可**: JWT ベースの安全な認証システム\n- 📋 **タスク管理**: 作成、更新、削除、割り当て機能\n- 📊 **統計・分析**: 詳細な進捗統計とダッシュボード\n- 🔔 **通知システム**: リアルタイム通知機能\n- 👥 **ユーザー管理**: プロフィール管理と権限制御\n\n## 認証方法\nこのAPIはJWTトークンベースの認証を使用します。\n1. `/api/v1/auth/login` でログインしてアクセストークンを取得\n2. 保護されたエンドポイントには `Authorization: Bearer <token>` ヘッダーを付与",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
-	LeftDelim:        "{{",
-	RightDelim:       "}}",
+	LeftDelim:        "[[",
+	RightDelim:       "]]",
 }
 
 func init() {
@@ -0,0 +1,1665 @@
+// Package v2 Code generated by swaggo/swag. DO NOT EDIT
+package v2
+
+import "github.com/swaggo/swag"
+
+const docTemplatev2 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "フィルタリング、ページング、ソート機能付きでタスク一覧を取得します。不正なパラメータは400を返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク一覧取得（v2）",
+                "parameters": [
+                    {
+                        "enum": [
+                            "TODO",
+                            "IN_PROGRESS",
+                            "DONE"
+                        ],
+                        "type": "string",
+                        "description": "ステータスフィルタ",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "LOW",
+                            "MEDIUM",
+                            "HIGH"
+                        ],
+                        "type": "string",
+                        "description": "優先度フィルタ",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "WORK",
+                            "PERSONAL",
+                            "STUDY",
+                            "HEALTH",
+                            "SHOPPING",
+                            "OTHER"
+                        ],
+                        "type": "string",
+                        "description": "カテゴリフィルタ",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "担当者IDフィルタ（いずれかの担当者に一致）",
+                        "name": "assignee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "assignee_idで指定した担当者の完了状態フィルタ",
+                        "name": "assignee_completed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "作成者IDフィルタ",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限日FROM",
+                        "name": "due_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "期限日TO",
+                        "name": "due_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "着手予定日FROM",
+                        "name": "start_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "着手予定日TO",
+                        "name": "start_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "this_week"
+                        ],
+                        "type": "string",
+                        "description": "着手予定日の期間指定（this_week: 今週（月曜開始）に着手予定）",
+                        "name": "starting",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "ページサイズ",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "title",
+                            "priority",
+                            "status",
+                            "due_date",
+                            "start_date"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "ソートフィールド",
+                        "name": "sort_field",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "ソート方向",
+                        "name": "sort_direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しいタスクを作成します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク作成（v2）",
+                "parameters": [
+                    {
+                        "description": "タスク作成情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "タスク作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "未完了のタスク数が上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在認証されているユーザーに割り当てられたタスクを取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "自分のタスク取得（v2）",
+                "responses": {
+                    "200": {
+                        "description": "自分のタスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2ListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "現在認証されているユーザーが作成または担当する、期限が過ぎているタスクの一覧を取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "期限切れタスク取得（v2）",
+                "responses": {
+                    "200": {
+                        "description": "期限切れタスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2ListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/quick-add": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "「レポート提出 明日 15時 #work !high」のような文字列を解析してタスクを作成します。preview=true の場合は解析結果のみを返します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "クイック追加（v2）",
+                "parameters": [
+                    {
+                        "description": "クイック追加情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/QuickAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解析結果（preview=true）",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2QuickAddPreviewResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "タスク作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2QuickAddResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "未完了のタスク数が上限に達している",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "キーワードでタスクを検索します（タイトルと説明が対象）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク検索（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "検索クエリ",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "結果の最大数",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク検索成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "パラメータが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーに割り当てられたタスクを取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "特定ユーザーのタスク取得（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ユーザーID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ユーザータスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2ListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのタスクを取得します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク取得（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのタスクを更新します（指定したフィールドのみ更新）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク更新（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "タスク更新情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたIDのタスクを削除します",
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク削除（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "タスク削除成功"
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを削除する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assign": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたタスクに担当者を追加します。担当者が期限日にキャパシティ超過となる場合はwarningsを返します（割り当ては行われます）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク割り当て（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "割り当て情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AssignTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "タスク割り当て成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2AssignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクまたはユーザーが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "既に割り当て済み",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assignees/me/completion": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログインユーザーの担当分を完了または未完了にします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "自分の担当分の完了状態変更（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "完了状態",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AssignmentCompletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "完了状態変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない、または担当者ではない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/assignees/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたユーザーをタスクの担当者から外します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク担当者の解除（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "担当者ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "担当者解除成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクまたは担当者が見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/estimate": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの見積もり（分・ポイント）と実績工数（分）を更新します。指定したフィールドのみ更新されます",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスク見積もり・実績更新（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "見積もり・実績",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskEstimateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "タスクの着手予定日時と期限を更新します。指定したフィールドのみ更新され、clear_start_dateで着手予定日時を消去できます。着手予定日時は期限以前である必要があります",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスクの着手予定日時・期限更新（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "着手予定日時・期限",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TaskScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定されたタスクのステータスを変更します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-v2"
+                ],
+                "summary": "タスクステータス変更（v2）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "タスクID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ステータス変更情報",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangeStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ステータス変更成功",
+                        "schema": {
+                            "$ref": "#/definitions/TaskV2DataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが無効",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "認証が必要",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "タスクを編集する権限なし",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "タスクが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "内部サーバーエラー",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AssignTaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "assignee_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "require_all_assignees": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "AssignmentCompletionRequest": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "ChangeStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "TODO",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "IN_PROGRESS"
+                }
+            }
+        },
+        "ErrorBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "機械判定用のエラーコード（UPPER_SNAKE_CASE）",
+                    "type": "string",
+                    "example": "TASK_NOT_FOUND"
+                },
+                "details": {
+                    "description": "入力エラーの詳細（フィールドごとの理由など）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ErrorDetail"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Task not found"
+                },
+                "request_id": {
+                    "description": "問い合わせ時に伝えるリクエストID（X-Request-IDと同じ値）",
+                    "type": "string",
+                    "example": "3f1c9a2e-7b4d-4e8a-9c1f-2d5e6a7b8c9d"
+                }
+            }
+        },
+        "ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "page_size"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "must be between 1 and 100"
+                }
+            }
+        },
+        "ErrorEnvelope": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/ErrorBody"
+                }
+            }
+        },
+        "PaginationV2": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "QuickAddPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "WORK"
+                },
+                "category_suggested": {
+                    "description": "#タグ・!優先度で指定せず、タイトルから推定したか",
+                    "type": "boolean",
+                    "example": false
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2024-12-02T15:00:00+09:00"
+                },
+                "has_time": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "HIGH"
+                },
+                "priority_suggested": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "title": {
+                    "type": "string",
+                    "example": "レポート提出"
+                },
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parsing.Token"
+                    }
+                }
+            }
+        },
+        "QuickAddRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "preview": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "レポート提出 明日 15時 #work !high"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
+        "TaskEstimateRequest": {
+            "type": "object",
+            "properties": {
+                "actual_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "estimate_points": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "TaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "WORK",
+                        "PERSONAL",
+                        "STUDY",
+                        "HEALTH",
+                        "SHOPPING",
+                        "OTHER"
+                    ],
+                    "example": "WORK"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "タスクの詳細説明"
+                },
+                "due_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "force": {
+                    "description": "作成時のみ: タイトルが似ている最近のタスクがあっても作成する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "group_id": {
+                    "description": "作成時のみ: グループタスクとして作成する。assignee_id を省略するとグループの自動割り当て方式で担当者を割り当てる",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "description": "作成時のみ: クライアントが生成したUUIDv7のID。オフライン中に作成したタスクを同期前から参照するために使用し、同じIDで再送すると作成済みのタスクを返す",
+                    "type": "string",
+                    "example": "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "LOW",
+                        "MEDIUM",
+                        "HIGH"
+                    ],
+                    "example": "HIGH"
+                },
+                "skip_auto_assign": {
+                    "description": "作成時のみ: 自動割り当てせずに担当者なしで作成する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-20T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "TODO",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "TODO"
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "重要なタスク"
+                }
+            }
+        },
+        "TaskScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "clear_start_date": {
+                    "description": "着手予定日時を消去する",
+                    "type": "boolean",
+                    "example": false
+                },
+                "due_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "start_date": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-12-20T09:00:00Z"
+                }
+            }
+        },
+        "TaskV2AssignResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskV2Response"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WorkloadWarning"
+                    }
+                }
+            }
+        },
+        "TaskV2DataResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskV2Response"
+                }
+            }
+        },
+        "TaskV2Estimate": {
+            "type": "object",
+            "properties": {
+                "actual_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "TaskV2ListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TaskV2Response"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/PaginationV2"
+                }
+            }
+        },
+        "TaskV2QuickAddPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/QuickAddPreviewResponse"
+                }
+            }
+        },
+        "TaskV2QuickAddResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/TaskV2Response"
+                },
+                "parsed": {
+                    "$ref": "#/definitions/QuickAddPreviewResponse"
+                }
+            }
+        },
+        "TaskV2Response": {
+            "type": "object",
+            "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TaskAssignee"
+                    }
+                },
+                "category": {
+                    "type": "string",
+                    "example": "WORK"
+                },
+                "completed_assignees": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-01-02T18:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "タスクの詳細説明"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "estimate": {
+                    "$ref": "#/definitions/TaskV2Estimate"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "is_overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "HIGH"
+                },
+                "require_all_assignees": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2024-12-20T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "TODO"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "重要なタスク"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "domain.TaskAssignee": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WorkloadWarning": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string"
+                },
+                "capacity_minutes": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "planned_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "parsing.Token": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/parsing.TokenKind"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "parsing.TokenKind": {
+            "type": "string",
+            "enum": [
+                "TEXT",
+                "DATE",
+                "TIME",
+                "CATEGORY",
+                "PRIORITY"
+            ],
+            "x-enum-comments": {
+                "TokenCategory": "#work などのカテゴリ指定",
+                "TokenDate": "日付表現（明日、tomorrow、12/25 など）",
+                "TokenPriority": "!high などの優先度指定",
+                "TokenText": "タイトルの一部",
+                "TokenTime": "時刻表現（15時、3pm など）"
+            },
+            "x-enum-varnames": [
+                "TokenText",
+                "TokenDate",
+                "TokenTime",
+                "TokenCategory",
+                "TokenPriority"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT認証トークン。値の形式: \"Bearer {token}\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfov2 holds exported Swagger Info so clients can modify it
+var SwaggerInfov2 = &swag.Spec{
+	Version:          "2.0",
+	Host:             "localhost:8080",
+	BasePath:         "/api/v2",
+	Schemes:          []string{},
+	Title:            "Yotei+ Task Management API v2",
+	Description:      "Yotei+ REST APIのバージョン2です。\n\n## v1からの変更点\n- エラーは `{\"error\": {\"code\", \"message\", \"details\", \"request_id\"}}` 形式で返します\n- 成功レスポンスは `success`・`message` を持たず、`data` にリソースを格納します\n- クエリパラメータの不正な値は無視せず400を返します\n- タスクの `assignee_id` を廃止し、見積もりを `estimate` にまとめました",
+	InfoInstanceName: "v2",
+	SwaggerTemplate:  docTemplatev2,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfov2.InstanceName(), SwaggerInfov2)
+}
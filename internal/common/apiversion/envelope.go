@@ -0,0 +1,41 @@
+package apiversion
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ErrorEnvelope はv2以降のエラーレスポンス
+// v1の{success, error, message}と異なり、エラー情報をerrorオブジェクトにまとめる
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+} // @name ErrorEnvelope
+
+// ErrorBody はエラーの内容
+type ErrorBody struct {
+	// 機械判定用のエラーコード（UPPER_SNAKE_CASE）
+	Code    string `json:"code" example:"TASK_NOT_FOUND"`
+	Message string `json:"message" example:"Task not found"`
+	// 入力エラーの詳細（フィールドごとの理由など）
+	Details []ErrorDetail `json:"details,omitempty"`
+	// 問い合わせ時に伝えるリクエストID（X-Request-IDと同じ値）
+	RequestID string `json:"request_id,omitempty" example:"3f1c9a2e-7b4d-4e8a-9c1f-2d5e6a7b8c9d"`
+} // @name ErrorBody
+
+// ErrorDetail は入力エラーの詳細
+type ErrorDetail struct {
+	Field  string `json:"field" example:"page_size"`
+	Reason string `json:"reason" example:"must be between 1 and 100"`
+} // @name ErrorDetail
+
+// AbortWithError はエラーエンベロープを返してリクエストを中断する
+func AbortWithError(c *gin.Context, status int, code, message string, details ...ErrorDetail) {
+	c.AbortWithStatusJSON(status, ErrorEnvelope{
+		Error: ErrorBody{
+			Code:      code,
+			Message:   message,
+			Details:   details,
+			RequestID: logger.RequestIDFromContext(c.Request.Context()),
+		},
+	})
+}
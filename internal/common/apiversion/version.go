@@ -0,0 +1,87 @@
+package apiversion
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// VersionHeader はレスポンスを処理したAPIバージョンを返すヘッダー
+	VersionHeader = "API-Version"
+	// DeprecationHeader は非推奨になった日時を返すヘッダー（RFC 9745）
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader は廃止予定日時を返すヘッダー（RFC 8594）
+	SunsetHeader = "Sunset"
+	// LinkHeader は後継バージョンのURLを返すヘッダー
+	LinkHeader = "Link"
+)
+
+// Version はAPIのメジャーバージョン
+type Version string
+
+const (
+	V1 Version = "v1"
+	V2 Version = "v2"
+)
+
+// Prefix はバージョンのURLプレフィックス（例: /api/v1）を返す
+func (v Version) Prefix() string {
+	return "/api/" + string(v)
+}
+
+// Group はバージョンのルーターグループを作成する
+// グループ内のすべてのレスポンスにAPI-Versionヘッダーを付与する
+func (v Version) Group(router gin.IRouter, handlers ...gin.HandlerFunc) *gin.RouterGroup {
+	group := router.Group(v.Prefix())
+	group.Use(func(c *gin.Context) {
+		c.Header(VersionHeader, string(v))
+		c.Next()
+	})
+	group.Use(handlers...)
+	return group
+}
+
+// Deprecation は後継バージョンへの移行が必要なエンドポイントの非推奨情報
+type Deprecation struct {
+	// 非推奨になった日時
+	Since time.Time
+	// 廃止予定日時（ゼロ値の場合はSunsetヘッダーを送信しない）
+	Sunset time.Time
+	// 後継バージョン
+	Successor Version
+}
+
+// Deprecated は非推奨のエンドポイントにDeprecation・Sunset・Linkヘッダーを付与するミドルウェア
+// Linkヘッダーにはリクエストパスのバージョン部分を後継バージョンに置き換えたURLを設定する
+func Deprecated(current Version, d Deprecation) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(d.Since.Unix(), 10)
+	var sunset string
+	if !d.Sunset.IsZero() {
+		sunset = d.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *gin.Context) {
+		c.Header(DeprecationHeader, deprecation)
+		if sunset != "" {
+			c.Header(SunsetHeader, sunset)
+		}
+		if successor := SuccessorPath(c.Request.URL.Path, current, d.Successor); successor != "" {
+			c.Writer.Header().Add(LinkHeader, "<"+successor+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
+
+// SuccessorPath はリクエストパスのバージョンプレフィックスを後継バージョンに置き換える
+// パスが現在のバージョンのものでない場合は空文字を返す
+func SuccessorPath(path string, current, successor Version) string {
+	rest, ok := strings.CutPrefix(path, current.Prefix())
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return ""
+	}
+	return successor.Prefix() + rest
+}
@@ -0,0 +1,186 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// ErrOpen は回路が開いていて外部サービスを呼び出さなかったことを表す
+var ErrOpen = errors.New("circuit breaker is open")
+
+const (
+	// DefaultFailureThreshold は回路を開くまでの既定の連続失敗回数
+	DefaultFailureThreshold = 5
+
+	// DefaultCooldown は回路を開いてから再度試すまでの既定の期間
+	DefaultCooldown = 30 * time.Second
+
+	// DefaultCallTimeout は外部サービスの1回の呼び出しの既定の制限時間
+	DefaultCallTimeout = 10 * time.Second
+)
+
+// 外部サービスごとのメトリクスの名前（%s は Breaker の名前）
+const (
+	MetricOpenedFormat   = "circuit_breaker_%s_opened_total"
+	MetricRejectedFormat = "circuit_breaker_%s_rejected_total"
+	MetricFailuresFormat = "circuit_breaker_%s_failures_total"
+)
+
+// State は回路の状態
+type State string
+
+const (
+	StateClosed   State = "closed"    // 通常どおり呼び出す
+	StateOpen     State = "open"      // 呼び出さずに ErrOpen を返す
+	StateHalfOpen State = "half_open" // 1件だけ試しに呼び出す
+)
+
+// Options は Breaker の設定（0以下の値は既定値を使う）
+type Options struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+	CallTimeout      time.Duration
+}
+
+// Breaker は外部サービス（SMTP・プッシュ通知など）の呼び出しを制限するサーキットブレーカー
+// 連続して失敗した場合は一定期間呼び出しをやめ、応答の遅いサービスがリクエストやワーカーを占有しないようにする
+// nilの場合は制限せずに呼び出す
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	timeout   time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New は新しいBreakerを作成する
+func New(name string, opts Options) *Breaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = DefaultFailureThreshold
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DefaultCooldown
+	}
+	if opts.CallTimeout <= 0 {
+		opts.CallTimeout = DefaultCallTimeout
+	}
+	return &Breaker{
+		name:      name,
+		threshold: opts.FailureThreshold,
+		cooldown:  opts.Cooldown,
+		timeout:   opts.CallTimeout,
+		now:       time.Now,
+		state:     StateClosed,
+	}
+}
+
+// Name は外部サービスの名前を返す
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State は現在の状態を返す
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && !b.now().Before(b.openedAt.Add(b.cooldown)) {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Do は回路が閉じている場合に fn を呼び出す
+// fn には呼び出しの制限時間を設定したコンテキストを渡す。回路が開いている場合は呼び出さずに ErrOpen を返す
+// 呼び出し元のコンテキストのキャンセルは外部サービスの失敗として数えない
+func (b *Breaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b == nil {
+		return fn(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !b.allow() {
+		metrics.Counter(fmt.Sprintf(MetricRejectedFormat, b.name)).Add(1)
+		return fmt.Errorf("%s: %w", b.name, ErrOpen)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+
+	err := fn(callCtx)
+	switch {
+	case err == nil:
+		b.record(true)
+	case ctx.Err() != nil:
+		// 呼び出し元が中断した場合は結果を数えない（試しの呼び出しは次のリクエストで再度行う）
+		b.release()
+	default:
+		metrics.Counter(fmt.Sprintf(MetricFailuresFormat, b.name)).Add(1)
+		b.record(false)
+	}
+	return err
+}
+
+// allow は呼び出してよいかを判定する（開いてから cooldown が経過した場合は1件だけ許可する）
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateClosed:
+		return true
+	case StateOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return true
+	default:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+}
+
+// record は呼び出しの結果から状態を更新する
+func (b *Breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		if b.state != StateOpen {
+			metrics.Counter(fmt.Sprintf(MetricOpenedFormat, b.name)).Add(1)
+		}
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release は結果を数えずに試しの呼び出しを終える
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
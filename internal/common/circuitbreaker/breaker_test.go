@@ -0,0 +1,123 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hryt430/Yotei+/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("smtp unavailable")
+
+// newTestBreaker は時刻を進められるBreakerを作成する
+func newTestBreaker(t *testing.T, name string) (*Breaker, *time.Time) {
+	t.Helper()
+
+	b := New(name, Options{FailureThreshold: 2, Cooldown: time.Minute, CallTimeout: time.Second})
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func fail(context.Context) error    { return errUnavailable }
+func succeed(context.Context) error { return nil }
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(t, "test_open")
+	rejected := metrics.Counter(fmt.Sprintf(MetricRejectedFormat, "test_open")).Value()
+
+	assert.ErrorIs(t, b.Do(context.Background(), fail), errUnavailable)
+	assert.Equal(t, StateClosed, b.State())
+	assert.ErrorIs(t, b.Do(context.Background(), fail), errUnavailable)
+	assert.Equal(t, StateOpen, b.State())
+
+	called := false
+	err := b.Do(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called)
+	assert.Equal(t, rejected+1, metrics.Counter(fmt.Sprintf(MetricRejectedFormat, "test_open")).Value())
+	assert.Equal(t, int64(1), metrics.Counter(fmt.Sprintf(MetricOpenedFormat, "test_open")).Value())
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(t, "test_reset")
+
+	require.Error(t, b.Do(context.Background(), fail))
+	require.NoError(t, b.Do(context.Background(), succeed))
+	require.Error(t, b.Do(context.Background(), fail))
+
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenAfterCooldown(t *testing.T) {
+	b, now := newTestBreaker(t, "test_half_open")
+	require.Error(t, b.Do(context.Background(), fail))
+	require.Error(t, b.Do(context.Background(), fail))
+
+	*now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	// 試しの呼び出しが失敗した場合は再度開く
+	assert.ErrorIs(t, b.Do(context.Background(), fail), errUnavailable)
+	assert.ErrorIs(t, b.Do(context.Background(), succeed), ErrOpen)
+
+	*now = now.Add(time.Minute)
+	assert.NoError(t, b.Do(context.Background(), succeed))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_AllowsSingleProbe(t *testing.T) {
+	b, now := newTestBreaker(t, "test_probe")
+	require.Error(t, b.Do(context.Background(), fail))
+	require.Error(t, b.Do(context.Background(), fail))
+	*now = now.Add(time.Minute)
+
+	err := b.Do(context.Background(), func(ctx context.Context) error {
+		// 試しの呼び出し中は他の呼び出しを拒否する
+		return b.Do(ctx, succeed)
+	})
+
+	assert.ErrorIs(t, err, ErrOpen)
+}
+
+func TestBreaker_SetsCallTimeout(t *testing.T) {
+	b, _ := newTestBreaker(t, "test_timeout")
+
+	err := b.Do(context.Background(), func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBreaker_CallerCancellationIsNotAFailure(t *testing.T) {
+	b, _ := newTestBreaker(t, "test_cancel")
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := b.Do(ctx, func(context.Context) error {
+			cancel()
+			return context.Canceled
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_NilCallsThrough(t *testing.T) {
+	var b *Breaker
+
+	assert.ErrorIs(t, b.Do(context.Background(), fail), errUnavailable)
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+// 利用状況の分析イベント
+const (
+	// AnalyticsTaskCreated はタスクの作成
+	AnalyticsTaskCreated = "task_created"
+	// AnalyticsSessionStarted はログイン（トークンの発行）
+	AnalyticsSessionStarted = "session_started"
+	// AnalyticsFeatureUsed は機能の利用（propertiesのfeatureに機能名を設定する）
+	AnalyticsFeatureUsed = "feature_used"
+)
+
+// AnalyticsTracker は利用状況の分析イベントを記録するインターフェース
+// 記録はバックグラウンドで行われ、失敗しても呼び出し元の処理には影響しない
+type AnalyticsTracker interface {
+	// Track はユーザーのイベントを記録する（オプトアウトしたユーザーのイベントは記録しない）
+	Track(ctx context.Context, userID, event string, properties map[string]interface{})
+}
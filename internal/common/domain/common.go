@@ -20,9 +20,67 @@ type UserValidator interface {
 	// ユーザーの存在確認
 	UserExists(ctx context.Context, userID string) (bool, error)
 
+	// 複数ユーザーの存在確認を一括で行い、存在しないユーザーIDを返す（全員存在する場合は空）
+	UsersExist(ctx context.Context, userIDs []string) ([]string, error)
+
 	// 単一ユーザー情報取得
 	GetUserInfo(ctx context.Context, userID string) (*UserInfo, error)
 
 	// 複数ユーザー情報の一括取得（N+1問題解決用）
 	GetUsersInfoBatch(ctx context.Context, userIDs []string) (map[string]*UserInfo, error)
 }
+
+// BlockChecker は統一されたユーザー間のブロック確認インターフェース
+type BlockChecker interface {
+	// どちらか一方がもう一方をブロックしているか
+	IsBlocked(ctx context.Context, userID1, userID2 string) (bool, error)
+
+	// 指定ユーザーとブロック関係にある（ブロックした・された）ユーザーID一覧
+	GetBlockedUserIDs(ctx context.Context, userID string) ([]string, error)
+}
+
+// PresenceTracker はWebSocket接続状況からユーザーのオンライン状態を記録するインターフェース
+type PresenceTracker interface {
+	// ユーザーの最初の接続が確立された
+	Connected(ctx context.Context, userID string) error
+
+	// 接続中のユーザーからハートビートを受信した
+	Heartbeat(ctx context.Context, userID string) error
+
+	// クライアントが離席中・復帰を通知した
+	SetAway(ctx context.Context, userID string, away bool) error
+
+	// ユーザーの全ての接続が切断された
+	Disconnected(ctx context.Context, userID string) error
+}
+
+// FeatureEvaluator は機能フラグの判定インターフェース（各モジュールのサービスが使用する）
+type FeatureEvaluator interface {
+	// ユーザーにフラグの機能を公開するか（フラグが存在しない・取得できない場合は false）
+	IsEnabled(ctx context.Context, key, userID string) bool
+}
+
+// actorIDKey はcontextに操作したユーザーのIDを格納するためのキー
+type actorIDKey struct{}
+
+// ginUserIDKey は認証ミドルウェアがGinのコンテキストに設定するユーザーIDのキー
+// Ginのコンテキストは文字列のキーのみ自身の値から返すため、リクエストのcontextとは別に参照する
+const ginUserIDKey = "user_id"
+
+// ContextWithActorID は操作したユーザーのIDを格納したcontextを返す（バックグラウンド処理で操作者を引き継ぐ場合に使用）
+func ContextWithActorID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorIDKey{}, userID)
+}
+
+// ActorIDFromContext は操作したユーザーのIDを返す（未設定の場合は空文字）
+// ContextWithActorIDで設定したID、なければ認証済みリクエストのユーザーIDを返す
+func ActorIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if userID, ok := ctx.Value(actorIDKey{}).(string); ok {
+		return userID
+	}
+	userID, _ := ctx.Value(ginUserIDKey).(string)
+	return userID
+}
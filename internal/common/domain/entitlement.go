@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// 契約プランで利用を制限する機能
+const (
+	// EntitlementRecurringTasks は繰り返しタスク
+	EntitlementRecurringTasks = "recurring_tasks"
+	// EntitlementShareLinks はタスクの公開共有リンク
+	EntitlementShareLinks = "share_links"
+)
+
+// ErrPlanUpgradeRequired は契約プランで利用できない機能を使おうとしたことを表す（402で返す）
+var ErrPlanUpgradeRequired = errors.New("plan upgrade required")
+
+// EntitlementChecker は契約プランで機能を利用できるかの確認インターフェース
+type EntitlementChecker interface {
+	// CheckEntitlement はユーザーがfeatureを利用できるか確認する（利用できない場合はErrPlanUpgradeRequired）
+	CheckEntitlement(ctx context.Context, userID, feature string) error
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 使用量の上限を設ける対象
+const (
+	// QuotaScopeUser はユーザーごとの上限
+	QuotaScopeUser = "user"
+	// QuotaScopeGroup はグループ（組織）ごとの上限
+	QuotaScopeGroup = "group"
+)
+
+// 上限を設けるリソース
+const (
+	// QuotaOpenTasks は未完了のタスク数（ユーザーは作成したタスク、グループはグループタスク）
+	QuotaOpenTasks = "open_tasks"
+	// QuotaGroups はオーナーのグループ数
+	QuotaGroups = "groups"
+	// QuotaAttachmentBytes は添付ファイルの合計サイズ（バイト）
+	QuotaAttachmentBytes = "attachment_bytes"
+	// QuotaInvitationsPerDay は直近24時間に作成した招待の数
+	QuotaInvitationsPerDay = "invitations_per_day"
+)
+
+// ErrQuotaExceeded は使用量が上限を超えることを表す（429で返す）
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaExceededError は上限を超えたリソースと使用量を表すエラー
+type QuotaExceededError struct {
+	Scope    string
+	Resource string
+	Limit    int64
+	Used     int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s %s limit is %d (used %d)", ErrQuotaExceeded, e.Scope, e.Resource, e.Limit, e.Used)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// QuotaChecker は使用量の上限の確認インターフェース（各モジュールのサービスが作成前に使用する）
+type QuotaChecker interface {
+	// CheckQuota はsubjectID（ユーザーまたはグループ）のresourceをamount増やしても上限を超えないか確認する
+	// 超える場合は*QuotaExceededErrorを返す
+	CheckQuota(ctx context.Context, scope, subjectID, resource string, amount int64) error
+}
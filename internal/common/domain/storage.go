@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrObjectNotFound はストレージに指定したキーのファイルがないことを表す
+var ErrObjectNotFound = errors.New("storage object not found")
+
+// FileStorage は添付ファイルなどのバイナリを保存するストレージゲートウェイ
+// キーは "attachments/<taskID>/<id>/original" のように "/" で区切った相対パスで指定する
+type FileStorage interface {
+	// Put はファイルを保存する（同じキーのファイルがある場合は置き換える）
+	Put(ctx context.Context, key, contentType string, body io.Reader) error
+	// Get はファイルを開く（ない場合はErrObjectNotFound）。呼び出し側で閉じる必要がある
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete はファイルを削除する（ない場合は何もしない）
+	Delete(ctx context.Context, key string) error
+}
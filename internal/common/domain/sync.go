@@ -0,0 +1,21 @@
+package domain
+
+import "context"
+
+// オフライン同期の変更フィードで扱うエンティティの種類
+const (
+	SyncEntityTask         = "task"
+	SyncEntityFriendship   = "friendship"
+	SyncEntityGroup        = "group"
+	SyncEntityNotification = "notification"
+)
+
+// ChangeRecorder はオフライン同期用の変更フィードにエンティティの変更を記録するインターフェース
+// 記録に失敗しても呼び出し元の処理には影響しない
+type ChangeRecorder interface {
+	// RecordUpsert はエンティティの作成・更新をuserIDsのユーザーに記録する
+	// 以前に変更を受け取ったユーザーのうちuserIDsに含まれないユーザーには削除として記録する
+	RecordUpsert(ctx context.Context, entityType, entityID string, userIDs []string, entity interface{})
+	// RecordDelete はエンティティの削除を、以前に変更を受け取ったすべてのユーザーに記録する
+	RecordDelete(ctx context.Context, entityType, entityID string)
+}
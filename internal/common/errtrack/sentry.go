@@ -0,0 +1,251 @@
+package errtrack
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// maxPendingSentryReports は同時に送信する報告の上限（超えた分は送らずにログにのみ残す）
+const maxPendingSentryReports = 16
+
+// SentryOptions はSentryへの報告の設定
+type SentryOptions struct {
+	DSN         string
+	Environment string
+	Release     string
+	HTTPClient  *http.Client // nilの場合は10秒でタイムアウトするクライアントを使う
+}
+
+// SentryTracker はSentryのStore APIにパニックを報告するTracker
+type SentryTracker struct {
+	endpoint    string
+	authHeader  string
+	environment string
+	release     string
+	serverName  string
+	client      *http.Client
+	logger      logger.Logger
+	pending     chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewSentryTracker はDSNを解析してSentryTrackerを作成する
+// DSNの形式は https://<公開鍵>@<ホスト>/<プロジェクトID>
+func NewSentryTracker(opts SentryOptions, logger logger.Logger) (*SentryTracker, error) {
+	endpoint, key, err := parseSentryDSN(opts.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	serverName, _ := os.Hostname()
+
+	return &SentryTracker{
+		endpoint:    endpoint,
+		authHeader:  fmt.Sprintf("Sentry sentry_version=7, sentry_client=yotei-plus/1.0, sentry_key=%s", key),
+		environment: opts.Environment,
+		release:     opts.Release,
+		serverName:  serverName,
+		client:      client,
+		logger:      logger,
+		pending:     make(chan struct{}, maxPendingSentryReports),
+	}, nil
+}
+
+// parseSentryDSN はDSNから送信先のURLと公開鍵を取り出す
+func parseSentryDSN(dsn string) (endpoint, key string, err error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("invalid sentry DSN: expected https://<key>@<host>/<project>")
+	}
+
+	path := strings.Trim(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	projectID := path[i+1:]
+	if projectID == "" {
+		return "", "", fmt.Errorf("invalid sentry DSN: missing project ID")
+	}
+	prefix := ""
+	if i >= 0 {
+		prefix = "/" + path[:i]
+	}
+
+	return fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, projectID), u.User.Username(), nil
+}
+
+// Capture は報告をSentryのイベントに変換して非同期に送信する
+func (t *SentryTracker) Capture(report *Report) {
+	select {
+	case t.pending <- struct{}{}:
+	default:
+		t.logger.Warn("Too many pending error reports, dropping report",
+			logger.String("component", report.Component))
+		return
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer func() {
+			<-t.pending
+			t.wg.Done()
+		}()
+		if err := t.send(report); err != nil {
+			t.logger.Warn("Failed to send error report to Sentry", logger.Error(err))
+		}
+	}()
+}
+
+// Flush は送信中の報告が送り終わるまで ctx の期限まで待つ
+func (t *SentryTracker) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// send は1件の報告を送信する
+func (t *SentryTracker) send(report *Report) error {
+	body, err := json.Marshal(t.event(report))
+	if err != nil {
+		return fmt.Errorf("failed to encode sentry event: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", t.authHeader)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sentryEvent はSentryのイベント（Store APIの形式）
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Message     string            `json:"message"`
+	Exception   sentryExceptions  `json:"exception"`
+	Tags        map[string]string `json:"tags"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Request     *sentryRequest    `json:"request,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Stacktrace sentryStacktrace `json:"stacktrace"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+type sentryRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// event は報告をSentryのイベントに変換する
+func (t *SentryTracker) event(report *Report) *sentryEvent {
+	frames := make([]sentryFrame, len(report.Frames))
+	for i, f := range report.Frames {
+		frames[i] = sentryFrame{
+			Function: f.Function,
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    strings.HasPrefix(f.Function, "github.com/hryt430/"),
+		}
+	}
+
+	event := &sentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   report.Time.UTC().Format(time.RFC3339),
+		Level:       "fatal",
+		Platform:    "go",
+		Logger:      report.Component,
+		ServerName:  t.serverName,
+		Environment: t.environment,
+		Release:     t.release,
+		Message:     report.Message,
+		Exception: sentryExceptions{Values: []sentryException{{
+			Type:       "panic",
+			Value:      report.Message,
+			Stacktrace: sentryStacktrace{Frames: frames},
+		}}},
+		Tags: map[string]string{"component": report.Component},
+	}
+	if report.RequestID != "" {
+		event.Tags["request_id"] = report.RequestID
+	}
+	if report.UserID != "" {
+		event.User = &sentryUser{ID: report.UserID}
+	}
+	if report.Method != "" {
+		event.Request = &sentryRequest{Method: report.Method, URL: report.Path}
+	}
+	return event
+}
+
+// newEventID はSentryのイベントID（UUIDのハイフンなし32文字）を生成する
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
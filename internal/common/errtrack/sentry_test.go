@@ -0,0 +1,107 @@
+package errtrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+func TestParseSentryDSN(t *testing.T) {
+	tests := []struct {
+		name         string
+		dsn          string
+		wantEndpoint string
+		wantKey      string
+		wantErr      bool
+	}{
+		{
+			name:         "hosted",
+			dsn:          "https://abc123@o42.ingest.sentry.io/4501",
+			wantEndpoint: "https://o42.ingest.sentry.io/api/4501/store/",
+			wantKey:      "abc123",
+		},
+		{
+			name:         "self-hosted with path prefix",
+			dsn:          "http://abc123@sentry.internal:9000/sentry/7",
+			wantEndpoint: "http://sentry.internal:9000/sentry/api/7/store/",
+			wantKey:      "abc123",
+		},
+		{name: "missing key", dsn: "https://o42.ingest.sentry.io/4501", wantErr: true},
+		{name: "missing project", dsn: "https://abc123@o42.ingest.sentry.io/", wantErr: true},
+		{name: "unsupported scheme", dsn: "ftp://abc123@o42.ingest.sentry.io/4501", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, key, err := parseSentryDSN(tt.dsn)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEndpoint, endpoint)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
+
+func TestSentryTracker_Capture(t *testing.T) {
+	var (
+		gotPath  string
+		gotAuth  string
+		gotEvent map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("X-Sentry-Auth")
+		_ = json.NewDecoder(r.Body).Decode(&gotEvent)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://public-key@", 1) + "/42"
+	tracker, err := NewSentryTracker(SentryOptions{DSN: dsn, Environment: "staging", Release: "yotei-plus@1.0.0"},
+		*logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	require.NoError(t, err)
+
+	tracker.Capture(&Report{
+		Message:   "index out of range",
+		Frames:    []Frame{{Function: "main.main", File: "/app/cmd/main.go", Line: 10}, {Function: "github.com/hryt430/Yotei+/internal/x.Handler", File: "/app/x.go", Line: 42}},
+		Component: "http",
+		RequestID: "req-1",
+		UserID:    "user-1",
+		Method:    http.MethodPost,
+		Path:      "/api/v1/tasks",
+		Time:      time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC),
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, tracker.Flush(ctx))
+
+	assert.Equal(t, "/api/42/store/", gotPath)
+	assert.Contains(t, gotAuth, "sentry_key=public-key")
+	assert.Equal(t, "index out of range", gotEvent["message"])
+	assert.Equal(t, "staging", gotEvent["environment"])
+	assert.Equal(t, "yotei-plus@1.0.0", gotEvent["release"])
+	assert.Equal(t, "2024-06-10T09:00:00Z", gotEvent["timestamp"])
+	assert.Len(t, gotEvent["event_id"], 32)
+	assert.Equal(t, map[string]interface{}{"component": "http", "request_id": "req-1"}, gotEvent["tags"])
+	assert.Equal(t, map[string]interface{}{"id": "user-1"}, gotEvent["user"])
+	assert.Equal(t, map[string]interface{}{"method": "POST", "url": "/api/v1/tasks"}, gotEvent["request"])
+
+	exception := gotEvent["exception"].(map[string]interface{})["values"].([]interface{})[0].(map[string]interface{})
+	frames := exception["stacktrace"].(map[string]interface{})["frames"].([]interface{})
+	require.Len(t, frames, 2)
+	assert.Equal(t, false, frames[0].(map[string]interface{})["in_app"])
+	assert.Equal(t, true, frames[1].(map[string]interface{})["in_app"])
+}
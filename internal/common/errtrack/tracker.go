@@ -0,0 +1,188 @@
+package errtrack
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// Report はエラー追跡サービスへ送るパニックの報告
+type Report struct {
+	Message   string
+	Stack     string  // debug.Stack() の出力（ログ用）
+	Frames    []Frame // 呼び出し元が先
+	Component string  // "http" またはワーカーなどのバックグラウンドのコンポーネント名
+	RequestID string
+	UserID    string
+	Method    string
+	Path      string
+	Time      time.Time
+}
+
+// Frame はスタックトレースの1フレーム
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Tracker はパニックを外部のエラー追跡サービスに報告するインターフェース
+type Tracker interface {
+	// Capture は報告を送る。呼び出し元を待たせないよう、送信は非同期で行う
+	Capture(report *Report)
+	// Flush は送信中の報告が送り終わるまで待つ（シャットダウン時に使用）
+	Flush(ctx context.Context) error
+}
+
+// NopTracker は報告を送らないTracker（エラー追跡サービスを設定していない場合に使用）
+type NopTracker struct{}
+
+// Capture は何もしない
+func (NopTracker) Capture(*Report) {}
+
+// Flush は何もしない
+func (NopTracker) Flush(context.Context) error { return nil }
+
+// NewPanicReport は回復したパニックの値から報告を作成する（recover した deferred 関数の中で呼び出す）
+func NewPanicReport(component string, recovered interface{}) *Report {
+	return &Report{
+		Message:   panicMessage(recovered),
+		Stack:     string(debug.Stack()),
+		Frames:    callerFrames(),
+		Component: component,
+		Time:      time.Now(),
+	}
+}
+
+// panicMessage はパニックの値を文字列にする
+func panicMessage(recovered interface{}) string {
+	if err, ok := recovered.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(recovered)
+}
+
+// callerFrames はパニックが発生した時点のスタックトレースを呼び出し元が先の順で返す
+// runtime と errtrack のフレーム（パニック・回復の処理）は含めない
+func callerFrames() []Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var result []Frame
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") && !isRecoveryFrame(frame.Function) {
+			result = append(result, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// recoveryFrames はパニックの回復・報告の処理のフレーム（スタックトレースに含めない）
+var recoveryFrames = map[string]bool{
+	"callerFrames":   true,
+	"NewPanicReport": true,
+	"ReportPanic":    true,
+	"Recover":        true,
+	"Go.func1":       true,
+	"Safe.func1":     true,
+}
+
+// isRecoveryFrame は関数がこのパッケージの回復・報告の処理かどうかを判定する
+func isRecoveryFrame(function string) bool {
+	const pkg = "/internal/common/errtrack."
+	i := strings.LastIndex(function, pkg)
+	return i >= 0 && recoveryFrames[function[i+len(pkg):]]
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultTracker Tracker = NopTracker{}
+	defaultLogger  *logger.Logger
+)
+
+// SetDefault はバックグラウンドのゴルーチンのパニックの報告先とログの出力先を設定する
+func SetDefault(tracker Tracker, log *logger.Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if tracker == nil {
+		tracker = NopTracker{}
+	}
+	defaultTracker = tracker
+	defaultLogger = log
+}
+
+// Default は SetDefault で設定したTrackerを返す
+func Default() Tracker {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultTracker
+}
+
+// ReportPanic は回復したパニックをログに記録し、SetDefault で設定したTrackerに報告する
+// 独自に recover している箇所から呼び出す（recover した deferred 関数の中で呼び出すこと）
+func ReportPanic(component string, recovered interface{}) *Report {
+	report := NewPanicReport(component, recovered)
+
+	defaultMu.RLock()
+	tracker, log := defaultTracker, defaultLogger
+	defaultMu.RUnlock()
+
+	if log == nil {
+		log = logger.Get()
+	}
+	log.Error("Panic recovered in background goroutine",
+		logger.String("component", component),
+		logger.String("panic", report.Message),
+		logger.String("stack", report.Stack))
+	tracker.Capture(report)
+	return report
+}
+
+// Recover はゴルーチンの先頭で defer して使い、パニックを回復して報告する
+//
+//	go func() {
+//		defer errtrack.Recover("escalation-worker")
+//		...
+//	}()
+func Recover(component string) {
+	if recovered := recover(); recovered != nil {
+		ReportPanic(component, recovered)
+	}
+}
+
+// Go はパニックを回復して報告するゴルーチンで fn を実行する
+func Go(component string, fn func()) {
+	go func() {
+		defer Recover(component)
+		fn()
+	}()
+}
+
+// Safe は fn を実行し、パニックした場合は回復して報告し false を返す
+// ワーカーの定期処理のように、1回の失敗でループ全体を止めたくない場合に使う
+func Safe(component string, fn func()) (ok bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			ReportPanic(component, recovered)
+			ok = false
+		}
+	}()
+	fn()
+	return true
+}
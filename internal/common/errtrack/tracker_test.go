@@ -0,0 +1,112 @@
+package errtrack
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// fakeTracker は受け取った報告を記録するテスト用のTracker
+type fakeTracker struct {
+	mu      sync.Mutex
+	reports []*Report
+	done    chan struct{}
+}
+
+func newFakeTracker() *fakeTracker {
+	return &fakeTracker{done: make(chan struct{}, 10)}
+}
+
+func (t *fakeTracker) Capture(report *Report) {
+	t.mu.Lock()
+	t.reports = append(t.reports, report)
+	t.mu.Unlock()
+	t.done <- struct{}{}
+}
+
+func (t *fakeTracker) Flush(context.Context) error { return nil }
+
+// useTracker はテストの間だけ既定のTrackerを差し替える
+func useTracker(t *testing.T) *fakeTracker {
+	tracker := newFakeTracker()
+	SetDefault(tracker, logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	t.Cleanup(func() { SetDefault(nil, nil) })
+	return tracker
+}
+
+func panickingWork() {
+	panic(errors.New("nil map write"))
+}
+
+func TestSafe(t *testing.T) {
+	tracker := useTracker(t)
+
+	ok := Safe("escalation-worker", panickingWork)
+
+	assert.False(t, ok)
+	require.Len(t, tracker.reports, 1)
+	report := tracker.reports[0]
+	assert.Equal(t, "escalation-worker", report.Component)
+	assert.Equal(t, "nil map write", report.Message)
+	assert.Contains(t, report.Stack, "panickingWork")
+
+	t.Run("returns true without panic", func(t *testing.T) {
+		assert.True(t, Safe("escalation-worker", func() {}))
+		assert.Len(t, tracker.reports, 1)
+	})
+}
+
+func TestGo(t *testing.T) {
+	tracker := useTracker(t)
+
+	Go("websocket-client", func() { panic("closed channel") })
+
+	select {
+	case <-tracker.done:
+	case <-time.After(time.Second):
+		t.Fatal("panic was not reported")
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	assert.Equal(t, "closed channel", tracker.reports[0].Message)
+	assert.Equal(t, "websocket-client", tracker.reports[0].Component)
+}
+
+func TestRecover(t *testing.T) {
+	tracker := useTracker(t)
+
+	func() {
+		defer Recover("undo-queue")
+		panickingWork()
+	}()
+
+	require.Len(t, tracker.reports, 1)
+	assert.Equal(t, "undo-queue", tracker.reports[0].Component)
+}
+
+func TestReportPanic_Frames(t *testing.T) {
+	tracker := useTracker(t)
+
+	func() {
+		defer Recover("http")
+		panickingWork()
+	}()
+
+	require.Len(t, tracker.reports, 1)
+	report := tracker.reports[0]
+	require.NotEmpty(t, report.Frames)
+	for _, frame := range report.Frames {
+		assert.False(t, strings.HasPrefix(frame.Function, "runtime."), frame.Function)
+		assert.False(t, isRecoveryFrame(frame.Function), frame.Function)
+	}
+	// 呼び出し元が先で、パニックした関数が最後になる
+	assert.True(t, strings.HasSuffix(report.Frames[len(report.Frames)-1].Function, ".panickingWork"))
+}
@@ -0,0 +1,123 @@
+package holiday
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedCountry は祝日を扱えない国が指定されたことを表す
+var ErrUnsupportedCountry = errors.New("unsupported holiday country")
+
+const (
+	// CountryJapan は日本の国民の祝日（内閣府の「国民の祝日について」に基づく）
+	CountryJapan = "JP"
+	// CountryNone は祝日を扱わない（土日などの休日のみで営業日を判定する）
+	CountryNone = "NONE"
+
+	// maxSearchDays は前後の営業日を探す日数の上限（勤務日がない設定で止まらないようにする）
+	maxSearchDays = 366
+)
+
+// Weekdays は月曜日から金曜日（グループの予定など、勤務曜日の設定がない場合の営業日の曜日）
+var Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// Holiday は祝日の日付と名前
+type Holiday struct {
+	Date string `json:"date"` // "2006-01-02"
+	Name string `json:"name"`
+}
+
+// Provider は祝日の取得元
+type Provider interface {
+	// Country は祝日の国（ISO 3166-1 alpha-2、扱わない場合は NONE）を返す
+	Country() string
+	// Lookup は date の暦日（date のタイムゾーンでの日付）が祝日の場合に祝日の名前を返す
+	Lookup(date time.Time) (string, bool)
+}
+
+// NewProvider は国の祝日の取得元を作成する（空の場合は祝日を扱わない）
+func NewProvider(country string) (Provider, error) {
+	switch strings.ToUpper(strings.TrimSpace(country)) {
+	case "", CountryNone:
+		return none{}, nil
+	case CountryJapan:
+		return Japan(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCountry, country)
+}
+
+// none は祝日を扱わない取得元
+type none struct{}
+
+func (none) Country() string                 { return CountryNone }
+func (none) Lookup(time.Time) (string, bool) { return "", false }
+
+// Name は date が祝日の場合に祝日の名前を返す（p が nil の場合は祝日を扱わない）
+func Name(p Provider, date time.Time) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	return p.Lookup(date)
+}
+
+// Between は from から to まで（どちらも暦日として from のタイムゾーンで解釈し、両端を含む）の祝日を日付の順に返す
+func Between(p Provider, from, to time.Time) []Holiday {
+	holidays := []Holiday{}
+	if p == nil {
+		return holidays
+	}
+	loc := from.Location()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	to = to.In(loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if name, ok := p.Lookup(day); ok {
+			holidays = append(holidays, Holiday{Date: day.Format("2006-01-02"), Name: name})
+		}
+	}
+	return holidays
+}
+
+// IsBusinessDay は date の暦日が勤務曜日かつ祝日でないかを判定する
+func IsBusinessDay(p Provider, date time.Time, workDays []time.Weekday) bool {
+	if !containsWeekday(workDays, date.Weekday()) {
+		return false
+	}
+	_, isHoliday := Name(p, date)
+	return !isHoliday
+}
+
+// NextBusinessDay は date 以降で最も近い営業日の同じ時刻を返す（date が営業日の場合は date）
+// 営業日が見つからない場合は false を返す
+func NextBusinessDay(p Provider, date time.Time, workDays []time.Weekday) (time.Time, bool) {
+	return searchBusinessDay(p, date, workDays, 1)
+}
+
+// PreviousBusinessDay は date 以前で最も近い営業日の同じ時刻を返す（date が営業日の場合は date）
+// 営業日が見つからない場合は false を返す
+func PreviousBusinessDay(p Provider, date time.Time, workDays []time.Weekday) (time.Time, bool) {
+	return searchBusinessDay(p, date, workDays, -1)
+}
+
+// searchBusinessDay は step 日ずつ進めて営業日を探す
+func searchBusinessDay(p Provider, date time.Time, workDays []time.Weekday, step int) (time.Time, bool) {
+	for i := 0; i <= maxSearchDays; i++ {
+		day := date.AddDate(0, 0, i*step)
+		if IsBusinessDay(p, day, workDays) {
+			return day, true
+		}
+	}
+	return date, false
+}
+
+// containsWeekday は曜日の一覧に day が含まれるか
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
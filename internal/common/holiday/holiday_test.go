@@ -0,0 +1,111 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestJapan_Holidays2024(t *testing.T) {
+	holidays := Between(Japan(), date(2024, time.January, 1), date(2024, time.December, 31))
+
+	assert.Equal(t, []Holiday{
+		{Date: "2024-01-01", Name: "元日"},
+		{Date: "2024-01-08", Name: "成人の日"},
+		{Date: "2024-02-11", Name: "建国記念の日"},
+		{Date: "2024-02-12", Name: "振替休日"},
+		{Date: "2024-02-23", Name: "天皇誕生日"},
+		{Date: "2024-03-20", Name: "春分の日"},
+		{Date: "2024-04-29", Name: "昭和の日"},
+		{Date: "2024-05-03", Name: "憲法記念日"},
+		{Date: "2024-05-04", Name: "みどりの日"},
+		{Date: "2024-05-05", Name: "こどもの日"},
+		{Date: "2024-05-06", Name: "振替休日"},
+		{Date: "2024-07-15", Name: "海の日"},
+		{Date: "2024-08-11", Name: "山の日"},
+		{Date: "2024-08-12", Name: "振替休日"},
+		{Date: "2024-09-16", Name: "敬老の日"},
+		{Date: "2024-09-22", Name: "秋分の日"},
+		{Date: "2024-09-23", Name: "振替休日"},
+		{Date: "2024-10-14", Name: "スポーツの日"},
+		{Date: "2024-11-03", Name: "文化の日"},
+		{Date: "2024-11-04", Name: "振替休日"},
+		{Date: "2024-11-23", Name: "勤労感謝の日"},
+	}, holidays)
+}
+
+func TestJapan_SpecialCases(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		want string
+	}{
+		// 即位の日の前後は国民の休日になる
+		{name: "2019 citizens' holiday before the accession", date: date(2019, time.April, 30), want: "国民の休日"},
+		{name: "2019 accession", date: date(2019, time.May, 1), want: "天皇の即位の日"},
+		{name: "2019 citizens' holiday after the accession", date: date(2019, time.May, 2), want: "国民の休日"},
+		// 敬老の日と秋分の日に挟まれた日
+		{name: "2026 citizens' holiday", date: date(2026, time.September, 22), want: "国民の休日"},
+		// 東京オリンピックによる移動
+		{name: "2021 moved sports day", date: date(2021, time.July, 23), want: "スポーツの日"},
+		{name: "2020 substitute for the emperor's birthday", date: date(2020, time.February, 24), want: "振替休日"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := Japan().Lookup(tt.date)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, name)
+		})
+	}
+
+	_, ok := Japan().Lookup(date(2021, time.October, 11))
+	assert.False(t, ok, "sports day moved to July in 2021")
+	_, ok = Japan().Lookup(date(2019, time.December, 23))
+	assert.False(t, ok, "no emperor's birthday in 2019")
+}
+
+func TestNewProvider(t *testing.T) {
+	p, err := NewProvider("jp")
+	require.NoError(t, err)
+	assert.Equal(t, CountryJapan, p.Country())
+
+	p, err = NewProvider("")
+	require.NoError(t, err)
+	_, ok := p.Lookup(date(2024, time.January, 1))
+	assert.False(t, ok)
+
+	_, err = NewProvider("XX")
+	assert.ErrorIs(t, err, ErrUnsupportedCountry)
+}
+
+func TestBusinessDays(t *testing.T) {
+	// 2024-05-03（金）〜05-06（月）は祝日と週末
+	friday := date(2024, time.May, 3)
+
+	assert.False(t, IsBusinessDay(Japan(), friday, Weekdays))
+	assert.True(t, IsBusinessDay(nil, friday, Weekdays))
+
+	next, ok := NextBusinessDay(Japan(), friday, Weekdays)
+	require.True(t, ok)
+	assert.Equal(t, date(2024, time.May, 7), next)
+
+	previous, ok := PreviousBusinessDay(Japan(), friday, Weekdays)
+	require.True(t, ok)
+	assert.Equal(t, date(2024, time.May, 2), previous)
+
+	// 営業日の場合はそのまま
+	same, ok := NextBusinessDay(Japan(), date(2024, time.May, 7), Weekdays)
+	require.True(t, ok)
+	assert.Equal(t, date(2024, time.May, 7), same)
+
+	// 勤務日がない場合は見つからない
+	_, ok = NextBusinessDay(Japan(), friday, nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,169 @@
+package holiday
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// 日本の祝日の特例（東京オリンピック・パラリンピックによる移動、天皇の即位）
+var japanSpecialHolidays = map[int][]struct {
+	month time.Month
+	day   int
+	name  string
+}{
+	2019: {
+		{time.May, 1, "天皇の即位の日"},
+		{time.October, 22, "即位礼正殿の儀の行われる日"},
+	},
+	2020: {
+		{time.July, 23, "海の日"},
+		{time.July, 24, "スポーツの日"},
+		{time.August, 10, "山の日"},
+	},
+	2021: {
+		{time.July, 22, "海の日"},
+		{time.July, 23, "スポーツの日"},
+		{time.August, 8, "山の日"},
+	},
+}
+
+// japan は日本の国民の祝日（振替休日・国民の休日を含む）の取得元
+// 年ごとに計算した祝日を保持する。春分の日・秋分の日は1980〜2099年の近似式で計算する
+type japan struct {
+	mu    sync.Mutex
+	years map[int]map[string]string
+}
+
+var japanProvider = &japan{years: make(map[int]map[string]string)}
+
+// Japan は日本の国民の祝日の取得元を返す
+func Japan() Provider {
+	return japanProvider
+}
+
+func (j *japan) Country() string { return CountryJapan }
+
+// Lookup は date の暦日が日本の祝日の場合に祝日の名前を返す
+func (j *japan) Lookup(date time.Time) (string, bool) {
+	name, ok := j.year(date.Year())[date.Format("01-02")]
+	return name, ok
+}
+
+// year は年の祝日（"01-02" 形式の月日から名前）を返す
+func (j *japan) year(year int) map[string]string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	holidays, ok := j.years[year]
+	if !ok {
+		holidays = japaneseHolidays(year)
+		j.years[year] = holidays
+	}
+	return holidays
+}
+
+// japaneseHolidays は年の祝日を計算する（2000年以降の祝日法に基づく）
+func japaneseHolidays(year int) map[string]string {
+	holidays := make(map[string]string)
+	add := func(month time.Month, day int, name string) {
+		holidays[time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format("01-02")] = name
+	}
+	nthMonday := func(month time.Month, n int) int {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return 1 + (int(time.Monday)-int(first.Weekday())+7)%7 + (n-1)*7
+	}
+
+	add(time.January, 1, "元日")
+	add(time.January, nthMonday(time.January, 2), "成人の日")
+	add(time.February, 11, "建国記念の日")
+	switch {
+	case year >= 2020:
+		add(time.February, 23, "天皇誕生日")
+	case year <= 2018:
+		add(time.December, 23, "天皇誕生日")
+	}
+	add(time.March, vernalEquinoxDay(year), "春分の日")
+	if year >= 2007 {
+		add(time.April, 29, "昭和の日")
+		add(time.May, 4, "みどりの日")
+	} else {
+		add(time.April, 29, "みどりの日")
+	}
+	add(time.May, 3, "憲法記念日")
+	add(time.May, 5, "こどもの日")
+	if year < 2020 || year > 2021 {
+		if year >= 2003 {
+			add(time.July, nthMonday(time.July, 3), "海の日")
+		} else {
+			add(time.July, 20, "海の日")
+		}
+		if year >= 2016 {
+			add(time.August, 11, "山の日")
+		}
+		if year >= 2020 {
+			add(time.October, nthMonday(time.October, 2), "スポーツの日")
+		} else {
+			add(time.October, nthMonday(time.October, 2), "体育の日")
+		}
+	}
+	if year >= 2003 {
+		add(time.September, nthMonday(time.September, 3), "敬老の日")
+	} else {
+		add(time.September, 15, "敬老の日")
+	}
+	add(time.September, autumnalEquinoxDay(year), "秋分の日")
+	add(time.November, 3, "文化の日")
+	add(time.November, 23, "勤労感謝の日")
+	for _, special := range japanSpecialHolidays[year] {
+		add(special.month, special.day, special.name)
+	}
+
+	// 国民の休日: 前日と翌日が祝日の平日（日曜日以外）
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	isHoliday := func(day time.Time) bool {
+		_, ok := holidays[day.Format("01-02")]
+		return ok && day.Year() == year
+	}
+	var citizens []time.Time
+	for day := start; day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if !isHoliday(day) && day.Weekday() != time.Sunday &&
+			isHoliday(day.AddDate(0, 0, -1)) && isHoliday(day.AddDate(0, 0, 1)) {
+			citizens = append(citizens, day)
+		}
+	}
+	for _, day := range citizens {
+		add(day.Month(), day.Day(), "国民の休日")
+	}
+
+	// 振替休日: 日曜日の祝日の後の最も近い祝日でない日（年をまたぐ場合は扱わない）
+	for day := start; day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Sunday || !isHoliday(day) {
+			continue
+		}
+		substitute := day.AddDate(0, 0, 1)
+		for isHoliday(substitute) {
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		if substitute.Year() == year {
+			add(substitute.Month(), substitute.Day(), "振替休日")
+		}
+	}
+	return holidays
+}
+
+// vernalEquinoxDay は3月の春分の日の日付を返す
+func vernalEquinoxDay(year int) int {
+	return equinoxDay(year, 20.8431)
+}
+
+// autumnalEquinoxDay は9月の秋分の日の日付を返す
+func autumnalEquinoxDay(year int) int {
+	return equinoxDay(year, 23.2488)
+}
+
+// equinoxDay は1980年を基準とする近似式で春分・秋分の日付を計算する
+func equinoxDay(year int, base float64) int {
+	y := float64(year - 1980)
+	return int(math.Floor(base + 0.242194*y - math.Floor(y/4)))
+}
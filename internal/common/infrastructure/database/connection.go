@@ -0,0 +1,18 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/hryt430/Yotei+/config"
+)
+
+// NewConnection はSTORAGE_DRIVERに応じてMySQL・PostgreSQL・SQLiteに接続する
+func NewConnection(cfg *config.Config) (*sql.DB, error) {
+	if cfg.UsesPostgres() {
+		return NewPostgresConnection(cfg)
+	}
+	if cfg.UsesSQLite() {
+		return NewSQLiteConnection(cfg)
+	}
+	return NewMySQLConnection(cfg)
+}
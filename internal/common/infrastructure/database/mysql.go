@@ -1,24 +1,47 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/hryt430/Yotei+/config"
 )
 
-func NewMySQLConnection(cfg *config.Config) (*sql.DB, error) {
-	dsn := cfg.GetDSN()
-	fmt.Printf("DSN: %q\n", dsn)
+// credentialConnector は新しい接続を作るたびに設定から現在の認証情報を読み込むコネクター
+// 秘密情報の取得元で認証情報がローテーションされた場合、新しい接続から新しい認証情報を使う
+type credentialConnector struct {
+	cfg *config.Config
+}
 
-	conn, err := sql.Open("mysql", dsn)
+// Connect は現在の認証情報でデータベースに接続する
+func (c *credentialConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsnConfig, err := mysql.ParseDSN(c.cfg.GetDSN())
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to parse DSN: %w", err)
 	}
+	connector, err := mysql.NewConnector(dsnConfig)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// Driver はMySQLドライバーを返す
+func (c *credentialConnector) Driver() driver.Driver {
+	return &mysql.MySQLDriver{}
+}
+
+func NewMySQLConnection(cfg *config.Config) (*sql.DB, error) {
+	user, _ := cfg.DatabaseCredentials()
+	fmt.Printf("DB: %s@%s:%s/%s\n", user, cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+
+	conn := sql.OpenDB(&credentialConnector{cfg: cfg})
 
 	// 接続確認
 	if err := conn.Ping(); err != nil {
@@ -26,6 +49,7 @@ func NewMySQLConnection(cfg *config.Config) (*sql.DB, error) {
 	}
 
 	// コネクションプールの設定
+	// 接続の寿命により、ローテーションされた認証情報は最大5分で全ての接続に反映される
 	conn.SetMaxOpenConns(25)
 	conn.SetMaxIdleConns(25)
 	conn.SetConnMaxLifetime(5 * time.Minute)
@@ -56,6 +80,16 @@ func executeInitSQL(db *sql.DB, filepath string) error {
 		return nil
 	}
 
+	if err := ExecuteSQLFile(db, filepath); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ 初期化SQL実行完了")
+	return nil
+}
+
+// ExecuteSQLFile はSQLファイルの文を順に実行する（既存テーブルなど無視可能なエラーは継続する）
+func ExecuteSQLFile(db *sql.DB, filepath string) error {
 	// ファイル読み込み
 	initSQL, err := os.ReadFile(filepath)
 	if err != nil {
@@ -88,7 +122,6 @@ func executeInitSQL(db *sql.DB, filepath string) error {
 		}
 	}
 
-	fmt.Println("✅ 初期化SQL実行完了")
 	return nil
 }
 
@@ -119,8 +152,8 @@ func splitSQLStatements(sqlContent string) []string {
 		cleanLines = append(cleanLines, line)
 	}
 
-	// 再結合してセミコロンで分割
-	cleanSQL := strings.Join(cleanLines, " ")
+	// 再結合してセミコロンで分割（行末コメントが後続の行を巻き込まないよう改行で結合する）
+	cleanSQL := strings.Join(cleanLines, "\n")
 	statements := strings.Split(cleanSQL, ";")
 
 	var result []string
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
+	"github.com/lib/pq"
+)
+
+// postgresInitSQLPath はPostgreSQLのスキーマ定義
+const postgresInitSQLPath = "postgres/init.sql"
+
+// ConflictKeys はリポジトリが ON DUPLICATE KEY UPDATE を使うテーブルの一意キー
+// PostgreSQL・SQLiteでは ON CONFLICT の対象列が必要なため、各スキーマの主キー・一意制約と合わせる
+var ConflictKeys = map[string][]string{
+	"analytics_preferences":         {"user_id"},
+	"api_keys":                      {"id"},
+	"billing_subscriptions":         {"user_id"},
+	"daily_stats":                   {"user_id", "stat_date"},
+	"deferred_reminders":            {"user_id", "task_id", "kind"},
+	"feature_flags":                 {"flag_key"},
+	"github_accounts":               {"user_id"},
+	"group_assignment_settings":     {"group_id"},
+	"group_chat_integrations":       {"group_id", "platform"},
+	"group_event_exceptions":        {"group_id", "event_id", "original_starts_at"},
+	"group_event_recurrences":       {"group_id", "event_id"},
+	"group_event_reminders":         {"group_id", "event_id"},
+	"group_event_rsvps":             {"group_id", "event_id", "user_id"},
+	"group_leaderboard_preferences": {"group_id", "user_id"},
+	"group_leaderboard_settings":    {"group_id"},
+	"group_schedule_card_settings":  {"group_id"},
+	"inbound_email_addresses":       {"user_id"},
+	"link_previews":                 {"url_hash"},
+	"login_alert_settings":          {"user_id"},
+	"login_devices":                 {"user_id", "fingerprint"},
+	"milestone_tasks":               {"task_id"},
+	"profile_settings":              {"user_id"},
+	"notification_dead_letters":     {"id"},
+	"notification_preferences":      {"user_id"},
+	"notifications":                 {"id"},
+	"sso_connections":               {"id"},
+	"social_digest_states":          {"user_id"},
+	"sso_identities":                {"connection_id", "subject"},
+	"sync_entity_versions":          {"entity_type", "entity_id"},
+	"task_assignees":                {"task_id", "user_id"},
+	"task_locations":                {"task_id"},
+	"task_snapshots":                {"task_id", "sequence"},
+	"user_working_hours":            {"user_id"},
+	"weekly_report_subscriptions":   {"user_id"},
+}
+
+// NewPostgresTranslator はリポジトリのクエリをPostgreSQLの方言に変換するTranslatorを作成する
+func NewPostgresTranslator() *sqlquery.Translator {
+	return sqlquery.NewTranslator(sqlquery.DialectPostgres, ConflictKeys)
+}
+
+// postgresConnector は新しい接続を作るたびに設定から現在の認証情報を読み込み、
+// 接続で実行するクエリをPostgreSQLの方言に変換するコネクター
+type postgresConnector struct {
+	cfg        *config.Config
+	translator *sqlquery.Translator
+}
+
+// Connect は現在の認証情報でデータベースに接続する
+func (c *postgresConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	connector, err := pq.NewConnector(c.cfg.GetPostgresDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DSN: %w", err)
+	}
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pgConn, ok := conn.(pqConn)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected postgres connection type %T", conn)
+	}
+	return &translatingConn{pqConn: pgConn, translator: c.translator}, nil
+}
+
+// Driver はPostgreSQLドライバーを返す
+func (c *postgresConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// pqConn はlib/pqの接続が実装しているインターフェース
+type pqConn interface {
+	driver.Conn
+	driver.ConnPrepareContext
+	driver.ConnBeginTx
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// translatingConn はクエリをPostgreSQLの方言に変換してから実行する接続
+// 変換以外（トランザクション・接続の確認など）はlib/pqの接続にそのまま委譲する
+type translatingConn struct {
+	pqConn
+	translator *sqlquery.Translator
+}
+
+func (c *translatingConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *translatingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	translated, err := c.translator.Translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.pqConn.PrepareContext(ctx, translated)
+}
+
+func (c *translatingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	translated, err := c.translator.Translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.pqConn.QueryContext(ctx, translated, args)
+}
+
+func (c *translatingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	translated, err := c.translator.Translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.pqConn.ExecContext(ctx, translated, args)
+}
+
+// CheckNamedValue は []byte の引数を文字列として渡す
+// JSON列には []byte をそのまま渡しているが、lib/pqは []byte をbytea形式で送るため
+func (c *translatingConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if b, ok := nv.Value.([]byte); ok {
+		nv.Value = string(b)
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+// NewPostgresConnection はPostgreSQLに接続する
+// リポジトリのクエリ（MySQLの方言）は接続ごとに変換して実行するため、リポジトリはMySQLと同じ実装を使う
+func NewPostgresConnection(cfg *config.Config) (*sql.DB, error) {
+	user, _ := cfg.DatabaseCredentials()
+	fmt.Printf("DB (postgres): %s@%s:%s/%s\n", user, cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+
+	// スキーマの作成はPL/pgSQLを含むため、変換せずにそのまま実行する
+	if err := initPostgresSchema(cfg, postgresInitSQLPath); err != nil {
+		fmt.Printf("⚠️ 初期化SQLの実行に失敗しました: %v\n", err)
+	}
+
+	conn := sql.OpenDB(&postgresConnector{cfg: cfg, translator: NewPostgresTranslator()})
+
+	// 接続確認
+	if err := conn.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// コネクションプールの設定（MySQLと同じ）
+	conn.SetMaxOpenConns(25)
+	conn.SetMaxIdleConns(25)
+	conn.SetConnMaxLifetime(5 * time.Minute)
+
+	fmt.Println("✅ DB接続成功しました!")
+	return conn, nil
+}
+
+// initPostgresSchema は未初期化の場合にスキーマ定義を実行する
+func initPostgresSchema(cfg *config.Config, filepath string) error {
+	if _, err := os.Stat(filepath); os.IsNotExist(err) {
+		fmt.Printf("初期化ファイル %s が見つかりません。スキップします。\n", filepath)
+		return nil
+	}
+
+	db, err := sql.Open("postgres", cfg.GetPostgresDSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if isAlreadyInitialized(db) {
+		fmt.Println("✅ データベースは既に初期化済みです。スキップします。")
+		return nil
+	}
+	if err := ExecuteSQLScript(db, filepath); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ 初期化SQL実行完了")
+	return nil
+}
+
+// ExecuteSQLScript はSQLファイル全体を1回で実行する（PostgreSQLの関数定義など、文の途中にセミコロンを含むスクリプト用）
+func ExecuteSQLScript(db *sql.DB, filepath string) error {
+	script, err := os.ReadFile(filepath)
+	if err != nil {
+		return fmt.Errorf("failed to read init SQL file: %w", err)
+	}
+	if _, err := db.Exec(string(script)); err != nil {
+		return fmt.Errorf("failed to execute %s: %w", filepath, err)
+	}
+	return nil
+}
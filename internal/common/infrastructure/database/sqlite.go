@@ -0,0 +1,241 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
+	sqliteMigrations "github.com/hryt430/Yotei+/sqlite"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteMigrationDir は埋め込んだマイグレーションのディレクトリ
+const sqliteMigrationDir = "migrations"
+
+// sqliteParams はSQLiteの接続の設定
+// WALで読み込みと書き込みを並行させ、書き込み中の接続は待つ。トランザクションは開始時に書き込みのロックを取り、
+// 読み込みから書き込みに切り替えるときのロックの競合（待たずに失敗する）を避ける
+const sqliteParams = "_busy_timeout=5000&_foreign_keys=on&_journal_mode=WAL&_txlock=immediate"
+
+var sqliteDriver = &sqlite3.SQLiteDriver{}
+
+// sqliteConnections はファイルごとの接続
+// モジュールごとのSqlHandlerが同じファイルに別々のコネクションプールで書き込むとロックを待つことが増えるため、1つのプールを共有する
+var (
+	sqliteMu          sync.Mutex
+	sqliteConnections = make(map[string]*sql.DB)
+)
+
+// NewSQLiteTranslator はリポジトリのクエリをSQLiteの方言に変換するTranslatorを作成する
+func NewSQLiteTranslator() *sqlquery.Translator {
+	return sqlquery.NewTranslator(sqlquery.DialectSQLite, ConflictKeys)
+}
+
+// sqliteConnector はクエリをSQLiteの方言に変換する接続を作るコネクター（translatorがnilの場合は変換しない）
+type sqliteConnector struct {
+	dsn        string
+	translator *sqlquery.Translator
+}
+
+// Connect はデータベースのファイルを開く
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := sqliteDriver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{SQLiteConn: conn.(*sqlite3.SQLiteConn), translator: c.translator}, nil
+}
+
+// Driver はSQLiteドライバーを返す
+func (c *sqliteConnector) Driver() driver.Driver {
+	return sqliteDriver
+}
+
+// sqliteConn はクエリをSQLiteの方言に変換してから実行する接続
+type sqliteConn struct {
+	*sqlite3.SQLiteConn
+	translator *sqlquery.Translator
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	translated, err := c.translator.Translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.PrepareContext(ctx, translated)
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	translated, err := c.translator.Translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.QueryContext(ctx, translated, args)
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	translated, err := c.translator.Translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.ExecContext(ctx, translated, args)
+}
+
+// CheckNamedValue は時刻の引数をUTCにする
+// SQLiteには日時の型がなく、時刻は文字列として比較するため、タイムゾーンを揃えないと順序が正しくならない
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case time.Time:
+		nv.Value = v.UTC()
+		return nil
+	case *time.Time:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = v.UTC()
+		}
+		return nil
+	case sql.NullTime:
+		if v.Valid {
+			nv.Value = v.Time.UTC()
+		} else {
+			nv.Value = nil
+		}
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+// NewSQLiteConnection はSQLiteのデータベースのファイルを開き、未適用のマイグレーションを適用する
+// 同じファイルへの接続は1つのコネクションプールを共有する
+func NewSQLiteConnection(cfg *config.Config) (*sql.DB, error) {
+	path := cfg.Storage.SQLitePath
+
+	sqliteMu.Lock()
+	defer sqliteMu.Unlock()
+	if conn, ok := sqliteConnections[path]; ok {
+		return conn, nil
+	}
+
+	fmt.Printf("DB (sqlite): %s\n", path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+	dsn := path + "?" + sqliteParams
+
+	// マイグレーションはリポジトリのクエリではないため、変換せずにそのまま実行する
+	migrationConn := sql.OpenDB(&sqliteConnector{dsn: dsn})
+	migrations, err := fs.Sub(sqliteMigrations.Migrations, sqliteMigrationDir)
+	if err == nil {
+		err = MigrateSQLite(migrationConn, migrations)
+	}
+	migrationConn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	conn := sql.OpenDB(&sqliteConnector{dsn: dsn, translator: NewSQLiteTranslator()})
+
+	// 接続確認
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// 書き込みは同時に1つの接続だけが行えるため、MySQLより少ない接続数にする
+	conn.SetMaxOpenConns(10)
+	conn.SetMaxIdleConns(10)
+
+	sqliteConnections[path] = conn
+	fmt.Println("✅ DB接続成功しました!")
+	return conn, nil
+}
+
+// MigrateSQLite は migrations（NNN_名前.sql）のうち未適用のものをファイル名の順に適用する
+// 適用したマイグレーションは schema_migrations に記録し、1つのマイグレーションを1つのトランザクションで適用する
+func MigrateSQLite(db *sql.DB, migrations fs.FS) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(255) PRIMARY KEY,
+		applied_at DATETIME NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	names, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(name, ".sql")
+		if applied[version] {
+			continue
+		}
+
+		script, err := fs.ReadFile(migrations, name)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		if err := applyMigration(db, version, string(script)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
+		}
+		fmt.Printf("✅ マイグレーション %s を適用しました\n", version)
+	}
+	return nil
+}
+
+// appliedMigrations は適用済みのマイグレーションのバージョンを返す
+func appliedMigrations(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration は1つのマイグレーションを適用して記録する
+func applyMigration(db *sql.DB, version, script string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)", version, time.Now().UTC()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,296 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultSaveInterval はファイルに書き出す間隔の既定値
+const DefaultSaveInterval = 30 * time.Second
+
+// Store はRedisの代わりにプロセス内で使うキー・バリューストア（REDIS_DRIVER=embedded 用）
+// キーごとに有効期限を持ち、期限切れのキーは存在しないものとして扱う
+// path を指定した場合は Save でファイルに書き出し、次の起動時に読み込む（Redisの永続化と同じく、最後の書き出し以降の変更は失われる）
+type Store struct {
+	path string // 空の場合はファイルに書き出さない
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+	dirty   bool
+}
+
+type entry struct {
+	value     string
+	expiresAt time.Time // ゼロ値の場合は期限なし
+}
+
+// fileEntry はファイルに書き出すエントリ
+type fileEntry struct {
+	Value     string     `json:"value"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Open はストアを作成し、pathのファイルがあれば読み込む（pathが空の場合は書き出さない）
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		now:     time.Now,
+		entries: make(map[string]entry),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var saved map[string]fileEntry
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	now := s.now()
+	for key, e := range saved {
+		var expiresAt time.Time
+		if e.ExpiresAt != nil {
+			if !e.ExpiresAt.After(now) {
+				continue
+			}
+			expiresAt = *e.ExpiresAt
+		}
+		s.entries[key] = entry{value: e.Value, expiresAt: expiresAt}
+	}
+	return s, nil
+}
+
+// Set は値を保存する（ttlが0以下の場合は期限なし）
+func (s *Store) Set(key, value string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(key, value, ttl)
+}
+
+// Get は値を取得する（キーがない・期限切れの場合はfalse）
+func (s *Store) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.get(key)
+	return e.value, ok
+}
+
+// Exists はキーが存在するか確認する
+func (s *Store) Exists(key string) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Del はキーを削除する
+func (s *Store) Del(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := s.entries[key]; ok {
+			delete(s.entries, key)
+			s.dirty = true
+		}
+	}
+}
+
+// Expire はキーの有効期限をttl後に変更する（キーがない・期限切れの場合はfalse）
+func (s *Store) Expire(key string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.get(key)
+	if !ok {
+		return false
+	}
+	s.set(key, e.value, ttl)
+	return true
+}
+
+// GetSet は値を保存し、変更前の値を返す（変更前にキーがなかった場合はfalse）
+func (s *Store) GetSet(key, value string, ttl time.Duration) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.get(key)
+	s.set(key, value, ttl)
+	return previous.value, ok
+}
+
+// Incr は整数の値を1増やし、増やした後の値を返す（キーがない・整数でない場合は0から数える）
+// ttlが0より大きい場合は有効期限をttl後に変更し、0以下の場合は変更前の有効期限を保持する
+func (s *Store) Incr(key string, ttl time.Duration) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, _ := s.get(key)
+	count, _ := strconv.ParseInt(previous.value, 10, 64)
+	count++
+	e := entry{value: strconv.FormatInt(count, 10), expiresAt: previous.expiresAt}
+	if ttl > 0 {
+		e.expiresAt = s.now().Add(ttl)
+	}
+	s.entries[key] = e
+	s.dirty = true
+	return count
+}
+
+// GetDel はキーを削除し、削除前の値を返す（キーがなかった場合はfalse）
+func (s *Store) GetDel(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.get(key)
+	if ok {
+		delete(s.entries, key)
+		s.dirty = true
+	}
+	return previous.value, ok
+}
+
+// Sweep は期限切れのキーを削除し、削除した数を返す
+func (s *Store) Sweep() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	removed := 0
+	for key, e := range s.entries {
+		if e.expired(now) {
+			delete(s.entries, key)
+			removed++
+		}
+	}
+	if removed > 0 {
+		s.dirty = true
+	}
+	return removed
+}
+
+// Save は前回の書き出し以降に変更があった場合、期限内のキーをファイルに書き出す
+// 一時ファイルに書き込んでから置き換えるため、書き出しの途中で停止してもファイルは壊れない
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	now := s.now()
+	saved := make(map[string]fileEntry, len(s.entries))
+	for key, e := range s.entries {
+		if e.expired(now) {
+			continue
+		}
+		fe := fileEntry{Value: e.value}
+		if !e.expiresAt.IsZero() {
+			expiresAt := e.expiresAt
+			fe.ExpiresAt = &expiresAt
+		}
+		saved[key] = fe
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	if err := s.writeFile(saved); err != nil {
+		// 次の書き出しで再試行する
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run はcontextがキャンセルされるまでinterval（0以下の場合は DefaultSaveInterval）ごとに期限切れのキーを削除してファイルに書き出し、
+// 停止時に最後の書き出しを行う（lifecycle.Manager.Go に登録する）
+func (s *Store) Run(interval time.Duration) func(ctx context.Context) error {
+	if interval <= 0 {
+		interval = DefaultSaveInterval
+	}
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				if err := s.Save(); err != nil {
+					return err
+				}
+				return ctx.Err()
+			case <-ticker.C:
+				s.Sweep()
+				// 書き出しに失敗しても次の間隔で再試行する
+				_ = s.Save()
+			}
+		}
+	}
+}
+
+func (s *Store) writeFile(saved map[string]fileEntry) error {
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// get は期限内のエントリを返す（期限切れのエントリは削除する）
+func (s *Store) get(key string) (entry, bool) {
+	e, ok := s.entries[key]
+	if !ok {
+		return entry{}, false
+	}
+	if e.expired(s.now()) {
+		delete(s.entries, key)
+		s.dirty = true
+		return entry{}, false
+	}
+	return e, true
+}
+
+func (s *Store) set(key, value string, ttl time.Duration) {
+	e := entry{value: value}
+	if ttl > 0 {
+		e.expiresAt = s.now().Add(ttl)
+	}
+	s.entries[key] = e
+	s.dirty = true
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !e.expiresAt.After(now)
+}
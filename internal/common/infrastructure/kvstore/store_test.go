@@ -0,0 +1,169 @@
+package kvstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore は時刻を進められるストアを作成する
+func newTestStore(t *testing.T, path string) (*Store, *time.Time) {
+	t.Helper()
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestStore_SetGetWithTTL(t *testing.T) {
+	s, now := newTestStore(t, "")
+
+	s.Set("blacklist:a", "1", time.Minute)
+	s.Set("last_seen:a", "100", 0)
+
+	value, ok := s.Get("blacklist:a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+	assert.True(t, s.Exists("last_seen:a"))
+
+	*now = now.Add(time.Minute)
+	assert.False(t, s.Exists("blacklist:a"))
+	assert.True(t, s.Exists("last_seen:a"), "keys without ttl never expire")
+}
+
+func TestStore_Expire(t *testing.T) {
+	s, now := newTestStore(t, "")
+
+	s.Set("presence:a", "online", time.Minute)
+	*now = now.Add(30 * time.Second)
+	assert.True(t, s.Expire("presence:a", time.Minute))
+
+	*now = now.Add(45 * time.Second)
+	assert.True(t, s.Exists("presence:a"))
+
+	*now = now.Add(15 * time.Second)
+	assert.False(t, s.Exists("presence:a"))
+	assert.False(t, s.Expire("presence:a", time.Minute), "expired keys cannot be extended")
+}
+
+func TestStore_GetSetAndGetDel(t *testing.T) {
+	s, _ := newTestStore(t, "")
+
+	previous, ok := s.GetSet("presence:a", "online", time.Minute)
+	assert.False(t, ok)
+	assert.Empty(t, previous)
+
+	previous, ok = s.GetSet("presence:a", "busy", time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, "online", previous)
+
+	previous, ok = s.GetDel("presence:a")
+	assert.True(t, ok)
+	assert.Equal(t, "busy", previous)
+	assert.False(t, s.Exists("presence:a"))
+
+	_, ok = s.GetDel("presence:a")
+	assert.False(t, ok)
+}
+
+func TestStore_Incr(t *testing.T) {
+	s, now := newTestStore(t, "")
+
+	assert.Equal(t, int64(1), s.Incr("attempts:a", time.Minute))
+	*now = now.Add(30 * time.Second)
+	assert.Equal(t, int64(2), s.Incr("attempts:a", time.Minute))
+
+	*now = now.Add(45 * time.Second)
+	value, ok := s.Get("attempts:a")
+	assert.True(t, ok, "incr extends the ttl")
+	assert.Equal(t, "2", value)
+
+	*now = now.Add(15 * time.Second)
+	assert.Equal(t, int64(1), s.Incr("attempts:a", time.Minute), "expired counters start over")
+
+	s.Set("attempts:b", "not a number", 0)
+	assert.Equal(t, int64(1), s.Incr("attempts:b", 0))
+}
+
+func TestStore_Sweep(t *testing.T) {
+	s, now := newTestStore(t, "")
+
+	s.Set("a", "1", time.Minute)
+	s.Set("b", "1", time.Hour)
+	s.Set("c", "1", 0)
+
+	*now = now.Add(time.Minute)
+	assert.Equal(t, 1, s.Sweep())
+	assert.Len(t, s.entries, 2)
+}
+
+func TestStore_SaveAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "redis.json")
+	s, now := newTestStore(t, path)
+
+	s.Set("blacklist:a", "1", time.Hour)
+	s.Set("blacklist:b", "1", time.Minute)
+	s.Set("last_seen:a", "100", 0)
+	require.NoError(t, s.Save())
+
+	// 書き出し後に期限切れになったキーは読み込まない
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	reopened.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+	assert.True(t, reopened.Exists("blacklist:a"))
+	assert.False(t, reopened.Exists("blacklist:b"))
+	value, ok := reopened.Get("last_seen:a")
+	assert.True(t, ok)
+	assert.Equal(t, "100", value)
+}
+
+func TestStore_SaveSkipsWhenUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redis.json")
+	s, _ := newTestStore(t, path)
+
+	require.NoError(t, s.Save())
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "nothing to save yet")
+
+	s.Set("a", "1", 0)
+	require.NoError(t, s.Save())
+	require.NoError(t, os.Remove(path))
+
+	require.NoError(t, s.Save())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "unchanged store is not rewritten")
+}
+
+func TestStore_OpenInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redis.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, err := Open(path)
+
+	assert.Error(t, err)
+}
+
+func TestStore_RunSavesOnStop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redis.json")
+	s, err := Open(path)
+	require.NoError(t, err)
+	s.Set("a", "1", 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(time.Hour)(ctx) }()
+	cancel()
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	assert.True(t, reopened.Exists("a"))
+}
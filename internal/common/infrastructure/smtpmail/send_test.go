@@ -0,0 +1,103 @@
+package smtpmail
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listen はテスト用のSMTPサーバーを起動し、接続ごとに handle を呼び出す
+func listen(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestSend(t *testing.T) {
+	received := make(chan string, 1)
+	addr := listen(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+
+		var body strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- body.String()
+					reply("250 OK")
+					continue
+				}
+				body.WriteString(line)
+				continue
+			}
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 localhost")
+			case strings.HasPrefix(line, "DATA"):
+				inData = true
+				reply("354 go ahead")
+			case strings.HasPrefix(line, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	})
+
+	err := Send(context.Background(), addr, nil, "from@example.com", []string{"to@example.com"}, []byte("Subject: hi\r\n\r\nhello\r\n"))
+
+	require.NoError(t, err)
+	assert.Contains(t, <-received, "hello")
+}
+
+func TestSend_StopsAtDeadline(t *testing.T) {
+	// 接続を受け付けるが応答しないサーバー
+	addr := listen(t, func(conn net.Conn) {
+		time.Sleep(time.Second)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+
+	err := Send(ctx, addr, nil, "from@example.com", []string{"to@example.com"}, []byte("hello"))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestSend_RejectsLineBreaks(t *testing.T) {
+	err := Send(context.Background(), "127.0.0.1:1", nil, "from@example.com", []string{"to@example.com\r\nRCPT TO:<x@example.com>"}, nil)
+
+	assert.Error(t, err)
+}
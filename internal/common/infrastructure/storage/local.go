@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/common/domain"
+)
+
+// LocalStorage はファイルをローカルのディレクトリに保存するストレージ
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage はdir以下にファイルを保存するストレージを作成する（ディレクトリがない場合は作成する）
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create file storage directory: %w", err)
+	}
+	return &LocalStorage{dir: dir}, nil
+}
+
+// Put はファイルを一時ファイルに書き込んでから置き換える（書き込み途中のファイルを読まれないようにする）
+func (s *LocalStorage) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+// Get はファイルを開く
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrObjectNotFound
+	}
+	return f, err
+}
+
+// Delete はファイルを削除する
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path はキーをディレクトリ内のパスに変換する（ディレクトリの外を指すキーはエラー）
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || strings.Contains(key, "\\") || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned[1:])), nil
+}
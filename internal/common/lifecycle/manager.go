@@ -0,0 +1,257 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DefaultStopTimeout はコンポーネントごとの停止の待ち時間の既定値
+const DefaultStopTimeout = 10 * time.Second
+
+// ErrStopTimeout はコンポーネントが待ち時間内に停止しなかった場合のエラー
+var ErrStopTimeout = errors.New("component did not stop in time")
+
+// Worker は Start・Stop を持つバックグラウンドのワーカー（Stopは実行中の処理の終了を待つ）
+type Worker interface {
+	Start(ctx context.Context)
+	Stop()
+}
+
+// StopFailure は停止に失敗したコンポーネント
+type StopFailure struct {
+	Name string
+	Err  error
+}
+
+// ShutdownError は停止に失敗したコンポーネントの一覧
+type ShutdownError struct {
+	Failures []StopFailure
+}
+
+func (e *ShutdownError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		parts[i] = fmt.Sprintf("%s: %v", f.Name, f.Err)
+	}
+	return "failed to stop components: " + strings.Join(parts, ", ")
+}
+
+// component は管理対象のコンポーネント
+type component struct {
+	name    string
+	timeout time.Duration
+	start   func(ctx context.Context)
+	stop    func(ctx context.Context) error
+	cancel  context.CancelFunc
+}
+
+// Manager はバックグラウンドのコンポーネント（スケジューラー・ワーカー・WebSocketハブなど）の起動と停止を管理する
+// 登録順に起動し、逆順に1つずつ停止する。停止はコンポーネントごとの待ち時間で打ち切り、
+// 待ち時間内に停止しなかったコンポーネントはそのcontextをキャンセルして停止を促し、失敗として報告する
+type Manager struct {
+	logger         logger.Logger
+	defaultTimeout time.Duration
+	mu             sync.Mutex
+	components     []*component
+	started        bool
+	stopped        bool
+	// disabled は登録しても起動しないコンポーネントの名前（値は登録されたかどうか）
+	disabled map[string]bool
+}
+
+// NewManager は新しいManagerを作成する（defaultTimeout が0以下の場合は DefaultStopTimeout を使う）
+func NewManager(defaultTimeout time.Duration, logger logger.Logger) *Manager {
+	if defaultTimeout <= 0 {
+		defaultTimeout = DefaultStopTimeout
+	}
+	return &Manager{
+		logger:         logger,
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+// Disable は指定した名前のコンポーネントを登録しても起動しないようにする（DISABLED_WORKERSなど、登録より前に呼び出す）
+func (m *Manager) Disable(names ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disabled == nil {
+		m.disabled = make(map[string]bool)
+	}
+	for _, name := range names {
+		m.disabled[name] = false
+	}
+}
+
+// Add はワーカーを登録する（timeout が0以下の場合は既定の待ち時間を使う）
+func (m *Manager) Add(name string, w Worker, timeout time.Duration) {
+	m.add(&component{
+		name:    name,
+		timeout: timeout,
+		start:   w.Start,
+		stop: func(ctx context.Context) error {
+			w.Stop()
+			return nil
+		},
+	})
+}
+
+// Go はcontextがキャンセルされるまで実行し続ける関数を登録する（WebSocketハブなど）
+// run のパニックは回復してエラー追跡サービスに報告する
+// 停止時はcontextをキャンセルし、run が戻るまで待つ。context.Canceled 以外のエラーは停止の失敗として報告する
+func (m *Manager) Go(name string, run func(ctx context.Context) error, timeout time.Duration) {
+	c := &component{name: name, timeout: timeout}
+	var (
+		done   chan struct{}
+		runErr error
+	)
+	c.start = func(ctx context.Context) {
+		done = make(chan struct{})
+		go func() {
+			defer close(done)
+			defer errtrack.Recover(name)
+			runErr = run(ctx)
+			if runErr != nil && !errors.Is(runErr, context.Canceled) && ctx.Err() == nil {
+				m.logger.Error("Background component stopped unexpectedly",
+					logger.Any("component", name), logger.Error(runErr))
+			}
+		}()
+	}
+	c.stop = func(ctx context.Context) error {
+		c.cancel()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if runErr != nil && !errors.Is(runErr, context.Canceled) {
+			return runErr
+		}
+		return nil
+	}
+	m.add(c)
+}
+
+// OnStop は停止時にだけ実行する処理を登録する（メッセージブローカーのクローズなど）
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error, timeout time.Duration) {
+	m.add(&component{
+		name:    name,
+		timeout: timeout,
+		start:   func(context.Context) {},
+		stop:    stop,
+	})
+}
+
+// add はコンポーネントを登録する
+func (m *Manager) add(c *component) {
+	if c.timeout <= 0 {
+		c.timeout = m.defaultTimeout
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.disabled[c.name]; ok {
+		m.disabled[c.name] = true
+		return
+	}
+	m.components = append(m.components, c)
+}
+
+// Start は登録順にコンポーネントを起動する。各コンポーネントには ctx から派生した個別のcontextを渡す
+func (m *Manager) Start(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		m.logger.Warn("Background components already started")
+		return
+	}
+	m.started = true
+
+	for name, registered := range m.disabled {
+		if registered {
+			m.logger.Info("Background component disabled", logger.Any("component", name))
+		} else {
+			m.logger.Warn("Unknown background component disabled", logger.Any("component", name))
+		}
+	}
+
+	for _, c := range m.components {
+		var componentCtx context.Context
+		componentCtx, c.cancel = context.WithCancel(ctx)
+		c.start(componentCtx)
+		m.logger.Info("Background component started", logger.Any("component", c.name))
+	}
+}
+
+// Shutdown は登録と逆順にコンポーネントを停止し、停止に失敗したコンポーネントを *ShutdownError で返す
+// 各コンポーネントの待ち時間は ctx の期限でも打ち切る
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.started || m.stopped {
+		m.mu.Unlock()
+		return nil
+	}
+	m.stopped = true
+	components := append([]*component(nil), m.components...)
+	m.mu.Unlock()
+
+	var failures []StopFailure
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		started := time.Now()
+
+		if err := m.stopComponent(ctx, c); err != nil {
+			failures = append(failures, StopFailure{Name: c.name, Err: err})
+			m.logger.Error("Failed to stop background component",
+				logger.Any("component", c.name),
+				logger.Any("timeout", c.timeout.String()),
+				logger.Error(err))
+			continue
+		}
+		m.logger.Info("Background component stopped",
+			logger.Any("component", c.name),
+			logger.Any("elapsed", time.Since(started).String()))
+	}
+
+	if len(failures) > 0 {
+		return &ShutdownError{Failures: failures}
+	}
+	return nil
+}
+
+// stopComponent は1つのコンポーネントを待ち時間内に停止する
+// 待ち時間を過ぎた場合はコンポーネントのcontextをキャンセルし、停止処理の終了は待たない
+func (m *Manager) stopComponent(ctx context.Context, c *component) error {
+	stopCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	defer c.cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errtrack.ReportPanic(c.name, r)
+				result <- fmt.Errorf("panic while stopping: %v", r)
+			}
+		}()
+		result <- c.stop(stopCtx)
+	}()
+
+	select {
+	case err := <-result:
+		if errors.Is(err, context.DeadlineExceeded) {
+			return ErrStopTimeout
+		}
+		return err
+	case <-stopCtx.Done():
+		return ErrStopTimeout
+	}
+}
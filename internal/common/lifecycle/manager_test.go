@@ -0,0 +1,171 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+func newTestManager() *Manager {
+	return NewManager(time.Second, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+}
+
+// fakeWorker は起動・停止を記録するテスト用のワーカー
+type fakeWorker struct {
+	name      string
+	events    *[]string
+	mu        *sync.Mutex
+	stopDelay time.Duration
+	ctx       context.Context
+}
+
+func (w *fakeWorker) Start(ctx context.Context) {
+	w.ctx = ctx
+	w.record("start " + w.name)
+}
+
+func (w *fakeWorker) Stop() {
+	time.Sleep(w.stopDelay)
+	w.record("stop " + w.name)
+}
+
+func (w *fakeWorker) record(event string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	*w.events = append(*w.events, event)
+}
+
+func TestManager_StartsInOrderAndStopsInReverse(t *testing.T) {
+	m := newTestManager()
+	var events []string
+	var mu sync.Mutex
+	m.Add("a", &fakeWorker{name: "a", events: &events, mu: &mu}, 0)
+	m.Add("b", &fakeWorker{name: "b", events: &events, mu: &mu}, 0)
+	m.OnStop("flush", func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, "stop flush")
+		return nil
+	}, 0)
+
+	m.Start(context.Background())
+	err := m.Shutdown(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"start a", "start b", "stop flush", "stop b", "stop a"}, events)
+}
+
+func TestManager_Disable(t *testing.T) {
+	m := newTestManager()
+	var events []string
+	var mu sync.Mutex
+	m.Disable("b", "unknown")
+	m.Add("a", &fakeWorker{name: "a", events: &events, mu: &mu}, 0)
+	m.Add("b", &fakeWorker{name: "b", events: &events, mu: &mu}, 0)
+
+	m.Start(context.Background())
+	err := m.Shutdown(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"start a", "stop a"}, events)
+}
+
+func TestManager_Go(t *testing.T) {
+	t.Run("cancels and waits for run", func(t *testing.T) {
+		m := newTestManager()
+		exited := false
+		m.Go("hub", func(ctx context.Context) error {
+			<-ctx.Done()
+			exited = true
+			return ctx.Err()
+		}, 0)
+
+		m.Start(context.Background())
+		err := m.Shutdown(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, exited)
+	})
+
+	t.Run("reports run error", func(t *testing.T) {
+		m := newTestManager()
+		runErr := errors.New("listener closed")
+		m.Go("hub", func(ctx context.Context) error {
+			<-ctx.Done()
+			return runErr
+		}, 0)
+
+		m.Start(context.Background())
+		err := m.Shutdown(context.Background())
+
+		var shutdownErr *ShutdownError
+		require.ErrorAs(t, err, &shutdownErr)
+		require.Len(t, shutdownErr.Failures, 1)
+		assert.Equal(t, "hub", shutdownErr.Failures[0].Name)
+		assert.ErrorIs(t, shutdownErr.Failures[0].Err, runErr)
+	})
+}
+
+func TestManager_ReportsComponentsThatDoNotStop(t *testing.T) {
+	m := newTestManager()
+	var events []string
+	var mu sync.Mutex
+	slow := &fakeWorker{name: "slow", events: &events, mu: &mu, stopDelay: time.Second}
+	m.Add("fast", &fakeWorker{name: "fast", events: &events, mu: &mu}, 0)
+	m.Add("slow", slow, 20*time.Millisecond)
+	m.OnStop("broken", func(ctx context.Context) error {
+		return errors.New("close failed")
+	}, 0)
+
+	m.Start(context.Background())
+	started := time.Now()
+	err := m.Shutdown(context.Background())
+
+	var shutdownErr *ShutdownError
+	require.ErrorAs(t, err, &shutdownErr)
+	require.Len(t, shutdownErr.Failures, 2)
+	assert.Equal(t, "broken", shutdownErr.Failures[0].Name)
+	assert.Equal(t, "slow", shutdownErr.Failures[1].Name)
+	assert.ErrorIs(t, shutdownErr.Failures[1].Err, ErrStopTimeout)
+	assert.Less(t, time.Since(started), 500*time.Millisecond)
+
+	// 停止しなかったコンポーネントのcontextはキャンセルされ、後続のコンポーネントも停止する
+	assert.Error(t, slow.ctx.Err())
+	mu.Lock()
+	assert.Contains(t, events, "stop fast")
+	mu.Unlock()
+}
+
+func TestManager_ShutdownContextBoundsWaiting(t *testing.T) {
+	m := newTestManager()
+	m.OnStop("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, time.Minute)
+
+	m.Start(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := m.Shutdown(ctx)
+
+	var shutdownErr *ShutdownError
+	require.ErrorAs(t, err, &shutdownErr)
+	assert.ErrorIs(t, shutdownErr.Failures[0].Err, ErrStopTimeout)
+}
+
+func TestManager_ShutdownWithoutStart(t *testing.T) {
+	m := newTestManager()
+	m.OnStop("flush", func(ctx context.Context) error {
+		t.Fatal("should not be called")
+		return nil
+	}, 0)
+
+	assert.NoError(t, m.Shutdown(context.Background()))
+}
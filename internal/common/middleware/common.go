@@ -3,194 +3,177 @@ package middleware
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
 	"github.com/hryt430/Yotei+/pkg/logger"
+	"go.uber.org/zap/zapcore"
 )
 
-// LoggerMiddleware はリクエストのロギングを行うミドルウェアです
-func LoggerMiddleware(log logger.Logger) gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		log.Info("HTTP Request",
-			logger.Any("method", param.Method),
-			logger.Any("path", param.Path),
-			logger.Any("status", param.StatusCode),
-			logger.Any("latency", param.Latency),
-			logger.Any("client_ip", param.ClientIP),
-			logger.Any("user_agent", param.Request.UserAgent()),
-		)
-		return ""
-	})
-}
+// RequestIDHeader はリクエストID（相関ID）を受け渡すヘッダーです
+const RequestIDHeader = "X-Request-ID"
 
-// RecoveryMiddleware はパニックからの回復を処理するミドルウェアです
-func RecoveryMiddleware(log logger.Logger) gin.HandlerFunc {
-	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		if err, ok := recovered.(string); ok {
-			log.Error("Panic recovered", logger.Any("error", err))
-		}
-		c.AbortWithStatus(http.StatusInternalServerError)
-	})
-}
+// maxRequestIDLength はクライアントから受け付けるリクエストIDの最大長です
+const maxRequestIDLength = 128
 
-// CORSMiddleware はCross-Origin Resource Sharingを処理するミドルウェアです
-func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
+// LoggerMiddleware はリクエストごとにメソッド・ルート・ステータス・レイテンシ・ユーザーIDを構造化ログに記録するミドルウェアです
+// RequestIDMiddlewareの後に適用すると、リクエストIDも記録されます
+func LoggerMiddleware(log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
+		start := time.Now()
 
-		// 設定からCORSの許可されたオリジンを取得
-		allowedOrigins := cfg.GetAllowedOrigins()
+		c.Next()
 
-		// 開発環境では全てのオリジンを許可
-		if cfg.IsDevelopment() || isOriginAllowed(origin, allowedOrigins) {
-			c.Header("Access-Control-Allow-Origin", origin)
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
 		}
 
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token, X-Requested-With")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Max-Age", "86400")
-
-		// プリフライトリクエスト（OPTIONS）の処理
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
-			return
+		fields := []zapcore.Field{
+			logger.String("method", c.Request.Method),
+			logger.String("route", route),
+			logger.String("path", c.Request.URL.Path),
+			logger.Int("status", c.Writer.Status()),
+			logger.Duration("latency", time.Since(start)),
+			logger.String("client_ip", c.ClientIP()),
+			logger.String("user_agent", c.Request.UserAgent()),
+		}
+		if userID := c.GetString("user_id"); userID != "" {
+			fields = append(fields, logger.String("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, logger.String("errors", c.Errors.String()))
 		}
 
-		c.Next()
-	}
-}
-
-// RateLimitMiddleware はレート制限を行うミドルウェアです
-func RateLimitMiddleware(cfg *config.Config) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// レート制限の実装（Redisを使用する場合はここで実装）
-		// 現在は簡単な通過のみ
-		c.Next()
+		reqLog := log.WithContext(c.Request.Context())
+		switch status := c.Writer.Status(); {
+		case status >= http.StatusInternalServerError:
+			reqLog.Error("HTTP Request", fields...)
+		case status >= http.StatusBadRequest:
+			reqLog.Warn("HTTP Request", fields...)
+		default:
+			reqLog.Info("HTTP Request", fields...)
+		}
 	}
 }
 
-// SecurityHeadersMiddleware はセキュリティヘッダーを設定するミドルウェアです
-func SecurityHeadersMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("X-Content-Type-Options", "nosniff")
-		c.Header("X-Frame-Options", "DENY")
-		c.Header("X-XSS-Protection", "1; mode=block")
-		c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
-
-		// 本番環境ではHTTPS必須
-		if gin.Mode() == gin.ReleaseMode {
-			c.Header("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
-		}
-
-		c.Next()
+// RecoveryMiddleware はパニックからの回復を処理するミドルウェアです
+// スタックトレースをリクエストID・ユーザーIDと共にログに記録してエラー追跡サービスに報告し、
+// レスポンスを書き出す前であれば標準のエラーレスポンス（500）を返します
+func RecoveryMiddleware(log logger.Logger, tracker errtrack.Tracker) gin.HandlerFunc {
+	if tracker == nil {
+		tracker = errtrack.NopTracker{}
 	}
-}
 
-// CSRFProtection はCSRF攻撃を防ぐミドルウェアです
-func CSRFProtection() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// GET, HEAD, OPTIONS は CSRF チェックをスキップ
-		if c.Request.Method == "GET" || c.Request.Method == "HEAD" || c.Request.Method == "OPTIONS" {
-			c.Next()
-			return
-		}
-
-		// CSRFトークンの取得（ヘッダーまたはフォームから）
-		token := c.GetHeader("X-CSRF-Token")
-		if token == "" {
-			token = c.PostForm("_csrf")
-		}
-
-		// セッションからCSRFトークンを取得（実際の実装ではセッションストアを使用）
-		sessionToken, exists := c.Get("csrf_token")
-		if !exists || token == "" || token != sessionToken.(string) {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "CSRF token validation failed",
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// ハンドラーが意図的に中断した場合はnet/httpに処理を任せる
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			// クライアントの切断はレスポンスを返せないため報告しない
+			if isBrokenPipe(recovered) {
+				_ = c.Error(fmt.Errorf("%v", recovered))
+				c.Abort()
+				return
+			}
+
+			report := errtrack.NewPanicReport("http", recovered)
+			report.RequestID = c.GetString("request_id")
+			report.UserID = c.GetString("user_id")
+			report.Method = c.Request.Method
+			report.Path = c.Request.URL.Path
+
+			log.WithContext(c.Request.Context()).Error("Panic recovered",
+				logger.String("panic", report.Message),
+				logger.String("method", report.Method),
+				logger.String("path", report.Path),
+				logger.String("user_id", report.UserID),
+				logger.String("stack", report.Stack))
+			tracker.Capture(report)
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "INTERNAL_ERROR",
+				"message": "Internal server error",
 			})
-			return
-		}
+		}()
 
 		c.Next()
 	}
 }
 
-// SetCSRFToken はCSRFトークンを生成・設定するミドルウェアです
-func SetCSRFToken() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// 既にトークンが設定されている場合はスキップ
-		if _, exists := c.Get("csrf_token"); exists {
-			c.Next()
-			return
-		}
-
-		// CSRFトークンの生成
-		token, err := generateCSRFToken()
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to generate CSRF token",
-			})
-			return
-		}
-
-		// コンテキストにトークンを設定
-		c.Set("csrf_token", token)
-
-		// レスポンスヘッダーにもトークンを設定
-		c.Header("X-CSRF-Token", token)
-
-		c.Next()
+// isBrokenPipe はパニックがクライアントの切断（broken pipe・connection reset）によるものかを判定します
+func isBrokenPipe(recovered interface{}) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
 	}
+	msg := strings.ToLower(syscallErr.Error())
+	return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
 }
 
 // RequestIDMiddleware はリクエストIDを生成・設定するミドルウェアです
+// クライアントが送信したX-Request-IDが妥当な場合はそれを引き継ぎ、リクエストのcontextにも格納します
+// （usecase・repositoryのログはlogger.WithContextでリクエストIDを出力します）
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
 			requestID = generateRequestID()
 		}
 
 		c.Set("request_id", requestID)
-		c.Header("X-Request-ID", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
 
 		c.Next()
 	}
 }
 
-// TimeoutMiddleware はリクエストタイムアウトを設定するミドルウェアです
-func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// タイムアウト設定（実際の実装ではcontext.WithTimeoutを使用）
-		c.Next()
-	}
-}
-
-// isOriginAllowed は指定されたオリジンが許可されているかチェックします
-func isOriginAllowed(origin string, allowedOrigins []string) bool {
-	for _, allowed := range allowedOrigins {
-		if origin == allowed || allowed == "*" {
-			return true
-		}
-	}
-	return false
-}
-
-// generateCSRFToken はCSRFトークンを生成します
-func generateCSRFToken() (string, error) {
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
-	}
-	return base64.URLEncoding.EncodeToString(bytes), nil
-}
-
 // generateRequestID はリクエストIDを生成します
 func generateRequestID() string {
 	bytes := make([]byte, 16)
 	rand.Read(bytes)
-	return base64.URLEncoding.EncodeToString(bytes)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// isValidRequestID はクライアントから受け取ったリクエストIDがログに出力できる形式か確認します
+// （英数字と「-_.:」のみ、最大128文字）
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
 }
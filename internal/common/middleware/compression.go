@@ -0,0 +1,237 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+
+	// compressionMinSize はこのサイズ未満のレスポンスを圧縮しない（バイト）
+	compressionMinSize = 1024
+	// brotliLevel はbrotliの圧縮レベル（速度と圧縮率のバランスを考慮）
+	brotliLevel = 4
+)
+
+// compressibleContentTypes は圧縮対象のContent-Type（前方一致）
+var compressibleContentTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/x-ndjson",
+	"image/svg+xml",
+	"text/",
+}
+
+var (
+	gzipWriterPool = sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	brotliWriterPool = sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(io.Discard, brotliLevel)
+	}}
+)
+
+// CompressionMiddleware はAccept-Encodingに応じてレスポンスをbrotliまたはgzipで圧縮するミドルウェアです
+// 小さなレスポンス、画像などの圧縮済みの形式、WebSocketのアップグレードは圧縮しません
+func CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		cw := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = cw
+		defer cw.close()
+
+		c.Next()
+	}
+}
+
+// compressWriter はレスポンスボディをcompressionMinSizeまでバッファし、
+// 圧縮対象であれば圧縮しながら書き出すResponseWriter
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding   string
+	buf        bytes.Buffer
+	compressor io.WriteCloser
+	decided    bool // 圧縮するかどうか決定済みか
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.compressor != nil {
+			return w.compressor.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= compressionMinSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush はバッファ済みのデータを書き出してからフラッシュする（ストリーミングレスポンス用）
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(w.buf.Len() >= compressionMinSize)
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide は圧縮するかどうかを決め、バッファ済みのデータを書き出す
+func (w *compressWriter) decide(largeEnough bool) error {
+	w.decided = true
+
+	if largeEnough && w.shouldCompress() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		w.compressor = w.newCompressor()
+	}
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	data := w.buf.Bytes()
+	w.buf = bytes.Buffer{}
+	if w.compressor != nil {
+		_, err := w.compressor.Write(data)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(data)
+	return err
+}
+
+// shouldCompress はステータス・ヘッダーから圧縮対象かどうかを判定する
+func (w *compressWriter) shouldCompress() bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	for _, prefix := range compressibleContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *compressWriter) newCompressor() io.WriteCloser {
+	switch w.encoding {
+	case encodingBrotli:
+		bw := brotliWriterPool.Get().(*brotli.Writer)
+		bw.Reset(w.ResponseWriter)
+		return &pooledWriter{WriteCloser: bw, release: func() { brotliWriterPool.Put(bw) }}
+	default:
+		gw := gzipWriterPool.Get().(*gzip.Writer)
+		gw.Reset(w.ResponseWriter)
+		return &pooledWriter{WriteCloser: gw, release: func() { gzipWriterPool.Put(gw) }}
+	}
+}
+
+// close は残りのデータを書き出し、圧縮を終了する
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide(w.buf.Len() >= compressionMinSize)
+	}
+	if w.compressor != nil {
+		_ = w.compressor.Close()
+	}
+}
+
+// pooledWriter はClose時に圧縮ライターをプールに戻す
+type pooledWriter struct {
+	io.WriteCloser
+	release func()
+}
+
+func (w *pooledWriter) Close() error {
+	err := w.WriteCloser.Close()
+	w.release()
+	return err
+}
+
+func (w *pooledWriter) Flush() error {
+	if flusher, ok := w.WriteCloser.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// negotiateEncoding はAccept-Encodingから使用する圧縮形式を選ぶ（brotliを優先）
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := make(map[string]bool)
+	wildcard := false
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if qualityIsZero(params) {
+			accepted[name] = false
+			continue
+		}
+		if name == "*" {
+			wildcard = true
+			continue
+		}
+		accepted[name] = true
+	}
+
+	for _, encoding := range []string{encodingBrotli, encodingGzip} {
+		allowed, listed := accepted[encoding]
+		if allowed || (!listed && wildcard) {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// qualityIsZero は「q=0」で明示的に拒否されているかを判定する
+func qualityIsZero(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.TrimSpace(key) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil && q == 0
+	}
+	return false
+}
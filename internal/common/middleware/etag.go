@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETagMiddleware はGETレスポンスのボディからETagを計算し、If-None-Matchが一致する場合は304を返すミドルウェアです
+// 一覧・統計など大きなレスポンスを返すエンドポイントに個別に適用します
+// レスポンスはユーザーごとに異なるため、Cache-Controlはprivateで毎回再検証させます
+func ETagMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		bw := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = bw
+
+		c.Next()
+
+		c.Writer = bw.ResponseWriter
+		if bw.Status() != http.StatusOK {
+			bw.flush()
+			return
+		}
+
+		// 圧縮の有無でボディのバイト列は変わるため弱いETagとする
+		sum := sha256.Sum256(bw.buf.Bytes())
+		etag := `W/"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+
+		header := c.Writer.Header()
+		header.Set("ETag", etag)
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", "private, no-cache")
+		}
+
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			c.Writer.WriteHeader(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+
+		bw.flush()
+	}
+}
+
+// bufferedWriter はETag計算のためにレスポンスボディをバッファするResponseWriter
+type bufferedWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// flush はバッファしたボディを書き出す
+func (w *bufferedWriter) flush() {
+	if w.buf.Len() == 0 {
+		return
+	}
+	_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+}
+
+// etagMatches はIf-None-MatchのいずれかのETagと一致するかを判定する（弱い比較）
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
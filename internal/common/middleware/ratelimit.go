@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitIdleTimeout はリクエストのないクライアントの状態を破棄するまでの時間です
+const rateLimitIdleTimeout = 5 * time.Minute
+
+// RateLimiter はクライアントごとの一定時間（既定は1秒）あたりのリクエスト数を制限するトークンバケットです
+// 上限は実行中に変更でき（設定の再読み込み用）、0の場合は制限しません
+type RateLimiter struct {
+	mu          sync.Mutex
+	rps         int
+	window      time.Duration
+	buckets     map[string]*rateBucket
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+// rateBucket はクライアントの残りのトークン
+type rateBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// NewRateLimiter は新しいRateLimiterを作成します
+func NewRateLimiter(rps int) *RateLimiter {
+	return NewWindowRateLimiter(rps, time.Second)
+}
+
+// NewWindowRateLimiter は window あたり limit 件までのリクエストを許可するRateLimiterを作成します
+// （例: 1分あたり10件。上限は window をかけて少しずつ回復します）
+func NewWindowRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		rps:         limit,
+		window:      window,
+		buckets:     make(map[string]*rateBucket),
+		lastCleanup: time.Now(),
+		now:         time.Now,
+	}
+}
+
+// SetRPS は1秒あたりの上限を変更します（0で制限しない）
+func (l *RateLimiter) SetRPS(rps int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rps = rps
+}
+
+// RPS は現在の1秒あたりの上限を返します
+func (l *RateLimiter) RPS() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rps
+}
+
+// Allow はクライアントのリクエストを許可するかを判定します（window 分のリクエストまでまとめて許可します）
+func (l *RateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rps <= 0 {
+		return true
+	}
+
+	now := l.now()
+	l.cleanup(now)
+
+	limit := float64(l.rps)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateBucket{tokens: limit, lastSeen: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens += now.Sub(bucket.lastSeen).Seconds() / l.window.Seconds() * limit
+	if bucket.tokens > limit {
+		bucket.tokens = limit
+	}
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// cleanup はしばらくリクエストのないクライアントの状態を破棄します
+func (l *RateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < rateLimitIdleTimeout {
+		return
+	}
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) >= rateLimitIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// RateLimitMiddleware はクライアントのIPアドレスごとにリクエスト数を制限するミドルウェアです
+// 上限を超えた場合は429を返します
+func RateLimitMiddleware(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter.Allow(c.ClientIP()) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":   "RATE_LIMITED",
+			"message": "Too many requests",
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/apiversion"
+)
+
+const (
+	// CSRFCookieName はCSRFトークンを保持するCookie名です（フロントエンドから読み取れるようHttpOnlyにしない）
+	CSRFCookieName = "csrf_token"
+	// CSRFHeaderName はCSRFトークンを送信するヘッダー名です
+	CSRFHeaderName = "X-CSRF-Token"
+
+	csrfCookieMaxAge = 12 * 60 * 60 // 12時間
+)
+
+// authCookieNames はCookie認証で使用するCookie名です（これらを送信するリクエストのみCSRF検証の対象）
+var authCookieNames = []string{"access_token", "refresh_token"}
+
+// CORSProfile は特定のパス配下に既定（CORS_*）と異なるCORSを適用するプロファイルです
+// Cookieを送信させない（Access-Control-Allow-Credentialsを返さない）ため、Authorizationヘッダーで認証するAPIに使います
+type CORSProfile struct {
+	// PathPrefix はプロファイルを適用するパス（例: /api/v1/clip、配下のパスを含む）
+	PathPrefix     string
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// matches はパスがプロファイルの対象か判定します
+func (p CORSProfile) matches(requestPath string) bool {
+	rest, ok := strings.CutPrefix(requestPath, p.PathPrefix)
+	return ok && (rest == "" || strings.HasPrefix(rest, "/"))
+}
+
+// CORSMiddleware はCross-Origin Resource Sharingを処理するミドルウェアです
+// 許可するオリジン・メソッド・ヘッダーは環境ごとの設定（CORS_*）から取得します
+// profilesに一致するパスには、既定の設定の代わりにプロファイルを適用します
+func CORSMiddleware(cfg *config.Config, profiles ...CORSProfile) gin.HandlerFunc {
+	allowedOrigins := cfg.GetAllowedOrigins()
+	allowedMethods := strings.Join(cfg.GetCORSAllowedMethods(), ", ")
+	allowedHeaders := strings.Join(cfg.GetCORSAllowedHeaders(), ", ")
+	exposedHeaders := strings.Join([]string{
+		RequestIDHeader,
+		CSRFHeaderName,
+		apiversion.VersionHeader,
+		apiversion.DeprecationHeader,
+		apiversion.SunsetHeader,
+		apiversion.LinkHeader,
+	}, ", ")
+	maxAge := strconv.Itoa(cfg.CORS.MaxAge)
+
+	return func(c *gin.Context) {
+		for _, profile := range profiles {
+			if profile.matches(c.Request.URL.Path) {
+				handleCORSProfile(c, profile, maxAge)
+				return
+			}
+		}
+
+		origin := c.Request.Header.Get("Origin")
+
+		if origin != "" && isOriginAllowed(origin, allowedOrigins) {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Access-Control-Allow-Credentials", "true")
+			c.Header("Access-Control-Expose-Headers", exposedHeaders)
+		}
+		// オリジンごとにレスポンスが変わるためキャッシュを分ける
+		c.Writer.Header().Add("Vary", "Origin")
+
+		// プリフライトリクエスト（OPTIONS）の処理
+		if c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", allowedMethods)
+			c.Header("Access-Control-Allow-Headers", allowedHeaders)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// handleCORSProfile はプロファイルのオリジン・メソッド・ヘッダーだけを許可します（資格情報は許可しない）
+func handleCORSProfile(c *gin.Context, profile CORSProfile, maxAge string) {
+	origin := c.Request.Header.Get("Origin")
+	allowed := origin != "" && isOriginAllowed(origin, profile.AllowedOrigins)
+
+	if allowed {
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", RequestIDHeader)
+	}
+	c.Writer.Header().Add("Vary", "Origin")
+
+	if c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != "" {
+		if allowed {
+			c.Header("Access-Control-Allow-Methods", strings.Join(profile.AllowedMethods, ", "))
+			c.Header("Access-Control-Allow-Headers", strings.Join(profile.AllowedHeaders, ", "))
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+
+	c.Next()
+}
+
+// SecurityHeadersMiddleware はセキュリティヘッダーを設定するミドルウェアです
+// HSTSは本番環境でのみ送信します（SECURITY_HSTS_MAX_AGEが0の場合は送信しない）
+func SecurityHeadersMiddleware(cfg *config.Config) gin.HandlerFunc {
+	frameOptions := cfg.Security.FrameOptions
+	if frameOptions == "" {
+		frameOptions = "DENY"
+	}
+
+	hsts := ""
+	if cfg.IsProduction() && cfg.Security.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.Security.HSTSMaxAge)
+		if cfg.Security.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Header("X-Frame-Options", frameOptions)
+		c.Header("X-XSS-Protection", "1; mode=block")
+		c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
+
+		if hsts != "" {
+			c.Header("Strict-Transport-Security", hsts)
+		}
+
+		c.Next()
+	}
+}
+
+// SetCSRFToken はCSRFトークンをCookieに発行するミドルウェアです（ダブルサブミットCookie方式）
+// トークンはX-CSRF-Tokenレスポンスヘッダーでも返却します
+func SetCSRFToken(cfg *config.Config) gin.HandlerFunc {
+	secure := cfg.IsProduction()
+
+	return func(c *gin.Context) {
+		token, err := c.Cookie(CSRFCookieName)
+		if err != nil || token == "" {
+			token, err = generateCSRFToken()
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to generate CSRF token",
+				})
+				return
+			}
+			c.SetSameSite(http.SameSiteLaxMode)
+			c.SetCookie(CSRFCookieName, token, csrfCookieMaxAge, "/", "", secure, false)
+		}
+
+		c.Set("csrf_token", token)
+		c.Header(CSRFHeaderName, token)
+
+		c.Next()
+	}
+}
+
+// CSRFProtection はCookie認証のリクエストに対してCSRF攻撃を防ぐミドルウェアです
+// 更新系メソッドでは、X-CSRF-TokenヘッダーとCookieのトークンが一致する必要があります
+// Bearerトークンで認証するリクエストや認証Cookieを持たないリクエストは対象外です
+// （認証ミドルウェアと同じく「Bearer 」で始まるAuthorizationヘッダーのみをCookie認証の代わりとみなす）
+func CSRFProtection() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) || !hasAuthCookie(c) || strings.HasPrefix(c.GetHeader("Authorization"), "Bearer ") {
+			c.Next()
+			return
+		}
+
+		cookieToken, err := c.Cookie(CSRFCookieName)
+		headerToken := c.GetHeader(CSRFHeaderName)
+		if err != nil || cookieToken == "" || headerToken == "" ||
+			subtle.ConstantTimeCompare([]byte(cookieToken), []byte(headerToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "CSRF token validation failed",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// isOriginAllowed は指定されたオリジンが許可されているかチェックします
+// 許可リストには「https://*.example.com」「http://localhost:*」のようなワイルドカードを指定できます
+func isOriginAllowed(origin string, allowedOrigins []string) bool {
+	for _, allowed := range allowedOrigins {
+		if origin == allowed || allowed == "*" {
+			return true
+		}
+		if strings.Contains(allowed, "*") {
+			if matched, err := path.Match(allowed, origin); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSafeMethod は状態を変更しないHTTPメソッドかどうかを判定します
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// hasAuthCookie はリクエストが認証Cookieを持つかどうかを判定します
+func hasAuthCookie(c *gin.Context) bool {
+	for _, name := range authCookieNames {
+		if value, err := c.Cookie(name); err == nil && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// generateCSRFToken はCSRFトークンを生成します
+func generateCSRFToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
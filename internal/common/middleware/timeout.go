@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// MetricRequestTimeouts は制限時間を超えて504を返したリクエストの数
+const MetricRequestTimeouts = "http_request_timeouts_total"
+
+// DefaultRequestTimeout はリクエストの既定の制限時間です
+const DefaultRequestTimeout = 30 * time.Second
+
+// RouteTimeouts はルートごとのリクエストの制限時間です
+type RouteTimeouts struct {
+	defaultTimeout time.Duration
+	routes         []routeTimeout // プレフィックスの長い順
+}
+
+// routeTimeout はルートのプレフィックスと制限時間
+type routeTimeout struct {
+	prefix  string
+	timeout time.Duration
+}
+
+// ParseRouteTimeouts は "/api/v1/tasks/export=5m,/api/v1/admin=1m" 形式の設定を解析します
+// プレフィックスはルートの定義（"/api/v1/tasks/:id" など）と比較し、0の場合はそのルートに制限時間を設定しません
+func ParseRouteTimeouts(defaultTimeout time.Duration, spec string) (*RouteTimeouts, error) {
+	timeouts := &RouteTimeouts{defaultTimeout: defaultTimeout}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, value, ok := strings.Cut(entry, "=")
+		prefix = strings.TrimSpace(prefix)
+		if !ok || !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("invalid route timeout entry: %q", entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("invalid route timeout for %s: %q", prefix, value)
+		}
+		timeouts.routes = append(timeouts.routes, routeTimeout{prefix: strings.TrimSuffix(prefix, "/"), timeout: timeout})
+	}
+	sort.SliceStable(timeouts.routes, func(i, j int) bool {
+		return len(timeouts.routes[i].prefix) > len(timeouts.routes[j].prefix)
+	})
+	return timeouts, nil
+}
+
+// For はルートの制限時間を返します（0の場合は制限しません）
+func (t *RouteTimeouts) For(route string) time.Duration {
+	for _, r := range t.routes {
+		if route == r.prefix || strings.HasPrefix(route, r.prefix+"/") {
+			return r.timeout
+		}
+	}
+	return t.defaultTimeout
+}
+
+// TimeoutMiddleware はリクエストのコンテキストにルートごとの期限を設定します
+// 期限を過ぎるとデータベース・外部サービスの呼び出しが中断され、まだ応答していない場合は504を返します
+// WebSocketの接続には設定しません
+func TimeoutMiddleware(timeouts *RouteTimeouts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := timeouts.For(c.FullPath())
+		if timeout <= 0 || c.IsWebsocket() {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			metrics.Counter(MetricRequestTimeouts).Add(1)
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
+				"error":   "REQUEST_TIMEOUT",
+				"message": "Request timed out",
+			})
+		}
+	}
+}
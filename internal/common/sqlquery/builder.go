@@ -0,0 +1,193 @@
+package sqlquery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrPlaceholderMismatch は条件のプレースホルダー（?）の数と引数の数が一致しない場合のエラー
+	ErrPlaceholderMismatch = errors.New("placeholder count does not match arguments")
+	// ErrInvalidSort は許可されていないソートフィールドが指定された場合のエラー
+	ErrInvalidSort = errors.New("invalid sort field")
+	// ErrInvalidPagination はページ番号・ページサイズが不正な場合のエラー
+	ErrInvalidPagination = errors.New("invalid pagination")
+)
+
+// Sort はソートに使えるフィールドの許可リストと既定値
+// Columns のキーはAPIで受け付けるフィールド名、値はSQLの列名（ORDER BY にそのまま埋め込むため、固定の値のみを使う）
+type Sort struct {
+	Columns          map[string]string
+	DefaultColumn    string
+	DefaultDirection string
+}
+
+// Builder は一覧・検索クエリのWHERE・ORDER BY・LIMIT句を組み立てる
+// 値はすべてプレースホルダーで渡し、SQLに埋め込むのは呼び出し側が固定で持つ列名・条件のみとする
+// 不正な条件が渡された場合は最初のエラーを保持し、Count・Select で返す
+type Builder struct {
+	conds   []string
+	args    []interface{}
+	orderBy string
+	limit   int
+	offset  int
+	paged   bool
+	err     error
+}
+
+// New は新しいBuilderを作成する
+func New() *Builder {
+	return &Builder{}
+}
+
+// Where は条件をANDで追加する。条件中の ? の数と args の数が一致しない場合はエラーを保持する
+func (b *Builder) Where(cond string, args ...interface{}) *Builder {
+	if n := strings.Count(cond, "?"); n != len(args) {
+		b.setErr(fmt.Errorf("%w: %q has %d placeholders, got %d args", ErrPlaceholderMismatch, cond, n, len(args)))
+		return b
+	}
+	b.conds = append(b.conds, cond)
+	b.args = append(b.args, args...)
+	return b
+}
+
+// WhereIn は column IN (?, ...) の条件を追加する。values が空の場合は何も一致しない条件になる
+func (b *Builder) WhereIn(column string, values ...interface{}) *Builder {
+	if len(values) == 0 {
+		return b.Where("1 = 0")
+	}
+	return b.Where(column+" IN ("+Placeholders(len(values))+")", values...)
+}
+
+// WhereLike は columns のいずれかが term を含む条件をORで追加する。term が空の場合は何も追加しない
+// term のワイルドカードはエスケープする（MySQLの既定のエスケープ文字 \ を使う）
+func (b *Builder) WhereLike(columns []string, term string) *Builder {
+	if term == "" || len(columns) == 0 {
+		return b
+	}
+	pattern := "%" + EscapeLike(term) + "%"
+	conds := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		conds[i] = column + " LIKE ?"
+		args[i] = pattern
+	}
+	return b.Where("("+strings.Join(conds, " OR ")+")", args...)
+}
+
+// OrderBy は許可リストに従ってソート順を設定する
+// field が空の場合は既定の列を使い、許可リストにない場合はエラーを保持する。direction は ASC・DESC 以外なら既定値を使う
+func (b *Builder) OrderBy(sort Sort, field, direction string) *Builder {
+	column := sort.DefaultColumn
+	if field != "" {
+		var ok bool
+		if column, ok = sort.Columns[field]; !ok {
+			b.setErr(fmt.Errorf("%w: %s", ErrInvalidSort, field))
+			return b
+		}
+	}
+
+	direction = strings.ToUpper(direction)
+	if direction != "ASC" && direction != "DESC" {
+		direction = sort.DefaultDirection
+	}
+	if direction == "" {
+		direction = "ASC"
+	}
+
+	b.orderBy = column + " " + direction
+	return b
+}
+
+// OrderByFixed は固定のソート順を設定する（ユーザーの入力を含めないこと）
+func (b *Builder) OrderByFixed(orderBy string) *Builder {
+	b.orderBy = orderBy
+	return b
+}
+
+// Paginate はページ番号（1始まり）とページサイズからLIMIT・OFFSETを設定する
+func (b *Builder) Paginate(page, pageSize int) *Builder {
+	if page <= 0 || pageSize <= 0 {
+		b.setErr(fmt.Errorf("%w: page=%d pageSize=%d", ErrInvalidPagination, page, pageSize))
+		return b
+	}
+	b.limit = pageSize
+	b.offset = (page - 1) * pageSize
+	b.paged = true
+	return b
+}
+
+// Err は組み立て中に発生した最初のエラーを返す
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// WhereClause は "WHERE ..." 句（条件がない場合は空文字）とその引数を返す
+func (b *Builder) WhereClause() (string, []interface{}) {
+	args := append([]interface{}(nil), b.args...)
+	if len(b.conds) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(b.conds, " AND "), args
+}
+
+// Count は from を対象に条件に一致する件数を数えるクエリを返す（ORDER BY・LIMITは含めない）
+func (b *Builder) Count(from string) (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	where, args := b.WhereClause()
+	return join("SELECT COUNT(*) FROM "+from, where), args, nil
+}
+
+// Select は from から columns を選択するクエリを返す
+func (b *Builder) Select(columns, from string) (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	where, args := b.WhereClause()
+	parts := []string{"SELECT " + columns + " FROM " + from, where}
+	if b.orderBy != "" {
+		parts = append(parts, "ORDER BY "+b.orderBy)
+	}
+	if b.paged {
+		parts = append(parts, "LIMIT ? OFFSET ?")
+		args = append(args, b.limit, b.offset)
+	}
+	return join(parts...), args, nil
+}
+
+// setErr は最初のエラーのみを保持する
+func (b *Builder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// join は空でない句を空白で連結する
+func join(parts ...string) string {
+	nonEmpty := parts[:0:0]
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+// Placeholders は n 個のプレースホルダーをカンマ区切りで返す
+func Placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?, ", n-1) + "?"
+}
+
+// EscapeLike はLIKE演算子のワイルドカード（% と _）とエスケープ文字をエスケープする
+func EscapeLike(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "%", "\\%")
+	s = strings.ReplaceAll(s, "_", "\\_")
+	return s
+}
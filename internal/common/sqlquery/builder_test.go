@@ -0,0 +1,154 @@
+package sqlquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSort = Sort{
+	Columns:          map[string]string{"created_at": "created_at", "title": "title"},
+	DefaultColumn:    "created_at",
+	DefaultDirection: "DESC",
+}
+
+func TestBuilder_Select(t *testing.T) {
+	t.Run("no conditions", func(t *testing.T) {
+		query, args, err := New().Select("id", "tasks")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM tasks", query)
+		assert.Empty(t, args)
+	})
+
+	t.Run("conditions, sort and pagination", func(t *testing.T) {
+		query, args, err := New().
+			Where("status = ?", "TODO").
+			Where("due_date BETWEEN ? AND ?", 1, 2).
+			OrderBy(testSort, "title", "asc").
+			Paginate(3, 20).
+			Select("id, title", "tasks")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id, title FROM tasks WHERE status = ? AND due_date BETWEEN ? AND ? ORDER BY title ASC LIMIT ? OFFSET ?", query)
+		assert.Equal(t, []interface{}{"TODO", 1, 2, 20, 40}, args)
+	})
+
+	t.Run("count omits order and limit", func(t *testing.T) {
+		builder := New().Where("status = ?", "TODO").OrderBy(testSort, "", "").Paginate(1, 10)
+
+		query, args, err := builder.Count("tasks")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT COUNT(*) FROM tasks WHERE status = ?", query)
+		assert.Equal(t, []interface{}{"TODO"}, args)
+	})
+
+	t.Run("count and select do not share args", func(t *testing.T) {
+		builder := New().Where("status = ?", "TODO").Paginate(1, 10)
+
+		_, countArgs, _ := builder.Count("tasks")
+		_, selectArgs, _ := builder.Select("id", "tasks")
+
+		assert.Equal(t, []interface{}{"TODO"}, countArgs)
+		assert.Equal(t, []interface{}{"TODO", 10, 0}, selectArgs)
+	})
+}
+
+func TestBuilder_Where(t *testing.T) {
+	t.Run("rejects placeholder mismatch", func(t *testing.T) {
+		_, _, err := New().Where("status = ? AND priority = ?", "TODO").Select("id", "tasks")
+
+		assert.ErrorIs(t, err, ErrPlaceholderMismatch)
+	})
+
+	t.Run("keeps the first error", func(t *testing.T) {
+		builder := New().Where("a = ?").OrderBy(testSort, "unknown", "")
+
+		assert.ErrorIs(t, builder.Err(), ErrPlaceholderMismatch)
+	})
+
+	t.Run("in", func(t *testing.T) {
+		query, args, err := New().WhereIn("id", "a", "b", "c").Select("id", "tasks")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM tasks WHERE id IN (?, ?, ?)", query)
+		assert.Equal(t, []interface{}{"a", "b", "c"}, args)
+	})
+
+	t.Run("empty in matches nothing", func(t *testing.T) {
+		query, args, err := New().WhereIn("id").Select("id", "tasks")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM tasks WHERE 1 = 0", query)
+		assert.Empty(t, args)
+	})
+}
+
+func TestBuilder_WhereLike(t *testing.T) {
+	t.Run("escapes wildcards", func(t *testing.T) {
+		query, args, err := New().WhereLike([]string{"name", "description"}, `50%_off\`).Select("id", "groups")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM groups WHERE (name LIKE ? OR description LIKE ?)", query)
+		assert.Equal(t, []interface{}{`%50\%\_off\\%`, `%50\%\_off\\%`}, args)
+	})
+
+	t.Run("empty term adds nothing", func(t *testing.T) {
+		query, args, err := New().WhereLike([]string{"name"}, "").Select("id", "groups")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM groups", query)
+		assert.Empty(t, args)
+	})
+}
+
+func TestBuilder_OrderBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     string
+		direction string
+		want      string
+		wantErr   bool
+	}{
+		{name: "defaults", want: "created_at DESC"},
+		{name: "allowed field", field: "title", direction: "ASC", want: "title ASC"},
+		{name: "lowercase direction", field: "title", direction: "desc", want: "title DESC"},
+		{name: "invalid direction falls back", field: "title", direction: "; DROP TABLE tasks", want: "title DESC"},
+		{name: "unknown field", field: "password", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := New().OrderBy(testSort, tt.field, tt.direction).Select("id", "tasks")
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSort)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "SELECT id FROM tasks ORDER BY "+tt.want, query)
+		})
+	}
+}
+
+func TestBuilder_Paginate(t *testing.T) {
+	t.Run("invalid page", func(t *testing.T) {
+		_, _, err := New().Paginate(0, 10).Select("id", "tasks")
+
+		assert.ErrorIs(t, err, ErrInvalidPagination)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		_, _, err := New().Paginate(1, 0).Count("tasks")
+
+		assert.ErrorIs(t, err, ErrInvalidPagination)
+	})
+}
+
+func TestPlaceholders(t *testing.T) {
+	assert.Equal(t, "", Placeholders(0))
+	assert.Equal(t, "?", Placeholders(1))
+	assert.Equal(t, "?, ?, ?", Placeholders(3))
+}
@@ -0,0 +1,238 @@
+package sqlquery
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedQuery は他の方言に変換できないクエリの場合のエラー
+var ErrUnsupportedQuery = errors.New("query cannot be translated")
+
+// Dialect はSQLの方言
+// リポジトリのクエリはMySQLの方言で書き、他のデータベースでは Translator で変換して実行する
+type Dialect string
+
+const (
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// mysqlSchema はリポジトリのクエリがテーブル名に付けるMySQLのデータベース名
+const mysqlSchema = "`Yotei-Plus`."
+
+// Translator はMySQLの方言で書かれたクエリを指定した方言に変換する
+// PostgreSQLでは次の書き換えを行う（文字列リテラルの中は変更しない）
+//   - プレースホルダー ? を $1, $2, ... にする
+//   - バッククォートで囲んだ識別子をダブルクォートで囲む
+//   - INSERT IGNORE を ON CONFLICT DO NOTHING にする
+//   - ON DUPLICATE KEY UPDATE col = VALUES(col) を ON CONFLICT (キー) DO UPDATE SET col = EXCLUDED.col にする
+//   - LIKE を ILIKE にする（MySQLの照合順序と同じく大文字・小文字を区別しない）
+//
+// SQLiteでは次の書き換えを行う（プレースホルダーとバッククォートはそのまま使える）
+//   - テーブル名の `Yotei-Plus`. を取り除く（データベースファイルが1つのため）
+//   - INSERT IGNORE を INSERT OR IGNORE にする
+//   - ON DUPLICATE KEY UPDATE col = VALUES(col) を ON CONFLICT (キー) DO UPDATE SET col = excluded.col にする
+//   - NOW() を現在時刻（UTC）、GREATEST・LEAST を MAX・MIN にする
+//   - LIKE ? に ESCAPE '\' を付ける（MySQLと同じくバックスラッシュでワイルドカードをエスケープする）
+type Translator struct {
+	dialect Dialect
+	// conflictKeys はテーブルごとの ON CONFLICT の対象列（MySQLの ON DUPLICATE KEY UPDATE が使う一意キー）
+	conflictKeys map[string][]string
+}
+
+// NewTranslator は新しいTranslatorを作成する
+// conflictKeys は ON DUPLICATE KEY UPDATE を使うテーブルごとの一意キーの列（スキーマ名を含まないテーブル名がキー）
+func NewTranslator(dialect Dialect, conflictKeys map[string][]string) *Translator {
+	return &Translator{dialect: dialect, conflictKeys: conflictKeys}
+}
+
+// Dialect は変換先の方言を返す
+func (t *Translator) Dialect() Dialect {
+	return t.dialect
+}
+
+var (
+	insertIgnorePattern = regexp.MustCompile(`(?i)\bINSERT\s+IGNORE\s+INTO\b`)
+	onDuplicatePattern  = regexp.MustCompile(`(?i)\bON\s+DUPLICATE\s+KEY\s+UPDATE\b`)
+	valuesFuncPattern   = regexp.MustCompile(`(?i)\bVALUES\s*\(\s*(\w+)\s*\)`)
+	insertTablePattern  = regexp.MustCompile(`(?i)\bINSERT\s+INTO\s+(?:"[^"]+"\.|\w+\.)?"?(\w+)"?`)
+	likePattern         = regexp.MustCompile(`(?i)\bLIKE\b`)
+	likeParamPattern    = regexp.MustCompile(`(?i)\bLIKE\s+\?`)
+	escapePattern       = regexp.MustCompile(`(?i)^\s*ESCAPE\b`)
+	nowPattern          = regexp.MustCompile(`(?i)\bNOW\(\s*\)`)
+	greatestPattern     = regexp.MustCompile(`(?i)\bGREATEST\(`)
+	leastPattern        = regexp.MustCompile(`(?i)\bLEAST\(`)
+)
+
+// sqliteNow はSQLiteで NOW() の代わりに使う現在時刻（ドライバーが time.Time を書き込む形式に合わせてUTCで比較できるようにする）
+const sqliteNow = "strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')"
+
+// Translate はクエリを変換する。MySQLの場合はそのまま返す
+func (t *Translator) Translate(query string) (string, error) {
+	if t == nil || t.dialect == DialectMySQL {
+		return query, nil
+	}
+	switch t.dialect {
+	case DialectPostgres:
+		return t.translatePostgres(query)
+	case DialectSQLite:
+		return t.translateSQLite(query)
+	default:
+		return "", fmt.Errorf("%w: unknown dialect %s", ErrUnsupportedQuery, t.dialect)
+	}
+}
+
+// translatePostgres はクエリをPostgreSQLの方言に変換する
+func (t *Translator) translatePostgres(query string) (string, error) {
+	code, literals := maskLiterals(query)
+
+	code = strings.ReplaceAll(code, "`", `"`)
+
+	ignore := insertIgnorePattern.MatchString(code)
+	if ignore {
+		code = insertIgnorePattern.ReplaceAllString(code, "INSERT INTO")
+	}
+
+	code, err := t.rewriteUpsert(code, "EXCLUDED")
+	if err != nil {
+		return "", err
+	}
+
+	code = likePattern.ReplaceAllString(code, "ILIKE")
+
+	if ignore {
+		code = strings.TrimRight(code, " \t\r\n;") + " ON CONFLICT DO NOTHING"
+	}
+
+	return unmaskLiterals(rebind(code), literals), nil
+}
+
+// translateSQLite はクエリをSQLiteの方言に変換する
+func (t *Translator) translateSQLite(query string) (string, error) {
+	code, literals := maskLiterals(query)
+
+	code = strings.ReplaceAll(code, mysqlSchema, "")
+	code = insertIgnorePattern.ReplaceAllString(code, "INSERT OR IGNORE INTO")
+
+	code, err := t.rewriteUpsert(code, "excluded")
+	if err != nil {
+		return "", err
+	}
+
+	code = nowPattern.ReplaceAllString(code, sqliteNow)
+	code = greatestPattern.ReplaceAllString(code, "MAX(")
+	code = leastPattern.ReplaceAllString(code, "MIN(")
+	code = addLikeEscape(code)
+
+	return unmaskLiterals(code, literals), nil
+}
+
+// rewriteUpsert は ON DUPLICATE KEY UPDATE を ON CONFLICT (キー) DO UPDATE SET に書き換える
+// 更新する値の VALUES(col) は excluded（挿入しようとした行）の列にする
+func (t *Translator) rewriteUpsert(code, excluded string) (string, error) {
+	loc := onDuplicatePattern.FindStringIndex(code)
+	if loc == nil {
+		return code, nil
+	}
+	match := insertTablePattern.FindStringSubmatch(code)
+	if match == nil {
+		return "", fmt.Errorf("%w: ON DUPLICATE KEY UPDATE without INSERT INTO", ErrUnsupportedQuery)
+	}
+	keys, ok := t.conflictKeys[match[1]]
+	if !ok {
+		return "", fmt.Errorf("%w: no conflict key for table %s", ErrUnsupportedQuery, match[1])
+	}
+	update := valuesFuncPattern.ReplaceAllString(code[loc[1]:], excluded+".$1")
+	return code[:loc[0]] + "ON CONFLICT (" + strings.Join(keys, ", ") + ") DO UPDATE SET" + update, nil
+}
+
+// addLikeEscape は ESCAPE を指定していない LIKE ? にバックスラッシュのエスケープ文字を指定する
+// SQLiteの LIKE は既定ではエスケープ文字がないため
+func addLikeEscape(code string) string {
+	matches := likeParamPattern.FindAllStringIndex(code, -1)
+	if matches == nil {
+		return code
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(code[last:m[1]])
+		if !escapePattern.MatchString(code[m[1]:]) {
+			b.WriteString(` ESCAPE '\'`)
+		}
+		last = m[1]
+	}
+	b.WriteString(code[last:])
+	return b.String()
+}
+
+// literalMarker は文字列リテラルを退避した位置を表す（クエリ中に現れない制御文字で囲む）
+const literalMarker = "\x00"
+
+// maskLiterals は文字列リテラルを退避し、マーカーに置き換えたクエリと退避したリテラルを返す
+// リテラル中の ? や LIKE などを書き換えないようにするため
+func maskLiterals(query string) (string, []string) {
+	var (
+		b        strings.Builder
+		literals []string
+	)
+	for i := 0; i < len(query); i++ {
+		if query[i] != '\'' {
+			b.WriteByte(query[i])
+			continue
+		}
+		// '' とバックスラッシュによるエスケープを含めてリテラルの終わりを探す
+		end := i + 1
+		for end < len(query) {
+			if query[end] == '\\' {
+				end += 2
+				continue
+			}
+			if query[end] == '\'' {
+				if end+1 < len(query) && query[end+1] == '\'' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		if end >= len(query) {
+			end = len(query) - 1
+		}
+		b.WriteString(literalMarker + strconv.Itoa(len(literals)) + literalMarker)
+		literals = append(literals, query[i:end+1])
+		i = end
+	}
+	return b.String(), literals
+}
+
+// unmaskLiterals は退避した文字列リテラルを元の位置に戻す
+func unmaskLiterals(code string, literals []string) string {
+	for i, literal := range literals {
+		code = strings.Replace(code, literalMarker+strconv.Itoa(i)+literalMarker, literal, 1)
+	}
+	return code
+}
+
+// rebind はプレースホルダー ? を $1, $2, ... に置き換える
+func rebind(code string) string {
+	if !strings.Contains(code, "?") {
+		return code
+	}
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(code); i++ {
+		if code[i] == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(code[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+package sqlquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConflictKeys = map[string][]string{
+	"feature_flags":  {"flag_key"},
+	"task_assignees": {"task_id", "user_id"},
+}
+
+func TestTranslator_MySQL(t *testing.T) {
+	query := "INSERT IGNORE INTO `Yotei-Plus`.tasks (id) VALUES (?)"
+
+	got, err := NewTranslator(DialectMySQL, nil).Translate(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, query, got)
+}
+
+func TestTranslator_Postgres(t *testing.T) {
+	translator := NewTranslator(DialectPostgres, testConflictKeys)
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "placeholders",
+			query: "SELECT id FROM tasks WHERE status = ? AND due_date BETWEEN ? AND ? LIMIT ? OFFSET ?",
+			want:  "SELECT id FROM tasks WHERE status = $1 AND due_date BETWEEN $2 AND $3 LIMIT $4 OFFSET $5",
+		},
+		{
+			name:  "backtick identifiers",
+			query: "SELECT COUNT(*) FROM `Yotei-Plus`.`groups` WHERE id = ?",
+			want:  `SELECT COUNT(*) FROM "Yotei-Plus"."groups" WHERE id = $1`,
+		},
+		{
+			name:  "literals are not rewritten",
+			query: "SELECT id FROM tasks WHERE title = 'what? LIKE `this`' AND note = 'it''s ?' AND id = ?",
+			want:  "SELECT id FROM tasks WHERE title = 'what? LIKE `this`' AND note = 'it''s ?' AND id = $1",
+		},
+		{
+			name:  "like is case insensitive",
+			query: "SELECT id FROM users WHERE username LIKE ? OR email NOT LIKE ?",
+			want:  "SELECT id FROM users WHERE username ILIKE $1 OR email NOT ILIKE $2",
+		},
+		{
+			name:  "insert ignore",
+			query: "INSERT IGNORE INTO `Yotei-Plus`.task_dependencies (task_id, depends_on_task_id) VALUES (?, ?)\n\t",
+			want:  `INSERT INTO "Yotei-Plus".task_dependencies (task_id, depends_on_task_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		},
+		{
+			name: "on duplicate key update",
+			query: `INSERT INTO feature_flags (flag_key, enabled, updated_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			enabled = VALUES(enabled),
+			updated_at = VALUES(updated_at)`,
+			want: `INSERT INTO feature_flags (flag_key, enabled, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (flag_key) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = EXCLUDED.updated_at`,
+		},
+		{
+			name:  "on duplicate key update with schema and composite key",
+			query: "INSERT INTO `Yotei-Plus`.task_assignees (task_id, user_id, completed_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE completed_at = VALUES(completed_at)",
+			want:  `INSERT INTO "Yotei-Plus".task_assignees (task_id, user_id, completed_at) VALUES ($1, $2, $3) ON CONFLICT (task_id, user_id) DO UPDATE SET completed_at = EXCLUDED.completed_at`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := translator.Translate(tt.query)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTranslator_PostgresUnknownConflictKey(t *testing.T) {
+	translator := NewTranslator(DialectPostgres, testConflictKeys)
+
+	_, err := translator.Translate("INSERT INTO daily_stats (user_id) VALUES (?) ON DUPLICATE KEY UPDATE user_id = VALUES(user_id)")
+
+	assert.ErrorIs(t, err, ErrUnsupportedQuery)
+}
+
+func TestTranslator_SQLite(t *testing.T) {
+	translator := NewTranslator(DialectSQLite, testConflictKeys)
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "schema qualifier",
+			query: "SELECT id FROM `Yotei-Plus`.tasks WHERE id = ? AND note = '`Yotei-Plus`.tasks'",
+			want:  "SELECT id FROM tasks WHERE id = ? AND note = '`Yotei-Plus`.tasks'",
+		},
+		{
+			name:  "insert ignore",
+			query: "INSERT IGNORE INTO `Yotei-Plus`.task_dependencies (task_id, depends_on_task_id) VALUES (?, ?)",
+			want:  "INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_task_id) VALUES (?, ?)",
+		},
+		{
+			name:  "on duplicate key update",
+			query: "INSERT INTO `Yotei-Plus`.task_assignees (task_id, user_id, completed_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE completed_at = VALUES(completed_at)",
+			want:  "INSERT INTO task_assignees (task_id, user_id, completed_at) VALUES (?, ?, ?) ON CONFLICT (task_id, user_id) DO UPDATE SET completed_at = excluded.completed_at",
+		},
+		{
+			name:  "functions",
+			query: "UPDATE sync_sequence SET pruned_through = GREATEST(pruned_through, ?), note = LEAST(a, b) WHERE expires_at < NOW()",
+			want:  "UPDATE sync_sequence SET pruned_through = MAX(pruned_through, ?), note = MIN(a, b) WHERE expires_at < " + sqliteNow,
+		},
+		{
+			name:  "like escapes with backslash",
+			query: "SELECT id FROM users WHERE username LIKE ? OR email LIKE ? ESCAPE '!' OR name LIKE 'a?'",
+			want:  `SELECT id FROM users WHERE username LIKE ? ESCAPE '\' OR email LIKE ? ESCAPE '!' OR name LIKE 'a?'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := translator.Translate(tt.query)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package sqlquery
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// DefaultStmtCacheSize はStmtCacheが保持するプリペアドステートメントの既定の上限
+const DefaultStmtCacheSize = 256
+
+// StmtCache はSQL文ごとにプリペアドステートメントを保持して使い回す
+// Builder で組み立てたクエリはフィルタの組み合わせごとに同じSQL文になるため、2回目以降は準備を省略できる
+// 上限を超えた場合は最も長く使われていないステートメントを閉じる（実行中のステートメントは実行を終えてから閉じる）
+type StmtCache struct {
+	db    *sql.DB
+	size  int
+	mu    sync.Mutex
+	stmts map[string]*list.Element
+	lru   *list.List
+}
+
+// cachedStmt はLRUのリストの要素
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int  // 実行中の呼び出しの数
+	evicted bool // キャッシュから外れ、実行中の呼び出しが終わったら閉じる
+}
+
+// NewStmtCache は新しいStmtCacheを作成する（size が0以下の場合は既定の上限を使う）
+func NewStmtCache(db *sql.DB, size int) *StmtCache {
+	if size <= 0 {
+		size = DefaultStmtCacheSize
+	}
+	return &StmtCache{
+		db:    db,
+		size:  size,
+		stmts: make(map[string]*list.Element),
+		lru:   list.New(),
+	}
+}
+
+// QueryContext はキャッシュしたステートメントでクエリを実行する
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	cs, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(cs)
+	return cs.stmt.QueryContext(ctx, args...)
+}
+
+// QueryRowContext はキャッシュしたステートメントで1行を取得する
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	cs, err := c.acquire(ctx, query)
+	if err != nil {
+		// 準備に失敗した場合はエラーをScanで返すため、そのまま実行する
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+	defer c.release(cs)
+	return cs.stmt.QueryRowContext(ctx, args...)
+}
+
+// ExecContext はキャッシュしたステートメントで文を実行する
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	cs, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(cs)
+	return cs.stmt.ExecContext(ctx, args...)
+}
+
+// Len はキャッシュしているステートメントの数を返す
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Close はキャッシュしているすべてのステートメントを閉じる（DBの接続は閉じない）
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		cs := e.Value.(*cachedStmt)
+		cs.evicted = true
+		if cs.refs > 0 {
+			continue
+		}
+		if err := cs.stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	c.stmts = make(map[string]*list.Element)
+	c.lru.Init()
+	return firstErr
+}
+
+// acquire はキャッシュからステートメントを取得し、なければ準備して追加する
+// 取得したステートメントは実行後に release で返す
+func (c *StmtCache) acquire(ctx context.Context, query string) (*cachedStmt, error) {
+	c.mu.Lock()
+	if e, ok := c.stmts[query]; ok {
+		c.lru.MoveToFront(e)
+		cs := e.Value.(*cachedStmt)
+		cs.refs++
+		c.mu.Unlock()
+		return cs, nil
+	}
+	c.mu.Unlock()
+
+	// 準備はDBへの往復を伴うため、ロックの外で行う
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 同時に同じ文を準備した場合は先に登録された方を使う
+	if e, ok := c.stmts[query]; ok {
+		_ = stmt.Close()
+		c.lru.MoveToFront(e)
+		cs := e.Value.(*cachedStmt)
+		cs.refs++
+		return cs, nil
+	}
+
+	cs := &cachedStmt{query: query, stmt: stmt, refs: 1}
+	c.stmts[query] = c.lru.PushFront(cs)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		evicted := oldest.Value.(*cachedStmt)
+		delete(c.stmts, evicted.query)
+		evicted.evicted = true
+		if evicted.refs == 0 {
+			_ = evicted.stmt.Close()
+		}
+	}
+	return cs, nil
+}
+
+// release は acquire で取得したステートメントを返し、キャッシュから外れていれば閉じる
+// 取得済みの sql.Rows は閉じたステートメントでも読み終えるまで使える
+func (c *StmtCache) release(cs *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cs.refs--
+	if cs.evicted && cs.refs == 0 {
+		_ = cs.stmt.Close()
+	}
+}
@@ -0,0 +1,121 @@
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDriver はステートメントの準備・クローズの回数を数えるテスト用のドライバー
+type countingDriver struct {
+	mu       sync.Mutex
+	prepared map[string]int
+	closed   int
+}
+
+func (d *countingDriver) Open(string) (driver.Conn, error) { return &countingConn{d: d}, nil }
+
+type countingConn struct{ d *countingDriver }
+
+func (c *countingConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.prepared[query]++
+	return &countingStmt{d: c.d}, nil
+}
+func (c *countingConn) Close() error              { return nil }
+func (c *countingConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type countingStmt struct{ d *countingDriver }
+
+func (s *countingStmt) Close() error {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.closed++
+	return nil
+}
+func (s *countingStmt) NumInput() int { return -1 }
+func (s *countingStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (s *countingStmt) Query([]driver.Value) (driver.Rows, error) { return &emptyRows{}, nil }
+
+type emptyRows struct{}
+
+func (r *emptyRows) Columns() []string         { return []string{"n"} }
+func (r *emptyRows) Close() error              { return nil }
+func (r *emptyRows) Next([]driver.Value) error { return io.EOF }
+
+// Connect・Driver により countingDriver を driver.Connector として使う
+func (d *countingDriver) Connect(context.Context) (driver.Conn, error) { return d.Open("") }
+func (d *countingDriver) Driver() driver.Driver                        { return d }
+
+// newCountingDB はテスト用のドライバーを使うDBを作成する
+func newCountingDB(t *testing.T) (*sql.DB, *countingDriver) {
+	d := &countingDriver{prepared: make(map[string]int)}
+	db := sql.OpenDB(d)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, d
+}
+
+func TestStmtCache_ReusesStatements(t *testing.T) {
+	db, d := newCountingDB(t)
+	cache := NewStmtCache(db, 10)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		rows, err := cache.QueryContext(ctx, "SELECT n FROM t WHERE a = ?", i)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+	}
+	_, err := cache.ExecContext(ctx, "UPDATE t SET a = ?", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, d.prepared["SELECT n FROM t WHERE a = ?"])
+	assert.Equal(t, 1, d.prepared["UPDATE t SET a = ?"])
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestStmtCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	db, d := newCountingDB(t)
+	cache := NewStmtCache(db, 2)
+	ctx := context.Background()
+
+	query := func(q string) {
+		rows, err := cache.QueryContext(ctx, q)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+	}
+
+	query("SELECT 1")
+	query("SELECT 2")
+	query("SELECT 1")
+	query("SELECT 3") // SELECT 2 が追い出される
+	query("SELECT 1")
+	query("SELECT 2")
+
+	assert.Equal(t, 1, d.prepared["SELECT 1"])
+	assert.Equal(t, 2, d.prepared["SELECT 2"])
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestStmtCache_Close(t *testing.T) {
+	db, d := newCountingDB(t)
+	cache := NewStmtCache(db, 0)
+	ctx := context.Background()
+
+	_, err := cache.ExecContext(ctx, "UPDATE t SET a = 1")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Close())
+
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, 1, d.closed)
+}
@@ -0,0 +1,81 @@
+package undo
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+)
+
+// Controller は取り消しのHTTPリクエストを処理するコントローラー
+type Controller struct {
+	queue *Queue
+}
+
+// NewController は新しいControllerを作成する
+func NewController(queue *Queue) *Controller {
+	return &Controller{
+		queue: queue,
+	}
+}
+
+// UndoResponse は取り消しのレスポンス
+type UndoResponse struct {
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message" example:"Operation undone successfully"`
+	Action  string `json:"action" example:"task.delete"`
+} // @name UndoResponse
+
+// ErrorResponse は取り消しのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"UNDO_EXPIRED"`
+	Message string `json:"message" example:"undo token not found or expired"`
+} // @name UndoErrorResponse
+
+// Undo 操作の取り消し
+// @Summary      操作の取り消し
+// @Description  タスク削除・友達削除・グループメンバー削除で返された取り消し用のトークンを送ると、取り消し期間中であれば操作を取り消します（操作したユーザーのみ）
+// @Tags         undo
+// @Accept       json
+// @Produce      json
+// @Param        token path string true "取り消し用のトークン"
+// @Security     BearerAuth
+// @Success      200 {object} UndoResponse "取り消し成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "トークンが見つからない・取り消し期間が過ぎた"
+// @Router       /undo/{token} [post]
+func (c *Controller) Undo(ctx *gin.Context) {
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	action, err := c.queue.Undo(user.ID.String(), ctx.Param("token"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "INTERNAL_ERROR"
+		if errors.Is(err, ErrTokenNotFound) {
+			status = http.StatusNotFound
+			code = "UNDO_EXPIRED"
+		}
+		ctx.JSON(status, ErrorResponse{
+			Success: false,
+			Error:   code,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, UndoResponse{
+		Success: true,
+		Message: "Operation undone successfully",
+		Action:  action,
+	})
+}
@@ -0,0 +1,147 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DefaultWindow は取り消し可能な時間の既定値
+const DefaultWindow = 10 * time.Second
+
+// 取り消し可能な操作の種類
+const (
+	ActionDeleteTask   = "task.delete"
+	ActionRemoveFriend = "friend.remove"
+	ActionRemoveMember = "group_member.remove"
+)
+
+// ErrTokenNotFound はトークンが存在しない・期限切れ・他のユーザーのものである場合のエラー
+var ErrTokenNotFound = errors.New("undo token not found or expired")
+
+// Token は取り消し用のトークンを表す
+type Token struct {
+	Token     string    `json:"undo_token" example:"0f8fad5b-d9cb-469f-a165-70867728950e"`
+	Action    string    `json:"action" example:"task.delete"`
+	ExpiresAt time.Time `json:"undo_expires_at"`
+} // @name UndoToken
+
+// Scheduler は取り消し可能な操作を遅らせて実行するインターフェース（各モジュールのサービスが使用する）
+type Scheduler interface {
+	Defer(ownerID, action string, run func(ctx context.Context) error) *Token
+}
+
+// job は取り消し期間中の操作
+type job struct {
+	ownerID string
+	action  string
+	run     func(ctx context.Context) error
+	timer   *time.Timer
+}
+
+// Queue は取り消し可能な操作を取り消し期間が過ぎるまで遅らせて実行するジョブキュー
+// 取り消された操作は実行されないため、取り消し後は操作前の状態のまま残る
+type Queue struct {
+	window time.Duration
+	logger logger.Logger
+
+	mu      sync.Mutex
+	pending map[string]*job
+	running sync.WaitGroup
+}
+
+// NewQueue は新しいQueueを作成する
+func NewQueue(window time.Duration, logger logger.Logger) *Queue {
+	return &Queue{
+		window:  window,
+		logger:  logger,
+		pending: make(map[string]*job),
+	}
+}
+
+// Window は取り消し可能な時間を返す
+func (q *Queue) Window() time.Duration {
+	return q.window
+}
+
+// Defer は操作を取り消し期間の経過後に実行するよう登録し、取り消し用のトークンを返す
+// 操作はリクエストのcontextが終了した後に実行されるため、runにはバックグラウンドのcontextが渡される
+func (q *Queue) Defer(ownerID, action string, run func(ctx context.Context) error) *Token {
+	token := uuid.New().String()
+	j := &job{
+		ownerID: ownerID,
+		action:  action,
+		run:     run,
+	}
+
+	q.mu.Lock()
+	q.pending[token] = j
+	j.timer = time.AfterFunc(q.window, func() { q.execute(token) })
+	q.mu.Unlock()
+
+	return &Token{
+		Token:     token,
+		Action:    action,
+		ExpiresAt: time.Now().Add(q.window),
+	}
+}
+
+// Undo は取り消し期間中の操作を取り消し、操作の種類を返す
+// 操作を登録したユーザー以外は取り消せない
+func (q *Queue) Undo(ownerID, token string) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j, ok := q.pending[token]
+	if !ok || j.ownerID != ownerID {
+		return "", ErrTokenNotFound
+	}
+	j.timer.Stop()
+	delete(q.pending, token)
+	return j.action, nil
+}
+
+// Flush は取り消し期間を待たずに保留中の操作をすべて実行する（シャットダウン時に使用）
+func (q *Queue) Flush() {
+	q.mu.Lock()
+	tokens := make([]string, 0, len(q.pending))
+	for token, j := range q.pending {
+		j.timer.Stop()
+		tokens = append(tokens, token)
+	}
+	q.mu.Unlock()
+
+	for _, token := range tokens {
+		q.execute(token)
+	}
+	q.running.Wait()
+}
+
+// execute は保留中の操作を取り出して実行する（取り消し済みの場合は何もしない）
+func (q *Queue) execute(token string) {
+	q.mu.Lock()
+	j, ok := q.pending[token]
+	if ok {
+		delete(q.pending, token)
+		q.running.Add(1)
+	}
+	q.mu.Unlock()
+	if !ok {
+		return
+	}
+	defer q.running.Done()
+	// 操作のパニックで他の保留中の操作・シャットダウンが止まらないよう回復して報告する
+	defer errtrack.Recover("undo-queue")
+
+	if err := j.run(context.Background()); err != nil {
+		q.logger.Error("Deferred operation failed",
+			logger.String("action", j.action),
+			logger.String("ownerID", j.ownerID),
+			logger.Error(err))
+	}
+}
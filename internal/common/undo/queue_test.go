@@ -0,0 +1,77 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQueue(window time.Duration) *Queue {
+	return NewQueue(window, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+}
+
+func TestQueue_RunsAfterWindow(t *testing.T) {
+	queue := newTestQueue(20 * time.Millisecond)
+	done := make(chan struct{})
+
+	token := queue.Defer("user-1", ActionDeleteTask, func(ctx context.Context) error {
+		close(done)
+		return nil
+	})
+
+	assert.Equal(t, ActionDeleteTask, token.Action)
+	assert.WithinDuration(t, time.Now().Add(20*time.Millisecond), token.ExpiresAt, 10*time.Millisecond)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deferred operation was not executed")
+	}
+
+	_, err := queue.Undo("user-1", token.Token)
+	assert.ErrorIs(t, err, ErrTokenNotFound, "executed operations can no longer be undone")
+}
+
+func TestQueue_Undo(t *testing.T) {
+	queue := newTestQueue(time.Hour)
+	var runs int32
+
+	token := queue.Defer("user-1", ActionRemoveFriend, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	_, err := queue.Undo("user-2", token.Token)
+	assert.ErrorIs(t, err, ErrTokenNotFound, "only the owner can undo")
+
+	action, err := queue.Undo("user-1", token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, ActionRemoveFriend, action)
+
+	_, err = queue.Undo("user-1", token.Token)
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	queue.Flush()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&runs), "undone operations are never executed")
+}
+
+func TestQueue_Flush(t *testing.T) {
+	queue := newTestQueue(time.Hour)
+	var runs int32
+
+	for i := 0; i < 3; i++ {
+		queue.Defer("user-1", ActionRemoveMember, func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return errors.New("failures are only logged")
+		})
+	}
+
+	queue.Flush()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&runs))
+}
@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+)
+
+const (
+	// DefaultUserInfoCacheTTL はユーザー情報をキャッシュするデフォルトの期間
+	DefaultUserInfoCacheTTL = 30 * time.Second
+
+	// maxCachedUsers はキャッシュするユーザー数の上限
+	maxCachedUsers = 10000
+)
+
+// CachedUserValidator はユーザー情報を短時間キャッシュするUserValidator
+// グループのメンバー一覧や友達一覧の表示のたびに同じユーザーの情報を取得しないようにする
+// 存在しないユーザーはキャッシュしない（登録直後のユーザーがTTLの間見つからないことを防ぐ）
+type CachedUserValidator struct {
+	next commonDomain.UserValidator
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]cachedUserInfo
+}
+
+type cachedUserInfo struct {
+	info      *commonDomain.UserInfo
+	expiresAt time.Time
+}
+
+// NewCachedUserValidator は新しいCachedUserValidatorを作成する
+// ttlが0以下の場合はキャッシュせずにnextをそのまま返す
+func NewCachedUserValidator(next commonDomain.UserValidator, ttl time.Duration) commonDomain.UserValidator {
+	if ttl <= 0 {
+		return next
+	}
+	return &CachedUserValidator{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cachedUserInfo),
+	}
+}
+
+// UserExists はユーザーが存在するかチェック
+func (v *CachedUserValidator) UserExists(ctx context.Context, userID string) (bool, error) {
+	if _, ok := v.get(userID); ok {
+		return true, nil
+	}
+	return v.next.UserExists(ctx, userID)
+}
+
+// UsersExist は複数ユーザーの存在を一括で確認する（キャッシュにないユーザーのみ問い合わせる）
+func (v *CachedUserValidator) UsersExist(ctx context.Context, userIDs []string) ([]string, error) {
+	var unknown []string
+	for _, userID := range uniqueIDs(userIDs) {
+		if _, ok := v.get(userID); !ok {
+			unknown = append(unknown, userID)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+	return v.next.UsersExist(ctx, unknown)
+}
+
+// GetUserInfo はユーザー情報を取得
+func (v *CachedUserValidator) GetUserInfo(ctx context.Context, userID string) (*commonDomain.UserInfo, error) {
+	if info, ok := v.get(userID); ok {
+		return info, nil
+	}
+
+	info, err := v.next.GetUserInfo(ctx, userID)
+	if err != nil || info == nil {
+		return info, err
+	}
+	v.put(map[string]*commonDomain.UserInfo{userID: info})
+	return copyUserInfo(info), nil
+}
+
+// GetUsersInfoBatch は複数ユーザーの基本情報を一括取得（キャッシュにないユーザーのみ問い合わせる）
+func (v *CachedUserValidator) GetUsersInfoBatch(ctx context.Context, userIDs []string) (map[string]*commonDomain.UserInfo, error) {
+	result := make(map[string]*commonDomain.UserInfo, len(userIDs))
+	var missing []string
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		if info, ok := v.get(userID); ok {
+			result[userID] = info
+		} else {
+			missing = append(missing, userID)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := v.next.GetUsersInfoBatch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	v.put(fetched)
+	for userID, info := range fetched {
+		result[userID] = copyUserInfo(info)
+	}
+	return result, nil
+}
+
+// Invalidate はユーザーのキャッシュを削除する（ユーザー名の変更時などに使用）
+func (v *CachedUserValidator) Invalidate(userID string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.entries, userID)
+}
+
+// get は有効期限内のキャッシュを返す
+func (v *CachedUserValidator) get(userID string) (*commonDomain.UserInfo, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	entry, ok := v.entries[userID]
+	if !ok || !v.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return copyUserInfo(entry.info), true
+}
+
+// put はユーザー情報をキャッシュする
+func (v *CachedUserValidator) put(infos map[string]*commonDomain.UserInfo) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	if len(v.entries)+len(infos) > maxCachedUsers {
+		v.evict(now, len(infos))
+	}
+
+	expiresAt := now.Add(v.ttl)
+	for userID, info := range infos {
+		if info != nil {
+			v.entries[userID] = cachedUserInfo{info: copyUserInfo(info), expiresAt: expiresAt}
+		}
+	}
+}
+
+// evict は期限切れのキャッシュを削除し、それでも足りない場合は任意のキャッシュを削除する
+// （呼び出し側で書き込みロックを取得すること）
+func (v *CachedUserValidator) evict(now time.Time, incoming int) {
+	for userID, entry := range v.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(v.entries, userID)
+		}
+	}
+	for userID := range v.entries {
+		if len(v.entries)+incoming <= maxCachedUsers {
+			return
+		}
+		delete(v.entries, userID)
+	}
+}
+
+// copyUserInfo はキャッシュの内容が呼び出し側で変更されないようコピーする
+func copyUserInfo(info *commonDomain.UserInfo) *commonDomain.UserInfo {
+	c := *info
+	return &c
+}
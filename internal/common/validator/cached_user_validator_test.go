@@ -0,0 +1,233 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingValidator は問い合わせ回数を数えるUserValidator（latencyでDBの往復時間を模擬する）
+type countingValidator struct {
+	mu        sync.Mutex
+	users     map[string]*commonDomain.UserInfo
+	batchIDs  [][]string
+	calls     int
+	latency   time.Duration
+	batchFail error
+}
+
+func newCountingValidator(ids ...string) *countingValidator {
+	v := &countingValidator{users: make(map[string]*commonDomain.UserInfo)}
+	for _, id := range ids {
+		v.users[id] = &commonDomain.UserInfo{ID: id, Username: "user-" + id, Email: id + "@example.com"}
+	}
+	return v
+}
+
+func (v *countingValidator) UserExists(ctx context.Context, userID string) (bool, error) {
+	info, err := v.GetUserInfo(ctx, userID)
+	return info != nil, err
+}
+
+func (v *countingValidator) UsersExist(ctx context.Context, userIDs []string) ([]string, error) {
+	infos, err := v.GetUsersInfoBatch(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, id := range userIDs {
+		if infos[id] == nil {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+func (v *countingValidator) GetUserInfo(ctx context.Context, userID string) (*commonDomain.UserInfo, error) {
+	infos, err := v.GetUsersInfoBatch(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return infos[userID], nil
+}
+
+func (v *countingValidator) GetUsersInfoBatch(ctx context.Context, userIDs []string) (map[string]*commonDomain.UserInfo, error) {
+	time.Sleep(v.latency)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.calls++
+	v.batchIDs = append(v.batchIDs, append([]string(nil), userIDs...))
+	if v.batchFail != nil {
+		return nil, v.batchFail
+	}
+	result := make(map[string]*commonDomain.UserInfo)
+	for _, id := range userIDs {
+		if info, ok := v.users[id]; ok {
+			c := *info
+			result[id] = &c
+		}
+	}
+	return result, nil
+}
+
+func TestCachedUserValidator_GetUsersInfoBatch(t *testing.T) {
+	ctx := context.Background()
+	next := newCountingValidator("a", "b", "c")
+	v := NewCachedUserValidator(next, time.Minute)
+
+	infos, err := v.GetUsersInfoBatch(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Len(t, infos, 2)
+
+	// キャッシュ済みのユーザーは問い合わせず、不足分のみ取得する
+	infos, err = v.GetUsersInfoBatch(ctx, []string{"a", "b", "c", "c"})
+	require.NoError(t, err)
+	assert.Len(t, infos, 3)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, next.batchIDs)
+
+	// 全員キャッシュ済みの場合は問い合わせない
+	_, err = v.GetUsersInfoBatch(ctx, []string{"a", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachedUserValidator_UsersExist(t *testing.T) {
+	ctx := context.Background()
+	next := newCountingValidator("a", "b")
+	v := NewCachedUserValidator(next, time.Minute)
+
+	_, err := v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+
+	// キャッシュ済みのユーザーは問い合わせず、残りを一度に確認する
+	missing, err := v.UsersExist(ctx, []string{"a", "b", "x", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x"}, missing)
+	assert.Equal(t, [][]string{{"a"}, {"b", "x"}}, next.batchIDs)
+
+	// 全員キャッシュ済みの場合は問い合わせない
+	missing, err = v.UsersExist(ctx, []string{"a"})
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachedUserValidator_Expiry(t *testing.T) {
+	ctx := context.Background()
+	next := newCountingValidator("a")
+	v := NewCachedUserValidator(next, time.Minute).(*CachedUserValidator)
+	now := time.Now()
+	v.now = func() time.Time { return now }
+
+	_, err := v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+	_, err = v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.calls)
+
+	now = now.Add(time.Minute)
+	_, err = v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls, "期限切れのキャッシュは再取得する")
+}
+
+func TestCachedUserValidator_DoesNotCacheMissingUsers(t *testing.T) {
+	ctx := context.Background()
+	next := newCountingValidator()
+	v := NewCachedUserValidator(next, time.Minute)
+
+	exists, err := v.UserExists(ctx, "new-user")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// 登録直後のユーザーはすぐに見つかる
+	next.users["new-user"] = &commonDomain.UserInfo{ID: "new-user"}
+	exists, err = v.UserExists(ctx, "new-user")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestCachedUserValidator_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	next := newCountingValidator("a")
+	v := NewCachedUserValidator(next, time.Minute).(*CachedUserValidator)
+
+	_, err := v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+
+	next.users["a"].Email = "changed@example.com"
+	v.Invalidate("a")
+
+	info, err := v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "changed@example.com", info.Email)
+}
+
+func TestCachedUserValidator_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	v := NewCachedUserValidator(newCountingValidator("a"), time.Minute)
+
+	info, err := v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+	info.Username = "modified"
+
+	info, err = v.GetUserInfo(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "user-a", info.Username)
+}
+
+func TestCachedUserValidator_Error(t *testing.T) {
+	next := newCountingValidator("a")
+	next.batchFail = errors.New("db down")
+	v := NewCachedUserValidator(next, time.Minute)
+
+	_, err := v.GetUsersInfoBatch(context.Background(), []string{"a"})
+	assert.Error(t, err)
+}
+
+func TestNewCachedUserValidator_Disabled(t *testing.T) {
+	next := newCountingValidator()
+	assert.Same(t, next, NewCachedUserValidator(next, 0))
+}
+
+// BenchmarkUserInfoBatch は100人分のユーザー情報取得をキャッシュの有無で比較する
+// （DBの往復時間を200µsとして模擬する）
+func BenchmarkUserInfoBatch(b *testing.B) {
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("user-%03d", i)
+	}
+	ctx := context.Background()
+
+	benchmarks := []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{"uncached", 0},
+		{"cached", time.Minute},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			next := newCountingValidator(ids...)
+			next.latency = 200 * time.Microsecond
+			v := NewCachedUserValidator(next, bm.ttl)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := v.GetUsersInfoBatch(ctx, ids); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(next.calls)/float64(b.N), "queries/op")
+		})
+	}
+}
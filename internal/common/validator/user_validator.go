@@ -24,6 +24,23 @@ func (v *UserValidator) UserExists(ctx context.Context, userID string) (bool, er
 	return v.userRepo.UserExists(userID)
 }
 
+// UsersExist は複数ユーザーの存在を一括で確認し、存在しないユーザーIDを返す
+func (v *UserValidator) UsersExist(ctx context.Context, userIDs []string) ([]string, error) {
+	userIDs = uniqueIDs(userIDs)
+	existing, err := v.userRepo.FindExistingUserIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, userID := range userIDs {
+		if !existing[userID] {
+			missing = append(missing, userID)
+		}
+	}
+	return missing, nil
+}
+
 // GetUserInfo はユーザー情報を取得
 func (v *UserValidator) GetUserInfo(ctx context.Context, userID string) (*commonDomain.UserInfo, error) {
 	basicInfo, err := v.userRepo.GetUserBasicInfo(userID)
@@ -59,3 +76,16 @@ func (v *UserValidator) GetUsersInfoBatch(ctx context.Context, userIDs []string)
 
 	return result, nil
 }
+
+// uniqueIDs は順序を保ったまま重複したIDを取り除く
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
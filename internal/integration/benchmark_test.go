@@ -0,0 +1,65 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskMemory "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/memory"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// BenchmarkListTasks はタスク一覧取得をMySQL実装とインメモリ実装（STORAGE_DRIVER=memory）で比較する
+//
+//	go test -tags integration -run '^$' -bench ListTasks ./internal/integration/...
+func BenchmarkListTasks(b *testing.B) {
+	mysqlRepo, _ := newTaskRepositories()
+	repos := []struct {
+		name string
+		repo usecase.TaskRepository
+	}{
+		{"mysql", mysqlRepo},
+		{"memory", taskMemory.NewTaskRepository()},
+	}
+
+	for _, r := range repos {
+		b.Run(r.name, func(b *testing.B) {
+			resetDatabase(b)
+			users := createUsers(b, 10)
+			seedBenchmarkTasks(b, r.repo, users, 1000)
+
+			createdBy := users[1].String()
+			filter := domain.ListFilter{CreatedBy: &createdBy}
+			ctx := context.Background()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, err := r.repo.ListTasks(ctx, filter, domain.Pagination{Page: 1, PageSize: 20}, domain.SortOptions{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// seedBenchmarkTasks はユーザーに均等に割り振ったタスクを作成する
+func seedBenchmarkTasks(b *testing.B, repo usecase.TaskRepository, users []uuid.UUID, n int) {
+	b.Helper()
+
+	ctx := context.Background()
+	base := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	for i := 0; i < n; i++ {
+		task := domain.NewTask(fmt.Sprintf("task %d", i), "", domain.PriorityMedium, domain.CategoryWork, users[i%len(users)].String())
+		task.ID = uuid.New().String()
+		task.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		task.UpdatedAt = task.CreatedAt
+		if err := repo.CreateTask(ctx, task); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package integration はMySQL・Redisを使用するリポジトリの結合テストです
+//
+// テストはintegrationビルドタグ付きで、dockertestで起動した使い捨てのコンテナ（mysql:8.0・redis:7-alpine）に対して実行します。
+// 一意制約や日付範囲のSQLなど、モックを使用する単体テストでは確認できない挙動を検証します。
+//
+//	go test -tags integration ./internal/integration/...
+//
+// Dockerデーモンに接続できる環境が必要です（DOCKER_HOSTで接続先を変更できます）。
+package integration
@@ -0,0 +1,68 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	databaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
+	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskExportRepository_StreamTasksByUser(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, _ := newTaskRepositories()
+	exportRepo := taskDatabase.NewTaskExportRepository(&databaseInfra.SqlHandler{Conn: testDB}, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	user, other := users[0], users[1]
+
+	base := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	var want []string
+	// 担当者の取得単位（500件）をまたぐ件数を作成する
+	for i := 0; i < 510; i++ {
+		task := createTask(t, taskRepo, user, base.Add(time.Duration(i)*time.Second), nil)
+		want = append(want, task.ID)
+	}
+	assigned := createTask(t, taskRepo, other, base.Add(time.Hour), func(task *domain.Task) {
+		task.AddAssignee(user.String())
+	})
+	want = append(want, assigned.ID)
+	createTask(t, taskRepo, other, base, nil)
+
+	tasks, errs := exportRepo.StreamTasksByUser(ctx, user.String())
+	var got []*domain.Task
+	for task := range tasks {
+		got = append(got, task)
+	}
+	require.NoError(t, <-errs)
+
+	assert.Equal(t, want, taskIDs(got))
+	require.Len(t, got[len(got)-1].Assignees, 1)
+	assert.Equal(t, user.String(), got[len(got)-1].Assignees[0].UserID)
+}
+
+func TestTaskExportRepository_StreamTasksByUser_Cancel(t *testing.T) {
+	taskRepo, _ := newTaskRepositories()
+	exportRepo := taskDatabase.NewTaskExportRepository(&databaseInfra.SqlHandler{Conn: testDB}, testLogger)
+
+	resetDatabase(t)
+	user := createUsers(t, 1)[0]
+	base := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	for i := 0; i < 3; i++ {
+		createTask(t, taskRepo, user, base.Add(time.Duration(i)*time.Second), nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tasks, errs := exportRepo.StreamTasksByUser(ctx, user.String())
+	for range tasks {
+	}
+	assert.ErrorIs(t, <-errs, context.Canceled)
+}
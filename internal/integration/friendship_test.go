@@ -0,0 +1,105 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mysqlDuplicateEntry は一意制約違反のエラー番号
+const mysqlDuplicateEntry = 1062
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+func TestFriendshipRepository_Uniqueness(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewFriendshipRepository(testDB, testLogger)
+
+	tests := []struct {
+		name      string
+		duplicate func(first *domain.Friendship) *domain.Friendship
+	}{
+		{
+			name: "同じ向きの申請は重複できない",
+			duplicate: func(first *domain.Friendship) *domain.Friendship {
+				return domain.NewFriendship(first.RequesterID, first.AddresseeID)
+			},
+		},
+		{
+			name: "逆向きの申請も重複できない",
+			duplicate: func(first *domain.Friendship) *domain.Friendship {
+				return domain.NewFriendship(first.AddresseeID, first.RequesterID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetDatabase(t)
+			users := createUsers(t, 2)
+
+			first := domain.NewFriendship(users[0], users[1])
+			require.NoError(t, repo.CreateFriendship(ctx, first))
+
+			err := repo.CreateFriendship(ctx, tt.duplicate(first))
+			require.Error(t, err)
+			assert.True(t, isDuplicateEntry(err), "expected duplicate entry error, got %v", err)
+		})
+	}
+}
+
+func TestFriendshipRepository_EitherDirection(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewFriendshipRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 3)
+	alice, bob, carol := users[0], users[1], users[2]
+
+	friendship := domain.NewFriendship(alice, bob)
+	require.NoError(t, repo.CreateFriendship(ctx, friendship))
+
+	// 承認前は友達ではない
+	areFriends, err := repo.AreFriends(ctx, bob, alice)
+	require.NoError(t, err)
+	assert.False(t, areFriends)
+
+	friendship.Accept()
+	require.NoError(t, repo.UpdateFriendship(ctx, friendship))
+
+	// どちらの向きでも同じ友達関係を取得できる
+	found, err := repo.GetFriendship(ctx, bob, alice)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, friendship.ID, found.ID)
+	assert.Equal(t, domain.FriendshipStatusAccepted, found.Status)
+	assert.NotNil(t, found.AcceptedAt)
+
+	areFriends, err = repo.AreFriends(ctx, bob, alice)
+	require.NoError(t, err)
+	assert.True(t, areFriends)
+
+	// 関係のないユーザー同士はnilを返す
+	notFound, err := repo.GetFriendship(ctx, alice, carol)
+	require.NoError(t, err)
+	assert.Nil(t, notFound)
+
+	// 逆向きの指定でも削除でき、削除後は再申請できる
+	require.NoError(t, repo.DeleteFriendship(ctx, bob, alice))
+	deleted, err := repo.GetFriendship(ctx, alice, bob)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	require.NoError(t, repo.CreateFriendship(ctx, domain.NewFriendship(bob, alice)))
+}
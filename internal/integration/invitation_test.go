@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationRepository_CodeCollision(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewInvitationRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+
+	first := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodCode, users[0], "", 24)
+	require.NoError(t, repo.CreateInvitation(ctx, first))
+
+	// 生成したコードが衝突した場合は一意制約で保存に失敗する
+	collided := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodCode, users[1], "", 24)
+	collided.Code = first.Code
+	err := repo.CreateInvitation(ctx, collided)
+	require.Error(t, err)
+	assert.True(t, isDuplicateEntry(err), "expected duplicate entry error, got %v", err)
+
+	// コードからは最初の招待を取得できる
+	found, err := repo.GetInvitationByCode(ctx, first.Code)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, first.ID, found.ID)
+	assert.Equal(t, users[0], found.InviterID)
+}
+
+func TestInvitationRepository_WithoutCode(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewInvitationRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 3)
+
+	// コードを持たないアプリ内招待は複数作成できる
+	var created []*domain.Invitation
+	for _, invitee := range users[1:] {
+		invitation := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodInApp, users[0], "", 24)
+		invitation.SetInvitee(invitee)
+		require.NoError(t, repo.CreateInvitation(ctx, invitation))
+		created = append(created, invitation)
+	}
+
+	for _, want := range created {
+		got, err := repo.GetInvitationByID(ctx, want.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Empty(t, got.Code)
+		assert.Empty(t, got.URL)
+		require.NotNil(t, got.InviteeID)
+		assert.Equal(t, *want.InviteeID, *got.InviteeID)
+	}
+
+	// 空のコードで招待を引けない
+	found, err := repo.GetInvitationByCode(ctx, "")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestInvitationRepository_IsValidInvitation(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewInvitationRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+
+	valid := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodURL, users[0], "", 24)
+	require.NoError(t, repo.CreateInvitation(ctx, valid))
+
+	expired := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodURL, users[0], "", 24)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, repo.CreateInvitation(ctx, expired))
+
+	ok, err := repo.IsValidInvitation(ctx, valid.Code)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.IsValidInvitation(ctx, expired.Code)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 期限切れの招待はステータスが更新される
+	marked, err := repo.MarkExpiredInvitations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	got, err := repo.GetInvitationByID(ctx, expired.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, domain.InvitationStatusExpired, got.Status)
+}
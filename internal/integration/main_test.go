@@ -0,0 +1,201 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mysqlPassword = "integration"
+	databaseName  = "Yotei-Plus"
+
+	// スキーマ定義（docker-composeと同じものを使用する）
+	initSQLPath = "../../mysql/init.sql"
+
+	// テストが異常終了した場合にコンテナを破棄するまでの秒数
+	containerExpireSeconds = 600
+)
+
+var (
+	testDB     *sql.DB
+	testRedis  *redis.Client
+	testLogger logger.Logger
+)
+
+// resetTables は各テストの前に削除するテーブル（外部キーの依存順）
+var resetTables = []string{
+	"group_members",
+	"groups",
+	"task_assignees",
+	"tasks",
+	"invitations",
+	"friendships",
+	"users",
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	testLogger = *logger.NewLogger(&logger.Config{Level: "error", Output: "console"})
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create docker pool: %v\n", err)
+		return 1
+	}
+	if err := pool.Client.Ping(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to docker: %v\n", err)
+		return 1
+	}
+	pool.MaxWait = 3 * time.Minute
+
+	mysqlResource, err := runContainer(pool, &dockertest.RunOptions{
+		Repository: "mysql",
+		Tag:        "8.0",
+		Env: []string{
+			"MYSQL_ROOT_PASSWORD=" + mysqlPassword,
+			"MYSQL_DATABASE=" + databaseName,
+		},
+		Cmd: []string{"--character-set-server=utf8mb4", "--collation-server=utf8mb4_unicode_ci"},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start mysql: %v\n", err)
+		return 1
+	}
+	defer pool.Purge(mysqlResource)
+
+	redisResource, err := runContainer(pool, &dockertest.RunOptions{
+		Repository: "redis",
+		Tag:        "7-alpine",
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start redis: %v\n", err)
+		return 1
+	}
+	defer pool.Purge(redisResource)
+
+	cfg := &config.Config{
+		Database: config.Database{
+			Host:     "localhost",
+			Port:     mysqlResource.GetPort("3306/tcp"),
+			User:     "root",
+			Password: mysqlPassword,
+			Name:     databaseName,
+			TimeZone: "UTC",
+		},
+	}
+
+	if err := pool.Retry(func() error {
+		db, err := sql.Open("mysql", cfg.GetDSN())
+		if err != nil {
+			return err
+		}
+		if err := db.Ping(); err != nil {
+			db.Close()
+			return err
+		}
+		testDB = db
+		return nil
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "mysql did not become ready: %v\n", err)
+		return 1
+	}
+	defer testDB.Close()
+
+	if err := loadSchema(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load schema: %v\n", err)
+		return 1
+	}
+
+	testRedis = redis.NewClient(&redis.Options{
+		Addr: "localhost:" + redisResource.GetPort("6379/tcp"),
+	})
+	defer testRedis.Close()
+	if err := pool.Retry(func() error {
+		return testRedis.Ping(context.Background()).Err()
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "redis did not become ready: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// runContainer はテスト終了後に自動削除されるコンテナを起動する
+func runContainer(pool *dockertest.Pool, opts *dockertest.RunOptions) (*dockertest.Resource, error) {
+	resource, err := pool.RunWithOptions(opts, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := resource.Expire(containerExpireSeconds); err != nil {
+		pool.Purge(resource)
+		return nil, err
+	}
+	return resource, nil
+}
+
+// loadSchema はinit.sqlを実行してテーブルを作成する
+// init.sqlはテーブルの定義順と外部キーの参照順が一致しないため、外部キー制約を無効にした接続で実行する
+func loadSchema(cfg *config.Config) error {
+	db, err := sql.Open("mysql", cfg.GetDSN()+"&foreign_key_checks=0")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return database.ExecuteSQLFile(db, initSQLPath)
+}
+
+// resetDatabase はテスト間でデータが干渉しないよう全テーブルを空にする
+func resetDatabase(t testing.TB) {
+	t.Helper()
+
+	for _, table := range resetTables {
+		_, err := testDB.Exec("DELETE FROM `" + table + "`")
+		require.NoError(t, err, "failed to reset %s", table)
+	}
+}
+
+// resetRedis はRedisのデータを削除する
+func resetRedis(t *testing.T) {
+	t.Helper()
+
+	require.NoError(t, testRedis.FlushDB(context.Background()).Err())
+}
+
+// createUsers は外部キー制約を満たすためのユーザーを作成する
+func createUsers(t testing.TB, n int) []uuid.UUID {
+	t.Helper()
+
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+		name := "user-" + ids[i].String()[:8]
+		_, err := testDB.Exec(
+			"INSERT INTO users (id, email, username, password) VALUES (?, ?, ?, ?)",
+			ids[i].String(), name+"@example.com", name, "hashed-password",
+		)
+		require.NoError(t, err)
+	}
+	return ids
+}
@@ -0,0 +1,10 @@
+// Package postgres はPostgreSQLを使用するリポジトリの結合テストです
+//
+// STORAGE_DRIVER=postgres の場合もリポジトリはMySQLと同じ実装を使い、接続でクエリをPostgreSQLの方言に変換します。
+// テストはintegrationビルドタグ付きで、dockertestで起動した使い捨てのコンテナ（postgres:16-alpine）に
+// postgres/init.sql のスキーマを読み込み、変換したクエリ（プレースホルダー・ON CONFLICT・ILIKEなど）を実際に実行して検証します。
+//
+//	go test -tags integration ./internal/integration/postgres/...
+//
+// Dockerデーモンに接続できる環境が必要です（DOCKER_HOSTで接続先を変更できます）。
+package postgres
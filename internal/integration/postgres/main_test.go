@@ -0,0 +1,154 @@
+//go:build integration
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	postgresPassword = "integration"
+	databaseName     = "yotei_plus"
+
+	// スキーマ定義（アプリケーションの起動時と同じものを使用する）
+	initSQLPath = "../../../postgres/init.sql"
+
+	// テストが異常終了した場合にコンテナを破棄するまでの秒数
+	containerExpireSeconds = 600
+)
+
+var (
+	testDB     *sql.DB
+	testLogger logger.Logger
+)
+
+// resetTables は各テストの前に削除するテーブル（外部キーの依存順）
+var resetTables = []string{
+	"sync_changes",
+	"sync_entity_recipients",
+	"sync_entity_versions",
+	"feature_flags",
+	"group_members",
+	"groups",
+	"task_assignees",
+	"tasks",
+	"invitations",
+	"friendships",
+	"users",
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	testLogger = *logger.NewLogger(&logger.Config{Level: "error", Output: "console"})
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create docker pool: %v\n", err)
+		return 1
+	}
+	if err := pool.Client.Ping(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to docker: %v\n", err)
+		return 1
+	}
+	pool.MaxWait = 3 * time.Minute
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "16-alpine",
+		Env: []string{
+			"POSTGRES_PASSWORD=" + postgresPassword,
+			"POSTGRES_DB=" + databaseName,
+		},
+	}, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start postgres: %v\n", err)
+		return 1
+	}
+	defer pool.Purge(resource)
+	if err := resource.Expire(containerExpireSeconds); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set container expiry: %v\n", err)
+		return 1
+	}
+
+	cfg := &config.Config{
+		Database: config.Database{
+			Host:     "localhost",
+			Port:     resource.GetPort("5432/tcp"),
+			User:     "postgres",
+			Password: postgresPassword,
+			Name:     databaseName,
+			TimeZone: "UTC",
+		},
+		Storage: config.Storage{Driver: config.StorageDriverPostgres},
+	}
+
+	// スキーマはPL/pgSQLを含むため、方言を変換しない接続で読み込む
+	if err := pool.Retry(func() error {
+		db, err := sql.Open("postgres", cfg.GetPostgresDSN())
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			return err
+		}
+		return database.ExecuteSQLScript(db, initSQLPath)
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "postgres did not become ready: %v\n", err)
+		return 1
+	}
+
+	testDB, err = database.NewPostgresConnection(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to postgres: %v\n", err)
+		return 1
+	}
+	defer testDB.Close()
+
+	return m.Run()
+}
+
+// resetDatabase はテスト間でデータが干渉しないよう全テーブルを空にする
+func resetDatabase(t testing.TB) {
+	t.Helper()
+
+	for _, table := range resetTables {
+		_, err := testDB.Exec("DELETE FROM `" + table + "`")
+		require.NoError(t, err, "failed to reset %s", table)
+	}
+}
+
+// createUsers は外部キー制約を満たすためのユーザーを作成する
+func createUsers(t testing.TB, n int) []uuid.UUID {
+	t.Helper()
+
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+		name := "user-" + ids[i].String()[:8]
+		_, err := testDB.Exec(
+			"INSERT INTO users (id, email, username, password) VALUES (?, ?, ?, ?)",
+			ids[i].String(), name+"@example.com", name, "hashed-password",
+		)
+		require.NoError(t, err)
+	}
+	return ids
+}
@@ -0,0 +1,147 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	featureFlagDomain "github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	featureFlagDatabase "github.com/hryt430/Yotei+/internal/modules/featureflag/interface/database"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	syncDomain "github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	syncDatabase "github.com/hryt430/Yotei+/internal/modules/sync/interface/database"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	databaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
+	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
+)
+
+// postgresUniqueViolation は一意制約違反のエラーコード
+const postgresUniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == postgresUniqueViolation
+}
+
+func TestTaskRepository_CreateAndSearch(t *testing.T) {
+	ctx := context.Background()
+	repo := taskDatabase.NewTaskRepository(&databaseInfra.SqlHandler{Conn: testDB}, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+
+	task := taskDomain.NewTask("Quarterly Report", "draft the 100% summary", taskDomain.PriorityHigh, taskDomain.CategoryWork, users[0].String())
+	task.ID = uuid.NewString()
+	task.AssignTo(users[1].String())
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	got, err := repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Quarterly Report", got.Title)
+	require.Len(t, got.Assignees, 1)
+	assert.Equal(t, users[1].String(), got.Assignees[0].UserID)
+
+	// MySQLの照合順序と同じく大文字・小文字を区別しない
+	found, err := repo.SearchTasks(ctx, "quarterly", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, task.ID, found[0].ID)
+
+	// ワイルドカードはエスケープされる
+	found, err = repo.SearchTasks(ctx, "100%", 10)
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+	found, err = repo.SearchTasks(ctx, "1%0", 10)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestFlagRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	repo := featureFlagDatabase.NewFlagRepository(testDB, testLogger)
+
+	resetDatabase(t)
+
+	flag, err := featureFlagDomain.NewFlag("task_history")
+	require.NoError(t, err)
+	flag.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.SaveFlag(ctx, flag))
+
+	// 同じキーで保存すると ON CONFLICT で更新される
+	flag.Enabled = true
+	flag.Percentage = 25
+	flag.Users = []string{"user-1"}
+	require.NoError(t, repo.SaveFlag(ctx, flag))
+
+	got, err := repo.GetFlag(ctx, "task_history")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, 25, got.Percentage)
+	assert.Equal(t, []string{"user-1"}, got.Users)
+
+	flags, err := repo.ListFlags(ctx)
+	require.NoError(t, err)
+	assert.Len(t, flags, 1)
+}
+
+func TestFriendshipRepository_Uniqueness(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewFriendshipRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+
+	require.NoError(t, repo.CreateFriendship(ctx, socialDomain.NewFriendship(users[0], users[1])))
+
+	// 逆向きの申請は生成列 user_pair の一意制約で重複になる
+	err := repo.CreateFriendship(ctx, socialDomain.NewFriendship(users[1], users[0]))
+	require.Error(t, err)
+	assert.True(t, isUniqueViolation(err), "expected unique violation, got %v", err)
+}
+
+func TestChangeRepository_AppendChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := syncDatabase.NewChangeRepository(testDB, testLogger)
+
+	resetDatabase(t)
+
+	before, _, err := repo.GetSequence(ctx)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	changes := []*syncDomain.Change{
+		{UserID: "user-1", EntityType: "task", EntityID: "task-1", Operation: syncDomain.OperationUpsert, Data: []byte(`{"title":"a"}`), ChangedAt: now},
+		{UserID: "user-2", EntityType: "task", EntityID: "task-1", Operation: syncDomain.OperationUpsert, Data: []byte(`{"title":"a"}`), ChangedAt: now},
+	}
+	require.NoError(t, repo.AppendChanges(ctx, "task", "task-1", changes, []string{"user-1", "user-2"}))
+	// 2回目はエンティティのバージョンを ON CONFLICT で更新する
+	require.NoError(t, repo.AppendChanges(ctx, "task", "task-1", changes[:1], []string{"user-1"}))
+
+	latest, _, err := repo.GetSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before+3, latest)
+
+	version, err := repo.GetEntityVersion(ctx, "task", "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+
+	listed, err := repo.ListChanges(ctx, "user-1", before, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, before+1, listed[0].Seq)
+	assert.JSONEq(t, `{"title":"a"}`, string(listed[0].Data))
+
+	recipients, err := repo.ListRecipients(ctx, "task", "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1"}, recipients)
+}
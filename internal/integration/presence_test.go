@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialRedis "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresenceStore_StatusLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := socialRedis.NewPresenceStore(testRedis)
+
+	resetRedis(t)
+	userID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+
+	previous, err := store.SetStatus(ctx, userID, domain.PresenceStatusOnline, time.Minute, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PresenceStatusOffline, previous)
+
+	previous, err = store.SetStatus(ctx, userID, domain.PresenceStatusAway, time.Minute, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PresenceStatusOnline, previous)
+
+	previous, err = store.SetOffline(ctx, userID, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, domain.PresenceStatusAway, previous)
+
+	presences, err := store.GetPresences(ctx, []uuid.UUID{userID})
+	require.NoError(t, err)
+	require.Len(t, presences, 1)
+	assert.Equal(t, domain.PresenceStatusOffline, presences[0].Status)
+	require.NotNil(t, presences[0].LastSeenAt)
+	assert.Equal(t, now.Add(time.Second).Unix(), presences[0].LastSeenAt.Unix())
+}
+
+func TestPresenceStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	store := socialRedis.NewPresenceStore(testRedis)
+
+	resetRedis(t)
+	expiring, staying, unknown := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	_, err := store.SetStatus(ctx, expiring, domain.PresenceStatusOnline, time.Second, now)
+	require.NoError(t, err)
+	_, err = store.SetStatus(ctx, staying, domain.PresenceStatusOnline, time.Second, now)
+	require.NoError(t, err)
+
+	// ハートビートで有効期限を延長したユーザーだけがオンラインのまま残る
+	alive, err := store.Refresh(ctx, staying, time.Minute, now)
+	require.NoError(t, err)
+	assert.True(t, alive)
+
+	time.Sleep(1500 * time.Millisecond)
+
+	alive, err = store.Refresh(ctx, expiring, time.Minute, now)
+	require.NoError(t, err)
+	assert.False(t, alive)
+
+	presences, err := store.GetPresences(ctx, []uuid.UUID{expiring, staying, unknown})
+	require.NoError(t, err)
+	require.Len(t, presences, 3)
+
+	assert.Equal(t, domain.PresenceStatusOffline, presences[0].Status)
+	assert.NotNil(t, presences[0].LastSeenAt, "last seen must outlive the status key")
+	assert.Equal(t, domain.PresenceStatusOnline, presences[1].Status)
+	assert.Equal(t, domain.PresenceStatusOffline, presences[2].Status)
+	assert.Nil(t, presences[2].LastSeenAt)
+}
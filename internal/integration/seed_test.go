@@ -0,0 +1,51 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	authDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/database"
+	authDatabase "github.com/hryt430/Yotei+/internal/modules/auth/interface/database"
+	groupDatabase "github.com/hryt430/Yotei+/internal/modules/group/interface/database"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	"github.com/hryt430/Yotei+/internal/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeeder_IdempotentAgainstMySQL(t *testing.T) {
+	ctx := context.Background()
+
+	resetDatabase(t)
+	taskRepo, _ := newTaskRepositories()
+	seeder := seed.NewSeeder(
+		&authDatabase.IUserRepository{SqlHandler: &authDatabaseInfra.SqlHandler{Conn: testDB}},
+		socialDatabase.NewFriendshipRepository(testDB, testLogger),
+		groupDatabase.NewGroupRepository(testDB, testLogger),
+		taskRepo,
+		&testLogger,
+	)
+
+	profile, err := seed.LookupProfile("demo")
+	require.NoError(t, err)
+
+	first, err := seeder.Run(ctx, profile, "password123")
+	require.NoError(t, err)
+	assert.Equal(t, profile.Users, first.Users.Created)
+
+	second, err := seeder.Run(ctx, profile, "password123")
+	require.NoError(t, err)
+	assert.Zero(t, second.Users.Created+second.Friendships.Created+second.Groups.Created+second.Tasks.Created)
+	assert.Equal(t, first.Tasks.Created, second.Tasks.Skipped)
+
+	// リセットするとプロファイルのデータが外部キーで連動して削除される
+	deleted, err := seed.Reset(ctx, testDB, profile)
+	require.NoError(t, err)
+	assert.Equal(t, int64(profile.Users), deleted)
+
+	var tasks int
+	require.NoError(t, testDB.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&tasks))
+	assert.Zero(t, tasks)
+}
@@ -0,0 +1,10 @@
+// Package sqlite はSQLiteを使用するリポジトリの結合テストです
+//
+// STORAGE_DRIVER=sqlite の場合もリポジトリはMySQLと同じ実装を使い、接続でクエリをSQLiteの方言に変換します。
+// テストはintegrationビルドタグ付きで、一時ディレクトリのデータベースファイルに埋め込みのマイグレーションを適用し、
+// 変換したクエリ（ON CONFLICT・LIKEのエスケープ・時刻の比較など）を実際に実行して検証します。
+//
+//	go test -tags integration ./internal/integration/sqlite/...
+//
+// Dockerは不要ですが、SQLiteドライバーのビルドにcgo（Cコンパイラ）が必要です。
+package sqlite
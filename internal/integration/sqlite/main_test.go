@@ -0,0 +1,116 @@
+//go:build integration
+
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testDB     *sql.DB
+	testLogger logger.Logger
+)
+
+// resetTables は各テストの前に削除するテーブル（外部キーの依存順）
+var resetTables = []string{
+	"sync_changes",
+	"sync_entity_recipients",
+	"sync_entity_versions",
+	"feature_flags",
+	"group_schedule_card_settings",
+	"group_chat_integrations",
+	"group_event_task_links",
+	"group_note_action_items",
+	"group_event_notes",
+	"group_event_exceptions",
+	"group_event_recurrences",
+	"group_event_rsvps",
+	"group_event_reminders",
+	"group_members",
+	"groups",
+	"deferred_reminders",
+	"task_issue_links",
+	"task_links",
+	"task_geofences",
+	"task_locations",
+	"task_assignees",
+	"tasks",
+	"invitations",
+	"friendships",
+	"notifications",
+	"data_import_records",
+	"data_exports",
+	"social_digest_states",
+	"notification_preferences",
+	"github_accounts",
+	"inbound_emails",
+	"inbound_email_addresses",
+	"users",
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	testLogger = *logger.NewLogger(&logger.Config{Level: "error", Output: "console"})
+
+	dir, err := os.MkdirTemp("", "yotei-plus-sqlite")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create temp dir: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := &config.Config{
+		Storage: config.Storage{
+			Driver:     config.StorageDriverSQLite,
+			SQLitePath: filepath.Join(dir, "yotei-plus.db"),
+		},
+	}
+	testDB, err = database.NewSQLiteConnection(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open sqlite: %v\n", err)
+		return 1
+	}
+	defer testDB.Close()
+
+	return m.Run()
+}
+
+// resetDatabase はテスト間でデータが干渉しないよう全テーブルを空にする
+func resetDatabase(t testing.TB) {
+	t.Helper()
+
+	for _, table := range resetTables {
+		_, err := testDB.Exec("DELETE FROM `" + table + "`")
+		require.NoError(t, err, "failed to reset %s", table)
+	}
+}
+
+// createUsers は外部キー制約を満たすためのユーザーを作成する
+func createUsers(t testing.TB, n int) []uuid.UUID {
+	t.Helper()
+
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+		name := "user-" + ids[i].String()[:8]
+		_, err := testDB.Exec(
+			"INSERT INTO users (id, email, username, password) VALUES (?, ?, ?, ?)",
+			ids[i].String(), name+"@example.com", name, "hashed-password",
+		)
+		require.NoError(t, err)
+	}
+	return ids
+}
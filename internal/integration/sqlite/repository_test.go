@@ -0,0 +1,1124 @@
+//go:build integration
+
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+	adminDatabase "github.com/hryt430/Yotei+/internal/modules/admin/interface/database"
+	authDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/database"
+	authDatabase "github.com/hryt430/Yotei+/internal/modules/auth/interface/database"
+	featureFlagDomain "github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	featureFlagDatabase "github.com/hryt430/Yotei+/internal/modules/featureflag/interface/database"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupDatabase "github.com/hryt430/Yotei+/internal/modules/group/interface/database"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	syncDomain "github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	syncDatabase "github.com/hryt430/Yotei+/internal/modules/sync/interface/database"
+	takeoutDomain "github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	takeoutDatabase "github.com/hryt430/Yotei+/internal/modules/takeout/interface/database"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	databaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
+	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	sqliteMigrations "github.com/hryt430/Yotei+/sqlite"
+)
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+func TestMigrateSQLite(t *testing.T) {
+	migrations, err := fs.Sub(sqliteMigrations.Migrations, "migrations")
+	require.NoError(t, err)
+
+	// 適用済みのマイグレーションは再適用しない
+	require.NoError(t, database.MigrateSQLite(testDB, migrations))
+
+	// 新しいマイグレーションだけを適用する
+	next := fstest.MapFS{
+		"999_test_column.sql": {Data: []byte("CREATE TABLE migration_test (id INTEGER PRIMARY KEY);")},
+	}
+	for name, file := range mustReadAll(t, migrations) {
+		next[name] = file
+	}
+	require.NoError(t, database.MigrateSQLite(testDB, next))
+	require.NoError(t, database.MigrateSQLite(testDB, next))
+
+	var versions []string
+	rows, err := testDB.Query("SELECT version FROM schema_migrations ORDER BY version")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var version string
+		require.NoError(t, rows.Scan(&version))
+		versions = append(versions, version)
+	}
+	assert.Equal(t, []string{"001_init", "002_notification_dead_letters", "003_short_links", "004_profile_settings", "005_username_history", "006_data_exports", "007_data_import_records", "008_social_digest", "009_group_event_rsvps", "010_group_event_recurrences", "011_group_event_notes", "012_group_event_task_links", "013_task_locations", "014_holiday_policies", "015_reminder_timing", "016_group_chat_integrations", "017_group_chat_teams", "018_task_issue_links", "019_task_links", "020_inbound_email", "021_group_schedule_card", "999_test_column"}, versions)
+
+	_, err = testDB.Exec("DROP TABLE migration_test")
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM schema_migrations WHERE version = ?", "999_test_column")
+	require.NoError(t, err)
+}
+
+// mustReadAll は埋め込みのマイグレーションをテスト用のファイルシステムにコピーする
+func mustReadAll(t *testing.T, fsys fs.FS) fstest.MapFS {
+	t.Helper()
+
+	files := fstest.MapFS{}
+	names, err := fs.Glob(fsys, "*.sql")
+	require.NoError(t, err)
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		require.NoError(t, err)
+		files[name] = &fstest.MapFile{Data: data}
+	}
+	return files
+}
+
+func TestUserRepository_CaseInsensitiveEmail(t *testing.T) {
+	repo := &authDatabase.IUserRepository{SqlHandler: &authDatabaseInfra.SqlHandler{Conn: testDB}}
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+
+	// MySQLの照合順序と同じく大文字・小文字を区別しない
+	got, err := repo.FindUserByEmail("USER-" + users[0].String()[:8] + "@EXAMPLE.COM")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, users[0], got.ID)
+}
+
+func TestTaskRepository_CreateAndSearch(t *testing.T) {
+	ctx := context.Background()
+	repo := taskDatabase.NewTaskRepository(&databaseInfra.SqlHandler{Conn: testDB}, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+
+	task := taskDomain.NewTask("Quarterly Report", "draft the 100% summary", taskDomain.PriorityHigh, taskDomain.CategoryWork, users[0].String())
+	task.ID = uuid.NewString()
+	task.AssignTo(users[1].String())
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	got, err := repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Quarterly Report", got.Title)
+	require.Len(t, got.Assignees, 1)
+	assert.Equal(t, users[1].String(), got.Assignees[0].UserID)
+
+	found, err := repo.SearchTasks(ctx, "quarterly", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, task.ID, found[0].ID)
+
+	// ワイルドカードはエスケープされる
+	found, err = repo.SearchTasks(ctx, "100%", 10)
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+	found, err = repo.SearchTasks(ctx, "1%0", 10)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestLocationRepository_LocationsAndGeofences(t *testing.T) {
+	ctx := context.Background()
+	handler := &databaseInfra.SqlHandler{Conn: testDB}
+	taskRepo := taskDatabase.NewTaskRepository(handler, testLogger)
+	repo := taskDatabase.NewLocationRepository(handler, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	taskIDs := make([]string, 2)
+	for i := range taskIDs {
+		task := taskDomain.NewTask("errand", "", taskDomain.PriorityMedium, taskDomain.CategoryPersonal, users[0].String())
+		task.ID = uuid.NewString()
+		require.NoError(t, taskRepo.CreateTask(ctx, task))
+		taskIDs[i] = task.ID
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	station := &taskDomain.TaskLocation{TaskID: taskIDs[0], Location: taskDomain.Location{Name: "東京駅", Latitude: 35.681236, Longitude: 139.767125}, UpdatedBy: users[0].String(), UpdatedAt: now}
+	require.NoError(t, repo.SetTaskLocation(ctx, station))
+	require.NoError(t, repo.SetTaskLocation(ctx, &taskDomain.TaskLocation{TaskID: taskIDs[1], Location: taskDomain.Location{Name: "新大阪駅", Latitude: 34.733165, Longitude: 135.500214}, UpdatedBy: users[0].String(), UpdatedAt: now}))
+
+	// 設定済みの場所は置き換える
+	station.Location.Name = "東京駅 丸の内口"
+	require.NoError(t, repo.SetTaskLocation(ctx, station))
+	got, err := repo.GetTaskLocation(ctx, taskIDs[0])
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "東京駅 丸の内口", got.Location.Name)
+	assert.InDelta(t, 139.767125, got.Location.Longitude, 1e-9)
+
+	inBounds, err := repo.ListTaskLocationsInBounds(ctx, taskDomain.BoundsAround(35.68, 139.76, 5000))
+	require.NoError(t, err)
+	require.Len(t, inBounds, 1)
+	assert.Equal(t, taskIDs[0], inBounds[0].TaskID)
+
+	byTask, err := repo.GetTaskLocations(ctx, append(taskIDs, uuid.NewString()))
+	require.NoError(t, err)
+	assert.Len(t, byTask, 2)
+
+	geofence := &taskDomain.Geofence{ID: uuid.NewString(), UserID: users[0].String(), TaskID: taskIDs[0], RadiusMeters: 200, CreatedAt: now}
+	require.NoError(t, repo.CreateGeofence(ctx, geofence))
+	// 同じユーザー・タスクのジオフェンスは1つのみ
+	assert.Error(t, repo.CreateGeofence(ctx, &taskDomain.Geofence{ID: uuid.NewString(), UserID: users[0].String(), TaskID: taskIDs[0], RadiusMeters: 300, CreatedAt: now}))
+
+	require.NoError(t, repo.UpdateGeofenceTriggeredAt(ctx, geofence.ID, now.Add(time.Minute)))
+	fetched, err := repo.GetGeofence(ctx, geofence.ID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	require.NotNil(t, fetched.LastTriggeredAt)
+	assert.True(t, now.Add(time.Minute).Equal(*fetched.LastTriggeredAt))
+
+	// タスクを削除すると場所とジオフェンスも削除される
+	require.NoError(t, taskRepo.DeleteTask(ctx, taskIDs[0]))
+	got, err = repo.GetTaskLocation(ctx, taskIDs[0])
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	geofences, err := repo.ListGeofencesByUser(ctx, users[0].String())
+	require.NoError(t, err)
+	assert.Empty(t, geofences)
+	assert.ErrorIs(t, repo.DeleteGeofence(ctx, geofence.ID), taskUseCase.ErrGeofenceNotFound)
+}
+
+func TestDeferredReminderRepository_SaveAndDeliver(t *testing.T) {
+	ctx := context.Background()
+	handler := &databaseInfra.SqlHandler{Conn: testDB}
+	taskRepo := taskDatabase.NewTaskRepository(handler, testLogger)
+	repo := taskDatabase.NewDeferredReminderRepository(handler, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	task := taskDomain.NewTask("report", "", taskDomain.PriorityMedium, taskDomain.CategoryWork, users[0].String())
+	task.ID = uuid.NewString()
+	require.NoError(t, taskRepo.CreateTask(ctx, task))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	reminder := taskDomain.NewDeferredReminder(users[0].String(), task, taskDomain.ReminderKindDueSoon)
+	reminder.ID = uuid.NewString()
+	reminder.CreatedAt = now
+	reminder.DeliverAt = now.Add(time.Hour)
+	require.NoError(t, repo.SaveDeferredReminder(ctx, reminder))
+
+	// 同じユーザー・タスク・種類の通知は置き換える
+	again := taskDomain.NewDeferredReminder(users[0].String(), task, taskDomain.ReminderKindDueSoon)
+	again.ID = uuid.NewString()
+	again.TaskTitle = "weekly report"
+	again.CreatedAt = now.Add(time.Minute)
+	again.DeliverAt = now.Add(2 * time.Hour)
+	require.NoError(t, repo.SaveDeferredReminder(ctx, again))
+
+	due, err := repo.ListDueDeferredReminders(ctx, now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	due, err = repo.ListDueDeferredReminders(ctx, now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, reminder.ID, due[0].ID)
+	assert.Equal(t, "weekly report", due[0].TaskTitle)
+	assert.Equal(t, taskDomain.ReminderKindDueSoon, due[0].Kind)
+
+	require.NoError(t, repo.DeleteDeferredReminders(ctx, []string{due[0].ID}))
+	due, err = repo.ListDueDeferredReminders(ctx, now.Add(24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestIssueLinkRepository_LinksAndAccounts(t *testing.T) {
+	ctx := context.Background()
+	handler := &databaseInfra.SqlHandler{Conn: testDB}
+	taskRepo := taskDatabase.NewTaskRepository(handler, testLogger)
+	repo := taskDatabase.NewIssueLinkRepository(handler, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	taskIDs := make([]string, 2)
+	for i := range taskIDs {
+		task := taskDomain.NewTask("release", "", taskDomain.PriorityMedium, taskDomain.CategoryWork, users[0].String())
+		task.ID = uuid.NewString()
+		require.NoError(t, taskRepo.CreateTask(ctx, task))
+		taskIDs[i] = task.ID
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	info := &taskDomain.IssueInfo{
+		Ref:   taskDomain.IssueRef{Repository: "octo/app", Number: 42},
+		Kind:  taskDomain.IssueKindPullRequest,
+		Title: "Add search",
+		State: taskDomain.IssueStateOpen,
+		URL:   "https://github.com/octo/app/pull/42",
+	}
+	links := make([]*taskDomain.IssueLink, len(taskIDs))
+	for i, taskID := range taskIDs {
+		links[i] = taskDomain.NewIssueLink(taskID, info, i == 0, users[0].String(), now)
+		links[i].ID = uuid.NewString()
+		require.NoError(t, repo.CreateIssueLink(ctx, links[i]))
+	}
+	// 同じタスクに同じIssue・Pull Requestは1つのみ
+	duplicate := taskDomain.NewIssueLink(taskIDs[0], info, true, users[0].String(), now)
+	duplicate.ID = uuid.NewString()
+	assert.Error(t, repo.CreateIssueLink(ctx, duplicate))
+
+	byIssue, err := repo.ListIssueLinksByIssue(ctx, taskDomain.IssueProviderGitHub, info.Ref)
+	require.NoError(t, err)
+	require.Len(t, byIssue, 2)
+
+	merged := *info
+	merged.State = taskDomain.IssueStateMerged
+	byIssue[0].ApplyInfo(&merged, now.Add(time.Minute))
+	require.NoError(t, repo.UpdateIssueLink(ctx, byIssue[0]))
+	got, err := repo.GetIssueLink(ctx, byIssue[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, taskDomain.IssueStateMerged, got.State)
+	assert.Equal(t, byIssue[0].CompleteOnClose, got.CompleteOnClose)
+
+	account := &taskDomain.GitHubAccount{UserID: users[0].String(), Login: "octocat", Token: "ghp_first", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.SaveGitHubAccount(ctx, account))
+	// 登録済みのトークンは置き換える
+	account.Token = "ghp_second"
+	account.UpdatedAt = now.Add(time.Hour)
+	require.NoError(t, repo.SaveGitHubAccount(ctx, account))
+	fetched, err := repo.GetGitHubAccount(ctx, users[0].String())
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	assert.Equal(t, "ghp_second", fetched.Token)
+	require.NoError(t, repo.DeleteGitHubAccount(ctx, users[0].String()))
+	fetched, err = repo.GetGitHubAccount(ctx, users[0].String())
+	require.NoError(t, err)
+	assert.Nil(t, fetched)
+
+	// タスクを削除すると関連付けも削除される
+	require.NoError(t, taskRepo.DeleteTask(ctx, taskIDs[0]))
+	remaining, err := repo.ListIssueLinksByTask(ctx, taskIDs[0])
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+	byIssue, err = repo.ListIssueLinksByIssue(ctx, taskDomain.IssueProviderGitHub, info.Ref)
+	require.NoError(t, err)
+	require.Len(t, byIssue, 1)
+	assert.Equal(t, taskIDs[1], byIssue[0].TaskID)
+}
+
+func TestTaskLinkRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	handler := &databaseInfra.SqlHandler{Conn: testDB}
+	taskRepo := taskDatabase.NewTaskRepository(handler, testLogger)
+	repo := taskDatabase.NewTaskLinkRepository(handler, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	task := taskDomain.NewTask("release", "", taskDomain.PriorityMedium, taskDomain.CategoryWork, users[0].String())
+	task.ID = uuid.NewString()
+	require.NoError(t, taskRepo.CreateTask(ctx, task))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	design, err := taskDomain.NewTaskLink(task.ID, taskDomain.TaskLinkTypeDesign, "https://www.figma.com/file/abc", "画面デザイン", users[0].String(), now.Add(time.Minute))
+	require.NoError(t, err)
+	design.ID = uuid.NewString()
+	require.NoError(t, repo.CreateTaskLink(ctx, design))
+	document, err := taskDomain.NewTaskLink(task.ID, taskDomain.TaskLinkTypeDocument, "https://docs.example.com/spec", "", users[0].String(), now)
+	require.NoError(t, err)
+	document.ID = uuid.NewString()
+	require.NoError(t, repo.CreateTaskLink(ctx, document))
+
+	links, err := repo.ListTaskLinksByTask(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, document.ID, links[0].ID)
+	assert.Equal(t, design.ID, links[1].ID)
+
+	require.NoError(t, design.Update(taskDomain.TaskLinkTypeLink, "https://www.figma.com/file/def", "新しいデザイン", now.Add(time.Hour)))
+	require.NoError(t, repo.UpdateTaskLink(ctx, design))
+	got, err := repo.GetTaskLink(ctx, design.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, taskDomain.TaskLinkTypeLink, got.Type)
+	assert.Equal(t, "https://www.figma.com/file/def", got.URL)
+	assert.Equal(t, "新しいデザイン", got.Title)
+
+	require.NoError(t, repo.DeleteTaskLink(ctx, design.ID))
+	got, err = repo.GetTaskLink(ctx, design.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// タスクを削除するとリンクも削除される
+	require.NoError(t, taskRepo.DeleteTask(ctx, task.ID))
+	links, err = repo.ListTaskLinksByTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, links)
+}
+
+func TestInboundEmailRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := taskDatabase.NewInboundEmailRepository(&databaseInfra.SqlHandler{Conn: testDB}, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	userID := users[0].String()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	address := &taskDomain.InboundAddress{
+		UserID:         userID,
+		Token:          "token1",
+		AllowedSenders: []string{"alice@example.com", "@corp.example.com"},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	require.NoError(t, repo.SaveInboundAddress(ctx, address))
+
+	// 保存し直すとトークンと差出人を置き換える
+	address.Token = "token2"
+	address.AllowedSenders = []string{"bob@example.com"}
+	address.UpdatedAt = now.Add(time.Hour)
+	require.NoError(t, repo.SaveInboundAddress(ctx, address))
+
+	got, err := repo.GetInboundAddressByToken(ctx, "token2")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, userID, got.UserID)
+	assert.Equal(t, []string{"bob@example.com"}, got.AllowedSenders)
+	got, err = repo.GetInboundAddressByToken(ctx, "token1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	received := map[string]time.Time{
+		"<old@example.com>":    now.Add(-2 * time.Hour),
+		"<recent@example.com>": now.Add(-time.Minute),
+	}
+	for messageID, receivedAt := range received {
+		require.NoError(t, repo.CreateInboundEmail(ctx, &taskDomain.InboundEmailRecord{
+			ID:         uuid.NewString(),
+			UserID:     userID,
+			MessageID:  messageID,
+			Sender:     "bob@example.com",
+			TaskID:     uuid.NewString(),
+			ReceivedAt: receivedAt,
+		}))
+	}
+	count, err := repo.CountInboundEmailsSince(ctx, userID, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	exists, err := repo.ExistsInboundEmail(ctx, userID, "<old@example.com>")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repo.ExistsInboundEmail(ctx, userID, "<other@example.com>")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, repo.DeleteInboundAddress(ctx, userID))
+	got, err = repo.GetInboundAddress(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestInvitationRepository_Expiry(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewInvitationRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+
+	// ローカル時刻で保存しても NOW() とUTCで比較される
+	tokyo := time.FixedZone("JST", 9*60*60)
+	valid := socialDomain.NewInvitation(socialDomain.InvitationTypeFriend, socialDomain.MethodURL, users[0], "", 1)
+	valid.ExpiresAt = time.Now().Add(30 * time.Minute).In(tokyo)
+	require.NoError(t, repo.CreateInvitation(ctx, valid))
+
+	expired := socialDomain.NewInvitation(socialDomain.InvitationTypeFriend, socialDomain.MethodURL, users[0], "", 1)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, repo.CreateInvitation(ctx, expired))
+
+	ok, err := repo.IsValidInvitation(ctx, valid.Code)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.IsValidInvitation(ctx, expired.Code)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	marked, err := repo.MarkExpiredInvitations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	got, err := repo.GetInvitationByID(ctx, valid.ID)
+	require.NoError(t, err)
+	assert.True(t, valid.ExpiresAt.Equal(got.ExpiresAt))
+}
+
+func TestExportRepository_ClaimAndExpire(t *testing.T) {
+	ctx := context.Background()
+	repo := takeoutDatabase.NewExportRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	first := takeoutDomain.NewExport(users[0], now.Add(-2*time.Minute))
+	require.NoError(t, repo.CreateExport(ctx, first))
+	second := takeoutDomain.NewExport(users[1], now.Add(-time.Minute))
+	require.NoError(t, repo.CreateExport(ctx, second))
+
+	active, err := repo.FindActiveExport(ctx, users[0])
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, first.ID, active.ID)
+
+	// 古い依頼から順に作成中にし、作成中のものは止まっていない限り取得しない
+	staleBefore := now.Add(-30 * time.Minute)
+	claimed, err := repo.ClaimNextExport(ctx, staleBefore, now)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, first.ID, claimed.ID)
+	assert.Equal(t, takeoutDomain.ExportProcessing, claimed.Status)
+
+	claimed, err = repo.ClaimNextExport(ctx, staleBefore, now)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, second.ID, claimed.ID)
+
+	claimed, err = repo.ClaimNextExport(ctx, staleBefore, now)
+	require.NoError(t, err)
+	assert.Nil(t, claimed)
+
+	// 作成中のまま止まった依頼は取得し直す
+	claimed, err = repo.ClaimNextExport(ctx, now.Add(time.Second), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, first.ID, claimed.ID)
+
+	claimed.Complete(takeoutDomain.ExportFileKey(users[0], claimed.ID), 1024, time.Hour, now)
+	require.NoError(t, repo.UpdateExport(ctx, claimed))
+
+	got, err := repo.GetExport(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, takeoutDomain.ExportReady, got.Status)
+	assert.Equal(t, int64(1024), got.SizeBytes)
+	assert.Equal(t, claimed.FileKey, got.FileKey)
+	require.NotNil(t, got.ExpiresAt)
+	assert.True(t, now.Add(time.Hour).Equal(*got.ExpiresAt))
+
+	active, err = repo.FindActiveExport(ctx, users[0])
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	expired, err := repo.ListExpiredExports(ctx, now.Add(30*time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+	expired, err = repo.ListExpiredExports(ctx, now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, first.ID, expired[0].ID)
+
+	exports, err := repo.ListExportsByUser(ctx, users[0], 10)
+	require.NoError(t, err)
+	require.Len(t, exports, 1)
+	assert.Equal(t, first.ID, exports[0].ID)
+}
+
+func TestImportRepository_SaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	repo := takeoutDatabase.NewImportRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	record, err := repo.FindImportedRecord(ctx, users[0], "tasks", "source-1")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	require.NoError(t, repo.SaveImportedRecord(ctx, &takeoutDomain.ImportedRecord{
+		UserID: users[0], Section: "tasks", SourceID: "source-1", TargetID: "target-1", ImportedAt: now,
+	}))
+
+	record, err = repo.FindImportedRecord(ctx, users[0], "tasks", "source-1")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "target-1", record.TargetID)
+	assert.True(t, now.Equal(record.ImportedAt))
+
+	// 対応はユーザー・セクションごとに記録する
+	record, err = repo.FindImportedRecord(ctx, users[1], "tasks", "source-1")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+	record, err = repo.FindImportedRecord(ctx, users[0], "groups", "source-1")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// 同じレコードの対応は重複して保存できない
+	assert.Error(t, repo.SaveImportedRecord(ctx, &takeoutDomain.ImportedRecord{
+		UserID: users[0], Section: "tasks", SourceID: "source-1", TargetID: "target-2", ImportedAt: now,
+	}))
+}
+
+func TestFlagRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	repo := featureFlagDatabase.NewFlagRepository(testDB, testLogger)
+
+	resetDatabase(t)
+
+	flag, err := featureFlagDomain.NewFlag("task_history")
+	require.NoError(t, err)
+	flag.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.SaveFlag(ctx, flag))
+
+	// 同じキーで保存すると ON CONFLICT で更新される
+	flag.Enabled = true
+	flag.Percentage = 25
+	flag.Users = []string{"user-1"}
+	require.NoError(t, repo.SaveFlag(ctx, flag))
+
+	got, err := repo.GetFlag(ctx, "task_history")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, 25, got.Percentage)
+	assert.Equal(t, []string{"user-1"}, got.Users)
+
+	flags, err := repo.ListFlags(ctx)
+	require.NoError(t, err)
+	assert.Len(t, flags, 1)
+}
+
+func TestFriendshipRepository_Uniqueness(t *testing.T) {
+	ctx := context.Background()
+	repo := socialDatabase.NewFriendshipRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+
+	require.NoError(t, repo.CreateFriendship(ctx, socialDomain.NewFriendship(users[0], users[1])))
+
+	// 逆向きの申請は生成列 user_pair の一意制約で重複になる
+	err := repo.CreateFriendship(ctx, socialDomain.NewFriendship(users[1], users[0]))
+	require.Error(t, err)
+	assert.True(t, isUniqueViolation(err), "expected unique violation, got %v", err)
+}
+
+func TestDigestRepositories_PendingCountsAndState(t *testing.T) {
+	ctx := context.Background()
+	friendships := socialDatabase.NewFriendshipRepository(testDB, testLogger)
+	invitations := socialDatabase.NewInvitationRepository(testDB, testLogger)
+	states := socialDatabase.NewDigestStateRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 3)
+	now := time.Now()
+
+	require.NoError(t, friendships.CreateFriendship(ctx, socialDomain.NewFriendship(users[1], users[0])))
+	require.NoError(t, friendships.CreateFriendship(ctx, socialDomain.NewFriendship(users[2], users[0])))
+
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'team', 'PROJECT', ?)", groupID.String(), users[1].String())
+	require.NoError(t, err)
+	invitation := socialDomain.NewInvitation(socialDomain.InvitationTypeGroup, socialDomain.MethodInApp, users[1], "", 24)
+	invitation.InviteeID = &users[0]
+	invitation.TargetID = &groupID
+	require.NoError(t, invitations.CreateInvitation(ctx, invitation))
+
+	// 期限切れの招待は数えない
+	expired := socialDomain.NewInvitation(socialDomain.InvitationTypeGroup, socialDomain.MethodInApp, users[2], "", 1)
+	expired.InviteeID = &users[0]
+	expired.TargetID = &groupID
+	expired.ExpiresAt = now.Add(-time.Minute)
+	require.NoError(t, invitations.CreateInvitation(ctx, expired))
+
+	requestCounts, err := friendships.CountPendingRequestsByAddressee(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{users[0]: 2}, requestCounts)
+
+	invitationCounts, err := invitations.CountPendingGroupInvitationsByInvitee(ctx, now.Add(time.Minute), now)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{users[0]: 1}, invitationCounts)
+
+	// 作成から時間が経っていない申請・招待は数えない
+	requestCounts, err = friendships.CountPendingRequestsByAddressee(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, requestCounts)
+
+	// 同じユーザーの記録は ON CONFLICT で上書きされる
+	sentAt := now.UTC().Truncate(time.Second)
+	require.NoError(t, states.SaveDigestState(ctx, &socialDomain.DigestState{UserID: users[0], NotificationID: "first", LastSentAt: sentAt}))
+	require.NoError(t, states.SaveDigestState(ctx, &socialDomain.DigestState{UserID: users[0], NotificationID: "second", LastSentAt: sentAt.Add(time.Hour)}))
+
+	list, err := states.ListDigestStates(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "second", list[0].NotificationID)
+	assert.True(t, sentAt.Add(time.Hour).Equal(list[0].LastSentAt))
+
+	require.NoError(t, states.DeleteDigestState(ctx, users[0]))
+	list, err = states.ListDigestStates(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestGroupRepository_EventRSVPs(t *testing.T) {
+	ctx := context.Background()
+	repo := groupDatabase.NewGroupRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'family', 'SCHEDULE', ?)", groupID.String(), users[0].String())
+	require.NoError(t, err)
+	eventID := uuid.NewString()
+
+	rsvp, err := repo.GetEventRSVP(ctx, groupID, eventID, users[0])
+	require.NoError(t, err)
+	assert.Nil(t, rsvp)
+
+	// 回答の変更と出席の記録は ON CONFLICT で上書きされる
+	respondedAt := time.Now().UTC().Truncate(time.Second)
+	saved := &groupDomain.EventRSVP{GroupID: groupID, EventID: eventID, UserID: users[0], Status: groupDomain.RSVPMaybe, RespondedAt: &respondedAt, UpdatedAt: respondedAt}
+	require.NoError(t, repo.SaveEventRSVP(ctx, saved))
+	attended := true
+	saved.Status = groupDomain.RSVPYes
+	saved.Attended = &attended
+	require.NoError(t, repo.SaveEventRSVP(ctx, saved))
+	require.NoError(t, repo.SaveEventRSVP(ctx, &groupDomain.EventRSVP{GroupID: groupID, EventID: uuid.NewString(), UserID: users[1], Status: groupDomain.RSVPNo, UpdatedAt: respondedAt}))
+
+	rsvp, err = repo.GetEventRSVP(ctx, groupID, eventID, users[0])
+	require.NoError(t, err)
+	require.NotNil(t, rsvp)
+	assert.Equal(t, groupDomain.RSVPYes, rsvp.Status)
+	require.NotNil(t, rsvp.Attended)
+	assert.True(t, *rsvp.Attended)
+	require.NotNil(t, rsvp.RespondedAt)
+	assert.True(t, respondedAt.Equal(*rsvp.RespondedAt))
+
+	rsvps, err := repo.ListEventRSVPs(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.Len(t, rsvps, 1)
+	rsvps, err = repo.ListGroupEventRSVPs(ctx, groupID)
+	require.NoError(t, err)
+	assert.Len(t, rsvps, 2)
+
+	reminded, err := repo.IsEventReminded(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.False(t, reminded)
+	require.NoError(t, repo.SaveEventReminder(ctx, groupID, eventID, respondedAt))
+	require.NoError(t, repo.SaveEventReminder(ctx, groupID, eventID, respondedAt.Add(time.Hour)))
+	reminded, err = repo.IsEventReminded(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.True(t, reminded)
+}
+
+func TestGroupRepository_EventRecurrences(t *testing.T) {
+	ctx := context.Background()
+	repo := groupDatabase.NewGroupRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'family', 'SCHEDULE', ?)", groupID.String(), users[0].String())
+	require.NoError(t, err)
+	eventID, endedID := uuid.NewString(), uuid.NewString()
+
+	recurrence, err := repo.GetEventRecurrence(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.Nil(t, recurrence)
+
+	// 設定の変更は ON CONFLICT で上書きされる
+	now := time.Now().UTC().Truncate(time.Second)
+	saved := &groupDomain.EventRecurrence{GroupID: groupID, EventID: eventID, Frequency: groupDomain.RecurrenceDaily, Interval: 1, Timezone: "UTC", CreatedBy: users[0], UpdatedAt: now}
+	require.NoError(t, repo.SaveEventRecurrence(ctx, saved))
+	saved.Frequency = groupDomain.RecurrenceWeekly
+	saved.Weekdays = []string{"MO", "TH"}
+	saved.Timezone = "Asia/Tokyo"
+	require.NoError(t, repo.SaveEventRecurrence(ctx, saved))
+	ended := now.Add(-time.Hour)
+	require.NoError(t, repo.SaveEventRecurrence(ctx, &groupDomain.EventRecurrence{GroupID: groupID, EventID: endedID, Frequency: groupDomain.RecurrenceDaily, Interval: 1, Until: &ended, Timezone: "UTC", CreatedBy: users[0], UpdatedAt: now}))
+
+	recurrence, err = repo.GetEventRecurrence(ctx, groupID, eventID)
+	require.NoError(t, err)
+	require.NotNil(t, recurrence)
+	assert.Equal(t, groupDomain.RecurrenceWeekly, recurrence.Frequency)
+	assert.Equal(t, []string{"MO", "TH"}, recurrence.Weekdays)
+	assert.Equal(t, "Asia/Tokyo", recurrence.Timezone)
+	assert.Nil(t, recurrence.Until)
+
+	recurrences, err := repo.ListEventRecurrences(ctx, groupID)
+	require.NoError(t, err)
+	assert.Len(t, recurrences, 2)
+	// 終了した繰り返しはリマインダーの対象にしない
+	recurrences, err = repo.ListActiveEventRecurrences(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, recurrences, 1)
+	assert.Equal(t, eventID, recurrences[0].EventID)
+
+	original := now.Add(24 * time.Hour)
+	movedTo := original.Add(2 * time.Hour)
+	exception := &groupDomain.EventException{GroupID: groupID, EventID: eventID, OriginalStartsAt: original, Cancelled: true, UpdatedBy: users[0], UpdatedAt: now}
+	require.NoError(t, repo.SaveEventException(ctx, exception))
+	exception.Cancelled = false
+	exception.StartsAt = &movedTo
+	require.NoError(t, repo.SaveEventException(ctx, exception))
+	require.NoError(t, repo.SaveEventException(ctx, &groupDomain.EventException{GroupID: groupID, EventID: eventID, OriginalStartsAt: original.Add(7 * 24 * time.Hour), Cancelled: true, UpdatedBy: users[0], UpdatedAt: now}))
+
+	exceptions, err := repo.ListEventExceptions(ctx, groupID, eventID)
+	require.NoError(t, err)
+	require.Len(t, exceptions, 2)
+	exceptions, err = repo.ListGroupEventExceptions(ctx, groupID)
+	require.NoError(t, err)
+	require.Len(t, exceptions, 2)
+	for _, e := range exceptions {
+		if e.OriginalStartsAt.Equal(original) {
+			assert.False(t, e.Cancelled)
+			require.NotNil(t, e.StartsAt)
+			assert.True(t, movedTo.Equal(*e.StartsAt))
+		}
+	}
+
+	require.NoError(t, repo.DeleteEventException(ctx, groupID, eventID, original))
+	exceptions, err = repo.ListEventExceptions(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.Len(t, exceptions, 1)
+
+	// 繰り返しを削除すると回ごとの変更も削除する
+	require.NoError(t, repo.DeleteEventRecurrence(ctx, groupID, eventID))
+	recurrence, err = repo.GetEventRecurrence(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.Nil(t, recurrence)
+	exceptions, err = repo.ListEventExceptions(ctx, groupID, eventID)
+	require.NoError(t, err)
+	assert.Empty(t, exceptions)
+}
+
+func TestGroupRepository_EventNotes(t *testing.T) {
+	ctx := context.Background()
+	repo := groupDatabase.NewGroupRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'team', 'SCHEDULE', ?)", groupID.String(), users[0].String())
+	require.NoError(t, err)
+	eventID := uuid.NewString() + "_20240603T090000Z"
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first := &groupDomain.EventNote{ID: uuid.New(), GroupID: groupID, EventID: eventID, Title: "定例会", Content: "- [ ] 資料を共有する @alice", CreatedBy: users[0], UpdatedBy: users[0], CreatedAt: now, UpdatedAt: now}
+	second := &groupDomain.EventNote{ID: uuid.New(), GroupID: groupID, EventID: eventID, Title: "補足", CreatedBy: users[1], UpdatedBy: users[1], CreatedAt: now.Add(time.Minute), UpdatedAt: now.Add(time.Minute)}
+	require.NoError(t, repo.CreateEventNote(ctx, first))
+	require.NoError(t, repo.CreateEventNote(ctx, second))
+
+	first.Title = "定例会（確定）"
+	first.UpdatedBy = users[1]
+	first.UpdatedAt = now.Add(time.Hour)
+	require.NoError(t, repo.UpdateEventNote(ctx, first))
+
+	note, err := repo.GetEventNote(ctx, groupID, first.ID)
+	require.NoError(t, err)
+	require.NotNil(t, note)
+	assert.Equal(t, "定例会（確定）", note.Title)
+	assert.Equal(t, eventID, note.EventID)
+	assert.Equal(t, users[1], note.UpdatedBy)
+	// 他のグループの議事録は取得しない
+	note, err = repo.GetEventNote(ctx, uuid.New(), first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, note)
+
+	notes, err := repo.ListEventNotes(ctx, groupID, eventID)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, first.ID, notes[0].ID)
+
+	taskID, unassignedID := uuid.NewString(), uuid.NewString()
+	require.NoError(t, repo.SaveNoteActionItem(ctx, &groupDomain.NoteActionItem{NoteID: first.ID, GroupID: groupID, TaskID: taskID, Text: "資料を共有する @alice", AssigneeID: &users[1], CreatedAt: now}))
+	require.NoError(t, repo.SaveNoteActionItem(ctx, &groupDomain.NoteActionItem{NoteID: first.ID, GroupID: groupID, TaskID: unassignedID, Text: "会議室を予約する", CreatedAt: now.Add(time.Second)}))
+
+	items, err := repo.ListNoteActionItems(ctx, first.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.NotNil(t, items[0].AssigneeID)
+	assert.Equal(t, users[1], *items[0].AssigneeID)
+	assert.Nil(t, items[1].AssigneeID)
+
+	item, err := repo.GetNoteActionItemByTask(ctx, groupID, unassignedID)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, first.ID, item.NoteID)
+
+	// 議事録を削除すると作成したタスクとの対応も削除する
+	require.NoError(t, repo.DeleteEventNote(ctx, groupID, first.ID))
+	note, err = repo.GetEventNote(ctx, groupID, first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, note)
+	item, err = repo.GetNoteActionItemByTask(ctx, groupID, taskID)
+	require.NoError(t, err)
+	assert.Nil(t, item)
+}
+
+func TestGroupRepository_EventTaskLinks(t *testing.T) {
+	ctx := context.Background()
+	repo := groupDatabase.NewGroupRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'team', 'SCHEDULE', ?)", groupID.String(), users[0].String())
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	taskID, blockID, seriesID, followUpID := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	occurrenceID := seriesID + "_20240603T090000Z"
+	require.NoError(t, repo.SaveEventTaskLink(ctx, &groupDomain.EventTaskLink{GroupID: groupID, EventID: blockID, TaskID: taskID, Kind: groupDomain.EventTaskLinkBlock, CreatedBy: users[0], CreatedAt: now}))
+	require.NoError(t, repo.SaveEventTaskLink(ctx, &groupDomain.EventTaskLink{GroupID: groupID, EventID: occurrenceID, TaskID: followUpID, Kind: groupDomain.EventTaskLinkFollowUp, CreatedBy: users[0], CreatedAt: now.Add(time.Minute)}))
+	// 同じ予定とタスクは重複して関連付けない
+	assert.Error(t, repo.SaveEventTaskLink(ctx, &groupDomain.EventTaskLink{GroupID: groupID, EventID: blockID, TaskID: taskID, Kind: groupDomain.EventTaskLinkBlock, CreatedBy: users[0], CreatedAt: now}))
+
+	links, err := repo.ListEventTaskLinks(ctx, groupID, blockID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, taskID, links[0].TaskID)
+	assert.Equal(t, groupDomain.EventTaskLinkBlock, links[0].Kind)
+	assert.Equal(t, users[0], links[0].CreatedBy)
+	links, err = repo.ListEventTaskLinks(ctx, uuid.New(), blockID)
+	require.NoError(t, err)
+	assert.Empty(t, links)
+
+	// タスクからは作業時間の予定とフォローアップの元の予定の両方をたどれる
+	links, err = repo.ListTaskLinks(ctx, taskID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, blockID, links[0].EventID)
+	links, err = repo.ListTaskLinks(ctx, blockID)
+	require.NoError(t, err)
+	assert.Len(t, links, 1)
+	// 繰り返しの予定のタスクからは回の関連付けも取得する
+	links, err = repo.ListTaskLinks(ctx, seriesID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, followUpID, links[0].TaskID)
+	assert.Equal(t, groupDomain.EventTaskLinkFollowUp, links[0].Kind)
+}
+
+func TestGroupRepository_ChatIntegrations(t *testing.T) {
+	ctx := context.Background()
+	repo := groupDatabase.NewGroupRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'team', 'PROJECT', ?)", groupID.String(), users[0].String())
+	require.NoError(t, err)
+
+	integration := groupDomain.NewChatIntegration(groupID, groupDomain.ChatPlatformDiscord, "https://discord.com/api/webhooks/1/token", users[0])
+	integration.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	integration.UpdatedAt = integration.CreatedAt
+	integration.Templates[groupDomain.ChatEventTaskCompleted] = "done: {{.task_title}}"
+	require.NoError(t, repo.SaveChatIntegration(ctx, integration))
+
+	saved, err := repo.GetChatIntegration(ctx, groupID, groupDomain.ChatPlatformDiscord)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, integration.WebhookURL, saved.WebhookURL)
+	assert.Equal(t, groupDomain.ChatEvents, saved.Events)
+	assert.Equal(t, "done: {{.task_title}}", saved.Templates[groupDomain.ChatEventTaskCompleted])
+	assert.Equal(t, groupDomain.DefaultChatSummaryHour, saved.SummaryHour)
+	assert.True(t, saved.Enabled)
+	assert.Equal(t, groupDomain.DefaultChatDueReminderHours, saved.DueReminderHours)
+	assert.Nil(t, saved.DueCheckedUntil)
+	assert.Equal(t, users[0], saved.CreatedBy)
+
+	// 同じ連携先の設定は上書きし、無効にした連携は投稿の対象にしない
+	saved.Events = []groupDomain.ChatEvent{groupDomain.ChatEventDailySummary}
+	saved.LastSummaryDate = "2024-06-03"
+	checkedUntil := time.Date(2024, 6, 4, 9, 0, 0, 0, time.UTC)
+	saved.MarkDueChecked(checkedUntil)
+	saved.Enabled = false
+	require.NoError(t, repo.SaveChatIntegration(ctx, saved))
+	integrations, err := repo.ListChatIntegrations(ctx, groupID)
+	require.NoError(t, err)
+	require.Len(t, integrations, 1)
+	assert.Equal(t, []groupDomain.ChatEvent{groupDomain.ChatEventDailySummary}, integrations[0].Events)
+	assert.Equal(t, "2024-06-03", integrations[0].LastSummaryDate)
+	require.NotNil(t, integrations[0].DueCheckedUntil)
+	assert.True(t, checkedUntil.Equal(*integrations[0].DueCheckedUntil))
+	enabled, err := repo.ListEnabledChatIntegrations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, enabled)
+
+	require.NoError(t, repo.DeleteChatIntegration(ctx, groupID, groupDomain.ChatPlatformDiscord))
+	saved, err = repo.GetChatIntegration(ctx, groupID, groupDomain.ChatPlatformDiscord)
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+}
+
+func TestGroupRepository_ScheduleCardSettings(t *testing.T) {
+	ctx := context.Background()
+	repo := groupDatabase.NewGroupRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	groupID := uuid.New()
+	_, err := testDB.Exec("INSERT INTO groups (id, name, type, owner_id) VALUES (?, 'family', 'SCHEDULE', ?)", groupID.String(), users[0].String())
+	require.NoError(t, err)
+
+	saved, err := repo.GetScheduleCardSettings(ctx, groupID)
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+
+	settings := groupDomain.NewScheduleCardSettings(groupID)
+	settings.Enabled = true
+	settings.Timezone = "Asia/Tokyo"
+	settings.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.SaveScheduleCardSettings(ctx, settings))
+
+	saved, err = repo.GetScheduleCardSettings(ctx, groupID)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.True(t, saved.Enabled)
+	assert.Equal(t, "Asia/Tokyo", saved.Timezone)
+	assert.True(t, saved.ShowTitles)
+
+	// 同じグループの設定は上書きする
+	saved.ShowTitles = false
+	saved.Enabled = false
+	require.NoError(t, repo.SaveScheduleCardSettings(ctx, saved))
+	saved, err = repo.GetScheduleCardSettings(ctx, groupID)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.False(t, saved.Enabled)
+	assert.False(t, saved.ShowTitles)
+}
+
+func TestChangeRepository_AppendChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := syncDatabase.NewChangeRepository(testDB, testLogger)
+
+	resetDatabase(t)
+
+	before, _, err := repo.GetSequence(ctx)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	changes := []*syncDomain.Change{
+		{UserID: "user-1", EntityType: "task", EntityID: "task-1", Operation: syncDomain.OperationUpsert, Data: []byte(`{"title":"a"}`), ChangedAt: now},
+		{UserID: "user-2", EntityType: "task", EntityID: "task-1", Operation: syncDomain.OperationUpsert, Data: []byte(`{"title":"a"}`), ChangedAt: now},
+	}
+	require.NoError(t, repo.AppendChanges(ctx, "task", "task-1", changes, []string{"user-1", "user-2"}))
+	// 2回目はエンティティのバージョンを ON CONFLICT で更新する
+	require.NoError(t, repo.AppendChanges(ctx, "task", "task-1", changes[:1], []string{"user-1"}))
+
+	latest, _, err := repo.GetSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before+3, latest)
+
+	version, err := repo.GetEntityVersion(ctx, "task", "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+
+	listed, err := repo.ListChanges(ctx, "user-1", before, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, before+1, listed[0].Seq)
+	assert.JSONEq(t, `{"title":"a"}`, string(listed[0].Data))
+
+	recipients, err := repo.ListRecipients(ctx, "task", "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1"}, recipients)
+
+	// 削除した範囲は GREATEST（SQLiteでは MAX）で後退しない
+	deleted, err := repo.PruneChanges(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	_, pruned, err := repo.GetSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest, pruned)
+}
+
+func TestAccountMergeRepository_MergeAccounts(t *testing.T) {
+	ctx := context.Background()
+	repo := adminDatabase.NewAccountMergeRepository(testDB, testLogger)
+
+	resetDatabase(t)
+	users := createUsers(t, 4)
+	primary, secondary, friend, other := users[0].String(), users[1].String(), users[2].String(), users[3].String()
+
+	mustExec := func(query string, args ...interface{}) {
+		t.Helper()
+		_, err := testDB.Exec(query, args...)
+		require.NoError(t, err)
+	}
+	count := func(query string, args ...interface{}) int {
+		t.Helper()
+		var n int
+		require.NoError(t, testDB.QueryRow(query, args...).Scan(&n))
+		return n
+	}
+
+	// 統合元が作成・担当したタスク（統合先も担当している）
+	mustExec("INSERT INTO tasks (id, title, created_by, assignee_id) VALUES ('task-1', 'a', ?, ?)", secondary, secondary)
+	mustExec("INSERT INTO task_assignees (task_id, user_id) VALUES ('task-1', ?), ('task-1', ?)", secondary, primary)
+	// 統合先との友達関係、統合先とも友達の相手、統合元のみの友達
+	mustExec("INSERT INTO friendships (id, requester_id, addressee_id, status) VALUES ('f-1', ?, ?, 'ACCEPTED')", primary, secondary)
+	mustExec("INSERT INTO friendships (id, requester_id, addressee_id, status) VALUES ('f-2', ?, ?, 'ACCEPTED')", friend, primary)
+	mustExec("INSERT INTO friendships (id, requester_id, addressee_id, status) VALUES ('f-3', ?, ?, 'ACCEPTED')", secondary, friend)
+	mustExec("INSERT INTO friendships (id, requester_id, addressee_id, status) VALUES ('f-4', ?, ?, 'PENDING')", other, secondary)
+	// 統合元が所有し統合先もメンバーのグループと、統合元のみがメンバーのグループ
+	mustExec("INSERT INTO groups (id, name, type, owner_id, member_count) VALUES ('g-1', 'team', 'PROJECT', ?, 2)", secondary)
+	mustExec("INSERT INTO group_members (id, group_id, user_id, role) VALUES ('m-1', 'g-1', ?, 'OWNER'), ('m-2', 'g-1', ?, 'MEMBER')", secondary, primary)
+	mustExec("INSERT INTO groups (id, name, type, owner_id, member_count) VALUES ('g-2', 'family', 'SCHEDULE', ?, 2)", friend)
+	mustExec("INSERT INTO group_members (id, group_id, user_id, role) VALUES ('m-3', 'g-2', ?, 'OWNER'), ('m-4', 'g-2', ?, 'MEMBER')", friend, secondary)
+	mustExec("INSERT INTO notifications (id, user_id, title, message) VALUES ('n-1', ?, 't', 'm')", secondary)
+
+	t.Run("dry runでは件数のみ返し変更しない", func(t *testing.T) {
+		counts, err := repo.MergeAccounts(ctx, users[0], users[1], true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, counts.CreatedTasks)
+		assert.Equal(t, 1, counts.AssignedTasks)
+		assert.Equal(t, 1, counts.DuplicateTaskAssignees)
+		assert.Equal(t, 0, counts.TaskAssignees)
+		assert.Equal(t, 2, counts.DuplicateFriendships)
+		assert.Equal(t, 1, counts.Friendships)
+		assert.Equal(t, 1, counts.DuplicateGroupMemberships)
+		assert.Equal(t, 1, counts.GroupMemberships)
+		assert.Equal(t, 1, counts.OwnedGroups)
+		assert.Equal(t, 1, counts.Notifications)
+
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM tasks WHERE created_by = ?", secondary))
+		assert.Equal(t, 4, count("SELECT COUNT(*) FROM friendships"))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM notifications WHERE user_id = ?", secondary))
+	})
+
+	t.Run("統合元のデータを統合先に付け替える", func(t *testing.T) {
+		_, err := repo.MergeAccounts(ctx, users[0], users[1], false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM tasks WHERE created_by = ? AND assignee_id = ?", primary, primary))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM task_assignees WHERE task_id = 'task-1'"))
+		assert.Equal(t, 0, count("SELECT COUNT(*) FROM friendships WHERE requester_id = ? OR addressee_id = ?", secondary, secondary))
+		assert.Equal(t, 2, count("SELECT COUNT(*) FROM friendships WHERE requester_id = ? OR addressee_id = ?", primary, primary))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM friendships WHERE requester_id = ? AND addressee_id = ? AND status = 'PENDING'", other, primary))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM groups WHERE id = 'g-1' AND owner_id = ? AND member_count = 1", primary))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM group_members WHERE group_id = 'g-1' AND user_id = ? AND role = 'OWNER'", primary))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM group_members WHERE group_id = 'g-2' AND user_id = ?", primary))
+		assert.Equal(t, 0, count("SELECT COUNT(*) FROM group_members WHERE user_id = ?", secondary))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM notifications WHERE user_id = ?", primary))
+	})
+
+	t.Run("再実行では何も付け替えない", func(t *testing.T) {
+		counts, err := repo.MergeAccounts(ctx, users[0], users[1], false)
+		require.NoError(t, err)
+		assert.Zero(t, *counts)
+	})
+}
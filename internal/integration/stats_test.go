@@ -0,0 +1,258 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	databaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
+	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaskRepositories() (usecase.TaskRepository, usecase.StatsRepository) {
+	handler := &databaseInfra.SqlHandler{Conn: testDB}
+	return taskDatabase.NewTaskRepository(handler, testLogger), taskDatabase.NewTaskStatsRepository(handler, testLogger)
+}
+
+// createTask は作成日時を指定してタスクを保存する（TIMESTAMP列の精度に合わせて秒単位で指定する）
+func createTask(t *testing.T, repo usecase.TaskRepository, createdBy uuid.UUID, createdAt time.Time, modify func(*domain.Task)) *domain.Task {
+	t.Helper()
+
+	task := domain.NewTask("task", "", domain.PriorityMedium, domain.CategoryWork, createdBy.String())
+	task.ID = uuid.New().String()
+	task.CreatedAt = createdAt
+	task.UpdatedAt = createdAt
+	if modify != nil {
+		modify(task)
+	}
+	require.NoError(t, repo.CreateTask(context.Background(), task))
+	return task
+}
+
+func taskIDs(tasks []*domain.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestTaskStatsRepository_GetTasksByDateRange(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, statsRepo := newTaskRepositories()
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	user, other := users[0], users[1]
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	inRange := createTask(t, taskRepo, user, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), nil)
+	atStart := createTask(t, taskRepo, user, start, nil)
+	atEnd := createTask(t, taskRepo, user, end, nil)
+	dueInRange := createTask(t, taskRepo, user, time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC), func(task *domain.Task) {
+		due := time.Date(2026, 3, 20, 18, 0, 0, 0, time.UTC)
+		task.DueDate = &due
+	})
+	assigned := createTask(t, taskRepo, other, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), func(task *domain.Task) {
+		task.AddAssignee(user.String())
+		task.UpdatedAt = task.CreatedAt
+	})
+
+	// 範囲外・他ユーザーのタスクは含まない
+	createTask(t, taskRepo, user, start.Add(-time.Second), nil)
+	createTask(t, taskRepo, user, end.Add(time.Second), nil)
+	createTask(t, taskRepo, other, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), nil)
+
+	tasks, err := statsRepo.GetTasksByDateRange(ctx, user.String(), start, end)
+	require.NoError(t, err)
+	assert.ElementsMatch(t,
+		[]string{inRange.ID, atStart.ID, atEnd.ID, dueInRange.ID, assigned.ID},
+		taskIDs(tasks))
+
+	// 作成日時の降順
+	for i := 1; i < len(tasks); i++ {
+		assert.False(t, tasks[i].CreatedAt.After(tasks[i-1].CreatedAt), "tasks must be ordered by created_at DESC")
+	}
+}
+
+func TestTaskStatsRepository_GetCompletedTasksByDateRange(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, statsRepo := newTaskRepositories()
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	user := users[0]
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+	createdAt := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	completeAt := func(completedAt time.Time) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.SetStatus(domain.TaskStatusDone)
+			task.CompletedAt = &completedAt
+			task.UpdatedAt = completedAt
+		}
+	}
+
+	first := createTask(t, taskRepo, user, createdAt, completeAt(start))
+	last := createTask(t, taskRepo, user, createdAt, completeAt(end))
+	middle := createTask(t, taskRepo, user, createdAt, completeAt(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)))
+	createTask(t, taskRepo, user, createdAt, completeAt(end.Add(time.Second)))
+	createTask(t, taskRepo, user, createdAt, func(task *domain.Task) {
+		task.SetStatus(domain.TaskStatusInProgress)
+	})
+
+	tasks, err := statsRepo.GetCompletedTasksByDateRange(ctx, user.String(), start, end)
+	require.NoError(t, err)
+	// 完了日時の昇順
+	assert.Equal(t, []string{first.ID, middle.ID, last.ID}, taskIDs(tasks))
+}
+
+func TestTaskStatsRepository_GetScheduledTasksByDateRange(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, statsRepo := newTaskRepositories()
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	user, other := users[0], users[1]
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+	createdAt := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	schedule := func(startDate, dueDate *time.Time) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.StartDate = startDate
+			task.DueDate = dueDate
+		}
+	}
+	inMarch := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	inApril := time.Date(2026, 4, 2, 18, 0, 0, 0, time.UTC)
+	inFebruary := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+
+	due := createTask(t, taskRepo, user, createdAt, schedule(nil, &end))
+	starting := createTask(t, taskRepo, user, createdAt, schedule(&inMarch, &inApril))
+	assigned := createTask(t, taskRepo, other, createdAt, func(task *domain.Task) {
+		schedule(nil, &inMarch)(task)
+		task.AddAssignee(user.String())
+	})
+
+	// 期間をまたぐだけのタスク・予定のないタスク・他ユーザーのタスクは含まない
+	createTask(t, taskRepo, user, createdAt, schedule(&inFebruary, &inApril))
+	createTask(t, taskRepo, user, inMarch, nil)
+	createTask(t, taskRepo, other, createdAt, schedule(nil, &inMarch))
+
+	tasks, err := statsRepo.GetScheduledTasksByDateRange(ctx, user.String(), start, end)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{due.ID, starting.ID, assigned.ID}, taskIDs(tasks))
+	for _, task := range tasks {
+		if task.ID == starting.ID {
+			require.NotNil(t, task.StartDate)
+			assert.True(t, inMarch.Equal(*task.StartDate))
+		}
+	}
+}
+
+func TestTaskStatsRepository_GetOverdueTasksCount(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, statsRepo := newTaskRepositories()
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	user, other := users[0], users[1]
+
+	now := time.Now().UTC().Truncate(time.Second)
+	withStatusAndDue := func(status domain.TaskStatus, due time.Time) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.SetStatus(status)
+			task.DueDate = &due
+		}
+	}
+
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusTodo, now.Add(-time.Hour)))
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusInProgress, now.Add(-time.Hour)))
+	createTask(t, taskRepo, other, now.Add(-48*time.Hour), func(task *domain.Task) {
+		withStatusAndDue(domain.TaskStatusTodo, now.Add(-time.Hour))(task)
+		task.AddAssignee(user.String())
+	})
+
+	// 完了済み・期限前・期限なしのタスクは含まない
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusDone, now.Add(-time.Hour)))
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusTodo, now.Add(24*time.Hour)))
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), nil)
+
+	overdue, err := statsRepo.GetOverdueTasksCount(ctx, user.String())
+	require.NoError(t, err)
+	assert.Equal(t, 3, overdue)
+}
+
+func TestTaskRepository_GetOverdueTasksByUser(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, _ := newTaskRepositories()
+
+	resetDatabase(t)
+	users := createUsers(t, 2)
+	user, other := users[0], users[1]
+
+	now := time.Now().UTC().Truncate(time.Second)
+	withStatusAndDue := func(status domain.TaskStatus, due time.Time) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.SetStatus(status)
+			task.DueDate = &due
+		}
+	}
+
+	created := createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusTodo, now.Add(-time.Hour)))
+	assigned := createTask(t, taskRepo, other, now.Add(-48*time.Hour), func(task *domain.Task) {
+		withStatusAndDue(domain.TaskStatusInProgress, now.Add(-40*24*time.Hour))(task)
+		task.AddAssignee(user.String())
+	})
+
+	// 他のユーザーのタスク・完了済み・期限前のタスクは含まない
+	createTask(t, taskRepo, other, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusTodo, now.Add(-time.Hour)))
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusDone, now.Add(-time.Hour)))
+	createTask(t, taskRepo, user, now.Add(-48*time.Hour), withStatusAndDue(domain.TaskStatusTodo, now.Add(24*time.Hour)))
+
+	tasks, err := taskRepo.GetOverdueTasksByUser(ctx, user.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{assigned.ID, created.ID}, taskIDs(tasks))
+	require.Len(t, tasks[0].Assignees, 1)
+}
+
+func TestTaskStatsRepository_GetTasksByDueDate(t *testing.T) {
+	ctx := context.Background()
+	taskRepo, statsRepo := newTaskRepositories()
+
+	resetDatabase(t)
+	users := createUsers(t, 1)
+	user := users[0]
+
+	day := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	dueAt := func(due time.Time, priority domain.Priority) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.DueDate = &due
+			task.Priority = priority
+		}
+	}
+
+	// その日の0:00:00〜23:59:59を期限日として扱い、期限の昇順で返す
+	startOfDay := createTask(t, taskRepo, user, createdAt, dueAt(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), domain.PriorityLow))
+	endOfDay := createTask(t, taskRepo, user, createdAt, dueAt(time.Date(2026, 3, 15, 23, 59, 59, 0, time.UTC), domain.PriorityHigh))
+	createTask(t, taskRepo, user, createdAt, dueAt(time.Date(2026, 3, 14, 23, 59, 59, 0, time.UTC), domain.PriorityHigh))
+	createTask(t, taskRepo, user, createdAt, dueAt(time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), domain.PriorityHigh))
+
+	tasks, err := statsRepo.GetTasksByDueDate(ctx, user.String(), day)
+	require.NoError(t, err)
+	assert.Equal(t, []string{startOfDay.ID, endOfDay.ID}, taskIDs(tasks))
+}
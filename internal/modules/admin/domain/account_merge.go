@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// MergeAccount は統合の対象のアカウント
+type MergeAccount struct {
+	ID       string `json:"id" example:"6f1c2a7e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"`
+	Username string `json:"username" example:"taro"`
+	Email    string `json:"email" example:"taro@example.com"`
+	Active   bool   `json:"active" example:"true"`
+} // @name AdminMergeAccount
+
+// MergeCounts はアカウントの統合で付け替える件数（dry runの場合は付け替える予定の件数）
+// Duplicate* は統合先のアカウントにも同じ関係があるため、統合元のものを削除する件数
+type MergeCounts struct {
+	CreatedTasks              int `json:"created_tasks" example:"42"`
+	AssignedTasks             int `json:"assigned_tasks" example:"10"`
+	TaskAssignees             int `json:"task_assignees" example:"12"`
+	DuplicateTaskAssignees    int `json:"duplicate_task_assignees" example:"1"`
+	Friendships               int `json:"friendships" example:"5"`
+	DuplicateFriendships      int `json:"duplicate_friendships" example:"2"` // 統合先との友達関係を含む
+	GroupMemberships          int `json:"group_memberships" example:"3"`
+	DuplicateGroupMemberships int `json:"duplicate_group_memberships" example:"1"`
+	OwnedGroups               int `json:"owned_groups" example:"1"`
+	Notifications             int `json:"notifications" example:"120"`
+	SSOIdentities             int `json:"sso_identities" example:"1"`
+} // @name AdminMergeCounts
+
+// AccountMerge はアカウントの統合の結果（dry runの場合はプレビュー）
+type AccountMerge struct {
+	Primary   MergeAccount `json:"primary"`
+	Secondary MergeAccount `json:"secondary"`
+	DryRun    bool         `json:"dry_run" example:"true"`
+	Counts    MergeCounts  `json:"counts"`
+	MergedAt  *time.Time   `json:"merged_at,omitempty"`
+} // @name AdminAccountMerge
+
+// グループでの役割（グループモジュールの MemberRole と同じ値）
+const (
+	groupRoleOwner = "OWNER"
+	groupRoleAdmin = "ADMIN"
+	groupRoleGuest = "GUEST"
+)
+
+// groupRoleRank は役割の強さ（カスタムロールはメンバーと同じに扱う）
+func groupRoleRank(role string) int {
+	switch role {
+	case groupRoleOwner:
+		return 3
+	case groupRoleAdmin:
+		return 2
+	case groupRoleGuest:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// StrongerGroupRole は両方のアカウントが同じグループのメンバーの場合に、統合後に残す役割を返す
+// 統合元の役割の方が強い場合のみ統合元の役割にする
+func StrongerGroupRole(primaryRole, secondaryRole string) string {
+	if groupRoleRank(secondaryRole) > groupRoleRank(primaryRole) {
+		return secondaryRole
+	}
+	return primaryRole
+}
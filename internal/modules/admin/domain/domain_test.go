@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotificationDelivery(t *testing.T) {
+	t.Run("failure rate is based on attempted deliveries", func(t *testing.T) {
+		delivery := NewNotificationDelivery(map[string]int{
+			"PENDING": 10,
+			"SENT":    50,
+			"READ":    40,
+			"FAILED":  25,
+		})
+
+		assert.Equal(t, 125, delivery.Total)
+		assert.Equal(t, 90, delivery.Delivered)
+		assert.Equal(t, 25, delivery.Failed)
+		assert.Equal(t, 10, delivery.Pending)
+		assert.InDelta(t, 25.0/115.0, delivery.FailureRate, 1e-9)
+	})
+
+	t.Run("no attempts", func(t *testing.T) {
+		delivery := NewNotificationDelivery(map[string]int{"PENDING": 3})
+
+		assert.Equal(t, 3, delivery.Total)
+		assert.Zero(t, delivery.FailureRate)
+	})
+}
+
+func TestFillDailyCounts(t *testing.T) {
+	from := time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	counts := FillDailyCounts(map[string]int{"2024-02-29": 4, "2024-03-02": 1}, from, 4)
+
+	assert.Equal(t, []DailyCount{
+		{Date: "2024-02-28", Count: 0},
+		{Date: "2024-02-29", Count: 4},
+		{Date: "2024-03-01", Count: 0},
+		{Date: "2024-03-02", Count: 1},
+	}, counts)
+	assert.Equal(t, 5, SumDailyCounts(counts))
+}
+
+func TestStrongerGroupRole(t *testing.T) {
+	assert.Equal(t, "OWNER", StrongerGroupRole("MEMBER", "OWNER"))
+	assert.Equal(t, "ADMIN", StrongerGroupRole("ADMIN", "MEMBER"))
+	assert.Equal(t, "ADMIN", StrongerGroupRole("GUEST", "ADMIN"))
+	// カスタムロールはメンバーと同じ強さのため、統合先の役割を残す
+	assert.Equal(t, "MEMBER", StrongerGroupRole("MEMBER", "7d5c1c8e-0000-0000-0000-000000000000"))
+	assert.Equal(t, "7d5c1c8e-0000-0000-0000-000000000000", StrongerGroupRole("GUEST", "7d5c1c8e-0000-0000-0000-000000000000"))
+}
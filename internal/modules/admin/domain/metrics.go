@@ -0,0 +1,101 @@
+package domain
+
+import "time"
+
+// DateLayout は日ごとの集計で使う日付の形式（日付はUTCで区切る）
+const DateLayout = "2006-01-02"
+
+// DailyCount は1日あたりの件数
+type DailyCount struct {
+	Date  string `json:"date" example:"2024-06-01"`
+	Count int    `json:"count" example:"12"`
+} // @name AdminDailyCount
+
+// UserGrowth はユーザー数と日ごとの新規登録数
+type UserGrowth struct {
+	TotalUsers int          `json:"total_users" example:"1520"`
+	NewUsers   []DailyCount `json:"new_users"`
+} // @name AdminUserGrowth
+
+// ActiveUsers は最終ログイン日時から判定したアクティブユーザー数
+type ActiveUsers struct {
+	DAU int `json:"dau" example:"320"`  // 直近24時間にログインしたユーザー数
+	WAU int `json:"wau" example:"910"`  // 直近7日間にログインしたユーザー数
+	MAU int `json:"mau" example:"1300"` // 直近30日間にログインしたユーザー数
+} // @name AdminActiveUsers
+
+// TaskCreation は期間中のタスク作成数
+type TaskCreation struct {
+	Total int          `json:"total" example:"4210"`
+	Daily []DailyCount `json:"daily"`
+} // @name AdminTaskCreation
+
+// NotificationDelivery は期間中の通知の配信状況
+type NotificationDelivery struct {
+	Total       int     `json:"total" example:"10000"`
+	Delivered   int     `json:"delivered" example:"9800"` // SENT・READ
+	Failed      int     `json:"failed" example:"150"`
+	Pending     int     `json:"pending" example:"50"`
+	FailureRate float64 `json:"failure_rate" example:"0.015"` // 配信を試みた通知のうち失敗した割合
+} // @name AdminNotificationDelivery
+
+// NewNotificationDelivery はステータスごとの通知数から配信状況を作成する
+func NewNotificationDelivery(countsByStatus map[string]int) NotificationDelivery {
+	delivery := NotificationDelivery{
+		Delivered: countsByStatus["SENT"] + countsByStatus["READ"],
+		Failed:    countsByStatus["FAILED"],
+		Pending:   countsByStatus["PENDING"],
+	}
+	for _, count := range countsByStatus {
+		delivery.Total += count
+	}
+	if attempted := delivery.Delivered + delivery.Failed; attempted > 0 {
+		delivery.FailureRate = float64(delivery.Failed) / float64(attempted)
+	}
+	return delivery
+}
+
+// TableUsage はテーブルごとのストレージ使用量
+type TableUsage struct {
+	Table      string `json:"table" example:"tasks"`
+	Rows       int64  `json:"rows" example:"52000"` // 概算（データベースの統計情報）
+	DataBytes  int64  `json:"data_bytes" example:"16384000"`
+	IndexBytes int64  `json:"index_bytes" example:"4096000"`
+} // @name AdminTableUsage
+
+// StorageUsage はデータベースと添付ファイルのストレージ使用量
+type StorageUsage struct {
+	Tables          []TableUsage `json:"tables"` // 使用量の多い順
+	DatabaseBytes   int64        `json:"database_bytes" example:"20480000"`
+	AttachmentBytes int64        `json:"attachment_bytes" example:"104857600"`
+} // @name AdminStorageUsage
+
+// Dashboard は管理者ダッシュボードの指標
+type Dashboard struct {
+	From          string               `json:"from" example:"2024-05-03"`
+	To            string               `json:"to" example:"2024-06-01"`
+	Users         UserGrowth           `json:"users"`
+	ActiveUsers   ActiveUsers          `json:"active_users"`
+	Tasks         TaskCreation         `json:"tasks"`
+	Notifications NotificationDelivery `json:"notifications"`
+	Storage       StorageUsage         `json:"storage"`
+} // @name AdminDashboard
+
+// FillDailyCounts はfromから days 日分の日ごとの件数を返す（件数がない日は0）
+func FillDailyCounts(counts map[string]int, from time.Time, days int) []DailyCount {
+	result := make([]DailyCount, 0, days)
+	for i := 0; i < days; i++ {
+		date := from.AddDate(0, 0, i).Format(DateLayout)
+		result = append(result, DailyCount{Date: date, Count: counts[date]})
+	}
+	return result
+}
+
+// SumDailyCounts は日ごとの件数の合計を返す
+func SumDailyCounts(counts []DailyCount) int {
+	total := 0
+	for _, count := range counts {
+		total += count.Count
+	}
+	return total
+}
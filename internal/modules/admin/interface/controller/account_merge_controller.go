@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	"github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+)
+
+// AccountMergeController はアカウントの統合のHTTPリクエストを処理するコントローラー
+type AccountMergeController struct {
+	mergeService *usecase.AccountMergeService
+}
+
+// NewAccountMergeController は新しいAccountMergeControllerを作成する
+func NewAccountMergeController(mergeService *usecase.AccountMergeService) *AccountMergeController {
+	return &AccountMergeController{
+		mergeService: mergeService,
+	}
+}
+
+// MergeAccountsRequest はアカウントの統合のリクエスト
+type MergeAccountsRequest struct {
+	PrimaryUserID   string `json:"primary_user_id" binding:"required,uuid" example:"6f1c2a7e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"`
+	SecondaryUserID string `json:"secondary_user_id" binding:"required,uuid" example:"0a9b8c7d-6e5f-4a3b-2c1d-0e9f8a7b6c5d"`
+	DryRun          bool   `json:"dry_run" example:"true"`
+} // @name AdminMergeAccountsRequest
+
+// AccountMergeResponse はアカウントの統合のレスポンス
+type AccountMergeResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.AccountMerge `json:"data"`
+} // @name AdminAccountMergeResponse
+
+// MergeAccounts アカウントの統合
+// @Summary      アカウントの統合
+// @Description  二重に登録されたアカウントを統合します。統合元（secondary）のタスク・友達・グループのメンバー・通知・SSOの紐付けを1つのトランザクションで統合先（primary）に付け替え、統合元のアカウントを無効化します。`dry_run`の場合は何も変更せず、付け替える件数を返します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body MergeAccountsRequest true "統合するアカウント"
+// @Security     BearerAuth
+// @Success      200 {object} AccountMergeResponse "統合成功（dry_runの場合はプレビュー）"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "アカウントが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/accounts/merge [post]
+func (c *AccountMergeController) MergeAccounts(ctx *gin.Context) {
+	var req MergeAccountsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	merge, err := c.mergeService.MergeAccounts(ctx, uuid.MustParse(req.PrimaryUserID), uuid.MustParse(req.SecondaryUserID), req.DryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidParameter):
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{Success: false, Error: "REQUEST_ERROR", Message: err.Error()})
+		case errors.Is(err, usecase.ErrAccountNotFound):
+			ctx.JSON(http.StatusNotFound, ErrorResponse{Success: false, Error: "NOT_FOUND", Message: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, ErrorResponse{Success: false, Error: "INTERNAL_ERROR", Message: "Failed to merge accounts"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AccountMergeResponse{Success: true, Data: *merge})
+}
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	"github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+)
+
+// AdminController は管理者向けのシステム指標のHTTPリクエストを処理するコントローラー
+type AdminController struct {
+	adminService *usecase.AdminService
+}
+
+// NewAdminController は新しいAdminControllerを作成する
+func NewAdminController(adminService *usecase.AdminService) *AdminController {
+	return &AdminController{
+		adminService: adminService,
+	}
+}
+
+// ErrorResponse は管理者APIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"REQUEST_ERROR"`
+	Message string `json:"message" example:"days must be between 1 and 365"`
+} // @name AdminErrorResponse
+
+// DashboardResponse は管理者ダッシュボードのレスポンス
+type DashboardResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    domain.Dashboard `json:"data"`
+} // @name AdminDashboardResponse
+
+// UserGrowthResponse はユーザー数の推移のレスポンス
+type UserGrowthResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    domain.UserGrowth `json:"data"`
+} // @name AdminUserGrowthResponse
+
+// ActiveUsersResponse はアクティブユーザー数のレスポンス
+type ActiveUsersResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    domain.ActiveUsers `json:"data"`
+} // @name AdminActiveUsersResponse
+
+// TaskCreationResponse はタスク作成数のレスポンス
+type TaskCreationResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.TaskCreation `json:"data"`
+} // @name AdminTaskCreationResponse
+
+// NotificationDeliveryResponse は通知の配信状況のレスポンス
+type NotificationDeliveryResponse struct {
+	Success bool                        `json:"success" example:"true"`
+	Data    domain.NotificationDelivery `json:"data"`
+} // @name AdminNotificationDeliveryResponse
+
+// StorageUsageResponse はストレージ使用量のレスポンス
+type StorageUsageResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.StorageUsage `json:"data"`
+} // @name AdminStorageUsageResponse
+
+// GetDashboard 管理者ダッシュボード
+// @Summary      管理者ダッシュボード
+// @Description  ユーザー数の推移・DAU/WAU/MAU・日ごとのタスク作成数・通知の失敗率・ストレージ使用量をまとめて取得します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        days query int false "集計日数（今日を含む）" default(30) minimum(1) maximum(365)
+// @Security     BearerAuth
+// @Success      200 {object} DashboardResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/dashboard [get]
+func (c *AdminController) GetDashboard(ctx *gin.Context) {
+	days, ok := parseDays(ctx)
+	if !ok {
+		return
+	}
+
+	dashboard, err := c.adminService.GetDashboard(ctx, days)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, DashboardResponse{Success: true, Data: *dashboard})
+}
+
+// GetUserGrowth ユーザー数の推移
+// @Summary      ユーザー数の推移
+// @Description  ユーザー数と日ごとの新規登録数を取得します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        days query int false "集計日数（今日を含む）" default(30) minimum(1) maximum(365)
+// @Security     BearerAuth
+// @Success      200 {object} UserGrowthResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/stats/users [get]
+func (c *AdminController) GetUserGrowth(ctx *gin.Context) {
+	days, ok := parseDays(ctx)
+	if !ok {
+		return
+	}
+
+	growth, err := c.adminService.GetUserGrowth(ctx, days)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, UserGrowthResponse{Success: true, Data: *growth})
+}
+
+// GetActiveUsers アクティブユーザー数
+// @Summary      アクティブユーザー数
+// @Description  直近24時間・7日間・30日間にログインしたユーザー数（DAU/WAU/MAU）を取得します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} ActiveUsersResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/stats/active-users [get]
+func (c *AdminController) GetActiveUsers(ctx *gin.Context) {
+	active, err := c.adminService.GetActiveUsers(ctx)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ActiveUsersResponse{Success: true, Data: *active})
+}
+
+// GetTaskCreation タスク作成数
+// @Summary      タスク作成数
+// @Description  日ごとのタスク作成数と期間中の合計を取得します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        days query int false "集計日数（今日を含む）" default(30) minimum(1) maximum(365)
+// @Security     BearerAuth
+// @Success      200 {object} TaskCreationResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/stats/tasks [get]
+func (c *AdminController) GetTaskCreation(ctx *gin.Context) {
+	days, ok := parseDays(ctx)
+	if !ok {
+		return
+	}
+
+	creation, err := c.adminService.GetTaskCreation(ctx, days)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskCreationResponse{Success: true, Data: *creation})
+}
+
+// GetNotificationDelivery 通知の配信状況
+// @Summary      通知の配信状況
+// @Description  期間中に作成された通知の配信済み・失敗・未送信の件数と失敗率を取得します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        days query int false "集計日数（今日を含む）" default(30) minimum(1) maximum(365)
+// @Security     BearerAuth
+// @Success      200 {object} NotificationDeliveryResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/stats/notifications [get]
+func (c *AdminController) GetNotificationDelivery(ctx *gin.Context) {
+	days, ok := parseDays(ctx)
+	if !ok {
+		return
+	}
+
+	delivery, err := c.adminService.GetNotificationDelivery(ctx, days)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NotificationDeliveryResponse{Success: true, Data: *delivery})
+}
+
+// GetStorageUsage ストレージ使用量
+// @Summary      ストレージ使用量
+// @Description  テーブルごとのデータベース使用量と添付ファイルの合計サイズを取得します（管理者のみ）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} StorageUsageResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/stats/storage [get]
+func (c *AdminController) GetStorageUsage(ctx *gin.Context) {
+	usage, err := c.adminService.GetStorageUsage(ctx)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StorageUsageResponse{Success: true, Data: *usage})
+}
+
+// parseDays はクエリパラメータの集計日数を取得する（範囲の検証はサービスで行う）
+func parseDays(ctx *gin.Context) (int, bool) {
+	value := ctx.Query("days")
+	if value == "" {
+		return usecase.DefaultMetricsDays, true
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "days must be an integer",
+		})
+		return 0, false
+	}
+	return days, true
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidParameter) {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+		Success: false,
+		Error:   "INTERNAL_ERROR",
+		Message: "Failed to get metrics",
+	})
+}
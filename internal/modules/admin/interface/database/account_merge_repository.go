@@ -0,0 +1,273 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	adminUsecase "github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// AccountMergeRepository はアカウントの統合のデータベースリポジトリ実装
+// 各モジュールのテーブルを1つのトランザクションで更新する（MySQL・PostgreSQL・SQLiteで同じクエリを使う）
+type AccountMergeRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewAccountMergeRepository は新しいAccountMergeRepositoryを作成する
+func NewAccountMergeRepository(db *sql.DB, logger logger.Logger) adminUsecase.AccountMergeRepository {
+	return &AccountMergeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// MergeAccounts は統合元のタスク・友達・グループのメンバー・通知・SSOの紐付けを統合先に付け替える
+// 統合先にも同じ関係がある場合は統合先のものを残し、統合元のものを削除する。dryRun の場合は件数を数えた後にロールバックする
+func (r *AccountMergeRepository) MergeAccounts(ctx context.Context, primaryID, secondaryID uuid.UUID, dryRun bool) (*domain.MergeCounts, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	primary, secondary := primaryID.String(), secondaryID.String()
+	counts := &domain.MergeCounts{}
+
+	// タスク
+	if counts.CreatedTasks, err = r.exec(ctx, tx, "reassign created tasks",
+		"UPDATE tasks SET created_by = ? WHERE created_by = ?", primary, secondary); err != nil {
+		return nil, err
+	}
+	if counts.AssignedTasks, err = r.exec(ctx, tx, "reassign assigned tasks",
+		"UPDATE tasks SET assignee_id = ? WHERE assignee_id = ?", primary, secondary); err != nil {
+		return nil, err
+	}
+	if counts.DuplicateTaskAssignees, counts.TaskAssignees, err = r.mergeTaskAssignees(ctx, tx, primary, secondary); err != nil {
+		return nil, err
+	}
+
+	// 友達
+	if counts.DuplicateFriendships, counts.Friendships, err = r.mergeFriendships(ctx, tx, primary, secondary); err != nil {
+		return nil, err
+	}
+
+	// グループ
+	if counts.DuplicateGroupMemberships, counts.GroupMemberships, err = r.mergeGroupMemberships(ctx, tx, primary, secondary); err != nil {
+		return nil, err
+	}
+	if counts.OwnedGroups, err = r.exec(ctx, tx, "reassign owned groups",
+		"UPDATE groups SET owner_id = ? WHERE owner_id = ?", primary, secondary); err != nil {
+		return nil, err
+	}
+
+	// 通知・SSOの紐付け
+	if counts.Notifications, err = r.exec(ctx, tx, "reassign notifications",
+		"UPDATE notifications SET user_id = ? WHERE user_id = ?", primary, secondary); err != nil {
+		return nil, err
+	}
+	if counts.SSOIdentities, err = r.exec(ctx, tx, "reassign sso identities",
+		"UPDATE sso_identities SET user_id = ? WHERE user_id = ?", primary, secondary); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return counts, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return counts, nil
+}
+
+// mergeTaskAssignees は統合元の担当を付け替える（統合先も担当しているタスクは統合元の担当を削除する）
+func (r *AccountMergeRepository) mergeTaskAssignees(ctx context.Context, tx *sql.Tx, primary, secondary string) (int, int, error) {
+	taskIDs, err := r.queryStrings(ctx, tx, "find duplicate task assignees", `
+		SELECT s.task_id
+		FROM task_assignees s
+		JOIN task_assignees p ON p.task_id = s.task_id AND p.user_id = ?
+		WHERE s.user_id = ?
+	`, primary, secondary)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, taskID := range taskIDs {
+		if _, err := r.exec(ctx, tx, "delete duplicate task assignee",
+			"DELETE FROM task_assignees WHERE task_id = ? AND user_id = ?", taskID, secondary); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	moved, err := r.exec(ctx, tx, "reassign task assignees",
+		"UPDATE task_assignees SET user_id = ? WHERE user_id = ?", primary, secondary)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(taskIDs), moved, nil
+}
+
+// mergeFriendships は統合元の友達関係を付け替える
+// 統合先との友達関係と、統合先にも友達関係（申請中・ブロックを含む）がある相手との関係は削除する
+func (r *AccountMergeRepository) mergeFriendships(ctx context.Context, tx *sql.Tx, primary, secondary string) (int, int, error) {
+	removed, err := r.exec(ctx, tx, "delete friendship between merged accounts", `
+		DELETE FROM friendships
+		WHERE (requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)
+	`, primary, secondary, secondary, primary)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	primaryFriends, err := r.queryStrings(ctx, tx, "find friendships of primary account", `
+		SELECT CASE WHEN requester_id = ? THEN addressee_id ELSE requester_id END
+		FROM friendships
+		WHERE requester_id = ? OR addressee_id = ?
+	`, primary, primary, primary)
+	if err != nil {
+		return 0, 0, err
+	}
+	known := make(map[string]bool, len(primaryFriends))
+	for _, id := range primaryFriends {
+		known[id] = true
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, CASE WHEN requester_id = ? THEN addressee_id ELSE requester_id END
+		FROM friendships
+		WHERE requester_id = ? OR addressee_id = ?
+	`, secondary, secondary, secondary)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to find friendships of secondary account", logger.Error(err))
+		return 0, 0, fmt.Errorf("failed to find friendships of secondary account: %w", err)
+	}
+	var duplicates []string
+	for rows.Next() {
+		var id, other string
+		if err := rows.Scan(&id, &other); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("failed to scan friendship: %w", err)
+		}
+		if known[other] {
+			duplicates = append(duplicates, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to iterate friendships: %w", err)
+	}
+
+	for _, id := range duplicates {
+		if _, err := r.exec(ctx, tx, "delete duplicate friendship",
+			"DELETE FROM friendships WHERE id = ?", id); err != nil {
+			return 0, 0, err
+		}
+	}
+	removed += len(duplicates)
+
+	requested, err := r.exec(ctx, tx, "reassign friendship requests",
+		"UPDATE friendships SET requester_id = ? WHERE requester_id = ?", primary, secondary)
+	if err != nil {
+		return 0, 0, err
+	}
+	addressed, err := r.exec(ctx, tx, "reassign received friendships",
+		"UPDATE friendships SET addressee_id = ? WHERE addressee_id = ?", primary, secondary)
+	if err != nil {
+		return 0, 0, err
+	}
+	return removed, requested + addressed, nil
+}
+
+// mergeGroupMemberships は統合元のグループのメンバーを付け替える
+// 統合先もメンバーのグループは統合元のメンバーを削除し、統合先の役割を強い方の役割にする
+func (r *AccountMergeRepository) mergeGroupMemberships(ctx context.Context, tx *sql.Tx, primary, secondary string) (int, int, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT s.id, s.group_id, s.role, p.role
+		FROM group_members s
+		JOIN group_members p ON p.group_id = s.group_id AND p.user_id = ?
+		WHERE s.user_id = ?
+	`, primary, secondary)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to find duplicate group members", logger.Error(err))
+		return 0, 0, fmt.Errorf("failed to find duplicate group members: %w", err)
+	}
+	type duplicate struct {
+		id, groupID, secondaryRole, primaryRole string
+	}
+	var duplicates []duplicate
+	for rows.Next() {
+		var d duplicate
+		if err := rows.Scan(&d.id, &d.groupID, &d.secondaryRole, &d.primaryRole); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		duplicates = append(duplicates, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to iterate group members: %w", err)
+	}
+
+	for _, d := range duplicates {
+		if role := domain.StrongerGroupRole(d.primaryRole, d.secondaryRole); role != d.primaryRole {
+			if _, err := r.exec(ctx, tx, "update group member role",
+				"UPDATE group_members SET role = ? WHERE group_id = ? AND user_id = ?", role, d.groupID, primary); err != nil {
+				return 0, 0, err
+			}
+		}
+		if _, err := r.exec(ctx, tx, "delete duplicate group member",
+			"DELETE FROM group_members WHERE id = ?", d.id); err != nil {
+			return 0, 0, err
+		}
+		if _, err := r.exec(ctx, tx, "update group member count",
+			"UPDATE groups SET member_count = member_count - 1, version = version + 1 WHERE id = ?", d.groupID); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	moved, err := r.exec(ctx, tx, "reassign group members",
+		"UPDATE group_members SET user_id = ? WHERE user_id = ?", primary, secondary)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(duplicates), moved, nil
+}
+
+// exec は更新クエリを実行し、更新した行数を返す
+func (r *AccountMergeRepository) exec(ctx context.Context, tx *sql.Tx, action, query string, args ...interface{}) (int, error) {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to "+action, logger.Error(err))
+		return 0, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return int(affected), nil
+}
+
+// queryStrings は1列の文字列を返すクエリを実行する
+func (r *AccountMergeRepository) queryStrings(ctx context.Context, tx *sql.Tx, action, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to "+action, logger.Error(err))
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to %s: %w", action, err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return values, nil
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	adminUsecase "github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// MetricsRepository はシステム全体の指標のデータベースリポジトリ実装
+type MetricsRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewMetricsRepository は新しいMetricsRepositoryを作成する
+func NewMetricsRepository(db *sql.DB, logger logger.Logger) adminUsecase.MetricsRepository {
+	return &MetricsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CountUsers はユーザー数を取得する
+func (r *MetricsRepository) CountUsers(ctx context.Context) (int, error) {
+	return r.count(ctx, `SELECT COUNT(*) FROM users`)
+}
+
+// CountUsersCreatedByDay は期間中の日ごとの新規登録数を取得する
+func (r *MetricsRepository) CountUsersCreatedByDay(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	query := `
+		SELECT DATE_FORMAT(created_at, '%Y-%m-%d') AS day, COUNT(*)
+		FROM users
+		WHERE created_at >= ? AND created_at < ?
+		GROUP BY day
+	`
+	return r.countByKey(ctx, query, from, to)
+}
+
+// CountUsersLoggedInSince は指定日時以降にログインしたユーザー数を取得する
+func (r *MetricsRepository) CountUsersLoggedInSince(ctx context.Context, since time.Time) (int, error) {
+	return r.count(ctx, `SELECT COUNT(*) FROM users WHERE last_login >= ?`, since)
+}
+
+// CountTasksCreatedByDay は期間中の日ごとのタスク作成数を取得する
+func (r *MetricsRepository) CountTasksCreatedByDay(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	query := `
+		SELECT DATE_FORMAT(created_at, '%Y-%m-%d') AS day, COUNT(*)
+		FROM tasks
+		WHERE created_at >= ? AND created_at < ?
+		GROUP BY day
+	`
+	return r.countByKey(ctx, query, from, to)
+}
+
+// CountNotificationsByStatus は期間中に作成された通知のステータスごとの件数を取得する
+func (r *MetricsRepository) CountNotificationsByStatus(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	query := `
+		SELECT status, COUNT(*)
+		FROM notifications
+		WHERE created_at >= ? AND created_at < ?
+		GROUP BY status
+	`
+	return r.countByKey(ctx, query, from, to)
+}
+
+// GetStorageUsage はテーブルごとの使用量（information_schemaの統計情報）と添付ファイルの合計サイズを取得する
+func (r *MetricsRepository) GetStorageUsage(ctx context.Context) (*domain.StorageUsage, error) {
+	query := `
+		SELECT table_name, COALESCE(table_rows, 0), COALESCE(data_length, 0), COALESCE(index_length, 0)
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE()
+		ORDER BY data_length + index_length DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query table usage", logger.Error(err))
+		return nil, fmt.Errorf("failed to query table usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := &domain.StorageUsage{Tables: []domain.TableUsage{}}
+	for rows.Next() {
+		var table domain.TableUsage
+		if err := rows.Scan(&table.Table, &table.Rows, &table.DataBytes, &table.IndexBytes); err != nil {
+			return nil, fmt.Errorf("failed to scan table usage: %w", err)
+		}
+		usage.Tables = append(usage.Tables, table)
+		usage.DatabaseBytes += table.DataBytes + table.IndexBytes
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate table usage: %w", err)
+	}
+
+	if err := r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(file_size), 0) FROM task_attachments`).Scan(&usage.AttachmentBytes); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to sum attachment sizes", logger.Error(err))
+		return nil, fmt.Errorf("failed to sum attachment sizes: %w", err)
+	}
+
+	return usage, nil
+}
+
+// count は件数を1つ返すクエリを実行する
+func (r *MetricsRepository) count(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count rows", logger.Error(err))
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	return count, nil
+}
+
+// countByKey はキーと件数の組を返すクエリを実行する
+func (r *MetricsRepository) countByKey(ctx context.Context, query string, args ...interface{}) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to aggregate rows", logger.Error(err))
+		return nil, fmt.Errorf("failed to aggregate rows: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan aggregate: %w", err)
+		}
+		counts[key] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate aggregates: %w", err)
+	}
+	return counts, nil
+}
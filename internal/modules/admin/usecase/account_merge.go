@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var ErrAccountNotFound = errors.New("account not found")
+
+// AccountMergeService は二重に登録されたアカウント（メールアドレスとSSOなど）を1つに統合するサービス
+// 統合元（secondary）のタスク・友達・グループのメンバー・通知・SSOの紐付けを統合先（primary）に付け替え、統合元のアカウントを無効化する
+type AccountMergeService struct {
+	Repository AccountMergeRepository
+	Accounts   AccountDirectory
+	Logger     logger.Logger
+
+	now func() time.Time
+}
+
+// NewAccountMergeService はAccountMergeServiceのコンストラクタ
+func NewAccountMergeService(repo AccountMergeRepository, accounts AccountDirectory, logger logger.Logger) *AccountMergeService {
+	return &AccountMergeService{
+		Repository: repo,
+		Accounts:   accounts,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// MergeAccounts は secondaryID のアカウントを primaryID のアカウントに統合する
+// dryRun の場合は何も変更せず、付け替える件数のみを返す
+func (s *AccountMergeService) MergeAccounts(ctx context.Context, primaryID, secondaryID uuid.UUID, dryRun bool) (*domain.AccountMerge, error) {
+	if primaryID == secondaryID {
+		return nil, fmt.Errorf("%w: primary and secondary accounts must be different", ErrInvalidParameter)
+	}
+
+	primary, err := s.findAccount(ctx, primaryID, "primary")
+	if err != nil {
+		return nil, err
+	}
+	if !primary.Active {
+		return nil, fmt.Errorf("%w: primary account is deactivated", ErrInvalidParameter)
+	}
+	secondary, err := s.findAccount(ctx, secondaryID, "secondary")
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.Repository.MergeAccounts(ctx, primaryID, secondaryID, dryRun)
+	if err != nil {
+		return nil, s.failed(ctx, "merge accounts", err)
+	}
+
+	merge := &domain.AccountMerge{
+		Primary:   *primary,
+		Secondary: *secondary,
+		DryRun:    dryRun,
+		Counts:    *counts,
+	}
+	if dryRun {
+		return merge, nil
+	}
+
+	// データの付け替えはコミット済みのため、無効化に失敗した場合はエラーを返して再実行できるようにする（再実行時の付け替えは0件になる）
+	if err := s.Accounts.DeactivateMergedAccount(ctx, secondaryID); err != nil {
+		return nil, s.failed(ctx, "deactivate merged account", err)
+	}
+	mergedAt := s.now()
+	merge.MergedAt = &mergedAt
+	merge.Secondary.Active = false
+
+	s.Logger.WithContext(ctx).Info("Accounts merged",
+		logger.String("primary_user_id", primaryID.String()),
+		logger.String("secondary_user_id", secondaryID.String()),
+		logger.Int("created_tasks", counts.CreatedTasks),
+		logger.Int("friendships", counts.Friendships),
+		logger.Int("group_memberships", counts.GroupMemberships),
+		logger.Int("notifications", counts.Notifications))
+	return merge, nil
+}
+
+// findAccount は統合の対象のアカウントを取得する
+func (s *AccountMergeService) findAccount(ctx context.Context, userID uuid.UUID, role string) (*domain.MergeAccount, error) {
+	account, err := s.Accounts.FindAccount(ctx, userID)
+	if err != nil {
+		return nil, s.failed(ctx, "find "+role+" account", err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("%w: %s account %s", ErrAccountNotFound, role, userID)
+	}
+	return account, nil
+}
+
+func (s *AccountMergeService) failed(ctx context.Context, action string, err error) error {
+	s.Logger.WithContext(ctx).Error("Failed to "+action, logger.Error(err))
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
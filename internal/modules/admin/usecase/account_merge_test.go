@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	"github.com/hryt430/Yotei+/internal/modules/admin/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+func newTestMergeService(t *testing.T) (*AccountMergeService, *mocks.MockAccountMergeRepository, *mocks.MockAccountDirectory) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockAccountMergeRepository(ctrl)
+	accounts := mocks.NewMockAccountDirectory(ctrl)
+	service := NewAccountMergeService(repo, accounts, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.now = func() time.Time { return testNow }
+	return service, repo, accounts
+}
+
+func TestAccountMergeService_MergeAccounts(t *testing.T) {
+	primaryID, secondaryID := uuid.New(), uuid.New()
+	primary := &domain.MergeAccount{ID: primaryID.String(), Username: "taro", Active: true}
+	secondary := &domain.MergeAccount{ID: secondaryID.String(), Username: "taro_sso", Active: true}
+	counts := &domain.MergeCounts{CreatedTasks: 3, Friendships: 2, DuplicateFriendships: 1, Notifications: 5}
+
+	t.Run("dry run does not deactivate the secondary account", func(t *testing.T) {
+		service, repo, accounts := newTestMergeService(t)
+		accounts.EXPECT().FindAccount(gomock.Any(), primaryID).Return(primary, nil)
+		accounts.EXPECT().FindAccount(gomock.Any(), secondaryID).Return(secondary, nil)
+		repo.EXPECT().MergeAccounts(gomock.Any(), primaryID, secondaryID, true).Return(counts, nil)
+
+		merge, err := service.MergeAccounts(context.Background(), primaryID, secondaryID, true)
+
+		require.NoError(t, err)
+		assert.True(t, merge.DryRun)
+		assert.Equal(t, *counts, merge.Counts)
+		assert.True(t, merge.Secondary.Active)
+		assert.Nil(t, merge.MergedAt)
+	})
+
+	t.Run("merge deactivates the secondary account", func(t *testing.T) {
+		service, repo, accounts := newTestMergeService(t)
+		accounts.EXPECT().FindAccount(gomock.Any(), primaryID).Return(primary, nil)
+		accounts.EXPECT().FindAccount(gomock.Any(), secondaryID).Return(secondary, nil)
+		repo.EXPECT().MergeAccounts(gomock.Any(), primaryID, secondaryID, false).Return(counts, nil)
+		accounts.EXPECT().DeactivateMergedAccount(gomock.Any(), secondaryID).Return(nil)
+
+		merge, err := service.MergeAccounts(context.Background(), primaryID, secondaryID, false)
+
+		require.NoError(t, err)
+		assert.False(t, merge.DryRun)
+		assert.False(t, merge.Secondary.Active)
+		require.NotNil(t, merge.MergedAt)
+		assert.Equal(t, testNow, *merge.MergedAt)
+	})
+
+	t.Run("same account", func(t *testing.T) {
+		service, _, _ := newTestMergeService(t)
+
+		_, err := service.MergeAccounts(context.Background(), primaryID, primaryID, true)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("deactivated primary account", func(t *testing.T) {
+		service, _, accounts := newTestMergeService(t)
+		accounts.EXPECT().FindAccount(gomock.Any(), primaryID).Return(&domain.MergeAccount{ID: primaryID.String()}, nil)
+
+		_, err := service.MergeAccounts(context.Background(), primaryID, secondaryID, false)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("secondary account not found", func(t *testing.T) {
+		service, _, accounts := newTestMergeService(t)
+		accounts.EXPECT().FindAccount(gomock.Any(), primaryID).Return(primary, nil)
+		accounts.EXPECT().FindAccount(gomock.Any(), secondaryID).Return(nil, nil)
+
+		_, err := service.MergeAccounts(context.Background(), primaryID, secondaryID, false)
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+
+	t.Run("repository error does not deactivate the secondary account", func(t *testing.T) {
+		service, repo, accounts := newTestMergeService(t)
+		accounts.EXPECT().FindAccount(gomock.Any(), primaryID).Return(primary, nil)
+		accounts.EXPECT().FindAccount(gomock.Any(), secondaryID).Return(secondary, nil)
+		repo.EXPECT().MergeAccounts(gomock.Any(), primaryID, secondaryID, false).Return(nil, errors.New("deadlock"))
+
+		_, err := service.MergeAccounts(context.Background(), primaryID, secondaryID, false)
+		assert.Error(t, err)
+	})
+}
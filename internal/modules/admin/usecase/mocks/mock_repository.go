@@ -0,0 +1,218 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/hryt430/Yotei+/internal/modules/admin/domain"
+)
+
+// MockMetricsRepository is a mock of MetricsRepository interface.
+type MockMetricsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsRepositoryMockRecorder
+}
+
+// MockMetricsRepositoryMockRecorder is the mock recorder for MockMetricsRepository.
+type MockMetricsRepositoryMockRecorder struct {
+	mock *MockMetricsRepository
+}
+
+// NewMockMetricsRepository creates a new mock instance.
+func NewMockMetricsRepository(ctrl *gomock.Controller) *MockMetricsRepository {
+	mock := &MockMetricsRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsRepository) EXPECT() *MockMetricsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountNotificationsByStatus mocks base method.
+func (m *MockMetricsRepository) CountNotificationsByStatus(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNotificationsByStatus", ctx, from, to)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNotificationsByStatus indicates an expected call of CountNotificationsByStatus.
+func (mr *MockMetricsRepositoryMockRecorder) CountNotificationsByStatus(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNotificationsByStatus", reflect.TypeOf((*MockMetricsRepository)(nil).CountNotificationsByStatus), ctx, from, to)
+}
+
+// CountTasksCreatedByDay mocks base method.
+func (m *MockMetricsRepository) CountTasksCreatedByDay(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasksCreatedByDay", ctx, from, to)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasksCreatedByDay indicates an expected call of CountTasksCreatedByDay.
+func (mr *MockMetricsRepositoryMockRecorder) CountTasksCreatedByDay(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasksCreatedByDay", reflect.TypeOf((*MockMetricsRepository)(nil).CountTasksCreatedByDay), ctx, from, to)
+}
+
+// CountUsers mocks base method.
+func (m *MockMetricsRepository) CountUsers(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockMetricsRepositoryMockRecorder) CountUsers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockMetricsRepository)(nil).CountUsers), ctx)
+}
+
+// CountUsersCreatedByDay mocks base method.
+func (m *MockMetricsRepository) CountUsersCreatedByDay(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsersCreatedByDay", ctx, from, to)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsersCreatedByDay indicates an expected call of CountUsersCreatedByDay.
+func (mr *MockMetricsRepositoryMockRecorder) CountUsersCreatedByDay(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersCreatedByDay", reflect.TypeOf((*MockMetricsRepository)(nil).CountUsersCreatedByDay), ctx, from, to)
+}
+
+// CountUsersLoggedInSince mocks base method.
+func (m *MockMetricsRepository) CountUsersLoggedInSince(ctx context.Context, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsersLoggedInSince", ctx, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsersLoggedInSince indicates an expected call of CountUsersLoggedInSince.
+func (mr *MockMetricsRepositoryMockRecorder) CountUsersLoggedInSince(ctx, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersLoggedInSince", reflect.TypeOf((*MockMetricsRepository)(nil).CountUsersLoggedInSince), ctx, since)
+}
+
+// GetStorageUsage mocks base method.
+func (m *MockMetricsRepository) GetStorageUsage(ctx context.Context) (*domain.StorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageUsage", ctx)
+	ret0, _ := ret[0].(*domain.StorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageUsage indicates an expected call of GetStorageUsage.
+func (mr *MockMetricsRepositoryMockRecorder) GetStorageUsage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageUsage", reflect.TypeOf((*MockMetricsRepository)(nil).GetStorageUsage), ctx)
+}
+
+// MockAccountMergeRepository is a mock of AccountMergeRepository interface.
+type MockAccountMergeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountMergeRepositoryMockRecorder
+}
+
+// MockAccountMergeRepositoryMockRecorder is the mock recorder for MockAccountMergeRepository.
+type MockAccountMergeRepositoryMockRecorder struct {
+	mock *MockAccountMergeRepository
+}
+
+// NewMockAccountMergeRepository creates a new mock instance.
+func NewMockAccountMergeRepository(ctrl *gomock.Controller) *MockAccountMergeRepository {
+	mock := &MockAccountMergeRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountMergeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountMergeRepository) EXPECT() *MockAccountMergeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// MergeAccounts mocks base method.
+func (m *MockAccountMergeRepository) MergeAccounts(ctx context.Context, primaryID, secondaryID uuid.UUID, dryRun bool) (*domain.MergeCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeAccounts", ctx, primaryID, secondaryID, dryRun)
+	ret0, _ := ret[0].(*domain.MergeCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeAccounts indicates an expected call of MergeAccounts.
+func (mr *MockAccountMergeRepositoryMockRecorder) MergeAccounts(ctx, primaryID, secondaryID, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeAccounts", reflect.TypeOf((*MockAccountMergeRepository)(nil).MergeAccounts), ctx, primaryID, secondaryID, dryRun)
+}
+
+// MockAccountDirectory is a mock of AccountDirectory interface.
+type MockAccountDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDirectoryMockRecorder
+}
+
+// MockAccountDirectoryMockRecorder is the mock recorder for MockAccountDirectory.
+type MockAccountDirectoryMockRecorder struct {
+	mock *MockAccountDirectory
+}
+
+// NewMockAccountDirectory creates a new mock instance.
+func NewMockAccountDirectory(ctrl *gomock.Controller) *MockAccountDirectory {
+	mock := &MockAccountDirectory{ctrl: ctrl}
+	mock.recorder = &MockAccountDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDirectory) EXPECT() *MockAccountDirectoryMockRecorder {
+	return m.recorder
+}
+
+// DeactivateMergedAccount mocks base method.
+func (m *MockAccountDirectory) DeactivateMergedAccount(ctx context.Context, secondaryID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateMergedAccount", ctx, secondaryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateMergedAccount indicates an expected call of DeactivateMergedAccount.
+func (mr *MockAccountDirectoryMockRecorder) DeactivateMergedAccount(ctx, secondaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateMergedAccount", reflect.TypeOf((*MockAccountDirectory)(nil).DeactivateMergedAccount), ctx, secondaryID)
+}
+
+// FindAccount mocks base method.
+func (m *MockAccountDirectory) FindAccount(ctx context.Context, userID uuid.UUID) (*domain.MergeAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAccount", ctx, userID)
+	ret0, _ := ret[0].(*domain.MergeAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAccount indicates an expected call of FindAccount.
+func (mr *MockAccountDirectoryMockRecorder) FindAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAccount", reflect.TypeOf((*MockAccountDirectory)(nil).FindAccount), ctx, userID)
+}
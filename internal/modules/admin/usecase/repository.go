@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+)
+
+// MetricsRepository はシステム全体の指標を集計するリポジトリインターフェース
+// 日ごとの集計はUTCの日付（YYYY-MM-DD）をキーとし、件数がない日は含めない
+type MetricsRepository interface {
+	// ユーザー
+	CountUsers(ctx context.Context) (int, error)
+	CountUsersCreatedByDay(ctx context.Context, from, to time.Time) (map[string]int, error)
+	CountUsersLoggedInSince(ctx context.Context, since time.Time) (int, error)
+
+	// タスク
+	CountTasksCreatedByDay(ctx context.Context, from, to time.Time) (map[string]int, error)
+
+	// 通知（ステータスごとの件数）
+	CountNotificationsByStatus(ctx context.Context, from, to time.Time) (map[string]int, error)
+
+	// ストレージ
+	GetStorageUsage(ctx context.Context) (*domain.StorageUsage, error)
+}
+
+// AccountMergeRepository はアカウントの統合でタスク・友達・グループ・通知を付け替えるリポジトリインターフェース
+// 付け替えは1つのトランザクションで行い、dryRun の場合は件数を数えた後にロールバックする
+type AccountMergeRepository interface {
+	MergeAccounts(ctx context.Context, primaryID, secondaryID uuid.UUID, dryRun bool) (*domain.MergeCounts, error)
+}
+
+// AccountDirectory は統合するアカウントを参照・無効化する（認証モジュールのユーザー）
+type AccountDirectory interface {
+	// FindAccount はアカウントを取得する（存在しない場合は nil, nil）
+	FindAccount(ctx context.Context, userID uuid.UUID) (*domain.MergeAccount, error)
+	// DeactivateMergedAccount は統合元のアカウントを無効化し、ログインできないようにする
+	DeactivateMergedAccount(ctx context.Context, secondaryID uuid.UUID) error
+}
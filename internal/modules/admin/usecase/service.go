@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// 集計期間（日数）
+const (
+	DefaultMetricsDays = 30
+	MaxMetricsDays     = 365
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// AdminService は管理者向けのシステム指標を扱うサービス
+type AdminService struct {
+	MetricsRepository MetricsRepository
+	Logger            logger.Logger
+
+	now func() time.Time
+}
+
+// NewAdminService はAdminServiceのコンストラクタ
+func NewAdminService(metricsRepo MetricsRepository, logger logger.Logger) *AdminService {
+	return &AdminService{
+		MetricsRepository: metricsRepo,
+		Logger:            logger,
+		now:               time.Now,
+	}
+}
+
+// GetDashboard は直近days日間（今日を含む）の指標をまとめて取得する
+func (s *AdminService) GetDashboard(ctx context.Context, days int) (*domain.Dashboard, error) {
+	from, to, err := s.period(days)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.GetUserGrowth(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.GetActiveUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.GetTaskCreation(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := s.GetNotificationDelivery(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := s.GetStorageUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Dashboard{
+		From:          from.Format(domain.DateLayout),
+		To:            to.Add(-time.Nanosecond).Format(domain.DateLayout),
+		Users:         *users,
+		ActiveUsers:   *active,
+		Tasks:         *tasks,
+		Notifications: *notifications,
+		Storage:       *storage,
+	}, nil
+}
+
+// GetUserGrowth はユーザー数と直近days日間の日ごとの新規登録数を取得する
+func (s *AdminService) GetUserGrowth(ctx context.Context, days int) (*domain.UserGrowth, error) {
+	from, to, err := s.period(days)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.MetricsRepository.CountUsers(ctx)
+	if err != nil {
+		return nil, s.failed(ctx, "count users", err)
+	}
+	counts, err := s.MetricsRepository.CountUsersCreatedByDay(ctx, from, to)
+	if err != nil {
+		return nil, s.failed(ctx, "count new users", err)
+	}
+
+	return &domain.UserGrowth{
+		TotalUsers: total,
+		NewUsers:   domain.FillDailyCounts(counts, from, days),
+	}, nil
+}
+
+// GetActiveUsers は直近24時間・7日間・30日間にログインしたユーザー数を取得する
+func (s *AdminService) GetActiveUsers(ctx context.Context) (*domain.ActiveUsers, error) {
+	now := s.now()
+	var active domain.ActiveUsers
+	for _, window := range []struct {
+		since time.Time
+		count *int
+	}{
+		{now.Add(-24 * time.Hour), &active.DAU},
+		{now.AddDate(0, 0, -7), &active.WAU},
+		{now.AddDate(0, 0, -30), &active.MAU},
+	} {
+		count, err := s.MetricsRepository.CountUsersLoggedInSince(ctx, window.since)
+		if err != nil {
+			return nil, s.failed(ctx, "count active users", err)
+		}
+		*window.count = count
+	}
+	return &active, nil
+}
+
+// GetTaskCreation は直近days日間の日ごとのタスク作成数を取得する
+func (s *AdminService) GetTaskCreation(ctx context.Context, days int) (*domain.TaskCreation, error) {
+	from, to, err := s.period(days)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.MetricsRepository.CountTasksCreatedByDay(ctx, from, to)
+	if err != nil {
+		return nil, s.failed(ctx, "count created tasks", err)
+	}
+
+	daily := domain.FillDailyCounts(counts, from, days)
+	return &domain.TaskCreation{
+		Total: domain.SumDailyCounts(daily),
+		Daily: daily,
+	}, nil
+}
+
+// GetNotificationDelivery は直近days日間に作成された通知の配信状況を取得する
+func (s *AdminService) GetNotificationDelivery(ctx context.Context, days int) (*domain.NotificationDelivery, error) {
+	from, to, err := s.period(days)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.MetricsRepository.CountNotificationsByStatus(ctx, from, to)
+	if err != nil {
+		return nil, s.failed(ctx, "count notifications", err)
+	}
+
+	delivery := domain.NewNotificationDelivery(counts)
+	return &delivery, nil
+}
+
+// GetStorageUsage はデータベースと添付ファイルのストレージ使用量を取得する
+func (s *AdminService) GetStorageUsage(ctx context.Context) (*domain.StorageUsage, error) {
+	usage, err := s.MetricsRepository.GetStorageUsage(ctx)
+	if err != nil {
+		return nil, s.failed(ctx, "get storage usage", err)
+	}
+	return usage, nil
+}
+
+// period は今日を含む直近days日間の期間 [from, to) をUTCの日付で返す
+func (s *AdminService) period(days int) (time.Time, time.Time, error) {
+	if days < 1 || days > MaxMetricsDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidParameter, MaxMetricsDays)
+	}
+	now := s.now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	return to.AddDate(0, 0, -days), to, nil
+}
+
+func (s *AdminService) failed(ctx context.Context, action string, err error) error {
+	s.Logger.WithContext(ctx).Error("Failed to "+action, logger.Error(err))
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
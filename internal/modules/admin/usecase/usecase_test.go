@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	"github.com/hryt430/Yotei+/internal/modules/admin/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testNow = time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*AdminService, *mocks.MockMetricsRepository) {
+	repo := mocks.NewMockMetricsRepository(gomock.NewController(t))
+	service := NewAdminService(repo, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.now = func() time.Time { return testNow }
+	return service, repo
+}
+
+func TestAdminService_GetDashboard(t *testing.T) {
+	service, repo := newTestService(t)
+	from := time.Date(2024, 5, 26, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().CountUsers(gomock.Any()).Return(120, nil)
+	repo.EXPECT().CountUsersCreatedByDay(gomock.Any(), from, to).Return(map[string]int{"2024-05-30": 3}, nil)
+	repo.EXPECT().CountUsersLoggedInSince(gomock.Any(), testNow.Add(-24*time.Hour)).Return(10, nil)
+	repo.EXPECT().CountUsersLoggedInSince(gomock.Any(), testNow.AddDate(0, 0, -7)).Return(40, nil)
+	repo.EXPECT().CountUsersLoggedInSince(gomock.Any(), testNow.AddDate(0, 0, -30)).Return(90, nil)
+	repo.EXPECT().CountTasksCreatedByDay(gomock.Any(), from, to).Return(map[string]int{"2024-05-26": 5, "2024-06-01": 7}, nil)
+	repo.EXPECT().CountNotificationsByStatus(gomock.Any(), from, to).Return(map[string]int{"SENT": 9, "FAILED": 1}, nil)
+	repo.EXPECT().GetStorageUsage(gomock.Any()).Return(&domain.StorageUsage{DatabaseBytes: 2048}, nil)
+
+	dashboard, err := service.GetDashboard(context.Background(), 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2024-05-26", dashboard.From)
+	assert.Equal(t, "2024-06-01", dashboard.To)
+	assert.Equal(t, 120, dashboard.Users.TotalUsers)
+	require.Len(t, dashboard.Users.NewUsers, 7)
+	assert.Equal(t, 3, dashboard.Users.NewUsers[4].Count)
+	assert.Equal(t, domain.ActiveUsers{DAU: 10, WAU: 40, MAU: 90}, dashboard.ActiveUsers)
+	assert.Equal(t, 12, dashboard.Tasks.Total)
+	assert.InDelta(t, 0.1, dashboard.Notifications.FailureRate, 1e-9)
+	assert.Equal(t, int64(2048), dashboard.Storage.DatabaseBytes)
+}
+
+func TestAdminService_InvalidDays(t *testing.T) {
+	service, _ := newTestService(t)
+
+	for _, days := range []int{0, -1, MaxMetricsDays + 1} {
+		_, err := service.GetTaskCreation(context.Background(), days)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "days=%d", days)
+	}
+}
+
+func TestAdminService_RepositoryError(t *testing.T) {
+	service, repo := newTestService(t)
+	repo.EXPECT().CountNotificationsByStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+	_, err := service.GetNotificationDelivery(context.Background(), 30)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidParameter)
+}
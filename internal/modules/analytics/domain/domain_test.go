@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrubProperties(t *testing.T) {
+	t.Run("properties that identify a person are removed", func(t *testing.T) {
+		scrubbed := ScrubProperties(map[string]interface{}{
+			"email":        "alice@example.com",
+			"user_name":    "alice",
+			"task_title":   "Buy milk",
+			"description":  "notes",
+			"client-ip":    "192.0.2.1",
+			"feature":      "share_links",
+			"priority":     "HIGH",
+			"has_due_date": true,
+			"count":        3,
+		})
+
+		assert.Equal(t, map[string]interface{}{
+			"feature":      "share_links",
+			"priority":     "HIGH",
+			"has_due_date": true,
+			"count":        3,
+		}, scrubbed)
+	})
+
+	t.Run("emails and ip addresses inside values are masked", func(t *testing.T) {
+		scrubbed := ScrubProperties(map[string]interface{}{
+			"source":  "forwarded by bob@example.com from 203.0.113.9",
+			"filters": []string{"assignee:carol@example.com"},
+		})
+
+		assert.Equal(t, "forwarded by [email] from [ip]", scrubbed["source"])
+		assert.Equal(t, []interface{}{"assignee:[email]"}, scrubbed["filters"])
+	})
+
+	t.Run("nested properties are scrubbed", func(t *testing.T) {
+		scrubbed := ScrubProperties(map[string]interface{}{
+			"filter": map[string]interface{}{"status": "TODO", "query": "secret project"},
+		})
+
+		assert.Equal(t, map[string]interface{}{"status": "TODO"}, scrubbed["filter"])
+	})
+
+	t.Run("long values are truncated and unknown types are dropped", func(t *testing.T) {
+		long := make([]rune, 300)
+		for i := range long {
+			long[i] = 'あ'
+		}
+		scrubbed := ScrubProperties(map[string]interface{}{
+			"source": string(long),
+			"task":   struct{ Title string }{Title: "Buy milk"},
+		})
+
+		assert.Len(t, []rune(scrubbed["source"].(string)), maxPropertyLength)
+		assert.NotContains(t, scrubbed, "task")
+	})
+}
+
+func TestSampling(t *testing.T) {
+	t.Run("the same user always gets the same decision", func(t *testing.T) {
+		sampling := Sampling{Default: 0.5}
+		for i := 0; i < 20; i++ {
+			id := AnonymousID([]byte("key"), string(rune('a'+i)))
+			assert.Equal(t, sampling.Sampled(id, "task_created"), sampling.Sampled(id, "task_created"))
+		}
+	})
+
+	t.Run("the rate is roughly respected", func(t *testing.T) {
+		sampling := Sampling{Default: 1, Rates: map[string]float64{"feature_used": 0.1}}
+		sampled := 0
+		for i := 0; i < 10000; i++ {
+			if sampling.Sampled(AnonymousID([]byte("key"), string(rune(i))), "feature_used") {
+				sampled++
+			}
+		}
+		assert.InDelta(t, 1000, sampled, 200)
+		assert.True(t, sampling.Sampled("anyone", "task_created"))
+	})
+
+	t.Run("zero disables an event", func(t *testing.T) {
+		assert.False(t, Sampling{Default: 0}.Sampled("anyone", "task_created"))
+	})
+}
+
+func TestParseSampleRates(t *testing.T) {
+	rates, err := ParseSampleRates("session_started=1, feature_used=10%,task_created=0.5")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"session_started": 1, "feature_used": 0.1, "task_created": 0.5}, rates)
+
+	for _, spec := range []string{"page_viewed=1", "feature_used", "feature_used=2", "feature_used=abc"} {
+		_, err := ParseSampleRates(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAnonymousID(t *testing.T) {
+	id := AnonymousID([]byte("key"), "user-1")
+	assert.Len(t, id, 32)
+	assert.Equal(t, id, AnonymousID([]byte("key"), "user-1"))
+	assert.NotEqual(t, id, AnonymousID([]byte("other-key"), "user-1"))
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+)
+
+var (
+	ErrUnknownEvent = errors.New("unknown analytics event")
+	ErrInvalidSink  = errors.New("invalid analytics sink")
+)
+
+// Events は記録できるイベント
+var Events = []string{
+	commonDomain.AnalyticsTaskCreated,
+	commonDomain.AnalyticsSessionStarted,
+	commonDomain.AnalyticsFeatureUsed,
+}
+
+// IsEvent は記録できるイベントかどうかを判定する
+func IsEvent(name string) bool {
+	for _, event := range Events {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Event はシンクに書き出す分析イベント
+// ユーザーIDは仮名化したIDに置き換え、プロパティは個人情報を取り除いてから保持する
+type Event struct {
+	ID          string                 `json:"event_id"`
+	Name        string                 `json:"event"`
+	AnonymousID string                 `json:"anonymous_id"`
+	Properties  map[string]interface{} `json:"properties"`
+	OccurredAt  time.Time              `json:"occurred_at"`
+}
+
+// AnonymousID はユーザーIDを仮名化したIDを返す
+// 同じ鍵では同じユーザーが常に同じIDになるため、鍵を知らない分析者でもユーザー単位の集計ができる
+func AnonymousID(key []byte, userID string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Preference はユーザーの分析イベントの記録の設定
+// 設定がないユーザーは記録に同意しているものとして扱う（設定画面でいつでもオプトアウトできる）
+type Preference struct {
+	UserID    string    `json:"-"`
+	OptedOut  bool      `json:"opted_out" example:"false"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name AnalyticsPreference
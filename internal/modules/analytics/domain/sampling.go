@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Sampling はイベントごとのサンプリング率（0〜1）
+// 同じユーザーの同じイベントは常に同じ判定になるため、サンプリングされたユーザーの行動は欠けずに残る
+type Sampling struct {
+	// Default はRatesに含まれないイベントのサンプリング率
+	Default float64
+	Rates   map[string]float64
+}
+
+// Rate はイベントのサンプリング率を返す
+func (s Sampling) Rate(event string) float64 {
+	if rate, ok := s.Rates[event]; ok {
+		return rate
+	}
+	return s.Default
+}
+
+// Sampled は仮名化したユーザーIDのイベントを記録するかどうかを判定する
+func (s Sampling) Sampled(anonymousID, event string) bool {
+	rate := s.Rate(event)
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	h := fnv.New64a()
+	h.Write([]byte(event + ":" + anonymousID))
+	return float64(h.Sum64()%10000) < rate*10000
+}
+
+// ParseSampleRate は "0.25" または "25%" 形式のサンプリング率を解析する
+func ParseSampleRate(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	var rate float64
+	var err error
+	if percentage, ok := strings.CutSuffix(value, "%"); ok {
+		rate, err = strconv.ParseFloat(percentage, 64)
+		rate /= 100
+	} else {
+		rate, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid sample rate: %q", value)
+	}
+	return rate, nil
+}
+
+// ParseSampleRates は "session_started=1,feature_used=10%" 形式の設定を解析する
+func ParseSampleRates(spec string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		event, value, ok := strings.Cut(entry, "=")
+		event = strings.TrimSpace(event)
+		if !ok || !IsEvent(event) {
+			return nil, fmt.Errorf("invalid sample rate entry: %q", entry)
+		}
+		rate, err := ParseSampleRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample rate for %s: %w", event, err)
+		}
+		rates[event] = rate
+	}
+	return rates, nil
+}
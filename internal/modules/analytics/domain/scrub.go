@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxPropertyLength は文字列のプロパティの最大文字数（超える部分は切り捨てる）
+	maxPropertyLength = 128
+	// maxPropertyDepth はネストしたプロパティの最大の深さ（超える値は取り除く）
+	maxPropertyDepth = 3
+)
+
+// piiKeyWords はプロパティ名に含まれる場合に値を取り除く単語（"_"・"-"で区切った単語単位で判定する）
+// 自由入力のテキスト（タイトル・説明・コメント）もメールアドレスや氏名を含みうるため取り除く
+var piiKeyWords = map[string]bool{
+	"email": true, "mail": true, "name": true, "username": true, "phone": true, "address": true,
+	"ip": true, "password": true, "token": true, "secret": true, "user": true,
+	"title": true, "description": true, "comment": true, "content": true, "message": true,
+	"body": true, "note": true, "notes": true, "text": true, "query": true, "url": true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	ipv4Pattern  = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+// ScrubProperties はプロパティから個人情報を取り除いたコピーを返す
+// 個人情報を示す名前のプロパティを取り除き、文字列中のメールアドレス・IPアドレスを伏せ字にする
+func ScrubProperties(properties map[string]interface{}) map[string]interface{} {
+	return scrubMap(properties, 0)
+}
+
+func scrubMap(properties map[string]interface{}, depth int) map[string]interface{} {
+	scrubbed := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		if isPIIKey(key) {
+			continue
+		}
+		if v, ok := scrubValue(value, depth); ok {
+			scrubbed[key] = v
+		}
+	}
+	return scrubbed
+}
+
+func scrubValue(value interface{}, depth int) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return v, true
+	case string:
+		return scrubString(v), true
+	case fmt.Stringer:
+		return scrubString(v.String()), true
+	case map[string]interface{}:
+		if depth+1 >= maxPropertyDepth {
+			return nil, false
+		}
+		return scrubMap(v, depth+1), true
+	case []string:
+		values := make([]interface{}, 0, len(v))
+		for _, s := range v {
+			values = append(values, scrubString(s))
+		}
+		return values, true
+	case []interface{}:
+		if depth+1 >= maxPropertyDepth {
+			return nil, false
+		}
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if scrubbed, ok := scrubValue(item, depth+1); ok {
+				values = append(values, scrubbed)
+			}
+		}
+		return values, true
+	default:
+		// 構造体などは個人情報を含むフィールドを判定できないため記録しない
+		return nil, false
+	}
+}
+
+// isPIIKey はプロパティ名が個人情報を示すかどうかを判定する
+func isPIIKey(key string) bool {
+	words := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	})
+	for _, word := range words {
+		if piiKeyWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubString は文字列中のメールアドレス・IPアドレスを伏せ字にして、長すぎる部分を切り捨てる
+func scrubString(s string) string {
+	s = emailPattern.ReplaceAllString(s, "[email]")
+	s = ipv4Pattern.ReplaceAllString(s, "[ip]")
+	if utf8.RuneCountInString(s) > maxPropertyLength {
+		s = string([]rune(s)[:maxPropertyLength])
+	}
+	return s
+}
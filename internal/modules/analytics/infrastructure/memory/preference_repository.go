@@ -0,0 +1,45 @@
+// Package memory は分析リポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+)
+
+// PreferenceRepository は分析イベントの記録の設定のインメモリリポジトリ
+type PreferenceRepository struct {
+	mu          sync.RWMutex
+	preferences map[string]domain.Preference
+}
+
+// NewPreferenceRepository は新しいPreferenceRepositoryを作成する
+func NewPreferenceRepository() *PreferenceRepository {
+	return &PreferenceRepository{
+		preferences: make(map[string]domain.Preference),
+	}
+}
+
+// GetPreference はユーザーの設定を取得する（存在しない場合は nil, nil）
+func (r *PreferenceRepository) GetPreference(ctx context.Context, userID string) (*domain.Preference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	preference, ok := r.preferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &preference, nil
+}
+
+// SavePreference はユーザーの設定を保存する（同じユーザーの設定は置き換える）
+func (r *PreferenceRepository) SavePreference(ctx context.Context, preference *domain.Preference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferences[preference.UserID] = *preference
+	return nil
+}
@@ -0,0 +1,104 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// finalFlushTimeout は停止時にバッファのイベントを書き出す時間の上限
+const finalFlushTimeout = 10 * time.Second
+
+// FlushWorker は分析イベントのバッファを定期的にシンクに書き出すワーカー
+// 一度に書き出す件数のイベントが溜まった場合は、間隔を待たずに書き出す
+type FlushWorker struct {
+	analyticsService *usecase.AnalyticsService
+	interval         time.Duration
+	logger           logger.Logger
+	stopCh           chan struct{}
+	doneCh           chan struct{}
+	started          bool
+}
+
+// NewFlushWorker は新しいFlushWorkerを作成
+func NewFlushWorker(
+	analyticsService *usecase.AnalyticsService,
+	interval time.Duration,
+	logger logger.Logger,
+) *FlushWorker {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &FlushWorker{
+		analyticsService: analyticsService,
+		interval:         interval,
+		logger:           logger,
+		stopCh:           make(chan struct{}),
+		doneCh:           make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始
+func (w *FlushWorker) Start(ctx context.Context) {
+	if w.started {
+		w.logger.Warn("Analytics flush worker already running")
+		return
+	}
+
+	w.started = true
+	ticker := time.NewTicker(w.interval)
+
+	w.logger.Info("Starting analytics flush worker", logger.Any("interval", w.interval.String()))
+
+	go func() {
+		defer func() {
+			ticker.Stop()
+			// 停止時に残っているイベントを書き出す（contextはキャンセル済みの場合がある）
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			w.flush(flushCtx)
+			cancel()
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.flush(ctx)
+			case <-w.analyticsService.BatchReady():
+				w.flush(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Analytics flush worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Analytics flush worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// flush はバッファのイベントを書き出す
+func (w *FlushWorker) flush(ctx context.Context) {
+	defer errtrack.Recover("analytics-flush-worker")
+	if err := w.analyticsService.Flush(ctx); err != nil {
+		w.logger.Error("Failed to flush analytics events", logger.Error(err))
+	}
+}
+
+// Stop はワーカーを停止し、残っているイベントの書き出しを待つ
+func (w *FlushWorker) Stop() {
+	if !w.started {
+		return
+	}
+
+	w.logger.Info("Stopping analytics flush worker")
+	select {
+	case <-w.stopCh:
+	default:
+		close(w.stopCh)
+	}
+	<-w.doneCh
+}
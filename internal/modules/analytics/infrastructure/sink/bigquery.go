@@ -0,0 +1,225 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+)
+
+const (
+	// bigQueryAPIBaseURL はBigQuery APIのベースURL
+	bigQueryAPIBaseURL = "https://bigquery.googleapis.com/bigquery/v2"
+	// bigQueryScope はストリーミング挿入に必要なOAuthのスコープ
+	bigQueryScope = "https://www.googleapis.com/auth/bigquery.insertdata"
+	// bigQueryTimeout はBigQueryへの問い合わせのタイムアウト
+	bigQueryTimeout = 30 * time.Second
+	// tokenRefreshMargin はアクセストークンを期限より前に更新する時間
+	tokenRefreshMargin = time.Minute
+)
+
+// BigQueryConfig はBigQueryシンクの設定
+type BigQueryConfig struct {
+	ProjectID string
+	Dataset   string
+	Table     string
+	// サービスアカウントの鍵（JSON）のファイルパス
+	CredentialsFile string
+}
+
+// serviceAccountKey はサービスアカウントの鍵のうち使用するフィールド
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// BigQuerySink はイベントをBigQueryのテーブルにストリーミング挿入するシンク
+// テーブルの列は event_id・event・anonymous_id・properties（JSON文字列）・occurred_at
+type BigQuerySink struct {
+	config     BigQueryConfig
+	key        serviceAccountKey
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewBigQuerySink はBigQueryシンクを作成する
+func NewBigQuerySink(config BigQueryConfig) (usecase.Sink, error) {
+	if config.ProjectID == "" || config.Dataset == "" || config.Table == "" {
+		return nil, fmt.Errorf("%w: bigquery project, dataset and table are required", domain.ErrInvalidSink)
+	}
+
+	data, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bigquery credentials: %w", err)
+	}
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil || key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("%w: invalid service account key", domain.ErrInvalidSink)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	return &BigQuerySink{
+		config:     config,
+		key:        key,
+		httpClient: &http.Client{Timeout: bigQueryTimeout},
+	}, nil
+}
+
+// Name はシンクの名前を返す
+func (s *BigQuerySink) Name() string {
+	return "bigquery"
+}
+
+// bigQueryRow はストリーミング挿入の行
+type bigQueryRow struct {
+	// 再送時に重複して挿入されないよう、イベントIDを挿入IDにする
+	InsertID string                 `json:"insertId"`
+	JSON     map[string]interface{} `json:"json"`
+}
+
+// bigQueryInsertResponse はストリーミング挿入のレスポンスのうち使用するフィールド
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Write はイベントをテーブルに挿入する
+func (s *BigQuerySink) Write(ctx context.Context, events []*domain.Event) error {
+	rows := make([]bigQueryRow, 0, len(events))
+	for _, event := range events {
+		properties, err := json.Marshal(event.Properties)
+		if err != nil {
+			return fmt.Errorf("failed to encode analytics event: %w", err)
+		}
+		rows = append(rows, bigQueryRow{
+			InsertID: event.ID,
+			JSON: map[string]interface{}{
+				"event_id":     event.ID,
+				"event":        event.Name,
+				"anonymous_id": event.AnonymousID,
+				"properties":   string(properties),
+				"occurred_at":  event.OccurredAt.Format(time.RFC3339Nano),
+			},
+		})
+	}
+	body, err := json.Marshal(map[string]interface{}{"rows": rows})
+	if err != nil {
+		return fmt.Errorf("failed to encode insert request: %w", err)
+	}
+
+	token, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/datasets/%s/tables/%s/insertAll", bigQueryAPIBaseURL,
+		url.PathEscape(s.config.ProjectID), url.PathEscape(s.config.Dataset), url.PathEscape(s.config.Table))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create insert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to insert analytics events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result bigQueryInsertResponse
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bigquery returned %d: %s", resp.StatusCode, result.Error.Message)
+	}
+	if len(result.InsertErrors) > 0 {
+		var messages []string
+		for _, insertError := range result.InsertErrors {
+			for _, e := range insertError.Errors {
+				messages = append(messages, fmt.Sprintf("row %d: %s", insertError.Index, e.Message))
+			}
+		}
+		return fmt.Errorf("bigquery rejected %d rows: %s", len(result.InsertErrors), strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// token はサービスアカウントのアクセストークンを返す（期限が近い場合は取得し直す）
+func (s *BigQuerySink) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.accessToken != "" && now.Add(tokenRefreshMargin).Before(s.expiresAt) {
+		return s.accessToken, nil
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(s.key.PrivateKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse service account key: %w", err)
+	}
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   s.key.ClientEmail,
+		"scope": bigQueryScope,
+		"aud":   s.key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token request: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		ErrorDescription string `json:"error_description"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, body.ErrorDescription)
+	}
+
+	s.accessToken = body.AccessToken
+	s.expiresAt = now.Add(time.Duration(body.ExpiresIn) * time.Second)
+	return s.accessToken, nil
+}
@@ -0,0 +1,74 @@
+// Package sink は分析イベントの書き出し先の実装です
+//
+// いずれのシンクもイベントを1行1イベントのJSON（NDJSON）として書き出します。
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+)
+
+// FileSink はイベントをローカルのファイルに追記するシンク（日付ごとにファイルを分ける）
+type FileSink struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileSink はdirにイベントを書き出すシンクを作成する（ディレクトリがない場合は作成する）
+func NewFileSink(dir string) (usecase.Sink, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("%w: file directory is required", domain.ErrInvalidSink)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create analytics directory: %w", err)
+	}
+	return &FileSink{dir: dir}, nil
+}
+
+// Name はシンクの名前を返す
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+// Write はイベントを events-YYYY-MM-DD.ndjson に追記する
+func (s *FileSink) Write(ctx context.Context, events []*domain.Event) error {
+	data, err := encodeNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.dir, "events-"+time.Now().UTC().Format("2006-01-02")+".ndjson")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open analytics file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write analytics file: %w", err)
+	}
+	return f.Close()
+}
+
+// encodeNDJSON はイベントを1行1イベントのJSONに変換する
+func encodeNDJSON(events []*domain.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return nil, fmt.Errorf("failed to encode analytics event: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+)
+
+// s3Timeout はS3へのアップロードのタイムアウト
+const s3Timeout = 30 * time.Second
+
+// S3Config はS3シンクの設定
+type S3Config struct {
+	Bucket string
+	Region string
+	// オブジェクトキーの接頭辞（例: "events/"）
+	Prefix string
+	// S3互換ストレージのエンドポイント（空の場合はAWSのS3。指定した場合はパス形式でアクセスする）
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3Sink はイベントをまとめてS3のオブジェクトとしてアップロードするシンク
+// オブジェクトは "接頭辞YYYY/MM/DD/時刻-ID.ndjson" に書き出す
+type S3Sink struct {
+	config     S3Config
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Sink はS3シンクを作成する
+func NewS3Sink(config S3Config) (usecase.Sink, error) {
+	if config.Bucket == "" || config.Region == "" {
+		return nil, fmt.Errorf("%w: s3 bucket and region are required", domain.ErrInvalidSink)
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: aws credentials are required", domain.ErrInvalidSink)
+	}
+	return &S3Sink{
+		config:     config,
+		httpClient: &http.Client{Timeout: s3Timeout},
+		now:        time.Now,
+	}, nil
+}
+
+// Name はシンクの名前を返す
+func (s *S3Sink) Name() string {
+	return "s3"
+}
+
+// Write はイベントを1つのオブジェクトとしてアップロードする
+func (s *S3Sink) Write(ctx context.Context, events []*domain.Event) error {
+	payload, err := encodeNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	key := s.config.Prefix + now.Format("2006/01/02") + "/" + strconv.FormatInt(now.UnixNano(), 10) + "-" + uuid.New().String() + ".ndjson"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	s.sign(req, payload, now)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload analytics events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// objectURL はオブジェクトのURLを返す
+func (s *S3Sink) objectURL(key string) string {
+	if s.config.Endpoint != "" {
+		return strings.TrimRight(s.config.Endpoint, "/") + "/" + s.config.Bucket + "/" + key
+	}
+	return "https://" + s.config.Bucket + ".s3." + s.config.Region + ".amazonaws.com/" + key
+}
+
+// sign はリクエストにSignature Version 4の署名を付与する
+func (s *S3Sink) sign(req *http.Request, payload []byte, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+	}
+
+	// 署名するヘッダー（名前の順）
+	headers := [][2]string{
+		{"content-type", req.Header.Get("Content-Type")},
+		{"host", req.URL.Host},
+		{"x-amz-content-sha256", payloadHash},
+		{"x-amz-date", amzDate},
+	}
+	if s.config.SessionToken != "" {
+		headers = append(headers, [2]string{"x-amz-security-token", s.config.SessionToken})
+	}
+
+	var canonicalHeaders, signedHeaders string
+	for i, h := range headers {
+		canonicalHeaders += h[0] + ":" + h[1] + "\n"
+		if i > 0 {
+			signedHeaders += ";"
+		}
+		signedHeaders += h[0]
+	}
+
+	canonicalRequest := req.Method + "\n" + canonicalURI(req.URL) + "\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + payloadHash
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.config.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalURI は署名に使用するパスを返す
+func canonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+)
+
+// AnalyticsController は分析イベントの記録の設定のHTTPリクエストを処理するコントローラー
+type AnalyticsController struct {
+	analyticsService *usecase.AnalyticsService
+}
+
+// NewAnalyticsController は新しいAnalyticsControllerを作成する
+func NewAnalyticsController(analyticsService *usecase.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+	}
+}
+
+// ErrorResponse は分析APIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"REQUEST_ERROR"`
+	Message string `json:"message" example:"Invalid request body"`
+} // @name AnalyticsErrorResponse
+
+// UpdatePreferenceRequest は設定の更新のリクエスト
+type UpdatePreferenceRequest struct {
+	OptedOut *bool `json:"opted_out" binding:"required" example:"true"`
+} // @name AnalyticsPreferenceRequest
+
+// PreferenceResponse は設定のレスポンス
+type PreferenceResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    *domain.Preference `json:"data"`
+} // @name AnalyticsPreferenceResponse
+
+// GetPreference 分析の設定
+// @Summary      分析の設定
+// @Description  ログイン中のユーザーの利用状況の記録（製品改善のための分析）の設定を取得します
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} PreferenceResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /analytics/preference [get]
+func (c *AnalyticsController) GetPreference(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	preference, err := c.analyticsService.GetPreference(ctx, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PreferenceResponse{Success: true, Data: preference})
+}
+
+// UpdatePreference 分析の設定の更新
+// @Summary      分析の設定の更新
+// @Description  利用状況の記録をオプトアウト（opted_out=true）またはオプトインします。オプトアウト後のイベントは記録されません
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdatePreferenceRequest true "設定"
+// @Security     BearerAuth
+// @Success      200 {object} PreferenceResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /analytics/preference [put]
+func (c *AnalyticsController) UpdatePreference(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	var req UpdatePreferenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	preference, err := c.analyticsService.SetOptOut(ctx, userID, *req.OptedOut)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PreferenceResponse{Success: true, Data: preference})
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to process analytics request",
+		})
+	}
+}
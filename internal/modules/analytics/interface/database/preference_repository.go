@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PreferenceRepository は分析イベントの記録の設定のデータベースリポジトリ実装
+type PreferenceRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPreferenceRepository は新しいPreferenceRepositoryを作成する
+func NewPreferenceRepository(db *sql.DB, logger logger.Logger) usecase.PreferenceRepository {
+	return &PreferenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPreference はユーザーの設定を取得する（存在しない場合は nil, nil）
+func (r *PreferenceRepository) GetPreference(ctx context.Context, userID string) (*domain.Preference, error) {
+	query := `SELECT user_id, opted_out, updated_at FROM analytics_preferences WHERE user_id = ?`
+
+	var preference domain.Preference
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&preference.UserID, &preference.OptedOut, &preference.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get analytics preference", logger.Any("user_id", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get analytics preference: %w", err)
+	}
+	return &preference, nil
+}
+
+// SavePreference はユーザーの設定を保存する（同じユーザーの設定は置き換える）
+func (r *PreferenceRepository) SavePreference(ctx context.Context, preference *domain.Preference) error {
+	query := `
+		INSERT INTO analytics_preferences (user_id, opted_out, updated_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			opted_out = VALUES(opted_out),
+			updated_at = VALUES(updated_at)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, preference.UserID, preference.OptedOut, preference.UpdatedAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save analytics preference", logger.Any("user_id", preference.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save analytics preference: %w", err)
+	}
+	return nil
+}
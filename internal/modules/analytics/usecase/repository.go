@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+)
+
+// PreferenceRepository はユーザーの分析イベントの記録の設定のリポジトリインターフェース
+type PreferenceRepository interface {
+	// GetPreference はユーザーの設定を取得する（存在しない場合は nil, nil）
+	GetPreference(ctx context.Context, userID string) (*domain.Preference, error)
+	// SavePreference はユーザーの設定を保存する（同じユーザーの設定は置き換える）
+	SavePreference(ctx context.Context, preference *domain.Preference) error
+}
+
+// Sink は分析イベントの書き出し先（ファイル・S3・BigQuery）のインターフェース
+type Sink interface {
+	Name() string
+	// Write はイベントをまとめて書き出す（失敗した場合は次の書き出しで再送する）
+	Write(ctx context.Context, events []*domain.Event) error
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+const (
+	// DefaultBufferSize は書き出し待ちのイベントを保持する件数の既定値（超えたイベントは破棄する）
+	DefaultBufferSize = 10000
+	// DefaultBatchSize は一度に書き出すイベント数の既定値
+	DefaultBatchSize = 500
+	// preferenceCacheTTL はオプトアウトの設定をキャッシュする期間（他のインスタンスでの変更が反映されるまでの時間）
+	preferenceCacheTTL = time.Minute
+	// maxCachedPreferences はキャッシュする設定の件数の上限（超えた場合はキャッシュを作り直す）
+	maxCachedPreferences = 10000
+)
+
+// 分析イベントのメトリクス名
+const (
+	MetricEventsQueued     = "analytics_events_queued_total"
+	MetricEventsDropped    = "analytics_events_dropped_total"
+	MetricEventsWritten    = "analytics_events_written_total"
+	MetricEventsSampledOut = "analytics_events_sampled_out_total"
+	MetricWriteFailures    = "analytics_write_failures_total"
+)
+
+// cachedPreference はキャッシュしたオプトアウトの設定
+type cachedPreference struct {
+	optedOut  bool
+	expiresAt time.Time
+}
+
+// AnalyticsService は利用状況の分析イベントを記録するサービス
+// イベントはバッファに溜めてバックグラウンドでシンクにまとめて書き出すため、リクエストの処理を遅らせない
+type AnalyticsService struct {
+	Preferences PreferenceRepository
+	// イベントの書き出し先（nilの場合はイベントを記録しない。オプトアウトの設定は変更できる）
+	Sink     Sink
+	Sampling domain.Sampling
+	// ユーザーIDの仮名化に使用する鍵
+	IDKey     []byte
+	BatchSize int
+	Logger    logger.Logger
+
+	buffer chan *domain.Event
+	full   chan struct{}
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedPreference
+
+	flushMu sync.Mutex
+	// 書き出しに失敗したイベント（次の書き出しで再送する）
+	pending []*domain.Event
+}
+
+// NewAnalyticsService はAnalyticsServiceのコンストラクタ
+func NewAnalyticsService(
+	preferences PreferenceRepository,
+	sink Sink,
+	sampling domain.Sampling,
+	idKey []byte,
+	bufferSize int,
+	logger logger.Logger,
+) *AnalyticsService {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &AnalyticsService{
+		Preferences: preferences,
+		Sink:        sink,
+		Sampling:    sampling,
+		IDKey:       idKey,
+		BatchSize:   DefaultBatchSize,
+		Logger:      logger,
+		buffer:      make(chan *domain.Event, bufferSize),
+		full:        make(chan struct{}, 1),
+		cache:       make(map[string]cachedPreference),
+	}
+}
+
+// Enabled はイベントを記録するかどうかを返す
+func (s *AnalyticsService) Enabled() bool {
+	return s.Sink != nil
+}
+
+// Track はユーザーのイベントをバッファに追加する
+// オプトアウトしたユーザー・サンプリングで除外したイベントは記録せず、プロパティの個人情報は取り除く
+func (s *AnalyticsService) Track(ctx context.Context, userID, event string, properties map[string]interface{}) {
+	if !s.Enabled() || userID == "" {
+		return
+	}
+	if !domain.IsEvent(event) {
+		s.Logger.WithContext(ctx).Warn("Ignoring unknown analytics event", logger.Any("event", event))
+		return
+	}
+
+	anonymousID := domain.AnonymousID(s.IDKey, userID)
+	if !s.Sampling.Sampled(anonymousID, event) {
+		metrics.Counter(MetricEventsSampledOut).Add(1)
+		return
+	}
+	if s.optedOut(ctx, userID) {
+		return
+	}
+
+	e := &domain.Event{
+		ID:          uuid.New().String(),
+		Name:        event,
+		AnonymousID: anonymousID,
+		Properties:  domain.ScrubProperties(properties),
+		OccurredAt:  time.Now().UTC(),
+	}
+	select {
+	case s.buffer <- e:
+		metrics.Counter(MetricEventsQueued).Add(1)
+	default:
+		// シンクの障害でバッファが溢れても、リクエストの処理は止めない
+		metrics.Counter(MetricEventsDropped).Add(1)
+		return
+	}
+	if len(s.buffer) >= s.batchSize() {
+		select {
+		case s.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// BatchReady はバッファに一度に書き出す件数のイベントが溜まったことを通知するチャネルを返す
+func (s *AnalyticsService) BatchReady() <-chan struct{} {
+	return s.full
+}
+
+// Flush はバッファのイベントをシンクに書き出す
+// 書き出しに失敗したイベントは保持して次の書き出しで再送する
+func (s *AnalyticsService) Flush(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	for {
+		batch := s.pending
+		s.pending = nil
+	fill:
+		for len(batch) < s.batchSize() {
+			select {
+			case e := <-s.buffer:
+				batch = append(batch, e)
+			default:
+				break fill
+			}
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := s.Sink.Write(ctx, batch); err != nil {
+			metrics.Counter(MetricWriteFailures).Add(1)
+			s.pending = batch
+			return fmt.Errorf("failed to write analytics events to %s: %w", s.Sink.Name(), err)
+		}
+		metrics.Counter(MetricEventsWritten).Add(int64(len(batch)))
+	}
+}
+
+// GetPreference はユーザーの分析イベントの記録の設定を取得する
+func (s *AnalyticsService) GetPreference(ctx context.Context, userID string) (*domain.Preference, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	preference, err := s.Preferences.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics preference: %w", err)
+	}
+	if preference == nil {
+		preference = &domain.Preference{UserID: userID}
+	}
+	return preference, nil
+}
+
+// SetOptOut はユーザーの分析イベントの記録をオプトアウト（またはオプトイン）する
+// このインスタンスでは直ちに反映し、他のインスタンスではキャッシュの期限切れ後に反映される
+func (s *AnalyticsService) SetOptOut(ctx context.Context, userID string, optedOut bool) (*domain.Preference, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	preference := &domain.Preference{
+		UserID:    userID,
+		OptedOut:  optedOut,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.Preferences.SavePreference(ctx, preference); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to save analytics preference",
+			logger.Any("user_id", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to save analytics preference: %w", err)
+	}
+	s.cachePreference(userID, optedOut)
+
+	s.Logger.WithContext(ctx).Info("Analytics preference updated",
+		logger.Any("user_id", userID), logger.Any("opted_out", optedOut))
+	return preference, nil
+}
+
+// optedOut はユーザーがオプトアウトしているかどうかを返す
+// 設定を取得できない場合は、同意を確認できないためオプトアウトとして扱う
+func (s *AnalyticsService) optedOut(ctx context.Context, userID string) bool {
+	s.cacheMu.Lock()
+	cached, ok := s.cache[userID]
+	s.cacheMu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.optedOut
+	}
+
+	preference, err := s.Preferences.GetPreference(ctx, userID)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to get analytics preference, skipping event",
+			logger.Any("user_id", userID), logger.Error(err))
+		return true
+	}
+	optedOut := preference != nil && preference.OptedOut
+	s.cachePreference(userID, optedOut)
+	return optedOut
+}
+
+// cachePreference はオプトアウトの設定をキャッシュする
+func (s *AnalyticsService) cachePreference(userID string, optedOut bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if len(s.cache) >= maxCachedPreferences {
+		s.cache = make(map[string]cachedPreference)
+	}
+	s.cache[userID] = cachedPreference{optedOut: optedOut, expiresAt: time.Now().Add(preferenceCacheTTL)}
+}
+
+// batchSize は一度に書き出すイベント数を返す
+func (s *AnalyticsService) batchSize() int {
+	if s.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return s.BatchSize
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	"github.com/hryt430/Yotei+/internal/modules/analytics/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testIDKey = []byte("test-key")
+
+func newTestService(t *testing.T, bufferSize int) (*AnalyticsService, *mocks.MockPreferenceRepository, *mocks.MockSink) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockPreferenceRepository(ctrl)
+	sink := mocks.NewMockSink(ctrl)
+	sink.EXPECT().Name().Return("test").AnyTimes()
+	service := NewAnalyticsService(repo, sink, domain.Sampling{Default: 1}, testIDKey, bufferSize,
+		*logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	return service, repo, sink
+}
+
+func TestAnalyticsService_Track(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("events are pseudonymized and scrubbed before being written", func(t *testing.T) {
+		service, repo, sink := newTestService(t, 10)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, map[string]interface{}{
+			"priority": "HIGH",
+			"title":    "Call alice@example.com",
+		})
+
+		var written []*domain.Event
+		sink.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []*domain.Event) error {
+			written = events
+			return nil
+		})
+		require.NoError(t, service.Flush(ctx))
+
+		require.Len(t, written, 1)
+		assert.Equal(t, commonDomain.AnalyticsTaskCreated, written[0].Name)
+		assert.Equal(t, domain.AnonymousID(testIDKey, "user-1"), written[0].AnonymousID)
+		assert.NotContains(t, written[0].AnonymousID, "user-1")
+		assert.Equal(t, map[string]interface{}{"priority": "HIGH"}, written[0].Properties)
+	})
+
+	t.Run("opted out users are not tracked", func(t *testing.T) {
+		service, repo, _ := newTestService(t, 10)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(&domain.Preference{UserID: "user-1", OptedOut: true}, nil)
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsSessionStarted, nil)
+		service.Track(ctx, "user-1", commonDomain.AnalyticsSessionStarted, nil)
+
+		// Sink.Writeは呼ばれない
+		require.NoError(t, service.Flush(ctx))
+	})
+
+	t.Run("users whose preference cannot be read are not tracked", func(t *testing.T) {
+		service, repo, _ := newTestService(t, 10)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, errors.New("db down"))
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsSessionStarted, nil)
+
+		require.NoError(t, service.Flush(ctx))
+	})
+
+	t.Run("opting out takes effect immediately", func(t *testing.T) {
+		service, repo, _ := newTestService(t, 10)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+		repo.EXPECT().SavePreference(gomock.Any(), gomock.Any()).Return(nil)
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsSessionStarted, nil)
+		_, err := service.SetOptOut(ctx, "user-1", true)
+		require.NoError(t, err)
+		service.Track(ctx, "user-1", commonDomain.AnalyticsSessionStarted, nil)
+
+		assert.Len(t, service.buffer, 1)
+	})
+
+	t.Run("sampled out events are not tracked", func(t *testing.T) {
+		service, _, _ := newTestService(t, 10)
+		service.Sampling = domain.Sampling{Default: 1, Rates: map[string]float64{commonDomain.AnalyticsFeatureUsed: 0}}
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsFeatureUsed, map[string]interface{}{"feature": "quick_add"})
+
+		assert.Empty(t, service.buffer)
+	})
+
+	t.Run("unknown events are ignored", func(t *testing.T) {
+		service, _, _ := newTestService(t, 10)
+
+		service.Track(ctx, "user-1", "page_viewed", nil)
+
+		assert.Empty(t, service.buffer)
+	})
+
+	t.Run("events are dropped when the buffer is full", func(t *testing.T) {
+		service, repo, _ := newTestService(t, 2)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+
+		for i := 0; i < 5; i++ {
+			service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, nil)
+		}
+
+		assert.Len(t, service.buffer, 2)
+	})
+
+	t.Run("a full batch is signalled to the worker", func(t *testing.T) {
+		service, repo, _ := newTestService(t, 10)
+		service.BatchSize = 2
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, nil)
+		assert.Empty(t, service.BatchReady())
+		service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, nil)
+		assert.Len(t, service.BatchReady(), 1)
+	})
+
+	t.Run("nothing is tracked without a sink", func(t *testing.T) {
+		service, _, _ := newTestService(t, 10)
+		service.Sink = nil
+
+		service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, nil)
+
+		assert.Empty(t, service.buffer)
+		assert.NoError(t, service.Flush(ctx))
+	})
+}
+
+func TestAnalyticsService_Flush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("events are written in batches", func(t *testing.T) {
+		service, repo, sink := newTestService(t, 10)
+		service.BatchSize = 2
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+		for i := 0; i < 5; i++ {
+			service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, nil)
+		}
+
+		var sizes []int
+		sink.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []*domain.Event) error {
+			sizes = append(sizes, len(events))
+			return nil
+		}).Times(3)
+
+		require.NoError(t, service.Flush(ctx))
+		assert.Equal(t, []int{2, 2, 1}, sizes)
+	})
+
+	t.Run("failed batches are retried on the next flush", func(t *testing.T) {
+		service, repo, sink := newTestService(t, 10)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+		service.Track(ctx, "user-1", commonDomain.AnalyticsTaskCreated, nil)
+
+		var first, second []*domain.Event
+		gomock.InOrder(
+			sink.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []*domain.Event) error {
+				first = events
+				return errors.New("unavailable")
+			}),
+			sink.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []*domain.Event) error {
+				second = events
+				return nil
+			}),
+		)
+
+		assert.Error(t, service.Flush(ctx))
+		require.NoError(t, service.Flush(ctx))
+		require.Len(t, second, 1)
+		assert.Equal(t, first[0].ID, second[0].ID)
+	})
+}
+
+func TestAnalyticsService_GetPreference(t *testing.T) {
+	t.Run("users without a preference are opted in", func(t *testing.T) {
+		service, repo, _ := newTestService(t, 10)
+		repo.EXPECT().GetPreference(gomock.Any(), "user-1").Return(nil, nil)
+
+		preference, err := service.GetPreference(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.False(t, preference.OptedOut)
+	})
+
+	t.Run("requires a user", func(t *testing.T) {
+		service, _, _ := newTestService(t, 10)
+
+		_, err := service.GetPreference(context.Background(), "")
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
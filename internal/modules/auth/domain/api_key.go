@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// APIKeyPrefix は発行するAPIキーの接頭辞（ログやシークレットスキャンで識別しやすくする）
+	APIKeyPrefix = "yp_"
+	// apiKeyDisplayLength は一覧に表示するキーの先頭の文字数（接頭辞を含む）
+	apiKeyDisplayLength = 10
+	maxAPIKeyNameLength = 100
+)
+
+// APIキーのスコープ
+const (
+	// APIKeyScopeSCIM はSCIMによるユーザー・グループのプロビジョニング
+	APIKeyScopeSCIM = "scim"
+	// APIKeyScopeQuick は音声アシスタント・ショートカットからのタスクの追加と今日の予定の取得（キーを発行したユーザーとして操作する）
+	APIKeyScopeQuick = "quick"
+	// APIKeyScopeClip はブラウザの拡張機能からのページのクリップ（キーを発行したユーザーとして操作する）
+	APIKeyScopeClip = "clip"
+)
+
+// APIKeyScopes は発行できるスコープの一覧
+var APIKeyScopes = []string{APIKeyScopeSCIM, APIKeyScopeQuick, APIKeyScopeClip}
+
+// personalAPIKeyScopes は各ユーザーが自分で発行できるスコープ
+var personalAPIKeyScopes = []string{APIKeyScopeQuick, APIKeyScopeClip}
+
+var (
+	ErrAPIKeyInvalid      = errors.New("invalid api key")
+	ErrAPIKeyNameRequired = errors.New("api key name is required")
+	ErrAPIKeyInvalidScope = errors.New("invalid api key scope")
+)
+
+// APIKey は管理者が外部システム向けに発行するAPIキー（キーはハッシュのみ保存する）
+// quick・clipスコープのキーは各ユーザーが自分のショートカット・ブラウザの拡張機能用に発行できる
+type APIKey struct {
+	ID   string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name string `json:"name" example:"Okta provisioning"`
+	// キーの先頭部分（どのキーか見分けるために表示する）
+	DisplayPrefix string   `json:"display_prefix" example:"yp_AbCdEfG"`
+	KeyHash       string   `json:"-"`
+	Scopes        []string `json:"scopes" example:"scim"`
+	// キーを発行したユーザー（SCIMで作成するグループのオーナー、quick・clipスコープで操作するユーザーになる）
+	CreatedBy  string     `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-06-01T09:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2024-06-01T09:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2024-06-01T09:00:00Z"`
+} // @name APIKey
+
+// NewAPIKey は新しいAPIキーを作成し、平文のキーと一緒に返す（平文のキーは保存しない）
+func NewAPIKey(name, createdBy string, scopes []string, now time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrAPIKeyNameRequired
+	}
+	if len(scopes) == 0 {
+		return nil, "", ErrAPIKeyInvalidScope
+	}
+	for _, scope := range scopes {
+		if !isAPIKeyScope(scope) {
+			return nil, "", ErrAPIKeyInvalidScope
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	plaintext := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		Name:          truncate(name, maxAPIKeyNameLength),
+		DisplayPrefix: plaintext[:apiKeyDisplayLength],
+		KeyHash:       HashAPIKey(plaintext),
+		Scopes:        append([]string(nil), scopes...),
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+	}, plaintext, nil
+}
+
+// HashAPIKey は保存・照合に使うキーのハッシュを返す
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// HasScope はキーにスコープが付与されているか
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOwnedBy はユーザーが自分で発行したquick・clipスコープのキーか
+func (k *APIKey) IsOwnedBy(userID string) bool {
+	if k.CreatedBy != userID {
+		return false
+	}
+	for _, scope := range personalAPIKeyScopes {
+		if k.HasScope(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevoked はキーが無効化されているか
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Revoke はキーを無効化する（既に無効化されている場合は何もしない）
+func (k *APIKey) Revoke(now time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &now
+	}
+}
+
+func isAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
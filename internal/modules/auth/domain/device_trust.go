@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// DeviceTrustDuration は確認コードでログインした端末を記憶する期間
+const DeviceTrustDuration = 30 * 24 * time.Hour
+
+// DeviceTrustCookie は記憶した端末を識別するCookieの名前
+const DeviceTrustCookie = "device_trust"
+
+// Remember は端末を記憶する（tokenのハッシュのみ保存し、Cookieを提示した場合に限り信頼する）
+func (d *LoginDevice) Remember(token string, now time.Time) {
+	until := now.Add(DeviceTrustDuration)
+	d.TrustTokenHash = hashVerificationCode(token)
+	d.TrustedUntil = &until
+}
+
+// IsRemembered はtokenが記憶した端末のものでかつ期限内か
+func (d *LoginDevice) IsRemembered(token string, now time.Time) bool {
+	if d.TrustTokenHash == "" || d.TrustedUntil == nil || !now.Before(*d.TrustedUntil) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashVerificationCode(token)), []byte(d.TrustTokenHash)) == 1
+}
+
+// Forget は端末の記憶と信頼を取り消す
+func (d *LoginDevice) Forget() {
+	d.Trusted = false
+	d.TrustTokenHash = ""
+	d.TrustedUntil = nil
+}
+
+// SignDeviceTrustCookie は端末IDとtokenに署名したCookieの値を作成する（"端末ID.token.署名"）
+func SignDeviceTrustCookie(deviceID, token string, secret []byte) string {
+	payload := deviceID + "." + token
+	return payload + "." + deviceTrustSignature(payload, secret)
+}
+
+// ParseDeviceTrustCookie はCookieの署名を検証して端末IDとtokenを返す
+func ParseDeviceTrustCookie(value string, secret []byte) (deviceID, token string, ok bool) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	expected := deviceTrustSignature(parts[0]+"."+parts[1], secret)
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func deviceTrustSignature(payload string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
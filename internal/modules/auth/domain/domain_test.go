@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -342,3 +345,243 @@ func TestUser_ConcurrentLastLoginUpdate(t *testing.T) {
 		assert.True(t, lastLogins[i].After(*lastLogins[i-1]))
 	}
 }
+
+func TestSecurityEvent_SetClient(t *testing.T) {
+	event := NewSecurityEvent(SecurityEventLoginFailed, "", "invalid password")
+	event.SetClient(ClientInfo{IPAddress: "203.0.113.10", UserAgent: strings.Repeat("あ", 200)})
+
+	assert.Equal(t, "203.0.113.10", event.IPAddress)
+	assert.LessOrEqual(t, len(event.UserAgent), maxUserAgentLength)
+	assert.True(t, utf8.ValidString(event.UserAgent), "user agent is cut on a character boundary")
+}
+
+func TestClientInfoFromContext(t *testing.T) {
+	ctx := ContextWithClientInfo(context.Background(), ClientInfo{IPAddress: "203.0.113.10", UserAgent: "curl/8.0"})
+
+	assert.Equal(t, "203.0.113.10", ClientInfoFromContext(ctx).IPAddress)
+	assert.Equal(t, ClientInfo{}, ClientInfoFromContext(context.Background()))
+}
+
+func TestIsValidSecurityEventType(t *testing.T) {
+	assert.True(t, IsValidSecurityEventType(SecurityEventPermissionDenied))
+	assert.False(t, IsValidSecurityEventType("unknown"))
+}
+
+func TestAssessLoginRisk(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	tokyo := &GeoLocation{Country: "JP", Latitude: 35.68, Longitude: 139.76}
+	osaka := &GeoLocation{Country: "JP", Latitude: 34.69, Longitude: 135.50}
+	london := &GeoLocation{Country: "GB", Latitude: 51.51, Longitude: -0.13}
+
+	laptop := NewLoginDevice("user-1", "laptop", now.Add(-24*time.Hour))
+	laptop.Seen("203.0.113.10", tokyo, now.Add(-time.Hour))
+	pending := NewLoginDevice("user-1", "phone", now)
+
+	tests := []struct {
+		name        string
+		devices     []*LoginDevice
+		userAgent   string
+		location    *GeoLocation
+		at          time.Time
+		wantReasons []string
+	}{
+		{"first login", nil, "laptop", tokyo, now, nil},
+		{"only pending devices count as no history", []*LoginDevice{pending}, "phone", tokyo, now, nil},
+		{"known device nearby", []*LoginDevice{laptop}, "laptop", tokyo, now, nil},
+		{"new device", []*LoginDevice{laptop, pending}, "phone", nil, now, []string{LoginRiskNewDevice}},
+		{"reachable by train", []*LoginDevice{laptop}, "laptop", osaka, now, nil},
+		{"too fast to reach", []*LoginDevice{laptop}, "laptop", osaka, now.Add(-50 * time.Minute), []string{LoginRiskImpossibleTravel}},
+		{"new country, impossible travel", []*LoginDevice{laptop}, "laptop", london, now, []string{LoginRiskNewCountry, LoginRiskImpossibleTravel}},
+		{"new country after a long trip", []*LoginDevice{laptop}, "laptop", london, now.Add(24 * time.Hour), []string{LoginRiskNewCountry}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk := AssessLoginRisk(tt.devices, DeviceFingerprint(tt.userAgent), tt.location, tt.at)
+			assert.Equal(t, tt.wantReasons, risk.Reasons())
+		})
+	}
+}
+
+func TestLoginDevice_Verify(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	device := NewLoginDevice("user-1", "phone", now)
+	device.StartVerification("123456", now)
+
+	for i := 0; i < MaxLoginVerificationAttempts; i++ {
+		assert.ErrorIs(t, device.Verify("000000", now), ErrLoginVerificationInvalid)
+	}
+	assert.ErrorIs(t, device.Verify("123456", now), ErrLoginVerificationExceeded, "locked after too many attempts")
+
+	device.StartVerification("654321", now)
+	assert.ErrorIs(t, device.Verify("654321", now.Add(LoginVerificationCodeTTL+time.Second)), ErrLoginVerificationExpired)
+	require.NoError(t, device.Verify("654321", now))
+	assert.False(t, device.Trusted, "verifying alone does not trust the device")
+
+	device.Seen("203.0.113.10", nil, now)
+	assert.Empty(t, device.VerificationCodeHash)
+	assert.True(t, device.IsKnown())
+}
+
+func TestLoginDevice_Remember(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	device := NewLoginDevice("user-1", "phone", now)
+	device.Remember("token", now)
+
+	assert.True(t, device.IsRemembered("token", now.Add(DeviceTrustDuration-time.Second)))
+	assert.False(t, device.IsRemembered("other", now))
+	assert.False(t, device.IsRemembered("token", now.Add(DeviceTrustDuration)), "expires after 30 days")
+
+	device.Forget()
+	assert.False(t, device.IsRemembered("token", now))
+}
+
+func TestDeviceTrustCookie(t *testing.T) {
+	secret := []byte("secret")
+	cookie := SignDeviceTrustCookie("device-1", "token", secret)
+
+	deviceID, token, ok := ParseDeviceTrustCookie(cookie, secret)
+	require.True(t, ok)
+	assert.Equal(t, "device-1", deviceID)
+	assert.Equal(t, "token", token)
+
+	_, _, ok = ParseDeviceTrustCookie(cookie, []byte("other-secret"))
+	assert.False(t, ok, "signed with another key")
+	_, _, ok = ParseDeviceTrustCookie(strings.Replace(cookie, "device-1", "device-2", 1), secret)
+	assert.False(t, ok, "tampered device id")
+	_, _, ok = ParseDeviceTrustCookie("garbage", secret)
+	assert.False(t, ok)
+}
+
+func TestUser_Deactivate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	user := NewUser("test@example.com", "testuser", "password")
+	assert.True(t, user.IsActive())
+
+	user.Deactivate(now)
+	assert.False(t, user.IsActive())
+	assert.Equal(t, now, *user.DeactivatedAt)
+
+	user.Deactivate(now.Add(time.Hour))
+	assert.Equal(t, now, *user.DeactivatedAt, "deactivating twice keeps the original time")
+
+	user.Activate(now)
+	assert.True(t, user.IsActive())
+}
+
+func TestNewAPIKey(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	key, plaintext, err := NewAPIKey(" Okta ", "admin-1", []string{APIKeyScopeSCIM}, now)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(plaintext, APIKeyPrefix))
+	assert.Equal(t, "Okta", key.Name)
+	assert.Equal(t, HashAPIKey(plaintext), key.KeyHash)
+	assert.NotContains(t, key.KeyHash, plaintext, "the plaintext key is not stored")
+	assert.True(t, strings.HasPrefix(plaintext, key.DisplayPrefix))
+	assert.True(t, key.HasScope(APIKeyScopeSCIM))
+	assert.False(t, key.HasScope("tasks"))
+
+	_, _, err = NewAPIKey("", "admin-1", []string{APIKeyScopeSCIM}, now)
+	assert.ErrorIs(t, err, ErrAPIKeyNameRequired)
+	_, _, err = NewAPIKey("key", "admin-1", []string{"tasks"}, now)
+	assert.ErrorIs(t, err, ErrAPIKeyInvalidScope)
+	_, _, err = NewAPIKey("key", "admin-1", nil, now)
+	assert.ErrorIs(t, err, ErrAPIKeyInvalidScope)
+
+	key.Revoke(now)
+	assert.True(t, key.IsRevoked())
+}
+
+func TestSSOConnection_Normalize(t *testing.T) {
+	conn := &SSOConnection{
+		Name:     " Example ",
+		Domains:  []string{"Example.com", "@example.com", "sub.example.com"},
+		Issuer:   "https://idp.example.com/",
+		ClientID: " client ",
+	}
+	require.NoError(t, conn.Normalize())
+	assert.Equal(t, "Example", conn.Name)
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, conn.Domains)
+	assert.Equal(t, "https://idp.example.com", conn.Issuer)
+	assert.Equal(t, "client", conn.ClientID)
+	assert.True(t, conn.HasDomain("Taro@EXAMPLE.com"))
+	assert.False(t, conn.HasDomain("taro@other.com"))
+
+	invalid := []*SSOConnection{
+		{Name: "x", Domains: []string{"example.com"}, Issuer: "http://idp.example.com", ClientID: "c"},
+		{Name: "x", Domains: []string{"localhost"}, Issuer: "https://idp.example.com", ClientID: "c"},
+		{Name: "x", Domains: nil, Issuer: "https://idp.example.com", ClientID: "c"},
+		{Name: "x", Domains: []string{"example.com"}, Issuer: "https://idp.example.com"},
+		{Name: " ", Domains: []string{"example.com"}, Issuer: "https://idp.example.com", ClientID: "c"},
+	}
+	for _, c := range invalid {
+		assert.ErrorIs(t, c.Normalize(), ErrSSOConnectionInvalid)
+	}
+
+	local := &SSOConnection{Name: "dev", Domains: []string{"example.com"}, Issuer: "http://localhost:8081", ClientID: "c"}
+	assert.NoError(t, local.Normalize(), "plain http is allowed for a local IdP")
+}
+
+func TestSSOConnection_MapRole(t *testing.T) {
+	conn := &SSOConnection{RoleClaim: "groups", AdminValues: []string{"yotei-admins"}}
+	assert.Equal(t, RoleAdmin, conn.MapRole(map[string]interface{}{"groups": []interface{}{"staff", "yotei-admins"}}))
+	assert.Equal(t, RoleAdmin, conn.MapRole(map[string]interface{}{"groups": "yotei-admins"}))
+	assert.Equal(t, RoleUser, conn.MapRole(map[string]interface{}{"groups": []interface{}{"staff"}}))
+	assert.Equal(t, RoleUser, conn.MapRole(map[string]interface{}{}))
+
+	assert.Empty(t, (&SSOConnection{}).MapRole(map[string]interface{}{"groups": "yotei-admins"}), "roles are left alone without a claim")
+}
+
+func TestSSOState_SignAndParse(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	secret := []byte("secret")
+	state := &SSOState{ConnectionID: "conn-1", State: "s", Nonce: "n", CodeVerifier: "v", ReturnTo: "/tasks", ExpiresAt: now.Add(SSOStateDuration)}
+
+	value, err := SignSSOState(state, secret)
+	require.NoError(t, err)
+
+	parsed, err := ParseSSOState(value, secret, now)
+	require.NoError(t, err)
+	assert.Equal(t, state.Nonce, parsed.Nonce)
+	assert.Equal(t, state.ReturnTo, parsed.ReturnTo)
+	assert.True(t, state.ExpiresAt.Equal(parsed.ExpiresAt))
+
+	_, err = ParseSSOState(value, []byte("other"), now)
+	assert.ErrorIs(t, err, ErrSSOStateInvalid, "signed with another secret")
+	_, err = ParseSSOState(value, secret, now.Add(SSOStateDuration))
+	assert.ErrorIs(t, err, ErrSSOStateInvalid, "expired")
+	_, err = ParseSSOState("x"+value, secret, now)
+	assert.ErrorIs(t, err, ErrSSOStateInvalid, "tampered")
+}
+
+func TestValidateUsername(t *testing.T) {
+	valid := []string{"alice", "bob_01", "taro.yamada", "山田太郎", "a-b"}
+	for _, username := range valid {
+		assert.NoError(t, ValidateUsername(username), username)
+	}
+
+	invalid := []string{"ab", strings.Repeat("a", MaxUsernameLength+1), "has space", "alice@example", "alice.", "alice-", "#tag"}
+	for _, username := range invalid {
+		assert.ErrorIs(t, ValidateUsername(username), ErrInvalidUsername, username)
+	}
+}
+
+func TestUsernamePolicy(t *testing.T) {
+	policy := NewUsernamePolicy([]string{" Yotei-Staff ", ""}, time.Hour, 24*time.Hour)
+
+	assert.True(t, policy.IsReserved("Admin"))
+	assert.True(t, policy.IsReserved("yotei-staff"))
+	assert.False(t, policy.IsReserved("alice"))
+
+	assert.ErrorIs(t, policy.Validate("ADMIN"), ErrUsernameReserved)
+	assert.ErrorIs(t, policy.Validate("a b"), ErrInvalidUsername)
+	assert.NoError(t, policy.Validate("alice"))
+
+	changedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	assert.True(t, policy.NextChangeAllowedAt(nil).IsZero())
+	assert.Equal(t, changedAt.Add(time.Hour), policy.NextChangeAllowedAt(NewUsernameChange(uuid.New(), "old", "new", changedAt)))
+
+	unlimited := NewUsernamePolicy(nil, 0, 0)
+	assert.True(t, unlimited.NextChangeAllowedAt(NewUsernameChange(uuid.New(), "old", "new", changedAt)).IsZero())
+}
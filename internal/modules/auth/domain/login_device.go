@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// LoginVerificationCodeTTL は不審なログインの確認コードの有効期間
+	LoginVerificationCodeTTL = 15 * time.Minute
+	// MaxLoginVerificationAttempts は確認コードの入力を受け付ける回数
+	MaxLoginVerificationAttempts = 5
+	// ImpossibleTravelSpeedKmh はこの速度を超える移動を不可能な移動とみなす（旅客機の巡航速度）
+	ImpossibleTravelSpeedKmh = 900
+	// minTravelDistanceKm はGeoIPの誤差とみなして無視する距離
+	minTravelDistanceKm = 100
+	earthRadiusKm       = 6371
+)
+
+// 不審なログインの理由
+const (
+	LoginRiskNewDevice        = "new_device"
+	LoginRiskNewCountry       = "new_country"
+	LoginRiskImpossibleTravel = "impossible_travel"
+)
+
+var (
+	ErrLoginVerificationExpired  = errors.New("login verification code expired")
+	ErrLoginVerificationInvalid  = errors.New("invalid login verification code")
+	ErrLoginVerificationExceeded = errors.New("too many login verification attempts")
+)
+
+// GeoLocation はIPアドレスから推定した位置
+type GeoLocation struct {
+	Country   string  `json:"country" example:"JP"` // ISO 3166-1 alpha-2
+	Latitude  float64 `json:"latitude" example:"35.68"`
+	Longitude float64 `json:"longitude" example:"139.76"`
+} // @name GeoLocation
+
+// LoginDevice はユーザーがログインした端末（User-Agentで識別する）
+type LoginDevice struct {
+	ID          string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID      string `json:"-"`
+	Fingerprint string `json:"-"`
+	UserAgent   string `json:"user_agent" example:"Mozilla/5.0"`
+	// 信頼済みの端末（最初の端末、ユーザーが信頼した端末）は確認コードを求めない
+	Trusted bool `json:"trusted" example:"true"`
+	// 確認コードでログインした際に記憶した端末の期限（期限内にCookieを提示した場合は確認コードを求めない）
+	TrustedUntil   *time.Time   `json:"trusted_until,omitempty" example:"2024-07-01T09:00:00Z"`
+	TrustTokenHash string       `json:"-"`
+	LastIP         string       `json:"last_ip" example:"203.0.113.10"`
+	LastLocation   *GeoLocation `json:"last_location,omitempty"`
+	// 確認コードの入力待ちの場合に設定する（コードはハッシュのみ保存する）
+	VerificationCodeHash  string     `json:"-"`
+	VerificationExpiresAt *time.Time `json:"-"`
+	VerificationAttempts  int        `json:"-"`
+	CreatedAt             time.Time  `json:"created_at" example:"2024-06-01T09:00:00Z"`
+	// ログインが完了した最後の日時（確認コードの入力待ちで一度もログインしていない端末はゼロ値）
+	LastSeenAt time.Time `json:"last_seen_at" example:"2024-06-01T09:00:00Z"`
+} // @name LoginDevice
+
+// NewLoginDevice は新しいLoginDeviceを作成する（IDは保存時に設定する）
+func NewLoginDevice(userID, userAgent string, now time.Time) *LoginDevice {
+	return &LoginDevice{
+		UserID:      userID,
+		Fingerprint: DeviceFingerprint(userAgent),
+		UserAgent:   truncate(userAgent, maxUserAgentLength),
+		CreatedAt:   now,
+	}
+}
+
+// DeviceFingerprint はUser-Agentから端末を識別する値を作成する
+func DeviceFingerprint(userAgent string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(userAgent)))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsKnown はログインが完了したことのある端末か
+func (d *LoginDevice) IsKnown() bool {
+	return !d.LastSeenAt.IsZero()
+}
+
+// Seen はログインが完了したことを記録する（確認コードの入力待ちは解除する）
+func (d *LoginDevice) Seen(ip string, location *GeoLocation, at time.Time) {
+	d.LastIP = ip
+	if location != nil {
+		d.LastLocation = location
+	}
+	d.LastSeenAt = at
+	d.clearVerification()
+}
+
+// StartVerification は確認コードの入力待ちにする
+func (d *LoginDevice) StartVerification(code string, now time.Time) {
+	expiresAt := now.Add(LoginVerificationCodeTTL)
+	d.VerificationCodeHash = hashVerificationCode(code)
+	d.VerificationExpiresAt = &expiresAt
+	d.VerificationAttempts = 0
+}
+
+// Verify は確認コードを検証する（失敗した場合は入力回数を数える）
+// 確認しただけでは信頼済みにしない（記憶する場合はRememberを使う）
+func (d *LoginDevice) Verify(code string, now time.Time) error {
+	if d.VerificationExpiresAt == nil || now.After(*d.VerificationExpiresAt) {
+		return ErrLoginVerificationExpired
+	}
+	if d.VerificationAttempts >= MaxLoginVerificationAttempts {
+		return ErrLoginVerificationExceeded
+	}
+	if subtle.ConstantTimeCompare([]byte(hashVerificationCode(code)), []byte(d.VerificationCodeHash)) != 1 {
+		d.VerificationAttempts++
+		return ErrLoginVerificationInvalid
+	}
+	return nil
+}
+
+func (d *LoginDevice) clearVerification() {
+	d.VerificationCodeHash = ""
+	d.VerificationExpiresAt = nil
+	d.VerificationAttempts = 0
+}
+
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoginAlertSettings は不審なログインの通知設定
+type LoginAlertSettings struct {
+	UserID string `json:"-"`
+	// 不審なログインを通知・メールで知らせる
+	AlertsEnabled bool `json:"alerts_enabled" example:"true"`
+	// 信頼済みでない端末からの不審なログインに、メールで送る確認コードの入力を求める
+	RequireVerification bool      `json:"require_verification" example:"false"`
+	UpdatedAt           time.Time `json:"updated_at" example:"2024-06-01T09:00:00Z"`
+} // @name LoginAlertSettings
+
+// DefaultLoginAlertSettings は設定を保存していないユーザーの通知設定
+func DefaultLoginAlertSettings(userID string) *LoginAlertSettings {
+	return &LoginAlertSettings{
+		UserID:        userID,
+		AlertsEnabled: true,
+	}
+}
+
+// LoginRisk はログインの不審な点
+type LoginRisk struct {
+	NewDevice        bool
+	NewCountry       bool
+	ImpossibleTravel bool
+}
+
+// IsAnomalous は不審な点があるか
+func (r LoginRisk) IsAnomalous() bool {
+	return r.NewDevice || r.NewCountry || r.ImpossibleTravel
+}
+
+// Reasons は不審な点の一覧を返す
+func (r LoginRisk) Reasons() []string {
+	var reasons []string
+	if r.NewDevice {
+		reasons = append(reasons, LoginRiskNewDevice)
+	}
+	if r.NewCountry {
+		reasons = append(reasons, LoginRiskNewCountry)
+	}
+	if r.ImpossibleTravel {
+		reasons = append(reasons, LoginRiskImpossibleTravel)
+	}
+	return reasons
+}
+
+// AssessLoginRisk はユーザーのこれまでの端末と比べてログインの不審な点を判定する
+// ログインしたことのある端末がない場合（最初のログイン）は不審とみなさない
+// locationがnil（GeoIPが無効・位置が不明）の場合は国と移動の判定を行わない
+func AssessLoginRisk(devices []*LoginDevice, fingerprint string, location *GeoLocation, at time.Time) LoginRisk {
+	var risk LoginRisk
+	var latest *LoginDevice
+	knownDevice := false
+	countries := map[string]bool{}
+	for _, d := range devices {
+		if !d.IsKnown() {
+			continue
+		}
+		if d.Fingerprint == fingerprint {
+			knownDevice = true
+		}
+		if d.LastLocation != nil {
+			countries[d.LastLocation.Country] = true
+			if latest == nil || d.LastSeenAt.After(latest.LastSeenAt) {
+				latest = d
+			}
+		}
+	}
+
+	hasHistory := false
+	for _, d := range devices {
+		if d.IsKnown() {
+			hasHistory = true
+			break
+		}
+	}
+	if !hasHistory {
+		return risk
+	}
+
+	risk.NewDevice = !knownDevice
+	if location == nil || latest == nil {
+		return risk
+	}
+
+	risk.NewCountry = location.Country != "" && !countries[location.Country]
+
+	distance := DistanceKm(*latest.LastLocation, *location)
+	if distance >= minTravelDistanceKm {
+		hours := at.Sub(latest.LastSeenAt).Hours()
+		risk.ImpossibleTravel = hours <= 0 || distance/hours > ImpossibleTravelSpeedKmh
+	}
+	return risk
+}
+
+// DistanceKm は2地点間の大円距離（km）を返す
+func DistanceKm(a, b GeoLocation) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(b.Latitude - a.Latitude)
+	dLon := toRad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Latitude))*math.Cos(toRad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// LoginAlert は不審なログインの通知内容
+type LoginAlert struct {
+	UserID    string
+	Email     string
+	Username  string
+	Reasons   []string
+	IPAddress string
+	UserAgent string
+	Location  *GeoLocation
+	At        time.Time
+	// 確認コードの入力を求める場合のコード（求めない場合は空）
+	VerificationCode string
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// セキュリティイベントの種類
+const (
+	SecurityEventLoginSucceeded     = "login_succeeded"
+	SecurityEventLoginFailed        = "login_failed"
+	SecurityEventTokenRefreshed     = "token_refreshed"
+	SecurityEventTokenRefreshFailed = "token_refresh_failed"
+	SecurityEventPasswordChanged    = "password_changed"
+	SecurityEventPermissionDenied   = "permission_denied"
+	SecurityEventSuspiciousLogin    = "suspicious_login"
+	SecurityEventAccountDeactivated = "account_deactivated"
+	SecurityEventAccountReactivated = "account_reactivated"
+	SecurityEventUsernameChanged    = "username_changed"
+)
+
+// SecurityEventTypes は記録するセキュリティイベントの種類の一覧
+var SecurityEventTypes = []string{
+	SecurityEventLoginSucceeded,
+	SecurityEventLoginFailed,
+	SecurityEventTokenRefreshed,
+	SecurityEventTokenRefreshFailed,
+	SecurityEventPasswordChanged,
+	SecurityEventPermissionDenied,
+	SecurityEventSuspiciousLogin,
+	SecurityEventAccountDeactivated,
+	SecurityEventAccountReactivated,
+	SecurityEventUsernameChanged,
+}
+
+// 記録する文字列の最大長（ヘッダーなどクライアントが自由に送れる値を切り詰める）
+const (
+	maxUserAgentLength = 512
+	maxDetailLength    = 255
+)
+
+// SecurityEvent は認証・認可に関するイベントの監査ログ
+type SecurityEvent struct {
+	ID        string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID    string    `json:"user_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // ユーザーを特定できない場合は空
+	Type      string    `json:"type" example:"login_failed"`
+	IPAddress string    `json:"ip_address" example:"203.0.113.10"`
+	UserAgent string    `json:"user_agent" example:"Mozilla/5.0"`
+	Detail    string    `json:"detail,omitempty" example:"invalid password"` // 失敗の理由・拒否されたルートなど
+	CreatedAt time.Time `json:"created_at" example:"2024-06-01T09:00:00Z"`
+} // @name SecurityEvent
+
+// NewSecurityEvent は新しいSecurityEventを作成する（IDと日時は記録時に設定する）
+func NewSecurityEvent(eventType, userID, detail string) *SecurityEvent {
+	return &SecurityEvent{
+		UserID: userID,
+		Type:   eventType,
+		Detail: truncate(detail, maxDetailLength),
+	}
+}
+
+// SetClient はリクエスト元のIPアドレスとUser-Agentを設定する
+func (e *SecurityEvent) SetClient(client ClientInfo) {
+	e.IPAddress = client.IPAddress
+	e.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+}
+
+// IsValidSecurityEventType はセキュリティイベントの種類が有効か確認する
+func IsValidSecurityEventType(eventType string) bool {
+	for _, t := range SecurityEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// SecurityEventFilter はセキュリティイベントの検索条件
+type SecurityEventFilter struct {
+	UserID string    // 空の場合は全ユーザー
+	Type   string    // 空の場合は全種類
+	Since  time.Time // この日時以降のイベント（保存期間の開始）
+	Before time.Time // この日時より前のイベント（ページングのカーソル、ゼロ値の場合は指定なし）
+	Limit  int
+}
+
+// ClientInfo はリクエスト元の情報
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+	// 記憶した端末のCookieの値（ない場合は空）
+	DeviceTrustCookie string
+}
+
+// clientInfoKey はcontextにリクエスト元の情報を格納するためのキー
+type clientInfoKey struct{}
+
+// ContextWithClientInfo はリクエスト元の情報を格納したcontextを返す
+func ContextWithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+// ClientInfoFromContext はcontextに格納されたリクエスト元の情報を返す（未設定の場合はゼロ値）
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	if ctx == nil {
+		return ClientInfo{}
+	}
+	client, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return client
+}
+
+// truncate は文字列をUTF-8の文字の途中で切らずにmaxバイト以内に切り詰める
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && s[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return s[:cut]
+}
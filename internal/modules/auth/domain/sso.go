@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// SSOStateCookie はIdPへのリダイレクト中のログイン状態を保存するCookieの名前
+	SSOStateCookie = "sso_state"
+	// SSOStateDuration はIdPでの認証を待つ期間
+	SSOStateDuration = 10 * time.Minute
+	// SSOSecurityDetail はSSOでのログインを監査ログで区別するための詳細
+	SSOSecurityDetail = "sso"
+
+	maxSSOConnectionNameLength = 100
+)
+
+var (
+	ErrSSOConnectionInvalid = errors.New("invalid sso connection")
+	ErrSSOStateInvalid      = errors.New("invalid or expired sso state")
+	ErrSSOEmailNotVerified  = errors.New("email address is not verified by the identity provider")
+	ErrSSODomainMismatch    = errors.New("email domain is not allowed for this sso connection")
+	ErrSSOUserNotFound      = errors.New("user is not provisioned")
+	ErrSSOAccountInactive   = errors.New("account is deactivated")
+)
+
+// SSOConnection は企業テナントのOIDCによるシングルサインオンの設定
+// メールアドレスのドメインでテナントを判定する
+type SSOConnection struct {
+	ID   string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name string `json:"name" example:"Example Inc."`
+	// 対象のメールアドレスのドメイン（小文字）
+	Domains      []string `json:"domains" example:"example.com"`
+	Issuer       string   `json:"issuer" example:"https://example.okta.com"`
+	ClientID     string   `json:"client_id" example:"0oa1abcd"`
+	ClientSecret string   `json:"-"`
+	// trueの場合、対象ドメインのユーザー（管理者を除く）はパスワードでログインできない
+	Enforced bool `json:"enforced" example:"true"`
+	// trueの場合、存在しないユーザーを初回ログイン時に作成する
+	JITProvisioning bool `json:"jit_provisioning" example:"true"`
+	// 役割を判定するIDトークンのクレーム（例: groups、空の場合は役割を変更しない）
+	RoleClaim string `json:"role_claim,omitempty" example:"groups"`
+	// RoleClaimにいずれかの値が含まれる場合は管理者、含まれない場合は一般ユーザーにする
+	AdminValues []string  `json:"admin_values,omitempty" example:"yotei-admins"`
+	CreatedAt   time.Time `json:"created_at" example:"2024-06-01T09:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2024-06-01T09:00:00Z"`
+} // @name SSOConnection
+
+// Normalize は名前・ドメイン・Issuerの表記を揃えて検証する
+func (c *SSOConnection) Normalize() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" || len(c.Name) > maxSSOConnectionNameLength {
+		return ErrSSOConnectionInvalid
+	}
+
+	domains := make([]string, 0, len(c.Domains))
+	seen := make(map[string]bool, len(c.Domains))
+	for _, d := range c.Domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" || strings.ContainsAny(d, "@/ ") || !strings.Contains(d, ".") {
+			return ErrSSOConnectionInvalid
+		}
+		if !seen[d] {
+			seen[d] = true
+			domains = append(domains, d)
+		}
+	}
+	if len(domains) == 0 {
+		return ErrSSOConnectionInvalid
+	}
+	c.Domains = domains
+
+	c.Issuer = strings.TrimRight(strings.TrimSpace(c.Issuer), "/")
+	issuer, err := url.Parse(c.Issuer)
+	if err != nil || issuer.Host == "" || (issuer.Scheme != "https" && !isLocalHost(issuer.Hostname())) {
+		return ErrSSOConnectionInvalid
+	}
+	c.ClientID = strings.TrimSpace(c.ClientID)
+	if c.ClientID == "" {
+		return ErrSSOConnectionInvalid
+	}
+	c.RoleClaim = strings.TrimSpace(c.RoleClaim)
+	return nil
+}
+
+// HasDomain はメールアドレスが対象ドメインのものか
+func (c *SSOConnection) HasDomain(email string) bool {
+	domain := EmailDomain(email)
+	for _, d := range c.Domains {
+		if d == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// MapRole はIDトークンのクレームから役割を判定する（RoleClaimが未設定の場合は空文字）
+// クレームは文字列または文字列の配列に対応する
+func (c *SSOConnection) MapRole(claims map[string]interface{}) string {
+	if c.RoleClaim == "" {
+		return ""
+	}
+	var values []string
+	switch v := claims[c.RoleClaim].(type) {
+	case string:
+		values = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	for _, value := range values {
+		for _, admin := range c.AdminValues {
+			if value == admin {
+				return RoleAdmin
+			}
+		}
+	}
+	return RoleUser
+}
+
+// EmailDomain はメールアドレスのドメインを小文字で返す
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
+
+func isLocalHost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1"
+}
+
+// SSOIdentity はIdPで認証したユーザー（検証済みのIDトークンのクレーム）
+type SSOIdentity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	Nonce             string
+	Claims            map[string]interface{}
+}
+
+// SSOIdentityLink はIdPのユーザー（接続ごとのsubject）とYotei+のユーザーの紐付け
+type SSOIdentityLink struct {
+	ConnectionID string
+	Subject      string
+	UserID       string
+	CreatedAt    time.Time
+}
+
+// SSOState はIdPへのリダイレクトからコールバックまでのログイン状態
+// 署名したCookieでブラウザに保存し、コールバックのstateと照合する
+type SSOState struct {
+	ConnectionID string    `json:"c"`
+	State        string    `json:"s"`
+	Nonce        string    `json:"n"`
+	CodeVerifier string    `json:"v"`
+	ReturnTo     string    `json:"r,omitempty"`
+	ExpiresAt    time.Time `json:"e"`
+}
+
+// SignSSOState はログイン状態に署名したCookieの値を作成する（"ペイロード.署名"）
+func SignSSOState(state *SSOState, secret []byte) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + ssoStateSignature(encoded, secret), nil
+}
+
+// ParseSSOState はCookieの署名と期限を検証してログイン状態を返す
+func ParseSSOState(value string, secret []byte, now time.Time) (*SSOState, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(ssoStateSignature(parts[0], secret))) {
+		return nil, ErrSSOStateInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrSSOStateInvalid
+	}
+	var state SSOState
+	if err := json.Unmarshal(payload, &state); err != nil || !now.Before(state.ExpiresAt) {
+		return nil, ErrSSOStateInvalid
+	}
+	return &state, nil
+}
+
+func ssoStateSignature(payload string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("sso_state:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
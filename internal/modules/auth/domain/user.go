@@ -21,6 +21,7 @@ type User struct {
 	Role          string         `json:"role"`
 	EmailVerified bool           `json:"email_verified"`
 	LastLogin     *time.Time     `json:"last_login"`
+	DeactivatedAt *time.Time     `json:"deactivated_at,omitempty"` // 無効化されたアカウントはログイン・トークンの更新ができない
 	RefreshTokens []RefreshToken `json:"-"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
@@ -59,6 +60,29 @@ func (u *User) UpdateLastLogin() {
 	u.UpdatedAt = now
 }
 
+// IsActive はアカウントが無効化されていないかを返す
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// Deactivate はアカウントを無効化する（既に無効化されている場合は何もしない）
+func (u *User) Deactivate(now time.Time) {
+	if u.DeactivatedAt != nil {
+		return
+	}
+	u.DeactivatedAt = &now
+	u.UpdatedAt = now
+}
+
+// Activate は無効化したアカウントを有効に戻す
+func (u *User) Activate(now time.Time) {
+	if u.DeactivatedAt == nil {
+		return
+	}
+	u.DeactivatedAt = nil
+	u.UpdatedAt = now
+}
+
 // IsAdmin はユーザーが管理者かどうかを返す
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
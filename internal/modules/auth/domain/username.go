@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ユーザー名の長さ制限（メンションの解析と合わせる）
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 30
+)
+
+// ユーザー名の変更の既定値
+const (
+	// DefaultUsernameChangeCooldown は次にユーザー名を変更できるまでの期間
+	DefaultUsernameChangeCooldown = 30 * 24 * time.Hour
+	// DefaultUsernameHoldPeriod は変更前のユーザー名を他のユーザーが使えない期間（この間は以前のユーザー名へのメンションも本人に届く）
+	DefaultUsernameHoldPeriod = 90 * 24 * time.Hour
+)
+
+var (
+	// ErrUsernameTaken は他のユーザーが使用中・保持中のユーザー名（既存のエラーメッセージに合わせる）
+	ErrUsernameTaken          = errors.New("username already exists")
+	ErrUsernameReserved       = errors.New("username is reserved")
+	ErrInvalidUsername        = errors.New("invalid username")
+	ErrUsernameChangeCooldown = errors.New("username was changed recently")
+)
+
+// usernamePattern はメンション（@username）として解析できる文字のみに一致する
+var usernamePattern = regexp.MustCompile(`^[\p{L}\p{N}_.\-]+$`)
+
+// DefaultReservedUsernames はシステム・運営と紛らわしい、またはURLのパスと衝突するため使えないユーザー名
+var DefaultReservedUsernames = []string{
+	"admin", "administrator", "root", "system", "sysadmin", "superuser",
+	"support", "help", "info", "contact", "security", "abuse", "postmaster", "webmaster",
+	"staff", "moderator", "official", "team",
+	"yotei", "yotei-plus", "yoteiplus",
+	"api", "www", "mail", "app", "static", "assets", "docs", "status",
+	"me", "public", "settings", "login", "logout", "signup", "register", "auth",
+	"everyone", "here", "channel", "all",
+	"null", "undefined", "anonymous", "unknown", "deleted",
+}
+
+// ValidateUsername はユーザー名の長さと文字を検証する
+// 末尾の「.」「-」はメンションの解析で取り除かれるため使えない
+func ValidateUsername(username string) error {
+	length := len([]rune(username))
+	if length < MinUsernameLength || length > MaxUsernameLength {
+		return fmt.Errorf("%w: must be %d to %d characters", ErrInvalidUsername, MinUsernameLength, MaxUsernameLength)
+	}
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("%w: only letters, numbers, '_', '.' and '-' are allowed", ErrInvalidUsername)
+	}
+	if strings.HasSuffix(username, ".") || strings.HasSuffix(username, "-") {
+		return fmt.Errorf("%w: must not end with '.' or '-'", ErrInvalidUsername)
+	}
+	return nil
+}
+
+// UsernamePolicy はユーザー名の予約語と変更の制限
+type UsernamePolicy struct {
+	reserved map[string]bool
+
+	// ChangeCooldown は次にユーザー名を変更できるまでの期間（0の場合は制限しない）
+	ChangeCooldown time.Duration
+	// HoldPeriod は変更前のユーザー名を他のユーザーが使えない期間（0の場合はすぐに使える）
+	HoldPeriod time.Duration
+}
+
+// NewUsernamePolicy は既定の予約語に extraReserved を加えたポリシーを作成する
+func NewUsernamePolicy(extraReserved []string, changeCooldown, holdPeriod time.Duration) *UsernamePolicy {
+	p := &UsernamePolicy{
+		reserved:       make(map[string]bool),
+		ChangeCooldown: changeCooldown,
+		HoldPeriod:     holdPeriod,
+	}
+	for _, words := range [][]string{DefaultReservedUsernames, extraReserved} {
+		for _, word := range words {
+			if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+				p.reserved[word] = true
+			}
+		}
+	}
+	return p
+}
+
+// DefaultUsernamePolicy は既定の予約語・期間のポリシーを返す
+func DefaultUsernamePolicy() *UsernamePolicy {
+	return NewUsernamePolicy(nil, DefaultUsernameChangeCooldown, DefaultUsernameHoldPeriod)
+}
+
+// IsReserved は予約語のユーザー名か判定する（大文字小文字を区別しない）
+func (p *UsernamePolicy) IsReserved(username string) bool {
+	return p.reserved[strings.ToLower(username)]
+}
+
+// Validate は新しいユーザー名の形式と予約語を検証する
+func (p *UsernamePolicy) Validate(username string) error {
+	if err := ValidateUsername(username); err != nil {
+		return err
+	}
+	if p.IsReserved(username) {
+		return ErrUsernameReserved
+	}
+	return nil
+}
+
+// NextChangeAllowedAt は最後の変更から次にユーザー名を変更できる日時を返す（変更したことがない場合はゼロ値）
+func (p *UsernamePolicy) NextChangeAllowedAt(last *UsernameChange) time.Time {
+	if last == nil || p.ChangeCooldown <= 0 {
+		return time.Time{}
+	}
+	return last.ChangedAt.Add(p.ChangeCooldown)
+}
+
+// UsernameChange はユーザー名の変更履歴
+// 変更前のユーザー名を保持期間の間は本人のものとして扱い、以前のユーザー名へのメンションを解決するために使う
+type UsernameChange struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	OldUsername string    `json:"old_username"`
+	NewUsername string    `json:"new_username"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// NewUsernameChange は新しいユーザー名の変更履歴を作成する
+func NewUsernameChange(userID uuid.UUID, oldUsername, newUsername string, changedAt time.Time) *UsernameChange {
+	return &UsernameChange{
+		ID:          uuid.New(),
+		UserID:      userID,
+		OldUsername: oldUsername,
+		NewUsername: newUsername,
+		ChangedAt:   changedAt,
+	}
+}
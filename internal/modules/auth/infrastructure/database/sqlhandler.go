@@ -20,7 +20,7 @@ func NewSqlHandler() SqlHandler {
 	}
 
 	// common/databaseからDBコネクションを取得
-	conn, err := commonDB.NewMySQLConnection(config)
+	conn, err := commonDB.NewConnection(config)
 	if err != nil {
 		panic(err.Error())
 	}
@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	deviceService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/device"
+)
+
+// geoIPTimeout はログインを待たせすぎないためのGeoIPの問い合わせのタイムアウト
+const geoIPTimeout = 2 * time.Second
+
+// HTTPGeoIPResolver はHTTPのGeoIPサービスでIPアドレスの位置を推定するゲートウェイ実装
+// URLの{ip}をIPアドレスに置き換えて問い合わせ、JSONの国コードと緯度経度を読み取る
+// （ipapi.co・ip-api.com・ipinfo.io形式などのフィールド名に対応する）
+type HTTPGeoIPResolver struct {
+	urlTemplate string
+	httpClient  *http.Client
+}
+
+// NewGeoIPResolver はGeoIPのゲートウェイを作成する（URLが未設定の場合はnilを返し、国と移動の判定を行わない）
+func NewGeoIPResolver(urlTemplate string) deviceService.GeoIPResolver {
+	if urlTemplate == "" {
+		return nil
+	}
+	return &HTTPGeoIPResolver{
+		urlTemplate: urlTemplate,
+		httpClient:  &http.Client{Timeout: geoIPTimeout},
+	}
+}
+
+// geoIPResponse はGeoIPサービスのレスポンスのうち使用するフィールド
+type geoIPResponse struct {
+	CountryCode  string   `json:"country_code"`
+	CountryCode2 string   `json:"countryCode"`
+	Country      string   `json:"country"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
+	Lat          *float64 `json:"lat"`
+	Lon          *float64 `json:"lon"`
+	Loc          string   `json:"loc"` // "35.6895,139.6917"
+}
+
+// Lookup はIPアドレスの位置を返す（プライベートアドレスや位置が分からない場合はnil, nil）
+func (g *HTTPGeoIPResolver) Lookup(ctx context.Context, ip string) (*domain.GeoLocation, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsLoopback() || parsed.IsPrivate() || parsed.IsLinkLocalUnicast() || parsed.IsUnspecified() {
+		return nil, nil
+	}
+
+	endpoint := strings.ReplaceAll(g.urlTemplate, "{ip}", url.PathEscape(ip))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GeoIP request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GeoIP: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GeoIP returned status %d", resp.StatusCode)
+	}
+
+	var body geoIPResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode GeoIP response: %w", err)
+	}
+	return body.location(), nil
+}
+
+func (r geoIPResponse) location() *domain.GeoLocation {
+	country := r.CountryCode
+	if country == "" {
+		country = r.CountryCode2
+	}
+	if country == "" && len(r.Country) == 2 {
+		country = r.Country
+	}
+
+	lat, lon := r.Latitude, r.Longitude
+	if lat == nil || lon == nil {
+		lat, lon = r.Lat, r.Lon
+	}
+	if (lat == nil || lon == nil) && r.Loc != "" {
+		var la, lo float64
+		if _, err := fmt.Sscanf(r.Loc, "%f,%f", &la, &lo); err == nil {
+			lat, lon = &la, &lo
+		}
+	}
+	if country == "" || lat == nil || lon == nil {
+		return nil
+	}
+	return &domain.GeoLocation{
+		Country:   strings.ToUpper(country),
+		Latitude:  *lat,
+		Longitude: *lon,
+	}
+}
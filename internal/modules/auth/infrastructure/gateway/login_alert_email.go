@@ -0,0 +1,213 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/smtpmail"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// LoginAlertEmailGateway は不審なログインのメールを送信するインターフェース
+type LoginAlertEmailGateway interface {
+	SendLoginAlertEmail(ctx context.Context, alert domain.LoginAlert) error
+}
+
+// SMTPLoginAlertEmailGateway はSMTPで不審なログインのメールを送信するゲートウェイ実装
+type SMTPLoginAlertEmailGateway struct {
+	config *config.Config
+	logger logger.Logger
+	// SMTPサーバーが応答しない・失敗が続く場合に送信をやめるサーキットブレーカー（nilの場合は制限しない）
+	breaker *circuitbreaker.Breaker
+	// SMTP送信関数（smtpmail.Send）
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewLoginAlertEmailGateway は設定に応じた不審なログインのメールゲートウェイを作成する
+// SMTP_HOSTが未設定の場合は送信内容をログに出力するだけのゲートウェイを返す
+func NewLoginAlertEmailGateway(config *config.Config, breaker *circuitbreaker.Breaker, logger logger.Logger) LoginAlertEmailGateway {
+	if config.External.SMTPHost == "" {
+		return &LogLoginAlertEmailGateway{logger: logger}
+	}
+	return &SMTPLoginAlertEmailGateway{
+		config:   config,
+		logger:   logger,
+		breaker:  breaker,
+		sendMail: smtpmail.Send,
+	}
+}
+
+// SendLoginAlertEmail は不審なログインのメールを送信する
+func (g *SMTPLoginAlertEmailGateway) SendLoginAlertEmail(ctx context.Context, alert domain.LoginAlert) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(g.config.External.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_FROM address: %w", err)
+	}
+	to, err := mail.ParseAddress(alert.Email)
+	if err != nil {
+		return fmt.Errorf("invalid user email address: %w", err)
+	}
+
+	msg, err := BuildLoginAlertMessage(from, to, alert)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if g.config.External.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", g.config.External.SMTPUsername, g.config.External.SMTPPassword, g.config.External.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(g.config.External.SMTPHost, g.config.External.SMTPPort)
+	err = g.breaker.Do(ctx, func(ctx context.Context) error {
+		return g.sendMail(ctx, addr, auth, from.Address, []string{to.Address}, msg)
+	})
+	if err != nil {
+		g.logger.Error("Failed to send login alert email",
+			logger.Any("userID", alert.UserID),
+			logger.Error(err))
+		return fmt.Errorf("failed to send login alert email: %w", err)
+	}
+
+	g.logger.Info("Login alert email sent", logger.Any("userID", alert.UserID))
+	return nil
+}
+
+// LogLoginAlertEmailGateway はSMTP未設定時に不審なログインのメールをログ出力するゲートウェイ実装（開発用）
+type LogLoginAlertEmailGateway struct {
+	logger logger.Logger
+}
+
+// SendLoginAlertEmail は不審なログインのメールの内容をログに出力する
+func (g *LogLoginAlertEmailGateway) SendLoginAlertEmail(ctx context.Context, alert domain.LoginAlert) error {
+	g.logger.Info("Login alert email (SMTP not configured)",
+		logger.Any("userID", alert.UserID),
+		logger.Any("to", alert.Email),
+		logger.Any("reasons", alert.Reasons),
+		logger.Any("verificationCode", alert.VerificationCode))
+	return nil
+}
+
+// BuildLoginAlertMessage はテキスト形式の不審なログインのメールを作成する
+func BuildLoginAlertMessage(from, to *mail.Address, alert domain.LoginAlert) ([]byte, error) {
+	data := newLoginAlertEmailData(alert)
+
+	var text bytes.Buffer
+	if err := loginAlertTextTemplate.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render login alert email: %w", err)
+	}
+
+	var msg bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.BEncoding.Encode("UTF-8", data.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write(text.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// loginAlertEmailData はメールテンプレートに渡すデータ
+type loginAlertEmailData struct {
+	Subject          string
+	Username         string
+	Reasons          string
+	At               string
+	IPAddress        string
+	Location         string
+	UserAgent        string
+	VerificationCode string
+	ExpiresIn        int
+}
+
+// LoginAlertReasonLabels は不審な点の表示名
+var LoginAlertReasonLabels = map[string]string{
+	domain.LoginRiskNewDevice:        "初めての端末",
+	domain.LoginRiskNewCountry:       "初めての国",
+	domain.LoginRiskImpossibleTravel: "前回のログインから短時間での遠距離の移動",
+}
+
+// LoginAlertReasons は不審な点を表示用に連結する
+func LoginAlertReasons(reasons []string) string {
+	labels := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		if label, ok := LoginAlertReasonLabels[reason]; ok {
+			labels = append(labels, label)
+		} else {
+			labels = append(labels, reason)
+		}
+	}
+	return strings.Join(labels, "、")
+}
+
+func newLoginAlertEmailData(alert domain.LoginAlert) loginAlertEmailData {
+	subject := "Yotei+アカウントへの新しいログインがありました"
+	if alert.VerificationCode != "" {
+		subject = "Yotei+ログインの確認コード"
+	}
+	location := "不明"
+	if alert.Location != nil {
+		location = alert.Location.Country
+	}
+	return loginAlertEmailData{
+		Subject:          subject,
+		Username:         alert.Username,
+		Reasons:          LoginAlertReasons(alert.Reasons),
+		At:               alert.At.Format("2006-01-02 15:04 MST"),
+		IPAddress:        alert.IPAddress,
+		Location:         location,
+		UserAgent:        alert.UserAgent,
+		VerificationCode: alert.VerificationCode,
+		ExpiresIn:        int(domain.LoginVerificationCodeTTL / time.Minute),
+	}
+}
+
+var loginAlertTextTemplate = textTemplate.Must(textTemplate.New("login_alert_text").Parse(
+	`{{.Username}}さん
+
+Yotei+アカウントにいつもと異なるログインがありました（{{.Reasons}}）。
+
+日時: {{.At}}
+IPアドレス: {{.IPAddress}}
+国: {{.Location}}
+端末: {{.UserAgent}}
+{{if .VerificationCode}}
+ご本人のログインの場合は、以下の確認コードを入力してログインを完了してください。
+
+確認コード: {{.VerificationCode}}
+有効期限: {{.ExpiresIn}}分
+{{end}}
+心当たりがない場合は、すぐにパスワードを変更してください。
+--
+Yotei+
+`))
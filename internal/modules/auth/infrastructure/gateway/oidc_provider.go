@@ -0,0 +1,328 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	ssoService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso"
+)
+
+const (
+	// oidcTimeout はIdPへの問い合わせのタイムアウト
+	oidcTimeout = 10 * time.Second
+	// oidcMetadataTTL はディスカバリとJWKSをキャッシュする期間
+	oidcMetadataTTL = time.Hour
+)
+
+// OIDCProvider はOpenID Connectの認可コードフローでIdPと通信するゲートウェイ実装
+// ディスカバリ（/.well-known/openid-configuration）でエンドポイントを取得し、IDトークンの署名をJWKSで検証する
+type OIDCProvider struct {
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	metadata map[string]*oidcMetadata
+}
+
+// NewOIDCProvider はOIDCのゲートウェイを作成する
+func NewOIDCProvider() ssoService.IdentityProvider {
+	return &OIDCProvider{
+		httpClient: &http.Client{Timeout: oidcTimeout},
+		metadata:   make(map[string]*oidcMetadata),
+	}
+}
+
+// oidcMetadata はIssuerごとのディスカバリの結果と署名鍵
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+// AuthCodeURL はIdPの認可エンドポイントのURLを作成する（PKCEはS256）
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, conn *domain.SSOConnection, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx, conn.Issuer)
+	if err != nil {
+		return "", err
+	}
+	endpoint, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", conn.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", "openid email profile")
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String(), nil
+}
+
+// tokenResponse はトークンエンドポイントのレスポンスのうち使用するフィールド
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange は認可コードをトークンに交換し、検証したIDトークンのクレームを返す
+func (p *OIDCProvider) Exchange(ctx context.Context, conn *domain.SSOConnection, redirectURI, code, codeVerifier string) (*domain.SSOIdentity, error) {
+	meta, err := p.discover(ctx, conn.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(conn.ClientID), url.QueryEscape(conn.ClientSecret))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return nil, fmt.Errorf("token endpoint returned status %d: %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+	return p.verifyIDToken(ctx, conn, meta, body.IDToken)
+}
+
+// verifyIDToken はIDトークンの署名・Issuer・Audience・有効期限を検証する
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, conn *domain.SSOConnection, meta *oidcMetadata, rawToken string) (*domain.SSOIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.signingKey(ctx, conn.Issuer, meta, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(conn.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	identity := &domain.SSOIdentity{Claims: claims}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+	identity.Nonce, _ = claims["nonce"].(string)
+	identity.Email = strings.TrimSpace(identity.Email)
+	if identity.Subject == "" || identity.Email == "" {
+		return nil, errors.New("id_token has no sub or email claim")
+	}
+	// email_verifiedを返さないIdP（企業向けの多くは管理者がメールアドレスを管理する）は確認済みとして扱う
+	switch v := claims["email_verified"].(type) {
+	case nil:
+		identity.EmailVerified = true
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified = strings.EqualFold(v, "true")
+	}
+	return identity, nil
+}
+
+// discover はIssuerのディスカバリを取得する（キャッシュがあればそれを使う）
+func (p *OIDCProvider) discover(ctx context.Context, issuer string) (*oidcMetadata, error) {
+	p.mu.Lock()
+	meta, ok := p.metadata[issuer]
+	p.mu.Unlock()
+	if ok && time.Since(meta.fetchedAt) < oidcMetadataTTL {
+		return meta, nil
+	}
+
+	meta = &oidcMetadata{}
+	if err := p.getJSON(ctx, issuer+"/.well-known/openid-configuration", meta); err != nil {
+		return nil, fmt.Errorf("failed to discover issuer: %w", err)
+	}
+	if strings.TrimRight(meta.Issuer, "/") != issuer || meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("issuer metadata is invalid")
+	}
+	if err := p.fetchKeys(ctx, meta); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.metadata[issuer] = meta
+	p.mu.Unlock()
+	return meta, nil
+}
+
+// signingKey はkidの公開鍵を返す（見つからない場合はIdPの鍵の更新に備えてJWKSを取得し直す）
+func (p *OIDCProvider) signingKey(ctx context.Context, issuer string, meta *oidcMetadata, kid string) (interface{}, error) {
+	if key := meta.key(kid); key != nil {
+		return key, nil
+	}
+	refreshed := &oidcMetadata{
+		Issuer:                meta.Issuer,
+		AuthorizationEndpoint: meta.AuthorizationEndpoint,
+		TokenEndpoint:         meta.TokenEndpoint,
+		JWKSURI:               meta.JWKSURI,
+	}
+	if err := p.fetchKeys(ctx, refreshed); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.metadata[issuer] = refreshed
+	p.mu.Unlock()
+
+	if key := refreshed.key(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// key はkidの公開鍵を返す（kidが空で鍵が1つの場合はその鍵）
+func (m *oidcMetadata) key(kid string) interface{} {
+	if key, ok := m.keys[kid]; ok {
+		return key
+	}
+	if kid == "" && len(m.keys) == 1 {
+		for _, key := range m.keys {
+			return key
+		}
+	}
+	return nil
+}
+
+// jwk はJWKSの鍵のうち使用するフィールド
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchKeys はJWKSを取得して署名用のRSA・ECの公開鍵を読み込む
+func (p *OIDCProvider) fetchKeys(ctx context.Context, meta *oidcMetadata) error {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	meta.keys = keys
+	meta.fetchedAt = time.Now()
+	return nil
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", endpoint, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// APIKeyRepository はAPIキーのインメモリリポジトリ
+type APIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[string]*domain.APIKey
+}
+
+// NewAPIKeyRepository は新しいAPIKeyRepositoryを作成する
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{
+		keys: make(map[string]*domain.APIKey),
+	}
+}
+
+// SaveAPIKey はAPIキーを作成または更新する
+func (r *APIKeyRepository) SaveAPIKey(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.ID] = copyAPIKey(key)
+	return nil
+}
+
+// GetAPIKey はAPIキーを取得する（存在しない場合はnil, nil）
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyAPIKey(key), nil
+}
+
+// FindAPIKeyByHash はキーのハッシュでAPIキーを取得する（存在しない場合はnil, nil）
+func (r *APIKeyRepository) FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			return copyAPIKey(key), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListAPIKeys は無効化したものを含むAPIキーを新しい順に取得する
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []*domain.APIKey{}
+	for _, key := range r.keys {
+		keys = append(keys, copyAPIKey(key))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func copyAPIKey(key *domain.APIKey) *domain.APIKey {
+	k := *key
+	k.Scopes = append([]string(nil), key.Scopes...)
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		k.LastUsedAt = &lastUsedAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		k.RevokedAt = &revokedAt
+	}
+	return &k
+}
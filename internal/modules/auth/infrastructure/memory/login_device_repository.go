@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// LoginDeviceRepository はログイン端末と不審なログインの通知設定のインメモリリポジトリ
+type LoginDeviceRepository struct {
+	mu       sync.RWMutex
+	devices  map[string]*domain.LoginDevice
+	settings map[string]*domain.LoginAlertSettings
+}
+
+// NewLoginDeviceRepository は新しいLoginDeviceRepositoryを作成する
+func NewLoginDeviceRepository() *LoginDeviceRepository {
+	return &LoginDeviceRepository{
+		devices:  make(map[string]*domain.LoginDevice),
+		settings: make(map[string]*domain.LoginAlertSettings),
+	}
+}
+
+// ListLoginDevices はユーザーのログイン端末を最後にログインした順に取得する
+func (r *LoginDeviceRepository) ListLoginDevices(ctx context.Context, userID string) ([]*domain.LoginDevice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	devices := []*domain.LoginDevice{}
+	for _, device := range r.devices {
+		if device.UserID == userID {
+			devices = append(devices, copyLoginDevice(device))
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if !devices[i].LastSeenAt.Equal(devices[j].LastSeenAt) {
+			return devices[i].LastSeenAt.After(devices[j].LastSeenAt)
+		}
+		return devices[i].CreatedAt.After(devices[j].CreatedAt)
+	})
+	return devices, nil
+}
+
+// GetLoginDevice は端末を取得する（存在しない場合はnil, nil）
+func (r *LoginDeviceRepository) GetLoginDevice(ctx context.Context, id string) (*domain.LoginDevice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	device, ok := r.devices[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyLoginDevice(device), nil
+}
+
+// SaveLoginDevice は端末を作成または更新する
+func (r *LoginDeviceRepository) SaveLoginDevice(ctx context.Context, device *domain.LoginDevice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.devices[device.ID] = copyLoginDevice(device)
+	return nil
+}
+
+// DeleteLoginDevice はユーザーの端末を削除し、削除したかどうかを返す
+func (r *LoginDeviceRepository) DeleteLoginDevice(ctx context.Context, userID, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	device, ok := r.devices[id]
+	if !ok || device.UserID != userID {
+		return false, nil
+	}
+	delete(r.devices, id)
+	return true, nil
+}
+
+// GetLoginAlertSettings は通知設定を取得する（保存していない場合はnil, nil）
+func (r *LoginDeviceRepository) GetLoginAlertSettings(ctx context.Context, userID string) (*domain.LoginAlertSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.settings[userID]
+	if !ok {
+		return nil, nil
+	}
+	s := *settings
+	return &s, nil
+}
+
+// SaveLoginAlertSettings は通知設定を作成または更新する
+func (r *LoginDeviceRepository) SaveLoginAlertSettings(ctx context.Context, settings *domain.LoginAlertSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := *settings
+	r.settings[settings.UserID] = &s
+	return nil
+}
+
+func copyLoginDevice(device *domain.LoginDevice) *domain.LoginDevice {
+	d := *device
+	if device.LastLocation != nil {
+		location := *device.LastLocation
+		d.LastLocation = &location
+	}
+	if device.TrustedUntil != nil {
+		trustedUntil := *device.TrustedUntil
+		d.TrustedUntil = &trustedUntil
+	}
+	if device.VerificationExpiresAt != nil {
+		expiresAt := *device.VerificationExpiresAt
+		d.VerificationExpiresAt = &expiresAt
+	}
+	return &d
+}
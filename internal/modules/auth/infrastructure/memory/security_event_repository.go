@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// SecurityEventRepository はセキュリティイベントの監査ログのインメモリリポジトリ
+type SecurityEventRepository struct {
+	mu     sync.RWMutex
+	events []*domain.SecurityEvent
+}
+
+// NewSecurityEventRepository は新しいSecurityEventRepositoryを作成する
+func NewSecurityEventRepository() *SecurityEventRepository {
+	return &SecurityEventRepository{}
+}
+
+// SaveSecurityEvent はセキュリティイベントを保存する
+func (r *SecurityEventRepository) SaveSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := *event
+	r.events = append(r.events, &e)
+	return nil
+}
+
+// ListSecurityEvents は条件に一致するイベントを新しい順に取得する
+func (r *SecurityEventRepository) ListSecurityEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []*domain.SecurityEvent{}
+	for _, event := range r.events {
+		if event.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		if filter.UserID != "" && event.UserID != filter.UserID {
+			continue
+		}
+		if filter.Type != "" && event.Type != filter.Type {
+			continue
+		}
+		if !filter.Before.IsZero() && !event.CreatedAt.Before(filter.Before) {
+			continue
+		}
+		e := *event
+		events = append(events, &e)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+// DeleteSecurityEventsBefore はbeforeより前のイベントを削除し、削除した件数を返す
+func (r *SecurityEventRepository) DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.events[:0]
+	for _, event := range r.events {
+		if !event.CreatedAt.Before(before) {
+			kept = append(kept, event)
+		}
+	}
+	deleted := int64(len(r.events) - len(kept))
+	r.events = kept
+	return deleted, nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// SSORepository はSSOの接続設定とIdPのユーザーの紐付けのインメモリリポジトリ
+type SSORepository struct {
+	mu          sync.RWMutex
+	connections map[string]*domain.SSOConnection
+	identities  map[string]*domain.SSOIdentityLink
+}
+
+// NewSSORepository は新しいSSORepositoryを作成する
+func NewSSORepository() *SSORepository {
+	return &SSORepository{
+		connections: make(map[string]*domain.SSOConnection),
+		identities:  make(map[string]*domain.SSOIdentityLink),
+	}
+}
+
+// SaveSSOConnection は接続設定を作成または更新する
+func (r *SSORepository) SaveSSOConnection(ctx context.Context, conn *domain.SSOConnection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.connections[conn.ID] = copySSOConnection(conn)
+	return nil
+}
+
+// GetSSOConnection は接続設定を取得する（存在しない場合はnil, nil）
+func (r *SSORepository) GetSSOConnection(ctx context.Context, id string) (*domain.SSOConnection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	conn, ok := r.connections[id]
+	if !ok {
+		return nil, nil
+	}
+	return copySSOConnection(conn), nil
+}
+
+// ListSSOConnections は接続設定を名前順に取得する
+func (r *SSORepository) ListSSOConnections(ctx context.Context) ([]*domain.SSOConnection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	conns := []*domain.SSOConnection{}
+	for _, conn := range r.connections {
+		conns = append(conns, copySSOConnection(conn))
+	}
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].Name < conns[j].Name
+	})
+	return conns, nil
+}
+
+// DeleteSSOConnection は接続設定と紐付けを削除する（削除した場合はtrue）
+func (r *SSORepository) DeleteSSOConnection(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.connections[id]; !ok {
+		return false, nil
+	}
+	delete(r.connections, id)
+	for key, link := range r.identities {
+		if link.ConnectionID == id {
+			delete(r.identities, key)
+		}
+	}
+	return true, nil
+}
+
+// FindSSOIdentity はIdPのユーザーの紐付けを取得する（存在しない場合はnil, nil）
+func (r *SSORepository) FindSSOIdentity(ctx context.Context, connectionID, subject string) (*domain.SSOIdentityLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, ok := r.identities[connectionID+"\x00"+subject]
+	if !ok {
+		return nil, nil
+	}
+	l := *link
+	return &l, nil
+}
+
+// SaveSSOIdentity はIdPのユーザーの紐付けを保存する
+func (r *SSORepository) SaveSSOIdentity(ctx context.Context, link *domain.SSOIdentityLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := *link
+	r.identities[link.ConnectionID+"\x00"+link.Subject] = &l
+	return nil
+}
+
+func copySSOConnection(conn *domain.SSOConnection) *domain.SSOConnection {
+	c := *conn
+	c.Domains = append([]string(nil), conn.Domains...)
+	c.AdminValues = append([]string(nil), conn.AdminValues...)
+	return &c
+}
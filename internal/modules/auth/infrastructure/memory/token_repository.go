@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// TokenRepository はトークンのインメモリリポジトリ
+// Redisを使用しない場合でもアクセストークンのブラックリストを有効にする
+type TokenRepository struct {
+	mu            sync.RWMutex
+	blacklist     map[string]time.Time // トークン → 失効日時
+	refreshTokens map[string]*domain.RefreshToken
+}
+
+// NewTokenRepository は新しいTokenRepositoryを作成する
+func NewTokenRepository() *TokenRepository {
+	return &TokenRepository{
+		blacklist:     make(map[string]time.Time),
+		refreshTokens: make(map[string]*domain.RefreshToken),
+	}
+}
+
+// SaveTokenToBlacklist はトークンをTTLの間ブラックリストに登録する
+func (r *TokenRepository) SaveTokenToBlacklist(token string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	// 期限切れのエントリが溜まらないよう、登録のたびに掃除する
+	for t, expiresAt := range r.blacklist {
+		if !expiresAt.After(now) {
+			delete(r.blacklist, t)
+		}
+	}
+	r.blacklist[token] = now.Add(ttl)
+	return nil
+}
+
+// IsTokenBlacklisted はトークンがブラックリストに登録されているか確認する
+func (r *TokenRepository) IsTokenBlacklisted(token string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expiresAt, ok := r.blacklist[token]
+	return ok && expiresAt.After(time.Now())
+}
+
+// SaveRefreshToken はリフレッシュトークンを保存する
+func (r *TokenRepository) SaveRefreshToken(token *domain.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refreshTokens[token.Token] = cloneRefreshToken(token)
+	return nil
+}
+
+// FindRefreshToken は失効していないリフレッシュトークンを取得する（存在しない場合は nil, nil）
+func (r *TokenRepository) FindRefreshToken(token string) (*domain.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	refreshToken, ok := r.refreshTokens[token]
+	if !ok || refreshToken.RevokedAt != nil {
+		return nil, nil
+	}
+	return cloneRefreshToken(refreshToken), nil
+}
+
+// RevokeRefreshToken はリフレッシュトークンを失効させる
+func (r *TokenRepository) RevokeRefreshToken(token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if refreshToken, ok := r.refreshTokens[token]; ok {
+		now := time.Now()
+		refreshToken.RevokedAt = &now
+	}
+	return nil
+}
+
+// DeleteExpiredRefreshTokens は有効期限切れのリフレッシュトークンを削除する
+func (r *TokenRepository) DeleteExpiredRefreshTokens() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for token, refreshToken := range r.refreshTokens {
+		if refreshToken.ExpiresAt.Before(now) {
+			delete(r.refreshTokens, token)
+		}
+	}
+	return nil
+}
+
+// cloneRefreshToken は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+func cloneRefreshToken(token *domain.RefreshToken) *domain.RefreshToken {
+	c := *token
+	if token.RevokedAt != nil {
+		revokedAt := *token.RevokedAt
+		c.RevokedAt = &revokedAt
+	}
+	return &c
+}
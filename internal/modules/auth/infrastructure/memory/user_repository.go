@@ -0,0 +1,233 @@
+// Package memory は認証モジュールのリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQL・Redisの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// findUsersLimit はユーザー検索の最大件数（MySQL実装と同じ）
+const findUsersLimit = 100
+
+// UserRepository はユーザーのインメモリリポジトリ
+// commonDomain.UserValidator も実装し、他モジュールからのユーザー存在確認に使用する
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]*domain.User
+}
+
+// NewUserRepository は新しいUserRepositoryを作成する
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users: make(map[uuid.UUID]*domain.User),
+	}
+}
+
+// CreateUser は新しいユーザーを作成する（メールアドレス・ユーザー名は大文字小文字を区別せず一意）
+func (r *UserRepository) CreateUser(user *domain.User) error {
+	if user.Role == "" {
+		user.Role = domain.RoleUser
+	}
+	if user.Role != domain.RoleUser && user.Role != domain.RoleAdmin {
+		return fmt.Errorf("invalid role: %s", user.Role)
+	}
+
+	now := time.Now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; ok {
+		return fmt.Errorf("failed to create user: duplicate id %s", user.ID)
+	}
+	if r.find(func(u *domain.User) bool { return strings.EqualFold(u.Email, user.Email) }) != nil {
+		return fmt.Errorf("failed to create user: duplicate email %s", user.Email)
+	}
+	if r.find(func(u *domain.User) bool { return strings.EqualFold(u.Username, user.Username) }) != nil {
+		return fmt.Errorf("failed to create user: duplicate username %s", user.Username)
+	}
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+// FindUserByEmail はメールアドレスでユーザーを検索する（存在しない場合は nil, nil）
+func (r *UserRepository) FindUserByEmail(email string) (*domain.User, error) {
+	return r.findOne(func(u *domain.User) bool { return strings.EqualFold(u.Email, email) }), nil
+}
+
+// FindUserByID はIDでユーザーを検索する（存在しない場合は nil, nil）
+func (r *UserRepository) FindUserByID(id uuid.UUID) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if user, ok := r.users[id]; ok {
+		return cloneUser(user), nil
+	}
+	return nil, nil
+}
+
+// FindUserByUsername はユーザー名でユーザーを検索する（存在しない場合は nil, nil）
+func (r *UserRepository) FindUserByUsername(username string) (*domain.User, error) {
+	return r.findOne(func(u *domain.User) bool { return strings.EqualFold(u.Username, username) }), nil
+}
+
+// FindUsers はユーザー名・メールアドレスの部分一致でユーザーをユーザー名順に検索する
+func (r *UserRepository) FindUsers(search string) ([]*domain.User, error) {
+	search = strings.ToLower(strings.TrimSpace(search))
+
+	r.mu.RLock()
+	users := []*domain.User{}
+	for _, user := range r.users {
+		if search == "" ||
+			strings.Contains(strings.ToLower(user.Username), search) ||
+			strings.Contains(strings.ToLower(user.Email), search) {
+			users = append(users, cloneUser(user))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Username) < strings.ToLower(users[j].Username)
+	})
+	if len(users) > findUsersLimit {
+		users = users[:findUsersLimit]
+	}
+	return users, nil
+}
+
+// UpdateUser はユーザーを更新する
+func (r *UserRepository) UpdateUser(user *domain.User) error {
+	if user.Role != domain.RoleUser && user.Role != domain.RoleAdmin {
+		return fmt.Errorf("invalid role: %s", user.Role)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.users[user.ID]
+	if !ok {
+		return fmt.Errorf("user not found: %s", user.ID.String())
+	}
+
+	user.UpdatedAt = time.Now()
+	stored := cloneUser(user)
+	stored.CreatedAt = current.CreatedAt
+	r.users[user.ID] = stored
+	return nil
+}
+
+// UserExists はユーザーが存在するかチェックする
+func (r *UserRepository) UserExists(ctx context.Context, userID string) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.users[id]
+	return ok, nil
+}
+
+// UsersExist は存在しないユーザーIDを返す
+func (r *UserRepository) UsersExist(ctx context.Context, userIDs []string) ([]string, error) {
+	infos, err := r.GetUsersInfoBatch(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if infos[userID] == nil && !seen[userID] {
+			missing = append(missing, userID)
+		}
+		seen[userID] = true
+	}
+	return missing, nil
+}
+
+// GetUserInfo はユーザーの基本情報を取得する（存在しない場合は nil, nil）
+func (r *UserRepository) GetUserInfo(ctx context.Context, userID string) (*commonDomain.UserInfo, error) {
+	infos, err := r.GetUsersInfoBatch(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return infos[userID], nil
+}
+
+// GetUsersInfoBatch は複数ユーザーの基本情報を一括取得する（存在しないユーザーは含まない）
+func (r *UserRepository) GetUsersInfoBatch(ctx context.Context, userIDs []string) (map[string]*commonDomain.UserInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*commonDomain.UserInfo, len(userIDs))
+	for _, userID := range userIDs {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			continue
+		}
+		if user, ok := r.users[id]; ok {
+			result[userID] = &commonDomain.UserInfo{
+				ID:       user.ID.String(),
+				Username: user.Username,
+				Email:    user.Email,
+			}
+		}
+	}
+	return result, nil
+}
+
+// find は条件に一致するユーザーを探す（呼び出し側でロックを取得すること）
+func (r *UserRepository) find(match func(u *domain.User) bool) *domain.User {
+	for _, user := range r.users {
+		if match(user) {
+			return user
+		}
+	}
+	return nil
+}
+
+// findOne は条件に一致するユーザーのコピーを返す
+func (r *UserRepository) findOne(match func(u *domain.User) bool) *domain.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if user := r.find(match); user != nil {
+		return cloneUser(user)
+	}
+	return nil
+}
+
+// cloneUser は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+// リフレッシュトークンはMySQL実装と同様にユーザーと一緒には保存しない
+func cloneUser(user *domain.User) *domain.User {
+	c := *user
+	c.RefreshTokens = nil
+	if user.LastLogin != nil {
+		lastLogin := *user.LastLogin
+		c.LastLogin = &lastLogin
+	}
+	if user.DeactivatedAt != nil {
+		deactivatedAt := *user.DeactivatedAt
+		c.DeactivatedAt = &deactivatedAt
+	}
+	return &c
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// UsernameHistoryRepository はユーザー名の変更履歴のインメモリリポジトリ
+type UsernameHistoryRepository struct {
+	mu      sync.RWMutex
+	changes []domain.UsernameChange
+}
+
+// NewUsernameHistoryRepository は新しいUsernameHistoryRepositoryを作成する
+func NewUsernameHistoryRepository() *UsernameHistoryRepository {
+	return &UsernameHistoryRepository{}
+}
+
+// SaveUsernameChange は変更履歴を保存する
+func (r *UsernameHistoryRepository) SaveUsernameChange(ctx context.Context, change *domain.UsernameChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.changes = append(r.changes, *change)
+	return nil
+}
+
+// FindLatestUsernameChange はユーザーの最後の変更を取得する（変更したことがない場合は nil, nil）
+func (r *UsernameHistoryRepository) FindLatestUsernameChange(ctx context.Context, userID uuid.UUID) (*domain.UsernameChange, error) {
+	return r.findLatest(func(c *domain.UsernameChange) bool { return c.UserID == userID }), nil
+}
+
+// FindRecentUsernameChange は since 以降に username（大文字小文字を区別しない）から変更した最新の履歴を取得する（ない場合は nil, nil）
+func (r *UsernameHistoryRepository) FindRecentUsernameChange(ctx context.Context, username string, since time.Time) (*domain.UsernameChange, error) {
+	return r.findLatest(func(c *domain.UsernameChange) bool {
+		return strings.EqualFold(c.OldUsername, username) && !c.ChangedAt.Before(since)
+	}), nil
+}
+
+func (r *UsernameHistoryRepository) findLatest(match func(c *domain.UsernameChange) bool) *domain.UsernameChange {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.UsernameChange
+	for i := range r.changes {
+		c := &r.changes[i]
+		if match(c) && (latest == nil || !c.ChangedAt.Before(latest.ChangedAt)) {
+			latest = c
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	found := *latest
+	return &found
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// APIKeyContextKey はAPIKeyRequiredが認証したAPIキーを格納するgin.Contextのキー
+const APIKeyContextKey = "api_key"
+
+// APIKeyAuthenticator はAPIキーを検証するインターフェース
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key, scope string) (*domain.APIKey, error)
+}
+
+// APIKeyRequired は管理者が発行したAPIキー（Authorization: Bearer <key>）を必要とするミドルウェア
+// scopeが付与されていないキーは拒否する。認証したキーはAPIKeyContextKeyに格納する
+func APIKeyRequired(authenticator APIKeyAuthenticator, scope string, abort ErrorHandler) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authHeader := ctx.GetHeader("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			abort(ctx, http.StatusUnauthorized, "API key required")
+			return
+		}
+
+		key, err := authenticator.Authenticate(ctx, strings.TrimPrefix(authHeader, "Bearer "), scope)
+		if err != nil {
+			if errors.Is(err, domain.ErrAPIKeyInvalid) {
+				abort(ctx, http.StatusUnauthorized, "Invalid API key")
+				return
+			}
+			abort(ctx, http.StatusInternalServerError, "Failed to authenticate API key")
+			return
+		}
+
+		ctx.Set(APIKeyContextKey, key)
+		ctx.Next()
+	}
+}
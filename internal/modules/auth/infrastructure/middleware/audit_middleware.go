@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
+)
+
+// PermissionDeniedAudit は認証済みユーザーへの403レスポンスを権限エラーとして監査ログに記録するミドルウェア
+// ルーター全体に適用し、ロールの不足と各モジュールの権限チェックの両方を記録する
+func PermissionDeniedAudit(recorder auditService.SecurityEventRecorder) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if ctx.Writer.Status() != http.StatusForbidden {
+			return
+		}
+		userID := ctx.GetString("user_id")
+		if userID == "" {
+			return
+		}
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = ctx.Request.URL.Path
+		}
+		event := domain.NewSecurityEvent(domain.SecurityEventPermissionDenied, userID, ctx.Request.Method+" "+route)
+		event.SetClient(domain.ClientInfo{
+			IPAddress: ctx.ClientIP(),
+			UserAgent: ctx.Request.UserAgent(),
+		})
+		recorder.Record(ctx, event)
+	}
+}
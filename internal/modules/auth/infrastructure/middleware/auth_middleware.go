@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
 	commonMiddleware "github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
 	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
 	token "github.com/hryt430/Yotei+/pkg/token"
 	"github.com/hryt430/Yotei+/pkg/utils"
@@ -14,11 +17,32 @@ import (
 
 type AuthMiddleware struct {
 	tokenUseCase tokenService.TokenService
+	// 認証・認可エラーのレスポンスを書き込む関数（APIバージョンごとのエラー形式に対応）
+	abort ErrorHandler
+	// 認証済みのユーザーの現在のプロフィールを取得する（nilの場合はトークンのクレームだけを使う）
+	Users UserResolver
+}
+
+// ErrorHandler は認証・認可エラーのレスポンスを書き込んでリクエストを中断する関数
+type ErrorHandler func(ctx *gin.Context, status int, message string)
+
+// UserResolver はユーザーの現在のプロフィールを取得する（存在しない場合は nil, nil）
+// リクエストごとに呼び出すため、キャッシュする実装（userService.ProfileCache）を使う
+type UserResolver interface {
+	FindUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
 }
 
 func NewAuthMiddleware(tokenUseCase tokenService.TokenService) *AuthMiddleware {
+	return NewAuthMiddlewareWithErrorHandler(tokenUseCase, func(ctx *gin.Context, status int, message string) {
+		ctx.AbortWithStatusJSON(status, utils.ErrorResponse(message))
+	})
+}
+
+// NewAuthMiddlewareWithErrorHandler はエラーレスポンスの形式を指定してAuthMiddlewareを作成する
+func NewAuthMiddlewareWithErrorHandler(tokenUseCase tokenService.TokenService, abort ErrorHandler) *AuthMiddleware {
 	return &AuthMiddleware{
 		tokenUseCase: tokenUseCase,
+		abort:        abort,
 	}
 }
 
@@ -28,7 +52,7 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 		// トークンの取得（ヘッダーまたはCookie）
 		tokenString := m.extractToken(ctx)
 		if tokenString == "" {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Authorization token required"))
+			m.abort(ctx, http.StatusUnauthorized, "Authorization token required")
 			return
 		}
 
@@ -36,22 +60,42 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 		claims, err := m.tokenUseCase.ValidateAccessToken(tokenString)
 		if err != nil {
 			if err == token.ErrExpiredToken {
-				ctx.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Token has expired"))
+				m.abort(ctx, http.StatusUnauthorized, "Token has expired")
 				return
 			}
 			if err == token.ErrTokenBlacklisted {
-				ctx.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Token has been revoked"))
+				m.abort(ctx, http.StatusUnauthorized, "Token has been revoked")
 				return
 			}
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("Invalid token"))
+			m.abort(ctx, http.StatusUnauthorized, "Invalid token")
 			return
 		}
 
 		// ユーザー情報をコンテキストに設定
-		ctx.Set("user_id", claims.UserID)
-		ctx.Set("email", claims.Email)
-		ctx.Set("username", claims.Username)
-		ctx.Set("role", claims.Role)
+		if status, message := m.setUser(ctx, claims); status != 0 {
+			m.abort(ctx, status, message)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// AuthOptional はログインしていなくてもアクセスできるエンドポイント用のミドルウェア
+// 有効なトークンがある場合のみユーザー情報をコンテキストに設定し、ない・無効な場合はログインしていない閲覧者として続行する
+func (m *AuthMiddleware) AuthOptional() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenString := m.extractToken(ctx)
+		if tokenString == "" {
+			ctx.Next()
+			return
+		}
+
+		claims, err := m.tokenUseCase.ValidateAccessToken(tokenString)
+		if err == nil {
+			// 無効化されたユーザーなどは設定されないため、ログインしていない閲覧者として扱われる
+			m.setUser(ctx, claims)
+		}
 
 		ctx.Next()
 	}
@@ -85,15 +129,44 @@ func (m *AuthMiddleware) WebSocketAuthRequired() gin.HandlerFunc {
 		}
 
 		// ユーザー情報をコンテキストに設定（既存のAuthRequiredと同じ）
-		ctx.Set("user_id", claims.UserID)
-		ctx.Set("email", claims.Email)
-		ctx.Set("username", claims.Username)
-		ctx.Set("role", claims.Role)
+		if status, message := m.setUser(ctx, claims); status != 0 {
+			ctx.JSON(status, gin.H{"error": message})
+			ctx.Abort()
+			return
+		}
 
 		ctx.Next()
 	}
 }
 
+// setUser はユーザー情報をコンテキストに設定する（設定できない場合はエラーレスポンスのステータスとメッセージを返す）
+// Users がある場合は現在のプロフィールを使い、トークンの発行後の役割の変更・アカウントの無効化を反映する
+func (m *AuthMiddleware) setUser(ctx *gin.Context, claims *token.Claims) (int, string) {
+	user := &domain.User{Email: claims.Email, Username: claims.Username, Role: claims.Role}
+	id, err := uuid.Parse(claims.UserID)
+	if err == nil {
+		user.ID = id
+		if m.Users != nil {
+			current, err := m.Users.FindUser(ctx.Request.Context(), id)
+			if err != nil {
+				return http.StatusInternalServerError, "Failed to load user"
+			}
+			if current == nil || !current.IsActive() {
+				return http.StatusUnauthorized, "User is not active"
+			}
+			user = current
+		}
+		// middleware.GetUserFromContext で参照する
+		ctx.Set("user", user)
+	}
+
+	ctx.Set("user_id", claims.UserID)
+	ctx.Set("email", user.Email)
+	ctx.Set("username", user.Username)
+	ctx.Set("role", user.Role)
+	return 0, ""
+}
+
 // extractToken はリクエストからトークンを抽出
 func (m *AuthMiddleware) extractToken(ctx *gin.Context) string {
 	// Authorizationヘッダーからトークンを取得
@@ -117,13 +190,13 @@ func (m *AuthMiddleware) RoleRequired(role string) gin.HandlerFunc {
 		// すでに認証済みであることを前提
 		userRole, exists := ctx.Get("role")
 		if !exists {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, utils.ErrorResponse("User not authenticated"))
+			m.abort(ctx, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		// ロールチェック
 		if userRole != role {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, utils.ErrorResponse("Access denied: insufficient privileges"))
+			m.abort(ctx, http.StatusForbidden, "Access denied: insufficient privileges")
 			return
 		}
 
@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/kvstore"
+)
+
+// EmbeddedTokenCache はプロセス内のストアを使用したトークンキャッシュの実装（REDIS_DRIVER=embedded 用）
+// RedisTokenCache と同じメソッドを持ち、Redisを用意しない小規模な環境でもブラックリストを有効にする
+type EmbeddedTokenCache struct {
+	store *kvstore.Store
+}
+
+func NewEmbeddedTokenCache(store *kvstore.Store) *EmbeddedTokenCache {
+	return &EmbeddedTokenCache{store: store}
+}
+
+func (c *EmbeddedTokenCache) SetWithTTL(key string, value string, ttl time.Duration) error {
+	c.store.Set(key, value, ttl)
+	return nil
+}
+
+// Get はキーがない場合、RedisTokenCache と同じく redis.Nil を返す
+func (c *EmbeddedTokenCache) Get(key string) (string, error) {
+	value, ok := c.store.Get(key)
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (c *EmbeddedTokenCache) Exists(key string) bool {
+	return c.store.Exists(key)
+}
+
+func (c *EmbeddedTokenCache) Delete(key string) error {
+	c.store.Del(key)
+	return nil
+}
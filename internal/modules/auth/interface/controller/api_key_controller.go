@@ -0,0 +1,381 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	apiKeyService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/apikey"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// APIKeyController は管理者が発行するAPIキー・ユーザーが発行するショートカット・ブラウザの拡張機能用のAPIキーのHTTPリクエストを処理するコントローラー
+type APIKeyController struct {
+	apiKeyService *apiKeyService.APIKeyService
+	logger        logger.Logger
+}
+
+// NewAPIKeyController は新しいAPIKeyControllerを作成する
+func NewAPIKeyController(apiKeyService *apiKeyService.APIKeyService, logger logger.Logger) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKeyRequest はAPIキーの発行リクエスト
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=100" example:"Okta provisioning"`
+	Scopes []string `json:"scopes" binding:"required,min=1" example:"scim"`
+} // @name CreateAPIKeyRequest
+
+// CreatedAPIKey は発行したAPIキー（keyは発行時のみ返す）
+type CreatedAPIKey struct {
+	*domain.APIKey
+	Key string `json:"key" example:"yp_AbCdEfGhIjKlMnOpQrStUvWxYz0123456789abcdefg"`
+} // @name CreatedAPIKey
+
+// CreateAPIKeyResponse はAPIキーの発行レスポンス
+type CreateAPIKeyResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    *CreatedAPIKey `json:"data"`
+} // @name CreateAPIKeyResponse
+
+// APIKeysResponse はAPIキー一覧のレスポンス
+type APIKeysResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    []*domain.APIKey `json:"data"`
+} // @name APIKeysResponse
+
+// CreateAPIKey APIキーの発行
+// @Summary      APIキーの発行（管理者）
+// @Description  外部システム向けのAPIキーを発行します。キーはレスポンスでのみ返し、再表示できません。scimスコープのキーはSCIM 2.0のプロビジョニングAPI（/scim/v2）に使用し、SCIMで作成したグループは発行した管理者がオーナーになります
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateAPIKeyRequest true "APIキーの名前とスコープ"
+// @Security     BearerAuth
+// @Success      201 {object} CreateAPIKeyResponse "発行成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/api-keys [post]
+func (c *APIKeyController) CreateAPIKey(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	key, plaintext, err := c.apiKeyService.CreateAPIKey(ctx, req.Name, userID.String(), req.Scopes)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNameRequired) || errors.Is(err, domain.ErrAPIKeyInvalidScope) {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.logger.Error("Failed to create api key", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to create api key",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		Success: true,
+		Data:    &CreatedAPIKey{APIKey: key, Key: plaintext},
+	})
+}
+
+// ListAPIKeys APIキー一覧
+// @Summary      APIキー一覧（管理者）
+// @Description  無効化したものを含むAPIキーを新しい順に取得します。キーは先頭部分（display_prefix）のみ返します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} APIKeysResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/api-keys [get]
+func (c *APIKeyController) ListAPIKeys(ctx *gin.Context) {
+	keys, err := c.apiKeyService.ListAPIKeys(ctx)
+	if err != nil {
+		c.logger.Error("Failed to list api keys", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get api keys",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, APIKeysResponse{Success: true, Data: keys})
+}
+
+// RevokeAPIKey APIキーの無効化
+// @Summary      APIキーの無効化（管理者）
+// @Description  APIキーを無効化します。無効化したキーでのリクエストは401になります
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "APIキーID"
+// @Security     BearerAuth
+// @Success      204 "無効化成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "APIキーが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/api-keys/{id} [delete]
+func (c *APIKeyController) RevokeAPIKey(ctx *gin.Context) {
+	if err := c.apiKeyService.RevokeAPIKey(ctx, ctx.Param("id")); err != nil {
+		if errors.Is(err, apiKeyService.ErrAPIKeyNotFound) {
+			ctx.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   "NOT_FOUND",
+				Message: "API key not found",
+			})
+			return
+		}
+		c.logger.Error("Failed to revoke api key", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to revoke api key",
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreateShortcutKeyRequest はショートカット用のAPIキーの発行リクエスト
+type CreateShortcutKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"iPhoneのショートカット"`
+} // @name CreateShortcutKeyRequest
+
+// CreateShortcutKey ショートカット用のAPIキーの発行
+// @Summary      ショートカット用のAPIキーの発行
+// @Description  Siriのショートカット・Googleアシスタントなどから使うquickスコープのAPIキーを発行します。キーはレスポンスでのみ返し、再表示できません。キーは /quick のエンドポイントに「Authorization: Bearer <key>」で指定し、発行したユーザーとして操作します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateShortcutKeyRequest true "APIキーの名前"
+// @Security     BearerAuth
+// @Success      201 {object} CreateAPIKeyResponse "発行成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/shortcut-keys [post]
+func (c *APIKeyController) CreateShortcutKey(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var req CreateShortcutKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	key, plaintext, err := c.apiKeyService.CreatePersonalAPIKey(ctx, req.Name, userID.String())
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNameRequired) {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.logger.Error("Failed to create shortcut key", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to create api key",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		Success: true,
+		Data:    &CreatedAPIKey{APIKey: key, Key: plaintext},
+	})
+}
+
+// CreateClipTokenRequest はブラウザの拡張機能用のトークンの発行リクエスト
+type CreateClipTokenRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Chrome（仕事用PC）"`
+} // @name CreateClipTokenRequest
+
+// CreateClipToken ブラウザの拡張機能用のトークンの発行
+// @Summary      ブラウザの拡張機能用のトークンの発行
+// @Description  ログイン中のセッション（アクセストークン）と引き換えに、ブラウザの拡張機能から使うclipスコープの長期間有効なトークン（APIキー）を発行します。トークンはレスポンスでのみ返し、再表示できません。トークンは POST /clip に「Authorization: Bearer <token>」で指定し、発行したユーザーとして操作します。一覧・無効化は /auth/shortcut-keys で行います
+// @Tags         clip
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateClipTokenRequest true "トークンの名前"
+// @Security     BearerAuth
+// @Success      201 {object} CreateAPIKeyResponse "発行成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /clip/token [post]
+func (c *APIKeyController) CreateClipToken(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var req CreateClipTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	key, plaintext, err := c.apiKeyService.CreateClipToken(ctx, req.Name, userID.String())
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNameRequired) {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.logger.Error("Failed to create clip token", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to create api key",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		Success: true,
+		Data:    &CreatedAPIKey{APIKey: key, Key: plaintext},
+	})
+}
+
+// ListShortcutKeys ショートカット用のAPIキー一覧
+// @Summary      ショートカット用のAPIキー一覧
+// @Description  ログイン中のユーザーが発行したショートカット用のAPIキー・ブラウザの拡張機能用のトークンを、無効化したものを含めて新しい順に取得します。キーは先頭部分（display_prefix）のみ返します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} APIKeysResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/shortcut-keys [get]
+func (c *APIKeyController) ListShortcutKeys(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	keys, err := c.apiKeyService.ListPersonalAPIKeys(ctx, userID.String())
+	if err != nil {
+		c.logger.Error("Failed to list shortcut keys", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get api keys",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, APIKeysResponse{Success: true, Data: keys})
+}
+
+// RevokeShortcutKey ショートカット用のAPIキーの無効化
+// @Summary      ショートカット用のAPIキーの無効化
+// @Description  ログイン中のユーザーが発行したショートカット用のAPIキー・ブラウザの拡張機能用のトークンを無効化します。無効化したキーでのリクエストは401になります
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "APIキーID"
+// @Security     BearerAuth
+// @Success      204 "無効化成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "APIキーが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/shortcut-keys/{id} [delete]
+func (c *APIKeyController) RevokeShortcutKey(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := c.apiKeyService.RevokePersonalAPIKey(ctx, userID.String(), ctx.Param("id")); err != nil {
+		if errors.Is(err, apiKeyService.ErrAPIKeyNotFound) {
+			ctx.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   "NOT_FOUND",
+				Message: "API key not found",
+			})
+			return
+		}
+		c.logger.Error("Failed to revoke shortcut key", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to revoke api key",
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
 	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
 	"github.com/hryt430/Yotei+/pkg/logger"
 
@@ -36,6 +39,14 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required" example:"password123"`
 } // @name LoginRequest
 
+// VerifyLoginRequest は不審なログインの確認のリクエスト構造体
+type VerifyLoginRequest struct {
+	ChallengeID string `json:"challenge_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Code        string `json:"code" binding:"required" example:"123456"`
+	// この端末を30日間記憶し、期間中の不審なログインに確認コードを求めない
+	RememberDevice bool `json:"remember_device" example:"true"`
+} // @name VerifyLoginRequest
+
 // RefreshTokenRequest はトークン更新のリクエスト構造体
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
@@ -75,6 +86,15 @@ type LoginResponse struct {
 	} `json:"data"`
 } // @name LoginResponse
 
+// LoginVerificationRequiredResponse は確認コードの入力が必要な場合のレスポンス構造体
+type LoginVerificationRequiredResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"VERIFICATION_REQUIRED"`
+	Message string `json:"message" example:"A verification code has been sent to your email"`
+	// POST /auth/login/verify に確認コードと一緒に指定する
+	ChallengeID string `json:"challenge_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+} // @name LoginVerificationRequiredResponse
+
 // RefreshTokenResponse はトークン更新のレスポンス構造体
 type RefreshTokenResponse struct {
 	Success bool   `json:"success" example:"true"`
@@ -161,6 +181,7 @@ func (c *AuthController) Register(ctx *gin.Context) {
 // @Success      200 {object} LoginResponse "ログイン成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証情報が無効"
+// @Failure      403 {object} LoginVerificationRequiredResponse "不審なログインのため確認コードの入力が必要（メールで送信済み）、またはSSOでのログインが必要（SSO_REQUIRED）"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /auth/login [post]
 func (c *AuthController) Login(ctx *gin.Context) {
@@ -177,8 +198,26 @@ func (c *AuthController) Login(ctx *gin.Context) {
 	// 入力値のサニタイズ
 	req.Email = strings.TrimSpace(req.Email)
 
-	accessToken, refreshToken, err := c.Interactor.AuthRepository.Login(ctx, req.Email, req.Password)
+	accessToken, refreshToken, err := c.Interactor.AuthRepository.Login(withClientInfo(ctx), req.Email, req.Password)
 	if err != nil {
+		var verificationErr *authService.LoginVerificationRequiredError
+		if errors.As(err, &verificationErr) {
+			ctx.JSON(http.StatusForbidden, LoginVerificationRequiredResponse{
+				Success:     false,
+				Error:       "VERIFICATION_REQUIRED",
+				Message:     "A verification code has been sent to your email",
+				ChallengeID: verificationErr.ChallengeID,
+			})
+			return
+		}
+		if errors.Is(err, authService.ErrSSORequired) {
+			ctx.JSON(http.StatusForbidden, ErrorResponse{
+				Success: false,
+				Error:   "SSO_REQUIRED",
+				Message: "This account must sign in with single sign-on",
+			})
+			return
+		}
 		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
 		Success: false,
 		Error:   "INVALID_CREDENTIALS",
@@ -187,6 +226,87 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
+	respondLogin(ctx, accessToken, refreshToken)
+}
+
+// VerifyLogin 不審なログインの確認
+// @Summary      不審なログインの確認
+// @Description  ログインが VERIFICATION_REQUIRED で拒否された場合に、メールで届いた確認コードを入力してログインを完了します。remember_device を指定すると端末を30日間記憶し（device_trust Cookie）、期間中は確認コードを求めません
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body VerifyLoginRequest true "確認コード"
+// @Success      200 {object} LoginResponse "ログイン成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "確認コードが無効・期限切れ、または入力回数の上限を超えた"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/login/verify [post]
+func (c *AuthController) VerifyLogin(ctx *gin.Context) {
+	var req VerifyLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	accessToken, refreshToken, deviceTrust, err := c.Interactor.AuthRepository.VerifyLogin(withClientInfo(ctx), req.ChallengeID, strings.TrimSpace(req.Code), req.RememberDevice)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrLoginVerificationInvalid),
+			errors.Is(err, domain.ErrLoginVerificationExpired),
+			errors.Is(err, domain.ErrLoginVerificationExceeded):
+			ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+				Success: false,
+				Error:   "INVALID_VERIFICATION_CODE",
+				Message: err.Error(),
+			})
+		default:
+			c.logger.Error("Failed to verify login", logger.Error(err))
+			ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+				Success: false,
+				Error:   "INTERNAL_ERROR",
+				Message: "Failed to verify login",
+			})
+		}
+		return
+	}
+
+	if deviceTrust != "" {
+		// 記憶した端末のCookie（サーバー側に保存したtokenと照合するため、端末の一覧から取り消せる）
+		ctx.SetCookie(
+			domain.DeviceTrustCookie,
+			deviceTrust,
+			int(domain.DeviceTrustDuration.Seconds()), // 30日間
+			"/",
+			"",
+			true, // Secure
+			true, // HTTPOnly
+		)
+	}
+
+	respondLogin(ctx, accessToken, refreshToken)
+}
+
+// respondLogin はトークンをCookieに設定してログイン成功のレスポンスを返す
+func respondLogin(ctx *gin.Context, accessToken, refreshToken string) {
+	setTokenCookies(ctx, accessToken, refreshToken)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Login successful",
+		"data": gin.H{
+			"access_token":  accessToken,
+			"refresh_token": refreshToken,
+			"token_type":    "Bearer",
+		},
+	})
+}
+
+// setTokenCookies はアクセストークンとリフレッシュトークンをCookieに設定する
+func setTokenCookies(ctx *gin.Context, accessToken, refreshToken string) {
 	// HTTPOnly cookieにトークンを設定
 	ctx.SetCookie(
 		"access_token",
@@ -208,16 +328,6 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		true, // Secure
 		true, // HTTPOnly
 	)
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Login successful",
-		"data": gin.H{
-			"access_token":  accessToken,
-			"refresh_token": refreshToken,
-			"token_type":    "Bearer",
-		},
-	})
 }
 
 // RefreshToken トークン更新
@@ -248,7 +358,7 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 		req.RefreshToken = refreshToken
 	}
 
-	newAccessToken, newRefreshToken, err := c.Interactor.RefreshToken(req.RefreshToken)
+	newAccessToken, newRefreshToken, err := c.Interactor.AuthRepository.RefreshToken(withClientInfo(ctx), req.RefreshToken)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
 		Success: false,
@@ -360,6 +470,16 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	})
 }
 
+// withClientInfo はリクエスト元のIPアドレスとUser-Agentを監査ログ用にcontextへ格納する
+func withClientInfo(ctx *gin.Context) context.Context {
+	deviceTrust, _ := ctx.Cookie(domain.DeviceTrustCookie)
+	return domain.ContextWithClientInfo(ctx, domain.ClientInfo{
+		IPAddress:         ctx.ClientIP(),
+		UserAgent:         ctx.Request.UserAgent(),
+		DeviceTrustCookie: deviceTrust,
+	})
+}
+
 // Me 現在のユーザー情報取得
 // @Summary      現在のユーザー情報取得
 // @Description  認証済みユーザーの詳細情報を取得します
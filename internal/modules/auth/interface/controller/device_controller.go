@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	deviceService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/device"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DeviceController はログイン端末と不審なログインの通知設定のHTTPリクエストを処理するコントローラー
+type DeviceController struct {
+	deviceService *deviceService.DeviceService
+	logger        logger.Logger
+}
+
+// NewDeviceController は新しいDeviceControllerを作成する
+func NewDeviceController(deviceService *deviceService.DeviceService, logger logger.Logger) *DeviceController {
+	return &DeviceController{
+		deviceService: deviceService,
+		logger:        logger,
+	}
+}
+
+// LoginDevicesResponse はログイン端末一覧のレスポンス
+type LoginDevicesResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    []*domain.LoginDevice `json:"data"`
+} // @name LoginDevicesResponse
+
+// LoginDeviceResponse はログイン端末のレスポンス
+type LoginDeviceResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    *domain.LoginDevice `json:"data"`
+} // @name LoginDeviceResponse
+
+// UpdateDeviceRequest はログイン端末の更新リクエスト
+type UpdateDeviceRequest struct {
+	Trusted *bool `json:"trusted" binding:"required" example:"true"`
+} // @name UpdateDeviceRequest
+
+// LoginAlertSettingsResponse は不審なログインの通知設定のレスポンス
+type LoginAlertSettingsResponse struct {
+	Success bool                       `json:"success" example:"true"`
+	Data    *domain.LoginAlertSettings `json:"data"`
+} // @name LoginAlertSettingsResponse
+
+// UpdateLoginAlertSettingsRequest は不審なログインの通知設定の更新リクエスト（省略した項目は変更しない）
+type UpdateLoginAlertSettingsRequest struct {
+	AlertsEnabled       *bool `json:"alerts_enabled" example:"true"`
+	RequireVerification *bool `json:"require_verification" example:"true"`
+} // @name UpdateLoginAlertSettingsRequest
+
+// ListDevices ログイン端末一覧
+// @Summary      ログイン端末一覧
+// @Description  自分のアカウントにログインした端末を最後にログインした順に取得します。記憶した端末はtrusted_untilに期限を返します。確認コードの入力待ちの端末も含みます（last_seen_atがゼロ値）
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} LoginDevicesResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/devices [get]
+func (c *DeviceController) ListDevices(ctx *gin.Context) {
+	userID, ok := c.requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	devices, err := c.deviceService.ListDevices(ctx, userID)
+	if err != nil {
+		c.logger.Error("Failed to list login devices", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get login devices",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LoginDevicesResponse{Success: true, Data: devices})
+}
+
+// UpdateDevice ログイン端末の信頼設定
+// @Summary      ログイン端末の信頼設定
+// @Description  端末を信頼済みにする、または信頼を取り消します。信頼済みの端末からの不審なログインには確認コードを求めません。falseを指定すると、確認コードでのログイン時に記憶した端末（device_trust Cookie）も取り消します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "端末ID"
+// @Param        request body UpdateDeviceRequest true "信頼設定"
+// @Security     BearerAuth
+// @Success      200 {object} LoginDeviceResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "端末が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/devices/{id} [put]
+func (c *DeviceController) UpdateDevice(ctx *gin.Context) {
+	userID, ok := c.requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var req UpdateDeviceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	device, err := c.deviceService.SetDeviceTrusted(ctx, userID, ctx.Param("id"), *req.Trusted)
+	if err != nil {
+		c.respondDeviceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LoginDeviceResponse{Success: true, Data: device})
+}
+
+// RemoveDevice ログイン端末の削除
+// @Summary      ログイン端末の削除
+// @Description  端末を一覧から削除します（記憶した端末のCookieも無効になります）。次にその端末からログインした場合は初めての端末として扱います
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "端末ID"
+// @Security     BearerAuth
+// @Success      204 "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "端末が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/devices/{id} [delete]
+func (c *DeviceController) RemoveDevice(ctx *gin.Context) {
+	userID, ok := c.requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.deviceService.RemoveDevice(ctx, userID, ctx.Param("id")); err != nil {
+		c.respondDeviceError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetLoginAlertSettings 不審なログインの通知設定
+// @Summary      不審なログインの通知設定
+// @Description  初めての端末・国からのログインや不可能な移動を検知した場合の通知設定を取得します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} LoginAlertSettingsResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/login-alerts [get]
+func (c *DeviceController) GetLoginAlertSettings(ctx *gin.Context) {
+	userID, ok := c.requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	settings, err := c.deviceService.GetAlertSettings(ctx, userID)
+	if err != nil {
+		c.logger.Error("Failed to get login alert settings", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get login alert settings",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LoginAlertSettingsResponse{Success: true, Data: settings})
+}
+
+// UpdateLoginAlertSettings 不審なログインの通知設定の更新
+// @Summary      不審なログインの通知設定の更新
+// @Description  通知の有無と、信頼済みでない端末からの不審なログインに確認コードを求めるかを変更します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdateLoginAlertSettingsRequest true "通知設定"
+// @Security     BearerAuth
+// @Success      200 {object} LoginAlertSettingsResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/login-alerts [put]
+func (c *DeviceController) UpdateLoginAlertSettings(ctx *gin.Context) {
+	userID, ok := c.requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var req UpdateLoginAlertSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	settings, err := c.deviceService.UpdateAlertSettings(ctx, userID, deviceService.UpdateAlertSettingsInput{
+		AlertsEnabled:       req.AlertsEnabled,
+		RequireVerification: req.RequireVerification,
+	})
+	if err != nil {
+		c.logger.Error("Failed to update login alert settings", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to update login alert settings",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LoginAlertSettingsResponse{Success: true, Data: settings})
+}
+
+// requireUser は認証済みユーザーのIDを返す（未認証の場合は401を返す）
+func (c *DeviceController) requireUser(ctx *gin.Context) (string, bool) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+func (c *DeviceController) respondDeviceError(ctx *gin.Context, err error) {
+	if errors.Is(err, deviceService.ErrDeviceNotFound) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "NOT_FOUND",
+			Message: "Login device not found",
+		})
+		return
+	}
+	c.logger.Error("Failed to update login device", logger.Error(err))
+	ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+		Success: false,
+		Error:   "INTERNAL_ERROR",
+		Message: "Failed to update login device",
+	})
+}
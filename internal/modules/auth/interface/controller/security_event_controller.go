@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// SecurityEventController はセキュリティイベントの監査ログのHTTPリクエストを処理するコントローラー
+type SecurityEventController struct {
+	auditService *auditService.AuditService
+	logger       logger.Logger
+}
+
+// NewSecurityEventController は新しいSecurityEventControllerを作成する
+func NewSecurityEventController(auditService *auditService.AuditService, logger logger.Logger) *SecurityEventController {
+	return &SecurityEventController{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// SecurityEventsResponse はセキュリティイベント一覧のレスポンス
+type SecurityEventsResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    []*domain.SecurityEvent `json:"data"`
+	// 次のページを取得する場合にbeforeに指定する日時（次のページがない場合は空）
+	NextBefore string `json:"next_before,omitempty" example:"2024-06-01T09:00:00Z"`
+} // @name SecurityEventsResponse
+
+// ListMySecurityEvents 自分のセキュリティイベント一覧
+// @Summary      自分のセキュリティイベント一覧
+// @Description  ログインの成功・失敗、不審なログイン、トークンの更新、パスワードの変更、権限エラーなど、自分のアカウントのセキュリティイベントを新しい順に取得します（保存期間内のみ）
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        type query string false "イベントの種類" Enums(login_succeeded, login_failed, token_refreshed, token_refresh_failed, password_changed, permission_denied, suspicious_login, account_deactivated, account_reactivated, username_changed)
+// @Param        before query string false "この日時より前のイベントを取得（RFC3339、ページング用）"
+// @Param        limit query int false "取得件数（既定50、最大200）"
+// @Security     BearerAuth
+// @Success      200 {object} SecurityEventsResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/security-events [get]
+func (c *SecurityEventController) ListMySecurityEvents(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	filter, ok := parseSecurityEventFilter(ctx)
+	if !ok {
+		return
+	}
+
+	events, err := c.auditService.ListUserEvents(ctx, userID.String(), filter)
+	c.respondEvents(ctx, events, filter, err)
+}
+
+// ListSecurityEvents セキュリティイベント一覧（管理者用）
+// @Summary      セキュリティイベント一覧（管理者用）
+// @Description  全ユーザーのセキュリティイベントを新しい順に取得します。ユーザーを特定できない失敗（存在しないメールアドレスでのログインなど）も含みます
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        user_id query string false "ユーザーID"
+// @Param        type query string false "イベントの種類" Enums(login_succeeded, login_failed, token_refreshed, token_refresh_failed, password_changed, permission_denied, suspicious_login, account_deactivated, account_reactivated, username_changed)
+// @Param        before query string false "この日時より前のイベントを取得（RFC3339、ページング用）"
+// @Param        limit query int false "取得件数（既定50、最大200）"
+// @Security     BearerAuth
+// @Success      200 {object} SecurityEventsResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/security-events [get]
+func (c *SecurityEventController) ListSecurityEvents(ctx *gin.Context) {
+	filter, ok := parseSecurityEventFilter(ctx)
+	if !ok {
+		return
+	}
+	filter.UserID = ctx.Query("user_id")
+
+	events, err := c.auditService.ListEvents(ctx, filter)
+	c.respondEvents(ctx, events, filter, err)
+}
+
+// respondEvents はイベント一覧と次のページのカーソルを返す
+func (c *SecurityEventController) respondEvents(ctx *gin.Context, events []*domain.SecurityEvent, filter domain.SecurityEventFilter, err error) {
+	if err != nil {
+		if errors.Is(err, auditService.ErrInvalidParameter) {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get security events",
+		})
+		return
+	}
+
+	response := SecurityEventsResponse{
+		Success: true,
+		Data:    events,
+	}
+	limit := filter.Limit
+	switch {
+	case limit <= 0:
+		limit = auditService.DefaultListLimit
+	case limit > auditService.MaxListLimit:
+		limit = auditService.MaxListLimit
+	}
+	if len(events) > 0 && len(events) >= limit {
+		response.NextBefore = events[len(events)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parseSecurityEventFilter はクエリパラメータから検索条件を読み込む（不正な場合は400を返す）
+func parseSecurityEventFilter(ctx *gin.Context) (domain.SecurityEventFilter, bool) {
+	filter := domain.SecurityEventFilter{Type: ctx.Query("type")}
+
+	if value := ctx.Query("before"); value != "" {
+		before, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "before must be an RFC3339 timestamp",
+			})
+			return filter, false
+		}
+		filter.Before = before
+	}
+
+	if value := ctx.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "limit must be a positive integer",
+			})
+			return filter, false
+		}
+		filter.Limit = limit
+	}
+
+	return filter, true
+}
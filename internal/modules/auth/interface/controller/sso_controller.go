@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	ssoService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// SSOController は企業SSO（OIDC）のログインと接続設定のHTTPリクエストを処理するコントローラー
+type SSOController struct {
+	ssoService *ssoService.SSOService
+	// ログイン後にリダイレクトするフロントエンドのURL（空の場合はトークンをJSONで返す）
+	successURL string
+	logger     logger.Logger
+}
+
+// NewSSOController は新しいSSOControllerを作成する
+func NewSSOController(ssoService *ssoService.SSOService, successURL string, logger logger.Logger) *SSOController {
+	return &SSOController{
+		ssoService: ssoService,
+		successURL: successURL,
+		logger:     logger,
+	}
+}
+
+// SSODiscovery はメールアドレスのドメインのSSO設定
+type SSODiscovery struct {
+	// SSOでログインできるか
+	SSO bool `json:"sso" example:"true"`
+	// trueの場合はパスワードでログインできない（管理者を除く）
+	Enforced     bool   `json:"enforced" example:"true"`
+	ConnectionID string `json:"connection_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name         string `json:"name,omitempty" example:"Example Inc."`
+} // @name SSODiscovery
+
+// SSODiscoveryResponse はSSO設定の確認レスポンス
+type SSODiscoveryResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    *SSODiscovery `json:"data"`
+} // @name SSODiscoveryResponse
+
+// SSOConnectionRequest は接続設定の作成・更新リクエスト
+type SSOConnectionRequest struct {
+	Name    string   `json:"name" binding:"required,max=100" example:"Example Inc."`
+	Domains []string `json:"domains" binding:"required,min=1" example:"example.com"`
+	Issuer  string   `json:"issuer" binding:"required" example:"https://example.okta.com"`
+	// IdPに登録したクライアント（更新時にclient_secretを省略した場合は変更しない）
+	ClientID        string   `json:"client_id" binding:"required" example:"0oa1abcd"`
+	ClientSecret    string   `json:"client_secret" example:"secret"`
+	Enforced        bool     `json:"enforced" example:"true"`
+	JITProvisioning bool     `json:"jit_provisioning" example:"true"`
+	RoleClaim       string   `json:"role_claim" example:"groups"`
+	AdminValues     []string `json:"admin_values" example:"yotei-admins"`
+} // @name SSOConnectionRequest
+
+func (r *SSOConnectionRequest) toDomain() *domain.SSOConnection {
+	return &domain.SSOConnection{
+		Name:            r.Name,
+		Domains:         r.Domains,
+		Issuer:          r.Issuer,
+		ClientID:        r.ClientID,
+		ClientSecret:    r.ClientSecret,
+		Enforced:        r.Enforced,
+		JITProvisioning: r.JITProvisioning,
+		RoleClaim:       r.RoleClaim,
+		AdminValues:     r.AdminValues,
+	}
+}
+
+// SSOConnectionResponse は接続設定のレスポンス
+type SSOConnectionResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    *domain.SSOConnection `json:"data"`
+} // @name SSOConnectionResponse
+
+// SSOConnectionsResponse は接続設定一覧のレスポンス
+type SSOConnectionsResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    []*domain.SSOConnection `json:"data"`
+} // @name SSOConnectionsResponse
+
+// Discover SSO設定の確認
+// @Summary      SSO設定の確認
+// @Description  メールアドレスのドメインにSSOの接続設定があるかを返します。ログイン画面でパスワードの入力欄を出すかSSOに誘導するかの判定に使用します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        email query string true "メールアドレス"
+// @Success      200 {object} SSODiscoveryResponse "取得成功"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/sso/discover [get]
+func (c *SSOController) Discover(ctx *gin.Context) {
+	discovery := &SSODiscovery{}
+	if c.ssoService.Enabled() {
+		conn, err := c.ssoService.FindConnectionForEmail(ctx, strings.TrimSpace(ctx.Query("email")))
+		if err != nil {
+			c.logger.Error("Failed to find sso connection", logger.Error(err))
+			ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+				Success: false,
+				Error:   "INTERNAL_ERROR",
+				Message: "Failed to get sso connection",
+			})
+			return
+		}
+		if conn != nil {
+			discovery = &SSODiscovery{SSO: true, Enforced: conn.Enforced, ConnectionID: conn.ID, Name: conn.Name}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, SSODiscoveryResponse{Success: true, Data: discovery})
+}
+
+// Login SSOでのログイン開始
+// @Summary      SSOでのログイン開始
+// @Description  メールアドレスのドメイン（またはconnection_id）の接続設定のIdPにリダイレクトします。ログイン状態はsso_state Cookie（10分間）に保存し、コールバックで照合します。return_toにはログイン後に表示するパス（/から始まる同じオリジンのパス）を指定できます
+// @Tags         auth
+// @Param        email query string false "メールアドレス"
+// @Param        connection_id query string false "接続設定ID"
+// @Param        return_to query string false "ログイン後に表示するパス"
+// @Success      302 "IdPの認可エンドポイントにリダイレクト"
+// @Failure      404 {object} ErrorResponse "接続設定が見つからない"
+// @Failure      503 {object} ErrorResponse "SSOが設定されていない"
+// @Failure      502 {object} ErrorResponse "IdPに接続できない"
+// @Router       /auth/sso/login [get]
+func (c *SSOController) Login(ctx *gin.Context) {
+	authURL, stateCookie, err := c.ssoService.BeginLogin(ctx, strings.TrimSpace(ctx.Query("email")), ctx.Query("connection_id"), ctx.Query("return_to"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ssoService.ErrSSONotConfigured):
+			ctx.JSON(http.StatusServiceUnavailable, ErrorResponse{
+				Success: false,
+				Error:   "SSO_NOT_CONFIGURED",
+				Message: "Single sign-on is not configured",
+			})
+		case errors.Is(err, ssoService.ErrSSOConnectionNotFound):
+			ctx.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   "NOT_FOUND",
+				Message: "No single sign-on connection for this email",
+			})
+		default:
+			c.logger.Error("Failed to begin sso login", logger.Error(err))
+			ctx.JSON(http.StatusBadGateway, ErrorResponse{
+				Success: false,
+				Error:   "IDP_ERROR",
+				Message: "Failed to contact the identity provider",
+			})
+		}
+		return
+	}
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(domain.SSOStateCookie, stateCookie, int(domain.SSOStateDuration.Seconds()), "/", "", true, true)
+	ctx.Redirect(http.StatusFound, authURL)
+}
+
+// Callback SSOのコールバック
+// @Summary      SSOのコールバック
+// @Description  IdPからの認可コードを検証してログインを完了します。ユーザーはIdPのユーザーと紐付け済みのユーザー、同じメールアドレスのユーザー、JITで作成したユーザーの順に対応付け、role_claimを設定した場合は役割を同期します。SSO_SUCCESS_URLを設定した場合はトークンをCookieに設定してリダイレクトし、未設定の場合はログインと同じJSONを返します
+// @Tags         auth
+// @Produce      json
+// @Param        code query string true "認可コード"
+// @Param        state query string true "state"
+// @Success      200 {object} LoginResponse "ログイン成功"
+// @Success      302 "ログイン後の画面にリダイレクト"
+// @Failure      401 {object} ErrorResponse "ログイン状態が無効、またはIdPのユーザーを対応付けられない"
+// @Failure      403 {object} ErrorResponse "アカウントが無効化されている"
+// @Failure      503 {object} ErrorResponse "SSOが設定されていない"
+// @Failure      502 {object} ErrorResponse "IdPとの通信に失敗"
+// @Router       /auth/sso/callback [get]
+func (c *SSOController) Callback(ctx *gin.Context) {
+	stateCookie, _ := ctx.Cookie(domain.SSOStateCookie)
+	ctx.SetCookie(domain.SSOStateCookie, "", -1, "/", "", true, true)
+
+	if idpErr := ctx.Query("error"); idpErr != "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "SSO_FAILED",
+			Message: "Identity provider returned " + idpErr,
+		})
+		return
+	}
+
+	result, err := c.ssoService.CompleteLogin(withClientInfo(ctx), stateCookie, ctx.Query("state"), ctx.Query("code"))
+	if err != nil {
+		c.respondCallbackError(ctx, err)
+		return
+	}
+
+	if c.successURL == "" {
+		respondLogin(ctx, result.AccessToken, result.RefreshToken)
+		return
+	}
+	setTokenCookies(ctx, result.AccessToken, result.RefreshToken)
+	ctx.Redirect(http.StatusFound, strings.TrimRight(c.successURL, "/")+result.ReturnTo)
+}
+
+func (c *SSOController) respondCallbackError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ssoService.ErrSSONotConfigured):
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Success: false,
+			Error:   "SSO_NOT_CONFIGURED",
+			Message: "Single sign-on is not configured",
+		})
+	case errors.Is(err, domain.ErrSSOAccountInactive):
+		ctx.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "ACCOUNT_DEACTIVATED",
+			Message: "Account is deactivated",
+		})
+	case errors.Is(err, domain.ErrSSOStateInvalid),
+		errors.Is(err, domain.ErrSSODomainMismatch),
+		errors.Is(err, domain.ErrSSOEmailNotVerified),
+		errors.Is(err, domain.ErrSSOUserNotFound),
+		errors.Is(err, ssoService.ErrSSOConnectionNotFound):
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "SSO_FAILED",
+			Message: err.Error(),
+		})
+	default:
+		c.logger.Error("Failed to complete sso login", logger.Error(err))
+		ctx.JSON(http.StatusBadGateway, ErrorResponse{
+			Success: false,
+			Error:   "IDP_ERROR",
+			Message: "Failed to complete sign-in with the identity provider",
+		})
+	}
+}
+
+// CreateConnection 接続設定の作成
+// @Summary      SSO接続設定の作成（管理者）
+// @Description  企業テナントのOIDCの接続設定を作成します。IdPにはSSO_REDIRECT_URLをコールバックURLとして登録してください。enforcedを有効にすると対象ドメインのユーザー（管理者を除く）はパスワードでログインできなくなり（403 SSO_REQUIRED）、jit_provisioningを有効にすると初回ログイン時にユーザーを作成します
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body SSOConnectionRequest true "接続設定"
+// @Security     BearerAuth
+// @Success      201 {object} SSOConnectionResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      409 {object} ErrorResponse "ドメインが他の接続設定に使われている"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/sso-connections [post]
+func (c *SSOController) CreateConnection(ctx *gin.Context) {
+	var req SSOConnectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	conn, err := c.ssoService.CreateConnection(ctx, req.toDomain())
+	if err != nil {
+		c.respondConnectionError(ctx, err, "Failed to create sso connection")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, SSOConnectionResponse{Success: true, Data: conn})
+}
+
+// ListConnections 接続設定一覧
+// @Summary      SSO接続設定一覧（管理者）
+// @Description  SSOの接続設定を名前順に取得します。client_secretは返しません
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} SSOConnectionsResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/sso-connections [get]
+func (c *SSOController) ListConnections(ctx *gin.Context) {
+	conns, err := c.ssoService.ListConnections(ctx)
+	if err != nil {
+		c.logger.Error("Failed to list sso connections", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get sso connections",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SSOConnectionsResponse{Success: true, Data: conns})
+}
+
+// UpdateConnection 接続設定の更新
+// @Summary      SSO接続設定の更新（管理者）
+// @Description  SSOの接続設定を更新します。client_secretを省略した場合は変更しません
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "接続設定ID"
+// @Param        request body SSOConnectionRequest true "接続設定"
+// @Security     BearerAuth
+// @Success      200 {object} SSOConnectionResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "接続設定が見つからない"
+// @Failure      409 {object} ErrorResponse "ドメインが他の接続設定に使われている"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/sso-connections/{id} [put]
+func (c *SSOController) UpdateConnection(ctx *gin.Context) {
+	var req SSOConnectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	conn, err := c.ssoService.UpdateConnection(ctx, ctx.Param("id"), req.toDomain())
+	if err != nil {
+		c.respondConnectionError(ctx, err, "Failed to update sso connection")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SSOConnectionResponse{Success: true, Data: conn})
+}
+
+// DeleteConnection 接続設定の削除
+// @Summary      SSO接続設定の削除（管理者）
+// @Description  SSOの接続設定とIdPのユーザーの紐付けを削除します。対象ドメインのユーザーは再びパスワードでログインできます
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "接続設定ID"
+// @Security     BearerAuth
+// @Success      204 "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "接続設定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /auth/admin/sso-connections/{id} [delete]
+func (c *SSOController) DeleteConnection(ctx *gin.Context) {
+	if err := c.ssoService.DeleteConnection(ctx, ctx.Param("id")); err != nil {
+		c.respondConnectionError(ctx, err, "Failed to delete sso connection")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *SSOController) respondConnectionError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrSSOConnectionInvalid), errors.Is(err, ssoService.ErrSSOClientSecretNeeded):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	case errors.Is(err, ssoService.ErrSSOConnectionNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "NOT_FOUND",
+			Message: "SSO connection not found",
+		})
+	case errors.Is(err, ssoService.ErrSSODomainInUse):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+			Success: false,
+			Error:   "CONFLICT",
+			Message: err.Error(),
+		})
+	default:
+		c.logger.Error(message, logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: message,
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
 	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
@@ -36,6 +38,12 @@ type UserResponse struct {
 	Role     string `json:"role"`
 }
 
+// ChangePasswordRequest はパスワード変更のリクエスト構造体
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
 // DetailedUserResponse は詳細なユーザー情報（本人または管理者用）
 type DetailedUserResponse struct {
 	ID            string `json:"id"`
@@ -54,7 +62,14 @@ func (c *UserController) GetUsers(ctx *gin.Context) {
 	// 検索クエリの取得
 	search := strings.TrimSpace(ctx.Query("search"))
 
-	users, err := c.UserService.GetUsers(ctx, search)
+	// 認証済みの場合はブロック関係にあるユーザーを除外する
+	var users []*domain.User
+	var err error
+	if viewerID, ok := currentUserID(ctx); ok {
+		users, err = c.UserService.GetUsersForViewer(ctx, viewerID, search)
+	} else {
+		users, err = c.UserService.GetUsers(ctx, search)
+	}
 	if err != nil {
 		c.logger.Error("Failed to get users", logger.Error(err))
 		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -242,6 +257,22 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 	})
 			return
 		}
+		if errors.Is(err, domain.ErrInvalidUsername) || errors.Is(err, domain.ErrUsernameReserved) {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "INVALID_USERNAME",
+				Message: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrUsernameChangeCooldown) {
+			ctx.JSON(http.StatusTooManyRequests, ErrorResponse{
+				Success: false,
+				Error:   "USERNAME_CHANGE_COOLDOWN",
+				Message: err.Error(),
+			})
+			return
+		}
 		if strings.Contains(err.Error(), "username already exists") {
 			ctx.JSON(http.StatusConflict, ErrorResponse{
 		Success: false,
@@ -346,3 +377,66 @@ func (c *UserController) UpdateCurrentUser(ctx *gin.Context) {
 	ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: userIDStr.(string)})
 	c.UpdateUser(ctx)
 }
+
+// ChangeCurrentUserPassword は現在のユーザーのパスワードを変更する
+func (c *UserController) ChangeCurrentUserPassword(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := c.UserService.ChangePassword(withClientInfo(ctx), userID, req.CurrentPassword, req.NewPassword); err != nil {
+		if err.Error() == "incorrect password" {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "INCORRECT_PASSWORD",
+				Message: "Current password is incorrect",
+			})
+			return
+		}
+		c.logger.Error("Failed to change password", logger.Any("userID", userID), logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to change password",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Password changed successfully",
+	})
+}
+
+// currentUserID はauth_middlewareで設定されたユーザーIDを取得する
+func currentUserID(ctx *gin.Context) (uuid.UUID, bool) {
+	value, exists := ctx.Get("user_id")
+	if !exists {
+		return uuid.Nil, false
+	}
+	userIDStr, ok := value.(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// APIKeyRepository はAPIキーのデータベースリポジトリ実装
+type APIKeyRepository struct {
+	SqlHandler
+}
+
+const apiKeyColumns = `id, name, display_prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at`
+
+// SaveAPIKey はAPIキーを作成または更新する（スコープはカンマ区切りで保存する）
+func (r *APIKeyRepository) SaveAPIKey(ctx context.Context, key *domain.APIKey) error {
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.api_keys
+		(` + apiKeyColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			name = VALUES(name),
+			last_used_at = VALUES(last_used_at),
+			revoked_at = VALUES(revoked_at)`
+	if _, err := r.Execute(query,
+		key.ID,
+		key.Name,
+		key.DisplayPrefix,
+		key.KeyHash,
+		strings.Join(key.Scopes, ","),
+		key.CreatedBy,
+		key.CreatedAt,
+		key.LastUsedAt,
+		key.RevokedAt,
+	); err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKey はAPIキーを取得する（存在しない場合はnil, nil）
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	return r.findOne(`id = ?`, id)
+}
+
+// FindAPIKeyByHash はキーのハッシュでAPIキーを取得する（存在しない場合はnil, nil）
+func (r *APIKeyRepository) FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	return r.findOne(`key_hash = ?`, keyHash)
+}
+
+// ListAPIKeys は無効化したものを含むAPIキーを新しい順に取得する
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + `
+		FROM ` + "`Yotei-Plus`" + `.api_keys
+		ORDER BY created_at DESC`
+
+	row, err := r.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer row.Close()
+
+	keys := []*domain.APIKey{}
+	for row.Next() {
+		key, err := scanAPIKey(row)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) findOne(condition string, arg interface{}) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + `
+		FROM ` + "`Yotei-Plus`" + `.api_keys
+		WHERE ` + condition
+
+	row, err := r.Query(query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil
+	}
+	return scanAPIKey(row)
+}
+
+func scanAPIKey(row Row) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes string
+	var lastUsedAt, revokedAt sql.NullTime
+	if err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.DisplayPrefix,
+		&key.KeyHash,
+		&scopes,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&lastUsedAt,
+		&revokedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan api key: %w", err)
+	}
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, ",")
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// LoginDeviceRepository はログイン端末と不審なログインの通知設定のデータベースリポジトリ実装
+type LoginDeviceRepository struct {
+	SqlHandler
+}
+
+const loginDeviceColumns = `id, user_id, fingerprint, user_agent, trusted, trusted_until, trust_token_hash, last_ip,
+	last_country, last_latitude, last_longitude,
+	verification_code_hash, verification_expires_at, verification_attempts,
+	created_at, last_seen_at`
+
+// ListLoginDevices はユーザーのログイン端末を最後にログインした順に取得する
+func (r *LoginDeviceRepository) ListLoginDevices(ctx context.Context, userID string) ([]*domain.LoginDevice, error) {
+	query := `SELECT ` + loginDeviceColumns + `
+		FROM ` + "`Yotei-Plus`" + `.login_devices
+		WHERE user_id = ?
+		ORDER BY last_seen_at DESC, created_at DESC`
+
+	row, err := r.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login devices: %w", err)
+	}
+	defer row.Close()
+
+	devices := []*domain.LoginDevice{}
+	for row.Next() {
+		device, err := scanLoginDevice(row)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// GetLoginDevice は端末を取得する（存在しない場合はnil, nil）
+func (r *LoginDeviceRepository) GetLoginDevice(ctx context.Context, id string) (*domain.LoginDevice, error) {
+	query := `SELECT ` + loginDeviceColumns + `
+		FROM ` + "`Yotei-Plus`" + `.login_devices
+		WHERE id = ?`
+
+	row, err := r.Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login device: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil
+	}
+	return scanLoginDevice(row)
+}
+
+// SaveLoginDevice は端末を作成または更新する
+func (r *LoginDeviceRepository) SaveLoginDevice(ctx context.Context, device *domain.LoginDevice) error {
+	var country sql.NullString
+	var latitude, longitude sql.NullFloat64
+	if device.LastLocation != nil {
+		country = sql.NullString{String: device.LastLocation.Country, Valid: true}
+		latitude = sql.NullFloat64{Float64: device.LastLocation.Latitude, Valid: true}
+		longitude = sql.NullFloat64{Float64: device.LastLocation.Longitude, Valid: true}
+	}
+	var lastSeenAt sql.NullTime
+	if device.IsKnown() {
+		lastSeenAt = sql.NullTime{Time: device.LastSeenAt, Valid: true}
+	}
+
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.login_devices
+		(` + loginDeviceColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			trusted = VALUES(trusted),
+			trusted_until = VALUES(trusted_until),
+			trust_token_hash = VALUES(trust_token_hash),
+			last_ip = VALUES(last_ip),
+			last_country = VALUES(last_country),
+			last_latitude = VALUES(last_latitude),
+			last_longitude = VALUES(last_longitude),
+			verification_code_hash = VALUES(verification_code_hash),
+			verification_expires_at = VALUES(verification_expires_at),
+			verification_attempts = VALUES(verification_attempts),
+			last_seen_at = VALUES(last_seen_at)`
+	if _, err := r.Execute(query,
+		device.ID,
+		device.UserID,
+		device.Fingerprint,
+		device.UserAgent,
+		device.Trusted,
+		device.TrustedUntil,
+		device.TrustTokenHash,
+		device.LastIP,
+		country,
+		latitude,
+		longitude,
+		device.VerificationCodeHash,
+		device.VerificationExpiresAt,
+		device.VerificationAttempts,
+		device.CreatedAt,
+		lastSeenAt,
+	); err != nil {
+		return fmt.Errorf("failed to save login device: %w", err)
+	}
+	return nil
+}
+
+// DeleteLoginDevice はユーザーの端末を削除し、削除したかどうかを返す
+func (r *LoginDeviceRepository) DeleteLoginDevice(ctx context.Context, userID, id string) (bool, error) {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.login_devices WHERE id = ? AND user_id = ?`
+	result, err := r.Execute(query, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete login device: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetLoginAlertSettings は通知設定を取得する（保存していない場合はnil, nil）
+func (r *LoginDeviceRepository) GetLoginAlertSettings(ctx context.Context, userID string) (*domain.LoginAlertSettings, error) {
+	query := `SELECT user_id, alerts_enabled, require_verification, updated_at
+		FROM ` + "`Yotei-Plus`" + `.login_alert_settings
+		WHERE user_id = ?`
+
+	row, err := r.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login alert settings: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil
+	}
+	var settings domain.LoginAlertSettings
+	if err := row.Scan(&settings.UserID, &settings.AlertsEnabled, &settings.RequireVerification, &settings.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan login alert settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// SaveLoginAlertSettings は通知設定を作成または更新する
+func (r *LoginDeviceRepository) SaveLoginAlertSettings(ctx context.Context, settings *domain.LoginAlertSettings) error {
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.login_alert_settings
+		(user_id, alerts_enabled, require_verification, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			alerts_enabled = VALUES(alerts_enabled),
+			require_verification = VALUES(require_verification),
+			updated_at = VALUES(updated_at)`
+	if _, err := r.Execute(query,
+		settings.UserID,
+		settings.AlertsEnabled,
+		settings.RequireVerification,
+		settings.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to save login alert settings: %w", err)
+	}
+	return nil
+}
+
+func scanLoginDevice(row Row) (*domain.LoginDevice, error) {
+	var device domain.LoginDevice
+	var country sql.NullString
+	var latitude, longitude sql.NullFloat64
+	var trustedUntil, expiresAt, lastSeenAt sql.NullTime
+	if err := row.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.Fingerprint,
+		&device.UserAgent,
+		&device.Trusted,
+		&trustedUntil,
+		&device.TrustTokenHash,
+		&device.LastIP,
+		&country,
+		&latitude,
+		&longitude,
+		&device.VerificationCodeHash,
+		&expiresAt,
+		&device.VerificationAttempts,
+		&device.CreatedAt,
+		&lastSeenAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan login device: %w", err)
+	}
+	if country.Valid {
+		device.LastLocation = &domain.GeoLocation{
+			Country:   country.String,
+			Latitude:  latitude.Float64,
+			Longitude: longitude.Float64,
+		}
+	}
+	if trustedUntil.Valid {
+		device.TrustedUntil = &trustedUntil.Time
+	}
+	if expiresAt.Valid {
+		device.VerificationExpiresAt = &expiresAt.Time
+	}
+	device.LastSeenAt = lastSeenAt.Time
+	return &device, nil
+}
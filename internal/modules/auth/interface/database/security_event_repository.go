@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// SecurityEventRepository はセキュリティイベントの監査ログのデータベースリポジトリ実装
+type SecurityEventRepository struct {
+	SqlHandler
+}
+
+// SaveSecurityEvent はセキュリティイベントを保存する
+func (r *SecurityEventRepository) SaveSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	var userID sql.NullString
+	if event.UserID != "" {
+		userID = sql.NullString{String: event.UserID, Valid: true}
+	}
+
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.security_events
+		(id, user_id, type, ip_address, user_agent, detail, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	if _, err := r.Execute(query,
+		event.ID,
+		userID,
+		event.Type,
+		event.IPAddress,
+		event.UserAgent,
+		event.Detail,
+		event.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to save security event: %w", err)
+	}
+	return nil
+}
+
+// ListSecurityEvents は条件に一致するイベントを新しい順に取得する
+func (r *SecurityEventRepository) ListSecurityEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	conditions := []string{"created_at >= ?"}
+	args := []interface{}{filter.Since}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if !filter.Before.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.Before)
+	}
+	args = append(args, filter.Limit)
+
+	query := `SELECT id, user_id, type, ip_address, user_agent, detail, created_at
+		FROM ` + "`Yotei-Plus`" + `.security_events
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at DESC
+		LIMIT ?`
+
+	row, err := r.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	defer row.Close()
+
+	events := []*domain.SecurityEvent{}
+	for row.Next() {
+		var event domain.SecurityEvent
+		var userID sql.NullString
+		if err := row.Scan(
+			&event.ID,
+			&userID,
+			&event.Type,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.Detail,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+		event.UserID = userID.String
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// DeleteSecurityEventsBefore はbeforeより前のイベントを削除し、削除した件数を返す
+func (r *SecurityEventRepository) DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.security_events WHERE created_at < ?`
+	result, err := r.Execute(query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete security events: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// SSORepository はSSOの接続設定とIdPのユーザーの紐付けのデータベースリポジトリ実装
+type SSORepository struct {
+	SqlHandler
+}
+
+const ssoConnectionColumns = `id, name, domains, issuer, client_id, client_secret, enforced, jit_provisioning, role_claim, admin_values, created_at, updated_at`
+
+// SaveSSOConnection は接続設定を作成または更新する（ドメインと管理者の値はカンマ区切りで保存する）
+func (r *SSORepository) SaveSSOConnection(ctx context.Context, conn *domain.SSOConnection) error {
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.sso_connections
+		(` + ssoConnectionColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			name = VALUES(name),
+			domains = VALUES(domains),
+			issuer = VALUES(issuer),
+			client_id = VALUES(client_id),
+			client_secret = VALUES(client_secret),
+			enforced = VALUES(enforced),
+			jit_provisioning = VALUES(jit_provisioning),
+			role_claim = VALUES(role_claim),
+			admin_values = VALUES(admin_values),
+			updated_at = VALUES(updated_at)`
+	if _, err := r.Execute(query,
+		conn.ID,
+		conn.Name,
+		strings.Join(conn.Domains, ","),
+		conn.Issuer,
+		conn.ClientID,
+		conn.ClientSecret,
+		conn.Enforced,
+		conn.JITProvisioning,
+		conn.RoleClaim,
+		strings.Join(conn.AdminValues, ","),
+		conn.CreatedAt,
+		conn.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to save sso connection: %w", err)
+	}
+	return nil
+}
+
+// GetSSOConnection は接続設定を取得する（存在しない場合はnil, nil）
+func (r *SSORepository) GetSSOConnection(ctx context.Context, id string) (*domain.SSOConnection, error) {
+	query := `SELECT ` + ssoConnectionColumns + `
+		FROM ` + "`Yotei-Plus`" + `.sso_connections
+		WHERE id = ?`
+
+	row, err := r.Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sso connection: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil
+	}
+	return scanSSOConnection(row)
+}
+
+// ListSSOConnections は接続設定を名前順に取得する
+func (r *SSORepository) ListSSOConnections(ctx context.Context) ([]*domain.SSOConnection, error) {
+	query := `SELECT ` + ssoConnectionColumns + `
+		FROM ` + "`Yotei-Plus`" + `.sso_connections
+		ORDER BY name`
+
+	row, err := r.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sso connections: %w", err)
+	}
+	defer row.Close()
+
+	conns := []*domain.SSOConnection{}
+	for row.Next() {
+		conn, err := scanSSOConnection(row)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
+
+// DeleteSSOConnection は接続設定を削除する（紐付けは外部キーで削除される、削除した場合はtrue）
+func (r *SSORepository) DeleteSSOConnection(ctx context.Context, id string) (bool, error) {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.sso_connections WHERE id = ?`
+
+	result, err := r.Execute(query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete sso connection: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindSSOIdentity はIdPのユーザーの紐付けを取得する（存在しない場合はnil, nil）
+func (r *SSORepository) FindSSOIdentity(ctx context.Context, connectionID, subject string) (*domain.SSOIdentityLink, error) {
+	query := `SELECT connection_id, subject, user_id, created_at
+		FROM ` + "`Yotei-Plus`" + `.sso_identities
+		WHERE connection_id = ? AND subject = ?`
+
+	row, err := r.Query(query, connectionID, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sso identity: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil
+	}
+	var link domain.SSOIdentityLink
+	if err := row.Scan(&link.ConnectionID, &link.Subject, &link.UserID, &link.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan sso identity: %w", err)
+	}
+	return &link, nil
+}
+
+// SaveSSOIdentity はIdPのユーザーの紐付けを保存する
+func (r *SSORepository) SaveSSOIdentity(ctx context.Context, link *domain.SSOIdentityLink) error {
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.sso_identities
+		(connection_id, subject, user_id, created_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE user_id = VALUES(user_id)`
+	if _, err := r.Execute(query, link.ConnectionID, link.Subject, link.UserID, link.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save sso identity: %w", err)
+	}
+	return nil
+}
+
+func scanSSOConnection(row Row) (*domain.SSOConnection, error) {
+	var conn domain.SSOConnection
+	var domains, adminValues string
+	if err := row.Scan(
+		&conn.ID,
+		&conn.Name,
+		&domains,
+		&conn.Issuer,
+		&conn.ClientID,
+		&conn.ClientSecret,
+		&conn.Enforced,
+		&conn.JITProvisioning,
+		&conn.RoleClaim,
+		&adminValues,
+		&conn.CreatedAt,
+		&conn.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan sso connection: %w", err)
+	}
+	if domains != "" {
+		conn.Domains = strings.Split(domains, ",")
+	}
+	if adminValues != "" {
+		conn.AdminValues = strings.Split(adminValues, ",")
+	}
+	return &conn, nil
+}
@@ -32,6 +32,44 @@ func (r *IUserRepository) UserExists(userID string) (bool, error) {
 	return row.Next(), nil
 }
 
+// FindExistingUserIDs は指定されたIDのうち存在するユーザーIDを一括取得
+func (r *IUserRepository) FindExistingUserIDs(userIDs []string) (map[string]bool, error) {
+	if len(userIDs) == 0 {
+		return make(map[string]bool), nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := `SELECT id FROM ` + "`Yotei-Plus`" + `.users 
+		WHERE id IN (` + strings.Join(placeholders, ",") + `)`
+
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check users existence: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			fmt.Printf("Warning: failed to close rows: %v\n", closeErr)
+		}
+	}()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		result[id] = true
+	}
+
+	return result, nil
+}
+
 // GetUserBasicInfo はユーザーの基本情報のみ取得
 func (r *IUserRepository) GetUserBasicInfo(userID string) (*UserBasicInfo, error) {
 	query := `SELECT id, username, email FROM ` + "`Yotei-Plus`" + `.users WHERE id = ? LIMIT 1`
@@ -124,8 +162,8 @@ func (r *IUserRepository) CreateUser(user *domain.User) error {
 	}
 
 	query := `INSERT INTO ` + "`Yotei-Plus`" + `.users 
-		(id, username, email, password, role, email_verified, last_login, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		(id, username, email, password, role, email_verified, last_login, deactivated_at, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := r.Execute(query,
 		user.ID.String(),
@@ -135,6 +173,7 @@ func (r *IUserRepository) CreateUser(user *domain.User) error {
 		user.Role,
 		user.EmailVerified,
 		user.LastLogin,
+		user.DeactivatedAt,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -147,7 +186,7 @@ func (r *IUserRepository) CreateUser(user *domain.User) error {
 
 // FindUserByEmail はメールアドレスでユーザーを検索する（コネクション管理改善）
 func (r *IUserRepository) FindUserByEmail(email string) (*domain.User, error) {
-	query := `SELECT id, username, email, password, role, email_verified, last_login, created_at, updated_at 
+	query := `SELECT id, username, email, password, role, email_verified, last_login, deactivated_at, created_at, updated_at 
 		FROM ` + "`Yotei-Plus`" + `.users 
 		WHERE email = ? LIMIT 1`
 
@@ -170,7 +209,7 @@ func (r *IUserRepository) FindUserByEmail(email string) (*domain.User, error) {
 
 // FindUserByID はIDでユーザーを検索する（コネクション管理改善）
 func (r *IUserRepository) FindUserByID(id uuid.UUID) (*domain.User, error) {
-	query := `SELECT id, username, email, password, role, email_verified, last_login, created_at, updated_at 
+	query := `SELECT id, username, email, password, role, email_verified, last_login, deactivated_at, created_at, updated_at 
 		FROM ` + "`Yotei-Plus`" + `.users 
 		WHERE id = ? LIMIT 1`
 
@@ -193,7 +232,7 @@ func (r *IUserRepository) FindUserByID(id uuid.UUID) (*domain.User, error) {
 
 // FindUserByUsername はユーザー名による検索（コネクション管理改善）
 func (r *IUserRepository) FindUserByUsername(username string) (*domain.User, error) {
-	query := `SELECT id, username, email, password, role, email_verified, last_login, created_at, updated_at 
+	query := `SELECT id, username, email, password, role, email_verified, last_login, deactivated_at, created_at, updated_at 
 		FROM ` + "`Yotei-Plus`" + `.users 
 		WHERE username = ? LIMIT 1`
 
@@ -222,14 +261,14 @@ func (r *IUserRepository) FindUsers(search string) ([]*domain.User, error) {
 	if search != "" {
 		search = strings.TrimSpace(search)
 		searchPattern := "%" + search + "%"
-		query = `SELECT id, username, email, password, role, email_verified, last_login, created_at, updated_at 
+		query = `SELECT id, username, email, password, role, email_verified, last_login, deactivated_at, created_at, updated_at 
 			FROM ` + "`Yotei-Plus`" + `.users 
 			WHERE username LIKE ? OR email LIKE ? 
 			ORDER BY username ASC 
 			LIMIT 100`
 		args = []interface{}{searchPattern, searchPattern}
 	} else {
-		query = `SELECT id, username, email, password, role, email_verified, last_login, created_at, updated_at 
+		query = `SELECT id, username, email, password, role, email_verified, last_login, deactivated_at, created_at, updated_at 
 			FROM ` + "`Yotei-Plus`" + `.users 
 			ORDER BY username ASC 
 			LIMIT 100`
@@ -269,7 +308,7 @@ func (r *IUserRepository) UpdateUser(user *domain.User) error {
 	user.UpdatedAt = time.Now()
 
 	query := `UPDATE ` + "`Yotei-Plus`" + `.users 
-		SET username = ?, email = ?, password = ?, role = ?, email_verified = ?, last_login = ?, updated_at = ? 
+		SET username = ?, email = ?, password = ?, role = ?, email_verified = ?, last_login = ?, deactivated_at = ?, updated_at = ? 
 		WHERE id = ?`
 
 	result, err := r.Execute(query,
@@ -279,6 +318,7 @@ func (r *IUserRepository) UpdateUser(user *domain.User) error {
 		user.Role,
 		user.EmailVerified,
 		user.LastLogin,
+		user.DeactivatedAt,
 		user.UpdatedAt,
 		user.ID.String(),
 	)
@@ -303,6 +343,7 @@ func (r *IUserRepository) scanUser(row Row) (*domain.User, error) {
 	var user domain.User
 	var idStr string
 	var lastLogin sql.NullTime
+	var deactivatedAt sql.NullTime
 
 	if err := row.Scan(
 		&idStr,
@@ -312,6 +353,7 @@ func (r *IUserRepository) scanUser(row Row) (*domain.User, error) {
 		&user.Role,
 		&user.EmailVerified,
 		&lastLogin,
+		&deactivatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	); err != nil {
@@ -329,6 +371,9 @@ func (r *IUserRepository) scanUser(row Row) (*domain.User, error) {
 	if lastLogin.Valid {
 		user.LastLogin = &lastLogin.Time
 	}
+	if deactivatedAt.Valid {
+		user.DeactivatedAt = &deactivatedAt.Time
+	}
 
 	return &user, nil
 }
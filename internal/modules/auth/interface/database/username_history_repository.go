@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// UsernameHistoryRepository はユーザー名の変更履歴のデータベースリポジトリ実装
+type UsernameHistoryRepository struct {
+	SqlHandler
+}
+
+// SaveUsernameChange は変更履歴を保存する
+func (r *UsernameHistoryRepository) SaveUsernameChange(ctx context.Context, change *domain.UsernameChange) error {
+	query := `INSERT INTO ` + "`Yotei-Plus`" + `.username_history
+		(id, user_id, old_username, new_username, changed_at)
+		VALUES (?, ?, ?, ?, ?)`
+	if _, err := r.Execute(query,
+		change.ID.String(),
+		change.UserID.String(),
+		change.OldUsername,
+		change.NewUsername,
+		change.ChangedAt,
+	); err != nil {
+		return fmt.Errorf("failed to save username change: %w", err)
+	}
+	return nil
+}
+
+// FindLatestUsernameChange はユーザーの最後の変更を取得する（変更したことがない場合は nil, nil）
+func (r *UsernameHistoryRepository) FindLatestUsernameChange(ctx context.Context, userID uuid.UUID) (*domain.UsernameChange, error) {
+	query := `SELECT id, user_id, old_username, new_username, changed_at
+		FROM ` + "`Yotei-Plus`" + `.username_history
+		WHERE user_id = ?
+		ORDER BY changed_at DESC
+		LIMIT 1`
+	return r.findOne(query, userID.String())
+}
+
+// FindRecentUsernameChange は since 以降に username（大文字小文字を区別しない）から変更した最新の履歴を取得する（ない場合は nil, nil）
+func (r *UsernameHistoryRepository) FindRecentUsernameChange(ctx context.Context, username string, since time.Time) (*domain.UsernameChange, error) {
+	query := `SELECT id, user_id, old_username, new_username, changed_at
+		FROM ` + "`Yotei-Plus`" + `.username_history
+		WHERE LOWER(old_username) = LOWER(?) AND changed_at >= ?
+		ORDER BY changed_at DESC
+		LIMIT 1`
+	return r.findOne(query, username, since)
+}
+
+func (r *UsernameHistoryRepository) findOne(query string, args ...interface{}) (*domain.UsernameChange, error) {
+	row, err := r.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query username history: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil
+	}
+	var change domain.UsernameChange
+	var id, userID string
+	if err := row.Scan(&id, &userID, &change.OldUsername, &change.NewUsername, &change.ChangedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan username change: %w", err)
+	}
+	if change.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid username change id: %w", err)
+	}
+	if change.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+	return &change, nil
+}
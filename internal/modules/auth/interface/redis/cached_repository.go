@@ -0,0 +1,157 @@
+package redis
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// ブラックリストの確認のメトリクス
+// ヒット率は hits / lookups、ストアの平均応答時間は lookup_microseconds / misses で求める
+const (
+	MetricBlacklistLookups      = "auth_token_blacklist_lookups_total"
+	MetricBlacklistCacheHits    = "auth_token_blacklist_cache_hits_total"
+	MetricBlacklistCacheMisses  = "auth_token_blacklist_cache_misses_total"
+	MetricBlacklistLookupMicros = "auth_token_blacklist_lookup_microseconds_total"
+)
+
+const (
+	// DefaultBlacklistCacheSize はLRUに保持するトークンの既定の上限
+	DefaultBlacklistCacheSize = 10000
+
+	// DefaultBlacklistNegativeTTL はブラックリストに登録されていないという結果をキャッシュする既定の期間
+	DefaultBlacklistNegativeTTL = 5 * time.Second
+
+	// blacklistedLookupTTL はストアで失効済みと確認したトークンをキャッシュする期間
+	// 残りの有効期限はわからないが、失効は取り消されず、期限切れのトークンはどちらにしても拒否されるため長めに保持する
+	blacklistedLookupTTL = time.Hour
+)
+
+// CachedTokenRepository はブラックリストの確認結果をプロセス内のLRUにキャッシュする ITokenRepository
+// 認証が必要なリクエストのたびにRedisへ問い合わせないよう、このプロセスで失効させたトークンはトークンの有効期限まで、
+// ストアで失効済みと確認したトークンは blacklistedLookupTTL の間、失効していないトークンは negativeTTL の間キャッシュする
+// このプロセスで失効させたトークンはすぐにキャッシュに反映する（他のインスタンスで失効させたトークンは最大 negativeTTL の間受け付ける）
+type CachedTokenRepository struct {
+	tokenService.ITokenRepository
+
+	size        int
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// blacklistEntry はLRUのリストの要素
+type blacklistEntry struct {
+	token       string
+	blacklisted bool
+	expiresAt   time.Time
+}
+
+// NewCachedTokenRepository は新しいCachedTokenRepositoryを作成する
+// size が0以下の場合は既定の上限、negativeTTL が負の場合は既定の期間を使う（0の場合は失効していない結果をキャッシュしない）
+func NewCachedTokenRepository(next tokenService.ITokenRepository, size int, negativeTTL time.Duration) *CachedTokenRepository {
+	if size <= 0 {
+		size = DefaultBlacklistCacheSize
+	}
+	if negativeTTL < 0 {
+		negativeTTL = DefaultBlacklistNegativeTTL
+	}
+	return &CachedTokenRepository{
+		ITokenRepository: next,
+		size:             size,
+		negativeTTL:      negativeTTL,
+		now:              time.Now,
+		entries:          make(map[string]*list.Element),
+		lru:              list.New(),
+	}
+}
+
+// SaveTokenToBlacklist はトークンをブラックリストに登録し、キャッシュにも反映する
+func (r *CachedTokenRepository) SaveTokenToBlacklist(token string, ttl time.Duration) error {
+	if err := r.ITokenRepository.SaveTokenToBlacklist(token, ttl); err != nil {
+		return err
+	}
+	r.put(token, true, ttl)
+	return nil
+}
+
+// IsTokenBlacklisted はキャッシュにない場合だけストアに問い合わせる
+func (r *CachedTokenRepository) IsTokenBlacklisted(token string) bool {
+	metrics.Counter(MetricBlacklistLookups).Add(1)
+	if blacklisted, ok := r.get(token); ok {
+		metrics.Counter(MetricBlacklistCacheHits).Add(1)
+		return blacklisted
+	}
+	metrics.Counter(MetricBlacklistCacheMisses).Add(1)
+
+	start := time.Now()
+	blacklisted := r.ITokenRepository.IsTokenBlacklisted(token)
+	metrics.Counter(MetricBlacklistLookupMicros).Add(time.Since(start).Microseconds())
+
+	if blacklisted {
+		r.put(token, true, blacklistedLookupTTL)
+	} else {
+		r.put(token, false, r.negativeTTL)
+	}
+	return blacklisted
+}
+
+// Len はキャッシュしているトークンの数を返す
+func (r *CachedTokenRepository) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+func (r *CachedTokenRepository) get(token string) (bool, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.entries[token]
+	if !ok {
+		return false, false
+	}
+	entry := elem.Value.(*blacklistEntry)
+	if !entry.expiresAt.After(r.now()) {
+		r.lru.Remove(elem)
+		delete(r.entries, token)
+		return false, false
+	}
+	r.lru.MoveToFront(elem)
+	return entry.blacklisted, true
+}
+
+func (r *CachedTokenRepository) put(token string, blacklisted bool, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiresAt := r.now().Add(ttl)
+	if elem, ok := r.entries[token]; ok {
+		entry := elem.Value.(*blacklistEntry)
+		// 問い合わせ中に失効させたトークンを、問い合わせ前の失効していない結果で上書きしない
+		if entry.blacklisted && !blacklisted && entry.expiresAt.After(r.now()) {
+			return
+		}
+		entry.blacklisted = blacklisted
+		entry.expiresAt = expiresAt
+		r.lru.MoveToFront(elem)
+		return
+	}
+
+	r.entries[token] = r.lru.PushFront(&blacklistEntry{token: token, blacklisted: blacklisted, expiresAt: expiresAt})
+	for r.lru.Len() > r.size {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*blacklistEntry).token)
+	}
+}
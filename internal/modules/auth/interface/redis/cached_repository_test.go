@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/token/mocks"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCachedRepository は時刻を進められるCachedTokenRepositoryを作成する
+func newTestCachedRepository(t *testing.T, size int) (*CachedTokenRepository, *mocks.MockITokenRepository, *time.Time) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	next := mocks.NewMockITokenRepository(ctrl)
+	repo := NewCachedTokenRepository(next, size, 5*time.Second)
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+	return repo, next, &now
+}
+
+func TestCachedTokenRepository_CachesNegativeLookups(t *testing.T) {
+	repo, next, now := newTestCachedRepository(t, 0)
+	hits := metrics.Counter(MetricBlacklistCacheHits).Value()
+	misses := metrics.Counter(MetricBlacklistCacheMisses).Value()
+
+	next.EXPECT().IsTokenBlacklisted("token-a").Return(false).Times(2)
+
+	assert.False(t, repo.IsTokenBlacklisted("token-a"))
+	assert.False(t, repo.IsTokenBlacklisted("token-a"))
+
+	*now = now.Add(5 * time.Second)
+	assert.False(t, repo.IsTokenBlacklisted("token-a"))
+
+	assert.Equal(t, hits+1, metrics.Counter(MetricBlacklistCacheHits).Value())
+	assert.Equal(t, misses+2, metrics.Counter(MetricBlacklistCacheMisses).Value())
+}
+
+func TestCachedTokenRepository_SaveUpdatesCache(t *testing.T) {
+	repo, next, now := newTestCachedRepository(t, 0)
+
+	next.EXPECT().IsTokenBlacklisted("token-a").Return(false)
+	next.EXPECT().SaveTokenToBlacklist("token-a", time.Hour).Return(nil)
+
+	assert.False(t, repo.IsTokenBlacklisted("token-a"))
+	assert.NoError(t, repo.SaveTokenToBlacklist("token-a", time.Hour))
+
+	// 失効させたトークンはストアに問い合わせずにトークンの有効期限まで拒否する
+	assert.True(t, repo.IsTokenBlacklisted("token-a"))
+	*now = now.Add(59 * time.Minute)
+	assert.True(t, repo.IsTokenBlacklisted("token-a"))
+}
+
+func TestCachedTokenRepository_SaveErrorIsNotCached(t *testing.T) {
+	repo, next, _ := newTestCachedRepository(t, 0)
+
+	next.EXPECT().SaveTokenToBlacklist("token-a", time.Hour).Return(errors.New("redis down"))
+	next.EXPECT().IsTokenBlacklisted("token-a").Return(false)
+
+	assert.Error(t, repo.SaveTokenToBlacklist("token-a", time.Hour))
+	assert.False(t, repo.IsTokenBlacklisted("token-a"))
+}
+
+func TestCachedTokenRepository_CachesBlacklistedLookups(t *testing.T) {
+	repo, next, now := newTestCachedRepository(t, 0)
+
+	next.EXPECT().IsTokenBlacklisted("token-a").Return(true)
+
+	assert.True(t, repo.IsTokenBlacklisted("token-a"))
+	*now = now.Add(30 * time.Minute)
+	assert.True(t, repo.IsTokenBlacklisted("token-a"))
+}
+
+func TestCachedTokenRepository_EvictsLeastRecentlyUsed(t *testing.T) {
+	repo, next, _ := newTestCachedRepository(t, 2)
+
+	next.EXPECT().IsTokenBlacklisted(gomock.Any()).Return(false).Times(4)
+
+	for i := 0; i < 3; i++ {
+		repo.IsTokenBlacklisted(fmt.Sprintf("token-%d", i))
+	}
+	assert.Equal(t, 2, repo.Len())
+
+	// token-0 は追い出されているため再度問い合わせる
+	repo.IsTokenBlacklisted("token-2")
+	repo.IsTokenBlacklisted("token-0")
+}
@@ -4,19 +4,27 @@ import (
 	"time"
 
 	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
-	"github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/redis"
 	"github.com/hryt430/Yotei+/internal/modules/auth/interface/database"
 	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
 )
 
+// TokenCache はブラックリストを保存するキー・バリューストア
+// Redis（RedisTokenCache）とプロセス内のストア（EmbeddedTokenCache）が実装する
+type TokenCache interface {
+	SetWithTTL(key string, value string, ttl time.Duration) error
+	Get(key string) (string, error)
+	Exists(key string) bool
+	Delete(key string) error
+}
+
 // TokenRepositoryAdapter はinfrastructure層の実装をusecase層のインターフェースに適合させる
 type TokenRepositoryAdapter struct {
-	tokenCache   *redis.RedisTokenCache
+	tokenCache   TokenCache
 	tokenStorage *database.TokenStorage
 }
 
 func NewTokenRepositoryAdapter(
-	tokenCache *redis.RedisTokenCache,
+	tokenCache TokenCache,
 	tokenStorage *database.TokenStorage,
 ) tokenService.ITokenRepository {
 	return &TokenRepositoryAdapter{
@@ -25,7 +33,7 @@ func NewTokenRepositoryAdapter(
 	}
 }
 
-// ブラックリスト関連（Redis使用）
+// ブラックリスト関連（Redisまたはプロセス内のストア使用）
 func (r *TokenRepositoryAdapter) SaveTokenToBlacklist(token string, ttl time.Duration) error {
 	key := "blacklist:" + token
 	return r.tokenCache.SetWithTTL(key, "1", ttl)
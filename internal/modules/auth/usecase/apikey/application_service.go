@@ -0,0 +1,149 @@
+package apiKeyService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// lastUsedInterval は最終利用日時を保存する間隔（リクエストごとに書き込まないようにする）
+const lastUsedInterval = time.Minute
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKeyService は管理者が発行するAPIキー・ユーザーが発行するショートカット・ブラウザの拡張機能用のAPIキーの発行・無効化・認証を扱うサービス
+type APIKeyService struct {
+	Repository IAPIKeyRepository
+	Logger     logger.Logger
+
+	now func() time.Time
+}
+
+// NewAPIKeyService はAPIKeyServiceのコンストラクタ
+func NewAPIKeyService(repo IAPIKeyRepository, logger logger.Logger) *APIKeyService {
+	return &APIKeyService{
+		Repository: repo,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// CreateAPIKey はAPIキーを発行し、平文のキーを返す（平文のキーは再表示できない）
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name, createdBy string, scopes []string) (*domain.APIKey, string, error) {
+	key, plaintext, err := domain.NewAPIKey(name, createdBy, scopes, s.now())
+	if err != nil {
+		return nil, "", err
+	}
+	key.ID = uuid.New().String()
+
+	if err := s.Repository.SaveAPIKey(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save api key: %w", err)
+	}
+	return key, plaintext, nil
+}
+
+// ListAPIKeys はAPIキーの一覧を取得する
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	keys, err := s.Repository.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey はAPIキーを無効化する（無効化したキーは一覧に残る）
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	key, err := s.Repository.GetAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if key == nil {
+		return ErrAPIKeyNotFound
+	}
+	return s.revoke(ctx, key)
+}
+
+// revoke はキーを無効化して保存する（既に無効化されている場合は何もしない）
+func (s *APIKeyService) revoke(ctx context.Context, key *domain.APIKey) error {
+	if key.IsRevoked() {
+		return nil
+	}
+
+	key.Revoke(s.now())
+	if err := s.Repository.SaveAPIKey(ctx, key); err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+	return nil
+}
+
+// CreatePersonalAPIKey はユーザーが自分のショートカット用にquickスコープのAPIキーを発行し、平文のキーを返す
+func (s *APIKeyService) CreatePersonalAPIKey(ctx context.Context, name, userID string) (*domain.APIKey, string, error) {
+	return s.CreateAPIKey(ctx, name, userID, []string{domain.APIKeyScopeQuick})
+}
+
+// CreateClipToken はユーザーがブラウザの拡張機能用にclipスコープのAPIキーを発行し、平文のキーを返す
+// 拡張機能はログイン中のセッションと引き換えにキーを受け取り、以降はキーだけで /clip を呼び出す
+func (s *APIKeyService) CreateClipToken(ctx context.Context, name, userID string) (*domain.APIKey, string, error) {
+	return s.CreateAPIKey(ctx, name, userID, []string{domain.APIKeyScopeClip})
+}
+
+// ListPersonalAPIKeys はユーザーが発行したquick・clipスコープのAPIキーを、無効化したものを含めて新しい順に取得する
+func (s *APIKeyService) ListPersonalAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	keys, err := s.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	personal := []*domain.APIKey{}
+	for _, key := range keys {
+		if key.IsOwnedBy(userID) {
+			personal = append(personal, key)
+		}
+	}
+	return personal, nil
+}
+
+// RevokePersonalAPIKey はユーザーが発行したquick・clipスコープのAPIキーを無効化する
+// 他のユーザー・管理者が発行したキーは存在しないものとして扱う
+func (s *APIKeyService) RevokePersonalAPIKey(ctx context.Context, userID, id string) error {
+	key, err := s.Repository.GetAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if key == nil || !key.IsOwnedBy(userID) {
+		return ErrAPIKeyNotFound
+	}
+	return s.revoke(ctx, key)
+}
+
+// Authenticate はキーを検証し、scopeが付与された有効なAPIキーを返す
+// 存在しない・無効化された・スコープがないキーはすべてdomain.ErrAPIKeyInvalidを返す
+func (s *APIKeyService) Authenticate(ctx context.Context, plaintext, scope string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(plaintext, domain.APIKeyPrefix) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	key, err := s.Repository.FindAPIKeyByHash(ctx, domain.HashAPIKey(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	if key == nil || key.IsRevoked() || !key.HasScope(scope) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	now := s.now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval {
+		key.LastUsedAt = &now
+		if err := s.Repository.SaveAPIKey(ctx, key); err != nil {
+			// 最終利用日時の保存に失敗しても認証は妨げない
+			s.Logger.Warn("Failed to update api key last used time", logger.Error(err))
+		}
+	}
+	return key, nil
+}
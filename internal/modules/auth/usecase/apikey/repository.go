@@ -0,0 +1,19 @@
+package apiKeyService
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// IAPIKeyRepository はAPIキーの永続化に関する操作を定義する
+type IAPIKeyRepository interface {
+	// SaveAPIKey はAPIキーを作成または更新する
+	SaveAPIKey(ctx context.Context, key *domain.APIKey) error
+	// GetAPIKey はAPIキーを取得する（存在しない場合はnil, nil）
+	GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error)
+	// FindAPIKeyByHash はキーのハッシュでAPIキーを取得する（存在しない場合はnil, nil）
+	FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	// ListAPIKeys は無効化したものを含むAPIキーを新しい順に取得する
+	ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+}
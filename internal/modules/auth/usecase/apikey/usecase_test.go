@@ -0,0 +1,187 @@
+package apiKeyService
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/apikey/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var (
+	testNow    = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	testLogger = *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+)
+
+func newTestService(t *testing.T) (*APIKeyService, *mocks.MockIAPIKeyRepository) {
+	repo := mocks.NewMockIAPIKeyRepository(gomock.NewController(t))
+	service := NewAPIKeyService(repo, testLogger)
+	service.now = func() time.Time { return testNow }
+	return service, repo
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	service, repo := newTestService(t)
+
+	var saved *domain.APIKey
+	repo.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *domain.APIKey) error {
+		saved = key
+		return nil
+	})
+
+	key, plaintext, err := service.CreateAPIKey(context.Background(), "Okta", "admin-1", []string{domain.APIKeyScopeSCIM})
+	require.NoError(t, err)
+	assert.NotEmpty(t, key.ID)
+	assert.Equal(t, "admin-1", key.CreatedBy)
+	assert.Equal(t, domain.HashAPIKey(plaintext), saved.KeyHash)
+
+	_, _, err = service.CreateAPIKey(context.Background(), "Okta", "admin-1", []string{"unknown"})
+	assert.ErrorIs(t, err, domain.ErrAPIKeyInvalidScope)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	newKey := func(t *testing.T) (*domain.APIKey, string) {
+		key, plaintext, err := domain.NewAPIKey("Okta", "admin-1", []string{domain.APIKeyScopeSCIM}, testNow.Add(-time.Hour))
+		require.NoError(t, err)
+		key.ID = "key-1"
+		return key, plaintext
+	}
+
+	t.Run("valid key records last use", func(t *testing.T) {
+		service, repo := newTestService(t)
+		key, plaintext := newKey(t)
+		repo.EXPECT().FindAPIKeyByHash(gomock.Any(), key.KeyHash).Return(key, nil)
+		repo.EXPECT().SaveAPIKey(gomock.Any(), key).Return(nil)
+
+		got, err := service.Authenticate(context.Background(), plaintext, domain.APIKeyScopeSCIM)
+		require.NoError(t, err)
+		assert.Equal(t, testNow, *got.LastUsedAt)
+	})
+
+	t.Run("recently used key is not saved again", func(t *testing.T) {
+		service, repo := newTestService(t)
+		key, plaintext := newKey(t)
+		lastUsed := testNow.Add(-10 * time.Second)
+		key.LastUsedAt = &lastUsed
+		repo.EXPECT().FindAPIKeyByHash(gomock.Any(), key.KeyHash).Return(key, nil)
+
+		_, err := service.Authenticate(context.Background(), plaintext, domain.APIKeyScopeSCIM)
+		require.NoError(t, err)
+	})
+
+	t.Run("revoked key is rejected", func(t *testing.T) {
+		service, repo := newTestService(t)
+		key, plaintext := newKey(t)
+		key.Revoke(testNow)
+		repo.EXPECT().FindAPIKeyByHash(gomock.Any(), key.KeyHash).Return(key, nil)
+
+		_, err := service.Authenticate(context.Background(), plaintext, domain.APIKeyScopeSCIM)
+		assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+	})
+
+	t.Run("key without the scope is rejected", func(t *testing.T) {
+		service, repo := newTestService(t)
+		key, plaintext := newKey(t)
+		repo.EXPECT().FindAPIKeyByHash(gomock.Any(), key.KeyHash).Return(key, nil)
+
+		_, err := service.Authenticate(context.Background(), plaintext, "other")
+		assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+	})
+
+	t.Run("unknown or malformed keys are rejected", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().FindAPIKeyByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := service.Authenticate(context.Background(), "yp_unknown", domain.APIKeyScopeSCIM)
+		assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+		_, err = service.Authenticate(context.Background(), "not-a-key", domain.APIKeyScopeSCIM)
+		assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+	})
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	service, repo := newTestService(t)
+	key, _, err := domain.NewAPIKey("Okta", "admin-1", []string{domain.APIKeyScopeSCIM}, testNow)
+	require.NoError(t, err)
+	key.ID = "key-1"
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), "key-1").Return(key, nil)
+	repo.EXPECT().SaveAPIKey(gomock.Any(), key).Return(nil)
+	require.NoError(t, service.RevokeAPIKey(context.Background(), "key-1"))
+	assert.True(t, key.IsRevoked())
+
+	repo.EXPECT().GetAPIKey(gomock.Any(), "missing").Return(nil, nil)
+	assert.ErrorIs(t, service.RevokeAPIKey(context.Background(), "missing"), ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyService_PersonalAPIKeys(t *testing.T) {
+	newKey := func(t *testing.T, id, createdBy, scope string) *domain.APIKey {
+		key, _, err := domain.NewAPIKey("key", createdBy, []string{scope}, testNow)
+		require.NoError(t, err)
+		key.ID = id
+		return key
+	}
+
+	t.Run("create issues a quick key owned by the user", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).Return(nil)
+
+		key, plaintext, err := service.CreatePersonalAPIKey(context.Background(), "iPhone", "user-1")
+		require.NoError(t, err)
+		assert.NotEmpty(t, plaintext)
+		assert.Equal(t, []string{domain.APIKeyScopeQuick}, key.Scopes)
+		assert.True(t, key.IsOwnedBy("user-1"))
+	})
+
+	t.Run("create clip token issues a clip key owned by the user", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).Return(nil)
+
+		key, plaintext, err := service.CreateClipToken(context.Background(), "Chrome", "user-1")
+		require.NoError(t, err)
+		assert.NotEmpty(t, plaintext)
+		assert.Equal(t, []string{domain.APIKeyScopeClip}, key.Scopes)
+		assert.True(t, key.IsOwnedBy("user-1"))
+	})
+
+	t.Run("list returns only the user's quick and clip keys", func(t *testing.T) {
+		service, repo := newTestService(t)
+		own := newKey(t, "key-1", "user-1", domain.APIKeyScopeQuick)
+		// 同じユーザーが管理者として発行したSCIMのキー・他のユーザーのキーは含めない
+		scim := newKey(t, "key-2", "user-1", domain.APIKeyScopeSCIM)
+		other := newKey(t, "key-3", "user-2", domain.APIKeyScopeQuick)
+		clip := newKey(t, "key-4", "user-1", domain.APIKeyScopeClip)
+		repo.EXPECT().ListAPIKeys(gomock.Any()).Return([]*domain.APIKey{own, scim, other, clip}, nil)
+
+		keys, err := service.ListPersonalAPIKeys(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.APIKey{own, clip}, keys)
+	})
+
+	t.Run("revoke only accepts the user's own quick keys", func(t *testing.T) {
+		service, repo := newTestService(t)
+		own := newKey(t, "key-1", "user-1", domain.APIKeyScopeQuick)
+		other := newKey(t, "key-3", "user-2", domain.APIKeyScopeQuick)
+		scim := newKey(t, "key-2", "user-1", domain.APIKeyScopeSCIM)
+
+		repo.EXPECT().GetAPIKey(gomock.Any(), "key-1").Return(own, nil)
+		repo.EXPECT().SaveAPIKey(gomock.Any(), own).Return(nil)
+		require.NoError(t, service.RevokePersonalAPIKey(context.Background(), "user-1", "key-1"))
+		assert.True(t, own.IsRevoked())
+
+		repo.EXPECT().GetAPIKey(gomock.Any(), "key-3").Return(other, nil)
+		assert.ErrorIs(t, service.RevokePersonalAPIKey(context.Background(), "user-1", "key-3"), ErrAPIKeyNotFound)
+		repo.EXPECT().GetAPIKey(gomock.Any(), "key-2").Return(scim, nil)
+		assert.ErrorIs(t, service.RevokePersonalAPIKey(context.Background(), "user-1", "key-2"), ErrAPIKeyNotFound)
+		assert.False(t, other.IsRevoked())
+		assert.False(t, scim.IsRevoked())
+	})
+}
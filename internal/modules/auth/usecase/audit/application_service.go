@@ -0,0 +1,129 @@
+package auditService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// DefaultRetention はセキュリティイベントを保存する期間の既定値
+	DefaultRetention = 90 * 24 * time.Hour
+	// DefaultListLimit・MaxListLimit は一覧で取得する件数の既定値と上限
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+	// purgeInterval は保存期間を過ぎたイベントを削除する間隔
+	purgeInterval = time.Hour
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// SecurityEventRecorder はセキュリティイベントを記録するインターフェース
+// 記録の失敗は認証処理を妨げないため、エラーは返さない
+type SecurityEventRecorder interface {
+	Record(ctx context.Context, event *domain.SecurityEvent)
+}
+
+// AuditService はセキュリティイベントの監査ログの記録・参照を扱うサービス
+type AuditService struct {
+	Repository ISecurityEventRepository
+	// イベントを保存する期間（期間を過ぎたイベントは参照できず、記録時に定期的に削除する）
+	Retention time.Duration
+	Logger    logger.Logger
+
+	mu         sync.Mutex
+	lastPurged time.Time
+	now        func() time.Time
+}
+
+// NewAuditService はAuditServiceのコンストラクタ
+func NewAuditService(repo ISecurityEventRepository, retention time.Duration, logger logger.Logger) *AuditService {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &AuditService{
+		Repository: repo,
+		Retention:  retention,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Record はセキュリティイベントを記録する
+// リクエスト元のIPアドレスとUser-Agentは、イベントに設定されていない場合contextから取得する
+func (s *AuditService) Record(ctx context.Context, event *domain.SecurityEvent) {
+	event.ID = uuid.New().String()
+	event.CreatedAt = s.now()
+	if event.IPAddress == "" && event.UserAgent == "" {
+		event.SetClient(domain.ClientInfoFromContext(ctx))
+	}
+
+	if err := s.Repository.SaveSecurityEvent(ctx, event); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to record security event",
+			logger.Any("type", event.Type), logger.Any("userID", event.UserID), logger.Error(err))
+		return
+	}
+
+	s.purgeExpired(ctx)
+}
+
+// ListUserEvents はユーザー本人のセキュリティイベントを新しい順に取得する
+func (s *AuditService) ListUserEvents(ctx context.Context, userID string, filter domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	filter.UserID = userID
+	return s.ListEvents(ctx, filter)
+}
+
+// ListEvents は条件に一致するセキュリティイベントを新しい順に取得する（管理者用）
+func (s *AuditService) ListEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	if filter.Type != "" && !domain.IsValidSecurityEventType(filter.Type) {
+		return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidParameter, filter.Type)
+	}
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = DefaultListLimit
+	case filter.Limit > MaxListLimit:
+		filter.Limit = MaxListLimit
+	}
+
+	// 保存期間を過ぎたイベントは削除前でも返さない
+	if since := s.now().Add(-s.Retention); filter.Since.Before(since) {
+		filter.Since = since
+	}
+
+	events, err := s.Repository.ListSecurityEvents(ctx, filter)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to list security events", logger.Error(err))
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	return events, nil
+}
+
+// purgeExpired は前回から一定時間が経過していれば、保存期間を過ぎたイベントを削除する
+func (s *AuditService) purgeExpired(ctx context.Context) {
+	s.mu.Lock()
+	now := s.now()
+	if now.Sub(s.lastPurged) < purgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurged = now
+	s.mu.Unlock()
+
+	deleted, err := s.Repository.DeleteSecurityEventsBefore(ctx, now.Add(-s.Retention))
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to purge expired security events", logger.Error(err))
+		return
+	}
+	if deleted > 0 {
+		s.Logger.WithContext(ctx).Info("Purged expired security events", logger.Any("deleted", deleted))
+	}
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// MockISecurityEventRepository is a mock of ISecurityEventRepository interface.
+type MockISecurityEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISecurityEventRepositoryMockRecorder
+}
+
+// MockISecurityEventRepositoryMockRecorder is the mock recorder for MockISecurityEventRepository.
+type MockISecurityEventRepositoryMockRecorder struct {
+	mock *MockISecurityEventRepository
+}
+
+// NewMockISecurityEventRepository creates a new mock instance.
+func NewMockISecurityEventRepository(ctrl *gomock.Controller) *MockISecurityEventRepository {
+	mock := &MockISecurityEventRepository{ctrl: ctrl}
+	mock.recorder = &MockISecurityEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISecurityEventRepository) EXPECT() *MockISecurityEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSecurityEventsBefore mocks base method.
+func (m *MockISecurityEventRepository) DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityEventsBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSecurityEventsBefore indicates an expected call of DeleteSecurityEventsBefore.
+func (mr *MockISecurityEventRepositoryMockRecorder) DeleteSecurityEventsBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityEventsBefore", reflect.TypeOf((*MockISecurityEventRepository)(nil).DeleteSecurityEventsBefore), ctx, before)
+}
+
+// ListSecurityEvents mocks base method.
+func (m *MockISecurityEventRepository) ListSecurityEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityEvents", ctx, filter)
+	ret0, _ := ret[0].([]*domain.SecurityEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityEvents indicates an expected call of ListSecurityEvents.
+func (mr *MockISecurityEventRepositoryMockRecorder) ListSecurityEvents(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityEvents", reflect.TypeOf((*MockISecurityEventRepository)(nil).ListSecurityEvents), ctx, filter)
+}
+
+// SaveSecurityEvent mocks base method.
+func (m *MockISecurityEventRepository) SaveSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSecurityEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSecurityEvent indicates an expected call of SaveSecurityEvent.
+func (mr *MockISecurityEventRepositoryMockRecorder) SaveSecurityEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSecurityEvent", reflect.TypeOf((*MockISecurityEventRepository)(nil).SaveSecurityEvent), ctx, event)
+}
@@ -0,0 +1,17 @@
+package auditService
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// ISecurityEventRepository はセキュリティイベントの監査ログの永続化に関する操作を定義する
+type ISecurityEventRepository interface {
+	SaveSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error
+	// ListSecurityEvents は条件に一致するイベントを新しい順に取得する
+	ListSecurityEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]*domain.SecurityEvent, error)
+	// DeleteSecurityEventsBefore はbefore より前のイベントを削除し、削除した件数を返す
+	DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
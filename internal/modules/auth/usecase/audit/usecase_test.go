@@ -0,0 +1,92 @@
+package auditService
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*AuditService, *mocks.MockISecurityEventRepository) {
+	repo := mocks.NewMockISecurityEventRepository(gomock.NewController(t))
+	service := NewAuditService(repo, 30*24*time.Hour, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.now = func() time.Time { return testNow }
+	return service, repo
+}
+
+func TestAuditService_Record(t *testing.T) {
+	t.Run("takes the client from the context and purges expired events", func(t *testing.T) {
+		service, repo := newTestService(t)
+		ctx := domain.ContextWithClientInfo(context.Background(), domain.ClientInfo{IPAddress: "203.0.113.10", UserAgent: "curl/8.0"})
+
+		repo.EXPECT().SaveSecurityEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event *domain.SecurityEvent) error {
+				assert.NotEmpty(t, event.ID)
+				assert.Equal(t, testNow, event.CreatedAt)
+				assert.Equal(t, "203.0.113.10", event.IPAddress)
+				assert.Equal(t, "curl/8.0", event.UserAgent)
+				return nil
+			}).Times(2)
+		repo.EXPECT().DeleteSecurityEventsBefore(gomock.Any(), testNow.Add(-30*24*time.Hour)).Return(int64(3), nil).Times(1)
+
+		service.Record(ctx, domain.NewSecurityEvent(domain.SecurityEventLoginSucceeded, "user-1", ""))
+		// purges at most once per interval
+		service.Record(ctx, domain.NewSecurityEvent(domain.SecurityEventLoginSucceeded, "user-1", ""))
+	})
+
+	t.Run("storage errors are not propagated", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().SaveSecurityEvent(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+		service.Record(context.Background(), domain.NewSecurityEvent(domain.SecurityEventLoginFailed, "", "unknown email"))
+	})
+}
+
+func TestAuditService_ListEvents(t *testing.T) {
+	t.Run("limits to the retention period and caps the page size", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().ListSecurityEvents(gomock.Any(), domain.SecurityEventFilter{
+			UserID: "user-1",
+			Type:   domain.SecurityEventLoginFailed,
+			Since:  testNow.Add(-30 * 24 * time.Hour),
+			Limit:  MaxListLimit,
+		}).Return([]*domain.SecurityEvent{{ID: "event-1"}}, nil)
+
+		events, err := service.ListUserEvents(context.Background(), "user-1", domain.SecurityEventFilter{
+			UserID: "someone-else",
+			Type:   domain.SecurityEventLoginFailed,
+			Limit:  1000,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		service, _ := newTestService(t)
+
+		_, err := service.ListEvents(context.Background(), domain.SecurityEventFilter{Type: "unknown"})
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("user is required", func(t *testing.T) {
+		service, _ := newTestService(t)
+
+		_, err := service.ListUserEvents(context.Background(), "", domain.SecurityEventFilter{})
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
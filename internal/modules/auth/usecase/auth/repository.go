@@ -2,6 +2,7 @@ package authService
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
 )
@@ -11,4 +12,33 @@ type IAuthRepository interface {
 	Login(ctx context.Context, email, password string) (accessToken string, refreshToken string, err error)
 	RefreshToken(ctx context.Context, refreshToken string) (newAccessToken string, newRefreshToken string, err error)
 	Logout(ctx context.Context, accessToken, refreshToken string) error
+	// VerifyLogin はメールで送った確認コードで不審なログインを完了する
+	// rememberDeviceの場合は端末を記憶し、Cookieに設定する値をdeviceTrustに返す（記憶しない場合は空）
+	VerifyLogin(ctx context.Context, challengeID, code string, rememberDevice bool) (accessToken string, refreshToken string, deviceTrust string, err error)
+}
+
+// ErrLoginVerificationRequired は不審なログインのため確認コードの入力が必要なことを表す
+var ErrLoginVerificationRequired = errors.New("login verification required")
+
+// ErrSSORequired はSSOを強制するドメインのユーザーがパスワードでログインしようとしたことを表す
+var ErrSSORequired = errors.New("sso login required")
+
+// LoginVerificationRequiredError は確認コードの入力が必要な場合にLoginが返すエラー
+// ChallengeIDとメールで送った確認コードをVerifyLoginに渡してログインを完了する
+type LoginVerificationRequiredError struct {
+	ChallengeID string
+}
+
+func (e *LoginVerificationRequiredError) Error() string {
+	return ErrLoginVerificationRequired.Error()
+}
+
+func (e *LoginVerificationRequiredError) Is(target error) bool {
+	return target == ErrLoginVerificationRequired
+}
+
+// RegistrationListener はユーザー登録の完了を他モジュールに通知するインターフェース
+// （メールアドレス宛ての招待の紐付けなどに使用し、失敗しても登録自体は成功とする）
+type RegistrationListener interface {
+	UserRegistered(ctx context.Context, user *domain.User)
 }
@@ -17,11 +17,12 @@ import (
 
 // MockUserRepository はテスト用のユーザーリポジトリモック
 type MockUserRepository struct {
-	CreateUserFunc      func(user *domain.User) error
-	FindUserByEmailFunc func(email string) (*domain.User, error)
-	FindUserByIDFunc    func(id uuid.UUID) (*domain.User, error)
-	FindUsersFunc       func(search string) ([]*domain.User, error)
-	UpdateUserFunc      func(user *domain.User) error
+	CreateUserFunc         func(user *domain.User) error
+	FindUserByEmailFunc    func(email string) (*domain.User, error)
+	FindUserByIDFunc       func(id uuid.UUID) (*domain.User, error)
+	FindUserByUsernameFunc func(username string) (*domain.User, error)
+	FindUsersFunc          func(search string) ([]*domain.User, error)
+	UpdateUserFunc         func(user *domain.User) error
 }
 
 func (m *MockUserRepository) CreateUser(user *domain.User) error {
@@ -45,6 +46,13 @@ func (m *MockUserRepository) FindUserByID(id uuid.UUID) (*domain.User, error) {
 	return nil, nil
 }
 
+func (m *MockUserRepository) FindUserByUsername(username string) (*domain.User, error) {
+	if m.FindUserByUsernameFunc != nil {
+		return m.FindUserByUsernameFunc(username)
+	}
+	return nil, nil
+}
+
 func (m *MockUserRepository) FindUsers(search string) ([]*domain.User, error) {
 	if m.FindUsersFunc != nil {
 		return m.FindUsersFunc(search)
@@ -0,0 +1,315 @@
+package deviceService
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// verificationCodeDigits は確認コードの桁数
+	verificationCodeDigits = 6
+	// trustTokenBytes は端末を記憶するCookieのtokenのバイト数
+	trustTokenBytes = 32
+)
+
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrDeviceNotFound   = errors.New("login device not found")
+)
+
+// LoginCheck はログイン時の判定結果
+type LoginCheck struct {
+	Risk   domain.LoginRisk
+	Device *domain.LoginDevice
+	// trueの場合はログインを完了せず、メールで送った確認コードの入力を求める（Device.IDを確認に使う）
+	VerificationRequired bool
+}
+
+// DeviceService はログイン端末の管理と不審なログインの検知を扱うサービス
+type DeviceService struct {
+	Repository ILoginDeviceRepository
+	// nilの場合は国と移動の判定を行わない
+	GeoIP GeoIPResolver
+	// nilの場合は通知しない（確認コードも送れないため、確認コードの入力も求めない）
+	Notifier LoginAlertNotifier
+	// 端末を記憶するCookieの署名鍵（空の場合は端末を記憶しない）
+	TrustSecret []byte
+	Logger      logger.Logger
+
+	now          func() time.Time
+	generateCode func() (string, error)
+}
+
+// NewDeviceService はDeviceServiceのコンストラクタ
+func NewDeviceService(repo ILoginDeviceRepository, geoIP GeoIPResolver, notifier LoginAlertNotifier, logger logger.Logger) *DeviceService {
+	return &DeviceService{
+		Repository:   repo,
+		GeoIP:        geoIP,
+		Notifier:     notifier,
+		Logger:       logger,
+		now:          time.Now,
+		generateCode: generateVerificationCode,
+	}
+}
+
+// CheckLogin はパスワードを確認したログインを端末の履歴と比べて判定する
+// 不審なログインは設定に従って通知し、確認コードを求める場合は端末を入力待ちにする
+func (s *DeviceService) CheckLogin(ctx context.Context, user *domain.User, client domain.ClientInfo) (*LoginCheck, error) {
+	userID := user.ID.String()
+	now := s.now()
+
+	devices, err := s.Repository.ListLoginDevices(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login devices: %w", err)
+	}
+	settings, err := s.GetAlertSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	location := s.lookup(ctx, client.IPAddress)
+
+	// 記憶した端末のCookieを提示した場合は、User-Agentが変わっていてもその端末とみなす
+	device := s.rememberedDevice(devices, client.DeviceTrustCookie, now)
+	remembered := device != nil
+	fingerprint := domain.DeviceFingerprint(client.UserAgent)
+	if remembered {
+		fingerprint = device.Fingerprint
+	} else {
+		for _, d := range devices {
+			if d.Fingerprint == fingerprint {
+				device = d
+				break
+			}
+		}
+	}
+	risk := domain.AssessLoginRisk(devices, fingerprint, location, now)
+	if device == nil {
+		device = domain.NewLoginDevice(userID, client.UserAgent, now)
+		device.ID = uuid.New().String()
+		// 最初にログインした端末は信頼済みとする
+		device.Trusted = len(devices) == 0
+	}
+
+	check := &LoginCheck{Risk: risk, Device: device}
+	alert := domain.LoginAlert{
+		UserID:    userID,
+		Email:     user.Email,
+		Username:  user.Username,
+		Reasons:   risk.Reasons(),
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Location:  location,
+		At:        now,
+	}
+
+	if risk.IsAnomalous() && settings.RequireVerification && !device.Trusted && !remembered && s.Notifier != nil {
+		code, err := s.generateCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate verification code: %w", err)
+		}
+		device.StartVerification(code, now)
+		if err := s.Repository.SaveLoginDevice(ctx, device); err != nil {
+			return nil, fmt.Errorf("failed to save login device: %w", err)
+		}
+		alert.VerificationCode = code
+		if err := s.Notifier.NotifyLoginAlert(ctx, alert); err != nil {
+			return nil, fmt.Errorf("failed to send login verification code: %w", err)
+		}
+		check.VerificationRequired = true
+		return check, nil
+	}
+
+	device.Seen(client.IPAddress, location, now)
+	if err := s.Repository.SaveLoginDevice(ctx, device); err != nil {
+		return nil, fmt.Errorf("failed to save login device: %w", err)
+	}
+	if risk.IsAnomalous() && settings.AlertsEnabled && s.Notifier != nil {
+		if err := s.Notifier.NotifyLoginAlert(ctx, alert); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to send login alert",
+				logger.Any("userID", userID), logger.Error(err))
+		}
+	}
+	return check, nil
+}
+
+// VerifyLogin は確認コードを検証してログインを完了する
+// 確認を完了したリクエストのIPアドレスと位置を端末の最後のログインとして記録する
+// rememberの場合は端末を一定期間記憶し、Cookieに設定する値を返す（記憶しない場合は空）
+func (s *DeviceService) VerifyLogin(ctx context.Context, deviceID, code string, client domain.ClientInfo, remember bool) (*domain.LoginDevice, string, error) {
+	if deviceID == "" || code == "" {
+		return nil, "", ErrInvalidParameter
+	}
+	device, err := s.Repository.GetLoginDevice(ctx, deviceID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get login device: %w", err)
+	}
+	if device == nil {
+		return nil, "", domain.ErrLoginVerificationInvalid
+	}
+
+	now := s.now()
+	if err := device.Verify(code, now); err != nil {
+		if errors.Is(err, domain.ErrLoginVerificationInvalid) {
+			if saveErr := s.Repository.SaveLoginDevice(ctx, device); saveErr != nil {
+				return nil, "", fmt.Errorf("failed to save login device: %w", saveErr)
+			}
+		}
+		return nil, "", err
+	}
+
+	var cookie string
+	if remember && len(s.TrustSecret) > 0 {
+		token, err := generateTrustToken()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate device trust token: %w", err)
+		}
+		device.Remember(token, now)
+		cookie = domain.SignDeviceTrustCookie(device.ID, token, s.TrustSecret)
+	}
+
+	device.Seen(client.IPAddress, s.lookup(ctx, client.IPAddress), now)
+	if err := s.Repository.SaveLoginDevice(ctx, device); err != nil {
+		return nil, "", fmt.Errorf("failed to save login device: %w", err)
+	}
+	return device, cookie, nil
+}
+
+// ListDevices はユーザーのログイン端末の一覧を取得する
+func (s *DeviceService) ListDevices(ctx context.Context, userID string) ([]*domain.LoginDevice, error) {
+	devices, err := s.Repository.ListLoginDevices(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login devices: %w", err)
+	}
+	return devices, nil
+}
+
+// SetDeviceTrusted はユーザーの端末を信頼済みにする（falseの場合は記憶した端末のCookieも含めて信頼を取り消す）
+func (s *DeviceService) SetDeviceTrusted(ctx context.Context, userID, deviceID string, trusted bool) (*domain.LoginDevice, error) {
+	device, err := s.Repository.GetLoginDevice(ctx, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login device: %w", err)
+	}
+	if device == nil || device.UserID != userID {
+		return nil, ErrDeviceNotFound
+	}
+	if trusted {
+		device.Trusted = true
+	} else {
+		device.Forget()
+	}
+	if err := s.Repository.SaveLoginDevice(ctx, device); err != nil {
+		return nil, fmt.Errorf("failed to save login device: %w", err)
+	}
+	return device, nil
+}
+
+// RemoveDevice はユーザーの端末を削除する（次にその端末からログインした場合は新しい端末として扱う）
+func (s *DeviceService) RemoveDevice(ctx context.Context, userID, deviceID string) error {
+	deleted, err := s.Repository.DeleteLoginDevice(ctx, userID, deviceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete login device: %w", err)
+	}
+	if !deleted {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// GetAlertSettings は不審なログインの通知設定を取得する（保存していない場合は既定値）
+func (s *DeviceService) GetAlertSettings(ctx context.Context, userID string) (*domain.LoginAlertSettings, error) {
+	settings, err := s.Repository.GetLoginAlertSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login alert settings: %w", err)
+	}
+	if settings == nil {
+		settings = domain.DefaultLoginAlertSettings(userID)
+	}
+	return settings, nil
+}
+
+// UpdateAlertSettingsInput は通知設定の更新内容（nilの項目は変更しない）
+type UpdateAlertSettingsInput struct {
+	AlertsEnabled       *bool
+	RequireVerification *bool
+}
+
+// UpdateAlertSettings は不審なログインの通知設定を更新する
+func (s *DeviceService) UpdateAlertSettings(ctx context.Context, userID string, input UpdateAlertSettingsInput) (*domain.LoginAlertSettings, error) {
+	settings, err := s.GetAlertSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.AlertsEnabled != nil {
+		settings.AlertsEnabled = *input.AlertsEnabled
+	}
+	if input.RequireVerification != nil {
+		settings.RequireVerification = *input.RequireVerification
+	}
+	settings.UpdatedAt = s.now()
+
+	if err := s.Repository.SaveLoginAlertSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save login alert settings: %w", err)
+	}
+	return settings, nil
+}
+
+// lookup はIPアドレスの位置を推定する（GeoIPが無効・失敗した場合はnil）
+func (s *DeviceService) lookup(ctx context.Context, ip string) *domain.GeoLocation {
+	if s.GeoIP == nil || ip == "" {
+		return nil
+	}
+	location, err := s.GeoIP.Lookup(ctx, ip)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("GeoIP lookup failed", logger.Any("ip", ip), logger.Error(err))
+		return nil
+	}
+	return location
+}
+
+// rememberedDevice はCookieが示す記憶した端末を返す（署名・token・期限が無効な場合はnil）
+func (s *DeviceService) rememberedDevice(devices []*domain.LoginDevice, cookie string, now time.Time) *domain.LoginDevice {
+	if cookie == "" || len(s.TrustSecret) == 0 {
+		return nil
+	}
+	deviceID, token, ok := domain.ParseDeviceTrustCookie(cookie, s.TrustSecret)
+	if !ok {
+		return nil
+	}
+	for _, d := range devices {
+		if d.ID == deviceID && d.IsRemembered(token, now) {
+			return d
+		}
+	}
+	return nil
+}
+
+// generateTrustToken は端末を記憶するCookieのtokenを生成する
+func generateTrustToken() (string, error) {
+	b := make([]byte, trustTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// generateVerificationCode は数字の確認コードを生成する
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n), nil
+}
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// MockILoginDeviceRepository is a mock of ILoginDeviceRepository interface.
+type MockILoginDeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginDeviceRepositoryMockRecorder
+}
+
+// MockILoginDeviceRepositoryMockRecorder is the mock recorder for MockILoginDeviceRepository.
+type MockILoginDeviceRepositoryMockRecorder struct {
+	mock *MockILoginDeviceRepository
+}
+
+// NewMockILoginDeviceRepository creates a new mock instance.
+func NewMockILoginDeviceRepository(ctrl *gomock.Controller) *MockILoginDeviceRepository {
+	mock := &MockILoginDeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockILoginDeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginDeviceRepository) EXPECT() *MockILoginDeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteLoginDevice mocks base method.
+func (m *MockILoginDeviceRepository) DeleteLoginDevice(ctx context.Context, userID, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginDevice", ctx, userID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLoginDevice indicates an expected call of DeleteLoginDevice.
+func (mr *MockILoginDeviceRepositoryMockRecorder) DeleteLoginDevice(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginDevice", reflect.TypeOf((*MockILoginDeviceRepository)(nil).DeleteLoginDevice), ctx, userID, id)
+}
+
+// GetLoginAlertSettings mocks base method.
+func (m *MockILoginDeviceRepository) GetLoginAlertSettings(ctx context.Context, userID string) (*domain.LoginAlertSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAlertSettings", ctx, userID)
+	ret0, _ := ret[0].(*domain.LoginAlertSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAlertSettings indicates an expected call of GetLoginAlertSettings.
+func (mr *MockILoginDeviceRepositoryMockRecorder) GetLoginAlertSettings(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAlertSettings", reflect.TypeOf((*MockILoginDeviceRepository)(nil).GetLoginAlertSettings), ctx, userID)
+}
+
+// GetLoginDevice mocks base method.
+func (m *MockILoginDeviceRepository) GetLoginDevice(ctx context.Context, id string) (*domain.LoginDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginDevice", ctx, id)
+	ret0, _ := ret[0].(*domain.LoginDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginDevice indicates an expected call of GetLoginDevice.
+func (mr *MockILoginDeviceRepositoryMockRecorder) GetLoginDevice(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginDevice", reflect.TypeOf((*MockILoginDeviceRepository)(nil).GetLoginDevice), ctx, id)
+}
+
+// ListLoginDevices mocks base method.
+func (m *MockILoginDeviceRepository) ListLoginDevices(ctx context.Context, userID string) ([]*domain.LoginDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoginDevices", ctx, userID)
+	ret0, _ := ret[0].([]*domain.LoginDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoginDevices indicates an expected call of ListLoginDevices.
+func (mr *MockILoginDeviceRepositoryMockRecorder) ListLoginDevices(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoginDevices", reflect.TypeOf((*MockILoginDeviceRepository)(nil).ListLoginDevices), ctx, userID)
+}
+
+// SaveLoginAlertSettings mocks base method.
+func (m *MockILoginDeviceRepository) SaveLoginAlertSettings(ctx context.Context, settings *domain.LoginAlertSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLoginAlertSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLoginAlertSettings indicates an expected call of SaveLoginAlertSettings.
+func (mr *MockILoginDeviceRepositoryMockRecorder) SaveLoginAlertSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginAlertSettings", reflect.TypeOf((*MockILoginDeviceRepository)(nil).SaveLoginAlertSettings), ctx, settings)
+}
+
+// SaveLoginDevice mocks base method.
+func (m *MockILoginDeviceRepository) SaveLoginDevice(ctx context.Context, device *domain.LoginDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLoginDevice", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLoginDevice indicates an expected call of SaveLoginDevice.
+func (mr *MockILoginDeviceRepositoryMockRecorder) SaveLoginDevice(ctx, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginDevice", reflect.TypeOf((*MockILoginDeviceRepository)(nil).SaveLoginDevice), ctx, device)
+}
+
+// MockGeoIPResolver is a mock of GeoIPResolver interface.
+type MockGeoIPResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoIPResolverMockRecorder
+}
+
+// MockGeoIPResolverMockRecorder is the mock recorder for MockGeoIPResolver.
+type MockGeoIPResolverMockRecorder struct {
+	mock *MockGeoIPResolver
+}
+
+// NewMockGeoIPResolver creates a new mock instance.
+func NewMockGeoIPResolver(ctrl *gomock.Controller) *MockGeoIPResolver {
+	mock := &MockGeoIPResolver{ctrl: ctrl}
+	mock.recorder = &MockGeoIPResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoIPResolver) EXPECT() *MockGeoIPResolverMockRecorder {
+	return m.recorder
+}
+
+// Lookup mocks base method.
+func (m *MockGeoIPResolver) Lookup(ctx context.Context, ip string) (*domain.GeoLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, ip)
+	ret0, _ := ret[0].(*domain.GeoLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockGeoIPResolverMockRecorder) Lookup(ctx, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockGeoIPResolver)(nil).Lookup), ctx, ip)
+}
+
+// MockLoginAlertNotifier is a mock of LoginAlertNotifier interface.
+type MockLoginAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAlertNotifierMockRecorder
+}
+
+// MockLoginAlertNotifierMockRecorder is the mock recorder for MockLoginAlertNotifier.
+type MockLoginAlertNotifierMockRecorder struct {
+	mock *MockLoginAlertNotifier
+}
+
+// NewMockLoginAlertNotifier creates a new mock instance.
+func NewMockLoginAlertNotifier(ctrl *gomock.Controller) *MockLoginAlertNotifier {
+	mock := &MockLoginAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockLoginAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAlertNotifier) EXPECT() *MockLoginAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyLoginAlert mocks base method.
+func (m *MockLoginAlertNotifier) NotifyLoginAlert(ctx context.Context, alert domain.LoginAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyLoginAlert", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyLoginAlert indicates an expected call of NotifyLoginAlert.
+func (mr *MockLoginAlertNotifierMockRecorder) NotifyLoginAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyLoginAlert", reflect.TypeOf((*MockLoginAlertNotifier)(nil).NotifyLoginAlert), ctx, alert)
+}
@@ -0,0 +1,33 @@
+package deviceService
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// ILoginDeviceRepository はログイン端末と不審なログインの通知設定の永続化に関する操作を定義する
+type ILoginDeviceRepository interface {
+	ListLoginDevices(ctx context.Context, userID string) ([]*domain.LoginDevice, error)
+	// GetLoginDevice は端末を取得する（存在しない場合はnil, nil）
+	GetLoginDevice(ctx context.Context, id string) (*domain.LoginDevice, error)
+	// SaveLoginDevice は端末を作成または更新する
+	SaveLoginDevice(ctx context.Context, device *domain.LoginDevice) error
+	// DeleteLoginDevice はユーザーの端末を削除し、削除したかどうかを返す
+	DeleteLoginDevice(ctx context.Context, userID, id string) (bool, error)
+
+	// GetLoginAlertSettings は通知設定を取得する（保存していない場合はnil, nil）
+	GetLoginAlertSettings(ctx context.Context, userID string) (*domain.LoginAlertSettings, error)
+	SaveLoginAlertSettings(ctx context.Context, settings *domain.LoginAlertSettings) error
+}
+
+// GeoIPResolver はIPアドレスから位置を推定するインターフェース
+type GeoIPResolver interface {
+	// Lookup は位置を返す（プライベートアドレスなど位置が分からない場合はnil, nil）
+	Lookup(ctx context.Context, ip string) (*domain.GeoLocation, error)
+}
+
+// LoginAlertNotifier は不審なログインをユーザーに知らせるインターフェース
+type LoginAlertNotifier interface {
+	NotifyLoginAlert(ctx context.Context, alert domain.LoginAlert) error
+}
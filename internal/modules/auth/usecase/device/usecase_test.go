@@ -0,0 +1,345 @@
+package deviceService
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/device/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var (
+	testNow    = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	testUser   = &domain.User{ID: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), Email: "user@example.com", Username: "user"}
+	tokyo      = &domain.GeoLocation{Country: "JP", Latitude: 35.68, Longitude: 139.76}
+	newYork    = &domain.GeoLocation{Country: "US", Latitude: 40.71, Longitude: -74.01}
+	laptop     = domain.ClientInfo{IPAddress: "203.0.113.10", UserAgent: "laptop"}
+	unknownUA  = domain.ClientInfo{IPAddress: "198.51.100.20", UserAgent: "unknown"}
+	testLogger = *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+)
+
+type testDeps struct {
+	repo     *mocks.MockILoginDeviceRepository
+	geoIP    *mocks.MockGeoIPResolver
+	notifier *mocks.MockLoginAlertNotifier
+}
+
+func newTestService(t *testing.T) (*DeviceService, testDeps) {
+	ctrl := gomock.NewController(t)
+	deps := testDeps{
+		repo:     mocks.NewMockILoginDeviceRepository(ctrl),
+		geoIP:    mocks.NewMockGeoIPResolver(ctrl),
+		notifier: mocks.NewMockLoginAlertNotifier(ctrl),
+	}
+	service := NewDeviceService(deps.repo, deps.geoIP, deps.notifier, testLogger)
+	service.now = func() time.Time { return testNow }
+	service.generateCode = func() (string, error) { return "123456", nil }
+	service.TrustSecret = []byte("secret")
+	return service, deps
+}
+
+func knownLaptop() *domain.LoginDevice {
+	device := domain.NewLoginDevice(testUser.ID.String(), laptop.UserAgent, testNow.Add(-48*time.Hour))
+	device.ID = "device-1"
+	device.Trusted = true
+	device.Seen(laptop.IPAddress, tokyo, testNow.Add(-2*time.Hour))
+	return device
+}
+
+func TestDeviceService_CheckLogin(t *testing.T) {
+	t.Run("first login registers a trusted device without alerting", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), testUser.ID.String()).Return(nil, nil)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), testUser.ID.String()).Return(nil, nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), laptop.IPAddress).Return(tokyo, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, device *domain.LoginDevice) error {
+				assert.NotEmpty(t, device.ID)
+				assert.True(t, device.Trusted)
+				assert.Equal(t, testNow, device.LastSeenAt)
+				assert.Equal(t, tokyo, device.LastLocation)
+				return nil
+			})
+
+		check, err := service.CheckLogin(context.Background(), testUser, laptop)
+
+		require.NoError(t, err)
+		assert.False(t, check.Risk.IsAnomalous())
+		assert.False(t, check.VerificationRequired)
+	})
+
+	t.Run("new device from another country alerts the user", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), gomock.Any()).Return([]*domain.LoginDevice{knownLaptop()}, nil)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), unknownUA.IPAddress).Return(newYork, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, device *domain.LoginDevice) error {
+				assert.False(t, device.Trusted)
+				return nil
+			})
+		deps.notifier.EXPECT().NotifyLoginAlert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, alert domain.LoginAlert) error {
+				assert.Equal(t, "user@example.com", alert.Email)
+				assert.Equal(t, []string{domain.LoginRiskNewDevice, domain.LoginRiskNewCountry, domain.LoginRiskImpossibleTravel}, alert.Reasons)
+				assert.Empty(t, alert.VerificationCode)
+				return nil
+			})
+
+		check, err := service.CheckLogin(context.Background(), testUser, unknownUA)
+
+		require.NoError(t, err)
+		assert.True(t, check.Risk.IsAnomalous())
+		assert.False(t, check.VerificationRequired)
+	})
+
+	t.Run("alerts can be turned off and notifier errors do not block login", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), gomock.Any()).Return([]*domain.LoginDevice{knownLaptop()}, nil).Times(2)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).Return(&domain.LoginAlertSettings{AlertsEnabled: false}, nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout")).Times(2)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := service.CheckLogin(context.Background(), testUser, unknownUA)
+		require.NoError(t, err)
+
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.notifier.EXPECT().NotifyLoginAlert(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+
+		_, err = service.CheckLogin(context.Background(), testUser, unknownUA)
+		require.NoError(t, err)
+	})
+
+	t.Run("requires verification for an untrusted device when enabled", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), gomock.Any()).Return([]*domain.LoginDevice{knownLaptop()}, nil)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).
+			Return(&domain.LoginAlertSettings{AlertsEnabled: false, RequireVerification: true}, nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, device *domain.LoginDevice) error {
+				assert.False(t, device.IsKnown())
+				assert.NotEmpty(t, device.VerificationCodeHash)
+				assert.NotEqual(t, "123456", device.VerificationCodeHash)
+				return nil
+			})
+		deps.notifier.EXPECT().NotifyLoginAlert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, alert domain.LoginAlert) error {
+				assert.Equal(t, "123456", alert.VerificationCode)
+				return nil
+			})
+
+		check, err := service.CheckLogin(context.Background(), testUser, unknownUA)
+
+		require.NoError(t, err)
+		assert.True(t, check.VerificationRequired)
+		assert.NotEmpty(t, check.Device.ID)
+	})
+
+	t.Run("a remembered device is recognised by its cookie", func(t *testing.T) {
+		service, deps := newTestService(t)
+		phone := domain.NewLoginDevice(testUser.ID.String(), "phone", testNow.Add(-48*time.Hour))
+		phone.ID = "device-2"
+		phone.Seen(unknownUA.IPAddress, nil, testNow.Add(-24*time.Hour))
+		phone.Remember("token", testNow.Add(-24*time.Hour))
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), gomock.Any()).Return([]*domain.LoginDevice{knownLaptop(), phone}, nil)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).
+			Return(&domain.LoginAlertSettings{AlertsEnabled: true, RequireVerification: true}, nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), phone).Return(nil)
+
+		// the browser was updated, so the User-Agent no longer matches
+		client := domain.ClientInfo{
+			IPAddress:         unknownUA.IPAddress,
+			UserAgent:         "phone v2",
+			DeviceTrustCookie: domain.SignDeviceTrustCookie("device-2", "token", service.TrustSecret),
+		}
+		check, err := service.CheckLogin(context.Background(), testUser, client)
+
+		require.NoError(t, err)
+		assert.False(t, check.Risk.IsAnomalous())
+		assert.False(t, check.VerificationRequired)
+		assert.Equal(t, "device-2", check.Device.ID)
+	})
+
+	t.Run("forged or expired cookies are ignored", func(t *testing.T) {
+		service, deps := newTestService(t)
+		phone := domain.NewLoginDevice(testUser.ID.String(), "phone", testNow.Add(-60*24*time.Hour))
+		phone.ID = "device-2"
+		phone.Seen(unknownUA.IPAddress, nil, testNow.Add(-40*24*time.Hour))
+		phone.Remember("token", testNow.Add(-40*24*time.Hour))
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), gomock.Any()).Return([]*domain.LoginDevice{knownLaptop(), phone}, nil).Times(2)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).
+			Return(&domain.LoginAlertSettings{RequireVerification: true}, nil).Times(2)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		deps.notifier.EXPECT().NotifyLoginAlert(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		for _, cookie := range []string{
+			domain.SignDeviceTrustCookie("device-2", "token", service.TrustSecret),
+			domain.SignDeviceTrustCookie("device-2", "token", []byte("forged")),
+		} {
+			client := domain.ClientInfo{IPAddress: unknownUA.IPAddress, UserAgent: "phone v2", DeviceTrustCookie: cookie}
+			check, err := service.CheckLogin(context.Background(), testUser, client)
+
+			require.NoError(t, err)
+			assert.True(t, check.VerificationRequired)
+		}
+	})
+
+	t.Run("trusted devices are not asked for verification", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().ListLoginDevices(gomock.Any(), gomock.Any()).Return([]*domain.LoginDevice{knownLaptop()}, nil)
+		deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), gomock.Any()).
+			Return(&domain.LoginAlertSettings{AlertsEnabled: true, RequireVerification: true}, nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(newYork, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).Return(nil)
+		deps.notifier.EXPECT().NotifyLoginAlert(gomock.Any(), gomock.Any()).Return(nil)
+
+		check, err := service.CheckLogin(context.Background(), testUser, laptop)
+
+		require.NoError(t, err)
+		assert.True(t, check.Risk.ImpossibleTravel)
+		assert.False(t, check.VerificationRequired)
+	})
+}
+
+func TestDeviceService_VerifyLogin(t *testing.T) {
+	pending := func() *domain.LoginDevice {
+		device := domain.NewLoginDevice(testUser.ID.String(), unknownUA.UserAgent, testNow)
+		device.ID = "device-2"
+		device.StartVerification("123456", testNow)
+		return device
+	}
+
+	t.Run("records the login without trusting the device", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "device-2").Return(pending(), nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), unknownUA.IPAddress).Return(newYork, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).Return(nil)
+
+		device, cookie, err := service.VerifyLogin(context.Background(), "device-2", "123456", unknownUA, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, cookie)
+		assert.False(t, device.Trusted)
+		assert.Nil(t, device.TrustedUntil)
+		assert.True(t, device.IsKnown())
+		assert.Empty(t, device.VerificationCodeHash)
+		assert.Equal(t, newYork, device.LastLocation)
+	})
+
+	t.Run("remembers the device for 30 days", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "device-2").Return(pending(), nil)
+		deps.geoIP.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).Return(nil)
+
+		device, cookie, err := service.VerifyLogin(context.Background(), "device-2", "123456", unknownUA, true)
+
+		require.NoError(t, err)
+		require.NotNil(t, device.TrustedUntil)
+		assert.Equal(t, testNow.Add(domain.DeviceTrustDuration), *device.TrustedUntil)
+		deviceID, token, ok := domain.ParseDeviceTrustCookie(cookie, service.TrustSecret)
+		require.True(t, ok)
+		assert.Equal(t, "device-2", deviceID)
+		assert.True(t, device.IsRemembered(token, testNow))
+	})
+
+	t.Run("wrong code counts the attempt", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "device-2").Return(pending(), nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, device *domain.LoginDevice) error {
+				assert.Equal(t, 1, device.VerificationAttempts)
+				assert.False(t, device.Trusted)
+				return nil
+			})
+
+		_, _, err := service.VerifyLogin(context.Background(), "device-2", "000000", unknownUA, false)
+
+		assert.ErrorIs(t, err, domain.ErrLoginVerificationInvalid)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		service, deps := newTestService(t)
+		service.now = func() time.Time { return testNow.Add(domain.LoginVerificationCodeTTL + time.Second) }
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "device-2").Return(pending(), nil)
+
+		_, _, err := service.VerifyLogin(context.Background(), "device-2", "123456", unknownUA, false)
+
+		assert.ErrorIs(t, err, domain.ErrLoginVerificationExpired)
+	})
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "missing").Return(nil, nil)
+
+		_, _, err := service.VerifyLogin(context.Background(), "missing", "123456", unknownUA, false)
+
+		assert.ErrorIs(t, err, domain.ErrLoginVerificationInvalid)
+	})
+}
+
+func TestDeviceService_Devices(t *testing.T) {
+	t.Run("revoking trust forgets the remembered device", func(t *testing.T) {
+		service, deps := newTestService(t)
+		device := knownLaptop()
+		device.Remember("token", testNow)
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "device-1").Return(device, nil)
+		deps.repo.EXPECT().SaveLoginDevice(gomock.Any(), device).Return(nil)
+
+		updated, err := service.SetDeviceTrusted(context.Background(), testUser.ID.String(), "device-1", false)
+
+		require.NoError(t, err)
+		assert.False(t, updated.Trusted)
+		assert.False(t, updated.IsRemembered("token", testNow))
+	})
+
+	t.Run("cannot trust another user's device", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().GetLoginDevice(gomock.Any(), "device-1").Return(knownLaptop(), nil)
+
+		_, err := service.SetDeviceTrusted(context.Background(), "someone-else", "device-1", true)
+
+		assert.ErrorIs(t, err, ErrDeviceNotFound)
+	})
+
+	t.Run("remove unknown device", func(t *testing.T) {
+		service, deps := newTestService(t)
+		deps.repo.EXPECT().DeleteLoginDevice(gomock.Any(), "user-1", "device-1").Return(false, nil)
+
+		assert.ErrorIs(t, service.RemoveDevice(context.Background(), "user-1", "device-1"), ErrDeviceNotFound)
+	})
+}
+
+func TestDeviceService_UpdateAlertSettings(t *testing.T) {
+	service, deps := newTestService(t)
+	deps.repo.EXPECT().GetLoginAlertSettings(gomock.Any(), "user-1").Return(nil, nil)
+	deps.repo.EXPECT().SaveLoginAlertSettings(gomock.Any(), gomock.Any()).Return(nil)
+
+	requireVerification := true
+	settings, err := service.UpdateAlertSettings(context.Background(), "user-1", UpdateAlertSettingsInput{RequireVerification: &requireVerification})
+
+	require.NoError(t, err)
+	assert.True(t, settings.AlertsEnabled)
+	assert.True(t, settings.RequireVerification)
+	assert.Equal(t, testNow, settings.UpdatedAt)
+}
+
+func TestGenerateVerificationCode(t *testing.T) {
+	code, err := generateVerificationCode()
+
+	require.NoError(t, err)
+	assert.Len(t, code, verificationCodeDigits)
+}
@@ -79,6 +79,21 @@ func (mr *MockIUserRepositoryMockRecorder) FindUserByID(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockIUserRepository)(nil).FindUserByID), id)
 }
 
+// FindUserByUsername mocks base method.
+func (m *MockIUserRepository) FindUserByUsername(username string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByUsername", username)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByUsername indicates an expected call of FindUserByUsername.
+func (mr *MockIUserRepositoryMockRecorder) FindUserByUsername(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByUsername", reflect.TypeOf((*MockIUserRepository)(nil).FindUserByUsername), username)
+}
+
 // FindUsers mocks base method.
 func (m *MockIUserRepository) FindUsers(search string) ([]*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,464 @@
+package ssoService
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
+	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// JITで作成するユーザー名の長さ（登録時の検証と同じ）
+	minUsernameLength = 3
+	maxUsernameLength = 30
+	// maxUsernameAttempts は重複したユーザー名に番号を付けて試す回数
+	maxUsernameAttempts = 20
+)
+
+var (
+	ErrSSOConnectionNotFound = errors.New("sso connection not found")
+	ErrSSODomainInUse        = errors.New("email domain is already used by another sso connection")
+	ErrSSOClientSecretNeeded = errors.New("client secret is required")
+	ErrSSONotConfigured      = errors.New("sso is not configured")
+)
+
+// usernameInvalidChars はJITで作成するユーザー名に使えない文字
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// SSOService は企業テナントのOIDCによるシングルサインオンを扱うサービス
+// メールアドレスのドメインで接続設定を選び、IdPで認証したユーザーに通常のトークンを発行する
+type SSOService struct {
+	Repository ISSORepository
+	Provider   IdentityProvider
+	Users      UserDirectory
+	Sessions   SessionIssuer
+	// IdPに登録したコールバックURL（空の場合はSSOを利用できない）
+	RedirectURL string
+	// ログイン状態のCookieの署名鍵
+	StateSecret []byte
+	// nilの場合はJITで作成したユーザーを通知しない
+	RegistrationListener authService.RegistrationListener
+	// nilの場合は監査ログを記録しない
+	SecurityEvents auditService.SecurityEventRecorder
+	Logger         logger.Logger
+
+	now          func() time.Time
+	randomString func(bytes int) (string, error)
+}
+
+// NewSSOService はSSOServiceのコンストラクタ
+func NewSSOService(repo ISSORepository, provider IdentityProvider, users UserDirectory, sessions SessionIssuer, redirectURL string, stateSecret []byte, logger logger.Logger) *SSOService {
+	return &SSOService{
+		Repository:   repo,
+		Provider:     provider,
+		Users:        users,
+		Sessions:     sessions,
+		RedirectURL:  redirectURL,
+		StateSecret:  stateSecret,
+		Logger:       logger,
+		now:          time.Now,
+		randomString: randomString,
+	}
+}
+
+// Enabled はSSOでのログインを利用できるか
+func (s *SSOService) Enabled() bool {
+	return s.RedirectURL != "" && len(s.StateSecret) > 0
+}
+
+// === 接続設定（管理者） ===
+
+// CreateConnection は接続設定を作成する
+func (s *SSOService) CreateConnection(ctx context.Context, conn *domain.SSOConnection) (*domain.SSOConnection, error) {
+	if err := conn.Normalize(); err != nil {
+		return nil, err
+	}
+	if conn.ClientSecret == "" {
+		return nil, ErrSSOClientSecretNeeded
+	}
+	if err := s.checkDomains(ctx, "", conn.Domains); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	conn.ID = uuid.New().String()
+	conn.CreatedAt = now
+	conn.UpdatedAt = now
+	if err := s.Repository.SaveSSOConnection(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save sso connection: %w", err)
+	}
+	return conn, nil
+}
+
+// UpdateConnection は接続設定を更新する（client_secretを省略した場合は変更しない）
+func (s *SSOService) UpdateConnection(ctx context.Context, id string, input *domain.SSOConnection) (*domain.SSOConnection, error) {
+	conn, err := s.getConnection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := input.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := s.checkDomains(ctx, id, input.Domains); err != nil {
+		return nil, err
+	}
+
+	conn.Name = input.Name
+	conn.Domains = input.Domains
+	conn.Issuer = input.Issuer
+	conn.ClientID = input.ClientID
+	if input.ClientSecret != "" {
+		conn.ClientSecret = input.ClientSecret
+	}
+	conn.Enforced = input.Enforced
+	conn.JITProvisioning = input.JITProvisioning
+	conn.RoleClaim = input.RoleClaim
+	conn.AdminValues = input.AdminValues
+	conn.UpdatedAt = s.now()
+	if err := s.Repository.SaveSSOConnection(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save sso connection: %w", err)
+	}
+	return conn, nil
+}
+
+// ListConnections は接続設定の一覧を取得する
+func (s *SSOService) ListConnections(ctx context.Context) ([]*domain.SSOConnection, error) {
+	conns, err := s.Repository.ListSSOConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sso connections: %w", err)
+	}
+	return conns, nil
+}
+
+// DeleteConnection は接続設定を削除する（対象ドメインのユーザーは再びパスワードでログインできる）
+func (s *SSOService) DeleteConnection(ctx context.Context, id string) error {
+	deleted, err := s.Repository.DeleteSSOConnection(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete sso connection: %w", err)
+	}
+	if !deleted {
+		return ErrSSOConnectionNotFound
+	}
+	return nil
+}
+
+// FindConnectionForEmail はメールアドレスのドメインの接続設定を取得する（ない場合はnil, nil）
+func (s *SSOService) FindConnectionForEmail(ctx context.Context, email string) (*domain.SSOConnection, error) {
+	if domain.EmailDomain(email) == "" {
+		return nil, nil
+	}
+	conns, err := s.ListConnections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, conn := range conns {
+		if conn.HasDomain(email) {
+			return conn, nil
+		}
+	}
+	return nil, nil
+}
+
+// RequiresSSO はユーザーがパスワードでログインできずSSOを使う必要があるか
+// 管理者はIdPの障害時にも設定を変更できるよう、パスワードでのログインを許可する
+func (s *SSOService) RequiresSSO(ctx context.Context, user *domain.User) (bool, error) {
+	if !s.Enabled() || user.IsAdmin() {
+		return false, nil
+	}
+	conn, err := s.FindConnectionForEmail(ctx, user.Email)
+	if err != nil {
+		return false, err
+	}
+	return conn != nil && conn.Enforced, nil
+}
+
+// === ログイン ===
+
+// BeginLogin はIdPでの認証を開始する
+// 接続設定はconnectionIDまたはメールアドレスのドメインで選び、IdPのURLとログイン状態のCookieの値を返す
+func (s *SSOService) BeginLogin(ctx context.Context, email, connectionID, returnTo string) (authURL string, stateCookie string, err error) {
+	if !s.Enabled() {
+		return "", "", ErrSSONotConfigured
+	}
+
+	var conn *domain.SSOConnection
+	if connectionID != "" {
+		conn, err = s.getConnection(ctx, connectionID)
+	} else {
+		conn, err = s.FindConnectionForEmail(ctx, email)
+		if err == nil && conn == nil {
+			err = ErrSSOConnectionNotFound
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	state := &domain.SSOState{
+		ConnectionID: conn.ID,
+		ReturnTo:     safeReturnTo(returnTo),
+		ExpiresAt:    s.now().Add(domain.SSOStateDuration),
+	}
+	if state.State, err = s.randomString(24); err != nil {
+		return "", "", err
+	}
+	if state.Nonce, err = s.randomString(24); err != nil {
+		return "", "", err
+	}
+	if state.CodeVerifier, err = s.randomString(32); err != nil {
+		return "", "", err
+	}
+
+	authURL, err = s.Provider.AuthCodeURL(ctx, conn, s.RedirectURL, state.State, state.Nonce, codeChallenge(state.CodeVerifier))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build authorization url: %w", err)
+	}
+	stateCookie, err = domain.SignSSOState(state, s.StateSecret)
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, stateCookie, nil
+}
+
+// LoginResult はSSOでのログインの結果
+type LoginResult struct {
+	User         *domain.User
+	AccessToken  string
+	RefreshToken string
+	// ログイン後に表示するパス（BeginLoginで指定した場合のみ）
+	ReturnTo string
+}
+
+// CompleteLogin はIdPからのコールバックを検証してログインを完了する
+// IdPのユーザーは紐付け済みのユーザー、同じメールアドレスのユーザー、JITで作成したユーザーの順に対応付ける
+func (s *SSOService) CompleteLogin(ctx context.Context, stateCookie, state, code string) (*LoginResult, error) {
+	if !s.Enabled() {
+		return nil, ErrSSONotConfigured
+	}
+	saved, err := domain.ParseSSOState(stateCookie, s.StateSecret, s.now())
+	if err != nil || state == "" || saved.State != state || code == "" {
+		return nil, domain.ErrSSOStateInvalid
+	}
+	conn, err := s.getConnection(ctx, saved.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.Provider.Exchange(ctx, conn, s.RedirectURL, code, saved.CodeVerifier)
+	if err != nil {
+		s.recordFailure(ctx, "", "token exchange failed")
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	if identity.Nonce != saved.Nonce {
+		s.recordFailure(ctx, "", "nonce mismatch")
+		return nil, domain.ErrSSOStateInvalid
+	}
+
+	user, err := s.resolveUser(ctx, conn, identity)
+	if err != nil {
+		userID := ""
+		if user != nil {
+			userID = user.ID.String()
+		}
+		s.recordFailure(ctx, userID, err.Error())
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := s.Sessions.IssueSession(ctx, user, domain.SSOSecurityDetail)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken, ReturnTo: saved.ReturnTo}, nil
+}
+
+// resolveUser はIdPのユーザーに対応するユーザーを取得または作成し、役割を同期する
+func (s *SSOService) resolveUser(ctx context.Context, conn *domain.SSOConnection, identity *domain.SSOIdentity) (*domain.User, error) {
+	user, err := s.linkedUser(ctx, conn, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		if !conn.HasDomain(identity.Email) {
+			return nil, domain.ErrSSODomainMismatch
+		}
+		if !identity.EmailVerified {
+			return nil, domain.ErrSSOEmailNotVerified
+		}
+		if user, err = s.Users.FindUserByEmail(identity.Email); err != nil {
+			return nil, err
+		}
+		if user == nil {
+			if !conn.JITProvisioning {
+				return nil, domain.ErrSSOUserNotFound
+			}
+			if user, err = s.provisionUser(ctx, conn, identity); err != nil {
+				return nil, err
+			}
+		}
+		link := &domain.SSOIdentityLink{ConnectionID: conn.ID, Subject: identity.Subject, UserID: user.ID.String(), CreatedAt: s.now()}
+		if err := s.Repository.SaveSSOIdentity(ctx, link); err != nil {
+			return nil, fmt.Errorf("failed to save sso identity: %w", err)
+		}
+	}
+
+	if !user.IsActive() {
+		return user, domain.ErrSSOAccountInactive
+	}
+	if role := conn.MapRole(identity.Claims); role != "" && role != user.Role {
+		if user, err = s.Users.SetRole(ctx, user.ID, role); err != nil {
+			return nil, fmt.Errorf("failed to update role: %w", err)
+		}
+		s.Logger.Info("SSO role synchronized", logger.Any("userID", user.ID), logger.Any("role", role))
+	}
+	return user, nil
+}
+
+// linkedUser は紐付け済みのユーザーを取得する（紐付けがない場合はnil, nil）
+func (s *SSOService) linkedUser(ctx context.Context, conn *domain.SSOConnection, identity *domain.SSOIdentity) (*domain.User, error) {
+	link, err := s.Repository.FindSSOIdentity(ctx, conn.ID, identity.Subject)
+	if err != nil || link == nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(link.UserID)
+	if err != nil {
+		return nil, nil
+	}
+	return s.Users.FindUserByID(userID)
+}
+
+// provisionUser はJITでユーザーを作成する（パスワードはランダムで、SSOでのみログインする）
+func (s *SSOService) provisionUser(ctx context.Context, conn *domain.SSOConnection, identity *domain.SSOIdentity) (*domain.User, error) {
+	username, err := s.availableUsername(identity)
+	if err != nil {
+		return nil, err
+	}
+	password, err := s.randomString(24)
+	if err != nil {
+		return nil, err
+	}
+
+	role := conn.MapRole(identity.Claims)
+	if role == "" {
+		role = domain.RoleUser
+	}
+	now := s.now()
+	user, err := s.Users.CreateUser(&domain.User{
+		ID:            uuid.New(),
+		Email:         identity.Email,
+		Username:      username,
+		Password:      password,
+		Role:          role,
+		EmailVerified: true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+	if s.RegistrationListener != nil {
+		s.RegistrationListener.UserRegistered(ctx, user)
+	}
+	s.Logger.Info("SSO user provisioned", logger.Any("userID", user.ID), logger.Any("connectionID", conn.ID))
+	return user, nil
+}
+
+// availableUsername はpreferred_usernameまたはメールアドレスから使われていないユーザー名を作る
+func (s *SSOService) availableUsername(identity *domain.SSOIdentity) (string, error) {
+	base := identity.PreferredUsername
+	if base == "" || strings.Contains(base, "@") {
+		base = identity.Email[:strings.LastIndex(identity.Email, "@")]
+	}
+	base = usernameInvalidChars.ReplaceAllString(base, "")
+	if len(base) > maxUsernameLength-3 {
+		base = base[:maxUsernameLength-3]
+	}
+	for len(base) < minUsernameLength {
+		base += "_"
+	}
+
+	for i := 1; i <= maxUsernameAttempts; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = base + strconv.Itoa(i)
+		}
+		existing, err := s.Users.FindUserByUsername(candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no available username for %s", base)
+}
+
+func (s *SSOService) getConnection(ctx context.Context, id string) (*domain.SSOConnection, error) {
+	conn, err := s.Repository.GetSSOConnection(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sso connection: %w", err)
+	}
+	if conn == nil {
+		return nil, ErrSSOConnectionNotFound
+	}
+	return conn, nil
+}
+
+// checkDomains はドメインが他の接続設定（exceptID以外）に使われていないか確認する
+func (s *SSOService) checkDomains(ctx context.Context, exceptID string, domains []string) error {
+	conns, err := s.ListConnections(ctx)
+	if err != nil {
+		return err
+	}
+	for _, conn := range conns {
+		if conn.ID == exceptID {
+			continue
+		}
+		for _, d := range domains {
+			if conn.HasDomain("@" + d) {
+				return ErrSSODomainInUse
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SSOService) recordFailure(ctx context.Context, userID, detail string) {
+	if s.SecurityEvents != nil {
+		s.SecurityEvents.Record(ctx, domain.NewSecurityEvent(domain.SecurityEventLoginFailed, userID, domain.SSOSecurityDetail+": "+detail))
+	}
+}
+
+// safeReturnTo はオープンリダイレクトを防ぐため、同じオリジンのパスのみ受け付ける
+func safeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") || strings.HasPrefix(returnTo, "/\\") {
+		return ""
+	}
+	return returnTo
+}
+
+// codeChallenge はPKCEのcode_verifierからcode_challenge（S256）を作る
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(bytes int) (string, error) {
+	b := make([]byte, bytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,315 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// MockISSORepository is a mock of ISSORepository interface.
+type MockISSORepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISSORepositoryMockRecorder
+}
+
+// MockISSORepositoryMockRecorder is the mock recorder for MockISSORepository.
+type MockISSORepositoryMockRecorder struct {
+	mock *MockISSORepository
+}
+
+// NewMockISSORepository creates a new mock instance.
+func NewMockISSORepository(ctrl *gomock.Controller) *MockISSORepository {
+	mock := &MockISSORepository{ctrl: ctrl}
+	mock.recorder = &MockISSORepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISSORepository) EXPECT() *MockISSORepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSSOConnection mocks base method.
+func (m *MockISSORepository) DeleteSSOConnection(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSSOConnection", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSSOConnection indicates an expected call of DeleteSSOConnection.
+func (mr *MockISSORepositoryMockRecorder) DeleteSSOConnection(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSSOConnection", reflect.TypeOf((*MockISSORepository)(nil).DeleteSSOConnection), ctx, id)
+}
+
+// FindSSOIdentity mocks base method.
+func (m *MockISSORepository) FindSSOIdentity(ctx context.Context, connectionID, subject string) (*domain.SSOIdentityLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSSOIdentity", ctx, connectionID, subject)
+	ret0, _ := ret[0].(*domain.SSOIdentityLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSSOIdentity indicates an expected call of FindSSOIdentity.
+func (mr *MockISSORepositoryMockRecorder) FindSSOIdentity(ctx, connectionID, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSSOIdentity", reflect.TypeOf((*MockISSORepository)(nil).FindSSOIdentity), ctx, connectionID, subject)
+}
+
+// GetSSOConnection mocks base method.
+func (m *MockISSORepository) GetSSOConnection(ctx context.Context, id string) (*domain.SSOConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSSOConnection", ctx, id)
+	ret0, _ := ret[0].(*domain.SSOConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSSOConnection indicates an expected call of GetSSOConnection.
+func (mr *MockISSORepositoryMockRecorder) GetSSOConnection(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSOConnection", reflect.TypeOf((*MockISSORepository)(nil).GetSSOConnection), ctx, id)
+}
+
+// ListSSOConnections mocks base method.
+func (m *MockISSORepository) ListSSOConnections(ctx context.Context) ([]*domain.SSOConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSSOConnections", ctx)
+	ret0, _ := ret[0].([]*domain.SSOConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSSOConnections indicates an expected call of ListSSOConnections.
+func (mr *MockISSORepositoryMockRecorder) ListSSOConnections(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSSOConnections", reflect.TypeOf((*MockISSORepository)(nil).ListSSOConnections), ctx)
+}
+
+// SaveSSOConnection mocks base method.
+func (m *MockISSORepository) SaveSSOConnection(ctx context.Context, conn *domain.SSOConnection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSSOConnection", ctx, conn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSSOConnection indicates an expected call of SaveSSOConnection.
+func (mr *MockISSORepositoryMockRecorder) SaveSSOConnection(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSSOConnection", reflect.TypeOf((*MockISSORepository)(nil).SaveSSOConnection), ctx, conn)
+}
+
+// SaveSSOIdentity mocks base method.
+func (m *MockISSORepository) SaveSSOIdentity(ctx context.Context, link *domain.SSOIdentityLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSSOIdentity", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSSOIdentity indicates an expected call of SaveSSOIdentity.
+func (mr *MockISSORepositoryMockRecorder) SaveSSOIdentity(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSSOIdentity", reflect.TypeOf((*MockISSORepository)(nil).SaveSSOIdentity), ctx, link)
+}
+
+// MockIdentityProvider is a mock of IdentityProvider interface.
+type MockIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityProviderMockRecorder
+}
+
+// MockIdentityProviderMockRecorder is the mock recorder for MockIdentityProvider.
+type MockIdentityProviderMockRecorder struct {
+	mock *MockIdentityProvider
+}
+
+// NewMockIdentityProvider creates a new mock instance.
+func NewMockIdentityProvider(ctrl *gomock.Controller) *MockIdentityProvider {
+	mock := &MockIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityProvider) EXPECT() *MockIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockIdentityProvider) AuthCodeURL(ctx context.Context, conn *domain.SSOConnection, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, conn, redirectURI, state, nonce, codeChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockIdentityProviderMockRecorder) AuthCodeURL(ctx, conn, redirectURI, state, nonce, codeChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockIdentityProvider)(nil).AuthCodeURL), ctx, conn, redirectURI, state, nonce, codeChallenge)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityProvider) Exchange(ctx context.Context, conn *domain.SSOConnection, redirectURI, code, codeVerifier string) (*domain.SSOIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, conn, redirectURI, code, codeVerifier)
+	ret0, _ := ret[0].(*domain.SSOIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityProviderMockRecorder) Exchange(ctx, conn, redirectURI, code, codeVerifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityProvider)(nil).Exchange), ctx, conn, redirectURI, code, codeVerifier)
+}
+
+// MockUserDirectory is a mock of UserDirectory interface.
+type MockUserDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDirectoryMockRecorder
+}
+
+// MockUserDirectoryMockRecorder is the mock recorder for MockUserDirectory.
+type MockUserDirectoryMockRecorder struct {
+	mock *MockUserDirectory
+}
+
+// NewMockUserDirectory creates a new mock instance.
+func NewMockUserDirectory(ctrl *gomock.Controller) *MockUserDirectory {
+	mock := &MockUserDirectory{ctrl: ctrl}
+	mock.recorder = &MockUserDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDirectory) EXPECT() *MockUserDirectoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserDirectory) CreateUser(user *domain.User) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserDirectoryMockRecorder) CreateUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserDirectory)(nil).CreateUser), user)
+}
+
+// FindUserByEmail mocks base method.
+func (m *MockUserDirectory) FindUserByEmail(email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByEmail", email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByEmail indicates an expected call of FindUserByEmail.
+func (mr *MockUserDirectoryMockRecorder) FindUserByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByEmail", reflect.TypeOf((*MockUserDirectory)(nil).FindUserByEmail), email)
+}
+
+// FindUserByID mocks base method.
+func (m *MockUserDirectory) FindUserByID(id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByID", id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByID indicates an expected call of FindUserByID.
+func (mr *MockUserDirectoryMockRecorder) FindUserByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockUserDirectory)(nil).FindUserByID), id)
+}
+
+// FindUserByUsername mocks base method.
+func (m *MockUserDirectory) FindUserByUsername(username string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByUsername", username)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByUsername indicates an expected call of FindUserByUsername.
+func (mr *MockUserDirectoryMockRecorder) FindUserByUsername(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByUsername", reflect.TypeOf((*MockUserDirectory)(nil).FindUserByUsername), username)
+}
+
+// SetRole mocks base method.
+func (m *MockUserDirectory) SetRole(ctx context.Context, id uuid.UUID, role string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, id, role)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserDirectoryMockRecorder) SetRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserDirectory)(nil).SetRole), ctx, id, role)
+}
+
+// MockSessionIssuer is a mock of SessionIssuer interface.
+type MockSessionIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionIssuerMockRecorder
+}
+
+// MockSessionIssuerMockRecorder is the mock recorder for MockSessionIssuer.
+type MockSessionIssuerMockRecorder struct {
+	mock *MockSessionIssuer
+}
+
+// NewMockSessionIssuer creates a new mock instance.
+func NewMockSessionIssuer(ctrl *gomock.Controller) *MockSessionIssuer {
+	mock := &MockSessionIssuer{ctrl: ctrl}
+	mock.recorder = &MockSessionIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionIssuer) EXPECT() *MockSessionIssuerMockRecorder {
+	return m.recorder
+}
+
+// IssueSession mocks base method.
+func (m *MockSessionIssuer) IssueSession(ctx context.Context, user *domain.User, detail string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueSession", ctx, user, detail)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IssueSession indicates an expected call of IssueSession.
+func (mr *MockSessionIssuerMockRecorder) IssueSession(ctx, user, detail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueSession", reflect.TypeOf((*MockSessionIssuer)(nil).IssueSession), ctx, user, detail)
+}
@@ -0,0 +1,46 @@
+package ssoService
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// ISSORepository はSSOの接続設定とIdPのユーザーの紐付けの永続化に関する操作を定義する
+type ISSORepository interface {
+	// SaveSSOConnection は接続設定を作成または更新する
+	SaveSSOConnection(ctx context.Context, conn *domain.SSOConnection) error
+	// GetSSOConnection は接続設定を取得する（存在しない場合はnil, nil）
+	GetSSOConnection(ctx context.Context, id string) (*domain.SSOConnection, error)
+	// ListSSOConnections は接続設定を名前順に取得する
+	ListSSOConnections(ctx context.Context) ([]*domain.SSOConnection, error)
+	// DeleteSSOConnection は接続設定と紐付けを削除し、削除したかどうかを返す
+	DeleteSSOConnection(ctx context.Context, id string) (bool, error)
+
+	// FindSSOIdentity はIdPのユーザーの紐付けを取得する（存在しない場合はnil, nil）
+	FindSSOIdentity(ctx context.Context, connectionID, subject string) (*domain.SSOIdentityLink, error)
+	SaveSSOIdentity(ctx context.Context, link *domain.SSOIdentityLink) error
+}
+
+// IdentityProvider はOIDCのIdPとの通信を行うインターフェース
+type IdentityProvider interface {
+	// AuthCodeURL はIdPの認可エンドポイントのURLを返す（PKCEのcode_challengeはS256）
+	AuthCodeURL(ctx context.Context, conn *domain.SSOConnection, redirectURI, state, nonce, codeChallenge string) (string, error)
+	// Exchange は認可コードをトークンに交換し、署名・発行者・対象者・有効期限を検証したIDトークンの内容を返す
+	Exchange(ctx context.Context, conn *domain.SSOConnection, redirectURI, code, codeVerifier string) (*domain.SSOIdentity, error)
+}
+
+// UserDirectory はSSOでログインするユーザーの検索・作成を行うインターフェース
+type UserDirectory interface {
+	FindUserByID(id uuid.UUID) (*domain.User, error)
+	FindUserByEmail(email string) (*domain.User, error)
+	FindUserByUsername(username string) (*domain.User, error)
+	CreateUser(user *domain.User) (*domain.User, error)
+	SetRole(ctx context.Context, id uuid.UUID, role string) (*domain.User, error)
+}
+
+// SessionIssuer はログインを完了してトークンを発行するインターフェース（パスワードでのログインと同じトークンを使う）
+type SessionIssuer interface {
+	IssueSession(ctx context.Context, user *domain.User, detail string) (accessToken string, refreshToken string, err error)
+}
@@ -0,0 +1,350 @@
+package ssoService
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var (
+	testNow    = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	testLogger = *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+	testSecret = []byte("state-secret")
+)
+
+type testMocks struct {
+	repo     *mocks.MockISSORepository
+	provider *mocks.MockIdentityProvider
+	users    *mocks.MockUserDirectory
+	sessions *mocks.MockSessionIssuer
+}
+
+func newTestService(t *testing.T) (*SSOService, *testMocks) {
+	ctrl := gomock.NewController(t)
+	m := &testMocks{
+		repo:     mocks.NewMockISSORepository(ctrl),
+		provider: mocks.NewMockIdentityProvider(ctrl),
+		users:    mocks.NewMockUserDirectory(ctrl),
+		sessions: mocks.NewMockSessionIssuer(ctrl),
+	}
+	service := NewSSOService(m.repo, m.provider, m.users, m.sessions, "https://api.example.com/callback", testSecret, testLogger)
+	service.now = func() time.Time { return testNow }
+	return service, m
+}
+
+func testConnection() *domain.SSOConnection {
+	return &domain.SSOConnection{
+		ID:              "conn-1",
+		Name:            "Example",
+		Domains:         []string{"example.com"},
+		Issuer:          "https://idp.example.com",
+		ClientID:        "client",
+		ClientSecret:    "secret",
+		Enforced:        true,
+		JITProvisioning: true,
+	}
+}
+
+func testStateCookie(t *testing.T) string {
+	value, err := domain.SignSSOState(&domain.SSOState{
+		ConnectionID: "conn-1",
+		State:        "state-1",
+		Nonce:        "nonce-1",
+		CodeVerifier: "verifier-1",
+		ReturnTo:     "/tasks",
+		ExpiresAt:    testNow.Add(domain.SSOStateDuration),
+	}, testSecret)
+	require.NoError(t, err)
+	return value
+}
+
+func TestSSOService_CreateConnection(t *testing.T) {
+	t.Run("normalizes and saves", func(t *testing.T) {
+		service, m := newTestService(t)
+		m.repo.EXPECT().ListSSOConnections(gomock.Any()).Return(nil, nil)
+		m.repo.EXPECT().SaveSSOConnection(gomock.Any(), gomock.Any()).Return(nil)
+
+		conn := testConnection()
+		conn.ID = ""
+		conn.Domains = []string{"EXAMPLE.com"}
+		created, err := service.CreateConnection(context.Background(), conn)
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, []string{"example.com"}, created.Domains)
+		assert.Equal(t, testNow, created.CreatedAt)
+	})
+
+	t.Run("domain used by another connection", func(t *testing.T) {
+		service, m := newTestService(t)
+		m.repo.EXPECT().ListSSOConnections(gomock.Any()).Return([]*domain.SSOConnection{testConnection()}, nil)
+
+		_, err := service.CreateConnection(context.Background(), testConnection())
+		assert.ErrorIs(t, err, ErrSSODomainInUse)
+	})
+
+	t.Run("client secret is required", func(t *testing.T) {
+		service, _ := newTestService(t)
+		conn := testConnection()
+		conn.ClientSecret = ""
+
+		_, err := service.CreateConnection(context.Background(), conn)
+		assert.ErrorIs(t, err, ErrSSOClientSecretNeeded)
+	})
+}
+
+func TestSSOService_UpdateConnection_KeepsSecret(t *testing.T) {
+	service, m := newTestService(t)
+	m.repo.EXPECT().GetSSOConnection(gomock.Any(), "conn-1").Return(testConnection(), nil)
+	m.repo.EXPECT().ListSSOConnections(gomock.Any()).Return([]*domain.SSOConnection{testConnection()}, nil)
+	m.repo.EXPECT().SaveSSOConnection(gomock.Any(), gomock.Any()).Return(nil)
+
+	input := testConnection()
+	input.ClientSecret = ""
+	input.Enforced = false
+	updated, err := service.UpdateConnection(context.Background(), "conn-1", input)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", updated.ClientSecret)
+	assert.False(t, updated.Enforced)
+}
+
+func TestSSOService_RequiresSSO(t *testing.T) {
+	service, m := newTestService(t)
+	m.repo.EXPECT().ListSSOConnections(gomock.Any()).Return([]*domain.SSOConnection{testConnection()}, nil).AnyTimes()
+
+	required, err := service.RequiresSSO(context.Background(), domain.NewUser("taro@example.com", "taro", "password"))
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	required, err = service.RequiresSSO(context.Background(), domain.NewUser("taro@other.com", "taro", "password"))
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	admin := domain.NewUser("admin@example.com", "admin", "password")
+	admin.Role = domain.RoleAdmin
+	required, err = service.RequiresSSO(context.Background(), admin)
+	require.NoError(t, err)
+	assert.False(t, required, "admins keep password login as a break-glass path")
+
+	service.RedirectURL = ""
+	required, err = service.RequiresSSO(context.Background(), domain.NewUser("taro@example.com", "taro", "password"))
+	require.NoError(t, err)
+	assert.False(t, required, "not enforced while sso is not configured")
+}
+
+func TestSSOService_BeginLogin(t *testing.T) {
+	service, m := newTestService(t)
+	m.repo.EXPECT().ListSSOConnections(gomock.Any()).Return([]*domain.SSOConnection{testConnection()}, nil)
+
+	var state, nonce, challenge string
+	m.provider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), service.RedirectURL, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.SSOConnection, _, s, n, c string) (string, error) {
+			state, nonce, challenge = s, n, c
+			return "https://idp.example.com/authorize?" + url.Values{"state": {s}}.Encode(), nil
+		})
+
+	authURL, cookie, err := service.BeginLogin(context.Background(), "taro@example.com", "", "https://evil.example.com")
+	require.NoError(t, err)
+	assert.Contains(t, authURL, "https://idp.example.com/authorize")
+
+	saved, err := domain.ParseSSOState(cookie, testSecret, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, "conn-1", saved.ConnectionID)
+	assert.Equal(t, state, saved.State)
+	assert.Equal(t, nonce, saved.Nonce)
+	assert.Equal(t, codeChallenge(saved.CodeVerifier), challenge)
+	assert.Empty(t, saved.ReturnTo, "only same-origin paths are kept")
+
+	m.repo.EXPECT().ListSSOConnections(gomock.Any()).Return([]*domain.SSOConnection{testConnection()}, nil)
+	_, _, err = service.BeginLogin(context.Background(), "taro@other.com", "", "")
+	assert.ErrorIs(t, err, ErrSSOConnectionNotFound)
+}
+
+func TestSSOService_CompleteLogin(t *testing.T) {
+	identity := func() *domain.SSOIdentity {
+		return &domain.SSOIdentity{
+			Subject:       "sub-1",
+			Email:         "taro@example.com",
+			EmailVerified: true,
+			Nonce:         "nonce-1",
+			Claims:        map[string]interface{}{"groups": []interface{}{"yotei-admins"}},
+		}
+	}
+	expectExchange := func(m *testMocks, conn *domain.SSOConnection, id *domain.SSOIdentity) {
+		m.repo.EXPECT().GetSSOConnection(gomock.Any(), "conn-1").Return(conn, nil)
+		m.provider.EXPECT().Exchange(gomock.Any(), conn, gomock.Any(), "code-1", "verifier-1").Return(id, nil)
+	}
+	expectSession := func(m *testMocks, user *domain.User) {
+		m.sessions.EXPECT().IssueSession(gomock.Any(), user, domain.SSOSecurityDetail).Return("access", "refresh", nil)
+	}
+
+	t.Run("linked user", func(t *testing.T) {
+		service, m := newTestService(t)
+		user := domain.NewUser("renamed@example.com", "taro", "password")
+		expectExchange(m, testConnection(), identity())
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").
+			Return(&domain.SSOIdentityLink{ConnectionID: "conn-1", Subject: "sub-1", UserID: user.ID.String()}, nil)
+		m.users.EXPECT().FindUserByID(user.ID).Return(user, nil)
+		expectSession(m, user)
+
+		result, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		require.NoError(t, err)
+		assert.Equal(t, "access", result.AccessToken)
+		assert.Equal(t, "/tasks", result.ReturnTo)
+	})
+
+	t.Run("existing user with the same email is linked", func(t *testing.T) {
+		service, m := newTestService(t)
+		user := domain.NewUser("taro@example.com", "taro", "password")
+		expectExchange(m, testConnection(), identity())
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").Return(nil, nil)
+		m.users.EXPECT().FindUserByEmail("taro@example.com").Return(user, nil)
+		m.repo.EXPECT().SaveSSOIdentity(gomock.Any(), &domain.SSOIdentityLink{
+			ConnectionID: "conn-1", Subject: "sub-1", UserID: user.ID.String(), CreatedAt: testNow,
+		}).Return(nil)
+		expectSession(m, user)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		require.NoError(t, err)
+	})
+
+	t.Run("just-in-time provisioning with mapped role", func(t *testing.T) {
+		service, m := newTestService(t)
+		conn := testConnection()
+		conn.RoleClaim = "groups"
+		conn.AdminValues = []string{"yotei-admins"}
+		id := identity()
+		id.PreferredUsername = "taro"
+		expectExchange(m, conn, id)
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").Return(nil, nil)
+		m.users.EXPECT().FindUserByEmail("taro@example.com").Return(nil, nil)
+		m.users.EXPECT().FindUserByUsername("taro").Return(domain.NewUser("other@example.com", "taro", "password"), nil)
+		m.users.EXPECT().FindUserByUsername("taro2").Return(nil, nil)
+
+		var created *domain.User
+		m.users.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(u *domain.User) (*domain.User, error) {
+			created = u
+			return u, nil
+		})
+		m.repo.EXPECT().SaveSSOIdentity(gomock.Any(), gomock.Any()).Return(nil)
+		m.sessions.EXPECT().IssueSession(gomock.Any(), gomock.Any(), domain.SSOSecurityDetail).Return("access", "refresh", nil)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		require.NoError(t, err)
+		assert.Equal(t, "taro2", created.Username)
+		assert.Equal(t, domain.RoleAdmin, created.Role)
+		assert.True(t, created.EmailVerified)
+		assert.NotEmpty(t, created.Password)
+	})
+
+	t.Run("role is synchronized on login", func(t *testing.T) {
+		service, m := newTestService(t)
+		conn := testConnection()
+		conn.RoleClaim = "groups"
+		conn.AdminValues = []string{"other-admins"}
+		admin := domain.NewUser("taro@example.com", "taro", "password")
+		admin.Role = domain.RoleAdmin
+		demoted := *admin
+		demoted.Role = domain.RoleUser
+
+		expectExchange(m, conn, identity())
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").
+			Return(&domain.SSOIdentityLink{UserID: admin.ID.String()}, nil)
+		m.users.EXPECT().FindUserByID(admin.ID).Return(admin, nil)
+		m.users.EXPECT().SetRole(gomock.Any(), admin.ID, domain.RoleUser).Return(&demoted, nil)
+		expectSession(m, &demoted)
+
+		result, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleUser, result.User.Role)
+	})
+
+	t.Run("unknown user without JIT", func(t *testing.T) {
+		service, m := newTestService(t)
+		conn := testConnection()
+		conn.JITProvisioning = false
+		expectExchange(m, conn, identity())
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").Return(nil, nil)
+		m.users.EXPECT().FindUserByEmail("taro@example.com").Return(nil, nil)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSOUserNotFound)
+	})
+
+	t.Run("email outside the connection domains", func(t *testing.T) {
+		service, m := newTestService(t)
+		id := identity()
+		id.Email = "taro@other.com"
+		expectExchange(m, testConnection(), id)
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").Return(nil, nil)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSODomainMismatch)
+	})
+
+	t.Run("unverified email is not linked", func(t *testing.T) {
+		service, m := newTestService(t)
+		id := identity()
+		id.EmailVerified = false
+		expectExchange(m, testConnection(), id)
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").Return(nil, nil)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSOEmailNotVerified)
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		service, m := newTestService(t)
+		user := domain.NewUser("taro@example.com", "taro", "password")
+		user.Deactivate(testNow)
+		expectExchange(m, testConnection(), identity())
+		m.repo.EXPECT().FindSSOIdentity(gomock.Any(), "conn-1", "sub-1").
+			Return(&domain.SSOIdentityLink{UserID: user.ID.String()}, nil)
+		m.users.EXPECT().FindUserByID(user.ID).Return(user, nil)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSOAccountInactive)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		service, m := newTestService(t)
+		id := identity()
+		id.Nonce = "replayed"
+		expectExchange(m, testConnection(), id)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "state-1", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSOStateInvalid)
+	})
+
+	t.Run("state mismatch", func(t *testing.T) {
+		service, _ := newTestService(t)
+
+		_, err := service.CompleteLogin(context.Background(), testStateCookie(t), "forged", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSOStateInvalid)
+		_, err = service.CompleteLogin(context.Background(), "", "state-1", "code-1")
+		assert.ErrorIs(t, err, domain.ErrSSOStateInvalid)
+	})
+}
+
+func TestAvailableUsername(t *testing.T) {
+	service, m := newTestService(t)
+	m.users.EXPECT().FindUserByUsername(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	name, err := service.availableUsername(&domain.SSOIdentity{Email: "t.yamada+work@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "t.yamadawork", name)
+
+	name, err = service.availableUsername(&domain.SSOIdentity{Email: "a@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "a__", name)
+}
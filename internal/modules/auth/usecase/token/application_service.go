@@ -37,11 +37,12 @@ func (t *TokenService) RevokeAccessToken(tokenString string) error {
 		return err
 	}
 
-	// 有効期限を計算
+	// 有効期限を計算（ブラックリストにはトークンの有効期限まで保持する）
 	expirationTime := time.Unix(claims.ExpiresAt.Time.Unix(), 0)
 	ttl := time.Until(expirationTime)
-	if ttl < 0 {
-		ttl = 0
+	if ttl <= 0 {
+		// 期限切れのトークンは検証で拒否されるため登録しない（TTL 0 は期限なしとして保存されるため）
+		return nil
 	}
 
 	// リポジトリを使用してブラックリストに保存
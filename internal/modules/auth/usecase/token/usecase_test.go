@@ -389,6 +389,8 @@ func TestTokenService_RevokeAccessToken(t *testing.T) {
 
 	validToken, err := service.GenerateAccessToken(user)
 	assert.NoError(t, err)
+	expiredToken, err := jwtManager.Generate(&token.Claims{UserID: user.ID.String()}, -time.Minute)
+	assert.NoError(t, err)
 
 	tests := []struct {
 		name          string
@@ -418,6 +420,14 @@ func TestTokenService_RevokeAccessToken(t *testing.T) {
 			},
 			expectedError: "token is malformed",
 		},
+		{
+			name:        "expired token is not blacklisted",
+			tokenString: expiredToken,
+			setupMocks:  func() {
+				// No mock expectations as the token is already rejected by validation
+			},
+			expectedError: "",
+		},
 		{
 			name:        "repository error",
 			tokenString: validToken,
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"time"
 
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
 	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
 	"github.com/hryt430/Yotei+/pkg/utils"
 
 	"context"
@@ -15,6 +17,21 @@ import (
 // userUseCase はユーザー関連のユースケースを実装する構造体
 type UserService struct {
 	UserRepository IUserRepository
+	// ブロック関係にあるユーザーを検索結果から除外する（nilの場合は除外しない）
+	BlockChecker commonDomain.BlockChecker
+	// プロフィール・役割の変更、無効化の際に破棄するユーザー情報のキャッシュ（nilの場合は何もしない）
+	UserInfoCache UserInfoCache
+	// パスワード・ユーザー名の変更を記録する監査ログ（nilの場合は記録しない）
+	SecurityEvents auditService.SecurityEventRecorder
+	// ユーザー名の変更履歴（nilの場合は変更の間隔の制限・変更前のユーザー名の保持を行わない）
+	UsernameHistory UsernameHistoryRepository
+	// ユーザー名の予約語と変更の制限（nilの場合は既定のポリシー）
+	UsernamePolicy *domain.UsernamePolicy
+}
+
+// UserInfoCache は他モジュールが参照するユーザー情報のキャッシュ
+type UserInfoCache interface {
+	Invalidate(userID string)
 }
 
 // NewUserUseCase は新しいUserUseCaseインスタンスを生成する
@@ -56,6 +73,38 @@ func (u *UserService) GetUsers(ctx context.Context, search string) ([]*domain.Us
 	return u.UserRepository.FindUsers(search)
 }
 
+// GetUsersForViewer は閲覧者とブロック関係にあるユーザーを除いたユーザー一覧を取得する
+func (u *UserService) GetUsersForViewer(ctx context.Context, viewerID uuid.UUID, search string) ([]*domain.User, error) {
+	users, err := u.GetUsers(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	if u.BlockChecker == nil {
+		return users, nil
+	}
+
+	blockedIDs, err := u.BlockChecker.GetBlockedUserIDs(ctx, viewerID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(blockedIDs) == 0 {
+		return users, nil
+	}
+
+	blocked := make(map[string]bool, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+
+	visible := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		if !blocked[user.ID.String()] {
+			visible = append(visible, user)
+		}
+	}
+	return visible, nil
+}
+
 // GetUserByEmail はメールアドレスでユーザーを検索する
 func (u *UserService) FindUserByEmail(email string) (*domain.User, error) {
 	user, err := u.UserRepository.FindUserByEmail(email)
@@ -86,6 +135,16 @@ func (u *UserService) UpdateUserProfile(id uuid.UUID, username, email string) (*
 
 	// 更新するフィールドをチェック
 	updated := false
+	oldUsername := user.Username
+
+	// ユーザー名の更新
+	if username != "" && username != user.Username {
+		if err := u.checkUsernameChange(context.Background(), user, username); err != nil {
+			return nil, err
+		}
+		user.Username = username
+		updated = true
+	}
 
 	// メールアドレスの更新
 	if email != "" && email != user.Email {
@@ -109,13 +168,84 @@ func (u *UserService) UpdateUserProfile(id uuid.UUID, username, email string) (*
 		if err := u.UserRepository.UpdateUser(user); err != nil {
 			return nil, err
 		}
+		if u.UserInfoCache != nil {
+			u.UserInfoCache.Invalidate(id.String())
+		}
+		if user.Username != oldUsername {
+			if err := u.usernameChanged(context.Background(), id, oldUsername, user.Username); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return user, nil
+}
+
+// FindUserByUsername はユーザー名でユーザーを検索する
+func (u *UserService) FindUserByUsername(username string) (*domain.User, error) {
+	return u.UserRepository.FindUserByUsername(username)
+}
+
+// SetActive はアカウントを無効化する、または有効に戻す
+// 無効化したユーザーはログイン・トークンの更新ができず、発行済みのアクセストークンも認証済みのユーザーのキャッシュが切れると使えなくなる
+func (u *UserService) SetActive(ctx context.Context, id uuid.UUID, active bool) (*domain.User, error) {
+	user, err := u.UserRepository.FindUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.IsActive() == active {
+		return user, nil
 	}
 
+	eventType := domain.SecurityEventAccountDeactivated
+	if active {
+		user.Activate(time.Now())
+		eventType = domain.SecurityEventAccountReactivated
+	} else {
+		user.Deactivate(time.Now())
+	}
+	if err := u.UserRepository.UpdateUser(user); err != nil {
+		return nil, err
+	}
+	if u.UserInfoCache != nil {
+		u.UserInfoCache.Invalidate(id.String())
+	}
+
+	if u.SecurityEvents != nil {
+		u.SecurityEvents.Record(ctx, domain.NewSecurityEvent(eventType, id.String(), ""))
+	}
+	return user, nil
+}
+
+// SetRole はユーザーの役割を変更する（SSOのクレームによる役割の同期で使用）
+func (u *UserService) SetRole(ctx context.Context, id uuid.UUID, role string) (*domain.User, error) {
+	user, err := u.UserRepository.FindUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	if err := user.SetRole(role); err != nil {
+		return nil, err
+	}
+	if err := u.UserRepository.UpdateUser(user); err != nil {
+		return nil, err
+	}
+	if u.UserInfoCache != nil {
+		u.UserInfoCache.Invalidate(id.String())
+	}
 	return user, nil
 }
 
 // ChangePassword はユーザーのパスワードを変更する
-func (u *UserService) ChangePassword(id uuid.UUID, oldPassword, newPassword string) error {
+func (u *UserService) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) error {
 	user, err := u.UserRepository.FindUserByID(id)
 	if err != nil {
 		return err
@@ -138,7 +268,14 @@ func (u *UserService) ChangePassword(id uuid.UUID, oldPassword, newPassword stri
 	user.Password = hashedPassword
 	user.UpdatedAt = time.Now()
 
-	return u.UserRepository.UpdateUser(user)
+	if err := u.UserRepository.UpdateUser(user); err != nil {
+		return err
+	}
+
+	if u.SecurityEvents != nil {
+		u.SecurityEvents.Record(ctx, domain.NewSecurityEvent(domain.SecurityEventPasswordChanged, id.String(), ""))
+	}
+	return nil
 }
 
 // UpdateLastLogin はユーザーの最終ログイン時間を更新する
@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -79,6 +81,21 @@ func (mr *MockIUserRepositoryMockRecorder) FindUserByID(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockIUserRepository)(nil).FindUserByID), id)
 }
 
+// FindUserByUsername mocks base method.
+func (m *MockIUserRepository) FindUserByUsername(username string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByUsername", username)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByUsername indicates an expected call of FindUserByUsername.
+func (mr *MockIUserRepositoryMockRecorder) FindUserByUsername(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByUsername", reflect.TypeOf((*MockIUserRepository)(nil).FindUserByUsername), username)
+}
+
 // FindUsers mocks base method.
 func (m *MockIUserRepository) FindUsers(search string) ([]*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -107,3 +124,70 @@ func (mr *MockIUserRepositoryMockRecorder) UpdateUser(user interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserRepository)(nil).UpdateUser), user)
 }
+
+// MockUsernameHistoryRepository is a mock of UsernameHistoryRepository interface.
+type MockUsernameHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsernameHistoryRepositoryMockRecorder
+}
+
+// MockUsernameHistoryRepositoryMockRecorder is the mock recorder for MockUsernameHistoryRepository.
+type MockUsernameHistoryRepositoryMockRecorder struct {
+	mock *MockUsernameHistoryRepository
+}
+
+// NewMockUsernameHistoryRepository creates a new mock instance.
+func NewMockUsernameHistoryRepository(ctrl *gomock.Controller) *MockUsernameHistoryRepository {
+	mock := &MockUsernameHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockUsernameHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsernameHistoryRepository) EXPECT() *MockUsernameHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLatestUsernameChange mocks base method.
+func (m *MockUsernameHistoryRepository) FindLatestUsernameChange(ctx context.Context, userID uuid.UUID) (*domain.UsernameChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestUsernameChange", ctx, userID)
+	ret0, _ := ret[0].(*domain.UsernameChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestUsernameChange indicates an expected call of FindLatestUsernameChange.
+func (mr *MockUsernameHistoryRepositoryMockRecorder) FindLatestUsernameChange(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestUsernameChange", reflect.TypeOf((*MockUsernameHistoryRepository)(nil).FindLatestUsernameChange), ctx, userID)
+}
+
+// FindRecentUsernameChange mocks base method.
+func (m *MockUsernameHistoryRepository) FindRecentUsernameChange(ctx context.Context, username string, since time.Time) (*domain.UsernameChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecentUsernameChange", ctx, username, since)
+	ret0, _ := ret[0].(*domain.UsernameChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecentUsernameChange indicates an expected call of FindRecentUsernameChange.
+func (mr *MockUsernameHistoryRepositoryMockRecorder) FindRecentUsernameChange(ctx, username, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecentUsernameChange", reflect.TypeOf((*MockUsernameHistoryRepository)(nil).FindRecentUsernameChange), ctx, username, since)
+}
+
+// SaveUsernameChange mocks base method.
+func (m *MockUsernameHistoryRepository) SaveUsernameChange(ctx context.Context, change *domain.UsernameChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUsernameChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUsernameChange indicates an expected call of SaveUsernameChange.
+func (mr *MockUsernameHistoryRepositoryMockRecorder) SaveUsernameChange(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUsernameChange", reflect.TypeOf((*MockUsernameHistoryRepository)(nil).SaveUsernameChange), ctx, change)
+}
@@ -0,0 +1,126 @@
+package userService
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// 認証済みのユーザーのキャッシュのメトリクス（ヒット率は hits / (hits + misses) で求める）
+const (
+	MetricProfileCacheHits   = "auth_user_cache_hits_total"
+	MetricProfileCacheMisses = "auth_user_cache_misses_total"
+)
+
+const (
+	// DefaultProfileCacheTTL は認証済みのユーザーをキャッシュする既定の期間
+	DefaultProfileCacheTTL = 30 * time.Second
+
+	// maxCachedProfiles はキャッシュするユーザー数の上限
+	maxCachedProfiles = 10000
+)
+
+// ProfileCache は認証ミドルウェアがリクエストごとに参照するユーザー（役割・有効かどうか）を短時間キャッシュする
+// プロフィール・役割の変更、無効化の際は UserService から Invalidate で破棄する
+// （他のインスタンスでの変更はTTLの間反映されない）
+type ProfileCache struct {
+	users IUserRepository
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu      sync.RWMutex
+	entries map[uuid.UUID]cachedProfile
+}
+
+type cachedProfile struct {
+	user      *domain.User
+	expiresAt time.Time
+}
+
+// NewProfileCache は新しいProfileCacheを作成する（ttlが0以下の場合は既定の期間を使う）
+func NewProfileCache(users IUserRepository, ttl time.Duration) *ProfileCache {
+	if ttl <= 0 {
+		ttl = DefaultProfileCacheTTL
+	}
+	return &ProfileCache{
+		users:   users,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[uuid.UUID]cachedProfile),
+	}
+}
+
+// FindUser はユーザーを取得する（存在しない場合は nil, nil）
+// パスワードのハッシュ・リフレッシュトークンは含めない。存在しないユーザーはキャッシュしない
+func (c *ProfileCache) FindUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if user, ok := c.get(id); ok {
+		metrics.Counter(MetricProfileCacheHits).Add(1)
+		return user, nil
+	}
+	metrics.Counter(MetricProfileCacheMisses).Add(1)
+
+	user, err := c.users.FindUserByID(id)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	profile := copyProfile(user)
+	profile.Password = ""
+	profile.RefreshTokens = nil
+	c.put(profile)
+	return copyProfile(profile), nil
+}
+
+// Invalidate はユーザーのキャッシュを削除する（UserInfoCache）
+func (c *ProfileCache) Invalidate(userID string) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
+
+func (c *ProfileCache) get(id uuid.UUID) (*domain.User, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[id]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return copyProfile(entry.user), true
+}
+
+func (c *ProfileCache) put(user *domain.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= maxCachedProfiles {
+		// 期限切れのキャッシュを削除し、それでも足りない場合は任意のキャッシュを削除する
+		for id, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		for id := range c.entries {
+			if len(c.entries) < maxCachedProfiles {
+				break
+			}
+			delete(c.entries, id)
+		}
+	}
+	c.entries[user.ID] = cachedProfile{user: user, expiresAt: now.Add(c.ttl)}
+}
+
+// copyProfile はキャッシュの内容が呼び出し側で変更されないようコピーする
+func copyProfile(user *domain.User) *domain.User {
+	c := *user
+	return &c
+}
@@ -0,0 +1,96 @@
+package userService
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/user/mocks"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// newTestProfileCache は時刻を進められるProfileCacheを作成する
+func newTestProfileCache(t *testing.T) (*ProfileCache, *mocks.MockIUserRepository, *time.Time) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockIUserRepository(ctrl)
+	cache := NewProfileCache(repo, 30*time.Second)
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, repo, &now
+}
+
+func TestProfileCache_FindUser(t *testing.T) {
+	cache, repo, now := newTestProfileCache(t)
+	user := &domain.User{ID: uuid.New(), Username: "alice", Password: "hashed", Role: domain.RoleUser}
+	hits := metrics.Counter(MetricProfileCacheHits).Value()
+	misses := metrics.Counter(MetricProfileCacheMisses).Value()
+
+	repo.EXPECT().FindUserByID(user.ID).Return(user, nil).Times(2)
+
+	got, err := cache.FindUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", got.Username)
+	assert.Empty(t, got.Password, "password hash is not cached")
+
+	// 呼び出し側の変更はキャッシュに影響しない
+	got.Role = domain.RoleAdmin
+	got, err = cache.FindUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, got.Role)
+
+	*now = now.Add(30 * time.Second)
+	_, err = cache.FindUser(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, hits+1, metrics.Counter(MetricProfileCacheHits).Value())
+	assert.Equal(t, misses+2, metrics.Counter(MetricProfileCacheMisses).Value())
+}
+
+func TestProfileCache_Invalidate(t *testing.T) {
+	cache, repo, _ := newTestProfileCache(t)
+	id := uuid.New()
+
+	gomock.InOrder(
+		repo.EXPECT().FindUserByID(id).Return(&domain.User{ID: id, Role: domain.RoleUser}, nil),
+		repo.EXPECT().FindUserByID(id).Return(&domain.User{ID: id, Role: domain.RoleAdmin}, nil),
+	)
+
+	_, err := cache.FindUser(context.Background(), id)
+	require.NoError(t, err)
+	cache.Invalidate(id.String())
+
+	got, err := cache.FindUser(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, got.Role)
+}
+
+func TestProfileCache_DoesNotCacheMissingUsersOrErrors(t *testing.T) {
+	cache, repo, _ := newTestProfileCache(t)
+	id := uuid.New()
+
+	gomock.InOrder(
+		repo.EXPECT().FindUserByID(id).Return(nil, errors.New("db down")),
+		repo.EXPECT().FindUserByID(id).Return(nil, nil),
+		repo.EXPECT().FindUserByID(id).Return(&domain.User{ID: id}, nil),
+	)
+
+	_, err := cache.FindUser(context.Background(), id)
+	assert.Error(t, err)
+
+	got, err := cache.FindUser(context.Background(), id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = cache.FindUser(context.Background(), id)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
@@ -1,6 +1,9 @@
 package userService
 
 import (
+	"context"
+	"time"
+
 	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
 
 	"github.com/google/uuid"
@@ -10,6 +13,16 @@ type IUserRepository interface {
 	CreateUser(user *domain.User) error
 	FindUserByEmail(email string) (*domain.User, error)
 	FindUserByID(id uuid.UUID) (*domain.User, error)
+	FindUserByUsername(username string) (*domain.User, error)
 	FindUsers(search string) ([]*domain.User, error)
 	UpdateUser(user *domain.User) error
 }
+
+// UsernameHistoryRepository はユーザー名の変更履歴のリポジトリ
+type UsernameHistoryRepository interface {
+	SaveUsernameChange(ctx context.Context, change *domain.UsernameChange) error
+	// FindLatestUsernameChange はユーザーの最後の変更を取得する（変更したことがない場合は nil, nil）
+	FindLatestUsernameChange(ctx context.Context, userID uuid.UUID) (*domain.UsernameChange, error)
+	// FindRecentUsernameChange は since 以降に username（大文字小文字を区別しない）から変更した最新の履歴を取得する（ない場合は nil, nil）
+	FindRecentUsernameChange(ctx context.Context, username string, since time.Time) (*domain.UsernameChange, error)
+}
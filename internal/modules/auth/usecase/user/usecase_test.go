@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
 	"github.com/hryt430/Yotei+/internal/modules/auth/usecase/user/mocks"
@@ -225,6 +226,32 @@ func TestUserService_GetUsers(t *testing.T) {
 	}
 }
 
+func TestUserService_GetUsersForViewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockBlockChecker := mocks.NewMockBlockChecker(ctrl)
+	service := NewUserService(mockRepo)
+	service.BlockChecker = mockBlockChecker
+
+	viewerID := uuid.New()
+	visibleUser := &domain.User{ID: uuid.New(), Username: "visible"}
+	blockedUser := &domain.User{ID: uuid.New(), Username: "blocked"}
+
+	mockRepo.EXPECT().
+		FindUsers("user").
+		Return([]*domain.User{visibleUser, blockedUser}, nil)
+	mockBlockChecker.EXPECT().
+		GetBlockedUserIDs(gomock.Any(), viewerID.String()).
+		Return([]string{blockedUser.ID.String()}, nil)
+
+	users, err := service.GetUsersForViewer(context.Background(), viewerID, "user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.User{visibleUser}, users)
+}
+
 func TestUserService_FindUserByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -566,6 +593,43 @@ func TestUserService_UpdateUserProfile(t *testing.T) {
 	}
 }
 
+type recordingUserInfoCache struct {
+	invalidated []string
+}
+
+func (c *recordingUserInfoCache) Invalidate(userID string) {
+	c.invalidated = append(c.invalidated, userID)
+}
+
+func TestUserService_UpdateUserProfile_InvalidatesUserInfoCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	cache := &recordingUserInfoCache{}
+	service := NewUserService(mockRepo)
+	service.UserInfoCache = cache
+
+	userID := uuid.New()
+	mockRepo.EXPECT().
+		FindUserByID(userID).
+		DoAndReturn(func(id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: id, Email: "original@example.com"}, nil
+		}).
+		Times(2)
+	mockRepo.EXPECT().FindUserByEmail("new@example.com").Return(nil, nil)
+	mockRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+
+	_, err := service.UpdateUserProfile(userID, "", "new@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{userID.String()}, cache.invalidated)
+
+	// 変更がない場合はキャッシュを破棄しない
+	_, err = service.UpdateUserProfile(userID, "", "original@example.com")
+	assert.NoError(t, err)
+	assert.Len(t, cache.invalidated, 1)
+}
+
 func TestUserService_ChangePassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -665,7 +729,7 @@ func TestUserService_ChangePassword(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := service.ChangePassword(tt.id, tt.oldPassword, tt.newPassword)
+			err := service.ChangePassword(context.Background(), tt.id, tt.oldPassword, tt.newPassword)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -677,6 +741,66 @@ func TestUserService_ChangePassword(t *testing.T) {
 	}
 }
 
+// securityEvents records the events passed to Record
+type securityEvents struct {
+	events []*domain.SecurityEvent
+}
+
+func (s *securityEvents) Record(ctx context.Context, event *domain.SecurityEvent) {
+	s.events = append(s.events, event)
+}
+
+func TestUserService_ChangePassword_RecordsSecurityEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	recorder := &securityEvents{}
+	service := NewUserService(mockRepo)
+	service.SecurityEvents = recorder
+
+	userID := uuid.New()
+	hashed, err := utils.HashPassword("oldpassword")
+	require.NoError(t, err)
+	mockRepo.EXPECT().FindUserByID(userID).Return(&domain.User{ID: userID, Password: hashed}, nil).Times(2)
+	mockRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+
+	// a wrong current password is not recorded as a change
+	assert.Error(t, service.ChangePassword(context.Background(), userID, "wrong", "newpassword123"))
+	require.NoError(t, service.ChangePassword(context.Background(), userID, "oldpassword", "newpassword123"))
+
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, domain.SecurityEventPasswordChanged, recorder.events[0].Type)
+	assert.Equal(t, userID.String(), recorder.events[0].UserID)
+}
+
+func TestUserService_SetActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	recorder := &securityEvents{}
+	service := NewUserService(mockRepo)
+	service.SecurityEvents = recorder
+
+	userID := uuid.New()
+	user := &domain.User{ID: userID}
+	mockRepo.EXPECT().FindUserByID(userID).Return(user, nil).Times(3)
+	mockRepo.EXPECT().UpdateUser(user).Return(nil).Times(2)
+
+	updated, err := service.SetActive(context.Background(), userID, false)
+	require.NoError(t, err)
+	assert.False(t, updated.IsActive())
+
+	// deactivating an inactive account changes nothing
+	_, err = service.SetActive(context.Background(), userID, false)
+	require.NoError(t, err)
+
+	updated, err = service.SetActive(context.Background(), userID, true)
+	require.NoError(t, err)
+	assert.True(t, updated.IsActive())
+
+	require.Len(t, recorder.events, 2)
+	assert.Equal(t, domain.SecurityEventAccountDeactivated, recorder.events[0].Type)
+	assert.Equal(t, domain.SecurityEventAccountReactivated, recorder.events[1].Type)
+}
+
 func TestUserService_UpdateLastLogin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -775,3 +899,129 @@ func TestUserService_UpdateLastLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_ChangeUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockHistory := mocks.NewMockUsernameHistoryRepository(ctrl)
+	cache := &recordingUserInfoCache{}
+	service := NewUserService(mockRepo)
+	service.UsernameHistory = mockHistory
+	service.UserInfoCache = cache
+	service.UsernamePolicy = domain.NewUsernamePolicy(nil, 24*time.Hour, 7*24*time.Hour)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	currentUser := func() *domain.User {
+		return &domain.User{ID: userID, Username: "alice", Role: domain.RoleUser}
+	}
+
+	t.Run("changes username and records history", func(t *testing.T) {
+		mockRepo.EXPECT().FindUserByID(userID).Return(currentUser(), nil)
+		mockRepo.EXPECT().FindUserByUsername("alice2").Return(nil, nil)
+		mockHistory.EXPECT().FindRecentUsernameChange(ctx, "alice2", gomock.Any()).Return(nil, nil)
+		mockHistory.EXPECT().FindLatestUsernameChange(ctx, userID).Return(nil, nil)
+		mockRepo.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+		mockHistory.EXPECT().SaveUsernameChange(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, change *domain.UsernameChange) error {
+				assert.Equal(t, userID, change.UserID)
+				assert.Equal(t, "alice", change.OldUsername)
+				assert.Equal(t, "alice2", change.NewUsername)
+				return nil
+			})
+
+		user, err := service.ChangeUsername(ctx, userID, " alice2 ")
+		require.NoError(t, err)
+		assert.Equal(t, "alice2", user.Username)
+		assert.Equal(t, []string{userID.String()}, cache.invalidated)
+	})
+
+	t.Run("reserved username", func(t *testing.T) {
+		mockRepo.EXPECT().FindUserByID(userID).Return(currentUser(), nil)
+
+		_, err := service.ChangeUsername(ctx, userID, "support")
+		assert.ErrorIs(t, err, domain.ErrUsernameReserved)
+	})
+
+	t.Run("username used by another user", func(t *testing.T) {
+		mockRepo.EXPECT().FindUserByID(userID).Return(currentUser(), nil)
+		mockRepo.EXPECT().FindUserByUsername("bob").Return(&domain.User{ID: uuid.New(), Username: "bob"}, nil)
+
+		_, err := service.ChangeUsername(ctx, userID, "bob")
+		assert.ErrorIs(t, err, domain.ErrUsernameTaken)
+	})
+
+	t.Run("previous username held for another user", func(t *testing.T) {
+		mockRepo.EXPECT().FindUserByID(userID).Return(currentUser(), nil)
+		mockRepo.EXPECT().FindUserByUsername("carol").Return(nil, nil)
+		mockHistory.EXPECT().FindRecentUsernameChange(ctx, "carol", gomock.Any()).
+			Return(domain.NewUsernameChange(uuid.New(), "carol", "caroline", time.Now().Add(-time.Hour)), nil)
+
+		_, err := service.ChangeUsername(ctx, userID, "carol")
+		assert.ErrorIs(t, err, domain.ErrUsernameTaken)
+	})
+
+	t.Run("cooldown after recent change", func(t *testing.T) {
+		mockRepo.EXPECT().FindUserByID(userID).Return(currentUser(), nil)
+		mockRepo.EXPECT().FindUserByUsername("alice3").Return(nil, nil)
+		mockHistory.EXPECT().FindRecentUsernameChange(ctx, "alice3", gomock.Any()).Return(nil, nil)
+		mockHistory.EXPECT().FindLatestUsernameChange(ctx, userID).
+			Return(domain.NewUsernameChange(userID, "alice0", "alice", time.Now().Add(-time.Hour)), nil)
+
+		_, err := service.ChangeUsername(ctx, userID, "alice3")
+		assert.ErrorIs(t, err, domain.ErrUsernameChangeCooldown)
+	})
+
+	t.Run("same username is a no-op", func(t *testing.T) {
+		mockRepo.EXPECT().FindUserByID(userID).Return(currentUser(), nil)
+
+		user, err := service.ChangeUsername(ctx, userID, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", user.Username)
+	})
+}
+
+func TestUserService_CheckUsernameAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	service := NewUserService(mockRepo)
+	ctx := context.Background()
+
+	// 変更履歴がない場合は形式・予約語・使用中のユーザー名のみ確認する
+	mockRepo.EXPECT().FindUserByUsername("newuser").Return(nil, nil)
+	assert.NoError(t, service.CheckUsernameAvailable(ctx, uuid.Nil, "newuser"))
+	assert.ErrorIs(t, service.CheckUsernameAvailable(ctx, uuid.Nil, "admin"), domain.ErrUsernameReserved)
+	assert.ErrorIs(t, service.CheckUsernameAvailable(ctx, uuid.Nil, "no spaces"), domain.ErrInvalidUsername)
+}
+
+func TestUserService_ResolveUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockHistory := mocks.NewMockUsernameHistoryRepository(ctrl)
+	service := NewUserService(mockRepo)
+	service.UsernameHistory = mockHistory
+	ctx := context.Background()
+
+	renamed := &domain.User{ID: uuid.New(), Username: "alice2"}
+	mockRepo.EXPECT().FindUserByUsername("alice").Return(nil, nil)
+	mockHistory.EXPECT().FindRecentUsernameChange(ctx, "alice", time.Time{}).
+		Return(domain.NewUsernameChange(renamed.ID, "alice", "alice2", time.Now()), nil)
+	mockRepo.EXPECT().FindUserByID(renamed.ID).Return(renamed, nil)
+
+	user, err := service.ResolveUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, renamed, user)
+
+	mockRepo.EXPECT().FindUserByUsername("nobody").Return(nil, nil)
+	mockHistory.EXPECT().FindRecentUsernameChange(ctx, "nobody", time.Time{}).Return(nil, nil)
+
+	user, err = service.ResolveUsername(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Nil(t, user)
+}
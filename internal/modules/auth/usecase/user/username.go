@@ -0,0 +1,150 @@
+package userService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/auth/domain"
+)
+
+// usernamePolicy は設定されたユーザー名のポリシーを返す（未設定の場合は既定のポリシー）
+func (u *UserService) usernamePolicy() *domain.UsernamePolicy {
+	if u.UsernamePolicy != nil {
+		return u.UsernamePolicy
+	}
+	return domain.DefaultUsernamePolicy()
+}
+
+// CheckUsernameAvailable は userID のユーザーが username を使えるか確認する（新規登録の場合は uuid.Nil）
+// 形式・予約語に加え、他のユーザーが使用中のユーザー名、保持期間中の他のユーザーの以前のユーザー名は使えない
+func (u *UserService) CheckUsernameAvailable(ctx context.Context, userID uuid.UUID, username string) error {
+	policy := u.usernamePolicy()
+	if err := policy.Validate(username); err != nil {
+		return err
+	}
+
+	existing, err := u.UserRepository.FindUserByUsername(username)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != userID {
+		return domain.ErrUsernameTaken
+	}
+
+	if u.UsernameHistory == nil || policy.HoldPeriod <= 0 {
+		return nil
+	}
+	held, err := u.UsernameHistory.FindRecentUsernameChange(ctx, username, time.Now().Add(-policy.HoldPeriod))
+	if err != nil {
+		return fmt.Errorf("failed to check username history: %w", err)
+	}
+	if held != nil && held.UserID != userID {
+		return domain.ErrUsernameTaken
+	}
+	return nil
+}
+
+// ChangeUsername はユーザー名を変更する
+// 前回の変更から ChangeCooldown が経過するまでは変更できない。変更前のユーザー名は履歴に残し、キャッシュしたユーザー情報を破棄する
+func (u *UserService) ChangeUsername(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+	user, err := u.UserRepository.FindUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	username = strings.TrimSpace(username)
+	if username == user.Username {
+		return user, nil
+	}
+
+	oldUsername := user.Username
+	if err := u.checkUsernameChange(ctx, user, username); err != nil {
+		return nil, err
+	}
+
+	user.Username = username
+	user.UpdatedAt = time.Now()
+	if err := u.UserRepository.UpdateUser(user); err != nil {
+		return nil, err
+	}
+	if u.UserInfoCache != nil {
+		u.UserInfoCache.Invalidate(id.String())
+	}
+	if err := u.usernameChanged(ctx, user.ID, oldUsername, username); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkUsernameChange は user のユーザー名を username に変更できるか確認する
+// 大文字小文字のみの変更は、以前からの予約語のユーザー名でも許可する
+func (u *UserService) checkUsernameChange(ctx context.Context, user *domain.User, username string) error {
+	if strings.EqualFold(username, user.Username) {
+		if err := domain.ValidateUsername(username); err != nil {
+			return err
+		}
+	} else if err := u.CheckUsernameAvailable(ctx, user.ID, username); err != nil {
+		return err
+	}
+
+	if u.UsernameHistory == nil {
+		return nil
+	}
+	last, err := u.UsernameHistory.FindLatestUsernameChange(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get username history: %w", err)
+	}
+	if next := u.usernamePolicy().NextChangeAllowedAt(last); time.Now().Before(next) {
+		return fmt.Errorf("%w: next change allowed at %s", domain.ErrUsernameChangeCooldown, next.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// RecordUsernameChange はポリシーを通さずに変更したユーザー名（SCIMによる同期など）を履歴に残す
+func (u *UserService) RecordUsernameChange(ctx context.Context, userID uuid.UUID, oldUsername, newUsername string) error {
+	if oldUsername == newUsername {
+		return nil
+	}
+	return u.usernameChanged(ctx, userID, oldUsername, newUsername)
+}
+
+// usernameChanged は変更を監査ログに記録し、変更前のユーザー名を履歴に残す
+func (u *UserService) usernameChanged(ctx context.Context, userID uuid.UUID, oldUsername, newUsername string) error {
+	if u.SecurityEvents != nil {
+		u.SecurityEvents.Record(ctx, domain.NewSecurityEvent(domain.SecurityEventUsernameChanged, userID.String(), oldUsername+" -> "+newUsername))
+	}
+
+	if u.UsernameHistory == nil {
+		return nil
+	}
+	change := domain.NewUsernameChange(userID, oldUsername, newUsername, time.Now())
+	if err := u.UsernameHistory.SaveUsernameChange(ctx, change); err != nil {
+		return fmt.Errorf("failed to save username history: %w", err)
+	}
+	return nil
+}
+
+// ResolveUsername はユーザー名からユーザーを取得する（存在しない場合は nil, nil）
+// 現在のユーザーに一致しない場合は、変更前にそのユーザー名を使っていたユーザーを返す（以前のユーザー名へのリンク・メンション用）
+func (u *UserService) ResolveUsername(ctx context.Context, username string) (*domain.User, error) {
+	user, err := u.UserRepository.FindUserByUsername(username)
+	if err != nil || user != nil || u.UsernameHistory == nil {
+		return user, err
+	}
+
+	change, err := u.UsernameHistory.FindRecentUsernameChange(ctx, username, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get username history: %w", err)
+	}
+	if change == nil {
+		return nil, nil
+	}
+	return u.UserRepository.FindUserByID(change.UserID)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+)
+
+func TestPlans(t *testing.T) {
+	t.Run("recurring tasks are only available on paid plans", func(t *testing.T) {
+		for _, plan := range Plans() {
+			assert.Equal(t, plan.IsPaid(), plan.HasEntitlement(commonDomain.EntitlementRecurringTasks), plan.ID)
+		}
+	})
+
+	t.Run("plans use known quota resources", func(t *testing.T) {
+		known := map[string]bool{
+			commonDomain.QuotaOpenTasks:         true,
+			commonDomain.QuotaGroups:            true,
+			commonDomain.QuotaAttachmentBytes:   true,
+			commonDomain.QuotaInvitationsPerDay: true,
+		}
+		for _, plan := range Plans() {
+			for resource := range plan.Limits {
+				assert.True(t, known[resource], "%s: %s", plan.ID, resource)
+			}
+		}
+	})
+
+	t.Run("callers cannot change the definitions", func(t *testing.T) {
+		Plans()[0].Limits[commonDomain.QuotaOpenTasks] = 1
+
+		plan, err := PlanByID(PlanFree)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), plan.Limits[commonDomain.QuotaOpenTasks])
+	})
+
+	t.Run("unknown plans are rejected", func(t *testing.T) {
+		_, err := PlanByID("enterprise")
+		assert.ErrorIs(t, err, ErrUnknownPlan)
+	})
+}
+
+func TestSubscription_EffectivePlanID(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *Subscription
+		want         string
+	}{
+		{"no subscription", nil, PlanFree},
+		{"active", &Subscription{PlanID: PlanPro, Status: StatusActive}, PlanPro},
+		{"trialing", &Subscription{PlanID: PlanTeam, Status: StatusTrialing}, PlanTeam},
+		{"payment being retried", &Subscription{PlanID: PlanPro, Status: StatusPastDue}, PlanPro},
+		{"canceled", &Subscription{PlanID: PlanPro, Status: StatusCanceled}, PlanFree},
+		{"unpaid", &Subscription{PlanID: PlanPro, Status: StatusUnpaid}, PlanFree},
+		{"incomplete checkout", &Subscription{PlanID: PlanPro, Status: StatusIncomplete}, PlanFree},
+		{"unknown plan", &Subscription{PlanID: "legacy", Status: StatusActive}, PlanFree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.subscription.EffectivePlanID())
+		})
+	}
+}
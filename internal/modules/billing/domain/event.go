@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// 契約に反映するStripeのイベント種別（それ以外のイベントは受信のみ）
+const (
+	EventCheckoutCompleted   = "checkout.session.completed"
+	EventSubscriptionCreated = "customer.subscription.created"
+	EventSubscriptionUpdated = "customer.subscription.updated"
+	EventSubscriptionDeleted = "customer.subscription.deleted"
+)
+
+// WebhookEvent は署名を検証したStripeのイベントのうち、契約の同期に使う値
+type WebhookEvent struct {
+	ID        string
+	Type      string
+	CreatedAt time.Time
+	// チェックアウトの client_reference_id、またはサブスクリプションの metadata.user_id
+	UserID         string
+	CustomerID     string
+	SubscriptionID string
+	// チェックアウト・サブスクリプションの metadata.plan_id
+	PlanID string
+	// サブスクリプションの価格ID（プランの判定に使う）
+	PriceID           string
+	Status            string
+	CurrentPeriodEnd  *time.Time
+	CancelAtPeriodEnd bool
+}
+
+// IsSubscriptionEvent はサブスクリプションの状態が変わったイベントか
+func (e *WebhookEvent) IsSubscriptionEvent() bool {
+	switch e.Type {
+	case EventSubscriptionCreated, EventSubscriptionUpdated, EventSubscriptionDeleted:
+		return true
+	}
+	return false
+}
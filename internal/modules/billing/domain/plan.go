@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+)
+
+// プランのID
+const (
+	PlanFree = "free"
+	PlanPro  = "pro"
+	PlanTeam = "team"
+)
+
+var (
+	ErrUnknownPlan        = errors.New("unknown plan")
+	ErrPlanNotPurchasable = errors.New("plan is not available for checkout")
+)
+
+// Plan は契約プランの定義
+// 上限は使用量の上限（QuotaService）に、機能は契約プランでの機能の制限に使う
+type Plan struct {
+	ID   string `json:"id" example:"pro"`
+	Name string `json:"name" example:"Pro"`
+	// リソースごとの上限（含まれないリソースは無制限）
+	Limits map[string]int64 `json:"limits"`
+	// 利用できる機能
+	Entitlements []string `json:"entitlements" example:"recurring_tasks,share_links"`
+	// チェックアウトで購入できるか（Stripeの価格が設定された有料プラン）
+	Purchasable bool `json:"purchasable" example:"true"`
+} // @name BillingPlan
+
+// HasEntitlement はプランでfeatureを利用できるか
+func (p *Plan) HasEntitlement(feature string) bool {
+	for _, e := range p.Entitlements {
+		if e == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPaid は有料プランか
+func (p *Plan) IsPaid() bool {
+	return p.ID != PlanFree
+}
+
+// Plans はプランの定義を表示順に返す（呼び出し側で変更できるよう毎回作成する）
+func Plans() []*Plan {
+	return []*Plan{
+		{
+			ID:   PlanFree,
+			Name: "Free",
+			Limits: map[string]int64{
+				commonDomain.QuotaOpenTasks:         100,
+				commonDomain.QuotaGroups:            1,
+				commonDomain.QuotaAttachmentBytes:   100 << 20, // 100MB
+				commonDomain.QuotaInvitationsPerDay: 10,
+			},
+			Entitlements: []string{},
+		},
+		{
+			ID:   PlanPro,
+			Name: "Pro",
+			Limits: map[string]int64{
+				commonDomain.QuotaGroups:            10,
+				commonDomain.QuotaAttachmentBytes:   10 << 30, // 10GB
+				commonDomain.QuotaInvitationsPerDay: 50,
+			},
+			Entitlements: []string{
+				commonDomain.EntitlementRecurringTasks,
+				commonDomain.EntitlementShareLinks,
+			},
+		},
+		{
+			ID:   PlanTeam,
+			Name: "Team",
+			Limits: map[string]int64{
+				commonDomain.QuotaAttachmentBytes:   100 << 30, // 100GB
+				commonDomain.QuotaInvitationsPerDay: 500,
+			},
+			Entitlements: []string{
+				commonDomain.EntitlementRecurringTasks,
+				commonDomain.EntitlementShareLinks,
+			},
+		},
+	}
+}
+
+// PlanByID はIDでプランを取得する（存在しない場合はErrUnknownPlan）
+func PlanByID(id string) (*Plan, error) {
+	for _, plan := range Plans() {
+		if plan.ID == id {
+			return plan, nil
+		}
+	}
+	return nil, ErrUnknownPlan
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// サブスクリプションの状態（Stripeの status の値）
+const (
+	StatusActive            = "active"
+	StatusTrialing          = "trialing"
+	StatusPastDue           = "past_due"
+	StatusIncomplete        = "incomplete"
+	StatusIncompleteExpired = "incomplete_expired"
+	StatusUnpaid            = "unpaid"
+	StatusCanceled          = "canceled"
+)
+
+var (
+	ErrBillingDisabled   = errors.New("billing is not configured")
+	ErrAlreadySubscribed = errors.New("already subscribed to a paid plan")
+	ErrInvalidSignature  = errors.New("invalid webhook signature")
+	ErrInvalidPayload    = errors.New("invalid webhook payload")
+)
+
+// Subscription はユーザーの有料プランの契約
+// Stripeのサブスクリプションの状態をWebhookで同期する
+type Subscription struct {
+	UserID               string     `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PlanID               string     `json:"plan_id" example:"pro"`
+	Status               string     `json:"status" example:"active"`
+	StripeCustomerID     string     `json:"-"`
+	StripeSubscriptionID string     `json:"-"`
+	CurrentPeriodEnd     *time.Time `json:"current_period_end,omitempty" example:"2024-07-01T00:00:00Z"`
+	// trueの場合、現在の期間の終了時に解約される
+	CancelAtPeriodEnd bool `json:"cancel_at_period_end" example:"false"`
+	// 最後に反映したWebhookのイベントの作成日時（順序が入れ替わって届いた古いイベントを無視する）
+	LastEventAt time.Time `json:"-"`
+	CreatedAt   time.Time `json:"created_at" example:"2024-06-01T09:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2024-06-01T09:00:00Z"`
+} // @name BillingSubscription
+
+// IsActive は有料プランを利用できる状態か
+func (s *Subscription) IsActive() bool {
+	return s != nil && IsActiveStatus(s.Status)
+}
+
+// IsActiveStatus は有料プランを利用できるサブスクリプションの状態か
+// 支払いの再試行中（past_due）は、Stripeが解約するまで利用を続けられる
+func IsActiveStatus(status string) bool {
+	switch status {
+	case StatusActive, StatusTrialing, StatusPastDue:
+		return true
+	}
+	return false
+}
+
+// EffectivePlanID は利用できるプランのIDを返す（契約がない、または有効でない場合はFree）
+func (s *Subscription) EffectivePlanID() string {
+	if !s.IsActive() {
+		return PlanFree
+	}
+	if _, err := PlanByID(s.PlanID); err != nil {
+		return PlanFree
+	}
+	return s.PlanID
+}
+
+// SubscriptionState はユーザーが利用できるプランと契約の状態
+type SubscriptionState struct {
+	Plan *Plan `json:"plan"`
+	// 有料プランの契約（契約したことがない場合はnull）
+	Subscription *Subscription `json:"subscription"`
+} // @name BillingSubscriptionState
+
+// CheckoutInput はチェックアウト作成の入力
+type CheckoutInput struct {
+	UserID  string
+	PlanID  string
+	PriceID string
+	// 以前に契約したことがある場合のStripeの顧客ID（空の場合は新しい顧客を作成する）
+	CustomerID string
+	SuccessURL string
+	CancelURL  string
+}
+
+// CheckoutSession は有料プランを購入するStripeのチェックアウト
+type CheckoutSession struct {
+	ID string `json:"id" example:"cs_test_a1b2c3"`
+	// 支払い画面のURL（フロントエンドはこのURLにリダイレクトする）
+	URL string `json:"url" example:"https://checkout.stripe.com/c/pay/cs_test_a1b2c3"`
+} // @name BillingCheckoutSession
@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+	"github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+)
+
+const (
+	// stripeAPIBaseURL はStripe APIのベースURL
+	stripeAPIBaseURL = "https://api.stripe.com/v1"
+	// stripeTimeout はStripe APIへの問い合わせのタイムアウト
+	stripeTimeout = 10 * time.Second
+	// stripeSignatureTolerance はWebhookの署名の時刻として許容するずれ（リプレイ攻撃の対策）
+	stripeSignatureTolerance = 5 * time.Minute
+)
+
+// StripeGateway はStripeのチェックアウトとWebhookを扱うゲートウェイ実装
+type StripeGateway struct {
+	secretKey     string
+	webhookSecret string
+	httpClient    *http.Client
+}
+
+// NewStripeGateway はStripeのゲートウェイを作成する
+func NewStripeGateway(secretKey, webhookSecret string) usecase.PaymentGateway {
+	return &StripeGateway{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		httpClient:    &http.Client{Timeout: stripeTimeout},
+	}
+}
+
+// stripeError はStripe APIのエラーレスポンス
+type stripeError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateCheckoutSession はサブスクリプションのチェックアウトを作成する
+// ユーザーIDは client_reference_id とサブスクリプションの metadata に設定し、Webhookで契約に紐付ける
+func (g *StripeGateway) CreateCheckoutSession(ctx context.Context, input domain.CheckoutInput) (*domain.CheckoutSession, error) {
+	form := url.Values{}
+	form.Set("mode", "subscription")
+	form.Set("line_items[0][price]", input.PriceID)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("client_reference_id", input.UserID)
+	form.Set("success_url", input.SuccessURL)
+	form.Set("cancel_url", input.CancelURL)
+	form.Set("metadata[user_id]", input.UserID)
+	form.Set("metadata[plan_id]", input.PlanID)
+	form.Set("subscription_data[metadata][user_id]", input.UserID)
+	form.Set("subscription_data[metadata][plan_id]", input.PlanID)
+	if input.CustomerID != "" {
+		form.Set("customer", input.CustomerID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, stripeAPIBaseURL+"/checkout/sessions", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(g.secretKey, "")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request checkout session: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body stripeError
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return nil, fmt.Errorf("stripe returned %d: %s", resp.StatusCode, body.Error.Message)
+	}
+
+	var session domain.CheckoutSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("failed to decode checkout session: %w", err)
+	}
+	return &session, nil
+}
+
+// stripeEvent はWebhookで届くイベント
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// stripeCheckoutSession はチェックアウトのうち使用するフィールド
+type stripeCheckoutSession struct {
+	ClientReferenceID string            `json:"client_reference_id"`
+	Customer          string            `json:"customer"`
+	Subscription      string            `json:"subscription"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+// stripeSubscription はサブスクリプションのうち使用するフィールド
+// current_period_end は新しいAPIバージョンではアイテムごとに返される
+type stripeSubscription struct {
+	ID                string            `json:"id"`
+	Customer          string            `json:"customer"`
+	Status            string            `json:"status"`
+	CurrentPeriodEnd  int64             `json:"current_period_end"`
+	CancelAtPeriodEnd bool              `json:"cancel_at_period_end"`
+	Metadata          map[string]string `json:"metadata"`
+	Items             struct {
+		Data []struct {
+			CurrentPeriodEnd int64 `json:"current_period_end"`
+			Price            struct {
+				ID string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// ParseWebhook はStripe-Signatureヘッダーの署名を検証してイベントを返す
+func (g *StripeGateway) ParseWebhook(payload []byte, signature string, now time.Time) (*domain.WebhookEvent, error) {
+	if err := g.verifySignature(payload, signature, now); err != nil {
+		return nil, err
+	}
+
+	var raw stripeEvent
+	if err := json.Unmarshal(payload, &raw); err != nil || raw.ID == "" {
+		return nil, domain.ErrInvalidPayload
+	}
+	event := &domain.WebhookEvent{
+		ID:        raw.ID,
+		Type:      raw.Type,
+		CreatedAt: time.Unix(raw.Created, 0),
+	}
+
+	switch {
+	case event.Type == domain.EventCheckoutCompleted:
+		var session stripeCheckoutSession
+		if err := json.Unmarshal(raw.Data.Object, &session); err != nil {
+			return nil, domain.ErrInvalidPayload
+		}
+		event.UserID = session.ClientReferenceID
+		event.CustomerID = session.Customer
+		event.SubscriptionID = session.Subscription
+		event.PlanID = session.Metadata["plan_id"]
+	case event.IsSubscriptionEvent():
+		var subscription stripeSubscription
+		if err := json.Unmarshal(raw.Data.Object, &subscription); err != nil || subscription.ID == "" {
+			return nil, domain.ErrInvalidPayload
+		}
+		event.UserID = subscription.Metadata["user_id"]
+		event.PlanID = subscription.Metadata["plan_id"]
+		event.CustomerID = subscription.Customer
+		event.SubscriptionID = subscription.ID
+		event.Status = subscription.Status
+		event.CancelAtPeriodEnd = subscription.CancelAtPeriodEnd
+		periodEnd := subscription.CurrentPeriodEnd
+		if len(subscription.Items.Data) > 0 {
+			event.PriceID = subscription.Items.Data[0].Price.ID
+			if periodEnd == 0 {
+				periodEnd = subscription.Items.Data[0].CurrentPeriodEnd
+			}
+		}
+		if periodEnd > 0 {
+			end := time.Unix(periodEnd, 0)
+			event.CurrentPeriodEnd = &end
+		}
+	}
+	return event, nil
+}
+
+// verifySignature は "t=タイムスタンプ,v1=署名" 形式の署名を検証する
+// 署名は "タイムスタンプ.ペイロード" のWebhookシークレットによるHMAC-SHA256（鍵のローテーション中は複数のv1が届く）
+func (g *StripeGateway) verifySignature(payload []byte, header string, now time.Time) error {
+	if g.webhookSecret == "" {
+		return domain.ErrInvalidSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return domain.ErrInvalidSignature
+	}
+	if diff := now.Sub(time.Unix(seconds, 0)); diff > stripeSignatureTolerance || diff < -stripeSignatureTolerance {
+		return domain.ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return domain.ErrInvalidSignature
+}
@@ -0,0 +1,88 @@
+// Package memory は課金リポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+)
+
+// SubscriptionRepository は契約のインメモリリポジトリ
+type SubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*domain.Subscription
+	events        map[string]bool
+}
+
+// NewSubscriptionRepository は新しいSubscriptionRepositoryを作成する
+func NewSubscriptionRepository() *SubscriptionRepository {
+	return &SubscriptionRepository{
+		subscriptions: make(map[string]*domain.Subscription),
+		events:        make(map[string]bool),
+	}
+}
+
+// GetSubscription はユーザーの契約を取得する（存在しない場合は nil, nil）
+func (r *SubscriptionRepository) GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.subscriptions[userID]
+	if !ok {
+		return nil, nil
+	}
+	return copySubscription(subscription), nil
+}
+
+// GetSubscriptionByStripeID はStripeのサブスクリプションIDで契約を取得する（存在しない場合は nil, nil）
+func (r *SubscriptionRepository) GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*domain.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, subscription := range r.subscriptions {
+		if subscription.StripeSubscriptionID == stripeSubscriptionID {
+			return copySubscription(subscription), nil
+		}
+	}
+	return nil, nil
+}
+
+// SaveSubscription は契約を保存する（同じユーザーの契約は置き換える）
+func (r *SubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions[subscription.UserID] = copySubscription(subscription)
+	return nil
+}
+
+// IsEventProcessed はWebhookのイベントを処理済みか
+func (r *SubscriptionRepository) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.events[eventID], nil
+}
+
+// MarkEventProcessed はWebhookのイベントを処理済みにする
+func (r *SubscriptionRepository) MarkEventProcessed(ctx context.Context, eventID, eventType string, processedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[eventID] = true
+	return nil
+}
+
+// copySubscription は呼び出し側の変更が保存済みの契約に影響しないようにコピーする
+func copySubscription(subscription *domain.Subscription) *domain.Subscription {
+	s := *subscription
+	if subscription.CurrentPeriodEnd != nil {
+		end := *subscription.CurrentPeriodEnd
+		s.CurrentPeriodEnd = &end
+	}
+	return &s
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+	"github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+)
+
+// maxWebhookPayloadSize はWebhookのリクエストボディの上限
+const maxWebhookPayloadSize = 1 << 20 // 1MB
+
+// BillingController は課金のHTTPリクエストを処理するコントローラー
+type BillingController struct {
+	billingService *usecase.BillingService
+}
+
+// NewBillingController は新しいBillingControllerを作成する
+func NewBillingController(billingService *usecase.BillingService) *BillingController {
+	return &BillingController{
+		billingService: billingService,
+	}
+}
+
+// ErrorResponse は課金APIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"ALREADY_SUBSCRIBED"`
+	Message string `json:"message" example:"already subscribed to a paid plan"`
+} // @name BillingErrorResponse
+
+// CheckoutRequest はチェックアウト作成のリクエスト
+type CheckoutRequest struct {
+	PlanID string `json:"plan_id" binding:"required" example:"pro"`
+} // @name BillingCheckoutRequest
+
+// PlanListResponse はプラン一覧のレスポンス
+type PlanListResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    []*domain.Plan `json:"data"`
+} // @name BillingPlanListResponse
+
+// SubscriptionResponse は契約の状態のレスポンス
+type SubscriptionResponse struct {
+	Success bool                      `json:"success" example:"true"`
+	Data    *domain.SubscriptionState `json:"data"`
+} // @name BillingSubscriptionResponse
+
+// CheckoutResponse はチェックアウトのレスポンス
+type CheckoutResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    *domain.CheckoutSession `json:"data"`
+} // @name BillingCheckoutResponse
+
+// ListPlans プラン一覧
+// @Summary      プラン一覧
+// @Description  Free・Pro・Teamの各プランの上限と利用できる機能を取得します（limitsに含まれないリソースは無制限）
+// @Tags         billing
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} PlanListResponse "取得成功"
+// @Router       /billing/plans [get]
+func (c *BillingController) ListPlans(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, PlanListResponse{Success: true, Data: c.billingService.ListPlans()})
+}
+
+// GetSubscription 契約の状態
+// @Summary      契約の状態
+// @Description  ログイン中のユーザーが利用できるプランと有料プランの契約の状態を取得します
+// @Tags         billing
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} SubscriptionResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /billing/subscription [get]
+func (c *BillingController) GetSubscription(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	state, err := c.billingService.GetSubscription(ctx, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SubscriptionResponse{Success: true, Data: state})
+}
+
+// CreateCheckout チェックアウトの作成
+// @Summary      チェックアウトの作成
+// @Description  有料プランを購入するStripeのチェックアウトを作成します。返されたURLにリダイレクトして支払いを行い、契約はWebhookで反映されます
+// @Tags         billing
+// @Accept       json
+// @Produce      json
+// @Param        request body CheckoutRequest true "購入するプラン"
+// @Security     BearerAuth
+// @Success      201 {object} CheckoutResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効、または購入できないプラン"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      409 {object} ErrorResponse "有料プランを契約中"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "課金が設定されていない"
+// @Router       /billing/checkout [post]
+func (c *BillingController) CreateCheckout(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	var req CheckoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	session, err := c.billingService.CreateCheckout(ctx, userID, req.PlanID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CheckoutResponse{Success: true, Data: session})
+}
+
+// HandleWebhook StripeのWebhook
+// @Summary      StripeのWebhook
+// @Description  Stripeからのイベント（チェックアウトの完了、サブスクリプションの変更・解約）を受信して契約に反映します。Stripe-Signatureヘッダーの署名を検証します
+// @Tags         billing
+// @Accept       json
+// @Produce      json
+// @Param        Stripe-Signature header string true "Stripeの署名"
+// @Success      200 {object} map[string]bool "受信成功"
+// @Failure      400 {object} ErrorResponse "署名またはペイロードが無効"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー（Stripeが再送する）"
+// @Failure      503 {object} ErrorResponse "課金が設定されていない"
+// @Router       /billing/webhook [post]
+func (c *BillingController) HandleWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	if err := c.billingService.HandleWebhook(ctx, payload, ctx.GetHeader("Stripe-Signature")); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"received": true})
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidParameter),
+		errors.Is(err, domain.ErrUnknownPlan),
+		errors.Is(err, domain.ErrPlanNotPurchasable):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrInvalidSignature), errors.Is(err, domain.ErrInvalidPayload):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "INVALID_WEBHOOK",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrAlreadySubscribed):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+			Success: false,
+			Error:   "ALREADY_SUBSCRIBED",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrBillingDisabled):
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Success: false,
+			Error:   "BILLING_DISABLED",
+			Message: err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to process billing request",
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+	"github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// SubscriptionRepository は契約のデータベースリポジトリ実装
+type SubscriptionRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewSubscriptionRepository は新しいSubscriptionRepositoryを作成する
+func NewSubscriptionRepository(db *sql.DB, logger logger.Logger) usecase.SubscriptionRepository {
+	return &SubscriptionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const subscriptionColumns = `
+	user_id, plan_id, status, stripe_customer_id, stripe_subscription_id,
+	current_period_end, cancel_at_period_end, last_event_at, created_at, updated_at
+`
+
+// GetSubscription はユーザーの契約を取得する（存在しない場合は nil, nil）
+func (r *SubscriptionRepository) GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM billing_subscriptions WHERE user_id = ?`
+
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get subscription", logger.Any("user_id", userID), logger.Error(err))
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscriptionByStripeID はStripeのサブスクリプションIDで契約を取得する（存在しない場合は nil, nil）
+func (r *SubscriptionRepository) GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM billing_subscriptions WHERE stripe_subscription_id = ?`
+
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, query, stripeSubscriptionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get subscription by stripe id",
+			logger.Any("stripe_subscription_id", stripeSubscriptionID), logger.Error(err))
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// SaveSubscription は契約を保存する（同じユーザーの契約は置き換える）
+func (r *SubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain.Subscription) error {
+	query := `
+		INSERT INTO billing_subscriptions (` + subscriptionColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			plan_id = VALUES(plan_id),
+			status = VALUES(status),
+			stripe_customer_id = VALUES(stripe_customer_id),
+			stripe_subscription_id = VALUES(stripe_subscription_id),
+			current_period_end = VALUES(current_period_end),
+			cancel_at_period_end = VALUES(cancel_at_period_end),
+			last_event_at = VALUES(last_event_at),
+			updated_at = VALUES(updated_at)
+	`
+
+	var stripeSubscriptionID sql.NullString
+	if subscription.StripeSubscriptionID != "" {
+		stripeSubscriptionID = sql.NullString{String: subscription.StripeSubscriptionID, Valid: true}
+	}
+	var currentPeriodEnd, lastEventAt sql.NullTime
+	if subscription.CurrentPeriodEnd != nil {
+		currentPeriodEnd = sql.NullTime{Time: *subscription.CurrentPeriodEnd, Valid: true}
+	}
+	if !subscription.LastEventAt.IsZero() {
+		lastEventAt = sql.NullTime{Time: subscription.LastEventAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.UserID,
+		subscription.PlanID,
+		subscription.Status,
+		subscription.StripeCustomerID,
+		stripeSubscriptionID,
+		currentPeriodEnd,
+		subscription.CancelAtPeriodEnd,
+		lastEventAt,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save subscription", logger.Any("user_id", subscription.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save subscription: %w", err)
+	}
+	return nil
+}
+
+// IsEventProcessed はWebhookのイベントを処理済みか
+func (r *SubscriptionRepository) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM billing_events WHERE event_id = ?`, eventID).Scan(&count)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to check billing event", logger.Any("event_id", eventID), logger.Error(err))
+		return false, fmt.Errorf("failed to check billing event: %w", err)
+	}
+	return count > 0, nil
+}
+
+// MarkEventProcessed はWebhookのイベントを処理済みにする（処理済みの場合は何もしない）
+func (r *SubscriptionRepository) MarkEventProcessed(ctx context.Context, eventID, eventType string, processedAt time.Time) error {
+	query := `INSERT IGNORE INTO billing_events (event_id, event_type, processed_at) VALUES (?, ?, ?)`
+
+	if _, err := r.db.ExecContext(ctx, query, eventID, eventType, processedAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to record billing event", logger.Any("event_id", eventID), logger.Error(err))
+		return fmt.Errorf("failed to record billing event: %w", err)
+	}
+	return nil
+}
+
+// scanSubscription は1行を契約に変換する
+func scanSubscription(row *sql.Row) (*domain.Subscription, error) {
+	var subscription domain.Subscription
+	var stripeSubscriptionID sql.NullString
+	var currentPeriodEnd, lastEventAt sql.NullTime
+	err := row.Scan(
+		&subscription.UserID,
+		&subscription.PlanID,
+		&subscription.Status,
+		&subscription.StripeCustomerID,
+		&stripeSubscriptionID,
+		&currentPeriodEnd,
+		&subscription.CancelAtPeriodEnd,
+		&lastEventAt,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan subscription: %w", err)
+	}
+	subscription.StripeSubscriptionID = stripeSubscriptionID.String
+	if currentPeriodEnd.Valid {
+		subscription.CurrentPeriodEnd = &currentPeriodEnd.Time
+	}
+	if lastEventAt.Valid {
+		subscription.LastEventAt = lastEventAt.Time
+	}
+	return &subscription, nil
+}
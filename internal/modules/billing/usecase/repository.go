@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+)
+
+// SubscriptionRepository は契約とWebhookの処理済みイベントのリポジトリインターフェース
+type SubscriptionRepository interface {
+	// GetSubscription はユーザーの契約を取得する（存在しない場合は nil, nil）
+	GetSubscription(ctx context.Context, userID string) (*domain.Subscription, error)
+	// GetSubscriptionByStripeID はStripeのサブスクリプションIDで契約を取得する（存在しない場合は nil, nil）
+	GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*domain.Subscription, error)
+	// SaveSubscription は契約を保存する（同じユーザーの契約は置き換える）
+	SaveSubscription(ctx context.Context, subscription *domain.Subscription) error
+	// IsEventProcessed はWebhookのイベントを処理済みか
+	IsEventProcessed(ctx context.Context, eventID string) (bool, error)
+	// MarkEventProcessed はWebhookのイベントを処理済みにする（処理済みの場合は何もしない）
+	MarkEventProcessed(ctx context.Context, eventID, eventType string, processedAt time.Time) error
+}
+
+// PaymentGateway は決済サービス（Stripe）のゲートウェイインターフェース
+type PaymentGateway interface {
+	CreateCheckoutSession(ctx context.Context, input domain.CheckoutInput) (*domain.CheckoutSession, error)
+	// ParseWebhook はWebhookの署名を検証してイベントを返す（不正な場合はdomain.ErrInvalidSignature）
+	ParseWebhook(payload []byte, signature string, now time.Time) (*domain.WebhookEvent, error)
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// CheckoutSettings はチェックアウトの設定
+type CheckoutSettings struct {
+	// プランIDごとのStripeの価格ID（価格のないプランは購入できない）
+	Prices map[string]string
+	// 支払い完了・中止後にリダイレクトするURL
+	SuccessURL string
+	CancelURL  string
+}
+
+// BillingService は契約プランと有料プランの購入を扱うサービス
+// 契約の状態はStripeのWebhookで同期する（チェックアウト後のリダイレクトでは変更しない）
+type BillingService struct {
+	Repository SubscriptionRepository
+	// 決済サービス（nilの場合はチェックアウトとWebhookを受け付けない）
+	Gateway  PaymentGateway
+	Settings CheckoutSettings
+	Logger   logger.Logger
+}
+
+// NewBillingService はBillingServiceのコンストラクタ
+func NewBillingService(
+	repository SubscriptionRepository,
+	gateway PaymentGateway,
+	settings CheckoutSettings,
+	logger logger.Logger,
+) *BillingService {
+	return &BillingService{
+		Repository: repository,
+		Gateway:    gateway,
+		Settings:   settings,
+		Logger:     logger,
+	}
+}
+
+// ListPlans はプランの定義を取得する
+func (s *BillingService) ListPlans() []*domain.Plan {
+	plans := domain.Plans()
+	for _, plan := range plans {
+		plan.Purchasable = s.isPurchasable(plan)
+	}
+	return plans
+}
+
+// GetSubscription はユーザーが利用できるプランと契約の状態を取得する
+func (s *BillingService) GetSubscription(ctx context.Context, userID string) (*domain.SubscriptionState, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	subscription, err := s.Repository.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	plan, err := domain.PlanByID(subscription.EffectivePlanID())
+	if err != nil {
+		return nil, err
+	}
+	plan.Purchasable = s.isPurchasable(plan)
+	return &domain.SubscriptionState{Plan: plan, Subscription: subscription}, nil
+}
+
+// EffectivePlan はユーザーが利用できるプランを取得する（契約が有効でない場合はFree）
+func (s *BillingService) EffectivePlan(ctx context.Context, userID string) (*domain.Plan, error) {
+	subscription, err := s.Repository.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	return domain.PlanByID(subscription.EffectivePlanID())
+}
+
+// CreateCheckout は有料プランを購入するチェックアウトを作成する
+// 有料プランを契約中の場合はErrAlreadySubscribed（プランの変更・解約はStripeで行う）
+func (s *BillingService) CreateCheckout(ctx context.Context, userID, planID string) (*domain.CheckoutSession, error) {
+	if userID == "" || planID == "" {
+		return nil, ErrInvalidParameter
+	}
+	if s.Gateway == nil {
+		return nil, domain.ErrBillingDisabled
+	}
+
+	plan, err := domain.PlanByID(planID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isPurchasable(plan) {
+		return nil, domain.ErrPlanNotPurchasable
+	}
+
+	subscription, err := s.Repository.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription.IsActive() {
+		return nil, domain.ErrAlreadySubscribed
+	}
+
+	input := domain.CheckoutInput{
+		UserID:     userID,
+		PlanID:     plan.ID,
+		PriceID:    s.Settings.Prices[plan.ID],
+		SuccessURL: s.Settings.SuccessURL,
+		CancelURL:  s.Settings.CancelURL,
+	}
+	if subscription != nil {
+		input.CustomerID = subscription.StripeCustomerID
+	}
+
+	session, err := s.Gateway.CreateCheckoutSession(ctx, input)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create checkout session",
+			logger.Any("user_id", userID), logger.Any("plan_id", plan.ID), logger.Error(err))
+		return nil, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+	return session, nil
+}
+
+// HandleWebhook はStripeのWebhookを検証して契約に反映する
+// 同じイベントは一度だけ反映し、反映に失敗した場合はエラーを返してStripeに再送させる
+func (s *BillingService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	if s.Gateway == nil {
+		return domain.ErrBillingDisabled
+	}
+
+	event, err := s.Gateway.ParseWebhook(payload, signature, time.Now())
+	if err != nil {
+		return err
+	}
+
+	processed, err := s.Repository.IsEventProcessed(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check webhook event: %w", err)
+	}
+	if processed {
+		return nil
+	}
+
+	switch {
+	case event.Type == domain.EventCheckoutCompleted:
+		err = s.applyCheckout(ctx, event)
+	case event.IsSubscriptionEvent():
+		err = s.applySubscription(ctx, event)
+	}
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to apply webhook event",
+			logger.Any("event_id", event.ID), logger.Any("event_type", event.Type), logger.Error(err))
+		return err
+	}
+
+	if err := s.Repository.MarkEventProcessed(ctx, event.ID, event.Type, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark webhook event as processed: %w", err)
+	}
+	return nil
+}
+
+// applyCheckout はチェックアウトの完了をユーザーとStripeのサブスクリプションに紐付ける
+func (s *BillingService) applyCheckout(ctx context.Context, event *domain.WebhookEvent) error {
+	if event.UserID == "" || event.SubscriptionID == "" {
+		s.Logger.WithContext(ctx).Warn("Ignoring checkout without a user or subscription",
+			logger.Any("event_id", event.ID))
+		return nil
+	}
+
+	subscription, err := s.Repository.GetSubscription(ctx, event.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+	now := time.Now()
+	if subscription == nil {
+		subscription = &domain.Subscription{UserID: event.UserID, CreatedAt: now}
+	}
+	// サブスクリプションのイベントが先に届いている場合は、その状態を残す
+	if subscription.StripeSubscriptionID != event.SubscriptionID {
+		resetSubscription(subscription, event.SubscriptionID)
+		subscription.Status = domain.StatusActive
+	}
+	if _, err := domain.PlanByID(event.PlanID); err == nil {
+		subscription.PlanID = event.PlanID
+	}
+	if event.CustomerID != "" {
+		subscription.StripeCustomerID = event.CustomerID
+	}
+	subscription.UpdatedAt = now
+
+	if err := s.Repository.SaveSubscription(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to save subscription: %w", err)
+	}
+	s.Logger.WithContext(ctx).Info("Checkout completed",
+		logger.Any("user_id", subscription.UserID), logger.Any("plan_id", subscription.PlanID))
+	return nil
+}
+
+// applySubscription はStripeのサブスクリプションの状態を契約に反映する
+func (s *BillingService) applySubscription(ctx context.Context, event *domain.WebhookEvent) error {
+	subscription, err := s.Repository.GetSubscriptionByStripeID(ctx, event.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription == nil && event.UserID != "" {
+		if subscription, err = s.Repository.GetSubscription(ctx, event.UserID); err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if subscription == nil {
+			subscription = &domain.Subscription{UserID: event.UserID, CreatedAt: time.Now()}
+		}
+		if subscription.StripeSubscriptionID != event.SubscriptionID {
+			// 有効な契約を、別のサブスクリプションの解約などで上書きしない
+			if subscription.IsActive() && !domain.IsActiveStatus(event.Status) {
+				s.Logger.WithContext(ctx).Warn("Ignoring inactive subscription event for a user with another active subscription",
+					logger.Any("event_id", event.ID), logger.Any("user_id", event.UserID))
+				return nil
+			}
+			resetSubscription(subscription, event.SubscriptionID)
+		}
+	}
+	if subscription == nil {
+		s.Logger.WithContext(ctx).Warn("Ignoring event for an unknown subscription",
+			logger.Any("event_id", event.ID), logger.Any("event_type", event.Type))
+		return nil
+	}
+	if event.CreatedAt.Before(subscription.LastEventAt) {
+		return nil
+	}
+
+	if planID := s.planForPrice(event.PriceID); planID != "" {
+		subscription.PlanID = planID
+	} else if _, err := domain.PlanByID(event.PlanID); err == nil {
+		subscription.PlanID = event.PlanID
+	}
+	subscription.Status = event.Status
+	if event.Type == domain.EventSubscriptionDeleted {
+		subscription.Status = domain.StatusCanceled
+	}
+	if event.CustomerID != "" {
+		subscription.StripeCustomerID = event.CustomerID
+	}
+	subscription.CurrentPeriodEnd = event.CurrentPeriodEnd
+	subscription.CancelAtPeriodEnd = event.CancelAtPeriodEnd
+	subscription.LastEventAt = event.CreatedAt
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.Repository.SaveSubscription(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to save subscription: %w", err)
+	}
+	s.Logger.WithContext(ctx).Info("Subscription updated",
+		logger.Any("user_id", subscription.UserID), logger.Any("plan_id", subscription.PlanID),
+		logger.Any("status", subscription.Status))
+	return nil
+}
+
+// isPurchasable はプランをチェックアウトで購入できるか
+func (s *BillingService) isPurchasable(plan *domain.Plan) bool {
+	return s.Gateway != nil && plan.IsPaid() && s.Settings.Prices[plan.ID] != ""
+}
+
+// planForPrice はStripeの価格IDに対応するプランのIDを返す（設定にない価格の場合は空文字）
+func (s *BillingService) planForPrice(priceID string) string {
+	if priceID == "" {
+		return ""
+	}
+	for planID, price := range s.Settings.Prices {
+		if price == priceID {
+			return planID
+		}
+	}
+	return ""
+}
+
+// resetSubscription は契約を新しいStripeのサブスクリプションに切り替える
+func resetSubscription(subscription *domain.Subscription, stripeSubscriptionID string) {
+	subscription.StripeSubscriptionID = stripeSubscriptionID
+	subscription.Status = ""
+	subscription.CurrentPeriodEnd = nil
+	subscription.CancelAtPeriodEnd = false
+	subscription.LastEventAt = time.Time{}
+}
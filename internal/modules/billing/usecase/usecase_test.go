@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/billing/domain"
+	"github.com/hryt430/Yotei+/internal/modules/billing/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testSettings = CheckoutSettings{
+	Prices:     map[string]string{domain.PlanPro: "price_pro", domain.PlanTeam: "price_team"},
+	SuccessURL: "https://app.example.com/billing/success",
+	CancelURL:  "https://app.example.com/billing/cancel",
+}
+
+func newTestService(t *testing.T) (*BillingService, *mocks.MockSubscriptionRepository, *mocks.MockPaymentGateway) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionRepository(ctrl)
+	gateway := mocks.NewMockPaymentGateway(ctrl)
+	service := NewBillingService(repo, gateway, testSettings, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	return service, repo, gateway
+}
+
+func TestBillingService_GetSubscription(t *testing.T) {
+	t.Run("users without a subscription are on the free plan", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(nil, nil)
+
+		state, err := service.GetSubscription(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.PlanFree, state.Plan.ID)
+		assert.Nil(t, state.Subscription)
+	})
+
+	t.Run("canceled subscriptions fall back to the free plan", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		subscription := &domain.Subscription{UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusCanceled}
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(subscription, nil)
+
+		state, err := service.GetSubscription(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.PlanFree, state.Plan.ID)
+		assert.Equal(t, subscription, state.Subscription)
+	})
+}
+
+func TestBillingService_ListPlans(t *testing.T) {
+	service, _, _ := newTestService(t)
+	service.Settings.Prices = map[string]string{domain.PlanPro: "price_pro"}
+
+	purchasable := map[string]bool{}
+	for _, plan := range service.ListPlans() {
+		purchasable[plan.ID] = plan.Purchasable
+	}
+	assert.Equal(t, map[string]bool{domain.PlanFree: false, domain.PlanPro: true, domain.PlanTeam: false}, purchasable)
+}
+
+func TestBillingService_CreateCheckout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates a checkout for the plan price", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusCanceled, StripeCustomerID: "cus_1",
+		}, nil)
+		gateway.EXPECT().CreateCheckoutSession(gomock.Any(), domain.CheckoutInput{
+			UserID:     "user-1",
+			PlanID:     domain.PlanTeam,
+			PriceID:    "price_team",
+			CustomerID: "cus_1",
+			SuccessURL: testSettings.SuccessURL,
+			CancelURL:  testSettings.CancelURL,
+		}).Return(&domain.CheckoutSession{ID: "cs_1", URL: "https://checkout.stripe.com/c/pay/cs_1"}, nil)
+
+		session, err := service.CreateCheckout(ctx, "user-1", domain.PlanTeam)
+		require.NoError(t, err)
+		assert.Equal(t, "cs_1", session.ID)
+	})
+
+	t.Run("active subscribers cannot check out again", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusActive,
+		}, nil)
+
+		_, err := service.CreateCheckout(ctx, "user-1", domain.PlanTeam)
+		assert.ErrorIs(t, err, domain.ErrAlreadySubscribed)
+	})
+
+	t.Run("the free plan cannot be purchased", func(t *testing.T) {
+		service, _, _ := newTestService(t)
+
+		_, err := service.CreateCheckout(ctx, "user-1", domain.PlanFree)
+		assert.ErrorIs(t, err, domain.ErrPlanNotPurchasable)
+	})
+
+	t.Run("unknown plans are rejected", func(t *testing.T) {
+		service, _, _ := newTestService(t)
+
+		_, err := service.CreateCheckout(ctx, "user-1", "enterprise")
+		assert.ErrorIs(t, err, domain.ErrUnknownPlan)
+	})
+
+	t.Run("requires a payment gateway", func(t *testing.T) {
+		service, _, _ := newTestService(t)
+		service.Gateway = nil
+
+		_, err := service.CreateCheckout(ctx, "user-1", domain.PlanPro)
+		assert.ErrorIs(t, err, domain.ErrBillingDisabled)
+	})
+}
+
+func TestBillingService_HandleWebhook(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{}`)
+	eventAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	periodEnd := eventAt.AddDate(0, 1, 0)
+
+	expectEvent := func(gateway *mocks.MockPaymentGateway, repo *mocks.MockSubscriptionRepository, event *domain.WebhookEvent) {
+		gateway.EXPECT().ParseWebhook(payload, "sig", gomock.Any()).Return(event, nil)
+		repo.EXPECT().IsEventProcessed(gomock.Any(), event.ID).Return(false, nil)
+	}
+
+	t.Run("invalid signatures are rejected", func(t *testing.T) {
+		service, _, gateway := newTestService(t)
+		gateway.EXPECT().ParseWebhook(payload, "sig", gomock.Any()).Return(nil, domain.ErrInvalidSignature)
+
+		assert.ErrorIs(t, service.HandleWebhook(ctx, payload, "sig"), domain.ErrInvalidSignature)
+	})
+
+	t.Run("processed events are ignored", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		gateway.EXPECT().ParseWebhook(payload, "sig", gomock.Any()).Return(&domain.WebhookEvent{ID: "evt_1", Type: domain.EventSubscriptionDeleted}, nil)
+		repo.EXPECT().IsEventProcessed(gomock.Any(), "evt_1").Return(true, nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("completed checkouts activate the purchased plan", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_1", Type: domain.EventCheckoutCompleted, CreatedAt: eventAt,
+			UserID: "user-1", CustomerID: "cus_1", SubscriptionID: "sub_1", PlanID: domain.PlanPro,
+		})
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(nil, nil)
+		repo.EXPECT().SaveSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *domain.Subscription) error {
+			assert.Equal(t, "user-1", s.UserID)
+			assert.Equal(t, domain.PlanPro, s.PlanID)
+			assert.Equal(t, domain.StatusActive, s.Status)
+			assert.Equal(t, "cus_1", s.StripeCustomerID)
+			assert.Equal(t, "sub_1", s.StripeSubscriptionID)
+			return nil
+		})
+		repo.EXPECT().MarkEventProcessed(gomock.Any(), "evt_1", domain.EventCheckoutCompleted, gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("checkouts keep the state of earlier subscription events", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_2", Type: domain.EventCheckoutCompleted, UserID: "user-1", SubscriptionID: "sub_1", PlanID: domain.PlanPro,
+		})
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusTrialing, StripeSubscriptionID: "sub_1", LastEventAt: eventAt,
+		}, nil)
+		repo.EXPECT().SaveSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *domain.Subscription) error {
+			assert.Equal(t, domain.StatusTrialing, s.Status)
+			assert.Equal(t, eventAt, s.LastEventAt)
+			return nil
+		})
+		repo.EXPECT().MarkEventProcessed(gomock.Any(), "evt_2", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("subscription updates map the price to a plan", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_3", Type: domain.EventSubscriptionUpdated, CreatedAt: eventAt,
+			SubscriptionID: "sub_1", PriceID: "price_team", Status: domain.StatusActive,
+			CurrentPeriodEnd: &periodEnd, CancelAtPeriodEnd: true,
+		})
+		repo.EXPECT().GetSubscriptionByStripeID(gomock.Any(), "sub_1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusActive, StripeSubscriptionID: "sub_1",
+		}, nil)
+		repo.EXPECT().SaveSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *domain.Subscription) error {
+			assert.Equal(t, domain.PlanTeam, s.PlanID)
+			assert.Equal(t, &periodEnd, s.CurrentPeriodEnd)
+			assert.True(t, s.CancelAtPeriodEnd)
+			assert.Equal(t, eventAt, s.LastEventAt)
+			return nil
+		})
+		repo.EXPECT().MarkEventProcessed(gomock.Any(), "evt_3", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("deleted subscriptions are canceled", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_4", Type: domain.EventSubscriptionDeleted, CreatedAt: eventAt, SubscriptionID: "sub_1",
+		})
+		repo.EXPECT().GetSubscriptionByStripeID(gomock.Any(), "sub_1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusActive, StripeSubscriptionID: "sub_1",
+		}, nil)
+		repo.EXPECT().SaveSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *domain.Subscription) error {
+			assert.Equal(t, domain.StatusCanceled, s.Status)
+			assert.Equal(t, domain.PlanFree, s.EffectivePlanID())
+			return nil
+		})
+		repo.EXPECT().MarkEventProcessed(gomock.Any(), "evt_4", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("events older than the last applied one are ignored", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_5", Type: domain.EventSubscriptionUpdated, CreatedAt: eventAt.Add(-time.Minute),
+			SubscriptionID: "sub_1", Status: domain.StatusIncomplete,
+		})
+		repo.EXPECT().GetSubscriptionByStripeID(gomock.Any(), "sub_1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusActive, StripeSubscriptionID: "sub_1", LastEventAt: eventAt,
+		}, nil)
+		repo.EXPECT().MarkEventProcessed(gomock.Any(), "evt_5", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("other subscriptions do not cancel an active one", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_6", Type: domain.EventSubscriptionDeleted, CreatedAt: eventAt, UserID: "user-1", SubscriptionID: "sub_old",
+		})
+		repo.EXPECT().GetSubscriptionByStripeID(gomock.Any(), "sub_old").Return(nil, nil)
+		repo.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(&domain.Subscription{
+			UserID: "user-1", PlanID: domain.PlanPro, Status: domain.StatusActive, StripeSubscriptionID: "sub_1",
+		}, nil)
+		repo.EXPECT().MarkEventProcessed(gomock.Any(), "evt_6", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+
+	t.Run("failures are returned so that the event is retried", func(t *testing.T) {
+		service, repo, gateway := newTestService(t)
+		expectEvent(gateway, repo, &domain.WebhookEvent{
+			ID: "evt_7", Type: domain.EventSubscriptionUpdated, CreatedAt: eventAt, SubscriptionID: "sub_1", Status: domain.StatusActive,
+		})
+		repo.EXPECT().GetSubscriptionByStripeID(gomock.Any(), "sub_1").Return(nil, errors.New("db down"))
+
+		assert.Error(t, service.HandleWebhook(ctx, payload, "sig"))
+	})
+}
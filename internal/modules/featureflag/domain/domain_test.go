@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlag_IsEnabledFor(t *testing.T) {
+	t.Run("disabled flags are off for everyone", func(t *testing.T) {
+		flag := &Flag{Key: "beta", Enabled: false, Percentage: 100, Users: []string{"user-1"}}
+		assert.False(t, flag.IsEnabledFor("user-1"))
+	})
+
+	t.Run("listed users are on regardless of the percentage", func(t *testing.T) {
+		flag := &Flag{Key: "beta", Enabled: true, Users: []string{"user-1"}}
+		assert.True(t, flag.IsEnabledFor("user-1"))
+		assert.False(t, flag.IsEnabledFor("user-2"))
+	})
+
+	t.Run("full rollout includes anonymous callers", func(t *testing.T) {
+		flag := &Flag{Key: "beta", Enabled: true, Percentage: 100}
+		assert.True(t, flag.IsEnabledFor(""))
+	})
+
+	t.Run("percentage rollout is stable and roughly proportional", func(t *testing.T) {
+		flag := &Flag{Key: "beta", Enabled: true, Percentage: 30}
+		enabled := 0
+		for i := 0; i < 1000; i++ {
+			userID := fmt.Sprintf("user-%d", i)
+			result := flag.IsEnabledFor(userID)
+			assert.Equal(t, result, flag.IsEnabledFor(userID))
+			if result {
+				enabled++
+			}
+		}
+		assert.InDelta(t, 300, enabled, 60)
+	})
+
+	t.Run("raising the percentage keeps users already enabled", func(t *testing.T) {
+		low := &Flag{Key: "beta", Enabled: true, Percentage: 20}
+		high := &Flag{Key: "beta", Enabled: true, Percentage: 60}
+		for i := 0; i < 200; i++ {
+			userID := fmt.Sprintf("user-%d", i)
+			if low.IsEnabledFor(userID) {
+				assert.True(t, high.IsEnabledFor(userID))
+			}
+		}
+	})
+}
+
+func TestFlag_SetUsers(t *testing.T) {
+	flag := &Flag{Key: "beta"}
+	flag.SetUsers([]string{"user-1", " ", "user-2", "user-1"})
+	assert.Equal(t, []string{"user-1", "user-2"}, flag.Users)
+}
+
+func TestFlag_SetPercentage(t *testing.T) {
+	flag := &Flag{Key: "beta"}
+	assert.NoError(t, flag.SetPercentage(50))
+	assert.ErrorIs(t, flag.SetPercentage(101), ErrInvalidRolloutPercent)
+	assert.ErrorIs(t, flag.SetPercentage(-1), ErrInvalidRolloutPercent)
+	assert.Equal(t, 50, flag.Percentage)
+}
+
+func TestParseFlagDefaults(t *testing.T) {
+	flags, err := ParseFlagDefaults("task_history=on, new_editor=25%, beta=off")
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+	assert.True(t, flags["task_history"].Enabled)
+	assert.Equal(t, 100, flags["task_history"].Percentage)
+	assert.True(t, flags["new_editor"].Enabled)
+	assert.Equal(t, 25, flags["new_editor"].Percentage)
+	assert.False(t, flags["beta"].Enabled)
+	assert.Equal(t, SourceConfig, flags["beta"].Source)
+
+	empty, err := ParseFlagDefaults("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = ParseFlagDefaults("task_history")
+	assert.Error(t, err)
+	_, err = ParseFlagDefaults("task_history=sometimes")
+	assert.Error(t, err)
+	_, err = ParseFlagDefaults("Task History=on")
+	assert.Error(t, err)
+}
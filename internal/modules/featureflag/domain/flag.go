@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// フラグの設定元
+const (
+	SourceConfig   = "config"   // 環境変数 FEATURE_FLAGS の既定値
+	SourceDatabase = "database" // 管理者が変更した値
+)
+
+// MaxRolloutPercentage は全ユーザーに公開する段階的公開の割合
+const MaxRolloutPercentage = 100
+
+var (
+	ErrInvalidFlagKey        = errors.New("flag key must be 1-64 characters of lowercase letters, digits, '_', '.' or '-'")
+	ErrInvalidRolloutPercent = errors.New("rollout percentage must be between 0 and 100")
+)
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// Flag は機能フラグを表す
+// Enabledが全体のスイッチで、無効の場合は誰にも公開しない
+// 有効の場合は、Usersに含まれるユーザーと、段階的公開の割合に含まれるユーザーに公開する
+type Flag struct {
+	Key         string    `json:"key" example:"task_history"`
+	Description string    `json:"description" example:"タスクの変更履歴の再生"`
+	Enabled     bool      `json:"enabled" example:"true"`
+	Percentage  int       `json:"percentage" example:"25"`                              // 段階的公開の割合（0〜100）
+	Users       []string  `json:"users" example:"123e4567-e89b-12d3-a456-426614174000"` // 割合に関係なく公開するユーザー
+	Source      string    `json:"source" example:"database"`
+	UpdatedAt   time.Time `json:"updated_at"`
+} // @name FeatureFlag
+
+// NewFlag は全ユーザーに公開しない状態の新しいフラグを作成する
+func NewFlag(key string) (*Flag, error) {
+	if err := ValidateFlagKey(key); err != nil {
+		return nil, err
+	}
+	return &Flag{
+		Key:       key,
+		Users:     []string{},
+		Source:    SourceDatabase,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// ValidateFlagKey はフラグのキーの形式を検証する
+func ValidateFlagKey(key string) error {
+	if !flagKeyPattern.MatchString(key) {
+		return ErrInvalidFlagKey
+	}
+	return nil
+}
+
+// SetPercentage は段階的公開の割合を設定する
+func (f *Flag) SetPercentage(percentage int) error {
+	if percentage < 0 || percentage > MaxRolloutPercentage {
+		return ErrInvalidRolloutPercent
+	}
+	f.Percentage = percentage
+	return nil
+}
+
+// SetUsers は割合に関係なく公開するユーザーを設定する（空のIDと重複は除く）
+func (f *Flag) SetUsers(userIDs []string) {
+	seen := make(map[string]bool, len(userIDs))
+	users := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		userID = strings.TrimSpace(userID)
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		users = append(users, userID)
+	}
+	f.Users = users
+}
+
+// IsEnabledFor はユーザーにフラグの機能を公開するかを判定する
+// 段階的公開の判定はキーとユーザーIDのハッシュで行うため、同じユーザーには常に同じ結果になり、
+// 割合を増やしても既に公開されたユーザーは公開されたままになる
+func (f *Flag) IsEnabledFor(userID string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if f.Percentage >= MaxRolloutPercentage {
+		return true
+	}
+	if userID == "" {
+		return false
+	}
+	for _, id := range f.Users {
+		if id == userID {
+			return true
+		}
+	}
+	return RolloutBucket(f.Key, userID) < f.Percentage
+}
+
+// RolloutBucket はユーザーの段階的公開のバケット（0〜99）を返す
+func RolloutBucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % MaxRolloutPercentage)
+}
+
+// ParseFlagDefaults は "task_history=on,new_editor=25%,beta=off" 形式の設定を解析する
+// on は全ユーザー、割合は段階的公開、off は無効として扱う
+func ParseFlagDefaults(spec string) (map[string]*Flag, error) {
+	flags := make(map[string]*Flag)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || ValidateFlagKey(key) != nil {
+			return nil, fmt.Errorf("invalid feature flag entry: %q", entry)
+		}
+
+		flag := &Flag{Key: key, Users: []string{}, Source: SourceConfig}
+		switch value = strings.ToLower(strings.TrimSpace(value)); value {
+		case "on", "true":
+			flag.Enabled = true
+			flag.Percentage = MaxRolloutPercentage
+		case "off", "false":
+		default:
+			percentage, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+			if err != nil || flag.SetPercentage(percentage) != nil {
+				return nil, fmt.Errorf("invalid feature flag value for %s: %q", key, value)
+			}
+			flag.Enabled = true
+		}
+		flags[key] = flag
+	}
+	return flags, nil
+}
@@ -0,0 +1,68 @@
+// Package memory は機能フラグリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+)
+
+// FlagRepository は機能フラグのインメモリリポジトリ
+type FlagRepository struct {
+	mu    sync.RWMutex
+	flags map[string]*domain.Flag
+}
+
+// NewFlagRepository は新しいFlagRepositoryを作成する
+func NewFlagRepository() *FlagRepository {
+	return &FlagRepository{
+		flags: make(map[string]*domain.Flag),
+	}
+}
+
+// ListFlags は全てのフラグをキー順に取得する
+func (r *FlagRepository) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flags := make([]*domain.Flag, 0, len(r.flags))
+	for _, flag := range r.flags {
+		flags = append(flags, copyFlag(flag))
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Key < flags[j].Key
+	})
+	return flags, nil
+}
+
+// GetFlag はキーでフラグを取得する（存在しない場合は nil, nil）
+func (r *FlagRepository) GetFlag(ctx context.Context, key string) (*domain.Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flag, ok := r.flags[key]
+	if !ok {
+		return nil, nil
+	}
+	return copyFlag(flag), nil
+}
+
+// SaveFlag はフラグを保存する（同じキーのフラグは置き換える）
+func (r *FlagRepository) SaveFlag(ctx context.Context, flag *domain.Flag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.flags[flag.Key] = copyFlag(flag)
+	return nil
+}
+
+// copyFlag は呼び出し側の変更が保存済みのフラグに影響しないようにコピーする
+func copyFlag(flag *domain.Flag) *domain.Flag {
+	f := *flag
+	f.Users = append([]string{}, flag.Users...)
+	return &f
+}
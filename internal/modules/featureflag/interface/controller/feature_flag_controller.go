@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/usecase"
+)
+
+// FeatureFlagController は機能フラグのHTTPリクエストを処理するコントローラー
+type FeatureFlagController struct {
+	featureFlagService *usecase.FeatureFlagService
+}
+
+// NewFeatureFlagController は新しいFeatureFlagControllerを作成する
+func NewFeatureFlagController(featureFlagService *usecase.FeatureFlagService) *FeatureFlagController {
+	return &FeatureFlagController{
+		featureFlagService: featureFlagService,
+	}
+}
+
+// ErrorResponse は機能フラグAPIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"REQUEST_ERROR"`
+	Message string `json:"message" example:"rollout percentage must be between 0 and 100"`
+} // @name FeatureFlagErrorResponse
+
+// UpdateFlagRequest はフラグの変更リクエスト（省略した項目は変更しない）
+type UpdateFlagRequest struct {
+	Description *string  `json:"description" example:"タスクの変更履歴の再生"`
+	Enabled     *bool    `json:"enabled" example:"true"`
+	Percentage  *int     `json:"percentage" example:"25"`
+	Users       []string `json:"users" example:"123e4567-e89b-12d3-a456-426614174000"` // 空の配列でユーザーの指定を解除する
+} // @name UpdateFeatureFlagRequest
+
+// FlagResponse はフラグのレスポンス
+type FlagResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Data    domain.Flag `json:"data"`
+} // @name FeatureFlagResponse
+
+// FlagListResponse はフラグ一覧のレスポンス
+type FlagListResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    []domain.Flag `json:"data"`
+} // @name FeatureFlagListResponse
+
+// UserFeaturesResponse はユーザーに対するフラグの判定結果のレスポンス
+type UserFeaturesResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    map[string]bool `json:"data"`
+} // @name UserFeaturesResponse
+
+// ListFlags 機能フラグ一覧
+// @Summary      機能フラグ一覧
+// @Description  環境変数の既定値と管理者が変更した値を合わせた機能フラグの一覧を取得します（管理者のみ）
+// @Tags         features
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} FlagListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/features [get]
+func (c *FeatureFlagController) ListFlags(ctx *gin.Context) {
+	flags, err := c.featureFlagService.ListFlags(ctx)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	data := make([]domain.Flag, 0, len(flags))
+	for _, flag := range flags {
+		data = append(data, *flag)
+	}
+	ctx.JSON(http.StatusOK, FlagListResponse{Success: true, Data: data})
+}
+
+// UpdateFlag 機能フラグの変更
+// @Summary      機能フラグの変更
+// @Description  機能フラグの有効・無効、段階的公開の割合、公開するユーザーを変更します。存在しないキーを指定した場合は新しいフラグを作成します（管理者のみ）
+// @Tags         features
+// @Accept       json
+// @Produce      json
+// @Param        key path string true "フラグのキー"
+// @Param        request body UpdateFlagRequest true "変更内容"
+// @Security     BearerAuth
+// @Success      200 {object} FlagResponse "変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/features/{key} [put]
+func (c *FeatureFlagController) UpdateFlag(ctx *gin.Context) {
+	var req UpdateFlagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	flag, err := c.featureFlagService.UpdateFlag(ctx, ctx.Param("key"), usecase.UpdateFlagInput{
+		Description: req.Description,
+		Enabled:     req.Enabled,
+		Percentage:  req.Percentage,
+		Users:       req.Users,
+	})
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, FlagResponse{Success: true, Data: *flag})
+}
+
+// GetMyFeatures 自分に公開されている機能
+// @Summary      自分に公開されている機能
+// @Description  全ての機能フラグについて、ログイン中のユーザーに公開されているかを取得します（クライアントの表示切り替え用）
+// @Tags         features
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} UserFeaturesResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/features [get]
+func (c *FeatureFlagController) GetMyFeatures(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	features, err := c.featureFlagService.GetUserFeatures(ctx, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, UserFeaturesResponse{Success: true, Data: features})
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidParameter) {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+		Success: false,
+		Error:   "INTERNAL_ERROR",
+		Message: "Failed to process feature flags",
+	})
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// FlagRepository は機能フラグのデータベースリポジトリ実装
+type FlagRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewFlagRepository は新しいFlagRepositoryを作成する
+func NewFlagRepository(db *sql.DB, logger logger.Logger) usecase.FlagRepository {
+	return &FlagRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListFlags は全てのフラグを取得する
+func (r *FlagRepository) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	query := `
+		SELECT flag_key, description, enabled, percentage, users, updated_at
+		FROM feature_flags
+		ORDER BY flag_key
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list feature flags", logger.Error(err))
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []*domain.Flag{}
+	for rows.Next() {
+		flag, err := scanFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature flags: %w", err)
+	}
+	return flags, nil
+}
+
+// GetFlag はキーでフラグを取得する（存在しない場合は nil, nil）
+func (r *FlagRepository) GetFlag(ctx context.Context, key string) (*domain.Flag, error) {
+	query := `
+		SELECT flag_key, description, enabled, percentage, users, updated_at
+		FROM feature_flags
+		WHERE flag_key = ?
+	`
+
+	flag, err := scanFlag(r.db.QueryRowContext(ctx, query, key))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get feature flag", logger.Any("key", key), logger.Error(err))
+		return nil, err
+	}
+	return flag, nil
+}
+
+// SaveFlag はフラグを保存する（同じキーのフラグは置き換える）
+func (r *FlagRepository) SaveFlag(ctx context.Context, flag *domain.Flag) error {
+	users, err := json.Marshal(flag.Users)
+	if err != nil {
+		return fmt.Errorf("failed to encode feature flag users: %w", err)
+	}
+
+	query := `
+		INSERT INTO feature_flags (flag_key, description, enabled, percentage, users, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			description = VALUES(description),
+			enabled = VALUES(enabled),
+			percentage = VALUES(percentage),
+			users = VALUES(users),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		flag.Key,
+		flag.Description,
+		flag.Enabled,
+		flag.Percentage,
+		string(users),
+		flag.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save feature flag", logger.Any("key", flag.Key), logger.Error(err))
+		return fmt.Errorf("failed to save feature flag: %w", err)
+	}
+	return nil
+}
+
+// rowScanner は *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFlag は1行をフラグに変換する
+func scanFlag(row rowScanner) (*domain.Flag, error) {
+	var flag domain.Flag
+	var users string
+	err := row.Scan(
+		&flag.Key,
+		&flag.Description,
+		&flag.Enabled,
+		&flag.Percentage,
+		&users,
+		&flag.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+	}
+	if err := json.Unmarshal([]byte(users), &flag.Users); err != nil {
+		return nil, fmt.Errorf("failed to decode feature flag users: %w", err)
+	}
+	if flag.Users == nil {
+		flag.Users = []string{}
+	}
+	flag.Source = domain.SourceDatabase
+	return &flag, nil
+}
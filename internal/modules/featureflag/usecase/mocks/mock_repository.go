@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+)
+
+// MockFlagRepository is a mock of FlagRepository interface.
+type MockFlagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlagRepositoryMockRecorder
+}
+
+// MockFlagRepositoryMockRecorder is the mock recorder for MockFlagRepository.
+type MockFlagRepositoryMockRecorder struct {
+	mock *MockFlagRepository
+}
+
+// NewMockFlagRepository creates a new mock instance.
+func NewMockFlagRepository(ctrl *gomock.Controller) *MockFlagRepository {
+	mock := &MockFlagRepository{ctrl: ctrl}
+	mock.recorder = &MockFlagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlagRepository) EXPECT() *MockFlagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetFlag mocks base method.
+func (m *MockFlagRepository) GetFlag(ctx context.Context, key string) (*domain.Flag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlag", ctx, key)
+	ret0, _ := ret[0].(*domain.Flag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlag indicates an expected call of GetFlag.
+func (mr *MockFlagRepositoryMockRecorder) GetFlag(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlag", reflect.TypeOf((*MockFlagRepository)(nil).GetFlag), ctx, key)
+}
+
+// ListFlags mocks base method.
+func (m *MockFlagRepository) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlags", ctx)
+	ret0, _ := ret[0].([]*domain.Flag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlags indicates an expected call of ListFlags.
+func (mr *MockFlagRepositoryMockRecorder) ListFlags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlags", reflect.TypeOf((*MockFlagRepository)(nil).ListFlags), ctx)
+}
+
+// SaveFlag mocks base method.
+func (m *MockFlagRepository) SaveFlag(ctx context.Context, flag *domain.Flag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFlag", ctx, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFlag indicates an expected call of SaveFlag.
+func (mr *MockFlagRepositoryMockRecorder) SaveFlag(ctx, flag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFlag", reflect.TypeOf((*MockFlagRepository)(nil).SaveFlag), ctx, flag)
+}
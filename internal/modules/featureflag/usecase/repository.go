@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+)
+
+// FlagRepository は管理者が変更した機能フラグのリポジトリインターフェース
+type FlagRepository interface {
+	ListFlags(ctx context.Context) ([]*domain.Flag, error)
+	// GetFlag はキーでフラグを取得する（存在しない場合は nil, nil）
+	GetFlag(ctx context.Context, key string) (*domain.Flag, error)
+	// SaveFlag はフラグを保存する（同じキーのフラグは置き換える）
+	SaveFlag(ctx context.Context, flag *domain.Flag) error
+}
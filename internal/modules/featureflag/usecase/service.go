@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DefaultCacheTTL はデータベースのフラグをキャッシュする期間の既定値
+const DefaultCacheTTL = 30 * time.Second
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// UpdateFlagInput はフラグの変更内容（nilの項目は変更しない）
+type UpdateFlagInput struct {
+	Description *string
+	Enabled     *bool
+	Percentage  *int
+	Users       []string
+}
+
+// FeatureFlagService は機能フラグの判定と管理を扱うサービス
+// 環境変数の既定値に、管理者がデータベースに保存した値を上書きして判定する
+type FeatureFlagService struct {
+	Repository FlagRepository
+	// 環境変数の既定値（実行中の変更はSetDefaultsで行う）
+	Defaults map[string]*domain.Flag
+	// データベースのフラグをキャッシュする期間（0の場合は判定のたびに取得する）
+	// 複数のインスタンスで動かす場合、管理者の変更は最大でこの期間だけ遅れて反映される
+	CacheTTL time.Duration
+	Logger   logger.Logger
+
+	mu       sync.RWMutex
+	cached   map[string]*domain.Flag
+	cachedAt time.Time
+	now      func() time.Time
+}
+
+// NewFeatureFlagService はFeatureFlagServiceのコンストラクタ
+func NewFeatureFlagService(repo FlagRepository, defaults map[string]*domain.Flag, cacheTTL time.Duration, logger logger.Logger) *FeatureFlagService {
+	if defaults == nil {
+		defaults = make(map[string]*domain.Flag)
+	}
+	return &FeatureFlagService{
+		Repository: repo,
+		Defaults:   defaults,
+		CacheTTL:   cacheTTL,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// IsEnabled はユーザーにフラグの機能を公開するかを判定する
+// データベースから取得できない場合は、前回取得した値または環境変数の既定値で判定する
+func (s *FeatureFlagService) IsEnabled(ctx context.Context, key, userID string) bool {
+	flags, err := s.flags(ctx)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to load feature flags, using last known values",
+			logger.Any("key", key), logger.Error(err))
+	}
+	flag, ok := flags[key]
+	return ok && flag.IsEnabledFor(userID)
+}
+
+// ListFlags は全てのフラグをキー順に取得する
+func (s *FeatureFlagService) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	flags, err := s.flags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feature flags: %w", err)
+	}
+
+	result := make([]*domain.Flag, 0, len(flags))
+	for _, flag := range flags {
+		f := *flag
+		result = append(result, &f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+// GetUserFeatures はユーザーに対する全てのフラグの判定結果を取得する（クライアントの表示切り替え用）
+func (s *FeatureFlagService) GetUserFeatures(ctx context.Context, userID string) (map[string]bool, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	flags, err := s.flags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feature flags: %w", err)
+	}
+
+	features := make(map[string]bool, len(flags))
+	for key, flag := range flags {
+		features[key] = flag.IsEnabledFor(userID)
+	}
+	return features, nil
+}
+
+// UpdateFlag はフラグを変更してデータベースに保存する
+// データベースにないフラグは、環境変数の既定値（ない場合は無効の新しいフラグ）から変更する
+func (s *FeatureFlagService) UpdateFlag(ctx context.Context, key string, input UpdateFlagInput) (*domain.Flag, error) {
+	if err := domain.ValidateFlagKey(key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+
+	flag, err := s.Repository.GetFlag(ctx, key)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get feature flag", logger.Any("key", key), logger.Error(err))
+		return nil, fmt.Errorf("failed to get feature flag: %w", err)
+	}
+	if flag == nil {
+		if flag, err = domain.NewFlag(key); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+		}
+		if defaults, ok := s.defaults()[key]; ok {
+			flag.Description = defaults.Description
+			flag.Enabled = defaults.Enabled
+			flag.Percentage = defaults.Percentage
+			flag.SetUsers(defaults.Users)
+		}
+	}
+
+	if input.Description != nil {
+		flag.Description = *input.Description
+	}
+	if input.Enabled != nil {
+		flag.Enabled = *input.Enabled
+	}
+	if input.Percentage != nil {
+		if err := flag.SetPercentage(*input.Percentage); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+		}
+	}
+	if input.Users != nil {
+		flag.SetUsers(input.Users)
+	}
+	flag.Source = domain.SourceDatabase
+	flag.UpdatedAt = s.now()
+
+	if err := s.Repository.SaveFlag(ctx, flag); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to save feature flag", logger.Any("key", key), logger.Error(err))
+		return nil, fmt.Errorf("failed to save feature flag: %w", err)
+	}
+
+	// 変更をこのインスタンスの判定に即時に反映する
+	s.mu.Lock()
+	s.cached = nil
+	s.mu.Unlock()
+
+	s.Logger.WithContext(ctx).Info("Feature flag updated",
+		logger.Any("key", key), logger.Any("enabled", flag.Enabled), logger.Any("percentage", flag.Percentage))
+	return flag, nil
+}
+
+// SetDefaults は環境変数の既定値を置き換える（設定の再読み込み用）
+// データベースに保存されたフラグは引き続き既定値より優先される
+func (s *FeatureFlagService) SetDefaults(defaults map[string]*domain.Flag) {
+	if defaults == nil {
+		defaults = make(map[string]*domain.Flag)
+	}
+
+	s.mu.Lock()
+	s.Defaults = defaults
+	s.cached = nil
+	s.mu.Unlock()
+}
+
+// defaults は現在の環境変数の既定値を返す
+func (s *FeatureFlagService) defaults() map[string]*domain.Flag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Defaults
+}
+
+// flags は環境変数の既定値にデータベースの値を上書きしたフラグを返す
+// 取得に失敗した場合は、前回取得した値（ない場合は既定値）とエラーを返す
+func (s *FeatureFlagService) flags(ctx context.Context) (map[string]*domain.Flag, error) {
+	s.mu.RLock()
+	defaults, cached, cachedAt := s.Defaults, s.cached, s.cachedAt
+	s.mu.RUnlock()
+	if cached != nil && s.now().Sub(cachedAt) < s.CacheTTL {
+		return cached, nil
+	}
+
+	stored, err := s.Repository.ListFlags(ctx)
+	if err != nil {
+		if cached != nil {
+			return cached, err
+		}
+		return defaults, err
+	}
+
+	merged := make(map[string]*domain.Flag, len(defaults)+len(stored))
+	for key, flag := range defaults {
+		merged[key] = flag
+	}
+	for _, flag := range stored {
+		merged[flag.Key] = flag
+	}
+
+	s.mu.Lock()
+	s.cached = merged
+	s.cachedAt = s.now()
+	s.mu.Unlock()
+	return merged, nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	"github.com/hryt430/Yotei+/internal/modules/featureflag/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T, defaults string) (*FeatureFlagService, *mocks.MockFlagRepository) {
+	flags, err := domain.ParseFlagDefaults(defaults)
+	require.NoError(t, err)
+	repo := mocks.NewMockFlagRepository(gomock.NewController(t))
+	service := NewFeatureFlagService(repo, flags, time.Minute, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.now = func() time.Time { return testNow }
+	return service, repo
+}
+
+func TestFeatureFlagService_IsEnabled(t *testing.T) {
+	t.Run("database values override config defaults", func(t *testing.T) {
+		service, repo := newTestService(t, "task_history=on,beta=off")
+		repo.EXPECT().ListFlags(gomock.Any()).Return([]*domain.Flag{
+			{Key: "beta", Enabled: true, Users: []string{"user-1"}},
+		}, nil)
+
+		assert.True(t, service.IsEnabled(context.Background(), "task_history", "user-1"))
+		assert.True(t, service.IsEnabled(context.Background(), "beta", "user-1"))
+		assert.False(t, service.IsEnabled(context.Background(), "beta", "user-2"))
+		assert.False(t, service.IsEnabled(context.Background(), "unknown", "user-1"))
+	})
+
+	t.Run("flags are cached until the TTL expires", func(t *testing.T) {
+		service, repo := newTestService(t, "")
+		repo.EXPECT().ListFlags(gomock.Any()).Return([]*domain.Flag{{Key: "beta", Enabled: true, Percentage: 100}}, nil).Times(2)
+
+		assert.True(t, service.IsEnabled(context.Background(), "beta", "user-1"))
+		assert.True(t, service.IsEnabled(context.Background(), "beta", "user-2"))
+
+		service.now = func() time.Time { return testNow.Add(2 * time.Minute) }
+		assert.True(t, service.IsEnabled(context.Background(), "beta", "user-1"))
+	})
+
+	t.Run("falls back to config defaults when the database fails", func(t *testing.T) {
+		service, repo := newTestService(t, "task_history=on")
+		repo.EXPECT().ListFlags(gomock.Any()).Return(nil, errors.New("db down"))
+
+		assert.True(t, service.IsEnabled(context.Background(), "task_history", "user-1"))
+	})
+
+	t.Run("reloaded defaults apply immediately", func(t *testing.T) {
+		service, repo := newTestService(t, "task_history=on")
+		repo.EXPECT().ListFlags(gomock.Any()).Return(nil, nil).Times(2)
+
+		assert.True(t, service.IsEnabled(context.Background(), "task_history", "user-1"))
+
+		defaults, err := domain.ParseFlagDefaults("task_history=off")
+		require.NoError(t, err)
+		service.SetDefaults(defaults)
+
+		assert.False(t, service.IsEnabled(context.Background(), "task_history", "user-1"))
+	})
+}
+
+func TestFeatureFlagService_GetUserFeatures(t *testing.T) {
+	service, repo := newTestService(t, "task_history=on,beta=off")
+	repo.EXPECT().ListFlags(gomock.Any()).Return(nil, nil)
+
+	features, err := service.GetUserFeatures(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"task_history": true, "beta": false}, features)
+}
+
+func TestFeatureFlagService_UpdateFlag(t *testing.T) {
+	t.Run("starts from the config default and invalidates the cache", func(t *testing.T) {
+		service, repo := newTestService(t, "beta=off")
+		repo.EXPECT().ListFlags(gomock.Any()).Return(nil, nil)
+		assert.False(t, service.IsEnabled(context.Background(), "beta", "user-1"))
+
+		enabled := true
+		percentage := 100
+		repo.EXPECT().GetFlag(gomock.Any(), "beta").Return(nil, nil)
+		repo.EXPECT().SaveFlag(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, flag *domain.Flag) error {
+				assert.True(t, flag.Enabled)
+				assert.Equal(t, 100, flag.Percentage)
+				assert.Equal(t, domain.SourceDatabase, flag.Source)
+				assert.Equal(t, testNow, flag.UpdatedAt)
+				return nil
+			})
+
+		_, err := service.UpdateFlag(context.Background(), "beta", UpdateFlagInput{Enabled: &enabled, Percentage: &percentage})
+		require.NoError(t, err)
+
+		repo.EXPECT().ListFlags(gomock.Any()).Return([]*domain.Flag{{Key: "beta", Enabled: true, Percentage: 100}}, nil)
+		assert.True(t, service.IsEnabled(context.Background(), "beta", "user-1"))
+	})
+
+	t.Run("updates only the given fields", func(t *testing.T) {
+		service, repo := newTestService(t, "")
+		existing := &domain.Flag{Key: "beta", Enabled: true, Percentage: 10, Users: []string{"user-1"}}
+		repo.EXPECT().GetFlag(gomock.Any(), "beta").Return(existing, nil)
+		repo.EXPECT().SaveFlag(gomock.Any(), existing).Return(nil)
+
+		flag, err := service.UpdateFlag(context.Background(), "beta", UpdateFlagInput{Users: []string{"user-2"}})
+
+		require.NoError(t, err)
+		assert.True(t, flag.Enabled)
+		assert.Equal(t, 10, flag.Percentage)
+		assert.Equal(t, []string{"user-2"}, flag.Users)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		service, repo := newTestService(t, "")
+
+		_, err := service.UpdateFlag(context.Background(), "Bad Key", UpdateFlagInput{})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		percentage := 150
+		repo.EXPECT().GetFlag(gomock.Any(), "beta").Return(nil, nil)
+		_, err = service.UpdateFlag(context.Background(), "beta", UpdateFlagInput{Percentage: &percentage})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssignmentPolicy は担当者を指定せずに作成したグループタスクの自動割り当て方式
+type AssignmentPolicy string
+
+const (
+	// AssignmentPolicyNone は自動割り当てをしない（デフォルト）
+	AssignmentPolicyNone AssignmentPolicy = "NONE"
+	// AssignmentPolicyRoundRobin はメンバーに順番に割り当てる
+	AssignmentPolicyRoundRobin AssignmentPolicy = "ROUND_ROBIN"
+	// AssignmentPolicyLeastLoaded は未完了のタスクが最も少ないメンバーに割り当てる
+	AssignmentPolicyLeastLoaded AssignmentPolicy = "LEAST_LOADED"
+)
+
+// IsValid は有効な割り当て方式かチェック
+func (p AssignmentPolicy) IsValid() bool {
+	switch p {
+	case AssignmentPolicyNone, AssignmentPolicyRoundRobin, AssignmentPolicyLeastLoaded:
+		return true
+	}
+	return false
+}
+
+// AssignmentSettings はグループの自動割り当て設定
+// LastAssigneeID は直前に自動割り当てしたメンバーで、ラウンドロビンの順番の基準にする
+type AssignmentSettings struct {
+	GroupID        uuid.UUID        `json:"group_id"`
+	Policy         AssignmentPolicy `json:"policy"`
+	LastAssigneeID *uuid.UUID       `json:"last_assignee_id,omitempty"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// NewAssignmentSettings は自動割り当てをしない設定を作成する
+func NewAssignmentSettings(groupID uuid.UUID) *AssignmentSettings {
+	return &AssignmentSettings{
+		GroupID:   groupID,
+		Policy:    AssignmentPolicyNone,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// IsEnabled は自動割り当てが有効かチェック
+func (s *AssignmentSettings) IsEnabled() bool {
+	return s.Policy == AssignmentPolicyRoundRobin || s.Policy == AssignmentPolicyLeastLoaded
+}
+
+// PickAssignee は候補（参加順）から担当者を選び、直前の担当者として記録する
+// 候補は直前の担当者の次から順に並べ、最少負荷方式で未完了タスク数が同じ場合もこの順で選ぶ
+// 自動割り当てが無効または候補がいない場合は false を返す
+func (s *AssignmentSettings) PickAssignee(candidates []uuid.UUID, openTasks map[uuid.UUID]int) (uuid.UUID, bool) {
+	if !s.IsEnabled() || len(candidates) == 0 {
+		return uuid.Nil, false
+	}
+
+	ordered := s.rotate(candidates)
+	picked := ordered[0]
+	if s.Policy == AssignmentPolicyLeastLoaded {
+		for _, candidate := range ordered[1:] {
+			if openTasks[candidate] < openTasks[picked] {
+				picked = candidate
+			}
+		}
+	}
+
+	s.LastAssigneeID = &picked
+	s.UpdatedAt = time.Now()
+	return picked, true
+}
+
+// rotate は直前の担当者の次の候補が先頭になるよう並べ替える
+// 直前の担当者が候補にいない（脱退した）場合は参加順のまま返す
+func (s *AssignmentSettings) rotate(candidates []uuid.UUID) []uuid.UUID {
+	if s.LastAssigneeID == nil {
+		return candidates
+	}
+	for i, candidate := range candidates {
+		if candidate == *s.LastAssigneeID {
+			next := i + 1
+			return append(append([]uuid.UUID{}, candidates[next:]...), candidates[:next]...)
+		}
+	}
+	return candidates
+}
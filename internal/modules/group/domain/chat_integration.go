@@ -0,0 +1,485 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultChatSummaryHour は1日のまとめを投稿する時刻（時）のデフォルト
+	DefaultChatSummaryHour = 9
+	// MaxChatTemplateLength はメッセージのテンプレートの最大文字数
+	MaxChatTemplateLength = 2000
+	// MaxChatSummaryTasks は1日のまとめの一覧ごとに載せるタスクの件数の上限
+	MaxChatSummaryTasks = 10
+	// DefaultChatDueReminderHours は期限の何時間前にリマインダーを投稿するかのデフォルト
+	DefaultChatDueReminderHours = 24
+	// MaxChatDueReminderHours は期限の何時間前にリマインダーを投稿するかの上限
+	MaxChatDueReminderHours = 168
+	// MaxChatDueReminders は1回の確認で連携ごとに投稿する期限のリマインダーの件数の上限
+	MaxChatDueReminders = 10
+	// chatSummaryDateLayout は1日のまとめを投稿した日付の形式
+	chatSummaryDateLayout = "2006-01-02"
+)
+
+// ChatPlatform はグループのチャット連携先のサービス
+type ChatPlatform string
+
+const (
+	// ChatPlatformDiscord はDiscordのチャンネルのWebhook
+	ChatPlatformDiscord ChatPlatform = "DISCORD"
+	// ChatPlatformTeams はMicrosoft Teamsのチャネルの受信Webhook（ワークフローのWebhookを含む）
+	ChatPlatformTeams ChatPlatform = "TEAMS"
+)
+
+// ParseChatPlatform はパスなどで指定した連携先（大文字・小文字を区別しない）を返す
+func ParseChatPlatform(value string) (ChatPlatform, error) {
+	platform := ChatPlatform(strings.ToUpper(strings.TrimSpace(value)))
+	if !platform.IsValid() {
+		return "", fmt.Errorf("unsupported platform: %s", value)
+	}
+	return platform, nil
+}
+
+// IsValid は有効な連携先かチェック
+func (p ChatPlatform) IsValid() bool {
+	switch p {
+	case ChatPlatformDiscord, ChatPlatformTeams:
+		return true
+	}
+	return false
+}
+
+// ChatEvent はチャットに投稿する出来事
+type ChatEvent string
+
+const (
+	// ChatEventTaskCompleted はグループタスクの完了
+	ChatEventTaskCompleted ChatEvent = "TASK_COMPLETED"
+	// ChatEventTaskAssigned はグループタスクへの担当者の割り当て
+	ChatEventTaskAssigned ChatEvent = "TASK_ASSIGNED"
+	// ChatEventTaskDueSoon はグループタスクの期限が近づいたことのリマインダー（期限の DueReminderHours 時間前に投稿する）
+	ChatEventTaskDueSoon ChatEvent = "TASK_DUE_SOON"
+	// ChatEventDailySummary は1日のまとめ（SummaryHour に投稿する）
+	ChatEventDailySummary ChatEvent = "DAILY_SUMMARY"
+)
+
+// ChatEvents はチャットに投稿できる出来事の一覧
+var ChatEvents = []ChatEvent{ChatEventTaskCompleted, ChatEventTaskAssigned, ChatEventTaskDueSoon, ChatEventDailySummary}
+
+// IsValid は有効な出来事かチェック
+func (e ChatEvent) IsValid() bool {
+	for _, event := range ChatEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultChatTemplates は出来事ごとのメッセージのデフォルトのテンプレート（text/template、Markdown）
+//
+// 使える変数は group_name と、出来事ごとに以下のとおり
+//   - TASK_COMPLETED: task_title, assignees, due_date
+//   - TASK_ASSIGNED: task_title, assignees（割り当てたメンバー）, due_date
+//   - TASK_DUE_SOON: task_title, assignees, due_date
+//   - DAILY_SUMMARY: date, completed_count, open_count, overdue_count, due_today_count, completed, due_today, overdue（一覧は1行1件）
+var DefaultChatTemplates = map[ChatEvent]string{
+	ChatEventTaskCompleted: "✅ **{{.task_title}}** が完了しました{{if .assignees}}（担当: {{.assignees}}）{{end}}",
+	ChatEventTaskAssigned:  "📌 **{{.task_title}}** を {{.assignees}} に割り当てました{{if .due_date}}（期限: {{.due_date}}）{{end}}",
+	ChatEventTaskDueSoon:   "⏰ **{{.task_title}}** の期限が近づいています（期限: {{.due_date}}{{if .assignees}}、担当: {{.assignees}}{{end}}）",
+	ChatEventDailySummary: "📊 **{{.group_name}}** の{{.date}}のまとめ\n" +
+		"完了 {{.completed_count}}件 / 未完了 {{.open_count}}件 / 期限切れ {{.overdue_count}}件" +
+		"{{if .overdue}}\n\n**期限切れ**\n{{.overdue}}{{end}}" +
+		"{{if .due_today}}\n\n**今日が期限**\n{{.due_today}}{{end}}" +
+		"{{if .completed}}\n\n**完了したタスク**\n{{.completed}}{{end}}",
+}
+
+// chatEventTitles は出来事ごとのメッセージの見出し（カード形式で投稿する連携先で使う）
+var chatEventTitles = map[ChatEvent]string{
+	ChatEventTaskCompleted: "タスクの完了",
+	ChatEventTaskAssigned:  "タスクの割り当て",
+	ChatEventTaskDueSoon:   "期限が近いタスク",
+	ChatEventDailySummary:  "1日のまとめ",
+}
+
+// chatEventFacts は出来事ごとにメッセージに添える項目と、その値のテンプレートの変数
+var chatEventFacts = map[ChatEvent][]struct{ name, key string }{
+	ChatEventTaskCompleted: {{"グループ", "group_name"}, {"タスク", "task_title"}, {"担当", "assignees"}},
+	ChatEventTaskAssigned:  {{"グループ", "group_name"}, {"タスク", "task_title"}, {"担当", "assignees"}, {"期限", "due_date"}},
+	ChatEventTaskDueSoon:   {{"グループ", "group_name"}, {"タスク", "task_title"}, {"担当", "assignees"}, {"期限", "due_date"}},
+	ChatEventDailySummary:  {{"完了", "completed_count"}, {"未完了", "open_count"}, {"期限切れ", "overdue_count"}, {"今日が期限", "due_today_count"}},
+}
+
+// ChatIntegration はグループのチャット連携の設定（グループ・連携先ごとに1つ）
+// 有効にした出来事をチャンネルのWebhookに投稿する
+type ChatIntegration struct {
+	GroupID          uuid.UUID            `json:"group_id"`
+	Platform         ChatPlatform         `json:"platform"`
+	WebhookURL       string               `json:"-"` // チャンネルに投稿できる秘密の値のため、表示には MaskedWebhookURL を使う
+	Events           []ChatEvent          `json:"events"`
+	Templates        map[ChatEvent]string `json:"templates"` // 変更した出来事のテンプレートのみ（ない場合はデフォルト）
+	SummaryHour      int                  `json:"summary_hour"`
+	Timezone         string               `json:"timezone"`
+	Enabled          bool                 `json:"enabled"`
+	LastSummaryDate  string               `json:"last_summary_date,omitempty"` // 最後に1日のまとめを投稿した日付（Timezone の日付）
+	DueReminderHours int                  `json:"due_reminder_hours"`          // 期限の何時間前にリマインダーを投稿するか
+	DueCheckedUntil  *time.Time           `json:"-"`                           // 期限のリマインダーを投稿済みの期限の日時（これより後の期限が対象）
+	CreatedBy        uuid.UUID            `json:"created_by"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+// NewChatIntegration はすべての出来事を投稿する有効な連携を作成する
+func NewChatIntegration(groupID uuid.UUID, platform ChatPlatform, webhookURL string, createdBy uuid.UUID) *ChatIntegration {
+	now := time.Now()
+	return &ChatIntegration{
+		GroupID:          groupID,
+		Platform:         platform,
+		WebhookURL:       webhookURL,
+		Events:           append([]ChatEvent{}, ChatEvents...),
+		Templates:        map[ChatEvent]string{},
+		SummaryHour:      DefaultChatSummaryHour,
+		Timezone:         "UTC",
+		Enabled:          true,
+		DueReminderHours: DefaultChatDueReminderHours,
+		CreatedBy:        createdBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// Validate は連携の設定をチェックする（出来事は重複を除いて ChatEvents の順に並べ、空のテンプレートは取り除く）
+func (c *ChatIntegration) Validate() error {
+	if !c.Platform.IsValid() {
+		return fmt.Errorf("unsupported platform: %s", c.Platform)
+	}
+	c.WebhookURL = strings.TrimSpace(c.WebhookURL)
+	if err := validateWebhookURL(c.Platform, c.WebhookURL); err != nil {
+		return err
+	}
+
+	events := make(map[ChatEvent]bool, len(c.Events))
+	for _, event := range c.Events {
+		if !event.IsValid() {
+			return fmt.Errorf("invalid event: %s", event)
+		}
+		events[event] = true
+	}
+	ordered := make([]ChatEvent, 0, len(events))
+	for _, event := range ChatEvents {
+		if events[event] {
+			ordered = append(ordered, event)
+		}
+	}
+	c.Events = ordered
+
+	for event, text := range c.Templates {
+		if !event.IsValid() {
+			return fmt.Errorf("invalid template event: %s", event)
+		}
+		if strings.TrimSpace(text) == "" {
+			delete(c.Templates, event)
+			continue
+		}
+		if len([]rune(text)) > MaxChatTemplateLength {
+			return fmt.Errorf("template for %s must be at most %d characters", event, MaxChatTemplateLength)
+		}
+		if _, err := parseChatTemplate(text); err != nil {
+			return fmt.Errorf("invalid template for %s: %w", event, err)
+		}
+	}
+
+	if c.SummaryHour < 0 || c.SummaryHour > 23 {
+		return errors.New("summary_hour must be between 0 and 23")
+	}
+	if c.DueReminderHours < 1 || c.DueReminderHours > MaxChatDueReminderHours {
+		return fmt.Errorf("due_reminder_hours must be between 1 and %d", MaxChatDueReminderHours)
+	}
+	if c.Timezone == "" {
+		return errors.New("timezone is required")
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %s", c.Timezone)
+	}
+	return nil
+}
+
+// validateWebhookURL は連携先のWebhookのURLかチェックする（他のホストへ送らないよう、ホストとパスを限定する）
+func validateWebhookURL(platform ChatPlatform, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.User != nil {
+		return errors.New("webhook_url must be an https URL")
+	}
+
+	switch platform {
+	case ChatPlatformDiscord:
+		switch parsed.Hostname() {
+		case "discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com":
+		default:
+			return errors.New("webhook_url must be a Discord webhook URL")
+		}
+		if parsed.Port() != "" || !strings.HasPrefix(parsed.Path, "/api/webhooks/") {
+			return errors.New("webhook_url must be a Discord webhook URL")
+		}
+	case ChatPlatformTeams:
+		// Office 365 コネクタの受信Webhookと、Power Automate のワークフローのWebhook
+		host := parsed.Hostname()
+		var ok bool
+		switch {
+		case strings.HasSuffix(host, ".webhook.office.com"):
+			ok = strings.HasPrefix(parsed.Path, "/webhookb2/")
+		case strings.HasSuffix(host, ".logic.azure.com"):
+			ok = strings.HasPrefix(parsed.Path, "/workflows/")
+		case strings.HasSuffix(host, ".environment.api.powerplatform.com"):
+			ok = strings.HasPrefix(parsed.Path, "/powerautomate/automations/direct/workflows/")
+		}
+		if !ok || (parsed.Port() != "" && parsed.Port() != "443") {
+			return errors.New("webhook_url must be a Microsoft Teams webhook URL")
+		}
+	}
+	return nil
+}
+
+// MaskedWebhookURL はWebhookのURLの最後の要素（トークン）とクエリ（ワークフローの署名）を伏せて返す
+func (c *ChatIntegration) MaskedWebhookURL() string {
+	webhookURL, _, _ := strings.Cut(c.WebhookURL, "?")
+	i := strings.LastIndex(webhookURL, "/")
+	if i < 0 {
+		return "****"
+	}
+	return webhookURL[:i+1] + "****"
+}
+
+// Subscribes は出来事を投稿するかを返す（無効にした連携は投稿しない）
+func (c *ChatIntegration) Subscribes(event ChatEvent) bool {
+	if !c.Enabled {
+		return false
+	}
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Location は1日のまとめと日時の表示に使うタイムゾーンを返す（不正な場合は UTC）
+func (c *ChatIntegration) Location() *time.Location {
+	if loc, err := time.LoadLocation(c.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Template は出来事のテンプレートを返す（変更していない場合はデフォルト）
+func (c *ChatIntegration) Template(event ChatEvent) string {
+	if text, ok := c.Templates[event]; ok && text != "" {
+		return text
+	}
+	return DefaultChatTemplates[event]
+}
+
+// Render は出来事のテンプレートに変数を埋め込んだメッセージを作成する（ない変数は空文字列とする）
+func (c *ChatIntegration) Render(event ChatEvent, vars map[string]string) (*ChatMessage, error) {
+	tmpl, err := parseChatTemplate(c.Template(event))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	message := &ChatMessage{Event: event, Title: chatEventTitles[event], Text: strings.TrimSpace(b.String())}
+	for _, fact := range chatEventFacts[event] {
+		if value := vars[fact.key]; value != "" {
+			message.Facts = append(message.Facts, ChatFact{Name: fact.name, Value: value})
+		}
+	}
+	return message, nil
+}
+
+// SummaryDue は now に1日のまとめを投稿するかを返す
+// Timezone で SummaryHour を過ぎていて、その日のまとめをまだ投稿していない場合に投稿する
+func (c *ChatIntegration) SummaryDue(now time.Time) bool {
+	if !c.Subscribes(ChatEventDailySummary) {
+		return false
+	}
+	local := now.In(c.Location())
+	return local.Hour() >= c.SummaryHour && c.LastSummaryDate != local.Format(chatSummaryDateLayout)
+}
+
+// MarkSummarySent は now の日付のまとめを投稿済みにする
+func (c *ChatIntegration) MarkSummarySent(now time.Time) {
+	c.LastSummaryDate = now.In(c.Location()).Format(chatSummaryDateLayout)
+}
+
+// DueWindow は now に期限のリマインダーを投稿するタスクの期限の範囲（from より後、to 以前）を返す
+// 前回の確認の続きから DueReminderHours 時間後までを対象とし、投稿しない場合は ok が false になる
+func (c *ChatIntegration) DueWindow(now time.Time) (from, to time.Time, ok bool) {
+	if !c.Subscribes(ChatEventTaskDueSoon) {
+		return time.Time{}, time.Time{}, false
+	}
+	from = now
+	if c.DueCheckedUntil != nil && c.DueCheckedUntil.After(now) {
+		from = *c.DueCheckedUntil
+	}
+	to = now.Add(time.Duration(c.DueReminderHours) * time.Hour)
+	return from, to, to.After(from)
+}
+
+// MarkDueChecked は to までの期限のリマインダーを投稿済みにする
+func (c *ChatIntegration) MarkDueChecked(to time.Time) {
+	c.DueCheckedUntil = &to
+}
+
+func parseChatTemplate(text string) (*template.Template, error) {
+	return template.New("chat").Option("missingkey=zero").Parse(text)
+}
+
+// ChatMessage はチャットに投稿するメッセージ
+// 連携先ごとの形式（Discordは本文のみ、Teamsは見出し・本文・項目のカード）への変換はゲートウェイで行う
+type ChatMessage struct {
+	Event ChatEvent
+	Title string     // 出来事の見出し（テストのメッセージでは空）
+	Text  string     // テンプレートから作成した本文（Markdown）
+	Facts []ChatFact // 本文に添える項目（値が空の項目は含めない）
+}
+
+// ChatFact はメッセージに添える項目
+type ChatFact struct {
+	Name  string
+	Value string
+}
+
+// ChatTask はチャットへの投稿に使うグループタスクの概要
+type ChatTask struct {
+	ID          string
+	Title       string
+	Done        bool
+	DueDate     *time.Time
+	CompletedAt *time.Time
+	AssigneeIDs []uuid.UUID
+}
+
+// ChatTaskVars はタスクの出来事のテンプレートの変数を作成する（期限は loc の日時）
+func ChatTaskVars(groupName string, task *ChatTask, assignees []string, loc *time.Location) map[string]string {
+	vars := map[string]string{
+		"group_name": groupName,
+		"task_title": task.Title,
+		"assignees":  strings.Join(assignees, ", "),
+	}
+	if task.DueDate != nil {
+		vars["due_date"] = task.DueDate.In(loc).Format("2006-01-02 15:04")
+	}
+	return vars
+}
+
+// DueSoonTasks は期限が from より後、to 以前の未完了のタスクを期限の順に返す
+func DueSoonTasks(tasks []*ChatTask, from, to time.Time) []*ChatTask {
+	var due []*ChatTask
+	for _, task := range tasks {
+		if task.Done || task.DueDate == nil {
+			continue
+		}
+		if task.DueDate.After(from) && !task.DueDate.After(to) {
+			due = append(due, task)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].DueDate.Before(*due[j].DueDate) })
+	return due
+}
+
+// DailySummary はグループタスクの1日のまとめ
+type DailySummary struct {
+	Date      string      // まとめの日付（連携のタイムゾーン）
+	Completed []*ChatTask // 直近24時間に完了したタスク
+	DueToday  []*ChatTask // 今日が期限の未完了のタスク（期限切れを除く）
+	Overdue   []*ChatTask // 期限切れの未完了のタスク
+	OpenCount int         // 未完了のタスクの件数
+}
+
+// NewDailySummary は now 時点のグループタスクの1日のまとめを作成する（日付は loc で区切る）
+// 一覧は期限（完了したタスクは完了日時）の順に並べる
+func NewDailySummary(tasks []*ChatTask, now time.Time, loc *time.Location) *DailySummary {
+	local := now.In(loc)
+	dayEnd := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+	summary := &DailySummary{Date: local.Format(chatSummaryDateLayout)}
+
+	for _, task := range tasks {
+		if task.Done {
+			if task.CompletedAt != nil && task.CompletedAt.After(now.Add(-24*time.Hour)) && !task.CompletedAt.After(now) {
+				summary.Completed = append(summary.Completed, task)
+			}
+			continue
+		}
+		summary.OpenCount++
+		if task.DueDate == nil {
+			continue
+		}
+		switch {
+		case task.DueDate.Before(now):
+			summary.Overdue = append(summary.Overdue, task)
+		case task.DueDate.Before(dayEnd):
+			summary.DueToday = append(summary.DueToday, task)
+		}
+	}
+
+	sort.SliceStable(summary.Completed, func(i, j int) bool {
+		return summary.Completed[i].CompletedAt.Before(*summary.Completed[j].CompletedAt)
+	})
+	for _, list := range [][]*ChatTask{summary.DueToday, summary.Overdue} {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].DueDate.Before(*list[j].DueDate) })
+	}
+	return summary
+}
+
+// IsEmpty は投稿する内容がない（完了したタスクも未完了のタスクもない）かを返す
+func (s *DailySummary) IsEmpty() bool {
+	return len(s.Completed) == 0 && s.OpenCount == 0
+}
+
+// Vars は1日のまとめのテンプレートの変数を作成する（期限は loc の時刻）
+func (s *DailySummary) Vars(groupName string, loc *time.Location) map[string]string {
+	return map[string]string{
+		"group_name":      groupName,
+		"date":            s.Date,
+		"completed_count": fmt.Sprint(len(s.Completed)),
+		"open_count":      fmt.Sprint(s.OpenCount),
+		"overdue_count":   fmt.Sprint(len(s.Overdue)),
+		"due_today_count": fmt.Sprint(len(s.DueToday)),
+		"completed":       chatTaskList(s.Completed, nil),
+		"due_today": chatTaskList(s.DueToday, func(task *ChatTask) string {
+			return task.DueDate.In(loc).Format("15:04") + "まで"
+		}),
+		"overdue": chatTaskList(s.Overdue, func(task *ChatTask) string {
+			return "期限 " + task.DueDate.In(loc).Format("1/2 15:04")
+		}),
+	}
+}
+
+// chatTaskList はタスクを1行1件の箇条書きにする（MaxChatSummaryTasks 件を超える分は件数だけ載せる）
+func chatTaskList(tasks []*ChatTask, note func(task *ChatTask) string) string {
+	lines := make([]string, 0, len(tasks))
+	for i, task := range tasks {
+		if i == MaxChatSummaryTasks {
+			lines = append(lines, fmt.Sprintf("ほか%d件", len(tasks)-i))
+			break
+		}
+		line := "- " + task.Title
+		if note != nil {
+			line += "（" + note(task) + "）"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
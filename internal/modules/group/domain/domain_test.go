@@ -1,10 +1,14 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -237,6 +241,11 @@ func TestGroupMember_CanManageGroup(t *testing.T) {
 			role:     RoleMember,
 			expected: false,
 		},
+		{
+			name:     "Guest cannot manage group",
+			role:     RoleGuest,
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -279,6 +288,12 @@ func TestGroupMember_CanInviteMembers(t *testing.T) {
 			allowMemberInvite: false,
 			expected:          false,
 		},
+		{
+			name:              "Guest cannot invite even when members can",
+			role:              RoleGuest,
+			allowMemberInvite: true,
+			expected:          false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -416,6 +431,7 @@ func TestMemberRoles(t *testing.T) {
 	assert.Equal(t, MemberRole("OWNER"), RoleOwner)
 	assert.Equal(t, MemberRole("ADMIN"), RoleAdmin)
 	assert.Equal(t, MemberRole("MEMBER"), RoleMember)
+	assert.Equal(t, MemberRole("GUEST"), RoleGuest)
 }
 
 func TestPrivacyLevels(t *testing.T) {
@@ -486,3 +502,974 @@ func BenchmarkGroupMember_CanInviteMembers(b *testing.B) {
 		member.CanInviteMembers(settings)
 	}
 }
+
+func TestDefaultPermissionMatrix(t *testing.T) {
+	matrix := DefaultPermissionMatrix()
+
+	tests := []struct {
+		name     string
+		role     MemberRole
+		action   GroupAction
+		expected bool
+	}{
+		{"Owner can edit group", RoleOwner, ActionEditGroup, true},
+		{"Admin can edit group", RoleAdmin, ActionEditGroup, true},
+		{"Member cannot edit group", RoleMember, ActionEditGroup, false},
+		{"All members can view group", RoleMember, ActionViewGroup, true},
+		{"All members can view tasks", RoleMember, ActionViewTasks, true},
+		{"Unknown action denied", RoleOwner, GroupAction("UNKNOWN"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matrix.Allows(tt.role, tt.action))
+		})
+	}
+}
+
+func TestPermissionMatrix_Merge(t *testing.T) {
+	merged := DefaultPermissionMatrix().Merge(PermissionMatrix{
+		ActionCreateTasks: {RoleAdmin, RoleMember},
+		ActionEditGroup:   {},
+		ActionManageRoles: {RoleMember}, // 変更できないアクションは無視される
+	})
+
+	assert.True(t, merged.Allows(RoleMember, ActionCreateTasks))
+	assert.False(t, merged.Allows(RoleAdmin, ActionEditGroup))
+	assert.True(t, merged.Allows(RoleOwner, ActionEditGroup), "オーナーは常に実行できる")
+	assert.False(t, merged.Allows(RoleMember, ActionManageRoles))
+	assert.False(t, DefaultPermissionMatrix().Allows(RoleMember, ActionCreateTasks), "デフォルトは変更されない")
+}
+
+func TestPermissionMatrix_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  PermissionMatrix
+		wantErr bool
+	}{
+		{"valid", PermissionMatrix{ActionInviteMembers: {RoleAdmin, RoleMember}}, false},
+		{"owner only", PermissionMatrix{ActionEditGroup: {}}, false},
+		{"not configurable", PermissionMatrix{ActionDeleteGroup: {RoleAdmin}}, true},
+		{"owner role", PermissionMatrix{ActionEditGroup: {RoleOwner}}, true},
+		{"unknown role", PermissionMatrix{ActionEditGroup: {MemberRole("VIEWER")}}, true},
+		{"guest role", PermissionMatrix{ActionViewTasks: {RoleGuest}}, true},
+		{"duplicate role", PermissionMatrix{ActionEditGroup: {RoleAdmin, RoleAdmin}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.matrix.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewCustomRole(t *testing.T) {
+	groupID := uuid.New()
+	tests := []struct {
+		name        string
+		roleName    string
+		permissions []GroupAction
+		wantErr     bool
+	}{
+		{"valid", "Task Manager", []GroupAction{ActionCreateTasks, ActionEditTasks}, false},
+		{"no permissions", "Viewer", nil, false},
+		{"empty name", "  ", nil, true},
+		{"name too long", string(make([]rune, MaxCustomRoleNameLength+1)), nil, true},
+		{"reserved name", "admin", nil, true},
+		{"guest is reserved", "Guest", nil, true},
+		{"not configurable", "Janitor", []GroupAction{ActionDeleteGroup}, true},
+		{"duplicate action", "Editor", []GroupAction{ActionEditTasks, ActionEditTasks}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := NewCustomRole(groupID, tt.roleName, tt.permissions)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, groupID, role.GroupID)
+			assert.Len(t, role.Permissions, len(tt.permissions))
+		})
+	}
+}
+
+func TestCustomRole_Allows(t *testing.T) {
+	role, err := NewCustomRole(uuid.New(), "Task Manager", []GroupAction{ActionCreateTasks})
+	require.NoError(t, err)
+
+	assert.True(t, role.Allows(ActionCreateTasks))
+	assert.True(t, role.Allows(ActionViewGroup), "every member can view the group")
+	assert.False(t, role.Allows(ActionDeleteTasks))
+	assert.False(t, role.Allows(ActionManageRoles))
+}
+
+func TestMemberRole_CustomRoleID(t *testing.T) {
+	role, err := NewCustomRole(uuid.New(), "Viewer", nil)
+	require.NoError(t, err)
+
+	id, ok := role.Role().CustomRoleID()
+	assert.True(t, ok)
+	assert.Equal(t, role.ID, id)
+
+	_, ok = RoleAdmin.CustomRoleID()
+	assert.False(t, ok)
+	_, ok = RoleGuest.CustomRoleID()
+	assert.False(t, ok)
+	_, ok = MemberRole("VIEWER").CustomRoleID()
+	assert.False(t, ok)
+}
+
+func TestPermissionMatrix_GuestIsReadOnly(t *testing.T) {
+	matrix := DefaultPermissionMatrix()
+	assert.True(t, matrix.Allows(RoleGuest, ActionViewGroup))
+	assert.True(t, matrix.Allows(RoleGuest, ActionViewTasks))
+	assert.True(t, matrix.Allows(RoleGuest, ActionViewSchedules))
+	assert.False(t, matrix.Allows(RoleGuest, ActionCreateTasks))
+	assert.False(t, matrix.Allows(RoleGuest, ActionInviteMembers))
+
+	// Opening an action to members does not extend it to guests unless it is read-only
+	opened := matrix.Merge(PermissionMatrix{ActionEditTasks: {RoleAdmin, RoleMember}})
+	assert.False(t, opened.Allows(RoleGuest, ActionEditTasks))
+
+	// Guests lose read access that members do not have
+	restricted := matrix.Merge(PermissionMatrix{ActionViewSchedules: {RoleAdmin}})
+	assert.False(t, restricted.Allows(RoleGuest, ActionViewSchedules))
+}
+
+func TestAssignmentSettings_PickAssignee(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	candidates := []uuid.UUID{a, b, c}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		settings := NewAssignmentSettings(uuid.New())
+		_, ok := settings.PickAssignee(candidates, nil)
+		assert.False(t, ok)
+		assert.Nil(t, settings.LastAssigneeID)
+	})
+
+	t.Run("round robin cycles through candidates", func(t *testing.T) {
+		settings := NewAssignmentSettings(uuid.New())
+		settings.Policy = AssignmentPolicyRoundRobin
+
+		var picked []uuid.UUID
+		for i := 0; i < 4; i++ {
+			id, ok := settings.PickAssignee(candidates, nil)
+			require.True(t, ok)
+			picked = append(picked, id)
+		}
+		assert.Equal(t, []uuid.UUID{a, b, c, a}, picked)
+		assert.Equal(t, a, *settings.LastAssigneeID)
+	})
+
+	t.Run("round robin restarts when the last assignee left", func(t *testing.T) {
+		settings := NewAssignmentSettings(uuid.New())
+		settings.Policy = AssignmentPolicyRoundRobin
+		gone := uuid.New()
+		settings.LastAssigneeID = &gone
+
+		id, ok := settings.PickAssignee(candidates, nil)
+		require.True(t, ok)
+		assert.Equal(t, a, id)
+	})
+
+	t.Run("least loaded picks the member with fewest open tasks", func(t *testing.T) {
+		settings := NewAssignmentSettings(uuid.New())
+		settings.Policy = AssignmentPolicyLeastLoaded
+
+		id, ok := settings.PickAssignee(candidates, map[uuid.UUID]int{a: 3, b: 1, c: 2})
+		require.True(t, ok)
+		assert.Equal(t, b, id)
+	})
+
+	t.Run("least loaded breaks ties in round robin order", func(t *testing.T) {
+		settings := NewAssignmentSettings(uuid.New())
+		settings.Policy = AssignmentPolicyLeastLoaded
+		settings.LastAssigneeID = &a
+
+		id, ok := settings.PickAssignee(candidates, map[uuid.UUID]int{a: 0, b: 1, c: 0})
+		require.True(t, ok)
+		assert.Equal(t, c, id)
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		settings := NewAssignmentSettings(uuid.New())
+		settings.Policy = AssignmentPolicyRoundRobin
+		_, ok := settings.PickAssignee(nil, nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestAssignmentPolicy_IsValid(t *testing.T) {
+	assert.True(t, AssignmentPolicyNone.IsValid())
+	assert.True(t, AssignmentPolicyRoundRobin.IsValid())
+	assert.True(t, AssignmentPolicyLeastLoaded.IsValid())
+	assert.False(t, AssignmentPolicy("RANDOM").IsValid())
+}
+
+func TestLeaderboardWeek(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// Sunday 20:00 UTC is already Monday in Tokyo
+	start, end := LeaderboardWeek(time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC), tokyo)
+	assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo), start)
+	assert.Equal(t, time.Date(2024, 6, 17, 0, 0, 0, 0, tokyo), end)
+
+	start, _ = LeaderboardWeek(time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC), time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestNewLeaderboard(t *testing.T) {
+	weekStart := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	now := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC) // 水曜
+	at := func(day, hour int) time.Time { return time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC) }
+	due := func(day int) *time.Time { d := at(day, 18); return &d }
+
+	alice, bob, carol, dave := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	members := []LeaderboardMember{
+		{UserID: alice, DisplayName: "alice", Visibility: LeaderboardVisible},
+		{UserID: bob, DisplayName: "bob", Visibility: LeaderboardAnonymous},
+		{UserID: carol, DisplayName: "carol", Visibility: LeaderboardVisible},
+		{UserID: dave, DisplayName: "dave", Visibility: LeaderboardHidden},
+	}
+	completions := map[uuid.UUID][]TaskCompletion{
+		alice: {
+			{CompletedAt: at(8, 10)}, // 前週（連続達成日数のみ）
+			{CompletedAt: at(9, 10)},
+			{CompletedAt: at(10, 10), DueDate: due(10)},
+			{CompletedAt: at(11, 10), DueDate: due(10)},
+			{CompletedAt: at(12, 10)},
+		},
+		bob: {
+			{CompletedAt: at(10, 10), DueDate: due(11)},
+			{CompletedAt: at(11, 10), DueDate: due(11)},
+			{CompletedAt: at(13, 10)}, // 未来（集計しない）
+		},
+		carol: {
+			{CompletedAt: at(11, 10)},
+			{CompletedAt: at(11, 12)},
+		},
+		dave: {{CompletedAt: at(11, 10)}},
+	}
+
+	board := NewLeaderboard(uuid.New(), members, completions, weekStart, weekEnd, now)
+
+	require.Len(t, board.Entries, 3)
+	first, second, third := board.Entries[0], board.Entries[1], board.Entries[2]
+	assert.Equal(t, "alice", first.DisplayName)
+	assert.Equal(t, 1, first.Rank)
+	assert.Equal(t, 3, first.CompletedTasks)
+	assert.Equal(t, 50.0, *first.OnTimeRate)
+	assert.Equal(t, 5, first.CurrentStreak)
+
+	assert.Equal(t, "bob", second.DisplayName)
+	assert.Equal(t, 2, second.Rank)
+	assert.Equal(t, 100.0, *second.OnTimeRate)
+	assert.Equal(t, 2, second.CurrentStreak) // 今日は未完了のため昨日から数える
+
+	assert.Equal(t, "carol", third.DisplayName)
+	assert.Equal(t, 3, third.Rank)
+	assert.Nil(t, third.OnTimeRate)
+
+	t.Run("anonymous members are hidden from others", func(t *testing.T) {
+		view := board.ForViewer(alice)
+		assert.True(t, view.Entries[0].IsMe)
+		require.NotNil(t, view.Entries[0].UserID)
+		assert.Nil(t, view.Entries[1].UserID)
+		assert.Equal(t, AnonymousMemberName, view.Entries[1].DisplayName)
+		assert.True(t, view.Entries[1].Anonymous)
+
+		own := board.ForViewer(bob)
+		assert.True(t, own.Entries[1].IsMe)
+		assert.Equal(t, "bob", own.Entries[1].DisplayName)
+		assert.Equal(t, bob, *own.Entries[1].UserID)
+	})
+
+	t.Run("ties share a rank", func(t *testing.T) {
+		tied := map[uuid.UUID][]TaskCompletion{
+			alice: completions[carol],
+			bob:   completions[carol],
+		}
+
+		board := NewLeaderboard(uuid.New(), members, tied, weekStart, weekEnd, now)
+
+		require.Len(t, board.Entries, 3)
+		assert.Equal(t, 1, board.Entries[0].Rank)
+		assert.Equal(t, 1, board.Entries[1].Rank)
+		assert.Equal(t, 3, board.Entries[2].Rank)
+	})
+}
+
+func TestLeaderboardVisibility_IsValid(t *testing.T) {
+	assert.True(t, LeaderboardVisible.IsValid())
+	assert.True(t, LeaderboardAnonymous.IsValid())
+	assert.True(t, LeaderboardHidden.IsValid())
+	assert.False(t, LeaderboardVisibility("PUBLIC").IsValid())
+}
+
+func TestRSVPStatus_IsValid(t *testing.T) {
+	assert.True(t, RSVPYes.IsValid())
+	assert.True(t, RSVPNo.IsValid())
+	assert.True(t, RSVPMaybe.IsValid())
+	assert.False(t, RSVPNoResponse.IsValid())
+	assert.False(t, RSVPStatus("LATE").IsValid())
+}
+
+func TestNewEventAttendees(t *testing.T) {
+	alice, bob, carol, former := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	event := &GroupEvent{ID: "event-1", StartsAt: time.Now()}
+	attended := true
+	attendees := NewEventAttendees(event, []uuid.UUID{alice, bob, carol}, []*EventRSVP{
+		{UserID: alice, Status: RSVPYes, Attended: &attended},
+		{UserID: bob, Status: RSVPMaybe},
+		{UserID: former, Status: RSVPNo}, // 脱退したメンバーの回答は含めない
+	})
+
+	require.Len(t, attendees.Attendees, 3)
+	assert.Equal(t, alice, attendees.Attendees[0].UserID)
+	assert.Equal(t, &attended, attendees.Attendees[0].Attended)
+	assert.Equal(t, RSVPNoResponse, attendees.Attendees[2].Status)
+	assert.Equal(t, 1, attendees.Yes)
+	assert.Equal(t, 0, attendees.No)
+	assert.Equal(t, 1, attendees.Maybe)
+	assert.Equal(t, 1, attendees.NoResponse)
+}
+
+func TestNewMemberAttendance(t *testing.T) {
+	now := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC)
+	alice, bob := uuid.New(), uuid.New()
+	events := []*GroupEvent{
+		{ID: "past-1", StartsAt: now.Add(-48 * time.Hour)},
+		{ID: "past-2", StartsAt: now.Add(-time.Hour)},
+		{ID: "past-3", StartsAt: now},
+		{ID: "future", StartsAt: now.Add(time.Hour)}, // 開始前の予定は集計しない
+	}
+	yes, no := true, false
+	rsvps := []*EventRSVP{
+		{UserID: alice, EventID: "past-1", Status: RSVPYes, Attended: &yes},
+		{UserID: alice, EventID: "past-2", Status: RSVPYes, Attended: &no},
+		{UserID: alice, EventID: "past-3", Status: RSVPNoResponse, Attended: &yes},
+		{UserID: alice, EventID: "future", Status: RSVPNo},
+		{UserID: bob, EventID: "past-1", Status: RSVPMaybe},
+	}
+
+	stats := NewMemberAttendance([]uuid.UUID{alice, bob}, events, rsvps, now)
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, MemberAttendance{
+		UserID:         alice,
+		Events:         3,
+		Yes:            2,
+		NoResponse:     1,
+		Attended:       2,
+		Absent:         1,
+		AttendanceRate: stats[0].AttendanceRate,
+	}, stats[0])
+	require.NotNil(t, stats[0].AttendanceRate)
+	assert.Equal(t, 66.7, *stats[0].AttendanceRate)
+
+	assert.Equal(t, 3, stats[1].Events)
+	assert.Equal(t, 1, stats[1].Maybe)
+	assert.Equal(t, 2, stats[1].NoResponse)
+	assert.Nil(t, stats[1].AttendanceRate)
+}
+
+func TestEventRecurrence_Validate(t *testing.T) {
+	until := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		recurrence EventRecurrence
+		wantError  bool
+	}{
+		{"weekly", EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Weekdays: []string{"fr", "MO"}, Timezone: "Asia/Tokyo"}, false},
+		{"monthly until", EventRecurrence{Frequency: RecurrenceMonthly, Interval: 2, Until: &until, Timezone: "UTC"}, false},
+		{"invalid frequency", EventRecurrence{Frequency: "YEARLY", Interval: 1, Timezone: "UTC"}, true},
+		{"zero interval", EventRecurrence{Frequency: RecurrenceDaily, Interval: 0, Timezone: "UTC"}, true},
+		{"too many occurrences", EventRecurrence{Frequency: RecurrenceDaily, Interval: 1, Count: MaxRecurrenceCount + 1, Timezone: "UTC"}, true},
+		{"count and until", EventRecurrence{Frequency: RecurrenceDaily, Interval: 1, Count: 3, Until: &until, Timezone: "UTC"}, true},
+		{"empty timezone", EventRecurrence{Frequency: RecurrenceDaily, Interval: 1}, true},
+		{"weekdays on daily", EventRecurrence{Frequency: RecurrenceDaily, Interval: 1, Weekdays: []string{"MO"}, Timezone: "UTC"}, true},
+		{"invalid weekday", EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Weekdays: []string{"XX"}, Timezone: "UTC"}, true},
+		{"duplicate weekday", EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Weekdays: []string{"MO", "mo"}, Timezone: "UTC"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.recurrence.Validate()
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// 曜日は大文字にして月曜日からの順に並べる
+	recurrence := EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Weekdays: []string{"fr", "MO"}, Timezone: "UTC"}
+	require.NoError(t, recurrence.Validate())
+	assert.Equal(t, []string{"MO", "FR"}, recurrence.Weekdays)
+}
+
+func TestEventRecurrence_Starts(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2024-06-03 は月曜日
+	first := time.Date(2024, 6, 3, 9, 0, 0, 0, tokyo)
+	format := func(starts []time.Time, loc *time.Location) []string {
+		formatted := make([]string, len(starts))
+		for i, start := range starts {
+			formatted[i] = start.In(loc).Format("2006-01-02 15:04")
+		}
+		return formatted
+	}
+
+	t.Run("daily with interval", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceDaily, Interval: 2, Timezone: "Asia/Tokyo"}
+		starts := recurrence.Starts(first, first, first.AddDate(0, 0, 7))
+		assert.Equal(t, []string{"2024-06-03 09:00", "2024-06-05 09:00", "2024-06-07 09:00", "2024-06-09 09:00"}, format(starts, tokyo))
+	})
+
+	t.Run("weekly on weekdays", func(t *testing.T) {
+		// 最初の回より前の曜日（月曜日の前の日曜日はない）は含めず、2週ごとに繰り返す
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 2, Weekdays: []string{"MO", "TH"}, Timezone: "Asia/Tokyo"}
+		starts := recurrence.Starts(first.AddDate(0, 0, 2), first, first.AddDate(0, 0, 28))
+		assert.Equal(t, []string{"2024-06-06 09:00", "2024-06-17 09:00", "2024-06-20 09:00"}, format(starts, tokyo))
+	})
+
+	t.Run("monthly skips missing days", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceMonthly, Interval: 1, Timezone: "UTC"}
+		start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+		starts := recurrence.Starts(start, start, start.AddDate(0, 7, 0))
+		assert.Equal(t, []string{"2024-01-31 10:00", "2024-03-31 10:00", "2024-05-31 10:00", "2024-07-31 10:00"}, format(starts, time.UTC))
+	})
+
+	t.Run("count includes occurrences before from", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceDaily, Interval: 1, Count: 3, Timezone: "Asia/Tokyo"}
+		starts := recurrence.Starts(first, first.AddDate(0, 0, 1), first.AddDate(0, 0, 30))
+		assert.Equal(t, []string{"2024-06-04 09:00", "2024-06-05 09:00"}, format(starts, tokyo))
+	})
+
+	t.Run("until is inclusive", func(t *testing.T) {
+		until := first.AddDate(0, 0, 14)
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Until: &until, Timezone: "Asia/Tokyo"}
+		starts := recurrence.Starts(first, first, first.AddDate(0, 0, 60))
+		assert.Equal(t, []string{"2024-06-03 09:00", "2024-06-10 09:00", "2024-06-17 09:00"}, format(starts, tokyo))
+	})
+
+	t.Run("keeps wall clock time across DST", func(t *testing.T) {
+		// 2024-03-10 に夏時間が始まっても 9:00 のまま繰り返す
+		start := time.Date(2024, 3, 8, 9, 0, 0, 0, newYork)
+		recurrence := &EventRecurrence{Frequency: RecurrenceDaily, Interval: 1, Timezone: "America/New_York"}
+		starts := recurrence.Starts(start, start, start.AddDate(0, 0, 4))
+		assert.Equal(t, []string{"2024-03-08 09:00", "2024-03-09 09:00", "2024-03-10 09:00", "2024-03-11 09:00"}, format(starts, newYork))
+		assert.Equal(t, 23*time.Hour, starts[2].Sub(starts[1]))
+	})
+
+	t.Run("includes", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Timezone: "Asia/Tokyo"}
+		assert.True(t, recurrence.Includes(first, first.AddDate(0, 0, 70)))
+		assert.False(t, recurrence.Includes(first, first.AddDate(0, 0, 71)))
+		assert.False(t, recurrence.Includes(first, first.AddDate(0, 0, -7)))
+	})
+}
+
+func TestParseOccurrenceID(t *testing.T) {
+	taskID := uuid.NewString()
+	original := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	id := OccurrenceID(taskID, original)
+	assert.Equal(t, taskID+"_20240603T000000Z", id)
+
+	eventID, parsed, err := ParseOccurrenceID(id)
+	require.NoError(t, err)
+	assert.Equal(t, taskID, eventID)
+	require.NotNil(t, parsed)
+	assert.True(t, original.Equal(*parsed))
+
+	// 回のIDでない場合は予定のIDをそのまま返す
+	eventID, parsed, err = ParseOccurrenceID(taskID)
+	require.NoError(t, err)
+	assert.Equal(t, taskID, eventID)
+	assert.Nil(t, parsed)
+
+	_, _, err = ParseOccurrenceID(taskID + "_tomorrow")
+	assert.Error(t, err)
+	_, _, err = ParseOccurrenceID("_20240603T000000Z")
+	assert.Error(t, err)
+}
+
+func TestExpandEvent(t *testing.T) {
+	first := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	event := &GroupEvent{ID: uuid.NewString(), Title: "Standup", StartsAt: first}
+	recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Timezone: "UTC"}
+	movedTo := first.AddDate(0, 0, 22) // 3回目を4回目の翌日に移動
+	exceptions := []*EventException{
+		{OriginalStartsAt: first.AddDate(0, 0, 7), Cancelled: true},
+		{OriginalStartsAt: first.AddDate(0, 0, 14), StartsAt: &movedTo},
+	}
+
+	// 繰り返しがない場合は期間内の予定そのもの
+	assert.Equal(t, []*GroupEvent{event}, ExpandEvent(event, nil, nil, first, first.AddDate(0, 0, 1), nil))
+	assert.Empty(t, ExpandEvent(event, nil, nil, first.AddDate(0, 0, 1), first.AddDate(0, 0, 2), nil))
+
+	occurrences := ExpandEvent(event, recurrence, exceptions, first, first.AddDate(0, 0, 28), nil)
+	require.Len(t, occurrences, 3)
+	assert.Equal(t, OccurrenceID(event.ID, first), occurrences[0].ID)
+	assert.Equal(t, event.ID, occurrences[0].SeriesID)
+	assert.Equal(t, first.AddDate(0, 0, 21), occurrences[1].StartsAt)
+	assert.False(t, occurrences[1].Moved)
+	assert.Equal(t, OccurrenceID(event.ID, first.AddDate(0, 0, 14)), occurrences[2].ID)
+	assert.Equal(t, movedTo, occurrences[2].StartsAt)
+	assert.True(t, occurrences[2].Moved)
+
+	// 元の開始日時が期間外でも、移動先が期間内なら含める
+	occurrences = ExpandEvent(event, recurrence, exceptions, first.AddDate(0, 0, 22), first.AddDate(0, 0, 23), nil)
+	require.Len(t, occurrences, 1)
+	assert.True(t, occurrences[0].Moved)
+
+	// 中止した回は見つからず、移動した回は変更後の日時で返す
+	assert.Nil(t, ResolveOccurrence(event, recurrence, exceptions, first.AddDate(0, 0, 7), nil))
+	assert.Nil(t, ResolveOccurrence(event, recurrence, exceptions, first.AddDate(0, 0, 8), nil))
+	resolved := ResolveOccurrence(event, recurrence, exceptions, first.AddDate(0, 0, 14), nil)
+	require.NotNil(t, resolved)
+	assert.Equal(t, movedTo, resolved.StartsAt)
+}
+
+func TestExpandEvent_HolidayPolicy(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	// 毎週月曜日の定例、2024-07-15 は海の日
+	first := time.Date(2024, 7, 8, 10, 0, 0, 0, tokyo)
+	holidayMonday := time.Date(2024, 7, 15, 10, 0, 0, 0, tokyo)
+	event := &GroupEvent{ID: uuid.NewString(), Title: "定例", StartsAt: first}
+	format := func(events []*GroupEvent) []string {
+		formatted := make([]string, len(events))
+		for i, event := range events {
+			formatted[i] = event.StartsAt.In(tokyo).Format("2006-01-02 15:04")
+		}
+		return formatted
+	}
+
+	t.Run("keep", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Timezone: "Asia/Tokyo"}
+		occurrences := ExpandEvent(event, recurrence, nil, first, first.AddDate(0, 0, 21), holiday.Japan())
+		assert.Equal(t, []string{"2024-07-08 10:00", "2024-07-15 10:00", "2024-07-22 10:00"}, format(occurrences))
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Timezone: "Asia/Tokyo", HolidayPolicy: RecurrenceHolidaySkip}
+		occurrences := ExpandEvent(event, recurrence, nil, first, first.AddDate(0, 0, 21), holiday.Japan())
+		assert.Equal(t, []string{"2024-07-08 10:00", "2024-07-22 10:00"}, format(occurrences))
+		assert.Nil(t, ResolveOccurrence(event, recurrence, nil, holidayMonday.UTC(), holiday.Japan()))
+
+		// 祝日の取得元がない場合は祝日として扱う日がない
+		assert.Len(t, ExpandEvent(event, recurrence, nil, first, first.AddDate(0, 0, 21), nil), 3)
+	})
+
+	t.Run("next business day", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Timezone: "Asia/Tokyo", HolidayPolicy: RecurrenceHolidayNextBusinessDay}
+		occurrences := ExpandEvent(event, recurrence, nil, first, first.AddDate(0, 0, 21), holiday.Japan())
+		assert.Equal(t, []string{"2024-07-08 10:00", "2024-07-16 10:00", "2024-07-22 10:00"}, format(occurrences))
+		assert.Equal(t, OccurrenceID(event.ID, holidayMonday), occurrences[1].ID)
+		assert.True(t, occurrences[1].Moved)
+
+		// 元の開始日時が期間の前でも、移動先が期間内なら含める
+		from := time.Date(2024, 7, 16, 0, 0, 0, 0, tokyo)
+		occurrences = ExpandEvent(event, recurrence, nil, from, from.AddDate(0, 0, 1), holiday.Japan())
+		assert.Equal(t, []string{"2024-07-16 10:00"}, format(occurrences))
+
+		resolved := ResolveOccurrence(event, recurrence, nil, holidayMonday.UTC(), holiday.Japan())
+		require.NotNil(t, resolved)
+		assert.Equal(t, "2024-07-16 10:00", resolved.StartsAt.In(tokyo).Format("2006-01-02 15:04"))
+
+		// 回ごとの変更は祝日の扱いより優先する
+		cancelled := []*EventException{{OriginalStartsAt: holidayMonday.UTC(), Cancelled: true}}
+		assert.Len(t, ExpandEvent(event, recurrence, cancelled, first, first.AddDate(0, 0, 21), holiday.Japan()), 2)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		recurrence := &EventRecurrence{Frequency: RecurrenceWeekly, Interval: 1, Timezone: "Asia/Tokyo", HolidayPolicy: "PREVIOUS_BUSINESS_DAY"}
+		assert.Error(t, recurrence.Validate())
+	})
+}
+
+func TestEventNote_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		note      EventNote
+		wantError bool
+	}{
+		{"valid", EventNote{Title: "定例会", Content: "- [ ] 資料を共有する"}, false},
+		{"empty content", EventNote{Title: "定例会"}, false},
+		{"blank title", EventNote{Title: "   "}, true},
+		{"title too long", EventNote{Title: strings.Repeat("議", MaxNoteTitleLength+1)}, true},
+		{"content too long", EventNote{Title: "定例会", Content: strings.Repeat("a", MaxNoteContentLength+1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.note.Validate()
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestExtractActionItems(t *testing.T) {
+	content := strings.Join([]string{
+		"# 定例会",
+		"- [ ] 資料を共有する @alice @bob",
+		"  * [ ] 会議室を予約する（@carol.）",
+		"- [x] 議事録を書く @alice",
+		"- 通常の箇条書き",
+		"- [ ] 資料を共有する @alice @bob",
+		"```",
+		"- [ ] コードブロックの中は無視する",
+		"```",
+		"- [ ] 見積もりを送る user@example.com",
+	}, "\n")
+
+	items := ExtractActionItems(content)
+	require.Len(t, items, 3)
+	assert.Equal(t, ActionItem{Text: "資料を共有する @alice @bob", Mentions: []string{"alice", "bob"}}, items[0])
+	// 末尾の句読点はユーザー名に含めない
+	assert.Equal(t, []string{"carol"}, items[1].Mentions)
+	// メールアドレスはメンションとして扱わない
+	assert.Equal(t, "見積もりを送る user@example.com", items[2].Text)
+	assert.Empty(t, items[2].Mentions)
+
+	// タスクのタイトルの上限を超える行は文字の途中で切らずに切り詰める
+	long := ExtractActionItems("- [ ] " + strings.Repeat("あ", 100))
+	require.Len(t, long, 1)
+	assert.LessOrEqual(t, len(long[0].Text), MaxActionItemBytes)
+	assert.True(t, utf8.ValidString(long[0].Text))
+
+	// 1つの議事録から作成するタスクは上限まで
+	var many []string
+	for i := 0; i < MaxNoteActionItems+5; i++ {
+		many = append(many, fmt.Sprintf("- [ ] item %d", i))
+	}
+	assert.Len(t, ExtractActionItems(strings.Join(many, "\n")), MaxNoteActionItems)
+}
+
+func TestFollowUpTitle(t *testing.T) {
+	assert.Equal(t, "「定例会」のフォローアップ", FollowUpTitle("定例会"))
+
+	// 長いタイトルはタスクのタイトルの上限に収める
+	title := FollowUpTitle(strings.Repeat("予", 100))
+	assert.LessOrEqual(t, len(title), MaxTaskTitleBytes)
+	assert.True(t, utf8.ValidString(title))
+	assert.True(t, strings.HasSuffix(title, "」のフォローアップ"))
+}
+
+func TestChatIntegration_Validate(t *testing.T) {
+	valid := "https://discord.com/api/webhooks/123/token"
+	tests := []struct {
+		name      string
+		modify    func(c *ChatIntegration)
+		wantError bool
+	}{
+		{"valid", func(c *ChatIntegration) {}, false},
+		{"webhook url with spaces", func(c *ChatIntegration) { c.WebhookURL = "  " + valid + " " }, false},
+		{"not https", func(c *ChatIntegration) { c.WebhookURL = "http://discord.com/api/webhooks/123/token" }, true},
+		{"other host", func(c *ChatIntegration) { c.WebhookURL = "https://example.com/api/webhooks/123/token" }, true},
+		{"other path", func(c *ChatIntegration) { c.WebhookURL = "https://discord.com/channels/123" }, true},
+		{"with port", func(c *ChatIntegration) { c.WebhookURL = "https://discord.com:8443/api/webhooks/123/token" }, true},
+		{"invalid event", func(c *ChatIntegration) { c.Events = []ChatEvent{"TASK_DELETED"} }, true},
+		{"invalid template", func(c *ChatIntegration) { c.Templates = map[ChatEvent]string{ChatEventTaskCompleted: "{{.task_title"} }, true},
+		{"template too long", func(c *ChatIntegration) {
+			c.Templates = map[ChatEvent]string{ChatEventTaskCompleted: strings.Repeat("あ", MaxChatTemplateLength+1)}
+		}, true},
+		{"invalid summary hour", func(c *ChatIntegration) { c.SummaryHour = 24 }, true},
+		{"invalid due reminder hours", func(c *ChatIntegration) { c.DueReminderHours = 0 }, true},
+		{"due reminder hours too long", func(c *ChatIntegration) { c.DueReminderHours = MaxChatDueReminderHours + 1 }, true},
+		{"invalid timezone", func(c *ChatIntegration) { c.Timezone = "Mars/Olympus" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			integration := NewChatIntegration(uuid.New(), ChatPlatformDiscord, valid, uuid.New())
+			tt.modify(integration)
+			err := integration.Validate()
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, valid, integration.WebhookURL)
+		})
+	}
+
+	// 出来事は重複を除いて決まった順に並べ、空のテンプレートは取り除く
+	integration := NewChatIntegration(uuid.New(), ChatPlatformDiscord, valid, uuid.New())
+	integration.Events = []ChatEvent{ChatEventDailySummary, ChatEventTaskCompleted, ChatEventDailySummary}
+	integration.Templates = map[ChatEvent]string{ChatEventTaskCompleted: " "}
+	require.NoError(t, integration.Validate())
+	assert.Equal(t, []ChatEvent{ChatEventTaskCompleted, ChatEventDailySummary}, integration.Events)
+	assert.Empty(t, integration.Templates)
+
+	// WebhookのURLのトークンは伏せる
+	assert.Equal(t, "https://discord.com/api/webhooks/123/****", integration.MaskedWebhookURL())
+
+	// Teamsは受信Webhookとワークフローのホストのみ受け付ける
+	teamsURLs := []struct {
+		url       string
+		wantError bool
+	}{
+		{"https://contoso.webhook.office.com/webhookb2/abc@def/IncomingWebhook/123/456", false},
+		{"https://prod-01.japaneast.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke?api-version=2016-06-01&sig=secret", false},
+		{"https://default123.environment.api.powerplatform.com/powerautomate/automations/direct/workflows/abc/triggers/manual/paths/invoke?sig=secret", false},
+		{"https://contoso.webhook.office.com/other/abc", true},
+		{"https://prod-01.japaneast.logic.azure.com:8443/workflows/abc", true},
+		{"https://logic.azure.com.example.com/workflows/abc", true},
+		{"https://discord.com/api/webhooks/123/token", true},
+	}
+	for _, tt := range teamsURLs {
+		err := NewChatIntegration(uuid.New(), ChatPlatformTeams, tt.url, uuid.New()).Validate()
+		assert.Equal(t, tt.wantError, err != nil, tt.url)
+	}
+	// ワークフローのWebhookはクエリの署名も伏せる
+	teams := NewChatIntegration(uuid.New(), ChatPlatformTeams, teamsURLs[1].url, uuid.New())
+	assert.Equal(t, "https://prod-01.japaneast.logic.azure.com:443/workflows/abc/triggers/manual/paths/****", teams.MaskedWebhookURL())
+
+	platform, err := ParseChatPlatform(" discord ")
+	require.NoError(t, err)
+	assert.Equal(t, ChatPlatformDiscord, platform)
+	_, err = ParseChatPlatform("irc")
+	assert.Error(t, err)
+}
+
+func TestChatIntegration_Render(t *testing.T) {
+	integration := NewChatIntegration(uuid.New(), ChatPlatformDiscord, "https://discord.com/api/webhooks/123/token", uuid.New())
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	due := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	task := &ChatTask{ID: "task-1", Title: "資料作成", DueDate: &due}
+
+	message, err := integration.Render(ChatEventTaskAssigned, ChatTaskVars("Project", task, []string{"alice", "bob"}, tokyo))
+	require.NoError(t, err)
+	assert.Equal(t, ChatEventTaskAssigned, message.Event)
+	assert.Equal(t, "📌 **資料作成** を alice, bob に割り当てました（期限: 2024-01-10 18:00）", message.Text)
+	// カード形式の連携先向けに見出しと項目を添える
+	assert.Equal(t, "タスクの割り当て", message.Title)
+	assert.Equal(t, []ChatFact{
+		{Name: "グループ", Value: "Project"},
+		{Name: "タスク", Value: "資料作成"},
+		{Name: "担当", Value: "alice, bob"},
+		{Name: "期限", Value: "2024-01-10 18:00"},
+	}, message.Facts)
+
+	// 担当者がいない場合は省略する
+	message, err = integration.Render(ChatEventTaskCompleted, ChatTaskVars("Project", task, nil, tokyo))
+	require.NoError(t, err)
+	assert.Equal(t, "✅ **資料作成** が完了しました", message.Text)
+	assert.Len(t, message.Facts, 2)
+
+	// 変更したテンプレートを使い、ない変数は空文字列にする
+	integration.Templates = map[ChatEvent]string{ChatEventTaskCompleted: "[{{.group_name}}] {{.task_title}} {{.unknown}}"}
+	message, err = integration.Render(ChatEventTaskCompleted, ChatTaskVars("Project", task, nil, tokyo))
+	require.NoError(t, err)
+	assert.Equal(t, "[Project] 資料作成", message.Text)
+}
+
+func TestChatIntegration_SummaryDue(t *testing.T) {
+	integration := NewChatIntegration(uuid.New(), ChatPlatformDiscord, "https://discord.com/api/webhooks/123/token", uuid.New())
+	integration.Timezone = "Asia/Tokyo"
+
+	// Asia/Tokyo の9時（UTCの0時）を過ぎてから投稿する
+	assert.False(t, integration.SummaryDue(time.Date(2024, 1, 9, 23, 59, 0, 0, time.UTC)))
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	assert.True(t, integration.SummaryDue(now))
+
+	// 1日に1回だけ投稿する
+	integration.MarkSummarySent(now)
+	assert.Equal(t, "2024-01-10", integration.LastSummaryDate)
+	assert.False(t, integration.SummaryDue(now.Add(10*time.Hour)))
+	assert.True(t, integration.SummaryDue(now.Add(24*time.Hour)))
+
+	// 1日のまとめを投稿しない・無効にした連携は投稿しない
+	integration.Events = []ChatEvent{ChatEventTaskCompleted}
+	assert.False(t, integration.SummaryDue(now.Add(24*time.Hour)))
+	integration.Events = ChatEvents
+	integration.Enabled = false
+	assert.False(t, integration.SummaryDue(now.Add(24*time.Hour)))
+}
+
+func TestNewDailySummary(t *testing.T) {
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	tasks := []*ChatTask{
+		{Title: "昨日完了", Done: true, CompletedAt: at(-2 * time.Hour)},
+		{Title: "先週完了", Done: true, CompletedAt: at(-48 * time.Hour)},
+		{Title: "今日の夕方", DueDate: at(8 * time.Hour)},
+		{Title: "今日の昼", DueDate: at(3 * time.Hour)},
+		{Title: "明日", DueDate: at(20 * time.Hour)},
+		{Title: "期限切れ", DueDate: at(-time.Hour)},
+		{Title: "期限なし"},
+	}
+
+	summary := NewDailySummary(tasks, now, time.UTC)
+	assert.Equal(t, "2024-01-10", summary.Date)
+	require.Len(t, summary.Completed, 1)
+	assert.Equal(t, "昨日完了", summary.Completed[0].Title)
+	require.Len(t, summary.DueToday, 2)
+	assert.Equal(t, "今日の昼", summary.DueToday[0].Title)
+	require.Len(t, summary.Overdue, 1)
+	assert.Equal(t, 5, summary.OpenCount)
+	assert.False(t, summary.IsEmpty())
+
+	vars := summary.Vars("Project", time.UTC)
+	assert.Equal(t, "2", vars["due_today_count"])
+	assert.Equal(t, "- 今日の昼（12:00まで）\n- 今日の夕方（17:00まで）", vars["due_today"])
+	assert.Equal(t, "- 期限切れ（期限 1/10 08:00）", vars["overdue"])
+
+	// 一覧は上限を超える分を件数だけ載せる
+	var many []*ChatTask
+	for i := 0; i < MaxChatSummaryTasks+3; i++ {
+		many = append(many, &ChatTask{Title: fmt.Sprintf("task %d", i), DueDate: at(-time.Duration(i+1) * time.Minute)})
+	}
+	overdue := NewDailySummary(many, now, time.UTC).Vars("Project", time.UTC)["overdue"]
+	assert.Len(t, strings.Split(overdue, "\n"), MaxChatSummaryTasks+1)
+	assert.True(t, strings.HasSuffix(overdue, "ほか3件"))
+
+	assert.True(t, NewDailySummary(nil, now, time.UTC).IsEmpty())
+}
+
+func TestChatIntegration_DueWindow(t *testing.T) {
+	integration := NewChatIntegration(uuid.New(), ChatPlatformTeams, "https://contoso.webhook.office.com/webhookb2/abc", uuid.New())
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	// 初回は now から DueReminderHours 時間後までが対象
+	from, to, ok := integration.DueWindow(now)
+	require.True(t, ok)
+	assert.Equal(t, now, from)
+	assert.Equal(t, now.Add(24*time.Hour), to)
+
+	// 2回目以降は前回の続きから
+	integration.MarkDueChecked(to)
+	from, to, ok = integration.DueWindow(now.Add(15 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, now.Add(24*time.Hour), from)
+	assert.Equal(t, now.Add(24*time.Hour+15*time.Minute), to)
+
+	// 前回から時間が経っていない・リマインダーを投稿しない連携は対象がない
+	_, _, ok = integration.DueWindow(now)
+	assert.False(t, ok)
+	integration.Events = []ChatEvent{ChatEventTaskCompleted}
+	_, _, ok = integration.DueWindow(now.Add(time.Hour))
+	assert.False(t, ok)
+}
+
+func TestDueSoonTasks(t *testing.T) {
+	from := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	at := func(d time.Duration) *time.Time {
+		v := from.Add(d)
+		return &v
+	}
+	tasks := []*ChatTask{
+		{Title: "夕方", DueDate: at(8 * time.Hour)},
+		{Title: "昼", DueDate: at(3 * time.Hour)},
+		{Title: "範囲の終わり", DueDate: at(24 * time.Hour)},
+		{Title: "範囲の始まり", DueDate: at(0)},
+		{Title: "明後日", DueDate: at(30 * time.Hour)},
+		{Title: "完了済み", Done: true, DueDate: at(time.Hour)},
+		{Title: "期限なし"},
+	}
+
+	due := DueSoonTasks(tasks, from, to)
+	titles := make([]string, len(due))
+	for i, task := range due {
+		titles[i] = task.Title
+	}
+	assert.Equal(t, []string{"昼", "夕方", "範囲の終わり"}, titles)
+}
+
+func TestScheduleCardLink_Verify(t *testing.T) {
+	secret := []byte("card-secret")
+	groupID := uuid.New()
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	// 日本時間では翌日
+	now := time.Date(2024, 6, 2, 20, 0, 0, 0, time.UTC)
+
+	link := NewScheduleCardLink(groupID, now, tokyo, secret)
+	assert.Equal(t, "2024-06-03", link.StartDate)
+	assert.True(t, link.Verify(secret, now))
+	assert.True(t, link.Verify(secret, now.Add(ScheduleCardLinkTTL-time.Second)))
+	assert.False(t, link.Verify(secret, now.Add(ScheduleCardLinkTTL)))
+	assert.False(t, link.Verify([]byte("other-secret"), now))
+	assert.False(t, link.Verify(nil, now))
+
+	// 開始日・有効期限・グループを変えたリンクは無効
+	tampered := *link
+	tampered.StartDate = "2024-06-10"
+	assert.False(t, tampered.Verify(secret, now))
+	tampered = *link
+	tampered.ExpiresAt = tampered.ExpiresAt.Add(24 * time.Hour)
+	assert.False(t, tampered.Verify(secret, now))
+	tampered = *link
+	tampered.GroupID = uuid.New()
+	assert.False(t, tampered.Verify(secret, now))
+
+	from, to, err := link.Range(tokyo)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo), from)
+	assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, tokyo), to)
+
+	path := link.PublicPath(ScheduleCardPDF)
+	assert.True(t, strings.HasPrefix(path, ScheduleCardPublicPathPrefix+groupID.String()+"/schedule-card?"))
+	assert.Contains(t, path, "start=2024-06-03")
+	assert.Contains(t, path, "format=pdf")
+}
+
+func TestNewScheduleCard(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo)
+	at := func(day, hour int) time.Time {
+		return from.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour).UTC()
+	}
+	events := []*GroupEvent{
+		{Title: "夕食", StartsAt: at(0, 19)},
+		{Title: "朝会", StartsAt: at(0, 9)},
+		{Title: "範囲外", StartsAt: at(7, 9)},
+	}
+	for i := 0; i < MaxScheduleCardEventsPerDay+2; i++ {
+		events = append(events, &GroupEvent{Title: fmt.Sprintf("練習%d", i), StartsAt: at(2, 10+i)})
+	}
+	schedule := &EventSchedule{GroupName: "Family", Events: events}
+	settings := NewScheduleCardSettings(uuid.New())
+	settings.Timezone = "Asia/Tokyo"
+
+	card := NewScheduleCard(schedule, settings, from)
+	assert.Equal(t, "Family", card.GroupName)
+	require.Len(t, card.Days, ScheduleCardDays)
+	assert.Equal(t, []ScheduleCardEvent{{Time: "09:00", Title: "朝会"}, {Time: "19:00", Title: "夕食"}}, card.Days[0].Events)
+	assert.Empty(t, card.Days[1].Events)
+	assert.Len(t, card.Days[2].Events, MaxScheduleCardEventsPerDay)
+	assert.Equal(t, 2, card.Days[2].More)
+	assert.Equal(t, time.Date(2024, 6, 9, 0, 0, 0, 0, tokyo), card.Days[6].Date)
+
+	// タイトルを表示しない設定では伏せる
+	settings.ShowTitles = false
+	card = NewScheduleCard(schedule, settings, from)
+	assert.Equal(t, ScheduleCardHiddenTitle, card.Days[0].Events[0].Title)
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RSVPStatus は予定への出欠の回答
+type RSVPStatus string
+
+const (
+	// RSVPYes は参加する
+	RSVPYes RSVPStatus = "YES"
+	// RSVPNo は参加しない
+	RSVPNo RSVPStatus = "NO"
+	// RSVPMaybe は未定
+	RSVPMaybe RSVPStatus = "MAYBE"
+	// RSVPNoResponse は未回答（回答せずに出席だけ記録したメンバーにも使う）
+	RSVPNoResponse RSVPStatus = "NO_RESPONSE"
+)
+
+// IsValid はメンバーが回答できる出欠かチェック（NO_RESPONSE は回答として受け付けない）
+func (s RSVPStatus) IsValid() bool {
+	switch s {
+	case RSVPYes, RSVPNo, RSVPMaybe:
+		return true
+	}
+	return false
+}
+
+// GroupEvent は予定共有グループの予定（開始日時のあるグループタスク）
+// 繰り返しの予定は回ごとに ID の異なる GroupEvent として扱う
+type GroupEvent struct {
+	ID               string         `json:"id"` // タスクID（繰り返しの予定の回はタスクIDと元の開始日時から作る回のID）
+	GroupID          uuid.UUID      `json:"group_id"`
+	Title            string         `json:"title"`
+	StartsAt         time.Time      `json:"starts_at"`
+	CreatedBy        uuid.UUID      `json:"created_by"`
+	SeriesID         string         `json:"series_id,omitempty"`          // 繰り返しの予定の回の場合、最初の回の予定（タスク）のID
+	OriginalStartsAt *time.Time     `json:"original_starts_at,omitempty"` // 繰り返しの予定の回の元の開始日時
+	Moved            bool           `json:"moved,omitempty"`              // 日時を変更した回か
+	Location         *EventLocation `json:"location,omitempty"`           // 予定のタスクに設定した場所（未設定の場合はnil）
+}
+
+// EventLocation は予定の場所（予定のタスクに設定した場所）
+type EventLocation struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// HasStarted は予定が開始しているか（開始後は出欠を回答できず、出席を記録できる）
+func (e *GroupEvent) HasStarted(now time.Time) bool {
+	return !now.Before(e.StartsAt)
+}
+
+// EventRSVP はメンバーの予定への出欠の回答と出席の記録
+type EventRSVP struct {
+	GroupID     uuid.UUID  `json:"group_id"`
+	EventID     string     `json:"event_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      RSVPStatus `json:"status"`
+	Attended    *bool      `json:"attended,omitempty"`     // 出席の記録（未記録の場合はnil）
+	RespondedAt *time.Time `json:"responded_at,omitempty"` // 出欠を回答した日時（未回答の場合はnil）
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// EventAttendee は予定の出欠一覧の1行
+type EventAttendee struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      RSVPStatus `json:"status"`
+	Attended    *bool      `json:"attended,omitempty"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// EventAttendees は予定のメンバー全員の出欠
+type EventAttendees struct {
+	Event      *GroupEvent     `json:"event"`
+	Attendees  []EventAttendee `json:"attendees"`
+	Yes        int             `json:"yes"`
+	No         int             `json:"no"`
+	Maybe      int             `json:"maybe"`
+	NoResponse int             `json:"no_response"`
+}
+
+// NewEventAttendees はメンバーと出欠の回答から出欠一覧を作成する（メンバーの順に並べ、脱退したメンバーの回答は含めない）
+func NewEventAttendees(event *GroupEvent, memberIDs []uuid.UUID, rsvps []*EventRSVP) *EventAttendees {
+	byUser := make(map[uuid.UUID]*EventRSVP, len(rsvps))
+	for _, rsvp := range rsvps {
+		byUser[rsvp.UserID] = rsvp
+	}
+
+	attendees := &EventAttendees{Event: event, Attendees: make([]EventAttendee, 0, len(memberIDs))}
+	for _, userID := range memberIDs {
+		attendee := EventAttendee{UserID: userID, Status: RSVPNoResponse}
+		if rsvp, ok := byUser[userID]; ok {
+			attendee.Status = rsvp.Status
+			attendee.Attended = rsvp.Attended
+			attendee.RespondedAt = rsvp.RespondedAt
+		}
+		switch attendee.Status {
+		case RSVPYes:
+			attendees.Yes++
+		case RSVPNo:
+			attendees.No++
+		case RSVPMaybe:
+			attendees.Maybe++
+		default:
+			attendees.NoResponse++
+		}
+		attendees.Attendees = append(attendees.Attendees, attendee)
+	}
+	return attendees
+}
+
+// MemberAttendance はメンバーごとの開始済みの予定の出欠の集計
+type MemberAttendance struct {
+	UserID         uuid.UUID `json:"user_id"`
+	Events         int       `json:"events"`          // 集計した開始済みの予定の数
+	Yes            int       `json:"yes"`             // 参加すると回答した数
+	No             int       `json:"no"`              // 参加しないと回答した数
+	Maybe          int       `json:"maybe"`           // 未定と回答した数
+	NoResponse     int       `json:"no_response"`     // 回答しなかった数
+	Attended       int       `json:"attended"`        // 出席を記録した数
+	Absent         int       `json:"absent"`          // 欠席を記録した数
+	AttendanceRate *float64  `json:"attendance_rate"` // 出席・欠席を記録した予定のうち出席した割合（0-100）、記録がない場合はnull
+}
+
+// NewMemberAttendance は開始済みの予定と出欠の回答からメンバーごとの出欠を集計する（メンバーの順に並べる）
+func NewMemberAttendance(memberIDs []uuid.UUID, events []*GroupEvent, rsvps []*EventRSVP, now time.Time) []MemberAttendance {
+	started := make(map[string]bool, len(events))
+	for _, event := range events {
+		if event.HasStarted(now) {
+			started[event.ID] = true
+		}
+	}
+
+	byMember := make(map[uuid.UUID]map[string]*EventRSVP, len(memberIDs))
+	for _, rsvp := range rsvps {
+		if !started[rsvp.EventID] {
+			continue
+		}
+		if byMember[rsvp.UserID] == nil {
+			byMember[rsvp.UserID] = make(map[string]*EventRSVP)
+		}
+		byMember[rsvp.UserID][rsvp.EventID] = rsvp
+	}
+
+	stats := make([]MemberAttendance, 0, len(memberIDs))
+	for _, userID := range memberIDs {
+		stat := MemberAttendance{UserID: userID, Events: len(started)}
+		responses := byMember[userID]
+		for eventID := range started {
+			rsvp, ok := responses[eventID]
+			if !ok {
+				stat.NoResponse++
+				continue
+			}
+			switch rsvp.Status {
+			case RSVPYes:
+				stat.Yes++
+			case RSVPNo:
+				stat.No++
+			case RSVPMaybe:
+				stat.Maybe++
+			default:
+				stat.NoResponse++
+			}
+			if rsvp.Attended != nil {
+				if *rsvp.Attended {
+					stat.Attended++
+				} else {
+					stat.Absent++
+				}
+			}
+		}
+		if recorded := stat.Attended + stat.Absent; recorded > 0 {
+			rate := math.Round(float64(stat.Attended)/float64(recorded)*1000) / 10
+			stat.AttendanceRate = &rate
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxBlockMinutes はタスクの作業時間として作成する予定の最大の長さ（分）
+	MaxBlockMinutes = 24 * 60
+	// MaxTaskTitleBytes は作成するタスクのタイトルの最大バイト数（タスクモジュールの上限に合わせる）
+	MaxTaskTitleBytes = 255
+	// MaxTaskDescriptionBytes は作成するタスクの説明の最大バイト数（タスクモジュールの上限に合わせる）
+	MaxTaskDescriptionBytes = 2000
+)
+
+// EventTaskLinkKind は予定とタスクの関連付けの種類
+type EventTaskLinkKind string
+
+const (
+	// EventTaskLinkBlock はタスクの作業時間として予定を作成した関連付け（TaskID が元のタスク）
+	EventTaskLinkBlock EventTaskLinkKind = "BLOCK"
+	// EventTaskLinkFollowUp は予定からフォローアップのタスクを作成した関連付け（TaskID が作成したタスク）
+	EventTaskLinkFollowUp EventTaskLinkKind = "FOLLOW_UP"
+)
+
+// EventTaskLink は予定とタスクの関連付け（予定とタスクの詳細の両方に表示する）
+type EventTaskLink struct {
+	GroupID   uuid.UUID         `json:"group_id"`
+	EventID   string            `json:"event_id"` // 予定のID（繰り返しの予定は回のID）
+	TaskID    string            `json:"task_id"`
+	Kind      EventTaskLinkKind `json:"kind"`
+	CreatedBy uuid.UUID         `json:"created_by"`
+	CreatedAt time.Time         `json:"created_at"`
+	Task      *LinkedTask       `json:"task,omitempty"` // 関連付けたタスク（予定の詳細の表示用）
+}
+
+// LinkedTask は予定に関連付けたタスクの概要
+type LinkedTask struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Status          string `json:"status"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty"`
+}
+
+// EventDetail は予定と関連付けたタスク
+type EventDetail struct {
+	Event *GroupEvent      `json:"event"`
+	Links []*EventTaskLink `json:"links"`
+}
+
+// FollowUpTitle は予定のフォローアップのタスクの既定のタイトルを返す（タスクのタイトルの上限に収める）
+func FollowUpTitle(eventTitle string) string {
+	const format = "「%s」のフォローアップ"
+	room := MaxTaskTitleBytes - len(fmt.Sprintf(format, ""))
+	return fmt.Sprintf(format, truncateBytes(eventTitle, room))
+}
@@ -22,22 +22,27 @@ const (
 	RoleOwner  MemberRole = "OWNER"  // 所有者
 	RoleAdmin  MemberRole = "ADMIN"  // 管理者
 	RoleMember MemberRole = "MEMBER" // メンバー
+	RoleGuest  MemberRole = "GUEST"  // ゲスト（閲覧のみ）
 )
 
 // Group はグループ情報を表すドメインエンティティ
 type Group struct {
-	ID          uuid.UUID     `json:"id"`
-	Name        string        `json:"name"`
-	Description string        `json:"description"`
-	Type        GroupType     `json:"type"`
-	OwnerID     uuid.UUID     `json:"owner_id"`
-	Settings    GroupSettings `json:"settings"`
-	MemberCount int           `json:"member_count"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-	Version     int           `json:"version"` // 楽観的ロック用
+	ID            uuid.UUID     `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Type          GroupType     `json:"type"`
+	OwnerID       uuid.UUID     `json:"owner_id"`
+	ParentGroupID *uuid.UUID    `json:"parent_group_id,omitempty"` // サブチームの場合の親グループ
+	Settings      GroupSettings `json:"settings"`
+	MemberCount   int           `json:"member_count"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	Version       int           `json:"version"` // 楽観的ロック用
 }
 
+// MaxGroupDepth はグループ階層の最大の深さ（最上位のグループを1とする）
+const MaxGroupDepth = 4
+
 // GroupSettings はグループの設定を表す
 type GroupSettings struct {
 	IsPublic            bool `json:"is_public"`            // 公開/非公開
@@ -81,6 +86,21 @@ func NewGroup(name, description string, groupType GroupType, ownerID uuid.UUID)
 	}
 }
 
+// CanHaveSubGroups はサブチームを作成できるグループかチェック（プロジェクトグループのみ）
+func (g *Group) CanHaveSubGroups() bool {
+	return g.Type == GroupTypeProject
+}
+
+// IsSubGroup は親グループを持つサブチームかチェック
+func (g *Group) IsSubGroup() bool {
+	return g.ParentGroupID != nil
+}
+
+// DefaultSettings はグループタイプに応じたデフォルト設定を返す
+func DefaultSettings(groupType GroupType) GroupSettings {
+	return getDefaultSettings(groupType)
+}
+
 // getDefaultSettings はグループタイプに応じたデフォルト設定を返す
 func getDefaultSettings(groupType GroupType) GroupSettings {
 	base := GroupSettings{
@@ -116,6 +136,13 @@ func (g *Group) AddMember() {
 	g.Version++
 }
 
+// AddMembers はメンバー数を一括で増加させる
+func (g *Group) AddMembers(count int) {
+	g.MemberCount += count
+	g.UpdatedAt = time.Now()
+	g.Version++
+}
+
 // RemoveMember はメンバー数を減少させる
 func (g *Group) RemoveMember() error {
 	if g.MemberCount <= 1 {
@@ -195,4 +222,7 @@ type GroupStats struct {
 	TaskCount     int `json:"task_count,omitempty"`     // プロジェクトグループの場合
 	ScheduleCount int `json:"schedule_count,omitempty"` // 予定共有グループの場合
 	ActiveMembers int `json:"active_members"`           // 最近活動したメンバー数
+
+	// Attendance はメンバーごとの予定の出欠（予定共有グループの場合）
+	Attendance []MemberAttendance `json:"attendance,omitempty"`
 }
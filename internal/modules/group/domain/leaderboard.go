@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxLeaderboardStreakDays は連続達成日数を数える日数の上限
+const MaxLeaderboardStreakDays = 90
+
+// AnonymousMemberName は匿名で参加するメンバーの表示名
+const AnonymousMemberName = "匿名メンバー"
+
+// LeaderboardVisibility はリーダーボードでのメンバーの公開範囲
+type LeaderboardVisibility string
+
+const (
+	// LeaderboardVisible は名前を表示する（デフォルト）
+	LeaderboardVisible LeaderboardVisibility = "VISIBLE"
+	// LeaderboardAnonymous は名前を伏せて順位に参加する
+	LeaderboardAnonymous LeaderboardVisibility = "ANONYMOUS"
+	// LeaderboardHidden はリーダーボードに参加しない
+	LeaderboardHidden LeaderboardVisibility = "HIDDEN"
+)
+
+// IsValid は有効な公開範囲かチェック
+func (v LeaderboardVisibility) IsValid() bool {
+	switch v {
+	case LeaderboardVisible, LeaderboardAnonymous, LeaderboardHidden:
+		return true
+	}
+	return false
+}
+
+// LeaderboardSettings はグループのリーダーボード設定
+// リーダーボードは有効にしたグループのみ表示し、週（Timezone の月曜日0時）ごとに集計をリセットする
+type LeaderboardSettings struct {
+	GroupID   uuid.UUID `json:"group_id"`
+	Enabled   bool      `json:"enabled"`
+	Timezone  string    `json:"timezone"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewLeaderboardSettings はリーダーボードを無効にした設定を作成する
+func NewLeaderboardSettings(groupID uuid.UUID) *LeaderboardSettings {
+	return &LeaderboardSettings{
+		GroupID:   groupID,
+		Enabled:   false,
+		Timezone:  "UTC",
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Location は週の区切りに使うタイムゾーンを返す（不正な場合は UTC）
+func (s *LeaderboardSettings) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// LeaderboardPreference はメンバーごとのリーダーボードの公開範囲
+type LeaderboardPreference struct {
+	GroupID    uuid.UUID             `json:"group_id"`
+	UserID     uuid.UUID             `json:"user_id"`
+	Visibility LeaderboardVisibility `json:"visibility"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// LeaderboardWeek はtを含む週（月曜日0時から翌週の月曜日0時まで）を返す
+func LeaderboardWeek(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := t.In(loc)
+	daysFromMonday := (int(local.Weekday()) + 6) % 7
+	start := time.Date(local.Year(), local.Month(), local.Day()-daysFromMonday, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// TaskCompletion はメンバーがグループタスクを完了した記録
+type TaskCompletion struct {
+	CompletedAt time.Time
+	DueDate     *time.Time
+}
+
+// LeaderboardMember はリーダーボードの集計対象のメンバー
+type LeaderboardMember struct {
+	UserID      uuid.UUID
+	DisplayName string
+	Visibility  LeaderboardVisibility
+}
+
+// LeaderboardEntry はリーダーボードの1行
+// 匿名のメンバーは本人以外にはユーザーIDと名前を返さない
+type LeaderboardEntry struct {
+	Rank           int        `json:"rank"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	DisplayName    string     `json:"display_name"`
+	Anonymous      bool       `json:"anonymous"`
+	IsMe           bool       `json:"is_me"`
+	CompletedTasks int        `json:"completed_tasks"` // 週内に完了したタスク数
+	DueTasks       int        `json:"due_tasks"`       // 完了したタスクのうち期限があるもの
+	OnTimeTasks    int        `json:"on_time_tasks"`   // 期限までに完了したタスク数
+	OnTimeRate     *float64   `json:"on_time_rate"`    // 期限内の完了率（0-100）、期限のあるタスクがない場合はnull
+	CurrentStreak  int        `json:"current_streak"`  // 週の終わり（今週は今日）まで毎日タスクを完了した日数
+
+	userID uuid.UUID
+}
+
+// Leaderboard はグループの週ごとのリーダーボード
+type Leaderboard struct {
+	GroupID     uuid.UUID           `json:"group_id"`
+	WeekStart   time.Time           `json:"week_start"`
+	WeekEnd     time.Time           `json:"week_end"` // この時刻に集計がリセットされる
+	Timezone    string              `json:"timezone"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Entries     []*LeaderboardEntry `json:"entries"`
+}
+
+// NewLeaderboard はメンバーの完了記録から週のリーダーボードを作成する
+// HIDDEN のメンバーは含めない。完了数、期限内の完了率、連続達成日数の順に並べ、同じ値のメンバーは同じ順位にする
+// completions には連続達成日数を数えるため週より前の完了も含めてよい
+func NewLeaderboard(groupID uuid.UUID, members []LeaderboardMember, completions map[uuid.UUID][]TaskCompletion, weekStart, weekEnd, now time.Time) *Leaderboard {
+	loc := weekStart.Location()
+	board := &Leaderboard{
+		GroupID:     groupID,
+		WeekStart:   weekStart,
+		WeekEnd:     weekEnd,
+		Timezone:    loc.String(),
+		GeneratedAt: now,
+		Entries:     []*LeaderboardEntry{},
+	}
+
+	// 連続達成日数は週の終わり（今週は今日）から遡って数える
+	streakEnd := weekEnd.Add(-time.Nanosecond)
+	if now.Before(streakEnd) {
+		streakEnd = now
+	}
+
+	for _, member := range members {
+		if member.Visibility == LeaderboardHidden {
+			continue
+		}
+		entry := &LeaderboardEntry{
+			userID:      member.UserID,
+			DisplayName: member.DisplayName,
+			Anonymous:   member.Visibility == LeaderboardAnonymous,
+		}
+		days := make(map[string]bool)
+		for _, c := range completions[member.UserID] {
+			if c.CompletedAt.After(streakEnd) {
+				continue
+			}
+			days[c.CompletedAt.In(loc).Format("2006-01-02")] = true
+			if c.CompletedAt.Before(weekStart) {
+				continue
+			}
+			entry.CompletedTasks++
+			if c.DueDate != nil {
+				entry.DueTasks++
+				if !c.CompletedAt.After(*c.DueDate) {
+					entry.OnTimeTasks++
+				}
+			}
+		}
+		if entry.DueTasks > 0 {
+			rate := math.Round(float64(entry.OnTimeTasks)/float64(entry.DueTasks)*1000) / 10
+			entry.OnTimeRate = &rate
+		}
+		entry.CurrentStreak = countStreak(days, streakEnd.In(loc))
+		board.Entries = append(board.Entries, entry)
+	}
+
+	sort.SliceStable(board.Entries, func(i, j int) bool {
+		return compareEntries(board.Entries[i], board.Entries[j]) < 0
+	})
+	for i, entry := range board.Entries {
+		if i > 0 && compareEntries(board.Entries[i-1], entry) == 0 {
+			entry.Rank = board.Entries[i-1].Rank
+		} else {
+			entry.Rank = i + 1
+		}
+	}
+	return board
+}
+
+// countStreak はlastDayから遡って毎日完了がある日数を数える（lastDayに完了がない場合は前日から数える）
+func countStreak(days map[string]bool, lastDay time.Time) int {
+	day := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day(), 0, 0, 0, 0, lastDay.Location())
+	if !days[day.Format("2006-01-02")] {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for streak < MaxLeaderboardStreakDays && days[day.Format("2006-01-02")] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// compareEntries は順位の比較（負の値はaが上位）
+func compareEntries(a, b *LeaderboardEntry) int {
+	if a.CompletedTasks != b.CompletedTasks {
+		return b.CompletedTasks - a.CompletedTasks
+	}
+	rateA, rateB := -1.0, -1.0
+	if a.OnTimeRate != nil {
+		rateA = *a.OnTimeRate
+	}
+	if b.OnTimeRate != nil {
+		rateB = *b.OnTimeRate
+	}
+	if rateA != rateB {
+		if rateA > rateB {
+			return -1
+		}
+		return 1
+	}
+	return b.CurrentStreak - a.CurrentStreak
+}
+
+// ForViewer は閲覧するメンバー向けのリーダーボードを返す
+// 自分の行には IsMe を付け、匿名のメンバーは本人以外にはユーザーIDと名前を伏せる
+func (l *Leaderboard) ForViewer(viewerID uuid.UUID) *Leaderboard {
+	view := *l
+	view.Entries = make([]*LeaderboardEntry, len(l.Entries))
+	for i, entry := range l.Entries {
+		e := *entry
+		e.IsMe = entry.userID == viewerID
+		if e.Anonymous && !e.IsMe {
+			e.DisplayName = AnonymousMemberName
+		} else {
+			id := entry.userID
+			e.UserID = &id
+		}
+		view.Entries[i] = &e
+	}
+	return &view
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxNoteTitleLength は議事録のタイトルの最大文字数
+	MaxNoteTitleLength = 200
+	// MaxNoteContentLength は議事録の本文（Markdown）の最大文字数
+	MaxNoteContentLength = 20000
+	// MaxNoteActionItems は1つの議事録から作成するタスクの上限
+	MaxNoteActionItems = 50
+	// MaxActionItemBytes はアクションアイテム（作成するタスクのタイトル）の最大バイト数（タスクのタイトルの上限に合わせる）
+	MaxActionItemBytes = 255
+)
+
+var (
+	// actionItemPattern は未完了のタスクリストの行（"- [ ] 内容"）に一致する
+	actionItemPattern = regexp.MustCompile(`^\s*[-*+]\s+\[ \]\s+(.+?)\s*$`)
+	// noteMentionPattern は行頭または英数字以外の直後にある@usernameに一致する（メールアドレスは一致しない）
+	noteMentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([\p{L}\p{N}_.\-]+)`)
+)
+
+// EventNote は予定に付ける議事録
+// 本文の未完了のタスクリストの行（アクションアイテム）からグループタスクを作成する
+type EventNote struct {
+	ID          uuid.UUID         `json:"id"`
+	GroupID     uuid.UUID         `json:"group_id"`
+	EventID     string            `json:"event_id"` // 予定のID（繰り返しの予定は回のID）
+	Title       string            `json:"title"`
+	Content     string            `json:"content"` // Markdown
+	CreatedBy   uuid.UUID         `json:"created_by"`
+	UpdatedBy   uuid.UUID         `json:"updated_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	ActionItems []*NoteActionItem `json:"action_items"` // 作成したタスク
+}
+
+// Validate は議事録のタイトルと本文をチェックする（タイトルの前後の空白は取り除く）
+func (n *EventNote) Validate() error {
+	n.Title = strings.TrimSpace(n.Title)
+	if n.Title == "" {
+		return errors.New("title is required")
+	}
+	if len([]rune(n.Title)) > MaxNoteTitleLength {
+		return fmt.Errorf("title must be at most %d characters", MaxNoteTitleLength)
+	}
+	if len([]rune(n.Content)) > MaxNoteContentLength {
+		return fmt.Errorf("content must be at most %d characters", MaxNoteContentLength)
+	}
+	return nil
+}
+
+// NoteActionItem は議事録のアクションアイテムから作成したタスク（タスクから元の議事録をたどるのに使う）
+type NoteActionItem struct {
+	NoteID     uuid.UUID  `json:"note_id"`
+	GroupID    uuid.UUID  `json:"group_id"`
+	TaskID     string     `json:"task_id"`
+	Text       string     `json:"text"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"` // メンションしたメンバー（いない場合はnil）
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ActionItem は議事録の本文から抽出したアクションアイテム
+type ActionItem struct {
+	Text     string   // 行の内容（作成するタスクのタイトル）
+	Mentions []string // 行に含まれる@username（出現順、重複なし）
+}
+
+// ExtractActionItems は議事録の本文から未完了のタスクリストの行を出現順に抽出する
+// 完了済みの行（"- [x]"）とコードブロックの中の行は含めず、同じ内容の行は1つにまとめる
+func ExtractActionItems(content string) []ActionItem {
+	var items []ActionItem
+	seen := make(map[string]bool)
+	inCodeBlock := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		match := actionItemPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		text := truncateBytes(match[1], MaxActionItemBytes)
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+		items = append(items, ActionItem{Text: text, Mentions: parseNoteMentions(text)})
+
+		if len(items) >= MaxNoteActionItems {
+			break
+		}
+	}
+	return items
+}
+
+// parseNoteMentions は行から@usernameを重複なく出現順に抽出する（末尾の句読点は取り除く）
+func parseNoteMentions(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range noteMentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		key := strings.ToLower(username)
+		if username == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// truncateBytes は文字列を UTF-8 の文字の途中で切らずに最大 max バイトに切り詰める
+func truncateBytes(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return strings.TrimSpace(s[:cut])
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GroupAction はグループでのアクション
+type GroupAction string
+
+const (
+	ActionViewGroup     GroupAction = "VIEW_GROUP"
+	ActionEditGroup     GroupAction = "EDIT_GROUP"
+	ActionDeleteGroup   GroupAction = "DELETE_GROUP"
+	ActionInviteMembers GroupAction = "INVITE_MEMBERS"
+	ActionRemoveMembers GroupAction = "REMOVE_MEMBERS"
+	ActionManageRoles   GroupAction = "MANAGE_ROLES"
+	ActionCreateTasks   GroupAction = "CREATE_TASKS"
+	ActionEditTasks     GroupAction = "EDIT_TASKS"
+	ActionDeleteTasks   GroupAction = "DELETE_TASKS"
+	ActionViewTasks     GroupAction = "VIEW_TASKS"
+	ActionViewSchedules GroupAction = "VIEW_SCHEDULES"
+)
+
+// PermissionMatrix はアクションごとに許可するロールを表す
+// オーナーは常に全てのアクションを実行できるため、OWNERは含めない
+// ゲストはメンバーに許可された閲覧のみ実行できるため、GUESTも含めない
+type PermissionMatrix map[GroupAction][]MemberRole
+
+// configurableActions はグループごとに許可するロールを変更できるアクション
+// グループの閲覧・削除・ロール管理は権限の昇格につながるため変更できない
+var configurableActions = map[GroupAction]bool{
+	ActionEditGroup:     true,
+	ActionInviteMembers: true,
+	ActionRemoveMembers: true,
+	ActionCreateTasks:   true,
+	ActionEditTasks:     true,
+	ActionDeleteTasks:   true,
+	ActionViewTasks:     true,
+	ActionViewSchedules: true,
+}
+
+// readOnlyActions はゲストが実行できる閲覧のアクション
+var readOnlyActions = map[GroupAction]bool{
+	ActionViewGroup:     true,
+	ActionViewTasks:     true,
+	ActionViewSchedules: true,
+}
+
+// IsReadOnlyAction はグループの内容を変更しない閲覧のアクションかチェック
+func IsReadOnlyAction(action GroupAction) bool {
+	return readOnlyActions[action]
+}
+
+// IsConfigurableAction はグループごとに許可するロールを変更できるアクションかチェック
+func IsConfigurableAction(action GroupAction) bool {
+	return configurableActions[action]
+}
+
+// DefaultPermissionMatrix は権限を変更していないグループの権限を返す
+func DefaultPermissionMatrix() PermissionMatrix {
+	managers := []MemberRole{RoleAdmin}
+	everyone := []MemberRole{RoleAdmin, RoleMember}
+	return PermissionMatrix{
+		ActionViewGroup:     everyone,
+		ActionEditGroup:     managers,
+		ActionDeleteGroup:   managers,
+		ActionInviteMembers: managers,
+		ActionRemoveMembers: managers,
+		ActionManageRoles:   managers,
+		ActionCreateTasks:   managers,
+		ActionEditTasks:     managers,
+		ActionDeleteTasks:   managers,
+		ActionViewTasks:     everyone,
+		ActionViewSchedules: everyone,
+	}
+}
+
+// Allows はロールがアクションを実行できるかチェック（未知のアクションは拒否する）
+func (m PermissionMatrix) Allows(role MemberRole, action GroupAction) bool {
+	roles, ok := m[action]
+	if !ok {
+		return false
+	}
+	if role == RoleOwner {
+		return true
+	}
+	// ゲストはメンバーに許可された閲覧のみ実行できる（権限設定でゲストに変更操作を許可することはできない）
+	if role == RoleGuest {
+		return IsReadOnlyAction(action) && m.Allows(RoleMember, action)
+	}
+	for _, allowed := range roles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge はデフォルトの権限にグループで変更した権限を上書きした結果を返す
+// 変更できないアクションの上書きは無視する
+func (m PermissionMatrix) Merge(overrides PermissionMatrix) PermissionMatrix {
+	merged := make(PermissionMatrix, len(m))
+	for action, roles := range m {
+		merged[action] = roles
+	}
+	for action, roles := range overrides {
+		if IsConfigurableAction(action) {
+			merged[action] = roles
+		}
+	}
+	return merged
+}
+
+// Validate はグループで変更する権限が正しいかチェックする
+func (m PermissionMatrix) Validate() error {
+	for action, roles := range m {
+		if !IsConfigurableAction(action) {
+			return fmt.Errorf("action %s cannot be configured", action)
+		}
+		seen := make(map[MemberRole]bool, len(roles))
+		for _, role := range roles {
+			if role != RoleAdmin && role != RoleMember {
+				return fmt.Errorf("invalid role %s for action %s", role, action)
+			}
+			if seen[role] {
+				return fmt.Errorf("duplicate role %s for action %s", role, action)
+			}
+			seen[role] = true
+		}
+	}
+	return nil
+}
+
+// ConfigurableActions は変更できるアクションを名前順で返す
+func ConfigurableActions() []GroupAction {
+	actions := make([]GroupAction, 0, len(configurableActions))
+	for action := range configurableActions {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
+}
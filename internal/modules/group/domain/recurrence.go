@@ -0,0 +1,394 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+)
+
+const (
+	// MaxRecurrenceInterval は繰り返しの間隔の上限
+	MaxRecurrenceInterval = 99
+	// MaxRecurrenceCount は繰り返しの回数の上限
+	MaxRecurrenceCount = 500
+	// MaxEventRangeDays は予定の一覧で一度に取得できる期間の上限（日）
+	MaxEventRangeDays = 92
+
+	// maxRecurrenceIterations は展開で数える回の上限（Until・Count のない古い予定の展開が止まらないようにする）
+	maxRecurrenceIterations = 100000
+	// occurrenceIDLayout は回のIDに含める元の開始日時（UTC）の書式
+	occurrenceIDLayout = "20060102T150405Z"
+	// maxHolidayShiftDays は祝日の回を次の営業日に移動する日数の上限（期間の前の回が期間内に移動する場合に展開する範囲）
+	maxHolidayShiftDays = 14
+)
+
+// RecurrenceFrequency は予定の繰り返しの単位
+type RecurrenceFrequency string
+
+const (
+	// RecurrenceDaily は毎日（Interval 日ごと）
+	RecurrenceDaily RecurrenceFrequency = "DAILY"
+	// RecurrenceWeekly は毎週（Interval 週ごとの Weekdays の曜日）
+	RecurrenceWeekly RecurrenceFrequency = "WEEKLY"
+	// RecurrenceMonthly は毎月（Interval か月ごとの最初の回と同じ日、その日がない月は飛ばす）
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// IsValid は繰り返しの単位が有効かチェック
+func (f RecurrenceFrequency) IsValid() bool {
+	switch f {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// RecurrenceHolidayPolicy は繰り返しの回が祝日になった場合の扱い
+type RecurrenceHolidayPolicy string
+
+const (
+	// RecurrenceHolidayKeep は祝日の回もそのまま行う
+	RecurrenceHolidayKeep RecurrenceHolidayPolicy = ""
+	// RecurrenceHolidaySkip は祝日の回を行わない
+	RecurrenceHolidaySkip RecurrenceHolidayPolicy = "SKIP"
+	// RecurrenceHolidayNextBusinessDay は祝日の回を次の営業日（祝日でない月〜金曜日）の同じ時刻に移動する
+	RecurrenceHolidayNextBusinessDay RecurrenceHolidayPolicy = "NEXT_BUSINESS_DAY"
+)
+
+// IsValid は祝日の扱いが有効かチェック
+func (p RecurrenceHolidayPolicy) IsValid() bool {
+	switch p {
+	case RecurrenceHolidayKeep, RecurrenceHolidaySkip, RecurrenceHolidayNextBusinessDay:
+		return true
+	}
+	return false
+}
+
+// weekdayOffsets は曜日（RFC 5545 の BYDAY の表記）ごとの月曜日からの日数
+var weekdayOffsets = map[string]int{
+	"MO": 0, "TU": 1, "WE": 2, "TH": 3, "FR": 4, "SA": 5, "SU": 6,
+}
+
+// EventRecurrence は予定の繰り返しの設定
+// 予定（グループタスク）の開始日時を最初の回とし、Timezone の壁時計の時刻で繰り返す
+type EventRecurrence struct {
+	GroupID   uuid.UUID           `json:"group_id"`
+	EventID   string              `json:"event_id"` // 最初の回の予定（タスク）のID
+	Frequency RecurrenceFrequency `json:"frequency"`
+	Interval  int                 `json:"interval"`
+	Weekdays  []string            `json:"weekdays,omitempty"` // WEEKLY の曜日（MO〜SU）、省略時は最初の回の曜日
+	Count     int                 `json:"count,omitempty"`    // 繰り返す回数（最初の回を含む、0は無制限）
+	Until     *time.Time          `json:"until,omitempty"`    // 最後の回の開始日時の上限（この日時を含む）
+	Timezone  string              `json:"timezone"`
+	// 回が祝日（Timezone の暦日）になった場合の扱い（空の場合はそのまま行う）
+	HolidayPolicy RecurrenceHolidayPolicy `json:"holiday_policy,omitempty"`
+	CreatedBy     uuid.UUID               `json:"created_by"`
+	UpdatedAt     time.Time               `json:"updated_at"`
+}
+
+// Validate は繰り返しの設定を検証し、曜日を月曜日からの順に並べ替える
+func (r *EventRecurrence) Validate() error {
+	if !r.Frequency.IsValid() {
+		return fmt.Errorf("invalid frequency: %s", r.Frequency)
+	}
+	if r.Interval < 1 || r.Interval > MaxRecurrenceInterval {
+		return fmt.Errorf("interval must be between 1 and %d", MaxRecurrenceInterval)
+	}
+	if r.Count < 0 || r.Count > MaxRecurrenceCount {
+		return fmt.Errorf("count must be between 0 and %d", MaxRecurrenceCount)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("count and until cannot be used together")
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil || r.Timezone == "" {
+		return fmt.Errorf("invalid timezone: %s", r.Timezone)
+	}
+	if !r.HolidayPolicy.IsValid() {
+		return fmt.Errorf("invalid holiday policy: %s", r.HolidayPolicy)
+	}
+
+	if len(r.Weekdays) > 0 && r.Frequency != RecurrenceWeekly {
+		return errors.New("weekdays can only be used with WEEKLY")
+	}
+	seen := make(map[string]bool, len(r.Weekdays))
+	for i, day := range r.Weekdays {
+		day = strings.ToUpper(day)
+		if _, ok := weekdayOffsets[day]; !ok {
+			return fmt.Errorf("invalid weekday: %s", day)
+		}
+		if seen[day] {
+			return fmt.Errorf("duplicate weekday: %s", day)
+		}
+		seen[day] = true
+		r.Weekdays[i] = day
+	}
+	sort.Slice(r.Weekdays, func(i, j int) bool {
+		return weekdayOffsets[r.Weekdays[i]] < weekdayOffsets[r.Weekdays[j]]
+	})
+	return nil
+}
+
+// Location は繰り返しに使うタイムゾーンを返す（不正な場合は UTC）
+func (r *EventRecurrence) Location() *time.Location {
+	if loc, err := time.LoadLocation(r.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Starts は最初の回の開始日時が first の繰り返しについて、元の開始日時が[from, to)の回の開始日時を順に返す
+// Count は from より前の回も含めて数える
+func (r *EventRecurrence) Starts(first, from, to time.Time) []time.Time {
+	first = first.Truncate(time.Second).In(r.Location())
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var starts []time.Time
+	n := 0
+	// emit は回を数え、繰り返しが終わった場合は false を返す
+	emit := func(t time.Time) bool {
+		if !t.Before(to) || (r.Until != nil && t.After(*r.Until)) ||
+			(r.Count > 0 && n >= r.Count) || n >= maxRecurrenceIterations {
+			return false
+		}
+		n++
+		if !t.Before(from) {
+			starts = append(starts, t)
+		}
+		return true
+	}
+
+	switch r.Frequency {
+	case RecurrenceDaily:
+		for i := 0; ; i++ {
+			if !emit(first.AddDate(0, 0, i*interval)) {
+				break
+			}
+		}
+	case RecurrenceWeekly:
+		offsets := []int{(int(first.Weekday()) + 6) % 7}
+		if len(r.Weekdays) > 0 {
+			offsets = offsets[:0]
+			for _, day := range r.Weekdays {
+				offsets = append(offsets, weekdayOffsets[day])
+			}
+			sort.Ints(offsets)
+		}
+		monday := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+		for week := 0; ; week += interval {
+			for _, offset := range offsets {
+				t := monday.AddDate(0, 0, week*7+offset)
+				if t.Before(first) {
+					continue
+				}
+				if !emit(t) {
+					return starts
+				}
+			}
+		}
+	case RecurrenceMonthly:
+		for i := 0; i < maxRecurrenceIterations; i++ {
+			t := first.AddDate(0, i*interval, 0)
+			// 最初の回と同じ日がない月（31日・2月29日など）は飛ばす
+			if t.Day() != first.Day() {
+				continue
+			}
+			if !emit(t) {
+				break
+			}
+		}
+	}
+	return starts
+}
+
+// holidayStart は元の開始日時が original の回に祝日の扱いを適用した開始日時を返す
+// 回を行わない場合は false を返す。祝日でない場合・移動先の営業日が見つからない場合は original のまま
+func (r *EventRecurrence) holidayStart(original time.Time, holidays holiday.Provider) (time.Time, bool) {
+	if r.HolidayPolicy == RecurrenceHolidayKeep {
+		return original, true
+	}
+	local := original.In(r.Location())
+	if _, ok := holiday.Name(holidays, local); !ok {
+		return original, true
+	}
+
+	switch r.HolidayPolicy {
+	case RecurrenceHolidaySkip:
+		return original, false
+	case RecurrenceHolidayNextBusinessDay:
+		next, ok := holiday.NextBusinessDay(holidays, local, holiday.Weekdays)
+		if ok && next.Before(local.AddDate(0, 0, maxHolidayShiftDays+1)) {
+			return next.UTC(), true
+		}
+	}
+	return original, true
+}
+
+// Includes は元の開始日時が original の回が繰り返しに含まれるか
+func (r *EventRecurrence) Includes(first, original time.Time) bool {
+	starts := r.Starts(first, original, original.Add(time.Second))
+	return len(starts) == 1 && starts[0].Equal(original)
+}
+
+// EventSchedule はグループの期間内の予定（繰り返しの予定は回ごとに展開したもの）
+type EventSchedule struct {
+	GroupID   uuid.UUID     `json:"group_id"`
+	GroupName string        `json:"group_name"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Events    []*GroupEvent `json:"events"`
+}
+
+// EventException は繰り返しの予定の1回分の変更（中止または日時の変更）
+type EventException struct {
+	GroupID          uuid.UUID  `json:"group_id"`
+	EventID          string     `json:"event_id"`           // 最初の回の予定（タスク）のID
+	OriginalStartsAt time.Time  `json:"original_starts_at"` // 変更する回の元の開始日時
+	Cancelled        bool       `json:"cancelled"`
+	StartsAt         *time.Time `json:"starts_at,omitempty"` // 変更後の開始日時（中止の場合はnil）
+	UpdatedBy        uuid.UUID  `json:"updated_by"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Validate は回の変更を検証する
+func (e *EventException) Validate() error {
+	if e.Cancelled && e.StartsAt != nil {
+		return errors.New("cancelled occurrence cannot be moved")
+	}
+	if !e.Cancelled && e.StartsAt == nil {
+		return errors.New("starts_at is required unless the occurrence is cancelled")
+	}
+	return nil
+}
+
+// OccurrenceID は繰り返しの予定の回のIDを返す（予定のIDと元の開始日時から作る）
+func OccurrenceID(eventID string, original time.Time) string {
+	return eventID + "_" + original.UTC().Format(occurrenceIDLayout)
+}
+
+// ParseOccurrenceID は予定のIDを最初の回の予定のIDと元の開始日時に分ける（回のIDでない場合の元の開始日時はnil）
+func ParseOccurrenceID(id string) (string, *time.Time, error) {
+	i := strings.LastIndex(id, "_")
+	if i < 0 {
+		return id, nil, nil
+	}
+	original, err := time.Parse(occurrenceIDLayout, id[i+1:])
+	if err != nil || i == 0 {
+		return "", nil, fmt.Errorf("invalid occurrence id: %s", id)
+	}
+	return id[:i], &original, nil
+}
+
+// Occurrence は元の開始日時が original の回を返す（movedTo を指定すると日時を変更した回）
+func (e *GroupEvent) Occurrence(original time.Time, movedTo *time.Time) *GroupEvent {
+	original = original.UTC()
+	occurrence := &GroupEvent{
+		ID:               OccurrenceID(e.ID, original),
+		GroupID:          e.GroupID,
+		Title:            e.Title,
+		StartsAt:         original,
+		CreatedBy:        e.CreatedBy,
+		SeriesID:         e.ID,
+		OriginalStartsAt: &original,
+		Location:         e.Location,
+	}
+	if movedTo != nil {
+		occurrence.StartsAt = *movedTo
+		occurrence.Moved = true
+	}
+	return occurrence
+}
+
+// ExpandEvent は予定を開始日時が[from, to)の回に展開する（開始日時の順に並べる）
+// 繰り返しがない場合は予定そのもの、ある場合は中止した回を除き、日時を変更した回は変更後の日時で返す
+// 変更していない回には繰り返しの祝日の扱いを適用する（holidays が nil の場合は祝日として扱う日がない）
+func ExpandEvent(event *GroupEvent, recurrence *EventRecurrence, exceptions []*EventException, from, to time.Time, holidays holiday.Provider) []*GroupEvent {
+	if recurrence == nil {
+		if !event.StartsAt.Before(from) && event.StartsAt.Before(to) {
+			return []*GroupEvent{event}
+		}
+		return nil
+	}
+
+	changed := make(map[int64]bool, len(exceptions))
+	for _, exception := range exceptions {
+		changed[exception.OriginalStartsAt.Unix()] = true
+	}
+
+	// 祝日の回を後ろに移動する場合は、期間の前の回が期間内に移動することがあるため前から展開する
+	expandFrom := from
+	if recurrence.HolidayPolicy == RecurrenceHolidayNextBusinessDay {
+		expandFrom = from.AddDate(0, 0, -maxHolidayShiftDays)
+	}
+
+	var occurrences []*GroupEvent
+	for _, original := range recurrence.Starts(event.StartsAt, expandFrom, to) {
+		if changed[original.Unix()] {
+			continue
+		}
+		start, ok := recurrence.holidayStart(original, holidays)
+		if !ok || start.Before(from) || !start.Before(to) {
+			continue
+		}
+		occurrences = append(occurrences, event.holidayOccurrence(original, start))
+	}
+	// 日時を変更した回は、元の開始日時が期間外でも変更後の日時が期間内なら含める
+	for _, exception := range exceptions {
+		if exception.Cancelled || exception.StartsAt == nil ||
+			exception.StartsAt.Before(from) || !exception.StartsAt.Before(to) {
+			continue
+		}
+		if recurrence.Includes(event.StartsAt, exception.OriginalStartsAt) {
+			occurrences = append(occurrences, event.Occurrence(exception.OriginalStartsAt, exception.StartsAt))
+		}
+	}
+
+	SortGroupEvents(occurrences)
+	return occurrences
+}
+
+// holidayOccurrence は祝日の扱いを適用した開始日時が start の回を返す（移動した場合は日時を変更した回として扱う）
+func (e *GroupEvent) holidayOccurrence(original, start time.Time) *GroupEvent {
+	if start.Equal(original) {
+		return e.Occurrence(original, nil)
+	}
+	return e.Occurrence(original, &start)
+}
+
+// SortGroupEvents は予定を開始日時の順（同じ場合はIDの順）に並べ替える
+func SortGroupEvents(events []*GroupEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].StartsAt.Equal(events[j].StartsAt) {
+			return events[i].StartsAt.Before(events[j].StartsAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+}
+
+// ResolveOccurrence は元の開始日時が original の回を返す（繰り返しに含まれない・中止した回・祝日で行わない回の場合はnil）
+func ResolveOccurrence(event *GroupEvent, recurrence *EventRecurrence, exceptions []*EventException, original time.Time, holidays holiday.Provider) *GroupEvent {
+	if !recurrence.Includes(event.StartsAt, original) {
+		return nil
+	}
+	for _, exception := range exceptions {
+		if exception.OriginalStartsAt.Unix() != original.Unix() {
+			continue
+		}
+		if exception.Cancelled {
+			return nil
+		}
+		return event.Occurrence(original, exception.StartsAt)
+	}
+	start, ok := recurrence.holidayStart(original, holidays)
+	if !ok {
+		return nil
+	}
+	return event.holidayOccurrence(original, start)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxCustomRoles は1グループで定義できるカスタムロールの上限
+	MaxCustomRoles = 20
+
+	// MaxCustomRoleNameLength はカスタムロール名の最大文字数
+	MaxCustomRoleNameLength = 50
+)
+
+// CustomRole はグループで定義する独自のロール（例: 閲覧者、タスク管理者）
+// メンバーに割り当てる場合は ID を MemberRole として保存する
+type CustomRole struct {
+	ID          uuid.UUID     `json:"id"`
+	GroupID     uuid.UUID     `json:"group_id"`
+	Name        string        `json:"name"`
+	Permissions []GroupAction `json:"permissions"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// NewCustomRole は新しいカスタムロールを作成する
+func NewCustomRole(groupID uuid.UUID, name string, permissions []GroupAction) (*CustomRole, error) {
+	now := time.Now()
+	role := &CustomRole{
+		ID:        uuid.New(),
+		GroupID:   groupID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := role.Update(name, permissions); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Update はロール名と権限を検証して更新する
+func (r *CustomRole) Update(name string, permissions []GroupAction) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("role name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxCustomRoleNameLength {
+		return fmt.Errorf("role name must be %d characters or less", MaxCustomRoleNameLength)
+	}
+	if IsBuiltinRole(MemberRole(strings.ToUpper(name))) {
+		return fmt.Errorf("role name %s is reserved", name)
+	}
+
+	seen := make(map[GroupAction]bool, len(permissions))
+	for _, action := range permissions {
+		// カスタムロールに与えられるのは権限設定で変更できるアクションのみ
+		if !IsConfigurableAction(action) {
+			return fmt.Errorf("action %s cannot be granted to a custom role", action)
+		}
+		if seen[action] {
+			return fmt.Errorf("duplicate action %s", action)
+		}
+		seen[action] = true
+	}
+
+	r.Name = name
+	r.Permissions = append([]GroupAction{}, permissions...)
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Role はメンバーに割り当てるときのロール値を返す
+func (r *CustomRole) Role() MemberRole {
+	return MemberRole(r.ID.String())
+}
+
+// Allows はカスタムロールがアクションを実行できるかチェック
+// グループの閲覧はメンバー全員に許可する
+func (r *CustomRole) Allows(action GroupAction) bool {
+	if action == ActionViewGroup {
+		return true
+	}
+	for _, granted := range r.Permissions {
+		if granted == action {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBuiltinRole は OWNER・ADMIN・MEMBER・GUEST のいずれかかチェック
+func IsBuiltinRole(role MemberRole) bool {
+	return role == RoleOwner || role == RoleAdmin || role == RoleMember || role == RoleGuest
+}
+
+// CustomRoleID はロールがカスタムロールを指す場合にそのIDを返す
+func (r MemberRole) CustomRoleID() (uuid.UUID, bool) {
+	if IsBuiltinRole(r) {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(string(r))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
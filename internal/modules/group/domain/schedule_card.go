@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ScheduleCardDays はスケジュールカードに表示する日数（開始日から1週間）
+	ScheduleCardDays = 7
+	// ScheduleCardLinkTTL はスケジュールカードの共有リンクの有効期間
+	ScheduleCardLinkTTL = 14 * 24 * time.Hour
+	// MaxScheduleCardEventsPerDay はスケジュールカードの1日に表示する予定の件数の上限（超えた分は件数だけ表示する）
+	MaxScheduleCardEventsPerDay = 4
+	// ScheduleCardHiddenTitle は予定のタイトルを表示しない設定の場合に表示する文字列
+	ScheduleCardHiddenTitle = "予定あり"
+
+	// scheduleCardDateLayout は共有リンクの開始日の書式
+	scheduleCardDateLayout = "2006-01-02"
+)
+
+// ScheduleCardPublicPathPrefix はスケジュールカードを表示する公開APIのパス（認証不要、署名で検証する）
+const ScheduleCardPublicPathPrefix = "/api/v1/public/groups/"
+
+// ScheduleCardFormat はスケジュールカードの出力形式
+type ScheduleCardFormat string
+
+const (
+	// ScheduleCardSVG はチャットに貼り付ける画像（SVG）
+	ScheduleCardSVG ScheduleCardFormat = "svg"
+	// ScheduleCardPDF は印刷・添付用のPDF
+	ScheduleCardPDF ScheduleCardFormat = "pdf"
+)
+
+// IsValid は有効な出力形式かチェック
+func (f ScheduleCardFormat) IsValid() bool {
+	switch f {
+	case ScheduleCardSVG, ScheduleCardPDF:
+		return true
+	}
+	return false
+}
+
+// ContentType は出力形式のContent-Typeを返す
+func (f ScheduleCardFormat) ContentType() string {
+	if f == ScheduleCardPDF {
+		return "application/pdf"
+	}
+	return "image/svg+xml"
+}
+
+// ScheduleCardSettings はグループのスケジュールカード設定
+// スケジュールカードは有効にしたグループのみ共有でき、日付の区切りは Timezone で計算する
+type ScheduleCardSettings struct {
+	GroupID    uuid.UUID `json:"group_id"`
+	Enabled    bool      `json:"enabled"`
+	Timezone   string    `json:"timezone"`
+	ShowTitles bool      `json:"show_titles"` // false の場合は予定のタイトルを伏せて「予定あり」と表示する
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewScheduleCardSettings はスケジュールカードを無効にした設定を作成する
+func NewScheduleCardSettings(groupID uuid.UUID) *ScheduleCardSettings {
+	return &ScheduleCardSettings{
+		GroupID:    groupID,
+		Enabled:    false,
+		Timezone:   "UTC",
+		ShowTitles: true,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// Location は日付の区切りに使うタイムゾーンを返す（不正な場合は UTC）
+func (s *ScheduleCardSettings) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// ScheduleCardLink はスケジュールカードの共有リンク
+// グループ・開始日・有効期限をHMAC-SHA256で署名し、署名を知っている人だけが認証なしでカードを表示できる
+type ScheduleCardLink struct {
+	GroupID   uuid.UUID
+	StartDate string // カードの開始日（YYYY-MM-DD、設定のタイムゾーンの日付）
+	ExpiresAt time.Time
+	Signature string
+}
+
+// NewScheduleCardLink は now を含む日から1週間のカードの署名付きリンクを作成する
+func NewScheduleCardLink(groupID uuid.UUID, now time.Time, loc *time.Location, secret []byte) *ScheduleCardLink {
+	link := &ScheduleCardLink{
+		GroupID:   groupID,
+		StartDate: now.In(loc).Format(scheduleCardDateLayout),
+		ExpiresAt: now.Add(ScheduleCardLinkTTL).Truncate(time.Second),
+	}
+	link.Signature = link.sign(secret)
+	return link
+}
+
+// Verify は署名が正しく、有効期限内かを検証する
+func (l *ScheduleCardLink) Verify(secret []byte, now time.Time) bool {
+	if len(secret) == 0 || l.Signature == "" || !now.Before(l.ExpiresAt) {
+		return false
+	}
+	if _, err := time.Parse(scheduleCardDateLayout, l.StartDate); err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(l.Signature), []byte(l.sign(secret)))
+}
+
+// Range はカードに表示する期間 [from, to) を loc の日付で返す
+func (l *ScheduleCardLink) Range(loc *time.Location) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(scheduleCardDateLayout, l.StartDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, from.AddDate(0, 0, ScheduleCardDays), nil
+}
+
+// PublicPath はカードを出力形式で表示する公開APIのパス（クエリに開始日・有効期限・署名を含む）
+func (l *ScheduleCardLink) PublicPath(format ScheduleCardFormat) string {
+	query := url.Values{}
+	query.Set("start", l.StartDate)
+	query.Set("expires", strconv.FormatInt(l.ExpiresAt.Unix(), 10))
+	query.Set("signature", l.Signature)
+	query.Set("format", string(format))
+	return ScheduleCardPublicPathPrefix + l.GroupID.String() + "/schedule-card?" + query.Encode()
+}
+
+func (l *ScheduleCardLink) sign(secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(l.GroupID.String() + "|" + l.StartDate + "|" + strconv.FormatInt(l.ExpiresAt.Unix(), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ScheduleCardEvent はスケジュールカードに表示する予定
+type ScheduleCardEvent struct {
+	Time  string // 開始時刻（HH:MM、設定のタイムゾーン）
+	Title string
+}
+
+// ScheduleCardDay はスケジュールカードの1日分
+type ScheduleCardDay struct {
+	Date   time.Time
+	Events []ScheduleCardEvent
+	More   int // 表示しきれなかった予定の件数
+}
+
+// ScheduleCard はグループの1週間の予定をまとめたカード（画像・PDFの元になる）
+type ScheduleCard struct {
+	GroupName string
+	Days      []ScheduleCardDay
+}
+
+// NewScheduleCard は from から1週間の予定を日ごとに開始時刻順にまとめる
+// 1日の予定は MaxScheduleCardEventsPerDay 件まで表示し、タイトルを表示しない設定の場合は伏せる
+func NewScheduleCard(schedule *EventSchedule, settings *ScheduleCardSettings, from time.Time) *ScheduleCard {
+	loc := settings.Location()
+	from = from.In(loc)
+
+	card := &ScheduleCard{
+		GroupName: schedule.GroupName,
+		Days:      make([]ScheduleCardDay, ScheduleCardDays),
+	}
+	for i := range card.Days {
+		card.Days[i].Date = from.AddDate(0, 0, i)
+	}
+
+	events := append([]*GroupEvent{}, schedule.Events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartsAt.Before(events[j].StartsAt)
+	})
+	for _, event := range events {
+		startsAt := event.StartsAt.In(loc)
+		index := -1
+		for i := range card.Days {
+			if !startsAt.Before(card.Days[i].Date) && startsAt.Before(card.Days[i].Date.AddDate(0, 0, 1)) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			continue
+		}
+
+		day := &card.Days[index]
+		if len(day.Events) >= MaxScheduleCardEventsPerDay {
+			day.More++
+			continue
+		}
+		title := event.Title
+		if !settings.ShowTitles {
+			title = ScheduleCardHiddenTitle
+		}
+		day.Events = append(day.Events, ScheduleCardEvent{Time: startsAt.Format("15:04"), Title: title})
+	}
+	return card
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// chatWebhookClient はチャットのWebhookにJSONを送信する、連携先のゲートウェイで共通の処理
+// 連携先ごとのゲートウェイはメッセージを連携先の形式に変換するだけにする
+type chatWebhookClient struct {
+	name       string // ログ・エラーに使う連携先の名前
+	httpClient *http.Client
+	breaker    *circuitbreaker.Breaker // 連携先が応答しない・失敗が続く場合に投稿をやめる（nilの場合は制限しない）
+	logger     logger.Logger
+}
+
+func newChatWebhookClient(name string, breaker *circuitbreaker.Breaker, logger logger.Logger) chatWebhookClient {
+	return chatWebhookClient{
+		name: name,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		breaker: breaker,
+		logger:  logger,
+	}
+}
+
+// post はメッセージを連携先の形式に変換した payload をWebhookに送信する
+func (c *chatWebhookClient) post(ctx context.Context, webhookURL string, message *domain.ChatMessage, payload interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %w", c.name, err)
+	}
+
+	var status int
+	err = c.breaker.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// サーバー側のエラーだけを失敗として数える（Webhookの削除などはグループごとの設定の問題）
+		status = resp.StatusCode
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return fmt.Errorf("%s returned status %d", c.name, status)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("Failed to post chat message", logger.Any("platform", c.name), logger.Any("event", message.Event), logger.Error(err))
+		return fmt.Errorf("failed to post %s message: %w", c.name, err)
+	}
+
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		c.logger.Warn("Chat webhook returned non-OK status", logger.Any("platform", c.name), logger.Any("status", status), logger.Any("event", message.Event))
+		return fmt.Errorf("%s returned non-OK status: %d", c.name, status)
+	}
+	return nil
+}
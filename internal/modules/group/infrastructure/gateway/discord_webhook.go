@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// discordMaxContentLength はDiscordのメッセージの本文の最大文字数
+	discordMaxContentLength = 2000
+	// discordUsername はWebhookで投稿するメッセージの送信者名
+	discordUsername = "Yotei+"
+)
+
+// discordWebhookMessage はDiscordのWebhookに送信するメッセージ形式
+type discordWebhookMessage struct {
+	Content         string                 `json:"content"`
+	Username        string                 `json:"username"`
+	AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+}
+
+// discordAllowedMentions はメッセージで通知するメンション（タスクのタイトルの@everyoneなどで通知しないよう空にする）
+type discordAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// DiscordWebhookPoster はDiscordのチャンネルのWebhookにメッセージを投稿するゲートウェイ実装
+type DiscordWebhookPoster struct {
+	client chatWebhookClient
+}
+
+// NewDiscordWebhookPoster は新しいDiscordWebhookPosterを作成する
+func NewDiscordWebhookPoster(breaker *circuitbreaker.Breaker, logger logger.Logger) usecase.ChatPoster {
+	return &DiscordWebhookPoster{
+		client: newChatWebhookClient("Discord", breaker, logger),
+	}
+}
+
+// PostMessage はメッセージの本文をWebhookに投稿する（本文が長い場合は切り詰める）
+func (p *DiscordWebhookPoster) PostMessage(ctx context.Context, webhookURL string, message *domain.ChatMessage) error {
+	content := []rune(message.Text)
+	if len(content) > discordMaxContentLength {
+		content = append(content[:discordMaxContentLength-1], '…')
+	}
+
+	return p.client.post(ctx, webhookURL, message, discordWebhookMessage{
+		Content:         string(content),
+		Username:        discordUsername,
+		AllowedMentions: discordAllowedMentions{Parse: []string{}},
+	})
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// adaptiveCardContentType はTeamsのメッセージに添付するAdaptive Cardの形式
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	// adaptiveCardVersion はTeamsで表示できるAdaptive Cardのバージョン
+	adaptiveCardVersion = "1.4"
+)
+
+// teamsWebhookMessage はTeamsの受信Webhook・ワークフローのWebhookに送信するメッセージ形式
+type teamsWebhookMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+// adaptiveCard はメッセージを表示するAdaptive Card（見出し・本文・項目の一覧）
+type adaptiveCard struct {
+	Schema  string                   `json:"$schema"`
+	Type    string                   `json:"type"`
+	Version string                   `json:"version"`
+	Body    []map[string]interface{} `json:"body"`
+	MSTeams map[string]string        `json:"msteams"`
+}
+
+// TeamsWebhookPoster はMicrosoft Teamsのチャネルの受信WebhookにメッセージをAdaptive Cardで投稿するゲートウェイ実装
+type TeamsWebhookPoster struct {
+	client chatWebhookClient
+}
+
+// NewTeamsWebhookPoster は新しいTeamsWebhookPosterを作成する
+func NewTeamsWebhookPoster(breaker *circuitbreaker.Breaker, logger logger.Logger) usecase.ChatPoster {
+	return &TeamsWebhookPoster{
+		client: newChatWebhookClient("Teams", breaker, logger),
+	}
+}
+
+// PostMessage はメッセージの見出し・本文・項目をAdaptive Cardにして投稿する
+func (p *TeamsWebhookPoster) PostMessage(ctx context.Context, webhookURL string, message *domain.ChatMessage) error {
+	var body []map[string]interface{}
+	if message.Title != "" {
+		body = append(body, map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   message.Title,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"wrap":   true,
+		})
+	}
+	body = append(body, map[string]interface{}{
+		"type": "TextBlock",
+		"text": message.Text,
+		"wrap": true,
+	})
+	if len(message.Facts) > 0 {
+		facts := make([]map[string]string, len(message.Facts))
+		for i, fact := range message.Facts {
+			facts[i] = map[string]string{"title": fact.Name, "value": fact.Value}
+		}
+		body = append(body, map[string]interface{}{
+			"type":  "FactSet",
+			"facts": facts,
+		})
+	}
+
+	return p.client.post(ctx, webhookURL, message, teamsWebhookMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: adaptiveCardContentType,
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: adaptiveCardVersion,
+				Body:    body,
+				MSTeams: map[string]string{"width": "Full"},
+			},
+		}},
+	})
+}
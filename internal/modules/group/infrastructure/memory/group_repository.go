@@ -0,0 +1,1138 @@
+// Package memory はグループリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+)
+
+// GroupRepository はグループのインメモリリポジトリ
+type GroupRepository struct {
+	mu          sync.RWMutex
+	groups      map[uuid.UUID]*domain.Group
+	members     map[uuid.UUID]map[uuid.UUID]*domain.GroupMember          // groupID → userID → メンバー
+	permissions map[uuid.UUID]domain.PermissionMatrix                    // groupID → 変更した権限
+	roles       map[uuid.UUID]map[uuid.UUID]*domain.CustomRole           // groupID → roleID → カスタムロール
+	assignments map[uuid.UUID]*domain.AssignmentSettings                 // groupID → 自動割り当て設定
+	leaderboard map[uuid.UUID]*domain.LeaderboardSettings                // groupID → リーダーボード設定
+	cards       map[uuid.UUID]*domain.ScheduleCardSettings               // groupID → スケジュールカード設定
+	visibility  map[uuid.UUID]map[uuid.UUID]domain.LeaderboardVisibility // groupID → userID → 公開範囲
+	rsvps       map[string]map[uuid.UUID]*domain.EventRSVP               // groupID/eventID → userID → 出欠
+	reminders   map[string]time.Time                                     // groupID/eventID → リマインダーの送信日時
+	recurrences map[string]*domain.EventRecurrence                       // groupID/eventID → 繰り返しの設定
+	exceptions  map[string]map[int64]*domain.EventException              // groupID/eventID → 元の開始日時（Unix秒） → 回の変更
+	notes       map[uuid.UUID]*domain.EventNote                          // noteID → 議事録
+	noteItems   map[uuid.UUID][]*domain.NoteActionItem                   // noteID → 作成したタスク
+	links       []*domain.EventTaskLink                                  // 予定とタスクの関連付け（作成順）
+	chats       map[string]*domain.ChatIntegration                       // groupID/platform → チャット連携
+}
+
+// NewGroupRepository は新しいGroupRepositoryを作成する
+func NewGroupRepository() *GroupRepository {
+	return &GroupRepository{
+		groups:      make(map[uuid.UUID]*domain.Group),
+		members:     make(map[uuid.UUID]map[uuid.UUID]*domain.GroupMember),
+		permissions: make(map[uuid.UUID]domain.PermissionMatrix),
+		roles:       make(map[uuid.UUID]map[uuid.UUID]*domain.CustomRole),
+		assignments: make(map[uuid.UUID]*domain.AssignmentSettings),
+		leaderboard: make(map[uuid.UUID]*domain.LeaderboardSettings),
+		cards:       make(map[uuid.UUID]*domain.ScheduleCardSettings),
+		visibility:  make(map[uuid.UUID]map[uuid.UUID]domain.LeaderboardVisibility),
+		rsvps:       make(map[string]map[uuid.UUID]*domain.EventRSVP),
+		reminders:   make(map[string]time.Time),
+		recurrences: make(map[string]*domain.EventRecurrence),
+		exceptions:  make(map[string]map[int64]*domain.EventException),
+		notes:       make(map[uuid.UUID]*domain.EventNote),
+		noteItems:   make(map[uuid.UUID][]*domain.NoteActionItem),
+		chats:       make(map[string]*domain.ChatIntegration),
+	}
+}
+
+// CreateGroup はグループを作成し、オーナーをメンバーとして追加する
+func (r *GroupRepository) CreateGroup(ctx context.Context, group *domain.Group) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[group.ID]; ok {
+		return fmt.Errorf("failed to create group: duplicate id %s", group.ID)
+	}
+	g := *group
+	r.groups[group.ID] = &g
+
+	return r.addMember(domain.NewGroupMember(group.ID, group.OwnerID, domain.RoleOwner))
+}
+
+// GetGroupByID はIDでグループを取得する（存在しない場合は nil, nil）
+func (r *GroupRepository) GetGroupByID(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	group, ok := r.groups[id]
+	if !ok {
+		return nil, nil
+	}
+	g := *group
+	return &g, nil
+}
+
+// UpdateGroup はグループを更新する（Versionは呼び出し側で1つ進めておく）
+func (r *GroupRepository) UpdateGroup(ctx context.Context, group *domain.Group) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.groups[group.ID]
+	if !ok || current.Version != group.Version-1 {
+		return fmt.Errorf("group not found or version conflict")
+	}
+
+	// 種別・オーナー・親グループ・作成日時は更新しない（MySQL実装と同じ）
+	g := *group
+	g.Type = current.Type
+	g.OwnerID = current.OwnerID
+	g.ParentGroupID = current.ParentGroupID
+	g.CreatedAt = current.CreatedAt
+	r.groups[group.ID] = &g
+	return nil
+}
+
+// DeleteGroup はグループとメンバーを削除する
+// サブチームは最上位のグループとして残す（MySQLの ON DELETE SET NULL と同じ）
+func (r *GroupRepository) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members, id)
+	delete(r.permissions, id)
+	delete(r.roles, id)
+	delete(r.assignments, id)
+	for key, integration := range r.chats {
+		if integration.GroupID == id {
+			delete(r.chats, key)
+		}
+	}
+	delete(r.groups, id)
+	for _, group := range r.groups {
+		if group.ParentGroupID != nil && *group.ParentGroupID == id {
+			group.ParentGroupID = nil
+		}
+	}
+	return nil
+}
+
+// ListGroupsByOwner はオーナーでグループを検索する
+func (r *GroupRepository) ListGroupsByOwner(ctx context.Context, ownerID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Group, int, error) {
+	return r.listGroups(pagination, func(group *domain.Group) bool {
+		return group.OwnerID == ownerID
+	})
+}
+
+// ListGroupsByMember はメンバーでグループを検索する
+func (r *GroupRepository) ListGroupsByMember(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Group, int, error) {
+	return r.listGroups(pagination, func(group *domain.Group) bool {
+		_, ok := r.members[group.ID][userID]
+		return ok
+	})
+}
+
+// SearchGroups は名前・説明の部分一致でグループを検索する
+func (r *GroupRepository) SearchGroups(ctx context.Context, query string, groupType *domain.GroupType, pagination commonDomain.Pagination) ([]*domain.Group, int, error) {
+	query = strings.ToLower(query)
+	return r.listGroups(pagination, func(group *domain.Group) bool {
+		if groupType != nil && group.Type != *groupType {
+			return false
+		}
+		return strings.Contains(strings.ToLower(group.Name), query) ||
+			strings.Contains(strings.ToLower(group.Description), query)
+	})
+}
+
+// ListChildGroups は直下のサブチームを作成日時の古い順に取得する
+func (r *GroupRepository) ListChildGroups(ctx context.Context, parentID uuid.UUID) ([]*domain.Group, error) {
+	r.mu.RLock()
+	groups := []*domain.Group{}
+	for _, group := range r.groups {
+		if group.ParentGroupID != nil && *group.ParentGroupID == parentID {
+			g := *group
+			groups = append(groups, &g)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if !groups[i].CreatedAt.Equal(groups[j].CreatedAt) {
+			return groups[i].CreatedAt.Before(groups[j].CreatedAt)
+		}
+		return groups[i].ID.String() < groups[j].ID.String()
+	})
+	return groups, nil
+}
+
+// AddMember はメンバーを追加する
+func (r *GroupRepository) AddMember(ctx context.Context, member *domain.GroupMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addMember(member)
+}
+
+// AddMembers はメンバーを一括追加し、グループのメンバー数を更新する
+// いずれかのユーザーが既にメンバーの場合は何も追加しない
+func (r *GroupRepository) AddMembers(ctx context.Context, group *domain.Group, members []*domain.GroupMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.groups[group.ID]
+	if !ok || current.Version != group.Version-1 {
+		return fmt.Errorf("group not found or version conflict")
+	}
+	for _, member := range members {
+		if _, exists := r.members[member.GroupID][member.UserID]; exists {
+			return fmt.Errorf("failed to add members: user %s is already a member", member.UserID)
+		}
+	}
+
+	for _, member := range members {
+		if err := r.addMember(member); err != nil {
+			return err
+		}
+	}
+	g := *current
+	g.MemberCount = group.MemberCount
+	g.UpdatedAt = group.UpdatedAt
+	g.Version = group.Version
+	r.groups[group.ID] = &g
+	return nil
+}
+
+// GetMember はメンバーを取得する（存在しない場合は nil, nil）
+func (r *GroupRepository) GetMember(ctx context.Context, groupID, userID uuid.UUID) (*domain.GroupMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.members[groupID][userID]
+	if !ok {
+		return nil, nil
+	}
+	m := *member
+	return &m, nil
+}
+
+// UpdateMemberRole はメンバーの権限を更新する
+func (r *GroupRepository) UpdateMemberRole(ctx context.Context, groupID, userID uuid.UUID, role domain.MemberRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if member, ok := r.members[groupID][userID]; ok {
+		member.Role = role
+		member.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// RemoveMember はメンバーを削除する
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members[groupID], userID)
+	return nil
+}
+
+// ListMembers はメンバー一覧を参加日時の古い順に取得する
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.GroupMember, error) {
+	r.mu.RLock()
+	members := make([]*domain.GroupMember, 0, len(r.members[groupID]))
+	for _, member := range r.members[groupID] {
+		m := *member
+		members = append(members, &m)
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if !members[i].JoinedAt.Equal(members[j].JoinedAt) {
+			return members[i].JoinedAt.Before(members[j].JoinedAt)
+		}
+		return members[i].ID.String() < members[j].ID.String()
+	})
+	return paginate(members, pagination), nil
+}
+
+// IsMember はメンバーかどうかチェックする
+func (r *GroupRepository) IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.members[groupID][userID]
+	return ok, nil
+}
+
+// GetExistingMemberIDs は指定されたユーザーのうち既にグループのメンバーであるユーザーIDを返す
+func (r *GroupRepository) GetExistingMemberIDs(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[uuid.UUID]bool)
+	for _, userID := range userIDs {
+		if _, ok := r.members[groupID][userID]; ok {
+			result[userID] = true
+		}
+	}
+	return result, nil
+}
+
+// GetMemberRole はメンバーの権限を取得する
+func (r *GroupRepository) GetMemberRole(ctx context.Context, groupID, userID uuid.UUID) (domain.MemberRole, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.members[groupID][userID]
+	if !ok {
+		return "", fmt.Errorf("member not found")
+	}
+	return member.Role, nil
+}
+
+// GetMemberCount はメンバー数を取得する
+func (r *GroupRepository) GetMemberCount(ctx context.Context, groupID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.members[groupID]), nil
+}
+
+// GetGroupStats はグループ統計情報を取得する
+func (r *GroupRepository) GetGroupStats(ctx context.Context, groupID uuid.UUID) (*domain.GroupStats, error) {
+	memberCount, err := r.GetMemberCount(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GroupStats{
+		MemberCount:   memberCount,
+		ActiveMembers: memberCount,
+	}, nil
+}
+
+// CountDistinctMembers は複数グループのメンバーを重複を除いて数える
+func (r *GroupRepository) CountDistinctMembers(ctx context.Context, groupIDs []uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[uuid.UUID]bool)
+	for _, groupID := range groupIDs {
+		for userID := range r.members[groupID] {
+			seen[userID] = true
+		}
+	}
+	return len(seen), nil
+}
+
+// GetPermissionOverrides はグループで変更した権限を取得する
+func (r *GroupRepository) GetPermissionOverrides(ctx context.Context, groupID uuid.UUID) (domain.PermissionMatrix, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return copyPermissionMatrix(r.permissions[groupID]), nil
+}
+
+// SavePermissionOverrides はグループで変更した権限を置き換える
+func (r *GroupRepository) SavePermissionOverrides(ctx context.Context, groupID uuid.UUID, overrides domain.PermissionMatrix) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[groupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	r.permissions[groupID] = copyPermissionMatrix(overrides)
+	return nil
+}
+
+// CreateCustomRole はカスタムロールを作成する
+func (r *GroupRepository) CreateCustomRole(ctx context.Context, role *domain.CustomRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[role.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	roles, ok := r.roles[role.GroupID]
+	if !ok {
+		roles = make(map[uuid.UUID]*domain.CustomRole)
+		r.roles[role.GroupID] = roles
+	}
+	for _, existing := range roles {
+		if strings.EqualFold(existing.Name, role.Name) {
+			return fmt.Errorf("failed to create custom role: duplicate name %s", role.Name)
+		}
+	}
+	roles[role.ID] = copyCustomRole(role)
+	return nil
+}
+
+// GetCustomRole はグループのカスタムロールを取得する（存在しない場合は nil, nil）
+func (r *GroupRepository) GetCustomRole(ctx context.Context, groupID, roleID uuid.UUID) (*domain.CustomRole, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	role, ok := r.roles[groupID][roleID]
+	if !ok {
+		return nil, nil
+	}
+	return copyCustomRole(role), nil
+}
+
+// ListCustomRoles はグループのカスタムロールを作成日時の古い順に取得する
+func (r *GroupRepository) ListCustomRoles(ctx context.Context, groupID uuid.UUID) ([]*domain.CustomRole, error) {
+	r.mu.RLock()
+	roles := make([]*domain.CustomRole, 0, len(r.roles[groupID]))
+	for _, role := range r.roles[groupID] {
+		roles = append(roles, copyCustomRole(role))
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(roles, func(i, j int) bool {
+		if !roles[i].CreatedAt.Equal(roles[j].CreatedAt) {
+			return roles[i].CreatedAt.Before(roles[j].CreatedAt)
+		}
+		return roles[i].ID.String() < roles[j].ID.String()
+	})
+	return roles, nil
+}
+
+// UpdateCustomRole はカスタムロールの名前と権限を更新する
+func (r *GroupRepository) UpdateCustomRole(ctx context.Context, role *domain.CustomRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.roles[role.GroupID][role.ID]
+	if !ok {
+		return fmt.Errorf("custom role not found")
+	}
+	for _, existing := range r.roles[role.GroupID] {
+		if existing.ID != role.ID && strings.EqualFold(existing.Name, role.Name) {
+			return fmt.Errorf("failed to update custom role: duplicate name %s", role.Name)
+		}
+	}
+
+	updated := copyCustomRole(role)
+	updated.CreatedAt = current.CreatedAt
+	r.roles[role.GroupID][role.ID] = updated
+	return nil
+}
+
+// DeleteCustomRole はカスタムロールを削除し、割り当てていたメンバーを MEMBER に戻す
+func (r *GroupRepository) DeleteCustomRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[groupID][roleID]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	for _, member := range r.members[groupID] {
+		if member.Role == role.Role() {
+			member.Role = domain.RoleMember
+			member.UpdatedAt = now
+		}
+	}
+	delete(r.roles[groupID], roleID)
+	return nil
+}
+
+// GetAssignmentSettings はグループの自動割り当て設定を取得する（未設定の場合は nil, nil）
+func (r *GroupRepository) GetAssignmentSettings(ctx context.Context, groupID uuid.UUID) (*domain.AssignmentSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.assignments[groupID]
+	if !ok {
+		return nil, nil
+	}
+	return copyAssignmentSettings(settings), nil
+}
+
+// SaveAssignmentSettings はグループの自動割り当て設定を保存する
+func (r *GroupRepository) SaveAssignmentSettings(ctx context.Context, settings *domain.AssignmentSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[settings.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	r.assignments[settings.GroupID] = copyAssignmentSettings(settings)
+	return nil
+}
+
+// MemberIDs はユーザーと同じグループに所属する他のユーザーIDを返す
+// 他モジュールのインメモリ実装（オンライン状態の公開範囲など）から参照する
+func (r *GroupRepository) MemberIDs(ctx context.Context, userID uuid.UUID) []uuid.UUID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, members := range r.members {
+		if _, ok := members[userID]; !ok {
+			continue
+		}
+		for id := range members {
+			if id != userID && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// addMember はメンバーを追加する（呼び出し側で書き込みロックを取得すること）
+func (r *GroupRepository) addMember(member *domain.GroupMember) error {
+	members, ok := r.members[member.GroupID]
+	if !ok {
+		members = make(map[uuid.UUID]*domain.GroupMember)
+		r.members[member.GroupID] = members
+	}
+	if _, exists := members[member.UserID]; exists {
+		return fmt.Errorf("failed to add member: user %s is already a member", member.UserID)
+	}
+	m := *member
+	members[member.UserID] = &m
+	return nil
+}
+
+// listGroups は条件に一致するグループを作成日時の新しい順に取得する
+func (r *GroupRepository) listGroups(pagination commonDomain.Pagination, match func(group *domain.Group) bool) ([]*domain.Group, int, error) {
+	r.mu.RLock()
+	groups := []*domain.Group{}
+	for _, group := range r.groups {
+		if match(group) {
+			g := *group
+			groups = append(groups, &g)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if !groups[i].CreatedAt.Equal(groups[j].CreatedAt) {
+			return groups[i].CreatedAt.After(groups[j].CreatedAt)
+		}
+		return groups[i].ID.String() < groups[j].ID.String()
+	})
+	return paginate(groups, pagination), len(groups), nil
+}
+
+// copyPermissionMatrix は呼び出し側で変更されないよう権限設定をコピーする
+func copyPermissionMatrix(matrix domain.PermissionMatrix) domain.PermissionMatrix {
+	copied := make(domain.PermissionMatrix, len(matrix))
+	for action, roles := range matrix {
+		copied[action] = append([]domain.MemberRole{}, roles...)
+	}
+	return copied
+}
+
+// copyCustomRole は呼び出し側で変更されないようカスタムロールをコピーする
+func copyCustomRole(role *domain.CustomRole) *domain.CustomRole {
+	copied := *role
+	copied.Permissions = append([]domain.GroupAction{}, role.Permissions...)
+	return &copied
+}
+
+// GetLeaderboardSettings はグループのリーダーボード設定を取得する（未設定の場合は nil, nil）
+func (r *GroupRepository) GetLeaderboardSettings(ctx context.Context, groupID uuid.UUID) (*domain.LeaderboardSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.leaderboard[groupID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *settings
+	return &copied, nil
+}
+
+// SaveLeaderboardSettings はグループのリーダーボード設定を保存する
+func (r *GroupRepository) SaveLeaderboardSettings(ctx context.Context, settings *domain.LeaderboardSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[settings.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	copied := *settings
+	r.leaderboard[settings.GroupID] = &copied
+	return nil
+}
+
+// GetScheduleCardSettings はグループのスケジュールカード設定を取得する（未設定の場合は nil, nil）
+func (r *GroupRepository) GetScheduleCardSettings(ctx context.Context, groupID uuid.UUID) (*domain.ScheduleCardSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.cards[groupID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *settings
+	return &copied, nil
+}
+
+// SaveScheduleCardSettings はグループのスケジュールカード設定を保存する
+func (r *GroupRepository) SaveScheduleCardSettings(ctx context.Context, settings *domain.ScheduleCardSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[settings.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	copied := *settings
+	r.cards[settings.GroupID] = &copied
+	return nil
+}
+
+// GetLeaderboardVisibilities はメンバーが設定した公開範囲を取得する（未設定のメンバーは含まない）
+func (r *GroupRepository) GetLeaderboardVisibilities(ctx context.Context, groupID uuid.UUID) (map[uuid.UUID]domain.LeaderboardVisibility, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	visibilities := make(map[uuid.UUID]domain.LeaderboardVisibility, len(r.visibility[groupID]))
+	for userID, visibility := range r.visibility[groupID] {
+		visibilities[userID] = visibility
+	}
+	return visibilities, nil
+}
+
+// SaveLeaderboardPreference はメンバーのリーダーボードの公開範囲を保存する
+func (r *GroupRepository) SaveLeaderboardPreference(ctx context.Context, preference *domain.LeaderboardPreference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[preference.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	if r.visibility[preference.GroupID] == nil {
+		r.visibility[preference.GroupID] = make(map[uuid.UUID]domain.LeaderboardVisibility)
+	}
+	r.visibility[preference.GroupID][preference.UserID] = preference.Visibility
+	return nil
+}
+
+// GetEventRSVP はメンバーの予定への出欠を取得する（未回答・出席も未記録の場合は nil, nil）
+func (r *GroupRepository) GetEventRSVP(ctx context.Context, groupID uuid.UUID, eventID string, userID uuid.UUID) (*domain.EventRSVP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rsvp, ok := r.rsvps[eventKey(groupID, eventID)][userID]
+	if !ok {
+		return nil, nil
+	}
+	return copyEventRSVP(rsvp), nil
+}
+
+// SaveEventRSVP はメンバーの予定への出欠の回答と出席の記録を保存する
+func (r *GroupRepository) SaveEventRSVP(ctx context.Context, rsvp *domain.EventRSVP) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[rsvp.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	key := eventKey(rsvp.GroupID, rsvp.EventID)
+	if r.rsvps[key] == nil {
+		r.rsvps[key] = make(map[uuid.UUID]*domain.EventRSVP)
+	}
+	r.rsvps[key][rsvp.UserID] = copyEventRSVP(rsvp)
+	return nil
+}
+
+// ListEventRSVPs は予定の出欠を取得する
+func (r *GroupRepository) ListEventRSVPs(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventRSVP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rsvps := make([]*domain.EventRSVP, 0, len(r.rsvps[eventKey(groupID, eventID)]))
+	for _, rsvp := range r.rsvps[eventKey(groupID, eventID)] {
+		rsvps = append(rsvps, copyEventRSVP(rsvp))
+	}
+	return rsvps, nil
+}
+
+// ListGroupEventRSVPs はグループのすべての予定の出欠を取得する
+func (r *GroupRepository) ListGroupEventRSVPs(ctx context.Context, groupID uuid.UUID) ([]*domain.EventRSVP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rsvps []*domain.EventRSVP
+	for _, byUser := range r.rsvps {
+		for _, rsvp := range byUser {
+			if rsvp.GroupID == groupID {
+				rsvps = append(rsvps, copyEventRSVP(rsvp))
+			}
+		}
+	}
+	return rsvps, nil
+}
+
+// IsEventReminded は予定の出欠のリマインダーを送信済みか確認する
+func (r *GroupRepository) IsEventReminded(ctx context.Context, groupID uuid.UUID, eventID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.reminders[eventKey(groupID, eventID)]
+	return ok, nil
+}
+
+// SaveEventReminder は予定の出欠のリマインダーを送信済みにする
+func (r *GroupRepository) SaveEventReminder(ctx context.Context, groupID uuid.UUID, eventID string, remindedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reminders[eventKey(groupID, eventID)] = remindedAt
+	return nil
+}
+
+// GetEventRecurrence は予定の繰り返しの設定を取得する（設定がない場合は nil, nil）
+func (r *GroupRepository) GetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.EventRecurrence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	recurrence, ok := r.recurrences[eventKey(groupID, eventID)]
+	if !ok {
+		return nil, nil
+	}
+	return copyEventRecurrence(recurrence), nil
+}
+
+// SaveEventRecurrence は予定の繰り返しの設定を保存する
+func (r *GroupRepository) SaveEventRecurrence(ctx context.Context, recurrence *domain.EventRecurrence) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[recurrence.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	r.recurrences[eventKey(recurrence.GroupID, recurrence.EventID)] = copyEventRecurrence(recurrence)
+	return nil
+}
+
+// DeleteEventRecurrence は予定の繰り返しの設定と回ごとの変更を削除する
+func (r *GroupRepository) DeleteEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.recurrences, eventKey(groupID, eventID))
+	delete(r.exceptions, eventKey(groupID, eventID))
+	return nil
+}
+
+// ListEventRecurrences はグループの繰り返しの設定を取得する
+func (r *GroupRepository) ListEventRecurrences(ctx context.Context, groupID uuid.UUID) ([]*domain.EventRecurrence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var recurrences []*domain.EventRecurrence
+	for _, recurrence := range r.recurrences {
+		if recurrence.GroupID == groupID {
+			recurrences = append(recurrences, copyEventRecurrence(recurrence))
+		}
+	}
+	return recurrences, nil
+}
+
+// ListActiveEventRecurrences はすべてのグループから、Until が now 以降（または無期限）の繰り返しの設定を取得する
+func (r *GroupRepository) ListActiveEventRecurrences(ctx context.Context, now time.Time) ([]*domain.EventRecurrence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var recurrences []*domain.EventRecurrence
+	for _, recurrence := range r.recurrences {
+		if recurrence.Until == nil || !recurrence.Until.Before(now) {
+			recurrences = append(recurrences, copyEventRecurrence(recurrence))
+		}
+	}
+	return recurrences, nil
+}
+
+// ListEventExceptions は予定の回ごとの変更を取得する
+func (r *GroupRepository) ListEventExceptions(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventException, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exceptions := make([]*domain.EventException, 0, len(r.exceptions[eventKey(groupID, eventID)]))
+	for _, exception := range r.exceptions[eventKey(groupID, eventID)] {
+		exceptions = append(exceptions, copyEventException(exception))
+	}
+	return exceptions, nil
+}
+
+// ListGroupEventExceptions はグループのすべての予定の回ごとの変更を取得する
+func (r *GroupRepository) ListGroupEventExceptions(ctx context.Context, groupID uuid.UUID) ([]*domain.EventException, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var exceptions []*domain.EventException
+	for _, byOriginal := range r.exceptions {
+		for _, exception := range byOriginal {
+			if exception.GroupID == groupID {
+				exceptions = append(exceptions, copyEventException(exception))
+			}
+		}
+	}
+	return exceptions, nil
+}
+
+// SaveEventException は回の変更を保存する（同じ回の変更は上書きする）
+func (r *GroupRepository) SaveEventException(ctx context.Context, exception *domain.EventException) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[exception.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	key := eventKey(exception.GroupID, exception.EventID)
+	if r.exceptions[key] == nil {
+		r.exceptions[key] = make(map[int64]*domain.EventException)
+	}
+	r.exceptions[key][exception.OriginalStartsAt.Unix()] = copyEventException(exception)
+	return nil
+}
+
+// DeleteEventException は回の変更を削除する
+func (r *GroupRepository) DeleteEventException(ctx context.Context, groupID uuid.UUID, eventID string, originalStartsAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.exceptions[eventKey(groupID, eventID)], originalStartsAt.Unix())
+	return nil
+}
+
+// CreateEventNote は議事録を作成する
+func (r *GroupRepository) CreateEventNote(ctx context.Context, note *domain.EventNote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[note.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	if _, ok := r.notes[note.ID]; ok {
+		return fmt.Errorf("failed to create note: duplicate id %s", note.ID)
+	}
+	r.notes[note.ID] = copyEventNote(note)
+	return nil
+}
+
+// GetEventNote はグループの議事録を取得する（存在しない場合は nil, nil）
+func (r *GroupRepository) GetEventNote(ctx context.Context, groupID, noteID uuid.UUID) (*domain.EventNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	note, ok := r.notes[noteID]
+	if !ok || note.GroupID != groupID {
+		return nil, nil
+	}
+	return copyEventNote(note), nil
+}
+
+// UpdateEventNote は議事録のタイトルと本文を更新する
+func (r *GroupRepository) UpdateEventNote(ctx context.Context, note *domain.EventNote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.notes[note.ID]; !ok {
+		return fmt.Errorf("note not found")
+	}
+	r.notes[note.ID] = copyEventNote(note)
+	return nil
+}
+
+// DeleteEventNote は議事録と、作成したタスクとの対応を削除する（タスクは削除しない）
+func (r *GroupRepository) DeleteEventNote(ctx context.Context, groupID, noteID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if note, ok := r.notes[noteID]; ok && note.GroupID == groupID {
+		delete(r.notes, noteID)
+		delete(r.noteItems, noteID)
+	}
+	return nil
+}
+
+// ListEventNotes は予定の議事録を作成日時の古い順に取得する
+func (r *GroupRepository) ListEventNotes(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var notes []*domain.EventNote
+	for _, note := range r.notes {
+		if note.GroupID == groupID && note.EventID == eventID {
+			notes = append(notes, copyEventNote(note))
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.Before(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+// ListNoteActionItems は議事録から作成したタスクを作成日時の古い順に取得する
+func (r *GroupRepository) ListNoteActionItems(ctx context.Context, noteID uuid.UUID) ([]*domain.NoteActionItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]*domain.NoteActionItem, 0, len(r.noteItems[noteID]))
+	for _, item := range r.noteItems[noteID] {
+		items = append(items, copyNoteActionItem(item))
+	}
+	return items, nil
+}
+
+// SaveNoteActionItem は議事録から作成したタスクを記録する
+func (r *GroupRepository) SaveNoteActionItem(ctx context.Context, item *domain.NoteActionItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.notes[item.NoteID]; !ok {
+		return fmt.Errorf("note not found")
+	}
+	r.noteItems[item.NoteID] = append(r.noteItems[item.NoteID], copyNoteActionItem(item))
+	return nil
+}
+
+// GetNoteActionItemByTask はタスクを作成したアクションアイテムを取得する（議事録から作成したタスクでない場合は nil, nil）
+func (r *GroupRepository) GetNoteActionItemByTask(ctx context.Context, groupID uuid.UUID, taskID string) (*domain.NoteActionItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, items := range r.noteItems {
+		for _, item := range items {
+			if item.GroupID == groupID && item.TaskID == taskID {
+				return copyNoteActionItem(item), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// SaveEventTaskLink は予定とタスクの関連付けを記録する
+func (r *GroupRepository) SaveEventTaskLink(ctx context.Context, link *domain.EventTaskLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.links {
+		if existing.EventID == link.EventID && existing.TaskID == link.TaskID {
+			return fmt.Errorf("event link already exists")
+		}
+	}
+	copied := *link
+	copied.Task = nil
+	r.links = append(r.links, &copied)
+	return nil
+}
+
+// ListEventTaskLinks は予定（回）の関連付けを作成日時の古い順に取得する
+func (r *GroupRepository) ListEventTaskLinks(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventTaskLink, error) {
+	return r.listLinks(func(link *domain.EventTaskLink) bool {
+		return link.GroupID == groupID && link.EventID == eventID
+	}), nil
+}
+
+// ListTaskLinks はタスク、またはタスクを予定（繰り返しの予定の回を含む）とする関連付けを作成日時の古い順に取得する
+func (r *GroupRepository) ListTaskLinks(ctx context.Context, taskID string) ([]*domain.EventTaskLink, error) {
+	return r.listLinks(func(link *domain.EventTaskLink) bool {
+		return link.TaskID == taskID || link.EventID == taskID || strings.HasPrefix(link.EventID, taskID+"_")
+	}), nil
+}
+
+func (r *GroupRepository) listLinks(match func(link *domain.EventTaskLink) bool) []*domain.EventTaskLink {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	links := []*domain.EventTaskLink{}
+	for _, link := range r.links {
+		if match(link) {
+			copied := *link
+			links = append(links, &copied)
+		}
+	}
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].CreatedAt.Before(links[j].CreatedAt)
+	})
+	return links
+}
+
+func eventKey(groupID uuid.UUID, eventID string) string {
+	return groupID.String() + "/" + eventID
+}
+
+// SaveChatIntegration はグループのチャット連携を保存する（同じ連携先の設定は上書きする）
+func (r *GroupRepository) SaveChatIntegration(ctx context.Context, integration *domain.ChatIntegration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[integration.GroupID]; !ok {
+		return fmt.Errorf("group not found")
+	}
+	key := eventKey(integration.GroupID, string(integration.Platform))
+	copied := copyChatIntegration(integration)
+	if existing, ok := r.chats[key]; ok {
+		copied.CreatedBy = existing.CreatedBy
+		copied.CreatedAt = existing.CreatedAt
+	}
+	r.chats[key] = copied
+	return nil
+}
+
+// GetChatIntegration はグループのチャット連携を取得する（設定がない場合は nil, nil）
+func (r *GroupRepository) GetChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform) (*domain.ChatIntegration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	integration, ok := r.chats[eventKey(groupID, string(platform))]
+	if !ok {
+		return nil, nil
+	}
+	return copyChatIntegration(integration), nil
+}
+
+// ListChatIntegrations はグループのチャット連携を連携先の順に取得する
+func (r *GroupRepository) ListChatIntegrations(ctx context.Context, groupID uuid.UUID) ([]*domain.ChatIntegration, error) {
+	return r.listChatIntegrations(func(integration *domain.ChatIntegration) bool {
+		return integration.GroupID == groupID
+	}), nil
+}
+
+// ListEnabledChatIntegrations はすべてのグループから有効なチャット連携を取得する
+func (r *GroupRepository) ListEnabledChatIntegrations(ctx context.Context) ([]*domain.ChatIntegration, error) {
+	return r.listChatIntegrations(func(integration *domain.ChatIntegration) bool {
+		return integration.Enabled
+	}), nil
+}
+
+// DeleteChatIntegration はグループのチャット連携を削除する
+func (r *GroupRepository) DeleteChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.chats, eventKey(groupID, string(platform)))
+	return nil
+}
+
+func (r *GroupRepository) listChatIntegrations(match func(integration *domain.ChatIntegration) bool) []*domain.ChatIntegration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	integrations := []*domain.ChatIntegration{}
+	for _, integration := range r.chats {
+		if match(integration) {
+			integrations = append(integrations, copyChatIntegration(integration))
+		}
+	}
+	sort.Slice(integrations, func(i, j int) bool {
+		if integrations[i].GroupID != integrations[j].GroupID {
+			return integrations[i].GroupID.String() < integrations[j].GroupID.String()
+		}
+		return integrations[i].Platform < integrations[j].Platform
+	})
+	return integrations
+}
+
+func copyEventRSVP(rsvp *domain.EventRSVP) *domain.EventRSVP {
+	copied := *rsvp
+	if rsvp.Attended != nil {
+		attended := *rsvp.Attended
+		copied.Attended = &attended
+	}
+	if rsvp.RespondedAt != nil {
+		respondedAt := *rsvp.RespondedAt
+		copied.RespondedAt = &respondedAt
+	}
+	return &copied
+}
+
+func copyEventRecurrence(recurrence *domain.EventRecurrence) *domain.EventRecurrence {
+	copied := *recurrence
+	copied.Weekdays = append([]string(nil), recurrence.Weekdays...)
+	if recurrence.Until != nil {
+		until := *recurrence.Until
+		copied.Until = &until
+	}
+	return &copied
+}
+
+func copyEventException(exception *domain.EventException) *domain.EventException {
+	copied := *exception
+	if exception.StartsAt != nil {
+		startsAt := *exception.StartsAt
+		copied.StartsAt = &startsAt
+	}
+	return &copied
+}
+
+func copyEventNote(note *domain.EventNote) *domain.EventNote {
+	copied := *note
+	// 作成したタスクは noteItems で管理する
+	copied.ActionItems = nil
+	return &copied
+}
+
+func copyNoteActionItem(item *domain.NoteActionItem) *domain.NoteActionItem {
+	copied := *item
+	if item.AssigneeID != nil {
+		assigneeID := *item.AssigneeID
+		copied.AssigneeID = &assigneeID
+	}
+	return &copied
+}
+
+func copyChatIntegration(integration *domain.ChatIntegration) *domain.ChatIntegration {
+	copied := *integration
+	copied.Events = append([]domain.ChatEvent{}, integration.Events...)
+	copied.Templates = make(map[domain.ChatEvent]string, len(integration.Templates))
+	for event, text := range integration.Templates {
+		copied.Templates[event] = text
+	}
+	if integration.DueCheckedUntil != nil {
+		until := *integration.DueCheckedUntil
+		copied.DueCheckedUntil = &until
+	}
+	return &copied
+}
+
+func copyAssignmentSettings(settings *domain.AssignmentSettings) *domain.AssignmentSettings {
+	copied := *settings
+	if settings.LastAssigneeID != nil {
+		id := *settings.LastAssigneeID
+		copied.LastAssigneeID = &id
+	}
+	return &copied
+}
+
+// paginate はページに該当する範囲を返す
+func paginate[T any](items []T, pagination commonDomain.Pagination) []T {
+	if pagination.PageSize <= 0 {
+		return items
+	}
+	page := pagination.Page
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * pagination.PageSize
+	if start >= len(items) {
+		return items[:0]
+	}
+	end := start + pagination.PageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupRepository_CreateGroup_AddsOwner(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	ownerID := uuid.New()
+	group := domain.NewGroup("Team", "", domain.GroupTypeProject, ownerID)
+
+	require.NoError(t, repo.CreateGroup(ctx, group))
+
+	role, err := repo.GetMemberRole(ctx, group.ID, ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleOwner, role)
+
+	groups, total, err := repo.ListGroupsByMember(ctx, ownerID, commonDomain.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, group.ID, groups[0].ID)
+}
+
+func TestGroupRepository_UpdateGroup_VersionConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	group := domain.NewGroup("Team", "", domain.GroupTypeProject, uuid.New())
+	require.NoError(t, repo.CreateGroup(ctx, group))
+
+	updated := *group
+	updated.Name = "Renamed"
+	updated.Version = group.Version + 1
+	require.NoError(t, repo.UpdateGroup(ctx, &updated))
+
+	// 古いバージョンからの更新は競合として拒否する
+	stale := *group
+	stale.Name = "Stale"
+	stale.Version = group.Version + 1
+	assert.Error(t, repo.UpdateGroup(ctx, &stale))
+
+	got, err := repo.GetGroupByID(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", got.Name)
+}
+
+func TestGroupRepository_MemberIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	ownerID, memberID, outsiderID := uuid.New(), uuid.New(), uuid.New()
+
+	group := domain.NewGroup("Team", "", domain.GroupTypeProject, ownerID)
+	require.NoError(t, repo.CreateGroup(ctx, group))
+	require.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, memberID, domain.RoleMember)))
+	require.NoError(t, repo.CreateGroup(ctx, domain.NewGroup("Other", "", domain.GroupTypeProject, outsiderID)))
+
+	// 重複したメンバー追加はエラー
+	assert.Error(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, memberID, domain.RoleMember)))
+
+	assert.ElementsMatch(t, []uuid.UUID{memberID}, repo.MemberIDs(ctx, ownerID))
+	assert.Empty(t, repo.MemberIDs(ctx, outsiderID))
+}
+
+func TestGroupRepository_AddMembers(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	ownerID, existingID, newID := uuid.New(), uuid.New(), uuid.New()
+
+	group := domain.NewGroup("Team", "", domain.GroupTypeProject, ownerID)
+	require.NoError(t, repo.CreateGroup(ctx, group))
+	require.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, existingID, domain.RoleMember)))
+
+	existing, err := repo.GetExistingMemberIDs(ctx, group.ID, []uuid.UUID{existingID, newID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{existingID: true}, existing)
+
+	// 既存メンバーを含む場合は何も追加しない
+	conflict := *group
+	conflict.AddMembers(2)
+	err = repo.AddMembers(ctx, &conflict, []*domain.GroupMember{
+		domain.NewGroupMember(group.ID, newID, domain.RoleMember),
+		domain.NewGroupMember(group.ID, existingID, domain.RoleMember),
+	})
+	assert.Error(t, err)
+	isMember, err := repo.IsMember(ctx, group.ID, newID)
+	require.NoError(t, err)
+	assert.False(t, isMember)
+
+	updated := *group
+	updated.AddMembers(1)
+	require.NoError(t, repo.AddMembers(ctx, &updated, []*domain.GroupMember{
+		domain.NewGroupMember(group.ID, newID, domain.RoleMember),
+	}))
+
+	got, err := repo.GetGroupByID(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, group.MemberCount+1, got.MemberCount)
+	assert.Equal(t, updated.Version, got.Version)
+	isMember, err = repo.IsMember(ctx, group.ID, newID)
+	require.NoError(t, err)
+	assert.True(t, isMember)
+}
+
+func TestGroupRepository_ChildGroups(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	ownerID, memberID := uuid.New(), uuid.New()
+
+	parent := domain.NewGroup("Project", "", domain.GroupTypeProject, ownerID)
+	require.NoError(t, repo.CreateGroup(ctx, parent))
+	child := domain.NewGroup("Team", "", domain.GroupTypeProject, ownerID)
+	child.ParentGroupID = &parent.ID
+	require.NoError(t, repo.CreateGroup(ctx, child))
+	require.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(child.ID, memberID, domain.RoleMember)))
+
+	children, err := repo.ListChildGroups(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, child.ID, children[0].ID)
+
+	// 両方に所属するオーナーは1人として数える
+	count, err := repo.CountDistinctMembers(ctx, []uuid.UUID{parent.ID, child.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// 親グループを削除してもサブチームは最上位のグループとして残る
+	require.NoError(t, repo.DeleteGroup(ctx, parent.ID))
+	got, err := repo.GetGroupByID(ctx, child.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Nil(t, got.ParentGroupID)
+}
+
+func TestGroupRepository_CustomRoles(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	ownerID, memberID := uuid.New(), uuid.New()
+
+	group := domain.NewGroup("Team", "", domain.GroupTypeProject, ownerID)
+	require.NoError(t, repo.CreateGroup(ctx, group))
+	role, err := domain.NewCustomRole(group.ID, "Viewer", nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCustomRole(ctx, role))
+	require.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, memberID, role.Role())))
+
+	// グループ内で名前は重複できない
+	duplicate, err := domain.NewCustomRole(group.ID, "viewer", nil)
+	require.NoError(t, err)
+	assert.Error(t, repo.CreateCustomRole(ctx, duplicate))
+
+	// 他のグループからは参照できない
+	got, err := repo.GetCustomRole(ctx, uuid.New(), role.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// 削除すると割り当てていたメンバーは MEMBER に戻る
+	require.NoError(t, repo.DeleteCustomRole(ctx, group.ID, role.ID))
+	memberRole, err := repo.GetMemberRole(ctx, group.ID, memberID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleMember, memberRole)
+
+	roles, err := repo.ListCustomRoles(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+}
+
+func TestGroupRepository_AssignmentSettings(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGroupRepository()
+	group := domain.NewGroup("Team", "", domain.GroupTypeProject, uuid.New())
+	require.NoError(t, repo.CreateGroup(ctx, group))
+
+	// 未設定の場合は nil
+	got, err := repo.GetAssignmentSettings(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	settings := domain.NewAssignmentSettings(group.ID)
+	settings.Policy = domain.AssignmentPolicyRoundRobin
+	assigneeID := uuid.New()
+	lastAssigneeID := assigneeID
+	settings.LastAssigneeID = &assigneeID
+	require.NoError(t, repo.SaveAssignmentSettings(ctx, settings))
+
+	// 保存後に呼び出し側で変更しても影響しない
+	*settings.LastAssigneeID = uuid.New()
+	got, err = repo.GetAssignmentSettings(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AssignmentPolicyRoundRobin, got.Policy)
+	assert.Equal(t, lastAssigneeID, *got.LastAssigneeID)
+
+	// 存在しないグループには保存できない
+	assert.Error(t, repo.SaveAssignmentSettings(ctx, domain.NewAssignmentSettings(uuid.New())))
+
+	// グループを削除すると設定も削除される
+	require.NoError(t, repo.DeleteGroup(ctx, group.ID))
+	got, err = repo.GetAssignmentSettings(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
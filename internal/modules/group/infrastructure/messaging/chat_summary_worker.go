@@ -0,0 +1,118 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// チャット連携の1日のまとめ・期限のリマインダーのメトリクス名
+const (
+	MetricChatSummaryRuns      = "group_chat_summary_runs_total"
+	MetricChatSummaryFailures  = "group_chat_summary_failures_total"
+	MetricChatSummariesSent    = "group_chat_summaries_sent_total"
+	MetricChatDueRemindersSent = "group_chat_due_reminders_sent_total"
+)
+
+// ChatSummaryWorker はグループのチャット連携に1日のまとめ・期限のリマインダーを投稿する時刻を定期的に確認して投稿するワーカー
+type ChatSummaryWorker struct {
+	groupService usecase.GroupService
+	interval     time.Duration
+	logger       logger.Logger
+	ticker       *time.Ticker
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+	isRunning    bool
+}
+
+// NewChatSummaryWorker は新しいChatSummaryWorkerを作成
+func NewChatSummaryWorker(
+	groupService usecase.GroupService,
+	interval time.Duration,
+	logger logger.Logger,
+) *ChatSummaryWorker {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &ChatSummaryWorker{
+		groupService: groupService,
+		interval:     interval,
+		logger:       logger,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始
+func (w *ChatSummaryWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Group chat summary worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(w.interval)
+
+	w.logger.Info("Starting group chat summary worker", logger.Any("interval", w.interval.String()))
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.run(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Group chat summary worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Group chat summary worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// run は1日のまとめと期限のリマインダーを投稿し、件数をメトリクスに記録する
+func (w *ChatSummaryWorker) run(ctx context.Context) {
+	defer errtrack.Recover("group-chat-summary-worker")
+	metrics.Counter(MetricChatSummaryRuns).Add(1)
+	now := time.Now()
+
+	sent, err := w.groupService.SendChatDailySummaries(ctx, now)
+	metrics.Counter(MetricChatSummariesSent).Add(int64(sent))
+	if err != nil {
+		metrics.Counter(MetricChatSummaryFailures).Add(1)
+		w.logger.Error("Failed to send chat daily summaries", logger.Error(err))
+	} else if sent > 0 {
+		w.logger.Info("Chat daily summaries sent", logger.Any("sent", sent))
+	}
+
+	reminded, err := w.groupService.SendChatDueReminders(ctx, now)
+	metrics.Counter(MetricChatDueRemindersSent).Add(int64(reminded))
+	if err != nil {
+		metrics.Counter(MetricChatSummaryFailures).Add(1)
+		w.logger.Error("Failed to send chat due reminders", logger.Error(err))
+	} else if reminded > 0 {
+		w.logger.Info("Chat due reminders sent", logger.Any("sent", reminded))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *ChatSummaryWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping group chat summary worker")
+	<-w.doneCh
+}
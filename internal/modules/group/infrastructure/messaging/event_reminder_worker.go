@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// 出欠のリマインダーのメトリクス名
+const (
+	MetricRSVPReminderRuns     = "group_rsvp_reminder_runs_total"
+	MetricRSVPReminderFailures = "group_rsvp_reminder_failures_total"
+	MetricRSVPRemindersSent    = "group_rsvp_reminders_sent_total"
+)
+
+// EventReminderWorker は開始が近い予定の出欠を回答していないメンバーにリマインダーを定期的に送るワーカー
+type EventReminderWorker struct {
+	groupService usecase.GroupService
+	interval     time.Duration
+	logger       logger.Logger
+	ticker       *time.Ticker
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+	isRunning    bool
+}
+
+// NewEventReminderWorker は新しいEventReminderWorkerを作成
+func NewEventReminderWorker(
+	groupService usecase.GroupService,
+	interval time.Duration,
+	logger logger.Logger,
+) *EventReminderWorker {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &EventReminderWorker{
+		groupService: groupService,
+		interval:     interval,
+		logger:       logger,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始
+func (w *EventReminderWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Group event reminder worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(w.interval)
+
+	w.logger.Info("Starting group event reminder worker", logger.Any("interval", w.interval.String()))
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.run(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Group event reminder worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Group event reminder worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// run はリマインダーを送り、件数をメトリクスに記録する
+func (w *EventReminderWorker) run(ctx context.Context) {
+	defer errtrack.Recover("group-event-reminder-worker")
+	metrics.Counter(MetricRSVPReminderRuns).Add(1)
+
+	sent, err := w.groupService.SendRSVPReminders(ctx, time.Now())
+	metrics.Counter(MetricRSVPRemindersSent).Add(int64(sent))
+	if err != nil {
+		metrics.Counter(MetricRSVPReminderFailures).Add(1)
+		w.logger.Error("Failed to send rsvp reminders", logger.Error(err))
+		return
+	}
+
+	if sent > 0 {
+		w.logger.Info("Rsvp reminders sent", logger.Any("sent", sent))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *EventReminderWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping group event reminder worker")
+	<-w.doneCh
+}
@@ -1,16 +1,21 @@
 package controller
 
 import (
+	"errors"
+	"mime"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
 	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/group/domain"
 	"github.com/hryt430/Yotei+/internal/modules/group/interface/dto"
 	groupUsecase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	"github.com/hryt430/Yotei+/internal/modules/group/usecase/ical"
 	"github.com/hryt430/Yotei+/pkg/logger"
 	"go.uber.org/zap/zapcore"
 )
@@ -29,10 +34,11 @@ func NewGroupController(groupService groupUsecase.GroupService, logger logger.Lo
 
 // Swagger用のリクエスト/レスポンス構造体定義
 type CreateGroupRequest struct {
-	Name        string               `json:"name" binding:"required,max=100" example:"プロジェクトチーム"`
-	Description string               `json:"description" binding:"max=500" example:"新製品開発プロジェクトのチーム"`
-	Type        string               `json:"type" binding:"required" enums:"PROJECT,SCHEDULE" example:"PROJECT"`
-	Settings    domain.GroupSettings `json:"settings"`
+	Name          string               `json:"name" binding:"required,max=100" example:"プロジェクトチーム"`
+	Description   string               `json:"description" binding:"max=500" example:"新製品開発プロジェクトのチーム"`
+	Type          string               `json:"type" binding:"required" enums:"PROJECT,SCHEDULE" example:"PROJECT"`
+	Settings      domain.GroupSettings `json:"settings"`
+	ParentGroupID *string              `json:"parent_group_id,omitempty" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // サブチームとして作成する場合の親グループ
 } // @name CreateGroupRequest
 
 type UpdateGroupRequest struct {
@@ -60,6 +66,7 @@ type ErrorResponse struct {
 // CreateGroup グループ作成
 // @Summary      グループ作成
 // @Description  新しいグループを作成します（プロジェクト管理用または予定共有用）
+// @Description  parent_group_id を指定すると、そのプロジェクトグループのサブチームとして作成します（親グループのオーナー・管理者のみ）
 // @Tags         groups
 // @Accept       json
 // @Produce      json
@@ -68,6 +75,8 @@ type ErrorResponse struct {
 // @Success      201 {object} dto.GroupResponse "グループ作成成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "親グループにサブチームを作成する権限なし"
+// @Failure      429 {object} ErrorResponse "グループ数が上限に達している"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /groups [post]
 func (gc *GroupController) CreateGroup(c *gin.Context) {
@@ -98,16 +107,50 @@ func (gc *GroupController) CreateGroup(c *gin.Context) {
 		OwnerID:     user.ID,
 		Settings:    req.Settings,
 	}
+	if req.ParentGroupID != nil {
+		parentID, err := gc.validateUUID(*req.ParentGroupID, "parent group ID")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_PARENT_GROUP_ID",
+				Message: "親グループIDが不正です",
+			})
+			return
+		}
+		input.ParentGroupID = &parentID
+	}
 
 	group, err := gc.groupService.CreateGroup(c.Request.Context(), input)
 	if err != nil {
-		gc.logError("create group", err,
-			logger.Any("userID", user.ID),
-			logger.Any("groupName", req.Name))
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "INTERNAL_ERROR",
-			Message: "グループの作成に失敗しました",
-		})
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidParentGroup):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_PARENT_GROUP",
+				Message: "サブチームはプロジェクトグループにのみ作成できます",
+			})
+		case errors.Is(err, groupUsecase.ErrGroupHierarchyTooDeep):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "GROUP_HIERARCHY_TOO_DEEP",
+				Message: "これ以上深い階層のサブチームは作成できません",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "親グループにサブチームを作成する権限がありません",
+			})
+		case errors.Is(err, commonDomain.ErrQuotaExceeded):
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{
+				Error:   "QUOTA_EXCEEDED",
+				Message: "作成できるグループ数の上限に達しています",
+			})
+		default:
+			gc.logError("create group", err,
+				logger.Any("userID", user.ID),
+				logger.Any("groupName", req.Name))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "グループの作成に失敗しました",
+			})
+		}
 		return
 	}
 
@@ -420,7 +463,7 @@ func (gc *GroupController) SearchGroups(c *gin.Context) {
 // @Success      200 {object} SuccessResponse "メンバー追加成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
-// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      403 {object} ErrorResponse "権限不足、またはブロック関係にあるユーザー"
 // @Failure      404 {object} ErrorResponse "グループまたはユーザーが見つからない"
 // @Failure      409 {object} ErrorResponse "既にメンバー"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
@@ -471,6 +514,20 @@ func (gc *GroupController) AddMember(c *gin.Context) {
 
 	err = gc.groupService.AddMember(c.Request.Context(), groupID, userIDToAdd, user.ID, role)
 	if err != nil {
+		if errors.Is(err, groupUsecase.ErrUserBlocked) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "USER_BLOCKED",
+				Message: "このユーザーはグループに追加できません",
+			})
+			return
+		}
+		if errors.Is(err, groupUsecase.ErrInvalidRole) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_ROLE",
+				Message: "グループで使用できないロールです",
+			})
+			return
+		}
 		gc.logError("add member", err,
 			logger.Any("groupID", groupID),
 			logger.Any("userIDToAdd", userIDToAdd),
@@ -493,16 +550,125 @@ func (gc *GroupController) AddMember(c *gin.Context) {
 	})
 }
 
+// BulkAddMembers メンバー一括追加
+// @Summary      メンバー一括追加
+// @Description  指定されたグループに複数のユーザーを一括で追加します（最大100人）。ユーザーごとの結果（ADDED / ALREADY_MEMBER / NOT_FOUND / BLOCKED）を返します
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.BulkAddMembersRequest true "一括追加するユーザー"
+// @Security     BearerAuth
+// @Success      200 {object} dto.BulkAddMembersResponse "ユーザーごとの追加結果"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/members/bulk [post]
+func (gc *GroupController) BulkAddMembers(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var req dto.BulkAddMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(req.UserIDs))
+	for i, id := range req.UserIDs {
+		userIDs[i], err = gc.validateUUID(id, "user ID")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_USER_ID",
+				Message: "ユーザーIDが不正です",
+			})
+			return
+		}
+	}
+
+	role := domain.MemberRole(req.Role)
+	if role == "" {
+		role = domain.RoleMember
+	}
+
+	results, err := gc.groupService.AddMembers(c.Request.Context(), groupID, user.ID, userIDs, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidBulkAddMembers):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_REQUEST",
+				Message: "追加するユーザーの指定が不正です",
+			})
+		case errors.Is(err, groupUsecase.ErrInvalidRole):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_ROLE",
+				Message: "グループで使用できないロールです",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "メンバーを追加する権限がありません",
+			})
+		default:
+			gc.logError("bulk add members", err,
+				logger.Any("groupID", groupID),
+				logger.Any("requesterID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "メンバーの追加に失敗しました",
+			})
+		}
+		return
+	}
+
+	response := dto.ToBulkAddMembersResponse(results)
+	gc.logger.Info("Members added in bulk",
+		logger.Any("groupID", groupID),
+		logger.Int("requested", len(userIDs)),
+		logger.Int("added", response.AddedCount))
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MemberRemovedResponse はメンバー削除レスポンス（取り消し可能な場合は取り消し用のトークンを含む）
+type MemberRemovedResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"メンバーを削除しました"`
+	Undo    *undo.Token `json:"undo,omitempty"`
+} // @name MemberRemovedResponse
+
 // RemoveMember メンバー削除
 // @Summary      メンバー削除
 // @Description  指定されたグループからメンバーを削除します（管理者のみ、または自分自身の脱退）
+// @Description  削除は取り消し期間（既定10秒）の経過後に実行され、その間はundo.undo_tokenをPOST /undo/{token}に送ると取り消せます
 // @Tags         groups
 // @Accept       json
 // @Produce      json
 // @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Param        userId path string true "削除するユーザーID" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Security     BearerAuth
-// @Success      200 {object} SuccessResponse "メンバー削除成功"
+// @Success      200 {object} MemberRemovedResponse "メンバー削除成功"
 // @Failure      400 {object} ErrorResponse "IDが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      403 {object} ErrorResponse "権限不足"
@@ -538,7 +704,7 @@ func (gc *GroupController) RemoveMember(c *gin.Context) {
 		return
 	}
 
-	err = gc.groupService.RemoveMember(c.Request.Context(), groupID, userIDToRemove, user.ID)
+	token, err := gc.groupService.RemoveMemberWithUndo(c.Request.Context(), groupID, userIDToRemove, user.ID)
 	if err != nil {
 		gc.logError("remove member", err,
 			logger.Any("groupID", groupID),
@@ -555,15 +721,17 @@ func (gc *GroupController) RemoveMember(c *gin.Context) {
 		logger.Any("groupID", groupID),
 		logger.Any("userIDToRemove", userIDToRemove))
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
+	c.JSON(http.StatusOK, MemberRemovedResponse{
 		Success: true,
 		Message: "メンバーを削除しました",
+		Undo:    token,
 	})
 }
 
 // UpdateMemberRole メンバー権限変更
 // @Summary      メンバー権限変更
 // @Description  指定されたメンバーの権限を変更します（管理者のみ）
+// @Description  OWNER・ADMIN・MEMBER・GUEST（閲覧のみ）のほか、グループで定義したカスタムロールのIDを指定できます
 // @Tags         groups
 // @Accept       json
 // @Produce      json
@@ -621,6 +789,13 @@ func (gc *GroupController) UpdateMemberRole(c *gin.Context) {
 
 	err = gc.groupService.UpdateMemberRole(c.Request.Context(), groupID, userIDToUpdate, user.ID, newRole)
 	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInvalidRole) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_ROLE",
+				Message: "グループで使用できないロールです",
+			})
+			return
+		}
 		gc.logError("update member role", err,
 			logger.Any("groupID", groupID),
 			logger.Any("userIDToUpdate", userIDToUpdate),
@@ -744,47 +919,2458 @@ func (gc *GroupController) GetGroupStats(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// === ヘルパーメソッド ===
+// GetGroupTree サブチームの階層取得
+// @Summary      サブチームの階層取得
+// @Description  グループとそのサブチームを階層で取得します。各グループの統計と、サブチームを含めた統計（メンバーは重複を除いて数える）を返します
+// @Description  上位グループのオーナー・管理者はサブチームでも管理者と同じ権限を持ちます。閲覧できない非公開のサブチームは含まれません
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.GroupTreeResponse "階層取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/tree [get]
+func (gc *GroupController) GetGroupTree(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
 
-func (gc *GroupController) validateUUID(id string, fieldName string) (uuid.UUID, error) {
-	parsedID, err := uuid.Parse(id)
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
 	if err != nil {
-		gc.logger.Error("Invalid UUID format",
-			logger.String("field", fieldName),
-			logger.String("value", id),
-			logger.Error(err))
-		return uuid.Nil, err
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
 	}
-	return parsedID, nil
+
+	tree, err := gc.groupService.GetGroupTree(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInsufficientPermissions) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+			return
+		}
+		gc.logError("get group tree", err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "サブチームの取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToGroupTreeResponse(tree))
 }
 
-func (gc *GroupController) logError(operation string, err error, fields ...zapcore.Field) {
-	allFields := append([]zapcore.Field{
-		logger.String("operation", operation),
-		logger.Error(err),
-	}, fields...)
-	gc.logger.Error("Operation failed", allFields...)
+// GetGroupPermissions グループの権限設定取得
+// @Summary      グループの権限設定取得
+// @Description  アクションごとに許可されているロールを取得します（オーナーは常に全てのアクションを実行できます）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.GroupPermissionsResponse "権限設定取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/permissions [get]
+func (gc *GroupController) GetGroupPermissions(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	matrix, err := gc.groupService.GetPermissionMatrix(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInsufficientPermissions) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+			return
+		}
+		gc.logError("get group permissions", err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "権限設定の取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToGroupPermissionsResponse(matrix))
 }
 
-// RegisterGroupRoutes はグループ関連のルートを登録する
-func RegisterGroupRoutes(router *gin.RouterGroup, controller *GroupController) {
-	groups := router.Group("/groups")
-	{
-		// グループ基本操作
-		groups.POST("", controller.CreateGroup)
-		groups.GET("/my", controller.ListMyGroups)
-		groups.GET("/search", controller.SearchGroups)
-		groups.GET("/:groupId", controller.GetGroup)
-		groups.PUT("/:groupId", controller.UpdateGroup)
-		groups.DELETE("/:groupId", controller.DeleteGroup)
+// UpdateGroupPermissions グループの権限設定変更
+// @Summary      グループの権限設定変更
+// @Description  アクションごとに許可するロール（ADMIN・MEMBER）を変更します（オーナーのみ）
+// @Description  変更できるのはグループ設定の編集・メンバーの招待と削除・タスクの作成・編集・削除・閲覧・予定の閲覧です。指定しなかったアクションはデフォルトに戻ります
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.UpdateGroupPermissionsRequest true "権限設定"
+// @Security     BearerAuth
+// @Success      200 {object} dto.GroupPermissionsResponse "権限設定変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足（オーナーのみ変更可能）"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/permissions [put]
+func (gc *GroupController) UpdateGroupPermissions(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
 
-		// メンバー管理
-		groups.POST("/:groupId/members", controller.AddMember)
-		groups.DELETE("/:groupId/members/:userId", controller.RemoveMember)
-		groups.PUT("/:groupId/members/:userId/role", controller.UpdateMemberRole)
-		groups.GET("/:groupId/members", controller.ListMembers)
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
 
-		// 統計情報
-		groups.GET("/:groupId/stats", controller.GetGroupStats)
+	var req dto.UpdateGroupPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	matrix, err := gc.groupService.UpdatePermissionMatrix(c.Request.Context(), groupID, user.ID, dto.ToPermissionMatrix(req))
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidPermissionMatrix):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_PERMISSIONS",
+				Message: "変更できないアクションまたはロールが含まれています",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "権限設定を変更できるのはオーナーのみです",
+			})
+		default:
+			gc.logError("update group permissions", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "権限設定の変更に失敗しました",
+			})
+		}
+		return
+	}
+
+	gc.logger.Info("Group permissions updated",
+		logger.Any("groupID", groupID),
+		logger.Any("userID", user.ID))
+
+	c.JSON(http.StatusOK, dto.ToGroupPermissionsResponse(matrix))
+}
+
+// ListCustomRoles カスタムロール一覧取得
+// @Summary      カスタムロール一覧取得
+// @Description  グループで定義したカスタムロールを作成日時の古い順に取得します（メンバーのみ）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.CustomRolesResponse "カスタムロール一覧取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/roles [get]
+func (gc *GroupController) ListCustomRoles(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	roles, err := gc.groupService.ListCustomRoles(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInsufficientPermissions) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+			return
+		}
+		gc.logError("list custom roles", err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "カスタムロールの取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCustomRolesResponse(roles))
+}
+
+// CreateCustomRole カスタムロール作成
+// @Summary      カスタムロール作成
+// @Description  アクションの組み合わせに名前を付けたカスタムロールを作成します（ロール管理の権限が必要）
+// @Description  付与できるのは権限設定で変更できるアクションのみで、グループの閲覧は常に許可されます。1グループ20件まで作成できます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.CustomRoleRequest true "カスタムロール"
+// @Security     BearerAuth
+// @Success      201 {object} dto.CustomRoleResponse "カスタムロール作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/roles [post]
+func (gc *GroupController) CreateCustomRole(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var req dto.CustomRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	role, err := gc.groupService.CreateCustomRole(c.Request.Context(), groupID, user.ID, dto.ToCustomRoleInput(req))
+	if err != nil {
+		gc.handleCustomRoleError(c, "create custom role", err, groupID, user.ID)
+		return
+	}
+
+	gc.logger.Info("Custom role created",
+		logger.Any("groupID", groupID),
+		logger.Any("roleID", role.ID))
+
+	c.JSON(http.StatusCreated, dto.ToCustomRoleResponse(role))
+}
+
+// UpdateCustomRole カスタムロール更新
+// @Summary      カスタムロール更新
+// @Description  カスタムロールの名前と権限を変更します（ロール管理の権限が必要）。割り当て済みのメンバーにもすぐに反映されます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        roleId path string true "カスタムロールID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.CustomRoleRequest true "カスタムロール"
+// @Security     BearerAuth
+// @Success      200 {object} dto.CustomRoleResponse "カスタムロール更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "カスタムロールが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/roles/{roleId} [put]
+func (gc *GroupController) UpdateCustomRole(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	roleID, err := gc.validateUUID(c.Param("roleId"), "role ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "ロールIDが不正です",
+		})
+		return
+	}
+
+	var req dto.CustomRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	role, err := gc.groupService.UpdateCustomRole(c.Request.Context(), groupID, roleID, user.ID, dto.ToCustomRoleInput(req))
+	if err != nil {
+		gc.handleCustomRoleError(c, "update custom role", err, groupID, user.ID)
+		return
+	}
+
+	gc.logger.Info("Custom role updated",
+		logger.Any("groupID", groupID),
+		logger.Any("roleID", roleID))
+
+	c.JSON(http.StatusOK, dto.ToCustomRoleResponse(role))
+}
+
+// DeleteCustomRole カスタムロール削除
+// @Summary      カスタムロール削除
+// @Description  カスタムロールを削除します（ロール管理の権限が必要）。割り当てていたメンバーは MEMBER に戻ります
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        roleId path string true "カスタムロールID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} SuccessResponse "カスタムロール削除成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "カスタムロールが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/roles/{roleId} [delete]
+func (gc *GroupController) DeleteCustomRole(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	roleID, err := gc.validateUUID(c.Param("roleId"), "role ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ROLE_ID",
+			Message: "ロールIDが不正です",
+		})
+		return
+	}
+
+	if err := gc.groupService.DeleteCustomRole(c.Request.Context(), groupID, roleID, user.ID); err != nil {
+		gc.handleCustomRoleError(c, "delete custom role", err, groupID, user.ID)
+		return
+	}
+
+	gc.logger.Info("Custom role deleted",
+		logger.Any("groupID", groupID),
+		logger.Any("roleID", roleID))
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "カスタムロールを削除しました",
+	})
+}
+
+// === ヘルパーメソッド ===
+
+func (gc *GroupController) validateUUID(id string, fieldName string) (uuid.UUID, error) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		gc.logger.Error("Invalid UUID format",
+			logger.String("field", fieldName),
+			logger.String("value", id),
+			logger.Error(err))
+		return uuid.Nil, err
+	}
+	return parsedID, nil
+}
+
+func (gc *GroupController) logError(operation string, err error, fields ...zapcore.Field) {
+	allFields := append([]zapcore.Field{
+		logger.String("operation", operation),
+		logger.Error(err),
+	}, fields...)
+	gc.logger.Error("Operation failed", allFields...)
+}
+
+// handleCustomRoleError はカスタムロール操作のエラーをレスポンスに変換する
+func (gc *GroupController) handleCustomRoleError(c *gin.Context, operation string, err error, groupID, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, groupUsecase.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ROLE",
+			Message: "ロール名または権限が不正です",
+		})
+	case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "FORBIDDEN",
+			Message: "ロールを管理する権限がありません",
+		})
+	case errors.Is(err, groupUsecase.ErrCustomRoleNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "ROLE_NOT_FOUND",
+			Message: "カスタムロールが見つかりません",
+		})
+	default:
+		gc.logError(operation, err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", userID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "カスタムロールの操作に失敗しました",
+		})
+	}
+}
+
+// GetAssignmentPolicy タスク自動割り当て設定取得
+// @Summary      タスク自動割り当て設定取得
+// @Description  担当者を指定せずに作成したグループタスクの自動割り当て方式を取得します（メンバーのみ）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.AssignmentSettingsResponse "自動割り当て設定取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/assignment-policy [get]
+func (gc *GroupController) GetAssignmentPolicy(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	settings, err := gc.groupService.GetAssignmentSettings(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInsufficientPermissions) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+			return
+		}
+		gc.logError("get assignment policy", err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "自動割り当て設定の取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAssignmentSettingsResponse(settings))
+}
+
+// UpdateAssignmentPolicy タスク自動割り当て設定変更
+// @Summary      タスク自動割り当て設定変更
+// @Description  担当者を指定せずに作成したグループタスクの自動割り当て方式を変更します（グループ設定の編集権限が必要）
+// @Description  NONE: 自動割り当てしない、ROUND_ROBIN: メンバーに順番に割り当てる、LEAST_LOADED: 未完了タスクが最も少ないメンバーに割り当てる。ゲストには割り当てません
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.UpdateAssignmentPolicyRequest true "自動割り当て方式"
+// @Security     BearerAuth
+// @Success      200 {object} dto.AssignmentSettingsResponse "自動割り当て設定変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/assignment-policy [put]
+func (gc *GroupController) UpdateAssignmentPolicy(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var req dto.UpdateAssignmentPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	settings, err := gc.groupService.UpdateAssignmentPolicy(c.Request.Context(), groupID, user.ID, domain.AssignmentPolicy(req.Policy))
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidAssignmentPolicy):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_POLICY",
+				Message: "自動割り当て方式が不正です",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "自動割り当て設定を変更する権限がありません",
+			})
+		default:
+			gc.logError("update assignment policy", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "自動割り当て設定の変更に失敗しました",
+			})
+		}
+		return
+	}
+
+	gc.logger.Info("Group assignment policy updated",
+		logger.Any("groupID", groupID),
+		logger.Any("userID", user.ID),
+		logger.Any("policy", settings.Policy))
+
+	c.JSON(http.StatusOK, dto.ToAssignmentSettingsResponse(settings))
+}
+
+// GetLeaderboard グループのリーダーボード取得
+// @Summary      グループのリーダーボード取得
+// @Description  グループタスクの週ごとの完了数・期限内の完了率・連続達成日数のランキングを取得します（メンバーのみ、グループで有効にしている場合のみ）
+// @Description  集計は週（設定したタイムゾーンの月曜日0時）ごとにリセットされます。非公開のメンバーは含まれず、匿名のメンバーは本人以外には名前とユーザーIDを伏せて返します。結果は数分間キャッシュされます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        period query string false "集計する週（省略時は current）" Enums(current, previous)
+// @Security     BearerAuth
+// @Success      200 {object} dto.LeaderboardResponse "リーダーボード取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "リーダーボードが無効"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/leaderboard [get]
+func (gc *GroupController) GetLeaderboard(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var previous bool
+	switch c.DefaultQuery("period", "current") {
+	case "current":
+	case "previous":
+		previous = true
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "periodはcurrentまたはpreviousを指定してください",
+		})
+		return
+	}
+
+	board, err := gc.groupService.GetLeaderboard(c.Request.Context(), groupID, user.ID, previous)
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+		case errors.Is(err, groupUsecase.ErrLeaderboardDisabled):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "LEADERBOARD_DISABLED",
+				Message: "このグループではリーダーボードが有効になっていません",
+			})
+		default:
+			gc.logError("get leaderboard", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "リーダーボードの取得に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLeaderboardResponse(board))
+}
+
+// GetLeaderboardSettings リーダーボード設定取得
+// @Summary      リーダーボード設定取得
+// @Description  グループのリーダーボードの有効・無効、週の区切りのタイムゾーン、自分の公開範囲を取得します（メンバーのみ）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.LeaderboardSettingsResponse "リーダーボード設定取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/leaderboard/settings [get]
+func (gc *GroupController) GetLeaderboardSettings(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	view, err := gc.groupService.GetLeaderboardSettings(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInsufficientPermissions) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+			return
+		}
+		gc.logError("get leaderboard settings", err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "リーダーボード設定の取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLeaderboardSettingsResponse(view.Settings, view.MyVisibility))
+}
+
+// UpdateLeaderboardSettings リーダーボード設定変更
+// @Summary      リーダーボード設定変更
+// @Description  グループのリーダーボードの有効・無効と週の区切りのタイムゾーンを変更します（グループ設定の編集権限が必要）。省略した項目は変更しません
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.UpdateLeaderboardSettingsRequest true "リーダーボード設定"
+// @Security     BearerAuth
+// @Success      200 {object} dto.LeaderboardSettingsResponse "リーダーボード設定変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/leaderboard/settings [put]
+func (gc *GroupController) UpdateLeaderboardSettings(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var req dto.UpdateLeaderboardSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	settings, err := gc.groupService.UpdateLeaderboardSettings(c.Request.Context(), groupID, user.ID, groupUsecase.LeaderboardSettingsInput{
+		Enabled:  req.Enabled,
+		Timezone: req.Timezone,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidLeaderboardSettings):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_REQUEST",
+				Message: "タイムゾーンが不正です",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "リーダーボード設定を変更する権限がありません",
+			})
+		default:
+			gc.logError("update leaderboard settings", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "リーダーボード設定の変更に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLeaderboardSettingsResponse(settings, ""))
+}
+
+// UpdateLeaderboardVisibility リーダーボードの公開範囲変更
+// @Summary      リーダーボードの公開範囲変更
+// @Description  自分をリーダーボードにどう表示するかを変更します（メンバーのみ）
+// @Description  VISIBLE: 名前を表示する（デフォルト）、ANONYMOUS: 名前を伏せて順位に参加する、HIDDEN: リーダーボードに参加しない
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.UpdateLeaderboardVisibilityRequest true "公開範囲"
+// @Security     BearerAuth
+// @Success      200 {object} dto.LeaderboardVisibilityResponse "公開範囲変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループのメンバーではない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/leaderboard/visibility [put]
+func (gc *GroupController) UpdateLeaderboardVisibility(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var req dto.UpdateLeaderboardVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	preference, err := gc.groupService.UpdateLeaderboardVisibility(c.Request.Context(), groupID, user.ID, domain.LeaderboardVisibility(req.Visibility))
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidLeaderboardSettings):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_REQUEST",
+				Message: "公開範囲が不正です",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループのメンバーではありません",
+			})
+		default:
+			gc.logError("update leaderboard visibility", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "公開範囲の変更に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLeaderboardVisibilityResponse(preference))
+}
+
+// GetScheduleCardSettings スケジュールカード設定取得
+// @Summary      スケジュールカード設定取得
+// @Description  グループのスケジュールカード（1週間の予定の共有用の画像・PDF）の有効・無効、日付の区切りのタイムゾーン、タイトルの表示を取得します（メンバーのみ）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.ScheduleCardSettingsResponse "スケジュールカード設定取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/schedule-card/settings [get]
+func (gc *GroupController) GetScheduleCardSettings(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	settings, err := gc.groupService.GetScheduleCardSettings(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		if errors.Is(err, groupUsecase.ErrInsufficientPermissions) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "グループへのアクセス権限がありません",
+			})
+			return
+		}
+		gc.logError("get schedule card settings", err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "スケジュールカード設定の取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToScheduleCardSettingsResponse(settings))
+}
+
+// UpdateScheduleCardSettings スケジュールカード設定変更
+// @Summary      スケジュールカード設定変更
+// @Description  グループのスケジュールカードの有効・無効、日付の区切りのタイムゾーン、タイトルの表示を変更します（グループ設定の編集権限が必要）。省略した項目は変更しません。無効にすると発行済みのリンクでもカードを表示できなくなります
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.UpdateScheduleCardSettingsRequest true "スケジュールカード設定"
+// @Security     BearerAuth
+// @Success      200 {object} dto.ScheduleCardSettingsResponse "スケジュールカード設定変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/schedule-card/settings [put]
+func (gc *GroupController) UpdateScheduleCardSettings(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	var req dto.UpdateScheduleCardSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	settings, err := gc.groupService.UpdateScheduleCardSettings(c.Request.Context(), groupID, user.ID, groupUsecase.ScheduleCardSettingsInput{
+		Enabled:    req.Enabled,
+		Timezone:   req.Timezone,
+		ShowTitles: req.ShowTitles,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidScheduleCardSettings):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_REQUEST",
+				Message: "タイムゾーンが不正です",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "スケジュールカード設定を変更する権限がありません",
+			})
+		default:
+			gc.logError("update schedule card settings", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "スケジュールカード設定の変更に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToScheduleCardSettingsResponse(settings))
+}
+
+// CreateScheduleCardLink スケジュールカードの共有リンク発行
+// @Summary      スケジュールカードの共有リンク発行
+// @Description  今日から1週間の予定のスケジュールカード（画像・PDF）の署名付きの共有リンクを発行します（予定の閲覧権限が必要、スケジュールカードを有効にしたグループのみ）
+// @Description  リンクは14日間有効で、認証なしで表示できるためチャットに貼り付けて共有できます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      201 {object} dto.ScheduleCardLinkResponse "共有リンク発行成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "予定の閲覧権限なし"
+// @Failure      404 {object} ErrorResponse "スケジュールカードが無効"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/schedule-card/link [post]
+func (gc *GroupController) CreateScheduleCardLink(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	view, err := gc.groupService.CreateScheduleCardLink(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrScheduleCardDisabled):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "SCHEDULE_CARD_DISABLED",
+				Message: "このグループではスケジュールカードが有効になっていません",
+			})
+		case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "FORBIDDEN",
+				Message: "予定を閲覧する権限がありません",
+			})
+		default:
+			gc.logError("create schedule card link", err,
+				logger.Any("groupID", groupID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "共有リンクの発行に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToScheduleCardLinkResponse(view.Link, view.ImageURL, view.PDFURL))
+}
+
+// GetPublicScheduleCard スケジュールカード表示
+// @Summary      スケジュールカード表示
+// @Description  署名付きの共有リンクのスケジュールカードを認証なしで出力します。svgは1200x630の画像（チャットのプレビュー向け）、pdfはA4の一覧です
+// @Description  出力は5分間キャッシュします。リンクの有効期限が切れた場合・グループがスケジュールカードを無効にした場合は表示できません
+// @Tags         public
+// @Produce      image/svg+xml
+// @Produce      application/pdf
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        start query string true "カードの開始日（YYYY-MM-DD）" example:"2024-06-03"
+// @Param        expires query int true "有効期限（Unix秒）" example:"1718582400"
+// @Param        signature query string true "署名"
+// @Param        format query string false "出力形式" Enums(svg, pdf) default(svg)
+// @Success      200 {file} file "スケジュールカード"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      403 {object} ErrorResponse "署名が無効・有効期限切れ"
+// @Failure      404 {object} ErrorResponse "スケジュールカードが無効"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /public/groups/{groupId}/schedule-card [get]
+func (gc *GroupController) GetPublicScheduleCard(c *gin.Context) {
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	format := domain.ScheduleCardFormat(c.DefaultQuery("format", string(domain.ScheduleCardSVG)))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "formatはsvgまたはpdfを指定してください",
+		})
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "expiresが不正です",
+		})
+		return
+	}
+
+	link := &domain.ScheduleCardLink{
+		GroupID:   groupID,
+		StartDate: c.Query("start"),
+		ExpiresAt: time.Unix(expires, 0),
+		Signature: c.Query("signature"),
+	}
+	data, err := gc.groupService.RenderScheduleCard(c.Request.Context(), link, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, groupUsecase.ErrInvalidScheduleCardLink):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "INVALID_LINK",
+				Message: "リンクが無効か、有効期限が切れています",
+			})
+		case errors.Is(err, groupUsecase.ErrScheduleCardDisabled):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "SCHEDULE_CARD_DISABLED",
+				Message: "スケジュールカードが見つかりません",
+			})
+		default:
+			gc.logError("render schedule card", err, logger.Any("groupID", groupID))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "INTERNAL_ERROR",
+				Message: "スケジュールカードの出力に失敗しました",
+			})
+		}
+		return
+	}
+
+	// チャットのリンクプレビューが取得できるよう、短時間だけ共有キャッシュを許可する（検索エンジンには残さない）
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": "schedule-card." + string(format)}))
+	c.Data(http.StatusOK, format.ContentType(), data)
+}
+
+// SetEventRSVP 予定への出欠の回答
+// @Summary      予定への出欠の回答
+// @Description  予定共有グループの予定（開始日時のあるグループタスク）に自分の出欠を回答します（メンバーのみ、予定の開始前まで）
+// @Description  YES: 参加する、NO: 参加しない、MAYBE: 未定。回答は開始前なら何度でも変更できます。未回答のメンバーには開始の24時間前にリマインダーを通知します
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.SetRSVPRequest true "出欠"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventRSVPResponse "出欠の回答成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループのメンバーではない"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      409 {object} ErrorResponse "予定が開始済み"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/rsvp [put]
+func (gc *GroupController) SetEventRSVP(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetRSVPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "出欠はYES・NO・MAYBEのいずれかを指定してください",
+		})
+		return
+	}
+
+	rsvp, err := gc.groupService.SetRSVP(c.Request.Context(), groupID, eventID, userID, domain.RSVPStatus(req.Status))
+	if err != nil {
+		gc.handleEventError(c, "set event rsvp", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventRSVPResponse(rsvp))
+}
+
+// GetEventAttendees 予定の出欠一覧取得
+// @Summary      予定の出欠一覧取得
+// @Description  予定共有グループの予定について、メンバー全員の出欠（未回答は NO_RESPONSE）と出席の記録を取得します（メンバーのみ）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventAttendeesResponse "出欠一覧取得成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/attendees [get]
+func (gc *GroupController) GetEventAttendees(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	attendees, err := gc.groupService.GetEventAttendees(c.Request.Context(), groupID, eventID, userID)
+	if err != nil {
+		gc.handleEventError(c, "get event attendees", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventAttendeesResponse(attendees))
+}
+
+// RecordEventAttendance 予定の出席の記録
+// @Summary      予定の出席の記録
+// @Description  メンバーが予定に出席したかを記録します（予定の作成者またはタスクの編集権限を持つメンバーのみ、予定の開始後）
+// @Description  記録した出席はグループ統計のメンバーごとの出席率に反映されます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.RecordAttendanceRequest true "出席の記録"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventRSVPResponse "出席の記録成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      409 {object} ErrorResponse "予定が開始していない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/attendance [put]
+func (gc *GroupController) RecordEventAttendance(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RecordAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+	memberID, err := gc.validateUUID(req.UserID, "user ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_USER_ID",
+			Message: "ユーザーIDが不正です",
+		})
+		return
+	}
+
+	rsvp, err := gc.groupService.RecordAttendance(c.Request.Context(), groupID, eventID, userID, memberID, *req.Attended)
+	if err != nil {
+		gc.handleEventError(c, "record event attendance", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventRSVPResponse(rsvp))
+}
+
+// ListGroupEvents グループの予定一覧取得
+// @Summary      グループの予定一覧取得
+// @Description  予定共有グループの期間内の予定を開始日時の順に取得します（予定の閲覧権限が必要、最大92日）。繰り返しの予定は回ごとに展開し、中止した回は含めず、日時を変更した回は変更後の日時で返します
+// @Description  format=ics の場合はカレンダーアプリに取り込めるiCalendar形式で返します。from・toは暦日としてtimezone（省略時はUTC）で解釈し、toの日を含みます
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        from query string true "開始日 (YYYY-MM-DD)"
+// @Param        to query string true "終了日 (YYYY-MM-DD)"
+// @Param        timezone query string false "日の区切りに使うタイムゾーン（省略時はUTC）" example:"Asia/Tokyo"
+// @Param        format query string false "出力形式（省略時は json）" Enums(json, ics)
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventScheduleResponse "取得成功（format=ics の場合はiCalendar）"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events [get]
+func (gc *GroupController) ListGroupEvents(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "ics" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "formatはjsonまたはicsを指定してください",
+		})
+		return
+	}
+	loc, err := time.LoadLocation(c.DefaultQuery("timezone", "UTC"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "タイムゾーンが不正です",
+		})
+		return
+	}
+	from, err := time.ParseInLocation("2006-01-02", c.Query("from"), loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "fromはYYYY-MM-DD形式で指定してください",
+		})
+		return
+	}
+	to, err := time.ParseInLocation("2006-01-02", c.Query("to"), loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "toはYYYY-MM-DD形式で指定してください",
+		})
+		return
+	}
+
+	schedule, err := gc.groupService.ListEventOccurrences(c.Request.Context(), groupID, user.ID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		gc.handleEventError(c, "list group events", err, groupID, user.ID)
+		return
+	}
+
+	if format == "ics" {
+		c.Header("Cache-Control", "private, no-cache")
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "group-events.ics"}))
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", ical.Calendar(schedule, time.Now()))
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToEventScheduleResponse(schedule))
+}
+
+// GetEventRecurrence 予定の繰り返し取得
+// @Summary      予定の繰り返し取得
+// @Description  予定に設定した繰り返しを取得します（予定の閲覧権限が必要）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventRecurrenceResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "予定または繰り返しが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/recurrence [get]
+func (gc *GroupController) GetEventRecurrence(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	recurrence, err := gc.groupService.GetEventRecurrence(c.Request.Context(), groupID, eventID, userID)
+	if err != nil {
+		gc.handleEventError(c, "get event recurrence", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventRecurrenceResponse(recurrence))
+}
+
+// SetEventRecurrence 予定の繰り返し設定
+// @Summary      予定の繰り返し設定
+// @Description  予定（開始日時のあるグループタスク）を繰り返しの予定にします（予定の作成者またはタスクの編集権限を持つメンバーのみ）。予定の開始日時を最初の回とし、timezoneの同じ時刻で繰り返します
+// @Description  繰り返しの予定の出欠・出席・リマインダーは回ごとに扱い、回は予定の一覧で返す回のID（タスクIDと元の開始日時）で指定します
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.SetEventRecurrenceRequest true "繰り返し"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventRecurrenceResponse "設定成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/recurrence [put]
+func (gc *GroupController) SetEventRecurrence(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetEventRecurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "繰り返しの設定が不正です",
+		})
+		return
+	}
+	if req.Interval == 0 {
+		req.Interval = 1
+	}
+
+	recurrence, err := gc.groupService.SetEventRecurrence(c.Request.Context(), groupID, eventID, userID, groupUsecase.EventRecurrenceInput{
+		Frequency:     domain.RecurrenceFrequency(req.Frequency),
+		Interval:      req.Interval,
+		Weekdays:      req.Weekdays,
+		Count:         req.Count,
+		Until:         req.Until,
+		Timezone:      req.Timezone,
+		HolidayPolicy: domain.RecurrenceHolidayPolicy(req.HolidayPolicy),
+	})
+	if err != nil {
+		gc.handleEventError(c, "set event recurrence", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventRecurrenceResponse(recurrence))
+}
+
+// DeleteEventRecurrence 予定の繰り返し削除
+// @Summary      予定の繰り返し削除
+// @Description  予定の繰り返しと回ごとの変更を削除し、1回だけの予定に戻します（予定の作成者またはタスクの編集権限を持つメンバーのみ）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} SuccessResponse "削除成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "予定または繰り返しが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/recurrence [delete]
+func (gc *GroupController) DeleteEventRecurrence(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := gc.groupService.DeleteEventRecurrence(c.Request.Context(), groupID, eventID, userID); err != nil {
+		gc.handleEventError(c, "delete event recurrence", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "予定の繰り返しを削除しました",
+	})
+}
+
+// ChangeEventOccurrence 繰り返しの予定の回の変更
+// @Summary      繰り返しの予定の回の変更
+// @Description  繰り返しの予定の1回分を中止するか、開始日時を変更します（予定の作成者またはタスクの編集権限を持つメンバーのみ、その回の開始前まで）。日時を変更した回の出欠は引き継ぎます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "回のID" example:"123e4567-e89b-12d3-a456-426614174000_20240108T100000Z"
+// @Param        request body dto.ChangeOccurrenceRequest true "回の変更"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventExceptionResponse "変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "回が見つからない"
+// @Failure      409 {object} ErrorResponse "回が開始済み"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/override [put]
+func (gc *GroupController) ChangeEventOccurrence(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangeOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	exception, err := gc.groupService.ChangeOccurrence(c.Request.Context(), groupID, eventID, userID, groupUsecase.OccurrenceChangeInput{
+		Cancelled: req.Cancelled,
+		StartsAt:  req.StartsAt,
+	})
+	if err != nil {
+		gc.handleEventError(c, "change event occurrence", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventExceptionResponse(exception))
+}
+
+// RestoreEventOccurrence 繰り返しの予定の回の変更の取り消し
+// @Summary      繰り返しの予定の回の変更の取り消し
+// @Description  繰り返しの予定の1回分の中止・日時の変更を取り消し、元の日時に戻します（予定の作成者またはタスクの編集権限を持つメンバーのみ）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "回のID" example:"123e4567-e89b-12d3-a456-426614174000_20240108T100000Z"
+// @Security     BearerAuth
+// @Success      200 {object} SuccessResponse "取り消し成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "回が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/override [delete]
+func (gc *GroupController) RestoreEventOccurrence(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := gc.groupService.RestoreOccurrence(c.Request.Context(), groupID, eventID, userID); err != nil {
+		gc.handleEventError(c, "restore event occurrence", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "予定の回を元に戻しました",
+	})
+}
+
+// CreateEventNote 予定の議事録作成
+// @Summary      予定の議事録作成
+// @Description  予定に議事録（Markdown）を付けます（タスクの作成権限が必要）。繰り返しの予定は回のIDで指定します
+// @Description  本文の未完了のタスクリストの行（"- [ ] 内容"）ごとにグループタスクを作成し、行で@usernameでメンションした最初のメンバーに割り当てます（メンバーがいない場合は担当者なし）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）または回のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.EventNoteRequest true "議事録"
+// @Security     BearerAuth
+// @Success      201 {object} dto.EventNoteResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/notes [post]
+func (gc *GroupController) CreateEventNote(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EventNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "議事録の内容が不正です",
+		})
+		return
+	}
+
+	note, err := gc.groupService.CreateEventNote(c.Request.Context(), groupID, eventID, userID, groupUsecase.EventNoteInput{
+		Title:   req.Title,
+		Content: req.Content,
+	})
+	if err != nil {
+		gc.handleEventError(c, "create event note", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToEventNoteResponse(note))
+}
+
+// ListEventNotes 予定の議事録一覧
+// @Summary      予定の議事録一覧
+// @Description  予定の議事録を作成日時の古い順に取得します（予定の閲覧権限が必要）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）または回のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {array} dto.EventNoteResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/notes [get]
+func (gc *GroupController) ListEventNotes(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	notes, err := gc.groupService.ListEventNotes(c.Request.Context(), groupID, eventID, userID)
+	if err != nil {
+		gc.handleEventError(c, "list event notes", err, groupID, userID)
+		return
+	}
+
+	responses := make([]*dto.EventNoteResponse, len(notes))
+	for i, note := range notes {
+		responses[i] = dto.ToEventNoteResponse(note)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetEventNote 議事録取得
+// @Summary      議事録取得
+// @Description  議事録と、アクションアイテムから作成したタスクを取得します（予定の閲覧権限が必要）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        noteId path string true "議事録ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventNoteResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "議事録が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/notes/{noteId} [get]
+func (gc *GroupController) GetEventNote(c *gin.Context) {
+	userID, groupID, noteID, ok := gc.noteRequest(c)
+	if !ok {
+		return
+	}
+
+	note, err := gc.groupService.GetEventNote(c.Request.Context(), groupID, noteID, userID)
+	if err != nil {
+		gc.handleEventError(c, "get event note", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventNoteResponse(note))
+}
+
+// UpdateEventNote 議事録更新
+// @Summary      議事録更新
+// @Description  議事録のタイトルと本文を更新します（議事録の作成者またはタスクの編集権限を持つメンバーのみ）
+// @Description  追加した未完了のタスクリストの行からタスクを作成します。本文から消した行のタスクは削除しません
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        noteId path string true "議事録ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.EventNoteRequest true "議事録"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventNoteResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "議事録が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/notes/{noteId} [put]
+func (gc *GroupController) UpdateEventNote(c *gin.Context) {
+	userID, groupID, noteID, ok := gc.noteRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EventNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "議事録の内容が不正です",
+		})
+		return
+	}
+
+	note, err := gc.groupService.UpdateEventNote(c.Request.Context(), groupID, noteID, userID, groupUsecase.EventNoteInput{
+		Title:   req.Title,
+		Content: req.Content,
+	})
+	if err != nil {
+		gc.handleEventError(c, "update event note", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventNoteResponse(note))
+}
+
+// DeleteEventNote 議事録削除
+// @Summary      議事録削除
+// @Description  議事録を削除します（議事録の作成者またはタスクの編集権限を持つメンバーのみ）。議事録から作成したタスクは削除しません
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        noteId path string true "議事録ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} SuccessResponse "削除成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "議事録が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/notes/{noteId} [delete]
+func (gc *GroupController) DeleteEventNote(c *gin.Context) {
+	userID, groupID, noteID, ok := gc.noteRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := gc.groupService.DeleteEventNote(c.Request.Context(), groupID, noteID, userID); err != nil {
+		gc.handleEventError(c, "delete event note", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "議事録を削除しました",
+	})
+}
+
+// GetTaskSourceNote タスクの元の議事録取得
+// @Summary      タスクの元の議事録取得
+// @Description  議事録のアクションアイテムから作成したグループタスクの、元の議事録を取得します（予定の閲覧権限が必要）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        taskId path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventNoteResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "議事録から作成したタスクでない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/tasks/{taskId}/note [get]
+func (gc *GroupController) GetTaskSourceNote(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+	taskID, err := gc.validateUUID(c.Param("taskId"), "task ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_TASK_ID",
+			Message: "タスクIDが不正です",
+		})
+		return
+	}
+
+	note, err := gc.groupService.GetTaskSourceNote(c.Request.Context(), groupID, taskID.String(), user.ID)
+	if err != nil {
+		gc.handleEventError(c, "get task source note", err, groupID, user.ID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventNoteResponse(note))
+}
+
+// GetGroupEvent 予定の詳細取得
+// @Summary      予定の詳細取得
+// @Description  予定と、関連付けたタスク（予定を作業時間とするタスク・予定から作成したフォローアップのタスク）を取得します（予定の閲覧権限が必要）。繰り返しの予定は回のIDで指定します
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）または回のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} dto.EventDetailResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "IDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへのアクセス権限なし"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId} [get]
+func (gc *GroupController) GetGroupEvent(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	detail, err := gc.groupService.GetEventDetail(c.Request.Context(), groupID, eventID, userID)
+	if err != nil {
+		gc.handleEventError(c, "get event detail", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEventDetailResponse(detail))
+}
+
+// ScheduleTaskBlock タスクの作業時間の予定作成
+// @Summary      タスクの作業時間の予定作成
+// @Description  グループタスクの作業時間として予定を作成し、タスクと関連付けます（予定共有グループ、タスクの作成権限が必要）
+// @Description  予定は作成したメンバーに割り当てます。長さを省略した場合はタスクの見積もり時間とします（見積もりがない場合は必須）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        taskId path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.ScheduleTaskBlockRequest true "開始日時と長さ"
+// @Security     BearerAuth
+// @Success      201 {object} dto.EventDetailResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/tasks/{taskId}/schedule-block [post]
+func (gc *GroupController) ScheduleTaskBlock(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+	taskID, err := gc.validateUUID(c.Param("taskId"), "task ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_TASK_ID",
+			Message: "タスクIDが不正です",
+		})
+		return
+	}
+
+	var req dto.ScheduleTaskBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "開始日時または長さが不正です",
+		})
+		return
+	}
+
+	detail, err := gc.groupService.ScheduleTaskBlock(c.Request.Context(), groupID, taskID.String(), user.ID, groupUsecase.TaskBlockInput{
+		StartsAt:        req.StartsAt,
+		DurationMinutes: req.DurationMinutes,
+	})
+	if err != nil {
+		gc.handleEventError(c, "schedule task block", err, groupID, user.ID)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToEventDetailResponse(detail))
+}
+
+// CreateFollowUpTask 予定のフォローアップのタスク作成
+// @Summary      予定のフォローアップのタスク作成
+// @Description  予定からフォローアップのグループタスクを作成し、予定と関連付けます（タスクの作成権限が必要）。繰り返しの予定は回のIDで指定します
+// @Description  タイトルを省略した場合は「予定のタイトル」のフォローアップ、担当者を省略した場合は作成したメンバーとし、説明には予定へのリンクを追記します
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        eventId path string true "予定（タスク）または回のID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body dto.CreateFollowUpTaskRequest true "フォローアップのタスク"
+// @Security     BearerAuth
+// @Success      201 {object} dto.EventTaskLinkResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "予定が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/events/{eventId}/follow-ups [post]
+func (gc *GroupController) CreateFollowUpTask(c *gin.Context) {
+	userID, groupID, eventID, ok := gc.eventRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateFollowUpTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "フォローアップのタスクの内容が不正です",
+		})
+		return
+	}
+
+	link, err := gc.groupService.CreateFollowUpTask(c.Request.Context(), groupID, eventID, userID, groupUsecase.FollowUpTaskInput{
+		Title:       req.Title,
+		Description: req.Description,
+		AssigneeID:  req.AssigneeID,
+	})
+	if err != nil {
+		gc.handleEventError(c, "create follow-up task", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToEventTaskLinkResponse(link))
+}
+
+// ListChatIntegrations グループのチャット連携一覧取得
+// @Summary      グループのチャット連携一覧取得
+// @Description  グループのチャット連携（Discord・Teams）の設定を取得します（グループ設定の編集権限が必要）。WebhookのURLは末尾のトークンを伏せて返します
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {array} dto.ChatIntegrationResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "グループIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/integrations [get]
+func (gc *GroupController) ListChatIntegrations(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return
+	}
+
+	integrations, err := gc.groupService.ListChatIntegrations(c.Request.Context(), groupID, user.ID)
+	if err != nil {
+		gc.handleChatIntegrationError(c, "list chat integrations", err, groupID, user.ID)
+		return
+	}
+
+	responses := make([]*dto.ChatIntegrationResponse, 0, len(integrations))
+	for _, integration := range integrations {
+		responses = append(responses, dto.ToChatIntegrationResponse(integration))
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// SaveChatIntegration グループのチャット連携の設定
+// @Summary      グループのチャット連携の設定
+// @Description  グループのタスクの完了・担当者の割り当て・期限のリマインダー・1日のまとめをチャットのチャンネル（Discord・Teams）に投稿する連携を設定します（グループ設定の編集権限が必要）。省略した項目は変更しません
+// @Description  新しく連携する場合はWebhookのURLが必須です。Teamsには見出しと項目を添えたAdaptive Cardで投稿します。テンプレートはGoのtext/template形式で、{{.group_name}} {{.task_title}} {{.assignees}} {{.due_date}}（1日のまとめは {{.date}} {{.completed}} {{.due_today}} {{.overdue}} と各件数）を使えます
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        platform path string true "連携先" Enums(discord, teams)
+// @Param        request body dto.SaveChatIntegrationRequest true "連携の設定"
+// @Security     BearerAuth
+// @Success      200 {object} dto.ChatIntegrationResponse "設定成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/integrations/{platform} [put]
+func (gc *GroupController) SaveChatIntegration(c *gin.Context) {
+	userID, groupID, platform, ok := gc.chatIntegrationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SaveChatIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		gc.logError("bind JSON", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "リクエストボディが不正です",
+		})
+		return
+	}
+
+	input := groupUsecase.ChatIntegrationInput{
+		WebhookURL:       req.WebhookURL,
+		SummaryHour:      req.SummaryHour,
+		Timezone:         req.Timezone,
+		Enabled:          req.Enabled,
+		DueReminderHours: req.DueReminderHours,
+	}
+	if req.Events != nil {
+		input.Events = make([]domain.ChatEvent, 0, len(req.Events))
+		for _, event := range req.Events {
+			input.Events = append(input.Events, domain.ChatEvent(event))
+		}
+	}
+	if req.Templates != nil {
+		input.Templates = make(map[domain.ChatEvent]string, len(req.Templates))
+		for event, text := range req.Templates {
+			input.Templates[domain.ChatEvent(event)] = text
+		}
+	}
+
+	integration, err := gc.groupService.SaveChatIntegration(c.Request.Context(), groupID, platform, userID, input)
+	if err != nil {
+		gc.handleChatIntegrationError(c, "save chat integration", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToChatIntegrationResponse(integration))
+}
+
+// DeleteChatIntegration グループのチャット連携の解除
+// @Summary      グループのチャット連携の解除
+// @Description  グループのチャット連携を解除します（グループ設定の編集権限が必要）
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        platform path string true "連携先" Enums(discord, teams)
+// @Security     BearerAuth
+// @Success      200 {object} SuccessResponse "解除成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "連携が設定されていない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/integrations/{platform} [delete]
+func (gc *GroupController) DeleteChatIntegration(c *gin.Context) {
+	userID, groupID, platform, ok := gc.chatIntegrationRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := gc.groupService.DeleteChatIntegration(c.Request.Context(), groupID, platform, userID); err != nil {
+		gc.handleChatIntegrationError(c, "delete chat integration", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "チャット連携を解除しました",
+	})
+}
+
+// TestChatIntegration グループのチャット連携のテスト
+// @Summary      グループのチャット連携のテスト
+// @Description  グループのチャット連携にテストのメッセージを投稿します（グループ設定の編集権限が必要）。無効にした連携にも投稿します
+// @Tags         groups
+// @Produce      json
+// @Param        groupId path string true "グループID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        platform path string true "連携先" Enums(discord, teams)
+// @Security     BearerAuth
+// @Success      200 {object} SuccessResponse "投稿成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限不足"
+// @Failure      404 {object} ErrorResponse "連携が設定されていない"
+// @Failure      502 {object} ErrorResponse "連携先への投稿に失敗"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/integrations/{platform}/test [post]
+func (gc *GroupController) TestChatIntegration(c *gin.Context) {
+	userID, groupID, platform, ok := gc.chatIntegrationRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := gc.groupService.TestChatIntegration(c.Request.Context(), groupID, platform, userID); err != nil {
+		gc.handleChatIntegrationError(c, "test chat integration", err, groupID, userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "テストのメッセージを投稿しました",
+	})
+}
+
+// chatIntegrationRequest はチャット連携のリクエストからログイン中のユーザーID・グループID・連携先を取得する（取得できない場合はエラーを返す）
+func (gc *GroupController) chatIntegrationRequest(c *gin.Context) (uuid.UUID, uuid.UUID, domain.ChatPlatform, bool) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	platform, err := domain.ParseChatPlatform(c.Param("platform"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_PLATFORM",
+			Message: "連携先が不正です",
+		})
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	return user.ID, groupID, platform, true
+}
+
+// handleChatIntegrationError はチャット連携の操作のエラーをレスポンスに変換する
+func (gc *GroupController) handleChatIntegrationError(c *gin.Context, operation string, err error, groupID, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, groupUsecase.ErrInvalidChatIntegration):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_INTEGRATION",
+			Message: "連携の設定が不正です",
+		})
+	case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "FORBIDDEN",
+			Message: "チャット連携を設定する権限がありません",
+		})
+	case errors.Is(err, groupUsecase.ErrChatIntegrationNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "INTEGRATION_NOT_FOUND",
+			Message: "チャット連携が設定されていません",
+		})
+	case errors.Is(err, groupUsecase.ErrChatDeliveryFailed), errors.Is(err, groupUsecase.ErrUnsupportedChatPlatform):
+		gc.logError(operation, err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", userID))
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "DELIVERY_FAILED",
+			Message: "連携先への投稿に失敗しました。WebhookのURLを確認してください",
+		})
+	default:
+		gc.logError(operation, err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", userID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "チャット連携の操作に失敗しました",
+		})
+	}
+}
+
+// eventRequest は予定のリクエストからログイン中のユーザーID・グループID・予定のIDを取得する（取得できない場合はエラーを返す）
+func (gc *GroupController) eventRequest(c *gin.Context) (uuid.UUID, uuid.UUID, string, bool) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	// 繰り返しの予定の回のIDはタスクIDと元の開始日時から作る
+	eventID := c.Param("eventId")
+	seriesID, _, err := domain.ParseOccurrenceID(eventID)
+	if err == nil {
+		_, err = gc.validateUUID(seriesID, "event ID")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_EVENT_ID",
+			Message: "予定のIDが不正です",
+		})
+		return uuid.Nil, uuid.Nil, "", false
+	}
+
+	return user.ID, groupID, eventID, true
+}
+
+// noteRequest は議事録のリクエストからログイン中のユーザーID・グループID・議事録IDを取得する（取得できない場合はエラーを返す）
+func (gc *GroupController) noteRequest(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		gc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "認証が必要です",
+		})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	groupID, err := gc.validateUUID(c.Param("groupId"), "group ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_GROUP_ID",
+			Message: "グループIDが不正です",
+		})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	noteID, err := gc.validateUUID(c.Param("noteId"), "note ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_NOTE_ID",
+			Message: "議事録IDが不正です",
+		})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return user.ID, groupID, noteID, true
+}
+
+// handleEventError は予定の出欠・繰り返し・議事録・タスクとの関連付けの操作のエラーをレスポンスに変換する
+func (gc *GroupController) handleEventError(c *gin.Context, operation string, err error, groupID, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, groupUsecase.ErrInvalidRSVP):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "出欠の内容が不正です",
+		})
+	case errors.Is(err, groupUsecase.ErrInvalidRecurrence):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_RECURRENCE",
+			Message: "繰り返しの設定または回の変更が不正です",
+		})
+	case errors.Is(err, groupUsecase.ErrInvalidNote):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_NOTE",
+			Message: "議事録の内容が不正です",
+		})
+	case errors.Is(err, groupUsecase.ErrInvalidEventLink):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_EVENT_LINK",
+			Message: "予定またはタスクの作成内容が不正です",
+		})
+	case errors.Is(err, groupUsecase.ErrInvalidEventRange):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: "期間はtoをfrom以降、92日以内で指定してください",
+		})
+	case errors.Is(err, groupUsecase.ErrInsufficientPermissions):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "FORBIDDEN",
+			Message: "この予定を操作する権限がありません",
+		})
+	case errors.Is(err, groupUsecase.ErrEventNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "EVENT_NOT_FOUND",
+			Message: "予定が見つかりません",
+		})
+	case errors.Is(err, groupUsecase.ErrNoteNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "NOTE_NOT_FOUND",
+			Message: "議事録が見つかりません",
+		})
+	case errors.Is(err, groupUsecase.ErrLinkedTaskNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "TASK_NOT_FOUND",
+			Message: "グループのタスクが見つかりません",
+		})
+	case errors.Is(err, groupUsecase.ErrRSVPClosed):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "RSVP_CLOSED",
+			Message: "開始済みの予定には出欠を回答できません",
+		})
+	case errors.Is(err, groupUsecase.ErrRecurrenceNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "RECURRENCE_NOT_FOUND",
+			Message: "予定に繰り返しが設定されていません",
+		})
+	case errors.Is(err, groupUsecase.ErrEventStarted):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "EVENT_STARTED",
+			Message: "開始済みの回は変更できません",
+		})
+	case errors.Is(err, groupUsecase.ErrEventNotStarted):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "EVENT_NOT_STARTED",
+			Message: "開始前の予定には出席を記録できません",
+		})
+	default:
+		gc.logError(operation, err,
+			logger.Any("groupID", groupID),
+			logger.Any("userID", userID))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "予定の操作に失敗しました",
+		})
+	}
+}
+
+// RegisterGroupRoutes はグループ関連のルートを登録する
+func RegisterGroupRoutes(router *gin.RouterGroup, controller *GroupController) {
+	groups := router.Group("/groups")
+	{
+		// グループ基本操作
+		groups.POST("", controller.CreateGroup)
+		groups.GET("/my", controller.ListMyGroups)
+		groups.GET("/search", controller.SearchGroups)
+		groups.GET("/:groupId", controller.GetGroup)
+		groups.PUT("/:groupId", controller.UpdateGroup)
+		groups.DELETE("/:groupId", controller.DeleteGroup)
+		groups.GET("/:groupId/tree", controller.GetGroupTree)
+		groups.GET("/:groupId/permissions", controller.GetGroupPermissions)
+		groups.PUT("/:groupId/permissions", controller.UpdateGroupPermissions)
+
+		// カスタムロール
+		groups.GET("/:groupId/roles", controller.ListCustomRoles)
+		groups.POST("/:groupId/roles", controller.CreateCustomRole)
+		groups.PUT("/:groupId/roles/:roleId", controller.UpdateCustomRole)
+		groups.DELETE("/:groupId/roles/:roleId", controller.DeleteCustomRole)
+
+		// タスクの自動割り当て
+		groups.GET("/:groupId/assignment-policy", controller.GetAssignmentPolicy)
+		groups.PUT("/:groupId/assignment-policy", controller.UpdateAssignmentPolicy)
+
+		// リーダーボード
+		groups.GET("/:groupId/leaderboard", controller.GetLeaderboard)
+		groups.GET("/:groupId/leaderboard/settings", controller.GetLeaderboardSettings)
+		groups.PUT("/:groupId/leaderboard/settings", controller.UpdateLeaderboardSettings)
+		groups.PUT("/:groupId/leaderboard/visibility", controller.UpdateLeaderboardVisibility)
+
+		// スケジュールカード
+		groups.GET("/:groupId/schedule-card/settings", controller.GetScheduleCardSettings)
+		groups.PUT("/:groupId/schedule-card/settings", controller.UpdateScheduleCardSettings)
+		groups.POST("/:groupId/schedule-card/link", controller.CreateScheduleCardLink)
+
+		// 予定の出欠
+		groups.PUT("/:groupId/events/:eventId/rsvp", controller.SetEventRSVP)
+		groups.GET("/:groupId/events/:eventId/attendees", controller.GetEventAttendees)
+		groups.PUT("/:groupId/events/:eventId/attendance", controller.RecordEventAttendance)
+
+		// 予定の繰り返し
+		groups.GET("/:groupId/events", controller.ListGroupEvents)
+		groups.GET("/:groupId/events/:eventId/recurrence", controller.GetEventRecurrence)
+		groups.PUT("/:groupId/events/:eventId/recurrence", controller.SetEventRecurrence)
+		groups.DELETE("/:groupId/events/:eventId/recurrence", controller.DeleteEventRecurrence)
+		groups.PUT("/:groupId/events/:eventId/override", controller.ChangeEventOccurrence)
+		groups.DELETE("/:groupId/events/:eventId/override", controller.RestoreEventOccurrence)
+
+		// 予定の議事録
+		groups.POST("/:groupId/events/:eventId/notes", controller.CreateEventNote)
+		groups.GET("/:groupId/events/:eventId/notes", controller.ListEventNotes)
+		groups.GET("/:groupId/notes/:noteId", controller.GetEventNote)
+		groups.PUT("/:groupId/notes/:noteId", controller.UpdateEventNote)
+		groups.DELETE("/:groupId/notes/:noteId", controller.DeleteEventNote)
+		groups.GET("/:groupId/tasks/:taskId/note", controller.GetTaskSourceNote)
+
+		// 予定とタスクの関連付け
+		groups.GET("/:groupId/events/:eventId", controller.GetGroupEvent)
+		groups.POST("/:groupId/tasks/:taskId/schedule-block", controller.ScheduleTaskBlock)
+		groups.POST("/:groupId/events/:eventId/follow-ups", controller.CreateFollowUpTask)
+
+		// チャット連携
+		groups.GET("/:groupId/integrations", controller.ListChatIntegrations)
+		groups.PUT("/:groupId/integrations/:platform", controller.SaveChatIntegration)
+		groups.DELETE("/:groupId/integrations/:platform", controller.DeleteChatIntegration)
+		groups.POST("/:groupId/integrations/:platform/test", controller.TestChatIntegration)
+
+		// メンバー管理
+		groups.POST("/:groupId/members", controller.AddMember)
+		groups.POST("/:groupId/members/bulk", controller.BulkAddMembers)
+		groups.DELETE("/:groupId/members/:userId", controller.RemoveMember)
+		groups.PUT("/:groupId/members/:userId/role", controller.UpdateMemberRole)
+		groups.GET("/:groupId/members", controller.ListMembers)
+
+		// 統計情報
+		groups.GET("/:groupId/stats", controller.GetGroupStats)
+	}
+}
+
+// RegisterPublicGroupRoutes は認証不要のグループ関連のルートを登録する（署名付きリンクで検証する）
+func RegisterPublicGroupRoutes(router *gin.RouterGroup, controller *GroupController) {
+	groups := router.Group("/public/groups")
+	{
+		groups.GET("/:groupId/schedule-card", controller.GetPublicScheduleCard)
 	}
 }
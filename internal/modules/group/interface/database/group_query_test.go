@@ -0,0 +1,89 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+)
+
+func TestBuildSearchGroupsQuery(t *testing.T) {
+	project := domain.GroupTypeProject
+	pagination := commonDomain.Pagination{Page: 3, PageSize: 20}
+
+	tests := []struct {
+		name      string
+		query     string
+		groupType *domain.GroupType
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "query only",
+			query:     "team",
+			wantWhere: " WHERE (g.name LIKE ? OR g.description LIKE ?)",
+			wantArgs:  []interface{}{"%team%", "%team%"},
+		},
+		{
+			name:      "query and type",
+			query:     "team",
+			groupType: &project,
+			wantWhere: " WHERE (g.name LIKE ? OR g.description LIKE ?) AND g.type = ?",
+			wantArgs:  []interface{}{"%team%", "%team%", "PROJECT"},
+		},
+		{
+			name:      "type only",
+			query:     "  ",
+			groupType: &project,
+			wantWhere: " WHERE g.type = ?",
+			wantArgs:  []interface{}{"PROJECT"},
+		},
+		{
+			name:      "neither",
+			wantWhere: "",
+			wantArgs:  nil,
+		},
+		{
+			name:      "wildcards are escaped",
+			query:     "100%_done",
+			wantWhere: " WHERE (g.name LIKE ? OR g.description LIKE ?)",
+			wantArgs:  []interface{}{`%100\%\_done%`, `%100\%\_done%`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := buildSearchGroupsQuery(tt.query, tt.groupType, pagination)
+
+			countQuery, countArgs, err := builder.Count("groups g")
+			require.NoError(t, err)
+			assert.Equal(t, "SELECT COUNT(*) FROM groups g"+tt.wantWhere, countQuery)
+			assert.Equal(t, tt.wantArgs, nilIfEmpty(countArgs))
+
+			query, args, err := builder.Select(groupSearchColumns, "groups g")
+			require.NoError(t, err)
+			assert.Equal(t, "SELECT "+groupSearchColumns+" FROM groups g"+tt.wantWhere+" ORDER BY g.created_at DESC LIMIT ? OFFSET ?", query)
+			assert.Equal(t, append(append([]interface{}{}, tt.wantArgs...), 20, 40), args)
+			assert.Equal(t, strings.Count(query, "?"), len(args))
+		})
+	}
+
+	t.Run("rejects invalid pagination", func(t *testing.T) {
+		_, _, err := buildSearchGroupsQuery("team", nil, commonDomain.Pagination{Page: 0, PageSize: 20}).Count("groups g")
+
+		assert.ErrorIs(t, err, sqlquery.ErrInvalidPagination)
+	})
+}
+
+// nilIfEmpty は空の引数をnilとして比較できるようにする
+func nilIfEmpty(args []interface{}) []interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
@@ -3,11 +3,14 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
 	"github.com/hryt430/Yotei+/internal/modules/group/domain"
 	groupUsecase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
 	"github.com/hryt430/Yotei+/pkg/logger"
@@ -15,33 +18,44 @@ import (
 
 type GroupRepository struct {
 	db     *sql.DB
+	stmts  *sqlquery.StmtCache
 	logger logger.Logger
 }
 
 func NewGroupRepository(db *sql.DB, logger logger.Logger) groupUsecase.GroupRepository {
 	return &GroupRepository{
 		db:     db,
+		stmts:  sqlquery.NewStmtCache(db, sqlquery.DefaultStmtCacheSize),
 		logger: logger,
 	}
 }
 
+// groupSearchColumns はグループ検索で選択するカラム（scanGroupsの順序と一致させる）
+const groupSearchColumns = "g.id, g.name, g.description, g.type, g.owner_id, g.settings, g.member_count, g.created_at, g.updated_at, g.version"
+
 // CreateGroup はグループを作成する
 func (r *GroupRepository) CreateGroup(ctx context.Context, group *domain.Group) error {
 	query := `
 		INSERT INTO groups (
-			id, name, description, type, owner_id, member_count, 
+			id, name, description, type, owner_id, parent_group_id, member_count, 
 			is_public, allow_member_invite, require_approval, enable_notifications,
 			default_privacy_level, allow_schedule_details, enable_gantt_chart, enable_task_dependency,
 			created_at, updated_at, version
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	var parentGroupID sql.NullString
+	if group.ParentGroupID != nil {
+		parentGroupID = sql.NullString{String: group.ParentGroupID.String(), Valid: true}
+	}
+
 	_, err := r.db.ExecContext(ctx, query,
 		group.ID.String(),
 		group.Name,
 		group.Description,
 		string(group.Type),
 		group.OwnerID.String(),
+		parentGroupID,
 		group.MemberCount,
 		group.Settings.IsPublic,
 		group.Settings.AllowMemberInvite,
@@ -57,7 +71,7 @@ func (r *GroupRepository) CreateGroup(ctx context.Context, group *domain.Group)
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to create group", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to create group", logger.Error(err))
 		return fmt.Errorf("failed to create group: %w", err)
 	}
 
@@ -69,64 +83,21 @@ func (r *GroupRepository) CreateGroup(ctx context.Context, group *domain.Group)
 // GetGroupByID はIDでグループを取得する
 func (r *GroupRepository) GetGroupByID(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
 	query := `
-		SELECT id, name, description, type, owner_id, member_count,
-			   is_public, allow_member_invite, require_approval, enable_notifications,
-			   default_privacy_level, allow_schedule_details, enable_gantt_chart, enable_task_dependency,
-			   created_at, updated_at, version
+		SELECT ` + groupColumns + `
 		FROM groups
 		WHERE id = ?
 	`
 
-	var group domain.Group
-	var idStr, ownerIDStr string
-	var defaultPrivacyLevel, allowScheduleDetails, enableGanttChart, enableTaskDependency sql.NullString
-
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(
-		&idStr,
-		&group.Name,
-		&group.Description,
-		(*string)(&group.Type),
-		&ownerIDStr,
-		&group.MemberCount,
-		&group.Settings.IsPublic,
-		&group.Settings.AllowMemberInvite,
-		&group.Settings.RequireApproval,
-		&group.Settings.EnableNotifications,
-		&defaultPrivacyLevel,
-		&allowScheduleDetails,
-		&enableGanttChart,
-		&enableTaskDependency,
-		&group.CreatedAt,
-		&group.UpdatedAt,
-		&group.Version,
-	)
-
+	group, err := r.scanGroup(r.db.QueryRowContext(ctx, query, id.String()))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get group by ID", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get group by ID", logger.Error(err))
 		return nil, fmt.Errorf("failed to get group: %w", err)
 	}
 
-	group.ID, _ = uuid.Parse(idStr)
-	group.OwnerID, _ = uuid.Parse(ownerIDStr)
-
-	// Optional fieldsの処理
-	if defaultPrivacyLevel.Valid {
-		group.Settings.DefaultPrivacyLevel = domain.PrivacyLevel(defaultPrivacyLevel.String)
-	}
-	if allowScheduleDetails.Valid {
-		group.Settings.AllowScheduleDetails = allowScheduleDetails.String == "1"
-	}
-	if enableGanttChart.Valid {
-		group.Settings.EnableGanttChart = enableGanttChart.String == "1"
-	}
-	if enableTaskDependency.Valid {
-		group.Settings.EnableTaskDependency = enableTaskDependency.String == "1"
-	}
-
-	return &group, nil
+	return group, nil
 }
 
 // UpdateGroup はグループを更新する
@@ -161,7 +132,7 @@ func (r *GroupRepository) UpdateGroup(ctx context.Context, group *domain.Group)
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to update group", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to update group", logger.Error(err))
 		return fmt.Errorf("failed to update group: %w", err)
 	}
 
@@ -188,14 +159,14 @@ func (r *GroupRepository) DeleteGroup(ctx context.Context, id uuid.UUID) error {
 	// メンバーを削除
 	_, err = tx.ExecContext(ctx, "DELETE FROM group_members WHERE group_id = ?", id.String())
 	if err != nil {
-		r.logger.Error("Failed to delete group members", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to delete group members", logger.Error(err))
 		return fmt.Errorf("failed to delete group members: %w", err)
 	}
 
 	// グループを削除
 	_, err = tx.ExecContext(ctx, "DELETE FROM groups WHERE id = ?", id.String())
 	if err != nil {
-		r.logger.Error("Failed to delete group", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to delete group", logger.Error(err))
 		return fmt.Errorf("failed to delete group: %w", err)
 	}
 
@@ -209,7 +180,7 @@ func (r *GroupRepository) ListGroupsByOwner(ctx context.Context, ownerID uuid.UU
 	var total int
 	err := r.db.QueryRowContext(ctx, countQuery, ownerID.String()).Scan(&total)
 	if err != nil {
-		r.logger.Error("Failed to count groups by owner", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to count groups by owner", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to count groups: %w", err)
 	}
 
@@ -225,7 +196,7 @@ func (r *GroupRepository) ListGroupsByOwner(ctx context.Context, ownerID uuid.UU
 
 	rows, err := r.db.QueryContext(ctx, query, ownerID.String(), pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to list groups by owner", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to list groups by owner", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to list groups: %w", err)
 	}
 	defer rows.Close()
@@ -238,6 +209,35 @@ func (r *GroupRepository) ListGroupsByOwner(ctx context.Context, ownerID uuid.UU
 	return groups, total, nil
 }
 
+// ListChildGroups は直下のサブチームを作成日時の古い順に取得する
+func (r *GroupRepository) ListChildGroups(ctx context.Context, parentID uuid.UUID) ([]*domain.Group, error) {
+	query := `
+		SELECT ` + groupColumns + `
+		FROM groups
+		WHERE parent_group_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, parentID.String())
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list child groups", logger.Error(err))
+		return nil, fmt.Errorf("failed to list child groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*domain.Group
+	for rows.Next() {
+		group, err := r.scanGroup(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan child group", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan child group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
 // ListGroupsByMember はメンバーでグループを検索する
 func (r *GroupRepository) ListGroupsByMember(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Group, int, error) {
 	// 総数を取得
@@ -250,7 +250,7 @@ func (r *GroupRepository) ListGroupsByMember(ctx context.Context, userID uuid.UU
 	var total int
 	err := r.db.QueryRowContext(ctx, countQuery, userID.String()).Scan(&total)
 	if err != nil {
-		r.logger.Error("Failed to count groups by member", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to count groups by member", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to count groups: %w", err)
 	}
 
@@ -267,7 +267,7 @@ func (r *GroupRepository) ListGroupsByMember(ctx context.Context, userID uuid.UU
 
 	rows, err := r.db.QueryContext(ctx, query, userID.String(), pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to list groups by member", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to list groups by member", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to list groups: %w", err)
 	}
 	defer rows.Close()
@@ -282,41 +282,29 @@ func (r *GroupRepository) ListGroupsByMember(ctx context.Context, userID uuid.UU
 
 // SearchGroups はグループを検索する
 func (r *GroupRepository) SearchGroups(ctx context.Context, query string, groupType *domain.GroupType, pagination commonDomain.Pagination) ([]*domain.Group, int, error) {
-	// 条件構築
-	conditions := []string{"(g.name LIKE ? OR g.description LIKE ?)"}
-	args := []interface{}{"%" + query + "%", "%" + query + "%"}
-
-	if groupType != nil {
-		conditions = append(conditions, "g.type = ?")
-		args = append(args, string(*groupType))
-	}
-
-	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+	builder := buildSearchGroupsQuery(query, groupType, pagination)
 
 	// 総数を取得
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM groups g %s", whereClause)
+	countQuery, args, err := builder.Count("groups g")
+	if err != nil {
+		return nil, 0, err
+	}
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = r.stmts.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
-		r.logger.Error("Failed to count search results", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to count search results", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
 	}
 
 	// データを取得
-	offset := (pagination.Page - 1) * pagination.PageSize
-	searchQuery := fmt.Sprintf(`
-		SELECT g.id, g.name, g.description, g.type, g.owner_id, g.settings, g.member_count, g.created_at, g.updated_at, g.version
-		FROM groups g
-		%s
-		ORDER BY g.created_at DESC
-		LIMIT ? OFFSET ?
-	`, whereClause)
-
-	args = append(args, pagination.PageSize, offset)
+	searchQuery, args, err := builder.Select(groupSearchColumns, "groups g")
+	if err != nil {
+		return nil, 0, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, searchQuery, args...)
+	rows, err := r.stmts.QueryContext(ctx, searchQuery, args...)
 	if err != nil {
-		r.logger.Error("Failed to search groups", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to search groups", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to search groups: %w", err)
 	}
 	defer rows.Close()
@@ -329,6 +317,21 @@ func (r *GroupRepository) SearchGroups(ctx context.Context, query string, groupT
 	return groups, total, nil
 }
 
+// buildSearchGroupsQuery はグループ検索の条件からクエリを組み立てる
+// 検索語のワイルドカードはエスケープし、空の場合は名前・説明で絞り込まない
+func buildSearchGroupsQuery(query string, groupType *domain.GroupType, pagination commonDomain.Pagination) *sqlquery.Builder {
+	builder := sqlquery.New().
+		WhereLike([]string{"g.name", "g.description"}, strings.TrimSpace(query))
+
+	if groupType != nil {
+		builder.Where("g.type = ?", string(*groupType))
+	}
+
+	return builder.
+		OrderByFixed("g.created_at DESC").
+		Paginate(pagination.Page, pagination.PageSize)
+}
+
 // AddMember はメンバーを追加する
 func (r *GroupRepository) AddMember(ctx context.Context, member *domain.GroupMember) error {
 	query := `
@@ -346,13 +349,75 @@ func (r *GroupRepository) AddMember(ctx context.Context, member *domain.GroupMem
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to add member", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to add member", logger.Error(err))
 		return fmt.Errorf("failed to add member: %w", err)
 	}
 
 	return nil
 }
 
+// AddMembers はメンバーを一括追加し、グループのメンバー数を更新する（1トランザクション）
+func (r *GroupRepository) AddMembers(ctx context.Context, group *domain.Group, members []*domain.GroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholders := make([]string, len(members))
+	args := make([]interface{}, 0, len(members)*6)
+	for i, member := range members {
+		placeholders[i] = "(?, ?, ?, ?, ?, ?)"
+		args = append(args,
+			member.ID.String(),
+			member.GroupID.String(),
+			member.UserID.String(),
+			string(member.Role),
+			member.JoinedAt,
+			member.UpdatedAt,
+		)
+	}
+	query := `
+		INSERT INTO group_members (id, group_id, user_id, role, joined_at, updated_at)
+		VALUES ` + strings.Join(placeholders, ", ")
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to add members", logger.Error(err))
+		return fmt.Errorf("failed to add members: %w", err)
+	}
+
+	// メンバー数は楽観ロックで更新する（UpdateGroupと同じ）
+	result, err := tx.ExecContext(ctx, `
+		UPDATE groups
+		SET member_count = ?, updated_at = ?, version = ?
+		WHERE id = ? AND version = ?
+	`,
+		group.MemberCount,
+		group.UpdatedAt,
+		group.Version,
+		group.ID.String(),
+		group.Version-1,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update group member count", logger.Error(err))
+		return fmt.Errorf("failed to update group member count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("group not found or version conflict")
+	}
+
+	return tx.Commit()
+}
+
 // GetMember はメンバーを取得する
 func (r *GroupRepository) GetMember(ctx context.Context, groupID, userID uuid.UUID) (*domain.GroupMember, error) {
 	query := `
@@ -377,7 +442,7 @@ func (r *GroupRepository) GetMember(ctx context.Context, groupID, userID uuid.UU
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get member", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get member", logger.Error(err))
 		return nil, fmt.Errorf("failed to get member: %w", err)
 	}
 
@@ -404,7 +469,7 @@ func (r *GroupRepository) UpdateMemberRole(ctx context.Context, groupID, userID
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to update member role", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to update member role", logger.Error(err))
 		return fmt.Errorf("failed to update member role: %w", err)
 	}
 
@@ -417,7 +482,7 @@ func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid
 
 	_, err := r.db.ExecContext(ctx, query, groupID.String(), userID.String())
 	if err != nil {
-		r.logger.Error("Failed to remove member", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to remove member", logger.Error(err))
 		return fmt.Errorf("failed to remove member: %w", err)
 	}
 
@@ -437,7 +502,7 @@ func (r *GroupRepository) ListMembers(ctx context.Context, groupID uuid.UUID, pa
 
 	rows, err := r.db.QueryContext(ctx, query, groupID.String(), pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to list members", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to list members", logger.Error(err))
 		return nil, fmt.Errorf("failed to list members: %w", err)
 	}
 	defer rows.Close()
@@ -456,7 +521,7 @@ func (r *GroupRepository) ListMembers(ctx context.Context, groupID uuid.UUID, pa
 			&member.UpdatedAt,
 		)
 		if err != nil {
-			r.logger.Error("Failed to scan member", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan member", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan member: %w", err)
 		}
 
@@ -477,13 +542,52 @@ func (r *GroupRepository) IsMember(ctx context.Context, groupID, userID uuid.UUI
 	var count int
 	err := r.db.QueryRowContext(ctx, query, groupID.String(), userID.String()).Scan(&count)
 	if err != nil {
-		r.logger.Error("Failed to check membership", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to check membership", logger.Error(err))
 		return false, fmt.Errorf("failed to check membership: %w", err)
 	}
 
 	return count > 0, nil
 }
 
+// GetExistingMemberIDs は指定されたユーザーのうち既にグループのメンバーであるユーザーIDを返す
+func (r *GroupRepository) GetExistingMemberIDs(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	result := make(map[uuid.UUID]bool)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, 0, len(userIDs)+1)
+	args = append(args, groupID.String())
+	for i, userID := range userIDs {
+		placeholders[i] = "?"
+		args = append(args, userID.String())
+	}
+	query := `
+		SELECT user_id FROM group_members
+		WHERE group_id = ? AND user_id IN (` + strings.Join(placeholders, ",") + `)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get existing members", logger.Error(err))
+		return nil, fmt.Errorf("failed to get existing members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userIDStr string
+		if err := rows.Scan(&userIDStr); err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		if userID, err := uuid.Parse(userIDStr); err == nil {
+			result[userID] = true
+		}
+	}
+
+	return result, rows.Err()
+}
+
 // GetMemberRole はメンバーの権限を取得する
 func (r *GroupRepository) GetMemberRole(ctx context.Context, groupID, userID uuid.UUID) (domain.MemberRole, error) {
 	query := "SELECT role FROM group_members WHERE group_id = ? AND user_id = ?"
@@ -494,7 +598,7 @@ func (r *GroupRepository) GetMemberRole(ctx context.Context, groupID, userID uui
 		if err == sql.ErrNoRows {
 			return "", fmt.Errorf("member not found")
 		}
-		r.logger.Error("Failed to get member role", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get member role", logger.Error(err))
 		return "", fmt.Errorf("failed to get member role: %w", err)
 	}
 
@@ -508,7 +612,7 @@ func (r *GroupRepository) GetMemberCount(ctx context.Context, groupID uuid.UUID)
 	var count int
 	err := r.db.QueryRowContext(ctx, query, groupID.String()).Scan(&count)
 	if err != nil {
-		r.logger.Error("Failed to get member count", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get member count", logger.Error(err))
 		return 0, fmt.Errorf("failed to get member count: %w", err)
 	}
 
@@ -533,76 +637,1494 @@ func (r *GroupRepository) GetGroupStats(ctx context.Context, groupID uuid.UUID)
 	return stats, nil
 }
 
-// === ヘルパーメソッド ===
+// CountDistinctMembers は複数グループのメンバーを重複を除いて数える
+func (r *GroupRepository) CountDistinctMembers(ctx context.Context, groupIDs []uuid.UUID) (int, error) {
+	if len(groupIDs) == 0 {
+		return 0, nil
+	}
 
-func (r *GroupRepository) scanGroups(rows *sql.Rows) ([]*domain.Group, error) {
-	var groups []*domain.Group
+	placeholders := make([]string, len(groupIDs))
+	args := make([]interface{}, len(groupIDs))
+	for i, groupID := range groupIDs {
+		placeholders[i] = "?"
+		args[i] = groupID.String()
+	}
+	query := fmt.Sprintf(
+		"SELECT COUNT(DISTINCT user_id) FROM group_members WHERE group_id IN (%s)",
+		strings.Join(placeholders, ", "),
+	)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count distinct members", logger.Error(err))
+		return 0, fmt.Errorf("failed to count distinct members: %w", err)
+	}
+	return count, nil
+}
+
+// GetPermissionOverrides はグループで変更した権限を取得する
+func (r *GroupRepository) GetPermissionOverrides(ctx context.Context, groupID uuid.UUID) (domain.PermissionMatrix, error) {
+	query := "SELECT action, roles FROM group_permissions WHERE group_id = ?"
+
+	rows, err := r.db.QueryContext(ctx, query, groupID.String())
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get group permissions", logger.Error(err))
+		return nil, fmt.Errorf("failed to get group permissions: %w", err)
+	}
+	defer rows.Close()
 
+	overrides := make(domain.PermissionMatrix)
 	for rows.Next() {
-		var group domain.Group
-		var settingsJSON string
-		var idStr, ownerIDStr string
+		var action, roles string
+		if err := rows.Scan(&action, &roles); err != nil {
+			return nil, fmt.Errorf("failed to scan group permission: %w", err)
+		}
 
-		err := rows.Scan(
-			&idStr,
-			&group.Name,
-			&group.Description,
-			(*string)(&group.Type),
-			&ownerIDStr,
-			&settingsJSON,
-			&group.MemberCount,
-			&group.CreatedAt,
-			&group.UpdatedAt,
-			&group.Version,
-		)
-		if err != nil {
-			r.logger.Error("Failed to scan group", logger.Error(err))
-			return nil, fmt.Errorf("failed to scan group: %w", err)
+		// rolesはカンマ区切り（空の場合はオーナーのみ）
+		memberRoles := []domain.MemberRole{}
+		for _, role := range strings.Split(roles, ",") {
+			if role != "" {
+				memberRoles = append(memberRoles, domain.MemberRole(role))
+			}
 		}
+		overrides[domain.GroupAction(action)] = memberRoles
+	}
 
-		group.ID, _ = uuid.Parse(idStr)
-		group.OwnerID, _ = uuid.Parse(ownerIDStr)
-		group.Settings = r.decodeGroupSettings(settingsJSON)
+	return overrides, rows.Err()
+}
 
-		groups = append(groups, &group)
+// SavePermissionOverrides はグループで変更した権限を置き換える
+func (r *GroupRepository) SavePermissionOverrides(ctx context.Context, groupID uuid.UUID, overrides domain.PermissionMatrix) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	return groups, nil
+	if _, err := tx.ExecContext(ctx, "DELETE FROM group_permissions WHERE group_id = ?", groupID.String()); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete group permissions", logger.Error(err))
+		return fmt.Errorf("failed to delete group permissions: %w", err)
+	}
+
+	for action, roles := range overrides {
+		roleNames := make([]string, len(roles))
+		for i, role := range roles {
+			roleNames[i] = string(role)
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO group_permissions (group_id, action, roles) VALUES (?, ?, ?)",
+			groupID.String(), string(action), strings.Join(roleNames, ","))
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to save group permission", logger.Error(err))
+			return fmt.Errorf("failed to save group permission: %w", err)
+		}
+	}
+
+	return tx.Commit()
 }
 
-func (r *GroupRepository) encodeGroupSettings(settings domain.GroupSettings) string {
-	// 簡易実装：JSONエンコード（実際の実装では適切なJSONライブラリを使用）
-	return fmt.Sprintf(`{
-		"is_public": %t,
-		"allow_member_invite": %t,
-		"require_approval": %t,
-		"enable_notifications": %t,
-		"default_privacy_level": "%s",
-		"allow_schedule_details": %t,
-		"enable_gantt_chart": %t,
-		"enable_task_dependency": %t
-	}`,
-		settings.IsPublic,
-		settings.AllowMemberInvite,
-		settings.RequireApproval,
-		settings.EnableNotifications,
-		string(settings.DefaultPrivacyLevel),
-		settings.AllowScheduleDetails,
-		settings.EnableGanttChart,
-		settings.EnableTaskDependency,
+// CreateCustomRole はカスタムロールを作成する
+func (r *GroupRepository) CreateCustomRole(ctx context.Context, role *domain.CustomRole) error {
+	query := `
+		INSERT INTO group_roles (id, group_id, name, permissions, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		role.ID.String(),
+		role.GroupID.String(),
+		role.Name,
+		encodeRolePermissions(role.Permissions),
+		role.CreatedAt,
+		role.UpdatedAt,
 	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create custom role", logger.Error(err))
+		return fmt.Errorf("failed to create custom role: %w", err)
+	}
+
+	return nil
 }
 
-func (r *GroupRepository) decodeGroupSettings(settingsJSON string) domain.GroupSettings {
-	// 簡易実装：デフォルト値を返す（実際の実装ではJSONパースを行う）
-	return domain.GroupSettings{
-		IsPublic:             false,
-		AllowMemberInvite:    true,
-		RequireApproval:      true,
-		EnableNotifications:  true,
-		DefaultPrivacyLevel:  domain.PrivacyLevelBusy,
-		AllowScheduleDetails: false,
-		EnableGanttChart:     true,
-		EnableTaskDependency: false,
+// GetCustomRole はグループのカスタムロールを取得する（存在しない場合は nil, nil）
+func (r *GroupRepository) GetCustomRole(ctx context.Context, groupID, roleID uuid.UUID) (*domain.CustomRole, error) {
+	query := `
+		SELECT id, group_id, name, permissions, created_at, updated_at
+		FROM group_roles
+		WHERE group_id = ? AND id = ?
+	`
+
+	role, err := scanCustomRole(r.db.QueryRowContext(ctx, query, groupID.String(), roleID.String()))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get custom role", logger.Error(err))
+		return nil, fmt.Errorf("failed to get custom role: %w", err)
+	}
+
+	return role, nil
+}
+
+// ListCustomRoles はグループのカスタムロールを作成日時の古い順に取得する
+func (r *GroupRepository) ListCustomRoles(ctx context.Context, groupID uuid.UUID) ([]*domain.CustomRole, error) {
+	query := `
+		SELECT id, group_id, name, permissions, created_at, updated_at
+		FROM group_roles
+		WHERE group_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID.String())
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list custom roles", logger.Error(err))
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*domain.CustomRole{}
+	for rows.Next() {
+		role, err := scanCustomRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan custom role: %w", err)
+		}
+		roles = append(roles, role)
 	}
+
+	return roles, rows.Err()
+}
+
+// UpdateCustomRole はカスタムロールの名前と権限を更新する
+func (r *GroupRepository) UpdateCustomRole(ctx context.Context, role *domain.CustomRole) error {
+	query := `
+		UPDATE group_roles
+		SET name = ?, permissions = ?, updated_at = ?
+		WHERE group_id = ? AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		role.Name,
+		encodeRolePermissions(role.Permissions),
+		role.UpdatedAt,
+		role.GroupID.String(),
+		role.ID.String(),
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update custom role", logger.Error(err))
+		return fmt.Errorf("failed to update custom role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("custom role not found")
+	}
+
+	return nil
+}
+
+// DeleteCustomRole はカスタムロールを削除し、割り当てていたメンバーを MEMBER に戻す
+func (r *GroupRepository) DeleteCustomRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE group_members SET role = ?, updated_at = NOW() WHERE group_id = ? AND role = ?",
+		string(domain.RoleMember), groupID.String(), roleID.String())
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to reset members of custom role", logger.Error(err))
+		return fmt.Errorf("failed to reset members of custom role: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM group_roles WHERE group_id = ? AND id = ?", groupID.String(), roleID.String()); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete custom role", logger.Error(err))
+		return fmt.Errorf("failed to delete custom role: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetAssignmentSettings はグループの自動割り当て設定を取得する（未設定の場合は nil, nil）
+func (r *GroupRepository) GetAssignmentSettings(ctx context.Context, groupID uuid.UUID) (*domain.AssignmentSettings, error) {
+	query := `
+		SELECT policy, last_assignee_id, updated_at
+		FROM group_assignment_settings
+		WHERE group_id = ?
+	`
+
+	settings := &domain.AssignmentSettings{GroupID: groupID}
+	var lastAssigneeID sql.NullString
+	err := r.db.QueryRowContext(ctx, query, groupID.String()).Scan(
+		(*string)(&settings.Policy),
+		&lastAssigneeID,
+		&settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get assignment settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get assignment settings: %w", err)
+	}
+
+	if lastAssigneeID.Valid {
+		if id, err := uuid.Parse(lastAssigneeID.String); err == nil {
+			settings.LastAssigneeID = &id
+		}
+	}
+	return settings, nil
+}
+
+// SaveAssignmentSettings はグループの自動割り当て設定を保存する
+func (r *GroupRepository) SaveAssignmentSettings(ctx context.Context, settings *domain.AssignmentSettings) error {
+	query := `
+		INSERT INTO group_assignment_settings (group_id, policy, last_assignee_id, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			policy = VALUES(policy),
+			last_assignee_id = VALUES(last_assignee_id),
+			updated_at = VALUES(updated_at)
+	`
+
+	var lastAssigneeID interface{}
+	if settings.LastAssigneeID != nil {
+		lastAssigneeID = settings.LastAssigneeID.String()
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		settings.GroupID.String(),
+		string(settings.Policy),
+		lastAssigneeID,
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save assignment settings", logger.Error(err))
+		return fmt.Errorf("failed to save assignment settings: %w", err)
+	}
+
+	return nil
+}
+
+// === ヘルパーメソッド ===
+
+// scanCustomRole は group_roles の1行を読み込む
+func scanCustomRole(row rowScanner) (*domain.CustomRole, error) {
+	var role domain.CustomRole
+	var idStr, groupIDStr, permissions string
+
+	if err := row.Scan(&idStr, &groupIDStr, &role.Name, &permissions, &role.CreatedAt, &role.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	role.ID, _ = uuid.Parse(idStr)
+	role.GroupID, _ = uuid.Parse(groupIDStr)
+	role.Permissions = []domain.GroupAction{}
+	for _, action := range strings.Split(permissions, ",") {
+		if action != "" {
+			role.Permissions = append(role.Permissions, domain.GroupAction(action))
+		}
+	}
+	return &role, nil
+}
+
+// encodeRolePermissions はカスタムロールの権限をカンマ区切りにする
+func encodeRolePermissions(actions []domain.GroupAction) string {
+	names := make([]string, len(actions))
+	for i, action := range actions {
+		names[i] = string(action)
+	}
+	return strings.Join(names, ",")
+}
+
+// groupColumns は scanGroup で読み込むカラム
+const groupColumns = `id, name, description, type, owner_id, parent_group_id, member_count,
+			   is_public, allow_member_invite, require_approval, enable_notifications,
+			   default_privacy_level, allow_schedule_details, enable_gantt_chart, enable_task_dependency,
+			   created_at, updated_at, version`
+
+// rowScanner は *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanGroup は groupColumns の順で1行を読み込む
+func (r *GroupRepository) scanGroup(row rowScanner) (*domain.Group, error) {
+	var group domain.Group
+	var idStr, ownerIDStr string
+	var parentGroupID, defaultPrivacyLevel, allowScheduleDetails, enableGanttChart, enableTaskDependency sql.NullString
+
+	err := row.Scan(
+		&idStr,
+		&group.Name,
+		&group.Description,
+		(*string)(&group.Type),
+		&ownerIDStr,
+		&parentGroupID,
+		&group.MemberCount,
+		&group.Settings.IsPublic,
+		&group.Settings.AllowMemberInvite,
+		&group.Settings.RequireApproval,
+		&group.Settings.EnableNotifications,
+		&defaultPrivacyLevel,
+		&allowScheduleDetails,
+		&enableGanttChart,
+		&enableTaskDependency,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	group.ID, _ = uuid.Parse(idStr)
+	group.OwnerID, _ = uuid.Parse(ownerIDStr)
+	if parentGroupID.Valid {
+		if parentID, err := uuid.Parse(parentGroupID.String); err == nil {
+			group.ParentGroupID = &parentID
+		}
+	}
+
+	// Optional fieldsの処理
+	if defaultPrivacyLevel.Valid {
+		group.Settings.DefaultPrivacyLevel = domain.PrivacyLevel(defaultPrivacyLevel.String)
+	}
+	if allowScheduleDetails.Valid {
+		group.Settings.AllowScheduleDetails = allowScheduleDetails.String == "1"
+	}
+	if enableGanttChart.Valid {
+		group.Settings.EnableGanttChart = enableGanttChart.String == "1"
+	}
+	if enableTaskDependency.Valid {
+		group.Settings.EnableTaskDependency = enableTaskDependency.String == "1"
+	}
+
+	return &group, nil
+}
+
+func (r *GroupRepository) scanGroups(rows *sql.Rows) ([]*domain.Group, error) {
+	var groups []*domain.Group
+
+	for rows.Next() {
+		var group domain.Group
+		var settingsJSON string
+		var idStr, ownerIDStr string
+
+		err := rows.Scan(
+			&idStr,
+			&group.Name,
+			&group.Description,
+			(*string)(&group.Type),
+			&ownerIDStr,
+			&settingsJSON,
+			&group.MemberCount,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+			&group.Version,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan group", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+
+		group.ID, _ = uuid.Parse(idStr)
+		group.OwnerID, _ = uuid.Parse(ownerIDStr)
+		group.Settings = r.decodeGroupSettings(settingsJSON)
+
+		groups = append(groups, &group)
+	}
+
+	return groups, nil
+}
+
+func (r *GroupRepository) encodeGroupSettings(settings domain.GroupSettings) string {
+	// 簡易実装：JSONエンコード（実際の実装では適切なJSONライブラリを使用）
+	return fmt.Sprintf(`{
+		"is_public": %t,
+		"allow_member_invite": %t,
+		"require_approval": %t,
+		"enable_notifications": %t,
+		"default_privacy_level": "%s",
+		"allow_schedule_details": %t,
+		"enable_gantt_chart": %t,
+		"enable_task_dependency": %t
+	}`,
+		settings.IsPublic,
+		settings.AllowMemberInvite,
+		settings.RequireApproval,
+		settings.EnableNotifications,
+		string(settings.DefaultPrivacyLevel),
+		settings.AllowScheduleDetails,
+		settings.EnableGanttChart,
+		settings.EnableTaskDependency,
+	)
+}
+
+func (r *GroupRepository) decodeGroupSettings(settingsJSON string) domain.GroupSettings {
+	// 簡易実装：デフォルト値を返す（実際の実装ではJSONパースを行う）
+	return domain.GroupSettings{
+		IsPublic:             false,
+		AllowMemberInvite:    true,
+		RequireApproval:      true,
+		EnableNotifications:  true,
+		DefaultPrivacyLevel:  domain.PrivacyLevelBusy,
+		AllowScheduleDetails: false,
+		EnableGanttChart:     true,
+		EnableTaskDependency: false,
+	}
+}
+
+// GetLeaderboardSettings はグループのリーダーボード設定を取得する（未設定の場合は nil, nil）
+func (r *GroupRepository) GetLeaderboardSettings(ctx context.Context, groupID uuid.UUID) (*domain.LeaderboardSettings, error) {
+	query := `
+		SELECT enabled, timezone, updated_at
+		FROM group_leaderboard_settings
+		WHERE group_id = ?
+	`
+
+	settings := &domain.LeaderboardSettings{GroupID: groupID}
+	err := r.db.QueryRowContext(ctx, query, groupID.String()).Scan(
+		&settings.Enabled,
+		&settings.Timezone,
+		&settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get leaderboard settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get leaderboard settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SaveLeaderboardSettings はグループのリーダーボード設定を保存する
+func (r *GroupRepository) SaveLeaderboardSettings(ctx context.Context, settings *domain.LeaderboardSettings) error {
+	query := `
+		INSERT INTO group_leaderboard_settings (group_id, enabled, timezone, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			enabled = VALUES(enabled),
+			timezone = VALUES(timezone),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		settings.GroupID.String(),
+		settings.Enabled,
+		settings.Timezone,
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save leaderboard settings", logger.Error(err))
+		return fmt.Errorf("failed to save leaderboard settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetScheduleCardSettings はグループのスケジュールカード設定を取得する（未設定の場合は nil, nil）
+func (r *GroupRepository) GetScheduleCardSettings(ctx context.Context, groupID uuid.UUID) (*domain.ScheduleCardSettings, error) {
+	query := `
+		SELECT enabled, timezone, show_titles, updated_at
+		FROM group_schedule_card_settings
+		WHERE group_id = ?
+	`
+
+	settings := &domain.ScheduleCardSettings{GroupID: groupID}
+	err := r.db.QueryRowContext(ctx, query, groupID.String()).Scan(
+		&settings.Enabled,
+		&settings.Timezone,
+		&settings.ShowTitles,
+		&settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get schedule card settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to get schedule card settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SaveScheduleCardSettings はグループのスケジュールカード設定を保存する
+func (r *GroupRepository) SaveScheduleCardSettings(ctx context.Context, settings *domain.ScheduleCardSettings) error {
+	query := `
+		INSERT INTO group_schedule_card_settings (group_id, enabled, timezone, show_titles, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			enabled = VALUES(enabled),
+			timezone = VALUES(timezone),
+			show_titles = VALUES(show_titles),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		settings.GroupID.String(),
+		settings.Enabled,
+		settings.Timezone,
+		settings.ShowTitles,
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save schedule card settings", logger.Error(err))
+		return fmt.Errorf("failed to save schedule card settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetLeaderboardVisibilities はメンバーが設定した公開範囲を取得する（未設定のメンバーは含まない）
+func (r *GroupRepository) GetLeaderboardVisibilities(ctx context.Context, groupID uuid.UUID) (map[uuid.UUID]domain.LeaderboardVisibility, error) {
+	query := `
+		SELECT user_id, visibility
+		FROM group_leaderboard_preferences
+		WHERE group_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID.String())
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get leaderboard preferences", logger.Error(err))
+		return nil, fmt.Errorf("failed to get leaderboard preferences: %w", err)
+	}
+	defer rows.Close()
+
+	visibilities := make(map[uuid.UUID]domain.LeaderboardVisibility)
+	for rows.Next() {
+		var userID, visibility string
+		if err := rows.Scan(&userID, &visibility); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard preference: %w", err)
+		}
+		if id, err := uuid.Parse(userID); err == nil {
+			visibilities[id] = domain.LeaderboardVisibility(visibility)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate leaderboard preferences: %w", err)
+	}
+
+	return visibilities, nil
+}
+
+// SaveLeaderboardPreference はメンバーのリーダーボードの公開範囲を保存する
+func (r *GroupRepository) SaveLeaderboardPreference(ctx context.Context, preference *domain.LeaderboardPreference) error {
+	query := `
+		INSERT INTO group_leaderboard_preferences (group_id, user_id, visibility, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			visibility = VALUES(visibility),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		preference.GroupID.String(),
+		preference.UserID.String(),
+		string(preference.Visibility),
+		preference.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save leaderboard preference", logger.Error(err))
+		return fmt.Errorf("failed to save leaderboard preference: %w", err)
+	}
+
+	return nil
+}
+
+// eventRSVPColumns は予定の出欠で選択するカラム（scanEventRSVPsの順序と一致させる）
+const eventRSVPColumns = "group_id, event_id, user_id, status, attended, responded_at, updated_at"
+
+// GetEventRSVP はメンバーの予定への出欠を取得する（未回答・出席も未記録の場合は nil, nil）
+func (r *GroupRepository) GetEventRSVP(ctx context.Context, groupID uuid.UUID, eventID string, userID uuid.UUID) (*domain.EventRSVP, error) {
+	query := `SELECT ` + eventRSVPColumns + `
+		FROM group_event_rsvps
+		WHERE group_id = ? AND event_id = ? AND user_id = ?
+	`
+
+	rsvps, err := r.queryEventRSVPs(ctx, query, groupID.String(), eventID, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(rsvps) == 0 {
+		return nil, nil
+	}
+	return rsvps[0], nil
+}
+
+// SaveEventRSVP はメンバーの予定への出欠の回答と出席の記録を保存する
+func (r *GroupRepository) SaveEventRSVP(ctx context.Context, rsvp *domain.EventRSVP) error {
+	query := `
+		INSERT INTO group_event_rsvps (group_id, event_id, user_id, status, attended, responded_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			status = VALUES(status),
+			attended = VALUES(attended),
+			responded_at = VALUES(responded_at),
+			updated_at = VALUES(updated_at)
+	`
+
+	var attended sql.NullBool
+	if rsvp.Attended != nil {
+		attended = sql.NullBool{Bool: *rsvp.Attended, Valid: true}
+	}
+	var respondedAt sql.NullTime
+	if rsvp.RespondedAt != nil {
+		respondedAt = sql.NullTime{Time: *rsvp.RespondedAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		rsvp.GroupID.String(),
+		rsvp.EventID,
+		rsvp.UserID.String(),
+		string(rsvp.Status),
+		attended,
+		respondedAt,
+		rsvp.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save event rsvp", logger.Error(err))
+		return fmt.Errorf("failed to save event rsvp: %w", err)
+	}
+
+	return nil
+}
+
+// ListEventRSVPs は予定の出欠を取得する
+func (r *GroupRepository) ListEventRSVPs(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventRSVP, error) {
+	query := `SELECT ` + eventRSVPColumns + `
+		FROM group_event_rsvps
+		WHERE group_id = ? AND event_id = ?
+	`
+	return r.queryEventRSVPs(ctx, query, groupID.String(), eventID)
+}
+
+// ListGroupEventRSVPs はグループのすべての予定の出欠を取得する
+func (r *GroupRepository) ListGroupEventRSVPs(ctx context.Context, groupID uuid.UUID) ([]*domain.EventRSVP, error) {
+	query := `SELECT ` + eventRSVPColumns + `
+		FROM group_event_rsvps
+		WHERE group_id = ?
+	`
+	return r.queryEventRSVPs(ctx, query, groupID.String())
+}
+
+// IsEventReminded は予定の出欠のリマインダーを送信済みか確認する
+func (r *GroupRepository) IsEventReminded(ctx context.Context, groupID uuid.UUID, eventID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM group_event_reminders WHERE group_id = ? AND event_id = ?`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, groupID.String(), eventID).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to check event reminder", logger.Error(err))
+		return false, fmt.Errorf("failed to check event reminder: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SaveEventReminder は予定の出欠のリマインダーを送信済みにする
+func (r *GroupRepository) SaveEventReminder(ctx context.Context, groupID uuid.UUID, eventID string, remindedAt time.Time) error {
+	query := `
+		INSERT INTO group_event_reminders (group_id, event_id, reminded_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			reminded_at = VALUES(reminded_at)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID.String(), eventID, remindedAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save event reminder", logger.Error(err))
+		return fmt.Errorf("failed to save event reminder: %w", err)
+	}
+	return nil
+}
+
+// queryEventRSVPs は予定の出欠を検索する
+func (r *GroupRepository) queryEventRSVPs(ctx context.Context, query string, args ...interface{}) ([]*domain.EventRSVP, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get event rsvps", logger.Error(err))
+		return nil, fmt.Errorf("failed to get event rsvps: %w", err)
+	}
+	defer rows.Close()
+
+	var rsvps []*domain.EventRSVP
+	for rows.Next() {
+		var (
+			groupID, userID, status string
+			rsvp                    domain.EventRSVP
+			attended                sql.NullBool
+			respondedAt             sql.NullTime
+		)
+		if err := rows.Scan(&groupID, &rsvp.EventID, &userID, &status, &attended, &respondedAt, &rsvp.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event rsvp: %w", err)
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		uid, err := uuid.Parse(userID)
+		if err != nil {
+			continue
+		}
+		rsvp.GroupID = gid
+		rsvp.UserID = uid
+		rsvp.Status = domain.RSVPStatus(status)
+		if attended.Valid {
+			rsvp.Attended = &attended.Bool
+		}
+		if respondedAt.Valid {
+			rsvp.RespondedAt = &respondedAt.Time
+		}
+		rsvps = append(rsvps, &rsvp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate event rsvps: %w", err)
+	}
+
+	return rsvps, nil
+}
+
+// eventRecurrenceColumns は予定の繰り返しで選択するカラム（queryEventRecurrencesの順序と一致させる）
+const eventRecurrenceColumns = "group_id, event_id, frequency, interval_count, weekdays, occurrence_count, until_at, timezone, holiday_policy, created_by, updated_at"
+
+// eventExceptionColumns は回の変更で選択するカラム（queryEventExceptionsの順序と一致させる）
+const eventExceptionColumns = "group_id, event_id, original_starts_at, cancelled, starts_at, updated_by, updated_at"
+
+// GetEventRecurrence は予定の繰り返しの設定を取得する（設定がない場合は nil, nil）
+func (r *GroupRepository) GetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.EventRecurrence, error) {
+	query := `SELECT ` + eventRecurrenceColumns + `
+		FROM group_event_recurrences
+		WHERE group_id = ? AND event_id = ?
+	`
+
+	recurrences, err := r.queryEventRecurrences(ctx, query, groupID.String(), eventID)
+	if err != nil {
+		return nil, err
+	}
+	if len(recurrences) == 0 {
+		return nil, nil
+	}
+	return recurrences[0], nil
+}
+
+// SaveEventRecurrence は予定の繰り返しの設定を保存する
+func (r *GroupRepository) SaveEventRecurrence(ctx context.Context, recurrence *domain.EventRecurrence) error {
+	query := `
+		INSERT INTO group_event_recurrences (` + eventRecurrenceColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			frequency = VALUES(frequency),
+			interval_count = VALUES(interval_count),
+			weekdays = VALUES(weekdays),
+			occurrence_count = VALUES(occurrence_count),
+			until_at = VALUES(until_at),
+			timezone = VALUES(timezone),
+			holiday_policy = VALUES(holiday_policy),
+			updated_at = VALUES(updated_at)
+	`
+
+	var until sql.NullTime
+	if recurrence.Until != nil {
+		until = sql.NullTime{Time: *recurrence.Until, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		recurrence.GroupID.String(),
+		recurrence.EventID,
+		string(recurrence.Frequency),
+		recurrence.Interval,
+		strings.Join(recurrence.Weekdays, ","),
+		recurrence.Count,
+		until,
+		recurrence.Timezone,
+		string(recurrence.HolidayPolicy),
+		recurrence.CreatedBy.String(),
+		recurrence.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save event recurrence", logger.Error(err))
+		return fmt.Errorf("failed to save event recurrence: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEventRecurrence は予定の繰り返しの設定と回ごとの変更を削除する
+func (r *GroupRepository) DeleteEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM group_event_exceptions WHERE group_id = ? AND event_id = ?", groupID.String(), eventID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete event exceptions", logger.Error(err))
+		return fmt.Errorf("failed to delete event exceptions: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM group_event_recurrences WHERE group_id = ? AND event_id = ?", groupID.String(), eventID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete event recurrence", logger.Error(err))
+		return fmt.Errorf("failed to delete event recurrence: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListEventRecurrences はグループの繰り返しの設定を取得する
+func (r *GroupRepository) ListEventRecurrences(ctx context.Context, groupID uuid.UUID) ([]*domain.EventRecurrence, error) {
+	query := `SELECT ` + eventRecurrenceColumns + `
+		FROM group_event_recurrences
+		WHERE group_id = ?
+	`
+	return r.queryEventRecurrences(ctx, query, groupID.String())
+}
+
+// ListActiveEventRecurrences はすべてのグループから、Until が now 以降（または無期限）の繰り返しの設定を取得する
+func (r *GroupRepository) ListActiveEventRecurrences(ctx context.Context, now time.Time) ([]*domain.EventRecurrence, error) {
+	query := `SELECT ` + eventRecurrenceColumns + `
+		FROM group_event_recurrences
+		WHERE until_at IS NULL OR until_at >= ?
+	`
+	return r.queryEventRecurrences(ctx, query, now)
+}
+
+// ListEventExceptions は予定の回ごとの変更を取得する
+func (r *GroupRepository) ListEventExceptions(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventException, error) {
+	query := `SELECT ` + eventExceptionColumns + `
+		FROM group_event_exceptions
+		WHERE group_id = ? AND event_id = ?
+	`
+	return r.queryEventExceptions(ctx, query, groupID.String(), eventID)
+}
+
+// ListGroupEventExceptions はグループのすべての予定の回ごとの変更を取得する
+func (r *GroupRepository) ListGroupEventExceptions(ctx context.Context, groupID uuid.UUID) ([]*domain.EventException, error) {
+	query := `SELECT ` + eventExceptionColumns + `
+		FROM group_event_exceptions
+		WHERE group_id = ?
+	`
+	return r.queryEventExceptions(ctx, query, groupID.String())
+}
+
+// SaveEventException は回の変更を保存する（同じ回の変更は上書きする）
+func (r *GroupRepository) SaveEventException(ctx context.Context, exception *domain.EventException) error {
+	query := `
+		INSERT INTO group_event_exceptions (` + eventExceptionColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			cancelled = VALUES(cancelled),
+			starts_at = VALUES(starts_at),
+			updated_by = VALUES(updated_by),
+			updated_at = VALUES(updated_at)
+	`
+
+	var startsAt sql.NullTime
+	if exception.StartsAt != nil {
+		startsAt = sql.NullTime{Time: *exception.StartsAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		exception.GroupID.String(),
+		exception.EventID,
+		exception.OriginalStartsAt,
+		exception.Cancelled,
+		startsAt,
+		exception.UpdatedBy.String(),
+		exception.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save event exception", logger.Error(err))
+		return fmt.Errorf("failed to save event exception: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEventException は回の変更を削除する
+func (r *GroupRepository) DeleteEventException(ctx context.Context, groupID uuid.UUID, eventID string, originalStartsAt time.Time) error {
+	query := `DELETE FROM group_event_exceptions WHERE group_id = ? AND event_id = ? AND original_starts_at = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID.String(), eventID, originalStartsAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete event exception", logger.Error(err))
+		return fmt.Errorf("failed to delete event exception: %w", err)
+	}
+	return nil
+}
+
+// queryEventRecurrences は予定の繰り返しの設定を検索する
+func (r *GroupRepository) queryEventRecurrences(ctx context.Context, query string, args ...interface{}) ([]*domain.EventRecurrence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get event recurrences", logger.Error(err))
+		return nil, fmt.Errorf("failed to get event recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var recurrences []*domain.EventRecurrence
+	for rows.Next() {
+		var (
+			groupID, frequency, weekdays, holidayPolicy, createdBy string
+			recurrence                                             domain.EventRecurrence
+			until                                                  sql.NullTime
+		)
+		if err := rows.Scan(&groupID, &recurrence.EventID, &frequency, &recurrence.Interval, &weekdays,
+			&recurrence.Count, &until, &recurrence.Timezone, &holidayPolicy, &createdBy, &recurrence.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event recurrence: %w", err)
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		recurrence.GroupID = gid
+		recurrence.CreatedBy, _ = uuid.Parse(createdBy)
+		recurrence.Frequency = domain.RecurrenceFrequency(frequency)
+		recurrence.HolidayPolicy = domain.RecurrenceHolidayPolicy(holidayPolicy)
+		if weekdays != "" {
+			recurrence.Weekdays = strings.Split(weekdays, ",")
+		}
+		if until.Valid {
+			recurrence.Until = &until.Time
+		}
+		recurrences = append(recurrences, &recurrence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate event recurrences: %w", err)
+	}
+
+	return recurrences, nil
+}
+
+// queryEventExceptions は回の変更を検索する
+func (r *GroupRepository) queryEventExceptions(ctx context.Context, query string, args ...interface{}) ([]*domain.EventException, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get event exceptions", logger.Error(err))
+		return nil, fmt.Errorf("failed to get event exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	var exceptions []*domain.EventException
+	for rows.Next() {
+		var (
+			groupID, updatedBy string
+			exception          domain.EventException
+			startsAt           sql.NullTime
+		)
+		if err := rows.Scan(&groupID, &exception.EventID, &exception.OriginalStartsAt, &exception.Cancelled,
+			&startsAt, &updatedBy, &exception.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event exception: %w", err)
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		exception.GroupID = gid
+		exception.UpdatedBy, _ = uuid.Parse(updatedBy)
+		if startsAt.Valid {
+			exception.StartsAt = &startsAt.Time
+		}
+		exceptions = append(exceptions, &exception)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate event exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+// eventNoteColumns は議事録で選択するカラム（queryEventNotesの順序と一致させる）
+const eventNoteColumns = "id, group_id, event_id, title, content, created_by, updated_by, created_at, updated_at"
+
+// noteActionItemColumns は議事録から作成したタスクで選択するカラム（queryNoteActionItemsの順序と一致させる）
+const noteActionItemColumns = "note_id, group_id, task_id, item_text, assignee_id, created_at"
+
+// CreateEventNote は議事録を作成する
+func (r *GroupRepository) CreateEventNote(ctx context.Context, note *domain.EventNote) error {
+	query := `
+		INSERT INTO group_event_notes (` + eventNoteColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		note.ID.String(),
+		note.GroupID.String(),
+		note.EventID,
+		note.Title,
+		note.Content,
+		note.CreatedBy.String(),
+		note.UpdatedBy.String(),
+		note.CreatedAt,
+		note.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create event note", logger.Error(err))
+		return fmt.Errorf("failed to create event note: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventNote はグループの議事録を取得する（存在しない場合は nil, nil）
+func (r *GroupRepository) GetEventNote(ctx context.Context, groupID, noteID uuid.UUID) (*domain.EventNote, error) {
+	query := `SELECT ` + eventNoteColumns + `
+		FROM group_event_notes
+		WHERE id = ? AND group_id = ?
+	`
+
+	notes, err := r.queryEventNotes(ctx, query, noteID.String(), groupID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, nil
+	}
+	return notes[0], nil
+}
+
+// UpdateEventNote は議事録のタイトルと本文を更新する
+func (r *GroupRepository) UpdateEventNote(ctx context.Context, note *domain.EventNote) error {
+	query := `
+		UPDATE group_event_notes
+		SET title = ?, content = ?, updated_by = ?, updated_at = ?
+		WHERE id = ? AND group_id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		note.Title,
+		note.Content,
+		note.UpdatedBy.String(),
+		note.UpdatedAt,
+		note.ID.String(),
+		note.GroupID.String(),
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update event note", logger.Error(err))
+		return fmt.Errorf("failed to update event note: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEventNote は議事録と、作成したタスクとの対応を削除する（タスクは削除しない）
+func (r *GroupRepository) DeleteEventNote(ctx context.Context, groupID, noteID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM group_note_action_items WHERE note_id = ? AND group_id = ?", noteID.String(), groupID.String()); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete note action items", logger.Error(err))
+		return fmt.Errorf("failed to delete note action items: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM group_event_notes WHERE id = ? AND group_id = ?", noteID.String(), groupID.String()); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete event note", logger.Error(err))
+		return fmt.Errorf("failed to delete event note: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListEventNotes は予定の議事録を作成日時の古い順に取得する
+func (r *GroupRepository) ListEventNotes(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventNote, error) {
+	query := `SELECT ` + eventNoteColumns + `
+		FROM group_event_notes
+		WHERE group_id = ? AND event_id = ?
+		ORDER BY created_at ASC
+	`
+	return r.queryEventNotes(ctx, query, groupID.String(), eventID)
+}
+
+// ListNoteActionItems は議事録から作成したタスクを作成日時の古い順に取得する
+func (r *GroupRepository) ListNoteActionItems(ctx context.Context, noteID uuid.UUID) ([]*domain.NoteActionItem, error) {
+	query := `SELECT ` + noteActionItemColumns + `
+		FROM group_note_action_items
+		WHERE note_id = ?
+		ORDER BY created_at ASC
+	`
+	return r.queryNoteActionItems(ctx, query, noteID.String())
+}
+
+// SaveNoteActionItem は議事録から作成したタスクを記録する
+func (r *GroupRepository) SaveNoteActionItem(ctx context.Context, item *domain.NoteActionItem) error {
+	query := `
+		INSERT INTO group_note_action_items (` + noteActionItemColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	var assigneeID sql.NullString
+	if item.AssigneeID != nil {
+		assigneeID = sql.NullString{String: item.AssigneeID.String(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		item.NoteID.String(),
+		item.GroupID.String(),
+		item.TaskID,
+		item.Text,
+		assigneeID,
+		item.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save note action item", logger.Error(err))
+		return fmt.Errorf("failed to save note action item: %w", err)
+	}
+
+	return nil
+}
+
+// GetNoteActionItemByTask はタスクを作成したアクションアイテムを取得する（議事録から作成したタスクでない場合は nil, nil）
+func (r *GroupRepository) GetNoteActionItemByTask(ctx context.Context, groupID uuid.UUID, taskID string) (*domain.NoteActionItem, error) {
+	query := `SELECT ` + noteActionItemColumns + `
+		FROM group_note_action_items
+		WHERE group_id = ? AND task_id = ?
+	`
+
+	items, err := r.queryNoteActionItems(ctx, query, groupID.String(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items[0], nil
+}
+
+// queryEventNotes は議事録を検索する
+func (r *GroupRepository) queryEventNotes(ctx context.Context, query string, args ...interface{}) ([]*domain.EventNote, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get event notes", logger.Error(err))
+		return nil, fmt.Errorf("failed to get event notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*domain.EventNote
+	for rows.Next() {
+		var (
+			id, groupID, createdBy, updatedBy string
+			note                              domain.EventNote
+		)
+		if err := rows.Scan(&id, &groupID, &note.EventID, &note.Title, &note.Content,
+			&createdBy, &updatedBy, &note.CreatedAt, &note.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event note: %w", err)
+		}
+		noteID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		note.ID = noteID
+		note.GroupID = gid
+		note.CreatedBy, _ = uuid.Parse(createdBy)
+		note.UpdatedBy, _ = uuid.Parse(updatedBy)
+		notes = append(notes, &note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate event notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// queryNoteActionItems は議事録から作成したタスクを検索する
+func (r *GroupRepository) queryNoteActionItems(ctx context.Context, query string, args ...interface{}) ([]*domain.NoteActionItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get note action items", logger.Error(err))
+		return nil, fmt.Errorf("failed to get note action items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.NoteActionItem
+	for rows.Next() {
+		var (
+			noteID, groupID string
+			assigneeID      sql.NullString
+			item            domain.NoteActionItem
+		)
+		if err := rows.Scan(&noteID, &groupID, &item.TaskID, &item.Text, &assigneeID, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan note action item: %w", err)
+		}
+		nid, err := uuid.Parse(noteID)
+		if err != nil {
+			continue
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		item.NoteID = nid
+		item.GroupID = gid
+		if assigneeID.Valid {
+			if id, err := uuid.Parse(assigneeID.String); err == nil {
+				item.AssigneeID = &id
+			}
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate note action items: %w", err)
+	}
+
+	return items, nil
+}
+
+// eventTaskLinkColumns は予定とタスクの関連付けで選択するカラム（queryEventTaskLinksの順序と一致させる）
+const eventTaskLinkColumns = "group_id, event_id, task_id, kind, created_by, created_at"
+
+// SaveEventTaskLink は予定とタスクの関連付けを記録する
+func (r *GroupRepository) SaveEventTaskLink(ctx context.Context, link *domain.EventTaskLink) error {
+	query := `
+		INSERT INTO group_event_task_links (` + eventTaskLinkColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.GroupID.String(),
+		link.EventID,
+		link.TaskID,
+		string(link.Kind),
+		link.CreatedBy.String(),
+		link.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save event task link", logger.Error(err))
+		return fmt.Errorf("failed to save event task link: %w", err)
+	}
+
+	return nil
+}
+
+// ListEventTaskLinks は予定（回）の関連付けを作成日時の古い順に取得する
+func (r *GroupRepository) ListEventTaskLinks(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventTaskLink, error) {
+	query := `SELECT ` + eventTaskLinkColumns + `
+		FROM group_event_task_links
+		WHERE group_id = ? AND event_id = ?
+		ORDER BY created_at ASC
+	`
+
+	return r.queryEventTaskLinks(ctx, query, groupID.String(), eventID)
+}
+
+// ListTaskLinks はタスク、またはタスクを予定（繰り返しの予定の回を含む）とする関連付けを作成日時の古い順に取得する
+func (r *GroupRepository) ListTaskLinks(ctx context.Context, taskID string) ([]*domain.EventTaskLink, error) {
+	// 繰り返しの予定の回のIDは "タスクID_開始日時" の形式（タスクIDは同じ長さなので _ のワイルドカードで他のタスクに一致しない）
+	query := `SELECT ` + eventTaskLinkColumns + `
+		FROM group_event_task_links
+		WHERE task_id = ? OR event_id = ? OR event_id LIKE ?
+		ORDER BY created_at ASC
+	`
+
+	return r.queryEventTaskLinks(ctx, query, taskID, taskID, taskID+"_%")
+}
+
+// queryEventTaskLinks は予定とタスクの関連付けを検索する
+func (r *GroupRepository) queryEventTaskLinks(ctx context.Context, query string, args ...interface{}) ([]*domain.EventTaskLink, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get event task links", logger.Error(err))
+		return nil, fmt.Errorf("failed to get event task links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*domain.EventTaskLink{}
+	for rows.Next() {
+		var (
+			groupID, kind, createdBy string
+			link                     domain.EventTaskLink
+		)
+		if err := rows.Scan(&groupID, &link.EventID, &link.TaskID, &kind, &createdBy, &link.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event task link: %w", err)
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		link.GroupID = gid
+		link.Kind = domain.EventTaskLinkKind(kind)
+		link.CreatedBy, _ = uuid.Parse(createdBy)
+		links = append(links, &link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate event task links: %w", err)
+	}
+
+	return links, nil
+}
+
+// chatIntegrationColumns はチャット連携で選択するカラム（queryChatIntegrationsの順序と一致させる）
+const chatIntegrationColumns = "group_id, platform, webhook_url, events, templates, summary_hour, timezone, enabled, last_summary_date, due_reminder_hours, due_checked_until, created_by, created_at, updated_at"
+
+// SaveChatIntegration はグループのチャット連携を保存する（同じ連携先の設定は上書きする）
+func (r *GroupRepository) SaveChatIntegration(ctx context.Context, integration *domain.ChatIntegration) error {
+	query := `
+		INSERT INTO group_chat_integrations (` + chatIntegrationColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			webhook_url = VALUES(webhook_url),
+			events = VALUES(events),
+			templates = VALUES(templates),
+			summary_hour = VALUES(summary_hour),
+			timezone = VALUES(timezone),
+			enabled = VALUES(enabled),
+			last_summary_date = VALUES(last_summary_date),
+			due_reminder_hours = VALUES(due_reminder_hours),
+			due_checked_until = VALUES(due_checked_until),
+			updated_at = VALUES(updated_at)
+	`
+
+	events := make([]string, len(integration.Events))
+	for i, event := range integration.Events {
+		events[i] = string(event)
+	}
+	templates, err := json.Marshal(integration.Templates)
+	if err != nil {
+		return fmt.Errorf("failed to encode chat templates: %w", err)
+	}
+	var dueCheckedUntil sql.NullTime
+	if integration.DueCheckedUntil != nil {
+		dueCheckedUntil = sql.NullTime{Time: *integration.DueCheckedUntil, Valid: true}
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		integration.GroupID.String(),
+		string(integration.Platform),
+		integration.WebhookURL,
+		strings.Join(events, ","),
+		string(templates),
+		integration.SummaryHour,
+		integration.Timezone,
+		integration.Enabled,
+		integration.LastSummaryDate,
+		integration.DueReminderHours,
+		dueCheckedUntil,
+		integration.CreatedBy.String(),
+		integration.CreatedAt,
+		integration.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save chat integration", logger.Error(err))
+		return fmt.Errorf("failed to save chat integration: %w", err)
+	}
+
+	return nil
+}
+
+// GetChatIntegration はグループのチャット連携を取得する（設定がない場合は nil, nil）
+func (r *GroupRepository) GetChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform) (*domain.ChatIntegration, error) {
+	query := `SELECT ` + chatIntegrationColumns + `
+		FROM group_chat_integrations
+		WHERE group_id = ? AND platform = ?
+	`
+
+	integrations, err := r.queryChatIntegrations(ctx, query, groupID.String(), string(platform))
+	if err != nil || len(integrations) == 0 {
+		return nil, err
+	}
+	return integrations[0], nil
+}
+
+// ListChatIntegrations はグループのチャット連携を連携先の順に取得する
+func (r *GroupRepository) ListChatIntegrations(ctx context.Context, groupID uuid.UUID) ([]*domain.ChatIntegration, error) {
+	query := `SELECT ` + chatIntegrationColumns + `
+		FROM group_chat_integrations
+		WHERE group_id = ?
+		ORDER BY platform ASC
+	`
+
+	return r.queryChatIntegrations(ctx, query, groupID.String())
+}
+
+// ListEnabledChatIntegrations はすべてのグループから有効なチャット連携を取得する
+func (r *GroupRepository) ListEnabledChatIntegrations(ctx context.Context) ([]*domain.ChatIntegration, error) {
+	query := `SELECT ` + chatIntegrationColumns + `
+		FROM group_chat_integrations
+		WHERE enabled = ?
+		ORDER BY group_id ASC, platform ASC
+	`
+
+	return r.queryChatIntegrations(ctx, query, true)
+}
+
+// DeleteChatIntegration はグループのチャット連携を削除する
+func (r *GroupRepository) DeleteChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform) error {
+	query := `DELETE FROM group_chat_integrations WHERE group_id = ? AND platform = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID.String(), string(platform)); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete chat integration", logger.Error(err))
+		return fmt.Errorf("failed to delete chat integration: %w", err)
+	}
+
+	return nil
+}
+
+// queryChatIntegrations はグループのチャット連携を検索する
+func (r *GroupRepository) queryChatIntegrations(ctx context.Context, query string, args ...interface{}) ([]*domain.ChatIntegration, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get chat integrations", logger.Error(err))
+		return nil, fmt.Errorf("failed to get chat integrations: %w", err)
+	}
+	defer rows.Close()
+
+	integrations := []*domain.ChatIntegration{}
+	for rows.Next() {
+		var (
+			groupID, platform, events, templates, createdBy string
+			dueCheckedUntil                                 sql.NullTime
+			integration                                     domain.ChatIntegration
+		)
+		err := rows.Scan(
+			&groupID,
+			&platform,
+			&integration.WebhookURL,
+			&events,
+			&templates,
+			&integration.SummaryHour,
+			&integration.Timezone,
+			&integration.Enabled,
+			&integration.LastSummaryDate,
+			&integration.DueReminderHours,
+			&dueCheckedUntil,
+			&createdBy,
+			&integration.CreatedAt,
+			&integration.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat integration: %w", err)
+		}
+		gid, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		integration.GroupID = gid
+		integration.Platform = domain.ChatPlatform(platform)
+		integration.CreatedBy, _ = uuid.Parse(createdBy)
+		if dueCheckedUntil.Valid {
+			integration.DueCheckedUntil = &dueCheckedUntil.Time
+		}
+		integration.Events = []domain.ChatEvent{}
+		if events != "" {
+			for _, event := range strings.Split(events, ",") {
+				integration.Events = append(integration.Events, domain.ChatEvent(event))
+			}
+		}
+		integration.Templates = map[domain.ChatEvent]string{}
+		if templates != "" {
+			if err := json.Unmarshal([]byte(templates), &integration.Templates); err != nil {
+				return nil, fmt.Errorf("failed to decode chat templates: %w", err)
+			}
+		}
+		integrations = append(integrations, &integration)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate chat integrations: %w", err)
+	}
+
+	return integrations, nil
 }
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,10 +12,11 @@ import (
 // === リクエストDTO ===
 
 type CreateGroupRequest struct {
-	Name        string               `json:"name" binding:"required,max=100" example:"プロジェクトチーム"`
-	Description string               `json:"description" binding:"max=500" example:"新製品開発プロジェクトのチーム"`
-	Type        string               `json:"type" binding:"required" enums:"PROJECT,SCHEDULE" example:"PROJECT"`
-	Settings    domain.GroupSettings `json:"settings"`
+	Name          string               `json:"name" binding:"required,max=100" example:"プロジェクトチーム"`
+	Description   string               `json:"description" binding:"max=500" example:"新製品開発プロジェクトのチーム"`
+	Type          string               `json:"type" binding:"required" enums:"PROJECT,SCHEDULE" example:"PROJECT"`
+	Settings      domain.GroupSettings `json:"settings"`
+	ParentGroupID *string              `json:"parent_group_id,omitempty" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // サブチームとして作成する場合の親グループ
 } // @name CreateGroupRequest
 
 type UpdateGroupRequest struct {
@@ -25,26 +27,112 @@ type UpdateGroupRequest struct {
 
 type AddMemberRequest struct {
 	UserID string `json:"user_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Role   string `json:"role" enums:"OWNER,ADMIN,MEMBER" example:"MEMBER"`
+	Role   string `json:"role" example:"MEMBER"` // OWNER・ADMIN・MEMBER・GUEST またはカスタムロールのID（省略時は MEMBER）
 } // @name AddMemberRequest
 
+type BulkAddMembersRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=100" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Role    string   `json:"role" example:"MEMBER"` // ADMIN・MEMBER・GUEST またはカスタムロールのID（省略時は MEMBER）
+} // @name BulkAddMembersRequest
+
 type UpdateMemberRoleRequest struct {
-	Role string `json:"role" binding:"required" enums:"OWNER,ADMIN,MEMBER" example:"ADMIN"`
+	Role string `json:"role" binding:"required" example:"ADMIN"` // OWNER・ADMIN・MEMBER・GUEST またはカスタムロールのID
 } // @name UpdateMemberRoleRequest
 
+type CustomRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=50" example:"タスク管理者"`
+	Permissions []string `json:"permissions" example:"CREATE_TASKS,EDIT_TASKS"` // 付与するアクション（権限設定で変更できるもののみ）
+} // @name CustomRoleRequest
+
+type UpdateGroupPermissionsRequest struct {
+	// アクションごとに許可するロール（ADMIN・MEMBER）。指定しなかったアクションはデフォルトに戻る
+	Permissions map[string][]string `json:"permissions" binding:"required"`
+} // @name UpdateGroupPermissionsRequest
+
+type UpdateAssignmentPolicyRequest struct {
+	Policy string `json:"policy" binding:"required,oneof=NONE ROUND_ROBIN LEAST_LOADED" example:"ROUND_ROBIN"`
+} // @name UpdateAssignmentPolicyRequest
+
+type UpdateLeaderboardSettingsRequest struct {
+	Enabled  *bool   `json:"enabled,omitempty" example:"true"`
+	Timezone *string `json:"timezone,omitempty" example:"Asia/Tokyo"` // 週の区切り（月曜日0時）に使うタイムゾーン
+} // @name UpdateLeaderboardSettingsRequest
+
+type UpdateScheduleCardSettingsRequest struct {
+	Enabled    *bool   `json:"enabled,omitempty" example:"true"`
+	Timezone   *string `json:"timezone,omitempty" example:"Asia/Tokyo"` // 日付の区切りに使うタイムゾーン
+	ShowTitles *bool   `json:"show_titles,omitempty" example:"false"`   // falseの場合は予定のタイトルを伏せる
+} // @name UpdateScheduleCardSettingsRequest
+
+type UpdateLeaderboardVisibilityRequest struct {
+	Visibility string `json:"visibility" binding:"required,oneof=VISIBLE ANONYMOUS HIDDEN" example:"ANONYMOUS"`
+} // @name UpdateLeaderboardVisibilityRequest
+
+type SetRSVPRequest struct {
+	Status string `json:"status" binding:"required,oneof=YES NO MAYBE" example:"YES"`
+} // @name SetRSVPRequest
+
+type RecordAttendanceRequest struct {
+	UserID   string `json:"user_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Attended *bool  `json:"attended" binding:"required" example:"true"`
+} // @name RecordAttendanceRequest
+
+type SetEventRecurrenceRequest struct {
+	Frequency string     `json:"frequency" binding:"required,oneof=DAILY WEEKLY MONTHLY" example:"WEEKLY"`
+	Interval  int        `json:"interval,omitempty" binding:"omitempty,min=1,max=99" example:"1"` // 省略時は1
+	Weekdays  []string   `json:"weekdays,omitempty" example:"MO,WE"`                              // WEEKLY の曜日（MO〜SU）、省略時は最初の回の曜日
+	Count     int        `json:"count,omitempty" binding:"omitempty,min=1,max=500" example:"10"`  // 繰り返す回数（最初の回を含む）、省略時は無制限
+	Until     *time.Time `json:"until,omitempty" example:"2024-12-31T23:59:59Z"`                  // 最後の回の開始日時の上限（count と同時に指定できない）
+	Timezone  string     `json:"timezone,omitempty" example:"Asia/Tokyo"`                         // 繰り返す時刻のタイムゾーン、省略時は UTC
+	// 回が祝日になった場合の扱い（SKIP: 行わない、NEXT_BUSINESS_DAY: 次の営業日に移動）、省略時はそのまま行う
+	HolidayPolicy string `json:"holiday_policy,omitempty" binding:"omitempty,oneof=SKIP NEXT_BUSINESS_DAY" example:"NEXT_BUSINESS_DAY"`
+} // @name SetEventRecurrenceRequest
+
+type ChangeOccurrenceRequest struct {
+	Cancelled bool       `json:"cancelled" example:"false"`                          // true の場合はこの回を中止する
+	StartsAt  *time.Time `json:"starts_at,omitempty" example:"2024-01-09T10:00:00Z"` // 変更後の開始日時（中止しない場合は必須）
+} // @name ChangeOccurrenceRequest
+
+type EventNoteRequest struct {
+	Title   string `json:"title" binding:"required,max=200" example:"定例会の議事録"`
+	Content string `json:"content" binding:"max=20000" example:"## 決定事項\n- [ ] 資料を共有する @alice"` // Markdown、未完了のタスクリストの行からタスクを作成する
+} // @name EventNoteRequest
+
+type ScheduleTaskBlockRequest struct {
+	StartsAt        time.Time `json:"starts_at" binding:"required" example:"2024-01-09T10:00:00Z"`
+	DurationMinutes *int      `json:"duration_minutes,omitempty" binding:"omitempty,min=1,max=1440" example:"90"` // 省略時はタスクの見積もり時間
+} // @name ScheduleTaskBlockRequest
+
+type CreateFollowUpTaskRequest struct {
+	Title       string     `json:"title,omitempty" binding:"max=255" example:"議事録を共有する"`                 // 省略時は「予定のタイトル」のフォローアップ
+	Description string     `json:"description,omitempty" binding:"max=1500" example:"決定事項をまとめて共有する"`     // 予定へのリンクを追記する
+	AssigneeID  *uuid.UUID `json:"assignee_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // 省略時は作成したメンバー
+} // @name CreateFollowUpTaskRequest
+
+type SaveChatIntegrationRequest struct {
+	WebhookURL       *string           `json:"webhook_url,omitempty" example:"https://discord.com/api/webhooks/123456789/abcdef"`   // 新しく連携する場合は必須
+	Events           []string          `json:"events,omitempty" example:"TASK_COMPLETED,TASK_ASSIGNED,TASK_DUE_SOON,DAILY_SUMMARY"` // 投稿する出来事（省略時は変更しない。新しく連携する場合はすべて）
+	Templates        map[string]string `json:"templates,omitempty"`                                                                 // 出来事ごとのメッセージのテンプレート（空文字列でデフォルトに戻す）
+	SummaryHour      *int              `json:"summary_hour,omitempty" binding:"omitempty,min=0,max=23" example:"9"`                 // 1日のまとめを投稿する時（省略時は9時）
+	Timezone         *string           `json:"timezone,omitempty" example:"Asia/Tokyo"`                                             // 1日のまとめの時刻・期限の表示のタイムゾーン（省略時はUTC）
+	Enabled          *bool             `json:"enabled,omitempty" example:"true"`
+	DueReminderHours *int              `json:"due_reminder_hours,omitempty" binding:"omitempty,min=1,max=168" example:"24"` // 期限の何時間前にリマインダーを投稿するか（省略時は24時間）
+} // @name SaveChatIntegrationRequest
+
 // === レスポンスDTO ===
 
 type GroupResponse struct {
-	ID          uuid.UUID            `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Name        string               `json:"name" example:"プロジェクトチーム"`
-	Description string               `json:"description" example:"新製品開発プロジェクトのチーム"`
-	Type        string               `json:"type" example:"PROJECT"`
-	OwnerID     uuid.UUID            `json:"owner_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Settings    domain.GroupSettings `json:"settings"`
-	MemberCount int                  `json:"member_count" example:"5"`
-	CreatedAt   time.Time            `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt   time.Time            `json:"updated_at" example:"2024-01-01T00:00:00Z"`
-	Version     int                  `json:"version" example:"1"`
+	ID            uuid.UUID            `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name          string               `json:"name" example:"プロジェクトチーム"`
+	Description   string               `json:"description" example:"新製品開発プロジェクトのチーム"`
+	Type          string               `json:"type" example:"PROJECT"`
+	OwnerID       uuid.UUID            `json:"owner_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ParentGroupID *uuid.UUID           `json:"parent_group_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Settings      domain.GroupSettings `json:"settings"`
+	MemberCount   int                  `json:"member_count" example:"5"`
+	CreatedAt     time.Time            `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt     time.Time            `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	Version       int                  `json:"version" example:"1"`
 } // @name GroupResponse
 
 type GroupWithMembersResponse struct {
@@ -71,13 +159,261 @@ type MemberListResponse struct {
 	Members []MemberWithUserResponse `json:"members"`
 } // @name MemberListResponse
 
+type BulkAddMemberResult struct {
+	UserID uuid.UUID `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status string    `json:"status" enums:"ADDED,ALREADY_MEMBER,NOT_FOUND,BLOCKED" example:"ADDED"`
+} // @name BulkAddMemberResult
+
+type BulkAddMembersResponse struct {
+	Results    []BulkAddMemberResult `json:"results"`
+	AddedCount int                   `json:"added_count" example:"3"`
+} // @name BulkAddMembersResponse
+
 type GroupStatsResponse struct {
 	MemberCount   int `json:"member_count" example:"5"`
 	TaskCount     int `json:"task_count,omitempty" example:"10"`
 	ScheduleCount int `json:"schedule_count,omitempty" example:"3"`
 	ActiveMembers int `json:"active_members" example:"4"`
+
+	Attendance []MemberAttendanceResponse `json:"attendance,omitempty"` // 予定共有グループの場合
 } // @name GroupStatsResponse
 
+type MemberAttendanceResponse struct {
+	UserID         uuid.UUID `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Events         int       `json:"events" example:"8"` // 集計した開始済みの予定の数
+	Yes            int       `json:"yes" example:"6"`
+	No             int       `json:"no" example:"1"`
+	Maybe          int       `json:"maybe" example:"0"`
+	NoResponse     int       `json:"no_response" example:"1"`
+	Attended       int       `json:"attended" example:"5"`
+	Absent         int       `json:"absent" example:"1"`
+	AttendanceRate *float64  `json:"attendance_rate" example:"83.3"` // 出席・欠席を記録した予定のうち出席した割合、記録がない場合はnull
+} // @name MemberAttendanceResponse
+
+type GroupTreeResponse struct {
+	Group    GroupResponse       `json:"group"`
+	Stats    GroupStatsResponse  `json:"stats"`
+	Total    GroupStatsResponse  `json:"total"` // サブチームを含めた統計
+	Children []GroupTreeResponse `json:"children"`
+} // @name GroupTreeResponse
+
+type GroupPermission struct {
+	Action       string   `json:"action" example:"CREATE_TASKS"`
+	Roles        []string `json:"roles" example:"ADMIN,MEMBER"` // オーナーは常に許可される
+	Configurable bool     `json:"configurable" example:"true"`  // グループごとに変更できるか
+} // @name GroupPermission
+
+type GroupPermissionsResponse struct {
+	Permissions []GroupPermission `json:"permissions"`
+} // @name GroupPermissionsResponse
+
+type CustomRoleResponse struct {
+	ID          uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"` // メンバーに割り当てるときのロール値
+	Name        string    `json:"name" example:"タスク管理者"`
+	Permissions []string  `json:"permissions" example:"CREATE_TASKS,EDIT_TASKS"`
+	CreatedAt   time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name CustomRoleResponse
+
+type CustomRolesResponse struct {
+	Roles []CustomRoleResponse `json:"roles"`
+} // @name CustomRolesResponse
+
+type AssignmentSettingsResponse struct {
+	GroupID        uuid.UUID  `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Policy         string     `json:"policy" example:"ROUND_ROBIN"`
+	LastAssigneeID *uuid.UUID `json:"last_assignee_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // 直前に自動割り当てしたメンバー
+	UpdatedAt      time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name AssignmentSettingsResponse
+
+type LeaderboardEntryResponse struct {
+	Rank           int        `json:"rank" example:"1"`
+	UserID         *uuid.UUID `json:"user_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // 匿名のメンバーは本人以外には返さない
+	DisplayName    string     `json:"display_name" example:"user123"`
+	Anonymous      bool       `json:"anonymous" example:"false"`
+	IsMe           bool       `json:"is_me" example:"true"`
+	CompletedTasks int        `json:"completed_tasks" example:"12"`
+	DueTasks       int        `json:"due_tasks" example:"10"`
+	OnTimeTasks    int        `json:"on_time_tasks" example:"9"`
+	OnTimeRate     *float64   `json:"on_time_rate" example:"90"` // 期限のあるタスクがない場合はnull
+	CurrentStreak  int        `json:"current_streak" example:"5"`
+} // @name LeaderboardEntryResponse
+
+type LeaderboardResponse struct {
+	GroupID     uuid.UUID                   `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	WeekStart   time.Time                   `json:"week_start" example:"2024-01-01T00:00:00+09:00"`
+	WeekEnd     time.Time                   `json:"week_end" example:"2024-01-08T00:00:00+09:00"` // この時刻に集計がリセットされる
+	Timezone    string                      `json:"timezone" example:"Asia/Tokyo"`
+	GeneratedAt time.Time                   `json:"generated_at" example:"2024-01-03T12:00:00Z"`
+	Entries     []*LeaderboardEntryResponse `json:"entries"`
+} // @name LeaderboardResponse
+
+type LeaderboardSettingsResponse struct {
+	GroupID      uuid.UUID `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Enabled      bool      `json:"enabled" example:"true"`
+	Timezone     string    `json:"timezone" example:"Asia/Tokyo"`
+	MyVisibility string    `json:"my_visibility,omitempty" example:"VISIBLE"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name LeaderboardSettingsResponse
+
+type ScheduleCardSettingsResponse struct {
+	GroupID    uuid.UUID `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Enabled    bool      `json:"enabled" example:"true"`
+	Timezone   string    `json:"timezone" example:"Asia/Tokyo"`
+	ShowTitles bool      `json:"show_titles" example:"true"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name ScheduleCardSettingsResponse
+
+type ScheduleCardLinkResponse struct {
+	GroupID   uuid.UUID `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StartDate string    `json:"start_date" example:"2024-06-03"` // カードの開始日（この日から1週間）
+	ImageURL  string    `json:"image_url" example:"https://api.example.com/api/v1/public/groups/123e4567-e89b-12d3-a456-426614174000/schedule-card?expires=1718582400&format=svg&signature=...&start=2024-06-03"`
+	PDFURL    string    `json:"pdf_url" example:"https://api.example.com/api/v1/public/groups/123e4567-e89b-12d3-a456-426614174000/schedule-card?expires=1718582400&format=pdf&signature=...&start=2024-06-03"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-06-17T00:00:00Z"`
+} // @name ScheduleCardLinkResponse
+
+type LeaderboardVisibilityResponse struct {
+	GroupID    uuid.UUID `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID     uuid.UUID `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Visibility string    `json:"visibility" example:"ANONYMOUS"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name LeaderboardVisibilityResponse
+
+type EventRSVPResponse struct {
+	GroupID     uuid.UUID  `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventID     string     `json:"event_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID      uuid.UUID  `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status      string     `json:"status" example:"YES"` // YES・NO・MAYBE、回答せずに出席だけ記録した場合は NO_RESPONSE
+	Attended    *bool      `json:"attended,omitempty" example:"true"`
+	RespondedAt *time.Time `json:"responded_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name EventRSVPResponse
+
+type GroupEventResponse struct {
+	ID               string                 `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"` // タスクID（繰り返しの予定は回のID）
+	GroupID          uuid.UUID              `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title            string                 `json:"title" example:"定例ミーティング"`
+	StartsAt         time.Time              `json:"starts_at" example:"2024-01-01T10:00:00Z"`
+	CreatedBy        uuid.UUID              `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	SeriesID         string                 `json:"series_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // 繰り返しの予定の最初の回の予定（タスク）のID
+	OriginalStartsAt *time.Time             `json:"original_starts_at,omitempty" example:"2024-01-01T10:00:00Z"`        // 繰り返しの予定の回の元の開始日時
+	Moved            bool                   `json:"moved,omitempty" example:"false"`                                    // 日時を変更した回か
+	Location         *EventLocationResponse `json:"location,omitempty"`                                                 // 予定のタスクに設定した場所
+} // @name GroupEventResponse
+
+type EventLocationResponse struct {
+	Name      string  `json:"name" example:"東京駅"`
+	Latitude  float64 `json:"latitude" example:"35.681236"`
+	Longitude float64 `json:"longitude" example:"139.767125"`
+} // @name EventLocationResponse
+
+type EventScheduleResponse struct {
+	GroupID   uuid.UUID            `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupName string               `json:"group_name" example:"家族"`
+	From      time.Time            `json:"from" example:"2024-01-01T00:00:00Z"`
+	To        time.Time            `json:"to" example:"2024-02-01T00:00:00Z"`
+	Events    []GroupEventResponse `json:"events"`
+} // @name EventScheduleResponse
+
+type EventRecurrenceResponse struct {
+	GroupID   uuid.UUID  `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventID   string     `json:"event_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Frequency string     `json:"frequency" example:"WEEKLY"`
+	Interval  int        `json:"interval" example:"1"`
+	Weekdays  []string   `json:"weekdays,omitempty" example:"MO,WE"`
+	Count     int        `json:"count,omitempty" example:"10"`
+	Until     *time.Time `json:"until,omitempty" example:"2024-12-31T23:59:59Z"`
+	Timezone  string     `json:"timezone" example:"Asia/Tokyo"`
+	// 回が祝日になった場合の扱い（SKIP・NEXT_BUSINESS_DAY、そのまま行う場合は省略）
+	HolidayPolicy string    `json:"holiday_policy,omitempty" example:"NEXT_BUSINESS_DAY"`
+	CreatedBy     uuid.UUID `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name EventRecurrenceResponse
+
+type EventExceptionResponse struct {
+	GroupID          uuid.UUID  `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventID          string     `json:"event_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	OriginalStartsAt time.Time  `json:"original_starts_at" example:"2024-01-08T10:00:00Z"`
+	Cancelled        bool       `json:"cancelled" example:"false"`
+	StartsAt         *time.Time `json:"starts_at,omitempty" example:"2024-01-09T10:00:00Z"`
+	UpdatedBy        uuid.UUID  `json:"updated_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UpdatedAt        time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name EventExceptionResponse
+
+type NoteActionItemResponse struct {
+	TaskID     string     `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Text       string     `json:"text" example:"資料を共有する @alice"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+} // @name NoteActionItemResponse
+
+type EventNoteResponse struct {
+	ID          uuid.UUID                `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupID     uuid.UUID                `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventID     string                   `json:"event_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title       string                   `json:"title" example:"定例会の議事録"`
+	Content     string                   `json:"content" example:"## 決定事項\n- [ ] 資料を共有する @alice"`
+	CreatedBy   uuid.UUID                `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UpdatedBy   uuid.UUID                `json:"updated_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt   time.Time                `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt   time.Time                `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ActionItems []NoteActionItemResponse `json:"action_items"` // 本文のアクションアイテムから作成したタスク
+} // @name EventNoteResponse
+
+type LinkedTaskResponse struct {
+	ID              string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title           string `json:"title" example:"資料を作成する"`
+	Status          string `json:"status" example:"TODO"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty" example:"90"`
+} // @name LinkedTaskResponse
+
+type EventTaskLinkResponse struct {
+	GroupID   uuid.UUID          `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EventID   string             `json:"event_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID    string             `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Kind      string             `json:"kind" example:"BLOCK" enums:"BLOCK,FOLLOW_UP"` // BLOCK: 予定がタスクの作業時間、FOLLOW_UP: タスクが予定のフォローアップ
+	CreatedBy uuid.UUID          `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt time.Time          `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	Task      LinkedTaskResponse `json:"task"`
+} // @name EventTaskLinkResponse
+
+type EventDetailResponse struct {
+	Event GroupEventResponse      `json:"event"`
+	Links []EventTaskLinkResponse `json:"links"` // 関連付けたタスク（作成日時の古い順）
+} // @name EventDetailResponse
+
+type EventAttendeeResponse struct {
+	UserID      uuid.UUID  `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status      string     `json:"status" example:"NO_RESPONSE"`
+	Attended    *bool      `json:"attended,omitempty" example:"true"`
+	RespondedAt *time.Time `json:"responded_at,omitempty" example:"2024-01-01T00:00:00Z"`
+} // @name EventAttendeeResponse
+
+type EventAttendeesResponse struct {
+	Event      GroupEventResponse      `json:"event"`
+	Attendees  []EventAttendeeResponse `json:"attendees"`
+	Yes        int                     `json:"yes" example:"3"`
+	No         int                     `json:"no" example:"1"`
+	Maybe      int                     `json:"maybe" example:"1"`
+	NoResponse int                     `json:"no_response" example:"2"`
+} // @name EventAttendeesResponse
+
+type ChatIntegrationResponse struct {
+	GroupID          uuid.UUID         `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Platform         string            `json:"platform" example:"DISCORD" enums:"DISCORD,TEAMS"`
+	WebhookURL       string            `json:"webhook_url" example:"https://discord.com/api/webhooks/123456789/****"` // 末尾のトークンを伏せたURL
+	Events           []string          `json:"events" example:"TASK_COMPLETED,TASK_ASSIGNED,TASK_DUE_SOON,DAILY_SUMMARY"`
+	Templates        map[string]string `json:"templates"` // 出来事ごとのメッセージのテンプレート（デフォルトを含む）
+	SummaryHour      int               `json:"summary_hour" example:"9"`
+	Timezone         string            `json:"timezone" example:"Asia/Tokyo"`
+	Enabled          bool              `json:"enabled" example:"true"`
+	LastSummaryDate  string            `json:"last_summary_date,omitempty" example:"2024-01-01"`
+	DueReminderHours int               `json:"due_reminder_hours" example:"24"`
+	CreatedBy        uuid.UUID         `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt        time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt        time.Time         `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name ChatIntegrationResponse
+
 type PaginationInfo struct {
 	Page       int `json:"page" example:"1"`
 	PageSize   int `json:"page_size" example:"10"`
@@ -96,16 +432,17 @@ type UserInfo struct {
 
 func ToGroupResponse(group *domain.Group) *GroupResponse {
 	return &GroupResponse{
-		ID:          group.ID,
-		Name:        group.Name,
-		Description: group.Description,
-		Type:        string(group.Type),
-		OwnerID:     group.OwnerID,
-		Settings:    group.Settings,
-		MemberCount: group.MemberCount,
-		CreatedAt:   group.CreatedAt,
-		UpdatedAt:   group.UpdatedAt,
-		Version:     group.Version,
+		ID:            group.ID,
+		Name:          group.Name,
+		Description:   group.Description,
+		Type:          string(group.Type),
+		OwnerID:       group.OwnerID,
+		ParentGroupID: group.ParentGroupID,
+		Settings:      group.Settings,
+		MemberCount:   group.MemberCount,
+		CreatedAt:     group.CreatedAt,
+		UpdatedAt:     group.UpdatedAt,
+		Version:       group.Version,
 	}
 }
 
@@ -187,13 +524,377 @@ func ToMemberListResponse(members []*groupUsecase.MemberWithUserInfo) *MemberLis
 	}
 }
 
+func ToBulkAddMembersResponse(results []*groupUsecase.BulkAddMemberResult) *BulkAddMembersResponse {
+	response := &BulkAddMembersResponse{
+		Results: make([]BulkAddMemberResult, len(results)),
+	}
+	for i, result := range results {
+		response.Results[i] = BulkAddMemberResult{
+			UserID: result.UserID,
+			Status: string(result.Status),
+		}
+		if result.Status == groupUsecase.BulkAddMemberAdded {
+			response.AddedCount++
+		}
+	}
+	return response
+}
+
 func ToGroupStatsResponse(stats *domain.GroupStats) *GroupStatsResponse {
-	return &GroupStatsResponse{
+	response := &GroupStatsResponse{
 		MemberCount:   stats.MemberCount,
 		TaskCount:     stats.TaskCount,
 		ScheduleCount: stats.ScheduleCount,
 		ActiveMembers: stats.ActiveMembers,
 	}
+	for _, attendance := range stats.Attendance {
+		response.Attendance = append(response.Attendance, MemberAttendanceResponse{
+			UserID:         attendance.UserID,
+			Events:         attendance.Events,
+			Yes:            attendance.Yes,
+			No:             attendance.No,
+			Maybe:          attendance.Maybe,
+			NoResponse:     attendance.NoResponse,
+			Attended:       attendance.Attended,
+			Absent:         attendance.Absent,
+			AttendanceRate: attendance.AttendanceRate,
+		})
+	}
+	return response
+}
+
+func ToGroupTreeResponse(tree *groupUsecase.GroupTree) GroupTreeResponse {
+	children := make([]GroupTreeResponse, len(tree.Children))
+	for i, child := range tree.Children {
+		children[i] = ToGroupTreeResponse(child)
+	}
+	return GroupTreeResponse{
+		Group:    *ToGroupResponse(tree.Group),
+		Stats:    *ToGroupStatsResponse(tree.Stats),
+		Total:    *ToGroupStatsResponse(tree.Total),
+		Children: children,
+	}
+}
+
+func ToPermissionMatrix(req UpdateGroupPermissionsRequest) domain.PermissionMatrix {
+	matrix := make(domain.PermissionMatrix, len(req.Permissions))
+	for action, roles := range req.Permissions {
+		memberRoles := make([]domain.MemberRole, len(roles))
+		for i, role := range roles {
+			memberRoles[i] = domain.MemberRole(role)
+		}
+		matrix[domain.GroupAction(action)] = memberRoles
+	}
+	return matrix
+}
+
+func ToGroupPermissionsResponse(matrix domain.PermissionMatrix) *GroupPermissionsResponse {
+	actions := make([]string, 0, len(matrix))
+	for action := range matrix {
+		actions = append(actions, string(action))
+	}
+	sort.Strings(actions)
+
+	response := &GroupPermissionsResponse{
+		Permissions: make([]GroupPermission, len(actions)),
+	}
+	for i, action := range actions {
+		roles := matrix[domain.GroupAction(action)]
+		roleNames := make([]string, len(roles))
+		for j, role := range roles {
+			roleNames[j] = string(role)
+		}
+		response.Permissions[i] = GroupPermission{
+			Action:       action,
+			Roles:        roleNames,
+			Configurable: domain.IsConfigurableAction(domain.GroupAction(action)),
+		}
+	}
+	return response
+}
+
+func ToCustomRoleInput(req CustomRoleRequest) groupUsecase.CustomRoleInput {
+	permissions := make([]domain.GroupAction, len(req.Permissions))
+	for i, action := range req.Permissions {
+		permissions[i] = domain.GroupAction(action)
+	}
+	return groupUsecase.CustomRoleInput{
+		Name:        req.Name,
+		Permissions: permissions,
+	}
+}
+
+func ToCustomRoleResponse(role *domain.CustomRole) *CustomRoleResponse {
+	permissions := make([]string, len(role.Permissions))
+	for i, action := range role.Permissions {
+		permissions[i] = string(action)
+	}
+	return &CustomRoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func ToCustomRolesResponse(roles []*domain.CustomRole) *CustomRolesResponse {
+	response := &CustomRolesResponse{
+		Roles: make([]CustomRoleResponse, len(roles)),
+	}
+	for i, role := range roles {
+		response.Roles[i] = *ToCustomRoleResponse(role)
+	}
+	return response
+}
+
+func ToAssignmentSettingsResponse(settings *domain.AssignmentSettings) *AssignmentSettingsResponse {
+	return &AssignmentSettingsResponse{
+		GroupID:        settings.GroupID,
+		Policy:         string(settings.Policy),
+		LastAssigneeID: settings.LastAssigneeID,
+		UpdatedAt:      settings.UpdatedAt,
+	}
+}
+
+func ToLeaderboardResponse(board *domain.Leaderboard) *LeaderboardResponse {
+	entries := make([]*LeaderboardEntryResponse, len(board.Entries))
+	for i, entry := range board.Entries {
+		entries[i] = &LeaderboardEntryResponse{
+			Rank:           entry.Rank,
+			UserID:         entry.UserID,
+			DisplayName:    entry.DisplayName,
+			Anonymous:      entry.Anonymous,
+			IsMe:           entry.IsMe,
+			CompletedTasks: entry.CompletedTasks,
+			DueTasks:       entry.DueTasks,
+			OnTimeTasks:    entry.OnTimeTasks,
+			OnTimeRate:     entry.OnTimeRate,
+			CurrentStreak:  entry.CurrentStreak,
+		}
+	}
+	return &LeaderboardResponse{
+		GroupID:     board.GroupID,
+		WeekStart:   board.WeekStart,
+		WeekEnd:     board.WeekEnd,
+		Timezone:    board.Timezone,
+		GeneratedAt: board.GeneratedAt,
+		Entries:     entries,
+	}
+}
+
+func ToLeaderboardSettingsResponse(settings *domain.LeaderboardSettings, myVisibility domain.LeaderboardVisibility) *LeaderboardSettingsResponse {
+	return &LeaderboardSettingsResponse{
+		GroupID:      settings.GroupID,
+		Enabled:      settings.Enabled,
+		Timezone:     settings.Timezone,
+		MyVisibility: string(myVisibility),
+		UpdatedAt:    settings.UpdatedAt,
+	}
+}
+
+func ToScheduleCardSettingsResponse(settings *domain.ScheduleCardSettings) *ScheduleCardSettingsResponse {
+	return &ScheduleCardSettingsResponse{
+		GroupID:    settings.GroupID,
+		Enabled:    settings.Enabled,
+		Timezone:   settings.Timezone,
+		ShowTitles: settings.ShowTitles,
+		UpdatedAt:  settings.UpdatedAt,
+	}
+}
+
+func ToScheduleCardLinkResponse(link *domain.ScheduleCardLink, imageURL, pdfURL string) *ScheduleCardLinkResponse {
+	return &ScheduleCardLinkResponse{
+		GroupID:   link.GroupID,
+		StartDate: link.StartDate,
+		ImageURL:  imageURL,
+		PDFURL:    pdfURL,
+		ExpiresAt: link.ExpiresAt,
+	}
+}
+
+func ToLeaderboardVisibilityResponse(preference *domain.LeaderboardPreference) *LeaderboardVisibilityResponse {
+	return &LeaderboardVisibilityResponse{
+		GroupID:    preference.GroupID,
+		UserID:     preference.UserID,
+		Visibility: string(preference.Visibility),
+		UpdatedAt:  preference.UpdatedAt,
+	}
+}
+
+func ToEventRSVPResponse(rsvp *domain.EventRSVP) *EventRSVPResponse {
+	return &EventRSVPResponse{
+		GroupID:     rsvp.GroupID,
+		EventID:     rsvp.EventID,
+		UserID:      rsvp.UserID,
+		Status:      string(rsvp.Status),
+		Attended:    rsvp.Attended,
+		RespondedAt: rsvp.RespondedAt,
+		UpdatedAt:   rsvp.UpdatedAt,
+	}
+}
+
+func ToEventAttendeesResponse(attendees *domain.EventAttendees) *EventAttendeesResponse {
+	response := &EventAttendeesResponse{
+		Event:      toGroupEventResponse(attendees.Event),
+		Attendees:  make([]EventAttendeeResponse, len(attendees.Attendees)),
+		Yes:        attendees.Yes,
+		No:         attendees.No,
+		Maybe:      attendees.Maybe,
+		NoResponse: attendees.NoResponse,
+	}
+	for i, attendee := range attendees.Attendees {
+		response.Attendees[i] = EventAttendeeResponse{
+			UserID:      attendee.UserID,
+			Status:      string(attendee.Status),
+			Attended:    attendee.Attended,
+			RespondedAt: attendee.RespondedAt,
+		}
+	}
+	return response
+}
+
+func toGroupEventResponse(event *domain.GroupEvent) GroupEventResponse {
+	response := GroupEventResponse{
+		ID:               event.ID,
+		GroupID:          event.GroupID,
+		Title:            event.Title,
+		StartsAt:         event.StartsAt,
+		CreatedBy:        event.CreatedBy,
+		SeriesID:         event.SeriesID,
+		OriginalStartsAt: event.OriginalStartsAt,
+		Moved:            event.Moved,
+	}
+	if event.Location != nil {
+		response.Location = &EventLocationResponse{
+			Name:      event.Location.Name,
+			Latitude:  event.Location.Latitude,
+			Longitude: event.Location.Longitude,
+		}
+	}
+	return response
+}
+
+func ToEventScheduleResponse(schedule *domain.EventSchedule) *EventScheduleResponse {
+	response := &EventScheduleResponse{
+		GroupID:   schedule.GroupID,
+		GroupName: schedule.GroupName,
+		From:      schedule.From,
+		To:        schedule.To,
+		Events:    make([]GroupEventResponse, len(schedule.Events)),
+	}
+	for i, event := range schedule.Events {
+		response.Events[i] = toGroupEventResponse(event)
+	}
+	return response
+}
+
+func ToEventRecurrenceResponse(recurrence *domain.EventRecurrence) *EventRecurrenceResponse {
+	return &EventRecurrenceResponse{
+		GroupID:       recurrence.GroupID,
+		EventID:       recurrence.EventID,
+		Frequency:     string(recurrence.Frequency),
+		Interval:      recurrence.Interval,
+		Weekdays:      recurrence.Weekdays,
+		Count:         recurrence.Count,
+		Until:         recurrence.Until,
+		Timezone:      recurrence.Timezone,
+		HolidayPolicy: string(recurrence.HolidayPolicy),
+		CreatedBy:     recurrence.CreatedBy,
+		UpdatedAt:     recurrence.UpdatedAt,
+	}
+}
+
+func ToEventNoteResponse(note *domain.EventNote) *EventNoteResponse {
+	items := make([]NoteActionItemResponse, len(note.ActionItems))
+	for i, item := range note.ActionItems {
+		items[i] = NoteActionItemResponse{
+			TaskID:     item.TaskID,
+			Text:       item.Text,
+			AssigneeID: item.AssigneeID,
+			CreatedAt:  item.CreatedAt,
+		}
+	}
+	return &EventNoteResponse{
+		ID:          note.ID,
+		GroupID:     note.GroupID,
+		EventID:     note.EventID,
+		Title:       note.Title,
+		Content:     note.Content,
+		CreatedBy:   note.CreatedBy,
+		UpdatedBy:   note.UpdatedBy,
+		CreatedAt:   note.CreatedAt,
+		UpdatedAt:   note.UpdatedAt,
+		ActionItems: items,
+	}
+}
+
+func ToEventTaskLinkResponse(link *domain.EventTaskLink) *EventTaskLinkResponse {
+	response := &EventTaskLinkResponse{
+		GroupID:   link.GroupID,
+		EventID:   link.EventID,
+		TaskID:    link.TaskID,
+		Kind:      string(link.Kind),
+		CreatedBy: link.CreatedBy,
+		CreatedAt: link.CreatedAt,
+	}
+	if link.Task != nil {
+		response.Task = LinkedTaskResponse{
+			ID:              link.Task.ID,
+			Title:           link.Task.Title,
+			Status:          link.Task.Status,
+			EstimateMinutes: link.Task.EstimateMinutes,
+		}
+	}
+	return response
+}
+
+func ToEventDetailResponse(detail *domain.EventDetail) *EventDetailResponse {
+	response := &EventDetailResponse{
+		Event: toGroupEventResponse(detail.Event),
+		Links: make([]EventTaskLinkResponse, len(detail.Links)),
+	}
+	for i, link := range detail.Links {
+		response.Links[i] = *ToEventTaskLinkResponse(link)
+	}
+	return response
+}
+
+func ToEventExceptionResponse(exception *domain.EventException) *EventExceptionResponse {
+	return &EventExceptionResponse{
+		GroupID:          exception.GroupID,
+		EventID:          exception.EventID,
+		OriginalStartsAt: exception.OriginalStartsAt,
+		Cancelled:        exception.Cancelled,
+		StartsAt:         exception.StartsAt,
+		UpdatedBy:        exception.UpdatedBy,
+		UpdatedAt:        exception.UpdatedAt,
+	}
+}
+
+func ToChatIntegrationResponse(integration *domain.ChatIntegration) *ChatIntegrationResponse {
+	response := &ChatIntegrationResponse{
+		GroupID:          integration.GroupID,
+		Platform:         string(integration.Platform),
+		WebhookURL:       integration.MaskedWebhookURL(),
+		Events:           make([]string, 0, len(integration.Events)),
+		Templates:        make(map[string]string, len(domain.ChatEvents)),
+		SummaryHour:      integration.SummaryHour,
+		Timezone:         integration.Timezone,
+		Enabled:          integration.Enabled,
+		LastSummaryDate:  integration.LastSummaryDate,
+		DueReminderHours: integration.DueReminderHours,
+		CreatedBy:        integration.CreatedBy,
+		CreatedAt:        integration.CreatedAt,
+		UpdatedAt:        integration.UpdatedAt,
+	}
+	for _, event := range integration.Events {
+		response.Events = append(response.Events, string(event))
+	}
+	for _, event := range domain.ChatEvents {
+		response.Templates[string(event)] = integration.Template(event)
+	}
+	return response
 }
 
 // === 共通レスポンス ===
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// maxAssignmentCandidates は自動割り当てで候補にするメンバー数の上限
+const maxAssignmentCandidates = 1000
+
+// ErrInvalidAssignmentPolicy は自動割り当て方式が不正であることを表すエラー
+var ErrInvalidAssignmentPolicy = errors.New("invalid assignment policy")
+
+// OpenTaskCounter はユーザーごとの未完了タスク数を数えるインターフェース（タスクモジュールとの連携）
+type OpenTaskCounter interface {
+	CountOpenTasks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+// GetAssignmentSettings はグループの自動割り当て設定を取得する（メンバーのみ）
+func (s *groupService) GetAssignmentSettings(ctx context.Context, groupID, requesterID uuid.UUID) (*domain.AssignmentSettings, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	return s.getAssignmentSettings(ctx, groupID)
+}
+
+// UpdateAssignmentPolicy はグループの自動割り当て方式を変更する（グループ編集の権限が必要）
+func (s *groupService) UpdateAssignmentPolicy(ctx context.Context, groupID, requesterID uuid.UUID, policy domain.AssignmentPolicy) (*domain.AssignmentSettings, error) {
+	if !policy.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAssignmentPolicy, policy)
+	}
+
+	canEdit, err := s.CheckPermission(ctx, groupID, requesterID, ActionEditGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canEdit {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getAssignmentSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	settings.Policy = policy
+	settings.UpdatedAt = time.Now()
+
+	if err := s.saveAssignmentSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Group assignment policy updated",
+		logger.Any("groupID", groupID),
+		logger.Any("policy", policy))
+	return settings, nil
+}
+
+// PickTaskAssignee はグループの自動割り当て方式で新しいタスクの担当者を選ぶ（タスク作成の権限が必要）
+// ゲストは候補にしない。自動割り当てが無効または候補がいない場合は nil を返す
+// counter は最少負荷方式でのみ使用する
+func (s *groupService) PickTaskAssignee(ctx context.Context, groupID, requesterID uuid.UUID, counter OpenTaskCounter) (*uuid.UUID, error) {
+	canCreate, err := s.CheckPermission(ctx, groupID, requesterID, ActionCreateTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canCreate {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getAssignmentSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.IsEnabled() {
+		return nil, nil
+	}
+
+	members, err := s.groupRepo.ListMembers(ctx, groupID, commonDomain.Pagination{Page: 1, PageSize: maxAssignmentCandidates})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+	candidates := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		if member.Role != domain.RoleGuest {
+			candidates = append(candidates, member.UserID)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var openTasks map[uuid.UUID]int
+	if settings.Policy == domain.AssignmentPolicyLeastLoaded && counter != nil {
+		openTasks, err = counter.CountOpenTasks(ctx, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count open tasks: %w", err)
+		}
+	}
+
+	assigneeID, ok := settings.PickAssignee(candidates, openTasks)
+	if !ok {
+		return nil, nil
+	}
+	if err := s.saveAssignmentSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Group task assignee picked",
+		logger.Any("groupID", groupID),
+		logger.Any("policy", settings.Policy),
+		logger.Any("assigneeID", assigneeID))
+	return &assigneeID, nil
+}
+
+// getAssignmentSettings は自動割り当て設定を取得する（未設定の場合は自動割り当てをしない設定）
+func (s *groupService) getAssignmentSettings(ctx context.Context, groupID uuid.UUID) (*domain.AssignmentSettings, error) {
+	settings, err := s.groupRepo.GetAssignmentSettings(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment settings: %w", err)
+	}
+	if settings == nil {
+		return domain.NewAssignmentSettings(groupID), nil
+	}
+	return settings, nil
+}
+
+func (s *groupService) saveAssignmentSettings(ctx context.Context, settings *domain.AssignmentSettings) error {
+	if err := s.groupRepo.SaveAssignmentSettings(ctx, settings); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save assignment settings", logger.Error(err))
+		return fmt.Errorf("failed to save assignment settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package card
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/pdf"
+)
+
+// チャットのリンクプレビューで使われる比率（1.91:1）の画像サイズと配置（px）
+const (
+	svgWidth      = 1200
+	svgHeight     = 630
+	svgMargin     = 40
+	svgHeaderH    = 90
+	svgColumnGap  = 8
+	svgDayHeaderH = 48
+	svgEventH     = 86
+	svgFontFamily = "'Hiragino Sans','Noto Sans JP','Yu Gothic',sans-serif"
+
+	// maxEventTitleWidth は列に収まる予定のタイトルの幅（全角の文字数）
+	maxEventTitleWidth = 7
+)
+
+// weekdays は曜日の表記（time.Weekday の順）
+var weekdays = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// Title はカードのタイトル（グループ名と期間）を返す
+func Title(card *domain.ScheduleCard) string {
+	return fmt.Sprintf("%s の予定（%s〜%s）", card.GroupName, dayLabel(card, 0), dayLabel(card, len(card.Days)-1))
+}
+
+// SVG はスケジュールカードを 1200x630 の画像（SVG）で出力する
+// 1週間を7列に並べ、列ごとに日付と予定（開始時刻とタイトル）を表示する
+func SVG(card *domain.ScheduleCard) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s">`,
+		svgWidth, svgHeight, svgWidth, svgHeight, svgFontFamily)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#f7f8fa"/>`, svgWidth, svgHeight)
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="32" font-weight="bold" fill="#1f2933">%s</text>`,
+		svgMargin, svgMargin+32, escape(truncate(card.GroupName, 30)))
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="20" fill="#616e7c">%s〜%s</text>`,
+		svgMargin, svgMargin+64, escape(dayLabel(card, 0)), escape(dayLabel(card, len(card.Days)-1)))
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="18" fill="#9aa5b1" text-anchor="end">Yotei+</text>`,
+		svgWidth-svgMargin, svgMargin+32)
+
+	columns := len(card.Days)
+	columnW := (svgWidth - 2*svgMargin - (columns-1)*svgColumnGap) / columns
+	top := svgMargin + svgHeaderH
+	bottom := svgHeight - svgMargin
+	for i, day := range card.Days {
+		x := svgMargin + i*(columnW+svgColumnGap)
+		headerFill := "#3e4c59"
+		if day.Date.Weekday() == 0 || day.Date.Weekday() == 6 {
+			headerFill = "#7b8794"
+		}
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" rx="8" fill="#ffffff" stroke="#e4e7eb"/>`, x, top, columnW, bottom-top)
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" rx="8" fill="%s"/>`, x, top, columnW, svgDayHeaderH, headerFill)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="20" font-weight="bold" fill="#ffffff" text-anchor="middle">%s</text>`,
+			x+columnW/2, top+32, escape(dayLabel(card, i)))
+
+		y := top + svgDayHeaderH + 12
+		if len(day.Events) == 0 && day.More == 0 {
+			fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="16" fill="#9aa5b1" text-anchor="middle">予定なし</text>`, x+columnW/2, y+28)
+		}
+		for _, event := range day.Events {
+			fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#e3f2fd"/>`, x+6, y, columnW-12, svgEventH-10)
+			fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="16" font-weight="bold" fill="#1565c0">%s</text>`, x+14, y+26, escape(event.Time))
+			fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="16" fill="#1f2933">%s</text>`, x+14, y+54, escape(truncate(event.Title, maxEventTitleWidth)))
+			y += svgEventH
+		}
+		if day.More > 0 {
+			fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="16" fill="#616e7c" text-anchor="middle">+%d件</text>`, x+columnW/2, y+22, day.More)
+		}
+	}
+
+	sb.WriteString(`</svg>`)
+	return []byte(sb.String())
+}
+
+// PDF はスケジュールカードをA4のPDFで出力する（日ごとに予定を一覧にする）
+func PDF(card *domain.ScheduleCard) []byte {
+	doc := pdf.New(Title(card))
+
+	doc.Text(card.GroupName+" の予定", 18)
+	doc.Text(dayLabel(card, 0)+"〜"+dayLabel(card, len(card.Days)-1), 10)
+	doc.Rule()
+
+	for i, day := range card.Days {
+		doc.Space(8)
+		doc.Text(dayLabel(card, i), 13)
+		if len(day.Events) == 0 && day.More == 0 {
+			doc.Text("予定なし", 11)
+		}
+		for _, event := range day.Events {
+			doc.Text(event.Time+"　"+event.Title, 11)
+		}
+		if day.More > 0 {
+			doc.Text(fmt.Sprintf("ほか%d件", day.More), 11)
+		}
+	}
+
+	doc.Space(8)
+	doc.Rule()
+	doc.Text("Yotei+", 9)
+	return doc.Bytes()
+}
+
+// dayLabel はi日目の日付の表記（例: 6/3(月)）を返す
+func dayLabel(card *domain.ScheduleCard, i int) string {
+	if i < 0 || i >= len(card.Days) {
+		return ""
+	}
+	date := card.Days[i].Date
+	return fmt.Sprintf("%d/%d(%s)", int(date.Month()), date.Day(), weekdays[date.Weekday()])
+}
+
+// truncate は全角を1、半角を0.5として width を超える文字列を「…」で切り詰める
+func truncate(s string, width float64) string {
+	runes := []rune(s)
+	var used float64
+	for i, r := range runes {
+		w := 1.0
+		if r < 0x80 {
+			w = 0.5
+		}
+		if used+w > width {
+			return string(runes[:i]) + "…"
+		}
+		used += w
+	}
+	return s
+}
+
+// escape はSVGのテキストとして出力できるようにエスケープする（XMLで使えない制御文字は取り除く）
+func escape(s string) string {
+	return html.EscapeString(strings.Map(func(r rune) rune {
+		if r < 0x20 {
+			return -1
+		}
+		return r
+	}, s))
+}
@@ -0,0 +1,49 @@
+package card
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+)
+
+func newTestCard() *domain.ScheduleCard {
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	card := &domain.ScheduleCard{GroupName: "Family & <Friends>", Days: make([]domain.ScheduleCardDay, domain.ScheduleCardDays)}
+	for i := range card.Days {
+		card.Days[i].Date = from.AddDate(0, 0, i)
+	}
+	card.Days[0].Events = []domain.ScheduleCardEvent{{Time: "09:00", Title: "朝会"}, {Time: "19:00", Title: "とても長い予定のタイトルです"}}
+	card.Days[2].More = 3
+	return card
+}
+
+func TestSVG(t *testing.T) {
+	out := SVG(newTestCard())
+
+	// 文字列をエスケープした正しいXMLになる
+	assert.NoError(t, xml.Unmarshal(out, new(struct{})))
+	svg := string(out)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="630"`))
+	assert.Contains(t, svg, "Family &amp; &lt;Friends&gt;")
+	assert.Contains(t, svg, "6/3(月)")
+	assert.Contains(t, svg, "6/9(日)")
+	assert.Contains(t, svg, "朝会")
+	assert.Contains(t, svg, "とても長い予定…")
+	assert.Contains(t, svg, "+3件")
+}
+
+func TestPDF(t *testing.T) {
+	out := string(PDF(newTestCard()))
+
+	assert.True(t, strings.HasPrefix(out, "%PDF-"))
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(out), "%%EOF"))
+}
+
+func TestTitle(t *testing.T) {
+	assert.Equal(t, "Family & <Friends> の予定（6/3(月)〜6/9(日)）", Title(newTestCard()))
+}
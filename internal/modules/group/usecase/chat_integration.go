@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	// ErrChatIntegrationNotFound はグループのチャット連携が設定されていないことを表すエラー
+	ErrChatIntegrationNotFound = errors.New("chat integration not found")
+
+	// ErrInvalidChatIntegration はチャット連携の設定が不正であることを表すエラー
+	ErrInvalidChatIntegration = errors.New("invalid chat integration")
+
+	// ErrUnsupportedChatPlatform はチャット連携先に投稿できない（投稿先が設定されていない）ことを表すエラー
+	ErrUnsupportedChatPlatform = errors.New("unsupported chat platform")
+
+	// ErrChatDeliveryFailed はチャットへの投稿に失敗したことを表すエラー
+	ErrChatDeliveryFailed = errors.New("chat delivery failed")
+)
+
+// ChatPoster はチャットのチャンネルのWebhookにメッセージを投稿するインターフェース（連携先ごとに設定する）
+type ChatPoster interface {
+	PostMessage(ctx context.Context, webhookURL string, message *domain.ChatMessage) error
+}
+
+// ChatTaskSource は1日のまとめ・期限のリマインダーに使うグループタスクを取得するインターフェース（タスクモジュールとの連携）
+type ChatTaskSource interface {
+	// ListGroupChatTasks はグループタスクをすべて取得する
+	ListGroupChatTasks(ctx context.Context, groupID uuid.UUID) ([]*domain.ChatTask, error)
+}
+
+// ChatIntegrationInput はチャット連携の設定の入力（nilの項目は変更しない）
+type ChatIntegrationInput struct {
+	WebhookURL       *string // 新しく設定する場合は必須
+	Events           []domain.ChatEvent
+	Templates        map[domain.ChatEvent]string // 空のテンプレートの出来事はデフォルトに戻す
+	SummaryHour      *int
+	Timezone         *string
+	Enabled          *bool
+	DueReminderHours *int
+}
+
+// SetChatPoster は連携先のチャットへの投稿先を設定する
+func (s *groupService) SetChatPoster(platform domain.ChatPlatform, poster ChatPoster) {
+	if s.chatPosters == nil {
+		s.chatPosters = make(map[domain.ChatPlatform]ChatPoster)
+	}
+	s.chatPosters[platform] = poster
+}
+
+// SetChatTaskSource は1日のまとめ・期限のリマインダーに使うグループタスクの取得元を設定する
+func (s *groupService) SetChatTaskSource(source ChatTaskSource) {
+	s.chatTasks = source
+}
+
+// ListChatIntegrations はグループのチャット連携を取得する（グループ編集の権限が必要）
+func (s *groupService) ListChatIntegrations(ctx context.Context, groupID, requesterID uuid.UUID) ([]*domain.ChatIntegration, error) {
+	if err := s.authorizeChatIntegration(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+
+	integrations, err := s.groupRepo.ListChatIntegrations(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// SaveChatIntegration はグループのチャット連携を設定する（グループ編集の権限が必要）
+// 新しく設定する場合はWebhookのURLが必須で、すべての出来事を投稿する
+func (s *groupService) SaveChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform, requesterID uuid.UUID, input ChatIntegrationInput) (*domain.ChatIntegration, error) {
+	if !platform.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported platform: %s", ErrInvalidChatIntegration, platform)
+	}
+	if err := s.authorizeChatIntegration(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.groupRepo.GetChatIntegration(ctx, groupID, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat integration: %w", err)
+	}
+	if integration == nil {
+		if input.WebhookURL == nil {
+			return nil, fmt.Errorf("%w: webhook_url is required", ErrInvalidChatIntegration)
+		}
+		integration = domain.NewChatIntegration(groupID, platform, *input.WebhookURL, requesterID)
+	}
+
+	if input.WebhookURL != nil {
+		integration.WebhookURL = *input.WebhookURL
+	}
+	if input.Events != nil {
+		integration.Events = input.Events
+	}
+	if input.Templates != nil {
+		if integration.Templates == nil {
+			integration.Templates = make(map[domain.ChatEvent]string)
+		}
+		for event, text := range input.Templates {
+			integration.Templates[event] = text
+		}
+	}
+	if input.SummaryHour != nil {
+		integration.SummaryHour = *input.SummaryHour
+	}
+	if input.Timezone != nil {
+		integration.Timezone = *input.Timezone
+	}
+	if input.Enabled != nil {
+		integration.Enabled = *input.Enabled
+	}
+	if input.DueReminderHours != nil {
+		integration.DueReminderHours = *input.DueReminderHours
+	}
+	if err := integration.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChatIntegration, err)
+	}
+	integration.UpdatedAt = time.Now()
+
+	if err := s.groupRepo.SaveChatIntegration(ctx, integration); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save chat integration", logger.Error(err))
+		return nil, fmt.Errorf("failed to save chat integration: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Group chat integration saved",
+		logger.Any("groupID", groupID),
+		logger.Any("platform", platform),
+		logger.Any("enabled", integration.Enabled))
+	return integration, nil
+}
+
+// DeleteChatIntegration はグループのチャット連携を解除する（グループ編集の権限が必要）
+func (s *groupService) DeleteChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform, requesterID uuid.UUID) error {
+	if err := s.authorizeChatIntegration(ctx, groupID, requesterID); err != nil {
+		return err
+	}
+
+	integration, err := s.groupRepo.GetChatIntegration(ctx, groupID, platform)
+	if err != nil {
+		return fmt.Errorf("failed to get chat integration: %w", err)
+	}
+	if integration == nil {
+		return ErrChatIntegrationNotFound
+	}
+
+	if err := s.groupRepo.DeleteChatIntegration(ctx, groupID, platform); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to delete chat integration", logger.Error(err))
+		return fmt.Errorf("failed to delete chat integration: %w", err)
+	}
+	return nil
+}
+
+// TestChatIntegration はグループのチャット連携にテストのメッセージを投稿する（グループ編集の権限が必要）
+// 無効にした連携にも投稿する
+func (s *groupService) TestChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform, requesterID uuid.UUID) error {
+	if err := s.authorizeChatIntegration(ctx, groupID, requesterID); err != nil {
+		return err
+	}
+
+	integration, err := s.groupRepo.GetChatIntegration(ctx, groupID, platform)
+	if err != nil {
+		return fmt.Errorf("failed to get chat integration: %w", err)
+	}
+	if integration == nil {
+		return ErrChatIntegrationNotFound
+	}
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil {
+		return ErrChatIntegrationNotFound
+	}
+
+	message := &domain.ChatMessage{Text: fmt.Sprintf("🔔 Yotei+ の **%s** との連携のテストです", group.Name)}
+	return s.postChatMessage(ctx, integration, message)
+}
+
+// NotifyChatTaskCompleted はグループタスクの完了をグループのチャット連携に投稿する
+// 投稿できなかった連携はログに記録し、エラーは返さない
+func (s *groupService) NotifyChatTaskCompleted(ctx context.Context, groupID uuid.UUID, task *domain.ChatTask) error {
+	return s.notifyChatTask(ctx, groupID, domain.ChatEventTaskCompleted, task, task.AssigneeIDs)
+}
+
+// NotifyChatTaskAssigned はグループタスクへの担当者の割り当てをグループのチャット連携に投稿する
+// 投稿できなかった連携はログに記録し、エラーは返さない
+func (s *groupService) NotifyChatTaskAssigned(ctx context.Context, groupID uuid.UUID, task *domain.ChatTask, assigneeIDs []uuid.UUID) error {
+	return s.notifyChatTask(ctx, groupID, domain.ChatEventTaskAssigned, task, assigneeIDs)
+}
+
+// notifyChatTask はタスクの出来事を投稿する連携に投稿する（担当者はユーザー名で表示する）
+func (s *groupService) notifyChatTask(ctx context.Context, groupID uuid.UUID, event domain.ChatEvent, task *domain.ChatTask, userIDs []uuid.UUID) error {
+	integrations, err := s.groupRepo.ListChatIntegrations(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to list chat integrations: %w", err)
+	}
+	var targets []*domain.ChatIntegration
+	for _, integration := range integrations {
+		if integration.Subscribes(event) {
+			targets = append(targets, integration)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil {
+		return nil
+	}
+	assignees := s.chatUsernames(ctx, userIDs)
+
+	for _, integration := range targets {
+		message, err := integration.Render(event, domain.ChatTaskVars(group.Name, task, assignees, integration.Location()))
+		if err == nil {
+			err = s.postChatMessage(ctx, integration, message)
+		}
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to post task to chat",
+				logger.Any("groupID", groupID),
+				logger.Any("platform", integration.Platform),
+				logger.Any("event", event),
+				logger.Any("taskID", task.ID),
+				logger.Error(err))
+		}
+	}
+	return nil
+}
+
+// SendChatDailySummaries は1日のまとめを投稿する時刻を過ぎた連携に、グループタスクのまとめを投稿する
+// まとめは連携ごとに1日1回だけ投稿し（投稿できなかった場合も投稿済みにする）、投稿した件数を返す
+func (s *groupService) SendChatDailySummaries(ctx context.Context, now time.Time) (int, error) {
+	if s.chatTasks == nil {
+		return 0, nil
+	}
+
+	integrations, err := s.groupRepo.ListEnabledChatIntegrations(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list chat integrations: %w", err)
+	}
+
+	sent := 0
+	for _, integration := range integrations {
+		if !integration.SummaryDue(now) {
+			continue
+		}
+		group, err := s.groupRepo.GetGroupByID(ctx, integration.GroupID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get group: %w", err)
+		}
+		if group == nil {
+			continue
+		}
+		tasks, err := s.chatTasks.ListGroupChatTasks(ctx, integration.GroupID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to list group tasks: %w", err)
+		}
+
+		loc := integration.Location()
+		summary := domain.NewDailySummary(tasks, now, loc)
+		// まとめる内容がない日は投稿しない
+		if !summary.IsEmpty() {
+			message, err := integration.Render(domain.ChatEventDailySummary, summary.Vars(group.Name, loc))
+			if err == nil {
+				err = s.postChatMessage(ctx, integration, message)
+			}
+			if err != nil {
+				s.logger.WithContext(ctx).Warn("Failed to post daily summary to chat",
+					logger.Any("groupID", integration.GroupID),
+					logger.Any("platform", integration.Platform),
+					logger.Error(err))
+			} else {
+				sent++
+			}
+		}
+
+		integration.MarkSummarySent(now)
+		if err := s.groupRepo.SaveChatIntegration(ctx, integration); err != nil {
+			return sent, fmt.Errorf("failed to save chat integration: %w", err)
+		}
+	}
+	return sent, nil
+}
+
+// SendChatDueReminders は期限が近づいたグループタスクのリマインダーを、期限のリマインダーを投稿する連携に投稿する
+// 前回の確認の続きから期限の DueReminderHours 時間前までのタスクを1回だけ投稿し（1回の確認で連携ごとに MaxChatDueReminders 件まで）、投稿した件数を返す
+func (s *groupService) SendChatDueReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.chatTasks == nil {
+		return 0, nil
+	}
+
+	integrations, err := s.groupRepo.ListEnabledChatIntegrations(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list chat integrations: %w", err)
+	}
+
+	sent := 0
+	for _, integration := range integrations {
+		from, to, ok := integration.DueWindow(now)
+		if !ok {
+			continue
+		}
+		group, err := s.groupRepo.GetGroupByID(ctx, integration.GroupID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get group: %w", err)
+		}
+		if group == nil {
+			continue
+		}
+		tasks, err := s.chatTasks.ListGroupChatTasks(ctx, integration.GroupID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to list group tasks: %w", err)
+		}
+
+		due := domain.DueSoonTasks(tasks, from, to)
+		if len(due) > domain.MaxChatDueReminders {
+			s.logger.WithContext(ctx).Warn("Too many due tasks to post to chat",
+				logger.Any("groupID", integration.GroupID),
+				logger.Any("platform", integration.Platform),
+				logger.Any("count", len(due)))
+			due = due[:domain.MaxChatDueReminders]
+		}
+		loc := integration.Location()
+		for _, task := range due {
+			vars := domain.ChatTaskVars(group.Name, task, s.chatUsernames(ctx, task.AssigneeIDs), loc)
+			message, err := integration.Render(domain.ChatEventTaskDueSoon, vars)
+			if err == nil {
+				err = s.postChatMessage(ctx, integration, message)
+			}
+			if err != nil {
+				s.logger.WithContext(ctx).Warn("Failed to post due reminder to chat",
+					logger.Any("groupID", integration.GroupID),
+					logger.Any("platform", integration.Platform),
+					logger.Any("taskID", task.ID),
+					logger.Error(err))
+				continue
+			}
+			sent++
+		}
+
+		integration.MarkDueChecked(to)
+		if err := s.groupRepo.SaveChatIntegration(ctx, integration); err != nil {
+			return sent, fmt.Errorf("failed to save chat integration: %w", err)
+		}
+	}
+	return sent, nil
+}
+
+// authorizeChatIntegration はチャット連携を操作する権限（グループ編集）を確認する
+func (s *groupService) authorizeChatIntegration(ctx context.Context, groupID, requesterID uuid.UUID) error {
+	canEdit, err := s.CheckPermission(ctx, groupID, requesterID, ActionEditGroup)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canEdit {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// postChatMessage は連携先の投稿先にメッセージを投稿する
+func (s *groupService) postChatMessage(ctx context.Context, integration *domain.ChatIntegration, message *domain.ChatMessage) error {
+	poster, ok := s.chatPosters[integration.Platform]
+	if !ok || poster == nil {
+		return fmt.Errorf("%w: %s", ErrUnsupportedChatPlatform, integration.Platform)
+	}
+	if err := poster.PostMessage(ctx, integration.WebhookURL, message); err != nil {
+		return fmt.Errorf("%w: %v", ErrChatDeliveryFailed, err)
+	}
+	return nil
+}
+
+// chatUsernames はユーザーのユーザー名を返す（取得できないユーザーは含めない）
+func (s *groupService) chatUsernames(ctx context.Context, userIDs []uuid.UUID) []string {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+	users, err := s.userValidator.GetUsersInfoBatch(ctx, ids)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to get user info for chat", logger.Error(err))
+		return nil
+	}
+
+	var names []string
+	for _, id := range ids {
+		if user := users[id]; user != nil {
+			names = append(names, user.Username)
+		}
+	}
+	return names
+}
@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// RSVPReminderLead は出欠を回答していないメンバーにリマインダーを送る、予定の開始までの時間
+	RSVPReminderLead = 24 * time.Hour
+	// maxEventMembers は予定の出欠で扱うメンバー数の上限
+	maxEventMembers = 1000
+)
+
+var (
+	// ErrEventNotFound は予定が見つからない（予定共有グループのグループタスクでない・開始日時がない）ことを表すエラー
+	ErrEventNotFound = errors.New("event not found")
+
+	// ErrInvalidRSVP は出欠の回答が不正であることを表すエラー
+	ErrInvalidRSVP = errors.New("invalid rsvp")
+
+	// ErrRSVPClosed は予定が開始していて出欠を回答できないことを表すエラー
+	ErrRSVPClosed = errors.New("rsvp is closed")
+
+	// ErrEventNotStarted は予定が開始しておらず出席を記録できないことを表すエラー
+	ErrEventNotStarted = errors.New("event has not started")
+)
+
+// EventDirectory は予定（開始日時のあるグループタスク）を取得するインターフェース（タスクモジュールとの連携）
+// 開始日時はタスクの着手予定日時、ない場合は期限とする
+type EventDirectory interface {
+	// GetGroupEvent はグループの予定を取得する（グループタスクでない・開始日時がない場合は nil, nil）
+	GetGroupEvent(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.GroupEvent, error)
+	// ListGroupEvents はグループの予定をすべて取得する
+	ListGroupEvents(ctx context.Context, groupID uuid.UUID) ([]*domain.GroupEvent, error)
+	// ListUpcomingEvents はすべてのグループから開始日時が[from, to)の予定を取得する
+	ListUpcomingEvents(ctx context.Context, from, to time.Time) ([]*domain.GroupEvent, error)
+}
+
+// EventReminderNotifier は出欠を回答していないメンバーにリマインダーを送るインターフェース
+type EventReminderNotifier interface {
+	NotifyRSVPReminder(ctx context.Context, userID uuid.UUID, event *domain.GroupEvent) error
+}
+
+// SetEventDirectory は予定の出欠に使う予定の取得元を設定する
+func (s *groupService) SetEventDirectory(directory EventDirectory) {
+	s.events = directory
+}
+
+// SetEventReminderNotifier は出欠のリマインダーの送信先を設定する
+func (s *groupService) SetEventReminderNotifier(notifier EventReminderNotifier) {
+	s.reminders = notifier
+}
+
+// SetRSVP は予定への自分の出欠を回答する（メンバーのみ、予定の開始前まで）
+// 回答は何度でも変更でき、記録済みの出席は変更しない
+func (s *groupService) SetRSVP(ctx context.Context, groupID uuid.UUID, eventID string, userID uuid.UUID, status domain.RSVPStatus) (*domain.EventRSVP, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status: %s", ErrInvalidRSVP, status)
+	}
+
+	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if !isMember {
+		return nil, ErrInsufficientPermissions
+	}
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if event.HasStarted(now) {
+		return nil, ErrRSVPClosed
+	}
+
+	rsvp, err := s.groupRepo.GetEventRSVP(ctx, groupID, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvp: %w", err)
+	}
+	if rsvp == nil {
+		rsvp = &domain.EventRSVP{GroupID: groupID, EventID: eventID, UserID: userID}
+	}
+	rsvp.Status = status
+	rsvp.RespondedAt = &now
+	rsvp.UpdatedAt = now
+
+	if err := s.groupRepo.SaveEventRSVP(ctx, rsvp); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save rsvp", logger.Error(err))
+		return nil, fmt.Errorf("failed to save rsvp: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Event rsvp updated",
+		logger.Any("groupID", groupID),
+		logger.Any("eventID", eventID),
+		logger.Any("userID", userID),
+		logger.Any("status", status))
+	return rsvp, nil
+}
+
+// GetEventAttendees は予定のメンバー全員の出欠を取得する（メンバーのみ）
+func (s *groupService) GetEventAttendees(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) (*domain.EventAttendees, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs, err := s.eventMemberIDs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	rsvps, err := s.groupRepo.ListEventRSVPs(ctx, groupID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvps: %w", err)
+	}
+
+	return domain.NewEventAttendees(event, memberIDs, rsvps), nil
+}
+
+// RecordAttendance はメンバーが予定に出席したかを記録する（予定の作成者またはタスクの編集権限を持つメンバーのみ、予定の開始後）
+func (s *groupService) RecordAttendance(ctx context.Context, groupID uuid.UUID, eventID string, requesterID, userID uuid.UUID, attended bool) (*domain.EventRSVP, error) {
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeEventChange(ctx, groupID, event, requesterID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !event.HasStarted(now) {
+		return nil, ErrEventNotStarted
+	}
+
+	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if !isMember {
+		return nil, fmt.Errorf("%w: user is not a member", ErrInvalidRSVP)
+	}
+
+	rsvp, err := s.groupRepo.GetEventRSVP(ctx, groupID, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvp: %w", err)
+	}
+	if rsvp == nil {
+		rsvp = &domain.EventRSVP{GroupID: groupID, EventID: eventID, UserID: userID, Status: domain.RSVPNoResponse}
+	}
+	rsvp.Attended = &attended
+	rsvp.UpdatedAt = now
+
+	if err := s.groupRepo.SaveEventRSVP(ctx, rsvp); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save attendance", logger.Error(err))
+		return nil, fmt.Errorf("failed to save attendance: %w", err)
+	}
+	return rsvp, nil
+}
+
+// SendRSVPReminders は開始まで RSVPReminderLead 以内の予定について、出欠を回答していないメンバーにリマインダーを送る
+// リマインダーは予定（繰り返しの予定は回）ごとに1回だけ送り、送ったリマインダーの件数を返す
+func (s *groupService) SendRSVPReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.events == nil || s.reminders == nil {
+		return 0, nil
+	}
+
+	events, err := s.upcomingEvents(ctx, now, now.Add(RSVPReminderLead))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, event := range events {
+		reminded, err := s.groupRepo.IsEventReminded(ctx, event.GroupID, event.ID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to check event reminder: %w", err)
+		}
+		if reminded {
+			continue
+		}
+		group, err := s.groupRepo.GetGroupByID(ctx, event.GroupID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get group: %w", err)
+		}
+		if group == nil || group.Type != domain.GroupTypeSchedule {
+			continue
+		}
+
+		memberIDs, err := s.eventMemberIDs(ctx, event.GroupID)
+		if err != nil {
+			return sent, err
+		}
+		rsvps, err := s.groupRepo.ListEventRSVPs(ctx, event.GroupID, event.ID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get rsvps: %w", err)
+		}
+		for _, attendee := range domain.NewEventAttendees(event, memberIDs, rsvps).Attendees {
+			if attendee.Status != domain.RSVPNoResponse {
+				continue
+			}
+			// 送れなかったメンバーがいても、同じ予定のリマインダーを繰り返し送らないよう送信済みにする
+			if err := s.reminders.NotifyRSVPReminder(ctx, attendee.UserID, event); err != nil {
+				s.logger.WithContext(ctx).Warn("Failed to send rsvp reminder",
+					logger.Any("groupID", event.GroupID),
+					logger.Any("eventID", event.ID),
+					logger.Any("userID", attendee.UserID),
+					logger.Error(err))
+				continue
+			}
+			sent++
+		}
+
+		if err := s.groupRepo.SaveEventReminder(ctx, event.GroupID, event.ID, now); err != nil {
+			return sent, fmt.Errorf("failed to save event reminder: %w", err)
+		}
+	}
+	return sent, nil
+}
+
+// memberAttendance は予定共有グループのメンバーごとの出欠を集計する
+func (s *groupService) memberAttendance(ctx context.Context, groupID uuid.UUID) ([]domain.MemberAttendance, error) {
+	events, err := s.events.ListGroupEvents(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	// 繰り返しの予定は開始済みの回に展開して集計する
+	now := time.Now()
+	events, err = s.expandGroupEvents(ctx, groupID, events, time.Time{}, now)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs, err := s.eventMemberIDs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	rsvps, err := s.groupRepo.ListGroupEventRSVPs(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rsvps: %w", err)
+	}
+
+	return domain.NewMemberAttendance(memberIDs, events, rsvps, now), nil
+}
+
+// getEvent は予定共有グループの予定を取得する
+// 繰り返しの予定は回のIDで指定した回（中止した回を除く）を返し、最初の回の予定のIDでは見つからないものとする
+func (s *groupService) getEvent(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.GroupEvent, error) {
+	seriesID, original, err := domain.ParseOccurrenceID(eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+	event, err := s.getSeries(ctx, groupID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence, err := s.groupRepo.GetEventRecurrence(ctx, groupID, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrence: %w", err)
+	}
+	if original == nil {
+		if recurrence != nil {
+			return nil, ErrEventNotFound
+		}
+		return event, nil
+	}
+	if recurrence == nil {
+		return nil, ErrEventNotFound
+	}
+
+	exceptions, err := s.groupRepo.ListEventExceptions(ctx, groupID, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence changes: %w", err)
+	}
+	occurrence := domain.ResolveOccurrence(event, recurrence, exceptions, *original, s.holidays)
+	if occurrence == nil {
+		return nil, ErrEventNotFound
+	}
+	return occurrence, nil
+}
+
+// getSeries は予定共有グループの予定（繰り返しの予定は最初の回）を取得する
+func (s *groupService) getSeries(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.GroupEvent, error) {
+	if s.events == nil || eventID == "" {
+		return nil, ErrEventNotFound
+	}
+
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil || group.Type != domain.GroupTypeSchedule {
+		return nil, ErrEventNotFound
+	}
+
+	event, err := s.events.GetGroupEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+	return event, nil
+}
+
+// eventMemberIDs は出欠の対象のメンバー（グループの現在のメンバー）のIDを取得する
+func (s *groupService) eventMemberIDs(ctx context.Context, groupID uuid.UUID) ([]uuid.UUID, error) {
+	members, err := s.groupRepo.ListMembers(ctx, groupID, commonDomain.Pagination{Page: 1, PageSize: maxEventMembers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+	memberIDs := make([]uuid.UUID, len(members))
+	for i, member := range members {
+		memberIDs[i] = member.UserID
+	}
+	return memberIDs, nil
+}
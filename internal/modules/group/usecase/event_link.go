@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	// ErrLinkedTaskNotFound は関連付けるタスクが見つからない（グループタスクでない）ことを表すエラー
+	ErrLinkedTaskNotFound = errors.New("linked task not found")
+
+	// ErrInvalidEventLink は予定とタスクの関連付けの入力が不正であることを表すエラー
+	ErrInvalidEventLink = errors.New("invalid event link")
+)
+
+// GroupTaskGateway はグループタスクを取得・作成するインターフェース（タスクモジュールとの連携）
+type GroupTaskGateway interface {
+	// GetGroupTask はグループタスクの概要を取得する（グループタスクでない場合は nil, nil）
+	GetGroupTask(ctx context.Context, groupID uuid.UUID, taskID string) (*domain.LinkedTask, error)
+	// CreateGroupTask はグループタスクを作成し、作成したタスクのIDを返す
+	CreateGroupTask(ctx context.Context, input GroupTaskInput) (string, error)
+}
+
+// GroupTaskInput は議事録・予定から作成するグループタスクの入力
+type GroupTaskInput struct {
+	GroupID         uuid.UUID
+	CreatedBy       uuid.UUID
+	AssigneeID      *uuid.UUID // nilの場合は担当者なしで作成する
+	Title           string
+	Description     string
+	StartDate       *time.Time // 着手予定日時（予定の開始日時）
+	DueDate         *time.Time
+	EstimateMinutes *int
+}
+
+// TaskBlockInput はタスクの作業時間として予定を作成する入力
+type TaskBlockInput struct {
+	StartsAt        time.Time
+	DurationMinutes *int // nilの場合はタスクの見積もり時間
+}
+
+// FollowUpTaskInput は予定からフォローアップのタスクを作成する入力
+type FollowUpTaskInput struct {
+	Title       string // 空の場合は「予定のタイトル」のフォローアップ
+	Description string
+	AssigneeID  *uuid.UUID // nilの場合は作成したメンバー
+}
+
+// SetGroupTaskGateway は議事録・予定から作成するグループタスクの取得・作成先を設定する
+func (s *groupService) SetGroupTaskGateway(gateway GroupTaskGateway) {
+	s.groupTasks = gateway
+}
+
+// GetEventDetail は予定を関連付けたタスクとともに取得する（予定の閲覧権限が必要）
+func (s *groupService) GetEventDetail(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) (*domain.EventDetail, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.eventDetail(ctx, event)
+}
+
+// ScheduleTaskBlock はグループタスクの作業時間として予定を作成し、タスクと関連付ける（タスクの作成権限が必要）
+// 予定は作成したメンバーに割り当て、長さを指定しない場合はタスクの見積もり時間とする
+func (s *groupService) ScheduleTaskBlock(ctx context.Context, groupID uuid.UUID, taskID string, requesterID uuid.UUID, input TaskBlockInput) (*domain.EventDetail, error) {
+	if input.StartsAt.IsZero() {
+		return nil, fmt.Errorf("%w: starts_at is required", ErrInvalidEventLink)
+	}
+
+	canCreate, err := s.CheckPermission(ctx, groupID, requesterID, ActionCreateTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canCreate {
+		return nil, ErrInsufficientPermissions
+	}
+
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil || group.Type != domain.GroupTypeSchedule {
+		return nil, fmt.Errorf("%w: group does not share schedules", ErrInvalidEventLink)
+	}
+
+	task, err := s.getLinkedTask(ctx, groupID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := task.EstimateMinutes
+	if input.DurationMinutes != nil {
+		duration = input.DurationMinutes
+	}
+	if duration == nil {
+		return nil, fmt.Errorf("%w: duration is required when the task has no estimate", ErrInvalidEventLink)
+	}
+	if *duration <= 0 || *duration > domain.MaxBlockMinutes {
+		return nil, fmt.Errorf("%w: duration must be between 1 and %d minutes", ErrInvalidEventLink, domain.MaxBlockMinutes)
+	}
+
+	startsAt := input.StartsAt
+	endsAt := startsAt.Add(time.Duration(*duration) * time.Minute)
+	minutes := *duration
+	eventID, err := s.groupTasks.CreateGroupTask(ctx, GroupTaskInput{
+		GroupID:         groupID,
+		CreatedBy:       requesterID,
+		AssigneeID:      &requesterID,
+		Title:           task.Title,
+		Description:     fmt.Sprintf("タスク「%s」の作業時間として作成しました。\n/tasks/%s", task.Title, task.ID),
+		StartDate:       &startsAt,
+		DueDate:         &endsAt,
+		EstimateMinutes: &minutes,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to create task block", logger.Error(err))
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	if err := s.groupRepo.SaveEventTaskLink(ctx, &domain.EventTaskLink{
+		GroupID:   groupID,
+		EventID:   eventID,
+		TaskID:    task.ID,
+		Kind:      domain.EventTaskLinkBlock,
+		CreatedBy: requesterID,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save event link", logger.Error(err))
+		return nil, fmt.Errorf("failed to save event link: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Task block scheduled",
+		logger.Any("groupID", groupID),
+		logger.Any("taskID", task.ID),
+		logger.Any("eventID", eventID),
+		logger.Any("minutes", minutes))
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.eventDetail(ctx, event)
+}
+
+// CreateFollowUpTask は予定（繰り返しの予定は回）からフォローアップのタスクを作成し、予定と関連付ける（タスクの作成権限が必要）
+func (s *groupService) CreateFollowUpTask(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID, input FollowUpTaskInput) (*domain.EventTaskLink, error) {
+	canCreate, err := s.CheckPermission(ctx, groupID, requesterID, ActionCreateTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canCreate {
+		return nil, ErrInsufficientPermissions
+	}
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if s.groupTasks == nil {
+		return nil, ErrLinkedTaskNotFound
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = domain.FollowUpTitle(event.Title)
+	}
+	if len(title) > domain.MaxTaskTitleBytes {
+		return nil, fmt.Errorf("%w: title must be at most %d bytes", ErrInvalidEventLink, domain.MaxTaskTitleBytes)
+	}
+	description := fmt.Sprintf("予定「%s」のフォローアップとして作成しました。\n/groups/%s/events/%s", event.Title, groupID, event.ID)
+	if body := strings.TrimSpace(input.Description); body != "" {
+		description = body + "\n\n" + description
+	}
+	if len(description) > domain.MaxTaskDescriptionBytes {
+		return nil, fmt.Errorf("%w: description is too long", ErrInvalidEventLink)
+	}
+
+	assigneeID := requesterID
+	if input.AssigneeID != nil {
+		isMember, err := s.groupRepo.IsMember(ctx, groupID, *input.AssigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check membership: %w", err)
+		}
+		if !isMember {
+			return nil, fmt.Errorf("%w: assignee is not a member", ErrInvalidEventLink)
+		}
+		assigneeID = *input.AssigneeID
+	}
+
+	taskID, err := s.groupTasks.CreateGroupTask(ctx, GroupTaskInput{
+		GroupID:     groupID,
+		CreatedBy:   requesterID,
+		AssigneeID:  &assigneeID,
+		Title:       title,
+		Description: description,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to create follow-up task", logger.Error(err))
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	link := &domain.EventTaskLink{
+		GroupID:   groupID,
+		EventID:   event.ID,
+		TaskID:    taskID,
+		Kind:      domain.EventTaskLinkFollowUp,
+		CreatedBy: requesterID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.groupRepo.SaveEventTaskLink(ctx, link); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save event link", logger.Error(err))
+		return nil, fmt.Errorf("failed to save event link: %w", err)
+	}
+	link.Task, err = s.groupTasks.GetGroupTask(ctx, groupID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Follow-up task created",
+		logger.Any("groupID", groupID),
+		logger.Any("eventID", event.ID),
+		logger.Any("taskID", taskID))
+	return link, nil
+}
+
+// ListTaskEventLinks はタスク（予定のタスクを含む）の予定との関連付けを取得する
+func (s *groupService) ListTaskEventLinks(ctx context.Context, taskID string) ([]*domain.EventTaskLink, error) {
+	links, err := s.groupRepo.ListTaskLinks(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event links: %w", err)
+	}
+	return links, nil
+}
+
+// eventDetail は予定に関連付けたタスクを取得する（削除されたタスクの関連付けは含めない）
+func (s *groupService) eventDetail(ctx context.Context, event *domain.GroupEvent) (*domain.EventDetail, error) {
+	links, err := s.groupRepo.ListEventTaskLinks(ctx, event.GroupID, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event links: %w", err)
+	}
+
+	detail := &domain.EventDetail{Event: event, Links: []*domain.EventTaskLink{}}
+	if s.groupTasks == nil {
+		return detail, nil
+	}
+	for _, link := range links {
+		task, err := s.groupTasks.GetGroupTask(ctx, event.GroupID, link.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+		if task == nil {
+			continue
+		}
+		link.Task = task
+		detail.Links = append(detail.Links, link)
+	}
+	return detail, nil
+}
+
+// getLinkedTask はグループタスクの概要を取得する
+func (s *groupService) getLinkedTask(ctx context.Context, groupID uuid.UUID, taskID string) (*domain.LinkedTask, error) {
+	if s.groupTasks == nil || taskID == "" {
+		return nil, ErrLinkedTaskNotFound
+	}
+	task, err := s.groupTasks.GetGroupTask(ctx, groupID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, ErrLinkedTaskNotFound
+	}
+	return task, nil
+}
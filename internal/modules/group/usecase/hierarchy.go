@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+)
+
+var (
+	// ErrInvalidParentGroup はサブチームの親に指定できないグループであることを表すエラー
+	ErrInvalidParentGroup = errors.New("invalid parent group")
+
+	// ErrGroupHierarchyTooDeep はグループ階層が上限を超えることを表すエラー
+	ErrGroupHierarchyTooDeep = errors.New("group hierarchy too deep")
+)
+
+// GetGroupTree はグループとそのサブチームを階層で取得し、サブチームを含めた統計を集計する
+// 閲覧できないサブチーム（非公開で、メンバーでも上位グループの管理者でもない）は含めない
+func (s *groupService) GetGroupTree(ctx context.Context, groupID, requesterID uuid.UUID) (*GroupTree, error) {
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil {
+		return nil, errors.New("group not found")
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, groupID, requesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	manages := member != nil && member.CanManageGroup()
+	if !manages {
+		manages, err = s.permissions.isAncestorManager(ctx, group, requesterID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if member == nil && !manages && !group.Settings.IsPublic {
+		return nil, ErrInsufficientPermissions
+	}
+
+	tree, _, err := s.buildGroupTree(ctx, group, requesterID, manages, 1, make(map[uuid.UUID]bool))
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// buildGroupTree はサブチームを再帰的に取得し、ノードとサブツリーに含まれるグループIDを返す
+// manages は要求者がこのグループを管理できるか（上位グループの管理者を含む）
+func (s *groupService) buildGroupTree(ctx context.Context, group *domain.Group, requesterID uuid.UUID, manages bool, depth int, visited map[uuid.UUID]bool) (*GroupTree, []uuid.UUID, error) {
+	visited[group.ID] = true
+
+	stats, err := s.groupRepo.GetGroupStats(ctx, group.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get group stats: %w", err)
+	}
+	node := &GroupTree{
+		Group:    group,
+		Stats:    stats,
+		Children: []*GroupTree{},
+	}
+	total := *stats
+	groupIDs := []uuid.UUID{group.ID}
+
+	if depth < domain.MaxGroupDepth && group.CanHaveSubGroups() {
+		children, err := s.groupRepo.ListChildGroups(ctx, group.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list child groups: %w", err)
+		}
+
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+
+			childManages := manages
+			if !manages {
+				member, err := s.groupRepo.GetMember(ctx, child.ID, requesterID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get member: %w", err)
+				}
+				if member == nil && !child.Settings.IsPublic {
+					continue
+				}
+				childManages = member != nil && member.CanManageGroup()
+			}
+
+			childNode, childIDs, err := s.buildGroupTree(ctx, child, requesterID, childManages, depth+1, visited)
+			if err != nil {
+				return nil, nil, err
+			}
+			node.Children = append(node.Children, childNode)
+			groupIDs = append(groupIDs, childIDs...)
+			total.TaskCount += childNode.Total.TaskCount
+			total.ScheduleCount += childNode.Total.ScheduleCount
+			total.ActiveMembers += childNode.Total.ActiveMembers
+		}
+	}
+
+	// 複数のサブチームに所属するメンバーを重複して数えない
+	if len(groupIDs) > 1 {
+		total.MemberCount, err = s.groupRepo.CountDistinctMembers(ctx, groupIDs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count members: %w", err)
+		}
+		if total.ActiveMembers > total.MemberCount {
+			total.ActiveMembers = total.MemberCount
+		}
+	}
+	node.Total = &total
+
+	return node, groupIDs, nil
+}
+
+// validateParentGroup はサブチームを作成できる親グループかチェックする
+// 親はプロジェクトグループで、作成者が親グループを管理できる必要がある
+func (s *groupService) validateParentGroup(ctx context.Context, parentID, creatorID uuid.UUID) error {
+	parent, err := s.groupRepo.GetGroupByID(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to get parent group: %w", err)
+	}
+	if parent == nil {
+		return fmt.Errorf("%w: parent group not found", ErrInvalidParentGroup)
+	}
+	if !parent.CanHaveSubGroups() {
+		return fmt.Errorf("%w: only project groups can have sub-groups", ErrInvalidParentGroup)
+	}
+
+	ancestors, err := s.permissions.getAncestors(ctx, parent)
+	if err != nil {
+		return err
+	}
+	// 親とその上位グループ、作成するグループの分を数える
+	if len(ancestors)+2 > domain.MaxGroupDepth {
+		return ErrGroupHierarchyTooDeep
+	}
+
+	canManage, err := s.CheckPermission(ctx, parentID, creatorID, ActionEditGroup)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canManage {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+)
+
+const (
+	// dateTimeLayout は iCalendar の UTC の日時の書式
+	dateTimeLayout = "20060102T150405Z"
+	// maxLineOctets は折り返さずに書ける1行のバイト数（RFC 5545 3.1）
+	maxLineOctets = 75
+	// uidDomain は予定の UID に付けるドメイン
+	uidDomain = "yotei-plus"
+)
+
+// Calendar はグループの予定を iCalendar（RFC 5545）形式で出力する
+// 繰り返しの予定は回ごとに展開済みの VEVENT として出力し、回のIDを UID にする
+func Calendar(schedule *domain.EventSchedule, now time.Time) []byte {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//Yotei+//Group Events//JA")
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "X-WR-CALNAME:"+escapeText(schedule.GroupName))
+
+	stamp := now.UTC().Format(dateTimeLayout)
+	for _, event := range schedule.Events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+event.ID+"@"+uidDomain)
+		writeLine(&buf, "DTSTAMP:"+stamp)
+		writeLine(&buf, "DTSTART:"+event.StartsAt.UTC().Format(dateTimeLayout))
+		writeLine(&buf, "SUMMARY:"+escapeText(event.Title))
+		if event.Location != nil {
+			writeLine(&buf, "LOCATION:"+escapeText(event.Location.Name))
+			// GEO は「緯度;経度」（RFC 5545 3.8.1.6）
+			writeLine(&buf, fmt.Sprintf("GEO:%s;%s",
+				strconv.FormatFloat(event.Location.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(event.Location.Longitude, 'f', -1, 64)))
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeText は TEXT の値の特殊文字をエスケープする
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeLine は1行を CRLF で書き込む（75バイトを超える行は UTF-8 の文字の途中で切らずに折り返す）
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		// UTF-8 の継続バイトの途中で切らない
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// 折り返した行は先頭の空白の分だけ短くする
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,51 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+)
+
+func TestCalendar(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	standup := &domain.GroupEvent{ID: uuid.NewString(), Title: "Standup", StartsAt: time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)}
+	occurrence := standup.Occurrence(time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC), nil)
+	dinner := &domain.GroupEvent{ID: uuid.NewString(), Title: "夕食; 家族, 全員", StartsAt: time.Date(2024, 6, 5, 10, 30, 0, 0, time.UTC),
+		Location: &domain.EventLocation{Name: "レストラン, 東京駅", Latitude: 35.681236, Longitude: 139.767125}}
+
+	out := string(Calendar(&domain.EventSchedule{
+		GroupName: "Family",
+		Events:    []*domain.GroupEvent{standup, dinner, occurrence},
+	}, now))
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-CALNAME:Family\r\n")
+	assert.Equal(t, 3, strings.Count(out, "BEGIN:VEVENT\r\n"))
+	assert.Contains(t, out, "UID:"+occurrence.ID+"@yotei-plus\r\n")
+	assert.Contains(t, out, "DTSTART:20240610T090000Z\r\n")
+	assert.Contains(t, out, "DTSTAMP:20240601T120000Z\r\n")
+	// Special characters in text values are escaped
+	assert.Contains(t, out, `SUMMARY:夕食\; 家族\, 全員`)
+	// Events with a location include LOCATION and GEO
+	assert.Contains(t, out, "LOCATION:レストラン\\, 東京駅\r\n")
+	assert.Contains(t, out, "GEO:35.681236;139.767125\r\n")
+	assert.Equal(t, 1, strings.Count(out, "GEO:"))
+}
+
+func TestWriteLine_Folding(t *testing.T) {
+	out := string(Calendar(&domain.EventSchedule{GroupName: strings.Repeat("予定", 40)}, time.Now()))
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		assert.True(t, strings.ToValidUTF8(line, "") == line, "lines must not split UTF-8 characters")
+	}
+	// Unfolding restores the original value
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	assert.Contains(t, unfolded, "X-WR-CALNAME:"+strings.Repeat("予定", 40)+"\r\n")
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// leaderboardCacheTTL はリーダーボードをキャッシュする期間
+	leaderboardCacheTTL = 5 * time.Minute
+	// maxCachedLeaderboards はキャッシュするリーダーボードの件数の上限（超えた場合はキャッシュを作り直す）
+	maxCachedLeaderboards = 1000
+	// maxLeaderboardMembers はリーダーボードで集計するメンバー数の上限
+	maxLeaderboardMembers = 1000
+)
+
+var (
+	// ErrLeaderboardDisabled はグループのリーダーボードが有効になっていないことを表すエラー
+	ErrLeaderboardDisabled = errors.New("leaderboard is disabled")
+
+	// ErrInvalidLeaderboardSettings はリーダーボードの設定・公開範囲が不正であることを表すエラー
+	ErrInvalidLeaderboardSettings = errors.New("invalid leaderboard settings")
+)
+
+// TaskCompletionProvider はメンバーがグループタスクを完了した記録を取得するインターフェース（タスクモジュールとの連携）
+type TaskCompletionProvider interface {
+	// ListTaskCompletions はグループタスクのうち各メンバーが担当者として[from, to)に完了したものを取得する
+	ListTaskCompletions(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID][]domain.TaskCompletion, error)
+}
+
+// LeaderboardSettingsInput はリーダーボード設定の変更の入力（nilの項目は変更しない）
+type LeaderboardSettingsInput struct {
+	Enabled  *bool
+	Timezone *string
+}
+
+// LeaderboardSettingsView はリーダーボード設定と閲覧するメンバーの公開範囲
+type LeaderboardSettingsView struct {
+	Settings     *domain.LeaderboardSettings
+	MyVisibility domain.LeaderboardVisibility
+}
+
+// cachedLeaderboard はキャッシュしたリーダーボード（閲覧者向けの加工前）
+type cachedLeaderboard struct {
+	board     *domain.Leaderboard
+	expiresAt time.Time
+}
+
+// leaderboardCache はグループと週ごとのリーダーボードのキャッシュ（ゼロ値で使用できる）
+type leaderboardCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedLeaderboard
+}
+
+func leaderboardCacheKey(groupID uuid.UUID, weekStart time.Time) string {
+	return groupID.String() + "/" + weekStart.Format(time.RFC3339)
+}
+
+func (c *leaderboardCache) get(key string, now time.Time) *domain.Leaderboard {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok || !now.Before(cached.expiresAt) {
+		return nil
+	}
+	return cached.board
+}
+
+func (c *leaderboardCache) put(key string, board *domain.Leaderboard, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil || len(c.entries) >= maxCachedLeaderboards {
+		c.entries = make(map[string]cachedLeaderboard)
+	}
+	c.entries[key] = cachedLeaderboard{board: board, expiresAt: now.Add(leaderboardCacheTTL)}
+}
+
+// invalidate はグループのリーダーボードのキャッシュを削除する（設定・公開範囲の変更時）
+func (c *leaderboardCache) invalidate(groupID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := groupID.String() + "/"
+	for key := range c.entries {
+		if len(key) > len(prefix) && key[:len(prefix)] == prefix {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// SetTaskCompletionProvider はリーダーボードの集計に使うタスクの完了記録の取得元を設定する
+func (s *groupService) SetTaskCompletionProvider(provider TaskCompletionProvider) {
+	s.completions = provider
+}
+
+// GetLeaderboard はグループの週ごとのリーダーボードを取得する（メンバーのみ）
+// previous が true の場合は前週の確定した結果を返す。集計は一定時間キャッシュする
+func (s *groupService) GetLeaderboard(ctx context.Context, groupID, requesterID uuid.UUID, previous bool) (*domain.Leaderboard, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getLeaderboardSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled || s.completions == nil {
+		return nil, ErrLeaderboardDisabled
+	}
+
+	now := time.Now()
+	weekStart, weekEnd := domain.LeaderboardWeek(now, settings.Location())
+	if previous {
+		weekStart, weekEnd = weekStart.AddDate(0, 0, -7), weekStart
+	}
+
+	key := leaderboardCacheKey(groupID, weekStart)
+	if board := s.leaderboards.get(key, now); board != nil {
+		return board.ForViewer(requesterID), nil
+	}
+
+	board, err := s.buildLeaderboard(ctx, groupID, weekStart, weekEnd, now)
+	if err != nil {
+		return nil, err
+	}
+	s.leaderboards.put(key, board, now)
+	return board.ForViewer(requesterID), nil
+}
+
+// buildLeaderboard はメンバーと完了記録を取得してリーダーボードを集計する
+func (s *groupService) buildLeaderboard(ctx context.Context, groupID uuid.UUID, weekStart, weekEnd, now time.Time) (*domain.Leaderboard, error) {
+	members, err := s.groupRepo.ListMembers(ctx, groupID, commonDomain.Pagination{Page: 1, PageSize: maxLeaderboardMembers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+	visibilities, err := s.groupRepo.GetLeaderboardVisibilities(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard preferences: %w", err)
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	userIDStrings := make([]string, 0, len(members))
+	for _, member := range members {
+		if visibilities[member.UserID] == domain.LeaderboardHidden {
+			continue
+		}
+		userIDs = append(userIDs, member.UserID)
+		userIDStrings = append(userIDStrings, member.UserID.String())
+	}
+
+	users, err := s.userValidator.GetUsersInfoBatch(ctx, userIDStrings)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to get user info for leaderboard", logger.Error(err))
+		users = nil
+	}
+	leaderboardMembers := make([]domain.LeaderboardMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		visibility, ok := visibilities[userID]
+		if !ok {
+			visibility = domain.LeaderboardVisible
+		}
+		name := userID.String()
+		if user := users[userID.String()]; user != nil {
+			name = user.Username
+		}
+		leaderboardMembers = append(leaderboardMembers, domain.LeaderboardMember{UserID: userID, DisplayName: name, Visibility: visibility})
+	}
+
+	// 連続達成日数を数えるため週より前の完了も取得する
+	from := weekEnd.AddDate(0, 0, -domain.MaxLeaderboardStreakDays-1)
+	completions, err := s.completions.ListTaskCompletions(ctx, groupID, userIDs, from, weekEnd)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get task completions for leaderboard",
+			logger.Any("groupID", groupID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get task completions: %w", err)
+	}
+
+	return domain.NewLeaderboard(groupID, leaderboardMembers, completions, weekStart, weekEnd, now), nil
+}
+
+// GetLeaderboardSettings はリーダーボード設定と自分の公開範囲を取得する（メンバーのみ）
+func (s *groupService) GetLeaderboardSettings(ctx context.Context, groupID, requesterID uuid.UUID) (*LeaderboardSettingsView, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getLeaderboardSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	visibilities, err := s.groupRepo.GetLeaderboardVisibilities(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard preferences: %w", err)
+	}
+	visibility, ok := visibilities[requesterID]
+	if !ok {
+		visibility = domain.LeaderboardVisible
+	}
+
+	return &LeaderboardSettingsView{Settings: settings, MyVisibility: visibility}, nil
+}
+
+// UpdateLeaderboardSettings はリーダーボードの有効・無効と週の区切りのタイムゾーンを変更する（グループ編集の権限が必要）
+func (s *groupService) UpdateLeaderboardSettings(ctx context.Context, groupID, requesterID uuid.UUID, input LeaderboardSettingsInput) (*domain.LeaderboardSettings, error) {
+	if input.Timezone != nil {
+		if _, err := time.LoadLocation(*input.Timezone); err != nil || *input.Timezone == "" {
+			return nil, fmt.Errorf("%w: invalid timezone: %s", ErrInvalidLeaderboardSettings, *input.Timezone)
+		}
+	}
+
+	canEdit, err := s.CheckPermission(ctx, groupID, requesterID, ActionEditGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canEdit {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getLeaderboardSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Enabled != nil {
+		settings.Enabled = *input.Enabled
+	}
+	if input.Timezone != nil {
+		settings.Timezone = *input.Timezone
+	}
+	settings.UpdatedAt = time.Now()
+
+	if err := s.groupRepo.SaveLeaderboardSettings(ctx, settings); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save leaderboard settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to save leaderboard settings: %w", err)
+	}
+	s.leaderboards.invalidate(groupID)
+
+	s.logger.WithContext(ctx).Info("Group leaderboard settings updated",
+		logger.Any("groupID", groupID),
+		logger.Any("enabled", settings.Enabled))
+	return settings, nil
+}
+
+// UpdateLeaderboardVisibility は自分のリーダーボードでの公開範囲を変更する（メンバーのみ）
+func (s *groupService) UpdateLeaderboardVisibility(ctx context.Context, groupID, userID uuid.UUID, visibility domain.LeaderboardVisibility) (*domain.LeaderboardPreference, error) {
+	if !visibility.IsValid() {
+		return nil, fmt.Errorf("%w: invalid visibility: %s", ErrInvalidLeaderboardSettings, visibility)
+	}
+
+	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if !isMember {
+		return nil, ErrInsufficientPermissions
+	}
+
+	preference := &domain.LeaderboardPreference{
+		GroupID:    groupID,
+		UserID:     userID,
+		Visibility: visibility,
+		UpdatedAt:  time.Now(),
+	}
+	if err := s.groupRepo.SaveLeaderboardPreference(ctx, preference); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save leaderboard preference", logger.Error(err))
+		return nil, fmt.Errorf("failed to save leaderboard preference: %w", err)
+	}
+	s.leaderboards.invalidate(groupID)
+
+	return preference, nil
+}
+
+// getLeaderboardSettings はリーダーボード設定を取得する（未設定の場合は無効の設定）
+func (s *groupService) getLeaderboardSettings(ctx context.Context, groupID uuid.UUID) (*domain.LeaderboardSettings, error) {
+	settings, err := s.groupRepo.GetLeaderboardSettings(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard settings: %w", err)
+	}
+	if settings == nil {
+		return domain.NewLeaderboardSettings(groupID), nil
+	}
+	return settings, nil
+}
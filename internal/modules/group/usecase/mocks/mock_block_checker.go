@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hryt430/Yotei+/internal/common/domain (interfaces: BlockChecker)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlockChecker is a mock of BlockChecker interface.
+type MockBlockChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockCheckerMockRecorder
+}
+
+// MockBlockCheckerMockRecorder is the mock recorder for MockBlockChecker.
+type MockBlockCheckerMockRecorder struct {
+	mock *MockBlockChecker
+}
+
+// NewMockBlockChecker creates a new mock instance.
+func NewMockBlockChecker(ctrl *gomock.Controller) *MockBlockChecker {
+	mock := &MockBlockChecker{ctrl: ctrl}
+	mock.recorder = &MockBlockCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockChecker) EXPECT() *MockBlockCheckerMockRecorder {
+	return m.recorder
+}
+
+// GetBlockedUserIDs mocks base method.
+func (m *MockBlockChecker) GetBlockedUserIDs(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUserIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUserIDs indicates an expected call of GetBlockedUserIDs.
+func (mr *MockBlockCheckerMockRecorder) GetBlockedUserIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUserIDs", reflect.TypeOf((*MockBlockChecker)(nil).GetBlockedUserIDs), arg0, arg1)
+}
+
+// IsBlocked mocks base method.
+func (m *MockBlockChecker) IsBlocked(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockBlockCheckerMockRecorder) IsBlocked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockBlockChecker)(nil).IsBlocked), arg0, arg1, arg2)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -14,7 +15,6 @@ import (
 	domain0 "github.com/hryt430/Yotei+/internal/modules/group/domain"
 )
 
-
 // MockGroupRepository is a mock of GroupRepository interface.
 type MockGroupRepository struct {
 	ctrl     *gomock.Controller
@@ -52,6 +52,63 @@ func (mr *MockGroupRepositoryMockRecorder) AddMember(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroupRepository)(nil).AddMember), arg0, arg1)
 }
 
+// AddMembers mocks base method.
+func (m *MockGroupRepository) AddMembers(arg0 context.Context, arg1 *domain0.Group, arg2 []*domain0.GroupMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockGroupRepositoryMockRecorder) AddMembers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockGroupRepository)(nil).AddMembers), arg0, arg1, arg2)
+}
+
+// CountDistinctMembers mocks base method.
+func (m *MockGroupRepository) CountDistinctMembers(arg0 context.Context, arg1 []uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDistinctMembers", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDistinctMembers indicates an expected call of CountDistinctMembers.
+func (mr *MockGroupRepositoryMockRecorder) CountDistinctMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDistinctMembers", reflect.TypeOf((*MockGroupRepository)(nil).CountDistinctMembers), arg0, arg1)
+}
+
+// CreateCustomRole mocks base method.
+func (m *MockGroupRepository) CreateCustomRole(arg0 context.Context, arg1 *domain0.CustomRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCustomRole indicates an expected call of CreateCustomRole.
+func (mr *MockGroupRepositoryMockRecorder) CreateCustomRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomRole", reflect.TypeOf((*MockGroupRepository)(nil).CreateCustomRole), arg0, arg1)
+}
+
+// CreateEventNote mocks base method.
+func (m *MockGroupRepository) CreateEventNote(arg0 context.Context, arg1 *domain0.EventNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventNote indicates an expected call of CreateEventNote.
+func (mr *MockGroupRepositoryMockRecorder) CreateEventNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventNote", reflect.TypeOf((*MockGroupRepository)(nil).CreateEventNote), arg0, arg1)
+}
+
 // CreateGroup mocks base method.
 func (m *MockGroupRepository) CreateGroup(arg0 context.Context, arg1 *domain0.Group) error {
 	m.ctrl.T.Helper()
@@ -66,6 +123,76 @@ func (mr *MockGroupRepositoryMockRecorder) CreateGroup(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupRepository)(nil).CreateGroup), arg0, arg1)
 }
 
+// DeleteChatIntegration mocks base method.
+func (m *MockGroupRepository) DeleteChatIntegration(arg0 context.Context, arg1 uuid.UUID, arg2 domain0.ChatPlatform) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChatIntegration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChatIntegration indicates an expected call of DeleteChatIntegration.
+func (mr *MockGroupRepositoryMockRecorder) DeleteChatIntegration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatIntegration", reflect.TypeOf((*MockGroupRepository)(nil).DeleteChatIntegration), arg0, arg1, arg2)
+}
+
+// DeleteCustomRole mocks base method.
+func (m *MockGroupRepository) DeleteCustomRole(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomRole indicates an expected call of DeleteCustomRole.
+func (mr *MockGroupRepositoryMockRecorder) DeleteCustomRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomRole", reflect.TypeOf((*MockGroupRepository)(nil).DeleteCustomRole), arg0, arg1, arg2)
+}
+
+// DeleteEventException mocks base method.
+func (m *MockGroupRepository) DeleteEventException(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventException", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEventException indicates an expected call of DeleteEventException.
+func (mr *MockGroupRepositoryMockRecorder) DeleteEventException(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventException", reflect.TypeOf((*MockGroupRepository)(nil).DeleteEventException), arg0, arg1, arg2, arg3)
+}
+
+// DeleteEventNote mocks base method.
+func (m *MockGroupRepository) DeleteEventNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventNote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEventNote indicates an expected call of DeleteEventNote.
+func (mr *MockGroupRepositoryMockRecorder) DeleteEventNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventNote", reflect.TypeOf((*MockGroupRepository)(nil).DeleteEventNote), arg0, arg1, arg2)
+}
+
+// DeleteEventRecurrence mocks base method.
+func (m *MockGroupRepository) DeleteEventRecurrence(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventRecurrence", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEventRecurrence indicates an expected call of DeleteEventRecurrence.
+func (mr *MockGroupRepositoryMockRecorder) DeleteEventRecurrence(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventRecurrence", reflect.TypeOf((*MockGroupRepository)(nil).DeleteEventRecurrence), arg0, arg1, arg2)
+}
+
 // DeleteGroup mocks base method.
 func (m *MockGroupRepository) DeleteGroup(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -80,6 +207,111 @@ func (mr *MockGroupRepositoryMockRecorder) DeleteGroup(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupRepository)(nil).DeleteGroup), arg0, arg1)
 }
 
+// GetAssignmentSettings mocks base method.
+func (m *MockGroupRepository) GetAssignmentSettings(arg0 context.Context, arg1 uuid.UUID) (*domain0.AssignmentSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentSettings", arg0, arg1)
+	ret0, _ := ret[0].(*domain0.AssignmentSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentSettings indicates an expected call of GetAssignmentSettings.
+func (mr *MockGroupRepositoryMockRecorder) GetAssignmentSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentSettings", reflect.TypeOf((*MockGroupRepository)(nil).GetAssignmentSettings), arg0, arg1)
+}
+
+// GetChatIntegration mocks base method.
+func (m *MockGroupRepository) GetChatIntegration(arg0 context.Context, arg1 uuid.UUID, arg2 domain0.ChatPlatform) (*domain0.ChatIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatIntegration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain0.ChatIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatIntegration indicates an expected call of GetChatIntegration.
+func (mr *MockGroupRepositoryMockRecorder) GetChatIntegration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatIntegration", reflect.TypeOf((*MockGroupRepository)(nil).GetChatIntegration), arg0, arg1, arg2)
+}
+
+// GetCustomRole mocks base method.
+func (m *MockGroupRepository) GetCustomRole(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain0.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain0.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomRole indicates an expected call of GetCustomRole.
+func (mr *MockGroupRepositoryMockRecorder) GetCustomRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomRole", reflect.TypeOf((*MockGroupRepository)(nil).GetCustomRole), arg0, arg1, arg2)
+}
+
+// GetEventNote mocks base method.
+func (m *MockGroupRepository) GetEventNote(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain0.EventNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventNote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain0.EventNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventNote indicates an expected call of GetEventNote.
+func (mr *MockGroupRepositoryMockRecorder) GetEventNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventNote", reflect.TypeOf((*MockGroupRepository)(nil).GetEventNote), arg0, arg1, arg2)
+}
+
+// GetEventRSVP mocks base method.
+func (m *MockGroupRepository) GetEventRSVP(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 uuid.UUID) (*domain0.EventRSVP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventRSVP", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain0.EventRSVP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventRSVP indicates an expected call of GetEventRSVP.
+func (mr *MockGroupRepositoryMockRecorder) GetEventRSVP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventRSVP", reflect.TypeOf((*MockGroupRepository)(nil).GetEventRSVP), arg0, arg1, arg2, arg3)
+}
+
+// GetEventRecurrence mocks base method.
+func (m *MockGroupRepository) GetEventRecurrence(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*domain0.EventRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventRecurrence", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain0.EventRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventRecurrence indicates an expected call of GetEventRecurrence.
+func (mr *MockGroupRepositoryMockRecorder) GetEventRecurrence(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventRecurrence", reflect.TypeOf((*MockGroupRepository)(nil).GetEventRecurrence), arg0, arg1, arg2)
+}
+
+// GetExistingMemberIDs mocks base method.
+func (m *MockGroupRepository) GetExistingMemberIDs(arg0 context.Context, arg1 uuid.UUID, arg2 []uuid.UUID) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExistingMemberIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExistingMemberIDs indicates an expected call of GetExistingMemberIDs.
+func (mr *MockGroupRepositoryMockRecorder) GetExistingMemberIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExistingMemberIDs", reflect.TypeOf((*MockGroupRepository)(nil).GetExistingMemberIDs), arg0, arg1, arg2)
+}
+
 // GetGroupByID mocks base method.
 func (m *MockGroupRepository) GetGroupByID(arg0 context.Context, arg1 uuid.UUID) (*domain0.Group, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +342,36 @@ func (mr *MockGroupRepositoryMockRecorder) GetGroupStats(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupStats", reflect.TypeOf((*MockGroupRepository)(nil).GetGroupStats), arg0, arg1)
 }
 
+// GetLeaderboardSettings mocks base method.
+func (m *MockGroupRepository) GetLeaderboardSettings(arg0 context.Context, arg1 uuid.UUID) (*domain0.LeaderboardSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboardSettings", arg0, arg1)
+	ret0, _ := ret[0].(*domain0.LeaderboardSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboardSettings indicates an expected call of GetLeaderboardSettings.
+func (mr *MockGroupRepositoryMockRecorder) GetLeaderboardSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboardSettings", reflect.TypeOf((*MockGroupRepository)(nil).GetLeaderboardSettings), arg0, arg1)
+}
+
+// GetLeaderboardVisibilities mocks base method.
+func (m *MockGroupRepository) GetLeaderboardVisibilities(arg0 context.Context, arg1 uuid.UUID) (map[uuid.UUID]domain0.LeaderboardVisibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboardVisibilities", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID]domain0.LeaderboardVisibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboardVisibilities indicates an expected call of GetLeaderboardVisibilities.
+func (mr *MockGroupRepositoryMockRecorder) GetLeaderboardVisibilities(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboardVisibilities", reflect.TypeOf((*MockGroupRepository)(nil).GetLeaderboardVisibilities), arg0, arg1)
+}
+
 // GetMember mocks base method.
 func (m *MockGroupRepository) GetMember(arg0 context.Context, arg1, arg2 uuid.UUID) (*domain0.GroupMember, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +417,66 @@ func (mr *MockGroupRepositoryMockRecorder) GetMemberRole(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockGroupRepository)(nil).GetMemberRole), arg0, arg1, arg2)
 }
 
+// GetNoteActionItemByTask mocks base method.
+func (m *MockGroupRepository) GetNoteActionItemByTask(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*domain0.NoteActionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteActionItemByTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain0.NoteActionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteActionItemByTask indicates an expected call of GetNoteActionItemByTask.
+func (mr *MockGroupRepositoryMockRecorder) GetNoteActionItemByTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteActionItemByTask", reflect.TypeOf((*MockGroupRepository)(nil).GetNoteActionItemByTask), arg0, arg1, arg2)
+}
+
+// GetPermissionOverrides mocks base method.
+func (m *MockGroupRepository) GetPermissionOverrides(arg0 context.Context, arg1 uuid.UUID) (domain0.PermissionMatrix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionOverrides", arg0, arg1)
+	ret0, _ := ret[0].(domain0.PermissionMatrix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionOverrides indicates an expected call of GetPermissionOverrides.
+func (mr *MockGroupRepositoryMockRecorder) GetPermissionOverrides(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionOverrides", reflect.TypeOf((*MockGroupRepository)(nil).GetPermissionOverrides), arg0, arg1)
+}
+
+// GetScheduleCardSettings mocks base method.
+func (m *MockGroupRepository) GetScheduleCardSettings(arg0 context.Context, arg1 uuid.UUID) (*domain0.ScheduleCardSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleCardSettings", arg0, arg1)
+	ret0, _ := ret[0].(*domain0.ScheduleCardSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleCardSettings indicates an expected call of GetScheduleCardSettings.
+func (mr *MockGroupRepositoryMockRecorder) GetScheduleCardSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleCardSettings", reflect.TypeOf((*MockGroupRepository)(nil).GetScheduleCardSettings), arg0, arg1)
+}
+
+// IsEventReminded mocks base method.
+func (m *MockGroupRepository) IsEventReminded(arg0 context.Context, arg1 uuid.UUID, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEventReminded", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEventReminded indicates an expected call of IsEventReminded.
+func (mr *MockGroupRepositoryMockRecorder) IsEventReminded(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEventReminded", reflect.TypeOf((*MockGroupRepository)(nil).IsEventReminded), arg0, arg1, arg2)
+}
+
 // IsMember mocks base method.
 func (m *MockGroupRepository) IsMember(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +492,186 @@ func (mr *MockGroupRepositoryMockRecorder) IsMember(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMember", reflect.TypeOf((*MockGroupRepository)(nil).IsMember), arg0, arg1, arg2)
 }
 
+// ListActiveEventRecurrences mocks base method.
+func (m *MockGroupRepository) ListActiveEventRecurrences(arg0 context.Context, arg1 time.Time) ([]*domain0.EventRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveEventRecurrences", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.EventRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveEventRecurrences indicates an expected call of ListActiveEventRecurrences.
+func (mr *MockGroupRepositoryMockRecorder) ListActiveEventRecurrences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveEventRecurrences", reflect.TypeOf((*MockGroupRepository)(nil).ListActiveEventRecurrences), arg0, arg1)
+}
+
+// ListChatIntegrations mocks base method.
+func (m *MockGroupRepository) ListChatIntegrations(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.ChatIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChatIntegrations", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.ChatIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChatIntegrations indicates an expected call of ListChatIntegrations.
+func (mr *MockGroupRepositoryMockRecorder) ListChatIntegrations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChatIntegrations", reflect.TypeOf((*MockGroupRepository)(nil).ListChatIntegrations), arg0, arg1)
+}
+
+// ListChildGroups mocks base method.
+func (m *MockGroupRepository) ListChildGroups(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChildGroups", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChildGroups indicates an expected call of ListChildGroups.
+func (mr *MockGroupRepositoryMockRecorder) ListChildGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChildGroups", reflect.TypeOf((*MockGroupRepository)(nil).ListChildGroups), arg0, arg1)
+}
+
+// ListCustomRoles mocks base method.
+func (m *MockGroupRepository) ListCustomRoles(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomRoles", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomRoles indicates an expected call of ListCustomRoles.
+func (mr *MockGroupRepositoryMockRecorder) ListCustomRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomRoles", reflect.TypeOf((*MockGroupRepository)(nil).ListCustomRoles), arg0, arg1)
+}
+
+// ListEnabledChatIntegrations mocks base method.
+func (m *MockGroupRepository) ListEnabledChatIntegrations(arg0 context.Context) ([]*domain0.ChatIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnabledChatIntegrations", arg0)
+	ret0, _ := ret[0].([]*domain0.ChatIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnabledChatIntegrations indicates an expected call of ListEnabledChatIntegrations.
+func (mr *MockGroupRepositoryMockRecorder) ListEnabledChatIntegrations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledChatIntegrations", reflect.TypeOf((*MockGroupRepository)(nil).ListEnabledChatIntegrations), arg0)
+}
+
+// ListEventExceptions mocks base method.
+func (m *MockGroupRepository) ListEventExceptions(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]*domain0.EventException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventExceptions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain0.EventException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventExceptions indicates an expected call of ListEventExceptions.
+func (mr *MockGroupRepositoryMockRecorder) ListEventExceptions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventExceptions", reflect.TypeOf((*MockGroupRepository)(nil).ListEventExceptions), arg0, arg1, arg2)
+}
+
+// ListEventNotes mocks base method.
+func (m *MockGroupRepository) ListEventNotes(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]*domain0.EventNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain0.EventNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventNotes indicates an expected call of ListEventNotes.
+func (mr *MockGroupRepositoryMockRecorder) ListEventNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventNotes", reflect.TypeOf((*MockGroupRepository)(nil).ListEventNotes), arg0, arg1, arg2)
+}
+
+// ListEventRSVPs mocks base method.
+func (m *MockGroupRepository) ListEventRSVPs(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]*domain0.EventRSVP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventRSVPs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain0.EventRSVP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventRSVPs indicates an expected call of ListEventRSVPs.
+func (mr *MockGroupRepositoryMockRecorder) ListEventRSVPs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventRSVPs", reflect.TypeOf((*MockGroupRepository)(nil).ListEventRSVPs), arg0, arg1, arg2)
+}
+
+// ListEventRecurrences mocks base method.
+func (m *MockGroupRepository) ListEventRecurrences(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.EventRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventRecurrences", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.EventRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventRecurrences indicates an expected call of ListEventRecurrences.
+func (mr *MockGroupRepositoryMockRecorder) ListEventRecurrences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventRecurrences", reflect.TypeOf((*MockGroupRepository)(nil).ListEventRecurrences), arg0, arg1)
+}
+
+// ListEventTaskLinks mocks base method.
+func (m *MockGroupRepository) ListEventTaskLinks(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]*domain0.EventTaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventTaskLinks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain0.EventTaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventTaskLinks indicates an expected call of ListEventTaskLinks.
+func (mr *MockGroupRepositoryMockRecorder) ListEventTaskLinks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventTaskLinks", reflect.TypeOf((*MockGroupRepository)(nil).ListEventTaskLinks), arg0, arg1, arg2)
+}
+
+// ListGroupEventExceptions mocks base method.
+func (m *MockGroupRepository) ListGroupEventExceptions(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.EventException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupEventExceptions", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.EventException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupEventExceptions indicates an expected call of ListGroupEventExceptions.
+func (mr *MockGroupRepositoryMockRecorder) ListGroupEventExceptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupEventExceptions", reflect.TypeOf((*MockGroupRepository)(nil).ListGroupEventExceptions), arg0, arg1)
+}
+
+// ListGroupEventRSVPs mocks base method.
+func (m *MockGroupRepository) ListGroupEventRSVPs(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.EventRSVP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupEventRSVPs", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.EventRSVP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupEventRSVPs indicates an expected call of ListGroupEventRSVPs.
+func (mr *MockGroupRepositoryMockRecorder) ListGroupEventRSVPs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupEventRSVPs", reflect.TypeOf((*MockGroupRepository)(nil).ListGroupEventRSVPs), arg0, arg1)
+}
+
 // ListGroupsByMember mocks base method.
 func (m *MockGroupRepository) ListGroupsByMember(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Pagination) ([]*domain0.Group, int, error) {
 	m.ctrl.T.Helper()
@@ -217,6 +719,36 @@ func (mr *MockGroupRepositoryMockRecorder) ListMembers(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockGroupRepository)(nil).ListMembers), arg0, arg1, arg2)
 }
 
+// ListNoteActionItems mocks base method.
+func (m *MockGroupRepository) ListNoteActionItems(arg0 context.Context, arg1 uuid.UUID) ([]*domain0.NoteActionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNoteActionItems", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.NoteActionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNoteActionItems indicates an expected call of ListNoteActionItems.
+func (mr *MockGroupRepositoryMockRecorder) ListNoteActionItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNoteActionItems", reflect.TypeOf((*MockGroupRepository)(nil).ListNoteActionItems), arg0, arg1)
+}
+
+// ListTaskLinks mocks base method.
+func (m *MockGroupRepository) ListTaskLinks(arg0 context.Context, arg1 string) ([]*domain0.EventTaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskLinks", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.EventTaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskLinks indicates an expected call of ListTaskLinks.
+func (mr *MockGroupRepositoryMockRecorder) ListTaskLinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskLinks", reflect.TypeOf((*MockGroupRepository)(nil).ListTaskLinks), arg0, arg1)
+}
+
 // RemoveMember mocks base method.
 func (m *MockGroupRepository) RemoveMember(arg0 context.Context, arg1, arg2 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -231,6 +763,174 @@ func (mr *MockGroupRepositoryMockRecorder) RemoveMember(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupRepository)(nil).RemoveMember), arg0, arg1, arg2)
 }
 
+// SaveAssignmentSettings mocks base method.
+func (m *MockGroupRepository) SaveAssignmentSettings(arg0 context.Context, arg1 *domain0.AssignmentSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAssignmentSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAssignmentSettings indicates an expected call of SaveAssignmentSettings.
+func (mr *MockGroupRepositoryMockRecorder) SaveAssignmentSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAssignmentSettings", reflect.TypeOf((*MockGroupRepository)(nil).SaveAssignmentSettings), arg0, arg1)
+}
+
+// SaveChatIntegration mocks base method.
+func (m *MockGroupRepository) SaveChatIntegration(arg0 context.Context, arg1 *domain0.ChatIntegration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChatIntegration", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChatIntegration indicates an expected call of SaveChatIntegration.
+func (mr *MockGroupRepositoryMockRecorder) SaveChatIntegration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChatIntegration", reflect.TypeOf((*MockGroupRepository)(nil).SaveChatIntegration), arg0, arg1)
+}
+
+// SaveEventException mocks base method.
+func (m *MockGroupRepository) SaveEventException(arg0 context.Context, arg1 *domain0.EventException) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEventException", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEventException indicates an expected call of SaveEventException.
+func (mr *MockGroupRepositoryMockRecorder) SaveEventException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEventException", reflect.TypeOf((*MockGroupRepository)(nil).SaveEventException), arg0, arg1)
+}
+
+// SaveEventRSVP mocks base method.
+func (m *MockGroupRepository) SaveEventRSVP(arg0 context.Context, arg1 *domain0.EventRSVP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEventRSVP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEventRSVP indicates an expected call of SaveEventRSVP.
+func (mr *MockGroupRepositoryMockRecorder) SaveEventRSVP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEventRSVP", reflect.TypeOf((*MockGroupRepository)(nil).SaveEventRSVP), arg0, arg1)
+}
+
+// SaveEventRecurrence mocks base method.
+func (m *MockGroupRepository) SaveEventRecurrence(arg0 context.Context, arg1 *domain0.EventRecurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEventRecurrence", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEventRecurrence indicates an expected call of SaveEventRecurrence.
+func (mr *MockGroupRepositoryMockRecorder) SaveEventRecurrence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEventRecurrence", reflect.TypeOf((*MockGroupRepository)(nil).SaveEventRecurrence), arg0, arg1)
+}
+
+// SaveEventReminder mocks base method.
+func (m *MockGroupRepository) SaveEventReminder(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEventReminder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEventReminder indicates an expected call of SaveEventReminder.
+func (mr *MockGroupRepositoryMockRecorder) SaveEventReminder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEventReminder", reflect.TypeOf((*MockGroupRepository)(nil).SaveEventReminder), arg0, arg1, arg2, arg3)
+}
+
+// SaveEventTaskLink mocks base method.
+func (m *MockGroupRepository) SaveEventTaskLink(arg0 context.Context, arg1 *domain0.EventTaskLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEventTaskLink", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEventTaskLink indicates an expected call of SaveEventTaskLink.
+func (mr *MockGroupRepositoryMockRecorder) SaveEventTaskLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEventTaskLink", reflect.TypeOf((*MockGroupRepository)(nil).SaveEventTaskLink), arg0, arg1)
+}
+
+// SaveLeaderboardPreference mocks base method.
+func (m *MockGroupRepository) SaveLeaderboardPreference(arg0 context.Context, arg1 *domain0.LeaderboardPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLeaderboardPreference", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLeaderboardPreference indicates an expected call of SaveLeaderboardPreference.
+func (mr *MockGroupRepositoryMockRecorder) SaveLeaderboardPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLeaderboardPreference", reflect.TypeOf((*MockGroupRepository)(nil).SaveLeaderboardPreference), arg0, arg1)
+}
+
+// SaveLeaderboardSettings mocks base method.
+func (m *MockGroupRepository) SaveLeaderboardSettings(arg0 context.Context, arg1 *domain0.LeaderboardSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLeaderboardSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLeaderboardSettings indicates an expected call of SaveLeaderboardSettings.
+func (mr *MockGroupRepositoryMockRecorder) SaveLeaderboardSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLeaderboardSettings", reflect.TypeOf((*MockGroupRepository)(nil).SaveLeaderboardSettings), arg0, arg1)
+}
+
+// SaveNoteActionItem mocks base method.
+func (m *MockGroupRepository) SaveNoteActionItem(arg0 context.Context, arg1 *domain0.NoteActionItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNoteActionItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNoteActionItem indicates an expected call of SaveNoteActionItem.
+func (mr *MockGroupRepositoryMockRecorder) SaveNoteActionItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNoteActionItem", reflect.TypeOf((*MockGroupRepository)(nil).SaveNoteActionItem), arg0, arg1)
+}
+
+// SavePermissionOverrides mocks base method.
+func (m *MockGroupRepository) SavePermissionOverrides(arg0 context.Context, arg1 uuid.UUID, arg2 domain0.PermissionMatrix) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePermissionOverrides", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePermissionOverrides indicates an expected call of SavePermissionOverrides.
+func (mr *MockGroupRepositoryMockRecorder) SavePermissionOverrides(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePermissionOverrides", reflect.TypeOf((*MockGroupRepository)(nil).SavePermissionOverrides), arg0, arg1, arg2)
+}
+
+// SaveScheduleCardSettings mocks base method.
+func (m *MockGroupRepository) SaveScheduleCardSettings(arg0 context.Context, arg1 *domain0.ScheduleCardSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveScheduleCardSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveScheduleCardSettings indicates an expected call of SaveScheduleCardSettings.
+func (mr *MockGroupRepositoryMockRecorder) SaveScheduleCardSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveScheduleCardSettings", reflect.TypeOf((*MockGroupRepository)(nil).SaveScheduleCardSettings), arg0, arg1)
+}
+
 // SearchGroups mocks base method.
 func (m *MockGroupRepository) SearchGroups(arg0 context.Context, arg1 string, arg2 *domain0.GroupType, arg3 domain.Pagination) ([]*domain0.Group, int, error) {
 	m.ctrl.T.Helper()
@@ -247,6 +947,34 @@ func (mr *MockGroupRepositoryMockRecorder) SearchGroups(arg0, arg1, arg2, arg3 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchGroups", reflect.TypeOf((*MockGroupRepository)(nil).SearchGroups), arg0, arg1, arg2, arg3)
 }
 
+// UpdateCustomRole mocks base method.
+func (m *MockGroupRepository) UpdateCustomRole(arg0 context.Context, arg1 *domain0.CustomRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomRole indicates an expected call of UpdateCustomRole.
+func (mr *MockGroupRepositoryMockRecorder) UpdateCustomRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomRole", reflect.TypeOf((*MockGroupRepository)(nil).UpdateCustomRole), arg0, arg1)
+}
+
+// UpdateEventNote mocks base method.
+func (m *MockGroupRepository) UpdateEventNote(arg0 context.Context, arg1 *domain0.EventNote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventNote indicates an expected call of UpdateEventNote.
+func (mr *MockGroupRepositoryMockRecorder) UpdateEventNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventNote", reflect.TypeOf((*MockGroupRepository)(nil).UpdateEventNote), arg0, arg1)
+}
+
 // UpdateGroup mocks base method.
 func (m *MockGroupRepository) UpdateGroup(arg0 context.Context, arg1 *domain0.Group) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	// ErrNoteNotFound は議事録が見つからない（タスクが議事録から作成したものでない）ことを表すエラー
+	ErrNoteNotFound = errors.New("note not found")
+
+	// ErrInvalidNote は議事録の入力が不正であることを表すエラー
+	ErrInvalidNote = errors.New("invalid note")
+)
+
+// EventNoteInput は議事録の作成・更新の入力
+type EventNoteInput struct {
+	Title   string
+	Content string // Markdown
+}
+
+// CreateEventNote は予定に議事録を付ける（タスクの作成権限が必要）
+// 本文の未完了のタスクリストの行からグループタスクを作成し、行でメンションしたメンバーに割り当てる
+func (s *groupService) CreateEventNote(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID, input EventNoteInput) (*domain.EventNote, error) {
+	now := time.Now()
+	note := &domain.EventNote{
+		ID:        uuid.New(),
+		GroupID:   groupID,
+		Title:     input.Title,
+		Content:   input.Content,
+		CreatedBy: requesterID,
+		UpdatedBy: requesterID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := note.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNote, err)
+	}
+
+	canCreate, err := s.CheckPermission(ctx, groupID, requesterID, ActionCreateTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canCreate {
+		return nil, ErrInsufficientPermissions
+	}
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	note.EventID = event.ID
+
+	if err := s.groupRepo.CreateEventNote(ctx, note); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to create note", logger.Error(err))
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+
+	if err := s.createActionItemTasks(ctx, note, nil); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Event note created",
+		logger.Any("groupID", groupID),
+		logger.Any("eventID", note.EventID),
+		logger.Any("noteID", note.ID),
+		logger.Any("actionItems", len(note.ActionItems)))
+	return note, nil
+}
+
+// ListEventNotes は予定の議事録を作成日時の古い順に取得する（予定の閲覧権限が必要）
+func (s *groupService) ListEventNotes(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) ([]*domain.EventNote, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	event, err := s.getEvent(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.groupRepo.ListEventNotes(ctx, groupID, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	for _, note := range notes {
+		if err := s.loadActionItems(ctx, note); err != nil {
+			return nil, err
+		}
+	}
+	return notes, nil
+}
+
+// GetEventNote は議事録を取得する（予定の閲覧権限が必要）
+func (s *groupService) GetEventNote(ctx context.Context, groupID, noteID, requesterID uuid.UUID) (*domain.EventNote, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	return s.getNote(ctx, groupID, noteID)
+}
+
+// UpdateEventNote は議事録を更新する（議事録の作成者またはタスクの編集権限を持つメンバーのみ）
+// 追加された未完了のタスクリストの行からタスクを作成する。本文から消した行のタスクは削除しない
+func (s *groupService) UpdateEventNote(ctx context.Context, groupID, noteID, requesterID uuid.UUID, input EventNoteInput) (*domain.EventNote, error) {
+	note, err := s.getNote(ctx, groupID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeNoteChange(ctx, note, requesterID); err != nil {
+		return nil, err
+	}
+
+	note.Title = input.Title
+	note.Content = input.Content
+	if err := note.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNote, err)
+	}
+	note.UpdatedBy = requesterID
+	note.UpdatedAt = time.Now()
+
+	if err := s.groupRepo.UpdateEventNote(ctx, note); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update note", logger.Error(err))
+		return nil, fmt.Errorf("failed to update note: %w", err)
+	}
+
+	// アクションアイテムのタスクは更新したメンバーが作成する
+	if err := s.createActionItemTasks(ctx, note, note.ActionItems); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// DeleteEventNote は議事録を削除する（議事録の作成者またはタスクの編集権限を持つメンバーのみ）
+// 議事録から作成したタスクは削除しない
+func (s *groupService) DeleteEventNote(ctx context.Context, groupID, noteID, requesterID uuid.UUID) error {
+	note, err := s.getNote(ctx, groupID, noteID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeNoteChange(ctx, note, requesterID); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.DeleteEventNote(ctx, groupID, noteID); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to delete note", logger.Error(err))
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Event note deleted",
+		logger.Any("groupID", groupID),
+		logger.Any("noteID", noteID),
+		logger.Any("deletedBy", requesterID))
+	return nil
+}
+
+// GetTaskSourceNote はタスクを作成した元の議事録を取得する（予定の閲覧権限が必要）
+func (s *groupService) GetTaskSourceNote(ctx context.Context, groupID uuid.UUID, taskID string, requesterID uuid.UUID) (*domain.EventNote, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	item, err := s.groupRepo.GetNoteActionItemByTask(ctx, groupID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action item: %w", err)
+	}
+	if item == nil {
+		return nil, ErrNoteNotFound
+	}
+	return s.getNote(ctx, groupID, item.NoteID)
+}
+
+// getNote はグループの議事録を作成したタスクとともに取得する
+func (s *groupService) getNote(ctx context.Context, groupID, noteID uuid.UUID) (*domain.EventNote, error) {
+	note, err := s.groupRepo.GetEventNote(ctx, groupID, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+	if note == nil {
+		return nil, ErrNoteNotFound
+	}
+	if err := s.loadActionItems(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// loadActionItems は議事録から作成したタスクを設定する
+func (s *groupService) loadActionItems(ctx context.Context, note *domain.EventNote) error {
+	items, err := s.groupRepo.ListNoteActionItems(ctx, note.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get action items: %w", err)
+	}
+	note.ActionItems = items
+	if note.ActionItems == nil {
+		note.ActionItems = []*domain.NoteActionItem{}
+	}
+	return nil
+}
+
+// authorizeNoteChange は議事録を変更できるか確認する（議事録の作成者またはタスクの編集権限を持つメンバー）
+func (s *groupService) authorizeNoteChange(ctx context.Context, note *domain.EventNote, requesterID uuid.UUID) error {
+	if note.CreatedBy == requesterID {
+		isEditor, err := s.isNonGuestMember(ctx, note.GroupID, requesterID)
+		if err != nil || isEditor {
+			return err
+		}
+	}
+	canEdit, err := s.CheckPermission(ctx, note.GroupID, requesterID, ActionEditTasks)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canEdit {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// createActionItemTasks は議事録の本文のアクションアイテムのうち、タスクを作成していないものからタスクを作成する
+// タスクの作成に失敗したアイテムは次回の更新で作成し直せるよう記録せずに続ける
+func (s *groupService) createActionItemTasks(ctx context.Context, note *domain.EventNote, existing []*domain.NoteActionItem) error {
+	note.ActionItems = existing
+	if note.ActionItems == nil {
+		note.ActionItems = []*domain.NoteActionItem{}
+	}
+	if s.groupTasks == nil {
+		return nil
+	}
+
+	created := make(map[string]bool, len(existing))
+	for _, item := range existing {
+		created[item.Text] = true
+	}
+	var pending []domain.ActionItem
+	for _, item := range domain.ExtractActionItems(note.Content) {
+		if !created[item.Text] {
+			pending = append(pending, item)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	usernames, err := s.memberUsernames(ctx, note.GroupID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pending {
+		// 最初にメンションしたメンバーを担当者にする（メンバーでないユーザーは無視する）
+		var assigneeID *uuid.UUID
+		for _, mention := range item.Mentions {
+			if userID, ok := usernames[strings.ToLower(mention)]; ok {
+				assigneeID = &userID
+				break
+			}
+		}
+
+		taskID, err := s.groupTasks.CreateGroupTask(ctx, GroupTaskInput{
+			GroupID:     note.GroupID,
+			CreatedBy:   note.UpdatedBy,
+			AssigneeID:  assigneeID,
+			Title:       item.Text,
+			Description: fmt.Sprintf("議事録「%s」から作成しました。\n/groups/%s/notes/%s", note.Title, note.GroupID, note.ID),
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to create task from action item",
+				logger.Any("groupID", note.GroupID),
+				logger.Any("noteID", note.ID),
+				logger.Error(err))
+			continue
+		}
+
+		actionItem := &domain.NoteActionItem{
+			NoteID:     note.ID,
+			GroupID:    note.GroupID,
+			TaskID:     taskID,
+			Text:       item.Text,
+			AssigneeID: assigneeID,
+			CreatedAt:  time.Now(),
+		}
+		if err := s.groupRepo.SaveNoteActionItem(ctx, actionItem); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to save action item", logger.Error(err))
+			return fmt.Errorf("failed to save action item: %w", err)
+		}
+		note.ActionItems = append(note.ActionItems, actionItem)
+	}
+	return nil
+}
+
+// memberUsernames はグループのメンバーのユーザー名（小文字）からユーザーIDへの対応を返す
+func (s *groupService) memberUsernames(ctx context.Context, groupID uuid.UUID) (map[string]uuid.UUID, error) {
+	usernames := make(map[string]uuid.UUID)
+	if s.userValidator == nil {
+		return usernames, nil
+	}
+
+	memberIDs, err := s.eventMemberIDs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(memberIDs))
+	for i, id := range memberIDs {
+		ids[i] = id.String()
+	}
+	infos, err := s.userValidator.GetUsersInfoBatch(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member info: %w", err)
+	}
+	for _, memberID := range memberIDs {
+		if info, ok := infos[memberID.String()]; ok && info != nil && info.Username != "" {
+			usernames[strings.ToLower(info.Username)] = memberID
+		}
+	}
+	return usernames, nil
+}
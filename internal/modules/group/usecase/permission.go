@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ErrInvalidPermissionMatrix はグループの権限設定が不正であることを表すエラー
+var ErrInvalidPermissionMatrix = errors.New("invalid permission matrix")
+
+// permissionMemberPageSize はアクションを実行できるメンバーを探す際にメンバーを取得する件数
+const permissionMemberPageSize = 500
+
+// permissionService はグループでの権限を判定する
+// メンバーのロール（カスタムロール・上位グループの管理者を含む）とグループごとの権限設定から判定し、グループの操作はすべてこの判定を通す
+type permissionService struct {
+	groupRepo GroupRepository
+}
+
+func newPermissionService(groupRepo GroupRepository) *permissionService {
+	return &permissionService{groupRepo: groupRepo}
+}
+
+// Can はユーザーがグループでアクションを実行できるかチェックする
+func (p *permissionService) Can(ctx context.Context, groupID, userID uuid.UUID, action GroupAction) (bool, error) {
+	// メンバーかどうかチェック
+	isMember, err := p.groupRepo.IsMember(ctx, groupID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if isMember {
+		// 権限取得
+		role, err := p.groupRepo.GetMemberRole(ctx, groupID, userID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get member role: %w", err)
+		}
+
+		// 権限チェック
+		allowed, err := p.allows(ctx, groupID, role, action)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	// 上位グループのオーナー・管理者はサブチームでも管理者と同じ権限を持つ
+	group, err := p.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil || !group.IsSubGroup() {
+		return false, nil
+	}
+	inherited, err := p.isAncestorManager(ctx, group, userID)
+	if err != nil || !inherited {
+		return false, err
+	}
+	return p.allows(ctx, groupID, domain.RoleAdmin, action)
+}
+
+// Matrix はデフォルトの権限にグループで変更した権限を反映して返す
+func (p *permissionService) Matrix(ctx context.Context, groupID uuid.UUID) (domain.PermissionMatrix, error) {
+	overrides, err := p.groupRepo.GetPermissionOverrides(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permission overrides: %w", err)
+	}
+	return domain.DefaultPermissionMatrix().Merge(overrides), nil
+}
+
+// allows はロールがアクションを実行できるかチェックする
+// カスタムロールはロールに設定した権限で判定し、オーナーと変更できないアクションはグループの権限設定を参照しない
+func (p *permissionService) allows(ctx context.Context, groupID uuid.UUID, role domain.MemberRole, action GroupAction) (bool, error) {
+	if roleID, ok := role.CustomRoleID(); ok {
+		customRole, err := p.groupRepo.GetCustomRole(ctx, groupID, roleID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get custom role: %w", err)
+		}
+		return customRole != nil && customRole.Allows(action), nil
+	}
+	if role == domain.RoleOwner || !domain.IsConfigurableAction(action) {
+		return domain.DefaultPermissionMatrix().Allows(role, action), nil
+	}
+
+	matrix, err := p.Matrix(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+	return matrix.Allows(role, action), nil
+}
+
+// isAncestorManager はユーザーが上位グループのいずれかでオーナーまたは管理者かチェックする
+func (p *permissionService) isAncestorManager(ctx context.Context, group *domain.Group, userID uuid.UUID) (bool, error) {
+	ancestors, err := p.getAncestors(ctx, group)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ancestor := range ancestors {
+		member, err := p.groupRepo.GetMember(ctx, ancestor.ID, userID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get member: %w", err)
+		}
+		if member != nil && member.CanManageGroup() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getAncestors は親グループから順に上位グループを取得する
+// 循環した親子関係があっても MaxGroupDepth 以上はたどらない
+func (p *permissionService) getAncestors(ctx context.Context, group *domain.Group) ([]*domain.Group, error) {
+	var ancestors []*domain.Group
+	visited := map[uuid.UUID]bool{group.ID: true}
+
+	parentID := group.ParentGroupID
+	for parentID != nil && !visited[*parentID] && len(ancestors) < domain.MaxGroupDepth {
+		parent, err := p.groupRepo.GetGroupByID(ctx, *parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent group: %w", err)
+		}
+		if parent == nil {
+			break
+		}
+		visited[parent.ID] = true
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentGroupID
+	}
+	return ancestors, nil
+}
+
+// ListMemberIDsWithPermission はグループでアクションを実行できるメンバーのユーザーIDを取得する（他のモジュールからの通知先の決定用）
+func (s *groupService) ListMemberIDsWithPermission(ctx context.Context, groupID uuid.UUID, action GroupAction) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	for page := 1; ; page++ {
+		members, err := s.groupRepo.ListMembers(ctx, groupID, commonDomain.Pagination{Page: page, PageSize: permissionMemberPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members: %w", err)
+		}
+		for _, member := range members {
+			allowed, err := s.permissions.Can(ctx, groupID, member.UserID, action)
+			if err != nil {
+				return nil, err
+			}
+			if allowed {
+				userIDs = append(userIDs, member.UserID)
+			}
+		}
+		if len(members) < permissionMemberPageSize {
+			break
+		}
+	}
+	return userIDs, nil
+}
+
+// GetPermissionMatrix はグループの権限設定を取得する（メンバーのみ）
+func (s *groupService) GetPermissionMatrix(ctx context.Context, groupID, requesterID uuid.UUID) (domain.PermissionMatrix, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	return s.permissions.Matrix(ctx, groupID)
+}
+
+// UpdatePermissionMatrix はグループの権限設定を変更する（オーナーのみ）
+// 指定しなかったアクションはデフォルトの権限に戻る
+func (s *groupService) UpdatePermissionMatrix(ctx context.Context, groupID, requesterID uuid.UUID, overrides domain.PermissionMatrix) (domain.PermissionMatrix, error) {
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil {
+		return nil, errors.New("group not found")
+	}
+	if group.OwnerID != requesterID {
+		return nil, ErrInsufficientPermissions
+	}
+
+	if err := overrides.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPermissionMatrix, err)
+	}
+
+	if err := s.groupRepo.SavePermissionOverrides(ctx, groupID, overrides); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save permission overrides", logger.Error(err))
+		return nil, fmt.Errorf("failed to save permission overrides: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Group permissions updated",
+		logger.Any("groupID", groupID),
+		logger.Int("overrides", len(overrides)))
+	return domain.DefaultPermissionMatrix().Merge(overrides), nil
+}
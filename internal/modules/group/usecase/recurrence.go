@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	// ErrInvalidRecurrence は予定の繰り返しの設定・回の変更が不正であることを表すエラー
+	ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+	// ErrRecurrenceNotFound は予定に繰り返しが設定されていないことを表すエラー
+	ErrRecurrenceNotFound = errors.New("recurrence not found")
+
+	// ErrEventStarted は開始済みの回を変更しようとしたことを表すエラー
+	ErrEventStarted = errors.New("event has already started")
+
+	// ErrInvalidEventRange は予定の一覧の期間が不正であることを表すエラー
+	ErrInvalidEventRange = errors.New("invalid event range")
+)
+
+// EventRecurrenceInput は予定の繰り返しの設定の入力
+type EventRecurrenceInput struct {
+	Frequency domain.RecurrenceFrequency
+	Interval  int
+	Weekdays  []string
+	Count     int
+	Until     *time.Time
+	Timezone  string // 空の場合は UTC
+	// 回が祝日になった場合の扱い（空の場合はそのまま行う）
+	HolidayPolicy domain.RecurrenceHolidayPolicy
+}
+
+// OccurrenceChangeInput は繰り返しの予定の1回分の変更の入力（中止するか、StartsAt に移動する）
+type OccurrenceChangeInput struct {
+	Cancelled bool
+	StartsAt  *time.Time
+}
+
+// SetHolidayProvider は繰り返しの予定の祝日の扱いに使う祝日の取得元を設定する
+func (s *groupService) SetHolidayProvider(holidays holiday.Provider) {
+	s.holidays = holidays
+}
+
+// ListEventOccurrences は予定共有グループの開始日時が[from, to)の予定を、繰り返しの予定を回ごとに展開して取得する（予定の閲覧権限が必要）
+func (s *groupService) ListEventOccurrences(ctx context.Context, groupID, requesterID uuid.UUID, from, to time.Time) (*domain.EventSchedule, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: 'to' must be after 'from'", ErrInvalidEventRange)
+	}
+	if to.Sub(from) > domain.MaxEventRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range must be within %d days", ErrInvalidEventRange, domain.MaxEventRangeDays)
+	}
+
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	return s.listEventOccurrences(ctx, groupID, from, to)
+}
+
+// listEventOccurrences は予定共有グループの[from, to)の予定を回ごとに展開して取得する（権限は呼び出し元で確認する）
+func (s *groupService) listEventOccurrences(ctx context.Context, groupID uuid.UUID, from, to time.Time) (*domain.EventSchedule, error) {
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil {
+		return nil, errors.New("group not found")
+	}
+
+	schedule := &domain.EventSchedule{
+		GroupID:   groupID,
+		GroupName: group.Name,
+		From:      from,
+		To:        to,
+		Events:    []*domain.GroupEvent{},
+	}
+	if s.events == nil || group.Type != domain.GroupTypeSchedule {
+		return schedule, nil
+	}
+
+	events, err := s.events.ListGroupEvents(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	expanded, err := s.expandGroupEvents(ctx, groupID, events, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if expanded != nil {
+		schedule.Events = expanded
+	}
+	return schedule, nil
+}
+
+// GetEventRecurrence は予定の繰り返しの設定を取得する（予定の閲覧権限が必要）
+func (s *groupService) GetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) (*domain.EventRecurrence, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	if _, err := s.getSeries(ctx, groupID, eventID); err != nil {
+		return nil, err
+	}
+	recurrence, err := s.groupRepo.GetEventRecurrence(ctx, groupID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrence: %w", err)
+	}
+	if recurrence == nil {
+		return nil, ErrRecurrenceNotFound
+	}
+	return recurrence, nil
+}
+
+// SetEventRecurrence は予定に繰り返しを設定する（予定の作成者またはタスクの編集権限を持つメンバーのみ）
+// 予定の開始日時を最初の回とし、設定後の出欠・リマインダーは回ごとに扱う。設定を変更しても回ごとの変更は残す
+func (s *groupService) SetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID, input EventRecurrenceInput) (*domain.EventRecurrence, error) {
+	event, err := s.getSeries(ctx, groupID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventChange(ctx, groupID, event, requesterID); err != nil {
+		return nil, err
+	}
+
+	recurrence := &domain.EventRecurrence{
+		GroupID:       groupID,
+		EventID:       eventID,
+		Frequency:     input.Frequency,
+		Interval:      input.Interval,
+		Weekdays:      append([]string(nil), input.Weekdays...),
+		Count:         input.Count,
+		Until:         input.Until,
+		Timezone:      input.Timezone,
+		HolidayPolicy: input.HolidayPolicy,
+		CreatedBy:     requesterID,
+		UpdatedAt:     time.Now(),
+	}
+	if recurrence.Timezone == "" {
+		recurrence.Timezone = "UTC"
+	}
+	if err := recurrence.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	if recurrence.Until != nil && recurrence.Until.Before(event.StartsAt) {
+		return nil, fmt.Errorf("%w: until must be after the first occurrence", ErrInvalidRecurrence)
+	}
+
+	existing, err := s.groupRepo.GetEventRecurrence(ctx, groupID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrence: %w", err)
+	}
+	if existing != nil {
+		recurrence.CreatedBy = existing.CreatedBy
+	}
+
+	if err := s.groupRepo.SaveEventRecurrence(ctx, recurrence); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save recurrence", logger.Error(err))
+		return nil, fmt.Errorf("failed to save recurrence: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Event recurrence updated",
+		logger.Any("groupID", groupID),
+		logger.Any("eventID", eventID),
+		logger.Any("frequency", recurrence.Frequency),
+		logger.Any("updatedBy", requesterID))
+	return recurrence, nil
+}
+
+// DeleteEventRecurrence は予定の繰り返しと回ごとの変更を削除し、1回だけの予定に戻す（予定の作成者またはタスクの編集権限を持つメンバーのみ）
+func (s *groupService) DeleteEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) error {
+	event, err := s.getSeries(ctx, groupID, eventID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventChange(ctx, groupID, event, requesterID); err != nil {
+		return err
+	}
+
+	recurrence, err := s.groupRepo.GetEventRecurrence(ctx, groupID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get recurrence: %w", err)
+	}
+	if recurrence == nil {
+		return ErrRecurrenceNotFound
+	}
+
+	if err := s.groupRepo.DeleteEventRecurrence(ctx, groupID, eventID); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to delete recurrence", logger.Error(err))
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Event recurrence deleted",
+		logger.Any("groupID", groupID),
+		logger.Any("eventID", eventID),
+		logger.Any("deletedBy", requesterID))
+	return nil
+}
+
+// ChangeOccurrence は繰り返しの予定の1回分を中止するか、日時を変更する（予定の作成者またはタスクの編集権限を持つメンバーのみ、その回の開始前まで）
+// 日時を変更した回の出欠はそのまま引き継ぐ。中止した回を変更する場合は RestoreOccurrence で元に戻してから変更する
+func (s *groupService) ChangeOccurrence(ctx context.Context, groupID uuid.UUID, occurrenceID string, requesterID uuid.UUID, input OccurrenceChangeInput) (*domain.EventException, error) {
+	exception := &domain.EventException{
+		GroupID:   groupID,
+		Cancelled: input.Cancelled,
+		StartsAt:  input.StartsAt,
+		UpdatedBy: requesterID,
+		UpdatedAt: time.Now(),
+	}
+	if err := exception.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	if exception.StartsAt != nil && !exception.StartsAt.After(exception.UpdatedAt) {
+		return nil, fmt.Errorf("%w: starts_at must be in the future", ErrInvalidRecurrence)
+	}
+
+	occurrence, err := s.getOccurrence(ctx, groupID, occurrenceID, requesterID)
+	if err != nil {
+		return nil, err
+	}
+	if occurrence.HasStarted(exception.UpdatedAt) {
+		return nil, ErrEventStarted
+	}
+
+	exception.EventID = occurrence.SeriesID
+	exception.OriginalStartsAt = *occurrence.OriginalStartsAt
+	if err := s.groupRepo.SaveEventException(ctx, exception); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save occurrence change", logger.Error(err))
+		return nil, fmt.Errorf("failed to save occurrence change: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Event occurrence changed",
+		logger.Any("groupID", groupID),
+		logger.Any("occurrenceID", occurrenceID),
+		logger.Any("cancelled", exception.Cancelled),
+		logger.Any("updatedBy", requesterID))
+	return exception, nil
+}
+
+// RestoreOccurrence は繰り返しの予定の1回分の変更を取り消し、元の日時に戻す（予定の作成者またはタスクの編集権限を持つメンバーのみ）
+func (s *groupService) RestoreOccurrence(ctx context.Context, groupID uuid.UUID, occurrenceID string, requesterID uuid.UUID) error {
+	// 中止した回も戻せるよう、変更の有無によらず繰り返しに含まれる回を対象にする
+	seriesID, original, err := domain.ParseOccurrenceID(occurrenceID)
+	if err != nil || original == nil {
+		return ErrEventNotFound
+	}
+	event, err := s.getSeries(ctx, groupID, seriesID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventChange(ctx, groupID, event, requesterID); err != nil {
+		return err
+	}
+	recurrence, err := s.groupRepo.GetEventRecurrence(ctx, groupID, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to get recurrence: %w", err)
+	}
+	if recurrence == nil || !recurrence.Includes(event.StartsAt, *original) {
+		return ErrEventNotFound
+	}
+
+	if err := s.groupRepo.DeleteEventException(ctx, groupID, seriesID, *original); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to delete occurrence change", logger.Error(err))
+		return fmt.Errorf("failed to delete occurrence change: %w", err)
+	}
+	return nil
+}
+
+// getOccurrence は繰り返しの予定の回を取得し、変更できるメンバーか確認する
+func (s *groupService) getOccurrence(ctx context.Context, groupID uuid.UUID, occurrenceID string, requesterID uuid.UUID) (*domain.GroupEvent, error) {
+	seriesID, original, err := domain.ParseOccurrenceID(occurrenceID)
+	if err != nil || original == nil {
+		return nil, ErrEventNotFound
+	}
+	series, err := s.getSeries(ctx, groupID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventChange(ctx, groupID, series, requesterID); err != nil {
+		return nil, err
+	}
+	return s.getEvent(ctx, groupID, occurrenceID)
+}
+
+// authorizeEventChange は予定の作成者またはタスクの編集権限を持つメンバーか確認する
+func (s *groupService) authorizeEventChange(ctx context.Context, groupID uuid.UUID, event *domain.GroupEvent, requesterID uuid.UUID) error {
+	if event.CreatedBy == requesterID {
+		isEditor, err := s.isNonGuestMember(ctx, groupID, requesterID)
+		if err != nil || isEditor {
+			return err
+		}
+	}
+	canEdit, err := s.CheckPermission(ctx, groupID, requesterID, ActionEditTasks)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canEdit {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// isNonGuestMember はゲスト以外のメンバーか確認する
+// 作成者は自分の予定・議事録を変更できるが、ゲストに変更された後は変更できない
+func (s *groupService) isNonGuestMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if !isMember {
+		return false, nil
+	}
+	role, err := s.groupRepo.GetMemberRole(ctx, groupID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get member role: %w", err)
+	}
+	return role != domain.RoleGuest, nil
+}
+
+// expandGroupEvents はグループの予定を開始日時が[from, to)の回に展開する
+func (s *groupService) expandGroupEvents(ctx context.Context, groupID uuid.UUID, events []*domain.GroupEvent, from, to time.Time) ([]*domain.GroupEvent, error) {
+	recurrences, err := s.groupRepo.ListEventRecurrences(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrences: %w", err)
+	}
+	exceptions, err := s.groupRepo.ListGroupEventExceptions(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence changes: %w", err)
+	}
+
+	recurrenceByEvent := make(map[string]*domain.EventRecurrence, len(recurrences))
+	for _, recurrence := range recurrences {
+		recurrenceByEvent[recurrence.EventID] = recurrence
+	}
+	exceptionsByEvent := make(map[string][]*domain.EventException)
+	for _, exception := range exceptions {
+		exceptionsByEvent[exception.EventID] = append(exceptionsByEvent[exception.EventID], exception)
+	}
+
+	var expanded []*domain.GroupEvent
+	for _, event := range events {
+		expanded = append(expanded, domain.ExpandEvent(event, recurrenceByEvent[event.ID], exceptionsByEvent[event.ID], from, to, s.holidays)...)
+	}
+	domain.SortGroupEvents(expanded)
+	return expanded, nil
+}
+
+// upcomingEvents は開始日時が[from, to)の予定を、繰り返しの予定を回ごとに展開してすべてのグループから取得する
+func (s *groupService) upcomingEvents(ctx context.Context, from, to time.Time) ([]*domain.GroupEvent, error) {
+	recurrences, err := s.groupRepo.ListActiveEventRecurrences(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurrences: %w", err)
+	}
+	recurring := make(map[string]bool, len(recurrences))
+	for _, recurrence := range recurrences {
+		recurring[recurrence.GroupID.String()+"/"+recurrence.EventID] = true
+	}
+
+	events, err := s.events.ListUpcomingEvents(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming events: %w", err)
+	}
+	var upcoming []*domain.GroupEvent
+	for _, event := range events {
+		// 繰り返しの予定は最初の回も含めて下で回ごとに展開する
+		if !recurring[event.GroupID.String()+"/"+event.ID] {
+			upcoming = append(upcoming, event)
+		}
+	}
+
+	for _, recurrence := range recurrences {
+		event, err := s.events.GetGroupEvent(ctx, recurrence.GroupID, recurrence.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		if event == nil {
+			continue
+		}
+		exceptions, err := s.groupRepo.ListEventExceptions(ctx, recurrence.GroupID, recurrence.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get occurrence changes: %w", err)
+		}
+		upcoming = append(upcoming, domain.ExpandEvent(event, recurrence, exceptions, from, to, s.holidays)...)
+	}
+	return upcoming, nil
+}
@@ -2,9 +2,12 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/group/domain"
 )
 
@@ -22,9 +25,20 @@ type GroupService interface {
 	GetMyGroups(ctx context.Context, userID uuid.UUID, groupType *domain.GroupType, pagination commonDomain.Pagination) ([]*domain.Group, int, error)
 	SearchGroups(ctx context.Context, query string, groupType *domain.GroupType, pagination commonDomain.Pagination) ([]*domain.Group, int, error)
 
+	// サブチーム
+	GetGroupTree(ctx context.Context, groupID, requesterID uuid.UUID) (*GroupTree, error)
+
 	// メンバー管理
 	AddMember(ctx context.Context, groupID, userID, inviterID uuid.UUID, role domain.MemberRole) error
+	AddMembers(ctx context.Context, groupID, inviterID uuid.UUID, userIDs []uuid.UUID, role domain.MemberRole) ([]*BulkAddMemberResult, error)
 	RemoveMember(ctx context.Context, groupID, userID, requesterID uuid.UUID) error
+	RemoveMemberWithUndo(ctx context.Context, groupID, userID, requesterID uuid.UUID) (*undo.Token, error)
+	// SetUndoScheduler はメンバー削除の取り消しに使うジョブキューを設定する
+	SetUndoScheduler(scheduler undo.Scheduler)
+	// SetQuotaChecker はグループ作成時にオーナーのグループ数の上限を確認するQuotaCheckerを設定する
+	SetQuotaChecker(quotas commonDomain.QuotaChecker)
+	// SetChangeRecorder はグループとメンバーの変更を記録するオフライン同期用の変更フィードを設定する
+	SetChangeRecorder(recorder commonDomain.ChangeRecorder)
 	UpdateMemberRole(ctx context.Context, groupID, userID, requesterID uuid.UUID, newRole domain.MemberRole) error
 	GetMembers(ctx context.Context, groupID uuid.UUID, pagination commonDomain.Pagination) ([]*MemberWithUserInfo, error)
 
@@ -32,8 +46,87 @@ type GroupService interface {
 	InviteFriendsToGroup(ctx context.Context, groupID, inviterID uuid.UUID, friendIDs []uuid.UUID, message string) ([]*GroupInviteResult, error)
 	GetAvailableFriends(ctx context.Context, groupID, userID uuid.UUID) ([]*AvailableFriend, error)
 
+	// タスクの自動割り当て
+	GetAssignmentSettings(ctx context.Context, groupID, requesterID uuid.UUID) (*domain.AssignmentSettings, error)
+	UpdateAssignmentPolicy(ctx context.Context, groupID, requesterID uuid.UUID, policy domain.AssignmentPolicy) (*domain.AssignmentSettings, error)
+	PickTaskAssignee(ctx context.Context, groupID, requesterID uuid.UUID, counter OpenTaskCounter) (*uuid.UUID, error)
+
+	// リーダーボード
+	// SetTaskCompletionProvider はリーダーボードの集計に使うタスクの完了記録の取得元を設定する
+	SetTaskCompletionProvider(provider TaskCompletionProvider)
+	GetLeaderboard(ctx context.Context, groupID, requesterID uuid.UUID, previous bool) (*domain.Leaderboard, error)
+	GetLeaderboardSettings(ctx context.Context, groupID, requesterID uuid.UUID) (*LeaderboardSettingsView, error)
+	UpdateLeaderboardSettings(ctx context.Context, groupID, requesterID uuid.UUID, input LeaderboardSettingsInput) (*domain.LeaderboardSettings, error)
+	UpdateLeaderboardVisibility(ctx context.Context, groupID, userID uuid.UUID, visibility domain.LeaderboardVisibility) (*domain.LeaderboardPreference, error)
+
+	// スケジュールカード
+	// SetScheduleCardSigner はスケジュールカードの共有リンクの署名鍵と公開URLのベースURLを設定する
+	SetScheduleCardSigner(secret []byte, baseURL string)
+	GetScheduleCardSettings(ctx context.Context, groupID, requesterID uuid.UUID) (*domain.ScheduleCardSettings, error)
+	UpdateScheduleCardSettings(ctx context.Context, groupID, requesterID uuid.UUID, input ScheduleCardSettingsInput) (*domain.ScheduleCardSettings, error)
+	CreateScheduleCardLink(ctx context.Context, groupID, requesterID uuid.UUID) (*ScheduleCardLinkView, error)
+	RenderScheduleCard(ctx context.Context, link *domain.ScheduleCardLink, format domain.ScheduleCardFormat) ([]byte, error)
+
+	// 予定の出欠
+	// SetEventDirectory は予定の出欠に使う予定の取得元を設定する
+	SetEventDirectory(directory EventDirectory)
+	// SetEventReminderNotifier は出欠のリマインダーの送信先を設定する
+	SetEventReminderNotifier(notifier EventReminderNotifier)
+	SetRSVP(ctx context.Context, groupID uuid.UUID, eventID string, userID uuid.UUID, status domain.RSVPStatus) (*domain.EventRSVP, error)
+	GetEventAttendees(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) (*domain.EventAttendees, error)
+	RecordAttendance(ctx context.Context, groupID uuid.UUID, eventID string, requesterID, userID uuid.UUID, attended bool) (*domain.EventRSVP, error)
+	SendRSVPReminders(ctx context.Context, now time.Time) (int, error)
+
+	// 予定の繰り返し
+	// SetHolidayProvider は繰り返しの予定の祝日の扱いに使う祝日の取得元を設定する
+	SetHolidayProvider(holidays holiday.Provider)
+	ListEventOccurrences(ctx context.Context, groupID, requesterID uuid.UUID, from, to time.Time) (*domain.EventSchedule, error)
+	GetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) (*domain.EventRecurrence, error)
+	SetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID, input EventRecurrenceInput) (*domain.EventRecurrence, error)
+	DeleteEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) error
+	ChangeOccurrence(ctx context.Context, groupID uuid.UUID, occurrenceID string, requesterID uuid.UUID, input OccurrenceChangeInput) (*domain.EventException, error)
+	RestoreOccurrence(ctx context.Context, groupID uuid.UUID, occurrenceID string, requesterID uuid.UUID) error
+
+	// 予定の議事録
+	CreateEventNote(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID, input EventNoteInput) (*domain.EventNote, error)
+	ListEventNotes(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) ([]*domain.EventNote, error)
+	GetEventNote(ctx context.Context, groupID, noteID, requesterID uuid.UUID) (*domain.EventNote, error)
+	UpdateEventNote(ctx context.Context, groupID, noteID, requesterID uuid.UUID, input EventNoteInput) (*domain.EventNote, error)
+	DeleteEventNote(ctx context.Context, groupID, noteID, requesterID uuid.UUID) error
+	GetTaskSourceNote(ctx context.Context, groupID uuid.UUID, taskID string, requesterID uuid.UUID) (*domain.EventNote, error)
+
+	// 予定とタスクの関連付け
+	// SetGroupTaskGateway は議事録・予定から作成するグループタスクの取得・作成先を設定する
+	SetGroupTaskGateway(gateway GroupTaskGateway)
+	GetEventDetail(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID) (*domain.EventDetail, error)
+	ScheduleTaskBlock(ctx context.Context, groupID uuid.UUID, taskID string, requesterID uuid.UUID, input TaskBlockInput) (*domain.EventDetail, error)
+	CreateFollowUpTask(ctx context.Context, groupID uuid.UUID, eventID string, requesterID uuid.UUID, input FollowUpTaskInput) (*domain.EventTaskLink, error)
+	// ListTaskEventLinks はタスク（予定のタスクを含む）の予定との関連付けを取得する（タスクの詳細の表示用、閲覧権限はタスクモジュールで確認する）
+	ListTaskEventLinks(ctx context.Context, taskID string) ([]*domain.EventTaskLink, error)
+
+	// チャット連携
+	// SetChatPoster は連携先のチャットへの投稿先を設定する
+	SetChatPoster(platform domain.ChatPlatform, poster ChatPoster)
+	// SetChatTaskSource は1日のまとめ・期限のリマインダーに使うグループタスクの取得元を設定する
+	SetChatTaskSource(source ChatTaskSource)
+	ListChatIntegrations(ctx context.Context, groupID, requesterID uuid.UUID) ([]*domain.ChatIntegration, error)
+	SaveChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform, requesterID uuid.UUID, input ChatIntegrationInput) (*domain.ChatIntegration, error)
+	DeleteChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform, requesterID uuid.UUID) error
+	TestChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform, requesterID uuid.UUID) error
+	NotifyChatTaskCompleted(ctx context.Context, groupID uuid.UUID, task *domain.ChatTask) error
+	NotifyChatTaskAssigned(ctx context.Context, groupID uuid.UUID, task *domain.ChatTask, assigneeIDs []uuid.UUID) error
+	SendChatDailySummaries(ctx context.Context, now time.Time) (int, error)
+	SendChatDueReminders(ctx context.Context, now time.Time) (int, error)
+
 	// 権限・統計
 	CheckPermission(ctx context.Context, groupID, userID uuid.UUID, action GroupAction) (bool, error)
+	ListMemberIDsWithPermission(ctx context.Context, groupID uuid.UUID, action GroupAction) ([]uuid.UUID, error)
+	GetPermissionMatrix(ctx context.Context, groupID, requesterID uuid.UUID) (domain.PermissionMatrix, error)
+	UpdatePermissionMatrix(ctx context.Context, groupID, requesterID uuid.UUID, overrides domain.PermissionMatrix) (domain.PermissionMatrix, error)
+	ListCustomRoles(ctx context.Context, groupID, requesterID uuid.UUID) ([]*domain.CustomRole, error)
+	CreateCustomRole(ctx context.Context, groupID, requesterID uuid.UUID, input CustomRoleInput) (*domain.CustomRole, error)
+	UpdateCustomRole(ctx context.Context, groupID, roleID, requesterID uuid.UUID, input CustomRoleInput) (*domain.CustomRole, error)
+	DeleteCustomRole(ctx context.Context, groupID, roleID, requesterID uuid.UUID) error
 	GetUserRole(ctx context.Context, groupID, userID uuid.UUID) (domain.MemberRole, error)
 	GetGroupStats(ctx context.Context, groupID, requesterID uuid.UUID) (*domain.GroupStats, error)
 	GetGroupActivity(ctx context.Context, groupID uuid.UUID, days int) (*GroupActivity, error)
@@ -48,6 +141,9 @@ type CreateGroupInput struct {
 	Type        domain.GroupType      `json:"type"`
 	OwnerID     uuid.UUID             `json:"owner_id"`
 	Settings    domain.GroupSettings  `json:"settings"`
+
+	// ParentGroupID を指定するとそのグループのサブチームとして作成する
+	ParentGroupID *uuid.UUID `json:"parent_group_id,omitempty"`
 }
 
 // UpdateGroupInput はグループ更新の入力
@@ -70,6 +166,20 @@ type MemberWithUserInfo struct {
 	UserInfo *commonDomain.UserInfo
 }
 
+// CustomRoleInput はカスタムロールの作成・更新の入力
+type CustomRoleInput struct {
+	Name        string               `json:"name"`
+	Permissions []domain.GroupAction `json:"permissions"`
+}
+
+// GroupTree はグループとそのサブチームの階層
+type GroupTree struct {
+	Group    *domain.Group
+	Stats    *domain.GroupStats // このグループ単体の統計
+	Total    *domain.GroupStats // サブチームを含めた統計（メンバーは重複を除いて数える）
+	Children []*GroupTree
+}
+
 // GroupInviteResult はグループ招待の結果
 type GroupInviteResult struct {
 	FriendID uuid.UUID `json:"friend_id"`
@@ -78,6 +188,22 @@ type GroupInviteResult struct {
 	Error    string    `json:"error,omitempty"`
 }
 
+// BulkAddMemberStatus はメンバー一括追加での各ユーザーの結果
+type BulkAddMemberStatus string
+
+const (
+	BulkAddMemberAdded         BulkAddMemberStatus = "ADDED"
+	BulkAddMemberAlreadyMember BulkAddMemberStatus = "ALREADY_MEMBER"
+	BulkAddMemberNotFound      BulkAddMemberStatus = "NOT_FOUND"
+	BulkAddMemberBlocked       BulkAddMemberStatus = "BLOCKED"
+)
+
+// BulkAddMemberResult はメンバー一括追加の結果
+type BulkAddMemberResult struct {
+	UserID uuid.UUID           `json:"user_id"`
+	Status BulkAddMemberStatus `json:"status"`
+}
+
 // AvailableFriend は招待可能な友達
 type AvailableFriend struct {
 	UserID     uuid.UUID              `json:"user_id"`
@@ -87,20 +213,20 @@ type AvailableFriend struct {
 }
 
 // GroupAction はグループでのアクション
-type GroupAction string
+type GroupAction = domain.GroupAction
 
 const (
-	ActionViewGroup      GroupAction = "VIEW_GROUP"
-	ActionEditGroup      GroupAction = "EDIT_GROUP"
-	ActionDeleteGroup    GroupAction = "DELETE_GROUP"
-	ActionInviteMembers  GroupAction = "INVITE_MEMBERS"
-	ActionRemoveMembers  GroupAction = "REMOVE_MEMBERS"
-	ActionManageRoles    GroupAction = "MANAGE_ROLES"
-	ActionCreateTasks    GroupAction = "CREATE_TASKS"
-	ActionEditTasks      GroupAction = "EDIT_TASKS"
-	ActionDeleteTasks    GroupAction = "DELETE_TASKS"
-	ActionViewTasks      GroupAction = "VIEW_TASKS"
-	ActionViewSchedules  GroupAction = "VIEW_SCHEDULES"
+	ActionViewGroup     = domain.ActionViewGroup
+	ActionEditGroup     = domain.ActionEditGroup
+	ActionDeleteGroup   = domain.ActionDeleteGroup
+	ActionInviteMembers = domain.ActionInviteMembers
+	ActionRemoveMembers = domain.ActionRemoveMembers
+	ActionManageRoles   = domain.ActionManageRoles
+	ActionCreateTasks   = domain.ActionCreateTasks
+	ActionEditTasks     = domain.ActionEditTasks
+	ActionDeleteTasks   = domain.ActionDeleteTasks
+	ActionViewTasks     = domain.ActionViewTasks
+	ActionViewSchedules = domain.ActionViewSchedules
 )
 
 // GroupActivity はグループ活動情報
@@ -123,9 +249,13 @@ type GroupRepository interface {
 	ListGroupsByOwner(ctx context.Context, ownerID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Group, int, error)
 	ListGroupsByMember(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Group, int, error)
 	SearchGroups(ctx context.Context, query string, groupType *domain.GroupType, pagination commonDomain.Pagination) ([]*domain.Group, int, error)
+	// ListChildGroups は直下のサブチームを作成日時の古い順に取得する
+	ListChildGroups(ctx context.Context, parentID uuid.UUID) ([]*domain.Group, error)
 
 	// グループメンバー管理
 	AddMember(ctx context.Context, member *domain.GroupMember) error
+	// AddMembers はメンバーを一括追加し、グループのメンバー数も同じトランザクションで更新する
+	AddMembers(ctx context.Context, group *domain.Group, members []*domain.GroupMember) error
 	GetMember(ctx context.Context, groupID, userID uuid.UUID) (*domain.GroupMember, error)
 	UpdateMemberRole(ctx context.Context, groupID, userID uuid.UUID, role domain.MemberRole) error
 	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
@@ -133,10 +263,105 @@ type GroupRepository interface {
 
 	// メンバーシップチェック
 	IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error)
+	GetExistingMemberIDs(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]bool, error)
 	GetMemberRole(ctx context.Context, groupID, userID uuid.UUID) (domain.MemberRole, error)
 
 	// 統計情報
 	GetMemberCount(ctx context.Context, groupID uuid.UUID) (int, error)
 	GetGroupStats(ctx context.Context, groupID uuid.UUID) (*domain.GroupStats, error)
+	// CountDistinctMembers は複数グループのメンバーを重複を除いて数える
+	CountDistinctMembers(ctx context.Context, groupIDs []uuid.UUID) (int, error)
+
+	// 権限設定（グループで変更したアクションのみ保存する）
+	GetPermissionOverrides(ctx context.Context, groupID uuid.UUID) (domain.PermissionMatrix, error)
+	SavePermissionOverrides(ctx context.Context, groupID uuid.UUID, overrides domain.PermissionMatrix) error
+
+	// カスタムロール
+	CreateCustomRole(ctx context.Context, role *domain.CustomRole) error
+	// GetCustomRole はグループのカスタムロールを取得する（存在しない場合は nil, nil）
+	GetCustomRole(ctx context.Context, groupID, roleID uuid.UUID) (*domain.CustomRole, error)
+	// ListCustomRoles はグループのカスタムロールを作成日時の古い順に取得する
+	ListCustomRoles(ctx context.Context, groupID uuid.UUID) ([]*domain.CustomRole, error)
+	UpdateCustomRole(ctx context.Context, role *domain.CustomRole) error
+	// DeleteCustomRole はカスタムロールを削除し、割り当てていたメンバーを MEMBER に戻す
+	DeleteCustomRole(ctx context.Context, groupID, roleID uuid.UUID) error
+
+	// タスクの自動割り当て
+	// GetAssignmentSettings はグループの自動割り当て設定を取得する（未設定の場合は nil, nil）
+	GetAssignmentSettings(ctx context.Context, groupID uuid.UUID) (*domain.AssignmentSettings, error)
+	SaveAssignmentSettings(ctx context.Context, settings *domain.AssignmentSettings) error
+
+	// リーダーボード
+	// GetLeaderboardSettings はグループのリーダーボード設定を取得する（未設定の場合は nil, nil）
+	GetLeaderboardSettings(ctx context.Context, groupID uuid.UUID) (*domain.LeaderboardSettings, error)
+	SaveLeaderboardSettings(ctx context.Context, settings *domain.LeaderboardSettings) error
+	// GetLeaderboardVisibilities はメンバーが設定した公開範囲を取得する（未設定のメンバーは含まない）
+	GetLeaderboardVisibilities(ctx context.Context, groupID uuid.UUID) (map[uuid.UUID]domain.LeaderboardVisibility, error)
+	SaveLeaderboardPreference(ctx context.Context, preference *domain.LeaderboardPreference) error
+
+	// スケジュールカード設定
+	// GetScheduleCardSettings はグループのスケジュールカード設定を取得する（未設定の場合は nil, nil）
+	GetScheduleCardSettings(ctx context.Context, groupID uuid.UUID) (*domain.ScheduleCardSettings, error)
+	SaveScheduleCardSettings(ctx context.Context, settings *domain.ScheduleCardSettings) error
+
+	// 予定の出欠
+	// GetEventRSVP はメンバーの予定への出欠を取得する（未回答・出席も未記録の場合は nil, nil）
+	GetEventRSVP(ctx context.Context, groupID uuid.UUID, eventID string, userID uuid.UUID) (*domain.EventRSVP, error)
+	// SaveEventRSVP はメンバーの予定への出欠の回答と出席の記録を保存する
+	SaveEventRSVP(ctx context.Context, rsvp *domain.EventRSVP) error
+	ListEventRSVPs(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventRSVP, error)
+	// ListGroupEventRSVPs はグループのすべての予定の出欠を取得する
+	ListGroupEventRSVPs(ctx context.Context, groupID uuid.UUID) ([]*domain.EventRSVP, error)
+	// IsEventReminded は予定の出欠のリマインダーを送信済みか確認する
+	IsEventReminded(ctx context.Context, groupID uuid.UUID, eventID string) (bool, error)
+	SaveEventReminder(ctx context.Context, groupID uuid.UUID, eventID string, remindedAt time.Time) error
+
+	// 予定の繰り返し
+	// GetEventRecurrence は予定の繰り返しの設定を取得する（設定がない場合は nil, nil）
+	GetEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.EventRecurrence, error)
+	SaveEventRecurrence(ctx context.Context, recurrence *domain.EventRecurrence) error
+	// DeleteEventRecurrence は予定の繰り返しの設定と回ごとの変更を削除する
+	DeleteEventRecurrence(ctx context.Context, groupID uuid.UUID, eventID string) error
+	ListEventRecurrences(ctx context.Context, groupID uuid.UUID) ([]*domain.EventRecurrence, error)
+	// ListActiveEventRecurrences はすべてのグループから、Until が now 以降（または無期限）の繰り返しの設定を取得する
+	ListActiveEventRecurrences(ctx context.Context, now time.Time) ([]*domain.EventRecurrence, error)
+	ListEventExceptions(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventException, error)
+	ListGroupEventExceptions(ctx context.Context, groupID uuid.UUID) ([]*domain.EventException, error)
+	// SaveEventException は回の変更を保存する（同じ回の変更は上書きする）
+	SaveEventException(ctx context.Context, exception *domain.EventException) error
+	DeleteEventException(ctx context.Context, groupID uuid.UUID, eventID string, originalStartsAt time.Time) error
+
+	// 予定の議事録
+	CreateEventNote(ctx context.Context, note *domain.EventNote) error
+	// GetEventNote はグループの議事録を取得する（存在しない場合は nil, nil）
+	GetEventNote(ctx context.Context, groupID, noteID uuid.UUID) (*domain.EventNote, error)
+	UpdateEventNote(ctx context.Context, note *domain.EventNote) error
+	// DeleteEventNote は議事録と、作成したタスクとの対応を削除する（タスクは削除しない）
+	DeleteEventNote(ctx context.Context, groupID, noteID uuid.UUID) error
+	// ListEventNotes は予定の議事録を作成日時の古い順に取得する
+	ListEventNotes(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventNote, error)
+	// ListNoteActionItems は議事録から作成したタスクを作成日時の古い順に取得する
+	ListNoteActionItems(ctx context.Context, noteID uuid.UUID) ([]*domain.NoteActionItem, error)
+	SaveNoteActionItem(ctx context.Context, item *domain.NoteActionItem) error
+	// GetNoteActionItemByTask はタスクを作成したアクションアイテムを取得する（議事録から作成したタスクでない場合は nil, nil）
+	GetNoteActionItemByTask(ctx context.Context, groupID uuid.UUID, taskID string) (*domain.NoteActionItem, error)
+
+	// 予定とタスクの関連付け
+	SaveEventTaskLink(ctx context.Context, link *domain.EventTaskLink) error
+	// ListEventTaskLinks は予定（回）の関連付けを作成日時の古い順に取得する
+	ListEventTaskLinks(ctx context.Context, groupID uuid.UUID, eventID string) ([]*domain.EventTaskLink, error)
+	// ListTaskLinks はタスク、またはタスクを予定（繰り返しの予定の回を含む）とする関連付けを作成日時の古い順に取得する
+	ListTaskLinks(ctx context.Context, taskID string) ([]*domain.EventTaskLink, error)
+
+	// チャット連携
+	// SaveChatIntegration はグループのチャット連携を保存する（同じ連携先の設定は上書きする）
+	SaveChatIntegration(ctx context.Context, integration *domain.ChatIntegration) error
+	// GetChatIntegration はグループのチャット連携を取得する（設定がない場合は nil, nil）
+	GetChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform) (*domain.ChatIntegration, error)
+	// ListChatIntegrations はグループのチャット連携を連携先の順に取得する
+	ListChatIntegrations(ctx context.Context, groupID uuid.UUID) ([]*domain.ChatIntegration, error)
+	// ListEnabledChatIntegrations はすべてのグループから有効なチャット連携を取得する
+	ListEnabledChatIntegrations(ctx context.Context) ([]*domain.ChatIntegration, error)
+	DeleteChatIntegration(ctx context.Context, groupID uuid.UUID, platform domain.ChatPlatform) error
 }
 
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	// ErrInvalidRole はロールの定義または割り当てが不正であることを表すエラー
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrCustomRoleNotFound はグループにカスタムロールが存在しないことを表すエラー
+	ErrCustomRoleNotFound = errors.New("custom role not found")
+)
+
+// ListCustomRoles はグループのカスタムロール一覧を取得する（メンバーのみ）
+func (s *groupService) ListCustomRoles(ctx context.Context, groupID, requesterID uuid.UUID) ([]*domain.CustomRole, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	roles, err := s.groupRepo.ListCustomRoles(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+	return roles, nil
+}
+
+// CreateCustomRole はグループにカスタムロールを作成する（ロール管理の権限が必要）
+func (s *groupService) CreateCustomRole(ctx context.Context, groupID, requesterID uuid.UUID, input CustomRoleInput) (*domain.CustomRole, error) {
+	if err := s.checkManageRoles(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.groupRepo.ListCustomRoles(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+	if len(roles) >= domain.MaxCustomRoles {
+		return nil, fmt.Errorf("%w: too many custom roles (max %d)", ErrInvalidRole, domain.MaxCustomRoles)
+	}
+
+	role, err := domain.NewCustomRole(groupID, input.Name, input.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRole, err)
+	}
+	if err := checkRoleNameAvailable(roles, role); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.CreateCustomRole(ctx, role); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to create custom role", logger.Error(err))
+		return nil, fmt.Errorf("failed to create custom role: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Custom role created",
+		logger.Any("groupID", groupID),
+		logger.Any("roleID", role.ID))
+	return role, nil
+}
+
+// UpdateCustomRole はカスタムロールの名前と権限を変更する（ロール管理の権限が必要）
+// 割り当て済みのメンバーには変更後の権限がそのまま適用される
+func (s *groupService) UpdateCustomRole(ctx context.Context, groupID, roleID, requesterID uuid.UUID, input CustomRoleInput) (*domain.CustomRole, error) {
+	if err := s.checkManageRoles(ctx, groupID, requesterID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.groupRepo.ListCustomRoles(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+	var role *domain.CustomRole
+	for _, r := range roles {
+		if r.ID == roleID {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		return nil, ErrCustomRoleNotFound
+	}
+
+	if err := role.Update(input.Name, input.Permissions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRole, err)
+	}
+	if err := checkRoleNameAvailable(roles, role); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.UpdateCustomRole(ctx, role); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update custom role", logger.Error(err))
+		return nil, fmt.Errorf("failed to update custom role: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Custom role updated",
+		logger.Any("groupID", groupID),
+		logger.Any("roleID", roleID))
+	return role, nil
+}
+
+// DeleteCustomRole はカスタムロールを削除する（ロール管理の権限が必要）
+// 割り当てていたメンバーは MEMBER に戻る
+func (s *groupService) DeleteCustomRole(ctx context.Context, groupID, roleID, requesterID uuid.UUID) error {
+	if err := s.checkManageRoles(ctx, groupID, requesterID); err != nil {
+		return err
+	}
+
+	role, err := s.groupRepo.GetCustomRole(ctx, groupID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to get custom role: %w", err)
+	}
+	if role == nil {
+		return ErrCustomRoleNotFound
+	}
+
+	if err := s.groupRepo.DeleteCustomRole(ctx, groupID, roleID); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to delete custom role", logger.Error(err))
+		return fmt.Errorf("failed to delete custom role: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Custom role deleted",
+		logger.Any("groupID", groupID),
+		logger.Any("roleID", roleID))
+	return nil
+}
+
+// checkManageRoles は要求者がグループのロールを管理できるかチェックする
+func (s *groupService) checkManageRoles(ctx context.Context, groupID, requesterID uuid.UUID) error {
+	canManage, err := s.CheckPermission(ctx, groupID, requesterID, ActionManageRoles)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canManage {
+		return ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// validateMemberRole はメンバーに割り当てるロールが組み込みのロールかグループで定義したカスタムロールかチェックする
+func (s *groupService) validateMemberRole(ctx context.Context, groupID uuid.UUID, role domain.MemberRole) error {
+	if domain.IsBuiltinRole(role) {
+		return nil
+	}
+
+	roleID, ok := role.CustomRoleID()
+	if !ok {
+		return fmt.Errorf("%w: unknown role %s", ErrInvalidRole, role)
+	}
+	customRole, err := s.groupRepo.GetCustomRole(ctx, groupID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to get custom role: %w", err)
+	}
+	if customRole == nil {
+		return fmt.Errorf("%w: role %s is not defined in this group", ErrInvalidRole, role)
+	}
+	return nil
+}
+
+// checkRoleNameAvailable はグループ内で同じ名前のカスタムロールがないかチェックする（大文字小文字は区別しない）
+func checkRoleNameAvailable(roles []*domain.CustomRole, role *domain.CustomRole) error {
+	for _, r := range roles {
+		if r.ID != role.ID && strings.EqualFold(r.Name, role.Name) {
+			return fmt.Errorf("%w: role name %s is already used", ErrInvalidRole, role.Name)
+		}
+	}
+	return nil
+}
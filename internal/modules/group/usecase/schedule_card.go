@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	"github.com/hryt430/Yotei+/internal/modules/group/usecase/card"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// scheduleCardCacheTTL は出力したスケジュールカードをキャッシュする期間
+	scheduleCardCacheTTL = 5 * time.Minute
+	// maxCachedScheduleCards はキャッシュするスケジュールカードの件数の上限（超えた場合はキャッシュを作り直す）
+	maxCachedScheduleCards = 500
+)
+
+var (
+	// ErrScheduleCardDisabled はグループのスケジュールカードが有効になっていないことを表すエラー
+	ErrScheduleCardDisabled = errors.New("schedule card is disabled")
+
+	// ErrInvalidScheduleCardLink はスケジュールカードのリンクの署名が不正か有効期限切れであることを表すエラー
+	ErrInvalidScheduleCardLink = errors.New("invalid schedule card link")
+
+	// ErrInvalidScheduleCardSettings はスケジュールカードの設定が不正であることを表すエラー
+	ErrInvalidScheduleCardSettings = errors.New("invalid schedule card settings")
+)
+
+// ScheduleCardSettingsInput はスケジュールカード設定の変更の入力（nilの項目は変更しない）
+type ScheduleCardSettingsInput struct {
+	Enabled    *bool
+	Timezone   *string
+	ShowTitles *bool
+}
+
+// ScheduleCardLinkView はスケジュールカードの共有リンクと、画像・PDFのURL
+// 公開URLのベースURLを設定していない場合、URLはパスのみ
+type ScheduleCardLinkView struct {
+	Link     *domain.ScheduleCardLink
+	ImageURL string
+	PDFURL   string
+}
+
+// cachedScheduleCard はキャッシュした出力済みのスケジュールカード
+type cachedScheduleCard struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// scheduleCardCache はグループ・開始日・出力形式ごとのスケジュールカードのキャッシュ（ゼロ値で使用できる）
+type scheduleCardCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedScheduleCard
+}
+
+func scheduleCardCacheKey(link *domain.ScheduleCardLink, format domain.ScheduleCardFormat) string {
+	return link.GroupID.String() + "/" + link.StartDate + "/" + string(format)
+}
+
+func (c *scheduleCardCache) get(key string, now time.Time) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok || !now.Before(cached.expiresAt) {
+		return nil
+	}
+	return cached.data
+}
+
+func (c *scheduleCardCache) put(key string, data []byte, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil || len(c.entries) >= maxCachedScheduleCards {
+		c.entries = make(map[string]cachedScheduleCard)
+	}
+	c.entries[key] = cachedScheduleCard{data: data, expiresAt: now.Add(scheduleCardCacheTTL)}
+}
+
+// invalidate はグループのスケジュールカードのキャッシュを削除する（設定の変更時）
+func (c *scheduleCardCache) invalidate(groupID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := groupID.String() + "/"
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// SetScheduleCardSigner はスケジュールカードの共有リンクの署名鍵と公開URLのベースURLを設定する
+func (s *groupService) SetScheduleCardSigner(secret []byte, baseURL string) {
+	s.cardSecret = secret
+	s.cardBaseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// GetScheduleCardSettings はスケジュールカード設定を取得する（メンバーのみ）
+func (s *groupService) GetScheduleCardSettings(ctx context.Context, groupID, requesterID uuid.UUID) (*domain.ScheduleCardSettings, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	return s.getScheduleCardSettings(ctx, groupID)
+}
+
+// UpdateScheduleCardSettings はスケジュールカードの有効・無効、日付の区切りのタイムゾーン、タイトルの表示を変更する（グループ編集の権限が必要）
+// 無効にすると発行済みのリンクでもカードを表示できなくなる
+func (s *groupService) UpdateScheduleCardSettings(ctx context.Context, groupID, requesterID uuid.UUID, input ScheduleCardSettingsInput) (*domain.ScheduleCardSettings, error) {
+	if input.Timezone != nil {
+		if _, err := time.LoadLocation(*input.Timezone); err != nil || *input.Timezone == "" {
+			return nil, fmt.Errorf("%w: invalid timezone: %s", ErrInvalidScheduleCardSettings, *input.Timezone)
+		}
+	}
+
+	canEdit, err := s.CheckPermission(ctx, groupID, requesterID, ActionEditGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canEdit {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getScheduleCardSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Enabled != nil {
+		settings.Enabled = *input.Enabled
+	}
+	if input.Timezone != nil {
+		settings.Timezone = *input.Timezone
+	}
+	if input.ShowTitles != nil {
+		settings.ShowTitles = *input.ShowTitles
+	}
+	settings.UpdatedAt = time.Now()
+
+	if err := s.groupRepo.SaveScheduleCardSettings(ctx, settings); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save schedule card settings", logger.Error(err))
+		return nil, fmt.Errorf("failed to save schedule card settings: %w", err)
+	}
+	s.scheduleCards.invalidate(groupID)
+
+	s.logger.WithContext(ctx).Info("Group schedule card settings updated",
+		logger.Any("groupID", groupID),
+		logger.Any("enabled", settings.Enabled))
+	return settings, nil
+}
+
+// CreateScheduleCardLink は今日から1週間のスケジュールカードの署名付きの共有リンクを発行する（予定の閲覧権限が必要）
+func (s *groupService) CreateScheduleCardLink(ctx context.Context, groupID, requesterID uuid.UUID) (*ScheduleCardLinkView, error) {
+	canView, err := s.CheckPermission(ctx, groupID, requesterID, ActionViewSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissions
+	}
+
+	settings, err := s.getScheduleCardSettings(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled || len(s.cardSecret) == 0 {
+		return nil, ErrScheduleCardDisabled
+	}
+
+	link := domain.NewScheduleCardLink(groupID, time.Now(), settings.Location(), s.cardSecret)
+	return &ScheduleCardLinkView{
+		Link:     link,
+		ImageURL: s.cardBaseURL + link.PublicPath(domain.ScheduleCardSVG),
+		PDFURL:   s.cardBaseURL + link.PublicPath(domain.ScheduleCardPDF),
+	}, nil
+}
+
+// RenderScheduleCard は署名付きリンクのスケジュールカードを出力する（認証不要、署名と有効期限で検証する）
+// 出力したカードは一定時間キャッシュする
+func (s *groupService) RenderScheduleCard(ctx context.Context, link *domain.ScheduleCardLink, format domain.ScheduleCardFormat) ([]byte, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("%w: invalid format: %s", ErrInvalidScheduleCardSettings, format)
+	}
+	now := time.Now()
+	if !link.Verify(s.cardSecret, now) {
+		return nil, ErrInvalidScheduleCardLink
+	}
+
+	settings, err := s.getScheduleCardSettings(ctx, link.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return nil, ErrScheduleCardDisabled
+	}
+
+	key := scheduleCardCacheKey(link, format)
+	if data := s.scheduleCards.get(key, now); data != nil {
+		return data, nil
+	}
+
+	from, to, err := link.Range(settings.Location())
+	if err != nil {
+		return nil, ErrInvalidScheduleCardLink
+	}
+	schedule, err := s.listEventOccurrences(ctx, link.GroupID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleCard := domain.NewScheduleCard(schedule, settings, from)
+	var data []byte
+	switch format {
+	case domain.ScheduleCardPDF:
+		data = card.PDF(scheduleCard)
+	default:
+		data = card.SVG(scheduleCard)
+	}
+	s.scheduleCards.put(key, data, now)
+	return data, nil
+}
+
+// getScheduleCardSettings はスケジュールカード設定を取得する（未設定の場合は無効の設定）
+func (s *groupService) getScheduleCardSettings(ctx context.Context, groupID uuid.UUID) (*domain.ScheduleCardSettings, error) {
+	settings, err := s.groupRepo.GetScheduleCardSettings(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule card settings: %w", err)
+	}
+	if settings == nil {
+		return domain.NewScheduleCardSettings(groupID), nil
+	}
+	return settings, nil
+}
@@ -7,13 +7,48 @@ import (
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/group/domain"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
 
+// MaxBulkAddMembers は一度に追加できるメンバー数の上限
+const MaxBulkAddMembers = 100
+
+// syncMemberPageSize は変更フィードの記録時にメンバーを取得する件数
+const syncMemberPageSize = 500
+
+var (
+	// ErrUserBlocked は追加しようとしたユーザーとブロック関係にあることを表すエラー
+	ErrUserBlocked = errors.New("user is blocked")
+
+	// ErrInsufficientPermissions は操作に必要な権限がないことを表すエラー
+	ErrInsufficientPermissions = errors.New("insufficient permissions")
+
+	// ErrInvalidBulkAddMembers はメンバー一括追加の対象ユーザーが不正であることを表すエラー
+	ErrInvalidBulkAddMembers = errors.New("invalid bulk add members request")
+)
+
 type groupService struct {
 	groupRepo     GroupRepository
 	userValidator commonDomain.UserValidator
+	blockChecker  commonDomain.BlockChecker   // nilの場合はブロック確認をしない
+	undoScheduler undo.Scheduler              // nilの場合はメンバー削除を即時に実行する
+	quotas        commonDomain.QuotaChecker   // nilの場合はグループ数の上限を確認しない
+	syncChanges   commonDomain.ChangeRecorder // nilの場合は変更フィードに記録しない
+	completions   TaskCompletionProvider      // nilの場合はリーダーボードを表示しない
+	events        EventDirectory              // nilの場合は予定の出欠を扱わない
+	reminders     EventReminderNotifier       // nilの場合は出欠のリマインダーを送らない
+	groupTasks    GroupTaskGateway            // nilの場合は議事録・予定からタスクを作成しない
+	holidays      holiday.Provider            // nilの場合は繰り返しの予定の祝日の扱いを適用しない
+	leaderboards  leaderboardCache
+	cardSecret    []byte // スケジュールカードの共有リンクの署名鍵（未設定の場合はリンクを発行しない）
+	cardBaseURL   string // スケジュールカードの公開URLのベースURL（未設定の場合はパスのみ）
+	scheduleCards scheduleCardCache
+	chatPosters   map[domain.ChatPlatform]ChatPoster // 連携先ごとのチャットへの投稿先（ない連携先には投稿しない）
+	chatTasks     ChatTaskSource                     // nilの場合は1日のまとめを投稿しない
+	permissions   *permissionService
 	logger        *logger.Logger
 }
 
@@ -25,6 +60,24 @@ func NewGroupService(
 	return &groupService{
 		groupRepo:     groupRepo,
 		userValidator: userValidator,
+		permissions:   newPermissionService(groupRepo),
+		logger:        logger,
+	}
+}
+
+// NewGroupServiceWithBlockChecker はブロック関係を確認するGroupServiceを作成する
+// 招待者とブロック関係にあるユーザーはメンバーに追加できない
+func NewGroupServiceWithBlockChecker(
+	groupRepo GroupRepository,
+	userValidator commonDomain.UserValidator,
+	blockChecker commonDomain.BlockChecker,
+	logger *logger.Logger,
+) GroupService {
+	return &groupService{
+		groupRepo:     groupRepo,
+		userValidator: userValidator,
+		blockChecker:  blockChecker,
+		permissions:   newPermissionService(groupRepo),
 		logger:        logger,
 	}
 }
@@ -41,24 +94,40 @@ func (s *groupService) CreateGroup(ctx context.Context, input CreateGroupInput)
 
 	exists, err := s.userValidator.UserExists(ctx, ownerID.String())
 	if err != nil {
-		s.logger.Error("Failed to validate owner existence", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to validate owner existence", logger.Error(err))
 		return nil, fmt.Errorf("failed to validate owner: %w", err)
 	}
 	if !exists {
 		return nil, errors.New("owner not found")
 	}
 
+	if s.quotas != nil {
+		if err := s.quotas.CheckQuota(ctx, commonDomain.QuotaScopeUser, ownerID.String(), commonDomain.QuotaGroups, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	// サブチームの場合は親グループを管理できるか確認する
+	if input.ParentGroupID != nil {
+		if err := s.validateParentGroup(ctx, *input.ParentGroupID, ownerID); err != nil {
+			return nil, err
+		}
+	}
+
 	// グループ作成
 	group := domain.NewGroup(input.Name, input.Description, input.Type, ownerID)
+	group.ParentGroupID = input.ParentGroupID
 	group.UpdateSettings(input.Settings)
 
 	err = s.groupRepo.CreateGroup(ctx, group)
 	if err != nil {
-		s.logger.Error("Failed to create group", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to create group", logger.Error(err))
 		return nil, fmt.Errorf("failed to create group: %w", err)
 	}
 
-	s.logger.Info("Group created successfully", logger.Any("groupID", group.ID))
+	s.syncGroupUpserted(ctx, group)
+
+	s.logger.WithContext(ctx).Info("Group created successfully", logger.Any("groupID", group.ID))
 	return group, nil
 }
 
@@ -79,7 +148,14 @@ func (s *groupService) GetGroup(ctx context.Context, groupID uuid.UUID, requeste
 		return nil, fmt.Errorf("failed to check membership: %w", err)
 	}
 	if !isMember && !group.Settings.IsPublic {
-		return nil, errors.New("access denied")
+		// 上位グループの管理者は非公開のサブチームも閲覧できる
+		inherited, err := s.permissions.isAncestorManager(ctx, group, requesterID)
+		if err != nil {
+			return nil, err
+		}
+		if !inherited {
+			return nil, errors.New("access denied")
+		}
 	}
 
 	// リクエスターの権限取得
@@ -101,7 +177,7 @@ func (s *groupService) GetGroup(ctx context.Context, groupID uuid.UUID, requeste
 	// ユーザー情報を一括取得
 	memberWithUserInfo, err := s.enrichMembersWithUserInfo(ctx, members)
 	if err != nil {
-		s.logger.Error("Failed to enrich members with user info", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to enrich members with user info", logger.Error(err))
 		// エラーでも継続（ユーザー情報なしで返す）
 		memberWithUserInfo = make([]*MemberWithUserInfo, len(members))
 		for i, member := range members {
@@ -161,11 +237,13 @@ func (s *groupService) UpdateGroup(ctx context.Context, groupID uuid.UUID, input
 	// 更新実行
 	err = s.groupRepo.UpdateGroup(ctx, group)
 	if err != nil {
-		s.logger.Error("Failed to update group", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to update group", logger.Error(err))
 		return nil, fmt.Errorf("failed to update group: %w", err)
 	}
 
-	s.logger.Info("Group updated successfully", logger.Any("groupID", groupID))
+	s.syncGroupUpserted(ctx, group)
+
+	s.logger.WithContext(ctx).Info("Group updated successfully", logger.Any("groupID", groupID))
 	return group, nil
 }
 
@@ -186,11 +264,15 @@ func (s *groupService) DeleteGroup(ctx context.Context, groupID uuid.UUID, reque
 	// 削除実行
 	err = s.groupRepo.DeleteGroup(ctx, groupID)
 	if err != nil {
-		s.logger.Error("Failed to delete group", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to delete group", logger.Error(err))
 		return fmt.Errorf("failed to delete group: %w", err)
 	}
 
-	s.logger.Info("Group deleted successfully", logger.Any("groupID", groupID))
+	if s.syncChanges != nil {
+		s.syncChanges.RecordDelete(ctx, commonDomain.SyncEntityGroup, groupID.String())
+	}
+
+	s.logger.WithContext(ctx).Info("Group deleted successfully", logger.Any("groupID", groupID))
 	return nil
 }
 
@@ -199,14 +281,14 @@ func (s *groupService) GetMyGroups(ctx context.Context, userID uuid.UUID, groupT
 	// オーナーのグループ取得
 	ownedGroups, ownedTotal, err := s.groupRepo.ListGroupsByOwner(ctx, userID, pagination)
 	if err != nil {
-		s.logger.Error("Failed to get owned groups", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get owned groups", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to get owned groups: %w", err)
 	}
 
 	// メンバーのグループ取得
 	memberGroups, memberTotal, err := s.groupRepo.ListGroupsByMember(ctx, userID, pagination)
 	if err != nil {
-		s.logger.Error("Failed to get member groups", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get member groups", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to get member groups: %w", err)
 	}
 
@@ -237,7 +319,7 @@ func (s *groupService) GetMyGroups(ctx context.Context, userID uuid.UUID, groupT
 func (s *groupService) SearchGroups(ctx context.Context, query string, groupType *domain.GroupType, pagination commonDomain.Pagination) ([]*domain.Group, int, error) {
 	groups, total, err := s.groupRepo.SearchGroups(ctx, query, groupType, pagination)
 	if err != nil {
-		s.logger.Error("Failed to search groups", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to search groups", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to search groups: %w", err)
 	}
 
@@ -254,6 +336,9 @@ func (s *groupService) AddMember(ctx context.Context, groupID, userID, inviterID
 	if !hasPermission {
 		return errors.New("insufficient permissions")
 	}
+	if err := s.validateMemberRole(ctx, groupID, role); err != nil {
+		return err
+	}
 
 	// ユーザー存在確認
 	exists, err := s.userValidator.UserExists(ctx, userID.String())
@@ -264,6 +349,15 @@ func (s *groupService) AddMember(ctx context.Context, groupID, userID, inviterID
 		return errors.New("user not found")
 	}
 
+	// ブロック関係チェック
+	blocked, err := s.isBlocked(ctx, inviterID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check block relationship: %w", err)
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+
 	// 既にメンバーかチェック
 	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
 	if err != nil {
@@ -277,7 +371,7 @@ func (s *groupService) AddMember(ctx context.Context, groupID, userID, inviterID
 	member := domain.NewGroupMember(groupID, userID, role)
 	err = s.groupRepo.AddMember(ctx, member)
 	if err != nil {
-		s.logger.Error("Failed to add member", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to add member", logger.Error(err))
 		return fmt.Errorf("failed to add member: %w", err)
 	}
 
@@ -289,15 +383,119 @@ func (s *groupService) AddMember(ctx context.Context, groupID, userID, inviterID
 	group.AddMember()
 	err = s.groupRepo.UpdateGroup(ctx, group)
 	if err != nil {
-		s.logger.Error("Failed to update group member count", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to update group member count", logger.Error(err))
 	}
+	s.syncGroupUpserted(ctx, group)
 
-	s.logger.Info("Member added successfully",
+	s.logger.WithContext(ctx).Info("Member added successfully",
 		logger.Any("groupID", groupID),
 		logger.Any("userID", userID))
 	return nil
 }
 
+// AddMembers は複数のユーザーを一括でメンバーに追加する
+// ユーザーの存在確認・ブロック確認・メンバーシップ確認はそれぞれ一度の問い合わせで行い、
+// 追加できるユーザーのみを1トランザクションで追加する
+func (s *groupService) AddMembers(ctx context.Context, groupID, inviterID uuid.UUID, userIDs []uuid.UUID, role domain.MemberRole) ([]*BulkAddMemberResult, error) {
+	userIDs = uniqueUUIDs(userIDs)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("%w: no users specified", ErrInvalidBulkAddMembers)
+	}
+	if len(userIDs) > MaxBulkAddMembers {
+		return nil, fmt.Errorf("%w: too many users (max %d)", ErrInvalidBulkAddMembers, MaxBulkAddMembers)
+	}
+	if role == domain.RoleOwner {
+		return nil, fmt.Errorf("%w: cannot add members as owner", ErrInvalidBulkAddMembers)
+	}
+
+	// 権限チェック
+	hasPermission, err := s.CheckPermission(ctx, groupID, inviterID, ActionInviteMembers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, ErrInsufficientPermissions
+	}
+	if err := s.validateMemberRole(ctx, groupID, role); err != nil {
+		return nil, err
+	}
+
+	// ユーザー存在確認（一括）
+	userIDStrings := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		userIDStrings[i] = userID.String()
+	}
+	missingIDs, err := s.userValidator.UsersExist(ctx, userIDStrings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate users: %w", err)
+	}
+	missing := make(map[string]bool, len(missingIDs))
+	for _, id := range missingIDs {
+		missing[id] = true
+	}
+
+	// ブロック関係チェック（一括）
+	blocked := make(map[string]bool)
+	if s.blockChecker != nil {
+		blockedIDs, err := s.blockChecker.GetBlockedUserIDs(ctx, inviterID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to check block relationship: %w", err)
+		}
+		for _, id := range blockedIDs {
+			blocked[id] = true
+		}
+	}
+
+	// 既にメンバーかチェック（一括）
+	existing, err := s.groupRepo.GetExistingMemberIDs(ctx, groupID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+
+	results := make([]*BulkAddMemberResult, len(userIDs))
+	var members []*domain.GroupMember
+	for i, userID := range userIDs {
+		result := &BulkAddMemberResult{UserID: userID}
+		switch {
+		case existing[userID]:
+			result.Status = BulkAddMemberAlreadyMember
+		case missing[userID.String()]:
+			result.Status = BulkAddMemberNotFound
+		case blocked[userID.String()]:
+			result.Status = BulkAddMemberBlocked
+		default:
+			result.Status = BulkAddMemberAdded
+			members = append(members, domain.NewGroupMember(groupID, userID, role))
+		}
+		results[i] = result
+	}
+
+	if len(members) == 0 {
+		return results, nil
+	}
+
+	// メンバー追加とメンバー数更新を1トランザクションで行う
+	group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil {
+		return nil, errors.New("group not found")
+	}
+	group.AddMembers(len(members))
+	if err := s.groupRepo.AddMembers(ctx, group, members); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to add members", logger.Error(err))
+		return nil, fmt.Errorf("failed to add members: %w", err)
+	}
+	s.syncGroupUpserted(ctx, group)
+
+	s.logger.WithContext(ctx).Info("Members added successfully",
+		logger.Any("groupID", groupID),
+		logger.Int("added", len(members)),
+		logger.Int("requested", len(userIDs)))
+	return results, nil
+}
+
 // RemoveMember はメンバーを削除する
 func (s *groupService) RemoveMember(ctx context.Context, groupID, userID, requesterID uuid.UUID) error {
 	// 権限チェック
@@ -312,7 +510,7 @@ func (s *groupService) RemoveMember(ctx context.Context, groupID, userID, reques
 	// メンバー削除
 	err = s.groupRepo.RemoveMember(ctx, groupID, userID)
 	if err != nil {
-		s.logger.Error("Failed to remove member", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to remove member", logger.Error(err))
 		return fmt.Errorf("failed to remove member: %w", err)
 	}
 
@@ -327,15 +525,66 @@ func (s *groupService) RemoveMember(ctx context.Context, groupID, userID, reques
 	}
 	err = s.groupRepo.UpdateGroup(ctx, group)
 	if err != nil {
-		s.logger.Error("Failed to update group member count", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to update group member count", logger.Error(err))
 	}
+	s.syncGroupUpserted(ctx, group)
 
-	s.logger.Info("Member removed successfully",
+	s.logger.WithContext(ctx).Info("Member removed successfully",
 		logger.Any("groupID", groupID),
 		logger.Any("userID", userID))
 	return nil
 }
 
+// SetUndoScheduler はメンバー削除の取り消しに使うジョブキューを設定する
+func (s *groupService) SetUndoScheduler(scheduler undo.Scheduler) {
+	s.undoScheduler = scheduler
+}
+
+// SetQuotaChecker はグループ作成時にオーナーのグループ数の上限を確認するQuotaCheckerを設定する
+func (s *groupService) SetQuotaChecker(quotas commonDomain.QuotaChecker) {
+	s.quotas = quotas
+}
+
+// SetChangeRecorder はグループとメンバーの変更を記録するオフライン同期用の変更フィードを設定する
+func (s *groupService) SetChangeRecorder(recorder commonDomain.ChangeRecorder) {
+	s.syncChanges = recorder
+}
+
+// RemoveMemberWithUndo はメンバー削除を取り消し期間の経過後に実行するよう登録し、取り消し用のトークンを返す
+// 権限とメンバーの存在は登録時に確認する。ジョブキューが未設定の場合は即時に削除し、nilのトークンを返す
+func (s *groupService) RemoveMemberWithUndo(ctx context.Context, groupID, userID, requesterID uuid.UUID) (*undo.Token, error) {
+	if s.undoScheduler == nil {
+		return nil, s.RemoveMember(ctx, groupID, userID, requesterID)
+	}
+
+	// 権限チェック
+	hasPermission, err := s.CheckPermission(ctx, groupID, requesterID, ActionRemoveMembers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission && requesterID != userID {
+		return nil, errors.New("insufficient permissions")
+	}
+
+	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if !isMember {
+		return nil, errors.New("user is not a member of this group")
+	}
+
+	token := s.undoScheduler.Defer(requesterID.String(), undo.ActionRemoveMember, func(ctx context.Context) error {
+		return s.RemoveMember(ctx, groupID, userID, requesterID)
+	})
+
+	s.logger.WithContext(ctx).Info("Member removal scheduled",
+		logger.Any("groupID", groupID),
+		logger.Any("userID", userID),
+		logger.Any("expiresAt", token.ExpiresAt))
+	return token, nil
+}
+
 // UpdateMemberRole はメンバーの権限を変更する
 func (s *groupService) UpdateMemberRole(ctx context.Context, groupID, userID, requesterID uuid.UUID, newRole domain.MemberRole) error {
 	// 権限チェック
@@ -347,29 +596,35 @@ func (s *groupService) UpdateMemberRole(ctx context.Context, groupID, userID, re
 		return errors.New("insufficient permissions")
 	}
 
-	// オーナーの変更は不可
-	requesterRole, err := s.groupRepo.GetMemberRole(ctx, groupID, requesterID)
-	if err != nil {
-		return fmt.Errorf("failed to get requester role: %w", err)
+	// 組み込みのロールかグループで定義したカスタムロールのみ割り当てられる
+	if err := s.validateMemberRole(ctx, groupID, newRole); err != nil {
+		return err
 	}
 
+	// オーナーの変更は不可（上位グループの管理者はメンバーでない場合があるため、対象がオーナーの場合のみ要求者を確認する）
 	targetRole, err := s.groupRepo.GetMemberRole(ctx, groupID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get target role: %w", err)
 	}
 
-	if targetRole == domain.RoleOwner && requesterRole != domain.RoleOwner {
-		return errors.New("cannot change owner role")
+	if targetRole == domain.RoleOwner {
+		requesterRole, err := s.groupRepo.GetMemberRole(ctx, groupID, requesterID)
+		if err != nil {
+			return fmt.Errorf("failed to get requester role: %w", err)
+		}
+		if requesterRole != domain.RoleOwner {
+			return errors.New("cannot change owner role")
+		}
 	}
 
 	// 権限更新
 	err = s.groupRepo.UpdateMemberRole(ctx, groupID, userID, newRole)
 	if err != nil {
-		s.logger.Error("Failed to update member role", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to update member role", logger.Error(err))
 		return fmt.Errorf("failed to update member role: %w", err)
 	}
 
-	s.logger.Info("Member role updated successfully",
+	s.logger.WithContext(ctx).Info("Member role updated successfully",
 		logger.Any("groupID", groupID),
 		logger.Any("userID", userID),
 		logger.Any("newRole", newRole))
@@ -388,23 +643,7 @@ func (s *groupService) GetMembers(ctx context.Context, groupID uuid.UUID, pagina
 
 // CheckPermission は権限をチェックする
 func (s *groupService) CheckPermission(ctx context.Context, groupID, userID uuid.UUID, action GroupAction) (bool, error) {
-	// メンバーかどうかチェック
-	isMember, err := s.groupRepo.IsMember(ctx, groupID, userID)
-	if err != nil {
-		return false, fmt.Errorf("failed to check membership: %w", err)
-	}
-	if !isMember {
-		return false, nil
-	}
-
-	// 権限取得
-	role, err := s.groupRepo.GetMemberRole(ctx, groupID, userID)
-	if err != nil {
-		return false, fmt.Errorf("failed to get member role: %w", err)
-	}
-
-	// 権限チェック
-	return s.hasPermissionForAction(role, action), nil
+	return s.permissions.Can(ctx, groupID, userID, action)
 }
 
 // GetUserRole はユーザーの権限を取得する
@@ -423,7 +662,27 @@ func (s *groupService) GetGroupStats(ctx context.Context, groupID uuid.UUID, req
 		return nil, errors.New("insufficient permissions")
 	}
 
-	return s.groupRepo.GetGroupStats(ctx, groupID)
+	stats, err := s.groupRepo.GetGroupStats(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 予定共有グループはメンバーごとの出欠も返す（集計できない場合も他の統計は返す）
+	if s.events != nil {
+		group, err := s.groupRepo.GetGroupByID(ctx, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group: %w", err)
+		}
+		if group != nil && group.Type == domain.GroupTypeSchedule {
+			attendance, err := s.memberAttendance(ctx, groupID)
+			if err != nil {
+				s.logger.WithContext(ctx).Warn("Failed to get member attendance",
+					logger.Any("groupID", groupID), logger.Error(err))
+			}
+			stats.Attendance = attendance
+		}
+	}
+	return stats, nil
 }
 
 // GetGroupActivity はグループ活動情報を取得する
@@ -439,6 +698,52 @@ func (s *groupService) GetGroupActivity(ctx context.Context, groupID uuid.UUID,
 
 // === ヘルパーメソッド ===
 
+// syncGroupUpserted はグループの作成・更新をメンバー全員の変更フィードに記録する
+// 脱退したメンバーには変更フィード側で削除として記録される
+func (s *groupService) syncGroupUpserted(ctx context.Context, group *domain.Group) {
+	if s.syncChanges == nil {
+		return
+	}
+
+	userIDs := []string{group.OwnerID.String()}
+	for page := 1; ; page++ {
+		members, err := s.groupRepo.ListMembers(ctx, group.ID, commonDomain.Pagination{Page: page, PageSize: syncMemberPageSize})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Failed to list members for sync", logger.Any("groupID", group.ID), logger.Error(err))
+			return
+		}
+		for _, member := range members {
+			userIDs = append(userIDs, member.UserID.String())
+		}
+		if len(members) < syncMemberPageSize {
+			break
+		}
+	}
+
+	s.syncChanges.RecordUpsert(ctx, commonDomain.SyncEntityGroup, group.ID.String(), userIDs, group)
+}
+
+// isBlocked は2人のユーザーがブロック関係にあるかチェックする
+func (s *groupService) isBlocked(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error) {
+	if s.blockChecker == nil {
+		return false, nil
+	}
+	return s.blockChecker.IsBlocked(ctx, userID1.String(), userID2.String())
+}
+
+// uniqueUUIDs は順序を保ったまま重複したIDを取り除く
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
 func (s *groupService) validateCreateGroupInput(input CreateGroupInput) error {
 	if input.Name == "" {
 		return errors.New("name is required")
@@ -455,25 +760,6 @@ func (s *groupService) validateCreateGroupInput(input CreateGroupInput) error {
 	return nil
 }
 
-func (s *groupService) hasPermissionForAction(role domain.MemberRole, action GroupAction) bool {
-	switch action {
-	case ActionViewGroup:
-		return true // 全メンバーが閲覧可能
-	case ActionEditGroup, ActionDeleteGroup:
-		return role == domain.RoleOwner || role == domain.RoleAdmin
-	case ActionInviteMembers:
-		return role == domain.RoleOwner || role == domain.RoleAdmin
-	case ActionRemoveMembers, ActionManageRoles:
-		return role == domain.RoleOwner || role == domain.RoleAdmin
-	case ActionCreateTasks, ActionEditTasks, ActionDeleteTasks:
-		return role == domain.RoleOwner || role == domain.RoleAdmin
-	case ActionViewTasks, ActionViewSchedules:
-		return true // 全メンバーが閲覧可能
-	default:
-		return false
-	}
-}
-
 func (s *groupService) enrichMembersWithUserInfo(ctx context.Context, members []*domain.GroupMember) ([]*MemberWithUserInfo, error) {
 	if len(members) == 0 {
 		return []*MemberWithUserInfo{}, nil
@@ -516,6 +802,20 @@ func (s *groupService) InviteFriendsToGroup(ctx context.Context, groupID, invite
 		return nil, errors.New("insufficient permissions")
 	}
 
+	// 招待対象ユーザーの存在確認（全員分を一度に問い合わせる）
+	friendIDStrings := make([]string, len(friendIDs))
+	for i, friendID := range friendIDs {
+		friendIDStrings[i] = friendID.String()
+	}
+	missingIDs, err := s.userValidator.UsersExist(ctx, friendIDStrings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate users: %w", err)
+	}
+	missing := make(map[string]bool, len(missingIDs))
+	for _, id := range missingIDs {
+		missing[id] = true
+	}
+
 	results := make([]*GroupInviteResult, len(friendIDs))
 
 	for i, friendID := range friendIDs {
@@ -540,20 +840,28 @@ func (s *groupService) InviteFriendsToGroup(ctx context.Context, groupID, invite
 		}
 
 		// ユーザー存在確認
-		exists, err := s.userValidator.UserExists(ctx, friendID.String())
-		if err != nil || !exists {
+		if missing[friendID.String()] {
 			result.Success = false
 			result.Error = "ユーザーが見つかりません"
 			results[i] = result
 			continue
 		}
 
+		// ブロック関係チェック
+		blocked, err := s.isBlocked(ctx, inviterID, friendID)
+		if err != nil || blocked {
+			result.Success = false
+			result.Error = "このユーザーはグループに招待できません"
+			results[i] = result
+			continue
+		}
+
 		// TODO: Social モジュールとの連携でグループ招待を作成
 		// 現在は直接メンバーとして追加
 		member := domain.NewGroupMember(groupID, friendID, domain.RoleMember)
 		err = s.groupRepo.AddMember(ctx, member)
 		if err != nil {
-			s.logger.Error("Failed to add member to group",
+			s.logger.WithContext(ctx).Error("Failed to add member to group",
 				logger.Any("groupID", groupID),
 				logger.Any("friendID", friendID),
 				logger.Error(err))
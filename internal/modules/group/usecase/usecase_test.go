@@ -3,14 +3,19 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupMemory "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/memory"
 	"github.com/hryt430/Yotei+/internal/modules/group/usecase/mocks"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
@@ -18,11 +23,18 @@ import (
 //go:generate mockgen -source=../domain/group_repository.go -destination=mocks/mock_group_repository.go -package=mocks
 //go:generate mockgen -source=user_validator.go -destination=mocks/mock_user_validator.go -package=mocks
 
+// newDefaultMatrixRepo returns a repository mock for groups that keep the default permission matrix
+func newDefaultMatrixRepo(t *testing.T) *mocks.MockGroupRepository {
+	repo := mocks.NewMockGroupRepository(gomock.NewController(t))
+	repo.EXPECT().GetPermissionOverrides(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	return repo
+}
+
 func TestGroupService_CreateGroup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -193,11 +205,44 @@ func TestGroupService_CreateGroup(t *testing.T) {
 	}
 }
 
+// quotaCheckerFunc は関数をQuotaCheckerとして使うためのアダプタ
+type quotaCheckerFunc func(ctx context.Context, scope, subjectID, resource string, amount int64) error
+
+func (f quotaCheckerFunc) CheckQuota(ctx context.Context, scope, subjectID, resource string, amount int64) error {
+	return f(ctx, scope, subjectID, resource, amount)
+}
+
+func TestGroupService_CreateGroup_Quota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+	service := NewGroupService(mockRepo, mockValidator, &mockLogger)
+
+	ownerID := uuid.New()
+	service.SetQuotaChecker(quotaCheckerFunc(func(ctx context.Context, scope, subjectID, resource string, amount int64) error {
+		assert.Equal(t, commonDomain.QuotaScopeUser, scope)
+		assert.Equal(t, ownerID.String(), subjectID)
+		assert.Equal(t, commonDomain.QuotaGroups, resource)
+		return &commonDomain.QuotaExceededError{Scope: scope, Resource: resource, Limit: 3, Used: 3}
+	}))
+	mockValidator.EXPECT().UserExists(gomock.Any(), ownerID.String()).Return(true, nil)
+
+	result, err := service.CreateGroup(context.Background(), CreateGroupInput{
+		Name:    "Test Group",
+		Type:    domain.GroupTypeProject,
+		OwnerID: ownerID,
+	})
+
+	assert.ErrorIs(t, err, commonDomain.ErrQuotaExceeded)
+	assert.Nil(t, result)
+}
+
 func TestGroupService_GetGroup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -357,7 +402,7 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -438,6 +483,11 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 				mockRepo.EXPECT().
 					GetMemberRole(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(domain.RoleMember, nil)
+
+				// Not a sub-group, so no permissions are inherited
+				mockRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Any()).
+					Return(&domain.Group{ID: uuid.New()}, nil)
 			},
 			expectedError: "insufficient permissions",
 		},
@@ -494,7 +544,7 @@ func TestGroupService_DeleteGroup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -595,7 +645,7 @@ func TestGroupService_AddMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -710,6 +760,11 @@ func TestGroupService_AddMember(t *testing.T) {
 				mockRepo.EXPECT().
 					GetMemberRole(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(domain.RoleMember, nil)
+
+				// Not a sub-group, so no permissions are inherited
+				mockRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Any()).
+					Return(&domain.Group{ID: uuid.New()}, nil)
 			},
 			expectedError: "insufficient permissions",
 		},
@@ -731,11 +786,175 @@ func TestGroupService_AddMember(t *testing.T) {
 	}
 }
 
+func TestGroupService_AddMember_BlockedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := newDefaultMatrixRepo(t)
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockBlockChecker := mocks.NewMockBlockChecker(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupServiceWithBlockChecker(mockRepo, mockValidator, mockBlockChecker, &mockLogger)
+
+	groupID, userID, inviterID := uuid.New(), uuid.New(), uuid.New()
+
+	// Permission check
+	mockRepo.EXPECT().
+		IsMember(gomock.Any(), groupID, inviterID).
+		Return(true, nil)
+	mockRepo.EXPECT().
+		GetMemberRole(gomock.Any(), groupID, inviterID).
+		Return(domain.RoleAdmin, nil)
+
+	// User validation
+	mockValidator.EXPECT().
+		UserExists(gomock.Any(), userID.String()).
+		Return(true, nil)
+
+	// Block check
+	mockBlockChecker.EXPECT().
+		IsBlocked(gomock.Any(), inviterID.String(), userID.String()).
+		Return(true, nil)
+
+	err := service.AddMember(context.Background(), groupID, userID, inviterID, domain.RoleMember)
+	assert.ErrorIs(t, err, ErrUserBlocked)
+}
+
+func TestGroupService_AddMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := newDefaultMatrixRepo(t)
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockBlockChecker := mocks.NewMockBlockChecker(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupServiceWithBlockChecker(mockRepo, mockValidator, mockBlockChecker, &mockLogger)
+
+	groupID, inviterID := uuid.New(), uuid.New()
+	newUser, member, missing, blocked := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	group := &domain.Group{ID: groupID, MemberCount: 2, Version: 3}
+
+	// Permission check
+	mockRepo.EXPECT().
+		IsMember(gomock.Any(), groupID, inviterID).
+		Return(true, nil)
+	mockRepo.EXPECT().
+		GetMemberRole(gomock.Any(), groupID, inviterID).
+		Return(domain.RoleAdmin, nil)
+
+	// 存在確認・ブロック確認・メンバーシップ確認はそれぞれ1回だけ
+	mockValidator.EXPECT().
+		UsersExist(gomock.Any(), []string{newUser.String(), member.String(), missing.String(), blocked.String()}).
+		Return([]string{missing.String()}, nil)
+	mockBlockChecker.EXPECT().
+		GetBlockedUserIDs(gomock.Any(), inviterID.String()).
+		Return([]string{blocked.String()}, nil)
+	mockRepo.EXPECT().
+		GetExistingMemberIDs(gomock.Any(), groupID, []uuid.UUID{newUser, member, missing, blocked}).
+		Return(map[uuid.UUID]bool{member: true}, nil)
+
+	mockRepo.EXPECT().
+		GetGroupByID(gomock.Any(), groupID).
+		Return(group, nil)
+	mockRepo.EXPECT().
+		AddMembers(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, g *domain.Group, members []*domain.GroupMember) error {
+			assert.Equal(t, 3, g.MemberCount)
+			assert.Equal(t, 4, g.Version)
+			if assert.Len(t, members, 1) {
+				assert.Equal(t, newUser, members[0].UserID)
+				assert.Equal(t, domain.RoleMember, members[0].Role)
+			}
+			return nil
+		})
+
+	results, err := service.AddMembers(context.Background(), groupID, inviterID,
+		[]uuid.UUID{newUser, member, missing, blocked, newUser}, domain.RoleMember)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*BulkAddMemberResult{
+		{UserID: newUser, Status: BulkAddMemberAdded},
+		{UserID: member, Status: BulkAddMemberAlreadyMember},
+		{UserID: missing, Status: BulkAddMemberNotFound},
+		{UserID: blocked, Status: BulkAddMemberBlocked},
+	}, results)
+}
+
+func TestGroupService_AddMembers_InvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(mocks.NewMockGroupRepository(ctrl), mocks.NewMockUserValidator(ctrl), &mockLogger)
+
+	tooMany := make([]uuid.UUID, MaxBulkAddMembers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []uuid.UUID
+		role    domain.MemberRole
+	}{
+		{"no users", nil, domain.RoleMember},
+		{"too many users", tooMany, domain.RoleMember},
+		{"owner role", []uuid.UUID{uuid.New()}, domain.RoleOwner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AddMembers(context.Background(), uuid.New(), uuid.New(), tt.userIDs, tt.role)
+			assert.ErrorIs(t, err, ErrInvalidBulkAddMembers)
+		})
+	}
+}
+
+func TestGroupService_AddMembers_NoneAdded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := newDefaultMatrixRepo(t)
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(mockRepo, mockValidator, &mockLogger)
+
+	groupID, inviterID, member := uuid.New(), uuid.New(), uuid.New()
+
+	mockRepo.EXPECT().IsMember(gomock.Any(), groupID, inviterID).Return(true, nil)
+	mockRepo.EXPECT().GetMemberRole(gomock.Any(), groupID, inviterID).Return(domain.RoleOwner, nil)
+	mockValidator.EXPECT().UsersExist(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().
+		GetExistingMemberIDs(gomock.Any(), groupID, gomock.Any()).
+		Return(map[uuid.UUID]bool{member: true}, nil)
+	// 追加するユーザーがいない場合はグループを更新しない
+
+	results, err := service.AddMembers(context.Background(), groupID, inviterID, []uuid.UUID{member}, domain.RoleMember)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*BulkAddMemberResult{{UserID: member, Status: BulkAddMemberAlreadyMember}}, results)
+}
+
 func TestGroupService_RemoveMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -812,7 +1031,7 @@ func TestGroupService_RemoveMember(t *testing.T) {
 					RemoveMember(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 
-				// Update group member count
+				// Parent group lookup for the permission check and member count update
 				group := &domain.Group{
 					ID:          uuid.New(),
 					MemberCount: 2,
@@ -820,7 +1039,8 @@ func TestGroupService_RemoveMember(t *testing.T) {
 				}
 				mockRepo.EXPECT().
 					GetGroupByID(gomock.Any(), gomock.Any()).
-					Return(group, nil)
+					Return(group, nil).
+					Times(2)
 
 				mockRepo.EXPECT().
 					UpdateGroup(gomock.Any(), gomock.Any()).
@@ -851,11 +1071,55 @@ func TestGroupService_RemoveMember(t *testing.T) {
 	}
 }
 
+func TestGroupService_RemoveMemberWithUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := newDefaultMatrixRepo(t)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(mockRepo, mocks.NewMockUserValidator(ctrl), &mockLogger)
+	queue := undo.NewQueue(time.Hour, mockLogger)
+	service.SetUndoScheduler(queue)
+
+	groupID, adminID, memberID := uuid.New(), uuid.New(), uuid.New()
+	mockRepo.EXPECT().IsMember(gomock.Any(), groupID, adminID).Return(true, nil).AnyTimes()
+	mockRepo.EXPECT().GetMemberRole(gomock.Any(), groupID, adminID).Return(domain.RoleAdmin, nil).AnyTimes()
+
+	t.Run("removal is deferred and can be undone", func(t *testing.T) {
+		mockRepo.EXPECT().IsMember(gomock.Any(), groupID, memberID).Return(true, nil)
+		// RemoveMember is not expected: the member stays until the undo window passes
+
+		token, err := service.RemoveMemberWithUndo(context.Background(), groupID, memberID, adminID)
+
+		require.NoError(t, err)
+		require.NotNil(t, token)
+		assert.Equal(t, undo.ActionRemoveMember, token.Action)
+
+		action, err := queue.Undo(adminID.String(), token.Token)
+		require.NoError(t, err)
+		assert.Equal(t, undo.ActionRemoveMember, action)
+		queue.Flush()
+	})
+
+	t.Run("target must be a member", func(t *testing.T) {
+		outsider := uuid.New()
+		mockRepo.EXPECT().IsMember(gomock.Any(), groupID, outsider).Return(false, nil)
+
+		_, err := service.RemoveMemberWithUndo(context.Background(), groupID, outsider, adminID)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestGroupService_GetMyGroups(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -957,7 +1221,7 @@ func TestGroupService_UpdateMemberRole(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -991,13 +1255,15 @@ func TestGroupService_UpdateMemberRole(t *testing.T) {
 					GetMemberRole(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(domain.RoleAdmin, nil)
 
+				// Target is the owner
 				mockRepo.EXPECT().
 					GetMemberRole(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(domain.RoleAdmin, nil)
+					Return(domain.RoleOwner, nil)
 
+				// Requester is not the owner
 				mockRepo.EXPECT().
 					GetMemberRole(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(domain.RoleOwner, nil)
+					Return(domain.RoleAdmin, nil)
 			},
 			expectedError: "cannot change owner role",
 		},
@@ -1016,6 +1282,11 @@ func TestGroupService_UpdateMemberRole(t *testing.T) {
 				mockRepo.EXPECT().
 					GetMemberRole(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(domain.RoleMember, nil)
+
+				// Not a sub-group, so no permissions are inherited
+				mockRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Any()).
+					Return(&domain.Group{ID: uuid.New()}, nil)
 			},
 			expectedError: "insufficient permissions",
 		},
@@ -1041,7 +1312,7 @@ func TestGroupService_GetGroupStats(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mocks.NewMockGroupRepository(ctrl)
+	mockRepo := newDefaultMatrixRepo(t)
 	mockValidator := mocks.NewMockUserValidator(ctrl)
 	mockLogger := *logger.NewLogger(&logger.Config{
 		Level:       "error", // Only log errors to reduce noise in tests
@@ -1089,6 +1360,11 @@ func TestGroupService_GetGroupStats(t *testing.T) {
 				mockRepo.EXPECT().
 					IsMember(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(false, nil)
+
+				// Not a sub-group, so no permissions are inherited
+				mockRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Any()).
+					Return(&domain.Group{ID: uuid.New()}, nil)
 			},
 			expectedError: "insufficient permissions",
 		},
@@ -1112,27 +1388,1382 @@ func TestGroupService_GetGroupStats(t *testing.T) {
 	}
 }
 
-func TestGroupService_hasPermissionForAction(t *testing.T) {
-	service := &groupService{}
+func TestGroupService_SubGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	tests := []struct {
-		name     string
-		role     domain.MemberRole
-		action   GroupAction
-		expected bool
-	}{
-		{"Owner can edit group", domain.RoleOwner, ActionEditGroup, true},
-		{"Admin can edit group", domain.RoleAdmin, ActionEditGroup, true},
-		{"Member cannot edit group", domain.RoleMember, ActionEditGroup, false},
-		{"All members can view group", domain.RoleMember, ActionViewGroup, true},
-		{"All members can view tasks", domain.RoleMember, ActionViewTasks, true},
-		{"Unknown action denied", domain.RoleOwner, GroupAction("UNKNOWN"), false},
-	}
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := service.hasPermissionForAction(tt.role, tt.action)
-			assert.Equal(t, tt.expected, result)
+	ownerID, adminID, memberID, leadID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	project, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(project.ID, adminID, domain.RoleAdmin)))
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(project.ID, memberID, domain.RoleMember)))
+
+	t.Run("parent admin creates sub-team", func(t *testing.T) {
+		team, err := service.CreateGroup(ctx, CreateGroupInput{
+			Name: "Backend", Type: domain.GroupTypeProject, OwnerID: adminID, ParentGroupID: &project.ID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &project.ID, team.ParentGroupID)
+	})
+
+	t.Run("member cannot create sub-team", func(t *testing.T) {
+		_, err := service.CreateGroup(ctx, CreateGroupInput{
+			Name: "Frontend", Type: domain.GroupTypeProject, OwnerID: memberID, ParentGroupID: &project.ID,
+		})
+		assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+
+	t.Run("schedule group cannot have sub-teams", func(t *testing.T) {
+		schedule, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Calendar", Type: domain.GroupTypeSchedule, OwnerID: ownerID})
+		assert.NoError(t, err)
+
+		_, err = service.CreateGroup(ctx, CreateGroupInput{
+			Name: "Sub", Type: domain.GroupTypeProject, OwnerID: ownerID, ParentGroupID: &schedule.ID,
+		})
+		assert.ErrorIs(t, err, ErrInvalidParentGroup)
+	})
+
+	t.Run("hierarchy depth is limited", func(t *testing.T) {
+		parent := project
+		for depth := 2; depth <= domain.MaxGroupDepth; depth++ {
+			child, err := service.CreateGroup(ctx, CreateGroupInput{
+				Name: "Level", Type: domain.GroupTypeProject, OwnerID: ownerID, ParentGroupID: &parent.ID,
+			})
+			assert.NoError(t, err)
+			parent = child
+		}
+
+		_, err := service.CreateGroup(ctx, CreateGroupInput{
+			Name: "Too deep", Type: domain.GroupTypeProject, OwnerID: ownerID, ParentGroupID: &parent.ID,
+		})
+		assert.ErrorIs(t, err, ErrGroupHierarchyTooDeep)
+	})
+
+	t.Run("parent admins manage sub-teams but not the other way round", func(t *testing.T) {
+		team, err := service.CreateGroup(ctx, CreateGroupInput{
+			Name: "QA", Type: domain.GroupTypeProject, OwnerID: ownerID, ParentGroupID: &project.ID,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(team.ID, leadID, domain.RoleAdmin)))
+
+		canEdit, err := service.CheckPermission(ctx, team.ID, adminID, ActionRemoveMembers)
+		assert.NoError(t, err)
+		assert.True(t, canEdit, "parent admin inherits admin rights")
+
+		canEdit, err = service.CheckPermission(ctx, team.ID, memberID, ActionEditGroup)
+		assert.NoError(t, err)
+		assert.False(t, canEdit, "parent members do not inherit rights")
+
+		canEdit, err = service.CheckPermission(ctx, project.ID, leadID, ActionEditGroup)
+		assert.NoError(t, err)
+		assert.False(t, canEdit, "sub-team admins do not manage the parent")
+
+		assert.NoError(t, service.UpdateMemberRole(ctx, team.ID, leadID, adminID, domain.RoleMember))
+	})
+}
+
+func TestGroupService_GetGroupTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, memberID, outsiderID := uuid.New(), uuid.New(), uuid.New()
+	project, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(project.ID, memberID, domain.RoleMember)))
+
+	backend, err := service.CreateGroup(ctx, CreateGroupInput{
+		Name: "Backend", Type: domain.GroupTypeProject, OwnerID: ownerID, ParentGroupID: &project.ID,
+		Settings: domain.GroupSettings{IsPublic: true},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(backend.ID, memberID, domain.RoleMember)))
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(backend.ID, outsiderID, domain.RoleMember)))
+
+	_, err = service.CreateGroup(ctx, CreateGroupInput{
+		Name: "Secret", Type: domain.GroupTypeProject, OwnerID: ownerID, ParentGroupID: &backend.ID,
+	})
+	assert.NoError(t, err)
+
+	t.Run("owner sees the whole subtree", func(t *testing.T) {
+		tree, err := service.GetGroupTree(ctx, project.ID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, tree.Stats.MemberCount)
+		// owner, member and outsider, each counted once
+		assert.Equal(t, 3, tree.Total.MemberCount)
+		if assert.Len(t, tree.Children, 1) {
+			assert.Equal(t, backend.ID, tree.Children[0].Group.ID)
+			assert.Len(t, tree.Children[0].Children, 1)
+		}
+	})
+
+	t.Run("private sub-teams are hidden from plain members", func(t *testing.T) {
+		tree, err := service.GetGroupTree(ctx, project.ID, memberID)
+		assert.NoError(t, err)
+		if assert.Len(t, tree.Children, 1) {
+			assert.Empty(t, tree.Children[0].Children)
+		}
+	})
+
+	t.Run("private group requires membership", func(t *testing.T) {
+		_, err := service.GetGroupTree(ctx, project.ID, uuid.New())
+		assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+}
+
+func TestGroupService_PermissionMatrix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, adminID, memberID := uuid.New(), uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, adminID, domain.RoleAdmin)))
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, memberID, domain.RoleMember)))
+
+	canCreate, err := service.CheckPermission(ctx, group.ID, memberID, ActionCreateTasks)
+	assert.NoError(t, err)
+	assert.False(t, canCreate, "members cannot create tasks by default")
+
+	t.Run("only the owner can change permissions", func(t *testing.T) {
+		_, err := service.UpdatePermissionMatrix(ctx, group.ID, adminID, domain.PermissionMatrix{})
+		assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+
+	t.Run("invalid matrix is rejected", func(t *testing.T) {
+		_, err := service.UpdatePermissionMatrix(ctx, group.ID, ownerID, domain.PermissionMatrix{
+			domain.ActionManageRoles: {domain.RoleMember},
+		})
+		assert.ErrorIs(t, err, ErrInvalidPermissionMatrix)
+	})
+
+	t.Run("changes are enforced", func(t *testing.T) {
+		matrix, err := service.UpdatePermissionMatrix(ctx, group.ID, ownerID, domain.PermissionMatrix{
+			domain.ActionCreateTasks: {domain.RoleAdmin, domain.RoleMember},
+			domain.ActionEditGroup:   {},
+		})
+		assert.NoError(t, err)
+		assert.True(t, matrix.Allows(domain.RoleMember, domain.ActionCreateTasks))
+
+		canCreate, err := service.CheckPermission(ctx, group.ID, memberID, ActionCreateTasks)
+		assert.NoError(t, err)
+		assert.True(t, canCreate)
+
+		canEdit, err := service.CheckPermission(ctx, group.ID, adminID, ActionEditGroup)
+		assert.NoError(t, err)
+		assert.False(t, canEdit)
+
+		canEdit, err = service.CheckPermission(ctx, group.ID, ownerID, ActionEditGroup)
+		assert.NoError(t, err)
+		assert.True(t, canEdit, "the owner keeps every permission")
+
+		_, err = service.UpdateGroup(ctx, group.ID, UpdateGroupInput{Name: &[]string{"Renamed"}[0]}, adminID)
+		assert.Error(t, err)
+
+		got, err := service.GetPermissionMatrix(ctx, group.ID, memberID)
+		assert.NoError(t, err)
+		assert.Equal(t, matrix, got)
+	})
+
+	t.Run("members with a permission follow the matrix", func(t *testing.T) {
+		_, err := service.UpdatePermissionMatrix(ctx, group.ID, ownerID, domain.PermissionMatrix{
+			domain.ActionEditTasks: {domain.RoleMember},
+		})
+		assert.NoError(t, err)
+
+		userIDs, err := service.ListMemberIDsWithPermission(ctx, group.ID, ActionEditTasks)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{ownerID, memberID}, userIDs)
+	})
+
+	t.Run("empty matrix restores the defaults", func(t *testing.T) {
+		_, err := service.UpdatePermissionMatrix(ctx, group.ID, ownerID, domain.PermissionMatrix{})
+		assert.NoError(t, err)
+
+		canCreate, err := service.CheckPermission(ctx, group.ID, memberID, ActionCreateTasks)
+		assert.NoError(t, err)
+		assert.False(t, canCreate)
+	})
+
+	t.Run("outsiders cannot read the matrix", func(t *testing.T) {
+		_, err := service.GetPermissionMatrix(ctx, group.ID, uuid.New())
+		assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+}
+
+func TestGroupService_CustomRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, adminID, memberID := uuid.New(), uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, adminID, domain.RoleAdmin)))
+	assert.NoError(t, repo.AddMember(ctx, domain.NewGroupMember(group.ID, memberID, domain.RoleMember)))
+
+	t.Run("members cannot manage roles", func(t *testing.T) {
+		_, err := service.CreateCustomRole(ctx, group.ID, memberID, CustomRoleInput{Name: "Viewer"})
+		assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	})
+
+	t.Run("invalid roles are rejected", func(t *testing.T) {
+		_, err := service.CreateCustomRole(ctx, group.ID, adminID, CustomRoleInput{
+			Name:        "Janitor",
+			Permissions: []domain.GroupAction{domain.ActionDeleteGroup},
 		})
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	taskManager, err := service.CreateCustomRole(ctx, group.ID, adminID, CustomRoleInput{
+		Name:        "Task Manager",
+		Permissions: []domain.GroupAction{domain.ActionCreateTasks, domain.ActionEditTasks},
+	})
+	assert.NoError(t, err)
+
+	t.Run("names are unique within the group", func(t *testing.T) {
+		_, err := service.CreateCustomRole(ctx, group.ID, adminID, CustomRoleInput{Name: "task manager"})
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("assigned role grants its permissions", func(t *testing.T) {
+		assert.NoError(t, service.UpdateMemberRole(ctx, group.ID, memberID, adminID, taskManager.Role()))
+
+		canCreate, err := service.CheckPermission(ctx, group.ID, memberID, ActionCreateTasks)
+		assert.NoError(t, err)
+		assert.True(t, canCreate)
+
+		canDelete, err := service.CheckPermission(ctx, group.ID, memberID, ActionDeleteTasks)
+		assert.NoError(t, err)
+		assert.False(t, canDelete)
+
+		canView, err := service.CheckPermission(ctx, group.ID, memberID, ActionViewGroup)
+		assert.NoError(t, err)
+		assert.True(t, canView)
+	})
+
+	t.Run("updates apply to assigned members", func(t *testing.T) {
+		_, err := service.UpdateCustomRole(ctx, group.ID, taskManager.ID, adminID, CustomRoleInput{
+			Name:        "Task Manager",
+			Permissions: []domain.GroupAction{domain.ActionDeleteTasks},
+		})
+		assert.NoError(t, err)
+
+		canDelete, err := service.CheckPermission(ctx, group.ID, memberID, ActionDeleteTasks)
+		assert.NoError(t, err)
+		assert.True(t, canDelete)
+	})
+
+	t.Run("undefined roles cannot be assigned", func(t *testing.T) {
+		err := service.UpdateMemberRole(ctx, group.ID, memberID, adminID, domain.MemberRole(uuid.New().String()))
+		assert.ErrorIs(t, err, ErrInvalidRole)
+
+		err = service.UpdateMemberRole(ctx, group.ID, memberID, adminID, domain.MemberRole("VIEWER"))
+		assert.ErrorIs(t, err, ErrInvalidRole)
+
+		// Roles of another group cannot be borrowed
+		other, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Other", Type: domain.GroupTypeProject, OwnerID: ownerID})
+		assert.NoError(t, err)
+		otherRole, err := service.CreateCustomRole(ctx, other.ID, ownerID, CustomRoleInput{Name: "Viewer"})
+		assert.NoError(t, err)
+		err = service.UpdateMemberRole(ctx, group.ID, memberID, adminID, otherRole.Role())
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("deleting a role resets its members", func(t *testing.T) {
+		assert.NoError(t, service.DeleteCustomRole(ctx, group.ID, taskManager.ID, adminID))
+
+		role, err := service.GetUserRole(ctx, group.ID, memberID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleMember, role)
+
+		err = service.DeleteCustomRole(ctx, group.ID, taskManager.ID, adminID)
+		assert.ErrorIs(t, err, ErrCustomRoleNotFound)
+
+		roles, err := service.ListCustomRoles(ctx, group.ID, memberID)
+		assert.NoError(t, err)
+		assert.Empty(t, roles)
+	})
+}
+
+func TestGroupService_GuestMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, guestID := uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	assert.NoError(t, err)
+	assert.NoError(t, service.AddMember(ctx, group.ID, guestID, ownerID, domain.RoleGuest))
+
+	for _, action := range []GroupAction{ActionViewGroup, ActionViewTasks, ActionViewSchedules} {
+		allowed, err := service.CheckPermission(ctx, group.ID, guestID, action)
+		assert.NoError(t, err)
+		assert.True(t, allowed, "guests can %s", action)
+	}
+
+	_, err = service.GetGroupStats(ctx, group.ID, guestID)
+	assert.NoError(t, err)
+
+	// Opening write actions to members does not extend them to guests
+	_, err = service.UpdatePermissionMatrix(ctx, group.ID, ownerID, domain.PermissionMatrix{
+		domain.ActionCreateTasks:   {domain.RoleAdmin, domain.RoleMember},
+		domain.ActionInviteMembers: {domain.RoleAdmin, domain.RoleMember},
+	})
+	assert.NoError(t, err)
+
+	for _, action := range []GroupAction{ActionCreateTasks, ActionEditTasks, ActionInviteMembers, ActionEditGroup} {
+		allowed, err := service.CheckPermission(ctx, group.ID, guestID, action)
+		assert.NoError(t, err)
+		assert.False(t, allowed, "guests cannot %s", action)
+	}
+
+	err = service.AddMember(ctx, group.ID, uuid.New(), guestID, domain.RoleMember)
+	assert.Error(t, err)
+}
+
+// stubOpenTaskCounter は固定の未完了タスク数を返す
+type stubOpenTaskCounter map[uuid.UUID]int
+
+func (c stubOpenTaskCounter) CountOpenTasks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	return c, nil
+}
+
+func TestGroupService_AssignmentPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, memberID, guestID := uuid.New(), uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	assert.NoError(t, err)
+	assert.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+	assert.NoError(t, service.AddMember(ctx, group.ID, guestID, ownerID, domain.RoleGuest))
+
+	// Auto-assignment is off until a policy is configured
+	settings, err := service.GetAssignmentSettings(ctx, group.ID, memberID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AssignmentPolicyNone, settings.Policy)
+	picked, err := service.PickTaskAssignee(ctx, group.ID, ownerID, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, picked)
+
+	// Members cannot change the policy and unknown policies are rejected
+	_, err = service.UpdateAssignmentPolicy(ctx, group.ID, memberID, domain.AssignmentPolicyRoundRobin)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.UpdateAssignmentPolicy(ctx, group.ID, ownerID, domain.AssignmentPolicy("RANDOM"))
+	assert.ErrorIs(t, err, ErrInvalidAssignmentPolicy)
+
+	// Round robin alternates between the owner and the member, skipping the guest
+	_, err = service.UpdateAssignmentPolicy(ctx, group.ID, ownerID, domain.AssignmentPolicyRoundRobin)
+	assert.NoError(t, err)
+	var assignees []uuid.UUID
+	for i := 0; i < 3; i++ {
+		picked, err := service.PickTaskAssignee(ctx, group.ID, ownerID, nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, picked) {
+			assignees = append(assignees, *picked)
+		}
+	}
+	assert.Equal(t, []uuid.UUID{ownerID, memberID, ownerID}, assignees)
+
+	settings, err = service.GetAssignmentSettings(ctx, group.ID, ownerID)
+	assert.NoError(t, err)
+	assert.Equal(t, ownerID, *settings.LastAssigneeID)
+
+	// Least loaded picks the member with the fewest open tasks
+	_, err = service.UpdateAssignmentPolicy(ctx, group.ID, ownerID, domain.AssignmentPolicyLeastLoaded)
+	assert.NoError(t, err)
+	picked, err = service.PickTaskAssignee(ctx, group.ID, ownerID, stubOpenTaskCounter{ownerID: 4, memberID: 2, guestID: 0})
+	assert.NoError(t, err)
+	if assert.NotNil(t, picked) {
+		assert.Equal(t, memberID, *picked)
+	}
+
+	// Picking requires permission to create tasks in the group
+	_, err = service.PickTaskAssignee(ctx, group.ID, guestID, nil)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+}
+
+// stubTaskCompletions は固定の完了記録を返し、呼び出し回数を数える
+type stubTaskCompletions struct {
+	completions map[uuid.UUID][]domain.TaskCompletion
+	calls       int
+}
+
+func (s *stubTaskCompletions) ListTaskCompletions(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID][]domain.TaskCompletion, error) {
+	s.calls++
+	return s.completions, nil
+}
+
+func TestGroupService_Leaderboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, memberID, outsiderID := uuid.New(), uuid.New(), uuid.New()
+	mockValidator.EXPECT().GetUsersInfoBatch(gomock.Any(), gomock.Any()).Return(map[string]*commonDomain.UserInfo{
+		ownerID.String():  {ID: ownerID.String(), Username: "owner"},
+		memberID.String(): {ID: memberID.String(), Username: "member"},
+	}, nil).AnyTimes()
+
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+
+	weekStart, _ := domain.LeaderboardWeek(time.Now(), time.UTC)
+	provider := &stubTaskCompletions{completions: map[uuid.UUID][]domain.TaskCompletion{
+		memberID: {{CompletedAt: weekStart}, {CompletedAt: weekStart}},
+		ownerID:  {{CompletedAt: weekStart}},
+	}}
+	service.SetTaskCompletionProvider(provider)
+
+	// The leaderboard is opt-in
+	_, err = service.GetLeaderboard(ctx, group.ID, memberID, false)
+	assert.ErrorIs(t, err, ErrLeaderboardDisabled)
+
+	// Only editors can enable it and the timezone is validated
+	enabled := true
+	_, err = service.UpdateLeaderboardSettings(ctx, group.ID, memberID, LeaderboardSettingsInput{Enabled: &enabled})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	badTimezone := "Mars/Olympus"
+	_, err = service.UpdateLeaderboardSettings(ctx, group.ID, ownerID, LeaderboardSettingsInput{Enabled: &enabled, Timezone: &badTimezone})
+	assert.ErrorIs(t, err, ErrInvalidLeaderboardSettings)
+	_, err = service.UpdateLeaderboardSettings(ctx, group.ID, ownerID, LeaderboardSettingsInput{Enabled: &enabled})
+	require.NoError(t, err)
+
+	// Non-members cannot view it
+	_, err = service.GetLeaderboard(ctx, group.ID, outsiderID, false)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+
+	board, err := service.GetLeaderboard(ctx, group.ID, ownerID, false)
+	require.NoError(t, err)
+	require.Len(t, board.Entries, 2)
+	assert.Equal(t, "member", board.Entries[0].DisplayName)
+	assert.Equal(t, 2, board.Entries[0].CompletedTasks)
+	assert.True(t, board.Entries[1].IsMe)
+
+	// Results are cached until preferences change
+	_, err = service.GetLeaderboard(ctx, group.ID, memberID, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	// Anonymous members are hidden from others but still ranked
+	_, err = service.UpdateLeaderboardVisibility(ctx, group.ID, memberID, domain.LeaderboardVisibility("PUBLIC"))
+	assert.ErrorIs(t, err, ErrInvalidLeaderboardSettings)
+	_, err = service.UpdateLeaderboardVisibility(ctx, group.ID, memberID, domain.LeaderboardAnonymous)
+	require.NoError(t, err)
+	board, err = service.GetLeaderboard(ctx, group.ID, ownerID, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.calls)
+	assert.Equal(t, domain.AnonymousMemberName, board.Entries[0].DisplayName)
+	assert.Nil(t, board.Entries[0].UserID)
+
+	view, err := service.GetLeaderboardSettings(ctx, group.ID, memberID)
+	require.NoError(t, err)
+	assert.True(t, view.Settings.Enabled)
+	assert.Equal(t, domain.LeaderboardAnonymous, view.MyVisibility)
+
+	// Hidden members are left out
+	_, err = service.UpdateLeaderboardVisibility(ctx, group.ID, memberID, domain.LeaderboardHidden)
+	require.NoError(t, err)
+	board, err = service.GetLeaderboard(ctx, group.ID, memberID, false)
+	require.NoError(t, err)
+	require.Len(t, board.Entries, 1)
+	assert.Equal(t, ownerID, *board.Entries[0].UserID)
+}
+
+func TestGroupService_ScheduleCard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+	secret := []byte("card-secret")
+	service.SetScheduleCardSigner(secret, "https://api.example.com/")
+
+	ownerID, memberID, outsiderID := uuid.New(), uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Family", Type: domain.GroupTypeSchedule, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+
+	dinner := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Dinner", StartsAt: time.Now().Add(2 * time.Hour), CreatedBy: ownerID}
+	directory := &stubEventDirectory{events: []*domain.GroupEvent{dinner}}
+	service.SetEventDirectory(directory)
+
+	// Cards are opt-in
+	_, err = service.CreateScheduleCardLink(ctx, group.ID, memberID)
+	assert.ErrorIs(t, err, ErrScheduleCardDisabled)
+
+	// Only editors can enable them and the timezone is validated
+	enabled := true
+	_, err = service.UpdateScheduleCardSettings(ctx, group.ID, memberID, ScheduleCardSettingsInput{Enabled: &enabled})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	badTimezone := "Mars/Olympus"
+	_, err = service.UpdateScheduleCardSettings(ctx, group.ID, ownerID, ScheduleCardSettingsInput{Timezone: &badTimezone})
+	assert.ErrorIs(t, err, ErrInvalidScheduleCardSettings)
+	_, err = service.UpdateScheduleCardSettings(ctx, group.ID, ownerID, ScheduleCardSettingsInput{Enabled: &enabled})
+	require.NoError(t, err)
+
+	settings, err := service.GetScheduleCardSettings(ctx, group.ID, memberID)
+	require.NoError(t, err)
+	assert.True(t, settings.Enabled)
+	assert.True(t, settings.ShowTitles)
+	_, err = service.GetScheduleCardSettings(ctx, group.ID, outsiderID)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+
+	// Non-members cannot create links
+	_, err = service.CreateScheduleCardLink(ctx, group.ID, outsiderID)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+
+	view, err := service.CreateScheduleCardLink(ctx, group.ID, memberID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(view.ImageURL, "https://api.example.com/api/v1/public/groups/"+group.ID.String()+"/schedule-card?"))
+	assert.Contains(t, view.ImageURL, "format=svg")
+	assert.Contains(t, view.PDFURL, "format=pdf")
+
+	// Anyone with the signed link can render the card
+	svg, err := service.RenderScheduleCard(ctx, view.Link, domain.ScheduleCardSVG)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "Dinner")
+	pdf, err := service.RenderScheduleCard(ctx, view.Link, domain.ScheduleCardPDF)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(pdf), "%PDF-"))
+
+	// Rendered cards are cached
+	directory.events = nil
+	svg, err = service.RenderScheduleCard(ctx, view.Link, domain.ScheduleCardSVG)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "Dinner")
+
+	// Tampered links are rejected
+	tampered := *view.Link
+	tampered.StartDate = time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	_, err = service.RenderScheduleCard(ctx, &tampered, domain.ScheduleCardSVG)
+	assert.ErrorIs(t, err, ErrInvalidScheduleCardLink)
+	_, err = service.RenderScheduleCard(ctx, view.Link, domain.ScheduleCardFormat("png"))
+	assert.ErrorIs(t, err, ErrInvalidScheduleCardSettings)
+
+	// Changing the settings rebuilds the card and hides titles
+	directory.events = []*domain.GroupEvent{dinner}
+	showTitles := false
+	_, err = service.UpdateScheduleCardSettings(ctx, group.ID, ownerID, ScheduleCardSettingsInput{ShowTitles: &showTitles})
+	require.NoError(t, err)
+	svg, err = service.RenderScheduleCard(ctx, view.Link, domain.ScheduleCardSVG)
+	require.NoError(t, err)
+	assert.NotContains(t, string(svg), "Dinner")
+	assert.Contains(t, string(svg), domain.ScheduleCardHiddenTitle)
+
+	// Disabling the card stops existing links from rendering
+	disabled := false
+	_, err = service.UpdateScheduleCardSettings(ctx, group.ID, ownerID, ScheduleCardSettingsInput{Enabled: &disabled})
+	require.NoError(t, err)
+	_, err = service.RenderScheduleCard(ctx, view.Link, domain.ScheduleCardSVG)
+	assert.ErrorIs(t, err, ErrScheduleCardDisabled)
+}
+
+type stubEventDirectory struct {
+	events []*domain.GroupEvent
+}
+
+func (s *stubEventDirectory) GetGroupEvent(ctx context.Context, groupID uuid.UUID, eventID string) (*domain.GroupEvent, error) {
+	for _, event := range s.events {
+		if event.GroupID == groupID && event.ID == eventID {
+			return event, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubEventDirectory) ListGroupEvents(ctx context.Context, groupID uuid.UUID) ([]*domain.GroupEvent, error) {
+	var events []*domain.GroupEvent
+	for _, event := range s.events {
+		if event.GroupID == groupID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *stubEventDirectory) ListUpcomingEvents(ctx context.Context, from, to time.Time) ([]*domain.GroupEvent, error) {
+	var events []*domain.GroupEvent
+	for _, event := range s.events {
+		if !event.StartsAt.Before(from) && event.StartsAt.Before(to) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type stubEventReminderNotifier struct {
+	reminded []uuid.UUID
+}
+
+func (s *stubEventReminderNotifier) NotifyRSVPReminder(ctx context.Context, userID uuid.UUID, event *domain.GroupEvent) error {
+	s.reminded = append(s.reminded, userID)
+	return nil
+}
+
+func TestGroupService_EventRSVP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, memberID, relativeID, outsiderID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Family", Type: domain.GroupTypeSchedule, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+	require.NoError(t, service.AddMember(ctx, group.ID, relativeID, ownerID, domain.RoleMember))
+	project, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	require.NoError(t, err)
+
+	now := time.Now()
+	upcoming := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Dinner", StartsAt: now.Add(2 * time.Hour), CreatedBy: memberID}
+	later := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Trip", StartsAt: now.Add(72 * time.Hour), CreatedBy: ownerID}
+	past := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Lunch", StartsAt: now.Add(-time.Hour), CreatedBy: memberID}
+	projectEvent := &domain.GroupEvent{ID: uuid.NewString(), GroupID: project.ID, Title: "Kickoff", StartsAt: now.Add(time.Hour), CreatedBy: ownerID}
+	directory := &stubEventDirectory{events: []*domain.GroupEvent{upcoming, later, past, projectEvent}}
+	notifier := &stubEventReminderNotifier{}
+
+	// Without an event directory there are no events
+	_, err = service.SetRSVP(ctx, group.ID, upcoming.ID, memberID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	service.SetEventDirectory(directory)
+	service.SetEventReminderNotifier(notifier)
+
+	// Only members can answer, only valid answers are accepted, and only before the event starts
+	_, err = service.SetRSVP(ctx, group.ID, upcoming.ID, outsiderID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.SetRSVP(ctx, group.ID, upcoming.ID, memberID, domain.RSVPNoResponse)
+	assert.ErrorIs(t, err, ErrInvalidRSVP)
+	_, err = service.SetRSVP(ctx, group.ID, past.ID, memberID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrRSVPClosed)
+	_, err = service.SetRSVP(ctx, project.ID, projectEvent.ID, ownerID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	_, err = service.SetRSVP(ctx, group.ID, upcoming.ID, memberID, domain.RSVPMaybe)
+	require.NoError(t, err)
+	rsvp, err := service.SetRSVP(ctx, group.ID, upcoming.ID, memberID, domain.RSVPYes)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RSVPYes, rsvp.Status)
+	_, err = service.SetRSVP(ctx, group.ID, upcoming.ID, ownerID, domain.RSVPNo)
+	require.NoError(t, err)
+
+	attendees, err := service.GetEventAttendees(ctx, group.ID, upcoming.ID, relativeID)
+	require.NoError(t, err)
+	assert.Len(t, attendees.Attendees, 3)
+	assert.Equal(t, 1, attendees.Yes)
+	assert.Equal(t, 1, attendees.No)
+	assert.Equal(t, 1, attendees.NoResponse)
+	_, err = service.GetEventAttendees(ctx, group.ID, upcoming.ID, outsiderID)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+
+	// Reminders go to members who have not answered, once per event within the lead time
+	sent, err := service.SendRSVPReminders(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []uuid.UUID{relativeID}, notifier.reminded)
+	sent, err = service.SendRSVPReminders(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// Attendance is recorded after the event starts by its creator or members who can edit tasks
+	attended := true
+	_, err = service.RecordAttendance(ctx, group.ID, upcoming.ID, memberID, ownerID, attended)
+	assert.ErrorIs(t, err, ErrEventNotStarted)
+	_, err = service.RecordAttendance(ctx, group.ID, past.ID, relativeID, memberID, attended)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.RecordAttendance(ctx, group.ID, past.ID, memberID, outsiderID, attended)
+	assert.ErrorIs(t, err, ErrInvalidRSVP)
+	rsvp, err = service.RecordAttendance(ctx, group.ID, past.ID, memberID, relativeID, attended)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RSVPNoResponse, rsvp.Status)
+	_, err = service.RecordAttendance(ctx, group.ID, past.ID, ownerID, ownerID, false)
+	require.NoError(t, err)
+
+	// Group stats include attendance for started events only
+	stats, err := service.GetGroupStats(ctx, group.ID, memberID)
+	require.NoError(t, err)
+	require.Len(t, stats.Attendance, 3)
+	byUser := make(map[uuid.UUID]domain.MemberAttendance)
+	for _, attendance := range stats.Attendance {
+		byUser[attendance.UserID] = attendance
+	}
+	assert.Equal(t, 1, byUser[relativeID].Events)
+	assert.Equal(t, 1, byUser[relativeID].Attended)
+	require.NotNil(t, byUser[relativeID].AttendanceRate)
+	assert.Equal(t, 100.0, *byUser[relativeID].AttendanceRate)
+	assert.Equal(t, 1, byUser[ownerID].Absent)
+	assert.Nil(t, byUser[memberID].AttendanceRate)
+	assert.Equal(t, 1, byUser[memberID].NoResponse)
+
+	projectStats, err := service.GetGroupStats(ctx, project.ID, ownerID)
+	require.NoError(t, err)
+	assert.Empty(t, projectStats.Attendance)
+}
+
+func TestGroupService_EventRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, memberID, relativeID := uuid.New(), uuid.New(), uuid.New()
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Family", Type: domain.GroupTypeSchedule, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+	require.NoError(t, service.AddMember(ctx, group.ID, relativeID, ownerID, domain.RoleMember))
+
+	// A weekly event whose first two occurrences have already started
+	now := time.Now().UTC()
+	first := now.Add(-14*24*time.Hour + 2*time.Hour).Truncate(time.Second)
+	standup := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Standup", StartsAt: first, CreatedBy: memberID}
+	notifier := &stubEventReminderNotifier{}
+	service.SetEventDirectory(&stubEventDirectory{events: []*domain.GroupEvent{standup}})
+	service.SetEventReminderNotifier(notifier)
+	occurrenceID := func(weeks int) string {
+		return domain.OccurrenceID(standup.ID, first.AddDate(0, 0, 7*weeks))
+	}
+
+	// Only the creator or members who can edit tasks can set a valid recurrence
+	weekly := EventRecurrenceInput{Frequency: domain.RecurrenceWeekly, Interval: 1}
+	_, err = service.SetEventRecurrence(ctx, group.ID, standup.ID, relativeID, weekly)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.SetEventRecurrence(ctx, group.ID, standup.ID, memberID, EventRecurrenceInput{Frequency: domain.RecurrenceWeekly})
+	assert.ErrorIs(t, err, ErrInvalidRecurrence)
+	require.NoError(t, service.UpdateMemberRole(ctx, group.ID, memberID, ownerID, domain.RoleGuest))
+	_, err = service.SetEventRecurrence(ctx, group.ID, standup.ID, memberID, weekly)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions, "creators changed to guests cannot change the event")
+	require.NoError(t, service.UpdateMemberRole(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+	_, err = service.GetEventRecurrence(ctx, group.ID, standup.ID, relativeID)
+	assert.ErrorIs(t, err, ErrRecurrenceNotFound)
+	recurrence, err := service.SetEventRecurrence(ctx, group.ID, standup.ID, memberID, weekly)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", recurrence.Timezone)
+	recurrence, err = service.GetEventRecurrence(ctx, group.ID, standup.ID, relativeID)
+	require.NoError(t, err)
+	assert.Equal(t, memberID, recurrence.CreatedBy)
+
+	// Occurrences are expanded in the list and answered by occurrence id
+	schedule, err := service.ListEventOccurrences(ctx, group.ID, relativeID, now.Add(-15*24*time.Hour), now.Add(10*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, schedule.Events, 4)
+	assert.Equal(t, occurrenceID(2), schedule.Events[2].ID)
+	_, err = service.ListEventOccurrences(ctx, group.ID, relativeID, now, now.Add(100*24*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidEventRange)
+
+	_, err = service.SetRSVP(ctx, group.ID, standup.ID, relativeID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	_, err = service.SetRSVP(ctx, group.ID, occurrenceID(2), relativeID, domain.RSVPYes)
+	require.NoError(t, err)
+	_, err = service.SetRSVP(ctx, group.ID, domain.OccurrenceID(standup.ID, first.Add(time.Hour)), relativeID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	// Cancel one upcoming occurrence and move another; started occurrences cannot be changed
+	_, err = service.ChangeOccurrence(ctx, group.ID, occurrenceID(3), relativeID, OccurrenceChangeInput{Cancelled: true})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.ChangeOccurrence(ctx, group.ID, occurrenceID(1), memberID, OccurrenceChangeInput{Cancelled: true})
+	assert.ErrorIs(t, err, ErrEventStarted)
+	past := now.Add(-time.Hour)
+	_, err = service.ChangeOccurrence(ctx, group.ID, occurrenceID(2), memberID, OccurrenceChangeInput{StartsAt: &past})
+	assert.ErrorIs(t, err, ErrInvalidRecurrence)
+	_, err = service.ChangeOccurrence(ctx, group.ID, occurrenceID(3), memberID, OccurrenceChangeInput{Cancelled: true})
+	require.NoError(t, err)
+	movedTo := now.Add(3 * time.Hour).Truncate(time.Second)
+	exception, err := service.ChangeOccurrence(ctx, group.ID, occurrenceID(2), ownerID, OccurrenceChangeInput{StartsAt: &movedTo})
+	require.NoError(t, err)
+	assert.Equal(t, standup.ID, exception.EventID)
+
+	_, err = service.SetRSVP(ctx, group.ID, occurrenceID(3), relativeID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	schedule, err = service.ListEventOccurrences(ctx, group.ID, relativeID, now.Add(-15*24*time.Hour), now.Add(10*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, schedule.Events, 3)
+	assert.True(t, schedule.Events[2].Moved)
+	assert.Equal(t, movedTo, schedule.Events[2].StartsAt)
+
+	// The answer carries over to the moved occurrence, and reminders are sent per occurrence
+	attendees, err := service.GetEventAttendees(ctx, group.ID, occurrenceID(2), ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attendees.Yes)
+	sent, err := service.SendRSVPReminders(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.ElementsMatch(t, []uuid.UUID{ownerID, memberID}, notifier.reminded)
+	sent, err = service.SendRSVPReminders(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// Attendance of started occurrences is counted in group stats
+	_, err = service.RecordAttendance(ctx, group.ID, occurrenceID(1), memberID, relativeID, true)
+	require.NoError(t, err)
+	stats, err := service.GetGroupStats(ctx, group.ID, ownerID)
+	require.NoError(t, err)
+	for _, attendance := range stats.Attendance {
+		assert.Equal(t, 2, attendance.Events)
+		if attendance.UserID == relativeID {
+			assert.Equal(t, 1, attendance.Attended)
+		}
+	}
+
+	// Restoring brings the cancelled occurrence back, and deleting the recurrence makes it a single event again
+	require.NoError(t, service.RestoreOccurrence(ctx, group.ID, occurrenceID(3), memberID))
+	schedule, err = service.ListEventOccurrences(ctx, group.ID, relativeID, now.Add(-15*24*time.Hour), now.Add(10*24*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, schedule.Events, 4)
+
+	require.NoError(t, service.DeleteEventRecurrence(ctx, group.ID, standup.ID, memberID))
+	assert.ErrorIs(t, service.DeleteEventRecurrence(ctx, group.ID, standup.ID, memberID), ErrRecurrenceNotFound)
+	_, err = service.SetRSVP(ctx, group.ID, standup.ID, relativeID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrRSVPClosed)
+	_, err = service.SetRSVP(ctx, group.ID, occurrenceID(2), relativeID, domain.RSVPYes)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
+
+type stubGroupTaskGateway struct {
+	created []GroupTaskInput
+	tasks   map[string]*domain.LinkedTask
+	groups  map[string]uuid.UUID
+	events  *stubEventDirectory // tasks with a start date are also registered as events
+	fail    bool
+}
+
+func (s *stubGroupTaskGateway) addTask(groupID uuid.UUID, task *domain.LinkedTask) {
+	if s.tasks == nil {
+		s.tasks = make(map[string]*domain.LinkedTask)
+		s.groups = make(map[string]uuid.UUID)
+	}
+	s.tasks[task.ID] = task
+	s.groups[task.ID] = groupID
+}
+
+func (s *stubGroupTaskGateway) GetGroupTask(ctx context.Context, groupID uuid.UUID, taskID string) (*domain.LinkedTask, error) {
+	if s.groups[taskID] != groupID {
+		return nil, nil
+	}
+	return s.tasks[taskID], nil
+}
+
+func (s *stubGroupTaskGateway) CreateGroupTask(ctx context.Context, input GroupTaskInput) (string, error) {
+	if s.fail {
+		return "", errors.New("task service unavailable")
+	}
+	s.created = append(s.created, input)
+	id := uuid.NewString()
+	s.addTask(input.GroupID, &domain.LinkedTask{ID: id, Title: input.Title, Status: "TODO", EstimateMinutes: input.EstimateMinutes})
+	if input.StartDate != nil && s.events != nil {
+		s.events.events = append(s.events.events, &domain.GroupEvent{ID: id, GroupID: input.GroupID, Title: input.Title, StartsAt: *input.StartDate, CreatedBy: input.CreatedBy})
+	}
+	return id, nil
+}
+
+func TestGroupService_EventNotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	ownerID, adminID, memberID, outsiderID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockValidator.EXPECT().GetUsersInfoBatch(gomock.Any(), gomock.Any()).Return(map[string]*commonDomain.UserInfo{
+		ownerID.String():  {ID: ownerID.String(), Username: "owner"},
+		adminID.String():  {ID: adminID.String(), Username: "Alice"},
+		memberID.String(): {ID: memberID.String(), Username: "bob"},
+	}, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Team", Type: domain.GroupTypeSchedule, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, adminID, ownerID, domain.RoleAdmin))
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+
+	meeting := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Weekly", StartsAt: time.Now().Add(-time.Hour), CreatedBy: ownerID}
+	tasks := &stubGroupTaskGateway{}
+	service.SetEventDirectory(&stubEventDirectory{events: []*domain.GroupEvent{meeting}})
+	service.SetGroupTaskGateway(tasks)
+
+	content := "## Decisions\n- [ ] Share the slides @alice\n- [x] Book the room @bob\n- [ ] Ask @carol about the budget\n- [ ] Share the slides @alice"
+
+	// Only members who can create tasks can write notes
+	_, err = service.CreateEventNote(ctx, group.ID, meeting.ID, memberID, EventNoteInput{Title: "Weekly notes", Content: content})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.CreateEventNote(ctx, group.ID, meeting.ID, adminID, EventNoteInput{Title: "  ", Content: content})
+	assert.ErrorIs(t, err, ErrInvalidNote)
+	_, err = service.CreateEventNote(ctx, group.ID, uuid.NewString(), adminID, EventNoteInput{Title: "Weekly notes"})
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	// Unchecked items become tasks assigned to the first mentioned member
+	note, err := service.CreateEventNote(ctx, group.ID, meeting.ID, adminID, EventNoteInput{Title: "Weekly notes", Content: content})
+	require.NoError(t, err)
+	require.Len(t, note.ActionItems, 2)
+	require.Len(t, tasks.created, 2)
+	assert.Equal(t, "Share the slides @alice", tasks.created[0].Title)
+	assert.Equal(t, adminID, *tasks.created[0].AssigneeID)
+	assert.Equal(t, adminID, tasks.created[0].CreatedBy)
+	assert.Contains(t, tasks.created[0].Description, note.ID.String())
+	assert.Nil(t, tasks.created[1].AssigneeID)
+	assert.Equal(t, adminID, *note.ActionItems[0].AssigneeID)
+
+	// Generated tasks link back to the note
+	source, err := service.GetTaskSourceNote(ctx, group.ID, note.ActionItems[1].TaskID, memberID)
+	require.NoError(t, err)
+	assert.Equal(t, note.ID, source.ID)
+	assert.Len(t, source.ActionItems, 2)
+	_, err = service.GetTaskSourceNote(ctx, group.ID, uuid.NewString(), memberID)
+	assert.ErrorIs(t, err, ErrNoteNotFound)
+	_, err = service.GetTaskSourceNote(ctx, group.ID, note.ActionItems[1].TaskID, outsiderID)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+
+	// Updating creates tasks only for new items; failed items are retried on the next update
+	_, err = service.UpdateEventNote(ctx, group.ID, note.ID, memberID, EventNoteInput{Title: "Weekly notes", Content: content})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	tasks.fail = true
+	updated, err := service.UpdateEventNote(ctx, group.ID, note.ID, adminID, EventNoteInput{Title: "Weekly notes", Content: content + "\n- [ ] Send the summary @BOB"})
+	require.NoError(t, err)
+	assert.Len(t, updated.ActionItems, 2)
+	tasks.fail = false
+	updated, err = service.UpdateEventNote(ctx, group.ID, note.ID, ownerID, EventNoteInput{Title: "Weekly notes (final)", Content: content + "\n- [ ] Send the summary @BOB"})
+	require.NoError(t, err)
+	require.Len(t, updated.ActionItems, 3)
+	require.Len(t, tasks.created, 3)
+	assert.Equal(t, memberID, *tasks.created[2].AssigneeID)
+	assert.Equal(t, ownerID, tasks.created[2].CreatedBy)
+	assert.Equal(t, ownerID, updated.UpdatedBy)
+
+	notes, err := service.ListEventNotes(ctx, group.ID, meeting.ID, memberID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "Weekly notes (final)", notes[0].Title)
+
+	// Creators changed to guests can no longer edit their notes
+	require.NoError(t, service.UpdateMemberRole(ctx, group.ID, adminID, ownerID, domain.RoleGuest))
+	_, err = service.UpdateEventNote(ctx, group.ID, note.ID, adminID, EventNoteInput{Title: "Weekly notes"})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	require.NoError(t, service.UpdateMemberRole(ctx, group.ID, adminID, ownerID, domain.RoleAdmin))
+
+	// Deleting the note keeps the tasks but removes the link
+	assert.ErrorIs(t, service.DeleteEventNote(ctx, group.ID, note.ID, memberID), ErrInsufficientPermissions)
+	require.NoError(t, service.DeleteEventNote(ctx, group.ID, note.ID, adminID))
+	_, err = service.GetEventNote(ctx, group.ID, note.ID, memberID)
+	assert.ErrorIs(t, err, ErrNoteNotFound)
+	_, err = service.GetTaskSourceNote(ctx, group.ID, updated.ActionItems[0].TaskID, memberID)
+	assert.ErrorIs(t, err, ErrNoteNotFound)
+}
+
+func TestGroupService_EventTaskLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	ownerID, adminID, memberID, outsiderID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Team", Type: domain.GroupTypeSchedule, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, adminID, ownerID, domain.RoleAdmin))
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+	project, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	require.NoError(t, err)
+
+	meeting := &domain.GroupEvent{ID: uuid.NewString(), GroupID: group.ID, Title: "Weekly", StartsAt: time.Now().Add(-time.Hour), CreatedBy: ownerID}
+	events := &stubEventDirectory{events: []*domain.GroupEvent{meeting}}
+	tasks := &stubGroupTaskGateway{events: events}
+	estimate := 90
+	report := &domain.LinkedTask{ID: uuid.NewString(), Title: "Write the report", Status: "TODO", EstimateMinutes: &estimate}
+	unestimated := &domain.LinkedTask{ID: uuid.NewString(), Title: "Review", Status: "TODO"}
+	tasks.addTask(group.ID, report)
+	tasks.addTask(group.ID, unestimated)
+	service.SetEventDirectory(events)
+	service.SetGroupTaskGateway(tasks)
+
+	startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Minute)
+
+	// Scheduling a block requires task creation permission, a group task and a duration
+	_, err = service.ScheduleTaskBlock(ctx, group.ID, report.ID, memberID, TaskBlockInput{StartsAt: startsAt})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.ScheduleTaskBlock(ctx, group.ID, uuid.NewString(), adminID, TaskBlockInput{StartsAt: startsAt})
+	assert.ErrorIs(t, err, ErrLinkedTaskNotFound)
+	_, err = service.ScheduleTaskBlock(ctx, group.ID, unestimated.ID, adminID, TaskBlockInput{StartsAt: startsAt})
+	assert.ErrorIs(t, err, ErrInvalidEventLink)
+	tooLong := domain.MaxBlockMinutes + 1
+	_, err = service.ScheduleTaskBlock(ctx, group.ID, unestimated.ID, adminID, TaskBlockInput{StartsAt: startsAt, DurationMinutes: &tooLong})
+	assert.ErrorIs(t, err, ErrInvalidEventLink)
+	_, err = service.ScheduleTaskBlock(ctx, project.ID, report.ID, ownerID, TaskBlockInput{StartsAt: startsAt})
+	assert.ErrorIs(t, err, ErrInvalidEventLink)
+
+	// The block defaults to the task's estimate and is assigned to the requester
+	block, err := service.ScheduleTaskBlock(ctx, group.ID, report.ID, adminID, TaskBlockInput{StartsAt: startsAt})
+	require.NoError(t, err)
+	require.Len(t, tasks.created, 1)
+	assert.Equal(t, startsAt, block.Event.StartsAt)
+	assert.Equal(t, startsAt.Add(90*time.Minute), *tasks.created[0].DueDate)
+	assert.Equal(t, adminID, *tasks.created[0].AssigneeID)
+	assert.Equal(t, report.Title, tasks.created[0].Title)
+	require.Len(t, block.Links, 1)
+	assert.Equal(t, domain.EventTaskLinkBlock, block.Links[0].Kind)
+	assert.Equal(t, report.ID, block.Links[0].Task.ID)
+
+	thirty := 30
+	_, err = service.ScheduleTaskBlock(ctx, group.ID, report.ID, adminID, TaskBlockInput{StartsAt: startsAt.Add(48 * time.Hour), DurationMinutes: &thirty})
+	require.NoError(t, err)
+	assert.Equal(t, 30, *tasks.created[1].EstimateMinutes)
+
+	// Follow-up tasks default to the event title and the requester
+	_, err = service.CreateFollowUpTask(ctx, group.ID, meeting.ID, memberID, FollowUpTaskInput{})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.CreateFollowUpTask(ctx, group.ID, meeting.ID, adminID, FollowUpTaskInput{AssigneeID: &outsiderID})
+	assert.ErrorIs(t, err, ErrInvalidEventLink)
+	_, err = service.CreateFollowUpTask(ctx, group.ID, uuid.NewString(), adminID, FollowUpTaskInput{})
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	followUp, err := service.CreateFollowUpTask(ctx, group.ID, meeting.ID, adminID, FollowUpTaskInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "「Weekly」のフォローアップ", followUp.Task.Title)
+	assert.Equal(t, adminID, *tasks.created[2].AssigneeID)
+	assert.Contains(t, tasks.created[2].Description, meeting.ID)
+	assigned, err := service.CreateFollowUpTask(ctx, group.ID, meeting.ID, ownerID, FollowUpTaskInput{Title: "Send minutes", Description: "Share with everyone", AssigneeID: &memberID})
+	require.NoError(t, err)
+	assert.Equal(t, "Send minutes", assigned.Task.Title)
+	assert.Equal(t, memberID, *tasks.created[3].AssigneeID)
+	assert.Contains(t, tasks.created[3].Description, "Share with everyone")
+
+	// The event detail shows its follow-ups; links to deleted tasks are hidden
+	detail, err := service.GetEventDetail(ctx, group.ID, meeting.ID, memberID)
+	require.NoError(t, err)
+	require.Len(t, detail.Links, 2)
+	assert.Equal(t, followUp.TaskID, detail.Links[0].TaskID)
+	delete(tasks.tasks, followUp.TaskID)
+	detail, err = service.GetEventDetail(ctx, group.ID, meeting.ID, memberID)
+	require.NoError(t, err)
+	assert.Len(t, detail.Links, 1)
+	_, err = service.GetEventDetail(ctx, group.ID, meeting.ID, outsiderID)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+
+	// The task detail shows links from both sides
+	links, err := service.ListTaskEventLinks(ctx, report.ID)
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+	links, err = service.ListTaskEventLinks(ctx, assigned.TaskID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, meeting.ID, links[0].EventID)
+	links, err = service.ListTaskEventLinks(ctx, meeting.ID)
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+}
+
+type stubChatPoster struct {
+	messages []*domain.ChatMessage
+	err      error
+}
+
+func (p *stubChatPoster) PostMessage(ctx context.Context, webhookURL string, message *domain.ChatMessage) error {
+	if p.err != nil {
+		return p.err
 	}
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+type stubChatTaskSource []*domain.ChatTask
+
+func (s stubChatTaskSource) ListGroupChatTasks(ctx context.Context, groupID uuid.UUID) ([]*domain.ChatTask, error) {
+	return s, nil
+}
+
+func TestGroupService_ChatIntegrations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID, memberID := uuid.New(), uuid.New()
+	mockValidator.EXPECT().GetUsersInfoBatch(gomock.Any(), gomock.Any()).Return(map[string]*commonDomain.UserInfo{
+		memberID.String(): {ID: memberID.String(), Username: "member"},
+	}, nil).AnyTimes()
+
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	require.NoError(t, err)
+	require.NoError(t, service.AddMember(ctx, group.ID, memberID, ownerID, domain.RoleMember))
+
+	webhookURL := "https://discord.com/api/webhooks/123/secret"
+
+	// Only editors can configure integrations and a new one needs a webhook URL
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, memberID, ChatIntegrationInput{WebhookURL: &webhookURL})
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID, ChatIntegrationInput{})
+	assert.ErrorIs(t, err, ErrInvalidChatIntegration)
+	badURL := "https://example.com/hook"
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID, ChatIntegrationInput{WebhookURL: &badURL})
+	assert.ErrorIs(t, err, ErrInvalidChatIntegration)
+
+	timezone := "Asia/Tokyo"
+	integration, err := service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID, ChatIntegrationInput{WebhookURL: &webhookURL, Timezone: &timezone})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ChatEvents, integration.Events)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/****", integration.MaskedWebhookURL())
+
+	// Partial updates keep the webhook URL
+	hour := 18
+	integration, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID, ChatIntegrationInput{SummaryHour: &hour})
+	require.NoError(t, err)
+	assert.Equal(t, webhookURL, integration.WebhookURL)
+	assert.Equal(t, 18, integration.SummaryHour)
+
+	_, err = service.ListChatIntegrations(ctx, group.ID, memberID)
+	assert.ErrorIs(t, err, ErrInsufficientPermissions)
+	integrations, err := service.ListChatIntegrations(ctx, group.ID, ownerID)
+	require.NoError(t, err)
+	assert.Len(t, integrations, 1)
+
+	// Without a poster for the platform nothing can be delivered
+	assert.ErrorIs(t, service.TestChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID), ErrUnsupportedChatPlatform)
+
+	poster := &stubChatPoster{}
+	service.SetChatPoster(domain.ChatPlatformDiscord, poster)
+	require.NoError(t, service.TestChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID))
+	require.Len(t, poster.messages, 1)
+	assert.Contains(t, poster.messages[0].Text, "Project")
+
+	// Task events are rendered with assignee names
+	due := time.Now().Add(time.Hour)
+	task := &domain.ChatTask{ID: "task-1", Title: "資料作成", DueDate: &due, AssigneeIDs: []uuid.UUID{memberID}}
+	require.NoError(t, service.NotifyChatTaskAssigned(ctx, group.ID, task, []uuid.UUID{memberID}))
+	require.Len(t, poster.messages, 2)
+	assert.Equal(t, domain.ChatEventTaskAssigned, poster.messages[1].Event)
+	assert.Contains(t, poster.messages[1].Text, "member")
+
+	// Unsubscribed events are not posted and delivery failures are not returned
+	events := []domain.ChatEvent{domain.ChatEventTaskAssigned, domain.ChatEventDailySummary}
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID, ChatIntegrationInput{Events: events})
+	require.NoError(t, err)
+	require.NoError(t, service.NotifyChatTaskCompleted(ctx, group.ID, task))
+	assert.Len(t, poster.messages, 2)
+	poster.err = errors.New("discord is down")
+	require.NoError(t, service.NotifyChatTaskAssigned(ctx, group.ID, task, nil))
+	poster.err = nil
+
+	// The daily summary is posted once a day after the summary hour
+	completedAt := time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)
+	service.SetChatTaskSource(stubChatTaskSource{
+		{ID: "task-1", Title: "資料作成", Done: true, CompletedAt: &completedAt},
+	})
+	sent, err := service.SendChatDailySummaries(ctx, time.Date(2024, 1, 10, 8, 59, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	sent, err = service.SendChatDailySummaries(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, domain.ChatEventDailySummary, poster.messages[len(poster.messages)-1].Event)
+	sent, err = service.SendChatDailySummaries(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// Disabled integrations are not posted to
+	disabled := false
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID, ChatIntegrationInput{Enabled: &disabled})
+	require.NoError(t, err)
+	count := len(poster.messages)
+	require.NoError(t, service.NotifyChatTaskAssigned(ctx, group.ID, task, nil))
+	assert.Len(t, poster.messages, count)
+
+	require.NoError(t, service.DeleteChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID))
+	assert.ErrorIs(t, service.DeleteChatIntegration(ctx, group.ID, domain.ChatPlatformDiscord, ownerID), ErrChatIntegrationNotFound)
+}
+
+func TestGroupService_ChatDueReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	repo := groupMemory.NewGroupRepository()
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	mockValidator.EXPECT().UserExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewGroupService(repo, mockValidator, &mockLogger)
+
+	ownerID := uuid.New()
+	mockValidator.EXPECT().GetUsersInfoBatch(gomock.Any(), gomock.Any()).Return(map[string]*commonDomain.UserInfo{
+		ownerID.String(): {ID: ownerID.String(), Username: "owner"},
+	}, nil).AnyTimes()
+
+	group, err := service.CreateGroup(ctx, CreateGroupInput{Name: "Project", Type: domain.GroupTypeProject, OwnerID: ownerID})
+	require.NoError(t, err)
+
+	webhookURL := "https://contoso.webhook.office.com/webhookb2/abc/IncomingWebhook/123/456"
+	hours := 2
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformTeams, ownerID, ChatIntegrationInput{WebhookURL: &webhookURL, DueReminderHours: &hours})
+	require.NoError(t, err)
+	invalidHours := 0
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformTeams, ownerID, ChatIntegrationInput{DueReminderHours: &invalidHours})
+	assert.ErrorIs(t, err, ErrInvalidChatIntegration)
+
+	poster := &stubChatPoster{}
+	service.SetChatPoster(domain.ChatPlatformTeams, poster)
+
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	service.SetChatTaskSource(stubChatTaskSource{
+		{ID: "task-1", Title: "資料作成", DueDate: at(time.Hour), AssigneeIDs: []uuid.UUID{ownerID}},
+		{ID: "task-2", Title: "見積もり", DueDate: at(2*time.Hour + 10*time.Minute)},
+		{ID: "task-3", Title: "完了済み", Done: true, DueDate: at(time.Hour)},
+	})
+
+	// Tasks due within the reminder window are posted once with a card title and facts
+	sent, err := service.SendChatDueReminders(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, poster.messages, 1)
+	assert.Equal(t, domain.ChatEventTaskDueSoon, poster.messages[0].Event)
+	assert.Equal(t, "期限が近いタスク", poster.messages[0].Title)
+	assert.Contains(t, poster.messages[0].Text, "資料作成")
+	assert.Contains(t, poster.messages[0].Facts, domain.ChatFact{Name: "担当", Value: "owner"})
+
+	sent, err = service.SendChatDueReminders(ctx, now.Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// The window moves forward on the next run
+	sent, err = service.SendChatDueReminders(ctx, now.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Contains(t, poster.messages[1].Text, "見積もり")
+
+	// Integrations that do not subscribe to due reminders are skipped
+	events := []domain.ChatEvent{domain.ChatEventTaskCompleted}
+	_, err = service.SaveChatIntegration(ctx, group.ID, domain.ChatPlatformTeams, ownerID, ChatIntegrationInput{Events: events})
+	require.NoError(t, err)
+	sent, err = service.SendChatDueReminders(ctx, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
 }
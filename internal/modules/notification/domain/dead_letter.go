@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDeadLetterNotFound は配信不能の記録が存在しないことを表す
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterRedriven は既に再送済みの記録を再送しようとしたことを表す
+	ErrDeadLetterRedriven = errors.New("dead letter already redriven")
+	// ErrRedriveFailed は再送しても送信できなかったことを表す
+	ErrRedriveFailed = errors.New("redrive failed")
+)
+
+// DeadLetter は再試行しても送信できなかった通知のチャネルごとの記録
+// 管理者が失敗の理由を確認し、原因を解消した後に再送する
+type DeadLetter struct {
+	ID             string      `json:"id"`
+	NotificationID string      `json:"notification_id"`
+	UserID         string      `json:"user_id"`
+	Channel        ChannelType `json:"channel"`
+	Target         string      `json:"target"` // 送信先（アプリ内通知の場合はユーザーID、LINEの場合はLINEユーザーID）
+	Reason         string      `json:"reason"` // 最後の送信の失敗の理由
+	Attempts       int         `json:"attempts"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	RedrivenAt     *time.Time  `json:"redriven_at,omitempty"`
+}
+
+// NewDeadLetter は送信できなかったチャネルの記録を作成する
+func NewDeadLetter(notification *Notification, channel Channel, reason string, attempts int) *DeadLetter {
+	now := time.Now()
+	letter := &DeadLetter{
+		ID:             uuid.New().String(),
+		NotificationID: notification.ID,
+		UserID:         notification.UserID,
+		Channel:        channel.GetType(),
+		Target:         notification.UserID,
+		Reason:         reason,
+		Attempts:       attempts,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if line, ok := channel.(*LineChannel); ok {
+		letter.Target = line.LineUserID
+	}
+	return letter
+}
+
+// ToChannel は再送先のチャネルを返す
+func (d *DeadLetter) ToChannel() Channel {
+	if d.Channel == LineMessage {
+		return NewLineChannel(d.UserID, d.Target, "")
+	}
+	return NewAppChannel(d.Target)
+}
+
+// RecordFailure は再送に失敗した理由と試行回数を記録する
+func (d *DeadLetter) RecordFailure(reason string, attempts int) {
+	d.Reason = reason
+	d.Attempts += attempts
+	d.UpdatedAt = time.Now()
+}
+
+// MarkRedriven は再送済みにする
+func (d *DeadLetter) MarkRedriven(attempts int) {
+	now := time.Now()
+	d.Attempts += attempts
+	d.RedrivenAt = &now
+	d.UpdatedAt = now
+}
+
+// IsRedriven は再送済みかを返す
+func (d *DeadLetter) IsRedriven() bool {
+	return d.RedrivenAt != nil
+}
+
+// DeadLetterAlertPolicy は配信不能の割合が高い場合に管理者へ知らせる条件
+type DeadLetterAlertPolicy struct {
+	Threshold     float64       // 配信不能の割合（0〜1、0以下の場合は知らせない）
+	MinDeliveries int           // 割合を判定する期間内の最小の送信数（少ない送信での誤検知を防ぐ）
+	Window        time.Duration // 割合を集計する期間（期間ごとに1回まで知らせる）
+}
+
+// DeadLetterWindow は期間内の送信数と配信不能の数
+type DeadLetterWindow struct {
+	Start       time.Time
+	Deliveries  int
+	DeadLetters int
+	Alerted     bool
+}
+
+// Rate は期間内の配信不能の割合を返す
+func (w *DeadLetterWindow) Rate() float64 {
+	if w.Deliveries == 0 {
+		return 0
+	}
+	return float64(w.DeadLetters) / float64(w.Deliveries)
+}
+
+// Record はチャネルへの送信の結果を記録し、管理者に知らせる必要がある場合は true を返す
+// 期間が過ぎている場合は新しい期間として数え直す
+func (w *DeadLetterWindow) Record(now time.Time, deadLettered bool, policy DeadLetterAlertPolicy) bool {
+	if w.Start.IsZero() || (policy.Window > 0 && !now.Before(w.Start.Add(policy.Window))) {
+		*w = DeadLetterWindow{Start: now}
+	}
+
+	w.Deliveries++
+	if deadLettered {
+		w.DeadLetters++
+	}
+
+	if !deadLettered || w.Alerted || policy.Threshold <= 0 || w.Deliveries < policy.MinDeliveries {
+		return false
+	}
+	if w.Rate() < policy.Threshold {
+		return false
+	}
+	w.Alerted = true
+	return true
+}
@@ -218,3 +218,251 @@ func TestChannelTypeConstants(t *testing.T) {
 	assert.Equal(t, ChannelType("APP_INTERNAL"), AppInternal)
 	assert.Equal(t, ChannelType("LINE"), LineMessage)
 }
+
+// ===================
+// Grouping Tests
+// ===================
+
+func newGroupableNotification(actorID string) *Notification {
+	return NewNotification("user123", TaskAssigned, "担当タスクが更新されました", "タスク「Task X」が更新されました。", map[string]string{
+		"task_id":           "task123",
+		"task_title":        "Task X",
+		"notification_type": "task_updated",
+		"actor_id":          actorID,
+	})
+}
+
+func TestNotification_GroupKey(t *testing.T) {
+	notification := newGroupableNotification("actor1")
+
+	key, ok := notification.GroupKey()
+	assert.True(t, ok)
+	assert.Equal(t, "user123:task_updated:actor1:task123", key)
+
+	// 実行者が不明な通知はまとめない
+	_, ok = newGroupableNotification("").GroupKey()
+	assert.False(t, ok)
+}
+
+func TestNotification_CanMerge(t *testing.T) {
+	now := time.Now()
+	notification := newGroupableNotification("actor1")
+	notification.StartGroup("key", now.Add(-2*time.Minute))
+
+	assert.True(t, notification.CanMerge(now, 5*time.Minute, 10))
+	assert.False(t, notification.CanMerge(now, time.Minute, 10), "outside window")
+	assert.False(t, notification.CanMerge(now, 0, 10), "grouping disabled")
+
+	notification.AddMetadata(MetadataGroupCount, "10")
+	assert.False(t, notification.CanMerge(now, 5*time.Minute, 10), "group is full")
+
+	notification.AddMetadata(MetadataGroupCount, "1")
+	notification.MarkAsRead()
+	assert.False(t, notification.CanMerge(now, 5*time.Minute, 10), "already read")
+}
+
+func TestNotification_MergeWith(t *testing.T) {
+	start := time.Now().Add(-time.Minute)
+	existing := newGroupableNotification("actor1")
+	existing.StartGroup("key", start)
+	existing.MarkAsSent()
+
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		incoming := newGroupableNotification("actor1")
+		incoming.AddChannel(NewAppChannel("user123"))
+		existing.MergeWith(incoming, now)
+	}
+
+	assert.Equal(t, 3, existing.GroupCount())
+	assert.Equal(t, "「Task X」に3件の更新があります。", existing.Message)
+	assert.Equal(t, StatusPending, existing.Status)
+	assert.Nil(t, existing.SentAt)
+	assert.Len(t, existing.Channels, 1)
+	assert.Equal(t, "key", existing.Metadata[MetadataGroupKey])
+	assert.Equal(t, start.Format(time.RFC3339Nano), existing.Metadata[MetadataGroupFirst])
+	assert.Equal(t, now.Format(time.RFC3339Nano), existing.Metadata[MetadataGroupLast])
+}
+
+func TestParseGroupWindows(t *testing.T) {
+	windows, err := ParseGroupWindows("task_updated=10m, task_mentioned=0")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, windows["task_updated"])
+	assert.Equal(t, time.Duration(0), windows["task_mentioned"])
+
+	empty, err := ParseGroupWindows("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = ParseGroupWindows("task_updated")
+	assert.Error(t, err)
+	_, err = ParseGroupWindows("task_updated=soon")
+	assert.Error(t, err)
+}
+
+// ===================
+// Template Tests
+// ===================
+
+func TestDefaultTemplateRegistry_Render(t *testing.T) {
+	registry := DefaultTemplateRegistry()
+
+	rendered, err := registry.Render("task_assigned", "ja", map[string]string{
+		"task_title":  "資料作成",
+		"description": "週次レポート",
+		"priority":    "HIGH",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "新しいタスクが割り当てられました", rendered.Title)
+	assert.Equal(t, "タスク「資料作成」があなたに割り当てられました。\n\n説明: 週次レポート\n優先度: HIGH", rendered.Message)
+
+	// 未登録の言語はデフォルト言語にフォールバックする
+	rendered, err = registry.Render("task_mentioned", "fr", map[string]string{
+		"task_title":  "資料作成",
+		"source_type": "COMMENT",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ja", rendered.Locale)
+	assert.Equal(t, "💬 コメントでメンションされました", rendered.Title)
+
+	_, err = registry.Render("unknown_event", "ja", nil)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+}
+
+func TestTemplateRegistry_Register(t *testing.T) {
+	registry, err := NewTemplateRegistry()
+	require.NoError(t, err)
+
+	err = registry.Register(Template{EventType: "custom", Locale: "ja", Title: "{{.title", Body: "body"})
+	assert.Error(t, err)
+
+	err = registry.Register(Template{EventType: "custom", Locale: "ja", Title: "お知らせ", Body: "{{.name}}さんへ"})
+	require.NoError(t, err)
+	assert.True(t, registry.Has("custom"))
+	assert.Len(t, registry.List(), 1)
+}
+
+func TestNotification_ApplyTemplate(t *testing.T) {
+	registry := DefaultTemplateRegistry()
+
+	notification := NewNotification("user123", TaskAssigned, "", "", map[string]string{
+		"notification_type": "task_updated",
+		"task_title":        "Task X",
+		"locale":            "en",
+	})
+	applied, err := notification.ApplyTemplate(registry)
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, "Your task has been updated", notification.Title)
+	assert.Equal(t, "The task \"Task X\" assigned to you has been updated.", notification.Message)
+
+	// イベント種別のないメタデータは文面を変更しない
+	plain := NewNotification("user123", TaskAssigned, "Title", "Message", map[string]string{"task_id": "task123"})
+	applied, err = plain.ApplyTemplate(registry)
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.Equal(t, "Title", plain.Title)
+}
+
+func TestNewDeadLetter(t *testing.T) {
+	notification := NewNotification("user123", TaskAssigned, "title", "message", nil)
+
+	app := NewDeadLetter(notification, NewAppChannel("user123"), "timeout", 3)
+	assert.NotEmpty(t, app.ID)
+	assert.Equal(t, notification.ID, app.NotificationID)
+	assert.Equal(t, AppInternal, app.Channel)
+	assert.Equal(t, "user123", app.Target)
+	assert.Equal(t, 3, app.Attempts)
+	assert.False(t, app.IsRedriven())
+
+	line := NewDeadLetter(notification, NewLineChannel("user123", "line_user_456", "token"), "bad gateway", 1)
+	assert.Equal(t, LineMessage, line.Channel)
+	assert.Equal(t, "line_user_456", line.Target)
+
+	channel, ok := line.ToChannel().(*LineChannel)
+	require.True(t, ok)
+	assert.Equal(t, "user123", channel.UserID)
+	assert.Equal(t, "line_user_456", channel.LineUserID)
+
+	appChannel, ok := app.ToChannel().(*AppChannel)
+	require.True(t, ok)
+	assert.Equal(t, "user123", appChannel.UserID)
+}
+
+func TestDeadLetter_RecordFailureAndMarkRedriven(t *testing.T) {
+	notification := NewNotification("user123", TaskAssigned, "title", "message", nil)
+	letter := NewDeadLetter(notification, NewAppChannel("user123"), "timeout", 3)
+
+	letter.RecordFailure("connection refused", 2)
+	assert.Equal(t, "connection refused", letter.Reason)
+	assert.Equal(t, 5, letter.Attempts)
+	assert.False(t, letter.IsRedriven())
+
+	letter.MarkRedriven(1)
+	assert.Equal(t, 6, letter.Attempts)
+	assert.True(t, letter.IsRedriven())
+	assert.Equal(t, letter.UpdatedAt, *letter.RedrivenAt)
+}
+
+func TestDeadLetterWindow_Record(t *testing.T) {
+	policy := DeadLetterAlertPolicy{Threshold: 0.5, MinDeliveries: 4, Window: time.Minute}
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("alerts once when rate crosses threshold", func(t *testing.T) {
+		var window DeadLetterWindow
+		assert.False(t, window.Record(start, true, policy))
+		assert.False(t, window.Record(start, false, policy))
+		assert.False(t, window.Record(start, false, policy))
+		// 4件中2件で50%に達する
+		assert.True(t, window.Record(start, true, policy))
+		assert.Equal(t, 0.5, window.Rate())
+		// 同じ期間内では再度知らせない
+		assert.False(t, window.Record(start, true, policy))
+	})
+
+	t.Run("does not alert below minimum deliveries", func(t *testing.T) {
+		var window DeadLetterWindow
+		assert.False(t, window.Record(start, true, policy))
+		assert.False(t, window.Record(start, true, policy))
+		assert.False(t, window.Record(start, true, policy))
+		assert.Equal(t, 1.0, window.Rate())
+	})
+
+	t.Run("does not alert on successful delivery", func(t *testing.T) {
+		window := DeadLetterWindow{Start: start, Deliveries: 3, DeadLetters: 3}
+		assert.False(t, window.Record(start, false, policy))
+	})
+
+	t.Run("starts new window after expiry", func(t *testing.T) {
+		window := DeadLetterWindow{Start: start, Deliveries: 10, DeadLetters: 10, Alerted: true}
+		next := start.Add(time.Minute)
+		assert.False(t, window.Record(next, true, policy))
+		assert.Equal(t, next, window.Start)
+		assert.Equal(t, 1, window.Deliveries)
+		assert.False(t, window.Alerted)
+	})
+
+	t.Run("disabled threshold never alerts", func(t *testing.T) {
+		var window DeadLetterWindow
+		disabled := DeadLetterAlertPolicy{Window: time.Minute}
+		for i := 0; i < 5; i++ {
+			assert.False(t, window.Record(start, true, disabled))
+		}
+	})
+}
+
+func TestPreferences_Validate(t *testing.T) {
+	preferences := DefaultPreferences("user123")
+	assert.Equal(t, DigestDaily, preferences.SocialDigest)
+	assert.NoError(t, preferences.Validate())
+
+	preferences.SocialDigest = "HOURLY"
+	assert.ErrorIs(t, preferences.Validate(), ErrInvalidPreferences)
+}
+
+func TestDigestFrequency_Interval(t *testing.T) {
+	assert.Equal(t, time.Duration(0), DigestOff.Interval())
+	assert.Equal(t, 24*time.Hour, DigestDaily.Interval())
+	assert.Equal(t, 7*24*time.Hour, DigestWeekly.Interval())
+	assert.Equal(t, time.Duration(0), DigestFrequency("HOURLY").Interval())
+}
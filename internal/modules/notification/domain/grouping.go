@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// グループ化で使用するメタデータのキー
+const (
+	MetadataEventType  = "notification_type" // イベント種別（task_updated など）
+	MetadataActorID    = "actor_id"          // 通知の原因となったユーザー
+	MetadataEntityID   = "entity_id"         // 対象エンティティ（未指定の場合は task_id）
+	MetadataGroupKey   = "group_key"
+	MetadataGroupCount = "group_count"
+	MetadataGroupFirst = "group_first_at"
+	MetadataGroupLast  = "group_last_at"
+)
+
+// デフォルトの1グループあたりの最大件数
+const defaultMaxGroupSize = 50
+
+// GroupingPolicy はイベント種別ごとの通知まとめ設定を表す
+type GroupingPolicy struct {
+	// イベント種別ごとのスライディングウィンドウ（0または未設定はまとめない）
+	Windows map[string]time.Duration
+	// 1つの通知にまとめる最大件数
+	MaxGroupSize int
+}
+
+// DefaultGroupingPolicy はデフォルトの通知まとめ設定を返す
+func DefaultGroupingPolicy() GroupingPolicy {
+	return GroupingPolicy{
+		Windows: map[string]time.Duration{
+			"task_updated":   5 * time.Minute,
+			"task_mentioned": 5 * time.Minute,
+		},
+		MaxGroupSize: defaultMaxGroupSize,
+	}
+}
+
+// WindowFor はイベント種別のウィンドウを返す
+func (p GroupingPolicy) WindowFor(eventType string) time.Duration {
+	return p.Windows[eventType]
+}
+
+// ParseGroupWindows は "task_updated=5m,task_mentioned=30s" 形式の設定を解析する
+func ParseGroupWindows(spec string) (map[string]time.Duration, error) {
+	windows := make(map[string]time.Duration)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		eventType, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(eventType) == "" {
+			return nil, fmt.Errorf("invalid group window entry: %q", entry)
+		}
+		window, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || window < 0 {
+			return nil, fmt.Errorf("invalid group window for %s: %q", eventType, value)
+		}
+		windows[strings.TrimSpace(eventType)] = window
+	}
+	return windows, nil
+}
+
+// EventType は通知のイベント種別を返す（メタデータがない場合は通知タイプ）
+func (n *Notification) EventType() string {
+	if eventType := n.Metadata[MetadataEventType]; eventType != "" {
+		return eventType
+	}
+	return strings.ToLower(string(n.Type))
+}
+
+// GroupKey は受信者・イベント種別・実行者・対象エンティティからまとめ用のキーを返す
+// 実行者または対象エンティティが不明な場合はまとめない
+func (n *Notification) GroupKey() (string, bool) {
+	actorID := n.Metadata[MetadataActorID]
+	entityID := n.Metadata[MetadataEntityID]
+	if entityID == "" {
+		entityID = n.Metadata["task_id"]
+	}
+	if actorID == "" || entityID == "" {
+		return "", false
+	}
+	return strings.Join([]string{n.UserID, n.EventType(), actorID, entityID}, ":"), true
+}
+
+// GroupCount はまとめられた通知の件数を返す
+func (n *Notification) GroupCount() int {
+	if count, err := strconv.Atoi(n.Metadata[MetadataGroupCount]); err == nil && count > 0 {
+		return count
+	}
+	return 1
+}
+
+// lastGroupedAt はまとめられた通知の最終更新日時を返す
+func (n *Notification) lastGroupedAt() time.Time {
+	if last, err := time.Parse(time.RFC3339Nano, n.Metadata[MetadataGroupLast]); err == nil {
+		return last
+	}
+	return n.UpdatedAt
+}
+
+// StartGroup は通知をまとめの起点として初期化する
+func (n *Notification) StartGroup(key string, now time.Time) {
+	n.AddMetadata(MetadataGroupKey, key)
+	n.AddMetadata(MetadataGroupCount, "1")
+	n.AddMetadata(MetadataGroupFirst, now.Format(time.RFC3339Nano))
+	n.AddMetadata(MetadataGroupLast, now.Format(time.RFC3339Nano))
+}
+
+// CanMerge は新しい通知をこの通知にまとめられるかを判定する
+// 既読・送信失敗の通知、ウィンドウを過ぎた通知、上限に達した通知にはまとめない
+func (n *Notification) CanMerge(now time.Time, window time.Duration, maxGroupSize int) bool {
+	if window <= 0 || n.Status == StatusRead || n.Status == StatusFailed {
+		return false
+	}
+	if maxGroupSize > 0 && n.GroupCount() >= maxGroupSize {
+		return false
+	}
+	return now.Sub(n.lastGroupedAt()) <= window
+}
+
+// MergeWith は新しい通知をまとめ、件数と本文を更新して再配信待ちに戻す
+func (n *Notification) MergeWith(incoming *Notification, now time.Time) {
+	count := n.GroupCount() + 1
+
+	for key, value := range incoming.Metadata {
+		switch key {
+		case MetadataGroupKey, MetadataGroupCount, MetadataGroupFirst, MetadataGroupLast:
+			continue
+		}
+		n.AddMetadata(key, value)
+	}
+	n.AddMetadata(MetadataGroupCount, strconv.Itoa(count))
+	n.AddMetadata(MetadataGroupLast, now.Format(time.RFC3339Nano))
+	n.AddMetadata("latest_message", incoming.Message)
+
+	// チャネルは永続化されないため、新しい通知のものを引き継ぐ
+	n.Channels = incoming.Channels
+	n.Title = incoming.Title
+	n.Message = groupedMessage(n.EventType(), n.Metadata["task_title"], count)
+	n.Status = StatusPending
+	n.SentAt = nil
+	n.UpdatedAt = now
+}
+
+// groupedMessage はまとめた通知の本文を作成する（例: 「タスクX」に3件の更新があります）
+func groupedMessage(eventType, entityTitle string, count int) string {
+	label := "通知"
+	switch eventType {
+	case "task_updated":
+		label = "更新"
+	case "task_mentioned":
+		label = "メンション"
+	}
+
+	if entityTitle == "" {
+		return fmt.Sprintf("%d件の%sがあります。", count, label)
+	}
+	return fmt.Sprintf("「%s」に%d件の%sがあります。", entityTitle, count, label)
+}
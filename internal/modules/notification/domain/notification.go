@@ -14,11 +14,16 @@ const (
 	TaskAssigned     NotificationType = "TASK_ASSIGNED"      // タスク割り当て
 	TaskCompleted    NotificationType = "TASK_COMPLETED"     // タスク完了
 	TaskDueSoon      NotificationType = "TASK_DUE_SOON"      // タスク期限間近
+	TaskMentioned    NotificationType = "TASK_MENTIONED"     // タスクでのメンション
 	SystemNotice     NotificationType = "SYSTEM_NOTICE"      // システムからの通知
 	FriendRequest    NotificationType = "FRIEND_REQUEST"     //フレンドリクエストの通知
 	FriendAccepted   NotificationType = "FRIEND_ACCEPTED"    //フレンドリクエスト認証の通知
 	GroupInvitation  NotificationType = "GROUP_INVITATION"   //グループ招待の通知
 	GroupMemberAdded NotificationType = "GROUP_MEMBER_ADDED" //グループメンバー追加の通知
+	SocialDigest     NotificationType = "SOCIAL_DIGEST"      // 未対応の友達申請・グループ招待のリマインダー
+	GroupEventRSVP   NotificationType = "GROUP_EVENT_RSVP"   // 予定の出欠を回答していないメンバーへのリマインダー
+	TaskNearby       NotificationType = "TASK_NEARBY"        // 場所を設定したタスクのジオフェンスに入ったときのリマインダー
+	TaskReminders    NotificationType = "TASK_REMINDERS"     // 勤務時間外のため保留したリマインダー・エスカレーションをまとめた通知
 )
 
 // NotificationStatus は通知の状態を表す
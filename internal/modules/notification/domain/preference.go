@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPreferences は通知の設定の値が不正であることを表す
+var ErrInvalidPreferences = errors.New("invalid notification preferences")
+
+// DigestFrequency はまとめて知らせる通知（ダイジェスト）を送る頻度
+type DigestFrequency string
+
+const (
+	DigestOff    DigestFrequency = "OFF"    // 送らない
+	DigestDaily  DigestFrequency = "DAILY"  // 1日に1回まで
+	DigestWeekly DigestFrequency = "WEEKLY" // 1週間に1回まで
+)
+
+// IsValid は頻度が定義済みの値か判定する
+func (f DigestFrequency) IsValid() bool {
+	switch f {
+	case DigestOff, DigestDaily, DigestWeekly:
+		return true
+	}
+	return false
+}
+
+// Interval は次のダイジェストを送るまでの最短の間隔を返す（送らない場合は0）
+func (f DigestFrequency) Interval() time.Duration {
+	switch f {
+	case DigestDaily:
+		return 24 * time.Hour
+	case DigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Preferences はユーザーごとの通知の設定
+type Preferences struct {
+	UserID string `json:"user_id"`
+	// SocialDigest は未対応の友達申請・グループ招待のリマインダーの頻度
+	SocialDigest DigestFrequency `json:"social_digest" enums:"OFF,DAILY,WEEKLY"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+} // @name NotificationPreferences
+
+// DefaultPreferences は設定を保存していないユーザーの設定を返す（リマインダーは1日に1回まで）
+func DefaultPreferences(userID string) *Preferences {
+	return &Preferences{
+		UserID:       userID,
+		SocialDigest: DigestDaily,
+	}
+}
+
+// Validate は設定の値を検証する
+func (p *Preferences) Validate() error {
+	if !p.SocialDigest.IsValid() {
+		return fmt.Errorf("%w: invalid social_digest: %s", ErrInvalidPreferences, p.SocialDigest)
+	}
+	return nil
+}
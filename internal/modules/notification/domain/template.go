@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// テンプレート関連の定数
+const (
+	DefaultLocale  = "ja"
+	MetadataLocale = "locale" // 通知の言語（未指定の場合はDefaultLocale）
+)
+
+var ErrTemplateNotFound = errors.New("notification template not found")
+
+// Template はイベント種別ごとの通知文面テンプレートを表す
+// Title・Bodyは text/template 形式で、メタデータを {{.task_title}} のように参照できる
+type Template struct {
+	EventType string            `json:"event_type"`
+	Locale    string            `json:"locale"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Sample    map[string]string `json:"sample,omitempty"` // プレビュー用のサンプル変数
+}
+
+// RenderedTemplate はテンプレートの描画結果を表す
+type RenderedTemplate struct {
+	EventType string `json:"event_type"`
+	Locale    string `json:"locale"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+}
+
+// Render は変数を埋め込んだタイトルと本文を返す（未設定の変数は空文字になる）
+func (t Template) Render(vars map[string]string) (*RenderedTemplate, error) {
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	title, err := renderText(t.EventType+".title", t.Title, vars)
+	if err != nil {
+		return nil, err
+	}
+	body, err := renderText(t.EventType+".body", t.Body, vars)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" || body == "" {
+		return nil, fmt.Errorf("template %s/%s rendered empty text", t.EventType, t.Locale)
+	}
+
+	return &RenderedTemplate{
+		EventType: t.EventType,
+		Locale:    t.Locale,
+		Title:     title,
+		Message:   body,
+	}, nil
+}
+
+// Validate はテンプレートの構文を検証する
+func (t Template) Validate() error {
+	if t.EventType == "" || t.Locale == "" {
+		return errors.New("event type and locale are required")
+	}
+	if _, err := t.Render(t.Sample); err != nil {
+		return err
+	}
+	return nil
+}
+
+func renderText(name, text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// TemplateRegistry はイベント種別・言語ごとのテンプレートを管理する
+type TemplateRegistry struct {
+	templates map[string]map[string]Template // eventType → locale → template
+}
+
+// NewTemplateRegistry は指定したテンプレートでレジストリを作成する
+func NewTemplateRegistry(templates ...Template) (*TemplateRegistry, error) {
+	r := &TemplateRegistry{templates: make(map[string]map[string]Template)}
+	for _, t := range templates {
+		if err := r.Register(t); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// DefaultTemplateRegistry は組み込みテンプレートを登録したレジストリを返す
+func DefaultTemplateRegistry() *TemplateRegistry {
+	r, err := NewTemplateRegistry(defaultTemplates...)
+	if err != nil {
+		// 組み込みテンプレートの不備はプログラムの誤り
+		panic(err)
+	}
+	return r
+}
+
+// Register はテンプレートを検証して登録する（同じイベント種別・言語は上書き）
+func (r *TemplateRegistry) Register(t Template) error {
+	if err := t.Validate(); err != nil {
+		return fmt.Errorf("invalid template %s/%s: %w", t.EventType, t.Locale, err)
+	}
+	if r.templates[t.EventType] == nil {
+		r.templates[t.EventType] = make(map[string]Template)
+	}
+	r.templates[t.EventType][t.Locale] = t
+	return nil
+}
+
+// Lookup はイベント種別と言語に対応するテンプレートを返す
+// 指定言語がない場合はDefaultLocaleのテンプレートを返す
+func (r *TemplateRegistry) Lookup(eventType, locale string) (Template, bool) {
+	locales, ok := r.templates[eventType]
+	if !ok {
+		return Template{}, false
+	}
+	if t, ok := locales[locale]; ok {
+		return t, true
+	}
+	t, ok := locales[DefaultLocale]
+	return t, ok
+}
+
+// Has はイベント種別のテンプレートが登録されているかを判定する
+func (r *TemplateRegistry) Has(eventType string) bool {
+	_, ok := r.Lookup(eventType, DefaultLocale)
+	return ok
+}
+
+// List は登録済みテンプレートをイベント種別・言語順に返す
+func (r *TemplateRegistry) List() []Template {
+	list := make([]Template, 0)
+	for _, locales := range r.templates {
+		for _, t := range locales {
+			list = append(list, t)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].EventType != list[j].EventType {
+			return list[i].EventType < list[j].EventType
+		}
+		return list[i].Locale < list[j].Locale
+	})
+	return list
+}
+
+// Render はイベント種別・言語のテンプレートで通知文面を作成する
+func (r *TemplateRegistry) Render(eventType, locale string, vars map[string]string) (*RenderedTemplate, error) {
+	t, ok := r.Lookup(eventType, locale)
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return t.Render(vars)
+}
+
+// ApplyTemplate はメタデータのイベント種別（notification_type）のテンプレートで通知のタイトル・本文を設定する
+// テンプレートがない場合は何もせず false を返す
+func (n *Notification) ApplyTemplate(registry *TemplateRegistry) (bool, error) {
+	if registry == nil {
+		return false, nil
+	}
+	locale := n.Metadata[MetadataLocale]
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	rendered, err := registry.Render(n.Metadata[MetadataEventType], locale, n.Metadata)
+	if errors.Is(err, ErrTemplateNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	n.Title = rendered.Title
+	n.Message = rendered.Message
+	return true, nil
+}
+
+// defaultTemplates は組み込みの通知テンプレート
+var defaultTemplates = []Template{
+	{
+		EventType: "task_assigned",
+		Locale:    "ja",
+		Title:     "新しいタスクが割り当てられました",
+		Body: "タスク「{{.task_title}}」があなたに割り当てられました。\n\n" +
+			"説明: {{.description}}\n優先度: {{.priority}}{{if .due_date_display}}\n期限: {{.due_date_display}}{{end}}",
+		Sample: map[string]string{"task_title": "資料作成", "description": "週次レポート", "priority": "HIGH", "due_date_display": "2024-01-01 18:00"},
+	},
+	{
+		EventType: "task_assigned",
+		Locale:    "en",
+		Title:     "A new task has been assigned to you",
+		Body: "The task \"{{.task_title}}\" has been assigned to you.\n\n" +
+			"Description: {{.description}}\nPriority: {{.priority}}{{if .due_date_display}}\nDue: {{.due_date_display}}{{end}}",
+		Sample: map[string]string{"task_title": "Write report", "description": "Weekly report", "priority": "HIGH", "due_date_display": "2024-01-01 18:00"},
+	},
+	{
+		EventType: "task_completed",
+		Locale:    "ja",
+		Title:     "タスクが完了されました",
+		Body:      "タスク「{{.task_title}}」が完了されました。\n\n担当者: {{.assignee_names}}",
+		Sample:    map[string]string{"task_title": "資料作成", "assignee_names": "alice, bob"},
+	},
+	{
+		EventType: "task_completed",
+		Locale:    "en",
+		Title:     "A task has been completed",
+		Body:      "The task \"{{.task_title}}\" has been completed.\n\nAssignees: {{.assignee_names}}",
+		Sample:    map[string]string{"task_title": "Write report", "assignee_names": "alice, bob"},
+	},
+	{
+		EventType: "task_updated",
+		Locale:    "ja",
+		Title:     "担当タスクが更新されました",
+		Body:      "あなたが担当するタスク「{{.task_title}}」が更新されました。",
+		Sample:    map[string]string{"task_title": "資料作成"},
+	},
+	{
+		EventType: "task_updated",
+		Locale:    "en",
+		Title:     "Your task has been updated",
+		Body:      "The task \"{{.task_title}}\" assigned to you has been updated.",
+		Sample:    map[string]string{"task_title": "Write report"},
+	},
+	{
+		EventType: "task_overdue",
+		Locale:    "ja",
+		Title:     "⚠️ タスクが期限切れです",
+		Body:      "タスク「{{.task_title}}」の期限が過ぎています。\n\n期限: {{.due_date_display}}\n優先度: {{.priority}}",
+		Sample:    map[string]string{"task_title": "資料作成", "due_date_display": "2024-01-01 18:00", "priority": "HIGH"},
+	},
+	{
+		EventType: "task_overdue",
+		Locale:    "en",
+		Title:     "⚠️ A task is overdue",
+		Body:      "The task \"{{.task_title}}\" is past its due date.\n\nDue: {{.due_date_display}}\nPriority: {{.priority}}",
+		Sample:    map[string]string{"task_title": "Write report", "due_date_display": "2024-01-01 18:00", "priority": "HIGH"},
+	},
+	{
+		EventType: "task_escalated",
+		Locale:    "ja",
+		Title:     "🚨 タスクがエスカレーションされました",
+		Body: "タスク「{{.task_title}}」が期限から{{.overdue_hours}}時間以上経過したため、ルール「{{.rule_name}}」によりエスカレーションされました。\n\n" +
+			"期限: {{.due_date_display}}\n優先度: {{.priority}}",
+		Sample: map[string]string{"task_title": "資料作成", "overdue_hours": "24", "rule_name": "期限超過", "due_date_display": "2024-01-01 18:00", "priority": "URGENT"},
+	},
+	{
+		EventType: "task_escalated",
+		Locale:    "en",
+		Title:     "🚨 A task has been escalated",
+		Body: "The task \"{{.task_title}}\" was escalated by the rule \"{{.rule_name}}\" because it is more than {{.overdue_hours}} hours overdue.\n\n" +
+			"Due: {{.due_date_display}}\nPriority: {{.priority}}",
+		Sample: map[string]string{"task_title": "Write report", "overdue_hours": "24", "rule_name": "Overdue", "due_date_display": "2024-01-01 18:00", "priority": "URGENT"},
+	},
+	{
+		EventType: "task_mentioned",
+		Locale:    "ja",
+		Title:     `💬 {{if eq .source_type "COMMENT"}}コメント{{else}}タスク{{end}}でメンションされました`,
+		Body:      "タスク「{{.task_title}}」であなたがメンションされました。{{if .excerpt}}\n\n{{.excerpt}}{{end}}",
+		Sample:    map[string]string{"task_title": "資料作成", "source_type": "COMMENT", "excerpt": "@alice 確認お願いします"},
+	},
+	{
+		EventType: "task_mentioned",
+		Locale:    "en",
+		Title:     `💬 You were mentioned in a {{if eq .source_type "COMMENT"}}comment{{else}}task{{end}}`,
+		Body:      "You were mentioned in the task \"{{.task_title}}\".{{if .excerpt}}\n\n{{.excerpt}}{{end}}",
+		Sample:    map[string]string{"task_title": "Write report", "source_type": "COMMENT", "excerpt": "@alice please review"},
+	},
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/output"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
@@ -29,17 +30,19 @@ type LineMessageDetail struct {
 type LineGateway struct {
 	config     *config.Config
 	httpClient *http.Client
+	breaker    *circuitbreaker.Breaker // LINE APIが応答しない・失敗が続く場合に送信をやめる（nilの場合は制限しない）
 	logger     logger.Logger
 }
 
 // NewLineGateway は新しいLineGatewayを作成する
-func NewLineGateway(config *config.Config, logger logger.Logger) output.LineNotificationGateway {
+func NewLineGateway(config *config.Config, breaker *circuitbreaker.Breaker, logger logger.Logger) output.LineNotificationGateway {
 	return &LineGateway{
 		config: config,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		logger: logger,
+		breaker: breaker,
+		logger:  logger,
 	}
 }
 
@@ -77,29 +80,41 @@ func (g *LineGateway) SendLineNotification(ctx context.Context, lineUserID, mess
 		return fmt.Errorf("failed to marshal LINE message: %w", err)
 	}
 
-	// リクエストの作成
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		g.logger.Error("Failed to create HTTP request", logger.Error(err))
-		return fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	// ヘッダーの設定
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+g.config.External.LineChannelToken)
-
-	// リクエストの送信
-	resp, err := g.httpClient.Do(req)
+	var status int
+	err = g.breaker.Do(ctx, func(ctx context.Context) error {
+		// リクエストの作成
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+
+		// ヘッダーの設定
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+g.config.External.LineChannelToken)
+
+		// リクエストの送信
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// サーバー側のエラーだけを失敗として数える
+		status = resp.StatusCode
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return fmt.Errorf("LINE API returned status %d", status)
+		}
+		return nil
+	})
 	if err != nil {
 		g.logger.Error("Failed to send LINE notification", logger.Error(err))
 		return fmt.Errorf("failed to send LINE notification: %w", err)
 	}
-	defer resp.Body.Close()
 
 	// レスポンスの確認
-	if resp.StatusCode != http.StatusOK {
-		g.logger.Error("LINE API returned non-OK status", logger.Any("status", resp.Status))
-		return fmt.Errorf("LINE API returned non-OK status: %s", resp.Status)
+	if status != http.StatusOK {
+		g.logger.Error("LINE API returned non-OK status", logger.Any("status", status))
+		return fmt.Errorf("LINE API returned non-OK status: %d", status)
 	}
 
 	g.logger.Info("Successfully sent LINE notification", logger.Any("lineUserID", lineUserID))
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+)
+
+// DeadLetterRepository は配信不能の記録のインメモリリポジトリ
+type DeadLetterRepository struct {
+	mu      sync.RWMutex
+	letters map[string]*domain.DeadLetter
+}
+
+// NewDeadLetterRepository は新しいDeadLetterRepositoryを作成する
+func NewDeadLetterRepository() *DeadLetterRepository {
+	return &DeadLetterRepository{
+		letters: make(map[string]*domain.DeadLetter),
+	}
+}
+
+// Save は記録を保存する（同じIDの記録は上書きする）
+func (r *DeadLetterRepository) Save(ctx context.Context, letter *domain.DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.letters[letter.ID] = cloneDeadLetter(letter)
+	return nil
+}
+
+// FindByID はIDから記録を取得する（存在しない場合は nil, nil）
+func (r *DeadLetterRepository) FindByID(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if letter, ok := r.letters[id]; ok {
+		return cloneDeadLetter(letter), nil
+	}
+	return nil, nil
+}
+
+// FindPending は再送していない記録を作成日時の新しい順に取得する
+func (r *DeadLetterRepository) FindPending(ctx context.Context, limit, offset int) ([]*domain.DeadLetter, error) {
+	r.mu.RLock()
+	letters := []*domain.DeadLetter{}
+	for _, letter := range r.letters {
+		if !letter.IsRedriven() {
+			letters = append(letters, cloneDeadLetter(letter))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(letters, func(i, j int) bool {
+		if !letters[i].CreatedAt.Equal(letters[j].CreatedAt) {
+			return letters[i].CreatedAt.After(letters[j].CreatedAt)
+		}
+		return letters[i].ID < letters[j].ID
+	})
+
+	if offset >= len(letters) {
+		return []*domain.DeadLetter{}, nil
+	}
+	letters = letters[offset:]
+	if limit >= 0 && len(letters) > limit {
+		letters = letters[:limit]
+	}
+	return letters, nil
+}
+
+// cloneDeadLetter は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+func cloneDeadLetter(letter *domain.DeadLetter) *domain.DeadLetter {
+	c := *letter
+	if letter.RedrivenAt != nil {
+		redrivenAt := *letter.RedrivenAt
+		c.RedrivenAt = &redrivenAt
+	}
+	return &c
+}
@@ -0,0 +1,169 @@
+// Package memory は通知リポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+)
+
+// NotificationRepository は通知のインメモリリポジトリ
+type NotificationRepository struct {
+	mu            sync.RWMutex
+	notifications map[string]*domain.Notification
+}
+
+// NewNotificationRepository は新しいNotificationRepositoryを作成する
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications: make(map[string]*domain.Notification),
+	}
+}
+
+// Save は通知を保存する（同じIDの通知は上書きする）
+func (r *NotificationRepository) Save(ctx context.Context, notification *domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := cloneNotification(notification)
+	if current, ok := r.notifications[notification.ID]; ok {
+		// 作成日時は更新しない（MySQL実装と同じ）
+		stored.CreatedAt = current.CreatedAt
+	}
+	r.notifications[notification.ID] = stored
+	return nil
+}
+
+// FindByID はIDから通知を取得する（存在しない場合は nil, nil）
+func (r *NotificationRepository) FindByID(ctx context.Context, id string) (*domain.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if notification, ok := r.notifications[id]; ok {
+		return cloneNotification(notification), nil
+	}
+	return nil, nil
+}
+
+// FindByUserID はユーザーの通知を作成日時の新しい順に取得する
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Notification, error) {
+	notifications := r.list(func(n *domain.Notification) bool {
+		return n.UserID == userID
+	})
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+	})
+
+	if offset >= len(notifications) {
+		return []*domain.Notification{}, nil
+	}
+	notifications = notifications[offset:]
+	if limit >= 0 && len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+// UpdateStatus は通知のステータスを更新する（送信済みにする場合は送信日時も記録する）
+func (r *NotificationRepository) UpdateStatus(ctx context.Context, id string, status domain.NotificationStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, ok := r.notifications[id]
+	if !ok {
+		return fmt.Errorf("notification not found: %s", id)
+	}
+
+	now := time.Now()
+	notification.Status = status
+	notification.UpdatedAt = now
+	if status == domain.StatusSent {
+		notification.SentAt = &now
+	}
+	return nil
+}
+
+// CountByUserIDAndStatus はユーザーIDとステータスに基づいて通知数を取得する
+func (r *NotificationRepository) CountByUserIDAndStatus(ctx context.Context, userID string, status domain.NotificationStatus) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, n := range r.notifications {
+		if n.UserID == userID && n.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindPendingNotifications は保留中の通知を作成日時の古い順に取得する
+func (r *NotificationRepository) FindPendingNotifications(ctx context.Context, limit int) ([]*domain.Notification, error) {
+	notifications := r.list(func(n *domain.Notification) bool {
+		return n.Status == domain.StatusPending
+	})
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].CreatedAt.Before(notifications[j].CreatedAt)
+	})
+	if limit >= 0 && len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+// FindLatestByGroupKey はまとめ用キーが一致するユーザーの最新の通知を取得する（存在しない場合はnil）
+func (r *NotificationRepository) FindLatestByGroupKey(ctx context.Context, userID, groupKey string) (*domain.Notification, error) {
+	notifications := r.list(func(n *domain.Notification) bool {
+		return n.UserID == userID && groupKey != "" && n.Metadata[domain.MetadataGroupKey] == groupKey
+	})
+
+	var latest *domain.Notification
+	for _, n := range notifications {
+		if latest == nil || n.UpdatedAt.After(latest.UpdatedAt) {
+			latest = n
+		}
+	}
+	return latest, nil
+}
+
+// list は条件に一致する通知のコピーを返す
+func (r *NotificationRepository) list(match func(n *domain.Notification) bool) []*domain.Notification {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notifications := []*domain.Notification{}
+	for _, n := range r.notifications {
+		if match(n) {
+			notifications = append(notifications, cloneNotification(n))
+		}
+	}
+	// mapの走査順に依存しないよう、同時刻の並び順をIDで固定する
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].ID < notifications[j].ID
+	})
+	return notifications
+}
+
+// cloneNotification は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+// 送信チャネルはMySQL実装と同様に保存しない
+func cloneNotification(n *domain.Notification) *domain.Notification {
+	c := *n
+	c.Channels = nil
+	if n.Metadata != nil {
+		c.Metadata = make(map[string]string, len(n.Metadata))
+		for k, v := range n.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	if n.SentAt != nil {
+		sentAt := *n.SentAt
+		c.SentAt = &sentAt
+	}
+	return &c
+}
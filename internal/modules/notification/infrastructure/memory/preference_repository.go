@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+)
+
+// PreferenceRepository は通知の設定のインメモリリポジトリ
+type PreferenceRepository struct {
+	mu          sync.RWMutex
+	preferences map[string]domain.Preferences
+}
+
+// NewPreferenceRepository は新しいPreferenceRepositoryを作成する
+func NewPreferenceRepository() *PreferenceRepository {
+	return &PreferenceRepository{
+		preferences: make(map[string]domain.Preferences),
+	}
+}
+
+// GetPreferences はユーザーの通知の設定を取得する（保存していない場合は nil, nil）
+func (r *PreferenceRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if preferences, ok := r.preferences[userID]; ok {
+		return &preferences, nil
+	}
+	return nil, nil
+}
+
+// SavePreferences はユーザーの通知の設定を保存する（保存済みの場合は上書きする）
+func (r *PreferenceRepository) SavePreferences(ctx context.Context, preferences *domain.Preferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferences[preferences.UserID] = *preferences
+	return nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/internal/modules/notification/interface/dto"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ListDeadLettersResponse は配信不能の記録一覧のレスポンス構造体
+type ListDeadLettersResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    []*domain.DeadLetter `json:"data"`
+} // @name ListDeadLettersResponse
+
+// DeadLetterResponse は配信不能の記録のレスポンス構造体
+type DeadLetterResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    *domain.DeadLetter `json:"data"`
+} // @name DeadLetterResponse
+
+// ListDeadLetters 配信不能の通知一覧取得
+// @Summary      配信不能の通知一覧取得
+// @Description  再試行しても送信できなかった通知（チャネルごと）を失敗の理由とともに新しい順に取得します。再送済みのものは含みません（管理者のみ）
+// @Tags         notifications
+// @Produce      json
+// @Param        limit query int false "取得数の上限" default(20) minimum(1) maximum(100)
+// @Param        offset query int false "取得開始位置" default(0) minimum(0)
+// @Security     BearerAuth
+// @Success      200 {object} ListDeadLettersResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/notifications/dead-letter [get]
+func (c *NotificationController) ListDeadLetters(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	letters, err := c.notificationUseCase.ListDeadLetters(ctx, limit, offset)
+	if err != nil {
+		c.logError("list dead letters", err)
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "list_dead_letters_failed",
+			Message: "配信不能の通知の取得に失敗しました",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ListDeadLettersResponse{
+		Success: true,
+		Data:    letters,
+	})
+}
+
+// RedriveDeadLetter 配信不能の通知の再送
+// @Summary      配信不能の通知の再送
+// @Description  配信不能の通知を記録したチャネルに再送します。再送に失敗した場合は失敗の理由を更新して一覧に残します（管理者のみ）
+// @Tags         notifications
+// @Produce      json
+// @Param        id path string true "配信不能の記録ID"
+// @Security     BearerAuth
+// @Success      200 {object} DeadLetterResponse "再送成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "記録が見つからない"
+// @Failure      409 {object} ErrorResponse "再送済み"
+// @Failure      502 {object} ErrorResponse "再送しても送信できなかった"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/notifications/dead-letter/{id}/redrive [post]
+func (c *NotificationController) RedriveDeadLetter(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	letter, err := c.notificationUseCase.RedriveDeadLetter(ctx, id)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, DeadLetterResponse{
+			Success: true,
+			Data:    letter,
+		})
+	case errors.Is(err, domain.ErrDeadLetterNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "dead_letter_not_found",
+			Message: "配信不能の通知が見つかりません",
+		})
+	case errors.Is(err, domain.ErrDeadLetterRedriven):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "dead_letter_redriven",
+			Message: "この通知は再送済みです",
+		})
+	case errors.Is(err, domain.ErrRedriveFailed):
+		c.logError("redrive dead letter", err, logger.Any("deadLetterID", id))
+		ctx.JSON(http.StatusBadGateway, dto.ErrorResponse{
+			Error:   "redrive_failed",
+			Message: "再送しましたが送信できませんでした",
+		})
+	default:
+		c.logError("redrive dead letter", err, logger.Any("deadLetterID", id))
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "redrive_dead_letter_failed",
+			Message: "配信不能の通知の再送に失敗しました",
+		})
+	}
+}
+
+// RegisterDeadLetterRoutes は配信不能の通知の管理のルートを登録する（管理者権限のグループに登録すること）
+func RegisterDeadLetterRoutes(router *gin.RouterGroup, controller *NotificationController) {
+	router.GET("", controller.ListDeadLetters)
+	router.POST("/:id/redrive", controller.RedriveDeadLetter)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/internal/modules/notification/interface/dto"
+	notification "github.com/hryt430/Yotei+/internal/modules/notification/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PreferenceController は通知の設定のコントローラー
+type PreferenceController struct {
+	preferenceService *notification.PreferenceService
+	logger            logger.Logger
+}
+
+// NewPreferenceController は新しいPreferenceControllerを作成する
+func NewPreferenceController(preferenceService *notification.PreferenceService, logger logger.Logger) *PreferenceController {
+	return &PreferenceController{
+		preferenceService: preferenceService,
+		logger:            logger,
+	}
+}
+
+// UpdatePreferencesRequest は通知の設定の変更のリクエスト構造体（省略した項目は変更しない）
+type UpdatePreferencesRequest struct {
+	SocialDigest *domain.DigestFrequency `json:"social_digest,omitempty" enums:"OFF,DAILY,WEEKLY" example:"WEEKLY"`
+} // @name UpdateNotificationPreferencesRequest
+
+// PreferencesResponse は通知の設定のレスポンス構造体
+type PreferencesResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    *domain.Preferences `json:"data"`
+} // @name NotificationPreferencesResponse
+
+// GetPreferences 通知の設定取得
+// @Summary      通知の設定取得
+// @Description  ログイン中のユーザーの通知の設定を取得します。social_digest は未対応の友達申請・グループ招待をまとめて知らせるリマインダーの頻度です（既定は DAILY）
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} PreferencesResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/notification-preferences [get]
+func (c *PreferenceController) GetPreferences(ctx *gin.Context) {
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	preferences, err := c.preferenceService.GetPreferences(ctx, user.ID.String())
+	if err != nil {
+		c.logger.Error("Failed to get notification preferences",
+			logger.Any("userID", user.ID), logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "get_preferences_failed",
+			Message: "通知の設定の取得に失敗しました",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PreferencesResponse{
+		Success: true,
+		Data:    preferences,
+	})
+}
+
+// UpdatePreferences 通知の設定変更
+// @Summary      通知の設定変更
+// @Description  ログイン中のユーザーの通知の設定を変更します。social_digest に OFF を指定すると未対応の友達申請・グループ招待のリマインダーを送りません
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdatePreferencesRequest true "変更する設定"
+// @Security     BearerAuth
+// @Success      200 {object} PreferencesResponse "変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/notification-preferences [put]
+func (c *PreferenceController) UpdatePreferences(ctx *gin.Context) {
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストの形式が正しくありません",
+		})
+		return
+	}
+
+	preferences, err := c.preferenceService.UpdatePreferences(ctx, user.ID.String(), notification.UpdatePreferencesInput{
+		SocialDigest: req.SocialDigest,
+	})
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, PreferencesResponse{
+			Success: true,
+			Data:    preferences,
+		})
+	case errors.Is(err, domain.ErrInvalidPreferences):
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_preferences",
+			Message: "social_digest には OFF・DAILY・WEEKLY のいずれかを指定してください",
+		})
+	default:
+		c.logger.Error("Failed to update notification preferences",
+			logger.Any("userID", user.ID), logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "update_preferences_failed",
+			Message: "通知の設定の変更に失敗しました",
+		})
+	}
+}
+
+// RegisterPreferenceRoutes は通知の設定のルートを登録する（認証が必要なグループに登録すること）
+func RegisterPreferenceRoutes(router *gin.RouterGroup, controller *PreferenceController) {
+	router.GET("", controller.GetPreferences)
+	router.PUT("", controller.UpdatePreferences)
+}
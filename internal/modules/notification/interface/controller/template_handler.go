@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/internal/modules/notification/interface/dto"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PreviewTemplateRequest は通知テンプレートプレビューのリクエスト構造体
+type PreviewTemplateRequest struct {
+	EventType string            `json:"event_type" binding:"required" example:"task_assigned"`
+	Locale    string            `json:"locale,omitempty" example:"ja"`
+	Variables map[string]string `json:"variables,omitempty" example:"{\"task_title\":\"資料作成\",\"priority\":\"HIGH\"}"`
+} // @name PreviewTemplateRequest
+
+// NotificationTemplateResponse は通知テンプレートのレスポンス構造体
+type NotificationTemplateResponse struct {
+	EventType string            `json:"event_type" example:"task_assigned"`
+	Locale    string            `json:"locale" example:"ja"`
+	Title     string            `json:"title" example:"新しいタスクが割り当てられました"`
+	Body      string            `json:"body" example:"タスク「{{.task_title}}」があなたに割り当てられました。"`
+	Sample    map[string]string `json:"sample,omitempty"`
+} // @name NotificationTemplateResponse
+
+// ListTemplatesResponse は通知テンプレート一覧のレスポンス構造体
+type ListTemplatesResponse struct {
+	Success bool                           `json:"success" example:"true"`
+	Data    []NotificationTemplateResponse `json:"data"`
+} // @name ListTemplatesResponse
+
+// PreviewTemplateResponse は通知テンプレートプレビューのレスポンス構造体
+type PreviewTemplateResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    domain.RenderedTemplate `json:"data"`
+} // @name PreviewTemplateResponse
+
+// ListTemplates 通知テンプレート一覧取得
+// @Summary      通知テンプレート一覧取得
+// @Description  登録済みの通知テンプレート（イベント種別・言語ごと）を取得します（管理者のみ）
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} ListTemplatesResponse "テンプレート一覧取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Router       /admin/notification-templates [get]
+func (c *NotificationController) ListTemplates(ctx *gin.Context) {
+	templates := c.notificationUseCase.ListTemplates(ctx)
+
+	responses := make([]NotificationTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		responses = append(responses, NotificationTemplateResponse{
+			EventType: t.EventType,
+			Locale:    t.Locale,
+			Title:     t.Title,
+			Body:      t.Body,
+			Sample:    t.Sample,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, ListTemplatesResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// PreviewTemplate 通知テンプレートプレビュー
+// @Summary      通知テンプレートプレビュー
+// @Description  指定した変数で通知テンプレートを描画します。変数を省略した場合はサンプル変数を使用します（管理者のみ）
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body PreviewTemplateRequest true "プレビュー内容"
+// @Security     BearerAuth
+// @Success      200 {object} PreviewTemplateResponse "プレビュー成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "テンプレートが見つからない"
+// @Router       /admin/notification-templates/preview [post]
+func (c *NotificationController) PreviewTemplate(ctx *gin.Context) {
+	var req PreviewTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.logError("bind JSON", err)
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストの形式が正しくありません",
+		})
+		return
+	}
+
+	rendered, err := c.notificationUseCase.PreviewTemplate(ctx, req.EventType, req.Locale, req.Variables)
+	if err != nil {
+		if errors.Is(err, domain.ErrTemplateNotFound) {
+			ctx.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "template_not_found",
+				Message: "通知テンプレートが見つかりません",
+			})
+			return
+		}
+		c.logError("preview template", err, logger.Any("eventType", req.EventType))
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "template_render_failed",
+			Message: "通知テンプレートの描画に失敗しました",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PreviewTemplateResponse{
+		Success: true,
+		Data:    *rendered,
+	})
+}
+
+// RegisterNotificationTemplateRoutes は通知テンプレート管理のルートを登録する（管理者権限のグループに登録すること）
+func RegisterNotificationTemplateRoutes(router *gin.RouterGroup, controller *NotificationController) {
+	router.GET("", controller.ListTemplates)
+	router.POST("/preview", controller.PreviewTemplate)
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/persistence"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DeadLetterRepository は配信不能の記録のデータベースリポジトリ実装
+type DeadLetterRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewDeadLetterRepository は新しいDeadLetterRepositoryを作成する
+func NewDeadLetterRepository(sqlHandler SqlHandler, logger logger.Logger) persistence.DeadLetterRepository {
+	return &DeadLetterRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const deadLetterColumns = `id, notification_id, user_id, channel, target, reason, attempts, created_at, updated_at, redriven_at`
+
+// Save は記録を保存する（同じIDの記録は上書きする）
+func (r *DeadLetterRepository) Save(ctx context.Context, letter *domain.DeadLetter) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.notification_dead_letters
+			(` + deadLetterColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			reason = VALUES(reason),
+			attempts = VALUES(attempts),
+			updated_at = VALUES(updated_at),
+			redriven_at = VALUES(redriven_at)
+	`
+
+	var redrivenAt interface{}
+	if letter.RedrivenAt != nil {
+		redrivenAt = *letter.RedrivenAt
+	}
+
+	_, err := r.ExecContext(ctx, query,
+		letter.ID,
+		letter.NotificationID,
+		letter.UserID,
+		letter.Channel,
+		letter.Target,
+		letter.Reason,
+		letter.Attempts,
+		letter.CreatedAt,
+		letter.UpdatedAt,
+		redrivenAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save dead letter",
+			logger.Any("id", letter.ID), logger.Error(err))
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+	return nil
+}
+
+// FindByID はIDから記録を取得する（存在しない場合は nil, nil）
+func (r *DeadLetterRepository) FindByID(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	letters, err := r.queryDeadLetters(ctx, `
+		SELECT `+deadLetterColumns+`
+		FROM `+"`Yotei-Plus`"+`.notification_dead_letters
+		WHERE id = ?
+		LIMIT 1
+	`, id)
+	if err != nil || len(letters) == 0 {
+		return nil, err
+	}
+	return letters[0], nil
+}
+
+// FindPending は再送していない記録を作成日時の新しい順に取得する
+func (r *DeadLetterRepository) FindPending(ctx context.Context, limit, offset int) ([]*domain.DeadLetter, error) {
+	return r.queryDeadLetters(ctx, `
+		SELECT `+deadLetterColumns+`
+		FROM `+"`Yotei-Plus`"+`.notification_dead_letters
+		WHERE redriven_at IS NULL
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?
+	`, limit, offset)
+}
+
+func (r *DeadLetterRepository) queryDeadLetters(ctx context.Context, query string, args ...interface{}) ([]*domain.DeadLetter, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query dead letters", logger.Error(err))
+		return nil, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := []*domain.DeadLetter{}
+	for rows.Next() {
+		var (
+			letter     domain.DeadLetter
+			redrivenAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&letter.ID,
+			&letter.NotificationID,
+			&letter.UserID,
+			&letter.Channel,
+			&letter.Target,
+			&letter.Reason,
+			&letter.Attempts,
+			&letter.CreatedAt,
+			&letter.UpdatedAt,
+			&redrivenAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		if redrivenAt.Valid {
+			letter.RedrivenAt = &redrivenAt.Time
+		}
+		letters = append(letters, &letter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dead letters: %w", err)
+	}
+	return letters, nil
+}
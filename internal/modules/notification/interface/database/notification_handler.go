@@ -22,7 +22,7 @@ func (r *NotificationServiceRepository) Save(ctx context.Context, notification *
 	// メタデータをJSON文字列に変換
 	metadataJSON, err := json.Marshal(notification.Metadata)
 	if err != nil {
-		r.Logger.Error("Failed to marshal metadata", logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to marshal metadata", logger.Error(err))
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
@@ -34,11 +34,17 @@ func (r *NotificationServiceRepository) Save(ctx context.Context, notification *
 		sentAt = nil
 	}
 
+	// まとめ用キー（まとめ対象外の通知はNULL）
+	var groupKey interface{}
+	if key := notification.Metadata[domain.MetadataGroupKey]; key != "" {
+		groupKey = key
+	}
+
 	query := `
 		INSERT INTO ` + "`Yotei-Plus`" + `.notifications (
-			id, user_id, title, message, type, status, metadata, created_at, updated_at, sent_at
+			id, user_id, title, message, type, status, metadata, group_key, created_at, updated_at, sent_at
 		) VALUES (
-			?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 		) ON DUPLICATE KEY UPDATE
 			user_id = VALUES(user_id),
 			title = VALUES(title),
@@ -46,6 +52,7 @@ func (r *NotificationServiceRepository) Save(ctx context.Context, notification *
 			type = VALUES(type),
 			status = VALUES(status),
 			metadata = VALUES(metadata),
+			group_key = VALUES(group_key),
 			updated_at = VALUES(updated_at),
 			sent_at = VALUES(sent_at)
 	`
@@ -59,13 +66,14 @@ func (r *NotificationServiceRepository) Save(ctx context.Context, notification *
 		notification.Type,
 		notification.Status,
 		metadataJSON,
+		groupKey,
 		notification.CreatedAt,
 		notification.UpdatedAt,
 		sentAt,
 	)
 
 	if err != nil {
-		r.Logger.Error("Failed to save notification", logger.Any("id", notification.ID), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to save notification", logger.Any("id", notification.ID), logger.Error(err))
 		return fmt.Errorf("failed to save notification: %w", err)
 	}
 
@@ -85,7 +93,7 @@ func (r *NotificationServiceRepository) FindByID(ctx context.Context, id string)
 
 	row, err := r.Query(query, id)
 	if err != nil {
-		r.Logger.Error("Failed to query notification", logger.Any("id", id), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to query notification", logger.Any("id", id), logger.Error(err))
 		return nil, fmt.Errorf("failed to query notification: %w", err)
 	}
 	defer row.Close()
@@ -114,13 +122,13 @@ func (r *NotificationServiceRepository) FindByID(ctx context.Context, id string)
 	)
 
 	if err != nil {
-		r.Logger.Error("Failed to scan notification", logger.Any("id", id), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to scan notification", logger.Any("id", id), logger.Error(err))
 		return nil, fmt.Errorf("failed to scan notification: %w", err)
 	}
 
 	// メタデータのデコード
 	if err := json.Unmarshal(metadataJSON, &notification.Metadata); err != nil {
-		r.Logger.Error("Failed to unmarshal metadata", logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to unmarshal metadata", logger.Error(err))
 		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 	}
 
@@ -148,7 +156,7 @@ func (r *NotificationServiceRepository) FindByUserID(ctx context.Context, userID
 
 	rows, err := r.Query(query, userID, limit, offset)
 	if err != nil {
-		r.Logger.Error("Failed to query notifications", logger.Any("userID", userID), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to query notifications", logger.Any("userID", userID), logger.Error(err))
 		return nil, fmt.Errorf("failed to query notifications: %w", err)
 	}
 	defer rows.Close()
@@ -175,13 +183,13 @@ func (r *NotificationServiceRepository) FindByUserID(ctx context.Context, userID
 		)
 
 		if err != nil {
-			r.Logger.Error("Failed to scan notification row", logger.Error(err))
+			r.Logger.WithContext(ctx).Error("Failed to scan notification row", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan notification row: %w", err)
 		}
 
 		// メタデータのデコード
 		if err := json.Unmarshal(metadataJSON, &notification.Metadata); err != nil {
-			r.Logger.Error("Failed to unmarshal metadata", logger.Error(err))
+			r.Logger.WithContext(ctx).Error("Failed to unmarshal metadata", logger.Error(err))
 			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 		}
 
@@ -222,13 +230,13 @@ func (r *NotificationServiceRepository) UpdateStatus(ctx context.Context, id str
 
 	result, err := r.Execute(query, status, now, status, sentAt, id)
 	if err != nil {
-		r.Logger.Error("Failed to update notification status", logger.Any("id", id), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to update notification status", logger.Any("id", id), logger.Error(err))
 		return fmt.Errorf("failed to update notification status: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		r.Logger.Error("Failed to get rows affected", logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to get rows affected", logger.Error(err))
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
@@ -249,7 +257,7 @@ func (r *NotificationServiceRepository) CountByUserIDAndStatus(ctx context.Conte
 
 	row, err := r.Query(query, userID, status)
 	if err != nil {
-		r.Logger.Error("Failed to query notification count", logger.Any("userID", userID), logger.Any("status", status), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to query notification count", logger.Any("userID", userID), logger.Any("status", status), logger.Error(err))
 		return 0, fmt.Errorf("failed to query notification count: %w", err)
 	}
 	defer row.Close()
@@ -257,7 +265,7 @@ func (r *NotificationServiceRepository) CountByUserIDAndStatus(ctx context.Conte
 	var count int
 	if row.Next() {
 		if err := row.Scan(&count); err != nil {
-			r.Logger.Error("Failed to scan count", logger.Error(err))
+			r.Logger.WithContext(ctx).Error("Failed to scan count", logger.Error(err))
 			return 0, fmt.Errorf("failed to scan count: %w", err)
 		}
 	}
@@ -281,7 +289,7 @@ func (r *NotificationServiceRepository) FindPendingNotifications(ctx context.Con
 
 	rows, err := r.Query(query, domain.StatusPending, limit)
 	if err != nil {
-		r.Logger.Error("Failed to query pending notifications", logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to query pending notifications", logger.Error(err))
 		return nil, fmt.Errorf("failed to query pending notifications: %w", err)
 	}
 	defer rows.Close()
@@ -308,13 +316,13 @@ func (r *NotificationServiceRepository) FindPendingNotifications(ctx context.Con
 		)
 
 		if err != nil {
-			r.Logger.Error("Failed to scan notification row", logger.Error(err))
+			r.Logger.WithContext(ctx).Error("Failed to scan notification row", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan notification row: %w", err)
 		}
 
 		// メタデータのデコード
 		if err := json.Unmarshal(metadataJSON, &notification.Metadata); err != nil {
-			r.Logger.Error("Failed to unmarshal metadata", logger.Error(err))
+			r.Logger.WithContext(ctx).Error("Failed to unmarshal metadata", logger.Error(err))
 			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 		}
 
@@ -329,19 +337,82 @@ func (r *NotificationServiceRepository) FindPendingNotifications(ctx context.Con
 	return notifications, nil
 }
 
+// FindLatestByGroupKey はまとめ用キーが一致するユーザーの最新の通知を取得する
+func (r *NotificationServiceRepository) FindLatestByGroupKey(ctx context.Context, userID, groupKey string) (*domain.Notification, error) {
+	query := `
+		SELECT 
+			id, user_id, title, message, type, status, metadata, created_at, updated_at, sent_at
+		FROM 
+			` + "`Yotei-Plus`" + `.notifications
+		WHERE 
+			user_id = ? AND group_key = ?
+		ORDER BY 
+			updated_at DESC
+		LIMIT 1
+	`
+
+	row, err := r.Query(query, userID, groupKey)
+	if err != nil {
+		r.Logger.WithContext(ctx).Error("Failed to query grouped notification", logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to query grouped notification: %w", err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return nil, nil // まとめ先の通知がない場合
+	}
+
+	var (
+		notification domain.Notification
+		metadataJSON []byte
+		sentAt       sql.NullTime
+	)
+
+	err = row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Title,
+		&notification.Message,
+		&notification.Type,
+		&notification.Status,
+		&metadataJSON,
+		&notification.CreatedAt,
+		&notification.UpdatedAt,
+		&sentAt,
+	)
+
+	if err != nil {
+		r.Logger.WithContext(ctx).Error("Failed to scan grouped notification", logger.Error(err))
+		return nil, fmt.Errorf("failed to scan grouped notification: %w", err)
+	}
+
+	// メタデータのデコード
+	if err := json.Unmarshal(metadataJSON, &notification.Metadata); err != nil {
+		r.Logger.WithContext(ctx).Error("Failed to unmarshal metadata", logger.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
+	// 送信日時の処理
+	if sentAt.Valid {
+		notification.SentAt = &sentAt.Time
+	}
+
+	return &notification, nil
+}
+
 // 通知を削除するメソッド
 func (r *NotificationServiceRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM ` + "`Yotei-Plus`" + `.notifications WHERE id = ?`
 
 	result, err := r.Execute(query, id)
 	if err != nil {
-		r.Logger.Error("Failed to delete notification", logger.Any("id", id), logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to delete notification", logger.Any("id", id), logger.Error(err))
 		return fmt.Errorf("failed to delete notification: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		r.Logger.Error("Failed to get rows affected", logger.Error(err))
+		r.Logger.WithContext(ctx).Error("Failed to get rows affected", logger.Error(err))
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/persistence"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PreferenceRepository は通知の設定のデータベースリポジトリ実装
+type PreferenceRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewPreferenceRepository は新しいPreferenceRepositoryを作成する
+func NewPreferenceRepository(sqlHandler SqlHandler, logger logger.Logger) persistence.PreferenceRepository {
+	return &PreferenceRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// GetPreferences はユーザーの通知の設定を取得する（保存していない場合は nil, nil）
+func (r *PreferenceRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	query := `
+		SELECT user_id, social_digest, updated_at
+		FROM ` + "`Yotei-Plus`" + `.notification_preferences
+		WHERE user_id = ?
+	`
+
+	var preferences domain.Preferences
+	err := r.QueryRowContext(ctx, query, userID).Scan(
+		&preferences.UserID,
+		&preferences.SocialDigest,
+		&preferences.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get notification preferences",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return &preferences, nil
+}
+
+// SavePreferences はユーザーの通知の設定を保存する（保存済みの場合は上書きする）
+func (r *PreferenceRepository) SavePreferences(ctx context.Context, preferences *domain.Preferences) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.notification_preferences
+			(user_id, social_digest, updated_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			social_digest = VALUES(social_digest),
+			updated_at = VALUES(updated_at)
+	`
+
+	if _, err := r.ExecContext(ctx, query,
+		preferences.UserID,
+		string(preferences.SocialDigest),
+		preferences.UpdatedAt,
+	); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save notification preferences",
+			logger.Any("userID", preferences.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
@@ -1,11 +1,13 @@
 package websocket
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
 	"github.com/hryt430/Yotei+/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -21,6 +23,13 @@ const (
 	maxMessageSize = 1024
 )
 
+// clientMessage はクライアントから受信するメッセージ
+// 離席中・復帰の通知: {"type":"presence","status":"AWAY"} / {"type":"presence","status":"ONLINE"}
+type clientMessage struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -69,6 +78,7 @@ func (c *Client) ReadPump() {
 	c.conn.SetPongHandler(func(string) error {
 		c.logger.Debug("Pong received", zap.Any("userID", c.UserID))
 		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.hub.trackPresence(presenceHeartbeat, c.UserID)
 		return nil
 	})
 
@@ -99,8 +109,22 @@ func (c *Client) ReadPump() {
 			zap.Any("messageType", messageType),
 			zap.Any("messageSize", len(message)))
 
-		// 実際にはクライアントからのメッセージ処理は今回必要ないが、
-		// 接続維持のためにReadMessageを呼び出す
+		c.handleMessage(message)
+	}
+}
+
+// handleMessage はクライアントからのメッセージを処理する（離席中・復帰の通知のみ対応）
+func (c *Client) handleMessage(message []byte) {
+	var msg clientMessage
+	if err := json.Unmarshal(message, &msg); err != nil || msg.Type != "presence" {
+		return
+	}
+
+	switch msg.Status {
+	case "AWAY":
+		c.hub.trackPresence(presenceAway, c.UserID)
+	case "ONLINE":
+		c.hub.trackPresence(presenceBack, c.UserID)
 	}
 }
 
@@ -208,7 +232,7 @@ func ServeWs(hub *Hub, logger logger.Logger) gin.HandlerFunc {
 		client.hub.register <- client
 
 		// クライアントのループを開始
-		go client.WritePump()
-		go client.ReadPump()
+		errtrack.Go("websocket-client", client.WritePump)
+		errtrack.Go("websocket-client", client.ReadPump)
 	}
 }
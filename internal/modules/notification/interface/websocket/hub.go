@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
 	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
@@ -24,10 +26,43 @@ type Hub struct {
 	// 通知送信チャネル
 	broadcast chan *domain.Notification
 
+	// ユーザー宛メッセージ送信チャネル（オンライン状態の変化など）
+	direct chan *userMessage
+
+	// オンライン状態の記録（nilの場合は記録しない、Run前に設定する）
+	PresenceTracker commonDomain.PresenceTracker
+	presenceEvents  chan presenceEvent
+
 	// ロガー
 	logger logger.Logger
 }
 
+// userMessage は特定ユーザーの全クライアントに送信するメッセージ
+type userMessage struct {
+	userID  string
+	payload []byte
+}
+
+// presenceEventKind はオンライン状態に関する接続イベントの種別
+type presenceEventKind int
+
+const (
+	presenceConnected presenceEventKind = iota
+	presenceHeartbeat
+	presenceAway
+	presenceBack
+	presenceDisconnected
+)
+
+// presenceEvent はPresenceTrackerに渡す接続イベント
+type presenceEvent struct {
+	kind   presenceEventKind
+	userID string
+}
+
+// presenceTimeout はオンライン状態の記録1件あたりのタイムアウト
+const presenceTimeout = 5 * time.Second
+
 // NewHub はWebSocketハブを作成する
 func NewHub(logger logger.Logger) *Hub {
 	return &Hub{
@@ -35,7 +70,10 @@ func NewHub(logger logger.Logger) *Hub {
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		broadcast:  make(chan *domain.Notification),
-		logger:     logger,
+		direct:     make(chan *userMessage, 256),
+		// 接続イベントは順番に処理するため、ハブとは別のゴルーチンで記録する
+		presenceEvents: make(chan presenceEvent, 1024),
+		logger:         logger,
 	}
 }
 
@@ -46,6 +84,10 @@ func (h *Hub) Run(ctx context.Context) error {
 	// 停止時のクリーンアップ用
 	defer h.cleanup()
 
+	if h.PresenceTracker != nil {
+		go h.runPresence(ctx)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -54,12 +96,18 @@ func (h *Hub) Run(ctx context.Context) error {
 
 		case client := <-h.register:
 			h.clientsMu.Lock()
-			if _, ok := h.clients[client.UserID]; !ok {
+			_, connected := h.clients[client.UserID]
+			if !connected {
 				h.clients[client.UserID] = make(map[*Client]bool)
 			}
 			h.clients[client.UserID][client] = true
 			h.clientsMu.Unlock()
 
+			// 最初の接続でオンラインにする
+			if !connected {
+				h.trackPresence(presenceConnected, client.UserID)
+			}
+
 			h.logger.Info("Client registered",
 				logger.Any("userID", client.UserID),
 				logger.Any("totalClients", len(h.clients)))
@@ -70,9 +118,10 @@ func (h *Hub) Run(ctx context.Context) error {
 				delete(h.clients[client.UserID], client)
 				close(client.send)
 
-				// ユーザーIDに対応するクライアントがなくなった場合、マップエントリを削除
+				// ユーザーIDに対応するクライアントがなくなった場合、マップエントリを削除してオフラインにする
 				if len(h.clients[client.UserID]) == 0 {
 					delete(h.clients, client.UserID)
+					h.trackPresence(presenceDisconnected, client.UserID)
 				}
 			}
 			h.clientsMu.Unlock()
@@ -128,10 +177,72 @@ func (h *Hub) Run(ctx context.Context) error {
 					logger.Any("userID", notification.UserID))
 			}
 			h.clientsMu.RUnlock()
+
+		case message := <-h.direct:
+			h.clientsMu.RLock()
+			for client := range h.clients[message.userID] {
+				select {
+				case client.send <- message.payload:
+				default:
+					h.logger.Warn("Client send channel full, dropping message",
+						logger.Any("userID", client.UserID))
+				}
+			}
+			h.clientsMu.RUnlock()
+		}
+	}
+}
+
+// runPresence は接続イベントを順番にPresenceTrackerへ記録する
+func (h *Hub) runPresence(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-h.presenceEvents:
+			h.applyPresence(ctx, event)
 		}
 	}
 }
 
+// applyPresence は接続イベントを1件記録する
+func (h *Hub) applyPresence(ctx context.Context, event presenceEvent) {
+	ctx, cancel := context.WithTimeout(ctx, presenceTimeout)
+	defer cancel()
+
+	var err error
+	switch event.kind {
+	case presenceConnected:
+		err = h.PresenceTracker.Connected(ctx, event.userID)
+	case presenceHeartbeat:
+		err = h.PresenceTracker.Heartbeat(ctx, event.userID)
+	case presenceAway:
+		err = h.PresenceTracker.SetAway(ctx, event.userID, true)
+	case presenceBack:
+		err = h.PresenceTracker.SetAway(ctx, event.userID, false)
+	case presenceDisconnected:
+		err = h.PresenceTracker.Disconnected(ctx, event.userID)
+	}
+	if err != nil {
+		h.logger.Warn("Failed to record presence",
+			logger.Any("userID", event.userID),
+			logger.Error(err))
+	}
+}
+
+// trackPresence は接続イベントをキューに追加する（ノンブロッキング）
+func (h *Hub) trackPresence(kind presenceEventKind, userID string) {
+	if h.PresenceTracker == nil {
+		return
+	}
+	select {
+	case h.presenceEvents <- presenceEvent{kind: kind, userID: userID}:
+	default:
+		h.logger.Warn("Presence event queue full, dropping event",
+			logger.Any("userID", userID))
+	}
+}
+
 // cleanup は停止時のクリーンアップ処理を行う
 func (h *Hub) cleanup() {
 	h.logger.Info("Cleaning up WebSocket hub")
@@ -169,3 +280,13 @@ func (h *Hub) SendNotification(notification *domain.Notification) {
 			logger.Any("userID", notification.UserID))
 	}
 }
+
+// SendToUser は指定ユーザーの接続中の全クライアントにメッセージを送信する
+func (h *Hub) SendToUser(userID string, message []byte) {
+	select {
+	case h.direct <- &userMessage{userID: userID, payload: message}:
+	default:
+		h.logger.Warn("Direct message channel full, dropping message",
+			logger.Any("userID", userID))
+	}
+}
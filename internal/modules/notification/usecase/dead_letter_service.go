@@ -0,0 +1,215 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// 配信不能のメトリクス
+const (
+	MetricDeadLetters      = "notification_dead_letters_total"
+	MetricDeadLetterAlerts = "notification_dead_letter_alerts_total"
+	MetricRedrives         = "notification_redrives_total"
+)
+
+const (
+	// DefaultRetryBackoff は最初の再試行までの既定の間隔
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// DefaultDeadLetterAlertWindow は配信不能の割合を集計する既定の期間
+	DefaultDeadLetterAlertWindow = 15 * time.Minute
+)
+
+// EventDeadLetterAlert は配信不能の割合が高いことを管理者に知らせる通知のイベント種別
+const EventDeadLetterAlert = "notification_dead_letter_alert"
+
+// deliver はチャネルに送信し、失敗した場合は再試行する
+// 全ての試行に失敗した場合は配信不能として記録し、割合が高い場合は管理者に知らせる
+func (uc *notificationUseCase) deliver(ctx context.Context, notification *domain.Notification, channel domain.Channel) error {
+	attempts, err := uc.sendWithRetry(ctx, notification, channel)
+	if err != nil && ctx.Err() != nil {
+		// 呼び出し元が中断した場合は配信不能として扱わない
+		return err
+	}
+
+	uc.recordDelivery(ctx, err != nil)
+	if err != nil {
+		uc.deadLetter(ctx, notification, channel, err, attempts)
+	}
+	return err
+}
+
+// sendWithRetry はチャネルに送信し、失敗した場合は間隔を空けて再試行する（試行回数を返す）
+// 回路が開いている場合・呼び出し元が中断した場合は再試行しない
+func (uc *notificationUseCase) sendWithRetry(ctx context.Context, notification *domain.Notification, channel domain.Channel) (int, error) {
+	backoff := uc.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := uc.sendToChannel(ctx, notification, channel)
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt >= uc.maxAttempts || errors.Is(err, circuitbreaker.ErrOpen) || ctx.Err() != nil {
+			return attempt, err
+		}
+
+		uc.logger.WithContext(ctx).Warn("Failed to send notification, retrying",
+			logger.Any("notificationID", notification.ID),
+			logger.Any("channel", channel.GetType()),
+			logger.Any("attempt", attempt),
+			logger.Error(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// deadLetter は送信できなかったチャネルを記録する（記録先がない場合はログにのみ出力する）
+func (uc *notificationUseCase) deadLetter(ctx context.Context, notification *domain.Notification, channel domain.Channel, cause error, attempts int) {
+	metrics.Counter(MetricDeadLetters).Add(1)
+	uc.logger.WithContext(ctx).Error("Notification dead-lettered",
+		logger.Any("notificationID", notification.ID),
+		logger.Any("channel", channel.GetType()),
+		logger.Any("attempts", attempts),
+		logger.Error(cause))
+
+	if uc.deadLetters == nil {
+		return
+	}
+	letter := domain.NewDeadLetter(notification, channel, cause.Error(), attempts)
+	if err := uc.deadLetters.Save(ctx, letter); err != nil {
+		uc.logger.WithContext(ctx).Error("Failed to save dead letter",
+			logger.Any("notificationID", notification.ID),
+			logger.Error(err))
+	}
+}
+
+// recordDelivery は送信の結果を集計し、配信不能の割合が閾値を超えた場合は管理者に知らせる
+func (uc *notificationUseCase) recordDelivery(ctx context.Context, deadLettered bool) {
+	if uc.admins == nil || uc.deadLetterAlert.Threshold <= 0 {
+		return
+	}
+
+	uc.deadLetterMu.Lock()
+	alert := uc.deadLetterWindow.Record(time.Now(), deadLettered, uc.deadLetterAlert)
+	window := uc.deadLetterWindow
+	uc.deadLetterMu.Unlock()
+
+	if alert {
+		uc.alertAdmins(ctx, window)
+	}
+}
+
+// alertAdmins は管理者にアプリ内通知で配信不能の割合を知らせる
+// 通知の送信自体の失敗を避けるため、再試行・配信不能の記録は行わない
+func (uc *notificationUseCase) alertAdmins(ctx context.Context, window domain.DeadLetterWindow) {
+	metrics.Counter(MetricDeadLetterAlerts).Add(1)
+	uc.logger.WithContext(ctx).Warn("Notification dead-letter rate exceeded threshold",
+		logger.Any("deliveries", window.Deliveries),
+		logger.Any("deadLetters", window.DeadLetters),
+		logger.Any("rate", window.Rate()))
+
+	adminIDs, err := uc.admins.ListAdminIDs(ctx)
+	if err != nil {
+		uc.logger.WithContext(ctx).Error("Failed to list admins for dead-letter alert", logger.Error(err))
+		return
+	}
+
+	title := "通知の配信不能が増えています"
+	message := fmt.Sprintf("%s以降の通知の送信%d件のうち%d件（%.0f%%）が再試行しても送信できませんでした。配信不能の一覧で理由を確認し、原因を解消してから再送してください。",
+		window.Start.Format("2006-01-02 15:04"), window.Deliveries, window.DeadLetters, window.Rate()*100)
+	metadata := map[string]string{domain.MetadataEventType: EventDeadLetterAlert}
+	for _, adminID := range adminIDs {
+		if err := uc.appGateway.SendNotification(ctx, adminID, title, message, metadata); err != nil {
+			uc.logger.WithContext(ctx).Error("Failed to send dead-letter alert",
+				logger.Any("adminID", adminID),
+				logger.Error(err))
+		}
+	}
+}
+
+// ListDeadLetters は再送していない配信不能の記録を新しい順に取得する
+func (uc *notificationUseCase) ListDeadLetters(ctx context.Context, limit, offset int) ([]*domain.DeadLetter, error) {
+	if uc.deadLetters == nil {
+		return []*domain.DeadLetter{}, nil
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	letters, err := uc.deadLetters.FindPending(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	return letters, nil
+}
+
+// RedriveDeadLetter は配信不能の通知を記録したチャネルに再送する
+// 再送に失敗した場合は理由を更新して記録を残す
+func (uc *notificationUseCase) RedriveDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	if uc.deadLetters == nil {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+	letter, err := uc.deadLetters.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dead letter: %w", err)
+	}
+	if letter == nil {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+	if letter.IsRedriven() {
+		return letter, domain.ErrDeadLetterRedriven
+	}
+
+	notification, err := uc.repository.FindByID(ctx, letter.NotificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification: %w", err)
+	}
+	if notification == nil {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+
+	attempts, sendErr := uc.sendWithRetry(ctx, notification, letter.ToChannel())
+	if sendErr != nil {
+		letter.RecordFailure(sendErr.Error(), attempts)
+	} else {
+		letter.MarkRedriven(attempts)
+	}
+	if err := uc.deadLetters.Save(ctx, letter); err != nil {
+		return nil, fmt.Errorf("failed to save dead letter: %w", err)
+	}
+	if sendErr != nil {
+		return letter, fmt.Errorf("%w: %v", domain.ErrRedriveFailed, sendErr)
+	}
+	metrics.Counter(MetricRedrives).Add(1)
+
+	// 送信に失敗したままの通知は送信済みにする
+	if notification.Status == domain.StatusFailed {
+		notification.MarkAsSent()
+		if err := uc.repository.Save(ctx, notification); err != nil {
+			uc.logger.WithContext(ctx).Error("Failed to update redriven notification status",
+				logger.Any("notificationID", notification.ID),
+				logger.Error(err))
+		}
+	}
+
+	uc.logger.WithContext(ctx).Info("Dead letter redriven",
+		logger.Any("deadLetterID", letter.ID),
+		logger.Any("notificationID", notification.ID))
+	return letter, nil
+}
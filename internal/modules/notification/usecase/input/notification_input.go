@@ -42,4 +42,16 @@ type NotificationUseCase interface {
 
 	// GetUnreadNotificationCount はユーザーの未読通知数を取得する
 	GetUnreadNotificationCount(ctx context.Context, userID string) (int, error)
+
+	// ListTemplates は登録済みの通知テンプレート一覧を取得する
+	ListTemplates(ctx context.Context) []domain.Template
+
+	// PreviewTemplate は通知テンプレートを描画した結果を返す
+	PreviewTemplate(ctx context.Context, eventType, locale string, vars map[string]string) (*domain.RenderedTemplate, error)
+
+	// ListDeadLetters は再送していない配信不能の記録を取得する
+	ListDeadLetters(ctx context.Context, limit, offset int) ([]*domain.DeadLetter, error)
+
+	// RedriveDeadLetter は配信不能の通知を再送する
+	RedriveDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error)
 }
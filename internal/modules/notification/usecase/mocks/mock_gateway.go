@@ -202,3 +202,41 @@ func (mr *MockWebhookOutputMockRecorder) SendWebhook(ctx, event, payload interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWebhook", reflect.TypeOf((*MockWebhookOutput)(nil).SendWebhook), ctx, event, payload)
 }
+
+// MockAdminDirectory is a mock of AdminDirectory interface.
+type MockAdminDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminDirectoryMockRecorder
+}
+
+// MockAdminDirectoryMockRecorder is the mock recorder for MockAdminDirectory.
+type MockAdminDirectoryMockRecorder struct {
+	mock *MockAdminDirectory
+}
+
+// NewMockAdminDirectory creates a new mock instance.
+func NewMockAdminDirectory(ctrl *gomock.Controller) *MockAdminDirectory {
+	mock := &MockAdminDirectory{ctrl: ctrl}
+	mock.recorder = &MockAdminDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminDirectory) EXPECT() *MockAdminDirectoryMockRecorder {
+	return m.recorder
+}
+
+// ListAdminIDs mocks base method.
+func (m *MockAdminDirectory) ListAdminIDs(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdminIDs", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdminIDs indicates an expected call of ListAdminIDs.
+func (mr *MockAdminDirectoryMockRecorder) ListAdminIDs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdminIDs", reflect.TypeOf((*MockAdminDirectory)(nil).ListAdminIDs), ctx)
+}
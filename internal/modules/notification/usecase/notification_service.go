@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
 	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/output"
@@ -25,7 +27,32 @@ type notificationUseCase struct {
 	appGateway    output.AppNotificationGateway
 	lineGateway   output.LineNotificationGateway
 	userValidator UserValidator
+	grouping      domain.GroupingPolicy
+	templates     *domain.TemplateRegistry
 	logger        logger.Logger
+
+	// 送信の再試行と配信不能の記録
+	maxAttempts     int
+	retryBackoff    time.Duration
+	deadLetters     persistence.DeadLetterRepository
+	admins          output.AdminDirectory
+	deadLetterAlert domain.DeadLetterAlertPolicy
+
+	deadLetterMu     sync.Mutex
+	deadLetterWindow domain.DeadLetterWindow
+}
+
+// Options は通知ユースケースの任意の設定
+type Options struct {
+	Grouping domain.GroupingPolicy
+	// チャネルごとの送信の試行回数（0以下の場合は再試行しない）と、再試行の間隔（試行ごとに2倍にする）
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	// 再試行しても送信できなかった通知の記録先（nilの場合はログにのみ出力する）
+	DeadLetters persistence.DeadLetterRepository
+	// 配信不能の割合が DeadLetterAlert を超えた場合に知らせる管理者（nilの場合は知らせない）
+	Admins          output.AdminDirectory
+	DeadLetterAlert domain.DeadLetterAlertPolicy
 }
 
 // NewNotificationUseCase は通知ユースケースのインスタンスを作成する
@@ -36,12 +63,46 @@ func NewNotificationUseCase(
 	userValidator UserValidator,
 	logger logger.Logger,
 ) input.NotificationUseCase {
+	return NewNotificationUseCaseWithGrouping(repository, appGateway, lineGateway, userValidator, domain.DefaultGroupingPolicy(), logger)
+}
+
+// NewNotificationUseCaseWithGrouping は通知まとめ設定を指定して通知ユースケースのインスタンスを作成する
+func NewNotificationUseCaseWithGrouping(
+	repository persistence.NotificationRepository,
+	appGateway output.AppNotificationGateway,
+	lineGateway output.LineNotificationGateway,
+	userValidator UserValidator,
+	grouping domain.GroupingPolicy,
+	logger logger.Logger,
+) input.NotificationUseCase {
+	return NewNotificationUseCaseWithOptions(repository, appGateway, lineGateway, userValidator, Options{Grouping: grouping}, logger)
+}
+
+// NewNotificationUseCaseWithOptions は通知まとめ・再試行・配信不能の記録を指定して通知ユースケースのインスタンスを作成する
+func NewNotificationUseCaseWithOptions(
+	repository persistence.NotificationRepository,
+	appGateway output.AppNotificationGateway,
+	lineGateway output.LineNotificationGateway,
+	userValidator UserValidator,
+	opts Options,
+	logger logger.Logger,
+) input.NotificationUseCase {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
 	return &notificationUseCase{
-		repository:    repository,
-		appGateway:    appGateway,
-		lineGateway:   lineGateway,
-		userValidator: userValidator,
-		logger:        logger,
+		repository:      repository,
+		appGateway:      appGateway,
+		lineGateway:     lineGateway,
+		userValidator:   userValidator,
+		grouping:        opts.Grouping,
+		templates:       domain.DefaultTemplateRegistry(),
+		logger:          logger,
+		maxAttempts:     opts.MaxAttempts,
+		retryBackoff:    opts.RetryBackoff,
+		deadLetters:     opts.DeadLetters,
+		admins:          opts.Admins,
+		deadLetterAlert: opts.DeadLetterAlert,
 	}
 }
 
@@ -55,7 +116,7 @@ func (uc *notificationUseCase) CreateNotification(ctx context.Context, input inp
 	// ユーザー存在確認（統一インターフェース使用）
 	exists, err := uc.userValidator.UserExists(ctx, input.UserID)
 	if err != nil {
-		uc.logger.Error("Failed to validate user existence", logger.Any("userID", input.UserID), logger.Error(err))
+		uc.logger.WithContext(ctx).Error("Failed to validate user existence", logger.Any("userID", input.UserID), logger.Error(err))
 		return nil, fmt.Errorf("failed to validate user: %w", err)
 	}
 	if !exists {
@@ -74,21 +135,75 @@ func (uc *notificationUseCase) CreateNotification(ctx context.Context, input inp
 		input.Metadata,
 	)
 
+	// テンプレートがあるイベント種別は文面をテンプレートから作成する
+	if _, err := notification.ApplyTemplate(uc.templates); err != nil {
+		if input.Title == "" || input.Message == "" {
+			return nil, fmt.Errorf("failed to render notification template: %w", err)
+		}
+		uc.logger.WithContext(ctx).Warn("Failed to render notification template, using given text",
+			logger.Any("eventType", notification.EventType()), logger.Error(err))
+	}
+
 	// チャネルの追加
 	if err := uc.addChannelsToNotification(ctx, notification, input); err != nil {
 		return nil, fmt.Errorf("failed to add channels: %w", err)
 	}
 
+	// 同じ実行者・対象の直近の通知があればまとめる
+	if grouped, err := uc.groupNotification(ctx, notification); err != nil {
+		return nil, err
+	} else if grouped != nil {
+		return grouped, nil
+	}
+
 	// 通知をデータベースに保存
 	if err := uc.repository.Save(ctx, notification); err != nil {
-		uc.logger.Error("Failed to save notification", logger.Any("notificationID", notification.ID), logger.Error(err))
+		uc.logger.WithContext(ctx).Error("Failed to save notification", logger.Any("notificationID", notification.ID), logger.Error(err))
 		return nil, fmt.Errorf("failed to save notification: %w", err)
 	}
 
-	uc.logger.Info("Notification created successfully", logger.Any("notificationID", notification.ID), logger.Any("userID", input.UserID))
+	uc.logger.WithContext(ctx).Info("Notification created successfully", logger.Any("notificationID", notification.ID), logger.Any("userID", input.UserID))
 	return notification, nil
 }
 
+// groupNotification はウィンドウ内の同じキーの通知に新しい通知をまとめる
+// まとめた場合は更新後の通知を、まとめ対象外の場合はnilを返す
+func (uc *notificationUseCase) groupNotification(ctx context.Context, notification *domain.Notification) (*domain.Notification, error) {
+	window := uc.grouping.WindowFor(notification.EventType())
+	if window <= 0 {
+		return nil, nil
+	}
+	key, ok := notification.GroupKey()
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now()
+	existing, err := uc.repository.FindLatestByGroupKey(ctx, notification.UserID, key)
+	if err != nil {
+		// まとめられなくても通知自体は作成する
+		uc.logger.WithContext(ctx).Warn("Failed to find notification to group", logger.Any("userID", notification.UserID), logger.Error(err))
+		existing = nil
+	}
+
+	if existing == nil || !existing.CanMerge(now, window, uc.grouping.MaxGroupSize) {
+		notification.StartGroup(key, now)
+		return nil, nil
+	}
+
+	existing.MergeWith(notification, now)
+	if err := uc.repository.Save(ctx, existing); err != nil {
+		uc.logger.WithContext(ctx).Error("Failed to save grouped notification", logger.Any("notificationID", existing.ID), logger.Error(err))
+		return nil, fmt.Errorf("failed to save notification: %w", err)
+	}
+
+	uc.logger.WithContext(ctx).Info("Notification grouped",
+		logger.Any("notificationID", existing.ID),
+		logger.Any("userID", existing.UserID),
+		logger.Any("count", existing.GroupCount()))
+	return existing, nil
+}
+
 // CreateScheduledNotification はスケジュール通知を作成する
 func (uc *notificationUseCase) CreateScheduledNotification(
 	ctx context.Context,
@@ -119,7 +234,7 @@ func (uc *notificationUseCase) CreateScheduledNotification(
 		return fmt.Errorf("failed to save scheduled notification: %w", err)
 	}
 
-	uc.logger.Info("Scheduled notification created",
+	uc.logger.WithContext(ctx).Info("Scheduled notification created",
 		logger.Any("notificationID", notification.ID),
 		logger.Any("scheduledTime", scheduledTime))
 
@@ -139,7 +254,7 @@ func (uc *notificationUseCase) SendNotification(ctx context.Context, id string)
 
 	// 送信済みの場合はスキップ
 	if notification.Status == domain.StatusSent {
-		uc.logger.Warn("Notification already sent", logger.Any("notificationID", id))
+		uc.logger.WithContext(ctx).Warn("Notification already sent", logger.Any("notificationID", id))
 		return nil
 	}
 
@@ -160,12 +275,12 @@ func (uc *notificationUseCase) sendToAllChannels(ctx context.Context, notificati
 		go func(ch domain.Channel) {
 			defer func() {
 				if r := recover(); r != nil {
-					uc.logger.Error("Panic in notification sending", logger.Any("panic", r))
+					errtrack.ReportPanic("notification-channel", r)
 					errorCh <- fmt.Errorf("panic occurred: %v", r)
 				}
 			}()
 
-			err := uc.sendToChannel(ctx, notification, ch)
+			err := uc.deliver(ctx, notification, ch)
 			errorCh <- err
 		}(channel)
 	}
@@ -188,7 +303,7 @@ func (uc *notificationUseCase) sendToAllChannels(ctx context.Context, notificati
 	// 全て成功
 	notification.MarkAsSent()
 	if err := uc.repository.Save(ctx, notification); err != nil {
-		uc.logger.Error("Failed to update notification status to sent", logger.Error(err))
+		uc.logger.WithContext(ctx).Error("Failed to update notification status to sent", logger.Error(err))
 		return fmt.Errorf("failed to update notification status: %w", err)
 	}
 
@@ -226,11 +341,11 @@ func (uc *notificationUseCase) ProcessPendingNotifications(ctx context.Context,
 		return fmt.Errorf("failed to find pending notifications: %w", err)
 	}
 
-	uc.logger.Info("Processing pending notifications", logger.Any("count", len(notifications)))
+	uc.logger.WithContext(ctx).Info("Processing pending notifications", logger.Any("count", len(notifications)))
 
 	for _, notification := range notifications {
 		if err := uc.SendNotification(ctx, notification.ID); err != nil {
-			uc.logger.Error("Failed to send pending notification",
+			uc.logger.WithContext(ctx).Error("Failed to send pending notification",
 				logger.Any("notificationID", notification.ID),
 				logger.Error(err))
 			continue
@@ -240,15 +355,37 @@ func (uc *notificationUseCase) ProcessPendingNotifications(ctx context.Context,
 	return nil
 }
 
+// ListTemplates は登録済みの通知テンプレート一覧を取得する
+func (uc *notificationUseCase) ListTemplates(ctx context.Context) []domain.Template {
+	return uc.templates.List()
+}
+
+// PreviewTemplate は通知テンプレートを描画した結果を返す（変数未指定の場合はサンプル変数を使用）
+func (uc *notificationUseCase) PreviewTemplate(ctx context.Context, eventType, locale string, vars map[string]string) (*domain.RenderedTemplate, error) {
+	if locale == "" {
+		locale = domain.DefaultLocale
+	}
+	template, ok := uc.templates.Lookup(eventType, locale)
+	if !ok {
+		return nil, domain.ErrTemplateNotFound
+	}
+	if len(vars) == 0 {
+		vars = template.Sample
+	}
+	return template.Render(vars)
+}
+
 // validateCreateInput は作成入力をバリデーション
 func (uc *notificationUseCase) validateCreateInput(input input.CreateNotificationInput) error {
 	if input.UserID == "" {
 		return errors.New("user ID is required")
 	}
-	if input.Title == "" {
+	// テンプレートで文面を作成する場合はタイトル・本文を省略できる
+	templated := uc.templates != nil && uc.templates.Has(input.Metadata[domain.MetadataEventType])
+	if input.Title == "" && !templated {
 		return errors.New("title is required")
 	}
-	if input.Message == "" {
+	if input.Message == "" && !templated {
 		return errors.New("message is required")
 	}
 	if len(input.Channels) == 0 {
@@ -268,6 +405,8 @@ func (uc *notificationUseCase) convertNotificationType(typeStr string) domain.No
 		return domain.TaskCompleted
 	case "TASK_DUE_SOON":
 		return domain.TaskDueSoon
+	case "TASK_MENTIONED":
+		return domain.TaskMentioned
 	case "SYSTEM_NOTICE":
 		return domain.SystemNotice
 	default:
@@ -288,7 +427,7 @@ func (uc *notificationUseCase) addChannelsToNotification(ctx context.Context, no
 			}
 			notification.AddChannel(domain.NewLineChannel(input.UserID, lineUserID, ""))
 		default:
-			uc.logger.Warn("Unknown channel type", logger.Any("channel", channelName))
+			uc.logger.WithContext(ctx).Warn("Unknown channel type", logger.Any("channel", channelName))
 		}
 	}
 	return nil
@@ -301,7 +440,7 @@ func (uc *notificationUseCase) MarkNotificationAsRead(ctx context.Context, id st
 	}
 
 	if err := uc.appGateway.MarkAsRead(ctx, id); err != nil {
-		uc.logger.Error("Failed to mark app notification as read", logger.Error(err))
+		uc.logger.WithContext(ctx).Error("Failed to mark app notification as read", logger.Error(err))
 		// アプリ内通知の既読更新失敗は致命的ではないので続行
 	}
 
@@ -40,3 +40,9 @@ type WebhookOutput interface {
 	// SendWebhook はWebhookを送信する
 	SendWebhook(ctx context.Context, event WebhookEvent, payload interface{}) error
 }
+
+// AdminDirectory は配信不能の割合が高い場合に知らせる管理者を取得するインターフェース
+type AdminDirectory interface {
+	// ListAdminIDs は有効な管理者のユーザーIDを取得する
+	ListAdminIDs(ctx context.Context) ([]string, error)
+}
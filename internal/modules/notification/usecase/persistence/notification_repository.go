@@ -25,4 +25,27 @@ type NotificationRepository interface {
 
 	// FindPendingNotifications は保留中の通知を取得する
 	FindPendingNotifications(ctx context.Context, limit int) ([]*domain.Notification, error)
+
+	// FindLatestByGroupKey はまとめ用キーが一致するユーザーの最新の通知を取得する（存在しない場合はnil）
+	FindLatestByGroupKey(ctx context.Context, userID, groupKey string) (*domain.Notification, error)
+}
+
+// DeadLetterRepository は配信不能の記録のリポジトリインターフェース
+type DeadLetterRepository interface {
+	// Save は記録を保存する（同じIDの記録は上書きする）
+	Save(ctx context.Context, letter *domain.DeadLetter) error
+
+	// FindByID はIDから記録を取得する（存在しない場合は nil, nil）
+	FindByID(ctx context.Context, id string) (*domain.DeadLetter, error)
+
+	// FindPending は再送していない記録を作成日時の新しい順に取得する
+	FindPending(ctx context.Context, limit, offset int) ([]*domain.DeadLetter, error)
+}
+
+// PreferenceRepository はユーザーごとの通知の設定のリポジトリインターフェース
+type PreferenceRepository interface {
+	// GetPreferences は設定を取得する（保存していない場合は nil, nil）
+	GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error)
+	// SavePreferences は設定を保存する（保存済みの場合は上書きする）
+	SavePreferences(ctx context.Context, preferences *domain.Preferences) error
 }
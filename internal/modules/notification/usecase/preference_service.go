@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/persistence"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ErrInvalidUserID はユーザーIDが指定されていないことを表す
+var ErrInvalidUserID = errors.New("user id is required")
+
+// UpdatePreferencesInput は通知の設定の変更（nilの項目は変更しない）
+type UpdatePreferencesInput struct {
+	SocialDigest *domain.DigestFrequency
+}
+
+// PreferenceService はユーザーごとの通知の設定を扱うサービス
+type PreferenceService struct {
+	repository persistence.PreferenceRepository
+	logger     logger.Logger
+}
+
+// NewPreferenceService はPreferenceServiceのコンストラクタ
+func NewPreferenceService(repository persistence.PreferenceRepository, logger logger.Logger) *PreferenceService {
+	return &PreferenceService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// GetPreferences は通知の設定を取得する（保存していない場合は既定の設定）
+func (s *PreferenceService) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+	preferences, err := s.repository.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	if preferences == nil {
+		return domain.DefaultPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences は通知の設定を変更する
+func (s *PreferenceService) UpdatePreferences(ctx context.Context, userID string, input UpdatePreferencesInput) (*domain.Preferences, error) {
+	preferences, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.SocialDigest != nil {
+		preferences.SocialDigest = *input.SocialDigest
+	}
+	if err := preferences.Validate(); err != nil {
+		return nil, err
+	}
+
+	preferences.UpdatedAt = time.Now()
+	if err := s.repository.SavePreferences(ctx, preferences); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save notification preferences",
+			logger.Any("userID", userID),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return preferences, nil
+}
@@ -3,12 +3,14 @@ package notification
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
 	"github.com/hryt430/Yotei+/internal/modules/notification/domain"
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/mocks"
@@ -549,3 +551,446 @@ func TestNotificationUseCase_GetNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationUseCase_CreateNotification_Grouping(t *testing.T) {
+	newInput := func() input.CreateNotificationInput {
+		return input.CreateNotificationInput{
+			UserID:  "user123",
+			Type:    "TASK_ASSIGNED",
+			Title:   "担当タスクが更新されました",
+			Message: "タスク「Task X」が更新されました。",
+			Metadata: map[string]string{
+				"task_id":           "task123",
+				"task_title":        "Task X",
+				"actor_id":          "actor1",
+				"notification_type": "task_updated",
+			},
+			Channels: []string{"app"},
+		}
+	}
+	groupKey := "user123:task_updated:actor1:task123"
+
+	tests := []struct {
+		name          string
+		policy        domain.GroupingPolicy
+		existing      func() *domain.Notification
+		expectLookup  bool
+		expectMerged  bool
+		expectedCount int
+	}{
+		{
+			name:   "merges into recent notification",
+			policy: domain.DefaultGroupingPolicy(),
+			existing: func() *domain.Notification {
+				n := domain.NewNotification("user123", domain.TaskAssigned, "t", "m", newInput().Metadata)
+				n.StartGroup(groupKey, time.Now().Add(-time.Minute))
+				n.AddMetadata(domain.MetadataGroupCount, "2")
+				return n
+			},
+			expectLookup:  true,
+			expectMerged:  true,
+			expectedCount: 3,
+		},
+		{
+			name:   "starts new group when window has passed",
+			policy: domain.DefaultGroupingPolicy(),
+			existing: func() *domain.Notification {
+				n := domain.NewNotification("user123", domain.TaskAssigned, "t", "m", newInput().Metadata)
+				n.StartGroup(groupKey, time.Now().Add(-time.Hour))
+				return n
+			},
+			expectLookup:  true,
+			expectedCount: 1,
+		},
+		{
+			name:          "starts new group when none exists",
+			policy:        domain.DefaultGroupingPolicy(),
+			existing:      func() *domain.Notification { return nil },
+			expectLookup:  true,
+			expectedCount: 1,
+		},
+		{
+			name:          "grouping disabled for event type",
+			policy:        domain.GroupingPolicy{Windows: map[string]time.Duration{"task_updated": 0}},
+			existing:      func() *domain.Notification { return nil },
+			expectedCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockNotificationRepository(ctrl)
+			mockUserValidator := mocks.NewMockUserValidator(ctrl)
+			useCase := NewNotificationUseCaseWithGrouping(
+				mockRepo,
+				mocks.NewMockAppNotificationGateway(ctrl),
+				mocks.NewMockLineNotificationGateway(ctrl),
+				mockUserValidator,
+				tt.policy,
+				*logger.NewLogger(&logger.Config{Level: "error", Output: "console"}),
+			)
+
+			existing := tt.existing()
+			mockUserValidator.EXPECT().UserExists(gomock.Any(), "user123").Return(true, nil)
+			if tt.expectLookup {
+				mockRepo.EXPECT().
+					FindLatestByGroupKey(gomock.Any(), "user123", groupKey).
+					Return(existing, nil)
+			}
+			mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+			n, err := useCase.CreateNotification(context.Background(), newInput())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, n.GroupCount())
+			if tt.expectMerged {
+				assert.Equal(t, existing.ID, n.ID)
+				assert.Equal(t, "「Task X」に3件の更新があります。", n.Message)
+			} else if tt.expectLookup {
+				assert.Equal(t, groupKey, n.Metadata[domain.MetadataGroupKey])
+			}
+		})
+	}
+}
+
+func TestNotificationUseCase_CreateNotification_Template(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	mockUserValidator := mocks.NewMockUserValidator(ctrl)
+	useCase := NewNotificationUseCase(
+		mockRepo,
+		mocks.NewMockAppNotificationGateway(ctrl),
+		mocks.NewMockLineNotificationGateway(ctrl),
+		mockUserValidator,
+		*logger.NewLogger(&logger.Config{Level: "error", Output: "console"}),
+	)
+
+	mockUserValidator.EXPECT().UserExists(gomock.Any(), "user123").Return(true, nil)
+	mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	// テンプレートのあるイベント種別はタイトル・本文を省略できる
+	notification, err := useCase.CreateNotification(context.Background(), input.CreateNotificationInput{
+		UserID: "user123",
+		Type:   "TASK_DUE_SOON",
+		Metadata: map[string]string{
+			"notification_type": "task_overdue",
+			"task_title":        "資料作成",
+			"due_date_display":  "2024-01-01 18:00",
+			"priority":          "HIGH",
+		},
+		Channels: []string{"app"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "⚠️ タスクが期限切れです", notification.Title)
+	assert.Equal(t, "タスク「資料作成」の期限が過ぎています。\n\n期限: 2024-01-01 18:00\n優先度: HIGH", notification.Message)
+
+	// テンプレートのないイベント種別は従来どおりタイトルが必須
+	_, err = useCase.CreateNotification(context.Background(), input.CreateNotificationInput{
+		UserID:   "user123",
+		Type:     "APP_NOTIFICATION",
+		Metadata: map[string]string{"notification_type": "custom"},
+		Channels: []string{"app"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "title is required")
+}
+
+func TestNotificationUseCase_PreviewTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewNotificationUseCase(
+		mocks.NewMockNotificationRepository(ctrl),
+		mocks.NewMockAppNotificationGateway(ctrl),
+		mocks.NewMockLineNotificationGateway(ctrl),
+		mocks.NewMockUserValidator(ctrl),
+		*logger.NewLogger(&logger.Config{Level: "error", Output: "console"}),
+	)
+
+	// 変数を省略した場合はサンプル変数で描画する
+	rendered, err := useCase.PreviewTemplate(context.Background(), "task_updated", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ja", rendered.Locale)
+	assert.Equal(t, "あなたが担当するタスク「資料作成」が更新されました。", rendered.Message)
+
+	rendered, err = useCase.PreviewTemplate(context.Background(), "task_updated", "en", map[string]string{"task_title": "Report"})
+	assert.NoError(t, err)
+	assert.Equal(t, "The task \"Report\" assigned to you has been updated.", rendered.Message)
+
+	_, err = useCase.PreviewTemplate(context.Background(), "unknown", "ja", nil)
+	assert.ErrorIs(t, err, domain.ErrTemplateNotFound)
+
+	assert.NotEmpty(t, useCase.ListTemplates(context.Background()))
+}
+
+// newDeadLetterUseCase は再試行・配信不能の記録を有効にしたユースケースを作成する
+func newDeadLetterUseCase(ctrl *gomock.Controller, opts Options) (*notificationUseCase, *mocks.MockNotificationRepository, *mocks.MockAppNotificationGateway, *mocks.MockLineNotificationGateway) {
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	mockAppGateway := mocks.NewMockAppNotificationGateway(ctrl)
+	mockLineGateway := mocks.NewMockLineNotificationGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+
+	useCase := NewNotificationUseCaseWithOptions(
+		mockRepo,
+		mockAppGateway,
+		mockLineGateway,
+		mocks.NewMockUserValidator(ctrl),
+		opts,
+		mockLogger,
+	).(*notificationUseCase)
+	return useCase, mockRepo, mockAppGateway, mockLineGateway
+}
+
+func TestNotificationUseCase_SendNotification_DeadLetter(t *testing.T) {
+	newNotification := func() *domain.Notification {
+		notification := domain.NewNotification("user123", domain.TaskAssigned, "title", "message", nil)
+		notification.AddChannel(domain.NewLineChannel("user123", "line_user_456", ""))
+		return notification
+	}
+
+	t.Run("retries then dead-letters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, mockRepo, _, mockLineGateway := newDeadLetterUseCase(ctrl, Options{MaxAttempts: 3, DeadLetters: deadLetters})
+		notification := newNotification()
+
+		mockRepo.EXPECT().FindByID(gomock.Any(), notification.ID).Return(notification, nil)
+		mockLineGateway.EXPECT().
+			SendLineNotification(gomock.Any(), "line_user_456", gomock.Any()).
+			Return(errors.New("bad gateway")).
+			Times(3)
+		deadLetters.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, letter *domain.DeadLetter) {
+				assert.Equal(t, notification.ID, letter.NotificationID)
+				assert.Equal(t, domain.LineMessage, letter.Channel)
+				assert.Equal(t, "line_user_456", letter.Target)
+				assert.Equal(t, "bad gateway", letter.Reason)
+				assert.Equal(t, 3, letter.Attempts)
+			}).
+			Return(nil)
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := useCase.SendNotification(context.Background(), notification.ID)
+		assert.Error(t, err)
+		assert.Equal(t, domain.StatusFailed, notification.Status)
+	})
+
+	t.Run("succeeds on retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, mockRepo, _, mockLineGateway := newDeadLetterUseCase(ctrl, Options{MaxAttempts: 3, DeadLetters: deadLetters})
+		notification := newNotification()
+
+		mockRepo.EXPECT().FindByID(gomock.Any(), notification.ID).Return(notification, nil)
+		gomock.InOrder(
+			mockLineGateway.EXPECT().SendLineNotification(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("bad gateway")),
+			mockLineGateway.EXPECT().SendLineNotification(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+		)
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := useCase.SendNotification(context.Background(), notification.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusSent, notification.Status)
+	})
+
+	t.Run("does not retry when circuit is open", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, mockRepo, _, mockLineGateway := newDeadLetterUseCase(ctrl, Options{MaxAttempts: 3, DeadLetters: deadLetters})
+		notification := newNotification()
+
+		mockRepo.EXPECT().FindByID(gomock.Any(), notification.ID).Return(notification, nil)
+		mockLineGateway.EXPECT().
+			SendLineNotification(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("line: %w", circuitbreaker.ErrOpen)).
+			Times(1)
+		deadLetters.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, letter *domain.DeadLetter) {
+				assert.Equal(t, 1, letter.Attempts)
+			}).
+			Return(nil)
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.Error(t, useCase.SendNotification(context.Background(), notification.ID))
+	})
+
+	t.Run("alerts admins when rate crosses threshold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		admins := mocks.NewMockAdminDirectory(ctrl)
+		useCase, mockRepo, mockAppGateway, mockLineGateway := newDeadLetterUseCase(ctrl, Options{
+			MaxAttempts:     1,
+			DeadLetters:     deadLetters,
+			Admins:          admins,
+			DeadLetterAlert: domain.DeadLetterAlertPolicy{Threshold: 0.5, MinDeliveries: 1, Window: time.Minute},
+		})
+		notification := newNotification()
+
+		mockRepo.EXPECT().FindByID(gomock.Any(), notification.ID).Return(notification, nil)
+		mockLineGateway.EXPECT().SendLineNotification(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+		deadLetters.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		admins.EXPECT().ListAdminIDs(gomock.Any()).Return([]string{"admin1", "admin2"}, nil)
+		for _, adminID := range []string{"admin1", "admin2"} {
+			mockAppGateway.EXPECT().
+				SendNotification(gomock.Any(), adminID, gomock.Any(), gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, userID, title, message string, metadata map[string]string) {
+					assert.Equal(t, EventDeadLetterAlert, metadata[domain.MetadataEventType])
+				}).
+				Return(nil)
+		}
+		mockRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.Error(t, useCase.SendNotification(context.Background(), notification.ID))
+	})
+}
+
+func TestNotificationUseCase_RedriveDeadLetter(t *testing.T) {
+	newLetter := func(notification *domain.Notification) *domain.DeadLetter {
+		return domain.NewDeadLetter(notification, domain.NewAppChannel("user123"), "timeout", 3)
+	}
+
+	t.Run("successful redrive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, mockRepo, mockAppGateway, _ := newDeadLetterUseCase(ctrl, Options{MaxAttempts: 1, DeadLetters: deadLetters})
+		notification := domain.NewNotification("user123", domain.TaskAssigned, "title", "message", nil)
+		notification.MarkAsFailed()
+		letter := newLetter(notification)
+
+		deadLetters.EXPECT().FindByID(gomock.Any(), letter.ID).Return(letter, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), notification.ID).Return(notification, nil)
+		mockAppGateway.EXPECT().SendNotification(gomock.Any(), "user123", "title", "message", gomock.Any()).Return(nil)
+		deadLetters.EXPECT().Save(gomock.Any(), letter).Return(nil)
+		mockRepo.EXPECT().
+			Save(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, saved *domain.Notification) {
+				assert.Equal(t, domain.StatusSent, saved.Status)
+			}).
+			Return(nil)
+
+		redriven, err := useCase.RedriveDeadLetter(context.Background(), letter.ID)
+		assert.NoError(t, err)
+		assert.True(t, redriven.IsRedriven())
+		assert.Equal(t, 4, redriven.Attempts)
+	})
+
+	t.Run("failed redrive keeps the dead letter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, mockRepo, mockAppGateway, _ := newDeadLetterUseCase(ctrl, Options{MaxAttempts: 1, DeadLetters: deadLetters})
+		notification := domain.NewNotification("user123", domain.TaskAssigned, "title", "message", nil)
+		letter := newLetter(notification)
+
+		deadLetters.EXPECT().FindByID(gomock.Any(), letter.ID).Return(letter, nil)
+		mockRepo.EXPECT().FindByID(gomock.Any(), notification.ID).Return(notification, nil)
+		mockAppGateway.EXPECT().SendNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("still down"))
+		deadLetters.EXPECT().Save(gomock.Any(), letter).Return(nil)
+
+		redriven, err := useCase.RedriveDeadLetter(context.Background(), letter.ID)
+		assert.ErrorIs(t, err, domain.ErrRedriveFailed)
+		assert.False(t, redriven.IsRedriven())
+		assert.Equal(t, "still down", redriven.Reason)
+	})
+
+	t.Run("dead letter not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, _, _, _ := newDeadLetterUseCase(ctrl, Options{DeadLetters: deadLetters})
+		deadLetters.EXPECT().FindByID(gomock.Any(), "missing").Return(nil, nil)
+
+		_, err := useCase.RedriveDeadLetter(context.Background(), "missing")
+		assert.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+	})
+
+	t.Run("already redriven", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deadLetters := mocks.NewMockDeadLetterRepository(ctrl)
+		useCase, _, _, _ := newDeadLetterUseCase(ctrl, Options{DeadLetters: deadLetters})
+		letter := newLetter(domain.NewNotification("user123", domain.TaskAssigned, "title", "message", nil))
+		letter.MarkRedriven(1)
+		deadLetters.EXPECT().FindByID(gomock.Any(), letter.ID).Return(letter, nil)
+
+		_, err := useCase.RedriveDeadLetter(context.Background(), letter.ID)
+		assert.ErrorIs(t, err, domain.ErrDeadLetterRedriven)
+	})
+}
+
+func TestPreferenceService_GetAndUpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPreferenceRepository(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+	service := NewPreferenceService(mockRepo, mockLogger)
+	ctx := context.Background()
+
+	t.Run("returns defaults when nothing is saved", func(t *testing.T) {
+		mockRepo.EXPECT().GetPreferences(ctx, "user123").Return(nil, nil)
+
+		preferences, err := service.GetPreferences(ctx, "user123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultPreferences("user123"), preferences)
+	})
+
+	t.Run("updates only the given fields", func(t *testing.T) {
+		weekly := domain.DigestWeekly
+		mockRepo.EXPECT().GetPreferences(ctx, "user123").Return(nil, nil)
+		mockRepo.EXPECT().SavePreferences(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, preferences *domain.Preferences) error {
+				assert.Equal(t, domain.DigestWeekly, preferences.SocialDigest)
+				assert.False(t, preferences.UpdatedAt.IsZero())
+				return nil
+			})
+
+		preferences, err := service.UpdatePreferences(ctx, "user123", UpdatePreferencesInput{SocialDigest: &weekly})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DigestWeekly, preferences.SocialDigest)
+	})
+
+	t.Run("rejects unknown digest frequency", func(t *testing.T) {
+		hourly := domain.DigestFrequency("HOURLY")
+		mockRepo.EXPECT().GetPreferences(ctx, "user123").Return(&domain.Preferences{UserID: "user123", SocialDigest: domain.DigestOff}, nil)
+
+		_, err := service.UpdatePreferences(ctx, "user123", UpdatePreferencesInput{SocialDigest: &hourly})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPreferences)
+	})
+
+	t.Run("requires user id", func(t *testing.T) {
+		_, err := service.GetPreferences(ctx, "")
+
+		assert.ErrorIs(t, err, ErrInvalidUserID)
+	})
+}
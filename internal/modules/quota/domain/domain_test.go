@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimits(t *testing.T) {
+	t.Run("parses limits and treats zero as unlimited", func(t *testing.T) {
+		limits, err := ParseLimits(" open_tasks=500, groups=0 ,invitations_per_day=50,")
+		require.NoError(t, err)
+		assert.Equal(t, Limits{"open_tasks": 500, "invitations_per_day": 50}, limits)
+		assert.Equal(t, int64(0), limits.Limit("groups"))
+	})
+
+	t.Run("empty spec means no limits", func(t *testing.T) {
+		limits, err := ParseLimits("")
+		require.NoError(t, err)
+		assert.Empty(t, limits)
+	})
+
+	t.Run("rejects unknown resources and bad values", func(t *testing.T) {
+		for _, spec := range []string{"storage=10", "open_tasks", "open_tasks=-1", "groups=many"} {
+			_, err := ParseLimits(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+}
+
+func TestNewUsage(t *testing.T) {
+	t.Run("unlimited resources have no limit or remaining", func(t *testing.T) {
+		usage := NewUsage("groups", 3, 0)
+		assert.Nil(t, usage.Limit)
+		assert.Nil(t, usage.Remaining)
+	})
+
+	t.Run("remaining never goes below zero", func(t *testing.T) {
+		usage := NewUsage("open_tasks", 12, 10)
+		require.NotNil(t, usage.Limit)
+		assert.Equal(t, int64(10), *usage.Limit)
+		assert.Equal(t, int64(0), *usage.Remaining)
+	})
+}
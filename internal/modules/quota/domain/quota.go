@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+)
+
+// Resources は上限を設けられるリソース（使用量の表示順）
+var Resources = []string{
+	commonDomain.QuotaOpenTasks,
+	commonDomain.QuotaGroups,
+	commonDomain.QuotaAttachmentBytes,
+	commonDomain.QuotaInvitationsPerDay,
+}
+
+// Limits はリソースごとの上限（含まれないリソースは無制限）
+type Limits map[string]int64
+
+// Limit はリソースの上限を返す（0の場合は無制限）
+func (l Limits) Limit(resource string) int64 {
+	return l[resource]
+}
+
+// ParseLimits は "open_tasks=500,groups=20" 形式の設定を解析する
+// 0を指定したリソースは無制限として扱う
+func ParseLimits(spec string) (Limits, error) {
+	limits := make(Limits)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		resource, value, ok := strings.Cut(entry, "=")
+		resource = strings.TrimSpace(resource)
+		if !ok || !IsResource(resource) {
+			return nil, fmt.Errorf("invalid quota entry: %q", entry)
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid quota limit: %q", entry)
+		}
+		if limit > 0 {
+			limits[resource] = limit
+		}
+	}
+	return limits, nil
+}
+
+// IsResource は上限を設けられるリソースか
+func IsResource(resource string) bool {
+	for _, r := range Resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage はリソースの使用量と上限
+type Usage struct {
+	Resource string `json:"resource" example:"open_tasks"`
+	Used     int64  `json:"used" example:"120"`
+	// 上限（nullの場合は無制限）
+	Limit *int64 `json:"limit" example:"500"`
+	// 残り（nullの場合は無制限）
+	Remaining *int64 `json:"remaining" example:"380"`
+} // @name QuotaUsage
+
+// NewUsage は使用量と上限からUsageを作成する（limitが0の場合は無制限）
+func NewUsage(resource string, used, limit int64) Usage {
+	usage := Usage{Resource: resource, Used: used}
+	if limit > 0 {
+		remaining := limit - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		usage.Limit = &limit
+		usage.Remaining = &remaining
+	}
+	return usage
+}
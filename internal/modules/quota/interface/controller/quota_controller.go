@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/quota/domain"
+	"github.com/hryt430/Yotei+/internal/modules/quota/usecase"
+)
+
+// QuotaController は使用量の上限のHTTPリクエストを処理するコントローラー
+type QuotaController struct {
+	quotaService *usecase.QuotaService
+}
+
+// NewQuotaController は新しいQuotaControllerを作成する
+func NewQuotaController(quotaService *usecase.QuotaService) *QuotaController {
+	return &QuotaController{
+		quotaService: quotaService,
+	}
+}
+
+// ErrorResponse は使用量APIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"FORBIDDEN"`
+	Message string `json:"message" example:"not a group member"`
+} // @name QuotaErrorResponse
+
+// UsageResponse は使用量のレスポンス
+type UsageResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    []domain.Usage `json:"data"`
+} // @name QuotaUsageResponse
+
+// GetMyUsage 自分の使用量
+// @Summary      自分の使用量
+// @Description  ログイン中のユーザーのリソースごとの使用量と上限を取得します（limitがnullのリソースは無制限）
+// @Tags         quotas
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} UsageResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /quotas/usage [get]
+func (c *QuotaController) GetMyUsage(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	usages, err := c.quotaService.GetUsage(ctx, commonDomain.QuotaScopeUser, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, UsageResponse{Success: true, Data: usages})
+}
+
+// GetGroupUsage グループの使用量
+// @Summary      グループの使用量
+// @Description  グループのリソースごとの使用量と上限を取得します（グループのメンバーのみ、limitがnullのリソースは無制限）
+// @Tags         quotas
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Security     BearerAuth
+// @Success      200 {object} UsageResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループのメンバーではない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /quotas/usage/groups/{id} [get]
+func (c *QuotaController) GetGroupUsage(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	usages, err := c.quotaService.GetGroupUsage(ctx, ctx.Param("id"), userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, UsageResponse{Success: true, Data: usages})
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecase.ErrNotGroupMember):
+		ctx.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "FORBIDDEN",
+			Message: err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to get quota usage",
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/quota/domain"
+)
+
+// MockUsageCounter is a mock of UsageCounter interface.
+type MockUsageCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageCounterMockRecorder
+}
+
+// MockUsageCounterMockRecorder is the mock recorder for MockUsageCounter.
+type MockUsageCounterMockRecorder struct {
+	mock *MockUsageCounter
+}
+
+// NewMockUsageCounter creates a new mock instance.
+func NewMockUsageCounter(ctrl *gomock.Controller) *MockUsageCounter {
+	mock := &MockUsageCounter{ctrl: ctrl}
+	mock.recorder = &MockUsageCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageCounter) EXPECT() *MockUsageCounterMockRecorder {
+	return m.recorder
+}
+
+// CountUsage mocks base method.
+func (m *MockUsageCounter) CountUsage(ctx context.Context, subjectID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsage", ctx, subjectID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsage indicates an expected call of CountUsage.
+func (mr *MockUsageCounterMockRecorder) CountUsage(ctx, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsage", reflect.TypeOf((*MockUsageCounter)(nil).CountUsage), ctx, subjectID)
+}
+
+// MockGroupMembership is a mock of GroupMembership interface.
+type MockGroupMembership struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupMembershipMockRecorder
+}
+
+// MockGroupMembershipMockRecorder is the mock recorder for MockGroupMembership.
+type MockGroupMembershipMockRecorder struct {
+	mock *MockGroupMembership
+}
+
+// NewMockGroupMembership creates a new mock instance.
+func NewMockGroupMembership(ctrl *gomock.Controller) *MockGroupMembership {
+	mock := &MockGroupMembership{ctrl: ctrl}
+	mock.recorder = &MockGroupMembershipMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupMembership) EXPECT() *MockGroupMembershipMockRecorder {
+	return m.recorder
+}
+
+// IsGroupMember mocks base method.
+func (m *MockGroupMembership) IsGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsGroupMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsGroupMember indicates an expected call of IsGroupMember.
+func (mr *MockGroupMembershipMockRecorder) IsGroupMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGroupMember", reflect.TypeOf((*MockGroupMembership)(nil).IsGroupMember), ctx, groupID, userID)
+}
+
+// MockPlanResolver is a mock of PlanResolver interface.
+type MockPlanResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlanResolverMockRecorder
+}
+
+// MockPlanResolverMockRecorder is the mock recorder for MockPlanResolver.
+type MockPlanResolverMockRecorder struct {
+	mock *MockPlanResolver
+}
+
+// NewMockPlanResolver creates a new mock instance.
+func NewMockPlanResolver(ctrl *gomock.Controller) *MockPlanResolver {
+	mock := &MockPlanResolver{ctrl: ctrl}
+	mock.recorder = &MockPlanResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlanResolver) EXPECT() *MockPlanResolverMockRecorder {
+	return m.recorder
+}
+
+// HasEntitlement mocks base method.
+func (m *MockPlanResolver) HasEntitlement(ctx context.Context, userID, feature string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasEntitlement", ctx, userID, feature)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasEntitlement indicates an expected call of HasEntitlement.
+func (mr *MockPlanResolverMockRecorder) HasEntitlement(ctx, userID, feature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasEntitlement", reflect.TypeOf((*MockPlanResolver)(nil).HasEntitlement), ctx, userID, feature)
+}
+
+// PlanLimits mocks base method.
+func (m *MockPlanResolver) PlanLimits(ctx context.Context, userID string) (domain.Limits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanLimits", ctx, userID)
+	ret0, _ := ret[0].(domain.Limits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanLimits indicates an expected call of PlanLimits.
+func (mr *MockPlanResolverMockRecorder) PlanLimits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanLimits", reflect.TypeOf((*MockPlanResolver)(nil).PlanLimits), ctx, userID)
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/modules/quota/domain"
+)
+
+// UsageCounter はユーザーまたはグループのリソースの使用量を数えるインターフェース
+// 各モジュールのリポジトリへの橋渡しはサーバーの組み立て時に登録する
+type UsageCounter interface {
+	CountUsage(ctx context.Context, subjectID string) (int64, error)
+}
+
+// GroupMembership はグループの使用量を参照できるメンバーかを確認するインターフェース
+type GroupMembership interface {
+	IsGroupMember(ctx context.Context, groupID, userID string) (bool, error)
+}
+
+// PlanResolver はユーザーの契約プランの上限と機能を返すインターフェース
+// 課金モジュールへの橋渡しはサーバーの組み立て時に設定する
+type PlanResolver interface {
+	// PlanLimits はユーザーのプランの上限を返す（nilの場合は設定の上限を使う）
+	PlanLimits(ctx context.Context, userID string) (domain.Limits, error)
+	// HasEntitlement はユーザーのプランでfeatureを利用できるか
+	HasEntitlement(ctx context.Context, userID, feature string) (bool, error)
+}
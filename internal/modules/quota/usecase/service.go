@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/quota/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrNotGroupMember   = errors.New("not a group member")
+)
+
+// QuotaService はユーザー・グループの使用量の上限を確認するサービス
+// 上限は設定（QUOTA_USER_LIMITS・QUOTA_GROUP_LIMITS）で一元管理し、使用量は登録されたUsageCounterで数える
+// Plansを設定した場合、ユーザーの上限は契約プランの上限が優先される
+type QuotaService struct {
+	// グループの使用量を参照するメンバーの確認（nilの場合はグループの使用量を参照できない）
+	Members GroupMembership
+	// ユーザーの契約プラン（nilの場合は設定の上限を使い、全ての機能を利用できる）
+	Plans  PlanResolver
+	Logger logger.Logger
+
+	mu       sync.RWMutex
+	limits   map[string]domain.Limits
+	counters map[string]map[string]UsageCounter
+}
+
+// NewQuotaService はQuotaServiceのコンストラクタ
+func NewQuotaService(userLimits, groupLimits domain.Limits, logger logger.Logger) *QuotaService {
+	s := &QuotaService{
+		Logger:   logger,
+		limits:   make(map[string]domain.Limits),
+		counters: make(map[string]map[string]UsageCounter),
+	}
+	s.SetLimits(commonDomain.QuotaScopeUser, userLimits)
+	s.SetLimits(commonDomain.QuotaScopeGroup, groupLimits)
+	return s
+}
+
+// SetLimits は対象の上限を置き換える（設定の再読み込み用）
+func (s *QuotaService) SetLimits(scope string, limits domain.Limits) {
+	if limits == nil {
+		limits = make(domain.Limits)
+	}
+	s.mu.Lock()
+	s.limits[scope] = limits
+	s.mu.Unlock()
+}
+
+// SetCounter は対象のリソースの使用量を数えるUsageCounterを登録する
+// 登録されていないリソースは上限を確認せず、使用量にも表示しない
+func (s *QuotaService) SetCounter(scope, resource string, counter UsageCounter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counters[scope] == nil {
+		s.counters[scope] = make(map[string]UsageCounter)
+	}
+	s.counters[scope][resource] = counter
+}
+
+// CheckQuota はsubjectIDのresourceをamount増やしても上限を超えないか確認する
+// 使用量を数えられない場合は、障害で操作を止めないよう警告ログを出力して許可する
+func (s *QuotaService) CheckQuota(ctx context.Context, scope, subjectID, resource string, amount int64) error {
+	s.mu.RLock()
+	counter := s.counters[scope][resource]
+	s.mu.RUnlock()
+	if counter == nil {
+		return nil
+	}
+	limit := s.limitsFor(ctx, scope, subjectID).Limit(resource)
+	if limit == 0 {
+		return nil
+	}
+
+	used, err := counter.CountUsage(ctx, subjectID)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to count quota usage, allowing the operation",
+			logger.Any("scope", scope), logger.Any("subject_id", subjectID),
+			logger.Any("resource", resource), logger.Error(err))
+		return nil
+	}
+	if used+amount > limit {
+		return &commonDomain.QuotaExceededError{Scope: scope, Resource: resource, Limit: limit, Used: used}
+	}
+	return nil
+}
+
+// limitsFor はsubjectIDに適用する上限を返す
+// ユーザーの契約プランを取得できない場合は、障害で操作を止めないよう警告ログを出力して設定の上限を使う
+func (s *QuotaService) limitsFor(ctx context.Context, scope, subjectID string) domain.Limits {
+	if scope == commonDomain.QuotaScopeUser && s.Plans != nil {
+		limits, err := s.Plans.PlanLimits(ctx, subjectID)
+		if err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to resolve plan limits, using configured limits",
+				logger.Any("user_id", subjectID), logger.Error(err))
+		} else if limits != nil {
+			return limits
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.limits[scope]
+}
+
+// CheckEntitlement はユーザーの契約プランでfeatureを利用できるか確認する
+// プランを取得できない場合は、障害で操作を止めないよう警告ログを出力して許可する
+func (s *QuotaService) CheckEntitlement(ctx context.Context, userID, feature string) error {
+	if s.Plans == nil {
+		return nil
+	}
+
+	ok, err := s.Plans.HasEntitlement(ctx, userID, feature)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to resolve plan entitlements, allowing the operation",
+			logger.Any("user_id", userID), logger.Any("feature", feature), logger.Error(err))
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s is not included in the current plan", commonDomain.ErrPlanUpgradeRequired, feature)
+	}
+	return nil
+}
+
+// GetUsage はsubjectIDの使用量と上限をリソースごとに取得する
+func (s *QuotaService) GetUsage(ctx context.Context, scope, subjectID string) ([]domain.Usage, error) {
+	if subjectID == "" || (scope != commonDomain.QuotaScopeUser && scope != commonDomain.QuotaScopeGroup) {
+		return nil, ErrInvalidParameter
+	}
+
+	s.mu.RLock()
+	counters := s.counters[scope]
+	s.mu.RUnlock()
+	limits := s.limitsFor(ctx, scope, subjectID)
+
+	usages := make([]domain.Usage, 0, len(counters))
+	for _, resource := range domain.Resources {
+		counter, ok := counters[resource]
+		if !ok {
+			continue
+		}
+		used, err := counter.CountUsage(ctx, subjectID)
+		if err != nil {
+			s.Logger.WithContext(ctx).Error("Failed to count quota usage",
+				logger.Any("scope", scope), logger.Any("subject_id", subjectID),
+				logger.Any("resource", resource), logger.Error(err))
+			return nil, fmt.Errorf("failed to count %s usage: %w", resource, err)
+		}
+		usages = append(usages, domain.NewUsage(resource, used, limits.Limit(resource)))
+	}
+	return usages, nil
+}
+
+// GetGroupUsage はグループの使用量と上限を取得する（グループのメンバーのみ）
+func (s *QuotaService) GetGroupUsage(ctx context.Context, groupID, userID string) ([]domain.Usage, error) {
+	if groupID == "" || userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	if s.Members == nil {
+		return nil, ErrNotGroupMember
+	}
+
+	isMember, err := s.Members.IsGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !isMember {
+		return nil, ErrNotGroupMember
+	}
+	return s.GetUsage(ctx, commonDomain.QuotaScopeGroup, groupID)
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/quota/domain"
+	"github.com/hryt430/Yotei+/internal/modules/quota/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testLogger = *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+
+func newTestService(t *testing.T, userLimits string) (*QuotaService, *mocks.MockUsageCounter) {
+	limits, err := domain.ParseLimits(userLimits)
+	require.NoError(t, err)
+	counter := mocks.NewMockUsageCounter(gomock.NewController(t))
+	service := NewQuotaService(limits, nil, testLogger)
+	service.SetCounter(commonDomain.QuotaScopeUser, commonDomain.QuotaOpenTasks, counter)
+	return service, counter
+}
+
+func TestQuotaService_CheckQuota(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("allows operations within the limit", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(9), nil)
+
+		assert.NoError(t, service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1))
+	})
+
+	t.Run("rejects operations over the limit with a typed error", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(10), nil)
+
+		err := service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1)
+		require.ErrorIs(t, err, commonDomain.ErrQuotaExceeded)
+		var exceeded *commonDomain.QuotaExceededError
+		require.True(t, errors.As(err, &exceeded))
+		assert.Equal(t, commonDomain.QuotaOpenTasks, exceeded.Resource)
+		assert.Equal(t, int64(10), exceeded.Limit)
+		assert.Equal(t, int64(10), exceeded.Used)
+	})
+
+	t.Run("unlimited resources are not counted", func(t *testing.T) {
+		service, _ := newTestService(t, "groups=3")
+
+		assert.NoError(t, service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1))
+	})
+
+	t.Run("counting failures allow the operation", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(0), errors.New("db down"))
+
+		assert.NoError(t, service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1))
+	})
+
+	t.Run("reloaded limits take effect immediately", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(5), nil)
+
+		service.SetLimits(commonDomain.QuotaScopeUser, domain.Limits{commonDomain.QuotaOpenTasks: 5})
+		assert.ErrorIs(t, service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1), commonDomain.ErrQuotaExceeded)
+	})
+}
+
+func TestQuotaService_GetUsage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports registered resources with their limits", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		groups := mocks.NewMockUsageCounter(gomock.NewController(t))
+		service.SetCounter(commonDomain.QuotaScopeUser, commonDomain.QuotaGroups, groups)
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(4), nil)
+		groups.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(2), nil)
+
+		usages, err := service.GetUsage(ctx, commonDomain.QuotaScopeUser, "user-1")
+		require.NoError(t, err)
+		require.Len(t, usages, 2)
+		assert.Equal(t, commonDomain.QuotaOpenTasks, usages[0].Resource)
+		assert.Equal(t, int64(6), *usages[0].Remaining)
+		assert.Equal(t, commonDomain.QuotaGroups, usages[1].Resource)
+		assert.Nil(t, usages[1].Limit)
+	})
+
+	t.Run("returns counting errors", func(t *testing.T) {
+		service, counter := newTestService(t, "")
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(0), errors.New("db down"))
+
+		_, err := service.GetUsage(ctx, commonDomain.QuotaScopeUser, "user-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unknown scopes", func(t *testing.T) {
+		service, _ := newTestService(t, "")
+
+		_, err := service.GetUsage(ctx, "org", "user-1")
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestQuotaService_GetGroupUsage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("members can see the group usage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		members := mocks.NewMockGroupMembership(ctrl)
+		counter := mocks.NewMockUsageCounter(ctrl)
+		service := NewQuotaService(nil, domain.Limits{commonDomain.QuotaOpenTasks: 100}, testLogger)
+		service.Members = members
+		service.SetCounter(commonDomain.QuotaScopeGroup, commonDomain.QuotaOpenTasks, counter)
+		members.EXPECT().IsGroupMember(gomock.Any(), "group-1", "user-1").Return(true, nil)
+		counter.EXPECT().CountUsage(gomock.Any(), "group-1").Return(int64(40), nil)
+
+		usages, err := service.GetGroupUsage(ctx, "group-1", "user-1")
+		require.NoError(t, err)
+		require.Len(t, usages, 1)
+		assert.Equal(t, int64(60), *usages[0].Remaining)
+	})
+
+	t.Run("outsiders are rejected", func(t *testing.T) {
+		members := mocks.NewMockGroupMembership(gomock.NewController(t))
+		service := NewQuotaService(nil, nil, testLogger)
+		service.Members = members
+		members.EXPECT().IsGroupMember(gomock.Any(), "group-1", "user-2").Return(false, nil)
+
+		_, err := service.GetGroupUsage(ctx, "group-1", "user-2")
+		assert.ErrorIs(t, err, ErrNotGroupMember)
+	})
+}
+
+func TestQuotaService_PlanLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("plan limits replace the configured user limits", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		plans := mocks.NewMockPlanResolver(gomock.NewController(t))
+		service.Plans = plans
+		plans.EXPECT().PlanLimits(gomock.Any(), "user-1").Return(domain.Limits{commonDomain.QuotaOpenTasks: 3}, nil)
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(3), nil)
+
+		assert.ErrorIs(t, service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1), commonDomain.ErrQuotaExceeded)
+	})
+
+	t.Run("configured limits are used when the plan cannot be resolved", func(t *testing.T) {
+		service, counter := newTestService(t, "open_tasks=10")
+		plans := mocks.NewMockPlanResolver(gomock.NewController(t))
+		service.Plans = plans
+		plans.EXPECT().PlanLimits(gomock.Any(), "user-1").Return(nil, errors.New("db down"))
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(3), nil)
+
+		assert.NoError(t, service.CheckQuota(ctx, commonDomain.QuotaScopeUser, "user-1", commonDomain.QuotaOpenTasks, 1))
+	})
+
+	t.Run("group limits ignore plans", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		counter := mocks.NewMockUsageCounter(ctrl)
+		service := NewQuotaService(nil, domain.Limits{commonDomain.QuotaOpenTasks: 5}, testLogger)
+		service.Plans = mocks.NewMockPlanResolver(ctrl)
+		service.SetCounter(commonDomain.QuotaScopeGroup, commonDomain.QuotaOpenTasks, counter)
+		counter.EXPECT().CountUsage(gomock.Any(), "group-1").Return(int64(5), nil)
+
+		assert.ErrorIs(t, service.CheckQuota(ctx, commonDomain.QuotaScopeGroup, "group-1", commonDomain.QuotaOpenTasks, 1), commonDomain.ErrQuotaExceeded)
+	})
+
+	t.Run("usage reports the plan limits", func(t *testing.T) {
+		service, counter := newTestService(t, "")
+		plans := mocks.NewMockPlanResolver(gomock.NewController(t))
+		service.Plans = plans
+		plans.EXPECT().PlanLimits(gomock.Any(), "user-1").Return(domain.Limits{commonDomain.QuotaOpenTasks: 100}, nil)
+		counter.EXPECT().CountUsage(gomock.Any(), "user-1").Return(int64(40), nil)
+
+		usages, err := service.GetUsage(ctx, commonDomain.QuotaScopeUser, "user-1")
+		require.NoError(t, err)
+		require.Len(t, usages, 1)
+		assert.Equal(t, int64(60), *usages[0].Remaining)
+	})
+}
+
+func TestQuotaService_CheckEntitlement(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every feature is available without plans", func(t *testing.T) {
+		service := NewQuotaService(nil, nil, testLogger)
+
+		assert.NoError(t, service.CheckEntitlement(ctx, "user-1", commonDomain.EntitlementShareLinks))
+	})
+
+	t.Run("features outside the plan require an upgrade", func(t *testing.T) {
+		plans := mocks.NewMockPlanResolver(gomock.NewController(t))
+		service := NewQuotaService(nil, nil, testLogger)
+		service.Plans = plans
+		plans.EXPECT().HasEntitlement(gomock.Any(), "user-1", commonDomain.EntitlementShareLinks).Return(false, nil)
+
+		assert.ErrorIs(t, service.CheckEntitlement(ctx, "user-1", commonDomain.EntitlementShareLinks), commonDomain.ErrPlanUpgradeRequired)
+	})
+
+	t.Run("plan lookup failures allow the operation", func(t *testing.T) {
+		plans := mocks.NewMockPlanResolver(gomock.NewController(t))
+		service := NewQuotaService(nil, nil, testLogger)
+		service.Plans = plans
+		plans.EXPECT().HasEntitlement(gomock.Any(), "user-1", commonDomain.EntitlementShareLinks).Return(false, errors.New("db down"))
+
+		assert.NoError(t, service.CheckEntitlement(ctx, "user-1", commonDomain.EntitlementShareLinks))
+	})
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// DirectoryUser はSCIMで管理するYotei+のユーザー
+type DirectoryUser struct {
+	ID        string
+	Username  string
+	Email     string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DirectoryGroup はSCIMのグループに対応するYotei+のグループ
+type DirectoryGroup struct {
+	ID        string
+	Name      string
+	OwnerID   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter(`userName eq "taro@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, &Filter{Attribute: "username", Value: "taro@example.com"}, f)
+
+	f, err = ParseFilter(`emails.value EQ "a\"b"`)
+	require.NoError(t, err)
+	assert.Equal(t, "emails.value", f.Attribute)
+	assert.Equal(t, `a"b`, f.Value)
+
+	f, err = ParseFilter("  ")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = ParseFilter(`userName sw "taro"`)
+	var scimErr *Error
+	require.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, http.StatusBadRequest, scimErr.StatusCode())
+	assert.Equal(t, ScimTypeInvalidFilter, scimErr.ScimType)
+}
+
+func TestParseMemberPath(t *testing.T) {
+	id, ok := ParseMemberPath("members")
+	assert.True(t, ok)
+	assert.Empty(t, id)
+
+	id, ok = ParseMemberPath(`members[value eq "user-1"]`)
+	assert.True(t, ok)
+	assert.Equal(t, "user-1", id)
+
+	_, ok = ParseMemberPath(`members[display eq "taro"]`)
+	assert.False(t, ok)
+
+	_, ok = ParseMemberPath("externalId")
+	assert.False(t, ok)
+}
+
+func TestPatchRequest_Validate(t *testing.T) {
+	req := &PatchRequest{
+		Schemas:    []string{SchemaPatchOp},
+		Operations: []PatchOperation{{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)}},
+	}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, PatchOpReplace, req.Operations[0].Op)
+
+	active, err := req.Operations[0].DecodeBool()
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	assert.Error(t, (&PatchRequest{Operations: req.Operations}).Validate(), "schema is required")
+	assert.Error(t, (&PatchRequest{Schemas: []string{SchemaPatchOp}}).Validate(), "operations are required")
+	assert.Error(t, (&PatchRequest{
+		Schemas:    []string{SchemaPatchOp},
+		Operations: []PatchOperation{{Op: "move"}},
+	}).Validate())
+}
+
+func TestUser_PrimaryEmail(t *testing.T) {
+	user := &User{UserName: "taro", Emails: []Email{{Value: "home@example.com"}, {Value: "work@example.com", Primary: true}}}
+	assert.Equal(t, "work@example.com", user.PrimaryEmail())
+
+	user = &User{UserName: "taro", Emails: []Email{{Value: "home@example.com"}}}
+	assert.Equal(t, "home@example.com", user.PrimaryEmail())
+
+	assert.Equal(t, "taro@example.com", (&User{UserName: "taro@example.com"}).PrimaryEmail())
+	assert.Empty(t, (&User{UserName: "taro"}).PrimaryEmail())
+}
+
+func TestGroup_MemberIDs(t *testing.T) {
+	group := &Group{Members: []Member{{Value: "a"}, {Value: ""}, {Value: "b"}, {Value: "a"}}}
+	assert.Equal(t, []string{"a", "b"}, group.MemberIDs())
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filterPattern は対応するフィルター（attribute eq "value"）
+var filterPattern = regexp.MustCompile(`(?i)^\s*([a-z][a-z0-9._]*)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// Filter は一覧のフィルター（attribute eq "value" のみ対応する）
+type Filter struct {
+	// 属性名（小文字に正規化する。例: username, emails.value, displayname）
+	Attribute string
+	Value     string
+}
+
+// ParseFilter はフィルターを解析する（空の場合はnil, nil）
+// IdPがユーザー・グループの存在確認に使う単純な一致のみ対応し、それ以外は400（invalidFilter）を返す
+func ParseFilter(filter string) (*Filter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	matches := filterPattern.FindStringSubmatch(filter)
+	if matches == nil {
+		return nil, Errorf(http.StatusBadRequest, ScimTypeInvalidFilter, "unsupported filter: %s", filter)
+	}
+	value, err := strconv.Unquote(matches[2])
+	if err != nil {
+		return nil, Errorf(http.StatusBadRequest, ScimTypeInvalidFilter, "invalid filter value: %s", matches[2])
+	}
+	return &Filter{Attribute: strings.ToLower(matches[1]), Value: value}, nil
+}
+
+// memberPathPattern はメンバーを指定するパス（members[value eq "id"]）
+var memberPathPattern = regexp.MustCompile(`(?i)^members\[(.+)\]$`)
+
+// ParseMemberPath はmembers[value eq "id"] 形式のパスからユーザーIDを取り出す
+// パスがmembersのみの場合は空文字とtrueを返す
+func ParseMemberPath(path string) (string, bool) {
+	path = strings.TrimSpace(path)
+	if strings.EqualFold(path, "members") {
+		return "", true
+	}
+	matches := memberPathPattern.FindStringSubmatch(path)
+	if matches == nil {
+		return "", false
+	}
+	filter, err := ParseFilter(matches[1])
+	if err != nil || filter == nil || filter.Attribute != "value" {
+		return "", false
+	}
+	return filter.Value, true
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// PATCHの操作
+const (
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+	PatchOpReplace = "replace"
+)
+
+// PatchRequest はPATCHのリクエスト（RFC 7644 3.5.2）
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+} // @name SCIMPatchRequest
+
+// PatchOperation はPATCHの操作
+// opは大文字小文字を区別しない（Azure ADは"Replace"のように送る）
+type PatchOperation struct {
+	Op    string          `json:"op" example:"replace"`
+	Path  string          `json:"path,omitempty" example:"active"`
+	Value json.RawMessage `json:"value,omitempty" swaggertype:"object"`
+} // @name SCIMPatchOperation
+
+// Validate はスキーマと操作を検証し、opを小文字に正規化する
+func (r *PatchRequest) Validate() error {
+	hasSchema := false
+	for _, schema := range r.Schemas {
+		if schema == SchemaPatchOp {
+			hasSchema = true
+		}
+	}
+	if !hasSchema {
+		return Errorf(http.StatusBadRequest, ScimTypeInvalidSyntax, "schemas must contain %s", SchemaPatchOp)
+	}
+	if len(r.Operations) == 0 {
+		return NewError(http.StatusBadRequest, ScimTypeInvalidSyntax, "Operations is required")
+	}
+	for i := range r.Operations {
+		op := strings.ToLower(r.Operations[i].Op)
+		switch op {
+		case PatchOpAdd, PatchOpRemove, PatchOpReplace:
+			r.Operations[i].Op = op
+		default:
+			return Errorf(http.StatusBadRequest, ScimTypeInvalidSyntax, "unsupported op: %s", r.Operations[i].Op)
+		}
+	}
+	return nil
+}
+
+// DecodeValue は操作のvalueをvに読み込む
+func (o PatchOperation) DecodeValue(v interface{}) error {
+	if len(o.Value) == 0 {
+		return Errorf(http.StatusBadRequest, ScimTypeInvalidValue, "value is required for %s %s", o.Op, o.Path)
+	}
+	if err := json.Unmarshal(o.Value, v); err != nil {
+		return Errorf(http.StatusBadRequest, ScimTypeInvalidValue, "invalid value for %s %s", o.Op, o.Path)
+	}
+	return nil
+}
+
+// DecodeBool はactiveなどの真偽値を読み込む（Azure ADは"False"のように文字列で送る）
+func (o PatchOperation) DecodeBool() (bool, error) {
+	var b bool
+	if err := json.Unmarshal(o.Value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(o.Value, &s); err == nil {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, Errorf(http.StatusBadRequest, ScimTypeInvalidValue, "%s must be a boolean", o.Path)
+}
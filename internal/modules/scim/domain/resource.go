@@ -0,0 +1,210 @@
+// Package domain はSCIM 2.0（RFC 7643・RFC 7644）のリソースとメッセージを定義します
+//
+// Yotei+はuserName・プライマリのメールアドレス・activeのみを保存します。
+// name・displayNameなど保存しない属性は受け付けますが、レスポンスには返しません。
+package domain
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ContentType はSCIMのリクエスト・レスポンスのContent-Type
+	ContentType = "application/scim+json"
+	// BasePath はSCIMのエンドポイントのパス（meta.locationに使う）
+	BasePath = "/scim/v2"
+)
+
+// スキーマURN
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// リソースの種類（meta.resourceType）
+const (
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// Meta はリソースのメタデータ
+type Meta struct {
+	ResourceType string    `json:"resourceType" example:"User"`
+	Created      time.Time `json:"created" example:"2024-06-01T09:00:00Z"`
+	LastModified time.Time `json:"lastModified" example:"2024-06-01T09:00:00Z"`
+	Location     string    `json:"location,omitempty" example:"/scim/v2/Users/123e4567-e89b-12d3-a456-426614174000"`
+} // @name SCIMMeta
+
+// Name はユーザーの氏名（保存しない）
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+} // @name SCIMName
+
+// Email はユーザーのメールアドレス
+type Email struct {
+	Value   string `json:"value" example:"taro@example.com"`
+	Type    string `json:"type,omitempty" example:"work"`
+	Primary bool   `json:"primary,omitempty" example:"true"`
+} // @name SCIMEmail
+
+// GroupRef はユーザーが所属するグループ
+type GroupRef struct {
+	Value   string `json:"value" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Display string `json:"display,omitempty" example:"Engineering"`
+} // @name SCIMGroupRef
+
+// User はSCIMのUserリソース
+type User struct {
+	Schemas    []string `json:"schemas"`
+	ID         string   `json:"id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ExternalID string   `json:"externalId,omitempty" example:"00u1abcd"`
+	UserName   string   `json:"userName" example:"taro"`
+	Name       *Name    `json:"name,omitempty"`
+	Emails     []Email  `json:"emails,omitempty"`
+	// 省略した場合は有効（作成時）・変更しない（PUT）
+	Active *bool `json:"active,omitempty" example:"true"`
+	// 作成時のみ使用する（省略した場合はランダムなパスワードを設定する）。レスポンスには返さない
+	Password string     `json:"password,omitempty"`
+	Groups   []GroupRef `json:"groups,omitempty"`
+	Meta     *Meta      `json:"meta,omitempty"`
+} // @name SCIMUser
+
+// PrimaryEmail はプライマリのメールアドレスを返す
+// プライマリの指定がない場合は最初のメールアドレス、メールアドレスがない場合はメールアドレス形式のuserNameを使う
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary && email.Value != "" {
+			return email.Value
+		}
+	}
+	for _, email := range u.Emails {
+		if email.Value != "" {
+			return email.Value
+		}
+	}
+	if strings.Contains(u.UserName, "@") {
+		return u.UserName
+	}
+	return ""
+}
+
+// IsActive はactiveを返す（省略した場合は有効）
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Member はグループのメンバー（valueはYotei+のユーザーID）
+type Member struct {
+	Value   string `json:"value" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Display string `json:"display,omitempty" example:"taro"`
+} // @name SCIMMember
+
+// Group はSCIMのGroupリソース（Yotei+のプロジェクトグループに対応する）
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ExternalID  string   `json:"externalId,omitempty" example:"00g1abcd"`
+	DisplayName string   `json:"displayName" example:"Engineering"`
+	Members     []Member `json:"members"`
+	Meta        *Meta    `json:"meta,omitempty"`
+} // @name SCIMGroup
+
+// MemberIDs はメンバーのユーザーIDを重複を除いて返す
+func (g *Group) MemberIDs() []string {
+	return uniqueMemberIDs(g.Members)
+}
+
+// ListResponse は一覧のレスポンス
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults" example:"1"`
+	StartIndex   int         `json:"startIndex" example:"1"`
+	ItemsPerPage int         `json:"itemsPerPage" example:"1"`
+	Resources    interface{} `json:"Resources"`
+} // @name SCIMListResponse
+
+// NewListResponse は一覧のレスポンスを作成する（startIndexは1始まり）
+func NewListResponse(resources interface{}, total, startIndex, itemsPerPage int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+// scimTypeの値（RFC 7644 3.12）
+const (
+	ScimTypeInvalidFilter = "invalidFilter"
+	ScimTypeUniqueness    = "uniqueness"
+	ScimTypeInvalidValue  = "invalidValue"
+	ScimTypeInvalidPath   = "invalidPath"
+	ScimTypeNoTarget      = "noTarget"
+	ScimTypeInvalidSyntax = "invalidSyntax"
+	ScimTypeMutability    = "mutability"
+)
+
+// Error はSCIMのエラーレスポンス（statusは文字列で返す）
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status" example:"409"`
+	ScimType string   `json:"scimType,omitempty" example:"uniqueness"`
+	Detail   string   `json:"detail,omitempty" example:"userName already exists"`
+} // @name SCIMError
+
+// NewError はSCIMのエラーを作成する
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// Errorf はdetailを書式指定してSCIMのエラーを作成する
+func Errorf(status int, scimType, format string, args ...interface{}) *Error {
+	return NewError(status, scimType, fmt.Sprintf(format, args...))
+}
+
+// ErrNotFound はリソースが見つからないエラーを作成する
+func ErrNotFound(resourceType, id string) *Error {
+	return Errorf(http.StatusNotFound, "", "%s %s not found", resourceType, id)
+}
+
+func (e *Error) Error() string {
+	return e.Status + " " + e.ScimType + ": " + e.Detail
+}
+
+// StatusCode はHTTPステータスコードを返す
+func (e *Error) StatusCode() int {
+	status, err := strconv.Atoi(e.Status)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+func uniqueMemberIDs(members []Member) []string {
+	seen := make(map[string]bool, len(members))
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.Value == "" || seen[member.Value] {
+			continue
+		}
+		seen[member.Value] = true
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
@@ -0,0 +1,402 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/scim/domain"
+	"github.com/hryt430/Yotei+/internal/modules/scim/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ActorContextKey はSCIMの操作を行う管理者（APIキーを発行した管理者）のユーザーIDを格納するgin.Contextのキー
+const ActorContextKey = "scim_actor"
+
+// SCIMController はSCIM 2.0のプロビジョニングAPIのHTTPリクエストを処理するコントローラー
+type SCIMController struct {
+	provisioningService *usecase.ProvisioningService
+	logger              logger.Logger
+}
+
+// NewSCIMController は新しいSCIMControllerを作成する
+func NewSCIMController(provisioningService *usecase.ProvisioningService, logger logger.Logger) *SCIMController {
+	return &SCIMController{
+		provisioningService: provisioningService,
+		logger:              logger,
+	}
+}
+
+// AbortWithError はSCIMのエラー形式で認証エラーなどを返してリクエストを中断する
+func AbortWithError(ctx *gin.Context, status int, message string) {
+	ctx.Header("Content-Type", domain.ContentType)
+	ctx.AbortWithStatusJSON(status, domain.NewError(status, "", message))
+}
+
+// serviceProviderConfig はSCIMの対応機能
+var serviceProviderConfig = gin.H{
+	"schemas":          []string{domain.SchemaServiceProviderConfig},
+	"documentationUri": "https://github.com/hryt430/Yotei-Plus#scim-provisioning",
+	"patch":            gin.H{"supported": true},
+	"bulk":             gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+	"filter":           gin.H{"supported": true, "maxResults": usecase.MaxCount},
+	"changePassword":   gin.H{"supported": false},
+	"sort":             gin.H{"supported": false},
+	"etag":             gin.H{"supported": false},
+	"authenticationSchemes": []gin.H{{
+		"type":        "oauthbearertoken",
+		"name":        "API key",
+		"description": "Authorization: Bearer <scimスコープのAPIキー>",
+		"primary":     true,
+	}},
+	"meta": gin.H{"resourceType": "ServiceProviderConfig", "location": domain.BasePath + "/ServiceProviderConfig"},
+}
+
+// GetServiceProviderConfig SCIMの対応機能
+// @Summary      SCIMの対応機能
+// @Description  SCIM 2.0の対応機能を返します（PATCH・eqのフィルターに対応し、bulk・sort・etagには対応しません）
+// @Tags         scim
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{} "対応機能"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Router       /scim/v2/ServiceProviderConfig [get]
+func (c *SCIMController) GetServiceProviderConfig(ctx *gin.Context) {
+	c.respond(ctx, http.StatusOK, serviceProviderConfig)
+}
+
+// ListUsers ユーザー一覧
+// @Summary      ユーザー一覧（SCIM）
+// @Description  ユーザーを取得します。filterはuserName・emails.value・externalIdのeqに対応します（externalIdは保存しないため常に0件）
+// @Tags         scim
+// @Produce      json
+// @Param        filter query string false "フィルター" example(userName eq "taro")
+// @Param        startIndex query int false "開始位置（1始まり）" default(1)
+// @Param        count query int false "件数（上限1000）" default(100)
+// @Security     BearerAuth
+// @Success      200 {object} domain.ListResponse "取得成功"
+// @Failure      400 {object} domain.Error "フィルターが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Users [get]
+func (c *SCIMController) ListUsers(ctx *gin.Context) {
+	startIndex, count := pagination(ctx)
+	list, err := c.provisioningService.ListUsers(ctx, ctx.Query("filter"), startIndex, count)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, list)
+}
+
+// CreateUser ユーザーの作成
+// @Summary      ユーザーの作成（SCIM）
+// @Description  メールアドレスを確認済みのユーザーを作成します。メールアドレスはemailsのプライマリ（なければメールアドレス形式のuserName）を使います。passwordを省略した場合はランダムなパスワードを設定します
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        request body SCIMUser true "ユーザー"
+// @Security     BearerAuth
+// @Success      201 {object} SCIMUser "作成成功"
+// @Failure      400 {object} domain.Error "リクエストが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      409 {object} domain.Error "userNameまたはメールアドレスが使用済み"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Users [post]
+func (c *SCIMController) CreateUser(ctx *gin.Context) {
+	var req domain.User
+	if !c.bind(ctx, &req) {
+		return
+	}
+	user, err := c.provisioningService.CreateUser(ctx, &req)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusCreated, user)
+}
+
+// GetUser ユーザーの取得
+// @Summary      ユーザーの取得（SCIM）
+// @Tags         scim
+// @Produce      json
+// @Param        id path string true "ユーザーID"
+// @Security     BearerAuth
+// @Success      200 {object} SCIMUser "取得成功"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "ユーザーが見つからない"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Users/{id} [get]
+func (c *SCIMController) GetUser(ctx *gin.Context) {
+	user, err := c.provisioningService.GetUser(ctx, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, user)
+}
+
+// ReplaceUser ユーザーの置き換え
+// @Summary      ユーザーの置き換え（SCIM）
+// @Description  userNameとメールアドレスを置き換えます。active=falseでアカウントを無効化し、activeを省略した場合は変更しません
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ユーザーID"
+// @Param        request body SCIMUser true "ユーザー"
+// @Security     BearerAuth
+// @Success      200 {object} SCIMUser "更新成功"
+// @Failure      400 {object} domain.Error "リクエストが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "ユーザーが見つからない"
+// @Failure      409 {object} domain.Error "userNameまたはメールアドレスが使用済み"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Users/{id} [put]
+func (c *SCIMController) ReplaceUser(ctx *gin.Context) {
+	var req domain.User
+	if !c.bind(ctx, &req) {
+		return
+	}
+	user, err := c.provisioningService.ReplaceUser(ctx, ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, user)
+}
+
+// PatchUser ユーザーの部分更新
+// @Summary      ユーザーの部分更新（SCIM）
+// @Description  active・userName・emailsを更新します。active=falseでアカウントを無効化します（ログイン・トークンの更新ができなくなります）。保存しない属性（nameなど）の操作は無視します
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ユーザーID"
+// @Param        request body domain.PatchRequest true "PATCHの操作"
+// @Security     BearerAuth
+// @Success      200 {object} SCIMUser "更新成功"
+// @Failure      400 {object} domain.Error "リクエストが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "ユーザーが見つからない"
+// @Failure      409 {object} domain.Error "userNameまたはメールアドレスが使用済み"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Users/{id} [patch]
+func (c *SCIMController) PatchUser(ctx *gin.Context) {
+	var req domain.PatchRequest
+	if !c.bind(ctx, &req) {
+		return
+	}
+	user, err := c.provisioningService.PatchUser(ctx, ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, user)
+}
+
+// DeleteUser ユーザーの無効化
+// @Summary      ユーザーの無効化（SCIM）
+// @Description  アカウントを無効化します。タスクなどのデータを残すためユーザーは削除せず、以降はactive=falseで返します
+// @Tags         scim
+// @Produce      json
+// @Param        id path string true "ユーザーID"
+// @Security     BearerAuth
+// @Success      204 "無効化成功"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "ユーザーが見つからない"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Users/{id} [delete]
+func (c *SCIMController) DeleteUser(ctx *gin.Context) {
+	if err := c.provisioningService.DeactivateUser(ctx, ctx.Param("id")); err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListGroups グループ一覧
+// @Summary      グループ一覧（SCIM）
+// @Description  APIキーを発行した管理者がオーナーのグループを取得します。filterはdisplayName・externalIdのeqに対応します。グループのオーナーはmembersに含めません
+// @Tags         scim
+// @Produce      json
+// @Param        filter query string false "フィルター" example(displayName eq "Engineering")
+// @Param        startIndex query int false "開始位置（1始まり）" default(1)
+// @Param        count query int false "件数（上限1000）" default(100)
+// @Security     BearerAuth
+// @Success      200 {object} domain.ListResponse "取得成功"
+// @Failure      400 {object} domain.Error "フィルターが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Groups [get]
+func (c *SCIMController) ListGroups(ctx *gin.Context) {
+	startIndex, count := pagination(ctx)
+	list, err := c.provisioningService.ListGroups(ctx, ctx.GetString(ActorContextKey), ctx.Query("filter"), startIndex, count)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, list)
+}
+
+// CreateGroup グループの作成
+// @Summary      グループの作成（SCIM）
+// @Description  APIキーを発行した管理者をオーナーとするプロジェクトグループを作成し、membersのユーザーをメンバーとして追加します
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        request body SCIMGroup true "グループ"
+// @Security     BearerAuth
+// @Success      201 {object} SCIMGroup "作成成功"
+// @Failure      400 {object} domain.Error "リクエストが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      409 {object} domain.Error "displayNameが使用済み"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Groups [post]
+func (c *SCIMController) CreateGroup(ctx *gin.Context) {
+	var req domain.Group
+	if !c.bind(ctx, &req) {
+		return
+	}
+	group, err := c.provisioningService.CreateGroup(ctx, ctx.GetString(ActorContextKey), &req)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusCreated, group)
+}
+
+// GetGroup グループの取得
+// @Summary      グループの取得（SCIM）
+// @Tags         scim
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Security     BearerAuth
+// @Success      200 {object} SCIMGroup "取得成功"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "グループが見つからない"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Groups/{id} [get]
+func (c *SCIMController) GetGroup(ctx *gin.Context) {
+	group, err := c.provisioningService.GetGroup(ctx, ctx.GetString(ActorContextKey), ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, group)
+}
+
+// ReplaceGroup グループの置き換え
+// @Summary      グループの置き換え（SCIM）
+// @Description  グループ名を変更し、オーナー以外のメンバーをmembersと一致させます
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Param        request body SCIMGroup true "グループ"
+// @Security     BearerAuth
+// @Success      200 {object} SCIMGroup "更新成功"
+// @Failure      400 {object} domain.Error "リクエストが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "グループが見つからない"
+// @Failure      409 {object} domain.Error "displayNameが使用済み"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Groups/{id} [put]
+func (c *SCIMController) ReplaceGroup(ctx *gin.Context) {
+	var req domain.Group
+	if !c.bind(ctx, &req) {
+		return
+	}
+	group, err := c.provisioningService.ReplaceGroup(ctx, ctx.GetString(ActorContextKey), ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, group)
+}
+
+// PatchGroup グループの部分更新
+// @Summary      グループの部分更新（SCIM）
+// @Description  displayNameの変更と、メンバーの追加（add members）・削除（remove members[value eq "id"]）・置き換え（replace members）を行います
+// @Tags         scim
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Param        request body domain.PatchRequest true "PATCHの操作"
+// @Security     BearerAuth
+// @Success      200 {object} SCIMGroup "更新成功"
+// @Failure      400 {object} domain.Error "リクエストが無効"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "グループが見つからない"
+// @Failure      409 {object} domain.Error "displayNameが使用済み"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Groups/{id} [patch]
+func (c *SCIMController) PatchGroup(ctx *gin.Context) {
+	var req domain.PatchRequest
+	if !c.bind(ctx, &req) {
+		return
+	}
+	group, err := c.provisioningService.PatchGroup(ctx, ctx.GetString(ActorContextKey), ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	c.respond(ctx, http.StatusOK, group)
+}
+
+// DeleteGroup グループの削除
+// @Summary      グループの削除（SCIM）
+// @Tags         scim
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Security     BearerAuth
+// @Success      204 "削除成功"
+// @Failure      401 {object} domain.Error "APIキーが無効"
+// @Failure      404 {object} domain.Error "グループが見つからない"
+// @Failure      500 {object} domain.Error "内部サーバーエラー"
+// @Router       /scim/v2/Groups/{id} [delete]
+func (c *SCIMController) DeleteGroup(ctx *gin.Context) {
+	if err := c.provisioningService.DeleteGroup(ctx, ctx.GetString(ActorContextKey), ctx.Param("id")); err != nil {
+		c.respondError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// bind はリクエストボディを読み込む（Content-Typeはapplication/scim+jsonとapplication/jsonの両方を受け付ける）
+func (c *SCIMController) bind(ctx *gin.Context, v interface{}) bool {
+	if err := json.NewDecoder(ctx.Request.Body).Decode(v); err != nil {
+		c.respond(ctx, http.StatusBadRequest, domain.NewError(http.StatusBadRequest, domain.ScimTypeInvalidSyntax, "invalid JSON body"))
+		return false
+	}
+	return true
+}
+
+func (c *SCIMController) respond(ctx *gin.Context, status int, body interface{}) {
+	ctx.Header("Content-Type", domain.ContentType)
+	ctx.JSON(status, body)
+}
+
+func (c *SCIMController) respondError(ctx *gin.Context, err error) {
+	var scimErr *domain.Error
+	if errors.As(err, &scimErr) {
+		c.respond(ctx, scimErr.StatusCode(), scimErr)
+		return
+	}
+	c.logger.Error("SCIM request failed", logger.Error(err))
+	c.respond(ctx, http.StatusInternalServerError, domain.NewError(http.StatusInternalServerError, "", "internal server error"))
+}
+
+// pagination はstartIndexとcountを読み込む（不正な値は既定値にする）
+func pagination(ctx *gin.Context) (startIndex, count int) {
+	startIndex, err := strconv.Atoi(ctx.DefaultQuery("startIndex", "1"))
+	if err != nil {
+		startIndex = 1
+	}
+	count, err = strconv.Atoi(ctx.DefaultQuery("count", strconv.Itoa(usecase.DefaultCount)))
+	if err != nil {
+		count = usecase.DefaultCount
+	}
+	return startIndex, count
+}
@@ -0,0 +1,264 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/scim/domain"
+)
+
+// MockUserDirectory is a mock of UserDirectory interface.
+type MockUserDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDirectoryMockRecorder
+}
+
+// MockUserDirectoryMockRecorder is the mock recorder for MockUserDirectory.
+type MockUserDirectoryMockRecorder struct {
+	mock *MockUserDirectory
+}
+
+// NewMockUserDirectory creates a new mock instance.
+func NewMockUserDirectory(ctrl *gomock.Controller) *MockUserDirectory {
+	mock := &MockUserDirectory{ctrl: ctrl}
+	mock.recorder = &MockUserDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDirectory) EXPECT() *MockUserDirectoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserDirectory) CreateUser(ctx context.Context, username, email, password string) (*domain.DirectoryUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, username, email, password)
+	ret0, _ := ret[0].(*domain.DirectoryUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserDirectoryMockRecorder) CreateUser(ctx, username, email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserDirectory)(nil).CreateUser), ctx, username, email, password)
+}
+
+// FindUserByEmail mocks base method.
+func (m *MockUserDirectory) FindUserByEmail(ctx context.Context, email string) (*domain.DirectoryUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.DirectoryUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByEmail indicates an expected call of FindUserByEmail.
+func (mr *MockUserDirectoryMockRecorder) FindUserByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByEmail", reflect.TypeOf((*MockUserDirectory)(nil).FindUserByEmail), ctx, email)
+}
+
+// FindUserByUsername mocks base method.
+func (m *MockUserDirectory) FindUserByUsername(ctx context.Context, username string) (*domain.DirectoryUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*domain.DirectoryUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByUsername indicates an expected call of FindUserByUsername.
+func (mr *MockUserDirectoryMockRecorder) FindUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByUsername", reflect.TypeOf((*MockUserDirectory)(nil).FindUserByUsername), ctx, username)
+}
+
+// GetUser mocks base method.
+func (m *MockUserDirectory) GetUser(ctx context.Context, id string) (*domain.DirectoryUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*domain.DirectoryUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserDirectoryMockRecorder) GetUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserDirectory)(nil).GetUser), ctx, id)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserDirectory) ListUsers(ctx context.Context) ([]*domain.DirectoryUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx)
+	ret0, _ := ret[0].([]*domain.DirectoryUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserDirectoryMockRecorder) ListUsers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserDirectory)(nil).ListUsers), ctx)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserDirectory) UpdateUser(ctx context.Context, user *domain.DirectoryUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserDirectoryMockRecorder) UpdateUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserDirectory)(nil).UpdateUser), ctx, user)
+}
+
+// MockGroupDirectory is a mock of GroupDirectory interface.
+type MockGroupDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupDirectoryMockRecorder
+}
+
+// MockGroupDirectoryMockRecorder is the mock recorder for MockGroupDirectory.
+type MockGroupDirectoryMockRecorder struct {
+	mock *MockGroupDirectory
+}
+
+// NewMockGroupDirectory creates a new mock instance.
+func NewMockGroupDirectory(ctrl *gomock.Controller) *MockGroupDirectory {
+	mock := &MockGroupDirectory{ctrl: ctrl}
+	mock.recorder = &MockGroupDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupDirectory) EXPECT() *MockGroupDirectoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockGroupDirectory) AddMember(ctx context.Context, ownerID, groupID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, ownerID, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockGroupDirectoryMockRecorder) AddMember(ctx, ownerID, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroupDirectory)(nil).AddMember), ctx, ownerID, groupID, userID)
+}
+
+// CreateGroup mocks base method.
+func (m *MockGroupDirectory) CreateGroup(ctx context.Context, ownerID, name string) (*domain.DirectoryGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, ownerID, name)
+	ret0, _ := ret[0].(*domain.DirectoryGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockGroupDirectoryMockRecorder) CreateGroup(ctx, ownerID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupDirectory)(nil).CreateGroup), ctx, ownerID, name)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockGroupDirectory) DeleteGroup(ctx context.Context, ownerID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, ownerID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockGroupDirectoryMockRecorder) DeleteGroup(ctx, ownerID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupDirectory)(nil).DeleteGroup), ctx, ownerID, id)
+}
+
+// GetGroup mocks base method.
+func (m *MockGroupDirectory) GetGroup(ctx context.Context, id string) (*domain.DirectoryGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", ctx, id)
+	ret0, _ := ret[0].(*domain.DirectoryGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockGroupDirectoryMockRecorder) GetGroup(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockGroupDirectory)(nil).GetGroup), ctx, id)
+}
+
+// ListGroups mocks base method.
+func (m *MockGroupDirectory) ListGroups(ctx context.Context, ownerID string) ([]*domain.DirectoryGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", ctx, ownerID)
+	ret0, _ := ret[0].([]*domain.DirectoryGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockGroupDirectoryMockRecorder) ListGroups(ctx, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupDirectory)(nil).ListGroups), ctx, ownerID)
+}
+
+// ListMemberIDs mocks base method.
+func (m *MockGroupDirectory) ListMemberIDs(ctx context.Context, id string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberIDs", ctx, id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberIDs indicates an expected call of ListMemberIDs.
+func (mr *MockGroupDirectoryMockRecorder) ListMemberIDs(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberIDs", reflect.TypeOf((*MockGroupDirectory)(nil).ListMemberIDs), ctx, id)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupDirectory) RemoveMember(ctx context.Context, ownerID, groupID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, ownerID, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupDirectoryMockRecorder) RemoveMember(ctx, ownerID, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupDirectory)(nil).RemoveMember), ctx, ownerID, groupID, userID)
+}
+
+// RenameGroup mocks base method.
+func (m *MockGroupDirectory) RenameGroup(ctx context.Context, ownerID, id, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameGroup", ctx, ownerID, id, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameGroup indicates an expected call of RenameGroup.
+func (mr *MockGroupDirectoryMockRecorder) RenameGroup(ctx, ownerID, id, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameGroup", reflect.TypeOf((*MockGroupDirectory)(nil).RenameGroup), ctx, ownerID, id, name)
+}
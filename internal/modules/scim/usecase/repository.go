@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/internal/modules/scim/domain"
+)
+
+// UserDirectory は認証モジュールのユーザーを操作するインターフェース
+type UserDirectory interface {
+	// CreateUser はメールアドレスを確認済みのユーザーを作成する（passwordは平文）
+	CreateUser(ctx context.Context, username, email, password string) (*domain.DirectoryUser, error)
+	// GetUser はユーザーを取得する（存在しない場合はnil, nil）
+	GetUser(ctx context.Context, id string) (*domain.DirectoryUser, error)
+	// FindUserByUsername・FindUserByEmail は大文字小文字を区別せずに検索する（存在しない場合はnil, nil）
+	FindUserByUsername(ctx context.Context, username string) (*domain.DirectoryUser, error)
+	FindUserByEmail(ctx context.Context, email string) (*domain.DirectoryUser, error)
+	// ListUsers はユーザーをユーザー名順に取得する
+	ListUsers(ctx context.Context) ([]*domain.DirectoryUser, error)
+	// UpdateUser はユーザー名・メールアドレス・有効かどうかを更新する
+	// 無効化した場合はログイン・トークンの更新ができなくなる
+	UpdateUser(ctx context.Context, user *domain.DirectoryUser) error
+}
+
+// GroupDirectory はグループモジュールのグループを操作するインターフェース
+// 操作はownerID（APIキーを発行した管理者）の権限で行う
+type GroupDirectory interface {
+	// CreateGroup はownerIDをオーナーとするプロジェクトグループを作成する
+	CreateGroup(ctx context.Context, ownerID, name string) (*domain.DirectoryGroup, error)
+	// GetGroup はグループを取得する（存在しない場合はnil, nil）
+	GetGroup(ctx context.Context, id string) (*domain.DirectoryGroup, error)
+	// ListGroups はownerIDがオーナーのグループを取得する
+	ListGroups(ctx context.Context, ownerID string) ([]*domain.DirectoryGroup, error)
+	RenameGroup(ctx context.Context, ownerID, id, name string) error
+	DeleteGroup(ctx context.Context, ownerID, id string) error
+	// ListMemberIDs はオーナーを含むメンバーのユーザーIDを取得する
+	ListMemberIDs(ctx context.Context, id string) ([]string, error)
+	AddMember(ctx context.Context, ownerID, groupID, userID string) error
+	RemoveMember(ctx context.Context, ownerID, groupID, userID string) error
+}
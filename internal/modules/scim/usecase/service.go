@@ -0,0 +1,728 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/scim/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// DefaultCount・MaxCount は一覧で返す件数の既定値と上限
+	DefaultCount = 100
+	MaxCount     = 1000
+	// maxUserNameLength・maxGroupNameLength は保存できる長さ（users.username・groups.name）
+	maxUserNameLength  = 255
+	maxGroupNameLength = 100
+	// randomPasswordBytes はパスワードを指定しないユーザーに設定するパスワードのバイト数
+	randomPasswordBytes = 24
+)
+
+// ProvisioningService はSCIMによるユーザーのプロビジョニングとグループのメンバー同期を扱うサービス
+// グループはAPIキーを発行した管理者（actor）がオーナーのグループのみ扱う
+type ProvisioningService struct {
+	Users  UserDirectory
+	Groups GroupDirectory
+	Logger logger.Logger
+
+	generatePassword func() (string, error)
+}
+
+// NewProvisioningService はProvisioningServiceのコンストラクタ
+func NewProvisioningService(users UserDirectory, groups GroupDirectory, logger logger.Logger) *ProvisioningService {
+	return &ProvisioningService{
+		Users:            users,
+		Groups:           groups,
+		Logger:           logger,
+		generatePassword: generateRandomPassword,
+	}
+}
+
+// === Users ===
+
+// ListUsers はユーザーの一覧を返す（startIndexは1始まり）
+// filterはuserName・emails.value・externalIdのeqに対応する（externalIdは保存しないため常に0件）
+func (s *ProvisioningService) ListUsers(ctx context.Context, filter string, startIndex, count int) (*domain.ListResponse, error) {
+	f, err := domain.ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*domain.DirectoryUser
+	switch {
+	case f == nil:
+		users, err = s.Users.ListUsers(ctx)
+	case f.Attribute == "username":
+		users, err = s.findUser(s.Users.FindUserByUsername(ctx, f.Value))
+	case f.Attribute == "emails" || f.Attribute == "emails.value":
+		users, err = s.findUser(s.Users.FindUserByEmail(ctx, f.Value))
+	case f.Attribute == "externalid":
+		users = nil
+	default:
+		return nil, domain.Errorf(http.StatusBadRequest, domain.ScimTypeInvalidFilter, "filtering by %s is not supported", f.Attribute)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	page, startIndex := paginate(len(users), startIndex, count)
+	resources := make([]*domain.User, 0, page.end-page.start)
+	for _, user := range users[page.start:page.end] {
+		resources = append(resources, toSCIMUser(user))
+	}
+	return domain.NewListResponse(resources, len(users), startIndex, len(resources)), nil
+}
+
+// GetUser はユーザーを取得する
+func (s *ProvisioningService) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user), nil
+}
+
+// CreateUser はユーザーを作成する（メールアドレスは確認済みとする）
+// passwordを省略した場合はランダムなパスワードを設定する（パスワードの再設定またはSSOでログインする）
+func (s *ProvisioningService) CreateUser(ctx context.Context, input *domain.User) (*domain.User, error) {
+	username, email, err := validateUser(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUserUniqueness(ctx, "", username, email); err != nil {
+		return nil, err
+	}
+
+	password := input.Password
+	if password == "" {
+		if password, err = s.generatePassword(); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.Users.CreateUser(ctx, username, email, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if !input.IsActive() {
+		user.Active = false
+		if err := s.Users.UpdateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to deactivate user: %w", err)
+		}
+	}
+
+	s.Logger.Info("SCIM user provisioned", logger.Any("userID", user.ID))
+	return toSCIMUser(user), nil
+}
+
+// ReplaceUser はユーザーを置き換える（PUT）。activeを省略した場合は変更しない
+func (s *ProvisioningService) ReplaceUser(ctx context.Context, id string, input *domain.User) (*domain.User, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	username, email, err := validateUser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Username = username
+	user.Email = email
+	if input.Active != nil {
+		user.Active = *input.Active
+	}
+	return s.saveUser(ctx, user)
+}
+
+// PatchUser はユーザーを部分的に更新する（PATCH）
+// active・userName・emailsに対応し、保存しない属性（nameなど）の操作は無視する
+func (s *ProvisioningService) PatchUser(ctx context.Context, id string, patch *domain.PatchRequest) (*domain.User, error) {
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range patch.Operations {
+		if err := applyUserOperation(user, op); err != nil {
+			return nil, err
+		}
+	}
+	if user.Username == "" || len(user.Username) > maxUserNameLength {
+		return nil, domain.NewError(http.StatusBadRequest, domain.ScimTypeInvalidValue, "userName is required and must be at most 255 characters")
+	}
+	if user.Email == "" {
+		return nil, domain.NewError(http.StatusBadRequest, domain.ScimTypeInvalidValue, "an email address is required")
+	}
+	return s.saveUser(ctx, user)
+}
+
+// DeactivateUser はユーザーを無効化する（DELETE）
+// タスクなどのデータを残すため削除はせず、ログイン・トークンの更新をできなくする
+func (s *ProvisioningService) DeactivateUser(ctx context.Context, id string) error {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.Active {
+		return nil
+	}
+	user.Active = false
+	if err := s.Users.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	s.Logger.Info("SCIM user deactivated", logger.Any("userID", user.ID))
+	return nil
+}
+
+func (s *ProvisioningService) getUser(ctx context.Context, id string) (*domain.DirectoryUser, error) {
+	user, err := s.Users.GetUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound(domain.ResourceTypeUser, id)
+	}
+	return user, nil
+}
+
+func (s *ProvisioningService) findUser(user *domain.DirectoryUser, err error) ([]*domain.DirectoryUser, error) {
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return []*domain.DirectoryUser{user}, nil
+}
+
+func (s *ProvisioningService) saveUser(ctx context.Context, user *domain.DirectoryUser) (*domain.User, error) {
+	if err := s.checkUserUniqueness(ctx, user.ID, user.Username, user.Email); err != nil {
+		return nil, err
+	}
+	if err := s.Users.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return toSCIMUser(user), nil
+}
+
+// checkUserUniqueness はユーザー名・メールアドレスが他のユーザー（exceptID以外）に使われていないか確認する
+func (s *ProvisioningService) checkUserUniqueness(ctx context.Context, exceptID, username, email string) error {
+	existing, err := s.Users.FindUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if existing != nil && existing.ID != exceptID {
+		return domain.Errorf(http.StatusConflict, domain.ScimTypeUniqueness, "userName %s already exists", username)
+	}
+	existing, err = s.Users.FindUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if existing != nil && existing.ID != exceptID {
+		return domain.Errorf(http.StatusConflict, domain.ScimTypeUniqueness, "email %s already exists", email)
+	}
+	return nil
+}
+
+func validateUser(input *domain.User) (username, email string, err error) {
+	username = strings.TrimSpace(input.UserName)
+	if username == "" || len(username) > maxUserNameLength {
+		return "", "", domain.NewError(http.StatusBadRequest, domain.ScimTypeInvalidValue, "userName is required and must be at most 255 characters")
+	}
+	email = strings.TrimSpace(input.PrimaryEmail())
+	if email == "" {
+		return "", "", domain.NewError(http.StatusBadRequest, domain.ScimTypeInvalidValue, "an email address is required (emails or an email-formatted userName)")
+	}
+	return username, email, nil
+}
+
+// applyUserOperation はPATCHの操作を1件ユーザーに適用する
+func applyUserOperation(user *domain.DirectoryUser, op domain.PatchOperation) error {
+	path := strings.ToLower(strings.TrimSpace(op.Path))
+	if op.Op == domain.PatchOpRemove {
+		if path == "username" {
+			return domain.NewError(http.StatusBadRequest, domain.ScimTypeMutability, "userName cannot be removed")
+		}
+		return nil
+	}
+
+	// パスを省略した場合は属性名と値の組を受け取る
+	if path == "" {
+		var attributes map[string]json.RawMessage
+		if err := op.DecodeValue(&attributes); err != nil {
+			return err
+		}
+		for name, value := range attributes {
+			if err := applyUserOperation(user, domain.PatchOperation{Op: op.Op, Path: name, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case path == "active":
+		active, err := op.DecodeBool()
+		if err != nil {
+			return err
+		}
+		user.Active = active
+	case path == "username":
+		var username string
+		if err := op.DecodeValue(&username); err != nil {
+			return err
+		}
+		user.Username = strings.TrimSpace(username)
+	case path == "emails":
+		var emails []domain.Email
+		if err := op.DecodeValue(&emails); err != nil {
+			return err
+		}
+		if email := (&domain.User{Emails: emails}).PrimaryEmail(); email != "" {
+			user.Email = strings.TrimSpace(email)
+		}
+	case strings.HasPrefix(path, "emails[") && strings.HasSuffix(path, "].value"):
+		var email string
+		if err := op.DecodeValue(&email); err != nil {
+			return err
+		}
+		if email = strings.TrimSpace(email); email != "" {
+			user.Email = email
+		}
+	}
+	return nil
+}
+
+// === Groups ===
+
+// ListGroups はactorがオーナーのグループの一覧を返す（startIndexは1始まり）
+// filterはdisplayName・externalIdのeqに対応する（externalIdは保存しないため常に0件）
+func (s *ProvisioningService) ListGroups(ctx context.Context, actor, filter string, startIndex, count int) (*domain.ListResponse, error) {
+	f, err := domain.ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if f != nil && f.Attribute != "displayname" && f.Attribute != "externalid" {
+		return nil, domain.Errorf(http.StatusBadRequest, domain.ScimTypeInvalidFilter, "filtering by %s is not supported", f.Attribute)
+	}
+
+	groups, err := s.Groups.ListGroups(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	if f != nil {
+		matched := []*domain.DirectoryGroup{}
+		for _, group := range groups {
+			if f.Attribute == "displayname" && strings.EqualFold(group.Name, f.Value) {
+				matched = append(matched, group)
+			}
+		}
+		groups = matched
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].CreatedAt.Before(groups[j].CreatedAt) })
+
+	page, startIndex := paginate(len(groups), startIndex, count)
+	resources := make([]*domain.Group, 0, page.end-page.start)
+	for _, group := range groups[page.start:page.end] {
+		resource, err := s.toSCIMGroup(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return domain.NewListResponse(resources, len(groups), startIndex, len(resources)), nil
+}
+
+// GetGroup はグループを取得する
+func (s *ProvisioningService) GetGroup(ctx context.Context, actor, id string) (*domain.Group, error) {
+	group, err := s.getGroup(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSCIMGroup(ctx, group)
+}
+
+// CreateGroup はactorがオーナーのプロジェクトグループを作成し、メンバーを追加する
+func (s *ProvisioningService) CreateGroup(ctx context.Context, actor string, input *domain.Group) (*domain.Group, error) {
+	name, err := validateGroupName(input.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupUniqueness(ctx, actor, "", name); err != nil {
+		return nil, err
+	}
+	memberIDs, err := s.validateMembers(ctx, input.MemberIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.Groups.CreateGroup(ctx, actor, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+	if err := s.syncMembers(ctx, actor, group, memberIDs); err != nil {
+		return nil, err
+	}
+
+	s.Logger.Info("SCIM group provisioned", logger.Any("groupID", group.ID))
+	return s.toSCIMGroup(ctx, group)
+}
+
+// ReplaceGroup はグループ名とメンバーを置き換える（PUT）
+func (s *ProvisioningService) ReplaceGroup(ctx context.Context, actor, id string, input *domain.Group) (*domain.Group, error) {
+	group, err := s.getGroup(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := validateGroupName(input.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs, err := s.validateMembers(ctx, input.MemberIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.renameGroup(ctx, actor, group, name); err != nil {
+		return nil, err
+	}
+	if err := s.syncMembers(ctx, actor, group, memberIDs); err != nil {
+		return nil, err
+	}
+	return s.toSCIMGroup(ctx, group)
+}
+
+// PatchGroup はグループ名の変更とメンバーの追加・削除・置き換えを行う（PATCH）
+func (s *ProvisioningService) PatchGroup(ctx context.Context, actor, id string, patch *domain.PatchRequest) (*domain.Group, error) {
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+	group, err := s.getGroup(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range patch.Operations {
+		if err := s.applyGroupOperation(ctx, actor, group, op); err != nil {
+			return nil, err
+		}
+	}
+	return s.toSCIMGroup(ctx, group)
+}
+
+// DeleteGroup はグループを削除する
+func (s *ProvisioningService) DeleteGroup(ctx context.Context, actor, id string) error {
+	if _, err := s.getGroup(ctx, actor, id); err != nil {
+		return err
+	}
+	if err := s.Groups.DeleteGroup(ctx, actor, id); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	s.Logger.Info("SCIM group deleted", logger.Any("groupID", id))
+	return nil
+}
+
+// getGroup はactorがオーナーのグループを取得する（他のグループは存在しないものとして扱う）
+func (s *ProvisioningService) getGroup(ctx context.Context, actor, id string) (*domain.DirectoryGroup, error) {
+	group, err := s.Groups.GetGroup(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	if group == nil || group.OwnerID != actor {
+		return nil, domain.ErrNotFound(domain.ResourceTypeGroup, id)
+	}
+	return group, nil
+}
+
+func (s *ProvisioningService) applyGroupOperation(ctx context.Context, actor string, group *domain.DirectoryGroup, op domain.PatchOperation) error {
+	path := strings.ToLower(strings.TrimSpace(op.Path))
+
+	// パスを省略した場合は属性名と値の組を受け取る
+	if path == "" {
+		if op.Op == domain.PatchOpRemove {
+			return domain.NewError(http.StatusBadRequest, domain.ScimTypeNoTarget, "path is required for remove")
+		}
+		var attributes map[string]json.RawMessage
+		if err := op.DecodeValue(&attributes); err != nil {
+			return err
+		}
+		for name, value := range attributes {
+			if err := s.applyGroupOperation(ctx, actor, group, domain.PatchOperation{Op: op.Op, Path: name, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if path == "displayname" {
+		if op.Op == domain.PatchOpRemove {
+			return domain.NewError(http.StatusBadRequest, domain.ScimTypeMutability, "displayName cannot be removed")
+		}
+		var name string
+		if err := op.DecodeValue(&name); err != nil {
+			return err
+		}
+		name, err := validateGroupName(name)
+		if err != nil {
+			return err
+		}
+		return s.renameGroup(ctx, actor, group, name)
+	}
+
+	memberID, ok := domain.ParseMemberPath(op.Path)
+	if !ok {
+		// externalIdなど保存しない属性の操作は無視する
+		return nil
+	}
+
+	var members []domain.Member
+	if len(op.Value) > 0 {
+		if err := op.DecodeValue(&members); err != nil {
+			return err
+		}
+	}
+	ids := (&domain.Group{Members: members}).MemberIDs()
+	if memberID != "" {
+		ids = []string{memberID}
+	}
+
+	switch op.Op {
+	case domain.PatchOpAdd:
+		ids, err := s.validateMembers(ctx, ids)
+		if err != nil {
+			return err
+		}
+		return s.addMembers(ctx, actor, group, ids)
+	case domain.PatchOpReplace:
+		ids, err := s.validateMembers(ctx, ids)
+		if err != nil {
+			return err
+		}
+		return s.syncMembers(ctx, actor, group, ids)
+	default:
+		// valueもフィルターもない場合はすべてのメンバーを削除する
+		if memberID == "" && len(op.Value) == 0 {
+			return s.syncMembers(ctx, actor, group, nil)
+		}
+		return s.removeMembers(ctx, actor, group, ids)
+	}
+}
+
+func (s *ProvisioningService) renameGroup(ctx context.Context, actor string, group *domain.DirectoryGroup, name string) error {
+	if name == group.Name {
+		return nil
+	}
+	if err := s.checkGroupUniqueness(ctx, actor, group.ID, name); err != nil {
+		return err
+	}
+	if err := s.Groups.RenameGroup(ctx, actor, group.ID, name); err != nil {
+		return fmt.Errorf("failed to rename group: %w", err)
+	}
+	group.Name = name
+	return nil
+}
+
+// checkGroupUniqueness はactorのグループに同じ名前のグループ（exceptID以外）がないか確認する
+func (s *ProvisioningService) checkGroupUniqueness(ctx context.Context, actor, exceptID, name string) error {
+	groups, err := s.Groups.ListGroups(ctx, actor)
+	if err != nil {
+		return fmt.Errorf("failed to list groups: %w", err)
+	}
+	for _, group := range groups {
+		if group.ID != exceptID && strings.EqualFold(group.Name, name) {
+			return domain.Errorf(http.StatusConflict, domain.ScimTypeUniqueness, "displayName %s already exists", name)
+		}
+	}
+	return nil
+}
+
+// validateMembers はメンバーのユーザーが存在するか確認する
+func (s *ProvisioningService) validateMembers(ctx context.Context, ids []string) ([]string, error) {
+	for _, id := range ids {
+		if _, err := s.getUser(ctx, id); err != nil {
+			if scimErr, ok := err.(*domain.Error); ok && scimErr.StatusCode() == http.StatusNotFound {
+				return nil, domain.Errorf(http.StatusBadRequest, domain.ScimTypeInvalidValue, "member %s does not exist", id)
+			}
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// syncMembers はオーナー以外のメンバーをidsと一致させる
+func (s *ProvisioningService) syncMembers(ctx context.Context, actor string, group *domain.DirectoryGroup, ids []string) error {
+	current, err := s.memberIDs(ctx, group)
+	if err != nil {
+		return err
+	}
+	desired := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		desired[id] = true
+	}
+
+	var removed []string
+	for _, id := range current {
+		if !desired[id] {
+			removed = append(removed, id)
+		}
+	}
+	if err := s.removeMembers(ctx, actor, group, removed); err != nil {
+		return err
+	}
+	return s.addMembers(ctx, actor, group, ids)
+}
+
+func (s *ProvisioningService) addMembers(ctx context.Context, actor string, group *domain.DirectoryGroup, ids []string) error {
+	current, err := s.memberIDs(ctx, group)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(current))
+	for _, id := range current {
+		existing[id] = true
+	}
+
+	for _, id := range ids {
+		if existing[id] || id == group.OwnerID {
+			continue
+		}
+		if err := s.Groups.AddMember(ctx, actor, group.ID, id); err != nil {
+			return fmt.Errorf("failed to add member %s: %w", id, err)
+		}
+		existing[id] = true
+	}
+	return nil
+}
+
+func (s *ProvisioningService) removeMembers(ctx context.Context, actor string, group *domain.DirectoryGroup, ids []string) error {
+	current, err := s.memberIDs(ctx, group)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(current))
+	for _, id := range current {
+		existing[id] = true
+	}
+
+	for _, id := range ids {
+		if !existing[id] {
+			continue
+		}
+		if err := s.Groups.RemoveMember(ctx, actor, group.ID, id); err != nil {
+			return fmt.Errorf("failed to remove member %s: %w", id, err)
+		}
+		existing[id] = false
+	}
+	return nil
+}
+
+// memberIDs はオーナーを除くメンバーのユーザーIDを返す（オーナーはSCIMのメンバーとして扱わない）
+func (s *ProvisioningService) memberIDs(ctx context.Context, group *domain.DirectoryGroup) ([]string, error) {
+	ids, err := s.Groups.ListMemberIDs(ctx, group.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	members := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != group.OwnerID {
+			members = append(members, id)
+		}
+	}
+	return members, nil
+}
+
+func (s *ProvisioningService) toSCIMGroup(ctx context.Context, group *domain.DirectoryGroup) (*domain.Group, error) {
+	ids, err := s.memberIDs(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]domain.Member, 0, len(ids))
+	for _, id := range ids {
+		member := domain.Member{Value: id}
+		if user, err := s.Users.GetUser(ctx, id); err == nil && user != nil {
+			member.Display = user.Username
+		}
+		members = append(members, member)
+	}
+
+	return &domain.Group{
+		Schemas:     []string{domain.SchemaGroup},
+		ID:          group.ID,
+		DisplayName: group.Name,
+		Members:     members,
+		Meta: &domain.Meta{
+			ResourceType: domain.ResourceTypeGroup,
+			Created:      group.CreatedAt,
+			LastModified: group.UpdatedAt,
+			Location:     domain.BasePath + "/Groups/" + group.ID,
+		},
+	}, nil
+}
+
+func validateGroupName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxGroupNameLength {
+		return "", domain.NewError(http.StatusBadRequest, domain.ScimTypeInvalidValue, "displayName is required and must be at most 100 characters")
+	}
+	return name, nil
+}
+
+// === Helpers ===
+
+func toSCIMUser(user *domain.DirectoryUser) *domain.User {
+	active := user.Active
+	return &domain.User{
+		Schemas:  []string{domain.SchemaUser},
+		ID:       user.ID,
+		UserName: user.Username,
+		Emails:   []domain.Email{{Value: user.Email, Type: "work", Primary: true}},
+		Active:   &active,
+		Meta: &domain.Meta{
+			ResourceType: domain.ResourceTypeUser,
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     domain.BasePath + "/Users/" + user.ID,
+		},
+	}
+}
+
+type pageRange struct {
+	start, end int
+}
+
+// paginate はstartIndex（1始まり）とcountから返す範囲を求める
+func paginate(total, startIndex, count int) (pageRange, int) {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > MaxCount {
+		count = MaxCount
+	}
+	start := startIndex - 1
+	if start > total {
+		start = total
+	}
+	end := start + count
+	if end > total {
+		end = total
+	}
+	return pageRange{start: start, end: end}, startIndex
+}
+
+func generateRandomPassword() (string, error) {
+	b := make([]byte, randomPasswordBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
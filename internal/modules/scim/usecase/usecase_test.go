@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/scim/domain"
+	"github.com/hryt430/Yotei+/internal/modules/scim/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var (
+	testNow    = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	testLogger = *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+)
+
+const testActor = "admin-1"
+
+func newTestService(t *testing.T) (*ProvisioningService, *mocks.MockUserDirectory, *mocks.MockGroupDirectory) {
+	ctrl := gomock.NewController(t)
+	users := mocks.NewMockUserDirectory(ctrl)
+	groups := mocks.NewMockGroupDirectory(ctrl)
+	service := NewProvisioningService(users, groups, testLogger)
+	service.generatePassword = func() (string, error) { return "generated-password", nil }
+	return service, users, groups
+}
+
+func scimError(t *testing.T, err error) *domain.Error {
+	t.Helper()
+	var scimErr *domain.Error
+	require.ErrorAs(t, err, &scimErr)
+	return scimErr
+}
+
+func TestProvisioningService_CreateUser(t *testing.T) {
+	t.Run("creates a user with a generated password", func(t *testing.T) {
+		service, users, _ := newTestService(t)
+		users.EXPECT().FindUserByUsername(gomock.Any(), "taro").Return(nil, nil)
+		users.EXPECT().FindUserByEmail(gomock.Any(), "taro@example.com").Return(nil, nil)
+		users.EXPECT().CreateUser(gomock.Any(), "taro", "taro@example.com", "generated-password").
+			Return(&domain.DirectoryUser{ID: "user-1", Username: "taro", Email: "taro@example.com", Active: true, CreatedAt: testNow}, nil)
+
+		user, err := service.CreateUser(context.Background(), &domain.User{
+			UserName: " taro ",
+			Emails:   []domain.Email{{Value: "taro@example.com", Primary: true}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", user.ID)
+		assert.True(t, user.IsActive())
+		assert.Equal(t, domain.BasePath+"/Users/user-1", user.Meta.Location)
+	})
+
+	t.Run("inactive users are deactivated after creation", func(t *testing.T) {
+		service, users, _ := newTestService(t)
+		users.EXPECT().FindUserByUsername(gomock.Any(), "taro@example.com").Return(nil, nil)
+		users.EXPECT().FindUserByEmail(gomock.Any(), "taro@example.com").Return(nil, nil)
+		users.EXPECT().CreateUser(gomock.Any(), "taro@example.com", "taro@example.com", "secret-password").
+			Return(&domain.DirectoryUser{ID: "user-1", Username: "taro@example.com", Email: "taro@example.com", Active: true}, nil)
+		users.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.DirectoryUser) error {
+			assert.False(t, u.Active)
+			return nil
+		})
+
+		inactive := false
+		user, err := service.CreateUser(context.Background(), &domain.User{UserName: "taro@example.com", Password: "secret-password", Active: &inactive})
+		require.NoError(t, err)
+		assert.False(t, user.IsActive())
+	})
+
+	t.Run("duplicate email is a conflict", func(t *testing.T) {
+		service, users, _ := newTestService(t)
+		users.EXPECT().FindUserByUsername(gomock.Any(), "taro").Return(nil, nil)
+		users.EXPECT().FindUserByEmail(gomock.Any(), "taro@example.com").Return(&domain.DirectoryUser{ID: "user-2"}, nil)
+
+		_, err := service.CreateUser(context.Background(), &domain.User{UserName: "taro", Emails: []domain.Email{{Value: "taro@example.com"}}})
+		scimErr := scimError(t, err)
+		assert.Equal(t, http.StatusConflict, scimErr.StatusCode())
+		assert.Equal(t, domain.ScimTypeUniqueness, scimErr.ScimType)
+	})
+
+	t.Run("email is required", func(t *testing.T) {
+		service, _, _ := newTestService(t)
+		_, err := service.CreateUser(context.Background(), &domain.User{UserName: "taro"})
+		assert.Equal(t, http.StatusBadRequest, scimError(t, err).StatusCode())
+	})
+}
+
+func TestProvisioningService_PatchUser(t *testing.T) {
+	service, users, _ := newTestService(t)
+	existing := &domain.DirectoryUser{ID: "user-1", Username: "taro", Email: "taro@example.com", Active: true}
+	users.EXPECT().GetUser(gomock.Any(), "user-1").Return(existing, nil)
+	users.EXPECT().FindUserByUsername(gomock.Any(), "taro").Return(existing, nil)
+	users.EXPECT().FindUserByEmail(gomock.Any(), "new@example.com").Return(nil, nil)
+	users.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.DirectoryUser) error {
+		assert.False(t, u.Active)
+		assert.Equal(t, "new@example.com", u.Email)
+		return nil
+	})
+
+	// Azure AD style: capitalized op, string boolean and a path-less replace
+	user, err := service.PatchUser(context.Background(), "user-1", &domain.PatchRequest{
+		Schemas: []string{domain.SchemaPatchOp},
+		Operations: []domain.PatchOperation{
+			{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+			{Op: "replace", Value: json.RawMessage(`{"emails[type eq \"work\"].value":"new@example.com","name.givenName":"Taro"}`)},
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, user.IsActive())
+	assert.Equal(t, "new@example.com", user.PrimaryEmail())
+}
+
+func TestProvisioningService_DeactivateUser(t *testing.T) {
+	service, users, _ := newTestService(t)
+	users.EXPECT().GetUser(gomock.Any(), "user-1").Return(&domain.DirectoryUser{ID: "user-1", Active: true}, nil)
+	users.EXPECT().UpdateUser(gomock.Any(), &domain.DirectoryUser{ID: "user-1", Active: false}).Return(nil)
+	require.NoError(t, service.DeactivateUser(context.Background(), "user-1"))
+
+	users.EXPECT().GetUser(gomock.Any(), "missing").Return(nil, nil)
+	err := service.DeactivateUser(context.Background(), "missing")
+	assert.Equal(t, http.StatusNotFound, scimError(t, err).StatusCode())
+}
+
+func TestProvisioningService_ListUsers(t *testing.T) {
+	service, users, _ := newTestService(t)
+	users.EXPECT().ListUsers(gomock.Any()).Return([]*domain.DirectoryUser{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil)
+
+	list, err := service.ListUsers(context.Background(), "", 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.TotalResults)
+	assert.Equal(t, 2, list.StartIndex)
+	assert.Equal(t, 1, list.ItemsPerPage)
+
+	_, err = service.ListUsers(context.Background(), `title eq "x"`, 1, 10)
+	assert.Equal(t, domain.ScimTypeInvalidFilter, scimError(t, err).ScimType)
+}
+
+// groupState simulates the members of a group, including its owner
+type groupState struct {
+	members []string
+}
+
+func (g *groupState) expect(groups *mocks.MockGroupDirectory, groupID string) {
+	groups.EXPECT().ListMemberIDs(gomock.Any(), groupID).DoAndReturn(func(context.Context, string) ([]string, error) {
+		return append([]string(nil), g.members...), nil
+	}).AnyTimes()
+	groups.EXPECT().AddMember(gomock.Any(), testActor, groupID, gomock.Any()).DoAndReturn(func(_ context.Context, _, _, userID string) error {
+		g.members = append(g.members, userID)
+		return nil
+	}).AnyTimes()
+	groups.EXPECT().RemoveMember(gomock.Any(), testActor, groupID, gomock.Any()).DoAndReturn(func(_ context.Context, _, _, userID string) error {
+		for i, id := range g.members {
+			if id == userID {
+				g.members = append(g.members[:i], g.members[i+1:]...)
+				break
+			}
+		}
+		return nil
+	}).AnyTimes()
+}
+
+func expectUsers(users *mocks.MockUserDirectory, ids ...string) {
+	for _, id := range ids {
+		users.EXPECT().GetUser(gomock.Any(), id).Return(&domain.DirectoryUser{ID: id, Username: "user " + id}, nil).AnyTimes()
+	}
+}
+
+func TestProvisioningService_CreateGroup(t *testing.T) {
+	service, users, groups := newTestService(t)
+	expectUsers(users, testActor, "user-1", "user-2")
+
+	state := &groupState{members: []string{testActor}}
+	state.expect(groups, "group-1")
+	groups.EXPECT().ListGroups(gomock.Any(), testActor).Return(nil, nil)
+	groups.EXPECT().CreateGroup(gomock.Any(), testActor, "Engineering").
+		Return(&domain.DirectoryGroup{ID: "group-1", Name: "Engineering", OwnerID: testActor}, nil)
+
+	group, err := service.CreateGroup(context.Background(), testActor, &domain.Group{
+		DisplayName: "Engineering",
+		Members:     []domain.Member{{Value: "user-1"}, {Value: "user-2"}, {Value: testActor}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testActor, "user-1", "user-2"}, state.members)
+	// The owner is a member in Yotei+ but not a SCIM member
+	assert.Equal(t, []string{"user-1", "user-2"}, group.MemberIDs())
+	assert.Equal(t, "user user-1", group.Members[0].Display)
+}
+
+func TestProvisioningService_CreateGroup_Validation(t *testing.T) {
+	t.Run("duplicate name is a conflict", func(t *testing.T) {
+		service, _, groups := newTestService(t)
+		groups.EXPECT().ListGroups(gomock.Any(), testActor).Return([]*domain.DirectoryGroup{{ID: "group-1", Name: "engineering", OwnerID: testActor}}, nil)
+
+		_, err := service.CreateGroup(context.Background(), testActor, &domain.Group{DisplayName: "Engineering"})
+		assert.Equal(t, http.StatusConflict, scimError(t, err).StatusCode())
+	})
+
+	t.Run("unknown member is rejected", func(t *testing.T) {
+		service, users, groups := newTestService(t)
+		groups.EXPECT().ListGroups(gomock.Any(), testActor).Return(nil, nil)
+		users.EXPECT().GetUser(gomock.Any(), "missing").Return(nil, nil)
+
+		_, err := service.CreateGroup(context.Background(), testActor, &domain.Group{DisplayName: "Engineering", Members: []domain.Member{{Value: "missing"}}})
+		scimErr := scimError(t, err)
+		assert.Equal(t, http.StatusBadRequest, scimErr.StatusCode())
+		assert.Equal(t, domain.ScimTypeInvalidValue, scimErr.ScimType)
+	})
+}
+
+func TestProvisioningService_PatchGroup(t *testing.T) {
+	service, users, groups := newTestService(t)
+	expectUsers(users, "user-1", "user-2", "user-3")
+
+	state := &groupState{members: []string{testActor, "user-1", "user-2"}}
+	state.expect(groups, "group-1")
+	groups.EXPECT().GetGroup(gomock.Any(), "group-1").Return(&domain.DirectoryGroup{ID: "group-1", Name: "Engineering", OwnerID: testActor}, nil)
+	groups.EXPECT().ListGroups(gomock.Any(), testActor).Return(nil, nil)
+	groups.EXPECT().RenameGroup(gomock.Any(), testActor, "group-1", "Platform").Return(nil)
+
+	group, err := service.PatchGroup(context.Background(), testActor, "group-1", &domain.PatchRequest{
+		Schemas: []string{domain.SchemaPatchOp},
+		Operations: []domain.PatchOperation{
+			{Op: "Add", Path: "members", Value: json.RawMessage(`[{"value":"user-3"}]`)},
+			{Op: "Remove", Path: `members[value eq "user-1"]`},
+			{Op: "Replace", Path: "displayName", Value: json.RawMessage(`"Platform"`)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Platform", group.DisplayName)
+	assert.ElementsMatch(t, []string{"user-2", "user-3"}, group.MemberIDs())
+	assert.Contains(t, state.members, testActor, "the owner is never removed")
+}
+
+func TestProvisioningService_ReplaceGroupMembers(t *testing.T) {
+	service, users, groups := newTestService(t)
+	expectUsers(users, "user-3")
+
+	state := &groupState{members: []string{testActor, "user-1", "user-2"}}
+	state.expect(groups, "group-1")
+	groups.EXPECT().GetGroup(gomock.Any(), "group-1").Return(&domain.DirectoryGroup{ID: "group-1", Name: "Engineering", OwnerID: testActor}, nil)
+
+	group, err := service.ReplaceGroup(context.Background(), testActor, "group-1", &domain.Group{
+		DisplayName: "Engineering",
+		Members:     []domain.Member{{Value: "user-3"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-3"}, group.MemberIDs())
+	assert.Equal(t, []string{testActor, "user-3"}, state.members)
+}
+
+func TestProvisioningService_GroupsAreScopedToActor(t *testing.T) {
+	service, _, groups := newTestService(t)
+	groups.EXPECT().GetGroup(gomock.Any(), "group-1").Return(&domain.DirectoryGroup{ID: "group-1", OwnerID: "someone-else"}, nil).Times(2)
+
+	_, err := service.GetGroup(context.Background(), testActor, "group-1")
+	assert.Equal(t, http.StatusNotFound, scimError(t, err).StatusCode())
+	err = service.DeleteGroup(context.Background(), testActor, "group-1")
+	assert.Equal(t, http.StatusNotFound, scimError(t, err).StatusCode())
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSlug(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		slug, err := GenerateSlug()
+		require.NoError(t, err)
+		assert.Len(t, slug, SlugLength)
+		assert.True(t, IsValidSlug(slug))
+		seen[slug] = true
+	}
+	assert.Len(t, seen, 100)
+}
+
+func TestIsValidSlug(t *testing.T) {
+	assert.True(t, IsValidSlug("aZ3kP9q"))
+	assert.False(t, IsValidSlug(""))
+	assert.False(t, IsValidSlug("abc-def"))
+	assert.False(t, IsValidSlug("../admin"))
+	assert.False(t, IsValidSlug("abcdefghijklmnopq"))
+}
+
+func TestValidateTargetURL(t *testing.T) {
+	assert.NoError(t, ValidateTargetURL("https://app.yotei-plus.com/invite/abc"))
+	assert.NoError(t, ValidateTargetURL("http://localhost:3000/invite/abc"))
+	assert.NoError(t, ValidateTargetURL("/api/v1/public/shares/token"))
+
+	assert.ErrorIs(t, ValidateTargetURL("//evil.example.com/phish"), ErrInvalidTargetURL)
+	assert.ErrorIs(t, ValidateTargetURL("javascript:alert(1)"), ErrInvalidTargetURL)
+	assert.ErrorIs(t, ValidateTargetURL(""), ErrInvalidTargetURL)
+}
+
+func TestNewLink(t *testing.T) {
+	t.Run("creates a link with a fresh slug", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		link, err := NewLink(KindInvitation, "invitation-1", "https://app.yotei-plus.com/invite/abc", &expiresAt)
+		require.NoError(t, err)
+
+		assert.True(t, IsValidSlug(link.Slug))
+		assert.Equal(t, KindInvitation, link.Kind)
+		assert.Equal(t, "invitation-1", link.Reference)
+		assert.Equal(t, &expiresAt, link.ExpiresAt)
+		assert.Zero(t, link.Clicks)
+	})
+
+	t.Run("rejects open redirects", func(t *testing.T) {
+		_, err := NewLink(KindShare, "share-1", "//evil.example.com", nil)
+		assert.ErrorIs(t, err, ErrInvalidTargetURL)
+	})
+}
+
+func TestLink_IsExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	assert.False(t, (&Link{}).IsExpired(now))
+	assert.False(t, (&Link{ExpiresAt: &expiresAt}).IsExpired(now))
+	assert.True(t, (&Link{ExpiresAt: &expiresAt}).IsExpired(expiresAt))
+}
+
+func TestLink_Retarget(t *testing.T) {
+	expiresAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	link := &Link{TargetURL: "https://example.com/a", ExpiresAt: &expiresAt}
+
+	sameExpiry := expiresAt
+	assert.False(t, link.Retarget("https://example.com/a", &sameExpiry))
+
+	extended := expiresAt.Add(24 * time.Hour)
+	assert.True(t, link.Retarget("https://example.com/a", &extended))
+	assert.Equal(t, extended, *link.ExpiresAt)
+
+	assert.True(t, link.Retarget("https://example.com/b", nil))
+	assert.Equal(t, "https://example.com/b", link.TargetURL)
+	assert.Nil(t, link.ExpiresAt)
+}
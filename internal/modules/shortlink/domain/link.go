@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 短縮リンクの発行元
+const (
+	KindInvitation = "invitation" // 招待（参照は招待ID）
+	KindShare      = "share"      // タスクの公開共有リンク（参照は共有リンクID）
+)
+
+// SlugLength は生成するスラッグの文字数（62^7 ≒ 3.5兆通り）
+const SlugLength = 7
+
+const slugAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+var (
+	ErrLinkNotFound     = errors.New("short link not found")
+	ErrLinkExpired      = errors.New("short link expired")
+	ErrSlugTaken        = errors.New("short link slug already exists")
+	ErrInvalidTargetURL = errors.New("short link target must be an http(s) URL or an absolute path")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-zA-Z0-9]{1,16}$`)
+
+// Link は /s/{slug} から転送する短縮リンクを表す
+// 発行元（Kind・Reference）ごとに1つだけ作成し、期限は発行元の招待・共有リンクに合わせる
+type Link struct {
+	Slug          string     `json:"slug" example:"aZ3kP9q"`
+	TargetURL     string     `json:"target_url" example:"https://app.yotei-plus.com/invite/abc123def456"`
+	Kind          string     `json:"kind" example:"invitation"`
+	Reference     string     `json:"reference" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Clicks        int64      `json:"clicks" example:"12"`
+	LastClickedAt *time.Time `json:"last_clicked_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+} // @name ShortLink
+
+// NewLink は新しいスラッグで短縮リンクを作成する
+func NewLink(kind, reference, targetURL string, expiresAt *time.Time) (*Link, error) {
+	if err := ValidateTargetURL(targetURL); err != nil {
+		return nil, err
+	}
+	slug, err := GenerateSlug()
+	if err != nil {
+		return nil, err
+	}
+	return &Link{
+		Slug:      slug,
+		TargetURL: targetURL,
+		Kind:      kind,
+		Reference: reference,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// GenerateSlug は推測しにくい英数字のスラッグを生成する
+func GenerateSlug() (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(slugAlphabet)))
+	for i := 0; i < SlugLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate short link slug: %w", err)
+		}
+		b.WriteByte(slugAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// IsValidSlug はスラッグの形式が正しいかを判定する（不正な形式はデータベースを引かずに404とする）
+func IsValidSlug(slug string) bool {
+	return slugPattern.MatchString(slug)
+}
+
+// ValidateTargetURL は転送先がhttp(s)のURLか、同じホストの絶対パスかを検証する
+// "//" で始まるパスは別のホストへの転送になるため受け付けない
+func ValidateTargetURL(target string) error {
+	switch {
+	case strings.HasPrefix(target, "https://"), strings.HasPrefix(target, "http://"):
+		return nil
+	case strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//"):
+		return nil
+	default:
+		return ErrInvalidTargetURL
+	}
+}
+
+// IsExpired はリンクが有効期限切れかを判定する
+func (l *Link) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// Retarget は転送先と期限を発行元の現在の値に合わせる（変更があった場合は true を返す）
+func (l *Link) Retarget(targetURL string, expiresAt *time.Time) bool {
+	changed := l.TargetURL != targetURL || !sameTime(l.ExpiresAt, expiresAt)
+	l.TargetURL = targetURL
+	l.ExpiresAt = expiresAt
+	return changed
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+)
+
+// SqlHandler は短縮リンクモジュール用のSQLハンドラー
+type SqlHandler struct {
+	Conn *sql.DB
+}
+
+// NewSqlHandler は新しいSqlHandlerを作成する
+func NewSqlHandler() SqlHandler {
+	// 共通のMySQLコネクションを使用
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load config: %v", err))
+	}
+	conn, err := database.NewConnection(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+
+	return SqlHandler{
+		Conn: conn,
+	}
+}
+
+// Close はデータベース接続を閉じる
+func (h *SqlHandler) Close() error {
+	if h.Conn != nil {
+		return h.Conn.Close()
+	}
+	return nil
+}
+
+// GetConnection はデータベース接続を取得する
+func (h *SqlHandler) GetConnection() *sql.DB {
+	return h.Conn
+}
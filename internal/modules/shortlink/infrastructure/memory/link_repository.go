@@ -0,0 +1,100 @@
+// Package memory は短縮リンクリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+)
+
+// LinkRepository は短縮リンクのインメモリリポジトリ
+type LinkRepository struct {
+	mu    sync.RWMutex
+	links map[string]*domain.Link // スラッグ → リンク
+}
+
+// NewLinkRepository は新しいLinkRepositoryを作成する
+func NewLinkRepository() *LinkRepository {
+	return &LinkRepository{
+		links: make(map[string]*domain.Link),
+	}
+}
+
+// CreateLink はリンクを作成する（スラッグが使用済みの場合は domain.ErrSlugTaken）
+func (r *LinkRepository) CreateLink(ctx context.Context, link *domain.Link) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.links[link.Slug]; ok {
+		return domain.ErrSlugTaken
+	}
+	r.links[link.Slug] = copyLink(link)
+	return nil
+}
+
+// UpdateLink は転送先と期限を更新する
+func (r *LinkRepository) UpdateLink(ctx context.Context, link *domain.Link) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.links[link.Slug]; ok {
+		updated := copyLink(link)
+		stored.TargetURL = updated.TargetURL
+		stored.ExpiresAt = updated.ExpiresAt
+	}
+	return nil
+}
+
+// GetLinkBySlug はスラッグでリンクを取得する（存在しない場合は nil, nil）
+func (r *LinkRepository) GetLinkBySlug(ctx context.Context, slug string) (*domain.Link, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if link, ok := r.links[slug]; ok {
+		return copyLink(link), nil
+	}
+	return nil, nil
+}
+
+// GetLinkByReference は発行元のリンクを取得する（存在しない場合は nil, nil）
+func (r *LinkRepository) GetLinkByReference(ctx context.Context, kind, reference string) (*domain.Link, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, link := range r.links {
+		if link.Kind == kind && link.Reference == reference {
+			return copyLink(link), nil
+		}
+	}
+	return nil, nil
+}
+
+// RecordClick はクリック数を1増やし、最後にクリックされた日時を記録する
+func (r *LinkRepository) RecordClick(ctx context.Context, slug string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if link, ok := r.links[slug]; ok {
+		link.Clicks++
+		link.LastClickedAt = &at
+	}
+	return nil
+}
+
+// copyLink は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+func copyLink(link *domain.Link) *domain.Link {
+	c := *link
+	if link.LastClickedAt != nil {
+		t := *link.LastClickedAt
+		c.LastClickedAt = &t
+	}
+	if link.ExpiresAt != nil {
+		t := *link.ExpiresAt
+		c.ExpiresAt = &t
+	}
+	return &c
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ShortLinkController は短縮リンクのHTTPリクエストを処理するコントローラー
+type ShortLinkController struct {
+	shortLinkService *usecase.ShortLinkService
+	logger           logger.Logger
+}
+
+// NewShortLinkController は新しいShortLinkControllerを作成する
+func NewShortLinkController(shortLinkService *usecase.ShortLinkService, logger logger.Logger) *ShortLinkController {
+	return &ShortLinkController{
+		shortLinkService: shortLinkService,
+		logger:           logger,
+	}
+}
+
+// ErrorResponse は短縮リンクAPIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"SHORT_LINK_NOT_FOUND"`
+	Message string `json:"message" example:"short link not found"`
+} // @name ShortLinkErrorResponse
+
+// ShortLinkResponse は短縮リンクのレスポンス
+type ShortLinkResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Data    domain.Link `json:"data"`
+	URL     string      `json:"url" example:"https://yt.plus/s/aB3dE5f"`
+} // @name ShortLinkResponse
+
+// Redirect は短縮リンクの転送先にリダイレクトする（/s/{slug}、認証不要）
+// 存在しない場合は404、招待・共有リンクの期限が切れている場合は410を返す
+func (c *ShortLinkController) Redirect(ctx *gin.Context) {
+	link, err := c.shortLinkService.Resolve(ctx, ctx.Param("slug"))
+	if err != nil {
+		handleServiceError(ctx, c.logger, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Redirect(http.StatusFound, link.TargetURL)
+}
+
+// GetLink 短縮リンクの取得
+// @Summary      短縮リンクの取得
+// @Description  短縮リンクの転送先・発行元・期限とクリック数を取得します。クリックとしては数えません（管理者のみ）
+// @Tags         short-links
+// @Produce      json
+// @Param        slug path string true "スラッグ"
+// @Security     BearerAuth
+// @Success      200 {object} ShortLinkResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "管理者権限が必要"
+// @Failure      404 {object} ErrorResponse "短縮リンクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /admin/short-links/{slug} [get]
+func (c *ShortLinkController) GetLink(ctx *gin.Context) {
+	link, err := c.shortLinkService.GetLink(ctx, ctx.Param("slug"))
+	if err != nil {
+		handleServiceError(ctx, c.logger, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ShortLinkResponse{
+		Success: true,
+		Data:    *link,
+		URL:     c.shortLinkService.URL(link),
+	})
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, log logger.Logger, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLinkNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "SHORT_LINK_NOT_FOUND",
+			Message: "short link not found",
+		})
+	case errors.Is(err, domain.ErrLinkExpired):
+		ctx.JSON(http.StatusGone, ErrorResponse{
+			Success: false,
+			Error:   "SHORT_LINK_EXPIRED",
+			Message: "short link has expired",
+		})
+	default:
+		log.WithContext(ctx).Error("Failed to process short link", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to process short link",
+		})
+	}
+}
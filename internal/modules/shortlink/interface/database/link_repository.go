@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const linkColumns = "slug, target_url, kind, reference, clicks, last_clicked_at, expires_at, created_at"
+
+// LinkRepository は短縮リンクのデータベースリポジトリ実装
+type LinkRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewLinkRepository は新しいLinkRepositoryを作成する
+func NewLinkRepository(db *sql.DB, logger logger.Logger) usecase.LinkRepository {
+	return &LinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateLink はリンクを作成する（スラッグが使用済みの場合は domain.ErrSlugTaken）
+func (r *LinkRepository) CreateLink(ctx context.Context, link *domain.Link) error {
+	existing, err := r.GetLinkBySlug(ctx, link.Slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrSlugTaken
+	}
+
+	query := `
+		INSERT INTO short_links (` + linkColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		link.Slug,
+		link.TargetURL,
+		link.Kind,
+		link.Reference,
+		link.Clicks,
+		link.LastClickedAt,
+		link.ExpiresAt,
+		link.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create short link",
+			logger.Any("kind", link.Kind), logger.Any("reference", link.Reference), logger.Error(err))
+		return fmt.Errorf("failed to create short link: %w", err)
+	}
+	return nil
+}
+
+// UpdateLink は転送先と期限を更新する
+func (r *LinkRepository) UpdateLink(ctx context.Context, link *domain.Link) error {
+	query := `UPDATE short_links SET target_url = ?, expires_at = ? WHERE slug = ?`
+	if _, err := r.db.ExecContext(ctx, query, link.TargetURL, link.ExpiresAt, link.Slug); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update short link", logger.Any("slug", link.Slug), logger.Error(err))
+		return fmt.Errorf("failed to update short link: %w", err)
+	}
+	return nil
+}
+
+// GetLinkBySlug はスラッグでリンクを取得する（存在しない場合は nil, nil）
+func (r *LinkRepository) GetLinkBySlug(ctx context.Context, slug string) (*domain.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM short_links WHERE slug = ?`
+	return r.getLink(ctx, query, slug)
+}
+
+// GetLinkByReference は発行元のリンクを取得する（存在しない場合は nil, nil）
+func (r *LinkRepository) GetLinkByReference(ctx context.Context, kind, reference string) (*domain.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM short_links WHERE kind = ? AND reference = ?`
+	return r.getLink(ctx, query, kind, reference)
+}
+
+// RecordClick はクリック数を1増やし、最後にクリックされた日時を記録する
+func (r *LinkRepository) RecordClick(ctx context.Context, slug string, at time.Time) error {
+	query := `UPDATE short_links SET clicks = clicks + 1, last_clicked_at = ? WHERE slug = ?`
+	if _, err := r.db.ExecContext(ctx, query, at, slug); err != nil {
+		return fmt.Errorf("failed to record short link click: %w", err)
+	}
+	return nil
+}
+
+func (r *LinkRepository) getLink(ctx context.Context, query string, args ...interface{}) (*domain.Link, error) {
+	var (
+		link          domain.Link
+		lastClickedAt sql.NullTime
+		expiresAt     sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&link.Slug,
+		&link.TargetURL,
+		&link.Kind,
+		&link.Reference,
+		&link.Clicks,
+		&lastClickedAt,
+		&expiresAt,
+		&link.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get short link", logger.Error(err))
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+	if lastClickedAt.Valid {
+		link.LastClickedAt = &lastClickedAt.Time
+	}
+	if expiresAt.Valid {
+		link.ExpiresAt = &expiresAt.Time
+	}
+	return &link, nil
+}
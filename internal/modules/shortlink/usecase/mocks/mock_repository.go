@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+)
+
+// MockLinkRepository is a mock of LinkRepository interface.
+type MockLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkRepositoryMockRecorder
+}
+
+// MockLinkRepositoryMockRecorder is the mock recorder for MockLinkRepository.
+type MockLinkRepositoryMockRecorder struct {
+	mock *MockLinkRepository
+}
+
+// NewMockLinkRepository creates a new mock instance.
+func NewMockLinkRepository(ctrl *gomock.Controller) *MockLinkRepository {
+	mock := &MockLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkRepository) EXPECT() *MockLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLink mocks base method.
+func (m *MockLinkRepository) CreateLink(ctx context.Context, link *domain.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLink indicates an expected call of CreateLink.
+func (mr *MockLinkRepositoryMockRecorder) CreateLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockLinkRepository)(nil).CreateLink), ctx, link)
+}
+
+// GetLinkByReference mocks base method.
+func (m *MockLinkRepository) GetLinkByReference(ctx context.Context, kind, reference string) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkByReference", ctx, kind, reference)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkByReference indicates an expected call of GetLinkByReference.
+func (mr *MockLinkRepositoryMockRecorder) GetLinkByReference(ctx, kind, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkByReference", reflect.TypeOf((*MockLinkRepository)(nil).GetLinkByReference), ctx, kind, reference)
+}
+
+// GetLinkBySlug mocks base method.
+func (m *MockLinkRepository) GetLinkBySlug(ctx context.Context, slug string) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkBySlug indicates an expected call of GetLinkBySlug.
+func (mr *MockLinkRepositoryMockRecorder) GetLinkBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkBySlug", reflect.TypeOf((*MockLinkRepository)(nil).GetLinkBySlug), ctx, slug)
+}
+
+// RecordClick mocks base method.
+func (m *MockLinkRepository) RecordClick(ctx context.Context, slug string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", ctx, slug, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockLinkRepositoryMockRecorder) RecordClick(ctx, slug, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockLinkRepository)(nil).RecordClick), ctx, slug, at)
+}
+
+// UpdateLink mocks base method.
+func (m *MockLinkRepository) UpdateLink(ctx context.Context, link *domain.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLink indicates an expected call of UpdateLink.
+func (mr *MockLinkRepositoryMockRecorder) UpdateLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLink", reflect.TypeOf((*MockLinkRepository)(nil).UpdateLink), ctx, link)
+}
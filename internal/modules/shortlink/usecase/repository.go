@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+)
+
+// LinkRepository は短縮リンクのリポジトリインターフェース
+type LinkRepository interface {
+	// CreateLink はリンクを作成する（スラッグが使用済みの場合は domain.ErrSlugTaken）
+	CreateLink(ctx context.Context, link *domain.Link) error
+	// UpdateLink は転送先と期限を更新する
+	UpdateLink(ctx context.Context, link *domain.Link) error
+	// GetLinkBySlug はスラッグでリンクを取得する（存在しない場合は nil, nil）
+	GetLinkBySlug(ctx context.Context, slug string) (*domain.Link, error)
+	// GetLinkByReference は発行元のリンクを取得する（存在しない場合は nil, nil）
+	GetLinkByReference(ctx context.Context, kind, reference string) (*domain.Link, error)
+	// RecordClick はクリック数を1増やし、最後にクリックされた日時を記録する
+	RecordClick(ctx context.Context, slug string, at time.Time) error
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// 短縮リンクのメトリクス
+const (
+	MetricShortLinksCreated = "short_links_created_total"
+	MetricShortLinkClicks   = "short_link_clicks_total"
+)
+
+// RedirectPath は短縮リンクの転送を行うパス（/s/{slug}）
+const RedirectPath = "/s/"
+
+// スラッグが衝突した場合に生成し直す回数
+const maxSlugAttempts = 3
+
+// ErrInvalidParameter は短縮リンクの発行元・転送先が不正であることを表す
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// ShortLinkService は招待・共有リンクの短縮URLの発行と転送を扱うサービス
+type ShortLinkService struct {
+	Repository LinkRepository
+	// 短縮URLのベースURL（例: https://yt.plus、{BaseURL}/s/{slug} を発行する）
+	BaseURL string
+	Logger  logger.Logger
+
+	now func() time.Time
+}
+
+// NewShortLinkService はShortLinkServiceのコンストラクタ
+func NewShortLinkService(repo LinkRepository, baseURL string, logger logger.Logger) *ShortLinkService {
+	return &ShortLinkService{
+		Repository: repo,
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Shorten は発行元の短縮URLを返す
+// 同じ発行元には同じスラッグを返し、転送先・期限が変わっていれば更新する
+func (s *ShortLinkService) Shorten(ctx context.Context, kind, reference, targetURL string, expiresAt *time.Time) (string, error) {
+	link, err := s.ShortenLink(ctx, kind, reference, targetURL, expiresAt)
+	if err != nil {
+		return "", err
+	}
+	return s.URL(link), nil
+}
+
+// ShortenLink は発行元の短縮リンクを作成または更新する
+func (s *ShortLinkService) ShortenLink(ctx context.Context, kind, reference, targetURL string, expiresAt *time.Time) (*domain.Link, error) {
+	if kind == "" || reference == "" {
+		return nil, ErrInvalidParameter
+	}
+	if err := domain.ValidateTargetURL(targetURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+
+	existing, err := s.Repository.GetLinkByReference(ctx, kind, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+	if existing != nil {
+		if existing.Retarget(targetURL, expiresAt) {
+			if err := s.Repository.UpdateLink(ctx, existing); err != nil {
+				return nil, fmt.Errorf("failed to update short link: %w", err)
+			}
+		}
+		return existing, nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		link, err := domain.NewLink(kind, reference, targetURL, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		err = s.Repository.CreateLink(ctx, link)
+		if err == nil {
+			metrics.Counter(MetricShortLinksCreated).Add(1)
+			return link, nil
+		}
+		if !errors.Is(err, domain.ErrSlugTaken) || attempt >= maxSlugAttempts {
+			return nil, fmt.Errorf("failed to create short link: %w", err)
+		}
+	}
+}
+
+// URL は短縮リンクの公開URLを返す
+func (s *ShortLinkService) URL(link *domain.Link) string {
+	return s.BaseURL + RedirectPath + link.Slug
+}
+
+// Resolve はスラッグから転送先のリンクを取得し、クリックを記録する
+// クリックの記録に失敗しても転送は行う
+func (s *ShortLinkService) Resolve(ctx context.Context, slug string) (*domain.Link, error) {
+	link, err := s.GetLink(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if link.IsExpired(now) {
+		return nil, domain.ErrLinkExpired
+	}
+
+	if err := s.Repository.RecordClick(ctx, link.Slug, now); err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to record short link click",
+			logger.Any("slug", link.Slug), logger.Error(err))
+	} else {
+		link.Clicks++
+		link.LastClickedAt = &now
+	}
+	metrics.Counter(MetricShortLinkClicks).Add(1)
+	return link, nil
+}
+
+// GetLink はスラッグでリンクを取得する（クリックは記録しない）
+func (s *ShortLinkService) GetLink(ctx context.Context, slug string) (*domain.Link, error) {
+	if !domain.IsValidSlug(slug) {
+		return nil, domain.ErrLinkNotFound
+	}
+
+	link, err := s.Repository.GetLinkBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+	if link == nil {
+		return nil, domain.ErrLinkNotFound
+	}
+	return link, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+	"github.com/hryt430/Yotei+/internal/modules/shortlink/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*ShortLinkService, *mocks.MockLinkRepository) {
+	repo := mocks.NewMockLinkRepository(gomock.NewController(t))
+	service := NewShortLinkService(repo, "https://yt.plus/", *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.now = func() time.Time { return testNow }
+	return service, repo
+}
+
+func TestShortLinkService_Shorten(t *testing.T) {
+	ctx := context.Background()
+	target := "https://app.yotei-plus.com/invite/abc123"
+	expiresAt := testNow.Add(72 * time.Hour)
+
+	t.Run("creates a link for a new reference", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetLinkByReference(ctx, domain.KindInvitation, "invitation-1").Return(nil, nil)
+		var created *domain.Link
+		repo.EXPECT().CreateLink(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, link *domain.Link) error {
+			created = link
+			return nil
+		})
+
+		shortURL, err := service.Shorten(ctx, domain.KindInvitation, "invitation-1", target, &expiresAt)
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.Equal(t, "https://yt.plus/s/"+created.Slug, shortURL)
+		assert.Equal(t, target, created.TargetURL)
+		assert.Equal(t, &expiresAt, created.ExpiresAt)
+	})
+
+	t.Run("reuses the slug and follows the new expiry", func(t *testing.T) {
+		service, repo := newTestService(t)
+		existing := &domain.Link{Slug: "aZ3kP9q", TargetURL: target, Kind: domain.KindInvitation, Reference: "invitation-1"}
+		repo.EXPECT().GetLinkByReference(ctx, domain.KindInvitation, "invitation-1").Return(existing, nil)
+		repo.EXPECT().UpdateLink(ctx, existing).Return(nil)
+
+		shortURL, err := service.Shorten(ctx, domain.KindInvitation, "invitation-1", target, &expiresAt)
+		require.NoError(t, err)
+		assert.Equal(t, "https://yt.plus/s/aZ3kP9q", shortURL)
+		assert.Equal(t, &expiresAt, existing.ExpiresAt)
+	})
+
+	t.Run("does not update an unchanged link", func(t *testing.T) {
+		service, repo := newTestService(t)
+		sameExpiry := expiresAt
+		existing := &domain.Link{Slug: "aZ3kP9q", TargetURL: target, ExpiresAt: &sameExpiry}
+		repo.EXPECT().GetLinkByReference(ctx, domain.KindInvitation, "invitation-1").Return(existing, nil)
+
+		_, err := service.Shorten(ctx, domain.KindInvitation, "invitation-1", target, &expiresAt)
+		require.NoError(t, err)
+	})
+
+	t.Run("retries when the slug is taken", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetLinkByReference(ctx, domain.KindShare, "share-1").Return(nil, nil)
+		gomock.InOrder(
+			repo.EXPECT().CreateLink(ctx, gomock.Any()).Return(domain.ErrSlugTaken),
+			repo.EXPECT().CreateLink(ctx, gomock.Any()).Return(nil),
+		)
+
+		_, err := service.Shorten(ctx, domain.KindShare, "share-1", "/api/v1/public/shares/token", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("gives up after repeated slug collisions", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetLinkByReference(ctx, domain.KindShare, "share-1").Return(nil, nil)
+		repo.EXPECT().CreateLink(ctx, gomock.Any()).Return(domain.ErrSlugTaken).Times(maxSlugAttempts)
+
+		_, err := service.Shorten(ctx, domain.KindShare, "share-1", "/api/v1/public/shares/token", nil)
+		assert.ErrorIs(t, err, domain.ErrSlugTaken)
+	})
+
+	t.Run("rejects invalid targets", func(t *testing.T) {
+		service, _ := newTestService(t)
+
+		_, err := service.Shorten(ctx, domain.KindShare, "share-1", "//evil.example.com", nil)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		_, err = service.Shorten(ctx, domain.KindShare, "", target, nil)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestShortLinkService_Resolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records the click", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetLinkBySlug(ctx, "aZ3kP9q").Return(&domain.Link{Slug: "aZ3kP9q", TargetURL: "https://example.com", Clicks: 2}, nil)
+		repo.EXPECT().RecordClick(ctx, "aZ3kP9q", testNow).Return(nil)
+
+		link, err := service.Resolve(ctx, "aZ3kP9q")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), link.Clicks)
+		assert.Equal(t, testNow, *link.LastClickedAt)
+	})
+
+	t.Run("redirects even when recording the click fails", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetLinkBySlug(ctx, "aZ3kP9q").Return(&domain.Link{Slug: "aZ3kP9q", TargetURL: "https://example.com"}, nil)
+		repo.EXPECT().RecordClick(ctx, "aZ3kP9q", testNow).Return(errors.New("db down"))
+
+		link, err := service.Resolve(ctx, "aZ3kP9q")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", link.TargetURL)
+	})
+
+	t.Run("expired links are not followed", func(t *testing.T) {
+		service, repo := newTestService(t)
+		expiresAt := testNow.Add(-time.Minute)
+		repo.EXPECT().GetLinkBySlug(ctx, "aZ3kP9q").Return(&domain.Link{Slug: "aZ3kP9q", ExpiresAt: &expiresAt}, nil)
+
+		_, err := service.Resolve(ctx, "aZ3kP9q")
+		assert.ErrorIs(t, err, domain.ErrLinkExpired)
+	})
+
+	t.Run("unknown and malformed slugs are not found", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetLinkBySlug(ctx, "missing").Return(nil, nil)
+
+		_, err := service.Resolve(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrLinkNotFound)
+
+		_, err = service.Resolve(ctx, "../etc")
+		assert.ErrorIs(t, err, domain.ErrLinkNotFound)
+	})
+}
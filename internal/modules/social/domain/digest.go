@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PendingDigest はユーザーが対応していない友達申請・グループ招待の件数（まとめて知らせるリマインダーの内容）
+type PendingDigest struct {
+	UserID           uuid.UUID
+	FriendRequests   int
+	GroupInvitations int
+}
+
+// Total は対応していない申請・招待の合計の件数を返す
+func (d PendingDigest) Total() int {
+	return d.FriendRequests + d.GroupInvitations
+}
+
+// Title はリマインダーの通知のタイトルを返す
+func (d PendingDigest) Title() string {
+	return fmt.Sprintf("未対応の申請・招待が%d件あります", d.Total())
+}
+
+// Message はリマインダーの通知の本文を返す（例: 「承認待ちの友達申請が3件、グループ招待が2件あります」）
+func (d PendingDigest) Message() string {
+	parts := make([]string, 0, 2)
+	if d.FriendRequests > 0 {
+		parts = append(parts, fmt.Sprintf("友達申請が%d件", d.FriendRequests))
+	}
+	if d.GroupInvitations > 0 {
+		parts = append(parts, fmt.Sprintf("グループ招待が%d件", d.GroupInvitations))
+	}
+	return "承認待ちの" + strings.Join(parts, "、") + "あります。"
+}
+
+// DigestState はユーザーに最後に送ったリマインダーの記録
+// 申請・招待にすべて対応した時点で、送った通知を既読にして記録を削除する
+type DigestState struct {
+	UserID         uuid.UUID
+	NotificationID string
+	LastSentAt     time.Time
+}
+
+// IsDue は頻度の間隔（0の場合は送らない）に対して次のリマインダーを送れるか判定する
+func (s *DigestState) IsDue(now time.Time, interval time.Duration) bool {
+	if interval <= 0 {
+		return false
+	}
+	if s == nil {
+		return true
+	}
+	return !now.Before(s.LastSentAt.Add(interval))
+}
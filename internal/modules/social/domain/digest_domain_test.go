@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingDigest_Message(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		digest PendingDigest
+		title  string
+		want   string
+	}{
+		{"requests and invitations", PendingDigest{UserID: userID, FriendRequests: 3, GroupInvitations: 2},
+			"未対応の申請・招待が5件あります", "承認待ちの友達申請が3件、グループ招待が2件あります。"},
+		{"requests only", PendingDigest{UserID: userID, FriendRequests: 1},
+			"未対応の申請・招待が1件あります", "承認待ちの友達申請が1件あります。"},
+		{"invitations only", PendingDigest{UserID: userID, GroupInvitations: 4},
+			"未対応の申請・招待が4件あります", "承認待ちのグループ招待が4件あります。"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.title, tt.digest.Title())
+			assert.Equal(t, tt.want, tt.digest.Message())
+		})
+	}
+}
+
+func TestDigestState_IsDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	state := &DigestState{UserID: uuid.New(), NotificationID: "n-1", LastSentAt: now.Add(-day)}
+
+	var never *DigestState
+	assert.True(t, never.IsDue(now, day), "first digest is due")
+	assert.False(t, never.IsDue(now, 0), "digest turned off")
+	assert.True(t, state.IsDue(now, day), "interval elapsed")
+	assert.False(t, state.IsDue(now.Add(-time.Minute), day), "interval not elapsed")
+	assert.False(t, state.IsDue(now, 7*day), "weekly digest not due yet")
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,6 +29,8 @@ type Friendship struct {
 	UpdatedAt   time.Time        `json:"updated_at"`
 	AcceptedAt  *time.Time       `json:"accepted_at,omitempty"`
 	BlockedAt   *time.Time       `json:"blocked_at,omitempty"`
+	// 放置された申請へのリマインダー送信日時（クリーンアップ対象の取得時のみ設定）
+	ReminderSentAt *time.Time `json:"reminder_sent_at,omitempty"`
 }
 
 // NewFriendship は新しい友達申請を作成する
@@ -52,6 +55,7 @@ func (f *Friendship) Accept() {
 }
 
 // Block はユーザーをブロックする
+// ブロック関係ではRequesterIDがブロックした側、AddresseeIDがブロックされた側となる
 func (f *Friendship) Block() {
 	f.Status = FriendshipStatusBlocked
 	now := time.Now()
@@ -69,6 +73,67 @@ func (f *Friendship) IsBlocked() bool {
 	return f.Status == FriendshipStatusBlocked
 }
 
+// IsBlockedBy は指定ユーザーがブロックした関係かチェック
+func (f *Friendship) IsBlockedBy(userID uuid.UUID) bool {
+	return f.IsBlocked() && f.RequesterID == userID
+}
+
+// FriendSort は友達一覧の並び順
+type FriendSort string
+
+const (
+	FriendSortRecentlyAdded  FriendSort = "recently_added"  // 友達になった日時の新しい順
+	FriendSortUsername       FriendSort = "username"        // ユーザー名順
+	FriendSortRecentlyActive FriendSort = "recently_active" // 最終ログインの新しい順
+)
+
+// ParseFriendSort は並び順を解析する（空文字の場合は友達になった日時の新しい順）
+func ParseFriendSort(value string) (FriendSort, error) {
+	switch sort := FriendSort(value); sort {
+	case "":
+		return FriendSortRecentlyAdded, nil
+	case FriendSortRecentlyAdded, FriendSortUsername, FriendSortRecentlyActive:
+		return sort, nil
+	default:
+		return "", ErrInvalidFriendSort
+	}
+}
+
+// PendingCleanupAction は放置された友達申請に対する処理
+type PendingCleanupAction int
+
+const (
+	PendingCleanupNone   PendingCleanupAction = iota // 処理なし
+	PendingCleanupRemind                             // 削除前のリマインダーを送信
+	PendingCleanupDelete                             // 申請を削除
+)
+
+// PendingCleanupAction は承認待ちの申請に対するクリーンアップ処理を判定する
+// ttl経過のreminderLead前にリマインダーを送り、リマインダーからreminderLead以上経過かつttl経過で削除する
+func (f *Friendship) PendingCleanupAction(now time.Time, ttl, reminderLead time.Duration) PendingCleanupAction {
+	if f.Status != FriendshipStatusPending || ttl <= 0 {
+		return PendingCleanupNone
+	}
+
+	age := now.Sub(f.CreatedAt)
+	if f.ReminderSentAt == nil {
+		if age >= ttl-reminderLead {
+			return PendingCleanupRemind
+		}
+		return PendingCleanupNone
+	}
+
+	if age >= ttl && now.Sub(*f.ReminderSentAt) >= reminderLead {
+		return PendingCleanupDelete
+	}
+	return PendingCleanupNone
+}
+
+// MarkReminderSent はリマインダーの送信を記録する
+func (f *Friendship) MarkReminderSent(now time.Time) {
+	f.ReminderSentAt = &now
+}
+
 // InvitationType は招待の種類
 type InvitationType string
 
@@ -86,6 +151,13 @@ const (
 	MethodURL   InvitationMethod = "URL"    // URL共有
 )
 
+// グループ招待で参加するときのロール（Metadata の group_role に保存する）
+const (
+	MetadataKeyGroupRole = "group_role"
+	GroupRoleMember      = "MEMBER" // メンバー（未指定の場合）
+	GroupRoleGuest       = "GUEST"  // ゲスト（閲覧のみ）
+)
+
 // InvitationStatus は招待のステータス
 type InvitationStatus string
 
@@ -95,6 +167,8 @@ const (
 	InvitationStatusDeclined InvitationStatus = "DECLINED" // 拒否
 	InvitationStatusExpired  InvitationStatus = "EXPIRED"  // 期限切れ
 	InvitationStatusCanceled InvitationStatus = "CANCELED" // キャンセル
+	// 招待メールが配信不能（バウンス）
+	InvitationStatusUndeliverable InvitationStatus = "UNDELIVERABLE"
 )
 
 // Invitation は招待を表すドメインエンティティ
@@ -118,6 +192,11 @@ type Invitation struct {
 	Message  string            `json:"message"`
 	Metadata map[string]string `json:"metadata,omitempty"`
 
+	// 招待メールの送信状況
+	EmailSentAt    *time.Time `json:"email_sent_at,omitempty"`
+	EmailSendCount int        `json:"email_send_count"`
+	BounceReason   string     `json:"bounce_reason,omitempty"`
+
 	ExpiresAt  time.Time  `json:"expires_at"`
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
@@ -131,6 +210,13 @@ type InviteeInfo struct {
 	Phone    string `json:"phone,omitempty"`
 }
 
+// InviteLinks は招待のWeb URLと、モバイルアプリで直接開くためのリンク
+type InviteLinks struct {
+	URL           string `json:"url"`                      // ブラウザで開くURL
+	DeepLink      string `json:"deep_link,omitempty"`      // アプリのカスタムスキーム（例: yoteiplus://invite/{code}）
+	UniversalLink string `json:"universal_link,omitempty"` // iOSのUniversal Links・AndroidのApp Links（アプリ未インストールの場合はWebで開く）
+}
+
 // NewInvitation は新しい招待を作成する
 func NewInvitation(
 	invitationType InvitationType,
@@ -177,6 +263,13 @@ func generateInvitationURL(invitation *Invitation) string {
 	return "https://yotei-plus.com/invite/" + invitation.Code
 }
 
+// EnsureCode は招待コードがない場合に生成する（メールで招待を送る場合に使用）
+func (i *Invitation) EnsureCode() {
+	if i.Code == "" {
+		i.Code = generateInvitationCode()
+	}
+}
+
 // SetInvitee は被招待者を設定する（登録済みユーザー）
 func (i *Invitation) SetInvitee(userID uuid.UUID) {
 	i.InviteeID = &userID
@@ -258,13 +351,107 @@ func (i *Invitation) AddMetadata(key, value string) {
 	i.UpdatedAt = time.Now()
 }
 
+// SetGroupRole はグループ招待で参加するときのロールを設定する
+func (i *Invitation) SetGroupRole(role string) error {
+	if i.Type != InvitationTypeGroup {
+		return fmt.Errorf("%w: role is only available for group invitations", ErrInvalidGroupRole)
+	}
+	if role != GroupRoleMember && role != GroupRoleGuest {
+		return fmt.Errorf("%w: %s", ErrInvalidGroupRole, role)
+	}
+	i.AddMetadata(MetadataKeyGroupRole, role)
+	return nil
+}
+
+// GroupRole はグループ招待で参加するときのロールを返す（未設定の場合は MEMBER）
+func (i *Invitation) GroupRole() string {
+	if role := i.Metadata[MetadataKeyGroupRole]; role != "" {
+		return role
+	}
+	return GroupRoleMember
+}
+
+// InviteeEmail は被招待者のメールアドレスを返す（未設定の場合は空文字）
+func (i *Invitation) InviteeEmail() string {
+	if i.InviteeInfo == nil {
+		return ""
+	}
+	return i.InviteeInfo.Email
+}
+
+// CanSendEmail は招待メールを送信できる状態かチェック
+func (i *Invitation) CanSendEmail() error {
+	if i.InviteeEmail() == "" {
+		return ErrInviteeEmailMissing
+	}
+	if !i.IsValid() {
+		return ErrInvalidInvitationStatus
+	}
+	if i.EmailSendCount >= MaxInvitationEmailSends {
+		return ErrInvitationEmailLimit
+	}
+	return nil
+}
+
+// NextEmailAllowedAt は招待メールを再送できる日時を返す
+func (i *Invitation) NextEmailAllowedAt(cooldown time.Duration) time.Time {
+	if i.EmailSentAt == nil {
+		return time.Time{}
+	}
+	return i.EmailSentAt.Add(cooldown)
+}
+
+// RecordEmailSent は招待メールの送信を記録する
+func (i *Invitation) RecordEmailSent(now time.Time) {
+	i.EmailSentAt = &now
+	i.EmailSendCount++
+	i.UpdatedAt = now
+}
+
+// MarkUndeliverable は招待メールのバウンスにより招待を配信不能にする
+func (i *Invitation) MarkUndeliverable(reason string) error {
+	if i.Status != InvitationStatusPending {
+		return ErrInvalidInvitationStatus
+	}
+	if runes := []rune(reason); len(runes) > maxBounceReasonLength {
+		reason = string(runes[:maxBounceReasonLength])
+	}
+
+	i.Status = InvitationStatusUndeliverable
+	i.BounceReason = reason
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
 // IsFriend は友達招待かどうかをチェック
 func (i *Invitation) IsFriend() bool {
 	return i.Type == InvitationTypeFriend
 }
 
+// InvitationEmail は招待メールの送信内容
+type InvitationEmail struct {
+	InvitationID   uuid.UUID
+	To             string
+	InviterName    string
+	InvitationType InvitationType
+	Message        string
+	InviteURL      string
+	Code           string
+	ExpiresAt      time.Time
+}
+
+// 招待メールの制約
+const (
+	MaxInvitationEmailSends = 5 // 1つの招待で送信できるメールの上限（再送を含む）
+	maxBounceReasonLength   = 255
+)
+
 // エラー定義
 var (
 	ErrInvitationExpired       = errors.New("invitation has expired")
 	ErrInvalidInvitationStatus = errors.New("invalid invitation status")
+	ErrInviteeEmailMissing     = errors.New("invitation has no invitee email")
+	ErrInvitationEmailLimit    = errors.New("invitation email send limit reached")
+	ErrInvalidFriendSort       = errors.New("invalid friend sort order")
+	ErrInvalidGroupRole        = errors.New("invalid group invitation role")
 )
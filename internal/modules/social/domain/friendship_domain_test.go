@@ -262,3 +262,87 @@ func TestFriendship_AcceptAfterBlock(t *testing.T) {
 	require.NotNil(t, friendship.AcceptedAt)
 	assert.True(t, friendship.AcceptedAt.After(*blockedAt))
 }
+
+func TestFriendship_PendingCleanupAction(t *testing.T) {
+	ttl := 30 * 24 * time.Hour
+	lead := 7 * 24 * time.Hour
+	now := time.Now()
+
+	newPending := func(age time.Duration) *Friendship {
+		friendship := NewFriendship(uuid.New(), uuid.New())
+		friendship.CreatedAt = now.Add(-age)
+		return friendship
+	}
+
+	t.Run("recent request needs no action", func(t *testing.T) {
+		assert.Equal(t, PendingCleanupNone, newPending(10*24*time.Hour).PendingCleanupAction(now, ttl, lead))
+	})
+
+	t.Run("request nearing ttl gets a reminder", func(t *testing.T) {
+		assert.Equal(t, PendingCleanupRemind, newPending(24*24*time.Hour).PendingCleanupAction(now, ttl, lead))
+	})
+
+	t.Run("expired request without reminder gets a reminder first", func(t *testing.T) {
+		assert.Equal(t, PendingCleanupRemind, newPending(40*24*time.Hour).PendingCleanupAction(now, ttl, lead))
+	})
+
+	t.Run("reminded request is deleted after lead time", func(t *testing.T) {
+		friendship := newPending(31 * 24 * time.Hour)
+		friendship.MarkReminderSent(now.Add(-lead))
+		assert.Equal(t, PendingCleanupDelete, friendship.PendingCleanupAction(now, ttl, lead))
+	})
+
+	t.Run("recently reminded request is kept", func(t *testing.T) {
+		friendship := newPending(40 * 24 * time.Hour)
+		friendship.MarkReminderSent(now.Add(-24 * time.Hour))
+		assert.Equal(t, PendingCleanupNone, friendship.PendingCleanupAction(now, ttl, lead))
+	})
+
+	t.Run("accepted request is ignored", func(t *testing.T) {
+		friendship := newPending(40 * 24 * time.Hour)
+		friendship.Accept()
+		assert.Equal(t, PendingCleanupNone, friendship.PendingCleanupAction(now, ttl, lead))
+	})
+
+	t.Run("zero ttl disables cleanup", func(t *testing.T) {
+		assert.Equal(t, PendingCleanupNone, newPending(400*24*time.Hour).PendingCleanupAction(now, 0, lead))
+	})
+}
+
+func TestFriendship_IsBlockedBy(t *testing.T) {
+	blockerID := uuid.New()
+	targetID := uuid.New()
+
+	friendship := NewFriendship(blockerID, targetID)
+	assert.False(t, friendship.IsBlockedBy(blockerID), "Pending request is not a block")
+
+	friendship.Block()
+	assert.True(t, friendship.IsBlockedBy(blockerID))
+	assert.False(t, friendship.IsBlockedBy(targetID), "Blocked user is not the blocker")
+}
+
+func TestParseFriendSort(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected FriendSort
+		wantErr  bool
+	}{
+		{"", FriendSortRecentlyAdded, false},
+		{"recently_added", FriendSortRecentlyAdded, false},
+		{"username", FriendSortUsername, false},
+		{"recently_active", FriendSortRecentlyActive, false},
+		{"oldest", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			sort, err := ParseFriendSort(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFriendSort)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sort)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -433,6 +434,20 @@ func TestInvitation_IsValid(t *testing.T) {
 	}
 }
 
+func TestInvitation_SetGroupRole(t *testing.T) {
+	invitation := NewInvitation(InvitationTypeGroup, MethodCode, uuid.New(), "Test", 24)
+	assert.Equal(t, GroupRoleMember, invitation.GroupRole(), "defaults to member")
+
+	require.NoError(t, invitation.SetGroupRole(GroupRoleGuest))
+	assert.Equal(t, GroupRoleGuest, invitation.GroupRole())
+
+	assert.ErrorIs(t, invitation.SetGroupRole("ADMIN"), ErrInvalidGroupRole)
+	assert.Equal(t, GroupRoleGuest, invitation.GroupRole())
+
+	friendInvitation := NewInvitation(InvitationTypeFriend, MethodCode, uuid.New(), "Test", 24)
+	assert.ErrorIs(t, friendInvitation.SetGroupRole(GroupRoleGuest), ErrInvalidGroupRole)
+}
+
 func TestInvitation_AddMetadata(t *testing.T) {
 	// Setup
 	invitation := NewInvitation(InvitationTypeFriend, MethodCode, uuid.New(), "Test", 24)
@@ -605,3 +620,77 @@ func TestInvitation_EdgeCases(t *testing.T) {
 		assert.WithinDuration(t, expectedExpiration, invitation.ExpiresAt, time.Second)
 	})
 }
+
+func TestInvitation_CanSendEmail(t *testing.T) {
+	newEmailInvitation := func() *Invitation {
+		invitation := NewInvitation(InvitationTypeFriend, MethodInApp, uuid.New(), "Hello", 24)
+		invitation.SetInviteeInfo(InviteeInfo{Email: "invitee@example.com"})
+		return invitation
+	}
+
+	t.Run("pending invitation with email", func(t *testing.T) {
+		assert.NoError(t, newEmailInvitation().CanSendEmail())
+	})
+
+	t.Run("no invitee email", func(t *testing.T) {
+		invitation := NewInvitation(InvitationTypeFriend, MethodCode, uuid.New(), "Hello", 24)
+		assert.ErrorIs(t, invitation.CanSendEmail(), ErrInviteeEmailMissing)
+	})
+
+	t.Run("expired invitation", func(t *testing.T) {
+		invitation := newEmailInvitation()
+		invitation.ExpiresAt = time.Now().Add(-time.Hour)
+		assert.ErrorIs(t, invitation.CanSendEmail(), ErrInvalidInvitationStatus)
+	})
+
+	t.Run("send limit reached", func(t *testing.T) {
+		invitation := newEmailInvitation()
+		invitation.EmailSendCount = MaxInvitationEmailSends
+		assert.ErrorIs(t, invitation.CanSendEmail(), ErrInvitationEmailLimit)
+	})
+}
+
+func TestInvitation_RecordEmailSent(t *testing.T) {
+	invitation := NewInvitation(InvitationTypeFriend, MethodCode, uuid.New(), "Hello", 24)
+	assert.True(t, invitation.NextEmailAllowedAt(10*time.Minute).IsZero())
+
+	now := time.Now()
+	invitation.RecordEmailSent(now)
+
+	require.NotNil(t, invitation.EmailSentAt)
+	assert.Equal(t, 1, invitation.EmailSendCount)
+	assert.Equal(t, now.Add(10*time.Minute), invitation.NextEmailAllowedAt(10*time.Minute))
+}
+
+func TestInvitation_MarkUndeliverable(t *testing.T) {
+	t.Run("pending invitation", func(t *testing.T) {
+		invitation := NewInvitation(InvitationTypeFriend, MethodCode, uuid.New(), "Hello", 24)
+
+		err := invitation.MarkUndeliverable(strings.Repeat("あ", 300))
+
+		require.NoError(t, err)
+		assert.Equal(t, InvitationStatusUndeliverable, invitation.Status)
+		assert.Len(t, []rune(invitation.BounceReason), 255)
+		assert.False(t, invitation.IsValid())
+	})
+
+	t.Run("accepted invitation", func(t *testing.T) {
+		invitation := NewInvitation(InvitationTypeFriend, MethodCode, uuid.New(), "Hello", 24)
+		require.NoError(t, invitation.Accept())
+
+		assert.ErrorIs(t, invitation.MarkUndeliverable("550 User unknown"), ErrInvalidInvitationStatus)
+		assert.Equal(t, InvitationStatusAccepted, invitation.Status)
+	})
+}
+
+func TestInvitation_EnsureCode(t *testing.T) {
+	invitation := NewInvitation(InvitationTypeFriend, MethodInApp, uuid.New(), "Hello", 24)
+	assert.Empty(t, invitation.Code)
+
+	invitation.EnsureCode()
+	code := invitation.Code
+	assert.NotEmpty(t, code)
+
+	invitation.EnsureCode()
+	assert.Equal(t, code, invitation.Code)
+}
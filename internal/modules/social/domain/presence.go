@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PresenceStatus はユーザーのオンライン状態
+type PresenceStatus string
+
+const (
+	PresenceStatusOnline  PresenceStatus = "ONLINE"  // WebSocket接続中
+	PresenceStatusAway    PresenceStatus = "AWAY"    // 接続中だが離席中
+	PresenceStatusOffline PresenceStatus = "OFFLINE" // 未接続
+)
+
+// Presence はユーザーのオンライン状態と最終接続日時
+type Presence struct {
+	UserID     uuid.UUID      `json:"user_id"`
+	Status     PresenceStatus `json:"status"`
+	LastSeenAt *time.Time     `json:"last_seen_at,omitempty"` // 最後にハートビートを受信した日時
+}
+
+// NewPresence は保存された状態からPresenceを作成する（状態がない場合はオフライン）
+func NewPresence(userID uuid.UUID, status PresenceStatus, lastSeenAt *time.Time) *Presence {
+	if status != PresenceStatusOnline && status != PresenceStatusAway {
+		status = PresenceStatusOffline
+	}
+	return &Presence{
+		UserID:     userID,
+		Status:     status,
+		LastSeenAt: lastSeenAt,
+	}
+}
+
+// IsConnected はWebSocket接続中（オンラインまたは離席中）か
+func (p *Presence) IsConnected() bool {
+	return p.Status == PresenceStatusOnline || p.Status == PresenceStatusAway
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPresence(t *testing.T) {
+	userID := uuid.New()
+	lastSeen := time.Now()
+
+	tests := []struct {
+		name      string
+		status    PresenceStatus
+		expected  PresenceStatus
+		connected bool
+	}{
+		{"online", PresenceStatusOnline, PresenceStatusOnline, true},
+		{"away", PresenceStatusAway, PresenceStatusAway, true},
+		{"offline", PresenceStatusOffline, PresenceStatusOffline, false},
+		{"missing status is offline", "", PresenceStatusOffline, false},
+		{"unknown status is offline", "BUSY", PresenceStatusOffline, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presence := NewPresence(userID, tt.status, &lastSeen)
+			assert.Equal(t, userID, presence.UserID)
+			assert.Equal(t, tt.expected, presence.Status)
+			assert.Equal(t, tt.connected, presence.IsConnected())
+			assert.Equal(t, &lastSeen, presence.LastSeenAt)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// プロフィールの制約
+const (
+	MaxDisplayNameLength = 50
+	MaxBioLength         = 160
+	MaxAvatarURLLength   = 512
+
+	// MaxProfileStreakDays は連続達成日数を数える日数の上限
+	MaxProfileStreakDays = 90
+	// ProfileStatsDays は完了したタスク数を数える直近の日数
+	ProfileStatsDays = 30
+)
+
+var (
+	ErrProfileNotFound     = errors.New("profile not found")
+	ErrInvalidProfileInput = errors.New("invalid profile input")
+)
+
+// ProfileVisibility は公開プロフィールの公開範囲
+type ProfileVisibility string
+
+const (
+	ProfileVisibilityPublic  ProfileVisibility = "PUBLIC"  // ログインしていない人を含む全員（デフォルト）
+	ProfileVisibilityFriends ProfileVisibility = "FRIENDS" // 友達のみ
+	ProfileVisibilityPrivate ProfileVisibility = "PRIVATE" // 本人のみ
+)
+
+// IsValid は有効な公開範囲かチェック
+func (v ProfileVisibility) IsValid() bool {
+	switch v {
+	case ProfileVisibilityPublic, ProfileVisibilityFriends, ProfileVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// ProfileRelationship は公開プロフィールの閲覧者と本人の関係
+type ProfileRelationship string
+
+const (
+	ProfileRelationshipSelf            ProfileRelationship = "SELF"             // 本人
+	ProfileRelationshipFriend          ProfileRelationship = "FRIEND"           // 友達
+	ProfileRelationshipRequestSent     ProfileRelationship = "REQUEST_SENT"     // 閲覧者が友達申請を送信済み
+	ProfileRelationshipRequestReceived ProfileRelationship = "REQUEST_RECEIVED" // 閲覧者が友達申請を受信済み
+	ProfileRelationshipNone            ProfileRelationship = "NONE"             // 関係なし
+	ProfileRelationshipAnonymous       ProfileRelationship = "ANONYMOUS"        // ログインしていない閲覧者
+)
+
+// ProfileSettings はユーザーが設定する公開プロフィールの内容と公開範囲
+type ProfileSettings struct {
+	UserID      uuid.UUID         `json:"user_id"`
+	DisplayName string            `json:"display_name"` // 空の場合はユーザー名を表示する
+	AvatarURL   string            `json:"avatar_url"`
+	Bio         string            `json:"bio"`
+	Visibility  ProfileVisibility `json:"visibility"`
+	ShowStats   bool              `json:"show_stats"` // 連続達成日数などの統計を公開プロフィールに含める
+	UpdatedAt   time.Time         `json:"updated_at"`
+} // @name ProfileSettings
+
+// DefaultProfileSettings は設定を保存していないユーザーの設定を返す（全員に公開、統計は非公開）
+func DefaultProfileSettings(userID uuid.UUID) *ProfileSettings {
+	return &ProfileSettings{
+		UserID:     userID,
+		Visibility: ProfileVisibilityPublic,
+		ShowStats:  false,
+	}
+}
+
+// Validate は設定の内容を検証する
+func (s *ProfileSettings) Validate() error {
+	if !s.Visibility.IsValid() {
+		return fmt.Errorf("%w: invalid visibility: %s", ErrInvalidProfileInput, s.Visibility)
+	}
+	if utf8.RuneCountInString(s.DisplayName) > MaxDisplayNameLength {
+		return fmt.Errorf("%w: display name must be at most %d characters", ErrInvalidProfileInput, MaxDisplayNameLength)
+	}
+	if utf8.RuneCountInString(s.Bio) > MaxBioLength {
+		return fmt.Errorf("%w: bio must be at most %d characters", ErrInvalidProfileInput, MaxBioLength)
+	}
+	if s.AvatarURL != "" {
+		if len(s.AvatarURL) > MaxAvatarURLLength || !strings.HasPrefix(s.AvatarURL, "https://") {
+			return fmt.Errorf("%w: avatar URL must be an https URL of at most %d characters", ErrInvalidProfileInput, MaxAvatarURLLength)
+		}
+	}
+	return nil
+}
+
+// VisibleTo は閲覧者との関係でプロフィールの内容を表示できるかを判定する
+func (s *ProfileSettings) VisibleTo(relationship ProfileRelationship) bool {
+	switch relationship {
+	case ProfileRelationshipSelf:
+		return true
+	case ProfileRelationshipFriend:
+		return s.Visibility != ProfileVisibilityPrivate
+	default:
+		return s.Visibility == ProfileVisibilityPublic
+	}
+}
+
+// ProfileStats は公開プロフィールに表示するタスクの統計
+type ProfileStats struct {
+	CurrentStreak  int `json:"current_streak" example:"5"`   // 今日（今日の完了がない場合は昨日）まで毎日タスクを完了した日数
+	CompletedTasks int `json:"completed_tasks" example:"42"` // 直近30日間に完了したタスク数
+} // @name ProfileStats
+
+// NewProfileStats はタスクの完了日時から統計を作成する
+// completedAt には連続達成日数を数えるため直近30日より前の完了も含めてよい。日付の区切りは now のタイムゾーンを使う
+func NewProfileStats(completedAt []time.Time, now time.Time) *ProfileStats {
+	loc := now.Location()
+	from := now.AddDate(0, 0, -ProfileStatsDays)
+	stats := &ProfileStats{}
+	days := make(map[string]bool)
+	for _, t := range completedAt {
+		if t.After(now) {
+			continue
+		}
+		days[t.In(loc).Format("2006-01-02")] = true
+		if !t.Before(from) {
+			stats.CompletedTasks++
+		}
+	}
+
+	// 今日の完了がない場合は昨日から遡って数える
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if !days[day.Format("2006-01-02")] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for stats.CurrentStreak < MaxProfileStreakDays && days[day.Format("2006-01-02")] {
+		stats.CurrentStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return stats
+}
+
+// ProfileViewer は閲覧者から見た本人との関係（友達申請ボタンの表示用）
+type ProfileViewer struct {
+	Relationship         ProfileRelationship `json:"relationship" example:"NONE"`
+	CanSendFriendRequest bool                `json:"can_send_friend_request" example:"true"`
+} // @name ProfileViewer
+
+// PublicProfile は閲覧者に合わせて公開範囲で絞り込んだプロフィール
+// 公開範囲外の場合はユーザー名のみを返し、Restricted を true にする
+type PublicProfile struct {
+	UserID      uuid.UUID      `json:"user_id"`
+	Username    string         `json:"username" example:"user123"`
+	DisplayName string         `json:"display_name,omitempty" example:"山田 太郎"`
+	AvatarURL   string         `json:"avatar_url,omitempty" example:"https://cdn.example.com/avatars/user123.png"`
+	Bio         string         `json:"bio,omitempty"`
+	Restricted  bool           `json:"restricted" example:"false"`
+	Stats       *ProfileStats  `json:"stats,omitempty"`
+	Viewer      *ProfileViewer `json:"viewer"`
+} // @name PublicProfile
+
+// NewPublicProfile は閲覧者との関係に応じて設定を絞り込んだ公開プロフィールを作成する
+// stats は統計を公開する設定で表示できる場合のみ含める
+func NewPublicProfile(userID uuid.UUID, username string, settings *ProfileSettings, relationship ProfileRelationship, stats *ProfileStats) *PublicProfile {
+	profile := &PublicProfile{
+		UserID:   userID,
+		Username: username,
+		Viewer: &ProfileViewer{
+			Relationship:         relationship,
+			CanSendFriendRequest: relationship == ProfileRelationshipNone,
+		},
+	}
+
+	if !settings.VisibleTo(relationship) {
+		profile.Restricted = true
+		return profile
+	}
+
+	profile.DisplayName = settings.DisplayName
+	if profile.DisplayName == "" {
+		profile.DisplayName = username
+	}
+	profile.AvatarURL = settings.AvatarURL
+	profile.Bio = settings.Bio
+	if settings.ShowStats || relationship == ProfileRelationshipSelf {
+		profile.Stats = stats
+	}
+	return profile
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *ProfileSettings)
+		wantErr bool
+	}{
+		{"default settings", func(s *ProfileSettings) {}, false},
+		{"full settings", func(s *ProfileSettings) {
+			s.DisplayName = "山田 太郎"
+			s.AvatarURL = "https://cdn.example.com/a.png"
+			s.Bio = strings.Repeat("あ", MaxBioLength)
+			s.Visibility = ProfileVisibilityFriends
+		}, false},
+		{"invalid visibility", func(s *ProfileSettings) { s.Visibility = "SECRET" }, true},
+		{"display name too long", func(s *ProfileSettings) { s.DisplayName = strings.Repeat("a", MaxDisplayNameLength+1) }, true},
+		{"bio too long", func(s *ProfileSettings) { s.Bio = strings.Repeat("あ", MaxBioLength+1) }, true},
+		{"avatar must be https", func(s *ProfileSettings) { s.AvatarURL = "http://cdn.example.com/a.png" }, true},
+		{"avatar too long", func(s *ProfileSettings) { s.AvatarURL = "https://" + strings.Repeat("a", MaxAvatarURLLength) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultProfileSettings(uuid.New())
+			tt.modify(settings)
+			err := settings.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidProfileInput))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProfileSettings_VisibleTo(t *testing.T) {
+	relationships := []ProfileRelationship{
+		ProfileRelationshipSelf,
+		ProfileRelationshipFriend,
+		ProfileRelationshipRequestSent,
+		ProfileRelationshipNone,
+		ProfileRelationshipAnonymous,
+	}
+	expected := map[ProfileVisibility][]bool{
+		ProfileVisibilityPublic:  {true, true, true, true, true},
+		ProfileVisibilityFriends: {true, true, false, false, false},
+		ProfileVisibilityPrivate: {true, false, false, false, false},
+	}
+
+	for visibility, want := range expected {
+		settings := &ProfileSettings{Visibility: visibility}
+		for i, relationship := range relationships {
+			assert.Equal(t, want[i], settings.VisibleTo(relationship), "%s to %s", visibility, relationship)
+		}
+	}
+}
+
+func TestNewPublicProfile(t *testing.T) {
+	userID := uuid.New()
+	stats := &ProfileStats{CurrentStreak: 3, CompletedTasks: 10}
+
+	t.Run("display name defaults to username", func(t *testing.T) {
+		profile := NewPublicProfile(userID, "alice", DefaultProfileSettings(userID), ProfileRelationshipAnonymous, stats)
+		assert.False(t, profile.Restricted)
+		assert.Equal(t, "alice", profile.DisplayName)
+		assert.Nil(t, profile.Stats)
+		assert.Equal(t, ProfileRelationshipAnonymous, profile.Viewer.Relationship)
+		assert.False(t, profile.Viewer.CanSendFriendRequest)
+	})
+
+	t.Run("stats shown when enabled", func(t *testing.T) {
+		settings := DefaultProfileSettings(userID)
+		settings.DisplayName = "Alice"
+		settings.ShowStats = true
+		profile := NewPublicProfile(userID, "alice", settings, ProfileRelationshipNone, stats)
+		assert.Equal(t, "Alice", profile.DisplayName)
+		assert.Equal(t, stats, profile.Stats)
+		assert.True(t, profile.Viewer.CanSendFriendRequest)
+	})
+
+	t.Run("self always sees stats", func(t *testing.T) {
+		profile := NewPublicProfile(userID, "alice", DefaultProfileSettings(userID), ProfileRelationshipSelf, stats)
+		assert.Equal(t, stats, profile.Stats)
+		assert.False(t, profile.Viewer.CanSendFriendRequest)
+	})
+
+	t.Run("restricted outside visibility", func(t *testing.T) {
+		settings := DefaultProfileSettings(userID)
+		settings.DisplayName = "Alice"
+		settings.Bio = "secret"
+		settings.ShowStats = true
+		settings.Visibility = ProfileVisibilityFriends
+		profile := NewPublicProfile(userID, "alice", settings, ProfileRelationshipNone, stats)
+		assert.True(t, profile.Restricted)
+		assert.Equal(t, "alice", profile.Username)
+		assert.Empty(t, profile.DisplayName)
+		assert.Empty(t, profile.Bio)
+		assert.Nil(t, profile.Stats)
+		assert.True(t, profile.Viewer.CanSendFriendRequest)
+	})
+}
+
+func TestNewProfileStats(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := func(daysAgo int) time.Time { return now.AddDate(0, 0, -daysAgo) }
+
+	t.Run("counts streak ending today", func(t *testing.T) {
+		stats := NewProfileStats([]time.Time{day(0), day(0), day(1), day(2), day(4)}, now)
+		assert.Equal(t, 3, stats.CurrentStreak)
+		assert.Equal(t, 5, stats.CompletedTasks)
+	})
+
+	t.Run("streak continues from yesterday", func(t *testing.T) {
+		stats := NewProfileStats([]time.Time{day(1), day(2)}, now)
+		assert.Equal(t, 2, stats.CurrentStreak)
+	})
+
+	t.Run("streak broken", func(t *testing.T) {
+		stats := NewProfileStats([]time.Time{day(2), day(3)}, now)
+		assert.Equal(t, 0, stats.CurrentStreak)
+	})
+
+	t.Run("completed tasks only within window", func(t *testing.T) {
+		stats := NewProfileStats([]time.Time{day(ProfileStatsDays + 1), day(5), now.Add(time.Hour)}, now)
+		assert.Equal(t, 1, stats.CompletedTasks)
+	})
+
+	t.Run("streak capped", func(t *testing.T) {
+		var completedAt []time.Time
+		for i := 0; i <= MaxProfileStreakDays+5; i++ {
+			completedAt = append(completedAt, day(i))
+		}
+		stats := NewProfileStats(completedAt, now)
+		assert.Equal(t, MaxProfileStreakDays, stats.CurrentStreak)
+	})
+}
@@ -20,7 +20,7 @@ func NewSqlHandler() SqlHandler {
 	if err != nil {
 		panic(fmt.Sprintf("Failed to load config: %v", err))
 	}
-	conn, err := database.NewMySQLConnection(cfg)
+	conn, err := database.NewConnection(cfg)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to connect to database: %v", err))
 	}
@@ -0,0 +1,229 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmlTemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/smtpmail"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// InvitationIDHeader はバウンス通知で招待を特定するためのメールヘッダー
+const InvitationIDHeader = "X-Yotei-Invitation-ID"
+
+// SMTPEmailGateway はSMTPで招待メールを送信するゲートウェイ実装
+type SMTPEmailGateway struct {
+	config *config.Config
+	logger logger.Logger
+	// SMTPサーバーが応答しない・失敗が続く場合に送信をやめるサーキットブレーカー（nilの場合は制限しない）
+	breaker *circuitbreaker.Breaker
+	// SMTP送信関数（smtpmail.Send）
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewInvitationEmailGateway は設定に応じた招待メールゲートウェイを作成する
+// SMTP_HOSTが未設定の場合は送信内容をログに出力するだけのゲートウェイを返す
+func NewInvitationEmailGateway(config *config.Config, breaker *circuitbreaker.Breaker, logger logger.Logger) usecase.InvitationEmailGateway {
+	if config.External.SMTPHost == "" {
+		return &LogEmailGateway{logger: logger}
+	}
+	return &SMTPEmailGateway{
+		config:   config,
+		logger:   logger,
+		breaker:  breaker,
+		sendMail: smtpmail.Send,
+	}
+}
+
+// SendInvitationEmail は招待メールを送信する
+func (g *SMTPEmailGateway) SendInvitationEmail(ctx context.Context, email domain.InvitationEmail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(g.config.External.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_FROM address: %w", err)
+	}
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return fmt.Errorf("invalid invitee email address: %w", err)
+	}
+
+	msg, err := BuildInvitationMessage(from, to, email)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if g.config.External.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", g.config.External.SMTPUsername, g.config.External.SMTPPassword, g.config.External.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(g.config.External.SMTPHost, g.config.External.SMTPPort)
+	err = g.breaker.Do(ctx, func(ctx context.Context) error {
+		return g.sendMail(ctx, addr, auth, from.Address, []string{to.Address}, msg)
+	})
+	if err != nil {
+		g.logger.Error("Failed to send invitation email",
+			logger.Any("invitationID", email.InvitationID),
+			logger.Error(err))
+		return fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	g.logger.Info("Invitation email sent", logger.Any("invitationID", email.InvitationID))
+	return nil
+}
+
+// LogEmailGateway はSMTP未設定時に招待メールをログ出力するゲートウェイ実装（開発用）
+type LogEmailGateway struct {
+	logger logger.Logger
+}
+
+// SendInvitationEmail は招待メールの内容をログに出力する
+func (g *LogEmailGateway) SendInvitationEmail(ctx context.Context, email domain.InvitationEmail) error {
+	g.logger.Info("Invitation email (SMTP not configured)",
+		logger.Any("invitationID", email.InvitationID),
+		logger.Any("to", email.To),
+		logger.Any("inviteURL", email.InviteURL))
+	return nil
+}
+
+// BuildInvitationMessage はテキスト・HTMLのマルチパート形式の招待メールを作成する
+func BuildInvitationMessage(from, to *mail.Address, email domain.InvitationEmail) ([]byte, error) {
+	data := newInvitationEmailData(email)
+
+	var text bytes.Buffer
+	if err := invitationTextTemplate.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render invitation email text: %w", err)
+	}
+	var html bytes.Buffer
+	if err := invitationHTMLTemplate.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render invitation email html: %w", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", text.Bytes()},
+		{"text/html; charset=UTF-8", html.Bytes()},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.BEncoding.Encode("UTF-8", data.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + writer.Boundary()},
+		{InvitationIDHeader, email.InvitationID.String()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// invitationEmailData はメールテンプレートに渡すデータ
+type invitationEmailData struct {
+	Subject     string
+	InviterName string
+	Target      string
+	Message     string
+	InviteURL   string
+	Code        string
+	ExpiresAt   string
+}
+
+func newInvitationEmailData(email domain.InvitationEmail) invitationEmailData {
+	target := "友達"
+	if email.InvitationType == domain.InvitationTypeGroup {
+		target = "グループ"
+	}
+	return invitationEmailData{
+		Subject:     fmt.Sprintf("%sさんからYotei+の%s招待が届いています", email.InviterName, target),
+		InviterName: email.InviterName,
+		Target:      target,
+		Message:     strings.TrimSpace(email.Message),
+		InviteURL:   email.InviteURL,
+		Code:        email.Code,
+		ExpiresAt:   email.ExpiresAt.Format("2006-01-02 15:04"),
+	}
+}
+
+var invitationTextTemplate = textTemplate.Must(textTemplate.New("invitation_text").Parse(
+	`{{.InviterName}}さんからYotei+の{{.Target}}招待が届いています。
+{{if .Message}}
+「{{.Message}}」
+{{end}}
+以下のURLから招待を受け取ってください。
+{{.InviteURL}}
+
+招待コード: {{.Code}}
+有効期限: {{.ExpiresAt}}
+
+このメールに心当たりがない場合は破棄してください。
+--
+Yotei+
+`))
+
+var invitationHTMLTemplate = htmlTemplate.Must(htmlTemplate.New("invitation_html").Parse(
+	`<!DOCTYPE html>
+<html lang="ja">
+<body style="margin:0;padding:24px;background:#f4f6fb;font-family:sans-serif;color:#1f2937;">
+  <div style="max-width:520px;margin:0 auto;background:#ffffff;border-radius:12px;overflow:hidden;">
+    <div style="background:#4f46e5;color:#ffffff;padding:20px 24px;font-size:20px;font-weight:bold;">Yotei+</div>
+    <div style="padding:24px;">
+      <p>{{.InviterName}}さんからYotei+の{{.Target}}招待が届いています。</p>
+      {{if .Message}}<blockquote style="margin:16px 0;padding:12px 16px;background:#f3f4f6;border-left:4px solid #4f46e5;">{{.Message}}</blockquote>{{end}}
+      <p style="text-align:center;margin:24px 0;">
+        <a href="{{.InviteURL}}" style="display:inline-block;padding:12px 28px;background:#4f46e5;color:#ffffff;border-radius:8px;text-decoration:none;font-weight:bold;">招待を受け取る</a>
+      </p>
+      <p>招待コード: <strong style="font-family:monospace;font-size:16px;">{{.Code}}</strong></p>
+      <p style="color:#6b7280;font-size:12px;">有効期限: {{.ExpiresAt}}<br>このメールに心当たりがない場合は破棄してください。</p>
+    </div>
+  </div>
+</body>
+</html>
+`))
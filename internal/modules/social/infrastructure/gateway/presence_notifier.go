@@ -0,0 +1,60 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PresenceChangedEventType はWebSocketで配信するオンライン状態変化イベントの種別
+const PresenceChangedEventType = "presence.changed"
+
+// UserMessageSender は接続中のユーザーにメッセージを送信する（WebSocketハブ）
+type UserMessageSender interface {
+	SendToUser(userID string, message []byte)
+}
+
+// presenceChangedEvent はWebSocketで配信するイベントのペイロード
+type presenceChangedEvent struct {
+	Type     string           `json:"type"`
+	Presence *domain.Presence `json:"presence"`
+}
+
+// WebSocketPresenceNotifier はオンライン状態の変化をWebSocketで配信する
+type WebSocketPresenceNotifier struct {
+	sender UserMessageSender
+	logger logger.Logger
+}
+
+// NewWebSocketPresenceNotifier は新しいWebSocketPresenceNotifierを作成する
+func NewWebSocketPresenceNotifier(sender UserMessageSender, logger logger.Logger) *WebSocketPresenceNotifier {
+	return &WebSocketPresenceNotifier{
+		sender: sender,
+		logger: logger,
+	}
+}
+
+// NotifyPresenceChanged はオンライン状態の変化を対象ユーザーに配信する
+func (n *WebSocketPresenceNotifier) NotifyPresenceChanged(ctx context.Context, recipientIDs []uuid.UUID, presence *domain.Presence) error {
+	message, err := json.Marshal(presenceChangedEvent{
+		Type:     PresenceChangedEventType,
+		Presence: presence,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal presence event: %w", err)
+	}
+
+	for _, recipientID := range recipientIDs {
+		n.sender.SendToUser(recipientID.String(), message)
+	}
+
+	n.logger.Debug("Presence change delivered",
+		logger.Any("userID", presence.UserID),
+		logger.Any("status", presence.Status),
+		logger.Any("recipients", len(recipientIDs)))
+	return nil
+}
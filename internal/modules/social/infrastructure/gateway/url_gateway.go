@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// invitePath は招待を開くパス（Web・Universal Links・ディープリンクで共通）
+const invitePath = "invite/"
+
+// InviteURLShortener は招待URLを短縮する（招待と同じ期限の短縮URLを返す）
+type InviteURLShortener interface {
+	ShortenInviteURL(ctx context.Context, invitationID uuid.UUID, inviteURL string) (string, error)
+}
+
+// InviteURLGateway は環境ごとの設定から招待のWeb URLとモバイルアプリ用のリンクを生成する
+type InviteURLGateway struct {
+	webURL           string
+	appScheme        string
+	universalLinkURL string
+	shortener        InviteURLShortener
+	logger           logger.Logger
+}
+
+// NewInviteURLGateway は設定から招待URLゲートウェイを作成する
+// shortener を指定した場合、Web URLは短縮URLにする（短縮に失敗した場合は元のURLを返す）
+func NewInviteURLGateway(config *config.Config, shortener InviteURLShortener, logger logger.Logger) usecase.URLGateway {
+	return &InviteURLGateway{
+		webURL:           strings.TrimRight(config.Social.InviteWebURL, "/"),
+		appScheme:        strings.TrimSuffix(config.Social.InviteAppScheme, "://"),
+		universalLinkURL: strings.TrimRight(config.Social.InviteUniversalLinkURL, "/"),
+		shortener:        shortener,
+		logger:           logger,
+	}
+}
+
+// GenerateInviteURL はブラウザで開く招待URLを生成する
+func (g *InviteURLGateway) GenerateInviteURL(ctx context.Context, invitationID uuid.UUID, code string) (string, error) {
+	inviteURL := g.webURL + "/" + invitePath + url.PathEscape(code)
+	if g.shortener == nil {
+		return inviteURL, nil
+	}
+
+	shortURL, err := g.shortener.ShortenInviteURL(ctx, invitationID, inviteURL)
+	if err != nil {
+		g.logger.WithContext(ctx).Warn("Failed to shorten invite URL",
+			logger.Any("invitationID", invitationID), logger.Error(err))
+		return inviteURL, nil
+	}
+	return shortURL, nil
+}
+
+// GenerateInviteLinks はWeb URLと、設定されている場合はディープリンク・Universal Linksを生成する
+func (g *InviteURLGateway) GenerateInviteLinks(ctx context.Context, invitationID uuid.UUID, code string) (*domain.InviteLinks, error) {
+	webURL, err := g.GenerateInviteURL(ctx, invitationID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	links := &domain.InviteLinks{URL: webURL}
+	if g.appScheme != "" {
+		links.DeepLink = g.appScheme + "://" + invitePath + url.PathEscape(code)
+	}
+	if g.universalLinkURL != "" {
+		links.UniversalLink = g.universalLinkURL + "/" + invitePath + url.PathEscape(code)
+	}
+	return links, nil
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+)
+
+// DigestStateRepository はリマインダーの送信の記録のインメモリリポジトリ
+type DigestStateRepository struct {
+	mu     sync.RWMutex
+	states map[uuid.UUID]domain.DigestState
+}
+
+// NewDigestStateRepository は新しいDigestStateRepositoryを作成する
+func NewDigestStateRepository() *DigestStateRepository {
+	return &DigestStateRepository{
+		states: make(map[uuid.UUID]domain.DigestState),
+	}
+}
+
+// ListDigestStates は全ユーザーのリマインダーの送信の記録を取得する
+func (r *DigestStateRepository) ListDigestStates(ctx context.Context) ([]*domain.DigestState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	states := make([]*domain.DigestState, 0, len(r.states))
+	for _, state := range r.states {
+		state := state
+		states = append(states, &state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].UserID.String() < states[j].UserID.String()
+	})
+	return states, nil
+}
+
+// SaveDigestState はリマインダーの送信の記録を保存する（既存の記録は上書きする）
+func (r *DigestStateRepository) SaveDigestState(ctx context.Context, state *domain.DigestState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[state.UserID] = *state
+	return nil
+}
+
+// DeleteDigestState はリマインダーの送信の記録を削除する
+func (r *DigestStateRepository) DeleteDigestState(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.states, userID)
+	return nil
+}
@@ -0,0 +1,406 @@
+// Package memory はソーシャル機能のリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+)
+
+// UserProfile は友達一覧の並び替えに使うユーザー情報
+type UserProfile struct {
+	Username  string
+	LastLogin *time.Time
+}
+
+// FriendshipRepository は友達関係のインメモリリポジトリ
+type FriendshipRepository struct {
+	mu          sync.RWMutex
+	friendships map[uuid.UUID]*domain.Friendship
+
+	// Users はユーザー名順・最終ログイン順の並び替えに使うユーザー情報を返す（未設定の場合は並び替えに使用しない）
+	Users func(ctx context.Context, userID uuid.UUID) (UserProfile, bool)
+	// GroupPeers は同じグループに所属するユーザーIDを返す（未設定の場合は友達のみを公開範囲とする）
+	GroupPeers func(ctx context.Context, userID uuid.UUID) []uuid.UUID
+}
+
+// NewFriendshipRepository は新しいFriendshipRepositoryを作成する
+func NewFriendshipRepository() *FriendshipRepository {
+	return &FriendshipRepository{
+		friendships: make(map[uuid.UUID]*domain.Friendship),
+	}
+}
+
+// CreateFriendship は友達関係を作成する（同じ2人の組み合わせは向きに関わらず1件まで）
+func (r *FriendshipRepository) CreateFriendship(ctx context.Context, friendship *domain.Friendship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.find(friendship.RequesterID, friendship.AddresseeID) != nil {
+		return fmt.Errorf("failed to create friendship: relationship between %s and %s already exists",
+			friendship.RequesterID, friendship.AddresseeID)
+	}
+	r.friendships[friendship.ID] = cloneFriendship(friendship)
+	return nil
+}
+
+// GetFriendship は2人の間の友達関係を取得する（存在しない場合は nil, nil）
+func (r *FriendshipRepository) GetFriendship(ctx context.Context, requesterID, addresseeID uuid.UUID) (*domain.Friendship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if friendship := r.find(requesterID, addresseeID); friendship != nil {
+		return cloneFriendship(friendship), nil
+	}
+	return nil, nil
+}
+
+// UpdateFriendship は友達関係のステータスと日時を更新する
+func (r *FriendshipRepository) UpdateFriendship(ctx context.Context, friendship *domain.Friendship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.friendships[friendship.ID]
+	if !ok {
+		return nil
+	}
+	current.Status = friendship.Status
+	current.UpdatedAt = friendship.UpdatedAt
+	current.AcceptedAt = cloneTime(friendship.AcceptedAt)
+	current.BlockedAt = cloneTime(friendship.BlockedAt)
+	return nil
+}
+
+// DeleteFriendship は2人の間の友達関係を削除する
+func (r *FriendshipRepository) DeleteFriendship(ctx context.Context, requesterID, addresseeID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if friendship := r.find(requesterID, addresseeID); friendship != nil {
+		delete(r.friendships, friendship.ID)
+	}
+	return nil
+}
+
+// GetFriends は承認済みの友達関係を指定の並び順で取得する
+func (r *FriendshipRepository) GetFriends(ctx context.Context, userID uuid.UUID, order domain.FriendSort, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
+	friendships := r.list(func(f *domain.Friendship) bool {
+		return f.Status == domain.FriendshipStatusAccepted && involves(f, userID)
+	})
+
+	switch order {
+	case domain.FriendSortUsername:
+		names := make(map[uuid.UUID]string, len(friendships))
+		for _, f := range friendships {
+			names[f.ID] = strings.ToLower(r.profile(ctx, otherParty(f, userID)).Username)
+		}
+		sort.SliceStable(friendships, func(i, j int) bool {
+			return names[friendships[i].ID] < names[friendships[j].ID]
+		})
+	case domain.FriendSortRecentlyActive:
+		logins := make(map[uuid.UUID]*time.Time, len(friendships))
+		for _, f := range friendships {
+			logins[f.ID] = r.profile(ctx, otherParty(f, userID)).LastLogin
+		}
+		// 一度もログインしていないユーザーは末尾に並べる
+		sort.SliceStable(friendships, func(i, j int) bool {
+			a, b := logins[friendships[i].ID], logins[friendships[j].ID]
+			if a == nil || b == nil {
+				return a != nil && b == nil
+			}
+			return a.After(*b)
+		})
+	default:
+		sort.SliceStable(friendships, func(i, j int) bool {
+			return addedAt(friendships[i]).After(addedAt(friendships[j]))
+		})
+	}
+	return paginate(friendships, pagination), nil
+}
+
+// GetPendingRequests は受信した承認待ちの申請を新しい順に取得する
+func (r *FriendshipRepository) GetPendingRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
+	friendships := r.list(func(f *domain.Friendship) bool {
+		return f.Status == domain.FriendshipStatusPending && f.AddresseeID == userID
+	})
+	sortByCreatedAtDesc(friendships)
+	return paginate(friendships, pagination), nil
+}
+
+// GetSentRequests は送信した承認待ちの申請を新しい順に取得する
+func (r *FriendshipRepository) GetSentRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
+	friendships := r.list(func(f *domain.Friendship) bool {
+		return f.Status == domain.FriendshipStatusPending && f.RequesterID == userID
+	})
+	sortByCreatedAtDesc(friendships)
+	return paginate(friendships, pagination), nil
+}
+
+// GetBlockedUsers はユーザーがブロックした関係をブロック日時の新しい順に取得する
+func (r *FriendshipRepository) GetBlockedUsers(ctx context.Context, blockerID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
+	friendships := r.list(func(f *domain.Friendship) bool {
+		return f.Status == domain.FriendshipStatusBlocked && f.RequesterID == blockerID
+	})
+	sort.SliceStable(friendships, func(i, j int) bool {
+		a, b := friendships[i].BlockedAt, friendships[j].BlockedAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.After(*b)
+	})
+	return paginate(friendships, pagination), nil
+}
+
+// GetBlockRelatedUserIDs はどちらかの方向でブロック関係にあるユーザーIDを取得する
+func (r *FriendshipRepository) GetBlockRelatedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return r.otherParties(userID, domain.FriendshipStatusBlocked), nil
+}
+
+// GetFriendIDs は友達のユーザーIDを取得する
+func (r *FriendshipRepository) GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return r.otherParties(userID, domain.FriendshipStatusAccepted), nil
+}
+
+// GetPresenceAudience はオンライン状態を公開する相手（友達と同じグループのメンバー）を取得する
+// どちらかの方向でブロック関係にあるユーザーは除外する
+func (r *FriendshipRepository) GetPresenceAudience(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	blocked := make(map[uuid.UUID]bool)
+	for _, id := range r.otherParties(userID, domain.FriendshipStatusBlocked) {
+		blocked[id] = true
+	}
+
+	candidates := r.otherParties(userID, domain.FriendshipStatusAccepted)
+	if r.GroupPeers != nil {
+		candidates = append(candidates, r.GroupPeers(ctx, userID)...)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	audience := []uuid.UUID{}
+	for _, id := range candidates {
+		if id == userID || blocked[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		audience = append(audience, id)
+	}
+	return audience, nil
+}
+
+// AreFriends は2人が友達かどうかチェックする
+func (r *FriendshipRepository) AreFriends(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error) {
+	return r.hasStatus(userID1, userID2, domain.FriendshipStatusAccepted), nil
+}
+
+// IsBlocked は2人の間にブロック関係があるかチェックする
+func (r *FriendshipRepository) IsBlocked(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error) {
+	return r.hasStatus(userID1, userID2, domain.FriendshipStatusBlocked), nil
+}
+
+// CountFriends は友達数を取得する
+func (r *FriendshipRepository) CountFriends(ctx context.Context, userID uuid.UUID) (int, error) {
+	return len(r.otherParties(userID, domain.FriendshipStatusAccepted)), nil
+}
+
+// GetMutualFriends はuserID1の友達関係のうち、相手がuserID2とも友達であるものを取得する
+func (r *FriendshipRepository) GetMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) ([]*domain.Friendship, error) {
+	friendsOf2 := make(map[uuid.UUID]bool)
+	for _, id := range r.otherParties(userID2, domain.FriendshipStatusAccepted) {
+		friendsOf2[id] = true
+	}
+
+	friendships := r.list(func(f *domain.Friendship) bool {
+		if f.Status != domain.FriendshipStatusAccepted || !involves(f, userID1) {
+			return false
+		}
+		other := otherParty(f, userID1)
+		return other != userID2 && friendsOf2[other]
+	})
+	sortByCreatedAtDesc(friendships)
+	return friendships, nil
+}
+
+// GetStalePendingRequests は指定日時より前に作成された承認待ちの申請を古い順に取得する
+func (r *FriendshipRepository) GetStalePendingRequests(ctx context.Context, createdBefore time.Time, limit int) ([]*domain.Friendship, error) {
+	friendships := r.list(func(f *domain.Friendship) bool {
+		return f.Status == domain.FriendshipStatusPending && f.CreatedAt.Before(createdBefore)
+	})
+	sort.SliceStable(friendships, func(i, j int) bool {
+		return friendships[i].CreatedAt.Before(friendships[j].CreatedAt)
+	})
+	if limit >= 0 && len(friendships) > limit {
+		friendships = friendships[:limit]
+	}
+	return friendships, nil
+}
+
+// MarkReminderSent は放置された申請へのリマインダー送信日時を記録する
+func (r *FriendshipRepository) MarkReminderSent(ctx context.Context, friendshipID uuid.UUID, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if friendship, ok := r.friendships[friendshipID]; ok {
+		friendship.ReminderSentAt = &sentAt
+	}
+	return nil
+}
+
+// DeletePendingRequest は承認待ちの申請を削除する（承認待ちでない場合は削除せず false を返す）
+func (r *FriendshipRepository) DeletePendingRequest(ctx context.Context, friendshipID uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	friendship, ok := r.friendships[friendshipID]
+	if !ok || friendship.Status != domain.FriendshipStatusPending {
+		return false, nil
+	}
+	delete(r.friendships, friendshipID)
+	return true, nil
+}
+
+// CountPendingRequestsByAddressee は createdBefore より前に作成された承認待ちの申請の数を申請先のユーザーごとに取得する
+func (r *FriendshipRepository) CountPendingRequestsByAddressee(ctx context.Context, createdBefore time.Time) (map[uuid.UUID]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int)
+	for _, f := range r.friendships {
+		if f.Status == domain.FriendshipStatusPending && f.CreatedAt.Before(createdBefore) {
+			counts[f.AddresseeID]++
+		}
+	}
+	return counts, nil
+}
+
+// find は2人の間の友達関係を向きに関わらず探す（呼び出し側でロックを取得すること）
+func (r *FriendshipRepository) find(userID1, userID2 uuid.UUID) *domain.Friendship {
+	for _, f := range r.friendships {
+		if (f.RequesterID == userID1 && f.AddresseeID == userID2) ||
+			(f.RequesterID == userID2 && f.AddresseeID == userID1) {
+			return f
+		}
+	}
+	return nil
+}
+
+// hasStatus は2人の間に指定ステータスの関係があるかチェックする
+func (r *FriendshipRepository) hasStatus(userID1, userID2 uuid.UUID, status domain.FriendshipStatus) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f := r.find(userID1, userID2)
+	return f != nil && f.Status == status
+}
+
+// list は条件に一致する友達関係のコピーを返す
+func (r *FriendshipRepository) list(match func(f *domain.Friendship) bool) []*domain.Friendship {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	friendships := []*domain.Friendship{}
+	for _, f := range r.friendships {
+		if match(f) {
+			friendships = append(friendships, cloneFriendship(f))
+		}
+	}
+	// mapの走査順に依存しないよう、同時刻の並び順をIDで固定する
+	sort.Slice(friendships, func(i, j int) bool {
+		return friendships[i].ID.String() < friendships[j].ID.String()
+	})
+	return friendships
+}
+
+// otherParties はユーザーと指定ステータスの関係にある相手のIDを返す
+func (r *FriendshipRepository) otherParties(userID uuid.UUID, status domain.FriendshipStatus) []uuid.UUID {
+	friendships := r.list(func(f *domain.Friendship) bool {
+		return f.Status == status && involves(f, userID)
+	})
+	ids := make([]uuid.UUID, len(friendships))
+	for i, f := range friendships {
+		ids[i] = otherParty(f, userID)
+	}
+	return ids
+}
+
+// profile は並び替えに使うユーザー情報を取得する
+func (r *FriendshipRepository) profile(ctx context.Context, userID uuid.UUID) UserProfile {
+	if r.Users == nil {
+		return UserProfile{}
+	}
+	profile, _ := r.Users(ctx, userID)
+	return profile
+}
+
+// involves はユーザーが友達関係の当事者かどうかを返す
+func involves(f *domain.Friendship, userID uuid.UUID) bool {
+	return f.RequesterID == userID || f.AddresseeID == userID
+}
+
+// otherParty は友達関係のうちユーザーではない側のIDを返す
+func otherParty(f *domain.Friendship, userID uuid.UUID) uuid.UUID {
+	if f.RequesterID == userID {
+		return f.AddresseeID
+	}
+	return f.RequesterID
+}
+
+// addedAt は友達になった日時を返す（承認日時がない場合は更新日時）
+func addedAt(f *domain.Friendship) time.Time {
+	if f.AcceptedAt != nil {
+		return *f.AcceptedAt
+	}
+	return f.UpdatedAt
+}
+
+// sortByCreatedAtDesc は作成日時の新しい順に並べる
+func sortByCreatedAtDesc(friendships []*domain.Friendship) {
+	sort.SliceStable(friendships, func(i, j int) bool {
+		return friendships[i].CreatedAt.After(friendships[j].CreatedAt)
+	})
+}
+
+// cloneFriendship は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+func cloneFriendship(f *domain.Friendship) *domain.Friendship {
+	c := *f
+	c.AcceptedAt = cloneTime(f.AcceptedAt)
+	c.BlockedAt = cloneTime(f.BlockedAt)
+	c.ReminderSentAt = cloneTime(f.ReminderSentAt)
+	return &c
+}
+
+// cloneTime は日時ポインタをコピーする
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// paginate はページに該当する範囲を返す
+func paginate[T any](items []T, pagination commonDomain.Pagination) []T {
+	if pagination.PageSize <= 0 {
+		return items
+	}
+	page := pagination.Page
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * pagination.PageSize
+	if start >= len(items) {
+		return items[:0]
+	}
+	end := start + pagination.PageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func acceptedFriendship(t *testing.T, repo *FriendshipRepository, a, b uuid.UUID, acceptedAt time.Time) *domain.Friendship {
+	t.Helper()
+
+	friendship := domain.NewFriendship(a, b)
+	friendship.Status = domain.FriendshipStatusAccepted
+	friendship.AcceptedAt = &acceptedAt
+	require.NoError(t, repo.CreateFriendship(context.Background(), friendship))
+	return friendship
+}
+
+func TestFriendshipRepository_CreateFriendship_UniquePair(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFriendshipRepository()
+	a, b := uuid.New(), uuid.New()
+
+	require.NoError(t, repo.CreateFriendship(ctx, domain.NewFriendship(a, b)))
+
+	// 逆方向の申請も同じ組み合わせとして扱う
+	assert.Error(t, repo.CreateFriendship(ctx, domain.NewFriendship(b, a)))
+
+	got, err := repo.GetFriendship(ctx, b, a)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, a, got.RequesterID)
+}
+
+func TestFriendshipRepository_GetFriends_Sort(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFriendshipRepository()
+	me, alice, bob, carol := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	lastLogin := now.Add(-time.Minute)
+
+	profiles := map[uuid.UUID]UserProfile{
+		alice: {Username: "alice"},
+		bob:   {Username: "Bob", LastLogin: &lastLogin},
+		carol: {Username: "carol", LastLogin: &now},
+	}
+	repo.Users = func(ctx context.Context, userID uuid.UUID) (UserProfile, bool) {
+		profile, ok := profiles[userID]
+		return profile, ok
+	}
+
+	acceptedFriendship(t, repo, me, carol, now.Add(-3*time.Hour))
+	acceptedFriendship(t, repo, alice, me, now.Add(-time.Hour))
+	acceptedFriendship(t, repo, me, bob, now.Add(-2*time.Hour))
+	// 承認待ちの申請は友達一覧に含まれない
+	require.NoError(t, repo.CreateFriendship(ctx, domain.NewFriendship(me, uuid.New())))
+
+	tests := []struct {
+		sort domain.FriendSort
+		want []uuid.UUID
+	}{
+		{domain.FriendSortRecentlyAdded, []uuid.UUID{alice, bob, carol}},
+		{domain.FriendSortUsername, []uuid.UUID{alice, bob, carol}},
+		// 一度もログインしていないユーザーは末尾
+		{domain.FriendSortRecentlyActive, []uuid.UUID{carol, bob, alice}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.sort), func(t *testing.T) {
+			friendships, err := repo.GetFriends(ctx, me, tt.sort, commonDomain.Pagination{Page: 1, PageSize: 10})
+			require.NoError(t, err)
+
+			got := make([]uuid.UUID, len(friendships))
+			for i, f := range friendships {
+				got[i] = otherParty(f, me)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFriendshipRepository_GetPresenceAudience(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFriendshipRepository()
+	me, friend, peer, blockedPeer := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	acceptedFriendship(t, repo, me, friend, time.Now())
+	blocked := domain.NewFriendship(blockedPeer, me)
+	blocked.Status = domain.FriendshipStatusBlocked
+	require.NoError(t, repo.CreateFriendship(ctx, blocked))
+
+	repo.GroupPeers = func(ctx context.Context, userID uuid.UUID) []uuid.UUID {
+		return []uuid.UUID{peer, friend, blockedPeer}
+	}
+
+	audience, err := repo.GetPresenceAudience(ctx, me)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{friend, peer}, audience)
+}
+
+func TestFriendshipRepository_GetMutualFriends(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFriendshipRepository()
+	u1, u2, mutual, onlyU1 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	acceptedFriendship(t, repo, u1, mutual, time.Now())
+	acceptedFriendship(t, repo, mutual, u2, time.Now())
+	acceptedFriendship(t, repo, u1, onlyU1, time.Now())
+	acceptedFriendship(t, repo, u1, u2, time.Now())
+
+	friendships, err := repo.GetMutualFriends(ctx, u1, u2)
+	require.NoError(t, err)
+	require.Len(t, friendships, 1)
+	assert.Equal(t, mutual, otherParty(friendships[0], u1))
+}
+
+func TestFriendshipRepository_DeletePendingRequest(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFriendshipRepository()
+
+	pending := domain.NewFriendship(uuid.New(), uuid.New())
+	require.NoError(t, repo.CreateFriendship(ctx, pending))
+	accepted := acceptedFriendship(t, repo, uuid.New(), uuid.New(), time.Now())
+
+	deleted, err := repo.DeletePendingRequest(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = repo.DeletePendingRequest(ctx, accepted.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted, "承認済みの関係は削除しない")
+}
@@ -0,0 +1,272 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+)
+
+// InvitationRepository は招待のインメモリリポジトリ
+type InvitationRepository struct {
+	mu          sync.RWMutex
+	invitations map[uuid.UUID]*domain.Invitation
+}
+
+// NewInvitationRepository は新しいInvitationRepositoryを作成する
+func NewInvitationRepository() *InvitationRepository {
+	return &InvitationRepository{
+		invitations: make(map[uuid.UUID]*domain.Invitation),
+	}
+}
+
+// CreateInvitation は招待を作成する（招待コードは一意）
+func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if invitation.Code != "" && r.findByCode(invitation.Code) != nil {
+		return fmt.Errorf("failed to create invitation: duplicate code")
+	}
+	r.invitations[invitation.ID] = cloneInvitation(invitation)
+	return nil
+}
+
+// GetInvitationByID はIDで招待を取得する（存在しない場合は nil, nil）
+func (r *InvitationRepository) GetInvitationByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if invitation, ok := r.invitations[id]; ok {
+		return cloneInvitation(invitation), nil
+	}
+	return nil, nil
+}
+
+// GetInvitationByCode は招待コードで招待を取得する（存在しない場合は nil, nil）
+func (r *InvitationRepository) GetInvitationByCode(ctx context.Context, code string) (*domain.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if invitation := r.findByCode(code); invitation != nil {
+		return cloneInvitation(invitation), nil
+	}
+	return nil, nil
+}
+
+// UpdateInvitation は招待のステータスと送信状況を更新する
+func (r *InvitationRepository) UpdateInvitation(ctx context.Context, invitation *domain.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.invitations[invitation.ID]
+	if !ok {
+		return nil
+	}
+	current.Status = invitation.Status
+	current.InviteeID = cloneUUID(invitation.InviteeID)
+	current.UpdatedAt = invitation.UpdatedAt
+	current.AcceptedAt = cloneTime(invitation.AcceptedAt)
+	current.EmailSentAt = cloneTime(invitation.EmailSentAt)
+	current.EmailSendCount = invitation.EmailSendCount
+	current.BounceReason = invitation.BounceReason
+	return nil
+}
+
+// DeleteInvitation は招待を削除する
+func (r *InvitationRepository) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.invitations, id)
+	return nil
+}
+
+// GetSentInvitations は送信した招待一覧を新しい順に取得する
+func (r *InvitationRepository) GetSentInvitations(ctx context.Context, inviterID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error) {
+	return r.list(pagination, func(invitation *domain.Invitation) bool {
+		return invitation.InviterID == inviterID
+	}), nil
+}
+
+// CountInvitationsSince は招待者がsince以降に作成した招待の数を取得する
+func (r *InvitationRepository) CountInvitationsSince(ctx context.Context, inviterID uuid.UUID, since time.Time) (int64, error) {
+	return r.count(func(invitation *domain.Invitation) bool {
+		return invitation.InviterID == inviterID && !invitation.CreatedAt.Before(since)
+	}), nil
+}
+
+// CountTargetInvitationsSince は対象（グループ）へのsince以降に作成された招待の数を取得する
+func (r *InvitationRepository) CountTargetInvitationsSince(ctx context.Context, targetID uuid.UUID, since time.Time) (int64, error) {
+	return r.count(func(invitation *domain.Invitation) bool {
+		return invitation.TargetID != nil && *invitation.TargetID == targetID && !invitation.CreatedAt.Before(since)
+	}), nil
+}
+
+func (r *InvitationRepository) count(match func(*domain.Invitation) bool) int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, invitation := range r.invitations {
+		if match(invitation) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetReceivedInvitations は受信した招待一覧を新しい順に取得する
+func (r *InvitationRepository) GetReceivedInvitations(ctx context.Context, inviteeID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error) {
+	return r.list(pagination, func(invitation *domain.Invitation) bool {
+		return invitation.InviteeID != nil && *invitation.InviteeID == inviteeID
+	}), nil
+}
+
+// GetPendingInvitationsByEmail はメールアドレス宛ての有効な招待一覧を古い順に取得する
+// （MySQLの照合順序と同じく大文字・小文字を区別しない）
+func (r *InvitationRepository) GetPendingInvitationsByEmail(ctx context.Context, email string) ([]*domain.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	invitations := []*domain.Invitation{}
+	for _, invitation := range r.invitations {
+		if invitation.InviteeID == nil &&
+			invitation.Status == domain.InvitationStatusPending &&
+			invitation.ExpiresAt.After(now) &&
+			strings.EqualFold(invitation.InviteeEmail(), email) {
+			invitations = append(invitations, cloneInvitation(invitation))
+		}
+	}
+	sort.SliceStable(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt.Before(invitations[j].CreatedAt)
+	})
+	return invitations, nil
+}
+
+// MarkExpiredInvitations は期限切れ招待をマークし、更新件数を返す
+func (r *InvitationRepository) MarkExpiredInvitations(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var count int64
+	for _, invitation := range r.invitations {
+		if invitation.Status == domain.InvitationStatusPending && invitation.ExpiresAt.Before(now) {
+			invitation.Status = domain.InvitationStatusExpired
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeleteExpiredInvitations は期限切れ招待を削除する
+func (r *InvitationRepository) DeleteExpiredInvitations(ctx context.Context, beforeDate time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, invitation := range r.invitations {
+		if invitation.Status == domain.InvitationStatusExpired && invitation.ExpiresAt.Before(beforeDate) {
+			delete(r.invitations, id)
+		}
+	}
+	return nil
+}
+
+// CountPendingGroupInvitationsByInvitee は createdBefore より前に作成され、now の時点で有効な登録済みユーザー宛てのグループ招待の数を被招待者ごとに取得する
+func (r *InvitationRepository) CountPendingGroupInvitationsByInvitee(ctx context.Context, createdBefore, now time.Time) (map[uuid.UUID]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int)
+	for _, invitation := range r.invitations {
+		if invitation.Type == domain.InvitationTypeGroup &&
+			invitation.Status == domain.InvitationStatusPending &&
+			invitation.InviteeID != nil &&
+			invitation.CreatedAt.Before(createdBefore) &&
+			invitation.ExpiresAt.After(now) {
+			counts[*invitation.InviteeID]++
+		}
+	}
+	return counts, nil
+}
+
+// IsValidInvitation は招待コードの妥当性を確認する
+func (r *InvitationRepository) IsValidInvitation(ctx context.Context, code string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitation := r.findByCode(code)
+	return invitation != nil &&
+		invitation.Status == domain.InvitationStatusPending &&
+		invitation.ExpiresAt.After(time.Now()), nil
+}
+
+// findByCode は招待コードで招待を探す（呼び出し側でロックを取得すること）
+func (r *InvitationRepository) findByCode(code string) *domain.Invitation {
+	if code == "" {
+		return nil
+	}
+	for _, invitation := range r.invitations {
+		if invitation.Code == code {
+			return invitation
+		}
+	}
+	return nil
+}
+
+// list は条件に一致する招待を作成日時の新しい順に取得する
+func (r *InvitationRepository) list(pagination commonDomain.Pagination, match func(invitation *domain.Invitation) bool) []*domain.Invitation {
+	r.mu.RLock()
+	invitations := []*domain.Invitation{}
+	for _, invitation := range r.invitations {
+		if match(invitation) {
+			invitations = append(invitations, cloneInvitation(invitation))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(invitations, func(i, j int) bool {
+		if !invitations[i].CreatedAt.Equal(invitations[j].CreatedAt) {
+			return invitations[i].CreatedAt.After(invitations[j].CreatedAt)
+		}
+		return invitations[i].ID.String() < invitations[j].ID.String()
+	})
+	return paginate(invitations, pagination)
+}
+
+// cloneInvitation は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+func cloneInvitation(invitation *domain.Invitation) *domain.Invitation {
+	c := *invitation
+	c.InviteeID = cloneUUID(invitation.InviteeID)
+	c.TargetID = cloneUUID(invitation.TargetID)
+	if invitation.InviteeInfo != nil {
+		info := *invitation.InviteeInfo
+		c.InviteeInfo = &info
+	}
+	if invitation.Metadata != nil {
+		c.Metadata = make(map[string]string, len(invitation.Metadata))
+		for k, v := range invitation.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	c.EmailSentAt = cloneTime(invitation.EmailSentAt)
+	c.AcceptedAt = cloneTime(invitation.AcceptedAt)
+	return &c
+}
+
+// cloneUUID はUUIDポインタをコピーする
+func cloneUUID(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	c := *id
+	return &c
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationRepository_GetPendingInvitationsByEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInvitationRepository()
+
+	newEmailInvitation := func(email string) *domain.Invitation {
+		invitation := domain.NewInvitation(domain.InvitationTypeGroup, domain.MethodCode, uuid.New(), "", 24)
+		invitation.SetInviteeInfo(domain.InviteeInfo{Email: email})
+		require.NoError(t, repo.CreateInvitation(ctx, invitation))
+		return invitation
+	}
+
+	pending := newEmailInvitation("Newcomer@Example.com")
+	newEmailInvitation("other@example.com")
+
+	canceled := newEmailInvitation("newcomer@example.com")
+	require.NoError(t, canceled.Cancel())
+	require.NoError(t, repo.UpdateInvitation(ctx, canceled))
+
+	linked := newEmailInvitation("newcomer@example.com")
+	linked.SetInvitee(uuid.New())
+	require.NoError(t, repo.UpdateInvitation(ctx, linked))
+
+	// キャンセル済み・紐付け済みの招待は含まない（大文字・小文字は区別しない）
+	invitations, err := repo.GetPendingInvitationsByEmail(ctx, "newcomer@example.com")
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	assert.Equal(t, pending.ID, invitations[0].ID)
+}
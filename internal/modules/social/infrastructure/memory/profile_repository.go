@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+)
+
+// ProfileRepository は公開プロフィールの設定のインメモリリポジトリ
+type ProfileRepository struct {
+	mu       sync.RWMutex
+	settings map[uuid.UUID]domain.ProfileSettings
+}
+
+// NewProfileRepository は新しいProfileRepositoryを作成する
+func NewProfileRepository() *ProfileRepository {
+	return &ProfileRepository{
+		settings: make(map[uuid.UUID]domain.ProfileSettings),
+	}
+}
+
+// GetProfileSettings は公開プロフィールの設定を取得する（保存していない場合は nil, nil）
+func (r *ProfileRepository) GetProfileSettings(ctx context.Context, userID uuid.UUID) (*domain.ProfileSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.settings[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &settings, nil
+}
+
+// SaveProfileSettings は公開プロフィールの設定を保存する（既存の設定は上書きする）
+func (r *ProfileRepository) SaveProfileSettings(ctx context.Context, settings *domain.ProfileSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.settings[settings.UserID] = *settings
+	return nil
+}
@@ -0,0 +1,125 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// クリーンアップのメトリクス名
+const (
+	MetricCleanupRuns               = "social_cleanup_runs_total"
+	MetricCleanupFailures           = "social_cleanup_failures_total"
+	MetricCleanupExpiredInvitations = "social_cleanup_expired_invitations_total"
+	MetricCleanupRemindedRequests   = "social_cleanup_reminded_friend_requests_total"
+	MetricCleanupDeletedRequests    = "social_cleanup_deleted_friend_requests_total"
+	MetricCleanupLastRun            = "social_cleanup_last_run_timestamp"
+)
+
+// CleanupWorker は期限切れ招待と放置された友達申請を定期的に整理するワーカー
+type CleanupWorker struct {
+	cleanupService *usecase.CleanupService
+	interval       time.Duration
+	logger         logger.Logger
+	ticker         *time.Ticker
+	stopCh         chan struct{}
+	doneCh         chan struct{}
+	isRunning      bool
+}
+
+// NewCleanupWorker は新しいCleanupWorkerを作成
+func NewCleanupWorker(
+	cleanupService *usecase.CleanupService,
+	interval time.Duration,
+	logger logger.Logger,
+) *CleanupWorker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &CleanupWorker{
+		cleanupService: cleanupService,
+		interval:       interval,
+		logger:         logger,
+		stopCh:         make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始
+func (w *CleanupWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Social cleanup worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(w.interval)
+
+	w.logger.Info("Starting social cleanup worker", logger.Any("interval", w.interval.String()))
+
+	// 初回実行
+	go w.cleanup(ctx)
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.cleanup(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Social cleanup worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Social cleanup worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// cleanup はクリーンアップを実行し、件数をメトリクスに記録する
+func (w *CleanupWorker) cleanup(ctx context.Context) {
+	defer errtrack.Recover("social-cleanup-worker")
+	now := time.Now()
+	metrics.Counter(MetricCleanupRuns).Add(1)
+	metrics.Counter(MetricCleanupLastRun).Set(now.Unix())
+
+	result, err := w.cleanupService.Run(ctx, now)
+	if result != nil {
+		metrics.Counter(MetricCleanupExpiredInvitations).Add(result.ExpiredInvitations)
+		metrics.Counter(MetricCleanupRemindedRequests).Add(int64(result.RemindedRequests))
+		metrics.Counter(MetricCleanupDeletedRequests).Add(int64(result.DeletedRequests))
+	}
+	if err != nil {
+		metrics.Counter(MetricCleanupFailures).Add(1)
+		w.logger.Error("Failed to clean up social data", logger.Error(err))
+		return
+	}
+
+	if result.ExpiredInvitations > 0 || result.RemindedRequests > 0 || result.DeletedRequests > 0 {
+		w.logger.Info("Social cleanup completed",
+			logger.Any("expiredInvitations", result.ExpiredInvitations),
+			logger.Any("remindedRequests", result.RemindedRequests),
+			logger.Any("deletedRequests", result.DeletedRequests))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *CleanupWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping social cleanup worker")
+	<-w.doneCh
+}
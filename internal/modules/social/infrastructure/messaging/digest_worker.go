@@ -0,0 +1,119 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// リマインダーのメトリクス名
+const (
+	MetricDigestRuns      = "social_digest_runs_total"
+	MetricDigestFailures  = "social_digest_failures_total"
+	MetricDigestSent      = "social_digest_sent_total"
+	MetricDigestDismissed = "social_digest_dismissed_total"
+)
+
+// DigestWorker は未対応の友達申請・グループ招待のリマインダーを定期的に送るワーカー
+// 送る頻度はユーザーごとの通知の設定に従い、ワーカーの実行間隔は設定を確認する間隔になる
+type DigestWorker struct {
+	digestService *usecase.DigestService
+	interval      time.Duration
+	logger        logger.Logger
+	ticker        *time.Ticker
+	stopCh        chan struct{}
+	doneCh        chan struct{}
+	isRunning     bool
+}
+
+// NewDigestWorker は新しいDigestWorkerを作成
+func NewDigestWorker(
+	digestService *usecase.DigestService,
+	interval time.Duration,
+	logger logger.Logger,
+) *DigestWorker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &DigestWorker{
+		digestService: digestService,
+		interval:      interval,
+		logger:        logger,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始
+func (w *DigestWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Social digest worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(w.interval)
+
+	w.logger.Info("Starting social digest worker", logger.Any("interval", w.interval.String()))
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.run(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Social digest worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Social digest worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// run はリマインダーを送り、件数をメトリクスに記録する
+func (w *DigestWorker) run(ctx context.Context) {
+	defer errtrack.Recover("social-digest-worker")
+	metrics.Counter(MetricDigestRuns).Add(1)
+
+	result, err := w.digestService.Run(ctx, time.Now())
+	if err != nil {
+		metrics.Counter(MetricDigestFailures).Add(1)
+		w.logger.Error("Failed to send pending social digests", logger.Error(err))
+		return
+	}
+	metrics.Counter(MetricDigestSent).Add(int64(result.Sent))
+	metrics.Counter(MetricDigestDismissed).Add(int64(result.Dismissed))
+	if result.Failed > 0 {
+		metrics.Counter(MetricDigestFailures).Add(int64(result.Failed))
+	}
+
+	if result.Sent > 0 || result.Dismissed > 0 || result.Failed > 0 {
+		w.logger.Info("Pending social digests processed",
+			logger.Any("sent", result.Sent),
+			logger.Any("dismissed", result.Dismissed),
+			logger.Any("failed", result.Failed))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *DigestWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping social digest worker")
+	<-w.doneCh
+}
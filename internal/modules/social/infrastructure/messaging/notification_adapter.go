@@ -3,6 +3,7 @@ package messaging
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
@@ -116,6 +117,41 @@ func (a *SocialNotificationAdapter) SendFriendAcceptedNotification(ctx context.C
 	return nil
 }
 
+// SendFriendRequestReminderNotification は未対応の友達申請のリマインダー通知を送信する
+func (a *SocialNotificationAdapter) SendFriendRequestReminderNotification(ctx context.Context, requesterID, addresseeID uuid.UUID, deleteAt time.Time) error {
+	input := notificationInput.CreateNotificationInput{
+		UserID:  addresseeID.String(),
+		Type:    "FRIEND_REQUEST",
+		Title:   "未対応の友達申請があります",
+		Message: fmt.Sprintf("%sまでに承認または拒否しない場合、友達申請は削除されます", deleteAt.Format("2006-01-02")),
+		Metadata: map[string]string{
+			"requester_id": requesterID.String(),
+			"request_type": "friend_request",
+			"action_type":  "reminder",
+			"delete_at":    deleteAt.Format(time.RFC3339),
+		},
+		Channels: []string{"app"},
+	}
+
+	notification, err := a.notificationUseCase.CreateNotification(ctx, input)
+	if err != nil {
+		a.logger.Error("Failed to create friend request reminder notification",
+			logger.Any("requesterID", requesterID),
+			logger.Any("addresseeID", addresseeID),
+			logger.Error(err))
+		return fmt.Errorf("failed to create friend request reminder notification: %w", err)
+	}
+
+	if err := a.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		a.logger.Error("Failed to send friend request reminder notification",
+			logger.Any("notificationID", notification.GetID()),
+			logger.Error(err))
+		return fmt.Errorf("failed to send friend request reminder notification: %w", err)
+	}
+
+	return nil
+}
+
 // SendInvitationNotification は招待通知を送信する
 func (a *SocialNotificationAdapter) SendInvitationNotification(ctx context.Context, invitation *domain.Invitation) error {
 	// 招待タイプに応じてメッセージを構築
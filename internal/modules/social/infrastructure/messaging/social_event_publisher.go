@@ -17,6 +17,7 @@ const (
 	EventFriendRequestSent     SocialEventType = "social.friend_request.sent"
 	EventFriendRequestAccepted SocialEventType = "social.friend_request.accepted"
 	EventFriendRequestDeclined SocialEventType = "social.friend_request.declined"
+	EventFriendRequestReminder SocialEventType = "social.friend_request.reminder"
 	EventFriendRemoved         SocialEventType = "social.friend.removed"
 	EventUserBlocked           SocialEventType = "social.user.blocked"
 	EventUserUnblocked         SocialEventType = "social.user.unblocked"
@@ -63,6 +64,7 @@ type InvitationPayload struct {
 type NotificationAdapter interface {
 	SendFriendRequestNotification(ctx context.Context, requesterID, addresseeID uuid.UUID, message string) error
 	SendFriendAcceptedNotification(ctx context.Context, requesterID, accepterID uuid.UUID) error
+	SendFriendRequestReminderNotification(ctx context.Context, requesterID, addresseeID uuid.UUID, deleteAt time.Time) error
 	SendInvitationNotification(ctx context.Context, invitation *domain.Invitation) error
 }
 
@@ -176,6 +178,37 @@ func (p *SocialEventPublisher) PublishFriendRequestDeclined(ctx context.Context,
 	return nil
 }
 
+// PublishFriendRequestReminder は放置された友達申請のリマインダーイベントを発行する
+func (p *SocialEventPublisher) PublishFriendRequestReminder(ctx context.Context, friendship *domain.Friendship, deleteAt time.Time) error {
+	event := &SocialEvent{
+		ID:   uuid.New().String(),
+		Type: EventFriendRequestReminder,
+		Payload: FriendRequestPayload{
+			FriendshipID: friendship.ID,
+			RequesterID:  friendship.RequesterID,
+			AddresseeID:  friendship.AddresseeID,
+			Status:       string(friendship.Status),
+			CreatedAt:    friendship.CreatedAt,
+		},
+		UserID:    friendship.AddresseeID, // 通知対象ユーザー
+		CreatedAt: time.Now(),
+	}
+
+	p.logger.Info("Publishing friend request reminder event",
+		logger.Any("eventID", event.ID),
+		logger.Any("addresseeID", friendship.AddresseeID),
+		logger.Any("deleteAt", deleteAt))
+
+	if err := p.notificationAdapter.SendFriendRequestReminderNotification(ctx, friendship.RequesterID, friendship.AddresseeID, deleteAt); err != nil {
+		p.logger.Error("Failed to send friend request reminder notification",
+			logger.Any("eventID", event.ID),
+			logger.Error(err))
+		return fmt.Errorf("failed to send friend request reminder notification: %w", err)
+	}
+
+	return nil
+}
+
 // PublishFriendRemoved は友達削除イベントを発行する
 func (p *SocialEventPublisher) PublishFriendRemoved(ctx context.Context, userID, friendID uuid.UUID) error {
 	event := &SocialEvent{
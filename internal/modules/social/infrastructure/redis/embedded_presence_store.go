@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/kvstore"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+)
+
+// EmbeddedPresenceStore はプロセス内のストアを使用したオンライン状態の保存先（REDIS_DRIVER=embedded 用）
+// PresenceStore と同じキーと有効期限で保存する
+type EmbeddedPresenceStore struct {
+	store *kvstore.Store
+}
+
+// NewEmbeddedPresenceStore は新しいEmbeddedPresenceStoreを作成する
+func NewEmbeddedPresenceStore(store *kvstore.Store) *EmbeddedPresenceStore {
+	return &EmbeddedPresenceStore{store: store}
+}
+
+// SetStatus は状態をttlの間保持して最終接続日時を記録し、変更前の状態を返す
+func (s *EmbeddedPresenceStore) SetStatus(ctx context.Context, userID uuid.UUID, status domain.PresenceStatus, ttl time.Duration, now time.Time) (domain.PresenceStatus, error) {
+	previous, ok := s.store.GetSet(statusKey(userID), string(status), ttl)
+	s.setLastSeen(userID, now)
+	return parseStoredStatus(previous, ok), nil
+}
+
+// Refresh は状態の有効期限を延長して最終接続日時を記録する（状態が失効していた場合はfalse）
+func (s *EmbeddedPresenceStore) Refresh(ctx context.Context, userID uuid.UUID, ttl time.Duration, now time.Time) (bool, error) {
+	alive := s.store.Expire(statusKey(userID), ttl)
+	s.setLastSeen(userID, now)
+	return alive, nil
+}
+
+// SetOffline は状態を削除して最終接続日時を記録し、変更前の状態を返す
+func (s *EmbeddedPresenceStore) SetOffline(ctx context.Context, userID uuid.UUID, now time.Time) (domain.PresenceStatus, error) {
+	previous, ok := s.store.GetDel(statusKey(userID))
+	s.setLastSeen(userID, now)
+	return parseStoredStatus(previous, ok), nil
+}
+
+// GetPresences は指定ユーザーのオンライン状態を一括取得する
+func (s *EmbeddedPresenceStore) GetPresences(ctx context.Context, userIDs []uuid.UUID) ([]*domain.Presence, error) {
+	presences := make([]*domain.Presence, len(userIDs))
+	for i, id := range userIDs {
+		status, _ := s.store.Get(statusKey(id))
+
+		var lastSeenAt *time.Time
+		if v, ok := s.store.Get(lastSeenKey(id)); ok {
+			if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+				t := time.Unix(unix, 0)
+				lastSeenAt = &t
+			}
+		}
+
+		presences[i] = domain.NewPresence(id, domain.PresenceStatus(status), lastSeenAt)
+	}
+	return presences, nil
+}
+
+func (s *EmbeddedPresenceStore) setLastSeen(userID uuid.UUID, now time.Time) {
+	s.store.Set(lastSeenKey(userID), strconv.FormatInt(now.Unix(), 10), lastSeenRetention)
+}
+
+// parseStoredStatus は保存されていた状態を取得する（キーがない場合はオフライン）
+func parseStoredStatus(value string, ok bool) domain.PresenceStatus {
+	if !ok {
+		return domain.PresenceStatusOffline
+	}
+	return domain.NewPresence(uuid.Nil, domain.PresenceStatus(value), nil).Status
+}
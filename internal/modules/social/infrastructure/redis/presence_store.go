@@ -0,0 +1,135 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+)
+
+const (
+	presenceStatusKeyPrefix   = "presence:status:"
+	presenceLastSeenKeyPrefix = "presence:last_seen:"
+
+	// 最終接続日時の保持期間
+	lastSeenRetention = 90 * 24 * time.Hour
+)
+
+// PresenceStore はRedisを使用したオンライン状態の保存先
+// 状態はハートビートのたびに有効期限を延長し、期限が切れるとオフラインとみなす
+type PresenceStore struct {
+	client *redis.Client
+}
+
+// NewPresenceStore は新しいPresenceStoreを作成する
+func NewPresenceStore(client *redis.Client) *PresenceStore {
+	return &PresenceStore{client: client}
+}
+
+// SetStatus は状態をttlの間保持して最終接続日時を記録し、変更前の状態を返す
+func (s *PresenceStore) SetStatus(ctx context.Context, userID uuid.UUID, status domain.PresenceStatus, ttl time.Duration, now time.Time) (domain.PresenceStatus, error) {
+	var previous *redis.StringCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		previous = pipe.Get(ctx, statusKey(userID))
+		pipe.Set(ctx, statusKey(userID), string(status), ttl)
+		pipe.Set(ctx, lastSeenKey(userID), now.Unix(), lastSeenRetention)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return "", fmt.Errorf("failed to set presence status: %w", err)
+	}
+	return parseStatus(previous), nil
+}
+
+// Refresh は状態の有効期限を延長して最終接続日時を記録する（状態が失効していた場合はfalse）
+func (s *PresenceStore) Refresh(ctx context.Context, userID uuid.UUID, ttl time.Duration, now time.Time) (bool, error) {
+	var alive *redis.BoolCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		alive = pipe.Expire(ctx, statusKey(userID), ttl)
+		pipe.Set(ctx, lastSeenKey(userID), now.Unix(), lastSeenRetention)
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to refresh presence: %w", err)
+	}
+	return alive.Val(), nil
+}
+
+// SetOffline は状態を削除して最終接続日時を記録し、変更前の状態を返す
+func (s *PresenceStore) SetOffline(ctx context.Context, userID uuid.UUID, now time.Time) (domain.PresenceStatus, error) {
+	var previous *redis.StringCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		previous = pipe.Get(ctx, statusKey(userID))
+		pipe.Del(ctx, statusKey(userID))
+		pipe.Set(ctx, lastSeenKey(userID), now.Unix(), lastSeenRetention)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return "", fmt.Errorf("failed to set presence offline: %w", err)
+	}
+	return parseStatus(previous), nil
+}
+
+// GetPresences は指定ユーザーのオンライン状態を一括取得する
+func (s *PresenceStore) GetPresences(ctx context.Context, userIDs []uuid.UUID) ([]*domain.Presence, error) {
+	if len(userIDs) == 0 {
+		return []*domain.Presence{}, nil
+	}
+
+	statusKeys := make([]string, len(userIDs))
+	lastSeenKeys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		statusKeys[i] = statusKey(id)
+		lastSeenKeys[i] = lastSeenKey(id)
+	}
+
+	var statuses, lastSeens *redis.SliceCmd
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		statuses = pipe.MGet(ctx, statusKeys...)
+		lastSeens = pipe.MGet(ctx, lastSeenKeys...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presences: %w", err)
+	}
+
+	presences := make([]*domain.Presence, len(userIDs))
+	for i, id := range userIDs {
+		var status domain.PresenceStatus
+		if v, ok := statuses.Val()[i].(string); ok {
+			status = domain.PresenceStatus(v)
+		}
+
+		var lastSeenAt *time.Time
+		if v, ok := lastSeens.Val()[i].(string); ok {
+			if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+				t := time.Unix(unix, 0)
+				lastSeenAt = &t
+			}
+		}
+
+		presences[i] = domain.NewPresence(id, status, lastSeenAt)
+	}
+
+	return presences, nil
+}
+
+func statusKey(userID uuid.UUID) string {
+	return presenceStatusKeyPrefix + userID.String()
+}
+
+func lastSeenKey(userID uuid.UUID) string {
+	return presenceLastSeenKeyPrefix + userID.String()
+}
+
+// parseStatus はGETの結果から状態を取得する（キーがない場合はオフライン）
+func parseStatus(cmd *redis.StringCmd) domain.PresenceStatus {
+	if cmd == nil || cmd.Err() != nil {
+		return domain.PresenceStatusOffline
+	}
+	return domain.NewPresence(uuid.Nil, domain.PresenceStatus(cmd.Val()), nil).Status
+}
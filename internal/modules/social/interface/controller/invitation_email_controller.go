@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/interface/dto"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// WebhookSecretHeader はバウンス通知Webhookの認証に使用するヘッダー
+const WebhookSecretHeader = "X-Webhook-Secret"
+
+// InvitationEmailBounceRequest はメール配信サービスからのバウンス通知のリクエスト構造体
+type InvitationEmailBounceRequest struct {
+	InvitationID string `json:"invitation_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email        string `json:"email" binding:"required,email" example:"friend@example.com"`
+	Reason       string `json:"reason" example:"550 5.1.1 User unknown"`
+} // @name InvitationEmailBounceRequest
+
+// ResendInvitationEmail 招待メール再送
+// @Summary      招待メール再送
+// @Description  送信した招待のメールを再送します。前回の送信から一定時間が経過するまで再送できません
+// @Tags         social
+// @Accept       json
+// @Produce      json
+// @Param        invitationId path string true "招待ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} InvitationResponse "招待メール再送成功"
+// @Failure      400 {object} ErrorResponse "招待IDが無効、メールアドレス未設定、送信上限到達、または招待が無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "この招待を再送する権限がない"
+// @Failure      404 {object} ErrorResponse "招待が見つからない"
+// @Failure      429 {object} ErrorResponse "再送の待ち時間中"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "招待メールが無効"
+// @Router       /social/invitations/{invitationId}/resend [put]
+func (sc *SocialController) ResendInvitationEmail(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		sc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	invitationID, err := sc.validateUUID(c.Param("invitationId"), "invitation ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_invitation_id",
+			Message: "無効な招待IDです",
+		})
+		return
+	}
+
+	invitation, err := sc.socialService.ResendInvitationEmail(c.Request.Context(), invitationID, user.ID)
+	if err != nil {
+		var cooldownErr *usecase.EmailCooldownError
+		switch {
+		case errors.As(err, &cooldownErr):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(cooldownErr.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Error:   "resend_cooldown",
+				Message: "招待メールは少し時間をおいてから再送してください",
+			})
+		case errors.Is(err, usecase.ErrInvitationNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "invitation_not_found",
+				Message: "招待が見つかりません",
+			})
+		case errors.Is(err, usecase.ErrNotInvitationOwner):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "access_denied",
+				Message: "この招待を再送する権限がありません",
+			})
+		case errors.Is(err, domain.ErrInviteeEmailMissing):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invitee_email_missing",
+				Message: "この招待にはメールアドレスが設定されていません",
+			})
+		case errors.Is(err, domain.ErrInvitationEmailLimit):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "email_send_limit_reached",
+				Message: "招待メールの送信回数の上限に達しました",
+			})
+		case errors.Is(err, domain.ErrInvalidInvitationStatus):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_invitation",
+				Message: "この招待は有効ではありません",
+			})
+		case errors.Is(err, usecase.ErrEmailGatewayNotEnabled):
+			c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+				Error:   "email_not_enabled",
+				Message: "招待メールは現在利用できません",
+			})
+		default:
+			sc.logError("resend invitation email", err,
+				logger.Any("invitationID", invitationID),
+				logger.Any("userID", user.ID))
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "resend_invitation_email_failed",
+				Message: "招待メールの再送に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sc.invitationResponse(c.Request.Context(), invitation))
+}
+
+// InvitationEmailWebhookController はメール配信サービスからのWebhookを処理する
+type InvitationEmailWebhookController struct {
+	socialService usecase.SocialService
+	secret        string
+	logger        logger.Logger
+}
+
+// NewInvitationEmailWebhookController は新しいInvitationEmailWebhookControllerを作成する
+// secretが空の場合、Webhookは無効になる
+func NewInvitationEmailWebhookController(socialService usecase.SocialService, secret string, logger logger.Logger) *InvitationEmailWebhookController {
+	return &InvitationEmailWebhookController{
+		socialService: socialService,
+		secret:        secret,
+		logger:        logger,
+	}
+}
+
+// HandleBounce 招待メールのバウンス通知
+// @Summary      招待メールのバウンス通知
+// @Description  メール配信サービスからのバウンス通知を受け取り、招待を配信不能（UNDELIVERABLE）にします。X-Webhook-Secretヘッダーで認証します
+// @Tags         social
+// @Accept       json
+// @Produce      json
+// @Param        X-Webhook-Secret header string true "Webhookシークレット"
+// @Param        request body InvitationEmailBounceRequest true "バウンス情報"
+// @Success      200 {object} SuccessResponse "バウンス処理成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "Webhookシークレットが無効"
+// @Failure      404 {object} ErrorResponse "招待が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "Webhookが無効"
+// @Router       /webhooks/email/bounces [post]
+func (wc *InvitationEmailWebhookController) HandleBounce(c *gin.Context) {
+	if wc.secret == "" {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "webhook_disabled",
+			Message: "Webhookは無効です",
+		})
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader(WebhookSecretHeader)), []byte(wc.secret)) != 1 {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "invalid_webhook_secret",
+			Message: "Webhookシークレットが無効です",
+		})
+		return
+	}
+
+	var req InvitationEmailBounceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストの形式が正しくありません",
+		})
+		return
+	}
+
+	invitationID, err := uuid.Parse(req.InvitationID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_invitation_id",
+			Message: "無効な招待IDです",
+		})
+		return
+	}
+
+	if err := wc.socialService.HandleInvitationEmailBounce(c.Request.Context(), invitationID, req.Email, req.Reason); err != nil {
+		if errors.Is(err, usecase.ErrInvitationNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "invitation_not_found",
+				Message: "招待が見つかりません",
+			})
+			return
+		}
+		wc.logger.Error("Failed to handle invitation email bounce",
+			logger.Any("invitationID", invitationID),
+			logger.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "bounce_handling_failed",
+			Message: "バウンス通知の処理に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "バウンス通知を処理しました",
+	})
+}
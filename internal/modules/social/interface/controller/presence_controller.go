@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/modules/social/interface/dto"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PresenceResponse はオンライン状態のレスポンス構造体
+type PresenceResponse struct {
+	UserID     string  `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status     string  `json:"status" enums:"ONLINE,AWAY,OFFLINE" example:"ONLINE"`
+	LastSeenAt *string `json:"last_seen_at,omitempty" example:"2024-01-01T00:00:00Z"`
+} // @name PresenceResponse
+
+// FriendsPresenceResponse はオンライン状態一覧のレスポンス構造体
+type FriendsPresenceResponse struct {
+	Presences []PresenceResponse `json:"presences"`
+} // @name FriendsPresenceResponse
+
+// PresenceController はオンライン状態のHTTPハンドラー
+type PresenceController struct {
+	presenceService *usecase.PresenceService
+	logger          logger.Logger
+}
+
+// NewPresenceController は新しいPresenceControllerを作成する
+func NewPresenceController(presenceService *usecase.PresenceService, logger logger.Logger) *PresenceController {
+	return &PresenceController{
+		presenceService: presenceService,
+		logger:          logger,
+	}
+}
+
+// GetFriendsPresence 友達のオンライン状態一括取得
+// @Summary      友達のオンライン状態一括取得
+// @Description  友達のオンライン状態（ONLINE/AWAY/OFFLINE）と最終接続日時を取得します。user_idsを指定した場合は、友達・同じグループのメンバーのうち指定したユーザーのみを返します
+// @Tags         social
+// @Accept       json
+// @Produce      json
+// @Param        user_ids query string false "ユーザーID（カンマ区切り、最大200件）"
+// @Security     BearerAuth
+// @Success      200 {object} FriendsPresenceResponse "オンライン状態取得成功"
+// @Failure      400 {object} ErrorResponse "ユーザーIDが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "オンライン状態が無効"
+// @Router       /social/friends/presence [get]
+func (pc *PresenceController) GetFriendsPresence(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	userIDs, err := parseUserIDs(c.Query("user_ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_user_ids",
+			Message: "無効なユーザーIDが含まれています",
+		})
+		return
+	}
+
+	presences, err := pc.presenceService.GetFriendsPresence(c.Request.Context(), user.ID, userIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrTooManyPresenceIDs):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "too_many_user_ids",
+				Message: "ユーザーIDは200件まで指定できます",
+			})
+		case errors.Is(err, usecase.ErrPresenceNotEnabled):
+			c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+				Error:   "presence_not_enabled",
+				Message: "オンライン状態は現在利用できません",
+			})
+		default:
+			pc.logger.Error("Failed to get friends presence",
+				logger.Any("userID", user.ID),
+				logger.Error(err))
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "get_presence_failed",
+				Message: "オンライン状態の取得に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToFriendsPresenceResponse(presences))
+}
+
+// parseUserIDs はカンマ区切りのユーザーIDを解析する
+func parseUserIDs(value string) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, nil
+}
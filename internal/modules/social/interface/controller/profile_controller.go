@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/interface/dto"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PublicProfileResponse は公開プロフィールのレスポンス構造体
+type PublicProfileResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    *domain.PublicProfile `json:"data"`
+} // @name PublicProfileResponse
+
+// ProfileSettingsResponse は公開プロフィールの設定のレスポンス構造体
+type ProfileSettingsResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    *domain.ProfileSettings `json:"data"`
+} // @name ProfileSettingsResponse
+
+// UpdateProfileSettingsRequest は公開プロフィールの設定の変更リクエスト（省略した項目は変更しない）
+type UpdateProfileSettingsRequest struct {
+	DisplayName *string `json:"display_name" example:"山田 太郎"`
+	AvatarURL   *string `json:"avatar_url" example:"https://cdn.example.com/avatars/user123.png"`
+	Bio         *string `json:"bio" example:"毎朝のランニングを続けています"`
+	Visibility  *string `json:"visibility" enums:"PUBLIC,FRIENDS,PRIVATE" example:"FRIENDS"`
+	ShowStats   *bool   `json:"show_stats" example:"true"`
+} // @name UpdateProfileSettingsRequest
+
+// ProfileController は公開プロフィールのHTTPハンドラー
+type ProfileController struct {
+	profileService *usecase.ProfileService
+	logger         logger.Logger
+}
+
+// NewProfileController は新しいProfileControllerを作成する
+func NewProfileController(profileService *usecase.ProfileService, logger logger.Logger) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// GetPublicProfile 公開プロフィール取得
+// @Summary      公開プロフィール取得
+// @Description  ユーザー名の公開プロフィール（表示名・アイコン・自己紹介・公開している場合は連続達成日数などの統計）を取得します。ログインしていなくても取得できます。公開範囲外の場合はユーザー名のみを返し、restrictedをtrueにします。ログインしている場合はviewerに閲覧者との関係と友達申請を送れるかを含めます
+// @Tags         social
+// @Produce      json
+// @Param        username path string true "ユーザー名"
+// @Security     BearerAuth
+// @Success      200 {object} PublicProfileResponse "取得成功"
+// @Failure      404 {object} ErrorResponse "ユーザーが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /users/{username}/public [get]
+func (pc *ProfileController) GetPublicProfile(c *gin.Context) {
+	// /users/:id と同じ階層のため、パスパラメータ名は id を共有する
+	username := c.Param("id")
+
+	var viewerID *uuid.UUID
+	if user, err := middleware.GetUserFromContext(c); err == nil {
+		viewerID = &user.ID
+	}
+
+	profile, err := pc.profileService.GetPublicProfile(c.Request.Context(), username, viewerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "profile_not_found",
+				Message: "ユーザーが見つかりません",
+			})
+			return
+		}
+		pc.logger.Error("Failed to get public profile",
+			logger.Any("username", username),
+			logger.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "get_profile_failed",
+			Message: "プロフィールの取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, PublicProfileResponse{
+		Success: true,
+		Data:    profile,
+	})
+}
+
+// GetProfileSettings 公開プロフィールの設定取得
+// @Summary      公開プロフィールの設定取得
+// @Description  自分の公開プロフィールの内容と公開範囲（PUBLIC: 全員 / FRIENDS: 友達のみ / PRIVATE: 自分のみ）を取得します。設定していない場合は全員に公開・統計は非公開です
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} ProfileSettingsResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /social/profile [get]
+func (pc *ProfileController) GetProfileSettings(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	settings, err := pc.profileService.GetProfileSettings(c.Request.Context(), user.ID)
+	if err != nil {
+		pc.logger.Error("Failed to get profile settings",
+			logger.Any("userID", user.ID),
+			logger.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "get_profile_settings_failed",
+			Message: "プロフィールの設定の取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ProfileSettingsResponse{
+		Success: true,
+		Data:    settings,
+	})
+}
+
+// UpdateProfileSettings 公開プロフィールの設定変更
+// @Summary      公開プロフィールの設定変更
+// @Description  自分の公開プロフィールの内容と公開範囲を変更します。省略した項目は変更しません。アイコンはhttpsのURLのみ指定できます
+// @Tags         social
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdateProfileSettingsRequest true "変更内容"
+// @Security     BearerAuth
+// @Success      200 {object} ProfileSettingsResponse "変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /social/profile [put]
+func (pc *ProfileController) UpdateProfileSettings(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	var req UpdateProfileSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストが無効です",
+		})
+		return
+	}
+
+	input := usecase.UpdateProfileSettingsInput{
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarURL,
+		Bio:         req.Bio,
+		ShowStats:   req.ShowStats,
+	}
+	if req.Visibility != nil {
+		visibility := domain.ProfileVisibility(*req.Visibility)
+		input.Visibility = &visibility
+	}
+
+	settings, err := pc.profileService.UpdateProfileSettings(c.Request.Context(), user.ID, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidProfileInput) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_profile_settings",
+				Message: err.Error(),
+			})
+			return
+		}
+		pc.logger.Error("Failed to update profile settings",
+			logger.Any("userID", user.ID),
+			logger.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "update_profile_settings_failed",
+			Message: "プロフィールの設定の変更に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ProfileSettingsResponse{
+		Success: true,
+		Data:    settings,
+	})
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,10 +10,12 @@ import (
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
 	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/social/domain"
 	"github.com/hryt430/Yotei+/internal/modules/social/interface/dto"
 	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
 	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/qrcode"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -41,6 +45,7 @@ type CreateInvitationRequest struct {
 	ExpiresHours int     `json:"expires_hours" binding:"min=1,max=168" example:"168"`
 	InviteeEmail *string `json:"invitee_email,omitempty" binding:"omitempty,email" example:"friend@example.com"`
 	TargetID     *string `json:"target_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupRole    string  `json:"group_role,omitempty" enums:"MEMBER,GUEST" example:"GUEST"` // グループ招待で参加するときのロール（GUESTは閲覧のみ、省略時はMEMBER）
 } // @name CreateInvitationRequest
 
 // FriendshipResponse は友達関係のレスポンス構造体
@@ -86,6 +91,15 @@ type InvitationResponse struct {
 	CreatedAt   string              `json:"created_at" example:"2024-01-01T00:00:00Z"`
 	UpdatedAt   string              `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 	AcceptedAt  *string             `json:"accepted_at,omitempty" example:"2024-01-01T01:00:00Z"`
+
+	EmailSentAt    *string `json:"email_sent_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	EmailSendCount int     `json:"email_send_count" example:"1"`
+	BounceReason   string  `json:"bounce_reason,omitempty" example:"550 5.1.1 User unknown"`
+
+	QRCodeURL string `json:"qr_code_url,omitempty" example:"/api/v1/social/invitations/123e4567-e89b-12d3-a456-426614174000/qr"`
+
+	DeepLink      string `json:"deep_link,omitempty" example:"yoteiplus://invite/abc123def456"`
+	UniversalLink string `json:"universal_link,omitempty" example:"https://app.yotei-plus.com/invite/abc123def456"`
 } // @name InvitationResponse
 
 // InvitationResultResponse は招待受諾結果のレスポンス構造体
@@ -110,11 +124,19 @@ type FriendsListResponse struct {
 	Pagination PaginationInfo               `json:"pagination"`
 } // @name FriendsListResponse
 
+// BlockedUsersResponse はブロックしたユーザー一覧のレスポンス構造体
+type BlockedUsersResponse struct {
+	Blocks     []FriendWithUserInfoResponse `json:"blocks"`
+	Pagination PaginationInfo               `json:"pagination"`
+} // @name BlockedUsersResponse
+
 // InviteURLResponse は招待URL生成のレスポンス構造体
 type InviteURLResponse struct {
-	URL       string `json:"url" example:"https://yotei-plus.com/invite/abc123def456"`
-	Code      string `json:"code" example:"abc123def456"`
-	ExpiresAt string `json:"expires_at" example:"2024-01-08T00:00:00Z"`
+	URL           string `json:"url" example:"https://yotei-plus.com/invite/abc123def456"`
+	DeepLink      string `json:"deep_link,omitempty" example:"yoteiplus://invite/abc123def456"`
+	UniversalLink string `json:"universal_link,omitempty" example:"https://app.yotei-plus.com/invite/abc123def456"`
+	Code          string `json:"code" example:"abc123def456"`
+	ExpiresAt     string `json:"expires_at" example:"2024-01-08T00:00:00Z"`
 } // @name InviteURLResponse
 
 // PaginationInfo はページング情報
@@ -131,6 +153,13 @@ type SuccessResponse struct {
 	Message string `json:"message" example:"操作が正常に完了しました"`
 } // @name SuccessResponse
 
+// FriendRemovedResponse は友達削除レスポンス（取り消し可能な場合は取り消し用のトークンを含む）
+type FriendRemovedResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"友達を削除しました"`
+	Undo    *undo.Token `json:"undo,omitempty"`
+} // @name FriendRemovedResponse
+
 // ErrorResponse はエラーレスポンス
 type ErrorResponse struct {
 	Error   string `json:"error" example:"INVALID_REQUEST"`
@@ -150,6 +179,7 @@ type ErrorResponse struct {
 // @Success      201 {object} FriendshipResponse "友達申請送信成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効（自分自身への申請など）"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "ブロック関係にあるユーザー"
 // @Failure      404 {object} ErrorResponse "ユーザーが見つからない"
 // @Failure      409 {object} ErrorResponse "既に友達または申請済み"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
@@ -195,6 +225,13 @@ func (sc *SocialController) SendFriendRequest(c *gin.Context) {
 
 	friendship, err := sc.socialService.SendFriendRequest(c.Request.Context(), user.ID, addresseeID, req.Message)
 	if err != nil {
+		if errors.Is(err, usecase.ErrUserBlocked) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "user_blocked",
+				Message: "このユーザーには友達申請を送信できません",
+			})
+			return
+		}
 		sc.logError("send friend request", err,
 			logger.Any("requesterID", user.ID),
 			logger.Any("addresseeID", addresseeID))
@@ -327,13 +364,13 @@ func (sc *SocialController) DeclineFriendRequest(c *gin.Context) {
 
 // RemoveFriend 友達削除
 // @Summary      友達削除
-// @Description  友達関係を解除します
+// @Description  友達関係を解除します。解除は取り消し期間（既定10秒）の経過後に実行され、その間はundo.undo_tokenをPOST /undo/{token}に送ると取り消せます
 // @Tags         social
 // @Accept       json
 // @Produce      json
 // @Param        userId path string true "削除する友達のユーザーID" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Security     BearerAuth
-// @Success      200 {object} SuccessResponse "友達削除成功"
+// @Success      200 {object} FriendRemovedResponse "友達削除成功"
 // @Failure      400 {object} ErrorResponse "ユーザーIDが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      404 {object} ErrorResponse "友達関係が見つからない"
@@ -359,7 +396,7 @@ func (sc *SocialController) RemoveFriend(c *gin.Context) {
 		return
 	}
 
-	err = sc.socialService.RemoveFriend(c.Request.Context(), user.ID, friendID)
+	token, err := sc.socialService.RemoveFriendWithUndo(c.Request.Context(), user.ID, friendID)
 	if err != nil {
 		sc.logError("remove friend", err,
 			logger.Any("userID", user.ID),
@@ -375,9 +412,10 @@ func (sc *SocialController) RemoveFriend(c *gin.Context) {
 		logger.Any("userID", user.ID),
 		logger.Any("friendID", friendID))
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
+	c.JSON(http.StatusOK, FriendRemovedResponse{
 		Success: true,
 		Message: "友達を削除しました",
+		Undo:    token,
 	})
 }
 
@@ -475,6 +513,13 @@ func (sc *SocialController) UnblockUser(c *gin.Context) {
 
 	err = sc.socialService.UnblockUser(c.Request.Context(), user.ID, targetID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrBlockNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "block_not_found",
+				Message: "ブロック関係が見つかりません",
+			})
+			return
+		}
 		sc.logError("unblock user", err,
 			logger.Any("userID", user.ID),
 			logger.Any("targetID", targetID))
@@ -495,18 +540,59 @@ func (sc *SocialController) UnblockUser(c *gin.Context) {
 	})
 }
 
+// GetBlockedUsers ブロックしたユーザー一覧取得
+// @Summary      ブロックしたユーザー一覧取得
+// @Description  自分がブロックしたユーザーの一覧を取得します（ページング対応）
+// @Tags         social
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "ページ番号" default(1) minimum(1)
+// @Param        page_size query int false "ページサイズ" default(20) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} BlockedUsersResponse "ブロック一覧取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /social/blocks [get]
+func (sc *SocialController) GetBlockedUsers(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		sc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	pagination := sc.getPaginationFromQuery(c)
+	blocked, err := sc.socialService.GetBlockedUsers(c.Request.Context(), user.ID, pagination)
+	if err != nil {
+		sc.logError("get blocked users", err, logger.Any("userID", user.ID))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "get_blocked_users_failed",
+			Message: "ブロック一覧の取得に失敗しました",
+		})
+		return
+	}
+
+	response := dto.ToBlockedUsersResponse(blocked, len(blocked), pagination.Page, pagination.PageSize)
+	c.JSON(http.StatusOK, response)
+}
+
 // === 友達一覧・検索 ===
 
 // GetFriends 友達一覧取得
 // @Summary      友達一覧取得
-// @Description  自分の友達一覧を取得します（ページング対応）
+// @Description  自分の友達一覧を取得します（ページング・並び替え対応）
 // @Tags         social
 // @Accept       json
 // @Produce      json
 // @Param        page query int false "ページ番号" default(1) minimum(1)
 // @Param        page_size query int false "ページサイズ" default(20) minimum(1) maximum(100)
+// @Param        sort_by query string false "並び順（友達になった日時・ユーザー名・最終ログイン）" Enums(recently_added, username, recently_active) default(recently_added)
 // @Security     BearerAuth
 // @Success      200 {object} FriendsListResponse "友達一覧取得成功"
+// @Failure      400 {object} ErrorResponse "並び順が無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /social/friends [get]
@@ -521,8 +607,17 @@ func (sc *SocialController) GetFriends(c *gin.Context) {
 		return
 	}
 
+	sort, err := domain.ParseFriendSort(c.Query("sort_by"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_sort_by",
+			Message: "並び順はrecently_added、username、recently_activeのいずれかを指定してください",
+		})
+		return
+	}
+
 	pagination := sc.getPaginationFromQuery(c)
-	friends, err := sc.socialService.GetFriends(c.Request.Context(), user.ID, pagination)
+	friends, total, err := sc.socialService.GetFriends(c.Request.Context(), user.ID, sort, pagination)
 	if err != nil {
 		sc.logError("get friends", err, logger.Any("userID", user.ID))
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -532,8 +627,6 @@ func (sc *SocialController) GetFriends(c *gin.Context) {
 		return
 	}
 
-	// TODO: 総数を取得する実装が必要
-	total := len(friends)
 	response := dto.ToFriendsListResponse(friends, total, pagination.Page, pagination.PageSize)
 	c.JSON(http.StatusOK, response)
 }
@@ -675,7 +768,9 @@ func (sc *SocialController) GetMutualFriends(c *gin.Context) {
 
 // CreateInvitation 招待作成
 // @Summary      招待作成
-// @Description  友達招待またはグループ招待を作成します
+// @Description  友達招待またはグループ招待を作成します。
+// @Description  invitee_emailを指定したグループ招待は、そのメールアドレスで新規登録したユーザーを自動的にグループに参加させます（招待できるのはグループの管理者のみ）
+// @Description  グループ招待でgroup_role=GUESTを指定すると、受諾したユーザーはタスク・予定・統計の閲覧のみできるゲストとして参加します
 // @Tags         social
 // @Accept       json
 // @Produce      json
@@ -684,6 +779,8 @@ func (sc *SocialController) GetMutualFriends(c *gin.Context) {
 // @Success      201 {object} InvitationResponse "招待作成成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループへの招待権限がない"
+// @Failure      429 {object} ErrorResponse "1日あたりの招待数が上限に達している"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /social/invitations [post]
 func (sc *SocialController) CreateInvitation(c *gin.Context) {
@@ -734,6 +831,7 @@ func (sc *SocialController) CreateInvitation(c *gin.Context) {
 		Message:      req.Message,
 		ExpiresHours: req.ExpiresHours,
 		InviteeEmail: req.InviteeEmail,
+		GroupRole:    req.GroupRole,
 	}
 
 	if req.TargetID != nil {
@@ -764,6 +862,27 @@ func (sc *SocialController) CreateInvitation(c *gin.Context) {
 
 	invitation, err := sc.socialService.CreateInvitation(c.Request.Context(), input)
 	if err != nil {
+		if errors.Is(err, usecase.ErrGroupInvitationNotAllowed) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "group_invitation_not_allowed",
+				Message: "このグループに招待する権限がありません",
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidGroupRole) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_group_role",
+				Message: "グループ招待のロールが正しくありません",
+			})
+			return
+		}
+		if errors.Is(err, commonDomain.ErrQuotaExceeded) {
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Error:   "quota_exceeded",
+				Message: "1日に作成できる招待数の上限に達しています",
+			})
+			return
+		}
 		sc.logError("create invitation", err, logger.Any("inviterID", user.ID))
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "create_invitation_failed",
@@ -776,7 +895,7 @@ func (sc *SocialController) CreateInvitation(c *gin.Context) {
 		logger.Any("inviterID", user.ID),
 		logger.Any("invitationID", invitation.ID))
 
-	response := dto.ToInvitationResponse(invitation)
+	response := sc.invitationResponse(c.Request.Context(), invitation)
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -843,7 +962,7 @@ func (sc *SocialController) GetInvitation(c *gin.Context) {
 		return
 	}
 
-	response := dto.ToInvitationResponse(invitation)
+	response := sc.invitationResponse(c.Request.Context(), invitation)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -932,6 +1051,7 @@ func (sc *SocialController) GetInvitationByCode(c *gin.Context) {
 // @Success      200 {object} InvitationResultResponse "招待受諾成功"
 // @Failure      400 {object} ErrorResponse "招待コードが必要"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "招待者とブロック関係にある"
 // @Failure      404 {object} ErrorResponse "有効な招待が見つからない"
 // @Failure      410 {object} ErrorResponse "招待の有効期限が切れている"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
@@ -958,6 +1078,13 @@ func (sc *SocialController) AcceptInvitation(c *gin.Context) {
 
 	result, err := sc.socialService.AcceptInvitation(c.Request.Context(), code, user.ID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrUserBlocked) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "user_blocked",
+				Message: "この招待は受諾できません",
+			})
+			return
+		}
 		sc.logError("accept invitation", err,
 			logger.Any("code", code),
 			logger.Any("userID", user.ID))
@@ -1128,6 +1255,7 @@ func (sc *SocialController) GetSentInvitations(c *gin.Context) {
 	// TODO: 総数を取得する実装が必要
 	total := len(invitations)
 	response := dto.ToInvitationsListResponse(invitations, total, pagination.Page, pagination.PageSize)
+	sc.addInviteLinks(c.Request.Context(), response, invitations)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -1169,6 +1297,7 @@ func (sc *SocialController) GetReceivedInvitations(c *gin.Context) {
 	// TODO: 総数を取得する実装が必要
 	total := len(invitations)
 	response := dto.ToInvitationsListResponse(invitations, total, pagination.Page, pagination.PageSize)
+	sc.addInviteLinks(c.Request.Context(), response, invitations)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -1207,7 +1336,25 @@ func (sc *SocialController) GenerateInviteURL(c *gin.Context) {
 		return
 	}
 
-	url, err := sc.socialService.GenerateInviteURL(c.Request.Context(), invitationID)
+	invitation, err := sc.socialService.GetInvitation(c.Request.Context(), invitationID)
+	if err != nil {
+		sc.logError("get invitation details", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "get_invitation_failed",
+			Message: "招待情報の取得に失敗しました",
+		})
+		return
+	}
+
+	if invitation == nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "invitation_not_found",
+			Message: "招待が見つかりません",
+		})
+		return
+	}
+
+	links, err := sc.socialService.GenerateInviteLinks(c.Request.Context(), invitation)
 	if err != nil {
 		sc.logError("generate invite URL", err,
 			logger.Any("userID", user.ID),
@@ -1219,10 +1366,78 @@ func (sc *SocialController) GenerateInviteURL(c *gin.Context) {
 		return
 	}
 
-	// 招待情報も取得してレスポンスに含める
+	response := dto.InviteURLResponse{
+		URL:           links.URL,
+		DeepLink:      links.DeepLink,
+		UniversalLink: links.UniversalLink,
+		Code:          invitation.Code,
+		ExpiresAt:     invitation.ExpiresAt,
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetInvitationQRCode 招待QRコード取得
+// @Summary      招待QRコード取得
+// @Description  招待URLをQRコード画像（PNG/SVG）で取得します。対面で招待を共有する場合に使用します（招待者のみ）
+// @Tags         social
+// @Produce      png
+// @Produce      image/svg+xml
+// @Param        invitationId path string true "招待ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        format query string false "画像形式" Enums(png, svg) default(png)
+// @Param        size query int false "画像サイズ（ピクセル、64〜1024）" default(256)
+// @Security     BearerAuth
+// @Success      200 {file} file "QRコード画像"
+// @Failure      400 {object} ErrorResponse "招待ID・形式・サイズが無効、または招待コードがない"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "この招待のQRコードを取得する権限がない"
+// @Failure      404 {object} ErrorResponse "招待が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /social/invitations/{invitationId}/qr [get]
+func (sc *SocialController) GetInvitationQRCode(c *gin.Context) {
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		sc.logError("get user from context", err)
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証が必要です",
+		})
+		return
+	}
+
+	invitationID, err := sc.validateUUID(c.Param("invitationId"), "invitation ID")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_invitation_id",
+			Message: "無効な招待IDです",
+		})
+		return
+	}
+
+	format, err := qrcode.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "画像形式はpngまたはsvgを指定してください",
+		})
+		return
+	}
+
+	size := qrcode.DefaultSize
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < qrcode.MinSize || size > qrcode.MaxSize {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_size",
+				Message: "サイズは64〜1024の範囲で指定してください",
+			})
+			return
+		}
+	}
+
 	invitation, err := sc.socialService.GetInvitation(c.Request.Context(), invitationID)
 	if err != nil {
-		sc.logError("get invitation details", err)
+		sc.logError("get invitation", err, logger.Any("invitationID", invitationID))
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "get_invitation_failed",
 			Message: "招待情報の取得に失敗しました",
@@ -1230,13 +1445,55 @@ func (sc *SocialController) GenerateInviteURL(c *gin.Context) {
 		return
 	}
 
-	response := dto.InviteURLResponse{
-		URL:       url,
-		Code:      invitation.Code,
-		ExpiresAt: invitation.ExpiresAt,
+	if invitation == nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "invitation_not_found",
+			Message: "招待が見つかりません",
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	// 権限チェック（招待者のみ）
+	if invitation.InviterID != user.ID {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "access_denied",
+			Message: "この招待のQRコードを取得する権限がありません",
+		})
+		return
+	}
+
+	if invitation.Code == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invitation_code_missing",
+			Message: "この招待には招待コードがありません",
+		})
+		return
+	}
+
+	url, err := sc.socialService.GenerateInviteURL(c.Request.Context(), invitationID)
+	if err != nil {
+		sc.logError("generate invite URL", err,
+			logger.Any("userID", user.ID),
+			logger.Any("invitationID", invitationID))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "generate_url_failed",
+			Message: "招待URLの生成に失敗しました",
+		})
+		return
+	}
+
+	image, err := qrcode.Encode(url, format, size)
+	if err != nil {
+		sc.logError("encode QR code", err, logger.Any("invitationID", invitationID))
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "generate_qr_code_failed",
+			Message: "QRコードの生成に失敗しました",
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, format.ContentType(), image)
 }
 
 // GetRelationship ユーザー間関係取得
@@ -1303,6 +1560,29 @@ func (sc *SocialController) validateUUID(id string, fieldName string) (uuid.UUID
 	return parsedID, nil
 }
 
+// invitationResponse は招待のレスポンスを作成する（招待コードがある場合はWeb・アプリのリンクを含める）
+func (sc *SocialController) invitationResponse(ctx context.Context, invitation *domain.Invitation) *dto.InvitationResponse {
+	response := dto.ToInvitationResponse(invitation)
+	if invitation.Code == "" {
+		return response
+	}
+
+	links, err := sc.socialService.GenerateInviteLinks(ctx, invitation)
+	if err != nil {
+		// リンクがなくても招待コードで参加できるため、レスポンスは返す
+		sc.logError("generate invite links", err, logger.Any("invitationID", invitation.ID))
+		return response
+	}
+	return response.WithLinks(links)
+}
+
+// addInviteLinks は招待一覧の各招待にWeb・アプリのリンクを含める
+func (sc *SocialController) addInviteLinks(ctx context.Context, response *dto.InvitationsListResponse, invitations []*domain.Invitation) {
+	for i, invitation := range invitations {
+		response.Invitations[i] = *sc.invitationResponse(ctx, invitation)
+	}
+}
+
 func (sc *SocialController) logError(operation string, err error, fields ...zapcore.Field) {
 	sc.logger.Error("Operation failed",
 		append([]zapcore.Field{
@@ -1346,6 +1626,7 @@ func RegisterSocialRoutes(router *gin.RouterGroup, controller *SocialController)
 		// ブロック機能
 		social.POST("/users/:userId/block", controller.BlockUser)
 		social.DELETE("/users/:userId/block", controller.UnblockUser)
+		social.GET("/blocks", controller.GetBlockedUsers)
 
 		// 友達一覧・検索
 		social.GET("/friends", controller.GetFriends)
@@ -1359,10 +1640,12 @@ func RegisterSocialRoutes(router *gin.RouterGroup, controller *SocialController)
 		social.GET("/invitations/code/:code", controller.GetInvitationByCode)
 		social.POST("/invitations/:code/accept", controller.AcceptInvitation)
 		social.PUT("/invitations/:invitationId/decline", controller.DeclineInvitation)
+		social.PUT("/invitations/:invitationId/resend", controller.ResendInvitationEmail)
 		social.DELETE("/invitations/:invitationId", controller.CancelInvitation)
 		social.GET("/invitations/sent", controller.GetSentInvitations)
 		social.GET("/invitations/received", controller.GetReceivedInvitations)
 		social.GET("/invitations/:invitationId/url", controller.GenerateInviteURL)
+		social.GET("/invitations/:invitationId/qr", controller.GetInvitationQRCode)
 
 		// 関係性チェック
 		social.GET("/relationships/:userId", controller.GetRelationship)
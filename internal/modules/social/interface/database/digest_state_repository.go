@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+type DigestStateRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewDigestStateRepository(db *sql.DB, logger logger.Logger) usecase.DigestStateRepository {
+	return &DigestStateRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListDigestStates は全ユーザーのリマインダーの送信の記録を取得する
+func (r *DigestStateRepository) ListDigestStates(ctx context.Context) ([]*domain.DigestState, error) {
+	query := `
+		SELECT user_id, notification_id, last_sent_at
+		FROM social_digest_states
+		ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list digest states", logger.Error(err))
+		return nil, fmt.Errorf("failed to list digest states: %w", err)
+	}
+	defer rows.Close()
+
+	var states []*domain.DigestState
+	for rows.Next() {
+		var state domain.DigestState
+		if err := rows.Scan(&state.UserID, &state.NotificationID, &state.LastSentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan digest state: %w", err)
+		}
+		states = append(states, &state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate digest states: %w", err)
+	}
+	return states, nil
+}
+
+// SaveDigestState はリマインダーの送信の記録を保存する（既存の記録は上書きする）
+func (r *DigestStateRepository) SaveDigestState(ctx context.Context, state *domain.DigestState) error {
+	query := `
+		INSERT INTO social_digest_states (user_id, notification_id, last_sent_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			notification_id = VALUES(notification_id),
+			last_sent_at = VALUES(last_sent_at)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, state.UserID, state.NotificationID, state.LastSentAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save digest state",
+			logger.Any("userID", state.UserID),
+			logger.Error(err))
+		return fmt.Errorf("failed to save digest state: %w", err)
+	}
+	return nil
+}
+
+// DeleteDigestState はリマインダーの送信の記録を削除する
+func (r *DigestStateRepository) DeleteDigestState(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM social_digest_states WHERE user_id = ?`, userID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete digest state",
+			logger.Any("userID", userID),
+			logger.Error(err))
+		return fmt.Errorf("failed to delete digest state: %w", err)
+	}
+	return nil
+}
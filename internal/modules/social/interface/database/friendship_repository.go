@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
@@ -41,7 +42,7 @@ func (r *FriendshipRepository) CreateFriendship(ctx context.Context, friendship
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to create friendship",
+		r.logger.WithContext(ctx).Error("Failed to create friendship",
 			logger.Any("friendship", friendship),
 			logger.Error(err))
 		return fmt.Errorf("failed to create friendship: %w", err)
@@ -76,7 +77,7 @@ func (r *FriendshipRepository) GetFriendship(ctx context.Context, requesterID, a
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get friendship",
+		r.logger.WithContext(ctx).Error("Failed to get friendship",
 			logger.Any("requesterID", requesterID),
 			logger.Any("addresseeID", addresseeID),
 			logger.Error(err))
@@ -119,7 +120,7 @@ func (r *FriendshipRepository) GetFriendshipByID(ctx context.Context, friendship
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get friendship by ID",
+		r.logger.WithContext(ctx).Error("Failed to get friendship by ID",
 			logger.Any("friendshipID", friendshipID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get friendship by ID: %w", err)
@@ -152,7 +153,7 @@ func (r *FriendshipRepository) UpdateFriendship(ctx context.Context, friendship
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to update friendship",
+		r.logger.WithContext(ctx).Error("Failed to update friendship",
 			logger.Any("friendship", friendship),
 			logger.Error(err))
 		return fmt.Errorf("failed to update friendship: %w", err)
@@ -170,7 +171,7 @@ func (r *FriendshipRepository) DeleteFriendship(ctx context.Context, requesterID
 
 	_, err := r.db.ExecContext(ctx, query, requesterID, addresseeID, addresseeID, requesterID)
 	if err != nil {
-		r.logger.Error("Failed to delete friendship",
+		r.logger.WithContext(ctx).Error("Failed to delete friendship",
 			logger.Any("requesterID", requesterID),
 			logger.Any("addresseeID", addresseeID),
 			logger.Error(err))
@@ -180,21 +181,34 @@ func (r *FriendshipRepository) DeleteFriendship(ctx context.Context, requesterID
 	return nil
 }
 
-// GetFriends は友達一覧を取得する
-func (r *FriendshipRepository) GetFriends(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
+// friendSortOrders は並び順ごとのORDER BY句（uは友達側のユーザー）
+var friendSortOrders = map[domain.FriendSort]string{
+	domain.FriendSortRecentlyAdded:  "COALESCE(f.accepted_at, f.updated_at) DESC, f.id",
+	domain.FriendSortUsername:       "u.username ASC, f.id",
+	domain.FriendSortRecentlyActive: "u.last_login IS NULL, u.last_login DESC, f.id",
+}
+
+// GetFriends は友達一覧を指定した並び順で取得する
+func (r *FriendshipRepository) GetFriends(ctx context.Context, userID uuid.UUID, sort domain.FriendSort, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
 	offset := (pagination.Page - 1) * pagination.PageSize
 
+	orderBy, ok := friendSortOrders[sort]
+	if !ok {
+		orderBy = friendSortOrders[domain.FriendSortRecentlyAdded]
+	}
+
 	query := `
-		SELECT id, requester_id, addressee_id, status, created_at, updated_at, accepted_at, blocked_at
-		FROM friendships
-		WHERE (requester_id = ? OR addressee_id = ?) AND status = ?
-		ORDER BY updated_at DESC
+		SELECT f.id, f.requester_id, f.addressee_id, f.status, f.created_at, f.updated_at, f.accepted_at, f.blocked_at
+		FROM friendships f
+		LEFT JOIN users u ON u.id = CASE WHEN f.requester_id = ? THEN f.addressee_id ELSE f.requester_id END
+		WHERE (f.requester_id = ? OR f.addressee_id = ?) AND f.status = ?
+		ORDER BY ` + orderBy + `
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, userID, domain.FriendshipStatusAccepted, pagination.PageSize, offset)
+	rows, err := r.db.QueryContext(ctx, query, userID, userID, userID, domain.FriendshipStatusAccepted, pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to get friends",
+		r.logger.WithContext(ctx).Error("Failed to get friends",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get friends: %w", err)
@@ -217,7 +231,7 @@ func (r *FriendshipRepository) GetFriends(ctx context.Context, userID uuid.UUID,
 			&blockedAt,
 		)
 		if err != nil {
-			r.logger.Error("Failed to scan friendship", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan friendship", logger.Error(err))
 			continue
 		}
 
@@ -232,13 +246,170 @@ func (r *FriendshipRepository) GetFriends(ctx context.Context, userID uuid.UUID,
 	}
 
 	if err = rows.Err(); err != nil {
-		r.logger.Error("Error iterating friendship rows", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Error iterating friendship rows", logger.Error(err))
 		return nil, fmt.Errorf("error iterating friendship rows: %w", err)
 	}
 
 	return friendships, nil
 }
 
+// GetBlockedUsers は指定ユーザーがブロックした関係を取得する
+func (r *FriendshipRepository) GetBlockedUsers(ctx context.Context, blockerID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
+	offset := (pagination.Page - 1) * pagination.PageSize
+
+	query := `
+		SELECT id, requester_id, addressee_id, status, created_at, updated_at, accepted_at, blocked_at
+		FROM friendships
+		WHERE requester_id = ? AND status = ?
+		ORDER BY blocked_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, blockerID, domain.FriendshipStatusBlocked, pagination.PageSize, offset)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get blocked users",
+			logger.Any("blockerID", blockerID),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	var friendships []*domain.Friendship
+	for rows.Next() {
+		var friendship domain.Friendship
+		var acceptedAt, blockedAt sql.NullTime
+
+		err := rows.Scan(
+			&friendship.ID,
+			&friendship.RequesterID,
+			&friendship.AddresseeID,
+			&friendship.Status,
+			&friendship.CreatedAt,
+			&friendship.UpdatedAt,
+			&acceptedAt,
+			&blockedAt,
+		)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan friendship", logger.Error(err))
+			continue
+		}
+
+		if acceptedAt.Valid {
+			friendship.AcceptedAt = &acceptedAt.Time
+		}
+		if blockedAt.Valid {
+			friendship.BlockedAt = &blockedAt.Time
+		}
+
+		friendships = append(friendships, &friendship)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithContext(ctx).Error("Error iterating friendship rows", logger.Error(err))
+		return nil, fmt.Errorf("error iterating friendship rows: %w", err)
+	}
+
+	return friendships, nil
+}
+
+// GetBlockRelatedUserIDs は指定ユーザーとブロック関係にある（ブロックした・された）ユーザーIDを取得する
+func (r *FriendshipRepository) GetBlockRelatedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT CASE WHEN requester_id = ? THEN addressee_id ELSE requester_id END
+		FROM friendships
+		WHERE (requester_id = ? OR addressee_id = ?) AND status = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, userID, userID, domain.FriendshipStatusBlocked)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get block related users",
+			logger.Any("userID", userID),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to get block related users: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan blocked user ID", logger.Error(err))
+			continue
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithContext(ctx).Error("Error iterating blocked user rows", logger.Error(err))
+		return nil, fmt.Errorf("error iterating blocked user rows: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// GetFriendIDs は友達のユーザーIDを全て取得する
+func (r *FriendshipRepository) GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT CASE WHEN requester_id = ? THEN addressee_id ELSE requester_id END
+		FROM friendships
+		WHERE (requester_id = ? OR addressee_id = ?) AND status = ?
+	`
+
+	return r.queryUserIDs(ctx, "friend IDs", query, userID, userID, userID, domain.FriendshipStatusAccepted)
+}
+
+// GetPresenceAudience はオンライン状態を共有する（友達・同じグループに所属する）ユーザーIDを取得する
+// ブロック関係にあるユーザーは含まない
+func (r *FriendshipRepository) GetPresenceAudience(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT CASE WHEN requester_id = ? THEN addressee_id ELSE requester_id END
+		FROM friendships
+		WHERE (requester_id = ? OR addressee_id = ?) AND status = ?
+		UNION
+		SELECT gm.user_id
+		FROM group_members gm
+		JOIN group_members me ON me.group_id = gm.group_id AND me.user_id = ?
+		WHERE gm.user_id <> ?
+		AND NOT EXISTS (
+			SELECT 1 FROM friendships b
+			WHERE b.status = ?
+			AND ((b.requester_id = ? AND b.addressee_id = gm.user_id)
+				OR (b.addressee_id = ? AND b.requester_id = gm.user_id))
+		)
+	`
+
+	return r.queryUserIDs(ctx, "presence audience", query,
+		userID, userID, userID, domain.FriendshipStatusAccepted,
+		userID, userID, domain.FriendshipStatusBlocked, userID, userID)
+}
+
+// queryUserIDs はユーザーIDの一覧を返すクエリを実行する
+func (r *FriendshipRepository) queryUserIDs(ctx context.Context, name string, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get "+name, logger.Error(err))
+		return nil, fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan user ID", logger.Any("query", name), logger.Error(err))
+			continue
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithContext(ctx).Error("Error iterating user ID rows", logger.Any("query", name), logger.Error(err))
+		return nil, fmt.Errorf("error iterating %s rows: %w", name, err)
+	}
+
+	return userIDs, nil
+}
+
 // GetPendingRequests は受信した友達申請を取得する
 func (r *FriendshipRepository) GetPendingRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error) {
 	offset := (pagination.Page - 1) * pagination.PageSize
@@ -253,7 +424,7 @@ func (r *FriendshipRepository) GetPendingRequests(ctx context.Context, userID uu
 
 	rows, err := r.db.QueryContext(ctx, query, userID, domain.FriendshipStatusPending, pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to get pending requests",
+		r.logger.WithContext(ctx).Error("Failed to get pending requests",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get pending requests: %w", err)
@@ -276,7 +447,7 @@ func (r *FriendshipRepository) GetPendingRequests(ctx context.Context, userID uu
 			&blockedAt,
 		)
 		if err != nil {
-			r.logger.Error("Failed to scan pending request", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan pending request", logger.Error(err))
 			continue
 		}
 
@@ -307,7 +478,7 @@ func (r *FriendshipRepository) GetSentRequests(ctx context.Context, userID uuid.
 
 	rows, err := r.db.QueryContext(ctx, query, userID, domain.FriendshipStatusPending, pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to get sent requests",
+		r.logger.WithContext(ctx).Error("Failed to get sent requests",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get sent requests: %w", err)
@@ -330,7 +501,7 @@ func (r *FriendshipRepository) GetSentRequests(ctx context.Context, userID uuid.
 			&blockedAt,
 		)
 		if err != nil {
-			r.logger.Error("Failed to scan sent request", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan sent request", logger.Error(err))
 			continue
 		}
 
@@ -358,7 +529,7 @@ func (r *FriendshipRepository) AreFriends(ctx context.Context, userID1, userID2
 	var count int
 	err := r.db.QueryRowContext(ctx, query, userID1, userID2, userID2, userID1, domain.FriendshipStatusAccepted).Scan(&count)
 	if err != nil {
-		r.logger.Error("Failed to check if users are friends",
+		r.logger.WithContext(ctx).Error("Failed to check if users are friends",
 			logger.Any("userID1", userID1),
 			logger.Any("userID2", userID2),
 			logger.Error(err))
@@ -379,7 +550,7 @@ func (r *FriendshipRepository) IsBlocked(ctx context.Context, userID1, userID2 u
 	var count int
 	err := r.db.QueryRowContext(ctx, query, userID1, userID2, userID2, userID1, domain.FriendshipStatusBlocked).Scan(&count)
 	if err != nil {
-		r.logger.Error("Failed to check if user is blocked",
+		r.logger.WithContext(ctx).Error("Failed to check if user is blocked",
 			logger.Any("userID1", userID1),
 			logger.Any("userID2", userID2),
 			logger.Error(err))
@@ -389,8 +560,8 @@ func (r *FriendshipRepository) IsBlocked(ctx context.Context, userID1, userID2 u
 	return count > 0, nil
 }
 
-// GetFriendCount は友達数を取得する
-func (r *FriendshipRepository) GetFriendCount(ctx context.Context, userID uuid.UUID) (int, error) {
+// CountFriends は友達数を取得する
+func (r *FriendshipRepository) CountFriends(ctx context.Context, userID uuid.UUID) (int, error) {
 	query := `
 		SELECT COUNT(*) FROM friendships
 		WHERE (requester_id = ? OR addressee_id = ?) AND status = ?
@@ -399,7 +570,7 @@ func (r *FriendshipRepository) GetFriendCount(ctx context.Context, userID uuid.U
 	var count int
 	err := r.db.QueryRowContext(ctx, query, userID, userID, domain.FriendshipStatusAccepted).Scan(&count)
 	if err != nil {
-		r.logger.Error("Failed to get friend count",
+		r.logger.WithContext(ctx).Error("Failed to get friend count",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return 0, fmt.Errorf("failed to get friend count: %w", err)
@@ -428,7 +599,7 @@ func (r *FriendshipRepository) GetMutualFriends(ctx context.Context, userID1, us
 		userID1, userID1, userID2, userID2,
 		userID1, userID1, userID2, userID2)
 	if err != nil {
-		r.logger.Error("Failed to get mutual friends",
+		r.logger.WithContext(ctx).Error("Failed to get mutual friends",
 			logger.Any("userID1", userID1),
 			logger.Any("userID2", userID2),
 			logger.Error(err))
@@ -452,7 +623,58 @@ func (r *FriendshipRepository) GetMutualFriends(ctx context.Context, userID1, us
 			&blockedAt,
 		)
 		if err != nil {
-			r.logger.Error("Failed to scan mutual friend", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan mutual friend", logger.Error(err))
+			continue
+		}
+
+		if acceptedAt.Valid {
+			friendship.AcceptedAt = &acceptedAt.Time
+		}
+		if blockedAt.Valid {
+			friendship.BlockedAt = &blockedAt.Time
+		}
+
+		friendships = append(friendships, &friendship)
+	}
+
+	return friendships, nil
+}
+
+// GetStalePendingRequests は指定日時より前に作成された承認待ちの申請を古い順に取得する
+func (r *FriendshipRepository) GetStalePendingRequests(ctx context.Context, createdBefore time.Time, limit int) ([]*domain.Friendship, error) {
+	query := `
+		SELECT id, requester_id, addressee_id, status, created_at, updated_at, accepted_at, blocked_at, reminder_sent_at
+		FROM friendships
+		WHERE status = ? AND created_at < ?
+		ORDER BY created_at ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.FriendshipStatusPending, createdBefore, limit)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get stale pending requests", logger.Error(err))
+		return nil, fmt.Errorf("failed to get stale pending requests: %w", err)
+	}
+	defer rows.Close()
+
+	var friendships []*domain.Friendship
+	for rows.Next() {
+		var friendship domain.Friendship
+		var acceptedAt, blockedAt, reminderSentAt sql.NullTime
+
+		err := rows.Scan(
+			&friendship.ID,
+			&friendship.RequesterID,
+			&friendship.AddresseeID,
+			&friendship.Status,
+			&friendship.CreatedAt,
+			&friendship.UpdatedAt,
+			&acceptedAt,
+			&blockedAt,
+			&reminderSentAt,
+		)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan friendship", logger.Error(err))
 			continue
 		}
 
@@ -462,9 +684,83 @@ func (r *FriendshipRepository) GetMutualFriends(ctx context.Context, userID1, us
 		if blockedAt.Valid {
 			friendship.BlockedAt = &blockedAt.Time
 		}
+		if reminderSentAt.Valid {
+			friendship.ReminderSentAt = &reminderSentAt.Time
+		}
 
 		friendships = append(friendships, &friendship)
 	}
 
+	if err = rows.Err(); err != nil {
+		r.logger.WithContext(ctx).Error("Error iterating friendship rows", logger.Error(err))
+		return nil, fmt.Errorf("error iterating friendship rows: %w", err)
+	}
+
 	return friendships, nil
 }
+
+// MarkReminderSent は放置された申請へのリマインダー送信日時を記録する
+func (r *FriendshipRepository) MarkReminderSent(ctx context.Context, friendshipID uuid.UUID, sentAt time.Time) error {
+	query := `UPDATE friendships SET reminder_sent_at = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, sentAt, friendshipID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to mark friend request reminder",
+			logger.Any("friendshipID", friendshipID),
+			logger.Error(err))
+		return fmt.Errorf("failed to mark friend request reminder: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePendingRequest は承認待ちの申請を削除する（承認待ちでない場合は削除せず false を返す）
+func (r *FriendshipRepository) DeletePendingRequest(ctx context.Context, friendshipID uuid.UUID) (bool, error) {
+	query := `DELETE FROM friendships WHERE id = ? AND status = ?`
+
+	result, err := r.db.ExecContext(ctx, query, friendshipID, domain.FriendshipStatusPending)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete pending friend request",
+			logger.Any("friendshipID", friendshipID),
+			logger.Error(err))
+		return false, fmt.Errorf("failed to delete pending friend request: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CountPendingRequestsByAddressee は createdBefore より前に作成された承認待ちの申請の数を申請先のユーザーごとに取得する
+func (r *FriendshipRepository) CountPendingRequestsByAddressee(ctx context.Context, createdBefore time.Time) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT addressee_id, COUNT(*)
+		FROM friendships
+		WHERE status = ? AND created_at < ?
+		GROUP BY addressee_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.FriendshipStatusPending, createdBefore)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count pending requests", logger.Error(err))
+		return nil, fmt.Errorf("failed to count pending requests: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var addresseeID uuid.UUID
+		var count int
+		if err := rows.Scan(&addresseeID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan pending request count: %w", err)
+		}
+		counts[addresseeID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pending request counts: %w", err)
+	}
+	return counts, nil
+}
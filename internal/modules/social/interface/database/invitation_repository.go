@@ -57,6 +57,15 @@ func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation
 		}
 	}
 
+	// 招待コード・URLがない場合（IN_APP招待）はNULLとする（code列は一意制約があるため空文字列を保存しない）
+	var code, inviteURL *string
+	if invitation.Code != "" {
+		code = &invitation.Code
+	}
+	if invitation.URL != "" {
+		inviteURL = &invitation.URL
+	}
+
 	_, err = r.db.ExecContext(ctx, query,
 		invitation.ID,
 		invitation.Type,
@@ -68,8 +77,8 @@ func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation
 		inviteeUsername,
 		inviteePhone,
 		invitation.TargetID,
-		invitation.Code,
-		invitation.URL,
+		code,
+		inviteURL,
 		invitation.Message,
 		metadataJSON,
 		invitation.ExpiresAt,
@@ -78,7 +87,7 @@ func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to create invitation",
+		r.logger.WithContext(ctx).Error("Failed to create invitation",
 			logger.Any("invitation", invitation),
 			logger.Error(err))
 		return fmt.Errorf("failed to create invitation: %w", err)
@@ -91,7 +100,8 @@ func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation
 func (r *InvitationRepository) GetInvitationByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
 	query := `
 		SELECT id, type, method, status, inviter_id, invitee_id, invitee_email, invitee_username, invitee_phone,
-			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at
+			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at,
+			   email_sent_at, email_send_count, bounce_reason
 		FROM invitations
 		WHERE id = ?
 	`
@@ -101,7 +111,7 @@ func (r *InvitationRepository) GetInvitationByID(ctx context.Context, id uuid.UU
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get invitation by ID",
+		r.logger.WithContext(ctx).Error("Failed to get invitation by ID",
 			logger.Any("id", id),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get invitation by ID: %w", err)
@@ -114,7 +124,8 @@ func (r *InvitationRepository) GetInvitationByID(ctx context.Context, id uuid.UU
 func (r *InvitationRepository) GetInvitationByCode(ctx context.Context, code string) (*domain.Invitation, error) {
 	query := `
 		SELECT id, type, method, status, inviter_id, invitee_id, invitee_email, invitee_username, invitee_phone,
-			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at
+			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at,
+			   email_sent_at, email_send_count, bounce_reason
 		FROM invitations
 		WHERE code = ?
 	`
@@ -124,7 +135,7 @@ func (r *InvitationRepository) GetInvitationByCode(ctx context.Context, code str
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get invitation by code",
+		r.logger.WithContext(ctx).Error("Failed to get invitation by code",
 			logger.Any("code", code),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get invitation by code: %w", err)
@@ -137,7 +148,8 @@ func (r *InvitationRepository) GetInvitationByCode(ctx context.Context, code str
 func (r *InvitationRepository) UpdateInvitation(ctx context.Context, invitation *domain.Invitation) error {
 	query := `
 		UPDATE invitations 
-		SET status = ?, invitee_id = ?, updated_at = ?, accepted_at = ?
+		SET status = ?, invitee_id = ?, updated_at = ?, accepted_at = ?,
+			email_sent_at = ?, email_send_count = ?, bounce_reason = ?
 		WHERE id = ?
 	`
 
@@ -146,11 +158,14 @@ func (r *InvitationRepository) UpdateInvitation(ctx context.Context, invitation
 		invitation.InviteeID,
 		invitation.UpdatedAt,
 		invitation.AcceptedAt,
+		invitation.EmailSentAt,
+		invitation.EmailSendCount,
+		invitation.BounceReason,
 		invitation.ID,
 	)
 
 	if err != nil {
-		r.logger.Error("Failed to update invitation",
+		r.logger.WithContext(ctx).Error("Failed to update invitation",
 			logger.Any("invitation", invitation),
 			logger.Error(err))
 		return fmt.Errorf("failed to update invitation: %w", err)
@@ -165,7 +180,7 @@ func (r *InvitationRepository) DeleteInvitation(ctx context.Context, id uuid.UUI
 
 	_, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		r.logger.Error("Failed to delete invitation",
+		r.logger.WithContext(ctx).Error("Failed to delete invitation",
 			logger.Any("id", id),
 			logger.Error(err))
 		return fmt.Errorf("failed to delete invitation: %w", err)
@@ -180,7 +195,8 @@ func (r *InvitationRepository) GetSentInvitations(ctx context.Context, inviterID
 
 	query := `
 		SELECT id, type, method, status, inviter_id, invitee_id, invitee_email, invitee_username, invitee_phone,
-			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at
+			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at,
+			   email_sent_at, email_send_count, bounce_reason
 		FROM invitations
 		WHERE inviter_id = ?
 		ORDER BY created_at DESC
@@ -189,7 +205,7 @@ func (r *InvitationRepository) GetSentInvitations(ctx context.Context, inviterID
 
 	rows, err := r.db.QueryContext(ctx, query, inviterID, pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to get sent invitations",
+		r.logger.WithContext(ctx).Error("Failed to get sent invitations",
 			logger.Any("inviterID", inviterID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get sent invitations: %w", err)
@@ -200,27 +216,56 @@ func (r *InvitationRepository) GetSentInvitations(ctx context.Context, inviterID
 	for rows.Next() {
 		invitation, err := r.scanInvitationFromRows(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan invitation", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan invitation", logger.Error(err))
 			continue
 		}
 		invitations = append(invitations, invitation)
 	}
 
 	if err = rows.Err(); err != nil {
-		r.logger.Error("Error iterating invitation rows", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Error iterating invitation rows", logger.Error(err))
 		return nil, fmt.Errorf("error iterating invitation rows: %w", err)
 	}
 
 	return invitations, nil
 }
 
+// CountInvitationsSince は招待者がsince以降に作成した招待の数を取得する
+func (r *InvitationRepository) CountInvitationsSince(ctx context.Context, inviterID uuid.UUID, since time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM invitations WHERE inviter_id = ? AND created_at >= ?`
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, inviterID, since).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count sent invitations",
+			logger.Any("inviterID", inviterID),
+			logger.Error(err))
+		return 0, fmt.Errorf("failed to count sent invitations: %w", err)
+	}
+	return count, nil
+}
+
+// CountTargetInvitationsSince は対象（グループ）へのsince以降に作成された招待の数を取得する
+func (r *InvitationRepository) CountTargetInvitationsSince(ctx context.Context, targetID uuid.UUID, since time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM invitations WHERE target_id = ? AND created_at >= ?`
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, targetID, since).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count target invitations",
+			logger.Any("targetID", targetID),
+			logger.Error(err))
+		return 0, fmt.Errorf("failed to count target invitations: %w", err)
+	}
+	return count, nil
+}
+
 // GetReceivedInvitations は受信した招待一覧を取得する
 func (r *InvitationRepository) GetReceivedInvitations(ctx context.Context, inviteeID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error) {
 	offset := (pagination.Page - 1) * pagination.PageSize
 
 	query := `
 		SELECT id, type, method, status, inviter_id, invitee_id, invitee_email, invitee_username, invitee_phone,
-			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at
+			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at,
+			   email_sent_at, email_send_count, bounce_reason
 		FROM invitations
 		WHERE invitee_id = ?
 		ORDER BY created_at DESC
@@ -229,7 +274,7 @@ func (r *InvitationRepository) GetReceivedInvitations(ctx context.Context, invit
 
 	rows, err := r.db.QueryContext(ctx, query, inviteeID, pagination.PageSize, offset)
 	if err != nil {
-		r.logger.Error("Failed to get received invitations",
+		r.logger.WithContext(ctx).Error("Failed to get received invitations",
 			logger.Any("inviteeID", inviteeID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to get received invitations: %w", err)
@@ -240,35 +285,76 @@ func (r *InvitationRepository) GetReceivedInvitations(ctx context.Context, invit
 	for rows.Next() {
 		invitation, err := r.scanInvitationFromRows(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan invitation", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan invitation", logger.Error(err))
 			continue
 		}
 		invitations = append(invitations, invitation)
 	}
 
 	if err = rows.Err(); err != nil {
-		r.logger.Error("Error iterating invitation rows", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Error iterating invitation rows", logger.Error(err))
 		return nil, fmt.Errorf("error iterating invitation rows: %w", err)
 	}
 
 	return invitations, nil
 }
 
-// MarkExpiredInvitations は期限切れ招待をマークする
-func (r *InvitationRepository) MarkExpiredInvitations(ctx context.Context) error {
+// GetPendingInvitationsByEmail はメールアドレス宛ての有効な招待一覧を取得する
+func (r *InvitationRepository) GetPendingInvitationsByEmail(ctx context.Context, email string) ([]*domain.Invitation, error) {
+	query := `
+		SELECT id, type, method, status, inviter_id, invitee_id, invitee_email, invitee_username, invitee_phone,
+			   target_id, code, url, message, metadata, expires_at, created_at, updated_at, accepted_at,
+			   email_sent_at, email_send_count, bounce_reason
+		FROM invitations
+		WHERE invitee_email = ? AND invitee_id IS NULL AND status = ? AND expires_at > ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, email, domain.InvitationStatusPending, time.Now())
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get invitations by email", logger.Error(err))
+		return nil, fmt.Errorf("failed to get invitations by email: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*domain.Invitation
+	for rows.Next() {
+		invitation, err := r.scanInvitationFromRows(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan invitation", logger.Error(err))
+			continue
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithContext(ctx).Error("Error iterating invitation rows", logger.Error(err))
+		return nil, fmt.Errorf("error iterating invitation rows: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// MarkExpiredInvitations は期限切れ招待をマークし、更新件数を返す
+func (r *InvitationRepository) MarkExpiredInvitations(ctx context.Context) (int64, error) {
 	query := `
 		UPDATE invitations 
 		SET status = ? 
 		WHERE status = ? AND expires_at < NOW()
 	`
 
-	_, err := r.db.ExecContext(ctx, query, domain.InvitationStatusExpired, domain.InvitationStatusPending)
+	result, err := r.db.ExecContext(ctx, query, domain.InvitationStatusExpired, domain.InvitationStatusPending)
 	if err != nil {
-		r.logger.Error("Failed to mark expired invitations", logger.Error(err))
-		return fmt.Errorf("failed to mark expired invitations: %w", err)
+		r.logger.WithContext(ctx).Error("Failed to mark expired invitations", logger.Error(err))
+		return 0, fmt.Errorf("failed to mark expired invitations: %w", err)
 	}
 
-	return nil
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return count, nil
 }
 
 // DeleteExpiredInvitations は期限切れ招待を削除する
@@ -280,13 +366,45 @@ func (r *InvitationRepository) DeleteExpiredInvitations(ctx context.Context, bef
 
 	_, err := r.db.ExecContext(ctx, query, domain.InvitationStatusExpired, beforeDate)
 	if err != nil {
-		r.logger.Error("Failed to delete expired invitations", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to delete expired invitations", logger.Error(err))
 		return fmt.Errorf("failed to delete expired invitations: %w", err)
 	}
 
 	return nil
 }
 
+// CountPendingGroupInvitationsByInvitee は createdBefore より前に作成され、now の時点で有効な登録済みユーザー宛てのグループ招待の数を被招待者ごとに取得する
+func (r *InvitationRepository) CountPendingGroupInvitationsByInvitee(ctx context.Context, createdBefore, now time.Time) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT invitee_id, COUNT(*)
+		FROM invitations
+		WHERE type = ? AND status = ? AND invitee_id IS NOT NULL AND created_at < ? AND expires_at > ?
+		GROUP BY invitee_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		domain.InvitationTypeGroup, domain.InvitationStatusPending, createdBefore, now)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count pending group invitations", logger.Error(err))
+		return nil, fmt.Errorf("failed to count pending group invitations: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var inviteeID uuid.UUID
+		var count int
+		if err := rows.Scan(&inviteeID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan pending group invitation count: %w", err)
+		}
+		counts[inviteeID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pending group invitation counts: %w", err)
+	}
+	return counts, nil
+}
+
 // IsValidInvitation は招待コードの妥当性を確認する
 func (r *InvitationRepository) IsValidInvitation(ctx context.Context, code string) (bool, error) {
 	query := `
@@ -297,7 +415,7 @@ func (r *InvitationRepository) IsValidInvitation(ctx context.Context, code strin
 	var count int
 	err := r.db.QueryRowContext(ctx, query, code, domain.InvitationStatusPending).Scan(&count)
 	if err != nil {
-		r.logger.Error("Failed to validate invitation code",
+		r.logger.WithContext(ctx).Error("Failed to validate invitation code",
 			logger.Any("code", code),
 			logger.Error(err))
 		return false, fmt.Errorf("failed to validate invitation code: %w", err)
@@ -311,7 +429,9 @@ func (r *InvitationRepository) scanInvitation(row *sql.Row) (*domain.Invitation,
 	var invitation domain.Invitation
 	var inviteeEmail, inviteeUsername, inviteePhone sql.NullString
 	var metadataJSON sql.NullString
-	var acceptedAt sql.NullTime
+	var acceptedAt, emailSentAt sql.NullTime
+	var bounceReason sql.NullString
+	var code, inviteURL sql.NullString
 
 	err := row.Scan(
 		&invitation.ID,
@@ -324,20 +444,26 @@ func (r *InvitationRepository) scanInvitation(row *sql.Row) (*domain.Invitation,
 		&inviteeUsername,
 		&inviteePhone,
 		&invitation.TargetID,
-		&invitation.Code,
-		&invitation.URL,
+		&code,
+		&inviteURL,
 		&invitation.Message,
 		&metadataJSON,
 		&invitation.ExpiresAt,
 		&invitation.CreatedAt,
 		&invitation.UpdatedAt,
 		&acceptedAt,
+		&emailSentAt,
+		&invitation.EmailSendCount,
+		&bounceReason,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	invitation.Code = code.String
+	invitation.URL = inviteURL.String
+
 	// InviteeInfoの構築
 	if inviteeEmail.Valid || inviteeUsername.Valid || inviteePhone.Valid {
 		invitation.InviteeInfo = &domain.InviteeInfo{
@@ -360,6 +486,12 @@ func (r *InvitationRepository) scanInvitation(row *sql.Row) (*domain.Invitation,
 		invitation.AcceptedAt = &acceptedAt.Time
 	}
 
+	// 招待メールの送信状況
+	if emailSentAt.Valid {
+		invitation.EmailSentAt = &emailSentAt.Time
+	}
+	invitation.BounceReason = bounceReason.String
+
 	return &invitation, nil
 }
 
@@ -368,7 +500,9 @@ func (r *InvitationRepository) scanInvitationFromRows(rows *sql.Rows) (*domain.I
 	var invitation domain.Invitation
 	var inviteeEmail, inviteeUsername, inviteePhone sql.NullString
 	var metadataJSON sql.NullString
-	var acceptedAt sql.NullTime
+	var acceptedAt, emailSentAt sql.NullTime
+	var bounceReason sql.NullString
+	var code, inviteURL sql.NullString
 
 	err := rows.Scan(
 		&invitation.ID,
@@ -381,20 +515,26 @@ func (r *InvitationRepository) scanInvitationFromRows(rows *sql.Rows) (*domain.I
 		&inviteeUsername,
 		&inviteePhone,
 		&invitation.TargetID,
-		&invitation.Code,
-		&invitation.URL,
+		&code,
+		&inviteURL,
 		&invitation.Message,
 		&metadataJSON,
 		&invitation.ExpiresAt,
 		&invitation.CreatedAt,
 		&invitation.UpdatedAt,
 		&acceptedAt,
+		&emailSentAt,
+		&invitation.EmailSendCount,
+		&bounceReason,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	invitation.Code = code.String
+	invitation.URL = inviteURL.String
+
 	// InviteeInfoの構築
 	if inviteeEmail.Valid || inviteeUsername.Valid || inviteePhone.Valid {
 		invitation.InviteeInfo = &domain.InviteeInfo{
@@ -417,5 +557,11 @@ func (r *InvitationRepository) scanInvitationFromRows(rows *sql.Rows) (*domain.I
 		invitation.AcceptedAt = &acceptedAt.Time
 	}
 
+	// 招待メールの送信状況
+	if emailSentAt.Valid {
+		invitation.EmailSentAt = &emailSentAt.Time
+	}
+	invitation.BounceReason = bounceReason.String
+
 	return &invitation, nil
 }
\ No newline at end of file
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+type ProfileRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewProfileRepository(db *sql.DB, logger logger.Logger) usecase.ProfileRepository {
+	return &ProfileRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetProfileSettings は公開プロフィールの設定を取得する（保存していない場合は nil, nil）
+func (r *ProfileRepository) GetProfileSettings(ctx context.Context, userID uuid.UUID) (*domain.ProfileSettings, error) {
+	query := `
+		SELECT user_id, display_name, avatar_url, bio, visibility, show_stats, updated_at
+		FROM profile_settings
+		WHERE user_id = ?
+	`
+
+	var settings domain.ProfileSettings
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&settings.UserID,
+		&settings.DisplayName,
+		&settings.AvatarURL,
+		&settings.Bio,
+		&settings.Visibility,
+		&settings.ShowStats,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.WithContext(ctx).Error("Failed to get profile settings",
+			logger.Any("userID", userID),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to get profile settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// SaveProfileSettings は公開プロフィールの設定を保存する（既存の設定は上書きする）
+func (r *ProfileRepository) SaveProfileSettings(ctx context.Context, settings *domain.ProfileSettings) error {
+	query := `
+		INSERT INTO profile_settings (user_id, display_name, avatar_url, bio, visibility, show_stats, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			display_name = VALUES(display_name),
+			avatar_url = VALUES(avatar_url),
+			bio = VALUES(bio),
+			visibility = VALUES(visibility),
+			show_stats = VALUES(show_stats),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		settings.UserID,
+		settings.DisplayName,
+		settings.AvatarURL,
+		settings.Bio,
+		settings.Visibility,
+		settings.ShowStats,
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save profile settings",
+			logger.Any("userID", settings.UserID),
+			logger.Error(err))
+		return fmt.Errorf("failed to save profile settings: %w", err)
+	}
+
+	return nil
+}
@@ -37,7 +37,8 @@ type CreateInvitationRequest struct {
 	Message      string  `json:"message" binding:"max=500"`
 	ExpiresHours int     `json:"expires_hours" binding:"min=1,max=168"` // 1-168時間（1週間）
 	InviteeEmail *string `json:"invitee_email,omitempty" binding:"omitempty,email"`
-	TargetID     *string `json:"target_id,omitempty"` // Group IDなど
+	TargetID     *string `json:"target_id,omitempty"`                                         // Group IDなど
+	GroupRole    string  `json:"group_role,omitempty" binding:"omitempty,oneof=MEMBER GUEST"` // グループ招待で参加するときのロール
 }
 
 type AcceptInvitationRequest struct {
@@ -88,6 +89,15 @@ type InvitationResponse struct {
 	CreatedAt   time.Time           `json:"created_at"`
 	UpdatedAt   time.Time           `json:"updated_at"`
 	AcceptedAt  *time.Time          `json:"accepted_at,omitempty"`
+
+	EmailSentAt    *time.Time `json:"email_sent_at,omitempty"`
+	EmailSendCount int        `json:"email_send_count"`
+	BounceReason   string     `json:"bounce_reason,omitempty"`
+
+	QRCodeURL string `json:"qr_code_url,omitempty"`
+
+	DeepLink      string `json:"deep_link,omitempty"`
+	UniversalLink string `json:"universal_link,omitempty"`
 }
 
 type InvitationResultResponse struct {
@@ -119,15 +129,32 @@ type SentRequestsResponse struct {
 	Pagination PaginationInfo                   `json:"pagination"`
 }
 
+type BlockedUsersResponse struct {
+	Blocks     []FriendWithUserInfoResponse `json:"blocks"`
+	Pagination PaginationInfo               `json:"pagination"`
+}
+
 type InvitationsListResponse struct {
 	Invitations []InvitationResponse `json:"invitations"`
 	Pagination  PaginationInfo       `json:"pagination"`
 }
 
+type PresenceResponse struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+type FriendsPresenceResponse struct {
+	Presences []PresenceResponse `json:"presences"`
+}
+
 type InviteURLResponse struct {
-	URL       string    `json:"url"`
-	Code      string    `json:"code"`
-	ExpiresAt time.Time `json:"expires_at"`
+	URL           string    `json:"url"`
+	DeepLink      string    `json:"deep_link,omitempty"`
+	UniversalLink string    `json:"universal_link,omitempty"`
+	Code          string    `json:"code"`
+	ExpiresAt     time.Time `json:"expires_at"`
 }
 
 type PaginationInfo struct {
@@ -219,7 +246,31 @@ func ToInvitationResponse(invitation *domain.Invitation) *InvitationResponse {
 		CreatedAt:   invitation.CreatedAt,
 		UpdatedAt:   invitation.UpdatedAt,
 		AcceptedAt:  invitation.AcceptedAt,
+
+		EmailSentAt:    invitation.EmailSentAt,
+		EmailSendCount: invitation.EmailSendCount,
+		BounceReason:   invitation.BounceReason,
+
+		QRCodeURL: InvitationQRCodePath(invitation),
+	}
+}
+
+// WithLinks は招待のWeb URLとモバイルアプリ用のリンクをレスポンスに設定する
+func (r *InvitationResponse) WithLinks(links *domain.InviteLinks) *InvitationResponse {
+	if links != nil {
+		r.URL = links.URL
+		r.DeepLink = links.DeepLink
+		r.UniversalLink = links.UniversalLink
 	}
+	return r
+}
+
+// InvitationQRCodePath は招待QRコード画像のパスを返す（招待コードがない場合は空文字）
+func InvitationQRCodePath(invitation *domain.Invitation) string {
+	if invitation.Code == "" {
+		return ""
+	}
+	return "/api/v1/social/invitations/" + invitation.ID.String() + "/qr"
 }
 
 func ToInvitationResultResponse(result *socialUsecase.InvitationResult) *InvitationResultResponse {
@@ -292,6 +343,40 @@ func ToPendingRequestsResponse(requests []*socialUsecase.FriendshipWithUserInfo,
 	}
 }
 
+func ToBlockedUsersResponse(blocked []*socialUsecase.FriendWithUserInfo, total, page, pageSize int) *BlockedUsersResponse {
+	blockResponses := make([]FriendWithUserInfoResponse, len(blocked))
+	for i, block := range blocked {
+		blockResponses[i] = *ToFriendWithUserInfoResponse(block)
+	}
+
+	totalPages := total / pageSize
+	if total%pageSize > 0 {
+		totalPages++
+	}
+
+	return &BlockedUsersResponse{
+		Blocks: blockResponses,
+		Pagination: PaginationInfo{
+			Page:       page,
+			PageSize:   pageSize,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+func ToFriendsPresenceResponse(presences []*domain.Presence) *FriendsPresenceResponse {
+	presenceResponses := make([]PresenceResponse, len(presences))
+	for i, presence := range presences {
+		presenceResponses[i] = PresenceResponse{
+			UserID:     presence.UserID,
+			Status:     string(presence.Status),
+			LastSeenAt: presence.LastSeenAt,
+		}
+	}
+	return &FriendsPresenceResponse{Presences: presenceResponses}
+}
+
 func ToInvitationsListResponse(invitations []*domain.Invitation, total, page, pageSize int) *InvitationsListResponse {
 	invitationResponses := make([]InvitationResponse, len(invitations))
 	for i, invitation := range invitations {
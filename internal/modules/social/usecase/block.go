@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+var (
+	ErrUserBlocked   = errors.New("user is blocked")
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+// GetBlockedUsers は自分がブロックしたユーザー一覧を取得する
+func (s *SocialServiceImpl) GetBlockedUsers(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*FriendWithUserInfo, error) {
+	friendships, err := s.friendshipRepo.GetBlockedUsers(ctx, userID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+
+	if len(friendships) == 0 {
+		return []*FriendWithUserInfo{}, nil
+	}
+
+	// ブロックしたユーザーの情報を一括取得
+	userIDs := make([]string, len(friendships))
+	for i, friendship := range friendships {
+		userIDs[i] = friendship.AddresseeID.String()
+	}
+
+	userInfoMap, err := s.userValidator.GetUsersInfoBatch(ctx, userIDs)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
+		userInfoMap = make(map[string]*commonDomain.UserInfo)
+	}
+
+	result := make([]*FriendWithUserInfo, len(friendships))
+	for i, friendship := range friendships {
+		result[i] = &FriendWithUserInfo{
+			Friendship: friendship,
+			UserInfo:   userInfoMap[friendship.AddresseeID.String()],
+		}
+	}
+
+	return result, nil
+}
+
+// blockChecker は友達関係リポジトリを使ったBlockCheckerの実装
+type blockChecker struct {
+	friendshipRepo FriendshipRepository
+}
+
+// NewBlockChecker は他モジュールから利用するBlockCheckerを作成する
+func NewBlockChecker(friendshipRepo FriendshipRepository) commonDomain.BlockChecker {
+	return &blockChecker{friendshipRepo: friendshipRepo}
+}
+
+// IsBlocked はどちらか一方がもう一方をブロックしているかチェック
+func (c *blockChecker) IsBlocked(ctx context.Context, userID1, userID2 string) (bool, error) {
+	id1, err := uuid.Parse(userID1)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+	id2, err := uuid.Parse(userID2)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return c.friendshipRepo.IsBlocked(ctx, id1, id2)
+}
+
+// GetBlockedUserIDs は指定ユーザーとブロック関係にあるユーザーIDを取得する
+func (c *blockChecker) GetBlockedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	ids, err := c.friendshipRepo.GetBlockRelatedUserIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(ids))
+	for i, blockedID := range ids {
+		result[i] = blockedID.String()
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// CleanupPolicy は放置された友達申請のクリーンアップ設定
+type CleanupPolicy struct {
+	// 承認待ちの申請を削除するまでの期間（0以下の場合は削除しない）
+	FriendRequestTTL time.Duration
+	// 削除の何日前にリマインダーを送るか
+	ReminderLead time.Duration
+	// 1回の実行で処理する申請の上限
+	BatchSize int
+}
+
+// DefaultCleanupPolicy はデフォルトのクリーンアップ設定を返す
+func DefaultCleanupPolicy() CleanupPolicy {
+	return CleanupPolicy{
+		FriendRequestTTL: 30 * 24 * time.Hour,
+		ReminderLead:     7 * 24 * time.Hour,
+		BatchSize:        500,
+	}
+}
+
+// CleanupResult はクリーンアップの実行結果
+type CleanupResult struct {
+	ExpiredInvitations int64
+	RemindedRequests   int
+	DeletedRequests    int
+}
+
+// CleanupService は期限切れ招待と放置された友達申請を整理する
+type CleanupService struct {
+	friendshipRepo FriendshipRepository
+	invitationRepo InvitationRepository
+	eventPublisher SocialEventPublisher
+	syncChanges    commonDomain.ChangeRecorder // nilの場合は変更フィードに記録しない
+	policy         CleanupPolicy
+	logger         *logger.Logger
+}
+
+// NewCleanupService は新しいCleanupServiceを作成する
+func NewCleanupService(
+	friendshipRepo FriendshipRepository,
+	invitationRepo InvitationRepository,
+	eventPublisher SocialEventPublisher,
+	policy CleanupPolicy,
+	logger *logger.Logger,
+) *CleanupService {
+	if policy.ReminderLead < 0 || policy.ReminderLead > policy.FriendRequestTTL {
+		policy.ReminderLead = 0
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = DefaultCleanupPolicy().BatchSize
+	}
+	return &CleanupService{
+		friendshipRepo: friendshipRepo,
+		invitationRepo: invitationRepo,
+		eventPublisher: eventPublisher,
+		policy:         policy,
+		logger:         logger,
+	}
+}
+
+// SetChangeRecorder は削除した友達申請を記録するオフライン同期用の変更フィードを設定する
+func (s *CleanupService) SetChangeRecorder(recorder commonDomain.ChangeRecorder) {
+	s.syncChanges = recorder
+}
+
+// Run は期限切れ招待をEXPIREDにし、放置された友達申請にリマインダーを送信・削除する
+// 一部の申請の処理に失敗しても残りの処理は継続する
+func (s *CleanupService) Run(ctx context.Context, now time.Time) (*CleanupResult, error) {
+	result := &CleanupResult{}
+
+	expired, err := s.invitationRepo.MarkExpiredInvitations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark expired invitations: %w", err)
+	}
+	result.ExpiredInvitations = expired
+
+	if s.policy.FriendRequestTTL <= 0 {
+		return result, nil
+	}
+
+	// リマインダー対象（削除予定日のReminderLead前）以降の申請を取得
+	createdBefore := now.Add(-(s.policy.FriendRequestTTL - s.policy.ReminderLead))
+	requests, err := s.friendshipRepo.GetStalePendingRequests(ctx, createdBefore, s.policy.BatchSize)
+	if err != nil {
+		return result, fmt.Errorf("failed to get stale friend requests: %w", err)
+	}
+
+	for _, request := range requests {
+		switch request.PendingCleanupAction(now, s.policy.FriendRequestTTL, s.policy.ReminderLead) {
+		case domain.PendingCleanupRemind:
+			deleteAt := now.Add(s.policy.ReminderLead)
+			if err := s.eventPublisher.PublishFriendRequestReminder(ctx, request, deleteAt); err != nil {
+				s.logger.WithContext(ctx).Error("Failed to publish friend request reminder",
+					logger.Any("friendshipID", request.ID),
+					logger.Error(err))
+				continue
+			}
+			request.MarkReminderSent(now)
+			if err := s.friendshipRepo.MarkReminderSent(ctx, request.ID, now); err != nil {
+				s.logger.WithContext(ctx).Error("Failed to record friend request reminder",
+					logger.Any("friendshipID", request.ID),
+					logger.Error(err))
+				continue
+			}
+			result.RemindedRequests++
+
+		case domain.PendingCleanupDelete:
+			// 取得後に承認された申請は削除しない
+			deleted, err := s.friendshipRepo.DeletePendingRequest(ctx, request.ID)
+			if err != nil {
+				s.logger.WithContext(ctx).Error("Failed to delete stale friend request",
+					logger.Any("friendshipID", request.ID),
+					logger.Error(err))
+				continue
+			}
+			if deleted {
+				result.DeletedRequests++
+				syncFriendshipDeleted(ctx, s.syncChanges, request.RequesterID, request.AddresseeID)
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DefaultDigestMinPendingAge はリマインダーに含める申請・招待の経過時間の既定値
+// 届いた直後の申請・招待は個別の通知で知らせているため、しばらく対応していないものだけを含める
+const DefaultDigestMinPendingAge = 24 * time.Hour
+
+// DigestStateRepository はリマインダーの送信の記録のリポジトリインターフェース
+type DigestStateRepository interface {
+	// ListDigestStates は全ユーザーの記録を取得する
+	ListDigestStates(ctx context.Context) ([]*domain.DigestState, error)
+	// SaveDigestState は記録を保存する（既存の記録は上書きする）
+	SaveDigestState(ctx context.Context, state *domain.DigestState) error
+	// DeleteDigestState は記録を削除する（存在しない場合は何もしない）
+	DeleteDigestState(ctx context.Context, userID uuid.UUID) error
+}
+
+// DigestPreferenceProvider はユーザーの通知の設定からリマインダーの頻度を取得するインターフェース
+type DigestPreferenceProvider interface {
+	// DigestInterval は次のリマインダーを送るまでの最短の間隔を返す（送らない設定の場合は0）
+	DigestInterval(ctx context.Context, userID uuid.UUID) (time.Duration, error)
+}
+
+// DigestNotifier はリマインダーの通知を送るインターフェース
+type DigestNotifier interface {
+	// SendPendingDigest はリマインダーを送り、通知のIDを返す
+	SendPendingDigest(ctx context.Context, digest domain.PendingDigest) (string, error)
+	// DismissPendingDigest は送ったリマインダーを既読にする（対応済みの申請・招待を知らせ続けない）
+	DismissPendingDigest(ctx context.Context, userID uuid.UUID, notificationID string) error
+}
+
+// DigestResult はリマインダーの実行結果
+type DigestResult struct {
+	Sent      int
+	Dismissed int
+	Failed    int
+}
+
+// DigestService は対応していない友達申請・グループ招待をまとめて知らせるリマインダーを送るサービス
+type DigestService struct {
+	friendshipRepo FriendshipRepository
+	invitationRepo InvitationRepository
+	stateRepo      DigestStateRepository
+	preferences    DigestPreferenceProvider
+	notifier       DigestNotifier
+	minPendingAge  time.Duration
+	logger         *logger.Logger
+}
+
+// NewDigestService は新しいDigestServiceを作成する
+func NewDigestService(
+	friendshipRepo FriendshipRepository,
+	invitationRepo InvitationRepository,
+	stateRepo DigestStateRepository,
+	preferences DigestPreferenceProvider,
+	notifier DigestNotifier,
+	minPendingAge time.Duration,
+	logger *logger.Logger,
+) *DigestService {
+	if minPendingAge < 0 {
+		minPendingAge = DefaultDigestMinPendingAge
+	}
+	return &DigestService{
+		friendshipRepo: friendshipRepo,
+		invitationRepo: invitationRepo,
+		stateRepo:      stateRepo,
+		preferences:    preferences,
+		notifier:       notifier,
+		minPendingAge:  minPendingAge,
+		logger:         logger,
+	}
+}
+
+// Run は対応していない申請・招待があるユーザーに設定の頻度でリマインダーを送る
+// すべて対応したユーザーには送ったリマインダーを既読にする。一部のユーザーの処理に失敗しても残りの処理は継続する
+func (s *DigestService) Run(ctx context.Context, now time.Time) (*DigestResult, error) {
+	createdBefore := now.Add(-s.minPendingAge)
+
+	requests, err := s.friendshipRepo.CountPendingRequestsByAddressee(ctx, createdBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending friend requests: %w", err)
+	}
+	invitations, err := s.invitationRepo.CountPendingGroupInvitationsByInvitee(ctx, createdBefore, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending group invitations: %w", err)
+	}
+	states, err := s.stateRepo.ListDigestStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest states: %w", err)
+	}
+
+	digests := make(map[uuid.UUID]*domain.PendingDigest)
+	digestFor := func(userID uuid.UUID) *domain.PendingDigest {
+		if digest, ok := digests[userID]; ok {
+			return digest
+		}
+		digest := &domain.PendingDigest{UserID: userID}
+		digests[userID] = digest
+		return digest
+	}
+	for userID, count := range requests {
+		digestFor(userID).FriendRequests = count
+	}
+	for userID, count := range invitations {
+		digestFor(userID).GroupInvitations = count
+	}
+
+	result := &DigestResult{}
+	stateByUser := make(map[uuid.UUID]*domain.DigestState, len(states))
+	for _, state := range states {
+		stateByUser[state.UserID] = state
+		if _, ok := digests[state.UserID]; ok {
+			continue
+		}
+		// すべて対応したユーザーのリマインダーは残さない
+		if err := s.dismiss(ctx, state); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to dismiss pending digest",
+				logger.Any("userID", state.UserID),
+				logger.Error(err))
+			result.Failed++
+			continue
+		}
+		result.Dismissed++
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(digests))
+	for userID := range digests {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i].String() < userIDs[j].String() })
+
+	for _, userID := range userIDs {
+		sent, err := s.sendIfDue(ctx, *digests[userID], stateByUser[userID], now)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("Failed to send pending digest",
+				logger.Any("userID", userID),
+				logger.Error(err))
+			result.Failed++
+			continue
+		}
+		if sent {
+			result.Sent++
+		}
+	}
+	return result, nil
+}
+
+// sendIfDue は設定の頻度の間隔が過ぎていればリマインダーを送る
+// 前回のリマインダーは新しいリマインダーに置き換えるため既読にする
+func (s *DigestService) sendIfDue(ctx context.Context, digest domain.PendingDigest, state *domain.DigestState, now time.Time) (bool, error) {
+	interval, err := s.preferences.DigestInterval(ctx, digest.UserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get digest preference: %w", err)
+	}
+	if !state.IsDue(now, interval) {
+		return false, nil
+	}
+
+	notificationID, err := s.notifier.SendPendingDigest(ctx, digest)
+	if err != nil {
+		return false, fmt.Errorf("failed to send pending digest: %w", err)
+	}
+	if state != nil && state.NotificationID != "" {
+		if err := s.notifier.DismissPendingDigest(ctx, digest.UserID, state.NotificationID); err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to dismiss previous pending digest",
+				logger.Any("userID", digest.UserID),
+				logger.Error(err))
+		}
+	}
+
+	if err := s.stateRepo.SaveDigestState(ctx, &domain.DigestState{
+		UserID:         digest.UserID,
+		NotificationID: notificationID,
+		LastSentAt:     now,
+	}); err != nil {
+		return false, fmt.Errorf("failed to save digest state: %w", err)
+	}
+	return true, nil
+}
+
+// dismiss は送ったリマインダーを既読にし、記録を削除する
+func (s *DigestService) dismiss(ctx context.Context, state *domain.DigestState) error {
+	if state.NotificationID != "" {
+		if err := s.notifier.DismissPendingDigest(ctx, state.UserID, state.NotificationID); err != nil {
+			return fmt.Errorf("failed to dismiss pending digest: %w", err)
+		}
+	}
+	if err := s.stateRepo.DeleteDigestState(ctx, state.UserID); err != nil {
+		return fmt.Errorf("failed to delete digest state: %w", err)
+	}
+	return nil
+}
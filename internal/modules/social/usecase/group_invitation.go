@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ErrGroupInvitationNotAllowed はグループへの招待権限がないことを表すエラー
+var ErrGroupInvitationNotAllowed = errors.New("not allowed to invite to this group")
+
+// GroupMembershipGateway はグループモジュールとの連携インターフェース
+type GroupMembershipGateway interface {
+	// 招待者がグループにメンバーを招待できるか
+	CanInviteToGroup(ctx context.Context, groupID, inviterID uuid.UUID) (bool, error)
+
+	// 招待を受けたユーザーを招待で指定したロールでグループのメンバーに追加する
+	JoinGroupByInvitation(ctx context.Context, groupID, userID, inviterID uuid.UUID, role string) error
+}
+
+// SetGroupMembershipGateway はグループ招待でメンバー追加を行うゲートウェイを設定する
+// （グループサービスとの相互参照になるため、作成後に設定する）
+func (s *SocialServiceImpl) SetGroupMembershipGateway(gateway GroupMembershipGateway) {
+	s.groupGateway = gateway
+}
+
+// ClaimEmailInvitations は新規登録したユーザーのメールアドレス宛ての招待をユーザーに紐付ける
+// グループ招待はそのままグループに参加させ、友達招待は受信した招待として受諾できるようにする
+func (s *SocialServiceImpl) ClaimEmailInvitations(ctx context.Context, userID uuid.UUID, email string) ([]*domain.Invitation, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, nil
+	}
+
+	invitations, err := s.invitationRepo.GetPendingInvitationsByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations by email: %w", err)
+	}
+
+	var claimed []*domain.Invitation
+	for _, invitation := range invitations {
+		if !invitation.IsValid() || invitation.InviteeID != nil {
+			continue
+		}
+
+		invitation.SetInvitee(userID)
+		joined := false
+		if invitation.Type == domain.InvitationTypeGroup && s.groupGateway != nil && invitation.TargetID != nil {
+			if err := s.groupGateway.JoinGroupByInvitation(ctx, *invitation.TargetID, userID, invitation.InviterID, invitation.GroupRole()); err != nil {
+				// 参加できなかった招待は紐付けだけ行い、受信した招待から改めて受諾できるようにする
+				s.logger.WithContext(ctx).Warn("Failed to join group from email invitation",
+					logger.Any("invitationID", invitation.ID),
+					logger.Any("groupID", invitation.TargetID),
+					logger.Error(err))
+			} else if err := invitation.Accept(); err == nil {
+				joined = true
+			}
+		}
+
+		if err := s.invitationRepo.UpdateInvitation(ctx, invitation); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to link invitation to registered user",
+				logger.Any("invitationID", invitation.ID),
+				logger.Error(err))
+			continue
+		}
+
+		if joined {
+			if err := s.eventPublisher.PublishInvitationAccepted(ctx, invitation); err != nil {
+				s.logger.WithContext(ctx).Error("Failed to publish invitation accepted event", logger.Error(err))
+			}
+		}
+		claimed = append(claimed, invitation)
+	}
+
+	if len(claimed) > 0 {
+		s.logger.WithContext(ctx).Info("Email invitations linked to registered user",
+			logger.Any("userID", userID),
+			logger.Int("count", len(claimed)))
+	}
+	return claimed, nil
+}
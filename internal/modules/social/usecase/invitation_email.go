@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DefaultInvitationEmailCooldown は招待メールを再送できるまでの待ち時間
+const DefaultInvitationEmailCooldown = 10 * time.Minute
+
+var (
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrNotInvitationOwner     = errors.New("not authorized to manage this invitation")
+	ErrEmailGatewayNotEnabled = errors.New("invitation email is not enabled")
+	ErrEmailResendCooldown    = errors.New("invitation email was sent recently")
+)
+
+// EmailCooldownError は再送の待ち時間中であることを表すエラー
+type EmailCooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *EmailCooldownError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrEmailResendCooldown, e.RetryAfter.Round(time.Second))
+}
+
+func (e *EmailCooldownError) Unwrap() error {
+	return ErrEmailResendCooldown
+}
+
+// InvitationEmailGateway は招待メール送信のインターフェース
+type InvitationEmailGateway interface {
+	SendInvitationEmail(ctx context.Context, email domain.InvitationEmail) error
+}
+
+// NewSocialServiceImplWithEmail は招待メール送信を有効にしたSocialServiceImplを作成する
+func NewSocialServiceImplWithEmail(
+	friendshipRepo FriendshipRepository,
+	invitationRepo InvitationRepository,
+	userValidator commonDomain.UserValidator,
+	eventPublisher SocialEventPublisher,
+	urlGateway URLGateway,
+	emailGateway InvitationEmailGateway,
+	emailCooldown time.Duration,
+	logger *logger.Logger,
+) SocialService {
+	service := NewSocialServiceImpl(friendshipRepo, invitationRepo, userValidator, eventPublisher, urlGateway, logger).(*SocialServiceImpl)
+	service.emailGateway = emailGateway
+	service.emailCooldown = emailCooldown
+	return service
+}
+
+// ResendInvitationEmail は招待メールを再送する（招待者のみ、待ち時間あり）
+func (s *SocialServiceImpl) ResendInvitationEmail(ctx context.Context, invitationID, inviterID uuid.UUID) (*domain.Invitation, error) {
+	if s.emailGateway == nil {
+		return nil, ErrEmailGatewayNotEnabled
+	}
+
+	invitation, err := s.invitationRepo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation == nil {
+		return nil, ErrInvitationNotFound
+	}
+	if invitation.InviterID != inviterID {
+		return nil, ErrNotInvitationOwner
+	}
+	if err := invitation.CanSendEmail(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if next := invitation.NextEmailAllowedAt(s.emailCooldown); now.Before(next) {
+		return nil, &EmailCooldownError{RetryAfter: next.Sub(now)}
+	}
+
+	if err := s.sendInvitationEmail(ctx, invitation, now); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Invitation email resent",
+		logger.Any("invitationID", invitation.ID),
+		logger.Any("sendCount", invitation.EmailSendCount))
+
+	return invitation, nil
+}
+
+// HandleInvitationEmailBounce はメール配信サービスからのバウンス通知で招待を配信不能にする
+// 宛先が一致しない場合は存在しない招待として扱う
+func (s *SocialServiceImpl) HandleInvitationEmailBounce(ctx context.Context, invitationID uuid.UUID, email, reason string) error {
+	invitation, err := s.invitationRepo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation == nil || !strings.EqualFold(invitation.InviteeEmail(), strings.TrimSpace(email)) {
+		return ErrInvitationNotFound
+	}
+
+	// 既に処理済みの招待は変更しない（バウンス通知の重複を許容する）
+	if invitation.Status != domain.InvitationStatusPending {
+		s.logger.WithContext(ctx).Info("Ignoring bounce for non-pending invitation",
+			logger.Any("invitationID", invitation.ID),
+			logger.Any("status", invitation.Status))
+		return nil
+	}
+
+	if err := invitation.MarkUndeliverable(reason); err != nil {
+		return err
+	}
+	if err := s.invitationRepo.UpdateInvitation(ctx, invitation); err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Warn("Invitation marked as undeliverable",
+		logger.Any("invitationID", invitation.ID),
+		logger.Any("reason", invitation.BounceReason))
+
+	return nil
+}
+
+// sendInvitationEmail は招待メールを送信し、送信記録を保存する
+func (s *SocialServiceImpl) sendInvitationEmail(ctx context.Context, invitation *domain.Invitation, now time.Time) error {
+	inviteURL, err := s.urlGateway.GenerateInviteURL(ctx, invitation.ID, invitation.Code)
+	if err != nil {
+		return fmt.Errorf("failed to generate invite URL: %w", err)
+	}
+
+	inviterName := "Yotei+ユーザー"
+	if info, err := s.userValidator.GetUserInfo(ctx, invitation.InviterID.String()); err == nil && info != nil && info.Username != "" {
+		inviterName = info.Username
+	}
+
+	if err := s.emailGateway.SendInvitationEmail(ctx, domain.InvitationEmail{
+		InvitationID:   invitation.ID,
+		To:             invitation.InviteeEmail(),
+		InviterName:    inviterName,
+		InvitationType: invitation.Type,
+		Message:        invitation.Message,
+		InviteURL:      inviteURL,
+		Code:           invitation.Code,
+		ExpiresAt:      invitation.ExpiresAt,
+	}); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to send invitation email",
+			logger.Any("invitationID", invitation.ID),
+			logger.Error(err))
+		return fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	invitation.RecordEmailSent(now)
+	if err := s.invitationRepo.UpdateInvitation(ctx, invitation); err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+	return nil
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hryt430/Yotei+/internal/modules/social/usecase (interfaces: FriendshipRepository,InvitationRepository)
+// Source: github.com/hryt430/Yotei+/internal/modules/social/usecase (interfaces: FriendshipRepository,InvitationRepository,PresenceRepository,ProfileRepository,DigestStateRepository)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -53,6 +53,36 @@ func (mr *MockFriendshipRepositoryMockRecorder) AreFriends(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).AreFriends), arg0, arg1, arg2)
 }
 
+// CountFriends mocks base method.
+func (m *MockFriendshipRepository) CountFriends(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFriends", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFriends indicates an expected call of CountFriends.
+func (mr *MockFriendshipRepositoryMockRecorder) CountFriends(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).CountFriends), arg0, arg1)
+}
+
+// CountPendingRequestsByAddressee mocks base method.
+func (m *MockFriendshipRepository) CountPendingRequestsByAddressee(arg0 context.Context, arg1 time.Time) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingRequestsByAddressee", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingRequestsByAddressee indicates an expected call of CountPendingRequestsByAddressee.
+func (mr *MockFriendshipRepositoryMockRecorder) CountPendingRequestsByAddressee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingRequestsByAddressee", reflect.TypeOf((*MockFriendshipRepository)(nil).CountPendingRequestsByAddressee), arg0, arg1)
+}
+
 // CreateFriendship mocks base method.
 func (m *MockFriendshipRepository) CreateFriendship(arg0 context.Context, arg1 *domain0.Friendship) error {
 	m.ctrl.T.Helper()
@@ -81,34 +111,79 @@ func (mr *MockFriendshipRepositoryMockRecorder) DeleteFriendship(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendship", reflect.TypeOf((*MockFriendshipRepository)(nil).DeleteFriendship), arg0, arg1, arg2)
 }
 
-// GetFriendCount mocks base method.
-func (m *MockFriendshipRepository) GetFriendCount(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+// DeletePendingRequest mocks base method.
+func (m *MockFriendshipRepository) DeletePendingRequest(arg0 context.Context, arg1 uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFriendCount", arg0, arg1)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "DeletePendingRequest", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePendingRequest indicates an expected call of DeletePendingRequest.
+func (mr *MockFriendshipRepositoryMockRecorder) DeletePendingRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingRequest", reflect.TypeOf((*MockFriendshipRepository)(nil).DeletePendingRequest), arg0, arg1)
+}
+
+// GetBlockRelatedUserIDs mocks base method.
+func (m *MockFriendshipRepository) GetBlockRelatedUserIDs(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockRelatedUserIDs", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFriendCount indicates an expected call of GetFriendCount.
-func (mr *MockFriendshipRepositoryMockRecorder) GetFriendCount(arg0, arg1 interface{}) *gomock.Call {
+// GetBlockRelatedUserIDs indicates an expected call of GetBlockRelatedUserIDs.
+func (mr *MockFriendshipRepositoryMockRecorder) GetBlockRelatedUserIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendCount", reflect.TypeOf((*MockFriendshipRepository)(nil).GetFriendCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockRelatedUserIDs", reflect.TypeOf((*MockFriendshipRepository)(nil).GetBlockRelatedUserIDs), arg0, arg1)
+}
+
+// GetBlockedUsers mocks base method.
+func (m *MockFriendshipRepository) GetBlockedUsers(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Pagination) ([]*domain0.Friendship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain0.Friendship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUsers indicates an expected call of GetBlockedUsers.
+func (mr *MockFriendshipRepositoryMockRecorder) GetBlockedUsers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUsers", reflect.TypeOf((*MockFriendshipRepository)(nil).GetBlockedUsers), arg0, arg1, arg2)
+}
+
+// GetFriendIDs mocks base method.
+func (m *MockFriendshipRepository) GetFriendIDs(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFriendIDs", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFriendIDs indicates an expected call of GetFriendIDs.
+func (mr *MockFriendshipRepositoryMockRecorder) GetFriendIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendIDs", reflect.TypeOf((*MockFriendshipRepository)(nil).GetFriendIDs), arg0, arg1)
 }
 
 // GetFriends mocks base method.
-func (m *MockFriendshipRepository) GetFriends(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Pagination) ([]*domain0.Friendship, error) {
+func (m *MockFriendshipRepository) GetFriends(arg0 context.Context, arg1 uuid.UUID, arg2 domain0.FriendSort, arg3 domain.Pagination) ([]*domain0.Friendship, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFriends", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetFriends", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain0.Friendship)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFriends indicates an expected call of GetFriends.
-func (mr *MockFriendshipRepositoryMockRecorder) GetFriends(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockFriendshipRepositoryMockRecorder) GetFriends(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).GetFriends), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).GetFriends), arg0, arg1, arg2, arg3)
 }
 
 // GetFriendship mocks base method.
@@ -156,6 +231,21 @@ func (mr *MockFriendshipRepositoryMockRecorder) GetPendingRequests(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingRequests", reflect.TypeOf((*MockFriendshipRepository)(nil).GetPendingRequests), arg0, arg1, arg2)
 }
 
+// GetPresenceAudience mocks base method.
+func (m *MockFriendshipRepository) GetPresenceAudience(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresenceAudience", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresenceAudience indicates an expected call of GetPresenceAudience.
+func (mr *MockFriendshipRepositoryMockRecorder) GetPresenceAudience(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresenceAudience", reflect.TypeOf((*MockFriendshipRepository)(nil).GetPresenceAudience), arg0, arg1)
+}
+
 // GetSentRequests mocks base method.
 func (m *MockFriendshipRepository) GetSentRequests(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Pagination) ([]*domain0.Friendship, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +261,21 @@ func (mr *MockFriendshipRepositoryMockRecorder) GetSentRequests(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentRequests", reflect.TypeOf((*MockFriendshipRepository)(nil).GetSentRequests), arg0, arg1, arg2)
 }
 
+// GetStalePendingRequests mocks base method.
+func (m *MockFriendshipRepository) GetStalePendingRequests(arg0 context.Context, arg1 time.Time, arg2 int) ([]*domain0.Friendship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStalePendingRequests", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain0.Friendship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStalePendingRequests indicates an expected call of GetStalePendingRequests.
+func (mr *MockFriendshipRepositoryMockRecorder) GetStalePendingRequests(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStalePendingRequests", reflect.TypeOf((*MockFriendshipRepository)(nil).GetStalePendingRequests), arg0, arg1, arg2)
+}
+
 // IsBlocked mocks base method.
 func (m *MockFriendshipRepository) IsBlocked(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -186,6 +291,20 @@ func (mr *MockFriendshipRepositoryMockRecorder) IsBlocked(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockFriendshipRepository)(nil).IsBlocked), arg0, arg1, arg2)
 }
 
+// MarkReminderSent mocks base method.
+func (m *MockFriendshipRepository) MarkReminderSent(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockFriendshipRepositoryMockRecorder) MarkReminderSent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockFriendshipRepository)(nil).MarkReminderSent), arg0, arg1, arg2)
+}
+
 // UpdateFriendship mocks base method.
 func (m *MockFriendshipRepository) UpdateFriendship(arg0 context.Context, arg1 *domain0.Friendship) error {
 	m.ctrl.T.Helper()
@@ -223,6 +342,51 @@ func (m *MockInvitationRepository) EXPECT() *MockInvitationRepositoryMockRecorde
 	return m.recorder
 }
 
+// CountInvitationsSince mocks base method.
+func (m *MockInvitationRepository) CountInvitationsSince(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInvitationsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInvitationsSince indicates an expected call of CountInvitationsSince.
+func (mr *MockInvitationRepositoryMockRecorder) CountInvitationsSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvitationsSince", reflect.TypeOf((*MockInvitationRepository)(nil).CountInvitationsSince), arg0, arg1, arg2)
+}
+
+// CountPendingGroupInvitationsByInvitee mocks base method.
+func (m *MockInvitationRepository) CountPendingGroupInvitationsByInvitee(arg0 context.Context, arg1, arg2 time.Time) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingGroupInvitationsByInvitee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingGroupInvitationsByInvitee indicates an expected call of CountPendingGroupInvitationsByInvitee.
+func (mr *MockInvitationRepositoryMockRecorder) CountPendingGroupInvitationsByInvitee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingGroupInvitationsByInvitee", reflect.TypeOf((*MockInvitationRepository)(nil).CountPendingGroupInvitationsByInvitee), arg0, arg1, arg2)
+}
+
+// CountTargetInvitationsSince mocks base method.
+func (m *MockInvitationRepository) CountTargetInvitationsSince(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTargetInvitationsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTargetInvitationsSince indicates an expected call of CountTargetInvitationsSince.
+func (mr *MockInvitationRepositoryMockRecorder) CountTargetInvitationsSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTargetInvitationsSince", reflect.TypeOf((*MockInvitationRepository)(nil).CountTargetInvitationsSince), arg0, arg1, arg2)
+}
+
 // CreateInvitation mocks base method.
 func (m *MockInvitationRepository) CreateInvitation(arg0 context.Context, arg1 *domain0.Invitation) error {
 	m.ctrl.T.Helper()
@@ -295,6 +459,21 @@ func (mr *MockInvitationRepositoryMockRecorder) GetInvitationByID(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByID", reflect.TypeOf((*MockInvitationRepository)(nil).GetInvitationByID), arg0, arg1)
 }
 
+// GetPendingInvitationsByEmail mocks base method.
+func (m *MockInvitationRepository) GetPendingInvitationsByEmail(arg0 context.Context, arg1 string) ([]*domain0.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvitationsByEmail", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvitationsByEmail indicates an expected call of GetPendingInvitationsByEmail.
+func (mr *MockInvitationRepositoryMockRecorder) GetPendingInvitationsByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvitationsByEmail", reflect.TypeOf((*MockInvitationRepository)(nil).GetPendingInvitationsByEmail), arg0, arg1)
+}
+
 // GetReceivedInvitations mocks base method.
 func (m *MockInvitationRepository) GetReceivedInvitations(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Pagination) ([]*domain0.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -341,11 +520,12 @@ func (mr *MockInvitationRepositoryMockRecorder) IsValidInvitation(arg0, arg1 int
 }
 
 // MarkExpiredInvitations mocks base method.
-func (m *MockInvitationRepository) MarkExpiredInvitations(arg0 context.Context) error {
+func (m *MockInvitationRepository) MarkExpiredInvitations(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "MarkExpiredInvitations", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // MarkExpiredInvitations indicates an expected call of MarkExpiredInvitations.
@@ -367,3 +547,204 @@ func (mr *MockInvitationRepositoryMockRecorder) UpdateInvitation(arg0, arg1 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitation", reflect.TypeOf((*MockInvitationRepository)(nil).UpdateInvitation), arg0, arg1)
 }
+
+// MockPresenceRepository is a mock of PresenceRepository interface.
+type MockPresenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceRepositoryMockRecorder
+}
+
+// MockPresenceRepositoryMockRecorder is the mock recorder for MockPresenceRepository.
+type MockPresenceRepositoryMockRecorder struct {
+	mock *MockPresenceRepository
+}
+
+// NewMockPresenceRepository creates a new mock instance.
+func NewMockPresenceRepository(ctrl *gomock.Controller) *MockPresenceRepository {
+	mock := &MockPresenceRepository{ctrl: ctrl}
+	mock.recorder = &MockPresenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceRepository) EXPECT() *MockPresenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPresences mocks base method.
+func (m *MockPresenceRepository) GetPresences(arg0 context.Context, arg1 []uuid.UUID) ([]*domain0.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresences", arg0, arg1)
+	ret0, _ := ret[0].([]*domain0.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresences indicates an expected call of GetPresences.
+func (mr *MockPresenceRepositoryMockRecorder) GetPresences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresences", reflect.TypeOf((*MockPresenceRepository)(nil).GetPresences), arg0, arg1)
+}
+
+// Refresh mocks base method.
+func (m *MockPresenceRepository) Refresh(arg0 context.Context, arg1 uuid.UUID, arg2 time.Duration, arg3 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockPresenceRepositoryMockRecorder) Refresh(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPresenceRepository)(nil).Refresh), arg0, arg1, arg2, arg3)
+}
+
+// SetOffline mocks base method.
+func (m *MockPresenceRepository) SetOffline(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (domain0.PresenceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOffline", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain0.PresenceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOffline indicates an expected call of SetOffline.
+func (mr *MockPresenceRepositoryMockRecorder) SetOffline(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOffline", reflect.TypeOf((*MockPresenceRepository)(nil).SetOffline), arg0, arg1, arg2)
+}
+
+// SetStatus mocks base method.
+func (m *MockPresenceRepository) SetStatus(arg0 context.Context, arg1 uuid.UUID, arg2 domain0.PresenceStatus, arg3 time.Duration, arg4 time.Time) (domain0.PresenceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(domain0.PresenceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPresenceRepositoryMockRecorder) SetStatus(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPresenceRepository)(nil).SetStatus), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockProfileRepository is a mock of ProfileRepository interface.
+type MockProfileRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileRepositoryMockRecorder
+}
+
+// MockProfileRepositoryMockRecorder is the mock recorder for MockProfileRepository.
+type MockProfileRepositoryMockRecorder struct {
+	mock *MockProfileRepository
+}
+
+// NewMockProfileRepository creates a new mock instance.
+func NewMockProfileRepository(ctrl *gomock.Controller) *MockProfileRepository {
+	mock := &MockProfileRepository{ctrl: ctrl}
+	mock.recorder = &MockProfileRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileRepository) EXPECT() *MockProfileRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetProfileSettings mocks base method.
+func (m *MockProfileRepository) GetProfileSettings(arg0 context.Context, arg1 uuid.UUID) (*domain0.ProfileSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileSettings", arg0, arg1)
+	ret0, _ := ret[0].(*domain0.ProfileSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileSettings indicates an expected call of GetProfileSettings.
+func (mr *MockProfileRepositoryMockRecorder) GetProfileSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileSettings", reflect.TypeOf((*MockProfileRepository)(nil).GetProfileSettings), arg0, arg1)
+}
+
+// SaveProfileSettings mocks base method.
+func (m *MockProfileRepository) SaveProfileSettings(arg0 context.Context, arg1 *domain0.ProfileSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProfileSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProfileSettings indicates an expected call of SaveProfileSettings.
+func (mr *MockProfileRepositoryMockRecorder) SaveProfileSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProfileSettings", reflect.TypeOf((*MockProfileRepository)(nil).SaveProfileSettings), arg0, arg1)
+}
+
+// MockDigestStateRepository is a mock of DigestStateRepository interface.
+type MockDigestStateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestStateRepositoryMockRecorder
+}
+
+// MockDigestStateRepositoryMockRecorder is the mock recorder for MockDigestStateRepository.
+type MockDigestStateRepositoryMockRecorder struct {
+	mock *MockDigestStateRepository
+}
+
+// NewMockDigestStateRepository creates a new mock instance.
+func NewMockDigestStateRepository(ctrl *gomock.Controller) *MockDigestStateRepository {
+	mock := &MockDigestStateRepository{ctrl: ctrl}
+	mock.recorder = &MockDigestStateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestStateRepository) EXPECT() *MockDigestStateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteDigestState mocks base method.
+func (m *MockDigestStateRepository) DeleteDigestState(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDigestState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDigestState indicates an expected call of DeleteDigestState.
+func (mr *MockDigestStateRepositoryMockRecorder) DeleteDigestState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDigestState", reflect.TypeOf((*MockDigestStateRepository)(nil).DeleteDigestState), arg0, arg1)
+}
+
+// ListDigestStates mocks base method.
+func (m *MockDigestStateRepository) ListDigestStates(arg0 context.Context) ([]*domain0.DigestState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDigestStates", arg0)
+	ret0, _ := ret[0].([]*domain0.DigestState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDigestStates indicates an expected call of ListDigestStates.
+func (mr *MockDigestStateRepositoryMockRecorder) ListDigestStates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDigestStates", reflect.TypeOf((*MockDigestStateRepository)(nil).ListDigestStates), arg0)
+}
+
+// SaveDigestState mocks base method.
+func (m *MockDigestStateRepository) SaveDigestState(arg0 context.Context, arg1 *domain0.DigestState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDigestState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDigestState indicates an expected call of SaveDigestState.
+func (mr *MockDigestStateRepositoryMockRecorder) SaveDigestState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDigestState", reflect.TypeOf((*MockDigestStateRepository)(nil).SaveDigestState), arg0, arg1)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hryt430/Yotei+/internal/modules/social/usecase (interfaces: SocialEventPublisher,URLGateway)
+// Source: github.com/hryt430/Yotei+/internal/modules/social/usecase (interfaces: SocialEventPublisher,URLGateway,InvitationEmailGateway,PresenceNotifier,GroupMembershipGateway,ProfileUserDirectory,ProfileStatsProvider,DigestPreferenceProvider,DigestNotifier)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -7,10 +7,12 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	domain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	domain "github.com/hryt430/Yotei+/internal/common/domain"
+	domain0 "github.com/hryt430/Yotei+/internal/modules/social/domain"
 )
 
 // MockSocialEventPublisher is a mock of SocialEventPublisher interface.
@@ -51,7 +53,7 @@ func (mr *MockSocialEventPublisherMockRecorder) PublishFriendRemoved(arg0, arg1,
 }
 
 // PublishFriendRequestAccepted mocks base method.
-func (m *MockSocialEventPublisher) PublishFriendRequestAccepted(arg0 context.Context, arg1 *domain.Friendship) error {
+func (m *MockSocialEventPublisher) PublishFriendRequestAccepted(arg0 context.Context, arg1 *domain0.Friendship) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishFriendRequestAccepted", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -65,7 +67,7 @@ func (mr *MockSocialEventPublisherMockRecorder) PublishFriendRequestAccepted(arg
 }
 
 // PublishFriendRequestDeclined mocks base method.
-func (m *MockSocialEventPublisher) PublishFriendRequestDeclined(arg0 context.Context, arg1 *domain.Friendship) error {
+func (m *MockSocialEventPublisher) PublishFriendRequestDeclined(arg0 context.Context, arg1 *domain0.Friendship) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishFriendRequestDeclined", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -78,8 +80,22 @@ func (mr *MockSocialEventPublisherMockRecorder) PublishFriendRequestDeclined(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishFriendRequestDeclined", reflect.TypeOf((*MockSocialEventPublisher)(nil).PublishFriendRequestDeclined), arg0, arg1)
 }
 
+// PublishFriendRequestReminder mocks base method.
+func (m *MockSocialEventPublisher) PublishFriendRequestReminder(arg0 context.Context, arg1 *domain0.Friendship, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishFriendRequestReminder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishFriendRequestReminder indicates an expected call of PublishFriendRequestReminder.
+func (mr *MockSocialEventPublisherMockRecorder) PublishFriendRequestReminder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishFriendRequestReminder", reflect.TypeOf((*MockSocialEventPublisher)(nil).PublishFriendRequestReminder), arg0, arg1, arg2)
+}
+
 // PublishFriendRequestSent mocks base method.
-func (m *MockSocialEventPublisher) PublishFriendRequestSent(arg0 context.Context, arg1 *domain.Friendship, arg2 string) error {
+func (m *MockSocialEventPublisher) PublishFriendRequestSent(arg0 context.Context, arg1 *domain0.Friendship, arg2 string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishFriendRequestSent", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
@@ -93,7 +109,7 @@ func (mr *MockSocialEventPublisherMockRecorder) PublishFriendRequestSent(arg0, a
 }
 
 // PublishInvitationAccepted mocks base method.
-func (m *MockSocialEventPublisher) PublishInvitationAccepted(arg0 context.Context, arg1 *domain.Invitation) error {
+func (m *MockSocialEventPublisher) PublishInvitationAccepted(arg0 context.Context, arg1 *domain0.Invitation) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishInvitationAccepted", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -107,7 +123,7 @@ func (mr *MockSocialEventPublisherMockRecorder) PublishInvitationAccepted(arg0,
 }
 
 // PublishInvitationCreated mocks base method.
-func (m *MockSocialEventPublisher) PublishInvitationCreated(arg0 context.Context, arg1 *domain.Invitation) error {
+func (m *MockSocialEventPublisher) PublishInvitationCreated(arg0 context.Context, arg1 *domain0.Invitation) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishInvitationCreated", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -121,7 +137,7 @@ func (mr *MockSocialEventPublisherMockRecorder) PublishInvitationCreated(arg0, a
 }
 
 // PublishInvitationDeclined mocks base method.
-func (m *MockSocialEventPublisher) PublishInvitationDeclined(arg0 context.Context, arg1 *domain.Invitation) error {
+func (m *MockSocialEventPublisher) PublishInvitationDeclined(arg0 context.Context, arg1 *domain0.Invitation) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PublishInvitationDeclined", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -171,6 +187,21 @@ func (m *MockURLGateway) EXPECT() *MockURLGatewayMockRecorder {
 	return m.recorder
 }
 
+// GenerateInviteLinks mocks base method.
+func (m *MockURLGateway) GenerateInviteLinks(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*domain0.InviteLinks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateInviteLinks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain0.InviteLinks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateInviteLinks indicates an expected call of GenerateInviteLinks.
+func (mr *MockURLGatewayMockRecorder) GenerateInviteLinks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateInviteLinks", reflect.TypeOf((*MockURLGateway)(nil).GenerateInviteLinks), arg0, arg1, arg2)
+}
+
 // GenerateInviteURL mocks base method.
 func (m *MockURLGateway) GenerateInviteURL(arg0 context.Context, arg1 uuid.UUID, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -185,3 +216,295 @@ func (mr *MockURLGatewayMockRecorder) GenerateInviteURL(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateInviteURL", reflect.TypeOf((*MockURLGateway)(nil).GenerateInviteURL), arg0, arg1, arg2)
 }
+
+// MockInvitationEmailGateway is a mock of InvitationEmailGateway interface.
+type MockInvitationEmailGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationEmailGatewayMockRecorder
+}
+
+// MockInvitationEmailGatewayMockRecorder is the mock recorder for MockInvitationEmailGateway.
+type MockInvitationEmailGatewayMockRecorder struct {
+	mock *MockInvitationEmailGateway
+}
+
+// NewMockInvitationEmailGateway creates a new mock instance.
+func NewMockInvitationEmailGateway(ctrl *gomock.Controller) *MockInvitationEmailGateway {
+	mock := &MockInvitationEmailGateway{ctrl: ctrl}
+	mock.recorder = &MockInvitationEmailGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationEmailGateway) EXPECT() *MockInvitationEmailGatewayMockRecorder {
+	return m.recorder
+}
+
+// SendInvitationEmail mocks base method.
+func (m *MockInvitationEmailGateway) SendInvitationEmail(arg0 context.Context, arg1 domain0.InvitationEmail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvitationEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendInvitationEmail indicates an expected call of SendInvitationEmail.
+func (mr *MockInvitationEmailGatewayMockRecorder) SendInvitationEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvitationEmail", reflect.TypeOf((*MockInvitationEmailGateway)(nil).SendInvitationEmail), arg0, arg1)
+}
+
+// MockPresenceNotifier is a mock of PresenceNotifier interface.
+type MockPresenceNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceNotifierMockRecorder
+}
+
+// MockPresenceNotifierMockRecorder is the mock recorder for MockPresenceNotifier.
+type MockPresenceNotifierMockRecorder struct {
+	mock *MockPresenceNotifier
+}
+
+// NewMockPresenceNotifier creates a new mock instance.
+func NewMockPresenceNotifier(ctrl *gomock.Controller) *MockPresenceNotifier {
+	mock := &MockPresenceNotifier{ctrl: ctrl}
+	mock.recorder = &MockPresenceNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceNotifier) EXPECT() *MockPresenceNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyPresenceChanged mocks base method.
+func (m *MockPresenceNotifier) NotifyPresenceChanged(arg0 context.Context, arg1 []uuid.UUID, arg2 *domain0.Presence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPresenceChanged", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPresenceChanged indicates an expected call of NotifyPresenceChanged.
+func (mr *MockPresenceNotifierMockRecorder) NotifyPresenceChanged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPresenceChanged", reflect.TypeOf((*MockPresenceNotifier)(nil).NotifyPresenceChanged), arg0, arg1, arg2)
+}
+
+// MockGroupMembershipGateway is a mock of GroupMembershipGateway interface.
+type MockGroupMembershipGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupMembershipGatewayMockRecorder
+}
+
+// MockGroupMembershipGatewayMockRecorder is the mock recorder for MockGroupMembershipGateway.
+type MockGroupMembershipGatewayMockRecorder struct {
+	mock *MockGroupMembershipGateway
+}
+
+// NewMockGroupMembershipGateway creates a new mock instance.
+func NewMockGroupMembershipGateway(ctrl *gomock.Controller) *MockGroupMembershipGateway {
+	mock := &MockGroupMembershipGateway{ctrl: ctrl}
+	mock.recorder = &MockGroupMembershipGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupMembershipGateway) EXPECT() *MockGroupMembershipGatewayMockRecorder {
+	return m.recorder
+}
+
+// CanInviteToGroup mocks base method.
+func (m *MockGroupMembershipGateway) CanInviteToGroup(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanInviteToGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanInviteToGroup indicates an expected call of CanInviteToGroup.
+func (mr *MockGroupMembershipGatewayMockRecorder) CanInviteToGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanInviteToGroup", reflect.TypeOf((*MockGroupMembershipGateway)(nil).CanInviteToGroup), arg0, arg1, arg2)
+}
+
+// JoinGroupByInvitation mocks base method.
+func (m *MockGroupMembershipGateway) JoinGroupByInvitation(arg0 context.Context, arg1, arg2, arg3 uuid.UUID, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinGroupByInvitation", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// JoinGroupByInvitation indicates an expected call of JoinGroupByInvitation.
+func (mr *MockGroupMembershipGatewayMockRecorder) JoinGroupByInvitation(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinGroupByInvitation", reflect.TypeOf((*MockGroupMembershipGateway)(nil).JoinGroupByInvitation), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockProfileUserDirectory is a mock of ProfileUserDirectory interface.
+type MockProfileUserDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileUserDirectoryMockRecorder
+}
+
+// MockProfileUserDirectoryMockRecorder is the mock recorder for MockProfileUserDirectory.
+type MockProfileUserDirectoryMockRecorder struct {
+	mock *MockProfileUserDirectory
+}
+
+// NewMockProfileUserDirectory creates a new mock instance.
+func NewMockProfileUserDirectory(ctrl *gomock.Controller) *MockProfileUserDirectory {
+	mock := &MockProfileUserDirectory{ctrl: ctrl}
+	mock.recorder = &MockProfileUserDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileUserDirectory) EXPECT() *MockProfileUserDirectoryMockRecorder {
+	return m.recorder
+}
+
+// FindActiveUserByUsername mocks base method.
+func (m *MockProfileUserDirectory) FindActiveUserByUsername(arg0 context.Context, arg1 string) (*domain.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*domain.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveUserByUsername indicates an expected call of FindActiveUserByUsername.
+func (mr *MockProfileUserDirectoryMockRecorder) FindActiveUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveUserByUsername", reflect.TypeOf((*MockProfileUserDirectory)(nil).FindActiveUserByUsername), arg0, arg1)
+}
+
+// MockProfileStatsProvider is a mock of ProfileStatsProvider interface.
+type MockProfileStatsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileStatsProviderMockRecorder
+}
+
+// MockProfileStatsProviderMockRecorder is the mock recorder for MockProfileStatsProvider.
+type MockProfileStatsProviderMockRecorder struct {
+	mock *MockProfileStatsProvider
+}
+
+// NewMockProfileStatsProvider creates a new mock instance.
+func NewMockProfileStatsProvider(ctrl *gomock.Controller) *MockProfileStatsProvider {
+	mock := &MockProfileStatsProvider{ctrl: ctrl}
+	mock.recorder = &MockProfileStatsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileStatsProvider) EXPECT() *MockProfileStatsProviderMockRecorder {
+	return m.recorder
+}
+
+// GetProfileStats mocks base method.
+func (m *MockProfileStatsProvider) GetProfileStats(arg0 context.Context, arg1 uuid.UUID) (*domain0.ProfileStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileStats", arg0, arg1)
+	ret0, _ := ret[0].(*domain0.ProfileStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileStats indicates an expected call of GetProfileStats.
+func (mr *MockProfileStatsProviderMockRecorder) GetProfileStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileStats", reflect.TypeOf((*MockProfileStatsProvider)(nil).GetProfileStats), arg0, arg1)
+}
+
+// MockDigestPreferenceProvider is a mock of DigestPreferenceProvider interface.
+type MockDigestPreferenceProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestPreferenceProviderMockRecorder
+}
+
+// MockDigestPreferenceProviderMockRecorder is the mock recorder for MockDigestPreferenceProvider.
+type MockDigestPreferenceProviderMockRecorder struct {
+	mock *MockDigestPreferenceProvider
+}
+
+// NewMockDigestPreferenceProvider creates a new mock instance.
+func NewMockDigestPreferenceProvider(ctrl *gomock.Controller) *MockDigestPreferenceProvider {
+	mock := &MockDigestPreferenceProvider{ctrl: ctrl}
+	mock.recorder = &MockDigestPreferenceProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestPreferenceProvider) EXPECT() *MockDigestPreferenceProviderMockRecorder {
+	return m.recorder
+}
+
+// DigestInterval mocks base method.
+func (m *MockDigestPreferenceProvider) DigestInterval(arg0 context.Context, arg1 uuid.UUID) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DigestInterval", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DigestInterval indicates an expected call of DigestInterval.
+func (mr *MockDigestPreferenceProviderMockRecorder) DigestInterval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DigestInterval", reflect.TypeOf((*MockDigestPreferenceProvider)(nil).DigestInterval), arg0, arg1)
+}
+
+// MockDigestNotifier is a mock of DigestNotifier interface.
+type MockDigestNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestNotifierMockRecorder
+}
+
+// MockDigestNotifierMockRecorder is the mock recorder for MockDigestNotifier.
+type MockDigestNotifierMockRecorder struct {
+	mock *MockDigestNotifier
+}
+
+// NewMockDigestNotifier creates a new mock instance.
+func NewMockDigestNotifier(ctrl *gomock.Controller) *MockDigestNotifier {
+	mock := &MockDigestNotifier{ctrl: ctrl}
+	mock.recorder = &MockDigestNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestNotifier) EXPECT() *MockDigestNotifierMockRecorder {
+	return m.recorder
+}
+
+// DismissPendingDigest mocks base method.
+func (m *MockDigestNotifier) DismissPendingDigest(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissPendingDigest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissPendingDigest indicates an expected call of DismissPendingDigest.
+func (mr *MockDigestNotifierMockRecorder) DismissPendingDigest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissPendingDigest", reflect.TypeOf((*MockDigestNotifier)(nil).DismissPendingDigest), arg0, arg1, arg2)
+}
+
+// SendPendingDigest mocks base method.
+func (m *MockDigestNotifier) SendPendingDigest(arg0 context.Context, arg1 domain0.PendingDigest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPendingDigest", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendPendingDigest indicates an expected call of SendPendingDigest.
+func (mr *MockDigestNotifierMockRecorder) SendPendingDigest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPendingDigest", reflect.TypeOf((*MockDigestNotifier)(nil).SendPendingDigest), arg0, arg1)
+}
@@ -79,3 +79,18 @@ func (mr *MockUserValidatorMockRecorder) UserExists(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserExists", reflect.TypeOf((*MockUserValidator)(nil).UserExists), arg0, arg1)
 }
+
+// UsersExist mocks base method.
+func (m *MockUserValidator) UsersExist(arg0 context.Context, arg1 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsersExist", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsersExist indicates an expected call of UsersExist.
+func (mr *MockUserValidatorMockRecorder) UsersExist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersExist", reflect.TypeOf((*MockUserValidator)(nil).UsersExist), arg0, arg1)
+}
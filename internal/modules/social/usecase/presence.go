@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DefaultPresenceTimeout はハートビートが途絶えてからオフラインとみなすまでの既定時間
+const DefaultPresenceTimeout = 90 * time.Second
+
+// MaxPresenceBatchSize は一度に取得できるオンライン状態の上限
+const MaxPresenceBatchSize = 200
+
+var (
+	ErrPresenceNotEnabled = errors.New("presence is not enabled")
+	ErrTooManyPresenceIDs = errors.New("too many user IDs for presence lookup")
+)
+
+// PresenceRepository はオンライン状態の保存先（Redisなど）のインターフェース
+type PresenceRepository interface {
+	// SetStatus は状態をttlの間保持して最終接続日時を記録し、変更前の状態を返す
+	SetStatus(ctx context.Context, userID uuid.UUID, status domain.PresenceStatus, ttl time.Duration, now time.Time) (domain.PresenceStatus, error)
+	// Refresh は状態の有効期限を延長して最終接続日時を記録する（状態が失効していた場合はfalse）
+	Refresh(ctx context.Context, userID uuid.UUID, ttl time.Duration, now time.Time) (bool, error)
+	// SetOffline は状態を削除して最終接続日時を記録し、変更前の状態を返す
+	SetOffline(ctx context.Context, userID uuid.UUID, now time.Time) (domain.PresenceStatus, error)
+	// GetPresences は指定ユーザーのオンライン状態を一括取得する
+	GetPresences(ctx context.Context, userIDs []uuid.UUID) ([]*domain.Presence, error)
+}
+
+// PresenceNotifier はオンライン状態の変化を接続中のユーザーに配信するインターフェース
+type PresenceNotifier interface {
+	NotifyPresenceChanged(ctx context.Context, recipientIDs []uuid.UUID, presence *domain.Presence) error
+}
+
+// PresenceService はWebSocket接続に基づくオンライン状態を管理する
+// presenceRepoがnilの場合（Redis未接続時）はオンライン状態を記録しない
+type PresenceService struct {
+	friendshipRepo FriendshipRepository
+	presenceRepo   PresenceRepository
+	notifier       PresenceNotifier
+	timeout        time.Duration
+	logger         *logger.Logger
+}
+
+// NewPresenceService は新しいPresenceServiceを作成する
+func NewPresenceService(
+	friendshipRepo FriendshipRepository,
+	presenceRepo PresenceRepository,
+	notifier PresenceNotifier,
+	timeout time.Duration,
+	logger *logger.Logger,
+) *PresenceService {
+	if timeout <= 0 {
+		timeout = DefaultPresenceTimeout
+	}
+	return &PresenceService{
+		friendshipRepo: friendshipRepo,
+		presenceRepo:   presenceRepo,
+		notifier:       notifier,
+		timeout:        timeout,
+		logger:         logger,
+	}
+}
+
+var _ commonDomain.PresenceTracker = (*PresenceService)(nil)
+
+// Enabled はオンライン状態の記録が有効か
+func (s *PresenceService) Enabled() bool {
+	return s.presenceRepo != nil
+}
+
+// Connected はユーザーの最初のWebSocket接続を記録し、オンラインにする
+func (s *PresenceService) Connected(ctx context.Context, userID string) error {
+	return s.setStatus(ctx, userID, domain.PresenceStatusOnline)
+}
+
+// Heartbeat は接続中ユーザーのハートビートを記録する
+// ハートビートが途絶えて状態が失効していた場合はオンラインに戻す
+func (s *PresenceService) Heartbeat(ctx context.Context, userID string) error {
+	if !s.Enabled() {
+		return nil
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	alive, err := s.presenceRepo.Refresh(ctx, id, s.timeout, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to refresh presence: %w", err)
+	}
+	if alive {
+		return nil
+	}
+	return s.setStatus(ctx, userID, domain.PresenceStatusOnline)
+}
+
+// SetAway はクライアントからの通知に応じて離席中・オンラインを切り替える
+func (s *PresenceService) SetAway(ctx context.Context, userID string, away bool) error {
+	status := domain.PresenceStatusOnline
+	if away {
+		status = domain.PresenceStatusAway
+	}
+	return s.setStatus(ctx, userID, status)
+}
+
+// Disconnected はユーザーの全てのWebSocket接続が切れたことを記録し、オフラインにする
+func (s *PresenceService) Disconnected(ctx context.Context, userID string) error {
+	if !s.Enabled() {
+		return nil
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	now := time.Now()
+	previous, err := s.presenceRepo.SetOffline(ctx, id, now)
+	if err != nil {
+		return fmt.Errorf("failed to set presence offline: %w", err)
+	}
+	if previous == domain.PresenceStatusOffline {
+		return nil
+	}
+
+	s.publish(ctx, domain.NewPresence(id, domain.PresenceStatusOffline, &now))
+	return nil
+}
+
+// GetFriendsPresence は友達・同じグループのメンバーのオンライン状態を取得する
+// userIDsが空の場合は全ての友達、指定した場合は閲覧できるユーザーのみを返す
+func (s *PresenceService) GetFriendsPresence(ctx context.Context, userID uuid.UUID, userIDs []uuid.UUID) ([]*domain.Presence, error) {
+	if !s.Enabled() {
+		return nil, ErrPresenceNotEnabled
+	}
+	if len(userIDs) > MaxPresenceBatchSize {
+		return nil, ErrTooManyPresenceIDs
+	}
+
+	var targets []uuid.UUID
+	if len(userIDs) == 0 {
+		friendIDs, err := s.friendshipRepo.GetFriendIDs(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get friend IDs: %w", err)
+		}
+		targets = friendIDs
+	} else {
+		audience, err := s.friendshipRepo.GetPresenceAudience(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get presence audience: %w", err)
+		}
+		visible := make(map[uuid.UUID]bool, len(audience))
+		for _, id := range audience {
+			visible[id] = true
+		}
+		for _, id := range userIDs {
+			if visible[id] {
+				targets = append(targets, id)
+				visible[id] = false // 重複を除外
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return []*domain.Presence{}, nil
+	}
+
+	presences, err := s.presenceRepo.GetPresences(ctx, targets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presences: %w", err)
+	}
+	return presences, nil
+}
+
+// setStatus は状態を保存し、変化があれば友達・グループメンバーに配信する
+func (s *PresenceService) setStatus(ctx context.Context, userID string, status domain.PresenceStatus) error {
+	if !s.Enabled() {
+		return nil
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	now := time.Now()
+	previous, err := s.presenceRepo.SetStatus(ctx, id, status, s.timeout, now)
+	if err != nil {
+		return fmt.Errorf("failed to set presence: %w", err)
+	}
+	if previous == status {
+		return nil
+	}
+
+	s.publish(ctx, domain.NewPresence(id, status, &now))
+	return nil
+}
+
+// publish はオンライン状態の変化を配信する（失敗しても状態の記録は成功とする）
+func (s *PresenceService) publish(ctx context.Context, presence *domain.Presence) {
+	if s.notifier == nil {
+		return
+	}
+
+	audience, err := s.friendshipRepo.GetPresenceAudience(ctx, presence.UserID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get presence audience",
+			logger.Any("userID", presence.UserID),
+			logger.Error(err))
+		return
+	}
+	if len(audience) == 0 {
+		return
+	}
+
+	if err := s.notifier.NotifyPresenceChanged(ctx, audience, presence); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to notify presence change",
+			logger.Any("userID", presence.UserID),
+			logger.Any("status", presence.Status),
+			logger.Error(err))
+	}
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/social/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ProfileRepository は公開プロフィールの設定のリポジトリインターフェース
+type ProfileRepository interface {
+	// GetProfileSettings は設定を取得する（保存していない場合は nil, nil）
+	GetProfileSettings(ctx context.Context, userID uuid.UUID) (*domain.ProfileSettings, error)
+	// SaveProfileSettings は設定を保存する（既存の設定は上書きする）
+	SaveProfileSettings(ctx context.Context, settings *domain.ProfileSettings) error
+}
+
+// ProfileUserDirectory はユーザー名から公開プロフィールの本人を取得するインターフェース
+type ProfileUserDirectory interface {
+	// FindActiveUserByUsername は無効化されていないユーザーを取得する（存在しない場合は nil, nil）
+	FindActiveUserByUsername(ctx context.Context, username string) (*commonDomain.UserInfo, error)
+}
+
+// ProfileStatsProvider は公開プロフィールに表示するタスクの統計を取得するインターフェース
+type ProfileStatsProvider interface {
+	GetProfileStats(ctx context.Context, userID uuid.UUID) (*domain.ProfileStats, error)
+}
+
+// UpdateProfileSettingsInput は公開プロフィールの設定の変更内容（nilの項目は変更しない）
+type UpdateProfileSettingsInput struct {
+	DisplayName *string
+	AvatarURL   *string
+	Bio         *string
+	Visibility  *domain.ProfileVisibility
+	ShowStats   *bool
+}
+
+// ProfileService は公開プロフィールとその公開範囲の設定を扱うサービス
+type ProfileService struct {
+	profileRepo    ProfileRepository
+	friendshipRepo FriendshipRepository
+	users          ProfileUserDirectory
+	stats          ProfileStatsProvider // nilの場合は統計を含めない
+	logger         *logger.Logger
+}
+
+// NewProfileService は新しいProfileServiceを作成する
+func NewProfileService(
+	profileRepo ProfileRepository,
+	friendshipRepo FriendshipRepository,
+	users ProfileUserDirectory,
+	stats ProfileStatsProvider,
+	logger *logger.Logger,
+) *ProfileService {
+	return &ProfileService{
+		profileRepo:    profileRepo,
+		friendshipRepo: friendshipRepo,
+		users:          users,
+		stats:          stats,
+		logger:         logger,
+	}
+}
+
+// GetPublicProfile はユーザー名の公開プロフィールを閲覧者に合わせて取得する
+// viewerID が nil の場合はログインしていない閲覧者として扱う
+// 存在しない・無効化された・ブロック関係にあるユーザーは domain.ErrProfileNotFound を返す
+func (s *ProfileService) GetPublicProfile(ctx context.Context, username string, viewerID *uuid.UUID) (*domain.PublicProfile, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, domain.ErrProfileNotFound
+	}
+
+	user, err := s.users.FindActiveUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrProfileNotFound
+	}
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+
+	relationship, err := s.relationship(ctx, userID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats *domain.ProfileStats
+	if s.stats != nil && settings.VisibleTo(relationship) && (settings.ShowStats || relationship == domain.ProfileRelationshipSelf) {
+		stats, err = s.stats.GetProfileStats(ctx, userID)
+		if err != nil {
+			// 統計を取得できなくてもプロフィールは表示する
+			s.logger.WithContext(ctx).Warn("Failed to get profile stats",
+				logger.Any("userID", userID),
+				logger.Error(err))
+			stats = nil
+		}
+	}
+
+	return domain.NewPublicProfile(userID, user.Username, settings, relationship, stats), nil
+}
+
+// relationship は閲覧者と本人の関係を返す（ブロック関係にある場合は domain.ErrProfileNotFound）
+func (s *ProfileService) relationship(ctx context.Context, userID uuid.UUID, viewerID *uuid.UUID) (domain.ProfileRelationship, error) {
+	if viewerID == nil {
+		return domain.ProfileRelationshipAnonymous, nil
+	}
+	if *viewerID == userID {
+		return domain.ProfileRelationshipSelf, nil
+	}
+
+	blocked, err := s.friendshipRepo.IsBlocked(ctx, *viewerID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check block status: %w", err)
+	}
+	if blocked {
+		// ブロック関係にあることを明かさない
+		return "", domain.ErrProfileNotFound
+	}
+
+	friendship, err := s.friendshipRepo.GetFriendship(ctx, *viewerID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get friendship: %w", err)
+	}
+	switch {
+	case friendship == nil:
+		return domain.ProfileRelationshipNone, nil
+	case friendship.IsFriend():
+		return domain.ProfileRelationshipFriend, nil
+	case friendship.Status == domain.FriendshipStatusPending && friendship.RequesterID == *viewerID:
+		return domain.ProfileRelationshipRequestSent, nil
+	case friendship.Status == domain.FriendshipStatusPending:
+		return domain.ProfileRelationshipRequestReceived, nil
+	default:
+		return domain.ProfileRelationshipNone, nil
+	}
+}
+
+// GetProfileSettings は自分の公開プロフィールの設定を取得する（保存していない場合は既定の設定）
+func (s *ProfileService) GetProfileSettings(ctx context.Context, userID uuid.UUID) (*domain.ProfileSettings, error) {
+	settings, err := s.profileRepo.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile settings: %w", err)
+	}
+	if settings == nil {
+		return domain.DefaultProfileSettings(userID), nil
+	}
+	return settings, nil
+}
+
+// UpdateProfileSettings は自分の公開プロフィールの設定を変更する
+func (s *ProfileService) UpdateProfileSettings(ctx context.Context, userID uuid.UUID, input UpdateProfileSettingsInput) (*domain.ProfileSettings, error) {
+	settings, err := s.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DisplayName != nil {
+		settings.DisplayName = strings.TrimSpace(*input.DisplayName)
+	}
+	if input.AvatarURL != nil {
+		settings.AvatarURL = strings.TrimSpace(*input.AvatarURL)
+	}
+	if input.Bio != nil {
+		settings.Bio = strings.TrimSpace(*input.Bio)
+	}
+	if input.Visibility != nil {
+		settings.Visibility = *input.Visibility
+	}
+	if input.ShowStats != nil {
+		settings.ShowStats = *input.ShowStats
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	settings.UpdatedAt = time.Now()
+	if err := s.profileRepo.SaveProfileSettings(ctx, settings); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save profile settings",
+			logger.Any("userID", userID),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to save profile settings: %w", err)
+	}
+	return settings, nil
+}
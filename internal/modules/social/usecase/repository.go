@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/social/domain"
 )
 
@@ -18,14 +19,16 @@ type SocialService interface {
 	AcceptFriendRequest(ctx context.Context, requesterID, addresseeID uuid.UUID) (*domain.Friendship, error)
 	DeclineFriendRequest(ctx context.Context, requesterID, addresseeID uuid.UUID) error
 	RemoveFriend(ctx context.Context, userID, friendID uuid.UUID) error
+	RemoveFriendWithUndo(ctx context.Context, userID, friendID uuid.UUID) (*undo.Token, error)
 	BlockUser(ctx context.Context, userID, targetID uuid.UUID) error
 	UnblockUser(ctx context.Context, userID, targetID uuid.UUID) error
 
 	// 友達一覧・検索
-	GetFriends(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*FriendWithUserInfo, error)
+	GetFriends(ctx context.Context, userID uuid.UUID, sort domain.FriendSort, pagination commonDomain.Pagination) ([]*FriendWithUserInfo, int, error)
 	GetPendingRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*FriendshipWithUserInfo, error)
 	GetSentRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*FriendshipWithUserInfo, error)
 	GetMutualFriends(ctx context.Context, userID, targetID uuid.UUID) ([]*FriendWithUserInfo, error)
+	GetBlockedUsers(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*FriendWithUserInfo, error)
 
 	// 招待管理
 	CreateInvitation(ctx context.Context, input CreateInvitationInput) (*domain.Invitation, error)
@@ -36,9 +39,15 @@ type SocialService interface {
 	CancelInvitation(ctx context.Context, invitationID, inviterID uuid.UUID) error
 	GetSentInvitations(ctx context.Context, inviterID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error)
 	GetReceivedInvitations(ctx context.Context, inviteeID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error)
+	ClaimEmailInvitations(ctx context.Context, userID uuid.UUID, email string) ([]*domain.Invitation, error)
+
+	// 招待メール
+	ResendInvitationEmail(ctx context.Context, invitationID, inviterID uuid.UUID) (*domain.Invitation, error)
+	HandleInvitationEmailBounce(ctx context.Context, invitationID uuid.UUID, email, reason string) error
 
 	// URL・招待コード
 	GenerateInviteURL(ctx context.Context, invitationID uuid.UUID) (string, error)
+	GenerateInviteLinks(ctx context.Context, invitation *domain.Invitation) (*domain.InviteLinks, error)
 	ValidateInviteCode(ctx context.Context, code string) (*domain.Invitation, error)
 
 	// 関係性チェック
@@ -56,6 +65,7 @@ type CreateInvitationInput struct {
 	ExpiresHours int
 	InviteeEmail *string
 	TargetID     *uuid.UUID // Group IDなど
+	GroupRole    string     // グループ招待で参加するときのロール（MEMBER・GUEST、空の場合は MEMBER）
 }
 
 // InvitationResult は招待受諾の結果
@@ -95,17 +105,34 @@ type FriendshipRepository interface {
 	DeleteFriendship(ctx context.Context, requesterID, addresseeID uuid.UUID) error
 
 	// 友達リスト・検索
-	GetFriends(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error)
+	GetFriends(ctx context.Context, userID uuid.UUID, sort domain.FriendSort, pagination commonDomain.Pagination) ([]*domain.Friendship, error)
 	GetPendingRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error)
 	GetSentRequests(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error)
 
+	// ブロックリスト
+	GetBlockedUsers(ctx context.Context, blockerID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Friendship, error)
+	GetBlockRelatedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+
+	// オンライン状態の公開範囲
+	GetFriendIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetPresenceAudience(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+
 	// 関係チェック
 	AreFriends(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error)
 	IsBlocked(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error)
 
 	// 統計
-	GetFriendCount(ctx context.Context, userID uuid.UUID) (int, error)
+	CountFriends(ctx context.Context, userID uuid.UUID) (int, error)
 	GetMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) ([]*domain.Friendship, error)
+
+	// 放置された申請のクリーンアップ
+	GetStalePendingRequests(ctx context.Context, createdBefore time.Time, limit int) ([]*domain.Friendship, error)
+	MarkReminderSent(ctx context.Context, friendshipID uuid.UUID, sentAt time.Time) error
+	DeletePendingRequest(ctx context.Context, friendshipID uuid.UUID) (bool, error)
+
+	// 未対応の申請のリマインダー
+	// CountPendingRequestsByAddressee は createdBefore より前に作成された承認待ちの申請の数を申請先のユーザーごとに取得する
+	CountPendingRequestsByAddressee(ctx context.Context, createdBefore time.Time) (map[uuid.UUID]int, error)
 }
 
 // InvitationRepository は招待のリポジトリインターフェース
@@ -120,11 +147,21 @@ type InvitationRepository interface {
 	// 招待一覧
 	GetSentInvitations(ctx context.Context, inviterID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error)
 	GetReceivedInvitations(ctx context.Context, inviteeID uuid.UUID, pagination commonDomain.Pagination) ([]*domain.Invitation, error)
+	// CountInvitationsSince は招待者がsince以降に作成した招待の数を取得する
+	CountInvitationsSince(ctx context.Context, inviterID uuid.UUID, since time.Time) (int64, error)
+	// CountTargetInvitationsSince は対象（グループ）へのsince以降に作成された招待の数を取得する
+	CountTargetInvitationsSince(ctx context.Context, targetID uuid.UUID, since time.Time) (int64, error)
+	// 未登録ユーザーのメールアドレス宛ての有効な（承認待ちで期限内の）招待一覧
+	GetPendingInvitationsByEmail(ctx context.Context, email string) ([]*domain.Invitation, error)
 
 	// 期限切れ招待の処理
-	MarkExpiredInvitations(ctx context.Context) error
+	MarkExpiredInvitations(ctx context.Context) (int64, error)
 	DeleteExpiredInvitations(ctx context.Context, beforeDate time.Time) error
 
+	// 未対応の招待のリマインダー
+	// CountPendingGroupInvitationsByInvitee は createdBefore より前に作成され、now の時点で有効な登録済みユーザー宛てのグループ招待の数を被招待者ごとに取得する
+	CountPendingGroupInvitationsByInvitee(ctx context.Context, createdBefore, now time.Time) (map[uuid.UUID]int, error)
+
 	// 招待検証
 	IsValidInvitation(ctx context.Context, code string) (bool, error)
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/social/domain"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
@@ -18,6 +20,12 @@ type SocialServiceImpl struct {
 	userValidator  commonDomain.UserValidator
 	eventPublisher SocialEventPublisher
 	urlGateway     URLGateway
+	emailGateway   InvitationEmailGateway      // nilの場合は招待メールを送信しない
+	groupGateway   GroupMembershipGateway      // nilの場合はグループ招待の受諾でメンバー追加しない
+	undoScheduler  undo.Scheduler              // nilの場合は友達削除を即時に実行する
+	quotas         commonDomain.QuotaChecker   // nilの場合は招待数の上限を確認しない
+	syncChanges    commonDomain.ChangeRecorder // nilの場合は変更フィードに記録しない
+	emailCooldown  time.Duration
 	logger         *logger.Logger
 }
 
@@ -26,6 +34,7 @@ type SocialEventPublisher interface {
 	PublishFriendRequestSent(ctx context.Context, friendship *domain.Friendship, message string) error
 	PublishFriendRequestAccepted(ctx context.Context, friendship *domain.Friendship) error
 	PublishFriendRequestDeclined(ctx context.Context, friendship *domain.Friendship) error
+	PublishFriendRequestReminder(ctx context.Context, friendship *domain.Friendship, deleteAt time.Time) error
 	PublishFriendRemoved(ctx context.Context, userID, friendID uuid.UUID) error
 	PublishUserBlocked(ctx context.Context, userID, targetID uuid.UUID) error
 	PublishInvitationCreated(ctx context.Context, invitation *domain.Invitation) error
@@ -36,6 +45,8 @@ type SocialEventPublisher interface {
 // URLGateway はURL生成のインターフェース
 type URLGateway interface {
 	GenerateInviteURL(ctx context.Context, invitationID uuid.UUID, code string) (string, error)
+	// GenerateInviteLinks はWeb URLとモバイルアプリ用のリンク（設定されている場合のみ）を生成する
+	GenerateInviteLinks(ctx context.Context, invitationID uuid.UUID, code string) (*domain.InviteLinks, error)
 }
 
 // NewSocialServiceImpl は新しいSocialServiceImplを作成する
@@ -53,6 +64,7 @@ func NewSocialServiceImpl(
 		userValidator:  userValidator,
 		eventPublisher: eventPublisher,
 		urlGateway:     urlGateway,
+		emailCooldown:  DefaultInvitationEmailCooldown,
 		logger:         logger,
 	}
 }
@@ -88,7 +100,7 @@ func (s *SocialServiceImpl) SendFriendRequest(ctx context.Context, requesterID,
 		case domain.FriendshipStatusPending:
 			return nil, errors.New("friend request already pending")
 		case domain.FriendshipStatusBlocked:
-			return nil, errors.New("user is blocked")
+			return nil, ErrUserBlocked
 		}
 	}
 
@@ -96,7 +108,7 @@ func (s *SocialServiceImpl) SendFriendRequest(ctx context.Context, requesterID,
 	friendship := domain.NewFriendship(requesterID, addresseeID)
 
 	if err := s.friendshipRepo.CreateFriendship(ctx, friendship); err != nil {
-		s.logger.Error("Failed to create friendship",
+		s.logger.WithContext(ctx).Error("Failed to create friendship",
 			logger.Any("requesterID", requesterID),
 			logger.Any("addresseeID", addresseeID),
 			logger.Error(err))
@@ -105,11 +117,12 @@ func (s *SocialServiceImpl) SendFriendRequest(ctx context.Context, requesterID,
 
 	// イベント発行
 	if err := s.eventPublisher.PublishFriendRequestSent(ctx, friendship, message); err != nil {
-		s.logger.Error("Failed to publish friend request sent event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish friend request sent event", logger.Error(err))
 		// イベント発行失敗は非致命的
 	}
+	syncFriendshipUpserted(ctx, s.syncChanges, friendship)
 
-	s.logger.Info("Friend request sent successfully",
+	s.logger.WithContext(ctx).Info("Friend request sent successfully",
 		logger.Any("requesterID", requesterID),
 		logger.Any("addresseeID", addresseeID))
 
@@ -140,7 +153,7 @@ func (s *SocialServiceImpl) AcceptFriendRequest(ctx context.Context, requesterID
 	friendship.Accept()
 
 	if err := s.friendshipRepo.UpdateFriendship(ctx, friendship); err != nil {
-		s.logger.Error("Failed to update friendship",
+		s.logger.WithContext(ctx).Error("Failed to update friendship",
 			logger.Any("friendshipID", friendship.ID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to update friendship: %w", err)
@@ -148,10 +161,11 @@ func (s *SocialServiceImpl) AcceptFriendRequest(ctx context.Context, requesterID
 
 	// イベント発行
 	if err := s.eventPublisher.PublishFriendRequestAccepted(ctx, friendship); err != nil {
-		s.logger.Error("Failed to publish friend request accepted event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish friend request accepted event", logger.Error(err))
 	}
+	syncFriendshipUpserted(ctx, s.syncChanges, friendship)
 
-	s.logger.Info("Friend request accepted successfully",
+	s.logger.WithContext(ctx).Info("Friend request accepted successfully",
 		logger.Any("friendshipID", friendship.ID))
 
 	return friendship, nil
@@ -174,7 +188,7 @@ func (s *SocialServiceImpl) DeclineFriendRequest(ctx context.Context, requesterI
 
 	// 友達申請を削除（拒否）
 	if err := s.friendshipRepo.DeleteFriendship(ctx, requesterID, addresseeID); err != nil {
-		s.logger.Error("Failed to delete friendship",
+		s.logger.WithContext(ctx).Error("Failed to delete friendship",
 			logger.Any("requesterID", requesterID),
 			logger.Any("addresseeID", addresseeID),
 			logger.Error(err))
@@ -183,10 +197,11 @@ func (s *SocialServiceImpl) DeclineFriendRequest(ctx context.Context, requesterI
 
 	// イベント発行
 	if err := s.eventPublisher.PublishFriendRequestDeclined(ctx, friendship); err != nil {
-		s.logger.Error("Failed to publish friend request declined event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish friend request declined event", logger.Error(err))
 	}
+	syncFriendshipDeleted(ctx, s.syncChanges, requesterID, addresseeID)
 
-	s.logger.Info("Friend request declined successfully",
+	s.logger.WithContext(ctx).Info("Friend request declined successfully",
 		logger.Any("requesterID", requesterID),
 		logger.Any("addresseeID", addresseeID))
 
@@ -207,7 +222,7 @@ func (s *SocialServiceImpl) RemoveFriend(ctx context.Context, userID, friendID u
 
 	// 友達関係を削除
 	if err := s.friendshipRepo.DeleteFriendship(ctx, userID, friendID); err != nil {
-		s.logger.Error("Failed to remove friend",
+		s.logger.WithContext(ctx).Error("Failed to remove friend",
 			logger.Any("userID", userID),
 			logger.Any("friendID", friendID),
 			logger.Error(err))
@@ -216,18 +231,67 @@ func (s *SocialServiceImpl) RemoveFriend(ctx context.Context, userID, friendID u
 
 	// イベント発行
 	if err := s.eventPublisher.PublishFriendRemoved(ctx, userID, friendID); err != nil {
-		s.logger.Error("Failed to publish friend removed event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish friend removed event", logger.Error(err))
 	}
+	syncFriendshipDeleted(ctx, s.syncChanges, userID, friendID)
 
-	s.logger.Info("Friend removed successfully",
+	s.logger.WithContext(ctx).Info("Friend removed successfully",
 		logger.Any("userID", userID),
 		logger.Any("friendID", friendID))
 
 	return nil
 }
 
+// SetUndoScheduler は友達削除の取り消しに使うジョブキューを設定する
+func (s *SocialServiceImpl) SetUndoScheduler(scheduler undo.Scheduler) {
+	s.undoScheduler = scheduler
+}
+
+// SetQuotaChecker は招待の作成時に1日あたりの招待数の上限を確認するQuotaCheckerを設定する
+func (s *SocialServiceImpl) SetQuotaChecker(quotas commonDomain.QuotaChecker) {
+	s.quotas = quotas
+}
+
+// SetChangeRecorder は友達関係の変更を記録するオフライン同期用の変更フィードを設定する
+func (s *SocialServiceImpl) SetChangeRecorder(recorder commonDomain.ChangeRecorder) {
+	s.syncChanges = recorder
+}
+
+// RemoveFriendWithUndo は友達削除を取り消し期間の経過後に実行するよう登録し、取り消し用のトークンを返す
+// ジョブキューが未設定の場合は即時に削除し、nilのトークンを返す
+func (s *SocialServiceImpl) RemoveFriendWithUndo(ctx context.Context, userID, friendID uuid.UUID) (*undo.Token, error) {
+	if s.undoScheduler == nil {
+		return nil, s.RemoveFriend(ctx, userID, friendID)
+	}
+
+	// 友達関係が存在するかチェック
+	areFriends, err := s.friendshipRepo.AreFriends(ctx, userID, friendID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check friendship: %w", err)
+	}
+	if !areFriends {
+		return nil, errors.New("not friends")
+	}
+
+	token := s.undoScheduler.Defer(userID.String(), undo.ActionRemoveFriend, func(ctx context.Context) error {
+		return s.RemoveFriend(ctx, userID, friendID)
+	})
+
+	s.logger.WithContext(ctx).Info("Friend removal scheduled",
+		logger.Any("userID", userID),
+		logger.Any("friendID", friendID),
+		logger.Any("expiresAt", token.ExpiresAt))
+
+	return token, nil
+}
+
 // BlockUser はユーザーをブロックする
+// 既存の友達関係・申請はブロック関係（ブロックした側がRequesterID）に置き換える
 func (s *SocialServiceImpl) BlockUser(ctx context.Context, userID, targetID uuid.UUID) error {
+	if userID == targetID {
+		return errors.New("cannot block yourself")
+	}
+
 	// 既存の関係をチェック
 	existingFriendship, err := s.friendshipRepo.GetFriendship(ctx, userID, targetID)
 	if err != nil {
@@ -235,44 +299,56 @@ func (s *SocialServiceImpl) BlockUser(ctx context.Context, userID, targetID uuid
 	}
 
 	if existingFriendship != nil {
-		// 既存の関係をブロック状態に更新
-		existingFriendship.Block()
-		if err := s.friendshipRepo.UpdateFriendship(ctx, existingFriendship); err != nil {
-			return fmt.Errorf("failed to update friendship to blocked: %w", err)
+		if existingFriendship.IsBlocked() {
+			// 既にどちらかがブロック済み（相手からのブロックは上書きしない）
+			return nil
 		}
-	} else {
-		// 新規ブロック関係を作成
-		friendship := domain.NewFriendship(userID, targetID)
-		friendship.Block()
-		if err := s.friendshipRepo.CreateFriendship(ctx, friendship); err != nil {
-			return fmt.Errorf("failed to create blocked relationship: %w", err)
+		if err := s.friendshipRepo.DeleteFriendship(ctx, userID, targetID); err != nil {
+			return fmt.Errorf("failed to remove existing relationship: %w", err)
 		}
 	}
 
+	// ブロック関係を作成
+	friendship := domain.NewFriendship(userID, targetID)
+	friendship.Block()
+	if err := s.friendshipRepo.CreateFriendship(ctx, friendship); err != nil {
+		return fmt.Errorf("failed to create blocked relationship: %w", err)
+	}
+
 	// イベント発行
 	if err := s.eventPublisher.PublishUserBlocked(ctx, userID, targetID); err != nil {
-		s.logger.Error("Failed to publish user blocked event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish user blocked event", logger.Error(err))
 	}
+	// ブロック関係は友達関係として配信しない
+	syncFriendshipDeleted(ctx, s.syncChanges, userID, targetID)
 
-	s.logger.Info("User blocked successfully",
+	s.logger.WithContext(ctx).Info("User blocked successfully",
 		logger.Any("userID", userID),
 		logger.Any("targetID", targetID))
 
 	return nil
 }
 
-// UnblockUser はブロックを解除する
+// UnblockUser はブロックを解除する（ブロックした本人のみ解除できる）
 func (s *SocialServiceImpl) UnblockUser(ctx context.Context, userID, targetID uuid.UUID) error {
+	friendship, err := s.friendshipRepo.GetFriendship(ctx, userID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to check existing relationship: %w", err)
+	}
+	if friendship == nil || !friendship.IsBlockedBy(userID) {
+		return ErrBlockNotFound
+	}
+
 	// ブロック関係を削除
 	if err := s.friendshipRepo.DeleteFriendship(ctx, userID, targetID); err != nil {
-		s.logger.Error("Failed to unblock user",
+		s.logger.WithContext(ctx).Error("Failed to unblock user",
 			logger.Any("userID", userID),
 			logger.Any("targetID", targetID),
 			logger.Error(err))
 		return fmt.Errorf("failed to unblock user: %w", err)
 	}
 
-	s.logger.Info("User unblocked successfully",
+	s.logger.WithContext(ctx).Info("User unblocked successfully",
 		logger.Any("userID", userID),
 		logger.Any("targetID", targetID))
 
@@ -281,15 +357,20 @@ func (s *SocialServiceImpl) UnblockUser(ctx context.Context, userID, targetID uu
 
 // === 友達一覧・検索 ===
 
-// GetFriends は友達一覧を取得する
-func (s *SocialServiceImpl) GetFriends(ctx context.Context, userID uuid.UUID, pagination commonDomain.Pagination) ([]*FriendWithUserInfo, error) {
-	friendships, err := s.friendshipRepo.GetFriends(ctx, userID, pagination)
+// GetFriends は友達一覧を指定した並び順で取得し、友達の総数とともに返す
+func (s *SocialServiceImpl) GetFriends(ctx context.Context, userID uuid.UUID, sort domain.FriendSort, pagination commonDomain.Pagination) ([]*FriendWithUserInfo, int, error) {
+	friendships, err := s.friendshipRepo.GetFriends(ctx, userID, sort, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get friends: %w", err)
+	}
+
+	total, err := s.friendshipRepo.CountFriends(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get friends: %w", err)
+		return nil, 0, fmt.Errorf("failed to count friends: %w", err)
 	}
 
 	if len(friendships) == 0 {
-		return []*FriendWithUserInfo{}, nil
+		return []*FriendWithUserInfo{}, total, nil
 	}
 
 	// ユーザー情報を一括取得
@@ -304,7 +385,7 @@ func (s *SocialServiceImpl) GetFriends(ctx context.Context, userID uuid.UUID, pa
 
 	userInfoMap, err := s.userValidator.GetUsersInfoBatch(ctx, userIDs)
 	if err != nil {
-		s.logger.Error("Failed to get user info batch", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
 		userInfoMap = make(map[string]*commonDomain.UserInfo)
 	}
 
@@ -322,7 +403,7 @@ func (s *SocialServiceImpl) GetFriends(ctx context.Context, userID uuid.UUID, pa
 		}
 	}
 
-	return result, nil
+	return result, total, nil
 }
 
 // GetPendingRequests は受信した友達申請を取得する
@@ -344,7 +425,7 @@ func (s *SocialServiceImpl) GetPendingRequests(ctx context.Context, userID uuid.
 
 	userInfoMap, err := s.userValidator.GetUsersInfoBatch(ctx, userIDs)
 	if err != nil {
-		s.logger.Error("Failed to get user info batch", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
 		userInfoMap = make(map[string]*commonDomain.UserInfo)
 	}
 
@@ -379,7 +460,7 @@ func (s *SocialServiceImpl) GetSentRequests(ctx context.Context, userID uuid.UUI
 
 	userInfoMap, err := s.userValidator.GetUsersInfoBatch(ctx, userIDs)
 	if err != nil {
-		s.logger.Error("Failed to get user info batch", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
 		userInfoMap = make(map[string]*commonDomain.UserInfo)
 	}
 
@@ -419,7 +500,7 @@ func (s *SocialServiceImpl) GetMutualFriends(ctx context.Context, userID, target
 
 	userInfoMap, err := s.userValidator.GetUsersInfoBatch(ctx, userIDs)
 	if err != nil {
-		s.logger.Error("Failed to get user info batch", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
 		userInfoMap = make(map[string]*commonDomain.UserInfo)
 	}
 
@@ -448,6 +529,21 @@ func (s *SocialServiceImpl) GetMutualFriends(ctx context.Context, userID, target
 
 // CreateInvitation は招待を作成する
 func (s *SocialServiceImpl) CreateInvitation(ctx context.Context, input CreateInvitationInput) (*domain.Invitation, error) {
+	// グループ招待はグループへの招待権限を確認する
+	if input.Type == domain.InvitationTypeGroup && input.TargetID != nil && s.groupGateway != nil {
+		allowed, err := s.groupGateway.CanInviteToGroup(ctx, *input.TargetID, input.InviterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check group permission: %w", err)
+		}
+		if !allowed {
+			return nil, ErrGroupInvitationNotAllowed
+		}
+	}
+
+	if err := s.checkInvitationQuota(ctx, input); err != nil {
+		return nil, err
+	}
+
 	// 招待作成
 	invitation := domain.NewInvitation(input.Type, input.Method, input.InviterID, input.Message, input.ExpiresHours)
 
@@ -456,17 +552,27 @@ func (s *SocialServiceImpl) CreateInvitation(ctx context.Context, input CreateIn
 		invitation.SetTarget(*input.TargetID)
 	}
 
+	// グループに参加するときのロール設定
+	if input.GroupRole != "" {
+		if err := invitation.SetGroupRole(input.GroupRole); err != nil {
+			return nil, err
+		}
+	}
+
 	// 被招待者情報設定
 	if input.InviteeEmail != nil {
 		inviteeInfo := domain.InviteeInfo{
 			Email: *input.InviteeEmail,
 		}
 		invitation.SetInviteeInfo(inviteeInfo)
+		if s.emailGateway != nil {
+			invitation.EnsureCode()
+		}
 	}
 
 	// データベースに保存
 	if err := s.invitationRepo.CreateInvitation(ctx, invitation); err != nil {
-		s.logger.Error("Failed to create invitation",
+		s.logger.WithContext(ctx).Error("Failed to create invitation",
 			logger.Any("invitation", invitation),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to create invitation: %w", err)
@@ -474,15 +580,34 @@ func (s *SocialServiceImpl) CreateInvitation(ctx context.Context, input CreateIn
 
 	// イベント発行
 	if err := s.eventPublisher.PublishInvitationCreated(ctx, invitation); err != nil {
-		s.logger.Error("Failed to publish invitation created event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish invitation created event", logger.Error(err))
+	}
+
+	// 招待メール送信（失敗しても招待作成は成功とし、再送で対応する）
+	if s.emailGateway != nil && invitation.InviteeEmail() != "" {
+		_ = s.sendInvitationEmail(ctx, invitation, time.Now())
 	}
 
-	s.logger.Info("Invitation created successfully",
+	s.logger.WithContext(ctx).Info("Invitation created successfully",
 		logger.Any("invitationID", invitation.ID))
 
 	return invitation, nil
 }
 
+// checkInvitationQuota は招待者（グループ招待の場合はグループも）の1日あたりの招待数が上限に達していないか確認する
+func (s *SocialServiceImpl) checkInvitationQuota(ctx context.Context, input CreateInvitationInput) error {
+	if s.quotas == nil {
+		return nil
+	}
+	if err := s.quotas.CheckQuota(ctx, commonDomain.QuotaScopeUser, input.InviterID.String(), commonDomain.QuotaInvitationsPerDay, 1); err != nil {
+		return err
+	}
+	if input.Type == domain.InvitationTypeGroup && input.TargetID != nil {
+		return s.quotas.CheckQuota(ctx, commonDomain.QuotaScopeGroup, input.TargetID.String(), commonDomain.QuotaInvitationsPerDay, 1)
+	}
+	return nil
+}
+
 // GetInvitation は招待詳細を取得する
 func (s *SocialServiceImpl) GetInvitation(ctx context.Context, invitationID uuid.UUID) (*domain.Invitation, error) {
 	return s.invitationRepo.GetInvitationByID(ctx, invitationID)
@@ -508,6 +633,22 @@ func (s *SocialServiceImpl) AcceptInvitation(ctx context.Context, code string, u
 		return nil, errors.New("invitation is not valid")
 	}
 
+	// 招待者とブロック関係にある場合は受諾できない
+	blocked, err := s.friendshipRepo.IsBlocked(ctx, invitation.InviterID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check block relationship: %w", err)
+	}
+	if blocked {
+		return nil, ErrUserBlocked
+	}
+
+	// グループ招待は先にグループへ参加する（参加できない場合は招待を受諾済みにしない）
+	if invitation.Type == domain.InvitationTypeGroup && s.groupGateway != nil && invitation.TargetID != nil {
+		if err := s.groupGateway.JoinGroupByInvitation(ctx, *invitation.TargetID, userID, invitation.InviterID, invitation.GroupRole()); err != nil {
+			return nil, fmt.Errorf("failed to join group: %w", err)
+		}
+	}
+
 	// 招待を受諾
 	if err := invitation.Accept(); err != nil {
 		return nil, fmt.Errorf("failed to accept invitation: %w", err)
@@ -531,18 +672,18 @@ func (s *SocialServiceImpl) AcceptInvitation(ctx context.Context, code string, u
 		friendship, err := s.SendFriendRequest(ctx, invitation.InviterID, userID, "招待から")
 		if err != nil {
 			// 既に友達の場合などは警告レベル
-			s.logger.Warn("Failed to create friendship from invitation", logger.Error(err))
+			s.logger.WithContext(ctx).Warn("Failed to create friendship from invitation", logger.Error(err))
 		} else {
 			result.Friendship = friendship
 		}
 	case domain.InvitationTypeGroup:
-		// グループメンバー追加（グループモジュールとの連携が必要）
 		result.Message = "グループ招待を受諾しました"
+		result.GroupID = invitation.TargetID
 	}
 
 	// イベント発行
 	if err := s.eventPublisher.PublishInvitationAccepted(ctx, invitation); err != nil {
-		s.logger.Error("Failed to publish invitation accepted event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish invitation accepted event", logger.Error(err))
 	}
 
 	return result, nil
@@ -574,7 +715,7 @@ func (s *SocialServiceImpl) DeclineInvitation(ctx context.Context, invitationID,
 
 	// イベント発行
 	if err := s.eventPublisher.PublishInvitationDeclined(ctx, invitation); err != nil {
-		s.logger.Error("Failed to publish invitation declined event", logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to publish invitation declined event", logger.Error(err))
 	}
 
 	return nil
@@ -635,6 +776,15 @@ func (s *SocialServiceImpl) GenerateInviteURL(ctx context.Context, invitationID
 	return s.urlGateway.GenerateInviteURL(ctx, invitationID, invitation.Code)
 }
 
+// GenerateInviteLinks は招待のWeb URLとモバイルアプリ用のリンクを生成する
+func (s *SocialServiceImpl) GenerateInviteLinks(ctx context.Context, invitation *domain.Invitation) (*domain.InviteLinks, error) {
+	if invitation.Code == "" {
+		return nil, errors.New("invitation does not have a code")
+	}
+
+	return s.urlGateway.GenerateInviteLinks(ctx, invitation.ID, invitation.Code)
+}
+
 // ValidateInviteCode は招待コードの妥当性を確認する
 func (s *SocialServiceImpl) ValidateInviteCode(ctx context.Context, code string) (*domain.Invitation, error) {
 	return s.invitationRepo.GetInvitationByCode(ctx, code)
@@ -674,3 +824,32 @@ func (s *SocialServiceImpl) GetRelationship(ctx context.Context, userID, targetI
 
 	return relationship, nil
 }
+
+// === 変更フィード ===
+
+// friendshipEntityID は変更フィードで友達関係を識別するIDを返す（申請の向きによらず同じ値になる）
+func friendshipEntityID(userID1, userID2 uuid.UUID) string {
+	a, b := userID1.String(), userID2.String()
+	if a > b {
+		a, b = b, a
+	}
+	return a + ":" + b
+}
+
+// syncFriendshipUpserted は友達申請・友達関係を両方のユーザーの変更フィードに記録する
+func syncFriendshipUpserted(ctx context.Context, recorder commonDomain.ChangeRecorder, friendship *domain.Friendship) {
+	if recorder == nil {
+		return
+	}
+	recorder.RecordUpsert(ctx, commonDomain.SyncEntityFriendship,
+		friendshipEntityID(friendship.RequesterID, friendship.AddresseeID),
+		[]string{friendship.RequesterID.String(), friendship.AddresseeID.String()}, friendship)
+}
+
+// syncFriendshipDeleted は友達申請・友達関係の削除を変更フィードに記録する
+func syncFriendshipDeleted(ctx context.Context, recorder commonDomain.ChangeRecorder, userID1, userID2 uuid.UUID) {
+	if recorder == nil {
+		return
+	}
+	recorder.RecordDelete(ctx, commonDomain.SyncEntityFriendship, friendshipEntityID(userID1, userID2))
+}
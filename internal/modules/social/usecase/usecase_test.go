@@ -9,8 +9,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/social/domain"
 	"github.com/hryt430/Yotei+/internal/modules/social/usecase/mocks"
 	"github.com/hryt430/Yotei+/pkg/logger"
@@ -506,6 +508,44 @@ func TestSocialService_RemoveFriend(t *testing.T) {
 	}
 }
 
+func TestSocialService_RemoveFriendWithUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImpl(
+		mockFriendshipRepo,
+		mocks.NewMockInvitationRepository(ctrl),
+		mocks.NewMockUserValidator(ctrl),
+		mockEventPublisher,
+		mocks.NewMockURLGateway(ctrl),
+		&mockLogger,
+	)
+	queue := undo.NewQueue(time.Hour, mockLogger)
+	service.(*SocialServiceImpl).SetUndoScheduler(queue)
+
+	userID, friendID := uuid.New(), uuid.New()
+	mockFriendshipRepo.EXPECT().AreFriends(gomock.Any(), userID, friendID).Return(true, nil)
+
+	token, err := service.RemoveFriendWithUndo(context.Background(), userID, friendID)
+
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, undo.ActionRemoveFriend, token.Action)
+
+	// The friendship is removed only once the deferred job runs
+	mockFriendshipRepo.EXPECT().AreFriends(gomock.Any(), userID, friendID).Return(true, nil)
+	mockFriendshipRepo.EXPECT().DeleteFriendship(gomock.Any(), userID, friendID).Return(nil)
+	mockEventPublisher.EXPECT().PublishFriendRemoved(gomock.Any(), userID, friendID).Return(nil)
+	queue.Flush()
+}
 func TestSocialService_BlockUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -560,7 +600,7 @@ func TestSocialService_BlockUser(t *testing.T) {
 			expectedError: "",
 		},
 		{
-			name:     "update existing friendship",
+			name:     "replace existing friendship",
 			userID:   uuid.New(),
 			targetID: uuid.New(),
 			setupMocks: func() {
@@ -573,7 +613,11 @@ func TestSocialService_BlockUser(t *testing.T) {
 					Return(existingFriendship, nil)
 
 				mockFriendshipRepo.EXPECT().
-					UpdateFriendship(gomock.Any(), gomock.Any()).
+					DeleteFriendship(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				mockFriendshipRepo.EXPECT().
+					CreateFriendship(gomock.Any(), gomock.Any()).
 					Do(func(ctx context.Context, friendship *domain.Friendship) {
 						assert.Equal(t, domain.FriendshipStatusBlocked, friendship.Status)
 						assert.NotNil(t, friendship.BlockedAt)
@@ -586,6 +630,22 @@ func TestSocialService_BlockUser(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "already blocked by target",
+			userID:   uuid.New(),
+			targetID: uuid.New(),
+			setupMocks: func() {
+				existingFriendship := &domain.Friendship{
+					RequesterID: uuid.New(),
+					Status:      domain.FriendshipStatusBlocked,
+				}
+
+				mockFriendshipRepo.EXPECT().
+					GetFriendship(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(existingFriendship, nil)
+			},
+			expectedError: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -635,6 +695,7 @@ func TestSocialService_GetFriends(t *testing.T) {
 		setupMocks    func(userID uuid.UUID)
 		expectedError string
 		expectedCount int
+		expectedTotal int
 	}{
 		{
 			name:       "successful get friends",
@@ -657,9 +718,13 @@ func TestSocialService_GetFriends(t *testing.T) {
 				}
 
 				mockFriendshipRepo.EXPECT().
-					GetFriends(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetFriends(gomock.Any(), userID, domain.FriendSortUsername, gomock.Any()).
 					Return(friendships, nil)
 
+				mockFriendshipRepo.EXPECT().
+					CountFriends(gomock.Any(), userID).
+					Return(11, nil)
+
 				mockUserValidator.EXPECT().
 					GetUsersInfoBatch(gomock.Any(), []string{friendID.String()}).
 					Return(map[string]*commonDomain.UserInfo{
@@ -668,6 +733,7 @@ func TestSocialService_GetFriends(t *testing.T) {
 			},
 			expectedError: "",
 			expectedCount: 1,
+			expectedTotal: 11,
 		},
 		{
 			name:       "empty result",
@@ -675,8 +741,12 @@ func TestSocialService_GetFriends(t *testing.T) {
 			pagination: commonDomain.Pagination{Page: 1, PageSize: 10},
 			setupMocks: func(userID uuid.UUID) {
 				mockFriendshipRepo.EXPECT().
-					GetFriends(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetFriends(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*domain.Friendship{}, nil)
+
+				mockFriendshipRepo.EXPECT().
+					CountFriends(gomock.Any(), userID).
+					Return(0, nil)
 			},
 			expectedError: "",
 			expectedCount: 0,
@@ -687,7 +757,7 @@ func TestSocialService_GetFriends(t *testing.T) {
 			pagination: commonDomain.Pagination{Page: 1, PageSize: 10},
 			setupMocks: func(userID uuid.UUID) {
 				mockFriendshipRepo.EXPECT().
-					GetFriends(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetFriends(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("database error"))
 			},
 			expectedError: "failed to get friends",
@@ -699,7 +769,7 @@ func TestSocialService_GetFriends(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks(tt.userID)
 
-			result, err := service.GetFriends(context.Background(), tt.userID, tt.pagination)
+			result, total, err := service.GetFriends(context.Background(), tt.userID, domain.FriendSortUsername, tt.pagination)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -708,6 +778,7 @@ func TestSocialService_GetFriends(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Len(t, result, tt.expectedCount)
+				assert.Equal(t, tt.expectedTotal, total)
 			}
 		})
 	}
@@ -861,6 +932,10 @@ func TestSocialService_AcceptInvitation(t *testing.T) {
 					GetInvitationByCode(gomock.Any(), "TEST123456").
 					Return(invitation, nil)
 
+				mockFriendshipRepo.EXPECT().
+					IsBlocked(gomock.Any(), invitation.InviterID, gomock.Any()).
+					Return(false, nil)
+
 				mockInvitationRepo.EXPECT().
 					UpdateInvitation(gomock.Any(), gomock.Any()).
 					Do(func(ctx context.Context, inv *domain.Invitation) {
@@ -920,6 +995,29 @@ func TestSocialService_AcceptInvitation(t *testing.T) {
 			},
 			expectedError: "invitation is not valid",
 		},
+		{
+			name:   "inviter blocked",
+			code:   "BLOCKED123",
+			userID: uuid.New(),
+			setupMocks: func() {
+				invitation := &domain.Invitation{
+					ID:        uuid.New(),
+					Type:      domain.InvitationTypeFriend,
+					InviterID: uuid.New(),
+					Status:    domain.InvitationStatusPending,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}
+
+				mockInvitationRepo.EXPECT().
+					GetInvitationByCode(gomock.Any(), "BLOCKED123").
+					Return(invitation, nil)
+
+				mockFriendshipRepo.EXPECT().
+					IsBlocked(gomock.Any(), invitation.InviterID, gomock.Any()).
+					Return(true, nil)
+			},
+			expectedError: ErrUserBlocked.Error(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1152,3 +1250,1156 @@ func TestSocialService_GenerateInviteURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSocialService_GenerateInviteLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockURLGateway := mocks.NewMockURLGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error",
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImpl(
+		mocks.NewMockFriendshipRepository(ctrl),
+		mocks.NewMockInvitationRepository(ctrl),
+		mocks.NewMockUserValidator(ctrl),
+		mocks.NewMockSocialEventPublisher(ctrl),
+		mockURLGateway,
+		&mockLogger,
+	)
+
+	t.Run("returns web and app links", func(t *testing.T) {
+		invitation := &domain.Invitation{ID: uuid.New(), Code: "TEST123456"}
+		expected := &domain.InviteLinks{
+			URL:           "https://example.com/invite/TEST123456",
+			DeepLink:      "yoteiplus://invite/TEST123456",
+			UniversalLink: "https://app.example.com/invite/TEST123456",
+		}
+		mockURLGateway.EXPECT().
+			GenerateInviteLinks(gomock.Any(), invitation.ID, "TEST123456").
+			Return(expected, nil)
+
+		links, err := service.GenerateInviteLinks(context.Background(), invitation)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, links)
+	})
+
+	t.Run("invitation without code", func(t *testing.T) {
+		links, err := service.GenerateInviteLinks(context.Background(), &domain.Invitation{ID: uuid.New()})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invitation does not have a code")
+		assert.Nil(t, links)
+	})
+}
+func TestSocialService_CreateInvitation_SendsEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockUserValidator := mocks.NewMockUserValidator(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockURLGateway := mocks.NewMockURLGateway(ctrl)
+	mockEmailGateway := mocks.NewMockInvitationEmailGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImplWithEmail(
+		mockFriendshipRepo,
+		mockInvitationRepo,
+		mockUserValidator,
+		mockEventPublisher,
+		mockURLGateway,
+		mockEmailGateway,
+		DefaultInvitationEmailCooldown,
+		&mockLogger,
+	)
+
+	inviterID := uuid.New()
+	email := "invitee@example.com"
+
+	t.Run("email is sent and recorded", func(t *testing.T) {
+		mockInvitationRepo.EXPECT().CreateInvitation(gomock.Any(), gomock.Any()).Return(nil)
+		mockEventPublisher.EXPECT().PublishInvitationCreated(gomock.Any(), gomock.Any()).Return(nil)
+		mockURLGateway.EXPECT().
+			GenerateInviteURL(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return("https://example.com/invite/abc", nil)
+		mockUserValidator.EXPECT().
+			GetUserInfo(gomock.Any(), inviterID.String()).
+			Return(&commonDomain.UserInfo{ID: inviterID.String(), Username: "alice"}, nil)
+		mockEmailGateway.EXPECT().
+			SendInvitationEmail(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, email domain.InvitationEmail) {
+				assert.Equal(t, "invitee@example.com", email.To)
+				assert.Equal(t, "alice", email.InviterName)
+				assert.Equal(t, "https://example.com/invite/abc", email.InviteURL)
+				assert.NotEmpty(t, email.Code)
+			}).
+			Return(nil)
+		mockInvitationRepo.EXPECT().UpdateInvitation(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeFriend,
+			Method:       domain.MethodInApp,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+			InviteeEmail: &email,
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.Code)
+		assert.Equal(t, 1, result.EmailSendCount)
+		assert.NotNil(t, result.EmailSentAt)
+	})
+
+	t.Run("email failure does not fail creation", func(t *testing.T) {
+		mockInvitationRepo.EXPECT().CreateInvitation(gomock.Any(), gomock.Any()).Return(nil)
+		mockEventPublisher.EXPECT().PublishInvitationCreated(gomock.Any(), gomock.Any()).Return(nil)
+		mockURLGateway.EXPECT().
+			GenerateInviteURL(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return("https://example.com/invite/abc", nil)
+		mockUserValidator.EXPECT().
+			GetUserInfo(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("user service down"))
+		mockEmailGateway.EXPECT().
+			SendInvitationEmail(gomock.Any(), gomock.Any()).
+			Return(errors.New("smtp unavailable"))
+
+		result, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeFriend,
+			Method:       domain.MethodCode,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+			InviteeEmail: &email,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.EmailSendCount)
+		assert.Nil(t, result.EmailSentAt)
+	})
+}
+
+func TestSocialService_GroupInvitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockGroupGateway := mocks.NewMockGroupMembershipGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImpl(
+		mockFriendshipRepo,
+		mockInvitationRepo,
+		mocks.NewMockUserValidator(ctrl),
+		mockEventPublisher,
+		mocks.NewMockURLGateway(ctrl),
+		&mockLogger,
+	).(*SocialServiceImpl)
+	service.SetGroupMembershipGateway(mockGroupGateway)
+
+	inviterID, groupID, userID := uuid.New(), uuid.New(), uuid.New()
+	email := "newcomer@example.com"
+
+	newGroupInvitation := func() *domain.Invitation {
+		invitation := domain.NewInvitation(domain.InvitationTypeGroup, domain.MethodCode, inviterID, "", 24)
+		invitation.SetTarget(groupID)
+		invitation.SetInviteeInfo(domain.InviteeInfo{Email: email})
+		return invitation
+	}
+
+	t.Run("create requires group invite permission", func(t *testing.T) {
+		mockGroupGateway.EXPECT().
+			CanInviteToGroup(gomock.Any(), groupID, inviterID).
+			Return(false, nil)
+
+		_, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeGroup,
+			Method:       domain.MethodCode,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+			InviteeEmail: &email,
+			TargetID:     &groupID,
+		})
+
+		assert.ErrorIs(t, err, ErrGroupInvitationNotAllowed)
+	})
+
+	t.Run("accept joins the group", func(t *testing.T) {
+		invitation := newGroupInvitation()
+		mockInvitationRepo.EXPECT().GetInvitationByCode(gomock.Any(), invitation.Code).Return(invitation, nil)
+		mockFriendshipRepo.EXPECT().IsBlocked(gomock.Any(), inviterID, userID).Return(false, nil)
+		mockGroupGateway.EXPECT().JoinGroupByInvitation(gomock.Any(), groupID, userID, inviterID, domain.GroupRoleMember).Return(nil)
+		mockInvitationRepo.EXPECT().UpdateInvitation(gomock.Any(), invitation).Return(nil)
+		mockEventPublisher.EXPECT().PublishInvitationAccepted(gomock.Any(), invitation).Return(nil)
+
+		result, err := service.AcceptInvitation(context.Background(), invitation.Code, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &groupID, result.GroupID)
+		assert.Equal(t, domain.InvitationStatusAccepted, invitation.Status)
+	})
+
+	t.Run("accept fails when joining the group fails", func(t *testing.T) {
+		invitation := newGroupInvitation()
+		mockInvitationRepo.EXPECT().GetInvitationByCode(gomock.Any(), invitation.Code).Return(invitation, nil)
+		mockFriendshipRepo.EXPECT().IsBlocked(gomock.Any(), inviterID, userID).Return(false, nil)
+		mockGroupGateway.EXPECT().
+			JoinGroupByInvitation(gomock.Any(), groupID, userID, inviterID, domain.GroupRoleMember).
+			Return(errors.New("insufficient permissions"))
+
+		_, err := service.AcceptInvitation(context.Background(), invitation.Code, userID)
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.InvitationStatusPending, invitation.Status)
+	})
+
+	t.Run("guest invitation joins the group as a guest", func(t *testing.T) {
+		mockGroupGateway.EXPECT().CanInviteToGroup(gomock.Any(), groupID, inviterID).Return(true, nil)
+		mockInvitationRepo.EXPECT().CreateInvitation(gomock.Any(), gomock.Any()).Return(nil)
+		mockEventPublisher.EXPECT().PublishInvitationCreated(gomock.Any(), gomock.Any()).Return(nil)
+
+		invitation, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeGroup,
+			Method:       domain.MethodCode,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+			TargetID:     &groupID,
+			GroupRole:    domain.GroupRoleGuest,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.GroupRoleGuest, invitation.GroupRole())
+
+		mockInvitationRepo.EXPECT().GetInvitationByCode(gomock.Any(), invitation.Code).Return(invitation, nil)
+		mockFriendshipRepo.EXPECT().IsBlocked(gomock.Any(), inviterID, userID).Return(false, nil)
+		mockGroupGateway.EXPECT().JoinGroupByInvitation(gomock.Any(), groupID, userID, inviterID, domain.GroupRoleGuest).Return(nil)
+		mockInvitationRepo.EXPECT().UpdateInvitation(gomock.Any(), invitation).Return(nil)
+		mockEventPublisher.EXPECT().PublishInvitationAccepted(gomock.Any(), invitation).Return(nil)
+
+		_, err = service.AcceptInvitation(context.Background(), invitation.Code, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("create rejects roles other than member and guest", func(t *testing.T) {
+		mockGroupGateway.EXPECT().CanInviteToGroup(gomock.Any(), groupID, inviterID).Return(true, nil)
+
+		_, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeGroup,
+			Method:       domain.MethodCode,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+			TargetID:     &groupID,
+			GroupRole:    "ADMIN",
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidGroupRole)
+	})
+
+	t.Run("registration claims email invitations", func(t *testing.T) {
+		groupInvitation := newGroupInvitation()
+		friendInvitation := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodCode, inviterID, "", 24)
+		friendInvitation.SetInviteeInfo(domain.InviteeInfo{Email: email})
+
+		mockInvitationRepo.EXPECT().
+			GetPendingInvitationsByEmail(gomock.Any(), email).
+			Return([]*domain.Invitation{groupInvitation, friendInvitation}, nil)
+		mockGroupGateway.EXPECT().JoinGroupByInvitation(gomock.Any(), groupID, userID, inviterID, domain.GroupRoleMember).Return(nil)
+		mockInvitationRepo.EXPECT().UpdateInvitation(gomock.Any(), groupInvitation).Return(nil)
+		mockInvitationRepo.EXPECT().UpdateInvitation(gomock.Any(), friendInvitation).Return(nil)
+		mockEventPublisher.EXPECT().PublishInvitationAccepted(gomock.Any(), groupInvitation).Return(nil)
+
+		claimed, err := service.ClaimEmailInvitations(context.Background(), userID, " "+email+" ")
+
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 2)
+		assert.Equal(t, domain.InvitationStatusAccepted, groupInvitation.Status)
+		assert.Equal(t, &userID, groupInvitation.InviteeID)
+		// 友達招待は紐付けのみ行い、受諾はユーザーに任せる
+		assert.Equal(t, domain.InvitationStatusPending, friendInvitation.Status)
+		assert.Equal(t, &userID, friendInvitation.InviteeID)
+	})
+
+	t.Run("group join failure still links the invitation", func(t *testing.T) {
+		invitation := newGroupInvitation()
+		mockInvitationRepo.EXPECT().
+			GetPendingInvitationsByEmail(gomock.Any(), email).
+			Return([]*domain.Invitation{invitation}, nil)
+		mockGroupGateway.EXPECT().
+			JoinGroupByInvitation(gomock.Any(), groupID, userID, inviterID, domain.GroupRoleMember).
+			Return(errors.New("group deleted"))
+		mockInvitationRepo.EXPECT().UpdateInvitation(gomock.Any(), invitation).Return(nil)
+
+		claimed, err := service.ClaimEmailInvitations(context.Background(), userID, email)
+
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Equal(t, domain.InvitationStatusPending, invitation.Status)
+		assert.Equal(t, &userID, invitation.InviteeID)
+	})
+}
+
+// scopedQuota は指定した対象の上限に達しているQuotaChecker（確認した対象を記録する）
+type scopedQuota struct {
+	exceeded string
+	checked  []string
+}
+
+func (q *scopedQuota) CheckQuota(ctx context.Context, scope, subjectID, resource string, amount int64) error {
+	q.checked = append(q.checked, scope+":"+subjectID+":"+resource)
+	if scope == q.exceeded {
+		return &commonDomain.QuotaExceededError{Scope: scope, Resource: resource, Limit: 50, Used: 50}
+	}
+	return nil
+}
+
+func TestSocialService_CreateInvitation_Quota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroupGateway := mocks.NewMockGroupMembershipGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+	service := NewSocialServiceImpl(
+		mocks.NewMockFriendshipRepository(ctrl),
+		mocks.NewMockInvitationRepository(ctrl),
+		mocks.NewMockUserValidator(ctrl),
+		mocks.NewMockSocialEventPublisher(ctrl),
+		mocks.NewMockURLGateway(ctrl),
+		&mockLogger,
+	).(*SocialServiceImpl)
+	service.SetGroupMembershipGateway(mockGroupGateway)
+
+	inviterID, groupID := uuid.New(), uuid.New()
+
+	t.Run("inviter daily limit rejects the invitation", func(t *testing.T) {
+		quota := &scopedQuota{exceeded: commonDomain.QuotaScopeUser}
+		service.SetQuotaChecker(quota)
+
+		_, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeFriend,
+			Method:       domain.MethodCode,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+		})
+
+		assert.ErrorIs(t, err, commonDomain.ErrQuotaExceeded)
+		assert.Equal(t, []string{"user:" + inviterID.String() + ":invitations_per_day"}, quota.checked)
+	})
+
+	t.Run("group daily limit applies to group invitations", func(t *testing.T) {
+		quota := &scopedQuota{exceeded: commonDomain.QuotaScopeGroup}
+		service.SetQuotaChecker(quota)
+		mockGroupGateway.EXPECT().CanInviteToGroup(gomock.Any(), groupID, inviterID).Return(true, nil)
+
+		_, err := service.CreateInvitation(context.Background(), CreateInvitationInput{
+			Type:         domain.InvitationTypeGroup,
+			Method:       domain.MethodCode,
+			InviterID:    inviterID,
+			ExpiresHours: 24,
+			TargetID:     &groupID,
+		})
+
+		assert.ErrorIs(t, err, commonDomain.ErrQuotaExceeded)
+		assert.Equal(t, []string{
+			"user:" + inviterID.String() + ":invitations_per_day",
+			"group:" + groupID.String() + ":invitations_per_day",
+		}, quota.checked)
+	})
+}
+
+func TestSocialService_ResendInvitationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockUserValidator := mocks.NewMockUserValidator(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockURLGateway := mocks.NewMockURLGateway(ctrl)
+	mockEmailGateway := mocks.NewMockInvitationEmailGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImplWithEmail(
+		mockFriendshipRepo,
+		mockInvitationRepo,
+		mockUserValidator,
+		mockEventPublisher,
+		mockURLGateway,
+		mockEmailGateway,
+		10*time.Minute,
+		&mockLogger,
+	)
+
+	inviterID := uuid.New()
+	newInvitation := func(sentAgo time.Duration) *domain.Invitation {
+		invitation := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodCode, inviterID, "Join us!", 24)
+		invitation.SetInviteeInfo(domain.InviteeInfo{Email: "invitee@example.com"})
+		invitation.RecordEmailSent(time.Now().Add(-sentAgo))
+		return invitation
+	}
+
+	tests := []struct {
+		name          string
+		inviterID     uuid.UUID
+		setupMocks    func()
+		expectedError error
+	}{
+		{
+			name:      "successful resend after cooldown",
+			inviterID: inviterID,
+			setupMocks: func() {
+				mockInvitationRepo.EXPECT().
+					GetInvitationByID(gomock.Any(), gomock.Any()).
+					Return(newInvitation(11*time.Minute), nil)
+				mockURLGateway.EXPECT().
+					GenerateInviteURL(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("https://example.com/invite/abc", nil)
+				mockUserValidator.EXPECT().
+					GetUserInfo(gomock.Any(), gomock.Any()).
+					Return(&commonDomain.UserInfo{Username: "alice"}, nil)
+				mockEmailGateway.EXPECT().SendInvitationEmail(gomock.Any(), gomock.Any()).Return(nil)
+				mockInvitationRepo.EXPECT().
+					UpdateInvitation(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, invitation *domain.Invitation) {
+						assert.Equal(t, 2, invitation.EmailSendCount)
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:      "within cooldown",
+			inviterID: inviterID,
+			setupMocks: func() {
+				mockInvitationRepo.EXPECT().
+					GetInvitationByID(gomock.Any(), gomock.Any()).
+					Return(newInvitation(time.Minute), nil)
+			},
+			expectedError: ErrEmailResendCooldown,
+		},
+		{
+			name:      "not the inviter",
+			inviterID: uuid.New(),
+			setupMocks: func() {
+				mockInvitationRepo.EXPECT().
+					GetInvitationByID(gomock.Any(), gomock.Any()).
+					Return(newInvitation(time.Hour), nil)
+			},
+			expectedError: ErrNotInvitationOwner,
+		},
+		{
+			name:      "invitation not found",
+			inviterID: inviterID,
+			setupMocks: func() {
+				mockInvitationRepo.EXPECT().
+					GetInvitationByID(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			expectedError: ErrInvitationNotFound,
+		},
+		{
+			name:      "undeliverable invitation",
+			inviterID: inviterID,
+			setupMocks: func() {
+				invitation := newInvitation(time.Hour)
+				_ = invitation.MarkUndeliverable("550 User unknown")
+				mockInvitationRepo.EXPECT().
+					GetInvitationByID(gomock.Any(), gomock.Any()).
+					Return(invitation, nil)
+			},
+			expectedError: domain.ErrInvalidInvitationStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			result, err := service.ResendInvitationEmail(context.Background(), uuid.New(), tt.inviterID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+
+	t.Run("cooldown error reports retry after", func(t *testing.T) {
+		mockInvitationRepo.EXPECT().
+			GetInvitationByID(gomock.Any(), gomock.Any()).
+			Return(newInvitation(4*time.Minute), nil)
+
+		_, err := service.ResendInvitationEmail(context.Background(), uuid.New(), inviterID)
+
+		var cooldownErr *EmailCooldownError
+		assert.True(t, errors.As(err, &cooldownErr))
+		assert.InDelta(t, (6 * time.Minute).Seconds(), cooldownErr.RetryAfter.Seconds(), 5)
+	})
+}
+
+func TestSocialService_HandleInvitationEmailBounce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockUserValidator := mocks.NewMockUserValidator(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockURLGateway := mocks.NewMockURLGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImpl(
+		mockFriendshipRepo,
+		mockInvitationRepo,
+		mockUserValidator,
+		mockEventPublisher,
+		mockURLGateway,
+		&mockLogger,
+	)
+
+	newInvitation := func() *domain.Invitation {
+		invitation := domain.NewInvitation(domain.InvitationTypeFriend, domain.MethodCode, uuid.New(), "Join us!", 24)
+		invitation.SetInviteeInfo(domain.InviteeInfo{Email: "invitee@example.com"})
+		return invitation
+	}
+
+	t.Run("marks invitation as undeliverable", func(t *testing.T) {
+		mockInvitationRepo.EXPECT().GetInvitationByID(gomock.Any(), gomock.Any()).Return(newInvitation(), nil)
+		mockInvitationRepo.EXPECT().
+			UpdateInvitation(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, invitation *domain.Invitation) {
+				assert.Equal(t, domain.InvitationStatusUndeliverable, invitation.Status)
+				assert.Equal(t, "550 User unknown", invitation.BounceReason)
+			}).
+			Return(nil)
+
+		err := service.HandleInvitationEmailBounce(context.Background(), uuid.New(), "Invitee@Example.com", "550 User unknown")
+		assert.NoError(t, err)
+	})
+
+	t.Run("email mismatch is treated as not found", func(t *testing.T) {
+		mockInvitationRepo.EXPECT().GetInvitationByID(gomock.Any(), gomock.Any()).Return(newInvitation(), nil)
+
+		err := service.HandleInvitationEmailBounce(context.Background(), uuid.New(), "other@example.com", "550 User unknown")
+		assert.ErrorIs(t, err, ErrInvitationNotFound)
+	})
+
+	t.Run("accepted invitation is left unchanged", func(t *testing.T) {
+		invitation := newInvitation()
+		_ = invitation.Accept()
+		mockInvitationRepo.EXPECT().GetInvitationByID(gomock.Any(), gomock.Any()).Return(invitation, nil)
+
+		err := service.HandleInvitationEmailBounce(context.Background(), uuid.New(), "invitee@example.com", "550 User unknown")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.InvitationStatusAccepted, invitation.Status)
+	})
+}
+
+func TestCleanupService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	policy := CleanupPolicy{
+		FriendRequestTTL: 30 * 24 * time.Hour,
+		ReminderLead:     7 * 24 * time.Hour,
+		BatchSize:        100,
+	}
+	service := NewCleanupService(mockFriendshipRepo, mockInvitationRepo, mockEventPublisher, policy, &mockLogger)
+	now := time.Now()
+
+	newPending := func(age time.Duration, remindedAgo *time.Duration) *domain.Friendship {
+		friendship := domain.NewFriendship(uuid.New(), uuid.New())
+		friendship.CreatedAt = now.Add(-age)
+		if remindedAgo != nil {
+			friendship.MarkReminderSent(now.Add(-*remindedAgo))
+		}
+		return friendship
+	}
+
+	t.Run("expires invitations, reminds and deletes stale requests", func(t *testing.T) {
+		lead := policy.ReminderLead
+		toRemind := newPending(25*24*time.Hour, nil)
+		toDelete := newPending(31*24*time.Hour, &lead)
+
+		mockInvitationRepo.EXPECT().MarkExpiredInvitations(gomock.Any()).Return(int64(3), nil)
+		mockFriendshipRepo.EXPECT().
+			GetStalePendingRequests(gomock.Any(), now.Add(-23*24*time.Hour), 100).
+			Return([]*domain.Friendship{toRemind, toDelete}, nil)
+		mockEventPublisher.EXPECT().
+			PublishFriendRequestReminder(gomock.Any(), toRemind, now.Add(lead)).
+			Return(nil)
+		mockFriendshipRepo.EXPECT().MarkReminderSent(gomock.Any(), toRemind.ID, now).Return(nil)
+		mockFriendshipRepo.EXPECT().DeletePendingRequest(gomock.Any(), toDelete.ID).Return(true, nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &CleanupResult{ExpiredInvitations: 3, RemindedRequests: 1, DeletedRequests: 1}, result)
+		assert.NotNil(t, toRemind.ReminderSentAt)
+	})
+
+	t.Run("failed reminder is not recorded and processing continues", func(t *testing.T) {
+		lead := policy.ReminderLead
+		toRemind := newPending(25*24*time.Hour, nil)
+		toDelete := newPending(31*24*time.Hour, &lead)
+
+		mockInvitationRepo.EXPECT().MarkExpiredInvitations(gomock.Any()).Return(int64(0), nil)
+		mockFriendshipRepo.EXPECT().
+			GetStalePendingRequests(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*domain.Friendship{toRemind, toDelete}, nil)
+		mockEventPublisher.EXPECT().
+			PublishFriendRequestReminder(gomock.Any(), toRemind, gomock.Any()).
+			Return(errors.New("publish failed"))
+		// 取得後に承認された申請は削除されない
+		mockFriendshipRepo.EXPECT().DeletePendingRequest(gomock.Any(), toDelete.ID).Return(false, nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &CleanupResult{}, result)
+	})
+
+	t.Run("invitation expiry failure aborts the run", func(t *testing.T) {
+		mockInvitationRepo.EXPECT().MarkExpiredInvitations(gomock.Any()).Return(int64(0), errors.New("db error"))
+
+		result, err := service.Run(context.Background(), now)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSocialService_UnblockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockUserValidator := mocks.NewMockUserValidator(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockURLGateway := mocks.NewMockURLGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImpl(
+		mockFriendshipRepo,
+		mockInvitationRepo,
+		mockUserValidator,
+		mockEventPublisher,
+		mockURLGateway,
+		&mockLogger,
+	)
+
+	t.Run("blocker can unblock", func(t *testing.T) {
+		userID, targetID := uuid.New(), uuid.New()
+		block := domain.NewFriendship(userID, targetID)
+		block.Block()
+
+		mockFriendshipRepo.EXPECT().GetFriendship(gomock.Any(), userID, targetID).Return(block, nil)
+		mockFriendshipRepo.EXPECT().DeleteFriendship(gomock.Any(), userID, targetID).Return(nil)
+
+		assert.NoError(t, service.UnblockUser(context.Background(), userID, targetID))
+	})
+
+	t.Run("blocked user cannot remove the block", func(t *testing.T) {
+		userID, targetID := uuid.New(), uuid.New()
+		block := domain.NewFriendship(targetID, userID)
+		block.Block()
+
+		mockFriendshipRepo.EXPECT().GetFriendship(gomock.Any(), userID, targetID).Return(block, nil)
+
+		err := service.UnblockUser(context.Background(), userID, targetID)
+		assert.ErrorIs(t, err, ErrBlockNotFound)
+	})
+
+	t.Run("accepted friendship is not removed", func(t *testing.T) {
+		userID, targetID := uuid.New(), uuid.New()
+		friendship := domain.NewFriendship(userID, targetID)
+		friendship.Accept()
+
+		mockFriendshipRepo.EXPECT().GetFriendship(gomock.Any(), userID, targetID).Return(friendship, nil)
+
+		err := service.UnblockUser(context.Background(), userID, targetID)
+		assert.ErrorIs(t, err, ErrBlockNotFound)
+	})
+}
+
+func TestSocialService_GetBlockedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockUserValidator := mocks.NewMockUserValidator(ctrl)
+	mockEventPublisher := mocks.NewMockSocialEventPublisher(ctrl)
+	mockURLGateway := mocks.NewMockURLGateway(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewSocialServiceImpl(
+		mockFriendshipRepo,
+		mockInvitationRepo,
+		mockUserValidator,
+		mockEventPublisher,
+		mockURLGateway,
+		&mockLogger,
+	)
+
+	userID, targetID := uuid.New(), uuid.New()
+	block := domain.NewFriendship(userID, targetID)
+	block.Block()
+	pagination := commonDomain.Pagination{Page: 1, PageSize: 20}
+
+	mockFriendshipRepo.EXPECT().GetBlockedUsers(gomock.Any(), userID, pagination).Return([]*domain.Friendship{block}, nil)
+	mockUserValidator.EXPECT().
+		GetUsersInfoBatch(gomock.Any(), []string{targetID.String()}).
+		Return(map[string]*commonDomain.UserInfo{
+			targetID.String(): {ID: targetID.String(), Username: "blocked"},
+		}, nil)
+
+	blocked, err := service.GetBlockedUsers(context.Background(), userID, pagination)
+
+	assert.NoError(t, err)
+	if assert.Len(t, blocked, 1) {
+		assert.Equal(t, "blocked", blocked[0].UserInfo.Username)
+	}
+}
+
+func TestPresenceService_StatusChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockPresenceRepo := mocks.NewMockPresenceRepository(ctrl)
+	mockNotifier := mocks.NewMockPresenceNotifier(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewPresenceService(mockFriendshipRepo, mockPresenceRepo, mockNotifier, time.Minute, &mockLogger)
+	ctx := context.Background()
+	userID := uuid.New()
+	audience := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("connect publishes online to friends and group members", func(t *testing.T) {
+		mockPresenceRepo.EXPECT().
+			SetStatus(gomock.Any(), userID, domain.PresenceStatusOnline, time.Minute, gomock.Any()).
+			Return(domain.PresenceStatusOffline, nil)
+		mockFriendshipRepo.EXPECT().GetPresenceAudience(gomock.Any(), userID).Return(audience, nil)
+		mockNotifier.EXPECT().
+			NotifyPresenceChanged(gomock.Any(), audience, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, recipients []uuid.UUID, presence *domain.Presence) error {
+				assert.Equal(t, userID, presence.UserID)
+				assert.Equal(t, domain.PresenceStatusOnline, presence.Status)
+				assert.NotNil(t, presence.LastSeenAt)
+				return nil
+			})
+
+		assert.NoError(t, service.Connected(ctx, userID.String()))
+	})
+
+	t.Run("heartbeat only refreshes while alive", func(t *testing.T) {
+		mockPresenceRepo.EXPECT().Refresh(gomock.Any(), userID, time.Minute, gomock.Any()).Return(true, nil)
+
+		assert.NoError(t, service.Heartbeat(ctx, userID.String()))
+	})
+
+	t.Run("heartbeat after expiry sets online again", func(t *testing.T) {
+		mockPresenceRepo.EXPECT().Refresh(gomock.Any(), userID, time.Minute, gomock.Any()).Return(false, nil)
+		mockPresenceRepo.EXPECT().
+			SetStatus(gomock.Any(), userID, domain.PresenceStatusOnline, time.Minute, gomock.Any()).
+			Return(domain.PresenceStatusOffline, nil)
+		mockFriendshipRepo.EXPECT().GetPresenceAudience(gomock.Any(), userID).Return(audience, nil)
+		mockNotifier.EXPECT().NotifyPresenceChanged(gomock.Any(), audience, gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.Heartbeat(ctx, userID.String()))
+	})
+
+	t.Run("unchanged status is not published", func(t *testing.T) {
+		mockPresenceRepo.EXPECT().
+			SetStatus(gomock.Any(), userID, domain.PresenceStatusAway, time.Minute, gomock.Any()).
+			Return(domain.PresenceStatusAway, nil)
+
+		assert.NoError(t, service.SetAway(ctx, userID.String(), true))
+	})
+
+	t.Run("disconnect publishes offline", func(t *testing.T) {
+		mockPresenceRepo.EXPECT().SetOffline(gomock.Any(), userID, gomock.Any()).Return(domain.PresenceStatusAway, nil)
+		mockFriendshipRepo.EXPECT().GetPresenceAudience(gomock.Any(), userID).Return(audience, nil)
+		mockNotifier.EXPECT().
+			NotifyPresenceChanged(gomock.Any(), audience, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, recipients []uuid.UUID, presence *domain.Presence) error {
+				assert.Equal(t, domain.PresenceStatusOffline, presence.Status)
+				return nil
+			})
+
+		assert.NoError(t, service.Disconnected(ctx, userID.String()))
+	})
+
+	t.Run("notify failure does not fail status update", func(t *testing.T) {
+		mockPresenceRepo.EXPECT().
+			SetStatus(gomock.Any(), userID, domain.PresenceStatusOnline, time.Minute, gomock.Any()).
+			Return(domain.PresenceStatusAway, nil)
+		mockFriendshipRepo.EXPECT().GetPresenceAudience(gomock.Any(), userID).Return(audience, nil)
+		mockNotifier.EXPECT().NotifyPresenceChanged(gomock.Any(), audience, gomock.Any()).Return(errors.New("hub closed"))
+
+		assert.NoError(t, service.SetAway(ctx, userID.String(), false))
+	})
+
+	t.Run("invalid user ID", func(t *testing.T) {
+		assert.Error(t, service.Connected(ctx, "not-a-uuid"))
+	})
+}
+
+func TestPresenceService_GetFriendsPresence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockPresenceRepo := mocks.NewMockPresenceRepository(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewPresenceService(mockFriendshipRepo, mockPresenceRepo, nil, 0, &mockLogger)
+	ctx := context.Background()
+	userID := uuid.New()
+	friendID := uuid.New()
+	groupMemberID := uuid.New()
+	strangerID := uuid.New()
+
+	t.Run("returns all friends when no IDs are given", func(t *testing.T) {
+		presences := []*domain.Presence{domain.NewPresence(friendID, domain.PresenceStatusOnline, nil)}
+		mockFriendshipRepo.EXPECT().GetFriendIDs(gomock.Any(), userID).Return([]uuid.UUID{friendID}, nil)
+		mockPresenceRepo.EXPECT().GetPresences(gomock.Any(), []uuid.UUID{friendID}).Return(presences, nil)
+
+		result, err := service.GetFriendsPresence(ctx, userID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, presences, result)
+	})
+
+	t.Run("filters requested IDs by audience", func(t *testing.T) {
+		mockFriendshipRepo.EXPECT().
+			GetPresenceAudience(gomock.Any(), userID).
+			Return([]uuid.UUID{friendID, groupMemberID}, nil)
+		mockPresenceRepo.EXPECT().
+			GetPresences(gomock.Any(), []uuid.UUID{groupMemberID, friendID}).
+			Return([]*domain.Presence{}, nil)
+
+		_, err := service.GetFriendsPresence(ctx, userID, []uuid.UUID{groupMemberID, strangerID, friendID, groupMemberID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no visible users skips lookup", func(t *testing.T) {
+		mockFriendshipRepo.EXPECT().GetPresenceAudience(gomock.Any(), userID).Return(nil, nil)
+
+		result, err := service.GetFriendsPresence(ctx, userID, []uuid.UUID{strangerID})
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("too many IDs", func(t *testing.T) {
+		userIDs := make([]uuid.UUID, MaxPresenceBatchSize+1)
+		_, err := service.GetFriendsPresence(ctx, userID, userIDs)
+		assert.ErrorIs(t, err, ErrTooManyPresenceIDs)
+	})
+
+	t.Run("disabled without presence repository", func(t *testing.T) {
+		disabled := NewPresenceService(mockFriendshipRepo, nil, nil, 0, &mockLogger)
+		assert.False(t, disabled.Enabled())
+		assert.NoError(t, disabled.Connected(ctx, userID.String()))
+
+		_, err := disabled.GetFriendsPresence(ctx, userID, nil)
+		assert.ErrorIs(t, err, ErrPresenceNotEnabled)
+	})
+}
+
+func TestProfileService_GetPublicProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockUsers := mocks.NewMockProfileUserDirectory(ctrl)
+	mockStats := mocks.NewMockProfileStatsProvider(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewProfileService(mockProfileRepo, mockFriendshipRepo, mockUsers, mockStats, &mockLogger)
+	ctx := context.Background()
+	userID := uuid.New()
+	viewerID := uuid.New()
+	user := &commonDomain.UserInfo{ID: userID.String(), Username: "alice"}
+	stats := &domain.ProfileStats{CurrentStreak: 4, CompletedTasks: 12}
+
+	t.Run("anonymous viewer sees public profile with stats", func(t *testing.T) {
+		settings := domain.DefaultProfileSettings(userID)
+		settings.DisplayName = "Alice"
+		settings.ShowStats = true
+		mockUsers.EXPECT().FindActiveUserByUsername(gomock.Any(), "alice").Return(user, nil)
+		mockProfileRepo.EXPECT().GetProfileSettings(gomock.Any(), userID).Return(settings, nil)
+		mockStats.EXPECT().GetProfileStats(gomock.Any(), userID).Return(stats, nil)
+
+		profile, err := service.GetPublicProfile(ctx, " alice ", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", profile.DisplayName)
+		assert.Equal(t, stats, profile.Stats)
+		assert.Equal(t, domain.ProfileRelationshipAnonymous, profile.Viewer.Relationship)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockUsers.EXPECT().FindActiveUserByUsername(gomock.Any(), "nobody").Return(nil, nil)
+
+		_, err := service.GetPublicProfile(ctx, "nobody", &viewerID)
+		assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+	})
+
+	t.Run("blocked viewer gets not found", func(t *testing.T) {
+		mockUsers.EXPECT().FindActiveUserByUsername(gomock.Any(), "alice").Return(user, nil)
+		mockFriendshipRepo.EXPECT().IsBlocked(gomock.Any(), viewerID, userID).Return(true, nil)
+
+		_, err := service.GetPublicProfile(ctx, "alice", &viewerID)
+		assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+	})
+
+	t.Run("friends only profile is restricted for pending request", func(t *testing.T) {
+		settings := domain.DefaultProfileSettings(userID)
+		settings.Visibility = domain.ProfileVisibilityFriends
+		settings.ShowStats = true
+		friendship := domain.NewFriendship(viewerID, userID)
+		mockUsers.EXPECT().FindActiveUserByUsername(gomock.Any(), "alice").Return(user, nil)
+		mockFriendshipRepo.EXPECT().IsBlocked(gomock.Any(), viewerID, userID).Return(false, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(gomock.Any(), viewerID, userID).Return(friendship, nil)
+		mockProfileRepo.EXPECT().GetProfileSettings(gomock.Any(), userID).Return(settings, nil)
+
+		profile, err := service.GetPublicProfile(ctx, "alice", &viewerID)
+		require.NoError(t, err)
+		assert.True(t, profile.Restricted)
+		assert.Nil(t, profile.Stats)
+		assert.Equal(t, domain.ProfileRelationshipRequestSent, profile.Viewer.Relationship)
+		assert.False(t, profile.Viewer.CanSendFriendRequest)
+	})
+
+	t.Run("friend sees friends only profile", func(t *testing.T) {
+		settings := domain.DefaultProfileSettings(userID)
+		settings.Visibility = domain.ProfileVisibilityFriends
+		friendship := domain.NewFriendship(userID, viewerID)
+		friendship.Accept()
+		mockUsers.EXPECT().FindActiveUserByUsername(gomock.Any(), "alice").Return(user, nil)
+		mockFriendshipRepo.EXPECT().IsBlocked(gomock.Any(), viewerID, userID).Return(false, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(gomock.Any(), viewerID, userID).Return(friendship, nil)
+		mockProfileRepo.EXPECT().GetProfileSettings(gomock.Any(), userID).Return(settings, nil)
+
+		profile, err := service.GetPublicProfile(ctx, "alice", &viewerID)
+		require.NoError(t, err)
+		assert.False(t, profile.Restricted)
+		assert.Equal(t, domain.ProfileRelationshipFriend, profile.Viewer.Relationship)
+	})
+
+	t.Run("stats failure still returns profile", func(t *testing.T) {
+		mockUsers.EXPECT().FindActiveUserByUsername(gomock.Any(), "alice").Return(user, nil)
+		mockProfileRepo.EXPECT().GetProfileSettings(gomock.Any(), userID).Return(nil, nil)
+		mockStats.EXPECT().GetProfileStats(gomock.Any(), userID).Return(nil, errors.New("db down"))
+
+		profile, err := service.GetPublicProfile(ctx, "alice", &userID)
+		require.NoError(t, err)
+		assert.Nil(t, profile.Stats)
+		assert.Equal(t, domain.ProfileRelationshipSelf, profile.Viewer.Relationship)
+	})
+}
+
+func TestProfileService_UpdateProfileSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProfileRepo := mocks.NewMockProfileRepository(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewProfileService(mockProfileRepo, nil, nil, nil, &mockLogger)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("updates given fields only", func(t *testing.T) {
+		existing := domain.DefaultProfileSettings(userID)
+		existing.Bio = "keep"
+		displayName := "  Alice  "
+		visibility := domain.ProfileVisibilityPrivate
+		mockProfileRepo.EXPECT().GetProfileSettings(gomock.Any(), userID).Return(existing, nil)
+		mockProfileRepo.EXPECT().SaveProfileSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, settings *domain.ProfileSettings) error {
+				assert.Equal(t, "Alice", settings.DisplayName)
+				assert.Equal(t, "keep", settings.Bio)
+				assert.Equal(t, domain.ProfileVisibilityPrivate, settings.Visibility)
+				assert.False(t, settings.UpdatedAt.IsZero())
+				return nil
+			})
+
+		_, err := service.UpdateProfileSettings(ctx, userID, UpdateProfileSettingsInput{
+			DisplayName: &displayName,
+			Visibility:  &visibility,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid input is not saved", func(t *testing.T) {
+		avatarURL := "http://example.com/a.png"
+		mockProfileRepo.EXPECT().GetProfileSettings(gomock.Any(), userID).Return(nil, nil)
+
+		_, err := service.UpdateProfileSettings(ctx, userID, UpdateProfileSettingsInput{AvatarURL: &avatarURL})
+		assert.ErrorIs(t, err, domain.ErrInvalidProfileInput)
+	})
+}
+
+func TestDigestService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mocks.NewMockFriendshipRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockInvitationRepository(ctrl)
+	mockStateRepo := mocks.NewMockDigestStateRepository(ctrl)
+	mockPreferences := mocks.NewMockDigestPreferenceProvider(ctrl)
+	mockNotifier := mocks.NewMockDigestNotifier(ctrl)
+	mockLogger := *logger.NewLogger(&logger.Config{
+		Level:       "error", // Only log errors to reduce noise in tests
+		Output:      "console",
+		Development: false,
+	})
+
+	service := NewDigestService(mockFriendshipRepo, mockInvitationRepo, mockStateRepo, mockPreferences, mockNotifier, 24*time.Hour, &mockLogger)
+	now := time.Now()
+	userID := uuid.New()
+
+	expectCounts := func(requests, invitations map[uuid.UUID]int, states []*domain.DigestState) {
+		mockFriendshipRepo.EXPECT().CountPendingRequestsByAddressee(gomock.Any(), now.Add(-24*time.Hour)).Return(requests, nil)
+		mockInvitationRepo.EXPECT().CountPendingGroupInvitationsByInvitee(gomock.Any(), now.Add(-24*time.Hour), now).Return(invitations, nil)
+		mockStateRepo.EXPECT().ListDigestStates(gomock.Any()).Return(states, nil)
+	}
+
+	t.Run("sends a digest with friend request and group invitation counts", func(t *testing.T) {
+		expectCounts(map[uuid.UUID]int{userID: 3}, map[uuid.UUID]int{userID: 2}, nil)
+		mockPreferences.EXPECT().DigestInterval(gomock.Any(), userID).Return(24*time.Hour, nil)
+		mockNotifier.EXPECT().
+			SendPendingDigest(gomock.Any(), domain.PendingDigest{UserID: userID, FriendRequests: 3, GroupInvitations: 2}).
+			Return("notification-1", nil)
+		mockStateRepo.EXPECT().
+			SaveDigestState(gomock.Any(), &domain.DigestState{UserID: userID, NotificationID: "notification-1", LastSentAt: now}).
+			Return(nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigestResult{Sent: 1}, result)
+	})
+
+	t.Run("does not send again within the preferred interval", func(t *testing.T) {
+		state := &domain.DigestState{UserID: userID, NotificationID: "notification-1", LastSentAt: now.Add(-23 * time.Hour)}
+		expectCounts(map[uuid.UUID]int{userID: 3}, nil, []*domain.DigestState{state})
+		mockPreferences.EXPECT().DigestInterval(gomock.Any(), userID).Return(24*time.Hour, nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigestResult{}, result)
+	})
+
+	t.Run("does not send when the digest is turned off", func(t *testing.T) {
+		expectCounts(map[uuid.UUID]int{userID: 1}, nil, nil)
+		mockPreferences.EXPECT().DigestInterval(gomock.Any(), userID).Return(time.Duration(0), nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigestResult{}, result)
+	})
+
+	t.Run("replaces the previous digest after the interval", func(t *testing.T) {
+		state := &domain.DigestState{UserID: userID, NotificationID: "notification-1", LastSentAt: now.Add(-7 * 24 * time.Hour)}
+		expectCounts(nil, map[uuid.UUID]int{userID: 1}, []*domain.DigestState{state})
+		mockPreferences.EXPECT().DigestInterval(gomock.Any(), userID).Return(7*24*time.Hour, nil)
+		mockNotifier.EXPECT().
+			SendPendingDigest(gomock.Any(), domain.PendingDigest{UserID: userID, GroupInvitations: 1}).
+			Return("notification-2", nil)
+		mockNotifier.EXPECT().DismissPendingDigest(gomock.Any(), userID, "notification-1").Return(nil)
+		mockStateRepo.EXPECT().
+			SaveDigestState(gomock.Any(), &domain.DigestState{UserID: userID, NotificationID: "notification-2", LastSentAt: now}).
+			Return(nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigestResult{Sent: 1}, result)
+	})
+
+	t.Run("dismisses the digest once everything is acted upon", func(t *testing.T) {
+		state := &domain.DigestState{UserID: userID, NotificationID: "notification-2", LastSentAt: now.Add(-time.Hour)}
+		expectCounts(nil, nil, []*domain.DigestState{state})
+		mockNotifier.EXPECT().DismissPendingDigest(gomock.Any(), userID, "notification-2").Return(nil)
+		mockStateRepo.EXPECT().DeleteDigestState(gomock.Any(), userID).Return(nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigestResult{Dismissed: 1}, result)
+	})
+
+	t.Run("failure for one user does not stop the others", func(t *testing.T) {
+		otherID := uuid.New()
+		expectCounts(map[uuid.UUID]int{userID: 1, otherID: 2}, nil, nil)
+		mockPreferences.EXPECT().DigestInterval(gomock.Any(), userID).Return(time.Duration(0), errors.New("db error"))
+		mockPreferences.EXPECT().DigestInterval(gomock.Any(), otherID).Return(24*time.Hour, nil)
+		mockNotifier.EXPECT().
+			SendPendingDigest(gomock.Any(), domain.PendingDigest{UserID: otherID, FriendRequests: 2}).
+			Return("notification-3", nil)
+		mockStateRepo.EXPECT().SaveDigestState(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := service.Run(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigestResult{Sent: 1, Failed: 1}, result)
+	})
+}
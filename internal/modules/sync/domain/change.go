@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidCursor はカーソルの形式が不正であることを表すエラー
+	ErrInvalidCursor = errors.New("invalid sync cursor")
+
+	// ErrCursorExpired はカーソル以降の変更の一部が保持期間を過ぎて削除されていることを表すエラー
+	// クライアントは全件を取得し直す必要がある
+	ErrCursorExpired = errors.New("sync cursor expired")
+)
+
+// Operation は変更の種類を表す
+type Operation string
+
+const (
+	// OperationUpsert はエンティティの作成・更新（dataに変更時点のエンティティを含む）
+	OperationUpsert Operation = "upsert"
+	// OperationDelete はエンティティの削除、またはユーザーが参照できなくなったこと（tombstone）
+	OperationDelete Operation = "delete"
+)
+
+// Change はユーザーに配信するエンティティの変更
+type Change struct {
+	Seq        int64           `json:"-"`
+	UserID     string          `json:"-"`
+	EntityType string          `json:"entity_type" example:"task"`
+	EntityID   string          `json:"entity_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Operation  Operation       `json:"op" example:"upsert"`
+	Version    int64           `json:"version" example:"128"` // 変更後のバージョン（POST /sync/push の base_version に指定する）
+	Data       json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	ChangedAt  time.Time       `json:"changed_at"`
+} // @name SyncChange
+
+// cursorPrefix はカーソルの形式のバージョン
+const cursorPrefix = "v1:"
+
+// EncodeCursor は変更の通し番号をクライアントに渡すカーソルに変換する
+func EncodeCursor(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatInt(seq, 10)))
+}
+
+// ParseCursor はカーソルを変更の通し番号に変換する（空の場合は最初から）
+func ParseCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(decoded), cursorPrefix), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return seq, nil
+}
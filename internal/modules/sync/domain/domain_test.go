@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("cursors round trip", func(t *testing.T) {
+		for _, seq := range []int64{0, 1, 9876543210} {
+			parsed, err := ParseCursor(EncodeCursor(seq))
+			require.NoError(t, err)
+			assert.Equal(t, seq, parsed)
+		}
+	})
+
+	t.Run("an empty cursor starts from the beginning", func(t *testing.T) {
+		seq, err := ParseCursor("")
+		require.NoError(t, err)
+		assert.Zero(t, seq)
+	})
+
+	t.Run("malformed cursors are rejected", func(t *testing.T) {
+		for _, cursor := range []string{"12", "!!!", EncodeCursor(-1), "djI6MTI"} {
+			_, err := ParseCursor(cursor)
+			assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrMutationRejected はクライアントの変更の内容が不正、または権限がないため適用できないことを表すエラー
+// 再送しても結果は変わらないため、クライアントは変更を破棄する必要がある
+var ErrMutationRejected = errors.New("sync mutation rejected")
+
+// MutationOp はクライアントの変更の種類を表す
+type MutationOp string
+
+const (
+	MutationCreate MutationOp = "create"
+	MutationUpdate MutationOp = "update"
+	MutationDelete MutationOp = "delete"
+)
+
+// IsValid は変更の種類が有効かどうかを返す
+func (op MutationOp) IsValid() bool {
+	switch op {
+	case MutationCreate, MutationUpdate, MutationDelete:
+		return true
+	}
+	return false
+}
+
+// Mutation はオフライン中にクライアントが行った変更
+type Mutation struct {
+	// クライアントが変更ごとに生成するID（再送時に同じ変更を二重に適用しないために使用）
+	ClientMutationID string     `json:"client_mutation_id" example:"0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"`
+	EntityType       string     `json:"entity_type" example:"task"`
+	EntityID         string     `json:"entity_id" example:"0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"` // 作成時はクライアントが生成したID
+	Operation        MutationOp `json:"op" example:"update" enums:"create,update,delete"`
+	// 変更の元にしたエンティティのバージョン（変更フィードの version、作成時は不要）
+	BaseVersion int64           `json:"base_version" example:"128"`
+	Data        json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+} // @name SyncMutation
+
+// MutationStatus はクライアントの変更の適用結果を表す
+type MutationStatus string
+
+const (
+	// MutationAccepted は変更を適用したこと
+	MutationAccepted MutationStatus = "accepted"
+	// MutationConflict はサーバー側で先に変更されていたため適用しなかったこと（dataにサーバーの現在の状態を含む）
+	MutationConflict MutationStatus = "conflict"
+	// MutationRejected は変更の内容が不正、または権限がないため適用しなかったこと
+	MutationRejected MutationStatus = "rejected"
+)
+
+// 競合の理由
+const (
+	ConflictVersionMismatch = "version_mismatch"
+	ConflictAlreadyExists   = "already_exists"
+	ConflictDeleted         = "deleted"
+)
+
+// MutationResult はクライアントの変更ごとの適用結果
+type MutationResult struct {
+	ClientMutationID string         `json:"client_mutation_id" example:"0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"`
+	EntityType       string         `json:"entity_type" example:"task"`
+	EntityID         string         `json:"entity_id" example:"0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"`
+	Status           MutationStatus `json:"status" example:"accepted" enums:"accepted,conflict,rejected"`
+	// accepted の場合は適用後、conflict の場合はサーバーの現在のエンティティのバージョン
+	Version int64 `json:"version" example:"129"`
+	// accepted の場合は適用後、conflict の場合はサーバーの現在のエンティティ（削除済みの場合は省略）
+	Data json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	// conflict の場合は version_mismatch・already_exists・deleted のいずれか、rejected の場合はエラーの内容
+	Reason    string    `json:"reason,omitempty" example:"version_mismatch"`
+	AppliedAt time.Time `json:"applied_at"`
+} // @name SyncMutationResult
@@ -0,0 +1,120 @@
+// Package memory は変更フィードのリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+)
+
+// ChangeRepository は変更フィードのインメモリリポジトリ
+type ChangeRepository struct {
+	mu            sync.RWMutex
+	seq           int64
+	prunedThrough int64
+	changes       []domain.Change // 通し番号順
+	recipients    map[string]map[string]bool
+	versions      map[string]int64
+}
+
+// NewChangeRepository は新しいChangeRepositoryを作成する
+func NewChangeRepository() *ChangeRepository {
+	return &ChangeRepository{
+		recipients: make(map[string]map[string]bool),
+		versions:   make(map[string]int64),
+	}
+}
+
+// AppendChanges は変更に通し番号を振って記録し、エンティティの変更を受け取るユーザーをrecipientsに置き換える
+func (r *ChangeRepository) AppendChanges(ctx context.Context, entityType, entityID string, changes []*domain.Change, recipients []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version := r.seq + int64(len(changes))
+	for _, change := range changes {
+		r.seq++
+		change.Seq = r.seq
+		change.Version = version
+		r.changes = append(r.changes, *change)
+	}
+
+	key := entityType + ":" + entityID
+	r.versions[key] = version
+	if len(recipients) == 0 {
+		delete(r.recipients, key)
+		return nil
+	}
+	users := make(map[string]bool, len(recipients))
+	for _, userID := range recipients {
+		users[userID] = true
+	}
+	r.recipients[key] = users
+	return nil
+}
+
+// ListRecipients はエンティティの変更を受け取っているユーザーを取得する
+func (r *ChangeRepository) ListRecipients(ctx context.Context, entityType, entityID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]string, 0, len(r.recipients[entityType+":"+entityID]))
+	for userID := range r.recipients[entityType+":"+entityID] {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// GetEntityVersion はエンティティの現在のバージョンを取得する（変更を記録したことがない場合は0）
+func (r *ChangeRepository) GetEntityVersion(ctx context.Context, entityType, entityID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.versions[entityType+":"+entityID], nil
+}
+
+// ListChanges はユーザーへの通し番号afterSeqより後の変更を通し番号順にlimit件まで取得する
+func (r *ChangeRepository) ListChanges(ctx context.Context, userID string, afterSeq int64, limit int) ([]*domain.Change, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	start := sort.Search(len(r.changes), func(i int) bool { return r.changes[i].Seq > afterSeq })
+	var result []*domain.Change
+	for i := start; i < len(r.changes) && len(result) < limit; i++ {
+		if r.changes[i].UserID == userID {
+			change := r.changes[i]
+			result = append(result, &change)
+		}
+	}
+	return result, nil
+}
+
+// GetSequence は最新の通し番号と、保持期間を過ぎて削除した変更の最大の通し番号を取得する
+func (r *ChangeRepository) GetSequence(ctx context.Context) (int64, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.seq, r.prunedThrough, nil
+}
+
+// PruneChanges はbeforeより前に記録した変更を削除し、削除した件数を返す
+func (r *ChangeRepository) PruneChanges(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for n < len(r.changes) && r.changes[n].ChangedAt.Before(before) {
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	r.prunedThrough = r.changes[n-1].Seq
+	r.changes = append([]domain.Change(nil), r.changes[n:]...)
+	return int64(n), nil
+}
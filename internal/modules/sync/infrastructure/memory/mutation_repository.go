@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+)
+
+// MutationRepository はクライアントの変更の適用結果のインメモリリポジトリ
+type MutationRepository struct {
+	mu      sync.RWMutex
+	results map[string]domain.MutationResult // キーは userID + ":" + clientMutationID
+}
+
+// NewMutationRepository は新しいMutationRepositoryを作成する
+func NewMutationRepository() *MutationRepository {
+	return &MutationRepository{
+		results: make(map[string]domain.MutationResult),
+	}
+}
+
+// GetMutationResult はユーザーの変更の適用結果を取得する（記録がない場合はnil）
+func (r *MutationRepository) GetMutationResult(ctx context.Context, userID, clientMutationID string) (*domain.MutationResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result, ok := r.results[userID+":"+clientMutationID]
+	if !ok {
+		return nil, nil
+	}
+	return &result, nil
+}
+
+// SaveMutationResult はユーザーの変更の適用結果を記録する
+func (r *MutationRepository) SaveMutationResult(ctx context.Context, userID string, result *domain.MutationResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results[userID+":"+result.ClientMutationID] = *result
+	return nil
+}
+
+// PruneMutationResults はbeforeより前に適用した変更の結果を削除し、削除した件数を返す
+func (r *MutationRepository) PruneMutationResults(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pruned int64
+	for key, result := range r.results {
+		if result.AppliedAt.Before(before) {
+			delete(r.results, key)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
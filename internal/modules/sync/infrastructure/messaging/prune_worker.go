@@ -0,0 +1,100 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// PruneWorker は保持期間を過ぎた変更フィードの変更を定期的に削除するワーカー
+type PruneWorker struct {
+	syncService *usecase.SyncService
+	interval    time.Duration
+	logger      logger.Logger
+	ticker      *time.Ticker
+	stopCh      chan struct{}
+	doneCh      chan struct{}
+	isRunning   bool
+}
+
+// NewPruneWorker は新しいPruneWorkerを作成
+func NewPruneWorker(
+	syncService *usecase.SyncService,
+	interval time.Duration,
+	logger logger.Logger,
+) *PruneWorker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &PruneWorker{
+		syncService: syncService,
+		interval:    interval,
+		logger:      logger,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始
+func (w *PruneWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Sync prune worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(w.interval)
+
+	w.logger.Info("Starting sync prune worker", logger.Any("interval", w.interval.String()))
+
+	// 初回実行
+	go w.prune(ctx)
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.prune(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Sync prune worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Sync prune worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// prune は保持期間を過ぎた変更を削除する
+func (w *PruneWorker) prune(ctx context.Context) {
+	defer errtrack.Recover("sync-prune-worker")
+	pruned, err := w.syncService.Prune(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to prune sync changes", logger.Error(err))
+		return
+	}
+	if pruned > 0 {
+		w.logger.Info("Sync changes pruned", logger.Any("pruned", pruned))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *PruneWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping sync prune worker")
+	<-w.doneCh
+}
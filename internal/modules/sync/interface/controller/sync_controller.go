@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+)
+
+// SyncController はオフライン同期のHTTPリクエストを処理するコントローラー
+type SyncController struct {
+	syncService *usecase.SyncService
+}
+
+// NewSyncController は新しいSyncControllerを作成する
+func NewSyncController(syncService *usecase.SyncService) *SyncController {
+	return &SyncController{
+		syncService: syncService,
+	}
+}
+
+// ErrorResponse は同期APIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"CURSOR_EXPIRED"`
+	Message string `json:"message" example:"sync cursor expired"`
+} // @name SyncErrorResponse
+
+// ChangesResponse は変更フィードのレスポンス
+type ChangesResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    *usecase.ChangesPage `json:"data"`
+} // @name SyncChangesResponse
+
+// PushRequest はクライアントの変更の送信リクエスト
+type PushRequest struct {
+	Mutations []*domain.Mutation `json:"mutations" binding:"required,min=1"`
+} // @name SyncPushRequest
+
+// PushResult はクライアントの変更の適用結果
+type PushResult struct {
+	Results []*domain.MutationResult `json:"results"`
+} // @name SyncPushResult
+
+// PushResponse はクライアントの変更の送信のレスポンス
+type PushResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Data    *PushResult `json:"data"`
+} // @name SyncPushResponse
+
+// GetChanges 変更フィード
+// @Summary      変更フィード
+// @Description  カーソル（since）以降にログイン中のユーザーが参照できるタスク・友達関係・グループ・通知の変更を通し番号順に返します。
+// @Description  削除や参照できなくなったエンティティは op=delete（tombstone）として返します。同じページ内で複数回変更されたエンティティは最後の変更だけを返します。
+// @Description  since を省略すると最初から、since=now を指定すると変更を返さずに最新のカーソルだけを返します。
+// @Description  has_more が true の間は next_cursor を since に指定して続きを取得してください。410 が返された場合は全件を取得し直してください
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        since query string false "前回のレスポンスの next_cursor、または now"
+// @Param        limit query int false "取得件数（既定100、最大500）"
+// @Security     BearerAuth
+// @Success      200 {object} ChangesResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "カーソルが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      410 {object} ErrorResponse "カーソルの保持期間切れ"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /sync/changes [get]
+func (c *SyncController) GetChanges(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	limit := 0
+	if raw := ctx.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "limit must be a positive integer",
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := c.syncService.GetChanges(ctx, userID, ctx.Query("since"), limit)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ChangesResponse{Success: true, Data: page})
+}
+
+// Push クライアントの変更の送信
+// @Summary      クライアントの変更の送信
+// @Description  オフライン中に行った変更（現在は entity_type=task の create・update・delete）をまとめて送信し、送信した順に適用します。最大100件です。
+// @Description  作成時の entity_id はクライアントが生成したUUIDv7、更新・削除時の base_version は変更の元にした変更フィードの version を指定します。
+// @Description  base_version がサーバーの現在のバージョンと一致しない場合は適用せず、status=conflict とサーバーの現在の状態（data）を返します。
+// @Description  内容が不正な変更や権限のない変更は status=rejected を返します。結果は client_mutation_id ごとに保持されるため、通信エラーの場合は同じ内容を再送してください
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        request body PushRequest true "クライアントの変更"
+// @Security     BearerAuth
+// @Success      200 {object} PushResponse "変更ごとの適用結果"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /sync/push [post]
+func (c *SyncController) Push(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return
+	}
+
+	var req PushRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	results, err := c.syncService.Push(ctx, userID, req.Mutations)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PushResponse{Success: true, Data: &PushResult{Results: results}})
+}
+
+// handleServiceError はサービスのエラーをHTTPレスポンスに変換する
+func handleServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCursorExpired):
+		ctx.JSON(http.StatusGone, ErrorResponse{
+			Success: false,
+			Error:   "CURSOR_EXPIRED",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrInvalidCursor), errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "INTERNAL_ERROR",
+			Message: "Failed to process sync request",
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ChangeRepository は変更フィードのデータベースリポジトリ実装
+//
+// 通し番号は sync_sequence の1行をトランザクション内で更新して採番する。
+// 行ロックはコミットまで保持されるため、変更は通し番号の順にコミットされ、
+// カーソルより小さい番号の変更が後から参照できるようになることはない。
+type ChangeRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewChangeRepository は新しいChangeRepositoryを作成する
+func NewChangeRepository(db *sql.DB, logger logger.Logger) usecase.ChangeRepository {
+	return &ChangeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// AppendChanges は変更に通し番号を振って記録し、エンティティの変更を受け取るユーザーをrecipientsに置き換える
+// エンティティのバージョンは今回振った最大の通し番号に更新する
+func (r *ChangeRepository) AppendChanges(ctx context.Context, entityType, entityID string, changes []*domain.Change, recipients []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 通し番号の採番（コミットまで行ロックを保持するため、続けて読み出す値は他のトランザクションに変更されない）
+	// LAST_INSERT_IDはMySQLのみのため、更新した値をSELECTで読み出す
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE sync_sequence SET last_seq = last_seq + ? WHERE id = 1", len(changes)); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to allocate sync sequence", logger.Error(err))
+		return fmt.Errorf("failed to allocate sync sequence: %w", err)
+	}
+	var lastSeq int64
+	if err := tx.QueryRowContext(ctx, "SELECT last_seq FROM sync_sequence WHERE id = 1").Scan(&lastSeq); err != nil {
+		return fmt.Errorf("failed to read sync sequence: %w", err)
+	}
+
+	values := make([]string, 0, len(changes))
+	args := make([]interface{}, 0, len(changes)*8)
+	for i, change := range changes {
+		change.Seq = lastSeq - int64(len(changes)) + int64(i) + 1
+		change.Version = lastSeq
+		var data interface{}
+		if len(change.Data) > 0 {
+			data = string(change.Data)
+		}
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, change.Seq, change.UserID, change.EntityType, change.EntityID, string(change.Operation), change.Version, data, change.ChangedAt)
+	}
+	query := `
+		INSERT INTO sync_changes (seq, user_id, entity_type, entity_id, operation, version, data, changed_at)
+		VALUES ` + strings.Join(values, ", ")
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to insert sync changes", logger.Error(err))
+		return fmt.Errorf("failed to insert sync changes: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO sync_entity_versions (entity_type, entity_id, version)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE version = VALUES(version)
+	`, entityType, entityID, lastSeq); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update sync entity version", logger.Error(err))
+		return fmt.Errorf("failed to update sync entity version: %w", err)
+	}
+
+	// 受け取るユーザーの置き換え
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM sync_entity_recipients WHERE entity_type = ? AND entity_id = ?", entityType, entityID); err != nil {
+		return fmt.Errorf("failed to delete sync recipients: %w", err)
+	}
+	if len(recipients) > 0 {
+		values = values[:0]
+		args = args[:0]
+		for _, userID := range recipients {
+			values = append(values, "(?, ?, ?)")
+			args = append(args, entityType, entityID, userID)
+		}
+		query = `
+			INSERT INTO sync_entity_recipients (entity_type, entity_id, user_id)
+			VALUES ` + strings.Join(values, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			r.logger.WithContext(ctx).Error("Failed to insert sync recipients", logger.Error(err))
+			return fmt.Errorf("failed to insert sync recipients: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListRecipients はエンティティの変更を受け取っているユーザーを取得する
+func (r *ChangeRepository) ListRecipients(ctx context.Context, entityType, entityID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT user_id FROM sync_entity_recipients WHERE entity_type = ? AND entity_id = ? ORDER BY user_id",
+		entityType, entityID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list sync recipients", logger.Error(err))
+		return nil, fmt.Errorf("failed to list sync recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan sync recipient: %w", err)
+		}
+		users = append(users, userID)
+	}
+	return users, rows.Err()
+}
+
+// GetEntityVersion はエンティティの現在のバージョンを取得する（変更を記録したことがない場合は0）
+func (r *ChangeRepository) GetEntityVersion(ctx context.Context, entityType, entityID string) (int64, error) {
+	var version int64
+	err := r.db.QueryRowContext(ctx,
+		"SELECT version FROM sync_entity_versions WHERE entity_type = ? AND entity_id = ?",
+		entityType, entityID).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get sync entity version", logger.Error(err))
+		return 0, fmt.Errorf("failed to get sync entity version: %w", err)
+	}
+	return version, nil
+}
+
+// ListChanges はユーザーへの通し番号afterSeqより後の変更を通し番号順にlimit件まで取得する
+func (r *ChangeRepository) ListChanges(ctx context.Context, userID string, afterSeq int64, limit int) ([]*domain.Change, error) {
+	query := `
+		SELECT seq, user_id, entity_type, entity_id, operation, version, data, changed_at
+		FROM sync_changes
+		WHERE user_id = ? AND seq > ?
+		ORDER BY seq ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, afterSeq, limit)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list sync changes", logger.Any("user_id", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to list sync changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*domain.Change
+	for rows.Next() {
+		var change domain.Change
+		var operation string
+		var data []byte
+		if err := rows.Scan(&change.Seq, &change.UserID, &change.EntityType, &change.EntityID, &operation, &change.Version, &data, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sync change: %w", err)
+		}
+		change.Operation = domain.Operation(operation)
+		change.Data = data
+		changes = append(changes, &change)
+	}
+	return changes, rows.Err()
+}
+
+// GetSequence は最新の通し番号と、保持期間を過ぎて削除した変更の最大の通し番号を取得する
+func (r *ChangeRepository) GetSequence(ctx context.Context) (int64, int64, error) {
+	var latest, prunedThrough int64
+	err := r.db.QueryRowContext(ctx, "SELECT last_seq, pruned_through FROM sync_sequence WHERE id = 1").Scan(&latest, &prunedThrough)
+	if err == sql.ErrNoRows {
+		return 0, 0, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get sync sequence", logger.Error(err))
+		return 0, 0, fmt.Errorf("failed to get sync sequence: %w", err)
+	}
+	return latest, prunedThrough, nil
+}
+
+// PruneChanges はbeforeより前に記録した変更を削除し、削除した件数を返す
+func (r *ChangeRepository) PruneChanges(ctx context.Context, before time.Time) (int64, error) {
+	var maxSeq sql.NullInt64
+	if err := r.db.QueryRowContext(ctx,
+		"SELECT MAX(seq) FROM sync_changes WHERE changed_at < ?", before).Scan(&maxSeq); err != nil {
+		return 0, fmt.Errorf("failed to find prunable sync changes: %w", err)
+	}
+	if !maxSeq.Valid {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM sync_changes WHERE seq <= ?", maxSeq.Int64)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to prune sync changes", logger.Error(err))
+		return 0, fmt.Errorf("failed to prune sync changes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE sync_sequence SET pruned_through = GREATEST(pruned_through, ?) WHERE id = 1", maxSeq.Int64); err != nil {
+		return 0, fmt.Errorf("failed to update pruned sync sequence: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit sync prune: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// MutationRepository はクライアントの変更の適用結果のデータベースリポジトリ実装
+type MutationRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewMutationRepository は新しいMutationRepositoryを作成する
+func NewMutationRepository(db *sql.DB, logger logger.Logger) usecase.MutationRepository {
+	return &MutationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetMutationResult はユーザーの変更の適用結果を取得する（記録がない場合はnil）
+func (r *MutationRepository) GetMutationResult(ctx context.Context, userID, clientMutationID string) (*domain.MutationResult, error) {
+	query := `
+		SELECT client_mutation_id, entity_type, entity_id, status, version, data, reason, applied_at
+		FROM sync_mutations
+		WHERE user_id = ? AND client_mutation_id = ?
+	`
+
+	var result domain.MutationResult
+	var status string
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query, userID, clientMutationID).Scan(
+		&result.ClientMutationID, &result.EntityType, &result.EntityID, &status,
+		&result.Version, &data, &result.Reason, &result.AppliedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get sync mutation result", logger.Any("user_id", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get sync mutation result: %w", err)
+	}
+	result.Status = domain.MutationStatus(status)
+	result.Data = data
+	return &result, nil
+}
+
+// SaveMutationResult はユーザーの変更の適用結果を記録する
+func (r *MutationRepository) SaveMutationResult(ctx context.Context, userID string, result *domain.MutationResult) error {
+	query := `
+		INSERT INTO sync_mutations (user_id, client_mutation_id, entity_type, entity_id, status, version, data, reason, applied_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var data interface{}
+	if len(result.Data) > 0 {
+		data = string(result.Data)
+	}
+	if _, err := r.db.ExecContext(ctx, query, userID, result.ClientMutationID, result.EntityType, result.EntityID,
+		string(result.Status), result.Version, data, result.Reason, result.AppliedAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save sync mutation result", logger.Any("user_id", userID), logger.Error(err))
+		return fmt.Errorf("failed to save sync mutation result: %w", err)
+	}
+	return nil
+}
+
+// PruneMutationResults はbeforeより前に適用した変更の結果を削除し、削除した件数を返す
+func (r *MutationRepository) PruneMutationResults(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM sync_mutations WHERE applied_at < ?", before)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to prune sync mutation results", logger.Error(err))
+		return 0, fmt.Errorf("failed to prune sync mutation results: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/sync/domain"
+)
+
+// MockChangeRepository is a mock of ChangeRepository interface.
+type MockChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeRepositoryMockRecorder
+}
+
+// MockChangeRepositoryMockRecorder is the mock recorder for MockChangeRepository.
+type MockChangeRepositoryMockRecorder struct {
+	mock *MockChangeRepository
+}
+
+// NewMockChangeRepository creates a new mock instance.
+func NewMockChangeRepository(ctrl *gomock.Controller) *MockChangeRepository {
+	mock := &MockChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeRepository) EXPECT() *MockChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendChanges mocks base method.
+func (m *MockChangeRepository) AppendChanges(ctx context.Context, entityType, entityID string, changes []*domain.Change, recipients []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendChanges", ctx, entityType, entityID, changes, recipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendChanges indicates an expected call of AppendChanges.
+func (mr *MockChangeRepositoryMockRecorder) AppendChanges(ctx, entityType, entityID, changes, recipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendChanges", reflect.TypeOf((*MockChangeRepository)(nil).AppendChanges), ctx, entityType, entityID, changes, recipients)
+}
+
+// GetEntityVersion mocks base method.
+func (m *MockChangeRepository) GetEntityVersion(ctx context.Context, entityType, entityID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityVersion", ctx, entityType, entityID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityVersion indicates an expected call of GetEntityVersion.
+func (mr *MockChangeRepositoryMockRecorder) GetEntityVersion(ctx, entityType, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityVersion", reflect.TypeOf((*MockChangeRepository)(nil).GetEntityVersion), ctx, entityType, entityID)
+}
+
+// GetSequence mocks base method.
+func (m *MockChangeRepository) GetSequence(ctx context.Context) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequence", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSequence indicates an expected call of GetSequence.
+func (mr *MockChangeRepositoryMockRecorder) GetSequence(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequence", reflect.TypeOf((*MockChangeRepository)(nil).GetSequence), ctx)
+}
+
+// ListChanges mocks base method.
+func (m *MockChangeRepository) ListChanges(ctx context.Context, userID string, afterSeq int64, limit int) ([]*domain.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChanges", ctx, userID, afterSeq, limit)
+	ret0, _ := ret[0].([]*domain.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChanges indicates an expected call of ListChanges.
+func (mr *MockChangeRepositoryMockRecorder) ListChanges(ctx, userID, afterSeq, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChanges", reflect.TypeOf((*MockChangeRepository)(nil).ListChanges), ctx, userID, afterSeq, limit)
+}
+
+// ListRecipients mocks base method.
+func (m *MockChangeRepository) ListRecipients(ctx context.Context, entityType, entityID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipients", ctx, entityType, entityID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipients indicates an expected call of ListRecipients.
+func (mr *MockChangeRepositoryMockRecorder) ListRecipients(ctx, entityType, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipients", reflect.TypeOf((*MockChangeRepository)(nil).ListRecipients), ctx, entityType, entityID)
+}
+
+// PruneChanges mocks base method.
+func (m *MockChangeRepository) PruneChanges(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneChanges", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneChanges indicates an expected call of PruneChanges.
+func (mr *MockChangeRepositoryMockRecorder) PruneChanges(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneChanges", reflect.TypeOf((*MockChangeRepository)(nil).PruneChanges), ctx, before)
+}
+
+// MockMutationRepository is a mock of MutationRepository interface.
+type MockMutationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMutationRepositoryMockRecorder
+}
+
+// MockMutationRepositoryMockRecorder is the mock recorder for MockMutationRepository.
+type MockMutationRepositoryMockRecorder struct {
+	mock *MockMutationRepository
+}
+
+// NewMockMutationRepository creates a new mock instance.
+func NewMockMutationRepository(ctrl *gomock.Controller) *MockMutationRepository {
+	mock := &MockMutationRepository{ctrl: ctrl}
+	mock.recorder = &MockMutationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMutationRepository) EXPECT() *MockMutationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMutationResult mocks base method.
+func (m *MockMutationRepository) GetMutationResult(ctx context.Context, userID, clientMutationID string) (*domain.MutationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutationResult", ctx, userID, clientMutationID)
+	ret0, _ := ret[0].(*domain.MutationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutationResult indicates an expected call of GetMutationResult.
+func (mr *MockMutationRepositoryMockRecorder) GetMutationResult(ctx, userID, clientMutationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutationResult", reflect.TypeOf((*MockMutationRepository)(nil).GetMutationResult), ctx, userID, clientMutationID)
+}
+
+// PruneMutationResults mocks base method.
+func (m *MockMutationRepository) PruneMutationResults(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneMutationResults", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneMutationResults indicates an expected call of PruneMutationResults.
+func (mr *MockMutationRepositoryMockRecorder) PruneMutationResults(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneMutationResults", reflect.TypeOf((*MockMutationRepository)(nil).PruneMutationResults), ctx, before)
+}
+
+// SaveMutationResult mocks base method.
+func (m *MockMutationRepository) SaveMutationResult(ctx context.Context, userID string, result *domain.MutationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMutationResult", ctx, userID, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMutationResult indicates an expected call of SaveMutationResult.
+func (mr *MockMutationRepositoryMockRecorder) SaveMutationResult(ctx, userID, result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMutationResult", reflect.TypeOf((*MockMutationRepository)(nil).SaveMutationResult), ctx, userID, result)
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+const (
+	// MaxPushMutations は一度に送信できるクライアントの変更の件数の上限
+	MaxPushMutations = 100
+	// maxClientMutationIDLength はクライアントが生成する変更IDの最大長
+	maxClientMutationIDLength = 100
+)
+
+// MutationHandler はクライアントの変更をエンティティに適用するインターフェース
+// エンティティの種類ごとに実装し、RegisterHandlerで登録する。
+// 適用した変更は各モジュールが変更フィードに記録し、その記録によってエンティティのバージョンが更新される。
+//
+// 内容が不正な変更や権限のない変更にはdomain.ErrMutationRejectedをラップしたエラーを返す。
+// それ以外のエラーは一時的な障害として扱い、送信された変更の処理を中断する。
+type MutationHandler interface {
+	// Get はエンティティの現在の状態を取得する（存在しない場合は型なしのnil）
+	Get(ctx context.Context, userID, entityID string) (interface{}, error)
+	// Create はクライアントが生成したIDでエンティティを作成する
+	Create(ctx context.Context, userID, entityID string, data json.RawMessage) (interface{}, error)
+	// Update はエンティティを更新する
+	Update(ctx context.Context, userID, entityID string, data json.RawMessage) (interface{}, error)
+	// Delete はエンティティを削除する
+	Delete(ctx context.Context, userID, entityID string) error
+}
+
+// RegisterHandler はエンティティの種類のクライアントの変更の適用先を登録する
+func (s *SyncService) RegisterHandler(entityType string, handler MutationHandler) {
+	s.handlers[entityType] = handler
+}
+
+// Push はオフライン中にクライアントが行った変更を送信された順に適用し、変更ごとの結果を返す
+//
+// 更新と削除は base_version がエンティティの現在のバージョンと一致する場合だけ適用し、
+// 一致しない場合はサーバーの現在の状態を含む conflict を返す。
+// 適用結果は client_mutation_id ごとに保持し、再送された変更には適用し直さずに以前の結果を返す。
+// 途中でエラーが発生した場合も、それまでに適用した変更の結果は保持されるため、全体を再送してよい。
+func (s *SyncService) Push(ctx context.Context, userID string, mutations []*domain.Mutation) ([]*domain.MutationResult, error) {
+	if userID == "" || len(mutations) == 0 {
+		return nil, ErrInvalidParameter
+	}
+	if len(mutations) > MaxPushMutations {
+		return nil, fmt.Errorf("%w: too many mutations (max %d)", ErrInvalidParameter, MaxPushMutations)
+	}
+	for _, mutation := range mutations {
+		if mutation == nil || mutation.ClientMutationID == "" {
+			return nil, fmt.Errorf("%w: client_mutation_id is required", ErrInvalidParameter)
+		}
+		if len(mutation.ClientMutationID) > maxClientMutationIDLength {
+			return nil, fmt.Errorf("%w: client_mutation_id too long (max %d characters)", ErrInvalidParameter, maxClientMutationIDLength)
+		}
+	}
+
+	results := make([]*domain.MutationResult, 0, len(mutations))
+	for _, mutation := range mutations {
+		result, err := s.pushMutation(ctx, userID, mutation)
+		if err != nil {
+			s.Logger.WithContext(ctx).Error("Failed to apply sync mutation",
+				logger.Any("userID", userID),
+				logger.Any("clientMutationID", mutation.ClientMutationID),
+				logger.Error(err))
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// pushMutation は変更を適用して結果を保持する（適用済みの場合は以前の結果を返す）
+// 同じ変更が同時に再送された場合に二重に適用しないよう、結果の確認から保持までをエンティティごとに直列化する
+func (s *SyncService) pushMutation(ctx context.Context, userID string, mutation *domain.Mutation) (*domain.MutationResult, error) {
+	lock := s.pushLockFor(mutation.EntityType, mutation.EntityID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	previous, err := s.Mutations.GetMutationResult(ctx, userID, mutation.ClientMutationID)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		return previous, nil
+	}
+
+	result, err := s.applyMutation(ctx, userID, mutation)
+	if err != nil {
+		return nil, err
+	}
+	result.ClientMutationID = mutation.ClientMutationID
+	result.EntityType = mutation.EntityType
+	result.EntityID = mutation.EntityID
+	result.AppliedAt = time.Now()
+
+	if err := s.Mutations.SaveMutationResult(ctx, userID, result); err != nil {
+		return nil, err
+	}
+	metrics.Counter(MetricMutationsPrefix + string(result.Status) + "_total").Add(1)
+	return result, nil
+}
+
+// applyMutation はエンティティの現在のバージョンと比較して変更を適用する
+func (s *SyncService) applyMutation(ctx context.Context, userID string, mutation *domain.Mutation) (*domain.MutationResult, error) {
+	handler, ok := s.handlers[mutation.EntityType]
+	switch {
+	case !ok:
+		return rejected(fmt.Sprintf("unsupported entity_type %q", mutation.EntityType)), nil
+	case !mutation.Operation.IsValid():
+		return rejected(fmt.Sprintf("unsupported op %q", mutation.Operation)), nil
+	case mutation.EntityID == "":
+		return rejected("entity_id is required"), nil
+	}
+
+	current, err := handler.Get(ctx, userID, mutation.EntityID)
+	if err != nil {
+		return rejectedOrError(err)
+	}
+	version, err := s.Changes.GetEntityVersion(ctx, mutation.EntityType, mutation.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entity interface{}
+	switch mutation.Operation {
+	case domain.MutationCreate:
+		if current != nil {
+			return conflict(domain.ConflictAlreadyExists, version, current)
+		}
+		entity, err = handler.Create(ctx, userID, mutation.EntityID, mutation.Data)
+	case domain.MutationUpdate:
+		if current == nil {
+			return conflict(domain.ConflictDeleted, version, nil)
+		}
+		if mutation.BaseVersion != version {
+			return conflict(domain.ConflictVersionMismatch, version, current)
+		}
+		entity, err = handler.Update(ctx, userID, mutation.EntityID, mutation.Data)
+	case domain.MutationDelete:
+		// 削除済みのエンティティの削除は、クライアントの意図どおりの状態のため適用済みとする
+		if current == nil {
+			return &domain.MutationResult{Status: domain.MutationAccepted, Version: version}, nil
+		}
+		if mutation.BaseVersion != version {
+			return conflict(domain.ConflictVersionMismatch, version, current)
+		}
+		err = handler.Delete(ctx, userID, mutation.EntityID)
+	}
+	if err != nil {
+		return rejectedOrError(err)
+	}
+
+	// 適用した変更は各モジュールが記録しているため、記録後のバージョンを返す
+	if version, err = s.Changes.GetEntityVersion(ctx, mutation.EntityType, mutation.EntityID); err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to get entity version after sync mutation",
+			logger.Any("entityType", mutation.EntityType),
+			logger.Any("entityID", mutation.EntityID),
+			logger.Error(err))
+	}
+	result := &domain.MutationResult{Status: domain.MutationAccepted, Version: version}
+	if entity != nil {
+		if result.Data, err = json.Marshal(entity); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// pushLockFor はエンティティへのクライアントの変更の適用に使うロックを返す
+func (s *SyncService) pushLockFor(entityType, entityID string) *sync.Mutex {
+	return &s.pushLocks[lockStripe(entityType, entityID)]
+}
+
+// conflict はサーバーの現在の状態を含む競合の結果を作成する
+func conflict(reason string, version int64, current interface{}) (*domain.MutationResult, error) {
+	result := &domain.MutationResult{Status: domain.MutationConflict, Version: version, Reason: reason}
+	if current != nil {
+		data, err := json.Marshal(current)
+		if err != nil {
+			return nil, err
+		}
+		result.Data = data
+	}
+	return result, nil
+}
+
+// rejected は適用しなかった変更の結果を作成する
+func rejected(reason string) *domain.MutationResult {
+	return &domain.MutationResult{Status: domain.MutationRejected, Reason: reason}
+}
+
+// rejectedOrError は変更の内容が原因のエラーを rejected の結果に変換し、それ以外のエラーはそのまま返す
+func rejectedOrError(err error) (*domain.MutationResult, error) {
+	if errors.Is(err, domain.ErrMutationRejected) {
+		return rejected(err.Error()), nil
+	}
+	return nil, err
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/usecase/mocks"
+)
+
+// fakeHandler はエンティティをマップで保持するテスト用のMutationHandler
+type fakeHandler struct {
+	entities map[string]map[string]string
+	applied  []domain.MutationOp
+	err      error
+}
+
+func (h *fakeHandler) Get(ctx context.Context, userID, entityID string) (interface{}, error) {
+	if entity, ok := h.entities[entityID]; ok {
+		return entity, nil
+	}
+	return nil, nil
+}
+
+func (h *fakeHandler) Create(ctx context.Context, userID, entityID string, data json.RawMessage) (interface{}, error) {
+	return h.apply(domain.MutationCreate, entityID, data)
+}
+
+func (h *fakeHandler) Update(ctx context.Context, userID, entityID string, data json.RawMessage) (interface{}, error) {
+	return h.apply(domain.MutationUpdate, entityID, data)
+}
+
+func (h *fakeHandler) Delete(ctx context.Context, userID, entityID string) error {
+	_, err := h.apply(domain.MutationDelete, entityID, nil)
+	return err
+}
+
+func (h *fakeHandler) apply(op domain.MutationOp, entityID string, data json.RawMessage) (interface{}, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.applied = append(h.applied, op)
+	if op == domain.MutationDelete {
+		delete(h.entities, entityID)
+		return nil, nil
+	}
+	var entity map[string]string
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrMutationRejected, err)
+	}
+	h.entities[entityID] = entity
+	return entity, nil
+}
+
+func newPushTestService(t *testing.T) (*SyncService, *mocks.MockChangeRepository, *mocks.MockMutationRepository, *fakeHandler) {
+	service, changes := newTestService(t)
+	handler := &fakeHandler{entities: map[string]map[string]string{
+		"task-1": {"title": "Buy milk"},
+	}}
+	service.RegisterHandler(commonDomain.SyncEntityTask, handler)
+	return service, changes, service.Mutations.(*mocks.MockMutationRepository), handler
+}
+
+func taskMutation(id string, op domain.MutationOp, entityID string, baseVersion int64, data string) *domain.Mutation {
+	mutation := &domain.Mutation{
+		ClientMutationID: id,
+		EntityType:       commonDomain.SyncEntityTask,
+		EntityID:         entityID,
+		Operation:        op,
+		BaseVersion:      baseVersion,
+	}
+	if data != "" {
+		mutation.Data = json.RawMessage(data)
+	}
+	return mutation
+}
+
+func TestSyncService_Push(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("mutations based on the current version are applied", func(t *testing.T) {
+		service, changes, mutations, handler := newPushTestService(t)
+		mutations.EXPECT().GetMutationResult(gomock.Any(), "user-1", gomock.Any()).Return(nil, nil).Times(2)
+		mutations.EXPECT().SaveMutationResult(gomock.Any(), "user-1", gomock.Any()).Return(nil).Times(2)
+		gomock.InOrder(
+			changes.EXPECT().GetEntityVersion(gomock.Any(), commonDomain.SyncEntityTask, "task-2").Return(int64(0), nil),
+			changes.EXPECT().GetEntityVersion(gomock.Any(), commonDomain.SyncEntityTask, "task-2").Return(int64(12), nil),
+		)
+		gomock.InOrder(
+			changes.EXPECT().GetEntityVersion(gomock.Any(), commonDomain.SyncEntityTask, "task-1").Return(int64(7), nil),
+			changes.EXPECT().GetEntityVersion(gomock.Any(), commonDomain.SyncEntityTask, "task-1").Return(int64(13), nil),
+		)
+
+		results, err := service.Push(ctx, "user-1", []*domain.Mutation{
+			taskMutation("m-1", domain.MutationCreate, "task-2", 0, `{"title":"Call mom"}`),
+			taskMutation("m-2", domain.MutationUpdate, "task-1", 7, `{"title":"Buy oat milk"}`),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, results, 2)
+		assert.Equal(t, "m-1", results[0].ClientMutationID)
+		assert.Equal(t, domain.MutationAccepted, results[0].Status)
+		assert.Equal(t, int64(12), results[0].Version)
+		assert.Equal(t, domain.MutationAccepted, results[1].Status)
+		assert.Equal(t, int64(13), results[1].Version)
+		assert.JSONEq(t, `{"title":"Buy oat milk"}`, string(results[1].Data))
+		assert.Equal(t, []domain.MutationOp{domain.MutationCreate, domain.MutationUpdate}, handler.applied)
+	})
+
+	t.Run("stale mutations conflict with the server state", func(t *testing.T) {
+		service, changes, mutations, handler := newPushTestService(t)
+		mutations.EXPECT().GetMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+		mutations.EXPECT().SaveMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		changes.EXPECT().GetEntityVersion(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(9), nil).Times(3)
+
+		results, err := service.Push(ctx, "user-1", []*domain.Mutation{
+			taskMutation("m-1", domain.MutationUpdate, "task-1", 7, `{"title":"Buy oat milk"}`),
+			taskMutation("m-2", domain.MutationCreate, "task-1", 0, `{"title":"Buy milk"}`),
+			taskMutation("m-3", domain.MutationUpdate, "task-9", 9, `{"title":"Gone"}`),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.MutationConflict, results[0].Status)
+		assert.Equal(t, domain.ConflictVersionMismatch, results[0].Reason)
+		assert.Equal(t, int64(9), results[0].Version)
+		assert.JSONEq(t, `{"title":"Buy milk"}`, string(results[0].Data))
+		assert.Equal(t, domain.ConflictAlreadyExists, results[1].Reason)
+		assert.Equal(t, domain.ConflictDeleted, results[2].Reason)
+		assert.Empty(t, results[2].Data)
+		assert.Empty(t, handler.applied)
+	})
+
+	t.Run("deleting a deleted entity is accepted", func(t *testing.T) {
+		service, changes, mutations, handler := newPushTestService(t)
+		mutations.EXPECT().GetMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		mutations.EXPECT().SaveMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		changes.EXPECT().GetEntityVersion(gomock.Any(), gomock.Any(), "task-9").Return(int64(4), nil)
+
+		results, err := service.Push(ctx, "user-1", []*domain.Mutation{
+			taskMutation("m-1", domain.MutationDelete, "task-9", 2, ""),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.MutationAccepted, results[0].Status)
+		assert.Empty(t, handler.applied)
+	})
+
+	t.Run("resent mutations return the previous result", func(t *testing.T) {
+		service, _, mutations, handler := newPushTestService(t)
+		previous := &domain.MutationResult{ClientMutationID: "m-1", Status: domain.MutationAccepted, Version: 12}
+		mutations.EXPECT().GetMutationResult(gomock.Any(), "user-1", "m-1").Return(previous, nil)
+
+		results, err := service.Push(ctx, "user-1", []*domain.Mutation{
+			taskMutation("m-1", domain.MutationUpdate, "task-1", 7, `{"title":"Buy oat milk"}`),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*domain.MutationResult{previous}, results)
+		assert.Empty(t, handler.applied)
+	})
+
+	t.Run("invalid mutations are rejected", func(t *testing.T) {
+		service, changes, mutations, _ := newPushTestService(t)
+		mutations.EXPECT().GetMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+		mutations.EXPECT().SaveMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		changes.EXPECT().GetEntityVersion(gomock.Any(), gomock.Any(), "task-1").Return(int64(7), nil)
+
+		unknown := taskMutation("m-1", domain.MutationCreate, "group-1", 0, `{}`)
+		unknown.EntityType = commonDomain.SyncEntityGroup
+		results, err := service.Push(ctx, "user-1", []*domain.Mutation{
+			unknown,
+			taskMutation("m-2", "upsert", "task-1", 7, `{}`),
+			taskMutation("m-3", domain.MutationUpdate, "task-1", 7, `"not an object"`),
+		})
+		require.NoError(t, err)
+
+		for _, result := range results {
+			assert.Equal(t, domain.MutationRejected, result.Status, result.ClientMutationID)
+			assert.NotEmpty(t, result.Reason)
+		}
+	})
+
+	t.Run("failures stop the batch so it can be resent", func(t *testing.T) {
+		service, changes, mutations, handler := newPushTestService(t)
+		handler.err = errors.New("db down")
+		mutations.EXPECT().GetMutationResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		changes.EXPECT().GetEntityVersion(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(7), nil)
+
+		_, err := service.Push(ctx, "user-1", []*domain.Mutation{
+			taskMutation("m-1", domain.MutationUpdate, "task-1", 7, `{"title":"Buy oat milk"}`),
+			taskMutation("m-2", domain.MutationDelete, "task-1", 7, ""),
+		})
+		assert.EqualError(t, err, "db down")
+	})
+
+	t.Run("requires client mutation ids and a bounded batch", func(t *testing.T) {
+		service, _, _, _ := newPushTestService(t)
+
+		_, err := service.Push(ctx, "user-1", []*domain.Mutation{taskMutation("", domain.MutationDelete, "task-1", 7, "")})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		batch := make([]*domain.Mutation, MaxPushMutations+1)
+		for i := range batch {
+			batch[i] = taskMutation(fmt.Sprintf("m-%d", i), domain.MutationDelete, "task-1", 7, "")
+		}
+		_, err = service.Push(ctx, "user-1", batch)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+)
+
+// ChangeRepository はオフライン同期用の変更フィードのリポジトリインターフェース
+type ChangeRepository interface {
+	// AppendChanges は変更に通し番号を振って記録し、エンティティの変更を受け取るユーザーをrecipientsに置き換える
+	// 通し番号は記録した順に単調増加し、大きな番号の変更が小さな番号の変更より先に参照できるようになることはない
+	// エンティティのバージョンは今回振った最大の通し番号に更新し、各変更のVersionに設定する
+	AppendChanges(ctx context.Context, entityType, entityID string, changes []*domain.Change, recipients []string) error
+	// ListRecipients はエンティティの変更を受け取っているユーザーを取得する
+	ListRecipients(ctx context.Context, entityType, entityID string) ([]string, error)
+	// GetEntityVersion はエンティティの現在のバージョンを取得する（変更を記録したことがない場合は0）
+	GetEntityVersion(ctx context.Context, entityType, entityID string) (int64, error)
+	// ListChanges はユーザーへの通し番号afterSeqより後の変更を通し番号順にlimit件まで取得する
+	ListChanges(ctx context.Context, userID string, afterSeq int64, limit int) ([]*domain.Change, error)
+	// GetSequence は最新の通し番号と、保持期間を過ぎて削除した変更の最大の通し番号を取得する
+	GetSequence(ctx context.Context) (latest int64, prunedThrough int64, err error)
+	// PruneChanges はbeforeより前に記録した変更を削除し、削除した件数を返す
+	PruneChanges(ctx context.Context, before time.Time) (int64, error)
+}
+
+// MutationRepository はクライアントの変更の適用結果のリポジトリインターフェース
+// 同じ変更が再送された場合に、適用し直さずに以前の結果を返すために使用する
+type MutationRepository interface {
+	// GetMutationResult はユーザーの変更の適用結果を取得する（記録がない場合はnil）
+	GetMutationResult(ctx context.Context, userID, clientMutationID string) (*domain.MutationResult, error)
+	// SaveMutationResult はユーザーの変更の適用結果を記録する
+	SaveMutationResult(ctx context.Context, userID string, result *domain.MutationResult) error
+	// PruneMutationResults はbeforeより前に適用した変更の結果を削除し、削除した件数を返す
+	PruneMutationResults(ctx context.Context, before time.Time) (int64, error)
+}
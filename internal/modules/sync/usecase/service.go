@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+const (
+	// CursorNow はカーソルに指定すると変更を返さずに最新のカーソルだけを返す値
+	// 全件を取得し直す前にこのカーソルを取得しておけば、取得中の変更も取りこぼさない
+	CursorNow = "now"
+	// DefaultLimit は一度に返す変更の件数の既定値
+	DefaultLimit = 100
+	// MaxLimit は一度に返す変更の件数の上限
+	MaxLimit = 500
+	// lockStripes は同じエンティティの変更の記録を直列化するロックの数
+	lockStripes = 64
+)
+
+// 変更フィードのメトリクス名
+const (
+	MetricChangesRecorded = "sync_changes_recorded_total"
+	MetricRecordFailures  = "sync_record_failures_total"
+	MetricChangesPruned   = "sync_changes_pruned_total"
+	// クライアントの変更の適用結果ごとの件数（sync_mutations_accepted_total など）
+	MetricMutationsPrefix = "sync_mutations_"
+)
+
+// ChangesPage は変更フィードの1ページ
+type ChangesPage struct {
+	Changes []*domain.Change `json:"changes"`
+	// 次の取得で since に指定するカーソル
+	NextCursor string `json:"next_cursor" example:"djE6MTI4"`
+	// trueの場合は続きの変更がある
+	HasMore bool `json:"has_more" example:"false"`
+} // @name SyncChangesPage
+
+// SyncService はオフライン同期用の変更フィードを記録・配信するサービス
+// エンティティの変更を参照できるユーザーごとに通し番号付きで記録し、カーソル以降の変更を返す
+type SyncService struct {
+	Changes   ChangeRepository
+	Mutations MutationRepository
+	// 変更とクライアントの変更の適用結果を保持する期間（0以下の場合は削除しない）
+	Retention time.Duration
+	Logger    logger.Logger
+
+	// エンティティの種類ごとのクライアントの変更の適用先
+	handlers map[string]MutationHandler
+
+	// 受け取るユーザーの比較と記録の間に同じエンティティの変更が割り込まないようにする
+	locks [lockStripes]sync.Mutex
+	// 同じエンティティへのクライアントの変更の適用を直列化する
+	// 適用中に各モジュールが変更を記録するため、記録用のロックとは別に持つ
+	pushLocks [lockStripes]sync.Mutex
+}
+
+// NewSyncService はSyncServiceのコンストラクタ
+func NewSyncService(changes ChangeRepository, mutations MutationRepository, retention time.Duration, logger logger.Logger) *SyncService {
+	return &SyncService{
+		Changes:   changes,
+		Mutations: mutations,
+		Retention: retention,
+		Logger:    logger,
+		handlers:  make(map[string]MutationHandler),
+	}
+}
+
+// GetChanges はカーソル以降にユーザーが参照できるエンティティの変更を取得する
+// 同じページ内で複数回変更されたエンティティは最後の変更だけを返す
+func (s *SyncService) GetChanges(ctx context.Context, userID, cursor string, limit int) (*ChangesPage, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	latest, prunedThrough, err := s.Changes.GetSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor == CursorNow {
+		return &ChangesPage{Changes: []*domain.Change{}, NextCursor: domain.EncodeCursor(latest)}, nil
+	}
+
+	afterSeq, err := domain.ParseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if afterSeq > latest {
+		return nil, domain.ErrInvalidCursor
+	}
+	if afterSeq < prunedThrough {
+		return nil, domain.ErrCursorExpired
+	}
+
+	changes, err := s.Changes.ListChanges(ctx, userID, afterSeq, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	nextSeq := afterSeq
+	if len(changes) > 0 {
+		nextSeq = changes[len(changes)-1].Seq
+	}
+
+	return &ChangesPage{
+		Changes:    latestPerEntity(changes),
+		NextCursor: domain.EncodeCursor(nextSeq),
+		HasMore:    hasMore,
+	}, nil
+}
+
+// RecordUpsert はエンティティの作成・更新をuserIDsのユーザーに記録する
+// 以前に変更を受け取ったユーザーのうちuserIDsに含まれないユーザーには削除として記録する
+func (s *SyncService) RecordUpsert(ctx context.Context, entityType, entityID string, userIDs []string, entity interface{}) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		s.recordFailed(ctx, entityType, entityID, err)
+		return
+	}
+
+	recipients := uniqueIDs(userIDs)
+	s.record(ctx, entityType, entityID, recipients, func(previous []string) []*domain.Change {
+		now := time.Now()
+		current := make(map[string]bool, len(recipients))
+		var changes []*domain.Change
+		for _, userID := range recipients {
+			current[userID] = true
+			changes = append(changes, &domain.Change{
+				UserID:     userID,
+				EntityType: entityType,
+				EntityID:   entityID,
+				Operation:  domain.OperationUpsert,
+				Data:       data,
+				ChangedAt:  now,
+			})
+		}
+		for _, userID := range previous {
+			if !current[userID] {
+				changes = append(changes, newDeleteChange(userID, entityType, entityID, now))
+			}
+		}
+		return changes
+	})
+}
+
+// RecordDelete はエンティティの削除を、以前に変更を受け取ったすべてのユーザーに記録する
+func (s *SyncService) RecordDelete(ctx context.Context, entityType, entityID string) {
+	s.record(ctx, entityType, entityID, nil, func(previous []string) []*domain.Change {
+		now := time.Now()
+		changes := make([]*domain.Change, 0, len(previous))
+		for _, userID := range previous {
+			changes = append(changes, newDeleteChange(userID, entityType, entityID, now))
+		}
+		return changes
+	})
+}
+
+// record は以前の受け取りユーザーから作成した変更を記録し、受け取りユーザーをrecipientsに置き換える
+func (s *SyncService) record(ctx context.Context, entityType, entityID string, recipients []string, build func(previous []string) []*domain.Change) {
+	lock := s.lockFor(entityType, entityID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	previous, err := s.Changes.ListRecipients(ctx, entityType, entityID)
+	if err != nil {
+		s.recordFailed(ctx, entityType, entityID, err)
+		return
+	}
+
+	changes := build(previous)
+	if len(changes) == 0 {
+		return
+	}
+
+	if err := s.Changes.AppendChanges(ctx, entityType, entityID, changes, recipients); err != nil {
+		s.recordFailed(ctx, entityType, entityID, err)
+		return
+	}
+	metrics.Counter(MetricChangesRecorded).Add(int64(len(changes)))
+}
+
+// recordFailed は記録の失敗をログとメトリクスに残す（呼び出し元の処理は続行する）
+func (s *SyncService) recordFailed(ctx context.Context, entityType, entityID string, err error) {
+	metrics.Counter(MetricRecordFailures).Add(1)
+	s.Logger.WithContext(ctx).Error("Failed to record sync change",
+		logger.Any("entityType", entityType),
+		logger.Any("entityID", entityID),
+		logger.Error(err))
+}
+
+// lockFor はエンティティの変更の記録に使うロックを返す
+func (s *SyncService) lockFor(entityType, entityID string) *sync.Mutex {
+	return &s.locks[lockStripe(entityType, entityID)]
+}
+
+// lockStripe はエンティティに対応するロックの番号を返す
+func lockStripe(entityType, entityID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(entityType + ":" + entityID))
+	return h.Sum32() % lockStripes
+}
+
+// Prune は保持期間を過ぎた変更とクライアントの変更の適用結果を削除し、削除した変更の件数を返す
+func (s *SyncService) Prune(ctx context.Context, now time.Time) (int64, error) {
+	if s.Retention <= 0 {
+		return 0, nil
+	}
+	before := now.Add(-s.Retention)
+	pruned, err := s.Changes.PruneChanges(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	metrics.Counter(MetricChangesPruned).Add(pruned)
+
+	if _, err := s.Mutations.PruneMutationResults(ctx, before); err != nil {
+		return pruned, err
+	}
+	return pruned, nil
+}
+
+// newDeleteChange は削除（tombstone）の変更を作成する
+func newDeleteChange(userID, entityType, entityID string, changedAt time.Time) *domain.Change {
+	return &domain.Change{
+		UserID:     userID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  domain.OperationDelete,
+		ChangedAt:  changedAt,
+	}
+}
+
+// latestPerEntity はエンティティごとに最後の変更だけを通し番号順に残す
+func latestPerEntity(changes []*domain.Change) []*domain.Change {
+	last := make(map[string]int, len(changes))
+	for i, change := range changes {
+		last[change.EntityType+":"+change.EntityID] = i
+	}
+
+	result := make([]*domain.Change, 0, len(last))
+	for i, change := range changes {
+		if last[change.EntityType+":"+change.EntityID] == i {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// uniqueIDs は空のIDと重複を取り除く
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
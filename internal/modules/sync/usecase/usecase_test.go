@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	"github.com/hryt430/Yotei+/internal/modules/sync/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+func newTestService(t *testing.T) (*SyncService, *mocks.MockChangeRepository) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockChangeRepository(ctrl)
+	service := NewSyncService(repo, mocks.NewMockMutationRepository(ctrl), 0, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	return service, repo
+}
+
+// operationsByUser は変更をユーザーごとの操作にまとめる
+func operationsByUser(changes []*domain.Change) map[string]domain.Operation {
+	ops := make(map[string]domain.Operation, len(changes))
+	for _, change := range changes {
+		ops[change.UserID] = change.Operation
+	}
+	return ops
+}
+
+func TestSyncService_RecordUpsert(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every recipient gets a snapshot of the entity", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().ListRecipients(gomock.Any(), commonDomain.SyncEntityTask, "task-1").Return(nil, nil)
+
+		var recorded []*domain.Change
+		repo.EXPECT().AppendChanges(gomock.Any(), commonDomain.SyncEntityTask, "task-1", gomock.Any(), []string{"user-1", "user-2"}).
+			DoAndReturn(func(_ context.Context, _, _ string, changes []*domain.Change, _ []string) error {
+				recorded = changes
+				return nil
+			})
+
+		service.RecordUpsert(ctx, commonDomain.SyncEntityTask, "task-1", []string{"user-1", "user-2", "user-1", ""},
+			map[string]string{"title": "Buy milk"})
+
+		require.Len(t, recorded, 2)
+		assert.Equal(t, map[string]domain.Operation{"user-1": domain.OperationUpsert, "user-2": domain.OperationUpsert}, operationsByUser(recorded))
+		assert.JSONEq(t, `{"title":"Buy milk"}`, string(recorded[0].Data))
+	})
+
+	t.Run("users who can no longer see the entity get a tombstone", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().ListRecipients(gomock.Any(), commonDomain.SyncEntityGroup, "group-1").Return([]string{"user-1", "user-2"}, nil)
+
+		var recorded []*domain.Change
+		repo.EXPECT().AppendChanges(gomock.Any(), commonDomain.SyncEntityGroup, "group-1", gomock.Any(), []string{"user-1"}).
+			DoAndReturn(func(_ context.Context, _, _ string, changes []*domain.Change, _ []string) error {
+				recorded = changes
+				return nil
+			})
+
+		service.RecordUpsert(ctx, commonDomain.SyncEntityGroup, "group-1", []string{"user-1"}, map[string]string{})
+
+		assert.Equal(t, map[string]domain.Operation{"user-1": domain.OperationUpsert, "user-2": domain.OperationDelete}, operationsByUser(recorded))
+		for _, change := range recorded {
+			if change.Operation == domain.OperationDelete {
+				assert.Empty(t, change.Data)
+			}
+		}
+	})
+
+	t.Run("failures do not reach the caller", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().ListRecipients(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		repo.EXPECT().AppendChanges(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+		assert.NotPanics(t, func() {
+			service.RecordUpsert(ctx, commonDomain.SyncEntityTask, "task-1", []string{"user-1"}, map[string]string{})
+		})
+	})
+}
+
+func TestSyncService_RecordDelete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("everyone who received the entity gets a tombstone", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().ListRecipients(gomock.Any(), commonDomain.SyncEntityTask, "task-1").Return([]string{"user-1", "user-2"}, nil)
+
+		var recorded []*domain.Change
+		repo.EXPECT().AppendChanges(gomock.Any(), commonDomain.SyncEntityTask, "task-1", gomock.Any(), nil).
+			DoAndReturn(func(_ context.Context, _, _ string, changes []*domain.Change, _ []string) error {
+				recorded = changes
+				return nil
+			})
+
+		service.RecordDelete(ctx, commonDomain.SyncEntityTask, "task-1")
+
+		assert.Equal(t, map[string]domain.Operation{"user-1": domain.OperationDelete, "user-2": domain.OperationDelete}, operationsByUser(recorded))
+	})
+
+	t.Run("entities nobody received are not recorded", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().ListRecipients(gomock.Any(), commonDomain.SyncEntityTask, "task-1").Return(nil, nil)
+
+		// AppendChangesは呼ばれない
+		service.RecordDelete(ctx, commonDomain.SyncEntityTask, "task-1")
+	})
+}
+
+func TestSyncService_GetChanges(t *testing.T) {
+	ctx := context.Background()
+
+	change := func(seq int64, entityID string, op domain.Operation) *domain.Change {
+		return &domain.Change{Seq: seq, UserID: "user-1", EntityType: commonDomain.SyncEntityTask, EntityID: entityID, Operation: op}
+	}
+
+	t.Run("only the latest change of each entity is returned", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(10), int64(0), nil)
+		repo.EXPECT().ListChanges(gomock.Any(), "user-1", int64(0), DefaultLimit+1).Return([]*domain.Change{
+			change(3, "task-1", domain.OperationUpsert),
+			change(5, "task-2", domain.OperationUpsert),
+			change(7, "task-1", domain.OperationDelete),
+		}, nil)
+
+		page, err := service.GetChanges(ctx, "user-1", "", 0)
+		require.NoError(t, err)
+
+		require.Len(t, page.Changes, 2)
+		assert.Equal(t, "task-2", page.Changes[0].EntityID)
+		assert.Equal(t, domain.OperationDelete, page.Changes[1].Operation)
+		assert.Equal(t, domain.EncodeCursor(7), page.NextCursor)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("the next cursor continues after the last change of the page", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(10), int64(0), nil)
+		repo.EXPECT().ListChanges(gomock.Any(), "user-1", int64(2), 3).Return([]*domain.Change{
+			change(3, "task-1", domain.OperationUpsert),
+			change(5, "task-2", domain.OperationUpsert),
+			change(8, "task-3", domain.OperationUpsert),
+		}, nil)
+
+		page, err := service.GetChanges(ctx, "user-1", domain.EncodeCursor(2), 2)
+		require.NoError(t, err)
+
+		assert.Len(t, page.Changes, 2)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, domain.EncodeCursor(5), page.NextCursor)
+	})
+
+	t.Run("an empty page keeps the cursor", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(10), int64(0), nil)
+		repo.EXPECT().ListChanges(gomock.Any(), "user-1", int64(10), gomock.Any()).Return(nil, nil)
+
+		page, err := service.GetChanges(ctx, "user-1", domain.EncodeCursor(10), 0)
+		require.NoError(t, err)
+
+		assert.Empty(t, page.Changes)
+		assert.Equal(t, domain.EncodeCursor(10), page.NextCursor)
+	})
+
+	t.Run("now returns the latest cursor without changes", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(42), int64(0), nil)
+
+		page, err := service.GetChanges(ctx, "user-1", CursorNow, 0)
+		require.NoError(t, err)
+
+		assert.Empty(t, page.Changes)
+		assert.Equal(t, domain.EncodeCursor(42), page.NextCursor)
+	})
+
+	t.Run("cursors older than the pruned changes have expired", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(42), int64(20), nil)
+
+		_, err := service.GetChanges(ctx, "user-1", domain.EncodeCursor(19), 0)
+		assert.ErrorIs(t, err, domain.ErrCursorExpired)
+
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(42), int64(20), nil)
+		_, err = service.GetChanges(ctx, "user-1", "", 0)
+		assert.ErrorIs(t, err, domain.ErrCursorExpired)
+	})
+
+	t.Run("malformed and future cursors are rejected", func(t *testing.T) {
+		service, repo := newTestService(t)
+		repo.EXPECT().GetSequence(gomock.Any()).Return(int64(42), int64(0), nil).Times(2)
+
+		_, err := service.GetChanges(ctx, "user-1", "not-a-cursor", 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+
+		_, err = service.GetChanges(ctx, "user-1", domain.EncodeCursor(43), 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+
+	t.Run("requires a user", func(t *testing.T) {
+		service, _ := newTestService(t)
+
+		_, err := service.GetChanges(ctx, "", "", 0)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestSyncService_Prune(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("changes older than the retention are pruned", func(t *testing.T) {
+		service, repo := newTestService(t)
+		service.Retention = 24 * time.Hour
+		repo.EXPECT().PruneChanges(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(3), nil)
+		service.Mutations.(*mocks.MockMutationRepository).EXPECT().
+			PruneMutationResults(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(1), nil)
+
+		pruned, err := service.Prune(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), pruned)
+	})
+
+	t.Run("nothing is pruned without a retention", func(t *testing.T) {
+		service, _ := newTestService(t)
+
+		pruned, err := service.Prune(context.Background(), now)
+		require.NoError(t, err)
+		assert.Zero(t, pruned)
+	})
+}
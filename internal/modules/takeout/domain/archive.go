@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveFormatVersion はエクスポートのZIPの形式のバージョン（項目を削除・変更した場合に上げる）
+const ArchiveFormatVersion = 1
+
+// Record はエクスポートする1件のデータ（キーは Section.Columns の列名）
+type Record map[string]interface{}
+
+// Section はエクスポートするデータの種類ごとのまとまり
+// ZIPには <Name>.json（レコードの配列）と <Name>.csv（Columns の順の列）の2つのファイルとして書き出す
+type Section struct {
+	Name    string
+	Columns []string
+	Records []Record
+}
+
+// ManifestSection はマニフェストに記載するファイルごとの件数
+type ManifestSection struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+}
+
+// Manifest はZIPに含めるエクスポートの概要（manifest.json）
+type Manifest struct {
+	FormatVersion int               `json:"format_version"`
+	ExportID      string            `json:"export_id"`
+	UserID        string            `json:"user_id"`
+	GeneratedAt   time.Time         `json:"generated_at"`
+	Sections      []ManifestSection `json:"sections"`
+}
+
+// WriteArchive はセクションを JSON と CSV のファイルにしてZIPに書き出す（先頭に manifest.json を置く）
+func WriteArchive(w io.Writer, export *Export, generatedAt time.Time, sections []*Section) error {
+	zw := zip.NewWriter(w)
+
+	manifest := Manifest{
+		FormatVersion: ArchiveFormatVersion,
+		ExportID:      export.ID.String(),
+		UserID:        export.UserID.String(),
+		GeneratedAt:   generatedAt.UTC(),
+		Sections:      make([]ManifestSection, 0, len(sections)),
+	}
+	for _, section := range sections {
+		manifest.Sections = append(manifest.Sections, ManifestSection{Name: section.Name, Records: len(section.Records)})
+	}
+	if err := writeJSON(zw, "manifest.json", generatedAt, manifest); err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		records := section.Records
+		if records == nil {
+			records = []Record{}
+		}
+		if err := writeJSON(zw, section.Name+".json", generatedAt, records); err != nil {
+			return err
+		}
+		if err := writeCSV(zw, section, generatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// createFile はZIPにファイルを追加する（更新日時はエクスポートした日時にする）
+func createFile(zw *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	return f, nil
+}
+
+func writeJSON(zw *zip.Writer, name string, modified time.Time, v interface{}) error {
+	f, err := createFile(zw, name, modified)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func writeCSV(zw *zip.Writer, section *Section, modified time.Time) error {
+	name := section.Name + ".csv"
+	f, err := createFile(zw, name, modified)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.Write(section.Columns); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	row := make([]string, len(section.Columns))
+	for _, record := range section.Records {
+		for i, column := range section.Columns {
+			row[i] = FormatCSVValue(record[column])
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// FormatCSVValue はレコードの値をCSVのセルの文字列にする
+// 日時はRFC3339（UTC）、文字列・整数の配列は「;」区切り、nil は空文字にする
+func FormatCSVValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if value == nil {
+			return ""
+		}
+		return FormatCSVValue(*value)
+	case []string:
+		return strings.Join(value, ";")
+	case []int:
+		values := make([]string, len(value))
+		for i, v := range value {
+			values[i] = strconv.Itoa(v)
+		}
+		return strings.Join(values, ";")
+	case *int:
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(*value)
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
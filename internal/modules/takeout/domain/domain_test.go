@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func TestExport_Lifecycle(t *testing.T) {
+	userID := uuid.New()
+	export := NewExport(userID, testNow)
+	assert.Equal(t, ExportPending, export.Status)
+	assert.True(t, export.IsActive())
+	assert.ErrorIs(t, export.CheckDownloadable(testNow), ErrExportNotReady)
+
+	export.Start(testNow)
+	assert.Equal(t, ExportProcessing, export.Status)
+	assert.True(t, export.IsActive())
+
+	key := ExportFileKey(userID, export.ID)
+	assert.Equal(t, "exports/"+userID.String()+"/"+export.ID.String()+".zip", key)
+	export.Complete(key, 2048, 24*time.Hour, testNow.Add(time.Minute))
+	assert.Equal(t, ExportReady, export.Status)
+	assert.False(t, export.IsActive())
+	assert.Equal(t, int64(2048), export.SizeBytes)
+	assert.Equal(t, testNow.Add(24*time.Hour+time.Minute), *export.ExpiresAt)
+	assert.NoError(t, export.CheckDownloadable(testNow.Add(time.Hour)))
+	assert.ErrorIs(t, export.CheckDownloadable(testNow.Add(25*time.Hour)), ErrExportExpired)
+	assert.Equal(t, "yotei-plus-export-20240601.zip", export.FileName())
+
+	export.Expire()
+	assert.Equal(t, ExportExpired, export.Status)
+	assert.Empty(t, export.FileKey)
+	assert.ErrorIs(t, export.CheckDownloadable(testNow), ErrExportExpired)
+}
+
+func TestExport_Fail(t *testing.T) {
+	export := NewExport(uuid.New(), testNow)
+	export.Start(testNow)
+	export.Fail("failed to build export", testNow)
+
+	assert.Equal(t, ExportFailed, export.Status)
+	assert.False(t, export.IsActive())
+	assert.ErrorIs(t, export.CheckDownloadable(testNow), ErrExportNotReady)
+}
+
+func TestFormatCSVValue(t *testing.T) {
+	at := time.Date(2024, 6, 1, 18, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	var nilTime *time.Time
+	progress := 40
+
+	assert.Equal(t, "", FormatCSVValue(nil))
+	assert.Equal(t, "text", FormatCSVValue("text"))
+	assert.Equal(t, "2024-06-01T09:30:00Z", FormatCSVValue(at))
+	assert.Equal(t, "2024-06-01T09:30:00Z", FormatCSVValue(&at))
+	assert.Equal(t, "", FormatCSVValue(nilTime))
+	assert.Equal(t, "", FormatCSVValue(time.Time{}))
+	assert.Equal(t, "work;urgent", FormatCSVValue([]string{"work", "urgent"}))
+	assert.Equal(t, "1;2;3", FormatCSVValue([]int{1, 2, 3}))
+	assert.Equal(t, "40", FormatCSVValue(&progress))
+	assert.Equal(t, "true", FormatCSVValue(true))
+	assert.Equal(t, "3", FormatCSVValue(3))
+}
+
+func TestWriteArchive(t *testing.T) {
+	export := NewExport(uuid.New(), testNow)
+	sections := []*Section{
+		{
+			Name:    "tasks",
+			Columns: []string{"id", "title", "tags", "due_date"},
+			Records: []Record{
+				{"id": "task-1", "title": "資料作成, 提出", "tags": []string{"work", "urgent"}, "due_date": testNow},
+				{"id": "task-2", "title": "買い物", "tags": []string{}, "due_date": nil},
+			},
+		},
+		{Name: "groups", Columns: []string{"id", "name"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, export, testNow, sections))
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	var names []string
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = content
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"manifest.json", "tasks.json", "tasks.csv", "groups.json", "groups.csv"}, names)
+
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, ArchiveFormatVersion, manifest.FormatVersion)
+	assert.Equal(t, export.UserID.String(), manifest.UserID)
+	assert.Equal(t, []ManifestSection{{Name: "tasks", Records: 2}, {Name: "groups", Records: 0}}, manifest.Sections)
+
+	var tasks []map[string]interface{}
+	require.NoError(t, json.Unmarshal(files["tasks.json"], &tasks))
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "資料作成, 提出", tasks[0]["title"])
+	assert.Equal(t, []interface{}{"work", "urgent"}, tasks[0]["tags"])
+
+	rows, err := csv.NewReader(bytes.NewReader(files["tasks.csv"])).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "title", "tags", "due_date"},
+		{"task-1", "資料作成, 提出", "work;urgent", "2024-06-01T09:00:00Z"},
+		{"task-2", "買い物", "", ""},
+	}, rows)
+
+	// 空のセクションは空の配列とヘッダーのみのCSVにする
+	assert.JSONEq(t, "[]", string(files["groups.json"]))
+	assert.Equal(t, "id,name\n", string(files["groups.csv"]))
+}
+
+func TestReadArchive(t *testing.T) {
+	export := NewExport(uuid.New(), testNow)
+	estimate := 30
+	sections := []*Section{
+		{
+			Name:    "tasks",
+			Columns: []string{"id", "assignees", "estimate_minutes", "due_date", "done"},
+			Records: []Record{
+				{"id": "task-1", "assignees": []string{"user-1"}, "estimate_minutes": &estimate, "due_date": testNow, "done": true},
+				{"id": "task-2", "assignees": []string{}, "estimate_minutes": nil, "due_date": nil, "done": false},
+			},
+		},
+		{Name: "working_hours", Columns: []string{"work_days"}, Records: []Record{{"work_days": []int{1, 2, 3}}}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, export, testNow, sections))
+
+	archive, err := ReadArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, export.UserID.String(), archive.SourceUserID)
+	assert.Equal(t, ArchiveFormatVersion, archive.Manifest.FormatVersion)
+	require.Len(t, archive.Sections["tasks"], 2)
+
+	task := archive.Sections["tasks"][0]
+	assert.Equal(t, "task-1", task.String("id"))
+	assert.Equal(t, []string{"user-1"}, task.Strings("assignees"))
+	require.NotNil(t, task.Int("estimate_minutes"))
+	assert.Equal(t, 30, *task.Int("estimate_minutes"))
+	require.NotNil(t, task.Time("due_date"))
+	assert.True(t, testNow.Equal(*task.Time("due_date")))
+	assert.True(t, task.Bool("done"))
+
+	empty := archive.Sections["tasks"][1]
+	assert.Empty(t, empty.Strings("assignees"))
+	assert.Nil(t, empty.Int("estimate_minutes"))
+	assert.Nil(t, empty.Time("due_date"))
+	assert.Equal(t, "", empty.String("missing"))
+	assert.Equal(t, []int{1, 2, 3}, archive.Sections["working_hours"][0].Ints("work_days"))
+}
+
+func TestReadArchive_Invalid(t *testing.T) {
+	// newArchive は指定したファイルだけを含むZIPを作成する
+	newArchive := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			f, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	read := func(data []byte) error {
+		_, err := ReadArchive(bytes.NewReader(data), int64(len(data)))
+		return err
+	}
+	userID := uuid.New().String()
+
+	assert.ErrorIs(t, read([]byte("not a zip")), ErrInvalidArchive)
+	assert.ErrorIs(t, read(newArchive(map[string]string{"tasks.json": "[]"})), ErrInvalidArchive)
+	assert.ErrorIs(t, read(newArchive(map[string]string{
+		"manifest.json": `{"format_version": 2, "user_id": "` + userID + `", "sections": []}`,
+	})), ErrUnsupportedArchive)
+	assert.ErrorIs(t, read(newArchive(map[string]string{
+		"manifest.json": `{"format_version": 0, "user_id": "` + userID + `", "sections": []}`,
+	})), ErrUnsupportedArchive)
+	assert.ErrorIs(t, read(newArchive(map[string]string{
+		"manifest.json": `{"format_version": 1, "user_id": "", "sections": []}`,
+	})), ErrInvalidArchive)
+	// マニフェストに記載されたセクションのファイルがない
+	assert.ErrorIs(t, read(newArchive(map[string]string{
+		"manifest.json": `{"format_version": 1, "user_id": "` + userID + `", "sections": [{"name": "tasks", "records": 1}]}`,
+	})), ErrInvalidArchive)
+	assert.ErrorIs(t, read(newArchive(map[string]string{
+		"manifest.json": `{"format_version": 1, "user_id": "` + userID + `", "sections": [{"name": "tasks", "records": 1}]}`,
+		"tasks.json":    `{"id": "task-1"}`,
+	})), ErrInvalidArchive)
+}
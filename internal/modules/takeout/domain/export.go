@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportStatus はデータのエクスポートの状態
+type ExportStatus string
+
+const (
+	ExportPending    ExportStatus = "PENDING"    // 作成待ち
+	ExportProcessing ExportStatus = "PROCESSING" // 作成中
+	ExportReady      ExportStatus = "READY"      // ダウンロード可能
+	ExportFailed     ExportStatus = "FAILED"     // 作成に失敗した
+	ExportExpired    ExportStatus = "EXPIRED"    // 保存期間が過ぎてファイルを削除した
+)
+
+var (
+	ErrExportNotFound = errors.New("export not found")
+	ErrExportNotReady = errors.New("export is not ready")
+	ErrExportExpired  = errors.New("export has expired")
+)
+
+// DefaultExportRetention はエクスポートしたファイルをダウンロードできる既定の期間
+const DefaultExportRetention = 7 * 24 * time.Hour
+
+// Export はユーザーの全データのエクスポート（ZIP）の依頼
+type Export struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Status      ExportStatus `json:"status"`
+	FileKey     string       `json:"-"` // ストレージのキー（作成済みの場合のみ）
+	SizeBytes   int64        `json:"size_bytes"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	StartedAt   *time.Time   `json:"started_at,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+}
+
+// NewExport は作成待ちのエクスポートを作成する
+func NewExport(userID uuid.UUID, now time.Time) *Export {
+	return &Export{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    ExportPending,
+		CreatedAt: now,
+	}
+}
+
+// IsActive は作成待ち・作成中か判定する
+func (e *Export) IsActive() bool {
+	return e.Status == ExportPending || e.Status == ExportProcessing
+}
+
+// Start は作成を開始する
+func (e *Export) Start(now time.Time) {
+	e.Status = ExportProcessing
+	e.StartedAt = &now
+	e.Error = ""
+}
+
+// Complete は作成したファイルを記録し、retention の間ダウンロードできるようにする
+func (e *Export) Complete(fileKey string, sizeBytes int64, retention time.Duration, now time.Time) {
+	expiresAt := now.Add(retention)
+	e.Status = ExportReady
+	e.FileKey = fileKey
+	e.SizeBytes = sizeBytes
+	e.CompletedAt = &now
+	e.ExpiresAt = &expiresAt
+}
+
+// Fail は作成に失敗したことを記録する
+func (e *Export) Fail(reason string, now time.Time) {
+	e.Status = ExportFailed
+	e.Error = reason
+	e.CompletedAt = &now
+}
+
+// Expire は保存期間が過ぎてファイルを削除したことを記録する
+func (e *Export) Expire() {
+	e.Status = ExportExpired
+	e.FileKey = ""
+}
+
+// CheckDownloadable はダウンロードできるか確認する
+func (e *Export) CheckDownloadable(now time.Time) error {
+	if e.Status == ExportExpired || (e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)) {
+		return ErrExportExpired
+	}
+	if e.Status != ExportReady {
+		return ErrExportNotReady
+	}
+	return nil
+}
+
+// FileName はダウンロードするファイル名（作成した日付を含める）
+func (e *Export) FileName() string {
+	at := e.CreatedAt
+	if e.CompletedAt != nil {
+		at = *e.CompletedAt
+	}
+	return "yotei-plus-export-" + at.UTC().Format("20060102") + ".zip"
+}
+
+// ExportFileKey はエクスポートしたファイルのストレージのキー
+func ExportFileKey(userID, exportID uuid.UUID) string {
+	return "exports/" + userID.String() + "/" + exportID.String() + ".zip"
+}
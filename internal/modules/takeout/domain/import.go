@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidArchive はアップロードされたファイルがエクスポートのZIPとして読み込めないことを表す
+	ErrInvalidArchive = errors.New("invalid archive")
+	// ErrUnsupportedArchive はこのサーバーが対応していない形式のバージョンのZIPであることを表す
+	ErrUnsupportedArchive = errors.New("unsupported archive format version")
+)
+
+// MaxArchiveEntrySize はインポートで読み込むZIP内の1ファイルの展開後のサイズの上限
+const MaxArchiveEntrySize = 64 << 20
+
+// Archive はインポートのために読み込んだエクスポートのZIP
+type Archive struct {
+	Manifest Manifest
+	// SourceUserID はエクスポート元のサーバーでのユーザーID
+	SourceUserID string
+	// Sections はセクション名ごとのレコード（マニフェストに記載されたセクションのみ）
+	Sections map[string][]Record
+}
+
+// ReadArchive はエクスポートのZIPを読み込む
+// マニフェストの形式のバージョンを確認し、各セクションのレコードは JSON のファイルから読み込む（CSVは使わない）
+func ReadArchive(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	archive := &Archive{Sections: make(map[string][]Record)}
+	if err := readJSON(files, "manifest.json", &archive.Manifest); err != nil {
+		return nil, err
+	}
+	version := archive.Manifest.FormatVersion
+	if version < 1 || version > ArchiveFormatVersion {
+		return nil, fmt.Errorf("%w: %d (supported: 1-%d)", ErrUnsupportedArchive, version, ArchiveFormatVersion)
+	}
+	if _, err := uuid.Parse(archive.Manifest.UserID); err != nil {
+		return nil, fmt.Errorf("%w: manifest has no valid user_id", ErrInvalidArchive)
+	}
+	archive.SourceUserID = archive.Manifest.UserID
+
+	for _, section := range archive.Manifest.Sections {
+		var records []Record
+		if err := readJSON(files, section.Name+".json", &records); err != nil {
+			return nil, err
+		}
+		archive.Sections[section.Name] = records
+	}
+	return archive, nil
+}
+
+// readJSON はZIP内の JSON のファイルを読み込む（数値は json.Number として読み込む）
+func readJSON(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%w: %s is missing", ErrInvalidArchive, name)
+	}
+	if f.UncompressedSize64 > MaxArchiveEntrySize {
+		return fmt.Errorf("%w: %s is too large", ErrInvalidArchive, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	defer rc.Close()
+
+	// ヘッダーのサイズが偽装されていても上限を超えて展開しない
+	decoder := json.NewDecoder(io.LimitReader(rc, MaxArchiveEntrySize))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	return nil
+}
+
+// String は文字列の値を返す（存在しない・文字列でない場合は空）
+func (r Record) String(key string) string {
+	if s, ok := r[key].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// Time は日時（RFC3339）の値を返す（存在しない・解析できない場合はnil）
+func (r Record) Time(key string) *time.Time {
+	switch value := r[key].(type) {
+	case time.Time:
+		return &value
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil
+		}
+		return &t
+	}
+	return nil
+}
+
+// Int は整数の値を返す（存在しない・整数でない場合はnil）
+func (r Record) Int(key string) *int {
+	return toInt(r[key])
+}
+
+func toInt(v interface{}) *int {
+	var n int64
+	switch value := v.(type) {
+	case int:
+		return &value
+	case json.Number:
+		v, err := value.Int64()
+		if err != nil {
+			return nil
+		}
+		n = v
+	case float64:
+		if value != float64(int64(value)) {
+			return nil
+		}
+		n = int64(value)
+	case string:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil
+		}
+		n = v
+	default:
+		return nil
+	}
+	i := int(n)
+	return &i
+}
+
+// Bool は真偽値を返す（存在しない・真偽値でない場合はfalse）
+func (r Record) Bool(key string) bool {
+	b, _ := r[key].(bool)
+	return b
+}
+
+// Strings は文字列の配列の値を返す（文字列でない要素は除く）
+func (r Record) Strings(key string) []string {
+	switch value := r[key].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Ints は整数の配列の値を返す（整数でない要素は除く）
+func (r Record) Ints(key string) []int {
+	switch value := r[key].(type) {
+	case []int:
+		return value
+	case []interface{}:
+		values := make([]int, 0, len(value))
+		for _, v := range value {
+			if n := toInt(v); n != nil {
+				values = append(values, *n)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// ImportedRecord はインポートで復元したレコードのエクスポート元のIDと復元先のIDの対応
+// 同じZIPを何度インポートしても同じレコードを重複して作成しないために記録する
+type ImportedRecord struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Section    string    `json:"section"`
+	SourceID   string    `json:"source_id"`
+	TargetID   string    `json:"target_id"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// SectionImportResult はセクションごとのインポートの件数
+type SectionImportResult struct {
+	Name     string `json:"name" example:"tasks"`
+	Imported int    `json:"imported" example:"42"` // 新しく復元した件数
+	Skipped  int    `json:"skipped" example:"3"`   // 復元済み・復元の対象外の件数
+	Invalid  int    `json:"invalid" example:"0"`   // 内容が不正で復元できなかった件数
+} // @name DataImportSectionResult
+
+// ImportResult はインポートの結果
+type ImportResult struct {
+	FormatVersion int                   `json:"format_version" example:"1"`
+	SourceUserID  string                `json:"source_user_id"`
+	ExportedAt    time.Time             `json:"exported_at"`
+	Sections      []SectionImportResult `json:"sections"`
+} // @name DataImportResult
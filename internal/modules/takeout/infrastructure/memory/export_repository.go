@@ -0,0 +1,143 @@
+// Package memory はデータのエクスポートのリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。データはプロセス終了時に失われます。
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+)
+
+// ExportRepository はデータのエクスポートのインメモリリポジトリ
+type ExportRepository struct {
+	mu      sync.RWMutex
+	exports map[uuid.UUID]*domain.Export
+}
+
+// NewExportRepository は新しいExportRepositoryを作成する
+func NewExportRepository() *ExportRepository {
+	return &ExportRepository{
+		exports: make(map[uuid.UUID]*domain.Export),
+	}
+}
+
+// CreateExport はエクスポートを作成する
+func (r *ExportRepository) CreateExport(ctx context.Context, export *domain.Export) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exports[export.ID] = copyExport(export)
+	return nil
+}
+
+// UpdateExport はエクスポートの状態・ファイルを更新する
+func (r *ExportRepository) UpdateExport(ctx context.Context, export *domain.Export) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.exports[export.ID]; ok {
+		r.exports[export.ID] = copyExport(export)
+	}
+	return nil
+}
+
+// GetExport はIDでエクスポートを取得する（存在しない場合は nil, nil）
+func (r *ExportRepository) GetExport(ctx context.Context, id uuid.UUID) (*domain.Export, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if export, ok := r.exports[id]; ok {
+		return copyExport(export), nil
+	}
+	return nil, nil
+}
+
+// ListExportsByUser はユーザーのエクスポートを新しい順に最大 limit 件取得する
+func (r *ExportRepository) ListExportsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Export, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exports := r.filter(func(e *domain.Export) bool { return e.UserID == userID })
+	sort.Slice(exports, func(i, j int) bool { return exports[i].CreatedAt.After(exports[j].CreatedAt) })
+	return limitExports(exports, limit), nil
+}
+
+// FindActiveExport はユーザーの作成待ち・作成中のエクスポートを取得する（存在しない場合は nil, nil）
+func (r *ExportRepository) FindActiveExport(ctx context.Context, userID uuid.UUID) (*domain.Export, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exports := r.filter(func(e *domain.Export) bool { return e.UserID == userID && e.IsActive() })
+	if len(exports) == 0 {
+		return nil, nil
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].CreatedAt.After(exports[j].CreatedAt) })
+	return exports[0], nil
+}
+
+// ClaimNextExport は作成待ち（または staleBefore より前に開始したまま止まっている）のエクスポートを1件取得して作成中にする
+func (r *ExportRepository) ClaimNextExport(ctx context.Context, staleBefore, now time.Time) (*domain.Export, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next *domain.Export
+	for _, export := range r.exports {
+		claimable := export.Status == domain.ExportPending ||
+			(export.Status == domain.ExportProcessing && export.StartedAt != nil && export.StartedAt.Before(staleBefore))
+		if claimable && (next == nil || export.CreatedAt.Before(next.CreatedAt)) {
+			next = export
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	next.Start(now)
+	return copyExport(next), nil
+}
+
+// ListExpiredExports はダウンロードの期限が過ぎたファイルのあるエクスポートを最大 limit 件取得する
+func (r *ExportRepository) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exports := r.filter(func(e *domain.Export) bool {
+		return e.Status == domain.ExportReady && e.ExpiresAt != nil && !e.ExpiresAt.After(now)
+	})
+	sort.Slice(exports, func(i, j int) bool { return exports[i].ExpiresAt.Before(*exports[j].ExpiresAt) })
+	return limitExports(exports, limit), nil
+}
+
+// filter は条件に一致するエクスポートのコピーを返す（呼び出し側でロックを取得する）
+func (r *ExportRepository) filter(match func(*domain.Export) bool) []*domain.Export {
+	var exports []*domain.Export
+	for _, export := range r.exports {
+		if match(export) {
+			exports = append(exports, copyExport(export))
+		}
+	}
+	return exports
+}
+
+func limitExports(exports []*domain.Export, limit int) []*domain.Export {
+	if limit > 0 && len(exports) > limit {
+		return exports[:limit]
+	}
+	return exports
+}
+
+// copyExport は呼び出し側の変更が保存済みのデータに影響しないようコピーする
+func copyExport(export *domain.Export) *domain.Export {
+	c := *export
+	for _, t := range []**time.Time{&c.StartedAt, &c.CompletedAt, &c.ExpiresAt} {
+		if *t != nil {
+			v := **t
+			*t = &v
+		}
+	}
+	return &c
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+)
+
+type importKey struct {
+	userID   uuid.UUID
+	section  string
+	sourceID string
+}
+
+// ImportRepository はインポートで復元したレコードの対応のインメモリリポジトリ
+type ImportRepository struct {
+	mu      sync.RWMutex
+	records map[importKey]domain.ImportedRecord
+}
+
+// NewImportRepository は新しいImportRepositoryを作成する
+func NewImportRepository() *ImportRepository {
+	return &ImportRepository{
+		records: make(map[importKey]domain.ImportedRecord),
+	}
+}
+
+// FindImportedRecord は復元済みのレコードの対応を取得する（復元していない場合は nil, nil）
+func (r *ImportRepository) FindImportedRecord(ctx context.Context, userID uuid.UUID, section, sourceID string) (*domain.ImportedRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if record, ok := r.records[importKey{userID, section, sourceID}]; ok {
+		return &record, nil
+	}
+	return nil, nil
+}
+
+// SaveImportedRecord は復元したレコードの対応を保存する
+func (r *ImportRepository) SaveImportedRecord(ctx context.Context, record *domain.ImportedRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records[importKey{record.UserID, record.Section, record.SourceID}] = *record
+	return nil
+}
@@ -0,0 +1,142 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// exportBatchSize は一度に作成するエクスポートの件数
+	exportBatchSize = 5
+	// expiredExportBatchSize は一度に削除する期限切れのエクスポートの件数
+	expiredExportBatchSize = 50
+)
+
+// ExportWorker はデータのエクスポートのZIPを非同期に作成し、期限切れのファイルを削除するワーカー
+// 依頼時の通知ですぐに作成し、通知を取りこぼした分や作成中に止まった分は定期的に作成する
+type ExportWorker struct {
+	takeoutService *usecase.TakeoutService
+	logger         logger.Logger
+	ticker         *time.Ticker
+	notifyCh       chan struct{}
+	stopCh         chan struct{}
+	doneCh         chan struct{}
+	isRunning      bool
+}
+
+// NewExportWorker は新しいExportWorkerを作成
+func NewExportWorker(
+	takeoutService *usecase.TakeoutService,
+	logger logger.Logger,
+) *ExportWorker {
+	return &ExportWorker{
+		takeoutService: takeoutService,
+		logger:         logger,
+		notifyCh:       make(chan struct{}, 1),
+		stopCh:         make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+}
+
+// Notify は作成待ちのエクスポートがあることをワーカーに通知する（処理中の場合は処理後にもう一度確認する）
+func (w *ExportWorker) Notify() {
+	select {
+	case w.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Start はワーカーを開始（1分ごとに作成待ちと期限切れのエクスポートを確認）
+func (w *ExportWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Data export worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(1 * time.Minute)
+
+	w.logger.Info("Starting data export worker")
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		// 初回実行（停止中に依頼された分を作成する）
+		w.process(ctx)
+		w.cleanup(ctx)
+
+		for {
+			select {
+			case <-w.notifyCh:
+				w.process(ctx)
+			case <-w.ticker.C:
+				w.process(ctx)
+				w.cleanup(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Data export worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Data export worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// process は作成待ちのエクスポートがなくなるまでZIPを作成する
+func (w *ExportWorker) process(ctx context.Context) {
+	defer errtrack.Recover("data-export-worker")
+	for {
+		processed, err := w.takeoutService.ProcessPendingExports(ctx, exportBatchSize)
+		if err != nil {
+			w.logger.Error("Failed to process pending data exports", logger.Error(err))
+			return
+		}
+		if processed > 0 {
+			w.logger.Debug("Data exports processed", logger.Any("count", processed))
+		}
+		if processed < exportBatchSize {
+			return
+		}
+
+		select {
+		case <-w.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+// cleanup はダウンロードの期限が過ぎたエクスポートのファイルを削除する
+func (w *ExportWorker) cleanup(ctx context.Context) {
+	defer errtrack.Recover("data-export-worker")
+	deleted, err := w.takeoutService.DeleteExpiredExports(ctx, expiredExportBatchSize)
+	if err != nil {
+		w.logger.Error("Failed to delete expired data exports", logger.Error(err))
+		return
+	}
+	if deleted > 0 {
+		w.logger.Info("Expired data exports deleted", logger.Any("count", deleted))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *ExportWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping data export worker")
+	<-w.doneCh
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ExportController はデータのエクスポートのHTTPリクエストを処理するコントローラー
+type ExportController struct {
+	takeoutService *usecase.TakeoutService
+	logger         logger.Logger
+}
+
+// NewExportController は新しいExportControllerを作成する
+func NewExportController(takeoutService *usecase.TakeoutService, logger logger.Logger) *ExportController {
+	return &ExportController{
+		takeoutService: takeoutService,
+		logger:         logger,
+	}
+}
+
+// ErrorResponse はデータのエクスポートAPIのエラーレスポンス
+type ErrorResponse struct {
+	Success bool   `json:"success" example:"false"`
+	Error   string `json:"error" example:"EXPORT_NOT_READY"`
+	Message string `json:"message" example:"export is not ready"`
+} // @name DataExportErrorResponse
+
+// ExportResponse はデータのエクスポートのレスポンス
+type ExportResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    domain.Export `json:"data"`
+} // @name DataExportResponse
+
+// ExportListResponse はデータのエクスポートの一覧のレスポンス
+type ExportListResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    []*domain.Export `json:"data"`
+} // @name DataExportListResponse
+
+// RequestExport データのエクスポートの依頼
+// @Summary      データのエクスポートの依頼
+// @Description  ログイン中のユーザーの全データ（タスク・コメント・添付ファイルの情報・グループ・友達・統計）のZIP（各データのJSONとCSV）の作成を依頼します。
+// @Description  ZIPはバックグラウンドで作成し、ダウンロードの準備ができたら通知します。作成待ち・作成中のエクスポートがある場合は新しく作成せず、そのエクスポートを返します
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      202 {object} ExportResponse "受付済み"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/export [post]
+func (c *ExportController) RequestExport(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	export, err := c.takeoutService.RequestExport(ctx, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, ExportResponse{Success: true, Data: *export})
+}
+
+// ListExports データのエクスポートの一覧
+// @Summary      データのエクスポートの一覧
+// @Description  ログイン中のユーザーのエクスポートを新しい順に最大20件取得します
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} ExportListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/export [get]
+func (c *ExportController) ListExports(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	exports, err := c.takeoutService.ListExports(ctx, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	if exports == nil {
+		exports = []*domain.Export{}
+	}
+
+	ctx.JSON(http.StatusOK, ExportListResponse{Success: true, Data: exports})
+}
+
+// GetExport データのエクスポートの状態
+// @Summary      データのエクスポートの状態
+// @Description  エクスポートの状態（PENDING・PROCESSING・READY・FAILED・EXPIRED）とダウンロードの期限を取得します
+// @Tags         users
+// @Produce      json
+// @Param        id path string true "エクスポートID"
+// @Security     BearerAuth
+// @Success      200 {object} ExportResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "エクスポートが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/export/{id} [get]
+func (c *ExportController) GetExport(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	exportID, ok := parseExportID(ctx)
+	if !ok {
+		return
+	}
+
+	export, err := c.takeoutService.GetExport(ctx, exportID, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ExportResponse{Success: true, Data: *export})
+}
+
+// DownloadExport データのエクスポートのダウンロード
+// @Summary      データのエクスポートのダウンロード
+// @Description  作成済みのエクスポートのZIPをダウンロードします。作成待ち・作成中の場合は 409、ダウンロードの期限が過ぎた場合は 410 を返します（もう一度依頼してください）
+// @Tags         users
+// @Produce      application/zip
+// @Param        id path string true "エクスポートID"
+// @Security     BearerAuth
+// @Success      200 {file} file "エクスポートのZIP"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "エクスポートが見つからない"
+// @Failure      409 {object} ErrorResponse "作成が完了していない"
+// @Failure      410 {object} ErrorResponse "ダウンロードの期限切れ"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/export/{id}/download [get]
+func (c *ExportController) DownloadExport(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	exportID, ok := parseExportID(ctx)
+	if !ok {
+		return
+	}
+
+	export, body, err := c.takeoutService.OpenDownload(ctx, exportID, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.FileName()}))
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.DataFromReader(http.StatusOK, export.SizeBytes, "application/zip", body, nil)
+}
+
+// requireUserID はログイン中のユーザーのIDを取得する（取得できない場合は401を返す）
+func requireUserID(ctx *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "UNAUTHORIZED",
+			Message: "authentication required",
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// parseExportID はパスのエクスポートIDを取得する（形式が不正な場合は存在しないものとして404を返す）
+func parseExportID(ctx *gin.Context) (uuid.UUID, bool) {
+	exportID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "EXPORT_NOT_FOUND",
+			Message: domain.ErrExportNotFound.Error(),
+		})
+		return uuid.Nil, false
+	}
+	return exportID, true
+}
+
+// handleError はサービスのエラーをHTTPレスポンスに変換する
+func (c *ExportController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrExportNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{Success: false, Error: "EXPORT_NOT_FOUND", Message: err.Error()})
+	case errors.Is(err, domain.ErrExportNotReady):
+		ctx.JSON(http.StatusConflict, ErrorResponse{Success: false, Error: "EXPORT_NOT_READY", Message: err.Error()})
+	case errors.Is(err, domain.ErrExportExpired):
+		ctx.JSON(http.StatusGone, ErrorResponse{Success: false, Error: "EXPORT_EXPIRED", Message: err.Error()})
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{Success: false, Error: "REQUEST_ERROR", Message: err.Error()})
+	default:
+		c.logger.WithContext(ctx).Error("Data export request failed", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{Success: false, Error: "INTERNAL_ERROR", Message: "Failed to process data export"})
+	}
+}
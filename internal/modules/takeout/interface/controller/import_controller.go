@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// maxImportArchiveSize はインポートでアップロードできるZIPの最大サイズ
+	maxImportArchiveSize = 100 << 20
+	// multipartOverhead はmultipartの区切りやヘッダーのためにZIPの最大サイズに加えて受け付けるバイト数
+	multipartOverhead = 1 << 20
+)
+
+// ImportController はデータのインポートのHTTPリクエストを処理するコントローラー
+type ImportController struct {
+	importService *usecase.ImportService
+	logger        logger.Logger
+}
+
+// NewImportController は新しいImportControllerを作成する
+func NewImportController(importService *usecase.ImportService, logger logger.Logger) *ImportController {
+	return &ImportController{
+		importService: importService,
+		logger:        logger,
+	}
+}
+
+// ImportResponse はデータのインポートのレスポンス
+type ImportResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.ImportResult `json:"data"`
+} // @name DataImportResponse
+
+// ImportData データのインポート
+// @Summary      データのインポート
+// @Description  別のYotei+のサーバーでエクスポートしたZIP（POST /me/export で作成したもの）から、プロフィールと勤務時間の設定・所有していたグループ・タスクを復元します。
+// @Description  タスク・グループは新しいIDで作成し、作成者・所有者はログイン中のユーザーになります。担当者はエクスポートしたユーザーが担当していた場合のみ引き継ぎます。コメント・添付ファイル・友達は復元しません。
+// @Description  復元したレコードは記録しているため、同じZIPを何度インポートしても重複して作成しません（途中で失敗した場合はもう一度インポートしてください）
+// @Tags         users
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "エクスポートのZIP（最大100MB）"
+// @Security     BearerAuth
+// @Success      200 {object} ImportResponse "インポート成功（セクションごとの件数）"
+// @Failure      400 {object} ErrorResponse "ZIPが不正"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      409 {object} ErrorResponse "インポートを実行中"
+// @Failure      413 {object} ErrorResponse "ファイルが大きすぎる"
+// @Failure      422 {object} ErrorResponse "対応していない形式のバージョン"
+// @Failure      429 {object} ErrorResponse "タスク・グループ数の上限に達している"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/import [post]
+func (c *ImportController) ImportData(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportArchiveSize+multipartOverhead)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+				Success: false,
+				Error:   "ARCHIVE_TOO_LARGE",
+				Message: fmt.Sprintf("archive must be at most %d bytes", maxImportArchiveSize),
+			})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{Success: false, Error: "REQUEST_ERROR", Message: "file is required"})
+		return
+	}
+	if header.Size > maxImportArchiveSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Success: false,
+			Error:   "ARCHIVE_TOO_LARGE",
+			Message: fmt.Sprintf("archive must be at most %d bytes", maxImportArchiveSize),
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := c.importService.ImportArchive(ctx, userID, file, header.Size)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ImportResponse{Success: true, Data: *result})
+}
+
+// handleError はサービスのエラーをHTTPレスポンスに変換する
+func (c *ImportController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidArchive):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{Success: false, Error: "INVALID_ARCHIVE", Message: err.Error()})
+	case errors.Is(err, domain.ErrUnsupportedArchive):
+		ctx.JSON(http.StatusUnprocessableEntity, ErrorResponse{Success: false, Error: "UNSUPPORTED_ARCHIVE", Message: err.Error()})
+	case errors.Is(err, usecase.ErrImportInProgress):
+		ctx.JSON(http.StatusConflict, ErrorResponse{Success: false, Error: "IMPORT_IN_PROGRESS", Message: err.Error()})
+	case errors.Is(err, commonDomain.ErrQuotaExceeded):
+		ctx.JSON(http.StatusTooManyRequests, ErrorResponse{Success: false, Error: "QUOTA_EXCEEDED", Message: err.Error()})
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{Success: false, Error: "REQUEST_ERROR", Message: err.Error()})
+	default:
+		c.logger.WithContext(ctx).Error("Data import failed", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{Success: false, Error: "INTERNAL_ERROR", Message: "Failed to import data"})
+	}
+}
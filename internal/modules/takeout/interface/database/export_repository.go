@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const exportColumns = "id, user_id, status, file_key, size_bytes, error, created_at, started_at, completed_at, expires_at"
+
+// 他のインスタンスと取り合った場合に次の候補を取得し直す回数
+const maxClaimAttempts = 3
+
+// ExportRepository はデータのエクスポートのデータベースリポジトリ実装
+type ExportRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewExportRepository は新しいExportRepositoryを作成する
+func NewExportRepository(db *sql.DB, logger logger.Logger) usecase.ExportRepository {
+	return &ExportRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateExport はエクスポートを作成する
+func (r *ExportRepository) CreateExport(ctx context.Context, export *domain.Export) error {
+	query := `
+		INSERT INTO data_exports (` + exportColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		export.ID.String(),
+		export.UserID.String(),
+		string(export.Status),
+		export.FileKey,
+		export.SizeBytes,
+		export.Error,
+		export.CreatedAt,
+		export.StartedAt,
+		export.CompletedAt,
+		export.ExpiresAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create data export",
+			logger.Any("userID", export.UserID), logger.Error(err))
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+	return nil
+}
+
+// UpdateExport はエクスポートの状態・ファイルを更新する
+func (r *ExportRepository) UpdateExport(ctx context.Context, export *domain.Export) error {
+	query := `
+		UPDATE data_exports
+		SET status = ?, file_key = ?, size_bytes = ?, error = ?, started_at = ?, completed_at = ?, expires_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		string(export.Status),
+		export.FileKey,
+		export.SizeBytes,
+		export.Error,
+		export.StartedAt,
+		export.CompletedAt,
+		export.ExpiresAt,
+		export.ID.String(),
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update data export",
+			logger.Any("exportID", export.ID), logger.Error(err))
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+	return nil
+}
+
+// GetExport はIDでエクスポートを取得する（存在しない場合は nil, nil）
+func (r *ExportRepository) GetExport(ctx context.Context, id uuid.UUID) (*domain.Export, error) {
+	query := `SELECT ` + exportColumns + ` FROM data_exports WHERE id = ?`
+	return r.getExport(ctx, query, id.String())
+}
+
+// ListExportsByUser はユーザーのエクスポートを新しい順に最大 limit 件取得する
+func (r *ExportRepository) ListExportsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Export, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM data_exports
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+	return r.listExports(ctx, query, userID.String(), limit)
+}
+
+// FindActiveExport はユーザーの作成待ち・作成中のエクスポートを取得する（存在しない場合は nil, nil）
+func (r *ExportRepository) FindActiveExport(ctx context.Context, userID uuid.UUID) (*domain.Export, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM data_exports
+		WHERE user_id = ? AND status IN (?, ?)
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.getExport(ctx, query, userID.String(), string(domain.ExportPending), string(domain.ExportProcessing))
+}
+
+// ClaimNextExport は作成待ち（または staleBefore より前に開始したまま止まっている）のエクスポートを1件取得して作成中にする
+// 候補を取得した後、状態が変わっていない場合のみ更新することで他のインスタンスとの二重処理を防ぐ
+func (r *ExportRepository) ClaimNextExport(ctx context.Context, staleBefore, now time.Time) (*domain.Export, error) {
+	for attempt := 0; attempt < maxClaimAttempts; attempt++ {
+		query := `
+			SELECT ` + exportColumns + `
+			FROM data_exports
+			WHERE status = ? OR (status = ? AND started_at < ?)
+			ORDER BY created_at ASC
+			LIMIT 1
+		`
+		export, err := r.getExport(ctx, query, string(domain.ExportPending), string(domain.ExportProcessing), staleBefore)
+		if err != nil || export == nil {
+			return nil, err
+		}
+
+		result, err := r.db.ExecContext(ctx, `
+			UPDATE data_exports
+			SET status = ?, started_at = ?, error = ''
+			WHERE id = ? AND (status = ? OR (status = ? AND started_at < ?))
+		`, string(domain.ExportProcessing), now, export.ID.String(),
+			string(domain.ExportPending), string(domain.ExportProcessing), staleBefore)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to claim data export",
+				logger.Any("exportID", export.ID), logger.Error(err))
+			return nil, fmt.Errorf("failed to claim data export: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim data export: %w", err)
+		}
+		if affected == 1 {
+			export.Start(now)
+			return export, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListExpiredExports はダウンロードの期限が過ぎたファイルのあるエクスポートを最大 limit 件取得する
+func (r *ExportRepository) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM data_exports
+		WHERE status = ? AND expires_at <= ?
+		ORDER BY expires_at ASC
+		LIMIT ?
+	`
+	return r.listExports(ctx, query, string(domain.ExportReady), now, limit)
+}
+
+func (r *ExportRepository) getExport(ctx context.Context, query string, args ...interface{}) (*domain.Export, error) {
+	export, err := scanExport(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get data export", logger.Error(err))
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+	return export, nil
+}
+
+func (r *ExportRepository) listExports(ctx context.Context, query string, args ...interface{}) ([]*domain.Export, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list data exports", logger.Error(err))
+		return nil, fmt.Errorf("failed to list data exports: %w", err)
+	}
+	defer rows.Close()
+
+	var exports []*domain.Export
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan data export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate data exports: %w", err)
+	}
+	return exports, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanExport(row rowScanner) (*domain.Export, error) {
+	var (
+		export                            domain.Export
+		id, userID, status                string
+		startedAt, completedAt, expiresAt sql.NullTime
+	)
+	if err := row.Scan(
+		&id,
+		&userID,
+		&status,
+		&export.FileKey,
+		&export.SizeBytes,
+		&export.Error,
+		&export.CreatedAt,
+		&startedAt,
+		&completedAt,
+		&expiresAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if export.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid export id %q: %w", id, err)
+	}
+	if export.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("invalid export user id %q: %w", userID, err)
+	}
+	export.Status = domain.ExportStatus(status)
+	if startedAt.Valid {
+		export.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+	return &export, nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ImportRepository はインポートで復元したレコードの対応のデータベースリポジトリ実装
+type ImportRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewImportRepository は新しいImportRepositoryを作成する
+func NewImportRepository(db *sql.DB, logger logger.Logger) usecase.ImportRepository {
+	return &ImportRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// FindImportedRecord は復元済みのレコードの対応を取得する（復元していない場合は nil, nil）
+func (r *ImportRepository) FindImportedRecord(ctx context.Context, userID uuid.UUID, section, sourceID string) (*domain.ImportedRecord, error) {
+	query := `
+		SELECT target_id, imported_at
+		FROM data_import_records
+		WHERE user_id = ? AND section = ? AND source_id = ?
+	`
+	record := &domain.ImportedRecord{UserID: userID, Section: section, SourceID: sourceID}
+	err := r.db.QueryRowContext(ctx, query, userID.String(), section, sourceID).Scan(&record.TargetID, &record.ImportedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get imported record",
+			logger.Any("userID", userID), logger.Any("section", section), logger.Error(err))
+		return nil, fmt.Errorf("failed to get imported record: %w", err)
+	}
+	return record, nil
+}
+
+// SaveImportedRecord は復元したレコードの対応を保存する
+func (r *ImportRepository) SaveImportedRecord(ctx context.Context, record *domain.ImportedRecord) error {
+	query := `
+		INSERT INTO data_import_records (user_id, section, source_id, target_id, imported_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		record.UserID.String(),
+		record.Section,
+		record.SourceID,
+		record.TargetID,
+		record.ImportedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save imported record",
+			logger.Any("userID", record.UserID), logger.Any("section", record.Section), logger.Error(err))
+		return fmt.Errorf("failed to save imported record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// MetricDataImportRecords はインポートで復元したレコードの件数のメトリクス
+const MetricDataImportRecords = "data_import_records_total"
+
+var (
+	// ErrSkipRecord はレコードが復元の対象外であることを表す（他のユーザーが所有するグループなど）
+	ErrSkipRecord = errors.New("record skipped")
+	// ErrInvalidRecord はレコードの内容が不正で復元できないことを表す
+	ErrInvalidRecord = errors.New("invalid record")
+	// ErrImportInProgress は同じユーザーのインポートが実行中であることを表す
+	ErrImportInProgress = errors.New("import already in progress")
+)
+
+// DataRestorer はインポートしたセクションのレコードの復元先（タスク・グループ・設定など）
+type DataRestorer interface {
+	// Section は復元するセクションの名前を返す
+	Section() string
+	// SourceID はエクスポート元のサーバーでのレコードのIDを返す（空の場合は不正なレコードとして扱う）
+	SourceID(record domain.Record) string
+	// RestoreRecord はレコードを復元し、復元先のIDを返す
+	// 復元の対象外の場合は ErrSkipRecord、内容が不正な場合は ErrInvalidRecord を返す
+	RestoreRecord(ctx context.Context, scope *ImportScope, record domain.Record) (string, error)
+}
+
+// ImportScope は実行中のインポートの情報（復元先が他のレコードの復元先のIDを参照するために使う）
+type ImportScope struct {
+	// UserID はインポートしているユーザー（復元したデータの所有者）
+	UserID uuid.UUID
+	// SourceUserID はエクスポート元のサーバーでのユーザーID
+	SourceUserID string
+
+	service *ImportService
+}
+
+// TargetID は復元済みのレコードの復元先のIDを返す（復元していない場合は空）
+func (s *ImportScope) TargetID(ctx context.Context, section, sourceID string) (string, error) {
+	record, err := s.service.Repository.FindImportedRecord(ctx, s.UserID, section, sourceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find imported record: %w", err)
+	}
+	if record == nil {
+		return "", nil
+	}
+	return record.TargetID, nil
+}
+
+// ImportService は別のYotei+のサーバーでエクスポートしたZIPからデータを復元するサービス
+// 復元したレコードはエクスポート元のIDと復元先のIDの対応を記録し、同じZIPを何度インポートしても重複して作成しない
+type ImportService struct {
+	Repository ImportRepository
+	// 復元先（この順で復元する。参照されるセクションを先に置く）
+	Restorers []DataRestorer
+	Logger    logger.Logger
+
+	now     func() time.Time
+	mu      sync.Mutex
+	running map[uuid.UUID]struct{}
+}
+
+// NewImportService はImportServiceのコンストラクタ
+func NewImportService(repo ImportRepository, restorers []DataRestorer, logger logger.Logger) *ImportService {
+	return &ImportService{
+		Repository: repo,
+		Restorers:  restorers,
+		Logger:     logger,
+		now:        time.Now,
+		running:    make(map[uuid.UUID]struct{}),
+	}
+}
+
+// ImportArchive はエクスポートのZIPを読み込み、ユーザーのデータとして復元する
+// 復元先のないセクション（コメント・友達など）は読み込まない
+func (s *ImportService) ImportArchive(ctx context.Context, userID uuid.UUID, r io.ReaderAt, size int64) (*domain.ImportResult, error) {
+	if userID == uuid.Nil {
+		return nil, ErrInvalidParameter
+	}
+
+	archive, err := domain.ReadArchive(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じユーザーのインポートを並行して実行すると対応の記録前に同じレコードを重複して作成するため、1件ずつ実行する
+	if !s.acquire(userID) {
+		return nil, ErrImportInProgress
+	}
+	defer s.release(userID)
+
+	scope := &ImportScope{UserID: userID, SourceUserID: archive.SourceUserID, service: s}
+	result := &domain.ImportResult{
+		FormatVersion: archive.Manifest.FormatVersion,
+		SourceUserID:  archive.SourceUserID,
+		ExportedAt:    archive.Manifest.GeneratedAt,
+		Sections:      []domain.SectionImportResult{},
+	}
+	for _, restorer := range s.Restorers {
+		records, ok := archive.Sections[restorer.Section()]
+		if !ok {
+			continue
+		}
+		sectionResult, err := s.restoreSection(ctx, scope, restorer, records)
+		if err != nil {
+			return nil, err
+		}
+		result.Sections = append(result.Sections, sectionResult)
+	}
+
+	s.Logger.WithContext(ctx).Info("Data import completed",
+		logger.Any("userID", userID),
+		logger.Any("sourceUserID", archive.SourceUserID),
+		logger.Any("sections", result.Sections))
+	return result, nil
+}
+
+// restoreSection はセクションのレコードを順に復元する
+// 復元先のエラー（レコードの内容によらない失敗）の場合はそこで中止する。復元済みのレコードは次のインポートでスキップする
+func (s *ImportService) restoreSection(ctx context.Context, scope *ImportScope, restorer DataRestorer, records []domain.Record) (domain.SectionImportResult, error) {
+	result := domain.SectionImportResult{Name: restorer.Section()}
+	for _, record := range records {
+		sourceID := restorer.SourceID(record)
+		if sourceID == "" {
+			result.Invalid++
+			continue
+		}
+
+		imported, err := s.Repository.FindImportedRecord(ctx, scope.UserID, restorer.Section(), sourceID)
+		if err != nil {
+			return result, fmt.Errorf("failed to find imported record: %w", err)
+		}
+		if imported != nil {
+			result.Skipped++
+			continue
+		}
+
+		targetID, err := restorer.RestoreRecord(ctx, scope, record)
+		switch {
+		case errors.Is(err, ErrSkipRecord):
+			result.Skipped++
+			continue
+		case errors.Is(err, ErrInvalidRecord):
+			s.Logger.WithContext(ctx).Debug("Skipping invalid import record",
+				logger.Any("section", restorer.Section()),
+				logger.Any("sourceID", sourceID),
+				logger.Error(err))
+			result.Invalid++
+			continue
+		case err != nil:
+			return result, fmt.Errorf("failed to restore %s: %w", restorer.Section(), err)
+		}
+
+		if err := s.Repository.SaveImportedRecord(ctx, &domain.ImportedRecord{
+			UserID:     scope.UserID,
+			Section:    restorer.Section(),
+			SourceID:   sourceID,
+			TargetID:   targetID,
+			ImportedAt: s.now(),
+		}); err != nil {
+			return result, fmt.Errorf("failed to save imported record: %w", err)
+		}
+		metrics.Counter(MetricDataImportRecords).Add(1)
+		result.Imported++
+	}
+	return result, nil
+}
+
+func (s *ImportService) acquire(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.running[userID]; ok {
+		return false
+	}
+	s.running[userID] = struct{}{}
+	return true
+}
+
+func (s *ImportService) release(userID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, userID)
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/infrastructure/memory"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// testRestorer はレコードの "id" を復元先のIDの接頭辞にして復元したレコードを記録する
+type testRestorer struct {
+	section  string
+	restored []domain.Record
+	// restore を設定するとレコードごとの結果を置き換える
+	restore func(scope *ImportScope, record domain.Record) (string, error)
+}
+
+func (r *testRestorer) Section() string { return r.section }
+
+func (r *testRestorer) SourceID(record domain.Record) string { return record.String("id") }
+
+func (r *testRestorer) RestoreRecord(ctx context.Context, scope *ImportScope, record domain.Record) (string, error) {
+	if r.restore != nil {
+		if _, err := r.restore(scope, record); err != nil {
+			return "", err
+		}
+	}
+	r.restored = append(r.restored, record)
+	return "new-" + record.String("id"), nil
+}
+
+// newTestArchive はセクションを書き出したエクスポートのZIPを作成する
+func newTestArchive(t *testing.T, sourceUserID uuid.UUID, sections ...*domain.Section) *bytes.Reader {
+	var buf bytes.Buffer
+	require.NoError(t, domain.WriteArchive(&buf, domain.NewExport(sourceUserID, testNow), testNow, sections))
+	return bytes.NewReader(buf.Bytes())
+}
+
+func newTestImportService(restorers ...DataRestorer) *ImportService {
+	service := NewImportService(memory.NewImportRepository(), restorers, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.now = func() time.Time { return testNow }
+	return service
+}
+
+func TestImportService_ImportArchive(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sourceUserID := uuid.New()
+
+	t.Run("restores records once and skips them on the next import", func(t *testing.T) {
+		groups := &testRestorer{section: "groups"}
+		tasks := &testRestorer{section: "tasks"}
+		service := newTestImportService(groups, tasks)
+		archive := newTestArchive(t, sourceUserID,
+			&domain.Section{Name: "tasks", Columns: []string{"id"}, Records: []domain.Record{{"id": "t1"}, {"id": "t2"}}},
+			&domain.Section{Name: "comments", Columns: []string{"id"}, Records: []domain.Record{{"id": "c1"}}},
+			&domain.Section{Name: "groups", Columns: []string{"id"}, Records: []domain.Record{{"id": "g1"}}},
+		)
+
+		result, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.NoError(t, err)
+		assert.Equal(t, sourceUserID.String(), result.SourceUserID)
+		assert.Equal(t, domain.ArchiveFormatVersion, result.FormatVersion)
+		// 復元先の順に復元し、復元先のないセクションは含めない
+		assert.Equal(t, []domain.SectionImportResult{
+			{Name: "groups", Imported: 1},
+			{Name: "tasks", Imported: 2},
+		}, result.Sections)
+
+		record, err := service.Repository.FindImportedRecord(ctx, userID, "tasks", "t1")
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.Equal(t, "new-t1", record.TargetID)
+		assert.Equal(t, testNow, record.ImportedAt)
+
+		result, err = service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.SectionImportResult{
+			{Name: "groups", Skipped: 1},
+			{Name: "tasks", Skipped: 2},
+		}, result.Sections)
+		assert.Len(t, tasks.restored, 2)
+	})
+
+	t.Run("counts skipped and invalid records", func(t *testing.T) {
+		tasks := &testRestorer{section: "tasks"}
+		// ErrInvalidRecord をラップしたエラーのみ不正なレコードとして扱う
+		tasks.restore = func(scope *ImportScope, record domain.Record) (string, error) {
+			switch record.String("id") {
+			case "skip":
+				return "", ErrSkipRecord
+			case "invalid":
+				return "", errors.Join(ErrInvalidRecord, errors.New("title is required"))
+			}
+			return "", nil
+		}
+		service := newTestImportService(tasks)
+		archive := newTestArchive(t, sourceUserID, &domain.Section{
+			Name:    "tasks",
+			Columns: []string{"id"},
+			Records: []domain.Record{{"id": "ok"}, {"id": "skip"}, {"id": "invalid"}, {"id": ""}},
+		})
+
+		result, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.SectionImportResult{{Name: "tasks", Imported: 1, Skipped: 1, Invalid: 2}}, result.Sections)
+
+		// スキップ・不正なレコードは記録しない
+		record, err := service.Repository.FindImportedRecord(ctx, userID, "tasks", "skip")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	t.Run("stops on restorer errors and resumes on the next import", func(t *testing.T) {
+		failing := true
+		tasks := &testRestorer{section: "tasks"}
+		tasks.restore = func(scope *ImportScope, record domain.Record) (string, error) {
+			if record.String("id") == "t2" && failing {
+				return "", errors.New("database is down")
+			}
+			return "", nil
+		}
+		service := newTestImportService(tasks)
+		archive := newTestArchive(t, sourceUserID, &domain.Section{
+			Name: "tasks", Columns: []string{"id"}, Records: []domain.Record{{"id": "t1"}, {"id": "t2"}},
+		})
+
+		_, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.Error(t, err)
+
+		failing = false
+		result, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.SectionImportResult{{Name: "tasks", Imported: 1, Skipped: 1}}, result.Sections)
+	})
+
+	t.Run("resolves ids restored earlier", func(t *testing.T) {
+		var parents []string
+		groups := &testRestorer{section: "groups"}
+		groups.restore = func(scope *ImportScope, record domain.Record) (string, error) {
+			parent, err := scope.TargetID(ctx, "groups", record.String("parent_group_id"))
+			parents = append(parents, parent)
+			return "", err
+		}
+		service := newTestImportService(groups)
+		archive := newTestArchive(t, sourceUserID, &domain.Section{
+			Name:    "groups",
+			Columns: []string{"id", "parent_group_id"},
+			Records: []domain.Record{{"id": "g1", "parent_group_id": nil}, {"id": "g2", "parent_group_id": "g1"}},
+		})
+
+		_, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "new-g1"}, parents)
+	})
+
+	t.Run("rejects unsupported archives", func(t *testing.T) {
+		service := newTestImportService(&testRestorer{section: "tasks"})
+		data := []byte("not a zip")
+
+		_, err := service.ImportArchive(ctx, userID, bytes.NewReader(data), int64(len(data)))
+		assert.ErrorIs(t, err, domain.ErrInvalidArchive)
+	})
+
+	t.Run("rejects concurrent imports of the same user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockImportRepository(ctrl)
+		service := newTestImportService(&testRestorer{section: "tasks"})
+		service.Repository = repo
+		archive := newTestArchive(t, sourceUserID, &domain.Section{
+			Name: "tasks", Columns: []string{"id"}, Records: []domain.Record{{"id": "t1"}},
+		})
+
+		// 1件目のインポートの実行中に同じユーザーのインポートを受け付けない
+		repo.EXPECT().FindImportedRecord(ctx, userID, "tasks", "t1").DoAndReturn(
+			func(ctx context.Context, userID uuid.UUID, section, sourceID string) (*domain.ImportedRecord, error) {
+				_, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+				assert.ErrorIs(t, err, ErrImportInProgress)
+				return &domain.ImportedRecord{TargetID: "new-t1"}, nil
+			})
+
+		_, err := service.ImportArchive(ctx, userID, archive, archive.Size())
+		require.NoError(t, err)
+	})
+}
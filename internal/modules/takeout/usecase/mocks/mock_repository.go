@@ -0,0 +1,268 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+)
+
+// MockExportRepository is a mock of ExportRepository interface.
+type MockExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepositoryMockRecorder
+}
+
+// MockExportRepositoryMockRecorder is the mock recorder for MockExportRepository.
+type MockExportRepositoryMockRecorder struct {
+	mock *MockExportRepository
+}
+
+// NewMockExportRepository creates a new mock instance.
+func NewMockExportRepository(ctrl *gomock.Controller) *MockExportRepository {
+	mock := &MockExportRepository{ctrl: ctrl}
+	mock.recorder = &MockExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimNextExport mocks base method.
+func (m *MockExportRepository) ClaimNextExport(ctx context.Context, staleBefore, now time.Time) (*domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNextExport", ctx, staleBefore, now)
+	ret0, _ := ret[0].(*domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNextExport indicates an expected call of ClaimNextExport.
+func (mr *MockExportRepositoryMockRecorder) ClaimNextExport(ctx, staleBefore, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNextExport", reflect.TypeOf((*MockExportRepository)(nil).ClaimNextExport), ctx, staleBefore, now)
+}
+
+// CreateExport mocks base method.
+func (m *MockExportRepository) CreateExport(ctx context.Context, export *domain.Export) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExport indicates an expected call of CreateExport.
+func (mr *MockExportRepositoryMockRecorder) CreateExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExport", reflect.TypeOf((*MockExportRepository)(nil).CreateExport), ctx, export)
+}
+
+// FindActiveExport mocks base method.
+func (m *MockExportRepository) FindActiveExport(ctx context.Context, userID uuid.UUID) (*domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveExport", ctx, userID)
+	ret0, _ := ret[0].(*domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveExport indicates an expected call of FindActiveExport.
+func (mr *MockExportRepositoryMockRecorder) FindActiveExport(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveExport", reflect.TypeOf((*MockExportRepository)(nil).FindActiveExport), ctx, userID)
+}
+
+// GetExport mocks base method.
+func (m *MockExportRepository) GetExport(ctx context.Context, id uuid.UUID) (*domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, id)
+	ret0, _ := ret[0].(*domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockExportRepositoryMockRecorder) GetExport(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockExportRepository)(nil).GetExport), ctx, id)
+}
+
+// ListExpiredExports mocks base method.
+func (m *MockExportRepository) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredExports", ctx, now, limit)
+	ret0, _ := ret[0].([]*domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredExports indicates an expected call of ListExpiredExports.
+func (mr *MockExportRepositoryMockRecorder) ListExpiredExports(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredExports", reflect.TypeOf((*MockExportRepository)(nil).ListExpiredExports), ctx, now, limit)
+}
+
+// ListExportsByUser mocks base method.
+func (m *MockExportRepository) ListExportsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExportsByUser", ctx, userID, limit)
+	ret0, _ := ret[0].([]*domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExportsByUser indicates an expected call of ListExportsByUser.
+func (mr *MockExportRepositoryMockRecorder) ListExportsByUser(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExportsByUser", reflect.TypeOf((*MockExportRepository)(nil).ListExportsByUser), ctx, userID, limit)
+}
+
+// UpdateExport mocks base method.
+func (m *MockExportRepository) UpdateExport(ctx context.Context, export *domain.Export) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExport indicates an expected call of UpdateExport.
+func (mr *MockExportRepositoryMockRecorder) UpdateExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExport", reflect.TypeOf((*MockExportRepository)(nil).UpdateExport), ctx, export)
+}
+
+// MockDataSource is a mock of DataSource interface.
+type MockDataSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataSourceMockRecorder
+}
+
+// MockDataSourceMockRecorder is the mock recorder for MockDataSource.
+type MockDataSourceMockRecorder struct {
+	mock *MockDataSource
+}
+
+// NewMockDataSource creates a new mock instance.
+func NewMockDataSource(ctrl *gomock.Controller) *MockDataSource {
+	mock := &MockDataSource{ctrl: ctrl}
+	mock.recorder = &MockDataSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataSource) EXPECT() *MockDataSourceMockRecorder {
+	return m.recorder
+}
+
+// Collect mocks base method.
+func (m *MockDataSource) Collect(ctx context.Context, userID uuid.UUID) (*domain.Section, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collect", ctx, userID)
+	ret0, _ := ret[0].(*domain.Section)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collect indicates an expected call of Collect.
+func (mr *MockDataSourceMockRecorder) Collect(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockDataSource)(nil).Collect), ctx, userID)
+}
+
+// MockExportNotifier is a mock of ExportNotifier interface.
+type MockExportNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportNotifierMockRecorder
+}
+
+// MockExportNotifierMockRecorder is the mock recorder for MockExportNotifier.
+type MockExportNotifierMockRecorder struct {
+	mock *MockExportNotifier
+}
+
+// NewMockExportNotifier creates a new mock instance.
+func NewMockExportNotifier(ctrl *gomock.Controller) *MockExportNotifier {
+	mock := &MockExportNotifier{ctrl: ctrl}
+	mock.recorder = &MockExportNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportNotifier) EXPECT() *MockExportNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyExportReady mocks base method.
+func (m *MockExportNotifier) NotifyExportReady(ctx context.Context, export *domain.Export) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyExportReady", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyExportReady indicates an expected call of NotifyExportReady.
+func (mr *MockExportNotifierMockRecorder) NotifyExportReady(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyExportReady", reflect.TypeOf((*MockExportNotifier)(nil).NotifyExportReady), ctx, export)
+}
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindImportedRecord mocks base method.
+func (m *MockImportRepository) FindImportedRecord(ctx context.Context, userID uuid.UUID, section, sourceID string) (*domain.ImportedRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindImportedRecord", ctx, userID, section, sourceID)
+	ret0, _ := ret[0].(*domain.ImportedRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindImportedRecord indicates an expected call of FindImportedRecord.
+func (mr *MockImportRepositoryMockRecorder) FindImportedRecord(ctx, userID, section, sourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImportedRecord", reflect.TypeOf((*MockImportRepository)(nil).FindImportedRecord), ctx, userID, section, sourceID)
+}
+
+// SaveImportedRecord mocks base method.
+func (m *MockImportRepository) SaveImportedRecord(ctx context.Context, record *domain.ImportedRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveImportedRecord", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveImportedRecord indicates an expected call of SaveImportedRecord.
+func (mr *MockImportRepositoryMockRecorder) SaveImportedRecord(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImportedRecord", reflect.TypeOf((*MockImportRepository)(nil).SaveImportedRecord), ctx, record)
+}
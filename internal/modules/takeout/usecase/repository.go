@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+)
+
+// ExportRepository はデータのエクスポートのリポジトリインターフェース
+type ExportRepository interface {
+	// CreateExport はエクスポートを作成する
+	CreateExport(ctx context.Context, export *domain.Export) error
+	// UpdateExport はエクスポートの状態・ファイルを更新する
+	UpdateExport(ctx context.Context, export *domain.Export) error
+	// GetExport はIDでエクスポートを取得する（存在しない場合は nil, nil）
+	GetExport(ctx context.Context, id uuid.UUID) (*domain.Export, error)
+	// ListExportsByUser はユーザーのエクスポートを新しい順に最大 limit 件取得する
+	ListExportsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Export, error)
+	// FindActiveExport はユーザーの作成待ち・作成中のエクスポートを取得する（存在しない場合は nil, nil）
+	FindActiveExport(ctx context.Context, userID uuid.UUID) (*domain.Export, error)
+	// ClaimNextExport は作成待ち（または staleBefore より前に開始したまま止まっている）のエクスポートを1件取得して作成中にする
+	// 他のインスタンスが先に取得した場合は取得しない（対象がない場合は nil, nil）
+	ClaimNextExport(ctx context.Context, staleBefore, now time.Time) (*domain.Export, error)
+	// ListExpiredExports はダウンロードの期限が過ぎたファイルのあるエクスポートを最大 limit 件取得する
+	ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error)
+}
+
+// DataSource はエクスポートに含めるデータの取得元（タスク・コメント・グループなど）
+type DataSource interface {
+	// Collect はユーザーのデータを1つのセクションにまとめて返す
+	Collect(ctx context.Context, userID uuid.UUID) (*domain.Section, error)
+}
+
+// ExportNotifier はエクスポートのダウンロードの準備ができたことをユーザーに知らせる
+type ExportNotifier interface {
+	NotifyExportReady(ctx context.Context, export *domain.Export) error
+}
+
+// ImportRepository はインポートで復元したレコードの対応のリポジトリインターフェース
+type ImportRepository interface {
+	// FindImportedRecord は復元済みのレコードの対応を取得する（復元していない場合は nil, nil）
+	FindImportedRecord(ctx context.Context, userID uuid.UUID, section, sourceID string) (*domain.ImportedRecord, error)
+	// SaveImportedRecord は復元したレコードの対応を保存する
+	SaveImportedRecord(ctx context.Context, record *domain.ImportedRecord) error
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
+)
+
+// データのエクスポートのメトリクス
+const (
+	MetricDataExportsCompleted = "data_exports_completed_total"
+	MetricDataExportsFailed    = "data_exports_failed_total"
+)
+
+const (
+	// listExportsLimit はエクスポートの一覧で返す件数
+	listExportsLimit = 20
+	// staleExportTimeout は作成中のまま止まっている（作成中にプロセスが終了した）とみなすまでの時間
+	staleExportTimeout = 30 * time.Minute
+	// exportFailureMessage は作成に失敗した場合にユーザーに返す理由（内部のエラーは返さない）
+	exportFailureMessage = "failed to build export"
+)
+
+// ErrInvalidParameter はエクスポートの依頼が不正であることを表す
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// ExportScheduler はエクスポートの作成を依頼するインターフェース
+type ExportScheduler interface {
+	// Notify は作成待ちのエクスポートがあることを通知する（作成の完了は待たない）
+	Notify()
+}
+
+// TakeoutService はユーザーの全データ（タスク・コメント・添付ファイルの情報・グループ・友達・統計）のエクスポートを扱うサービス
+// 依頼を受け付けた後に非同期でZIP（JSONとCSV）を作成してストレージに保存し、準備ができたら通知する
+type TakeoutService struct {
+	Repository ExportRepository
+	// エクスポートに含めるデータの取得元（この順でZIPに書き出す）
+	Sources []DataSource
+	Storage commonDomain.FileStorage
+	// ダウンロードの準備ができたことの通知先（未設定の場合は通知しない）
+	Notifier ExportNotifier
+	// 作成の依頼先（未設定の場合は作成待ちのまま次の定期実行で作成する）
+	Scheduler ExportScheduler
+	// 作成したファイルをダウンロードできる期間
+	Retention time.Duration
+	Logger    logger.Logger
+
+	now func() time.Time
+}
+
+// NewTakeoutService はTakeoutServiceのコンストラクタ
+func NewTakeoutService(repo ExportRepository, sources []DataSource, storage commonDomain.FileStorage, retention time.Duration, logger logger.Logger) *TakeoutService {
+	if retention <= 0 {
+		retention = domain.DefaultExportRetention
+	}
+	return &TakeoutService{
+		Repository: repo,
+		Sources:    sources,
+		Storage:    storage,
+		Retention:  retention,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// RequestExport はエクスポートの作成を依頼する
+// 作成待ち・作成中のエクスポートがある場合は新しく作成せず、そのエクスポートを返す
+func (s *TakeoutService) RequestExport(ctx context.Context, userID uuid.UUID) (*domain.Export, error) {
+	if userID == uuid.Nil {
+		return nil, ErrInvalidParameter
+	}
+
+	active, err := s.Repository.FindActiveExport(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active export: %w", err)
+	}
+	if active != nil {
+		return active, nil
+	}
+
+	export := domain.NewExport(userID, s.now())
+	if err := s.Repository.CreateExport(ctx, export); err != nil {
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+	if s.Scheduler != nil {
+		s.Scheduler.Notify()
+	}
+	return export, nil
+}
+
+// ListExports はユーザーのエクスポートを新しい順に取得する
+func (s *TakeoutService) ListExports(ctx context.Context, userID uuid.UUID) ([]*domain.Export, error) {
+	exports, err := s.Repository.ListExportsByUser(ctx, userID, listExportsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exports: %w", err)
+	}
+	return exports, nil
+}
+
+// GetExport はユーザーのエクスポートを取得する（他のユーザーのエクスポートは存在しないものとして扱う）
+func (s *TakeoutService) GetExport(ctx context.Context, id, userID uuid.UUID) (*domain.Export, error) {
+	export, err := s.Repository.GetExport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	if export == nil || export.UserID != userID {
+		return nil, domain.ErrExportNotFound
+	}
+	return export, nil
+}
+
+// OpenDownload はダウンロードするエクスポートのファイルを開く（呼び出し側で閉じる）
+func (s *TakeoutService) OpenDownload(ctx context.Context, id, userID uuid.UUID) (*domain.Export, io.ReadCloser, error) {
+	export, err := s.GetExport(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := export.CheckDownloadable(s.now()); err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := s.Storage.Get(ctx, export.FileKey)
+	if errors.Is(err, commonDomain.ErrObjectNotFound) {
+		return nil, nil, domain.ErrExportExpired
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	return export, reader, nil
+}
+
+// ProcessPendingExports は作成待ちのエクスポートを最大limit件作成し、処理した件数を返す
+func (s *TakeoutService) ProcessPendingExports(ctx context.Context, limit int) (int, error) {
+	processed := 0
+	for processed < limit {
+		now := s.now()
+		export, err := s.Repository.ClaimNextExport(ctx, now.Add(-staleExportTimeout), now)
+		if err != nil {
+			return processed, fmt.Errorf("failed to claim export: %w", err)
+		}
+		if export == nil {
+			break
+		}
+		if err := s.BuildExport(ctx, export); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// BuildExport は作成中のエクスポートのZIPを作成してストレージに保存し、ダウンロードできるようにする
+// データの取得・保存に失敗した場合は作成失敗として記録し、エラーは返さない（ユーザーが依頼し直す）
+func (s *TakeoutService) BuildExport(ctx context.Context, export *domain.Export) error {
+	key := domain.ExportFileKey(export.UserID, export.ID)
+	size, err := s.writeArchive(ctx, export, key)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to build export",
+			logger.Any("exportID", export.ID),
+			logger.Any("userID", export.UserID),
+			logger.Error(err))
+		metrics.Counter(MetricDataExportsFailed).Add(1)
+		export.Fail(exportFailureMessage, s.now())
+		if err := s.Repository.UpdateExport(ctx, export); err != nil {
+			return fmt.Errorf("failed to update export: %w", err)
+		}
+		return nil
+	}
+
+	export.Complete(key, size, s.Retention, s.now())
+	if err := s.Repository.UpdateExport(ctx, export); err != nil {
+		return fmt.Errorf("failed to update export: %w", err)
+	}
+	metrics.Counter(MetricDataExportsCompleted).Add(1)
+
+	if s.Notifier != nil {
+		if err := s.Notifier.NotifyExportReady(ctx, export); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to notify export ready",
+				logger.Any("exportID", export.ID),
+				logger.Error(err))
+		}
+	}
+	return nil
+}
+
+// writeArchive は全ての取得元のデータを集めてZIPを作成し、ストレージに保存したサイズを返す
+// ZIPはメモリに溜めずにストレージに書き込みながら作成する
+func (s *TakeoutService) writeArchive(ctx context.Context, export *domain.Export, key string) (int64, error) {
+	sections := make([]*domain.Section, 0, len(s.Sources))
+	for _, source := range s.Sources {
+		section, err := source.Collect(ctx, export.UserID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to collect data: %w", err)
+		}
+		sections = append(sections, section)
+	}
+
+	pr, pw := io.Pipe()
+	counter := &countingWriter{w: pw}
+	done := make(chan struct{})
+	generatedAt := s.now()
+	go func() {
+		defer close(done)
+		pw.CloseWithError(domain.WriteArchive(counter, export, generatedAt, sections))
+	}()
+
+	err := s.Storage.Put(ctx, key, "application/zip", pr)
+	// 保存に失敗した場合に書き込み側が止まったままにならないようにする
+	pr.Close()
+	<-done
+	if err != nil {
+		return 0, fmt.Errorf("failed to store export: %w", err)
+	}
+	return counter.n, nil
+}
+
+// DeleteExpiredExports はダウンロードの期限が過ぎたエクスポートのファイルを最大limit件削除し、削除した件数を返す
+func (s *TakeoutService) DeleteExpiredExports(ctx context.Context, limit int) (int, error) {
+	exports, err := s.Repository.ListExpiredExports(ctx, s.now(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired exports: %w", err)
+	}
+
+	deleted := 0
+	for _, export := range exports {
+		if err := s.Storage.Delete(ctx, export.FileKey); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to delete expired export",
+				logger.Any("exportID", export.ID),
+				logger.Error(err))
+			continue
+		}
+		export.Expire()
+		if err := s.Repository.UpdateExport(ctx, export); err != nil {
+			return deleted, fmt.Errorf("failed to update export: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// countingWriter は書き込んだバイト数を数える
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/storage"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	"github.com/hryt430/Yotei+/internal/modules/takeout/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+type testScheduler struct{ notified int }
+
+func (s *testScheduler) Notify() { s.notified++ }
+
+// failingStorage は保存に失敗するストレージ
+type failingStorage struct{ commonDomain.FileStorage }
+
+func (failingStorage) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	return errors.New("disk full")
+}
+
+type testService struct {
+	*TakeoutService
+	repo     *mocks.MockExportRepository
+	source   *mocks.MockDataSource
+	notifier *mocks.MockExportNotifier
+	storage  *storage.MemoryStorage
+}
+
+func newTestService(t *testing.T) *testService {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockExportRepository(ctrl)
+	source := mocks.NewMockDataSource(ctrl)
+	notifier := mocks.NewMockExportNotifier(ctrl)
+	files := storage.NewMemoryStorage()
+
+	service := NewTakeoutService(repo, []DataSource{source}, files, 48*time.Hour, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"}))
+	service.Notifier = notifier
+	service.now = func() time.Time { return testNow }
+	return &testService{TakeoutService: service, repo: repo, source: source, notifier: notifier, storage: files}
+}
+
+func TestTakeoutService_RequestExport(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("creates a pending export and wakes the worker", func(t *testing.T) {
+		s := newTestService(t)
+		scheduler := &testScheduler{}
+		s.Scheduler = scheduler
+		s.repo.EXPECT().FindActiveExport(ctx, userID).Return(nil, nil)
+		s.repo.EXPECT().CreateExport(ctx, gomock.Any()).Return(nil)
+
+		export, err := s.RequestExport(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, userID, export.UserID)
+		assert.Equal(t, domain.ExportPending, export.Status)
+		assert.Equal(t, testNow, export.CreatedAt)
+		assert.Equal(t, 1, scheduler.notified)
+	})
+
+	t.Run("returns the export already in progress", func(t *testing.T) {
+		s := newTestService(t)
+		active := domain.NewExport(userID, testNow.Add(-time.Minute))
+		active.Start(testNow)
+		s.repo.EXPECT().FindActiveExport(ctx, userID).Return(active, nil)
+
+		export, err := s.RequestExport(ctx, userID)
+		require.NoError(t, err)
+		assert.Same(t, active, export)
+	})
+
+	t.Run("rejects an empty user", func(t *testing.T) {
+		s := newTestService(t)
+		_, err := s.RequestExport(ctx, uuid.Nil)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestTakeoutService_GetExport(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	export := domain.NewExport(userID, testNow)
+
+	t.Run("returns the user's export", func(t *testing.T) {
+		s := newTestService(t)
+		s.repo.EXPECT().GetExport(ctx, export.ID).Return(export, nil)
+
+		got, err := s.GetExport(ctx, export.ID, userID)
+		require.NoError(t, err)
+		assert.Same(t, export, got)
+	})
+
+	t.Run("hides exports of other users", func(t *testing.T) {
+		s := newTestService(t)
+		s.repo.EXPECT().GetExport(ctx, export.ID).Return(export, nil)
+
+		_, err := s.GetExport(ctx, export.ID, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrExportNotFound)
+	})
+}
+
+func TestTakeoutService_ProcessPendingExports(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("builds the archive, stores it and notifies the user", func(t *testing.T) {
+		s := newTestService(t)
+		export := domain.NewExport(userID, testNow)
+		export.Start(testNow)
+		s.repo.EXPECT().ClaimNextExport(ctx, testNow.Add(-staleExportTimeout), testNow).Return(export, nil)
+		s.repo.EXPECT().ClaimNextExport(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.source.EXPECT().Collect(ctx, userID).Return(&domain.Section{
+			Name:    "tasks",
+			Columns: []string{"id", "title"},
+			Records: []domain.Record{{"id": "task-1", "title": "資料作成"}},
+		}, nil)
+		s.repo.EXPECT().UpdateExport(ctx, export).Return(nil)
+		s.notifier.EXPECT().NotifyExportReady(ctx, export).Return(nil)
+
+		processed, err := s.ProcessPendingExports(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		assert.Equal(t, domain.ExportReady, export.Status)
+		assert.Equal(t, domain.ExportFileKey(userID, export.ID), export.FileKey)
+		assert.Equal(t, testNow.Add(48*time.Hour), *export.ExpiresAt)
+
+		reader, err := s.storage.Get(ctx, export.FileKey)
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(content)), export.SizeBytes)
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 3)
+		assert.Equal(t, "tasks.csv", archive.File[2].Name)
+	})
+
+	t.Run("marks the export failed when data cannot be collected", func(t *testing.T) {
+		s := newTestService(t)
+		export := domain.NewExport(userID, testNow)
+		export.Start(testNow)
+		s.repo.EXPECT().ClaimNextExport(ctx, gomock.Any(), gomock.Any()).Return(export, nil)
+		s.repo.EXPECT().ClaimNextExport(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.source.EXPECT().Collect(ctx, userID).Return(nil, errors.New("database is down"))
+		s.repo.EXPECT().UpdateExport(ctx, export).Return(nil)
+
+		processed, err := s.ProcessPendingExports(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		assert.Equal(t, domain.ExportFailed, export.Status)
+		assert.Equal(t, exportFailureMessage, export.Error)
+	})
+
+	t.Run("marks the export failed when the archive cannot be stored", func(t *testing.T) {
+		s := newTestService(t)
+		s.Storage = failingStorage{}
+		export := domain.NewExport(userID, testNow)
+		export.Start(testNow)
+		s.source.EXPECT().Collect(ctx, userID).Return(&domain.Section{Name: "tasks", Columns: []string{"id"}}, nil)
+		s.repo.EXPECT().UpdateExport(ctx, export).Return(nil)
+
+		require.NoError(t, s.BuildExport(ctx, export))
+		assert.Equal(t, domain.ExportFailed, export.Status)
+		assert.Empty(t, export.FileKey)
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		s := newTestService(t)
+		first := domain.NewExport(userID, testNow)
+		s.repo.EXPECT().ClaimNextExport(ctx, gomock.Any(), gomock.Any()).Return(first, nil)
+		s.source.EXPECT().Collect(ctx, userID).Return(&domain.Section{Name: "tasks", Columns: []string{"id"}}, nil)
+		s.repo.EXPECT().UpdateExport(ctx, first).Return(nil)
+		s.notifier.EXPECT().NotifyExportReady(ctx, first).Return(errors.New("smtp down"))
+
+		processed, err := s.ProcessPendingExports(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		assert.Equal(t, domain.ExportReady, first.Status)
+	})
+}
+
+func TestTakeoutService_OpenDownload(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	newReadyExport := func(s *testService) *domain.Export {
+		export := domain.NewExport(userID, testNow.Add(-time.Hour))
+		key := domain.ExportFileKey(userID, export.ID)
+		require.NoError(t, s.storage.Put(ctx, key, "application/zip", bytes.NewReader([]byte("zip"))))
+		export.Complete(key, 3, time.Hour, testNow.Add(-30*time.Minute))
+		return export
+	}
+
+	t.Run("opens a ready export", func(t *testing.T) {
+		s := newTestService(t)
+		export := newReadyExport(s)
+		s.repo.EXPECT().GetExport(ctx, export.ID).Return(export, nil)
+
+		got, reader, err := s.OpenDownload(ctx, export.ID, userID)
+		require.NoError(t, err)
+		defer reader.Close()
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Same(t, export, got)
+		assert.Equal(t, "zip", string(content))
+	})
+
+	t.Run("rejects an expired export", func(t *testing.T) {
+		s := newTestService(t)
+		export := newReadyExport(s)
+		s.now = func() time.Time { return testNow.Add(time.Hour) }
+		s.repo.EXPECT().GetExport(ctx, export.ID).Return(export, nil)
+
+		_, _, err := s.OpenDownload(ctx, export.ID, userID)
+		assert.ErrorIs(t, err, domain.ErrExportExpired)
+	})
+
+	t.Run("rejects an export still in progress", func(t *testing.T) {
+		s := newTestService(t)
+		export := domain.NewExport(userID, testNow)
+		s.repo.EXPECT().GetExport(ctx, export.ID).Return(export, nil)
+
+		_, _, err := s.OpenDownload(ctx, export.ID, userID)
+		assert.ErrorIs(t, err, domain.ErrExportNotReady)
+	})
+
+	t.Run("treats a missing file as expired", func(t *testing.T) {
+		s := newTestService(t)
+		export := newReadyExport(s)
+		require.NoError(t, s.storage.Delete(ctx, export.FileKey))
+		s.repo.EXPECT().GetExport(ctx, export.ID).Return(export, nil)
+
+		_, _, err := s.OpenDownload(ctx, export.ID, userID)
+		assert.ErrorIs(t, err, domain.ErrExportExpired)
+	})
+}
+
+func TestTakeoutService_DeleteExpiredExports(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	userID := uuid.New()
+	export := domain.NewExport(userID, testNow.Add(-72*time.Hour))
+	key := domain.ExportFileKey(userID, export.ID)
+	require.NoError(t, s.storage.Put(ctx, key, "application/zip", bytes.NewReader([]byte("zip"))))
+	export.Complete(key, 3, 48*time.Hour, testNow.Add(-72*time.Hour))
+
+	s.repo.EXPECT().ListExpiredExports(ctx, testNow, 50).Return([]*domain.Export{export}, nil)
+	s.repo.EXPECT().UpdateExport(ctx, export).Return(nil)
+
+	deleted, err := s.DeleteExpiredExports(ctx, 50)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Equal(t, domain.ExportExpired, export.Status)
+	_, err = s.storage.Get(ctx, key)
+	assert.ErrorIs(t, err, commonDomain.ErrObjectNotFound)
+}
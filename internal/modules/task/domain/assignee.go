@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"time"
+)
+
+// TaskAssignee はタスクの担当者と担当者ごとの完了状態を表す
+type TaskAssignee struct {
+	UserID      string     `json:"user_id"`
+	AssignedAt  time.Time  `json:"assigned_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// IsCompleted は担当者が自分の分を完了しているかを判定する
+func (a *TaskAssignee) IsCompleted() bool {
+	return a.CompletedAt != nil
+}
+
+// AssigneeIDs は担当者IDの一覧を割り当て順で返す
+// 担当者一覧が未設定の場合はAssigneeIDを担当者として扱う
+func (t *Task) AssigneeIDs() []string {
+	if len(t.Assignees) == 0 {
+		if t.AssigneeID != nil {
+			return []string{*t.AssigneeID}
+		}
+		return []string{}
+	}
+
+	ids := make([]string, 0, len(t.Assignees))
+	for _, assignee := range t.Assignees {
+		ids = append(ids, assignee.UserID)
+	}
+	return ids
+}
+
+// PendingAssigneeIDs は自分の分を完了していない担当者IDの一覧を返す
+func (t *Task) PendingAssigneeIDs() []string {
+	if len(t.Assignees) == 0 {
+		if t.AssigneeID != nil && t.Status != TaskStatusDone {
+			return []string{*t.AssigneeID}
+		}
+		return []string{}
+	}
+
+	ids := []string{}
+	for _, assignee := range t.Assignees {
+		if !assignee.IsCompleted() {
+			ids = append(ids, assignee.UserID)
+		}
+	}
+	return ids
+}
+
+// HasAssignee は指定ユーザーが担当者に含まれるかを判定する
+func (t *Task) HasAssignee(userID string) bool {
+	for _, id := range t.AssigneeIDs() {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAssignee は指定ユーザーの担当情報を返す（担当者でない場合はnil）
+func (t *Task) FindAssignee(userID string) *TaskAssignee {
+	t.ensureAssignees()
+	for _, assignee := range t.Assignees {
+		if assignee.UserID == userID {
+			return assignee
+		}
+	}
+	return nil
+}
+
+// AddAssignee は担当者を追加する。既に担当者の場合はfalseを返す
+func (t *Task) AddAssignee(userID string) bool {
+	if t.HasAssignee(userID) {
+		return false
+	}
+
+	t.ensureAssignees()
+	now := time.Now()
+	t.Assignees = append(t.Assignees, &TaskAssignee{
+		UserID:     userID,
+		AssignedAt: now,
+	})
+	t.syncPrimaryAssignee()
+	t.UpdatedAt = now
+	t.UpdateIsOverdue()
+	return true
+}
+
+// RemoveAssignee は担当者を外す。担当者でない場合はfalseを返す
+func (t *Task) RemoveAssignee(userID string) bool {
+	t.ensureAssignees()
+	for i, assignee := range t.Assignees {
+		if assignee.UserID == userID {
+			t.Assignees = append(t.Assignees[:i], t.Assignees[i+1:]...)
+			t.syncPrimaryAssignee()
+			t.UpdatedAt = time.Now()
+			return true
+		}
+	}
+	return false
+}
+
+// ReassignTo は既存の担当者をすべて外し、指定ユーザーのみを担当者にする
+func (t *Task) ReassignTo(userID string) {
+	now := time.Now()
+	t.Assignees = []*TaskAssignee{{UserID: userID, AssignedAt: now}}
+	t.syncPrimaryAssignee()
+	t.UpdatedAt = now
+	t.UpdateIsOverdue()
+}
+
+// CompleteAssignment は担当者の分を完了にする
+// 全員完了が必要なタスクは全担当者が完了した時点で、それ以外は最初の完了でタスクを完了にする
+// 担当者でない場合はfalseを返す
+func (t *Task) CompleteAssignment(userID string) bool {
+	assignee := t.FindAssignee(userID)
+	if assignee == nil {
+		return false
+	}
+
+	now := time.Now()
+	if assignee.CompletedAt == nil {
+		assignee.CompletedAt = &now
+	}
+	t.UpdatedAt = now
+
+	if !t.RequireAllAssignees || len(t.PendingAssigneeIDs()) == 0 {
+		if t.Status != TaskStatusDone {
+			t.SetStatus(TaskStatusDone)
+		}
+	}
+	return true
+}
+
+// ReopenAssignment は担当者の完了を取り消す
+// 全員完了が必要なタスクが完了済みの場合は進行中に戻す
+// 担当者でない場合はfalseを返す
+func (t *Task) ReopenAssignment(userID string) bool {
+	assignee := t.FindAssignee(userID)
+	if assignee == nil {
+		return false
+	}
+
+	assignee.CompletedAt = nil
+	t.UpdatedAt = time.Now()
+
+	if t.RequireAllAssignees && t.Status == TaskStatusDone {
+		t.SetStatus(TaskStatusInProgress)
+	}
+	return true
+}
+
+// AssigneeProgress は完了済み担当者数と担当者数を返す
+func (t *Task) AssigneeProgress() (completed, total int) {
+	ids := t.AssigneeIDs()
+	total = len(ids)
+	if len(t.Assignees) == 0 {
+		if total > 0 && t.Status == TaskStatusDone {
+			completed = total
+		}
+		return completed, total
+	}
+
+	for _, assignee := range t.Assignees {
+		if assignee.IsCompleted() {
+			completed++
+		}
+	}
+	return completed, total
+}
+
+// ensureAssignees は担当者一覧が未設定でAssigneeIDのみある場合に一覧へ移す
+func (t *Task) ensureAssignees() {
+	if len(t.Assignees) == 0 && t.AssigneeID != nil {
+		t.Assignees = []*TaskAssignee{{UserID: *t.AssigneeID, AssignedAt: t.UpdatedAt}}
+	}
+}
+
+// syncPrimaryAssignee はAssigneeIDを最初の担当者に合わせる（単一担当者APIとの互換性）
+func (t *Task) syncPrimaryAssignee() {
+	if len(t.Assignees) == 0 {
+		t.AssigneeID = nil
+		return
+	}
+	id := t.Assignees[0].UserID
+	t.AssigneeID = &id
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_AddRemoveAssignee(t *testing.T) {
+	task := NewTask("task", "", PriorityMedium, CategoryWork, "owner")
+
+	assert.True(t, task.AddAssignee("user-1"))
+	assert.True(t, task.AddAssignee("user-2"))
+	assert.False(t, task.AddAssignee("user-1"))
+
+	assert.Equal(t, []string{"user-1", "user-2"}, task.AssigneeIDs())
+	require.NotNil(t, task.AssigneeID)
+	assert.Equal(t, "user-1", *task.AssigneeID)
+
+	assert.True(t, task.RemoveAssignee("user-1"))
+	assert.False(t, task.RemoveAssignee("user-1"))
+	assert.Equal(t, "user-2", *task.AssigneeID)
+
+	assert.True(t, task.RemoveAssignee("user-2"))
+	assert.Nil(t, task.AssigneeID)
+	assert.Empty(t, task.AssigneeIDs())
+}
+
+func TestTask_LegacyAssigneeID(t *testing.T) {
+	assigneeID := "user-1"
+	task := &Task{ID: "task", Status: TaskStatusTodo, AssigneeID: &assigneeID}
+
+	assert.True(t, task.HasAssignee("user-1"))
+	assert.Equal(t, []string{"user-1"}, task.PendingAssigneeIDs())
+
+	assert.True(t, task.AddAssignee("user-2"))
+	assert.Equal(t, []string{"user-1", "user-2"}, task.AssigneeIDs())
+}
+
+func TestTask_ReassignTo(t *testing.T) {
+	task := NewTask("task", "", PriorityMedium, CategoryWork, "owner")
+	task.AddAssignee("user-1")
+	task.AddAssignee("user-2")
+
+	task.ReassignTo("user-3")
+
+	assert.Equal(t, []string{"user-3"}, task.AssigneeIDs())
+	assert.Equal(t, "user-3", *task.AssigneeID)
+}
+
+func TestTask_CompleteAssignment(t *testing.T) {
+	t.Run("require all assignees", func(t *testing.T) {
+		task := NewTask("task", "", PriorityMedium, CategoryWork, "owner")
+		task.RequireAllAssignees = true
+		task.AddAssignee("user-1")
+		task.AddAssignee("user-2")
+
+		assert.True(t, task.CompleteAssignment("user-1"))
+		assert.Equal(t, TaskStatusTodo, task.Status)
+		assert.Equal(t, []string{"user-2"}, task.PendingAssigneeIDs())
+
+		assert.True(t, task.CompleteAssignment("user-2"))
+		assert.Equal(t, TaskStatusDone, task.Status)
+		assert.NotNil(t, task.CompletedAt)
+
+		completed, total := task.AssigneeProgress()
+		assert.Equal(t, 2, completed)
+		assert.Equal(t, 2, total)
+
+		assert.True(t, task.ReopenAssignment("user-2"))
+		assert.Equal(t, TaskStatusInProgress, task.Status)
+		assert.Nil(t, task.CompletedAt)
+	})
+
+	t.Run("any assignee completes task", func(t *testing.T) {
+		task := NewTask("task", "", PriorityMedium, CategoryWork, "owner")
+		task.AddAssignee("user-1")
+		task.AddAssignee("user-2")
+
+		assert.True(t, task.CompleteAssignment("user-2"))
+		assert.Equal(t, TaskStatusDone, task.Status)
+	})
+
+	t.Run("not an assignee", func(t *testing.T) {
+		task := NewTask("task", "", PriorityMedium, CategoryWork, "owner")
+		task.AddAssignee("user-1")
+
+		assert.False(t, task.CompleteAssignment("user-9"))
+		assert.False(t, task.ReopenAssignment("user-9"))
+		assert.Equal(t, TaskStatusTodo, task.Status)
+	})
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ThumbnailStatus は添付ファイルのサムネイルの生成状況を表す
+type ThumbnailStatus string
+
+const (
+	// ThumbnailNone は画像ではないためサムネイルを生成しないこと
+	ThumbnailNone ThumbnailStatus = "NONE"
+	// ThumbnailPending は生成待ちであること
+	ThumbnailPending ThumbnailStatus = "PENDING"
+	// ThumbnailReady は全サイズのサムネイルを生成済みであること
+	ThumbnailReady ThumbnailStatus = "READY"
+	// ThumbnailFailed は画像を読み込めなかったため生成できなかったこと
+	ThumbnailFailed ThumbnailStatus = "FAILED"
+)
+
+// ThumbnailSize はサムネイルの大きさを表す
+type ThumbnailSize string
+
+const (
+	ThumbnailSmall  ThumbnailSize = "small"
+	ThumbnailMedium ThumbnailSize = "medium"
+	ThumbnailLarge  ThumbnailSize = "large"
+)
+
+// ThumbnailSizes は生成するサムネイルの大きさの一覧
+var ThumbnailSizes = []ThumbnailSize{ThumbnailSmall, ThumbnailMedium, ThumbnailLarge}
+
+// Pixels はサムネイルの長辺の最大ピクセル数を返す
+func (s ThumbnailSize) Pixels() int {
+	switch s {
+	case ThumbnailSmall:
+		return 128
+	case ThumbnailMedium:
+		return 320
+	case ThumbnailLarge:
+		return 640
+	}
+	return 0
+}
+
+// ParseThumbnailSize はサムネイルの大きさを解析する（空の場合は medium）
+func ParseThumbnailSize(s string) (ThumbnailSize, error) {
+	if s == "" {
+		return ThumbnailMedium, nil
+	}
+	size := ThumbnailSize(s)
+	if size.Pixels() == 0 {
+		return "", fmt.Errorf("invalid thumbnail size: %s", s)
+	}
+	return size, nil
+}
+
+// Attachment はタスクの添付ファイルを表す
+// ファイル本体とサムネイルはストレージに保存し、StorageKey を接頭辞としたキーで参照する
+type Attachment struct {
+	ID              string          `json:"id"`
+	TaskID          string          `json:"task_id"`
+	Filename        string          `json:"filename"`
+	StorageKey      string          `json:"-"`
+	Size            int64           `json:"size"`
+	MimeType        string          `json:"mime_type"`
+	ThumbnailStatus ThumbnailStatus `json:"thumbnail_status"`
+	UploadedBy      string          `json:"uploaded_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// NewAttachment は新しい添付ファイルを作成する（サムネイルを生成できる画像は生成待ちにする）
+func NewAttachment(id, taskID, filename, mimeType string, size int64, uploadedBy string) *Attachment {
+	status := ThumbnailNone
+	if IsThumbnailable(mimeType) {
+		status = ThumbnailPending
+	}
+	return &Attachment{
+		ID:              id,
+		TaskID:          taskID,
+		Filename:        filename,
+		StorageKey:      fmt.Sprintf("attachments/%s/%s", taskID, id),
+		Size:            size,
+		MimeType:        mimeType,
+		ThumbnailStatus: status,
+		UploadedBy:      uploadedBy,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// IsThumbnailable はサムネイルを生成できる画像の形式かを返す
+func IsThumbnailable(mimeType string) bool {
+	switch mimeType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// ObjectKey はファイル本体のストレージのキーを返す
+func (a *Attachment) ObjectKey() string {
+	return a.StorageKey + "/original"
+}
+
+// ThumbnailKey はサムネイルのストレージのキーを返す
+func (a *Attachment) ThumbnailKey(size ThumbnailSize) string {
+	return a.StorageKey + "/thumbnail-" + string(size)
+}
+
+// ThumbnailMimeType はサムネイルの形式を返す（JPEGの画像はJPEG、それ以外は透過を保つためPNG）
+func (a *Attachment) ThumbnailMimeType() string {
+	if a.MimeType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAttachment(t *testing.T) {
+	image := NewAttachment("att-1", "task-1", "photo.png", "image/png", 2048, "user-1")
+	assert.Equal(t, ThumbnailPending, image.ThumbnailStatus)
+	assert.Equal(t, "attachments/task-1/att-1/original", image.ObjectKey())
+	assert.Equal(t, "attachments/task-1/att-1/thumbnail-small", image.ThumbnailKey(ThumbnailSmall))
+	assert.Equal(t, "image/png", image.ThumbnailMimeType())
+
+	document := NewAttachment("att-2", "task-1", "spec.pdf", "application/pdf", 2048, "user-1")
+	assert.Equal(t, ThumbnailNone, document.ThumbnailStatus)
+
+	photo := NewAttachment("att-3", "task-1", "photo.jpg", "image/jpeg", 2048, "user-1")
+	assert.Equal(t, "image/jpeg", photo.ThumbnailMimeType())
+}
+
+func TestParseThumbnailSize(t *testing.T) {
+	size, err := ParseThumbnailSize("")
+	assert.NoError(t, err)
+	assert.Equal(t, ThumbnailMedium, size)
+
+	size, err = ParseThumbnailSize("large")
+	assert.NoError(t, err)
+	assert.Equal(t, 640, size.Pixels())
+
+	_, err = ParseThumbnailSize("huge")
+	assert.Error(t, err)
+}
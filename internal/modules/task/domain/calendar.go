@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+)
+
+// MaxCalendarRangeDays はカレンダーで一度に取得できる期間の上限（日）
+const MaxCalendarRangeDays = 62
+
+// CalendarEntryKind はカレンダー上の予定の種類を表す型
+type CalendarEntryKind string
+
+// カレンダー上の予定の種類
+const (
+	CalendarEntryStart CalendarEntryKind = "START" // 着手予定日時
+	CalendarEntryDue   CalendarEntryKind = "DUE"   // 期限
+)
+
+// CalendarEntry はカレンダーの1日に表示する予定を表す
+type CalendarEntry struct {
+	Kind CalendarEntryKind `json:"kind"`
+	At   time.Time         `json:"at"` // カレンダーのタイムゾーンでの日時
+	Task *Task             `json:"task"`
+}
+
+// CalendarDay はカレンダーの1日分の予定を表す
+type CalendarDay struct {
+	Date    string           `json:"date"`              // "2006-01-02"
+	Holiday string           `json:"holiday,omitempty"` // 祝日の名前（祝日でない場合は省略）
+	Entries []*CalendarEntry `json:"entries"`
+}
+
+// Calendar は期間内の予定を日ごとにまとめたカレンダーを表す
+type Calendar struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Timezone string         `json:"timezone"`
+	Days     []*CalendarDay `json:"days"`
+}
+
+// NewCalendar はタスクの着手予定日時と期限を日ごとに振り分けてカレンダーを作成する
+// from・toは暦日としてlocで解釈し、予定のない日も空の日として含める。祝日の日には祝日の名前を付ける
+func NewCalendar(tasks []*Task, from, to time.Time, loc *time.Location, holidays holiday.Provider) *Calendar {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+
+	calendar := &Calendar{
+		From:     start.Format("2006-01-02"),
+		To:       end.Format("2006-01-02"),
+		Timezone: loc.String(),
+		Days:     []*CalendarDay{},
+	}
+
+	days := make(map[string]*CalendarDay)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		calendarDay := &CalendarDay{
+			Date:    day.Format("2006-01-02"),
+			Entries: []*CalendarEntry{},
+		}
+		calendarDay.Holiday, _ = holiday.Name(holidays, day)
+		calendar.Days = append(calendar.Days, calendarDay)
+		days[calendarDay.Date] = calendarDay
+	}
+
+	add := func(task *Task, kind CalendarEntryKind, at *time.Time) {
+		if at == nil {
+			return
+		}
+		local := at.In(loc)
+		if day, ok := days[local.Format("2006-01-02")]; ok {
+			day.Entries = append(day.Entries, &CalendarEntry{Kind: kind, At: local, Task: task})
+		}
+	}
+	for _, task := range tasks {
+		add(task, CalendarEntryStart, task.StartDate)
+		add(task, CalendarEntryDue, task.DueDate)
+	}
+
+	for _, day := range calendar.Days {
+		sort.SliceStable(day.Entries, func(i, j int) bool {
+			if !day.Entries[i].At.Equal(day.Entries[j].At) {
+				return day.Entries[i].At.Before(day.Entries[j].At)
+			}
+			return day.Entries[i].Task.ID < day.Entries[j].Task.ID
+		})
+	}
+
+	return calendar
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendar(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	// 2024-06-03 16:00 UTC is 2024-06-04 01:00 in Tokyo
+	lateNight := NewTask("late", "", PriorityMedium, CategoryWork, "user-1")
+	lateNight.ID = "late"
+	lateNight.SetDueDate(time.Date(2024, 6, 3, 16, 0, 0, 0, time.UTC))
+
+	spanning := NewTask("spanning", "", PriorityMedium, CategoryWork, "user-1")
+	spanning.ID = "spanning"
+	start := time.Date(2024, 6, 3, 15, 30, 0, 0, time.UTC)
+	spanning.SetStartDate(&start)
+	spanning.SetDueDate(time.Date(2024, 6, 20, 9, 0, 0, 0, time.UTC)) // outside the range
+
+	calendar := NewCalendar([]*Task{lateNight, spanning}, from, to, tokyo, nil)
+
+	assert.Equal(t, "2024-06-03", calendar.From)
+	assert.Equal(t, "2024-06-05", calendar.To)
+	assert.Equal(t, "Asia/Tokyo", calendar.Timezone)
+	require.Len(t, calendar.Days, 3)
+
+	assert.Len(t, calendar.Days[0].Entries, 0)
+	require.Len(t, calendar.Days[1].Entries, 2)
+	assert.Equal(t, CalendarEntryStart, calendar.Days[1].Entries[0].Kind)
+	assert.Equal(t, "spanning", calendar.Days[1].Entries[0].Task.ID)
+	assert.Equal(t, CalendarEntryDue, calendar.Days[1].Entries[1].Kind)
+	assert.Equal(t, "late", calendar.Days[1].Entries[1].Task.ID)
+	assert.Equal(t, tokyo, calendar.Days[1].Entries[1].At.Location())
+	assert.NotNil(t, calendar.Days[2].Entries, "empty days are returned as empty lists")
+}
+
+func TestNewCalendar_Holidays(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	from := time.Date(2024, 5, 2, 0, 0, 0, 0, tokyo)
+	to := time.Date(2024, 5, 7, 0, 0, 0, 0, tokyo)
+
+	calendar := NewCalendar(nil, from, to, tokyo, holiday.Japan())
+
+	require.Len(t, calendar.Days, 6)
+	var names []string
+	for _, day := range calendar.Days {
+		names = append(names, day.Holiday)
+	}
+	assert.Equal(t, []string{"", "憲法記念日", "みどりの日", "こどもの日", "振替休日", ""}, names)
+}
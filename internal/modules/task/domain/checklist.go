@@ -0,0 +1,18 @@
+package domain
+
+// ChecklistItem はタスク説明のタスクリスト（- [ ] / - [x]）から抽出したチェックリストの項目
+// 説明の保存時に抽出するため、チェック状態の変更は説明の編集で行う
+type ChecklistItem struct {
+	Text    string `json:"text" example:"資料を印刷する"`
+	Checked bool   `json:"checked" example:"false"`
+} // @name ChecklistItem
+
+// ChecklistProgress はチェック済みの項目数と全項目数を返す
+func (t *Task) ChecklistProgress() (completed, total int) {
+	for _, item := range t.Checklist {
+		if item.Checked {
+			completed++
+		}
+	}
+	return completed, len(t.Checklist)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTask_ChecklistProgress(t *testing.T) {
+	task := NewTask("task", "", PriorityMedium, CategoryWork, "owner")
+
+	completed, total := task.ChecklistProgress()
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 0, total)
+
+	task.Checklist = []ChecklistItem{
+		{Text: "milk", Checked: true},
+		{Text: "eggs"},
+		{Text: "bread", Checked: true},
+	}
+	completed, total = task.ChecklistProgress()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 3, total)
+}
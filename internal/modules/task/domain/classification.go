@@ -0,0 +1,30 @@
+package domain
+
+// Classification はタスクのタイトル・説明から推定したカテゴリと優先度
+// 手がかりがない場合はCategoryOther・PriorityMediumで、確信度は0になる
+type Classification struct {
+	Category           Category `json:"category" example:"WORK"`
+	CategoryConfidence float64  `json:"category_confidence" example:"0.75"` // 0〜1（0の場合は推定なし）
+	Priority           Priority `json:"priority" example:"HIGH"`
+	PriorityConfidence float64  `json:"priority_confidence" example:"1"` // 0〜1（0の場合は推定なし）
+	// 推定の根拠になったキーワード
+	Keywords []string `json:"keywords,omitempty" example:"会議,至急"`
+} // @name Classification
+
+// NewUnclassified は推定なしの分類結果を返す
+func NewUnclassified() *Classification {
+	return &Classification{
+		Category: CategoryOther,
+		Priority: PriorityMedium,
+	}
+}
+
+// HasCategory はカテゴリを推定できたかを返す
+func (c *Classification) HasCategory() bool {
+	return c != nil && c.CategoryConfidence > 0
+}
+
+// HasPriority は優先度を推定できたかを返す
+func (c *Classification) HasPriority() bool {
+	return c != nil && c.PriorityConfidence > 0
+}
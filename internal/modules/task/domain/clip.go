@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	// MaxClipSelectionBytes はクリップする選択範囲の最大サイズ（説明に収まらない分は切り詰める）
+	MaxClipSelectionBytes = 10000
+	// MaxClipNoteBytes はクリップに添えるメモの最大サイズ
+	MaxClipNoteBytes = 1000
+
+	maxClipTitleBytes       = 255
+	maxClipDescriptionBytes = 2000
+)
+
+// ErrInvalidClip はクリップするページのURLが正しくないことを表すエラー
+var ErrInvalidClip = errors.New("invalid clip")
+
+// Clip はブラウザの拡張機能からクリップしたページ（URL・タイトル・選択範囲・メモ）
+type Clip struct {
+	URL string
+	// Title はページのタイトル（空の場合はURLのホストとパスをタスクのタイトルにする）
+	Title     string
+	Selection string
+	Note      string
+}
+
+// Validate はURLがhttp・httpsの絶対URLかチェックする
+func (c Clip) Validate() error {
+	if _, err := normalizeTaskLinkURL(c.URL); err != nil {
+		return ErrInvalidClip
+	}
+	if len(c.Selection) > MaxClipSelectionBytes || len(c.Note) > MaxClipNoteBytes {
+		return ErrInvalidClip
+	}
+	return nil
+}
+
+// TaskTitle はタスクのタイトル（ページのタイトル、空の場合はURLのホストとパス）を返す
+func (c Clip) TaskTitle() string {
+	title := strings.Join(strings.Fields(c.Title), " ")
+	if title == "" {
+		title = strings.TrimSpace(c.URL)
+		if parsed, err := url.Parse(title); err == nil && parsed.Host != "" {
+			title = strings.TrimSuffix(parsed.Host+parsed.Path, "/")
+		}
+	}
+	return truncateBytes(title, maxClipTitleBytes)
+}
+
+// LinkTitle はタスクに追加するリンクのタイトル（ページのタイトル）を返す
+func (c Clip) LinkTitle() string {
+	return truncateBytes(strings.Join(strings.Fields(c.Title), " "), MaxTaskLinkTitleLength)
+}
+
+// TaskDescription はタスクの説明（メモと、Markdownの引用にした選択範囲）を返す
+// 説明の上限を超える場合は選択範囲を切り詰める
+func (c Clip) TaskDescription() string {
+	var parts []string
+	if note := strings.TrimSpace(c.Note); note != "" {
+		parts = append(parts, note)
+	}
+	if selection := strings.TrimSpace(c.Selection); selection != "" {
+		lines := strings.Split(strings.ReplaceAll(selection, "\r\n", "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return truncateBytes(strings.Join(parts, "\n\n"), maxClipDescriptionBytes)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClip_Validate(t *testing.T) {
+	assert.NoError(t, Clip{URL: "https://example.com/article?id=1"}.Validate())
+	assert.NoError(t, Clip{URL: " http://example.com "}.Validate())
+
+	for _, invalid := range []Clip{
+		{URL: ""},
+		{URL: "example.com/article"},
+		{URL: "javascript:alert(1)"},
+		{URL: "chrome://extensions"},
+		{URL: "https://" + strings.Repeat("a", MaxTaskLinkURLLength)},
+		{URL: "https://example.com", Selection: strings.Repeat("a", MaxClipSelectionBytes+1)},
+		{URL: "https://example.com", Note: strings.Repeat("a", MaxClipNoteBytes+1)},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), ErrInvalidClip, invalid.URL)
+	}
+}
+
+func TestClip_TaskTitle(t *testing.T) {
+	assert.Equal(t, "記事 のタイトル", Clip{URL: "https://example.com", Title: "  記事\n のタイトル "}.TaskTitle())
+	// タイトルがない場合はURLのホストとパス
+	assert.Equal(t, "example.com/blog/post", Clip{URL: "https://example.com/blog/post/?utm_source=x"}.TaskTitle())
+	assert.Equal(t, "example.com", Clip{URL: "https://example.com/"}.TaskTitle())
+
+	long := Clip{URL: "https://example.com", Title: strings.Repeat("あ", 100)}
+	assert.LessOrEqual(t, len(long.TaskTitle()), 255)
+	assert.True(t, utf8.ValidString(long.TaskTitle()))
+}
+
+func TestClip_TaskDescription(t *testing.T) {
+	clip := Clip{
+		URL:       "https://example.com",
+		Selection: "1行目\r\n\r\n2行目",
+		Note:      " 後で読む ",
+	}
+	assert.Equal(t, "後で読む\n\n> 1行目\n>\n> 2行目", clip.TaskDescription())
+	assert.Equal(t, "", Clip{URL: "https://example.com"}.TaskDescription())
+
+	// 説明の上限を超える選択範囲は切り詰める
+	long := Clip{URL: "https://example.com", Selection: strings.Repeat("あ", 3000)}
+	assert.LessOrEqual(t, len(long.TaskDescription()), 2000)
+	assert.True(t, utf8.ValidString(long.TaskDescription()))
+}
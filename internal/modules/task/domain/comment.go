@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// コメント本文の最大文字数
+const MaxCommentLength = 5000
+
+// TaskComment はタスクへのコメントを表す
+type TaskComment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	UserID    string    `json:"user_id"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewTaskComment は新しいコメントを作成する
+func NewTaskComment(taskID, userID, comment string) (*TaskComment, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, errors.New("comment is required")
+	}
+	if len([]rune(comment)) > MaxCommentLength {
+		return nil, errors.New("comment too long (max 5000 characters)")
+	}
+
+	now := time.Now()
+	return &TaskComment{
+		TaskID:    taskID,
+		UserID:    userID,
+		Comment:   comment,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
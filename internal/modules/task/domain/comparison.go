@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ComparisonPeriod は期間比較の単位
+type ComparisonPeriod string
+
+const (
+	ComparisonPeriodDay   ComparisonPeriod = "day"
+	ComparisonPeriodWeek  ComparisonPeriod = "week" // 月曜日始まり
+	ComparisonPeriodMonth ComparisonPeriod = "month"
+)
+
+// 比較対象の期間を何期間前まで遡れるか
+const MaxComparisonOffset = 52
+
+// ErrInvalidComparisonPeriod は比較の単位が不正な場合のエラー
+var ErrInvalidComparisonPeriod = errors.New("invalid comparison period")
+
+// ParseComparisonPeriod は比較の単位を検証する（空文字の場合は week）
+func ParseComparisonPeriod(s string) (ComparisonPeriod, error) {
+	switch p := ComparisonPeriod(s); p {
+	case "":
+		return ComparisonPeriodWeek, nil
+	case ComparisonPeriodDay, ComparisonPeriodWeek, ComparisonPeriodMonth:
+		return p, nil
+	}
+	return "", ErrInvalidComparisonPeriod
+}
+
+// periodStart はtを含む期間の開始日時を返す
+func (p ComparisonPeriod) periodStart(t time.Time) time.Time {
+	switch p {
+	case ComparisonPeriodDay:
+		start, _ := GetDayStartEnd(t)
+		return start
+	case ComparisonPeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	start, _ := GetWeekStartEnd(t)
+	return start
+}
+
+// shift は期間の開始日時をn期間ずらす
+func (p ComparisonPeriod) shift(start time.Time, n int) time.Time {
+	switch p {
+	case ComparisonPeriodDay:
+		return start.AddDate(0, 0, n)
+	case ComparisonPeriodMonth:
+		return start.AddDate(0, n, 0)
+	}
+	return start.AddDate(0, 0, 7*n)
+}
+
+// ComparisonRanges は比較する2つの期間を返す
+// 現在の期間は開始から現在時刻まで、比較対象はoffset期間前の同じ経過時点まで（期間の長さを超えない）とし、途中の期間でも同じ条件で比べられるようにする
+func ComparisonRanges(period ComparisonPeriod, offset int, now time.Time) (currentFrom, currentTo, previousFrom, previousTo time.Time) {
+	currentFrom = period.periodStart(now)
+	currentTo = now
+	previousFrom = period.shift(currentFrom, -offset)
+	previousTo = previousFrom.Add(now.Sub(currentFrom))
+	if end := period.shift(previousFrom, 1).Add(-time.Nanosecond); previousTo.After(end) {
+		previousTo = end
+	}
+	return currentFrom, currentTo, previousFrom, previousTo
+}
+
+// PeriodMetrics は期間内の実績
+type PeriodMetrics struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Completed      int       `json:"completed"`       // 期間内に完了したタスク数
+	Created        int       `json:"created"`         // 期間内に作成されたタスク数
+	Due            int       `json:"due"`             // 期限が期間内（期間の終わりまで）のタスク数
+	Overdue        int       `json:"overdue"`         // 期限が期間内のタスクのうち期限までに完了しなかったタスク数
+	CompletionRate float64   `json:"completion_rate"` // 期限が期間内のタスクのうち期間の終わりまでに完了した割合（0-100）
+}
+
+// NewPeriodMetrics は期間[from, to]の実績を集計する
+// createdとcompletedは期間内に作成・完了したタスク、dueは期限が期間内のタスクの候補（期間外のものは除く）
+func NewPeriodMetrics(from, to time.Time, created, completed, due []*Task) PeriodMetrics {
+	metrics := PeriodMetrics{From: from, To: to}
+	within := func(t *time.Time) bool {
+		return t != nil && !t.Before(from) && !t.After(to)
+	}
+
+	seen := make(map[string]bool)
+	for _, task := range created {
+		if !seen[task.ID] && within(&task.CreatedAt) {
+			seen[task.ID] = true
+			metrics.Created++
+		}
+	}
+	seen = make(map[string]bool)
+	for _, task := range completed {
+		if !seen[task.ID] && within(task.CompletedAt) {
+			seen[task.ID] = true
+			metrics.Completed++
+		}
+	}
+
+	seen = make(map[string]bool)
+	doneByEnd := 0
+	for _, task := range due {
+		if seen[task.ID] || !within(task.DueDate) {
+			continue
+		}
+		seen[task.ID] = true
+		metrics.Due++
+		if task.CompletedAt == nil || task.CompletedAt.After(*task.DueDate) {
+			metrics.Overdue++
+		}
+		if task.CompletedAt != nil && !task.CompletedAt.After(to) {
+			doneByEnd++
+		}
+	}
+	if metrics.Due > 0 {
+		metrics.CompletionRate = math.Round(float64(doneByEnd)/float64(metrics.Due)*1000) / 10
+	}
+	return metrics
+}
+
+// MetricChange は比較対象からの変化
+type MetricChange struct {
+	Delta         float64  `json:"delta"`          // 差（完了率はポイント）
+	PercentChange *float64 `json:"percent_change"` // 変化率（%）、比較対象が0の場合はnull
+}
+
+func newMetricChange(current, previous float64) MetricChange {
+	change := MetricChange{Delta: math.Round((current-previous)*10) / 10}
+	if previous != 0 {
+		pct := math.Round((current-previous)/previous*1000) / 10
+		change.PercentChange = &pct
+	}
+	return change
+}
+
+// ComparisonChanges は指標ごとの変化
+type ComparisonChanges struct {
+	Completed      MetricChange `json:"completed"`
+	Created        MetricChange `json:"created"`
+	Overdue        MetricChange `json:"overdue"` // 減るほど良い
+	CompletionRate MetricChange `json:"completion_rate"`
+}
+
+// StatsComparison は現在の期間と比較対象の期間の実績の比較
+type StatsComparison struct {
+	Period   ComparisonPeriod  `json:"period"`
+	Offset   int               `json:"offset"`
+	Current  PeriodMetrics     `json:"current"`
+	Previous PeriodMetrics     `json:"previous"`
+	Changes  ComparisonChanges `json:"changes"`
+}
+
+// NewStatsComparison は2つの期間の実績を比較する
+func NewStatsComparison(period ComparisonPeriod, offset int, current, previous PeriodMetrics) *StatsComparison {
+	return &StatsComparison{
+		Period:   period,
+		Offset:   offset,
+		Current:  current,
+		Previous: previous,
+		Changes: ComparisonChanges{
+			Completed:      newMetricChange(float64(current.Completed), float64(previous.Completed)),
+			Created:        newMetricChange(float64(current.Created), float64(previous.Created)),
+			Overdue:        newMetricChange(float64(current.Overdue), float64(previous.Overdue)),
+			CompletionRate: newMetricChange(current.CompletionRate, previous.CompletionRate),
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComparisonPeriod(t *testing.T) {
+	period, err := ParseComparisonPeriod("")
+	require.NoError(t, err)
+	assert.Equal(t, ComparisonPeriodWeek, period)
+
+	period, err = ParseComparisonPeriod("month")
+	require.NoError(t, err)
+	assert.Equal(t, ComparisonPeriodMonth, period)
+
+	_, err = ParseComparisonPeriod("year")
+	assert.ErrorIs(t, err, ErrInvalidComparisonPeriod)
+}
+
+func TestComparisonRanges(t *testing.T) {
+	now := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC) // 水曜
+
+	t.Run("week compares the same elapsed time", func(t *testing.T) {
+		currentFrom, currentTo, previousFrom, previousTo := ComparisonRanges(ComparisonPeriodWeek, 1, now)
+
+		assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), currentFrom)
+		assert.Equal(t, now, currentTo)
+		assert.Equal(t, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), previousFrom)
+		assert.Equal(t, time.Date(2024, 6, 5, 15, 0, 0, 0, time.UTC), previousTo)
+	})
+
+	t.Run("day with offset", func(t *testing.T) {
+		_, _, previousFrom, previousTo := ComparisonRanges(ComparisonPeriodDay, 7, now)
+
+		assert.Equal(t, time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), previousFrom)
+		assert.Equal(t, time.Date(2024, 6, 5, 15, 0, 0, 0, time.UTC), previousTo)
+	})
+
+	t.Run("month is capped at the end of a shorter month", func(t *testing.T) {
+		_, _, previousFrom, previousTo := ComparisonRanges(ComparisonPeriodMonth, 1, time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), previousFrom)
+		assert.Equal(t, time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC), previousTo)
+	})
+}
+
+func TestNewPeriodMetrics(t *testing.T) {
+	from := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		v := time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	onTime := &Task{ID: "on-time", CreatedAt: *at(10, 9), DueDate: at(11, 18), CompletedAt: at(11, 10)}
+	late := &Task{ID: "late", CreatedAt: *at(1, 9), DueDate: at(10, 18), CompletedAt: at(12, 10)}
+	open := &Task{ID: "open", CreatedAt: *at(11, 9), DueDate: at(12, 12)}
+	future := &Task{ID: "future", CreatedAt: *at(12, 9), DueDate: at(14, 18)}
+
+	metrics := NewPeriodMetrics(from, to,
+		[]*Task{onTime, late, open, future},
+		[]*Task{onTime, late},
+		[]*Task{onTime, late, open, future, onTime})
+
+	assert.Equal(t, 3, metrics.Created)
+	assert.Equal(t, 2, metrics.Completed)
+	assert.Equal(t, 3, metrics.Due)
+	assert.Equal(t, 2, metrics.Overdue)
+	assert.Equal(t, 66.7, metrics.CompletionRate)
+}
+
+func TestNewStatsComparison(t *testing.T) {
+	current := PeriodMetrics{Completed: 12, Created: 5, Overdue: 1, CompletionRate: 80}
+	previous := PeriodMetrics{Completed: 10, Created: 0, Overdue: 2, CompletionRate: 60}
+
+	comparison := NewStatsComparison(ComparisonPeriodWeek, 1, current, previous)
+
+	assert.Equal(t, 2.0, comparison.Changes.Completed.Delta)
+	require.NotNil(t, comparison.Changes.Completed.PercentChange)
+	assert.Equal(t, 20.0, *comparison.Changes.Completed.PercentChange)
+	assert.Equal(t, 5.0, comparison.Changes.Created.Delta)
+	assert.Nil(t, comparison.Changes.Created.PercentChange)
+	assert.Equal(t, -50.0, *comparison.Changes.Overdue.PercentChange)
+	assert.Equal(t, 20.0, comparison.Changes.CompletionRate.Delta)
+	assert.Equal(t, 33.3, *comparison.Changes.CompletionRate.PercentChange)
+}
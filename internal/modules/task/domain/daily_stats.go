@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"time"
+)
+
+// DailyStatsDateLayout は集計テーブルの日付（UTC）の形式
+const DailyStatsDateLayout = "2006-01-02"
+
+// DailyStatsSnapshot は集計テーブルに保存するユーザーの日次統計（UTCの日付ごと）
+// タスクの変更時に該当する日の行を集計し直し、夜間の再集計でずれを直す
+type DailyStatsSnapshot struct {
+	UserID          string
+	Date            string // YYYY-MM-DD（UTC）
+	TotalTasks      int
+	CompletedTasks  int
+	InProgressTasks int
+	TodoTasks       int
+	OverdueTasks    int
+	// StaleAt は未完了タスクの期限のうち集計時点より後で最も早いもの
+	// この時刻を過ぎると期限切れ数が変わるため、行を使わずに集計し直す
+	StaleAt   *time.Time
+	UpdatedAt time.Time
+}
+
+// NewDailyStatsSnapshot は日次統計と集計時点から保存する行を作成する
+func NewDailyStatsSnapshot(userID string, stats *DailyStats, tasks []*Task, now time.Time) *DailyStatsSnapshot {
+	snapshot := &DailyStatsSnapshot{
+		UserID:          userID,
+		Date:            stats.Date.UTC().Format(DailyStatsDateLayout),
+		TotalTasks:      stats.TotalTasks,
+		CompletedTasks:  stats.CompletedTasks,
+		InProgressTasks: stats.InProgressTasks,
+		TodoTasks:       stats.TodoTasks,
+		OverdueTasks:    stats.OverdueTasks,
+		UpdatedAt:       now,
+	}
+	for _, task := range tasks {
+		if task.Status == TaskStatusDone || task.DueDate == nil || !task.DueDate.After(now) {
+			continue
+		}
+		if snapshot.StaleAt == nil || task.DueDate.Before(*snapshot.StaleAt) {
+			due := *task.DueDate
+			snapshot.StaleAt = &due
+		}
+	}
+	return snapshot
+}
+
+// IsFresh は行をそのまま使えるか（期限切れ数が変わっていないか）を返す
+func (s *DailyStatsSnapshot) IsFresh(now time.Time) bool {
+	return s.StaleAt == nil || now.Before(*s.StaleAt)
+}
+
+// ToDailyStats は行を日次統計に変換する
+func (s *DailyStatsSnapshot) ToDailyStats() *DailyStats {
+	date, _ := time.Parse(DailyStatsDateLayout, s.Date)
+	return &DailyStats{
+		Date:            date,
+		TotalTasks:      s.TotalTasks,
+		CompletedTasks:  s.CompletedTasks,
+		InProgressTasks: s.InProgressTasks,
+		TodoTasks:       s.TodoTasks,
+		OverdueTasks:    s.OverdueTasks,
+		CompletionRate:  CalculateCompletionRate(s.CompletedTasks, s.TotalTasks),
+	}
+}
+
+// DailyStatsKeys はタスクの変更で集計し直す必要がある日（UTC）を返す（作成日と変更前後の期限日）
+func DailyStatsKeys(task *Task, before *TaskState) []string {
+	seen := make(map[string]bool)
+	var dates []string
+	add := func(t *time.Time) {
+		if t == nil {
+			return
+		}
+		date := t.UTC().Format(DailyStatsDateLayout)
+		if !seen[date] {
+			seen[date] = true
+			dates = append(dates, date)
+		}
+	}
+	add(&task.CreatedAt)
+	add(task.DueDate)
+	if before != nil {
+		add(before.DueDate)
+	}
+	return dates
+}
+
+// DailyStatsUsers はタスクの変更で集計し直す必要があるユーザーを返す（作成者と変更前後の担当者）
+func DailyStatsUsers(task *Task, before *TaskState) []string {
+	seen := make(map[string]bool)
+	var users []string
+	add := func(userID string) {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			users = append(users, userID)
+		}
+	}
+	add(task.CreatedBy)
+	for _, id := range task.AssigneeIDs() {
+		add(id)
+	}
+	if before != nil {
+		for _, assignee := range before.Assignees {
+			add(assignee.UserID)
+		}
+	}
+	return users
+}
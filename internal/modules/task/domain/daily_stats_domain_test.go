@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDailyStatsSnapshot(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	soon := now.Add(2 * time.Hour)
+	later := now.Add(5 * time.Hour)
+	tasks := []*Task{
+		{ID: "overdue", Status: TaskStatusTodo, DueDate: &past},
+		{ID: "later", Status: TaskStatusTodo, DueDate: &later},
+		{ID: "soon", Status: TaskStatusInProgress, DueDate: &soon},
+		{ID: "done", Status: TaskStatusDone, DueDate: &now},
+	}
+	stats := NewDailyStats(time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), tasks)
+
+	snapshot := NewDailyStatsSnapshot("user-1", stats, tasks, now)
+
+	assert.Equal(t, "2024-06-10", snapshot.Date)
+	assert.Equal(t, 4, snapshot.TotalTasks)
+	assert.Equal(t, 1, snapshot.CompletedTasks)
+	require.NotNil(t, snapshot.StaleAt)
+	assert.Equal(t, soon, *snapshot.StaleAt)
+	assert.True(t, snapshot.IsFresh(now.Add(time.Hour)))
+	assert.False(t, snapshot.IsFresh(soon))
+
+	restored := snapshot.ToDailyStats()
+	assert.Equal(t, stats.Date, restored.Date)
+	assert.Equal(t, stats.TotalTasks, restored.TotalTasks)
+	assert.Equal(t, stats.CompletionRate, restored.CompletionRate)
+}
+
+func TestDailyStatsKeys(t *testing.T) {
+	created := time.Date(2024, 6, 10, 23, 30, 0, 0, time.FixedZone("JST", 9*60*60)) // 2024-06-10 14:30 UTC
+	oldDue := time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC)
+	newDue := time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
+	task := &Task{CreatedBy: "user-1", CreatedAt: created, DueDate: &newDue}
+	task.Assignees = []*TaskAssignee{{UserID: "user-2"}, {UserID: "user-1"}}
+	before := &TaskState{DueDate: &oldDue, Assignees: []*TaskAssignee{{UserID: "user-3"}}}
+
+	assert.Equal(t, []string{"2024-06-10"}, DailyStatsKeys(task, nil))
+	assert.Equal(t, []string{"2024-06-10", "2024-06-12"}, DailyStatsKeys(task, before))
+	assert.Equal(t, []string{"user-1", "user-2"}, DailyStatsUsers(task, nil))
+	assert.Equal(t, []string{"user-1", "user-2", "user-3"}, DailyStatsUsers(task, before))
+}
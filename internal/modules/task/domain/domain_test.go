@@ -126,6 +126,34 @@ func TestTask_SetDueDate(t *testing.T) {
 	assert.True(t, task.UpdatedAt.After(originalUpdatedAt))
 }
 
+func TestTask_HasValidSchedule(t *testing.T) {
+	now := time.Now()
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		startDate *time.Time
+		dueDate   *time.Time
+		expected  bool
+	}{
+		{name: "no dates", expected: true},
+		{name: "start only", startDate: &now, expected: true},
+		{name: "start before due", startDate: &before, dueDate: &now, expected: true},
+		{name: "start equals due", startDate: &now, dueDate: &now, expected: true},
+		{name: "start after due", startDate: &after, dueDate: &now, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := NewTask("Test", "", PriorityMedium, CategoryWork, "creator")
+			task.SetStartDate(tt.startDate)
+			task.DueDate = tt.dueDate
+
+			assert.Equal(t, tt.expected, task.HasValidSchedule())
+		})
+	}
+}
+
 func TestTask_CheckIsOverdue(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// 重複タスクの検出で使用する定数
+const (
+	DuplicateSimilarityThreshold = 0.5                 // これ以上の類似度のタスクを重複の候補とする
+	DefaultDuplicateWindow       = 14 * 24 * time.Hour // 重複の候補とする作成日時の期間
+	MaxDuplicateWindowDays       = 90
+	MaxDuplicateCandidates       = 5
+)
+
+// DuplicateCandidate は作成しようとしているタスクと重複している可能性のあるタスク
+type DuplicateCandidate struct {
+	TaskID     string     `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title      string     `json:"title" example:"週次レポートを提出する"`
+	Status     TaskStatus `json:"status" example:"TODO"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Similarity float64    `json:"similarity" example:"0.82"` // タイトルの類似度（0〜1）
+} // @name DuplicateCandidate
+
+// FindDuplicateCandidates はタイトルが似ているタスクを類似度の高い順に最大件数まで返す
+// 完了済みのタスクと作成日時がsinceより前のタスクは対象外
+func FindDuplicateCandidates(title string, tasks []*Task, since time.Time) []*DuplicateCandidate {
+	grams := titleTrigrams(title)
+	if len(grams) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tasks))
+	var candidates []*DuplicateCandidate
+	for _, task := range tasks {
+		if task == nil || seen[task.ID] || task.Status == TaskStatusDone || task.CreatedAt.Before(since) {
+			continue
+		}
+		seen[task.ID] = true
+
+		similarity := trigramSimilarity(grams, titleTrigrams(task.Title))
+		if similarity < DuplicateSimilarityThreshold {
+			continue
+		}
+		candidates = append(candidates, &DuplicateCandidate{
+			TaskID:     task.ID,
+			Title:      task.Title,
+			Status:     task.Status,
+			CreatedBy:  task.CreatedBy,
+			CreatedAt:  task.CreatedAt,
+			Similarity: float64(int(similarity*100+0.5)) / 100,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Similarity != candidates[j].Similarity {
+			return candidates[i].Similarity > candidates[j].Similarity
+		}
+		return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+	})
+	if len(candidates) > MaxDuplicateCandidates {
+		candidates = candidates[:MaxDuplicateCandidates]
+	}
+	return candidates
+}
+
+// TitleSimilarity は2つのタイトルのトライグラムの類似度（Jaccard係数、0〜1）を返す
+// 大文字・小文字、空白、記号の違いは無視する
+func TitleSimilarity(a, b string) float64 {
+	return trigramSimilarity(titleTrigrams(a), titleTrigrams(b))
+}
+
+// titleTrigrams はタイトルを文字・数字の語に分け、語ごとのn文字の組の集合を返す
+// 短い語も比較できるよう、語の前後に空白を補う（PostgreSQLのpg_trgmと同じ方式）
+// 日本語のように空白で区切らないタイトルは全体が1語になり、1文字の情報量も多いため、漢字・かなを含む語は2文字の組にする
+func titleTrigrams(title string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	grams := make(map[string]bool)
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		n := 3
+		if containsCJK(word) {
+			runes, n = runes[1:], 2
+		}
+		for i := 0; i+n <= len(runes); i++ {
+			grams[string(runes[i:i+n])] = true
+		}
+	}
+	return grams
+}
+
+func containsCJK(word string) bool {
+	for _, r := range word {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return true
+		}
+	}
+	return false
+}
+
+func trigramSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for gram := range a {
+		if b[gram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
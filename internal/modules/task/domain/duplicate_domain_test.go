@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      string
+		duplicate bool
+	}{
+		{"identical titles", "Buy milk", "Buy milk", true},
+		{"case and punctuation are ignored", "Buy milk", "buy milk!", true},
+		{"extra word", "Write the report", "write report", true},
+		{"different object", "Buy milk", "Buy bread", false},
+		{"japanese with particle dropped", "週次レポートを提出する", "週次レポート提出", true},
+		{"japanese different task", "会議の準備", "会議の議事録", false},
+		{"no shared characters", "掃除", "洗濯", false},
+		{"empty title", "", "Buy milk", false},
+		{"symbols only", "!!!", "!!!", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similarity := TitleSimilarity(tt.a, tt.b)
+			assert.Equal(t, tt.duplicate, similarity >= DuplicateSimilarityThreshold, "similarity=%v", similarity)
+			assert.Equal(t, similarity, TitleSimilarity(tt.b, tt.a))
+		})
+	}
+}
+
+func TestFindDuplicateCandidates(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-DefaultDuplicateWindow)
+	newTask := func(id, title string, status TaskStatus, createdAt time.Time) *Task {
+		return &Task{ID: id, Title: title, Status: status, CreatedBy: "user-1", CreatedAt: createdAt}
+	}
+
+	t.Run("returns similar open tasks ordered by similarity", func(t *testing.T) {
+		tasks := []*Task{
+			newTask("t1", "Write the weekly report", TaskStatusTodo, now.Add(-time.Hour)),
+			newTask("t2", "Write weekly report", TaskStatusInProgress, now.Add(-2*time.Hour)),
+			newTask("t3", "Buy milk", TaskStatusTodo, now.Add(-time.Hour)),
+		}
+
+		candidates := FindDuplicateCandidates("write weekly report", tasks, since)
+
+		require.Len(t, candidates, 2)
+		assert.Equal(t, "t2", candidates[0].TaskID)
+		assert.Equal(t, 1.0, candidates[0].Similarity)
+		assert.Equal(t, "t1", candidates[1].TaskID)
+		assert.Equal(t, TaskStatusTodo, candidates[1].Status)
+	})
+
+	t.Run("completed and old tasks are ignored", func(t *testing.T) {
+		tasks := []*Task{
+			newTask("done", "Buy milk", TaskStatusDone, now.Add(-time.Hour)),
+			newTask("old", "Buy milk", TaskStatusTodo, since.Add(-time.Hour)),
+		}
+
+		assert.Empty(t, FindDuplicateCandidates("Buy milk", tasks, since))
+	})
+
+	t.Run("tasks listed twice are returned once", func(t *testing.T) {
+		task := newTask("t1", "Buy milk", TaskStatusTodo, now)
+
+		assert.Len(t, FindDuplicateCandidates("Buy milk", []*Task{task, task}, since), 1)
+	})
+
+	t.Run("at most MaxDuplicateCandidates are returned", func(t *testing.T) {
+		var tasks []*Task
+		for i := 0; i < MaxDuplicateCandidates+3; i++ {
+			tasks = append(tasks, newTask(string(rune('a'+i)), "Buy milk", TaskStatusTodo, now.Add(-time.Duration(i)*time.Minute)))
+		}
+
+		candidates := FindDuplicateCandidates("Buy milk", tasks, since)
+
+		require.Len(t, candidates, MaxDuplicateCandidates)
+		assert.Equal(t, "a", candidates[0].TaskID, "newer tasks come first when equally similar")
+	})
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+)
+
+// EscalationScope はエスカレーションルールの適用範囲を表す型
+type EscalationScope string
+
+// エスカレーション適用範囲の定数
+const (
+	EscalationScopeUser  EscalationScope = "USER"  // ユーザー単位（作成者・担当者のタスク）
+	EscalationScopeGroup EscalationScope = "GROUP" // グループ単位（グループタスク）
+)
+
+// EscalationRule は期限切れタスクのエスカレーションルールを表す
+type EscalationRule struct {
+	ID                string          `json:"id"`
+	Scope             EscalationScope `json:"scope"`
+	OwnerID           string          `json:"owner_id"` // USERならユーザーID、GROUPならグループID
+	Name              string          `json:"name"`
+	OverdueHours      int             `json:"overdue_hours"` // 期限切れから何時間後に発動するか
+	RaisePriority     bool            `json:"raise_priority"`
+	NotifyAssignee    bool            `json:"notify_assignee"`
+	NotifyGroupAdmins bool            `json:"notify_group_admins"`
+	ReassignTo        *string         `json:"reassign_to,omitempty"`
+	Enabled           bool            `json:"enabled"`
+	CreatedBy         string          `json:"created_by"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// NewEscalationRule は新しいエスカレーションルールを作成する
+func NewEscalationRule(scope EscalationScope, ownerID, name string, overdueHours int, createdBy string) *EscalationRule {
+	now := time.Now()
+	return &EscalationRule{
+		Scope:          scope,
+		OwnerID:        ownerID,
+		Name:           name,
+		OverdueHours:   overdueHours,
+		RaisePriority:  true,
+		NotifyAssignee: true,
+		Enabled:        true,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// ShouldEscalate はタスクが期限からOverdueHours以上経過しているかを判定する
+func (r *EscalationRule) ShouldEscalate(task *Task, now time.Time) bool {
+	if !r.Enabled || task.DueDate == nil || task.Status == TaskStatusDone {
+		return false
+	}
+	return !now.Before(task.DueDate.Add(time.Duration(r.OverdueHours) * time.Hour))
+}
+
+// Raise は優先度を1段階引き上げた値を返す（HIGHはそのまま）
+func (p Priority) Raise() Priority {
+	switch p {
+	case PriorityLow:
+		return PriorityMedium
+	case PriorityMedium:
+		return PriorityHigh
+	default:
+		return PriorityHigh
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscalationRule_ShouldEscalate(t *testing.T) {
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupRule func(rule *EscalationRule)
+		setupTask func(task *Task)
+		want      bool
+	}{
+		{
+			name:      "overdue beyond threshold",
+			setupTask: func(task *Task) { task.SetDueDate(now.Add(-25 * time.Hour)) },
+			want:      true,
+		},
+		{
+			name:      "exactly at threshold",
+			setupTask: func(task *Task) { task.SetDueDate(now.Add(-24 * time.Hour)) },
+			want:      true,
+		},
+		{
+			name:      "overdue but within threshold",
+			setupTask: func(task *Task) { task.SetDueDate(now.Add(-2 * time.Hour)) },
+			want:      false,
+		},
+		{
+			name:      "no due date",
+			setupTask: func(task *Task) {},
+			want:      false,
+		},
+		{
+			name: "done task",
+			setupTask: func(task *Task) {
+				task.SetDueDate(now.Add(-48 * time.Hour))
+				task.SetStatus(TaskStatusDone)
+			},
+			want: false,
+		},
+		{
+			name:      "disabled rule",
+			setupRule: func(rule *EscalationRule) { rule.Enabled = false },
+			setupTask: func(task *Task) { task.SetDueDate(now.Add(-48 * time.Hour)) },
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewEscalationRule(EscalationScopeUser, "user-1", "test", 24, "user-1")
+			if tt.setupRule != nil {
+				tt.setupRule(rule)
+			}
+			task := NewTask("Test", "Description", PriorityMedium, CategoryWork, "user-1")
+			tt.setupTask(task)
+
+			assert.Equal(t, tt.want, rule.ShouldEscalate(task, now))
+		})
+	}
+}
+
+func TestPriority_Raise(t *testing.T) {
+	assert.Equal(t, PriorityMedium, PriorityLow.Raise())
+	assert.Equal(t, PriorityHigh, PriorityMedium.Raise())
+	assert.Equal(t, PriorityHigh, PriorityHigh.Raise())
+}
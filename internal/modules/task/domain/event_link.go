@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// TaskEventLink はタスクとグループの予定の関連付け（タスクの詳細に表示する）
+// Kind が BLOCK の場合は予定がタスクの作業時間、FOLLOW_UP の場合はタスクが予定のフォローアップ
+type TaskEventLink struct {
+	GroupID   string    `json:"group_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	EventID   string    `json:"event_id" example:"550e8400-e29b-41d4-a716-446655440002"` // 予定のタスクID（繰り返しの予定は回のID）
+	TaskID    string    `json:"task_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Kind      string    `json:"kind" example:"BLOCK" enums:"BLOCK,FOLLOW_UP"`
+	CreatedAt time.Time `json:"created_at"`
+} // @name TaskEventLink
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// TaskStatsRecord は統計のエクスポートの1行（タスク1件分の集計用の項目）
+// 表計算ソフトなどで集計し直せるよう、期限切れ・遅延・リードタイムを算出済みで出力する
+type TaskStatsRecord struct {
+	TaskID          string     `json:"task_id"`
+	Status          TaskStatus `json:"status"`
+	Priority        Priority   `json:"priority"`
+	Category        Category   `json:"category"`
+	CreatedBy       string     `json:"created_by"`
+	Assignees       []string   `json:"assignees"`
+	CreatedAt       time.Time  `json:"created_at"`
+	DueDate         *time.Time `json:"due_date,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	EstimateMinutes *int       `json:"estimate_minutes,omitempty"`
+	EstimatePoints  *int       `json:"estimate_points,omitempty"`
+	ActualMinutes   *int       `json:"actual_minutes,omitempty"`
+	IsOverdue       bool       `json:"is_overdue"`                // nowの時点で期限を過ぎて未完了
+	CompletedLate   bool       `json:"completed_late"`            // 期限を過ぎて完了
+	LeadTimeHours   *float64   `json:"lead_time_hours,omitempty"` // 作成から完了までの時間（完了したタスクのみ）
+}
+
+// NewTaskStatsRecord はタスクから統計のエクスポートの1行を作成する
+func NewTaskStatsRecord(task *Task, now time.Time) *TaskStatsRecord {
+	record := &TaskStatsRecord{
+		TaskID:          task.ID,
+		Status:          task.Status,
+		Priority:        task.Priority,
+		Category:        task.Category,
+		CreatedBy:       task.CreatedBy,
+		Assignees:       task.AssigneeIDs(),
+		CreatedAt:       task.CreatedAt,
+		DueDate:         task.DueDate,
+		CompletedAt:     task.CompletedAt,
+		EstimateMinutes: task.EstimateMinutes,
+		EstimatePoints:  task.EstimatePoints,
+		ActualMinutes:   task.ActualMinutes,
+	}
+	if record.Assignees == nil {
+		record.Assignees = []string{}
+	}
+	if task.DueDate != nil {
+		record.IsOverdue = task.Status != TaskStatusDone && now.After(*task.DueDate)
+		record.CompletedLate = task.CompletedAt != nil && task.CompletedAt.After(*task.DueDate)
+	}
+	if task.CompletedAt != nil {
+		hours := math.Round(task.CompletedAt.Sub(task.CreatedAt).Hours()*10) / 10
+		record.LeadTimeHours = &hours
+	}
+	return record
+}
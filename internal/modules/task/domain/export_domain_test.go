@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskStatsRecord(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-48 * time.Hour)
+	due := now.Add(-24 * time.Hour)
+
+	t.Run("open task past its due date is overdue", func(t *testing.T) {
+		task := &Task{ID: "task-1", Status: TaskStatusInProgress, CreatedAt: created, DueDate: &due}
+
+		record := NewTaskStatsRecord(task, now)
+
+		assert.True(t, record.IsOverdue)
+		assert.False(t, record.CompletedLate)
+		assert.Nil(t, record.LeadTimeHours)
+		assert.Equal(t, []string{}, record.Assignees)
+	})
+
+	t.Run("task completed after its due date is late", func(t *testing.T) {
+		completed := due.Add(90 * time.Minute)
+		task := &Task{ID: "task-1", Status: TaskStatusDone, CreatedAt: created, DueDate: &due, CompletedAt: &completed}
+		task.Assignees = []*TaskAssignee{{UserID: "user-2"}}
+
+		record := NewTaskStatsRecord(task, now)
+
+		assert.False(t, record.IsOverdue)
+		assert.True(t, record.CompletedLate)
+		require.NotNil(t, record.LeadTimeHours)
+		assert.Equal(t, 25.5, *record.LeadTimeHours)
+		assert.Equal(t, []string{"user-2"}, record.Assignees)
+	})
+}
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FlowScope はバーンダウン・累積フロー図の対象
+type FlowScope string
+
+const (
+	FlowScopeUser  FlowScope = "user"  // 自分が作成・担当するタスク
+	FlowScopeGroup FlowScope = "group" // グループタスク
+)
+
+// バーンダウン・累積フロー図の期間（日数）
+const (
+	DefaultFlowRangeDays = 30
+	MaxFlowRangeDays     = MaxBurndownDays
+)
+
+// ErrInvalidFlowRange は期間の指定が不正な場合のエラー
+var ErrInvalidFlowRange = errors.New("invalid range")
+
+var reFlowRange = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// ParseFlowRange は"30d"・"12w"形式の期間を日数に変換する（空文字の場合は既定の日数）
+func ParseFlowRange(s string) (int, error) {
+	if s == "" {
+		return DefaultFlowRangeDays, nil
+	}
+	m := reFlowRange.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("%w: %q (expected e.g. 30d or 12w)", ErrInvalidFlowRange, s)
+	}
+	days, _ := strconv.Atoi(m[1])
+	if m[2] == "w" {
+		days *= 7
+	}
+	if days < 1 || days > MaxFlowRangeDays {
+		return 0, fmt.Errorf("%w: must be between 1 and %d days", ErrInvalidFlowRange, MaxFlowRangeDays)
+	}
+	return days, nil
+}
+
+// StatusTransition はタスクのステータスの変化（作成を含む）
+type StatusTransition struct {
+	TaskID string
+	Status TaskStatus
+	At     time.Time
+}
+
+// NewStatusTransitions は変更イベントからステータスの変化を取り出す
+// ステータスを含まないイベントは無視する
+func NewStatusTransitions(events []*TaskEvent) ([]*StatusTransition, error) {
+	transitions := make([]*StatusTransition, 0, len(events))
+	for _, event := range events {
+		raw, ok := event.Changes["status"]
+		if !ok {
+			continue
+		}
+		var status TaskStatus
+		if err := json.Unmarshal(raw, &status); err != nil {
+			return nil, fmt.Errorf("invalid status in task event %s: %w", event.ID, err)
+		}
+		transitions = append(transitions, &StatusTransition{TaskID: event.TaskID, Status: status, At: event.OccurredAt})
+	}
+	return transitions, nil
+}
+
+// FlowBurndownPoint はバーンダウンの1日分の値
+// Remaining はその日の終わり時点の未完了タスク数、Added・Completed はその日に追加・完了したタスク数
+type FlowBurndownPoint struct {
+	Date      time.Time `json:"date"`
+	Remaining int       `json:"remaining"`
+	Added     int       `json:"added"`
+	Completed int       `json:"completed"`
+	Ideal     float64   `json:"ideal"`
+}
+
+// FlowBurndown は期間内の日ごとの未完了タスク数
+type FlowBurndown struct {
+	Scope    FlowScope           `json:"scope"`
+	ScopeID  string              `json:"scope_id"` // ユーザーIDまたはグループID
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Timezone string              `json:"timezone"`
+	Points   []FlowBurndownPoint `json:"points"`
+}
+
+// CumulativeFlowPoint は累積フロー図の1日分の値（その日の終わり時点のステータスごとのタスク数）
+type CumulativeFlowPoint struct {
+	Date       time.Time `json:"date"`
+	Todo       int       `json:"todo"`
+	InProgress int       `json:"in_progress"`
+	Done       int       `json:"done"`
+}
+
+// CumulativeFlow は期間内の日ごとのステータス別タスク数
+type CumulativeFlow struct {
+	Scope    FlowScope             `json:"scope"`
+	ScopeID  string                `json:"scope_id"`
+	From     time.Time             `json:"from"`
+	To       time.Time             `json:"to"`
+	Timezone string                `json:"timezone"`
+	Points   []CumulativeFlowPoint `json:"points"`
+}
+
+// dailyFlow は1日の終わり時点のステータス別タスク数と、その日の追加・完了数
+type dailyFlow struct {
+	date      time.Time
+	counts    map[TaskStatus]int
+	added     int
+	completed int
+}
+
+// replayDailyFlow はステータスの変化を時系列に適用し、今日（now）までdays日分の日ごとの集計を返す
+// 変化が記録される前のタスクの状態は分からないため、最初の変化の時点からタスクがあるものとする
+func replayDailyFlow(transitions []*StatusTransition, days int, now time.Time) []dailyFlow {
+	sorted := append([]*StatusTransition(nil), transitions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	today := startOfDay(now)
+	from := today.AddDate(0, 0, -(days - 1))
+	current := make(map[string]TaskStatus)
+	counts := make(map[TaskStatus]int)
+	apply := func(t *StatusTransition) (added, completed bool) {
+		previous, existed := current[t.TaskID]
+		if existed {
+			counts[previous]--
+		}
+		current[t.TaskID] = t.Status
+		counts[t.Status]++
+		return !existed, t.Status == TaskStatusDone && previous != TaskStatusDone
+	}
+
+	// 期間より前の変化は期間の開始時点の状態として適用する
+	i := 0
+	for ; i < len(sorted) && sorted[i].At.Before(from); i++ {
+		apply(sorted[i])
+	}
+
+	result := make([]dailyFlow, 0, days)
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		flow := dailyFlow{date: day}
+		for ; i < len(sorted) && sorted[i].At.Before(next); i++ {
+			added, completed := apply(sorted[i])
+			if added {
+				flow.added++
+			}
+			if completed {
+				flow.completed++
+			}
+		}
+		flow.counts = make(map[TaskStatus]int, len(counts))
+		for status, count := range counts {
+			flow.counts[status] = count
+		}
+		result = append(result, flow)
+	}
+	return result
+}
+
+// NewFlowBurndown はステータスの変化から日ごとの未完了タスク数を計算する
+// 理想線は期間の開始日の未完了タスク数から最終日（今日）に0になる直線とする
+func NewFlowBurndown(scope FlowScope, scopeID string, transitions []*StatusTransition, days int, now time.Time) *FlowBurndown {
+	flows := replayDailyFlow(transitions, days, now)
+	burndown := &FlowBurndown{
+		Scope:    scope,
+		ScopeID:  scopeID,
+		From:     flows[0].date,
+		To:       flows[len(flows)-1].date,
+		Timezone: now.Location().String(),
+		Points:   make([]FlowBurndownPoint, 0, len(flows)),
+	}
+
+	for i, flow := range flows {
+		point := FlowBurndownPoint{
+			Date:      flow.date,
+			Remaining: flow.counts[TaskStatusTodo] + flow.counts[TaskStatusInProgress],
+			Added:     flow.added,
+			Completed: flow.completed,
+		}
+		if i == 0 {
+			point.Ideal = float64(point.Remaining)
+		} else {
+			start := float64(burndown.Points[0].Remaining)
+			point.Ideal = start - start*float64(i)/float64(len(flows)-1)
+		}
+		burndown.Points = append(burndown.Points, point)
+	}
+	return burndown
+}
+
+// NewCumulativeFlow はステータスの変化から日ごとのステータス別タスク数を計算する
+func NewCumulativeFlow(scope FlowScope, scopeID string, transitions []*StatusTransition, days int, now time.Time) *CumulativeFlow {
+	flows := replayDailyFlow(transitions, days, now)
+	cfd := &CumulativeFlow{
+		Scope:    scope,
+		ScopeID:  scopeID,
+		From:     flows[0].date,
+		To:       flows[len(flows)-1].date,
+		Timezone: now.Location().String(),
+		Points:   make([]CumulativeFlowPoint, 0, len(flows)),
+	}
+
+	for _, flow := range flows {
+		cfd.Points = append(cfd.Points, CumulativeFlowPoint{
+			Date:       flow.date,
+			Todo:       flow.counts[TaskStatusTodo],
+			InProgress: flow.counts[TaskStatusInProgress],
+			Done:       flow.counts[TaskStatusDone],
+		})
+	}
+	return cfd
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlowRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "", want: DefaultFlowRangeDays},
+		{value: "14d", want: 14},
+		{value: "4w", want: 28},
+		{value: "0d", wantErr: true},
+		{value: "400d", wantErr: true},
+		{value: "3m", wantErr: true},
+		{value: "d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFlowRange(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFlowRange)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewStatusTransitions(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	events := []*TaskEvent{
+		{TaskID: "task-1", Type: TaskEventCreated, Changes: map[string]json.RawMessage{"status": json.RawMessage(`"TODO"`), "title": json.RawMessage(`"a"`)}, OccurredAt: at},
+		{TaskID: "task-1", Type: TaskEventFieldChanged, Changes: map[string]json.RawMessage{"title": json.RawMessage(`"b"`)}, OccurredAt: at.Add(time.Hour)},
+		{TaskID: "task-1", Type: TaskEventStatusChanged, Changes: map[string]json.RawMessage{"status": json.RawMessage(`"DONE"`)}, OccurredAt: at.Add(2 * time.Hour)},
+	}
+
+	transitions, err := NewStatusTransitions(events)
+
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, TaskStatusTodo, transitions[0].Status)
+	assert.Equal(t, TaskStatusDone, transitions[1].Status)
+	assert.Equal(t, at.Add(2*time.Hour), transitions[1].At)
+}
+
+func TestNewFlowBurndownAndCumulativeFlow(t *testing.T) {
+	now := time.Date(2024, 6, 5, 15, 0, 0, 0, time.UTC)
+	day := func(d, hour int) time.Time { return time.Date(2024, 6, d, hour, 0, 0, 0, time.UTC) }
+	transitions := []*StatusTransition{
+		{TaskID: "old", Status: TaskStatusTodo, At: day(1, 9)}, // 期間より前
+		{TaskID: "a", Status: TaskStatusTodo, At: day(3, 9)},
+		{TaskID: "b", Status: TaskStatusTodo, At: day(3, 10)},
+		{TaskID: "a", Status: TaskStatusInProgress, At: day(4, 9)},
+		{TaskID: "a", Status: TaskStatusDone, At: day(4, 18)},
+		{TaskID: "old", Status: TaskStatusDone, At: day(5, 9)},
+		{TaskID: "a", Status: TaskStatusInProgress, At: day(5, 10)}, // 再開
+		{TaskID: "future", Status: TaskStatusTodo, At: day(5, 20)},  // 現在より後でも当日分に含める
+	}
+
+	burndown := NewFlowBurndown(FlowScopeUser, "user-1", transitions, 3, now)
+
+	require.Len(t, burndown.Points, 3)
+	assert.Equal(t, day(3, 0), burndown.From)
+	assert.Equal(t, day(5, 0), burndown.To)
+	assert.Equal(t, []int{3, 2, 3}, []int{burndown.Points[0].Remaining, burndown.Points[1].Remaining, burndown.Points[2].Remaining})
+	assert.Equal(t, 2, burndown.Points[0].Added)
+	assert.Equal(t, 1, burndown.Points[1].Completed)
+	assert.Equal(t, 1, burndown.Points[2].Completed)
+	assert.Equal(t, 1, burndown.Points[2].Added)
+	assert.Equal(t, 3.0, burndown.Points[0].Ideal)
+	assert.Equal(t, 1.5, burndown.Points[1].Ideal)
+	assert.Equal(t, 0.0, burndown.Points[2].Ideal)
+
+	cfd := NewCumulativeFlow(FlowScopeUser, "user-1", transitions, 3, now)
+
+	require.Len(t, cfd.Points, 3)
+	assert.Equal(t, CumulativeFlowPoint{Date: day(3, 0), Todo: 3}, cfd.Points[0])
+	assert.Equal(t, CumulativeFlowPoint{Date: day(4, 0), Todo: 2, Done: 1}, cfd.Points[1])
+	assert.Equal(t, CumulativeFlowPoint{Date: day(5, 0), Todo: 2, InProgress: 1, Done: 1}, cfd.Points[2])
+}
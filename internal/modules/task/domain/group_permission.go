@@ -0,0 +1,16 @@
+package domain
+
+// GroupAction はタスクモジュールで確認するグループでのアクション（グループモジュールのアクションと同じ値）
+// 実行できるかどうかはグループの権限設定（ロールごとの権限・グループごとの変更・カスタムロール）に従う
+type GroupAction string
+
+const (
+	// GroupActionEditGroup はグループの編集（マイルストーンの管理など）
+	GroupActionEditGroup GroupAction = "EDIT_GROUP"
+	// GroupActionEditTasks はグループタスクの編集（添付ファイル・リンク・場所などの変更を含む）
+	GroupActionEditTasks GroupAction = "EDIT_TASKS"
+	// GroupActionDeleteTasks はグループタスクの削除
+	GroupActionDeleteTasks GroupAction = "DELETE_TASKS"
+	// GroupActionViewTasks はグループタスクの閲覧
+	GroupActionViewTasks GroupAction = "VIEW_TASKS"
+)
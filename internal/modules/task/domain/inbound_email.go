@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// InboundEmailStatus は受信したメールの処理結果
+type InboundEmailStatus string
+
+const (
+	// InboundEmailCreated はタスクを作成した
+	InboundEmailCreated InboundEmailStatus = "CREATED"
+	// InboundEmailDuplicate は同じMessage-IDのメールからタスクを作成済み
+	InboundEmailDuplicate InboundEmailStatus = "DUPLICATE"
+	// InboundEmailUnknownRecipient は宛先が有効なメールアドレスではない
+	InboundEmailUnknownRecipient InboundEmailStatus = "UNKNOWN_RECIPIENT"
+	// InboundEmailSenderNotAllowed は差出人が許可リストにない、またはSPF・DKIMで確認できない
+	InboundEmailSenderNotAllowed InboundEmailStatus = "SENDER_NOT_ALLOWED"
+	// InboundEmailSpam は受信したサービスがスパムと判定した
+	InboundEmailSpam InboundEmailStatus = "SPAM"
+	// InboundEmailRateLimited は1時間に作成できるタスクの上限に達した
+	InboundEmailRateLimited InboundEmailStatus = "RATE_LIMITED"
+)
+
+const (
+	// MaxInboundAllowedSenders は許可する差出人の上限
+	MaxInboundAllowedSenders = 20
+	// MaxInboundAttachments は1通のメールから保存する添付ファイルの上限
+	MaxInboundAttachments = 10
+	// MaxInboundTitleBytes・MaxInboundDescriptionBytes はタスクのタイトル・説明の上限（長い場合は切り詰める）
+	MaxInboundTitleBytes       = 255
+	MaxInboundDescriptionBytes = 2000
+	// InboundBodyFilename は説明に収まらない本文を保存する添付ファイルの名前
+	InboundBodyFilename = "message.txt"
+	// inboundUntitled は件名のないメールのタスクのタイトル
+	inboundUntitled = "（件名なし）"
+)
+
+// ErrInvalidInboundSender は許可する差出人の形式が正しくないことを表すエラー
+var ErrInvalidInboundSender = errors.New("allowed sender must be an email address or @domain")
+
+// inboundSubjectPrefixes は転送・返信で件名に付く接頭辞（タスクのタイトルから除く）
+var inboundSubjectPrefixes = []string{"fwd:", "fw:", "re:", "転送:", "転送：", "返信:", "返信："}
+
+// InboundAddress はユーザーごとのタスク作成用のメールアドレス
+// Token をローカル部とするアドレス（token@受信ドメイン）に、許可した差出人から送ったメールをタスクにする
+type InboundAddress struct {
+	UserID string
+	Token  string
+	// AllowedSenders は許可する差出人（小文字のメールアドレス、または "@example.com" のドメイン）
+	AllowedSenders []string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewInboundAddressToken はアドレスのローカル部にする推測できないトークンを生成する
+func NewInboundAddressToken() (string, error) {
+	b := make([]byte, 15)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate inbound address token: %w", err)
+	}
+	return strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)), nil
+}
+
+// Address は受信ドメインのメールアドレスを返す
+func (a *InboundAddress) Address(emailDomain string) string {
+	return a.Token + "@" + emailDomain
+}
+
+// Allows は差出人のメールアドレスが許可リストに一致するか
+func (a *InboundAddress) Allows(sender string) bool {
+	sender = strings.ToLower(strings.TrimSpace(sender))
+	at := strings.LastIndex(sender, "@")
+	if at <= 0 {
+		return false
+	}
+	for _, allowed := range a.AllowedSenders {
+		if allowed == sender || (strings.HasPrefix(allowed, "@") && allowed == sender[at:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeInboundSenders は許可する差出人を小文字にそろえ、重複を除いてチェックする
+func NormalizeInboundSenders(senders []string) ([]string, error) {
+	result := make([]string, 0, len(senders))
+	seen := make(map[string]bool)
+	for _, sender := range senders {
+		sender = strings.ToLower(strings.TrimSpace(sender))
+		if sender == "" || seen[sender] {
+			continue
+		}
+		if strings.HasPrefix(sender, "@") {
+			if domainPart := sender[1:]; domainPart == "" || !strings.Contains(domainPart, ".") || strings.ContainsAny(domainPart, "@ ") {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidInboundSender, sender)
+			}
+		} else if addr, err := mail.ParseAddress(sender); err != nil || addr.Address != sender {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInboundSender, sender)
+		}
+		seen[sender] = true
+		result = append(result, sender)
+	}
+	if len(result) > MaxInboundAllowedSenders {
+		return nil, fmt.Errorf("%w: at most %d senders", ErrInvalidInboundSender, MaxInboundAllowedSenders)
+	}
+	return result, nil
+}
+
+// InboundTokenFromRecipient は受信ドメイン宛てのアドレスのローカル部（"+"以降のタグを除く）を返す
+func InboundTokenFromRecipient(recipient, emailDomain string) (string, bool) {
+	if addr, err := mail.ParseAddress(recipient); err == nil {
+		recipient = addr.Address
+	}
+	local, host, ok := strings.Cut(strings.TrimSpace(recipient), "@")
+	if !ok || local == "" || !strings.EqualFold(host, emailDomain) {
+		return "", false
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return strings.ToLower(local), local != ""
+}
+
+// InboundEmail はメールの受信サービスから届いたメール
+type InboundEmail struct {
+	Recipients []string
+	// From は差出人（Fromヘッダー）のメールアドレス
+	From      string
+	MessageID string
+	Subject   string
+	Body      string // テキストの本文
+	// SenderVerified は差出人（From）のドメインと一致するドメインでSPF・DKIMのいずれかを通過したか
+	SenderVerified bool
+	// SpamFlagged・SpamScore は受信したサービスのスパム判定
+	SpamFlagged bool
+	SpamScore   float64
+	Attachments []InboundAttachment
+}
+
+// InboundAttachment はメールの添付ファイル
+type InboundAttachment struct {
+	Filename string
+	Content  []byte
+}
+
+// TaskTitle は件名から転送・返信の接頭辞を除いてタスクのタイトルを返す
+func (e *InboundEmail) TaskTitle() string {
+	title := strings.Join(strings.Fields(e.Subject), " ")
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, prefix := range inboundSubjectPrefixes {
+			if len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+				title = strings.TrimSpace(title[len(prefix):])
+				trimmed = true
+			}
+		}
+	}
+	if title == "" {
+		return inboundUntitled
+	}
+	return truncateBytes(title, MaxInboundTitleBytes)
+}
+
+// TaskDescription は本文をタスクの説明として返す（説明に収まらず切り詰めた場合は true）
+func (e *InboundEmail) TaskDescription() (string, bool) {
+	body := strings.TrimSpace(strings.ReplaceAll(e.Body, "\r\n", "\n"))
+	if len(body) <= MaxInboundDescriptionBytes {
+		return body, false
+	}
+	return truncateBytes(body, MaxInboundDescriptionBytes), true
+}
+
+// InboundEmailRecord はメールから作成したタスクの記録（Message-IDでの重複の確認と1時間あたりの上限の確認に使う）
+type InboundEmailRecord struct {
+	ID         string
+	UserID     string
+	MessageID  string
+	Sender     string
+	TaskID     string
+	ReceivedAt time.Time
+}
+
+// truncateBytes はUTF-8の文字の途中で切らないように max バイト以内に切り詰める
+func truncateBytes(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut]
+}
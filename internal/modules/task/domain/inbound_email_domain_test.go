@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInboundAddressToken(t *testing.T) {
+	first, err := NewInboundAddressToken()
+	require.NoError(t, err)
+	second, err := NewInboundAddressToken()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 24)
+	assert.Equal(t, strings.ToLower(first), first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestNormalizeInboundSenders(t *testing.T) {
+	got, err := NormalizeInboundSenders([]string{" Alice@Example.com ", "@Corp.Example.com", "alice@example.com", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com", "@corp.example.com"}, got)
+
+	for _, invalid := range []string{"alice", "Alice <alice@example.com>", "@", "@localhost", "@a@b.com"} {
+		_, err := NormalizeInboundSenders([]string{invalid})
+		assert.ErrorIs(t, err, ErrInvalidInboundSender, invalid)
+	}
+
+	tooMany := make([]string, MaxInboundAllowedSenders+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("a", i+1) + "@example.com"
+	}
+	_, err = NormalizeInboundSenders(tooMany)
+	assert.ErrorIs(t, err, ErrInvalidInboundSender)
+}
+
+func TestInboundAddress_Allows(t *testing.T) {
+	address := &InboundAddress{AllowedSenders: []string{"alice@example.com", "@corp.example.com"}}
+
+	assert.True(t, address.Allows("Alice@Example.com"))
+	assert.True(t, address.Allows("bob@corp.example.com"))
+	// サブドメインは一致しない
+	assert.False(t, address.Allows("bob@sub.corp.example.com"))
+	assert.False(t, address.Allows("mallory@example.com"))
+	assert.False(t, address.Allows(""))
+}
+
+func TestInboundTokenFromRecipient(t *testing.T) {
+	token, ok := InboundTokenFromRecipient("ABCDEF+work@In.Example.com", "in.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "abcdef", token)
+
+	token, ok = InboundTokenFromRecipient("Tasks <abcdef@in.example.com>", "in.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "abcdef", token)
+
+	_, ok = InboundTokenFromRecipient("abcdef@example.com", "in.example.com")
+	assert.False(t, ok)
+	_, ok = InboundTokenFromRecipient("+work@in.example.com", "in.example.com")
+	assert.False(t, ok)
+}
+
+func TestInboundEmail_TaskTitle(t *testing.T) {
+	assert.Equal(t, "見積もりの確認", (&InboundEmail{Subject: "Fwd: RE:  見積もりの確認"}).TaskTitle())
+	assert.Equal(t, "請求書", (&InboundEmail{Subject: "転送： 請求書"}).TaskTitle())
+	assert.Equal(t, "（件名なし）", (&InboundEmail{Subject: " Fwd: "}).TaskTitle())
+
+	long := (&InboundEmail{Subject: strings.Repeat("あ", 100)}).TaskTitle()
+	assert.LessOrEqual(t, len(long), MaxInboundTitleBytes)
+	assert.True(t, utf8.ValidString(long))
+}
+
+func TestInboundEmail_TaskDescription(t *testing.T) {
+	description, truncated := (&InboundEmail{Body: "\r\n本文\r\n2行目\r\n"}).TaskDescription()
+	assert.Equal(t, "本文\n2行目", description)
+	assert.False(t, truncated)
+
+	description, truncated = (&InboundEmail{Body: strings.Repeat("あ", 1000)}).TaskDescription()
+	assert.True(t, truncated)
+	assert.LessOrEqual(t, len(description), MaxInboundDescriptionBytes)
+	assert.True(t, utf8.ValidString(description))
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IssueProvider はタスクに関連付けるIssue・Pull Requestの管理サービス
+type IssueProvider string
+
+const (
+	IssueProviderGitHub IssueProvider = "GITHUB"
+)
+
+// IssueKind はIssueとPull Requestの区別
+type IssueKind string
+
+const (
+	IssueKindIssue       IssueKind = "ISSUE"
+	IssueKindPullRequest IssueKind = "PULL_REQUEST"
+)
+
+// IssueState はIssue・Pull Requestの状態
+type IssueState string
+
+const (
+	IssueStateOpen IssueState = "OPEN"
+	// IssueStateClosed は完了して閉じたIssue、またはマージせずに閉じたPull Request
+	IssueStateClosed IssueState = "CLOSED"
+	// IssueStateNotPlanned は対応しないことにして閉じたIssue
+	IssueStateNotPlanned IssueState = "NOT_PLANNED"
+	// IssueStateMerged はマージしたPull Request
+	IssueStateMerged IssueState = "MERGED"
+)
+
+const (
+	// MaxIssueLinksPerTask は1つのタスクに関連付けられるIssue・Pull Requestの上限
+	MaxIssueLinksPerTask = 20
+	// MaxIssueTitleLength は保存するIssue・Pull Requestのタイトルの最大文字数（長い場合は切り詰める）
+	MaxIssueTitleLength = 255
+)
+
+var (
+	// ErrInvalidIssueRef はIssue・Pull RequestのURL・参照の形式が正しくないことを表すエラー
+	ErrInvalidIssueRef = errors.New("issue reference must be a GitHub issue or pull request URL, or owner/repo#number")
+
+	// githubRepositoryPattern はGitHubのリポジトリ名（owner/name）
+	githubRepositoryPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})/[A-Za-z0-9._-]{1,100}$`)
+)
+
+// IssueRef はリポジトリと番号で指定したIssue・Pull Request
+// GitHubのリポジトリ名は大文字・小文字を区別しないため、Repository は小文字にそろえる
+type IssueRef struct {
+	Repository string // owner/name
+	Number     int
+}
+
+// String は "owner/name#123" 形式の参照を返す
+func (r IssueRef) String() string {
+	return fmt.Sprintf("%s#%d", r.Repository, r.Number)
+}
+
+// NewIssueRef はリポジトリと番号をチェックして参照を作成する
+func NewIssueRef(repository string, number int) (IssueRef, error) {
+	repository = strings.TrimSpace(repository)
+	if !githubRepositoryPattern.MatchString(repository) || number <= 0 {
+		return IssueRef{}, ErrInvalidIssueRef
+	}
+	return IssueRef{Repository: strings.ToLower(repository), Number: number}, nil
+}
+
+// ParseIssueRef はGitHubのIssue・Pull RequestのURL（https://github.com/owner/name/issues/123, .../pull/123）
+// または "owner/name#123" 形式の参照を解析する
+func ParseIssueRef(value string) (IssueRef, error) {
+	value = strings.TrimSpace(value)
+	if repository, number, ok := strings.Cut(value, "#"); ok && !strings.Contains(value, "://") {
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return IssueRef{}, ErrInvalidIssueRef
+		}
+		return NewIssueRef(repository, n)
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || !strings.EqualFold(u.Host, "github.com") {
+		return IssueRef{}, ErrInvalidIssueRef
+	}
+	// /owner/name/issues/123 または /owner/name/pull/123（/files などのタブのURLも受け付ける）
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || (parts[2] != "issues" && parts[2] != "pull") {
+		return IssueRef{}, ErrInvalidIssueRef
+	}
+	n, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return IssueRef{}, ErrInvalidIssueRef
+	}
+	return NewIssueRef(parts[0]+"/"+parts[1], n)
+}
+
+// IssueInfo はGitHubから取得した、またはWebhookで届いたIssue・Pull Requestの情報
+type IssueInfo struct {
+	Ref   IssueRef
+	Kind  IssueKind
+	Title string
+	State IssueState
+	URL   string
+}
+
+// IssueLink はタスクに関連付けたIssue・Pull Request
+type IssueLink struct {
+	ID         string        `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID     string        `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Provider   IssueProvider `json:"provider" example:"GITHUB"`
+	Repository string        `json:"repository" example:"hryt430/yotei-plus"`
+	Number     int           `json:"number" example:"42"`
+	Kind       IssueKind     `json:"kind" example:"PULL_REQUEST"`
+	Title      string        `json:"title" example:"タスクの検索を追加"`
+	State      IssueState    `json:"state" example:"OPEN"`
+	URL        string        `json:"url" example:"https://github.com/hryt430/yotei-plus/pull/42"`
+	// CompleteOnClose はPull Requestのマージ・Issueの完了でタスクを完了にするか
+	CompleteOnClose bool      `json:"complete_on_close" example:"true"`
+	CreatedBy       string    `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174002"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+} // @name IssueLink
+
+// NewIssueLink はGitHubから取得した情報でタスクとIssue・Pull Requestの関連付けを作成する
+func NewIssueLink(taskID string, info *IssueInfo, completeOnClose bool, createdBy string, now time.Time) *IssueLink {
+	link := &IssueLink{
+		TaskID:          taskID,
+		Provider:        IssueProviderGitHub,
+		Repository:      info.Ref.Repository,
+		Number:          info.Ref.Number,
+		CompleteOnClose: completeOnClose,
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+	}
+	link.ApplyInfo(info, now)
+	return link
+}
+
+// Ref はリンク先の参照を返す
+func (l *IssueLink) Ref() IssueRef {
+	return IssueRef{Repository: l.Repository, Number: l.Number}
+}
+
+// ApplyInfo はIssue・Pull Requestの最新の情報を反映し、タスクを完了にするかを返す
+// 完了にするのは、CompleteOnClose のリンクがマージ・完了の状態に変わった場合だけ（同じ状態の再通知やタスクを再開した後には完了にしない）
+func (l *IssueLink) ApplyInfo(info *IssueInfo, now time.Time) bool {
+	completes := l.CompleteOnClose && info.State.Completes(info.Kind) && l.State != info.State
+
+	l.Kind = info.Kind
+	l.Title = truncateRunes(strings.TrimSpace(info.Title), MaxIssueTitleLength)
+	l.State = info.State
+	if info.URL != "" {
+		l.URL = info.URL
+	}
+	l.UpdatedAt = now
+	return completes
+}
+
+// Completes はタスクを完了にする状態か（マージしたPull Request・完了して閉じたIssue）
+func (s IssueState) Completes(kind IssueKind) bool {
+	if kind == IssueKindPullRequest {
+		return s == IssueStateMerged
+	}
+	return s == IssueStateClosed
+}
+
+// GitHubAccount はユーザーが登録したGitHubのアクセストークン
+// 非公開のリポジトリのIssue・Pull Requestを関連付けるときに使い、トークンはレスポンスに含めない
+type GitHubAccount struct {
+	UserID    string    `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174002"`
+	Login     string    `json:"login" example:"octocat"`
+	Token     string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name GitHubAccount
+
+// MaskedToken はトークンの末尾4文字だけを残した表示用の値を返す
+func (a *GitHubAccount) MaskedToken() string {
+	runes := []rune(a.Token)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", 8) + string(runes[len(runes)-4:])
+}
+
+func truncateRunes(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
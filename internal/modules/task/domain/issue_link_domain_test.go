@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssueRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    IssueRef
+		wantErr bool
+	}{
+		{name: "issue URL", value: "https://github.com/Octo/App/issues/12", want: IssueRef{Repository: "octo/app", Number: 12}},
+		{name: "pull request URL", value: " https://github.com/octo/app/pull/42 ", want: IssueRef{Repository: "octo/app", Number: 42}},
+		{name: "pull request tab URL", value: "https://github.com/octo/app/pull/42/files", want: IssueRef{Repository: "octo/app", Number: 42}},
+		{name: "shorthand", value: "octo/my.app#7", want: IssueRef{Repository: "octo/my.app", Number: 7}},
+		{name: "other host", value: "https://gitlab.com/octo/app/issues/1", wantErr: true},
+		{name: "http", value: "http://github.com/octo/app/issues/1", wantErr: true},
+		{name: "not an issue", value: "https://github.com/octo/app/commit/abc", wantErr: true},
+		{name: "invalid number", value: "octo/app#0", wantErr: true},
+		{name: "missing owner", value: "app#1", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIssueRef(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidIssueRef)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIssueLink_ApplyInfo(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	newLink := func(kind IssueKind, completeOnClose bool) *IssueLink {
+		return NewIssueLink("task-1", &IssueInfo{
+			Ref:   IssueRef{Repository: "octo/app", Number: 1},
+			Kind:  kind,
+			Title: "Add search",
+			State: IssueStateOpen,
+			URL:   "https://github.com/octo/app/pull/1",
+		}, completeOnClose, "user-1", now)
+	}
+	info := func(kind IssueKind, state IssueState) *IssueInfo {
+		return &IssueInfo{Ref: IssueRef{Repository: "octo/app", Number: 1}, Kind: kind, Title: "Add search", State: state}
+	}
+
+	t.Run("merging a pull request completes the task", func(t *testing.T) {
+		link := newLink(IssueKindPullRequest, true)
+		assert.True(t, link.ApplyInfo(info(IssueKindPullRequest, IssueStateMerged), now.Add(time.Hour)))
+		assert.Equal(t, IssueStateMerged, link.State)
+		assert.Equal(t, now.Add(time.Hour), link.UpdatedAt)
+		// URLが届かない場合は以前のURLを残す
+		assert.Equal(t, "https://github.com/octo/app/pull/1", link.URL)
+
+		// 同じ状態の再通知では完了にしない（タスクを再開した場合に再び完了にしない）
+		assert.False(t, link.ApplyInfo(info(IssueKindPullRequest, IssueStateMerged), now.Add(2*time.Hour)))
+	})
+
+	t.Run("closing a pull request without merging does not complete the task", func(t *testing.T) {
+		link := newLink(IssueKindPullRequest, true)
+		assert.False(t, link.ApplyInfo(info(IssueKindPullRequest, IssueStateClosed), now))
+		assert.Equal(t, IssueStateClosed, link.State)
+	})
+
+	t.Run("closing an issue as completed completes the task", func(t *testing.T) {
+		link := newLink(IssueKindIssue, true)
+		assert.True(t, link.ApplyInfo(info(IssueKindIssue, IssueStateClosed), now))
+	})
+
+	t.Run("closing an issue as not planned does not complete the task", func(t *testing.T) {
+		link := newLink(IssueKindIssue, true)
+		assert.False(t, link.ApplyInfo(info(IssueKindIssue, IssueStateNotPlanned), now))
+	})
+
+	t.Run("links without complete on close only sync the state", func(t *testing.T) {
+		link := newLink(IssueKindPullRequest, false)
+		assert.False(t, link.ApplyInfo(info(IssueKindPullRequest, IssueStateMerged), now))
+		assert.Equal(t, IssueStateMerged, link.State)
+	})
+
+	t.Run("long titles are truncated", func(t *testing.T) {
+		link := newLink(IssueKindIssue, true)
+		long := info(IssueKindIssue, IssueStateOpen)
+		long.Title = strings.Repeat("あ", MaxIssueTitleLength+10)
+		link.ApplyInfo(long, now)
+		assert.Len(t, []rune(link.Title), MaxIssueTitleLength)
+	})
+}
+
+func TestGitHubAccount_MaskedToken(t *testing.T) {
+	assert.Equal(t, "********abcd", (&GitHubAccount{Token: "ghp_0123456789abcd"}).MaskedToken())
+	assert.Equal(t, "***", (&GitHubAccount{Token: "abc"}).MaskedToken())
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LinkPreviewStatus はリンクのプレビューの取得結果
+type LinkPreviewStatus string
+
+const (
+	LinkPreviewReady  LinkPreviewStatus = "READY"  // 取得済み
+	LinkPreviewFailed LinkPreviewStatus = "FAILED" // 取得できなかった（一定時間後に再取得する）
+)
+
+// プレビューの制約
+const (
+	// MaxLinkPreviewsPerText は1つの本文から取得するプレビューの最大数
+	MaxLinkPreviewsPerText = 5
+	// LinkPreviewFailureRetry は取得できなかったリンクを再取得するまでの時間
+	LinkPreviewFailureRetry = time.Hour
+
+	maxLinkPreviewURLLength = 2048
+)
+
+// LinkPreview はタスクの説明・コメント中のリンクのプレビュー（OpenGraphのメタデータ）
+type LinkPreview struct {
+	URL         string            `json:"url" example:"https://example.com/article"`
+	Title       string            `json:"title" example:"記事のタイトル"`
+	Description string            `json:"description,omitempty" example:"記事の概要"`
+	ImageURL    string            `json:"image_url,omitempty" example:"https://example.com/ogp.png"`
+	SiteName    string            `json:"site_name,omitempty" example:"Example"`
+	Status      LinkPreviewStatus `json:"-"`
+	FetchedAt   time.Time         `json:"fetched_at"`
+} // @name LinkPreview
+
+// NewFailedLinkPreview は取得できなかったリンクの記録を作成する
+func NewFailedLinkPreview(rawURL string, fetchedAt time.Time) *LinkPreview {
+	return &LinkPreview{URL: rawURL, Status: LinkPreviewFailed, FetchedAt: fetchedAt}
+}
+
+// IsReady は表示できるプレビューかを返す
+func (p *LinkPreview) IsReady() bool {
+	return p.Status == LinkPreviewReady
+}
+
+// IsStale は再取得が必要かを返す（取得できなかった場合はttlとLinkPreviewFailureRetryの短い方で再取得する）
+func (p *LinkPreview) IsStale(now time.Time, ttl time.Duration) bool {
+	if p.Status == LinkPreviewFailed && (ttl <= 0 || LinkPreviewFailureRetry < ttl) {
+		ttl = LinkPreviewFailureRetry
+	}
+	if ttl <= 0 {
+		return false
+	}
+	return !now.Before(p.FetchedAt.Add(ttl))
+}
+
+// LinkPreviewPolicy はプレビューを取得するリンクのドメインの許可・拒否リスト
+// ドメインはサブドメインにも一致する（example.com は www.example.com にも一致する）
+type LinkPreviewPolicy struct {
+	Allowed []string // 空の場合は拒否リスト以外の全てのドメインを許可する
+	Denied  []string
+}
+
+// NewLinkPreviewPolicy はドメインを小文字・先頭の "*." や "." を除いた形にそろえてポリシーを作成する
+func NewLinkPreviewPolicy(allowed, denied []string) LinkPreviewPolicy {
+	return LinkPreviewPolicy{Allowed: normalizeDomains(allowed), Denied: normalizeDomains(denied)}
+}
+
+// Permits はリンクのプレビューを取得してよいかを返す
+// http・httpsの標準ポートのドメイン名のURLに限り、IPアドレスや認証情報を含むURLは取得しない
+func (p LinkPreviewPolicy) Permits(rawURL string) bool {
+	if len(rawURL) > maxLinkPreviewURLLength {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User != nil || u.Opaque != "" {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	default:
+		return false
+	}
+	switch u.Port() {
+	case "", "80", "443":
+	default:
+		return false
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return false
+	}
+	if matchesDomain(host, p.Denied) {
+		return false
+	}
+	return len(p.Allowed) == 0 || matchesDomain(host, p.Allowed)
+}
+
+// matchesDomain はホストがいずれかのドメインまたはそのサブドメインかを返す
+func matchesDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.TrimSpace(strings.ToLower(d)), "*")
+		d = strings.Trim(d, ".")
+		if d != "" {
+			normalized = append(normalized, d)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxLocationNameLength は場所の名前の最大文字数
+	MaxLocationNameLength = 200
+
+	// MinGeofenceRadius はジオフェンスの半径の下限（メートル、端末の位置情報の精度より小さくしない）
+	MinGeofenceRadius = 100
+	// MaxGeofenceRadius はジオフェンスの半径の上限（メートル）
+	MaxGeofenceRadius = 5000
+	// DefaultGeofenceRadius は半径を指定しない場合のジオフェンスの半径（メートル）
+	DefaultGeofenceRadius = 200
+	// MaxGeofencesPerUser は1ユーザーが登録できるジオフェンスの上限（iOS で監視できる領域の数に合わせる）
+	MaxGeofencesPerUser = 20
+	// GeofenceCooldown は同じジオフェンスで再びリマインダーを送るまでの間隔（境界付近での通知の連続を防ぐ）
+	GeofenceCooldown = time.Hour
+	// GeofenceTolerance は端末が報告した位置をジオフェンスの内側とみなす半径の余裕（メートル、位置情報の誤差を見込む）
+	GeofenceTolerance = 100
+
+	// DefaultNearbyRadius は近くのタスクを探す既定の半径（メートル）
+	DefaultNearbyRadius = 1000
+	// MaxNearbyRadius は近くのタスクを探す半径の上限（メートル）
+	MaxNearbyRadius = 50000
+
+	// earthRadiusMeters は地球の平均半径（メートル）
+	earthRadiusMeters = 6371008.8
+)
+
+// Location はタスク・予定の場所（名前と緯度・経度）
+type Location struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+} // @name Location
+
+// Validate は場所の名前と緯度・経度をチェックする（名前の前後の空白は取り除く）
+func (l *Location) Validate() error {
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Name == "" {
+		return errors.New("name is required")
+	}
+	if len([]rune(l.Name)) > MaxLocationNameLength {
+		return fmt.Errorf("name must be at most %d characters", MaxLocationNameLength)
+	}
+	return ValidateCoordinates(l.Latitude, l.Longitude)
+}
+
+// DistanceMeters は2地点間の大円距離（メートル）をハーバーサイン公式で計算する
+func (l Location) DistanceMeters(latitude, longitude float64) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// ValidateCoordinates は緯度・経度が範囲内かをチェックする
+func ValidateCoordinates(latitude, longitude float64) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// GeoBounds は緯度・経度の範囲（近くのタスクを索引で絞り込むのに使う）
+type GeoBounds struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// BoundsAround は中心から半径 radiusMeters の円を含む緯度・経度の範囲を返す
+// 極を含む場合と日付変更線をまたぐ場合は経度の全範囲とする
+func BoundsAround(latitude, longitude, radiusMeters float64) GeoBounds {
+	dLat := radiusMeters / earthRadiusMeters * 180 / math.Pi
+	bounds := GeoBounds{
+		MinLatitude:  latitude - dLat,
+		MaxLatitude:  latitude + dLat,
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+	if bounds.MinLatitude <= -90 || bounds.MaxLatitude >= 90 {
+		bounds.MinLatitude = math.Max(bounds.MinLatitude, -90)
+		bounds.MaxLatitude = math.Min(bounds.MaxLatitude, 90)
+		return bounds
+	}
+
+	// 中心から最も離れた緯度での経度の幅で、円を含むようにする
+	maxLat := math.Max(math.Abs(bounds.MinLatitude), math.Abs(bounds.MaxLatitude)) * math.Pi / 180
+	dLng := dLat / math.Cos(maxLat)
+	if longitude-dLng < -180 || longitude+dLng > 180 {
+		return bounds
+	}
+	bounds.MinLongitude = longitude - dLng
+	bounds.MaxLongitude = longitude + dLng
+	return bounds
+}
+
+// TaskLocation はタスクに設定した場所
+type TaskLocation struct {
+	TaskID    string    `json:"task_id"`
+	Location  Location  `json:"location"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name TaskLocation
+
+// NearbyTask は場所が近いタスクと中心からの距離
+type NearbyTask struct {
+	Task           *Task    `json:"task"`
+	Location       Location `json:"location"`
+	DistanceMeters float64  `json:"distance_meters"`
+} // @name NearbyTask
+
+// Geofence はタスクの場所の周囲に登録したジオフェンス（端末が範囲に入ったことを報告するとリマインダーを送る）
+// 場所はタスクの場所を参照するため、タスクの場所を変更するとジオフェンスの中心も移る
+type Geofence struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"user_id"`
+	TaskID          string     `json:"task_id"`
+	RadiusMeters    int        `json:"radius_meters"`
+	CreatedAt       time.Time  `json:"created_at"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+	Location        *Location  `json:"location,omitempty"` // タスクの場所（取得時に設定する）
+} // @name Geofence
+
+// Contains は地点がジオフェンスの内側（位置情報の誤差の余裕を含む）かを返す
+func (g *Geofence) Contains(latitude, longitude float64) bool {
+	if g.Location == nil {
+		return false
+	}
+	return g.Location.DistanceMeters(latitude, longitude) <= float64(g.RadiusMeters+GeofenceTolerance)
+}
+
+// CanTrigger は前回のリマインダーから GeofenceCooldown が経過しているかを返す
+func (g *Geofence) CanTrigger(now time.Time) bool {
+	return g.LastTriggeredAt == nil || !now.Before(g.LastTriggeredAt.Add(GeofenceCooldown))
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		loc     Location
+		wantErr bool
+	}{
+		{name: "valid", loc: Location{Name: " 東京駅 ", Latitude: 35.681236, Longitude: 139.767125}},
+		{name: "empty name", loc: Location{Name: "  ", Latitude: 35, Longitude: 139}, wantErr: true},
+		{name: "name too long", loc: Location{Name: strings.Repeat("駅", MaxLocationNameLength+1)}, wantErr: true},
+		{name: "latitude out of range", loc: Location{Name: "x", Latitude: 90.1}, wantErr: true},
+		{name: "longitude out of range", loc: Location{Name: "x", Longitude: -180.5}, wantErr: true},
+		{name: "NaN", loc: Location{Name: "x", Latitude: math.NaN()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.loc.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			// 名前の前後の空白は取り除く
+			assert.Equal(t, "東京駅", tt.loc.Name)
+		})
+	}
+}
+
+func TestLocation_DistanceMeters(t *testing.T) {
+	tokyo := Location{Name: "東京駅", Latitude: 35.681236, Longitude: 139.767125}
+
+	assert.Equal(t, 0.0, tokyo.DistanceMeters(tokyo.Latitude, tokyo.Longitude))
+	// 東京駅から新大阪駅までは約400km
+	assert.InDelta(t, 400_000, tokyo.DistanceMeters(34.733165, 135.500214), 5_000)
+	// 経度1度は赤道上で約111km
+	assert.InDelta(t, 111_195, Location{}.DistanceMeters(0, 1), 100)
+}
+
+func TestBoundsAround(t *testing.T) {
+	t.Run("contains the circle", func(t *testing.T) {
+		center := Location{Latitude: 35.68, Longitude: 139.76}
+		bounds := BoundsAround(center.Latitude, center.Longitude, 1000)
+
+		assert.Less(t, bounds.MinLatitude, center.Latitude)
+		assert.Greater(t, bounds.MaxLatitude, center.Latitude)
+		// 境界の各辺の中点は中心から半径以上離れている
+		assert.GreaterOrEqual(t, center.DistanceMeters(bounds.MaxLatitude, center.Longitude), 999.0)
+		assert.GreaterOrEqual(t, center.DistanceMeters(center.Latitude, bounds.MaxLongitude), 999.0)
+		assert.GreaterOrEqual(t, center.DistanceMeters(center.Latitude, bounds.MinLongitude), 999.0)
+	})
+
+	t.Run("near the pole", func(t *testing.T) {
+		bounds := BoundsAround(89.999, 10, 1000)
+		assert.Equal(t, 90.0, bounds.MaxLatitude)
+		assert.Equal(t, -180.0, bounds.MinLongitude)
+		assert.Equal(t, 180.0, bounds.MaxLongitude)
+	})
+
+	t.Run("across the antimeridian", func(t *testing.T) {
+		bounds := BoundsAround(0, 179.999, 1000)
+		assert.Equal(t, -180.0, bounds.MinLongitude)
+		assert.Equal(t, 180.0, bounds.MaxLongitude)
+	})
+}
+
+func TestGeofence_ContainsAndCanTrigger(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	fence := &Geofence{RadiusMeters: 200}
+
+	// 場所がない場合は範囲に入らない
+	assert.False(t, fence.Contains(0, 0))
+
+	fence.Location = &Location{Latitude: 0, Longitude: 0}
+	assert.True(t, fence.Contains(0, 0.002))  // 約220m（誤差の余裕の内側）
+	assert.False(t, fence.Contains(0, 0.003)) // 約330m
+
+	assert.True(t, fence.CanTrigger(now))
+	triggered := now.Add(-30 * time.Minute)
+	fence.LastTriggeredAt = &triggered
+	assert.False(t, fence.CanTrigger(now))
+	assert.True(t, fence.CanTrigger(triggered.Add(GeofenceCooldown)))
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MentionSourceType はメンションが含まれる本文の種類を表す型
+type MentionSourceType string
+
+// メンション元の定数
+const (
+	MentionSourceDescription MentionSourceType = "DESCRIPTION"
+	MentionSourceComment     MentionSourceType = "COMMENT"
+)
+
+// ユーザー名の長さ制限（ユーザー登録時の制約に合わせる）
+const (
+	minMentionUsernameLength = 3
+	maxMentionUsernameLength = 30
+	MaxMentionsPerText       = 20
+)
+
+// mentionPattern は行頭または英数字以外の直後にある@usernameに一致する
+// メールアドレス（user@example.com）は一致しない
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([\p{L}\p{N}_.\-]+)`)
+
+// MentionSource はメンション元（タスク説明またはコメント）を表す
+type MentionSource struct {
+	Type MentionSourceType `json:"type"`
+	ID   string            `json:"id"` // 説明の場合はタスクID、コメントの場合はコメントID
+}
+
+// Mention はメンションの記録を表す
+type Mention struct {
+	ID              string            `json:"id"`
+	TaskID          string            `json:"task_id"`
+	SourceType      MentionSourceType `json:"source_type"`
+	SourceID        string            `json:"source_id"`
+	MentionedUserID string            `json:"mentioned_user_id"`
+	AuthorID        string            `json:"author_id"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// NewMention は新しいメンションを作成する
+func NewMention(taskID string, source MentionSource, mentionedUserID, authorID string) *Mention {
+	return &Mention{
+		TaskID:          taskID,
+		SourceType:      source.Type,
+		SourceID:        source.ID,
+		MentionedUserID: mentionedUserID,
+		AuthorID:        authorID,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// ParseMentions は本文から@usernameを重複なく出現順に抽出する
+// 末尾の句読点は取り除き、長さが不正なものは無視する
+func ParseMentions(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		length := len([]rune(username))
+		if length < minMentionUsernameLength || length > maxMentionUsernameLength {
+			continue
+		}
+
+		key := strings.ToLower(username)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		usernames = append(usernames, username)
+
+		if len(usernames) >= MaxMentionsPerText {
+			break
+		}
+	}
+
+	return usernames
+}
+
+// NewMentionUsernames は編集後の本文で新たに追加されたメンションのみを返す
+func NewMentionUsernames(text, previousText string) []string {
+	previous := make(map[string]bool)
+	for _, username := range ParseMentions(previousText) {
+		previous[strings.ToLower(username)] = true
+	}
+
+	var added []string
+	for _, username := range ParseMentions(text) {
+		if !previous[strings.ToLower(username)] {
+			added = append(added, username)
+		}
+	}
+	return added
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"single mention", "@alice please review", []string{"alice"}},
+		{"multiple mentions in order", "cc @bob and @alice.", []string{"bob", "alice"}},
+		{"case-insensitive duplicates", "@Alice @alice @ALICE", []string{"Alice"}},
+		{"email address is ignored", "mail alice@example.com", nil},
+		{"japanese punctuation boundary", "確認お願いします、@taro_y", []string{"taro_y"}},
+		{"too short username", "@ab hi", nil},
+		{"trailing punctuation trimmed", "thanks @carol-", []string{"carol"}},
+		{"no mentions", "plain text", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseMentions(tt.text))
+		})
+	}
+}
+
+func TestParseMentions_Limit(t *testing.T) {
+	var parts []string
+	for i := 0; i < MaxMentionsPerText+5; i++ {
+		parts = append(parts, "@user"+strings.Repeat("x", i+1))
+	}
+
+	assert.Len(t, ParseMentions(strings.Join(parts, " ")), MaxMentionsPerText)
+}
+
+func TestNewMentionUsernames(t *testing.T) {
+	added := NewMentionUsernames("@alice @bob @carol", "@Alice was here")
+	assert.Equal(t, []string{"bob", "carol"}, added)
+
+	assert.Empty(t, NewMentionUsernames("@alice", "@alice"))
+}
+
+func TestNewTaskComment(t *testing.T) {
+	comment, err := NewTaskComment("task-1", "user-1", "  hello  ")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", comment.Comment)
+
+	_, err = NewTaskComment("task-1", "user-1", "   ")
+	assert.Error(t, err)
+
+	_, err = NewTaskComment("task-1", "user-1", strings.Repeat("あ", MaxCommentLength+1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MaxBurndownDays はバーンダウンで返す日数の上限（超える場合は直近の日数に絞る）
+const MaxBurndownDays = 366
+
+// Milestone はプロジェクトグループのマイルストーンを表す
+// Position はグループ内の並び順（0始まり）
+type Milestone struct {
+	ID        string    `json:"id"`
+	GroupID   string    `json:"group_id"`
+	Name      string    `json:"name"`
+	DueDate   time.Time `json:"due_date"`
+	Position  int       `json:"position"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewMilestone は新しいマイルストーンを作成する
+func NewMilestone(groupID, name string, dueDate time.Time, position int, createdBy string) *Milestone {
+	now := time.Now()
+	return &Milestone{
+		GroupID:   groupID,
+		Name:      name,
+		DueDate:   dueDate,
+		Position:  position,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// MilestoneProgress はマイルストーンの進捗（完了タスク数/タスク数）を表す
+type MilestoneProgress struct {
+	TotalTasks     int     `json:"total_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	CompletionRate float64 `json:"completion_rate"` // 完了率（0-100）
+}
+
+// NewMilestoneProgress はマイルストーンに紐付いたタスクから進捗を計算する
+func NewMilestoneProgress(tasks []*Task) MilestoneProgress {
+	progress := MilestoneProgress{TotalTasks: len(tasks)}
+	for _, task := range tasks {
+		if task.Status == TaskStatusDone {
+			progress.CompletedTasks++
+		}
+	}
+	if progress.TotalTasks > 0 {
+		progress.CompletionRate = math.Round(float64(progress.CompletedTasks)/float64(progress.TotalTasks)*1000) / 10
+	}
+	return progress
+}
+
+// BurndownPoint はバーンダウンの1日分の値を表す
+// Remaining はその日の終わり時点の未完了タスク数で、未来の日は nil
+type BurndownPoint struct {
+	Date      time.Time `json:"date"`
+	Remaining *int      `json:"remaining,omitempty"`
+	Ideal     float64   `json:"ideal"`
+}
+
+// MilestoneBurndown はマイルストーンのバーンダウンを表す
+type MilestoneBurndown struct {
+	MilestoneID string          `json:"milestone_id"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	TotalTasks  int             `json:"total_tasks"`
+	Points      []BurndownPoint `json:"points"`
+}
+
+// NewMilestoneBurndown はマイルストーンの作成日から期限日（期限を過ぎている場合は今日）までの日ごとの未完了タスク数を計算する
+// 理想線は作成日のタスク数から期限日に0になる直線とする
+func NewMilestoneBurndown(milestone *Milestone, tasks []*Task, now time.Time) *MilestoneBurndown {
+	loc := now.Location()
+	from := startOfDay(milestone.CreatedAt.In(loc))
+	due := startOfDay(milestone.DueDate.In(loc))
+	today := startOfDay(now)
+
+	to := due
+	if today.After(to) {
+		to = today
+	}
+	if to.Before(from) {
+		from = to
+	}
+	if days := daysBetween(from, to) + 1; days > MaxBurndownDays {
+		from = to.AddDate(0, 0, -(MaxBurndownDays - 1))
+	}
+
+	burndown := &MilestoneBurndown{
+		MilestoneID: milestone.ID,
+		From:        from,
+		To:          to,
+		TotalTasks:  len(tasks),
+		Points:      []BurndownPoint{},
+	}
+
+	idealDays := daysBetween(from, due)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		point := BurndownPoint{Date: day}
+
+		elapsed := daysBetween(from, day)
+		if idealDays > 0 && elapsed < idealDays {
+			point.Ideal = math.Round(float64(len(tasks))*float64(idealDays-elapsed)/float64(idealDays)*10) / 10
+		}
+
+		if !day.After(today) {
+			remaining := countRemainingAt(tasks, day.AddDate(0, 0, 1))
+			point.Remaining = &remaining
+		}
+
+		burndown.Points = append(burndown.Points, point)
+	}
+
+	return burndown
+}
+
+// countRemainingAt は指定時刻より前に作成され、その時点で完了していないタスク数を数える
+func countRemainingAt(tasks []*Task, at time.Time) int {
+	remaining := 0
+	for _, task := range tasks {
+		if !task.CreatedAt.Before(at) {
+			continue
+		}
+		if task.Status == TaskStatusDone && task.CompletedAt != nil && task.CompletedAt.Before(at) {
+			continue
+		}
+		remaining++
+	}
+	return remaining
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween は日付（0時）間の日数を返す
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
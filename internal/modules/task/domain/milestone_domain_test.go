@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMilestoneTask(createdAt time.Time, completedAt *time.Time) *Task {
+	task := NewTask("task", "", PriorityMedium, CategoryWork, "user-1")
+	task.CreatedAt = createdAt
+	if completedAt != nil {
+		task.Status = TaskStatusDone
+		task.CompletedAt = completedAt
+	}
+	return task
+}
+
+func TestNewMilestoneProgress(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	done := day.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		tasks []*Task
+		want  MilestoneProgress
+	}{
+		{
+			name: "no tasks",
+			want: MilestoneProgress{},
+		},
+		{
+			name: "partially done",
+			tasks: []*Task{
+				newMilestoneTask(day, &done),
+				newMilestoneTask(day, nil),
+				newMilestoneTask(day, nil),
+			},
+			want: MilestoneProgress{TotalTasks: 3, CompletedTasks: 1, CompletionRate: 33.3},
+		},
+		{
+			name:  "all done",
+			tasks: []*Task{newMilestoneTask(day, &done)},
+			want:  MilestoneProgress{TotalTasks: 1, CompletedTasks: 1, CompletionRate: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewMilestoneProgress(tt.tasks))
+		})
+	}
+}
+
+func TestNewMilestoneBurndown(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	milestone := NewMilestone("group-1", "Beta", start.AddDate(0, 0, 4), 0, "user-1")
+	milestone.ID = "milestone-1"
+	milestone.CreatedAt = start
+
+	completedDay2 := start.AddDate(0, 0, 1).Add(3 * time.Hour)
+	tasks := []*Task{
+		newMilestoneTask(start.Add(-48*time.Hour), &completedDay2),
+		newMilestoneTask(start, nil),
+		newMilestoneTask(start.AddDate(0, 0, 2), nil), // added on day 3
+		newMilestoneTask(start, nil),
+	}
+	now := start.AddDate(0, 0, 2).Add(time.Hour)
+
+	burndown := NewMilestoneBurndown(milestone, tasks, now)
+
+	assert.Equal(t, "milestone-1", burndown.MilestoneID)
+	assert.Equal(t, 4, burndown.TotalTasks)
+	require.Len(t, burndown.Points, 5)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), burndown.From)
+	assert.Equal(t, time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), burndown.To)
+
+	var remaining []int
+	for _, point := range burndown.Points {
+		if point.Remaining != nil {
+			remaining = append(remaining, *point.Remaining)
+		}
+	}
+	assert.Equal(t, []int{3, 2, 3}, remaining, "future days have no actual values")
+
+	ideal := make([]float64, 0, len(burndown.Points))
+	for _, point := range burndown.Points {
+		ideal = append(ideal, point.Ideal)
+	}
+	assert.Equal(t, []float64{4, 3, 2, 1, 0}, ideal)
+}
+
+func TestNewMilestoneBurndown_PastDue(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	milestone := NewMilestone("group-1", "Beta", start.AddDate(0, 0, 1), 0, "user-1")
+	milestone.CreatedAt = start
+	now := start.AddDate(0, 0, 3)
+
+	burndown := NewMilestoneBurndown(milestone, []*Task{newMilestoneTask(start, nil)}, now)
+
+	require.Len(t, burndown.Points, 4, "extends to today when past due")
+	last := burndown.Points[len(burndown.Points)-1]
+	require.NotNil(t, last.Remaining)
+	assert.Equal(t, 1, *last.Remaining)
+	assert.Equal(t, float64(0), last.Ideal)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuickSummaryMaxTasks は音声アシスタント・ショートカット向けの今日の予定で読み上げるタスクの件数の上限
+const QuickSummaryMaxTasks = 5
+
+// QuickAddReply は音声アシスタント・ショートカットからタスクを追加したときに返す短い文を返す
+// 期限はlocの日時で読み上げる
+func QuickAddReply(task *Task, loc *time.Location) string {
+	reply := fmt.Sprintf("「%s」を追加しました。", task.Title)
+	if task.DueDate != nil {
+		reply += "期限は" + formatQuickDateTime(task.DueDate.In(loc)) + "です。"
+	}
+	return reply
+}
+
+// Summary は今日の予定を音声アシスタント・ショートカットで読み上げる短い文にまとめる
+// 未完了のタスクを期限切れ・今日が期限・今日着手予定の順に QuickSummaryMaxTasks 件まで並べる
+func (t *Today) Summary() string {
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	var dueOpen []*Task
+	for _, task := range t.DueToday {
+		if task.Status != TaskStatusDone {
+			dueOpen = append(dueOpen, task)
+		}
+	}
+
+	var counts []string
+	if len(dueOpen) > 0 {
+		counts = append(counts, fmt.Sprintf("今日が期限のタスクが%d件", len(dueOpen)))
+	}
+	if len(t.Overdue) > 0 {
+		counts = append(counts, fmt.Sprintf("期限切れのタスクが%d件", len(t.Overdue)))
+	}
+	if len(t.StartingToday) > 0 {
+		counts = append(counts, fmt.Sprintf("今日着手予定のタスクが%d件", len(t.StartingToday)))
+	}
+
+	var b strings.Builder
+	if len(counts) == 0 {
+		b.WriteString("今日の予定はありません。")
+	} else {
+		b.WriteString(strings.Join(counts, "、") + "あります。")
+	}
+	if t.UnreadNotificationCount > 0 {
+		fmt.Fprintf(&b, "未読の通知が%d件あります。", t.UnreadNotificationCount)
+	}
+
+	// 同じタスクが複数の区分に含まれる場合は最初の区分でだけ読み上げる
+	seen := make(map[string]bool)
+	listed, remaining := 0, 0
+	add := func(task *Task, note string) {
+		if seen[task.ID] {
+			return
+		}
+		seen[task.ID] = true
+		if listed == QuickSummaryMaxTasks {
+			remaining++
+			return
+		}
+		listed++
+		fmt.Fprintf(&b, "\n・%s（%s）", task.Title, note)
+	}
+	for _, task := range t.Overdue {
+		add(task, "期限切れ")
+	}
+	for _, task := range dueOpen {
+		add(task, task.DueDate.In(loc).Format("15:04")+"まで")
+	}
+	for _, task := range t.StartingToday {
+		add(task, "着手予定")
+	}
+	if remaining > 0 {
+		fmt.Fprintf(&b, "\nほか%d件", remaining)
+	}
+	return b.String()
+}
+
+// formatQuickDateTime は日時を「1月2日 15:04」の形式で返す
+func formatQuickDateTime(at time.Time) string {
+	return fmt.Sprintf("%d月%d日 %s", int(at.Month()), at.Day(), at.Format("15:04"))
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickAddReply(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	task := NewTask("資料作成", "", PriorityMedium, CategoryWork, "user-1")
+	assert.Equal(t, "「資料作成」を追加しました。", QuickAddReply(task, tokyo))
+
+	// 期限はユーザーのタイムゾーンで読み上げる
+	task.SetDueDate(time.Date(2024, 6, 4, 6, 0, 0, 0, time.UTC))
+	assert.Equal(t, "「資料作成」を追加しました。期限は6月4日 15:00です。", QuickAddReply(task, tokyo))
+}
+
+func TestToday_Summary(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Date(2024, 6, 4, 8, 30, 0, 0, tokyo)
+	at := func(day, hour int) *time.Time {
+		value := time.Date(2024, 6, day, hour, 0, 0, 0, tokyo)
+		return &value
+	}
+
+	t.Run("nothing scheduled", func(t *testing.T) {
+		today := NewToday(nil, now, tokyo)
+		assert.Equal(t, "今日の予定はありません。", today.Summary())
+	})
+
+	t.Run("lists open tasks by section", func(t *testing.T) {
+		due := newTodayTask("due", at(4, 18))
+		due.Title = "資料作成"
+		done := newTodayTask("done", at(4, 9))
+		done.Status = TaskStatusDone
+		overdue := newTodayTask("overdue", at(3, 12))
+		overdue.Title = "経費精算"
+		// 今日が期限かつ今日着手予定のタスクは期限の区分でだけ読み上げる
+		due.SetStartDate(at(4, 10))
+		starting := newTodayTask("starting", at(10, 12))
+		starting.Title = "設計レビュー"
+		starting.SetStartDate(at(4, 10))
+
+		today := NewToday([]*Task{due, done, overdue, starting}, now, tokyo)
+		today.UnreadNotificationCount = 3
+
+		assert.Equal(t,
+			"今日が期限のタスクが1件、期限切れのタスクが1件、今日着手予定のタスクが2件あります。未読の通知が3件あります。"+
+				"\n・経費精算（期限切れ）\n・資料作成（18:00まで）\n・設計レビュー（着手予定）",
+			today.Summary())
+	})
+
+	t.Run("limits the number of tasks read out", func(t *testing.T) {
+		var tasks []*Task
+		for i := 0; i < QuickSummaryMaxTasks+2; i++ {
+			tasks = append(tasks, newTodayTask(fmt.Sprintf("task-%d", i), at(4, 9+i)))
+		}
+
+		summary := NewToday(tasks, now, tokyo).Summary()
+		assert.Contains(t, summary, "今日が期限のタスクが7件あります。")
+		assert.Contains(t, summary, "・task-4（13:00まで）")
+		assert.NotContains(t, summary, "task-5")
+		assert.Contains(t, summary, "\nほか2件")
+	})
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReminderKind は勤務時間外のため保留した通知の種類
+type ReminderKind string
+
+const (
+	ReminderKindDueSoon    ReminderKind = "DUE_SOON"   // 期限間近
+	ReminderKindStart      ReminderKind = "START"      // 着手予定
+	ReminderKindEscalation ReminderKind = "ESCALATION" // エスカレーション
+)
+
+// reminderKindLabels はまとめた通知に表示する種類の名前
+var reminderKindLabels = map[ReminderKind]string{
+	ReminderKindDueSoon:    "期限間近",
+	ReminderKindStart:      "着手予定",
+	ReminderKindEscalation: "エスカレーション",
+}
+
+// Label は種類の表示名を返す
+func (k ReminderKind) Label() string {
+	if label, ok := reminderKindLabels[k]; ok {
+		return label
+	}
+	return string(k)
+}
+
+// DeferredReminder は通知先の勤務時間外のため、次の勤務時間の開始時まで保留した通知
+// 同じユーザー・タスク・種類の通知は1件にまとめる（後から保留した通知で置き換える）
+type DeferredReminder struct {
+	ID        string       `json:"id"`
+	UserID    string       `json:"user_id"`
+	TaskID    string       `json:"task_id"`
+	TaskTitle string       `json:"task_title"`
+	Kind      ReminderKind `json:"kind"`
+	DeliverAt time.Time    `json:"deliver_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// NewDeferredReminder はタスクの通知を保留する（送る日時は DeliverAt に設定する）
+func NewDeferredReminder(userID string, task *Task, kind ReminderKind) *DeferredReminder {
+	return &DeferredReminder{
+		UserID:    userID,
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+		Kind:      kind,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ReminderDigest は保留した通知をまとめて送る1件の通知の題名と本文を返す
+// 通知は保留した順に並べる
+func ReminderDigest(reminders []*DeferredReminder) (string, string) {
+	sorted := make([]*DeferredReminder, len(reminders))
+	copy(sorted, reminders)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var b strings.Builder
+	b.WriteString("勤務時間外に届いた通知をまとめてお知らせします。\n")
+	for _, reminder := range sorted {
+		fmt.Fprintf(&b, "\n・%s: 「%s」", reminder.Kind.Label(), reminder.TaskTitle)
+	}
+	return fmt.Sprintf("🔔 勤務時間外の通知（%d件）", len(sorted)), b.String()
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderDigest(t *testing.T) {
+	base := time.Date(2024, 6, 12, 20, 0, 0, 0, time.UTC)
+	reminders := []*DeferredReminder{
+		{TaskTitle: "レビュー", Kind: ReminderKindEscalation, CreatedAt: base.Add(time.Hour)},
+		{TaskTitle: "資料作成", Kind: ReminderKindDueSoon, CreatedAt: base},
+	}
+
+	title, message := ReminderDigest(reminders)
+
+	assert.Equal(t, "🔔 勤務時間外の通知（2件）", title)
+	assert.Equal(t, "勤務時間外に届いた通知をまとめてお知らせします。\n\n・期限間近: 「資料作成」\n・エスカレーション: 「レビュー」", message)
+}
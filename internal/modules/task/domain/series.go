@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// SeriesGranularity は時系列統計の集計単位
+type SeriesGranularity string
+
+const (
+	SeriesGranularityDay   SeriesGranularity = "day"
+	SeriesGranularityWeek  SeriesGranularity = "week" // 月曜日始まり
+	SeriesGranularityMonth SeriesGranularity = "month"
+)
+
+const (
+	// MaxSeriesPoints は時系列統計の点の数の上限
+	MaxSeriesPoints = 366
+	// MaxSeriesRollingWindow は移動平均の区間数の上限
+	MaxSeriesRollingWindow = 30
+)
+
+// ErrInvalidSeriesGranularity は集計単位が不正な場合のエラー
+var ErrInvalidSeriesGranularity = errors.New("invalid series granularity")
+
+// ParseSeriesGranularity は集計単位を検証する（空文字の場合は day）
+func ParseSeriesGranularity(s string) (SeriesGranularity, error) {
+	switch g := SeriesGranularity(s); g {
+	case "":
+		return SeriesGranularityDay, nil
+	case SeriesGranularityDay, SeriesGranularityWeek, SeriesGranularityMonth:
+		return g, nil
+	}
+	return "", ErrInvalidSeriesGranularity
+}
+
+// bucketStart はtを含む区間の開始日時を返す
+func (g SeriesGranularity) bucketStart(t time.Time) time.Time {
+	return ComparisonPeriod(g).periodStart(t)
+}
+
+// shift は区間の開始日時をn区間ずらす
+func (g SeriesGranularity) shift(start time.Time, n int) time.Time {
+	return ComparisonPeriod(g).shift(start, n)
+}
+
+// SeriesRange は[from, to]を区間の境界に広げた範囲[start, end)と点の数を返す
+// fetchFrom は移動平均を先頭の点から計算するために必要な集計の開始日時（window-1区間前）
+func SeriesRange(g SeriesGranularity, from, to time.Time, window int) (fetchFrom, start, end time.Time, points int) {
+	start = g.bucketStart(from)
+	end = g.shift(g.bucketStart(to), 1)
+	for b := start; b.Before(end); b = g.shift(b, 1) {
+		points++
+	}
+	fetchFrom = start
+	if window > 1 {
+		fetchFrom = g.shift(start, -(window - 1))
+	}
+	return fetchFrom, start, end, points
+}
+
+// SeriesRollingAverage は直近window区間（その点を含む）の平均
+type SeriesRollingAverage struct {
+	Completed float64 `json:"completed"`
+	Created   float64 `json:"created"`
+}
+
+// SeriesPoint は時系列統計の1区間
+type SeriesPoint struct {
+	PeriodMetrics
+	RollingAverage *SeriesRollingAverage `json:"rolling_average,omitempty"` // 移動平均を指定した場合のみ
+}
+
+// StatsSeries は期間内の区間ごとの実績
+type StatsSeries struct {
+	Granularity SeriesGranularity `json:"granularity"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Timezone    string            `json:"timezone"`
+	Window      int               `json:"window,omitempty"`
+	Points      []*SeriesPoint    `json:"points"`
+}
+
+// NewStatsSeries は[from, to]を区間の境界に広げ、区間ごとの実績を集計する
+// タスクのない区間も0で埋めて返す。window が2以上の場合は完了数・作成数の移動平均を付ける
+// created・completed・scheduled は SeriesRange の fetchFrom から end までに作成・完了・期限のあるタスク
+// 期限がnowより後の未完了タスクは期限切れに数えない
+func NewStatsSeries(g SeriesGranularity, from, to time.Time, window int, created, completed, scheduled []*Task, now time.Time) *StatsSeries {
+	fetchFrom, start, end, _ := SeriesRange(g, from, to, window)
+
+	var starts []time.Time
+	for b := fetchFrom; b.Before(end); b = g.shift(b, 1) {
+		starts = append(starts, b)
+	}
+	bucketOf := func(t *time.Time) int {
+		if t == nil || t.Before(fetchFrom) || !t.Before(end) {
+			return -1
+		}
+		return sort.Search(len(starts), func(i int) bool { return starts[i].After(*t) }) - 1
+	}
+	group := func(tasks []*Task, at func(*Task) *time.Time) [][]*Task {
+		buckets := make([][]*Task, len(starts))
+		for _, task := range tasks {
+			if i := bucketOf(at(task)); i >= 0 {
+				buckets[i] = append(buckets[i], task)
+			}
+		}
+		return buckets
+	}
+	createdBy := group(created, func(t *Task) *time.Time { return &t.CreatedAt })
+	completedBy := group(completed, func(t *Task) *time.Time { return t.CompletedAt })
+	dueBy := group(scheduled, func(t *Task) *time.Time { return t.DueDate })
+
+	metrics := make([]PeriodMetrics, len(starts))
+	for i, b := range starts {
+		metrics[i] = NewPeriodMetrics(b, g.shift(b, 1).Add(-time.Nanosecond), createdBy[i], completedBy[i], dueBy[i])
+		seen := make(map[string]bool)
+		for _, task := range dueBy[i] {
+			if !seen[task.ID] && task.CompletedAt == nil && task.DueDate.After(now) {
+				metrics[i].Overdue--
+			}
+			seen[task.ID] = true
+		}
+	}
+
+	series := &StatsSeries{
+		Granularity: g,
+		From:        start,
+		To:          end.Add(-time.Nanosecond),
+		Timezone:    start.Location().String(),
+		Points:      []*SeriesPoint{},
+	}
+	if window > 1 {
+		series.Window = window
+	}
+	for i, m := range metrics {
+		if m.From.Before(start) {
+			continue
+		}
+		point := &SeriesPoint{PeriodMetrics: m}
+		if window > 1 {
+			var completedSum, createdSum int
+			for _, w := range metrics[i-window+1 : i+1] {
+				completedSum += w.Completed
+				createdSum += w.Created
+			}
+			point.RollingAverage = &SeriesRollingAverage{
+				Completed: math.Round(float64(completedSum)/float64(window)*10) / 10,
+				Created:   math.Round(float64(createdSum)/float64(window)*10) / 10,
+			}
+		}
+		series.Points = append(series.Points, point)
+	}
+	return series
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeriesGranularity(t *testing.T) {
+	g, err := ParseSeriesGranularity("")
+	require.NoError(t, err)
+	assert.Equal(t, SeriesGranularityDay, g)
+
+	g, err = ParseSeriesGranularity("month")
+	require.NoError(t, err)
+	assert.Equal(t, SeriesGranularityMonth, g)
+
+	_, err = ParseSeriesGranularity("hour")
+	assert.ErrorIs(t, err, ErrInvalidSeriesGranularity)
+}
+
+func TestSeriesRange(t *testing.T) {
+	from := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC) // 水曜
+	to := time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)   // 翌週の木曜
+
+	fetchFrom, start, end, points := SeriesRange(SeriesGranularityWeek, from, to, 3)
+
+	assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, 6, 24, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, 2, points)
+	assert.Equal(t, time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC), fetchFrom)
+
+	_, _, _, points = SeriesRange(SeriesGranularityMonth, from, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), 0)
+	assert.Equal(t, 3, points)
+}
+
+func TestNewStatsSeries(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		v := time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+	now := *at(13, 12)
+
+	done := &Task{ID: "done", CreatedAt: *at(9, 9), DueDate: at(10, 18), CompletedAt: at(10, 10)}
+	late := &Task{ID: "late", CreatedAt: *at(10, 9), DueDate: at(10, 12), CompletedAt: at(12, 10)}
+	open := &Task{ID: "open", CreatedAt: *at(12, 9), DueDate: at(14, 18)}
+
+	t.Run("zero-fills empty buckets", func(t *testing.T) {
+		series := NewStatsSeries(SeriesGranularityDay, *at(10, 0), *at(14, 0), 0,
+			[]*Task{done, late, open}, []*Task{done, late}, []*Task{done, late, open}, now)
+
+		require.Len(t, series.Points, 5)
+		assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), series.Points[0].From)
+		assert.Equal(t, 1, series.Points[0].Created)
+		assert.Equal(t, 1, series.Points[0].Completed)
+		assert.Equal(t, 2, series.Points[0].Due)
+		assert.Equal(t, 1, series.Points[0].Overdue)
+		assert.Equal(t, PeriodMetrics{From: *at(11, 0), To: at(12, 0).Add(-time.Nanosecond)}, series.Points[1].PeriodMetrics)
+		assert.Equal(t, 1, series.Points[2].Completed)
+		assert.Nil(t, series.Points[0].RollingAverage)
+
+		// Open tasks due after now are not overdue yet
+		assert.Equal(t, 1, series.Points[4].Due)
+		assert.Equal(t, 0, series.Points[4].Overdue)
+	})
+
+	t.Run("rolling average includes buckets before from", func(t *testing.T) {
+		series := NewStatsSeries(SeriesGranularityDay, *at(11, 0), *at(12, 0), 2,
+			[]*Task{done, late, open}, []*Task{done, late}, []*Task{done, late, open}, now)
+
+		require.Len(t, series.Points, 2)
+		assert.Equal(t, 2, series.Window)
+		require.NotNil(t, series.Points[0].RollingAverage)
+		assert.Equal(t, 0.5, series.Points[0].RollingAverage.Completed)
+		assert.Equal(t, 0.5, series.Points[0].RollingAverage.Created)
+		assert.Equal(t, 0.5, series.Points[1].RollingAverage.Completed)
+	})
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ShareTargetType は共有リンクの対象の種類を表す型
+type ShareTargetType string
+
+// 共有対象の定数
+const (
+	ShareTargetTask     ShareTargetType = "TASK"      // 単一タスク
+	ShareTargetTaskList ShareTargetType = "TASK_LIST" // フィルタ済みタスク一覧
+)
+
+// 共有リンクの制約
+const (
+	shareTokenBytes        = 32 // 256bitのトークン
+	MinSharePasswordLength = 4
+	MaxSharePasswordLength = 72 // bcryptの上限
+	MaxShareLinkLifetime   = 365 * 24 * time.Hour
+)
+
+// SharePublicPathPrefix は共有リンクを閲覧する公開APIのパス（認証不要）
+const SharePublicPathPrefix = "/api/v1/public/shares/"
+
+// ShareLink はタスクまたはタスク一覧の読み取り専用公開リンクを表す
+type ShareLink struct {
+	ID           string          `json:"id"`
+	Token        string          `json:"token"`
+	OwnerID      string          `json:"owner_id"`
+	TargetType   ShareTargetType `json:"target_type"`
+	TaskID       *string         `json:"task_id,omitempty"` // TASKの場合のみ
+	Filter       *ListFilter     `json:"filter,omitempty"`  // TASK_LISTの場合のみ
+	PasswordHash string          `json:"-"`
+	ExpiresAt    *time.Time      `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time      `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// NewTaskShareLink は単一タスクの共有リンクを作成する
+func NewTaskShareLink(ownerID, taskID string, expiresAt *time.Time) (*ShareLink, error) {
+	link, err := newShareLink(ownerID, ShareTargetTask, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	link.TaskID = &taskID
+	return link, nil
+}
+
+// NewTaskListShareLink はフィルタ済みタスク一覧の共有リンクを作成する
+func NewTaskListShareLink(ownerID string, filter ListFilter, expiresAt *time.Time) (*ShareLink, error) {
+	link, err := newShareLink(ownerID, ShareTargetTaskList, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	link.Filter = &filter
+	return link, nil
+}
+
+func newShareLink(ownerID string, targetType ShareTargetType, expiresAt *time.Time) (*ShareLink, error) {
+	now := time.Now()
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, errors.New("expires_at must be in the future")
+		}
+		if expiresAt.Sub(now) > MaxShareLinkLifetime {
+			return nil, errors.New("expires_at must be within 365 days")
+		}
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareLink{
+		Token:      token,
+		OwnerID:    ownerID,
+		TargetType: targetType,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+	}, nil
+}
+
+// generateShareToken は推測不可能なURLセーフのトークンを生成する
+func generateShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ValidateSharePassword は共有リンクのパスワードを検証する
+func ValidateSharePassword(password string) error {
+	length := len(password)
+	if length < MinSharePasswordLength || length > MaxSharePasswordLength {
+		return fmt.Errorf("password must be between %d and %d characters", MinSharePasswordLength, MaxSharePasswordLength)
+	}
+	return nil
+}
+
+// RequiresPassword はリンクの閲覧にパスワードが必要かを判定する
+func (l *ShareLink) RequiresPassword() bool {
+	return l.PasswordHash != ""
+}
+
+// PublicPath はリンクを閲覧する公開APIのパスを返す
+func (l *ShareLink) PublicPath() string {
+	return SharePublicPathPrefix + l.Token
+}
+
+// IsExpired はリンクが有効期限切れかを判定する
+func (l *ShareLink) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// IsRevoked はリンクが無効化済みかを判定する
+func (l *ShareLink) IsRevoked() bool {
+	return l.RevokedAt != nil
+}
+
+// IsActive はリンクが閲覧可能な状態かを判定する
+func (l *ShareLink) IsActive(now time.Time) bool {
+	return !l.IsRevoked() && !l.IsExpired(now)
+}
+
+// Revoke はリンクを無効化する
+func (l *ShareLink) Revoke() {
+	if l.RevokedAt == nil {
+		now := time.Now()
+		l.RevokedAt = &now
+	}
+}
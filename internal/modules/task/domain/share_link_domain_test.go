@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskShareLink(t *testing.T) {
+	link, err := NewTaskShareLink("owner", "task-1", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, ShareTargetTask, link.TargetType)
+	require.NotNil(t, link.TaskID)
+	assert.Equal(t, "task-1", *link.TaskID)
+	assert.GreaterOrEqual(t, len(link.Token), 43)
+	assert.True(t, link.IsActive(time.Now()))
+	assert.False(t, link.RequiresPassword())
+
+	other, err := NewTaskShareLink("owner", "task-1", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, link.Token, other.Token)
+}
+
+func TestNewShareLink_Expiry(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	_, err := NewTaskShareLink("owner", "task-1", &past)
+	assert.Error(t, err)
+
+	tooFar := time.Now().Add(MaxShareLinkLifetime + time.Hour)
+	_, err = NewTaskListShareLink("owner", ListFilter{}, &tooFar)
+	assert.Error(t, err)
+
+	future := time.Now().Add(time.Hour)
+	link, err := NewTaskListShareLink("owner", ListFilter{}, &future)
+	require.NoError(t, err)
+	assert.True(t, link.IsActive(time.Now()))
+	assert.True(t, link.IsExpired(future))
+}
+
+func TestShareLink_Revoke(t *testing.T) {
+	link, err := NewTaskShareLink("owner", "task-1", nil)
+	require.NoError(t, err)
+
+	link.Revoke()
+	revokedAt := link.RevokedAt
+	link.Revoke()
+
+	assert.True(t, link.IsRevoked())
+	assert.Same(t, revokedAt, link.RevokedAt)
+	assert.False(t, link.IsActive(time.Now()))
+}
+
+func TestValidateSharePassword(t *testing.T) {
+	assert.Error(t, ValidateSharePassword("abc"))
+	assert.NoError(t, ValidateSharePassword("abcd"))
+}
@@ -45,12 +45,26 @@ type Task struct {
 	Status      TaskStatus `json:"status"`
 	Priority    Priority   `json:"priority"`
 	Category    Category   `json:"category"`
-	AssigneeID  *string    `json:"assignee_id,omitempty"`
+	AssigneeID  *string    `json:"assignee_id,omitempty"` // 主担当者（最初の担当者）
 	CreatedBy   string     `json:"created_by"`
+	StartDate   *time.Time `json:"start_date,omitempty"` // 着手予定日時
 	DueDate     *time.Time `json:"due_date,omitempty"`
 	IsOverdue   bool       `json:"is_overdue"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// 見積もりと実績
+	EstimateMinutes *int       `json:"estimate_minutes,omitempty"` // 見積もり工数（分）
+	EstimatePoints  *int       `json:"estimate_points,omitempty"`  // 見積もりポイント
+	ActualMinutes   *int       `json:"actual_minutes,omitempty"`   // 実績工数（分）
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`     // 完了日時
+
+	// 複数担当者
+	Assignees           []*TaskAssignee `json:"assignees"`
+	RequireAllAssignees bool            `json:"require_all_assignees"` // 全担当者の完了でタスク完了とするか
+
+	// 説明から抽出したチェックリスト
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
 }
 
 // ListFilter はタスク一覧取得時のフィルタを表す
@@ -58,10 +72,16 @@ type ListFilter struct {
 	Status      *TaskStatus `json:"status,omitempty"`
 	Priority    *Priority   `json:"priority,omitempty"`
 	Category    *Category   `json:"category,omitempty"`
-	AssigneeID  *string     `json:"assignee_id,omitempty"`
+	AssigneeID  *string     `json:"assignee_id,omitempty"` // いずれかの担当者に一致
 	CreatedBy   *string     `json:"created_by,omitempty"`
 	DueDateFrom *time.Time  `json:"due_date_from,omitempty"`
 	DueDateTo   *time.Time  `json:"due_date_to,omitempty"`
+
+	StartDateFrom *time.Time `json:"start_date_from,omitempty"`
+	StartDateTo   *time.Time `json:"start_date_to,omitempty"`
+
+	// AssigneeIDと併用し、その担当者の完了状態で絞り込む
+	AssigneeCompleted *bool `json:"assignee_completed,omitempty"`
 }
 
 // Pagination はページング情報を表す
@@ -100,20 +120,38 @@ func NewTaskWithDefaults(title, description string, priority Priority, createdBy
 	return NewTask(title, description, priority, CategoryOther, createdBy)
 }
 
-// AssignTo はタスクを特定のユーザーに割り当てる
+// AssignTo はタスクを特定のユーザーに割り当てる（既存の担当者は維持する）
 func (t *Task) AssignTo(userID string) {
-	t.AssigneeID = &userID
-	t.UpdatedAt = time.Now()
-	t.UpdateIsOverdue()
+	t.AddAssignee(userID)
 }
 
 // SetStatus はタスクのステータスを設定する
+// DONEに変更した時点で完了日時を記録し、DONE以外に戻した場合は消去する
 func (t *Task) SetStatus(status TaskStatus) {
+	now := time.Now()
+	if status == TaskStatusDone && t.Status != TaskStatusDone {
+		t.CompletedAt = &now
+	} else if status != TaskStatusDone {
+		t.CompletedAt = nil
+	}
 	t.Status = status
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
 	t.UpdateIsOverdue()
 }
 
+// SetEstimate はタスクの見積もり（分・ポイント）を設定する
+func (t *Task) SetEstimate(minutes, points *int) {
+	t.EstimateMinutes = minutes
+	t.EstimatePoints = points
+	t.UpdatedAt = time.Now()
+}
+
+// RecordActualMinutes は実績工数（分）を記録する
+func (t *Task) RecordActualMinutes(minutes int) {
+	t.ActualMinutes = &minutes
+	t.UpdatedAt = time.Now()
+}
+
 // SetDueDate はタスクの期限を設定する
 func (t *Task) SetDueDate(date time.Time) {
 	t.DueDate = &date
@@ -121,6 +159,17 @@ func (t *Task) SetDueDate(date time.Time) {
 	t.UpdateIsOverdue()
 }
 
+// SetStartDate はタスクの着手予定日時を設定する（nilの場合は消去する）
+func (t *Task) SetStartDate(date *time.Time) {
+	t.StartDate = date
+	t.UpdatedAt = time.Now()
+}
+
+// HasValidSchedule は着手予定日時が期限以前かを判定する（どちらかが未設定の場合は常に有効）
+func (t *Task) HasValidSchedule() bool {
+	return t.StartDate == nil || t.DueDate == nil || !t.StartDate.After(*t.DueDate)
+}
+
 // SetCategory はタスクのカテゴリを設定する
 func (t *Task) SetCategory(category Category) {
 	t.Category = category
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TaskEventType はタスクの変更イベントの種類
+type TaskEventType string
+
+const (
+	TaskEventCreated       TaskEventType = "CREATED"        // 作成（全フィールドを含む）
+	TaskEventFieldChanged  TaskEventType = "FIELD_CHANGED"  // ステータス以外のフィールドの変更
+	TaskEventStatusChanged TaskEventType = "STATUS_CHANGED" // ステータスの変更（付随するフィールドの変更を含む）
+)
+
+// TaskSnapshotInterval はスナップショットを保存するイベント数の間隔
+const TaskSnapshotInterval = 20
+
+// FeatureTaskHistory は変更履歴の再生を公開する機能フラグのキー（変更の記録はフラグに関係なく行う）
+const FeatureTaskHistory = "task_history"
+
+// TaskState は履歴として記録するタスクの状態
+// 変更はフィールド名（JSONのキー）ごとに記録するため、全フィールドを omitempty なしで持つ
+type TaskState struct {
+	Title               string          `json:"title"`
+	Description         string          `json:"description"`
+	Status              TaskStatus      `json:"status"`
+	Priority            Priority        `json:"priority"`
+	Category            Category        `json:"category"`
+	StartDate           *time.Time      `json:"start_date"`
+	DueDate             *time.Time      `json:"due_date"`
+	EstimateMinutes     *int            `json:"estimate_minutes"`
+	EstimatePoints      *int            `json:"estimate_points"`
+	ActualMinutes       *int            `json:"actual_minutes"`
+	CompletedAt         *time.Time      `json:"completed_at"`
+	Assignees           []*TaskAssignee `json:"assignees"`
+	RequireAllAssignees bool            `json:"require_all_assignees"`
+} // @name TaskState
+
+// NewTaskState はタスクの現在の状態を返す
+func NewTaskState(task *Task) *TaskState {
+	assignees := make([]*TaskAssignee, 0, len(task.Assignees))
+	for _, assignee := range task.Assignees {
+		copied := *assignee
+		assignees = append(assignees, &copied)
+	}
+	return &TaskState{
+		Title:               task.Title,
+		Description:         task.Description,
+		Status:              task.Status,
+		Priority:            task.Priority,
+		Category:            task.Category,
+		StartDate:           task.StartDate,
+		DueDate:             task.DueDate,
+		EstimateMinutes:     task.EstimateMinutes,
+		EstimatePoints:      task.EstimatePoints,
+		ActualMinutes:       task.ActualMinutes,
+		CompletedAt:         task.CompletedAt,
+		Assignees:           assignees,
+		RequireAllAssignees: task.RequireAllAssignees,
+	}
+}
+
+// TaskEvent はタスクの変更イベント（追記のみ、IDは保存時に採番する）
+// Changes には変更されたフィールドの変更後の値のみを持つ
+type TaskEvent struct {
+	ID         string                     `json:"id"`
+	TaskID     string                     `json:"task_id"`
+	Sequence   int64                      `json:"sequence"` // タスクごとの1始まりの連番
+	Type       TaskEventType              `json:"type"`
+	ActorID    string                     `json:"actor_id,omitempty"` // 変更したユーザー（システムによる変更の場合は空）
+	Changes    map[string]json.RawMessage `json:"changes" swaggertype:"object"`
+	OccurredAt time.Time                  `json:"occurred_at"`
+} // @name TaskEvent
+
+// TaskSnapshot はあるイベントまでを適用したタスクの状態
+// 再生はスナップショットから開始し、それ以降のイベントのみを適用する
+type TaskSnapshot struct {
+	TaskID   string     `json:"task_id"`
+	Sequence int64      `json:"sequence"`
+	State    *TaskState `json:"state"`
+	TakenAt  time.Time  `json:"taken_at"` // Sequenceのイベントの発生日時
+}
+
+// NewTaskCreatedEvent はタスクの作成イベントを作成する
+func NewTaskCreatedEvent(taskID, actorID string, sequence int64, state *TaskState, occurredAt time.Time) (*TaskEvent, error) {
+	changes, err := diffTaskStates(nil, state)
+	if err != nil {
+		return nil, err
+	}
+	return newTaskEvent(taskID, actorID, sequence, TaskEventCreated, changes, occurredAt), nil
+}
+
+// NewTaskChangeEvent は変更前後の状態の差分から変更イベントを作成する
+// 差分がない場合はnilを返す
+func NewTaskChangeEvent(taskID, actorID string, sequence int64, before, after *TaskState, occurredAt time.Time) (*TaskEvent, error) {
+	changes, err := diffTaskStates(before, after)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	eventType := TaskEventFieldChanged
+	if before.Status != after.Status {
+		eventType = TaskEventStatusChanged
+	}
+	return newTaskEvent(taskID, actorID, sequence, eventType, changes, occurredAt), nil
+}
+
+func newTaskEvent(taskID, actorID string, sequence int64, eventType TaskEventType, changes map[string]json.RawMessage, occurredAt time.Time) *TaskEvent {
+	return &TaskEvent{
+		TaskID:     taskID,
+		Sequence:   sequence,
+		Type:       eventType,
+		ActorID:    actorID,
+		Changes:    changes,
+		OccurredAt: occurredAt,
+	}
+}
+
+// ShouldSnapshot はこのイベントの後にスナップショットを保存するかを判定する
+func (e *TaskEvent) ShouldSnapshot() bool {
+	return e.Sequence%TaskSnapshotInterval == 0
+}
+
+// ReplayTaskEvents はスナップショット（nilの場合は空の状態）に連番順のイベントを適用した状態を返す
+func ReplayTaskEvents(snapshot *TaskSnapshot, events []*TaskEvent) (*TaskState, error) {
+	fields := map[string]json.RawMessage{}
+	if snapshot != nil {
+		var err error
+		if fields, err = stateFields(snapshot.State); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]*TaskEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Sequence < sorted[j].Sequence })
+
+	for _, event := range sorted {
+		for field, value := range event.Changes {
+			fields[field] = value
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task state: %w", err)
+	}
+	var state TaskState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode task state: %w", err)
+	}
+	return &state, nil
+}
+
+// diffTaskStates は変更後の状態のうち変更前と異なるフィールドを返す（変更前がnilの場合は全フィールド）
+func diffTaskStates(before, after *TaskState) (map[string]json.RawMessage, error) {
+	afterFields, err := stateFields(after)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return afterFields, nil
+	}
+	beforeFields, err := stateFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]json.RawMessage{}
+	for field, value := range afterFields {
+		if !bytes.Equal(beforeFields[field], value) {
+			changes[field] = value
+		}
+	}
+	return changes, nil
+}
+
+// stateFields は状態をフィールド名ごとのJSONに分解する
+func stateFields(state *TaskState) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task state: %w", err)
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode task state: %w", err)
+	}
+	return fields, nil
+}
+
+// TaskHistory はある時点に再生したタスクの状態
+type TaskHistory struct {
+	TaskID    string     `json:"task_id"`
+	At        time.Time  `json:"at"`
+	Sequence  int64      `json:"sequence"` // 適用した最後のイベントの連番
+	State     *TaskState `json:"state"`
+	LastEvent *TaskEvent `json:"last_event"` // 最後に適用したイベント（誰がいつこの状態にしたか）
+} // @name TaskHistory
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskChangeEvent(t *testing.T) {
+	task := NewTask("Write report", "", PriorityMedium, CategoryWork, "user-1")
+	before := NewTaskState(task)
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("no changes", func(t *testing.T) {
+		event, err := NewTaskChangeEvent("task-1", "user-1", 2, before, NewTaskState(task), now)
+
+		require.NoError(t, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("field change only records changed fields", func(t *testing.T) {
+		changed := *task
+		changed.Title = "Write final report"
+		changed.SetDueDate(now.Add(24 * time.Hour))
+
+		event, err := NewTaskChangeEvent("task-1", "user-2", 2, before, NewTaskState(&changed), now)
+
+		require.NoError(t, err)
+		require.NotNil(t, event)
+		assert.Equal(t, TaskEventFieldChanged, event.Type)
+		assert.Equal(t, "user-2", event.ActorID)
+		assert.Len(t, event.Changes, 2)
+		assert.JSONEq(t, `"Write final report"`, string(event.Changes["title"]))
+		assert.Contains(t, event.Changes, "due_date")
+	})
+
+	t.Run("status change", func(t *testing.T) {
+		changed := *task
+		changed.SetStatus(TaskStatusDone)
+
+		event, err := NewTaskChangeEvent("task-1", "user-2", 2, before, NewTaskState(&changed), now)
+
+		require.NoError(t, err)
+		require.NotNil(t, event)
+		assert.Equal(t, TaskEventStatusChanged, event.Type)
+		assert.JSONEq(t, `"DONE"`, string(event.Changes["status"]))
+		assert.Contains(t, event.Changes, "completed_at", "side effects of the status change are recorded together")
+	})
+}
+
+func TestReplayTaskEvents(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	task := NewTask("Write report", "draft", PriorityLow, CategoryWork, "user-1")
+	created, err := NewTaskCreatedEvent("task-1", "user-1", 1, NewTaskState(task), now)
+	require.NoError(t, err)
+
+	renamed := *task
+	renamed.Title = "Write final report"
+	rename, err := NewTaskChangeEvent("task-1", "user-2", 2, NewTaskState(task), NewTaskState(&renamed), now.Add(time.Hour))
+	require.NoError(t, err)
+
+	done := renamed
+	done.SetStatus(TaskStatusDone)
+	complete, err := NewTaskChangeEvent("task-1", "user-2", 3, NewTaskState(&renamed), NewTaskState(&done), now.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	t.Run("replays events in sequence order", func(t *testing.T) {
+		state, err := ReplayTaskEvents(nil, []*TaskEvent{complete, created, rename})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Write final report", state.Title)
+		assert.Equal(t, "draft", state.Description)
+		assert.Equal(t, TaskStatusDone, state.Status)
+		assert.NotNil(t, state.CompletedAt)
+	})
+
+	t.Run("partial replay", func(t *testing.T) {
+		state, err := ReplayTaskEvents(nil, []*TaskEvent{created, rename})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Write final report", state.Title)
+		assert.Equal(t, TaskStatusTodo, state.Status)
+		assert.Nil(t, state.CompletedAt)
+	})
+
+	t.Run("starts from snapshot", func(t *testing.T) {
+		snapshot := &TaskSnapshot{TaskID: "task-1", Sequence: 2, State: NewTaskState(&renamed), TakenAt: now.Add(time.Hour)}
+
+		state, err := ReplayTaskEvents(snapshot, []*TaskEvent{complete})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Write final report", state.Title)
+		assert.Equal(t, TaskStatusDone, state.Status)
+	})
+}
+
+func TestTaskEvent_ShouldSnapshot(t *testing.T) {
+	assert.False(t, (&TaskEvent{Sequence: 1}).ShouldSnapshot())
+	assert.True(t, (&TaskEvent{Sequence: TaskSnapshotInterval}).ShouldSnapshot())
+	assert.True(t, (&TaskEvent{Sequence: 2 * TaskSnapshotInterval}).ShouldSnapshot())
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TaskLinkType はタスクのリンクの種類
+type TaskLinkType string
+
+const (
+	// ユーザーが追加するリンク
+	TaskLinkTypeLink     TaskLinkType = "LINK"
+	TaskLinkTypeDocument TaskLinkType = "DOCUMENT"
+	TaskLinkTypeDesign   TaskLinkType = "DESIGN"
+
+	// 連携が管理するリンク（種類・URL・タイトルは変更できない）
+	TaskLinkTypeGitHubIssue       TaskLinkType = "GITHUB_ISSUE"
+	TaskLinkTypeGitHubPullRequest TaskLinkType = "GITHUB_PULL_REQUEST"
+	TaskLinkTypeCalendarEvent     TaskLinkType = "CALENDAR_EVENT"
+)
+
+const (
+	// MaxTaskLinksPerTask は1つのタスクにユーザーが追加できるリンクの上限（連携のリンクは含めない）
+	MaxTaskLinksPerTask = 50
+	// MaxTaskLinkURLLength はリンクのURLの最大長
+	MaxTaskLinkURLLength = 2048
+	// MaxTaskLinkTitleLength はリンクのタイトルの最大文字数
+	MaxTaskLinkTitleLength = 255
+)
+
+// ErrInvalidTaskLink はリンクの種類・URL・タイトルが正しくないことを表すエラー
+var ErrInvalidTaskLink = errors.New("invalid task link")
+
+// IsValid はユーザーが追加できる種類か
+func (t TaskLinkType) IsValid() bool {
+	switch t {
+	case TaskLinkTypeLink, TaskLinkTypeDocument, TaskLinkTypeDesign:
+		return true
+	}
+	return false
+}
+
+// IsGitHub はGitHubのIssue・Pull Requestの種類か
+func (t TaskLinkType) IsGitHub() bool {
+	return t == TaskLinkTypeGitHubIssue || t == TaskLinkTypeGitHubPullRequest
+}
+
+// TaskLink はタスクに関連付けた外部のURL（ユーザーが追加したリンクと、GitHub・予定の連携のリンク）
+type TaskLink struct {
+	ID     string       `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID string       `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Type   TaskLinkType `json:"type" example:"DOCUMENT" enums:"LINK,DOCUMENT,DESIGN,GITHUB_ISSUE,GITHUB_PULL_REQUEST,CALENDAR_EVENT"`
+	// URL は外部のURL（予定の場合はアプリ内のパス）
+	URL   string `json:"url" example:"https://docs.example.com/spec"`
+	Title string `json:"title" example:"仕様書"`
+	// Managed は連携が管理するリンクか（変更できず、予定のリンクは予定の画面から解除する）
+	Managed bool `json:"managed" example:"false"`
+	// State は連携先の状態（GitHubのIssue・Pull Requestの OPEN・MERGED など、予定の BLOCK・FOLLOW_UP）
+	State     string    `json:"state,omitempty" example:"OPEN"`
+	CreatedBy string    `json:"created_by,omitempty" example:"123e4567-e89b-12d3-a456-426614174002"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name TaskLink
+
+// NewTaskLink は種類・URL・タイトルをチェックしてユーザーのリンクを作成する（種類が空の場合は LINK）
+func NewTaskLink(taskID string, linkType TaskLinkType, rawURL, title, createdBy string, now time.Time) (*TaskLink, error) {
+	link := &TaskLink{
+		TaskID:    taskID,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if linkType == "" {
+		linkType = TaskLinkTypeLink
+	}
+	if err := link.Update(linkType, rawURL, title, now); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// Update はユーザーのリンクの種類・URL・タイトルをチェックして変更する
+func (l *TaskLink) Update(linkType TaskLinkType, rawURL, title string, now time.Time) error {
+	if !linkType.IsValid() {
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidTaskLink, linkType)
+	}
+	normalized, err := normalizeTaskLinkURL(rawURL)
+	if err != nil {
+		return err
+	}
+	title = strings.TrimSpace(title)
+	if len([]rune(title)) > MaxTaskLinkTitleLength {
+		return fmt.Errorf("%w: title must be at most %d characters", ErrInvalidTaskLink, MaxTaskLinkTitleLength)
+	}
+
+	l.Type = linkType
+	l.URL = normalized
+	l.Title = title
+	l.UpdatedAt = now
+	return nil
+}
+
+// normalizeTaskLinkURL は前後の空白を除き、ホストのあるhttp・httpsのURLかを確認する
+func normalizeTaskLinkURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > MaxTaskLinkURLLength {
+		return "", fmt.Errorf("%w: url must be 1 to %d characters", ErrInvalidTaskLink, MaxTaskLinkURLLength)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: url must be an http or https URL", ErrInvalidTaskLink)
+	}
+	return rawURL, nil
+}
+
+// ToTaskLink はIssue・Pull Requestの関連付けをタスクのリンクとして返す
+func (l *IssueLink) ToTaskLink() *TaskLink {
+	linkType := TaskLinkTypeGitHubIssue
+	if l.Kind == IssueKindPullRequest {
+		linkType = TaskLinkTypeGitHubPullRequest
+	}
+	return &TaskLink{
+		ID:        l.ID,
+		TaskID:    l.TaskID,
+		Type:      linkType,
+		URL:       l.URL,
+		Title:     l.Title,
+		Managed:   true,
+		State:     string(l.State),
+		CreatedBy: l.CreatedBy,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
+
+// ToTaskLink はグループの予定との関連付けを taskID のタスクのリンクとして返す
+// タスクを予定とする関連付け（予定から見たフォローアップなど）は外部のリンクではないため false を返す
+func (l *TaskEventLink) ToTaskLink(taskID string) (*TaskLink, bool) {
+	if l.TaskID != taskID {
+		return nil, false
+	}
+	return &TaskLink{
+		ID:        l.EventID,
+		TaskID:    taskID,
+		Type:      TaskLinkTypeCalendarEvent,
+		URL:       fmt.Sprintf("/groups/%s/events/%s", l.GroupID, l.EventID),
+		Managed:   true,
+		State:     l.Kind,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.CreatedAt,
+	}, true
+}
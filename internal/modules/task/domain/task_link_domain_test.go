@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskLink(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("defaults to LINK and trims input", func(t *testing.T) {
+		link, err := NewTaskLink("task-1", "", " https://docs.example.com/spec ", " 仕様書 ", "user-1", now)
+		require.NoError(t, err)
+		assert.Equal(t, TaskLinkTypeLink, link.Type)
+		assert.Equal(t, "https://docs.example.com/spec", link.URL)
+		assert.Equal(t, "仕様書", link.Title)
+		assert.False(t, link.Managed)
+		assert.Equal(t, now, link.CreatedAt)
+		assert.Equal(t, now, link.UpdatedAt)
+	})
+
+	tests := []struct {
+		name     string
+		linkType TaskLinkType
+		url      string
+		title    string
+	}{
+		{name: "integration type", linkType: TaskLinkTypeGitHubIssue, url: "https://github.com/octo/app/issues/1"},
+		{name: "unknown type", linkType: "VIDEO", url: "https://example.com"},
+		{name: "empty url", linkType: TaskLinkTypeLink, url: " "},
+		{name: "relative url", linkType: TaskLinkTypeLink, url: "/tasks/1"},
+		{name: "other scheme", linkType: TaskLinkTypeLink, url: "javascript:alert(1)"},
+		{name: "missing host", linkType: TaskLinkTypeLink, url: "https:///path"},
+		{name: "too long url", linkType: TaskLinkTypeLink, url: "https://example.com/" + strings.Repeat("a", MaxTaskLinkURLLength)},
+		{name: "too long title", linkType: TaskLinkTypeDesign, url: "https://example.com", title: strings.Repeat("あ", MaxTaskLinkTitleLength+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTaskLink("task-1", tt.linkType, tt.url, tt.title, "user-1", now)
+			assert.ErrorIs(t, err, ErrInvalidTaskLink)
+		})
+	}
+}
+
+func TestIssueLink_ToTaskLink(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	link := NewIssueLink("task-1", &IssueInfo{
+		Ref:   IssueRef{Repository: "octo/app", Number: 42},
+		Kind:  IssueKindPullRequest,
+		Title: "Add search",
+		State: IssueStateMerged,
+		URL:   "https://github.com/octo/app/pull/42",
+	}, true, "user-1", now)
+	link.ID = "link-1"
+
+	got := link.ToTaskLink()
+	assert.Equal(t, "link-1", got.ID)
+	assert.Equal(t, TaskLinkTypeGitHubPullRequest, got.Type)
+	assert.Equal(t, "https://github.com/octo/app/pull/42", got.URL)
+	assert.Equal(t, "MERGED", got.State)
+	assert.True(t, got.Managed)
+
+	link.Kind = IssueKindIssue
+	assert.Equal(t, TaskLinkTypeGitHubIssue, link.ToTaskLink().Type)
+}
+
+func TestTaskEventLink_ToTaskLink(t *testing.T) {
+	link := &TaskEventLink{GroupID: "group-1", EventID: "event-1", TaskID: "task-1", Kind: "BLOCK"}
+
+	got, ok := link.ToTaskLink("task-1")
+	require.True(t, ok)
+	assert.Equal(t, TaskLinkTypeCalendarEvent, got.Type)
+	assert.Equal(t, "/groups/group-1/events/event-1", got.URL)
+	assert.Equal(t, "BLOCK", got.State)
+	assert.True(t, got.Managed)
+
+	// 予定のタスクから見た関連付けはリンクにしない
+	_, ok = link.ToTaskLink("event-1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TaskDependency はタスク間の依存関係（DependsOnID の完了後に TaskID を開始する）を表す
+type TaskDependency struct {
+	TaskID      string    `json:"task_id"`
+	DependsOnID string    `json:"depends_on_id"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewTaskDependency は新しい依存関係を作成する
+func NewTaskDependency(taskID, dependsOnID, createdBy string) *TaskDependency {
+	return &TaskDependency{
+		TaskID:      taskID,
+		DependsOnID: dependsOnID,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// TimelineTask はガントチャート表示用のタスクを表す
+// 期限のないタスクは期間が決まらないため、クリティカルパスの計算では所要時間0として扱い SlackHours は nil になる
+type TimelineTask struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Status      TaskStatus `json:"status"`
+	Priority    Priority   `json:"priority"`
+	AssigneeIDs []string   `json:"assignee_ids"`
+	StartDate   time.Time  `json:"start_date"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	MilestoneID string     `json:"milestone_id,omitempty"`
+	DependsOn   []string   `json:"depends_on"`
+	Critical    bool       `json:"critical"`              // クリティカルパス上のタスクか
+	SlackHours  *float64   `json:"slack_hours,omitempty"` // 全体の完了を遅らせずに遅延できる時間
+}
+
+// Timeline はグループのガントチャート表示用データを表す
+type Timeline struct {
+	GroupID      string          `json:"group_id"`
+	From         *time.Time      `json:"from,omitempty"`
+	To           *time.Time      `json:"to,omitempty"`
+	Tasks        []*TimelineTask `json:"tasks"`
+	Milestones   []*Milestone    `json:"milestones"`
+	CriticalPath []string        `json:"critical_path"` // クリティカルパス上のタスクID（最早開始順）
+	Truncated    bool            `json:"truncated"`     // タスク数が上限を超えたため一部のみ返しているか
+}
+
+// TaskStartDate はタイムラインでのタスクの開始日時を返す
+// 着手予定日時が未設定の場合は作成日時（期限が作成日時より前の場合は期限）とする
+func TaskStartDate(task *Task) time.Time {
+	if task.StartDate != nil {
+		return *task.StartDate
+	}
+	if task.DueDate != nil && task.DueDate.Before(task.CreatedAt) {
+		return *task.DueDate
+	}
+	return task.CreatedAt
+}
+
+// NewTimeline はタスク・依存関係・マイルストーンからタイムラインを作成し、クリティカルパスを計算する
+// milestoneIDs はタスクIDからマイルストーンIDへの対応で、tasks に含まれないタスクへの依存関係は無視する
+func NewTimeline(groupID string, tasks []*Task, dependencies []*TaskDependency, milestoneIDs map[string]string, milestones []*Milestone) *Timeline {
+	timeline := &Timeline{
+		GroupID:      groupID,
+		Tasks:        make([]*TimelineTask, 0, len(tasks)),
+		Milestones:   milestones,
+		CriticalPath: []string{},
+	}
+	if timeline.Milestones == nil {
+		timeline.Milestones = []*Milestone{}
+	}
+
+	byID := make(map[string]*TimelineTask, len(tasks))
+	for _, task := range tasks {
+		item := &TimelineTask{
+			ID:          task.ID,
+			Title:       task.Title,
+			Status:      task.Status,
+			Priority:    task.Priority,
+			AssigneeIDs: task.AssigneeIDs(),
+			StartDate:   TaskStartDate(task),
+			DueDate:     task.DueDate,
+			MilestoneID: milestoneIDs[task.ID],
+			DependsOn:   []string{},
+		}
+		if item.AssigneeIDs == nil {
+			item.AssigneeIDs = []string{}
+		}
+		timeline.Tasks = append(timeline.Tasks, item)
+		byID[task.ID] = item
+		timeline.extend(item.StartDate)
+		if item.DueDate != nil {
+			timeline.extend(*item.DueDate)
+		}
+	}
+	for _, milestone := range timeline.Milestones {
+		timeline.extend(milestone.DueDate)
+	}
+
+	for _, dependency := range dependencies {
+		item, ok := byID[dependency.TaskID]
+		if !ok || byID[dependency.DependsOnID] == nil || containsID(item.DependsOn, dependency.DependsOnID) {
+			continue
+		}
+		item.DependsOn = append(item.DependsOn, dependency.DependsOnID)
+	}
+
+	timeline.markCriticalPath()
+	return timeline
+}
+
+func (t *Timeline) extend(at time.Time) {
+	if t.From == nil || at.Before(*t.From) {
+		from := at
+		t.From = &from
+	}
+	if t.To == nil || at.After(*t.To) {
+		to := at
+		t.To = &to
+	}
+}
+
+// markCriticalPath は依存関係と各タスクの期間（開始から期限まで）から最早・最遅開始を求め、余裕のないタスクをクリティカルとする
+// 循環した依存関係に含まれるタスクは計算から除外する
+func (t *Timeline) markCriticalPath() {
+	order := t.topologicalOrder()
+	if len(order) == 0 {
+		return
+	}
+
+	successors := make(map[string][]*TimelineTask)
+	for _, item := range order {
+		for _, dependsOnID := range item.DependsOn {
+			successors[dependsOnID] = append(successors[dependsOnID], item)
+		}
+	}
+
+	// 前進計算（最早開始・最早終了）
+	earliestStart := make(map[string]time.Duration, len(order))
+	earliestFinish := make(map[string]time.Duration, len(order))
+	var projectEnd time.Duration
+	for _, item := range order {
+		var start time.Duration
+		for _, dependsOnID := range item.DependsOn {
+			if finish := earliestFinish[dependsOnID]; finish > start {
+				start = finish
+			}
+		}
+		earliestStart[item.ID] = start
+		earliestFinish[item.ID] = start + timelineDuration(item)
+		if earliestFinish[item.ID] > projectEnd {
+			projectEnd = earliestFinish[item.ID]
+		}
+	}
+
+	// 後退計算（最遅開始）
+	latestStart := make(map[string]time.Duration, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		item := order[i]
+		finish := projectEnd
+		for _, successor := range successors[item.ID] {
+			if latestStart[successor.ID] < finish {
+				finish = latestStart[successor.ID]
+			}
+		}
+		latestStart[item.ID] = finish - timelineDuration(item)
+	}
+
+	var critical []*TimelineTask
+	for _, item := range order {
+		if item.DueDate == nil {
+			continue
+		}
+		slack := latestStart[item.ID] - earliestStart[item.ID]
+		hours := math.Round(slack.Hours()*10) / 10
+		item.SlackHours = &hours
+		if slack < time.Minute {
+			item.Critical = true
+			critical = append(critical, item)
+		}
+	}
+
+	sort.SliceStable(critical, func(i, j int) bool {
+		if earliestStart[critical[i].ID] != earliestStart[critical[j].ID] {
+			return earliestStart[critical[i].ID] < earliestStart[critical[j].ID]
+		}
+		return critical[i].StartDate.Before(critical[j].StartDate)
+	})
+	for _, item := range critical {
+		t.CriticalPath = append(t.CriticalPath, item.ID)
+	}
+}
+
+// topologicalOrder は依存先が先になるようにタスクを並べる（循環に含まれるタスクは含まない）
+func (t *Timeline) topologicalOrder() []*TimelineTask {
+	remaining := make(map[string]int, len(t.Tasks))
+	successors := make(map[string][]*TimelineTask)
+	var queue []*TimelineTask
+	for _, item := range t.Tasks {
+		remaining[item.ID] = len(item.DependsOn)
+		for _, dependsOnID := range item.DependsOn {
+			successors[dependsOnID] = append(successors[dependsOnID], item)
+		}
+		if len(item.DependsOn) == 0 {
+			queue = append(queue, item)
+		}
+	}
+
+	order := make([]*TimelineTask, 0, len(t.Tasks))
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+		order = append(order, item)
+		for _, successor := range successors[item.ID] {
+			remaining[successor.ID]--
+			if remaining[successor.ID] == 0 {
+				queue = append(queue, successor)
+			}
+		}
+	}
+	return order
+}
+
+// timelineDuration はタスクの期間を返す（期限のないタスクは0）
+func timelineDuration(item *TimelineTask) time.Duration {
+	if item.DueDate == nil || item.DueDate.Before(item.StartDate) {
+		return 0
+	}
+	return item.DueDate.Sub(item.StartDate)
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTimelineTask(id string, start time.Time, days int) *Task {
+	task := NewTask(id, "", PriorityMedium, CategoryWork, "user-1")
+	task.ID = id
+	task.CreatedAt = start
+	if days >= 0 {
+		task.SetDueDate(start.AddDate(0, 0, days))
+	}
+	return task
+}
+
+func TestNewTimeline_CriticalPath(t *testing.T) {
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+
+	// design(2d) → build(5d) → release(1d)
+	//          └→ docs(2d) ───┘
+	tasks := []*Task{
+		newTimelineTask("design", start, 2),
+		newTimelineTask("build", start, 5),
+		newTimelineTask("docs", start, 2),
+		newTimelineTask("release", start, 1),
+		newTimelineTask("someday", start, -1),
+	}
+	dependencies := []*TaskDependency{
+		NewTaskDependency("build", "design", "user-1"),
+		NewTaskDependency("docs", "design", "user-1"),
+		NewTaskDependency("release", "build", "user-1"),
+		NewTaskDependency("release", "docs", "user-1"),
+		NewTaskDependency("release", "outside", "user-1"), // not part of the timeline
+	}
+	milestone := NewMilestone("group-1", "GA", start.AddDate(0, 0, 10), 0, "user-1")
+	milestone.ID = "m1"
+
+	timeline := NewTimeline("group-1", tasks, dependencies, map[string]string{"release": "m1"}, []*Milestone{milestone})
+
+	assert.Equal(t, []string{"design", "build", "release"}, timeline.CriticalPath)
+	byID := make(map[string]*TimelineTask)
+	for _, task := range timeline.Tasks {
+		byID[task.ID] = task
+	}
+
+	assert.True(t, byID["build"].Critical)
+	assert.False(t, byID["docs"].Critical)
+	require.NotNil(t, byID["docs"].SlackHours)
+	assert.Equal(t, float64(72), *byID["docs"].SlackHours)
+	assert.Equal(t, []string{"build", "docs"}, byID["release"].DependsOn)
+	assert.Equal(t, "m1", byID["release"].MilestoneID)
+
+	assert.False(t, byID["someday"].Critical, "tasks without a due date are never critical")
+	assert.Nil(t, byID["someday"].SlackHours)
+
+	require.NotNil(t, timeline.From)
+	require.NotNil(t, timeline.To)
+	assert.Equal(t, start, *timeline.From)
+	assert.Equal(t, milestone.DueDate, *timeline.To)
+}
+
+func TestNewTimeline_IgnoresCycles(t *testing.T) {
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	tasks := []*Task{
+		newTimelineTask("a", start, 1),
+		newTimelineTask("b", start, 1),
+		newTimelineTask("c", start, 3),
+	}
+	dependencies := []*TaskDependency{
+		NewTaskDependency("a", "b", "user-1"),
+		NewTaskDependency("b", "a", "user-1"),
+	}
+
+	timeline := NewTimeline("group-1", tasks, dependencies, nil, nil)
+
+	assert.Equal(t, []string{"c"}, timeline.CriticalPath)
+	assert.Empty(t, timeline.Milestones)
+}
+
+func TestTaskStartDate(t *testing.T) {
+	created := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	start := created.AddDate(0, 0, 2)
+
+	task := newTimelineTask("a", created, 5)
+	assert.Equal(t, created, TaskStartDate(task))
+
+	task.SetStartDate(&start)
+	assert.Equal(t, start, TaskStartDate(task))
+
+	overdue := newTimelineTask("b", created, -1)
+	overdue.SetDueDate(created.AddDate(0, 0, -1))
+	assert.Equal(t, *overdue.DueDate, TaskStartDate(overdue), "due dates before creation start the bar at the due date")
+}
+
+func TestNewTimeline_Empty(t *testing.T) {
+	timeline := NewTimeline("group-1", nil, nil, nil, nil)
+
+	assert.Empty(t, timeline.Tasks)
+	assert.Empty(t, timeline.CriticalPath)
+	assert.Nil(t, timeline.From)
+	assert.Nil(t, timeline.To)
+}
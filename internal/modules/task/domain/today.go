@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// TodayCarryoverDays は今日の予定に含める期限切れタスクをさかのぼる日数
+const TodayCarryoverDays = 30
+
+// Today はホーム画面に表示する今日の予定をまとめたものを表す
+type Today struct {
+	Date     string `json:"date"` // "2006-01-02"
+	Timezone string `json:"timezone"`
+
+	DueToday      []*Task `json:"due_today"`      // 今日が期限のタスク（完了済みを含む）
+	Overdue       []*Task `json:"overdue"`        // 前日までに期限を過ぎた未完了のタスク
+	StartingToday []*Task `json:"starting_today"` // 今日着手予定の未完了のタスク
+
+	UnreadNotificationCount int `json:"unread_notification_count"`
+}
+
+// NewToday はタスクを今日が期限・期限切れ・今日着手予定に振り分ける
+// 日の区切りはlocで判定し、期限切れはTodayCarryoverDays日前までのものに限る
+func NewToday(tasks []*Task, now time.Time, loc *time.Location) *Today {
+	local := now.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	carryoverStart := dayStart.AddDate(0, 0, -TodayCarryoverDays)
+
+	today := &Today{
+		Date:          dayStart.Format("2006-01-02"),
+		Timezone:      loc.String(),
+		DueToday:      []*Task{},
+		Overdue:       []*Task{},
+		StartingToday: []*Task{},
+	}
+
+	within := func(at *time.Time, from, to time.Time) bool {
+		return at != nil && !at.Before(from) && at.Before(to)
+	}
+	for _, task := range tasks {
+		done := task.Status == TaskStatusDone
+		switch {
+		case within(task.DueDate, dayStart, dayEnd):
+			today.DueToday = append(today.DueToday, task)
+		case within(task.DueDate, carryoverStart, dayStart) && !done:
+			today.Overdue = append(today.Overdue, task)
+		}
+		if within(task.StartDate, dayStart, dayEnd) && !done {
+			today.StartingToday = append(today.StartingToday, task)
+		}
+	}
+
+	sortByTime := func(tasks []*Task, at func(*Task) time.Time) {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if !at(tasks[i]).Equal(at(tasks[j])) {
+				return at(tasks[i]).Before(at(tasks[j]))
+			}
+			return tasks[i].ID < tasks[j].ID
+		})
+	}
+	dueDate := func(task *Task) time.Time { return *task.DueDate }
+	sortByTime(today.DueToday, dueDate)
+	sortByTime(today.Overdue, dueDate)
+	sortByTime(today.StartingToday, func(task *Task) time.Time { return *task.StartDate })
+
+	return today
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTodayTask(id string, due *time.Time) *Task {
+	task := NewTask(id, "", PriorityMedium, CategoryWork, "user-1")
+	task.ID = id
+	if due != nil {
+		task.SetDueDate(*due)
+	}
+	return task
+}
+
+func TestNewToday(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	// 2024-06-03 23:30 UTC is 2024-06-04 08:30 in Tokyo
+	now := time.Date(2024, 6, 3, 23, 30, 0, 0, time.UTC)
+
+	at := func(day, hour int) *time.Time {
+		value := time.Date(2024, 6, day, hour, 0, 0, 0, tokyo)
+		return &value
+	}
+
+	dueEvening := newTodayTask("due-evening", at(4, 18))
+	dueMorning := newTodayTask("due-morning", at(4, 9))
+	dueDone := newTodayTask("due-done", at(4, 12))
+	dueDone.Status = TaskStatusDone
+	overdue := newTodayTask("overdue", at(3, 23))
+	overdueDone := newTodayTask("overdue-done", at(2, 12))
+	overdueDone.Status = TaskStatusDone
+	stale := newTodayTask("stale", at(4-TodayCarryoverDays-1, 12))
+	starting := newTodayTask("starting", at(10, 12))
+	starting.SetStartDate(at(4, 10))
+	tomorrow := newTodayTask("tomorrow", at(5, 0))
+
+	today := NewToday([]*Task{dueEvening, dueMorning, dueDone, overdue, overdueDone, stale, starting, tomorrow}, now, tokyo)
+
+	assert.Equal(t, "2024-06-04", today.Date)
+	assert.Equal(t, "Asia/Tokyo", today.Timezone)
+	assert.Equal(t, []string{"due-morning", "due-done", "due-evening"}, todayIDs(today.DueToday))
+	assert.Equal(t, []string{"overdue"}, todayIDs(today.Overdue), "done and long-overdue tasks are not carried over")
+	assert.Equal(t, []string{"starting"}, todayIDs(today.StartingToday))
+}
+
+func TestNewToday_Empty(t *testing.T) {
+	today := NewToday(nil, time.Now(), time.UTC)
+
+	assert.NotNil(t, today.DueToday, "empty sections are returned as empty lists")
+	assert.NotNil(t, today.Overdue)
+	assert.NotNil(t, today.StartingToday)
+}
+
+func todayIDs(tasks []*Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
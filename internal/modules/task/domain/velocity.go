@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// VelocityStats は完了実績と見積もり精度の集計値を表す
+type VelocityStats struct {
+	CompletedTasks   int     `json:"completed_tasks"`
+	CompletedPoints  int     `json:"completed_points"`
+	EstimatedMinutes int     `json:"estimated_minutes"` // 見積もり（分）が設定された完了タスクの見積もり合計
+	ActualMinutes    int     `json:"actual_minutes"`    // 見積もりと実績の両方があるタスクの実績合計
+	MeasuredTasks    int     `json:"measured_tasks"`    // 見積もりと実績の両方があるタスク数
+	EstimateRatio    float64 `json:"estimate_ratio"`    // 実績/見積もり（1より大きいと見積もり不足）
+	AccuracyRate     float64 `json:"accuracy_rate"`     // 見積もり精度（0-100）
+}
+
+// WeeklyVelocity は週ごとのベロシティを表す
+type WeeklyVelocity struct {
+	WeekStart time.Time `json:"week_start"`
+	WeekEnd   time.Time `json:"week_end"`
+	VelocityStats
+}
+
+// CategoryVelocity はカテゴリごとのベロシティを表す
+type CategoryVelocity struct {
+	Category Category `json:"category"`
+	VelocityStats
+}
+
+// VelocityReport は期間内のベロシティと見積もり精度のレポートを表す
+type VelocityReport struct {
+	From                 time.Time           `json:"from"`
+	To                   time.Time           `json:"to"`
+	Weeks                []*WeeklyVelocity   `json:"weeks"`
+	Categories           []*CategoryVelocity `json:"categories"`
+	Total                VelocityStats       `json:"total"`
+	AverageTasksPerWeek  float64             `json:"average_tasks_per_week"`
+	AveragePointsPerWeek float64             `json:"average_points_per_week"`
+}
+
+// velocityAccumulator は集計途中の値を保持する
+type velocityAccumulator struct {
+	stats       VelocityStats
+	measuredSum float64 // タスクごとの精度の合計
+	measuredEst int     // 実績があるタスクの見積もり合計
+}
+
+func (a *velocityAccumulator) add(task *Task) {
+	a.stats.CompletedTasks++
+	if task.EstimatePoints != nil {
+		a.stats.CompletedPoints += *task.EstimatePoints
+	}
+	if task.EstimateMinutes == nil || *task.EstimateMinutes <= 0 {
+		return
+	}
+	a.stats.EstimatedMinutes += *task.EstimateMinutes
+
+	if task.ActualMinutes == nil || *task.ActualMinutes <= 0 {
+		return
+	}
+	estimate := float64(*task.EstimateMinutes)
+	actual := float64(*task.ActualMinutes)
+	a.stats.MeasuredTasks++
+	a.stats.ActualMinutes += *task.ActualMinutes
+	a.measuredEst += *task.EstimateMinutes
+	a.measuredSum += math.Min(estimate, actual) / math.Max(estimate, actual) * 100
+}
+
+func (a *velocityAccumulator) result() VelocityStats {
+	stats := a.stats
+	if stats.MeasuredTasks > 0 {
+		stats.EstimateRatio = math.Round(float64(stats.ActualMinutes)/float64(a.measuredEst)*100) / 100
+		stats.AccuracyRate = math.Round(a.measuredSum/float64(stats.MeasuredTasks)*10) / 10
+	}
+	return stats
+}
+
+// NewVelocityReport は完了済みタスクから週次・カテゴリ別のレポートを作成する
+// 週は月曜開始で、fromを含む週からtoを含む週までを対象とする
+func NewVelocityReport(tasks []*Task, from, to time.Time) *VelocityReport {
+	firstWeek, _ := GetWeekStartEnd(from)
+	_, lastWeekEnd := GetWeekStartEnd(to)
+
+	report := &VelocityReport{
+		From:       firstWeek,
+		To:         lastWeekEnd,
+		Weeks:      []*WeeklyVelocity{},
+		Categories: []*CategoryVelocity{},
+	}
+
+	var weekStarts []time.Time
+	weeks := make(map[string]*velocityAccumulator)
+	for week := firstWeek; !week.After(lastWeekEnd); week = week.AddDate(0, 0, 7) {
+		weekStarts = append(weekStarts, week)
+		weeks[week.Format("2006-01-02")] = &velocityAccumulator{}
+	}
+
+	categories := make(map[Category]*velocityAccumulator)
+	total := &velocityAccumulator{}
+
+	for _, task := range tasks {
+		if task.Status != TaskStatusDone || task.CompletedAt == nil {
+			continue
+		}
+		completedAt := task.CompletedAt.In(from.Location())
+		if completedAt.Before(firstWeek) || completedAt.After(lastWeekEnd) {
+			continue
+		}
+
+		weekStart, _ := GetWeekStartEnd(completedAt)
+		if acc, ok := weeks[weekStart.Format("2006-01-02")]; ok {
+			acc.add(task)
+		}
+
+		category := task.Category
+		if category == "" {
+			category = CategoryOther
+		}
+		if categories[category] == nil {
+			categories[category] = &velocityAccumulator{}
+		}
+		categories[category].add(task)
+		total.add(task)
+	}
+
+	for _, weekStart := range weekStarts {
+		_, weekEnd := GetWeekStartEnd(weekStart)
+		report.Weeks = append(report.Weeks, &WeeklyVelocity{
+			WeekStart:     weekStart,
+			WeekEnd:       weekEnd,
+			VelocityStats: weeks[weekStart.Format("2006-01-02")].result(),
+		})
+	}
+
+	for category, acc := range categories {
+		report.Categories = append(report.Categories, &CategoryVelocity{
+			Category:      category,
+			VelocityStats: acc.result(),
+		})
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].CompletedTasks > report.Categories[j].CompletedTasks ||
+			(report.Categories[i].CompletedTasks == report.Categories[j].CompletedTasks &&
+				report.Categories[i].Category < report.Categories[j].Category)
+	})
+
+	report.Total = total.result()
+	if len(report.Weeks) > 0 {
+		report.AverageTasksPerWeek = math.Round(float64(report.Total.CompletedTasks)/float64(len(report.Weeks))*10) / 10
+		report.AveragePointsPerWeek = math.Round(float64(report.Total.CompletedPoints)/float64(len(report.Weeks))*10) / 10
+	}
+
+	return report
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestTask_SetStatusCompletedAt(t *testing.T) {
+	task := NewTask("task", "", PriorityMedium, CategoryWork, "user-1")
+	assert.Nil(t, task.CompletedAt)
+
+	task.SetStatus(TaskStatusDone)
+	require.NotNil(t, task.CompletedAt)
+
+	task.SetStatus(TaskStatusInProgress)
+	assert.Nil(t, task.CompletedAt)
+}
+
+func TestEstimatedEffortMinutes_UsesEstimate(t *testing.T) {
+	task := NewTask("task", "", PriorityHigh, CategoryWork, "user-1")
+	assert.Equal(t, 120, EstimatedEffortMinutes(task))
+
+	task.SetEstimate(intPtr(45), nil)
+	assert.Equal(t, 45, EstimatedEffortMinutes(task))
+}
+
+func TestNewVelocityReport(t *testing.T) {
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC) // 月曜
+	to := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)  // 翌週水曜
+
+	newDone := func(category Category, completedAt time.Time, estimate, actual, points *int) *Task {
+		task := NewTask("task", "", PriorityMedium, category, "user-1")
+		task.Status = TaskStatusDone
+		task.CompletedAt = &completedAt
+		task.EstimateMinutes = estimate
+		task.ActualMinutes = actual
+		task.EstimatePoints = points
+		return task
+	}
+
+	tasks := []*Task{
+		newDone(CategoryWork, time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC), intPtr(60), intPtr(120), intPtr(3)),
+		newDone(CategoryWork, time.Date(2024, 6, 5, 10, 0, 0, 0, time.UTC), intPtr(60), intPtr(60), intPtr(2)),
+		newDone(CategoryStudy, time.Date(2024, 6, 11, 10, 0, 0, 0, time.UTC), intPtr(30), nil, intPtr(1)),
+		newDone(CategoryStudy, time.Date(2024, 5, 31, 10, 0, 0, 0, time.UTC), intPtr(30), intPtr(30), nil), // 期間外
+		NewTask("todo", "", PriorityLow, CategoryWork, "user-1"),                                           // 未完了
+	}
+
+	report := NewVelocityReport(tasks, from, to)
+
+	require.Len(t, report.Weeks, 2)
+	assert.Equal(t, from, report.Weeks[0].WeekStart)
+	assert.Equal(t, 2, report.Weeks[0].CompletedTasks)
+	assert.Equal(t, 5, report.Weeks[0].CompletedPoints)
+	assert.Equal(t, 1, report.Weeks[1].CompletedTasks)
+
+	assert.Equal(t, 3, report.Total.CompletedTasks)
+	assert.Equal(t, 6, report.Total.CompletedPoints)
+	assert.Equal(t, 150, report.Total.EstimatedMinutes)
+	assert.Equal(t, 2, report.Total.MeasuredTasks)
+	assert.Equal(t, 180, report.Total.ActualMinutes)
+	assert.Equal(t, 1.5, report.Total.EstimateRatio)
+	assert.Equal(t, 75.0, report.Total.AccuracyRate)
+	assert.Equal(t, 1.5, report.AverageTasksPerWeek)
+	assert.Equal(t, 3.0, report.AveragePointsPerWeek)
+
+	require.Len(t, report.Categories, 2)
+	assert.Equal(t, CategoryWork, report.Categories[0].Category)
+	assert.Equal(t, 2, report.Categories[0].CompletedTasks)
+	assert.Equal(t, CategoryStudy, report.Categories[1].Category)
+	assert.Equal(t, 0, report.Categories[1].MeasuredTasks)
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 週次レポートで使用する定数
+const (
+	WeeklyReportTopCategories = 3  // 上位カテゴリの件数
+	WeeklyReportMaxTasks      = 20 // 完了・遅延したタスクの一覧の最大件数
+	WeeklyReportDeliveryHour  = 8  // 月曜日の何時以降に前週のレポートを配信するか（購読者のタイムゾーン）
+)
+
+// ErrInvalidISOWeek はISO週の形式が不正な場合のエラー
+var ErrInvalidISOWeek = errors.New("invalid ISO week")
+
+var reISOWeek = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// WeeklyReport は1週間（ISO週、月曜日〜日曜日）の個人の振り返りレポート
+type WeeklyReport struct {
+	UserID    string    `json:"user_id"`
+	Week      string    `json:"week" example:"2024-W23"` // ISO週
+	WeekStart time.Time `json:"week_start"`
+	WeekEnd   time.Time `json:"week_end"`
+	Timezone  string    `json:"timezone" example:"Asia/Tokyo"`
+
+	CompletedCount int `json:"completed_count"` // 週内に完了したタスク数
+	OnTimeCount    int `json:"on_time_count"`   // 期限が週内のタスクのうち期限までに完了した数
+	SlippedCount   int `json:"slipped_count"`   // 期限が週内のタスクのうち期限を過ぎて完了した、または週末時点で未完了の数
+
+	TrackedMinutes   int `json:"tracked_minutes"`   // 完了したタスクの実績工数の合計（分）
+	EstimatedMinutes int `json:"estimated_minutes"` // 完了したタスクの見積もり工数の合計（分）
+
+	TopCategories []*WeeklyReportCategory `json:"top_categories"`
+	Completed     []*WeeklyReportTask     `json:"completed"` // 完了日時の順（最大件数まで）
+	Slipped       []*WeeklyReportTask     `json:"slipped"`   // 遅れの大きい順（最大件数まで）
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// WeeklyReportCategory はカテゴリごとの完了数と実績工数
+type WeeklyReportCategory struct {
+	Category       Category `json:"category"`
+	CompletedCount int      `json:"completed_count"`
+	TrackedMinutes int      `json:"tracked_minutes"`
+}
+
+// WeeklyReportTask はレポートに載せるタスクの要約
+type WeeklyReportTask struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Category      Category   `json:"category"`
+	Priority      Priority   `json:"priority"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	ActualMinutes *int       `json:"actual_minutes,omitempty"`
+	SlipHours     int        `json:"slip_hours,omitempty"` // 期限からの遅れ（時間、未完了の場合は週末時点）
+}
+
+// ParseISOWeek は"2024-W23"形式のISO週を解析し、その週の月曜日0時（loc）を返す
+func ParseISOWeek(week string, loc *time.Location) (time.Time, error) {
+	m := reISOWeek.FindStringSubmatch(week)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("%w: %q (expected YYYY-Www)", ErrInvalidISOWeek, week)
+	}
+	year, _ := strconv.Atoi(m[1])
+	number, _ := strconv.Atoi(m[2])
+
+	// 1月4日を含む週がその年の第1週
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	week1Start, _ := GetWeekStartEnd(jan4)
+	start := week1Start.AddDate(0, 0, 7*(number-1))
+
+	if y, w := start.ISOWeek(); number < 1 || y != year || w != number {
+		return time.Time{}, fmt.Errorf("%w: %q does not exist", ErrInvalidISOWeek, week)
+	}
+	return start, nil
+}
+
+// FormatISOWeek は日時を含むISO週を"2024-W23"形式で返す
+func FormatISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// PreviousISOWeek はnowの前の週のISO週を返す
+func PreviousISOWeek(now time.Time) string {
+	return FormatISOWeek(now.AddDate(0, 0, -7))
+}
+
+// NewWeeklyReport は週内に完了したタスクと期限が週内のタスクからレポートを作成する
+// completedは週内に完了したタスク、dueは期限が週内のタスク（重複していてもよい）
+func NewWeeklyReport(userID string, weekStart time.Time, completed, due []*Task, now time.Time) *WeeklyReport {
+	_, weekEnd := GetWeekStartEnd(weekStart)
+	report := &WeeklyReport{
+		UserID:        userID,
+		Week:          FormatISOWeek(weekStart),
+		WeekStart:     weekStart,
+		WeekEnd:       weekEnd,
+		Timezone:      weekStart.Location().String(),
+		TopCategories: []*WeeklyReportCategory{},
+		Completed:     []*WeeklyReportTask{},
+		Slipped:       []*WeeklyReportTask{},
+		GeneratedAt:   now,
+	}
+
+	categories := make(map[Category]*WeeklyReportCategory)
+	seen := make(map[string]bool, len(completed))
+	for _, task := range completed {
+		if seen[task.ID] || task.CompletedAt == nil || task.CompletedAt.Before(weekStart) || task.CompletedAt.After(weekEnd) {
+			continue
+		}
+		seen[task.ID] = true
+
+		report.CompletedCount++
+		category, ok := categories[task.Category]
+		if !ok {
+			category = &WeeklyReportCategory{Category: task.Category}
+			categories[task.Category] = category
+		}
+		category.CompletedCount++
+		if task.ActualMinutes != nil && *task.ActualMinutes > 0 {
+			report.TrackedMinutes += *task.ActualMinutes
+			category.TrackedMinutes += *task.ActualMinutes
+		}
+		if task.EstimateMinutes != nil && *task.EstimateMinutes > 0 {
+			report.EstimatedMinutes += *task.EstimateMinutes
+		}
+		report.Completed = append(report.Completed, newWeeklyReportTask(task))
+	}
+	sort.SliceStable(report.Completed, func(i, j int) bool {
+		return report.Completed[i].CompletedAt.Before(*report.Completed[j].CompletedAt)
+	})
+
+	// 週末前にレポートを作成した場合は、作成時点までに期限を過ぎたタスクのみを遅延とする
+	cutoff := weekEnd
+	if now.Before(cutoff) {
+		cutoff = now
+	}
+	seen = make(map[string]bool, len(due))
+	for _, task := range due {
+		if seen[task.ID] || task.DueDate == nil || task.DueDate.Before(weekStart) || task.DueDate.After(cutoff) {
+			continue
+		}
+		seen[task.ID] = true
+
+		finishedAt := cutoff
+		if task.CompletedAt != nil && task.CompletedAt.Before(cutoff) {
+			finishedAt = *task.CompletedAt
+		}
+		if task.CompletedAt != nil && !task.CompletedAt.After(*task.DueDate) {
+			report.OnTimeCount++
+			continue
+		}
+		report.SlippedCount++
+		slipped := newWeeklyReportTask(task)
+		slipped.SlipHours = int(finishedAt.Sub(*task.DueDate).Hours())
+		report.Slipped = append(report.Slipped, slipped)
+	}
+	sort.SliceStable(report.Slipped, func(i, j int) bool {
+		return report.Slipped[i].SlipHours > report.Slipped[j].SlipHours
+	})
+
+	for _, category := range categories {
+		report.TopCategories = append(report.TopCategories, category)
+	}
+	sort.Slice(report.TopCategories, func(i, j int) bool {
+		a, b := report.TopCategories[i], report.TopCategories[j]
+		if a.CompletedCount != b.CompletedCount {
+			return a.CompletedCount > b.CompletedCount
+		}
+		if a.TrackedMinutes != b.TrackedMinutes {
+			return a.TrackedMinutes > b.TrackedMinutes
+		}
+		return a.Category < b.Category
+	})
+
+	if len(report.TopCategories) > WeeklyReportTopCategories {
+		report.TopCategories = report.TopCategories[:WeeklyReportTopCategories]
+	}
+	if len(report.Completed) > WeeklyReportMaxTasks {
+		report.Completed = report.Completed[:WeeklyReportMaxTasks]
+	}
+	if len(report.Slipped) > WeeklyReportMaxTasks {
+		report.Slipped = report.Slipped[:WeeklyReportMaxTasks]
+	}
+	return report
+}
+
+func newWeeklyReportTask(task *Task) *WeeklyReportTask {
+	return &WeeklyReportTask{
+		ID:            task.ID,
+		Title:         task.Title,
+		Category:      task.Category,
+		Priority:      task.Priority,
+		DueDate:       task.DueDate,
+		CompletedAt:   task.CompletedAt,
+		ActualMinutes: task.ActualMinutes,
+	}
+}
+
+// WeeklyReportSubscription は週次レポートのメール配信の購読
+type WeeklyReportSubscription struct {
+	UserID       string    `json:"user_id"`
+	Timezone     string    `json:"timezone" example:"Asia/Tokyo"` // 週の区切りと配信時刻のタイムゾーン
+	LastSentWeek string    `json:"last_sent_week,omitempty" example:"2024-W23"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// DueWeek は配信すべき前週のISO週を返す（まだ配信時刻でないか、配信済みの場合は空文字）
+// 月曜日の配信時刻を過ぎていれば、その週のうちは前週のレポートを配信する
+func (s *WeeklyReportSubscription) DueWeek(now time.Time) string {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	weekStart, _ := GetWeekStartEnd(local)
+	if local.Before(weekStart.Add(WeeklyReportDeliveryHour * time.Hour)) {
+		return ""
+	}
+	week := PreviousISOWeek(local)
+	if week == s.LastSentWeek {
+		return ""
+	}
+	return week
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseISOWeek(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		week    string
+		want    time.Time
+		wantErr bool
+	}{
+		{week: "2024-W23", want: time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo)},
+		{week: "2024-W01", want: time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo)},
+		{week: "2021-W01", want: time.Date(2021, 1, 4, 0, 0, 0, 0, tokyo)},   // 1月1日が金曜日
+		{week: "2020-W53", want: time.Date(2020, 12, 28, 0, 0, 0, 0, tokyo)}, // 53週ある年
+		{week: "2021-W53", wantErr: true},
+		{week: "2024-W00", wantErr: true},
+		{week: "2024-23", wantErr: true},
+		{week: "2024-W5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.week, func(t *testing.T) {
+			got, err := ParseISOWeek(tt.week, tokyo)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidISOWeek)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+			assert.Equal(t, tt.week, FormatISOWeek(got))
+		})
+	}
+}
+
+func TestNewWeeklyReport(t *testing.T) {
+	weekStart := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		v := time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+	newTask := func(id string, category Category, due, completed *time.Time, actual *int) *Task {
+		task := NewTask(id, "", PriorityMedium, category, "user-1")
+		task.ID = id
+		task.DueDate = due
+		if completed != nil {
+			task.Status = TaskStatusDone
+			task.CompletedAt = completed
+		}
+		task.ActualMinutes = actual
+		return task
+	}
+
+	onTime := newTask("on-time", CategoryWork, at(5, 18), at(5, 10), intPtr(90))
+	late := newTask("late", CategoryWork, at(4, 18), at(6, 18), intPtr(30))
+	noDue := newTask("no-due", CategoryStudy, nil, at(7, 12), nil)
+	open := newTask("open", CategoryPersonal, at(8, 9), nil, nil)
+	lastWeek := newTask("last-week", CategoryHealth, nil, at(1, 12), intPtr(600)) // 期間外
+
+	report := NewWeeklyReport("user-1", weekStart,
+		[]*Task{onTime, late, noDue, lastWeek, late},
+		[]*Task{onTime, late, open},
+		now)
+
+	assert.Equal(t, "2024-W23", report.Week)
+	assert.Equal(t, 3, report.CompletedCount)
+	assert.Equal(t, 120, report.TrackedMinutes)
+	assert.Equal(t, 1, report.OnTimeCount)
+	assert.Equal(t, 2, report.SlippedCount)
+
+	require.Len(t, report.TopCategories, 2)
+	assert.Equal(t, CategoryWork, report.TopCategories[0].Category)
+	assert.Equal(t, 2, report.TopCategories[0].CompletedCount)
+	assert.Equal(t, 120, report.TopCategories[0].TrackedMinutes)
+
+	require.Len(t, report.Completed, 3)
+	assert.Equal(t, "on-time", report.Completed[0].ID)
+	assert.Equal(t, "no-due", report.Completed[2].ID)
+
+	// 未完了のタスクは週末までの遅れ、完了したタスクは完了までの遅れ
+	require.Len(t, report.Slipped, 2)
+	assert.Equal(t, "late", report.Slipped[0].ID)
+	assert.Equal(t, 48, report.Slipped[0].SlipHours)
+	assert.Equal(t, "open", report.Slipped[1].ID)
+	assert.Equal(t, 38, report.Slipped[1].SlipHours)
+}
+
+func TestNewWeeklyReport_CurrentWeekOnlyCountsPastDue(t *testing.T) {
+	weekStart := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	pastDue := time.Date(2024, 6, 4, 12, 0, 0, 0, time.UTC)
+	upcoming := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+
+	overdue := NewTask("overdue", "", PriorityMedium, CategoryWork, "user-1")
+	overdue.ID = "overdue"
+	overdue.DueDate = &pastDue
+	notYetDue := NewTask("not yet due", "", PriorityMedium, CategoryWork, "user-1")
+	notYetDue.ID = "not-yet-due"
+	notYetDue.DueDate = &upcoming
+
+	report := NewWeeklyReport("user-1", weekStart, nil, []*Task{overdue, notYetDue}, now)
+
+	assert.Equal(t, 1, report.SlippedCount)
+	require.Len(t, report.Slipped, 1)
+	assert.Equal(t, 24, report.Slipped[0].SlipHours)
+	assert.Empty(t, report.Completed)
+	assert.Empty(t, report.TopCategories)
+}
+
+func TestWeeklyReportSubscription_DueWeek(t *testing.T) {
+	sub := &WeeklyReportSubscription{UserID: "user-1", Timezone: "Asia/Tokyo"}
+
+	// 2024-06-10は月曜日。東京の8時はUTCの前日23時
+	assert.Empty(t, sub.DueWeek(time.Date(2024, 6, 9, 22, 59, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-W23", sub.DueWeek(time.Date(2024, 6, 9, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-W23", sub.DueWeek(time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)))
+
+	sub.LastSentWeek = "2024-W23"
+	assert.Empty(t, sub.DueWeek(time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-W24", sub.DueWeek(time.Date(2024, 6, 17, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,340 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+)
+
+// 作業量計算で使用する定数
+const (
+	DefaultWorkTimezone  = "Asia/Tokyo"
+	DefaultWorkStartTime = "09:00"
+	DefaultWorkEndTime   = "18:00"
+	MaxDailyCapacity     = 24 * 60 // 1日の最大キャパシティ（分）
+)
+
+// 優先度ごとの既定の見積もり工数（分）
+var defaultEffortMinutes = map[Priority]int{
+	PriorityLow:    30,
+	PriorityMedium: 60,
+	PriorityHigh:   120,
+}
+
+// HolidayPolicy は期限が祝日・勤務日以外になった場合の扱い
+type HolidayPolicy string
+
+const (
+	// HolidayPolicyKeep は期限を変更しない
+	HolidayPolicyKeep HolidayPolicy = "KEEP"
+	// HolidayPolicyNextBusinessDay は期限を次の営業日に延期する
+	HolidayPolicyNextBusinessDay HolidayPolicy = "NEXT_BUSINESS_DAY"
+	// HolidayPolicyPreviousBusinessDay は期限を前の営業日に前倒しする
+	HolidayPolicyPreviousBusinessDay HolidayPolicy = "PREVIOUS_BUSINESS_DAY"
+)
+
+// IsValid は期限の扱いが有効かチェック
+func (p HolidayPolicy) IsValid() bool {
+	switch p {
+	case HolidayPolicyKeep, HolidayPolicyNextBusinessDay, HolidayPolicyPreviousBusinessDay:
+		return true
+	}
+	return false
+}
+
+// ReminderTiming はリマインダー・エスカレーションの通知を送る時間帯
+type ReminderTiming string
+
+const (
+	// ReminderTimingWorkingHours は勤務時間内（営業日の開始〜終了時刻）にのみ送り、時間外の通知は次の勤務時間の開始時にまとめて送る
+	ReminderTimingWorkingHours ReminderTiming = "WORKING_HOURS"
+	// ReminderTimingAnytime は時間帯にかかわらずすぐに送る
+	ReminderTimingAnytime ReminderTiming = "ANYTIME"
+)
+
+// IsValid は通知の時間帯が有効かチェック
+func (t ReminderTiming) IsValid() bool {
+	switch t {
+	case ReminderTimingWorkingHours, ReminderTimingAnytime:
+		return true
+	}
+	return false
+}
+
+// WorkingHours はユーザーの勤務時間とキャパシティ設定を表す
+type WorkingHours struct {
+	UserID               string         `json:"user_id"`
+	Timezone             string         `json:"timezone"`
+	WorkDays             []time.Weekday `json:"work_days" swaggertype:"array,integer"` // 勤務曜日（0=日曜）
+	StartTime            string         `json:"start_time"`                            // "HH:MM"
+	EndTime              string         `json:"end_time"`                              // "HH:MM"
+	DailyCapacityMinutes int            `json:"daily_capacity_minutes"`                // 0の場合は勤務時間から算出
+	HolidayPolicy        HolidayPolicy  `json:"holiday_policy"`                        // 期限が祝日・勤務日以外になった場合の扱い
+	ReminderTiming       ReminderTiming `json:"reminder_timing"`                       // リマインダー・エスカレーションの通知を送る時間帯
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+// DefaultWorkingHours は未設定ユーザー向けの既定値（平日9:00-18:00）を返す
+func DefaultWorkingHours(userID string) *WorkingHours {
+	return &WorkingHours{
+		UserID:         userID,
+		Timezone:       DefaultWorkTimezone,
+		WorkDays:       []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		StartTime:      DefaultWorkStartTime,
+		EndTime:        DefaultWorkEndTime,
+		HolidayPolicy:  HolidayPolicyKeep,
+		ReminderTiming: ReminderTimingWorkingHours,
+	}
+}
+
+// Validate は設定値を検証する（期限の扱いが未指定の場合は KEEP、通知の時間帯が未指定の場合は WORKING_HOURS にする）
+func (w *WorkingHours) Validate() error {
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %s", w.Timezone)
+	}
+	for _, day := range w.WorkDays {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("invalid work day: %d", day)
+		}
+	}
+
+	start, err := parseClock(w.StartTime)
+	if err != nil {
+		return err
+	}
+	end, err := parseClock(w.EndTime)
+	if err != nil {
+		return err
+	}
+	if end <= start {
+		return errors.New("end_time must be after start_time")
+	}
+
+	if w.DailyCapacityMinutes < 0 || w.DailyCapacityMinutes > MaxDailyCapacity {
+		return fmt.Errorf("daily capacity must be between 0 and %d minutes", MaxDailyCapacity)
+	}
+	if w.HolidayPolicy == "" {
+		w.HolidayPolicy = HolidayPolicyKeep
+	}
+	if !w.HolidayPolicy.IsValid() {
+		return fmt.Errorf("invalid holiday policy: %s", w.HolidayPolicy)
+	}
+	if w.ReminderTiming == "" {
+		w.ReminderTiming = ReminderTimingWorkingHours
+	}
+	if !w.ReminderTiming.IsValid() {
+		return fmt.Errorf("invalid reminder timing: %s", w.ReminderTiming)
+	}
+	return nil
+}
+
+// Location は設定されたタイムゾーンを返す（不正な場合はUTC）
+func (w *WorkingHours) Location() *time.Location {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsWorkDay は指定曜日が勤務日かを判定する
+func (w *WorkingHours) IsWorkDay(day time.Weekday) bool {
+	for _, d := range w.WorkDays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusinessDay は指定日（勤務時間のタイムゾーンでの暦日）が勤務日かつ祝日でないかを判定する
+func (w *WorkingHours) IsBusinessDay(date time.Time, holidays holiday.Provider) bool {
+	return holiday.IsBusinessDay(holidays, date.In(w.Location()), w.WorkDays)
+}
+
+// AdjustDueDate は期限の扱いに従って、祝日・勤務日以外の期限を前後の営業日の同じ時刻に移動する
+// 移動しない場合（営業日・KEEP・営業日が見つからない場合）は期限をそのまま返す
+func (w *WorkingHours) AdjustDueDate(due time.Time, holidays holiday.Provider) time.Time {
+	if w.IsBusinessDay(due, holidays) {
+		return due
+	}
+
+	local := due.In(w.Location())
+	var adjusted time.Time
+	var ok bool
+	switch w.HolidayPolicy {
+	case HolidayPolicyNextBusinessDay:
+		adjusted, ok = holiday.NextBusinessDay(holidays, local, w.WorkDays)
+	case HolidayPolicyPreviousBusinessDay:
+		adjusted, ok = holiday.PreviousBusinessDay(holidays, local, w.WorkDays)
+	}
+	if !ok {
+		return due
+	}
+	return adjusted.In(due.Location())
+}
+
+// NextReminderTime は at に送る通知を実際に送る日時を返す
+// 通知の時間帯が WORKING_HOURS の場合、営業日の勤務時間外の通知は次の勤務時間の開始時刻に移す
+// 勤務時間内・ANYTIME・営業日が見つからない場合は at をそのまま返す
+func (w *WorkingHours) NextReminderTime(at time.Time, holidays holiday.Provider) time.Time {
+	if w.ReminderTiming == ReminderTimingAnytime {
+		return at
+	}
+	start, err := parseClock(w.StartTime)
+	if err != nil {
+		return at
+	}
+	end, err := parseClock(w.EndTime)
+	if err != nil || end <= start {
+		return at
+	}
+
+	local := at.In(w.Location())
+	minute := local.Hour()*60 + local.Minute()
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	if w.IsBusinessDay(day, holidays) {
+		if minute >= start && minute < end {
+			return at
+		}
+		if minute < start {
+			return atClock(day, start).In(at.Location())
+		}
+	}
+
+	next, ok := holiday.NextBusinessDay(holidays, day.AddDate(0, 0, 1), w.WorkDays)
+	if !ok {
+		return at
+	}
+	return atClock(next, start).In(at.Location())
+}
+
+// atClock は day の暦日の minute 分（0時からの分）の日時を返す
+func atClock(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
+
+// CapacityOn は指定日のキャパシティ（分）を返す。勤務日以外は0
+func (w *WorkingHours) CapacityOn(date time.Time) int {
+	if !w.IsWorkDay(date.In(w.Location()).Weekday()) {
+		return 0
+	}
+	if w.DailyCapacityMinutes > 0 {
+		return w.DailyCapacityMinutes
+	}
+
+	start, err := parseClock(w.StartTime)
+	if err != nil {
+		return 0
+	}
+	end, err := parseClock(w.EndTime)
+	if err != nil || end <= start {
+		return 0
+	}
+	return end - start
+}
+
+// EstimatedEffortMinutes はタスクの見積もり工数（分）を返す
+// 見積もりが未設定の場合は優先度ごとの既定値を使用する
+func EstimatedEffortMinutes(task *Task) int {
+	if task.EstimateMinutes != nil && *task.EstimateMinutes > 0 {
+		return *task.EstimateMinutes
+	}
+	if minutes, ok := defaultEffortMinutes[task.Priority]; ok {
+		return minutes
+	}
+	return defaultEffortMinutes[PriorityMedium]
+}
+
+// DailyWorkload は1日分の作業量を表す
+type DailyWorkload struct {
+	Date            string   `json:"date"` // "2006-01-02"
+	PlannedMinutes  int      `json:"planned_minutes"`
+	CapacityMinutes int      `json:"capacity_minutes"`
+	TaskCount       int      `json:"task_count"`
+	TaskIDs         []string `json:"task_ids"`
+	OverCapacity    bool     `json:"over_capacity"`
+}
+
+// Workload は期間内の作業量を表す
+type Workload struct {
+	UserID               string           `json:"user_id"`
+	From                 string           `json:"from"`
+	To                   string           `json:"to"`
+	Timezone             string           `json:"timezone"`
+	Days                 []*DailyWorkload `json:"days"`
+	TotalPlannedMinutes  int              `json:"total_planned_minutes"`
+	TotalCapacityMinutes int              `json:"total_capacity_minutes"`
+	OverCapacityDays     int              `json:"over_capacity_days"`
+}
+
+// WorkloadWarning は担当者のキャパシティ超過警告を表す
+type WorkloadWarning struct {
+	AssigneeID      string `json:"assignee_id"`
+	Date            string `json:"date"`
+	PlannedMinutes  int    `json:"planned_minutes"`
+	CapacityMinutes int    `json:"capacity_minutes"`
+	Message         string `json:"message"`
+}
+
+// NewWorkload は担当中の未完了タスクを期限日ごとに集計して作業量を算出する
+// from・toは暦日として勤務時間のタイムゾーンで解釈し、期限のないタスクと完了済みタスクは対象外
+func NewWorkload(userID string, hours *WorkingHours, tasks []*Task, from, to time.Time) *Workload {
+	loc := hours.Location()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+
+	workload := &Workload{
+		UserID:   userID,
+		From:     start.Format("2006-01-02"),
+		To:       end.Format("2006-01-02"),
+		Timezone: loc.String(),
+	}
+
+	days := make(map[string]*DailyWorkload)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		daily := &DailyWorkload{
+			Date:            key,
+			CapacityMinutes: hours.CapacityOn(day),
+			TaskIDs:         []string{},
+		}
+		days[key] = daily
+		workload.Days = append(workload.Days, daily)
+	}
+
+	for _, task := range tasks {
+		if task.Status == TaskStatusDone || task.DueDate == nil {
+			continue
+		}
+		daily, ok := days[task.DueDate.In(loc).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		daily.PlannedMinutes += EstimatedEffortMinutes(task)
+		daily.TaskCount++
+		daily.TaskIDs = append(daily.TaskIDs, task.ID)
+	}
+
+	for _, daily := range workload.Days {
+		daily.OverCapacity = daily.PlannedMinutes > daily.CapacityMinutes && daily.TaskCount > 0
+		if daily.OverCapacity {
+			workload.OverCapacityDays++
+		}
+		workload.TotalPlannedMinutes += daily.PlannedMinutes
+		workload.TotalCapacityMinutes += daily.CapacityMinutes
+	}
+
+	return workload
+}
+
+// parseClock は"HH:MM"形式の時刻を0時からの経過分に変換する
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time format (HH:MM): %s", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
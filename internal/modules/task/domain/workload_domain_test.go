@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingHours_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(w *WorkingHours)
+		wantErr bool
+	}{
+		{name: "default is valid", modify: func(w *WorkingHours) {}},
+		{name: "invalid timezone", modify: func(w *WorkingHours) { w.Timezone = "Mars/Base" }, wantErr: true},
+		{name: "invalid work day", modify: func(w *WorkingHours) { w.WorkDays = []time.Weekday{7} }, wantErr: true},
+		{name: "invalid time format", modify: func(w *WorkingHours) { w.StartTime = "9am" }, wantErr: true},
+		{name: "end before start", modify: func(w *WorkingHours) { w.EndTime = "08:00" }, wantErr: true},
+		{name: "capacity too large", modify: func(w *WorkingHours) { w.DailyCapacityMinutes = MaxDailyCapacity + 1 }, wantErr: true},
+		{name: "empty holiday policy", modify: func(w *WorkingHours) { w.HolidayPolicy = "" }},
+		{name: "invalid holiday policy", modify: func(w *WorkingHours) { w.HolidayPolicy = "SKIP" }, wantErr: true},
+		{name: "empty reminder timing", modify: func(w *WorkingHours) { w.ReminderTiming = "" }},
+		{name: "invalid reminder timing", modify: func(w *WorkingHours) { w.ReminderTiming = "NEVER" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours := DefaultWorkingHours("user-1")
+			tt.modify(hours)
+
+			err := hours.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkingHours_CapacityOn(t *testing.T) {
+	hours := DefaultWorkingHours("user-1")
+	hours.Timezone = "UTC"
+
+	wednesday := time.Date(2024, 6, 12, 10, 0, 0, 0, time.UTC)
+	saturday := time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 9*60, hours.CapacityOn(wednesday))
+	assert.Equal(t, 0, hours.CapacityOn(saturday))
+
+	hours.DailyCapacityMinutes = 300
+	assert.Equal(t, 300, hours.CapacityOn(wednesday))
+}
+
+func TestWorkingHours_AdjustDueDate(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	// 2024-05-03（金・憲法記念日）18:00、翌営業日は05-07（火）
+	due := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+
+	hours := DefaultWorkingHours("user-1")
+	assert.Equal(t, due, hours.AdjustDueDate(due, holiday.Japan()), "KEEP does not move the due date")
+
+	hours.HolidayPolicy = HolidayPolicyNextBusinessDay
+	assert.Equal(t, time.Date(2024, 5, 7, 18, 0, 0, 0, tokyo).UTC(), hours.AdjustDueDate(due, holiday.Japan()))
+	assert.Equal(t, time.UTC, hours.AdjustDueDate(due, holiday.Japan()).Location())
+
+	hours.HolidayPolicy = HolidayPolicyPreviousBusinessDay
+	assert.Equal(t, time.Date(2024, 5, 2, 18, 0, 0, 0, tokyo).UTC(), hours.AdjustDueDate(due, holiday.Japan()))
+
+	// 祝日を扱わない場合は勤務日なのでそのまま
+	assert.Equal(t, due, hours.AdjustDueDate(due, nil))
+
+	// 勤務日がない場合は移動しない
+	hours.WorkDays = nil
+	assert.Equal(t, due, hours.AdjustDueDate(due, holiday.Japan()))
+}
+
+func TestWorkingHours_NextReminderTime(t *testing.T) {
+	hours := DefaultWorkingHours("user-1")
+	hours.Timezone = "UTC"
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		// 勤務時間内はそのまま
+		{name: "within working hours", at: time.Date(2024, 6, 12, 10, 30, 0, 0, time.UTC), want: time.Date(2024, 6, 12, 10, 30, 0, 0, time.UTC)},
+		// 始業前は当日の始業時刻
+		{name: "before start", at: time.Date(2024, 6, 12, 6, 0, 0, 0, time.UTC), want: time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC)},
+		// 終業後は翌営業日の始業時刻
+		{name: "after end", at: time.Date(2024, 6, 12, 18, 0, 0, 0, time.UTC), want: time.Date(2024, 6, 13, 9, 0, 0, 0, time.UTC)},
+		// 金曜日の終業後は月曜日
+		{name: "weekend", at: time.Date(2024, 6, 14, 22, 0, 0, 0, time.UTC), want: time.Date(2024, 6, 17, 9, 0, 0, 0, time.UTC)},
+		// 2024-05-03〜05-06は祝日と週末
+		{name: "holidays", at: time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC), want: time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hours.NextReminderTime(tt.at, holiday.Japan()))
+		})
+	}
+
+	t.Run("anytime", func(t *testing.T) {
+		anytime := *hours
+		anytime.ReminderTiming = ReminderTimingAnytime
+		at := time.Date(2024, 6, 15, 23, 0, 0, 0, time.UTC)
+		assert.Equal(t, at, anytime.NextReminderTime(at, holiday.Japan()))
+	})
+
+	t.Run("keeps the time zone of at", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		require.NoError(t, err)
+		local := *hours
+		local.Timezone = "Asia/Tokyo"
+		// 2024-06-12（水）20:00 JST は翌日09:00 JST（00:00 UTC）
+		at := time.Date(2024, 6, 12, 11, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2024, 6, 13, 9, 0, 0, 0, tokyo).UTC(), local.NextReminderTime(at, nil))
+	})
+}
+
+func TestNewWorkload(t *testing.T) {
+	hours := DefaultWorkingHours("user-1")
+	hours.Timezone = "UTC"
+	hours.DailyCapacityMinutes = 120
+
+	from := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC) // 水曜
+	to := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)   // 土曜
+
+	newTask := func(id string, priority Priority, due time.Time, status TaskStatus) *Task {
+		task := NewTask(id, "", priority, CategoryWork, "user-1")
+		task.ID = id
+		task.SetDueDate(due)
+		task.SetStatus(status)
+		return task
+	}
+
+	tasks := []*Task{
+		newTask("t1", PriorityHigh, time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC), TaskStatusTodo),      // 120分
+		newTask("t2", PriorityLow, time.Date(2024, 6, 12, 17, 0, 0, 0, time.UTC), TaskStatusInProgress), // 30分
+		newTask("t3", PriorityMedium, time.Date(2024, 6, 13, 9, 0, 0, 0, time.UTC), TaskStatusTodo),     // 60分
+		newTask("t4", PriorityHigh, time.Date(2024, 6, 13, 9, 0, 0, 0, time.UTC), TaskStatusDone),       // 完了済み
+		newTask("t5", PriorityLow, time.Date(2024, 6, 15, 9, 0, 0, 0, time.UTC), TaskStatusTodo),        // 休日
+		newTask("t6", PriorityLow, time.Date(2024, 6, 20, 9, 0, 0, 0, time.UTC), TaskStatusTodo),        // 期間外
+	}
+
+	workload := NewWorkload("user-1", hours, tasks, from, to)
+
+	require.Len(t, workload.Days, 4)
+	assert.Equal(t, "2024-06-12", workload.From)
+	assert.Equal(t, "2024-06-15", workload.To)
+
+	assert.Equal(t, 150, workload.Days[0].PlannedMinutes)
+	assert.Equal(t, 2, workload.Days[0].TaskCount)
+	assert.True(t, workload.Days[0].OverCapacity)
+
+	assert.Equal(t, 60, workload.Days[1].PlannedMinutes)
+	assert.False(t, workload.Days[1].OverCapacity)
+
+	assert.Equal(t, 0, workload.Days[2].TaskCount)
+	assert.False(t, workload.Days[2].OverCapacity)
+
+	assert.Equal(t, 0, workload.Days[3].CapacityMinutes)
+	assert.True(t, workload.Days[3].OverCapacity)
+
+	assert.Equal(t, 2, workload.OverCapacityDays)
+	assert.Equal(t, 240, workload.TotalPlannedMinutes)
+	assert.Equal(t, 360, workload.TotalCapacityMinutes)
+}
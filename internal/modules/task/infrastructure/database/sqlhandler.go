@@ -1,16 +1,20 @@
 package databaseInfra
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/hryt430/Yotei+/config"
 	commonDB "github.com/hryt430/Yotei+/internal/common/infrastructure/database"
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
 	"github.com/hryt430/Yotei+/internal/modules/task/interface/database"
 )
 
 type SqlHandler struct {
 	Conn *sql.DB
+	// Stmts はプリペアドステートメントのキャッシュ（nilの場合は毎回Connで直接実行する）
+	Stmts *sqlquery.StmtCache
 }
 
 func NewSqlHandler() SqlHandler {
@@ -20,18 +24,25 @@ func NewSqlHandler() SqlHandler {
 	}
 
 	// common/databaseからDBコネクションを取得
-	conn, err := commonDB.NewMySQLConnection(config)
+	conn, err := commonDB.NewConnection(config)
 	if err != nil {
 		panic(err.Error())
 	}
 
 	sqlHandler := new(SqlHandler)
 	sqlHandler.Conn = conn
+	sqlHandler.Stmts = sqlquery.NewStmtCache(conn, sqlquery.DefaultStmtCacheSize)
 	return *sqlHandler
 }
 
 func (h *SqlHandler) Execute(statement string, args ...interface{}) (database.Result, error) {
-	res, err := h.Conn.Exec(statement, args...)
+	var res sql.Result
+	var err error
+	if h.Stmts != nil {
+		res, err = h.Stmts.ExecContext(context.Background(), statement, args...)
+	} else {
+		res, err = h.Conn.Exec(statement, args...)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("ステートメント実行失敗: %w", err)
 	}
@@ -39,7 +50,13 @@ func (h *SqlHandler) Execute(statement string, args ...interface{}) (database.Re
 }
 
 func (h *SqlHandler) Query(statement string, args ...interface{}) (database.Row, error) {
-	rows, err := h.Conn.Query(statement, args...)
+	var rows *sql.Rows
+	var err error
+	if h.Stmts != nil {
+		rows, err = h.Stmts.QueryContext(context.Background(), statement, args...)
+	} else {
+		rows, err = h.Conn.Query(statement, args...)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("クエリ実行失敗: %w", err)
 	}
@@ -47,6 +64,9 @@ func (h *SqlHandler) Query(statement string, args ...interface{}) (database.Row,
 }
 
 func (h *SqlHandler) Close() error {
+	if h.Stmts != nil {
+		_ = h.Stmts.Close()
+	}
 	return h.Conn.Close()
 }
 
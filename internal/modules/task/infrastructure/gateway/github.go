@@ -0,0 +1,252 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// githubAPIVersion はリクエストで指定するGitHub REST APIのバージョン
+	githubAPIVersion = "2022-11-28"
+	// githubTimeout はGitHub APIへの問い合わせのタイムアウト
+	githubTimeout = 10 * time.Second
+	// githubMaxResponseBytes はGitHub APIのレスポンスとして読み込む最大サイズ
+	githubMaxResponseBytes = 1 << 20
+	// githubSignaturePrefix はX-Hub-Signature-256ヘッダーの署名の接頭辞
+	githubSignaturePrefix = "sha256="
+)
+
+// GitHubGateway はGitHubのREST APIとWebhookを扱うゲートウェイ実装
+type GitHubGateway struct {
+	apiURL        string
+	webhookSecret string
+	httpClient    *http.Client
+	breaker       *circuitbreaker.Breaker // GitHubが応答しない・失敗が続く場合に問い合わせをやめる（nilの場合は制限しない）
+	logger        logger.Logger
+}
+
+// NewGitHubGateway はGitHubのゲートウェイを作成する
+// apiURLはGitHub APIのベースURL（GitHub Enterprise Serverの場合は https://ホスト/api/v3）、webhookSecretが空の場合はWebhookを受け付けない
+func NewGitHubGateway(apiURL, webhookSecret string, breaker *circuitbreaker.Breaker, logger logger.Logger) usecase.GitHubClient {
+	return &GitHubGateway{
+		apiURL:        strings.TrimRight(apiURL, "/"),
+		webhookSecret: webhookSecret,
+		httpClient:    &http.Client{Timeout: githubTimeout},
+		breaker:       breaker,
+		logger:        logger,
+	}
+}
+
+// githubUser はGitHub APIのユーザー
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubIssue はGitHub APIとWebhookのIssue（Pull Requestの場合は pull_request が設定される）
+type githubIssue struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	StateReason string `json:"state_reason"`
+	HTMLURL     string `json:"html_url"`
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+}
+
+// githubPullRequest はWebhookのPull Request
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	HTMLURL string `json:"html_url"`
+}
+
+// githubWebhookPayload はissues・pull_requestイベントのペイロード
+type githubWebhookPayload struct {
+	Action      string             `json:"action"`
+	Issue       *githubIssue       `json:"issue"`
+	PullRequest *githubPullRequest `json:"pull_request"`
+	Repository  struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// GetAuthenticatedUser はトークンのユーザー名を取得する
+func (g *GitHubGateway) GetAuthenticatedUser(ctx context.Context, token string) (string, error) {
+	var user githubUser
+	status, err := g.get(ctx, token, "/user", &user)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusUnauthorized {
+		return "", usecase.ErrInvalidGitHubToken
+	}
+	if status != http.StatusOK || user.Login == "" {
+		return "", fmt.Errorf("%w: unexpected status %d", usecase.ErrGitHubUnavailable, status)
+	}
+	return user.Login, nil
+}
+
+// GetIssue はIssue・Pull Requestを取得する
+// Issue APIはPull Requestも返すため、マージしたかは pull_request.merged_at で判定する
+func (g *GitHubGateway) GetIssue(ctx context.Context, token string, ref domain.IssueRef) (*domain.IssueInfo, error) {
+	owner, name, _ := strings.Cut(ref.Repository, "/")
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/issues/" + strconv.Itoa(ref.Number)
+
+	var issue githubIssue
+	status, err := g.get(ctx, token, path, &issue)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return nil, usecase.ErrIssueNotFound
+	case status == http.StatusUnauthorized && token != "":
+		return nil, usecase.ErrInvalidGitHubToken
+	case status != http.StatusOK:
+		return nil, fmt.Errorf("%w: unexpected status %d", usecase.ErrGitHubUnavailable, status)
+	}
+	return issue.toInfo(ref.Repository), nil
+}
+
+// ParseWebhook はX-Hub-Signature-256ヘッダーの署名を検証してIssue・Pull Requestのイベントを返す
+func (g *GitHubGateway) ParseWebhook(payload []byte, event, signature string) (*domain.IssueInfo, error) {
+	if g.webhookSecret == "" {
+		return nil, usecase.ErrGitHubWebhookDisabled
+	}
+	if !g.verifySignature(payload, signature) {
+		return nil, usecase.ErrInvalidGitHubSignature
+	}
+	if event != "issues" && event != "pull_request" {
+		return nil, nil
+	}
+
+	var raw githubWebhookPayload
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", usecase.ErrInvalidGitHubWebhook, err)
+	}
+
+	var info *domain.IssueInfo
+	switch {
+	case event == "issues" && raw.Issue != nil:
+		info = raw.Issue.toInfo(raw.Repository.FullName)
+	case event == "pull_request" && raw.PullRequest != nil:
+		info = raw.PullRequest.toInfo(raw.Repository.FullName)
+	default:
+		return nil, fmt.Errorf("%w: missing %s", usecase.ErrInvalidGitHubWebhook, event)
+	}
+	ref, err := domain.NewIssueRef(raw.Repository.FullName, info.Ref.Number)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", usecase.ErrInvalidGitHubWebhook, err)
+	}
+	info.Ref = ref
+	return info, nil
+}
+
+// verifySignature は "sha256=署名" 形式の署名を検証する（署名はペイロードのWebhookシークレットによるHMAC-SHA256）
+func (g *GitHubGateway) verifySignature(payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, githubSignaturePrefix) {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(strings.TrimPrefix(signature, githubSignaturePrefix)), []byte(expected))
+}
+
+// get はGitHub APIにGETで問い合わせ、200の場合はレスポンスを out に読み込んでステータスコードを返す
+func (g *GitHubGateway) get(ctx context.Context, token, path string, out interface{}) (int, error) {
+	var status int
+	err := g.breaker.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.apiURL+path, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", githubAPIVersion)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// サーバー側のエラー・レート制限だけを失敗として数える（404・401はリポジトリ・トークンごとの問題）
+		status = resp.StatusCode
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return fmt.Errorf("github returned status %d", status)
+		}
+		if status != http.StatusOK {
+			return nil
+		}
+		return json.NewDecoder(io.LimitReader(resp.Body, githubMaxResponseBytes)).Decode(out)
+	})
+	if err != nil {
+		g.logger.WithContext(ctx).Error("Failed to call GitHub API", logger.Any("path", path), logger.Error(err))
+		return 0, fmt.Errorf("%w: %v", usecase.ErrGitHubUnavailable, err)
+	}
+	return status, nil
+}
+
+func (i *githubIssue) toInfo(repository string) *domain.IssueInfo {
+	info := &domain.IssueInfo{
+		Ref:   domain.IssueRef{Repository: repository, Number: i.Number},
+		Kind:  domain.IssueKindIssue,
+		Title: i.Title,
+		State: domain.IssueStateOpen,
+		URL:   i.HTMLURL,
+	}
+	if i.PullRequest != nil {
+		info.Kind = domain.IssueKindPullRequest
+		if i.PullRequest.MergedAt != nil {
+			info.State = domain.IssueStateMerged
+		} else if i.State == "closed" {
+			info.State = domain.IssueStateClosed
+		}
+		return info
+	}
+	if i.State == "closed" {
+		// 理由のない古いIssueは完了として扱い、not_planned・duplicateなどは完了にしない
+		info.State = domain.IssueStateClosed
+		if i.StateReason != "" && i.StateReason != "completed" {
+			info.State = domain.IssueStateNotPlanned
+		}
+	}
+	return info
+}
+
+func (p *githubPullRequest) toInfo(repository string) *domain.IssueInfo {
+	info := &domain.IssueInfo{
+		Ref:   domain.IssueRef{Repository: repository, Number: p.Number},
+		Kind:  domain.IssueKindPullRequest,
+		Title: p.Title,
+		State: domain.IssueStateOpen,
+		URL:   p.HTMLURL,
+	}
+	if p.Merged {
+		info.State = domain.IssueStateMerged
+	} else if p.State == "closed" {
+		info.State = domain.IssueStateClosed
+	}
+	return info
+}
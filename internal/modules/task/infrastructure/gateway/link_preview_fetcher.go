@@ -0,0 +1,293 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// リンク先のページの取得の制約
+const (
+	linkPreviewTimeout      = 5 * time.Second
+	linkPreviewMaxBytes     = 1 << 20 // <head>を読むのに十分な大きさ
+	linkPreviewMaxRedirects = 3
+	linkPreviewUserAgent    = "Yotei+ LinkPreview/1.0 (+https://yotei-plus.com)"
+
+	maxPreviewTitleLength       = 200
+	maxPreviewDescriptionLength = 500
+)
+
+var (
+	// ErrForbiddenAddress はリンク先が内部ネットワークのアドレスの場合のエラー
+	ErrForbiddenAddress = errors.New("link preview target resolves to a non-public address")
+	// ErrNoPreview はリンク先のページにタイトルがない場合のエラー
+	ErrNoPreview = errors.New("link preview metadata not found")
+)
+
+// nonPublicNetworks はIsPrivateなどで判定できない、インターネットから到達できないアドレス範囲
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",       // この(現在の)ネットワーク
+	"100.64.0.0/10",   // キャリアグレードNAT
+	"192.0.0.0/24",    // IETFプロトコル割り当て
+	"192.0.2.0/24",    // ドキュメント用
+	"198.18.0.0/15",   // ベンチマーク用
+	"198.51.100.0/24", // ドキュメント用
+	"203.0.113.0/24",  // ドキュメント用
+	"240.0.0.0/4",     // 予約済み
+	"64:ff9b::/96",    // NAT64（内部のIPv4アドレスに変換される）
+	"2001:db8::/32",   // ドキュメント用
+)
+
+// HTTPLinkPreviewFetcher はリンク先のHTMLのOpenGraph（og:*）のメタデータからプレビューを取得するゲートウェイ実装
+// SSRF対策として、接続先のIPアドレスを接続時に検証し（DNSの再バインドにも対応）、リダイレクト先もドメインのポリシーで検証する
+type HTTPLinkPreviewFetcher struct {
+	policy     domain.LinkPreviewPolicy
+	httpClient *http.Client
+}
+
+// NewLinkPreviewFetcher はプレビューを取得するゲートウェイを作成する
+func NewLinkPreviewFetcher(policy domain.LinkPreviewPolicy) *HTTPLinkPreviewFetcher {
+	dialer := &net.Dialer{
+		Timeout: linkPreviewTimeout,
+		Control: rejectNonPublicAddress,
+	}
+	transport := &http.Transport{
+		// 環境変数のプロキシを使うと接続先のアドレスを検証できないため使用しない
+		Proxy:                  nil,
+		DialContext:            dialer.DialContext,
+		TLSHandshakeTimeout:    linkPreviewTimeout,
+		ResponseHeaderTimeout:  linkPreviewTimeout,
+		MaxResponseHeaderBytes: 64 << 10,
+		MaxIdleConns:           10,
+		IdleConnTimeout:        30 * time.Second,
+	}
+
+	f := &HTTPLinkPreviewFetcher{policy: policy}
+	f.httpClient = &http.Client{
+		Timeout:   linkPreviewTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > linkPreviewMaxRedirects {
+				return errors.New("too many redirects")
+			}
+			if !f.policy.Permits(req.URL.String()) {
+				return fmt.Errorf("redirect to %s is not permitted", req.URL.Host)
+			}
+			return nil
+		},
+	}
+	return f
+}
+
+// FetchLinkPreview はリンク先のページを取得してプレビューを作成する
+func (f *HTTPLinkPreviewFetcher) FetchLinkPreview(ctx context.Context, rawURL string) (*domain.LinkPreview, error) {
+	if !f.policy.Permits(rawURL) {
+		return nil, fmt.Errorf("link preview for %s is not permitted", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create link preview request: %w", err)
+	}
+	req.Header.Set("User-Agent", linkPreviewUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch link preview: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("link preview returned status %d", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("link preview content type %q is not HTML", contentType)
+	}
+
+	// Shift_JISなどのページもUTF-8として読む
+	body, err := charset.NewReader(io.LimitReader(resp.Body, linkPreviewMaxBytes), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect link preview charset: %w", err)
+	}
+
+	meta := parseHeadMetadata(body)
+	preview := meta.preview(resp.Request.URL)
+	if preview.Title == "" {
+		return nil, ErrNoPreview
+	}
+	return preview, nil
+}
+
+// headMetadata はHTMLの<head>から読み取ったメタデータ
+type headMetadata struct {
+	title       string
+	description string
+	properties  map[string]string // og:title など
+}
+
+// parseHeadMetadata はHTMLの<head>の<title>と<meta>を読み取る（<body>以降は読まない）
+func parseHeadMetadata(r io.Reader) *headMetadata {
+	meta := &headMetadata{properties: make(map[string]string)}
+	tokenizer := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return meta
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "body":
+				return meta
+			case "title":
+				inTitle = meta.title == ""
+			case "meta":
+				meta.readMetaTag(token)
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.Data == "head" {
+				return meta
+			}
+			if token.Data == "title" {
+				inTitle = false
+			}
+		case html.TextToken:
+			if inTitle {
+				meta.title += string(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// readMetaTag は<meta property="og:*">・<meta name="description">の内容を記録する（最初の値を優先する）
+func (m *headMetadata) readMetaTag(token html.Token) {
+	var key, content string
+	for _, attr := range token.Attr {
+		switch strings.ToLower(attr.Key) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(attr.Val))
+			}
+		case "content":
+			content = attr.Val
+		}
+	}
+	if key == "" || content == "" {
+		return
+	}
+	if key == "description" && m.description == "" {
+		m.description = content
+		return
+	}
+	if _, exists := m.properties[key]; !exists {
+		m.properties[key] = content
+	}
+}
+
+// preview はメタデータからプレビューを作成する（OpenGraphがない場合は<title>・descriptionを使用する）
+func (m *headMetadata) preview(pageURL *url.URL) *domain.LinkPreview {
+	title := firstNonEmpty(m.properties["og:title"], m.properties["twitter:title"], m.title)
+	description := firstNonEmpty(m.properties["og:description"], m.properties["twitter:description"], m.description)
+
+	return &domain.LinkPreview{
+		Title:       truncate(cleanText(title), maxPreviewTitleLength),
+		Description: truncate(cleanText(description), maxPreviewDescriptionLength),
+		ImageURL:    resolveImageURL(pageURL, firstNonEmpty(m.properties["og:image"], m.properties["twitter:image"])),
+		SiteName:    truncate(cleanText(m.properties["og:site_name"]), maxPreviewTitleLength),
+	}
+}
+
+// resolveImageURL は画像のURLをページのURLから解決する（http・https以外は使用しない）
+func resolveImageURL(pageURL *url.URL, image string) string {
+	if image == "" || pageURL == nil {
+		return ""
+	}
+	ref, err := url.Parse(strings.TrimSpace(image))
+	if err != nil {
+		return ""
+	}
+	resolved := pageURL.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
+
+// rejectNonPublicAddress は接続先がインターネット上のアドレスでない場合に接続を拒否する
+func rejectNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// isPublicIP はインターネット上のユニキャストアドレスかを返す
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// cleanText は改行などの連続する空白を1つの空白にする
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	return string(runes[:maxLength]) + "…"
+}
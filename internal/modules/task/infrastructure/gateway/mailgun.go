@@ -0,0 +1,293 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// mailgunMaxMemory はWebhookのmultipartを読み込む際にメモリに保持する最大サイズ（超えた分は一時ファイルに書き出す）
+	mailgunMaxMemory = 32 << 20
+
+	// mailgunSignatureTolerance はWebhookの署名の時刻として許容するずれ（リプレイ攻撃の対策）
+	mailgunSignatureTolerance = 5 * time.Minute
+)
+
+// MailgunGateway はMailgunのInbound Routes（forward）のWebhookを扱うゲートウェイ実装
+type MailgunGateway struct {
+	signingKey string
+	logger     logger.Logger
+	now        func() time.Time
+
+	// 受け付けたWebhookのtokenと、そのtokenの署名の時刻が許容範囲を外れる日時（同じWebhookの再送を拒否する）
+	mu         sync.Mutex
+	usedTokens map[string]time.Time
+}
+
+// NewMailgunGateway はMailgunのゲートウェイを作成する
+// signingKeyはWebhookの署名の検証に使うHTTP webhook signing key（空の場合はWebhookを受け付けない）
+func NewMailgunGateway(signingKey string, logger logger.Logger) usecase.InboundEmailParser {
+	return &MailgunGateway{
+		signingKey: signingKey,
+		logger:     logger,
+		now:        time.Now,
+		usedTokens: make(map[string]time.Time),
+	}
+}
+
+// ParseInboundEmail はWebhookの署名を検証して受信したメールを返す
+// 添付ファイルを含む場合はmultipart/form-data、含まない場合はapplication/x-www-form-urlencodedで届く
+func (g *MailgunGateway) ParseInboundEmail(contentType string, payload []byte) (*domain.InboundEmail, error) {
+	if g.signingKey == "" {
+		return nil, usecase.ErrInboundEmailDisabled
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", usecase.ErrInvalidInboundEmail, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if err := req.ParseMultipartForm(mailgunMaxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, fmt.Errorf("%w: %v", usecase.ErrInvalidInboundEmail, err)
+	}
+	if req.MultipartForm != nil {
+		defer func() {
+			if err := req.MultipartForm.RemoveAll(); err != nil {
+				g.logger.Warn("Failed to remove inbound email temporary files", logger.Error(err))
+			}
+		}()
+	}
+
+	if !g.verifySignature(req.FormValue("timestamp"), req.FormValue("token"), req.FormValue("signature")) {
+		return nil, usecase.ErrInvalidInboundSignature
+	}
+
+	headers, err := parseMailgunHeaders(req.FormValue("message-headers"))
+	if err != nil {
+		return nil, err
+	}
+
+	from := mailgunSender(req.FormValue("from"), req.FormValue("sender"))
+	email := &domain.InboundEmail{
+		Recipients:     strings.Split(req.FormValue("recipient"), ","),
+		From:           from,
+		MessageID:      strings.TrimSpace(headers.get("Message-Id")),
+		Subject:        req.FormValue("subject"),
+		Body:           req.FormValue("body-plain"),
+		SenderVerified: mailgunSenderVerified(headers, from, req.FormValue("sender")),
+		SpamFlagged:    strings.EqualFold(headers.get("X-Mailgun-Sflag"), "Yes"),
+	}
+	if score := headers.get("X-Mailgun-Sscore"); score != "" {
+		if email.SpamScore, err = strconv.ParseFloat(strings.TrimSpace(score), 64); err != nil {
+			return nil, fmt.Errorf("%w: invalid spam score", usecase.ErrInvalidInboundEmail)
+		}
+	}
+
+	if req.MultipartForm != nil {
+		if email.Attachments, err = mailgunAttachments(req.MultipartForm, req.FormValue("attachment-count")); err != nil {
+			return nil, err
+		}
+	}
+	return email, nil
+}
+
+// verifySignature はtimestampとtokenを連結した文字列のHMAC-SHA256（16進数）と署名を比較する
+// 時刻が許容範囲を外れたWebhookと、受け付け済みのtokenのWebhook（再送）は拒否する
+func (g *MailgunGateway) verifySignature(timestamp, token, signature string) bool {
+	if timestamp == "" || token == "" || signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(g.signingKey))
+	mac.Write([]byte(timestamp + token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return false
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	signedAt := time.Unix(seconds, 0)
+	now := g.now()
+	if diff := now.Sub(signedAt); diff > mailgunSignatureTolerance || diff < -mailgunSignatureTolerance {
+		return false
+	}
+	return g.useToken(token, signedAt.Add(mailgunSignatureTolerance), now)
+}
+
+// useToken はtokenを受け付け済みとして記録する（記録済みの場合はfalse）
+// tokenは署名の時刻が許容範囲を外れるまで保持し、それ以降の再送は時刻の検証で拒否する
+func (g *MailgunGateway) useToken(token string, expiresAt, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for used, expiry := range g.usedTokens {
+		if !expiry.After(now) {
+			delete(g.usedTokens, used)
+		}
+	}
+	if _, ok := g.usedTokens[token]; ok {
+		return false
+	}
+	g.usedTokens[token] = expiresAt
+	return true
+}
+
+// mailgunSenderVerified は差出人（Fromヘッダー）のドメインからの送信と確認できたか判定する
+// DKIMを通過し、全ての署名のドメイン（d=）が差出人のドメインと一致する場合（複数の署名のうちどれが通過したかは分からないため）、
+// またはSPFを通過し、エンベロープの差出人のドメインが差出人のドメインと一致する場合のみ確認済みとする
+func mailgunSenderVerified(headers mailgunHeaders, from, envelopeSender string) bool {
+	fromDomain := addressDomain(from)
+	if fromDomain == "" {
+		return false
+	}
+
+	if strings.EqualFold(headers.get("X-Mailgun-Dkim-Check-Result"), "Pass") {
+		signatures := headers.getAll("DKIM-Signature")
+		aligned := len(signatures) > 0
+		for _, signature := range signatures {
+			if !domainAligned(fromDomain, dkimSigningDomain(signature)) {
+				aligned = false
+				break
+			}
+		}
+		if aligned {
+			return true
+		}
+	}
+
+	return strings.EqualFold(headers.get("X-Mailgun-Spf"), "Pass") &&
+		domainAligned(fromDomain, addressDomain(strings.TrimSpace(envelopeSender)))
+}
+
+// dkimSigningDomain はDKIM-Signatureヘッダーのdタグ（署名したドメイン）を小文字で返す
+func dkimSigningDomain(signature string) string {
+	for _, tag := range strings.Split(signature, ";") {
+		name, value, ok := strings.Cut(tag, "=")
+		if ok && strings.TrimSpace(name) == "d" {
+			return strings.ToLower(strings.Join(strings.Fields(value), ""))
+		}
+	}
+	return ""
+}
+
+// addressDomain はメールアドレスのドメインを小文字で返す
+func addressDomain(address string) string {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSuffix(address[at+1:], "."))
+}
+
+// domainAligned は差出人のドメインが確認済みのドメインと同じか、そのサブドメインであるか判定する
+// 確認済みのドメインが差出人のドメインのサブドメインの場合は一致とみなさない（co.jpなどの共通のドメインの下の別の組織を区別できないため）
+func domainAligned(fromDomain, verifiedDomain string) bool {
+	if verifiedDomain == "" {
+		return false
+	}
+	return fromDomain == verifiedDomain || strings.HasSuffix(fromDomain, "."+verifiedDomain)
+}
+
+// mailgunHeaders はWebhookのmessage-headers（[名前, 値]の配列）
+type mailgunHeaders [][2]string
+
+// parseMailgunHeaders はmessage-headersのJSONを読み込む（空の場合はヘッダーなし）
+func parseMailgunHeaders(raw string) (mailgunHeaders, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var headers mailgunHeaders
+	if err := json.Unmarshal([]byte(raw), &headers); err != nil {
+		return nil, fmt.Errorf("%w: invalid message-headers: %v", usecase.ErrInvalidInboundEmail, err)
+	}
+	return headers, nil
+}
+
+// get は名前（大文字・小文字を区別しない）が一致する最初のヘッダーの値を返す
+func (h mailgunHeaders) get(name string) string {
+	for _, header := range h {
+		if strings.EqualFold(header[0], name) {
+			return header[1]
+		}
+	}
+	return ""
+}
+
+// getAll は名前（大文字・小文字を区別しない）が一致する全てのヘッダーの値を返す
+func (h mailgunHeaders) getAll(name string) []string {
+	var values []string
+	for _, header := range h {
+		if strings.EqualFold(header[0], name) {
+			values = append(values, header[1])
+		}
+	}
+	return values
+}
+
+// mailgunSender はFromヘッダーのメールアドレスを小文字で返す（読み込めない場合はエンベロープの差出人）
+func mailgunSender(from, sender string) string {
+	if addr, err := mail.ParseAddress(from); err == nil {
+		return strings.ToLower(addr.Address)
+	}
+	return strings.ToLower(strings.TrimSpace(sender))
+}
+
+// mailgunAttachments はattachment-1〜attachment-Nのファイルを読み込む
+func mailgunAttachments(form *multipart.Form, rawCount string) ([]domain.InboundAttachment, error) {
+	if rawCount == "" {
+		return nil, nil
+	}
+	count, err := strconv.Atoi(rawCount)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("%w: invalid attachment-count", usecase.ErrInvalidInboundEmail)
+	}
+	// 実際に届いたファイルより多い件数は読まない
+	if count > len(form.File) {
+		count = len(form.File)
+	}
+
+	attachments := make([]domain.InboundAttachment, 0, count)
+	for i := 1; i <= count; i++ {
+		files := form.File["attachment-"+strconv.Itoa(i)]
+		if len(files) == 0 {
+			continue
+		}
+		content, err := readMultipartFile(files[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", usecase.ErrInvalidInboundEmail, err)
+		}
+		attachments = append(attachments, domain.InboundAttachment{
+			Filename: files[0].Filename,
+			Content:  content,
+		})
+	}
+	return attachments, nil
+}
+
+func readMultipartFile(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
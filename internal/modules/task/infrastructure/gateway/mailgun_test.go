@@ -0,0 +1,196 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const testSigningKey = "test-signing-key"
+
+var testNow = time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+func newTestMailgunGateway() *MailgunGateway {
+	g := NewMailgunGateway(testSigningKey, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})).(*MailgunGateway)
+	g.now = func() time.Time { return testNow }
+	return g
+}
+
+// mailgunPayload はtimestampとtokenで署名したWebhookの本文を作成する
+func mailgunPayload(t *testing.T, signedAt time.Time, token string, from, sender string, headers [][2]string) []byte {
+	t.Helper()
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSigningKey))
+	mac.Write([]byte(timestamp + token))
+
+	rawHeaders, err := json.Marshal(headers)
+	require.NoError(t, err)
+
+	form := url.Values{
+		"timestamp":       {timestamp},
+		"token":           {token},
+		"signature":       {hex.EncodeToString(mac.Sum(nil))},
+		"recipient":       {"abc@in.example.com"},
+		"from":            {from},
+		"sender":          {sender},
+		"subject":         {"件名"},
+		"body-plain":      {"本文"},
+		"message-headers": {string(rawHeaders)},
+	}
+	return []byte(form.Encode())
+}
+
+func TestMailgunGateway_ParseInboundEmail_Signature(t *testing.T) {
+	const contentType = "application/x-www-form-urlencoded"
+
+	t.Run("正常系: 署名の時刻が許容範囲内", func(t *testing.T) {
+		g := newTestMailgunGateway()
+		payload := mailgunPayload(t, testNow.Add(-time.Minute), "token-1", "alice@example.com", "alice@example.com", nil)
+
+		email, err := g.ParseInboundEmail(contentType, payload)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", email.From)
+	})
+
+	t.Run("異常系: 時刻が古いWebhookは拒否する", func(t *testing.T) {
+		g := newTestMailgunGateway()
+		payload := mailgunPayload(t, testNow.Add(-10*time.Minute), "token-1", "alice@example.com", "alice@example.com", nil)
+
+		_, err := g.ParseInboundEmail(contentType, payload)
+		assert.ErrorIs(t, err, usecase.ErrInvalidInboundSignature)
+	})
+
+	t.Run("異常系: 時刻が未来のWebhookは拒否する", func(t *testing.T) {
+		g := newTestMailgunGateway()
+		payload := mailgunPayload(t, testNow.Add(10*time.Minute), "token-1", "alice@example.com", "alice@example.com", nil)
+
+		_, err := g.ParseInboundEmail(contentType, payload)
+		assert.ErrorIs(t, err, usecase.ErrInvalidInboundSignature)
+	})
+
+	t.Run("異常系: 同じtokenの再送は拒否する", func(t *testing.T) {
+		g := newTestMailgunGateway()
+		payload := mailgunPayload(t, testNow, "token-1", "alice@example.com", "alice@example.com", nil)
+
+		_, err := g.ParseInboundEmail(contentType, payload)
+		require.NoError(t, err)
+
+		_, err = g.ParseInboundEmail(contentType, payload)
+		assert.ErrorIs(t, err, usecase.ErrInvalidInboundSignature)
+	})
+
+	t.Run("異常系: 署名が一致しない", func(t *testing.T) {
+		g := newTestMailgunGateway()
+		payload := mailgunPayload(t, testNow, "token-1", "alice@example.com", "alice@example.com", nil)
+		g.signingKey = "other-key"
+
+		_, err := g.ParseInboundEmail(contentType, payload)
+		assert.ErrorIs(t, err, usecase.ErrInvalidInboundSignature)
+	})
+
+	t.Run("正常系: 期限切れのtokenは記録から削除する", func(t *testing.T) {
+		g := newTestMailgunGateway()
+		_, err := g.ParseInboundEmail(contentType, mailgunPayload(t, testNow, "token-1", "alice@example.com", "alice@example.com", nil))
+		require.NoError(t, err)
+
+		g.now = func() time.Time { return testNow.Add(10 * time.Minute) }
+		_, err = g.ParseInboundEmail(contentType, mailgunPayload(t, testNow.Add(10*time.Minute), "token-2", "alice@example.com", "alice@example.com", nil))
+		require.NoError(t, err)
+		assert.NotContains(t, g.usedTokens, "token-1")
+	})
+}
+
+func TestMailgunGateway_ParseInboundEmail_SenderVerified(t *testing.T) {
+	const contentType = "application/x-www-form-urlencoded"
+
+	tests := []struct {
+		name     string
+		from     string
+		sender   string
+		headers  [][2]string
+		verified bool
+	}{
+		{
+			name:     "DKIMの署名のドメインが差出人と一致",
+			from:     "Alice <alice@example.com>",
+			sender:   "bounce@mailer.example.net",
+			headers:  [][2]string{{"X-Mailgun-Dkim-Check-Result", "Pass"}, {"DKIM-Signature", "v=1; a=rsa-sha256; d=example.com; s=sel; b=xxx"}},
+			verified: true,
+		},
+		{
+			name:     "DKIMの署名のドメインが差出人の親ドメイン",
+			from:     "alice@mail.example.com",
+			sender:   "bounce@mailer.example.net",
+			headers:  [][2]string{{"X-Mailgun-Dkim-Check-Result", "Pass"}, {"DKIM-Signature", "v=1; d=example.com; s=sel"}},
+			verified: true,
+		},
+		{
+			name:     "DKIMの署名のドメインが差出人と異なる",
+			from:     "ceo@example.com",
+			sender:   "attacker@evil.test",
+			headers:  [][2]string{{"X-Mailgun-Dkim-Check-Result", "Pass"}, {"DKIM-Signature", "v=1; d=evil.test; s=sel"}},
+			verified: false,
+		},
+		{
+			name:   "差出人と異なるドメインの署名が含まれる",
+			from:   "ceo@example.com",
+			sender: "attacker@evil.test",
+			headers: [][2]string{
+				{"X-Mailgun-Dkim-Check-Result", "Pass"},
+				{"DKIM-Signature", "v=1; d=example.com; s=sel"},
+				{"DKIM-Signature", "v=1; d=evil.test; s=sel"},
+			},
+			verified: false,
+		},
+		{
+			name:     "DKIMの署名のドメインが差出人のサブドメイン",
+			from:     "ceo@example.com",
+			sender:   "attacker@evil.test",
+			headers:  [][2]string{{"X-Mailgun-Dkim-Check-Result", "Pass"}, {"DKIM-Signature", "v=1; d=evil.example.com; s=sel"}},
+			verified: false,
+		},
+		{
+			name:     "SPFのエンベロープの差出人のドメインが一致",
+			from:     "alice@example.com",
+			sender:   "alice@example.com",
+			headers:  [][2]string{{"X-Mailgun-Spf", "Pass"}},
+			verified: true,
+		},
+		{
+			name:     "SPFのエンベロープの差出人のドメインが異なる",
+			from:     "ceo@example.com",
+			sender:   "attacker@evil.test",
+			headers:  [][2]string{{"X-Mailgun-Spf", "Pass"}},
+			verified: false,
+		},
+		{
+			name:     "SPF・DKIMのいずれも通過しない",
+			from:     "alice@example.com",
+			sender:   "alice@example.com",
+			headers:  [][2]string{{"X-Mailgun-Spf", "Fail"}, {"X-Mailgun-Dkim-Check-Result", "Fail"}, {"DKIM-Signature", "v=1; d=example.com"}},
+			verified: false,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestMailgunGateway()
+			payload := mailgunPayload(t, testNow, "token-"+strconv.Itoa(i), tt.from, tt.sender, tt.headers)
+
+			email, err := g.ParseInboundEmail(contentType, payload)
+			require.NoError(t, err)
+			assert.Equal(t, tt.verified, email.SenderVerified)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/smtpmail"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/report"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// SMTPWeeklyReportMailer はSMTPで週次レポートを送信するゲートウェイ実装
+type SMTPWeeklyReportMailer struct {
+	config *config.Config
+	logger logger.Logger
+	// SMTPサーバーが応答しない・失敗が続く場合に送信をやめるサーキットブレーカー（nilの場合は制限しない）
+	breaker *circuitbreaker.Breaker
+	// SMTP送信関数（smtpmail.Send）
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewWeeklyReportMailer は設定に応じた週次レポートのメールゲートウェイを作成する
+// SMTP_HOSTが未設定の場合は送信内容をログに出力するだけのゲートウェイを返す
+func NewWeeklyReportMailer(config *config.Config, breaker *circuitbreaker.Breaker, logger logger.Logger) usecase.WeeklyReportMailer {
+	if config.External.SMTPHost == "" {
+		return &LogWeeklyReportMailer{logger: logger}
+	}
+	return &SMTPWeeklyReportMailer{
+		config:   config,
+		logger:   logger,
+		breaker:  breaker,
+		sendMail: smtpmail.Send,
+	}
+}
+
+// SendWeeklyReport は週次レポートをメールで送信する（PDFを添付する）
+func (g *SMTPWeeklyReportMailer) SendWeeklyReport(ctx context.Context, user *commonDomain.UserInfo, weekly *domain.WeeklyReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(g.config.External.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_FROM address: %w", err)
+	}
+	to := &mail.Address{Name: user.Username, Address: user.Email}
+
+	msg, err := BuildWeeklyReportMessage(from, to, weekly)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if g.config.External.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", g.config.External.SMTPUsername, g.config.External.SMTPPassword, g.config.External.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(g.config.External.SMTPHost, g.config.External.SMTPPort)
+	err = g.breaker.Do(ctx, func(ctx context.Context) error {
+		return g.sendMail(ctx, addr, auth, from.Address, []string{to.Address}, msg)
+	})
+	if err != nil {
+		g.logger.Error("Failed to send weekly report email",
+			logger.Any("userID", user.ID),
+			logger.Any("week", weekly.Week),
+			logger.Error(err))
+		return fmt.Errorf("failed to send weekly report email: %w", err)
+	}
+
+	g.logger.Info("Weekly report email sent", logger.Any("userID", user.ID), logger.Any("week", weekly.Week))
+	return nil
+}
+
+// LogWeeklyReportMailer はSMTP未設定時に週次レポートをログ出力するゲートウェイ実装（開発用）
+type LogWeeklyReportMailer struct {
+	logger logger.Logger
+}
+
+// SendWeeklyReport は週次レポートの概要をログに出力する
+func (g *LogWeeklyReportMailer) SendWeeklyReport(ctx context.Context, user *commonDomain.UserInfo, weekly *domain.WeeklyReport) error {
+	g.logger.Info("Weekly report email (SMTP not configured)",
+		logger.Any("userID", user.ID),
+		logger.Any("to", user.Email),
+		logger.Any("week", weekly.Week),
+		logger.Any("completed", weekly.CompletedCount),
+		logger.Any("slipped", weekly.SlippedCount))
+	return nil
+}
+
+// BuildWeeklyReportMessage はテキスト・HTMLの本文にPDFを添付した週次レポートのメールを作成する
+func BuildWeeklyReportMessage(from, to *mail.Address, weekly *domain.WeeklyReport) ([]byte, error) {
+	text, err := report.Text(weekly)
+	if err != nil {
+		return nil, err
+	}
+	html, err := report.HTML(weekly)
+	if err != nil {
+		return nil, err
+	}
+
+	// 本文（multipart/alternative）
+	var alternative bytes.Buffer
+	altWriter := multipart.NewWriter(&alternative)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		w, err := altWriter.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := altWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	// 本文とPDFの添付（multipart/mixed）
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	w, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + altWriter.Boundary()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(alternative.Bytes()); err != nil {
+		return nil, err
+	}
+
+	filename := fmt.Sprintf("weekly-report-%s.pdf", weekly.Week)
+	w, err = writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType("application/pdf", map[string]string{"name": filename})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(wrapBase64(report.PDF(weekly))); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.BEncoding.Encode("UTF-8", report.Subject(weekly))},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/mixed; boundary=" + writer.Boundary()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// wrapBase64 はRFC 2045に従い76文字ごとに改行したBase64を返す
+func wrapBase64(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var buf bytes.Buffer
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76])
+		buf.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,1550 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// EscalationRuleRepository はエスカレーションルールのインメモリリポジトリ
+type EscalationRuleRepository struct {
+	mu          sync.RWMutex
+	rules       map[string]*domain.EscalationRule
+	escalations map[[2]string]time.Time // (taskID, ruleID) → 適用日時
+}
+
+// NewEscalationRuleRepository は新しいEscalationRuleRepositoryを作成する
+func NewEscalationRuleRepository() *EscalationRuleRepository {
+	return &EscalationRuleRepository{
+		rules:       make(map[string]*domain.EscalationRule),
+		escalations: make(map[[2]string]time.Time),
+	}
+}
+
+// CreateRule はルールを作成する
+func (r *EscalationRuleRepository) CreateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ID] = cloneRule(rule)
+	return nil
+}
+
+// GetRuleByID はIDでルールを取得する
+func (r *EscalationRuleRepository) GetRuleByID(ctx context.Context, id string) (*domain.EscalationRule, error) {
+	if id == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, usecase.ErrEscalationRuleNotFound
+	}
+	return cloneRule(rule), nil
+}
+
+// ListRulesByOwner はユーザーまたはグループのルールを取得する
+func (r *EscalationRuleRepository) ListRulesByOwner(ctx context.Context, scope domain.EscalationScope, ownerID string) ([]*domain.EscalationRule, error) {
+	return r.list(func(rule *domain.EscalationRule) bool {
+		return rule.Scope == scope && rule.OwnerID == ownerID
+	}), nil
+}
+
+// ListEnabledRules は有効なルールを全て取得する
+func (r *EscalationRuleRepository) ListEnabledRules(ctx context.Context) ([]*domain.EscalationRule, error) {
+	return r.list(func(rule *domain.EscalationRule) bool { return rule.Enabled }), nil
+}
+
+// UpdateRule はルールを更新する
+func (r *EscalationRuleRepository) UpdateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[rule.ID]; !ok {
+		return usecase.ErrEscalationRuleNotFound
+	}
+	r.rules[rule.ID] = cloneRule(rule)
+	return nil
+}
+
+// DeleteRule はルールを削除する
+func (r *EscalationRuleRepository) DeleteRule(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return usecase.ErrEscalationRuleNotFound
+	}
+	delete(r.rules, id)
+	for key := range r.escalations {
+		if key[1] == id {
+			delete(r.escalations, key)
+		}
+	}
+	return nil
+}
+
+// HasEscalated はルールがタスクに適用済みかを確認する
+func (r *EscalationRuleRepository) HasEscalated(ctx context.Context, taskID, ruleID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.escalations[[2]string{taskID, ruleID}]
+	return ok, nil
+}
+
+// RecordEscalation は適用済みとして記録する（記録済みの場合は何もしない）
+func (r *EscalationRuleRepository) RecordEscalation(ctx context.Context, taskID, ruleID string, escalatedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := [2]string{taskID, ruleID}
+	if _, ok := r.escalations[key]; !ok {
+		r.escalations[key] = escalatedAt
+	}
+	return nil
+}
+
+// list は条件に一致するルールを発動までの時間が短い順に返す
+func (r *EscalationRuleRepository) list(match func(rule *domain.EscalationRule) bool) []*domain.EscalationRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := []*domain.EscalationRule{}
+	for _, rule := range r.rules {
+		if match(rule) {
+			rules = append(rules, cloneRule(rule))
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].OverdueHours != rules[j].OverdueHours {
+			return rules[i].OverdueHours < rules[j].OverdueHours
+		}
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+	return rules
+}
+
+func cloneRule(rule *domain.EscalationRule) *domain.EscalationRule {
+	c := *rule
+	c.ReassignTo = cloneString(rule.ReassignTo)
+	return &c
+}
+
+// WorkloadRepository は勤務時間設定のインメモリリポジトリ
+type WorkloadRepository struct {
+	mu    sync.RWMutex
+	hours map[string]*domain.WorkingHours
+}
+
+// NewWorkloadRepository は新しいWorkloadRepositoryを作成する
+func NewWorkloadRepository() *WorkloadRepository {
+	return &WorkloadRepository{
+		hours: make(map[string]*domain.WorkingHours),
+	}
+}
+
+// GetWorkingHours は勤務時間設定を取得する（未設定の場合は nil, nil）
+func (r *WorkloadRepository) GetWorkingHours(ctx context.Context, userID string) (*domain.WorkingHours, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hours, ok := r.hours[userID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneWorkingHours(hours), nil
+}
+
+// SaveWorkingHours は勤務時間設定を保存する
+func (r *WorkloadRepository) SaveWorkingHours(ctx context.Context, hours *domain.WorkingHours) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hours[hours.UserID] = cloneWorkingHours(hours)
+	return nil
+}
+
+func cloneWorkingHours(hours *domain.WorkingHours) *domain.WorkingHours {
+	c := *hours
+	c.WorkDays = append([]time.Weekday(nil), hours.WorkDays...)
+	return &c
+}
+
+// CommentRepository はタスクコメントのインメモリリポジトリ
+type CommentRepository struct {
+	mu       sync.RWMutex
+	comments []*domain.TaskComment
+}
+
+// NewCommentRepository は新しいCommentRepositoryを作成する
+func NewCommentRepository() *CommentRepository {
+	return &CommentRepository{}
+}
+
+// CreateComment はコメントを作成する
+func (r *CommentRepository) CreateComment(ctx context.Context, comment *domain.TaskComment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *comment
+	r.comments = append(r.comments, &c)
+	return nil
+}
+
+// ListComments はタスクのコメントを古い順に取得する
+func (r *CommentRepository) ListComments(ctx context.Context, taskID string, pagination domain.Pagination) ([]*domain.TaskComment, int, error) {
+	r.mu.RLock()
+	comments := []*domain.TaskComment{}
+	for _, comment := range r.comments {
+		if comment.TaskID == taskID {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	return paginate(comments, pagination), len(comments), nil
+}
+
+// ListCommentsByUser はユーザーが書いたコメントを古い順に全件取得する
+func (r *CommentRepository) ListCommentsByUser(ctx context.Context, userID string) ([]*domain.TaskComment, error) {
+	r.mu.RLock()
+	comments := []*domain.TaskComment{}
+	for _, comment := range r.comments {
+		if comment.UserID == userID {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	return comments, nil
+}
+
+// MentionRepository はメンション記録のインメモリリポジトリ
+type MentionRepository struct {
+	mu       sync.RWMutex
+	mentions []*domain.Mention
+	seen     map[mentionKey]bool
+}
+
+// mentionKey はメンションの一意キー（MySQLのunique_mentionと同じ）
+type mentionKey struct {
+	sourceType      domain.MentionSourceType
+	sourceID        string
+	mentionedUserID string
+}
+
+// NewMentionRepository は新しいMentionRepositoryを作成する
+func NewMentionRepository() *MentionRepository {
+	return &MentionRepository{
+		seen: make(map[mentionKey]bool),
+	}
+}
+
+// SaveMentions はメンションを保存する（同じメンション元・ユーザーの記録がある場合は無視する）
+func (r *MentionRepository) SaveMentions(ctx context.Context, mentions []*domain.Mention) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, mention := range mentions {
+		key := mentionKey{mention.SourceType, mention.SourceID, mention.MentionedUserID}
+		if r.seen[key] {
+			continue
+		}
+		r.seen[key] = true
+		m := *mention
+		r.mentions = append(r.mentions, &m)
+	}
+	return nil
+}
+
+// ListMentionsForUser はユーザー宛てのメンションを新しい順に取得する
+func (r *MentionRepository) ListMentionsForUser(ctx context.Context, userID string, pagination domain.Pagination) ([]*domain.Mention, int, error) {
+	r.mu.RLock()
+	mentions := []*domain.Mention{}
+	for _, mention := range r.mentions {
+		if mention.MentionedUserID == userID {
+			m := *mention
+			mentions = append(mentions, &m)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(mentions, func(i, j int) bool {
+		if !mentions[i].CreatedAt.Equal(mentions[j].CreatedAt) {
+			return mentions[i].CreatedAt.After(mentions[j].CreatedAt)
+		}
+		return mentions[i].ID < mentions[j].ID
+	})
+	return paginate(mentions, pagination), len(mentions), nil
+}
+
+// ShareLinkRepository は共有リンクのインメモリリポジトリ
+type ShareLinkRepository struct {
+	mu    sync.RWMutex
+	links map[string]*domain.ShareLink
+}
+
+// NewShareLinkRepository は新しいShareLinkRepositoryを作成する
+func NewShareLinkRepository() *ShareLinkRepository {
+	return &ShareLinkRepository{
+		links: make(map[string]*domain.ShareLink),
+	}
+}
+
+// CreateShareLink は共有リンクを作成する
+func (r *ShareLinkRepository) CreateShareLink(ctx context.Context, link *domain.ShareLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.links[link.ID] = cloneShareLink(link)
+	return nil
+}
+
+// GetShareLinkByID はIDで共有リンクを取得する
+func (r *ShareLinkRepository) GetShareLinkByID(ctx context.Context, id string) (*domain.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, ok := r.links[id]
+	if !ok {
+		return nil, usecase.ErrShareLinkNotFound
+	}
+	return cloneShareLink(link), nil
+}
+
+// GetShareLinkByToken はトークンで共有リンクを取得する
+func (r *ShareLinkRepository) GetShareLinkByToken(ctx context.Context, token string) (*domain.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, link := range r.links {
+		if link.Token == token {
+			return cloneShareLink(link), nil
+		}
+	}
+	return nil, usecase.ErrShareLinkNotFound
+}
+
+// ListShareLinksByOwner はユーザーが作成した共有リンクを新しい順に取得する
+func (r *ShareLinkRepository) ListShareLinksByOwner(ctx context.Context, ownerID string) ([]*domain.ShareLink, error) {
+	r.mu.RLock()
+	links := []*domain.ShareLink{}
+	for _, link := range r.links {
+		if link.OwnerID == ownerID {
+			links = append(links, cloneShareLink(link))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(links, func(i, j int) bool { return links[i].CreatedAt.After(links[j].CreatedAt) })
+	return links, nil
+}
+
+// CountActiveShareLinks は無効化・期限切れでない共有リンクの数を取得する
+func (r *ShareLinkRepository) CountActiveShareLinks(ctx context.Context, ownerID string, now time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, link := range r.links {
+		if link.OwnerID == ownerID && link.RevokedAt == nil && (link.ExpiresAt == nil || link.ExpiresAt.After(now)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RevokeShareLink は共有リンクを無効化する
+func (r *ShareLinkRepository) RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	link, ok := r.links[id]
+	if !ok || link.RevokedAt != nil {
+		return usecase.ErrShareLinkNotFound
+	}
+	link.RevokedAt = &revokedAt
+	return nil
+}
+
+func cloneShareLink(link *domain.ShareLink) *domain.ShareLink {
+	c := *link
+	c.TaskID = cloneString(link.TaskID)
+	c.ExpiresAt = cloneTime(link.ExpiresAt)
+	c.RevokedAt = cloneTime(link.RevokedAt)
+	if link.Filter != nil {
+		filter := *link.Filter
+		c.Filter = &filter
+	}
+	return &c
+}
+
+// MilestoneRepository はマイルストーンのインメモリリポジトリ
+type MilestoneRepository struct {
+	mu         sync.RWMutex
+	milestones map[string]*domain.Milestone
+	taskLinks  map[string]string // taskID → milestoneID
+}
+
+// NewMilestoneRepository は新しいMilestoneRepositoryを作成する
+func NewMilestoneRepository() *MilestoneRepository {
+	return &MilestoneRepository{
+		milestones: make(map[string]*domain.Milestone),
+		taskLinks:  make(map[string]string),
+	}
+}
+
+// CreateMilestone はマイルストーンを作成する
+func (r *MilestoneRepository) CreateMilestone(ctx context.Context, milestone *domain.Milestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *milestone
+	r.milestones[milestone.ID] = &c
+	return nil
+}
+
+// GetMilestoneByID はIDでマイルストーンを取得する
+func (r *MilestoneRepository) GetMilestoneByID(ctx context.Context, id string) (*domain.Milestone, error) {
+	if id == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	milestone, ok := r.milestones[id]
+	if !ok {
+		return nil, usecase.ErrMilestoneNotFound
+	}
+	c := *milestone
+	return &c, nil
+}
+
+// ListMilestonesByGroup はグループのマイルストーンを並び順で取得する
+func (r *MilestoneRepository) ListMilestonesByGroup(ctx context.Context, groupID string) ([]*domain.Milestone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	milestones := []*domain.Milestone{}
+	for _, milestone := range r.milestones {
+		if milestone.GroupID == groupID {
+			c := *milestone
+			milestones = append(milestones, &c)
+		}
+	}
+	sort.SliceStable(milestones, func(i, j int) bool {
+		if milestones[i].Position != milestones[j].Position {
+			return milestones[i].Position < milestones[j].Position
+		}
+		return milestones[i].CreatedAt.Before(milestones[j].CreatedAt)
+	})
+	return milestones, nil
+}
+
+// UpdateMilestone はマイルストーンを更新する
+func (r *MilestoneRepository) UpdateMilestone(ctx context.Context, milestone *domain.Milestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.milestones[milestone.ID]; !ok {
+		return usecase.ErrMilestoneNotFound
+	}
+	c := *milestone
+	r.milestones[milestone.ID] = &c
+	return nil
+}
+
+// DeleteMilestone はマイルストーンとタスクの紐付けを削除する
+func (r *MilestoneRepository) DeleteMilestone(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.milestones[id]; !ok {
+		return usecase.ErrMilestoneNotFound
+	}
+	delete(r.milestones, id)
+	for taskID, milestoneID := range r.taskLinks {
+		if milestoneID == id {
+			delete(r.taskLinks, taskID)
+		}
+	}
+	return nil
+}
+
+// SetTaskMilestone はタスクをマイルストーンに紐付ける（既存の紐付けは置き換える）
+func (r *MilestoneRepository) SetTaskMilestone(ctx context.Context, taskID, milestoneID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.milestones[milestoneID]; !ok {
+		return usecase.ErrMilestoneNotFound
+	}
+	r.taskLinks[taskID] = milestoneID
+	return nil
+}
+
+// RemoveTaskMilestone はタスクとマイルストーンの紐付けを解除する
+func (r *MilestoneRepository) RemoveTaskMilestone(ctx context.Context, taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.taskLinks, taskID)
+	return nil
+}
+
+// GetMilestoneIDForTask はタスクが属するマイルストーンIDを取得する（未設定の場合は空文字）
+func (r *MilestoneRepository) GetMilestoneIDForTask(ctx context.Context, taskID string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.taskLinks[taskID], nil
+}
+
+// ListMilestoneTaskIDs はマイルストーンに紐付いたタスクIDを取得する
+func (r *MilestoneRepository) ListMilestoneTaskIDs(ctx context.Context, milestoneID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var taskIDs []string
+	for taskID, id := range r.taskLinks {
+		if id == milestoneID {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Strings(taskIDs)
+	return taskIDs, nil
+}
+
+// DependencyRepository はタスク間の依存関係のインメモリリポジトリ
+type DependencyRepository struct {
+	mu           sync.RWMutex
+	dependencies map[string][]*domain.TaskDependency // taskID → 依存関係（追加順）
+}
+
+// NewDependencyRepository は新しいDependencyRepositoryを作成する
+func NewDependencyRepository() *DependencyRepository {
+	return &DependencyRepository{
+		dependencies: make(map[string][]*domain.TaskDependency),
+	}
+}
+
+// AddDependency は依存関係を追加する（登録済みの場合は何もしない）
+func (r *DependencyRepository) AddDependency(ctx context.Context, dependency *domain.TaskDependency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.dependencies[dependency.TaskID] {
+		if existing.DependsOnID == dependency.DependsOnID {
+			return nil
+		}
+	}
+	c := *dependency
+	r.dependencies[dependency.TaskID] = append(r.dependencies[dependency.TaskID], &c)
+	return nil
+}
+
+// RemoveDependency は依存関係を削除する
+func (r *DependencyRepository) RemoveDependency(ctx context.Context, taskID, dependsOnID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dependencies := r.dependencies[taskID]
+	for i, existing := range dependencies {
+		if existing.DependsOnID == dependsOnID {
+			r.dependencies[taskID] = append(dependencies[:i:i], dependencies[i+1:]...)
+			return nil
+		}
+	}
+	return usecase.ErrDependencyNotFound
+}
+
+// ListDependencies は指定したタスクが依存している依存関係を取得する
+func (r *DependencyRepository) ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.TaskDependency{}
+	for _, taskID := range taskIDs {
+		for _, dependency := range r.dependencies[taskID] {
+			c := *dependency
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+// TaskHistoryRepository はタスクの変更イベントとスナップショットのインメモリリポジトリ
+type TaskHistoryRepository struct {
+	mu        sync.RWMutex
+	events    map[string][]*domain.TaskEvent    // taskID → 連番順のイベント
+	snapshots map[string][]*domain.TaskSnapshot // taskID → 連番順のスナップショット
+}
+
+// NewTaskHistoryRepository は新しいTaskHistoryRepositoryを作成する
+func NewTaskHistoryRepository() *TaskHistoryRepository {
+	return &TaskHistoryRepository{
+		events:    make(map[string][]*domain.TaskEvent),
+		snapshots: make(map[string][]*domain.TaskSnapshot),
+	}
+}
+
+// AppendTaskEvent はイベントを追記する（連番が最後のイベントより大きくない場合はエラー）
+func (r *TaskHistoryRepository) AppendTaskEvent(ctx context.Context, event *domain.TaskEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := r.events[event.TaskID]
+	if len(events) > 0 && events[len(events)-1].Sequence >= event.Sequence {
+		return fmt.Errorf("task event sequence %d already exists", event.Sequence)
+	}
+	c := *event
+	r.events[event.TaskID] = append(events, &c)
+	return nil
+}
+
+// GetLastTaskEventSequence はタスクの最後のイベントの連番を返す（イベントがない場合は0）
+func (r *TaskHistoryRepository) GetLastTaskEventSequence(ctx context.Context, taskID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := r.events[taskID]
+	if len(events) == 0 {
+		return 0, nil
+	}
+	return events[len(events)-1].Sequence, nil
+}
+
+// ListTaskEvents は連番がafterSequenceより大きく、until以前に発生したイベントを連番順に取得する
+func (r *TaskHistoryRepository) ListTaskEvents(ctx context.Context, taskID string, afterSequence int64, until time.Time) ([]*domain.TaskEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.TaskEvent{}
+	for _, event := range r.events[taskID] {
+		if event.Sequence > afterSequence && !event.OccurredAt.After(until) {
+			c := *event
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+// ListStatusEvents は複数タスクの作成・ステータス変更のイベントのうちuntil以前に発生したものを発生順に取得する
+func (r *TaskHistoryRepository) ListStatusEvents(ctx context.Context, taskIDs []string, until time.Time) ([]*domain.TaskEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.TaskEvent{}
+	for _, taskID := range taskIDs {
+		for _, event := range r.events[taskID] {
+			if (event.Type == domain.TaskEventCreated || event.Type == domain.TaskEventStatusChanged) && !event.OccurredAt.After(until) {
+				c := *event
+				result = append(result, &c)
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].OccurredAt.Before(result[j].OccurredAt) })
+	return result, nil
+}
+
+// SaveTaskSnapshot はスナップショットを保存する
+func (r *TaskHistoryRepository) SaveTaskSnapshot(ctx context.Context, snapshot *domain.TaskSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *snapshot
+	r.snapshots[snapshot.TaskID] = append(r.snapshots[snapshot.TaskID], &c)
+	return nil
+}
+
+// GetLatestTaskSnapshot はuntil以前に取得した最新のスナップショットを返す（ない場合はnil）
+func (r *TaskHistoryRepository) GetLatestTaskSnapshot(ctx context.Context, taskID string, until time.Time) (*domain.TaskSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.TaskSnapshot
+	for _, snapshot := range r.snapshots[taskID] {
+		if !snapshot.TakenAt.After(until) && (latest == nil || snapshot.Sequence > latest.Sequence) {
+			latest = snapshot
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	c := *latest
+	return &c, nil
+}
+
+// paginate はページに該当する範囲を返す
+func paginate[T any](items []T, pagination domain.Pagination) []T {
+	if pagination.PageSize <= 0 {
+		return items
+	}
+	page := pagination.Page
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * pagination.PageSize
+	if start >= len(items) {
+		return items[:0]
+	}
+	end := start + pagination.PageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// AttachmentRepository は添付ファイルのインメモリリポジトリ
+type AttachmentRepository struct {
+	mu          sync.RWMutex
+	attachments map[string]*domain.Attachment
+}
+
+// NewAttachmentRepository は新しいAttachmentRepositoryを作成する
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{attachments: make(map[string]*domain.Attachment)}
+}
+
+// CreateAttachment は添付ファイルを作成する
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *attachment
+	r.attachments[attachment.ID] = &copied
+	return nil
+}
+
+// GetAttachment はIDで添付ファイルを取得する
+func (r *AttachmentRepository) GetAttachment(ctx context.Context, id string) (*domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attachment, ok := r.attachments[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attachment
+	return &copied, nil
+}
+
+// ListAttachmentsByTask はタスクの添付ファイルをアップロードした順に取得する
+func (r *AttachmentRepository) ListAttachmentsByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	return r.list(func(a *domain.Attachment) bool { return a.TaskID == taskID }, 0), nil
+}
+
+// ListAttachmentsByUser はユーザーがアップロードした添付ファイルをアップロードした順に取得する
+func (r *AttachmentRepository) ListAttachmentsByUser(ctx context.Context, userID string) ([]*domain.Attachment, error) {
+	return r.list(func(a *domain.Attachment) bool { return a.UploadedBy == userID }, 0), nil
+}
+
+// DeleteAttachment は添付ファイルを削除する
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attachments, id)
+	return nil
+}
+
+// ListPendingThumbnails はサムネイルが生成待ちの添付ファイルを古い順にlimit件取得する
+func (r *AttachmentRepository) ListPendingThumbnails(ctx context.Context, limit int) ([]*domain.Attachment, error) {
+	return r.list(func(a *domain.Attachment) bool { return a.ThumbnailStatus == domain.ThumbnailPending }, limit), nil
+}
+
+// UpdateThumbnailStatus はサムネイルの生成状況を更新する
+func (r *AttachmentRepository) UpdateThumbnailStatus(ctx context.Context, id string, status domain.ThumbnailStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attachment, ok := r.attachments[id]; ok {
+		attachment.ThumbnailStatus = status
+	}
+	return nil
+}
+
+// SumAttachmentBytesByUploader はユーザーがアップロードした添付ファイルの合計サイズを取得する
+func (r *AttachmentRepository) SumAttachmentBytesByUploader(ctx context.Context, userID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int64
+	for _, attachment := range r.attachments {
+		if attachment.UploadedBy == userID {
+			total += attachment.Size
+		}
+	}
+	return total, nil
+}
+
+// list は条件に一致する添付ファイルを古い順に取得する（limitが0の場合は全件）
+func (r *AttachmentRepository) list(match func(*domain.Attachment) bool, limit int) []*domain.Attachment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Attachment, 0)
+	for _, attachment := range r.attachments {
+		if match(attachment) {
+			copied := *attachment
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// LinkPreviewRepository はリンクのプレビューのインメモリリポジトリ
+type LinkPreviewRepository struct {
+	mu       sync.RWMutex
+	previews map[string]*domain.LinkPreview
+}
+
+// NewLinkPreviewRepository は新しいLinkPreviewRepositoryを作成する
+func NewLinkPreviewRepository() *LinkPreviewRepository {
+	return &LinkPreviewRepository{previews: make(map[string]*domain.LinkPreview)}
+}
+
+// GetLinkPreviews はURLのプレビューを取得する（キャッシュにないURLは含まれない）
+func (r *LinkPreviewRepository) GetLinkPreviews(ctx context.Context, urls []string) (map[string]*domain.LinkPreview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*domain.LinkPreview, len(urls))
+	for _, url := range urls {
+		if preview, ok := r.previews[url]; ok {
+			copied := *preview
+			result[url] = &copied
+		}
+	}
+	return result, nil
+}
+
+// SaveLinkPreview はプレビューを保存する（同じURLのプレビューは置き換える）
+func (r *LinkPreviewRepository) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *preview
+	r.previews[preview.URL] = &copied
+	return nil
+}
+
+// WeeklyReportSubscriptionRepository は週次レポートの配信購読のインメモリリポジトリ
+type WeeklyReportSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*domain.WeeklyReportSubscription
+}
+
+// NewWeeklyReportSubscriptionRepository は新しいWeeklyReportSubscriptionRepositoryを作成する
+func NewWeeklyReportSubscriptionRepository() *WeeklyReportSubscriptionRepository {
+	return &WeeklyReportSubscriptionRepository{subscriptions: make(map[string]*domain.WeeklyReportSubscription)}
+}
+
+// GetSubscription は購読を取得する（未購読の場合は nil, nil）
+func (r *WeeklyReportSubscriptionRepository) GetSubscription(ctx context.Context, userID string) (*domain.WeeklyReportSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.subscriptions[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+// SaveSubscription は購読を保存する
+func (r *WeeklyReportSubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain.WeeklyReportSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *subscription
+	r.subscriptions[subscription.UserID] = &copied
+	return nil
+}
+
+// DeleteSubscription は購読を削除する
+func (r *WeeklyReportSubscriptionRepository) DeleteSubscription(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.subscriptions, userID)
+	return nil
+}
+
+// ListSubscriptions はすべての購読をユーザーID順に取得する
+func (r *WeeklyReportSubscriptionRepository) ListSubscriptions(ctx context.Context) ([]*domain.WeeklyReportSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.WeeklyReportSubscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		copied := *subscription
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+	return result, nil
+}
+
+// MarkSent は週のレポートを配信済みとして記録する
+func (r *WeeklyReportSubscriptionRepository) MarkSent(ctx context.Context, userID, week string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if subscription, ok := r.subscriptions[userID]; ok {
+		subscription.LastSentWeek = week
+	}
+	return nil
+}
+
+// DailyStatsRepository は日次統計の集計テーブルのインメモリリポジトリ
+type DailyStatsRepository struct {
+	mu        sync.RWMutex
+	snapshots map[[2]string]*domain.DailyStatsSnapshot // (userID, date) → 行
+}
+
+// NewDailyStatsRepository は新しいDailyStatsRepositoryを作成する
+func NewDailyStatsRepository() *DailyStatsRepository {
+	return &DailyStatsRepository{snapshots: make(map[[2]string]*domain.DailyStatsSnapshot)}
+}
+
+// GetSnapshots はfromからtoまでのユーザーの行を日付をキーに取得する
+func (r *DailyStatsRepository) GetSnapshots(ctx context.Context, userID, from, to string) (map[string]*domain.DailyStatsSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*domain.DailyStatsSnapshot)
+	for key, snapshot := range r.snapshots {
+		if key[0] == userID && key[1] >= from && key[1] <= to {
+			copied := *snapshot
+			result[key[1]] = &copied
+		}
+	}
+	return result, nil
+}
+
+// SaveSnapshot は行を保存する
+func (r *DailyStatsRepository) SaveSnapshot(ctx context.Context, snapshot *domain.DailyStatsSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *snapshot
+	r.snapshots[[2]string{snapshot.UserID, snapshot.Date}] = &copied
+	return nil
+}
+
+// ListSnapshots はfrom以降の日付の行を日付・ユーザーID順にlimit件まで取得する
+func (r *DailyStatsRepository) ListSnapshots(ctx context.Context, from string, limit int) ([]*domain.DailyStatsSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.DailyStatsSnapshot
+	for key, snapshot := range r.snapshots {
+		if key[1] >= from {
+			copied := *snapshot
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Date != result[j].Date {
+			return result[i].Date < result[j].Date
+		}
+		return result[i].UserID < result[j].UserID
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// LocationRepository はタスクの場所とジオフェンスのインメモリリポジトリ
+type LocationRepository struct {
+	mu        sync.RWMutex
+	locations map[string]*domain.TaskLocation
+	geofences map[string]*domain.Geofence
+}
+
+// NewLocationRepository は新しいLocationRepositoryを作成する
+func NewLocationRepository() *LocationRepository {
+	return &LocationRepository{
+		locations: make(map[string]*domain.TaskLocation),
+		geofences: make(map[string]*domain.Geofence),
+	}
+}
+
+// SetTaskLocation はタスクの場所を設定する（設定済みの場合は置き換える）
+func (r *LocationRepository) SetTaskLocation(ctx context.Context, location *domain.TaskLocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *location
+	r.locations[location.TaskID] = &copied
+	return nil
+}
+
+// GetTaskLocation はタスクの場所を取得する
+func (r *LocationRepository) GetTaskLocation(ctx context.Context, taskID string) (*domain.TaskLocation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	location, ok := r.locations[taskID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *location
+	return &copied, nil
+}
+
+// GetTaskLocations は複数のタスクの場所をタスクIDごとに取得する
+func (r *LocationRepository) GetTaskLocations(ctx context.Context, taskIDs []string) (map[string]*domain.TaskLocation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*domain.TaskLocation, len(taskIDs))
+	for _, taskID := range taskIDs {
+		if location, ok := r.locations[taskID]; ok {
+			copied := *location
+			result[taskID] = &copied
+		}
+	}
+	return result, nil
+}
+
+// DeleteTaskLocation はタスクの場所を削除する
+func (r *LocationRepository) DeleteTaskLocation(ctx context.Context, taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.locations, taskID)
+	return nil
+}
+
+// ListTaskLocationsInBounds は緯度・経度の範囲内にあるタスクの場所をタスクID順に取得する
+func (r *LocationRepository) ListTaskLocationsInBounds(ctx context.Context, bounds domain.GeoBounds) ([]*domain.TaskLocation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.TaskLocation
+	for _, location := range r.locations {
+		lat, lng := location.Location.Latitude, location.Location.Longitude
+		if lat >= bounds.MinLatitude && lat <= bounds.MaxLatitude &&
+			lng >= bounds.MinLongitude && lng <= bounds.MaxLongitude {
+			copied := *location
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].TaskID < result[j].TaskID })
+	return result, nil
+}
+
+// CreateGeofence はジオフェンスを作成する（同じユーザー・タスクのジオフェンスは1つのみ）
+func (r *LocationRepository) CreateGeofence(ctx context.Context, geofence *domain.Geofence) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.geofences {
+		if existing.UserID == geofence.UserID && existing.TaskID == geofence.TaskID {
+			return usecase.ErrGeofenceExists
+		}
+	}
+	copied := *geofence
+	copied.Location = nil
+	r.geofences[geofence.ID] = &copied
+	return nil
+}
+
+// GetGeofence はIDでジオフェンスを取得する
+func (r *LocationRepository) GetGeofence(ctx context.Context, id string) (*domain.Geofence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	geofence, ok := r.geofences[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *geofence
+	return &copied, nil
+}
+
+// ListGeofencesByUser はユーザーのジオフェンスを登録した順に取得する
+func (r *LocationRepository) ListGeofencesByUser(ctx context.Context, userID string) ([]*domain.Geofence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Geofence, 0)
+	for _, geofence := range r.geofences {
+		if geofence.UserID == userID {
+			copied := *geofence
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// DeleteGeofence はジオフェンスを削除する
+func (r *LocationRepository) DeleteGeofence(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.geofences[id]; !ok {
+		return usecase.ErrGeofenceNotFound
+	}
+	delete(r.geofences, id)
+	return nil
+}
+
+// DeleteGeofencesByTask はタスクのジオフェンスをすべて削除する
+func (r *LocationRepository) DeleteGeofencesByTask(ctx context.Context, taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, geofence := range r.geofences {
+		if geofence.TaskID == taskID {
+			delete(r.geofences, id)
+		}
+	}
+	return nil
+}
+
+// UpdateGeofenceTriggeredAt はジオフェンスのリマインダーを最後に送った日時を更新する
+func (r *LocationRepository) UpdateGeofenceTriggeredAt(ctx context.Context, id string, triggeredAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	geofence, ok := r.geofences[id]
+	if !ok {
+		return usecase.ErrGeofenceNotFound
+	}
+	geofence.LastTriggeredAt = &triggeredAt
+	return nil
+}
+
+// IssueLinkRepository はタスクとIssue・Pull Requestの関連付けとGitHubのトークンのインメモリリポジトリ
+type IssueLinkRepository struct {
+	mu       sync.RWMutex
+	links    map[string]*domain.IssueLink
+	accounts map[string]*domain.GitHubAccount
+}
+
+// NewIssueLinkRepository は新しいIssueLinkRepositoryを作成する
+func NewIssueLinkRepository() *IssueLinkRepository {
+	return &IssueLinkRepository{
+		links:    make(map[string]*domain.IssueLink),
+		accounts: make(map[string]*domain.GitHubAccount),
+	}
+}
+
+// CreateIssueLink はタスクとIssue・Pull Requestの関連付けを作成する
+func (r *IssueLinkRepository) CreateIssueLink(ctx context.Context, link *domain.IssueLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.links {
+		if existing.TaskID == link.TaskID && existing.Provider == link.Provider && existing.Ref() == link.Ref() {
+			return fmt.Errorf("issue link already exists: %s", link.Ref())
+		}
+	}
+	copied := *link
+	r.links[link.ID] = &copied
+	return nil
+}
+
+// GetIssueLink はIDで関連付けを取得する
+func (r *IssueLinkRepository) GetIssueLink(ctx context.Context, id string) (*domain.IssueLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, ok := r.links[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *link
+	return &copied, nil
+}
+
+// ListIssueLinksByTask はタスクの関連付けを登録した順に取得する
+func (r *IssueLinkRepository) ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error) {
+	return r.list(func(link *domain.IssueLink) bool { return link.TaskID == taskID }), nil
+}
+
+// ListIssueLinksByIssue はIssue・Pull Requestを関連付けたすべてのタスクの関連付けを取得する
+func (r *IssueLinkRepository) ListIssueLinksByIssue(ctx context.Context, provider domain.IssueProvider, ref domain.IssueRef) ([]*domain.IssueLink, error) {
+	return r.list(func(link *domain.IssueLink) bool { return link.Provider == provider && link.Ref() == ref }), nil
+}
+
+// UpdateIssueLink は関連付けの種類・タイトル・状態・URLを更新する
+func (r *IssueLinkRepository) UpdateIssueLink(ctx context.Context, link *domain.IssueLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.links[link.ID]
+	if !ok {
+		return nil
+	}
+	existing.Kind = link.Kind
+	existing.Title = link.Title
+	existing.State = link.State
+	existing.URL = link.URL
+	existing.UpdatedAt = link.UpdatedAt
+	return nil
+}
+
+// DeleteIssueLink は関連付けを削除する
+func (r *IssueLinkRepository) DeleteIssueLink(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.links, id)
+	return nil
+}
+
+// SaveGitHubAccount はGitHubのトークンを保存する（登録済みの場合は置き換える）
+func (r *IssueLinkRepository) SaveGitHubAccount(ctx context.Context, account *domain.GitHubAccount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *account
+	if existing, ok := r.accounts[account.UserID]; ok {
+		copied.CreatedAt = existing.CreatedAt
+	}
+	r.accounts[account.UserID] = &copied
+	return nil
+}
+
+// GetGitHubAccount はユーザーのGitHubのトークンを取得する
+func (r *IssueLinkRepository) GetGitHubAccount(ctx context.Context, userID string) (*domain.GitHubAccount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.accounts[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *account
+	return &copied, nil
+}
+
+// DeleteGitHubAccount はユーザーのGitHubのトークンを削除する
+func (r *IssueLinkRepository) DeleteGitHubAccount(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.accounts, userID)
+	return nil
+}
+
+// list は条件に合う関連付けを登録した順に取得する
+func (r *IssueLinkRepository) list(match func(link *domain.IssueLink) bool) []*domain.IssueLink {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.IssueLink, 0)
+	for _, link := range r.links {
+		if match(link) {
+			copied := *link
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// TaskLinkRepository はユーザーがタスクに追加したリンクのインメモリリポジトリ
+type TaskLinkRepository struct {
+	mu    sync.RWMutex
+	links map[string]*domain.TaskLink
+}
+
+// NewTaskLinkRepository は新しいTaskLinkRepositoryを作成する
+func NewTaskLinkRepository() *TaskLinkRepository {
+	return &TaskLinkRepository{
+		links: make(map[string]*domain.TaskLink),
+	}
+}
+
+// CreateTaskLink はタスクにリンクを追加する
+func (r *TaskLinkRepository) CreateTaskLink(ctx context.Context, link *domain.TaskLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *link
+	r.links[link.ID] = &copied
+	return nil
+}
+
+// GetTaskLink はIDでリンクを取得する
+func (r *TaskLinkRepository) GetTaskLink(ctx context.Context, id string) (*domain.TaskLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, ok := r.links[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *link
+	return &copied, nil
+}
+
+// ListTaskLinksByTask はタスクのリンクを追加した順に取得する
+func (r *TaskLinkRepository) ListTaskLinksByTask(ctx context.Context, taskID string) ([]*domain.TaskLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.TaskLink, 0)
+	for _, link := range r.links {
+		if link.TaskID == taskID {
+			copied := *link
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// UpdateTaskLink はリンクの種類・URL・タイトルを更新する
+func (r *TaskLinkRepository) UpdateTaskLink(ctx context.Context, link *domain.TaskLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.links[link.ID]
+	if !ok {
+		return nil
+	}
+	existing.Type = link.Type
+	existing.URL = link.URL
+	existing.Title = link.Title
+	existing.UpdatedAt = link.UpdatedAt
+	return nil
+}
+
+// DeleteTaskLink はリンクを削除する
+func (r *TaskLinkRepository) DeleteTaskLink(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.links, id)
+	return nil
+}
+
+// InboundEmailRepository はタスク作成用のメールアドレスと受信したメールの記録のインメモリリポジトリ
+type InboundEmailRepository struct {
+	mu        sync.RWMutex
+	addresses map[string]*domain.InboundAddress // userID → アドレス
+	emails    []*domain.InboundEmailRecord
+}
+
+// NewInboundEmailRepository は新しいInboundEmailRepositoryを作成する
+func NewInboundEmailRepository() *InboundEmailRepository {
+	return &InboundEmailRepository{
+		addresses: make(map[string]*domain.InboundAddress),
+	}
+}
+
+// SaveInboundAddress はユーザーのアドレスを保存する（作成済みの場合は置き換える）
+func (r *InboundEmailRepository) SaveInboundAddress(ctx context.Context, address *domain.InboundAddress) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *address
+	copied.AllowedSenders = append([]string(nil), address.AllowedSenders...)
+	if existing, ok := r.addresses[address.UserID]; ok {
+		copied.CreatedAt = existing.CreatedAt
+	}
+	r.addresses[address.UserID] = &copied
+	return nil
+}
+
+// GetInboundAddress はユーザーのアドレスを取得する
+func (r *InboundEmailRepository) GetInboundAddress(ctx context.Context, userID string) (*domain.InboundAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	address, ok := r.addresses[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *address
+	return &copied, nil
+}
+
+// GetInboundAddressByToken はアドレスのトークンでアドレスを取得する
+func (r *InboundEmailRepository) GetInboundAddressByToken(ctx context.Context, token string) (*domain.InboundAddress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, address := range r.addresses {
+		if address.Token == token {
+			copied := *address
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteInboundAddress はユーザーのアドレスを削除する
+func (r *InboundEmailRepository) DeleteInboundAddress(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.addresses, userID)
+	return nil
+}
+
+// CreateInboundEmail はメールから作成したタスクを記録する
+func (r *InboundEmailRepository) CreateInboundEmail(ctx context.Context, record *domain.InboundEmailRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *record
+	r.emails = append(r.emails, &copied)
+	return nil
+}
+
+// ExistsInboundEmail はユーザー宛ての同じMessage-IDのメールからタスクを作成済みか
+func (r *InboundEmailRepository) ExistsInboundEmail(ctx context.Context, userID, messageID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, email := range r.emails {
+		if email.UserID == userID && email.MessageID == messageID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CountInboundEmailsSince は since 以降にユーザー宛てのメールから作成したタスクの数を返す
+func (r *InboundEmailRepository) CountInboundEmailsSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, email := range r.emails {
+		if email.UserID == userID && !email.ReceivedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeferredReminderRepository は勤務時間外のため保留した通知のインメモリリポジトリ
+type DeferredReminderRepository struct {
+	mu        sync.RWMutex
+	reminders map[[3]string]*domain.DeferredReminder // (userID, taskID, kind) → 通知
+}
+
+// NewDeferredReminderRepository は新しいDeferredReminderRepositoryを作成する
+func NewDeferredReminderRepository() *DeferredReminderRepository {
+	return &DeferredReminderRepository{
+		reminders: make(map[[3]string]*domain.DeferredReminder),
+	}
+}
+
+// SaveDeferredReminder は通知を保留する（同じユーザー・タスク・種類の通知は置き換える）
+func (r *DeferredReminderRepository) SaveDeferredReminder(ctx context.Context, reminder *domain.DeferredReminder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := [3]string{reminder.UserID, reminder.TaskID, string(reminder.Kind)}
+	if existing, ok := r.reminders[key]; ok {
+		existing.TaskTitle = reminder.TaskTitle
+		existing.DeliverAt = reminder.DeliverAt
+		return nil
+	}
+	copied := *reminder
+	r.reminders[key] = &copied
+	return nil
+}
+
+// ListDueDeferredReminders は送る日時が until 以前の通知を送る日時の順に取得する
+func (r *DeferredReminderRepository) ListDueDeferredReminders(ctx context.Context, until time.Time, limit int) ([]*domain.DeferredReminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.DeferredReminder{}
+	for _, reminder := range r.reminders {
+		if !reminder.DeliverAt.After(until) {
+			copied := *reminder
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].DeliverAt.Equal(result[j].DeliverAt) {
+			return result[i].DeliverAt.Before(result[j].DeliverAt)
+		}
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// DeleteDeferredReminders は送った通知を削除する
+func (r *DeferredReminderRepository) DeleteDeferredReminders(ctx context.Context, ids []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	for key, reminder := range r.reminders {
+		if deleted[reminder.ID] {
+			delete(r.reminders, key)
+		}
+	}
+	return nil
+}
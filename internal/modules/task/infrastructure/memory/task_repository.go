@@ -0,0 +1,593 @@
+// Package memory はタスクモジュールのリポジトリのインメモリ実装です
+//
+// STORAGE_DRIVER=memory のときにMySQLの代わりに使用します。デモ・フロントエンド開発・
+// MySQL実装とのベンチマーク比較用で、データはプロセス終了時に失われます。
+// 取得結果は保存データの複製を返すため、呼び出し側で変更しても保存内容には影響しません。
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// MySQL実装と同じ取得件数の上限
+const (
+	maxSearchResults   = 100
+	defaultSearchLimit = 20
+	maxOverdueResults  = 1000
+	maxAssigneeResults = 500
+	overdueLookback    = 30 * 24 * time.Hour
+)
+
+// 許可されたソートフィールド（MySQL実装と同じ）
+var allowedSortFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"title":      true,
+	"priority":   true,
+	"status":     true,
+	"due_date":   true,
+	"start_date": true,
+}
+
+// TaskRepository はタスクのインメモリリポジトリ
+// usecase.TaskRepository と usecase.StatsRepository の両方を実装する
+type TaskRepository struct {
+	mu    sync.RWMutex
+	tasks map[string]*domain.Task
+}
+
+// NewTaskRepository は新しいTaskRepositoryを作成する
+func NewTaskRepository() *TaskRepository {
+	return &TaskRepository{
+		tasks: make(map[string]*domain.Task),
+	}
+}
+
+// CreateTask はタスクを作成する
+func (r *TaskRepository) CreateTask(ctx context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tasks[task.ID] = storedTask(task)
+	return nil
+}
+
+// GetTaskByID はIDによりタスクを取得する
+func (r *TaskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
+	if id == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, usecase.ErrTaskNotFound
+	}
+	return cloneTask(task), nil
+}
+
+// ListTasks はタスク一覧を取得する
+func (r *TaskRepository) ListTasks(
+	ctx context.Context,
+	filter domain.ListFilter,
+	pagination domain.Pagination,
+	sortOptions domain.SortOptions,
+) ([]*domain.Task, int, error) {
+	if pagination.Page <= 0 {
+		return nil, 0, usecase.ErrInvalidParameter
+	}
+	if pagination.PageSize <= 0 || pagination.PageSize > 100 {
+		return nil, 0, usecase.ErrInvalidParameter
+	}
+	if sortOptions.Field != "" && !allowedSortFields[sortOptions.Field] {
+		return nil, 0, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	matched := r.filter(func(task *domain.Task) bool { return matchesFilter(task, filter) })
+	r.mu.RUnlock()
+
+	field := sortOptions.Field
+	if field == "" {
+		field = "created_at"
+	}
+	desc := sortOptions.Direction != "ASC"
+	sort.SliceStable(matched, func(i, j int) bool {
+		c := compareTasks(matched[i], matched[j], field)
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	total := len(matched)
+	start := (pagination.Page - 1) * pagination.PageSize
+	if start >= total {
+		return []*domain.Task{}, total, nil
+	}
+	end := start + pagination.PageSize
+	if end > total {
+		end = total
+	}
+
+	return matched[start:end], total, nil
+}
+
+// UpdateTask はタスクを更新する
+func (r *TaskRepository) UpdateTask(ctx context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[task.ID]; !ok {
+		return usecase.ErrTaskNotFound
+	}
+	r.tasks[task.ID] = storedTask(task)
+	return nil
+}
+
+// DeleteTask はタスクを削除する
+func (r *TaskRepository) DeleteTask(ctx context.Context, id string) error {
+	if id == "" {
+		return usecase.ErrInvalidParameter
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[id]; !ok {
+		return usecase.ErrTaskNotFound
+	}
+	delete(r.tasks, id)
+	return nil
+}
+
+// GetOverdueTasks は過去30日以内に期限が切れた未完了のタスクを取得する
+func (r *TaskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, error) {
+	now := time.Now()
+	since := now.Add(-overdueLookback)
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return task.DueDate != nil && task.DueDate.Before(now) && !task.DueDate.Before(since) &&
+			task.Status != domain.TaskStatusDone
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return limitTasks(tasks, maxOverdueResults), nil
+}
+
+// GetOverdueTasksByUser はユーザーが作成または担当する期限切れの未完了のタスクを取得する
+func (r *TaskRepository) GetOverdueTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	now := time.Now()
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return involves(task, userID) && task.DueDate != nil && task.DueDate.Before(now) &&
+			task.Status != domain.TaskStatusDone
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return limitTasks(tasks, maxOverdueResults), nil
+}
+
+// GetTasksByAssignee は特定のユーザーに割り当てられたタスクを取得する
+func (r *TaskRepository) GetTasksByAssignee(ctx context.Context, userID string) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool { return task.HasAssignee(userID) })
+	r.mu.RUnlock()
+
+	// 未着手→進行中→完了、期限の近い順（期限なしは最後）、作成日の新しい順
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if ra, rb := statusRank(a.Status), statusRank(b.Status); ra != rb {
+			return ra < rb
+		}
+		if c := compareDueDates(a.DueDate, b.DueDate); c != 0 {
+			return c < 0
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return limitTasks(tasks, maxAssigneeResults), nil
+}
+
+// SearchTasks はタイトル・説明の部分一致でタスクを検索する
+// タイトルが前方一致するもの、説明が前方一致するもの、その他の順に並べる
+func (r *TaskRepository) SearchTasks(ctx context.Context, query string, limit int) ([]*domain.Task, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []*domain.Task{}, nil
+	}
+	if limit <= 0 || limit > maxSearchResults {
+		limit = defaultSearchLimit
+	}
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return strings.Contains(strings.ToLower(task.Title), query) ||
+			strings.Contains(strings.ToLower(task.Description), query)
+	})
+	r.mu.RUnlock()
+
+	rank := func(task *domain.Task) int {
+		switch {
+		case strings.HasPrefix(strings.ToLower(task.Title), query):
+			return 1
+		case strings.HasPrefix(strings.ToLower(task.Description), query):
+			return 2
+		default:
+			return 3
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if ri, rj := rank(tasks[i]), rank(tasks[j]); ri != rj {
+			return ri < rj
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+	return limitTasks(tasks, limit), nil
+}
+
+// === StatsRepository ===
+
+// GetTasksByDateRange は作成日・期限日・更新日のいずれかが期間内にあるタスクを取得する
+func (r *TaskRepository) GetTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return involves(task, userID) &&
+			(within(task.CreatedAt, start, end) ||
+				(task.DueDate != nil && within(*task.DueDate, start, end)) ||
+				within(task.UpdatedAt, start, end))
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].CreatedAt.After(tasks[j].CreatedAt) })
+	return tasks, nil
+}
+
+// GetTasksByDueDate は指定日に期限があるタスクを取得する
+func (r *TaskRepository) GetTasksByDueDate(ctx context.Context, userID string, dueDate time.Time) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	dayStart := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, dueDate.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return involves(task, userID) && task.DueDate != nil && within(*task.DueDate, dayStart, dayEnd)
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(*tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		}
+		return tasks[i].Priority > tasks[j].Priority
+	})
+	return tasks, nil
+}
+
+// GetRecentCompletedTasks は最近完了したタスクを取得する
+func (r *TaskRepository) GetRecentCompletedTasks(ctx context.Context, userID string, limit int) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return involves(task, userID) && task.Status == domain.TaskStatusDone
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt) })
+	return limitTasks(tasks, limit), nil
+}
+
+// GetOverdueTasksCount は期限切れタスク数を取得する
+func (r *TaskRepository) GetOverdueTasksCount(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, usecase.ErrInvalidParameter
+	}
+
+	now := time.Now()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, task := range r.tasks {
+		if involves(task, userID) && task.DueDate != nil && task.DueDate.Before(now) && task.Status != domain.TaskStatusDone {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetCompletedTasksByDateRange は指定期間に完了したタスクを取得する
+func (r *TaskRepository) GetCompletedTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return involves(task, userID) && task.Status == domain.TaskStatusDone &&
+			task.CompletedAt != nil && within(*task.CompletedAt, start, end)
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].CompletedAt.Before(*tasks[j].CompletedAt) })
+	return tasks, nil
+}
+
+// StreamTasksByUser はユーザーが作成または担当するタスクを作成日時順にチャネルへ送る
+func (r *TaskRepository) StreamTasksByUser(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+	out := make(chan *domain.Task)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		if userID == "" {
+			close(out)
+			errs <- usecase.ErrInvalidParameter
+			return
+		}
+
+		r.mu.RLock()
+		tasks := r.filter(func(task *domain.Task) bool { return involves(task, userID) })
+		r.mu.RUnlock()
+
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+				return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+			}
+			return tasks[i].ID < tasks[j].ID
+		})
+
+		for _, task := range tasks {
+			select {
+			case out <- task:
+			case <-ctx.Done():
+				close(out)
+				errs <- ctx.Err()
+				return
+			}
+		}
+		close(out)
+		errs <- nil
+	}()
+
+	return out, errs
+}
+
+// GetScheduledTasksByDateRange は着手予定日時または期限が指定期間内にあるタスクを取得する
+func (r *TaskRepository) GetScheduledTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	r.mu.RLock()
+	tasks := r.filter(func(task *domain.Task) bool {
+		return involves(task, userID) &&
+			((task.StartDate != nil && within(*task.StartDate, start, end)) ||
+				(task.DueDate != nil && within(*task.DueDate, start, end)))
+	})
+	r.mu.RUnlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool { return compareTasks(tasks[i], tasks[j], "due_date") < 0 })
+	return tasks, nil
+}
+
+// === ヘルパー ===
+
+// filter は条件に一致するタスクの複製を返す（呼び出し側で読み取りロックを取得すること）
+func (r *TaskRepository) filter(match func(task *domain.Task) bool) []*domain.Task {
+	tasks := []*domain.Task{}
+	for _, task := range r.tasks {
+		if match(task) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	return tasks
+}
+
+// matchesFilter はタスクが一覧のフィルタに一致するかを判定する
+func matchesFilter(task *domain.Task, filter domain.ListFilter) bool {
+	if filter.Status != nil && task.Status != *filter.Status {
+		return false
+	}
+	if filter.Priority != nil && task.Priority != *filter.Priority {
+		return false
+	}
+	if filter.Category != nil && task.Category != *filter.Category {
+		return false
+	}
+	if filter.AssigneeID != nil {
+		assignee := task.FindAssignee(*filter.AssigneeID)
+		if assignee == nil {
+			return false
+		}
+		if filter.AssigneeCompleted != nil && assignee.IsCompleted() != *filter.AssigneeCompleted {
+			return false
+		}
+	}
+	if filter.CreatedBy != nil && task.CreatedBy != *filter.CreatedBy {
+		return false
+	}
+	if filter.DueDateFrom != nil && (task.DueDate == nil || task.DueDate.Before(*filter.DueDateFrom)) {
+		return false
+	}
+	if filter.DueDateTo != nil && (task.DueDate == nil || task.DueDate.After(*filter.DueDateTo)) {
+		return false
+	}
+	if filter.StartDateFrom != nil && (task.StartDate == nil || task.StartDate.Before(*filter.StartDateFrom)) {
+		return false
+	}
+	if filter.StartDateTo != nil && (task.StartDate == nil || task.StartDate.After(*filter.StartDateTo)) {
+		return false
+	}
+	return true
+}
+
+// compareTasks は指定フィールドでタスクを比較する（同値の場合はIDで順序を固定する）
+func compareTasks(a, b *domain.Task, field string) int {
+	var c int
+	switch field {
+	case "updated_at":
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case "title":
+		c = strings.Compare(a.Title, b.Title)
+	case "priority":
+		c = strings.Compare(string(a.Priority), string(b.Priority))
+	case "status":
+		c = strings.Compare(string(a.Status), string(b.Status))
+	case "due_date":
+		c = compareNullableTimes(a.DueDate, b.DueDate)
+	case "start_date":
+		c = compareNullableTimes(a.StartDate, b.StartDate)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	return c
+}
+
+// compareNullableTimes はNULL許容の日時を比較する（MySQLと同様にNULLは最小値として扱う）
+func compareNullableTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+// compareDueDates は期限日を比較する（期限なしは最後）
+func compareDueDates(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+// statusRank は担当タスク一覧でのステータスの並び順
+func statusRank(status domain.TaskStatus) int {
+	switch status {
+	case domain.TaskStatusTodo:
+		return 1
+	case domain.TaskStatusInProgress:
+		return 2
+	case domain.TaskStatusDone:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// involves はユーザーがタスクの作成者または担当者かを判定する
+func involves(task *domain.Task, userID string) bool {
+	return task.CreatedBy == userID || task.HasAssignee(userID)
+}
+
+// within は日時が期間内（両端を含む）かを判定する
+func within(t, start, end time.Time) bool {
+	return !t.Before(start) && !t.After(end)
+}
+
+func limitTasks(tasks []*domain.Task, limit int) []*domain.Task {
+	if len(tasks) > limit {
+		return tasks[:limit]
+	}
+	return tasks
+}
+
+// storedTask は保存用の複製を作成する（カテゴリ未設定はMySQL実装と同じくOTHERとする）
+func storedTask(task *domain.Task) *domain.Task {
+	stored := cloneTask(task)
+	if stored.Category == "" {
+		stored.Category = domain.CategoryOther
+	}
+	return stored
+}
+
+// cloneTask はタスクを複製する
+func cloneTask(task *domain.Task) *domain.Task {
+	c := *task
+	c.AssigneeID = cloneString(task.AssigneeID)
+	c.StartDate = cloneTime(task.StartDate)
+	c.DueDate = cloneTime(task.DueDate)
+	c.EstimateMinutes = cloneInt(task.EstimateMinutes)
+	c.EstimatePoints = cloneInt(task.EstimatePoints)
+	c.ActualMinutes = cloneInt(task.ActualMinutes)
+	c.CompletedAt = cloneTime(task.CompletedAt)
+	c.Assignees = make([]*domain.TaskAssignee, len(task.Assignees))
+	for i, assignee := range task.Assignees {
+		a := *assignee
+		a.CompletedAt = cloneTime(assignee.CompletedAt)
+		c.Assignees[i] = &a
+	}
+	return &c
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func cloneInt(n *int) *int {
+	if n == nil {
+		return nil
+	}
+	v := *n
+	return &v
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
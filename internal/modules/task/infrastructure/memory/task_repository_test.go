@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTask(title string, priority domain.Priority, createdBy string, createdAt time.Time) *domain.Task {
+	task := domain.NewTask(title, "", priority, domain.CategoryWork, createdBy)
+	task.ID = uuid.New().String()
+	task.CreatedAt = createdAt
+	task.UpdatedAt = createdAt
+	return task
+}
+
+func TestTaskRepository_ListTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository()
+	base := time.Now().Add(-time.Hour)
+
+	high := newTestTask("high", domain.PriorityHigh, "user-1", base)
+	low := newTestTask("low", domain.PriorityLow, "user-1", base.Add(time.Minute))
+	assigned := newTestTask("assigned", domain.PriorityHigh, "user-2", base.Add(2*time.Minute))
+	assigned.AssignTo("user-1")
+	for _, task := range []*domain.Task{high, low, assigned} {
+		require.NoError(t, repo.CreateTask(ctx, task))
+	}
+
+	t.Run("デフォルトは作成日時の新しい順", func(t *testing.T) {
+		tasks, total, err := repo.ListTasks(ctx, domain.ListFilter{}, domain.Pagination{Page: 1, PageSize: 10}, domain.SortOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []string{assigned.ID, low.ID, high.ID}, taskIDs(tasks))
+	})
+
+	t.Run("優先度と担当者で絞り込む", func(t *testing.T) {
+		priority := domain.PriorityHigh
+		assignee := "user-1"
+		tasks, total, err := repo.ListTasks(ctx,
+			domain.ListFilter{Priority: &priority, AssigneeID: &assignee},
+			domain.Pagination{Page: 1, PageSize: 10},
+			domain.SortOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []string{assigned.ID}, taskIDs(tasks))
+	})
+
+	t.Run("着手予定日で絞り込み、着手予定日順に並べる", func(t *testing.T) {
+		later, sooner := base.Add(48*time.Hour), base.Add(24*time.Hour)
+		high.SetStartDate(&later)
+		low.SetStartDate(&sooner)
+		for _, task := range []*domain.Task{high, low} {
+			require.NoError(t, repo.UpdateTask(ctx, task))
+		}
+		t.Cleanup(func() {
+			for _, task := range []*domain.Task{high, low} {
+				task.SetStartDate(nil)
+				require.NoError(t, repo.UpdateTask(ctx, task))
+			}
+		})
+
+		from := base
+		tasks, total, err := repo.ListTasks(ctx,
+			domain.ListFilter{StartDateFrom: &from},
+			domain.Pagination{Page: 1, PageSize: 10},
+			domain.SortOptions{Field: "start_date", Direction: "ASC"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []string{low.ID, high.ID}, taskIDs(tasks))
+	})
+
+	t.Run("ページ範囲外は空で総件数を返す", func(t *testing.T) {
+		tasks, total, err := repo.ListTasks(ctx, domain.ListFilter{}, domain.Pagination{Page: 2, PageSize: 3}, domain.SortOptions{Field: "title", Direction: "ASC"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("不正なパラメータ", func(t *testing.T) {
+		_, _, err := repo.ListTasks(ctx, domain.ListFilter{}, domain.Pagination{Page: 0, PageSize: 10}, domain.SortOptions{})
+		assert.ErrorIs(t, err, usecase.ErrInvalidParameter)
+
+		_, _, err = repo.ListTasks(ctx, domain.ListFilter{}, domain.Pagination{Page: 1, PageSize: 10}, domain.SortOptions{Field: "password"})
+		assert.ErrorIs(t, err, usecase.ErrInvalidParameter)
+	})
+}
+
+func TestTaskRepository_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository()
+	task := newTestTask("original", domain.PriorityMedium, "user-1", time.Now())
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	// 保存後に呼び出し側のタスクを変更しても保存済みのデータは変わらない
+	task.Title = "changed by caller"
+	got, err := repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "original", got.Title)
+
+	got.Title = "changed after get"
+	again, err := repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "original", again.Title)
+}
+
+func TestTaskRepository_GetTaskByID_NotFound(t *testing.T) {
+	repo := NewTaskRepository()
+
+	_, err := repo.GetTaskByID(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, usecase.ErrTaskNotFound)
+}
+
+func TestTaskRepository_GetOverdueTasksByUser(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTaskRepository()
+	now := time.Now()
+	withDue := func(task *domain.Task, status domain.TaskStatus, due time.Time) *domain.Task {
+		task.SetStatus(status)
+		task.DueDate = &due
+		require.NoError(t, repo.CreateTask(ctx, task))
+		return task
+	}
+
+	created := withDue(newTestTask("created", domain.PriorityMedium, "user-1", now), domain.TaskStatusTodo, now.Add(-time.Hour))
+	assigned := newTestTask("assigned", domain.PriorityMedium, "user-2", now)
+	assigned.AddAssignee("user-1")
+	withDue(assigned, domain.TaskStatusInProgress, now.Add(-48*time.Hour))
+
+	// 他のユーザーのタスク・完了済み・期限前のタスクは含まない
+	withDue(newTestTask("other", domain.PriorityMedium, "user-2", now), domain.TaskStatusTodo, now.Add(-time.Hour))
+	withDue(newTestTask("done", domain.PriorityMedium, "user-1", now), domain.TaskStatusDone, now.Add(-time.Hour))
+	withDue(newTestTask("upcoming", domain.PriorityMedium, "user-1", now), domain.TaskStatusTodo, now.Add(time.Hour))
+
+	tasks, err := repo.GetOverdueTasksByUser(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{assigned.ID, created.ID}, taskIDs(tasks))
+
+	_, err = repo.GetOverdueTasksByUser(ctx, "")
+	assert.ErrorIs(t, err, usecase.ErrInvalidParameter)
+}
+
+func taskIDs(tasks []*domain.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+// BenchmarkTaskRepository_ListTasks はMySQL実装（internal/integration）との比較用
+func BenchmarkTaskRepository_ListTasks(b *testing.B) {
+	ctx := context.Background()
+	repo := NewTaskRepository()
+	base := time.Now().Add(-24 * time.Hour)
+	for i := 0; i < 1000; i++ {
+		createdBy := fmt.Sprintf("user-%d", i%10)
+		task := newTestTask(fmt.Sprintf("task %d", i), domain.PriorityMedium, createdBy, base.Add(time.Duration(i)*time.Second))
+		if err := repo.CreateTask(ctx, task); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	createdBy := "user-1"
+	filter := domain.ListFilter{CreatedBy: &createdBy}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, _, err := repo.ListTasks(ctx, filter, domain.Pagination{Page: 1, PageSize: 20}, domain.SortOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// dailyStatsReconcileHour は日次統計の再集計を行う時刻（UTCの時）
+const dailyStatsReconcileHour = 3
+
+// DailyStatsWorker は日次統計の集計テーブルを夜間に再集計するワーカー
+type DailyStatsWorker struct {
+	statsService *usecase.DailyStatsService
+	logger       logger.Logger
+	ticker       *time.Ticker
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+	isRunning    bool
+	lastRun      string // 最後に再集計した日（UTC、YYYY-MM-DD）
+}
+
+// NewDailyStatsWorker は新しいDailyStatsWorkerを作成
+func NewDailyStatsWorker(
+	statsService *usecase.DailyStatsService,
+	logger logger.Logger,
+) *DailyStatsWorker {
+	return &DailyStatsWorker{
+		statsService: statsService,
+		logger:       logger,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始（1時間ごとに確認し、UTCの3時以降に1日1回再集計する）
+func (w *DailyStatsWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Daily stats worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(time.Hour)
+
+	w.logger.Info("Starting daily stats worker")
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.reconcile(ctx, time.Now())
+			case <-w.stopCh:
+				w.logger.Info("Daily stats worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Daily stats worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// reconcile はその日の再集計がまだで再集計の時刻を過ぎていれば直近の行を集計し直す
+func (w *DailyStatsWorker) reconcile(ctx context.Context, now time.Time) {
+	defer errtrack.Recover("daily-stats-worker")
+	now = now.UTC()
+	today := now.Format(domain.DailyStatsDateLayout)
+	if w.lastRun == today || now.Hour() < dailyStatsReconcileHour {
+		return
+	}
+
+	refreshed, err := w.statsService.Reconcile(ctx, now)
+	if err != nil {
+		w.logger.Error("Failed to reconcile daily stats", logger.Error(err))
+		return
+	}
+	w.lastRun = today
+
+	w.logger.Info("Daily stats reconciled", logger.Any("count", refreshed))
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *DailyStatsWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping daily stats worker")
+	<-w.doneCh
+}
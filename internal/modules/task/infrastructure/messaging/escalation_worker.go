@@ -0,0 +1,95 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// EscalationWorker は期限切れタスクのエスカレーションルールを定期評価するワーカー
+type EscalationWorker struct {
+	escalationService *usecase.EscalationService
+	logger            logger.Logger
+	ticker            *time.Ticker
+	stopCh            chan struct{}
+	doneCh            chan struct{}
+	isRunning         bool
+}
+
+// NewEscalationWorker は新しいEscalationWorkerを作成
+func NewEscalationWorker(
+	escalationService *usecase.EscalationService,
+	logger logger.Logger,
+) *EscalationWorker {
+	return &EscalationWorker{
+		escalationService: escalationService,
+		logger:            logger,
+		stopCh:            make(chan struct{}),
+		doneCh:            make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始（15分ごとにルールを評価）
+func (w *EscalationWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Escalation worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(15 * time.Minute) // 時間単位のルールに対して十分な粒度
+
+	w.logger.Info("Starting escalation worker")
+
+	// 初回実行
+	go w.evaluate(ctx)
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.evaluate(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Escalation worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Escalation worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// evaluate はエスカレーションルールを評価する
+func (w *EscalationWorker) evaluate(ctx context.Context) {
+	defer errtrack.Recover("escalation-worker")
+	applied, err := w.escalationService.EvaluateOverdueTasks(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to evaluate escalation rules", logger.Error(err))
+		return
+	}
+
+	if applied > 0 {
+		w.logger.Info("Escalation rules applied", logger.Any("count", applied))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *EscalationWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping escalation worker")
+	<-w.doneCh
+}
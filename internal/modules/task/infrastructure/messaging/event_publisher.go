@@ -36,7 +36,7 @@ type EventPublisher interface {
 	PublishTaskCreated(ctx context.Context, task *domain.Task) error
 	PublishTaskUpdated(ctx context.Context, task *domain.Task) error
 	PublishTaskDeleted(ctx context.Context, taskID string) error
-	PublishTaskAssigned(ctx context.Context, task *domain.Task) error
+	PublishTaskAssigned(ctx context.Context, task *domain.Task, assigneeIDs []string) error
 	PublishTaskCompleted(ctx context.Context, task *domain.Task) error
 	PublishTaskOverdue(ctx context.Context, task *domain.Task) error
 }
@@ -65,10 +65,10 @@ func (p *LogEventPublisher) PublishTaskDeleted(ctx context.Context, taskID strin
 }
 
 // PublishTaskAssigned はタスク割り当てイベントを発行する
-func (p *LogEventPublisher) PublishTaskAssigned(ctx context.Context, task *domain.Task) error {
+func (p *LogEventPublisher) PublishTaskAssigned(ctx context.Context, task *domain.Task, assigneeIDs []string) error {
 	data := map[string]interface{}{
-		"task_id":     task.ID,
-		"assignee_id": *task.AssigneeID,
+		"task_id":      task.ID,
+		"assignee_ids": assigneeIDs,
 	}
 	return p.publishEvent(ctx, EventTaskAssigned, task.ID, data)
 }
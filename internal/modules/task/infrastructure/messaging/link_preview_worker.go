@@ -0,0 +1,125 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// リンクのプレビューの取得の並列数と、取得待ちのURLの最大数
+const (
+	linkPreviewConcurrency = 4
+	linkPreviewQueueSize   = 256
+)
+
+// LinkPreviewWorker はタスクの説明・コメント中のリンクのプレビューを非同期に取得するワーカー
+// 取得待ちのURLはメモリ上に保持し、キューが一杯の場合や停止中に予約されたURLは次に表示・保存されたときに再び予約される
+type LinkPreviewWorker struct {
+	linkPreviewService *usecase.LinkPreviewService
+	logger             logger.Logger
+	queue              chan string
+	stopCh             chan struct{}
+	doneCh             chan struct{}
+	wg                 sync.WaitGroup
+	isRunning          bool
+
+	mu      sync.Mutex
+	pending map[string]bool // 取得待ち・取得中のURL（同じURLを重複して取得しない）
+}
+
+// NewLinkPreviewWorker は新しいLinkPreviewWorkerを作成
+func NewLinkPreviewWorker(
+	linkPreviewService *usecase.LinkPreviewService,
+	logger logger.Logger,
+) *LinkPreviewWorker {
+	return &LinkPreviewWorker{
+		linkPreviewService: linkPreviewService,
+		logger:             logger,
+		queue:              make(chan string, linkPreviewQueueSize),
+		stopCh:             make(chan struct{}),
+		doneCh:             make(chan struct{}),
+		pending:            make(map[string]bool),
+	}
+}
+
+// Enqueue はURLのプレビューの取得を予約する（取得待ち・取得中のURLは無視する）
+func (w *LinkPreviewWorker) Enqueue(urls []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, url := range urls {
+		if w.pending[url] {
+			continue
+		}
+		select {
+		case w.queue <- url:
+			w.pending[url] = true
+		default:
+			w.logger.Debug("Link preview queue is full", logger.Any("url", url))
+			return
+		}
+	}
+}
+
+// Start はワーカーを開始
+func (w *LinkPreviewWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Link preview worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.logger.Info("Starting link preview worker")
+
+	for i := 0; i < linkPreviewConcurrency; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			for {
+				select {
+				case url := <-w.queue:
+					w.unfurl(ctx, url)
+				case <-w.stopCh:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		w.wg.Wait()
+		w.isRunning = false
+		close(w.doneCh)
+		w.logger.Info("Link preview worker stopped")
+	}()
+}
+
+// unfurl はURLのプレビューを取得する
+func (w *LinkPreviewWorker) unfurl(ctx context.Context, url string) {
+	defer errtrack.Recover("link-preview-worker")
+	defer func() {
+		w.mu.Lock()
+		delete(w.pending, url)
+		w.mu.Unlock()
+	}()
+
+	if err := w.linkPreviewService.Unfurl(ctx, url); err != nil {
+		w.logger.Error("Failed to unfurl link", logger.Any("url", url), logger.Error(err))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *LinkPreviewWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping link preview worker")
+	<-w.doneCh
+}
@@ -2,23 +2,30 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
 	"github.com/hryt430/Yotei+/internal/modules/task/domain"
 	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
 
-// TaskDueNotificationScheduler はタスク期限通知のスケジューラー
+// startCheckInterval はスケジューラーのチェック間隔（着手通知はこの間隔内に着手予定日時を迎えるタスクを対象とする）
+const startCheckInterval = 1 * time.Hour
+
+// TaskDueNotificationScheduler はタスク期限通知・着手通知のスケジューラー
 type TaskDueNotificationScheduler struct {
 	taskService         usecase.TaskService
 	notificationService NotificationService
 	eventPublisher      *TaskEventPublisher
+	reminders           usecase.ReminderScheduler // 未設定の場合は勤務時間外もすぐに通知する
 	logger              logger.Logger
 	ticker              *time.Ticker
 	stopCh              chan struct{}
+	doneCh              chan struct{}
 	isRunning           bool
 }
 
@@ -35,9 +42,15 @@ func NewTaskDueNotificationScheduler(
 		eventPublisher:      eventPublisher,
 		logger:              logger,
 		stopCh:              make(chan struct{}),
+		doneCh:              make(chan struct{}),
 	}
 }
 
+// SetReminderScheduler は担当者の勤務時間外の期限通知・着手通知を保留するスケジューラーを設定する
+func (s *TaskDueNotificationScheduler) SetReminderScheduler(reminders usecase.ReminderScheduler) {
+	s.reminders = reminders
+}
+
 // Start はスケジューラーを開始（1時間ごとにチェック）
 func (s *TaskDueNotificationScheduler) Start(ctx context.Context) {
 	if s.isRunning {
@@ -46,18 +59,20 @@ func (s *TaskDueNotificationScheduler) Start(ctx context.Context) {
 	}
 
 	s.isRunning = true
-	s.ticker = time.NewTicker(1 * time.Hour) // 1時間ごとにチェック
+	s.ticker = time.NewTicker(startCheckInterval) // 1時間ごとにチェック
 
 	s.logger.Info("Starting task due notification scheduler")
 
 	// 初回実行
 	go s.checkAndNotifyDueTasks(ctx)
 	go s.checkAndNotifyOverdueTasks(ctx)
+	go s.checkAndNotifyStartingTasks(ctx)
 
 	go func() {
 		defer func() {
 			s.ticker.Stop()
 			s.isRunning = false
+			close(s.doneCh)
 		}()
 
 		for {
@@ -65,6 +80,7 @@ func (s *TaskDueNotificationScheduler) Start(ctx context.Context) {
 			case <-s.ticker.C:
 				s.checkAndNotifyDueTasks(ctx)
 				s.checkAndNotifyOverdueTasks(ctx)
+				s.checkAndNotifyStartingTasks(ctx)
 			case <-s.stopCh:
 				s.logger.Info("Task due notification scheduler stopped")
 				return
@@ -78,6 +94,7 @@ func (s *TaskDueNotificationScheduler) Start(ctx context.Context) {
 
 // checkAndNotifyDueTasks は12時間以内に期限を迎えるタスクをチェックして通知
 func (s *TaskDueNotificationScheduler) checkAndNotifyDueTasks(ctx context.Context) {
+	defer errtrack.Recover("task-due-scheduler")
 	s.logger.Info("Checking tasks due within 12 hours")
 
 	now := time.Now()
@@ -105,6 +122,7 @@ func (s *TaskDueNotificationScheduler) checkAndNotifyDueTasks(ctx context.Contex
 
 // checkAndNotifyOverdueTasks は期限切れタスクをチェックして通知
 func (s *TaskDueNotificationScheduler) checkAndNotifyOverdueTasks(ctx context.Context) {
+	defer errtrack.Recover("task-due-scheduler")
 	s.logger.Info("Checking overdue tasks")
 
 	// 期限切れタスクを取得
@@ -118,7 +136,7 @@ func (s *TaskDueNotificationScheduler) checkAndNotifyOverdueTasks(ctx context.Co
 
 	// 各期限切れタスクについて通知を作成
 	for _, task := range tasks {
-		if task.AssigneeID != nil {
+		if len(task.PendingAssigneeIDs()) > 0 {
 			if err := s.eventPublisher.PublishTaskOverdue(ctx, task); err != nil {
 				s.logger.Error("Failed to publish task overdue event",
 					logger.Any("taskID", task.ID),
@@ -129,6 +147,53 @@ func (s *TaskDueNotificationScheduler) checkAndNotifyOverdueTasks(ctx context.Co
 	}
 }
 
+// checkAndNotifyStartingTasks は次のチェックまでに着手予定日時を迎える未着手タスクをチェックして通知
+// チェック間隔と同じ幅の期間を対象にすることで、同じタスクへの着手通知は1回になる
+func (s *TaskDueNotificationScheduler) checkAndNotifyStartingTasks(ctx context.Context) {
+	defer errtrack.Recover("task-due-scheduler")
+	now := time.Now()
+	until := now.Add(startCheckInterval)
+
+	todo := domain.TaskStatusTodo
+	filter := domain.ListFilter{
+		Status:        &todo,
+		StartDateFrom: &now,
+		StartDateTo:   &until,
+	}
+
+	tasks, _, err := s.taskService.ListTasks(ctx, filter, domain.Pagination{Page: 1, PageSize: 1000}, domain.SortOptions{
+		Field:     "start_date",
+		Direction: "ASC",
+	})
+	if err != nil {
+		s.logger.Error("Failed to get tasks starting soon", logger.Error(err))
+		return
+	}
+
+	s.logger.Info("Found tasks starting soon", logger.Any("count", len(tasks)))
+
+	for _, task := range tasks {
+		// 範囲の端は一方のみ含める（次回のチェックと重複しないようにする）
+		if task.StartDate == nil || !task.StartDate.After(now) {
+			continue
+		}
+		var errs []error
+		for _, assigneeID := range task.PendingAssigneeIDs() {
+			if s.deferReminder(ctx, task, assigneeID, domain.ReminderKindStart, now) {
+				continue
+			}
+			if err := s.createStartNotificationFor(ctx, task, assigneeID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			s.logger.Error("Failed to create start notification",
+				logger.Any("taskID", task.ID),
+				logger.Error(err))
+		}
+	}
+}
+
 // getTasksDueWithin12Hours は12時間以内に期限を迎えるタスクを取得
 func (s *TaskDueNotificationScheduler) getTasksDueWithin12Hours(ctx context.Context, from, to time.Time) ([]*domain.Task, error) {
 	// 期限でフィルタリング
@@ -157,7 +222,7 @@ func (s *TaskDueNotificationScheduler) getTasksDueWithin12Hours(ctx context.Cont
 	var dueTasks []*domain.Task
 	for _, task := range tasks {
 		if task.Status != domain.TaskStatusDone &&
-			task.AssigneeID != nil &&
+			len(task.PendingAssigneeIDs()) > 0 &&
 			task.DueDate != nil &&
 			s.shouldNotifyForTask(task, from, to) {
 			dueTasks = append(dueTasks, task)
@@ -177,12 +242,39 @@ func (s *TaskDueNotificationScheduler) shouldNotifyForTask(task *domain.Task, fr
 	return task.DueDate.After(from) && task.DueDate.Before(to)
 }
 
-// createDueNotification は期限通知を作成
+// createDueNotification は自分の分を完了していない担当者ごとに期限通知を作成
 func (s *TaskDueNotificationScheduler) createDueNotification(ctx context.Context, task *domain.Task, now time.Time) error {
-	if task.AssigneeID == nil {
-		return nil
+	var errs []error
+	for _, assigneeID := range task.PendingAssigneeIDs() {
+		if s.deferReminder(ctx, task, assigneeID, domain.ReminderKindDueSoon, now) {
+			continue
+		}
+		if err := s.createDueNotificationFor(ctx, task, assigneeID, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deferReminder は担当者の勤務時間外の場合に通知を保留し、保留した場合は true を返す
+// 保留に失敗した場合はすぐに通知する
+func (s *TaskDueNotificationScheduler) deferReminder(ctx context.Context, task *domain.Task, assigneeID string, kind domain.ReminderKind, now time.Time) bool {
+	if s.reminders == nil {
+		return false
 	}
+	deferred, err := s.reminders.Defer(ctx, domain.NewDeferredReminder(assigneeID, task, kind), now)
+	if err != nil {
+		s.logger.Warn("Failed to defer reminder",
+			logger.Any("taskID", task.ID),
+			logger.Any("assigneeID", assigneeID),
+			logger.Error(err))
+		return false
+	}
+	return deferred
+}
 
+// createDueNotificationFor は指定した担当者への期限通知を作成
+func (s *TaskDueNotificationScheduler) createDueNotificationFor(ctx context.Context, task *domain.Task, assigneeID string, now time.Time) error {
 	// 期限までの時間を計算
 	timeUntilDue := task.DueDate.Sub(now)
 	hoursUntilDue := int(timeUntilDue.Hours())
@@ -207,7 +299,7 @@ func (s *TaskDueNotificationScheduler) createDueNotification(ctx context.Context
 	}
 
 	createInput := input.CreateNotificationInput{
-		UserID:   *task.AssigneeID,
+		UserID:   assigneeID,
 		Type:     "TASK_DUE_SOON",
 		Title:    title,
 		Message:  message,
@@ -223,12 +315,55 @@ func (s *TaskDueNotificationScheduler) createDueNotification(ctx context.Context
 	s.logger.Info("Created due notification",
 		logger.Any("taskID", task.ID),
 		logger.Any("notificationID", notification.GetID()),
-		logger.Any("assigneeID", *task.AssigneeID))
+		logger.Any("assigneeID", assigneeID))
+
+	return nil
+}
+
+// createStartNotificationFor は指定した担当者への着手通知を作成
+func (s *TaskDueNotificationScheduler) createStartNotificationFor(ctx context.Context, task *domain.Task, assigneeID string) error {
+	message := fmt.Sprintf(
+		"タスク「%s」の着手予定時刻です。\n\n着手予定: %s\n優先度: %s",
+		task.Title,
+		task.StartDate.Format("2006-01-02 15:04"),
+		task.Priority,
+	)
+
+	metadata := map[string]string{
+		"task_id":           task.ID,
+		"task_title":        task.Title,
+		"start_date":        task.StartDate.Format(time.RFC3339),
+		"priority":          string(task.Priority),
+		"notification_type": "task_start_soon",
+		"action_url":        fmt.Sprintf("/tasks/%s", task.ID),
+	}
+	if task.DueDate != nil {
+		metadata["due_date"] = task.DueDate.Format(time.RFC3339)
+	}
+
+	createInput := input.CreateNotificationInput{
+		UserID:   assigneeID,
+		Type:     "TASK_DUE_SOON", // 着手通知も期限間近通知と同じタイプ（notification_typeで区別する）
+		Title:    "▶️ タスク着手通知",
+		Message:  message,
+		Metadata: metadata,
+		Channels: []string{"app"},
+	}
+
+	notification, err := s.notificationService.CreateNotification(ctx, createInput)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	s.logger.Info("Created start notification",
+		logger.Any("taskID", task.ID),
+		logger.Any("notificationID", notification.GetID()),
+		logger.Any("assigneeID", assigneeID))
 
 	return nil
 }
 
-// Stop はスケジューラーを停止
+// Stop はスケジューラーを停止し、実行中のチェックが終わるまで待つ
 func (s *TaskDueNotificationScheduler) Stop() {
 	if !s.isRunning {
 		return
@@ -236,4 +371,5 @@ func (s *TaskDueNotificationScheduler) Stop() {
 
 	close(s.stopCh)
 	s.logger.Info("Stopping task due notification scheduler")
+	<-s.doneCh
 }
@@ -0,0 +1,96 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// reminderDigestInterval は保留した通知を配信する間隔（勤務時間の開始から遅れて届く時間の上限）
+const reminderDigestInterval = 5 * time.Minute
+
+// ReminderDigestWorker は勤務時間外のため保留した通知を、勤務時間の開始時にまとめて配信するワーカー
+type ReminderDigestWorker struct {
+	reminderService *usecase.ReminderService
+	logger          logger.Logger
+	ticker          *time.Ticker
+	stopCh          chan struct{}
+	doneCh          chan struct{}
+	isRunning       bool
+}
+
+// NewReminderDigestWorker は新しいReminderDigestWorkerを作成
+func NewReminderDigestWorker(
+	reminderService *usecase.ReminderService,
+	logger logger.Logger,
+) *ReminderDigestWorker {
+	return &ReminderDigestWorker{
+		reminderService: reminderService,
+		logger:          logger,
+		stopCh:          make(chan struct{}),
+		doneCh:          make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始（5分ごとに配信）
+func (w *ReminderDigestWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Reminder digest worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(reminderDigestInterval)
+
+	w.logger.Info("Starting reminder digest worker")
+
+	// 初回実行（停止中に送る日時を迎えた通知を配信する）
+	go w.deliver(ctx)
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.deliver(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Reminder digest worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Reminder digest worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// deliver は送る日時を迎えた保留した通知を配信する
+func (w *ReminderDigestWorker) deliver(ctx context.Context) {
+	defer errtrack.Recover("reminder-digest-worker")
+	delivered, err := w.reminderService.DeliverDue(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to deliver deferred reminders", logger.Error(err))
+	}
+	if delivered > 0 {
+		w.logger.Info("Deferred reminders delivered", logger.Any("count", delivered))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *ReminderDigestWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping reminder digest worker")
+	<-w.doneCh
+}
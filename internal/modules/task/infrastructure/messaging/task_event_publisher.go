@@ -2,7 +2,10 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
@@ -22,6 +25,9 @@ type NotificationDomain interface {
 	GetTitle() string
 }
 
+// 通知テンプレートに渡す期限の表示形式
+const dueDateDisplayLayout = "2006-01-02 15:04"
+
 // TaskEventPublisher は実際に通知を作成するEventPublisher
 type TaskEventPublisher struct {
 	notificationService NotificationService
@@ -53,12 +59,18 @@ func (p *TaskEventPublisher) PublishTaskCreated(ctx context.Context, task *domai
 func (p *TaskEventPublisher) PublishTaskUpdated(ctx context.Context, task *domain.Task) error {
 	p.logger.Info("Publishing task updated event", logger.Any("taskID", task.ID))
 
-	// タスクが割り当てられている場合、担当者に更新通知を送信
-	if task.AssigneeID != nil && *task.AssigneeID != task.CreatedBy {
-		return p.createTaskUpdateNotification(ctx, task)
+	// タスクが割り当てられている場合、作成者以外の担当者に更新通知を送信
+	var errs []error
+	for _, assigneeID := range task.AssigneeIDs() {
+		if assigneeID == task.CreatedBy {
+			continue
+		}
+		if err := p.createTaskUpdateNotification(ctx, task, assigneeID); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // PublishTaskDeleted はタスク削除イベントを発行する
@@ -71,24 +83,30 @@ func (p *TaskEventPublisher) PublishTaskDeleted(ctx context.Context, taskID stri
 	return nil
 }
 
-// PublishTaskAssigned はタスク割り当てイベントを発行する
-func (p *TaskEventPublisher) PublishTaskAssigned(ctx context.Context, task *domain.Task) error {
-	p.logger.Info("Publishing task assigned event", logger.Any("taskID", task.ID))
+// PublishTaskAssigned はタスク割り当てイベントを発行する（新たに追加された担当者ごとに通知）
+func (p *TaskEventPublisher) PublishTaskAssigned(ctx context.Context, task *domain.Task, assigneeIDs []string) error {
+	p.logger.Info("Publishing task assigned event",
+		logger.Any("taskID", task.ID), logger.Any("assigneeIDs", assigneeIDs))
 
-	if task.AssigneeID == nil {
-		return nil
+	var errs []error
+	for _, assigneeID := range assigneeIDs {
+		if err := p.createTaskAssignedNotification(ctx, task, assigneeID); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return p.createTaskAssignedNotification(ctx, task)
+	return errors.Join(errs...)
 }
 
 // PublishTaskCompleted はタスク完了イベントを発行する
 func (p *TaskEventPublisher) PublishTaskCompleted(ctx context.Context, task *domain.Task) error {
 	p.logger.Info("Publishing task completed event", logger.Any("taskID", task.ID))
 
-	// タスク作成者に完了通知を送信（担当者が異なる場合）
-	if task.AssigneeID != nil && *task.AssigneeID != task.CreatedBy {
-		return p.createTaskCompletedNotification(ctx, task)
+	// タスク作成者に完了通知を送信（作成者以外の担当者がいる場合）
+	for _, assigneeID := range task.AssigneeIDs() {
+		if assigneeID != task.CreatedBy {
+			return p.createTaskCompletedNotification(ctx, task)
+		}
 	}
 
 	return nil
@@ -98,30 +116,24 @@ func (p *TaskEventPublisher) PublishTaskCompleted(ctx context.Context, task *dom
 func (p *TaskEventPublisher) PublishTaskOverdue(ctx context.Context, task *domain.Task) error {
 	p.logger.Info("Publishing task overdue event", logger.Any("taskID", task.ID))
 
-	if task.AssigneeID == nil {
-		return nil
+	// 自分の分を完了していない担当者に通知
+	var errs []error
+	for _, assigneeID := range task.PendingAssigneeIDs() {
+		if err := p.createTaskOverdueNotification(ctx, task, assigneeID); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return p.createTaskOverdueNotification(ctx, task)
+	return errors.Join(errs...)
 }
 
 // createTaskAssignedNotification はタスク割り当て通知を作成
-func (p *TaskEventPublisher) createTaskAssignedNotification(ctx context.Context, task *domain.Task) error {
-	title := fmt.Sprintf("新しいタスクが割り当てられました")
-	message := fmt.Sprintf(
-		"タスク「%s」があなたに割り当てられました。\n\n説明: %s\n優先度: %s",
-		task.Title,
-		task.Description,
-		task.Priority,
-	)
-
-	if task.DueDate != nil {
-		message += fmt.Sprintf("\n期限: %s", task.DueDate.Format("2006-01-02 15:04"))
-	}
-
+func (p *TaskEventPublisher) createTaskAssignedNotification(ctx context.Context, task *domain.Task, assigneeID string) error {
+	// タイトル・本文は通知テンプレート（task_assigned）で作成される
 	metadata := map[string]string{
 		"task_id":           task.ID,
 		"task_title":        task.Title,
+		"description":       task.Description,
 		"priority":          string(task.Priority),
 		"created_by":        task.CreatedBy,
 		"notification_type": "task_assigned",
@@ -130,13 +142,12 @@ func (p *TaskEventPublisher) createTaskAssignedNotification(ctx context.Context,
 
 	if task.DueDate != nil {
 		metadata["due_date"] = task.DueDate.Format(time.RFC3339)
+		metadata["due_date_display"] = task.DueDate.Format(dueDateDisplayLayout)
 	}
 
 	createInput := input.CreateNotificationInput{
-		UserID:   *task.AssigneeID,
+		UserID:   assigneeID,
 		Type:     "TASK_ASSIGNED",
-		Title:    title,
-		Message:  message,
 		Metadata: metadata,
 		Channels: []string{"app"}, // アプリ内通知
 	}
@@ -152,24 +163,19 @@ func (p *TaskEventPublisher) createTaskAssignedNotification(ctx context.Context,
 	p.logger.Info("Task assigned notification created",
 		logger.Any("taskID", task.ID),
 		logger.Any("notificationID", notification.GetID()),
-		logger.Any("assigneeID", *task.AssigneeID))
+		logger.Any("assigneeID", assigneeID))
 
 	return nil
 }
 
 // createTaskCompletedNotification はタスク完了通知を作成
 func (p *TaskEventPublisher) createTaskCompletedNotification(ctx context.Context, task *domain.Task) error {
-	title := fmt.Sprintf("タスクが完了されました")
-	message := fmt.Sprintf(
-		"タスク「%s」が完了されました。\n\n担当者: %s",
-		task.Title,
-		*task.AssigneeID, // 実際のプロダクトではユーザー名を取得
-	)
-
+	// タイトル・本文は通知テンプレート（task_completed）で作成される
 	metadata := map[string]string{
 		"task_id":           task.ID,
 		"task_title":        task.Title,
-		"assignee_id":       *task.AssigneeID,
+		"assignee_id":       strings.Join(task.AssigneeIDs(), ","),
+		"assignee_names":    strings.Join(task.AssigneeIDs(), ", "), // 実際のプロダクトではユーザー名を取得
 		"completed_at":      time.Now().Format(time.RFC3339),
 		"notification_type": "task_completed",
 		"action_url":        fmt.Sprintf("/tasks/%s", task.ID),
@@ -178,8 +184,6 @@ func (p *TaskEventPublisher) createTaskCompletedNotification(ctx context.Context
 	createInput := input.CreateNotificationInput{
 		UserID:   task.CreatedBy,
 		Type:     "TASK_COMPLETED",
-		Title:    title,
-		Message:  message,
 		Metadata: metadata,
 		Channels: []string{"app"},
 	}
@@ -201,27 +205,22 @@ func (p *TaskEventPublisher) createTaskCompletedNotification(ctx context.Context
 }
 
 // createTaskUpdateNotification はタスク更新通知を作成
-func (p *TaskEventPublisher) createTaskUpdateNotification(ctx context.Context, task *domain.Task) error {
-	title := fmt.Sprintf("担当タスクが更新されました")
-	message := fmt.Sprintf(
-		"あなたが担当するタスク「%s」が更新されました。",
-		task.Title,
-	)
-
+func (p *TaskEventPublisher) createTaskUpdateNotification(ctx context.Context, task *domain.Task, assigneeID string) error {
+	// タイトル・本文は通知テンプレート（task_updated）で作成される
 	metadata := map[string]string{
 		"task_id":           task.ID,
 		"task_title":        task.Title,
 		"updated_by":        task.CreatedBy, // 簡略化、実際は更新者を追跡
 		"updated_at":        time.Now().Format(time.RFC3339),
+		"actor_id":          task.CreatedBy, // 通知まとめ用の実行者
+		"entity_id":         task.ID,
 		"notification_type": "task_updated",
 		"action_url":        fmt.Sprintf("/tasks/%s", task.ID),
 	}
 
 	createInput := input.CreateNotificationInput{
-		UserID:   *task.AssigneeID,
+		UserID:   assigneeID,
 		Type:     "TASK_ASSIGNED", // 更新通知も割り当て通知と同じタイプを使用
-		Title:    title,
-		Message:  message,
 		Metadata: metadata,
 		Channels: []string{"app"},
 	}
@@ -237,25 +236,19 @@ func (p *TaskEventPublisher) createTaskUpdateNotification(ctx context.Context, t
 	p.logger.Info("Task update notification created",
 		logger.Any("taskID", task.ID),
 		logger.Any("notificationID", notification.GetID()),
-		logger.Any("assigneeID", *task.AssigneeID))
+		logger.Any("assigneeID", assigneeID))
 
 	return nil
 }
 
 // createTaskOverdueNotification はタスク期限切れ通知を作成
-func (p *TaskEventPublisher) createTaskOverdueNotification(ctx context.Context, task *domain.Task) error {
-	title := fmt.Sprintf("⚠️ タスクが期限切れです")
-	message := fmt.Sprintf(
-		"タスク「%s」の期限が過ぎています。\n\n期限: %s\n優先度: %s",
-		task.Title,
-		task.DueDate.Format("2006-01-02 15:04"),
-		task.Priority,
-	)
-
+func (p *TaskEventPublisher) createTaskOverdueNotification(ctx context.Context, task *domain.Task, assigneeID string) error {
+	// タイトル・本文は通知テンプレート（task_overdue）で作成される
 	metadata := map[string]string{
 		"task_id":           task.ID,
 		"task_title":        task.Title,
 		"due_date":          task.DueDate.Format(time.RFC3339),
+		"due_date_display":  task.DueDate.Format(dueDateDisplayLayout),
 		"priority":          string(task.Priority),
 		"notification_type": "task_overdue",
 		"action_url":        fmt.Sprintf("/tasks/%s", task.ID),
@@ -263,10 +256,8 @@ func (p *TaskEventPublisher) createTaskOverdueNotification(ctx context.Context,
 	}
 
 	createInput := input.CreateNotificationInput{
-		UserID:   *task.AssigneeID,
+		UserID:   assigneeID,
 		Type:     "TASK_DUE_SOON", // 期限切れも期限間近通知と同じタイプ
-		Title:    title,
-		Message:  message,
 		Metadata: metadata,
 		Channels: []string{"app"},
 	}
@@ -282,7 +273,98 @@ func (p *TaskEventPublisher) createTaskOverdueNotification(ctx context.Context,
 	p.logger.Info("Task overdue notification created",
 		logger.Any("taskID", task.ID),
 		logger.Any("notificationID", notification.GetID()),
-		logger.Any("assigneeID", *task.AssigneeID))
+		logger.Any("assigneeID", assigneeID))
+
+	return nil
+}
+
+// NotifyTaskEscalated はエスカレーションルール適用時の通知を作成
+func (p *TaskEventPublisher) NotifyTaskEscalated(ctx context.Context, task *domain.Task, recipientID string, rule *domain.EscalationRule) error {
+	// タイトル・本文は通知テンプレート（task_escalated）で作成される
+	metadata := map[string]string{
+		"task_id":           task.ID,
+		"task_title":        task.Title,
+		"due_date":          task.DueDate.Format(time.RFC3339),
+		"due_date_display":  task.DueDate.Format(dueDateDisplayLayout),
+		"priority":          string(task.Priority),
+		"rule_id":           rule.ID,
+		"rule_name":         rule.Name,
+		"overdue_hours":     strconv.Itoa(rule.OverdueHours),
+		"rule_scope":        string(rule.Scope),
+		"notification_type": "task_escalated",
+		"action_url":        fmt.Sprintf("/tasks/%s", task.ID),
+		"urgency":           "high",
+	}
+	if ids := task.AssigneeIDs(); len(ids) > 0 {
+		metadata["assignee_id"] = strings.Join(ids, ",")
+	}
+
+	createInput := input.CreateNotificationInput{
+		UserID:   recipientID,
+		Type:     "TASK_DUE_SOON", // 期限切れ関連は期限間近通知と同じタイプ
+		Metadata: metadata,
+		Channels: []string{"app"},
+	}
+
+	notification, err := p.notificationService.CreateNotification(ctx, createInput)
+	if err != nil {
+		p.logger.Error("Failed to create task escalation notification",
+			logger.Any("taskID", task.ID),
+			logger.Any("recipientID", recipientID),
+			logger.Error(err))
+		return fmt.Errorf("failed to create task escalation notification: %w", err)
+	}
+
+	p.logger.Info("Task escalation notification created",
+		logger.Any("taskID", task.ID),
+		logger.Any("notificationID", notification.GetID()),
+		logger.Any("recipientID", recipientID))
+
+	return nil
+}
+
+// NotifyMentioned はタスクの説明・コメントでメンションされたユーザーへの通知を作成
+func (p *TaskEventPublisher) NotifyMentioned(ctx context.Context, task *domain.Task, mention *domain.Mention, excerpt string) error {
+	actionURL := fmt.Sprintf("/tasks/%s", task.ID)
+	if mention.SourceType == domain.MentionSourceComment {
+		actionURL = fmt.Sprintf("/tasks/%s#comment-%s", task.ID, mention.SourceID)
+	}
+
+	// タイトル・本文は通知テンプレート（task_mentioned）で作成される
+	metadata := map[string]string{
+		"task_id":           task.ID,
+		"task_title":        task.Title,
+		"mention_id":        mention.ID,
+		"source_type":       string(mention.SourceType),
+		"source_id":         mention.SourceID,
+		"author_id":         mention.AuthorID,
+		"excerpt":           excerpt,
+		"actor_id":          mention.AuthorID, // 通知まとめ用の実行者
+		"entity_id":         task.ID,
+		"notification_type": "task_mentioned",
+		"action_url":        actionURL,
+	}
+
+	createInput := input.CreateNotificationInput{
+		UserID:   mention.MentionedUserID,
+		Type:     "TASK_MENTIONED",
+		Metadata: metadata,
+		Channels: []string{"app"},
+	}
+
+	notification, err := p.notificationService.CreateNotification(ctx, createInput)
+	if err != nil {
+		p.logger.Error("Failed to create task mention notification",
+			logger.Any("taskID", task.ID),
+			logger.Any("mentionedUserID", mention.MentionedUserID),
+			logger.Error(err))
+		return fmt.Errorf("failed to create task mention notification: %w", err)
+	}
+
+	p.logger.Info("Task mention notification created",
+		logger.Any("taskID", task.ID),
+		logger.Any("notificationID", notification.GetID()),
+		logger.Any("mentionedUserID", mention.MentionedUserID))
 
 	return nil
 }
@@ -0,0 +1,124 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// thumbnailBatchSize は一度に生成する添付ファイルの件数
+const thumbnailBatchSize = 20
+
+// ThumbnailWorker は画像の添付ファイルのサムネイルを非同期に生成するワーカー
+// アップロード時の通知ですぐに生成し、通知を取りこぼした分や失敗した分は定期的に再試行する
+type ThumbnailWorker struct {
+	attachmentService *usecase.AttachmentService
+	logger            logger.Logger
+	ticker            *time.Ticker
+	notifyCh          chan struct{}
+	stopCh            chan struct{}
+	doneCh            chan struct{}
+	isRunning         bool
+}
+
+// NewThumbnailWorker は新しいThumbnailWorkerを作成
+func NewThumbnailWorker(
+	attachmentService *usecase.AttachmentService,
+	logger logger.Logger,
+) *ThumbnailWorker {
+	return &ThumbnailWorker{
+		attachmentService: attachmentService,
+		logger:            logger,
+		notifyCh:          make(chan struct{}, 1),
+		stopCh:            make(chan struct{}),
+		doneCh:            make(chan struct{}),
+	}
+}
+
+// Notify は生成待ちの添付ファイルがあることをワーカーに通知する（処理中の場合は処理後にもう一度確認する）
+func (w *ThumbnailWorker) Notify() {
+	select {
+	case w.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Start はワーカーを開始（1分ごとに生成待ちの添付ファイルを確認）
+func (w *ThumbnailWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Thumbnail worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(1 * time.Minute)
+
+	w.logger.Info("Starting thumbnail worker")
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		// 初回実行（停止中にアップロードされた分を生成する）
+		w.process(ctx)
+
+		for {
+			select {
+			case <-w.notifyCh:
+				w.process(ctx)
+			case <-w.ticker.C:
+				w.process(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Thumbnail worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Thumbnail worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// process は生成待ちの添付ファイルがなくなるまでサムネイルを生成する
+func (w *ThumbnailWorker) process(ctx context.Context) {
+	defer errtrack.Recover("thumbnail-worker")
+	for {
+		processed, err := w.attachmentService.ProcessPendingThumbnails(ctx, thumbnailBatchSize)
+		if err != nil {
+			w.logger.Error("Failed to process pending thumbnails", logger.Error(err))
+			return
+		}
+		if processed > 0 {
+			w.logger.Debug("Thumbnails generated", logger.Any("count", processed))
+		}
+		// 生成できなかった添付ファイルは次の定期実行で再試行する
+		if processed < thumbnailBatchSize {
+			return
+		}
+
+		select {
+		case <-w.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *ThumbnailWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping thumbnail worker")
+	<-w.doneCh
+}
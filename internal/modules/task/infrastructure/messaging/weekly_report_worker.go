@@ -0,0 +1,95 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// WeeklyReportWorker は配信時刻を過ぎた購読者に週次レポートのメールを送るワーカー
+type WeeklyReportWorker struct {
+	reportService *usecase.WeeklyReportService
+	logger        logger.Logger
+	ticker        *time.Ticker
+	stopCh        chan struct{}
+	doneCh        chan struct{}
+	isRunning     bool
+}
+
+// NewWeeklyReportWorker は新しいWeeklyReportWorkerを作成
+func NewWeeklyReportWorker(
+	reportService *usecase.WeeklyReportService,
+	logger logger.Logger,
+) *WeeklyReportWorker {
+	return &WeeklyReportWorker{
+		reportService: reportService,
+		logger:        logger,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// Start はワーカーを開始（1時間ごとに配信対象を確認）
+func (w *WeeklyReportWorker) Start(ctx context.Context) {
+	if w.isRunning {
+		w.logger.Warn("Weekly report worker already running")
+		return
+	}
+
+	w.isRunning = true
+	w.ticker = time.NewTicker(time.Hour) // 配信時刻は購読者のタイムゾーンの時単位
+
+	w.logger.Info("Starting weekly report worker")
+
+	// 初回実行
+	go w.send(ctx)
+
+	go func() {
+		defer func() {
+			w.ticker.Stop()
+			w.isRunning = false
+			close(w.doneCh)
+		}()
+
+		for {
+			select {
+			case <-w.ticker.C:
+				w.send(ctx)
+			case <-w.stopCh:
+				w.logger.Info("Weekly report worker stopped")
+				return
+			case <-ctx.Done():
+				w.logger.Info("Weekly report worker stopped due to context cancellation")
+				return
+			}
+		}
+	}()
+}
+
+// send は配信時刻を過ぎた購読者に前週のレポートを送る
+func (w *WeeklyReportWorker) send(ctx context.Context) {
+	defer errtrack.Recover("weekly-report-worker")
+	sent, err := w.reportService.SendDueReports(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to send weekly reports", logger.Error(err))
+		return
+	}
+
+	if sent > 0 {
+		w.logger.Info("Weekly reports sent", logger.Any("count", sent))
+	}
+}
+
+// Stop はワーカーを停止し、実行中の処理が終わるまで待つ
+func (w *WeeklyReportWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	close(w.stopCh)
+	w.logger.Info("Stopping weekly report worker")
+	<-w.doneCh
+}
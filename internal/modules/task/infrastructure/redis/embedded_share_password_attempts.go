@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/kvstore"
+)
+
+// EmbeddedSharePasswordAttemptStore はプロセス内のストアを使用した共有リンクのパスワードの試行回数の保存先（REDIS_DRIVER=embedded 用）
+// SharePasswordAttemptStore と同じキーと有効期限で保存する
+type EmbeddedSharePasswordAttemptStore struct {
+	store *kvstore.Store
+}
+
+// NewEmbeddedSharePasswordAttemptStore は新しいEmbeddedSharePasswordAttemptStoreを作成する
+func NewEmbeddedSharePasswordAttemptStore(store *kvstore.Store) *EmbeddedSharePasswordAttemptStore {
+	return &EmbeddedSharePasswordAttemptStore{store: store}
+}
+
+// ReserveAttempt は試行回数を1増やして増やした後の回数を返す（回数は最後の試行から ttl の間保持する）
+func (s *EmbeddedSharePasswordAttemptStore) ReserveAttempt(ctx context.Context, linkID string, ttl time.Duration) (int, error) {
+	return int(s.store.Incr(sharePasswordAttemptKey(linkID), ttl)), nil
+}
+
+// ResetAttempts は試行回数を削除する
+func (s *EmbeddedSharePasswordAttemptStore) ResetAttempts(ctx context.Context, linkID string) error {
+	s.store.Del(sharePasswordAttemptKey(linkID))
+	return nil
+}
@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// sharePasswordAttemptKeyPrefix は共有リンクのパスワードの試行回数のキー（共有リンクのIDを続ける）
+const sharePasswordAttemptKeyPrefix = "share:password_attempts:"
+
+// SharePasswordAttemptStore はRedisを使用した共有リンクのパスワードの試行回数の保存先
+// INCRで数えるため、複数のインスタンスに並行して届いた試行も全て数える
+type SharePasswordAttemptStore struct {
+	client *redis.Client
+}
+
+// NewSharePasswordAttemptStore は新しいSharePasswordAttemptStoreを作成する
+func NewSharePasswordAttemptStore(client *redis.Client) *SharePasswordAttemptStore {
+	return &SharePasswordAttemptStore{client: client}
+}
+
+// ReserveAttempt は試行回数を1増やして増やした後の回数を返す（回数は最後の試行から ttl の間保持する）
+func (s *SharePasswordAttemptStore) ReserveAttempt(ctx context.Context, linkID string, ttl time.Duration) (int, error) {
+	var count *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, sharePasswordAttemptKey(linkID))
+		pipe.Expire(ctx, sharePasswordAttemptKey(linkID), ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve share password attempt: %w", err)
+	}
+	return int(count.Val()), nil
+}
+
+// ResetAttempts は試行回数を削除する
+func (s *SharePasswordAttemptStore) ResetAttempts(ctx context.Context, linkID string) error {
+	if err := s.client.Del(ctx, sharePasswordAttemptKey(linkID)).Err(); err != nil {
+		return fmt.Errorf("failed to reset share password attempts: %w", err)
+	}
+	return nil
+}
+
+func sharePasswordAttemptKey(linkID string) string {
+	return sharePasswordAttemptKeyPrefix + linkID
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// multipartOverhead はmultipartの区切りやヘッダーのために添付ファイルの最大サイズに加えて受け付けるバイト数
+const multipartOverhead = 1 << 20
+
+// thumbnailRetryAfter は生成待ちのサムネイルを再取得するまでの秒数
+const thumbnailRetryAfter = "5"
+
+// AttachmentController はタスクの添付ファイルのHTTPリクエストを処理するコントローラー
+type AttachmentController struct {
+	attachmentService *usecase.AttachmentService
+}
+
+// NewAttachmentController は新しいAttachmentControllerを作成する
+func NewAttachmentController(attachmentService *usecase.AttachmentService) *AttachmentController {
+	return &AttachmentController{
+		attachmentService: attachmentService,
+	}
+}
+
+// AttachmentResponse は添付ファイルのレスポンス
+type AttachmentResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    domain.Attachment `json:"data"`
+} // @name AttachmentResponse
+
+// AttachmentListResponse は添付ファイル一覧のレスポンス
+type AttachmentListResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    []*domain.Attachment `json:"data"`
+} // @name AttachmentListResponse
+
+// UploadAttachment 添付ファイルのアップロード
+// @Summary      添付ファイルのアップロード
+// @Description  タスクにファイルを添付します（multipart/form-data の file）。ファイルの形式は内容から判定し、画像（JPEG・PNG・GIF）の場合はサムネイルを非同期に生成します（thumbnail_status が READY になると取得できます）。タスクの作成者・担当者と、グループタスクの場合はグループのメンバーが添付できます
+// @Tags         tasks
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        file formData file true "添付するファイル"
+// @Security     BearerAuth
+// @Success      201 {object} AttachmentResponse "アップロード成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      413 {object} ErrorResponse "ファイルが大きすぎる"
+// @Failure      429 {object} ErrorResponse "添付ファイルの合計サイズの上限に達している"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/attachments [post]
+func (c *AttachmentController) UploadAttachment(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if c.attachmentService.MaxSize > 0 {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, c.attachmentService.MaxSize+multipartOverhead)
+	}
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handleAttachmentError(ctx, fmt.Errorf("%w: max %d bytes", usecase.ErrAttachmentTooLarge, c.attachmentService.MaxSize))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "file is required",
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		handleAttachmentError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := c.attachmentService.UploadAttachment(ctx, ctx.Param("id"), userID, usecase.AttachmentUpload{
+		Filename: header.Filename,
+		Size:     header.Size,
+		Body:     file,
+	})
+	if err != nil {
+		handleAttachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, AttachmentResponse{
+		Success: true,
+		Data:    *attachment,
+	})
+}
+
+// ListAttachments 添付ファイル一覧
+// @Summary      添付ファイル一覧
+// @Description  タスクの添付ファイルをアップロードした順に取得します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Security     BearerAuth
+// @Success      200 {object} AttachmentListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/attachments [get]
+func (c *AttachmentController) ListAttachments(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	attachments, err := c.attachmentService.ListAttachments(ctx, ctx.Param("id"), userID)
+	if err != nil {
+		handleAttachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AttachmentListResponse{
+		Success: true,
+		Data:    attachments,
+	})
+}
+
+// DownloadAttachment 添付ファイルのダウンロード
+// @Summary      添付ファイルのダウンロード
+// @Description  添付ファイルの本体をダウンロードします
+// @Tags         tasks
+// @Produce      octet-stream
+// @Param        id path string true "添付ファイルID"
+// @Security     BearerAuth
+// @Success      200 {file} file "添付ファイル"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "添付ファイルが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /attachments/{id} [get]
+func (c *AttachmentController) DownloadAttachment(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, body, err := c.attachmentService.OpenAttachment(ctx, ctx.Param("id"), userID)
+	if err != nil {
+		handleAttachmentError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	// ブラウザで開かず保存させ、アップロードされたHTMLなどが実行されないようにする
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.MimeType, body, nil)
+}
+
+// GetThumbnail 添付ファイルのサムネイル
+// @Summary      添付ファイルのサムネイル
+// @Description  画像の添付ファイルのサムネイルを取得します（small: 128px・medium: 320px・large: 640px、縦横比を保ち、元の画像より大きくはしません）。サムネイルは変更されないため、ETag とブラウザのキャッシュ（Cache-Control）を利用できます。生成待ちの場合は 202 と Retry-After を返します
+// @Tags         tasks
+// @Produce      png
+// @Produce      jpeg
+// @Param        id path string true "添付ファイルID"
+// @Param        size query string false "サムネイルの大きさ（省略時は medium）" Enums(small, medium, large)
+// @Param        If-None-Match header string false "前回取得したサムネイルのETag"
+// @Security     BearerAuth
+// @Success      200 {file} file "サムネイル"
+// @Success      202 {object} ErrorResponse "サムネイルの生成待ち"
+// @Success      304 "変更なし"
+// @Failure      400 {object} ErrorResponse "大きさが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "添付ファイルが見つからない、または画像ではない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /attachments/{id}/thumbnail [get]
+func (c *AttachmentController) GetThumbnail(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	size, err := domain.ParseThumbnailSize(ctx.Query("size"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "size must be one of small, medium, large",
+		})
+		return
+	}
+
+	attachment, body, err := c.attachmentService.OpenThumbnail(ctx, ctx.Param("id"), userID, size)
+	if err != nil {
+		handleAttachmentError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	// 添付ファイルは変更できないため、同じ添付ファイル・大きさのサムネイルは常に同じ内容になる
+	etag := fmt.Sprintf(`"%s-%s"`, attachment.ID, size)
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", "private, max-age=31536000, immutable")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.DataFromReader(http.StatusOK, -1, attachment.ThumbnailMimeType(), body, nil)
+}
+
+// DeleteAttachment 添付ファイルの削除
+// @Summary      添付ファイルの削除
+// @Description  添付ファイルとそのサムネイルを削除します（アップロードしたユーザーとタスクの作成者のみ）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "添付ファイルID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "添付ファイルが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /attachments/{id} [delete]
+func (c *AttachmentController) DeleteAttachment(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.attachmentService.DeleteAttachment(ctx, ctx.Param("id"), userID); err != nil {
+		handleAttachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// handleAttachmentError は添付ファイルのエラーをHTTPレスポンスに変換する（それ以外はhandleServiceErrorに委ねる）
+func handleAttachmentError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrAttachmentNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Attachment not found",
+		})
+	case errors.Is(err, usecase.ErrAttachmentTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecase.ErrThumbnailNotReady):
+		ctx.Header("Retry-After", thumbnailRetryAfter)
+		ctx.JSON(http.StatusAccepted, ErrorResponse{
+			Success: false,
+			Error:   "THUMBNAIL_PENDING",
+			Message: "Thumbnail is being generated",
+		})
+	case errors.Is(err, usecase.ErrThumbnailUnavailable):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Thumbnail is not available for this attachment",
+		})
+	default:
+		handleServiceError(ctx, err)
+	}
+}
+
+// etagMatches はIf-None-Matchのいずれかのタグ（弱いタグを含む）がetagと一致するかを返す
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
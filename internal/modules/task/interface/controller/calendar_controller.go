@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// CalendarController はカレンダー表示用のHTTPリクエストを処理するコントローラー
+type CalendarController struct {
+	calendarService *usecase.CalendarService
+}
+
+// NewCalendarController は新しいCalendarControllerを作成する
+func NewCalendarController(calendarService *usecase.CalendarService) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// CalendarResponse はカレンダーのレスポンス
+type CalendarResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    domain.Calendar `json:"data"`
+} // @name CalendarResponse
+
+// GetCalendar カレンダー取得
+// @Summary      カレンダー取得
+// @Description  自分が作成・担当するタスクの着手予定日時と期限を、指定期間の日ごとにまとめて取得します（最大62日）。日の区切りはtimezone、省略時は勤務時間設定のタイムゾーンです
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        from query string true "開始日 (YYYY-MM-DD)"
+// @Param        to query string true "終了日 (YYYY-MM-DD)"
+// @Param        timezone query string false "タイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {object} CalendarResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/calendar [get]
+func (c *CalendarController) GetCalendar(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", ctx.Query("from"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid from date format. Use YYYY-MM-DD",
+		})
+		return
+	}
+	to, err := time.Parse("2006-01-02", ctx.Query("to"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid to date format. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	calendar, err := c.calendarService.GetCalendar(ctx, userID, from, to, ctx.Query("timezone"))
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CalendarResponse{
+		Success: true,
+		Data:    *calendar,
+	})
+}
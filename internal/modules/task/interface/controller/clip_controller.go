@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// clipErrorCodes はブラウザの拡張機能向けAPIのミドルウェアのエラーのステータスごとのコード
+var clipErrorCodes = map[int]string{
+	http.StatusUnauthorized:    "UNAUTHORIZED",
+	http.StatusTooManyRequests: "RATE_LIMITED",
+}
+
+// ClipController はブラウザの拡張機能からのページのクリップのHTTPリクエストを処理するコントローラー
+type ClipController struct {
+	clipService *usecase.ClipService
+}
+
+// NewClipController は新しいClipControllerを作成する
+func NewClipController(clipService *usecase.ClipService) *ClipController {
+	return &ClipController{
+		clipService: clipService,
+	}
+}
+
+// ClipRequest はページのクリップのリクエスト
+type ClipRequest struct {
+	URL   string `json:"url" binding:"required,max=2048" example:"https://example.com/articles/123"`
+	Title string `json:"title" binding:"max=1000" example:"記事のタイトル"`
+	// ページで選択していたテキスト（10000バイトまで、Markdownの引用として説明に入れる）
+	Selection string `json:"selection" example:"あとで確認したい一文"`
+	// 添えるメモ（1000バイトまで、説明の先頭に入れる）
+	Note string `json:"note" example:"金曜までに読む"`
+} // @name ClipRequest
+
+// ClipData はクリップから作成したタスクと追加したリンク
+type ClipData struct {
+	Task TaskResponse `json:"task"`
+	// リンクの追加に失敗した場合は含めない（タスクは作成済み）
+	Link *domain.TaskLink `json:"link,omitempty"`
+} // @name ClipData
+
+// ClipResponse はページのクリップのレスポンス
+type ClipResponse struct {
+	Success bool     `json:"success" example:"true"`
+	Data    ClipData `json:"data"`
+} // @name ClipResponse
+
+// AbortClipError はエラーを返してリクエストを中断する（トークンの認証・レート制限のミドルウェアのエラーにも使う）
+func AbortClipError(ctx *gin.Context, status int, message string) {
+	code, ok := clipErrorCodes[status]
+	if !ok {
+		code = "INTERNAL_ERROR"
+	}
+	ctx.AbortWithStatusJSON(status, ErrorResponse{
+		Success: false,
+		Error:   code,
+		Message: message,
+	})
+}
+
+// Clip ページのクリップ
+// @Summary      ページのクリップ
+// @Description  ブラウザの拡張機能から、閲覧中のページのタイトルをタイトル、メモと選択範囲（Markdownの引用）を説明としてタスクを作成し、ページのURLをリンクとして追加します。GitHubのIssue・Pull RequestのページはIssue・Pull Requestとして関連付けます。clipスコープのトークン（/clip/token で発行）で認証し、トークンごとにレート制限します。CORSは拡張機能のオリジン（CORS_CLIP_ALLOWED_ORIGINS）のみ許可します
+// @Tags         clip
+// @Accept       json
+// @Produce      json
+// @Param        request body ClipRequest true "クリップするページ"
+// @Security     BearerAuth
+// @Success      201 {object} ClipResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "トークンが無効"
+// @Failure      429 {object} ErrorResponse "リクエストが多すぎる・タスクの上限に達した"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /clip [post]
+func (c *ClipController) Clip(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req ClipRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	task, link, err := c.clipService.Clip(ctx, userID, domain.Clip{
+		URL:       req.URL,
+		Title:     req.Title,
+		Selection: req.Selection,
+		Note:      req.Note,
+	})
+	if err != nil {
+		handleClipError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, ClipResponse{
+		Success: true,
+		Data: ClipData{
+			Task: taskToResponse(task),
+			Link: link,
+		},
+	})
+}
+
+func handleClipError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	default:
+		handleServiceError(ctx, err)
+	}
+}
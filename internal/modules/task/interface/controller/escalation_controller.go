@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// EscalationController はエスカレーションルールのHTTPリクエストを処理するコントローラー
+type EscalationController struct {
+	escalationService *usecase.EscalationService
+}
+
+// NewEscalationController は新しいEscalationControllerを作成する
+func NewEscalationController(escalationService *usecase.EscalationService) *EscalationController {
+	return &EscalationController{
+		escalationService: escalationService,
+	}
+}
+
+// EscalationRuleRequest はエスカレーションルール作成/更新リクエスト
+type EscalationRuleRequest struct {
+	GroupID           *string `json:"group_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name              string  `json:"name" binding:"required,max=100" example:"24時間超過で優先度を上げる"`
+	OverdueHours      int     `json:"overdue_hours" binding:"min=0,max=720" example:"24"`
+	RaisePriority     bool    `json:"raise_priority" example:"true"`
+	NotifyAssignee    bool    `json:"notify_assignee" example:"true"`
+	NotifyGroupAdmins bool    `json:"notify_group_admins" example:"false"`
+	ReassignTo        *string `json:"reassign_to,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Enabled           *bool   `json:"enabled,omitempty" example:"true"`
+} // @name EscalationRuleRequest
+
+// EscalationRuleResponse はエスカレーションルールレスポンス
+type EscalationRuleResponse struct {
+	ID                string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Scope             string    `json:"scope" example:"USER"`
+	OwnerID           string    `json:"owner_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name              string    `json:"name" example:"24時間超過で優先度を上げる"`
+	OverdueHours      int       `json:"overdue_hours" example:"24"`
+	RaisePriority     bool      `json:"raise_priority" example:"true"`
+	NotifyAssignee    bool      `json:"notify_assignee" example:"true"`
+	NotifyGroupAdmins bool      `json:"notify_group_admins" example:"false"`
+	ReassignTo        *string   `json:"reassign_to,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Enabled           bool      `json:"enabled" example:"true"`
+	CreatedBy         string    `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt         time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt         time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name EscalationRuleResponse
+
+// ListEscalationRules エスカレーションルール一覧
+// @Summary      エスカレーションルール一覧
+// @Description  自分のエスカレーションルール、またはgroup_id指定時はグループのルールを取得します（グループはOWNER/ADMINのみ）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        group_id query string false "グループID"
+// @Security     BearerAuth
+// @Success      200 {array} EscalationRuleResponse "ルール一覧取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/escalation-rules [get]
+func (c *EscalationController) ListEscalationRules(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var groupID *string
+	if g := ctx.Query("group_id"); g != "" {
+		groupID = &g
+	}
+
+	rules, err := c.escalationService.ListRules(ctx, userID, groupID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	responses := make([]EscalationRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, escalationRuleToResponse(rule))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    responses,
+	})
+}
+
+// CreateEscalationRule エスカレーションルール作成
+// @Summary      エスカレーションルール作成
+// @Description  期限切れタスクのエスカレーションルールを作成します。group_idを指定するとグループタスク向けのルールになります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body EscalationRuleRequest true "ルール情報"
+// @Security     BearerAuth
+// @Success      201 {object} EscalationRuleResponse "ルール作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/escalation-rules [post]
+func (c *EscalationController) CreateEscalationRule(ctx *gin.Context) {
+	userID, input, ok := c.bindRuleRequest(ctx)
+	if !ok {
+		return
+	}
+
+	rule, err := c.escalationService.CreateRule(ctx, userID, input)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Escalation rule created successfully",
+		"data":    escalationRuleToResponse(rule),
+	})
+}
+
+// UpdateEscalationRule エスカレーションルール更新
+// @Summary      エスカレーションルール更新
+// @Description  エスカレーションルールを更新します（適用範囲は変更できません）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        rule_id path string true "ルールID"
+// @Param        request body EscalationRuleRequest true "ルール情報"
+// @Security     BearerAuth
+// @Success      200 {object} EscalationRuleResponse "ルール更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "ルールが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/escalation-rules/{rule_id} [put]
+func (c *EscalationController) UpdateEscalationRule(ctx *gin.Context) {
+	userID, input, ok := c.bindRuleRequest(ctx)
+	if !ok {
+		return
+	}
+
+	rule, err := c.escalationService.UpdateRule(ctx, userID, ctx.Param("rule_id"), input)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Escalation rule updated successfully",
+		"data":    escalationRuleToResponse(rule),
+	})
+}
+
+// DeleteEscalationRule エスカレーションルール削除
+// @Summary      エスカレーションルール削除
+// @Description  エスカレーションルールを削除します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        rule_id path string true "ルールID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "ルール削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "ルールが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/escalation-rules/{rule_id} [delete]
+func (c *EscalationController) DeleteEscalationRule(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := c.escalationService.DeleteRule(ctx, userID, ctx.Param("rule_id")); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Escalation rule deleted successfully",
+	})
+}
+
+// bindRuleRequest はリクエストを検証してサービス入力に変換する
+func (c *EscalationController) bindRuleRequest(ctx *gin.Context) (string, usecase.EscalationRuleInput, bool) {
+	var req EscalationRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return "", usecase.EscalationRuleInput{}, false
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return "", usecase.EscalationRuleInput{}, false
+	}
+
+	// enabled未指定時は有効とする
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	return userID, usecase.EscalationRuleInput{
+		GroupID:           req.GroupID,
+		Name:              req.Name,
+		OverdueHours:      req.OverdueHours,
+		RaisePriority:     req.RaisePriority,
+		NotifyAssignee:    req.NotifyAssignee,
+		NotifyGroupAdmins: req.NotifyGroupAdmins,
+		ReassignTo:        req.ReassignTo,
+		Enabled:           enabled,
+	}, true
+}
+
+// escalationRuleToResponse はドメインモデルからレスポンスモデルに変換する
+func escalationRuleToResponse(rule *domain.EscalationRule) EscalationRuleResponse {
+	return EscalationRuleResponse{
+		ID:                rule.ID,
+		Scope:             string(rule.Scope),
+		OwnerID:           rule.OwnerID,
+		Name:              rule.Name,
+		OverdueHours:      rule.OverdueHours,
+		RaisePriority:     rule.RaisePriority,
+		NotifyAssignee:    rule.NotifyAssignee,
+		NotifyGroupAdmins: rule.NotifyGroupAdmins,
+		ReassignTo:        rule.ReassignTo,
+		Enabled:           rule.Enabled,
+		CreatedBy:         rule.CreatedBy,
+		CreatedAt:         rule.CreatedAt,
+		UpdatedAt:         rule.UpdatedAt,
+	}
+}
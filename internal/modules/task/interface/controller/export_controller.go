@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// exportFlushLines はエクスポートのレスポンスをクライアントへ送り出す行数の間隔
+const exportFlushLines = 100
+
+// ExportController はタスク・統計のエクスポートのHTTPリクエストを処理するコントローラー
+type ExportController struct {
+	exportService *usecase.ExportService
+}
+
+// NewExportController は新しいExportControllerを作成する
+func NewExportController(exportService *usecase.ExportService) *ExportController {
+	return &ExportController{
+		exportService: exportService,
+	}
+}
+
+// ExportErrorLine はエクスポートの途中でエラーになった場合に最終行として出力する行
+type ExportErrorLine struct {
+	Error   string `json:"error" example:"EXPORT_FAILED"`
+	Message string `json:"message" example:"Export was interrupted"`
+} // @name ExportErrorLine
+
+// ExportTasks タスクのエクスポート
+// @Summary      タスクのエクスポート
+// @Description  自分が作成または担当するタスクを作成日時順にNDJSON（1行に1件のタスク、形式はタスク取得と同じ）で全件ダウンロードします。件数に関わらず逐次書き出します。途中でエラーになった場合は最終行に ExportErrorLine を出力します
+// @Tags         tasks
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Success      200 {object} TaskResponse "エクスポート成功（1行に1件）"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/export [get]
+func (c *ExportController) ExportTasks(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	w := newNDJSONWriter(ctx, "tasks")
+	err := c.exportService.ExportTasks(ctx.Request.Context(), userID, func(task *domain.Task) error {
+		return w.write(taskToResponse(task))
+	})
+	w.finish(err)
+}
+
+// ExportStats 統計のエクスポート
+// @Summary      統計のエクスポート
+// @Description  自分が作成または担当するタスクを1行1件の集計用の項目（ステータス・期限切れ・期限後の完了・リードタイムなど）としてNDJSONで全件ダウンロードします。件数に関わらず逐次書き出します。途中でエラーになった場合は最終行に ExportErrorLine を出力します
+// @Tags         stats
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Success      200 {object} domain.TaskStatsRecord "エクスポート成功（1行に1件）"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/export [get]
+func (c *ExportController) ExportStats(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	w := newNDJSONWriter(ctx, "task-stats")
+	err := c.exportService.ExportTaskStats(ctx.Request.Context(), userID, time.Now(), func(record *domain.TaskStatsRecord) error {
+		return w.write(record)
+	})
+	w.finish(err)
+}
+
+// ndjsonWriter はNDJSONのレスポンスを逐次書き出す
+// 最初の行を書き出すまではヘッダーを送らないため、取得前のエラーは通常のエラーレスポンスで返せる
+type ndjsonWriter struct {
+	ctx     *gin.Context
+	name    string
+	encoder *json.Encoder
+	lines   int
+	started bool
+}
+
+// newNDJSONWriter はファイル名（拡張子なし）を指定してndjsonWriterを作成する
+func newNDJSONWriter(ctx *gin.Context, name string) *ndjsonWriter {
+	return &ndjsonWriter{ctx: ctx, name: name}
+}
+
+// start はレスポンスのヘッダーを送る
+func (w *ndjsonWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+
+	filename := fmt.Sprintf("%s-%s.ndjson", w.name, time.Now().Format("20060102"))
+	w.ctx.Header("Content-Type", "application/x-ndjson; charset=utf-8")
+	w.ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.ctx.Header("Cache-Control", "no-store")
+	w.ctx.Status(http.StatusOK)
+	w.encoder = json.NewEncoder(w.ctx.Writer)
+}
+
+// write は1行を書き出し、exportFlushLines行ごとにクライアントへ送り出す
+func (w *ndjsonWriter) write(v interface{}) error {
+	w.start()
+	if err := w.encoder.Encode(v); err != nil {
+		return err
+	}
+	w.lines++
+	if w.lines%exportFlushLines == 0 {
+		w.ctx.Writer.Flush()
+	}
+	return nil
+}
+
+// finish は残りを送り出す。エラーの場合、書き出し前であればエラーレスポンスを、書き出し後であれば最終行にエラーを出力する
+func (w *ndjsonWriter) finish(err error) {
+	if err != nil && !w.started {
+		handleServiceError(w.ctx, err)
+		return
+	}
+	w.start()
+	if err != nil && w.ctx.Request.Context().Err() == nil {
+		_ = w.encoder.Encode(ExportErrorLine{Error: "EXPORT_FAILED", Message: "Export was interrupted"})
+	}
+	w.ctx.Writer.Flush()
+}
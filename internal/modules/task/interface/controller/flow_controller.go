@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// FlowController はバーンダウン・累積フロー図のHTTPリクエストを処理するコントローラー
+type FlowController struct {
+	flowService *usecase.FlowService
+}
+
+// NewFlowController は新しいFlowControllerを作成する
+func NewFlowController(flowService *usecase.FlowService) *FlowController {
+	return &FlowController{
+		flowService: flowService,
+	}
+}
+
+// FlowBurndownResponse はバーンダウンのレスポンス
+type FlowBurndownResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.FlowBurndown `json:"data"`
+} // @name FlowBurndownResponse
+
+// CumulativeFlowResponse は累積フロー図のレスポンス
+type CumulativeFlowResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    domain.CumulativeFlow `json:"data"`
+} // @name CumulativeFlowResponse
+
+// GetBurndown バーンダウン取得
+// @Summary      バーンダウン取得
+// @Description  期間内の日ごとの未完了タスク数（その日に追加・完了した数と理想線を含む）を取得します。タスクの変更履歴から当時のステータスを再生して集計するため、過去の日の値は後の変更の影響を受けません。scope=userは自分が作成・担当するタスク、scope=groupはグループタスク（メンバーのみ）が対象です
+// @Tags         stats
+// @Produce      json
+// @Param        scope query string false "対象（省略時は user）" Enums(user, group)
+// @Param        group_id query string false "グループID（scope=group の場合は必須）"
+// @Param        range query string false "期間（日数または週数、省略時は 30d）" example:"12w"
+// @Param        timezone query string false "タイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {object} FlowBurndownResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが不正"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループのメンバーではない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/burndown [get]
+func (c *FlowController) GetBurndown(ctx *gin.Context) {
+	query, ok := flowQuery(ctx)
+	if !ok {
+		return
+	}
+
+	burndown, err := c.flowService.GetBurndown(ctx, query, time.Now())
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, FlowBurndownResponse{
+		Success: true,
+		Data:    *burndown,
+	})
+}
+
+// GetCumulativeFlow 累積フロー図取得
+// @Summary      累積フロー図取得
+// @Description  期間内の日ごとのステータス別タスク数（その日の終わり時点）を取得します。タスクの変更履歴から当時のステータスを再生して集計します。パラメータはバーンダウンと同じです
+// @Tags         stats
+// @Produce      json
+// @Param        scope query string false "対象（省略時は user）" Enums(user, group)
+// @Param        group_id query string false "グループID（scope=group の場合は必須）"
+// @Param        range query string false "期間（日数または週数、省略時は 30d）" example:"12w"
+// @Param        timezone query string false "タイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {object} CumulativeFlowResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが不正"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループのメンバーではない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/cumulative-flow [get]
+func (c *FlowController) GetCumulativeFlow(ctx *gin.Context) {
+	query, ok := flowQuery(ctx)
+	if !ok {
+		return
+	}
+
+	cfd, err := c.flowService.GetCumulativeFlow(ctx, query, time.Now())
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CumulativeFlowResponse{
+		Success: true,
+		Data:    *cfd,
+	})
+}
+
+// flowQuery はクエリパラメータから取得条件を作成する
+func flowQuery(ctx *gin.Context) (usecase.FlowQuery, bool) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return usecase.FlowQuery{}, false
+	}
+
+	return usecase.FlowQuery{
+		UserID:   userID,
+		Scope:    domain.FlowScope(ctx.Query("scope")),
+		GroupID:  ctx.Query("group_id"),
+		Range:    ctx.Query("range"),
+		Timezone: ctx.Query("timezone"),
+	}, true
+}
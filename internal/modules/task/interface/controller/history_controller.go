@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// HistoryController はタスクの変更履歴のHTTPリクエストを処理するコントローラー
+type HistoryController struct {
+	historyService *usecase.HistoryService
+}
+
+// NewHistoryController は新しいHistoryControllerを作成する
+func NewHistoryController(historyService *usecase.HistoryService) *HistoryController {
+	return &HistoryController{
+		historyService: historyService,
+	}
+}
+
+// TaskHistoryResponse はタスクの変更履歴のレスポンス
+type TaskHistoryResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    domain.TaskHistory `json:"data"`
+} // @name TaskHistoryResponse
+
+// GetTaskHistory タスクの変更履歴の再生
+// @Summary      タスクの変更履歴の再生
+// @Description  タスクの変更イベントを再生し、指定した時点のタスクの状態と、その状態にした最後の変更（変更者・日時・変更したフィールド）を取得します。タスクの作成者・担当者と、グループタスクの場合はグループのメンバーが参照できます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        at query string false "再生する時点（RFC3339、省略時は現在）" example:"2024-06-01T09:00:00Z"
+// @Security     BearerAuth
+// @Success      200 {object} TaskHistoryResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスク・指定した時点の履歴が見つからない、または機能が公開されていない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/history [get]
+func (c *HistoryController) GetTaskHistory(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	at := time.Now()
+	if value := ctx.Query("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "at must be an RFC3339 timestamp",
+			})
+			return
+		}
+		at = parsed
+	}
+
+	history, err := c.historyService.GetTaskHistory(ctx, ctx.Param("id"), userID, at)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskHistoryResponse{
+		Success: true,
+		Data:    *history,
+	})
+}
@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// maxInboundEmailPayloadSize はメールの受信のWebhookのリクエストボディの上限（Mailgunが受け付けるメールの上限は25MB）
+const maxInboundEmailPayloadSize = 40 << 20 // 40MB（添付ファイルのmultipartのエンコード分を含む）
+
+// InboundEmailController はタスク作成用のメールアドレスとメールの受信のWebhookのHTTPリクエストを処理するコントローラー
+type InboundEmailController struct {
+	inboundEmailService *usecase.InboundEmailService
+}
+
+// NewInboundEmailController は新しいInboundEmailControllerを作成する
+func NewInboundEmailController(inboundEmailService *usecase.InboundEmailService) *InboundEmailController {
+	return &InboundEmailController{
+		inboundEmailService: inboundEmailService,
+	}
+}
+
+// InboundAddressRequest はタスク作成用のメールアドレスの作成・差出人の変更リクエスト
+type InboundAddressRequest struct {
+	// 許可する差出人（メールアドレス、または "@example.com" のドメイン、最大20件）。空の場合はアカウントのメールアドレス
+	AllowedSenders []string `json:"allowed_senders" binding:"max=20,dive,max=255" example:"user@example.com,@example.co.jp"`
+} // @name InboundAddressRequest
+
+// InboundAddressData はタスク作成用のメールアドレス
+type InboundAddressData struct {
+	Email          string    `json:"email" example:"k3q7xw2m9p4r8t6v1y5z0abc@in.example.com"`
+	AllowedSenders []string  `json:"allowed_senders" example:"user@example.com,@example.co.jp"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+} // @name InboundAddressData
+
+// InboundAddressResponse はタスク作成用のメールアドレスのレスポンス
+type InboundAddressResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    InboundAddressData `json:"data"`
+} // @name InboundAddressResponse
+
+// InboundEmailWebhookResponse はメールの受信のWebhookの受信結果
+type InboundEmailWebhookResponse struct {
+	Received bool                      `json:"received" example:"true"`
+	Status   domain.InboundEmailStatus `json:"status" example:"CREATED" enums:"CREATED,DUPLICATE,UNKNOWN_RECIPIENT,SENDER_NOT_ALLOWED,SPAM,RATE_LIMITED"`
+	TaskID   string                    `json:"task_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // 作成したタスクのID
+} // @name InboundEmailWebhookResponse
+
+// GetInboundAddress タスク作成用のメールアドレス
+// @Summary      タスク作成用のメールアドレス
+// @Description  メールを送るとタスクを作成できる自分専用のメールアドレスと、許可した差出人を取得します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} InboundAddressResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "メールアドレスを作成していない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /integrations/email [get]
+func (c *InboundEmailController) GetInboundAddress(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	address, err := c.inboundEmailService.GetAddress(ctx, userID)
+	if err != nil {
+		handleInboundEmailError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toResponse(address))
+}
+
+// EnableInboundAddress タスク作成用のメールアドレスの作成・差出人の変更
+// @Summary      タスク作成用のメールアドレスの作成・差出人の変更
+// @Description  自分専用のメールアドレスを作成し、許可する差出人を設定します。作成済みの場合はアドレスを変えずに差出人だけを置き換えます。許可した差出人からSPF・DKIMで確認できたメールだけを受け付け、件名をタイトル、本文を説明としてタスクを作成し、添付ファイル（10件まで）をタスクに添付します。説明に収まらない本文は message.txt として添付します。スパムと判定されたメール・同じMessage-IDのメールは無視し、1時間に作成できるタスクの数には上限があります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body InboundAddressRequest true "許可する差出人"
+// @Security     BearerAuth
+// @Success      200 {object} InboundAddressResponse "作成・変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "メールの受信が設定されていない"
+// @Router       /integrations/email [put]
+func (c *InboundEmailController) EnableInboundAddress(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req InboundAddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	address, err := c.inboundEmailService.EnableAddress(ctx, userID, req.AllowedSenders)
+	if err != nil {
+		handleInboundEmailError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toResponse(address))
+}
+
+// RotateInboundAddress タスク作成用のメールアドレスの再発行
+// @Summary      タスク作成用のメールアドレスの再発行
+// @Description  メールアドレスを新しいものに変えます。以前のアドレス宛てのメールは受け付けなくなります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} InboundAddressResponse "再発行成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "メールアドレスを作成していない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "メールの受信が設定されていない"
+// @Router       /integrations/email/rotate [post]
+func (c *InboundEmailController) RotateInboundAddress(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	address, err := c.inboundEmailService.RotateAddress(ctx, userID)
+	if err != nil {
+		handleInboundEmailError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toResponse(address))
+}
+
+// DisableInboundAddress タスク作成用のメールアドレスの削除
+// @Summary      タスク作成用のメールアドレスの削除
+// @Description  メールアドレスを削除します。作成済みのタスクはそのまま残ります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "メールアドレスを作成していない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /integrations/email [delete]
+func (c *InboundEmailController) DisableInboundAddress(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.inboundEmailService.DisableAddress(ctx, userID); err != nil {
+		handleInboundEmailError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Inbound email address deleted successfully",
+	})
+}
+
+// HandleWebhook メールの受信のWebhook
+// @Summary      メールの受信のWebhook
+// @Description  MailgunのInbound Routes（forward）で転送されたメールを受信し、宛先のメールアドレスのユーザーのタスクを作成します。timestamp・token・signatureの署名を検証します。宛先・差出人・スパム判定・重複・上限で受け付けなかったメールも、再送されないように200でstatusを返します
+// @Tags         tasks
+// @Accept       mpfd
+// @Produce      json
+// @Success      200 {object} InboundEmailWebhookResponse "受信成功"
+// @Failure      400 {object} ErrorResponse "ペイロードが無効"
+// @Failure      401 {object} ErrorResponse "署名が無効"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "メールの受信が設定されていない"
+// @Router       /webhooks/email/inbound [post]
+func (c *InboundEmailController) HandleWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxInboundEmailPayloadSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	status, task, err := c.inboundEmailService.HandleWebhook(ctx, ctx.GetHeader("Content-Type"), payload)
+	if err != nil {
+		handleInboundEmailError(ctx, err)
+		return
+	}
+
+	resp := InboundEmailWebhookResponse{
+		Received: true,
+		Status:   status,
+	}
+	if task != nil {
+		resp.TaskID = task.ID
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// toResponse はアドレスをメールアドレスを含むレスポンスに変換する
+func (c *InboundEmailController) toResponse(address *domain.InboundAddress) InboundAddressResponse {
+	return InboundAddressResponse{
+		Success: true,
+		Data: InboundAddressData{
+			Email:          c.inboundEmailService.EmailAddress(address),
+			AllowedSenders: address.AllowedSenders,
+			CreatedAt:      address.CreatedAt,
+			UpdatedAt:      address.UpdatedAt,
+		},
+	}
+}
+
+func handleInboundEmailError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInboundAddressNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Inbound email address not found",
+		})
+	case errors.Is(err, usecase.ErrInboundEmailDisabled):
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Success: false,
+			Error:   "INBOUND_EMAIL_DISABLED",
+			Message: "Inbound email is not configured",
+		})
+	case errors.Is(err, usecase.ErrInvalidInboundSignature):
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "INVALID_WEBHOOK",
+			Message: "Invalid webhook signature",
+		})
+	case errors.Is(err, usecase.ErrInvalidInboundEmail):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "INVALID_WEBHOOK",
+			Message: err.Error(),
+		})
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	default:
+		handleServiceError(ctx, err)
+	}
+}
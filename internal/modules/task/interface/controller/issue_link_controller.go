@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// maxGitHubWebhookPayloadSize はGitHubのWebhookのリクエストボディの上限（GitHubが送るペイロードの上限は25MBだが、Issue・Pull Requestのイベントは小さい）
+const maxGitHubWebhookPayloadSize = 5 << 20 // 5MB
+
+// IssueLinkController はGitHubのトークン・GitHubのWebhookのHTTPリクエストを処理するコントローラー
+// Issue・Pull Requestの関連付けはタスクのリンク（TaskLinkController）として扱う
+type IssueLinkController struct {
+	issueLinkService *usecase.IssueLinkService
+}
+
+// NewIssueLinkController は新しいIssueLinkControllerを作成する
+func NewIssueLinkController(issueLinkService *usecase.IssueLinkService) *IssueLinkController {
+	return &IssueLinkController{
+		issueLinkService: issueLinkService,
+	}
+}
+
+// GitHubAccountRequest はGitHubのトークンの登録リクエスト
+type GitHubAccountRequest struct {
+	Token string `json:"token" binding:"required,max=255" example:"github_pat_xxxxxxxx"`
+} // @name GitHubAccountRequest
+
+// GitHubAccountData は登録したGitHubのアカウント（トークンは末尾4文字だけを表示する）
+type GitHubAccountData struct {
+	Login     string    `json:"login" example:"octocat"`
+	Token     string    `json:"token" example:"********abcd"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name GitHubAccountData
+
+// GitHubAccountResponse はGitHubのアカウントのレスポンス
+type GitHubAccountResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    GitHubAccountData `json:"data"`
+} // @name GitHubAccountResponse
+
+// GitHubWebhookResponse はGitHubのWebhookの受信結果
+type GitHubWebhookResponse struct {
+	Received       bool `json:"received" example:"true"`
+	CompletedTasks int  `json:"completed_tasks" example:"1"` // 完了にしたタスクの数
+} // @name GitHubWebhookResponse
+
+// GetGitHubAccount 登録したGitHubのアカウント
+// @Summary      登録したGitHubのアカウント
+// @Description  Issue・Pull Requestの取得に使うGitHubのアカウントを取得します。トークンは末尾4文字だけを表示します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} GitHubAccountResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "GitHubのトークンが登録されていない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /integrations/github [get]
+func (c *IssueLinkController) GetGitHubAccount(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	account, err := c.issueLinkService.GetGitHubAccount(ctx, userID)
+	if err != nil {
+		handleIssueLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gitHubAccountToResponse(account))
+}
+
+// ConnectGitHub GitHubのトークンの登録
+// @Summary      GitHubのトークンの登録
+// @Description  非公開のリポジトリのIssue・Pull Requestを関連付けるためのGitHubのトークン（Fine-grainedトークンの場合はIssues・Pull requestsの読み取り権限）を登録します。GitHubでトークンを確認してから保存し、登録済みの場合は置き換えます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body GitHubAccountRequest true "GitHubのトークン"
+// @Security     BearerAuth
+// @Success      200 {object} GitHubAccountResponse "登録成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効、またはトークンが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      502 {object} ErrorResponse "GitHubに接続できない"
+// @Router       /integrations/github [put]
+func (c *IssueLinkController) ConnectGitHub(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req GitHubAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	account, err := c.issueLinkService.ConnectGitHub(ctx, userID, req.Token)
+	if err != nil {
+		handleIssueLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gitHubAccountToResponse(account))
+}
+
+// DisconnectGitHub GitHubのトークンの削除
+// @Summary      GitHubのトークンの削除
+// @Description  登録したGitHubのトークンを削除します。関連付けたIssue・Pull RequestとWebhookによる状態の同期はそのまま残ります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "GitHubのトークンが登録されていない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /integrations/github [delete]
+func (c *IssueLinkController) DisconnectGitHub(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.issueLinkService.DisconnectGitHub(ctx, userID); err != nil {
+		handleIssueLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "GitHub account disconnected successfully",
+	})
+}
+
+// HandleWebhook GitHubのWebhook
+// @Summary      GitHubのWebhook
+// @Description  GitHubのissues・pull_requestイベントを受信し、関連付けたタスクにIssue・Pull Requestの状態を反映します。Pull Requestのマージ・Issueの完了で、complete_on_closeの関連付けのタスクを完了にします。X-Hub-Signature-256ヘッダーの署名を検証します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        X-GitHub-Event header string true "イベントの種類（issues, pull_request, ping）"
+// @Param        X-Hub-Signature-256 header string true "Webhookシークレットによる署名"
+// @Success      200 {object} GitHubWebhookResponse "受信成功"
+// @Failure      400 {object} ErrorResponse "ペイロードが無効"
+// @Failure      401 {object} ErrorResponse "署名が無効"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      503 {object} ErrorResponse "Webhookが設定されていない"
+// @Router       /webhooks/github [post]
+func (c *IssueLinkController) HandleWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxGitHubWebhookPayloadSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	completed, err := c.issueLinkService.HandleWebhook(ctx, payload, ctx.GetHeader("X-GitHub-Event"), ctx.GetHeader("X-Hub-Signature-256"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGitHubWebhookDisabled):
+			ctx.JSON(http.StatusServiceUnavailable, ErrorResponse{
+				Success: false,
+				Error:   "WEBHOOK_DISABLED",
+				Message: "GitHub webhook is not configured",
+			})
+		case errors.Is(err, usecase.ErrInvalidGitHubSignature):
+			ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+				Success: false,
+				Error:   "INVALID_WEBHOOK",
+				Message: "Invalid webhook signature",
+			})
+		case errors.Is(err, usecase.ErrInvalidGitHubWebhook):
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "INVALID_WEBHOOK",
+				Message: err.Error(),
+			})
+		default:
+			handleServiceError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GitHubWebhookResponse{
+		Received:       true,
+		CompletedTasks: completed,
+	})
+}
+
+// gitHubAccountToResponse はGitHubのアカウントからトークンを伏せたレスポンスに変換する
+func gitHubAccountToResponse(account *domain.GitHubAccount) GitHubAccountResponse {
+	return GitHubAccountResponse{
+		Success: true,
+		Data: GitHubAccountData{
+			Login:     account.Login,
+			Token:     account.MaskedToken(),
+			CreatedAt: account.CreatedAt,
+			UpdatedAt: account.UpdatedAt,
+		},
+	}
+}
+
+func handleIssueLinkError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrIssueLinkNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Issue link not found",
+		})
+	case errors.Is(err, usecase.ErrIssueNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Issue or pull request not found on GitHub",
+		})
+	case errors.Is(err, usecase.ErrGitHubAccountNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "GitHub account not connected",
+		})
+	case errors.Is(err, usecase.ErrIssueLinkExists):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Issue already linked to this task",
+		})
+	case errors.Is(err, usecase.ErrInvalidGitHubToken):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "INVALID_GITHUB_TOKEN",
+			Message: "GitHub token is invalid or expired",
+		})
+	case errors.Is(err, usecase.ErrGitHubUnavailable):
+		ctx.JSON(http.StatusBadGateway, ErrorResponse{
+			Success: false,
+			Error:   "GITHUB_UNAVAILABLE",
+			Message: "GitHub is unavailable",
+		})
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	default:
+		handleServiceError(ctx, err)
+	}
+}
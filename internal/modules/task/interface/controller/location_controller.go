@@ -0,0 +1,446 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// LocationController はタスクの場所・近くのタスク・ジオフェンスのHTTPリクエストを処理するコントローラー
+type LocationController struct {
+	locationService *usecase.LocationService
+}
+
+// NewLocationController は新しいLocationControllerを作成する
+func NewLocationController(locationService *usecase.LocationService) *LocationController {
+	return &LocationController{
+		locationService: locationService,
+	}
+}
+
+// TaskLocationRequest はタスクの場所の設定リクエスト
+type TaskLocationRequest struct {
+	Name      string   `json:"name" binding:"required,max=200" example:"東京駅"`
+	Latitude  *float64 `json:"latitude" binding:"required" example:"35.681236"`
+	Longitude *float64 `json:"longitude" binding:"required" example:"139.767125"`
+} // @name TaskLocationRequest
+
+// GeofenceRequest はジオフェンスの登録リクエスト（radius_metersを省略した場合は200m）
+type GeofenceRequest struct {
+	RadiusMeters int `json:"radius_meters" example:"200"`
+} // @name GeofenceRequest
+
+// GeofenceEnterRequest は端末がジオフェンスに入ったことの報告（緯度・経度は任意）
+type GeofenceEnterRequest struct {
+	Latitude  *float64 `json:"latitude,omitempty" example:"35.6813"`
+	Longitude *float64 `json:"longitude,omitempty" example:"139.7671"`
+} // @name GeofenceEnterRequest
+
+// TaskLocationResponse はタスクの場所のレスポンス
+type TaskLocationResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.TaskLocation `json:"data"`
+} // @name TaskLocationResponse
+
+// NearbyTaskItem は近くのタスクの1件
+type NearbyTaskItem struct {
+	Task           TaskResponse    `json:"task"`
+	Location       domain.Location `json:"location"`
+	DistanceMeters float64         `json:"distance_meters" example:"120.5"`
+} // @name NearbyTaskItem
+
+// NearbyTasksResponse は近くのタスクのレスポンス
+type NearbyTasksResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    []NearbyTaskItem `json:"data"`
+} // @name NearbyTasksResponse
+
+// GeofenceResponse はジオフェンスのレスポンス
+type GeofenceResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    domain.Geofence `json:"data"`
+} // @name GeofenceResponse
+
+// GeofenceListResponse はジオフェンス一覧のレスポンス
+type GeofenceListResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    []*domain.Geofence `json:"data"`
+} // @name GeofenceListResponse
+
+// GeofenceEnterResponse はジオフェンスに入ったことの報告のレスポンス
+type GeofenceEnterResponse struct {
+	Success   bool `json:"success" example:"true"`
+	Triggered bool `json:"triggered" example:"true"` // リマインダーを送ったか
+} // @name GeofenceEnterResponse
+
+// GetTaskLocation タスクの場所
+// @Summary      タスクの場所
+// @Description  タスクに設定した場所（名前と緯度・経度）を取得します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskLocationResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない、または場所が設定されていない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/location [get]
+func (c *LocationController) GetTaskLocation(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	location, err := c.locationService.GetTaskLocation(ctx, ctx.Param("id"), userID)
+	if err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskLocationResponse{
+		Success: true,
+		Data:    *location,
+	})
+}
+
+// SetTaskLocation タスクの場所の設定
+// @Summary      タスクの場所の設定
+// @Description  タスクに場所（名前と緯度・経度）を設定します。設定済みの場合は置き換え、登録済みのジオフェンスの中心も新しい場所に移ります。グループタスクの場所はグループの予定の場所として表示されます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        request body TaskLocationRequest true "場所"
+// @Security     BearerAuth
+// @Success      200 {object} TaskLocationResponse "設定成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/location [put]
+func (c *LocationController) SetTaskLocation(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	location, err := c.locationService.SetTaskLocation(ctx, ctx.Param("id"), userID, domain.Location{
+		Name:      req.Name,
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+	})
+	if err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskLocationResponse{
+		Success: true,
+		Data:    *location,
+	})
+}
+
+// DeleteTaskLocation タスクの場所の削除
+// @Summary      タスクの場所の削除
+// @Description  タスクの場所を削除します。タスクに登録されたジオフェンスもすべて削除されます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない、または場所が設定されていない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/location [delete]
+func (c *LocationController) DeleteTaskLocation(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.locationService.DeleteTaskLocation(ctx, ctx.Param("id"), userID); err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Task location deleted successfully",
+	})
+}
+
+// ListNearbyTasks 近くのタスク
+// @Summary      近くのタスク
+// @Description  指定した地点から半径内に場所がある未完了のタスク（自分が参照できるもの）を近い順に最大50件取得します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        lat query number true "緯度"
+// @Param        lng query number true "経度"
+// @Param        radius query int false "半径（メートル、省略時は1000、最大50000）"
+// @Security     BearerAuth
+// @Success      200 {object} NearbyTasksResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/nearby [get]
+func (c *LocationController) ListNearbyTasks(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	latitude, latErr := strconv.ParseFloat(ctx.Query("lat"), 64)
+	longitude, lngErr := strconv.ParseFloat(ctx.Query("lng"), 64)
+	if latErr != nil || lngErr != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "lat and lng are required",
+		})
+		return
+	}
+	radius := 0
+	if raw := ctx.Query("radius"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "radius must be a positive integer",
+			})
+			return
+		}
+		radius = parsed
+	}
+
+	nearby, err := c.locationService.ListNearbyTasks(ctx, userID, usecase.NearbyQuery{
+		Latitude:     latitude,
+		Longitude:    longitude,
+		RadiusMeters: radius,
+	})
+	if err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	items := make([]NearbyTaskItem, 0, len(nearby))
+	for _, n := range nearby {
+		items = append(items, NearbyTaskItem{
+			Task:           taskToResponse(n.Task),
+			Location:       n.Location,
+			DistanceMeters: n.DistanceMeters,
+		})
+	}
+	ctx.JSON(http.StatusOK, NearbyTasksResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// RegisterGeofence ジオフェンスの登録
+// @Summary      ジオフェンスの登録
+// @Description  タスクの場所の周囲にジオフェンスを登録します（半径100〜5000m、1ユーザーあたり20件まで、同じタスクには1つ）。モバイル端末は GET /geofences で取得した領域を監視し、範囲に入ったら POST /geofences/{id}/enter で報告します。タスクに場所が設定されている必要があります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        request body GeofenceRequest false "ジオフェンスの半径"
+// @Security     BearerAuth
+// @Success      201 {object} GeofenceResponse "登録成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効、または登録数の上限に達している"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない、または場所が設定されていない"
+// @Failure      409 {object} ErrorResponse "タスクにジオフェンスを登録済み"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/geofences [post]
+func (c *LocationController) RegisterGeofence(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req GeofenceRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	geofence, err := c.locationService.RegisterGeofence(ctx, ctx.Param("id"), userID, req.RadiusMeters)
+	if err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, GeofenceResponse{
+		Success: true,
+		Data:    *geofence,
+	})
+}
+
+// ListGeofences ジオフェンス一覧
+// @Summary      ジオフェンス一覧
+// @Description  自分が登録したジオフェンスをタスクの場所とともに登録した順に取得します（端末で監視する領域の同期用）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} GeofenceListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /geofences [get]
+func (c *LocationController) ListGeofences(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	geofences, err := c.locationService.ListGeofences(ctx, userID)
+	if err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GeofenceListResponse{
+		Success: true,
+		Data:    geofences,
+	})
+}
+
+// DeleteGeofence ジオフェンスの削除
+// @Summary      ジオフェンスの削除
+// @Description  自分が登録したジオフェンスを削除します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ジオフェンスID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "ジオフェンスが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /geofences/{id} [delete]
+func (c *LocationController) DeleteGeofence(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.locationService.DeleteGeofence(ctx, ctx.Param("id"), userID); err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Geofence deleted successfully",
+	})
+}
+
+// ReportGeofenceEnter ジオフェンスに入ったことの報告
+// @Summary      ジオフェンスに入ったことの報告
+// @Description  モバイル端末がジオフェンスに入ったことを報告し、タスクのリマインダーを通知します。緯度・経度を指定した場合は範囲内（誤差100mを含む）かを確認します。完了済みのタスク・前回のリマインダーから1時間以内・範囲外の場合は通知せず triggered が false になります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ジオフェンスID"
+// @Param        request body GeofenceEnterRequest false "端末の位置"
+// @Security     BearerAuth
+// @Success      200 {object} GeofenceEnterResponse "報告成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを参照する権限がない"
+// @Failure      404 {object} ErrorResponse "ジオフェンスが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /geofences/{id}/enter [post]
+func (c *LocationController) ReportGeofenceEnter(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req GeofenceEnterRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	triggered, err := c.locationService.ReportGeofenceEnter(ctx, ctx.Param("id"), userID, usecase.GeofenceEnterInput{
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	}, time.Now())
+	if err != nil {
+		handleLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GeofenceEnterResponse{
+		Success:   true,
+		Triggered: triggered,
+	})
+}
+
+// handleLocationError は場所・ジオフェンスのエラーをHTTPレスポンスに変換する（それ以外はhandleServiceErrorに委ねる）
+func handleLocationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrLocationNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Task location not found",
+		})
+	case errors.Is(err, usecase.ErrGeofenceNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Geofence not found",
+		})
+	case errors.Is(err, usecase.ErrGeofenceExists):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Geofence already registered for this task",
+		})
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+	default:
+		handleServiceError(ctx, err)
+	}
+}
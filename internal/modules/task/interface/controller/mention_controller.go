@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/markdown"
+)
+
+// MentionController はコメント・メンションのHTTPリクエストを処理するコントローラー
+type MentionController struct {
+	mentionService *usecase.MentionService
+}
+
+// NewMentionController は新しいMentionControllerを作成する
+func NewMentionController(mentionService *usecase.MentionService) *MentionController {
+	return &MentionController{
+		mentionService: mentionService,
+	}
+}
+
+// CommentRequest はコメント投稿リクエスト
+type CommentRequest struct {
+	Comment string `json:"comment" binding:"required,max=5000" example:"@alice レビューをお願いします"`
+} // @name CommentRequest
+
+// CommentCreateResponse はコメント投稿レスポンス
+type CommentCreateResponse struct {
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message" example:"Comment added successfully"`
+	Data    struct {
+		Comment  domain.TaskComment `json:"comment"`
+		Mentions []domain.Mention   `json:"mentions"`
+	} `json:"data"`
+} // @name CommentCreateResponse
+
+// CommentResponse はコメントのレスポンス
+type CommentResponse struct {
+	domain.TaskComment
+	// format=html を指定した場合のみ設定する
+	CommentHTML *string `json:"comment_html,omitempty" example:"<p><strong>確認</strong>しました</p>"`
+	// コメント中のリンクの取得済みのプレビュー
+	LinkPreviews []*domain.LinkPreview `json:"link_previews,omitempty"`
+} // @name CommentResponse
+
+// CommentListResponse はコメント一覧レスポンス
+type CommentListResponse struct {
+	Success bool `json:"success" example:"true"`
+	Data    struct {
+		Comments   []CommentResponse `json:"comments"`
+		TotalCount int               `json:"total_count" example:"5"`
+		Page       int               `json:"page" example:"1"`
+		PageSize   int               `json:"page_size" example:"10"`
+	} `json:"data"`
+} // @name CommentListResponse
+
+// MentionListResponse はメンション一覧レスポンス
+type MentionListResponse struct {
+	Success bool `json:"success" example:"true"`
+	Data    struct {
+		Mentions   []domain.Mention `json:"mentions"`
+		TotalCount int              `json:"total_count" example:"5"`
+		Page       int              `json:"page" example:"1"`
+		PageSize   int              `json:"page_size" example:"10"`
+	} `json:"data"`
+} // @name MentionListResponse
+
+// AddComment コメント投稿
+// @Summary      コメント投稿
+// @Description  タスクにコメントを投稿します。本文中の@usernameは、友達または同じグループのメンバーであればメンションとして記録され通知されます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body CommentRequest true "コメント"
+// @Security     BearerAuth
+// @Success      201 {object} CommentCreateResponse "投稿成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/comments [post]
+func (c *MentionController) AddComment(ctx *gin.Context) {
+	var req CommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	comment, mentions, err := c.mentionService.AddComment(ctx, ctx.Param("id"), userID, req.Comment)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Comment added successfully",
+		"data": gin.H{
+			"comment":  comment,
+			"mentions": mentions,
+		},
+	})
+}
+
+// ListComments コメント一覧取得
+// @Summary      コメント一覧取得
+// @Description  タスクのコメントを投稿順に取得します。format=html を指定すると、Markdownのコメントを安全なHTMLに変換した comment_html を追加します。コメント中のリンクは取得済みのプレビューを link_previews で返します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        page query int false "ページ番号" default(1) minimum(1)
+// @Param        page_size query int false "ページサイズ" default(10) minimum(1) maximum(100)
+// @Param        format query string false "コメントの形式（html で comment_html を追加）" Enums(markdown, html)
+// @Security     BearerAuth
+// @Success      200 {object} CommentListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを閲覧する権限なし"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/comments [get]
+func (c *MentionController) ListComments(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	pagination := parsePagination(ctx)
+	asHTML, ok := parseTextFormat(ctx)
+	if !ok {
+		return
+	}
+
+	comments, total, err := c.mentionService.ListComments(ctx, ctx.Param("id"), userID, pagination)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"comments":    c.commentsToResponse(ctx, comments, asHTML),
+			"total_count": total,
+			"page":        pagination.Page,
+			"page_size":   pagination.PageSize,
+		},
+	})
+}
+
+// ListMyMentions 自分へのメンション一覧取得
+// @Summary      自分へのメンション一覧取得
+// @Description  タスクの説明・コメントで自分がメンションされた記録を新しい順に取得します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "ページ番号" default(1) minimum(1)
+// @Param        page_size query int false "ページサイズ" default(10) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} MentionListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/mentions [get]
+func (c *MentionController) ListMyMentions(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	pagination := parsePagination(ctx)
+
+	mentions, total, err := c.mentionService.ListMentions(ctx, userID, pagination)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"mentions":    mentions,
+			"total_count": total,
+			"page":        pagination.Page,
+			"page_size":   pagination.PageSize,
+		},
+	})
+}
+
+// commentsToResponse はコメント一覧をリンクのプレビューを含むレスポンス形式に変換する（asHTMLの場合はHTMLに変換した本文を追加する）
+func (c *MentionController) commentsToResponse(ctx *gin.Context, comments []*domain.TaskComment, asHTML bool) []CommentResponse {
+	responses := make([]CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		response := CommentResponse{
+			TaskComment:  *comment,
+			LinkPreviews: c.mentionService.GetCommentLinkPreviews(ctx, comment),
+		}
+		if asHTML {
+			rendered := markdown.RenderHTML(comment.Comment)
+			response.CommentHTML = &rendered
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
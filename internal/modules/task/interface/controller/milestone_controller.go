@@ -0,0 +1,401 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// MilestoneController はプロジェクトグループのマイルストーンのHTTPリクエストを処理するコントローラー
+type MilestoneController struct {
+	milestoneService *usecase.MilestoneService
+}
+
+// NewMilestoneController は新しいMilestoneControllerを作成する
+func NewMilestoneController(milestoneService *usecase.MilestoneService) *MilestoneController {
+	return &MilestoneController{
+		milestoneService: milestoneService,
+	}
+}
+
+// MilestoneRequest はマイルストーン作成/更新リクエスト
+type MilestoneRequest struct {
+	GroupID string    `json:"group_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name    string    `json:"name" binding:"required,max=100" example:"ベータ版リリース"`
+	DueDate time.Time `json:"due_date" binding:"required" format:"date-time" example:"2024-12-31T23:59:59Z"`
+} // @name MilestoneRequest
+
+// MilestoneOrderRequest はマイルストーン並べ替えリクエスト
+type MilestoneOrderRequest struct {
+	GroupID      string   `json:"group_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	MilestoneIDs []string `json:"milestone_ids" binding:"required"`
+} // @name MilestoneOrderRequest
+
+// TaskMilestoneRequest はタスクのマイルストーン設定リクエスト（milestone_idがnullの場合は解除）
+type TaskMilestoneRequest struct {
+	MilestoneID *string `json:"milestone_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+} // @name TaskMilestoneRequest
+
+// MilestoneResponse はマイルストーンレスポンス
+type MilestoneResponse struct {
+	ID        string                   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupID   string                   `json:"group_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string                   `json:"name" example:"ベータ版リリース"`
+	DueDate   time.Time                `json:"due_date" example:"2024-12-31T23:59:59Z"`
+	Position  int                      `json:"position" example:"0"`
+	Progress  domain.MilestoneProgress `json:"progress"`
+	CreatedBy string                   `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt time.Time                `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt time.Time                `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name MilestoneResponse
+
+// MilestoneBurndownResponse はマイルストーンのバーンダウンのレスポンス
+type MilestoneBurndownResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Data    domain.MilestoneBurndown `json:"data"`
+} // @name MilestoneBurndownResponse
+
+// ListMilestones マイルストーン一覧
+// @Summary      マイルストーン一覧
+// @Description  プロジェクトグループのマイルストーンを並び順で進捗（完了タスク数/タスク数）とともに取得します（グループのメンバーのみ）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        group_id query string true "グループID"
+// @Security     BearerAuth
+// @Success      200 {array} MilestoneResponse "マイルストーン一覧取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/milestones [get]
+func (c *MilestoneController) ListMilestones(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	milestones, err := c.milestoneService.ListMilestones(ctx, userID, ctx.Query("group_id"))
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    milestonesToResponse(milestones),
+	})
+}
+
+// CreateMilestone マイルストーン作成
+// @Summary      マイルストーン作成
+// @Description  プロジェクトグループにマイルストーンを作成します（グループ編集の権限が必要、並び順は最後）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body MilestoneRequest true "マイルストーン情報"
+// @Security     BearerAuth
+// @Success      201 {object} MilestoneResponse "マイルストーン作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/milestones [post]
+func (c *MilestoneController) CreateMilestone(ctx *gin.Context) {
+	userID, input, ok := c.bindMilestoneRequest(ctx)
+	if !ok {
+		return
+	}
+
+	milestone, err := c.milestoneService.CreateMilestone(ctx, userID, input)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Milestone created successfully",
+		"data":    milestoneToResponse(milestone),
+	})
+}
+
+// GetMilestone マイルストーン取得
+// @Summary      マイルストーン取得
+// @Description  マイルストーンを進捗とともに取得します（グループのメンバーのみ）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        milestone_id path string true "マイルストーンID"
+// @Security     BearerAuth
+// @Success      200 {object} MilestoneResponse "マイルストーン取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "マイルストーンが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/milestones/{milestone_id} [get]
+func (c *MilestoneController) GetMilestone(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	milestone, err := c.milestoneService.GetMilestone(ctx, userID, ctx.Param("milestone_id"))
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    milestoneToResponse(milestone),
+	})
+}
+
+// UpdateMilestone マイルストーン更新
+// @Summary      マイルストーン更新
+// @Description  マイルストーンの名前・期限を更新します（グループ編集の権限が必要、group_idは無視されます）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        milestone_id path string true "マイルストーンID"
+// @Param        request body MilestoneRequest true "マイルストーン情報"
+// @Security     BearerAuth
+// @Success      200 {object} MilestoneResponse "マイルストーン更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "マイルストーンが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/milestones/{milestone_id} [put]
+func (c *MilestoneController) UpdateMilestone(ctx *gin.Context) {
+	userID, input, ok := c.bindMilestoneRequest(ctx)
+	if !ok {
+		return
+	}
+
+	milestone, err := c.milestoneService.UpdateMilestone(ctx, userID, ctx.Param("milestone_id"), input)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Milestone updated successfully",
+		"data":    milestoneToResponse(milestone),
+	})
+}
+
+// DeleteMilestone マイルストーン削除
+// @Summary      マイルストーン削除
+// @Description  マイルストーンを削除します（グループ編集の権限が必要、紐付いていたタスクは削除されません）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        milestone_id path string true "マイルストーンID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "マイルストーン削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "マイルストーンが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/milestones/{milestone_id} [delete]
+func (c *MilestoneController) DeleteMilestone(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.milestoneService.DeleteMilestone(ctx, userID, ctx.Param("milestone_id")); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Milestone deleted successfully",
+	})
+}
+
+// ReorderMilestones マイルストーン並べ替え
+// @Summary      マイルストーン並べ替え
+// @Description  グループの全てのマイルストーンIDを新しい順序で指定して並べ替えます（グループ編集の権限が必要）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body MilestoneOrderRequest true "並び順"
+// @Security     BearerAuth
+// @Success      200 {array} MilestoneResponse "並べ替え成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/milestones/order [put]
+func (c *MilestoneController) ReorderMilestones(ctx *gin.Context) {
+	var req MilestoneOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	milestones, err := c.milestoneService.ReorderMilestones(ctx, userID, req.GroupID, req.MilestoneIDs)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Milestones reordered successfully",
+		"data":    milestonesToResponse(milestones),
+	})
+}
+
+// SetTaskMilestone タスクのマイルストーン設定
+// @Summary      タスクのマイルストーン設定
+// @Description  グループタスクをそのグループのマイルストーンに紐付けます。milestone_idにnullを指定すると解除します（グループでタスク編集の権限が必要）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        request body TaskMilestoneRequest true "マイルストーンID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "設定成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクまたはマイルストーンが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/milestone [put]
+func (c *MilestoneController) SetTaskMilestone(ctx *gin.Context) {
+	var req TaskMilestoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.milestoneService.SetTaskMilestone(ctx, userID, ctx.Param("id"), req.MilestoneID); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Task milestone updated successfully",
+	})
+}
+
+// GetMilestoneBurndown マイルストーンのバーンダウン取得
+// @Summary      マイルストーンのバーンダウン取得
+// @Description  マイルストーンの作成日から期限日（期限を過ぎている場合は今日）までの日ごとの未完了タスク数と理想線を取得します（グループのメンバーのみ）
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Param        milestone_id path string true "マイルストーンID"
+// @Security     BearerAuth
+// @Success      200 {object} MilestoneBurndownResponse "バーンダウン取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "マイルストーンが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/milestones/{milestone_id}/burndown [get]
+func (c *MilestoneController) GetMilestoneBurndown(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	burndown, err := c.milestoneService.GetMilestoneBurndown(ctx, userID, ctx.Param("milestone_id"), time.Now())
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, MilestoneBurndownResponse{
+		Success: true,
+		Data:    *burndown,
+	})
+}
+
+// bindMilestoneRequest はリクエストを検証してサービス入力に変換する
+func (c *MilestoneController) bindMilestoneRequest(ctx *gin.Context) (string, usecase.MilestoneInput, bool) {
+	var req MilestoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return "", usecase.MilestoneInput{}, false
+	}
+
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return "", usecase.MilestoneInput{}, false
+	}
+
+	return userID, usecase.MilestoneInput{
+		GroupID: req.GroupID,
+		Name:    req.Name,
+		DueDate: req.DueDate,
+	}, true
+}
+
+// requireUserID は認証済みユーザーIDを取得する（取得できない場合は401を返す）
+func requireUserID(ctx *gin.Context) (string, bool) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// milestoneToResponse はマイルストーンからレスポンスモデルに変換する
+func milestoneToResponse(milestone *usecase.MilestoneWithProgress) MilestoneResponse {
+	return MilestoneResponse{
+		ID:        milestone.ID,
+		GroupID:   milestone.GroupID,
+		Name:      milestone.Name,
+		DueDate:   milestone.DueDate,
+		Position:  milestone.Position,
+		Progress:  milestone.Progress,
+		CreatedBy: milestone.CreatedBy,
+		CreatedAt: milestone.CreatedAt,
+		UpdatedAt: milestone.UpdatedAt,
+	}
+}
+
+func milestonesToResponse(milestones []*usecase.MilestoneWithProgress) []MilestoneResponse {
+	responses := make([]MilestoneResponse, 0, len(milestones))
+	for _, milestone := range milestones {
+		responses = append(responses, milestoneToResponse(milestone))
+	}
+	return responses
+}
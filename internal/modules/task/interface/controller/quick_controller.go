@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// maxQuickTextBytes はショートカットから追加するタスクの本文の上限（バイト）
+const maxQuickTextBytes = 1024
+
+// quickErrorMessages はショートカットに返すステータスごとのエラーの文言
+// 音声アシスタントがそのまま読み上げられるように、短い平易な文にする
+var quickErrorMessages = map[int]string{
+	http.StatusBadRequest:            "内容を読み取れませんでした。",
+	http.StatusUnauthorized:          "キーが無効です。アプリでキーを確認してください。",
+	http.StatusForbidden:             "この操作はできません。",
+	http.StatusRequestEntityTooLarge: "内容が長すぎます。",
+	http.StatusTooManyRequests:       "リクエストが多すぎます。少し待ってからお試しください。",
+}
+
+// QuickController は音声アシスタント・ショートカット向けの最小限のAPIのHTTPリクエストを処理するコントローラー
+// リクエスト・レスポンスはどちらもプレーンテキスト
+type QuickController struct {
+	taskService  usecase.TaskService
+	todayService *usecase.TodayService
+}
+
+// NewQuickController は新しいQuickControllerを作成する
+func NewQuickController(taskService usecase.TaskService, todayService *usecase.TodayService) *QuickController {
+	return &QuickController{
+		taskService:  taskService,
+		todayService: todayService,
+	}
+}
+
+// AbortQuickError はエラーをショートカット向けの短いプレーンテキストで返してリクエストを中断する
+// （APIキーの認証・レート制限のミドルウェアのエラーにも使う。messageは使わない）
+func AbortQuickError(ctx *gin.Context, status int, _ string) {
+	message, ok := quickErrorMessages[status]
+	if !ok {
+		message = "エラーが発生しました。"
+	}
+	abortQuickText(ctx, status, message)
+}
+
+// abortQuickText はプレーンテキストのエラーを返してリクエストを中断する
+func abortQuickText(ctx *gin.Context, status int, message string) {
+	ctx.Data(status, "text/plain; charset=utf-8", []byte(message))
+	ctx.Abort()
+}
+
+// AddTask ショートカットからのタスクの追加
+// @Summary      ショートカットからのタスクの追加
+// @Description  音声アシスタント・ショートカット向けに、プレーンテキストの本文をクイック追加と同じ書式（「明日15時 資料作成 #仕事 !高」など）で解析してタスクを作成し、読み上げ用の短い文を返します。quickスコープのAPIキー（/auth/shortcut-keys で発行）で認証し、キーごとにレート制限します。エラーも短いプレーンテキストで返します
+// @Tags         quick
+// @Accept       plain
+// @Produce      plain
+// @Param        request body string true "タスクの内容" example:"明日15時 資料作成"
+// @Param        timezone query string false "相対日付のタイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      201 {string} string "「資料作成」を追加しました。期限は6月4日 15:00です。"
+// @Failure      400 {string} string "内容を読み取れませんでした。"
+// @Failure      401 {string} string "キーが無効です。アプリでキーを確認してください。"
+// @Failure      413 {string} string "内容が長すぎます。"
+// @Failure      429 {string} string "リクエストが多すぎます。少し待ってからお試しください。（タスクの上限に達した場合も429）"
+// @Failure      500 {string} string "エラーが発生しました。"
+// @Router       /quick/task [post]
+func (c *QuickController) AddTask(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		AbortQuickError(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxQuickTextBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			AbortQuickError(ctx, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		AbortQuickError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		AbortQuickError(ctx, http.StatusBadRequest, "empty text")
+		return
+	}
+
+	loc, err := c.todayService.Location(ctx, userID, ctx.Query("timezone"))
+	if err != nil {
+		abortQuickServiceError(ctx, err)
+		return
+	}
+
+	task, _, err := c.taskService.QuickAddTask(ctx, text, userID, time.Now().In(loc))
+	if err != nil {
+		abortQuickServiceError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusCreated, "text/plain; charset=utf-8", []byte(domain.QuickAddReply(task, loc)))
+}
+
+// GetToday ショートカット向けの今日の予定
+// @Summary      ショートカット向けの今日の予定
+// @Description  音声アシスタント・ショートカット向けに、今日が期限・期限切れ・今日着手予定の未完了のタスクと未読通知数を読み上げ用の短いプレーンテキストで返します（タスクは5件まで）。quickスコープのAPIキーで認証し、キーごとにレート制限します
+// @Tags         quick
+// @Produce      plain
+// @Param        timezone query string false "タイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {string} string "今日が期限のタスクが1件あります。\n・資料作成（18:00まで）"
+// @Failure      400 {string} string "内容を読み取れませんでした。"
+// @Failure      401 {string} string "キーが無効です。アプリでキーを確認してください。"
+// @Failure      429 {string} string "リクエストが多すぎます。少し待ってからお試しください。"
+// @Failure      500 {string} string "エラーが発生しました。"
+// @Router       /quick/today [get]
+func (c *QuickController) GetToday(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		AbortQuickError(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	today, err := c.todayService.GetToday(ctx, userID, ctx.Query("timezone"))
+	if err != nil {
+		abortQuickServiceError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(today.Summary()))
+}
+
+// abortQuickServiceError はサービスのエラーをショートカット向けのステータスに変換して返す
+func abortQuickServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		AbortQuickError(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		AbortQuickError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, commonDomain.ErrQuotaExceeded):
+		abortQuickText(ctx, http.StatusTooManyRequests, "タスクの上限に達したため追加できません。")
+	default:
+		AbortQuickError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/report"
+)
+
+// WeeklyReportController は週次レポートのHTTPリクエストを処理するコントローラー
+type WeeklyReportController struct {
+	reportService *usecase.WeeklyReportService
+}
+
+// NewWeeklyReportController は新しいWeeklyReportControllerを作成する
+func NewWeeklyReportController(reportService *usecase.WeeklyReportService) *WeeklyReportController {
+	return &WeeklyReportController{
+		reportService: reportService,
+	}
+}
+
+// WeeklyReportResponse は週次レポートのレスポンス
+type WeeklyReportResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.WeeklyReport `json:"data"`
+} // @name WeeklyReportResponse
+
+// WeeklyReportSubscriptionRequest は週次レポートの配信購読リクエスト
+type WeeklyReportSubscriptionRequest struct {
+	Timezone string `json:"timezone" example:"Asia/Tokyo"` // 省略時は勤務時間設定のタイムゾーン
+} // @name WeeklyReportSubscriptionRequest
+
+// WeeklyReportSubscriptionResponse は週次レポートの配信購読のレスポンス
+type WeeklyReportSubscriptionResponse struct {
+	Success bool                            `json:"success" example:"true"`
+	Data    domain.WeeklyReportSubscription `json:"data"`
+} // @name WeeklyReportSubscriptionResponse
+
+// GetWeeklyReport 週次レポート取得
+// @Summary      週次レポート取得
+// @Description  指定したISO週（月曜日〜日曜日）の完了したタスク、遅れたタスク、作業時間、よく取り組んだカテゴリをまとめたレポートを取得します。formatでJSON・HTML・PDFを選べます（PDFはダウンロード用）。週の区切りはtimezone、省略時は勤務時間設定のタイムゾーンです
+// @Tags         stats
+// @Produce      json
+// @Produce      html
+// @Produce      application/pdf
+// @Param        week path string true "ISO週 (YYYY-Www)" example:"2024-W23"
+// @Param        format query string false "出力形式（省略時は json）" Enums(json, html, pdf)
+// @Param        timezone query string false "タイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {object} WeeklyReportResponse "取得成功（format=html・pdf の場合はレポートのHTML・PDF）"
+// @Failure      400 {object} ErrorResponse "週の形式が不正、または未来の週"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/reports/weekly/{week} [get]
+func (c *WeeklyReportController) GetWeeklyReport(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "html" && format != "pdf" {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid format. Use json, html or pdf",
+		})
+		return
+	}
+
+	weekly, err := c.reportService.GenerateWeeklyReport(ctx, userID, ctx.Param("week"), ctx.Query("timezone"), time.Now())
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-cache")
+	switch format {
+	case "html":
+		html, err := report.HTML(weekly)
+		if err != nil {
+			handleServiceError(ctx, err)
+			return
+		}
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", html)
+	case "pdf":
+		filename := fmt.Sprintf("weekly-report-%s.pdf", weekly.Week)
+		ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		ctx.Data(http.StatusOK, "application/pdf", report.PDF(weekly))
+	default:
+		ctx.JSON(http.StatusOK, WeeklyReportResponse{
+			Success: true,
+			Data:    *weekly,
+		})
+	}
+}
+
+// GetSubscription 週次レポートの配信購読取得
+// @Summary      週次レポートの配信購読取得
+// @Description  週次レポートのメール配信の購読状況を取得します
+// @Tags         stats
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} WeeklyReportSubscriptionResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "購読していない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/reports/subscription [get]
+func (c *WeeklyReportController) GetSubscription(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	subscription, err := c.reportService.GetSubscription(ctx, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, WeeklyReportSubscriptionResponse{
+		Success: true,
+		Data:    *subscription,
+	})
+}
+
+// Subscribe 週次レポートの配信購読
+// @Summary      週次レポートの配信購読
+// @Description  毎週月曜日8時（timezone）以降に前週のレポートをメールで受け取ります。メールにはPDFが添付されます。購読済みの場合はタイムゾーンを更新します
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Param        request body WeeklyReportSubscriptionRequest false "配信のタイムゾーン"
+// @Security     BearerAuth
+// @Success      200 {object} WeeklyReportSubscriptionResponse "購読成功"
+// @Failure      400 {object} ErrorResponse "タイムゾーンが不正"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/reports/subscription [put]
+func (c *WeeklyReportController) Subscribe(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req WeeklyReportSubscriptionRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	subscription, err := c.reportService.Subscribe(ctx, userID, req.Timezone)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, WeeklyReportSubscriptionResponse{
+		Success: true,
+		Data:    *subscription,
+	})
+}
+
+// Unsubscribe 週次レポートの配信解除
+// @Summary      週次レポートの配信解除
+// @Description  週次レポートのメール配信を解除します
+// @Tags         stats
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "解除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "購読していない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/reports/subscription [delete]
+func (c *WeeklyReportController) Unsubscribe(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.reportService.Unsubscribe(ctx, userID); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Unsubscribed from weekly reports",
+	})
+}
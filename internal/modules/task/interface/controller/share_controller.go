@@ -0,0 +1,398 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// 共有リンクのパスワードを渡すヘッダー（URLに残さないためクエリでは受け付けない）
+const sharePasswordHeader = "X-Share-Password"
+
+// ShareController はタスク共有リンクのHTTPリクエストを処理するコントローラー
+type ShareController struct {
+	shareService *usecase.ShareService
+}
+
+// NewShareController は新しいShareControllerを作成する
+func NewShareController(shareService *usecase.ShareService) *ShareController {
+	return &ShareController{
+		shareService: shareService,
+	}
+}
+
+// ShareLinkRequest は単一タスクの共有リンク作成リクエスト
+type ShareLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at" format:"date-time" example:"2024-12-31T23:59:59Z"`
+	Password  string     `json:"password" example:"secret123"`
+} // @name ShareLinkRequest
+
+// TaskListShareLinkRequest はタスク一覧の共有リンク作成リクエスト
+type TaskListShareLinkRequest struct {
+	ExpiresAt    *time.Time `json:"expires_at" format:"date-time" example:"2024-12-31T23:59:59Z"`
+	Password     string     `json:"password" example:"secret123"`
+	Status       string     `json:"status" binding:"omitempty,oneof=TODO IN_PROGRESS DONE" example:"TODO"`
+	Priority     string     `json:"priority" binding:"omitempty,oneof=LOW MEDIUM HIGH" example:"HIGH"`
+	Category     string     `json:"category" binding:"omitempty,oneof=WORK PERSONAL STUDY HEALTH SHOPPING OTHER" example:"WORK"`
+	AssignedToMe bool       `json:"assigned_to_me" example:"false"`
+	DueDateFrom  *time.Time `json:"due_date_from" format:"date-time" example:"2024-01-01T00:00:00Z"`
+	DueDateTo    *time.Time `json:"due_date_to" format:"date-time" example:"2024-12-31T23:59:59Z"`
+} // @name TaskListShareLinkRequest
+
+// ShareLinkResponse は共有リンクのレスポンス
+type ShareLinkResponse struct {
+	ID          string             `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TargetType  string             `json:"target_type" example:"TASK"`
+	TaskID      *string            `json:"task_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Filter      *domain.ListFilter `json:"filter,omitempty"`
+	Token       string             `json:"token" example:"q2Xb1m8rQ0c4fX1sX9w2g3yM3w0s8nT1Q6o5vK7a9dE"`
+	Path        string             `json:"path" example:"/api/v1/public/shares/q2Xb1m8rQ0c4fX1sX9w2g3yM3w0s8nT1Q6o5vK7a9dE"`
+	ShortURL    string             `json:"short_url,omitempty" example:"https://yt.plus/s/aB3dE5f"` // 短縮URL（設定されている場合・閲覧可能な場合のみ）
+	HasPassword bool               `json:"has_password" example:"true"`
+	Active      bool               `json:"active" example:"true"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	RevokedAt   *time.Time         `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" example:"2024-01-01T00:00:00Z"`
+} // @name ShareLinkResponse
+
+// ShareLinkCreateResponse は共有リンク作成レスポンス
+type ShareLinkCreateResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Share link created successfully"`
+	Data    ShareLinkResponse `json:"data"`
+} // @name ShareLinkCreateResponse
+
+// ShareLinkListResponse は共有リンク一覧レスポンス
+type ShareLinkListResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    []ShareLinkResponse `json:"data"`
+} // @name ShareLinkListResponse
+
+// PublicTaskResponse は公開リンクで表示するタスク（ユーザーIDや工数などの非公開項目は含まない）
+type PublicTaskResponse struct {
+	Title              string     `json:"title" example:"重要なタスク"`
+	Description        string     `json:"description" example:"タスクの詳細説明"`
+	Status             string     `json:"status" example:"TODO"`
+	Priority           string     `json:"priority" example:"HIGH"`
+	Category           string     `json:"category" example:"WORK"`
+	DueDate            *time.Time `json:"due_date,omitempty" example:"2024-12-31T23:59:59Z"`
+	IsOverdue          bool       `json:"is_overdue" example:"false"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty" example:"2024-01-02T18:00:00Z"`
+	AssigneeCount      int        `json:"assignee_count" example:"2"`
+	CompletedAssignees int        `json:"completed_assignees" example:"1"`
+	UpdatedAt          time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+} // @name PublicTaskResponse
+
+// PublicShareResponse は公開リンクの閲覧レスポンス（target_typeに応じてtaskまたはtasksを返す）
+type PublicShareResponse struct {
+	Success bool `json:"success" example:"true"`
+	Data    struct {
+		TargetType string               `json:"target_type" example:"TASK"`
+		Task       *PublicTaskResponse  `json:"task,omitempty"`
+		Tasks      []PublicTaskResponse `json:"tasks,omitempty"`
+		TotalCount int                  `json:"total_count,omitempty" example:"5"`
+		Page       int                  `json:"page,omitempty" example:"1"`
+		PageSize   int                  `json:"page_size,omitempty" example:"10"`
+		ExpiresAt  *time.Time           `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	} `json:"data"`
+} // @name PublicShareResponse
+
+// CreateTaskShareLink タスク共有リンク作成
+// @Summary      タスク共有リンク作成
+// @Description  タスクの読み取り専用公開リンクを作成します（作成者・担当者のみ）。有効期限とパスワードを任意で設定できます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body ShareLinkRequest false "共有設定"
+// @Security     BearerAuth
+// @Success      201 {object} ShareLinkCreateResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      402 {object} ErrorResponse "契約プランで共有リンクを利用できない"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/share-links [post]
+func (c *ShareController) CreateTaskShareLink(ctx *gin.Context) {
+	var req ShareLinkRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	link, err := c.shareService.CreateTaskShareLink(ctx, userID, ctx.Param("id"), usecase.ShareLinkInput{
+		ExpiresAt: req.ExpiresAt,
+		Password:  req.Password,
+	})
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Share link created successfully",
+		"data":    c.shareLinkResponse(ctx, link),
+	})
+}
+
+// CreateTaskListShareLink タスク一覧共有リンク作成
+// @Summary      タスク一覧共有リンク作成
+// @Description  条件で絞り込んだタスク一覧の読み取り専用公開リンクを作成します。対象は自分が作成したタスク（assigned_to_meがtrueの場合は自分が担当するタスク）に限られます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body TaskListShareLinkRequest true "共有設定と絞り込み条件"
+// @Security     BearerAuth
+// @Success      201 {object} ShareLinkCreateResponse "作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      402 {object} ErrorResponse "契約プランで共有リンクを利用できない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/share-links [post]
+func (c *ShareController) CreateTaskListShareLink(ctx *gin.Context) {
+	var req TaskListShareLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var filter domain.ListFilter
+	if req.Status != "" {
+		s := domain.TaskStatus(req.Status)
+		filter.Status = &s
+	}
+	if req.Priority != "" {
+		p := domain.Priority(req.Priority)
+		filter.Priority = &p
+	}
+	if req.Category != "" {
+		cat := domain.Category(req.Category)
+		filter.Category = &cat
+	}
+	if req.AssignedToMe {
+		filter.AssigneeID = &userID
+	}
+	filter.DueDateFrom = req.DueDateFrom
+	filter.DueDateTo = req.DueDateTo
+
+	link, err := c.shareService.CreateTaskListShareLink(ctx, userID, filter, usecase.ShareLinkInput{
+		ExpiresAt: req.ExpiresAt,
+		Password:  req.Password,
+	})
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Share link created successfully",
+		"data":    c.shareLinkResponse(ctx, link),
+	})
+}
+
+// ListShareLinks 共有リンク一覧取得
+// @Summary      共有リンク一覧取得
+// @Description  自分が作成した共有リンク（無効化・期限切れを含む）を新しい順に取得します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} ShareLinkListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/share-links [get]
+func (c *ShareController) ListShareLinks(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	links, err := c.shareService.ListShareLinks(ctx, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	responses := make([]ShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = c.shareLinkResponse(ctx, link)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    responses,
+	})
+}
+
+// RevokeShareLink 共有リンク無効化
+// @Summary      共有リンク無効化
+// @Description  自分が作成した共有リンクを無効化します。無効化後はリンクから閲覧できなくなります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        link_id path string true "共有リンクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{} "無効化成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "共有リンクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/share-links/{link_id} [delete]
+func (c *ShareController) RevokeShareLink(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := c.shareService.RevokeShareLink(ctx, userID, ctx.Param("link_id")); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Share link revoked successfully",
+	})
+}
+
+// GetPublicShare 公開リンク閲覧
+// @Summary      公開リンク閲覧
+// @Description  共有リンクのタスクまたはタスク一覧を認証なしで取得します。パスワード付きのリンクはX-Share-Passwordヘッダーでパスワードを指定してください
+// @Tags         public
+// @Accept       json
+// @Produce      json
+// @Param        token path string true "共有トークン"
+// @Param        X-Share-Password header string false "共有リンクのパスワード"
+// @Param        page query int false "ページ番号（一覧の場合）" default(1) minimum(1)
+// @Param        page_size query int false "ページサイズ（一覧の場合）" default(10) minimum(1) maximum(100)
+// @Success      200 {object} PublicShareResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "パスワードが必要"
+// @Failure      403 {object} ErrorResponse "パスワードが違う"
+// @Failure      404 {object} ErrorResponse "共有リンクが見つからない"
+// @Failure      410 {object} ErrorResponse "共有リンクの有効期限切れ"
+// @Failure      429 {object} ErrorResponse "パスワードを続けて間違えたため一時的に閲覧できない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /public/shares/{token} [get]
+func (c *ShareController) GetPublicShare(ctx *gin.Context) {
+	// 共有内容をキャッシュ・検索エンジンに残さない
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("X-Robots-Tag", "noindex, nofollow")
+
+	pagination := parsePagination(ctx)
+
+	content, err := c.shareService.ViewSharedContent(ctx, ctx.Param("token"), ctx.GetHeader(sharePasswordHeader), pagination)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	data := gin.H{
+		"target_type": content.Link.TargetType,
+		"expires_at":  content.Link.ExpiresAt,
+	}
+
+	if content.Task != nil {
+		data["task"] = taskToPublicResponse(content.Task)
+	} else {
+		responses := make([]PublicTaskResponse, len(content.Tasks))
+		for i, task := range content.Tasks {
+			responses[i] = *taskToPublicResponse(task)
+		}
+		data["tasks"] = responses
+		data["total_count"] = content.Total
+		data["page"] = pagination.Page
+		data["page_size"] = pagination.PageSize
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// shareLinkToResponse は共有リンクをレスポンス形式に変換する
+func shareLinkToResponse(link *domain.ShareLink) ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:          link.ID,
+		TargetType:  string(link.TargetType),
+		TaskID:      link.TaskID,
+		Filter:      link.Filter,
+		Token:       link.Token,
+		Path:        link.PublicPath(),
+		HasPassword: link.RequiresPassword(),
+		Active:      link.IsActive(time.Now()),
+		ExpiresAt:   link.ExpiresAt,
+		RevokedAt:   link.RevokedAt,
+		CreatedAt:   link.CreatedAt,
+	}
+}
+
+// shareLinkResponse は共有リンクを短縮URL付きのレスポンス形式に変換する
+func (c *ShareController) shareLinkResponse(ctx *gin.Context, link *domain.ShareLink) ShareLinkResponse {
+	response := shareLinkToResponse(link)
+	response.ShortURL = c.shareService.ShortURL(ctx, link)
+	return response
+}
+
+// taskToPublicResponse はタスクを公開用のレスポンス形式に変換する
+func taskToPublicResponse(task *domain.Task) *PublicTaskResponse {
+	completed, total := task.AssigneeProgress()
+	return &PublicTaskResponse{
+		Title:              task.Title,
+		Description:        task.Description,
+		Status:             string(task.Status),
+		Priority:           string(task.Priority),
+		Category:           string(task.Category),
+		DueDate:            task.DueDate,
+		IsOverdue:          task.CheckIsOverdue(),
+		CompletedAt:        task.CompletedAt,
+		AssigneeCount:      total,
+		CompletedAssignees: completed,
+		UpdatedAt:          task.UpdatedAt,
+	}
+}
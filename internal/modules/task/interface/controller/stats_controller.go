@@ -122,6 +122,24 @@ type ProgressSummaryResponse struct {
 	Data    []DailyStatsData `json:"data"`
 } // @name ProgressSummaryResponse
 
+// VelocityReportResponse はベロシティレポートのレスポンス
+type VelocityReportResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    domain.VelocityReport `json:"data"`
+} // @name VelocityReportResponse
+
+// StatsComparisonResponse は期間比較のレスポンス
+type StatsComparisonResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Data    domain.StatsComparison `json:"data"`
+} // @name StatsComparisonResponse
+
+// StatsSeriesResponse は時系列統計のレスポンス
+type StatsSeriesResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    domain.StatsSeries `json:"data"`
+} // @name StatsSeriesResponse
+
 // ProgressLevelResponse は進捗レベルのレスポンス
 type ProgressLevelResponse struct {
 	Success bool              `json:"success" example:"true"`
@@ -377,6 +395,194 @@ func (c *TaskStatsController) GetProgressSummary(ctx *gin.Context) {
 	})
 }
 
+// GetVelocityReport ベロシティレポート取得
+// @Summary      ベロシティレポート取得
+// @Description  直近の週ごと・カテゴリごとの完了タスク数、完了ポイント、見積もり精度を取得します
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Param        weeks query int false "対象週数（今週を含む）" default(8) minimum(1) maximum(52)
+// @Security     BearerAuth
+// @Success      200 {object} VelocityReportResponse "ベロシティレポート取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/velocity [get]
+func (c *TaskStatsController) GetVelocityReport(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	weeks, err := strconv.Atoi(ctx.DefaultQuery("weeks", "8"))
+	if err != nil || weeks < 1 || weeks > 52 {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid weeks parameter. Must be between 1 and 52",
+		})
+		return
+	}
+
+	report, err := c.statsService.GetVelocityReport(ctx, userID, weeks, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Failed to get velocity report",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, VelocityReportResponse{
+		Success: true,
+		Data:    *report,
+	})
+}
+
+// GetStatsComparison 期間比較取得
+// @Summary      期間比較取得
+// @Description  今日・今週・今月の実績（完了数、作成数、期限切れ数、完了率）を、offset期間前の同じ経過時点までの実績と比較し、差と変化率を取得します。比較対象が0の場合、変化率はnullです
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Param        period query string false "比較の単位" Enums(day, week, month) default(week)
+// @Param        offset query int false "何期間前と比較するか" default(1) minimum(1) maximum(52)
+// @Security     BearerAuth
+// @Success      200 {object} StatsComparisonResponse "期間比較取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/compare [get]
+func (c *TaskStatsController) GetStatsComparison(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	period, err := domain.ParseComparisonPeriod(ctx.Query("period"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid period parameter. Use day, week or month",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "1"))
+	if err != nil || offset < 1 || offset > domain.MaxComparisonOffset {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid offset parameter. Must be between 1 and 52",
+		})
+		return
+	}
+
+	comparison, err := c.statsService.GetStatsComparison(ctx, userID, period, offset, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Failed to get stats comparison",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatsComparisonResponse{
+		Success: true,
+		Data:    *comparison,
+	})
+}
+
+// GetStatsSeries 時系列統計取得
+// @Summary      時系列統計取得
+// @Description  任意の期間の実績（完了数、作成数、期限のあるタスク数、期限切れ数、完了率）を日・週・月ごとに取得します。期間は区間の境界（週は月曜日、月は1日）に広げ、タスクのない区間も0で返します。rollingを指定すると完了数・作成数の移動平均（期間より前の区間も含めた直近rolling区間の平均）を付けます
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Param        from query string true "開始日（YYYY-MM-DD）" example:"2024-01-01"
+// @Param        to query string true "終了日（YYYY-MM-DD、この日を含む）" example:"2024-03-31"
+// @Param        granularity query string false "集計単位" Enums(day, week, month) default(day)
+// @Param        rolling query int false "移動平均の区間数（0は付けない）" default(0) minimum(0) maximum(30)
+// @Param        timezone query string false "区間の区切りに使うタイムゾーン（省略時は UTC）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {object} StatsSeriesResponse "時系列統計取得成功"
+// @Failure      400 {object} ErrorResponse "パラメータが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/stats/series [get]
+func (c *TaskStatsController) GetStatsSeries(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	from, errFrom := time.Parse("2006-01-02", ctx.Query("from"))
+	to, errTo := time.Parse("2006-01-02", ctx.Query("to"))
+	if errFrom != nil || errTo != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid from/to parameter. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	granularity, err := domain.ParseSeriesGranularity(ctx.Query("granularity"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid granularity parameter. Use day, week or month",
+		})
+		return
+	}
+
+	rolling, err := strconv.Atoi(ctx.DefaultQuery("rolling", "0"))
+	if err != nil || rolling < 0 || rolling > domain.MaxSeriesRollingWindow {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Invalid rolling parameter. Must be between 0 and 30",
+		})
+		return
+	}
+
+	series, err := c.statsService.GetStatsSeries(ctx, userID, usecase.StatsSeriesQuery{
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+		Rolling:     rolling,
+		Timezone:    ctx.Query("timezone"),
+	}, time.Now())
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatsSeriesResponse{
+		Success: true,
+		Data:    *series,
+	})
+}
+
 // GetProgressLevel 進捗レベル取得
 // @Summary      進捗レベル取得
 // @Description  完了率に基づく進捗レベル情報を取得します
@@ -10,8 +10,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/task/domain"
 	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/parsing"
+	"github.com/hryt430/Yotei+/pkg/markdown"
 )
 
 // TaskController はタスク関連のHTTPリクエストを処理するコントローラー
@@ -34,7 +38,16 @@ type TaskRequest struct {
 	Priority    string        `json:"priority" binding:"omitempty,oneof=LOW MEDIUM HIGH" example:"HIGH"`
 	Category    string        `json:"category" binding:"omitempty,oneof=WORK PERSONAL STUDY HEALTH SHOPPING OTHER" example:"WORK"`
 	AssigneeID  *string       `json:"assignee_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StartDate   *time.Time `json:"start_date" format:"date-time" example:"2024-12-20T09:00:00Z"`
 	DueDate     *time.Time `json:"due_date" format:"date-time" example:"2024-12-31T23:59:59Z"`
+
+	// 作成時のみ: クライアントが生成したUUIDv7のID。オフライン中に作成したタスクを同期前から参照するために使用し、同じIDで再送すると作成済みのタスクを返す
+	ID *string `json:"id,omitempty" example:"0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"`
+	// 作成時のみ: グループタスクとして作成する。assignee_id を省略するとグループの自動割り当て方式で担当者を割り当てる
+	GroupID        *string `json:"group_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	SkipAutoAssign bool    `json:"skip_auto_assign,omitempty" example:"false"` // 作成時のみ: 自動割り当てせずに担当者なしで作成する
+	// 作成時のみ: タイトルが似ている最近のタスクがあっても作成する
+	Force bool `json:"force,omitempty" example:"false"`
 } // @name TaskRequest
 
 // TaskResponse はタスクレスポンス
@@ -47,10 +60,39 @@ type TaskResponse struct {
 	Category    string     `json:"category" example:"WORK"`
 	AssigneeID  *string    `json:"assignee_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
 	CreatedBy   string     `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StartDate   *time.Time `json:"start_date,omitempty" example:"2024-12-20T09:00:00Z"`
 	DueDate     *time.Time `json:"due_date,omitempty" example:"2024-12-31T23:59:59Z"`
 	IsOverdue   bool       `json:"is_overdue" example:"false"`
 	CreatedAt   time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
 	UpdatedAt   time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+
+	EstimateMinutes *int       `json:"estimate_minutes,omitempty" example:"90"`
+	EstimatePoints  *int       `json:"estimate_points,omitempty" example:"3"`
+	ActualMinutes   *int       `json:"actual_minutes,omitempty" example:"120"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty" example:"2024-01-02T18:00:00Z"`
+
+	Assignees           []*domain.TaskAssignee `json:"assignees"`
+	RequireAllAssignees bool                   `json:"require_all_assignees" example:"false"`
+	CompletedAssignees  int                    `json:"completed_assignees" example:"1"`
+
+	// 説明のMarkdownから抽出したチェックリスト
+	Checklist          []domain.ChecklistItem `json:"checklist,omitempty"`
+	ChecklistCompleted int                    `json:"checklist_completed" example:"2"`
+
+	// format=html を指定した場合のみ設定する
+	DescriptionHTML *string `json:"description_html,omitempty" example:"<p>タスクの<strong>詳細</strong>説明</p>"`
+
+	// 説明中のリンクの取得済みのプレビュー（タスク取得時のみ設定する）
+	LinkPreviews []*domain.LinkPreview `json:"link_previews,omitempty"`
+
+	// グループの予定との関連付け（タスク取得時のみ設定する）
+	EventLinks []*domain.TaskEventLink `json:"event_links,omitempty"`
+
+	// タスクの場所（タスク取得時のみ設定する）
+	Location *domain.Location `json:"location,omitempty"`
+
+	// ユーザーが追加したリンクとGitHub・予定の連携のリンク（タスク取得時のみ設定する）
+	Links []*domain.TaskLink `json:"links,omitempty"`
 } // @name TaskResponse
 
 // TaskCreateResponse はタスク作成レスポンス
@@ -67,6 +109,16 @@ type TaskUpdateResponse struct {
 	Data    TaskResponse `json:"data"`
 } // @name TaskUpdateResponse
 
+// TaskAssignResponse はタスク割り当てレスポンス
+type TaskAssignResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Message string                  `json:"message" example:"Task assigned successfully"`
+	Data    TaskResponse            `json:"data"`
+	Warning *domain.WorkloadWarning `json:"warning,omitempty"`
+
+	Warnings []*domain.WorkloadWarning `json:"warnings,omitempty"`
+} // @name TaskAssignResponse
+
 // TaskGetResponse はタスク取得レスポンス
 type TaskGetResponse struct {
 	Success bool         `json:"success" example:"true"`
@@ -84,17 +136,40 @@ type TaskListResponse struct {
 	} `json:"data"`
 } // @name TaskListResponse
 
+// TaskCollectionResponse はページングなしのタスク一覧レスポンス（検索・期限切れ・担当タスク）
+type TaskCollectionResponse struct {
+	Success bool `json:"success" example:"true"`
+	Data    struct {
+		Tasks []TaskResponse `json:"tasks"`
+		Count int            `json:"count" example:"5"`
+	} `json:"data"`
+} // @name TaskCollectionResponse
+
 // TaskDeleteResponse はタスク削除レスポンス
 type TaskDeleteResponse struct {
 	Success bool   `json:"success" example:"true"`
 	Message string `json:"message" example:"Task deleted successfully"`
 } // @name TaskDeleteResponse
 
+// TaskDeletedResponse はタスク削除レスポンス（取り消し可能な場合は取り消し用のトークンを含む）
+type TaskDeletedResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Task deleted successfully"`
+	Undo    *undo.Token `json:"undo,omitempty"`
+} // @name TaskDeletedResponse
+
 // AssignTaskRequest はタスク割り当てリクエスト
 type AssignTaskRequest struct {
-	AssigneeID string `json:"assignee_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AssigneeID          string   `json:"assignee_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AssigneeIDs         []string `json:"assignee_ids"`
+	RequireAllAssignees *bool    `json:"require_all_assignees" example:"true"`
 } // @name AssignTaskRequest
 
+// AssignmentCompletionRequest は担当者ごとの完了状態変更リクエスト
+type AssignmentCompletionRequest struct {
+	Completed bool `json:"completed" example:"true"`
+} // @name AssignmentCompletionRequest
+
 // ErrorResponse はエラーレスポンス構造体
 type ErrorResponse struct {
 	Success bool   `json:"success" example:"false"`
@@ -107,6 +182,91 @@ type ChangeStatusRequest struct {
 	Status string `json:"status" binding:"required,oneof=TODO IN_PROGRESS DONE" example:"IN_PROGRESS"`
 } // @name ChangeStatusRequest
 
+// TaskEstimateRequest は見積もり・実績更新リクエスト（指定したフィールドのみ更新）
+type TaskEstimateRequest struct {
+	EstimateMinutes *int `json:"estimate_minutes" example:"90"`
+	EstimatePoints  *int `json:"estimate_points" example:"3"`
+	ActualMinutes   *int `json:"actual_minutes" example:"120"`
+} // @name TaskEstimateRequest
+
+// TaskScheduleRequest は着手予定日時・期限の更新リクエスト（指定したフィールドのみ更新）
+type TaskScheduleRequest struct {
+	StartDate      *time.Time `json:"start_date" format:"date-time" example:"2024-12-20T09:00:00Z"`
+	DueDate        *time.Time `json:"due_date" format:"date-time" example:"2024-12-31T23:59:59Z"`
+	ClearStartDate bool       `json:"clear_start_date" example:"false"` // 着手予定日時を消去する
+} // @name TaskScheduleRequest
+
+// QuickAddRequest はクイック追加リクエスト
+type QuickAddRequest struct {
+	Text     string `json:"text" binding:"required,max=500" example:"レポート提出 明日 15時 #work !high"`
+	Timezone string `json:"timezone" example:"Asia/Tokyo"`
+	Preview  bool   `json:"preview" example:"true"`
+} // @name QuickAddRequest
+
+// QuickAddPreviewResponse はクイック追加の解析結果
+type QuickAddPreviewResponse struct {
+	Title    string          `json:"title" example:"レポート提出"`
+	DueDate  *time.Time      `json:"due_date,omitempty" example:"2024-12-02T15:00:00+09:00"`
+	HasTime  bool            `json:"has_time" example:"true"`
+	Category string          `json:"category" example:"WORK"`
+	Priority string          `json:"priority" example:"HIGH"`
+	Tokens   []parsing.Token `json:"tokens"`
+
+	// #タグ・!優先度で指定せず、タイトルから推定したか
+	CategorySuggested bool `json:"category_suggested,omitempty" example:"false"`
+	PrioritySuggested bool `json:"priority_suggested,omitempty" example:"false"`
+} // @name QuickAddPreviewResponse
+
+// QuickAddPreviewResult はクイック追加の解析結果レスポンス（preview=true）
+type QuickAddPreviewResult struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    QuickAddPreviewResponse `json:"data"`
+} // @name QuickAddPreviewResult
+
+// QuickAddTaskResponse はクイック追加のタスク作成レスポンス
+type QuickAddTaskResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Message string                  `json:"message" example:"Task created successfully"`
+	Data    TaskResponse            `json:"data"`
+	Parsed  QuickAddPreviewResponse `json:"parsed"`
+} // @name QuickAddTaskResponse
+
+// ClassifyRequest はカテゴリ・優先度の推定リクエスト
+type ClassifyRequest struct {
+	Title       string `json:"title" binding:"max=200" example:"至急 取引先への見積を送る"`
+	Description string `json:"description" binding:"max=10000" example:"来週の会議までに"`
+} // @name ClassifyRequest
+
+// ClassifyResponse はカテゴリ・優先度の推定レスポンス
+type ClassifyResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    domain.Classification `json:"data"`
+} // @name ClassifyResponse
+
+// DuplicateTaskResponse は重複の可能性のあるタスクがあり作成しなかった場合のレスポンス
+type DuplicateTaskResponse struct {
+	Success    bool                         `json:"success" example:"false"`
+	Error      string                       `json:"error" example:"DUPLICATE_TASK"`
+	Message    string                       `json:"message" example:"Similar tasks already exist"`
+	Duplicates []*domain.DuplicateCandidate `json:"duplicates"`
+} // @name DuplicateTaskResponse
+
+// CheckDuplicateRequest は重複タスクの確認リクエスト
+type CheckDuplicateRequest struct {
+	Title   string  `json:"title" binding:"required" example:"週次レポートを提出する"`
+	GroupID *string `json:"group_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"` // 指定するとグループタスクから探す
+	Days    int     `json:"days,omitempty" binding:"omitempty,min=1,max=90" example:"14"`     // 候補とするタスクの作成日の期間（省略時は14日）
+} // @name CheckDuplicateRequest
+
+// CheckDuplicateResponse は重複タスクの確認レスポンス
+type CheckDuplicateResponse struct {
+	Success bool `json:"success" example:"true"`
+	Data    struct {
+		HasDuplicates bool                         `json:"has_duplicates" example:"true"`
+		Duplicates    []*domain.DuplicateCandidate `json:"duplicates"`
+	} `json:"data"`
+} // @name CheckDuplicateResponse
+
 // FlexibleTime は複数の日付フォーマットに対応するカスタム型
 type FlexibleTime struct {
 	time.Time
@@ -145,6 +305,9 @@ func (ft FlexibleTime) MarshalJSON() ([]byte, error) {
 // CreateTask タスク作成
 // @Summary      タスク作成
 // @Description  新しいタスクを作成します
+// @Description  group_id を指定するとグループタスクとして作成します（グループでタスク作成の権限が必要）。assignee_id を省略した場合はグループの自動割り当て方式で担当者を割り当て、skip_auto_assign で無効にできます
+// @Description  id にクライアントが生成したUUIDv7を指定すると、そのIDでタスクを作成します。同じIDで再送した場合は作成済みのタスクを返します
+// @Description  直近14日間に作成したタスク（group_id 指定時はグループタスク）にタイトルが似ている未完了のタスクがある場合は作成せず、409 で候補を返します。force=true で作成します（id 指定時は確認しません）
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
@@ -153,6 +316,9 @@ func (ft FlexibleTime) MarshalJSON() ([]byte, error) {
 // @Success      201 {object} TaskCreateResponse "タスク作成成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループでタスクを作成する権限なし"
+// @Failure      409 {object} DuplicateTaskResponse "タイトルが似ているタスクがある（指定したIDを別のユーザーが使用している場合は ErrorResponse）"
+// @Failure      429 {object} ErrorResponse "未完了のタスク数が上限に達している"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks [post]
 func (c *TaskController) CreateTask(ctx *gin.Context) {
@@ -193,33 +359,87 @@ func (c *TaskController) CreateTask(ctx *gin.Context) {
 		priority = domain.Priority(req.Priority)
 	}
 
-	// タスク作成
-	task, err := c.taskService.CreateTaskWithDefaults(
-		ctx,
-		req.Title,
-		req.Description,
-		priority,
-		userID,
-	)
-	if err != nil {
-		handleServiceError(ctx, err)
+	startDate, dueDate := nonZeroTime(req.StartDate), nonZeroTime(req.DueDate)
+	if startDate != nil && dueDate != nil && startDate.After(*dueDate) {
+		handleServiceError(ctx, usecase.ErrStartAfterDue)
 		return
 	}
 
-	if req.DueDate != nil && !req.DueDate.IsZero() {
-		dueDate := *req.DueDate
-		_, err = c.taskService.UpdateTask(
+	// 重複の確認（クライアントが生成したIDでの作成は再送・オフラインの同期のため確認しない）
+	if !req.Force && req.ID == nil {
+		input := usecase.DuplicateCheckInput{Title: req.Title, UserID: userID}
+		if req.GroupID != nil {
+			input.GroupID = *req.GroupID
+		}
+		duplicates, err := c.taskService.FindDuplicateTasks(ctx, input)
+		if err != nil {
+			handleServiceError(ctx, err)
+			return
+		}
+		if len(duplicates) > 0 {
+			ctx.JSON(http.StatusConflict, DuplicateTaskResponse{
+				Success:    false,
+				Error:      "DUPLICATE_TASK",
+				Message:    "Similar tasks already exist",
+				Duplicates: duplicates,
+			})
+			return
+		}
+	}
+
+	// タスク作成（グループ指定時はグループタスクとして作成）
+	var task *domain.Task
+	if req.GroupID != nil && *req.GroupID != "" {
+		input := usecase.CreateGroupTaskInput{
+			Title:          req.Title,
+			Description:    req.Description,
+			Priority:       priority,
+			Category:       domain.Category(req.Category),
+			GroupID:        *req.GroupID,
+			CreatedBy:      userID,
+			SkipAutoAssign: req.SkipAutoAssign,
+		}
+		if req.AssigneeID != nil {
+			input.AssigneeID = *req.AssigneeID
+		}
+		if req.ID != nil {
+			input.ID = *req.ID
+		}
+		task, err = c.taskService.CreateGroupTask(ctx, input)
+	} else if req.ID != nil && *req.ID != "" {
+		category := domain.CategoryOther
+		if req.Category != "" {
+			category = domain.Category(req.Category)
+		}
+		task, err = c.taskService.CreateTaskWithID(
+			ctx,
+			*req.ID,
+			req.Title,
+			req.Description,
+			priority,
+			category,
+			userID,
+		)
+	} else {
+		task, err = c.taskService.CreateTaskWithDefaults(
 			ctx,
-			task.ID,
-			nil, nil, nil, nil, // title, description, status, priority は nil
-			&dueDate,
+			req.Title,
+			req.Description,
+			priority,
+			userID,
 		)
+	}
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	if startDate != nil || dueDate != nil {
+		task, err = c.taskService.UpdateTaskSchedule(ctx, task.ID, userID, startDate, dueDate, false)
 		if err != nil {
 			handleServiceError(ctx, err)
 			return
 		}
-		// レスポンス用にタスクの期限日を更新
-		task.DueDate = &dueDate
 	}
 
 	ctx.JSON(http.StatusCreated, gin.H{
@@ -231,11 +451,12 @@ func (c *TaskController) CreateTask(ctx *gin.Context) {
 
 // GetTask タスク取得
 // @Summary      タスク取得
-// @Description  指定されたIDのタスクを取得します
+// @Description  指定されたIDのタスクを取得します。format=html を指定すると、Markdownの説明を安全なHTMLに変換した description_html を追加します。説明中のリンクは取得済みのプレビュー（OpenGraph）を link_previews で返します（未取得のリンクはバックグラウンドで取得します）
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        format query string false "説明の形式（html で description_html を追加）" Enums(markdown, html)
 // @Security     BearerAuth
 // @Success      200 {object} TaskGetResponse "タスク取得成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
@@ -246,15 +467,30 @@ func (c *TaskController) CreateTask(ctx *gin.Context) {
 func (c *TaskController) GetTask(ctx *gin.Context) {
 	taskID := ctx.Param("id")
 
+	asHTML, ok := parseTextFormat(ctx)
+	if !ok {
+		return
+	}
+
 	task, err := c.taskService.GetTask(ctx, taskID)
 	if err != nil {
 		handleServiceError(ctx, err)
 		return
 	}
 
+	response := taskToResponse(task)
+	response.LinkPreviews = c.taskService.GetLinkPreviews(ctx, task)
+	response.EventLinks = c.taskService.GetEventLinks(ctx, task)
+	response.Location = c.taskService.GetLocation(ctx, task)
+	response.Links = c.taskService.GetLinks(ctx, task)
+	if asHTML {
+		rendered := markdown.RenderHTML(task.Description)
+		response.DescriptionHTML = &rendered
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    taskToResponse(task),
+		"data":    response,
 	})
 }
 
@@ -270,11 +506,16 @@ func (c *TaskController) GetTask(ctx *gin.Context) {
 // @Success      200 {object} TaskUpdateResponse "タスク更新成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
 // @Failure      404 {object} ErrorResponse "タスクが見つからない"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks/{id} [put]
 func (c *TaskController) UpdateTask(ctx *gin.Context) {
 	taskID := ctx.Param("id")
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 
 	var req TaskRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -311,9 +552,19 @@ func (c *TaskController) UpdateTask(ctx *gin.Context) {
 		dueDate = req.DueDate
 	}
 
-	task, err := c.taskService.UpdateTask(
+	// 着手予定日時は期限と合わせて検証するため、期限とまとめて先に更新する
+	if startDate := nonZeroTime(req.StartDate); startDate != nil {
+		if _, err := c.taskService.UpdateTaskSchedule(ctx, taskID, userID, startDate, dueDate, false); err != nil {
+			handleServiceError(ctx, err)
+			return
+		}
+		dueDate = nil
+	}
+
+	task, err := c.taskService.UpdateTaskAsUser(
 		ctx,
 		taskID,
+		userID,
 		title,
 		description,
 		status,
@@ -334,30 +585,35 @@ func (c *TaskController) UpdateTask(ctx *gin.Context) {
 
 // DeleteTask タスク削除
 // @Summary      タスク削除
-// @Description  指定されたIDのタスクを削除します
+// @Description  指定されたIDのタスクを削除します。削除は取り消し期間（既定10秒）の経過後に実行され、その間はundo.undo_tokenをPOST /undo/{token}に送ると取り消せます
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Security     BearerAuth
-// @Success      200 {object} TaskDeleteResponse "タスク削除成功"
+// @Success      200 {object} TaskDeletedResponse "タスク削除成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを削除する権限なし"
 // @Failure      404 {object} ErrorResponse "タスクが見つからない"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks/{id} [delete]
 func (c *TaskController) DeleteTask(ctx *gin.Context) {
-	taskID := ctx.Param("id")
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 
-	err := c.taskService.DeleteTask(ctx, taskID)
+	token, err := c.taskService.DeleteTaskWithUndo(ctx, ctx.Param("id"), userID)
 	if err != nil {
 		handleServiceError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Task deleted successfully",
+	ctx.JSON(http.StatusOK, TaskDeletedResponse{
+		Success: true,
+		Message: "Task deleted successfully",
+		Undo:    token,
 	})
 }
 
@@ -370,13 +626,17 @@ func (c *TaskController) DeleteTask(ctx *gin.Context) {
 // @Param        status query string false "ステータスフィルタ" Enums(TODO,IN_PROGRESS,DONE)
 // @Param        priority query string false "優先度フィルタ" Enums(LOW,MEDIUM,HIGH)
 // @Param        category query string false "カテゴリフィルタ" Enums(WORK,PERSONAL,STUDY,HEALTH,SHOPPING,OTHER)
-// @Param        assignee_id query string false "担当者IDフィルタ" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        assignee_id query string false "担当者IDフィルタ（いずれかの担当者に一致）" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        assignee_completed query bool false "assignee_idで指定した担当者の完了状態フィルタ"
 // @Param        created_by query string false "作成者IDフィルタ" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Param        due_date_from query string false "期限日FROM" example:"2024-01-01"
 // @Param        due_date_to query string false "期限日TO" example:"2024-12-31"
+// @Param        start_date_from query string false "着手予定日FROM" example:"2024-01-01"
+// @Param        start_date_to query string false "着手予定日TO" example:"2024-12-31"
+// @Param        starting query string false "着手予定日の期間指定（this_week: 今週（月曜開始）に着手予定）" Enums(this_week)
 // @Param        page query int false "ページ番号" default(1) minimum(1)
 // @Param        page_size query int false "ページサイズ" default(10) minimum(1) maximum(100)
-// @Param        sort_field query string false "ソートフィールド" Enums(created_at,updated_at,title,priority,status,due_date) default(created_at)
+// @Param        sort_field query string false "ソートフィールド" Enums(created_at,updated_at,title,priority,status,due_date,start_date) default(created_at)
 // @Param        sort_direction query string false "ソート方向" Enums(ASC,DESC) default(DESC)
 // @Security     BearerAuth
 // @Success      200 {object} TaskListResponse "タスク一覧取得成功"
@@ -417,21 +677,27 @@ func (c *TaskController) ListTasks(ctx *gin.Context) {
 
 // AssignTask タスク割り当て
 // @Summary      タスク割り当て
-// @Description  指定されたタスクをユーザーに割り当てます
+// @Description  指定されたタスクに担当者を追加します（assignee_id・assignee_idsのいずれかまたは両方を指定）。require_all_assigneesをtrueにすると全担当者の完了でタスクが完了になります。担当者が期限日にキャパシティ超過となる場合はwarningsを返します（割り当ては行われます）
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Param        request body AssignTaskRequest true "割り当て情報"
 // @Security     BearerAuth
-// @Success      200 {object} TaskUpdateResponse "タスク割り当て成功"
+// @Success      200 {object} TaskAssignResponse "タスク割り当て成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
 // @Failure      404 {object} ErrorResponse "タスクまたはユーザーが見つからない"
+// @Failure      409 {object} ErrorResponse "既に割り当て済み"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks/{id}/assign [put]
 func (c *TaskController) AssignTask(ctx *gin.Context) {
 	taskID := ctx.Param("id")
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 
 	var req AssignTaskRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -443,16 +709,118 @@ func (c *TaskController) AssignTask(ctx *gin.Context) {
 		return
 	}
 
-	task, err := c.taskService.AssignTask(ctx, taskID, req.AssigneeID)
+	assigneeIDs := req.AssigneeIDs
+	if req.AssigneeID != "" {
+		assigneeIDs = append([]string{req.AssigneeID}, assigneeIDs...)
+	}
+	if len(assigneeIDs) == 0 {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "assignee_id or assignee_ids is required",
+		})
+		return
+	}
+
+	task, warnings, err := c.taskService.AssignTaskToUsersWithWorkloadCheck(ctx, taskID, userID, assigneeIDs, req.RequireAllAssignees)
 	if err != nil {
 		handleServiceError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"success": true,
 		"message": "Task assigned successfully",
 		"data":    taskToResponse(task),
+	}
+	if len(warnings) > 0 {
+		response["warning"] = warnings[0]
+		response["warnings"] = warnings
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UnassignTask タスク担当者の解除
+// @Summary      タスク担当者の解除
+// @Description  指定されたユーザーをタスクの担当者から外します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        user_id path string true "担当者ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} TaskUpdateResponse "担当者解除成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
+// @Failure      404 {object} ErrorResponse "タスクまたは担当者が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/assignees/{user_id} [delete]
+func (c *TaskController) UnassignTask(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := c.taskService.UnassignTask(ctx, ctx.Param("id"), userID, ctx.Param("user_id"))
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Assignee removed successfully",
+		"data":    taskToResponse(task),
+	})
+}
+
+// SetMyAssignmentCompletion 自分の担当分の完了状態変更
+// @Summary      自分の担当分の完了状態変更
+// @Description  ログインユーザーの担当分を完了または未完了にします。全員完了が必要なタスクは全担当者の完了でタスクが完了になり、それ以外は最初の完了でタスクが完了になります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body AssignmentCompletionRequest true "完了状態"
+// @Security     BearerAuth
+// @Success      200 {object} TaskUpdateResponse "完了状態変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない、または担当者ではない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/assignees/me/completion [put]
+func (c *TaskController) SetMyAssignmentCompletion(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req AssignmentCompletionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	task, err := c.taskService.SetAssignmentCompletion(ctx, ctx.Param("id"), userID, req.Completed)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Assignment completion updated successfully",
+		"data":    taskToResponse(task),
 	})
 }
 
@@ -468,11 +836,16 @@ func (c *TaskController) AssignTask(ctx *gin.Context) {
 // @Success      200 {object} TaskUpdateResponse "ステータス変更成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
 // @Failure      404 {object} ErrorResponse "タスクが見つからない"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks/{id}/status [put]
 func (c *TaskController) ChangeTaskStatus(ctx *gin.Context) {
 	taskID := ctx.Param("id")
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 
 	var req ChangeStatusRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -485,7 +858,7 @@ func (c *TaskController) ChangeTaskStatus(ctx *gin.Context) {
 	}
 
 	status := domain.TaskStatus(req.Status)
-	task, err := c.taskService.ChangeTaskStatus(ctx, taskID, status)
+	task, err := c.taskService.ChangeTaskStatusAsUser(ctx, taskID, userID, status)
 	if err != nil {
 		handleServiceError(ctx, err)
 		return
@@ -498,19 +871,121 @@ func (c *TaskController) ChangeTaskStatus(ctx *gin.Context) {
 	})
 }
 
+// UpdateTaskEstimate タスク見積もり・実績更新
+// @Summary      タスク見積もり・実績更新
+// @Description  タスクの見積もり（分・ポイント）と実績工数（分）を更新します。指定したフィールドのみ更新されます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body TaskEstimateRequest true "見積もり・実績"
+// @Security     BearerAuth
+// @Success      200 {object} TaskUpdateResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/estimate [put]
+func (c *TaskController) UpdateTaskEstimate(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskEstimateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	task, err := c.taskService.UpdateTaskEstimate(ctx, taskID, userID, req.EstimateMinutes, req.EstimatePoints, req.ActualMinutes)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Task estimate updated successfully",
+		"data":    taskToResponse(task),
+	})
+}
+
+// UpdateTaskSchedule タスクの着手予定日時・期限更新
+// @Summary      タスクの着手予定日時・期限更新
+// @Description  タスクの着手予定日時と期限を更新します。指定したフィールドのみ更新され、clear_start_dateで着手予定日時を消去できます。着手予定日時は期限以前である必要があります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body TaskScheduleRequest true "着手予定日時・期限"
+// @Security     BearerAuth
+// @Success      200 {object} TaskUpdateResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "タスクを編集する権限なし"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/schedule [put]
+func (c *TaskController) UpdateTaskSchedule(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	task, err := c.taskService.UpdateTaskSchedule(ctx, taskID, userID, nonZeroTime(req.StartDate), nonZeroTime(req.DueDate), req.ClearStartDate)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Task schedule updated successfully",
+		"data":    taskToResponse(task),
+	})
+}
+
 // GetOverdueTasks 期限切れタスク取得
 // @Summary      期限切れタスク取得
-// @Description  期限が過ぎているタスクの一覧を取得します
+// @Description  現在認証されているユーザーが作成または担当する、期限が過ぎているタスクの一覧を取得します
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {object} TaskListResponse "期限切れタスク取得成功"
+// @Success      200 {object} TaskCollectionResponse "期限切れタスク取得成功"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks/overdue [get]
 func (c *TaskController) GetOverdueTasks(ctx *gin.Context) {
-	tasks, err := c.taskService.GetOverdueTasks(ctx)
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: err.Error(),
+	})
+		return
+	}
+
+	tasks, err := c.taskService.GetOverdueTasksByUser(ctx, userID)
 	if err != nil {
 		handleServiceError(ctx, err)
 		return
@@ -534,7 +1009,7 @@ func (c *TaskController) GetOverdueTasks(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {object} TaskListResponse "自分のタスク取得成功"
+// @Success      200 {object} TaskCollectionResponse "自分のタスク取得成功"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
 // @Router       /tasks/my [get]
@@ -574,7 +1049,7 @@ func (c *TaskController) GetMyTasks(ctx *gin.Context) {
 // @Produce      json
 // @Param        user_id path string true "ユーザーID" example:"123e4567-e89b-12d3-a456-426614174000"
 // @Security     BearerAuth
-// @Success      200 {object} TaskListResponse "ユーザータスク取得成功"
+// @Success      200 {object} TaskCollectionResponse "ユーザータスク取得成功"
 // @Failure      400 {object} ErrorResponse "リクエストが無効"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      404 {object} ErrorResponse "ユーザーが見つからない"
@@ -609,7 +1084,7 @@ func (c *TaskController) GetUserTasks(ctx *gin.Context) {
 // @Param        q query string true "検索クエリ" example:"重要"
 // @Param        limit query int false "結果の最大数" default(20) minimum(1) maximum(100)
 // @Security     BearerAuth
-// @Success      200 {object} TaskListResponse "タスク検索成功"
+// @Success      200 {object} TaskCollectionResponse "タスク検索成功"
 // @Failure      400 {object} ErrorResponse "検索クエリが必要"
 // @Failure      401 {object} ErrorResponse "認証が必要"
 // @Failure      500 {object} ErrorResponse "内部サーバーエラー"
@@ -650,10 +1125,191 @@ func (c *TaskController) SearchTasks(ctx *gin.Context) {
 	})
 }
 
+// QuickAddTask クイック追加
+// @Summary      クイック追加
+// @Description  「レポート提出 明日 15時 #work !high」のような文字列を解析してタスクを作成します。preview=true の場合は解析結果のみを返します
+// @Description  #タグ・!優先度を省略した場合はタイトルのキーワードからカテゴリ・優先度を推定し、category_suggested・priority_suggested で示します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body QuickAddRequest true "クイック追加情報"
+// @Security     BearerAuth
+// @Success      200 {object} QuickAddPreviewResult "解析結果（preview=true）"
+// @Success      201 {object} QuickAddTaskResponse "タスク作成成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      429 {object} ErrorResponse "未完了のタスク数が上限に達している"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/quick-add [post]
+func (c *TaskController) QuickAddTask(ctx *gin.Context) {
+	var req QuickAddRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// 相対日付の基準時刻（タイムゾーン指定があればそれを使用）
+	now := time.Now()
+	if req.Timezone != "" {
+		loc, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "Invalid timezone",
+			})
+			return
+		}
+		now = now.In(loc)
+	}
+
+	if req.Preview {
+		parsed, err := c.taskService.ParseQuickAdd(ctx, req.Text, now)
+		if err != nil {
+			handleServiceError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    quickAddPreviewToResponse(parsed),
+		})
+		return
+	}
+
+	task, parsed, err := c.taskService.QuickAddTask(ctx, req.Text, userID, now)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Task created successfully",
+		"data":    taskToResponse(task),
+		"parsed":  quickAddPreviewToResponse(parsed),
+	})
+}
+
+// ClassifyTask カテゴリ・優先度の推定
+// @Summary      カテゴリ・優先度の推定
+// @Description  タイトル・説明に含まれるキーワードからタスクのカテゴリと優先度を推定します。タスクは作成しません（入力中のプレビュー用）
+// @Description  推定できなかった場合は OTHER・MEDIUM を返し、確信度（category_confidence・priority_confidence）は0になります。クイック追加でも #タグ・!優先度を省略した場合に同じ推定を使用します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body ClassifyRequest true "推定するタスク"
+// @Security     BearerAuth
+// @Success      200 {object} ClassifyResponse "推定結果"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/classify [post]
+func (c *TaskController) ClassifyTask(ctx *gin.Context) {
+	var req ClassifyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	classification, err := c.taskService.ClassifyTask(ctx, req.Title, req.Description)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    classification,
+	})
+}
+
+// CheckDuplicateTask 重複タスクの確認
+// @Summary      重複タスクの確認
+// @Description  作成しようとしているタスクとタイトルが似ている最近の未完了のタスクを、類似度の高い順に最大5件返します。タスクは作成しません
+// @Description  group_id を指定するとグループタスクから、省略すると自分が作成したタスクから探します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body CheckDuplicateRequest true "確認するタスク"
+// @Security     BearerAuth
+// @Success      200 {object} CheckDuplicateResponse "確認結果"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "グループのメンバーでない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/check-duplicate [post]
+func (c *TaskController) CheckDuplicateTask(ctx *gin.Context) {
+	var req CheckDuplicateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input := usecase.DuplicateCheckInput{
+		Title:  req.Title,
+		UserID: userID,
+		Window: time.Duration(req.Days) * 24 * time.Hour,
+	}
+	if req.GroupID != nil {
+		input.GroupID = *req.GroupID
+	}
+	duplicates, err := c.taskService.FindDuplicateTasks(ctx, input)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+	if duplicates == nil {
+		duplicates = []*domain.DuplicateCandidate{}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"has_duplicates": len(duplicates) > 0,
+			"duplicates":     duplicates,
+		},
+	})
+}
+
 // 以下、既存のヘルパー関数たち...
 
 // taskToResponse はドメインモデルからレスポンスモデルに変換する
 func taskToResponse(task *domain.Task) TaskResponse {
+	completed, _ := task.AssigneeProgress()
+	checklistCompleted, _ := task.ChecklistProgress()
 	return TaskResponse{
 		ID:          task.ID,
 		Title:       task.Title,
@@ -663,13 +1319,55 @@ func taskToResponse(task *domain.Task) TaskResponse {
 		Category:    string(task.Category),
 		AssigneeID:  task.AssigneeID,
 		CreatedBy:   task.CreatedBy,
+		StartDate:   task.StartDate,
 		DueDate:     task.DueDate,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
 		IsOverdue:   task.CheckIsOverdue(),
+
+		EstimateMinutes: task.EstimateMinutes,
+		EstimatePoints:  task.EstimatePoints,
+		ActualMinutes:   task.ActualMinutes,
+		CompletedAt:     task.CompletedAt,
+
+		Assignees:           assigneesToResponse(task),
+		RequireAllAssignees: task.RequireAllAssignees,
+		CompletedAssignees:  completed,
+
+		Checklist:          task.Checklist,
+		ChecklistCompleted: checklistCompleted,
 	}
 }
 
+// parseTextFormat はformatクエリから本文をHTMLに変換して返すかを判定する
+// 無効な値の場合は400を返し、okにfalseを返す
+func parseTextFormat(ctx *gin.Context) (asHTML bool, ok bool) {
+	switch ctx.Query("format") {
+	case "", "markdown":
+		return false, true
+	case "html":
+		return true, true
+	}
+	ctx.JSON(http.StatusBadRequest, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "format must be one of markdown, html",
+	})
+	return false, false
+}
+
+// assigneesToResponse は担当者一覧をレスポンス形式に変換する
+func assigneesToResponse(task *domain.Task) []*domain.TaskAssignee {
+	if len(task.Assignees) > 0 {
+		return task.Assignees
+	}
+	assignees := []*domain.TaskAssignee{}
+	for _, id := range task.AssigneeIDs() {
+		assignees = append(assignees, &domain.TaskAssignee{UserID: id, AssignedAt: task.UpdatedAt})
+	}
+	return assignees
+}
+
 // tasksToResponse はタスクリストをレスポンス形式に変換する
 func tasksToResponse(tasks []*domain.Task) []TaskResponse {
 	var taskResponses []TaskResponse
@@ -679,6 +1377,21 @@ func tasksToResponse(tasks []*domain.Task) []TaskResponse {
 	return taskResponses
 }
 
+// quickAddPreviewToResponse はクイック追加の解析結果をレスポンス形式に変換する
+func quickAddPreviewToResponse(parsed *parsing.Result) QuickAddPreviewResponse {
+	return QuickAddPreviewResponse{
+		Title:    parsed.Title,
+		DueDate:  parsed.DueDate,
+		HasTime:  parsed.HasTime,
+		Category: string(parsed.CategoryOrDefault()),
+		Priority: string(parsed.PriorityOrDefault()),
+		Tokens:   parsed.Tokens,
+
+		CategorySuggested: parsed.CategorySuggested,
+		PrioritySuggested: parsed.PrioritySuggested,
+	}
+}
+
 // getUserIDFromContext は認証済みユーザーIDをコンテキストから取得する
 func getUserIDFromContext(ctx *gin.Context) (string, error) {
 	userID, exists := ctx.Get("user_id")
@@ -711,6 +1424,12 @@ func parseListFilter(ctx *gin.Context) domain.ListFilter {
 		filter.AssigneeID = &assigneeID
 	}
 
+	if completedStr := ctx.Query("assignee_completed"); completedStr != "" {
+		if completed, err := strconv.ParseBool(completedStr); err == nil {
+			filter.AssigneeCompleted = &completed
+		}
+	}
+
 	if createdBy := ctx.Query("created_by"); createdBy != "" {
 		filter.CreatedBy = &createdBy
 	}
@@ -729,9 +1448,41 @@ func parseListFilter(ctx *gin.Context) domain.ListFilter {
 		}
 	}
 
+	if startDateFromStr := ctx.Query("start_date_from"); startDateFromStr != "" {
+		ft := &FlexibleTime{}
+		if err := ft.UnmarshalJSON([]byte(`"` + startDateFromStr + `"`)); err == nil {
+			filter.StartDateFrom = &ft.Time
+		}
+	}
+
+	if startDateToStr := ctx.Query("start_date_to"); startDateToStr != "" {
+		ft := &FlexibleTime{}
+		if err := ft.UnmarshalJSON([]byte(`"` + startDateToStr + `"`)); err == nil {
+			filter.StartDateTo = &ft.Time
+		}
+	}
+
+	// 今週着手予定のタスク（start_date_from・start_date_toより優先）
+	if ctx.Query("starting") == startingThisWeek {
+		weekStart, weekEnd := domain.GetWeekStartEnd(time.Now())
+		filter.StartDateFrom = &weekStart
+		filter.StartDateTo = &weekEnd
+	}
+
 	return filter
 }
 
+// startingThisWeek はstartingクエリで今週着手予定のタスクを指定する値
+const startingThisWeek = "this_week"
+
+// nonZeroTime はゼロ値の日時をnilとして扱う
+func nonZeroTime(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
+
 // parsePagination はクエリパラメータからページネーション情報を解析する
 func parsePagination(ctx *gin.Context) domain.Pagination {
 	page := 1
@@ -769,6 +1520,7 @@ func parseSortOptions(ctx *gin.Context) domain.SortOptions {
 			"priority":   true,
 			"status":     true,
 			"due_date":   true,
+			"start_date": true,
 		}
 		if allowedFields[sf] {
 			sortField = sf
@@ -800,6 +1552,115 @@ func handleServiceError(ctx *gin.Context, err error) {
 		Error:   "REQUEST_ERROR",
 		Message: "Invalid parameters",
 	})
+	case errors.Is(err, usecase.ErrEscalationRuleNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Escalation rule not found",
+	})
+	case errors.Is(err, usecase.ErrAssigneeNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "User is not assigned to this task",
+	})
+	case errors.Is(err, usecase.ErrDuplicateAssignment):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Task already assigned to this user",
+	})
+	case errors.Is(err, usecase.ErrMilestoneNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Milestone not found",
+	})
+	case errors.Is(err, usecase.ErrDependencyNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Task dependency not found",
+	})
+	case errors.Is(err, usecase.ErrDependencyCycle):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Task dependency would create a cycle",
+	})
+	case errors.Is(err, usecase.ErrShareLinkNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Share link not found",
+	})
+	case errors.Is(err, usecase.ErrShareLinkExpired):
+		ctx.JSON(http.StatusGone, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Share link expired",
+	})
+	case errors.Is(err, usecase.ErrSharePasswordRequired):
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+		Success: false,
+		Error:   "PASSWORD_REQUIRED",
+		Message: "Password required",
+	})
+	case errors.Is(err, usecase.ErrSharePasswordInvalid):
+		ctx.JSON(http.StatusForbidden, ErrorResponse{
+		Success: false,
+		Error:   "PASSWORD_INVALID",
+		Message: "Invalid password",
+	})
+	case errors.Is(err, usecase.ErrSharePasswordLocked):
+		ctx.Header("Retry-After", "900")
+		ctx.JSON(http.StatusTooManyRequests, ErrorResponse{
+		Success: false,
+		Error:   "PASSWORD_LOCKED",
+		Message: "Too many invalid passwords, try again later",
+	})
+	case errors.Is(err, usecase.ErrTaskHistoryNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "No task history at the requested time",
+	})
+	case errors.Is(err, usecase.ErrWeeklyReportSubscriptionNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Weekly report subscription not found",
+	})
+	case errors.Is(err, usecase.ErrFeatureDisabled):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+		Success: false,
+		Error:   "FEATURE_DISABLED",
+		Message: "This feature is not available for your account",
+	})
+	case errors.Is(err, commonDomain.ErrQuotaExceeded):
+		ctx.JSON(http.StatusTooManyRequests, ErrorResponse{
+		Success: false,
+		Error:   "QUOTA_EXCEEDED",
+		Message: err.Error(),
+	})
+	case errors.Is(err, commonDomain.ErrPlanUpgradeRequired):
+		ctx.JSON(http.StatusPaymentRequired, ErrorResponse{
+		Success: false,
+		Error:   "PLAN_UPGRADE_REQUIRED",
+		Message: err.Error(),
+	})
+	case errors.Is(err, usecase.ErrTaskIDConflict):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Task ID is already in use",
+	})
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		ctx.JSON(http.StatusForbidden, ErrorResponse{
+		Success: false,
+		Error:   "REQUEST_ERROR",
+		Message: "Permission denied",
+	})
 	default:
 		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
 		Success: false,
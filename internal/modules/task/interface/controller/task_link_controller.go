@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// TaskLinkController はタスクのリンクのHTTPリクエストを処理するコントローラー
+type TaskLinkController struct {
+	taskLinkService *usecase.TaskLinkService
+}
+
+// NewTaskLinkController は新しいTaskLinkControllerを作成する
+func NewTaskLinkController(taskLinkService *usecase.TaskLinkService) *TaskLinkController {
+	return &TaskLinkController{
+		taskLinkService: taskLinkService,
+	}
+}
+
+// TaskLinkRequest はタスクにリンクを追加するリクエスト
+// typeを省略した場合、GitHubのIssue・Pull RequestのURLは関連付け、それ以外は LINK として追加する
+type TaskLinkRequest struct {
+	URL   string              `json:"url" binding:"required,max=2048" example:"https://github.com/hryt430/yotei-plus/pull/42"`
+	Type  domain.TaskLinkType `json:"type,omitempty" example:"GITHUB_PULL_REQUEST" enums:"LINK,DOCUMENT,DESIGN,GITHUB_ISSUE,GITHUB_PULL_REQUEST"`
+	Title string              `json:"title,omitempty" binding:"max=255" example:"タスクの検索を追加"`
+	// GitHubのIssue・Pull Requestのマージ・完了でタスクを完了にするか（省略した場合は完了にする）
+	CompleteOnClose *bool `json:"complete_on_close,omitempty" example:"true"`
+} // @name TaskLinkRequest
+
+// TaskLinkUpdateRequest はリンクの変更リクエスト（省略した項目は変更しない）
+type TaskLinkUpdateRequest struct {
+	Type  *domain.TaskLinkType `json:"type,omitempty" example:"DOCUMENT" enums:"LINK,DOCUMENT,DESIGN"`
+	URL   *string              `json:"url,omitempty" binding:"omitempty,max=2048" example:"https://docs.example.com/spec"`
+	Title *string              `json:"title,omitempty" binding:"omitempty,max=255" example:"仕様書"`
+} // @name TaskLinkUpdateRequest
+
+// TaskLinkResponse はタスクのリンクのレスポンス
+type TaskLinkResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    domain.TaskLink `json:"data"`
+} // @name TaskLinkResponse
+
+// TaskLinkListResponse はタスクのリンク一覧のレスポンス
+type TaskLinkListResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    []*domain.TaskLink `json:"data"`
+} // @name TaskLinkListResponse
+
+// ListLinks タスクのリンク一覧
+// @Summary      タスクのリンク一覧
+// @Description  ユーザーが追加したリンク、関連付けたGitHubのIssue・Pull Request、グループの予定（アプリ内のパス）を追加・関連付けた順に取得します。連携のリンクは managed がtrueで、stateに連携先の状態が入ります
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskLinkListResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/links [get]
+func (c *TaskLinkController) ListLinks(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	links, err := c.taskLinkService.ListLinks(ctx, ctx.Param("id"), userID)
+	if err != nil {
+		handleTaskLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskLinkListResponse{
+		Success: true,
+		Data:    links,
+	})
+}
+
+// CreateLink タスクへのリンクの追加
+// @Summary      タスクへのリンクの追加
+// @Description  タスクにhttp・httpsのURLを種類（LINK・DOCUMENT・DESIGN）とタイトル付きで追加します（1タスクにつき50件まで）。GitHubのIssue・Pull RequestのURL（または "owner/name#123"）は、typeを省略するかGITHUB_ISSUE・GITHUB_PULL_REQUESTを指定するとGitHubから取得して関連付け（1タスクにつき20件まで）、complete_on_closeがtrueの場合はPull Requestのマージ・Issueの完了でタスクが完了になります。登録したGitHubのトークンで取得するため、トークンで参照できる非公開のリポジトリも指定できます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        request body TaskLinkRequest true "リンク"
+// @Security     BearerAuth
+// @Success      201 {object} TaskLinkResponse "追加成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効、GitHubのトークンが無効、または上限に達している"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスク、またはIssue・Pull Requestが見つからない"
+// @Failure      409 {object} ErrorResponse "追加・関連付け済み"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Failure      502 {object} ErrorResponse "GitHubに接続できない"
+// @Router       /tasks/{id}/links [post]
+func (c *TaskLinkController) CreateLink(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	link, err := c.taskLinkService.CreateLink(ctx, ctx.Param("id"), userID, usecase.CreateTaskLinkInput{
+		Type:            req.Type,
+		URL:             req.URL,
+		Title:           req.Title,
+		CompleteOnClose: req.CompleteOnClose,
+	})
+	if err != nil {
+		handleTaskLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, TaskLinkResponse{
+		Success: true,
+		Data:    *link,
+	})
+}
+
+// UpdateLink タスクのリンクの変更
+// @Summary      タスクのリンクの変更
+// @Description  ユーザーが追加したリンクの種類・URL・タイトルを変更します。GitHub・予定の連携のリンクは変更できません
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        linkId path string true "リンクのID"
+// @Param        request body TaskLinkUpdateRequest true "変更する項目"
+// @Security     BearerAuth
+// @Success      200 {object} TaskLinkResponse "変更成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスク、またはリンクが見つからない"
+// @Failure      409 {object} ErrorResponse "同じURLのリンクを追加済み"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/links/{linkId} [patch]
+func (c *TaskLinkController) UpdateLink(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskLinkUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	link, err := c.taskLinkService.UpdateLink(ctx, ctx.Param("id"), ctx.Param("linkId"), userID, usecase.UpdateTaskLinkInput{
+		Type:  req.Type,
+		URL:   req.URL,
+		Title: req.Title,
+	})
+	if err != nil {
+		handleTaskLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskLinkResponse{
+		Success: true,
+		Data:    *link,
+	})
+}
+
+// DeleteLink タスクのリンクの削除
+// @Summary      タスクのリンクの削除
+// @Description  ユーザーが追加したリンクを削除します。GitHubのIssue・Pull Requestの場合は関連付けを解除します。予定のリンクは予定の画面から解除してください
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        linkId path string true "リンクのID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスク、またはリンクが見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/links/{linkId} [delete]
+func (c *TaskLinkController) DeleteLink(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.taskLinkService.DeleteLink(ctx, ctx.Param("id"), ctx.Param("linkId"), userID); err != nil {
+		handleTaskLinkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Task link deleted successfully",
+	})
+}
+
+func handleTaskLinkError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrTaskLinkNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Task link not found",
+		})
+	case errors.Is(err, usecase.ErrTaskLinkExists):
+		ctx.JSON(http.StatusConflict, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: "Link already added to this task",
+		})
+	default:
+		// GitHubのIssue・Pull Requestの関連付けのエラー
+		handleIssueLinkError(ctx, err)
+	}
+}
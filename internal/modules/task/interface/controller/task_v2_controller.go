@@ -0,0 +1,903 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hryt430/Yotei+/internal/common/apiversion"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// TaskV2Controller は/api/v2のタスクエンドポイントを処理するコントローラー
+// v1との違い:
+//   - エラーはapiversion.ErrorEnvelope（{"error": {"code", "message", "details", "request_id"}}）で返す
+//   - 成功レスポンスはsuccess・messageを持たず、dataにリソースを格納する
+//   - クエリパラメータの不正な値は無視せず400を返す
+type TaskV2Controller struct {
+	taskService usecase.TaskService
+}
+
+// NewTaskV2Controller は新しいTaskV2Controllerを作成する
+func NewTaskV2Controller(taskService usecase.TaskService) *TaskV2Controller {
+	return &TaskV2Controller{
+		taskService: taskService,
+	}
+}
+
+// TaskV2Response はv2のタスクレスポンス
+// 単一担当者のassignee_idは廃止し、担当者はassigneesのみで表す
+type TaskV2Response struct {
+	ID          string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title       string     `json:"title" example:"重要なタスク"`
+	Description string     `json:"description" example:"タスクの詳細説明"`
+	Status      string     `json:"status" example:"TODO"`
+	Priority    string     `json:"priority" example:"HIGH"`
+	Category    string     `json:"category" example:"WORK"`
+	CreatedBy   string     `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StartDate   *time.Time `json:"start_date" example:"2024-12-20T09:00:00Z"`
+	DueDate     *time.Time `json:"due_date" example:"2024-12-31T23:59:59Z"`
+	IsOverdue   bool       `json:"is_overdue" example:"false"`
+	CompletedAt *time.Time `json:"completed_at" example:"2024-01-02T18:00:00Z"`
+	CreatedAt   time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+
+	Estimate TaskV2Estimate `json:"estimate"`
+
+	Assignees           []*domain.TaskAssignee `json:"assignees"`
+	RequireAllAssignees bool                   `json:"require_all_assignees" example:"false"`
+	CompletedAssignees  int                    `json:"completed_assignees" example:"1"`
+} // @name TaskV2Response
+
+// TaskV2Estimate はv2のタスクの見積もり・実績
+type TaskV2Estimate struct {
+	Minutes       *int `json:"minutes" example:"90"`
+	Points        *int `json:"points" example:"3"`
+	ActualMinutes *int `json:"actual_minutes" example:"120"`
+} // @name TaskV2Estimate
+
+// TaskV2DataResponse はv2の単一タスクレスポンス
+type TaskV2DataResponse struct {
+	Data TaskV2Response `json:"data"`
+} // @name TaskV2DataResponse
+
+// TaskV2AssignResponse はv2のタスク割り当てレスポンス
+type TaskV2AssignResponse struct {
+	Data     TaskV2Response            `json:"data"`
+	Warnings []*domain.WorkloadWarning `json:"warnings"`
+} // @name TaskV2AssignResponse
+
+// TaskV2QuickAddResponse はv2のクイック追加レスポンス
+type TaskV2QuickAddResponse struct {
+	Data   TaskV2Response          `json:"data"`
+	Parsed QuickAddPreviewResponse `json:"parsed"`
+} // @name TaskV2QuickAddResponse
+
+// TaskV2QuickAddPreviewResponse はv2のクイック追加の解析結果レスポンス
+type TaskV2QuickAddPreviewResponse struct {
+	Data QuickAddPreviewResponse `json:"data"`
+} // @name TaskV2QuickAddPreviewResponse
+
+// TaskV2ListResponse はv2のタスク一覧レスポンス（dataは常に配列）
+type TaskV2ListResponse struct {
+	Data       []TaskV2Response `json:"data"`
+	Pagination *PaginationV2    `json:"pagination,omitempty"`
+} // @name TaskV2ListResponse
+
+// PaginationV2 はv2のページネーション情報
+type PaginationV2 struct {
+	Page       int `json:"page" example:"1"`
+	PageSize   int `json:"page_size" example:"10"`
+	TotalCount int `json:"total_count" example:"50"`
+	TotalPages int `json:"total_pages" example:"5"`
+} // @name PaginationV2
+
+// CreateTask タスク作成
+// @Summary      タスク作成（v2）
+// @Description  新しいタスクを作成します
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        request body TaskRequest true "タスク作成情報"
+// @Security     BearerAuth
+// @Success      201 {object} TaskV2DataResponse "タスク作成成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      429 {object} apiversion.ErrorEnvelope "未完了のタスク数が上限に達している"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks [post]
+func (c *TaskV2Controller) CreateTask(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+	if req.Title == "" {
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "VALIDATION_FAILED", "Request validation failed",
+			apiversion.ErrorDetail{Field: "title", Reason: "required"})
+		return
+	}
+
+	startDate, dueDate := nonZeroTime(req.StartDate), nonZeroTime(req.DueDate)
+	if startDate != nil && dueDate != nil && startDate.After(*dueDate) {
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "VALIDATION_FAILED", "Request validation failed",
+			apiversion.ErrorDetail{Field: "start_date", Reason: "must not be after due_date"})
+		return
+	}
+
+	priority := domain.PriorityMedium
+	if req.Priority != "" {
+		priority = domain.Priority(req.Priority)
+	}
+
+	task, err := c.taskService.CreateTaskWithDefaults(ctx, req.Title, req.Description, priority, userID)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	if startDate != nil || dueDate != nil {
+		task, err = c.taskService.UpdateTaskSchedule(ctx, task.ID, userID, startDate, dueDate, false)
+		if err != nil {
+			handleServiceErrorV2(ctx, err)
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusCreated, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// GetTask タスク取得
+// @Summary      タスク取得（v2）
+// @Description  指定されたIDのタスクを取得します
+// @Tags         tasks-v2
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "タスク取得成功"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id} [get]
+func (c *TaskV2Controller) GetTask(ctx *gin.Context) {
+	task, err := c.taskService.GetTask(ctx, ctx.Param("id"))
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// UpdateTask タスク更新
+// @Summary      タスク更新（v2）
+// @Description  指定されたIDのタスクを更新します（指定したフィールドのみ更新）
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body TaskRequest true "タスク更新情報"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "タスク更新成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを編集する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id} [put]
+func (c *TaskV2Controller) UpdateTask(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	var title, description *string
+	var status *domain.TaskStatus
+	var priority *domain.Priority
+	var dueDate *time.Time
+	if req.Title != "" {
+		title = &req.Title
+	}
+	if req.Description != "" {
+		description = &req.Description
+	}
+	if req.Status != "" {
+		s := domain.TaskStatus(req.Status)
+		status = &s
+	}
+	if req.Priority != "" {
+		p := domain.Priority(req.Priority)
+		priority = &p
+	}
+	if req.DueDate != nil && !req.DueDate.IsZero() {
+		dueDate = req.DueDate
+	}
+
+	// 着手予定日時は期限と合わせて検証するため、期限とまとめて先に更新する
+	if startDate := nonZeroTime(req.StartDate); startDate != nil {
+		if _, err := c.taskService.UpdateTaskSchedule(ctx, ctx.Param("id"), userID, startDate, dueDate, false); err != nil {
+			handleServiceErrorV2(ctx, err)
+			return
+		}
+		dueDate = nil
+	}
+
+	task, err := c.taskService.UpdateTaskAsUser(ctx, ctx.Param("id"), userID, title, description, status, priority, dueDate)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// DeleteTask タスク削除
+// @Summary      タスク削除（v2）
+// @Description  指定されたIDのタスクを削除します
+// @Tags         tasks-v2
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      204 "タスク削除成功"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを削除する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id} [delete]
+func (c *TaskV2Controller) DeleteTask(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.taskService.DeleteTaskAsUser(ctx, ctx.Param("id"), userID); err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListTasks タスク一覧取得
+// @Summary      タスク一覧取得（v2）
+// @Description  フィルタリング、ページング、ソート機能付きでタスク一覧を取得します。不正なパラメータは400を返します
+// @Tags         tasks-v2
+// @Produce      json
+// @Param        status query string false "ステータスフィルタ" Enums(TODO,IN_PROGRESS,DONE)
+// @Param        priority query string false "優先度フィルタ" Enums(LOW,MEDIUM,HIGH)
+// @Param        category query string false "カテゴリフィルタ" Enums(WORK,PERSONAL,STUDY,HEALTH,SHOPPING,OTHER)
+// @Param        assignee_id query string false "担当者IDフィルタ（いずれかの担当者に一致）"
+// @Param        assignee_completed query bool false "assignee_idで指定した担当者の完了状態フィルタ"
+// @Param        created_by query string false "作成者IDフィルタ"
+// @Param        due_date_from query string false "期限日FROM" example:"2024-01-01"
+// @Param        due_date_to query string false "期限日TO" example:"2024-12-31"
+// @Param        start_date_from query string false "着手予定日FROM" example:"2024-01-01"
+// @Param        start_date_to query string false "着手予定日TO" example:"2024-12-31"
+// @Param        starting query string false "着手予定日の期間指定（this_week: 今週（月曜開始）に着手予定）" Enums(this_week)
+// @Param        page query int false "ページ番号" default(1) minimum(1)
+// @Param        page_size query int false "ページサイズ" default(10) minimum(1) maximum(100)
+// @Param        sort_field query string false "ソートフィールド" Enums(created_at,updated_at,title,priority,status,due_date,start_date) default(created_at)
+// @Param        sort_direction query string false "ソート方向" Enums(ASC,DESC) default(DESC)
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2ListResponse "タスク一覧取得成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "パラメータが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks [get]
+func (c *TaskV2Controller) ListTasks(ctx *gin.Context) {
+	if details := validateListQueryV2(ctx); len(details) > 0 {
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", details...)
+		return
+	}
+
+	pagination := parsePagination(ctx)
+	tasks, total, err := c.taskService.ListTasks(ctx, parseListFilter(ctx), pagination, parseSortOptions(ctx))
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2ListResponse{
+		Data: tasksToV2Response(tasks),
+		Pagination: &PaginationV2{
+			Page:       pagination.Page,
+			PageSize:   pagination.PageSize,
+			TotalCount: total,
+			TotalPages: (total + pagination.PageSize - 1) / pagination.PageSize,
+		},
+	})
+}
+
+// SearchTasks タスク検索
+// @Summary      タスク検索（v2）
+// @Description  キーワードでタスクを検索します（タイトルと説明が対象）
+// @Tags         tasks-v2
+// @Produce      json
+// @Param        q query string true "検索クエリ" example:"重要"
+// @Param        limit query int false "結果の最大数" default(20) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2ListResponse "タスク検索成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "パラメータが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/search [get]
+func (c *TaskV2Controller) SearchTasks(ctx *gin.Context) {
+	var details []apiversion.ErrorDetail
+	query := ctx.Query("q")
+	if query == "" {
+		details = append(details, apiversion.ErrorDetail{Field: "q", Reason: "required"})
+	}
+	limit, detail := parseIntQueryV2(ctx, "limit", 20, 1, 100)
+	if detail != nil {
+		details = append(details, *detail)
+	}
+	if len(details) > 0 {
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", details...)
+		return
+	}
+
+	tasks, err := c.taskService.SearchTasks(ctx, query, limit)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2ListResponse{Data: tasksToV2Response(tasks)})
+}
+
+// AssignTask タスク割り当て
+// @Summary      タスク割り当て（v2）
+// @Description  指定されたタスクに担当者を追加します。担当者が期限日にキャパシティ超過となる場合はwarningsを返します（割り当ては行われます）
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body AssignTaskRequest true "割り当て情報"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2AssignResponse "タスク割り当て成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを編集する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクまたはユーザーが見つからない"
+// @Failure      409 {object} apiversion.ErrorEnvelope "既に割り当て済み"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id}/assign [put]
+func (c *TaskV2Controller) AssignTask(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req AssignTaskRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	assigneeIDs := req.AssigneeIDs
+	if req.AssigneeID != "" {
+		assigneeIDs = append([]string{req.AssigneeID}, assigneeIDs...)
+	}
+	if len(assigneeIDs) == 0 {
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "VALIDATION_FAILED", "Request validation failed",
+			apiversion.ErrorDetail{Field: "assignee_ids", Reason: "required"})
+		return
+	}
+
+	task, warnings, err := c.taskService.AssignTaskToUsersWithWorkloadCheck(ctx, ctx.Param("id"), userID, assigneeIDs, req.RequireAllAssignees)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+	if warnings == nil {
+		warnings = []*domain.WorkloadWarning{}
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2AssignResponse{
+		Data:     taskToV2Response(task),
+		Warnings: warnings,
+	})
+}
+
+// UnassignTask タスク担当者の解除
+// @Summary      タスク担当者の解除（v2）
+// @Description  指定されたユーザーをタスクの担当者から外します
+// @Tags         tasks-v2
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        user_id path string true "担当者ID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "担当者解除成功"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを編集する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクまたは担当者が見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id}/assignees/{user_id} [delete]
+func (c *TaskV2Controller) UnassignTask(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := c.taskService.UnassignTask(ctx, ctx.Param("id"), userID, ctx.Param("user_id"))
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// SetMyAssignmentCompletion 自分の担当分の完了状態変更
+// @Summary      自分の担当分の完了状態変更（v2）
+// @Description  ログインユーザーの担当分を完了または未完了にします
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body AssignmentCompletionRequest true "完了状態"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "完了状態変更成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない、または担当者ではない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id}/assignees/me/completion [put]
+func (c *TaskV2Controller) SetMyAssignmentCompletion(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req AssignmentCompletionRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	task, err := c.taskService.SetAssignmentCompletion(ctx, ctx.Param("id"), userID, req.Completed)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// ChangeTaskStatus タスクステータス変更
+// @Summary      タスクステータス変更（v2）
+// @Description  指定されたタスクのステータスを変更します
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body ChangeStatusRequest true "ステータス変更情報"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "ステータス変更成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを編集する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id}/status [put]
+func (c *TaskV2Controller) ChangeTaskStatus(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req ChangeStatusRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	task, err := c.taskService.ChangeTaskStatusAsUser(ctx, ctx.Param("id"), userID, domain.TaskStatus(req.Status))
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// UpdateTaskEstimate タスク見積もり・実績更新
+// @Summary      タスク見積もり・実績更新（v2）
+// @Description  タスクの見積もり（分・ポイント）と実績工数（分）を更新します。指定したフィールドのみ更新されます
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body TaskEstimateRequest true "見積もり・実績"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "更新成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを編集する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id}/estimate [put]
+func (c *TaskV2Controller) UpdateTaskEstimate(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskEstimateRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	task, err := c.taskService.UpdateTaskEstimate(ctx, ctx.Param("id"), userID, req.EstimateMinutes, req.EstimatePoints, req.ActualMinutes)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// UpdateTaskSchedule タスクの着手予定日時・期限更新
+// @Summary      タスクの着手予定日時・期限更新（v2）
+// @Description  タスクの着手予定日時と期限を更新します。指定したフィールドのみ更新され、clear_start_dateで着手予定日時を消去できます。着手予定日時は期限以前である必要があります
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Param        request body TaskScheduleRequest true "着手予定日時・期限"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2DataResponse "更新成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      403 {object} apiversion.ErrorEnvelope "タスクを編集する権限なし"
+// @Failure      404 {object} apiversion.ErrorEnvelope "タスクが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/{id}/schedule [put]
+func (c *TaskV2Controller) UpdateTaskSchedule(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req TaskScheduleRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	task, err := c.taskService.UpdateTaskSchedule(ctx, ctx.Param("id"), userID, nonZeroTime(req.StartDate), nonZeroTime(req.DueDate), req.ClearStartDate)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2DataResponse{Data: taskToV2Response(task)})
+}
+
+// GetOverdueTasks 期限切れタスク取得
+// @Summary      期限切れタスク取得（v2）
+// @Description  現在認証されているユーザーが作成または担当する、期限が過ぎているタスクの一覧を取得します
+// @Tags         tasks-v2
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2ListResponse "期限切れタスク取得成功"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/overdue [get]
+func (c *TaskV2Controller) GetOverdueTasks(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, err := c.taskService.GetOverdueTasksByUser(ctx, userID)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2ListResponse{Data: tasksToV2Response(tasks)})
+}
+
+// GetMyTasks 自分のタスク取得
+// @Summary      自分のタスク取得（v2）
+// @Description  現在認証されているユーザーに割り当てられたタスクを取得します
+// @Tags         tasks-v2
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2ListResponse "自分のタスク取得成功"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/my [get]
+func (c *TaskV2Controller) GetMyTasks(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, err := c.taskService.GetTasksByAssignee(ctx, userID)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2ListResponse{Data: tasksToV2Response(tasks)})
+}
+
+// GetUserTasks 特定ユーザーのタスク取得
+// @Summary      特定ユーザーのタスク取得（v2）
+// @Description  指定されたユーザーに割り当てられたタスクを取得します
+// @Tags         tasks-v2
+// @Produce      json
+// @Param        user_id path string true "ユーザーID" example:"123e4567-e89b-12d3-a456-426614174000"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2ListResponse "ユーザータスク取得成功"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      404 {object} apiversion.ErrorEnvelope "ユーザーが見つからない"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/user/{user_id} [get]
+func (c *TaskV2Controller) GetUserTasks(ctx *gin.Context) {
+	tasks, err := c.taskService.GetTasksByAssignee(ctx, ctx.Param("user_id"))
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TaskV2ListResponse{Data: tasksToV2Response(tasks)})
+}
+
+// QuickAddTask クイック追加
+// @Summary      クイック追加（v2）
+// @Description  「レポート提出 明日 15時 #work !high」のような文字列を解析してタスクを作成します。preview=true の場合は解析結果のみを返します
+// @Tags         tasks-v2
+// @Accept       json
+// @Produce      json
+// @Param        request body QuickAddRequest true "クイック追加情報"
+// @Security     BearerAuth
+// @Success      200 {object} TaskV2QuickAddPreviewResponse "解析結果（preview=true）"
+// @Success      201 {object} TaskV2QuickAddResponse "タスク作成成功"
+// @Failure      400 {object} apiversion.ErrorEnvelope "リクエストが無効"
+// @Failure      401 {object} apiversion.ErrorEnvelope "認証が必要"
+// @Failure      429 {object} apiversion.ErrorEnvelope "未完了のタスク数が上限に達している"
+// @Failure      500 {object} apiversion.ErrorEnvelope "内部サーバーエラー"
+// @Router       /tasks/quick-add [post]
+func (c *TaskV2Controller) QuickAddTask(ctx *gin.Context) {
+	userID, ok := requireUserIDV2(ctx)
+	if !ok {
+		return
+	}
+
+	var req QuickAddRequest
+	if !bindJSONV2(ctx, &req) {
+		return
+	}
+
+	now := time.Now()
+	if req.Timezone != "" {
+		loc, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			apiversion.AbortWithError(ctx, http.StatusBadRequest, "VALIDATION_FAILED", "Request validation failed",
+				apiversion.ErrorDetail{Field: "timezone", Reason: "unknown time zone"})
+			return
+		}
+		now = now.In(loc)
+	}
+
+	if req.Preview {
+		parsed, err := c.taskService.ParseQuickAdd(ctx, req.Text, now)
+		if err != nil {
+			handleServiceErrorV2(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, TaskV2QuickAddPreviewResponse{Data: quickAddPreviewToResponse(parsed)})
+		return
+	}
+
+	task, parsed, err := c.taskService.QuickAddTask(ctx, req.Text, userID, now)
+	if err != nil {
+		handleServiceErrorV2(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, TaskV2QuickAddResponse{
+		Data:   taskToV2Response(task),
+		Parsed: quickAddPreviewToResponse(parsed),
+	})
+}
+
+// taskToV2Response はドメインモデルからv2のレスポンスモデルに変換する
+func taskToV2Response(task *domain.Task) TaskV2Response {
+	completed, _ := task.AssigneeProgress()
+	return TaskV2Response{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      string(task.Status),
+		Priority:    string(task.Priority),
+		Category:    string(task.Category),
+		CreatedBy:   task.CreatedBy,
+		StartDate:   task.StartDate,
+		DueDate:     task.DueDate,
+		IsOverdue:   task.CheckIsOverdue(),
+		CompletedAt: task.CompletedAt,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+		Estimate: TaskV2Estimate{
+			Minutes:       task.EstimateMinutes,
+			Points:        task.EstimatePoints,
+			ActualMinutes: task.ActualMinutes,
+		},
+		Assignees:           assigneesToResponse(task),
+		RequireAllAssignees: task.RequireAllAssignees,
+		CompletedAssignees:  completed,
+	}
+}
+
+// tasksToV2Response はタスクリストをv2のレスポンス形式に変換する（空の場合も空配列を返す）
+func tasksToV2Response(tasks []*domain.Task) []TaskV2Response {
+	responses := make([]TaskV2Response, 0, len(tasks))
+	for _, task := range tasks {
+		responses = append(responses, taskToV2Response(task))
+	}
+	return responses
+}
+
+// requireUserIDV2 は認証済みユーザーIDを取得し、取得できない場合は401を返す
+func requireUserIDV2(ctx *gin.Context) (string, bool) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		apiversion.AbortWithError(ctx, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return "", false
+	}
+	return userID, true
+}
+
+// bindJSONV2 はリクエストボディをバインドし、失敗した場合はフィールドごとの詳細付きで400を返す
+func bindJSONV2(ctx *gin.Context, req any) bool {
+	err := ctx.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "INVALID_JSON", "Request body is not valid JSON")
+		return false
+	}
+
+	details := make([]apiversion.ErrorDetail, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		reason := fe.Tag()
+		if fe.Param() != "" {
+			reason += "=" + fe.Param()
+		}
+		details = append(details, apiversion.ErrorDetail{Field: jsonFieldName(req, fe.StructField()), Reason: reason})
+	}
+	apiversion.AbortWithError(ctx, http.StatusBadRequest, "VALIDATION_FAILED", "Request validation failed", details...)
+	return false
+}
+
+// jsonFieldName は構造体フィールドのJSON名を返す（タグがない場合はフィールド名）
+func jsonFieldName(req any, structField string) string {
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if f, ok := t.FieldByName(structField); ok {
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return structField
+}
+
+// validateListQueryV2 は一覧取得のクエリパラメータを検証する
+// v1では不正な値を無視してデフォルト値を使うが、v2ではエラーとして返す
+func validateListQueryV2(ctx *gin.Context) []apiversion.ErrorDetail {
+	var details []apiversion.ErrorDetail
+
+	enums := []struct {
+		field   string
+		allowed []string
+	}{
+		{"status", []string{string(domain.TaskStatusTodo), string(domain.TaskStatusInProgress), string(domain.TaskStatusDone)}},
+		{"priority", []string{string(domain.PriorityLow), string(domain.PriorityMedium), string(domain.PriorityHigh)}},
+		{"category", []string{
+			string(domain.CategoryWork), string(domain.CategoryPersonal), string(domain.CategoryStudy),
+			string(domain.CategoryHealth), string(domain.CategoryShopping), string(domain.CategoryOther),
+		}},
+		{"sort_field", []string{"created_at", "updated_at", "title", "priority", "status", "due_date", "start_date"}},
+		{"starting", []string{startingThisWeek}},
+		{"sort_direction", []string{"ASC", "DESC"}},
+	}
+	for _, e := range enums {
+		if value := ctx.Query(e.field); value != "" && !containsString(e.allowed, value) {
+			details = append(details, apiversion.ErrorDetail{Field: e.field, Reason: "must be one of " + strings.Join(e.allowed, ",")})
+		}
+	}
+
+	if value := ctx.Query("assignee_completed"); value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			details = append(details, apiversion.ErrorDetail{Field: "assignee_completed", Reason: "must be a boolean"})
+		}
+	}
+
+	for _, field := range []string{"due_date_from", "due_date_to", "start_date_from", "start_date_to"} {
+		if value := ctx.Query(field); value != "" {
+			ft := &FlexibleTime{}
+			if err := ft.UnmarshalJSON([]byte(`"` + value + `"`)); err != nil {
+				details = append(details, apiversion.ErrorDetail{Field: field, Reason: "must be a date (YYYY-MM-DD) or RFC3339 timestamp"})
+			}
+		}
+	}
+
+	if _, detail := parseIntQueryV2(ctx, "page", 1, 1, 0); detail != nil {
+		details = append(details, *detail)
+	}
+	if _, detail := parseIntQueryV2(ctx, "page_size", 10, 1, 100); detail != nil {
+		details = append(details, *detail)
+	}
+
+	return details
+}
+
+// parseIntQueryV2 は整数のクエリパラメータを解析する（maxが0の場合は上限なし）
+func parseIntQueryV2(ctx *gin.Context, field string, defaultValue, min, max int) (int, *apiversion.ErrorDetail) {
+	value := ctx.Query(field)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || (max > 0 && n > max) {
+		reason := "must be an integer >= " + strconv.Itoa(min)
+		if max > 0 {
+			reason = "must be an integer between " + strconv.Itoa(min) + " and " + strconv.Itoa(max)
+		}
+		return 0, &apiversion.ErrorDetail{Field: field, Reason: reason}
+	}
+	return n, nil
+}
+
+// containsString はスライスに値が含まれるかを判定する
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// handleServiceErrorV2 はサービスレイヤーからのエラーをv2のエラーエンベロープで返す
+// v1と異なり、エラーの種類ごとに個別のコードを返す
+func handleServiceErrorV2(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrTaskNotFound):
+		apiversion.AbortWithError(ctx, http.StatusNotFound, "TASK_NOT_FOUND", "Task not found")
+	case errors.Is(err, usecase.ErrUserNotFound):
+		apiversion.AbortWithError(ctx, http.StatusNotFound, "USER_NOT_FOUND", "User not found")
+	case errors.Is(err, usecase.ErrAssigneeNotFound):
+		apiversion.AbortWithError(ctx, http.StatusNotFound, "ASSIGNEE_NOT_FOUND", "User is not assigned to this task")
+	case errors.Is(err, usecase.ErrDuplicateAssignment):
+		apiversion.AbortWithError(ctx, http.StatusConflict, "ALREADY_ASSIGNED", "Task already assigned to this user")
+	case errors.Is(err, usecase.ErrInvalidParameter):
+		apiversion.AbortWithError(ctx, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid parameters")
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		apiversion.AbortWithError(ctx, http.StatusForbidden, "PERMISSION_DENIED", "Permission denied")
+	case errors.Is(err, commonDomain.ErrQuotaExceeded):
+		apiversion.AbortWithError(ctx, http.StatusTooManyRequests, "QUOTA_EXCEEDED", err.Error())
+	default:
+		apiversion.AbortWithError(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+	}
+}
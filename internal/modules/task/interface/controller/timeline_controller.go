@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// TimelineController はグループのタイムラインとタスクの依存関係のHTTPリクエストを処理するコントローラー
+type TimelineController struct {
+	timelineService *usecase.TimelineService
+}
+
+// NewTimelineController は新しいTimelineControllerを作成する
+func NewTimelineController(timelineService *usecase.TimelineService) *TimelineController {
+	return &TimelineController{
+		timelineService: timelineService,
+	}
+}
+
+// TaskDependencyRequest はタスクの依存関係追加リクエスト
+type TaskDependencyRequest struct {
+	DependsOnID string `json:"depends_on_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+} // @name TaskDependencyRequest
+
+// TimelineResponse はグループのタイムラインのレスポンス
+type TimelineResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    domain.Timeline `json:"data"`
+} // @name TimelineResponse
+
+// GetGroupTimeline グループのタイムライン取得
+// @Summary      グループのタイムライン取得
+// @Description  ガントチャート表示用に、グループタスクの開始日・期限・依存関係・マイルストーンと、サーバー側で計算したクリティカルパスを取得します（グループのメンバーのみ、タスクは500件まで）
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        groupId path string true "グループID"
+// @Security     BearerAuth
+// @Success      200 {object} TimelineResponse "タイムライン取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /groups/{groupId}/timeline [get]
+func (c *TimelineController) GetGroupTimeline(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	timeline, err := c.timelineService.GetGroupTimeline(ctx, userID, ctx.Param("groupId"))
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TimelineResponse{
+		Success: true,
+		Data:    *timeline,
+	})
+}
+
+// AddTaskDependency タスクの依存関係追加
+// @Summary      タスクの依存関係追加
+// @Description  タスクがdepends_on_idのタスクの完了後に開始する依存関係を追加します。2つのタスクは同じグループのグループタスクである必要があります（グループでタスク編集の権限が必要）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        request body TaskDependencyRequest true "依存先のタスク"
+// @Security     BearerAuth
+// @Success      201 {object} TaskDeleteResponse "依存関係追加成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "タスクが見つからない"
+// @Failure      409 {object} ErrorResponse "依存関係が循環する"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/dependencies [post]
+func (c *TimelineController) AddTaskDependency(ctx *gin.Context) {
+	var req TaskDependencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.timelineService.AddDependency(ctx, userID, ctx.Param("id"), req.DependsOnID); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Task dependency added successfully",
+	})
+}
+
+// RemoveTaskDependency タスクの依存関係削除
+// @Summary      タスクの依存関係削除
+// @Description  タスクの依存関係を削除します（グループでタスク編集の権限が必要）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "タスクID"
+// @Param        depends_on_id path string true "依存先のタスクID"
+// @Security     BearerAuth
+// @Success      200 {object} TaskDeleteResponse "依存関係削除成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      404 {object} ErrorResponse "依存関係が見つからない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/{id}/dependencies/{depends_on_id} [delete]
+func (c *TimelineController) RemoveTaskDependency(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.timelineService.RemoveDependency(ctx, userID, ctx.Param("id"), ctx.Param("depends_on_id")); err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Task dependency removed successfully",
+	})
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// TodayController はホーム画面用の今日の予定のHTTPリクエストを処理するコントローラー
+type TodayController struct {
+	todayService *usecase.TodayService
+}
+
+// NewTodayController は新しいTodayControllerを作成する
+func NewTodayController(todayService *usecase.TodayService) *TodayController {
+	return &TodayController{
+		todayService: todayService,
+	}
+}
+
+// TodayResponse は今日の予定のレスポンス
+type TodayResponse struct {
+	Success bool         `json:"success" example:"true"`
+	Data    domain.Today `json:"data"`
+} // @name TodayResponse
+
+// GetToday 今日の予定取得
+// @Summary      今日の予定取得
+// @Description  ホーム画面用に、今日が期限のタスク・前日までに期限を過ぎた未完了のタスク（30日前まで）・今日着手予定のタスクと未読通知数をまとめて取得します。日の区切りはtimezone、省略時は勤務時間設定のタイムゾーンです
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        timezone query string false "タイムゾーン（省略時は勤務時間設定のタイムゾーン）" example:"Asia/Tokyo"
+// @Security     BearerAuth
+// @Success      200 {object} TodayResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /me/today [get]
+func (c *TodayController) GetToday(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	today, err := c.todayService.GetToday(ctx, userID, ctx.Query("timezone"))
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TodayResponse{
+		Success: true,
+		Data:    *today,
+	})
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// WorkloadController は勤務時間・作業量のHTTPリクエストを処理するコントローラー
+type WorkloadController struct {
+	workloadService *usecase.WorkloadService
+}
+
+// NewWorkloadController は新しいWorkloadControllerを作成する
+func NewWorkloadController(workloadService *usecase.WorkloadService) *WorkloadController {
+	return &WorkloadController{
+		workloadService: workloadService,
+	}
+}
+
+// WorkingHoursRequest は勤務時間設定の更新リクエスト
+type WorkingHoursRequest struct {
+	Timezone             string `json:"timezone" example:"Asia/Tokyo"`
+	WorkDays             []int  `json:"work_days" example:"1,2,3,4,5"`
+	StartTime            string `json:"start_time" example:"09:00"`
+	EndTime              string `json:"end_time" example:"18:00"`
+	DailyCapacityMinutes int    `json:"daily_capacity_minutes" binding:"min=0,max=1440" example:"420"`
+	// 期限が祝日・勤務日以外になった場合の扱い（KEEP・NEXT_BUSINESS_DAY・PREVIOUS_BUSINESS_DAY、省略時はKEEP）
+	HolidayPolicy string `json:"holiday_policy,omitempty" example:"NEXT_BUSINESS_DAY"`
+	// リマインダー・エスカレーションの通知を送る時間帯（WORKING_HOURS・ANYTIME、省略時はWORKING_HOURS）
+	// WORKING_HOURSの場合、勤務時間外・祝日の通知は次の勤務時間の開始時にまとめて送る
+	ReminderTiming string `json:"reminder_timing,omitempty" example:"WORKING_HOURS"`
+} // @name WorkingHoursRequest
+
+// WorkingHoursResponse は勤務時間設定レスポンス
+type WorkingHoursResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    domain.WorkingHours `json:"data"`
+} // @name WorkingHoursResponse
+
+// WorkloadResponse は作業量レスポンス
+type WorkloadResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    domain.Workload `json:"data"`
+} // @name WorkloadResponse
+
+// GetWorkingHours 勤務時間設定取得
+// @Summary      勤務時間設定取得
+// @Description  自分の勤務時間・キャパシティ設定を取得します（未設定の場合は平日9:00-18:00）
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} WorkingHoursResponse "取得成功"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/workload/settings [get]
+func (c *WorkloadController) GetWorkingHours(ctx *gin.Context) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	hours, err := c.workloadService.GetWorkingHours(ctx, userID)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    hours,
+	})
+}
+
+// UpdateWorkingHours 勤務時間設定更新
+// @Summary      勤務時間設定更新
+// @Description  自分の勤務時間・キャパシティ設定を更新します。daily_capacity_minutesが0の場合は勤務時間から算出します
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body WorkingHoursRequest true "勤務時間設定"
+// @Security     BearerAuth
+// @Success      200 {object} WorkingHoursResponse "更新成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/workload/settings [put]
+func (c *WorkloadController) UpdateWorkingHours(ctx *gin.Context) {
+	var req WorkingHoursRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	hours, err := c.workloadService.UpdateWorkingHours(ctx, userID, usecase.WorkingHoursInput{
+		Timezone:             req.Timezone,
+		WorkDays:             req.WorkDays,
+		StartTime:            req.StartTime,
+		EndTime:              req.EndTime,
+		DailyCapacityMinutes: req.DailyCapacityMinutes,
+		HolidayPolicy:        req.HolidayPolicy,
+		ReminderTiming:       req.ReminderTiming,
+	})
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    hours,
+	})
+}
+
+// GetWorkload 作業量取得
+// @Summary      作業量取得
+// @Description  担当中の未完了タスクの見積もり工数を日ごとに集計し、キャパシティ超過日を返します。他ユーザーの作業量はグループ管理者がgroup_idを指定した場合のみ参照できます
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        user_id query string false "対象ユーザーID（省略時は自分）"
+// @Param        group_id query string false "グループID（他ユーザー参照時に必須）"
+// @Param        from query string false "開始日 (YYYY-MM-DD、省略時は今日)"
+// @Param        to query string false "終了日 (YYYY-MM-DD、省略時は開始日から6日後)"
+// @Security     BearerAuth
+// @Success      200 {object} WorkloadResponse "取得成功"
+// @Failure      400 {object} ErrorResponse "リクエストが無効"
+// @Failure      401 {object} ErrorResponse "認証が必要"
+// @Failure      403 {object} ErrorResponse "権限がない"
+// @Failure      500 {object} ErrorResponse "内部サーバーエラー"
+// @Router       /tasks/workload [get]
+func (c *WorkloadController) GetWorkload(ctx *gin.Context) {
+	requesterID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
+			Success: false,
+			Error:   "REQUEST_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	from := time.Now()
+	if v := ctx.Query("from"); v != "" {
+		if from, err = time.ParseInLocation("2006-01-02", v, time.Local); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "Invalid from date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	to := from.AddDate(0, 0, 6)
+	if v := ctx.Query("to"); v != "" {
+		if to, err = time.ParseInLocation("2006-01-02", v, time.Local); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "REQUEST_ERROR",
+				Message: "Invalid to date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	var groupID *string
+	if g := ctx.Query("group_id"); g != "" {
+		groupID = &g
+	}
+
+	workload, err := c.workloadService.GetWorkload(ctx, requesterID, ctx.Query("user_id"), groupID, from, to)
+	if err != nil {
+		handleServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    workload,
+	})
+}
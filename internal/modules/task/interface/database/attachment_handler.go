@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// AttachmentRepository は添付ファイルのデータベースリポジトリ実装
+// ファイルのストレージのキーは file_path に保存する
+type AttachmentRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewAttachmentRepository は新しいAttachmentRepositoryを作成する
+func NewAttachmentRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.AttachmentRepository {
+	return &AttachmentRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const attachmentColumns = `id, task_id, filename, file_path, file_size, mime_type, thumbnail_status, uploaded_by, created_at`
+
+// CreateAttachment は添付ファイルを作成する
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_attachments (` + attachmentColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.Filename,
+		attachment.StorageKey,
+		attachment.Size,
+		attachment.MimeType,
+		attachment.ThumbnailStatus,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create attachment", logger.Any("attachmentID", attachment.ID), logger.Error(err))
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachment はIDにより添付ファイルを取得する（存在しない場合は nil）
+func (r *AttachmentRepository) GetAttachment(ctx context.Context, id string) (*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_attachments
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	attachments, err := r.queryAttachments(query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	return attachments[0], nil
+}
+
+// ListAttachmentsByTask はタスクの添付ファイルをアップロードした順に取得する
+func (r *AttachmentRepository) ListAttachmentsByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_attachments
+		WHERE task_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryAttachments(query, taskID)
+}
+
+// ListAttachmentsByUser はユーザーがアップロードした添付ファイルをアップロードした順に取得する（データのエクスポート用）
+func (r *AttachmentRepository) ListAttachmentsByUser(ctx context.Context, userID string) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_attachments
+		WHERE uploaded_by = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryAttachments(query, userID)
+}
+
+// DeleteAttachment は添付ファイルを削除する
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_attachments WHERE id = ?`
+
+	if _, err := r.Execute(query, id); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete attachment", logger.Any("attachmentID", id), logger.Error(err))
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
+
+// ListPendingThumbnails はサムネイルが生成待ちの添付ファイルを古い順にlimit件取得する
+func (r *AttachmentRepository) ListPendingThumbnails(ctx context.Context, limit int) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_attachments
+		WHERE thumbnail_status = ?
+		ORDER BY created_at ASC, id ASC
+		LIMIT ?
+	`
+
+	return r.queryAttachments(query, domain.ThumbnailPending, limit)
+}
+
+// UpdateThumbnailStatus はサムネイルの生成状況を更新する
+func (r *AttachmentRepository) UpdateThumbnailStatus(ctx context.Context, id string, status domain.ThumbnailStatus) error {
+	query := `UPDATE ` + "`Yotei-Plus`" + `.task_attachments SET thumbnail_status = ? WHERE id = ?`
+
+	if _, err := r.Execute(query, status, id); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update thumbnail status", logger.Any("attachmentID", id), logger.Error(err))
+		return fmt.Errorf("failed to update thumbnail status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepository) queryAttachments(query string, args ...interface{}) ([]*domain.Attachment, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query attachments", logger.Error(err))
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var attachments []*domain.Attachment
+	for rows.Next() {
+		var attachment domain.Attachment
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.TaskID,
+			&attachment.Filename,
+			&attachment.StorageKey,
+			&attachment.Size,
+			&attachment.MimeType,
+			&attachment.ThumbnailStatus,
+			&attachment.UploadedBy,
+			&attachment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, &attachment)
+	}
+
+	return attachments, nil
+}
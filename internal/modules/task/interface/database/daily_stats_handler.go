@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DailyStatsRepository は日次統計の集計テーブルのデータベースリポジトリ実装
+type DailyStatsRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewDailyStatsRepository は新しいDailyStatsRepositoryを作成する
+func NewDailyStatsRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.DailyStatsRepository {
+	return &DailyStatsRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const dailyStatsColumns = `user_id, stat_date, total_tasks, completed_tasks, in_progress_tasks, todo_tasks, overdue_tasks, stale_at, updated_at`
+
+// GetSnapshots はfromからtoまでのユーザーの行を日付をキーに取得する
+func (r *DailyStatsRepository) GetSnapshots(ctx context.Context, userID, from, to string) (map[string]*domain.DailyStatsSnapshot, error) {
+	snapshots, err := r.querySnapshots(ctx, `
+		SELECT `+dailyStatsColumns+`
+		FROM `+"`Yotei-Plus`"+`.daily_stats
+		WHERE user_id = ? AND stat_date BETWEEN ? AND ?
+	`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*domain.DailyStatsSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		result[snapshot.Date] = snapshot
+	}
+	return result, nil
+}
+
+// SaveSnapshot は行を保存する（存在する場合は上書き）
+func (r *DailyStatsRepository) SaveSnapshot(ctx context.Context, snapshot *domain.DailyStatsSnapshot) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.daily_stats
+			(` + dailyStatsColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			total_tasks = VALUES(total_tasks),
+			completed_tasks = VALUES(completed_tasks),
+			in_progress_tasks = VALUES(in_progress_tasks),
+			todo_tasks = VALUES(todo_tasks),
+			overdue_tasks = VALUES(overdue_tasks),
+			stale_at = VALUES(stale_at),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.Execute(query,
+		snapshot.UserID,
+		snapshot.Date,
+		snapshot.TotalTasks,
+		snapshot.CompletedTasks,
+		snapshot.InProgressTasks,
+		snapshot.TodoTasks,
+		snapshot.OverdueTasks,
+		snapshot.StaleAt,
+		snapshot.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save daily stats",
+			logger.Any("userID", snapshot.UserID),
+			logger.Any("date", snapshot.Date),
+			logger.Error(err))
+		return fmt.Errorf("failed to save daily stats: %w", err)
+	}
+	return nil
+}
+
+// ListSnapshots はfrom以降の日付の行を日付・ユーザーID順にlimit件まで取得する
+func (r *DailyStatsRepository) ListSnapshots(ctx context.Context, from string, limit int) ([]*domain.DailyStatsSnapshot, error) {
+	return r.querySnapshots(ctx, `
+		SELECT `+dailyStatsColumns+`
+		FROM `+"`Yotei-Plus`"+`.daily_stats
+		WHERE stat_date >= ?
+		ORDER BY stat_date, user_id
+		LIMIT ?
+	`, from, limit)
+}
+
+// querySnapshots は行を取得する共通処理
+func (r *DailyStatsRepository) querySnapshots(ctx context.Context, query string, args ...interface{}) ([]*domain.DailyStatsSnapshot, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query daily stats", logger.Error(err))
+		return nil, fmt.Errorf("failed to query daily stats: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var snapshots []*domain.DailyStatsSnapshot
+	for rows.Next() {
+		var s domain.DailyStatsSnapshot
+		var date time.Time
+		var staleAt sql.NullTime
+		if err := rows.Scan(&s.UserID, &date, &s.TotalTasks, &s.CompletedTasks, &s.InProgressTasks,
+			&s.TodoTasks, &s.OverdueTasks, &staleAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan daily stats: %w", err)
+		}
+		s.Date = date.Format(domain.DailyStatsDateLayout)
+		if staleAt.Valid {
+			s.StaleAt = &staleAt.Time
+		}
+		snapshots = append(snapshots, &s)
+	}
+	return snapshots, nil
+}
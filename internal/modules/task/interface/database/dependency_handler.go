@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DependencyRepository はタスク間の依存関係のデータベースリポジトリ実装
+type DependencyRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewDependencyRepository は新しいDependencyRepositoryを作成する
+func NewDependencyRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.DependencyRepository {
+	return &DependencyRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// AddDependency は依存関係を追加する（登録済みの場合は何もしない）
+func (r *DependencyRepository) AddDependency(ctx context.Context, dependency *domain.TaskDependency) error {
+	query := `
+		INSERT IGNORE INTO ` + "`Yotei-Plus`" + `.task_dependencies (task_id, depends_on_task_id, created_by, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		dependency.TaskID,
+		dependency.DependsOnID,
+		dependency.CreatedBy,
+		dependency.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to add task dependency",
+			logger.Any("taskID", dependency.TaskID), logger.Any("dependsOnID", dependency.DependsOnID), logger.Error(err))
+		return fmt.Errorf("failed to add task dependency: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveDependency は依存関係を削除する
+func (r *DependencyRepository) RemoveDependency(ctx context.Context, taskID, dependsOnID string) error {
+	query := `
+		DELETE FROM ` + "`Yotei-Plus`" + `.task_dependencies
+		WHERE task_id = ? AND depends_on_task_id = ?
+	`
+
+	result, err := r.Execute(query, taskID, dependsOnID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to remove task dependency",
+			logger.Any("taskID", taskID), logger.Any("dependsOnID", dependsOnID), logger.Error(err))
+		return fmt.Errorf("failed to remove task dependency: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrDependencyNotFound
+	}
+
+	return nil
+}
+
+// ListDependencies は指定したタスクが依存している依存関係を取得する
+func (r *DependencyRepository) ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error) {
+	if len(taskIDs) == 0 {
+		return []*domain.TaskDependency{}, nil
+	}
+
+	placeholders := make([]string, len(taskIDs))
+	args := make([]interface{}, len(taskIDs))
+	for i, taskID := range taskIDs {
+		placeholders[i] = "?"
+		args[i] = taskID
+	}
+
+	query := `
+		SELECT task_id, depends_on_task_id, created_by, created_at
+		FROM ` + "`Yotei-Plus`" + `.task_dependencies
+		WHERE task_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list task dependencies", logger.Error(err))
+		return nil, fmt.Errorf("failed to list task dependencies: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	dependencies := []*domain.TaskDependency{}
+	for rows.Next() {
+		var dependency domain.TaskDependency
+		if err := rows.Scan(&dependency.TaskID, &dependency.DependsOnID, &dependency.CreatedBy, &dependency.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task dependency: %w", err)
+		}
+		dependencies = append(dependencies, &dependency)
+	}
+
+	return dependencies, nil
+}
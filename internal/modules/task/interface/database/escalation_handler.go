@@ -0,0 +1,374 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// EscalationRuleRepository はエスカレーションルールのデータベースリポジトリ実装
+type EscalationRuleRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewEscalationRuleRepository は新しいEscalationRuleRepositoryを作成する
+func NewEscalationRuleRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.EscalationRuleRepository {
+	return &EscalationRuleRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const escalationRuleColumns = `id, scope, owner_id, name, overdue_hours, raise_priority, notify_assignee,
+	notify_group_admins, reassign_to, enabled, created_by, created_at, updated_at`
+
+// CreateRule はエスカレーションルールを作成する
+func (r *EscalationRuleRepository) CreateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_escalation_rules (` + escalationRuleColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		rule.ID,
+		string(rule.Scope),
+		rule.OwnerID,
+		rule.Name,
+		rule.OverdueHours,
+		rule.RaisePriority,
+		rule.NotifyAssignee,
+		rule.NotifyGroupAdmins,
+		rule.ReassignTo,
+		rule.Enabled,
+		rule.CreatedBy,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create escalation rule", logger.Any("ruleID", rule.ID), logger.Error(err))
+		return fmt.Errorf("failed to create escalation rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetRuleByID はIDによりエスカレーションルールを取得する
+func (r *EscalationRuleRepository) GetRuleByID(ctx context.Context, id string) (*domain.EscalationRule, error) {
+	if id == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	query := `
+		SELECT ` + escalationRuleColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_escalation_rules
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	rules, err := r.queryRules(query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, usecase.ErrEscalationRuleNotFound
+	}
+
+	return rules[0], nil
+}
+
+// ListRulesByOwner は所有者（ユーザーまたはグループ）のルール一覧を取得する
+func (r *EscalationRuleRepository) ListRulesByOwner(ctx context.Context, scope domain.EscalationScope, ownerID string) ([]*domain.EscalationRule, error) {
+	query := `
+		SELECT ` + escalationRuleColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_escalation_rules
+		WHERE scope = ? AND owner_id = ?
+		ORDER BY overdue_hours ASC, created_at ASC
+	`
+
+	return r.queryRules(query, string(scope), ownerID)
+}
+
+// ListEnabledRules は有効なルールを全て取得する
+func (r *EscalationRuleRepository) ListEnabledRules(ctx context.Context) ([]*domain.EscalationRule, error) {
+	query := `
+		SELECT ` + escalationRuleColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_escalation_rules
+		WHERE enabled = TRUE
+		ORDER BY overdue_hours ASC
+	`
+
+	return r.queryRules(query)
+}
+
+// UpdateRule はエスカレーションルールを更新する
+func (r *EscalationRuleRepository) UpdateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	query := `
+		UPDATE ` + "`Yotei-Plus`" + `.task_escalation_rules SET
+			name = ?,
+			overdue_hours = ?,
+			raise_priority = ?,
+			notify_assignee = ?,
+			notify_group_admins = ?,
+			reassign_to = ?,
+			enabled = ?,
+			updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.Execute(query,
+		rule.Name,
+		rule.OverdueHours,
+		rule.RaisePriority,
+		rule.NotifyAssignee,
+		rule.NotifyGroupAdmins,
+		rule.ReassignTo,
+		rule.Enabled,
+		rule.UpdatedAt,
+		rule.ID,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update escalation rule", logger.Any("ruleID", rule.ID), logger.Error(err))
+		return fmt.Errorf("failed to update escalation rule: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrEscalationRuleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRule はエスカレーションルールを削除する
+func (r *EscalationRuleRepository) DeleteRule(ctx context.Context, id string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_escalation_rules WHERE id = ?`
+
+	result, err := r.Execute(query, id)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete escalation rule", logger.Any("ruleID", id), logger.Error(err))
+		return fmt.Errorf("failed to delete escalation rule: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrEscalationRuleNotFound
+	}
+
+	return nil
+}
+
+// HasEscalated はタスクに対してルールが適用済みかを確認する
+func (r *EscalationRuleRepository) HasEscalated(ctx context.Context, taskID, ruleID string) (bool, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM ` + "`Yotei-Plus`" + `.task_escalations
+		WHERE task_id = ? AND rule_id = ?
+	`
+
+	rows, err := r.Query(query, taskID, ruleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to query escalation history: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var count int
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return false, fmt.Errorf("failed to scan escalation count: %w", err)
+		}
+	}
+
+	return count > 0, nil
+}
+
+// RecordEscalation はルールの適用を記録する
+func (r *EscalationRuleRepository) RecordEscalation(ctx context.Context, taskID, ruleID string, escalatedAt time.Time) error {
+	query := `
+		INSERT IGNORE INTO ` + "`Yotei-Plus`" + `.task_escalations (task_id, rule_id, escalated_at)
+		VALUES (?, ?, ?)
+	`
+
+	if _, err := r.Execute(query, taskID, ruleID, escalatedAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to record escalation",
+			logger.Any("taskID", taskID), logger.Any("ruleID", ruleID), logger.Error(err))
+		return fmt.Errorf("failed to record escalation: %w", err)
+	}
+
+	return nil
+}
+
+// queryRules はルール一覧を取得する共通処理
+func (r *EscalationRuleRepository) queryRules(query string, args ...interface{}) ([]*domain.EscalationRule, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query escalation rules", logger.Error(err))
+		return nil, fmt.Errorf("failed to query escalation rules: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var rules []*domain.EscalationRule
+	for rows.Next() {
+		var rule domain.EscalationRule
+		var scope string
+		var reassignTo sql.NullString
+
+		err := rows.Scan(
+			&rule.ID,
+			&scope,
+			&rule.OwnerID,
+			&rule.Name,
+			&rule.OverdueHours,
+			&rule.RaisePriority,
+			&rule.NotifyAssignee,
+			&rule.NotifyGroupAdmins,
+			&reassignTo,
+			&rule.Enabled,
+			&rule.CreatedBy,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escalation rule: %w", err)
+		}
+
+		rule.Scope = domain.EscalationScope(scope)
+		if reassignTo.Valid {
+			id := reassignTo.String
+			rule.ReassignTo = &id
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
+
+// GroupTaskResolver はグループタスクの紐付け・グループのメンバーを参照するリポジトリ実装
+// 権限の確認はグループモジュールの権限設定に従うため、ここでは扱わない
+type GroupTaskResolver struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewGroupTaskResolver は新しいGroupTaskResolverを作成する
+func NewGroupTaskResolver(sqlHandler SqlHandler, logger logger.Logger) usecase.GroupTaskStore {
+	return &GroupTaskResolver{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// GetGroupIDsForTask はタスクが属するグループIDを取得する
+func (r *GroupTaskResolver) GetGroupIDsForTask(ctx context.Context, taskID string) ([]string, error) {
+	query := `
+		SELECT group_id
+		FROM ` + "`Yotei-Plus`" + `.group_tasks
+		WHERE task_id = ?
+	`
+
+	return r.queryIDs(query, taskID)
+}
+
+// ListGroupTaskIDs はグループタスクのIDを紐付けた順に取得する
+func (r *GroupTaskResolver) ListGroupTaskIDs(ctx context.Context, groupID string) ([]string, error) {
+	query := `
+		SELECT task_id
+		FROM ` + "`Yotei-Plus`" + `.group_tasks
+		WHERE group_id = ?
+		ORDER BY created_at ASC, task_id ASC
+	`
+
+	return r.queryIDs(query, groupID)
+}
+
+// IsGroupMember はユーザーがグループのメンバーかを確認する
+func (r *GroupTaskResolver) IsGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	query := `
+		SELECT user_id
+		FROM ` + "`Yotei-Plus`" + `.group_members
+		WHERE group_id = ? AND user_id = ?
+		LIMIT 1
+	`
+
+	ids, err := r.queryIDs(query, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	return len(ids) > 0, nil
+}
+
+// IsProjectGroup はグループがプロジェクトグループかを確認する
+func (r *GroupTaskResolver) IsProjectGroup(ctx context.Context, groupID string) (bool, error) {
+	query := `
+		SELECT id
+		FROM ` + "`Yotei-Plus`" + `.groups
+		WHERE id = ? AND type = 'PROJECT'
+		LIMIT 1
+	`
+
+	ids, err := r.queryIDs(query, groupID)
+	if err != nil {
+		return false, err
+	}
+	return len(ids) > 0, nil
+}
+
+// AddTaskToGroup はタスクをグループタスクとして紐付ける
+func (r *GroupTaskResolver) AddTaskToGroup(ctx context.Context, taskID, groupID string) error {
+	query := `
+		INSERT IGNORE INTO ` + "`Yotei-Plus`" + `.group_tasks (id, task_id, group_id)
+		VALUES (?, ?, ?)
+	`
+
+	if _, err := r.Execute(query, uuid.New().String(), taskID, groupID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to add task to group",
+			logger.Any("taskID", taskID), logger.Any("groupID", groupID), logger.Error(err))
+		return fmt.Errorf("failed to add task to group: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GroupTaskResolver) queryIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query group relations", logger.Error(err))
+		return nil, fmt.Errorf("failed to query group relations: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// exportChunkSize は担当者をまとめて取得する件数（行を読み進めながらこの件数ごとに送る）
+const exportChunkSize = 500
+
+// TaskExportRepository はエクスポート用のタスクのデータベースリポジトリ実装
+type TaskExportRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewTaskExportRepository は新しいTaskExportRepositoryを作成する
+func NewTaskExportRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.TaskExportRepository {
+	return &TaskExportRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// StreamTasksByUser はユーザーが作成または担当するタスクを作成日時順にチャネルへ送る
+// 結果セットをカーソルで読み進め、exportChunkSize件ごとに担当者を取得して送るため、件数に関わらずメモリ使用量は一定
+func (r *TaskExportRepository) StreamTasksByUser(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+	tasks := make(chan *domain.Task, exportChunkSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		err := r.streamTasks(ctx, userID, tasks)
+		close(tasks)
+		errs <- err
+		close(errs)
+	}()
+
+	return tasks, errs
+}
+
+// streamTasks はタスクを読み進めてチャネルへ送る
+func (r *TaskExportRepository) streamTasks(ctx context.Context, userID string, out chan<- *domain.Task) error {
+	if userID == "" {
+		return usecase.ErrInvalidParameter
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE created_by = ?
+		   OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.Query(query, userID, userID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query tasks for export", logger.Any("userID", userID), logger.Error(err))
+		return fmt.Errorf("failed to query tasks for export: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	// 担当者の取得はタスクのリポジトリの処理を使う（N+1回避のためチャンク単位）
+	assignees := &TaskRepository{SqlHandler: r.SqlHandler, logger: r.logger}
+	chunk := make([]*domain.Task, 0, exportChunkSize)
+	flush := func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := assignees.loadAssignees(chunk); err != nil {
+			return err
+		}
+		for _, task := range chunk {
+			select {
+			case out <- task:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		task, err := scanTaskColumns(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan task for export", logger.Error(err))
+			return fmt.Errorf("failed to scan task: %w", err)
+		}
+		chunk = append(chunk, task)
+		if len(chunk) == exportChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// InboundEmailRepository はタスク作成用のメールアドレスと受信したメールの記録のデータベースリポジトリ実装
+type InboundEmailRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewInboundEmailRepository は新しいInboundEmailRepositoryを作成する
+func NewInboundEmailRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.InboundEmailRepository {
+	return &InboundEmailRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const inboundAddressColumns = `user_id, token, allowed_senders, created_at, updated_at`
+
+// SaveInboundAddress はユーザーのアドレスを保存する（作成済みの場合は置き換える）
+func (r *InboundEmailRepository) SaveInboundAddress(ctx context.Context, address *domain.InboundAddress) error {
+	senders, err := json.Marshal(address.AllowedSenders)
+	if err != nil {
+		return fmt.Errorf("failed to encode allowed senders: %w", err)
+	}
+
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.inbound_email_addresses (` + inboundAddressColumns + `)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			token = VALUES(token),
+			allowed_senders = VALUES(allowed_senders),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err = r.Execute(query,
+		address.UserID,
+		address.Token,
+		string(senders),
+		address.CreatedAt,
+		address.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save inbound address", logger.Any("userID", address.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save inbound address: %w", err)
+	}
+
+	return nil
+}
+
+// GetInboundAddress はユーザーのアドレスを取得する（未作成の場合は nil）
+func (r *InboundEmailRepository) GetInboundAddress(ctx context.Context, userID string) (*domain.InboundAddress, error) {
+	query := `
+		SELECT ` + inboundAddressColumns + `
+		FROM ` + "`Yotei-Plus`" + `.inbound_email_addresses
+		WHERE user_id = ?
+	`
+
+	return r.queryInboundAddress(ctx, query, userID)
+}
+
+// GetInboundAddressByToken はアドレスのトークンでアドレスを取得する（存在しない場合は nil）
+func (r *InboundEmailRepository) GetInboundAddressByToken(ctx context.Context, token string) (*domain.InboundAddress, error) {
+	query := `
+		SELECT ` + inboundAddressColumns + `
+		FROM ` + "`Yotei-Plus`" + `.inbound_email_addresses
+		WHERE token = ?
+	`
+
+	return r.queryInboundAddress(ctx, query, token)
+}
+
+// DeleteInboundAddress はユーザーのアドレスを削除する
+func (r *InboundEmailRepository) DeleteInboundAddress(ctx context.Context, userID string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.inbound_email_addresses WHERE user_id = ?`
+
+	if _, err := r.Execute(query, userID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete inbound address", logger.Any("userID", userID), logger.Error(err))
+		return fmt.Errorf("failed to delete inbound address: %w", err)
+	}
+
+	return nil
+}
+
+// CreateInboundEmail はメールから作成したタスクを記録する
+func (r *InboundEmailRepository) CreateInboundEmail(ctx context.Context, record *domain.InboundEmailRecord) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.inbound_emails (id, user_id, message_id, sender, task_id, received_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		record.ID,
+		record.UserID,
+		record.MessageID,
+		record.Sender,
+		record.TaskID,
+		record.ReceivedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create inbound email", logger.Any("userID", record.UserID), logger.Error(err))
+		return fmt.Errorf("failed to create inbound email: %w", err)
+	}
+
+	return nil
+}
+
+// ExistsInboundEmail はユーザー宛ての同じMessage-IDのメールからタスクを作成済みか
+func (r *InboundEmailRepository) ExistsInboundEmail(ctx context.Context, userID, messageID string) (bool, error) {
+	count, err := countRows(r.SqlHandler, `
+		SELECT COUNT(*)
+		FROM `+"`Yotei-Plus`"+`.inbound_emails
+		WHERE user_id = ? AND message_id = ?
+	`, userID, messageID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to check inbound email", logger.Any("userID", userID), logger.Error(err))
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CountInboundEmailsSince は since 以降にユーザー宛てのメールから作成したタスクの数を返す
+func (r *InboundEmailRepository) CountInboundEmailsSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	count, err := countRows(r.SqlHandler, `
+		SELECT COUNT(*)
+		FROM `+"`Yotei-Plus`"+`.inbound_emails
+		WHERE user_id = ? AND received_at >= ?
+	`, userID, since)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to count inbound emails", logger.Any("userID", userID), logger.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// queryInboundAddress はアドレスを1件取得する共通処理（存在しない場合は nil）
+func (r *InboundEmailRepository) queryInboundAddress(ctx context.Context, query string, args ...interface{}) (*domain.InboundAddress, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query inbound address", logger.Error(err))
+		return nil, fmt.Errorf("failed to query inbound address: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+	var address domain.InboundAddress
+	var senders string
+	if err := rows.Scan(
+		&address.UserID,
+		&address.Token,
+		&senders,
+		&address.CreatedAt,
+		&address.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan inbound address: %w", err)
+	}
+	if err := json.Unmarshal([]byte(senders), &address.AllowedSenders); err != nil {
+		return nil, fmt.Errorf("failed to decode allowed senders: %w", err)
+	}
+	return &address, nil
+}
@@ -0,0 +1,242 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// IssueLinkRepository はタスクとIssue・Pull Requestの関連付けとGitHubのトークンのデータベースリポジトリ実装
+type IssueLinkRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewIssueLinkRepository は新しいIssueLinkRepositoryを作成する
+func NewIssueLinkRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.IssueLinkRepository {
+	return &IssueLinkRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const (
+	issueLinkColumns     = `id, task_id, provider, repository, number, kind, title, state, url, complete_on_close, created_by, created_at, updated_at`
+	githubAccountColumns = `user_id, login, token, created_at, updated_at`
+)
+
+// CreateIssueLink はタスクとIssue・Pull Requestの関連付けを作成する
+func (r *IssueLinkRepository) CreateIssueLink(ctx context.Context, link *domain.IssueLink) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_issue_links (` + issueLinkColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		link.ID,
+		link.TaskID,
+		link.Provider,
+		link.Repository,
+		link.Number,
+		link.Kind,
+		link.Title,
+		link.State,
+		link.URL,
+		link.CompleteOnClose,
+		link.CreatedBy,
+		link.CreatedAt,
+		link.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create issue link", logger.Any("taskID", link.TaskID), logger.Error(err))
+		return fmt.Errorf("failed to create issue link: %w", err)
+	}
+
+	return nil
+}
+
+// GetIssueLink はIDで関連付けを取得する（存在しない場合は nil）
+func (r *IssueLinkRepository) GetIssueLink(ctx context.Context, id string) (*domain.IssueLink, error) {
+	query := `
+		SELECT ` + issueLinkColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_issue_links
+		WHERE id = ?
+	`
+
+	links, err := r.queryIssueLinks(query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	return links[0], nil
+}
+
+// ListIssueLinksByTask はタスクの関連付けを登録した順に取得する
+func (r *IssueLinkRepository) ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error) {
+	query := `
+		SELECT ` + issueLinkColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_issue_links
+		WHERE task_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryIssueLinks(query, taskID)
+}
+
+// ListIssueLinksByIssue はIssue・Pull Requestを関連付けたすべてのタスクの関連付けを取得する
+func (r *IssueLinkRepository) ListIssueLinksByIssue(ctx context.Context, provider domain.IssueProvider, ref domain.IssueRef) ([]*domain.IssueLink, error) {
+	query := `
+		SELECT ` + issueLinkColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_issue_links
+		WHERE provider = ? AND repository = ? AND number = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryIssueLinks(query, provider, ref.Repository, ref.Number)
+}
+
+// UpdateIssueLink は関連付けの種類・タイトル・状態・URLを更新する
+func (r *IssueLinkRepository) UpdateIssueLink(ctx context.Context, link *domain.IssueLink) error {
+	query := `
+		UPDATE ` + "`Yotei-Plus`" + `.task_issue_links
+		SET kind = ?, title = ?, state = ?, url = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.Execute(query, link.Kind, link.Title, link.State, link.URL, link.UpdatedAt, link.ID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update issue link", logger.Any("linkID", link.ID), logger.Error(err))
+		return fmt.Errorf("failed to update issue link: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteIssueLink は関連付けを削除する
+func (r *IssueLinkRepository) DeleteIssueLink(ctx context.Context, id string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_issue_links WHERE id = ?`
+
+	if _, err := r.Execute(query, id); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete issue link", logger.Any("linkID", id), logger.Error(err))
+		return fmt.Errorf("failed to delete issue link: %w", err)
+	}
+
+	return nil
+}
+
+// SaveGitHubAccount はGitHubのトークンを保存する（登録済みの場合は置き換える）
+func (r *IssueLinkRepository) SaveGitHubAccount(ctx context.Context, account *domain.GitHubAccount) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.github_accounts (` + githubAccountColumns + `)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			login = VALUES(login),
+			token = VALUES(token),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.Execute(query,
+		account.UserID,
+		account.Login,
+		account.Token,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save github account", logger.Any("userID", account.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save github account: %w", err)
+	}
+
+	return nil
+}
+
+// GetGitHubAccount はユーザーのGitHubのトークンを取得する（未登録の場合は nil）
+func (r *IssueLinkRepository) GetGitHubAccount(ctx context.Context, userID string) (*domain.GitHubAccount, error) {
+	query := `
+		SELECT ` + githubAccountColumns + `
+		FROM ` + "`Yotei-Plus`" + `.github_accounts
+		WHERE user_id = ?
+	`
+
+	rows, err := r.Query(query, userID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query github account", logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to query github account: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+	var account domain.GitHubAccount
+	if err := rows.Scan(
+		&account.UserID,
+		&account.Login,
+		&account.Token,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan github account: %w", err)
+	}
+	return &account, nil
+}
+
+// DeleteGitHubAccount はユーザーのGitHubのトークンを削除する
+func (r *IssueLinkRepository) DeleteGitHubAccount(ctx context.Context, userID string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.github_accounts WHERE user_id = ?`
+
+	if _, err := r.Execute(query, userID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete github account", logger.Any("userID", userID), logger.Error(err))
+		return fmt.Errorf("failed to delete github account: %w", err)
+	}
+
+	return nil
+}
+
+// queryIssueLinks は関連付けの一覧を取得する共通処理
+func (r *IssueLinkRepository) queryIssueLinks(query string, args ...interface{}) ([]*domain.IssueLink, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query issue links", logger.Error(err))
+		return nil, fmt.Errorf("failed to query issue links: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	links := []*domain.IssueLink{}
+	for rows.Next() {
+		var link domain.IssueLink
+		err := rows.Scan(
+			&link.ID,
+			&link.TaskID,
+			&link.Provider,
+			&link.Repository,
+			&link.Number,
+			&link.Kind,
+			&link.Title,
+			&link.State,
+			&link.URL,
+			&link.CompleteOnClose,
+			&link.CreatedBy,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue link: %w", err)
+		}
+		links = append(links, &link)
+	}
+
+	return links, nil
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// LinkPreviewRepository はリンクのプレビューのキャッシュのデータベースリポジトリ実装
+// URLは長いため、URLのSHA-256を主キーにする
+type LinkPreviewRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewLinkPreviewRepository は新しいLinkPreviewRepositoryを作成する
+func NewLinkPreviewRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.LinkPreviewRepository {
+	return &LinkPreviewRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const linkPreviewColumns = `url_hash, url, title, description, image_url, site_name, status, fetched_at`
+
+// GetLinkPreviews はURLのプレビューを取得する（キャッシュにないURLは含まれない）
+func (r *LinkPreviewRepository) GetLinkPreviews(ctx context.Context, urls []string) (map[string]*domain.LinkPreview, error) {
+	previews := make(map[string]*domain.LinkPreview, len(urls))
+	if len(urls) == 0 {
+		return previews, nil
+	}
+
+	placeholders := make([]string, len(urls))
+	args := make([]interface{}, len(urls))
+	for i, url := range urls {
+		placeholders[i] = "?"
+		args[i] = linkPreviewURLHash(url)
+	}
+	query := `
+		SELECT url, title, description, image_url, site_name, status, fetched_at
+		FROM ` + "`Yotei-Plus`" + `.link_previews
+		WHERE url_hash IN (` + strings.Join(placeholders, ", ") + `)
+	`
+
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query link previews", logger.Error(err))
+		return nil, fmt.Errorf("failed to query link previews: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	for rows.Next() {
+		var preview domain.LinkPreview
+		err := rows.Scan(
+			&preview.URL,
+			&preview.Title,
+			&preview.Description,
+			&preview.ImageURL,
+			&preview.SiteName,
+			&preview.Status,
+			&preview.FetchedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan link preview: %w", err)
+		}
+		previews[preview.URL] = &preview
+	}
+
+	return previews, nil
+}
+
+// SaveLinkPreview はプレビューを保存する（同じURLのプレビューは置き換える）
+func (r *LinkPreviewRepository) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.link_previews (` + linkPreviewColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			title = VALUES(title),
+			description = VALUES(description),
+			image_url = VALUES(image_url),
+			site_name = VALUES(site_name),
+			status = VALUES(status),
+			fetched_at = VALUES(fetched_at)
+	`
+
+	_, err := r.Execute(query,
+		linkPreviewURLHash(preview.URL),
+		preview.URL,
+		preview.Title,
+		preview.Description,
+		preview.ImageURL,
+		preview.SiteName,
+		preview.Status,
+		preview.FetchedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save link preview", logger.Any("url", preview.URL), logger.Error(err))
+		return fmt.Errorf("failed to save link preview: %w", err)
+	}
+
+	return nil
+}
+
+// linkPreviewURLHash はURLのSHA-256を16進数で返す
+func linkPreviewURLHash(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
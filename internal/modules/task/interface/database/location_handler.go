@@ -0,0 +1,302 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// LocationRepository はタスクの場所とジオフェンスのデータベースリポジトリ実装
+type LocationRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewLocationRepository は新しいLocationRepositoryを作成する
+func NewLocationRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.LocationRepository {
+	return &LocationRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const (
+	taskLocationColumns = `task_id, name, latitude, longitude, updated_by, updated_at`
+	geofenceColumns     = `id, user_id, task_id, radius_meters, created_at, last_triggered_at`
+)
+
+// SetTaskLocation はタスクの場所を設定する（設定済みの場合は置き換える）
+func (r *LocationRepository) SetTaskLocation(ctx context.Context, location *domain.TaskLocation) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_locations (` + taskLocationColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			name = VALUES(name),
+			latitude = VALUES(latitude),
+			longitude = VALUES(longitude),
+			updated_by = VALUES(updated_by),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.Execute(query,
+		location.TaskID,
+		location.Location.Name,
+		location.Location.Latitude,
+		location.Location.Longitude,
+		location.UpdatedBy,
+		location.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to set task location", logger.Any("taskID", location.TaskID), logger.Error(err))
+		return fmt.Errorf("failed to set task location: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaskLocation はタスクの場所を取得する（未設定の場合は nil）
+func (r *LocationRepository) GetTaskLocation(ctx context.Context, taskID string) (*domain.TaskLocation, error) {
+	query := `
+		SELECT ` + taskLocationColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_locations
+		WHERE task_id = ?
+	`
+
+	locations, err := r.queryTaskLocations(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, nil
+	}
+	return locations[0], nil
+}
+
+// GetTaskLocations は複数のタスクの場所をタスクIDごとに取得する（未設定のタスクは含めない）
+func (r *LocationRepository) GetTaskLocations(ctx context.Context, taskIDs []string) (map[string]*domain.TaskLocation, error) {
+	result := make(map[string]*domain.TaskLocation, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(taskIDs))
+	args := make([]interface{}, len(taskIDs))
+	for i, taskID := range taskIDs {
+		placeholders[i] = "?"
+		args[i] = taskID
+	}
+
+	query := `
+		SELECT ` + taskLocationColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_locations
+		WHERE task_id IN (` + strings.Join(placeholders, ", ") + `)
+	`
+
+	locations, err := r.queryTaskLocations(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, location := range locations {
+		result[location.TaskID] = location
+	}
+	return result, nil
+}
+
+// DeleteTaskLocation はタスクの場所を削除する
+func (r *LocationRepository) DeleteTaskLocation(ctx context.Context, taskID string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_locations WHERE task_id = ?`
+
+	if _, err := r.Execute(query, taskID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete task location", logger.Any("taskID", taskID), logger.Error(err))
+		return fmt.Errorf("failed to delete task location: %w", err)
+	}
+
+	return nil
+}
+
+// ListTaskLocationsInBounds は緯度・経度の範囲内にあるタスクの場所をタスクID順に取得する
+func (r *LocationRepository) ListTaskLocationsInBounds(ctx context.Context, bounds domain.GeoBounds) ([]*domain.TaskLocation, error) {
+	query := `
+		SELECT ` + taskLocationColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_locations
+		WHERE latitude BETWEEN ? AND ?
+		  AND longitude BETWEEN ? AND ?
+		ORDER BY task_id ASC
+	`
+
+	return r.queryTaskLocations(query, bounds.MinLatitude, bounds.MaxLatitude, bounds.MinLongitude, bounds.MaxLongitude)
+}
+
+// CreateGeofence はジオフェンスを作成する
+func (r *LocationRepository) CreateGeofence(ctx context.Context, geofence *domain.Geofence) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_geofences (` + geofenceColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		geofence.ID,
+		geofence.UserID,
+		geofence.TaskID,
+		geofence.RadiusMeters,
+		geofence.CreatedAt,
+		geofence.LastTriggeredAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create geofence", logger.Any("geofenceID", geofence.ID), logger.Error(err))
+		return fmt.Errorf("failed to create geofence: %w", err)
+	}
+
+	return nil
+}
+
+// GetGeofence はIDでジオフェンスを取得する（存在しない場合は nil）
+func (r *LocationRepository) GetGeofence(ctx context.Context, id string) (*domain.Geofence, error) {
+	query := `
+		SELECT ` + geofenceColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_geofences
+		WHERE id = ?
+	`
+
+	geofences, err := r.queryGeofences(query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(geofences) == 0 {
+		return nil, nil
+	}
+	return geofences[0], nil
+}
+
+// ListGeofencesByUser はユーザーのジオフェンスを登録した順に取得する
+func (r *LocationRepository) ListGeofencesByUser(ctx context.Context, userID string) ([]*domain.Geofence, error) {
+	query := `
+		SELECT ` + geofenceColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_geofences
+		WHERE user_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryGeofences(query, userID)
+}
+
+// DeleteGeofence はジオフェンスを削除する
+func (r *LocationRepository) DeleteGeofence(ctx context.Context, id string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_geofences WHERE id = ?`
+
+	result, err := r.Execute(query, id)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete geofence", logger.Any("geofenceID", id), logger.Error(err))
+		return fmt.Errorf("failed to delete geofence: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrGeofenceNotFound
+	}
+
+	return nil
+}
+
+// DeleteGeofencesByTask はタスクのジオフェンスをすべて削除する
+func (r *LocationRepository) DeleteGeofencesByTask(ctx context.Context, taskID string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_geofences WHERE task_id = ?`
+
+	if _, err := r.Execute(query, taskID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete task geofences", logger.Any("taskID", taskID), logger.Error(err))
+		return fmt.Errorf("failed to delete task geofences: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGeofenceTriggeredAt はジオフェンスのリマインダーを最後に送った日時を更新する
+func (r *LocationRepository) UpdateGeofenceTriggeredAt(ctx context.Context, id string, triggeredAt time.Time) error {
+	query := `UPDATE ` + "`Yotei-Plus`" + `.task_geofences SET last_triggered_at = ? WHERE id = ?`
+
+	if _, err := r.Execute(query, triggeredAt, id); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update geofence", logger.Any("geofenceID", id), logger.Error(err))
+		return fmt.Errorf("failed to update geofence: %w", err)
+	}
+
+	return nil
+}
+
+// queryTaskLocations はタスクの場所の一覧を取得する共通処理
+func (r *LocationRepository) queryTaskLocations(query string, args ...interface{}) ([]*domain.TaskLocation, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query task locations", logger.Error(err))
+		return nil, fmt.Errorf("failed to query task locations: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var locations []*domain.TaskLocation
+	for rows.Next() {
+		var location domain.TaskLocation
+		err := rows.Scan(
+			&location.TaskID,
+			&location.Location.Name,
+			&location.Location.Latitude,
+			&location.Location.Longitude,
+			&location.UpdatedBy,
+			&location.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task location: %w", err)
+		}
+		locations = append(locations, &location)
+	}
+
+	return locations, nil
+}
+
+// queryGeofences はジオフェンスの一覧を取得する共通処理
+func (r *LocationRepository) queryGeofences(query string, args ...interface{}) ([]*domain.Geofence, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query geofences", logger.Error(err))
+		return nil, fmt.Errorf("failed to query geofences: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	geofences := []*domain.Geofence{}
+	for rows.Next() {
+		var geofence domain.Geofence
+		var lastTriggeredAt sql.NullTime
+		err := rows.Scan(
+			&geofence.ID,
+			&geofence.UserID,
+			&geofence.TaskID,
+			&geofence.RadiusMeters,
+			&geofence.CreatedAt,
+			&lastTriggeredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan geofence: %w", err)
+		}
+		if lastTriggeredAt.Valid {
+			geofence.LastTriggeredAt = &lastTriggeredAt.Time
+		}
+		geofences = append(geofences, &geofence)
+	}
+
+	return geofences, nil
+}
@@ -0,0 +1,422 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// CommentRepository はタスクコメントのデータベースリポジトリ実装
+type CommentRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewCommentRepository は新しいCommentRepositoryを作成する
+func NewCommentRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.CommentRepository {
+	return &CommentRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// CreateComment はコメントを保存する
+func (r *CommentRepository) CreateComment(ctx context.Context, comment *domain.TaskComment) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_comments (id, task_id, user_id, comment, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		comment.ID,
+		comment.TaskID,
+		comment.UserID,
+		comment.Comment,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create comment", logger.Any("taskID", comment.TaskID), logger.Error(err))
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
+
+// ListComments はタスクのコメントを古い順に取得する
+func (r *CommentRepository) ListComments(ctx context.Context, taskID string, pagination domain.Pagination) ([]*domain.TaskComment, int, error) {
+	total, err := countRows(r.SqlHandler, `
+		SELECT COUNT(*)
+		FROM `+"`Yotei-Plus`"+`.task_comments
+		WHERE task_id = ?
+	`, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, task_id, user_id, comment, created_at, updated_at
+		FROM ` + "`Yotei-Plus`" + `.task_comments
+		WHERE task_id = ?
+		ORDER BY created_at ASC, id ASC
+		LIMIT ? OFFSET ?
+	`
+
+	offset := (pagination.Page - 1) * pagination.PageSize
+	comments, err := r.queryComments(ctx, query, taskID, pagination.PageSize, offset)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query comments", logger.Any("taskID", taskID), logger.Error(err))
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// ListCommentsByUser はユーザーが書いたコメントを古い順に全件取得する（データのエクスポート用）
+func (r *CommentRepository) ListCommentsByUser(ctx context.Context, userID string) ([]*domain.TaskComment, error) {
+	query := `
+		SELECT id, task_id, user_id, comment, created_at, updated_at
+		FROM ` + "`Yotei-Plus`" + `.task_comments
+		WHERE user_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	comments, err := r.queryComments(ctx, query, userID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query comments by user", logger.Any("userID", userID), logger.Error(err))
+		return nil, err
+	}
+	return comments, nil
+}
+
+// queryComments はコメントを取得するクエリを実行する
+func (r *CommentRepository) queryComments(ctx context.Context, query string, args ...interface{}) ([]*domain.TaskComment, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	comments := []*domain.TaskComment{}
+	for rows.Next() {
+		var comment domain.TaskComment
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.TaskID,
+			&comment.UserID,
+			&comment.Comment,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	return comments, nil
+}
+
+// MentionRepository はメンション記録のデータベースリポジトリ実装
+type MentionRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewMentionRepository は新しいMentionRepositoryを作成する
+func NewMentionRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.MentionRepository {
+	return &MentionRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// SaveMentions はメンションを保存する（同じメンション元・ユーザーの記録は無視する）
+func (r *MentionRepository) SaveMentions(ctx context.Context, mentions []*domain.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(mentions))
+	args := make([]interface{}, 0, len(mentions)*7)
+	for _, mention := range mentions {
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			mention.ID,
+			mention.TaskID,
+			string(mention.SourceType),
+			mention.SourceID,
+			mention.MentionedUserID,
+			mention.AuthorID,
+			mention.CreatedAt,
+		)
+	}
+
+	query := `
+		INSERT IGNORE INTO ` + "`Yotei-Plus`" + `.task_mentions
+			(id, task_id, source_type, source_id, mentioned_user_id, author_id, created_at)
+		VALUES ` + strings.Join(values, ", ")
+
+	if _, err := r.Execute(query, args...); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save mentions", logger.Error(err))
+		return fmt.Errorf("failed to save mentions: %w", err)
+	}
+
+	return nil
+}
+
+// ListMentionsForUser はユーザーへのメンションを新しい順に取得する
+func (r *MentionRepository) ListMentionsForUser(ctx context.Context, userID string, pagination domain.Pagination) ([]*domain.Mention, int, error) {
+	total, err := countRows(r.SqlHandler, `
+		SELECT COUNT(*)
+		FROM `+"`Yotei-Plus`"+`.task_mentions
+		WHERE mentioned_user_id = ?
+	`, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, task_id, source_type, source_id, mentioned_user_id, author_id, created_at
+		FROM ` + "`Yotei-Plus`" + `.task_mentions
+		WHERE mentioned_user_id = ?
+		ORDER BY created_at DESC, id ASC
+		LIMIT ? OFFSET ?
+	`
+
+	offset := (pagination.Page - 1) * pagination.PageSize
+	rows, err := r.Query(query, userID, pagination.PageSize, offset)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query mentions", logger.Any("userID", userID), logger.Error(err))
+		return nil, 0, fmt.Errorf("failed to query mentions: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	mentions := []*domain.Mention{}
+	for rows.Next() {
+		var mention domain.Mention
+		var sourceType string
+		if err := rows.Scan(
+			&mention.ID,
+			&mention.TaskID,
+			&sourceType,
+			&mention.SourceID,
+			&mention.MentionedUserID,
+			&mention.AuthorID,
+			&mention.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan mention: %w", err)
+		}
+		mention.SourceType = domain.MentionSourceType(sourceType)
+		mentions = append(mentions, &mention)
+	}
+
+	return mentions, total, nil
+}
+
+// MentionDirectory はユーザー・友達・グループのテーブルからメンション先を解決する実装
+type MentionDirectory struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewMentionDirectory は新しいMentionDirectoryを作成する
+func NewMentionDirectory(sqlHandler SqlHandler, logger logger.Logger) usecase.MentionDirectory {
+	return &MentionDirectory{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// ResolveUsernames はユーザー名（大文字小文字を区別しない）からユーザーIDを解決する
+// 現在のユーザーに一致しないユーザー名は、変更前のユーザー名として解決する
+func (d *MentionDirectory) ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(usernames) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(usernames))
+	args := make([]interface{}, len(usernames))
+	for i, username := range usernames {
+		placeholders[i] = "?"
+		args[i] = username
+	}
+
+	query := `
+		SELECT id, username
+		FROM ` + "`Yotei-Plus`" + `.users
+		WHERE username IN (` + strings.Join(placeholders, ", ") + `)
+	`
+
+	rows, err := d.Query(query, args...)
+	if err != nil {
+		d.logger.WithContext(ctx).Error("Failed to resolve usernames", logger.Error(err))
+		return nil, fmt.Errorf("failed to resolve usernames: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			d.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	for rows.Next() {
+		var id, username string
+		if err := rows.Scan(&id, &username); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		result[strings.ToLower(username)] = id
+	}
+
+	if err := d.resolvePreviousUsernames(ctx, usernames, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resolvePreviousUsernames は現在のユーザーに一致しないユーザー名を、変更履歴から最後にそのユーザー名を使っていたユーザーに解決する
+func (d *MentionDirectory) resolvePreviousUsernames(ctx context.Context, usernames []string, result map[string]string) error {
+	var placeholders []string
+	var args []interface{}
+	for _, username := range usernames {
+		key := strings.ToLower(username)
+		if _, ok := result[key]; ok {
+			continue
+		}
+		placeholders = append(placeholders, "?")
+		args = append(args, key)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT h.user_id, h.old_username
+		FROM ` + "`Yotei-Plus`" + `.username_history h
+		JOIN ` + "`Yotei-Plus`" + `.users u ON u.id = h.user_id
+		WHERE LOWER(h.old_username) IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY h.changed_at DESC
+	`
+
+	rows, err := d.Query(query, args...)
+	if err != nil {
+		d.logger.WithContext(ctx).Error("Failed to resolve previous usernames", logger.Error(err))
+		return fmt.Errorf("failed to resolve previous usernames: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			d.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	for rows.Next() {
+		var id, username string
+		if err := rows.Scan(&id, &username); err != nil {
+			return fmt.Errorf("failed to scan username history: %w", err)
+		}
+		// 新しい順に並べているため、最初に見つかった履歴を使う
+		if key := strings.ToLower(username); result[key] == "" {
+			result[key] = id
+		}
+	}
+	return nil
+}
+
+// FilterVisibleUsers は作成者と承認済みの友達、または同じグループに所属するユーザーのみを返す
+// 作成者とブロック関係にあるユーザーは除外する
+func (d *MentionDirectory) FilterVisibleUsers(ctx context.Context, authorID string, userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return []string{}, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, 0, len(userIDs)+5)
+	for i, userID := range userIDs {
+		placeholders[i] = "?"
+		args = append(args, userID)
+	}
+	args = append(args, authorID, authorID, authorID, authorID, authorID)
+
+	query := `
+		SELECT u.id
+		FROM ` + "`Yotei-Plus`" + `.users u
+		WHERE u.id IN (` + strings.Join(placeholders, ", ") + `)
+		  AND (
+			EXISTS (
+				SELECT 1 FROM ` + "`Yotei-Plus`" + `.friendships f
+				WHERE f.status = 'ACCEPTED'
+				  AND ((f.requester_id = ? AND f.addressee_id = u.id)
+				    OR (f.addressee_id = ? AND f.requester_id = u.id))
+			)
+			OR EXISTS (
+				SELECT 1
+				FROM ` + "`Yotei-Plus`" + `.group_members gm1
+				JOIN ` + "`Yotei-Plus`" + `.group_members gm2 ON gm1.group_id = gm2.group_id
+				WHERE gm1.user_id = ? AND gm2.user_id = u.id
+			)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM ` + "`Yotei-Plus`" + `.friendships b
+			WHERE b.status = 'BLOCKED'
+			  AND ((b.requester_id = ? AND b.addressee_id = u.id)
+			    OR (b.addressee_id = ? AND b.requester_id = u.id))
+		  )
+	`
+
+	rows, err := d.Query(query, args...)
+	if err != nil {
+		d.logger.WithContext(ctx).Error("Failed to filter visible users", logger.Any("authorID", authorID), logger.Error(err))
+		return nil, fmt.Errorf("failed to filter visible users: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			d.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	visible := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		visible[id] = true
+	}
+
+	// 入力順を維持する
+	result := make([]string, 0, len(visible))
+	for _, userID := range userIDs {
+		if visible[userID] {
+			result = append(result, userID)
+		}
+	}
+	return result, nil
+}
+
+// countRows はCOUNTクエリを実行して件数を返す
+func countRows(handler SqlHandler, query string, args ...interface{}) (int, error) {
+	rows, err := handler.Query(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	defer rows.Close()
+
+	var count int
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to scan count: %w", err)
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// MilestoneRepository はマイルストーンのデータベースリポジトリ実装
+type MilestoneRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewMilestoneRepository は新しいMilestoneRepositoryを作成する
+func NewMilestoneRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.MilestoneRepository {
+	return &MilestoneRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const milestoneColumns = `id, group_id, name, due_date, position, created_by, created_at, updated_at`
+
+// CreateMilestone はマイルストーンを作成する
+func (r *MilestoneRepository) CreateMilestone(ctx context.Context, milestone *domain.Milestone) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_milestones (` + milestoneColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		milestone.ID,
+		milestone.GroupID,
+		milestone.Name,
+		milestone.DueDate,
+		milestone.Position,
+		milestone.CreatedBy,
+		milestone.CreatedAt,
+		milestone.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create milestone", logger.Any("milestoneID", milestone.ID), logger.Error(err))
+		return fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return nil
+}
+
+// GetMilestoneByID はIDによりマイルストーンを取得する
+func (r *MilestoneRepository) GetMilestoneByID(ctx context.Context, id string) (*domain.Milestone, error) {
+	if id == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	query := `
+		SELECT ` + milestoneColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_milestones
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	milestones, err := r.queryMilestones(query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(milestones) == 0 {
+		return nil, usecase.ErrMilestoneNotFound
+	}
+
+	return milestones[0], nil
+}
+
+// ListMilestonesByGroup はグループのマイルストーンを並び順で取得する
+func (r *MilestoneRepository) ListMilestonesByGroup(ctx context.Context, groupID string) ([]*domain.Milestone, error) {
+	query := `
+		SELECT ` + milestoneColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_milestones
+		WHERE group_id = ?
+		ORDER BY position ASC, created_at ASC
+	`
+
+	return r.queryMilestones(query, groupID)
+}
+
+// UpdateMilestone はマイルストーンを更新する
+func (r *MilestoneRepository) UpdateMilestone(ctx context.Context, milestone *domain.Milestone) error {
+	query := `
+		UPDATE ` + "`Yotei-Plus`" + `.task_milestones SET
+			name = ?,
+			due_date = ?,
+			position = ?,
+			updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.Execute(query,
+		milestone.Name,
+		milestone.DueDate,
+		milestone.Position,
+		milestone.UpdatedAt,
+		milestone.ID,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update milestone", logger.Any("milestoneID", milestone.ID), logger.Error(err))
+		return fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrMilestoneNotFound
+	}
+
+	return nil
+}
+
+// DeleteMilestone はマイルストーンを削除する（タスクの紐付けは外部キーで削除される）
+func (r *MilestoneRepository) DeleteMilestone(ctx context.Context, id string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_milestones WHERE id = ?`
+
+	result, err := r.Execute(query, id)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete milestone", logger.Any("milestoneID", id), logger.Error(err))
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrMilestoneNotFound
+	}
+
+	return nil
+}
+
+// SetTaskMilestone はタスクをマイルストーンに紐付ける（既存の紐付けは置き換える）
+func (r *MilestoneRepository) SetTaskMilestone(ctx context.Context, taskID, milestoneID string) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.milestone_tasks (task_id, milestone_id)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE milestone_id = VALUES(milestone_id)
+	`
+
+	if _, err := r.Execute(query, taskID, milestoneID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to attach task to milestone",
+			logger.Any("taskID", taskID), logger.Any("milestoneID", milestoneID), logger.Error(err))
+		return fmt.Errorf("failed to attach task to milestone: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveTaskMilestone はタスクとマイルストーンの紐付けを解除する
+func (r *MilestoneRepository) RemoveTaskMilestone(ctx context.Context, taskID string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.milestone_tasks WHERE task_id = ?`
+
+	if _, err := r.Execute(query, taskID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to detach task from milestone", logger.Any("taskID", taskID), logger.Error(err))
+		return fmt.Errorf("failed to detach task from milestone: %w", err)
+	}
+
+	return nil
+}
+
+// GetMilestoneIDForTask はタスクが属するマイルストーンIDを取得する（未設定の場合は空文字）
+func (r *MilestoneRepository) GetMilestoneIDForTask(ctx context.Context, taskID string) (string, error) {
+	query := `
+		SELECT milestone_id
+		FROM ` + "`Yotei-Plus`" + `.milestone_tasks
+		WHERE task_id = ?
+		LIMIT 1
+	`
+
+	ids, err := r.queryIDs(query, taskID)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
+// ListMilestoneTaskIDs はマイルストーンに紐付いたタスクIDを取得する
+func (r *MilestoneRepository) ListMilestoneTaskIDs(ctx context.Context, milestoneID string) ([]string, error) {
+	query := `
+		SELECT task_id
+		FROM ` + "`Yotei-Plus`" + `.milestone_tasks
+		WHERE milestone_id = ?
+		ORDER BY task_id ASC
+	`
+
+	return r.queryIDs(query, milestoneID)
+}
+
+// queryMilestones はマイルストーン一覧を取得する共通処理
+func (r *MilestoneRepository) queryMilestones(query string, args ...interface{}) ([]*domain.Milestone, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query milestones", logger.Error(err))
+		return nil, fmt.Errorf("failed to query milestones: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var milestones []*domain.Milestone
+	for rows.Next() {
+		var milestone domain.Milestone
+		err := rows.Scan(
+			&milestone.ID,
+			&milestone.GroupID,
+			&milestone.Name,
+			&milestone.DueDate,
+			&milestone.Position,
+			&milestone.CreatedBy,
+			&milestone.CreatedAt,
+			&milestone.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+		milestones = append(milestones, &milestone)
+	}
+
+	return milestones, nil
+}
+
+func (r *MilestoneRepository) queryIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query milestone tasks", logger.Error(err))
+		return nil, fmt.Errorf("failed to query milestone tasks: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// DeferredReminderRepository は勤務時間外のため保留した通知のデータベースリポジトリ実装
+type DeferredReminderRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewDeferredReminderRepository は新しいDeferredReminderRepositoryを作成する
+func NewDeferredReminderRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.DeferredReminderRepository {
+	return &DeferredReminderRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const deferredReminderColumns = `id, user_id, task_id, task_title, kind, deliver_at, created_at`
+
+// SaveDeferredReminder は通知を保留する（同じユーザー・タスク・種類の通知は置き換える）
+func (r *DeferredReminderRepository) SaveDeferredReminder(ctx context.Context, reminder *domain.DeferredReminder) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.deferred_reminders (` + deferredReminderColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			task_title = VALUES(task_title),
+			deliver_at = VALUES(deliver_at)
+	`
+
+	_, err := r.Execute(query,
+		reminder.ID,
+		reminder.UserID,
+		reminder.TaskID,
+		reminder.TaskTitle,
+		reminder.Kind,
+		reminder.DeliverAt,
+		reminder.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save deferred reminder",
+			logger.Any("userID", reminder.UserID), logger.Any("taskID", reminder.TaskID), logger.Error(err))
+		return fmt.Errorf("failed to save deferred reminder: %w", err)
+	}
+
+	return nil
+}
+
+// ListDueDeferredReminders は送る日時が until 以前の通知を送る日時の順に取得する
+func (r *DeferredReminderRepository) ListDueDeferredReminders(ctx context.Context, until time.Time, limit int) ([]*domain.DeferredReminder, error) {
+	query := `
+		SELECT ` + deferredReminderColumns + `
+		FROM ` + "`Yotei-Plus`" + `.deferred_reminders
+		WHERE deliver_at <= ?
+		ORDER BY deliver_at ASC, created_at ASC, id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.Query(query, until, limit)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query deferred reminders", logger.Error(err))
+		return nil, fmt.Errorf("failed to query deferred reminders: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	reminders := []*domain.DeferredReminder{}
+	for rows.Next() {
+		var reminder domain.DeferredReminder
+		err := rows.Scan(
+			&reminder.ID,
+			&reminder.UserID,
+			&reminder.TaskID,
+			&reminder.TaskTitle,
+			&reminder.Kind,
+			&reminder.DeliverAt,
+			&reminder.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deferred reminder: %w", err)
+		}
+		reminders = append(reminders, &reminder)
+	}
+
+	return reminders, nil
+}
+
+// DeleteDeferredReminders は送った通知を削除する
+func (r *DeferredReminderRepository) DeleteDeferredReminders(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.deferred_reminders WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+
+	if _, err := r.Execute(query, args...); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete deferred reminders", logger.Any("count", len(ids)), logger.Error(err))
+		return fmt.Errorf("failed to delete deferred reminders: %w", err)
+	}
+
+	return nil
+}
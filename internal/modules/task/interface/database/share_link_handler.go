@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const shareLinkColumns = "id, token, owner_id, target_type, task_id, filter, password_hash, expires_at, revoked_at, created_at"
+
+// ShareLinkRepository は共有リンクのデータベースリポジトリ実装
+type ShareLinkRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewShareLinkRepository は新しいShareLinkRepositoryを作成する
+func NewShareLinkRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.ShareLinkRepository {
+	return &ShareLinkRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// CreateShareLink は共有リンクを保存する
+func (r *ShareLinkRepository) CreateShareLink(ctx context.Context, link *domain.ShareLink) error {
+	var filter sql.NullString
+	if link.Filter != nil {
+		encoded, err := json.Marshal(link.Filter)
+		if err != nil {
+			return fmt.Errorf("failed to encode share link filter: %w", err)
+		}
+		filter = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_share_links (` + shareLinkColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		link.ID,
+		link.Token,
+		link.OwnerID,
+		string(link.TargetType),
+		link.TaskID,
+		filter,
+		link.PasswordHash,
+		link.ExpiresAt,
+		link.RevokedAt,
+		link.CreatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create share link", logger.Any("ownerID", link.OwnerID), logger.Error(err))
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return nil
+}
+
+// GetShareLinkByID はIDで共有リンクを取得する
+func (r *ShareLinkRepository) GetShareLinkByID(ctx context.Context, id string) (*domain.ShareLink, error) {
+	links, err := r.queryShareLinks(`
+		SELECT `+shareLinkColumns+`
+		FROM `+"`Yotei-Plus`"+`.task_share_links
+		WHERE id = ?
+		LIMIT 1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, usecase.ErrShareLinkNotFound
+	}
+	return links[0], nil
+}
+
+// GetShareLinkByToken はトークンで共有リンクを取得する
+func (r *ShareLinkRepository) GetShareLinkByToken(ctx context.Context, token string) (*domain.ShareLink, error) {
+	links, err := r.queryShareLinks(`
+		SELECT `+shareLinkColumns+`
+		FROM `+"`Yotei-Plus`"+`.task_share_links
+		WHERE token = ?
+		LIMIT 1
+	`, token)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, usecase.ErrShareLinkNotFound
+	}
+	return links[0], nil
+}
+
+// ListShareLinksByOwner はユーザーが作成した共有リンクを新しい順に取得する
+func (r *ShareLinkRepository) ListShareLinksByOwner(ctx context.Context, ownerID string) ([]*domain.ShareLink, error) {
+	return r.queryShareLinks(`
+		SELECT `+shareLinkColumns+`
+		FROM `+"`Yotei-Plus`"+`.task_share_links
+		WHERE owner_id = ?
+		ORDER BY created_at DESC
+	`, ownerID)
+}
+
+// CountActiveShareLinks は無効化・期限切れでない共有リンクの数を返す
+func (r *ShareLinkRepository) CountActiveShareLinks(ctx context.Context, ownerID string, now time.Time) (int, error) {
+	return countRows(r.SqlHandler, `
+		SELECT COUNT(*)
+		FROM `+"`Yotei-Plus`"+`.task_share_links
+		WHERE owner_id = ?
+		  AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > ?)
+	`, ownerID, now)
+}
+
+// RevokeShareLink は共有リンクを無効化する
+func (r *ShareLinkRepository) RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `
+		UPDATE ` + "`Yotei-Plus`" + `.task_share_links
+		SET revoked_at = ?
+		WHERE id = ? AND revoked_at IS NULL
+	`
+
+	result, err := r.Execute(query, revokedAt, id)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to revoke share link", logger.Any("shareLinkID", id), logger.Error(err))
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrShareLinkNotFound
+	}
+
+	return nil
+}
+
+func (r *ShareLinkRepository) queryShareLinks(query string, args ...interface{}) ([]*domain.ShareLink, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query share links", logger.Error(err))
+		return nil, fmt.Errorf("failed to query share links: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	links := []*domain.ShareLink{}
+	for rows.Next() {
+		var link domain.ShareLink
+		var targetType string
+		var taskID, filter sql.NullString
+		var expiresAt, revokedAt sql.NullTime
+
+		if err := rows.Scan(
+			&link.ID,
+			&link.Token,
+			&link.OwnerID,
+			&targetType,
+			&taskID,
+			&filter,
+			&link.PasswordHash,
+			&expiresAt,
+			&revokedAt,
+			&link.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+
+		link.TargetType = domain.ShareTargetType(targetType)
+		if taskID.Valid {
+			id := taskID.String
+			link.TaskID = &id
+		}
+		if filter.Valid && filter.String != "" {
+			var f domain.ListFilter
+			if err := json.Unmarshal([]byte(filter.String), &f); err != nil {
+				return nil, fmt.Errorf("failed to decode share link filter: %w", err)
+			}
+			link.Filter = &f
+		}
+		if expiresAt.Valid {
+			t := expiresAt.Time
+			link.ExpiresAt = &t
+		}
+		if revokedAt.Valid {
+			t := revokedAt.Time
+			link.RevokedAt = &t
+		}
+
+		links = append(links, &link)
+	}
+
+	return links, nil
+}
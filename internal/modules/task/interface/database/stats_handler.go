@@ -33,7 +33,7 @@ func (r *TaskStatsRepository) GetTasksByDateRange(ctx context.Context, userID st
 	query := `
 		SELECT id, title, description, status, priority, category, assignee_id, created_by, due_date, created_at, updated_at
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND (
 		    (created_at BETWEEN ? AND ?) OR
 		    (due_date BETWEEN ? AND ?) OR
@@ -44,7 +44,7 @@ func (r *TaskStatsRepository) GetTasksByDateRange(ctx context.Context, userID st
 
 	rows, err := r.Query(query, userID, userID, start, end, start, end, start, end)
 	if err != nil {
-		r.logger.Error("Failed to get tasks by date range",
+		r.logger.WithContext(ctx).Error("Failed to get tasks by date range",
 			logger.Any("userID", userID),
 			logger.Any("start", start),
 			logger.Any("end", end),
@@ -53,7 +53,7 @@ func (r *TaskStatsRepository) GetTasksByDateRange(ctx context.Context, userID st
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -61,13 +61,13 @@ func (r *TaskStatsRepository) GetTasksByDateRange(ctx context.Context, userID st
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan task row in date range query", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan task row in date range query", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Tasks retrieved by date range",
+	r.logger.WithContext(ctx).Debug("Tasks retrieved by date range",
 		logger.Any("userID", userID),
 		logger.Any("count", len(tasks)),
 		logger.Any("start", start),
@@ -89,14 +89,14 @@ func (r *TaskStatsRepository) GetTasksByDueDate(ctx context.Context, userID stri
 	query := `
 		SELECT id, title, description, status, priority, category, assignee_id, created_by, due_date, created_at, updated_at
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND due_date BETWEEN ? AND ?
 		ORDER BY due_date ASC, priority DESC
 	`
 
 	rows, err := r.Query(query, userID, userID, dayStart, dayEnd)
 	if err != nil {
-		r.logger.Error("Failed to get tasks by due date",
+		r.logger.WithContext(ctx).Error("Failed to get tasks by due date",
 			logger.Any("userID", userID),
 			logger.Any("dueDate", dueDate),
 			logger.Error(err))
@@ -104,7 +104,7 @@ func (r *TaskStatsRepository) GetTasksByDueDate(ctx context.Context, userID stri
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -112,13 +112,13 @@ func (r *TaskStatsRepository) GetTasksByDueDate(ctx context.Context, userID stri
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan task row in due date query", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan task row in due date query", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Tasks retrieved by due date",
+	r.logger.WithContext(ctx).Debug("Tasks retrieved by due date",
 		logger.Any("userID", userID),
 		logger.Any("count", len(tasks)),
 		logger.Any("dueDate", dueDate))
@@ -139,7 +139,7 @@ func (r *TaskStatsRepository) GetRecentCompletedTasks(ctx context.Context, userI
 	query := `
 		SELECT id, title, description, status, priority, category, assignee_id, created_by, due_date, created_at, updated_at
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND status = ?
 		ORDER BY updated_at DESC
 		LIMIT ?
@@ -147,7 +147,7 @@ func (r *TaskStatsRepository) GetRecentCompletedTasks(ctx context.Context, userI
 
 	rows, err := r.Query(query, userID, userID, string(domain.TaskStatusDone), limit)
 	if err != nil {
-		r.logger.Error("Failed to get recent completed tasks",
+		r.logger.WithContext(ctx).Error("Failed to get recent completed tasks",
 			logger.Any("userID", userID),
 			logger.Any("limit", limit),
 			logger.Error(err))
@@ -155,7 +155,7 @@ func (r *TaskStatsRepository) GetRecentCompletedTasks(ctx context.Context, userI
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -163,13 +163,60 @@ func (r *TaskStatsRepository) GetRecentCompletedTasks(ctx context.Context, userI
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan task row in recent completed query", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan task row in recent completed query", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Recent completed tasks retrieved",
+	r.logger.WithContext(ctx).Debug("Recent completed tasks retrieved",
+		logger.Any("userID", userID),
+		logger.Any("count", len(tasks)))
+
+	return tasks, nil
+}
+
+// GetCompletedTasksByDateRange は指定期間に完了したタスクを取得する（ベロシティ集計用）
+func (r *TaskStatsRepository) GetCompletedTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
+		  AND status = ?
+		  AND completed_at BETWEEN ? AND ?
+		ORDER BY completed_at ASC
+	`
+
+	rows, err := r.Query(query, userID, userID, string(domain.TaskStatusDone), start, end)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get completed tasks by date range",
+			logger.Any("userID", userID),
+			logger.Any("start", start),
+			logger.Any("end", end),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to query completed tasks: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTaskColumns(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan task row in completed query", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	r.logger.WithContext(ctx).Debug("Completed tasks retrieved by date range",
 		logger.Any("userID", userID),
 		logger.Any("count", len(tasks)))
 
@@ -187,33 +234,33 @@ func (r *TaskStatsRepository) GetOverdueTasksCount(ctx context.Context, userID s
 	query := `
 		SELECT COUNT(*)
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND due_date < ?
 		  AND status != ?
 	`
 
 	row, err := r.Query(query, userID, userID, now, string(domain.TaskStatusDone))
 	if err != nil {
-		r.logger.Error("Failed to get overdue tasks count",
+		r.logger.WithContext(ctx).Error("Failed to get overdue tasks count",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return 0, fmt.Errorf("failed to query overdue tasks count: %w", err)
 	}
 	defer func() {
 		if closeErr := row.Close(); closeErr != nil {
-			r.logger.Error("Failed to close row", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close row", logger.Error(closeErr))
 		}
 	}()
 
 	var count int
 	if row.Next() {
 		if err := row.Scan(&count); err != nil {
-			r.logger.Error("Failed to scan overdue count", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan overdue count", logger.Error(err))
 			return 0, fmt.Errorf("failed to scan overdue count: %w", err)
 		}
 	}
 
-	r.logger.Debug("Overdue tasks count retrieved",
+	r.logger.WithContext(ctx).Debug("Overdue tasks count retrieved",
 		logger.Any("userID", userID),
 		logger.Any("count", count))
 
@@ -229,21 +276,21 @@ func (r *TaskStatsRepository) GetTasksCountByStatus(ctx context.Context, userID
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND created_at BETWEEN ? AND ?
 		GROUP BY status
 	`
 
 	rows, err := r.Query(query, userID, userID, start, end)
 	if err != nil {
-		r.logger.Error("Failed to get tasks count by status",
+		r.logger.WithContext(ctx).Error("Failed to get tasks count by status",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to query tasks count by status: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -253,7 +300,7 @@ func (r *TaskStatsRepository) GetTasksCountByStatus(ctx context.Context, userID
 		var count int
 
 		if err := rows.Scan(&status, &count); err != nil {
-			r.logger.Error("Failed to scan status count", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan status count", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan status count: %w", err)
 		}
 
@@ -272,21 +319,21 @@ func (r *TaskStatsRepository) GetTasksCountByCategory(ctx context.Context, userI
 	query := `
 		SELECT category, COUNT(*) as count
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND created_at BETWEEN ? AND ?
 		GROUP BY category
 	`
 
 	rows, err := r.Query(query, userID, userID, start, end)
 	if err != nil {
-		r.logger.Error("Failed to get tasks count by category",
+		r.logger.WithContext(ctx).Error("Failed to get tasks count by category",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to query tasks count by category: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -296,7 +343,7 @@ func (r *TaskStatsRepository) GetTasksCountByCategory(ctx context.Context, userI
 		var count int
 
 		if err := rows.Scan(&category, &count); err != nil {
-			r.logger.Error("Failed to scan category count", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan category count", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan category count: %w", err)
 		}
 
@@ -315,21 +362,21 @@ func (r *TaskStatsRepository) GetTasksCountByPriority(ctx context.Context, userI
 	query := `
 		SELECT priority, COUNT(*) as count
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE (assignee_id = ? OR created_by = ?)
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
 		  AND status != ?
 		GROUP BY priority
 	`
 
 	rows, err := r.Query(query, userID, userID, string(domain.TaskStatusDone))
 	if err != nil {
-		r.logger.Error("Failed to get tasks count by priority",
+		r.logger.WithContext(ctx).Error("Failed to get tasks count by priority",
 			logger.Any("userID", userID),
 			logger.Error(err))
 		return nil, fmt.Errorf("failed to query tasks count by priority: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -339,7 +386,7 @@ func (r *TaskStatsRepository) GetTasksCountByPriority(ctx context.Context, userI
 		var count int
 
 		if err := rows.Scan(&priority, &count); err != nil {
-			r.logger.Error("Failed to scan priority count", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan priority count", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan priority count: %w", err)
 		}
 
@@ -349,6 +396,51 @@ func (r *TaskStatsRepository) GetTasksCountByPriority(ctx context.Context, userI
 	return counts, nil
 }
 
+// GetScheduledTasksByDateRange は着手予定日時または期限が指定期間内にあるタスクを取得する
+func (r *TaskStatsRepository) GetScheduledTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE (created_by = ? OR id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?))
+		  AND (
+		    (start_date BETWEEN ? AND ?) OR
+		    (due_date BETWEEN ? AND ?)
+		  )
+		ORDER BY due_date ASC, id ASC
+	`
+
+	rows, err := r.Query(query, userID, userID, start, end, start, end)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get scheduled tasks by date range",
+			logger.Any("userID", userID),
+			logger.Any("start", start),
+			logger.Any("end", end),
+			logger.Error(err))
+		return nil, fmt.Errorf("failed to query scheduled tasks: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTaskColumns(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan task row in scheduled query", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
 // scanTaskFromRow は共通のタスクスキャン処理（TaskRepositoryと重複するが統計用に独立させる）
 func (r *TaskStatsRepository) scanTaskFromRow(row Row) (*domain.Task, error) {
 	var task domain.Task
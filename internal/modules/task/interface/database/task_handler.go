@@ -3,10 +3,12 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
 	"github.com/hryt430/Yotei+/internal/modules/task/domain"
 	"github.com/hryt430/Yotei+/internal/modules/task/interface/dto"
 	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
@@ -27,6 +29,13 @@ func NewTaskRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.Task
 	}
 }
 
+// taskColumns はタスク取得時に選択するカラム（scanTaskFromRowの順序と一致させる）
+const taskColumns = `id, title, description, status, priority, category, assignee_id, created_by, due_date, created_at, updated_at,
+			estimate_minutes, estimate_points, actual_minutes, completed_at, require_all_assignees, start_date, checklist`
+
+// tasksTable はタスクのテーブル名
+const tasksTable = "`Yotei-Plus`.tasks"
+
 // SQLインジェクション対策：許可されたソートフィールドの定義
 var allowedSortFields = map[string]string{
 	"created_at": "created_at",
@@ -35,6 +44,14 @@ var allowedSortFields = map[string]string{
 	"priority":   "priority",
 	"status":     "status",
 	"due_date":   "due_date",
+	"start_date": "start_date",
+}
+
+// taskSort はタスク一覧のソート（既定は作成日時の降順）
+var taskSort = sqlquery.Sort{
+	Columns:          allowedSortFields,
+	DefaultColumn:    "created_at",
+	DefaultDirection: "DESC",
 }
 
 // SQLインジェクション対策：許可されたフィルタフィールドの定義
@@ -44,37 +61,55 @@ var allowedFilterFields = map[string]bool{
 	"assignee_id": true,
 	"created_by":  true,
 	"due_date":    true,
+	"start_date":  true,
 }
 
 // CreateTask はタスクを作成する
 func (r *TaskRepository) CreateTask(ctx context.Context, task *domain.Task) error {
 	query := `
 		INSERT INTO ` + "`Yotei-Plus`" + `.tasks (
-			id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at
+			id, title, description, status, priority, category, assignee_id, created_by, due_date, created_at, updated_at,
+			estimate_minutes, estimate_points, actual_minutes, completed_at, require_all_assignees, start_date, checklist
 		) VALUES (
-			?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 		)
 	`
 
 	model := dto.FromDomain(task)
-	_, err := r.Execute(query,
+	checklist, err := encodeChecklist(model.Checklist)
+	if err != nil {
+		return err
+	}
+	_, err = r.Execute(query,
 		model.ID,
 		model.Title,
 		model.Description,
 		model.Status,
 		model.Priority,
+		model.Category,
 		model.AssigneeID,
 		model.CreatedBy,
 		model.DueDate,
 		model.CreatedAt,
 		model.UpdatedAt,
+		model.EstimateMinutes,
+		model.EstimatePoints,
+		model.ActualMinutes,
+		model.CompletedAt,
+		model.RequireAllAssignees,
+		model.StartDate,
+		checklist,
 	)
 	if err != nil {
-		r.logger.Error("Failed to create task", logger.Any("taskID", task.ID), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to create task", logger.Any("taskID", task.ID), logger.Error(err))
 		return fmt.Errorf("failed to create task: %w", err)
 	}
 
-	r.logger.Debug("Task created successfully", logger.Any("taskID", task.ID))
+	if err := r.saveAssignees(task); err != nil {
+		return err
+	}
+
+	r.logger.WithContext(ctx).Debug("Task created successfully", logger.Any("taskID", task.ID))
 	return nil
 }
 
@@ -85,7 +120,7 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 	}
 
 	query := `
-		SELECT id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at 
+		SELECT ` + taskColumns + ` 
 		FROM ` + "`Yotei-Plus`" + `.tasks 
 		WHERE id = ?
 		LIMIT 1
@@ -93,12 +128,12 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 
 	row, err := r.Query(query, id)
 	if err != nil {
-		r.logger.Error("Failed to query task by ID", logger.Any("id", id), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to query task by ID", logger.Any("id", id), logger.Error(err))
 		return nil, fmt.Errorf("failed to query task: %w", err)
 	}
 	defer func() {
 		if closeErr := row.Close(); closeErr != nil {
-			r.logger.Error("Failed to close row", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close row", logger.Error(closeErr))
 		}
 	}()
 
@@ -108,10 +143,14 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 
 	task, err := r.scanTaskFromRow(row)
 	if err != nil {
-		r.logger.Error("Failed to scan task", logger.Any("id", id), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to scan task", logger.Any("id", id), logger.Error(err))
 		return nil, fmt.Errorf("failed to scan task: %w", err)
 	}
 
+	if err := r.loadAssignees([]*domain.Task{task}); err != nil {
+		return nil, err
+	}
+
 	return task, nil
 }
 
@@ -127,11 +166,11 @@ func (r *TaskRepository) ListTasks(
 		return nil, 0, err
 	}
 
-	// WHERE句とパラメータの構築（SQLインジェクション対策）
-	whereClause, args := r.buildWhereClause(filter)
+	// WHERE・ORDER BY・LIMIT句の構築（値はすべてプレースホルダーで渡す）
+	builder := buildListTasksQuery(filter, pagination, sort)
 
 	// カウント取得（パフォーマンス改善：インデックス使用）
-	total, err := r.getTaskCount(ctx, whereClause, args)
+	total, err := r.getTaskCount(ctx, builder)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -140,31 +179,20 @@ func (r *TaskRepository) ListTasks(
 		return []*domain.Task{}, 0, nil
 	}
 
-	// ソートフィールドとディレクションの検証
-	sortField := r.validateSortField(sort.Field)
-	sortDirection := r.validateSortDirection(sort.Direction)
-
 	// メインクエリ（パフォーマンス改善：必要なカラムのみ選択）
-	query := fmt.Sprintf(`
-		SELECT id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at
-		FROM `+"`Yotei-Plus`"+`.tasks
-		%s
-		ORDER BY %s %s
-		LIMIT ? OFFSET ?
-	`, whereClause, sortField, sortDirection)
-
-	// ページネーション用のパラメータを追加
-	offset := (pagination.Page - 1) * pagination.PageSize
-	args = append(args, pagination.PageSize, offset)
+	query, args, err := builder.Select(taskColumns, tasksTable)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	rows, err := r.Query(query, args...)
 	if err != nil {
-		r.logger.Error("Failed to list tasks", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to list tasks", logger.Error(err))
 		return nil, 0, fmt.Errorf("failed to list tasks: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -172,13 +200,17 @@ func (r *TaskRepository) ListTasks(
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan task row", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan task row", logger.Error(err))
 			return nil, 0, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Tasks listed successfully",
+	if err := r.loadAssignees(tasks); err != nil {
+		return nil, 0, err
+	}
+
+	r.logger.WithContext(ctx).Debug("Tasks listed successfully",
 		logger.Any("count", len(tasks)),
 		logger.Any("total", total))
 
@@ -201,7 +233,7 @@ func (r *TaskRepository) SearchTasks(ctx context.Context, query string, limit in
 	// FULLTEXT検索またはLIKE検索（パフォーマンス改善）
 	// 本来はFULLTEXTのインデックスを使用するのが理想
 	sqlQuery := `
-		SELECT id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM ` + "`Yotei-Plus`" + `.tasks
 		WHERE (title LIKE ? OR description LIKE ?)
 		ORDER BY 
@@ -215,17 +247,17 @@ func (r *TaskRepository) SearchTasks(ctx context.Context, query string, limit in
 	`
 
 	// ワイルドカードパターンの構築（SQLインジェクション対策）
-	pattern := "%" + r.escapeLikePattern(query) + "%"
-	exactPattern := r.escapeLikePattern(query) + "%"
+	pattern := "%" + sqlquery.EscapeLike(query) + "%"
+	exactPattern := sqlquery.EscapeLike(query) + "%"
 
 	rows, err := r.Query(sqlQuery, pattern, pattern, exactPattern, exactPattern, limit)
 	if err != nil {
-		r.logger.Error("Failed to search tasks", logger.Any("query", query), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to search tasks", logger.Any("query", query), logger.Error(err))
 		return nil, fmt.Errorf("failed to search tasks: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -233,13 +265,17 @@ func (r *TaskRepository) SearchTasks(ctx context.Context, query string, limit in
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan task in search", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan task in search", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Task search completed",
+	if err := r.loadAssignees(tasks); err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("Task search completed",
 		logger.Any("query", query),
 		logger.Any("resultCount", len(tasks)))
 
@@ -254,7 +290,7 @@ func (r *TaskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, e
 	doneStatus := string(domain.TaskStatusDone)
 
 	query := `
-		SELECT id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM ` + "`Yotei-Plus`" + `.tasks
 		WHERE due_date < ? 
 		  AND due_date >= ?
@@ -265,12 +301,76 @@ func (r *TaskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, e
 
 	rows, err := r.Query(query, now, thirtyDaysAgo, doneStatus)
 	if err != nil {
-		r.logger.Error("Failed to get overdue tasks", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get overdue tasks", logger.Error(err))
 		return nil, fmt.Errorf("failed to get overdue tasks: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := r.scanTaskFromRow(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to scan overdue task", logger.Error(err))
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := r.loadAssignees(tasks); err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("Overdue tasks retrieved", logger.Any("count", len(tasks)))
+	return tasks, nil
+}
+
+// GetOverdueTasksByUser はユーザーが作成または担当する期限切れのタスクを取得
+// 主担当（assignee_id）・作成者（created_by）は (列, status, due_date) の複合インデックス、
+// 複数担当者は task_assignees の主キーで絞り込み、tasks の全件走査を避ける
+func (r *TaskRepository) GetOverdueTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, usecase.ErrInvalidParameter
+	}
+
+	now := time.Now()
+	todoStatus := string(domain.TaskStatusTodo)
+	inProgressStatus := string(domain.TaskStatusInProgress)
+
+	// status != 'DONE' ではインデックスの範囲検索にならないため、未完了のステータスを列挙する
+	// ユーザーごとの件数は少ないため、GetOverdueTasks と違い期限の下限は設けない
+	query := `
+		SELECT ` + taskColumns + `
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE assignee_id = ? AND status IN (?, ?) AND due_date < ?
+		UNION
+		SELECT ` + taskColumns + `
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE created_by = ? AND status IN (?, ?) AND due_date < ?
+		UNION
+		SELECT ` + taskColumns + `
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?)
+		  AND status IN (?, ?) AND due_date < ?
+		ORDER BY due_date ASC
+		LIMIT 1000
+	`
+
+	rows, err := r.Query(query,
+		userID, todoStatus, inProgressStatus, now,
+		userID, todoStatus, inProgressStatus, now,
+		userID, todoStatus, inProgressStatus, now,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get overdue tasks by user", logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get overdue tasks by user: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -278,13 +378,19 @@ func (r *TaskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Task, e
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan overdue task", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan overdue task", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Overdue tasks retrieved", logger.Any("count", len(tasks)))
+	if err := r.loadAssignees(tasks); err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("Overdue tasks by user retrieved",
+		logger.Any("userID", userID),
+		logger.Any("count", len(tasks)))
 	return tasks, nil
 }
 
@@ -296,9 +402,9 @@ func (r *TaskRepository) GetTasksByAssignee(ctx context.Context, userID string)
 
 	// パフォーマンス改善：インデックス利用、大量データ対策
 	query := `
-		SELECT id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM ` + "`Yotei-Plus`" + `.tasks
-		WHERE assignee_id = ?
+		WHERE id IN (SELECT task_id FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE user_id = ?)
 		ORDER BY 
 			CASE status 
 				WHEN 'TODO' THEN 1 
@@ -313,12 +419,12 @@ func (r *TaskRepository) GetTasksByAssignee(ctx context.Context, userID string)
 
 	rows, err := r.Query(query, userID)
 	if err != nil {
-		r.logger.Error("Failed to get tasks by assignee", logger.Any("userID", userID), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get tasks by assignee", logger.Any("userID", userID), logger.Error(err))
 		return nil, fmt.Errorf("failed to get tasks by assignee: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -326,13 +432,17 @@ func (r *TaskRepository) GetTasksByAssignee(ctx context.Context, userID string)
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan assignee task", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan assignee task", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Tasks by assignee retrieved",
+	if err := r.loadAssignees(tasks); err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("Tasks by assignee retrieved",
 		logger.Any("userID", userID),
 		logger.Any("count", len(tasks)))
 
@@ -347,31 +457,51 @@ func (r *TaskRepository) UpdateTask(ctx context.Context, task *domain.Task) erro
 			description = ?,
 			status = ?,
 			priority = ?,
+			category = ?,
 			assignee_id = ?,
 			due_date = ?,
-			updated_at = ?
+			updated_at = ?,
+			estimate_minutes = ?,
+			estimate_points = ?,
+			actual_minutes = ?,
+			completed_at = ?,
+			require_all_assignees = ?,
+			start_date = ?,
+			checklist = ?
 		WHERE id = ?
 	`
 
 	model := dto.FromDomain(task)
+	checklist, err := encodeChecklist(model.Checklist)
+	if err != nil {
+		return err
+	}
 	result, err := r.Execute(query,
 		model.Title,
 		model.Description,
 		model.Status,
 		model.Priority,
+		model.Category,
 		model.AssigneeID,
 		model.DueDate,
 		model.UpdatedAt,
+		model.EstimateMinutes,
+		model.EstimatePoints,
+		model.ActualMinutes,
+		model.CompletedAt,
+		model.RequireAllAssignees,
+		model.StartDate,
+		checklist,
 		model.ID,
 	)
 	if err != nil {
-		r.logger.Error("Failed to update task", logger.Any("taskID", task.ID), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to update task", logger.Any("taskID", task.ID), logger.Error(err))
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
 	affected, err := result.RowsAffected()
 	if err != nil {
-		r.logger.Error("Failed to get rows affected", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get rows affected", logger.Error(err))
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
@@ -379,7 +509,11 @@ func (r *TaskRepository) UpdateTask(ctx context.Context, task *domain.Task) erro
 		return usecase.ErrTaskNotFound
 	}
 
-	r.logger.Debug("Task updated successfully", logger.Any("taskID", task.ID))
+	if err := r.saveAssignees(task); err != nil {
+		return err
+	}
+
+	r.logger.WithContext(ctx).Debug("Task updated successfully", logger.Any("taskID", task.ID))
 	return nil
 }
 
@@ -393,13 +527,13 @@ func (r *TaskRepository) DeleteTask(ctx context.Context, id string) error {
 
 	result, err := r.Execute(query, id)
 	if err != nil {
-		r.logger.Error("Failed to delete task", logger.Any("taskID", id), logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to delete task", logger.Any("taskID", id), logger.Error(err))
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
 	affected, err := result.RowsAffected()
 	if err != nil {
-		r.logger.Error("Failed to get rows affected", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get rows affected", logger.Error(err))
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
@@ -407,7 +541,7 @@ func (r *TaskRepository) DeleteTask(ctx context.Context, id string) error {
 		return usecase.ErrTaskNotFound
 	}
 
-	r.logger.Debug("Task deleted successfully", logger.Any("taskID", id))
+	r.logger.WithContext(ctx).Debug("Task deleted successfully", logger.Any("taskID", id))
 	return nil
 }
 
@@ -430,71 +564,63 @@ func (r *TaskRepository) validateListParams(filter domain.ListFilter, pagination
 	return nil
 }
 
-func (r *TaskRepository) buildWhereClause(filter domain.ListFilter) (string, []interface{}) {
-	var conds []string
-	var args []interface{}
+// buildListTasksQuery はタスク一覧のフィルタ・ソート・ページネーションからクエリを組み立てる
+func buildListTasksQuery(filter domain.ListFilter, pagination domain.Pagination, sort domain.SortOptions) *sqlquery.Builder {
+	builder := sqlquery.New()
 
 	if filter.Status != nil {
-		conds = append(conds, "status = ?")
-		args = append(args, string(*filter.Status))
+		builder.Where("status = ?", string(*filter.Status))
 	}
 	if filter.Priority != nil {
-		conds = append(conds, "priority = ?")
-		args = append(args, string(*filter.Priority))
+		builder.Where("priority = ?", string(*filter.Priority))
+	}
+	if filter.Category != nil {
+		builder.Where("category = ?", string(*filter.Category))
 	}
 	if filter.AssigneeID != nil {
-		conds = append(conds, "assignee_id = ?")
-		args = append(args, *filter.AssigneeID)
+		subquery := "SELECT task_id FROM `Yotei-Plus`.task_assignees WHERE user_id = ?"
+		if filter.AssigneeCompleted != nil {
+			if *filter.AssigneeCompleted {
+				subquery += " AND completed_at IS NOT NULL"
+			} else {
+				subquery += " AND completed_at IS NULL"
+			}
+		}
+		builder.Where("id IN ("+subquery+")", *filter.AssigneeID)
 	}
 	if filter.CreatedBy != nil {
-		conds = append(conds, "created_by = ?")
-		args = append(args, *filter.CreatedBy)
+		builder.Where("created_by = ?", *filter.CreatedBy)
 	}
 	if filter.DueDateFrom != nil {
-		conds = append(conds, "due_date >= ?")
-		args = append(args, *filter.DueDateFrom)
+		builder.Where("due_date >= ?", *filter.DueDateFrom)
 	}
 	if filter.DueDateTo != nil {
-		conds = append(conds, "due_date <= ?")
-		args = append(args, *filter.DueDateTo)
+		builder.Where("due_date <= ?", *filter.DueDateTo)
 	}
-
-	whereClause := ""
-	if len(conds) > 0 {
-		whereClause = "WHERE " + strings.Join(conds, " AND ")
+	if filter.StartDateFrom != nil {
+		builder.Where("start_date >= ?", *filter.StartDateFrom)
 	}
-
-	return whereClause, args
-}
-
-func (r *TaskRepository) validateSortField(field string) string {
-	if validField, exists := allowedSortFields[field]; exists {
-		return validField
+	if filter.StartDateTo != nil {
+		builder.Where("start_date <= ?", *filter.StartDateTo)
 	}
-	return "created_at" // デフォルト
-}
-
-func (r *TaskRepository) validateSortDirection(direction string) string {
-	if direction == "ASC" || direction == "DESC" {
-		return direction
-	}
-	return "DESC" // デフォルト
-}
 
-// escapeLikePattern はLIKE演算子のワイルドカードをエスケープ（SQLインジェクション対策）
-func (r *TaskRepository) escapeLikePattern(pattern string) string {
-	// MySQL/MariaDBの場合のエスケープ
-	pattern = strings.ReplaceAll(pattern, "\\", "\\\\")
-	pattern = strings.ReplaceAll(pattern, "%", "\\%")
-	pattern = strings.ReplaceAll(pattern, "_", "\\_")
-	return pattern
+	return builder.
+		OrderBy(taskSort, sort.Field, sort.Direction).
+		Paginate(pagination.Page, pagination.PageSize)
 }
 
 // scanTaskFromRow はRowからTaskをスキャンする共通処理（改善版）
 func (r *TaskRepository) scanTaskFromRow(row Row) (*domain.Task, error) {
+	return scanTaskColumns(row)
+}
+
+// scanTaskColumns はtaskColumnsで選択した行をTaskに変換する
+func scanTaskColumns(row Row) (*domain.Task, error) {
 	var m dto.TaskModel
-	var assigneeID sql.NullString
-	var dueDate sql.NullTime
+	var category, assigneeID, checklist sql.NullString
+	var dueDate, completedAt, startDate sql.NullTime
+	var estimateMinutes, estimatePoints, actualMinutes sql.NullInt64
+	var requireAll sql.NullBool
 
 	err := row.Scan(
 		&m.ID,
@@ -502,11 +628,19 @@ func (r *TaskRepository) scanTaskFromRow(row Row) (*domain.Task, error) {
 		&m.Description,
 		&m.Status,
 		&m.Priority,
+		&category,
 		&assigneeID,
 		&m.CreatedBy,
 		&dueDate,
 		&m.CreatedAt,
 		&m.UpdatedAt,
+		&estimateMinutes,
+		&estimatePoints,
+		&actualMinutes,
+		&completedAt,
+		&requireAll,
+		&startDate,
+		&checklist,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -521,29 +655,163 @@ func (r *TaskRepository) scanTaskFromRow(row Row) (*domain.Task, error) {
 		d := dueDate.Time
 		m.DueDate = &d
 	}
+	m.Category = string(domain.CategoryOther)
+	if category.Valid && category.String != "" {
+		m.Category = category.String
+	}
+	m.EstimateMinutes = nullIntPtr(estimateMinutes)
+	m.EstimatePoints = nullIntPtr(estimatePoints)
+	m.ActualMinutes = nullIntPtr(actualMinutes)
+	if completedAt.Valid {
+		c := completedAt.Time
+		m.CompletedAt = &c
+	}
+	m.RequireAllAssignees = requireAll.Valid && requireAll.Bool
+	if startDate.Valid {
+		d := startDate.Time
+		m.StartDate = &d
+	}
+	if checklist.Valid && checklist.String != "" {
+		if err := json.Unmarshal([]byte(checklist.String), &m.Checklist); err != nil {
+			return nil, fmt.Errorf("failed to decode checklist: %w", err)
+		}
+	}
 
 	return m.ToDomain(), nil
 }
 
+// encodeChecklist はチェックリストをJSONに変換する（項目がない場合はNULL）
+func encodeChecklist(items []domain.ChecklistItem) (sql.NullString, error) {
+	if len(items) == 0 {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode checklist: %w", err)
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// nullIntPtr はNULL許容の整数をポインタに変換する
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
+
+// saveAssignees はタスクの担当者一覧をtask_assigneesテーブルに同期する
+func (r *TaskRepository) saveAssignees(task *domain.Task) error {
+	models := dto.AssigneesFromDomain(task)
+
+	// 外れた担当者を削除
+	deleteQuery := `DELETE FROM ` + "`Yotei-Plus`" + `.task_assignees WHERE task_id = ?`
+	args := []interface{}{task.ID}
+	if len(models) > 0 {
+		placeholders := make([]string, len(models))
+		for i, m := range models {
+			placeholders[i] = "?"
+			args = append(args, m.UserID)
+		}
+		deleteQuery += " AND user_id NOT IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+	if _, err := r.Execute(deleteQuery, args...); err != nil {
+		r.logger.Error("Failed to delete task assignees", logger.Any("taskID", task.ID), logger.Error(err))
+		return fmt.Errorf("failed to delete task assignees: %w", err)
+	}
+
+	upsertQuery := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_assignees (task_id, user_id, assigned_at, completed_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE completed_at = VALUES(completed_at)
+	`
+	for _, m := range models {
+		if _, err := r.Execute(upsertQuery, m.TaskID, m.UserID, m.AssignedAt, m.CompletedAt); err != nil {
+			r.logger.Error("Failed to save task assignee",
+				logger.Any("taskID", task.ID), logger.Any("userID", m.UserID), logger.Error(err))
+			return fmt.Errorf("failed to save task assignee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadAssignees はタスク一覧の担当者をまとめて取得して設定する（N+1回避）
+func (r *TaskRepository) loadAssignees(tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Task, len(tasks))
+	placeholders := make([]string, 0, len(tasks))
+	args := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		task.Assignees = []*domain.TaskAssignee{}
+		byID[task.ID] = task
+		placeholders = append(placeholders, "?")
+		args = append(args, task.ID)
+	}
+
+	query := `
+		SELECT task_id, user_id, assigned_at, completed_at
+		FROM ` + "`Yotei-Plus`" + `.task_assignees
+		WHERE task_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY assigned_at ASC, user_id ASC
+	`
+
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query task assignees", logger.Error(err))
+		return fmt.Errorf("failed to query task assignees: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	for rows.Next() {
+		var m dto.TaskAssigneeModel
+		var completedAt sql.NullTime
+		if err := rows.Scan(&m.TaskID, &m.UserID, &m.AssignedAt, &completedAt); err != nil {
+			r.logger.Error("Failed to scan task assignee", logger.Error(err))
+			return fmt.Errorf("failed to scan task assignee: %w", err)
+		}
+		if completedAt.Valid {
+			c := completedAt.Time
+			m.CompletedAt = &c
+		}
+		if task, ok := byID[m.TaskID]; ok {
+			task.Assignees = append(task.Assignees, m.ToDomain())
+		}
+	}
+
+	return nil
+}
+
 // getTaskCount はタスクの総数を取得する（パフォーマンス改善）
-func (r *TaskRepository) getTaskCount(ctx context.Context, whereClause string, args []interface{}) (int, error) {
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM "+"`Yotei-Plus`"+".tasks %s", whereClause)
+func (r *TaskRepository) getTaskCount(ctx context.Context, builder *sqlquery.Builder) (int, error) {
+	countQuery, args, err := builder.Count(tasksTable)
+	if err != nil {
+		return 0, err
+	}
 
 	row, err := r.Query(countQuery, args...)
 	if err != nil {
-		r.logger.Error("Failed to count tasks", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to count tasks", logger.Error(err))
 		return 0, fmt.Errorf("failed to count tasks: %w", err)
 	}
 	defer func() {
 		if closeErr := row.Close(); closeErr != nil {
-			r.logger.Error("Failed to close count row", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close count row", logger.Error(closeErr))
 		}
 	}()
 
 	var count int
 	if row.Next() {
 		if err := row.Scan(&count); err != nil {
-			r.logger.Error("Failed to scan count", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan count", logger.Error(err))
 			return 0, fmt.Errorf("failed to scan count: %w", err)
 		}
 	}
@@ -555,10 +823,13 @@ func (r *TaskRepository) getTaskCount(ctx context.Context, whereClause string, a
 func (r *TaskRepository) GetTasksForNotification(ctx context.Context, from, to time.Time) ([]*domain.Task, error) {
 	// 期限が近いアサイン済みタスクのみを効率的に取得
 	query := `
-		SELECT id, title, description, status, priority, assignee_id, created_by, due_date, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM ` + "`Yotei-Plus`" + `.tasks
 		WHERE due_date BETWEEN ? AND ?
-		  AND assignee_id IS NOT NULL
+		  AND EXISTS (
+			SELECT 1 FROM ` + "`Yotei-Plus`" + `.task_assignees ta
+			WHERE ta.task_id = tasks.id AND ta.completed_at IS NULL
+		  )
 		  AND status IN ('TODO', 'IN_PROGRESS')
 		ORDER BY due_date ASC
 		LIMIT 1000
@@ -566,12 +837,12 @@ func (r *TaskRepository) GetTasksForNotification(ctx context.Context, from, to t
 
 	rows, err := r.Query(query, from, to)
 	if err != nil {
-		r.logger.Error("Failed to get tasks for notification", logger.Error(err))
+		r.logger.WithContext(ctx).Error("Failed to get tasks for notification", logger.Error(err))
 		return nil, fmt.Errorf("failed to get tasks for notification: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
-			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
 		}
 	}()
 
@@ -579,12 +850,16 @@ func (r *TaskRepository) GetTasksForNotification(ctx context.Context, from, to t
 	for rows.Next() {
 		task, err := r.scanTaskFromRow(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan notification task", logger.Error(err))
+			r.logger.WithContext(ctx).Error("Failed to scan notification task", logger.Error(err))
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		tasks = append(tasks, task)
 	}
 
-	r.logger.Debug("Tasks for notification retrieved", logger.Any("count", len(tasks)))
+	if err := r.loadAssignees(tasks); err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("Tasks for notification retrieved", logger.Any("count", len(tasks)))
 	return tasks, nil
 }
@@ -0,0 +1,247 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskHistoryRepository はタスクの変更イベントとスナップショットのデータベースリポジトリ実装
+type TaskHistoryRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewTaskHistoryRepository は新しいTaskHistoryRepositoryを作成する
+func NewTaskHistoryRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.TaskHistoryRepository {
+	return &TaskHistoryRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// AppendTaskEvent はイベントを追記する（(task_id, sequence) の一意制約で連番の重複を防ぐ）
+func (r *TaskHistoryRepository) AppendTaskEvent(ctx context.Context, event *domain.TaskEvent) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode task event changes: %w", err)
+	}
+
+	var actorID sql.NullString
+	if event.ActorID != "" {
+		actorID = sql.NullString{String: event.ActorID, Valid: true}
+	}
+
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_events (id, task_id, sequence, type, actor_id, changes, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.Execute(query,
+		event.ID,
+		event.TaskID,
+		event.Sequence,
+		event.Type,
+		actorID,
+		string(changes),
+		event.OccurredAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to append task event",
+			logger.Any("taskID", event.TaskID), logger.Any("sequence", event.Sequence), logger.Error(err))
+		return fmt.Errorf("failed to append task event: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastTaskEventSequence はタスクの最後のイベントの連番を返す（イベントがない場合は0）
+func (r *TaskHistoryRepository) GetLastTaskEventSequence(ctx context.Context, taskID string) (int64, error) {
+	query := `
+		SELECT COALESCE(MAX(sequence), 0)
+		FROM ` + "`Yotei-Plus`" + `.task_events
+		WHERE task_id = ?
+	`
+
+	rows, err := r.Query(query, taskID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get last task event", logger.Any("taskID", taskID), logger.Error(err))
+		return 0, fmt.Errorf("failed to get last task event: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var sequence int64
+	if rows.Next() {
+		if err := rows.Scan(&sequence); err != nil {
+			return 0, fmt.Errorf("failed to scan task event sequence: %w", err)
+		}
+	}
+
+	return sequence, nil
+}
+
+// ListTaskEvents は連番がafterSequenceより大きく、until以前に発生したイベントを連番順に取得する
+func (r *TaskHistoryRepository) ListTaskEvents(ctx context.Context, taskID string, afterSequence int64, until time.Time) ([]*domain.TaskEvent, error) {
+	query := `
+		SELECT id, task_id, sequence, type, actor_id, changes, occurred_at
+		FROM ` + "`Yotei-Plus`" + `.task_events
+		WHERE task_id = ? AND sequence > ? AND occurred_at <= ?
+		ORDER BY sequence ASC
+	`
+
+	rows, err := r.Query(query, taskID, afterSequence, until)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to list task events", logger.Any("taskID", taskID), logger.Error(err))
+		return nil, fmt.Errorf("failed to list task events: %w", err)
+	}
+	return r.scanTaskEvents(ctx, rows)
+}
+
+// statusEventsBatchSize は1回のクエリで指定するタスクIDの上限
+const statusEventsBatchSize = 500
+
+// ListStatusEvents は複数タスクの作成・ステータス変更のイベントのうちuntil以前に発生したものを発生順に取得する
+func (r *TaskHistoryRepository) ListStatusEvents(ctx context.Context, taskIDs []string, until time.Time) ([]*domain.TaskEvent, error) {
+	events := []*domain.TaskEvent{}
+	for start := 0; start < len(taskIDs); start += statusEventsBatchSize {
+		batch := taskIDs[start:min(start+statusEventsBatchSize, len(taskIDs))]
+
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)+1)
+		for i, taskID := range batch {
+			placeholders[i] = "?"
+			args = append(args, taskID)
+		}
+		args = append(args, until)
+
+		query := `
+			SELECT id, task_id, sequence, type, actor_id, changes, occurred_at
+			FROM ` + "`Yotei-Plus`" + `.task_events
+			WHERE task_id IN (` + strings.Join(placeholders, ", ") + `)
+			  AND type IN ('CREATED', 'STATUS_CHANGED')
+			  AND occurred_at <= ?
+			ORDER BY occurred_at ASC, task_id ASC, sequence ASC
+		`
+
+		rows, err := r.Query(query, args...)
+		if err != nil {
+			r.logger.WithContext(ctx).Error("Failed to list task status events", logger.Error(err))
+			return nil, fmt.Errorf("failed to list task status events: %w", err)
+		}
+		batchEvents, err := r.scanTaskEvents(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, batchEvents...)
+	}
+
+	// バッチごとの結果をまとめて発生順に並べる
+	sort.SliceStable(events, func(i, j int) bool { return events[i].OccurredAt.Before(events[j].OccurredAt) })
+	return events, nil
+}
+
+// scanTaskEvents はイベントの行を読み取る（rowsは読み取り後に閉じる）
+func (r *TaskHistoryRepository) scanTaskEvents(ctx context.Context, rows Row) ([]*domain.TaskEvent, error) {
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	events := []*domain.TaskEvent{}
+	for rows.Next() {
+		var event domain.TaskEvent
+		var actorID sql.NullString
+		var changes string
+		err := rows.Scan(
+			&event.ID,
+			&event.TaskID,
+			&event.Sequence,
+			&event.Type,
+			&actorID,
+			&changes,
+			&event.OccurredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task event: %w", err)
+		}
+		if err := json.Unmarshal([]byte(changes), &event.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode task event changes: %w", err)
+		}
+		event.ActorID = actorID.String
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+// SaveTaskSnapshot はスナップショットを保存する（同じ連番のスナップショットは置き換える）
+func (r *TaskHistoryRepository) SaveTaskSnapshot(ctx context.Context, snapshot *domain.TaskSnapshot) error {
+	state, err := json.Marshal(snapshot.State)
+	if err != nil {
+		return fmt.Errorf("failed to encode task snapshot: %w", err)
+	}
+
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_snapshots (task_id, sequence, state, taken_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE state = VALUES(state), taken_at = VALUES(taken_at)
+	`
+
+	if _, err := r.Execute(query, snapshot.TaskID, snapshot.Sequence, string(state), snapshot.TakenAt); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save task snapshot",
+			logger.Any("taskID", snapshot.TaskID), logger.Any("sequence", snapshot.Sequence), logger.Error(err))
+		return fmt.Errorf("failed to save task snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestTaskSnapshot はuntil以前に取得した最新のスナップショットを返す（ない場合はnil）
+func (r *TaskHistoryRepository) GetLatestTaskSnapshot(ctx context.Context, taskID string, until time.Time) (*domain.TaskSnapshot, error) {
+	query := `
+		SELECT task_id, sequence, state, taken_at
+		FROM ` + "`Yotei-Plus`" + `.task_snapshots
+		WHERE task_id = ? AND taken_at <= ?
+		ORDER BY sequence DESC
+		LIMIT 1
+	`
+
+	rows, err := r.Query(query, taskID, until)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to get task snapshot", logger.Any("taskID", taskID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get task snapshot: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	var snapshot domain.TaskSnapshot
+	var state string
+	if err := rows.Scan(&snapshot.TaskID, &snapshot.Sequence, &state, &snapshot.TakenAt); err != nil {
+		return nil, fmt.Errorf("failed to scan task snapshot: %w", err)
+	}
+	if err := json.Unmarshal([]byte(state), &snapshot.State); err != nil {
+		return nil, fmt.Errorf("failed to decode task snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskLinkRepository はユーザーがタスクに追加したリンクのデータベースリポジトリ実装
+type TaskLinkRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewTaskLinkRepository は新しいTaskLinkRepositoryを作成する
+func NewTaskLinkRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.TaskLinkRepository {
+	return &TaskLinkRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const taskLinkColumns = `id, task_id, type, url, title, created_by, created_at, updated_at`
+
+// CreateTaskLink はタスクにリンクを追加する
+func (r *TaskLinkRepository) CreateTaskLink(ctx context.Context, link *domain.TaskLink) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.task_links (` + taskLinkColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.Execute(query,
+		link.ID,
+		link.TaskID,
+		link.Type,
+		link.URL,
+		link.Title,
+		link.CreatedBy,
+		link.CreatedAt,
+		link.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to create task link", logger.Any("taskID", link.TaskID), logger.Error(err))
+		return fmt.Errorf("failed to create task link: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaskLink はIDでリンクを取得する（存在しない場合は nil）
+func (r *TaskLinkRepository) GetTaskLink(ctx context.Context, id string) (*domain.TaskLink, error) {
+	query := `
+		SELECT ` + taskLinkColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_links
+		WHERE id = ?
+	`
+
+	links, err := r.queryTaskLinks(query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	return links[0], nil
+}
+
+// ListTaskLinksByTask はタスクのリンクを追加した順に取得する
+func (r *TaskLinkRepository) ListTaskLinksByTask(ctx context.Context, taskID string) ([]*domain.TaskLink, error) {
+	query := `
+		SELECT ` + taskLinkColumns + `
+		FROM ` + "`Yotei-Plus`" + `.task_links
+		WHERE task_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.queryTaskLinks(query, taskID)
+}
+
+// UpdateTaskLink はリンクの種類・URL・タイトルを更新する
+func (r *TaskLinkRepository) UpdateTaskLink(ctx context.Context, link *domain.TaskLink) error {
+	query := `
+		UPDATE ` + "`Yotei-Plus`" + `.task_links
+		SET type = ?, url = ?, title = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.Execute(query, link.Type, link.URL, link.Title, link.UpdatedAt, link.ID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to update task link", logger.Any("linkID", link.ID), logger.Error(err))
+		return fmt.Errorf("failed to update task link: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTaskLink はリンクを削除する
+func (r *TaskLinkRepository) DeleteTaskLink(ctx context.Context, id string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.task_links WHERE id = ?`
+
+	if _, err := r.Execute(query, id); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete task link", logger.Any("linkID", id), logger.Error(err))
+		return fmt.Errorf("failed to delete task link: %w", err)
+	}
+
+	return nil
+}
+
+// queryTaskLinks はリンクの一覧を取得する共通処理
+func (r *TaskLinkRepository) queryTaskLinks(query string, args ...interface{}) ([]*domain.TaskLink, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query task links", logger.Error(err))
+		return nil, fmt.Errorf("failed to query task links: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	links := []*domain.TaskLink{}
+	for rows.Next() {
+		var link domain.TaskLink
+		err := rows.Scan(
+			&link.ID,
+			&link.TaskID,
+			&link.Type,
+			&link.URL,
+			&link.Title,
+			&link.CreatedBy,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task link: %w", err)
+		}
+		links = append(links, &link)
+	}
+
+	return links, nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/common/sqlquery"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// listFilterCase はListTasksのフィルタ1つ分の設定と、期待する条件・引数
+type listFilterCase struct {
+	apply func(*domain.ListFilter)
+	cond  string
+	arg   interface{}
+}
+
+func listFilterCases() []listFilterCase {
+	status := domain.TaskStatusTodo
+	priority := domain.PriorityHigh
+	category := domain.CategoryWork
+	assignee := "user-2"
+	createdBy := "user-1"
+	dueFrom := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	dueTo := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	startFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	startTo := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	return []listFilterCase{
+		{func(f *domain.ListFilter) { f.Status = &status }, "status = ?", string(status)},
+		{func(f *domain.ListFilter) { f.Priority = &priority }, "priority = ?", string(priority)},
+		{func(f *domain.ListFilter) { f.Category = &category }, "category = ?", string(category)},
+		{func(f *domain.ListFilter) { f.AssigneeID = &assignee }, "id IN (SELECT task_id FROM `Yotei-Plus`.task_assignees WHERE user_id = ?)", assignee},
+		{func(f *domain.ListFilter) { f.CreatedBy = &createdBy }, "created_by = ?", createdBy},
+		{func(f *domain.ListFilter) { f.DueDateFrom = &dueFrom }, "due_date >= ?", dueFrom},
+		{func(f *domain.ListFilter) { f.DueDateTo = &dueTo }, "due_date <= ?", dueTo},
+		{func(f *domain.ListFilter) { f.StartDateFrom = &startFrom }, "start_date >= ?", startFrom},
+		{func(f *domain.ListFilter) { f.StartDateTo = &startTo }, "start_date <= ?", startTo},
+	}
+}
+
+func TestBuildListTasksQuery_AllFilterCombinations(t *testing.T) {
+	cases := listFilterCases()
+	pagination := domain.Pagination{Page: 2, PageSize: 10}
+
+	for mask := 0; mask < 1<<len(cases); mask++ {
+		var filter domain.ListFilter
+		var conds []string
+		var args []interface{}
+		for i, c := range cases {
+			if mask&(1<<i) != 0 {
+				c.apply(&filter)
+				conds = append(conds, c.cond)
+				args = append(args, c.arg)
+			}
+		}
+
+		where := ""
+		if len(conds) > 0 {
+			where = " WHERE " + strings.Join(conds, " AND ")
+		}
+
+		builder := buildListTasksQuery(filter, pagination, domain.SortOptions{})
+
+		countQuery, countArgs, err := builder.Count(tasksTable)
+		require.NoError(t, err, "mask %b", mask)
+		assert.Equal(t, "SELECT COUNT(*) FROM "+tasksTable+where, countQuery, "mask %b", mask)
+		assert.Equal(t, args, countArgs, "mask %b", mask)
+
+		query, selectArgs, err := builder.Select(taskColumns, tasksTable)
+		require.NoError(t, err, "mask %b", mask)
+		assert.Equal(t, "SELECT "+taskColumns+" FROM "+tasksTable+where+" ORDER BY created_at DESC LIMIT ? OFFSET ?", query, "mask %b", mask)
+		assert.Equal(t, append(append([]interface{}{}, args...), 10, 10), selectArgs, "mask %b", mask)
+		assert.Equal(t, strings.Count(query, "?"), len(selectArgs), "mask %b", mask)
+	}
+}
+
+func TestBuildListTasksQuery_AssigneeCompleted(t *testing.T) {
+	assignee := "user-2"
+	completed := true
+	pending := false
+
+	tests := []struct {
+		name      string
+		completed *bool
+		want      string
+	}{
+		{name: "any", completed: nil, want: "WHERE id IN (SELECT task_id FROM `Yotei-Plus`.task_assignees WHERE user_id = ?)"},
+		{name: "completed", completed: &completed, want: "WHERE id IN (SELECT task_id FROM `Yotei-Plus`.task_assignees WHERE user_id = ? AND completed_at IS NOT NULL)"},
+		{name: "pending", completed: &pending, want: "WHERE id IN (SELECT task_id FROM `Yotei-Plus`.task_assignees WHERE user_id = ? AND completed_at IS NULL)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := domain.ListFilter{AssigneeID: &assignee, AssigneeCompleted: tt.completed}
+
+			where, args := buildListTasksQuery(filter, domain.Pagination{Page: 1, PageSize: 20}, domain.SortOptions{}).WhereClause()
+
+			assert.Equal(t, tt.want, where)
+			assert.Equal(t, []interface{}{assignee}, args)
+		})
+	}
+
+	t.Run("ignored without assignee", func(t *testing.T) {
+		filter := domain.ListFilter{AssigneeCompleted: &completed}
+
+		where, args := buildListTasksQuery(filter, domain.Pagination{Page: 1, PageSize: 20}, domain.SortOptions{}).WhereClause()
+
+		assert.Empty(t, where)
+		assert.Empty(t, args)
+	})
+}
+
+func TestBuildListTasksQuery_Sort(t *testing.T) {
+	pagination := domain.Pagination{Page: 1, PageSize: 20}
+
+	for field, column := range allowedSortFields {
+		for _, direction := range []string{"ASC", "DESC"} {
+			query, _, err := buildListTasksQuery(domain.ListFilter{}, pagination, domain.SortOptions{Field: field, Direction: direction}).
+				Select("id", tasksTable)
+
+			require.NoError(t, err)
+			assert.Contains(t, query, "ORDER BY "+column+" "+direction+" LIMIT")
+		}
+	}
+
+	t.Run("rejects unknown field", func(t *testing.T) {
+		_, _, err := buildListTasksQuery(domain.ListFilter{}, pagination, domain.SortOptions{Field: "id; DROP TABLE tasks"}).
+			Select("id", tasksTable)
+
+		assert.ErrorIs(t, err, sqlquery.ErrInvalidSort)
+	})
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskUsageRepository は使用量の集計のデータベースリポジトリ実装
+type TaskUsageRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewTaskUsageRepository は新しいTaskUsageRepositoryを作成する
+func NewTaskUsageRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.TaskUsageRepository {
+	return &TaskUsageRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// CountOpenTasksByCreator はユーザーが作成した未完了のタスク数を取得する
+func (r *TaskUsageRepository) CountOpenTasksByCreator(ctx context.Context, userID string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM ` + "`Yotei-Plus`" + `.tasks
+		WHERE created_by = ? AND status <> 'DONE'
+	`
+
+	return r.queryInt(ctx, "count open tasks", query, userID)
+}
+
+// CountOpenGroupTasks はグループタスクのうち未完了のものの数を取得する
+func (r *TaskUsageRepository) CountOpenGroupTasks(ctx context.Context, groupID string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM ` + "`Yotei-Plus`" + `.group_tasks gt
+		JOIN ` + "`Yotei-Plus`" + `.tasks t ON t.id = gt.task_id
+		WHERE gt.group_id = ? AND t.status <> 'DONE'
+	`
+
+	return r.queryInt(ctx, "count open group tasks", query, groupID)
+}
+
+// SumAttachmentBytesByUploader はユーザーがアップロードした添付ファイルの合計サイズ（バイト）を取得する
+func (r *TaskUsageRepository) SumAttachmentBytesByUploader(ctx context.Context, userID string) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(file_size), 0)
+		FROM ` + "`Yotei-Plus`" + `.task_attachments
+		WHERE uploaded_by = ?
+	`
+
+	return r.queryInt(ctx, "sum attachment size", query, userID)
+}
+
+func (r *TaskUsageRepository) queryInt(ctx context.Context, operation, query string, args ...interface{}) (int64, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to "+operation, logger.Error(err))
+		return 0, fmt.Errorf("failed to %s: %w", operation, err)
+	}
+	defer rows.Close()
+
+	var value int64
+	if rows.Next() {
+		if err := rows.Scan(&value); err != nil {
+			return 0, fmt.Errorf("failed to scan %s: %w", operation, err)
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// WeeklyReportSubscriptionRepository は週次レポートの配信購読のデータベースリポジトリ実装
+type WeeklyReportSubscriptionRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewWeeklyReportSubscriptionRepository は新しいWeeklyReportSubscriptionRepositoryを作成する
+func NewWeeklyReportSubscriptionRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.WeeklyReportSubscriptionRepository {
+	return &WeeklyReportSubscriptionRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+const weeklyReportSubscriptionColumns = `user_id, timezone, last_sent_week, created_at, updated_at`
+
+// GetSubscription はユーザーの購読を取得する（未購読の場合は nil）
+func (r *WeeklyReportSubscriptionRepository) GetSubscription(ctx context.Context, userID string) (*domain.WeeklyReportSubscription, error) {
+	subscriptions, err := r.querySubscriptions(ctx, `
+		SELECT `+weeklyReportSubscriptionColumns+`
+		FROM `+"`Yotei-Plus`"+`.weekly_report_subscriptions
+		WHERE user_id = ?
+		LIMIT 1
+	`, userID)
+	if err != nil || len(subscriptions) == 0 {
+		return nil, err
+	}
+	return subscriptions[0], nil
+}
+
+// SaveSubscription は購読を保存する（存在する場合は上書き）
+func (r *WeeklyReportSubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain.WeeklyReportSubscription) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.weekly_report_subscriptions
+			(` + weeklyReportSubscriptionColumns + `)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			timezone = VALUES(timezone),
+			last_sent_week = VALUES(last_sent_week),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.Execute(query,
+		subscription.UserID,
+		subscription.Timezone,
+		subscription.LastSentWeek,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save weekly report subscription",
+			logger.Any("userID", subscription.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save weekly report subscription: %w", err)
+	}
+	return nil
+}
+
+// DeleteSubscription は購読を削除する
+func (r *WeeklyReportSubscriptionRepository) DeleteSubscription(ctx context.Context, userID string) error {
+	query := `DELETE FROM ` + "`Yotei-Plus`" + `.weekly_report_subscriptions WHERE user_id = ?`
+
+	if _, err := r.Execute(query, userID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to delete weekly report subscription",
+			logger.Any("userID", userID), logger.Error(err))
+		return fmt.Errorf("failed to delete weekly report subscription: %w", err)
+	}
+	return nil
+}
+
+// ListSubscriptions はすべての購読を取得する
+func (r *WeeklyReportSubscriptionRepository) ListSubscriptions(ctx context.Context) ([]*domain.WeeklyReportSubscription, error) {
+	return r.querySubscriptions(ctx, `
+		SELECT `+weeklyReportSubscriptionColumns+`
+		FROM `+"`Yotei-Plus`"+`.weekly_report_subscriptions
+		ORDER BY user_id
+	`)
+}
+
+// MarkSent は週のレポートを配信済みとして記録する
+func (r *WeeklyReportSubscriptionRepository) MarkSent(ctx context.Context, userID, week string) error {
+	query := `
+		UPDATE ` + "`Yotei-Plus`" + `.weekly_report_subscriptions
+		SET last_sent_week = ?
+		WHERE user_id = ?
+	`
+
+	if _, err := r.Execute(query, week, userID); err != nil {
+		r.logger.WithContext(ctx).Error("Failed to mark weekly report as sent",
+			logger.Any("userID", userID), logger.Any("week", week), logger.Error(err))
+		return fmt.Errorf("failed to mark weekly report as sent: %w", err)
+	}
+	return nil
+}
+
+// querySubscriptions は購読一覧を取得する共通処理
+func (r *WeeklyReportSubscriptionRepository) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]*domain.WeeklyReportSubscription, error) {
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query weekly report subscriptions", logger.Error(err))
+		return nil, fmt.Errorf("failed to query weekly report subscriptions: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	var subscriptions []*domain.WeeklyReportSubscription
+	for rows.Next() {
+		var s domain.WeeklyReportSubscription
+		if err := rows.Scan(&s.UserID, &s.Timezone, &s.LastSentWeek, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan weekly report subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, &s)
+	}
+	return subscriptions, nil
+}
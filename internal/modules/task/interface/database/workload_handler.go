@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// WorkloadRepository は勤務時間設定のデータベースリポジトリ実装
+type WorkloadRepository struct {
+	SqlHandler
+	logger logger.Logger
+}
+
+// NewWorkloadRepository は新しいWorkloadRepositoryを作成する
+func NewWorkloadRepository(sqlHandler SqlHandler, logger logger.Logger) usecase.WorkloadRepository {
+	return &WorkloadRepository{
+		SqlHandler: sqlHandler,
+		logger:     logger,
+	}
+}
+
+// GetWorkingHours はユーザーの勤務時間設定を取得する（未設定の場合は nil）
+func (r *WorkloadRepository) GetWorkingHours(ctx context.Context, userID string) (*domain.WorkingHours, error) {
+	query := `
+		SELECT user_id, timezone, work_days, start_time, end_time, daily_capacity_minutes, holiday_policy, reminder_timing, updated_at
+		FROM ` + "`Yotei-Plus`" + `.user_working_hours
+		WHERE user_id = ?
+		LIMIT 1
+	`
+
+	rows, err := r.Query(query, userID)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to query working hours", logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to query working hours: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.logger.WithContext(ctx).Error("Failed to close rows", logger.Error(closeErr))
+		}
+	}()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	var hours domain.WorkingHours
+	var workDays string
+	err = rows.Scan(
+		&hours.UserID,
+		&hours.Timezone,
+		&workDays,
+		&hours.StartTime,
+		&hours.EndTime,
+		&hours.DailyCapacityMinutes,
+		&hours.HolidayPolicy,
+		&hours.ReminderTiming,
+		&hours.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan working hours: %w", err)
+	}
+
+	hours.WorkDays, err = parseWorkDays(workDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hours, nil
+}
+
+// SaveWorkingHours はユーザーの勤務時間設定を保存する（存在する場合は上書き）
+func (r *WorkloadRepository) SaveWorkingHours(ctx context.Context, hours *domain.WorkingHours) error {
+	query := `
+		INSERT INTO ` + "`Yotei-Plus`" + `.user_working_hours
+			(user_id, timezone, work_days, start_time, end_time, daily_capacity_minutes, holiday_policy, reminder_timing, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			timezone = VALUES(timezone),
+			work_days = VALUES(work_days),
+			start_time = VALUES(start_time),
+			end_time = VALUES(end_time),
+			daily_capacity_minutes = VALUES(daily_capacity_minutes),
+			holiday_policy = VALUES(holiday_policy),
+			reminder_timing = VALUES(reminder_timing),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.Execute(query,
+		hours.UserID,
+		hours.Timezone,
+		formatWorkDays(hours.WorkDays),
+		hours.StartTime,
+		hours.EndTime,
+		hours.DailyCapacityMinutes,
+		hours.HolidayPolicy,
+		hours.ReminderTiming,
+		hours.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("Failed to save working hours", logger.Any("userID", hours.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save working hours: %w", err)
+	}
+
+	return nil
+}
+
+// formatWorkDays は曜日をカンマ区切り文字列に変換する（例: "1,2,3,4,5"）
+func formatWorkDays(days []time.Weekday) string {
+	values := make([]string, len(days))
+	for i, day := range days {
+		values[i] = strconv.Itoa(int(day))
+	}
+	return strings.Join(values, ",")
+}
+
+// parseWorkDays はカンマ区切り文字列を曜日に変換する
+func parseWorkDays(value string) ([]time.Weekday, error) {
+	days := []time.Weekday{}
+	if value == "" {
+		return days, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid work_days value: %s", value)
+		}
+		days = append(days, time.Weekday(n))
+	}
+	return days, nil
+}
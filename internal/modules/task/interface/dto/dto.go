@@ -13,11 +13,62 @@ type TaskModel struct {
 	Description string     `db:"description"`
 	Status      string     `db:"status"`
 	Priority    string     `db:"priority"`
+	Category    string     `db:"category"`
 	AssigneeID  *string    `db:"assignee_id"`
 	CreatedBy   string     `db:"created_by"`
+	StartDate   *time.Time `db:"start_date"`
 	DueDate     *time.Time `db:"due_date"`
 	CreatedAt   time.Time  `db:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at"`
+
+	EstimateMinutes *int       `db:"estimate_minutes"`
+	EstimatePoints  *int       `db:"estimate_points"`
+	ActualMinutes   *int       `db:"actual_minutes"`
+	CompletedAt     *time.Time `db:"completed_at"`
+
+	RequireAllAssignees bool `db:"require_all_assignees"`
+
+	Checklist []domain.ChecklistItem `db:"checklist"`
+}
+
+// TaskAssigneeModel はtask_assigneesテーブルにマッピングするための構造体
+type TaskAssigneeModel struct {
+	TaskID      string     `db:"task_id"`
+	UserID      string     `db:"user_id"`
+	AssignedAt  time.Time  `db:"assigned_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// ToDomain はモデルをドメインエンティティに変換する
+func (m *TaskAssigneeModel) ToDomain() *domain.TaskAssignee {
+	return &domain.TaskAssignee{
+		UserID:      m.UserID,
+		AssignedAt:  m.AssignedAt,
+		CompletedAt: m.CompletedAt,
+	}
+}
+
+// AssigneesFromDomain はタスクの担当者一覧をモデルに変換する
+// 担当者一覧が未設定でAssigneeIDのみある場合はそれを担当者とする
+func AssigneesFromDomain(task *domain.Task) []*TaskAssigneeModel {
+	if len(task.Assignees) == 0 && task.AssigneeID != nil {
+		return []*TaskAssigneeModel{{
+			TaskID:     task.ID,
+			UserID:     *task.AssigneeID,
+			AssignedAt: task.UpdatedAt,
+		}}
+	}
+
+	models := make([]*TaskAssigneeModel, 0, len(task.Assignees))
+	for _, assignee := range task.Assignees {
+		models = append(models, &TaskAssigneeModel{
+			TaskID:      task.ID,
+			UserID:      assignee.UserID,
+			AssignedAt:  assignee.AssignedAt,
+			CompletedAt: assignee.CompletedAt,
+		})
+	}
+	return models
 }
 
 // ToDomain はモデルをドメインエンティティに変換する
@@ -28,11 +79,22 @@ func (m *TaskModel) ToDomain() *domain.Task {
 		Description: m.Description,
 		Status:      domain.TaskStatus(m.Status),
 		Priority:    domain.Priority(m.Priority),
+		Category:    domain.Category(m.Category),
 		AssigneeID:  m.AssigneeID,
 		CreatedBy:   m.CreatedBy,
+		StartDate:   m.StartDate,
 		DueDate:     m.DueDate,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
+
+		EstimateMinutes: m.EstimateMinutes,
+		EstimatePoints:  m.EstimatePoints,
+		ActualMinutes:   m.ActualMinutes,
+		CompletedAt:     m.CompletedAt,
+
+		RequireAllAssignees: m.RequireAllAssignees,
+
+		Checklist: m.Checklist,
 	}
 }
 
@@ -44,10 +106,21 @@ func FromDomain(task *domain.Task) *TaskModel {
 		Description: task.Description,
 		Status:      string(task.Status),
 		Priority:    string(task.Priority),
+		Category:    string(task.Category),
 		AssigneeID:  task.AssigneeID,
 		CreatedBy:   task.CreatedBy,
+		StartDate:   task.StartDate,
 		DueDate:     task.DueDate,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
+
+		EstimateMinutes: task.EstimateMinutes,
+		EstimatePoints:  task.EstimatePoints,
+		ActualMinutes:   task.ActualMinutes,
+		CompletedAt:     task.CompletedAt,
+
+		RequireAllAssignees: task.RequireAllAssignees,
+
+		Checklist: task.Checklist,
 	}
 }
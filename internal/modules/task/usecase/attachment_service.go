@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// AttachmentRepository はタスクの添付ファイルのリポジトリインターフェース
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *domain.Attachment) error
+	// GetAttachment はIDで添付ファイルを取得する（存在しない場合は nil）
+	GetAttachment(ctx context.Context, id string) (*domain.Attachment, error)
+	// ListAttachmentsByTask はタスクの添付ファイルをアップロードした順に取得する
+	ListAttachmentsByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error)
+	// ListAttachmentsByUser はユーザーがアップロードした添付ファイルをアップロードした順に取得する（データのエクスポート用）
+	ListAttachmentsByUser(ctx context.Context, userID string) ([]*domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, id string) error
+	// ListPendingThumbnails はサムネイルが生成待ちの添付ファイルを古い順にlimit件取得する
+	ListPendingThumbnails(ctx context.Context, limit int) ([]*domain.Attachment, error)
+	UpdateThumbnailStatus(ctx context.Context, id string, status domain.ThumbnailStatus) error
+}
+
+// ThumbnailScheduler はサムネイルの生成を依頼するインターフェース
+type ThumbnailScheduler interface {
+	// Notify は生成待ちの添付ファイルがあることを通知する（生成の完了は待たない）
+	Notify()
+}
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	// ErrThumbnailNotReady はサムネイルがまだ生成されていないことを表す
+	ErrThumbnailNotReady = errors.New("thumbnail is not ready")
+	// ErrThumbnailUnavailable は画像ではない、または画像を読み込めなかったためサムネイルがないことを表す
+	ErrThumbnailUnavailable = errors.New("thumbnail is not available")
+)
+
+const (
+	maxAttachmentFilenameLength = 255
+	// maxThumbnailSourcePixels はサムネイルを生成する画像の画素数の上限（展開後の画像でメモリを使い切らないようにする）
+	maxThumbnailSourcePixels = 50_000_000
+)
+
+// AttachmentService はタスクの添付ファイルとそのサムネイルを扱うサービス
+// 添付ファイルを参照できるのはタスクを参照できるユーザー（作成者・担当者・グループのメンバー）
+type AttachmentService struct {
+	AttachmentRepository AttachmentRepository
+	TaskRepository       TaskRepository
+	GroupResolver        GroupTaskResolver
+	Storage              commonDomain.FileStorage
+	// 添付ファイルの合計サイズの上限の確認（未設定の場合は上限なし）
+	Quotas commonDomain.QuotaChecker
+	// サムネイルの生成の依頼先（未設定の場合は生成待ちのまま次の定期実行で生成する）
+	Thumbnails ThumbnailScheduler
+	// 添付ファイル1件あたりの最大サイズ（0の場合は上限なし）
+	MaxSize int64
+	Logger  logger.Logger
+}
+
+// NewAttachmentService はAttachmentServiceのコンストラクタ
+func NewAttachmentService(
+	attachmentRepo AttachmentRepository,
+	taskRepo TaskRepository,
+	groupResolver GroupTaskResolver,
+	storage commonDomain.FileStorage,
+	maxSize int64,
+	logger logger.Logger,
+) *AttachmentService {
+	return &AttachmentService{
+		AttachmentRepository: attachmentRepo,
+		TaskRepository:       taskRepo,
+		GroupResolver:        groupResolver,
+		Storage:              storage,
+		MaxSize:              maxSize,
+		Logger:               logger,
+	}
+}
+
+// AttachmentUpload はアップロードされたファイル
+type AttachmentUpload struct {
+	Filename string
+	Size     int64
+	Body     io.Reader
+}
+
+// UploadAttachment はタスクにファイルを添付する
+// ファイルの形式はクライアントの申告ではなく内容から判定し、画像の場合はサムネイルの生成を依頼する
+func (s *AttachmentService) UploadAttachment(ctx context.Context, taskID, userID string, upload AttachmentUpload) (*domain.Attachment, error) {
+	filename := attachmentFilename(upload.Filename)
+	if filename == "" || len(filename) > maxAttachmentFilenameLength {
+		return nil, fmt.Errorf("%w: invalid filename", ErrInvalidParameter)
+	}
+	if upload.Size <= 0 || upload.Body == nil {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidParameter)
+	}
+	if s.MaxSize > 0 && upload.Size > s.MaxSize {
+		return nil, fmt.Errorf("%w: max %d bytes", ErrAttachmentTooLarge, s.MaxSize)
+	}
+
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.Quotas != nil {
+		if err := s.Quotas.CheckQuota(ctx, commonDomain.QuotaScopeUser, userID, commonDomain.QuotaAttachmentBytes, upload.Size); err != nil {
+			return nil, err
+		}
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	mimeType := http.DetectContentType(head[:n])
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), upload.Body), upload.Size)
+
+	attachment := domain.NewAttachment(uuid.New().String(), task.ID, filename, mimeType, upload.Size, userID)
+	if err := s.Storage.Put(ctx, attachment.ObjectKey(), mimeType, body); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if err := s.AttachmentRepository.CreateAttachment(ctx, attachment); err != nil {
+		s.deleteObjects(ctx, attachment)
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	if attachment.ThumbnailStatus == domain.ThumbnailPending && s.Thumbnails != nil {
+		s.Thumbnails.Notify()
+	}
+	return attachment, nil
+}
+
+// SaveInboundAttachment はメールの添付ファイルをタスクに保存する（InboundEmailServiceから呼ばれる）
+func (s *AttachmentService) SaveInboundAttachment(ctx context.Context, taskID, userID string, attachment domain.InboundAttachment) error {
+	_, err := s.UploadAttachment(ctx, taskID, userID, AttachmentUpload{
+		Filename: attachment.Filename,
+		Size:     int64(len(attachment.Content)),
+		Body:     bytes.NewReader(attachment.Content),
+	})
+	return err
+}
+
+// ListAttachments はタスクの添付ファイルを取得する
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID, userID string) ([]*domain.Attachment, error) {
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+	return s.AttachmentRepository.ListAttachmentsByTask(ctx, taskID)
+}
+
+// GetAttachment は添付ファイルを取得する
+func (s *AttachmentService) GetAttachment(ctx context.Context, id, userID string) (*domain.Attachment, error) {
+	attachment, err := s.AttachmentRepository.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, ErrAttachmentNotFound
+	}
+
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, attachment.TaskID, userID); err != nil {
+		// 削除されたタスクの添付ファイルは存在しないものとして扱う
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// OpenAttachment は添付ファイルの本体を開く（呼び出し側で閉じる必要がある）
+func (s *AttachmentService) OpenAttachment(ctx context.Context, id, userID string) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.GetAttachment(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.Storage.Get(ctx, attachment.ObjectKey())
+	if errors.Is(err, commonDomain.ErrObjectNotFound) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+// OpenThumbnail は添付ファイルのサムネイルを開く（呼び出し側で閉じる必要がある）
+// 生成待ちの場合はErrThumbnailNotReady、画像ではない場合や生成に失敗した場合はErrThumbnailUnavailableを返す
+func (s *AttachmentService) OpenThumbnail(ctx context.Context, id, userID string, size domain.ThumbnailSize) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.GetAttachment(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch attachment.ThumbnailStatus {
+	case domain.ThumbnailPending:
+		return nil, nil, ErrThumbnailNotReady
+	case domain.ThumbnailReady:
+	default:
+		return nil, nil, ErrThumbnailUnavailable
+	}
+
+	body, err := s.Storage.Get(ctx, attachment.ThumbnailKey(size))
+	if errors.Is(err, commonDomain.ErrObjectNotFound) {
+		return nil, nil, ErrThumbnailUnavailable
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+// DeleteAttachment は添付ファイルを削除する（タスクを変更できる、アップロードしたユーザーとタスクの作成者のみ）
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, id, userID string) error {
+	attachment, err := s.GetAttachment(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, attachment.TaskID, userID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != userID && task.CreatedBy != userID {
+		return ErrPermissionDenied
+	}
+
+	if err := s.AttachmentRepository.DeleteAttachment(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	s.deleteObjects(ctx, attachment)
+	return nil
+}
+
+// ProcessPendingThumbnails は生成待ちの添付ファイルのサムネイルを最大limit件生成し、処理した件数を返す
+// ストレージの障害などで生成できなかった添付ファイルは生成待ちのまま残し、次回に再試行する
+func (s *AttachmentService) ProcessPendingThumbnails(ctx context.Context, limit int) (int, error) {
+	attachments, err := s.AttachmentRepository.ListPendingThumbnails(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending thumbnails: %w", err)
+	}
+
+	processed := 0
+	for _, attachment := range attachments {
+		if err := s.GenerateThumbnails(ctx, attachment); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to generate thumbnails",
+				logger.Any("attachmentID", attachment.ID),
+				logger.Error(err))
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// GenerateThumbnails は添付ファイルの画像から全サイズのサムネイルを生成して保存する
+// 画像として読み込めない場合は生成失敗として記録し、エラーは返さない
+func (s *AttachmentService) GenerateThumbnails(ctx context.Context, attachment *domain.Attachment) error {
+	img, err := s.loadImage(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	if img == nil {
+		return s.AttachmentRepository.UpdateThumbnailStatus(ctx, attachment.ID, domain.ThumbnailFailed)
+	}
+
+	format := imaging.PNG
+	if attachment.ThumbnailMimeType() == "image/jpeg" {
+		format = imaging.JPEG
+	}
+	for _, size := range domain.ThumbnailSizes {
+		// 元の画像より大きいサイズは拡大せず、元の大きさのまま保存する
+		thumbnail := imaging.Fit(img, size.Pixels(), size.Pixels(), imaging.Lanczos)
+
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, thumbnail, format, imaging.JPEGQuality(85)); err != nil {
+			return fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		if err := s.Storage.Put(ctx, attachment.ThumbnailKey(size), attachment.ThumbnailMimeType(), &buf); err != nil {
+			return fmt.Errorf("failed to store thumbnail: %w", err)
+		}
+	}
+	return s.AttachmentRepository.UpdateThumbnailStatus(ctx, attachment.ID, domain.ThumbnailReady)
+}
+
+// loadImage は添付ファイルの画像を読み込む（画像として読み込めない場合は nil）
+func (s *AttachmentService) loadImage(ctx context.Context, attachment *domain.Attachment) (image.Image, error) {
+	reader, err := s.Storage.Get(ctx, attachment.ObjectKey())
+	if errors.Is(err, commonDomain.ErrObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || int64(config.Width)*int64(config.Height) > maxThumbnailSourcePixels {
+		return nil, nil
+	}
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, nil
+	}
+	return img, nil
+}
+
+// deleteObjects は添付ファイルの本体とサムネイルをストレージから削除する（失敗はログに記録する）
+func (s *AttachmentService) deleteObjects(ctx context.Context, attachment *domain.Attachment) {
+	keys := []string{attachment.ObjectKey()}
+	for _, size := range domain.ThumbnailSizes {
+		keys = append(keys, attachment.ThumbnailKey(size))
+	}
+	for _, key := range keys {
+		if err := s.Storage.Delete(ctx, key); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to delete attachment object",
+				logger.Any("attachmentID", attachment.ID),
+				logger.Any("key", key),
+				logger.Error(err))
+		}
+	}
+}
+
+// attachmentFilename はクライアントが送信したファイル名からディレクトリ部分を除く
+func attachmentFilename(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
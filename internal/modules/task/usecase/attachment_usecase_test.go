@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonStorage "github.com/hryt430/Yotei+/internal/common/infrastructure/storage"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=attachment_service.go -destination=mocks/mock_attachment.go -package=mocks
+
+type attachmentTestMocks struct {
+	taskRepo       *mocks.MockTaskRepository
+	attachmentRepo *mocks.MockAttachmentRepository
+	resolver       *mocks.MockGroupTaskResolver
+	scheduler      *mocks.MockThumbnailScheduler
+	storage        *commonStorage.MemoryStorage
+}
+
+func newAttachmentTestService(t *testing.T) (*AttachmentService, *attachmentTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &attachmentTestMocks{
+		taskRepo:       mocks.NewMockTaskRepository(ctrl),
+		attachmentRepo: mocks.NewMockAttachmentRepository(ctrl),
+		resolver:       mocks.NewMockGroupTaskResolver(ctrl),
+		scheduler:      mocks.NewMockThumbnailScheduler(ctrl),
+		storage:        commonStorage.NewMemoryStorage(),
+	}
+	service := NewAttachmentService(m.attachmentRepo, m.taskRepo, m.resolver, m.storage, 1<<20, *createTestLogger())
+	service.Thumbnails = m.scheduler
+	return service, m
+}
+
+// testPNG は指定した大きさのPNG画像を作成する
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	ctx := context.Background()
+	task := &domain.Task{ID: "task-1", CreatedBy: "owner"}
+
+	t.Run("images are stored and queued for thumbnails", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		data := testPNG(t, 10, 10)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.attachmentRepo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Return(nil)
+		m.scheduler.EXPECT().Notify()
+
+		// クライアントの申告ではなく内容から形式を判定する
+		attachment, err := service.UploadAttachment(ctx, "task-1", "owner", AttachmentUpload{
+			Filename: `C:\Users\owner\photo.txt`,
+			Size:     int64(len(data)),
+			Body:     bytes.NewReader(data),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "photo.txt", attachment.Filename)
+		assert.Equal(t, "image/png", attachment.MimeType)
+		assert.Equal(t, domain.ThumbnailPending, attachment.ThumbnailStatus)
+
+		stored, err := m.storage.Get(ctx, attachment.ObjectKey())
+		require.NoError(t, err)
+		content, _ := io.ReadAll(stored)
+		assert.Equal(t, data, content)
+	})
+
+	t.Run("other files are not queued", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.attachmentRepo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Return(nil)
+
+		attachment, err := service.UploadAttachment(ctx, "task-1", "owner", AttachmentUpload{
+			Filename: "notes.txt",
+			Size:     5,
+			Body:     bytes.NewReader([]byte("hello")),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ThumbnailNone, attachment.ThumbnailStatus)
+	})
+
+	t.Run("rejects files over the size limit", func(t *testing.T) {
+		service, _ := newAttachmentTestService(t)
+
+		_, err := service.UploadAttachment(ctx, "task-1", "owner", AttachmentUpload{
+			Filename: "large.bin",
+			Size:     2 << 20,
+			Body:     bytes.NewReader(nil),
+		})
+		assert.ErrorIs(t, err, ErrAttachmentTooLarge)
+	})
+
+	t.Run("rejects users who cannot see the task", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+
+		_, err := service.UploadAttachment(ctx, "task-1", "stranger", AttachmentUpload{
+			Filename: "notes.txt",
+			Size:     5,
+			Body:     bytes.NewReader([]byte("hello")),
+		})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("rejects group members who can only view the task", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		_, err := service.UploadAttachment(ctx, "task-1", "guest", AttachmentUpload{
+			Filename: "notes.txt",
+			Size:     5,
+			Body:     bytes.NewReader([]byte("hello")),
+		})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+}
+
+func TestAttachmentService_GenerateThumbnails(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("generates every size without enlarging", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		attachment := domain.NewAttachment("att-1", "task-1", "wide.png", "image/png", 0, "owner")
+		require.NoError(t, m.storage.Put(ctx, attachment.ObjectKey(), "image/png", bytes.NewReader(testPNG(t, 400, 200))))
+		m.attachmentRepo.EXPECT().UpdateThumbnailStatus(gomock.Any(), "att-1", domain.ThumbnailReady).Return(nil)
+
+		require.NoError(t, service.GenerateThumbnails(ctx, attachment))
+
+		expected := map[domain.ThumbnailSize]image.Point{
+			domain.ThumbnailSmall:  {X: 128, Y: 64},
+			domain.ThumbnailMedium: {X: 320, Y: 160},
+			domain.ThumbnailLarge:  {X: 400, Y: 200},
+		}
+		for size, bounds := range expected {
+			reader, err := m.storage.Get(ctx, attachment.ThumbnailKey(size))
+			require.NoError(t, err, size)
+			config, err := png.DecodeConfig(reader)
+			require.NoError(t, err, size)
+			assert.Equal(t, bounds, image.Point{X: config.Width, Y: config.Height}, size)
+		}
+	})
+
+	t.Run("marks unreadable images as failed", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		attachment := domain.NewAttachment("att-1", "task-1", "broken.png", "image/png", 0, "owner")
+		require.NoError(t, m.storage.Put(ctx, attachment.ObjectKey(), "image/png", bytes.NewReader([]byte("\x89PNG broken"))))
+		m.attachmentRepo.EXPECT().UpdateThumbnailStatus(gomock.Any(), "att-1", domain.ThumbnailFailed).Return(nil)
+
+		require.NoError(t, service.GenerateThumbnails(ctx, attachment))
+	})
+}
+
+func TestAttachmentService_OpenThumbnail(t *testing.T) {
+	ctx := context.Background()
+	task := &domain.Task{ID: "task-1", CreatedBy: "owner"}
+
+	tests := []struct {
+		name          string
+		status        domain.ThumbnailStatus
+		expectedError error
+	}{
+		{name: "pending thumbnails are not ready", status: domain.ThumbnailPending, expectedError: ErrThumbnailNotReady},
+		{name: "non-image files have no thumbnail", status: domain.ThumbnailNone, expectedError: ErrThumbnailUnavailable},
+		{name: "failed thumbnails are unavailable", status: domain.ThumbnailFailed, expectedError: ErrThumbnailUnavailable},
+		{name: "missing objects are unavailable", status: domain.ThumbnailReady, expectedError: ErrThumbnailUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newAttachmentTestService(t)
+			attachment := domain.NewAttachment("att-1", "task-1", "photo.png", "image/png", 10, "owner")
+			attachment.ThumbnailStatus = tt.status
+			m.attachmentRepo.EXPECT().GetAttachment(gomock.Any(), "att-1").Return(attachment, nil)
+			m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+
+			_, _, err := service.OpenThumbnail(ctx, "att-1", "owner", domain.ThumbnailSmall)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	ctx := context.Background()
+	task := &domain.Task{ID: "task-1", CreatedBy: "owner", Assignees: []*domain.TaskAssignee{{UserID: "assignee"}}}
+
+	t.Run("only the uploader or the task creator can delete", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		attachment := domain.NewAttachment("att-1", "task-1", "notes.txt", "text/plain", 10, "owner")
+		m.attachmentRepo.EXPECT().GetAttachment(gomock.Any(), "att-1").Return(attachment, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil).Times(2)
+
+		err := service.DeleteAttachment(ctx, "att-1", "assignee")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("uploaders who can only view the task cannot delete", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		attachment := domain.NewAttachment("att-1", "task-1", "notes.txt", "text/plain", 10, "guest")
+		m.attachmentRepo.EXPECT().GetAttachment(gomock.Any(), "att-1").Return(attachment, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil).Times(2)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil).Times(2)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionViewTasks).Return(true, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		err := service.DeleteAttachment(ctx, "att-1", "guest")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("deletes the file and its thumbnails", func(t *testing.T) {
+		service, m := newAttachmentTestService(t)
+		attachment := domain.NewAttachment("att-1", "task-1", "photo.png", "image/png", 10, "assignee")
+		require.NoError(t, m.storage.Put(ctx, attachment.ObjectKey(), "image/png", bytes.NewReader([]byte("data"))))
+		require.NoError(t, m.storage.Put(ctx, attachment.ThumbnailKey(domain.ThumbnailSmall), "image/png", bytes.NewReader([]byte("data"))))
+		m.attachmentRepo.EXPECT().GetAttachment(gomock.Any(), "att-1").Return(attachment, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil).Times(2)
+		m.attachmentRepo.EXPECT().DeleteAttachment(gomock.Any(), "att-1").Return(nil)
+
+		require.NoError(t, service.DeleteAttachment(ctx, "att-1", "assignee"))
+
+		_, err := m.storage.Get(ctx, attachment.ObjectKey())
+		assert.Error(t, err)
+		_, err = m.storage.Get(ctx, attachment.ThumbnailKey(domain.ThumbnailSmall))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// CalendarService はカレンダー表示用の予定を扱うサービス
+type CalendarService struct {
+	StatsRepository    StatsRepository
+	WorkloadRepository WorkloadRepository
+	Logger             logger.Logger
+
+	// カレンダーに表示する祝日（未設定の場合は表示しない）
+	Holidays holiday.Provider
+}
+
+// NewCalendarService はCalendarServiceのコンストラクタ
+func NewCalendarService(
+	statsRepo StatsRepository,
+	workloadRepo WorkloadRepository,
+	logger logger.Logger,
+) *CalendarService {
+	return &CalendarService{
+		StatsRepository:    statsRepo,
+		WorkloadRepository: workloadRepo,
+		Logger:             logger,
+	}
+}
+
+// GetCalendar は指定期間のタスクの着手予定日時・期限を日ごとにまとめ、祝日の名前を付けて取得する
+// from・toは暦日として扱い、timezoneが空の場合はユーザーの勤務時間設定のタイムゾーンで日を区切る
+func (s *CalendarService) GetCalendar(ctx context.Context, userID string, from, to time.Time, timezone string) (*domain.Calendar, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must be after 'from'", ErrInvalidParameter)
+	}
+	if to.Sub(from) >= domain.MaxCalendarRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range must be within %d days", ErrInvalidParameter, domain.MaxCalendarRangeDays)
+	}
+
+	loc, err := resolveUserLocation(ctx, s.WorkloadRepository, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	tasks, err := s.StatsRepository.GetScheduledTasksByDateRange(ctx, userID, start, end)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get scheduled tasks",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get scheduled tasks: %w", err)
+	}
+	for _, task := range tasks {
+		task.PrepareForResponse()
+	}
+
+	return domain.NewCalendar(tasks, start, end, loc, s.Holidays), nil
+}
+
+// resolveUserLocation は日の区切りに使うタイムゾーンを決定する（指定がなければ勤務時間設定、未設定なら既定値）
+func resolveUserLocation(ctx context.Context, workloadRepo WorkloadRepository, userID, timezone string) (*time.Location, error) {
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timezone: %s", ErrInvalidParameter, timezone)
+		}
+		return loc, nil
+	}
+
+	hours, err := workloadRepo.GetWorkingHours(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working hours: %w", err)
+	}
+	if hours == nil {
+		hours = domain.DefaultWorkingHours(userID)
+	}
+	return hours.Location(), nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+func newCalendarTestService(t *testing.T) (*CalendarService, *mocks.MockStatsRepository, *mocks.MockWorkloadRepository) {
+	ctrl := gomock.NewController(t)
+	statsRepo := mocks.NewMockStatsRepository(ctrl)
+	workloadRepo := mocks.NewMockWorkloadRepository(ctrl)
+	return NewCalendarService(statsRepo, workloadRepo, *createTestLogger()), statsRepo, workloadRepo
+}
+
+func TestCalendarService_GetCalendar(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("uses the timezone from working hours", func(t *testing.T) {
+		service, statsRepo, workloadRepo := newCalendarTestService(t)
+		hours := domain.DefaultWorkingHours("user-1")
+		hours.Timezone = "America/New_York"
+		workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+
+		due := time.Date(2024, 6, 10, 2, 0, 0, 0, time.UTC) // 2024-06-09 22:00 in New York
+		task := &domain.Task{ID: "task-1", DueDate: &due}
+		statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+				assert.Equal(t, "2024-06-01T00:00:00-04:00", start.Format(time.RFC3339))
+				assert.Equal(t, "2024-06-30T23:59:59-04:00", end.Format(time.RFC3339))
+				return []*domain.Task{task}, nil
+			})
+
+		calendar, err := service.GetCalendar(context.Background(), "user-1", from, to, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", calendar.Timezone)
+		require.Len(t, calendar.Days, 30)
+		assert.Len(t, calendar.Days[8].Entries, 1)
+		assert.Equal(t, "2024-06-09", calendar.Days[8].Date)
+	})
+
+	t.Run("explicit timezone overrides working hours", func(t *testing.T) {
+		service, statsRepo, _ := newCalendarTestService(t)
+		statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		calendar, err := service.GetCalendar(context.Background(), "user-1", from, to, "UTC")
+
+		require.NoError(t, err)
+		assert.Equal(t, "UTC", calendar.Timezone)
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		service, _, _ := newCalendarTestService(t)
+
+		_, err := service.GetCalendar(context.Background(), "user-1", from, to, "Mars/Olympus")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("range too long", func(t *testing.T) {
+		service, _, _ := newCalendarTestService(t)
+
+		_, err := service.GetCalendar(context.Background(), "user-1", from, from.AddDate(0, 0, domain.MaxCalendarRangeDays), "UTC")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("to before from", func(t *testing.T) {
+		service, _, _ := newCalendarTestService(t)
+
+		_, err := service.GetCalendar(context.Background(), "user-1", to, from, "UTC")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
@@ -0,0 +1,201 @@
+package classification
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// タイトルは説明より短く要点を表すため、タイトル中のキーワードを重く数える
+const (
+	titleWeight       = 2
+	descriptionWeight = 1
+)
+
+// CategoryRule はキーワードとカテゴリの対応
+type CategoryRule struct {
+	Category domain.Category
+	Keywords []string
+}
+
+// PriorityRule はキーワードと優先度の対応
+type PriorityRule struct {
+	Priority domain.Priority
+	Keywords []string
+}
+
+// Rules はキーワードによる分類のルール
+// 同点の場合は先に並べたルールを優先する
+type Rules struct {
+	Categories []CategoryRule
+	Priorities []PriorityRule
+}
+
+// DefaultRules は既定の分類ルール
+// 英字のキーワードは単語単位、日本語のキーワードは部分一致で判定する
+var DefaultRules = Rules{
+	Categories: []CategoryRule{
+		{domain.CategoryWork, []string{
+			"会議", "ミーティング", "打ち合わせ", "打合せ", "商談", "資料", "報告", "レポート", "議事録", "提出",
+			"納品", "見積", "請求", "顧客", "取引先", "上司", "出張", "プレゼン", "稟議", "経費",
+			"meeting", "report", "client", "customer", "invoice", "presentation", "proposal", "review", "deploy", "release",
+		}},
+		{domain.CategoryStudy, []string{
+			"勉強", "学習", "試験", "宿題", "課題", "講義", "授業", "復習", "予習", "資格", "参考書", "単語",
+			"study", "exam", "homework", "lecture", "course", "class", "learn",
+		}},
+		{domain.CategoryHealth, []string{
+			"病院", "歯医者", "通院", "診察", "健康診断", "健診", "薬", "運動", "ジム", "ランニング", "筋トレ", "ヨガ", "散歩", "ストレッチ",
+			"doctor", "dentist", "hospital", "medicine", "gym", "workout", "running", "yoga",
+		}},
+		{domain.CategoryShopping, []string{
+			"買う", "買い物", "買い出し", "購入", "注文", "スーパー", "日用品", "食材", "牛乳",
+			"buy", "purchase", "groceries", "grocery", "shopping",
+		}},
+		{domain.CategoryPersonal, []string{
+			"家族", "誕生日", "掃除", "洗濯", "料理", "旅行", "友達", "引っ越し", "役所", "振込", "ゴミ",
+			"birthday", "family", "cleaning", "laundry", "travel", "trip", "friend",
+		}},
+	},
+	Priorities: []PriorityRule{
+		{domain.PriorityHigh, []string{
+			"至急", "大至急", "緊急", "急ぎ", "今日中", "本日中", "締め切り", "締切", "重要", "必ず",
+			"asap", "urgent", "important", "critical", "deadline",
+		}},
+		{domain.PriorityLow, []string{
+			"いつか", "そのうち", "暇なとき", "時間があれば", "余裕があれば", "できれば", "任意",
+			"someday", "maybe", "eventually", "optional",
+		}},
+	},
+}
+
+// KeywordClassifier はキーワードのルールでタスクのカテゴリと優先度を推定する
+type KeywordClassifier struct {
+	rules Rules
+}
+
+// NewKeywordClassifier は新しいKeywordClassifierを作成する
+func NewKeywordClassifier(rules Rules) *KeywordClassifier {
+	return &KeywordClassifier{rules: rules}
+}
+
+// Classify はタイトル・説明に含まれるキーワードからカテゴリと優先度を推定する
+// 確信度は最も多く一致したカテゴリ（優先度）の重みの、一致したすべての重みに対する割合
+func (c *KeywordClassifier) Classify(ctx context.Context, title, description string) (*domain.Classification, error) {
+	texts := []weightedText{
+		{normalize(title), titleWeight},
+		{normalize(description), descriptionWeight},
+	}
+	result := domain.NewUnclassified()
+	var keywords []string
+
+	categoryScores := make([]int, len(c.rules.Categories))
+	for i, rule := range c.rules.Categories {
+		categoryScores[i], keywords = score(texts, rule.Keywords, keywords)
+	}
+	if best, confidence := pickBest(categoryScores); best >= 0 {
+		result.Category = c.rules.Categories[best].Category
+		result.CategoryConfidence = confidence
+	}
+
+	priorityScores := make([]int, len(c.rules.Priorities))
+	for i, rule := range c.rules.Priorities {
+		priorityScores[i], keywords = score(texts, rule.Keywords, keywords)
+	}
+	if best, confidence := pickBest(priorityScores); best >= 0 {
+		result.Priority = c.rules.Priorities[best].Priority
+		result.PriorityConfidence = confidence
+	}
+
+	result.Keywords = keywords
+	return result, nil
+}
+
+type weightedText struct {
+	text   string
+	weight int
+}
+
+// score はキーワードが一致した文章の重みの合計と、一致したキーワードを返す
+func score(texts []weightedText, ruleKeywords []string, matched []string) (int, []string) {
+	total := 0
+	for _, keyword := range ruleKeywords {
+		hit := false
+		for _, t := range texts {
+			if containsKeyword(t.text, keyword) {
+				total += t.weight
+				hit = true
+			}
+		}
+		if hit {
+			matched = append(matched, keyword)
+		}
+	}
+	return total, matched
+}
+
+// pickBest は最も点数の高いルールの位置と確信度を返す（一致がない場合は-1）
+func pickBest(scores []int) (int, float64) {
+	best, sum := -1, 0
+	for i, s := range scores {
+		sum += s
+		if s > 0 && (best < 0 || s > scores[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return -1, 0
+	}
+	return best, math.Round(float64(scores[best])/float64(sum)*100) / 100
+}
+
+// containsKeyword は文章にキーワードが含まれるかを返す
+// 英字のキーワードは "run" が "brunch" に一致しないよう、前後が英字でない位置のみ一致とする
+func containsKeyword(text, keyword string) bool {
+	if !isASCIIWord(keyword) {
+		return strings.Contains(text, keyword)
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], keyword)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(keyword)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isASCIILetter(before) && !isASCIILetter(after) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isASCIIWord(s string) bool {
+	for _, r := range s {
+		if !isASCIILetter(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isASCIILetter(r rune) bool {
+	return r < unicode.MaxASCII && unicode.IsLetter(r)
+}
+
+// normalize は全角英数字を半角にし、小文字にする
+func normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r >= '！' && r <= '～' {
+			r = r - '！' + '!'
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
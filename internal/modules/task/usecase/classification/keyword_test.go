@@ -0,0 +1,110 @@
+package classification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+func TestKeywordClassifier_Classify(t *testing.T) {
+	tests := []struct {
+		name         string
+		title        string
+		description  string
+		wantCategory domain.Category
+		wantPriority domain.Priority
+		wantKeywords []string
+	}{
+		{
+			name:         "japanese work task with urgency",
+			title:        "至急 取引先への見積を送る",
+			wantCategory: domain.CategoryWork,
+			wantPriority: domain.PriorityHigh,
+			wantKeywords: []string{"見積", "取引先", "至急"},
+		},
+		{
+			name:         "english shopping task",
+			title:        "Buy groceries",
+			wantCategory: domain.CategoryShopping,
+			wantPriority: domain.PriorityMedium,
+			wantKeywords: []string{"buy", "groceries"},
+		},
+		{
+			name:         "low priority from description",
+			title:        "ヨガ教室を探す",
+			description:  "時間があれば",
+			wantCategory: domain.CategoryHealth,
+			wantPriority: domain.PriorityLow,
+		},
+		{
+			name:         "full-width letters are normalized",
+			title:        "ＵＲＧＥＮＴ： exam prep",
+			wantCategory: domain.CategoryStudy,
+			wantPriority: domain.PriorityHigh,
+		},
+		{
+			name:         "english keywords match whole words only",
+			title:        "brunch with Bob",
+			wantCategory: domain.CategoryOther,
+			wantPriority: domain.PriorityMedium,
+		},
+		{
+			name:         "no keywords",
+			title:        "あれをやる",
+			wantCategory: domain.CategoryOther,
+			wantPriority: domain.PriorityMedium,
+		},
+	}
+
+	classifier := NewKeywordClassifier(DefaultRules)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := classifier.Classify(context.Background(), tt.title, tt.description)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCategory, result.Category)
+			assert.Equal(t, tt.wantPriority, result.Priority)
+			assert.Equal(t, tt.wantCategory != domain.CategoryOther, result.HasCategory())
+			if tt.wantKeywords != nil {
+				assert.ElementsMatch(t, tt.wantKeywords, result.Keywords)
+			}
+		})
+	}
+}
+
+func TestKeywordClassifier_Confidence(t *testing.T) {
+	classifier := NewKeywordClassifier(DefaultRules)
+
+	t.Run("title keywords outweigh description keywords", func(t *testing.T) {
+		result, err := classifier.Classify(context.Background(), "会議の準備", "帰りに牛乳")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CategoryWork, result.Category)
+		assert.InDelta(t, 0.67, result.CategoryConfidence, 0.01)
+	})
+
+	t.Run("single matching category is fully confident", func(t *testing.T) {
+		result, err := classifier.Classify(context.Background(), "歯医者", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, result.CategoryConfidence)
+		assert.Zero(t, result.PriorityConfidence)
+	})
+
+	t.Run("ties go to the earlier rule", func(t *testing.T) {
+		rules := Rules{Categories: []CategoryRule{
+			{domain.CategoryPersonal, []string{"a"}},
+			{domain.CategoryWork, []string{"b"}},
+		}}
+
+		result, err := NewKeywordClassifier(rules).Classify(context.Background(), "a b", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CategoryPersonal, result.Category)
+		assert.Equal(t, 0.5, result.CategoryConfidence)
+	})
+}
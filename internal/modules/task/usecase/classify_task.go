@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/parsing"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskClassifier はタスクのタイトル・説明からカテゴリと優先度を推定するインターフェース
+// 現在はキーワードのルールで推定し、将来は学習済みモデルなどに差し替えられるようにする
+type TaskClassifier interface {
+	Classify(ctx context.Context, title, description string) (*domain.Classification, error)
+}
+
+// ClassifyTask はタスクのカテゴリと優先度を推定する（タスクは作成しない）
+// 推定しない設定の場合は推定なしの結果を返す
+func (s *TaskService) ClassifyTask(ctx context.Context, title, description string) (*domain.Classification, error) {
+	if strings.TrimSpace(title) == "" && strings.TrimSpace(description) == "" {
+		return nil, fmt.Errorf("%w: title or description is required", ErrInvalidParameter)
+	}
+	if s.Classifier == nil {
+		return domain.NewUnclassified(), nil
+	}
+
+	classification, err := s.Classifier.Classify(ctx, title, description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify task: %w", err)
+	}
+	return classification, nil
+}
+
+// suggestClassification はクイック追加で指定されなかったカテゴリ・優先度を推定結果で補う
+// 推定に失敗してもタスクは作成できるため、エラーはログのみ
+func (s *TaskService) suggestClassification(ctx context.Context, parsed *parsing.Result) {
+	if s.Classifier == nil || (parsed.Category != nil && parsed.Priority != nil) {
+		return
+	}
+
+	classification, err := s.Classifier.Classify(ctx, parsed.Title, "")
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to classify quick add task", logger.Error(err))
+		return
+	}
+	if parsed.Category == nil && classification.HasCategory() {
+		category := classification.Category
+		parsed.Category = &category
+		parsed.CategorySuggested = true
+	}
+	if parsed.Priority == nil && classification.HasPriority() {
+		priority := classification.Priority
+		parsed.Priority = &priority
+		parsed.PrioritySuggested = true
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=classify_task.go -destination=mocks/mock_classify_task.go -package=mocks
+
+func newClassifyTestService(t *testing.T) (*TaskService, *mocks.MockTaskClassifier) {
+	ctrl := gomock.NewController(t)
+	classifier := mocks.NewMockTaskClassifier(ctrl)
+	service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.Classifier = classifier
+	return service, classifier
+}
+
+func TestTaskService_ClassifyTask(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the classifier result", func(t *testing.T) {
+		service, classifier := newClassifyTestService(t)
+		expected := &domain.Classification{Category: domain.CategoryWork, CategoryConfidence: 1, Priority: domain.PriorityMedium}
+		classifier.EXPECT().Classify(gomock.Any(), "会議の資料", "").Return(expected, nil)
+
+		result, err := service.ClassifyTask(ctx, "会議の資料", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("without a classifier nothing is suggested", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		result, err := service.ClassifyTask(ctx, "会議の資料", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CategoryOther, result.Category)
+		assert.False(t, result.HasCategory())
+		assert.False(t, result.HasPriority())
+	})
+
+	t.Run("empty text is rejected", func(t *testing.T) {
+		service, _ := newClassifyTestService(t)
+
+		_, err := service.ClassifyTask(ctx, " ", "")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestTaskService_ParseQuickAdd_SuggestsClassification(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 12, 10, 0, 0, 0, time.UTC)
+	suggestion := &domain.Classification{
+		Category: domain.CategoryShopping, CategoryConfidence: 1,
+		Priority: domain.PriorityHigh, PriorityConfidence: 1,
+	}
+
+	t.Run("fills in missing category and priority", func(t *testing.T) {
+		service, classifier := newClassifyTestService(t)
+		classifier.EXPECT().Classify(gomock.Any(), "牛乳を買う", "").Return(suggestion, nil)
+
+		parsed, err := service.ParseQuickAdd(ctx, "牛乳を買う 明日", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CategoryShopping, parsed.CategoryOrDefault())
+		assert.Equal(t, domain.PriorityHigh, parsed.PriorityOrDefault())
+		assert.True(t, parsed.CategorySuggested)
+		assert.True(t, parsed.PrioritySuggested)
+	})
+
+	t.Run("explicit tags win over suggestions", func(t *testing.T) {
+		service, classifier := newClassifyTestService(t)
+		classifier.EXPECT().Classify(gomock.Any(), "牛乳を買う", "").Return(suggestion, nil)
+
+		parsed, err := service.ParseQuickAdd(ctx, "牛乳を買う #personal", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CategoryPersonal, parsed.CategoryOrDefault())
+		assert.False(t, parsed.CategorySuggested)
+		assert.True(t, parsed.PrioritySuggested)
+	})
+
+	t.Run("classifier is not called when both are given", func(t *testing.T) {
+		service, _ := newClassifyTestService(t)
+
+		parsed, err := service.ParseQuickAdd(ctx, "牛乳を買う #personal !low", now)
+
+		require.NoError(t, err)
+		assert.False(t, parsed.CategorySuggested)
+		assert.False(t, parsed.PrioritySuggested)
+	})
+
+	t.Run("classifier errors fall back to defaults", func(t *testing.T) {
+		service, classifier := newClassifyTestService(t)
+		classifier.EXPECT().Classify(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("model unavailable"))
+
+		parsed, err := service.ParseQuickAdd(ctx, "牛乳を買う", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CategoryOther, parsed.CategoryOrDefault())
+		assert.False(t, parsed.CategorySuggested)
+	})
+}
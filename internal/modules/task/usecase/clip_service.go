@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ClipTaskCreator はクリップからタスクを作成するインターフェース（TaskServiceが実装する）
+type ClipTaskCreator interface {
+	CreateTask(ctx context.Context, title, description string, priority domain.Priority, category domain.Category, createdBy string) (*domain.Task, error)
+}
+
+// ClipLinkCreator はクリップしたページのリンクをタスクに追加するインターフェース（TaskLinkServiceが実装する）
+type ClipLinkCreator interface {
+	AddClipLink(ctx context.Context, taskID, userID, rawURL, title string) (*domain.TaskLink, error)
+}
+
+// ClipService はブラウザの拡張機能からクリップしたページからタスクを作成するサービス
+type ClipService struct {
+	Tasks  ClipTaskCreator
+	Links  ClipLinkCreator
+	Logger logger.Logger
+}
+
+// NewClipService はClipServiceのコンストラクタ
+func NewClipService(tasks ClipTaskCreator, links ClipLinkCreator, logger logger.Logger) *ClipService {
+	return &ClipService{
+		Tasks:  tasks,
+		Links:  links,
+		Logger: logger,
+	}
+}
+
+// Clip はページのタイトルをタイトル、メモと選択範囲を説明としてタスクを作成し、ページのリンクを追加する
+// リンクの追加に失敗してもタスクは作成済みのため、ログに記録してリンクなし（nil）で返す
+func (s *ClipService) Clip(ctx context.Context, userID string, clip domain.Clip) (*domain.Task, *domain.TaskLink, error) {
+	if err := clip.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+
+	task, err := s.Tasks.CreateTask(ctx, clip.TaskTitle(), clip.TaskDescription(), domain.PriorityMedium, domain.CategoryOther, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	link, err := s.Links.AddClipLink(ctx, task.ID, userID, clip.URL, clip.LinkTitle())
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to add clipped link",
+			logger.Any("taskID", task.ID), logger.Error(err))
+		return task, nil, nil
+	}
+	return task, link, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=clip_service.go -destination=mocks/mock_clip.go -package=mocks
+
+func newClipTestService(t *testing.T) (*ClipService, *mocks.MockClipTaskCreator, *mocks.MockClipLinkCreator) {
+	ctrl := gomock.NewController(t)
+	tasks := mocks.NewMockClipTaskCreator(ctrl)
+	links := mocks.NewMockClipLinkCreator(ctrl)
+	return NewClipService(tasks, links, *createTestLogger()), tasks, links
+}
+
+func TestClipService_Clip(t *testing.T) {
+	ctx := context.Background()
+	clip := domain.Clip{
+		URL:       "https://example.com/article",
+		Title:     "記事のタイトル",
+		Selection: "引用する部分",
+		Note:      "後で読む",
+	}
+
+	t.Run("creates a task with a link to the page", func(t *testing.T) {
+		service, tasks, links := newClipTestService(t)
+		task := newIssueLinkTestTask("task-1", "user-1")
+		link := &domain.TaskLink{ID: "link-1", TaskID: "task-1", URL: clip.URL}
+		tasks.EXPECT().CreateTask(gomock.Any(), "記事のタイトル", "後で読む\n\n> 引用する部分", domain.PriorityMedium, domain.CategoryOther, "user-1").
+			Return(task, nil)
+		links.EXPECT().AddClipLink(gomock.Any(), "task-1", "user-1", clip.URL, "記事のタイトル").Return(link, nil)
+
+		gotTask, gotLink, err := service.Clip(ctx, "user-1", clip)
+		require.NoError(t, err)
+		assert.Equal(t, task, gotTask)
+		assert.Equal(t, link, gotLink)
+	})
+
+	t.Run("keeps the task when adding the link fails", func(t *testing.T) {
+		service, tasks, links := newClipTestService(t)
+		tasks.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "user-1").
+			Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		links.EXPECT().AddClipLink(gomock.Any(), "task-1", "user-1", gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		task, link, err := service.Clip(ctx, "user-1", clip)
+		require.NoError(t, err)
+		assert.Equal(t, "task-1", task.ID)
+		assert.Nil(t, link)
+	})
+
+	t.Run("rejects invalid urls", func(t *testing.T) {
+		service, _, _ := newClipTestService(t)
+		_, _, err := service.Clip(ctx, "user-1", domain.Clip{URL: "chrome://newtab"})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("returns task creation errors", func(t *testing.T) {
+		service, tasks, _ := newClipTestService(t)
+		tasks.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "user-1").
+			Return(nil, assert.AnError)
+
+		_, _, err := service.Clip(ctx, "user-1", clip)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
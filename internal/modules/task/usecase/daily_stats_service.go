@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// dailyStatsReconcileDays は夜間の再集計で集計し直す過去の日数
+	dailyStatsReconcileDays = 7
+	// maxDailyStatsReconcileRows は1回の再集計で集計し直す行数の上限
+	maxDailyStatsReconcileRows = 10000
+)
+
+// DailyStatsRepository は日次統計の集計テーブルのリポジトリインターフェース
+type DailyStatsRepository interface {
+	// GetSnapshots はfromからtoまで（YYYY-MM-DD、toを含む）のユーザーの行を日付をキーに取得する
+	GetSnapshots(ctx context.Context, userID, from, to string) (map[string]*domain.DailyStatsSnapshot, error)
+	// SaveSnapshot は行を保存する（存在する場合は上書き）
+	SaveSnapshot(ctx context.Context, snapshot *domain.DailyStatsSnapshot) error
+	// ListSnapshots はfrom以降（YYYY-MM-DD）の日付の行をlimit件まで取得する
+	ListSnapshots(ctx context.Context, from string, limit int) ([]*domain.DailyStatsSnapshot, error)
+}
+
+// DailyStatsUpdater はタスクの変更を日次統計の集計テーブルに反映するインターフェース
+type DailyStatsUpdater interface {
+	TaskChanged(ctx context.Context, before *domain.TaskState, task *domain.Task)
+	TaskDeleted(ctx context.Context, task *domain.Task)
+}
+
+// DailyStatsService は日次統計の集計テーブルを維持するサービス
+// タスクの作成・変更・削除で該当する日の行を集計し直し、未集計・期限切れ数が変わった行は取得時に集計する
+type DailyStatsService struct {
+	StatsRepository StatsRepository
+	Repository      DailyStatsRepository
+	Logger          logger.Logger
+}
+
+// NewDailyStatsService はDailyStatsServiceのコンストラクタ
+func NewDailyStatsService(
+	statsRepo StatsRepository,
+	repository DailyStatsRepository,
+	logger logger.Logger,
+) *DailyStatsService {
+	return &DailyStatsService{
+		StatsRepository: statsRepo,
+		Repository:      repository,
+		Logger:          logger,
+	}
+}
+
+// GetDailyStatsRange はfromからtoまで（UTCの日付、toを含む）の日次統計を日付順に取得する
+// 行がない日・期限切れ数が変わった日はタスクから集計して保存する
+func (s *DailyStatsService) GetDailyStatsRange(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyStats, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidParameter)
+	}
+
+	snapshots, err := s.Repository.GetSnapshots(ctx, userID, from.Format(domain.DailyStatsDateLayout), to.Format(domain.DailyStatsDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily stats snapshots: %w", err)
+	}
+
+	now := time.Now()
+	var days []*domain.DailyStats
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if snapshot, ok := snapshots[d.Format(domain.DailyStatsDateLayout)]; ok && snapshot.IsFresh(now) {
+			days = append(days, snapshot.ToDailyStats())
+			continue
+		}
+		stats, err := s.refresh(ctx, userID, d, now)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, stats)
+	}
+	return days, nil
+}
+
+// TaskChanged はタスクの作成・変更を集計テーブルに反映する（beforeは変更前の状態、作成時はnil）
+// 作成者と変更前後の担当者について、作成日と変更前後の期限日の行を集計し直す
+func (s *DailyStatsService) TaskChanged(ctx context.Context, before *domain.TaskState, task *domain.Task) {
+	now := time.Now()
+	for _, userID := range domain.DailyStatsUsers(task, before) {
+		for _, date := range domain.DailyStatsKeys(task, before) {
+			day, err := time.Parse(domain.DailyStatsDateLayout, date)
+			if err != nil {
+				continue
+			}
+			if _, err := s.refresh(ctx, userID, day, now); err != nil {
+				s.Logger.WithContext(ctx).Warn("Failed to update daily stats",
+					logger.Any("userID", userID),
+					logger.Any("date", date),
+					logger.Any("taskID", task.ID),
+					logger.Error(err))
+			}
+		}
+	}
+}
+
+// TaskDeleted はタスクの削除を集計テーブルに反映する
+func (s *DailyStatsService) TaskDeleted(ctx context.Context, task *domain.Task) {
+	s.TaskChanged(ctx, nil, task)
+}
+
+// Reconcile は直近の行をタスクから集計し直す（イベントの取りこぼしや期限切れ数の変化を直す夜間の再集計）
+// 集計し直した行数を返す
+func (s *DailyStatsService) Reconcile(ctx context.Context, now time.Time) (int, error) {
+	from := now.UTC().AddDate(0, 0, -dailyStatsReconcileDays).Format(domain.DailyStatsDateLayout)
+	snapshots, err := s.Repository.ListSnapshots(ctx, from, maxDailyStatsReconcileRows)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list daily stats snapshots: %w", err)
+	}
+
+	refreshed := 0
+	for _, snapshot := range snapshots {
+		if ctx.Err() != nil {
+			return refreshed, ctx.Err()
+		}
+		day, err := time.Parse(domain.DailyStatsDateLayout, snapshot.Date)
+		if err != nil {
+			continue
+		}
+		if _, err := s.refresh(ctx, snapshot.UserID, day, now); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to reconcile daily stats",
+				logger.Any("userID", snapshot.UserID),
+				logger.Any("date", snapshot.Date),
+				logger.Error(err))
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, nil
+}
+
+// refresh はその日（UTC）の日次統計をタスクから集計して保存する
+func (s *DailyStatsService) refresh(ctx context.Context, userID string, day, now time.Time) (*domain.DailyStats, error) {
+	stats, tasks, err := loadDailyStats(ctx, s.StatsRepository, userID, day)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repository.SaveSnapshot(ctx, domain.NewDailyStatsSnapshot(userID, stats, tasks, now)); err != nil {
+		return nil, fmt.Errorf("failed to save daily stats snapshot: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=daily_stats_service.go -destination=mocks/mock_daily_stats.go -package=mocks
+
+func newDailyStatsTestService(t *testing.T) (*DailyStatsService, *mocks.MockStatsRepository, *mocks.MockDailyStatsRepository) {
+	ctrl := gomock.NewController(t)
+	statsRepo := mocks.NewMockStatsRepository(ctrl)
+	repo := mocks.NewMockDailyStatsRepository(ctrl)
+	return NewDailyStatsService(statsRepo, repo, *createTestLogger()), statsRepo, repo
+}
+
+func TestDailyStatsService_GetDailyStatsRange(t *testing.T) {
+	from := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC)
+
+	t.Run("uses fresh rows and computes missing days", func(t *testing.T) {
+		service, statsRepo, repo := newDailyStatsTestService(t)
+		repo.EXPECT().GetSnapshots(gomock.Any(), "user-1", "2024-06-10", "2024-06-11").
+			Return(map[string]*domain.DailyStatsSnapshot{
+				"2024-06-10": {UserID: "user-1", Date: "2024-06-10", TotalTasks: 4, CompletedTasks: 2},
+			}, nil)
+		statsRepo.EXPECT().GetTasksByDueDate(gomock.Any(), "user-1", to).
+			Return([]*domain.Task{{ID: "task-1", Status: domain.TaskStatusDone}}, nil)
+		statsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+		repo.EXPECT().SaveSnapshot(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, snapshot *domain.DailyStatsSnapshot) error {
+				assert.Equal(t, "2024-06-11", snapshot.Date)
+				assert.Equal(t, 1, snapshot.CompletedTasks)
+				return nil
+			})
+
+		days, err := service.GetDailyStatsRange(context.Background(), "user-1", from, to)
+
+		require.NoError(t, err)
+		require.Len(t, days, 2)
+		assert.Equal(t, 4, days[0].TotalTasks)
+		assert.Equal(t, 50.0, days[0].CompletionRate)
+		assert.Equal(t, 1, days[1].TotalTasks)
+	})
+
+	t.Run("recomputes rows past their stale time", func(t *testing.T) {
+		service, statsRepo, repo := newDailyStatsTestService(t)
+		staleAt := time.Now().Add(-time.Minute)
+		repo.EXPECT().GetSnapshots(gomock.Any(), "user-1", "2024-06-10", "2024-06-10").
+			Return(map[string]*domain.DailyStatsSnapshot{
+				"2024-06-10": {UserID: "user-1", Date: "2024-06-10", TotalTasks: 1, StaleAt: &staleAt},
+			}, nil)
+		statsRepo.EXPECT().GetTasksByDueDate(gomock.Any(), "user-1", from).Return(nil, nil)
+		statsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+		repo.EXPECT().SaveSnapshot(gomock.Any(), gomock.Any()).Return(nil)
+
+		days, err := service.GetDailyStatsRange(context.Background(), "user-1", from, from)
+
+		require.NoError(t, err)
+		require.Len(t, days, 1)
+		assert.Equal(t, 0, days[0].TotalTasks)
+	})
+
+	t.Run("rejects reversed range", func(t *testing.T) {
+		service, _, _ := newDailyStatsTestService(t)
+
+		_, err := service.GetDailyStatsRange(context.Background(), "user-1", to, from)
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestDailyStatsService_TaskChanged(t *testing.T) {
+	service, statsRepo, repo := newDailyStatsTestService(t)
+	created := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 6, 12, 18, 0, 0, 0, time.UTC)
+	task := &domain.Task{ID: "task-1", CreatedBy: "user-1", CreatedAt: created, DueDate: &due}
+	task.Assignees = []*domain.TaskAssignee{{UserID: "user-2"}}
+
+	statsRepo.EXPECT().GetTasksByDueDate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(4)
+	statsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(4)
+	saved := make(map[string]bool)
+	repo.EXPECT().SaveSnapshot(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, snapshot *domain.DailyStatsSnapshot) error {
+			saved[snapshot.UserID+"/"+snapshot.Date] = true
+			return nil
+		}).Times(4)
+
+	service.TaskChanged(context.Background(), nil, task)
+
+	assert.Equal(t, map[string]bool{
+		"user-1/2024-06-10": true,
+		"user-1/2024-06-12": true,
+		"user-2/2024-06-10": true,
+		"user-2/2024-06-12": true,
+	}, saved)
+}
+
+func TestDailyStatsService_Reconcile(t *testing.T) {
+	service, statsRepo, repo := newDailyStatsTestService(t)
+	now := time.Date(2024, 6, 15, 3, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().ListSnapshots(gomock.Any(), "2024-06-08", maxDailyStatsReconcileRows).
+		Return([]*domain.DailyStatsSnapshot{
+			{UserID: "user-1", Date: "2024-06-10"},
+			{UserID: "user-2", Date: "2024-06-14"},
+		}, nil)
+	statsRepo.EXPECT().GetTasksByDueDate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	statsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	repo.EXPECT().SaveSnapshot(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	refreshed, err := service.Reconcile(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, refreshed)
+}
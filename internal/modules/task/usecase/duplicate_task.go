@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// 重複の候補を探すタスクの最大数
+const (
+	duplicateScanPageSize = 100
+	maxDuplicateScanTasks = 200
+)
+
+// DuplicateCheckInput は重複タスクの確認の入力
+type DuplicateCheckInput struct {
+	Title  string
+	UserID string
+	// GroupID を指定するとグループタスクから探す（空の場合はユーザーが作成したタスクから探す）
+	GroupID string
+	// Window は候補とするタスクの作成日時の期間（0の場合はサービスの既定値）
+	Window time.Duration
+}
+
+// FindDuplicateTasks は作成しようとしているタスクとタイトルが似ている最近のタスクを探す
+// 完了済みのタスクは候補にしない
+func (s *TaskService) FindDuplicateTasks(ctx context.Context, input DuplicateCheckInput) ([]*domain.DuplicateCandidate, error) {
+	if strings.TrimSpace(input.Title) == "" || input.UserID == "" {
+		return nil, fmt.Errorf("%w: title and userID are required", ErrInvalidParameter)
+	}
+	window := input.Window
+	if window <= 0 {
+		window = s.DuplicateWindow
+	}
+	if window <= 0 {
+		window = domain.DefaultDuplicateWindow
+	}
+	since := time.Now().Add(-window)
+
+	var tasks []*domain.Task
+	var err error
+	if input.GroupID != "" {
+		tasks, err = s.recentGroupTasks(ctx, input.GroupID, input.UserID)
+	} else {
+		tasks, err = s.recentTasksCreatedBy(ctx, input.UserID, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FindDuplicateCandidates(input.Title, tasks, since), nil
+}
+
+// recentTasksCreatedBy はユーザーが作成したタスクを新しい順に、sinceより前のタスクが現れるまで取得する
+func (s *TaskService) recentTasksCreatedBy(ctx context.Context, userID string, since time.Time) ([]*domain.Task, error) {
+	filter := domain.ListFilter{CreatedBy: &userID}
+	sortOptions := domain.SortOptions{Field: "created_at", Direction: "DESC"}
+
+	var tasks []*domain.Task
+	for page := 1; len(tasks) < maxDuplicateScanTasks; page++ {
+		pageTasks, _, err := s.TaskRepository.ListTasks(ctx, filter,
+			domain.Pagination{Page: page, PageSize: duplicateScanPageSize}, sortOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list recent tasks: %w", err)
+		}
+		tasks = append(tasks, pageTasks...)
+		if len(pageTasks) < duplicateScanPageSize || pageTasks[len(pageTasks)-1].CreatedAt.Before(since) {
+			break
+		}
+	}
+	return tasks, nil
+}
+
+// recentGroupTasks はグループタスクを新しく紐付けた順に最大数まで取得する（メンバーのみ）
+func (s *TaskService) recentGroupTasks(ctx context.Context, groupID, userID string) ([]*domain.Task, error) {
+	if s.GroupResolver == nil {
+		return nil, fmt.Errorf("%w: group tasks are not supported", ErrInvalidParameter)
+	}
+	isMember, err := s.GroupResolver.IsGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !isMember {
+		return nil, ErrPermissionDenied
+	}
+
+	taskIDs, err := s.GroupResolver.ListGroupTaskIDs(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group tasks: %w", err)
+	}
+	if len(taskIDs) > maxDuplicateScanTasks {
+		taskIDs = taskIDs[len(taskIDs)-maxDuplicateScanTasks:]
+	}
+
+	tasks := make([]*domain.Task, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+func TestTaskService_FindDuplicateTasks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newTask := func(id, title string, createdAt time.Time) *domain.Task {
+		return &domain.Task{ID: id, Title: title, Status: domain.TaskStatusTodo, CreatedBy: "user-1", CreatedAt: createdAt}
+	}
+
+	t.Run("searches recent tasks created by the user", func(t *testing.T) {
+		repo := &MockTaskRepository{
+			ListTasksFunc: func(ctx context.Context, filter domain.ListFilter, pagination domain.Pagination, sortOptions domain.SortOptions) ([]*domain.Task, int, error) {
+				require.NotNil(t, filter.CreatedBy)
+				assert.Equal(t, "user-1", *filter.CreatedBy)
+				assert.Equal(t, "created_at", sortOptions.Field)
+				assert.Equal(t, "DESC", sortOptions.Direction)
+				return []*domain.Task{
+					newTask("recent", "Buy milk", now.Add(-time.Hour)),
+					newTask("other", "Clean the room", now.Add(-time.Hour)),
+					newTask("old", "Buy milk", now.Add(-30*24*time.Hour)),
+				}, 3, nil
+			},
+		}
+		service := NewTaskService(repo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		candidates, err := service.FindDuplicateTasks(ctx, DuplicateCheckInput{Title: "buy milk", UserID: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, "recent", candidates[0].TaskID)
+	})
+
+	t.Run("window overrides the default period", func(t *testing.T) {
+		repo := &MockTaskRepository{
+			ListTasksFunc: func(ctx context.Context, filter domain.ListFilter, pagination domain.Pagination, sortOptions domain.SortOptions) ([]*domain.Task, int, error) {
+				return []*domain.Task{newTask("old", "Buy milk", now.Add(-30*24*time.Hour))}, 1, nil
+			},
+		}
+		service := NewTaskService(repo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		candidates, err := service.FindDuplicateTasks(ctx, DuplicateCheckInput{Title: "Buy milk", UserID: "user-1", Window: 60 * 24 * time.Hour})
+
+		require.NoError(t, err)
+		assert.Len(t, candidates, 1)
+	})
+
+	t.Run("searches group tasks for members", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		resolver := mocks.NewMockGroupTaskResolver(ctrl)
+		tasks := map[string]*domain.Task{"g1": newTask("g1", "資料を作成する", now)}
+		repo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				if task, ok := tasks[id]; ok {
+					return task, nil
+				}
+				return nil, ErrTaskNotFound
+			},
+		}
+		service := NewTaskService(repo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+		service.GroupResolver = resolver
+
+		resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "user-2").Return(true, nil)
+		resolver.EXPECT().ListGroupTaskIDs(gomock.Any(), "group-1").Return([]string{"deleted", "g1"}, nil)
+
+		candidates, err := service.FindDuplicateTasks(ctx, DuplicateCheckInput{Title: "資料を作成", UserID: "user-2", GroupID: "group-1"})
+
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, "g1", candidates[0].TaskID)
+	})
+
+	t.Run("non-members cannot search group tasks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		resolver := mocks.NewMockGroupTaskResolver(ctrl)
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+		service.GroupResolver = resolver
+
+		resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "outsider").Return(false, nil)
+
+		_, err := service.FindDuplicateTasks(ctx, DuplicateCheckInput{Title: "資料作成", UserID: "outsider", GroupID: "group-1"})
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("title is required", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		_, err := service.FindDuplicateTasks(ctx, DuplicateCheckInput{Title: " ", UserID: "user-1"})
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
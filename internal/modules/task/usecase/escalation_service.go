@@ -0,0 +1,500 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// EscalationRuleRepository はエスカレーションルールのリポジトリインターフェース
+type EscalationRuleRepository interface {
+	CreateRule(ctx context.Context, rule *domain.EscalationRule) error
+	GetRuleByID(ctx context.Context, id string) (*domain.EscalationRule, error)
+	ListRulesByOwner(ctx context.Context, scope domain.EscalationScope, ownerID string) ([]*domain.EscalationRule, error)
+	ListEnabledRules(ctx context.Context) ([]*domain.EscalationRule, error)
+	UpdateRule(ctx context.Context, rule *domain.EscalationRule) error
+	DeleteRule(ctx context.Context, id string) error
+
+	// 適用済み記録（同じルールを同じタスクに二重適用しないため）
+	HasEscalated(ctx context.Context, taskID, ruleID string) (bool, error)
+	RecordEscalation(ctx context.Context, taskID, ruleID string, escalatedAt time.Time) error
+}
+
+// GroupTaskStore はグループタスクの紐付けとグループのメンバーを参照するインターフェース
+type GroupTaskStore interface {
+	GetGroupIDsForTask(ctx context.Context, taskID string) ([]string, error)
+	// ListGroupTaskIDs はグループタスクのIDを紐付けた順に取得する
+	ListGroupTaskIDs(ctx context.Context, groupID string) ([]string, error)
+	IsGroupMember(ctx context.Context, groupID, userID string) (bool, error)
+	// IsProjectGroup はグループがプロジェクトグループかを確認する（存在しない場合は false）
+	IsProjectGroup(ctx context.Context, groupID string) (bool, error)
+	// AddTaskToGroup はタスクをグループタスクとして紐付ける
+	AddTaskToGroup(ctx context.Context, taskID, groupID string) error
+}
+
+// GroupTaskResolver はグループモジュールとの連携インターフェース
+// 権限はグループの権限設定（ロールごとの権限・グループごとの変更・カスタムロール）に従って確認する
+type GroupTaskResolver interface {
+	GroupTaskStore
+	// CheckGroupPermission はユーザーがグループでアクションを実行できるかを確認する
+	CheckGroupPermission(ctx context.Context, groupID, userID string, action domain.GroupAction) (bool, error)
+	// ListGroupMemberIDsWithPermission はグループでアクションを実行できるメンバーのユーザーIDを取得する
+	ListGroupMemberIDsWithPermission(ctx context.Context, groupID string, action domain.GroupAction) ([]string, error)
+}
+
+// EscalationNotifier はエスカレーション通知のインターフェース
+type EscalationNotifier interface {
+	NotifyTaskEscalated(ctx context.Context, task *domain.Task, recipientID string, rule *domain.EscalationRule) error
+}
+
+// EscalationRuleInput はルール作成・更新の入力
+type EscalationRuleInput struct {
+	GroupID           *string
+	Name              string
+	OverdueHours      int
+	RaisePriority     bool
+	NotifyAssignee    bool
+	NotifyGroupAdmins bool
+	ReassignTo        *string
+	Enabled           bool
+}
+
+// EscalationService は期限切れタスクのエスカレーションを扱うサービス
+type EscalationService struct {
+	TaskRepository TaskRepository
+	RuleRepository EscalationRuleRepository
+	GroupResolver  GroupTaskResolver
+	UserValidator  UserValidator
+	Notifier       EscalationNotifier
+	Logger         logger.Logger
+
+	// 変更履歴の記録（未設定の場合は記録しない）
+	HistoryRecorder TaskHistoryRecorder
+
+	// オフライン同期用の変更フィードへの記録（未設定の場合は記録しない）
+	SyncChanges commonDomain.ChangeRecorder
+
+	// 通知先の勤務時間外の通知の保留（未設定の場合はすぐに通知する）
+	Reminders ReminderScheduler
+}
+
+// NewEscalationService はEscalationServiceのコンストラクタ
+func NewEscalationService(
+	taskRepo TaskRepository,
+	ruleRepo EscalationRuleRepository,
+	groupResolver GroupTaskResolver,
+	userValidator UserValidator,
+	notifier EscalationNotifier,
+	logger logger.Logger,
+) *EscalationService {
+	return &EscalationService{
+		TaskRepository: taskRepo,
+		RuleRepository: ruleRepo,
+		GroupResolver:  groupResolver,
+		UserValidator:  userValidator,
+		Notifier:       notifier,
+		Logger:         logger,
+	}
+}
+
+// === エラー定義 ===
+
+var (
+	ErrEscalationRuleNotFound = errors.New("escalation rule not found")
+	ErrPermissionDenied       = errors.New("permission denied")
+)
+
+// === ルール管理 ===
+
+// CreateRule はエスカレーションルールを作成する
+// GroupIDが指定された場合はグループルールとなり、グループでタスク編集の権限が必要
+func (s *EscalationService) CreateRule(ctx context.Context, userID string, input EscalationRuleInput) (*domain.EscalationRule, error) {
+	if err := s.validateRuleInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	scope, ownerID, err := s.resolveOwner(ctx, userID, input.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := domain.NewEscalationRule(scope, ownerID, strings.TrimSpace(input.Name), input.OverdueHours, userID)
+	rule.ID = uuid.New().String()
+	applyRuleInput(rule, input)
+
+	if err := s.RuleRepository.CreateRule(ctx, rule); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create escalation rule",
+			logger.Any("ownerID", ownerID), logger.Error(err))
+		return nil, fmt.Errorf("failed to create escalation rule: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Escalation rule created",
+		logger.Any("ruleID", rule.ID), logger.Any("scope", rule.Scope), logger.Any("ownerID", ownerID))
+
+	return rule, nil
+}
+
+// ListRules はユーザー自身、または指定グループのルール一覧を取得する
+func (s *EscalationService) ListRules(ctx context.Context, userID string, groupID *string) ([]*domain.EscalationRule, error) {
+	scope, ownerID, err := s.resolveOwner(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.RuleRepository.ListRulesByOwner(ctx, scope, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escalation rules: %w", err)
+	}
+	return rules, nil
+}
+
+// UpdateRule はエスカレーションルールを更新する（適用範囲は変更不可）
+func (s *EscalationService) UpdateRule(ctx context.Context, userID, ruleID string, input EscalationRuleInput) (*domain.EscalationRule, error) {
+	rule, err := s.getManageableRule(ctx, userID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 適用範囲は既存ルールに従う
+	input.GroupID = nil
+	if rule.Scope == domain.EscalationScopeGroup {
+		input.GroupID = &rule.OwnerID
+	}
+	if err := s.validateRuleInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	rule.Name = strings.TrimSpace(input.Name)
+	rule.OverdueHours = input.OverdueHours
+	applyRuleInput(rule, input)
+	rule.UpdatedAt = time.Now()
+
+	if err := s.RuleRepository.UpdateRule(ctx, rule); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update escalation rule",
+			logger.Any("ruleID", ruleID), logger.Error(err))
+		return nil, fmt.Errorf("failed to update escalation rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule はエスカレーションルールを削除する
+func (s *EscalationService) DeleteRule(ctx context.Context, userID, ruleID string) error {
+	if _, err := s.getManageableRule(ctx, userID, ruleID); err != nil {
+		return err
+	}
+
+	if err := s.RuleRepository.DeleteRule(ctx, ruleID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to delete escalation rule",
+			logger.Any("ruleID", ruleID), logger.Error(err))
+		return fmt.Errorf("failed to delete escalation rule: %w", err)
+	}
+
+	return nil
+}
+
+// === ルール評価 ===
+
+// EvaluateOverdueTasks は期限切れタスクに対してルールを評価し、適用した件数を返す
+// バックグラウンドワーカーから定期的に呼び出される
+func (s *EscalationService) EvaluateOverdueTasks(ctx context.Context, now time.Time) (int, error) {
+	rules, err := s.RuleRepository.ListEnabledRules(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list enabled escalation rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return 0, nil
+	}
+
+	rulesByOwner := make(map[string][]*domain.EscalationRule)
+	for _, rule := range rules {
+		key := ruleOwnerKey(rule.Scope, rule.OwnerID)
+		rulesByOwner[key] = append(rulesByOwner[key], rule)
+	}
+
+	tasks, err := s.TaskRepository.GetOverdueTasks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get overdue tasks: %w", err)
+	}
+
+	applied := 0
+	for _, task := range tasks {
+		candidates, err := s.collectRulesForTask(ctx, task, rulesByOwner)
+		if err != nil {
+			s.Logger.WithContext(ctx).Error("Failed to collect escalation rules for task",
+				logger.Any("taskID", task.ID), logger.Error(err))
+			continue
+		}
+
+		for _, rule := range candidates {
+			if !rule.ShouldEscalate(task, now) {
+				continue
+			}
+
+			done, err := s.RuleRepository.HasEscalated(ctx, task.ID, rule.ID)
+			if err != nil {
+				s.Logger.WithContext(ctx).Error("Failed to check escalation history",
+					logger.Any("taskID", task.ID), logger.Any("ruleID", rule.ID), logger.Error(err))
+				continue
+			}
+			if done {
+				continue
+			}
+
+			if err := s.applyRule(ctx, task, rule, now); err != nil {
+				s.Logger.WithContext(ctx).Error("Failed to apply escalation rule",
+					logger.Any("taskID", task.ID), logger.Any("ruleID", rule.ID), logger.Error(err))
+				continue
+			}
+			applied++
+		}
+	}
+
+	return applied, nil
+}
+
+// isSoleAssignee は指定ユーザーがタスクの唯一の担当者かを判定する
+func isSoleAssignee(task *domain.Task, userID string) bool {
+	ids := task.AssigneeIDs()
+	return len(ids) == 1 && ids[0] == userID
+}
+
+// applyRule は1つのルールをタスクに適用する
+func (s *EscalationService) applyRule(ctx context.Context, task *domain.Task, rule *domain.EscalationRule, now time.Time) error {
+	changed := false
+	var before *domain.TaskState
+	if s.HistoryRecorder != nil {
+		before = domain.NewTaskState(task)
+	}
+
+	if rule.RaisePriority && task.Priority != domain.PriorityHigh {
+		task.Priority = task.Priority.Raise()
+		changed = true
+	}
+
+	if rule.ReassignTo != nil && !isSoleAssignee(task, *rule.ReassignTo) {
+		task.ReassignTo(*rule.ReassignTo)
+		changed = true
+	}
+
+	if changed {
+		task.UpdatedAt = now
+		if err := s.TaskRepository.UpdateTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+		if before != nil {
+			s.HistoryRecorder.RecordChange(ctx, before, task)
+		}
+		if s.SyncChanges != nil {
+			s.SyncChanges.RecordUpsert(ctx, commonDomain.SyncEntityTask, task.ID, append([]string{task.CreatedBy}, task.AssigneeIDs()...), task)
+		}
+	}
+
+	// 適用済みとして記録（通知失敗時も再適用しない）
+	if err := s.RuleRepository.RecordEscalation(ctx, task.ID, rule.ID, now); err != nil {
+		return fmt.Errorf("failed to record escalation: %w", err)
+	}
+
+	for _, recipientID := range s.collectRecipients(ctx, task, rule) {
+		if s.deferNotification(ctx, task, recipientID, now) {
+			continue
+		}
+		if err := s.Notifier.NotifyTaskEscalated(ctx, task, recipientID, rule); err != nil {
+			// 通知失敗は非致命的
+			s.Logger.WithContext(ctx).Warn("Failed to notify task escalation",
+				logger.Any("taskID", task.ID), logger.Any("recipientID", recipientID), logger.Error(err))
+		}
+	}
+
+	s.Logger.WithContext(ctx).Info("Escalation rule applied",
+		logger.Any("taskID", task.ID), logger.Any("ruleID", rule.ID), logger.Any("priority", task.Priority))
+
+	return nil
+}
+
+// deferNotification は通知先の勤務時間外の場合にエスカレーションの通知を保留し、保留した場合は true を返す
+// 保留に失敗した場合はすぐに通知する
+func (s *EscalationService) deferNotification(ctx context.Context, task *domain.Task, recipientID string, now time.Time) bool {
+	if s.Reminders == nil {
+		return false
+	}
+	deferred, err := s.Reminders.Defer(ctx, domain.NewDeferredReminder(recipientID, task, domain.ReminderKindEscalation), now)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to defer escalation notification",
+			logger.Any("taskID", task.ID), logger.Any("recipientID", recipientID), logger.Error(err))
+		return false
+	}
+	return deferred
+}
+
+// collectRulesForTask はタスクに適用されうるルールを集める
+// ユーザールールは作成者・担当者のもの、グループルールはタスクが属するグループのもの
+// 担当者のユーザールールのうち再割り当てを含むものは、担当者が作成したタスクにのみ適用する（他人のタスクの担当を付け替えないため）
+func (s *EscalationService) collectRulesForTask(ctx context.Context, task *domain.Task, rulesByOwner map[string][]*domain.EscalationRule) ([]*domain.EscalationRule, error) {
+	seen := make(map[string]bool)
+	var result []*domain.EscalationRule
+	add := func(rules []*domain.EscalationRule) {
+		for _, rule := range rules {
+			if !seen[rule.ID] {
+				seen[rule.ID] = true
+				result = append(result, rule)
+			}
+		}
+	}
+
+	add(rulesByOwner[ruleOwnerKey(domain.EscalationScopeUser, task.CreatedBy)])
+	for _, assigneeID := range task.AssigneeIDs() {
+		var rules []*domain.EscalationRule
+		for _, rule := range rulesByOwner[ruleOwnerKey(domain.EscalationScopeUser, assigneeID)] {
+			if rule.ReassignTo == nil || rule.OwnerID == task.CreatedBy {
+				rules = append(rules, rule)
+			}
+		}
+		add(rules)
+	}
+
+	groupIDs, err := s.GroupResolver.GetGroupIDsForTask(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, groupID := range groupIDs {
+		add(rulesByOwner[ruleOwnerKey(domain.EscalationScopeGroup, groupID)])
+	}
+
+	// 短い経過時間のルールから順に適用する
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].OverdueHours < result[j].OverdueHours
+	})
+	return result, nil
+}
+
+// collectRecipients は通知先（担当者・グループでタスク編集の権限を持つメンバー）を重複なく集める
+func (s *EscalationService) collectRecipients(ctx context.Context, task *domain.Task, rule *domain.EscalationRule) []string {
+	seen := make(map[string]bool)
+	var recipients []string
+	add := func(userID string) {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+
+	if rule.NotifyAssignee {
+		for _, assigneeID := range task.PendingAssigneeIDs() {
+			add(assigneeID)
+		}
+	}
+
+	if rule.NotifyGroupAdmins && rule.Scope == domain.EscalationScopeGroup {
+		adminIDs, err := s.GroupResolver.ListGroupMemberIDsWithPermission(ctx, rule.OwnerID, domain.GroupActionEditTasks)
+		if err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to get group admins for escalation",
+				logger.Any("groupID", rule.OwnerID), logger.Error(err))
+		}
+		for _, adminID := range adminIDs {
+			add(adminID)
+		}
+	}
+
+	return recipients
+}
+
+// === ヘルパー ===
+
+// resolveOwner はルールの所有者（ユーザーまたはグループ）を決定し、権限を確認する
+// グループルールはグループタスクの優先度・担当者を変更するため、グループでタスク編集の権限が必要
+func (s *EscalationService) resolveOwner(ctx context.Context, userID string, groupID *string) (domain.EscalationScope, string, error) {
+	if groupID == nil || *groupID == "" {
+		return domain.EscalationScopeUser, userID, nil
+	}
+
+	canManage, err := s.GroupResolver.CheckGroupPermission(ctx, *groupID, userID, domain.GroupActionEditTasks)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check group permission: %w", err)
+	}
+	if !canManage {
+		return "", "", ErrPermissionDenied
+	}
+	return domain.EscalationScopeGroup, *groupID, nil
+}
+
+// getManageableRule はルールを取得し、ユーザーが管理できるかを確認する
+func (s *EscalationService) getManageableRule(ctx context.Context, userID, ruleID string) (*domain.EscalationRule, error) {
+	rule, err := s.RuleRepository.GetRuleByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rule.Scope {
+	case domain.EscalationScopeUser:
+		if rule.OwnerID != userID {
+			return nil, ErrPermissionDenied
+		}
+	case domain.EscalationScopeGroup:
+		if _, _, err := s.resolveOwner(ctx, userID, &rule.OwnerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return rule, nil
+}
+
+func (s *EscalationService) validateRuleInput(ctx context.Context, input EscalationRuleInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidParameter)
+	}
+	if len(input.Name) > 100 {
+		return fmt.Errorf("%w: name too long (max 100 characters)", ErrInvalidParameter)
+	}
+	if input.OverdueHours < 0 || input.OverdueHours > 24*30 {
+		return fmt.Errorf("%w: overdue hours must be between 0 and 720", ErrInvalidParameter)
+	}
+	if !input.RaisePriority && !input.NotifyAssignee && !input.NotifyGroupAdmins && input.ReassignTo == nil {
+		return fmt.Errorf("%w: rule has no action", ErrInvalidParameter)
+	}
+	if input.NotifyGroupAdmins && input.GroupID == nil {
+		return fmt.Errorf("%w: notify_group_admins requires group_id", ErrInvalidParameter)
+	}
+
+	if input.ReassignTo != nil {
+		exists, err := s.UserValidator.UserExists(ctx, *input.ReassignTo)
+		if err != nil {
+			return fmt.Errorf("failed to validate user: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("%w: reassign_to user not found", ErrInvalidParameter)
+		}
+
+		// グループルールの再割り当て先はグループのメンバーに限る
+		if input.GroupID != nil {
+			isMember, err := s.GroupResolver.IsGroupMember(ctx, *input.GroupID, *input.ReassignTo)
+			if err != nil {
+				return fmt.Errorf("failed to check group membership: %w", err)
+			}
+			if !isMember {
+				return fmt.Errorf("%w: reassign_to user is not a group member", ErrInvalidParameter)
+			}
+		}
+	}
+	return nil
+}
+
+func applyRuleInput(rule *domain.EscalationRule, input EscalationRuleInput) {
+	rule.RaisePriority = input.RaisePriority
+	rule.NotifyAssignee = input.NotifyAssignee
+	rule.NotifyGroupAdmins = input.NotifyGroupAdmins
+	rule.ReassignTo = input.ReassignTo
+	rule.Enabled = input.Enabled
+}
+
+func ruleOwnerKey(scope domain.EscalationScope, ownerID string) string {
+	return string(scope) + ":" + ownerID
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=escalation_service.go -destination=mocks/mock_escalation.go -package=mocks
+
+type escalationTestMocks struct {
+	taskRepo *mocks.MockTaskRepository
+	ruleRepo *mocks.MockEscalationRuleRepository
+	resolver *mocks.MockGroupTaskResolver
+	notifier *mocks.MockEscalationNotifier
+}
+
+func newEscalationTestService(t *testing.T) (*EscalationService, *escalationTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &escalationTestMocks{
+		taskRepo: mocks.NewMockTaskRepository(ctrl),
+		ruleRepo: mocks.NewMockEscalationRuleRepository(ctrl),
+		resolver: mocks.NewMockGroupTaskResolver(ctrl),
+		notifier: mocks.NewMockEscalationNotifier(ctrl),
+	}
+	service := NewEscalationService(m.taskRepo, m.ruleRepo, m.resolver, &MockUserValidator{}, m.notifier, *createTestLogger())
+	return service, m
+}
+
+func TestEscalationService_CreateRule(t *testing.T) {
+	groupID := "group-1"
+	member := "member-1"
+	outsider := "outsider-1"
+
+	tests := []struct {
+		name          string
+		input         EscalationRuleInput
+		setupMocks    func(m *escalationTestMocks)
+		expectedError error
+		expectedScope domain.EscalationScope
+	}{
+		{
+			name:  "user rule",
+			input: EscalationRuleInput{Name: "1日超過", OverdueHours: 24, RaisePriority: true, Enabled: true},
+			setupMocks: func(m *escalationTestMocks) {
+				m.ruleRepo.EXPECT().CreateRule(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedScope: domain.EscalationScopeUser,
+		},
+		{
+			name:  "group rule by admin",
+			input: EscalationRuleInput{GroupID: &groupID, Name: "管理者へ通知", NotifyGroupAdmins: true, Enabled: true},
+			setupMocks: func(m *escalationTestMocks) {
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "user-1", domain.GroupActionEditTasks).Return(true, nil)
+				m.ruleRepo.EXPECT().CreateRule(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedScope: domain.EscalationScopeGroup,
+		},
+		{
+			name:  "group rule by non-admin",
+			input: EscalationRuleInput{GroupID: &groupID, Name: "管理者へ通知", NotifyGroupAdmins: true, Enabled: true},
+			setupMocks: func(m *escalationTestMocks) {
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "user-1", domain.GroupActionEditTasks).Return(false, nil)
+			},
+			expectedError: ErrPermissionDenied,
+		},
+		{
+			name:  "group rule reassigning to member",
+			input: EscalationRuleInput{GroupID: &groupID, Name: "再割り当て", ReassignTo: &member, Enabled: true},
+			setupMocks: func(m *escalationTestMocks) {
+				m.resolver.EXPECT().IsGroupMember(gomock.Any(), groupID, member).Return(true, nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "user-1", domain.GroupActionEditTasks).Return(true, nil)
+				m.ruleRepo.EXPECT().CreateRule(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedScope: domain.EscalationScopeGroup,
+		},
+		{
+			name:  "group rule reassigning to non-member",
+			input: EscalationRuleInput{GroupID: &groupID, Name: "再割り当て", ReassignTo: &outsider, Enabled: true},
+			setupMocks: func(m *escalationTestMocks) {
+				m.resolver.EXPECT().IsGroupMember(gomock.Any(), groupID, outsider).Return(false, nil)
+			},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name:          "rule without action",
+			input:         EscalationRuleInput{Name: "何もしない", OverdueHours: 24},
+			setupMocks:    func(m *escalationTestMocks) {},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name:          "notify admins without group",
+			input:         EscalationRuleInput{Name: "管理者へ通知", NotifyGroupAdmins: true},
+			setupMocks:    func(m *escalationTestMocks) {},
+			expectedError: ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newEscalationTestService(t)
+			tt.setupMocks(m)
+
+			rule, err := service.CreateRule(context.Background(), "user-1", tt.input)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, rule)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, rule.ID)
+			assert.Equal(t, tt.expectedScope, rule.Scope)
+			assert.Equal(t, "user-1", rule.CreatedBy)
+		})
+	}
+}
+
+func TestEscalationService_DeleteRule_NotOwner(t *testing.T) {
+	service, m := newEscalationTestService(t)
+
+	m.ruleRepo.EXPECT().GetRuleByID(gomock.Any(), "rule-1").Return(&domain.EscalationRule{
+		ID:      "rule-1",
+		Scope:   domain.EscalationScopeUser,
+		OwnerID: "other-user",
+	}, nil)
+
+	err := service.DeleteRule(context.Background(), "user-1", "rule-1")
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
+
+func TestEscalationService_EvaluateOverdueTasks(t *testing.T) {
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+	dueDate := now.Add(-30 * time.Hour)
+	assignee := "assignee-1"
+
+	newTask := func() *domain.Task {
+		return &domain.Task{
+			ID:         "task-1",
+			Title:      "期限切れタスク",
+			Status:     domain.TaskStatusTodo,
+			Priority:   domain.PriorityLow,
+			DueDate:    &dueDate,
+			AssigneeID: &assignee,
+			CreatedBy:  "creator-1",
+		}
+	}
+
+	userRule := &domain.EscalationRule{
+		ID: "rule-user", Scope: domain.EscalationScopeUser, OwnerID: "creator-1",
+		OverdueHours: 24, RaisePriority: true, NotifyAssignee: true, Enabled: true,
+	}
+	groupRule := &domain.EscalationRule{
+		ID: "rule-group", Scope: domain.EscalationScopeGroup, OwnerID: "group-1",
+		OverdueHours: 24, NotifyGroupAdmins: true, Enabled: true,
+	}
+	laterRule := &domain.EscalationRule{
+		ID: "rule-later", Scope: domain.EscalationScopeUser, OwnerID: "creator-1",
+		OverdueHours: 48, RaisePriority: true, Enabled: true,
+	}
+
+	t.Run("applies due rules and notifies recipients", func(t *testing.T) {
+		service, m := newEscalationTestService(t)
+		task := newTask()
+
+		m.ruleRepo.EXPECT().ListEnabledRules(gomock.Any()).Return([]*domain.EscalationRule{userRule, groupRule, laterRule}, nil)
+		m.taskRepo.EXPECT().GetOverdueTasks(gomock.Any()).Return([]*domain.Task{task}, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+
+		m.ruleRepo.EXPECT().HasEscalated(gomock.Any(), "task-1", "rule-user").Return(false, nil)
+		m.taskRepo.EXPECT().UpdateTask(gomock.Any(), task).Return(nil)
+		m.ruleRepo.EXPECT().RecordEscalation(gomock.Any(), "task-1", "rule-user", now).Return(nil)
+		m.notifier.EXPECT().NotifyTaskEscalated(gomock.Any(), task, assignee, userRule).Return(nil)
+
+		m.ruleRepo.EXPECT().HasEscalated(gomock.Any(), "task-1", "rule-group").Return(false, nil)
+		m.ruleRepo.EXPECT().RecordEscalation(gomock.Any(), "task-1", "rule-group", now).Return(nil)
+		m.resolver.EXPECT().ListGroupMemberIDsWithPermission(gomock.Any(), "group-1", domain.GroupActionEditTasks).Return([]string{"admin-1", "admin-2"}, nil)
+		m.notifier.EXPECT().NotifyTaskEscalated(gomock.Any(), task, "admin-1", groupRule).Return(nil)
+		m.notifier.EXPECT().NotifyTaskEscalated(gomock.Any(), task, "admin-2", groupRule).Return(errors.New("send failed"))
+
+		applied, err := service.EvaluateOverdueTasks(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+		assert.Equal(t, domain.PriorityMedium, task.Priority)
+	})
+
+	t.Run("skips rules already applied", func(t *testing.T) {
+		service, m := newEscalationTestService(t)
+		task := newTask()
+
+		m.ruleRepo.EXPECT().ListEnabledRules(gomock.Any()).Return([]*domain.EscalationRule{userRule}, nil)
+		m.taskRepo.EXPECT().GetOverdueTasks(gomock.Any()).Return([]*domain.Task{task}, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.ruleRepo.EXPECT().HasEscalated(gomock.Any(), "task-1", "rule-user").Return(true, nil)
+
+		applied, err := service.EvaluateOverdueTasks(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+		assert.Equal(t, domain.PriorityLow, task.Priority)
+	})
+
+	t.Run("defers notifications outside working hours", func(t *testing.T) {
+		service, m := newEscalationTestService(t)
+		reminders := mocks.NewMockReminderScheduler(gomock.NewController(t))
+		service.Reminders = reminders
+		task := newTask()
+
+		m.ruleRepo.EXPECT().ListEnabledRules(gomock.Any()).Return([]*domain.EscalationRule{userRule}, nil)
+		m.taskRepo.EXPECT().GetOverdueTasks(gomock.Any()).Return([]*domain.Task{task}, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.ruleRepo.EXPECT().HasEscalated(gomock.Any(), "task-1", "rule-user").Return(false, nil)
+		m.taskRepo.EXPECT().UpdateTask(gomock.Any(), task).Return(nil)
+		m.ruleRepo.EXPECT().RecordEscalation(gomock.Any(), "task-1", "rule-user", now).Return(nil)
+		reminders.EXPECT().Defer(gomock.Any(), gomock.Any(), now).DoAndReturn(
+			func(ctx context.Context, reminder *domain.DeferredReminder, now time.Time) (bool, error) {
+				assert.Equal(t, assignee, reminder.UserID)
+				assert.Equal(t, domain.ReminderKindEscalation, reminder.Kind)
+				return true, nil
+			})
+		// 保留した通知はすぐには送らない（NotifyTaskEscalated を呼ばない）
+
+		applied, err := service.EvaluateOverdueTasks(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+		assert.Equal(t, domain.PriorityMedium, task.Priority)
+	})
+
+	t.Run("assignee's reassign rule skips tasks they did not create", func(t *testing.T) {
+		service, m := newEscalationTestService(t)
+		task := newTask()
+		other := "other-1"
+		assigneeRule := &domain.EscalationRule{
+			ID: "rule-assignee", Scope: domain.EscalationScopeUser, OwnerID: assignee,
+			OverdueHours: 24, ReassignTo: &other, Enabled: true,
+		}
+
+		m.ruleRepo.EXPECT().ListEnabledRules(gomock.Any()).Return([]*domain.EscalationRule{assigneeRule}, nil)
+		m.taskRepo.EXPECT().GetOverdueTasks(gomock.Any()).Return([]*domain.Task{task}, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+		// 担当者のルールは他人（creator-1）が作成したタスクには適用しない（HasEscalated・UpdateTask を呼ばない）
+
+		applied, err := service.EvaluateOverdueTasks(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+		assert.Equal(t, []string{assignee}, task.AssigneeIDs())
+	})
+
+	t.Run("no enabled rules", func(t *testing.T) {
+		service, m := newEscalationTestService(t)
+
+		m.ruleRepo.EXPECT().ListEnabledRules(gomock.Any()).Return(nil, nil)
+
+		applied, err := service.EvaluateOverdueTasks(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+	})
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskExportRepository はエクスポート用にタスクを1件ずつ取得するリポジトリインターフェース
+type TaskExportRepository interface {
+	// StreamTasksByUser はユーザーが作成または担当するタスクを作成日時順にチャネルへ送る
+	// タスクのチャネルは送り終えるかctxがキャンセルされると閉じ、その後エラーのチャネルに結果（成功時はnil）を1件送る
+	StreamTasksByUser(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error)
+}
+
+// ExportService はタスク・統計を全件エクスポートするサービス
+// 全件をスライスに読み込まず、リポジトリから受け取ったタスクを1件ずつ書き出す
+type ExportService struct {
+	Repository TaskExportRepository
+	Logger     logger.Logger
+}
+
+// NewExportService はExportServiceのコンストラクタ
+func NewExportService(repository TaskExportRepository, logger logger.Logger) *ExportService {
+	return &ExportService{
+		Repository: repository,
+		Logger:     logger,
+	}
+}
+
+// ExportTasks はユーザーが作成または担当するタスクを作成日時順にwriteへ渡す
+// writeがエラーを返した場合（クライアントの切断など）は取得を中止してそのエラーを返す
+func (s *ExportService) ExportTasks(ctx context.Context, userID string, write func(*domain.Task) error) error {
+	return s.stream(ctx, userID, write)
+}
+
+// ExportTaskStats はユーザーが作成または担当するタスクを統計のエクスポートの行に変換してwriteへ渡す
+func (s *ExportService) ExportTaskStats(ctx context.Context, userID string, now time.Time, write func(*domain.TaskStatsRecord) error) error {
+	return s.stream(ctx, userID, func(task *domain.Task) error {
+		return write(domain.NewTaskStatsRecord(task, now))
+	})
+}
+
+// stream はリポジトリのチャネルからタスクを受け取り、1件ずつwriteへ渡す
+func (s *ExportService) stream(ctx context.Context, userID string, write func(*domain.Task) error) error {
+	if userID == "" {
+		return ErrInvalidParameter
+	}
+
+	// write が失敗した場合にリポジトリの取得を止めるため、専用のコンテキストを使う
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tasks, errs := s.Repository.StreamTasksByUser(ctx, userID)
+	count := 0
+	for task := range tasks {
+		if err := write(task); err != nil {
+			cancel()
+			// 取得側のゴルーチンが終了するまで読み捨てる
+			for range tasks {
+			}
+			<-errs
+			s.Logger.WithContext(ctx).Warn("Export aborted",
+				logger.Any("userID", userID),
+				logger.Any("count", count),
+				logger.Error(err))
+			return err
+		}
+		count++
+	}
+	if err := <-errs; err != nil {
+		return fmt.Errorf("failed to stream tasks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=export_service.go -destination=mocks/mock_export.go -package=mocks
+
+// streamOf はタスクを順に送るリポジトリの結果を作る（ctxがキャンセルされると送るのをやめる）
+func streamOf(ctx context.Context, tasks []*domain.Task, err error) (<-chan *domain.Task, <-chan error) {
+	out := make(chan *domain.Task)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		for _, task := range tasks {
+			select {
+			case out <- task:
+			case <-ctx.Done():
+				close(out)
+				errs <- ctx.Err()
+				return
+			}
+		}
+		close(out)
+		errs <- err
+	}()
+	return out, errs
+}
+
+func newExportTestService(t *testing.T) (*ExportService, *mocks.MockTaskExportRepository) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockTaskExportRepository(ctrl)
+	return NewExportService(repo, *createTestLogger()), repo
+}
+
+func TestExportService_ExportTasks(t *testing.T) {
+	tasks := []*domain.Task{{ID: "task-1"}, {ID: "task-2"}, {ID: "task-3"}}
+
+	t.Run("writes every task in order", func(t *testing.T) {
+		service, repo := newExportTestService(t)
+		repo.EXPECT().StreamTasksByUser(gomock.Any(), "user-1").
+			DoAndReturn(func(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+				return streamOf(ctx, tasks, nil)
+			})
+
+		var written []string
+		err := service.ExportTasks(context.Background(), "user-1", func(task *domain.Task) error {
+			written = append(written, task.ID)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"task-1", "task-2", "task-3"}, written)
+	})
+
+	t.Run("stops the repository when writing fails", func(t *testing.T) {
+		service, repo := newExportTestService(t)
+		repo.EXPECT().StreamTasksByUser(gomock.Any(), "user-1").
+			DoAndReturn(func(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+				return streamOf(ctx, tasks, nil)
+			})
+		writeErr := errors.New("client disconnected")
+
+		count := 0
+		err := service.ExportTasks(context.Background(), "user-1", func(task *domain.Task) error {
+			count++
+			return writeErr
+		})
+
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		service, repo := newExportTestService(t)
+		repo.EXPECT().StreamTasksByUser(gomock.Any(), "user-1").
+			DoAndReturn(func(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+				return streamOf(ctx, tasks[:1], errors.New("connection lost"))
+			})
+
+		err := service.ExportTasks(context.Background(), "user-1", func(task *domain.Task) error { return nil })
+
+		assert.ErrorContains(t, err, "connection lost")
+	})
+
+	t.Run("requires a user", func(t *testing.T) {
+		service, _ := newExportTestService(t)
+
+		err := service.ExportTasks(context.Background(), "", func(task *domain.Task) error { return nil })
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestExportService_ExportTaskStats(t *testing.T) {
+	service, repo := newExportTestService(t)
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Hour)
+	repo.EXPECT().StreamTasksByUser(gomock.Any(), "user-1").
+		DoAndReturn(func(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+			return streamOf(ctx, []*domain.Task{{ID: "task-1", Status: domain.TaskStatusTodo, DueDate: &due}}, nil)
+		})
+
+	var records []*domain.TaskStatsRecord
+	err := service.ExportTaskStats(context.Background(), "user-1", now, func(record *domain.TaskStatsRecord) error {
+		records = append(records, record)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "task-1", records[0].TaskID)
+	assert.True(t, records[0].IsOverdue)
+}
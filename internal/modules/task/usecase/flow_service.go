@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+const (
+	// flowScanPageSize はユーザーのタスクを取得する際の1ページの件数
+	flowScanPageSize = 100
+	// maxFlowTasks は集計の対象にするタスクの上限
+	maxFlowTasks = 5000
+)
+
+// FlowQuery はバーンダウン・累積フロー図の取得条件
+type FlowQuery struct {
+	UserID   string
+	Scope    domain.FlowScope // 空の場合は user
+	GroupID  string           // scope が group の場合に必須
+	Range    string           // "30d"・"12w" 形式（空の場合は既定の期間）
+	Timezone string           // 空の場合は勤務時間設定のタイムゾーン
+}
+
+// FlowService はタスクの変更履歴からバーンダウン・累積フロー図を作成するサービス
+// 現在のタスクの状態ではなく記録されたステータスの変化を再生するため、過去の日の値も当時のまま集計される
+type FlowService struct {
+	TaskRepository     TaskRepository
+	HistoryRepository  TaskHistoryRepository
+	GroupResolver      GroupTaskResolver
+	WorkloadRepository WorkloadRepository
+	Logger             logger.Logger
+}
+
+// NewFlowService はFlowServiceのコンストラクタ
+func NewFlowService(
+	taskRepo TaskRepository,
+	historyRepo TaskHistoryRepository,
+	groupResolver GroupTaskResolver,
+	workloadRepo WorkloadRepository,
+	logger logger.Logger,
+) *FlowService {
+	return &FlowService{
+		TaskRepository:     taskRepo,
+		HistoryRepository:  historyRepo,
+		GroupResolver:      groupResolver,
+		WorkloadRepository: workloadRepo,
+		Logger:             logger,
+	}
+}
+
+// GetBurndown は期間内の日ごとの未完了タスク数を取得する
+func (s *FlowService) GetBurndown(ctx context.Context, query FlowQuery, now time.Time) (*domain.FlowBurndown, error) {
+	in, err := s.loadTransitions(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewFlowBurndown(in.scope, in.scopeID, in.transitions, in.days, in.now), nil
+}
+
+// GetCumulativeFlow は期間内の日ごとのステータス別タスク数を取得する
+func (s *FlowService) GetCumulativeFlow(ctx context.Context, query FlowQuery, now time.Time) (*domain.CumulativeFlow, error) {
+	in, err := s.loadTransitions(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewCumulativeFlow(in.scope, in.scopeID, in.transitions, in.days, in.now), nil
+}
+
+// flowInput はバーンダウン・累積フロー図の集計に使う値
+type flowInput struct {
+	scope       domain.FlowScope
+	scopeID     string
+	transitions []*domain.StatusTransition
+	days        int
+	now         time.Time // 日の区切りに使うタイムゾーンの現在時刻
+}
+
+// loadTransitions は取得条件を検証し、対象タスクのステータスの変化を変更履歴から取得する
+func (s *FlowService) loadTransitions(ctx context.Context, query FlowQuery, now time.Time) (*flowInput, error) {
+	if query.UserID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	days, err := domain.ParseFlowRange(query.Range)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFlowRange) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidParameter, err.Error())
+		}
+		return nil, err
+	}
+
+	loc, err := resolveUserLocation(ctx, s.WorkloadRepository, query.UserID, query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := query.Scope
+	if scope == "" {
+		scope = domain.FlowScopeUser
+	}
+
+	var scopeID string
+	var taskIDs []string
+	switch scope {
+	case domain.FlowScopeUser:
+		scopeID = query.UserID
+		taskIDs, err = s.userTaskIDs(ctx, query.UserID)
+	case domain.FlowScopeGroup:
+		if query.GroupID == "" {
+			return nil, fmt.Errorf("%w: group_id is required for group scope", ErrInvalidParameter)
+		}
+		scopeID = query.GroupID
+		taskIDs, err = s.groupTaskIDs(ctx, query.GroupID, query.UserID)
+	default:
+		return nil, fmt.Errorf("%w: invalid scope: %s", ErrInvalidParameter, scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.HistoryRepository.ListStatusEvents(ctx, taskIDs, now)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to list task status events",
+			logger.Any("scope", scope), logger.Any("scopeID", scopeID), logger.Error(err))
+		return nil, fmt.Errorf("failed to list task status events: %w", err)
+	}
+
+	transitions, err := domain.NewStatusTransitions(events)
+	if err != nil {
+		return nil, err
+	}
+	return &flowInput{
+		scope:       scope,
+		scopeID:     scopeID,
+		transitions: transitions,
+		days:        days,
+		now:         now.In(loc),
+	}, nil
+}
+
+// userTaskIDs はユーザーが作成したタスクと担当するタスクのIDを重複なく上限まで取得する
+func (s *FlowService) userTaskIDs(ctx context.Context, userID string) ([]string, error) {
+	seen := make(map[string]bool)
+	var taskIDs []string
+	for _, filter := range []domain.ListFilter{
+		{CreatedBy: &userID},
+		{AssigneeID: &userID},
+	} {
+		for page := 1; len(taskIDs) < maxFlowTasks; page++ {
+			tasks, _, err := s.TaskRepository.ListTasks(ctx, filter,
+				domain.Pagination{Page: page, PageSize: flowScanPageSize},
+				domain.SortOptions{Field: "created_at", Direction: "DESC"})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tasks: %w", err)
+			}
+			for _, task := range tasks {
+				if !seen[task.ID] && len(taskIDs) < maxFlowTasks {
+					seen[task.ID] = true
+					taskIDs = append(taskIDs, task.ID)
+				}
+			}
+			if len(tasks) < flowScanPageSize {
+				break
+			}
+		}
+	}
+	return taskIDs, nil
+}
+
+// groupTaskIDs はグループタスクのIDを上限まで取得する（メンバーのみ）
+func (s *FlowService) groupTaskIDs(ctx context.Context, groupID, userID string) ([]string, error) {
+	if s.GroupResolver == nil {
+		return nil, fmt.Errorf("%w: group tasks are not supported", ErrInvalidParameter)
+	}
+	isMember, err := s.GroupResolver.IsGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !isMember {
+		return nil, ErrPermissionDenied
+	}
+
+	taskIDs, err := s.GroupResolver.ListGroupTaskIDs(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group tasks: %w", err)
+	}
+	if len(taskIDs) > maxFlowTasks {
+		taskIDs = taskIDs[len(taskIDs)-maxFlowTasks:] // 新しく紐付けたタスクを優先する
+	}
+	return taskIDs, nil
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+type flowTestMocks struct {
+	taskRepo     *mocks.MockTaskRepository
+	historyRepo  *mocks.MockTaskHistoryRepository
+	resolver     *mocks.MockGroupTaskResolver
+	workloadRepo *mocks.MockWorkloadRepository
+}
+
+func newFlowTestService(t *testing.T) (*FlowService, *flowTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &flowTestMocks{
+		taskRepo:     mocks.NewMockTaskRepository(ctrl),
+		historyRepo:  mocks.NewMockTaskHistoryRepository(ctrl),
+		resolver:     mocks.NewMockGroupTaskResolver(ctrl),
+		workloadRepo: mocks.NewMockWorkloadRepository(ctrl),
+	}
+	return NewFlowService(m.taskRepo, m.historyRepo, m.resolver, m.workloadRepo, *createTestLogger()), m
+}
+
+// statusEvent はステータスを含む変更イベントを作成する
+func statusEvent(taskID string, eventType domain.TaskEventType, status domain.TaskStatus, at time.Time) *domain.TaskEvent {
+	return &domain.TaskEvent{
+		TaskID:     taskID,
+		Type:       eventType,
+		Changes:    map[string]json.RawMessage{"status": json.RawMessage(`"` + string(status) + `"`)},
+		OccurredAt: at,
+	}
+}
+
+func TestFlowService_GetBurndown(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("user scope combines created and assigned tasks", func(t *testing.T) {
+		service, m := newFlowTestService(t)
+		m.taskRepo.EXPECT().ListTasks(gomock.Any(), gomock.Any(), domain.Pagination{Page: 1, PageSize: flowScanPageSize}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filter domain.ListFilter, pagination domain.Pagination, sort domain.SortOptions) ([]*domain.Task, int, error) {
+				if filter.CreatedBy != nil {
+					assert.Equal(t, "user-1", *filter.CreatedBy)
+					return []*domain.Task{{ID: "task-1"}, {ID: "task-2"}}, 2, nil
+				}
+				assert.Equal(t, "user-1", *filter.AssigneeID)
+				return []*domain.Task{{ID: "task-2"}, {ID: "task-3"}}, 2, nil
+			}).Times(2)
+		m.historyRepo.EXPECT().ListStatusEvents(gomock.Any(), []string{"task-1", "task-2", "task-3"}, now).Return([]*domain.TaskEvent{
+			statusEvent("task-1", domain.TaskEventCreated, domain.TaskStatusTodo, now.AddDate(0, 0, -20)),
+			statusEvent("task-2", domain.TaskEventCreated, domain.TaskStatusTodo, now.AddDate(0, 0, -3)),
+			statusEvent("task-1", domain.TaskEventStatusChanged, domain.TaskStatusDone, now.AddDate(0, 0, -1)),
+		}, nil)
+
+		burndown, err := service.GetBurndown(context.Background(), FlowQuery{UserID: "user-1", Range: "1w", Timezone: "UTC"}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.FlowScopeUser, burndown.Scope)
+		assert.Equal(t, "user-1", burndown.ScopeID)
+		require.Len(t, burndown.Points, 7)
+		assert.Equal(t, 1, burndown.Points[0].Remaining)
+		assert.Equal(t, 2, burndown.Points[3].Remaining)
+		assert.Equal(t, 1, burndown.Points[3].Added)
+		assert.Equal(t, 1, burndown.Points[5].Remaining)
+		assert.Equal(t, 1, burndown.Points[5].Completed)
+	})
+
+	t.Run("days are split in the working hours timezone", func(t *testing.T) {
+		service, m := newFlowTestService(t)
+		hours := domain.DefaultWorkingHours("user-1")
+		hours.Timezone = "Asia/Tokyo"
+		m.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+		m.taskRepo.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, nil).Times(2)
+		m.historyRepo.EXPECT().ListStatusEvents(gomock.Any(), gomock.Any(), now).Return(nil, nil)
+
+		burndown, err := service.GetBurndown(context.Background(), FlowQuery{UserID: "user-1"}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Tokyo", burndown.Timezone)
+		require.Len(t, burndown.Points, domain.DefaultFlowRangeDays)
+		assert.Equal(t, "2024-06-10T00:00:00+09:00", burndown.To.Format(time.RFC3339))
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		service, _ := newFlowTestService(t)
+
+		_, err := service.GetBurndown(context.Background(), FlowQuery{UserID: "user-1", Range: "10y"}, now)
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		service, _ := newFlowTestService(t)
+
+		_, err := service.GetBurndown(context.Background(), FlowQuery{UserID: "user-1", Scope: "team", Timezone: "UTC"}, now)
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestFlowService_GetCumulativeFlow(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("group scope", func(t *testing.T) {
+		service, m := newFlowTestService(t)
+		m.resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "user-1").Return(true, nil)
+		m.resolver.EXPECT().ListGroupTaskIDs(gomock.Any(), "group-1").Return([]string{"task-1", "task-2"}, nil)
+		m.historyRepo.EXPECT().ListStatusEvents(gomock.Any(), []string{"task-1", "task-2"}, now).Return([]*domain.TaskEvent{
+			statusEvent("task-1", domain.TaskEventCreated, domain.TaskStatusTodo, now.AddDate(0, 0, -2)),
+			statusEvent("task-2", domain.TaskEventCreated, domain.TaskStatusTodo, now.AddDate(0, 0, -2)),
+			statusEvent("task-1", domain.TaskEventStatusChanged, domain.TaskStatusInProgress, now.AddDate(0, 0, -1)),
+		}, nil)
+
+		cfd, err := service.GetCumulativeFlow(context.Background(),
+			FlowQuery{UserID: "user-1", Scope: domain.FlowScopeGroup, GroupID: "group-1", Range: "3d", Timezone: "UTC"}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.FlowScopeGroup, cfd.Scope)
+		assert.Equal(t, "group-1", cfd.ScopeID)
+		require.Len(t, cfd.Points, 3)
+		assert.Equal(t, domain.CumulativeFlowPoint{Date: cfd.Points[0].Date, Todo: 2}, cfd.Points[0])
+		assert.Equal(t, domain.CumulativeFlowPoint{Date: cfd.Points[1].Date, Todo: 1, InProgress: 1}, cfd.Points[1])
+	})
+
+	t.Run("non member is denied", func(t *testing.T) {
+		service, m := newFlowTestService(t)
+		m.resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "user-2").Return(false, nil)
+
+		_, err := service.GetCumulativeFlow(context.Background(),
+			FlowQuery{UserID: "user-2", Scope: domain.FlowScopeGroup, GroupID: "group-1", Timezone: "UTC"}, now)
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("group scope requires group_id", func(t *testing.T) {
+		service, _ := newFlowTestService(t)
+
+		_, err := service.GetCumulativeFlow(context.Background(),
+			FlowQuery{UserID: "user-1", Scope: domain.FlowScopeGroup, Timezone: "UTC"}, now)
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
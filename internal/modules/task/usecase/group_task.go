@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// GroupTaskAssigner はグループタスクの作成権限と担当者の自動割り当てをグループモジュールに問い合わせるインターフェース
+type GroupTaskAssigner interface {
+	CanCreateGroupTask(ctx context.Context, groupID, userID string) (bool, error)
+	// PickAssignee はグループの自動割り当て方式で担当者を選ぶ（自動割り当てしない場合は空文字）
+	PickAssignee(ctx context.Context, groupID, requesterID string) (string, error)
+}
+
+// TaskEventLinkProvider はタスクとグループの予定の関連付けをグループモジュールに問い合わせるインターフェース
+type TaskEventLinkProvider interface {
+	// ListTaskEventLinks はタスク、またはタスクを予定とする関連付けを取得する
+	ListTaskEventLinks(ctx context.Context, taskID string) ([]*domain.TaskEventLink, error)
+}
+
+// CreateGroupTaskInput はグループタスク作成の入力
+type CreateGroupTaskInput struct {
+	// ID はクライアントが生成したUUIDv7のID（空の場合はサーバーで生成する）
+	ID          string
+	Title       string
+	Description string
+	Priority    domain.Priority
+	Category    domain.Category
+	GroupID     string
+	CreatedBy   string
+
+	// AssigneeID が空の場合はグループの自動割り当て方式で担当者を選ぶ
+	AssigneeID string
+	// SkipAutoAssign を指定すると担当者なしで作成する
+	SkipAutoAssign bool
+}
+
+// CreateGroupTask はグループタスクを作成する（グループでタスク作成の権限が必要）
+// 担当者を指定しなかった場合はグループの自動割り当て方式で担当者を割り当てる
+func (s *TaskService) CreateGroupTask(ctx context.Context, input CreateGroupTaskInput) (*domain.Task, error) {
+	if input.GroupID == "" {
+		return nil, fmt.Errorf("%w: groupID is required", ErrInvalidParameter)
+	}
+	if err := s.validateCreateTaskInput(input.Title, input.Description, input.CreatedBy); err != nil {
+		return nil, err
+	}
+	if s.GroupResolver == nil || s.GroupAssigner == nil {
+		return nil, fmt.Errorf("%w: group tasks are not supported", ErrInvalidParameter)
+	}
+	if input.ID != "" {
+		if err := validateClientTaskID(input.ID); err != nil {
+			return nil, err
+		}
+		// 同じIDでの再送は作成済みのタスクを返す
+		if existing, err := s.findClientTask(ctx, input.ID, input.CreatedBy); err != nil || existing != nil {
+			return existing, err
+		}
+	}
+
+	canCreate, err := s.GroupAssigner.CanCreateGroupTask(ctx, input.GroupID, input.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group permission: %w", err)
+	}
+	if !canCreate {
+		return nil, ErrPermissionDenied
+	}
+
+	if input.AssigneeID != "" {
+		isMember, err := s.GroupResolver.IsGroupMember(ctx, input.GroupID, input.AssigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check group membership: %w", err)
+		}
+		if !isMember {
+			return nil, fmt.Errorf("%w: assignee is not a member of the group", ErrInvalidParameter)
+		}
+	}
+
+	if err := s.checkOpenTaskQuota(ctx, commonDomain.QuotaScopeGroup, input.GroupID); err != nil {
+		return nil, err
+	}
+
+	category := input.Category
+	if category == "" {
+		category = domain.CategoryOther
+	}
+	task := domain.NewTask(input.Title, input.Description, input.Priority, category, input.CreatedBy)
+	task.ID = input.ID
+	task, err = s.saveNewTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.GroupResolver.AddTaskToGroup(ctx, task.ID, input.GroupID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to add task to group",
+			logger.Any("taskID", task.ID), logger.Any("groupID", input.GroupID), logger.Error(err))
+		return nil, fmt.Errorf("failed to add task to group: %w", err)
+	}
+
+	assigneeID := input.AssigneeID
+	if assigneeID == "" && !input.SkipAutoAssign {
+		assigneeID, err = s.GroupAssigner.PickAssignee(ctx, input.GroupID, input.CreatedBy)
+		if err != nil {
+			// 自動割り当ての失敗は非致命的（担当者なしのまま作成する）
+			s.Logger.WithContext(ctx).Warn("Failed to pick assignee for group task",
+				logger.Any("taskID", task.ID), logger.Any("groupID", input.GroupID), logger.Error(err))
+			return task, nil
+		}
+	}
+	if assigneeID == "" {
+		return task, nil
+	}
+
+	return s.AssignTask(ctx, task.ID, input.CreatedBy, assigneeID)
+}
+
+// GetEventLinks はタスクとグループの予定の関連付けを返す（取得できない場合は表示しない）
+func (s *TaskService) GetEventLinks(ctx context.Context, task *domain.Task) []*domain.TaskEventLink {
+	if s.EventLinks == nil {
+		return nil
+	}
+	links, err := s.EventLinks.ListTaskEventLinks(ctx, task.ID)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to get event links",
+			logger.Any("taskID", task.ID),
+			logger.Error(err))
+		return nil
+	}
+	return links
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=group_task.go -destination=mocks/mock_group_task.go -package=mocks
+
+type groupTaskTestMocks struct {
+	resolver *mocks.MockGroupTaskResolver
+	assigner *mocks.MockGroupTaskAssigner
+	tasks    map[string]*domain.Task
+}
+
+func newGroupTaskTestService(t *testing.T) (*TaskService, *groupTaskTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &groupTaskTestMocks{
+		resolver: mocks.NewMockGroupTaskResolver(ctrl),
+		assigner: mocks.NewMockGroupTaskAssigner(ctrl),
+		tasks:    make(map[string]*domain.Task),
+	}
+	repo := &MockTaskRepository{
+		CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+			m.tasks[task.ID] = task
+			return nil
+		},
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return m.tasks[id], nil
+		},
+		UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+			return nil
+		},
+	}
+	service := NewTaskService(repo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.GroupResolver = m.resolver
+	service.GroupAssigner = m.assigner
+	return service, m
+}
+
+func TestTaskService_CreateGroupTask(t *testing.T) {
+	groupID := "group-1"
+
+	tests := []struct {
+		name              string
+		input             CreateGroupTaskInput
+		setupMocks        func(m *groupTaskTestMocks)
+		expectedError     error
+		expectedAssignees []string
+	}{
+		{
+			name:  "auto-assigns when no assignee is given",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "owner"},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "owner").Return(true, nil)
+				m.resolver.EXPECT().AddTaskToGroup(gomock.Any(), gomock.Any(), groupID).Return(nil)
+				m.assigner.EXPECT().PickAssignee(gomock.Any(), groupID, "owner").Return("member-1", nil)
+			},
+			expectedAssignees: []string{"member-1"},
+		},
+		{
+			name:  "explicit assignee skips auto-assignment",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "owner", AssigneeID: "member-2"},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "owner").Return(true, nil)
+				m.resolver.EXPECT().IsGroupMember(gomock.Any(), groupID, "member-2").Return(true, nil)
+				m.resolver.EXPECT().AddTaskToGroup(gomock.Any(), gomock.Any(), groupID).Return(nil)
+			},
+			expectedAssignees: []string{"member-2"},
+		},
+		{
+			name:  "override flag creates the task unassigned",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "owner", SkipAutoAssign: true},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "owner").Return(true, nil)
+				m.resolver.EXPECT().AddTaskToGroup(gomock.Any(), gomock.Any(), groupID).Return(nil)
+			},
+		},
+		{
+			name:  "no policy leaves the task unassigned",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "owner"},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "owner").Return(true, nil)
+				m.resolver.EXPECT().AddTaskToGroup(gomock.Any(), gomock.Any(), groupID).Return(nil)
+				m.assigner.EXPECT().PickAssignee(gomock.Any(), groupID, "owner").Return("", nil)
+			},
+		},
+		{
+			name:  "auto-assignment failure does not fail creation",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "owner"},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "owner").Return(true, nil)
+				m.resolver.EXPECT().AddTaskToGroup(gomock.Any(), gomock.Any(), groupID).Return(nil)
+				m.assigner.EXPECT().PickAssignee(gomock.Any(), groupID, "owner").Return("", errors.New("db error"))
+			},
+		},
+		{
+			name:  "requires permission to create group tasks",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "guest"},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "guest").Return(false, nil)
+			},
+			expectedError: ErrPermissionDenied,
+		},
+		{
+			name:  "assignee must be a group member",
+			input: CreateGroupTaskInput{Title: "資料作成", GroupID: groupID, CreatedBy: "owner", AssigneeID: "outsider"},
+			setupMocks: func(m *groupTaskTestMocks) {
+				m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), groupID, "owner").Return(true, nil)
+				m.resolver.EXPECT().IsGroupMember(gomock.Any(), groupID, "outsider").Return(false, nil)
+			},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name:          "group is required",
+			input:         CreateGroupTaskInput{Title: "資料作成", CreatedBy: "owner"},
+			setupMocks:    func(m *groupTaskTestMocks) {},
+			expectedError: ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newGroupTaskTestService(t)
+			tt.setupMocks(m)
+
+			task, err := service.CreateGroupTask(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, m.tasks)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.CategoryOther, task.Category)
+			assert.ElementsMatch(t, tt.expectedAssignees, task.AssigneeIDs())
+		})
+	}
+}
+
+func TestTaskService_CreateGroupTask_NotConfigured(t *testing.T) {
+	service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+	_, err := service.CreateGroupTask(context.Background(), CreateGroupTaskInput{Title: "資料作成", GroupID: "group-1", CreatedBy: "owner"})
+
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+// exhaustedQuota は指定した対象の上限に達しているQuotaChecker
+type exhaustedQuota struct {
+	scope string
+}
+
+func (q exhaustedQuota) CheckQuota(ctx context.Context, scope, subjectID, resource string, amount int64) error {
+	if scope != q.scope {
+		return nil
+	}
+	return &commonDomain.QuotaExceededError{Scope: scope, Resource: resource, Limit: 1, Used: 1}
+}
+
+func TestTaskService_CreateGroupTask_Quota(t *testing.T) {
+	for _, scope := range []string{commonDomain.QuotaScopeGroup, commonDomain.QuotaScopeUser} {
+		t.Run(scope+" open task limit rejects creation", func(t *testing.T) {
+			service, m := newGroupTaskTestService(t)
+			service.Quotas = exhaustedQuota{scope: scope}
+			m.assigner.EXPECT().CanCreateGroupTask(gomock.Any(), "group-1", "owner").Return(true, nil)
+
+			_, err := service.CreateGroupTask(context.Background(), CreateGroupTaskInput{Title: "資料作成", GroupID: "group-1", CreatedBy: "owner"})
+
+			assert.ErrorIs(t, err, commonDomain.ErrQuotaExceeded)
+			assert.Empty(t, m.tasks)
+		})
+	}
+}
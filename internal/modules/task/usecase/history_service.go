@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskHistoryRepository はタスクの変更イベントとスナップショットのリポジトリインターフェース
+type TaskHistoryRepository interface {
+	// AppendTaskEvent はイベントを追記する（同じタスクで連番が重複する場合はエラー）
+	AppendTaskEvent(ctx context.Context, event *domain.TaskEvent) error
+	// GetLastTaskEventSequence はタスクの最後のイベントの連番を返す（イベントがない場合は0）
+	GetLastTaskEventSequence(ctx context.Context, taskID string) (int64, error)
+	// ListTaskEvents は連番がafterSequenceより大きく、until以前に発生したイベントを連番順に取得する
+	ListTaskEvents(ctx context.Context, taskID string, afterSequence int64, until time.Time) ([]*domain.TaskEvent, error)
+	// ListStatusEvents は複数タスクの作成・ステータス変更のイベントのうちuntil以前に発生したものを発生順に取得する
+	ListStatusEvents(ctx context.Context, taskIDs []string, until time.Time) ([]*domain.TaskEvent, error)
+	SaveTaskSnapshot(ctx context.Context, snapshot *domain.TaskSnapshot) error
+	// GetLatestTaskSnapshot はuntil以前に取得した最新のスナップショットを返す（ない場合はnil）
+	GetLatestTaskSnapshot(ctx context.Context, taskID string, until time.Time) (*domain.TaskSnapshot, error)
+}
+
+// TaskHistoryRecorder はタスクの変更履歴を記録するインターフェース
+// 記録の失敗はタスクの操作を妨げないため、エラーは返さない
+type TaskHistoryRecorder interface {
+	RecordCreated(ctx context.Context, task *domain.Task)
+	// RecordChange は変更前の状態と変更後のタスクの差分を記録する（差分がない場合は何もしない）
+	RecordChange(ctx context.Context, before *domain.TaskState, task *domain.Task)
+}
+
+// ErrTaskHistoryNotFound は指定した時点にタスクの履歴がない場合のエラー
+var ErrTaskHistoryNotFound = errors.New("task history not found")
+
+// ErrFeatureDisabled は機能フラグでユーザーに公開されていない機能を使おうとした場合のエラー
+var ErrFeatureDisabled = errors.New("feature is not available")
+
+// HistoryService はタスクの変更履歴の記録と再生を扱うサービス
+type HistoryService struct {
+	HistoryRepository TaskHistoryRepository
+	TaskRepository    TaskRepository
+	GroupResolver     GroupTaskResolver
+	// 変更履歴の再生を公開するユーザーの判定（未設定の場合は全ユーザーに公開する）
+	Features commonDomain.FeatureEvaluator
+	Logger   logger.Logger
+
+	// 同じタスクへの同時の変更で連番が重複しないように記録を直列化する
+	mu sync.Mutex
+}
+
+// NewHistoryService はHistoryServiceのコンストラクタ
+func NewHistoryService(
+	historyRepo TaskHistoryRepository,
+	taskRepo TaskRepository,
+	groupResolver GroupTaskResolver,
+	logger logger.Logger,
+) *HistoryService {
+	return &HistoryService{
+		HistoryRepository: historyRepo,
+		TaskRepository:    taskRepo,
+		GroupResolver:     groupResolver,
+		Logger:            logger,
+	}
+}
+
+// RecordCreated はタスクの作成イベントを記録する
+func (s *HistoryService) RecordCreated(ctx context.Context, task *domain.Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := domain.NewTaskCreatedEvent(task.ID, task.CreatedBy, 1, domain.NewTaskState(task), task.CreatedAt)
+	if err == nil {
+		err = s.append(ctx, event, domain.NewTaskState(task))
+	}
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to record task creation",
+			logger.Any("taskID", task.ID), logger.Error(err))
+	}
+}
+
+// RecordChange は変更前の状態と変更後のタスクの差分を変更イベントとして記録する
+// 操作したユーザーはcontextから取得する（取得できない場合はシステムによる変更として記録する）
+func (s *HistoryService) RecordChange(ctx context.Context, before *domain.TaskState, task *domain.Task) {
+	if err := s.recordChange(ctx, before, task); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to record task change",
+			logger.Any("taskID", task.ID), logger.Error(err))
+	}
+}
+
+func (s *HistoryService) recordChange(ctx context.Context, before *domain.TaskState, task *domain.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sequence, err := s.HistoryRepository.GetLastTaskEventSequence(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get last task event: %w", err)
+	}
+
+	now := time.Now()
+
+	// 履歴の記録開始前に作成されたタスクは、最初の変更の直前の状態を起点とする
+	if sequence == 0 {
+		baseline, err := domain.NewTaskCreatedEvent(task.ID, "", 1, before, now)
+		if err != nil {
+			return err
+		}
+		if err := s.append(ctx, baseline, before); err != nil {
+			return err
+		}
+		sequence = baseline.Sequence
+	}
+
+	after := domain.NewTaskState(task)
+	event, err := domain.NewTaskChangeEvent(task.ID, commonDomain.ActorIDFromContext(ctx), sequence+1, before, after, now)
+	if err != nil || event == nil {
+		return err
+	}
+	return s.append(ctx, event, after)
+}
+
+// append はイベントを追記し、スナップショットの間隔に達した場合は適用後の状態を保存する
+func (s *HistoryService) append(ctx context.Context, event *domain.TaskEvent, state *domain.TaskState) error {
+	event.ID = uuid.New().String()
+	if err := s.HistoryRepository.AppendTaskEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to append task event: %w", err)
+	}
+	if !event.ShouldSnapshot() {
+		return nil
+	}
+
+	snapshot := &domain.TaskSnapshot{
+		TaskID:   event.TaskID,
+		Sequence: event.Sequence,
+		State:    state,
+		TakenAt:  event.OccurredAt,
+	}
+	if err := s.HistoryRepository.SaveTaskSnapshot(ctx, snapshot); err != nil {
+		// スナップショットは再生の高速化のためのものなので、失敗しても履歴は失われない
+		s.Logger.WithContext(ctx).Warn("Failed to save task snapshot",
+			logger.Any("taskID", event.TaskID), logger.Any("sequence", event.Sequence), logger.Error(err))
+	}
+	return nil
+}
+
+// GetTaskHistory は指定した時点のタスクの状態を変更履歴から再生する
+// タスクの作成者・担当者と、グループタスクの場合はグループでタスク閲覧の権限を持つメンバーが参照できる
+func (s *HistoryService) GetTaskHistory(ctx context.Context, taskID, userID string, at time.Time) (*domain.TaskHistory, error) {
+	if taskID == "" || userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	if s.Features != nil && !s.Features.IsEnabled(ctx, domain.FeatureTaskHistory, userID) {
+		return nil, ErrFeatureDisabled
+	}
+
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.HistoryRepository.GetLatestTaskSnapshot(ctx, taskID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task snapshot: %w", err)
+	}
+
+	// スナップショットのイベント自体も取得して最後のイベントとして返す
+	// 変更後の値を持つイベントの再適用は状態を変えない
+	var afterSequence int64
+	if snapshot != nil {
+		afterSequence = snapshot.Sequence - 1
+	}
+	events, err := s.HistoryRepository.ListTaskEvents(ctx, taskID, afterSequence, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task events: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, ErrTaskHistoryNotFound
+	}
+
+	state, err := domain.ReplayTaskEvents(snapshot, events)
+	if err != nil {
+		return nil, err
+	}
+
+	last := events[len(events)-1]
+	return &domain.TaskHistory{
+		TaskID:    taskID,
+		At:        at,
+		Sequence:  last.Sequence,
+		State:     state,
+		LastEvent: last,
+	}, nil
+}
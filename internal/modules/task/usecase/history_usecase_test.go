@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=history_service.go -destination=mocks/mock_history.go -package=mocks
+
+type historyTestMocks struct {
+	historyRepo *mocks.MockTaskHistoryRepository
+	taskRepo    *mocks.MockTaskRepository
+	resolver    *mocks.MockGroupTaskResolver
+}
+
+func newHistoryTestService(t *testing.T) (*HistoryService, *historyTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &historyTestMocks{
+		historyRepo: mocks.NewMockTaskHistoryRepository(ctrl),
+		taskRepo:    mocks.NewMockTaskRepository(ctrl),
+		resolver:    mocks.NewMockGroupTaskResolver(ctrl),
+	}
+	return NewHistoryService(m.historyRepo, m.taskRepo, m.resolver, *createTestLogger()), m
+}
+
+// featureFlags はフラグのキーごとに公開するユーザーを持つテスト用のFeatureEvaluator
+type featureFlags map[string]map[string]bool
+
+func (f featureFlags) IsEnabled(ctx context.Context, key, userID string) bool {
+	return f[key][userID]
+}
+
+func newHistoryTestTask() *domain.Task {
+	task := domain.NewTask("Write report", "", domain.PriorityMedium, domain.CategoryWork, "owner")
+	task.ID = "task-1"
+	return task
+}
+
+func TestHistoryService_RecordChange(t *testing.T) {
+	t.Run("appends a change event with the actor from the context", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		task := newHistoryTestTask()
+		before := domain.NewTaskState(task)
+		task.SetStatus(domain.TaskStatusInProgress)
+
+		m.historyRepo.EXPECT().GetLastTaskEventSequence(gomock.Any(), "task-1").Return(int64(3), nil)
+		m.historyRepo.EXPECT().AppendTaskEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event *domain.TaskEvent) error {
+				assert.NotEmpty(t, event.ID)
+				assert.Equal(t, int64(4), event.Sequence)
+				assert.Equal(t, domain.TaskEventStatusChanged, event.Type)
+				assert.Equal(t, "editor", event.ActorID)
+				return nil
+			})
+
+		service.RecordChange(commonDomain.ContextWithActorID(context.Background(), "editor"), before, task)
+	})
+
+	t.Run("records a baseline for tasks created before history existed", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		task := newHistoryTestTask()
+		before := domain.NewTaskState(task)
+		task.Title = "Write final report"
+
+		var appended []*domain.TaskEvent
+		m.historyRepo.EXPECT().GetLastTaskEventSequence(gomock.Any(), "task-1").Return(int64(0), nil)
+		m.historyRepo.EXPECT().AppendTaskEvent(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(ctx context.Context, event *domain.TaskEvent) error {
+				appended = append(appended, event)
+				return nil
+			})
+
+		service.RecordChange(context.Background(), before, task)
+
+		require.Len(t, appended, 2)
+		assert.Equal(t, domain.TaskEventCreated, appended[0].Type)
+		assert.Equal(t, int64(1), appended[0].Sequence)
+		assert.Equal(t, domain.TaskEventFieldChanged, appended[1].Type)
+		assert.Equal(t, int64(2), appended[1].Sequence)
+		assert.Empty(t, appended[1].ActorID, "changes without an actor are recorded as system changes")
+	})
+
+	t.Run("saves a snapshot at the snapshot interval", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		task := newHistoryTestTask()
+		before := domain.NewTaskState(task)
+		task.Priority = domain.PriorityHigh
+
+		m.historyRepo.EXPECT().GetLastTaskEventSequence(gomock.Any(), "task-1").Return(int64(domain.TaskSnapshotInterval-1), nil)
+		m.historyRepo.EXPECT().AppendTaskEvent(gomock.Any(), gomock.Any()).Return(nil)
+		m.historyRepo.EXPECT().SaveTaskSnapshot(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, snapshot *domain.TaskSnapshot) error {
+				assert.Equal(t, int64(domain.TaskSnapshotInterval), snapshot.Sequence)
+				assert.Equal(t, domain.PriorityHigh, snapshot.State.Priority)
+				return nil
+			})
+
+		service.RecordChange(context.Background(), before, task)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		task := newHistoryTestTask()
+
+		m.historyRepo.EXPECT().GetLastTaskEventSequence(gomock.Any(), "task-1").Return(int64(1), nil)
+
+		service.RecordChange(context.Background(), domain.NewTaskState(task), task)
+	})
+}
+
+func TestHistoryService_GetTaskHistory(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	task := newHistoryTestTask()
+	created, err := domain.NewTaskCreatedEvent("task-1", "owner", 1, domain.NewTaskState(task), now)
+	require.NoError(t, err)
+	renamed := *task
+	renamed.Title = "Write final report"
+	rename, err := domain.NewTaskChangeEvent("task-1", "member", 2, domain.NewTaskState(task), domain.NewTaskState(&renamed), now.Add(time.Hour))
+	require.NoError(t, err)
+
+	t.Run("replays events up to the requested time", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		at := now.Add(2 * time.Hour)
+
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.historyRepo.EXPECT().GetLatestTaskSnapshot(gomock.Any(), "task-1", at).Return(nil, nil)
+		m.historyRepo.EXPECT().ListTaskEvents(gomock.Any(), "task-1", int64(0), at).
+			Return([]*domain.TaskEvent{created, rename}, nil)
+
+		history, err := service.GetTaskHistory(context.Background(), "task-1", "owner", at)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), history.Sequence)
+		assert.Equal(t, "Write final report", history.State.Title)
+		assert.Equal(t, "member", history.LastEvent.ActorID)
+	})
+
+	t.Run("starts from the latest snapshot", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		at := now.Add(2 * time.Hour)
+		snapshot := &domain.TaskSnapshot{TaskID: "task-1", Sequence: 2, State: domain.NewTaskState(&renamed), TakenAt: rename.OccurredAt}
+
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.historyRepo.EXPECT().GetLatestTaskSnapshot(gomock.Any(), "task-1", at).Return(snapshot, nil)
+		m.historyRepo.EXPECT().ListTaskEvents(gomock.Any(), "task-1", int64(1), at).
+			Return([]*domain.TaskEvent{rename}, nil)
+
+		history, err := service.GetTaskHistory(context.Background(), "task-1", "owner", at)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Write final report", history.State.Title)
+		assert.Equal(t, int64(2), history.Sequence)
+	})
+
+	t.Run("group members can read the history", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionViewTasks).Return(true, nil)
+		m.historyRepo.EXPECT().GetLatestTaskSnapshot(gomock.Any(), "task-1", now).Return(nil, nil)
+		m.historyRepo.EXPECT().ListTaskEvents(gomock.Any(), "task-1", int64(0), now).
+			Return([]*domain.TaskEvent{created}, nil)
+
+		history, err := service.GetTaskHistory(context.Background(), "task-1", "member", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Write report", history.State.Title)
+	})
+
+	t.Run("other users are denied", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+
+		_, err := service.GetTaskHistory(context.Background(), "task-1", "stranger", now)
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("before the first event", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+		at := now.Add(-time.Hour)
+
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.historyRepo.EXPECT().GetLatestTaskSnapshot(gomock.Any(), "task-1", at).Return(nil, nil)
+		m.historyRepo.EXPECT().ListTaskEvents(gomock.Any(), "task-1", int64(0), at).Return(nil, nil)
+
+		_, err := service.GetTaskHistory(context.Background(), "task-1", "owner", at)
+
+		assert.ErrorIs(t, err, ErrTaskHistoryNotFound)
+	})
+
+	t.Run("hidden behind the feature flag", func(t *testing.T) {
+		service, _ := newHistoryTestService(t)
+		service.Features = featureFlags{domain.FeatureTaskHistory: {"owner": true}}
+
+		_, err := service.GetTaskHistory(context.Background(), "task-1", "member", now)
+
+		assert.ErrorIs(t, err, ErrFeatureDisabled)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, m := newHistoryTestService(t)
+
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		m.historyRepo.EXPECT().GetLatestTaskSnapshot(gomock.Any(), "task-1", now).Return(nil, errors.New("db down"))
+
+		_, err := service.GetTaskHistory(context.Background(), "task-1", "owner", now)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskService_RecordsHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := mocks.NewMockTaskHistoryRecorder(ctrl)
+	task := newHistoryTestTask()
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return task, nil
+		},
+		UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+			return nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.HistoryRecorder = recorder
+
+	recorder.EXPECT().RecordChange(gomock.Any(), gomock.Any(), task).
+		Do(func(ctx context.Context, before *domain.TaskState, after *domain.Task) {
+			assert.Equal(t, domain.TaskStatusTodo, before.Status)
+			assert.Equal(t, domain.TaskStatusInProgress, after.Status)
+		})
+
+	_, err := service.ChangeTaskStatus(context.Background(), "task-1", domain.TaskStatusInProgress)
+
+	require.NoError(t, err)
+}
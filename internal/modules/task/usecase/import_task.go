@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// ImportTask は別のサーバーからインポートしたタスクを新しいIDで作成する
+// 状態・期限・見積もり・作成日時などはインポート元の値を引き継ぐ（作成者と担当者は呼び出し側で置き換える）
+func (s *TaskService) ImportTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	if err := s.validateCreateTaskInput(task.Title, task.Description, task.CreatedBy); err != nil {
+		return nil, err
+	}
+	if err := validateImportedTask(task); err != nil {
+		return nil, err
+	}
+	if task.EstimateMinutes != nil || task.EstimatePoints != nil || task.ActualMinutes != nil {
+		if err := validateEstimate(task.EstimateMinutes, task.EstimatePoints, task.ActualMinutes); err != nil {
+			return nil, err
+		}
+	}
+
+	task.ID = ""
+	if task.Status == domain.TaskStatusDone {
+		if task.CompletedAt == nil {
+			completedAt := task.UpdatedAt
+			task.CompletedAt = &completedAt
+		}
+		// 完了済みのタスクは担当者の分も完了済みにする
+		for _, assignee := range task.Assignees {
+			if assignee.CompletedAt == nil {
+				assignee.CompletedAt = task.CompletedAt
+			}
+		}
+	} else {
+		task.CompletedAt = nil
+	}
+	task.UpdateIsOverdue()
+	return s.saveNewTask(ctx, task)
+}
+
+// validateImportedTask はインポートしたタスクの状態・優先度・カテゴリ・日時を検証する
+func validateImportedTask(task *domain.Task) error {
+	switch task.Status {
+	case domain.TaskStatusTodo, domain.TaskStatusInProgress, domain.TaskStatusDone:
+	default:
+		return fmt.Errorf("%w: invalid status: %s", ErrInvalidParameter, task.Status)
+	}
+	switch task.Priority {
+	case domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh:
+	default:
+		return fmt.Errorf("%w: invalid priority: %s", ErrInvalidParameter, task.Priority)
+	}
+	switch task.Category {
+	case domain.CategoryWork, domain.CategoryPersonal, domain.CategoryStudy,
+		domain.CategoryHealth, domain.CategoryShopping, domain.CategoryOther:
+	default:
+		return fmt.Errorf("%w: invalid category: %s", ErrInvalidParameter, task.Category)
+	}
+	if task.CreatedAt.IsZero() || task.UpdatedAt.IsZero() {
+		return fmt.Errorf("%w: created_at and updated_at are required", ErrInvalidParameter)
+	}
+	if !task.HasValidSchedule() {
+		return fmt.Errorf("%w: start_date must not be after due_date", ErrInvalidParameter)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+func TestTaskService_ImportTask(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(48 * time.Hour)
+	newImportedTask := func() *domain.Task {
+		return &domain.Task{
+			ID:        "source-task",
+			Title:     "Write report",
+			Status:    domain.TaskStatusDone,
+			Priority:  domain.PriorityHigh,
+			Category:  domain.CategoryWork,
+			CreatedBy: "user-1",
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		}
+	}
+
+	t.Run("creates the task with a new id and keeps the imported state", func(t *testing.T) {
+		var saved *domain.Task
+		repo := &MockTaskRepository{
+			CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+				saved = task
+				return nil
+			},
+		}
+		service := NewTaskService(repo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		task := newImportedTask()
+		task.AddAssignee("user-1")
+		task.UpdatedAt = updatedAt
+		created, err := service.ImportTask(ctx, task)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.NotEqual(t, "source-task", created.ID)
+		assert.Equal(t, domain.TaskStatusDone, saved.Status)
+		assert.Equal(t, createdAt, saved.CreatedAt)
+		require.NotNil(t, saved.CompletedAt)
+		assert.Equal(t, updatedAt, *saved.CompletedAt)
+		require.Len(t, saved.Assignees, 1)
+		assert.True(t, saved.Assignees[0].IsCompleted())
+	})
+
+	t.Run("clears completed_at of unfinished tasks", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		task := newImportedTask()
+		task.Status = domain.TaskStatusInProgress
+		task.CompletedAt = &updatedAt
+		created, err := service.ImportTask(ctx, task)
+
+		require.NoError(t, err)
+		assert.Nil(t, created.CompletedAt)
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+		before := createdAt.Add(-time.Hour)
+		for name, modify := range map[string]func(*domain.Task){
+			"status":     func(task *domain.Task) { task.Status = "ARCHIVED" },
+			"priority":   func(task *domain.Task) { task.Priority = "URGENT" },
+			"category":   func(task *domain.Task) { task.Category = "" },
+			"title":      func(task *domain.Task) { task.Title = " " },
+			"created_at": func(task *domain.Task) { task.CreatedAt = time.Time{} },
+			"schedule":   func(task *domain.Task) { task.StartDate = &createdAt; task.DueDate = &before },
+			"estimate":   func(task *domain.Task) { minutes := -1; task.EstimateMinutes = &minutes },
+		} {
+			task := newImportedTask()
+			modify(task)
+			_, err := service.ImportTask(ctx, task)
+			assert.ErrorIs(t, err, ErrInvalidParameter, name)
+		}
+	})
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// InboundEmailRepository はタスク作成用のメールアドレスと受信したメールの記録のリポジトリインターフェース
+type InboundEmailRepository interface {
+	// SaveInboundAddress はユーザーのアドレスを保存する（作成済みの場合は置き換える）
+	SaveInboundAddress(ctx context.Context, address *domain.InboundAddress) error
+	// GetInboundAddress はユーザーのアドレスを取得する（未作成の場合は nil）
+	GetInboundAddress(ctx context.Context, userID string) (*domain.InboundAddress, error)
+	// GetInboundAddressByToken はアドレスのトークンでアドレスを取得する（存在しない場合は nil）
+	GetInboundAddressByToken(ctx context.Context, token string) (*domain.InboundAddress, error)
+	DeleteInboundAddress(ctx context.Context, userID string) error
+
+	CreateInboundEmail(ctx context.Context, record *domain.InboundEmailRecord) error
+	// ExistsInboundEmail はユーザー宛ての同じMessage-IDのメールからタスクを作成済みか
+	ExistsInboundEmail(ctx context.Context, userID, messageID string) (bool, error)
+	// CountInboundEmailsSince は since 以降にユーザー宛てのメールから作成したタスクの数を返す
+	CountInboundEmailsSince(ctx context.Context, userID string, since time.Time) (int, error)
+}
+
+// InboundEmailParser はメールの受信サービスのWebhookのインターフェース
+type InboundEmailParser interface {
+	// ParseInboundEmail はWebhookの署名を検証して受信したメールを返す
+	ParseInboundEmail(contentType string, payload []byte) (*domain.InboundEmail, error)
+}
+
+// InboundTaskCreator はメールからタスクを作成するインターフェース（TaskServiceが実装する）
+type InboundTaskCreator interface {
+	CreateTask(ctx context.Context, title, description string, priority domain.Priority, category domain.Category, createdBy string) (*domain.Task, error)
+}
+
+// InboundAttachmentSaver はメールの添付ファイルをタスクに保存するインターフェース（AttachmentServiceが実装する）
+type InboundAttachmentSaver interface {
+	SaveInboundAttachment(ctx context.Context, taskID, userID string, attachment domain.InboundAttachment) error
+}
+
+var (
+	// ErrInboundAddressNotFound はタスク作成用のメールアドレスを作成していないことを表すエラー
+	ErrInboundAddressNotFound = errors.New("inbound email address not found")
+	// ErrInboundEmailDisabled はメールの受信が設定されていないことを表すエラー
+	ErrInboundEmailDisabled = errors.New("inbound email is not configured")
+	// ErrInvalidInboundSignature はWebhookの署名が無効であることを表すエラー
+	ErrInvalidInboundSignature = errors.New("invalid inbound email signature")
+	// ErrInvalidInboundEmail はWebhookのペイロードが無効であることを表すエラー
+	ErrInvalidInboundEmail = errors.New("invalid inbound email payload")
+)
+
+const (
+	// defaultInboundMaxPerHour はユーザーごとに1時間にメールから作成できるタスクの数の既定値
+	defaultInboundMaxPerHour = 20
+	// defaultInboundMaxSpamScore はタスクを作成するスパムスコアの上限の既定値
+	defaultInboundMaxSpamScore = 5.0
+)
+
+// InboundEmailService はユーザーごとのメールアドレスに届いたメールからタスクを作成するサービス
+// 件名をタイトル、本文を説明とし、添付ファイルはタスクの添付ファイルとして保存する
+// 許可した差出人からのSPF・DKIMで確認できたメールだけを受け付け、スパム判定・Message-IDの重複・1時間あたりの上限で弾く
+type InboundEmailService struct {
+	Repository InboundEmailRepository
+	Parser     InboundEmailParser
+	Tasks      InboundTaskCreator
+	// 添付ファイルの保存先（未設定の場合は添付ファイルを保存しない）
+	Attachments   InboundAttachmentSaver
+	UserValidator UserValidator
+	// Domain はメールを受信するドメイン（空の場合はアドレスを作成できない）
+	Domain string
+	// MaxPerHour はユーザーごとに1時間にメールから作成できるタスクの数（0以下の場合は既定値）
+	MaxPerHour int
+	// MaxSpamScore はタスクを作成するスパムスコアの上限（0以下の場合は既定値）
+	MaxSpamScore float64
+	Logger       logger.Logger
+}
+
+// NewInboundEmailService はInboundEmailServiceのコンストラクタ
+func NewInboundEmailService(
+	repo InboundEmailRepository,
+	parser InboundEmailParser,
+	tasks InboundTaskCreator,
+	userValidator UserValidator,
+	emailDomain string,
+	logger logger.Logger,
+) *InboundEmailService {
+	return &InboundEmailService{
+		Repository:    repo,
+		Parser:        parser,
+		Tasks:         tasks,
+		UserValidator: userValidator,
+		Domain:        emailDomain,
+		Logger:        logger,
+	}
+}
+
+// === アドレス ===
+
+// GetAddress はユーザーのタスク作成用のメールアドレスを取得する
+func (s *InboundEmailService) GetAddress(ctx context.Context, userID string) (*domain.InboundAddress, error) {
+	address, err := s.Repository.GetInboundAddress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inbound address: %w", err)
+	}
+	if address == nil {
+		return nil, ErrInboundAddressNotFound
+	}
+	return address, nil
+}
+
+// EnableAddress はタスク作成用のメールアドレスを作成し、許可する差出人を設定する
+// 作成済みの場合はアドレスを変えずに差出人だけを置き換え、差出人が空の場合はアカウントのメールアドレスを許可する
+func (s *InboundEmailService) EnableAddress(ctx context.Context, userID string, senders []string) (*domain.InboundAddress, error) {
+	if s.Domain == "" {
+		return nil, ErrInboundEmailDisabled
+	}
+
+	allowed, err := domain.NormalizeInboundSenders(senders)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	if len(allowed) == 0 {
+		if allowed, err = s.accountSenders(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	address, err := s.Repository.GetInboundAddress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inbound address: %w", err)
+	}
+	now := time.Now()
+	if address == nil {
+		token, err := domain.NewInboundAddressToken()
+		if err != nil {
+			return nil, err
+		}
+		address = &domain.InboundAddress{UserID: userID, Token: token, CreatedAt: now}
+	}
+	address.AllowedSenders = allowed
+	address.UpdatedAt = now
+
+	if err := s.save(ctx, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// RotateAddress はタスク作成用のメールアドレスを新しいものに変える（以前のアドレス宛てのメールは受け付けなくなる）
+func (s *InboundEmailService) RotateAddress(ctx context.Context, userID string) (*domain.InboundAddress, error) {
+	if s.Domain == "" {
+		return nil, ErrInboundEmailDisabled
+	}
+
+	address, err := s.Repository.GetInboundAddress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inbound address: %w", err)
+	}
+	if address == nil {
+		return nil, ErrInboundAddressNotFound
+	}
+
+	if address.Token, err = domain.NewInboundAddressToken(); err != nil {
+		return nil, err
+	}
+	address.UpdatedAt = time.Now()
+	if err := s.save(ctx, address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// DisableAddress はタスク作成用のメールアドレスを削除する
+func (s *InboundEmailService) DisableAddress(ctx context.Context, userID string) error {
+	address, err := s.Repository.GetInboundAddress(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get inbound address: %w", err)
+	}
+	if address == nil {
+		return ErrInboundAddressNotFound
+	}
+	if err := s.Repository.DeleteInboundAddress(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete inbound address: %w", err)
+	}
+	return nil
+}
+
+// === Webhook ===
+
+// HandleWebhook はメールの受信サービスのWebhookを検証し、受け付けたメールからタスクを作成する
+// 宛先・差出人・スパム判定・重複・上限で受け付けなかったメールはエラーではなく理由を返す（受信サービスに再送させないため）
+func (s *InboundEmailService) HandleWebhook(ctx context.Context, contentType string, payload []byte) (domain.InboundEmailStatus, *domain.Task, error) {
+	if s.Domain == "" || s.Parser == nil {
+		return "", nil, ErrInboundEmailDisabled
+	}
+	email, err := s.Parser.ParseInboundEmail(contentType, payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	address, err := s.findAddress(ctx, email.Recipients)
+	if err != nil {
+		return "", nil, err
+	}
+	if address == nil {
+		return domain.InboundEmailUnknownRecipient, nil, nil
+	}
+
+	log := s.Logger.WithContext(ctx)
+	if !email.SenderVerified || !address.Allows(email.From) {
+		log.Info("Rejected inbound email from sender not allowed",
+			logger.Any("userID", address.UserID), logger.Any("sender", email.From))
+		return domain.InboundEmailSenderNotAllowed, nil, nil
+	}
+	if email.SpamFlagged || email.SpamScore > s.maxSpamScore() {
+		log.Info("Rejected inbound email flagged as spam",
+			logger.Any("userID", address.UserID), logger.Any("spamScore", email.SpamScore))
+		return domain.InboundEmailSpam, nil, nil
+	}
+
+	if email.MessageID != "" {
+		exists, err := s.Repository.ExistsInboundEmail(ctx, address.UserID, email.MessageID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to check inbound email: %w", err)
+		}
+		if exists {
+			return domain.InboundEmailDuplicate, nil, nil
+		}
+	}
+
+	now := time.Now()
+	count, err := s.Repository.CountInboundEmailsSince(ctx, address.UserID, now.Add(-time.Hour))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to count inbound emails: %w", err)
+	}
+	if count >= s.maxPerHour() {
+		log.Warn("Inbound email rate limit reached", logger.Any("userID", address.UserID))
+		return domain.InboundEmailRateLimited, nil, nil
+	}
+
+	description, truncated := email.TaskDescription()
+	task, err := s.Tasks.CreateTask(ctx, email.TaskTitle(), description, domain.PriorityMedium, domain.CategoryOther, address.UserID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := &domain.InboundEmailRecord{
+		ID:         uuid.New().String(),
+		UserID:     address.UserID,
+		MessageID:  email.MessageID,
+		Sender:     email.From,
+		TaskID:     task.ID,
+		ReceivedAt: now,
+	}
+	if err := s.Repository.CreateInboundEmail(ctx, record); err != nil {
+		log.Warn("Failed to record inbound email", logger.Any("taskID", task.ID), logger.Error(err))
+	}
+
+	s.saveAttachments(ctx, task, address.UserID, email, truncated)
+	return domain.InboundEmailCreated, task, nil
+}
+
+// findAddress は宛先のうち受信ドメインのアドレスを探す（見つからない場合は nil）
+func (s *InboundEmailService) findAddress(ctx context.Context, recipients []string) (*domain.InboundAddress, error) {
+	for _, recipient := range recipients {
+		token, ok := domain.InboundTokenFromRecipient(recipient, s.Domain)
+		if !ok {
+			continue
+		}
+		address, err := s.Repository.GetInboundAddressByToken(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inbound address: %w", err)
+		}
+		if address != nil {
+			return address, nil
+		}
+	}
+	return nil, nil
+}
+
+// saveAttachments はメールの添付ファイルと、説明に収まらなかった本文をタスクに保存する（失敗はログに記録する）
+func (s *InboundEmailService) saveAttachments(ctx context.Context, task *domain.Task, userID string, email *domain.InboundEmail, truncated bool) {
+	if s.Attachments == nil {
+		return
+	}
+
+	attachments := email.Attachments
+	if len(attachments) > domain.MaxInboundAttachments {
+		s.Logger.WithContext(ctx).Warn("Skipped inbound email attachments over the limit",
+			logger.Any("taskID", task.ID), logger.Any("count", len(attachments)))
+		attachments = attachments[:domain.MaxInboundAttachments]
+	}
+	if truncated {
+		attachments = append(attachments, domain.InboundAttachment{
+			Filename: domain.InboundBodyFilename,
+			Content:  []byte(email.Body),
+		})
+	}
+
+	for _, attachment := range attachments {
+		if err := s.Attachments.SaveInboundAttachment(ctx, task.ID, userID, attachment); err != nil {
+			s.Logger.WithContext(ctx).Warn("Failed to save inbound email attachment",
+				logger.Any("taskID", task.ID),
+				logger.Any("filename", attachment.Filename),
+				logger.Error(err))
+		}
+	}
+}
+
+// accountSenders はアカウントのメールアドレスを許可する差出人として返す
+func (s *InboundEmailService) accountSenders(ctx context.Context, userID string) ([]string, error) {
+	if s.UserValidator == nil {
+		return nil, fmt.Errorf("%w: allowed senders are required", ErrInvalidParameter)
+	}
+	user, err := s.UserValidator.GetUserInfo(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || user.Email == "" {
+		return nil, fmt.Errorf("%w: allowed senders are required", ErrInvalidParameter)
+	}
+	return domain.NormalizeInboundSenders([]string{user.Email})
+}
+
+// save はアドレスを保存する
+func (s *InboundEmailService) save(ctx context.Context, address *domain.InboundAddress) error {
+	if err := s.Repository.SaveInboundAddress(ctx, address); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to save inbound address",
+			logger.Any("userID", address.UserID), logger.Error(err))
+		return fmt.Errorf("failed to save inbound address: %w", err)
+	}
+	return nil
+}
+
+// EmailAddress はアドレスのトークンと受信ドメインのメールアドレスを返す
+func (s *InboundEmailService) EmailAddress(address *domain.InboundAddress) string {
+	return address.Address(s.Domain)
+}
+
+func (s *InboundEmailService) maxPerHour() int {
+	if s.MaxPerHour <= 0 {
+		return defaultInboundMaxPerHour
+	}
+	return s.MaxPerHour
+}
+
+func (s *InboundEmailService) maxSpamScore() float64 {
+	if s.MaxSpamScore <= 0 {
+		return defaultInboundMaxSpamScore
+	}
+	return s.MaxSpamScore
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=inbound_email_service.go -destination=mocks/mock_inbound_email.go -package=mocks
+
+type inboundEmailTestMocks struct {
+	repo        *mocks.MockInboundEmailRepository
+	parser      *mocks.MockInboundEmailParser
+	tasks       *mocks.MockInboundTaskCreator
+	attachments *mocks.MockInboundAttachmentSaver
+}
+
+func newInboundEmailTestService(t *testing.T) (*InboundEmailService, *inboundEmailTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &inboundEmailTestMocks{
+		repo:        mocks.NewMockInboundEmailRepository(ctrl),
+		parser:      mocks.NewMockInboundEmailParser(ctrl),
+		tasks:       mocks.NewMockInboundTaskCreator(ctrl),
+		attachments: mocks.NewMockInboundAttachmentSaver(ctrl),
+	}
+	service := NewInboundEmailService(m.repo, m.parser, m.tasks, &MockUserValidator{}, "in.example.com", *createTestLogger())
+	service.Attachments = m.attachments
+	return service, m
+}
+
+func newInboundTestAddress() *domain.InboundAddress {
+	return &domain.InboundAddress{
+		UserID:         "user-1",
+		Token:          "token1",
+		AllowedSenders: []string{"alice@example.com"},
+	}
+}
+
+func newInboundTestEmail() *domain.InboundEmail {
+	return &domain.InboundEmail{
+		Recipients:     []string{"someone@example.com", "token1+work@in.example.com"},
+		From:           "alice@example.com",
+		MessageID:      "<abc@example.com>",
+		Subject:        "Fwd: 見積もりの確認",
+		Body:           "金曜までに確認する",
+		SenderVerified: true,
+	}
+}
+
+func TestInboundEmailService_EnableAddress(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates an address allowing the account email by default", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		m.repo.EXPECT().GetInboundAddress(gomock.Any(), "user-1").Return(nil, nil)
+		m.repo.EXPECT().SaveInboundAddress(gomock.Any(), gomock.Any()).Return(nil)
+
+		address, err := service.EnableAddress(ctx, "user-1", nil)
+		require.NoError(t, err)
+		assert.NotEmpty(t, address.Token)
+		assert.Equal(t, []string{"test@example.com"}, address.AllowedSenders)
+		assert.Equal(t, address.Token+"@in.example.com", service.EmailAddress(address))
+	})
+
+	t.Run("keeps the address when changing senders", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		m.repo.EXPECT().GetInboundAddress(gomock.Any(), "user-1").Return(newInboundTestAddress(), nil)
+		m.repo.EXPECT().SaveInboundAddress(gomock.Any(), gomock.Any()).Return(nil)
+
+		address, err := service.EnableAddress(ctx, "user-1", []string{"@Corp.Example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "token1", address.Token)
+		assert.Equal(t, []string{"@corp.example.com"}, address.AllowedSenders)
+	})
+
+	t.Run("rejects invalid senders", func(t *testing.T) {
+		service, _ := newInboundEmailTestService(t)
+		_, err := service.EnableAddress(ctx, "user-1", []string{"not an address"})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("requires an inbound domain", func(t *testing.T) {
+		service, _ := newInboundEmailTestService(t)
+		service.Domain = ""
+		_, err := service.EnableAddress(ctx, "user-1", nil)
+		assert.ErrorIs(t, err, ErrInboundEmailDisabled)
+	})
+}
+
+func TestInboundEmailService_RotateAddress(t *testing.T) {
+	ctx := context.Background()
+	service, m := newInboundEmailTestService(t)
+	m.repo.EXPECT().GetInboundAddress(gomock.Any(), "user-1").Return(newInboundTestAddress(), nil)
+	m.repo.EXPECT().SaveInboundAddress(gomock.Any(), gomock.Any()).Return(nil)
+
+	address, err := service.RotateAddress(ctx, "user-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, "token1", address.Token)
+	assert.Equal(t, []string{"alice@example.com"}, address.AllowedSenders)
+}
+
+func TestInboundEmailService_HandleWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates a task with attachments", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		email := newInboundTestEmail()
+		email.Attachments = []domain.InboundAttachment{{Filename: "quote.pdf", Content: []byte("%PDF")}}
+		m.parser.EXPECT().ParseInboundEmail("multipart/form-data", []byte("payload")).Return(email, nil)
+		m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(newInboundTestAddress(), nil)
+		m.repo.EXPECT().ExistsInboundEmail(gomock.Any(), "user-1", "<abc@example.com>").Return(false, nil)
+		m.repo.EXPECT().CountInboundEmailsSince(gomock.Any(), "user-1", gomock.Any()).Return(0, nil)
+
+		task := newIssueLinkTestTask("task-1", "user-1")
+		m.tasks.EXPECT().CreateTask(gomock.Any(), "見積もりの確認", "金曜までに確認する", domain.PriorityMedium, domain.CategoryOther, "user-1").
+			Return(task, nil)
+		m.repo.EXPECT().CreateInboundEmail(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, record *domain.InboundEmailRecord) error {
+				assert.Equal(t, "task-1", record.TaskID)
+				assert.Equal(t, "alice@example.com", record.Sender)
+				return nil
+			})
+		m.attachments.EXPECT().SaveInboundAttachment(gomock.Any(), "task-1", "user-1", email.Attachments[0]).Return(nil)
+
+		status, created, err := service.HandleWebhook(ctx, "multipart/form-data", []byte("payload"))
+		require.NoError(t, err)
+		assert.Equal(t, domain.InboundEmailCreated, status)
+		assert.Equal(t, task, created)
+	})
+
+	t.Run("saves the full body when the description is truncated", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		email := newInboundTestEmail()
+		email.Body = strings.Repeat("a", domain.MaxInboundDescriptionBytes+1)
+		m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(email, nil)
+		m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(newInboundTestAddress(), nil)
+		m.repo.EXPECT().ExistsInboundEmail(gomock.Any(), "user-1", gomock.Any()).Return(false, nil)
+		m.repo.EXPECT().CountInboundEmailsSince(gomock.Any(), "user-1", gomock.Any()).Return(0, nil)
+		m.tasks.EXPECT().CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "user-1").
+			Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		// 記録に失敗してもタスクの作成は成功とする
+		m.repo.EXPECT().CreateInboundEmail(gomock.Any(), gomock.Any()).Return(assert.AnError)
+		m.attachments.EXPECT().SaveInboundAttachment(gomock.Any(), "task-1", "user-1", domain.InboundAttachment{
+			Filename: domain.InboundBodyFilename,
+			Content:  []byte(email.Body),
+		}).Return(nil)
+
+		status, _, err := service.HandleWebhook(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InboundEmailCreated, status)
+	})
+
+	t.Run("ignores unknown recipients", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(newInboundTestEmail(), nil)
+		m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(nil, nil)
+
+		status, task, err := service.HandleWebhook(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InboundEmailUnknownRecipient, status)
+		assert.Nil(t, task)
+	})
+
+	t.Run("rejects senders not allowed or not verified", func(t *testing.T) {
+		for name, modify := range map[string]func(*domain.InboundEmail){
+			"not allowed":  func(e *domain.InboundEmail) { e.From = "mallory@example.com" },
+			"not verified": func(e *domain.InboundEmail) { e.SenderVerified = false },
+		} {
+			t.Run(name, func(t *testing.T) {
+				service, m := newInboundEmailTestService(t)
+				email := newInboundTestEmail()
+				modify(email)
+				m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(email, nil)
+				m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(newInboundTestAddress(), nil)
+
+				status, _, err := service.HandleWebhook(ctx, "", nil)
+				require.NoError(t, err)
+				assert.Equal(t, domain.InboundEmailSenderNotAllowed, status)
+			})
+		}
+	})
+
+	t.Run("rejects spam", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		email := newInboundTestEmail()
+		email.SpamScore = 7.5
+		m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(email, nil)
+		m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(newInboundTestAddress(), nil)
+
+		status, _, err := service.HandleWebhook(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InboundEmailSpam, status)
+	})
+
+	t.Run("skips duplicate messages", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(newInboundTestEmail(), nil)
+		m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(newInboundTestAddress(), nil)
+		m.repo.EXPECT().ExistsInboundEmail(gomock.Any(), "user-1", "<abc@example.com>").Return(true, nil)
+
+		status, _, err := service.HandleWebhook(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InboundEmailDuplicate, status)
+	})
+
+	t.Run("limits tasks per hour", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		service.MaxPerHour = 3
+		m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(newInboundTestEmail(), nil)
+		m.repo.EXPECT().GetInboundAddressByToken(gomock.Any(), "token1").Return(newInboundTestAddress(), nil)
+		m.repo.EXPECT().ExistsInboundEmail(gomock.Any(), "user-1", gomock.Any()).Return(false, nil)
+		m.repo.EXPECT().CountInboundEmailsSince(gomock.Any(), "user-1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, since time.Time) (int, error) {
+				assert.WithinDuration(t, time.Now().Add(-time.Hour), since, time.Minute)
+				return 3, nil
+			})
+
+		status, _, err := service.HandleWebhook(ctx, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InboundEmailRateLimited, status)
+	})
+
+	t.Run("returns signature errors from the parser", func(t *testing.T) {
+		service, m := newInboundEmailTestService(t)
+		m.parser.EXPECT().ParseInboundEmail(gomock.Any(), gomock.Any()).Return(nil, ErrInvalidInboundSignature)
+
+		_, _, err := service.HandleWebhook(ctx, "", nil)
+		assert.ErrorIs(t, err, ErrInvalidInboundSignature)
+	})
+}
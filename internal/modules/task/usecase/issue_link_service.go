@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// IssueLinkRepository はタスクとIssue・Pull Requestの関連付けと、ユーザーのGitHubのトークンのリポジトリインターフェース
+type IssueLinkRepository interface {
+	CreateIssueLink(ctx context.Context, link *domain.IssueLink) error
+	// GetIssueLink はIDで関連付けを取得する（存在しない場合は nil）
+	GetIssueLink(ctx context.Context, id string) (*domain.IssueLink, error)
+	// ListIssueLinksByTask はタスクの関連付けを登録した順に取得する
+	ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error)
+	// ListIssueLinksByIssue はIssue・Pull Requestを関連付けたすべてのタスクの関連付けを取得する（Webhookでの状態の同期用）
+	ListIssueLinksByIssue(ctx context.Context, provider domain.IssueProvider, ref domain.IssueRef) ([]*domain.IssueLink, error)
+	// UpdateIssueLink は関連付けの種類・タイトル・状態・URLを更新する
+	UpdateIssueLink(ctx context.Context, link *domain.IssueLink) error
+	DeleteIssueLink(ctx context.Context, id string) error
+
+	// GitHubのトークン（ユーザーごとに1つ）
+	// SaveGitHubAccount はトークンを保存する（登録済みの場合は置き換える）
+	SaveGitHubAccount(ctx context.Context, account *domain.GitHubAccount) error
+	// GetGitHubAccount はユーザーのトークンを取得する（未登録の場合は nil）
+	GetGitHubAccount(ctx context.Context, userID string) (*domain.GitHubAccount, error)
+	DeleteGitHubAccount(ctx context.Context, userID string) error
+}
+
+// GitHubClient はGitHubのAPIとWebhookのインターフェース
+type GitHubClient interface {
+	// GetAuthenticatedUser はトークンのユーザー名を取得する（トークンの確認用）
+	GetAuthenticatedUser(ctx context.Context, token string) (string, error)
+	// GetIssue はIssue・Pull Requestを取得する（tokenが空の場合は公開リポジトリのみ）
+	GetIssue(ctx context.Context, token string, ref domain.IssueRef) (*domain.IssueInfo, error)
+	// ParseWebhook はX-Hub-Signature-256ヘッダーの署名を検証してIssue・Pull Requestのイベントを返す
+	// Issue・Pull Request以外のイベント（pingなど）の場合は nil
+	ParseWebhook(payload []byte, event, signature string) (*domain.IssueInfo, error)
+}
+
+// TaskStatusChanger はタスクの状態を変更するインターフェース（Webhookでのタスクの完了用）
+type TaskStatusChanger interface {
+	ChangeTaskStatus(ctx context.Context, taskID string, status domain.TaskStatus) (*domain.Task, error)
+}
+
+var (
+	// ErrIssueLinkNotFound はタスクとIssue・Pull Requestの関連付けが見つからないことを表すエラー
+	ErrIssueLinkNotFound = errors.New("issue link not found")
+	// ErrIssueLinkExists はタスクにIssue・Pull Requestを関連付け済みであることを表すエラー
+	ErrIssueLinkExists = errors.New("issue already linked to this task")
+	// ErrIssueNotFound はGitHubにIssue・Pull Requestがない、またはトークンで参照できないことを表すエラー
+	ErrIssueNotFound = errors.New("issue not found on GitHub")
+	// ErrGitHubAccountNotFound はGitHubのトークンを登録していないことを表すエラー
+	ErrGitHubAccountNotFound = errors.New("github account not connected")
+	// ErrInvalidGitHubToken はGitHubのトークンが無効・期限切れであることを表すエラー
+	ErrInvalidGitHubToken = errors.New("github token is invalid")
+	// ErrGitHubUnavailable はGitHubのAPIに接続できない・エラーが返ったことを表すエラー
+	ErrGitHubUnavailable = errors.New("github is unavailable")
+	// ErrGitHubWebhookDisabled はWebhookのシークレットが設定されていないことを表すエラー
+	ErrGitHubWebhookDisabled = errors.New("github webhook is not configured")
+	// ErrInvalidGitHubSignature はWebhookの署名が無効であることを表すエラー
+	ErrInvalidGitHubSignature = errors.New("invalid github webhook signature")
+	// ErrInvalidGitHubWebhook はWebhookのペイロードが無効であることを表すエラー
+	ErrInvalidGitHubWebhook = errors.New("invalid github webhook payload")
+)
+
+// IssueLinkService はタスクとGitHubのIssue・Pull Requestの関連付けと、Webhookによる状態の同期を扱うサービス
+// 関連付けを参照・変更できるのはタスクを参照できるユーザー（作成者・担当者・グループのメンバー）
+type IssueLinkService struct {
+	Repository     IssueLinkRepository
+	TaskRepository TaskRepository
+	GroupResolver  GroupTaskResolver
+	GitHub         GitHubClient
+	// Pull Requestのマージ・Issueの完了でタスクを完了にする（未設定の場合は状態の同期だけを行う）
+	StatusChanger TaskStatusChanger
+	Logger        logger.Logger
+}
+
+// NewIssueLinkService はIssueLinkServiceのコンストラクタ
+func NewIssueLinkService(
+	repo IssueLinkRepository,
+	taskRepo TaskRepository,
+	groupResolver GroupTaskResolver,
+	github GitHubClient,
+	logger logger.Logger,
+) *IssueLinkService {
+	return &IssueLinkService{
+		Repository:     repo,
+		TaskRepository: taskRepo,
+		GroupResolver:  groupResolver,
+		GitHub:         github,
+		Logger:         logger,
+	}
+}
+
+// === GitHubのトークン ===
+
+// ConnectGitHub はユーザーのGitHubのトークンを確認して保存する（登録済みの場合は置き換える）
+func (s *IssueLinkService) ConnectGitHub(ctx context.Context, userID, token string) (*domain.GitHubAccount, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidParameter)
+	}
+
+	login, err := s.GitHub.GetAuthenticatedUser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := &domain.GitHubAccount{
+		UserID:    userID,
+		Login:     login,
+		Token:     token,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if existing, err := s.Repository.GetGitHubAccount(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get github account: %w", err)
+	} else if existing != nil {
+		account.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.Repository.SaveGitHubAccount(ctx, account); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to save github account",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to save github account: %w", err)
+	}
+	return account, nil
+}
+
+// GetGitHubAccount はユーザーが登録したGitHubのアカウントを取得する
+func (s *IssueLinkService) GetGitHubAccount(ctx context.Context, userID string) (*domain.GitHubAccount, error) {
+	account, err := s.Repository.GetGitHubAccount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get github account: %w", err)
+	}
+	if account == nil {
+		return nil, ErrGitHubAccountNotFound
+	}
+	return account, nil
+}
+
+// DisconnectGitHub はユーザーのGitHubのトークンを削除する（関連付けたIssue・Pull Requestは残る）
+func (s *IssueLinkService) DisconnectGitHub(ctx context.Context, userID string) error {
+	if _, err := s.GetGitHubAccount(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.Repository.DeleteGitHubAccount(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete github account: %w", err)
+	}
+	return nil
+}
+
+// === タスクの関連付け ===
+
+// ListIssueLinks はタスクに関連付けたIssue・Pull Requestを取得する
+func (s *IssueLinkService) ListIssueLinks(ctx context.Context, taskID, userID string) ([]*domain.IssueLink, error) {
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.ListIssueLinksByTask(ctx, taskID)
+}
+
+// ListIssueLinksByTask はタスクに関連付けたIssue・Pull Requestを取得する（アクセス権は確認しない。タスクのリンク一覧用）
+func (s *IssueLinkService) ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error) {
+	links, err := s.Repository.ListIssueLinksByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue links: %w", err)
+	}
+	return links, nil
+}
+
+// LinkIssue はタスクにIssue・Pull Request（URL、または "owner/name#123"）を関連付ける
+// Issue・Pull Requestはユーザーのトークンで取得する（未登録の場合は公開リポジトリのみ）
+// completeOnClose はマージ・完了でタスクを完了にするか（nilの場合は完了にする）
+func (s *IssueLinkService) LinkIssue(ctx context.Context, taskID, userID, rawRef string, completeOnClose *bool) (*domain.IssueLink, error) {
+	ref, err := domain.ParseIssueRef(rawRef)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.Repository.ListIssueLinksByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue links: %w", err)
+	}
+	for _, link := range links {
+		if link.Ref() == ref {
+			return nil, ErrIssueLinkExists
+		}
+	}
+	if len(links) >= domain.MaxIssueLinksPerTask {
+		return nil, fmt.Errorf("%w: a task can have at most %d linked issues", ErrInvalidParameter, domain.MaxIssueLinksPerTask)
+	}
+
+	var token string
+	account, err := s.Repository.GetGitHubAccount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get github account: %w", err)
+	}
+	if account != nil {
+		token = account.Token
+	}
+	info, err := s.GitHub.GetIssue(ctx, token, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	complete := true
+	if completeOnClose != nil {
+		complete = *completeOnClose
+	}
+	link := domain.NewIssueLink(taskID, info, complete, userID, time.Now())
+	link.ID = uuid.New().String()
+	if err := s.Repository.CreateIssueLink(ctx, link); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create issue link",
+			logger.Any("taskID", taskID), logger.Any("issue", ref.String()), logger.Error(err))
+		return nil, fmt.Errorf("failed to create issue link: %w", err)
+	}
+	return link, nil
+}
+
+// UnlinkIssue はタスクとIssue・Pull Requestの関連付けを解除する
+func (s *IssueLinkService) UnlinkIssue(ctx context.Context, taskID, linkID, userID string) error {
+	link, err := s.Repository.GetIssueLink(ctx, linkID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue link: %w", err)
+	}
+	if link == nil || link.TaskID != taskID {
+		return ErrIssueLinkNotFound
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return err
+	}
+
+	if err := s.Repository.DeleteIssueLink(ctx, linkID); err != nil {
+		return fmt.Errorf("failed to delete issue link: %w", err)
+	}
+	return nil
+}
+
+// === Webhook ===
+
+// HandleWebhook はGitHubのWebhookを検証し、Issue・Pull Requestを関連付けたタスクに状態を反映する
+// マージ・完了に変わったIssue・Pull Requestを CompleteOnClose で関連付けたタスクは完了にし、完了にしたタスクの数を返す
+func (s *IssueLinkService) HandleWebhook(ctx context.Context, payload []byte, event, signature string) (int, error) {
+	info, err := s.GitHub.ParseWebhook(payload, event, signature)
+	if err != nil {
+		return 0, err
+	}
+	if info == nil {
+		return 0, nil
+	}
+
+	links, err := s.Repository.ListIssueLinksByIssue(ctx, domain.IssueProviderGitHub, info.Ref)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list issue links: %w", err)
+	}
+
+	// タスクを完了にしてから関連付けの状態を更新する（完了にできなかった場合はWebhookの再送で再び完了にする）
+	completed := 0
+	now := time.Now()
+	for _, link := range links {
+		if link.ApplyInfo(info, now) && s.StatusChanger != nil {
+			ok, err := s.completeTask(ctx, link.TaskID)
+			if err != nil {
+				return completed, err
+			}
+			if ok {
+				completed++
+			}
+		}
+		if err := s.Repository.UpdateIssueLink(ctx, link); err != nil {
+			return completed, fmt.Errorf("failed to update issue link: %w", err)
+		}
+	}
+	return completed, nil
+}
+
+// completeTask はリンク先のマージ・完了でタスクを完了にする（完了済み・削除済みのタスクは変更しない）
+func (s *IssueLinkService) completeTask(ctx context.Context, taskID string) (bool, error) {
+	task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+	if errors.Is(err, ErrTaskNotFound) || (err == nil && task == nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task.Status == domain.TaskStatusDone {
+		return false, nil
+	}
+
+	if _, err := s.StatusChanger.ChangeTaskStatus(ctx, taskID, domain.TaskStatusDone); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to complete task from github webhook",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return false, fmt.Errorf("failed to complete task: %w", err)
+	}
+	return true, nil
+}
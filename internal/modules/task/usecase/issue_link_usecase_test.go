@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=issue_link_service.go -destination=mocks/mock_issue_link.go -package=mocks
+
+type issueLinkTestMocks struct {
+	taskRepo *mocks.MockTaskRepository
+	linkRepo *mocks.MockIssueLinkRepository
+	resolver *mocks.MockGroupTaskResolver
+	github   *mocks.MockGitHubClient
+	changer  *mocks.MockTaskStatusChanger
+}
+
+func newIssueLinkTestService(t *testing.T) (*IssueLinkService, *issueLinkTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &issueLinkTestMocks{
+		taskRepo: mocks.NewMockTaskRepository(ctrl),
+		linkRepo: mocks.NewMockIssueLinkRepository(ctrl),
+		resolver: mocks.NewMockGroupTaskResolver(ctrl),
+		github:   mocks.NewMockGitHubClient(ctrl),
+		changer:  mocks.NewMockTaskStatusChanger(ctrl),
+	}
+	service := NewIssueLinkService(m.linkRepo, m.taskRepo, m.resolver, m.github, *createTestLogger())
+	service.StatusChanger = m.changer
+	return service, m
+}
+
+func newIssueLinkTestTask(id, owner string) *domain.Task {
+	task := domain.NewTask("Release v2", "", domain.PriorityMedium, domain.CategoryWork, owner)
+	task.ID = id
+	return task
+}
+
+var pullRequest42 = domain.IssueInfo{
+	Ref:   domain.IssueRef{Repository: "octo/app", Number: 42},
+	Kind:  domain.IssueKindPullRequest,
+	Title: "Add search",
+	State: domain.IssueStateOpen,
+	URL:   "https://github.com/octo/app/pull/42",
+}
+
+func TestIssueLinkService_LinkIssue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("links a pull request using the user's token", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().ListIssueLinksByTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.linkRepo.EXPECT().GetGitHubAccount(gomock.Any(), "user-1").Return(&domain.GitHubAccount{UserID: "user-1", Token: "ghp_secret"}, nil)
+		info := pullRequest42
+		m.github.EXPECT().GetIssue(gomock.Any(), "ghp_secret", info.Ref).Return(&info, nil)
+		m.linkRepo.EXPECT().CreateIssueLink(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, link *domain.IssueLink) error {
+				assert.NotEmpty(t, link.ID)
+				assert.Equal(t, "task-1", link.TaskID)
+				assert.Equal(t, domain.IssueKindPullRequest, link.Kind)
+				assert.True(t, link.CompleteOnClose)
+				return nil
+			})
+
+		link, err := service.LinkIssue(ctx, "task-1", "user-1", "https://github.com/Octo/App/pull/42", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "octo/app", link.Repository)
+		assert.Equal(t, 42, link.Number)
+	})
+
+	t.Run("public repositories can be linked without a token", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().ListIssueLinksByTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.linkRepo.EXPECT().GetGitHubAccount(gomock.Any(), "user-1").Return(nil, nil)
+		info := pullRequest42
+		m.github.EXPECT().GetIssue(gomock.Any(), "", info.Ref).Return(&info, nil)
+		m.linkRepo.EXPECT().CreateIssueLink(gomock.Any(), gomock.Any()).Return(nil)
+
+		completeOnClose := false
+		link, err := service.LinkIssue(ctx, "task-1", "user-1", "octo/app#42", &completeOnClose)
+		require.NoError(t, err)
+		assert.False(t, link.CompleteOnClose)
+	})
+
+	t.Run("rejects invalid references", func(t *testing.T) {
+		service, _ := newIssueLinkTestService(t)
+		_, err := service.LinkIssue(ctx, "task-1", "user-1", "https://example.com/octo/app/pull/42", nil)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("rejects issues already linked to the task", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		existing := domain.NewIssueLink("task-1", &pullRequest42, true, "user-1", time.Now())
+		m.linkRepo.EXPECT().ListIssueLinksByTask(gomock.Any(), "task-1").Return([]*domain.IssueLink{existing}, nil)
+
+		_, err := service.LinkIssue(ctx, "task-1", "user-1", "octo/app#42", nil)
+		assert.ErrorIs(t, err, ErrIssueLinkExists)
+	})
+
+	t.Run("other users cannot link issues", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+
+		_, err := service.LinkIssue(ctx, "task-1", "user-2", "octo/app#42", nil)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("group members who can only view the task cannot link issues", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		_, err := service.LinkIssue(ctx, "task-1", "guest", "octo/app#42", nil)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("issues GitHub does not return are not found", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().ListIssueLinksByTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.linkRepo.EXPECT().GetGitHubAccount(gomock.Any(), "user-1").Return(nil, nil)
+		m.github.EXPECT().GetIssue(gomock.Any(), "", gomock.Any()).Return(nil, ErrIssueNotFound)
+
+		_, err := service.LinkIssue(ctx, "task-1", "user-1", "octo/private#1", nil)
+		assert.ErrorIs(t, err, ErrIssueNotFound)
+	})
+}
+
+func TestIssueLinkService_UnlinkIssue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("links of other tasks are not found", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		link := domain.NewIssueLink("task-2", &pullRequest42, true, "user-1", time.Now())
+		link.ID = "link-1"
+		m.linkRepo.EXPECT().GetIssueLink(gomock.Any(), "link-1").Return(link, nil)
+
+		assert.ErrorIs(t, service.UnlinkIssue(ctx, "task-1", "link-1", "user-1"), ErrIssueLinkNotFound)
+	})
+
+	t.Run("deletes the link", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		link := domain.NewIssueLink("task-1", &pullRequest42, true, "user-1", time.Now())
+		link.ID = "link-1"
+		m.linkRepo.EXPECT().GetIssueLink(gomock.Any(), "link-1").Return(link, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().DeleteIssueLink(gomock.Any(), "link-1").Return(nil)
+
+		assert.NoError(t, service.UnlinkIssue(ctx, "task-1", "link-1", "user-1"))
+	})
+}
+
+func TestIssueLinkService_ConnectGitHub(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves a verified token", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		m.github.EXPECT().GetAuthenticatedUser(gomock.Any(), "ghp_new").Return("octocat", nil)
+		m.linkRepo.EXPECT().GetGitHubAccount(gomock.Any(), "user-1").Return(&domain.GitHubAccount{UserID: "user-1", CreatedAt: createdAt}, nil)
+		m.linkRepo.EXPECT().SaveGitHubAccount(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, account *domain.GitHubAccount) error {
+				assert.Equal(t, "ghp_new", account.Token)
+				// 置き換える場合も登録日時は残す
+				assert.Equal(t, createdAt, account.CreatedAt)
+				return nil
+			})
+
+		account, err := service.ConnectGitHub(ctx, "user-1", " ghp_new ")
+		require.NoError(t, err)
+		assert.Equal(t, "octocat", account.Login)
+	})
+
+	t.Run("invalid tokens are not saved", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.github.EXPECT().GetAuthenticatedUser(gomock.Any(), "ghp_bad").Return("", ErrInvalidGitHubToken)
+
+		_, err := service.ConnectGitHub(ctx, "user-1", "ghp_bad")
+		assert.ErrorIs(t, err, ErrInvalidGitHubToken)
+	})
+
+	t.Run("disconnecting without a token is not found", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.linkRepo.EXPECT().GetGitHubAccount(gomock.Any(), "user-1").Return(nil, nil)
+
+		assert.ErrorIs(t, service.DisconnectGitHub(ctx, "user-1"), ErrGitHubAccountNotFound)
+	})
+}
+
+func TestIssueLinkService_HandleWebhook(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{}`)
+	merged := pullRequest42
+	merged.State = domain.IssueStateMerged
+
+	newLinks := func() []*domain.IssueLink {
+		completing := domain.NewIssueLink("task-1", &pullRequest42, true, "user-1", time.Now())
+		completing.ID = "link-1"
+		syncOnly := domain.NewIssueLink("task-2", &pullRequest42, false, "user-1", time.Now())
+		syncOnly.ID = "link-2"
+		return []*domain.IssueLink{completing, syncOnly}
+	}
+
+	t.Run("merging a pull request completes linked tasks", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.github.EXPECT().ParseWebhook(payload, "pull_request", "sig").Return(&merged, nil)
+		m.linkRepo.EXPECT().ListIssueLinksByIssue(gomock.Any(), domain.IssueProviderGitHub, merged.Ref).Return(newLinks(), nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.changer.EXPECT().ChangeTaskStatus(gomock.Any(), "task-1", domain.TaskStatusDone).Return(nil, nil)
+		m.linkRepo.EXPECT().UpdateIssueLink(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, link *domain.IssueLink) error {
+				assert.Equal(t, domain.IssueStateMerged, link.State)
+				return nil
+			}).Times(2)
+
+		completed, err := service.HandleWebhook(ctx, payload, "pull_request", "sig")
+		require.NoError(t, err)
+		assert.Equal(t, 1, completed)
+	})
+
+	t.Run("completed tasks are left unchanged", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.github.EXPECT().ParseWebhook(payload, "pull_request", "sig").Return(&merged, nil)
+		m.linkRepo.EXPECT().ListIssueLinksByIssue(gomock.Any(), domain.IssueProviderGitHub, merged.Ref).Return(newLinks()[:1], nil)
+		done := newIssueLinkTestTask("task-1", "user-1")
+		done.SetStatus(domain.TaskStatusDone)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(done, nil)
+		m.linkRepo.EXPECT().UpdateIssueLink(gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := service.HandleWebhook(ctx, payload, "pull_request", "sig")
+		require.NoError(t, err)
+		assert.Zero(t, completed)
+	})
+
+	t.Run("other events are ignored", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.github.EXPECT().ParseWebhook(payload, "ping", "sig").Return(nil, nil)
+
+		completed, err := service.HandleWebhook(ctx, payload, "ping", "sig")
+		require.NoError(t, err)
+		assert.Zero(t, completed)
+	})
+
+	t.Run("invalid signatures are rejected", func(t *testing.T) {
+		service, m := newIssueLinkTestService(t)
+		m.github.EXPECT().ParseWebhook(payload, "pull_request", "bad").Return(nil, ErrInvalidGitHubSignature)
+
+		_, err := service.HandleWebhook(ctx, payload, "pull_request", "bad")
+		assert.ErrorIs(t, err, ErrInvalidGitHubSignature)
+	})
+}
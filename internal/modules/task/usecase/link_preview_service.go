@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/markdown"
+)
+
+// LinkPreviewRepository はリンクのプレビューのキャッシュのリポジトリインターフェース
+type LinkPreviewRepository interface {
+	// キャッシュにないURLは結果に含まれない
+	GetLinkPreviews(ctx context.Context, urls []string) (map[string]*domain.LinkPreview, error)
+	// 同じURLのプレビューがある場合は置き換える
+	SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error
+}
+
+// LinkPreviewFetcher はリンク先のページからプレビューを取得するインターフェース
+type LinkPreviewFetcher interface {
+	FetchLinkPreview(ctx context.Context, url string) (*domain.LinkPreview, error)
+}
+
+// LinkPreviewQueue はプレビューを非同期に取得するキューのインターフェース
+type LinkPreviewQueue interface {
+	Enqueue(urls []string)
+}
+
+// LinkPreviewProvider はタスクの説明・コメント中のリンクのプレビューのインターフェース
+type LinkPreviewProvider interface {
+	// 本文中のリンクのうち、キャッシュにないか古いものの取得を予約する
+	RequestPreviews(ctx context.Context, text string)
+	// 本文中のリンクの取得済みのプレビューを出現順に返す
+	Previews(ctx context.Context, text string) []*domain.LinkPreview
+}
+
+// LinkPreviewService はリンクのプレビュー（OpenGraphのメタデータ）を取得・キャッシュするサービス
+type LinkPreviewService struct {
+	Repository LinkPreviewRepository
+	Fetcher    LinkPreviewFetcher
+	Policy     domain.LinkPreviewPolicy
+	CacheTTL   time.Duration // 0の場合は取得できたプレビューを再取得しない
+	Logger     logger.Logger
+
+	// 非同期の取得（未設定の場合はプレビューを取得しない）
+	Queue LinkPreviewQueue
+}
+
+// NewLinkPreviewService はLinkPreviewServiceのコンストラクタ
+func NewLinkPreviewService(
+	repo LinkPreviewRepository,
+	fetcher LinkPreviewFetcher,
+	policy domain.LinkPreviewPolicy,
+	cacheTTL time.Duration,
+	logger logger.Logger,
+) *LinkPreviewService {
+	return &LinkPreviewService{
+		Repository: repo,
+		Fetcher:    fetcher,
+		Policy:     policy,
+		CacheTTL:   cacheTTL,
+		Logger:     logger,
+	}
+}
+
+// RequestPreviews は本文中のリンクのうち、キャッシュにないか古いものの取得を予約する
+// 取得は非同期に行うため、保存処理は待たせない
+func (s *LinkPreviewService) RequestPreviews(ctx context.Context, text string) {
+	urls := s.previewURLs(text)
+	if len(urls) == 0 || s.Queue == nil {
+		return
+	}
+
+	cached, err := s.Repository.GetLinkPreviews(ctx, urls)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get cached link previews", logger.Error(err))
+		return
+	}
+	s.enqueueStale(urls, cached)
+}
+
+// Previews は本文中のリンクの取得済みのプレビューを出現順に返す
+// 古くなったプレビューもそのまま返し、再取得を予約する
+func (s *LinkPreviewService) Previews(ctx context.Context, text string) []*domain.LinkPreview {
+	urls := s.previewURLs(text)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	cached, err := s.Repository.GetLinkPreviews(ctx, urls)
+	if err != nil {
+		// プレビューがなくても本文は表示できるため、エラーはログのみ
+		s.Logger.WithContext(ctx).Error("Failed to get cached link previews", logger.Error(err))
+		return nil
+	}
+	if s.Queue != nil {
+		s.enqueueStale(urls, cached)
+	}
+
+	var previews []*domain.LinkPreview
+	for _, url := range urls {
+		if preview, ok := cached[url]; ok && preview.IsReady() {
+			previews = append(previews, preview)
+		}
+	}
+	return previews
+}
+
+// Unfurl はリンク先のページからプレビューを取得してキャッシュする（キューから呼び出す）
+// 取得できなかった場合もその結果をキャッシュし、しばらく再取得しない
+func (s *LinkPreviewService) Unfurl(ctx context.Context, url string) error {
+	if !s.Policy.Permits(url) {
+		return nil
+	}
+
+	// 予約後に別の処理で取得済みの場合は取得しない
+	cached, err := s.Repository.GetLinkPreviews(ctx, []string{url})
+	if err != nil {
+		return fmt.Errorf("failed to get cached link preview: %w", err)
+	}
+	if preview, ok := cached[url]; ok && !preview.IsStale(time.Now(), s.CacheTTL) {
+		return nil
+	}
+
+	preview, err := s.Fetcher.FetchLinkPreview(ctx, url)
+	if err != nil {
+		s.Logger.WithContext(ctx).Debug("Failed to fetch link preview",
+			logger.Any("url", url), logger.Error(err))
+		preview = domain.NewFailedLinkPreview(url, time.Now())
+	} else {
+		preview.URL = url
+		preview.Status = domain.LinkPreviewReady
+		preview.FetchedAt = time.Now()
+	}
+
+	if err := s.Repository.SaveLinkPreview(ctx, preview); err != nil {
+		return fmt.Errorf("failed to save link preview: %w", err)
+	}
+	return nil
+}
+
+// previewURLs は本文中のリンクのうちプレビューを取得してよいものを最大数まで返す
+func (s *LinkPreviewService) previewURLs(text string) []string {
+	if text == "" {
+		return nil
+	}
+	var urls []string
+	for _, url := range markdown.Links(text) {
+		if !s.Policy.Permits(url) {
+			continue
+		}
+		urls = append(urls, url)
+		if len(urls) == domain.MaxLinkPreviewsPerText {
+			break
+		}
+	}
+	return urls
+}
+
+// enqueueStale はキャッシュにないか古いURLの取得を予約する
+func (s *LinkPreviewService) enqueueStale(urls []string, cached map[string]*domain.LinkPreview) {
+	now := time.Now()
+	var stale []string
+	for _, url := range urls {
+		if preview, ok := cached[url]; !ok || preview.IsStale(now, s.CacheTTL) {
+			stale = append(stale, url)
+		}
+	}
+	if len(stale) > 0 {
+		s.Queue.Enqueue(stale)
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=link_preview_service.go -destination=mocks/mock_link_preview.go -package=mocks
+
+type linkPreviewTestMocks struct {
+	repo    *mocks.MockLinkPreviewRepository
+	fetcher *mocks.MockLinkPreviewFetcher
+	queue   *mocks.MockLinkPreviewQueue
+}
+
+func newLinkPreviewTestService(t *testing.T) (*LinkPreviewService, *linkPreviewTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &linkPreviewTestMocks{
+		repo:    mocks.NewMockLinkPreviewRepository(ctrl),
+		fetcher: mocks.NewMockLinkPreviewFetcher(ctrl),
+		queue:   mocks.NewMockLinkPreviewQueue(ctrl),
+	}
+	policy := domain.NewLinkPreviewPolicy(nil, []string{"denied.example"})
+	service := NewLinkPreviewService(m.repo, m.fetcher, policy, 24*time.Hour, *createTestLogger())
+	service.Queue = m.queue
+	return service, m
+}
+
+func TestLinkPreviewService_RequestPreviews(t *testing.T) {
+	ctx := context.Background()
+	text := "[記事](https://example.com/a) https://example.com/b https://denied.example/x http://10.0.0.1/ `https://example.com/code`"
+
+	t.Run("only missing and stale permitted links are queued", func(t *testing.T) {
+		service, m := newLinkPreviewTestService(t)
+		fresh := &domain.LinkPreview{URL: "https://example.com/a", Status: domain.LinkPreviewReady, FetchedAt: time.Now()}
+
+		m.repo.EXPECT().GetLinkPreviews(gomock.Any(), []string{"https://example.com/a", "https://example.com/b"}).
+			Return(map[string]*domain.LinkPreview{fresh.URL: fresh}, nil)
+		m.queue.EXPECT().Enqueue([]string{"https://example.com/b"})
+
+		service.RequestPreviews(ctx, text)
+	})
+
+	t.Run("text without links does nothing", func(t *testing.T) {
+		service, _ := newLinkPreviewTestService(t)
+
+		service.RequestPreviews(ctx, "リンクなし")
+	})
+}
+
+func TestLinkPreviewService_Previews(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns ready previews in link order and refreshes stale ones", func(t *testing.T) {
+		service, m := newLinkPreviewTestService(t)
+		old := &domain.LinkPreview{URL: "https://example.com/b", Title: "B", Status: domain.LinkPreviewReady, FetchedAt: time.Now().Add(-48 * time.Hour)}
+		failed := domain.NewFailedLinkPreview("https://example.com/c", time.Now())
+		fresh := &domain.LinkPreview{URL: "https://example.com/a", Title: "A", Status: domain.LinkPreviewReady, FetchedAt: time.Now()}
+
+		m.repo.EXPECT().GetLinkPreviews(gomock.Any(), gomock.Any()).
+			Return(map[string]*domain.LinkPreview{old.URL: old, failed.URL: failed, fresh.URL: fresh}, nil)
+		m.queue.EXPECT().Enqueue([]string{"https://example.com/b"})
+
+		previews := service.Previews(ctx, "https://example.com/a https://example.com/b https://example.com/c")
+
+		require.Len(t, previews, 2)
+		assert.Equal(t, "A", previews[0].Title)
+		assert.Equal(t, "B", previews[1].Title)
+	})
+
+	t.Run("repository errors are not returned", func(t *testing.T) {
+		service, m := newLinkPreviewTestService(t)
+		m.repo.EXPECT().GetLinkPreviews(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		assert.Empty(t, service.Previews(ctx, "https://example.com/a"))
+	})
+}
+
+func TestLinkPreviewService_Unfurl(t *testing.T) {
+	ctx := context.Background()
+	url := "https://example.com/a"
+
+	t.Run("fetched previews are cached as ready", func(t *testing.T) {
+		service, m := newLinkPreviewTestService(t)
+		m.repo.EXPECT().GetLinkPreviews(gomock.Any(), []string{url}).Return(map[string]*domain.LinkPreview{}, nil)
+		m.fetcher.EXPECT().FetchLinkPreview(gomock.Any(), url).Return(&domain.LinkPreview{Title: "記事"}, nil)
+		m.repo.EXPECT().SaveLinkPreview(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, preview *domain.LinkPreview) error {
+				assert.Equal(t, url, preview.URL)
+				assert.Equal(t, "記事", preview.Title)
+				assert.True(t, preview.IsReady())
+				assert.False(t, preview.FetchedAt.IsZero())
+				return nil
+			})
+
+		require.NoError(t, service.Unfurl(ctx, url))
+	})
+
+	t.Run("fetch failures are cached as failed", func(t *testing.T) {
+		service, m := newLinkPreviewTestService(t)
+		m.repo.EXPECT().GetLinkPreviews(gomock.Any(), []string{url}).Return(map[string]*domain.LinkPreview{}, nil)
+		m.fetcher.EXPECT().FetchLinkPreview(gomock.Any(), url).Return(nil, errors.New("timeout"))
+		m.repo.EXPECT().SaveLinkPreview(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, preview *domain.LinkPreview) error {
+				assert.Equal(t, domain.LinkPreviewFailed, preview.Status)
+				return nil
+			})
+
+		require.NoError(t, service.Unfurl(ctx, url))
+	})
+
+	t.Run("fresh previews are not fetched again", func(t *testing.T) {
+		service, m := newLinkPreviewTestService(t)
+		fresh := &domain.LinkPreview{URL: url, Status: domain.LinkPreviewReady, FetchedAt: time.Now()}
+		m.repo.EXPECT().GetLinkPreviews(gomock.Any(), []string{url}).Return(map[string]*domain.LinkPreview{url: fresh}, nil)
+
+		require.NoError(t, service.Unfurl(ctx, url))
+	})
+
+	t.Run("denied domains are never fetched", func(t *testing.T) {
+		service, _ := newLinkPreviewTestService(t)
+
+		require.NoError(t, service.Unfurl(ctx, "https://denied.example/x"))
+	})
+}
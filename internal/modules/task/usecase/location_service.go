@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// maxNearbyTasks は近くのタスクとして返す件数の上限
+const maxNearbyTasks = 50
+
+// LocationRepository はタスクの場所とジオフェンスのリポジトリインターフェース
+type LocationRepository interface {
+	// SetTaskLocation はタスクの場所を設定する（設定済みの場合は置き換える）
+	SetTaskLocation(ctx context.Context, location *domain.TaskLocation) error
+	// GetTaskLocation はタスクの場所を取得する（未設定の場合は nil）
+	GetTaskLocation(ctx context.Context, taskID string) (*domain.TaskLocation, error)
+	// GetTaskLocations は複数のタスクの場所をタスクIDごとに取得する（未設定のタスクは含めない）
+	GetTaskLocations(ctx context.Context, taskIDs []string) (map[string]*domain.TaskLocation, error)
+	DeleteTaskLocation(ctx context.Context, taskID string) error
+	// ListTaskLocationsInBounds は緯度・経度の範囲内にあるタスクの場所を取得する
+	ListTaskLocationsInBounds(ctx context.Context, bounds domain.GeoBounds) ([]*domain.TaskLocation, error)
+
+	// ジオフェンス（ユーザーとタスクごとに1つ）
+	CreateGeofence(ctx context.Context, geofence *domain.Geofence) error
+	// GetGeofence はIDでジオフェンスを取得する（存在しない場合は nil、場所は設定しない）
+	GetGeofence(ctx context.Context, id string) (*domain.Geofence, error)
+	// ListGeofencesByUser はユーザーのジオフェンスを登録した順に取得する（場所は設定しない）
+	ListGeofencesByUser(ctx context.Context, userID string) ([]*domain.Geofence, error)
+	DeleteGeofence(ctx context.Context, id string) error
+	// DeleteGeofencesByTask はタスクのジオフェンスをすべて削除する（タスクの場所の削除時）
+	DeleteGeofencesByTask(ctx context.Context, taskID string) error
+	UpdateGeofenceTriggeredAt(ctx context.Context, id string, triggeredAt time.Time) error
+}
+
+// GeofenceNotifier はジオフェンスに入ったユーザーにタスクのリマインダーを送るインターフェース
+type GeofenceNotifier interface {
+	NotifyGeofenceEnter(ctx context.Context, userID string, task *domain.Task, location domain.Location) error
+}
+
+// TaskLocationProvider はタスクの場所を取得するインターフェース（タスクの詳細の表示用）
+type TaskLocationProvider interface {
+	// GetTaskLocation はタスクの場所を取得する（未設定の場合は nil）
+	GetTaskLocation(ctx context.Context, taskID string) (*domain.TaskLocation, error)
+}
+
+var (
+	// ErrLocationNotFound はタスクに場所が設定されていないことを表すエラー
+	ErrLocationNotFound = errors.New("task location not found")
+	// ErrGeofenceNotFound はジオフェンスが見つからないことを表すエラー
+	ErrGeofenceNotFound = errors.New("geofence not found")
+	// ErrGeofenceExists はタスクにジオフェンスを登録済みであることを表すエラー
+	ErrGeofenceExists = errors.New("geofence already exists")
+)
+
+// NearbyQuery は近くのタスクの検索条件
+type NearbyQuery struct {
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters int // 0の場合は DefaultNearbyRadius
+}
+
+// GeofenceEnterInput は端末がジオフェンスに入ったことの報告
+// 緯度・経度を指定した場合はジオフェンスの内側かを確認し、外側の場合はリマインダーを送らない
+type GeofenceEnterInput struct {
+	Latitude  *float64
+	Longitude *float64
+}
+
+// LocationService はタスクの場所・近くのタスクの検索・ジオフェンスのリマインダーを扱うサービス
+// 場所を参照・変更できるのはタスクを参照できるユーザー（作成者・担当者・グループのメンバー）
+type LocationService struct {
+	LocationRepository LocationRepository
+	TaskRepository     TaskRepository
+	GroupResolver      GroupTaskResolver
+	// ジオフェンスのリマインダーの送信先（未設定の場合は送らない）
+	Notifier GeofenceNotifier
+	Logger   logger.Logger
+}
+
+// NewLocationService はLocationServiceのコンストラクタ
+func NewLocationService(
+	locationRepo LocationRepository,
+	taskRepo TaskRepository,
+	groupResolver GroupTaskResolver,
+	logger logger.Logger,
+) *LocationService {
+	return &LocationService{
+		LocationRepository: locationRepo,
+		TaskRepository:     taskRepo,
+		GroupResolver:      groupResolver,
+		Logger:             logger,
+	}
+}
+
+// === タスクの場所 ===
+
+// GetTaskLocation はタスクの場所を取得する
+func (s *LocationService) GetTaskLocation(ctx context.Context, taskID, userID string) (*domain.TaskLocation, error) {
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	location, err := s.LocationRepository.GetTaskLocation(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task location: %w", err)
+	}
+	if location == nil {
+		return nil, ErrLocationNotFound
+	}
+	return location, nil
+}
+
+// SetTaskLocation はタスクの場所を設定する（設定済みの場合は置き換え、登録済みのジオフェンスの中心も移る）
+func (s *LocationService) SetTaskLocation(ctx context.Context, taskID, userID string, location domain.Location) (*domain.TaskLocation, error) {
+	if err := location.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	taskLocation := &domain.TaskLocation{
+		TaskID:    taskID,
+		Location:  location,
+		UpdatedBy: userID,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.LocationRepository.SetTaskLocation(ctx, taskLocation); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to set task location",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return nil, fmt.Errorf("failed to set task location: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Task location set", logger.Any("taskID", taskID))
+	return taskLocation, nil
+}
+
+// DeleteTaskLocation はタスクの場所を削除する（タスクのジオフェンスもすべて削除する）
+func (s *LocationService) DeleteTaskLocation(ctx context.Context, taskID, userID string) error {
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return err
+	}
+
+	existing, err := s.LocationRepository.GetTaskLocation(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task location: %w", err)
+	}
+	if existing == nil {
+		return ErrLocationNotFound
+	}
+
+	if err := s.LocationRepository.DeleteGeofencesByTask(ctx, taskID); err != nil {
+		return fmt.Errorf("failed to delete geofences: %w", err)
+	}
+	if err := s.LocationRepository.DeleteTaskLocation(ctx, taskID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to delete task location",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return fmt.Errorf("failed to delete task location: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Task location deleted", logger.Any("taskID", taskID))
+	return nil
+}
+
+// ListNearbyTasks は地点から半径内に場所があるユーザーの未完了のタスクを近い順に取得する（最大 maxNearbyTasks 件）
+func (s *LocationService) ListNearbyTasks(ctx context.Context, userID string, query NearbyQuery) ([]*domain.NearbyTask, error) {
+	if err := domain.ValidateCoordinates(query.Latitude, query.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	radius := query.RadiusMeters
+	if radius == 0 {
+		radius = domain.DefaultNearbyRadius
+	}
+	if radius < 0 || radius > domain.MaxNearbyRadius {
+		return nil, fmt.Errorf("%w: radius must be between 1 and %d meters", ErrInvalidParameter, domain.MaxNearbyRadius)
+	}
+
+	// 緯度・経度の範囲で索引を使って絞り込み、距離は取得後に計算する
+	candidates, err := s.LocationRepository.ListTaskLocationsInBounds(ctx,
+		domain.BoundsAround(query.Latitude, query.Longitude, float64(radius)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task locations: %w", err)
+	}
+
+	var nearby []*domain.NearbyTask
+	for _, candidate := range candidates {
+		distance := candidate.Location.DistanceMeters(query.Latitude, query.Longitude)
+		if distance > float64(radius) {
+			continue
+		}
+
+		task, err := s.TaskRepository.GetTaskByID(ctx, candidate.TaskID)
+		if errors.Is(err, ErrTaskNotFound) || (err == nil && task == nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+		if task.Status == domain.TaskStatusDone {
+			continue
+		}
+		if err := checkTaskAccess(ctx, s.GroupResolver, task, userID, domain.GroupActionViewTasks); errors.Is(err, ErrPermissionDenied) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		nearby = append(nearby, &domain.NearbyTask{
+			Task:           task,
+			Location:       candidate.Location,
+			DistanceMeters: distance,
+		})
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].DistanceMeters < nearby[j].DistanceMeters
+	})
+	if len(nearby) > maxNearbyTasks {
+		nearby = nearby[:maxNearbyTasks]
+	}
+	return nearby, nil
+}
+
+// === ジオフェンス ===
+
+// RegisterGeofence はタスクの場所の周囲にジオフェンスを登録する（radiusMetersが0の場合は DefaultGeofenceRadius）
+// タスクに場所が設定されている必要があり、同じタスクには1つだけ登録できる
+func (s *LocationService) RegisterGeofence(ctx context.Context, taskID, userID string, radiusMeters int) (*domain.Geofence, error) {
+	if radiusMeters == 0 {
+		radiusMeters = domain.DefaultGeofenceRadius
+	}
+	if radiusMeters < domain.MinGeofenceRadius || radiusMeters > domain.MaxGeofenceRadius {
+		return nil, fmt.Errorf("%w: radius must be between %d and %d meters",
+			ErrInvalidParameter, domain.MinGeofenceRadius, domain.MaxGeofenceRadius)
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	location, err := s.LocationRepository.GetTaskLocation(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task location: %w", err)
+	}
+	if location == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	existing, err := s.LocationRepository.ListGeofencesByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list geofences: %w", err)
+	}
+	for _, geofence := range existing {
+		if geofence.TaskID == taskID {
+			return nil, ErrGeofenceExists
+		}
+	}
+	if len(existing) >= domain.MaxGeofencesPerUser {
+		return nil, fmt.Errorf("%w: too many geofences (max %d)", ErrInvalidParameter, domain.MaxGeofencesPerUser)
+	}
+
+	geofence := &domain.Geofence{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		TaskID:       taskID,
+		RadiusMeters: radiusMeters,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.LocationRepository.CreateGeofence(ctx, geofence); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create geofence",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return nil, fmt.Errorf("failed to create geofence: %w", err)
+	}
+	geofence.Location = &location.Location
+
+	s.Logger.WithContext(ctx).Info("Geofence registered",
+		logger.Any("geofenceID", geofence.ID), logger.Any("taskID", taskID))
+	return geofence, nil
+}
+
+// ListGeofences はユーザーのジオフェンスをタスクの場所とともに取得する（端末で監視する領域の同期用）
+// 場所が削除されたタスクのジオフェンスは含めない
+func (s *LocationService) ListGeofences(ctx context.Context, userID string) ([]*domain.Geofence, error) {
+	geofences, err := s.LocationRepository.ListGeofencesByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list geofences: %w", err)
+	}
+
+	taskIDs := make([]string, 0, len(geofences))
+	for _, geofence := range geofences {
+		taskIDs = append(taskIDs, geofence.TaskID)
+	}
+	locations, err := s.LocationRepository.GetTaskLocations(ctx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task locations: %w", err)
+	}
+
+	result := make([]*domain.Geofence, 0, len(geofences))
+	for _, geofence := range geofences {
+		location, ok := locations[geofence.TaskID]
+		if !ok {
+			continue
+		}
+		geofence.Location = &location.Location
+		result = append(result, geofence)
+	}
+	return result, nil
+}
+
+// DeleteGeofence はジオフェンスを削除する（登録したユーザーのみ）
+func (s *LocationService) DeleteGeofence(ctx context.Context, geofenceID, userID string) error {
+	if _, err := s.getOwnGeofence(ctx, geofenceID, userID); err != nil {
+		return err
+	}
+
+	if err := s.LocationRepository.DeleteGeofence(ctx, geofenceID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to delete geofence",
+			logger.Any("geofenceID", geofenceID), logger.Error(err))
+		return fmt.Errorf("failed to delete geofence: %w", err)
+	}
+	return nil
+}
+
+// ReportGeofenceEnter は端末がジオフェンスに入ったことを受け取り、タスクのリマインダーを送る
+// 完了済みのタスク・前回のリマインダーから GeofenceCooldown が経過していない場合・報告した位置が外側の場合は送らず false を返す
+func (s *LocationService) ReportGeofenceEnter(ctx context.Context, geofenceID, userID string, input GeofenceEnterInput, now time.Time) (bool, error) {
+	if (input.Latitude == nil) != (input.Longitude == nil) {
+		return false, fmt.Errorf("%w: latitude and longitude must be specified together", ErrInvalidParameter)
+	}
+	if input.Latitude != nil {
+		if err := domain.ValidateCoordinates(*input.Latitude, *input.Longitude); err != nil {
+			return false, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+		}
+	}
+
+	geofence, err := s.getOwnGeofence(ctx, geofenceID, userID)
+	if err != nil {
+		return false, err
+	}
+	task, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, geofence.TaskID, userID)
+	if err != nil {
+		return false, err
+	}
+	location, err := s.LocationRepository.GetTaskLocation(ctx, geofence.TaskID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get task location: %w", err)
+	}
+	if location == nil {
+		return false, ErrLocationNotFound
+	}
+	geofence.Location = &location.Location
+
+	if task.Status == domain.TaskStatusDone || !geofence.CanTrigger(now) {
+		return false, nil
+	}
+	if input.Latitude != nil && !geofence.Contains(*input.Latitude, *input.Longitude) {
+		return false, nil
+	}
+
+	if err := s.LocationRepository.UpdateGeofenceTriggeredAt(ctx, geofence.ID, now); err != nil {
+		return false, fmt.Errorf("failed to update geofence: %w", err)
+	}
+	if s.Notifier != nil {
+		if err := s.Notifier.NotifyGeofenceEnter(ctx, userID, task, location.Location); err != nil {
+			s.Logger.WithContext(ctx).Error("Failed to send geofence reminder",
+				logger.Any("geofenceID", geofence.ID), logger.Error(err))
+			return false, fmt.Errorf("failed to send geofence reminder: %w", err)
+		}
+	}
+
+	s.Logger.WithContext(ctx).Info("Geofence reminder sent",
+		logger.Any("geofenceID", geofence.ID), logger.Any("taskID", task.ID))
+	return true, nil
+}
+
+// getOwnGeofence はユーザーが登録したジオフェンスを取得する（他のユーザーのジオフェンスは見つからないものとする）
+func (s *LocationService) getOwnGeofence(ctx context.Context, geofenceID, userID string) (*domain.Geofence, error) {
+	geofence, err := s.LocationRepository.GetGeofence(ctx, geofenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geofence: %w", err)
+	}
+	if geofence == nil || geofence.UserID != userID {
+		return nil, ErrGeofenceNotFound
+	}
+	return geofence, nil
+}
+
+// GetLocation はタスクの場所を返す（未設定・取得できない場合は表示しない）
+func (s *TaskService) GetLocation(ctx context.Context, task *domain.Task) *domain.Location {
+	if s.Locations == nil {
+		return nil
+	}
+	location, err := s.Locations.GetTaskLocation(ctx, task.ID)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to get task location",
+			logger.Any("taskID", task.ID),
+			logger.Error(err))
+		return nil
+	}
+	if location == nil {
+		return nil
+	}
+	return &location.Location
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=location_service.go -destination=mocks/mock_location.go -package=mocks
+
+type locationTestMocks struct {
+	taskRepo     *mocks.MockTaskRepository
+	locationRepo *mocks.MockLocationRepository
+	resolver     *mocks.MockGroupTaskResolver
+	notifier     *mocks.MockGeofenceNotifier
+}
+
+func newLocationTestService(t *testing.T) (*LocationService, *locationTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &locationTestMocks{
+		taskRepo:     mocks.NewMockTaskRepository(ctrl),
+		locationRepo: mocks.NewMockLocationRepository(ctrl),
+		resolver:     mocks.NewMockGroupTaskResolver(ctrl),
+		notifier:     mocks.NewMockGeofenceNotifier(ctrl),
+	}
+	service := NewLocationService(m.locationRepo, m.taskRepo, m.resolver, *createTestLogger())
+	service.Notifier = m.notifier
+	return service, m
+}
+
+func newLocationTestTask(id, owner string) *domain.Task {
+	task := domain.NewTask("Buy milk", "", domain.PriorityMedium, domain.CategoryPersonal, owner)
+	task.ID = id
+	return task
+}
+
+var tokyoStation = domain.Location{Name: "東京駅", Latitude: 35.681236, Longitude: 139.767125}
+
+func TestLocationService_SetTaskLocation(t *testing.T) {
+	t.Run("sets the location of an accessible task", func(t *testing.T) {
+		service, m := newLocationTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+		m.locationRepo.EXPECT().SetTaskLocation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, location *domain.TaskLocation) error {
+				assert.Equal(t, "東京駅", location.Location.Name)
+				assert.Equal(t, "user-1", location.UpdatedBy)
+				return nil
+			})
+
+		location := tokyoStation
+		location.Name = "  東京駅  "
+		got, err := service.SetTaskLocation(context.Background(), "task-1", "user-1", location)
+		require.NoError(t, err)
+		assert.Equal(t, "task-1", got.TaskID)
+	})
+
+	t.Run("rejects invalid coordinates", func(t *testing.T) {
+		service, _ := newLocationTestService(t)
+		_, err := service.SetTaskLocation(context.Background(), "task-1", "user-1", domain.Location{Name: "x", Latitude: 91})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("other users cannot set the location", func(t *testing.T) {
+		service, m := newLocationTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+
+		_, err := service.SetTaskLocation(context.Background(), "task-1", "user-2", tokyoStation)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("group members who can only view the task cannot set the location", func(t *testing.T) {
+		service, m := newLocationTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		_, err := service.SetTaskLocation(context.Background(), "task-1", "guest", tokyoStation)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+}
+
+func TestLocationService_DeleteTaskLocation_ViewOnlyMember(t *testing.T) {
+	service, m := newLocationTestService(t)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+	m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+	m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+	err := service.DeleteTaskLocation(context.Background(), "task-1", "guest")
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
+
+func TestLocationService_ListNearbyTasks(t *testing.T) {
+	service, m := newLocationTestService(t)
+
+	near := newLocationTestTask("near", "user-1")
+	nearer := newLocationTestTask("nearer", "user-1")
+	done := newLocationTestTask("done", "user-1")
+	done.Status = domain.TaskStatusDone
+	others := newLocationTestTask("others", "user-2")
+
+	at := func(taskID string, lng float64) *domain.TaskLocation {
+		return &domain.TaskLocation{TaskID: taskID, Location: domain.Location{Name: taskID, Latitude: 0, Longitude: lng}}
+	}
+	m.locationRepo.EXPECT().ListTaskLocationsInBounds(gomock.Any(), gomock.Any()).Return([]*domain.TaskLocation{
+		at("near", 0.005),   // about 550m
+		at("nearer", 0.001), // about 110m
+		at("far", 0.02),     // about 2.2km, outside the radius
+		at("done", 0.001),
+		at("others", 0.001),
+		at("deleted", 0.001),
+	}, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "near").Return(near, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "nearer").Return(nearer, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "done").Return(done, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "others").Return(others, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "deleted").Return(nil, ErrTaskNotFound)
+	m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "others").Return(nil, nil)
+
+	nearby, err := service.ListNearbyTasks(context.Background(), "user-1", NearbyQuery{Latitude: 0, Longitude: 0})
+	require.NoError(t, err)
+	require.Len(t, nearby, 2)
+	assert.Equal(t, "nearer", nearby[0].Task.ID)
+	assert.Equal(t, "near", nearby[1].Task.ID)
+	assert.InDelta(t, 556, nearby[1].DistanceMeters, 5)
+
+	_, err = service.ListNearbyTasks(context.Background(), "user-1", NearbyQuery{Latitude: 0, Longitude: 0, RadiusMeters: domain.MaxNearbyRadius + 1})
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+func TestLocationService_RegisterGeofence(t *testing.T) {
+	tests := []struct {
+		name          string
+		radius        int
+		setupMocks    func(m *locationTestMocks)
+		expectedError error
+	}{
+		{
+			name: "uses the default radius",
+			setupMocks: func(m *locationTestMocks) {
+				m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+				m.locationRepo.EXPECT().GetTaskLocation(gomock.Any(), "task-1").Return(&domain.TaskLocation{TaskID: "task-1", Location: tokyoStation}, nil)
+				m.locationRepo.EXPECT().ListGeofencesByUser(gomock.Any(), "user-1").Return(nil, nil)
+				m.locationRepo.EXPECT().CreateGeofence(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, geofence *domain.Geofence) error {
+						assert.Equal(t, domain.DefaultGeofenceRadius, geofence.RadiusMeters)
+						return nil
+					})
+			},
+		},
+		{
+			name:          "radius below the minimum",
+			radius:        domain.MinGeofenceRadius - 1,
+			setupMocks:    func(m *locationTestMocks) {},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name: "task has no location",
+			setupMocks: func(m *locationTestMocks) {
+				m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+				m.locationRepo.EXPECT().GetTaskLocation(gomock.Any(), "task-1").Return(nil, nil)
+			},
+			expectedError: ErrLocationNotFound,
+		},
+		{
+			name: "already registered",
+			setupMocks: func(m *locationTestMocks) {
+				m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+				m.locationRepo.EXPECT().GetTaskLocation(gomock.Any(), "task-1").Return(&domain.TaskLocation{TaskID: "task-1", Location: tokyoStation}, nil)
+				m.locationRepo.EXPECT().ListGeofencesByUser(gomock.Any(), "user-1").Return([]*domain.Geofence{{ID: "g1", TaskID: "task-1"}}, nil)
+			},
+			expectedError: ErrGeofenceExists,
+		},
+		{
+			name: "too many geofences",
+			setupMocks: func(m *locationTestMocks) {
+				m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newLocationTestTask("task-1", "user-1"), nil)
+				m.locationRepo.EXPECT().GetTaskLocation(gomock.Any(), "task-1").Return(&domain.TaskLocation{TaskID: "task-1", Location: tokyoStation}, nil)
+				existing := make([]*domain.Geofence, domain.MaxGeofencesPerUser)
+				for i := range existing {
+					existing[i] = &domain.Geofence{TaskID: "other"}
+				}
+				m.locationRepo.EXPECT().ListGeofencesByUser(gomock.Any(), "user-1").Return(existing, nil)
+			},
+			expectedError: ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newLocationTestService(t)
+			tt.setupMocks(m)
+
+			geofence, err := service.RegisterGeofence(context.Background(), "task-1", "user-1", tt.radius)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, geofence.Location)
+			assert.Equal(t, tokyoStation, *geofence.Location)
+		})
+	}
+}
+
+func TestLocationService_ReportGeofenceEnter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	recently := now.Add(-10 * time.Minute)
+	inside, outside := tokyoStation.Latitude, tokyoStation.Latitude+0.01
+	lng := tokyoStation.Longitude
+
+	tests := []struct {
+		name          string
+		geofence      *domain.Geofence
+		input         GeofenceEnterInput
+		taskDone      bool
+		expectNotify  bool
+		expectedError error
+	}{
+		{
+			name:         "sends a reminder",
+			geofence:     &domain.Geofence{ID: "g1", UserID: "user-1", TaskID: "task-1", RadiusMeters: 200},
+			input:        GeofenceEnterInput{Latitude: &inside, Longitude: &lng},
+			expectNotify: true,
+		},
+		{
+			name:         "trusts the device when no position is reported",
+			geofence:     &domain.Geofence{ID: "g1", UserID: "user-1", TaskID: "task-1", RadiusMeters: 200},
+			expectNotify: true,
+		},
+		{
+			name:     "reported position is outside",
+			geofence: &domain.Geofence{ID: "g1", UserID: "user-1", TaskID: "task-1", RadiusMeters: 200},
+			input:    GeofenceEnterInput{Latitude: &outside, Longitude: &lng},
+		},
+		{
+			name:     "within the cooldown",
+			geofence: &domain.Geofence{ID: "g1", UserID: "user-1", TaskID: "task-1", RadiusMeters: 200, LastTriggeredAt: &recently},
+		},
+		{
+			name:     "task is done",
+			geofence: &domain.Geofence{ID: "g1", UserID: "user-1", TaskID: "task-1", RadiusMeters: 200},
+			taskDone: true,
+		},
+		{
+			name:          "other user's geofence",
+			geofence:      &domain.Geofence{ID: "g1", UserID: "user-2", TaskID: "task-1", RadiusMeters: 200},
+			expectedError: ErrGeofenceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newLocationTestService(t)
+			m.locationRepo.EXPECT().GetGeofence(gomock.Any(), "g1").Return(tt.geofence, nil)
+			if tt.expectedError == nil {
+				task := newLocationTestTask("task-1", "user-1")
+				if tt.taskDone {
+					task.Status = domain.TaskStatusDone
+				}
+				m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+				m.locationRepo.EXPECT().GetTaskLocation(gomock.Any(), "task-1").Return(&domain.TaskLocation{TaskID: "task-1", Location: tokyoStation}, nil)
+			}
+			if tt.expectNotify {
+				m.locationRepo.EXPECT().UpdateGeofenceTriggeredAt(gomock.Any(), "g1", now).Return(nil)
+				m.notifier.EXPECT().NotifyGeofenceEnter(gomock.Any(), "user-1", gomock.Any(), tokyoStation).Return(nil)
+			}
+
+			triggered, err := service.ReportGeofenceEnter(context.Background(), "g1", "user-1", tt.input, now)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectNotify, triggered)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/markdown"
+)
+
+// 通知に含める本文抜粋の最大文字数
+const mentionExcerptLength = 100
+
+// CommentRepository はタスクコメントのリポジトリインターフェース
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *domain.TaskComment) error
+	ListComments(ctx context.Context, taskID string, pagination domain.Pagination) ([]*domain.TaskComment, int, error)
+	// ListCommentsByUser はユーザーが書いたコメントを古い順に全件取得する（データのエクスポート用）
+	ListCommentsByUser(ctx context.Context, userID string) ([]*domain.TaskComment, error)
+}
+
+// MentionRepository はメンション記録のリポジトリインターフェース
+type MentionRepository interface {
+	// 同じメンション元・ユーザーの記録が既にある場合は無視する
+	SaveMentions(ctx context.Context, mentions []*domain.Mention) error
+	ListMentionsForUser(ctx context.Context, userID string, pagination domain.Pagination) ([]*domain.Mention, int, error)
+}
+
+// MentionDirectory はメンション先ユーザーの解決インターフェース
+type MentionDirectory interface {
+	// ユーザー名（小文字）からユーザーIDへの対応を返す。存在しないユーザー名は含まれない
+	// 現在のユーザーに一致しない場合は、変更前にそのユーザー名を使っていたユーザーに解決する
+	ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error)
+	// 作成者から見えるユーザー（承認済みの友達または同じグループのメンバー、かつブロック関係にない）のみを返す
+	FilterVisibleUsers(ctx context.Context, authorID string, userIDs []string) ([]string, error)
+}
+
+// MentionNotifier はメンション通知のインターフェース
+type MentionNotifier interface {
+	NotifyMentioned(ctx context.Context, task *domain.Task, mention *domain.Mention, excerpt string) error
+}
+
+// MentionProcessor は本文中のメンションを処理するインターフェース
+type MentionProcessor interface {
+	ProcessMentions(ctx context.Context, task *domain.Task, source domain.MentionSource, authorID, text, previousText string) ([]*domain.Mention, error)
+}
+
+// MentionService はコメントとメンションを扱うサービス
+type MentionService struct {
+	TaskRepository    TaskRepository
+	CommentRepository CommentRepository
+	MentionRepository MentionRepository
+	Directory         MentionDirectory
+	Notifier          MentionNotifier
+	Logger            logger.Logger
+
+	// グループタスクのコメントの閲覧・追加の権限の確認（未設定の場合は作成者・担当者のみ）
+	GroupResolver GroupTaskResolver
+
+	// コメント中のリンクのプレビュー（未設定の場合は取得しない）
+	LinkPreviews LinkPreviewProvider
+}
+
+// NewMentionService はMentionServiceのコンストラクタ
+func NewMentionService(
+	taskRepo TaskRepository,
+	commentRepo CommentRepository,
+	mentionRepo MentionRepository,
+	directory MentionDirectory,
+	notifier MentionNotifier,
+	logger logger.Logger,
+) *MentionService {
+	return &MentionService{
+		TaskRepository:    taskRepo,
+		CommentRepository: commentRepo,
+		MentionRepository: mentionRepo,
+		Directory:         directory,
+		Notifier:          notifier,
+		Logger:            logger,
+	}
+}
+
+// ProcessMentions は本文中の新しいメンションを解決・保存し、メンションされたユーザーに通知する
+// 存在しないユーザー、作成者から見えないユーザー、作成者自身へのメンションは無視する
+func (s *MentionService) ProcessMentions(
+	ctx context.Context,
+	task *domain.Task,
+	source domain.MentionSource,
+	authorID, text, previousText string,
+) ([]*domain.Mention, error) {
+	if task == nil || authorID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	// コードやURL中の@usernameはメンションとして扱わない
+	plainText := markdown.PlainText(text)
+	usernames := domain.NewMentionUsernames(plainText, markdown.PlainText(previousText))
+	if len(usernames) == 0 {
+		return []*domain.Mention{}, nil
+	}
+
+	resolved, err := s.Directory.ResolveUsernames(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mentioned users: %w", err)
+	}
+
+	var candidates []string
+	for _, username := range usernames {
+		userID, ok := resolved[strings.ToLower(username)]
+		if !ok || userID == authorID {
+			continue
+		}
+		candidates = append(candidates, userID)
+	}
+	candidates = uniqueStrings(candidates)
+	if len(candidates) == 0 {
+		return []*domain.Mention{}, nil
+	}
+
+	visible, err := s.Directory.FilterVisibleUsers(ctx, authorID, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check mention visibility: %w", err)
+	}
+	if len(visible) == 0 {
+		return []*domain.Mention{}, nil
+	}
+
+	mentions := make([]*domain.Mention, 0, len(visible))
+	for _, userID := range visible {
+		mention := domain.NewMention(task.ID, source, userID, authorID)
+		mention.ID = uuid.New().String()
+		mentions = append(mentions, mention)
+	}
+
+	if err := s.MentionRepository.SaveMentions(ctx, mentions); err != nil {
+		return nil, fmt.Errorf("failed to save mentions: %w", err)
+	}
+
+	excerpt := mentionExcerpt(plainText)
+	for _, mention := range mentions {
+		if err := s.Notifier.NotifyMentioned(ctx, task, mention, excerpt); err != nil {
+			// 通知の失敗でメンション自体は失敗させない
+			s.Logger.WithContext(ctx).Error("Failed to send mention notification",
+				logger.Any("taskID", task.ID),
+				logger.Any("mentionedUserID", mention.MentionedUserID),
+				logger.Error(err))
+		}
+	}
+
+	s.Logger.WithContext(ctx).Info("Mentions processed",
+		logger.Any("taskID", task.ID),
+		logger.Any("sourceType", source.Type),
+		logger.Any("count", len(mentions)))
+
+	return mentions, nil
+}
+
+// AddComment はタスクにコメントを追加し、コメント中のメンションを処理する
+// タスクを編集できるユーザーのみ追加できる（ゲストなど閲覧のみのメンバーは追加できない）
+func (s *MentionService) AddComment(ctx context.Context, taskID, userID, text string) (*domain.TaskComment, []*domain.Mention, error) {
+	if taskID == "" || userID == "" {
+		return nil, nil, ErrInvalidParameter
+	}
+
+	comment, err := domain.NewTaskComment(taskID, userID, text)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment.ID = uuid.New().String()
+	if err := s.CommentRepository.CreateComment(ctx, comment); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create comment",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return nil, nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	source := domain.MentionSource{Type: domain.MentionSourceComment, ID: comment.ID}
+	mentions, err := s.ProcessMentions(ctx, task, source, userID, comment.Comment, "")
+	if err != nil {
+		// コメントは保存済みのため、メンション処理の失敗はログのみ
+		s.Logger.WithContext(ctx).Error("Failed to process comment mentions",
+			logger.Any("commentID", comment.ID), logger.Error(err))
+		mentions = []*domain.Mention{}
+	}
+	if s.LinkPreviews != nil {
+		s.LinkPreviews.RequestPreviews(ctx, comment.Comment)
+	}
+
+	return comment, mentions, nil
+}
+
+// ListComments はタスクのコメント一覧を取得する（タスクを閲覧できるユーザーのみ）
+func (s *MentionService) ListComments(ctx context.Context, taskID, userID string, pagination domain.Pagination) ([]*domain.TaskComment, int, error) {
+	if taskID == "" {
+		return nil, 0, ErrInvalidParameter
+	}
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, 0, err
+	}
+	normalizePagination(&pagination)
+	return s.CommentRepository.ListComments(ctx, taskID, pagination)
+}
+
+// GetCommentLinkPreviews はコメント中のリンクの取得済みのプレビューを返す
+func (s *MentionService) GetCommentLinkPreviews(ctx context.Context, comment *domain.TaskComment) []*domain.LinkPreview {
+	if s.LinkPreviews == nil {
+		return nil
+	}
+	return s.LinkPreviews.Previews(ctx, comment.Comment)
+}
+
+// ListMentions はユーザーがメンションされた記録を新しい順に取得する
+func (s *MentionService) ListMentions(ctx context.Context, userID string, pagination domain.Pagination) ([]*domain.Mention, int, error) {
+	if userID == "" {
+		return nil, 0, ErrInvalidParameter
+	}
+	normalizePagination(&pagination)
+	return s.MentionRepository.ListMentionsForUser(ctx, userID, pagination)
+}
+
+// normalizePagination はページング情報にデフォルト値と上限を適用する
+func normalizePagination(pagination *domain.Pagination) {
+	if pagination.Page <= 0 {
+		pagination.Page = 1
+	}
+	if pagination.PageSize <= 0 {
+		pagination.PageSize = 20
+	}
+	if pagination.PageSize > 100 {
+		pagination.PageSize = 100
+	}
+}
+
+// mentionExcerpt は通知用に本文を改行なしで切り詰める
+func mentionExcerpt(text string) string {
+	excerpt := strings.Join(strings.Fields(text), " ")
+	runes := []rune(excerpt)
+	if len(runes) > mentionExcerptLength {
+		return string(runes[:mentionExcerptLength]) + "…"
+	}
+	return excerpt
+}
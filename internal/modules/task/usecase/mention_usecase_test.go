@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=mention_service.go -destination=mocks/mock_mention.go -package=mocks
+
+type mentionTestDeps struct {
+	taskRepo    *mocks.MockTaskRepository
+	commentRepo *mocks.MockCommentRepository
+	mentionRepo *mocks.MockMentionRepository
+	directory   *mocks.MockMentionDirectory
+	notifier    *mocks.MockMentionNotifier
+	resolver    *mocks.MockGroupTaskResolver
+}
+
+func newMentionTestService(t *testing.T) (*MentionService, mentionTestDeps) {
+	ctrl := gomock.NewController(t)
+	deps := mentionTestDeps{
+		taskRepo:    mocks.NewMockTaskRepository(ctrl),
+		commentRepo: mocks.NewMockCommentRepository(ctrl),
+		mentionRepo: mocks.NewMockMentionRepository(ctrl),
+		directory:   mocks.NewMockMentionDirectory(ctrl),
+		notifier:    mocks.NewMockMentionNotifier(ctrl),
+		resolver:    mocks.NewMockGroupTaskResolver(ctrl),
+	}
+	service := NewMentionService(deps.taskRepo, deps.commentRepo, deps.mentionRepo, deps.directory, deps.notifier, *createTestLogger())
+	service.GroupResolver = deps.resolver
+	return service, deps
+}
+
+func TestMentionService_ProcessMentions(t *testing.T) {
+	task := &domain.Task{ID: "task-1", Title: "Review", CreatedBy: "author"}
+	source := domain.MentionSource{Type: domain.MentionSourceDescription, ID: "task-1"}
+
+	t.Run("only visible users are mentioned", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+
+		deps.directory.EXPECT().
+			ResolveUsernames(gomock.Any(), []string{"alice", "Bob", "stranger", "me_self", "unknown"}).
+			Return(map[string]string{"alice": "user-a", "bob": "user-b", "stranger": "user-s", "me_self": "author"}, nil)
+		deps.directory.EXPECT().
+			FilterVisibleUsers(gomock.Any(), "author", []string{"user-a", "user-b", "user-s"}).
+			Return([]string{"user-a", "user-b"}, nil)
+		deps.mentionRepo.EXPECT().SaveMentions(gomock.Any(), gomock.Len(2)).Return(nil)
+		deps.notifier.EXPECT().NotifyMentioned(gomock.Any(), task, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		mentions, err := service.ProcessMentions(context.Background(), task, source, "author",
+			"@alice @Bob @stranger @me_self @unknown", "")
+
+		require.NoError(t, err)
+		require.Len(t, mentions, 2)
+		assert.Equal(t, "user-a", mentions[0].MentionedUserID)
+		assert.Equal(t, domain.MentionSourceDescription, mentions[0].SourceType)
+		assert.Equal(t, "author", mentions[0].AuthorID)
+		assert.NotEmpty(t, mentions[0].ID)
+	})
+
+	t.Run("mentions already in previous text are skipped", func(t *testing.T) {
+		service, _ := newMentionTestService(t)
+
+		mentions, err := service.ProcessMentions(context.Background(), task, source, "author", "@alice updated", "@alice")
+
+		require.NoError(t, err)
+		assert.Empty(t, mentions)
+	})
+
+	t.Run("mentions in code and links are ignored", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+
+		deps.directory.EXPECT().ResolveUsernames(gomock.Any(), []string{"alice"}).Return(map[string]string{}, nil)
+
+		mentions, err := service.ProcessMentions(context.Background(), task, source, "author",
+			"**@alice** `@bob`\n\n```\n@carol\n```\n\nhttps://example.com/@dave", "")
+
+		require.NoError(t, err)
+		assert.Empty(t, mentions)
+	})
+
+	t.Run("notification failure does not fail", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+
+		deps.directory.EXPECT().ResolveUsernames(gomock.Any(), gomock.Any()).Return(map[string]string{"alice": "user-a"}, nil)
+		deps.directory.EXPECT().FilterVisibleUsers(gomock.Any(), "author", []string{"user-a"}).Return([]string{"user-a"}, nil)
+		deps.mentionRepo.EXPECT().SaveMentions(gomock.Any(), gomock.Any()).Return(nil)
+		deps.notifier.EXPECT().NotifyMentioned(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+
+		mentions, err := service.ProcessMentions(context.Background(), task, source, "author", "@alice", "")
+
+		require.NoError(t, err)
+		assert.Len(t, mentions, 1)
+	})
+}
+
+func TestMentionService_AddComment(t *testing.T) {
+	task := &domain.Task{ID: "task-1", Title: "Review", CreatedBy: "owner"}
+
+	t.Run("comment with mention", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+
+		deps.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		deps.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		deps.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "commenter", domain.GroupActionEditTasks).Return(true, nil)
+		deps.commentRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil)
+		deps.directory.EXPECT().ResolveUsernames(gomock.Any(), []string{"owner_name"}).Return(map[string]string{"owner_name": "owner"}, nil)
+		deps.directory.EXPECT().FilterVisibleUsers(gomock.Any(), "commenter", []string{"owner"}).Return([]string{"owner"}, nil)
+		deps.mentionRepo.EXPECT().SaveMentions(gomock.Any(), gomock.Any()).Return(nil)
+		deps.notifier.EXPECT().
+			NotifyMentioned(gomock.Any(), task, gomock.Any(), "@owner_name done").
+			DoAndReturn(func(_ context.Context, _ *domain.Task, mention *domain.Mention, _ string) error {
+				assert.Equal(t, domain.MentionSourceComment, mention.SourceType)
+				assert.NotEmpty(t, mention.SourceID)
+				return nil
+			})
+
+		comment, mentions, err := service.AddComment(context.Background(), "task-1", "commenter", " @owner_name done ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "@owner_name done", comment.Comment)
+		require.Len(t, mentions, 1)
+		assert.Equal(t, comment.ID, mentions[0].SourceID)
+	})
+
+	t.Run("empty comment", func(t *testing.T) {
+		service, _ := newMentionTestService(t)
+
+		_, _, err := service.AddComment(context.Background(), "task-1", "commenter", "  ")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("task not found", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+		deps.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "missing").Return(nil, ErrTaskNotFound)
+
+		_, _, err := service.AddComment(context.Background(), "missing", "commenter", "hello")
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+
+	t.Run("users outside the task's groups cannot comment", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+		deps.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		deps.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+
+		_, _, err := service.AddComment(context.Background(), "task-1", "stranger", "@owner_name hello")
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("group members who can only view the task cannot comment", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+		deps.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		deps.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		deps.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		_, _, err := service.AddComment(context.Background(), "task-1", "guest", "hello")
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+}
+
+func TestMentionService_ListComments(t *testing.T) {
+	task := &domain.Task{ID: "task-1", Title: "Review", CreatedBy: "owner"}
+	pagination := domain.Pagination{Page: 1, PageSize: 20}
+
+	t.Run("group members who can view the task list comments", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+		deps.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		deps.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		deps.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionViewTasks).Return(true, nil)
+		deps.commentRepo.EXPECT().ListComments(gomock.Any(), "task-1", pagination).
+			Return([]*domain.TaskComment{{ID: "comment-1", TaskID: "task-1"}}, 1, nil)
+
+		comments, total, err := service.ListComments(context.Background(), "task-1", "guest", pagination)
+
+		require.NoError(t, err)
+		assert.Len(t, comments, 1)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("users outside the task's groups cannot list comments", func(t *testing.T) {
+		service, deps := newMentionTestService(t)
+		deps.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		deps.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		deps.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "stranger", domain.GroupActionViewTasks).Return(false, nil)
+
+		_, _, err := service.ListComments(context.Background(), "task-1", "stranger", pagination)
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+}
+
+func TestTaskService_UpdateTaskAsUser_ProcessesMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	processor := mocks.NewMockMentionProcessor(ctrl)
+
+	task := &domain.Task{ID: "task-1", Title: "Review", Description: "@alice", CreatedBy: "owner"}
+	task.AddAssignee("editor")
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return task, nil
+		},
+		UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+			return nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.MentionProcessor = processor
+
+	processor.EXPECT().
+		ProcessMentions(gomock.Any(), task, domain.MentionSource{Type: domain.MentionSourceDescription, ID: "task-1"}, "editor", "@alice @bob", "@alice").
+		Return([]*domain.Mention{}, nil)
+
+	description := "@alice @bob"
+	_, err := service.UpdateTaskAsUser(context.Background(), "task-1", "editor", nil, &description, nil, nil, nil)
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,442 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// maxMilestonesPerGroup は1グループあたりのマイルストーン数の上限
+const maxMilestonesPerGroup = 100
+
+// MilestoneRepository はマイルストーンのリポジトリインターフェース
+type MilestoneRepository interface {
+	CreateMilestone(ctx context.Context, milestone *domain.Milestone) error
+	GetMilestoneByID(ctx context.Context, id string) (*domain.Milestone, error)
+	// ListMilestonesByGroup はグループのマイルストーンを並び順で取得する
+	ListMilestonesByGroup(ctx context.Context, groupID string) ([]*domain.Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone *domain.Milestone) error
+	DeleteMilestone(ctx context.Context, id string) error
+
+	// タスクの紐付け（タスクは1つのマイルストーンにのみ属する）
+	SetTaskMilestone(ctx context.Context, taskID, milestoneID string) error
+	RemoveTaskMilestone(ctx context.Context, taskID string) error
+	// GetMilestoneIDForTask はタスクが属するマイルストーンIDを取得する（未設定の場合は空文字）
+	GetMilestoneIDForTask(ctx context.Context, taskID string) (string, error)
+	ListMilestoneTaskIDs(ctx context.Context, milestoneID string) ([]string, error)
+}
+
+// MilestoneInput はマイルストーン作成・更新の入力
+type MilestoneInput struct {
+	GroupID string // 作成時のみ使用
+	Name    string
+	DueDate time.Time
+}
+
+// MilestoneWithProgress はマイルストーンと進捗を表す
+type MilestoneWithProgress struct {
+	*domain.Milestone
+	Progress domain.MilestoneProgress
+}
+
+// MilestoneService はプロジェクトグループのマイルストーンを扱うサービス
+type MilestoneService struct {
+	TaskRepository      TaskRepository
+	MilestoneRepository MilestoneRepository
+	GroupResolver       GroupTaskResolver
+	Logger              logger.Logger
+}
+
+// NewMilestoneService はMilestoneServiceのコンストラクタ
+func NewMilestoneService(
+	taskRepo TaskRepository,
+	milestoneRepo MilestoneRepository,
+	groupResolver GroupTaskResolver,
+	logger logger.Logger,
+) *MilestoneService {
+	return &MilestoneService{
+		TaskRepository:      taskRepo,
+		MilestoneRepository: milestoneRepo,
+		GroupResolver:       groupResolver,
+		Logger:              logger,
+	}
+}
+
+// ErrMilestoneNotFound はマイルストーンが見つからないことを表すエラー
+var ErrMilestoneNotFound = errors.New("milestone not found")
+
+// === マイルストーン管理 ===
+
+// CreateMilestone はプロジェクトグループにマイルストーンを作成する（グループ編集の権限が必要）
+// 並び順は最後になる
+func (s *MilestoneService) CreateMilestone(ctx context.Context, userID string, input MilestoneInput) (*MilestoneWithProgress, error) {
+	if input.GroupID == "" {
+		return nil, fmt.Errorf("%w: groupID is required", ErrInvalidParameter)
+	}
+	if err := validateMilestoneInput(input); err != nil {
+		return nil, err
+	}
+	if err := s.requireManager(ctx, input.GroupID, userID); err != nil {
+		return nil, err
+	}
+
+	isProject, err := s.GroupResolver.IsProjectGroup(ctx, input.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group type: %w", err)
+	}
+	if !isProject {
+		return nil, fmt.Errorf("%w: milestones are only available for project groups", ErrInvalidParameter)
+	}
+
+	existing, err := s.MilestoneRepository.ListMilestonesByGroup(ctx, input.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	if len(existing) >= maxMilestonesPerGroup {
+		return nil, fmt.Errorf("%w: too many milestones (max %d)", ErrInvalidParameter, maxMilestonesPerGroup)
+	}
+
+	milestone := domain.NewMilestone(input.GroupID, strings.TrimSpace(input.Name), input.DueDate, len(existing), userID)
+	milestone.ID = uuid.New().String()
+
+	if err := s.MilestoneRepository.CreateMilestone(ctx, milestone); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create milestone",
+			logger.Any("groupID", input.GroupID), logger.Error(err))
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Milestone created",
+		logger.Any("milestoneID", milestone.ID), logger.Any("groupID", milestone.GroupID))
+
+	return &MilestoneWithProgress{Milestone: milestone}, nil
+}
+
+// ListMilestones はグループのマイルストーンを並び順で進捗とともに取得する（メンバーのみ）
+func (s *MilestoneService) ListMilestones(ctx context.Context, userID, groupID string) ([]*MilestoneWithProgress, error) {
+	if groupID == "" {
+		return nil, fmt.Errorf("%w: groupID is required", ErrInvalidParameter)
+	}
+	if err := s.requireMember(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	milestones, err := s.MilestoneRepository.ListMilestonesByGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	result := make([]*MilestoneWithProgress, 0, len(milestones))
+	for _, milestone := range milestones {
+		withProgress, err := s.withProgress(ctx, milestone)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, withProgress)
+	}
+	return result, nil
+}
+
+// GetMilestone はマイルストーンを進捗とともに取得する（メンバーのみ）
+func (s *MilestoneService) GetMilestone(ctx context.Context, userID, milestoneID string) (*MilestoneWithProgress, error) {
+	milestone, err := s.getVisibleMilestone(ctx, userID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withProgress(ctx, milestone)
+}
+
+// UpdateMilestone はマイルストーンの名前・期限を更新する（グループ編集の権限が必要）
+func (s *MilestoneService) UpdateMilestone(ctx context.Context, userID, milestoneID string, input MilestoneInput) (*MilestoneWithProgress, error) {
+	if err := validateMilestoneInput(input); err != nil {
+		return nil, err
+	}
+
+	milestone, err := s.getManageableMilestone(ctx, userID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone.Name = strings.TrimSpace(input.Name)
+	milestone.DueDate = input.DueDate
+	milestone.UpdatedAt = time.Now()
+
+	if err := s.MilestoneRepository.UpdateMilestone(ctx, milestone); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update milestone",
+			logger.Any("milestoneID", milestoneID), logger.Error(err))
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	return s.withProgress(ctx, milestone)
+}
+
+// DeleteMilestone はマイルストーンを削除する（紐付いていたタスクは削除しない）
+func (s *MilestoneService) DeleteMilestone(ctx context.Context, userID, milestoneID string) error {
+	milestone, err := s.getManageableMilestone(ctx, userID, milestoneID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.MilestoneRepository.DeleteMilestone(ctx, milestoneID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to delete milestone",
+			logger.Any("milestoneID", milestoneID), logger.Error(err))
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	// 後ろのマイルストーンの並び順を詰める
+	return s.reorder(ctx, milestone.GroupID, nil)
+}
+
+// ReorderMilestones はグループのマイルストーンを指定した順に並べ替える（グループ編集の権限が必要）
+// milestoneIDs にはグループの全てのマイルストーンを重複なく指定する
+func (s *MilestoneService) ReorderMilestones(ctx context.Context, userID, groupID string, milestoneIDs []string) ([]*MilestoneWithProgress, error) {
+	if groupID == "" {
+		return nil, fmt.Errorf("%w: groupID is required", ErrInvalidParameter)
+	}
+	if err := s.requireManager(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.reorder(ctx, groupID, milestoneIDs); err != nil {
+		return nil, err
+	}
+	return s.ListMilestones(ctx, userID, groupID)
+}
+
+// === タスクの紐付け ===
+
+// SetTaskMilestone はタスクをマイルストーンに紐付ける（milestoneIDが nil の場合は紐付けを解除する）
+// タスクはマイルストーンのグループのグループタスクである必要があり、グループでタスク編集の権限を持つメンバーのみ変更できる
+func (s *MilestoneService) SetTaskMilestone(ctx context.Context, userID, taskID string, milestoneID *string) error {
+	if taskID == "" {
+		return fmt.Errorf("%w: taskID is required", ErrInvalidParameter)
+	}
+	if _, err := s.TaskRepository.GetTaskByID(ctx, taskID); err != nil {
+		return err
+	}
+
+	if milestoneID == nil || *milestoneID == "" {
+		return s.removeTaskMilestone(ctx, userID, taskID)
+	}
+
+	milestone, err := s.getEditableMilestone(ctx, userID, *milestoneID)
+	if err != nil {
+		return err
+	}
+
+	groupIDs, err := s.GroupResolver.GetGroupIDsForTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get groups for task: %w", err)
+	}
+	if !containsString(groupIDs, milestone.GroupID) {
+		return fmt.Errorf("%w: task does not belong to the milestone's group", ErrInvalidParameter)
+	}
+
+	if err := s.MilestoneRepository.SetTaskMilestone(ctx, taskID, milestone.ID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to attach task to milestone",
+			logger.Any("taskID", taskID), logger.Any("milestoneID", milestone.ID), logger.Error(err))
+		return fmt.Errorf("failed to attach task to milestone: %w", err)
+	}
+	return nil
+}
+
+// === 統計 ===
+
+// GetMilestoneBurndown はマイルストーンの日ごとの未完了タスク数を取得する（メンバーのみ）
+func (s *MilestoneService) GetMilestoneBurndown(ctx context.Context, userID, milestoneID string, now time.Time) (*domain.MilestoneBurndown, error) {
+	milestone, err := s.getVisibleMilestone(ctx, userID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.milestoneTasks(ctx, milestone.ID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewMilestoneBurndown(milestone, tasks, now), nil
+}
+
+// === ヘルパー ===
+
+func (s *MilestoneService) removeTaskMilestone(ctx context.Context, userID, taskID string) error {
+	currentID, err := s.MilestoneRepository.GetMilestoneIDForTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get milestone for task: %w", err)
+	}
+	if currentID == "" {
+		return nil
+	}
+	if _, err := s.getEditableMilestone(ctx, userID, currentID); err != nil {
+		return err
+	}
+
+	if err := s.MilestoneRepository.RemoveTaskMilestone(ctx, taskID); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to detach task from milestone",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return fmt.Errorf("failed to detach task from milestone: %w", err)
+	}
+	return nil
+}
+
+// reorder はマイルストーンの並び順を振り直す（orderが nil の場合は現在の順で詰める）
+func (s *MilestoneService) reorder(ctx context.Context, groupID string, order []string) error {
+	milestones, err := s.MilestoneRepository.ListMilestonesByGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	if order != nil {
+		byID := make(map[string]*domain.Milestone, len(milestones))
+		for _, milestone := range milestones {
+			byID[milestone.ID] = milestone
+		}
+		if len(order) != len(milestones) {
+			return fmt.Errorf("%w: all milestones of the group must be specified", ErrInvalidParameter)
+		}
+		ordered := make([]*domain.Milestone, 0, len(order))
+		for _, id := range order {
+			milestone, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("%w: unknown or duplicate milestone %s", ErrInvalidParameter, id)
+			}
+			delete(byID, id)
+			ordered = append(ordered, milestone)
+		}
+		milestones = ordered
+	}
+
+	now := time.Now()
+	for position, milestone := range milestones {
+		if milestone.Position == position {
+			continue
+		}
+		milestone.Position = position
+		milestone.UpdatedAt = now
+		if err := s.MilestoneRepository.UpdateMilestone(ctx, milestone); err != nil {
+			return fmt.Errorf("failed to update milestone position: %w", err)
+		}
+	}
+	return nil
+}
+
+// withProgress はマイルストーンに紐付いたタスクから進捗を計算する
+func (s *MilestoneService) withProgress(ctx context.Context, milestone *domain.Milestone) (*MilestoneWithProgress, error) {
+	tasks, err := s.milestoneTasks(ctx, milestone.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &MilestoneWithProgress{
+		Milestone: milestone,
+		Progress:  domain.NewMilestoneProgress(tasks),
+	}, nil
+}
+
+// milestoneTasks はマイルストーンに紐付いたタスクを取得する（削除済みのタスクは除く）
+func (s *MilestoneService) milestoneTasks(ctx context.Context, milestoneID string) ([]*domain.Task, error) {
+	taskIDs, err := s.MilestoneRepository.ListMilestoneTaskIDs(ctx, milestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestone tasks: %w", err)
+	}
+
+	tasks := make([]*domain.Task, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get milestone task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// getVisibleMilestone はマイルストーンを取得し、ユーザーがグループのメンバーかを確認する
+func (s *MilestoneService) getVisibleMilestone(ctx context.Context, userID, milestoneID string) (*domain.Milestone, error) {
+	milestone, err := s.MilestoneRepository.GetMilestoneByID(ctx, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireMember(ctx, milestone.GroupID, userID); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// getEditableMilestone はマイルストーンを取得し、ユーザーがグループのタスクを編集できるかを確認する
+func (s *MilestoneService) getEditableMilestone(ctx context.Context, userID, milestoneID string) (*domain.Milestone, error) {
+	milestone, err := s.MilestoneRepository.GetMilestoneByID(ctx, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	canEdit, err := s.GroupResolver.CheckGroupPermission(ctx, milestone.GroupID, userID, domain.GroupActionEditTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group permission: %w", err)
+	}
+	if !canEdit {
+		return nil, ErrPermissionDenied
+	}
+	return milestone, nil
+}
+
+// getManageableMilestone はマイルストーンを取得し、ユーザーがグループを管理できるかを確認する
+func (s *MilestoneService) getManageableMilestone(ctx context.Context, userID, milestoneID string) (*domain.Milestone, error) {
+	milestone, err := s.MilestoneRepository.GetMilestoneByID(ctx, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireManager(ctx, milestone.GroupID, userID); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// requireManager はマイルストーンを管理できるかを確認する（グループ編集の権限が必要）
+func (s *MilestoneService) requireManager(ctx context.Context, groupID, userID string) error {
+	canManage, err := s.GroupResolver.CheckGroupPermission(ctx, groupID, userID, domain.GroupActionEditGroup)
+	if err != nil {
+		return fmt.Errorf("failed to check group permission: %w", err)
+	}
+	if !canManage {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *MilestoneService) requireMember(ctx context.Context, groupID, userID string) error {
+	isMember, err := s.GroupResolver.IsGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !isMember {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func validateMilestoneInput(input MilestoneInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidParameter)
+	}
+	if len(input.Name) > 100 {
+		return fmt.Errorf("%w: name too long (max 100 characters)", ErrInvalidParameter)
+	}
+	if input.DueDate.IsZero() {
+		return fmt.Errorf("%w: due date is required", ErrInvalidParameter)
+	}
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
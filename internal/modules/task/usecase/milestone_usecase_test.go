@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=milestone_service.go -destination=mocks/mock_milestone.go -package=mocks
+
+type milestoneTestMocks struct {
+	taskRepo      *mocks.MockTaskRepository
+	milestoneRepo *mocks.MockMilestoneRepository
+	resolver      *mocks.MockGroupTaskResolver
+}
+
+func newMilestoneTestService(t *testing.T) (*MilestoneService, *milestoneTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &milestoneTestMocks{
+		taskRepo:      mocks.NewMockTaskRepository(ctrl),
+		milestoneRepo: mocks.NewMockMilestoneRepository(ctrl),
+		resolver:      mocks.NewMockGroupTaskResolver(ctrl),
+	}
+	service := NewMilestoneService(m.taskRepo, m.milestoneRepo, m.resolver, *createTestLogger())
+	return service, m
+}
+
+func newTestMilestone(id string, position int) *domain.Milestone {
+	milestone := domain.NewMilestone("group-1", "Milestone "+id, time.Now().AddDate(0, 1, 0), position, "owner")
+	milestone.ID = id
+	return milestone
+}
+
+func TestMilestoneService_CreateMilestone(t *testing.T) {
+	groupID := "group-1"
+	due := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		input            MilestoneInput
+		setupMocks       func(m *milestoneTestMocks)
+		expectedError    error
+		expectedPosition int
+	}{
+		{
+			name:  "appended after existing milestones",
+			input: MilestoneInput{GroupID: groupID, Name: "Beta", DueDate: due},
+			setupMocks: func(m *milestoneTestMocks) {
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "owner", domain.GroupActionEditGroup).Return(true, nil)
+				m.resolver.EXPECT().IsProjectGroup(gomock.Any(), groupID).Return(true, nil)
+				m.milestoneRepo.EXPECT().ListMilestonesByGroup(gomock.Any(), groupID).
+					Return([]*domain.Milestone{newTestMilestone("m1", 0), newTestMilestone("m2", 1)}, nil)
+				m.milestoneRepo.EXPECT().CreateMilestone(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedPosition: 2,
+		},
+		{
+			name:  "requires group management permission",
+			input: MilestoneInput{GroupID: groupID, Name: "Beta", DueDate: due},
+			setupMocks: func(m *milestoneTestMocks) {
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "owner", domain.GroupActionEditGroup).Return(false, nil)
+			},
+			expectedError: ErrPermissionDenied,
+		},
+		{
+			name:  "only project groups",
+			input: MilestoneInput{GroupID: groupID, Name: "Beta", DueDate: due},
+			setupMocks: func(m *milestoneTestMocks) {
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "owner", domain.GroupActionEditGroup).Return(true, nil)
+				m.resolver.EXPECT().IsProjectGroup(gomock.Any(), groupID).Return(false, nil)
+			},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name:          "due date is required",
+			input:         MilestoneInput{GroupID: groupID, Name: "Beta"},
+			setupMocks:    func(m *milestoneTestMocks) {},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name:          "name is required",
+			input:         MilestoneInput{GroupID: groupID, Name: "  ", DueDate: due},
+			setupMocks:    func(m *milestoneTestMocks) {},
+			expectedError: ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newMilestoneTestService(t)
+			tt.setupMocks(m)
+
+			milestone, err := service.CreateMilestone(context.Background(), "owner", tt.input)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, milestone.ID)
+			assert.Equal(t, tt.expectedPosition, milestone.Position)
+			assert.Equal(t, 0, milestone.Progress.TotalTasks)
+		})
+	}
+}
+
+func TestMilestoneService_ListMilestones_Progress(t *testing.T) {
+	service, m := newMilestoneTestService(t)
+
+	doneTask := domain.NewTask("done", "", domain.PriorityMedium, domain.CategoryWork, "owner")
+	doneTask.ID = "task-1"
+	doneTask.SetStatus(domain.TaskStatusDone)
+	openTask := domain.NewTask("open", "", domain.PriorityMedium, domain.CategoryWork, "owner")
+	openTask.ID = "task-2"
+
+	m.resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "member").Return(true, nil)
+	m.milestoneRepo.EXPECT().ListMilestonesByGroup(gomock.Any(), "group-1").
+		Return([]*domain.Milestone{newTestMilestone("m1", 0)}, nil)
+	m.milestoneRepo.EXPECT().ListMilestoneTaskIDs(gomock.Any(), "m1").Return([]string{"task-1", "task-2", "deleted"}, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(doneTask, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-2").Return(openTask, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "deleted").Return(nil, ErrTaskNotFound)
+
+	milestones, err := service.ListMilestones(context.Background(), "member", "group-1")
+
+	require.NoError(t, err)
+	require.Len(t, milestones, 1)
+	assert.Equal(t, domain.MilestoneProgress{TotalTasks: 2, CompletedTasks: 1, CompletionRate: 50}, milestones[0].Progress)
+}
+
+func TestMilestoneService_ListMilestones_NonMember(t *testing.T) {
+	service, m := newMilestoneTestService(t)
+	m.resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "outsider").Return(false, nil)
+
+	_, err := service.ListMilestones(context.Background(), "outsider", "group-1")
+
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
+
+func TestMilestoneService_ReorderMilestones(t *testing.T) {
+	tests := []struct {
+		name          string
+		order         []string
+		expectedError error
+	}{
+		{name: "reorders all milestones", order: []string{"m3", "m1", "m2"}},
+		{name: "missing milestone", order: []string{"m3", "m1"}, expectedError: ErrInvalidParameter},
+		{name: "duplicate milestone", order: []string{"m3", "m3", "m1"}, expectedError: ErrInvalidParameter},
+		{name: "unknown milestone", order: []string{"m3", "m1", "other"}, expectedError: ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newMilestoneTestService(t)
+			existing := []*domain.Milestone{newTestMilestone("m1", 0), newTestMilestone("m2", 1), newTestMilestone("m3", 2)}
+
+			m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "owner", domain.GroupActionEditGroup).Return(true, nil)
+			m.milestoneRepo.EXPECT().ListMilestonesByGroup(gomock.Any(), "group-1").Return(existing, nil)
+
+			positions := make(map[string]int)
+			if tt.expectedError == nil {
+				m.milestoneRepo.EXPECT().UpdateMilestone(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, milestone *domain.Milestone) error {
+						positions[milestone.ID] = milestone.Position
+						return nil
+					}).Times(3)
+				m.resolver.EXPECT().IsGroupMember(gomock.Any(), "group-1", "owner").Return(true, nil)
+				m.milestoneRepo.EXPECT().ListMilestonesByGroup(gomock.Any(), "group-1").Return(nil, nil)
+			}
+
+			_, err := service.ReorderMilestones(context.Background(), "owner", "group-1", tt.order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"m3": 0, "m1": 1, "m2": 2}, positions)
+		})
+	}
+}
+
+func TestMilestoneService_SetTaskMilestone(t *testing.T) {
+	task := domain.NewTask("task", "", domain.PriorityMedium, domain.CategoryWork, "owner")
+	task.ID = "task-1"
+	milestoneID := "m1"
+	emptyID := ""
+
+	tests := []struct {
+		name          string
+		milestoneID   *string
+		setupMocks    func(m *milestoneTestMocks)
+		expectedError error
+	}{
+		{
+			name:        "attaches a group task",
+			milestoneID: &milestoneID,
+			setupMocks: func(m *milestoneTestMocks) {
+				m.milestoneRepo.EXPECT().GetMilestoneByID(gomock.Any(), "m1").Return(newTestMilestone("m1", 0), nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(true, nil)
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+				m.milestoneRepo.EXPECT().SetTaskMilestone(gomock.Any(), "task-1", "m1").Return(nil)
+			},
+		},
+		{
+			name:        "task must belong to the milestone's group",
+			milestoneID: &milestoneID,
+			setupMocks: func(m *milestoneTestMocks) {
+				m.milestoneRepo.EXPECT().GetMilestoneByID(gomock.Any(), "m1").Return(newTestMilestone("m1", 0), nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(true, nil)
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-2"}, nil)
+			},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name:        "members without task edit permission cannot attach",
+			milestoneID: &milestoneID,
+			setupMocks: func(m *milestoneTestMocks) {
+				m.milestoneRepo.EXPECT().GetMilestoneByID(gomock.Any(), "m1").Return(newTestMilestone("m1", 0), nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(false, nil)
+			},
+			expectedError: ErrPermissionDenied,
+		},
+		{
+			name:        "nil milestone detaches",
+			milestoneID: nil,
+			setupMocks: func(m *milestoneTestMocks) {
+				m.milestoneRepo.EXPECT().GetMilestoneIDForTask(gomock.Any(), "task-1").Return("m1", nil)
+				m.milestoneRepo.EXPECT().GetMilestoneByID(gomock.Any(), "m1").Return(newTestMilestone("m1", 0), nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(true, nil)
+				m.milestoneRepo.EXPECT().RemoveTaskMilestone(gomock.Any(), "task-1").Return(nil)
+			},
+		},
+		{
+			name:        "members without task edit permission cannot detach",
+			milestoneID: nil,
+			setupMocks: func(m *milestoneTestMocks) {
+				m.milestoneRepo.EXPECT().GetMilestoneIDForTask(gomock.Any(), "task-1").Return("m1", nil)
+				m.milestoneRepo.EXPECT().GetMilestoneByID(gomock.Any(), "m1").Return(newTestMilestone("m1", 0), nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(false, nil)
+			},
+			expectedError: ErrPermissionDenied,
+		},
+		{
+			name:        "detaching an unattached task is a no-op",
+			milestoneID: &emptyID,
+			setupMocks: func(m *milestoneTestMocks) {
+				m.milestoneRepo.EXPECT().GetMilestoneIDForTask(gomock.Any(), "task-1").Return("", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newMilestoneTestService(t)
+			m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+			tt.setupMocks(m)
+
+			err := service.SetTaskMilestone(context.Background(), "member", "task-1", tt.milestoneID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMilestoneService_DeleteMilestone_CompactsPositions(t *testing.T) {
+	service, m := newMilestoneTestService(t)
+	m.milestoneRepo.EXPECT().GetMilestoneByID(gomock.Any(), "m1").Return(newTestMilestone("m1", 0), nil)
+	m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "owner", domain.GroupActionEditGroup).Return(true, nil)
+	m.milestoneRepo.EXPECT().DeleteMilestone(gomock.Any(), "m1").Return(nil)
+	m.milestoneRepo.EXPECT().ListMilestonesByGroup(gomock.Any(), "group-1").
+		Return([]*domain.Milestone{newTestMilestone("m2", 1), newTestMilestone("m3", 2)}, nil)
+
+	var updated []*domain.Milestone
+	m.milestoneRepo.EXPECT().UpdateMilestone(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, milestone *domain.Milestone) error {
+			updated = append(updated, milestone)
+			return nil
+		}).Times(2)
+
+	require.NoError(t, service.DeleteMilestone(context.Background(), "owner", "m1"))
+	require.Len(t, updated, 2)
+	assert.Equal(t, 0, updated[0].Position)
+	assert.Equal(t, 1, updated[1].Position)
+}
@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockAttachmentRepository is a mock of AttachmentRepository interface.
+type MockAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryMockRecorder
+}
+
+// MockAttachmentRepositoryMockRecorder is the mock recorder for MockAttachmentRepository.
+type MockAttachmentRepositoryMockRecorder struct {
+	mock *MockAttachmentRepository
+}
+
+// NewMockAttachmentRepository creates a new mock instance.
+func NewMockAttachmentRepository(ctrl *gomock.Controller) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepository) EXPECT() *MockAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachmentRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) CreateAttachment(ctx, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).CreateAttachment), ctx, attachment)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) DeleteAttachment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).DeleteAttachment), ctx, id)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentRepository) GetAttachment(ctx context.Context, id string) (*domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, id)
+	ret0, _ := ret[0].(*domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) GetAttachment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).GetAttachment), ctx, id)
+}
+
+// ListAttachmentsByTask mocks base method.
+func (m *MockAttachmentRepository) ListAttachmentsByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachmentsByTask", ctx, taskID)
+	ret0, _ := ret[0].([]*domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachmentsByTask indicates an expected call of ListAttachmentsByTask.
+func (mr *MockAttachmentRepositoryMockRecorder) ListAttachmentsByTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachmentsByTask", reflect.TypeOf((*MockAttachmentRepository)(nil).ListAttachmentsByTask), ctx, taskID)
+}
+
+// ListAttachmentsByUser mocks base method.
+func (m *MockAttachmentRepository) ListAttachmentsByUser(ctx context.Context, userID string) ([]*domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachmentsByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachmentsByUser indicates an expected call of ListAttachmentsByUser.
+func (mr *MockAttachmentRepositoryMockRecorder) ListAttachmentsByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachmentsByUser", reflect.TypeOf((*MockAttachmentRepository)(nil).ListAttachmentsByUser), ctx, userID)
+}
+
+// ListPendingThumbnails mocks base method.
+func (m *MockAttachmentRepository) ListPendingThumbnails(ctx context.Context, limit int) ([]*domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingThumbnails", ctx, limit)
+	ret0, _ := ret[0].([]*domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingThumbnails indicates an expected call of ListPendingThumbnails.
+func (mr *MockAttachmentRepositoryMockRecorder) ListPendingThumbnails(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingThumbnails", reflect.TypeOf((*MockAttachmentRepository)(nil).ListPendingThumbnails), ctx, limit)
+}
+
+// UpdateThumbnailStatus mocks base method.
+func (m *MockAttachmentRepository) UpdateThumbnailStatus(ctx context.Context, id string, status domain.ThumbnailStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateThumbnailStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateThumbnailStatus indicates an expected call of UpdateThumbnailStatus.
+func (mr *MockAttachmentRepositoryMockRecorder) UpdateThumbnailStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateThumbnailStatus", reflect.TypeOf((*MockAttachmentRepository)(nil).UpdateThumbnailStatus), ctx, id, status)
+}
+
+// MockThumbnailScheduler is a mock of ThumbnailScheduler interface.
+type MockThumbnailScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockThumbnailSchedulerMockRecorder
+}
+
+// MockThumbnailSchedulerMockRecorder is the mock recorder for MockThumbnailScheduler.
+type MockThumbnailSchedulerMockRecorder struct {
+	mock *MockThumbnailScheduler
+}
+
+// NewMockThumbnailScheduler creates a new mock instance.
+func NewMockThumbnailScheduler(ctrl *gomock.Controller) *MockThumbnailScheduler {
+	mock := &MockThumbnailScheduler{ctrl: ctrl}
+	mock.recorder = &MockThumbnailSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThumbnailScheduler) EXPECT() *MockThumbnailSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockThumbnailScheduler) Notify() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify")
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockThumbnailSchedulerMockRecorder) Notify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockThumbnailScheduler)(nil).Notify))
+}
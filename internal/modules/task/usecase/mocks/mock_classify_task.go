@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: classify_task.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockTaskClassifier is a mock of TaskClassifier interface.
+type MockTaskClassifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskClassifierMockRecorder
+}
+
+// MockTaskClassifierMockRecorder is the mock recorder for MockTaskClassifier.
+type MockTaskClassifierMockRecorder struct {
+	mock *MockTaskClassifier
+}
+
+// NewMockTaskClassifier creates a new mock instance.
+func NewMockTaskClassifier(ctrl *gomock.Controller) *MockTaskClassifier {
+	mock := &MockTaskClassifier{ctrl: ctrl}
+	mock.recorder = &MockTaskClassifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskClassifier) EXPECT() *MockTaskClassifierMockRecorder {
+	return m.recorder
+}
+
+// Classify mocks base method.
+func (m *MockTaskClassifier) Classify(ctx context.Context, title, description string) (*domain.Classification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Classify", ctx, title, description)
+	ret0, _ := ret[0].(*domain.Classification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Classify indicates an expected call of Classify.
+func (mr *MockTaskClassifierMockRecorder) Classify(ctx, title, description interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Classify", reflect.TypeOf((*MockTaskClassifier)(nil).Classify), ctx, title, description)
+}
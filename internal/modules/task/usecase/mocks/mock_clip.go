@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: clip_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockClipTaskCreator is a mock of ClipTaskCreator interface.
+type MockClipTaskCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockClipTaskCreatorMockRecorder
+}
+
+// MockClipTaskCreatorMockRecorder is the mock recorder for MockClipTaskCreator.
+type MockClipTaskCreatorMockRecorder struct {
+	mock *MockClipTaskCreator
+}
+
+// NewMockClipTaskCreator creates a new mock instance.
+func NewMockClipTaskCreator(ctrl *gomock.Controller) *MockClipTaskCreator {
+	mock := &MockClipTaskCreator{ctrl: ctrl}
+	mock.recorder = &MockClipTaskCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClipTaskCreator) EXPECT() *MockClipTaskCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateTask mocks base method.
+func (m *MockClipTaskCreator) CreateTask(ctx context.Context, title, description string, priority domain.Priority, category domain.Category, createdBy string) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, title, description, priority, category, createdBy)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockClipTaskCreatorMockRecorder) CreateTask(ctx, title, description, priority, category, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockClipTaskCreator)(nil).CreateTask), ctx, title, description, priority, category, createdBy)
+}
+
+// MockClipLinkCreator is a mock of ClipLinkCreator interface.
+type MockClipLinkCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockClipLinkCreatorMockRecorder
+}
+
+// MockClipLinkCreatorMockRecorder is the mock recorder for MockClipLinkCreator.
+type MockClipLinkCreatorMockRecorder struct {
+	mock *MockClipLinkCreator
+}
+
+// NewMockClipLinkCreator creates a new mock instance.
+func NewMockClipLinkCreator(ctrl *gomock.Controller) *MockClipLinkCreator {
+	mock := &MockClipLinkCreator{ctrl: ctrl}
+	mock.recorder = &MockClipLinkCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClipLinkCreator) EXPECT() *MockClipLinkCreatorMockRecorder {
+	return m.recorder
+}
+
+// AddClipLink mocks base method.
+func (m *MockClipLinkCreator) AddClipLink(ctx context.Context, taskID, userID, rawURL, title string) (*domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClipLink", ctx, taskID, userID, rawURL, title)
+	ret0, _ := ret[0].(*domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddClipLink indicates an expected call of AddClipLink.
+func (mr *MockClipLinkCreatorMockRecorder) AddClipLink(ctx, taskID, userID, rawURL, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClipLink", reflect.TypeOf((*MockClipLinkCreator)(nil).AddClipLink), ctx, taskID, userID, rawURL, title)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: daily_stats_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockDailyStatsRepository is a mock of DailyStatsRepository interface.
+type MockDailyStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyStatsRepositoryMockRecorder
+}
+
+// MockDailyStatsRepositoryMockRecorder is the mock recorder for MockDailyStatsRepository.
+type MockDailyStatsRepositoryMockRecorder struct {
+	mock *MockDailyStatsRepository
+}
+
+// NewMockDailyStatsRepository creates a new mock instance.
+func NewMockDailyStatsRepository(ctrl *gomock.Controller) *MockDailyStatsRepository {
+	mock := &MockDailyStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockDailyStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyStatsRepository) EXPECT() *MockDailyStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSnapshots mocks base method.
+func (m *MockDailyStatsRepository) GetSnapshots(ctx context.Context, userID, from, to string) (map[string]*domain.DailyStatsSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshots", ctx, userID, from, to)
+	ret0, _ := ret[0].(map[string]*domain.DailyStatsSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshots indicates an expected call of GetSnapshots.
+func (mr *MockDailyStatsRepositoryMockRecorder) GetSnapshots(ctx, userID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshots", reflect.TypeOf((*MockDailyStatsRepository)(nil).GetSnapshots), ctx, userID, from, to)
+}
+
+// ListSnapshots mocks base method.
+func (m *MockDailyStatsRepository) ListSnapshots(ctx context.Context, from string, limit int) ([]*domain.DailyStatsSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSnapshots", ctx, from, limit)
+	ret0, _ := ret[0].([]*domain.DailyStatsSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSnapshots indicates an expected call of ListSnapshots.
+func (mr *MockDailyStatsRepositoryMockRecorder) ListSnapshots(ctx, from, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSnapshots", reflect.TypeOf((*MockDailyStatsRepository)(nil).ListSnapshots), ctx, from, limit)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockDailyStatsRepository) SaveSnapshot(ctx context.Context, snapshot *domain.DailyStatsSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockDailyStatsRepositoryMockRecorder) SaveSnapshot(ctx, snapshot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockDailyStatsRepository)(nil).SaveSnapshot), ctx, snapshot)
+}
+
+// MockDailyStatsUpdater is a mock of DailyStatsUpdater interface.
+type MockDailyStatsUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyStatsUpdaterMockRecorder
+}
+
+// MockDailyStatsUpdaterMockRecorder is the mock recorder for MockDailyStatsUpdater.
+type MockDailyStatsUpdaterMockRecorder struct {
+	mock *MockDailyStatsUpdater
+}
+
+// NewMockDailyStatsUpdater creates a new mock instance.
+func NewMockDailyStatsUpdater(ctrl *gomock.Controller) *MockDailyStatsUpdater {
+	mock := &MockDailyStatsUpdater{ctrl: ctrl}
+	mock.recorder = &MockDailyStatsUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyStatsUpdater) EXPECT() *MockDailyStatsUpdaterMockRecorder {
+	return m.recorder
+}
+
+// TaskChanged mocks base method.
+func (m *MockDailyStatsUpdater) TaskChanged(ctx context.Context, before *domain.TaskState, task *domain.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TaskChanged", ctx, before, task)
+}
+
+// TaskChanged indicates an expected call of TaskChanged.
+func (mr *MockDailyStatsUpdaterMockRecorder) TaskChanged(ctx, before, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskChanged", reflect.TypeOf((*MockDailyStatsUpdater)(nil).TaskChanged), ctx, before, task)
+}
+
+// TaskDeleted mocks base method.
+func (m *MockDailyStatsUpdater) TaskDeleted(ctx context.Context, task *domain.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TaskDeleted", ctx, task)
+}
+
+// TaskDeleted indicates an expected call of TaskDeleted.
+func (mr *MockDailyStatsUpdaterMockRecorder) TaskDeleted(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskDeleted", reflect.TypeOf((*MockDailyStatsUpdater)(nil).TaskDeleted), ctx, task)
+}
@@ -0,0 +1,414 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: escalation_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockEscalationRuleRepository is a mock of EscalationRuleRepository interface.
+type MockEscalationRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscalationRuleRepositoryMockRecorder
+}
+
+// MockEscalationRuleRepositoryMockRecorder is the mock recorder for MockEscalationRuleRepository.
+type MockEscalationRuleRepositoryMockRecorder struct {
+	mock *MockEscalationRuleRepository
+}
+
+// NewMockEscalationRuleRepository creates a new mock instance.
+func NewMockEscalationRuleRepository(ctrl *gomock.Controller) *MockEscalationRuleRepository {
+	mock := &MockEscalationRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockEscalationRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscalationRuleRepository) EXPECT() *MockEscalationRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockEscalationRuleRepository) CreateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockEscalationRuleRepositoryMockRecorder) CreateRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockEscalationRuleRepository)(nil).CreateRule), ctx, rule)
+}
+
+// DeleteRule mocks base method.
+func (m *MockEscalationRuleRepository) DeleteRule(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockEscalationRuleRepositoryMockRecorder) DeleteRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockEscalationRuleRepository)(nil).DeleteRule), ctx, id)
+}
+
+// GetRuleByID mocks base method.
+func (m *MockEscalationRuleRepository) GetRuleByID(ctx context.Context, id string) (*domain.EscalationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.EscalationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleByID indicates an expected call of GetRuleByID.
+func (mr *MockEscalationRuleRepositoryMockRecorder) GetRuleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByID", reflect.TypeOf((*MockEscalationRuleRepository)(nil).GetRuleByID), ctx, id)
+}
+
+// HasEscalated mocks base method.
+func (m *MockEscalationRuleRepository) HasEscalated(ctx context.Context, taskID, ruleID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasEscalated", ctx, taskID, ruleID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasEscalated indicates an expected call of HasEscalated.
+func (mr *MockEscalationRuleRepositoryMockRecorder) HasEscalated(ctx, taskID, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasEscalated", reflect.TypeOf((*MockEscalationRuleRepository)(nil).HasEscalated), ctx, taskID, ruleID)
+}
+
+// ListEnabledRules mocks base method.
+func (m *MockEscalationRuleRepository) ListEnabledRules(ctx context.Context) ([]*domain.EscalationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnabledRules", ctx)
+	ret0, _ := ret[0].([]*domain.EscalationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnabledRules indicates an expected call of ListEnabledRules.
+func (mr *MockEscalationRuleRepositoryMockRecorder) ListEnabledRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledRules", reflect.TypeOf((*MockEscalationRuleRepository)(nil).ListEnabledRules), ctx)
+}
+
+// ListRulesByOwner mocks base method.
+func (m *MockEscalationRuleRepository) ListRulesByOwner(ctx context.Context, scope domain.EscalationScope, ownerID string) ([]*domain.EscalationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRulesByOwner", ctx, scope, ownerID)
+	ret0, _ := ret[0].([]*domain.EscalationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRulesByOwner indicates an expected call of ListRulesByOwner.
+func (mr *MockEscalationRuleRepositoryMockRecorder) ListRulesByOwner(ctx, scope, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRulesByOwner", reflect.TypeOf((*MockEscalationRuleRepository)(nil).ListRulesByOwner), ctx, scope, ownerID)
+}
+
+// RecordEscalation mocks base method.
+func (m *MockEscalationRuleRepository) RecordEscalation(ctx context.Context, taskID, ruleID string, escalatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordEscalation", ctx, taskID, ruleID, escalatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordEscalation indicates an expected call of RecordEscalation.
+func (mr *MockEscalationRuleRepositoryMockRecorder) RecordEscalation(ctx, taskID, ruleID, escalatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEscalation", reflect.TypeOf((*MockEscalationRuleRepository)(nil).RecordEscalation), ctx, taskID, ruleID, escalatedAt)
+}
+
+// UpdateRule mocks base method.
+func (m *MockEscalationRuleRepository) UpdateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockEscalationRuleRepositoryMockRecorder) UpdateRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockEscalationRuleRepository)(nil).UpdateRule), ctx, rule)
+}
+
+// MockGroupTaskStore is a mock of GroupTaskStore interface.
+type MockGroupTaskStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupTaskStoreMockRecorder
+}
+
+// MockGroupTaskStoreMockRecorder is the mock recorder for MockGroupTaskStore.
+type MockGroupTaskStoreMockRecorder struct {
+	mock *MockGroupTaskStore
+}
+
+// NewMockGroupTaskStore creates a new mock instance.
+func NewMockGroupTaskStore(ctrl *gomock.Controller) *MockGroupTaskStore {
+	mock := &MockGroupTaskStore{ctrl: ctrl}
+	mock.recorder = &MockGroupTaskStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupTaskStore) EXPECT() *MockGroupTaskStoreMockRecorder {
+	return m.recorder
+}
+
+// AddTaskToGroup mocks base method.
+func (m *MockGroupTaskStore) AddTaskToGroup(ctx context.Context, taskID, groupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskToGroup", ctx, taskID, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskToGroup indicates an expected call of AddTaskToGroup.
+func (mr *MockGroupTaskStoreMockRecorder) AddTaskToGroup(ctx, taskID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskToGroup", reflect.TypeOf((*MockGroupTaskStore)(nil).AddTaskToGroup), ctx, taskID, groupID)
+}
+
+// GetGroupIDsForTask mocks base method.
+func (m *MockGroupTaskStore) GetGroupIDsForTask(ctx context.Context, taskID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupIDsForTask", ctx, taskID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupIDsForTask indicates an expected call of GetGroupIDsForTask.
+func (mr *MockGroupTaskStoreMockRecorder) GetGroupIDsForTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupIDsForTask", reflect.TypeOf((*MockGroupTaskStore)(nil).GetGroupIDsForTask), ctx, taskID)
+}
+
+// IsGroupMember mocks base method.
+func (m *MockGroupTaskStore) IsGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsGroupMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsGroupMember indicates an expected call of IsGroupMember.
+func (mr *MockGroupTaskStoreMockRecorder) IsGroupMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGroupMember", reflect.TypeOf((*MockGroupTaskStore)(nil).IsGroupMember), ctx, groupID, userID)
+}
+
+// IsProjectGroup mocks base method.
+func (m *MockGroupTaskStore) IsProjectGroup(ctx context.Context, groupID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProjectGroup", ctx, groupID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProjectGroup indicates an expected call of IsProjectGroup.
+func (mr *MockGroupTaskStoreMockRecorder) IsProjectGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProjectGroup", reflect.TypeOf((*MockGroupTaskStore)(nil).IsProjectGroup), ctx, groupID)
+}
+
+// ListGroupTaskIDs mocks base method.
+func (m *MockGroupTaskStore) ListGroupTaskIDs(ctx context.Context, groupID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupTaskIDs", ctx, groupID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupTaskIDs indicates an expected call of ListGroupTaskIDs.
+func (mr *MockGroupTaskStoreMockRecorder) ListGroupTaskIDs(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupTaskIDs", reflect.TypeOf((*MockGroupTaskStore)(nil).ListGroupTaskIDs), ctx, groupID)
+}
+
+// MockGroupTaskResolver is a mock of GroupTaskResolver interface.
+type MockGroupTaskResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupTaskResolverMockRecorder
+}
+
+// MockGroupTaskResolverMockRecorder is the mock recorder for MockGroupTaskResolver.
+type MockGroupTaskResolverMockRecorder struct {
+	mock *MockGroupTaskResolver
+}
+
+// NewMockGroupTaskResolver creates a new mock instance.
+func NewMockGroupTaskResolver(ctrl *gomock.Controller) *MockGroupTaskResolver {
+	mock := &MockGroupTaskResolver{ctrl: ctrl}
+	mock.recorder = &MockGroupTaskResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupTaskResolver) EXPECT() *MockGroupTaskResolverMockRecorder {
+	return m.recorder
+}
+
+// AddTaskToGroup mocks base method.
+func (m *MockGroupTaskResolver) AddTaskToGroup(ctx context.Context, taskID, groupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskToGroup", ctx, taskID, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskToGroup indicates an expected call of AddTaskToGroup.
+func (mr *MockGroupTaskResolverMockRecorder) AddTaskToGroup(ctx, taskID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskToGroup", reflect.TypeOf((*MockGroupTaskResolver)(nil).AddTaskToGroup), ctx, taskID, groupID)
+}
+
+// CheckGroupPermission mocks base method.
+func (m *MockGroupTaskResolver) CheckGroupPermission(ctx context.Context, groupID, userID string, action domain.GroupAction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckGroupPermission", ctx, groupID, userID, action)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGroupPermission indicates an expected call of CheckGroupPermission.
+func (mr *MockGroupTaskResolverMockRecorder) CheckGroupPermission(ctx, groupID, userID, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGroupPermission", reflect.TypeOf((*MockGroupTaskResolver)(nil).CheckGroupPermission), ctx, groupID, userID, action)
+}
+
+// GetGroupIDsForTask mocks base method.
+func (m *MockGroupTaskResolver) GetGroupIDsForTask(ctx context.Context, taskID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupIDsForTask", ctx, taskID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupIDsForTask indicates an expected call of GetGroupIDsForTask.
+func (mr *MockGroupTaskResolverMockRecorder) GetGroupIDsForTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupIDsForTask", reflect.TypeOf((*MockGroupTaskResolver)(nil).GetGroupIDsForTask), ctx, taskID)
+}
+
+// IsGroupMember mocks base method.
+func (m *MockGroupTaskResolver) IsGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsGroupMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsGroupMember indicates an expected call of IsGroupMember.
+func (mr *MockGroupTaskResolverMockRecorder) IsGroupMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGroupMember", reflect.TypeOf((*MockGroupTaskResolver)(nil).IsGroupMember), ctx, groupID, userID)
+}
+
+// IsProjectGroup mocks base method.
+func (m *MockGroupTaskResolver) IsProjectGroup(ctx context.Context, groupID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProjectGroup", ctx, groupID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProjectGroup indicates an expected call of IsProjectGroup.
+func (mr *MockGroupTaskResolverMockRecorder) IsProjectGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProjectGroup", reflect.TypeOf((*MockGroupTaskResolver)(nil).IsProjectGroup), ctx, groupID)
+}
+
+// ListGroupMemberIDsWithPermission mocks base method.
+func (m *MockGroupTaskResolver) ListGroupMemberIDsWithPermission(ctx context.Context, groupID string, action domain.GroupAction) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupMemberIDsWithPermission", ctx, groupID, action)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupMemberIDsWithPermission indicates an expected call of ListGroupMemberIDsWithPermission.
+func (mr *MockGroupTaskResolverMockRecorder) ListGroupMemberIDsWithPermission(ctx, groupID, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMemberIDsWithPermission", reflect.TypeOf((*MockGroupTaskResolver)(nil).ListGroupMemberIDsWithPermission), ctx, groupID, action)
+}
+
+// ListGroupTaskIDs mocks base method.
+func (m *MockGroupTaskResolver) ListGroupTaskIDs(ctx context.Context, groupID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupTaskIDs", ctx, groupID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupTaskIDs indicates an expected call of ListGroupTaskIDs.
+func (mr *MockGroupTaskResolverMockRecorder) ListGroupTaskIDs(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupTaskIDs", reflect.TypeOf((*MockGroupTaskResolver)(nil).ListGroupTaskIDs), ctx, groupID)
+}
+
+// MockEscalationNotifier is a mock of EscalationNotifier interface.
+type MockEscalationNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscalationNotifierMockRecorder
+}
+
+// MockEscalationNotifierMockRecorder is the mock recorder for MockEscalationNotifier.
+type MockEscalationNotifierMockRecorder struct {
+	mock *MockEscalationNotifier
+}
+
+// NewMockEscalationNotifier creates a new mock instance.
+func NewMockEscalationNotifier(ctrl *gomock.Controller) *MockEscalationNotifier {
+	mock := &MockEscalationNotifier{ctrl: ctrl}
+	mock.recorder = &MockEscalationNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscalationNotifier) EXPECT() *MockEscalationNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyTaskEscalated mocks base method.
+func (m *MockEscalationNotifier) NotifyTaskEscalated(ctx context.Context, task *domain.Task, recipientID string, rule *domain.EscalationRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyTaskEscalated", ctx, task, recipientID, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyTaskEscalated indicates an expected call of NotifyTaskEscalated.
+func (mr *MockEscalationNotifierMockRecorder) NotifyTaskEscalated(ctx, task, recipientID, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTaskEscalated", reflect.TypeOf((*MockEscalationNotifier)(nil).NotifyTaskEscalated), ctx, task, recipientID, rule)
+}
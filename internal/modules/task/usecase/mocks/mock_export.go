@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockTaskExportRepository is a mock of TaskExportRepository interface.
+type MockTaskExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskExportRepositoryMockRecorder
+}
+
+// MockTaskExportRepositoryMockRecorder is the mock recorder for MockTaskExportRepository.
+type MockTaskExportRepositoryMockRecorder struct {
+	mock *MockTaskExportRepository
+}
+
+// NewMockTaskExportRepository creates a new mock instance.
+func NewMockTaskExportRepository(ctrl *gomock.Controller) *MockTaskExportRepository {
+	mock := &MockTaskExportRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskExportRepository) EXPECT() *MockTaskExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// StreamTasksByUser mocks base method.
+func (m *MockTaskExportRepository) StreamTasksByUser(ctx context.Context, userID string) (<-chan *domain.Task, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTasksByUser", ctx, userID)
+	ret0, _ := ret[0].(<-chan *domain.Task)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// StreamTasksByUser indicates an expected call of StreamTasksByUser.
+func (mr *MockTaskExportRepositoryMockRecorder) StreamTasksByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTasksByUser", reflect.TypeOf((*MockTaskExportRepository)(nil).StreamTasksByUser), ctx, userID)
+}
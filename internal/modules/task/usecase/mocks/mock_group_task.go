@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: group_task.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockGroupTaskAssigner is a mock of GroupTaskAssigner interface.
+type MockGroupTaskAssigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupTaskAssignerMockRecorder
+}
+
+// MockGroupTaskAssignerMockRecorder is the mock recorder for MockGroupTaskAssigner.
+type MockGroupTaskAssignerMockRecorder struct {
+	mock *MockGroupTaskAssigner
+}
+
+// NewMockGroupTaskAssigner creates a new mock instance.
+func NewMockGroupTaskAssigner(ctrl *gomock.Controller) *MockGroupTaskAssigner {
+	mock := &MockGroupTaskAssigner{ctrl: ctrl}
+	mock.recorder = &MockGroupTaskAssignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupTaskAssigner) EXPECT() *MockGroupTaskAssignerMockRecorder {
+	return m.recorder
+}
+
+// CanCreateGroupTask mocks base method.
+func (m *MockGroupTaskAssigner) CanCreateGroupTask(ctx context.Context, groupID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanCreateGroupTask", ctx, groupID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanCreateGroupTask indicates an expected call of CanCreateGroupTask.
+func (mr *MockGroupTaskAssignerMockRecorder) CanCreateGroupTask(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanCreateGroupTask", reflect.TypeOf((*MockGroupTaskAssigner)(nil).CanCreateGroupTask), ctx, groupID, userID)
+}
+
+// PickAssignee mocks base method.
+func (m *MockGroupTaskAssigner) PickAssignee(ctx context.Context, groupID, requesterID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PickAssignee", ctx, groupID, requesterID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PickAssignee indicates an expected call of PickAssignee.
+func (mr *MockGroupTaskAssignerMockRecorder) PickAssignee(ctx, groupID, requesterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickAssignee", reflect.TypeOf((*MockGroupTaskAssigner)(nil).PickAssignee), ctx, groupID, requesterID)
+}
+
+// MockTaskEventLinkProvider is a mock of TaskEventLinkProvider interface.
+type MockTaskEventLinkProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskEventLinkProviderMockRecorder
+}
+
+// MockTaskEventLinkProviderMockRecorder is the mock recorder for MockTaskEventLinkProvider.
+type MockTaskEventLinkProviderMockRecorder struct {
+	mock *MockTaskEventLinkProvider
+}
+
+// NewMockTaskEventLinkProvider creates a new mock instance.
+func NewMockTaskEventLinkProvider(ctrl *gomock.Controller) *MockTaskEventLinkProvider {
+	mock := &MockTaskEventLinkProvider{ctrl: ctrl}
+	mock.recorder = &MockTaskEventLinkProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskEventLinkProvider) EXPECT() *MockTaskEventLinkProviderMockRecorder {
+	return m.recorder
+}
+
+// ListTaskEventLinks mocks base method.
+func (m *MockTaskEventLinkProvider) ListTaskEventLinks(ctx context.Context, taskID string) ([]*domain.TaskEventLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskEventLinks", ctx, taskID)
+	ret0, _ := ret[0].([]*domain.TaskEventLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskEventLinks indicates an expected call of ListTaskEventLinks.
+func (mr *MockTaskEventLinkProviderMockRecorder) ListTaskEventLinks(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskEventLinks", reflect.TypeOf((*MockTaskEventLinkProvider)(nil).ListTaskEventLinks), ctx, taskID)
+}
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: history_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockTaskHistoryRepository is a mock of TaskHistoryRepository interface.
+type MockTaskHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskHistoryRepositoryMockRecorder
+}
+
+// MockTaskHistoryRepositoryMockRecorder is the mock recorder for MockTaskHistoryRepository.
+type MockTaskHistoryRepositoryMockRecorder struct {
+	mock *MockTaskHistoryRepository
+}
+
+// NewMockTaskHistoryRepository creates a new mock instance.
+func NewMockTaskHistoryRepository(ctrl *gomock.Controller) *MockTaskHistoryRepository {
+	mock := &MockTaskHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskHistoryRepository) EXPECT() *MockTaskHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendTaskEvent mocks base method.
+func (m *MockTaskHistoryRepository) AppendTaskEvent(ctx context.Context, event *domain.TaskEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendTaskEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendTaskEvent indicates an expected call of AppendTaskEvent.
+func (mr *MockTaskHistoryRepositoryMockRecorder) AppendTaskEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendTaskEvent", reflect.TypeOf((*MockTaskHistoryRepository)(nil).AppendTaskEvent), ctx, event)
+}
+
+// GetLastTaskEventSequence mocks base method.
+func (m *MockTaskHistoryRepository) GetLastTaskEventSequence(ctx context.Context, taskID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastTaskEventSequence", ctx, taskID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastTaskEventSequence indicates an expected call of GetLastTaskEventSequence.
+func (mr *MockTaskHistoryRepositoryMockRecorder) GetLastTaskEventSequence(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastTaskEventSequence", reflect.TypeOf((*MockTaskHistoryRepository)(nil).GetLastTaskEventSequence), ctx, taskID)
+}
+
+// GetLatestTaskSnapshot mocks base method.
+func (m *MockTaskHistoryRepository) GetLatestTaskSnapshot(ctx context.Context, taskID string, until time.Time) (*domain.TaskSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestTaskSnapshot", ctx, taskID, until)
+	ret0, _ := ret[0].(*domain.TaskSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestTaskSnapshot indicates an expected call of GetLatestTaskSnapshot.
+func (mr *MockTaskHistoryRepositoryMockRecorder) GetLatestTaskSnapshot(ctx, taskID, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTaskSnapshot", reflect.TypeOf((*MockTaskHistoryRepository)(nil).GetLatestTaskSnapshot), ctx, taskID, until)
+}
+
+// ListStatusEvents mocks base method.
+func (m *MockTaskHistoryRepository) ListStatusEvents(ctx context.Context, taskIDs []string, until time.Time) ([]*domain.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusEvents", ctx, taskIDs, until)
+	ret0, _ := ret[0].([]*domain.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusEvents indicates an expected call of ListStatusEvents.
+func (mr *MockTaskHistoryRepositoryMockRecorder) ListStatusEvents(ctx, taskIDs, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusEvents", reflect.TypeOf((*MockTaskHistoryRepository)(nil).ListStatusEvents), ctx, taskIDs, until)
+}
+
+// ListTaskEvents mocks base method.
+func (m *MockTaskHistoryRepository) ListTaskEvents(ctx context.Context, taskID string, afterSequence int64, until time.Time) ([]*domain.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskEvents", ctx, taskID, afterSequence, until)
+	ret0, _ := ret[0].([]*domain.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskEvents indicates an expected call of ListTaskEvents.
+func (mr *MockTaskHistoryRepositoryMockRecorder) ListTaskEvents(ctx, taskID, afterSequence, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskEvents", reflect.TypeOf((*MockTaskHistoryRepository)(nil).ListTaskEvents), ctx, taskID, afterSequence, until)
+}
+
+// SaveTaskSnapshot mocks base method.
+func (m *MockTaskHistoryRepository) SaveTaskSnapshot(ctx context.Context, snapshot *domain.TaskSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTaskSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTaskSnapshot indicates an expected call of SaveTaskSnapshot.
+func (mr *MockTaskHistoryRepositoryMockRecorder) SaveTaskSnapshot(ctx, snapshot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTaskSnapshot", reflect.TypeOf((*MockTaskHistoryRepository)(nil).SaveTaskSnapshot), ctx, snapshot)
+}
+
+// MockTaskHistoryRecorder is a mock of TaskHistoryRecorder interface.
+type MockTaskHistoryRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskHistoryRecorderMockRecorder
+}
+
+// MockTaskHistoryRecorderMockRecorder is the mock recorder for MockTaskHistoryRecorder.
+type MockTaskHistoryRecorderMockRecorder struct {
+	mock *MockTaskHistoryRecorder
+}
+
+// NewMockTaskHistoryRecorder creates a new mock instance.
+func NewMockTaskHistoryRecorder(ctrl *gomock.Controller) *MockTaskHistoryRecorder {
+	mock := &MockTaskHistoryRecorder{ctrl: ctrl}
+	mock.recorder = &MockTaskHistoryRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskHistoryRecorder) EXPECT() *MockTaskHistoryRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordChange mocks base method.
+func (m *MockTaskHistoryRecorder) RecordChange(ctx context.Context, before *domain.TaskState, task *domain.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordChange", ctx, before, task)
+}
+
+// RecordChange indicates an expected call of RecordChange.
+func (mr *MockTaskHistoryRecorderMockRecorder) RecordChange(ctx, before, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordChange", reflect.TypeOf((*MockTaskHistoryRecorder)(nil).RecordChange), ctx, before, task)
+}
+
+// RecordCreated mocks base method.
+func (m *MockTaskHistoryRecorder) RecordCreated(ctx context.Context, task *domain.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordCreated", ctx, task)
+}
+
+// RecordCreated indicates an expected call of RecordCreated.
+func (mr *MockTaskHistoryRecorderMockRecorder) RecordCreated(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCreated", reflect.TypeOf((*MockTaskHistoryRecorder)(nil).RecordCreated), ctx, task)
+}
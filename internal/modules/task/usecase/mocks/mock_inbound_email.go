@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: inbound_email_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockInboundEmailRepository is a mock of InboundEmailRepository interface.
+type MockInboundEmailRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboundEmailRepositoryMockRecorder
+}
+
+// MockInboundEmailRepositoryMockRecorder is the mock recorder for MockInboundEmailRepository.
+type MockInboundEmailRepositoryMockRecorder struct {
+	mock *MockInboundEmailRepository
+}
+
+// NewMockInboundEmailRepository creates a new mock instance.
+func NewMockInboundEmailRepository(ctrl *gomock.Controller) *MockInboundEmailRepository {
+	mock := &MockInboundEmailRepository{ctrl: ctrl}
+	mock.recorder = &MockInboundEmailRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInboundEmailRepository) EXPECT() *MockInboundEmailRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountInboundEmailsSince mocks base method.
+func (m *MockInboundEmailRepository) CountInboundEmailsSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInboundEmailsSince", ctx, userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInboundEmailsSince indicates an expected call of CountInboundEmailsSince.
+func (mr *MockInboundEmailRepositoryMockRecorder) CountInboundEmailsSince(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInboundEmailsSince", reflect.TypeOf((*MockInboundEmailRepository)(nil).CountInboundEmailsSince), ctx, userID, since)
+}
+
+// CreateInboundEmail mocks base method.
+func (m *MockInboundEmailRepository) CreateInboundEmail(ctx context.Context, record *domain.InboundEmailRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInboundEmail", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInboundEmail indicates an expected call of CreateInboundEmail.
+func (mr *MockInboundEmailRepositoryMockRecorder) CreateInboundEmail(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInboundEmail", reflect.TypeOf((*MockInboundEmailRepository)(nil).CreateInboundEmail), ctx, record)
+}
+
+// DeleteInboundAddress mocks base method.
+func (m *MockInboundEmailRepository) DeleteInboundAddress(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInboundAddress", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInboundAddress indicates an expected call of DeleteInboundAddress.
+func (mr *MockInboundEmailRepositoryMockRecorder) DeleteInboundAddress(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInboundAddress", reflect.TypeOf((*MockInboundEmailRepository)(nil).DeleteInboundAddress), ctx, userID)
+}
+
+// ExistsInboundEmail mocks base method.
+func (m *MockInboundEmailRepository) ExistsInboundEmail(ctx context.Context, userID, messageID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsInboundEmail", ctx, userID, messageID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsInboundEmail indicates an expected call of ExistsInboundEmail.
+func (mr *MockInboundEmailRepositoryMockRecorder) ExistsInboundEmail(ctx, userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsInboundEmail", reflect.TypeOf((*MockInboundEmailRepository)(nil).ExistsInboundEmail), ctx, userID, messageID)
+}
+
+// GetInboundAddress mocks base method.
+func (m *MockInboundEmailRepository) GetInboundAddress(ctx context.Context, userID string) (*domain.InboundAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundAddress", ctx, userID)
+	ret0, _ := ret[0].(*domain.InboundAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboundAddress indicates an expected call of GetInboundAddress.
+func (mr *MockInboundEmailRepositoryMockRecorder) GetInboundAddress(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundAddress", reflect.TypeOf((*MockInboundEmailRepository)(nil).GetInboundAddress), ctx, userID)
+}
+
+// GetInboundAddressByToken mocks base method.
+func (m *MockInboundEmailRepository) GetInboundAddressByToken(ctx context.Context, token string) (*domain.InboundAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundAddressByToken", ctx, token)
+	ret0, _ := ret[0].(*domain.InboundAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboundAddressByToken indicates an expected call of GetInboundAddressByToken.
+func (mr *MockInboundEmailRepositoryMockRecorder) GetInboundAddressByToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundAddressByToken", reflect.TypeOf((*MockInboundEmailRepository)(nil).GetInboundAddressByToken), ctx, token)
+}
+
+// SaveInboundAddress mocks base method.
+func (m *MockInboundEmailRepository) SaveInboundAddress(ctx context.Context, address *domain.InboundAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInboundAddress", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInboundAddress indicates an expected call of SaveInboundAddress.
+func (mr *MockInboundEmailRepositoryMockRecorder) SaveInboundAddress(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInboundAddress", reflect.TypeOf((*MockInboundEmailRepository)(nil).SaveInboundAddress), ctx, address)
+}
+
+// MockInboundEmailParser is a mock of InboundEmailParser interface.
+type MockInboundEmailParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboundEmailParserMockRecorder
+}
+
+// MockInboundEmailParserMockRecorder is the mock recorder for MockInboundEmailParser.
+type MockInboundEmailParserMockRecorder struct {
+	mock *MockInboundEmailParser
+}
+
+// NewMockInboundEmailParser creates a new mock instance.
+func NewMockInboundEmailParser(ctrl *gomock.Controller) *MockInboundEmailParser {
+	mock := &MockInboundEmailParser{ctrl: ctrl}
+	mock.recorder = &MockInboundEmailParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInboundEmailParser) EXPECT() *MockInboundEmailParserMockRecorder {
+	return m.recorder
+}
+
+// ParseInboundEmail mocks base method.
+func (m *MockInboundEmailParser) ParseInboundEmail(contentType string, payload []byte) (*domain.InboundEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseInboundEmail", contentType, payload)
+	ret0, _ := ret[0].(*domain.InboundEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseInboundEmail indicates an expected call of ParseInboundEmail.
+func (mr *MockInboundEmailParserMockRecorder) ParseInboundEmail(contentType, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseInboundEmail", reflect.TypeOf((*MockInboundEmailParser)(nil).ParseInboundEmail), contentType, payload)
+}
+
+// MockInboundTaskCreator is a mock of InboundTaskCreator interface.
+type MockInboundTaskCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboundTaskCreatorMockRecorder
+}
+
+// MockInboundTaskCreatorMockRecorder is the mock recorder for MockInboundTaskCreator.
+type MockInboundTaskCreatorMockRecorder struct {
+	mock *MockInboundTaskCreator
+}
+
+// NewMockInboundTaskCreator creates a new mock instance.
+func NewMockInboundTaskCreator(ctrl *gomock.Controller) *MockInboundTaskCreator {
+	mock := &MockInboundTaskCreator{ctrl: ctrl}
+	mock.recorder = &MockInboundTaskCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInboundTaskCreator) EXPECT() *MockInboundTaskCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateTask mocks base method.
+func (m *MockInboundTaskCreator) CreateTask(ctx context.Context, title, description string, priority domain.Priority, category domain.Category, createdBy string) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, title, description, priority, category, createdBy)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockInboundTaskCreatorMockRecorder) CreateTask(ctx, title, description, priority, category, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockInboundTaskCreator)(nil).CreateTask), ctx, title, description, priority, category, createdBy)
+}
+
+// MockInboundAttachmentSaver is a mock of InboundAttachmentSaver interface.
+type MockInboundAttachmentSaver struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboundAttachmentSaverMockRecorder
+}
+
+// MockInboundAttachmentSaverMockRecorder is the mock recorder for MockInboundAttachmentSaver.
+type MockInboundAttachmentSaverMockRecorder struct {
+	mock *MockInboundAttachmentSaver
+}
+
+// NewMockInboundAttachmentSaver creates a new mock instance.
+func NewMockInboundAttachmentSaver(ctrl *gomock.Controller) *MockInboundAttachmentSaver {
+	mock := &MockInboundAttachmentSaver{ctrl: ctrl}
+	mock.recorder = &MockInboundAttachmentSaverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInboundAttachmentSaver) EXPECT() *MockInboundAttachmentSaverMockRecorder {
+	return m.recorder
+}
+
+// SaveInboundAttachment mocks base method.
+func (m *MockInboundAttachmentSaver) SaveInboundAttachment(ctx context.Context, taskID, userID string, attachment domain.InboundAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInboundAttachment", ctx, taskID, userID, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInboundAttachment indicates an expected call of SaveInboundAttachment.
+func (mr *MockInboundAttachmentSaverMockRecorder) SaveInboundAttachment(ctx, taskID, userID, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInboundAttachment", reflect.TypeOf((*MockInboundAttachmentSaver)(nil).SaveInboundAttachment), ctx, taskID, userID, attachment)
+}
@@ -0,0 +1,272 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: issue_link_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockIssueLinkRepository is a mock of IssueLinkRepository interface.
+type MockIssueLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueLinkRepositoryMockRecorder
+}
+
+// MockIssueLinkRepositoryMockRecorder is the mock recorder for MockIssueLinkRepository.
+type MockIssueLinkRepositoryMockRecorder struct {
+	mock *MockIssueLinkRepository
+}
+
+// NewMockIssueLinkRepository creates a new mock instance.
+func NewMockIssueLinkRepository(ctrl *gomock.Controller) *MockIssueLinkRepository {
+	mock := &MockIssueLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockIssueLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueLinkRepository) EXPECT() *MockIssueLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIssueLink mocks base method.
+func (m *MockIssueLinkRepository) CreateIssueLink(ctx context.Context, link *domain.IssueLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssueLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIssueLink indicates an expected call of CreateIssueLink.
+func (mr *MockIssueLinkRepositoryMockRecorder) CreateIssueLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssueLink", reflect.TypeOf((*MockIssueLinkRepository)(nil).CreateIssueLink), ctx, link)
+}
+
+// DeleteGitHubAccount mocks base method.
+func (m *MockIssueLinkRepository) DeleteGitHubAccount(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitHubAccount", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGitHubAccount indicates an expected call of DeleteGitHubAccount.
+func (mr *MockIssueLinkRepositoryMockRecorder) DeleteGitHubAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitHubAccount", reflect.TypeOf((*MockIssueLinkRepository)(nil).DeleteGitHubAccount), ctx, userID)
+}
+
+// DeleteIssueLink mocks base method.
+func (m *MockIssueLinkRepository) DeleteIssueLink(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIssueLink", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIssueLink indicates an expected call of DeleteIssueLink.
+func (mr *MockIssueLinkRepositoryMockRecorder) DeleteIssueLink(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIssueLink", reflect.TypeOf((*MockIssueLinkRepository)(nil).DeleteIssueLink), ctx, id)
+}
+
+// GetGitHubAccount mocks base method.
+func (m *MockIssueLinkRepository) GetGitHubAccount(ctx context.Context, userID string) (*domain.GitHubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitHubAccount", ctx, userID)
+	ret0, _ := ret[0].(*domain.GitHubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitHubAccount indicates an expected call of GetGitHubAccount.
+func (mr *MockIssueLinkRepositoryMockRecorder) GetGitHubAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubAccount", reflect.TypeOf((*MockIssueLinkRepository)(nil).GetGitHubAccount), ctx, userID)
+}
+
+// GetIssueLink mocks base method.
+func (m *MockIssueLinkRepository) GetIssueLink(ctx context.Context, id string) (*domain.IssueLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssueLink", ctx, id)
+	ret0, _ := ret[0].(*domain.IssueLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssueLink indicates an expected call of GetIssueLink.
+func (mr *MockIssueLinkRepositoryMockRecorder) GetIssueLink(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssueLink", reflect.TypeOf((*MockIssueLinkRepository)(nil).GetIssueLink), ctx, id)
+}
+
+// ListIssueLinksByIssue mocks base method.
+func (m *MockIssueLinkRepository) ListIssueLinksByIssue(ctx context.Context, provider domain.IssueProvider, ref domain.IssueRef) ([]*domain.IssueLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssueLinksByIssue", ctx, provider, ref)
+	ret0, _ := ret[0].([]*domain.IssueLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssueLinksByIssue indicates an expected call of ListIssueLinksByIssue.
+func (mr *MockIssueLinkRepositoryMockRecorder) ListIssueLinksByIssue(ctx, provider, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueLinksByIssue", reflect.TypeOf((*MockIssueLinkRepository)(nil).ListIssueLinksByIssue), ctx, provider, ref)
+}
+
+// ListIssueLinksByTask mocks base method.
+func (m *MockIssueLinkRepository) ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssueLinksByTask", ctx, taskID)
+	ret0, _ := ret[0].([]*domain.IssueLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssueLinksByTask indicates an expected call of ListIssueLinksByTask.
+func (mr *MockIssueLinkRepositoryMockRecorder) ListIssueLinksByTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueLinksByTask", reflect.TypeOf((*MockIssueLinkRepository)(nil).ListIssueLinksByTask), ctx, taskID)
+}
+
+// SaveGitHubAccount mocks base method.
+func (m *MockIssueLinkRepository) SaveGitHubAccount(ctx context.Context, account *domain.GitHubAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGitHubAccount", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGitHubAccount indicates an expected call of SaveGitHubAccount.
+func (mr *MockIssueLinkRepositoryMockRecorder) SaveGitHubAccount(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGitHubAccount", reflect.TypeOf((*MockIssueLinkRepository)(nil).SaveGitHubAccount), ctx, account)
+}
+
+// UpdateIssueLink mocks base method.
+func (m *MockIssueLinkRepository) UpdateIssueLink(ctx context.Context, link *domain.IssueLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIssueLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIssueLink indicates an expected call of UpdateIssueLink.
+func (mr *MockIssueLinkRepositoryMockRecorder) UpdateIssueLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIssueLink", reflect.TypeOf((*MockIssueLinkRepository)(nil).UpdateIssueLink), ctx, link)
+}
+
+// MockGitHubClient is a mock of GitHubClient interface.
+type MockGitHubClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitHubClientMockRecorder
+}
+
+// MockGitHubClientMockRecorder is the mock recorder for MockGitHubClient.
+type MockGitHubClientMockRecorder struct {
+	mock *MockGitHubClient
+}
+
+// NewMockGitHubClient creates a new mock instance.
+func NewMockGitHubClient(ctrl *gomock.Controller) *MockGitHubClient {
+	mock := &MockGitHubClient{ctrl: ctrl}
+	mock.recorder = &MockGitHubClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitHubClient) EXPECT() *MockGitHubClientMockRecorder {
+	return m.recorder
+}
+
+// GetAuthenticatedUser mocks base method.
+func (m *MockGitHubClient) GetAuthenticatedUser(ctx context.Context, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthenticatedUser", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthenticatedUser indicates an expected call of GetAuthenticatedUser.
+func (mr *MockGitHubClientMockRecorder) GetAuthenticatedUser(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthenticatedUser", reflect.TypeOf((*MockGitHubClient)(nil).GetAuthenticatedUser), ctx, token)
+}
+
+// GetIssue mocks base method.
+func (m *MockGitHubClient) GetIssue(ctx context.Context, token string, ref domain.IssueRef) (*domain.IssueInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssue", ctx, token, ref)
+	ret0, _ := ret[0].(*domain.IssueInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssue indicates an expected call of GetIssue.
+func (mr *MockGitHubClientMockRecorder) GetIssue(ctx, token, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssue", reflect.TypeOf((*MockGitHubClient)(nil).GetIssue), ctx, token, ref)
+}
+
+// ParseWebhook mocks base method.
+func (m *MockGitHubClient) ParseWebhook(payload []byte, event, signature string) (*domain.IssueInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseWebhook", payload, event, signature)
+	ret0, _ := ret[0].(*domain.IssueInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseWebhook indicates an expected call of ParseWebhook.
+func (mr *MockGitHubClientMockRecorder) ParseWebhook(payload, event, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseWebhook", reflect.TypeOf((*MockGitHubClient)(nil).ParseWebhook), payload, event, signature)
+}
+
+// MockTaskStatusChanger is a mock of TaskStatusChanger interface.
+type MockTaskStatusChanger struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskStatusChangerMockRecorder
+}
+
+// MockTaskStatusChangerMockRecorder is the mock recorder for MockTaskStatusChanger.
+type MockTaskStatusChangerMockRecorder struct {
+	mock *MockTaskStatusChanger
+}
+
+// NewMockTaskStatusChanger creates a new mock instance.
+func NewMockTaskStatusChanger(ctrl *gomock.Controller) *MockTaskStatusChanger {
+	mock := &MockTaskStatusChanger{ctrl: ctrl}
+	mock.recorder = &MockTaskStatusChangerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskStatusChanger) EXPECT() *MockTaskStatusChangerMockRecorder {
+	return m.recorder
+}
+
+// ChangeTaskStatus mocks base method.
+func (m *MockTaskStatusChanger) ChangeTaskStatus(ctx context.Context, taskID string, status domain.TaskStatus) (*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTaskStatus", ctx, taskID, status)
+	ret0, _ := ret[0].(*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeTaskStatus indicates an expected call of ChangeTaskStatus.
+func (mr *MockTaskStatusChangerMockRecorder) ChangeTaskStatus(ctx, taskID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTaskStatus", reflect.TypeOf((*MockTaskStatusChanger)(nil).ChangeTaskStatus), ctx, taskID, status)
+}
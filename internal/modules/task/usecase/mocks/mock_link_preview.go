@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: link_preview_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockLinkPreviewRepository is a mock of LinkPreviewRepository interface.
+type MockLinkPreviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkPreviewRepositoryMockRecorder
+}
+
+// MockLinkPreviewRepositoryMockRecorder is the mock recorder for MockLinkPreviewRepository.
+type MockLinkPreviewRepositoryMockRecorder struct {
+	mock *MockLinkPreviewRepository
+}
+
+// NewMockLinkPreviewRepository creates a new mock instance.
+func NewMockLinkPreviewRepository(ctrl *gomock.Controller) *MockLinkPreviewRepository {
+	mock := &MockLinkPreviewRepository{ctrl: ctrl}
+	mock.recorder = &MockLinkPreviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkPreviewRepository) EXPECT() *MockLinkPreviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLinkPreviews mocks base method.
+func (m *MockLinkPreviewRepository) GetLinkPreviews(ctx context.Context, urls []string) (map[string]*domain.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkPreviews", ctx, urls)
+	ret0, _ := ret[0].(map[string]*domain.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkPreviews indicates an expected call of GetLinkPreviews.
+func (mr *MockLinkPreviewRepositoryMockRecorder) GetLinkPreviews(ctx, urls interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkPreviews", reflect.TypeOf((*MockLinkPreviewRepository)(nil).GetLinkPreviews), ctx, urls)
+}
+
+// SaveLinkPreview mocks base method.
+func (m *MockLinkPreviewRepository) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLinkPreview", ctx, preview)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLinkPreview indicates an expected call of SaveLinkPreview.
+func (mr *MockLinkPreviewRepositoryMockRecorder) SaveLinkPreview(ctx, preview interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLinkPreview", reflect.TypeOf((*MockLinkPreviewRepository)(nil).SaveLinkPreview), ctx, preview)
+}
+
+// MockLinkPreviewFetcher is a mock of LinkPreviewFetcher interface.
+type MockLinkPreviewFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkPreviewFetcherMockRecorder
+}
+
+// MockLinkPreviewFetcherMockRecorder is the mock recorder for MockLinkPreviewFetcher.
+type MockLinkPreviewFetcherMockRecorder struct {
+	mock *MockLinkPreviewFetcher
+}
+
+// NewMockLinkPreviewFetcher creates a new mock instance.
+func NewMockLinkPreviewFetcher(ctrl *gomock.Controller) *MockLinkPreviewFetcher {
+	mock := &MockLinkPreviewFetcher{ctrl: ctrl}
+	mock.recorder = &MockLinkPreviewFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkPreviewFetcher) EXPECT() *MockLinkPreviewFetcherMockRecorder {
+	return m.recorder
+}
+
+// FetchLinkPreview mocks base method.
+func (m *MockLinkPreviewFetcher) FetchLinkPreview(ctx context.Context, url string) (*domain.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchLinkPreview", ctx, url)
+	ret0, _ := ret[0].(*domain.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchLinkPreview indicates an expected call of FetchLinkPreview.
+func (mr *MockLinkPreviewFetcherMockRecorder) FetchLinkPreview(ctx, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchLinkPreview", reflect.TypeOf((*MockLinkPreviewFetcher)(nil).FetchLinkPreview), ctx, url)
+}
+
+// MockLinkPreviewQueue is a mock of LinkPreviewQueue interface.
+type MockLinkPreviewQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkPreviewQueueMockRecorder
+}
+
+// MockLinkPreviewQueueMockRecorder is the mock recorder for MockLinkPreviewQueue.
+type MockLinkPreviewQueueMockRecorder struct {
+	mock *MockLinkPreviewQueue
+}
+
+// NewMockLinkPreviewQueue creates a new mock instance.
+func NewMockLinkPreviewQueue(ctrl *gomock.Controller) *MockLinkPreviewQueue {
+	mock := &MockLinkPreviewQueue{ctrl: ctrl}
+	mock.recorder = &MockLinkPreviewQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkPreviewQueue) EXPECT() *MockLinkPreviewQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockLinkPreviewQueue) Enqueue(urls []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enqueue", urls)
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockLinkPreviewQueueMockRecorder) Enqueue(urls interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockLinkPreviewQueue)(nil).Enqueue), urls)
+}
+
+// MockLinkPreviewProvider is a mock of LinkPreviewProvider interface.
+type MockLinkPreviewProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkPreviewProviderMockRecorder
+}
+
+// MockLinkPreviewProviderMockRecorder is the mock recorder for MockLinkPreviewProvider.
+type MockLinkPreviewProviderMockRecorder struct {
+	mock *MockLinkPreviewProvider
+}
+
+// NewMockLinkPreviewProvider creates a new mock instance.
+func NewMockLinkPreviewProvider(ctrl *gomock.Controller) *MockLinkPreviewProvider {
+	mock := &MockLinkPreviewProvider{ctrl: ctrl}
+	mock.recorder = &MockLinkPreviewProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkPreviewProvider) EXPECT() *MockLinkPreviewProviderMockRecorder {
+	return m.recorder
+}
+
+// Previews mocks base method.
+func (m *MockLinkPreviewProvider) Previews(ctx context.Context, text string) []*domain.LinkPreview {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Previews", ctx, text)
+	ret0, _ := ret[0].([]*domain.LinkPreview)
+	return ret0
+}
+
+// Previews indicates an expected call of Previews.
+func (mr *MockLinkPreviewProviderMockRecorder) Previews(ctx, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Previews", reflect.TypeOf((*MockLinkPreviewProvider)(nil).Previews), ctx, text)
+}
+
+// RequestPreviews mocks base method.
+func (m *MockLinkPreviewProvider) RequestPreviews(ctx context.Context, text string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestPreviews", ctx, text)
+}
+
+// RequestPreviews indicates an expected call of RequestPreviews.
+func (mr *MockLinkPreviewProviderMockRecorder) RequestPreviews(ctx, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPreviews", reflect.TypeOf((*MockLinkPreviewProvider)(nil).RequestPreviews), ctx, text)
+}
@@ -0,0 +1,271 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: location_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockLocationRepository is a mock of LocationRepository interface.
+type MockLocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationRepositoryMockRecorder
+}
+
+// MockLocationRepositoryMockRecorder is the mock recorder for MockLocationRepository.
+type MockLocationRepositoryMockRecorder struct {
+	mock *MockLocationRepository
+}
+
+// NewMockLocationRepository creates a new mock instance.
+func NewMockLocationRepository(ctrl *gomock.Controller) *MockLocationRepository {
+	mock := &MockLocationRepository{ctrl: ctrl}
+	mock.recorder = &MockLocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationRepository) EXPECT() *MockLocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateGeofence mocks base method.
+func (m *MockLocationRepository) CreateGeofence(ctx context.Context, geofence *domain.Geofence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGeofence", ctx, geofence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGeofence indicates an expected call of CreateGeofence.
+func (mr *MockLocationRepositoryMockRecorder) CreateGeofence(ctx, geofence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGeofence", reflect.TypeOf((*MockLocationRepository)(nil).CreateGeofence), ctx, geofence)
+}
+
+// DeleteGeofence mocks base method.
+func (m *MockLocationRepository) DeleteGeofence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGeofence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGeofence indicates an expected call of DeleteGeofence.
+func (mr *MockLocationRepositoryMockRecorder) DeleteGeofence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGeofence", reflect.TypeOf((*MockLocationRepository)(nil).DeleteGeofence), ctx, id)
+}
+
+// DeleteGeofencesByTask mocks base method.
+func (m *MockLocationRepository) DeleteGeofencesByTask(ctx context.Context, taskID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGeofencesByTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGeofencesByTask indicates an expected call of DeleteGeofencesByTask.
+func (mr *MockLocationRepositoryMockRecorder) DeleteGeofencesByTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGeofencesByTask", reflect.TypeOf((*MockLocationRepository)(nil).DeleteGeofencesByTask), ctx, taskID)
+}
+
+// DeleteTaskLocation mocks base method.
+func (m *MockLocationRepository) DeleteTaskLocation(ctx context.Context, taskID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskLocation", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskLocation indicates an expected call of DeleteTaskLocation.
+func (mr *MockLocationRepositoryMockRecorder) DeleteTaskLocation(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskLocation", reflect.TypeOf((*MockLocationRepository)(nil).DeleteTaskLocation), ctx, taskID)
+}
+
+// GetGeofence mocks base method.
+func (m *MockLocationRepository) GetGeofence(ctx context.Context, id string) (*domain.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofence", ctx, id)
+	ret0, _ := ret[0].(*domain.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofence indicates an expected call of GetGeofence.
+func (mr *MockLocationRepositoryMockRecorder) GetGeofence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofence", reflect.TypeOf((*MockLocationRepository)(nil).GetGeofence), ctx, id)
+}
+
+// GetTaskLocation mocks base method.
+func (m *MockLocationRepository) GetTaskLocation(ctx context.Context, taskID string) (*domain.TaskLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskLocation", ctx, taskID)
+	ret0, _ := ret[0].(*domain.TaskLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskLocation indicates an expected call of GetTaskLocation.
+func (mr *MockLocationRepositoryMockRecorder) GetTaskLocation(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLocation", reflect.TypeOf((*MockLocationRepository)(nil).GetTaskLocation), ctx, taskID)
+}
+
+// GetTaskLocations mocks base method.
+func (m *MockLocationRepository) GetTaskLocations(ctx context.Context, taskIDs []string) (map[string]*domain.TaskLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskLocations", ctx, taskIDs)
+	ret0, _ := ret[0].(map[string]*domain.TaskLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskLocations indicates an expected call of GetTaskLocations.
+func (mr *MockLocationRepositoryMockRecorder) GetTaskLocations(ctx, taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLocations", reflect.TypeOf((*MockLocationRepository)(nil).GetTaskLocations), ctx, taskIDs)
+}
+
+// ListGeofencesByUser mocks base method.
+func (m *MockLocationRepository) ListGeofencesByUser(ctx context.Context, userID string) ([]*domain.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGeofencesByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGeofencesByUser indicates an expected call of ListGeofencesByUser.
+func (mr *MockLocationRepositoryMockRecorder) ListGeofencesByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeofencesByUser", reflect.TypeOf((*MockLocationRepository)(nil).ListGeofencesByUser), ctx, userID)
+}
+
+// ListTaskLocationsInBounds mocks base method.
+func (m *MockLocationRepository) ListTaskLocationsInBounds(ctx context.Context, bounds domain.GeoBounds) ([]*domain.TaskLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskLocationsInBounds", ctx, bounds)
+	ret0, _ := ret[0].([]*domain.TaskLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskLocationsInBounds indicates an expected call of ListTaskLocationsInBounds.
+func (mr *MockLocationRepositoryMockRecorder) ListTaskLocationsInBounds(ctx, bounds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskLocationsInBounds", reflect.TypeOf((*MockLocationRepository)(nil).ListTaskLocationsInBounds), ctx, bounds)
+}
+
+// SetTaskLocation mocks base method.
+func (m *MockLocationRepository) SetTaskLocation(ctx context.Context, location *domain.TaskLocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskLocation", ctx, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskLocation indicates an expected call of SetTaskLocation.
+func (mr *MockLocationRepositoryMockRecorder) SetTaskLocation(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskLocation", reflect.TypeOf((*MockLocationRepository)(nil).SetTaskLocation), ctx, location)
+}
+
+// UpdateGeofenceTriggeredAt mocks base method.
+func (m *MockLocationRepository) UpdateGeofenceTriggeredAt(ctx context.Context, id string, triggeredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGeofenceTriggeredAt", ctx, id, triggeredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGeofenceTriggeredAt indicates an expected call of UpdateGeofenceTriggeredAt.
+func (mr *MockLocationRepositoryMockRecorder) UpdateGeofenceTriggeredAt(ctx, id, triggeredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGeofenceTriggeredAt", reflect.TypeOf((*MockLocationRepository)(nil).UpdateGeofenceTriggeredAt), ctx, id, triggeredAt)
+}
+
+// MockGeofenceNotifier is a mock of GeofenceNotifier interface.
+type MockGeofenceNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeofenceNotifierMockRecorder
+}
+
+// MockGeofenceNotifierMockRecorder is the mock recorder for MockGeofenceNotifier.
+type MockGeofenceNotifierMockRecorder struct {
+	mock *MockGeofenceNotifier
+}
+
+// NewMockGeofenceNotifier creates a new mock instance.
+func NewMockGeofenceNotifier(ctrl *gomock.Controller) *MockGeofenceNotifier {
+	mock := &MockGeofenceNotifier{ctrl: ctrl}
+	mock.recorder = &MockGeofenceNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeofenceNotifier) EXPECT() *MockGeofenceNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyGeofenceEnter mocks base method.
+func (m *MockGeofenceNotifier) NotifyGeofenceEnter(ctx context.Context, userID string, task *domain.Task, location domain.Location) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyGeofenceEnter", ctx, userID, task, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyGeofenceEnter indicates an expected call of NotifyGeofenceEnter.
+func (mr *MockGeofenceNotifierMockRecorder) NotifyGeofenceEnter(ctx, userID, task, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyGeofenceEnter", reflect.TypeOf((*MockGeofenceNotifier)(nil).NotifyGeofenceEnter), ctx, userID, task, location)
+}
+
+// MockTaskLocationProvider is a mock of TaskLocationProvider interface.
+type MockTaskLocationProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskLocationProviderMockRecorder
+}
+
+// MockTaskLocationProviderMockRecorder is the mock recorder for MockTaskLocationProvider.
+type MockTaskLocationProviderMockRecorder struct {
+	mock *MockTaskLocationProvider
+}
+
+// NewMockTaskLocationProvider creates a new mock instance.
+func NewMockTaskLocationProvider(ctrl *gomock.Controller) *MockTaskLocationProvider {
+	mock := &MockTaskLocationProvider{ctrl: ctrl}
+	mock.recorder = &MockTaskLocationProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskLocationProvider) EXPECT() *MockTaskLocationProviderMockRecorder {
+	return m.recorder
+}
+
+// GetTaskLocation mocks base method.
+func (m *MockTaskLocationProvider) GetTaskLocation(ctx context.Context, taskID string) (*domain.TaskLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskLocation", ctx, taskID)
+	ret0, _ := ret[0].(*domain.TaskLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskLocation indicates an expected call of GetTaskLocation.
+func (mr *MockTaskLocationProviderMockRecorder) GetTaskLocation(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLocation", reflect.TypeOf((*MockTaskLocationProvider)(nil).GetTaskLocation), ctx, taskID)
+}
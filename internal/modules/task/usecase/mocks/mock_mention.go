@@ -0,0 +1,262 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mention_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockCommentRepository is a mock of CommentRepository interface.
+type MockCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepositoryMockRecorder
+}
+
+// MockCommentRepositoryMockRecorder is the mock recorder for MockCommentRepository.
+type MockCommentRepositoryMockRecorder struct {
+	mock *MockCommentRepository
+}
+
+// NewMockCommentRepository creates a new mock instance.
+func NewMockCommentRepository(ctrl *gomock.Controller) *MockCommentRepository {
+	mock := &MockCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentRepository) CreateComment(ctx context.Context, comment *domain.TaskComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentRepositoryMockRecorder) CreateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentRepository)(nil).CreateComment), ctx, comment)
+}
+
+// ListComments mocks base method.
+func (m *MockCommentRepository) ListComments(ctx context.Context, taskID string, pagination domain.Pagination) ([]*domain.TaskComment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", ctx, taskID, pagination)
+	ret0, _ := ret[0].([]*domain.TaskComment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockCommentRepositoryMockRecorder) ListComments(ctx, taskID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockCommentRepository)(nil).ListComments), ctx, taskID, pagination)
+}
+
+// ListCommentsByUser mocks base method.
+func (m *MockCommentRepository) ListCommentsByUser(ctx context.Context, userID string) ([]*domain.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsByUser indicates an expected call of ListCommentsByUser.
+func (mr *MockCommentRepositoryMockRecorder) ListCommentsByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsByUser", reflect.TypeOf((*MockCommentRepository)(nil).ListCommentsByUser), ctx, userID)
+}
+
+// MockMentionRepository is a mock of MentionRepository interface.
+type MockMentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionRepositoryMockRecorder
+}
+
+// MockMentionRepositoryMockRecorder is the mock recorder for MockMentionRepository.
+type MockMentionRepositoryMockRecorder struct {
+	mock *MockMentionRepository
+}
+
+// NewMockMentionRepository creates a new mock instance.
+func NewMockMentionRepository(ctrl *gomock.Controller) *MockMentionRepository {
+	mock := &MockMentionRepository{ctrl: ctrl}
+	mock.recorder = &MockMentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionRepository) EXPECT() *MockMentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListMentionsForUser mocks base method.
+func (m *MockMentionRepository) ListMentionsForUser(ctx context.Context, userID string, pagination domain.Pagination) ([]*domain.Mention, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMentionsForUser", ctx, userID, pagination)
+	ret0, _ := ret[0].([]*domain.Mention)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMentionsForUser indicates an expected call of ListMentionsForUser.
+func (mr *MockMentionRepositoryMockRecorder) ListMentionsForUser(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMentionsForUser", reflect.TypeOf((*MockMentionRepository)(nil).ListMentionsForUser), ctx, userID, pagination)
+}
+
+// SaveMentions mocks base method.
+func (m *MockMentionRepository) SaveMentions(ctx context.Context, mentions []*domain.Mention) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMentions", ctx, mentions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMentions indicates an expected call of SaveMentions.
+func (mr *MockMentionRepositoryMockRecorder) SaveMentions(ctx, mentions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMentions", reflect.TypeOf((*MockMentionRepository)(nil).SaveMentions), ctx, mentions)
+}
+
+// MockMentionDirectory is a mock of MentionDirectory interface.
+type MockMentionDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionDirectoryMockRecorder
+}
+
+// MockMentionDirectoryMockRecorder is the mock recorder for MockMentionDirectory.
+type MockMentionDirectoryMockRecorder struct {
+	mock *MockMentionDirectory
+}
+
+// NewMockMentionDirectory creates a new mock instance.
+func NewMockMentionDirectory(ctrl *gomock.Controller) *MockMentionDirectory {
+	mock := &MockMentionDirectory{ctrl: ctrl}
+	mock.recorder = &MockMentionDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionDirectory) EXPECT() *MockMentionDirectoryMockRecorder {
+	return m.recorder
+}
+
+// FilterVisibleUsers mocks base method.
+func (m *MockMentionDirectory) FilterVisibleUsers(ctx context.Context, authorID string, userIDs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterVisibleUsers", ctx, authorID, userIDs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterVisibleUsers indicates an expected call of FilterVisibleUsers.
+func (mr *MockMentionDirectoryMockRecorder) FilterVisibleUsers(ctx, authorID, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterVisibleUsers", reflect.TypeOf((*MockMentionDirectory)(nil).FilterVisibleUsers), ctx, authorID, userIDs)
+}
+
+// ResolveUsernames mocks base method.
+func (m *MockMentionDirectory) ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveUsernames", ctx, usernames)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveUsernames indicates an expected call of ResolveUsernames.
+func (mr *MockMentionDirectoryMockRecorder) ResolveUsernames(ctx, usernames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveUsernames", reflect.TypeOf((*MockMentionDirectory)(nil).ResolveUsernames), ctx, usernames)
+}
+
+// MockMentionNotifier is a mock of MentionNotifier interface.
+type MockMentionNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionNotifierMockRecorder
+}
+
+// MockMentionNotifierMockRecorder is the mock recorder for MockMentionNotifier.
+type MockMentionNotifierMockRecorder struct {
+	mock *MockMentionNotifier
+}
+
+// NewMockMentionNotifier creates a new mock instance.
+func NewMockMentionNotifier(ctrl *gomock.Controller) *MockMentionNotifier {
+	mock := &MockMentionNotifier{ctrl: ctrl}
+	mock.recorder = &MockMentionNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionNotifier) EXPECT() *MockMentionNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyMentioned mocks base method.
+func (m *MockMentionNotifier) NotifyMentioned(ctx context.Context, task *domain.Task, mention *domain.Mention, excerpt string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyMentioned", ctx, task, mention, excerpt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyMentioned indicates an expected call of NotifyMentioned.
+func (mr *MockMentionNotifierMockRecorder) NotifyMentioned(ctx, task, mention, excerpt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyMentioned", reflect.TypeOf((*MockMentionNotifier)(nil).NotifyMentioned), ctx, task, mention, excerpt)
+}
+
+// MockMentionProcessor is a mock of MentionProcessor interface.
+type MockMentionProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionProcessorMockRecorder
+}
+
+// MockMentionProcessorMockRecorder is the mock recorder for MockMentionProcessor.
+type MockMentionProcessorMockRecorder struct {
+	mock *MockMentionProcessor
+}
+
+// NewMockMentionProcessor creates a new mock instance.
+func NewMockMentionProcessor(ctrl *gomock.Controller) *MockMentionProcessor {
+	mock := &MockMentionProcessor{ctrl: ctrl}
+	mock.recorder = &MockMentionProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionProcessor) EXPECT() *MockMentionProcessorMockRecorder {
+	return m.recorder
+}
+
+// ProcessMentions mocks base method.
+func (m *MockMentionProcessor) ProcessMentions(ctx context.Context, task *domain.Task, source domain.MentionSource, authorID, text, previousText string) ([]*domain.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessMentions", ctx, task, source, authorID, text, previousText)
+	ret0, _ := ret[0].([]*domain.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessMentions indicates an expected call of ProcessMentions.
+func (mr *MockMentionProcessorMockRecorder) ProcessMentions(ctx, task, source, authorID, text, previousText interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessMentions", reflect.TypeOf((*MockMentionProcessor)(nil).ProcessMentions), ctx, task, source, authorID, text, previousText)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: milestone_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockMilestoneRepository is a mock of MilestoneRepository interface.
+type MockMilestoneRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMilestoneRepositoryMockRecorder
+}
+
+// MockMilestoneRepositoryMockRecorder is the mock recorder for MockMilestoneRepository.
+type MockMilestoneRepositoryMockRecorder struct {
+	mock *MockMilestoneRepository
+}
+
+// NewMockMilestoneRepository creates a new mock instance.
+func NewMockMilestoneRepository(ctrl *gomock.Controller) *MockMilestoneRepository {
+	mock := &MockMilestoneRepository{ctrl: ctrl}
+	mock.recorder = &MockMilestoneRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMilestoneRepository) EXPECT() *MockMilestoneRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMilestone mocks base method.
+func (m *MockMilestoneRepository) CreateMilestone(ctx context.Context, milestone *domain.Milestone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMilestone", ctx, milestone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMilestone indicates an expected call of CreateMilestone.
+func (mr *MockMilestoneRepositoryMockRecorder) CreateMilestone(ctx, milestone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMilestone", reflect.TypeOf((*MockMilestoneRepository)(nil).CreateMilestone), ctx, milestone)
+}
+
+// DeleteMilestone mocks base method.
+func (m *MockMilestoneRepository) DeleteMilestone(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMilestone", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMilestone indicates an expected call of DeleteMilestone.
+func (mr *MockMilestoneRepositoryMockRecorder) DeleteMilestone(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMilestone", reflect.TypeOf((*MockMilestoneRepository)(nil).DeleteMilestone), ctx, id)
+}
+
+// GetMilestoneByID mocks base method.
+func (m *MockMilestoneRepository) GetMilestoneByID(ctx context.Context, id string) (*domain.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestoneByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestoneByID indicates an expected call of GetMilestoneByID.
+func (mr *MockMilestoneRepositoryMockRecorder) GetMilestoneByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestoneByID", reflect.TypeOf((*MockMilestoneRepository)(nil).GetMilestoneByID), ctx, id)
+}
+
+// GetMilestoneIDForTask mocks base method.
+func (m *MockMilestoneRepository) GetMilestoneIDForTask(ctx context.Context, taskID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestoneIDForTask", ctx, taskID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestoneIDForTask indicates an expected call of GetMilestoneIDForTask.
+func (mr *MockMilestoneRepositoryMockRecorder) GetMilestoneIDForTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestoneIDForTask", reflect.TypeOf((*MockMilestoneRepository)(nil).GetMilestoneIDForTask), ctx, taskID)
+}
+
+// ListMilestoneTaskIDs mocks base method.
+func (m *MockMilestoneRepository) ListMilestoneTaskIDs(ctx context.Context, milestoneID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMilestoneTaskIDs", ctx, milestoneID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMilestoneTaskIDs indicates an expected call of ListMilestoneTaskIDs.
+func (mr *MockMilestoneRepositoryMockRecorder) ListMilestoneTaskIDs(ctx, milestoneID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMilestoneTaskIDs", reflect.TypeOf((*MockMilestoneRepository)(nil).ListMilestoneTaskIDs), ctx, milestoneID)
+}
+
+// ListMilestonesByGroup mocks base method.
+func (m *MockMilestoneRepository) ListMilestonesByGroup(ctx context.Context, groupID string) ([]*domain.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMilestonesByGroup", ctx, groupID)
+	ret0, _ := ret[0].([]*domain.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMilestonesByGroup indicates an expected call of ListMilestonesByGroup.
+func (mr *MockMilestoneRepositoryMockRecorder) ListMilestonesByGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMilestonesByGroup", reflect.TypeOf((*MockMilestoneRepository)(nil).ListMilestonesByGroup), ctx, groupID)
+}
+
+// RemoveTaskMilestone mocks base method.
+func (m *MockMilestoneRepository) RemoveTaskMilestone(ctx context.Context, taskID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskMilestone", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskMilestone indicates an expected call of RemoveTaskMilestone.
+func (mr *MockMilestoneRepositoryMockRecorder) RemoveTaskMilestone(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskMilestone", reflect.TypeOf((*MockMilestoneRepository)(nil).RemoveTaskMilestone), ctx, taskID)
+}
+
+// SetTaskMilestone mocks base method.
+func (m *MockMilestoneRepository) SetTaskMilestone(ctx context.Context, taskID, milestoneID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskMilestone", ctx, taskID, milestoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskMilestone indicates an expected call of SetTaskMilestone.
+func (mr *MockMilestoneRepositoryMockRecorder) SetTaskMilestone(ctx, taskID, milestoneID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskMilestone", reflect.TypeOf((*MockMilestoneRepository)(nil).SetTaskMilestone), ctx, taskID, milestoneID)
+}
+
+// UpdateMilestone mocks base method.
+func (m *MockMilestoneRepository) UpdateMilestone(ctx context.Context, milestone *domain.Milestone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMilestone", ctx, milestone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMilestone indicates an expected call of UpdateMilestone.
+func (mr *MockMilestoneRepositoryMockRecorder) UpdateMilestone(ctx, milestone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMilestone", reflect.TypeOf((*MockMilestoneRepository)(nil).UpdateMilestone), ctx, milestone)
+}
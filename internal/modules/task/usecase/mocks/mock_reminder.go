@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminder_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockDeferredReminderRepository is a mock of DeferredReminderRepository interface.
+type MockDeferredReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeferredReminderRepositoryMockRecorder
+}
+
+// MockDeferredReminderRepositoryMockRecorder is the mock recorder for MockDeferredReminderRepository.
+type MockDeferredReminderRepositoryMockRecorder struct {
+	mock *MockDeferredReminderRepository
+}
+
+// NewMockDeferredReminderRepository creates a new mock instance.
+func NewMockDeferredReminderRepository(ctrl *gomock.Controller) *MockDeferredReminderRepository {
+	mock := &MockDeferredReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockDeferredReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeferredReminderRepository) EXPECT() *MockDeferredReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteDeferredReminders mocks base method.
+func (m *MockDeferredReminderRepository) DeleteDeferredReminders(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeferredReminders", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeferredReminders indicates an expected call of DeleteDeferredReminders.
+func (mr *MockDeferredReminderRepositoryMockRecorder) DeleteDeferredReminders(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeferredReminders", reflect.TypeOf((*MockDeferredReminderRepository)(nil).DeleteDeferredReminders), ctx, ids)
+}
+
+// ListDueDeferredReminders mocks base method.
+func (m *MockDeferredReminderRepository) ListDueDeferredReminders(ctx context.Context, until time.Time, limit int) ([]*domain.DeferredReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueDeferredReminders", ctx, until, limit)
+	ret0, _ := ret[0].([]*domain.DeferredReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueDeferredReminders indicates an expected call of ListDueDeferredReminders.
+func (mr *MockDeferredReminderRepositoryMockRecorder) ListDueDeferredReminders(ctx, until, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueDeferredReminders", reflect.TypeOf((*MockDeferredReminderRepository)(nil).ListDueDeferredReminders), ctx, until, limit)
+}
+
+// SaveDeferredReminder mocks base method.
+func (m *MockDeferredReminderRepository) SaveDeferredReminder(ctx context.Context, reminder *domain.DeferredReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeferredReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeferredReminder indicates an expected call of SaveDeferredReminder.
+func (mr *MockDeferredReminderRepositoryMockRecorder) SaveDeferredReminder(ctx, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeferredReminder", reflect.TypeOf((*MockDeferredReminderRepository)(nil).SaveDeferredReminder), ctx, reminder)
+}
+
+// MockReminderDigestNotifier is a mock of ReminderDigestNotifier interface.
+type MockReminderDigestNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderDigestNotifierMockRecorder
+}
+
+// MockReminderDigestNotifierMockRecorder is the mock recorder for MockReminderDigestNotifier.
+type MockReminderDigestNotifierMockRecorder struct {
+	mock *MockReminderDigestNotifier
+}
+
+// NewMockReminderDigestNotifier creates a new mock instance.
+func NewMockReminderDigestNotifier(ctrl *gomock.Controller) *MockReminderDigestNotifier {
+	mock := &MockReminderDigestNotifier{ctrl: ctrl}
+	mock.recorder = &MockReminderDigestNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderDigestNotifier) EXPECT() *MockReminderDigestNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyReminderDigest mocks base method.
+func (m *MockReminderDigestNotifier) NotifyReminderDigest(ctx context.Context, userID string, reminders []*domain.DeferredReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyReminderDigest", ctx, userID, reminders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyReminderDigest indicates an expected call of NotifyReminderDigest.
+func (mr *MockReminderDigestNotifierMockRecorder) NotifyReminderDigest(ctx, userID, reminders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyReminderDigest", reflect.TypeOf((*MockReminderDigestNotifier)(nil).NotifyReminderDigest), ctx, userID, reminders)
+}
+
+// MockReminderScheduler is a mock of ReminderScheduler interface.
+type MockReminderScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderSchedulerMockRecorder
+}
+
+// MockReminderSchedulerMockRecorder is the mock recorder for MockReminderScheduler.
+type MockReminderSchedulerMockRecorder struct {
+	mock *MockReminderScheduler
+}
+
+// NewMockReminderScheduler creates a new mock instance.
+func NewMockReminderScheduler(ctrl *gomock.Controller) *MockReminderScheduler {
+	mock := &MockReminderScheduler{ctrl: ctrl}
+	mock.recorder = &MockReminderSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderScheduler) EXPECT() *MockReminderSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Defer mocks base method.
+func (m *MockReminderScheduler) Defer(ctx context.Context, reminder *domain.DeferredReminder, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Defer", ctx, reminder, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Defer indicates an expected call of Defer.
+func (mr *MockReminderSchedulerMockRecorder) Defer(ctx, reminder, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Defer", reflect.TypeOf((*MockReminderScheduler)(nil).Defer), ctx, reminder, now)
+}
@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: share_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockSharePasswordAttemptStore is a mock of SharePasswordAttemptStore interface.
+type MockSharePasswordAttemptStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSharePasswordAttemptStoreMockRecorder
+}
+
+// MockSharePasswordAttemptStoreMockRecorder is the mock recorder for MockSharePasswordAttemptStore.
+type MockSharePasswordAttemptStoreMockRecorder struct {
+	mock *MockSharePasswordAttemptStore
+}
+
+// NewMockSharePasswordAttemptStore creates a new mock instance.
+func NewMockSharePasswordAttemptStore(ctrl *gomock.Controller) *MockSharePasswordAttemptStore {
+	mock := &MockSharePasswordAttemptStore{ctrl: ctrl}
+	mock.recorder = &MockSharePasswordAttemptStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSharePasswordAttemptStore) EXPECT() *MockSharePasswordAttemptStoreMockRecorder {
+	return m.recorder
+}
+
+// ReserveAttempt mocks base method.
+func (m *MockSharePasswordAttemptStore) ReserveAttempt(ctx context.Context, linkID string, ttl time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveAttempt", ctx, linkID, ttl)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveAttempt indicates an expected call of ReserveAttempt.
+func (mr *MockSharePasswordAttemptStoreMockRecorder) ReserveAttempt(ctx, linkID, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveAttempt", reflect.TypeOf((*MockSharePasswordAttemptStore)(nil).ReserveAttempt), ctx, linkID, ttl)
+}
+
+// ResetAttempts mocks base method.
+func (m *MockSharePasswordAttemptStore) ResetAttempts(ctx context.Context, linkID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAttempts", ctx, linkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAttempts indicates an expected call of ResetAttempts.
+func (mr *MockSharePasswordAttemptStoreMockRecorder) ResetAttempts(ctx, linkID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockSharePasswordAttemptStore)(nil).ResetAttempts), ctx, linkID)
+}
+
+// MockShareLinkRepository is a mock of ShareLinkRepository interface.
+type MockShareLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkRepositoryMockRecorder
+}
+
+// MockShareLinkRepositoryMockRecorder is the mock recorder for MockShareLinkRepository.
+type MockShareLinkRepositoryMockRecorder struct {
+	mock *MockShareLinkRepository
+}
+
+// NewMockShareLinkRepository creates a new mock instance.
+func NewMockShareLinkRepository(ctrl *gomock.Controller) *MockShareLinkRepository {
+	mock := &MockShareLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockShareLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareLinkRepository) EXPECT() *MockShareLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountActiveShareLinks mocks base method.
+func (m *MockShareLinkRepository) CountActiveShareLinks(ctx context.Context, ownerID string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveShareLinks", ctx, ownerID, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveShareLinks indicates an expected call of CountActiveShareLinks.
+func (mr *MockShareLinkRepositoryMockRecorder) CountActiveShareLinks(ctx, ownerID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveShareLinks", reflect.TypeOf((*MockShareLinkRepository)(nil).CountActiveShareLinks), ctx, ownerID, now)
+}
+
+// CreateShareLink mocks base method.
+func (m *MockShareLinkRepository) CreateShareLink(ctx context.Context, link *domain.ShareLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShareLink indicates an expected call of CreateShareLink.
+func (mr *MockShareLinkRepositoryMockRecorder) CreateShareLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareLink", reflect.TypeOf((*MockShareLinkRepository)(nil).CreateShareLink), ctx, link)
+}
+
+// GetShareLinkByID mocks base method.
+func (m *MockShareLinkRepository) GetShareLinkByID(ctx context.Context, id string) (*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareLinkByID", ctx, id)
+	ret0, _ := ret[0].(*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareLinkByID indicates an expected call of GetShareLinkByID.
+func (mr *MockShareLinkRepositoryMockRecorder) GetShareLinkByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinkByID", reflect.TypeOf((*MockShareLinkRepository)(nil).GetShareLinkByID), ctx, id)
+}
+
+// GetShareLinkByToken mocks base method.
+func (m *MockShareLinkRepository) GetShareLinkByToken(ctx context.Context, token string) (*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareLinkByToken", ctx, token)
+	ret0, _ := ret[0].(*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareLinkByToken indicates an expected call of GetShareLinkByToken.
+func (mr *MockShareLinkRepositoryMockRecorder) GetShareLinkByToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinkByToken", reflect.TypeOf((*MockShareLinkRepository)(nil).GetShareLinkByToken), ctx, token)
+}
+
+// ListShareLinksByOwner mocks base method.
+func (m *MockShareLinkRepository) ListShareLinksByOwner(ctx context.Context, ownerID string) ([]*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShareLinksByOwner", ctx, ownerID)
+	ret0, _ := ret[0].([]*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShareLinksByOwner indicates an expected call of ListShareLinksByOwner.
+func (mr *MockShareLinkRepositoryMockRecorder) ListShareLinksByOwner(ctx, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShareLinksByOwner", reflect.TypeOf((*MockShareLinkRepository)(nil).ListShareLinksByOwner), ctx, ownerID)
+}
+
+// RevokeShareLink mocks base method.
+func (m *MockShareLinkRepository) RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShareLink", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShareLink indicates an expected call of RevokeShareLink.
+func (mr *MockShareLinkRepositoryMockRecorder) RevokeShareLink(ctx, id, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShareLink", reflect.TypeOf((*MockShareLinkRepository)(nil).RevokeShareLink), ctx, id, revokedAt)
+}
+
+// MockShareURLShortener is a mock of ShareURLShortener interface.
+type MockShareURLShortener struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareURLShortenerMockRecorder
+}
+
+// MockShareURLShortenerMockRecorder is the mock recorder for MockShareURLShortener.
+type MockShareURLShortenerMockRecorder struct {
+	mock *MockShareURLShortener
+}
+
+// NewMockShareURLShortener creates a new mock instance.
+func NewMockShareURLShortener(ctrl *gomock.Controller) *MockShareURLShortener {
+	mock := &MockShareURLShortener{ctrl: ctrl}
+	mock.recorder = &MockShareURLShortenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareURLShortener) EXPECT() *MockShareURLShortenerMockRecorder {
+	return m.recorder
+}
+
+// ShortenShareURL mocks base method.
+func (m *MockShareURLShortener) ShortenShareURL(ctx context.Context, link *domain.ShareLink) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortenShareURL", ctx, link)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShortenShareURL indicates an expected call of ShortenShareURL.
+func (mr *MockShareURLShortenerMockRecorder) ShortenShareURL(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortenShareURL", reflect.TypeOf((*MockShareURLShortener)(nil).ShortenShareURL), ctx, link)
+}
@@ -36,6 +36,21 @@ func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetCompletedTasksByDateRange mocks base method.
+func (m *MockStatsRepository) GetCompletedTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedTasksByDateRange", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedTasksByDateRange indicates an expected call of GetCompletedTasksByDateRange.
+func (mr *MockStatsRepositoryMockRecorder) GetCompletedTasksByDateRange(ctx, userID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedTasksByDateRange", reflect.TypeOf((*MockStatsRepository)(nil).GetCompletedTasksByDateRange), ctx, userID, start, end)
+}
+
 // GetOverdueTasksCount mocks base method.
 func (m *MockStatsRepository) GetOverdueTasksCount(ctx context.Context, userID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,21 @@ func (mr *MockStatsRepositoryMockRecorder) GetRecentCompletedTasks(ctx, userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentCompletedTasks", reflect.TypeOf((*MockStatsRepository)(nil).GetRecentCompletedTasks), ctx, userID, limit)
 }
 
+// GetScheduledTasksByDateRange mocks base method.
+func (m *MockStatsRepository) GetScheduledTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTasksByDateRange", ctx, userID, start, end)
+	ret0, _ := ret[0].([]*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTasksByDateRange indicates an expected call of GetScheduledTasksByDateRange.
+func (mr *MockStatsRepositoryMockRecorder) GetScheduledTasksByDateRange(ctx, userID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTasksByDateRange", reflect.TypeOf((*MockStatsRepository)(nil).GetScheduledTasksByDateRange), ctx, userID, start, end)
+}
+
 // GetTasksByDateRange mocks base method.
 func (m *MockStatsRepository) GetTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -94,4 +124,42 @@ func (m *MockStatsRepository) GetTasksByDueDate(ctx context.Context, userID stri
 func (mr *MockStatsRepositoryMockRecorder) GetTasksByDueDate(ctx, userID, dueDate interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByDueDate", reflect.TypeOf((*MockStatsRepository)(nil).GetTasksByDueDate), ctx, userID, dueDate)
-}
\ No newline at end of file
+}
+
+// MockDailyStatsSource is a mock of DailyStatsSource interface.
+type MockDailyStatsSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockDailyStatsSourceMockRecorder
+}
+
+// MockDailyStatsSourceMockRecorder is the mock recorder for MockDailyStatsSource.
+type MockDailyStatsSourceMockRecorder struct {
+	mock *MockDailyStatsSource
+}
+
+// NewMockDailyStatsSource creates a new mock instance.
+func NewMockDailyStatsSource(ctrl *gomock.Controller) *MockDailyStatsSource {
+	mock := &MockDailyStatsSource{ctrl: ctrl}
+	mock.recorder = &MockDailyStatsSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDailyStatsSource) EXPECT() *MockDailyStatsSourceMockRecorder {
+	return m.recorder
+}
+
+// GetDailyStatsRange mocks base method.
+func (m *MockDailyStatsSource) GetDailyStatsRange(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyStatsRange", ctx, userID, from, to)
+	ret0, _ := ret[0].([]*domain.DailyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyStatsRange indicates an expected call of GetDailyStatsRange.
+func (mr *MockDailyStatsSourceMockRecorder) GetDailyStatsRange(ctx, userID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyStatsRange", reflect.TypeOf((*MockDailyStatsSource)(nil).GetDailyStatsRange), ctx, userID, from, to)
+}
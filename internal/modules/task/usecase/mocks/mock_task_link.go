@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_link_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockTaskLinkRepository is a mock of TaskLinkRepository interface.
+type MockTaskLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskLinkRepositoryMockRecorder
+}
+
+// MockTaskLinkRepositoryMockRecorder is the mock recorder for MockTaskLinkRepository.
+type MockTaskLinkRepositoryMockRecorder struct {
+	mock *MockTaskLinkRepository
+}
+
+// NewMockTaskLinkRepository creates a new mock instance.
+func NewMockTaskLinkRepository(ctrl *gomock.Controller) *MockTaskLinkRepository {
+	mock := &MockTaskLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskLinkRepository) EXPECT() *MockTaskLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskLink mocks base method.
+func (m *MockTaskLinkRepository) CreateTaskLink(ctx context.Context, link *domain.TaskLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskLink indicates an expected call of CreateTaskLink.
+func (mr *MockTaskLinkRepositoryMockRecorder) CreateTaskLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskLink", reflect.TypeOf((*MockTaskLinkRepository)(nil).CreateTaskLink), ctx, link)
+}
+
+// DeleteTaskLink mocks base method.
+func (m *MockTaskLinkRepository) DeleteTaskLink(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskLink", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskLink indicates an expected call of DeleteTaskLink.
+func (mr *MockTaskLinkRepositoryMockRecorder) DeleteTaskLink(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskLink", reflect.TypeOf((*MockTaskLinkRepository)(nil).DeleteTaskLink), ctx, id)
+}
+
+// GetTaskLink mocks base method.
+func (m *MockTaskLinkRepository) GetTaskLink(ctx context.Context, id string) (*domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskLink", ctx, id)
+	ret0, _ := ret[0].(*domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskLink indicates an expected call of GetTaskLink.
+func (mr *MockTaskLinkRepositoryMockRecorder) GetTaskLink(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLink", reflect.TypeOf((*MockTaskLinkRepository)(nil).GetTaskLink), ctx, id)
+}
+
+// ListTaskLinksByTask mocks base method.
+func (m *MockTaskLinkRepository) ListTaskLinksByTask(ctx context.Context, taskID string) ([]*domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskLinksByTask", ctx, taskID)
+	ret0, _ := ret[0].([]*domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskLinksByTask indicates an expected call of ListTaskLinksByTask.
+func (mr *MockTaskLinkRepositoryMockRecorder) ListTaskLinksByTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskLinksByTask", reflect.TypeOf((*MockTaskLinkRepository)(nil).ListTaskLinksByTask), ctx, taskID)
+}
+
+// UpdateTaskLink mocks base method.
+func (m *MockTaskLinkRepository) UpdateTaskLink(ctx context.Context, link *domain.TaskLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskLink indicates an expected call of UpdateTaskLink.
+func (mr *MockTaskLinkRepositoryMockRecorder) UpdateTaskLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskLink", reflect.TypeOf((*MockTaskLinkRepository)(nil).UpdateTaskLink), ctx, link)
+}
+
+// MockTaskIssueLinker is a mock of TaskIssueLinker interface.
+type MockTaskIssueLinker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskIssueLinkerMockRecorder
+}
+
+// MockTaskIssueLinkerMockRecorder is the mock recorder for MockTaskIssueLinker.
+type MockTaskIssueLinkerMockRecorder struct {
+	mock *MockTaskIssueLinker
+}
+
+// NewMockTaskIssueLinker creates a new mock instance.
+func NewMockTaskIssueLinker(ctrl *gomock.Controller) *MockTaskIssueLinker {
+	mock := &MockTaskIssueLinker{ctrl: ctrl}
+	mock.recorder = &MockTaskIssueLinkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskIssueLinker) EXPECT() *MockTaskIssueLinkerMockRecorder {
+	return m.recorder
+}
+
+// LinkIssue mocks base method.
+func (m *MockTaskIssueLinker) LinkIssue(ctx context.Context, taskID, userID, rawRef string, completeOnClose *bool) (*domain.IssueLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIssue", ctx, taskID, userID, rawRef, completeOnClose)
+	ret0, _ := ret[0].(*domain.IssueLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkIssue indicates an expected call of LinkIssue.
+func (mr *MockTaskIssueLinkerMockRecorder) LinkIssue(ctx, taskID, userID, rawRef, completeOnClose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIssue", reflect.TypeOf((*MockTaskIssueLinker)(nil).LinkIssue), ctx, taskID, userID, rawRef, completeOnClose)
+}
+
+// ListIssueLinksByTask mocks base method.
+func (m *MockTaskIssueLinker) ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssueLinksByTask", ctx, taskID)
+	ret0, _ := ret[0].([]*domain.IssueLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssueLinksByTask indicates an expected call of ListIssueLinksByTask.
+func (mr *MockTaskIssueLinkerMockRecorder) ListIssueLinksByTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueLinksByTask", reflect.TypeOf((*MockTaskIssueLinker)(nil).ListIssueLinksByTask), ctx, taskID)
+}
+
+// UnlinkIssue mocks base method.
+func (m *MockTaskIssueLinker) UnlinkIssue(ctx context.Context, taskID, linkID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIssue", ctx, taskID, linkID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIssue indicates an expected call of UnlinkIssue.
+func (mr *MockTaskIssueLinkerMockRecorder) UnlinkIssue(ctx, taskID, linkID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIssue", reflect.TypeOf((*MockTaskIssueLinker)(nil).UnlinkIssue), ctx, taskID, linkID, userID)
+}
+
+// MockTaskLinkProvider is a mock of TaskLinkProvider interface.
+type MockTaskLinkProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskLinkProviderMockRecorder
+}
+
+// MockTaskLinkProviderMockRecorder is the mock recorder for MockTaskLinkProvider.
+type MockTaskLinkProviderMockRecorder struct {
+	mock *MockTaskLinkProvider
+}
+
+// NewMockTaskLinkProvider creates a new mock instance.
+func NewMockTaskLinkProvider(ctrl *gomock.Controller) *MockTaskLinkProvider {
+	mock := &MockTaskLinkProvider{ctrl: ctrl}
+	mock.recorder = &MockTaskLinkProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskLinkProvider) EXPECT() *MockTaskLinkProviderMockRecorder {
+	return m.recorder
+}
+
+// ListTaskLinks mocks base method.
+func (m *MockTaskLinkProvider) ListTaskLinks(ctx context.Context, taskID string) ([]*domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskLinks", ctx, taskID)
+	ret0, _ := ret[0].([]*domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskLinks indicates an expected call of ListTaskLinks.
+func (mr *MockTaskLinkProviderMockRecorder) ListTaskLinks(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskLinks", reflect.TypeOf((*MockTaskLinkProvider)(nil).ListTaskLinks), ctx, taskID)
+}
@@ -78,6 +78,21 @@ func (mr *MockTaskRepositoryMockRecorder) GetOverdueTasks(ctx interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueTasks", reflect.TypeOf((*MockTaskRepository)(nil).GetOverdueTasks), ctx)
 }
 
+// GetOverdueTasksByUser mocks base method.
+func (m *MockTaskRepository) GetOverdueTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueTasksByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueTasksByUser indicates an expected call of GetOverdueTasksByUser.
+func (mr *MockTaskRepositoryMockRecorder) GetOverdueTasksByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueTasksByUser", reflect.TypeOf((*MockTaskRepository)(nil).GetOverdueTasksByUser), ctx, userID)
+}
+
 // GetTaskByID mocks base method.
 func (m *MockTaskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -151,4 +166,4 @@ func (m *MockTaskRepository) UpdateTask(ctx context.Context, task *domain.Task)
 func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, task interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, task)
-}
\ No newline at end of file
+}
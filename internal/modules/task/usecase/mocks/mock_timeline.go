@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: timeline_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockDependencyRepository is a mock of DependencyRepository interface.
+type MockDependencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDependencyRepositoryMockRecorder
+}
+
+// MockDependencyRepositoryMockRecorder is the mock recorder for MockDependencyRepository.
+type MockDependencyRepositoryMockRecorder struct {
+	mock *MockDependencyRepository
+}
+
+// NewMockDependencyRepository creates a new mock instance.
+func NewMockDependencyRepository(ctrl *gomock.Controller) *MockDependencyRepository {
+	mock := &MockDependencyRepository{ctrl: ctrl}
+	mock.recorder = &MockDependencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDependencyRepository) EXPECT() *MockDependencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDependency mocks base method.
+func (m *MockDependencyRepository) AddDependency(ctx context.Context, dependency *domain.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockDependencyRepositoryMockRecorder) AddDependency(ctx, dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockDependencyRepository)(nil).AddDependency), ctx, dependency)
+}
+
+// ListDependencies mocks base method.
+func (m *MockDependencyRepository) ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependencies", ctx, taskIDs)
+	ret0, _ := ret[0].([]*domain.TaskDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependencies indicates an expected call of ListDependencies.
+func (mr *MockDependencyRepositoryMockRecorder) ListDependencies(ctx, taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependencies", reflect.TypeOf((*MockDependencyRepository)(nil).ListDependencies), ctx, taskIDs)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockDependencyRepository) RemoveDependency(ctx context.Context, taskID, dependsOnID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, taskID, dependsOnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockDependencyRepositoryMockRecorder) RemoveDependency(ctx, taskID, dependsOnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockDependencyRepository)(nil).RemoveDependency), ctx, taskID, dependsOnID)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: today_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUnreadNotificationCounter is a mock of UnreadNotificationCounter interface.
+type MockUnreadNotificationCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnreadNotificationCounterMockRecorder
+}
+
+// MockUnreadNotificationCounterMockRecorder is the mock recorder for MockUnreadNotificationCounter.
+type MockUnreadNotificationCounterMockRecorder struct {
+	mock *MockUnreadNotificationCounter
+}
+
+// NewMockUnreadNotificationCounter creates a new mock instance.
+func NewMockUnreadNotificationCounter(ctrl *gomock.Controller) *MockUnreadNotificationCounter {
+	mock := &MockUnreadNotificationCounter{ctrl: ctrl}
+	mock.recorder = &MockUnreadNotificationCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnreadNotificationCounter) EXPECT() *MockUnreadNotificationCounterMockRecorder {
+	return m.recorder
+}
+
+// GetUnreadNotificationCount mocks base method.
+func (m *MockUnreadNotificationCounter) GetUnreadNotificationCount(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadNotificationCount", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadNotificationCount indicates an expected call of GetUnreadNotificationCount.
+func (mr *MockUnreadNotificationCounterMockRecorder) GetUnreadNotificationCount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadNotificationCount", reflect.TypeOf((*MockUnreadNotificationCounter)(nil).GetUnreadNotificationCount), ctx, userID)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: weekly_report_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/common/domain"
+	domain0 "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockWeeklyReportSubscriptionRepository is a mock of WeeklyReportSubscriptionRepository interface.
+type MockWeeklyReportSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeeklyReportSubscriptionRepositoryMockRecorder
+}
+
+// MockWeeklyReportSubscriptionRepositoryMockRecorder is the mock recorder for MockWeeklyReportSubscriptionRepository.
+type MockWeeklyReportSubscriptionRepositoryMockRecorder struct {
+	mock *MockWeeklyReportSubscriptionRepository
+}
+
+// NewMockWeeklyReportSubscriptionRepository creates a new mock instance.
+func NewMockWeeklyReportSubscriptionRepository(ctrl *gomock.Controller) *MockWeeklyReportSubscriptionRepository {
+	mock := &MockWeeklyReportSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockWeeklyReportSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeeklyReportSubscriptionRepository) EXPECT() *MockWeeklyReportSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWeeklyReportSubscriptionRepository) DeleteSubscription(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWeeklyReportSubscriptionRepositoryMockRecorder) DeleteSubscription(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWeeklyReportSubscriptionRepository)(nil).DeleteSubscription), ctx, userID)
+}
+
+// GetSubscription mocks base method.
+func (m *MockWeeklyReportSubscriptionRepository) GetSubscription(ctx context.Context, userID string) (*domain0.WeeklyReportSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, userID)
+	ret0, _ := ret[0].(*domain0.WeeklyReportSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWeeklyReportSubscriptionRepositoryMockRecorder) GetSubscription(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWeeklyReportSubscriptionRepository)(nil).GetSubscription), ctx, userID)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockWeeklyReportSubscriptionRepository) ListSubscriptions(ctx context.Context) ([]*domain0.WeeklyReportSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx)
+	ret0, _ := ret[0].([]*domain0.WeeklyReportSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockWeeklyReportSubscriptionRepositoryMockRecorder) ListSubscriptions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockWeeklyReportSubscriptionRepository)(nil).ListSubscriptions), ctx)
+}
+
+// MarkSent mocks base method.
+func (m *MockWeeklyReportSubscriptionRepository) MarkSent(ctx context.Context, userID, week string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, userID, week)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockWeeklyReportSubscriptionRepositoryMockRecorder) MarkSent(ctx, userID, week interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockWeeklyReportSubscriptionRepository)(nil).MarkSent), ctx, userID, week)
+}
+
+// SaveSubscription mocks base method.
+func (m *MockWeeklyReportSubscriptionRepository) SaveSubscription(ctx context.Context, subscription *domain0.WeeklyReportSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSubscription indicates an expected call of SaveSubscription.
+func (mr *MockWeeklyReportSubscriptionRepositoryMockRecorder) SaveSubscription(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSubscription", reflect.TypeOf((*MockWeeklyReportSubscriptionRepository)(nil).SaveSubscription), ctx, subscription)
+}
+
+// MockWeeklyReportMailer is a mock of WeeklyReportMailer interface.
+type MockWeeklyReportMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeeklyReportMailerMockRecorder
+}
+
+// MockWeeklyReportMailerMockRecorder is the mock recorder for MockWeeklyReportMailer.
+type MockWeeklyReportMailerMockRecorder struct {
+	mock *MockWeeklyReportMailer
+}
+
+// NewMockWeeklyReportMailer creates a new mock instance.
+func NewMockWeeklyReportMailer(ctrl *gomock.Controller) *MockWeeklyReportMailer {
+	mock := &MockWeeklyReportMailer{ctrl: ctrl}
+	mock.recorder = &MockWeeklyReportMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeeklyReportMailer) EXPECT() *MockWeeklyReportMailerMockRecorder {
+	return m.recorder
+}
+
+// SendWeeklyReport mocks base method.
+func (m *MockWeeklyReportMailer) SendWeeklyReport(ctx context.Context, to *domain.UserInfo, report *domain0.WeeklyReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendWeeklyReport", ctx, to, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendWeeklyReport indicates an expected call of SendWeeklyReport.
+func (mr *MockWeeklyReportMailerMockRecorder) SendWeeklyReport(ctx, to, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWeeklyReport", reflect.TypeOf((*MockWeeklyReportMailer)(nil).SendWeeklyReport), ctx, to, report)
+}
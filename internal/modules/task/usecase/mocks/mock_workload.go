@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workload_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// MockWorkloadRepository is a mock of WorkloadRepository interface.
+type MockWorkloadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkloadRepositoryMockRecorder
+}
+
+// MockWorkloadRepositoryMockRecorder is the mock recorder for MockWorkloadRepository.
+type MockWorkloadRepositoryMockRecorder struct {
+	mock *MockWorkloadRepository
+}
+
+// NewMockWorkloadRepository creates a new mock instance.
+func NewMockWorkloadRepository(ctrl *gomock.Controller) *MockWorkloadRepository {
+	mock := &MockWorkloadRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkloadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkloadRepository) EXPECT() *MockWorkloadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetWorkingHours mocks base method.
+func (m *MockWorkloadRepository) GetWorkingHours(ctx context.Context, userID string) (*domain.WorkingHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkingHours", ctx, userID)
+	ret0, _ := ret[0].(*domain.WorkingHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkingHours indicates an expected call of GetWorkingHours.
+func (mr *MockWorkloadRepositoryMockRecorder) GetWorkingHours(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkingHours", reflect.TypeOf((*MockWorkloadRepository)(nil).GetWorkingHours), ctx, userID)
+}
+
+// SaveWorkingHours mocks base method.
+func (m *MockWorkloadRepository) SaveWorkingHours(ctx context.Context, hours *domain.WorkingHours) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWorkingHours", ctx, hours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWorkingHours indicates an expected call of SaveWorkingHours.
+func (mr *MockWorkloadRepositoryMockRecorder) SaveWorkingHours(ctx, hours interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWorkingHours", reflect.TypeOf((*MockWorkloadRepository)(nil).SaveWorkingHours), ctx, hours)
+}
+
+// MockWorkloadChecker is a mock of WorkloadChecker interface.
+type MockWorkloadChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkloadCheckerMockRecorder
+}
+
+// MockWorkloadCheckerMockRecorder is the mock recorder for MockWorkloadChecker.
+type MockWorkloadCheckerMockRecorder struct {
+	mock *MockWorkloadChecker
+}
+
+// NewMockWorkloadChecker creates a new mock instance.
+func NewMockWorkloadChecker(ctrl *gomock.Controller) *MockWorkloadChecker {
+	mock := &MockWorkloadChecker{ctrl: ctrl}
+	mock.recorder = &MockWorkloadCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkloadChecker) EXPECT() *MockWorkloadCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckAssignment mocks base method.
+func (m *MockWorkloadChecker) CheckAssignment(ctx context.Context, task *domain.Task, assigneeID string) (*domain.WorkloadWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAssignment", ctx, task, assigneeID)
+	ret0, _ := ret[0].(*domain.WorkloadWarning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAssignment indicates an expected call of CheckAssignment.
+func (mr *MockWorkloadCheckerMockRecorder) CheckAssignment(ctx, task, assigneeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAssignment", reflect.TypeOf((*MockWorkloadChecker)(nil).CheckAssignment), ctx, task, assigneeID)
+}
@@ -0,0 +1,453 @@
+package parsing
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// エラー定義
+var (
+	ErrEmptyInput = errors.New("quick add text is empty")
+	ErrEmptyTitle = errors.New("quick add text has no title")
+)
+
+// Result はクイック追加文字列の解析結果（作成前のプレビュー）
+type Result struct {
+	Title    string           `json:"title"`
+	DueDate  *time.Time       `json:"due_date,omitempty"`
+	HasTime  bool             `json:"has_time"` // 時刻まで指定されたか
+	Category *domain.Category `json:"category,omitempty"`
+	Priority *domain.Priority `json:"priority,omitempty"`
+	Tokens   []Token          `json:"tokens"`
+
+	// カテゴリ・優先度を指定せず、タイトルから推定したか
+	CategorySuggested bool `json:"category_suggested,omitempty"`
+	PrioritySuggested bool `json:"priority_suggested,omitempty"`
+}
+
+// CategoryOrDefault はカテゴリ未指定時に CategoryOther を返す
+func (r *Result) CategoryOrDefault() domain.Category {
+	if r.Category == nil {
+		return domain.CategoryOther
+	}
+	return *r.Category
+}
+
+// PriorityOrDefault は優先度未指定時に PriorityMedium を返す
+func (r *Result) PriorityOrDefault() domain.Priority {
+	if r.Priority == nil {
+		return domain.PriorityMedium
+	}
+	return *r.Priority
+}
+
+// categoryAliases は #タグ とカテゴリの対応表
+var categoryAliases = map[string]domain.Category{
+	"work":     domain.CategoryWork,
+	"仕事":       domain.CategoryWork,
+	"personal": domain.CategoryPersonal,
+	"private":  domain.CategoryPersonal,
+	"個人":       domain.CategoryPersonal,
+	"プライベート":   domain.CategoryPersonal,
+	"study":    domain.CategoryStudy,
+	"学習":       domain.CategoryStudy,
+	"勉強":       domain.CategoryStudy,
+	"health":   domain.CategoryHealth,
+	"健康":       domain.CategoryHealth,
+	"shopping": domain.CategoryShopping,
+	"買い物":      domain.CategoryShopping,
+	"other":    domain.CategoryOther,
+	"その他":      domain.CategoryOther,
+}
+
+// priorityAliases は !優先度 と優先度の対応表
+var priorityAliases = map[string]domain.Priority{
+	"high":   domain.PriorityHigh,
+	"h":      domain.PriorityHigh,
+	"urgent": domain.PriorityHigh,
+	"高":      domain.PriorityHigh,
+	"緊急":     domain.PriorityHigh,
+	"medium": domain.PriorityMedium,
+	"med":    domain.PriorityMedium,
+	"m":      domain.PriorityMedium,
+	"中":      domain.PriorityMedium,
+	"low":    domain.PriorityLow,
+	"l":      domain.PriorityLow,
+	"低":      domain.PriorityLow,
+}
+
+// 相対日付の表現（日数オフセット）
+var relativeDays = []struct {
+	word   string
+	offset int
+}{
+	// 前方一致で判定するため、長い表現を先に並べる
+	{"明後日", 2},
+	{"あさって", 2},
+	{"今日", 0},
+	{"きょう", 0},
+	{"明日", 1},
+	{"あした", 1},
+	{"today", 0},
+	{"tomorrow", 1},
+	{"tmr", 1},
+}
+
+var jaWeekdays = map[string]time.Weekday{
+	"日": time.Sunday, "月": time.Monday, "火": time.Tuesday, "水": time.Wednesday,
+	"木": time.Thursday, "金": time.Friday, "土": time.Saturday,
+}
+
+var enWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var (
+	reJaWeekday  = regexp.MustCompile(`^(来週|今週)?の?([日月火水木金土])曜日?`)
+	reJaAfter    = regexp.MustCompile(`^(\d{1,3})(日|週間)後`)
+	reJaNextWeek = regexp.MustCompile(`^来週`)
+	reJaMonthDay = regexp.MustCompile(`^(?:(\d{4})年)?(\d{1,2})月(\d{1,2})日`)
+	reISODate    = regexp.MustCompile(`^(\d{4})[-/](\d{1,2})[-/](\d{1,2})`)
+	reSlashDate  = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})`)
+
+	reJaTime    = regexp.MustCompile(`^(午前|午後)?(\d{1,2})時(?:(\d{1,2})分|(半))?$`)
+	reClockTime = regexp.MustCompile(`^(\d{1,2}):(\d{2})(am|pm)?$`)
+	reAmPmTime  = regexp.MustCompile(`^(\d{1,2})(am|pm)$`)
+)
+
+// Parse はクイック追加の文字列を解析してタイトル・期限・カテゴリ・優先度を抽出する
+// now は相対日付（明日、来週など）の基準時刻で、そのタイムゾーンで期限を計算する
+func Parse(input string, now time.Time) (*Result, error) {
+	tokens := Tokenize(input)
+	if len(tokens) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	result := &Result{}
+	var titleParts []string
+	var date *time.Time
+	var hour, minute int
+	hasTime := false
+
+	for i := 0; i < len(tokens); i++ {
+		raw := tokens[i].Value
+		value := normalize(raw)
+
+		// カテゴリ（#work / #仕事）
+		if strings.HasPrefix(value, "#") && result.Category == nil {
+			if category, ok := categoryAliases[value[1:]]; ok {
+				result.Category = &category
+				result.Tokens = append(result.Tokens, Token{Kind: TokenCategory, Value: raw})
+				continue
+			}
+		}
+
+		// 優先度（!high / !高）
+		if strings.HasPrefix(value, "!") && result.Priority == nil {
+			if priority, ok := priorityAliases[value[1:]]; ok {
+				result.Priority = &priority
+				result.Tokens = append(result.Tokens, Token{Kind: TokenPriority, Value: raw})
+				continue
+			}
+		}
+
+		// 英語の複数語表現（next monday / next week / in 3 days）
+		if date == nil {
+			if d, consumed, ok := parseEnglishPhrase(tokens[i:], now); ok {
+				date = &d
+				for _, t := range tokens[i : i+consumed] {
+					result.Tokens = append(result.Tokens, Token{Kind: TokenDate, Value: t.Value})
+				}
+				i += consumed - 1
+				continue
+			}
+		}
+
+		// 日付（時刻付きの「明日15時」も含む）
+		if date == nil {
+			if d, rest, ok := parseDatePrefix(value, now); ok {
+				if rest == "" {
+					date = &d
+					result.Tokens = append(result.Tokens, Token{Kind: TokenDate, Value: raw})
+					continue
+				}
+				if !hasTime {
+					if h, m, ok := parseTime(rest); ok {
+						date = &d
+						hour, minute, hasTime = h, m, true
+						result.Tokens = append(result.Tokens, Token{Kind: TokenDate, Value: raw})
+						continue
+					}
+				}
+			}
+		}
+
+		// 時刻
+		if !hasTime {
+			if h, m, ok := parseTime(value); ok {
+				hour, minute, hasTime = h, m, true
+				result.Tokens = append(result.Tokens, Token{Kind: TokenTime, Value: raw})
+				continue
+			}
+		}
+
+		titleParts = append(titleParts, raw)
+		result.Tokens = append(result.Tokens, Token{Kind: TokenText, Value: raw})
+	}
+
+	result.Title = strings.Join(titleParts, " ")
+	if result.Title == "" {
+		return nil, ErrEmptyTitle
+	}
+
+	result.HasTime = hasTime
+	result.DueDate = resolveDueDate(date, hasTime, hour, minute, now)
+
+	return result, nil
+}
+
+// resolveDueDate は日付と時刻の指定から期限を組み立てる
+func resolveDueDate(date *time.Time, hasTime bool, hour, minute int, now time.Time) *time.Time {
+	switch {
+	case date != nil && hasTime:
+		due := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location())
+		return &due
+	case date != nil:
+		// 日付のみの場合はその日の終わりを期限とする
+		_, dayEnd := domain.GetDayStartEnd(*date)
+		return &dayEnd
+	case hasTime:
+		// 時刻のみの場合は直近のその時刻（過ぎていれば翌日）
+		due := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !due.After(now) {
+			due = due.AddDate(0, 0, 1)
+		}
+		return &due
+	default:
+		return nil
+	}
+}
+
+// parseDatePrefix は文字列先頭の日付表現を解析し、残りの文字列を返す
+func parseDatePrefix(s string, now time.Time) (time.Time, string, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, rd := range relativeDays {
+		if strings.HasPrefix(s, rd.word) {
+			return today.AddDate(0, 0, rd.offset), s[len(rd.word):], true
+		}
+	}
+
+	if m := reJaWeekday.FindStringSubmatch(s); m != nil {
+		weekday := jaWeekdays[m[2]]
+		var d time.Time
+		if m[1] == "来週" {
+			d = weekdayInNextWeek(today, weekday)
+		} else {
+			d = nextWeekday(today, weekday)
+		}
+		return d, s[len(m[0]):], true
+	}
+
+	if m := reJaAfter.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "週間" {
+			n *= 7
+		}
+		return today.AddDate(0, 0, n), s[len(m[0]):], true
+	}
+
+	if m := reJaNextWeek.FindString(s); m != "" {
+		return weekdayInNextWeek(today, time.Monday), s[len(m):], true
+	}
+
+	if m := reJaMonthDay.FindStringSubmatch(s); m != nil {
+		if d, ok := buildDate(m[1], m[2], m[3], today); ok {
+			return d, s[len(m[0]):], true
+		}
+	}
+
+	if m := reISODate.FindStringSubmatch(s); m != nil {
+		if d, ok := buildDate(m[1], m[2], m[3], today); ok {
+			return d, s[len(m[0]):], true
+		}
+	}
+
+	if m := reSlashDate.FindStringSubmatch(s); m != nil {
+		if d, ok := buildDate("", m[1], m[2], today); ok {
+			return d, s[len(m[0]):], true
+		}
+	}
+
+	if weekday, ok := enWeekdays[s]; ok {
+		return nextWeekday(today, weekday), "", true
+	}
+
+	return time.Time{}, "", false
+}
+
+// parseEnglishPhrase は複数トークンにまたがる英語の日付表現を解析する
+// 戻り値の consumed は消費したトークン数
+func parseEnglishPhrase(tokens []Token, now time.Time) (time.Time, int, bool) {
+	if len(tokens) < 2 {
+		return time.Time{}, 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	first := normalize(tokens[0].Value)
+	second := normalize(tokens[1].Value)
+
+	switch first {
+	case "next":
+		if second == "week" {
+			return weekdayInNextWeek(today, time.Monday), 2, true
+		}
+		if weekday, ok := enWeekdays[second]; ok {
+			return weekdayInNextWeek(today, weekday), 2, true
+		}
+	case "in":
+		if len(tokens) < 3 {
+			return time.Time{}, 0, false
+		}
+		n, err := strconv.Atoi(second)
+		if err != nil || n < 0 {
+			return time.Time{}, 0, false
+		}
+		switch normalize(tokens[2].Value) {
+		case "day", "days":
+			return today.AddDate(0, 0, n), 3, true
+		case "week", "weeks":
+			return today.AddDate(0, 0, n*7), 3, true
+		}
+	}
+
+	return time.Time{}, 0, false
+}
+
+// parseTime は時刻表現を解析して時・分を返す
+func parseTime(s string) (int, int, bool) {
+	switch s {
+	case "noon", "正午":
+		return 12, 0, true
+	}
+
+	if m := reJaTime.FindStringSubmatch(s); m != nil {
+		hour, _ := strconv.Atoi(m[2])
+		minute := 0
+		if m[3] != "" {
+			minute, _ = strconv.Atoi(m[3])
+		} else if m[4] != "" {
+			minute = 30
+		}
+		switch m[1] {
+		case "午前":
+			if hour > 12 {
+				return 0, 0, false
+			}
+			if hour == 12 {
+				hour = 0
+			}
+		case "午後":
+			if hour > 12 {
+				return 0, 0, false
+			}
+			if hour < 12 {
+				hour += 12
+			}
+		}
+		return validTime(hour, minute)
+	}
+
+	if m := reClockTime.FindStringSubmatch(s); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if m[3] != "" {
+			var ok bool
+			if hour, ok = applyMeridiem(hour, m[3]); !ok {
+				return 0, 0, false
+			}
+		}
+		return validTime(hour, minute)
+	}
+
+	if m := reAmPmTime.FindStringSubmatch(s); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		hour, ok := applyMeridiem(hour, m[2])
+		if !ok {
+			return 0, 0, false
+		}
+		return validTime(hour, 0)
+	}
+
+	return 0, 0, false
+}
+
+// applyMeridiem は12時間表記を24時間表記に変換する
+func applyMeridiem(hour int, meridiem string) (int, bool) {
+	if hour < 1 || hour > 12 {
+		return 0, false
+	}
+	if meridiem == "am" {
+		return hour % 12, true
+	}
+	return hour%12 + 12, true
+}
+
+func validTime(hour, minute int) (int, int, bool) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+// buildDate は年・月・日の文字列から日付を作成する
+// 年が省略され、かつ日付が過去の場合は翌年とみなす
+func buildDate(yearStr, monthStr, dayStr string, today time.Time) (time.Time, bool) {
+	month, _ := strconv.Atoi(monthStr)
+	day, _ := strconv.Atoi(dayStr)
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	year := today.Year()
+	if yearStr != "" {
+		year, _ = strconv.Atoi(yearStr)
+	}
+
+	d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, today.Location())
+	// 2/30 のような存在しない日付は正規化されて月がずれるため弾く
+	if d.Month() != time.Month(month) {
+		return time.Time{}, false
+	}
+
+	if yearStr == "" && d.Before(today) {
+		d = d.AddDate(1, 0, 0)
+	}
+	return d, true
+}
+
+// nextWeekday は今日より後で最も近い指定曜日を返す（今日が同じ曜日なら翌週）
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// weekdayInNextWeek は来週（月曜始まり）の指定曜日を返す
+func weekdayInNextWeek(today time.Time, weekday time.Weekday) time.Time {
+	weekStart, _ := domain.GetWeekStartEnd(today)
+	offset := (int(weekday) + 6) % 7 // 月曜=0 ... 日曜=6
+	return weekStart.AddDate(0, 0, 7+offset)
+}
@@ -0,0 +1,170 @@
+package parsing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// 2024-06-12（水）10:00 JST を基準時刻とする
+var jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+var baseNow = time.Date(2024, 6, 12, 10, 0, 0, 0, jst)
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("レポート提出　明日 15時\t#work")
+
+	require.Len(t, tokens, 4)
+	assert.Equal(t, "レポート提出", tokens[0].Value)
+	assert.Equal(t, "明日", tokens[1].Value)
+	assert.Equal(t, "15時", tokens[2].Value)
+	assert.Equal(t, "#work", tokens[3].Value)
+	for _, token := range tokens {
+		assert.Equal(t, TokenText, token.Kind)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantTitle    string
+		wantDue      *time.Time
+		wantHasTime  bool
+		wantCategory *domain.Category
+		wantPriority *domain.Priority
+	}{
+		{
+			name:         "japanese full example",
+			input:        "レポート提出 明日 15時 #work !high",
+			wantTitle:    "レポート提出",
+			wantDue:      timePtr(time.Date(2024, 6, 13, 15, 0, 0, 0, jst)),
+			wantHasTime:  true,
+			wantCategory: categoryPtr(domain.CategoryWork),
+			wantPriority: priorityPtr(domain.PriorityHigh),
+		},
+		{
+			name:         "japanese compound date and time",
+			input:        "歯医者 明後日午後3時半 #健康 !中",
+			wantTitle:    "歯医者",
+			wantDue:      timePtr(time.Date(2024, 6, 14, 15, 30, 0, 0, jst)),
+			wantHasTime:  true,
+			wantCategory: categoryPtr(domain.CategoryHealth),
+			wantPriority: priorityPtr(domain.PriorityMedium),
+		},
+		{
+			name:      "date only falls on end of day",
+			input:     "買い出し 6月20日",
+			wantTitle: "買い出し",
+			wantDue:   timePtr(time.Date(2024, 6, 20, 23, 59, 59, 999999999, jst)),
+		},
+		{
+			name:      "past month-day rolls to next year",
+			input:     "年賀状 1/5",
+			wantTitle: "年賀状",
+			wantDue:   timePtr(time.Date(2025, 1, 5, 23, 59, 59, 999999999, jst)),
+		},
+		{
+			name:        "time only later today",
+			input:       "call mom 3pm",
+			wantTitle:   "call mom",
+			wantDue:     timePtr(time.Date(2024, 6, 12, 15, 0, 0, 0, jst)),
+			wantHasTime: true,
+		},
+		{
+			name:        "time only already passed rolls to tomorrow",
+			input:       "朝会 9:30",
+			wantTitle:   "朝会",
+			wantDue:     timePtr(time.Date(2024, 6, 13, 9, 30, 0, 0, jst)),
+			wantHasTime: true,
+		},
+		{
+			name:         "english next weekday",
+			input:        "submit report next monday 10am #study !low",
+			wantTitle:    "submit report",
+			wantDue:      timePtr(time.Date(2024, 6, 17, 10, 0, 0, 0, jst)),
+			wantHasTime:  true,
+			wantCategory: categoryPtr(domain.CategoryStudy),
+			wantPriority: priorityPtr(domain.PriorityLow),
+		},
+		{
+			name:      "japanese next week weekday",
+			input:     "定例 来週金曜",
+			wantTitle: "定例",
+			wantDue:   timePtr(time.Date(2024, 6, 21, 23, 59, 59, 999999999, jst)),
+		},
+		{
+			name:      "english in n days",
+			input:     "renew passport in 3 days",
+			wantTitle: "renew passport",
+			wantDue:   timePtr(time.Date(2024, 6, 15, 23, 59, 59, 999999999, jst)),
+		},
+		{
+			name:      "unknown tag stays in title",
+			input:     "読書 #novel",
+			wantTitle: "読書 #novel",
+		},
+		{
+			name:         "full-width symbols are normalized",
+			input:        "資料作成 ＃仕事 ！ｈｉｇｈ",
+			wantTitle:    "資料作成",
+			wantCategory: categoryPtr(domain.CategoryWork),
+			wantPriority: priorityPtr(domain.PriorityHigh),
+		},
+		{
+			name:      "word starting with date keyword is kept",
+			input:     "明日の準備",
+			wantTitle: "明日の準備",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.input, baseNow)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantTitle, result.Title)
+			assert.Equal(t, tt.wantHasTime, result.HasTime)
+			if tt.wantDue == nil {
+				assert.Nil(t, result.DueDate)
+			} else {
+				require.NotNil(t, result.DueDate)
+				assert.True(t, tt.wantDue.Equal(*result.DueDate), "want %v, got %v", tt.wantDue, result.DueDate)
+			}
+			assert.Equal(t, tt.wantCategory, result.Category)
+			assert.Equal(t, tt.wantPriority, result.Priority)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse("   ", baseNow)
+	assert.ErrorIs(t, err, ErrEmptyInput)
+
+	_, err = Parse("明日 15時 #work", baseNow)
+	assert.ErrorIs(t, err, ErrEmptyTitle)
+}
+
+func TestParse_TokenKinds(t *testing.T) {
+	result, err := Parse("レポート提出 明日 15時 #work !high", baseNow)
+	require.NoError(t, err)
+
+	kinds := make([]TokenKind, len(result.Tokens))
+	for i, token := range result.Tokens {
+		kinds[i] = token.Kind
+	}
+	assert.Equal(t, []TokenKind{TokenText, TokenDate, TokenTime, TokenCategory, TokenPriority}, kinds)
+}
+
+func TestResult_Defaults(t *testing.T) {
+	result := &Result{Title: "test"}
+	assert.Equal(t, domain.CategoryOther, result.CategoryOrDefault())
+	assert.Equal(t, domain.PriorityMedium, result.PriorityOrDefault())
+}
+
+func timePtr(t time.Time) *time.Time                 { return &t }
+func categoryPtr(c domain.Category) *domain.Category { return &c }
+func priorityPtr(p domain.Priority) *domain.Priority { return &p }
@@ -0,0 +1,52 @@
+package parsing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TokenKind はトークンの種類を表す型
+type TokenKind string
+
+// トークン種別の定数
+const (
+	TokenText     TokenKind = "TEXT"     // タイトルの一部
+	TokenDate     TokenKind = "DATE"     // 日付表現（明日、tomorrow、12/25 など）
+	TokenTime     TokenKind = "TIME"     // 時刻表現（15時、3pm など）
+	TokenCategory TokenKind = "CATEGORY" // #work などのカテゴリ指定
+	TokenPriority TokenKind = "PRIORITY" // !high などの優先度指定
+)
+
+// Token は入力文字列を分割した最小単位
+type Token struct {
+	Kind  TokenKind `json:"kind"`
+	Value string    `json:"value"`
+}
+
+// Tokenize は入力文字列を空白（全角スペースを含む）で分割する
+// 種別の判定は Parser 側で行うため、ここでは全て TokenText として返す
+func Tokenize(input string) []Token {
+	fields := strings.FieldsFunc(input, unicode.IsSpace)
+
+	tokens := make([]Token, 0, len(fields))
+	for _, field := range fields {
+		tokens = append(tokens, Token{Kind: TokenText, Value: field})
+	}
+	return tokens
+}
+
+// normalize は全角英数字・記号を半角に変換し、小文字化する
+func normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r >= '！' && r <= '～':
+			// 全角ASCII（U+FF01〜U+FF5E）を半角に変換
+			b.WriteRune(r - '！' + '!')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.ToLower(b.String())
+}
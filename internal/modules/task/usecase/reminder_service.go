@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// maxReminderDeliveryBatch は1回の配信で送る保留した通知の件数の上限
+const maxReminderDeliveryBatch = 1000
+
+// DeferredReminderRepository は勤務時間外のため保留した通知のリポジトリインターフェース
+type DeferredReminderRepository interface {
+	// SaveDeferredReminder は通知を保留する（同じユーザー・タスク・種類の通知は置き換える）
+	SaveDeferredReminder(ctx context.Context, reminder *domain.DeferredReminder) error
+	// ListDueDeferredReminders は送る日時が until 以前の通知を送る日時の順に取得する
+	ListDueDeferredReminders(ctx context.Context, until time.Time, limit int) ([]*domain.DeferredReminder, error)
+	DeleteDeferredReminders(ctx context.Context, ids []string) error
+}
+
+// ReminderDigestNotifier は保留した通知をまとめて1件の通知で送るインターフェース
+type ReminderDigestNotifier interface {
+	NotifyReminderDigest(ctx context.Context, userID string, reminders []*domain.DeferredReminder) error
+}
+
+// ReminderScheduler はリマインダー・エスカレーションの通知を送る日時を決めるインターフェース
+type ReminderScheduler interface {
+	// Defer は now が通知先の勤務時間外の場合に通知を保留し、保留した場合は true を返す
+	Defer(ctx context.Context, reminder *domain.DeferredReminder, now time.Time) (bool, error)
+}
+
+// ReminderService は通知先の勤務時間・祝日に合わせてリマインダー・エスカレーションの通知を保留し、
+// 次の勤務時間の開始時にまとめて送るサービス
+type ReminderService struct {
+	WorkloadRepository WorkloadRepository
+	ReminderRepository DeferredReminderRepository
+	Notifier           ReminderDigestNotifier
+	Logger             logger.Logger
+
+	// 営業日の判定に使う祝日（未設定の場合は勤務曜日のみで判定する）
+	Holidays holiday.Provider
+}
+
+// NewReminderService はReminderServiceのコンストラクタ
+func NewReminderService(
+	workloadRepo WorkloadRepository,
+	reminderRepo DeferredReminderRepository,
+	notifier ReminderDigestNotifier,
+	logger logger.Logger,
+) *ReminderService {
+	return &ReminderService{
+		WorkloadRepository: workloadRepo,
+		ReminderRepository: reminderRepo,
+		Notifier:           notifier,
+		Logger:             logger,
+	}
+}
+
+// Defer は通知先の勤務時間の設定に従って、勤務時間外の通知を次の勤務時間の開始時まで保留する
+// 勤務時間内・通知の時間帯が ANYTIME の場合は保留せずに false を返す（呼び出し元がすぐに送る）
+func (s *ReminderService) Defer(ctx context.Context, reminder *domain.DeferredReminder, now time.Time) (bool, error) {
+	hours, err := s.WorkloadRepository.GetWorkingHours(ctx, reminder.UserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get working hours: %w", err)
+	}
+	if hours == nil {
+		hours = domain.DefaultWorkingHours(reminder.UserID)
+	}
+
+	deliverAt := hours.NextReminderTime(now, s.Holidays)
+	if !deliverAt.After(now) {
+		return false, nil
+	}
+
+	if reminder.ID == "" {
+		reminder.ID = uuid.New().String()
+	}
+	reminder.DeliverAt = deliverAt
+	if err := s.ReminderRepository.SaveDeferredReminder(ctx, reminder); err != nil {
+		return false, fmt.Errorf("failed to save deferred reminder: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Reminder deferred to working hours",
+		logger.Any("userID", reminder.UserID),
+		logger.Any("taskID", reminder.TaskID),
+		logger.Any("kind", reminder.Kind),
+		logger.Any("deliverAt", deliverAt))
+	return true, nil
+}
+
+// DeliverDue は送る日時を迎えた保留した通知を、ユーザーごとに1件の通知にまとめて送り、送った件数を返す
+// 送れなかったユーザーの通知は残し、次回の配信で送り直す
+// バックグラウンドワーカーから定期的に呼び出される
+func (s *ReminderService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	reminders, err := s.ReminderRepository.ListDueDeferredReminders(ctx, now, maxReminderDeliveryBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list deferred reminders: %w", err)
+	}
+
+	var userIDs []string
+	byUser := make(map[string][]*domain.DeferredReminder)
+	for _, reminder := range reminders {
+		if _, ok := byUser[reminder.UserID]; !ok {
+			userIDs = append(userIDs, reminder.UserID)
+		}
+		byUser[reminder.UserID] = append(byUser[reminder.UserID], reminder)
+	}
+
+	delivered := 0
+	var errs []error
+	for _, userID := range userIDs {
+		userReminders := byUser[userID]
+		if err := s.Notifier.NotifyReminderDigest(ctx, userID, userReminders); err != nil {
+			s.Logger.WithContext(ctx).Error("Failed to deliver deferred reminders",
+				logger.Any("userID", userID), logger.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+
+		ids := make([]string, len(userReminders))
+		for i, reminder := range userReminders {
+			ids[i] = reminder.ID
+		}
+		if err := s.ReminderRepository.DeleteDeferredReminders(ctx, ids); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete deferred reminders: %w", err))
+			continue
+		}
+		delivered += len(userReminders)
+	}
+
+	return delivered, errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=reminder_service.go -destination=mocks/mock_reminder.go -package=mocks
+
+type reminderTestMocks struct {
+	workloadRepo *mocks.MockWorkloadRepository
+	reminderRepo *mocks.MockDeferredReminderRepository
+	notifier     *mocks.MockReminderDigestNotifier
+}
+
+func newReminderTestService(t *testing.T) (*ReminderService, *reminderTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &reminderTestMocks{
+		workloadRepo: mocks.NewMockWorkloadRepository(ctrl),
+		reminderRepo: mocks.NewMockDeferredReminderRepository(ctrl),
+		notifier:     mocks.NewMockReminderDigestNotifier(ctrl),
+	}
+	service := NewReminderService(m.workloadRepo, m.reminderRepo, m.notifier, *createTestLogger())
+	service.Holidays = holiday.Japan()
+	return service, m
+}
+
+func TestReminderService_Defer(t *testing.T) {
+	task := &domain.Task{ID: "task-1", Title: "資料作成"}
+	hours := domain.DefaultWorkingHours("user-1")
+	hours.Timezone = "UTC"
+
+	t.Run("within working hours", func(t *testing.T) {
+		service, m := newReminderTestService(t)
+		m.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+
+		deferred, err := service.Defer(context.Background(), domain.NewDeferredReminder("user-1", task, domain.ReminderKindDueSoon),
+			time.Date(2024, 6, 12, 10, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.False(t, deferred)
+	})
+
+	t.Run("outside working hours", func(t *testing.T) {
+		service, m := newReminderTestService(t)
+		m.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+		m.reminderRepo.EXPECT().SaveDeferredReminder(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, reminder *domain.DeferredReminder) error {
+				assert.NotEmpty(t, reminder.ID)
+				// 金曜日の夜は月曜日の始業時刻に送る
+				assert.Equal(t, time.Date(2024, 6, 17, 9, 0, 0, 0, time.UTC), reminder.DeliverAt)
+				return nil
+			})
+
+		deferred, err := service.Defer(context.Background(), domain.NewDeferredReminder("user-1", task, domain.ReminderKindDueSoon),
+			time.Date(2024, 6, 14, 21, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.True(t, deferred)
+	})
+
+	t.Run("anytime", func(t *testing.T) {
+		service, m := newReminderTestService(t)
+		anytime := *hours
+		anytime.ReminderTiming = domain.ReminderTimingAnytime
+		m.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(&anytime, nil)
+
+		deferred, err := service.Defer(context.Background(), domain.NewDeferredReminder("user-1", task, domain.ReminderKindStart),
+			time.Date(2024, 6, 15, 23, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.False(t, deferred)
+	})
+}
+
+func TestReminderService_DeliverDue(t *testing.T) {
+	now := time.Date(2024, 6, 17, 9, 0, 0, 0, time.UTC)
+	reminders := []*domain.DeferredReminder{
+		{ID: "r-1", UserID: "user-1", TaskID: "task-1", Kind: domain.ReminderKindDueSoon},
+		{ID: "r-2", UserID: "user-2", TaskID: "task-2", Kind: domain.ReminderKindEscalation},
+		{ID: "r-3", UserID: "user-1", TaskID: "task-3", Kind: domain.ReminderKindStart},
+	}
+
+	t.Run("batches reminders per user", func(t *testing.T) {
+		service, m := newReminderTestService(t)
+		m.reminderRepo.EXPECT().ListDueDeferredReminders(gomock.Any(), now, maxReminderDeliveryBatch).Return(reminders, nil)
+		m.notifier.EXPECT().NotifyReminderDigest(gomock.Any(), "user-1", []*domain.DeferredReminder{reminders[0], reminders[2]}).Return(nil)
+		m.reminderRepo.EXPECT().DeleteDeferredReminders(gomock.Any(), []string{"r-1", "r-3"}).Return(nil)
+		m.notifier.EXPECT().NotifyReminderDigest(gomock.Any(), "user-2", []*domain.DeferredReminder{reminders[1]}).Return(nil)
+		m.reminderRepo.EXPECT().DeleteDeferredReminders(gomock.Any(), []string{"r-2"}).Return(nil)
+
+		delivered, err := service.DeliverDue(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, delivered)
+	})
+
+	t.Run("keeps reminders that failed to send", func(t *testing.T) {
+		service, m := newReminderTestService(t)
+		m.reminderRepo.EXPECT().ListDueDeferredReminders(gomock.Any(), now, maxReminderDeliveryBatch).Return(reminders, nil)
+		m.notifier.EXPECT().NotifyReminderDigest(gomock.Any(), "user-1", gomock.Any()).Return(errors.New("send failed"))
+		m.notifier.EXPECT().NotifyReminderDigest(gomock.Any(), "user-2", gomock.Any()).Return(nil)
+		m.reminderRepo.EXPECT().DeleteDeferredReminders(gomock.Any(), []string{"r-2"}).Return(nil)
+
+		delivered, err := service.DeliverDue(context.Background(), now)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, delivered)
+	})
+}
@@ -0,0 +1,239 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/pdf"
+)
+
+// weeklyData はテンプレートに渡す表示用に整形した週次レポート
+type weeklyData struct {
+	Title          string
+	Period         string
+	CompletedCount int
+	OnTimeCount    int
+	SlippedCount   int
+	OnTimeRate     string // 期限が週内のタスクがない場合は空
+	Tracked        string
+	Estimated      string
+	Categories     []weeklyCategory
+	Completed      []weeklyTask
+	Slipped        []weeklyTask
+	MoreCompleted  int // 一覧に載せきれなかった件数
+	MoreSlipped    int
+}
+
+type weeklyCategory struct {
+	Name      string
+	Completed int
+	Tracked   string
+}
+
+type weeklyTask struct {
+	Title    string
+	Category string
+	Detail   string
+}
+
+func newWeeklyData(r *domain.WeeklyReport) weeklyData {
+	data := weeklyData{
+		Title: fmt.Sprintf("週次レポート %s", r.Week),
+		Period: fmt.Sprintf("%s〜%s（%s）",
+			r.WeekStart.Format("2006/01/02"), r.WeekEnd.Format("2006/01/02"), r.Timezone),
+		CompletedCount: r.CompletedCount,
+		OnTimeCount:    r.OnTimeCount,
+		SlippedCount:   r.SlippedCount,
+		Tracked:        formatMinutes(r.TrackedMinutes),
+		Estimated:      formatMinutes(r.EstimatedMinutes),
+		MoreCompleted:  r.CompletedCount - len(r.Completed),
+		MoreSlipped:    r.SlippedCount - len(r.Slipped),
+	}
+	if due := r.OnTimeCount + r.SlippedCount; due > 0 {
+		data.OnTimeRate = fmt.Sprintf("%d%%", r.OnTimeCount*100/due)
+	}
+
+	for _, c := range r.TopCategories {
+		data.Categories = append(data.Categories, weeklyCategory{
+			Name:      c.Category.GetDisplayName(),
+			Completed: c.CompletedCount,
+			Tracked:   formatMinutes(c.TrackedMinutes),
+		})
+	}
+	for _, t := range r.Completed {
+		detail := t.CompletedAt.In(r.WeekStart.Location()).Format("01/02 15:04") + " 完了"
+		if t.ActualMinutes != nil && *t.ActualMinutes > 0 {
+			detail += "・" + formatMinutes(*t.ActualMinutes)
+		}
+		data.Completed = append(data.Completed, weeklyTask{Title: t.Title, Category: t.Category.GetDisplayName(), Detail: detail})
+	}
+	for _, t := range r.Slipped {
+		detail := fmt.Sprintf("期限 %s・%s遅れ", t.DueDate.In(r.WeekStart.Location()).Format("01/02 15:04"), formatHours(t.SlipHours))
+		if t.CompletedAt == nil {
+			detail += "（未完了）"
+		}
+		data.Slipped = append(data.Slipped, weeklyTask{Title: t.Title, Category: t.Category.GetDisplayName(), Detail: detail})
+	}
+	return data
+}
+
+// formatMinutes は分を「3時間20分」の形式にする
+func formatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d分", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d時間", minutes/60)
+	}
+	return fmt.Sprintf("%d時間%d分", minutes/60, minutes%60)
+}
+
+// formatHours は時間を「2日3時間」の形式にする
+func formatHours(hours int) string {
+	switch {
+	case hours < 1:
+		return "1時間未満"
+	case hours < 24:
+		return fmt.Sprintf("%d時間", hours)
+	case hours%24 == 0:
+		return fmt.Sprintf("%d日", hours/24)
+	}
+	return fmt.Sprintf("%d日%d時間", hours/24, hours%24)
+}
+
+// Subject はメールの件名を返す
+func Subject(r *domain.WeeklyReport) string {
+	return fmt.Sprintf("Yotei+ 週次レポート（%s）: 完了%d件", r.Week, r.CompletedCount)
+}
+
+// Text は週次レポートをプレーンテキストで出力する
+func Text(r *domain.WeeklyReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := weeklyTextTemplate.Execute(&buf, newWeeklyData(r)); err != nil {
+		return nil, fmt.Errorf("failed to render weekly report text: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// HTML は週次レポートをHTMLで出力する（メール本文にも使えるようスタイルはインラインで指定する）
+func HTML(r *domain.WeeklyReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := weeklyHTMLTemplate.Execute(&buf, newWeeklyData(r)); err != nil {
+		return nil, fmt.Errorf("failed to render weekly report html: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// PDF は週次レポートをA4のPDFで出力する
+func PDF(r *domain.WeeklyReport) []byte {
+	data := newWeeklyData(r)
+	doc := pdf.New(data.Title)
+
+	doc.Text("Yotei+ "+data.Title, 18)
+	doc.Text(data.Period, 10)
+	doc.Rule()
+
+	doc.Text(fmt.Sprintf("完了したタスク: %d件", data.CompletedCount), 12)
+	if data.OnTimeRate != "" {
+		doc.Text(fmt.Sprintf("期限内の完了: %d件　遅延: %d件（期限内率 %s）", data.OnTimeCount, data.SlippedCount, data.OnTimeRate), 12)
+	}
+	doc.Text(fmt.Sprintf("作業時間: %s（見積もり %s）", data.Tracked, data.Estimated), 12)
+
+	section := func(title string) {
+		doc.Space(8)
+		doc.Text(title, 14)
+		doc.Rule()
+	}
+	if len(data.Categories) > 0 {
+		section("よく取り組んだカテゴリ")
+		for i, c := range data.Categories {
+			doc.Text(fmt.Sprintf("%d. %s　%d件・%s", i+1, c.Name, c.Completed, c.Tracked), 11)
+		}
+	}
+	tasks := func(title string, items []weeklyTask, more int) {
+		if len(items) == 0 {
+			return
+		}
+		section(title)
+		for _, t := range items {
+			doc.Text(fmt.Sprintf("・%s［%s］", t.Title, t.Category), 11)
+			doc.Text("　　"+t.Detail, 9)
+		}
+		if more > 0 {
+			doc.Text(fmt.Sprintf("ほか%d件", more), 9)
+		}
+	}
+	tasks("完了したタスク", data.Completed, data.MoreCompleted)
+	tasks("遅れたタスク", data.Slipped, data.MoreSlipped)
+
+	return doc.Bytes()
+}
+
+var weeklyTextTemplate = textTemplate.Must(textTemplate.New("weekly_report_text").Funcs(textTemplate.FuncMap{"inc": inc}).Parse(
+	`Yotei+ {{.Title}}
+{{.Period}}
+
+完了したタスク: {{.CompletedCount}}件
+{{- if .OnTimeRate}}
+期限内の完了: {{.OnTimeCount}}件 / 遅延: {{.SlippedCount}}件（期限内率 {{.OnTimeRate}}）
+{{- end}}
+作業時間: {{.Tracked}}（見積もり {{.Estimated}}）
+{{if .Categories}}
+■ よく取り組んだカテゴリ
+{{range $i, $c := .Categories}}{{$i | inc}}. {{$c.Name}} {{$c.Completed}}件・{{$c.Tracked}}
+{{end}}{{end}}{{if .Completed}}
+■ 完了したタスク
+{{range .Completed}}・{{.Title}}［{{.Category}}］{{.Detail}}
+{{end}}{{if .MoreCompleted}}ほか{{.MoreCompleted}}件
+{{end}}{{end}}{{if .Slipped}}
+■ 遅れたタスク
+{{range .Slipped}}・{{.Title}}［{{.Category}}］{{.Detail}}
+{{end}}{{if .MoreSlipped}}ほか{{.MoreSlipped}}件
+{{end}}{{end}}
+--
+Yotei+
+`))
+
+var weeklyHTMLTemplate = htmlTemplate.Must(htmlTemplate.New("weekly_report_html").Parse(
+	`<!DOCTYPE html>
+<html lang="ja">
+<head><meta charset="UTF-8"><title>Yotei+ {{.Title}}</title></head>
+<body style="margin:0;padding:24px;background:#f4f6fb;font-family:sans-serif;color:#1f2937;">
+  <div style="max-width:640px;margin:0 auto;background:#ffffff;border-radius:12px;overflow:hidden;">
+    <div style="background:#4f46e5;color:#ffffff;padding:20px 24px;">
+      <div style="font-size:20px;font-weight:bold;">Yotei+ {{.Title}}</div>
+      <div style="font-size:13px;opacity:0.85;">{{.Period}}</div>
+    </div>
+    <div style="padding:24px;">
+      <table style="width:100%;border-collapse:collapse;text-align:center;">
+        <tr>
+          <td style="padding:8px;"><div style="font-size:28px;font-weight:bold;">{{.CompletedCount}}</div><div style="font-size:12px;color:#6b7280;">完了</div></td>
+          <td style="padding:8px;"><div style="font-size:28px;font-weight:bold;">{{if .OnTimeRate}}{{.OnTimeRate}}{{else}}-{{end}}</div><div style="font-size:12px;color:#6b7280;">期限内率</div></td>
+          <td style="padding:8px;"><div style="font-size:28px;font-weight:bold;">{{.SlippedCount}}</div><div style="font-size:12px;color:#6b7280;">遅延</div></td>
+          <td style="padding:8px;"><div style="font-size:28px;font-weight:bold;">{{.Tracked}}</div><div style="font-size:12px;color:#6b7280;">作業時間（見積もり {{.Estimated}}）</div></td>
+        </tr>
+      </table>
+      {{- if .Categories}}
+      <h2 style="font-size:16px;border-bottom:1px solid #e5e7eb;padding-bottom:4px;">よく取り組んだカテゴリ</h2>
+      <ol>{{range .Categories}}<li>{{.Name}} {{.Completed}}件・{{.Tracked}}</li>{{end}}</ol>
+      {{- end}}
+      {{- if .Completed}}
+      <h2 style="font-size:16px;border-bottom:1px solid #e5e7eb;padding-bottom:4px;">完了したタスク</h2>
+      <ul>{{range .Completed}}<li>{{.Title}} <span style="color:#6b7280;">［{{.Category}}］{{.Detail}}</span></li>{{end}}</ul>
+      {{- if .MoreCompleted}}<p style="color:#6b7280;font-size:12px;">ほか{{.MoreCompleted}}件</p>{{end}}
+      {{- end}}
+      {{- if .Slipped}}
+      <h2 style="font-size:16px;border-bottom:1px solid #e5e7eb;padding-bottom:4px;">遅れたタスク</h2>
+      <ul>{{range .Slipped}}<li>{{.Title}} <span style="color:#b91c1c;">［{{.Category}}］{{.Detail}}</span></li>{{end}}</ul>
+      {{- if .MoreSlipped}}<p style="color:#6b7280;font-size:12px;">ほか{{.MoreSlipped}}件</p>{{end}}
+      {{- end}}
+    </div>
+  </div>
+</body>
+</html>
+`))
+
+func inc(i int) int { return i + 1 }
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+func newTestReport(t *testing.T) *domain.WeeklyReport {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	weekStart := time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo)
+	completedAt := time.Date(2024, 6, 4, 10, 0, 0, 0, tokyo)
+	dueDate := time.Date(2024, 6, 5, 18, 0, 0, 0, tokyo)
+	actual := 200
+
+	done := domain.NewTask("<script>見積書を送る</script>", "", domain.PriorityHigh, domain.CategoryWork, "user-1")
+	done.ID = "done"
+	done.Status = domain.TaskStatusDone
+	done.CompletedAt = &completedAt
+	done.ActualMinutes = &actual
+	open := domain.NewTask("月次報告", "", domain.PriorityMedium, domain.CategoryWork, "user-1")
+	open.ID = "open"
+	open.DueDate = &dueDate
+
+	return domain.NewWeeklyReport("user-1", weekStart, []*domain.Task{done}, []*domain.Task{open}, weekStart.AddDate(0, 0, 8))
+}
+
+func TestText(t *testing.T) {
+	text, err := Text(newTestReport(t))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "2024/06/03〜2024/06/09（Asia/Tokyo）")
+	assert.Contains(t, string(text), "完了したタスク: 1件")
+	assert.Contains(t, string(text), "作業時間: 3時間20分")
+	assert.Contains(t, string(text), "1. 仕事 1件・3時間20分")
+	assert.Contains(t, string(text), "・月次報告［仕事］期限 06/05 18:00・4日5時間遅れ（未完了）")
+}
+
+func TestHTML_EscapesTaskTitles(t *testing.T) {
+	html, err := HTML(newTestReport(t))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "&lt;script&gt;見積書を送る&lt;/script&gt;")
+	assert.NotContains(t, string(html), "<script>")
+}
+
+func TestPDF(t *testing.T) {
+	doc := PDF(newTestReport(t))
+
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4")))
+	assert.True(t, strings.HasSuffix(string(doc), "%%EOF\n"))
+	// 文字列はUTF-16BEの16進で出力される（「月次報告」）
+	assert.Contains(t, string(doc), "67086B215831544A")
+}
@@ -31,6 +31,9 @@ type TaskRepository interface {
 	// 期限切れのタスクを取得
 	GetOverdueTasks(ctx context.Context) ([]*domain.Task, error)
 
+	// 特定のユーザーが作成または担当する期限切れのタスクを取得
+	GetOverdueTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error)
+
 	// 特定のユーザーに割り当てられたタスクの取得
 	GetTasksByAssignee(ctx context.Context, userID string) ([]*domain.Task, error)
 
@@ -9,8 +9,12 @@ import (
 
 	"github.com/google/uuid"
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/parsing"
 	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/markdown"
 )
 
 type UserValidator = commonDomain.UserValidator
@@ -20,7 +24,7 @@ type EventPublisher interface {
 	PublishTaskCreated(ctx context.Context, task *domain.Task) error
 	PublishTaskUpdated(ctx context.Context, task *domain.Task) error
 	PublishTaskDeleted(ctx context.Context, taskID string) error
-	PublishTaskAssigned(ctx context.Context, task *domain.Task) error
+	PublishTaskAssigned(ctx context.Context, task *domain.Task, assigneeIDs []string) error
 	PublishTaskCompleted(ctx context.Context, task *domain.Task) error
 }
 
@@ -31,9 +35,10 @@ type UserInfo = commonDomain.UserInfo
 
 // TaskWithUserInfo はタスクとユーザー情報を含む構造体（N+1問題解決用）
 type TaskWithUserInfo struct {
-	Task         *domain.Task `json:"task"`
-	CreatorInfo  *UserInfo    `json:"creator_info,omitempty"`
-	AssigneeInfo *UserInfo    `json:"assignee_info,omitempty"`
+	Task          *domain.Task `json:"task"`
+	CreatorInfo   *UserInfo    `json:"creator_info,omitempty"`
+	AssigneeInfo  *UserInfo    `json:"assignee_info,omitempty"`
+	AssigneesInfo []*UserInfo  `json:"assignees_info,omitempty"`
 }
 
 // TaskService は改良されたタスクサービス
@@ -43,6 +48,55 @@ type TaskService struct {
 	EventPublisher EventPublisher
 	Logger         logger.Logger
 
+	// 割り当て時のキャパシティ確認（未設定の場合は確認しない）
+	WorkloadChecker WorkloadChecker
+
+	// タスク説明中のメンション処理（未設定の場合は処理しない）
+	MentionProcessor MentionProcessor
+
+	// グループタスクの紐付け・作成権限の確認と担当者の自動割り当て（未設定の場合はグループタスクを作成できない）
+	GroupResolver GroupTaskResolver
+	GroupAssigner GroupTaskAssigner
+
+	// 削除の取り消し（未設定の場合は即時に削除する）
+	UndoScheduler undo.Scheduler
+
+	// 変更履歴の記録（未設定の場合は記録しない）
+	HistoryRecorder TaskHistoryRecorder
+
+	// 日次統計の集計テーブルの更新（未設定の場合は更新しない）
+	DailyStats DailyStatsUpdater
+
+	// 未完了のタスク数の上限の確認（未設定の場合は確認しない）
+	Quotas commonDomain.QuotaChecker
+
+	// 利用状況の分析イベントの記録（未設定の場合は記録しない）
+	Analytics commonDomain.AnalyticsTracker
+
+	// オフライン同期用の変更フィードへの記録（未設定の場合は記録しない）
+	SyncChanges commonDomain.ChangeRecorder
+
+	// 説明中のリンクのプレビュー（未設定の場合は取得しない）
+	LinkPreviews LinkPreviewProvider
+
+	// グループの予定との関連付け（未設定の場合は表示しない）
+	EventLinks TaskEventLinkProvider
+
+	// タスクの場所（未設定の場合は表示しない）
+	Locations TaskLocationProvider
+
+	// タスクのリンク（未設定の場合は表示しない）
+	Links TaskLinkProvider
+
+	// 祝日・勤務日以外の期限の自動調整（未設定の場合は調整しない）
+	DueDateAdjuster DueDateAdjuster
+
+	// 重複タスクの候補とする作成日時の期間
+	DuplicateWindow time.Duration
+
+	// クイック追加でのカテゴリ・優先度の推定（未設定の場合は推定しない）
+	Classifier TaskClassifier
+
 	// 非同期イベント設定
 	AsyncEventTimeout time.Duration
 	MaxRetries        int
@@ -60,6 +114,7 @@ func NewTaskService(
 		UserValidator:     userValidator,
 		EventPublisher:    eventPublisher,
 		Logger:            logger,
+		DuplicateWindow:   domain.DefaultDuplicateWindow,
 		AsyncEventTimeout: 30 * time.Second,
 		MaxRetries:        3,
 	}
@@ -72,8 +127,13 @@ var (
 	ErrInvalidParameter    = errors.New("invalid parameter")
 	ErrUserNotFound        = errors.New("user not found")
 	ErrDuplicateAssignment = errors.New("task already assigned to this user")
+	ErrAssigneeNotFound    = errors.New("user is not assigned to this task")
+	ErrTaskIDConflict      = errors.New("task id is already used by another user")
 )
 
+// 1タスクあたりの担当者数の上限
+const maxAssigneesPerTask = 50
+
 // === メインサービスメソッド ===
 
 // CreateTask はタスクを作成する（統一インターフェース使用）
@@ -90,39 +150,185 @@ func (s *TaskService) CreateTask(
 		return nil, err
 	}
 
+	// タスク作成
+	task := domain.NewTask(title, description, priority, category, createdBy)
+	return s.saveNewTask(ctx, task)
+}
+
+// QuickAddTask は自然言語の文字列を解析してタスクを作成する
+func (s *TaskService) QuickAddTask(ctx context.Context, text, createdBy string, now time.Time) (*domain.Task, *parsing.Result, error) {
+	parsed, err := s.ParseQuickAdd(ctx, text, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validateCreateTaskInput(parsed.Title, "", createdBy); err != nil {
+		return nil, nil, err
+	}
+
+	task := domain.NewTask(parsed.Title, "", parsed.PriorityOrDefault(), parsed.CategoryOrDefault(), createdBy)
+	if parsed.DueDate != nil {
+		task.SetDueDate(s.adjustDueDate(ctx, task, *parsed.DueDate))
+	}
+
+	task, err = s.saveNewTask(ctx, task)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.trackEvent(ctx, createdBy, commonDomain.AnalyticsFeatureUsed, map[string]interface{}{"feature": "quick_add"})
+
+	return task, parsed, nil
+}
+
+// ParseQuickAdd はクイック追加の文字列を解析する（タスクは作成しない）
+// #タグ・!優先度で指定しなかったカテゴリ・優先度はタイトルから推定する
+func (s *TaskService) ParseQuickAdd(ctx context.Context, text string, now time.Time) (*parsing.Result, error) {
+	parsed, err := parsing.Parse(text, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	s.suggestClassification(ctx, parsed)
+	return parsed, nil
+}
+
+// CreateTaskWithID はクライアントが生成したUUIDv7のIDでタスクを作成する
+// オフライン中のクライアントが同期前にタスクを参照できるようにするために使用する。
+// 同じユーザーが同じIDで作成済みの場合は再送として扱い、作成済みのタスクを返す
+func (s *TaskService) CreateTaskWithID(
+	ctx context.Context,
+	id,
+	title,
+	description string,
+	priority domain.Priority,
+	category domain.Category,
+	createdBy string,
+) (*domain.Task, error) {
+	if err := validateClientTaskID(id); err != nil {
+		return nil, err
+	}
+	if err := s.validateCreateTaskInput(title, description, createdBy); err != nil {
+		return nil, err
+	}
+	if existing, err := s.findClientTask(ctx, id, createdBy); err != nil || existing != nil {
+		return existing, err
+	}
+
+	task := domain.NewTask(title, description, priority, category, createdBy)
+	task.ID = id
+	return s.saveNewTask(ctx, task)
+}
+
+// validateClientTaskID はクライアントが生成したタスクのIDがUUIDv7かどうか検証する
+func validateClientTaskID(id string) error {
+	parsed, err := uuid.Parse(id)
+	if err != nil || parsed.Version() != 7 {
+		return fmt.Errorf("%w: id must be a UUIDv7", ErrInvalidParameter)
+	}
+	return nil
+}
+
+// findClientTask はクライアントが生成したIDのタスクを取得する（存在しない場合はnil）
+// 別のユーザーが作成したタスクの場合はErrTaskIDConflictを返す
+func (s *TaskService) findClientTask(ctx context.Context, id, createdBy string) (*domain.Task, error) {
+	task, err := s.TaskRepository.GetTaskByID(ctx, id)
+	if errors.Is(err, ErrTaskNotFound) || (err == nil && task == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if task.CreatedBy != createdBy {
+		return nil, ErrTaskIDConflict
+	}
+	return task, nil
+}
+
+// newTaskID はタスクのIDを生成する
+// 作成順に並ぶUUIDv7を使い、主キーのインデックスへの挿入位置を局所化する
+func newTaskID() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate task id: %w", err)
+	}
+	return id.String(), nil
+}
+
+// saveNewTask は作成者の存在確認を行い、新しいタスクを保存してイベントを発行する
+// IDが未設定の場合は新しいIDを生成する
+func (s *TaskService) saveNewTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	createdBy := task.CreatedBy
+
 	// 作成者の存在確認（統一インターフェース使用）
 	exists, err := s.UserValidator.UserExists(ctx, createdBy)
 	if err != nil {
-		s.Logger.Error("Failed to validate user existence",
+		s.Logger.WithContext(ctx).Error("Failed to validate user existence",
 			logger.Any("userID", createdBy), logger.Error(err))
 		return nil, fmt.Errorf("failed to validate user: %w", err)
 	}
 	if !exists {
 		return nil, ErrUserNotFound
 	}
+	if err := s.checkOpenTaskQuota(ctx, commonDomain.QuotaScopeUser, createdBy); err != nil {
+		return nil, err
+	}
 
-	// タスク作成
-	task := domain.NewTask(title, description, priority, category, createdBy)
-	task.ID = uuid.New().String()
+	if task.ID == "" {
+		if task.ID, err = newTaskID(); err != nil {
+			return nil, err
+		}
+	}
+	task.Checklist = extractChecklist(task.Description)
 
 	err = s.TaskRepository.CreateTask(ctx, task)
 	if err != nil {
-		s.Logger.Error("Failed to create task",
+		s.Logger.WithContext(ctx).Error("Failed to create task",
 			logger.Any("taskID", task.ID), logger.Error(err))
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
+	if s.HistoryRecorder != nil {
+		s.HistoryRecorder.RecordCreated(ctx, task)
+	}
+	if s.DailyStats != nil {
+		s.DailyStats.TaskChanged(ctx, nil, task)
+	}
+
 	// イベント発行（非同期）
 	s.publishEventAsync(ctx, "task_created", func() error {
 		return s.EventPublisher.PublishTaskCreated(ctx, task)
 	})
+	s.syncTaskUpserted(ctx, task)
+
+	s.processDescriptionMentions(ctx, task, createdBy, "")
+	s.requestLinkPreviews(ctx, task)
+
+	s.trackEvent(ctx, createdBy, commonDomain.AnalyticsTaskCreated, map[string]interface{}{
+		"priority":     string(task.Priority),
+		"category":     string(task.Category),
+		"has_due_date": task.DueDate != nil,
+	})
 
-	s.Logger.Info("Task created successfully",
+	s.Logger.WithContext(ctx).Info("Task created successfully",
 		logger.Any("taskID", task.ID), logger.Any("createdBy", createdBy))
 
 	return task, nil
 }
 
+// trackEvent は利用状況の分析イベントを記録する
+func (s *TaskService) trackEvent(ctx context.Context, userID, event string, properties map[string]interface{}) {
+	if s.Analytics != nil {
+		s.Analytics.Track(ctx, userID, event, properties)
+	}
+}
+
+// checkOpenTaskQuota はユーザーまたはグループの未完了のタスク数が上限に達していないか確認する
+func (s *TaskService) checkOpenTaskQuota(ctx context.Context, scope, subjectID string) error {
+	if s.Quotas == nil {
+		return nil
+	}
+	return s.Quotas.CheckQuota(ctx, scope, subjectID, commonDomain.QuotaOpenTasks, 1)
+}
+
 // CreateTaskWithDefaults はデフォルトカテゴリでタスクを作成する（下位互換性）
 func (s *TaskService) CreateTaskWithDefaults(
 	ctx context.Context,
@@ -158,20 +364,15 @@ func (s *TaskService) GetTaskWithUserInfo(ctx context.Context, id string) (*Task
 	}
 
 	// ユーザー情報を一括取得（N+1問題解決）
-	userIDs := []string{task.CreatedBy}
-	if task.AssigneeID != nil {
-		userIDs = append(userIDs, *task.AssigneeID)
-	}
+	userIDs := append([]string{task.CreatedBy}, task.AssigneeIDs()...)
 
 	userInfoMap, err := s.UserValidator.GetUsersInfoBatch(ctx, userIDs)
 	if err != nil {
-		s.Logger.Error("Failed to get user info batch", logger.Error(err))
+		s.Logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
 		// エラーでもタスク情報は返す（ユーザー情報は空）
 	} else {
 		result.CreatorInfo = userInfoMap[task.CreatedBy]
-		if task.AssigneeID != nil {
-			result.AssigneeInfo = userInfoMap[*task.AssigneeID]
-		}
+		setAssigneesInfo(result, userInfoMap)
 	}
 
 	return result, nil
@@ -227,8 +428,8 @@ func (s *TaskService) ListTasksWithUserInfo(
 	userIDSet := make(map[string]bool)
 	for _, task := range tasks {
 		userIDSet[task.CreatedBy] = true
-		if task.AssigneeID != nil {
-			userIDSet[*task.AssigneeID] = true
+		for _, assigneeID := range task.AssigneeIDs() {
+			userIDSet[assigneeID] = true
 		}
 	}
 
@@ -243,7 +444,7 @@ func (s *TaskService) ListTasksWithUserInfo(
 		if batchInfo, err := s.UserValidator.GetUsersInfoBatch(ctx, userIDs); err == nil {
 			userInfoMap = batchInfo
 		} else {
-			s.Logger.Error("Failed to get user info batch", logger.Error(err))
+			s.Logger.WithContext(ctx).Error("Failed to get user info batch", logger.Error(err))
 			// エラーログは出力するが、処理は継続（グレースフルな劣化）
 		}
 	}
@@ -255,15 +456,69 @@ func (s *TaskService) ListTasksWithUserInfo(
 			Task:        task,
 			CreatorInfo: userInfoMap[task.CreatedBy],
 		}
-		if task.AssigneeID != nil {
-			result[i].AssigneeInfo = userInfoMap[*task.AssigneeID]
-		}
+		setAssigneesInfo(result[i], userInfoMap)
 	}
 
 	return result, total, nil
 }
 
+// setAssigneesInfo は担当者のユーザー情報を設定する
+func setAssigneesInfo(result *TaskWithUserInfo, userInfoMap map[string]*UserInfo) {
+	if result.Task.AssigneeID != nil {
+		result.AssigneeInfo = userInfoMap[*result.Task.AssigneeID]
+	}
+	for _, assigneeID := range result.Task.AssigneeIDs() {
+		if info, ok := userInfoMap[assigneeID]; ok && info != nil {
+			result.AssigneesInfo = append(result.AssigneesInfo, info)
+		}
+	}
+}
+
+// processDescriptionMentions はタスク説明中の新しいメンションを処理する
+// メンションの失敗でタスクの作成・更新は失敗させない
+func (s *TaskService) processDescriptionMentions(ctx context.Context, task *domain.Task, authorID, previousDescription string) {
+	if s.MentionProcessor == nil || task.Description == "" {
+		return
+	}
+
+	source := domain.MentionSource{Type: domain.MentionSourceDescription, ID: task.ID}
+	if _, err := s.MentionProcessor.ProcessMentions(ctx, task, source, authorID, task.Description, previousDescription); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to process description mentions",
+			logger.Any("taskID", task.ID), logger.Error(err))
+	}
+}
+
+// requestLinkPreviews は説明中のリンクのプレビューの取得を予約する
+func (s *TaskService) requestLinkPreviews(ctx context.Context, task *domain.Task) {
+	if s.LinkPreviews == nil || task.Description == "" {
+		return
+	}
+	s.LinkPreviews.RequestPreviews(ctx, task.Description)
+}
+
+// GetLinkPreviews は説明中のリンクの取得済みのプレビューを返す
+func (s *TaskService) GetLinkPreviews(ctx context.Context, task *domain.Task) []*domain.LinkPreview {
+	if s.LinkPreviews == nil {
+		return nil
+	}
+	return s.LinkPreviews.Previews(ctx, task.Description)
+}
+
+// extractChecklist はMarkdownの説明のタスクリストからチェックリストを抽出する
+func extractChecklist(description string) []domain.ChecklistItem {
+	items := markdown.Checklist(description)
+	if len(items) == 0 {
+		return nil
+	}
+	checklist := make([]domain.ChecklistItem, 0, len(items))
+	for _, item := range items {
+		checklist = append(checklist, domain.ChecklistItem{Text: item.Text, Checked: item.Checked})
+	}
+	return checklist
+}
+
 // UpdateTask はタスクを更新する（イベント発行）
+// 説明中のメンションはタスク作成者によるものとして扱う
 func (s *TaskService) UpdateTask(
 	ctx context.Context,
 	id string,
@@ -271,6 +526,20 @@ func (s *TaskService) UpdateTask(
 	status *domain.TaskStatus,
 	priority *domain.Priority,
 	dueDate *time.Time,
+) (*domain.Task, error) {
+	return s.UpdateTaskAsUser(ctx, id, "", title, description, status, priority, dueDate)
+}
+
+// UpdateTaskAsUser は編集者を指定してタスクを更新する
+// 編集者はタスクを編集できる必要がある（グループタスクはタスク編集の権限が必要）
+// 編集者が空の場合はシステムによる更新としてタスク作成者を編集者として扱う
+func (s *TaskService) UpdateTaskAsUser(
+	ctx context.Context,
+	id, editorID string,
+	title, description *string,
+	status *domain.TaskStatus,
+	priority *domain.Priority,
+	dueDate *time.Time,
 ) (*domain.Task, error) {
 	if id == "" {
 		return nil, ErrInvalidParameter
@@ -281,7 +550,7 @@ func (s *TaskService) UpdateTask(
 		return nil, err
 	}
 
-	task, err := s.TaskRepository.GetTaskByID(ctx, id)
+	task, err := s.getTaskForEditor(ctx, id, editorID)
 	if err != nil {
 		return nil, err
 	}
@@ -289,6 +558,8 @@ func (s *TaskService) UpdateTask(
 	// 変更追跡
 	hasChanges := false
 	oldStatus := task.Status
+	oldDescription := task.Description
+	before := s.stateBeforeChange(task)
 
 	// 各フィールドの更新（指定されている場合のみ）
 	if title != nil && *title != task.Title {
@@ -297,10 +568,11 @@ func (s *TaskService) UpdateTask(
 	}
 	if description != nil && *description != task.Description {
 		task.Description = *description
+		task.Checklist = extractChecklist(task.Description)
 		hasChanges = true
 	}
 	if status != nil && *status != task.Status {
-		task.Status = *status
+		task.SetStatus(*status)
 		hasChanges = true
 	}
 	if priority != nil && *priority != task.Priority {
@@ -308,8 +580,9 @@ func (s *TaskService) UpdateTask(
 		hasChanges = true
 	}
 	if dueDate != nil {
-		if task.DueDate == nil || !dueDate.Equal(*task.DueDate) {
-			task.DueDate = dueDate
+		adjusted := s.adjustDueDate(ctx, task, *dueDate)
+		if task.DueDate == nil || !adjusted.Equal(*task.DueDate) {
+			task.DueDate = &adjusted
 			hasChanges = true
 		}
 	}
@@ -318,15 +591,20 @@ func (s *TaskService) UpdateTask(
 	if !hasChanges {
 		return task, nil
 	}
+	if !task.HasValidSchedule() {
+		return nil, ErrStartAfterDue
+	}
 
 	task.UpdatedAt = time.Now()
 
 	err = s.TaskRepository.UpdateTask(ctx, task)
 	if err != nil {
-		s.Logger.Error("Failed to update task",
+		s.Logger.WithContext(ctx).Error("Failed to update task",
 			logger.Any("taskID", id), logger.Error(err))
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
 
 	// イベント発行（非同期）
 	s.publishEventAsync(ctx, "task_updated", func() error {
@@ -340,10 +618,147 @@ func (s *TaskService) UpdateTask(
 		})
 	}
 
-	s.Logger.Info("Task updated successfully", logger.Any("taskID", id))
+	if task.Description != oldDescription {
+		if editorID == "" {
+			editorID = task.CreatedBy
+		}
+		s.processDescriptionMentions(ctx, task, editorID, oldDescription)
+		s.requestLinkPreviews(ctx, task)
+	}
+
+	s.Logger.WithContext(ctx).Info("Task updated successfully", logger.Any("taskID", id))
 	return task, nil
 }
 
+// 見積もり・実績の上限
+const (
+	maxEstimateMinutes = 60 * 24 * 7 // 1週間
+	maxEstimatePoints  = 1000
+)
+
+// UpdateTaskEstimate はタスクの見積もり（分・ポイント）と実績工数を更新する
+// nilのフィールドは変更しない（タスクを編集できるユーザーのみ）
+func (s *TaskService) UpdateTaskEstimate(
+	ctx context.Context,
+	id, userID string,
+	estimateMinutes, estimatePoints, actualMinutes *int,
+) (*domain.Task, error) {
+	if id == "" {
+		return nil, ErrInvalidParameter
+	}
+	if err := validateEstimate(estimateMinutes, estimatePoints, actualMinutes); err != nil {
+		return nil, err
+	}
+
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.stateBeforeChange(task)
+	minutes, points := task.EstimateMinutes, task.EstimatePoints
+	if estimateMinutes != nil {
+		minutes = estimateMinutes
+	}
+	if estimatePoints != nil {
+		points = estimatePoints
+	}
+	task.SetEstimate(minutes, points)
+	if actualMinutes != nil {
+		task.RecordActualMinutes(*actualMinutes)
+	}
+
+	if err := s.TaskRepository.UpdateTask(ctx, task); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update task estimate",
+			logger.Any("taskID", id), logger.Error(err))
+		return nil, fmt.Errorf("failed to update task estimate: %w", err)
+	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
+
+	s.publishEventAsync(ctx, "task_updated", func() error {
+		return s.EventPublisher.PublishTaskUpdated(ctx, task)
+	})
+
+	return task, nil
+}
+
+// ErrStartAfterDue は着手予定日時が期限より後の場合のエラー
+var ErrStartAfterDue = fmt.Errorf("%w: start_date must not be after due_date", ErrInvalidParameter)
+
+// UpdateTaskSchedule はタスクの着手予定日時と期限をまとめて更新する
+// nilのフィールドは変更せず、clearStartDateがtrueの場合は着手予定日時を消去する（タスクを編集できるユーザーのみ）
+func (s *TaskService) UpdateTaskSchedule(
+	ctx context.Context,
+	id, userID string,
+	startDate, dueDate *time.Time,
+	clearStartDate bool,
+) (*domain.Task, error) {
+	if id == "" {
+		return nil, ErrInvalidParameter
+	}
+	if startDate == nil && dueDate == nil && !clearStartDate {
+		return nil, fmt.Errorf("%w: no schedule fields specified", ErrInvalidParameter)
+	}
+	if startDate != nil && clearStartDate {
+		return nil, fmt.Errorf("%w: start_date and clear_start_date cannot be combined", ErrInvalidParameter)
+	}
+
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.stateBeforeChange(task)
+	if clearStartDate {
+		task.SetStartDate(nil)
+	} else if startDate != nil {
+		task.SetStartDate(startDate)
+	}
+	if dueDate != nil {
+		task.SetDueDate(s.adjustDueDate(ctx, task, *dueDate))
+	}
+	if !task.HasValidSchedule() {
+		return nil, ErrStartAfterDue
+	}
+
+	if err := s.TaskRepository.UpdateTask(ctx, task); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update task schedule",
+			logger.Any("taskID", id), logger.Error(err))
+		return nil, fmt.Errorf("failed to update task schedule: %w", err)
+	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
+
+	s.publishEventAsync(ctx, "task_updated", func() error {
+		return s.EventPublisher.PublishTaskUpdated(ctx, task)
+	})
+
+	return task, nil
+}
+
+// adjustDueDate はタスクの作成者の期限の扱いの設定に従って、祝日・勤務日以外の期限を前後の営業日に移動する
+// 設定を取得できない場合や、移動すると着手予定日時より前になる場合は移動しない
+func (s *TaskService) adjustDueDate(ctx context.Context, task *domain.Task, due time.Time) time.Time {
+	if s.DueDateAdjuster == nil {
+		return due
+	}
+
+	adjusted, err := s.DueDateAdjuster.AdjustDueDate(ctx, task.CreatedBy, due)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to adjust due date",
+			logger.Any("taskID", task.ID), logger.Error(err))
+		return due
+	}
+	if adjusted.Equal(due) || (task.StartDate != nil && adjusted.Before(*task.StartDate)) {
+		return due
+	}
+
+	s.Logger.WithContext(ctx).Info("Due date moved to a business day",
+		logger.Any("taskID", task.ID), logger.Any("dueDate", due), logger.Any("adjusted", adjusted))
+	return adjusted
+}
+
 // DeleteTask はタスクを削除する（イベント発行）
 func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	if id == "" {
@@ -351,14 +766,14 @@ func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	}
 
 	// 存在確認
-	_, err := s.TaskRepository.GetTaskByID(ctx, id)
+	task, err := s.TaskRepository.GetTaskByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	err = s.TaskRepository.DeleteTask(ctx, id)
 	if err != nil {
-		s.Logger.Error("Failed to delete task",
+		s.Logger.WithContext(ctx).Error("Failed to delete task",
 			logger.Any("taskID", id), logger.Error(err))
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -367,76 +782,278 @@ func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	s.publishEventAsync(ctx, "task_deleted", func() error {
 		return s.EventPublisher.PublishTaskDeleted(ctx, id)
 	})
+	s.syncTaskDeleted(ctx, id)
+	if s.DailyStats != nil {
+		s.DailyStats.TaskDeleted(ctx, task)
+	}
 
-	s.Logger.Info("Task deleted successfully", logger.Any("taskID", id))
+	s.Logger.WithContext(ctx).Info("Task deleted successfully", logger.Any("taskID", id))
 	return nil
 }
 
+// DeleteTaskAsUser はユーザーが削除できるタスクを削除する（グループタスクはタスク削除の権限が必要）
+func (s *TaskService) DeleteTaskAsUser(ctx context.Context, id, userID string) error {
+	if id == "" {
+		return ErrInvalidParameter
+	}
+	if _, err := getTaskWithAccess(ctx, s.TaskRepository, s.GroupResolver, id, userID, domain.GroupActionDeleteTasks); err != nil {
+		return err
+	}
+	return s.DeleteTask(ctx, id)
+}
+
+// DeleteTaskWithUndo はタスクの削除を取り消し期間の経過後に実行するよう登録し、取り消し用のトークンを返す
+// UndoSchedulerが未設定の場合は即時に削除し、nilのトークンを返す
+func (s *TaskService) DeleteTaskWithUndo(ctx context.Context, id, userID string) (*undo.Token, error) {
+	if s.UndoScheduler == nil {
+		return nil, s.DeleteTaskAsUser(ctx, id, userID)
+	}
+	if id == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	// 存在と削除の権限の確認
+	if _, err := getTaskWithAccess(ctx, s.TaskRepository, s.GroupResolver, id, userID, domain.GroupActionDeleteTasks); err != nil {
+		return nil, err
+	}
+
+	token := s.UndoScheduler.Defer(userID, undo.ActionDeleteTask, func(ctx context.Context) error {
+		return s.DeleteTask(ctx, id)
+	})
+
+	s.Logger.WithContext(ctx).Info("Task deletion scheduled",
+		logger.Any("taskID", id), logger.Any("expiresAt", token.ExpiresAt))
+	return token, nil
+}
+
 // / AssignTask はタスクを指定されたユーザーに割り当てる（統一インターフェース使用）
-func (s *TaskService) AssignTask(ctx context.Context, taskID string, assigneeID string) (*domain.Task, error) {
-	if taskID == "" || assigneeID == "" {
+// 既存の担当者は維持され、担当者が追加される
+func (s *TaskService) AssignTask(ctx context.Context, taskID, userID string, assigneeID string) (*domain.Task, error) {
+	return s.AssignTaskToUsers(ctx, taskID, userID, []string{assigneeID}, nil)
+}
+
+// AssignTaskToUsers はタスクに複数の担当者を追加する（タスクを編集できるユーザーのみ）
+// requireAllがnilでない場合は全員完了が必要なタスクかどうかも更新する
+func (s *TaskService) AssignTaskToUsers(ctx context.Context, taskID, userID string, assigneeIDs []string, requireAll *bool) (*domain.Task, error) {
+	assigneeIDs = uniqueStrings(assigneeIDs)
+	if taskID == "" || len(assigneeIDs) == 0 {
 		return nil, ErrInvalidParameter
 	}
+	if len(assigneeIDs) > maxAssigneesPerTask {
+		return nil, fmt.Errorf("%w: too many assignees (max %d)", ErrInvalidParameter, maxAssigneesPerTask)
+	}
+
+	for _, assigneeID := range assigneeIDs {
+		if assigneeID == "" {
+			return nil, ErrInvalidParameter
+		}
+	}
 
-	// アサイン先ユーザーの存在確認（統一インターフェース使用）
-	exists, err := s.UserValidator.UserExists(ctx, assigneeID)
+	// アサイン先ユーザーの存在確認（全員分を一度に問い合わせる）
+	missing, err := s.UserValidator.UsersExist(ctx, assigneeIDs)
 	if err != nil {
-		s.Logger.Error("Failed to validate assignee existence",
-			logger.Any("assigneeID", assigneeID), logger.Error(err))
+		s.Logger.WithContext(ctx).Error("Failed to validate assignee existence",
+			logger.Any("assigneeIDs", assigneeIDs), logger.Error(err))
 		return nil, fmt.Errorf("failed to validate assignee: %w", err)
 	}
-	if !exists {
+	if len(missing) > 0 {
 		return nil, ErrUserNotFound
 	}
 
-	task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	before := s.stateBeforeChange(task)
+	var added []string
+	for _, assigneeID := range assigneeIDs {
+		if task.AddAssignee(assigneeID) {
+			added = append(added, assigneeID)
+		}
+	}
+	if len(task.AssigneeIDs()) > maxAssigneesPerTask {
+		return nil, fmt.Errorf("%w: too many assignees (max %d)", ErrInvalidParameter, maxAssigneesPerTask)
+	}
+
+	ruleChanged := requireAll != nil && *requireAll != task.RequireAllAssignees
+	if ruleChanged {
+		task.RequireAllAssignees = *requireAll
+	}
+
 	// 既に同じユーザーにアサインされているかチェック
-	if task.AssigneeID != nil && *task.AssigneeID == assigneeID {
+	if len(added) == 0 && !ruleChanged {
 		return nil, ErrDuplicateAssignment
 	}
 
-	task.AssignTo(assigneeID)
-
 	err = s.TaskRepository.UpdateTask(ctx, task)
 	if err != nil {
-		s.Logger.Error("Failed to update task assignment",
+		s.Logger.WithContext(ctx).Error("Failed to update task assignment",
 			logger.Any("taskID", taskID), logger.Error(err))
 		return nil, fmt.Errorf("failed to update task assignment: %w", err)
 	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
 
 	// イベント発行（非同期）
-	s.publishEventAsync(ctx, "task_assigned", func() error {
-		return s.EventPublisher.PublishTaskAssigned(ctx, task)
+	if len(added) > 0 {
+		s.publishEventAsync(ctx, "task_assigned", func() error {
+			return s.EventPublisher.PublishTaskAssigned(ctx, task, added)
+		})
+	}
+
+	s.Logger.WithContext(ctx).Info("Task assigned successfully",
+		logger.Any("taskID", taskID), logger.Any("assigneeIDs", added))
+
+	return task, nil
+}
+
+// AssignTaskWithWorkloadCheck はタスクを割り当て、担当者がキャパシティ超過なら警告を返す
+// 警告は割り当てを妨げない
+func (s *TaskService) AssignTaskWithWorkloadCheck(ctx context.Context, taskID, userID string, assigneeID string) (*domain.Task, *domain.WorkloadWarning, error) {
+	task, warnings, err := s.AssignTaskToUsersWithWorkloadCheck(ctx, taskID, userID, []string{assigneeID}, nil)
+	if err != nil || len(warnings) == 0 {
+		return task, nil, err
+	}
+	return task, warnings[0], nil
+}
+
+// AssignTaskToUsersWithWorkloadCheck は複数の担当者を追加し、キャパシティ超過となる担当者ごとの警告を返す
+func (s *TaskService) AssignTaskToUsersWithWorkloadCheck(ctx context.Context, taskID, userID string, assigneeIDs []string, requireAll *bool) (*domain.Task, []*domain.WorkloadWarning, error) {
+	task, err := s.AssignTaskToUsers(ctx, taskID, userID, assigneeIDs, requireAll)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.WorkloadChecker == nil {
+		return task, nil, nil
+	}
+
+	var warnings []*domain.WorkloadWarning
+	for _, assigneeID := range uniqueStrings(assigneeIDs) {
+		warning, err := s.WorkloadChecker.CheckAssignment(ctx, task, assigneeID)
+		if err != nil {
+			// 確認失敗は非致命的
+			s.Logger.WithContext(ctx).Warn("Failed to check assignee workload",
+				logger.Any("taskID", taskID), logger.Any("assigneeID", assigneeID), logger.Error(err))
+			continue
+		}
+		if warning != nil {
+			s.Logger.WithContext(ctx).Info("Task assigned to over-capacity user",
+				logger.Any("taskID", taskID), logger.Any("assigneeID", assigneeID), logger.Any("date", warning.Date))
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return task, warnings, nil
+}
+
+// UnassignTask はタスクから担当者を外す（タスクを編集できるユーザーのみ）
+func (s *TaskService) UnassignTask(ctx context.Context, taskID, userID string, assigneeID string) (*domain.Task, error) {
+	if taskID == "" || assigneeID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	task, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.stateBeforeChange(task)
+	if !task.RemoveAssignee(assigneeID) {
+		return nil, ErrAssigneeNotFound
+	}
+
+	if err := s.TaskRepository.UpdateTask(ctx, task); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update task assignment",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return nil, fmt.Errorf("failed to update task assignment: %w", err)
+	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
+
+	s.publishEventAsync(ctx, "task_updated", func() error {
+		return s.EventPublisher.PublishTaskUpdated(ctx, task)
 	})
 
-	s.Logger.Info("Task assigned successfully",
+	s.Logger.WithContext(ctx).Info("Task unassigned successfully",
 		logger.Any("taskID", taskID), logger.Any("assigneeID", assigneeID))
 
 	return task, nil
 }
 
+// SetAssignmentCompletion は担当者ごとの完了状態を変更する
+// 全員完了が必要なタスクは全担当者が完了した時点でタスクも完了になる
+func (s *TaskService) SetAssignmentCompletion(ctx context.Context, taskID string, assigneeID string, completed bool) (*domain.Task, error) {
+	if taskID == "" || assigneeID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStatus := task.Status
+	before := s.stateBeforeChange(task)
+	var ok bool
+	if completed {
+		ok = task.CompleteAssignment(assigneeID)
+	} else {
+		ok = task.ReopenAssignment(assigneeID)
+	}
+	if !ok {
+		return nil, ErrAssigneeNotFound
+	}
+
+	if err := s.TaskRepository.UpdateTask(ctx, task); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update assignment completion",
+			logger.Any("taskID", taskID), logger.Any("assigneeID", assigneeID), logger.Error(err))
+		return nil, fmt.Errorf("failed to update assignment completion: %w", err)
+	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
+
+	s.publishEventAsync(ctx, "task_updated", func() error {
+		return s.EventPublisher.PublishTaskUpdated(ctx, task)
+	})
+
+	if oldStatus != domain.TaskStatusDone && task.Status == domain.TaskStatusDone {
+		s.publishEventAsync(ctx, "task_completed", func() error {
+			return s.EventPublisher.PublishTaskCompleted(ctx, task)
+		})
+	}
+
+	return task, nil
+}
+
 // ChangeTaskStatus はタスクのステータスを変更する（イベント発行）
+// 連携（GitHubのIssueのクローズなど）による変更で使い、ユーザーの権限は確認しない
 func (s *TaskService) ChangeTaskStatus(ctx context.Context, taskID string, status domain.TaskStatus) (*domain.Task, error) {
+	return s.ChangeTaskStatusAsUser(ctx, taskID, "", status)
+}
+
+// ChangeTaskStatusAsUser はユーザーが編集できるタスクのステータスを変更する
+// ユーザーが空の場合はシステムによる変更として権限を確認しない
+func (s *TaskService) ChangeTaskStatusAsUser(ctx context.Context, taskID, userID string, status domain.TaskStatus) (*domain.Task, error) {
 	if taskID == "" {
 		return nil, ErrInvalidParameter
 	}
 
-	task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+	task, err := s.getTaskForEditor(ctx, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	oldStatus := task.Status
+	before := s.stateBeforeChange(task)
 	task.SetStatus(status)
 
 	err = s.TaskRepository.UpdateTask(ctx, task)
 	if err != nil {
 		return nil, err
 	}
+	s.recordChange(ctx, before, task)
+	s.syncTaskUpserted(ctx, task)
 
 	// イベント発行（非同期）
 	s.publishEventAsync(ctx, "task_updated", func() error {
@@ -453,6 +1070,14 @@ func (s *TaskService) ChangeTaskStatus(ctx context.Context, taskID string, statu
 	return task, nil
 }
 
+// getTaskForEditor は編集者が変更できるタスクを取得する（編集者が空の場合はシステムによる変更として権限を確認しない）
+func (s *TaskService) getTaskForEditor(ctx context.Context, id, editorID string) (*domain.Task, error) {
+	if editorID == "" {
+		return s.TaskRepository.GetTaskByID(ctx, id)
+	}
+	return getEditableTask(ctx, s.TaskRepository, s.GroupResolver, id, editorID)
+}
+
 // === その他のメソッド ===
 
 // GetOverdueTasks は期限切れのタスクを取得する
@@ -460,6 +1085,14 @@ func (s *TaskService) GetOverdueTasks(ctx context.Context) ([]*domain.Task, erro
 	return s.TaskRepository.GetOverdueTasks(ctx)
 }
 
+// GetOverdueTasksByUser は特定のユーザーが作成または担当する期限切れのタスクを取得する
+func (s *TaskService) GetOverdueTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	return s.TaskRepository.GetOverdueTasksByUser(ctx, userID)
+}
+
 // GetTasksByAssignee は特定のユーザーに割り当てられたタスクを取得する
 func (s *TaskService) GetTasksByAssignee(ctx context.Context, userID string) ([]*domain.Task, error) {
 	if userID == "" {
@@ -479,11 +1112,54 @@ func (s *TaskService) SearchTasks(ctx context.Context, query string, limit int)
 	return s.TaskRepository.SearchTasks(ctx, query, limit)
 }
 
+// === 変更履歴 ===
+
+// stateBeforeChange は変更履歴・日次統計を記録する場合に変更前の状態を返す（記録しない場合はnil）
+func (s *TaskService) stateBeforeChange(task *domain.Task) *domain.TaskState {
+	if s.HistoryRecorder == nil && s.DailyStats == nil {
+		return nil
+	}
+	return domain.NewTaskState(task)
+}
+
+// recordChange は変更前の状態と保存したタスクの差分を変更履歴に記録し、日次統計に反映する
+func (s *TaskService) recordChange(ctx context.Context, before *domain.TaskState, task *domain.Task) {
+	if before == nil {
+		return
+	}
+	if s.HistoryRecorder != nil {
+		s.HistoryRecorder.RecordChange(ctx, before, task)
+	}
+	if s.DailyStats != nil {
+		s.DailyStats.TaskChanged(ctx, before, task)
+	}
+}
+
+// === 変更フィード ===
+
+// syncTaskUpserted はタスクの作成・更新を作成者と担当者の変更フィードに記録する
+func (s *TaskService) syncTaskUpserted(ctx context.Context, task *domain.Task) {
+	if s.SyncChanges == nil {
+		return
+	}
+	s.SyncChanges.RecordUpsert(ctx, commonDomain.SyncEntityTask, task.ID, append([]string{task.CreatedBy}, task.AssigneeIDs()...), task)
+}
+
+// syncTaskDeleted はタスクの削除を変更フィードに記録する
+func (s *TaskService) syncTaskDeleted(ctx context.Context, id string) {
+	if s.SyncChanges == nil {
+		return
+	}
+	s.SyncChanges.RecordDelete(ctx, commonDomain.SyncEntityTask, id)
+}
+
 // === 非同期イベント発行メソッド ===
 
 // publishEventAsync はイベントを非同期で発行する
 func (s *TaskService) publishEventAsync(ctx context.Context, eventType string, publishFunc func() error) {
 	go func() {
+		defer errtrack.Recover("task-event-publisher")
+
 		// タイムアウト付きコンテキスト
 		_, cancel := context.WithTimeout(ctx, s.AsyncEventTimeout)
 		defer cancel()
@@ -492,7 +1168,7 @@ func (s *TaskService) publishEventAsync(ctx context.Context, eventType string, p
 		for attempt := 1; attempt <= s.MaxRetries; attempt++ {
 			if err := publishFunc(); err != nil {
 				lastErr = err
-				s.Logger.Warn("Event publish failed, retrying...",
+				s.Logger.WithContext(ctx).Warn("Event publish failed, retrying...",
 					logger.Any("eventType", eventType),
 					logger.Any("attempt", attempt),
 					logger.Error(err))
@@ -503,14 +1179,14 @@ func (s *TaskService) publishEventAsync(ctx context.Context, eventType string, p
 			}
 
 			// 成功
-			s.Logger.Debug("Event published successfully",
+			s.Logger.WithContext(ctx).Debug("Event published successfully",
 				logger.Any("eventType", eventType),
 				logger.Any("attempt", attempt))
 			return
 		}
 
 		// 全ての試行が失敗
-		s.Logger.Error("Event publish failed after all retries",
+		s.Logger.WithContext(ctx).Error("Event publish failed after all retries",
 			logger.Any("eventType", eventType),
 			logger.Any("maxRetries", s.MaxRetries),
 			logger.Error(lastErr))
@@ -535,6 +1211,22 @@ func (s *TaskService) validateCreateTaskInput(title, description, createdBy stri
 	return nil
 }
 
+func validateEstimate(estimateMinutes, estimatePoints, actualMinutes *int) error {
+	if estimateMinutes == nil && estimatePoints == nil && actualMinutes == nil {
+		return fmt.Errorf("%w: no estimate fields specified", ErrInvalidParameter)
+	}
+	if estimateMinutes != nil && (*estimateMinutes < 0 || *estimateMinutes > maxEstimateMinutes) {
+		return fmt.Errorf("%w: estimate_minutes must be between 0 and %d", ErrInvalidParameter, maxEstimateMinutes)
+	}
+	if actualMinutes != nil && (*actualMinutes < 0 || *actualMinutes > maxEstimateMinutes) {
+		return fmt.Errorf("%w: actual_minutes must be between 0 and %d", ErrInvalidParameter, maxEstimateMinutes)
+	}
+	if estimatePoints != nil && (*estimatePoints < 0 || *estimatePoints > maxEstimatePoints) {
+		return fmt.Errorf("%w: estimate_points must be between 0 and %d", ErrInvalidParameter, maxEstimatePoints)
+	}
+	return nil
+}
+
 func (s *TaskService) validateUpdateTaskInput(title, description *string) error {
 	if title != nil {
 		if strings.TrimSpace(*title) == "" {
@@ -549,3 +1241,16 @@ func (s *TaskService) validateUpdateTaskInput(title, description *string) error
 	}
 	return nil
 }
+
+// uniqueStrings は順序を保ったまま重複を取り除く
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/utils"
+)
+
+// 1ユーザーあたりの有効な共有リンク数の上限
+const maxActiveShareLinksPerUser = 100
+
+const (
+	// maxSharePasswordFailures は共有リンクごとにパスワードを続けて試せる回数の上限（超えた場合はしばらく検証しない）
+	maxSharePasswordFailures = 10
+	// sharePasswordLockout は試行回数を保持する期間（上限に達した共有リンクは最後の試行からこの間パスワードを検証しない）
+	sharePasswordLockout = 15 * time.Minute
+	// maxTrackedShareTokens はプロセスのメモリで試行回数を記録する共有リンクの件数の上限（超えた場合は期限切れの記録を削除する）
+	maxTrackedShareTokens = 10000
+)
+
+var (
+	ErrShareLinkNotFound     = errors.New("share link not found")
+	ErrShareLinkExpired      = errors.New("share link expired")
+	ErrSharePasswordRequired = errors.New("share link password required")
+	ErrSharePasswordInvalid  = errors.New("share link password invalid")
+	// ErrSharePasswordLocked はパスワードを続けて間違えたため、共有リンクのパスワードをしばらく検証しないことを表すエラー
+	ErrSharePasswordLocked = errors.New("share link password attempts exceeded")
+)
+
+// SharePasswordAttemptStore は共有リンクごとのパスワードの試行回数の保存先
+// 複数のインスタンスで同じ回数を数えるよう、Redis（またはREDIS_DRIVER=embeddedのストア）に保存する
+type SharePasswordAttemptStore interface {
+	// ReserveAttempt は試行回数を1増やして増やした後の回数を返す（回数は最後の試行から ttl の間保持する）
+	// 回数の確認と増加を一度に行うため、並行した試行も全て数える
+	ReserveAttempt(ctx context.Context, linkID string, ttl time.Duration) (int, error)
+	// ResetAttempts は試行回数を削除する
+	ResetAttempts(ctx context.Context, linkID string) error
+}
+
+// sharePasswordAttempt は共有リンクのパスワードの試行回数
+type sharePasswordAttempt struct {
+	count     int
+	expiresAt time.Time
+}
+
+// sharePasswordThrottle は試行回数をこのプロセスのメモリで数える SharePasswordAttemptStore（ゼロ値で使用できる）
+// Redisを使えない場合に使い、回数は再起動で消え、インスタンス間で共有されない
+type sharePasswordThrottle struct {
+	mu       sync.Mutex
+	attempts map[string]sharePasswordAttempt
+}
+
+// ReserveAttempt は試行回数を1増やして増やした後の回数を返す
+func (t *sharePasswordThrottle) ReserveAttempt(ctx context.Context, linkID string, ttl time.Duration) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.attempts == nil {
+		t.attempts = make(map[string]sharePasswordAttempt)
+	}
+	if len(t.attempts) >= maxTrackedShareTokens {
+		for key, attempt := range t.attempts {
+			if !now.Before(attempt.expiresAt) {
+				delete(t.attempts, key)
+			}
+		}
+	}
+	attempt := t.attempts[linkID]
+	if !now.Before(attempt.expiresAt) {
+		attempt.count = 0
+	}
+	attempt.count++
+	attempt.expiresAt = now.Add(ttl)
+	t.attempts[linkID] = attempt
+	return attempt.count, nil
+}
+
+// ResetAttempts は試行回数を削除する
+func (t *sharePasswordThrottle) ResetAttempts(ctx context.Context, linkID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.attempts, linkID)
+	return nil
+}
+
+// ShareLinkRepository は共有リンクのリポジトリインターフェース
+type ShareLinkRepository interface {
+	CreateShareLink(ctx context.Context, link *domain.ShareLink) error
+	// 存在しない場合は ErrShareLinkNotFound を返す
+	GetShareLinkByID(ctx context.Context, id string) (*domain.ShareLink, error)
+	// 存在しない場合は ErrShareLinkNotFound を返す
+	GetShareLinkByToken(ctx context.Context, token string) (*domain.ShareLink, error)
+	ListShareLinksByOwner(ctx context.Context, ownerID string) ([]*domain.ShareLink, error)
+	CountActiveShareLinks(ctx context.Context, ownerID string, now time.Time) (int, error)
+	RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+// ShareURLShortener は共有リンクの短縮URLを発行する（同じリンクには同じ短縮URLを返す）
+type ShareURLShortener interface {
+	ShortenShareURL(ctx context.Context, link *domain.ShareLink) (string, error)
+}
+
+// ShareLinkInput は共有リンク作成の入力
+type ShareLinkInput struct {
+	ExpiresAt *time.Time
+	Password  string // 空の場合はパスワードなし
+}
+
+// ShareService はタスクの公開共有リンクを扱うサービス
+type ShareService struct {
+	TaskRepository      TaskRepository
+	ShareLinkRepository ShareLinkRepository
+	// 契約プランでの共有リンクの利用可否（nilの場合は全てのユーザーが利用できる）
+	Entitlements commonDomain.EntitlementChecker
+	// 利用状況の分析イベントの記録（nilの場合は記録しない）
+	Analytics commonDomain.AnalyticsTracker
+	// 共有リンクの短縮URLの発行（nilの場合は短縮しない）
+	ShortURLs ShareURLShortener
+	// パスワードの試行回数の保存先（nilの場合はこのプロセスのメモリで数える）
+	PasswordAttempts SharePasswordAttemptStore
+	Logger           logger.Logger
+
+	passwordThrottle sharePasswordThrottle
+}
+
+// NewShareService はShareServiceのコンストラクタ
+func NewShareService(
+	taskRepo TaskRepository,
+	shareLinkRepo ShareLinkRepository,
+	logger logger.Logger,
+) *ShareService {
+	return &ShareService{
+		TaskRepository:      taskRepo,
+		ShareLinkRepository: shareLinkRepo,
+		Logger:              logger,
+	}
+}
+
+// CreateTaskShareLink は単一タスクの共有リンクを作成する（作成者または担当者のみ）
+func (s *ShareService) CreateTaskShareLink(ctx context.Context, ownerID, taskID string, input ShareLinkInput) (*domain.ShareLink, error) {
+	if ownerID == "" || taskID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.CreatedBy != ownerID && !task.HasAssignee(ownerID) {
+		return nil, ErrPermissionDenied
+	}
+
+	link, err := domain.NewTaskShareLink(ownerID, taskID, input.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	return s.saveShareLink(ctx, link, input.Password)
+}
+
+// CreateTaskListShareLink はフィルタ済みタスク一覧の共有リンクを作成する
+// 共有範囲は作成者自身が作成したタスク、または自身が担当するタスクに限定する
+func (s *ShareService) CreateTaskListShareLink(ctx context.Context, ownerID string, filter domain.ListFilter, input ShareLinkInput) (*domain.ShareLink, error) {
+	if ownerID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	if filter.AssigneeID == nil || *filter.AssigneeID != ownerID {
+		filter.CreatedBy = &ownerID
+	}
+
+	link, err := domain.NewTaskListShareLink(ownerID, filter, input.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	return s.saveShareLink(ctx, link, input.Password)
+}
+
+// saveShareLink はパスワードを設定して共有リンクを保存する
+func (s *ShareService) saveShareLink(ctx context.Context, link *domain.ShareLink, password string) (*domain.ShareLink, error) {
+	if s.Entitlements != nil {
+		if err := s.Entitlements.CheckEntitlement(ctx, link.OwnerID, commonDomain.EntitlementShareLinks); err != nil {
+			return nil, err
+		}
+	}
+
+	if password != "" {
+		if err := domain.ValidateSharePassword(password); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+		}
+		hash, err := utils.HashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		link.PasswordHash = hash
+	}
+
+	count, err := s.ShareLinkRepository.CountActiveShareLinks(ctx, link.OwnerID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count share links: %w", err)
+	}
+	if count >= maxActiveShareLinksPerUser {
+		return nil, fmt.Errorf("%w: too many active share links (max %d)", ErrInvalidParameter, maxActiveShareLinksPerUser)
+	}
+
+	link.ID = uuid.New().String()
+	if err := s.ShareLinkRepository.CreateShareLink(ctx, link); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create share link",
+			logger.Any("ownerID", link.OwnerID), logger.Error(err))
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Share link created",
+		logger.Any("shareLinkID", link.ID),
+		logger.Any("ownerID", link.OwnerID),
+		logger.Any("targetType", link.TargetType))
+
+	if s.Analytics != nil {
+		s.Analytics.Track(ctx, link.OwnerID, commonDomain.AnalyticsFeatureUsed, map[string]interface{}{
+			"feature":     commonDomain.EntitlementShareLinks,
+			"target_type": string(link.TargetType),
+			"protected":   link.PasswordHash != "",
+			"has_expiry":  link.ExpiresAt != nil,
+		})
+	}
+
+	return link, nil
+}
+
+// ListShareLinks はユーザーが作成した共有リンクの一覧を取得する
+func (s *ShareService) ListShareLinks(ctx context.Context, ownerID string) ([]*domain.ShareLink, error) {
+	if ownerID == "" {
+		return nil, ErrInvalidParameter
+	}
+	return s.ShareLinkRepository.ListShareLinksByOwner(ctx, ownerID)
+}
+
+// ShortURL は閲覧可能な共有リンクの短縮URLを返す
+// 短縮しない設定の場合・無効化済みや期限切れの場合・発行に失敗した場合は空文字列を返す
+func (s *ShareService) ShortURL(ctx context.Context, link *domain.ShareLink) string {
+	if s.ShortURLs == nil || !link.IsActive(time.Now()) {
+		return ""
+	}
+	shortURL, err := s.ShortURLs.ShortenShareURL(ctx, link)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to shorten share link URL",
+			logger.Any("shareLinkID", link.ID), logger.Error(err))
+		return ""
+	}
+	return shortURL
+}
+
+// RevokeShareLink は共有リンクを無効化する（作成者のみ）
+func (s *ShareService) RevokeShareLink(ctx context.Context, ownerID, linkID string) error {
+	if ownerID == "" || linkID == "" {
+		return ErrInvalidParameter
+	}
+
+	link, err := s.ShareLinkRepository.GetShareLinkByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	if link.OwnerID != ownerID {
+		// 他人のリンクの存在は明かさない
+		return ErrShareLinkNotFound
+	}
+	if link.IsRevoked() {
+		return nil
+	}
+
+	if err := s.ShareLinkRepository.RevokeShareLink(ctx, linkID, time.Now()); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to revoke share link",
+			logger.Any("shareLinkID", linkID), logger.Error(err))
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Share link revoked", logger.Any("shareLinkID", linkID))
+	return nil
+}
+
+// ResolveShareLink はトークンから閲覧可能な共有リンクを取得する
+// 無効化済みのリンクは存在しないものとして扱う
+func (s *ShareService) ResolveShareLink(ctx context.Context, token, password string) (*domain.ShareLink, error) {
+	if token == "" {
+		return nil, ErrShareLinkNotFound
+	}
+
+	link, err := s.ShareLinkRepository.GetShareLinkByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if link.IsRevoked() {
+		return nil, ErrShareLinkNotFound
+	}
+	if link.IsExpired(time.Now()) {
+		return nil, ErrShareLinkExpired
+	}
+
+	if link.RequiresPassword() {
+		if password == "" {
+			return nil, ErrSharePasswordRequired
+		}
+		// 並行した推測で上限を超えないよう、パスワードを検証する前に試行を数える（正しい場合は回数を削除する）
+		attempts := s.passwordAttempts()
+		count, err := attempts.ReserveAttempt(ctx, link.ID, sharePasswordLockout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve share link password attempt: %w", err)
+		}
+		if count > maxSharePasswordFailures {
+			return nil, ErrSharePasswordLocked
+		}
+		if !utils.CheckPasswordHash(password, link.PasswordHash) {
+			return nil, ErrSharePasswordInvalid
+		}
+		if err := attempts.ResetAttempts(ctx, link.ID); err != nil {
+			s.Logger.Warn("Failed to reset share link password attempts", logger.Any("linkID", link.ID), logger.Error(err))
+		}
+	}
+
+	return link, nil
+}
+
+// passwordAttempts はパスワードの試行回数の保存先を返す
+func (s *ShareService) passwordAttempts() SharePasswordAttemptStore {
+	if s.PasswordAttempts != nil {
+		return s.PasswordAttempts
+	}
+	return &s.passwordThrottle
+}
+
+// SharedContent は共有リンクで閲覧できる内容を表す
+type SharedContent struct {
+	Link  *domain.ShareLink
+	Task  *domain.Task   // TASKの場合
+	Tasks []*domain.Task // TASK_LISTの場合
+	Total int
+}
+
+// ViewSharedContent はトークンとパスワードを検証し、共有リンクのタスクまたはタスク一覧を取得する
+func (s *ShareService) ViewSharedContent(ctx context.Context, token, password string, pagination domain.Pagination) (*SharedContent, error) {
+	link, err := s.ResolveShareLink(ctx, token, password)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &SharedContent{Link: link}
+	switch link.TargetType {
+	case domain.ShareTargetTask:
+		if link.TaskID == nil {
+			return nil, ErrShareLinkNotFound
+		}
+		task, err := s.TaskRepository.GetTaskByID(ctx, *link.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		domain.PrepareTaskForResponse(task)
+		content.Task = task
+
+	case domain.ShareTargetTaskList:
+		if link.Filter == nil {
+			return nil, ErrShareLinkNotFound
+		}
+		normalizePagination(&pagination)
+		tasks, total, err := s.TaskRepository.ListTasks(ctx, *link.Filter, pagination, domain.SortOptions{
+			Field:     "due_date",
+			Direction: "ASC",
+		})
+		if err != nil {
+			return nil, err
+		}
+		domain.PrepareTasksForResponse(tasks)
+		content.Tasks = tasks
+		content.Total = total
+
+	default:
+		return nil, ErrShareLinkNotFound
+	}
+
+	return content, nil
+}
@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+	"github.com/hryt430/Yotei+/pkg/utils"
+)
+
+//go:generate mockgen -source=share_service.go -destination=mocks/mock_share.go -package=mocks
+
+func newShareTestService(t *testing.T) (*ShareService, *mocks.MockTaskRepository, *mocks.MockShareLinkRepository) {
+	ctrl := gomock.NewController(t)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	linkRepo := mocks.NewMockShareLinkRepository(ctrl)
+	return NewShareService(taskRepo, linkRepo, *createTestLogger()), taskRepo, linkRepo
+}
+
+// entitlementFunc は関数をEntitlementCheckerとして使うテスト用のアダプタ
+type entitlementFunc func(ctx context.Context, userID, feature string) error
+
+func (f entitlementFunc) CheckEntitlement(ctx context.Context, userID, feature string) error {
+	return f(ctx, userID, feature)
+}
+
+// trackerFunc は関数をAnalyticsTrackerとして使うテスト用のアダプタ
+type trackerFunc func(ctx context.Context, userID, event string, properties map[string]interface{})
+
+func (f trackerFunc) Track(ctx context.Context, userID, event string, properties map[string]interface{}) {
+	f(ctx, userID, event, properties)
+}
+
+func TestShareService_CreateTaskShareLink(t *testing.T) {
+	task := &domain.Task{ID: "task-1", CreatedBy: "owner"}
+
+	t.Run("creator can share with password", func(t *testing.T) {
+		service, taskRepo, linkRepo := newShareTestService(t)
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		linkRepo.EXPECT().CountActiveShareLinks(gomock.Any(), "owner", gomock.Any()).Return(0, nil)
+		linkRepo.EXPECT().CreateShareLink(gomock.Any(), gomock.Any()).Return(nil)
+
+		link, err := service.CreateTaskShareLink(context.Background(), "owner", "task-1", ShareLinkInput{Password: "secret"})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, link.ID)
+		assert.True(t, link.RequiresPassword())
+		assert.NotEqual(t, "secret", link.PasswordHash)
+	})
+
+	t.Run("unrelated user cannot share", func(t *testing.T) {
+		service, taskRepo, _ := newShareTestService(t)
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+
+		_, err := service.CreateTaskShareLink(context.Background(), "stranger", "task-1", ShareLinkInput{})
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("too many active links", func(t *testing.T) {
+		service, taskRepo, linkRepo := newShareTestService(t)
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		linkRepo.EXPECT().CountActiveShareLinks(gomock.Any(), "owner", gomock.Any()).Return(maxActiveShareLinksPerUser, nil)
+
+		_, err := service.CreateTaskShareLink(context.Background(), "owner", "task-1", ShareLinkInput{})
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("plans without share links require an upgrade", func(t *testing.T) {
+		service, taskRepo, _ := newShareTestService(t)
+		var checkedFeature string
+		service.Entitlements = entitlementFunc(func(ctx context.Context, userID, feature string) error {
+			checkedFeature = feature
+			return commonDomain.ErrPlanUpgradeRequired
+		})
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+
+		_, err := service.CreateTaskShareLink(context.Background(), "owner", "task-1", ShareLinkInput{})
+
+		assert.ErrorIs(t, err, commonDomain.ErrPlanUpgradeRequired)
+		assert.Equal(t, commonDomain.EntitlementShareLinks, checkedFeature)
+	})
+
+	t.Run("created links are tracked as feature usage", func(t *testing.T) {
+		service, taskRepo, linkRepo := newShareTestService(t)
+		var tracked []map[string]interface{}
+		service.Analytics = trackerFunc(func(ctx context.Context, userID, event string, properties map[string]interface{}) {
+			assert.Equal(t, "owner", userID)
+			assert.Equal(t, commonDomain.AnalyticsFeatureUsed, event)
+			tracked = append(tracked, properties)
+		})
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+		linkRepo.EXPECT().CountActiveShareLinks(gomock.Any(), "owner", gomock.Any()).Return(0, nil)
+		linkRepo.EXPECT().CreateShareLink(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := service.CreateTaskShareLink(context.Background(), "owner", "task-1", ShareLinkInput{})
+
+		require.NoError(t, err)
+		require.Len(t, tracked, 1)
+		assert.Equal(t, commonDomain.EntitlementShareLinks, tracked[0]["feature"])
+		assert.Equal(t, false, tracked[0]["protected"])
+	})
+}
+
+func TestShareService_CreateTaskListShareLink_RestrictsScope(t *testing.T) {
+	service, _, linkRepo := newShareTestService(t)
+	linkRepo.EXPECT().CountActiveShareLinks(gomock.Any(), "owner", gomock.Any()).Return(0, nil).Times(2)
+	linkRepo.EXPECT().CreateShareLink(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	other := "someone-else"
+	link, err := service.CreateTaskListShareLink(context.Background(), "owner", domain.ListFilter{CreatedBy: &other}, ShareLinkInput{})
+	require.NoError(t, err)
+	require.NotNil(t, link.Filter.CreatedBy)
+	assert.Equal(t, "owner", *link.Filter.CreatedBy)
+
+	owner := "owner"
+	link, err = service.CreateTaskListShareLink(context.Background(), "owner", domain.ListFilter{AssigneeID: &owner}, ShareLinkInput{})
+	require.NoError(t, err)
+	assert.Nil(t, link.Filter.CreatedBy)
+}
+
+func TestShareService_ViewSharedContent(t *testing.T) {
+	taskID := "task-1"
+	newLink := func(t *testing.T, password string) *domain.ShareLink {
+		link, err := domain.NewTaskShareLink("owner", taskID, nil)
+		require.NoError(t, err)
+		link.ID = uuid.New().String()
+		if password != "" {
+			hash, err := utils.HashPassword(password)
+			require.NoError(t, err)
+			link.PasswordHash = hash
+		}
+		return link
+	}
+
+	t.Run("task link", func(t *testing.T) {
+		service, taskRepo, linkRepo := newShareTestService(t)
+		link := newLink(t, "")
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil)
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), taskID).Return(&domain.Task{ID: taskID, Title: "shared"}, nil)
+
+		content, err := service.ViewSharedContent(context.Background(), link.Token, "", domain.Pagination{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "shared", content.Task.Title)
+	})
+
+	t.Run("password required and invalid", func(t *testing.T) {
+		service, _, linkRepo := newShareTestService(t)
+		link := newLink(t, "secret")
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil).Times(2)
+
+		_, err := service.ViewSharedContent(context.Background(), link.Token, "", domain.Pagination{})
+		assert.ErrorIs(t, err, ErrSharePasswordRequired)
+
+		_, err = service.ViewSharedContent(context.Background(), link.Token, "wrong", domain.Pagination{})
+		assert.ErrorIs(t, err, ErrSharePasswordInvalid)
+	})
+
+	t.Run("too many invalid passwords lock the link", func(t *testing.T) {
+		service, taskRepo, linkRepo := newShareTestService(t)
+		link := newLink(t, "secret")
+		other := newLink(t, "secret")
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil).Times(maxSharePasswordFailures + 1)
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), other.Token).Return(other, nil)
+		taskRepo.EXPECT().GetTaskByID(gomock.Any(), taskID).Return(&domain.Task{ID: taskID, Title: "shared"}, nil)
+
+		for i := 0; i < maxSharePasswordFailures; i++ {
+			_, err := service.ViewSharedContent(context.Background(), link.Token, "wrong", domain.Pagination{})
+			assert.ErrorIs(t, err, ErrSharePasswordInvalid)
+		}
+
+		// 上限に達したリンクは正しいパスワードでも検証しない
+		_, err := service.ViewSharedContent(context.Background(), link.Token, "secret", domain.Pagination{})
+		assert.ErrorIs(t, err, ErrSharePasswordLocked)
+
+		// 他のリンクには影響しない
+		_, err = service.ViewSharedContent(context.Background(), other.Token, "secret", domain.Pagination{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("parallel guesses cannot exceed the limit", func(t *testing.T) {
+		service, _, linkRepo := newShareTestService(t)
+		link := newLink(t, "secret")
+		const guesses = maxSharePasswordFailures * 3
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil).Times(guesses)
+
+		var mu sync.Mutex
+		checked := 0
+		var wg sync.WaitGroup
+		for i := 0; i < guesses; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.ResolveShareLink(context.Background(), link.Token, "wrong")
+				if errors.Is(err, ErrSharePasswordInvalid) {
+					mu.Lock()
+					checked++
+					mu.Unlock()
+				} else {
+					assert.ErrorIs(t, err, ErrSharePasswordLocked)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// パスワードを検証するのは上限の回数まで
+		assert.Equal(t, maxSharePasswordFailures, checked)
+	})
+
+	t.Run("attempts are counted in the shared store", func(t *testing.T) {
+		service, _, linkRepo := newShareTestService(t)
+		attempts := mocks.NewMockSharePasswordAttemptStore(gomock.NewController(t))
+		service.PasswordAttempts = attempts
+		link := newLink(t, "secret")
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil).Times(3)
+
+		gomock.InOrder(
+			attempts.EXPECT().ReserveAttempt(gomock.Any(), link.ID, sharePasswordLockout).Return(1, nil),
+			attempts.EXPECT().ReserveAttempt(gomock.Any(), link.ID, sharePasswordLockout).Return(2, nil),
+			attempts.EXPECT().ResetAttempts(gomock.Any(), link.ID).Return(nil),
+			attempts.EXPECT().ReserveAttempt(gomock.Any(), link.ID, sharePasswordLockout).Return(maxSharePasswordFailures+1, nil),
+		)
+
+		_, err := service.ResolveShareLink(context.Background(), link.Token, "wrong")
+		assert.ErrorIs(t, err, ErrSharePasswordInvalid)
+
+		// 正しいパスワードの場合は回数を削除する
+		_, err = service.ResolveShareLink(context.Background(), link.Token, "secret")
+		assert.NoError(t, err)
+
+		// 他のインスタンスで上限に達した場合は検証しない
+		_, err = service.ResolveShareLink(context.Background(), link.Token, "secret")
+		assert.ErrorIs(t, err, ErrSharePasswordLocked)
+	})
+
+	t.Run("store errors reject the attempt", func(t *testing.T) {
+		service, _, linkRepo := newShareTestService(t)
+		attempts := mocks.NewMockSharePasswordAttemptStore(gomock.NewController(t))
+		service.PasswordAttempts = attempts
+		link := newLink(t, "secret")
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil)
+		attempts.EXPECT().ReserveAttempt(gomock.Any(), link.ID, sharePasswordLockout).Return(0, errors.New("redis down"))
+
+		_, err := service.ResolveShareLink(context.Background(), link.Token, "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("expired and revoked links", func(t *testing.T) {
+		service, _, linkRepo := newShareTestService(t)
+		expired := newLink(t, "")
+		past := time.Now().Add(-time.Minute)
+		expired.ExpiresAt = &past
+		revoked := newLink(t, "")
+		revoked.Revoke()
+
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), expired.Token).Return(expired, nil)
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), revoked.Token).Return(revoked, nil)
+
+		_, err := service.ViewSharedContent(context.Background(), expired.Token, "", domain.Pagination{})
+		assert.ErrorIs(t, err, ErrShareLinkExpired)
+
+		_, err = service.ViewSharedContent(context.Background(), revoked.Token, "", domain.Pagination{})
+		assert.ErrorIs(t, err, ErrShareLinkNotFound)
+	})
+
+	t.Run("list link uses stored filter", func(t *testing.T) {
+		service, taskRepo, linkRepo := newShareTestService(t)
+		owner := "owner"
+		link, err := domain.NewTaskListShareLink(owner, domain.ListFilter{CreatedBy: &owner}, nil)
+		require.NoError(t, err)
+		linkRepo.EXPECT().GetShareLinkByToken(gomock.Any(), link.Token).Return(link, nil)
+		taskRepo.EXPECT().
+			ListTasks(gomock.Any(), *link.Filter, domain.Pagination{Page: 1, PageSize: 20}, gomock.Any()).
+			Return([]*domain.Task{{ID: "a"}, {ID: "b"}}, 2, nil)
+
+		content, err := service.ViewSharedContent(context.Background(), link.Token, "", domain.Pagination{})
+
+		require.NoError(t, err)
+		assert.Len(t, content.Tasks, 2)
+		assert.Equal(t, 2, content.Total)
+	})
+}
+
+func TestShareService_RevokeShareLink(t *testing.T) {
+	service, _, linkRepo := newShareTestService(t)
+	link, err := domain.NewTaskShareLink("owner", "task-1", nil)
+	require.NoError(t, err)
+	link.ID = "link-1"
+
+	linkRepo.EXPECT().GetShareLinkByID(gomock.Any(), "link-1").Return(link, nil).Times(2)
+	linkRepo.EXPECT().RevokeShareLink(gomock.Any(), "link-1", gomock.Any()).Return(nil)
+
+	assert.ErrorIs(t, service.RevokeShareLink(context.Background(), "stranger", "link-1"), ErrShareLinkNotFound)
+	assert.NoError(t, service.RevokeShareLink(context.Background(), "owner", "link-1"))
+}
+
+func TestShareService_ShortURL(t *testing.T) {
+	link, err := domain.NewTaskShareLink("owner", "task-1", nil)
+	require.NoError(t, err)
+	link.ID = "link-1"
+
+	t.Run("returns nothing when short links are not configured", func(t *testing.T) {
+		service, _, _ := newShareTestService(t)
+		assert.Empty(t, service.ShortURL(context.Background(), link))
+	})
+
+	t.Run("shortens active links", func(t *testing.T) {
+		service, _, _ := newShareTestService(t)
+		shortener := mocks.NewMockShareURLShortener(gomock.NewController(t))
+		service.ShortURLs = shortener
+		shortener.EXPECT().ShortenShareURL(gomock.Any(), link).Return("https://yt.plus/s/aZ3kP9q", nil)
+
+		assert.Equal(t, "https://yt.plus/s/aZ3kP9q", service.ShortURL(context.Background(), link))
+	})
+
+	t.Run("falls back to no short URL when shortening fails", func(t *testing.T) {
+		service, _, _ := newShareTestService(t)
+		shortener := mocks.NewMockShareURLShortener(gomock.NewController(t))
+		service.ShortURLs = shortener
+		shortener.EXPECT().ShortenShareURL(gomock.Any(), link).Return("", errors.New("db down"))
+
+		assert.Empty(t, service.ShortURL(context.Background(), link))
+	})
+
+	t.Run("revoked links are not shortened", func(t *testing.T) {
+		service, _, _ := newShareTestService(t)
+		service.ShortURLs = mocks.NewMockShareURLShortener(gomock.NewController(t))
+		revoked := *link
+		revoked.Revoke()
+
+		assert.Empty(t, service.ShortURL(context.Background(), &revoked))
+	})
+}
@@ -22,13 +22,26 @@ type StatsRepository interface {
 
 	// GetOverdueTasksCount は期限切れタスク数を取得する
 	GetOverdueTasksCount(ctx context.Context, userID string) (int, error)
+
+	// GetCompletedTasksByDateRange は指定期間に完了したタスクを取得する
+	GetCompletedTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error)
+
+	// GetScheduledTasksByDateRange は着手予定日時または期限が指定期間内にあるタスクを取得する
+	GetScheduledTasksByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error)
+}
+
+// DailyStatsSource は集計テーブルから日次統計を取得するインターフェース
+type DailyStatsSource interface {
+	// GetDailyStatsRange はfromからtoまで（UTCの日付、toを含む）の日次統計を日付順に取得する
+	GetDailyStatsRange(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyStats, error)
 }
 
 // TaskStatsService はタスク統計情報を提供するサービス
 type TaskStatsService struct {
-	taskRepo  TaskRepository
-	statsRepo StatsRepository
-	logger    *logger.Logger
+	taskRepo   TaskRepository
+	statsRepo  StatsRepository
+	dailyStats DailyStatsSource // nilの場合は毎回タスクから集計する
+	logger     *logger.Logger
 }
 
 // NewTaskStatsService は新しいTaskStatsServiceを作成する
@@ -44,6 +57,11 @@ func NewTaskStatsService(
 	}
 }
 
+// SetDailyStatsSource は日次・週次統計の取得に使う集計テーブルを設定する
+func (s *TaskStatsService) SetDailyStatsSource(source DailyStatsSource) {
+	s.dailyStats = source
+}
+
 // GetDashboardStats はダッシュボード用の統計情報を取得する
 func (s *TaskStatsService) GetDashboardStats(ctx context.Context, userID string) (*domain.DashboardStats, error) {
 	now := time.Now()
@@ -51,14 +69,14 @@ func (s *TaskStatsService) GetDashboardStats(ctx context.Context, userID string)
 	// 今日の統計
 	todayStats, err := s.GetDailyStats(ctx, userID, now)
 	if err != nil {
-		s.logger.Error("Failed to get today stats", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get today stats", logger.Any("userID", userID), logger.Error(err))
 		return nil, fmt.Errorf("failed to get today stats: %w", err)
 	}
 
 	// 今週の統計
 	weeklyOverview, err := s.GetWeeklyStats(ctx, userID, now)
 	if err != nil {
-		s.logger.Error("Failed to get weekly stats", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get weekly stats", logger.Any("userID", userID), logger.Error(err))
 		return nil, fmt.Errorf("failed to get weekly stats: %w", err)
 	}
 
@@ -66,35 +84,35 @@ func (s *TaskStatsService) GetDashboardStats(ctx context.Context, userID string)
 	nextWeek := now.AddDate(0, 0, 7)
 	upcomingWeekTasks, err := s.GetWeeklyPreview(ctx, userID, nextWeek)
 	if err != nil {
-		s.logger.Error("Failed to get upcoming week tasks", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get upcoming week tasks", logger.Any("userID", userID), logger.Error(err))
 		return nil, fmt.Errorf("failed to get upcoming week tasks: %w", err)
 	}
 
 	// カテゴリ別統計
 	categoryBreakdown, err := s.GetCategoryBreakdown(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get category breakdown", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get category breakdown", logger.Any("userID", userID), logger.Error(err))
 		categoryBreakdown = make(map[domain.Category]int) // エラー時は空のマップ
 	}
 
 	// 優先度別統計
 	priorityBreakdown, err := s.GetPriorityBreakdown(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get priority breakdown", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get priority breakdown", logger.Any("userID", userID), logger.Error(err))
 		priorityBreakdown = make(map[domain.Priority]int) // エラー時は空のマップ
 	}
 
 	// 最近の完了タスク
 	recentCompletions, err := s.statsRepo.GetRecentCompletedTasks(ctx, userID, 5)
 	if err != nil {
-		s.logger.Error("Failed to get recent completions", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get recent completions", logger.Any("userID", userID), logger.Error(err))
 		recentCompletions = []*domain.Task{} // エラー時は空のスライス
 	}
 
 	// 期限切れタスク数
 	overdueCount, err := s.statsRepo.GetOverdueTasksCount(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get overdue count", logger.Any("userID", userID), logger.Error(err))
+		s.logger.WithContext(ctx).Error("Failed to get overdue count", logger.Any("userID", userID), logger.Error(err))
 		overdueCount = 0 // エラー時は0
 	}
 
@@ -110,19 +128,35 @@ func (s *TaskStatsService) GetDashboardStats(ctx context.Context, userID string)
 }
 
 // GetDailyStats は指定日の統計情報を取得する
+// 集計テーブルが設定されている場合、UTCの日付はテーブルから取得する
 func (s *TaskStatsService) GetDailyStats(ctx context.Context, userID string, date time.Time) (*domain.DailyStats, error) {
+	if s.dailyStats != nil && date.Location() == time.UTC {
+		days, err := s.dailyStats.GetDailyStatsRange(ctx, userID, date, date)
+		if err == nil && len(days) == 1 {
+			return days[0], nil
+		}
+		s.logger.WithContext(ctx).Warn("Failed to get materialized daily stats, computing from tasks",
+			logger.Any("userID", userID), logger.Error(err))
+	}
+
+	stats, _, err := loadDailyStats(ctx, s.statsRepo, userID, date)
+	return stats, err
+}
+
+// loadDailyStats はその日が期限または作成日のタスクから日次統計を集計する（集計したタスクも返す）
+func loadDailyStats(ctx context.Context, statsRepo StatsRepository, userID string, date time.Time) (*domain.DailyStats, []*domain.Task, error) {
 	dayStart, dayEnd := domain.GetDayStartEnd(date)
 
 	// その日が期限のタスクを取得
-	tasks, err := s.statsRepo.GetTasksByDueDate(ctx, userID, date)
+	tasks, err := statsRepo.GetTasksByDueDate(ctx, userID, date)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks by due date: %w", err)
+		return nil, nil, fmt.Errorf("failed to get tasks by due date: %w", err)
 	}
 
 	// その日に作成されたタスクも含める
-	createdTasks, err := s.statsRepo.GetTasksByDateRange(ctx, userID, dayStart, dayEnd)
+	createdTasks, err := statsRepo.GetTasksByDateRange(ctx, userID, dayStart, dayEnd)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks by date range: %w", err)
+		return nil, nil, fmt.Errorf("failed to get tasks by date range: %w", err)
 	}
 
 	// 重複を除去してマージ
@@ -139,7 +173,7 @@ func (s *TaskStatsService) GetDailyStats(ctx context.Context, userID string, dat
 		allTasks = append(allTasks, task)
 	}
 
-	return domain.NewDailyStats(date, allTasks), nil
+	return domain.NewDailyStats(date, allTasks), allTasks, nil
 }
 
 // GetWeeklyStats は指定週の統計情報を取得する
@@ -148,11 +182,24 @@ func (s *TaskStatsService) GetWeeklyStats(ctx context.Context, userID string, da
 
 	dailyStats := make(map[string]*domain.DailyStats)
 
+	// 集計テーブルが設定されている場合、UTCの週はまとめて取得する
+	if s.dailyStats != nil && date.Location() == time.UTC {
+		days, err := s.dailyStats.GetDailyStatsRange(ctx, userID, weekStart, weekEnd)
+		if err == nil && len(days) == 7 {
+			for _, day := range days {
+				dailyStats[domain.GetWeekdayName(day.Date.Weekday())] = day
+			}
+			return domain.NewWeeklyStats(weekStart, weekEnd, dailyStats), nil
+		}
+		s.logger.WithContext(ctx).Warn("Failed to get materialized weekly stats, computing from tasks",
+			logger.Any("userID", userID), logger.Error(err))
+	}
+
 	// 各曜日の統計を取得
 	for d := weekStart; !d.After(weekEnd); d = d.AddDate(0, 0, 1) {
 		dayStats, err := s.GetDailyStats(ctx, userID, d)
 		if err != nil {
-			s.logger.Error("Failed to get daily stats",
+			s.logger.WithContext(ctx).Error("Failed to get daily stats",
 				logger.Any("userID", userID),
 				logger.Any("date", d),
 				logger.Error(err))
@@ -181,7 +228,7 @@ func (s *TaskStatsService) GetWeeklyPreview(ctx context.Context, userID string,
 	for d := weekStart; !d.After(weekEnd); d = d.AddDate(0, 0, 1) {
 		tasks, err := s.statsRepo.GetTasksByDueDate(ctx, userID, d)
 		if err != nil {
-			s.logger.Error("Failed to get tasks for preview",
+			s.logger.WithContext(ctx).Error("Failed to get tasks for preview",
 				logger.Any("userID", userID),
 				logger.Any("date", d),
 				logger.Error(err))
@@ -269,7 +316,7 @@ func (s *TaskStatsService) GetProgressSummary(ctx context.Context, userID string
 		date := today.AddDate(0, 0, -i)
 		dailyStats, err := s.GetDailyStats(ctx, userID, date)
 		if err != nil {
-			s.logger.Error("Failed to get daily stats for summary",
+			s.logger.WithContext(ctx).Error("Failed to get daily stats for summary",
 				logger.Any("userID", userID),
 				logger.Any("date", date),
 				logger.Error(err))
@@ -315,3 +362,124 @@ func (s *TaskStatsService) GetMonthlyStats(ctx context.Context, userID string, y
 
 	return domain.NewWeeklyStats(monthStart, monthEnd, dailyStats), nil
 }
+
+// GetVelocityReport は直近weeks週分のベロシティと見積もり精度を取得する（今週を含む）
+func (s *TaskStatsService) GetVelocityReport(ctx context.Context, userID string, weeks int, now time.Time) (*domain.VelocityReport, error) {
+	if weeks < 1 || weeks > 52 {
+		return nil, fmt.Errorf("%w: weeks must be between 1 and 52", ErrInvalidParameter)
+	}
+
+	thisWeekStart, thisWeekEnd := domain.GetWeekStartEnd(now)
+	from := thisWeekStart.AddDate(0, 0, -7*(weeks-1))
+
+	tasks, err := s.statsRepo.GetCompletedTasksByDateRange(ctx, userID, from, thisWeekEnd)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get completed tasks for velocity",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+
+	return domain.NewVelocityReport(tasks, from, thisWeekEnd), nil
+}
+
+// GetStatsComparison は現在の期間とoffset期間前の実績（完了・作成・期限切れ・完了率）を比較する
+// 比較対象は期間の開始から現在と同じ経過時点までを集計する
+func (s *TaskStatsService) GetStatsComparison(ctx context.Context, userID string, period domain.ComparisonPeriod, offset int, now time.Time) (*domain.StatsComparison, error) {
+	if offset < 1 || offset > domain.MaxComparisonOffset {
+		return nil, fmt.Errorf("%w: offset must be between 1 and %d", ErrInvalidParameter, domain.MaxComparisonOffset)
+	}
+
+	currentFrom, currentTo, previousFrom, previousTo := domain.ComparisonRanges(period, offset, now)
+
+	current, err := s.getPeriodMetrics(ctx, userID, currentFrom, currentTo)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.getPeriodMetrics(ctx, userID, previousFrom, previousTo)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewStatsComparison(period, offset, current, previous), nil
+}
+
+// StatsSeriesQuery は時系列統計の取得条件
+type StatsSeriesQuery struct {
+	From        time.Time // 暦日として扱う
+	To          time.Time // 暦日として扱う（この日を含む）
+	Granularity domain.SeriesGranularity
+	Rolling     int    // 移動平均の区間数（0または1の場合は付けない）
+	Timezone    string // 空の場合は UTC
+}
+
+// GetStatsSeries は任意の期間の実績（完了・作成・期限・期限切れ・完了率）を日・週・月ごとに取得する
+// 期間は区間の境界に広げ、タスクのない区間も0で埋める
+func (s *TaskStatsService) GetStatsSeries(ctx context.Context, userID string, query StatsSeriesQuery, now time.Time) (*domain.StatsSeries, error) {
+	if query.Rolling < 0 || query.Rolling > domain.MaxSeriesRollingWindow {
+		return nil, fmt.Errorf("%w: rolling must be between 0 and %d", ErrInvalidParameter, domain.MaxSeriesRollingWindow)
+	}
+	loc := time.UTC
+	if query.Timezone != "" {
+		l, err := time.LoadLocation(query.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timezone: %s", ErrInvalidParameter, query.Timezone)
+		}
+		loc = l
+	}
+
+	from := time.Date(query.From.Year(), query.From.Month(), query.From.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(query.To.Year(), query.To.Month(), query.To.Day(), 0, 0, 0, 0, loc)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidParameter)
+	}
+	fetchFrom, _, end, points := domain.SeriesRange(query.Granularity, from, to, query.Rolling)
+	if points > domain.MaxSeriesPoints {
+		return nil, fmt.Errorf("%w: range must be within %d points", ErrInvalidParameter, domain.MaxSeriesPoints)
+	}
+	fetchTo := end.Add(-time.Nanosecond)
+
+	created, err := s.statsRepo.GetTasksByDateRange(ctx, userID, fetchFrom, fetchTo)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get tasks for series",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get tasks by date range: %w", err)
+	}
+	completed, err := s.statsRepo.GetCompletedTasksByDateRange(ctx, userID, fetchFrom, fetchTo)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get completed tasks for series",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+	scheduled, err := s.statsRepo.GetScheduledTasksByDateRange(ctx, userID, fetchFrom, fetchTo)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get scheduled tasks for series",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get scheduled tasks: %w", err)
+	}
+
+	return domain.NewStatsSeries(query.Granularity, from, to, query.Rolling, created, completed, scheduled, now), nil
+}
+
+// getPeriodMetrics は期間内の実績を集計する
+func (s *TaskStatsService) getPeriodMetrics(ctx context.Context, userID string, from, to time.Time) (domain.PeriodMetrics, error) {
+	created, err := s.statsRepo.GetTasksByDateRange(ctx, userID, from, to)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get tasks for comparison",
+			logger.Any("userID", userID), logger.Error(err))
+		return domain.PeriodMetrics{}, fmt.Errorf("failed to get tasks by date range: %w", err)
+	}
+	completed, err := s.statsRepo.GetCompletedTasksByDateRange(ctx, userID, from, to)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get completed tasks for comparison",
+			logger.Any("userID", userID), logger.Error(err))
+		return domain.PeriodMetrics{}, fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+	scheduled, err := s.statsRepo.GetScheduledTasksByDateRange(ctx, userID, from, to)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to get scheduled tasks for comparison",
+			logger.Any("userID", userID), logger.Error(err))
+		return domain.PeriodMetrics{}, fmt.Errorf("failed to get scheduled tasks: %w", err)
+	}
+
+	return domain.NewPeriodMetrics(from, to, created, completed, scheduled), nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hryt430/Yotei+/internal/modules/task/domain"
 	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
@@ -882,3 +883,200 @@ func TestTaskStatsService_GetWeeklyPreview(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskStatsService_GetVelocityReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatsRepo := mocks.NewMockStatsRepository(ctrl)
+	service := NewTaskStatsService(mocks.NewMockTaskRepository(ctrl), mockStatsRepo, createTestLogger())
+
+	now := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC) // 水曜
+	completedAt := time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC)
+	estimate, actual := 60, 90
+
+	t.Run("success", func(t *testing.T) {
+		mockStatsRepo.EXPECT().
+			GetCompletedTasksByDateRange(gomock.Any(), "user123",
+				time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 16, 23, 59, 59, 999999999, time.UTC)).
+			Return([]*domain.Task{{
+				ID:              "task1",
+				Status:          domain.TaskStatusDone,
+				Category:        domain.CategoryWork,
+				CompletedAt:     &completedAt,
+				EstimateMinutes: &estimate,
+				ActualMinutes:   &actual,
+			}}, nil)
+
+		report, err := service.GetVelocityReport(context.Background(), "user123", 2, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Weeks, 2)
+		assert.Equal(t, 1, report.Weeks[0].CompletedTasks)
+		assert.Equal(t, 1.5, report.Total.EstimateRatio)
+	})
+
+	t.Run("invalid weeks", func(t *testing.T) {
+		_, err := service.GetVelocityReport(context.Background(), "user123", 0, now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockStatsRepo.EXPECT().
+			GetCompletedTasksByDateRange(gomock.Any(), "user123", gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		_, err := service.GetVelocityReport(context.Background(), "user123", 4, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskStatsService_GetStatsComparison(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatsRepo := mocks.NewMockStatsRepository(ctrl)
+	service := NewTaskStatsService(mocks.NewMockTaskRepository(ctrl), mockStatsRepo, createTestLogger())
+
+	now := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC) // 水曜
+	currentFrom := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	previousFrom := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	previousTo := time.Date(2024, 6, 5, 15, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2024, 6, 11, 10, 0, 0, 0, time.UTC)
+	previousCompletedAt := completedAt.AddDate(0, 0, -7)
+
+	t.Run("success", func(t *testing.T) {
+		current := []*domain.Task{
+			{ID: "task1", CreatedAt: currentFrom, CompletedAt: &completedAt},
+			{ID: "task2", CreatedAt: currentFrom, CompletedAt: &completedAt},
+		}
+		previous := []*domain.Task{{ID: "task3", CreatedAt: previousFrom, CompletedAt: &previousCompletedAt}}
+
+		mockStatsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), "user123", currentFrom, now).Return(current, nil)
+		mockStatsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "user123", currentFrom, now).Return(current, nil)
+		mockStatsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user123", currentFrom, now).Return(nil, nil)
+		mockStatsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), "user123", previousFrom, previousTo).Return(previous, nil)
+		mockStatsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "user123", previousFrom, previousTo).Return(previous, nil)
+		mockStatsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user123", previousFrom, previousTo).Return(nil, nil)
+
+		comparison, err := service.GetStatsComparison(context.Background(), "user123", domain.ComparisonPeriodWeek, 1, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, comparison.Current.Completed)
+		assert.Equal(t, 1, comparison.Previous.Completed)
+		assert.Equal(t, 100.0, *comparison.Changes.Completed.PercentChange)
+	})
+
+	t.Run("invalid offset", func(t *testing.T) {
+		_, err := service.GetStatsComparison(context.Background(), "user123", domain.ComparisonPeriodWeek, 0, now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockStatsRepo.EXPECT().
+			GetTasksByDateRange(gomock.Any(), "user123", gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		_, err := service.GetStatsComparison(context.Background(), "user123", domain.ComparisonPeriodDay, 1, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskStatsService_GetStatsSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatsRepo := mocks.NewMockStatsRepository(ctrl)
+	service := NewTaskStatsService(mocks.NewMockTaskRepository(ctrl), mockStatsRepo, createTestLogger())
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Date(2024, 6, 20, 12, 0, 0, 0, tokyo)
+	from := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 18, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		// Weeks of 2024-06-10 and 2024-06-17 plus one week before for the rolling average
+		fetchFrom := time.Date(2024, 6, 3, 0, 0, 0, 0, tokyo)
+		fetchTo := time.Date(2024, 6, 24, 0, 0, 0, 0, tokyo).Add(-time.Nanosecond)
+		completedAt := time.Date(2024, 6, 11, 10, 0, 0, 0, tokyo)
+		tasks := []*domain.Task{{ID: "task1", CreatedAt: completedAt, CompletedAt: &completedAt}}
+
+		mockStatsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), "user123", fetchFrom, fetchTo).Return(tasks, nil)
+		mockStatsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "user123", fetchFrom, fetchTo).Return(tasks, nil)
+		mockStatsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user123", fetchFrom, fetchTo).Return(nil, nil)
+
+		series, err := service.GetStatsSeries(context.Background(), "user123", StatsSeriesQuery{
+			From: from, To: to, Granularity: domain.SeriesGranularityWeek, Rolling: 2, Timezone: "Asia/Tokyo",
+		}, now)
+
+		require.NoError(t, err)
+		require.Len(t, series.Points, 2)
+		assert.Equal(t, "Asia/Tokyo", series.Timezone)
+		assert.Equal(t, 1, series.Points[0].Completed)
+		assert.Equal(t, 0, series.Points[1].Completed)
+		assert.Equal(t, 0.5, series.Points[1].RollingAverage.Completed)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := service.GetStatsSeries(context.Background(), "user123", StatsSeriesQuery{From: to, To: from, Granularity: domain.SeriesGranularityDay}, now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		_, err = service.GetStatsSeries(context.Background(), "user123", StatsSeriesQuery{From: from, To: to, Granularity: domain.SeriesGranularityDay, Timezone: "Mars/Olympus"}, now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		_, err = service.GetStatsSeries(context.Background(), "user123", StatsSeriesQuery{From: from, To: from.AddDate(2, 0, 0), Granularity: domain.SeriesGranularityDay}, now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		_, err = service.GetStatsSeries(context.Background(), "user123", StatsSeriesQuery{From: from, To: to, Granularity: domain.SeriesGranularityDay, Rolling: 31}, now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestTaskStatsService_DailyStatsSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStatsRepo := mocks.NewMockStatsRepository(ctrl)
+	source := mocks.NewMockDailyStatsSource(ctrl)
+	service := NewTaskStatsService(mocks.NewMockTaskRepository(ctrl), mockStatsRepo, createTestLogger())
+	service.SetDailyStatsSource(source)
+
+	t.Run("daily stats for a UTC date come from the source", func(t *testing.T) {
+		date := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+		source.EXPECT().GetDailyStatsRange(gomock.Any(), "user123", date, date).
+			Return([]*domain.DailyStats{{Date: date, TotalTasks: 3}}, nil)
+
+		stats, err := service.GetDailyStats(context.Background(), "user123", date)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.TotalTasks)
+	})
+
+	t.Run("weekly stats fetch the whole week at once", func(t *testing.T) {
+		date := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+		source.EXPECT().GetDailyStatsRange(gomock.Any(), "user123", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID string, from, to time.Time) ([]*domain.DailyStats, error) {
+				var days []*domain.DailyStats
+				for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+					days = append(days, &domain.DailyStats{Date: d, TotalTasks: 1, CompletedTasks: 1})
+				}
+				return days, nil
+			})
+
+		stats, err := service.GetWeeklyStats(context.Background(), "user123", date)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, stats.TotalTasks)
+	})
+
+	t.Run("non-UTC dates are computed from tasks", func(t *testing.T) {
+		date := time.Date(2024, 6, 10, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+		mockStatsRepo.EXPECT().GetTasksByDueDate(gomock.Any(), "user123", date).Return(nil, nil)
+		mockStatsRepo.EXPECT().GetTasksByDateRange(gomock.Any(), "user123", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		stats, err := service.GetDailyStats(context.Background(), "user123", date)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.TotalTasks)
+	})
+}
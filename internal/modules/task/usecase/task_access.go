@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// getViewableTask はユーザーが閲覧できるタスクを取得する
+// 添付ファイル・場所・リンク・Issueの関連付け・変更履歴の参照で使う
+func getViewableTask(ctx context.Context, taskRepo TaskRepository, resolver GroupTaskResolver, taskID, userID string) (*domain.Task, error) {
+	return getTaskWithAccess(ctx, taskRepo, resolver, taskID, userID, domain.GroupActionViewTasks)
+}
+
+// getEditableTask はユーザーが変更できるタスクを取得する
+// 添付ファイル・場所・リンク・Issueの関連付けの変更で使う（ゲストなど閲覧のみのメンバーは変更できない）
+func getEditableTask(ctx context.Context, taskRepo TaskRepository, resolver GroupTaskResolver, taskID, userID string) (*domain.Task, error) {
+	return getTaskWithAccess(ctx, taskRepo, resolver, taskID, userID, domain.GroupActionEditTasks)
+}
+
+func getTaskWithAccess(ctx context.Context, taskRepo TaskRepository, resolver GroupTaskResolver, taskID, userID string, action domain.GroupAction) (*domain.Task, error) {
+	task, err := taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := checkTaskAccess(ctx, resolver, task, userID, action); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// checkTaskAccess はユーザーがタスクの作成者・担当者か、タスクが属するグループのいずれかでアクションを実行できるかを確認する
+func checkTaskAccess(ctx context.Context, resolver GroupTaskResolver, task *domain.Task, userID string, action domain.GroupAction) error {
+	if task.CreatedBy == userID || task.HasAssignee(userID) {
+		return nil
+	}
+	if resolver == nil {
+		return ErrPermissionDenied
+	}
+
+	groupIDs, err := resolver.GetGroupIDsForTask(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get task groups: %w", err)
+	}
+	for _, groupID := range groupIDs {
+		allowed, err := resolver.CheckGroupPermission(ctx, groupID, userID, action)
+		if err != nil {
+			return fmt.Errorf("failed to check group permission: %w", err)
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return ErrPermissionDenied
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// TaskLinkRepository はユーザーがタスクに追加したリンクのリポジトリインターフェース
+type TaskLinkRepository interface {
+	CreateTaskLink(ctx context.Context, link *domain.TaskLink) error
+	// GetTaskLink はIDでリンクを取得する（存在しない場合は nil）
+	GetTaskLink(ctx context.Context, id string) (*domain.TaskLink, error)
+	// ListTaskLinksByTask はタスクのリンクを追加した順に取得する
+	ListTaskLinksByTask(ctx context.Context, taskID string) ([]*domain.TaskLink, error)
+	// UpdateTaskLink はリンクの種類・URL・タイトルを更新する
+	UpdateTaskLink(ctx context.Context, link *domain.TaskLink) error
+	DeleteTaskLink(ctx context.Context, id string) error
+}
+
+// TaskIssueLinker はGitHubのIssue・Pull Requestの関連付けのインターフェース（IssueLinkServiceが実装する）
+type TaskIssueLinker interface {
+	ListIssueLinksByTask(ctx context.Context, taskID string) ([]*domain.IssueLink, error)
+	LinkIssue(ctx context.Context, taskID, userID, rawRef string, completeOnClose *bool) (*domain.IssueLink, error)
+	UnlinkIssue(ctx context.Context, taskID, linkID, userID string) error
+}
+
+// TaskLinkProvider はタスクの詳細に表示するリンクのインターフェース（TaskLinkServiceが実装する）
+type TaskLinkProvider interface {
+	ListTaskLinks(ctx context.Context, taskID string) ([]*domain.TaskLink, error)
+}
+
+var (
+	// ErrTaskLinkNotFound はタスクのリンクが見つからないことを表すエラー
+	ErrTaskLinkNotFound = errors.New("task link not found")
+	// ErrTaskLinkExists はタスクに同じURLのリンクを追加済みであることを表すエラー
+	ErrTaskLinkExists = errors.New("link already added to this task")
+)
+
+// CreateTaskLinkInput はタスクにリンクを追加する入力
+// GitHubのIssue・Pull RequestのURLで種類が空、または種類がGitHubの場合はIssue・Pull Requestとして関連付ける
+type CreateTaskLinkInput struct {
+	Type  domain.TaskLinkType
+	URL   string
+	Title string
+	// Issue・Pull Requestのマージ・完了でタスクを完了にするか（nilの場合は完了にする）
+	CompleteOnClose *bool
+}
+
+// UpdateTaskLinkInput はリンクの変更の入力（nilの項目は変更しない）
+type UpdateTaskLinkInput struct {
+	Type  *domain.TaskLinkType
+	URL   *string
+	Title *string
+}
+
+// TaskLinkService はタスクのリンク（ユーザーが追加したURLと、GitHub・予定の連携のリンク）を扱うサービス
+// リンクを参照・変更できるのはタスクを参照できるユーザー（作成者・担当者・グループのメンバー）
+type TaskLinkService struct {
+	Repository     TaskLinkRepository
+	TaskRepository TaskRepository
+	GroupResolver  GroupTaskResolver
+	// GitHubのIssue・Pull Requestの関連付け（未設定の場合はGitHubのURLも通常のリンクとして追加する）
+	Issues TaskIssueLinker
+	// グループの予定との関連付け（未設定の場合は含めない）
+	EventLinks TaskEventLinkProvider
+	Logger     logger.Logger
+}
+
+// NewTaskLinkService はTaskLinkServiceのコンストラクタ
+func NewTaskLinkService(
+	repo TaskLinkRepository,
+	taskRepo TaskRepository,
+	groupResolver GroupTaskResolver,
+	logger logger.Logger,
+) *TaskLinkService {
+	return &TaskLinkService{
+		Repository:     repo,
+		TaskRepository: taskRepo,
+		GroupResolver:  groupResolver,
+		Logger:         logger,
+	}
+}
+
+// ListLinks はタスクのリンクを追加・関連付けた順に取得する
+func (s *TaskLinkService) ListLinks(ctx context.Context, taskID, userID string) ([]*domain.TaskLink, error) {
+	if _, err := getViewableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+	return s.ListTaskLinks(ctx, taskID)
+}
+
+// ListTaskLinks はユーザーのリンク・GitHubのIssue・Pull Request・グループの予定をまとめて追加・関連付けた順に返す（アクセス権は確認しない）
+func (s *TaskLinkService) ListTaskLinks(ctx context.Context, taskID string) ([]*domain.TaskLink, error) {
+	links, err := s.Repository.ListTaskLinksByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task links: %w", err)
+	}
+
+	if s.Issues != nil {
+		issueLinks, err := s.Issues.ListIssueLinksByTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		for _, issueLink := range issueLinks {
+			links = append(links, issueLink.ToTaskLink())
+		}
+	}
+
+	if s.EventLinks != nil {
+		eventLinks, err := s.EventLinks.ListTaskEventLinks(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list event links: %w", err)
+		}
+		for _, eventLink := range eventLinks {
+			if link, ok := eventLink.ToTaskLink(taskID); ok {
+				links = append(links, link)
+			}
+		}
+	}
+
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].CreatedAt.Before(links[j].CreatedAt)
+	})
+	return links, nil
+}
+
+// CreateLink はタスクにリンクを追加する
+// GitHubのIssue・Pull RequestはIssueLinkServiceで関連付け、状態をWebhookで同期する
+func (s *TaskLinkService) CreateLink(ctx context.Context, taskID, userID string, input CreateTaskLinkInput) (*domain.TaskLink, error) {
+	switch {
+	case input.Type == domain.TaskLinkTypeCalendarEvent:
+		return nil, fmt.Errorf("%w: calendar links are created from group events", ErrInvalidParameter)
+	case s.isIssueLink(input):
+		if s.Issues == nil {
+			return nil, fmt.Errorf("%w: github links are not supported", ErrInvalidParameter)
+		}
+		issueLink, err := s.Issues.LinkIssue(ctx, taskID, userID, input.URL, input.CompleteOnClose)
+		if err != nil {
+			return nil, err
+		}
+		return issueLink.ToTaskLink(), nil
+	}
+
+	link, err := domain.NewTaskLink(taskID, input.Type, input.URL, input.Title, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.Repository.ListTaskLinksByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task links: %w", err)
+	}
+	for _, existing := range links {
+		if existing.URL == link.URL {
+			return nil, ErrTaskLinkExists
+		}
+	}
+	if len(links) >= domain.MaxTaskLinksPerTask {
+		return nil, fmt.Errorf("%w: a task can have at most %d links", ErrInvalidParameter, domain.MaxTaskLinksPerTask)
+	}
+
+	link.ID = uuid.New().String()
+	if err := s.Repository.CreateTaskLink(ctx, link); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to create task link",
+			logger.Any("taskID", taskID), logger.Error(err))
+		return nil, fmt.Errorf("failed to create task link: %w", err)
+	}
+	return link, nil
+}
+
+// UpdateLink はユーザーが追加したリンクの種類・URL・タイトルを変更する（連携のリンクは変更できない）
+func (s *TaskLinkService) UpdateLink(ctx context.Context, taskID, linkID, userID string, input UpdateTaskLinkInput) (*domain.TaskLink, error) {
+	link, err := s.getLink(ctx, taskID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	linkType, rawURL, title := link.Type, link.URL, link.Title
+	if input.Type != nil {
+		linkType = *input.Type
+	}
+	if input.URL != nil {
+		rawURL = *input.URL
+	}
+	if input.Title != nil {
+		title = *input.Title
+	}
+	if err := link.Update(linkType, rawURL, title, time.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+
+	if input.URL != nil {
+		links, err := s.Repository.ListTaskLinksByTask(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list task links: %w", err)
+		}
+		for _, existing := range links {
+			if existing.ID != link.ID && existing.URL == link.URL {
+				return nil, ErrTaskLinkExists
+			}
+		}
+	}
+
+	if err := s.Repository.UpdateTaskLink(ctx, link); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to update task link",
+			logger.Any("linkID", linkID), logger.Error(err))
+		return nil, fmt.Errorf("failed to update task link: %w", err)
+	}
+	return link, nil
+}
+
+// DeleteLink はタスクのリンクを削除する（GitHubのIssue・Pull Requestの場合は関連付けを解除する）
+func (s *TaskLinkService) DeleteLink(ctx context.Context, taskID, linkID, userID string) error {
+	_, err := s.getLink(ctx, taskID, linkID)
+	if errors.Is(err, ErrTaskLinkNotFound) && s.Issues != nil {
+		err = s.Issues.UnlinkIssue(ctx, taskID, linkID, userID)
+		if errors.Is(err, ErrIssueLinkNotFound) {
+			return ErrTaskLinkNotFound
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := getEditableTask(ctx, s.TaskRepository, s.GroupResolver, taskID, userID); err != nil {
+		return err
+	}
+
+	if err := s.Repository.DeleteTaskLink(ctx, linkID); err != nil {
+		return fmt.Errorf("failed to delete task link: %w", err)
+	}
+	return nil
+}
+
+// AddClipLink はブラウザの拡張機能でクリップしたページのリンクをタスクに追加する（ClipServiceから呼ばれる）
+// GitHubのIssue・Pull RequestのページはIssue・Pull Requestとして関連付ける
+func (s *TaskLinkService) AddClipLink(ctx context.Context, taskID, userID, rawURL, title string) (*domain.TaskLink, error) {
+	return s.CreateLink(ctx, taskID, userID, CreateTaskLinkInput{
+		URL:   rawURL,
+		Title: title,
+	})
+}
+
+// === 内部処理 ===
+
+// isIssueLink はGitHubのIssue・Pull Requestとして関連付けるリンクか
+func (s *TaskLinkService) isIssueLink(input CreateTaskLinkInput) bool {
+	if input.Type.IsGitHub() {
+		return true
+	}
+	if input.Type != "" || s.Issues == nil {
+		return false
+	}
+	_, err := domain.ParseIssueRef(input.URL)
+	return err == nil
+}
+
+// getLink はタスクのユーザーのリンクを取得する（他のタスクのリンクは見つからないものとして扱う）
+func (s *TaskLinkService) getLink(ctx context.Context, taskID, linkID string) (*domain.TaskLink, error) {
+	link, err := s.Repository.GetTaskLink(ctx, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task link: %w", err)
+	}
+	if link == nil || link.TaskID != taskID {
+		return nil, ErrTaskLinkNotFound
+	}
+	return link, nil
+}
+
+// GetLinks はタスクのリンクを返す（取得できない場合は表示しない）
+func (s *TaskService) GetLinks(ctx context.Context, task *domain.Task) []*domain.TaskLink {
+	if s.Links == nil {
+		return nil
+	}
+	links, err := s.Links.ListTaskLinks(ctx, task.ID)
+	if err != nil {
+		s.Logger.WithContext(ctx).Warn("Failed to get task links",
+			logger.Any("taskID", task.ID),
+			logger.Error(err))
+		return nil
+	}
+	return links
+}
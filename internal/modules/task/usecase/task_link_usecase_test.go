@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=task_link_service.go -destination=mocks/mock_task_link.go -package=mocks
+
+type taskLinkTestMocks struct {
+	taskRepo   *mocks.MockTaskRepository
+	linkRepo   *mocks.MockTaskLinkRepository
+	resolver   *mocks.MockGroupTaskResolver
+	issues     *mocks.MockTaskIssueLinker
+	eventLinks *mocks.MockTaskEventLinkProvider
+}
+
+func newTaskLinkTestService(t *testing.T) (*TaskLinkService, *taskLinkTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &taskLinkTestMocks{
+		taskRepo:   mocks.NewMockTaskRepository(ctrl),
+		linkRepo:   mocks.NewMockTaskLinkRepository(ctrl),
+		resolver:   mocks.NewMockGroupTaskResolver(ctrl),
+		issues:     mocks.NewMockTaskIssueLinker(ctrl),
+		eventLinks: mocks.NewMockTaskEventLinkProvider(ctrl),
+	}
+	service := NewTaskLinkService(m.linkRepo, m.taskRepo, m.resolver, *createTestLogger())
+	service.Issues = m.issues
+	service.EventLinks = m.eventLinks
+	return service, m
+}
+
+func TestTaskLinkService_ListLinks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("merges user, GitHub and calendar links in the order they were added", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+
+		document, err := domain.NewTaskLink("task-1", domain.TaskLinkTypeDocument, "https://docs.example.com/spec", "仕様書", "user-1", now.Add(2*time.Hour))
+		require.NoError(t, err)
+		document.ID = "link-1"
+		m.linkRepo.EXPECT().ListTaskLinksByTask(gomock.Any(), "task-1").Return([]*domain.TaskLink{document}, nil)
+
+		issueLink := domain.NewIssueLink("task-1", &pullRequest42, true, "user-1", now)
+		issueLink.ID = "link-2"
+		m.issues.EXPECT().ListIssueLinksByTask(gomock.Any(), "task-1").Return([]*domain.IssueLink{issueLink}, nil)
+
+		m.eventLinks.EXPECT().ListTaskEventLinks(gomock.Any(), "task-1").Return([]*domain.TaskEventLink{
+			{GroupID: "group-1", EventID: "event-1", TaskID: "task-1", Kind: "BLOCK", CreatedAt: now.Add(time.Hour)},
+			// 他のタスクをこのタスク（予定）のフォローアップとした関連付けは含めない
+			{GroupID: "group-1", EventID: "task-1", TaskID: "task-2", Kind: "FOLLOW_UP", CreatedAt: now},
+		}, nil)
+
+		links, err := service.ListLinks(ctx, "task-1", "user-1")
+		require.NoError(t, err)
+		require.Len(t, links, 3)
+		assert.Equal(t, domain.TaskLinkTypeGitHubPullRequest, links[0].Type)
+		assert.Equal(t, domain.TaskLinkTypeCalendarEvent, links[1].Type)
+		assert.Equal(t, "/groups/group-1/events/event-1", links[1].URL)
+		assert.Equal(t, "link-1", links[2].ID)
+	})
+
+	t.Run("other users cannot list links", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return(nil, nil)
+
+		_, err := service.ListLinks(ctx, "task-1", "user-2")
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+}
+
+func TestTaskLinkService_CreateLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("adds a user link", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().ListTaskLinksByTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.linkRepo.EXPECT().CreateTaskLink(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, link *domain.TaskLink) error {
+				assert.NotEmpty(t, link.ID)
+				assert.Equal(t, domain.TaskLinkTypeDesign, link.Type)
+				assert.Equal(t, "user-1", link.CreatedBy)
+				return nil
+			})
+
+		link, err := service.CreateLink(ctx, "task-1", "user-1", CreateTaskLinkInput{
+			Type:  domain.TaskLinkTypeDesign,
+			URL:   "https://www.figma.com/file/abc",
+			Title: "画面デザイン",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "画面デザイン", link.Title)
+	})
+
+	t.Run("GitHub URLs are linked as issues", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		completeOnClose := false
+		issueLink := domain.NewIssueLink("task-1", &pullRequest42, false, "user-1", time.Now())
+		m.issues.EXPECT().LinkIssue(gomock.Any(), "task-1", "user-1", "https://github.com/octo/app/pull/42", &completeOnClose).
+			Return(issueLink, nil)
+
+		link, err := service.CreateLink(ctx, "task-1", "user-1", CreateTaskLinkInput{
+			URL:             "https://github.com/octo/app/pull/42",
+			CompleteOnClose: &completeOnClose,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskLinkTypeGitHubPullRequest, link.Type)
+		assert.True(t, link.Managed)
+	})
+
+	t.Run("GitHub URLs can be added as plain links", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().ListTaskLinksByTask(gomock.Any(), "task-1").Return(nil, nil)
+		m.linkRepo.EXPECT().CreateTaskLink(gomock.Any(), gomock.Any()).Return(nil)
+
+		link, err := service.CreateLink(ctx, "task-1", "user-1", CreateTaskLinkInput{
+			Type: domain.TaskLinkTypeLink,
+			URL:  "https://github.com/octo/app/issues/1",
+		})
+		require.NoError(t, err)
+		assert.False(t, link.Managed)
+	})
+
+	t.Run("calendar links cannot be added", func(t *testing.T) {
+		service, _ := newTaskLinkTestService(t)
+		_, err := service.CreateLink(ctx, "task-1", "user-1", CreateTaskLinkInput{
+			Type: domain.TaskLinkTypeCalendarEvent,
+			URL:  "/groups/group-1/events/event-1",
+		})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("rejects invalid URLs", func(t *testing.T) {
+		service, _ := newTaskLinkTestService(t)
+		_, err := service.CreateLink(ctx, "task-1", "user-1", CreateTaskLinkInput{URL: "ftp://example.com/file"})
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("rejects URLs already added to the task", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		existing, err := domain.NewTaskLink("task-1", "", "https://example.com", "", "user-1", time.Now())
+		require.NoError(t, err)
+		m.linkRepo.EXPECT().ListTaskLinksByTask(gomock.Any(), "task-1").Return([]*domain.TaskLink{existing}, nil)
+
+		_, err = service.CreateLink(ctx, "task-1", "user-1", CreateTaskLinkInput{URL: "https://example.com"})
+		assert.ErrorIs(t, err, ErrTaskLinkExists)
+	})
+
+	t.Run("group members who can only view the task cannot add links", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		_, err := service.CreateLink(ctx, "task-1", "guest", CreateTaskLinkInput{URL: "https://example.com"})
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+}
+
+func TestTaskLinkService_UpdateLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("changes only the given fields", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		link, err := domain.NewTaskLink("task-1", domain.TaskLinkTypeLink, "https://example.com", "参考", "user-1", time.Now())
+		require.NoError(t, err)
+		link.ID = "link-1"
+		m.linkRepo.EXPECT().GetTaskLink(gomock.Any(), "link-1").Return(link, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().UpdateTaskLink(gomock.Any(), link).Return(nil)
+
+		linkType := domain.TaskLinkTypeDocument
+		updated, err := service.UpdateLink(ctx, "task-1", "link-1", "user-1", UpdateTaskLinkInput{Type: &linkType})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskLinkTypeDocument, updated.Type)
+		assert.Equal(t, "参考", updated.Title)
+	})
+
+	t.Run("integration links cannot be changed", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.linkRepo.EXPECT().GetTaskLink(gomock.Any(), "link-2").Return(nil, nil)
+
+		title := "変更"
+		_, err := service.UpdateLink(ctx, "task-1", "link-2", "user-1", UpdateTaskLinkInput{Title: &title})
+		assert.ErrorIs(t, err, ErrTaskLinkNotFound)
+	})
+}
+
+func TestTaskLinkService_DeleteLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes a user link", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		link, err := domain.NewTaskLink("task-1", "", "https://example.com", "", "user-1", time.Now())
+		require.NoError(t, err)
+		link.ID = "link-1"
+		m.linkRepo.EXPECT().GetTaskLink(gomock.Any(), "link-1").Return(link, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.linkRepo.EXPECT().DeleteTaskLink(gomock.Any(), "link-1").Return(nil)
+
+		require.NoError(t, service.DeleteLink(ctx, "task-1", "link-1", "user-1"))
+	})
+
+	t.Run("group members who can only view the task cannot delete links", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		link, err := domain.NewTaskLink("task-1", "", "https://example.com", "", "user-1", time.Now())
+		require.NoError(t, err)
+		link.ID = "link-1"
+		m.linkRepo.EXPECT().GetTaskLink(gomock.Any(), "link-1").Return(link, nil)
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(newIssueLinkTestTask("task-1", "user-1"), nil)
+		m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task-1").Return([]string{"group-1"}, nil)
+		m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", domain.GroupActionEditTasks).Return(false, nil)
+
+		assert.ErrorIs(t, service.DeleteLink(ctx, "task-1", "link-1", "guest"), ErrPermissionDenied)
+	})
+
+	t.Run("unlinks GitHub issues", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.linkRepo.EXPECT().GetTaskLink(gomock.Any(), "link-2").Return(nil, nil)
+		m.issues.EXPECT().UnlinkIssue(gomock.Any(), "task-1", "link-2", "user-1").Return(nil)
+
+		require.NoError(t, service.DeleteLink(ctx, "task-1", "link-2", "user-1"))
+	})
+
+	t.Run("unknown links are not found", func(t *testing.T) {
+		service, m := newTaskLinkTestService(t)
+		m.linkRepo.EXPECT().GetTaskLink(gomock.Any(), "link-3").Return(nil, nil)
+		m.issues.EXPECT().UnlinkIssue(gomock.Any(), "task-1", "link-3", "user-1").Return(ErrIssueLinkNotFound)
+
+		assert.ErrorIs(t, service.DeleteLink(ctx, "task-1", "link-3", "user-1"), ErrTaskLinkNotFound)
+	})
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// maxTimelineTasks はタイムラインで返すタスク数の上限
+const maxTimelineTasks = 500
+
+// maxDependencyTraversal は循環チェックで辿るタスク数の上限
+const maxDependencyTraversal = 10000
+
+// DependencyRepository はタスク間の依存関係のリポジトリインターフェース
+type DependencyRepository interface {
+	// AddDependency は依存関係を追加する（登録済みの場合は何もしない）
+	AddDependency(ctx context.Context, dependency *domain.TaskDependency) error
+	RemoveDependency(ctx context.Context, taskID, dependsOnID string) error
+	// ListDependencies は指定したタスクが依存している依存関係を取得する
+	ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error)
+}
+
+// TimelineService はグループのタイムライン（ガントチャート）とタスクの依存関係を扱うサービス
+type TimelineService struct {
+	TaskRepository       TaskRepository
+	DependencyRepository DependencyRepository
+	MilestoneRepository  MilestoneRepository
+	GroupResolver        GroupTaskResolver
+	Logger               logger.Logger
+}
+
+// NewTimelineService はTimelineServiceのコンストラクタ
+func NewTimelineService(
+	taskRepo TaskRepository,
+	dependencyRepo DependencyRepository,
+	milestoneRepo MilestoneRepository,
+	groupResolver GroupTaskResolver,
+	logger logger.Logger,
+) *TimelineService {
+	return &TimelineService{
+		TaskRepository:       taskRepo,
+		DependencyRepository: dependencyRepo,
+		MilestoneRepository:  milestoneRepo,
+		GroupResolver:        groupResolver,
+		Logger:               logger,
+	}
+}
+
+var (
+	ErrDependencyNotFound = errors.New("task dependency not found")
+	ErrDependencyCycle    = errors.New("task dependency would create a cycle")
+)
+
+// === 依存関係 ===
+
+// AddDependency はタスクが別のタスクの完了後に開始する依存関係を追加する
+// 2つのタスクは同じグループのグループタスクである必要があり、そのグループでタスク編集の権限を持つメンバーのみ追加できる
+func (s *TimelineService) AddDependency(ctx context.Context, userID, taskID, dependsOnID string) error {
+	if taskID == "" || dependsOnID == "" {
+		return fmt.Errorf("%w: taskID and dependsOnID are required", ErrInvalidParameter)
+	}
+	if taskID == dependsOnID {
+		return fmt.Errorf("%w: a task cannot depend on itself", ErrInvalidParameter)
+	}
+	if err := s.requireSharedGroup(ctx, userID, taskID, dependsOnID); err != nil {
+		return err
+	}
+
+	cycle, err := s.dependsOn(ctx, dependsOnID, taskID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrDependencyCycle
+	}
+
+	if err := s.DependencyRepository.AddDependency(ctx, domain.NewTaskDependency(taskID, dependsOnID, userID)); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to add task dependency",
+			logger.Any("taskID", taskID), logger.Any("dependsOnID", dependsOnID), logger.Error(err))
+		return fmt.Errorf("failed to add task dependency: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Task dependency added",
+		logger.Any("taskID", taskID), logger.Any("dependsOnID", dependsOnID))
+	return nil
+}
+
+// RemoveDependency は依存関係を削除する（タスクのグループでタスク編集の権限を持つメンバーのみ）
+func (s *TimelineService) RemoveDependency(ctx context.Context, userID, taskID, dependsOnID string) error {
+	if taskID == "" || dependsOnID == "" {
+		return fmt.Errorf("%w: taskID and dependsOnID are required", ErrInvalidParameter)
+	}
+	if err := s.requireSharedGroup(ctx, userID, taskID, dependsOnID); err != nil {
+		return err
+	}
+
+	if err := s.DependencyRepository.RemoveDependency(ctx, taskID, dependsOnID); err != nil {
+		if errors.Is(err, ErrDependencyNotFound) {
+			return err
+		}
+		s.Logger.WithContext(ctx).Error("Failed to remove task dependency",
+			logger.Any("taskID", taskID), logger.Any("dependsOnID", dependsOnID), logger.Error(err))
+		return fmt.Errorf("failed to remove task dependency: %w", err)
+	}
+	return nil
+}
+
+// === タイムライン ===
+
+// GetGroupTimeline はグループタスクの期間・依存関係・マイルストーンとクリティカルパスを取得する（タスク閲覧の権限が必要）
+func (s *TimelineService) GetGroupTimeline(ctx context.Context, userID, groupID string) (*domain.Timeline, error) {
+	if groupID == "" {
+		return nil, fmt.Errorf("%w: groupID is required", ErrInvalidParameter)
+	}
+	canView, err := s.GroupResolver.CheckGroupPermission(ctx, groupID, userID, domain.GroupActionViewTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group permission: %w", err)
+	}
+	if !canView {
+		return nil, ErrPermissionDenied
+	}
+
+	taskIDs, err := s.GroupResolver.ListGroupTaskIDs(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group tasks: %w", err)
+	}
+	truncated := len(taskIDs) > maxTimelineTasks
+	if truncated {
+		taskIDs = taskIDs[:maxTimelineTasks]
+	}
+
+	tasks := make([]*domain.Task, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, err := s.TaskRepository.GetTaskByID(ctx, taskID)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get group task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	dependencies, err := s.DependencyRepository.ListDependencies(ctx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task dependencies: %w", err)
+	}
+
+	milestones, err := s.MilestoneRepository.ListMilestonesByGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	milestoneIDs := make(map[string]string)
+	for _, milestone := range milestones {
+		ids, err := s.MilestoneRepository.ListMilestoneTaskIDs(ctx, milestone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestone tasks: %w", err)
+		}
+		for _, id := range ids {
+			milestoneIDs[id] = milestone.ID
+		}
+	}
+
+	timeline := domain.NewTimeline(groupID, tasks, dependencies, milestoneIDs, milestones)
+	timeline.Truncated = truncated
+	return timeline, nil
+}
+
+// === ヘルパー ===
+
+// requireSharedGroup は2つのタスクが同じグループに属し、ユーザーがそのグループでタスクを編集できるかを確認する
+func (s *TimelineService) requireSharedGroup(ctx context.Context, userID, taskID, otherID string) error {
+	for _, id := range []string{taskID, otherID} {
+		if _, err := s.TaskRepository.GetTaskByID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	groupIDs, err := s.GroupResolver.GetGroupIDsForTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get groups for task: %w", err)
+	}
+	otherGroupIDs, err := s.GroupResolver.GetGroupIDsForTask(ctx, otherID)
+	if err != nil {
+		return fmt.Errorf("failed to get groups for task: %w", err)
+	}
+
+	shared := false
+	for _, groupID := range groupIDs {
+		if !containsString(otherGroupIDs, groupID) {
+			continue
+		}
+		shared = true
+		canEdit, err := s.GroupResolver.CheckGroupPermission(ctx, groupID, userID, domain.GroupActionEditTasks)
+		if err != nil {
+			return fmt.Errorf("failed to check group permission: %w", err)
+		}
+		if canEdit {
+			return nil
+		}
+	}
+	if !shared {
+		return fmt.Errorf("%w: tasks must belong to the same group", ErrInvalidParameter)
+	}
+	return ErrPermissionDenied
+}
+
+// dependsOn は from が（間接的に）target に依存しているかを確認する
+func (s *TimelineService) dependsOn(ctx context.Context, from, target string) (bool, error) {
+	visited := map[string]bool{from: true}
+	frontier := []string{from}
+	for len(frontier) > 0 {
+		if len(visited) > maxDependencyTraversal {
+			return false, fmt.Errorf("%w: dependency chain too long", ErrInvalidParameter)
+		}
+
+		dependencies, err := s.DependencyRepository.ListDependencies(ctx, frontier)
+		if err != nil {
+			return false, fmt.Errorf("failed to list task dependencies: %w", err)
+		}
+
+		var next []string
+		for _, dependency := range dependencies {
+			if dependency.DependsOnID == target {
+				return true, nil
+			}
+			if !visited[dependency.DependsOnID] {
+				visited[dependency.DependsOnID] = true
+				next = append(next, dependency.DependsOnID)
+			}
+		}
+		frontier = next
+	}
+	return false, nil
+}
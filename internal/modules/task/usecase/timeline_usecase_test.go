@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=timeline_service.go -destination=mocks/mock_timeline.go -package=mocks
+
+type timelineTestMocks struct {
+	taskRepo       *mocks.MockTaskRepository
+	dependencyRepo *mocks.MockDependencyRepository
+	milestoneRepo  *mocks.MockMilestoneRepository
+	resolver       *mocks.MockGroupTaskResolver
+}
+
+func newTimelineTestService(t *testing.T) (*TimelineService, *timelineTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &timelineTestMocks{
+		taskRepo:       mocks.NewMockTaskRepository(ctrl),
+		dependencyRepo: mocks.NewMockDependencyRepository(ctrl),
+		milestoneRepo:  mocks.NewMockMilestoneRepository(ctrl),
+		resolver:       mocks.NewMockGroupTaskResolver(ctrl),
+	}
+	service := NewTimelineService(m.taskRepo, m.dependencyRepo, m.milestoneRepo, m.resolver, *createTestLogger())
+	return service, m
+}
+
+func newTimelineTestTask(id string) *domain.Task {
+	task := domain.NewTask(id, "", domain.PriorityMedium, domain.CategoryWork, "owner")
+	task.ID = id
+	return task
+}
+
+func (m *timelineTestMocks) expectTasks(ids ...string) {
+	for _, id := range ids {
+		m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), id).Return(newTimelineTestTask(id), nil).AnyTimes()
+	}
+}
+
+func TestTimelineService_AddDependency(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(m *timelineTestMocks)
+		expectedError error
+	}{
+		{
+			name: "adds a dependency between tasks of the same group",
+			setupMocks: func(m *timelineTestMocks) {
+				m.expectTasks("build", "design")
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "build").Return([]string{"group-1"}, nil)
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "design").Return([]string{"group-1"}, nil)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(true, nil)
+				m.dependencyRepo.EXPECT().ListDependencies(gomock.Any(), []string{"design"}).Return(nil, nil)
+				m.dependencyRepo.EXPECT().AddDependency(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, dependency *domain.TaskDependency) error {
+						assert.Equal(t, "build", dependency.TaskID)
+						assert.Equal(t, "design", dependency.DependsOnID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "rejects an indirect cycle",
+			setupMocks: func(m *timelineTestMocks) {
+				m.expectTasks("build", "design")
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), gomock.Any()).Return([]string{"group-1"}, nil).Times(2)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(true, nil)
+				// design → spec → build already exists
+				m.dependencyRepo.EXPECT().ListDependencies(gomock.Any(), []string{"design"}).
+					Return([]*domain.TaskDependency{domain.NewTaskDependency("design", "spec", "owner")}, nil)
+				m.dependencyRepo.EXPECT().ListDependencies(gomock.Any(), []string{"spec"}).
+					Return([]*domain.TaskDependency{domain.NewTaskDependency("spec", "build", "owner")}, nil)
+			},
+			expectedError: ErrDependencyCycle,
+		},
+		{
+			name: "tasks must share a group",
+			setupMocks: func(m *timelineTestMocks) {
+				m.expectTasks("build", "design")
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "build").Return([]string{"group-1"}, nil)
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "design").Return([]string{"group-2"}, nil)
+			},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name: "members without task edit permission cannot add",
+			setupMocks: func(m *timelineTestMocks) {
+				m.expectTasks("build", "design")
+				m.resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), gomock.Any()).Return([]string{"group-1"}, nil).Times(2)
+				m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(false, nil)
+			},
+			expectedError: ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTimelineTestService(t)
+			tt.setupMocks(m)
+
+			err := service.AddDependency(context.Background(), "member", "build", "design")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTimelineService_AddDependency_Self(t *testing.T) {
+	service, _ := newTimelineTestService(t)
+
+	err := service.AddDependency(context.Background(), "member", "build", "build")
+
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+func TestTimelineService_GetGroupTimeline(t *testing.T) {
+	service, m := newTimelineTestService(t)
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+
+	design := newTimelineTestTask("design")
+	design.CreatedAt = start
+	design.SetDueDate(start.AddDate(0, 0, 2))
+	build := newTimelineTestTask("build")
+	build.CreatedAt = start
+	build.SetDueDate(start.AddDate(0, 0, 3))
+	milestone := domain.NewMilestone("group-1", "GA", start.AddDate(0, 0, 7), 0, "owner")
+	milestone.ID = "m1"
+
+	m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionViewTasks).Return(true, nil)
+	m.resolver.EXPECT().ListGroupTaskIDs(gomock.Any(), "group-1").Return([]string{"design", "build", "deleted"}, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "design").Return(design, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "build").Return(build, nil)
+	m.taskRepo.EXPECT().GetTaskByID(gomock.Any(), "deleted").Return(nil, ErrTaskNotFound)
+	m.dependencyRepo.EXPECT().ListDependencies(gomock.Any(), []string{"design", "build", "deleted"}).
+		Return([]*domain.TaskDependency{domain.NewTaskDependency("build", "design", "owner")}, nil)
+	m.milestoneRepo.EXPECT().ListMilestonesByGroup(gomock.Any(), "group-1").Return([]*domain.Milestone{milestone}, nil)
+	m.milestoneRepo.EXPECT().ListMilestoneTaskIDs(gomock.Any(), "m1").Return([]string{"build"}, nil)
+
+	timeline, err := service.GetGroupTimeline(context.Background(), "member", "group-1")
+
+	require.NoError(t, err)
+	require.Len(t, timeline.Tasks, 2)
+	assert.Equal(t, []string{"design", "build"}, timeline.CriticalPath)
+	assert.Equal(t, "m1", timeline.Tasks[1].MilestoneID)
+	assert.False(t, timeline.Truncated)
+}
+
+func TestTimelineService_GetGroupTimeline_NonMember(t *testing.T) {
+	service, m := newTimelineTestService(t)
+	m.resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "outsider", domain.GroupActionViewTasks).Return(false, nil)
+
+	_, err := service.GetGroupTimeline(context.Background(), "outsider", "group-1")
+
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
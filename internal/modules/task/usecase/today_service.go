@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// UnreadNotificationCounter は通知モジュールとの連携インターフェース
+type UnreadNotificationCounter interface {
+	GetUnreadNotificationCount(ctx context.Context, userID string) (int, error)
+}
+
+// TodayService はホーム画面用の今日の予定をまとめるサービス
+type TodayService struct {
+	StatsRepository     StatsRepository
+	WorkloadRepository  WorkloadRepository
+	NotificationCounter UnreadNotificationCounter
+	Logger              logger.Logger
+}
+
+// NewTodayService はTodayServiceのコンストラクタ
+func NewTodayService(
+	statsRepo StatsRepository,
+	workloadRepo WorkloadRepository,
+	notificationCounter UnreadNotificationCounter,
+	logger logger.Logger,
+) *TodayService {
+	return &TodayService{
+		StatsRepository:     statsRepo,
+		WorkloadRepository:  workloadRepo,
+		NotificationCounter: notificationCounter,
+		Logger:              logger,
+	}
+}
+
+// GetToday は今日が期限のタスク・期限切れのタスク・今日着手予定のタスクと未読通知数をまとめて取得する
+// タスクと未読通知数は並行して取得し、timezoneが空の場合はユーザーの勤務時間設定のタイムゾーンで日を区切る
+func (s *TodayService) GetToday(ctx context.Context, userID, timezone string) (*domain.Today, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	var (
+		wg          sync.WaitGroup
+		today       *domain.Today
+		tasksErr    error
+		unreadCount int
+		unreadErr   error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		today, tasksErr = s.loadTasks(ctx, userID, timezone)
+	}()
+	go func() {
+		defer wg.Done()
+		unreadCount, unreadErr = s.NotificationCounter.GetUnreadNotificationCount(ctx, userID)
+	}()
+	wg.Wait()
+
+	if tasksErr != nil {
+		return nil, tasksErr
+	}
+	if unreadErr != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get unread notification count",
+			logger.Any("userID", userID), logger.Error(unreadErr))
+		return nil, fmt.Errorf("failed to get unread notification count: %w", unreadErr)
+	}
+
+	today.UnreadNotificationCount = unreadCount
+	return today, nil
+}
+
+// Location はユーザーの日の区切りに使うタイムゾーンを返す（timezoneが空の場合は勤務時間設定のタイムゾーン）
+func (s *TodayService) Location(ctx context.Context, userID, timezone string) (*time.Location, error) {
+	return resolveUserLocation(ctx, s.WorkloadRepository, userID, timezone)
+}
+
+// loadTasks は今日の予定に含めるタスクを取得して振り分ける
+func (s *TodayService) loadTasks(ctx context.Context, userID, timezone string) (*domain.Today, error) {
+	loc, err := s.Location(ctx, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(loc)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	start := dayStart.AddDate(0, 0, -domain.TodayCarryoverDays)
+	end := dayStart.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	tasks, err := s.StatsRepository.GetScheduledTasksByDateRange(ctx, userID, start, end)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get scheduled tasks",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to get scheduled tasks: %w", err)
+	}
+	for _, task := range tasks {
+		task.PrepareForResponse()
+	}
+
+	return domain.NewToday(tasks, now, loc), nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=today_service.go -destination=mocks/mock_today.go -package=mocks
+
+type todayTestMocks struct {
+	statsRepo    *mocks.MockStatsRepository
+	workloadRepo *mocks.MockWorkloadRepository
+	counter      *mocks.MockUnreadNotificationCounter
+}
+
+func newTodayTestService(t *testing.T) (*TodayService, *todayTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &todayTestMocks{
+		statsRepo:    mocks.NewMockStatsRepository(ctrl),
+		workloadRepo: mocks.NewMockWorkloadRepository(ctrl),
+		counter:      mocks.NewMockUnreadNotificationCounter(ctrl),
+	}
+	return NewTodayService(m.statsRepo, m.workloadRepo, m.counter, *createTestLogger()), m
+}
+
+func TestTodayService_GetToday(t *testing.T) {
+	service, m := newTodayTestService(t)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	now := time.Now().In(tokyo)
+	dueToday := &domain.Task{ID: "due-today", Status: domain.TaskStatusTodo}
+	dueToday.SetDueDate(time.Date(now.Year(), now.Month(), now.Day(), 23, 0, 0, 0, tokyo))
+	overdue := &domain.Task{ID: "overdue", Status: domain.TaskStatusInProgress}
+	overdue.SetDueDate(time.Date(now.Year(), now.Month(), now.Day()-2, 12, 0, 0, 0, tokyo))
+
+	m.counter.EXPECT().GetUnreadNotificationCount(gomock.Any(), "user-1").Return(3, nil)
+	m.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+			assert.Equal(t, tokyo, start.Location())
+			assert.Equal(t, domain.TodayCarryoverDays*24*time.Hour+24*time.Hour-time.Nanosecond, end.Sub(start))
+			return []*domain.Task{dueToday, overdue}, nil
+		})
+
+	today, err := service.GetToday(context.Background(), "user-1", "Asia/Tokyo")
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Format("2006-01-02"), today.Date)
+	assert.Equal(t, 3, today.UnreadNotificationCount)
+	require.Len(t, today.DueToday, 1)
+	assert.Equal(t, "due-today", today.DueToday[0].ID)
+	require.Len(t, today.Overdue, 1)
+	assert.Equal(t, "overdue", today.Overdue[0].ID)
+	assert.Empty(t, today.StartingToday)
+}
+
+func TestTodayService_GetToday_UsesWorkingHoursTimezone(t *testing.T) {
+	service, m := newTodayTestService(t)
+	hours := domain.DefaultWorkingHours("user-1")
+	hours.Timezone = "America/New_York"
+
+	m.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+	m.counter.EXPECT().GetUnreadNotificationCount(gomock.Any(), "user-1").Return(0, nil)
+	m.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	today, err := service.GetToday(context.Background(), "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", today.Timezone)
+}
+
+func TestTodayService_GetToday_Errors(t *testing.T) {
+	t.Run("task lookup fails", func(t *testing.T) {
+		service, m := newTodayTestService(t)
+		m.counter.EXPECT().GetUnreadNotificationCount(gomock.Any(), "user-1").Return(1, nil)
+		m.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("db down"))
+
+		_, err := service.GetToday(context.Background(), "user-1", "UTC")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unread count fails", func(t *testing.T) {
+		service, m := newTodayTestService(t)
+		m.counter.EXPECT().GetUnreadNotificationCount(gomock.Any(), "user-1").Return(0, errors.New("db down"))
+		m.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := service.GetToday(context.Background(), "user-1", "UTC")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		service, m := newTodayTestService(t)
+		m.counter.EXPECT().GetUnreadNotificationCount(gomock.Any(), "user-1").Return(0, nil)
+
+		_, err := service.GetToday(context.Background(), "user-1", "Mars/Base")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestTodayService_Location(t *testing.T) {
+	service, m := newTodayTestService(t)
+
+	// 指定がない場合は勤務時間設定のタイムゾーンを使う
+	hours := domain.DefaultWorkingHours("user-1")
+	hours.Timezone = "America/New_York"
+	m.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+	loc, err := service.Location(context.Background(), "user-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", loc.String())
+
+	loc, err = service.Location(context.Background(), "user-1", "Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", loc.String())
+
+	_, err = service.Location(context.Background(), "user-1", "Not/AZone")
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
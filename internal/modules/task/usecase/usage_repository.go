@@ -0,0 +1,13 @@
+package usecase
+
+import "context"
+
+// TaskUsageRepository は使用量の上限の確認に使うタスク・添付ファイルの集計のリポジトリインターフェース
+type TaskUsageRepository interface {
+	// CountOpenTasksByCreator はユーザーが作成した未完了のタスク数を取得する
+	CountOpenTasksByCreator(ctx context.Context, userID string) (int64, error)
+	// CountOpenGroupTasks はグループタスクのうち未完了のものの数を取得する
+	CountOpenGroupTasks(ctx context.Context, groupID string) (int64, error)
+	// SumAttachmentBytesByUploader はユーザーがアップロードした添付ファイルの合計サイズ（バイト）を取得する
+	SumAttachmentBytesByUploader(ctx context.Context, userID string) (int64, error)
+}
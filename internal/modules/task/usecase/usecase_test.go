@@ -6,10 +6,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
 	"github.com/hryt430/Yotei+/pkg/logger"
 )
 
@@ -28,14 +33,15 @@ func createTestLogger() *logger.Logger {
 
 // MockTaskRepository はテスト用のTaskRepositoryモック
 type MockTaskRepository struct {
-	CreateTaskFunc         func(ctx context.Context, task *domain.Task) error
-	GetTaskByIDFunc        func(ctx context.Context, id string) (*domain.Task, error)
-	ListTasksFunc          func(ctx context.Context, filter domain.ListFilter, pagination domain.Pagination, sortOptions domain.SortOptions) ([]*domain.Task, int, error)
-	UpdateTaskFunc         func(ctx context.Context, task *domain.Task) error
-	DeleteTaskFunc         func(ctx context.Context, id string) error
-	GetOverdueTasksFunc    func(ctx context.Context) ([]*domain.Task, error)
-	GetTasksByAssigneeFunc func(ctx context.Context, userID string) ([]*domain.Task, error)
-	SearchTasksFunc        func(ctx context.Context, query string, limit int) ([]*domain.Task, error)
+	CreateTaskFunc            func(ctx context.Context, task *domain.Task) error
+	GetTaskByIDFunc           func(ctx context.Context, id string) (*domain.Task, error)
+	ListTasksFunc             func(ctx context.Context, filter domain.ListFilter, pagination domain.Pagination, sortOptions domain.SortOptions) ([]*domain.Task, int, error)
+	UpdateTaskFunc            func(ctx context.Context, task *domain.Task) error
+	DeleteTaskFunc            func(ctx context.Context, id string) error
+	GetOverdueTasksFunc       func(ctx context.Context) ([]*domain.Task, error)
+	GetOverdueTasksByUserFunc func(ctx context.Context, userID string) ([]*domain.Task, error)
+	GetTasksByAssigneeFunc    func(ctx context.Context, userID string) ([]*domain.Task, error)
+	SearchTasksFunc           func(ctx context.Context, query string, limit int) ([]*domain.Task, error)
 }
 
 func (m *MockTaskRepository) CreateTask(ctx context.Context, task *domain.Task) error {
@@ -80,6 +86,13 @@ func (m *MockTaskRepository) GetOverdueTasks(ctx context.Context) ([]*domain.Tas
 	return []*domain.Task{}, nil
 }
 
+func (m *MockTaskRepository) GetOverdueTasksByUser(ctx context.Context, userID string) ([]*domain.Task, error) {
+	if m.GetOverdueTasksByUserFunc != nil {
+		return m.GetOverdueTasksByUserFunc(ctx, userID)
+	}
+	return []*domain.Task{}, nil
+}
+
 func (m *MockTaskRepository) GetTasksByAssignee(ctx context.Context, userID string) ([]*domain.Task, error) {
 	if m.GetTasksByAssigneeFunc != nil {
 		return m.GetTasksByAssigneeFunc(ctx, userID)
@@ -97,6 +110,7 @@ func (m *MockTaskRepository) SearchTasks(ctx context.Context, query string, limi
 // MockUserValidator はテスト用のUserValidatorモック
 type MockUserValidator struct {
 	UserExistsFunc        func(ctx context.Context, userID string) (bool, error)
+	UsersExistFunc        func(ctx context.Context, userIDs []string) ([]string, error)
 	GetUserInfoFunc       func(ctx context.Context, userID string) (*commonDomain.UserInfo, error)
 	GetUsersInfoBatchFunc func(ctx context.Context, userIDs []string) (map[string]*commonDomain.UserInfo, error)
 }
@@ -108,6 +122,24 @@ func (m *MockUserValidator) UserExists(ctx context.Context, userID string) (bool
 	return true, nil
 }
 
+func (m *MockUserValidator) UsersExist(ctx context.Context, userIDs []string) ([]string, error) {
+	if m.UsersExistFunc != nil {
+		return m.UsersExistFunc(ctx, userIDs)
+	}
+	// UsersExistFuncが未設定の場合はUserExistsの結果から組み立てる
+	var missing []string
+	for _, userID := range userIDs {
+		exists, err := m.UserExists(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missing = append(missing, userID)
+		}
+	}
+	return missing, nil
+}
+
 func (m *MockUserValidator) GetUserInfo(ctx context.Context, userID string) (*commonDomain.UserInfo, error) {
 	if m.GetUserInfoFunc != nil {
 		return m.GetUserInfoFunc(ctx, userID)
@@ -139,7 +171,7 @@ type MockEventPublisher struct {
 	PublishTaskCreatedFunc   func(ctx context.Context, task *domain.Task) error
 	PublishTaskUpdatedFunc   func(ctx context.Context, task *domain.Task) error
 	PublishTaskDeletedFunc   func(ctx context.Context, taskID string) error
-	PublishTaskAssignedFunc  func(ctx context.Context, task *domain.Task) error
+	PublishTaskAssignedFunc  func(ctx context.Context, task *domain.Task, assigneeIDs []string) error
 	PublishTaskCompletedFunc func(ctx context.Context, task *domain.Task) error
 }
 
@@ -164,9 +196,9 @@ func (m *MockEventPublisher) PublishTaskDeleted(ctx context.Context, taskID stri
 	return nil
 }
 
-func (m *MockEventPublisher) PublishTaskAssigned(ctx context.Context, task *domain.Task) error {
+func (m *MockEventPublisher) PublishTaskAssigned(ctx context.Context, task *domain.Task, assigneeIDs []string) error {
 	if m.PublishTaskAssignedFunc != nil {
-		return m.PublishTaskAssignedFunc(ctx, task)
+		return m.PublishTaskAssignedFunc(ctx, task, assigneeIDs)
 	}
 	return nil
 }
@@ -485,7 +517,7 @@ func TestTaskService_AssignTask(t *testing.T) {
 				}
 
 				mockEventPublisher := &MockEventPublisher{
-					PublishTaskAssignedFunc: func(ctx context.Context, task *domain.Task) error {
+					PublishTaskAssignedFunc: func(ctx context.Context, task *domain.Task, assigneeIDs []string) error {
 						return nil
 					},
 				}
@@ -600,7 +632,7 @@ func TestTaskService_AssignTask(t *testing.T) {
 
 			service := NewTaskService(mockRepo, mockUserValidator, mockEventPublisher, *mockLogger)
 
-			task, err := service.AssignTask(context.Background(), tt.taskID, tt.assigneeID)
+			task, err := service.AssignTask(context.Background(), tt.taskID, "user123", tt.assigneeID)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -701,6 +733,96 @@ func TestTaskService_DeleteTask(t *testing.T) {
 	}
 }
 
+// recordingUndoScheduler は登録された操作を保持し、テストから実行できるundo.Scheduler
+type recordingUndoScheduler struct {
+	ownerID string
+	action  string
+	run     func(ctx context.Context) error
+}
+
+func (s *recordingUndoScheduler) Defer(ownerID, action string, run func(ctx context.Context) error) *undo.Token {
+	s.ownerID, s.action, s.run = ownerID, action, run
+	return &undo.Token{Token: "undo-token", Action: action, ExpiresAt: time.Now().Add(10 * time.Second)}
+}
+
+func TestTaskService_DeleteTaskWithUndo(t *testing.T) {
+	task := &domain.Task{ID: "task123", Title: "Test Task", CreatedBy: "user123"}
+
+	t.Run("defers deletion until the undo window passes", func(t *testing.T) {
+		var deleted []string
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+			DeleteTaskFunc: func(ctx context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+		}
+		mockEventPublisher := &MockEventPublisher{
+			PublishTaskDeletedFunc: func(ctx context.Context, taskID string) error {
+				return nil
+			},
+		}
+		scheduler := &recordingUndoScheduler{}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, mockEventPublisher, *createTestLogger())
+		service.UndoScheduler = scheduler
+
+		token, err := service.DeleteTaskWithUndo(context.Background(), "task123", "user123")
+
+		require.NoError(t, err)
+		require.NotNil(t, token)
+		assert.Equal(t, "undo-token", token.Token)
+		assert.Equal(t, "user123", scheduler.ownerID)
+		assert.Equal(t, undo.ActionDeleteTask, scheduler.action)
+		assert.Empty(t, deleted, "the task is kept until the deferred job runs")
+
+		require.NoError(t, scheduler.run(context.Background()))
+		assert.Equal(t, []string{"task123"}, deleted)
+	})
+
+	t.Run("deletes immediately without a scheduler", func(t *testing.T) {
+		var deleted []string
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+			DeleteTaskFunc: func(ctx context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+		}
+		mockEventPublisher := &MockEventPublisher{
+			PublishTaskDeletedFunc: func(ctx context.Context, taskID string) error {
+				return nil
+			},
+		}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, mockEventPublisher, *createTestLogger())
+
+		token, err := service.DeleteTaskWithUndo(context.Background(), "task123", "user123")
+
+		require.NoError(t, err)
+		assert.Nil(t, token)
+		assert.Equal(t, []string{"task123"}, deleted)
+	})
+
+	t.Run("missing task is not scheduled", func(t *testing.T) {
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return nil, ErrTaskNotFound
+			},
+		}
+		scheduler := &recordingUndoScheduler{}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+		service.UndoScheduler = scheduler
+
+		_, err := service.DeleteTaskWithUndo(context.Background(), "nonexistent", "user123")
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		assert.Nil(t, scheduler.run)
+	})
+}
+
 func TestTaskService_ChangeTaskStatus(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -808,3 +930,580 @@ func TestTaskService_ChangeTaskStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_QuickAddTask(t *testing.T) {
+	now := time.Date(2024, 6, 12, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		text          string
+		setupMocks    func() (*MockTaskRepository, *MockUserValidator, *MockEventPublisher)
+		expectedError error
+	}{
+		{
+			name: "successful quick add",
+			text: "レポート提出 明日 15時 #work !high",
+			setupMocks: func() (*MockTaskRepository, *MockUserValidator, *MockEventPublisher) {
+				mockRepo := &MockTaskRepository{
+					CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+						assert.Equal(t, "レポート提出", task.Title)
+						assert.Equal(t, domain.PriorityHigh, task.Priority)
+						assert.Equal(t, domain.CategoryWork, task.Category)
+						assert.NotNil(t, task.DueDate)
+						assert.True(t, time.Date(2024, 6, 13, 15, 0, 0, 0, time.UTC).Equal(*task.DueDate))
+						assert.NotEmpty(t, task.ID)
+						return nil
+					},
+				}
+				return mockRepo, &MockUserValidator{}, &MockEventPublisher{}
+			},
+			expectedError: nil,
+		},
+		{
+			name: "text without title",
+			text: "明日 #work",
+			setupMocks: func() (*MockTaskRepository, *MockUserValidator, *MockEventPublisher) {
+				return &MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}
+			},
+			expectedError: ErrInvalidParameter,
+		},
+		{
+			name: "user not found",
+			text: "買い物 today",
+			setupMocks: func() (*MockTaskRepository, *MockUserValidator, *MockEventPublisher) {
+				mockUserValidator := &MockUserValidator{
+					UserExistsFunc: func(ctx context.Context, userID string) (bool, error) {
+						return false, nil
+					},
+				}
+				return &MockTaskRepository{}, mockUserValidator, &MockEventPublisher{}
+			},
+			expectedError: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, mockUserValidator, mockEventPublisher := tt.setupMocks()
+			mockLogger := createTestLogger()
+
+			service := NewTaskService(mockRepo, mockUserValidator, mockEventPublisher, *mockLogger)
+
+			task, parsed, err := service.QuickAddTask(context.Background(), tt.text, "user123", now)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, task)
+				assert.Nil(t, parsed)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, task)
+				assert.NotNil(t, parsed)
+				assert.Equal(t, parsed.Title, task.Title)
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTaskWithID(t *testing.T) {
+	const id = "0190a6b2-7c1e-7d3a-9f00-1a2b3c4d5e6f"
+
+	t.Run("the client id is kept", func(t *testing.T) {
+		var created *domain.Task
+		mockRepo := &MockTaskRepository{
+			CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+				created = task
+				return nil
+			},
+		}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		task, err := service.CreateTaskWithID(context.Background(), id, "Offline task", "", domain.PriorityLow, domain.CategoryOther, "user123")
+		require.NoError(t, err)
+		assert.Equal(t, id, task.ID)
+		require.NotNil(t, created)
+		assert.Equal(t, id, created.ID)
+	})
+
+	t.Run("ids must be UUIDv7", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		for _, invalid := range []string{"task-1", uuid.New().String()} {
+			_, err := service.CreateTaskWithID(context.Background(), invalid, "Offline task", "", domain.PriorityLow, domain.CategoryOther, "user123")
+			assert.ErrorIs(t, err, ErrInvalidParameter, invalid)
+		}
+	})
+
+	t.Run("a resent id returns the created task", func(t *testing.T) {
+		existing := &domain.Task{ID: id, Title: "Offline task", CreatedBy: "user123"}
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, taskID string) (*domain.Task, error) {
+				return existing, nil
+			},
+			CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+				t.Fatal("the task must not be created twice")
+				return nil
+			},
+		}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		task, err := service.CreateTaskWithID(context.Background(), id, "Offline task", "", domain.PriorityLow, domain.CategoryOther, "user123")
+		require.NoError(t, err)
+		assert.Same(t, existing, task)
+
+		_, err = service.CreateTaskWithID(context.Background(), id, "Offline task", "", domain.PriorityLow, domain.CategoryOther, "someone-else")
+		assert.ErrorIs(t, err, ErrTaskIDConflict)
+	})
+
+	t.Run("server generated ids are UUIDv7", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		task, err := service.CreateTask(context.Background(), "Online task", "", domain.PriorityLow, domain.CategoryOther, "user123")
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Version(7), uuid.MustParse(task.ID).Version())
+	})
+}
+
+func TestTaskService_UpdateTaskEstimate(t *testing.T) {
+	minutes, points, actual := 90, 3, 120
+	negative := -1
+
+	t.Run("set estimate and actual", func(t *testing.T) {
+		task := &domain.Task{ID: "task123", CreatedBy: "user123", Status: domain.TaskStatusInProgress, Priority: domain.PriorityMedium}
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+		}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		updated, err := service.UpdateTaskEstimate(context.Background(), "task123", "user123", &minutes, &points, &actual)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, *updated.EstimateMinutes)
+		assert.Equal(t, 3, *updated.EstimatePoints)
+		assert.Equal(t, 120, *updated.ActualMinutes)
+	})
+
+	t.Run("keeps existing estimate when omitted", func(t *testing.T) {
+		task := &domain.Task{ID: "task123", CreatedBy: "user123", EstimateMinutes: &minutes, EstimatePoints: &points}
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+		}
+		service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		updated, err := service.UpdateTaskEstimate(context.Background(), "task123", "user123", nil, nil, &actual)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, *updated.EstimateMinutes)
+		assert.Equal(t, 3, *updated.EstimatePoints)
+	})
+
+	t.Run("negative value", func(t *testing.T) {
+		service := NewTaskService(&MockTaskRepository{}, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+		_, err := service.UpdateTaskEstimate(context.Background(), "task123", "user123", &negative, nil, nil)
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestTaskService_UpdateTaskSchedule(t *testing.T) {
+	start := time.Date(2030, 1, 6, 9, 0, 0, 0, time.UTC)
+	due := start.AddDate(0, 0, 3)
+	newService := func(task *domain.Task) *TaskService {
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+		}
+		return NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	}
+
+	t.Run("sets start and due dates", func(t *testing.T) {
+		service := newService(&domain.Task{ID: "task123", CreatedBy: "user123", Status: domain.TaskStatusTodo})
+
+		updated, err := service.UpdateTaskSchedule(context.Background(), "task123", "user123", &start, &due, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, start, *updated.StartDate)
+		assert.Equal(t, due, *updated.DueDate)
+	})
+
+	t.Run("rejects a start date after the existing due date", func(t *testing.T) {
+		existingDue := start.AddDate(0, 0, -1)
+		service := newService(&domain.Task{ID: "task123", CreatedBy: "user123", DueDate: &existingDue})
+
+		_, err := service.UpdateTaskSchedule(context.Background(), "task123", "user123", &start, nil, false)
+
+		assert.ErrorIs(t, err, ErrStartAfterDue)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("clears the start date", func(t *testing.T) {
+		service := newService(&domain.Task{ID: "task123", CreatedBy: "user123", StartDate: &start})
+
+		updated, err := service.UpdateTaskSchedule(context.Background(), "task123", "user123", nil, nil, true)
+
+		require.NoError(t, err)
+		assert.Nil(t, updated.StartDate)
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		service := newService(&domain.Task{ID: "task123", CreatedBy: "user123"})
+
+		_, err := service.UpdateTaskSchedule(context.Background(), "task123", "user123", nil, nil, false)
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestTaskService_UpdateTask_DueBeforeStart(t *testing.T) {
+	start := time.Date(2030, 1, 6, 9, 0, 0, 0, time.UTC)
+	due := start.Add(-time.Hour)
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return &domain.Task{ID: "task123", StartDate: &start}, nil
+		},
+		UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+			t.Fatal("task with an invalid schedule must not be saved")
+			return nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+	_, err := service.UpdateTask(context.Background(), "task123", nil, nil, nil, nil, &due)
+
+	assert.ErrorIs(t, err, ErrStartAfterDue)
+}
+
+func TestTaskService_GetOverdueTasksByUser(t *testing.T) {
+	mockRepo := &MockTaskRepository{
+		GetOverdueTasksFunc: func(ctx context.Context) ([]*domain.Task, error) {
+			t.Fatal("overdue tasks must be scoped to the user")
+			return nil, nil
+		},
+		GetOverdueTasksByUserFunc: func(ctx context.Context, userID string) ([]*domain.Task, error) {
+			return []*domain.Task{{ID: "task123", CreatedBy: userID}}, nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+	tasks, err := service.GetOverdueTasksByUser(context.Background(), "user123")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "user123", tasks[0].CreatedBy)
+
+	_, err = service.GetOverdueTasksByUser(context.Background(), "")
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+func TestTaskService_UpdateTask_ExtractsChecklist(t *testing.T) {
+	var saved *domain.Task
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return &domain.Task{ID: "task123", Description: "- [ ] old"}, nil
+		},
+		UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+			saved = task
+			return nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+	description := "## 買い物\n\n- [x] 牛乳\n- [ ] **卵** を\n  10個\n\n```\n- [ ] コード中の項目\n```"
+	_, err := service.UpdateTask(context.Background(), "task123", nil, &description, nil, nil, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, []domain.ChecklistItem{
+		{Text: "牛乳", Checked: true},
+		{Text: "卵 を 10個", Checked: false},
+	}, saved.Checklist)
+
+	// タスクリストがなくなった場合はチェックリストも消去する
+	description = "メモのみ"
+	_, err = service.UpdateTask(context.Background(), "task123", nil, &description, nil, nil, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, saved.Checklist)
+}
+
+func TestTaskService_AssignTaskToUsers(t *testing.T) {
+	newTask := func() *domain.Task {
+		return &domain.Task{ID: "task123", Status: domain.TaskStatusTodo, CreatedBy: "user123"}
+	}
+	userExists := &MockUserValidator{
+		UserExistsFunc: func(ctx context.Context, userID string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	t.Run("adds multiple assignees and notifies new ones", func(t *testing.T) {
+		task := newTask()
+		task.AddAssignee("user1")
+		notified := make(chan []string, 1)
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+		}
+		mockEventPublisher := &MockEventPublisher{
+			PublishTaskAssignedFunc: func(ctx context.Context, task *domain.Task, assigneeIDs []string) error {
+				notified <- assigneeIDs
+				return nil
+			},
+		}
+		service := NewTaskService(mockRepo, userExists, mockEventPublisher, *createTestLogger())
+		requireAll := true
+
+		updated, err := service.AssignTaskToUsers(context.Background(), "task123", "user123", []string{"user1", "user2", "user3", "user2"}, &requireAll)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"user1", "user2", "user3"}, updated.AssigneeIDs())
+		assert.Equal(t, "user1", *updated.AssigneeID)
+		assert.True(t, updated.RequireAllAssignees)
+		select {
+		case ids := <-notified:
+			assert.Equal(t, []string{"user2", "user3"}, ids)
+		case <-time.After(time.Second):
+			t.Fatal("task assigned event was not published")
+		}
+	})
+
+	t.Run("only completion rule changed", func(t *testing.T) {
+		task := newTask()
+		task.AddAssignee("user1")
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+		}
+		service := NewTaskService(mockRepo, userExists, &MockEventPublisher{}, *createTestLogger())
+		requireAll := true
+
+		updated, err := service.AssignTaskToUsers(context.Background(), "task123", "user123", []string{"user1"}, &requireAll)
+
+		assert.NoError(t, err)
+		assert.True(t, updated.RequireAllAssignees)
+	})
+
+	t.Run("all already assigned", func(t *testing.T) {
+		task := newTask()
+		task.AddAssignee("user1")
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				return task, nil
+			},
+		}
+		service := NewTaskService(mockRepo, userExists, &MockEventPublisher{}, *createTestLogger())
+
+		_, err := service.AssignTaskToUsers(context.Background(), "task123", "user123", []string{"user1"}, nil)
+
+		assert.Equal(t, ErrDuplicateAssignment, err)
+	})
+
+	t.Run("validates all assignees in one call", func(t *testing.T) {
+		var calls [][]string
+		validator := &MockUserValidator{
+			UsersExistFunc: func(ctx context.Context, userIDs []string) ([]string, error) {
+				calls = append(calls, userIDs)
+				return []string{"ghost"}, nil
+			},
+		}
+		mockRepo := &MockTaskRepository{
+			GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+				t.Fatal("task should not be loaded when an assignee is missing")
+				return nil, nil
+			},
+		}
+		service := NewTaskService(mockRepo, validator, &MockEventPublisher{}, *createTestLogger())
+
+		_, err := service.AssignTaskToUsers(context.Background(), "task123", "user123", []string{"user1", "ghost", "user2"}, nil)
+
+		assert.Equal(t, ErrUserNotFound, err)
+		assert.Equal(t, [][]string{{"user1", "ghost", "user2"}}, calls)
+	})
+}
+
+func TestTaskService_UnassignTask(t *testing.T) {
+	task := &domain.Task{ID: "task123", Status: domain.TaskStatusTodo, CreatedBy: "user123"}
+	task.AddAssignee("user1")
+	task.AddAssignee("user2")
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return task, nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+
+	updated, err := service.UnassignTask(context.Background(), "task123", "user123", "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user2"}, updated.AssigneeIDs())
+
+	_, err = service.UnassignTask(context.Background(), "task123", "user123", "user1")
+	assert.Equal(t, ErrAssigneeNotFound, err)
+}
+
+func TestTaskService_SetAssignmentCompletion(t *testing.T) {
+	task := &domain.Task{ID: "task123", Status: domain.TaskStatusInProgress, RequireAllAssignees: true}
+	task.AddAssignee("user1")
+	task.AddAssignee("user2")
+	completed := make(chan struct{}, 1)
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return task, nil
+		},
+	}
+	mockEventPublisher := &MockEventPublisher{
+		PublishTaskCompletedFunc: func(ctx context.Context, task *domain.Task) error {
+			completed <- struct{}{}
+			return nil
+		},
+	}
+	service := NewTaskService(mockRepo, &MockUserValidator{}, mockEventPublisher, *createTestLogger())
+
+	updated, err := service.SetAssignmentCompletion(context.Background(), "task123", "user1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusInProgress, updated.Status)
+
+	updated, err = service.SetAssignmentCompletion(context.Background(), "task123", "user2", true)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusDone, updated.Status)
+	select {
+	case <-completed:
+	case <-time.After(time.Second):
+		t.Fatal("task completed event was not published")
+	}
+
+	_, err = service.SetAssignmentCompletion(context.Background(), "task123", "user9", true)
+	assert.Equal(t, ErrAssigneeNotFound, err)
+}
+
+func TestTaskService_GroupGuestCannotModifyTask(t *testing.T) {
+	due := time.Date(2030, 1, 6, 9, 0, 0, 0, time.UTC)
+	minutes := 30
+	title := "変更"
+	requireAll := true
+
+	tests := []struct {
+		name   string
+		action domain.GroupAction
+		call   func(service *TaskService) error
+	}{
+		{
+			name:   "update",
+			action: domain.GroupActionEditTasks,
+			call: func(service *TaskService) error {
+				_, err := service.UpdateTaskAsUser(context.Background(), "task123", "guest", &title, nil, nil, nil, nil)
+				return err
+			},
+		},
+		{
+			name:   "estimate",
+			action: domain.GroupActionEditTasks,
+			call: func(service *TaskService) error {
+				_, err := service.UpdateTaskEstimate(context.Background(), "task123", "guest", &minutes, nil, nil)
+				return err
+			},
+		},
+		{
+			name:   "schedule",
+			action: domain.GroupActionEditTasks,
+			call: func(service *TaskService) error {
+				_, err := service.UpdateTaskSchedule(context.Background(), "task123", "guest", nil, &due, false)
+				return err
+			},
+		},
+		{
+			name:   "assign",
+			action: domain.GroupActionEditTasks,
+			call: func(service *TaskService) error {
+				_, _, err := service.AssignTaskToUsersWithWorkloadCheck(context.Background(), "task123", "guest", []string{"guest"}, &requireAll)
+				return err
+			},
+		},
+		{
+			name:   "unassign",
+			action: domain.GroupActionEditTasks,
+			call: func(service *TaskService) error {
+				_, err := service.UnassignTask(context.Background(), "task123", "guest", "user1")
+				return err
+			},
+		},
+		{
+			name:   "change status",
+			action: domain.GroupActionEditTasks,
+			call: func(service *TaskService) error {
+				_, err := service.ChangeTaskStatusAsUser(context.Background(), "task123", "guest", domain.TaskStatusDone)
+				return err
+			},
+		},
+		{
+			name:   "delete",
+			action: domain.GroupActionDeleteTasks,
+			call: func(service *TaskService) error {
+				return service.DeleteTaskAsUser(context.Background(), "task123", "guest")
+			},
+		},
+		{
+			name:   "delete with undo",
+			action: domain.GroupActionDeleteTasks,
+			call: func(service *TaskService) error {
+				_, err := service.DeleteTaskWithUndo(context.Background(), "task123", "guest")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &domain.Task{ID: "task123", Title: "Test Task", Status: domain.TaskStatusTodo, CreatedBy: "user123"}
+			task.AddAssignee("user1")
+			mockRepo := &MockTaskRepository{
+				GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+					return task, nil
+				},
+				UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
+					t.Fatal("a guest must not change the task")
+					return nil
+				},
+				DeleteTaskFunc: func(ctx context.Context, id string) error {
+					t.Fatal("a guest must not delete the task")
+					return nil
+				},
+			}
+			resolver := mocks.NewMockGroupTaskResolver(gomock.NewController(t))
+			// ゲストはグループタスクの閲覧のみ許可されている
+			resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task123").Return([]string{"group-1"}, nil)
+			resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "guest", tt.action).Return(false, nil)
+
+			service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+			service.GroupResolver = resolver
+			service.UndoScheduler = &recordingUndoScheduler{}
+
+			assert.ErrorIs(t, tt.call(service), ErrPermissionDenied)
+		})
+	}
+}
+
+func TestTaskService_GroupEditorCanUpdateTask(t *testing.T) {
+	task := &domain.Task{ID: "task123", Title: "Test Task", Status: domain.TaskStatusTodo, CreatedBy: "user123"}
+	mockRepo := &MockTaskRepository{
+		GetTaskByIDFunc: func(ctx context.Context, id string) (*domain.Task, error) {
+			return task, nil
+		},
+	}
+	resolver := mocks.NewMockGroupTaskResolver(gomock.NewController(t))
+	resolver.EXPECT().GetGroupIDsForTask(gomock.Any(), "task123").Return([]string{"group-1"}, nil)
+	resolver.EXPECT().CheckGroupPermission(gomock.Any(), "group-1", "member", domain.GroupActionEditTasks).Return(true, nil)
+
+	service := NewTaskService(mockRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.GroupResolver = resolver
+
+	updated, err := service.ChangeTaskStatusAsUser(context.Background(), "task123", "member", domain.TaskStatusInProgress)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusInProgress, updated.Status)
+}
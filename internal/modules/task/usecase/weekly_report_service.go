@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// WeeklyReportSubscriptionRepository は週次レポートの配信購読のリポジトリインターフェース
+type WeeklyReportSubscriptionRepository interface {
+	// 未購読の場合は nil, nil を返す
+	GetSubscription(ctx context.Context, userID string) (*domain.WeeklyReportSubscription, error)
+	SaveSubscription(ctx context.Context, subscription *domain.WeeklyReportSubscription) error
+	DeleteSubscription(ctx context.Context, userID string) error
+	ListSubscriptions(ctx context.Context) ([]*domain.WeeklyReportSubscription, error)
+	// MarkSent は週のレポートを配信済みとして記録する
+	MarkSent(ctx context.Context, userID, week string) error
+}
+
+// WeeklyReportMailer は週次レポートをメールで配信するインターフェース
+type WeeklyReportMailer interface {
+	SendWeeklyReport(ctx context.Context, to *commonDomain.UserInfo, report *domain.WeeklyReport) error
+}
+
+// ErrWeeklyReportSubscriptionNotFound は週次レポートを購読していない場合のエラー
+var ErrWeeklyReportSubscriptionNotFound = errors.New("weekly report subscription not found")
+
+// WeeklyReportService は週次の個人レポートの作成と配信を扱うサービス
+type WeeklyReportService struct {
+	StatsRepository    StatsRepository
+	WorkloadRepository WorkloadRepository
+	Subscriptions      WeeklyReportSubscriptionRepository
+	UserValidator      UserValidator
+	Mailer             WeeklyReportMailer
+	Logger             logger.Logger
+}
+
+// NewWeeklyReportService はWeeklyReportServiceのコンストラクタ
+func NewWeeklyReportService(
+	statsRepo StatsRepository,
+	workloadRepo WorkloadRepository,
+	subscriptions WeeklyReportSubscriptionRepository,
+	userValidator UserValidator,
+	mailer WeeklyReportMailer,
+	logger logger.Logger,
+) *WeeklyReportService {
+	return &WeeklyReportService{
+		StatsRepository:    statsRepo,
+		WorkloadRepository: workloadRepo,
+		Subscriptions:      subscriptions,
+		UserValidator:      userValidator,
+		Mailer:             mailer,
+		Logger:             logger,
+	}
+}
+
+// GenerateWeeklyReport は指定したISO週（例: "2024-W23"）のレポートを作成する
+// timezoneが空の場合はユーザーの勤務時間設定のタイムゾーンで週を区切る。未来の週は作成できない
+func (s *WeeklyReportService) GenerateWeeklyReport(ctx context.Context, userID, week, timezone string, now time.Time) (*domain.WeeklyReport, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	loc, err := resolveUserLocation(ctx, s.WorkloadRepository, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, userID, week, loc, now)
+}
+
+func (s *WeeklyReportService) generate(ctx context.Context, userID, week string, loc *time.Location, now time.Time) (*domain.WeeklyReport, error) {
+	weekStart, err := domain.ParseISOWeek(week, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	if weekStart.After(now) {
+		return nil, fmt.Errorf("%w: week %s has not started yet", ErrInvalidParameter, week)
+	}
+	_, weekEnd := domain.GetWeekStartEnd(weekStart)
+
+	completed, err := s.StatsRepository.GetCompletedTasksByDateRange(ctx, userID, weekStart, weekEnd)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get completed tasks for weekly report",
+			logger.Any("userID", userID), logger.Any("week", week), logger.Error(err))
+		return nil, fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+	scheduled, err := s.StatsRepository.GetScheduledTasksByDateRange(ctx, userID, weekStart, weekEnd)
+	if err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to get scheduled tasks for weekly report",
+			logger.Any("userID", userID), logger.Any("week", week), logger.Error(err))
+		return nil, fmt.Errorf("failed to get scheduled tasks: %w", err)
+	}
+
+	return domain.NewWeeklyReport(userID, weekStart, completed, scheduled, now.In(loc)), nil
+}
+
+// === 配信購読 ===
+
+// GetSubscription は週次レポートの配信購読を取得する
+func (s *WeeklyReportService) GetSubscription(ctx context.Context, userID string) (*domain.WeeklyReportSubscription, error) {
+	subscription, err := s.Subscriptions.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly report subscription: %w", err)
+	}
+	if subscription == nil {
+		return nil, ErrWeeklyReportSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// Subscribe は週次レポートのメール配信を購読する（購読済みの場合はタイムゾーンを更新する）
+// timezoneが空の場合はユーザーの勤務時間設定のタイムゾーンを使う
+func (s *WeeklyReportService) Subscribe(ctx context.Context, userID, timezone string) (*domain.WeeklyReportSubscription, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+	loc, err := resolveUserLocation(ctx, s.WorkloadRepository, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.Subscriptions.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly report subscription: %w", err)
+	}
+	now := time.Now()
+	if subscription == nil {
+		// 購読した週の前週分から配信しないよう、前週を配信済みとしておく
+		subscription = &domain.WeeklyReportSubscription{
+			UserID:       userID,
+			LastSentWeek: domain.PreviousISOWeek(now.In(loc)),
+			CreatedAt:    now,
+		}
+	}
+	subscription.Timezone = loc.String()
+	subscription.UpdatedAt = now
+
+	if err := s.Subscriptions.SaveSubscription(ctx, subscription); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to save weekly report subscription",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to save weekly report subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// Unsubscribe は週次レポートのメール配信を解除する
+func (s *WeeklyReportService) Unsubscribe(ctx context.Context, userID string) error {
+	if _, err := s.GetSubscription(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.Subscriptions.DeleteSubscription(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete weekly report subscription: %w", err)
+	}
+	return nil
+}
+
+// SendDueReports は配信時刻を過ぎた購読者に前週のレポートをメールで配信し、配信した件数を返す
+// 1人の配信に失敗しても他の購読者への配信は続け、失敗した購読者は次回の実行で再送する
+func (s *WeeklyReportService) SendDueReports(ctx context.Context, now time.Time) (int, error) {
+	subscriptions, err := s.Subscriptions.ListSubscriptions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list weekly report subscriptions: %w", err)
+	}
+
+	sent := 0
+	for _, subscription := range subscriptions {
+		week := subscription.DueWeek(now)
+		if week == "" {
+			continue
+		}
+		if err := s.send(ctx, subscription, week, now); err != nil {
+			s.Logger.WithContext(ctx).Error("Failed to send weekly report",
+				logger.Any("userID", subscription.UserID), logger.Any("week", week), logger.Error(err))
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *WeeklyReportService) send(ctx context.Context, subscription *domain.WeeklyReportSubscription, week string, now time.Time) error {
+	loc, err := time.LoadLocation(subscription.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	report, err := s.generate(ctx, subscription.UserID, week, loc, now)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.UserValidator.GetUserInfo(ctx, subscription.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
+	}
+	if user == nil || user.Email == "" {
+		return ErrUserNotFound
+	}
+
+	if err := s.Mailer.SendWeeklyReport(ctx, user, report); err != nil {
+		return fmt.Errorf("failed to send weekly report email: %w", err)
+	}
+	if err := s.Subscriptions.MarkSent(ctx, subscription.UserID, week); err != nil {
+		return fmt.Errorf("failed to mark weekly report as sent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=weekly_report_service.go -destination=mocks/mock_weekly_report_service.go -package=mocks
+
+type weeklyReportTestDeps struct {
+	statsRepo     *mocks.MockStatsRepository
+	workloadRepo  *mocks.MockWorkloadRepository
+	subscriptions *mocks.MockWeeklyReportSubscriptionRepository
+	userValidator *MockUserValidator
+	mailer        *mocks.MockWeeklyReportMailer
+}
+
+func newWeeklyReportTestService(t *testing.T) (*WeeklyReportService, *weeklyReportTestDeps) {
+	ctrl := gomock.NewController(t)
+	deps := &weeklyReportTestDeps{
+		statsRepo:     mocks.NewMockStatsRepository(ctrl),
+		workloadRepo:  mocks.NewMockWorkloadRepository(ctrl),
+		subscriptions: mocks.NewMockWeeklyReportSubscriptionRepository(ctrl),
+		userValidator: &MockUserValidator{},
+		mailer:        mocks.NewMockWeeklyReportMailer(ctrl),
+	}
+	service := NewWeeklyReportService(deps.statsRepo, deps.workloadRepo, deps.subscriptions,
+		deps.userValidator, deps.mailer, *createTestLogger())
+	return service, deps
+}
+
+func TestWeeklyReportService_GenerateWeeklyReport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+
+	t.Run("builds the report for the week in the given timezone", func(t *testing.T) {
+		service, deps := newWeeklyReportTestService(t)
+		completedAt := time.Date(2024, 6, 4, 1, 0, 0, 0, time.UTC)
+		done := &domain.Task{ID: "task-1", Status: domain.TaskStatusDone, Category: domain.CategoryWork, CompletedAt: &completedAt}
+
+		deps.statsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID string, start, end time.Time) ([]*domain.Task, error) {
+				assert.Equal(t, "2024-06-03T00:00:00+09:00", start.Format(time.RFC3339))
+				assert.Equal(t, "2024-06-09T23:59:59+09:00", end.Format(time.RFC3339))
+				return []*domain.Task{done}, nil
+			})
+		deps.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		report, err := service.GenerateWeeklyReport(ctx, "user-1", "2024-W23", "Asia/Tokyo", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-W23", report.Week)
+		assert.Equal(t, "Asia/Tokyo", report.Timezone)
+		assert.Equal(t, 1, report.CompletedCount)
+	})
+
+	t.Run("falls back to the working hours timezone", func(t *testing.T) {
+		service, deps := newWeeklyReportTestService(t)
+		hours := domain.DefaultWorkingHours("user-1")
+		hours.Timezone = "America/New_York"
+		deps.workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+		deps.statsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "user-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		report, err := service.GenerateWeeklyReport(ctx, "user-1", "2024-W23", "", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", report.Timezone)
+	})
+
+	t.Run("invalid and future weeks are rejected", func(t *testing.T) {
+		service, _ := newWeeklyReportTestService(t)
+
+		_, err := service.GenerateWeeklyReport(ctx, "user-1", "2024-23", "UTC", now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+
+		_, err = service.GenerateWeeklyReport(ctx, "user-1", "2024-W25", "UTC", now)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestWeeklyReportService_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new subscriptions skip the week before subscribing", func(t *testing.T) {
+		service, deps := newWeeklyReportTestService(t)
+		deps.subscriptions.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(nil, nil)
+		deps.subscriptions.EXPECT().SaveSubscription(gomock.Any(), gomock.Any()).Return(nil)
+
+		subscription, err := service.Subscribe(ctx, "user-1", "Asia/Tokyo")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Tokyo", subscription.Timezone)
+		assert.Equal(t, domain.PreviousISOWeek(time.Now()), subscription.LastSentWeek)
+		assert.Empty(t, subscription.DueWeek(time.Now()))
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		service, _ := newWeeklyReportTestService(t)
+
+		_, err := service.Subscribe(ctx, "user-1", "Mars/Olympus")
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("unsubscribe without a subscription", func(t *testing.T) {
+		service, deps := newWeeklyReportTestService(t)
+		deps.subscriptions.EXPECT().GetSubscription(gomock.Any(), "user-1").Return(nil, nil)
+
+		err := service.Unsubscribe(ctx, "user-1")
+
+		assert.ErrorIs(t, err, ErrWeeklyReportSubscriptionNotFound)
+	})
+}
+
+func TestWeeklyReportService_SendDueReports(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC) // 東京は月曜日9時、UTCはまだ月曜日0時
+
+	t.Run("sends the previous week to due subscribers only", func(t *testing.T) {
+		service, deps := newWeeklyReportTestService(t)
+		deps.subscriptions.EXPECT().ListSubscriptions(gomock.Any()).Return([]*domain.WeeklyReportSubscription{
+			{UserID: "tokyo", Timezone: "Asia/Tokyo", LastSentWeek: "2024-W22"},
+			{UserID: "utc", Timezone: "UTC", LastSentWeek: "2024-W22"},         // まだ8時前
+			{UserID: "sent", Timezone: "Asia/Tokyo", LastSentWeek: "2024-W23"}, // 配信済み
+		}, nil)
+		deps.statsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "tokyo", gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "tokyo", gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.mailer.EXPECT().SendWeeklyReport(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, to *commonDomain.UserInfo, report *domain.WeeklyReport) error {
+				assert.Equal(t, "tokyo", to.ID)
+				assert.Equal(t, "2024-W23", report.Week)
+				return nil
+			})
+		deps.subscriptions.EXPECT().MarkSent(gomock.Any(), "tokyo", "2024-W23").Return(nil)
+
+		sent, err := service.SendDueReports(ctx, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("failed deliveries are not marked as sent", func(t *testing.T) {
+		service, deps := newWeeklyReportTestService(t)
+		deps.subscriptions.EXPECT().ListSubscriptions(gomock.Any()).Return([]*domain.WeeklyReportSubscription{
+			{UserID: "tokyo", Timezone: "Asia/Tokyo", LastSentWeek: "2024-W22"},
+		}, nil)
+		deps.statsRepo.EXPECT().GetCompletedTasksByDateRange(gomock.Any(), "tokyo", gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.statsRepo.EXPECT().GetScheduledTasksByDateRange(gomock.Any(), "tokyo", gomock.Any(), gomock.Any()).Return(nil, nil)
+		deps.mailer.EXPECT().SendWeeklyReport(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("smtp unavailable"))
+
+		sent, err := service.SendDueReports(ctx, now)
+
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
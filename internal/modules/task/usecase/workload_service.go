@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// 作業量照会の期間上限（日）
+const maxWorkloadRangeDays = 62
+
+// WorkloadRepository は勤務時間設定のリポジトリインターフェース
+type WorkloadRepository interface {
+	// 未設定の場合は nil, nil を返す
+	GetWorkingHours(ctx context.Context, userID string) (*domain.WorkingHours, error)
+	SaveWorkingHours(ctx context.Context, hours *domain.WorkingHours) error
+}
+
+// WorkloadChecker は割り当て時のキャパシティ確認インターフェース
+type WorkloadChecker interface {
+	CheckAssignment(ctx context.Context, task *domain.Task, assigneeID string) (*domain.WorkloadWarning, error)
+}
+
+// DueDateAdjuster は祝日・勤務日以外の期限の調整インターフェース
+type DueDateAdjuster interface {
+	AdjustDueDate(ctx context.Context, userID string, due time.Time) (time.Time, error)
+}
+
+// WorkingHoursInput は勤務時間設定の更新入力
+type WorkingHoursInput struct {
+	Timezone             string
+	WorkDays             []int
+	StartTime            string
+	EndTime              string
+	DailyCapacityMinutes int
+	HolidayPolicy        string // 空の場合は KEEP
+	ReminderTiming       string // 空の場合は WORKING_HOURS
+}
+
+// WorkloadService は勤務時間・作業量を扱うサービス
+type WorkloadService struct {
+	TaskRepository     TaskRepository
+	WorkloadRepository WorkloadRepository
+	GroupResolver      GroupTaskResolver
+	Logger             logger.Logger
+
+	// 営業日の判定に使う祝日（未設定の場合は勤務曜日のみで判定する）
+	Holidays holiday.Provider
+}
+
+// NewWorkloadService はWorkloadServiceのコンストラクタ
+func NewWorkloadService(
+	taskRepo TaskRepository,
+	workloadRepo WorkloadRepository,
+	groupResolver GroupTaskResolver,
+	logger logger.Logger,
+) *WorkloadService {
+	return &WorkloadService{
+		TaskRepository:     taskRepo,
+		WorkloadRepository: workloadRepo,
+		GroupResolver:      groupResolver,
+		Logger:             logger,
+	}
+}
+
+// GetWorkingHours はユーザーの勤務時間設定を取得する（未設定時は既定値）
+func (s *WorkloadService) GetWorkingHours(ctx context.Context, userID string) (*domain.WorkingHours, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	hours, err := s.WorkloadRepository.GetWorkingHours(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working hours: %w", err)
+	}
+	if hours == nil {
+		return domain.DefaultWorkingHours(userID), nil
+	}
+	return hours, nil
+}
+
+// UpdateWorkingHours はユーザーの勤務時間設定を更新する
+func (s *WorkloadService) UpdateWorkingHours(ctx context.Context, userID string, input WorkingHoursInput) (*domain.WorkingHours, error) {
+	if userID == "" {
+		return nil, ErrInvalidParameter
+	}
+
+	hours := domain.DefaultWorkingHours(userID)
+	if input.Timezone != "" {
+		hours.Timezone = input.Timezone
+	}
+	if input.StartTime != "" {
+		hours.StartTime = input.StartTime
+	}
+	if input.EndTime != "" {
+		hours.EndTime = input.EndTime
+	}
+	if input.WorkDays != nil {
+		hours.WorkDays = normalizeWorkDays(input.WorkDays)
+	}
+	hours.DailyCapacityMinutes = input.DailyCapacityMinutes
+	if input.HolidayPolicy != "" {
+		hours.HolidayPolicy = domain.HolidayPolicy(input.HolidayPolicy)
+	}
+	if input.ReminderTiming != "" {
+		hours.ReminderTiming = domain.ReminderTiming(input.ReminderTiming)
+	}
+	hours.UpdatedAt = time.Now()
+
+	if err := hours.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+
+	if err := s.WorkloadRepository.SaveWorkingHours(ctx, hours); err != nil {
+		s.Logger.WithContext(ctx).Error("Failed to save working hours",
+			logger.Any("userID", userID), logger.Error(err))
+		return nil, fmt.Errorf("failed to save working hours: %w", err)
+	}
+
+	s.Logger.WithContext(ctx).Info("Working hours updated", logger.Any("userID", userID))
+	return hours, nil
+}
+
+// AdjustDueDate はユーザーの期限の扱いの設定に従って、祝日・勤務日以外の期限を前後の営業日に移動する
+// 設定がない（KEEP の）場合は期限をそのまま返す
+func (s *WorkloadService) AdjustDueDate(ctx context.Context, userID string, due time.Time) (time.Time, error) {
+	hours, err := s.GetWorkingHours(ctx, userID)
+	if err != nil {
+		return due, err
+	}
+	return hours.AdjustDueDate(due, s.Holidays), nil
+}
+
+// GetWorkload は指定期間の作業量を取得する
+// 他ユーザーの作業量はgroupIDを指定し、そのグループの管理者かつ対象がメンバーの場合のみ参照可能
+func (s *WorkloadService) GetWorkload(ctx context.Context, requesterID, userID string, groupID *string, from, to time.Time) (*domain.Workload, error) {
+	if userID == "" {
+		userID = requesterID
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must be after 'from'", ErrInvalidParameter)
+	}
+	if to.Sub(from) > maxWorkloadRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range must be within %d days", ErrInvalidParameter, maxWorkloadRangeDays)
+	}
+
+	if userID != requesterID {
+		if err := s.checkGroupAccess(ctx, requesterID, userID, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	hours, err := s.GetWorkingHours(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.TaskRepository.GetTasksByAssignee(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned tasks: %w", err)
+	}
+
+	return domain.NewWorkload(userID, hours, tasks, from, to), nil
+}
+
+// CheckAssignment は割り当て後に担当者の期限日がキャパシティ超過になるかを確認する
+// 超過しない場合や期限のないタスクの場合は nil を返す
+func (s *WorkloadService) CheckAssignment(ctx context.Context, task *domain.Task, assigneeID string) (*domain.WorkloadWarning, error) {
+	if task.DueDate == nil || task.Status == domain.TaskStatusDone {
+		return nil, nil
+	}
+
+	hours, err := s.GetWorkingHours(ctx, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.TaskRepository.GetTasksByAssignee(ctx, assigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned tasks: %w", err)
+	}
+
+	// 期限日は担当者のタイムゾーンで判定する
+	dueDate := task.DueDate.In(hours.Location())
+	workload := domain.NewWorkload(assigneeID, hours, tasks, dueDate, dueDate)
+	if len(workload.Days) == 0 {
+		return nil, nil
+	}
+
+	daily := workload.Days[0]
+
+	// 割り当て前のタスクは集計に含まれないため加算する
+	included := false
+	for _, id := range daily.TaskIDs {
+		if id == task.ID {
+			included = true
+			break
+		}
+	}
+	if !included {
+		daily.PlannedMinutes += domain.EstimatedEffortMinutes(task)
+	}
+
+	if daily.PlannedMinutes <= daily.CapacityMinutes {
+		return nil, nil
+	}
+
+	return &domain.WorkloadWarning{
+		AssigneeID:      assigneeID,
+		Date:            daily.Date,
+		PlannedMinutes:  daily.PlannedMinutes,
+		CapacityMinutes: daily.CapacityMinutes,
+		Message: fmt.Sprintf("assignee is over capacity on %s (%d/%d minutes)",
+			daily.Date, daily.PlannedMinutes, daily.CapacityMinutes),
+	}, nil
+}
+
+// checkGroupAccess はメンバーの作業量を参照できるかを確認する（グループでタスク編集の権限が必要）
+func (s *WorkloadService) checkGroupAccess(ctx context.Context, requesterID, userID string, groupID *string) error {
+	if groupID == nil || *groupID == "" {
+		return ErrPermissionDenied
+	}
+
+	canManage, err := s.GroupResolver.CheckGroupPermission(ctx, *groupID, requesterID, domain.GroupActionEditTasks)
+	if err != nil {
+		return fmt.Errorf("failed to check group permission: %w", err)
+	}
+	if !canManage {
+		return ErrPermissionDenied
+	}
+
+	isMember, err := s.GroupResolver.IsGroupMember(ctx, *groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !isMember {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// normalizeWorkDays は曜日の重複を除き、範囲外の値はそのまま残して検証に委ねる
+func normalizeWorkDays(days []int) []time.Weekday {
+	seen := make(map[int]bool)
+	result := make([]time.Weekday, 0, len(days))
+	for _, day := range days {
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+		result = append(result, time.Weekday(day))
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/internal/modules/task/usecase/mocks"
+)
+
+//go:generate mockgen -source=workload_service.go -destination=mocks/mock_workload.go -package=mocks
+
+func newWorkloadTestService(t *testing.T) (*WorkloadService, *mocks.MockTaskRepository, *mocks.MockWorkloadRepository, *mocks.MockGroupTaskResolver) {
+	ctrl := gomock.NewController(t)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	workloadRepo := mocks.NewMockWorkloadRepository(ctrl)
+	resolver := mocks.NewMockGroupTaskResolver(ctrl)
+	return NewWorkloadService(taskRepo, workloadRepo, resolver, *createTestLogger()), taskRepo, workloadRepo, resolver
+}
+
+func TestWorkloadService_UpdateWorkingHours(t *testing.T) {
+	t.Run("valid settings", func(t *testing.T) {
+		service, _, workloadRepo, _ := newWorkloadTestService(t)
+		workloadRepo.EXPECT().SaveWorkingHours(gomock.Any(), gomock.Any()).Return(nil)
+
+		hours, err := service.UpdateWorkingHours(context.Background(), "user-1", WorkingHoursInput{
+			Timezone:  "UTC",
+			WorkDays:  []int{1, 2, 3, 3},
+			StartTime: "10:00",
+			EndTime:   "16:00",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []time.Weekday{time.Monday, time.Tuesday, time.Wednesday}, hours.WorkDays)
+		assert.Equal(t, "10:00", hours.StartTime)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		service, _, _, _ := newWorkloadTestService(t)
+
+		_, err := service.UpdateWorkingHours(context.Background(), "user-1", WorkingHoursInput{
+			StartTime: "18:00",
+			EndTime:   "09:00",
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestWorkloadService_GetWorkload(t *testing.T) {
+	from := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 16, 0, 0, 0, 0, time.UTC)
+	groupID := "group-1"
+
+	t.Run("own workload with default hours", func(t *testing.T) {
+		service, taskRepo, workloadRepo, _ := newWorkloadTestService(t)
+		workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(nil, nil)
+		taskRepo.EXPECT().GetTasksByAssignee(gomock.Any(), "user-1").Return([]*domain.Task{}, nil)
+
+		workload, err := service.GetWorkload(context.Background(), "user-1", "", nil, from, to)
+
+		require.NoError(t, err)
+		assert.Len(t, workload.Days, 7)
+		assert.Equal(t, 5*9*60, workload.TotalCapacityMinutes)
+	})
+
+	t.Run("other user without group", func(t *testing.T) {
+		service, _, _, _ := newWorkloadTestService(t)
+
+		_, err := service.GetWorkload(context.Background(), "user-1", "user-2", nil, from, to)
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("group admin views member", func(t *testing.T) {
+		service, taskRepo, workloadRepo, resolver := newWorkloadTestService(t)
+		resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "admin-1", domain.GroupActionEditTasks).Return(true, nil)
+		resolver.EXPECT().IsGroupMember(gomock.Any(), groupID, "user-2").Return(true, nil)
+		workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-2").Return(nil, nil)
+		taskRepo.EXPECT().GetTasksByAssignee(gomock.Any(), "user-2").Return(nil, nil)
+
+		workload, err := service.GetWorkload(context.Background(), "admin-1", "user-2", &groupID, from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-2", workload.UserID)
+	})
+
+	t.Run("non-admin member", func(t *testing.T) {
+		service, _, _, resolver := newWorkloadTestService(t)
+		resolver.EXPECT().CheckGroupPermission(gomock.Any(), groupID, "user-1", domain.GroupActionEditTasks).Return(false, nil)
+
+		_, err := service.GetWorkload(context.Background(), "user-1", "user-2", &groupID, from, to)
+
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("range too long", func(t *testing.T) {
+		service, _, _, _ := newWorkloadTestService(t)
+
+		_, err := service.GetWorkload(context.Background(), "user-1", "", nil, from, from.AddDate(0, 3, 0))
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestWorkloadService_CheckAssignment(t *testing.T) {
+	due := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC)
+	hours := domain.DefaultWorkingHours("user-2")
+	hours.Timezone = "UTC"
+	hours.DailyCapacityMinutes = 120
+
+	existing := &domain.Task{ID: "existing", Status: domain.TaskStatusTodo, Priority: domain.PriorityMedium, DueDate: &due}
+
+	t.Run("over capacity returns warning", func(t *testing.T) {
+		service, taskRepo, workloadRepo, _ := newWorkloadTestService(t)
+		task := &domain.Task{ID: "new", Status: domain.TaskStatusTodo, Priority: domain.PriorityHigh, DueDate: &due}
+		workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-2").Return(hours, nil)
+		taskRepo.EXPECT().GetTasksByAssignee(gomock.Any(), "user-2").Return([]*domain.Task{existing, task}, nil)
+
+		warning, err := service.CheckAssignment(context.Background(), task, "user-2")
+
+		require.NoError(t, err)
+		require.NotNil(t, warning)
+		assert.Equal(t, "2024-06-12", warning.Date)
+		assert.Equal(t, 180, warning.PlannedMinutes)
+		assert.Equal(t, 120, warning.CapacityMinutes)
+	})
+
+	t.Run("within capacity", func(t *testing.T) {
+		service, taskRepo, workloadRepo, _ := newWorkloadTestService(t)
+		task := &domain.Task{ID: "new", Status: domain.TaskStatusTodo, Priority: domain.PriorityLow, DueDate: &due}
+		workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-2").Return(hours, nil)
+		taskRepo.EXPECT().GetTasksByAssignee(gomock.Any(), "user-2").Return([]*domain.Task{existing}, nil)
+
+		warning, err := service.CheckAssignment(context.Background(), task, "user-2")
+
+		require.NoError(t, err)
+		assert.Nil(t, warning)
+	})
+
+	t.Run("task without due date", func(t *testing.T) {
+		service, _, _, _ := newWorkloadTestService(t)
+
+		warning, err := service.CheckAssignment(context.Background(), &domain.Task{ID: "new"}, "user-2")
+
+		require.NoError(t, err)
+		assert.Nil(t, warning)
+	})
+}
+
+func TestTaskService_AssignTaskWithWorkloadCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	workloadRepo := mocks.NewMockWorkloadRepository(ctrl)
+
+	due := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC)
+	hours := domain.DefaultWorkingHours("user-2")
+	hours.Timezone = "UTC"
+	hours.DailyCapacityMinutes = 60
+
+	task := &domain.Task{ID: "task-1", Status: domain.TaskStatusTodo, Priority: domain.PriorityHigh, DueDate: &due, CreatedBy: "user-1"}
+
+	taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+	taskRepo.EXPECT().UpdateTask(gomock.Any(), task).Return(nil)
+	workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-2").Return(hours, nil)
+	taskRepo.EXPECT().GetTasksByAssignee(gomock.Any(), "user-2").Return([]*domain.Task{task}, nil)
+
+	service := NewTaskService(taskRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.WorkloadChecker = NewWorkloadService(taskRepo, workloadRepo, nil, *createTestLogger())
+
+	assigned, warning, err := service.AssignTaskWithWorkloadCheck(context.Background(), "task-1", "user-1", "user-2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", *assigned.AssigneeID)
+	require.NotNil(t, warning)
+	assert.Equal(t, 120, warning.PlannedMinutes)
+}
+
+func TestTaskService_UpdateTaskScheduleWithHolidayPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	workloadRepo := mocks.NewMockWorkloadRepository(ctrl)
+
+	// 2024-05-03（金）は祝日のため、翌営業日の05-07（火）に延期する
+	due := time.Date(2024, 5, 3, 17, 0, 0, 0, time.UTC)
+	hours := domain.DefaultWorkingHours("user-1")
+	hours.Timezone = "UTC"
+	hours.HolidayPolicy = domain.HolidayPolicyNextBusinessDay
+
+	task := &domain.Task{ID: "task-1", Status: domain.TaskStatusTodo, Priority: domain.PriorityMedium, CreatedBy: "user-1"}
+
+	taskRepo.EXPECT().GetTaskByID(gomock.Any(), "task-1").Return(task, nil)
+	taskRepo.EXPECT().UpdateTask(gomock.Any(), task).Return(nil)
+	workloadRepo.EXPECT().GetWorkingHours(gomock.Any(), "user-1").Return(hours, nil)
+
+	workloadService := NewWorkloadService(taskRepo, workloadRepo, nil, *createTestLogger())
+	workloadService.Holidays = holiday.Japan()
+	service := NewTaskService(taskRepo, &MockUserValidator{}, &MockEventPublisher{}, *createTestLogger())
+	service.DueDateAdjuster = workloadService
+
+	updated, err := service.UpdateTaskSchedule(context.Background(), "task-1", "user-1", nil, &due, false)
+
+	require.NoError(t, err)
+	require.NotNil(t, updated.DueDate)
+	assert.Equal(t, time.Date(2024, 5, 7, 17, 0, 0, 0, time.UTC), *updated.DueDate)
+}
@@ -0,0 +1,63 @@
+// Package seed はローカル開発・負荷試験用のデモデータを投入します
+//
+// データのIDはプロファイル名と連番から決定的に生成するため、同じプロファイルで何度実行しても重複しません。
+package seed
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Profile は投入するデータの規模
+type Profile struct {
+	Name string
+
+	Users           int // ユーザー数
+	FriendsPerUser  int // 1ユーザーあたりの友達申請数
+	Groups          int // グループ数
+	MembersPerGroup int // 1グループあたりのメンバー数（オーナーを除く）
+	TasksPerUser    int // 1ユーザーあたりの単発タスク数（繰り返しタスクは別に作成する）
+}
+
+// profiles は定義済みのプロファイル
+var profiles = map[string]Profile{
+	// 画面確認用の小さなデータ
+	"demo": {Name: "demo", Users: 8, FriendsPerUser: 3, Groups: 2, MembersPerGroup: 4, TasksPerUser: 12},
+	// ローカル開発用
+	"dev": {Name: "dev", Users: 50, FriendsPerUser: 6, Groups: 10, MembersPerGroup: 6, TasksPerUser: 30},
+	// 負荷試験用
+	"load": {Name: "load", Users: 2000, FriendsPerUser: 25, Groups: 300, MembersPerGroup: 15, TasksPerUser: 60},
+}
+
+// LookupProfile は定義済みのプロファイルを取得する
+func LookupProfile(name string) (Profile, error) {
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown seed profile %q (available: %v)", name, ProfileNames())
+	}
+	return profile, nil
+}
+
+// ProfileNames は定義済みのプロファイル名を返す
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate はプロファイルの値を検証する
+func (p Profile) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if p.Users < 1 {
+		return fmt.Errorf("users must be at least 1")
+	}
+	if p.FriendsPerUser < 0 || p.Groups < 0 || p.MembersPerGroup < 0 || p.TasksPerUser < 0 {
+		return fmt.Errorf("counts must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,530 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUsecase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/utils"
+)
+
+// namespace はシードデータのID（UUIDv5）の名前空間
+var namespace = uuid.MustParse("0b6f3c52-8e1d-5a47-9c2e-5d9a7f41b3e8")
+
+// データの分布
+const (
+	acceptedFriendshipRatio = 0.85 // 承認済みの友達申請（残りは承認待ち）
+	projectGroupRatio       = 0.7  // プロジェクトグループ（残りは予定共有グループ）
+
+	overdueTaskRatio    = 0.15 // 期限切れの未完了タスク
+	doneTaskRatio       = 0.35 // 完了済みタスク
+	inProgressTaskRatio = 0.15 // 進行中のタスク（残りは未着手）
+	noDueDateRatio      = 0.1  // 期限なし（期限切れタスクを除く）
+	estimatedTaskRatio  = 0.5  // 見積もりあり
+	sharedTaskRatio     = 0.2  // 友達を担当者にする
+	selfAssignedRatio   = 0.5  // 自分を担当者にする
+
+	// 繰り返しタスクを持つユーザーの割合と回数（過去・今後で半分ずつ）
+	// タスクに繰り返しの設定はないため、同じタイトルのタスクを毎週作成して表現する
+	recurringUserRatio   = 0.3
+	recurringOccurrences = 8
+)
+
+type weighted[T any] struct {
+	value  T
+	weight int
+}
+
+var categoryWeights = []weighted[taskDomain.Category]{
+	{taskDomain.CategoryWork, 35},
+	{taskDomain.CategoryPersonal, 20},
+	{taskDomain.CategoryStudy, 15},
+	{taskDomain.CategoryHealth, 10},
+	{taskDomain.CategoryShopping, 10},
+	{taskDomain.CategoryOther, 10},
+}
+
+var priorityWeights = []weighted[taskDomain.Priority]{
+	{taskDomain.PriorityLow, 30},
+	{taskDomain.PriorityMedium, 50},
+	{taskDomain.PriorityHigh, 20},
+}
+
+var taskTitles = map[taskDomain.Category][]string{
+	taskDomain.CategoryWork:     {"企画書の作成", "顧客との打ち合わせ準備", "コードレビュー", "見積書の送付", "議事録の共有", "四半期目標の見直し"},
+	taskDomain.CategoryPersonal: {"部屋の掃除", "銀行の手続き", "旅行の予約", "年賀状の準備"},
+	taskDomain.CategoryStudy:    {"英単語の復習", "資格試験の過去問", "技術書を1章読む", "オンライン講座の受講"},
+	taskDomain.CategoryHealth:   {"ランニング30分", "歯医者の予約", "健康診断の予約", "ストレッチ"},
+	taskDomain.CategoryShopping: {"日用品の買い出し", "プレゼントの購入", "食材の買い物"},
+	taskDomain.CategoryOther:    {"書類の整理", "メールの整理", "写真の整理"},
+}
+
+var routines = []struct {
+	title    string
+	category taskDomain.Category
+}{
+	{"週次ミーティングの準備", taskDomain.CategoryWork},
+	{"週報の提出", taskDomain.CategoryWork},
+	{"ジムでトレーニング", taskDomain.CategoryHealth},
+	{"週末の買い出し", taskDomain.CategoryShopping},
+	{"英会話レッスン", taskDomain.CategoryStudy},
+}
+
+var groupNames = []string{"新サービス開発", "マーケティング", "読書会", "フットサル部", "家族の予定", "社内勉強会", "引っ越し準備", "旅行計画"}
+
+var estimateMinutes = []int{15, 30, 60, 90, 120, 240}
+
+// UserRepository はシードに使用するユーザーリポジトリ
+type UserRepository interface {
+	FindUserByID(id uuid.UUID) (*authDomain.User, error)
+	CreateUser(user *authDomain.User) error
+}
+
+// FriendshipRepository はシードに使用する友達関係リポジトリ
+type FriendshipRepository interface {
+	GetFriendship(ctx context.Context, requesterID, addresseeID uuid.UUID) (*socialDomain.Friendship, error)
+	CreateFriendship(ctx context.Context, friendship *socialDomain.Friendship) error
+}
+
+// GroupRepository はシードに使用するグループリポジトリ
+type GroupRepository interface {
+	GetGroupByID(ctx context.Context, id uuid.UUID) (*groupDomain.Group, error)
+	CreateGroup(ctx context.Context, group *groupDomain.Group) error
+	AddMember(ctx context.Context, member *groupDomain.GroupMember) error
+}
+
+// TaskRepository はシードに使用するタスクリポジトリ
+type TaskRepository interface {
+	GetTaskByID(ctx context.Context, id string) (*taskDomain.Task, error)
+	CreateTask(ctx context.Context, task *taskDomain.Task) error
+}
+
+// Count は作成・既存によるスキップの件数
+type Count struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
+
+func (c *Count) add(created bool) {
+	if created {
+		c.Created++
+	} else {
+		c.Skipped++
+	}
+}
+
+// Result はシードの実行結果
+type Result struct {
+	Users       Count `json:"users"`
+	Friendships Count `json:"friendships"`
+	Groups      Count `json:"groups"`
+	Tasks       Count `json:"tasks"`
+}
+
+// Seeder はプロファイルに従ってデータを投入する
+type Seeder struct {
+	users       UserRepository
+	friendships FriendshipRepository
+	groups      GroupRepository
+	tasks       TaskRepository
+	logger      *logger.Logger
+
+	// テストで時刻を固定するために差し替える
+	now func() time.Time
+}
+
+// NewSeeder は新しいSeederを作成する
+func NewSeeder(users UserRepository, friendships FriendshipRepository, groups GroupRepository, tasks TaskRepository, logger *logger.Logger) *Seeder {
+	return &Seeder{
+		users:       users,
+		friendships: friendships,
+		groups:      groups,
+		tasks:       tasks,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Run はプロファイルのデータを投入する
+// 既に存在するデータはスキップするため、同じプロファイルで繰り返し実行できる
+func (s *Seeder) Run(ctx context.Context, profile Profile, password string) (*Result, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC().Truncate(time.Minute)
+	result := &Result{}
+
+	users := make([]uuid.UUID, profile.Users)
+	for i := range users {
+		users[i] = seedID(profile, "user", i)
+		created, err := s.seedUser(ctx, profile, i, passwordHash, now)
+		if err != nil {
+			return nil, err
+		}
+		result.Users.add(created)
+	}
+
+	friends, err := s.seedFriendships(ctx, profile, users, now, &result.Friendships)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.seedGroups(ctx, profile, users, now, &result.Groups); err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if err := s.seedTasks(ctx, profile, i, users, friends[i], now, &result.Tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.WithContext(ctx).Info("Seed data loaded",
+		logger.Any("profile", profile.Name),
+		logger.Any("result", result))
+
+	return result, nil
+}
+
+// Reset はプロファイルで作成したユーザーを削除する
+// タスク・友達関係・グループは外部キーのON DELETE CASCADEで合わせて削除される
+func Reset(ctx context.Context, db *sql.DB, profile Profile) (int64, error) {
+	result, err := db.ExecContext(ctx,
+		"DELETE FROM `Yotei-Plus`.users WHERE email LIKE ?",
+		"seed-"+profile.Name+"-%@example.com")
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset seed profile %s: %w", profile.Name, err)
+	}
+	return result.RowsAffected()
+}
+
+func (s *Seeder) seedUser(ctx context.Context, profile Profile, index int, passwordHash string, now time.Time) (bool, error) {
+	id := seedID(profile, "user", index)
+	existing, err := s.users.FindUserByID(id)
+	if err != nil {
+		return false, fmt.Errorf("failed to find seed user: %w", err)
+	}
+	if existing != nil {
+		return false, nil
+	}
+
+	rng := seedRand(profile, "user", index)
+	user := authDomain.NewUser(
+		fmt.Sprintf("seed-%s-%04d@example.com", profile.Name, index),
+		fmt.Sprintf("%s_user%04d", profile.Name, index),
+		passwordHash,
+	)
+	user.ID = id
+	user.EmailVerified = true
+	user.CreatedAt = now.Add(-days(rng, 30, 365))
+	user.UpdatedAt = user.CreatedAt
+
+	if err := s.users.CreateUser(user); err != nil {
+		return false, fmt.Errorf("failed to create seed user: %w", err)
+	}
+	return true, nil
+}
+
+// seedFriendships は友達申請を作成し、ユーザーごとの承認済みの友達を返す
+func (s *Seeder) seedFriendships(ctx context.Context, profile Profile, users []uuid.UUID, now time.Time, count *Count) (map[int][]int, error) {
+	friends := make(map[int][]int)
+	seen := make(map[[2]int]bool)
+
+	for i := range users {
+		rng := seedRand(profile, "friends", i)
+		made := 0
+		for attempt := 0; made < profile.FriendsPerUser && attempt < profile.FriendsPerUser*3; attempt++ {
+			j := rng.Intn(len(users))
+			pair := [2]int{min(i, j), max(i, j)}
+			if i == j || seen[pair] {
+				continue
+			}
+			seen[pair] = true
+			made++
+
+			// 既存の場合も乱数を同じだけ消費し、再実行時に以降の計画が変わらないようにする
+			accepted := rng.Float64() < acceptedFriendshipRatio
+			createdAt := now.Add(-days(rng, 1, 180))
+			acceptedAt := createdAt.Add(hours(rng, 1, 48))
+			if accepted {
+				friends[i] = append(friends[i], j)
+				friends[j] = append(friends[j], i)
+			}
+
+			existing, err := s.friendships.GetFriendship(ctx, users[i], users[j])
+			if err != nil {
+				return nil, fmt.Errorf("failed to find seed friendship: %w", err)
+			}
+			if existing != nil {
+				count.add(false)
+				continue
+			}
+
+			friendship := socialDomain.NewFriendship(users[i], users[j])
+			friendship.ID = seedID(profile, "friendship", pair[0], pair[1])
+			friendship.CreatedAt = createdAt
+			friendship.UpdatedAt = createdAt
+			if accepted {
+				friendship.Status = socialDomain.FriendshipStatusAccepted
+				friendship.AcceptedAt = &acceptedAt
+				friendship.UpdatedAt = acceptedAt
+			}
+
+			if err := s.friendships.CreateFriendship(ctx, friendship); err != nil {
+				return nil, fmt.Errorf("failed to create seed friendship: %w", err)
+			}
+			count.add(true)
+		}
+	}
+
+	return friends, nil
+}
+
+func (s *Seeder) seedGroups(ctx context.Context, profile Profile, users []uuid.UUID, now time.Time, count *Count) error {
+	for g := 0; g < profile.Groups; g++ {
+		id := seedID(profile, "group", g)
+		existing, err := s.groups.GetGroupByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find seed group: %w", err)
+		}
+		if existing != nil {
+			count.add(false)
+			continue
+		}
+
+		rng := seedRand(profile, "group", g)
+		owner := rng.Intn(len(users))
+		groupType := groupDomain.GroupTypeSchedule
+		if rng.Float64() < projectGroupRatio {
+			groupType = groupDomain.GroupTypeProject
+		}
+
+		members := make([]int, 0, profile.MembersPerGroup)
+		for _, m := range rng.Perm(len(users)) {
+			if len(members) == profile.MembersPerGroup {
+				break
+			}
+			if m != owner {
+				members = append(members, m)
+			}
+		}
+
+		name := fmt.Sprintf("%s #%d", groupNames[g%len(groupNames)], g+1)
+		group := groupDomain.NewGroup(name, "シードデータのグループ", groupType, users[owner])
+		group.ID = id
+		group.MemberCount = 1 + len(members)
+		group.CreatedAt = now.Add(-days(rng, 7, 120))
+		group.UpdatedAt = group.CreatedAt
+
+		if err := s.groups.CreateGroup(ctx, group); err != nil {
+			return fmt.Errorf("failed to create seed group: %w", err)
+		}
+
+		for k, m := range members {
+			role := groupDomain.RoleMember
+			if k == 0 {
+				role = groupDomain.RoleAdmin
+			}
+			member := groupDomain.NewGroupMember(group.ID, users[m], role)
+			member.ID = seedID(profile, "group_member", g, m)
+			member.JoinedAt = group.CreatedAt.Add(hours(rng, 1, 72))
+			member.UpdatedAt = member.JoinedAt
+			if err := s.groups.AddMember(ctx, member); err != nil {
+				return fmt.Errorf("failed to add seed group member: %w", err)
+			}
+		}
+		count.add(true)
+	}
+	return nil
+}
+
+func (s *Seeder) seedTasks(ctx context.Context, profile Profile, index int, users []uuid.UUID, friends []int, now time.Time, count *Count) error {
+	rng := seedRand(profile, "tasks", index)
+	owner := users[index]
+
+	var tasks []*taskDomain.Task
+	for t := 0; t < profile.TasksPerUser; t++ {
+		task := newOneOffTask(rng, owner, now)
+		task.ID = seedID(profile, "task", index, t).String()
+		assign(rng, task, owner, users, friends)
+		tasks = append(tasks, task)
+	}
+
+	if rng.Float64() < recurringUserRatio {
+		routine := routines[rng.Intn(len(routines))]
+		offset := hours(rng, 0, 24*7)
+		for k := 0; k < recurringOccurrences; k++ {
+			due := now.Add(time.Duration(k-recurringOccurrences/2)*7*24*time.Hour + offset)
+			task := taskDomain.NewTask(routine.title, "毎週の繰り返しタスク", taskDomain.PriorityMedium, routine.category, owner.String())
+			task.ID = seedID(profile, "routine", index, k).String()
+			task.DueDate = &due
+			task.CreatedAt = earlier(due.Add(-7*24*time.Hour), now)
+			task.UpdatedAt = task.CreatedAt
+			if due.Before(now) {
+				completedAt := due.Add(-hours(rng, 1, 24))
+				task.Status = taskDomain.TaskStatusDone
+				task.CompletedAt = &completedAt
+				task.UpdatedAt = completedAt
+			}
+			task.UpdateIsOverdue()
+			tasks = append(tasks, task)
+		}
+	}
+
+	for _, task := range tasks {
+		_, err := s.tasks.GetTaskByID(ctx, task.ID)
+		if err == nil {
+			count.add(false)
+			continue
+		}
+		if !errors.Is(err, taskUsecase.ErrTaskNotFound) {
+			return fmt.Errorf("failed to find seed task: %w", err)
+		}
+
+		if err := s.tasks.CreateTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to create seed task: %w", err)
+		}
+		count.add(true)
+	}
+	return nil
+}
+
+// newOneOffTask は分布に従って単発タスクを作成する
+func newOneOffTask(rng *rand.Rand, owner uuid.UUID, now time.Time) *taskDomain.Task {
+	category := pick(rng, categoryWeights)
+	titles := taskTitles[category]
+	task := taskDomain.NewTask(titles[rng.Intn(len(titles))], "", pick(rng, priorityWeights), category, owner.String())
+
+	var due time.Time
+	r := rng.Float64()
+	switch {
+	case r < overdueTaskRatio:
+		due = now.Add(-days(rng, 1, 14))
+		task.CreatedAt = due.Add(-days(rng, 3, 21))
+		task.Status = taskDomain.TaskStatusTodo
+		if rng.Intn(2) == 0 {
+			task.Status = taskDomain.TaskStatusInProgress
+		}
+	case r < overdueTaskRatio+doneTaskRatio:
+		due = now.Add(-days(rng, 0, 30) + days(rng, 0, 7))
+		// 期限前に完了したタスクも含めるため、作成日時は期限と現在のうち早い方を基準にする
+		task.CreatedAt = earlier(due, now).Add(-days(rng, 3, 21))
+		completedAt := task.CreatedAt.Add(time.Duration(rng.Int63n(int64(now.Sub(task.CreatedAt)))))
+		task.Status = taskDomain.TaskStatusDone
+		task.CompletedAt = &completedAt
+		if rng.Float64() < estimatedTaskRatio {
+			actual := estimateMinutes[rng.Intn(len(estimateMinutes))]
+			task.ActualMinutes = &actual
+		}
+	case r < overdueTaskRatio+doneTaskRatio+inProgressTaskRatio:
+		due = now.Add(days(rng, 1, 14))
+		task.CreatedAt = now.Add(-days(rng, 1, 10))
+		task.Status = taskDomain.TaskStatusInProgress
+	default:
+		due = now.Add(days(rng, 1, 30))
+		task.CreatedAt = now.Add(-days(rng, 0, 10))
+		task.Status = taskDomain.TaskStatusTodo
+	}
+
+	if r < overdueTaskRatio || rng.Float64() >= noDueDateRatio {
+		task.DueDate = &due
+	}
+	if rng.Float64() < estimatedTaskRatio {
+		estimate := estimateMinutes[rng.Intn(len(estimateMinutes))]
+		task.EstimateMinutes = &estimate
+	}
+
+	task.UpdatedAt = task.CreatedAt
+	if task.CompletedAt != nil {
+		task.UpdatedAt = *task.CompletedAt
+	}
+	task.UpdateIsOverdue()
+	return task
+}
+
+// assign は担当者を設定する（友達との共有タスク・自分のタスク・担当者なし）
+func assign(rng *rand.Rand, task *taskDomain.Task, owner uuid.UUID, users []uuid.UUID, friends []int) {
+	switch r := rng.Float64(); {
+	case len(friends) > 0 && r < sharedTaskRatio:
+		task.AddAssignee(users[friends[rng.Intn(len(friends))]].String())
+	case r < sharedTaskRatio+selfAssignedRatio:
+		task.AddAssignee(owner.String())
+	default:
+		return
+	}
+
+	for _, assignee := range task.Assignees {
+		assignee.AssignedAt = task.CreatedAt
+		assignee.CompletedAt = task.CompletedAt
+	}
+	task.UpdatedAt = task.CreatedAt
+	if task.CompletedAt != nil {
+		task.UpdatedAt = *task.CompletedAt
+	}
+}
+
+// seedID はプロファイル名・種類・連番から決定的なIDを生成する
+func seedID(profile Profile, kind string, keys ...int) uuid.UUID {
+	name := profile.Name + ":" + kind
+	for _, key := range keys {
+		name += ":" + strconv.Itoa(key)
+	}
+	return uuid.NewSHA1(namespace, []byte(name))
+}
+
+// seedRand はseedIDと同じキーから決定的な乱数生成器を作成する
+func seedRand(profile Profile, kind string, keys ...int) *rand.Rand {
+	id := seedID(profile, kind, keys...)
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(id[:8]))))
+}
+
+func pick[T any](rng *rand.Rand, choices []weighted[T]) T {
+	total := 0
+	for _, c := range choices {
+		total += c.weight
+	}
+	n := rng.Intn(total)
+	for _, c := range choices {
+		if n < c.weight {
+			return c.value
+		}
+		n -= c.weight
+	}
+	return choices[len(choices)-1].value
+}
+
+// days はfrom〜to日の範囲のランダムな期間を返す（分単位）
+func days(rng *rand.Rand, from, to int) time.Duration {
+	return hours(rng, from*24, to*24)
+}
+
+// hours はfrom〜to時間の範囲のランダムな期間を返す（分単位）
+func hours(rng *rand.Rand, from, to int) time.Duration {
+	minutes := from*60 + rng.Intn((to-from)*60+1)
+	return time.Duration(minutes) * time.Minute
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,201 @@
+package seed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUsecase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	users       map[uuid.UUID]*authDomain.User
+	friendships map[[2]uuid.UUID]*socialDomain.Friendship
+	groups      map[uuid.UUID]*groupDomain.Group
+	members     map[uuid.UUID][]*groupDomain.GroupMember
+	tasks       map[string]*taskDomain.Task
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		users:       make(map[uuid.UUID]*authDomain.User),
+		friendships: make(map[[2]uuid.UUID]*socialDomain.Friendship),
+		groups:      make(map[uuid.UUID]*groupDomain.Group),
+		members:     make(map[uuid.UUID][]*groupDomain.GroupMember),
+		tasks:       make(map[string]*taskDomain.Task),
+	}
+}
+
+func (f *fakeStore) FindUserByID(id uuid.UUID) (*authDomain.User, error) {
+	return f.users[id], nil
+}
+
+func (f *fakeStore) CreateUser(user *authDomain.User) error {
+	f.users[user.ID] = user
+	return nil
+}
+
+func (f *fakeStore) GetFriendship(ctx context.Context, requesterID, addresseeID uuid.UUID) (*socialDomain.Friendship, error) {
+	if friendship, ok := f.friendships[[2]uuid.UUID{requesterID, addresseeID}]; ok {
+		return friendship, nil
+	}
+	return f.friendships[[2]uuid.UUID{addresseeID, requesterID}], nil
+}
+
+func (f *fakeStore) CreateFriendship(ctx context.Context, friendship *socialDomain.Friendship) error {
+	f.friendships[[2]uuid.UUID{friendship.RequesterID, friendship.AddresseeID}] = friendship
+	return nil
+}
+
+func (f *fakeStore) GetGroupByID(ctx context.Context, id uuid.UUID) (*groupDomain.Group, error) {
+	return f.groups[id], nil
+}
+
+func (f *fakeStore) CreateGroup(ctx context.Context, group *groupDomain.Group) error {
+	f.groups[group.ID] = group
+	f.members[group.ID] = append(f.members[group.ID], groupDomain.NewGroupMember(group.ID, group.OwnerID, groupDomain.RoleOwner))
+	return nil
+}
+
+func (f *fakeStore) AddMember(ctx context.Context, member *groupDomain.GroupMember) error {
+	f.members[member.GroupID] = append(f.members[member.GroupID], member)
+	return nil
+}
+
+func (f *fakeStore) GetTaskByID(ctx context.Context, id string) (*taskDomain.Task, error) {
+	if task, ok := f.tasks[id]; ok {
+		return task, nil
+	}
+	return nil, taskUsecase.ErrTaskNotFound
+}
+
+func (f *fakeStore) CreateTask(ctx context.Context, task *taskDomain.Task) error {
+	f.tasks[task.ID] = task
+	return nil
+}
+
+var testNow = time.Date(2026, 6, 15, 9, 30, 0, 0, time.UTC)
+
+func newTestSeeder(store *fakeStore) *Seeder {
+	log := logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+	seeder := NewSeeder(store, store, store, store, log)
+	seeder.now = func() time.Time { return testNow }
+	return seeder
+}
+
+func TestSeeder_Run(t *testing.T) {
+	profile, err := LookupProfile("dev")
+	require.NoError(t, err)
+
+	store := newFakeStore()
+	result, err := newTestSeeder(store).Run(context.Background(), profile, "password123")
+	require.NoError(t, err)
+
+	assert.Equal(t, Count{Created: profile.Users}, result.Users)
+	assert.Equal(t, Count{Created: profile.Groups}, result.Groups)
+	assert.Equal(t, len(store.friendships), result.Friendships.Created)
+	assert.Equal(t, len(store.tasks), result.Tasks.Created)
+	assert.GreaterOrEqual(t, result.Tasks.Created, profile.Users*profile.TasksPerUser)
+
+	// 友達申請は自分宛てや同じ組み合わせの重複を含まない
+	pairs := make(map[[2]uuid.UUID]bool)
+	for _, friendship := range store.friendships {
+		assert.NotEqual(t, friendship.RequesterID, friendship.AddresseeID)
+		pair := [2]uuid.UUID{friendship.RequesterID, friendship.AddresseeID}
+		reverse := [2]uuid.UUID{friendship.AddresseeID, friendship.RequesterID}
+		assert.False(t, pairs[reverse], "friendship must be unique per pair")
+		pairs[pair] = true
+	}
+
+	// グループのメンバー数はオーナーを含む
+	for id, group := range store.groups {
+		assert.Equal(t, group.MemberCount, len(store.members[id]))
+	}
+
+	// 期限切れ・完了・繰り返し・複数カテゴリのタスクを含む
+	var overdue, done, routine int
+	categories := make(map[taskDomain.Category]bool)
+	for _, task := range store.tasks {
+		categories[task.Category] = true
+		if task.Status == taskDomain.TaskStatusDone {
+			done++
+			require.NotNil(t, task.CompletedAt)
+			assert.False(t, task.CompletedAt.After(testNow), "completed_at must not be in the future")
+			assert.False(t, task.CompletedAt.Before(task.CreatedAt), "completed_at must be after created_at")
+		} else {
+			assert.Nil(t, task.CompletedAt)
+		}
+		if task.DueDate != nil && task.DueDate.Before(testNow) && task.Status != taskDomain.TaskStatusDone {
+			overdue++
+		}
+		if task.Description == "毎週の繰り返しタスク" {
+			routine++
+		}
+		assert.False(t, task.CreatedAt.After(testNow), "created_at must not be in the future")
+	}
+	assert.Positive(t, overdue)
+	assert.Positive(t, done)
+	assert.Positive(t, routine)
+	assert.Len(t, categories, len(categoryWeights))
+}
+
+func TestSeeder_RunIsIdempotent(t *testing.T) {
+	profile, err := LookupProfile("demo")
+	require.NoError(t, err)
+
+	store := newFakeStore()
+	first, err := newTestSeeder(store).Run(context.Background(), profile, "password123")
+	require.NoError(t, err)
+
+	second, err := newTestSeeder(store).Run(context.Background(), profile, "password123")
+	require.NoError(t, err)
+
+	assert.Equal(t, Count{Skipped: first.Users.Created}, second.Users)
+	assert.Equal(t, Count{Skipped: first.Friendships.Created}, second.Friendships)
+	assert.Equal(t, Count{Skipped: first.Groups.Created}, second.Groups)
+	assert.Equal(t, Count{Skipped: first.Tasks.Created}, second.Tasks)
+}
+
+func TestSeeder_RunIsDeterministic(t *testing.T) {
+	profile, err := LookupProfile("demo")
+	require.NoError(t, err)
+
+	a, b := newFakeStore(), newFakeStore()
+	_, err = newTestSeeder(a).Run(context.Background(), profile, "password123")
+	require.NoError(t, err)
+	_, err = newTestSeeder(b).Run(context.Background(), profile, "password123")
+	require.NoError(t, err)
+
+	require.Equal(t, len(a.tasks), len(b.tasks))
+	for id, task := range a.tasks {
+		other, ok := b.tasks[id]
+		require.True(t, ok)
+		assert.Equal(t, task.Title, other.Title)
+		assert.Equal(t, task.Status, other.Status)
+		assert.Equal(t, task.DueDate, other.DueDate)
+	}
+
+	// プロファイルが異なればIDも異なる
+	other := profile
+	other.Name = "other"
+	assert.NotEqual(t, seedID(profile, "user", 0), seedID(other, "user", 0))
+}
+
+func TestLookupProfile(t *testing.T) {
+	_, err := LookupProfile("unknown")
+	assert.Error(t, err)
+
+	for _, name := range ProfileNames() {
+		profile, err := LookupProfile(name)
+		require.NoError(t, err)
+		assert.NoError(t, profile.Validate())
+	}
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	adminDomain "github.com/hryt430/Yotei+/internal/modules/admin/domain"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+)
+
+// accountMergeDirectory はアカウントの統合の対象を認証モジュールのユーザーから取得・無効化する
+type accountMergeDirectory struct {
+	userService *userService.UserService
+}
+
+func (d *accountMergeDirectory) FindAccount(ctx context.Context, userID uuid.UUID) (*adminDomain.MergeAccount, error) {
+	user, err := d.userService.FindUserByID(userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return &adminDomain.MergeAccount{
+		ID:       user.ID.String(),
+		Username: user.Username,
+		Email:    user.Email,
+		Active:   user.IsActive(),
+	}, nil
+}
+
+// DeactivateMergedAccount は統合元のアカウントを無効化する（監査ログには account_deactivated として記録する）
+func (d *accountMergeDirectory) DeactivateMergedAccount(ctx context.Context, secondaryID uuid.UUID) error {
+	_, err := d.userService.SetActive(ctx, secondaryID, false)
+	return err
+}
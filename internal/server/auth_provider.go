@@ -0,0 +1,170 @@
+package server
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/token"
+
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	authGateway "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/gateway"
+	apiKeyService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/apikey"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
+	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
+	deviceService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/device"
+	ssoService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso"
+	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+)
+
+// authProvider は認証・ユーザー・トークン・監査ログ・不審なログインの検知・SSO・APIキーを組み立てる
+var authProvider = provider{
+	name:     "auth",
+	requires: []string{"storage", "notification"},
+	provide: func(w *wiring) error {
+		cfg, log, repos := w.cfg, w.log, w.repos
+
+		// JWTマネージャーの初期化
+		accessTokenDuration, err := time.ParseDuration(cfg.GetJWTAccessTokenDuration())
+		if err != nil {
+			return err
+		}
+
+		refreshTokenDuration, err := time.ParseDuration(cfg.GetJWTRefreshTokenDuration())
+		if err != nil {
+			return err
+		}
+
+		jwtManager := token.NewJWTManager(cfg.JWT.SecretKey, cfg.JWT.Issuer)
+
+		// シークレットストアで署名鍵がローテーションされた場合は、発行済みのアクセストークンの有効期限まで以前の鍵でも検証する
+		if secrets := cfg.SecretStore(); secrets != nil {
+			secrets.OnChange(config.SecretJWTKey, func(value string) {
+				jwtManager.RotateSecretKey(value, accessTokenDuration)
+				log.Info("JWT signing key rotated", logger.Any("source", secrets.Source()))
+			})
+		}
+
+		// 認証イベントの監査ログ
+		auditSvc := auditService.NewAuditService(repos.securityEventRepository, securityAuditRetention(cfg, log), log)
+
+		userSvc := userService.NewUserService(repos.userRepository)
+		userSvc.SecurityEvents = auditSvc
+		// ユーザー名の予約語・変更の間隔と、変更前のユーザー名の保持
+		userSvc.UsernameHistory = repos.usernameHistoryRepository
+		userSvc.UsernamePolicy = usernamePolicy(cfg, log)
+
+		// プロフィール・役割の変更、無効化の際に破棄するキャッシュ
+		var caches userInfoCaches
+		if cache, ok := w.userValidator.(userService.UserInfoCache); ok {
+			caches = append(caches, cache)
+		}
+		// 認証ミドルウェアがリクエストごとに参照するユーザー
+		if ttl := authUserCacheTTL(cfg, log); ttl > 0 {
+			w.deps.ProfileCache = userService.NewProfileCache(repos.userRepository, ttl)
+			caches = append(caches, w.deps.ProfileCache)
+		}
+		if len(caches) > 0 {
+			userSvc.UserInfoCache = caches
+		}
+
+		tokenSvc := tokenService.NewTokenService(repos.tokenRepository, jwtManager, accessTokenDuration, refreshTokenDuration)
+
+		// AuthRepository の実装
+		authRepository := &AuthRepositoryImpl{
+			UserService:    *userSvc,
+			TokenService:   *tokenSvc,
+			SecurityEvents: auditSvc,
+		}
+		authSvc := authService.NewAuthService(authRepository, *userSvc, *tokenSvc)
+
+		// 不審なログインの検知（通知はアプリ内通知とメールで送る）
+		deviceSvc := deviceService.NewDeviceService(
+			repos.loginDeviceRepository,
+			authGateway.NewGeoIPResolver(cfg.External.GeoIPURL),
+			&loginAlertNotifier{
+				notificationUseCase: w.deps.NotificationUseCase,
+				email:               authGateway.NewLoginAlertEmailGateway(cfg, w.smtpBreaker, log),
+				logger:              log,
+			},
+			log,
+		)
+		deviceSvc.TrustSecret = []byte(cfg.Security.SessionSecret)
+		authRepository.Devices = deviceSvc
+
+		// 企業SSO（OIDC、ドメインごとの強制・JITでのユーザー作成）
+		ssoSvc := ssoService.NewSSOService(
+			repos.ssoRepository,
+			authGateway.NewOIDCProvider(),
+			userSvc,
+			authRepository,
+			cfg.Security.SSORedirectURL,
+			[]byte(cfg.Security.SessionSecret),
+			log,
+		)
+		ssoSvc.SecurityEvents = auditSvc
+		authRepository.SSO = ssoSvc
+
+		w.userService = userSvc
+		w.tokenService = tokenSvc
+		w.authService = authSvc
+		w.authRepository = authRepository
+		w.deps.AuditService = auditSvc
+		w.deps.DeviceService = deviceSvc
+		w.deps.SSOService = ssoSvc
+		// 外部システム向けのAPIキー（SCIMプロビジョニングで使用）
+		w.deps.APIKeyService = apiKeyService.NewAPIKeyService(repos.apiKeyRepository, log)
+		return nil
+	},
+}
+
+// userInfoCaches はユーザー情報の複数のキャッシュをまとめて破棄する
+type userInfoCaches []userService.UserInfoCache
+
+func (c userInfoCaches) Invalidate(userID string) {
+	for _, cache := range c {
+		cache.Invalidate(userID)
+	}
+}
+
+// authUserCacheTTL は設定から認証済みのユーザーをキャッシュする期間を読み込む（0の場合はトークンのクレームだけを使う）
+func authUserCacheTTL(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Security.AuthUserCacheTTL); err == nil && d >= 0 {
+		return d
+	} else if cfg.Security.AuthUserCacheTTL != "" {
+		log.Warn("Invalid AUTH_USER_CACHE_TTL, using default", logger.Any("value", cfg.Security.AuthUserCacheTTL))
+	}
+	return userService.DefaultProfileCacheTTL
+}
+
+// usernamePolicy は設定からユーザー名の予約語と変更の制限を読み込む
+// 不正な期間は既定値で置き換える
+func usernamePolicy(cfg *config.Config, log logger.Logger) *authDomain.UsernamePolicy {
+	cooldown := authDomain.DefaultUsernameChangeCooldown
+	if d, err := time.ParseDuration(cfg.Security.UsernameChangeCooldown); err == nil && d >= 0 {
+		cooldown = d
+	} else if cfg.Security.UsernameChangeCooldown != "" {
+		log.Warn("Invalid USERNAME_CHANGE_COOLDOWN, using default", logger.Any("value", cfg.Security.UsernameChangeCooldown))
+	}
+
+	hold := authDomain.DefaultUsernameHoldPeriod
+	if d, err := time.ParseDuration(cfg.Security.UsernameHoldPeriod); err == nil && d >= 0 {
+		hold = d
+	} else if cfg.Security.UsernameHoldPeriod != "" {
+		log.Warn("Invalid USERNAME_HOLD_PERIOD, using default", logger.Any("value", cfg.Security.UsernameHoldPeriod))
+	}
+
+	return authDomain.NewUsernamePolicy(strings.Split(cfg.Security.ReservedUsernames, ","), cooldown, hold)
+}
+
+// securityAuditRetention は設定から監査ログの保存期間を読み込む
+func securityAuditRetention(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Security.AuditRetention); err == nil && d > 0 {
+		return d
+	} else if cfg.Security.AuditRetention != "" {
+		log.Warn("Invalid SECURITY_AUDIT_RETENTION, using default", logger.Any("value", cfg.Security.AuditRetention))
+	}
+	return auditService.DefaultRetention
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+
+	billingUseCase "github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+	quotaDomain "github.com/hryt430/Yotei+/internal/modules/quota/domain"
+)
+
+// billingPlanResolver は課金モジュールの契約プランを使用量の上限と機能の制限に橋渡しする
+type billingPlanResolver struct {
+	billing *billingUseCase.BillingService
+}
+
+// PlanLimits はユーザーが利用できるプランの上限を返す
+func (r *billingPlanResolver) PlanLimits(ctx context.Context, userID string) (quotaDomain.Limits, error) {
+	plan, err := r.billing.EffectivePlan(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return quotaDomain.Limits(plan.Limits), nil
+}
+
+// HasEntitlement はユーザーが利用できるプランにfeatureが含まれるか
+func (r *billingPlanResolver) HasEntitlement(ctx context.Context, userID, feature string) (bool, error) {
+	plan, err := r.billing.EffectivePlan(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return plan.HasEntitlement(feature), nil
+}
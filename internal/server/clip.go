@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/apiversion"
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	authMiddleware "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/middleware"
+	authController "github.com/hryt430/Yotei+/internal/modules/auth/interface/controller"
+	taskController "github.com/hryt430/Yotei+/internal/modules/task/interface/controller"
+)
+
+// clipRateLimitWindow はブラウザの拡張機能向けAPIのレート制限（CLIP_RATE_LIMIT）の単位時間
+const clipRateLimitWindow = time.Minute
+
+// clipPath はブラウザの拡張機能向けAPIのパス（CORSのプロファイルを適用する）
+const clipPath = "/clip"
+
+// clipCORSProfile はブラウザの拡張機能向けAPIのCORSのプロファイル
+// 拡張機能のオリジン（CORS_CLIP_ALLOWED_ORIGINS）だけを許可し、Cookieは送信させない
+func clipCORSProfile(cfg *config.Config) middleware.CORSProfile {
+	return middleware.CORSProfile{
+		PathPrefix:     apiversion.V1.Prefix() + clipPath,
+		AllowedOrigins: cfg.GetClipAllowedOrigins(),
+		AllowedMethods: []string{http.MethodPost, http.MethodOptions},
+		AllowedHeaders: []string{"Content-Type", "Authorization", middleware.RequestIDHeader},
+	}
+}
+
+// setupClipRoutes はブラウザの拡張機能向けのページのクリップのAPIをセットアップする
+// ログイン中のセッションと引き換えにclipスコープのトークンを発行し、/clip はトークンで認証してトークンごとにリクエスト数を制限する
+func setupClipRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.APIKeyService == nil || deps.ClipService == nil {
+		deps.Logger.Warn("API key or clip service not available, skipping clip routes")
+		return
+	}
+
+	apiKeyCtrl := authController.NewAPIKeyController(deps.APIKeyService, deps.Logger)
+	clipCtrl := taskController.NewClipController(deps.ClipService)
+	limiter := middleware.NewWindowRateLimiter(deps.Config.Security.ClipRateLimit, clipRateLimitWindow)
+
+	clipRoutes := router.Group(clipPath)
+	{
+		clipRoutes.POST("/token", newAuthMiddleware(deps, nil).AuthRequired(), apiKeyCtrl.CreateClipToken)
+		clipRoutes.POST("",
+			authMiddleware.APIKeyRequired(deps.APIKeyService, authDomain.APIKeyScopeClip, taskController.AbortClipError),
+			apiKeyRateLimit(limiter, clipRateLimitWindow, taskController.AbortClipError),
+			apiKeyActor(deps, taskController.AbortClipError),
+			clipCtrl.Clip,
+		)
+	}
+}
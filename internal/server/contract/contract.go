@@ -0,0 +1,218 @@
+// Package contract はswag initで生成したSwagger仕様と実際のJSONレスポンスを照合する契約テスト用のヘルパーです
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// specURL はコンパイラに登録する仕様のURL（$refの解決にのみ使用）
+const specURL = "mem://swagger.json"
+
+// ginParamPattern はginのパスパラメータ（:id・*path）
+var ginParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Spec はレスポンス検証用に読み込んだSwagger 2.0仕様
+//
+// アノテーションとのズレを検出するため、仕様のスキーマを次のように厳格化して検証します
+//   - propertiesを持つオブジェクトは、additionalPropertiesの指定がなければ未定義のフィールドを許可しない
+//   - requiredでないフィールドはnullを許可する（Swagger 2.0にはnullableがないため）
+type Spec struct {
+	basePath string
+	paths    map[string]map[string]operation
+
+	compiler *jsonschema.Compiler
+	mu       sync.Mutex
+	schemas  map[string]*jsonschema.Schema
+}
+
+type operation struct {
+	responses map[string]response
+}
+
+type response struct {
+	hasSchema bool
+	pointer   string
+}
+
+// Load はswag.ReadDocなどで取得したSwagger仕様（JSON）を読み込む
+func Load(doc string) (*Spec, error) {
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(doc), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger spec: %w", err)
+	}
+
+	spec := &Spec{
+		paths:   make(map[string]map[string]operation),
+		schemas: make(map[string]*jsonschema.Schema),
+	}
+	spec.basePath, _ = raw["basePath"].(string)
+
+	if definitions, ok := raw["definitions"].(map[string]any); ok {
+		for _, def := range definitions {
+			strictify(def)
+		}
+	}
+
+	paths, _ := raw["paths"].(map[string]any)
+	for path, item := range paths {
+		methods, _ := item.(map[string]any)
+		spec.paths[path] = make(map[string]operation)
+		for method, op := range methods {
+			opMap, _ := op.(map[string]any)
+			responses, _ := opMap["responses"].(map[string]any)
+			parsed := operation{responses: make(map[string]response)}
+			for status, resp := range responses {
+				respMap, _ := resp.(map[string]any)
+				schema, hasSchema := respMap["schema"]
+				if hasSchema {
+					strictify(schema)
+				}
+				parsed.responses[status] = response{
+					hasSchema: hasSchema,
+					pointer:   "#/paths/" + escapePointer(path) + "/" + method + "/responses/" + status + "/schema",
+				}
+			}
+			spec.paths[path][strings.ToUpper(method)] = parsed
+		}
+	}
+
+	strict, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	spec.compiler = jsonschema.NewCompiler()
+	spec.compiler.Draft = jsonschema.Draft4
+	if err := spec.compiler.AddResource(specURL, bytes.NewReader(strict)); err != nil {
+		return nil, fmt.Errorf("failed to load swagger spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// SpecPath はginのルート（例: /api/v1/tasks/:id）を仕様のパス（例: /tasks/{id}）に変換する
+// ベースパス外のルートの場合はokがfalseになる
+func (s *Spec) SpecPath(route string) (string, bool) {
+	rest, ok := strings.CutPrefix(route, s.basePath)
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return "", false
+	}
+	if rest == "" {
+		rest = "/"
+	}
+	return ginParamPattern.ReplaceAllString(rest, "{$1}"), true
+}
+
+// HasOperation はginのルートとメソッドの組み合わせが仕様に記載されているかを判定する
+func (s *Spec) HasOperation(method, route string) bool {
+	path, ok := s.SpecPath(route)
+	if !ok {
+		return false
+	}
+	_, ok = s.paths[path][method]
+	return ok
+}
+
+// ValidateResponse はレスポンスのステータスとボディが仕様に一致するかを検証する
+func (s *Spec) ValidateResponse(method, route string, status int, body []byte) error {
+	path, ok := s.SpecPath(route)
+	if !ok {
+		return fmt.Errorf("%s is outside of base path %s", route, s.basePath)
+	}
+	op, ok := s.paths[path][method]
+	if !ok {
+		return fmt.Errorf("%s %s is not documented", method, path)
+	}
+	resp, ok := op.responses[strconv.Itoa(status)]
+	if !ok {
+		return fmt.Errorf("%s %s: status %d is not documented", method, path, status)
+	}
+
+	if !resp.hasSchema {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("%s %s: status %d is documented without body but got %s", method, path, status, body)
+		}
+		return nil
+	}
+
+	schema, err := s.schema(resp.pointer)
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Errorf("%s %s: response is not valid JSON: %w", method, path, err)
+	}
+	if err := schema.Validate(v); err != nil {
+		return fmt.Errorf("%s %s: status %d: %#v", method, path, status, err)
+	}
+	return nil
+}
+
+// schema はJSONポインタで指定したスキーマをコンパイルする（結果はキャッシュする）
+func (s *Spec) schema(pointer string) (*jsonschema.Schema, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if schema, ok := s.schemas[pointer]; ok {
+		return schema, nil
+	}
+	schema, err := s.compiler.Compile(specURL + pointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %s: %w", pointer, err)
+	}
+	s.schemas[pointer] = schema
+	return schema, nil
+}
+
+// strictify はスキーマを厳格化する（Specのコメントを参照）
+func strictify(node any) {
+	schema, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		required := make(map[string]bool)
+		if list, ok := schema["required"].([]any); ok {
+			for _, name := range list {
+				if s, ok := name.(string); ok {
+					required[s] = true
+				}
+			}
+		}
+		for name, prop := range properties {
+			strictify(prop)
+			if !required[name] {
+				properties[name] = map[string]any{
+					"anyOf": []any{prop, map[string]any{"type": "null"}},
+				}
+			}
+		}
+		if _, ok := schema["additionalProperties"]; !ok {
+			schema["additionalProperties"] = false
+		}
+	}
+
+	strictify(schema["items"])
+	strictify(schema["additionalProperties"])
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schema[key].([]any); ok {
+			for _, sub := range list {
+				strictify(sub)
+			}
+		}
+	}
+}
+
+// escapePointer はJSONポインタのトークンをエスケープする
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
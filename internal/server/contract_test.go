@@ -0,0 +1,352 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggo/swag"
+
+	"github.com/hryt430/Yotei+/config"
+	_ "github.com/hryt430/Yotei+/docs"
+	_ "github.com/hryt430/Yotei+/docs/v2"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/internal/server/contract"
+	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/token"
+)
+
+// 契約テストの対象とするハンドラー（HandlerNameに含まれる型名）
+// 対象のハンドラーを持つルートはすべてテストケースが必要
+var contractHandlers = []string{"(*TaskController).", "(*TaskV2Controller)."}
+
+const (
+	contractUserID  = "11111111-1111-1111-1111-111111111111"
+	contractOtherID = "22222222-2222-2222-2222-222222222222"
+	contractTaskID  = "33333333-3333-3333-3333-333333333333"
+	contractMissing = "99999999-9999-9999-9999-999999999999"
+)
+
+type contractCase struct {
+	name   string
+	method string
+	// ginのルート（仕様との照合とカバレッジ確認に使用）
+	route string
+	// 実際のリクエストパス（空の場合はrouteと同じ）
+	path   string
+	body   string
+	noAuth bool
+	status int
+}
+
+func TestContract_TaskEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	specs := map[string]*contract.Spec{}
+	for prefix, instance := range map[string]string{"/api/v1": swag.Name, "/api/v2": "v2"} {
+		doc, err := swag.ReadDoc(instance)
+		require.NoError(t, err)
+		spec, err := contract.Load(doc)
+		require.NoError(t, err)
+		specs[prefix] = spec
+	}
+
+	var cases []contractCase
+	for _, prefix := range []string{"/api/v1", "/api/v2"} {
+		deleted := http.StatusOK
+		if prefix == "/api/v2" {
+			deleted = http.StatusNoContent
+		}
+		cases = append(cases,
+			contractCase{name: "create", method: "POST", route: "/tasks", body: `{"title":"契約テスト","priority":"HIGH","due_date":"2030-01-01T09:00:00Z"}`, status: http.StatusCreated},
+			contractCase{name: "create without title", method: "POST", route: "/tasks", body: `{"description":"x"}`, status: http.StatusBadRequest},
+			contractCase{name: "create with invalid status", method: "POST", route: "/tasks", body: `{"title":"x","status":"UNKNOWN"}`, status: http.StatusBadRequest},
+			contractCase{name: "create unauthenticated", method: "POST", route: "/tasks", body: `{"title":"x"}`, noAuth: true, status: http.StatusUnauthorized},
+			contractCase{name: "quick add", method: "POST", route: "/tasks/quick-add", body: `{"text":"レポート提出 明日 15時 #work !high","timezone":"Asia/Tokyo"}`, status: http.StatusCreated},
+			contractCase{name: "quick add preview", method: "POST", route: "/tasks/quick-add", body: `{"text":"レポート提出 明日 15時 #work !high","preview":true}`, status: http.StatusOK},
+			contractCase{name: "get", method: "GET", route: "/tasks/:id", path: "/tasks/" + contractTaskID, status: http.StatusOK},
+			contractCase{name: "get missing", method: "GET", route: "/tasks/:id", path: "/tasks/" + contractMissing, status: http.StatusNotFound},
+			contractCase{name: "update", method: "PUT", route: "/tasks/:id", path: "/tasks/" + contractTaskID, body: `{"title":"更新後","status":"IN_PROGRESS"}`, status: http.StatusOK},
+			contractCase{name: "delete", method: "DELETE", route: "/tasks/:id", path: "/tasks/" + contractTaskID, status: deleted},
+			contractCase{name: "list", method: "GET", route: "/tasks", path: "/tasks?status=TODO&page=1&page_size=10", status: http.StatusOK},
+			contractCase{name: "search", method: "GET", route: "/tasks/search", path: "/tasks/search?q=" + "contract", status: http.StatusOK},
+			contractCase{name: "search without query", method: "GET", route: "/tasks/search", status: http.StatusBadRequest},
+			contractCase{name: "assign", method: "PUT", route: "/tasks/:id/assign", path: "/tasks/" + contractTaskID + "/assign", body: `{"assignee_ids":["` + contractOtherID + `"]}`, status: http.StatusOK},
+			contractCase{name: "assign without assignee", method: "PUT", route: "/tasks/:id/assign", path: "/tasks/" + contractTaskID + "/assign", body: `{}`, status: http.StatusBadRequest},
+			contractCase{name: "unassign", method: "DELETE", route: "/tasks/:id/assignees/:user_id", path: "/tasks/" + contractTaskID + "/assignees/" + contractUserID, status: http.StatusOK},
+			contractCase{name: "complete my assignment", method: "PUT", route: "/tasks/:id/assignees/me/completion", path: "/tasks/" + contractTaskID + "/assignees/me/completion", body: `{"completed":true}`, status: http.StatusOK},
+			contractCase{name: "change status", method: "PUT", route: "/tasks/:id/status", path: "/tasks/" + contractTaskID + "/status", body: `{"status":"DONE"}`, status: http.StatusOK},
+			contractCase{name: "update schedule", method: "PUT", route: "/tasks/:id/schedule", path: "/tasks/" + contractTaskID + "/schedule", body: `{"start_date":"2030-01-01T09:00:00Z","due_date":"2030-01-05T18:00:00Z"}`, status: http.StatusOK},
+			contractCase{name: "update schedule with start after due", method: "PUT", route: "/tasks/:id/schedule", path: "/tasks/" + contractTaskID + "/schedule", body: `{"start_date":"2030-01-06T09:00:00Z","due_date":"2030-01-05T18:00:00Z"}`, status: http.StatusBadRequest},
+			contractCase{name: "update estimate", method: "PUT", route: "/tasks/:id/estimate", path: "/tasks/" + contractTaskID + "/estimate", body: `{"estimate_minutes":90,"estimate_points":3}`, status: http.StatusOK},
+			contractCase{name: "overdue", method: "GET", route: "/tasks/overdue", status: http.StatusOK},
+			contractCase{name: "my tasks", method: "GET", route: "/tasks/my", status: http.StatusOK},
+			contractCase{name: "user tasks", method: "GET", route: "/tasks/user/:user_id", path: "/tasks/user/" + contractUserID, status: http.StatusOK},
+		)
+		if prefix == "/api/v1" {
+			cases = append(cases,
+				contractCase{name: "create duplicate", method: "POST", route: "/tasks", body: `{"title":"Contract task!"}`, status: http.StatusConflict},
+				contractCase{name: "create duplicate with force", method: "POST", route: "/tasks", body: `{"title":"Contract task!","force":true}`, status: http.StatusCreated},
+				contractCase{name: "check duplicate", method: "POST", route: "/tasks/check-duplicate", body: `{"title":"contract task","days":7}`, status: http.StatusOK},
+				contractCase{name: "check duplicate without title", method: "POST", route: "/tasks/check-duplicate", body: `{}`, status: http.StatusBadRequest},
+				contractCase{name: "classify", method: "POST", route: "/tasks/classify", body: `{"title":"至急 取引先への見積を送る"}`, status: http.StatusOK},
+				contractCase{name: "classify without text", method: "POST", route: "/tasks/classify", body: `{}`, status: http.StatusBadRequest},
+			)
+		}
+		if prefix == "/api/v2" {
+			cases = append(cases,
+				contractCase{name: "list with invalid query", method: "GET", route: "/tasks", path: "/tasks?page_size=1000&status=UNKNOWN", status: http.StatusBadRequest},
+			)
+		}
+		for i := len(cases) - 1; i >= 0 && !strings.HasPrefix(cases[i].route, "/api/"); i-- {
+			if cases[i].path == "" {
+				cases[i].path = cases[i].route
+			}
+			cases[i].route = prefix + cases[i].route
+			cases[i].path = prefix + cases[i].path
+		}
+	}
+
+	covered := make(map[string]bool)
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.route+" "+tc.name, func(t *testing.T) {
+			router, accessToken := newContractRouter(t)
+
+			var body *strings.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			} else {
+				body = strings.NewReader("")
+			}
+			req := httptest.NewRequest(tc.method, tc.path, body)
+			req.Header.Set("Content-Type", "application/json")
+			if !tc.noAuth {
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.status, w.Code, w.Body.String())
+
+			spec := specs[tc.route[:len("/api/v1")]]
+			assert.NoError(t, spec.ValidateResponse(tc.method, tc.route, w.Code, w.Body.Bytes()))
+		})
+		covered[tc.method+" "+tc.route] = true
+	}
+
+	// 対象のハンドラーを持つすべてのルートがテストされ、仕様に記載されていることを確認する
+	router, _ := newContractRouter(t)
+	for _, route := range router.Routes() {
+		if !isContractHandler(route.Handler) {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		assert.True(t, covered[key], "no contract test case for %s", key)
+		if spec, ok := specs[route.Path[:len("/api/v1")]]; assert.True(t, ok, "no spec for %s", key) {
+			assert.True(t, spec.HasOperation(route.Method, route.Path), "%s is not documented", key)
+		}
+	}
+}
+
+// isContractHandler はハンドラーが契約テストの対象かを判定する
+func isContractHandler(handler string) bool {
+	for _, name := range contractHandlers {
+		if strings.Contains(handler, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// newContractRouter はフェイクの依存関係でルーターを作成し、認証済みユーザーのアクセストークンを返す
+func newContractRouter(t *testing.T) (*gin.Engine, string) {
+	t.Helper()
+
+	cfg, err := config.LoadConfig("")
+	require.NoError(t, err)
+	cfg.Environment = "test"
+	cfg.Security.EnableCSRF = false
+
+	log := logger.NewLogger(&logger.Config{
+		Level:  "fatal",
+		Output: "console",
+	})
+
+	jwtManager := token.NewJWTManager("contract-test-secret", "contract-test")
+	accessToken, err := jwtManager.Generate(&token.Claims{
+		UserID:   contractUserID,
+		Email:    "contract@example.com",
+		Username: "contract",
+		Role:     "user",
+	}, time.Hour)
+	require.NoError(t, err)
+
+	dueDate := time.Now().Add(-24 * time.Hour)
+	repo := newFakeTaskRepository(&taskDomain.Task{
+		ID:          contractTaskID,
+		Title:       "contract task",
+		Description: "契約テスト用のタスク",
+		Status:      taskDomain.TaskStatusTodo,
+		Priority:    taskDomain.PriorityMedium,
+		Category:    taskDomain.CategoryWork,
+		CreatedBy:   contractUserID,
+		DueDate:     &dueDate,
+		CreatedAt:   time.Now().Add(-48 * time.Hour),
+		UpdatedAt:   time.Now().Add(-48 * time.Hour),
+		Assignees: []*taskDomain.TaskAssignee{
+			{UserID: contractUserID, AssignedAt: time.Now().Add(-48 * time.Hour)},
+		},
+	})
+
+	taskService := taskUseCase.NewTaskService(repo, fakeUserValidator{}, fakeTaskEventPublisher{}, *log)
+
+	deps := &Dependencies{
+		TokenService: *tokenService.NewTokenService(fakeTokenRepository{}, jwtManager, time.Hour, time.Hour),
+		TaskService:  *taskService,
+		Logger:       *log,
+		Config:       cfg,
+	}
+	return SetupRouter(deps), accessToken
+}
+
+// fakeTaskRepository はメモリ上でタスクを保持するTaskRepository
+type fakeTaskRepository struct {
+	mu    sync.Mutex
+	tasks map[string]*taskDomain.Task
+}
+
+func newFakeTaskRepository(tasks ...*taskDomain.Task) *fakeTaskRepository {
+	repo := &fakeTaskRepository{tasks: make(map[string]*taskDomain.Task)}
+	for _, task := range tasks {
+		repo.tasks[task.ID] = task
+	}
+	return repo
+}
+
+func (r *fakeTaskRepository) CreateTask(ctx context.Context, task *taskDomain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks[task.ID] = task
+	return nil
+}
+
+func (r *fakeTaskRepository) GetTaskByID(ctx context.Context, id string) (*taskDomain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, taskUseCase.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (r *fakeTaskRepository) ListTasks(ctx context.Context, filter taskDomain.ListFilter, pagination taskDomain.Pagination, sortOptions taskDomain.SortOptions) ([]*taskDomain.Task, int, error) {
+	tasks := r.all()
+	return tasks, len(tasks), nil
+}
+
+func (r *fakeTaskRepository) UpdateTask(ctx context.Context, task *taskDomain.Task) error {
+	return r.CreateTask(ctx, task)
+}
+
+func (r *fakeTaskRepository) DeleteTask(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tasks, id)
+	return nil
+}
+
+func (r *fakeTaskRepository) GetOverdueTasks(ctx context.Context) ([]*taskDomain.Task, error) {
+	return r.all(), nil
+}
+
+func (r *fakeTaskRepository) GetOverdueTasksByUser(ctx context.Context, userID string) ([]*taskDomain.Task, error) {
+	return r.all(), nil
+}
+
+func (r *fakeTaskRepository) GetTasksByAssignee(ctx context.Context, userID string) ([]*taskDomain.Task, error) {
+	return r.all(), nil
+}
+
+func (r *fakeTaskRepository) SearchTasks(ctx context.Context, query string, limit int) ([]*taskDomain.Task, error) {
+	return r.all(), nil
+}
+
+func (r *fakeTaskRepository) all() []*taskDomain.Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := make([]*taskDomain.Task, 0, len(r.tasks))
+	for _, task := range r.tasks {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// fakeUserValidator はすべてのユーザーを存在するものとして扱うUserValidator
+type fakeUserValidator struct{}
+
+func (fakeUserValidator) UserExists(ctx context.Context, userID string) (bool, error) {
+	return true, nil
+}
+
+func (fakeUserValidator) UsersExist(ctx context.Context, userIDs []string) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeUserValidator) GetUserInfo(ctx context.Context, userID string) (*commonDomain.UserInfo, error) {
+	return &commonDomain.UserInfo{ID: userID, Username: "user-" + userID[:8]}, nil
+}
+
+func (v fakeUserValidator) GetUsersInfoBatch(ctx context.Context, userIDs []string) (map[string]*commonDomain.UserInfo, error) {
+	users := make(map[string]*commonDomain.UserInfo, len(userIDs))
+	for _, id := range userIDs {
+		users[id], _ = v.GetUserInfo(ctx, id)
+	}
+	return users, nil
+}
+
+// fakeTaskEventPublisher はイベントを破棄するEventPublisher
+type fakeTaskEventPublisher struct{}
+
+func (fakeTaskEventPublisher) PublishTaskCreated(ctx context.Context, task *taskDomain.Task) error {
+	return nil
+}
+
+func (fakeTaskEventPublisher) PublishTaskUpdated(ctx context.Context, task *taskDomain.Task) error {
+	return nil
+}
+
+func (fakeTaskEventPublisher) PublishTaskDeleted(ctx context.Context, taskID string) error {
+	return nil
+}
+
+func (fakeTaskEventPublisher) PublishTaskAssigned(ctx context.Context, task *taskDomain.Task, assigneeIDs []string) error {
+	return nil
+}
+
+func (fakeTaskEventPublisher) PublishTaskCompleted(ctx context.Context, task *taskDomain.Task) error {
+	return nil
+}
+
+// fakeTokenRepository はブラックリスト・リフレッシュトークンを持たないトークンリポジトリ
+type fakeTokenRepository struct{}
+
+func (fakeTokenRepository) SaveTokenToBlacklist(token string, ttl time.Duration) error { return nil }
+func (fakeTokenRepository) IsTokenBlacklisted(token string) bool                       { return false }
+func (fakeTokenRepository) SaveRefreshToken(token *authDomain.RefreshToken) error      { return nil }
+func (fakeTokenRepository) FindRefreshToken(token string) (*authDomain.RefreshToken, error) {
+	return nil, nil
+}
+func (fakeTokenRepository) RevokeRefreshToken(token string) error { return nil }
+func (fakeTokenRepository) DeleteExpiredRefreshTokens() error     { return nil }
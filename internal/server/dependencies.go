@@ -2,312 +2,187 @@ package server
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"strings"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
-	"github.com/hryt430/Yotei+/config"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
 	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
 
 	"github.com/hryt430/Yotei+/pkg/logger"
-	"github.com/hryt430/Yotei+/pkg/token"
-
-	// Common domain and validator (統一インターフェース)
-	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
-	commonValidator "github.com/hryt430/Yotei+/internal/common/validator"
+	"github.com/hryt430/Yotei+/pkg/utils"
 
 	// Auth module
 	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
-	authDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/database"
-	authRedisInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/redis"
-	authDatabase "github.com/hryt430/Yotei+/internal/modules/auth/interface/database"
-	authRedis "github.com/hryt430/Yotei+/internal/modules/auth/interface/redis"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
 	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
+	deviceService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/device"
+	ssoService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso"
 	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
 	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
-
-	// Notification module
-	notificationDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/database"
-	notificationGateway "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/gateway"
-	notificationMessaging "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/messaging"
-	notificationDatabase "github.com/hryt430/Yotei+/internal/modules/notification/interface/database"
-	"github.com/hryt430/Yotei+/internal/modules/notification/interface/websocket"
-	notificationUseCase "github.com/hryt430/Yotei+/internal/modules/notification/usecase"
-	notificationOutput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/output"
-	notificationPersistence "github.com/hryt430/Yotei+/internal/modules/notification/usecase/persistence"
-
-	// Task module
-	taskDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
-	taskMessaging "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/messaging"
-	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
-	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
-
-	// Social module
-	socialDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/database"
-	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
-	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
-
-	// Group module
-	groupDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/database"
-	groupDatabase "github.com/hryt430/Yotei+/internal/modules/group/interface/database"
-	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
 )
 
-// NewDependencies は依存関係を初期化します（統一インターフェース対応版）
-func NewDependencies(cfg *config.Config, log logger.Logger) (*Dependencies, error) {
-	// Redis接続の初期化
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     cfg.Redis.Host + ":" + cfg.Redis.Port,
-		Password: cfg.Redis.Password,
-		DB:       0,
-	})
-
-	// Redis接続テスト
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := redisClient.Ping(ctx).Err(); err != nil {
-		log.Warn("Redis connection failed, continuing without Redis", logger.Error(err))
-		// Redisが利用できない場合はnilを設定（開発環境対応）
-		redisClient = nil
+// AuthRepositoryImpl はAuthRepositoryの実装
+type AuthRepositoryImpl struct {
+	UserService          userService.UserService
+	TokenService         tokenService.TokenService
+	RegistrationListener authService.RegistrationListener   // nilの場合は登録を通知しない
+	SecurityEvents       auditService.SecurityEventRecorder // nilの場合は監査ログを記録しない
+	Devices              *deviceService.DeviceService       // nilの場合は不審なログインを検知しない
+	SSO                  *ssoService.SSOService             // nilの場合はSSOを強制しない
+	Analytics            commonDomain.AnalyticsTracker      // nilの場合はログインを分析イベントに記録しない
+}
+
+// recordSecurityEvent は認証イベントを監査ログに記録する
+func (r *AuthRepositoryImpl) recordSecurityEvent(ctx context.Context, eventType, userID, detail string) {
+	if r.SecurityEvents != nil {
+		r.SecurityEvents.Record(ctx, authDomain.NewSecurityEvent(eventType, userID, detail))
 	}
+}
 
-	// JWTマネージャーの初期化
-	accessTokenDuration, err := time.ParseDuration(cfg.GetJWTAccessTokenDuration())
-	if err != nil {
+func (r *AuthRepositoryImpl) Register(ctx context.Context, email, username, password string) (*authDomain.User, error) {
+	// 予約語・使用中のユーザー名、他のユーザーが変更前に使っていた保持期間中のユーザー名は登録できない
+	if err := r.UserService.CheckUsernameAvailable(ctx, uuid.Nil, username); err != nil {
 		return nil, err
 	}
 
-	refreshTokenDuration, err := time.ParseDuration(cfg.GetJWTRefreshTokenDuration())
+	user := &authDomain.User{
+		ID:       uuid.New(),
+		Email:    email,
+		Username: username,
+		Password: password,
+	}
+
+	newUser, err := r.UserService.CreateUser(user)
 	if err != nil {
 		return nil, err
 	}
 
-	jwtManager := token.NewJWTManager(cfg.JWT.SecretKey, cfg.JWT.Issuer)
-
-	// Auth module dependencies
-	authSqlHandler := authDatabaseInfra.NewSqlHandler()
-	userRepository := &authDatabase.IUserRepository{
-		SqlHandler: &authSqlHandler,
-	}
-	tokenStorage := &authDatabase.TokenStorage{
-		SqlHandler: &authSqlHandler,
+	if r.RegistrationListener != nil {
+		r.RegistrationListener.UserRegistered(ctx, newUser)
 	}
+	return newUser, nil
+}
 
-	// Redis Token Cache（Redis利用可能時のみ）
-	var tokenRepository tokenService.ITokenRepository
-	if redisClient != nil {
-		redisTokenCache := authRedisInfra.NewRedisTokenCache(redisClient)
-		tokenRepository = authRedis.NewTokenRepositoryAdapter(redisTokenCache, tokenStorage)
-	} else {
-		// Redis不使用時はDBのみ使用するアダプタを作成（logger追加）
-		tokenRepository = NewDBOnlyTokenRepository(tokenStorage, log)
+func (r *AuthRepositoryImpl) Login(ctx context.Context, email, password string) (accessToken string, refreshToken string, err error) {
+	user, err := r.UserService.FindUserByEmail(email)
+	if err != nil {
+		return "", "", err
 	}
 
-	userSvc := userService.NewUserService(userRepository)
-	tokenSvc := tokenService.NewTokenService(tokenRepository, jwtManager, accessTokenDuration, refreshTokenDuration)
-
-	// AuthRepository の実装
-	authRepository := &AuthRepositoryImpl{
-		UserService:  *userSvc,
-		TokenService: *tokenSvc,
+	if user == nil {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginFailed, "", "unknown email")
+		return "", "", errors.New("invalid email or password")
 	}
-	authSvc := authService.NewAuthService(authRepository, *userSvc, *tokenSvc)
-
-	// **統一されたUserValidator の実装**
-	var userValidator commonDomain.UserValidator = commonValidator.NewUserValidator(userRepository)
-
-	// Notification module dependencies
-	notificationSqlHandler := notificationDatabaseInfra.NewSqlHandler()
-	notificationRepo := &notificationDatabase.NotificationServiceRepository{
-		SqlHandler: &notificationSqlHandler,
-		Logger:     log,
+	if !utils.CheckPasswordHash(password, user.Password) {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginFailed, user.ID.String(), "invalid password")
+		return "", "", errors.New("invalid email or password")
 	}
-
-	// WebSocketハブの初期化
-	wsHub := websocket.NewHub(log)
-
-	// Notification gateways
-	appGateway := notificationGateway.NewAppNotificationGateway(cfg, notificationRepo, wsHub, log)
-	lineGateway := notificationGateway.NewLineGateway(cfg, log)
-
-	// Type assertions to ensure interface compliance
-	var notificationRepository notificationPersistence.NotificationRepository = notificationRepo
-	var appNotificationGateway notificationOutput.AppNotificationGateway = appGateway
-	var lineNotificationGateway notificationOutput.LineNotificationGateway = lineGateway
-
-	// **通知ユースケース（統一されたUserValidatorを使用）**
-	notificationUseCaseImpl := notificationUseCase.NewNotificationUseCase(
-		notificationRepository,
-		appNotificationGateway,
-		lineNotificationGateway,
-		userValidator, // 統一されたUserValidatorを使用
-		log,
-	)
-
-	// Task module dependencies
-	taskSqlHandler := taskDatabaseInfra.NewSqlHandler()
-	taskRepository := taskDatabase.NewTaskRepository(&taskSqlHandler, log)
-
-	// 統計リポジトリの初期化
-	statsRepository := taskDatabase.NewTaskStatsRepository(&taskSqlHandler, log)
-
-	// Event Publisher（修正版：戻り値統一）
-	notificationAdapter := taskMessaging.NewNotificationAdapter(notificationUseCaseImpl)
-	eventPublisher := taskMessaging.NewTaskEventPublisher(notificationAdapter, log)
-
-	// **Task Service（統一されたUserValidatorを使用）**
-	taskService := taskUseCase.NewTaskService(
-		taskRepository,
-		userValidator, // 統一されたUserValidatorを使用
-		eventPublisher,
-		log,
-	)
-
-	// Stats Service
-	statsService := taskUseCase.NewTaskStatsService(
-		taskRepository,
-		statsRepository,
-		&log,
-	)
-
-	// Social module dependencies
-	socialSqlHandler := socialDatabaseInfra.NewSqlHandler()
-	friendshipRepository := socialDatabase.NewFriendshipRepository(socialSqlHandler.GetConnection(), log)
-	invitationRepository := socialDatabase.NewInvitationRepository(socialSqlHandler.GetConnection(), log)
-
-	// Social event publisher (simplified for now)
-	socialEventPublisher := &SimpleSocialEventPublisher{logger: log}
-
-	// URL gateway (simplified for now)
-	urlGateway := &SimpleURLGateway{baseURL: "http://localhost:8080"}
-
-	socialService := socialUseCase.NewSocialServiceImpl(
-		friendshipRepository,
-		invitationRepository,
-		userValidator, // using the existing userValidator
-		socialEventPublisher,
-		urlGateway,
-		&log,
-	)
-
-	// Group module dependencies
-	groupSqlHandler := groupDatabaseInfra.NewSqlHandler()
-	groupRepository := groupDatabase.NewGroupRepository(groupSqlHandler.GetConnection(), log)
-	groupService := groupUseCase.NewGroupService(groupRepository, userValidator, &log)
-
-	// メッセージブローカーとスケジューラー
-	messageBroker := notificationMessaging.NewInMemoryMessageBroker(log)
-
-	// **タスク期限通知スケジューラー（統一されたUserValidatorを使用）**
-	taskScheduler := taskMessaging.NewTaskDueNotificationScheduler(
-		*taskService,
-		notificationAdapter,
-		eventPublisher,
-		log,
-	)
-
-	return &Dependencies{
-		AuthService:         *authSvc,
-		TokenService:        *tokenSvc,
-		UserService:         *userSvc,
-		NotificationUseCase: notificationUseCaseImpl,
-		TaskService:         *taskService,
-		StatsService:        statsService,
-		SocialService:       socialService,
-		GroupService:        groupService,
-		WSHub:               wsHub,
-		TaskScheduler:       taskScheduler,
-		MessageBroker:       messageBroker,
-		Logger:              log,
-		Config:              cfg,
-		// context管理用フィールドは初期化時は設定しない
-	}, nil
-}
-
-// DBOnlyTokenRepository はRedis不使用時のトークンリポジトリ実装（修正版）
-type DBOnlyTokenRepository struct {
-	tokenStorage *authDatabase.TokenStorage
-	logger       logger.Logger
-}
-
-// NewDBOnlyTokenRepository は新しいDBOnlyTokenRepositoryを作成
-func NewDBOnlyTokenRepository(tokenStorage *authDatabase.TokenStorage, logger logger.Logger) *DBOnlyTokenRepository {
-	return &DBOnlyTokenRepository{
-		tokenStorage: tokenStorage,
-		logger:       logger,
+	// 無効化されたアカウント（SCIMでの無効化など）はログインできない
+	if !user.IsActive() {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginFailed, user.ID.String(), "account deactivated")
+		return "", "", errors.New("invalid email or password")
+	}
+	// SSOを強制するドメインのユーザーはIdPでログインする
+	if r.SSO != nil {
+		required, err := r.SSO.RequiresSSO(ctx, user)
+		if err != nil {
+			return "", "", err
+		}
+		if required {
+			r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginFailed, user.ID.String(), "sso required")
+			return "", "", authService.ErrSSORequired
+		}
 	}
-}
 
-func (r *DBOnlyTokenRepository) SaveTokenToBlacklist(token string, ttl time.Duration) error {
-	// DBのみの場合はブラックリスト機能を簡易実装
-	r.logger.Warn("Token blacklist feature disabled (Redis not available)")
-	return nil
-}
+	// 初めての端末・国、不可能な移動を検知し、設定に応じて確認コードの入力を求める
+	if r.Devices != nil {
+		check, err := r.Devices.CheckLogin(ctx, user, authDomain.ClientInfoFromContext(ctx))
+		if err != nil {
+			return "", "", err
+		}
+		if check.Risk.IsAnomalous() {
+			r.recordSecurityEvent(ctx, authDomain.SecurityEventSuspiciousLogin, user.ID.String(), strings.Join(check.Risk.Reasons(), ","))
+		}
+		if check.VerificationRequired {
+			return "", "", &authService.LoginVerificationRequiredError{ChallengeID: check.Device.ID}
+		}
+	}
 
-func (r *DBOnlyTokenRepository) IsTokenBlacklisted(token string) bool {
-	// DBのみの場合は常にfalse（ブラックリスト機能無効）
-	return false
+	return r.completeLogin(ctx, user, "password", "")
 }
 
-func (r *DBOnlyTokenRepository) SaveRefreshToken(token *authDomain.RefreshToken) error {
-	return r.tokenStorage.SaveRefreshToken(token)
-}
+// VerifyLogin はメールで送った確認コードで不審なログインを完了する
+func (r *AuthRepositoryImpl) VerifyLogin(ctx context.Context, challengeID, code string, rememberDevice bool) (accessToken string, refreshToken string, deviceTrust string, err error) {
+	if r.Devices == nil {
+		return "", "", "", authDomain.ErrLoginVerificationInvalid
+	}
 
-func (r *DBOnlyTokenRepository) FindRefreshToken(token string) (*authDomain.RefreshToken, error) {
-	return r.tokenStorage.FindRefreshTokenByToken(token)
-}
+	device, deviceTrust, err := r.Devices.VerifyLogin(ctx, challengeID, code, authDomain.ClientInfoFromContext(ctx), rememberDevice)
+	if err != nil {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginFailed, "", err.Error())
+		return "", "", "", err
+	}
 
-func (r *DBOnlyTokenRepository) RevokeRefreshToken(token string) error {
-	return r.tokenStorage.RevokeRefreshToken(token)
-}
+	userID, err := uuid.Parse(device.UserID)
+	if err != nil {
+		return "", "", "", err
+	}
+	user, err := r.UserService.FindUserByID(userID)
+	if err != nil {
+		return "", "", "", err
+	}
+	if user == nil || !user.IsActive() {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginFailed, device.UserID, "account deactivated")
+		return "", "", "", authDomain.ErrLoginVerificationInvalid
+	}
 
-func (r *DBOnlyTokenRepository) DeleteExpiredRefreshTokens() error {
-	return r.tokenStorage.DeleteExpiredRefreshTokens()
+	detail := "verified"
+	if deviceTrust != "" {
+		detail = "verified, device remembered"
+	}
+	accessToken, refreshToken, err = r.completeLogin(ctx, user, "password_verified", detail)
+	if err != nil {
+		return "", "", "", err
+	}
+	return accessToken, refreshToken, deviceTrust, nil
 }
 
-// AuthRepositoryImpl はAuthRepositoryの実装
-type AuthRepositoryImpl struct {
-	UserService  userService.UserService
-	TokenService tokenService.TokenService
+// IssueSession はSSOで認証したユーザーにパスワードでのログインと同じトークンを発行する
+func (r *AuthRepositoryImpl) IssueSession(ctx context.Context, user *authDomain.User, detail string) (accessToken string, refreshToken string, err error) {
+	return r.completeLogin(ctx, user, "sso", detail)
 }
 
-func (r *AuthRepositoryImpl) Register(ctx context.Context, email, username, password string) (*authDomain.User, error) {
-	user := &authDomain.User{
-		Email:    email,
-		Username: username,
-		Password: password,
+// completeLogin は最終ログイン時間を更新してトークンを発行する
+// methodはログインの方法（password・password_verified・sso）で、分析イベントに記録する
+func (r *AuthRepositoryImpl) completeLogin(ctx context.Context, user *authDomain.User, method, detail string) (accessToken string, refreshToken string, err error) {
+	// 最終ログイン時間を更新（管理者ダッシュボードのアクティブユーザー数に使用する）
+	if err := r.UserService.UpdateLastLogin(user.ID); err != nil {
+		return "", "", err
 	}
 
-	return r.UserService.CreateUser(user)
-}
-
-func (r *AuthRepositoryImpl) Login(ctx context.Context, email, password string) (accessToken string, refreshToken string, err error) {
-	user, err := r.UserService.FindUserByEmail(email)
+	accessToken, err = r.TokenService.GenerateAccessToken(user)
 	if err != nil {
 		return "", "", err
 	}
 
-	if user != nil {
-		accessToken, err := r.TokenService.GenerateAccessToken(user)
-		if err != nil {
-			return "", "", err
-		}
-
-		refreshToken, err := r.TokenService.GenerateRefreshToken(user)
-		if err != nil {
-			return "", "", err
-		}
-
-		return accessToken, refreshToken, nil
+	refreshToken, err = r.TokenService.GenerateRefreshToken(user)
+	if err != nil {
+		return "", "", err
 	}
 
-	return "", "", nil
+	r.recordSecurityEvent(ctx, authDomain.SecurityEventLoginSucceeded, user.ID.String(), detail)
+	if r.Analytics != nil {
+		r.Analytics.Track(ctx, user.ID.String(), commonDomain.AnalyticsSessionStarted, map[string]interface{}{"method": method})
+	}
+	return accessToken, refreshToken, nil
 }
 
 func (r *AuthRepositoryImpl) RefreshToken(ctx context.Context, refreshToken string) (newAccessToken string, newRefreshToken string, err error) {
 	tokenEntity, err := r.TokenService.ValidateRefreshToken(refreshToken)
 	if err != nil {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventTokenRefreshFailed, "", err.Error())
 		return "", "", err
 	}
 
@@ -315,6 +190,10 @@ func (r *AuthRepositoryImpl) RefreshToken(ctx context.Context, refreshToken stri
 	if err != nil {
 		return "", "", err
 	}
+	if user == nil || !user.IsActive() {
+		r.recordSecurityEvent(ctx, authDomain.SecurityEventTokenRefreshFailed, tokenEntity.UserID.String(), "account deactivated")
+		return "", "", errors.New("account is deactivated")
+	}
 
 	newAccessToken, err = r.TokenService.GenerateAccessToken(user)
 	if err != nil {
@@ -331,6 +210,7 @@ func (r *AuthRepositoryImpl) RefreshToken(ctx context.Context, refreshToken stri
 		return "", "", err
 	}
 
+	r.recordSecurityEvent(ctx, authDomain.SecurityEventTokenRefreshed, user.ID.String(), "")
 	return newAccessToken, newRefreshToken, nil
 }
 
@@ -364,6 +244,14 @@ func (p *SimpleSocialEventPublisher) PublishFriendRequestAccepted(ctx context.Co
 	return nil
 }
 
+func (p *SimpleSocialEventPublisher) PublishFriendRequestReminder(ctx context.Context, friendship *socialDomain.Friendship, deleteAt time.Time) error {
+	p.logger.Info("Friend request reminder",
+		logger.Any("friendshipID", friendship.ID),
+		logger.Any("addresseeID", friendship.AddresseeID),
+		logger.Any("deleteAt", deleteAt))
+	return nil
+}
+
 func (p *SimpleSocialEventPublisher) PublishFriendRequestDeclined(ctx context.Context, friendship *socialDomain.Friendship) error {
 	p.logger.Info("Friend request declined",
 		logger.Any("friendshipID", friendship.ID),
@@ -405,12 +293,3 @@ func (p *SimpleSocialEventPublisher) PublishInvitationDeclined(ctx context.Conte
 		logger.Any("inviteeID", invitation.InviteeID))
 	return nil
 }
-
-// SimpleURLGateway は簡単なURL生成ゲートウェイ実装
-type SimpleURLGateway struct {
-	baseURL string
-}
-
-func (g *SimpleURLGateway) GenerateInviteURL(ctx context.Context, invitationID uuid.UUID, code string) (string, error) {
-	return fmt.Sprintf("%s/invite/%s", g.baseURL, code), nil
-}
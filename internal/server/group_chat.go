@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// groupChatSummaryInterval はグループのチャット連携に1日のまとめ・期限のリマインダーを投稿する時刻を確認する間隔
+const groupChatSummaryInterval = 15 * time.Minute
+
+// groupChatEventPublisher はタスクのイベントの発行に加えて、グループタスクの完了・割り当てをグループのチャット連携に投稿する
+// タスクのイベントの発行は失敗すると再試行されるため、チャットへの投稿は発行に成功した後に1回だけ行い、エラーは返さない
+type groupChatEventPublisher struct {
+	taskUseCase.EventPublisher
+	groupService groupUseCase.GroupService
+	groupTasks   taskUseCase.GroupTaskStore
+	log          logger.Logger
+}
+
+func (p *groupChatEventPublisher) PublishTaskCompleted(ctx context.Context, task *taskDomain.Task) error {
+	if err := p.EventPublisher.PublishTaskCompleted(ctx, task); err != nil {
+		return err
+	}
+
+	chatTask := toChatTask(task)
+	p.notifyGroups(ctx, task.ID, func(ctx context.Context, groupID uuid.UUID) error {
+		return p.groupService.NotifyChatTaskCompleted(ctx, groupID, chatTask)
+	})
+	return nil
+}
+
+func (p *groupChatEventPublisher) PublishTaskAssigned(ctx context.Context, task *taskDomain.Task, assigneeIDs []string) error {
+	if err := p.EventPublisher.PublishTaskAssigned(ctx, task, assigneeIDs); err != nil {
+		return err
+	}
+
+	chatTask := toChatTask(task)
+	assignees := parseUUIDs(assigneeIDs)
+	p.notifyGroups(ctx, task.ID, func(ctx context.Context, groupID uuid.UUID) error {
+		return p.groupService.NotifyChatTaskAssigned(ctx, groupID, chatTask, assignees)
+	})
+	return nil
+}
+
+// notifyGroups はタスクを紐付けたグループごとに notify を呼び出す（グループタスクでない場合は何もしない）
+// リクエストの終了後も投稿を続けられるよう、呼び出し元のキャンセルは引き継がない
+func (p *groupChatEventPublisher) notifyGroups(ctx context.Context, taskID string, notify func(ctx context.Context, groupID uuid.UUID) error) {
+	ctx = context.WithoutCancel(ctx)
+	groupIDs, err := p.groupTasks.GetGroupIDsForTask(ctx, taskID)
+	if err != nil {
+		p.log.WithContext(ctx).Warn("Failed to get groups for chat notification", logger.Any("taskID", taskID), logger.Error(err))
+		return
+	}
+	for _, id := range groupIDs {
+		groupID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		if err := notify(ctx, groupID); err != nil {
+			p.log.WithContext(ctx).Warn("Failed to notify group chat",
+				logger.Any("groupID", groupID), logger.Any("taskID", taskID), logger.Error(err))
+		}
+	}
+}
+
+// groupChatTasks はグループタスクをチャット連携の1日のまとめ用に取得する
+type groupChatTasks struct {
+	groupTasks     taskUseCase.GroupTaskStore
+	taskRepository taskUseCase.TaskRepository
+}
+
+func (g *groupChatTasks) ListGroupChatTasks(ctx context.Context, groupID uuid.UUID) ([]*groupDomain.ChatTask, error) {
+	taskIDs, err := g.groupTasks.ListGroupTaskIDs(ctx, groupID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*groupDomain.ChatTask, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, err := g.taskRepository.GetTaskByID(ctx, taskID)
+		if errors.Is(err, taskUseCase.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, toChatTask(task))
+	}
+	return tasks, nil
+}
+
+// toChatTask はタスクをチャットへの投稿に使う概要に変換する
+func toChatTask(task *taskDomain.Task) *groupDomain.ChatTask {
+	return &groupDomain.ChatTask{
+		ID:          task.ID,
+		Title:       task.Title,
+		Done:        task.Status == taskDomain.TaskStatusDone,
+		DueDate:     task.DueDate,
+		CompletedAt: task.CompletedAt,
+		AssigneeIDs: parseUUIDs(task.AssigneeIDs()),
+	}
+}
+
+// parseUUIDs はUUIDの文字列を変換する（不正な値は含めない）
+func parseUUIDs(values []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		if id, err := uuid.Parse(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
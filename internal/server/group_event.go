@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+const (
+	// groupEventPageSize は開始が近い予定を探すときに1回で取得するタスクの件数
+	groupEventPageSize = 100
+	// groupEventReminderInterval は出欠のリマインダーを送る予定を確認する間隔
+	groupEventReminderInterval = 15 * time.Minute
+)
+
+// groupEvents はグループタスクを予定共有グループの予定として取得する（予定の出欠用）
+// 予定の開始日時はタスクの着手予定日時、ない場合は期限とし、場所はタスクに設定した場所とする
+type groupEvents struct {
+	groupTasks     taskUseCase.GroupTaskStore
+	taskRepository taskUseCase.TaskRepository
+	locations      taskUseCase.LocationRepository // nilの場合は場所を設定しない
+}
+
+func (g *groupEvents) GetGroupEvent(ctx context.Context, groupID uuid.UUID, eventID string) (*groupDomain.GroupEvent, error) {
+	groupIDs, err := g.groupTasks.GetGroupIDsForTask(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(groupIDs, groupID.String()) {
+		return nil, nil
+	}
+
+	task, err := g.taskRepository.GetTaskByID(ctx, eventID)
+	if errors.Is(err, taskUseCase.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	event := toGroupEvent(groupID, task)
+	if event == nil {
+		return nil, nil
+	}
+	if err := g.attachLocations(ctx, []*groupDomain.GroupEvent{event}); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (g *groupEvents) ListGroupEvents(ctx context.Context, groupID uuid.UUID) ([]*groupDomain.GroupEvent, error) {
+	taskIDs, err := g.groupTasks.ListGroupTaskIDs(ctx, groupID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*groupDomain.GroupEvent
+	for _, taskID := range taskIDs {
+		task, err := g.taskRepository.GetTaskByID(ctx, taskID)
+		if errors.Is(err, taskUseCase.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if event := toGroupEvent(groupID, task); event != nil {
+			events = append(events, event)
+		}
+	}
+	if err := g.attachLocations(ctx, events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (g *groupEvents) ListUpcomingEvents(ctx context.Context, from, to time.Time) ([]*groupDomain.GroupEvent, error) {
+	// 着手予定日時のあるタスクと、期限のみのタスクをそれぞれ検索する
+	tasks, err := g.listTasks(ctx, taskDomain.ListFilter{StartDateFrom: &from, StartDateTo: &to})
+	if err != nil {
+		return nil, err
+	}
+	dueTasks, err := g.listTasks(ctx, taskDomain.ListFilter{DueDateFrom: &from, DueDateTo: &to})
+	if err != nil {
+		return nil, err
+	}
+	tasks = append(tasks, dueTasks...)
+
+	seen := make(map[string]bool, len(tasks))
+	var events []*groupDomain.GroupEvent
+	for _, task := range tasks {
+		if seen[task.ID] {
+			continue
+		}
+		seen[task.ID] = true
+
+		startsAt := groupEventStart(task)
+		if startsAt == nil || startsAt.Before(from) || !startsAt.Before(to) {
+			continue
+		}
+		groupIDs, err := g.groupTasks.GetGroupIDsForTask(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range groupIDs {
+			groupID, err := uuid.Parse(id)
+			if err != nil {
+				continue
+			}
+			events = append(events, toGroupEvent(groupID, task))
+		}
+	}
+	return events, nil
+}
+
+// attachLocations は予定のタスクに設定した場所を予定に設定する
+func (g *groupEvents) attachLocations(ctx context.Context, events []*groupDomain.GroupEvent) error {
+	if g.locations == nil || len(events) == 0 {
+		return nil
+	}
+	taskIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		taskIDs = append(taskIDs, event.ID)
+	}
+	locations, err := g.locations.GetTaskLocations(ctx, taskIDs)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if location, ok := locations[event.ID]; ok {
+			event.Location = &groupDomain.EventLocation{
+				Name:      location.Location.Name,
+				Latitude:  location.Location.Latitude,
+				Longitude: location.Location.Longitude,
+			}
+		}
+	}
+	return nil
+}
+
+// listTasks はフィルタに一致するタスクをすべて取得する
+func (g *groupEvents) listTasks(ctx context.Context, filter taskDomain.ListFilter) ([]*taskDomain.Task, error) {
+	var tasks []*taskDomain.Task
+	for page := 1; ; page++ {
+		found, total, err := g.taskRepository.ListTasks(ctx, filter,
+			taskDomain.Pagination{Page: page, PageSize: groupEventPageSize},
+			taskDomain.SortOptions{Field: "created_at", Direction: "ASC"})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, found...)
+		if len(found) < groupEventPageSize || len(tasks) >= total {
+			return tasks, nil
+		}
+	}
+}
+
+// groupEventStart はタスクの予定としての開始日時を返す（着手予定日時も期限もない場合はnil）
+func groupEventStart(task *taskDomain.Task) *time.Time {
+	if task.StartDate != nil {
+		return task.StartDate
+	}
+	return task.DueDate
+}
+
+// toGroupEvent はタスクを予定に変換する（開始日時がない場合はnil）
+func toGroupEvent(groupID uuid.UUID, task *taskDomain.Task) *groupDomain.GroupEvent {
+	startsAt := groupEventStart(task)
+	if startsAt == nil {
+		return nil
+	}
+	createdBy, _ := uuid.Parse(task.CreatedBy)
+	return &groupDomain.GroupEvent{
+		ID:        task.ID,
+		GroupID:   groupID,
+		Title:     task.Title,
+		StartsAt:  *startsAt,
+		CreatedBy: createdBy,
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// groupEventReminderNotifier は予定の出欠を回答していないメンバーにアプリ内通知でリマインダーを送る
+type groupEventReminderNotifier struct {
+	notificationUseCase notificationInput.NotificationUseCase
+}
+
+func (n *groupEventReminderNotifier) NotifyRSVPReminder(ctx context.Context, userID uuid.UUID, event *groupDomain.GroupEvent) error {
+	notification, err := n.notificationUseCase.CreateNotification(ctx, notificationInput.CreateNotificationInput{
+		UserID:  userID.String(),
+		Type:    string(notificationDomain.GroupEventRSVP),
+		Title:   "予定の出欠を回答してください",
+		Message: fmt.Sprintf("「%s」の出欠が未回答です。", event.Title),
+		Metadata: map[string]string{
+			"action_url": fmt.Sprintf("/groups/%s/events/%s", event.GroupID, event.ID),
+			"group_id":   event.GroupID.String(),
+			"event_id":   event.ID,
+			"starts_at":  event.StartsAt.Format(time.RFC3339),
+		},
+		Channels: []string{"app"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rsvp reminder notification: %w", err)
+	}
+	if err := n.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		return fmt.Errorf("failed to send rsvp reminder notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// groupMembershipGateway はソーシャルモジュールのグループ招待をグループサービスに橋渡しする
+type groupMembershipGateway struct {
+	groupService groupUseCase.GroupService
+}
+
+func (g *groupMembershipGateway) CanInviteToGroup(ctx context.Context, groupID, inviterID uuid.UUID) (bool, error) {
+	return g.groupService.CheckPermission(ctx, groupID, inviterID, groupUseCase.ActionInviteMembers)
+}
+
+// JoinGroupByInvitation は招待者の権限で、招待で指定したロールのメンバーとして追加する（招待後に権限を失った場合は参加できない）
+func (g *groupMembershipGateway) JoinGroupByInvitation(ctx context.Context, groupID, userID, inviterID uuid.UUID, role string) error {
+	return g.groupService.AddMember(ctx, groupID, userID, inviterID, groupDomain.MemberRole(role))
+}
+
+// invitationRegistrationListener は新規登録したユーザーにメールアドレス宛ての招待を紐付ける
+type invitationRegistrationListener struct {
+	socialService socialUseCase.SocialService
+	logger        logger.Logger
+}
+
+func (l *invitationRegistrationListener) UserRegistered(ctx context.Context, user *authDomain.User) {
+	if _, err := l.socialService.ClaimEmailInvitations(ctx, user.ID, user.Email); err != nil {
+		l.logger.WithContext(ctx).Error("Failed to claim email invitations",
+			logger.Any("userID", user.ID),
+			logger.Error(err))
+	}
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// groupTaskAssigner はタスクモジュールのグループタスク作成をグループサービスに橋渡しする
+type groupTaskAssigner struct {
+	groupService groupUseCase.GroupService
+	counter      groupUseCase.OpenTaskCounter
+}
+
+func (g *groupTaskAssigner) CanCreateGroupTask(ctx context.Context, groupID, userID string) (bool, error) {
+	gid, uid, ok := parseGroupAndUser(groupID, userID)
+	if !ok {
+		return false, nil
+	}
+	return g.groupService.CheckPermission(ctx, gid, uid, groupUseCase.ActionCreateTasks)
+}
+
+func (g *groupTaskAssigner) PickAssignee(ctx context.Context, groupID, requesterID string) (string, error) {
+	gid, uid, ok := parseGroupAndUser(groupID, requesterID)
+	if !ok {
+		return "", nil
+	}
+	assigneeID, err := g.groupService.PickTaskAssignee(ctx, gid, uid, g.counter)
+	if err != nil || assigneeID == nil {
+		return "", err
+	}
+	return assigneeID.String(), nil
+}
+
+// groupTaskPermissions はタスクモジュールのグループの権限の確認をグループサービスの権限設定に橋渡しする
+// グループサービスはタスクモジュールより後に組み立てるため、groupProvider で設定する（未設定の場合は権限なしとする）
+type groupTaskPermissions struct {
+	taskUseCase.GroupTaskStore
+	groupService groupUseCase.GroupService
+}
+
+func (g *groupTaskPermissions) CheckGroupPermission(ctx context.Context, groupID, userID string, action taskDomain.GroupAction) (bool, error) {
+	gid, uid, ok := parseGroupAndUser(groupID, userID)
+	if !ok || g.groupService == nil {
+		return false, nil
+	}
+	return g.groupService.CheckPermission(ctx, gid, uid, groupUseCase.GroupAction(action))
+}
+
+func (g *groupTaskPermissions) ListGroupMemberIDsWithPermission(ctx context.Context, groupID string, action taskDomain.GroupAction) ([]string, error) {
+	gid, err := uuid.Parse(groupID)
+	if err != nil || g.groupService == nil {
+		return nil, nil
+	}
+	userIDs, err := g.groupService.ListMemberIDsWithPermission(ctx, gid, groupUseCase.GroupAction(action))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = userID.String()
+	}
+	return ids, nil
+}
+
+// groupTaskGateway は議事録・予定から作成するグループタスクをタスクモジュールで取得・作成する
+type groupTaskGateway struct {
+	taskService    *taskUseCase.TaskService
+	groupTasks     taskUseCase.GroupTaskStore
+	taskRepository taskUseCase.TaskRepository
+}
+
+func (g *groupTaskGateway) GetGroupTask(ctx context.Context, groupID uuid.UUID, taskID string) (*groupDomain.LinkedTask, error) {
+	groupIDs, err := g.groupTasks.GetGroupIDsForTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(groupIDs, groupID.String()) {
+		return nil, nil
+	}
+
+	task, err := g.taskRepository.GetTaskByID(ctx, taskID)
+	if errors.Is(err, taskUseCase.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &groupDomain.LinkedTask{
+		ID:              task.ID,
+		Title:           task.Title,
+		Status:          string(task.Status),
+		EstimateMinutes: task.EstimateMinutes,
+	}, nil
+}
+
+func (g *groupTaskGateway) CreateGroupTask(ctx context.Context, input groupUseCase.GroupTaskInput) (string, error) {
+	createInput := taskUseCase.CreateGroupTaskInput{
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    taskDomain.PriorityMedium,
+		GroupID:     input.GroupID.String(),
+		CreatedBy:   input.CreatedBy.String(),
+		// 担当者を指定しない場合は自動割り当てせず担当者なしで作成する
+		SkipAutoAssign: input.AssigneeID == nil,
+	}
+	if input.AssigneeID != nil {
+		createInput.AssigneeID = input.AssigneeID.String()
+	}
+	task, err := g.taskService.CreateGroupTask(ctx, createInput)
+	if err != nil {
+		return "", err
+	}
+
+	if input.StartDate != nil || input.DueDate != nil {
+		if _, err := g.taskService.UpdateTaskSchedule(ctx, task.ID, createInput.CreatedBy, input.StartDate, input.DueDate, false); err != nil {
+			return "", err
+		}
+	}
+	if input.EstimateMinutes != nil {
+		if _, err := g.taskService.UpdateTaskEstimate(ctx, task.ID, createInput.CreatedBy, input.EstimateMinutes, nil, nil); err != nil {
+			return "", err
+		}
+	}
+	return task.ID, nil
+}
+
+// taskEventLinks はグループの予定との関連付けをタスクの詳細に表示する
+type taskEventLinks struct {
+	groupService groupUseCase.GroupService
+}
+
+func (t *taskEventLinks) ListTaskEventLinks(ctx context.Context, taskID string) ([]*taskDomain.TaskEventLink, error) {
+	links, err := t.groupService.ListTaskEventLinks(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*taskDomain.TaskEventLink, len(links))
+	for i, link := range links {
+		result[i] = &taskDomain.TaskEventLink{
+			GroupID:   link.GroupID.String(),
+			EventID:   link.EventID,
+			TaskID:    link.TaskID,
+			Kind:      string(link.Kind),
+			CreatedAt: link.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// openTaskCounter は担当者が自分の分を完了していない未完了タスクを数える（最少負荷方式の自動割り当て用）
+type openTaskCounter struct {
+	taskRepository taskUseCase.TaskRepository
+}
+
+func (c *openTaskCounter) CountOpenTasks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(userIDs))
+	for _, userID := range userIDs {
+		tasks, err := c.taskRepository.GetTasksByAssignee(ctx, userID.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			if task.Status == taskDomain.TaskStatusDone {
+				continue
+			}
+			if assignee := task.FindAssignee(userID.String()); assignee == nil || !assignee.IsCompleted() {
+				counts[userID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// groupTaskCompletions はメンバーが担当者として完了したグループタスクを取得する（グループのリーダーボード用）
+type groupTaskCompletions struct {
+	groupTasks     taskUseCase.GroupTaskStore
+	taskRepository taskUseCase.TaskRepository
+}
+
+func (g *groupTaskCompletions) ListTaskCompletions(ctx context.Context, groupID uuid.UUID, userIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID][]groupDomain.TaskCompletion, error) {
+	taskIDs, err := g.groupTasks.ListGroupTaskIDs(ctx, groupID.String())
+	if err != nil {
+		return nil, err
+	}
+	inGroup := make(map[string]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		inGroup[id] = true
+	}
+
+	completions := make(map[uuid.UUID][]groupDomain.TaskCompletion, len(userIDs))
+	for _, userID := range userIDs {
+		tasks, err := g.taskRepository.GetTasksByAssignee(ctx, userID.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			if !inGroup[task.ID] {
+				continue
+			}
+			// 担当者ごとの完了日時がない場合はタスクの完了日時を使う
+			completedAt := task.CompletedAt
+			if assignee := task.FindAssignee(userID.String()); assignee != nil && assignee.CompletedAt != nil {
+				completedAt = assignee.CompletedAt
+			}
+			if completedAt == nil || completedAt.Before(from) || !completedAt.Before(to) {
+				continue
+			}
+			completions[userID] = append(completions[userID], groupDomain.TaskCompletion{
+				CompletedAt: *completedAt,
+				DueDate:     task.DueDate,
+			})
+		}
+	}
+	return completions, nil
+}
+
+func parseGroupAndUser(groupID, userID string) (uuid.UUID, uuid.UUID, bool) {
+	gid, err := uuid.Parse(groupID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return gid, uid, true
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	authGateway "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/gateway"
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// loginAlertNotifier は不審なログインをアプリ内通知とメールでユーザーに知らせる
+// 確認コードはメールでのみ送る（アプリ内通知はログイン後にしか読めないため）
+type loginAlertNotifier struct {
+	notificationUseCase notificationInput.NotificationUseCase
+	email               authGateway.LoginAlertEmailGateway
+	logger              logger.Logger
+}
+
+func (n *loginAlertNotifier) NotifyLoginAlert(ctx context.Context, alert authDomain.LoginAlert) error {
+	if alert.VerificationCode == "" {
+		n.notifyInApp(ctx, alert)
+	}
+	return n.email.SendLoginAlertEmail(ctx, alert)
+}
+
+// notifyInApp はアプリ内通知を送る（失敗してもメールは送る）
+func (n *loginAlertNotifier) notifyInApp(ctx context.Context, alert authDomain.LoginAlert) {
+	country := ""
+	if alert.Location != nil {
+		country = alert.Location.Country
+	}
+	message := fmt.Sprintf("いつもと異なるログインがありました（%s）。IPアドレス: %s。心当たりがない場合はパスワードを変更してください。",
+		authGateway.LoginAlertReasons(alert.Reasons), alert.IPAddress)
+
+	notification, err := n.notificationUseCase.CreateNotification(ctx, notificationInput.CreateNotificationInput{
+		UserID:  alert.UserID,
+		Type:    string(notificationDomain.SystemNotice),
+		Title:   "新しいログインがありました",
+		Message: message,
+		Metadata: map[string]string{
+			"alert_type": "suspicious_login",
+			"reasons":    strings.Join(alert.Reasons, ","),
+			"ip_address": alert.IPAddress,
+			"country":    country,
+		},
+		Channels: []string{"app"},
+	})
+	if err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to create login alert notification",
+			logger.Any("userID", alert.UserID), logger.Error(err))
+		return
+	}
+	if err := n.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		n.logger.WithContext(ctx).Warn("Failed to send login alert notification",
+			logger.Any("userID", alert.UserID), logger.Error(err))
+	}
+}
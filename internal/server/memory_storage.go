@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	commonStorage "github.com/hryt430/Yotei+/internal/common/infrastructure/storage"
+	analyticsMemory "github.com/hryt430/Yotei+/internal/modules/analytics/infrastructure/memory"
+	authMemory "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/memory"
+	billingMemory "github.com/hryt430/Yotei+/internal/modules/billing/infrastructure/memory"
+	featureFlagMemory "github.com/hryt430/Yotei+/internal/modules/featureflag/infrastructure/memory"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupMemory "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/memory"
+	notificationMemory "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/memory"
+	shortLinkMemory "github.com/hryt430/Yotei+/internal/modules/shortlink/infrastructure/memory"
+	socialMemory "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/memory"
+	syncMemory "github.com/hryt430/Yotei+/internal/modules/sync/infrastructure/memory"
+	takeoutMemory "github.com/hryt430/Yotei+/internal/modules/takeout/infrastructure/memory"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskMemory "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/memory"
+)
+
+// newMemoryStorage はインメモリのリポジトリを作成する（MySQL・Redisに接続しない）
+// デモ・フロントエンド開発・MySQL実装との性能比較用で、データはプロセス終了時に失われる
+func newMemoryStorage() *storage {
+	users := authMemory.NewUserRepository()
+	usernameHistory := authMemory.NewUsernameHistoryRepository()
+	groups := groupMemory.NewGroupRepository()
+	friendships := socialMemory.NewFriendshipRepository()
+
+	// モジュールをまたぐ参照（友達一覧の並び替え・オンライン状態の公開範囲）をインメモリ実装同士で接続する
+	friendships.Users = func(ctx context.Context, userID uuid.UUID) (socialMemory.UserProfile, bool) {
+		user, _ := users.FindUserByID(userID)
+		if user == nil {
+			return socialMemory.UserProfile{}, false
+		}
+		return socialMemory.UserProfile{Username: user.Username, LastLogin: user.LastLogin}, true
+	}
+	friendships.GroupPeers = groups.MemberIDs
+
+	taskRepository := taskMemory.NewTaskRepository()
+	attachments := taskMemory.NewAttachmentRepository()
+	groupTaskResolver := &memoryGroupTaskResolver{
+		groups:     groups,
+		taskGroups: make(map[string][]string),
+		groupTasks: make(map[string][]string),
+	}
+
+	return &storage{
+		userRepository:            users,
+		userValidator:             users,
+		tokenRepository:           authMemory.NewTokenRepository(),
+		securityEventRepository:   authMemory.NewSecurityEventRepository(),
+		usernameHistoryRepository: usernameHistory,
+		loginDeviceRepository:     authMemory.NewLoginDeviceRepository(),
+		apiKeyRepository:          authMemory.NewAPIKeyRepository(),
+		ssoRepository:             authMemory.NewSSORepository(),
+
+		notificationRepository:           notificationMemory.NewNotificationRepository(),
+		deadLetterRepository:             notificationMemory.NewDeadLetterRepository(),
+		notificationPreferenceRepository: notificationMemory.NewPreferenceRepository(),
+
+		taskRepository:           taskRepository,
+		statsRepository:          taskRepository,
+		escalationRuleRepository: taskMemory.NewEscalationRuleRepository(),
+		groupTaskResolver:        groupTaskResolver,
+		workloadRepository:       taskMemory.NewWorkloadRepository(),
+		commentRepository:        taskMemory.NewCommentRepository(),
+		mentionRepository:        taskMemory.NewMentionRepository(),
+		mentionDirectory:         &memoryMentionDirectory{users: users, usernameHistory: usernameHistory, friendships: friendships, groups: groups},
+		shareLinkRepository:      taskMemory.NewShareLinkRepository(),
+		milestoneRepository:      taskMemory.NewMilestoneRepository(),
+		dependencyRepository:     taskMemory.NewDependencyRepository(),
+		taskHistoryRepository:    taskMemory.NewTaskHistoryRepository(),
+		taskUsageRepository:      &memoryTaskUsage{tasks: taskRepository, groupTasks: groupTaskResolver, attachments: attachments},
+		attachmentRepository:     attachments,
+		linkPreviewRepository:    taskMemory.NewLinkPreviewRepository(),
+		weeklyReportRepository:   taskMemory.NewWeeklyReportSubscriptionRepository(),
+		dailyStatsRepository:     taskMemory.NewDailyStatsRepository(),
+		taskExportRepository:     taskRepository,
+		locationRepository:       taskMemory.NewLocationRepository(),
+		issueLinkRepository:      taskMemory.NewIssueLinkRepository(),
+		taskLinkRepository:       taskMemory.NewTaskLinkRepository(),
+		inboundEmailRepository:   taskMemory.NewInboundEmailRepository(),
+		reminderRepository:       taskMemory.NewDeferredReminderRepository(),
+
+		friendshipRepository:  friendships,
+		invitationRepository:  socialMemory.NewInvitationRepository(),
+		profileRepository:     socialMemory.NewProfileRepository(),
+		digestStateRepository: socialMemory.NewDigestStateRepository(),
+
+		groupRepository: groups,
+
+		featureFlagRepository: featureFlagMemory.NewFlagRepository(),
+
+		shortLinkRepository: shortLinkMemory.NewLinkRepository(),
+
+		dataExportRepository: takeoutMemory.NewExportRepository(),
+		dataImportRepository: takeoutMemory.NewImportRepository(),
+
+		subscriptionRepository: billingMemory.NewSubscriptionRepository(),
+
+		analyticsPreferenceRepository: analyticsMemory.NewPreferenceRepository(),
+		syncChangeRepository:          syncMemory.NewChangeRepository(),
+		syncMutationRepository:        syncMemory.NewMutationRepository(),
+
+		fileStorage: commonStorage.NewMemoryStorage(),
+	}
+}
+
+// memoryGroupTaskResolver はインメモリのグループリポジトリを参照するGroupTaskStore
+// タスクとグループの紐付けはこの構造体で保持する
+type memoryGroupTaskResolver struct {
+	groups *groupMemory.GroupRepository
+
+	mu         sync.RWMutex
+	taskGroups map[string][]string // taskID → groupID
+	groupTasks map[string][]string // groupID → taskID（紐付けた順）
+}
+
+// GetGroupIDsForTask はタスクが属するグループIDを取得する
+func (r *memoryGroupTaskResolver) GetGroupIDsForTask(ctx context.Context, taskID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.taskGroups[taskID]...), nil
+}
+
+// AddTaskToGroup はタスクをグループタスクとして紐付ける
+func (r *memoryGroupTaskResolver) AddTaskToGroup(ctx context.Context, taskID, groupID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.taskGroups[taskID] {
+		if id == groupID {
+			return nil
+		}
+	}
+	r.taskGroups[taskID] = append(r.taskGroups[taskID], groupID)
+	r.groupTasks[groupID] = append(r.groupTasks[groupID], taskID)
+	return nil
+}
+
+// ListGroupTaskIDs はグループタスクのIDを紐付けた順に取得する
+func (r *memoryGroupTaskResolver) ListGroupTaskIDs(ctx context.Context, groupID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.groupTasks[groupID]...), nil
+}
+
+// IsGroupMember はユーザーがグループのメンバーかを確認する
+func (r *memoryGroupTaskResolver) IsGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	member, err := r.member(ctx, groupID, userID)
+	return member != nil, err
+}
+
+// IsProjectGroup はグループがプロジェクトグループかを確認する
+func (r *memoryGroupTaskResolver) IsProjectGroup(ctx context.Context, groupID string) (bool, error) {
+	id, err := uuid.Parse(groupID)
+	if err != nil {
+		return false, nil
+	}
+	group, err := r.groups.GetGroupByID(ctx, id)
+	if err != nil || group == nil {
+		return false, err
+	}
+	return group.Type == groupDomain.GroupTypeProject, nil
+}
+
+func (r *memoryGroupTaskResolver) member(ctx context.Context, groupID, userID string) (*groupDomain.GroupMember, error) {
+	gid, err := uuid.Parse(groupID)
+	if err != nil {
+		return nil, nil
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil
+	}
+	return r.groups.GetMember(ctx, gid, uid)
+}
+
+// memoryMentionDirectory はインメモリのリポジトリを参照するMentionDirectory
+type memoryMentionDirectory struct {
+	users           *authMemory.UserRepository
+	usernameHistory *authMemory.UsernameHistoryRepository
+	friendships     *socialMemory.FriendshipRepository
+	groups          *groupMemory.GroupRepository
+}
+
+// ResolveUsernames はユーザー名（大文字小文字を区別しない）からユーザーIDを解決する
+// 現在のユーザーに一致しないユーザー名は、変更前のユーザー名として解決する
+func (d *memoryMentionDirectory) ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, username := range usernames {
+		user, err := d.users.FindUserByUsername(username)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			result[strings.ToLower(user.Username)] = user.ID.String()
+			continue
+		}
+		// 現在のユーザーに一致しない場合は変更前のユーザー名として解決する
+		change, err := d.usernameHistory.FindRecentUsernameChange(ctx, username, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			if user, _ := d.users.FindUserByID(change.UserID); user != nil {
+				result[strings.ToLower(username)] = user.ID.String()
+			}
+		}
+	}
+	return result, nil
+}
+
+// FilterVisibleUsers は作成者と承認済みの友達、または同じグループに所属するユーザーのみを返す
+// 作成者とブロック関係にあるユーザーは除外する
+func (d *memoryMentionDirectory) FilterVisibleUsers(ctx context.Context, authorID string, userIDs []string) ([]string, error) {
+	visible := []string{}
+	author, err := uuid.Parse(authorID)
+	if err != nil {
+		return visible, nil
+	}
+
+	groupPeers := make(map[uuid.UUID]bool)
+	for _, id := range d.groups.MemberIDs(ctx, author) {
+		groupPeers[id] = true
+	}
+
+	for _, userID := range userIDs {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			continue
+		}
+		if user, _ := d.users.FindUserByID(id); user == nil {
+			continue
+		}
+		blocked, err := d.friendships.IsBlocked(ctx, author, id)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			continue
+		}
+		friends, err := d.friendships.AreFriends(ctx, author, id)
+		if err != nil {
+			return nil, err
+		}
+		if friends || groupPeers[id] {
+			visible = append(visible, userID)
+		}
+	}
+	return visible, nil
+}
+
+// memoryTaskUsage はインメモリのタスクリポジトリとグループタスクの紐付けから使用量を集計するTaskUsageRepository
+type memoryTaskUsage struct {
+	tasks       *taskMemory.TaskRepository
+	groupTasks  *memoryGroupTaskResolver
+	attachments *taskMemory.AttachmentRepository
+}
+
+// CountOpenTasksByCreator はユーザーが作成した未完了のタスク数を取得する
+func (u *memoryTaskUsage) CountOpenTasksByCreator(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	for _, status := range []taskDomain.TaskStatus{taskDomain.TaskStatusTodo, taskDomain.TaskStatusInProgress} {
+		status := status
+		_, total, err := u.tasks.ListTasks(ctx,
+			taskDomain.ListFilter{CreatedBy: &userID, Status: &status},
+			taskDomain.Pagination{Page: 1, PageSize: 1},
+			taskDomain.SortOptions{})
+		if err != nil {
+			return 0, err
+		}
+		count += int64(total)
+	}
+	return count, nil
+}
+
+// CountOpenGroupTasks はグループタスクのうち未完了のものの数を取得する
+func (u *memoryTaskUsage) CountOpenGroupTasks(ctx context.Context, groupID string) (int64, error) {
+	taskIDs, err := u.groupTasks.ListGroupTaskIDs(ctx, groupID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, taskID := range taskIDs {
+		task, err := u.tasks.GetTaskByID(ctx, taskID)
+		if err != nil {
+			return 0, err
+		}
+		if task != nil && task.Status != taskDomain.TaskStatusDone {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SumAttachmentBytesByUploader はユーザーがアップロードした添付ファイルの合計サイズを取得する
+func (u *memoryTaskUsage) SumAttachmentBytesByUploader(ctx context.Context, userID string) (int64, error) {
+	return u.attachments.SumAttachmentBytesByUploader(ctx, userID)
+}
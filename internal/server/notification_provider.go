@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/pkg/logger"
+
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationGateway "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/gateway"
+	notificationMessaging "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/messaging"
+	"github.com/hryt430/Yotei+/internal/modules/notification/interface/websocket"
+	notificationUseCase "github.com/hryt430/Yotei+/internal/modules/notification/usecase"
+	notificationOutput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/output"
+)
+
+// notificationProvider は通知・WebSocketハブ・メッセージブローカーを組み立てる
+var notificationProvider = provider{
+	name:     "notification",
+	requires: []string{"storage", "sync"},
+	provide: func(w *wiring) error {
+		cfg, log := w.cfg, w.log
+
+		// 通知の変更はオフライン同期用の変更フィードにも記録する
+		notificationRepository := &syncNotificationRepository{
+			NotificationRepository: w.repos.notificationRepository,
+			changes:                w.deps.SyncService,
+			logger:                 log,
+		}
+
+		// WebSocketハブの初期化
+		wsHub := websocket.NewHub(log)
+
+		// Notification gateways
+		var appNotificationGateway notificationOutput.AppNotificationGateway = notificationGateway.NewAppNotificationGateway(cfg, notificationRepository, wsHub, log)
+		var lineNotificationGateway notificationOutput.LineNotificationGateway = notificationGateway.NewLineGateway(cfg, w.lineBreaker, log)
+
+		// **通知ユースケース（統一されたUserValidatorを使用）**
+		w.deps.NotificationUseCase = notificationUseCase.NewNotificationUseCaseWithOptions(
+			notificationRepository,
+			appNotificationGateway,
+			lineNotificationGateway,
+			w.userValidator, // 統一されたUserValidatorを使用
+			notificationUseCase.Options{
+				Grouping:        notificationGroupingPolicy(cfg, log),
+				MaxAttempts:     cfg.Notification.DeliveryAttempts,
+				RetryBackoff:    notificationRetryBackoff(cfg, log),
+				DeadLetters:     w.repos.deadLetterRepository,
+				Admins:          &notificationAdminDirectory{users: w.repos.userRepository},
+				DeadLetterAlert: notificationDeadLetterAlertPolicy(cfg, log),
+			},
+			log,
+		)
+		// ユーザーごとの通知の設定（未対応の申請・招待のリマインダーの頻度など）
+		w.deps.NotificationPreferenceService = notificationUseCase.NewPreferenceService(w.repos.notificationPreferenceRepository, log)
+		w.deps.WSHub = wsHub
+		w.deps.MessageBroker = notificationMessaging.NewInMemoryMessageBroker(log)
+
+		// WebSocketハブ（contextのキャンセルで停止）
+		w.lifecycle.Go("websocket-hub", wsHub.Run, 5*time.Second)
+		// メッセージブローカー（後に登録するワーカーの停止後に閉じる）
+		w.lifecycle.OnStop("message-broker", func(context.Context) error {
+			return w.deps.MessageBroker.Close()
+		}, 0)
+		return nil
+	},
+}
+
+// notificationGroupingPolicy は設定から通知まとめのポリシーを作成する
+// 設定が不正な場合はデフォルトのウィンドウを使用する
+func notificationGroupingPolicy(cfg *config.Config, log logger.Logger) notificationDomain.GroupingPolicy {
+	policy := notificationDomain.DefaultGroupingPolicy()
+	if cfg.Notification.GroupMaxSize > 0 {
+		policy.MaxGroupSize = cfg.Notification.GroupMaxSize
+	}
+
+	windows, err := notificationDomain.ParseGroupWindows(cfg.Notification.GroupWindows)
+	if err != nil {
+		log.Warn("Invalid NOTIFICATION_GROUP_WINDOWS, using defaults", logger.Error(err))
+		return policy
+	}
+	for eventType, window := range windows {
+		policy.Windows[eventType] = window
+	}
+	return policy
+}
+
+// notificationRetryBackoff は設定から通知の再試行の間隔を読み込む
+func notificationRetryBackoff(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Notification.RetryBackoff); err == nil && d >= 0 {
+		return d
+	} else if cfg.Notification.RetryBackoff != "" {
+		log.Warn("Invalid NOTIFICATION_RETRY_BACKOFF, using default", logger.Any("value", cfg.Notification.RetryBackoff))
+	}
+	return notificationUseCase.DefaultRetryBackoff
+}
+
+// notificationDeadLetterAlertPolicy は設定から配信不能の割合を管理者に知らせる条件を読み込む
+func notificationDeadLetterAlertPolicy(cfg *config.Config, log logger.Logger) notificationDomain.DeadLetterAlertPolicy {
+	policy := notificationDomain.DeadLetterAlertPolicy{
+		Threshold:     float64(cfg.Notification.DeadLetterAlertPercent) / 100,
+		MinDeliveries: cfg.Notification.DeadLetterAlertMinDeliveries,
+		Window:        notificationUseCase.DefaultDeadLetterAlertWindow,
+	}
+	if d, err := time.ParseDuration(cfg.Notification.DeadLetterAlertWindow); err == nil && d > 0 {
+		policy.Window = d
+	} else if cfg.Notification.DeadLetterAlertWindow != "" {
+		log.Warn("Invalid NOTIFICATION_DEAD_LETTER_ALERT_WINDOW, using default", logger.Any("value", cfg.Notification.DeadLetterAlertWindow))
+	}
+	return policy
+}
+
+// notificationAdminDirectory は配信不能の割合を知らせる管理者を認証モジュールのユーザーから取得する
+type notificationAdminDirectory struct {
+	users userService.IUserRepository
+}
+
+// ListAdminIDs は有効な管理者のユーザーIDを返す
+func (d *notificationAdminDirectory) ListAdminIDs(ctx context.Context) ([]string, error) {
+	users, err := d.users.FindUsers("")
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, user := range users {
+		if user.Role == authDomain.RoleAdmin && user.IsActive() {
+			ids = append(ids, user.ID.String())
+		}
+	}
+	return ids, nil
+}
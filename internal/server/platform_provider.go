@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/undo"
+	"github.com/hryt430/Yotei+/pkg/logger"
+
+	adminUseCase "github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+	analyticsDomain "github.com/hryt430/Yotei+/internal/modules/analytics/domain"
+	analyticsMessaging "github.com/hryt430/Yotei+/internal/modules/analytics/infrastructure/messaging"
+	analyticsSink "github.com/hryt430/Yotei+/internal/modules/analytics/infrastructure/sink"
+	analyticsUseCase "github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+	billingDomain "github.com/hryt430/Yotei+/internal/modules/billing/domain"
+	billingGateway "github.com/hryt430/Yotei+/internal/modules/billing/infrastructure/gateway"
+	billingUseCase "github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+	quotaDomain "github.com/hryt430/Yotei+/internal/modules/quota/domain"
+	quotaUseCase "github.com/hryt430/Yotei+/internal/modules/quota/usecase"
+	syncMessaging "github.com/hryt430/Yotei+/internal/modules/sync/infrastructure/messaging"
+	syncUseCase "github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+)
+
+// syncProvider はオフライン同期用の変更フィード（タスク・友達関係・グループ・通知の変更をユーザーごとに記録する）を組み立てる
+// 各モジュールのプロバイダーは変更の記録先として Dependencies.SyncService を設定する
+var syncProvider = provider{
+	name:     "sync",
+	requires: []string{"storage"},
+	provide: func(w *wiring) error {
+		syncService := syncUseCase.NewSyncService(w.repos.syncChangeRepository, w.repos.syncMutationRepository, syncChangeRetention(w.cfg, w.log), w.log)
+		w.deps.SyncService = syncService
+
+		// 変更フィードの整理ワーカー（SYNC_CHANGE_RETENTION=0の場合は削除しない）
+		if syncService.Retention > 0 {
+			w.deps.SyncPruneWorker = syncMessaging.NewPruneWorker(syncService, time.Hour, w.log)
+			w.lifecycle.Add("sync-prune-worker", w.deps.SyncPruneWorker, 0)
+		}
+		return nil
+	},
+}
+
+// quotaProvider は使用量の上限（タスク・グループ・招待・添付ファイルの作成時に確認する）を組み立てる
+var quotaProvider = provider{
+	name:     "quota",
+	requires: []string{"storage", "task", "social", "group"},
+	provide: func(w *wiring) error {
+		quotaService := quotaUseCase.NewQuotaService(
+			quotaLimits("QUOTA_USER_LIMITS", w.cfg.Quota.UserLimits, w.log),
+			quotaLimits("QUOTA_GROUP_LIMITS", w.cfg.Quota.GroupLimits, w.log),
+			w.log,
+		)
+		quotaService.Members = w.repos.groupTaskResolver
+		registerQuotaCounters(quotaService, w.repos.taskUsageRepository, w.repos.groupRepository, w.repos.invitationRepository)
+
+		w.taskService.Quotas = quotaService
+		w.deps.GroupService.SetQuotaChecker(quotaService)
+		if impl, ok := w.socialServiceImpl(); ok {
+			impl.SetQuotaChecker(quotaService)
+		}
+		w.deps.ShareService.Entitlements = quotaService
+		w.deps.AttachmentService.Quotas = quotaService
+
+		w.deps.QuotaService = quotaService
+		return nil
+	},
+}
+
+// billingProvider は課金（STRIPE_SECRET_KEYが設定されている場合のみ、契約プランの上限・機能を適用する）を組み立てる
+var billingProvider = provider{
+	name:     "billing",
+	requires: []string{"storage", "quota"},
+	provide: func(w *wiring) error {
+		cfg := w.cfg
+		if !cfg.BillingEnabled() {
+			return nil
+		}
+
+		billingService := billingUseCase.NewBillingService(
+			w.repos.subscriptionRepository,
+			billingGateway.NewStripeGateway(cfg.Billing.StripeSecretKey, cfg.Billing.StripeWebhookSecret),
+			billingUseCase.CheckoutSettings{
+				Prices: map[string]string{
+					billingDomain.PlanPro:  cfg.Billing.StripePricePro,
+					billingDomain.PlanTeam: cfg.Billing.StripePriceTeam,
+				},
+				SuccessURL: cfg.Billing.SuccessURL,
+				CancelURL:  cfg.Billing.CancelURL,
+			},
+			w.log,
+		)
+		w.deps.QuotaService.Plans = &billingPlanResolver{billing: billingService}
+		w.deps.BillingService = billingService
+		return nil
+	},
+}
+
+// analyticsProvider は利用状況の分析（ANALYTICS_SINKが設定されている場合のみイベントを記録する）を組み立てる
+var analyticsProvider = provider{
+	name:     "analytics",
+	requires: []string{"storage", "auth", "task"},
+	provide: func(w *wiring) error {
+		cfg, log := w.cfg, w.log
+
+		sink, err := newAnalyticsSink(cfg)
+		if err != nil {
+			log.Error("Failed to set up analytics sink, analytics events will not be recorded", logger.Error(err))
+			sink = nil
+		}
+		analyticsService := analyticsUseCase.NewAnalyticsService(
+			w.repos.analyticsPreferenceRepository,
+			sink,
+			analyticsSampling(cfg, log),
+			analyticsIDKey(cfg),
+			cfg.Analytics.BufferSize,
+			log,
+		)
+		w.deps.AnalyticsService = analyticsService
+		if !analyticsService.Enabled() {
+			return nil
+		}
+
+		w.taskService.Analytics = analyticsService
+		w.deps.ShareService.Analytics = analyticsService
+		w.authRepository.Analytics = analyticsService
+
+		// 分析イベントの書き出しワーカー（停止時に残っているイベントを書き出すため長めに待つ）
+		w.deps.AnalyticsWorker = analyticsMessaging.NewFlushWorker(analyticsService, analyticsFlushInterval(cfg, log), log)
+		w.lifecycle.Add("analytics-flush-worker", w.deps.AnalyticsWorker, 15*time.Second)
+		return nil
+	},
+}
+
+// adminProvider は管理者ダッシュボード（集計クエリがあるMySQLストレージのみ）とアカウントの統合（SQLストレージのみ）を組み立てる
+var adminProvider = provider{
+	name:     "admin",
+	requires: []string{"storage", "auth"},
+	provide: func(w *wiring) error {
+		if w.repos.adminMetricsRepository != nil {
+			w.deps.AdminService = adminUseCase.NewAdminService(w.repos.adminMetricsRepository, w.log)
+		}
+		if w.repos.accountMergeRepository != nil {
+			w.deps.AccountMergeService = adminUseCase.NewAccountMergeService(
+				w.repos.accountMergeRepository,
+				&accountMergeDirectory{userService: w.userService},
+				w.log,
+			)
+		}
+		return nil
+	},
+}
+
+// undoProvider は削除操作の取り消し（取り消し期間が過ぎるまで削除を遅らせる）を組み立てる
+// 停止時は取り消し期間中の削除をすぐに実行するため、他のバックグラウンドの処理より後に登録する
+var undoProvider = provider{
+	name:     "undo",
+	requires: []string{"task", "social", "group"},
+	provide: func(w *wiring) error {
+		undoQueue := undo.NewQueue(undoWindow(w.cfg, w.log), w.log)
+		w.deps.UndoQueue = undoQueue
+		if undoQueue.Window() > 0 {
+			w.taskService.UndoScheduler = undoQueue
+			if impl, ok := w.socialServiceImpl(); ok {
+				impl.SetUndoScheduler(undoQueue)
+			}
+			w.deps.GroupService.SetUndoScheduler(undoQueue)
+		}
+
+		// 取り消し期間中の削除操作（ワーカー・ブローカーより先に実行する）
+		w.lifecycle.OnStop("undo-queue", func(context.Context) error {
+			undoQueue.Flush()
+			return nil
+		}, 0)
+		return nil
+	},
+}
+
+// quotaLimits は設定から使用量の上限を読み込む（不正な場合は上限なしで起動する）
+func quotaLimits(key, spec string, log logger.Logger) quotaDomain.Limits {
+	limits, err := quotaDomain.ParseLimits(spec)
+	if err != nil {
+		log.Warn("Invalid "+key+", ignoring", logger.Any("value", spec), logger.Error(err))
+		return nil
+	}
+	return limits
+}
+
+// newAnalyticsSink は設定から分析イベントの書き出し先を作成する（ANALYTICS_SINK未設定の場合はnil）
+func newAnalyticsSink(cfg *config.Config) (analyticsUseCase.Sink, error) {
+	switch cfg.Analytics.Sink {
+	case "":
+		return nil, nil
+	case config.AnalyticsSinkFile:
+		return analyticsSink.NewFileSink(cfg.Analytics.FileDir)
+	case config.AnalyticsSinkS3:
+		return analyticsSink.NewS3Sink(analyticsSink.S3Config{
+			Bucket:          cfg.Analytics.S3Bucket,
+			Region:          cfg.Analytics.S3Region,
+			Prefix:          cfg.Analytics.S3Prefix,
+			Endpoint:        cfg.Analytics.S3Endpoint,
+			AccessKeyID:     cfg.Secrets.AWSAccessKeyID,
+			SecretAccessKey: cfg.Secrets.AWSSecretAccessKey,
+			SessionToken:    cfg.Secrets.AWSSessionToken,
+		})
+	case config.AnalyticsSinkBigQuery:
+		return analyticsSink.NewBigQuerySink(analyticsSink.BigQueryConfig{
+			ProjectID:       cfg.Analytics.BigQueryProject,
+			Dataset:         cfg.Analytics.BigQueryDataset,
+			Table:           cfg.Analytics.BigQueryTable,
+			CredentialsFile: cfg.Analytics.BigQueryCredentials,
+		})
+	default:
+		return nil, fmt.Errorf("%w: %s", analyticsDomain.ErrInvalidSink, cfg.Analytics.Sink)
+	}
+}
+
+// analyticsSampling は設定から分析イベントのサンプリング率を読み込む（不正な値は全件記録として扱う）
+func analyticsSampling(cfg *config.Config, log logger.Logger) analyticsDomain.Sampling {
+	sampling := analyticsDomain.Sampling{Default: 1}
+	if rate, err := analyticsDomain.ParseSampleRate(cfg.Analytics.SampleRate); err == nil {
+		sampling.Default = rate
+	} else if cfg.Analytics.SampleRate != "" {
+		log.Warn("Invalid ANALYTICS_SAMPLE_RATE, using default", logger.Any("value", cfg.Analytics.SampleRate))
+	}
+
+	rates, err := analyticsDomain.ParseSampleRates(cfg.Analytics.SampleRates)
+	if err != nil {
+		log.Warn("Invalid ANALYTICS_SAMPLE_RATES, ignoring", logger.Any("value", cfg.Analytics.SampleRates), logger.Error(err))
+		return sampling
+	}
+	sampling.Rates = rates
+	return sampling
+}
+
+// analyticsIDKey はユーザーIDの仮名化に使用する鍵を返す
+func analyticsIDKey(cfg *config.Config) []byte {
+	if cfg.Analytics.IDKey != "" {
+		return []byte(cfg.Analytics.IDKey)
+	}
+	return []byte(cfg.Security.SessionSecret)
+}
+
+// analyticsFlushInterval は設定から分析イベントを書き出す間隔を読み込む
+func analyticsFlushInterval(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Analytics.FlushInterval); err == nil && d > 0 {
+		return d
+	} else if cfg.Analytics.FlushInterval != "" {
+		log.Warn("Invalid ANALYTICS_FLUSH_INTERVAL, using default", logger.Any("value", cfg.Analytics.FlushInterval))
+	}
+	return 10 * time.Second
+}
+
+// undoWindow は削除操作を取り消せる時間を設定から読み込む（0の場合は取り消しを無効にする）
+func undoWindow(cfg *config.Config, log logger.Logger) time.Duration {
+	d, err := time.ParseDuration(cfg.Server.UndoWindow)
+	if err != nil || d < 0 {
+		if cfg.Server.UndoWindow != "" {
+			log.Warn("Invalid UNDO_WINDOW, using default", logger.Any("value", cfg.Server.UndoWindow))
+		}
+		return undo.DefaultWindow
+	}
+	return d
+}
@@ -0,0 +1,385 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/circuitbreaker"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/common/holiday"
+	"github.com/hryt430/Yotei+/internal/common/infrastructure/kvstore"
+	commonStorage "github.com/hryt430/Yotei+/internal/common/infrastructure/storage"
+	"github.com/hryt430/Yotei+/internal/common/lifecycle"
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	commonValidator "github.com/hryt430/Yotei+/internal/common/validator"
+	"github.com/hryt430/Yotei+/pkg/logger"
+
+	authRedisInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/redis"
+	authRedis "github.com/hryt430/Yotei+/internal/modules/auth/interface/redis"
+	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
+	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	taskMessaging "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/messaging"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// provider は1つのモジュールの依存関係を組み立てる
+// 組み立てた値は Dependencies と wiring の共有フィールドに設定し、バックグラウンドの処理は wiring.lifecycle に登録する
+type provider struct {
+	name     string
+	requires []string // 先に実行されている必要があるプロバイダー
+	provide  func(w *wiring) error
+}
+
+// defaultProviders はアプリケーション全体を組み立てるプロバイダー（実行順）
+// バックグラウンドの処理は登録と逆順に停止するため、取り消し期間中の削除操作は最後に登録して最初に実行する
+var defaultProviders = []provider{
+	coreProvider,
+	storageProvider,
+	syncProvider,
+	notificationProvider,
+	authProvider,
+	featureFlagProvider,
+	shortLinkProvider,
+	taskProvider,
+	socialProvider,
+	groupProvider,
+	takeoutProvider,
+	scimProvider,
+	quotaProvider,
+	billingProvider,
+	analyticsProvider,
+	adminProvider,
+	undoProvider,
+}
+
+// wiring はプロバイダー間で共有する組み立て中の状態
+type wiring struct {
+	cfg       *config.Config
+	log       logger.Logger
+	deps      *Dependencies
+	lifecycle *lifecycle.Manager
+	provided  map[string]bool
+
+	// coreProvider（外部サービスごとのサーキットブレーカー・祝日の取得元）
+	smtpBreaker    *circuitbreaker.Breaker
+	lineBreaker    *circuitbreaker.Breaker
+	discordBreaker *circuitbreaker.Breaker
+	teamsBreaker   *circuitbreaker.Breaker
+	githubBreaker  *circuitbreaker.Breaker
+	holidays       holiday.Provider
+
+	// storageProvider
+	repos         *storage
+	redisClient   *redis.Client  // Redisに接続できない場合・インメモリの場合はnil
+	embeddedStore *kvstore.Store // REDIS_DRIVER=embedded の場合のみ（Redisの代わりに使う）
+	userValidator commonDomain.UserValidator
+
+	// authProvider（Dependencies には値で設定するため、組み立て中はポインタで保持する）
+	userService    *userService.UserService
+	tokenService   *tokenService.TokenService
+	authService    *authService.AuthService
+	authRepository *AuthRepositoryImpl
+
+	// taskProvider
+	taskService          *taskUseCase.TaskService
+	eventPublisher       *taskMessaging.TaskEventPublisher
+	notificationAdapter  *taskMessaging.NotificationAdapter
+	linkPreviewService   *taskUseCase.LinkPreviewService // LINK_PREVIEW_ENABLED=falseの場合はnil
+	groupTaskPermissions *groupTaskPermissions
+
+	// socialProvider
+	blockChecker commonDomain.BlockChecker
+}
+
+// NewDependencies は依存関係を初期化します（統一インターフェース対応版）
+func NewDependencies(cfg *config.Config, log logger.Logger) (*Dependencies, error) {
+	return newDependencies(cfg, log, defaultProviders...)
+}
+
+// newDependencies は指定したプロバイダーだけで依存関係を組み立てる
+// テストでは必要なモジュールのプロバイダーだけを渡して一部を組み立てられる
+func newDependencies(cfg *config.Config, log logger.Logger, providers ...provider) (*Dependencies, error) {
+	w := &wiring{
+		cfg:       cfg,
+		log:       log,
+		deps:      &Dependencies{Logger: log, Config: cfg},
+		lifecycle: lifecycle.NewManager(lifecycle.DefaultStopTimeout, log),
+		provided:  make(map[string]bool),
+	}
+	// DISABLED_WORKERS・standaloneプロファイルで起動しないワーカー
+	w.lifecycle.Disable(cfg.GetDisabledWorkers()...)
+
+	for _, p := range providers {
+		for _, required := range p.requires {
+			if !w.provided[required] {
+				return nil, fmt.Errorf("provider %q requires %q", p.name, required)
+			}
+		}
+		if err := p.provide(w); err != nil {
+			return nil, fmt.Errorf("provider %q: %w", p.name, err)
+		}
+		w.provided[p.name] = true
+	}
+
+	// 値で保持するサービスは、他のモジュールからの設定が終わってから設定する
+	if w.userService != nil {
+		w.deps.UserService = *w.userService
+	}
+	if w.tokenService != nil {
+		w.deps.TokenService = *w.tokenService
+	}
+	if w.authService != nil {
+		w.deps.AuthService = *w.authService
+	}
+	if w.taskService != nil {
+		w.deps.TaskService = *w.taskService
+	}
+	w.deps.lifecycle = w.lifecycle
+
+	return w.deps, nil
+}
+
+// socialServiceImpl はソーシャルサービスの実装を返す（グループ参加・取り消し・上限などの設定に使用する）
+func (w *wiring) socialServiceImpl() (*socialUseCase.SocialServiceImpl, bool) {
+	impl, ok := w.deps.SocialService.(*socialUseCase.SocialServiceImpl)
+	return impl, ok
+}
+
+// coreProvider はパニックの報告先とレート制限を組み立てる
+var coreProvider = provider{
+	name: "core",
+	provide: func(w *wiring) error {
+		// パニックの報告先（SENTRY_DSN未設定の場合はログにのみ記録する）
+		var errorTracker errtrack.Tracker = errtrack.NopTracker{}
+		if w.cfg.ErrorTracker.SentryDSN != "" {
+			sentryTracker, err := errtrack.NewSentryTracker(errtrack.SentryOptions{
+				DSN:         w.cfg.ErrorTracker.SentryDSN,
+				Environment: w.cfg.Environment,
+				Release:     w.cfg.ErrorTracker.Release,
+			}, w.log)
+			if err != nil {
+				return err
+			}
+			errorTracker = sentryTracker
+		}
+		// バックグラウンドのゴルーチンのパニックも同じ報告先に送る
+		errtrack.SetDefault(errorTracker, &w.log)
+		w.deps.ErrorTracker = errorTracker
+		// 送信中の報告（最後に停止し、他のサービスの停止中のパニックも送り終える）
+		w.lifecycle.OnStop("error-tracker", errorTracker.Flush, 0)
+
+		// レート制限（RATE_LIMIT_RPSは再起動せずに変更できる）
+		w.deps.RateLimiter = middleware.NewRateLimiter(w.cfg.Security.RateLimitRPS)
+
+		// ルートごとの制限時間と、応答の遅い外部サービスの呼び出しを打ち切るサーキットブレーカー
+		w.deps.RouteTimeouts = routeTimeouts(w.cfg, w.log)
+		breakerOptions := circuitBreakerOptions(w.cfg, w.log)
+		w.smtpBreaker = circuitbreaker.New("smtp", breakerOptions)
+		w.lineBreaker = circuitbreaker.New("line", breakerOptions)
+		w.discordBreaker = circuitbreaker.New("discord", breakerOptions)
+		w.teamsBreaker = circuitbreaker.New("teams", breakerOptions)
+		w.githubBreaker = circuitbreaker.New("github", breakerOptions)
+
+		// 期限の自動延期・繰り返しの予定・カレンダーで使う祝日
+		w.holidays = holidayProvider(w.cfg, w.log)
+		return nil
+	},
+}
+
+// holidayProvider は設定から祝日の取得元を読み込む（HOLIDAY_COUNTRYが不正な場合は日本の祝日を使う）
+func holidayProvider(cfg *config.Config, log logger.Logger) holiday.Provider {
+	holidays, err := holiday.NewProvider(cfg.Calendar.HolidayCountry)
+	if err != nil {
+		log.Warn("Invalid HOLIDAY_COUNTRY, using default", logger.Any("value", cfg.Calendar.HolidayCountry))
+		return holiday.Japan()
+	}
+	return holidays
+}
+
+// routeTimeouts は設定からルートごとの制限時間を読み込む（ROUTE_TIMEOUTSが不正な場合は全てのルートに既定の制限時間を使う）
+func routeTimeouts(cfg *config.Config, log logger.Logger) *middleware.RouteTimeouts {
+	defaultTimeout, err := time.ParseDuration(cfg.Server.RequestTimeout)
+	if err != nil || defaultTimeout < 0 {
+		if cfg.Server.RequestTimeout != "" {
+			log.Warn("Invalid REQUEST_TIMEOUT, using default", logger.Any("value", cfg.Server.RequestTimeout))
+		}
+		defaultTimeout = middleware.DefaultRequestTimeout
+	}
+
+	timeouts, err := middleware.ParseRouteTimeouts(defaultTimeout, cfg.Server.RouteTimeouts)
+	if err != nil {
+		log.Warn("Invalid ROUTE_TIMEOUTS, using default", logger.Error(err))
+		timeouts, _ = middleware.ParseRouteTimeouts(defaultTimeout, "")
+	}
+	return timeouts
+}
+
+// circuitBreakerOptions は設定から外部サービスのサーキットブレーカーの設定を読み込む（不正な値は既定値を使う）
+func circuitBreakerOptions(cfg *config.Config, log logger.Logger) circuitbreaker.Options {
+	opts := circuitbreaker.Options{FailureThreshold: cfg.External.CircuitBreakerThreshold}
+	if d, err := time.ParseDuration(cfg.External.CircuitBreakerCooldown); err == nil && d > 0 {
+		opts.Cooldown = d
+	} else if cfg.External.CircuitBreakerCooldown != "" {
+		log.Warn("Invalid CIRCUIT_BREAKER_COOLDOWN, using default", logger.Any("value", cfg.External.CircuitBreakerCooldown))
+	}
+	if d, err := time.ParseDuration(cfg.External.ExternalCallTimeout); err == nil && d > 0 {
+		opts.CallTimeout = d
+	} else if cfg.External.ExternalCallTimeout != "" {
+		log.Warn("Invalid EXTERNAL_CALL_TIMEOUT, using default", logger.Any("value", cfg.External.ExternalCallTimeout))
+	}
+	return opts
+}
+
+// storageDrivers は STORAGE_DRIVER ごとのリポジトリの組み立て
+// 保存先を追加する場合はここに登録する（config.Validate の対応も必要）
+var storageDrivers = map[string]func(w *wiring) (*storage, error){
+	config.StorageDriverMySQL:    sqlStorageDriver(config.StorageDriverMySQL),
+	config.StorageDriverPostgres: sqlStorageDriver(config.StorageDriverPostgres),
+	config.StorageDriverSQLite:   sqlStorageDriver(config.StorageDriverSQLite),
+	// インメモリの場合はデータベース・Redisに接続しない
+	config.StorageDriverMemory: func(w *wiring) (*storage, error) {
+		w.log.Warn("Using in-memory storage, data will be lost on shutdown")
+		return newMemoryStorage(), nil
+	},
+}
+
+// sqlStorageDriver はSQLデータベース（MySQL・PostgreSQL・SQLite）のリポジトリの組み立てを返す
+// REDIS_DRIVER=embedded の場合はRedisの代わりにプロセス内のストアを使う
+// SQLiteの場合は単一のバイナリで動くよう、Redisサーバーには接続しない
+func sqlStorageDriver(driver string) func(w *wiring) (*storage, error) {
+	return func(w *wiring) (*storage, error) {
+		var tokenCache authRedis.TokenCache
+		switch {
+		case w.cfg.UsesEmbeddedRedis():
+			store, err := newEmbeddedStore(w)
+			if err != nil {
+				return nil, err
+			}
+			w.embeddedStore = store
+			tokenCache = authRedisInfra.NewEmbeddedTokenCache(store)
+		case driver != config.StorageDriverSQLite:
+			w.redisClient = newRedisClient(w.cfg, w.log)
+			if w.redisClient != nil {
+				tokenCache = authRedisInfra.NewRedisTokenCache(w.redisClient)
+			}
+		}
+		if tokenCache == nil {
+			// Redisを使えない場合もブラックリストを無効にしないよう、このプロセスのメモリに保持する（再起動で消え、インスタンス間で共有されない）
+			w.log.Warn("Redis not available, keeping token blacklist in process memory")
+			store, err := kvstore.Open("")
+			if err != nil {
+				return nil, fmt.Errorf("failed to open in-memory token blacklist: %w", err)
+			}
+			// ファイルには書き出さず、期限切れのキーの削除だけを定期的に行う
+			w.lifecycle.Go("token-blacklist-store", store.Run(kvstore.DefaultSaveInterval), 0)
+			tokenCache = authRedisInfra.NewEmbeddedTokenCache(store)
+		}
+		repos := newSQLStorage(driver, tokenCache, w.log)
+
+		// Redisへの問い合わせを認証が必要なリクエストのたびに行わないよう、確認結果をプロセス内にキャッシュする
+		if w.redisClient != nil {
+			repos.tokenRepository = authRedis.NewCachedTokenRepository(
+				repos.tokenRepository,
+				w.cfg.JWT.BlacklistCacheSize,
+				blacklistNegativeTTL(w.cfg, w.log),
+			)
+		}
+
+		fileStorage, err := commonStorage.NewLocalStorage(w.cfg.Storage.FileDir)
+		if err != nil {
+			return nil, err
+		}
+		repos.fileStorage = fileStorage
+		return repos, nil
+	}
+}
+
+// storageProvider は STORAGE_DRIVER に応じてリポジトリを組み立てる
+var storageProvider = provider{
+	name: "storage",
+	provide: func(w *wiring) error {
+		driver := strings.ToLower(w.cfg.Storage.Driver)
+		if driver == "" {
+			driver = config.StorageDriverMySQL
+		}
+		newStorage, ok := storageDrivers[driver]
+		if !ok {
+			return fmt.Errorf("unsupported storage driver: %s", w.cfg.Storage.Driver)
+		}
+
+		repos, err := newStorage(w)
+		if err != nil {
+			return err
+		}
+		w.repos = repos
+
+		// **統一されたUserValidator の実装**（グループ・友達一覧などで繰り返し参照するユーザー情報を短時間キャッシュ）
+		w.userValidator = commonValidator.NewCachedUserValidator(repos.userValidator, userInfoCacheTTL(w.cfg, w.log))
+		return nil
+	},
+}
+
+// newRedisClient はRedisに接続する（接続できない場合はnilを返し、Redisなしで起動する）
+func newRedisClient(cfg *config.Config, log logger.Logger) *redis.Client {
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Host + ":" + cfg.Redis.Port,
+		Password: cfg.Redis.Password,
+		DB:       0,
+	})
+
+	// Redis接続テスト
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		log.Warn("Redis connection failed, continuing without Redis", logger.Error(err))
+		// Redisが利用できない場合はnilを設定（開発環境対応）
+		return nil
+	}
+	return redisClient
+}
+
+// newEmbeddedStore はRedisの代わりに使うプロセス内のストアを開き、定期的な書き出しを登録する
+func newEmbeddedStore(w *wiring) (*kvstore.Store, error) {
+	store, err := kvstore.Open(w.cfg.Redis.EmbeddedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded redis store: %w", err)
+	}
+	w.log.Info("Using embedded store instead of Redis", logger.Any("path", w.cfg.Redis.EmbeddedPath))
+
+	interval := kvstore.DefaultSaveInterval
+	if d, err := time.ParseDuration(w.cfg.Redis.EmbeddedSaveInterval); err == nil && d > 0 {
+		interval = d
+	} else if w.cfg.Redis.EmbeddedSaveInterval != "" {
+		w.log.Warn("Invalid REDIS_EMBEDDED_SAVE_INTERVAL, using default", logger.Any("value", w.cfg.Redis.EmbeddedSaveInterval))
+	}
+	w.lifecycle.Go("embedded-redis", store.Run(interval), 0)
+	return store, nil
+}
+
+// blacklistNegativeTTL は設定から失効していないトークンの確認結果をキャッシュする期間を読み込む（0でキャッシュしない）
+func blacklistNegativeTTL(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.JWT.BlacklistNegativeTTL); err == nil && d >= 0 {
+		return d
+	} else if cfg.JWT.BlacklistNegativeTTL != "" {
+		log.Warn("Invalid TOKEN_BLACKLIST_NEGATIVE_TTL, using default", logger.Any("value", cfg.JWT.BlacklistNegativeTTL))
+	}
+	return authRedis.DefaultBlacklistNegativeTTL
+}
+
+// userInfoCacheTTL は設定からユーザー情報のキャッシュ期間を読み込む（0でキャッシュしない）
+func userInfoCacheTTL(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Storage.UserInfoCacheTTL); err == nil && d >= 0 {
+		return d
+	} else if cfg.Storage.UserInfoCacheTTL != "" {
+		log.Warn("Invalid USER_INFO_CACHE_TTL, using default", logger.Any("value", cfg.Storage.UserInfoCacheTTL))
+	}
+	return commonValidator.DefaultUserInfoCacheTTL
+}
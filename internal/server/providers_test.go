@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hryt430/Yotei+/config"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// newProviderTestConfig はインメモリのストレージを使う設定を作成する
+func newProviderTestConfig(t *testing.T) (*config.Config, logger.Logger) {
+	t.Helper()
+
+	cfg, err := config.LoadConfig("")
+	require.NoError(t, err)
+	cfg.Environment = "test"
+	cfg.Storage.Driver = config.StorageDriverMemory
+
+	return cfg, *logger.NewLogger(&logger.Config{Level: "fatal", Output: "console"})
+}
+
+func TestNewDependencies_MemoryStorage(t *testing.T) {
+	cfg, log := newProviderTestConfig(t)
+
+	deps, err := NewDependencies(cfg, log)
+	require.NoError(t, err)
+
+	assert.NotNil(t, deps.TaskService.TaskRepository)
+	assert.NotNil(t, deps.TaskService.GroupAssigner)
+	assert.NotNil(t, deps.TaskService.Quotas)
+	assert.NotNil(t, deps.UserService.BlockChecker)
+	assert.NotNil(t, deps.SocialService)
+	assert.NotNil(t, deps.GroupService)
+	assert.NotNil(t, deps.SCIMService)
+	assert.NotNil(t, deps.QuotaService)
+	assert.NotNil(t, deps.UndoQueue)
+	assert.NotNil(t, deps.TaskScheduler)
+	assert.NotNil(t, deps.ErrorTracker)
+	// 集計クエリはMySQLのみ
+	assert.Nil(t, deps.AdminService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartBackgroundServices(ctx, deps)
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer stopCancel()
+	assert.NoError(t, StopBackgroundServices(stopCtx, deps))
+}
+
+func TestNewDependencies_PartialWiring(t *testing.T) {
+	cfg, log := newProviderTestConfig(t)
+
+	deps, err := newDependencies(cfg, log,
+		coreProvider, storageProvider, syncProvider, notificationProvider, authProvider, featureFlagProvider, taskProvider)
+	require.NoError(t, err)
+
+	// 組み立てたモジュールだけが設定される
+	assert.NotNil(t, deps.TaskService.TaskRepository)
+	assert.NotNil(t, deps.NotificationUseCase)
+	assert.Nil(t, deps.TaskService.GroupAssigner)
+	assert.Nil(t, deps.SocialService)
+	assert.Nil(t, deps.GroupService)
+	assert.Nil(t, deps.QuotaService)
+
+	user, err := deps.UserService.CreateUser(authDomain.NewUser("partial@example.com", "partial", "Passw0rd!"))
+	require.NoError(t, err)
+	task, err := deps.TaskService.CreateTask(context.Background(), "partial wiring", "", taskDomain.PriorityMedium, taskDomain.CategoryWork, user.ID.String())
+	require.NoError(t, err)
+
+	got, err := deps.TaskService.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "partial wiring", got.Title)
+}
+
+func TestNewDependencies_MissingRequirement(t *testing.T) {
+	cfg, log := newProviderTestConfig(t)
+
+	_, err := newDependencies(cfg, log, coreProvider, storageProvider, taskProvider)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `provider "task" requires "sync"`)
+}
+
+func TestNewDependencies_UnsupportedStorageDriver(t *testing.T) {
+	cfg, log := newProviderTestConfig(t)
+	cfg.Storage.Driver = "cassandra"
+
+	_, err := newDependencies(cfg, log, coreProvider, storageProvider)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported storage driver: cassandra")
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/hryt430/Yotei+/internal/common/middleware"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	authMiddleware "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/middleware"
+	taskController "github.com/hryt430/Yotei+/internal/modules/task/interface/controller"
+)
+
+// quickRateLimitWindow はショートカット向けAPIのレート制限（QUICK_RATE_LIMIT）の単位時間
+const quickRateLimitWindow = time.Minute
+
+// setupQuickRoutes は音声アシスタント・ショートカット向けの最小限のAPIをセットアップする
+// ユーザーが発行したquickスコープのAPIキーで認証し、キーごとに1分あたりのリクエスト数を制限する
+func setupQuickRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.APIKeyService == nil || deps.TodayService == nil {
+		deps.Logger.Warn("API key or today service not available, skipping quick routes")
+		return
+	}
+
+	quickCtrl := taskController.NewQuickController(deps.TaskService, deps.TodayService)
+	limiter := middleware.NewWindowRateLimiter(deps.Config.Security.QuickRateLimit, quickRateLimitWindow)
+
+	quickRoutes := router.Group("/quick")
+	quickRoutes.Use(
+		authMiddleware.APIKeyRequired(deps.APIKeyService, authDomain.APIKeyScopeQuick, taskController.AbortQuickError),
+		apiKeyRateLimit(limiter, quickRateLimitWindow, taskController.AbortQuickError),
+		apiKeyActor(deps, taskController.AbortQuickError),
+	)
+	{
+		quickRoutes.POST("/task", quickCtrl.AddTask)
+		quickRoutes.GET("/today", quickCtrl.GetToday)
+	}
+}
+
+// apiKeyRateLimit はAPIキーごとにリクエスト数を制限するミドルウェア
+// （authMiddleware.APIKeyRequiredの後に使う）
+func apiKeyRateLimit(limiter *middleware.RateLimiter, window time.Duration, abort authMiddleware.ErrorHandler) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key, _ := ctx.MustGet(authMiddleware.APIKeyContextKey).(*authDomain.APIKey)
+		if key == nil || limiter.Allow(key.ID) {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("Retry-After", strconv.Itoa(int(window.Seconds())))
+		abort(ctx, http.StatusTooManyRequests, "Too many requests")
+	}
+}
+
+// apiKeyActor はAPIキーを発行したユーザーをタスクを操作するユーザーとして設定するミドルウェア
+// 無効化・削除されたユーザーのキーは拒否する（authMiddleware.APIKeyRequiredの後に使う）
+func apiKeyActor(deps *Dependencies, abort authMiddleware.ErrorHandler) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key, ok := ctx.MustGet(authMiddleware.APIKeyContextKey).(*authDomain.APIKey)
+		if !ok {
+			abort(ctx, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		userID, err := uuid.Parse(key.CreatedBy)
+		if err != nil {
+			abort(ctx, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+
+		var user *authDomain.User
+		if deps.ProfileCache != nil {
+			user, err = deps.ProfileCache.FindUser(ctx.Request.Context(), userID)
+		} else {
+			user, err = deps.UserService.FindUserByID(userID)
+		}
+		if err != nil {
+			abort(ctx, http.StatusInternalServerError, "Failed to load user")
+			return
+		}
+		if user == nil || !user.IsActive() {
+			abort(ctx, http.StatusUnauthorized, "User is not active")
+			return
+		}
+
+		ctx.Set("user_id", key.CreatedBy)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	quotaUseCase "github.com/hryt430/Yotei+/internal/modules/quota/usecase"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// invitationQuotaWindow は1日あたりの招待数を数える期間（直近24時間）
+const invitationQuotaWindow = 24 * time.Hour
+
+// usageCounterFunc は関数をUsageCounterとして使うためのアダプタ
+type usageCounterFunc func(ctx context.Context, subjectID string) (int64, error)
+
+func (f usageCounterFunc) CountUsage(ctx context.Context, subjectID string) (int64, error) {
+	return f(ctx, subjectID)
+}
+
+// registerQuotaCounters は各モジュールのリポジトリで使用量を数えるUsageCounterを登録する
+func registerQuotaCounters(
+	quotas *quotaUseCase.QuotaService,
+	taskUsage taskUseCase.TaskUsageRepository,
+	groups groupUseCase.GroupRepository,
+	invitations socialUseCase.InvitationRepository,
+) {
+	user, group := commonDomain.QuotaScopeUser, commonDomain.QuotaScopeGroup
+
+	quotas.SetCounter(user, commonDomain.QuotaOpenTasks, usageCounterFunc(taskUsage.CountOpenTasksByCreator))
+	quotas.SetCounter(group, commonDomain.QuotaOpenTasks, usageCounterFunc(taskUsage.CountOpenGroupTasks))
+	quotas.SetCounter(user, commonDomain.QuotaAttachmentBytes, usageCounterFunc(taskUsage.SumAttachmentBytesByUploader))
+
+	quotas.SetCounter(user, commonDomain.QuotaGroups, usageCounterFunc(func(ctx context.Context, subjectID string) (int64, error) {
+		ownerID, err := uuid.Parse(subjectID)
+		if err != nil {
+			return 0, err
+		}
+		_, total, err := groups.ListGroupsByOwner(ctx, ownerID, commonDomain.Pagination{Page: 1, PageSize: 1})
+		return int64(total), err
+	}))
+
+	quotas.SetCounter(user, commonDomain.QuotaInvitationsPerDay, usageCounterFunc(func(ctx context.Context, subjectID string) (int64, error) {
+		inviterID, err := uuid.Parse(subjectID)
+		if err != nil {
+			return 0, err
+		}
+		return invitations.CountInvitationsSince(ctx, inviterID, time.Now().Add(-invitationQuotaWindow))
+	}))
+	quotas.SetCounter(group, commonDomain.QuotaInvitationsPerDay, usageCounterFunc(func(ctx context.Context, subjectID string) (int64, error) {
+		groupID, err := uuid.Parse(subjectID)
+		if err != nil {
+			return 0, err
+		}
+		return invitations.CountTargetInvitationsSince(ctx, groupID, time.Now().Add(-invitationQuotaWindow))
+	}))
+}
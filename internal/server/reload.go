@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+
+	"github.com/hryt430/Yotei+/config"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	featureFlagDomain "github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	quotaDomain "github.com/hryt430/Yotei+/internal/modules/quota/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// ConfigFile は再起動せずに変更できる設定を監視するファイル
+const ConfigFile = ".env"
+
+// Reloader は設定ファイルとシークレットの変更を実行中のサービスに反映する
+// ログレベル・レート制限・機能フラグの既定値・使用量の上限は設定ファイルの変更時（またはSIGHUP）に、
+// DB・JWTの認証情報はシークレットストアの定期的な再取得時に反映する
+type Reloader struct {
+	watcher *config.Watcher
+	secrets *config.SecretStore
+	deps    *Dependencies
+}
+
+// NewReloader は新しいReloaderを作成する
+func NewReloader(deps *Dependencies, file string) *Reloader {
+	r := &Reloader{
+		watcher: config.NewWatcher(file, deps.Config.Tunables()),
+		secrets: deps.Config.SecretStore(),
+		deps:    deps,
+	}
+	r.watcher.OnChange(r.apply)
+	return r
+}
+
+// Start は設定ファイルの監視とシークレットの定期的な再取得を開始する（ctxが終了するまで）
+func (r *Reloader) Start(ctx context.Context) {
+	log := r.deps.Logger
+
+	go func() {
+		err := r.watcher.Run(ctx, func(changed bool, err error) {
+			if err != nil {
+				log.Warn("Failed to reload config, keeping current values", logger.Error(err))
+			}
+		})
+		if err != nil {
+			log.Warn("Config file watcher not started", logger.Any("file", ConfigFile), logger.Error(err))
+		}
+	}()
+
+	if r.secrets != nil {
+		go r.secrets.Run(ctx, r.deps.Config.GetSecretsRefreshInterval(), func(changed []string, err error) {
+			if err != nil {
+				log.Warn("Failed to refresh secrets, keeping current values",
+					logger.Any("source", r.secrets.Source()), logger.Error(err))
+				return
+			}
+			if len(changed) > 0 {
+				log.Info("Secrets rotated", logger.Any("source", r.secrets.Source()), logger.Any("keys", changed))
+			}
+		})
+	}
+}
+
+// Reload は設定ファイルとシークレットを直ちに読み直す（SIGHUP用）
+func (r *Reloader) Reload(ctx context.Context) {
+	log := r.deps.Logger
+
+	if _, err := r.watcher.Reload(); err != nil {
+		log.Warn("Failed to reload config, keeping current values", logger.Error(err))
+	}
+	if r.secrets != nil {
+		changed, err := r.secrets.Refresh(ctx)
+		if err != nil {
+			log.Warn("Failed to refresh secrets, keeping current values",
+				logger.Any("source", r.secrets.Source()), logger.Error(err))
+		} else if len(changed) > 0 {
+			log.Info("Secrets rotated", logger.Any("source", r.secrets.Source()), logger.Any("keys", changed))
+		}
+	}
+}
+
+// apply は変更された設定を各サービスに反映する
+func (r *Reloader) apply(tunables config.Tunables) {
+	deps := r.deps
+
+	// ロガーはコピーしてもレベルを共有するため、全てのサービスのログに反映される
+	deps.Logger.SetLevel(tunables.LogLevel)
+
+	if deps.RateLimiter != nil {
+		deps.RateLimiter.SetRPS(tunables.RateLimitRPS)
+	}
+
+	if deps.FeatureFlagService != nil {
+		flags, err := featureFlagDomain.ParseFlagDefaults(tunables.FeatureFlags)
+		if err != nil {
+			deps.Logger.Warn("Invalid FEATURE_FLAGS, keeping current defaults",
+				logger.Any("value", tunables.FeatureFlags), logger.Error(err))
+		} else {
+			deps.FeatureFlagService.SetDefaults(flags)
+		}
+	}
+
+	if deps.QuotaService != nil {
+		applyQuotaLimits(deps, commonDomain.QuotaScopeUser, "QUOTA_USER_LIMITS", tunables.QuotaUser)
+		applyQuotaLimits(deps, commonDomain.QuotaScopeGroup, "QUOTA_GROUP_LIMITS", tunables.QuotaGroup)
+	}
+
+	deps.Logger.Info("Config reloaded",
+		logger.Any("log_level", tunables.LogLevel),
+		logger.Any("rate_limit_rps", tunables.RateLimitRPS),
+		logger.Any("feature_flags", tunables.FeatureFlags),
+		logger.Any("quota_user_limits", tunables.QuotaUser),
+		logger.Any("quota_group_limits", tunables.QuotaGroup))
+}
+
+// applyQuotaLimits は使用量の上限を置き換える（不正な場合は現在の上限のままにする）
+func applyQuotaLimits(deps *Dependencies, scope, key, spec string) {
+	limits, err := quotaDomain.ParseLimits(spec)
+	if err != nil {
+		deps.Logger.Warn("Invalid "+key+", keeping current limits",
+			logger.Any("value", spec), logger.Error(err))
+		return
+	}
+	deps.QuotaService.SetLimits(scope, limits)
+}
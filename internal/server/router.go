@@ -2,35 +2,78 @@ package server
 
 import (
 	"context"
-	"sync"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/apiversion"
+	"github.com/hryt430/Yotei+/internal/common/errtrack"
+	"github.com/hryt430/Yotei+/internal/common/lifecycle"
 	"github.com/hryt430/Yotei+/internal/common/middleware"
+	"github.com/hryt430/Yotei+/internal/common/undo"
 	"github.com/hryt430/Yotei+/pkg/logger"
+	"github.com/hryt430/Yotei+/pkg/metrics"
 
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
 	authMiddleware "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/middleware"
 	authController "github.com/hryt430/Yotei+/internal/modules/auth/interface/controller"
 	userController "github.com/hryt430/Yotei+/internal/modules/auth/interface/controller"
+	apiKeyService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/apikey"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
 	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
+	deviceService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/device"
+	ssoService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso"
 	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
 	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
 
 	notificationMessaging "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/messaging"
 	notificationController "github.com/hryt430/Yotei+/internal/modules/notification/interface/controller"
 	"github.com/hryt430/Yotei+/internal/modules/notification/interface/websocket"
+	notificationService "github.com/hryt430/Yotei+/internal/modules/notification/usecase"
 	notificationUseCase "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
 
 	taskMessaging "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/messaging"
 	taskController "github.com/hryt430/Yotei+/internal/modules/task/interface/controller"
 	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
 
+	socialMessaging "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/messaging"
 	socialController "github.com/hryt430/Yotei+/internal/modules/social/interface/controller"
 	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
 
+	groupMessaging "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/messaging"
 	groupController "github.com/hryt430/Yotei+/internal/modules/group/interface/controller"
 	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+
+	adminController "github.com/hryt430/Yotei+/internal/modules/admin/interface/controller"
+	adminUseCase "github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+
+	scimDomain "github.com/hryt430/Yotei+/internal/modules/scim/domain"
+	scimController "github.com/hryt430/Yotei+/internal/modules/scim/interface/controller"
+	scimUseCase "github.com/hryt430/Yotei+/internal/modules/scim/usecase"
+
+	featureFlagController "github.com/hryt430/Yotei+/internal/modules/featureflag/interface/controller"
+	featureFlagUseCase "github.com/hryt430/Yotei+/internal/modules/featureflag/usecase"
+	shortLinkController "github.com/hryt430/Yotei+/internal/modules/shortlink/interface/controller"
+	shortLinkUseCase "github.com/hryt430/Yotei+/internal/modules/shortlink/usecase"
+
+	quotaController "github.com/hryt430/Yotei+/internal/modules/quota/interface/controller"
+	quotaUseCase "github.com/hryt430/Yotei+/internal/modules/quota/usecase"
+
+	billingController "github.com/hryt430/Yotei+/internal/modules/billing/interface/controller"
+	billingUseCase "github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+
+	// Analytics module
+	analyticsMessaging "github.com/hryt430/Yotei+/internal/modules/analytics/infrastructure/messaging"
+	analyticsController "github.com/hryt430/Yotei+/internal/modules/analytics/interface/controller"
+	analyticsUseCase "github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+	syncMessaging "github.com/hryt430/Yotei+/internal/modules/sync/infrastructure/messaging"
+	syncController "github.com/hryt430/Yotei+/internal/modules/sync/interface/controller"
+	syncUseCase "github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+
+	// Takeout module
+	takeoutController "github.com/hryt430/Yotei+/internal/modules/takeout/interface/controller"
+	takeoutUseCase "github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
 )
 
 // Dependencies は各モジュールの依存関係を格納する構造体
@@ -38,22 +81,85 @@ type Dependencies struct {
 	AuthService         authService.AuthService
 	TokenService        tokenService.TokenService
 	UserService         userService.UserService
+	ProfileCache        *userService.ProfileCache // AUTH_USER_CACHE_TTL=0の場合はnil
+	AuditService        *auditService.AuditService
+	DeviceService       *deviceService.DeviceService
+	APIKeyService       *apiKeyService.APIKeyService
+	SSOService          *ssoService.SSOService
 	NotificationUseCase notificationUseCase.NotificationUseCase
 	TaskService         taskUseCase.TaskService
 	StatsService        *taskUseCase.TaskStatsService
+	EscalationService   *taskUseCase.EscalationService
+	WorkloadService     *taskUseCase.WorkloadService
+	MentionService      *taskUseCase.MentionService
+	ShareService        *taskUseCase.ShareService
+	MilestoneService    *taskUseCase.MilestoneService
+	TimelineService     *taskUseCase.TimelineService
+	HistoryService      *taskUseCase.HistoryService
+	AttachmentService   *taskUseCase.AttachmentService
+	CalendarService     *taskUseCase.CalendarService
+	WeeklyReportService *taskUseCase.WeeklyReportService
+	ExportService       *taskUseCase.ExportService
+	FlowService         *taskUseCase.FlowService
+	TodayService        *taskUseCase.TodayService
+	LocationService     *taskUseCase.LocationService
+	IssueLinkService    *taskUseCase.IssueLinkService
+	TaskLinkService     *taskUseCase.TaskLinkService
+	InboundEmailService *taskUseCase.InboundEmailService
+	ClipService         *taskUseCase.ClipService
+	// ユーザーごとの通知の設定
+	NotificationPreferenceService *notificationService.PreferenceService
 	// Social and Group modules
-	SocialService socialUseCase.SocialService
-	GroupService  groupUseCase.GroupService
+	SocialService   socialUseCase.SocialService
+	PresenceService *socialUseCase.PresenceService
+	ProfileService  *socialUseCase.ProfileService
+	GroupService    groupUseCase.GroupService
+	UndoQueue       *undo.Queue
+	// Admin module（MySQLストレージのみ）
+	AdminService *adminUseCase.AdminService
+	// アカウントの統合（SQLストレージのみ）
+	AccountMergeService *adminUseCase.AccountMergeService
+	// SCIM module
+	SCIMService *scimUseCase.ProvisioningService
+	// Feature flag module
+	FeatureFlagService *featureFlagUseCase.FeatureFlagService
+	// Short link module
+	ShortLinkService *shortLinkUseCase.ShortLinkService
+	// Takeout module
+	TakeoutService    *takeoutUseCase.TakeoutService
+	DataImportService *takeoutUseCase.ImportService
+	// Quota module
+	QuotaService *quotaUseCase.QuotaService
+	// Billing module（STRIPE_SECRET_KEY未設定の場合はnil）
+	BillingService *billingUseCase.BillingService
+	// Analytics module
+	AnalyticsService *analyticsUseCase.AnalyticsService
+	// Sync module
+	SyncService *syncUseCase.SyncService
 	// Infrastructure
-	WSHub         *websocket.Hub
-	TaskScheduler *taskMessaging.TaskDueNotificationScheduler
-	MessageBroker notificationMessaging.MessageBroker
-	Logger        logger.Logger
-	Config        *config.Config
-
-	// バックグラウンドサービス管理用
-	cancelFunc   context.CancelFunc
-	backgroundWg sync.WaitGroup
+	RateLimiter         *middleware.RateLimiter
+	RouteTimeouts       *middleware.RouteTimeouts // nilの場合はリクエストに制限時間を設定しない
+	ErrorTracker        errtrack.Tracker          // nilの場合はパニックをログにのみ記録する
+	WSHub               *websocket.Hub
+	TaskScheduler       *taskMessaging.TaskDueNotificationScheduler
+	EscalationWorker    *taskMessaging.EscalationWorker
+	WeeklyReportWorker  *taskMessaging.WeeklyReportWorker
+	DailyStatsWorker    *taskMessaging.DailyStatsWorker
+	ThumbnailWorker     *taskMessaging.ThumbnailWorker
+	LinkPreviewWorker   *taskMessaging.LinkPreviewWorker // LINK_PREVIEW_ENABLED=falseの場合はnil
+	ReminderWorker      *taskMessaging.ReminderDigestWorker
+	SocialCleanupWorker *socialMessaging.CleanupWorker
+	SocialDigestWorker  *socialMessaging.DigestWorker
+	EventReminderWorker *groupMessaging.EventReminderWorker
+	ChatSummaryWorker   *groupMessaging.ChatSummaryWorker
+	AnalyticsWorker     *analyticsMessaging.FlushWorker // ANALYTICS_SINK未設定の場合はnil
+	SyncPruneWorker     *syncMessaging.PruneWorker      // SYNC_CHANGE_RETENTION=0の場合はnil
+	MessageBroker       notificationMessaging.MessageBroker
+	Logger              logger.Logger
+	Config              *config.Config
+
+	// バックグラウンドサービス管理用（NewDependencies で各モジュールのワーカーを登録する）
+	lifecycle *lifecycle.Manager
 }
 
 // SetupRouter はAPIルーターをセットアップする
@@ -67,16 +173,28 @@ func SetupRouter(deps *Dependencies) *gin.Engine {
 	router := gin.New()
 
 	// 共通ミドルウェアの適用
-	router.Use(middleware.RecoveryMiddleware(deps.Logger))
+	router.Use(middleware.RecoveryMiddleware(deps.Logger, deps.ErrorTracker))
+	router.Use(middleware.RequestIDMiddleware())
 	router.Use(middleware.LoggerMiddleware(deps.Logger))
-	router.Use(middleware.CORSMiddleware(deps.Config))
+	if deps.RateLimiter != nil {
+		router.Use(middleware.RateLimitMiddleware(deps.RateLimiter))
+	}
+	if deps.RouteTimeouts != nil {
+		router.Use(middleware.TimeoutMiddleware(deps.RouteTimeouts))
+	}
+	// 権限エラーの監査ログ
+	if deps.AuditService != nil {
+		router.Use(authMiddleware.PermissionDeniedAudit(deps.AuditService))
+	}
+	router.Use(middleware.CompressionMiddleware())
+	router.Use(middleware.CORSMiddleware(deps.Config, clipCORSProfile(deps.Config)))
 
 	// セキュリティヘッダー
-	router.Use(middleware.SecurityHeadersMiddleware())
+	router.Use(middleware.SecurityHeadersMiddleware(deps.Config))
 
-	// Next.jsとのCSRF連携
+	// Next.jsとのCSRF連携（Cookie認証のリクエストのみ検証）
 	if deps.Config.EnableCSRF() {
-		router.Use(middleware.SetCSRFToken())
+		router.Use(middleware.SetCSRFToken(deps.Config))
 		router.Use(middleware.CSRFProtection())
 	}
 
@@ -89,12 +207,19 @@ func SetupRouter(deps *Dependencies) *gin.Engine {
 		})
 	})
 
-	// APIグループ
-	api := router.Group("/api/v1")
+	// APIグループ（バージョンごと）
+	api := apiversion.V1.Group(router)
+	apiV2 := apiversion.V2.Group(router)
 
 	// WebSocketエンドポイント（認証必要）
 	setupWebSocketRoutes(router, deps)
 
+	// SCIMエンドポイント（APIキー認証）
+	setupSCIMRoutes(router, deps)
+
+	// 短縮リンクの転送（認証不要）
+	setupShortLinkRoutes(router, api, deps)
+
 	// 各モジュールのルート設定
 	setupAuthRoutes(api, deps)
 	setupUserRoutes(api, deps)
@@ -102,6 +227,20 @@ func SetupRouter(deps *Dependencies) *gin.Engine {
 	setupTaskRoutes(api, deps)
 	setupSocialRoutes(api, deps)
 	setupGroupRoutes(api, deps)
+	setupTakeoutRoutes(api, deps)
+	setupMetricsRoutes(api, deps)
+	setupAdminRoutes(api, deps)
+	setupFeatureFlagRoutes(api, deps)
+	setupQuotaRoutes(api, deps)
+	setupBillingRoutes(api, deps)
+	setupAnalyticsRoutes(api, deps)
+	setupSyncRoutes(api, deps)
+	setupUndoRoutes(api, deps)
+	setupQuickRoutes(api, deps)
+	setupClipRoutes(api, deps)
+
+	// v2のルート設定
+	setupTaskV2Routes(apiV2, deps)
 
 	return router
 }
@@ -114,7 +253,7 @@ func setupWebSocketRoutes(router *gin.Engine, deps *Dependencies) {
 	}
 
 	// 認証ミドルウェアの初期化（notificationRoutesと同じパターン）
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
 
 	// WebSocketエンドポイント
 	wsGroup := router.Group("/ws")
@@ -126,13 +265,63 @@ func setupWebSocketRoutes(router *gin.Engine, deps *Dependencies) {
 	}
 }
 
+// setupSCIMRoutes はSCIM 2.0のプロビジョニングAPIをセットアップする
+// IdPはscimスコープのAPIキーで認証し、APIのバージョンとは独立した /scim/v2 に配置する
+func setupSCIMRoutes(router *gin.Engine, deps *Dependencies) {
+	if deps.SCIMService == nil || deps.APIKeyService == nil {
+		deps.Logger.Warn("SCIM service not available, skipping SCIM routes")
+		return
+	}
+
+	scimCtrl := scimController.NewSCIMController(deps.SCIMService, deps.Logger)
+
+	scimRoutes := router.Group(scimDomain.BasePath)
+	scimRoutes.Use(
+		authMiddleware.APIKeyRequired(deps.APIKeyService, authDomain.APIKeyScopeSCIM, scimController.AbortWithError),
+		scimActor(),
+	)
+	{
+		scimRoutes.GET("/ServiceProviderConfig", scimCtrl.GetServiceProviderConfig)
+
+		scimRoutes.GET("/Users", scimCtrl.ListUsers)
+		scimRoutes.POST("/Users", scimCtrl.CreateUser)
+		scimRoutes.GET("/Users/:id", scimCtrl.GetUser)
+		scimRoutes.PUT("/Users/:id", scimCtrl.ReplaceUser)
+		scimRoutes.PATCH("/Users/:id", scimCtrl.PatchUser)
+		scimRoutes.DELETE("/Users/:id", scimCtrl.DeleteUser)
+
+		scimRoutes.GET("/Groups", scimCtrl.ListGroups)
+		scimRoutes.POST("/Groups", scimCtrl.CreateGroup)
+		scimRoutes.GET("/Groups/:id", scimCtrl.GetGroup)
+		scimRoutes.PUT("/Groups/:id", scimCtrl.ReplaceGroup)
+		scimRoutes.PATCH("/Groups/:id", scimCtrl.PatchGroup)
+		scimRoutes.DELETE("/Groups/:id", scimCtrl.DeleteGroup)
+	}
+}
+
 // setupAuthRoutes は認証モジュールのルートをセットアップする
 func setupAuthRoutes(router *gin.RouterGroup, deps *Dependencies) {
 	// 認証コントローラの初期化
 	authCtrl := authController.NewAuthController(deps.AuthService, deps.Logger)
+	var securityEventCtrl *authController.SecurityEventController
+	if deps.AuditService != nil {
+		securityEventCtrl = authController.NewSecurityEventController(deps.AuditService, deps.Logger)
+	}
+	var deviceCtrl *authController.DeviceController
+	if deps.DeviceService != nil {
+		deviceCtrl = authController.NewDeviceController(deps.DeviceService, deps.Logger)
+	}
+	var apiKeyCtrl *authController.APIKeyController
+	if deps.APIKeyService != nil {
+		apiKeyCtrl = authController.NewAPIKeyController(deps.APIKeyService, deps.Logger)
+	}
+	var ssoCtrl *authController.SSOController
+	if deps.SSOService != nil {
+		ssoCtrl = authController.NewSSOController(deps.SSOService, deps.Config.Security.SSOSuccessURL, deps.Logger)
+	}
 
 	// 認証ミドルウェアの初期化
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
 
 	// 認証ルートグループ
 	authRoutes := router.Group("/auth")
@@ -140,7 +329,13 @@ func setupAuthRoutes(router *gin.RouterGroup, deps *Dependencies) {
 		// パブリックエンドポイント
 		authRoutes.POST("/register", authCtrl.Register)
 		authRoutes.POST("/login", authCtrl.Login)
+		authRoutes.POST("/login/verify", authCtrl.VerifyLogin)
 		authRoutes.POST("/refresh-token", authCtrl.RefreshToken)
+		if ssoCtrl != nil {
+			authRoutes.GET("/sso/discover", ssoCtrl.Discover)
+			authRoutes.GET("/sso/login", ssoCtrl.Login)
+			authRoutes.GET("/sso/callback", ssoCtrl.Callback)
+		}
 
 		// 認証が必要なエンドポイント
 		authenticated := authRoutes.Group("")
@@ -148,13 +343,41 @@ func setupAuthRoutes(router *gin.RouterGroup, deps *Dependencies) {
 		{
 			authenticated.POST("/logout", authCtrl.Logout)
 			authenticated.GET("/me", authCtrl.Me)
+			if securityEventCtrl != nil {
+				authenticated.GET("/security-events", securityEventCtrl.ListMySecurityEvents)
+			}
+			if deviceCtrl != nil {
+				authenticated.GET("/devices", deviceCtrl.ListDevices)
+				authenticated.PUT("/devices/:id", deviceCtrl.UpdateDevice)
+				authenticated.DELETE("/devices/:id", deviceCtrl.RemoveDevice)
+				authenticated.GET("/login-alerts", deviceCtrl.GetLoginAlertSettings)
+				authenticated.PUT("/login-alerts", deviceCtrl.UpdateLoginAlertSettings)
+			}
+			if apiKeyCtrl != nil {
+				authenticated.POST("/shortcut-keys", apiKeyCtrl.CreateShortcutKey)
+				authenticated.GET("/shortcut-keys", apiKeyCtrl.ListShortcutKeys)
+				authenticated.DELETE("/shortcut-keys/:id", apiKeyCtrl.RevokeShortcutKey)
+			}
 		}
 
 		// 管理者専用エンドポイント
 		admin := authRoutes.Group("/admin")
 		admin.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
 		{
-			// 将来の管理者機能用
+			if securityEventCtrl != nil {
+				admin.GET("/security-events", securityEventCtrl.ListSecurityEvents)
+			}
+			if apiKeyCtrl != nil {
+				admin.POST("/api-keys", apiKeyCtrl.CreateAPIKey)
+				admin.GET("/api-keys", apiKeyCtrl.ListAPIKeys)
+				admin.DELETE("/api-keys/:id", apiKeyCtrl.RevokeAPIKey)
+			}
+			if ssoCtrl != nil {
+				admin.GET("/sso-connections", ssoCtrl.ListConnections)
+				admin.POST("/sso-connections", ssoCtrl.CreateConnection)
+				admin.PUT("/sso-connections/:id", ssoCtrl.UpdateConnection)
+				admin.DELETE("/sso-connections/:id", ssoCtrl.DeleteConnection)
+			}
 		}
 	}
 }
@@ -165,7 +388,7 @@ func setupUserRoutes(router *gin.RouterGroup, deps *Dependencies) {
 	userCtrl := userController.NewUserController(deps.UserService, deps.Logger)
 
 	// 認証ミドルウェアの初期化
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
 
 	// ユーザールートグループ（認証が必要）
 	userRoutes := router.Group("/users")
@@ -177,6 +400,7 @@ func setupUserRoutes(router *gin.RouterGroup, deps *Dependencies) {
 		// 現在のユーザー関連（互換性維持）
 		userRoutes.GET("/me", userCtrl.GetCurrentUser)
 		userRoutes.PUT("/me", userCtrl.UpdateCurrentUser)
+		userRoutes.PUT("/me/password", userCtrl.ChangeCurrentUserPassword)
 
 		// 特定ユーザー関連
 		userRoutes.GET("/:id", userCtrl.GetUser)
@@ -190,7 +414,7 @@ func setupNotificationRoutes(router *gin.RouterGroup, deps *Dependencies) {
 	notificationCtrl := notificationController.NewNotificationController(deps.NotificationUseCase, deps.Logger)
 
 	// 認証ミドルウェアの初期化
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
 
 	// 通知ルートグループ（認証が必要）
 	notificationRoutes := router.Group("/notifications")
@@ -198,6 +422,203 @@ func setupNotificationRoutes(router *gin.RouterGroup, deps *Dependencies) {
 
 	// 通知ルートの登録
 	notificationController.RegisterNotificationRoutes(notificationRoutes, notificationCtrl)
+
+	// ログイン中のユーザーの通知の設定
+	preferenceCtrl := notificationController.NewPreferenceController(deps.NotificationPreferenceService, deps.Logger)
+	preferenceRoutes := router.Group("/me/notification-preferences")
+	preferenceRoutes.Use(authMw.AuthRequired())
+	notificationController.RegisterPreferenceRoutes(preferenceRoutes, preferenceCtrl)
+
+	// 通知テンプレート管理（管理者のみ）
+	templateRoutes := router.Group("/admin/notification-templates")
+	templateRoutes.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
+	notificationController.RegisterNotificationTemplateRoutes(templateRoutes, notificationCtrl)
+
+	// 配信不能の通知の確認と再送（管理者のみ）
+	deadLetterRoutes := router.Group("/admin/notifications/dead-letter")
+	deadLetterRoutes.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
+	notificationController.RegisterDeadLetterRoutes(deadLetterRoutes, notificationCtrl)
+}
+
+// setupMetricsRoutes は運用メトリクスのルートをセットアップする（管理者のみ）
+func setupMetricsRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	authMw := newAuthMiddleware(deps, nil)
+
+	metricsRoutes := router.Group("/admin/metrics")
+	metricsRoutes.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
+	metricsRoutes.GET("", gin.WrapH(metrics.Handler()))
+}
+
+// setupAdminRoutes は管理者ダッシュボードとアカウントの統合のルートをセットアップする（管理者のみ）
+func setupAdminRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	authMw := newAuthMiddleware(deps, nil)
+	adminRoutes := router.Group("/admin")
+	adminRoutes.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
+
+	if deps.AccountMergeService != nil {
+		mergeCtrl := adminController.NewAccountMergeController(deps.AccountMergeService)
+		adminRoutes.POST("/accounts/merge", mergeCtrl.MergeAccounts)
+	} else {
+		deps.Logger.Warn("Account merge service not available, skipping account merge routes")
+	}
+
+	if deps.AdminService == nil {
+		deps.Logger.Warn("Admin service not available, skipping admin dashboard routes")
+		return
+	}
+	adminCtrl := adminController.NewAdminController(deps.AdminService)
+	{
+		adminRoutes.GET("/dashboard", adminCtrl.GetDashboard)
+		adminRoutes.GET("/stats/users", adminCtrl.GetUserGrowth)
+		adminRoutes.GET("/stats/active-users", adminCtrl.GetActiveUsers)
+		adminRoutes.GET("/stats/tasks", adminCtrl.GetTaskCreation)
+		adminRoutes.GET("/stats/notifications", adminCtrl.GetNotificationDelivery)
+		adminRoutes.GET("/stats/storage", adminCtrl.GetStorageUsage)
+	}
+}
+
+// setupShortLinkRoutes は短縮リンクの転送（/s/{slug}）と管理者向けのクリック数の確認をセットアップする
+// 転送は短く保つためAPIのバージョンとは独立したパスに配置する
+func setupShortLinkRoutes(router *gin.Engine, api *gin.RouterGroup, deps *Dependencies) {
+	if deps.ShortLinkService == nil {
+		deps.Logger.Warn("Short link service not available, skipping short link routes")
+		return
+	}
+
+	shortLinkCtrl := shortLinkController.NewShortLinkController(deps.ShortLinkService, deps.Logger)
+	authMw := newAuthMiddleware(deps, nil)
+
+	router.GET(shortLinkUseCase.RedirectPath+":slug", shortLinkCtrl.Redirect)
+
+	adminRoutes := api.Group("/admin/short-links")
+	adminRoutes.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
+	{
+		adminRoutes.GET("/:slug", shortLinkCtrl.GetLink)
+	}
+}
+
+// setupTakeoutRoutes はデータのエクスポート・インポートのルートをセットアップする
+func setupTakeoutRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.TakeoutService == nil {
+		return
+	}
+	exportCtrl := takeoutController.NewExportController(deps.TakeoutService, deps.Logger)
+	authMw := newAuthMiddleware(deps, nil)
+
+	exportRoutes := router.Group("/me/export")
+	exportRoutes.Use(authMw.AuthRequired())
+	{
+		exportRoutes.POST("", exportCtrl.RequestExport)
+		exportRoutes.GET("", exportCtrl.ListExports)
+		exportRoutes.GET("/:id", exportCtrl.GetExport)
+		exportRoutes.GET("/:id/download", exportCtrl.DownloadExport)
+	}
+
+	if deps.DataImportService != nil {
+		importCtrl := takeoutController.NewImportController(deps.DataImportService, deps.Logger)
+		router.POST("/me/import", authMw.AuthRequired(), importCtrl.ImportData)
+	}
+}
+
+// setupFeatureFlagRoutes は機能フラグのルートをセットアップする
+func setupFeatureFlagRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	featureFlagCtrl := featureFlagController.NewFeatureFlagController(deps.FeatureFlagService)
+	authMw := newAuthMiddleware(deps, nil)
+
+	// クライアントの表示切り替え用
+	meRoutes := router.Group("/me")
+	meRoutes.Use(authMw.AuthRequired())
+	meRoutes.GET("/features", featureFlagCtrl.GetMyFeatures)
+
+	// フラグの管理（管理者のみ）
+	adminRoutes := router.Group("/admin/features")
+	adminRoutes.Use(authMw.AuthRequired(), authMw.RoleRequired("admin"))
+	{
+		adminRoutes.GET("", featureFlagCtrl.ListFlags)
+		adminRoutes.PUT("/:key", featureFlagCtrl.UpdateFlag)
+	}
+}
+
+// setupQuotaRoutes は使用量の上限のルートをセットアップする
+func setupQuotaRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	quotaCtrl := quotaController.NewQuotaController(deps.QuotaService)
+	authMw := newAuthMiddleware(deps, nil)
+
+	quotaRoutes := router.Group("/quotas")
+	quotaRoutes.Use(authMw.AuthRequired())
+	{
+		quotaRoutes.GET("/usage", quotaCtrl.GetMyUsage)
+		quotaRoutes.GET("/usage/groups/:id", quotaCtrl.GetGroupUsage)
+	}
+}
+
+// setupBillingRoutes は課金のルートをセットアップする
+// StripeのWebhookは署名で検証するため認証しない
+func setupBillingRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.BillingService == nil {
+		deps.Logger.Info("Billing is not configured, skipping billing routes")
+		return
+	}
+
+	billingCtrl := billingController.NewBillingController(deps.BillingService)
+	authMw := newAuthMiddleware(deps, nil)
+
+	billingRoutes := router.Group("/billing")
+	{
+		billingRoutes.GET("/plans", billingCtrl.ListPlans)
+		billingRoutes.POST("/webhook", billingCtrl.HandleWebhook)
+
+		billingRoutes.GET("/subscription", authMw.AuthRequired(), billingCtrl.GetSubscription)
+		billingRoutes.POST("/checkout", authMw.AuthRequired(), billingCtrl.CreateCheckout)
+	}
+}
+
+// setupAnalyticsRoutes は分析イベントの記録の設定のルートをセットアップする
+// イベントを記録しない場合も、オプトアウトの設定は変更できる
+func setupAnalyticsRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.AnalyticsService == nil {
+		return
+	}
+
+	analyticsCtrl := analyticsController.NewAnalyticsController(deps.AnalyticsService)
+	authMw := newAuthMiddleware(deps, nil)
+
+	analyticsRoutes := router.Group("/analytics")
+	analyticsRoutes.Use(authMw.AuthRequired())
+	{
+		analyticsRoutes.GET("/preference", analyticsCtrl.GetPreference)
+		analyticsRoutes.PUT("/preference", analyticsCtrl.UpdatePreference)
+	}
+}
+
+// setupSyncRoutes はオフライン同期の変更フィードと変更の送信のルートをセットアップする
+func setupSyncRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.SyncService == nil {
+		return
+	}
+
+	syncCtrl := syncController.NewSyncController(deps.SyncService)
+	authMw := newAuthMiddleware(deps, nil)
+
+	syncRoutes := router.Group("/sync")
+	syncRoutes.Use(authMw.AuthRequired())
+	{
+		syncRoutes.GET("/changes", syncCtrl.GetChanges)
+		syncRoutes.POST("/push", syncCtrl.Push)
+	}
+}
+
+// setupUndoRoutes は削除操作の取り消しのルートをセットアップする
+func setupUndoRoutes(router *gin.RouterGroup, deps *Dependencies) {
+	if deps.UndoQueue == nil {
+		return
+	}
+	undoCtrl := undo.NewController(deps.UndoQueue)
+	authMw := newAuthMiddleware(deps, nil)
+
+	undoRoutes := router.Group("/undo")
+	undoRoutes.Use(authMw.AuthRequired())
+	undoRoutes.POST("/:token", undoCtrl.Undo)
 }
 
 // setupTaskRoutes はタスクモジュールのルートをセットアップする
@@ -208,37 +629,219 @@ func setupTaskRoutes(router *gin.RouterGroup, deps *Dependencies) {
 	// 統計コントローラの初期化
 	statsCtrl := taskController.NewTaskStatsController(deps.StatsService)
 
+	// エスカレーションコントローラの初期化
+	escalationCtrl := taskController.NewEscalationController(deps.EscalationService)
+
+	// 作業量コントローラの初期化
+	workloadCtrl := taskController.NewWorkloadController(deps.WorkloadService)
+
+	// コメント・メンションコントローラの初期化
+	mentionCtrl := taskController.NewMentionController(deps.MentionService)
+
+	// 共有リンクコントローラの初期化
+	shareCtrl := taskController.NewShareController(deps.ShareService)
+
+	// マイルストーンコントローラの初期化
+	milestoneCtrl := taskController.NewMilestoneController(deps.MilestoneService)
+
+	// タイムライン・依存関係コントローラの初期化
+	timelineCtrl := taskController.NewTimelineController(deps.TimelineService)
+
+	// 変更履歴コントローラの初期化
+	historyCtrl := taskController.NewHistoryController(deps.HistoryService)
+
+	// カレンダーコントローラの初期化
+	calendarCtrl := taskController.NewCalendarController(deps.CalendarService)
+
+	// 週次レポートコントローラの初期化
+	weeklyReportCtrl := taskController.NewWeeklyReportController(deps.WeeklyReportService)
+
+	// エクスポートコントローラの初期化
+	exportCtrl := taskController.NewExportController(deps.ExportService)
+
+	// バーンダウン・累積フロー図コントローラの初期化
+	flowCtrl := taskController.NewFlowController(deps.FlowService)
+
+	// 今日の予定コントローラの初期化
+	todayCtrl := taskController.NewTodayController(deps.TodayService)
+
+	// 添付ファイルコントローラの初期化
+	attachmentCtrl := taskController.NewAttachmentController(deps.AttachmentService)
+
+	// 場所・ジオフェンスコントローラの初期化
+	locationCtrl := taskController.NewLocationController(deps.LocationService)
+
+	// GitHubのトークン・Webhookコントローラの初期化
+	issueLinkCtrl := taskController.NewIssueLinkController(deps.IssueLinkService)
+
+	// タスクのリンクコントローラの初期化
+	taskLinkCtrl := taskController.NewTaskLinkController(deps.TaskLinkService)
+
+	// メールからのタスク作成コントローラの初期化
+	inboundEmailCtrl := taskController.NewInboundEmailController(deps.InboundEmailService)
+
 	// 認証ミドルウェアの初期化
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
+
+	// 一覧・統計のレスポンスは変更がなければ304を返す
+	etag := middleware.ETagMiddleware()
+
+	// 公開共有リンクの閲覧（認証不要）
+	publicRoutes := router.Group("/public")
+	{
+		publicRoutes.GET("/shares/:token", shareCtrl.GetPublicShare)
+	}
+
+	// ホーム画面（認証が必要）
+	meRoutes := router.Group("/me")
+	meRoutes.Use(authMw.AuthRequired())
+	{
+		meRoutes.GET("/today", todayCtrl.GetToday)
+	}
+
+	// 添付ファイル（認証が必要）
+	attachmentRoutes := router.Group("/attachments")
+	attachmentRoutes.Use(authMw.AuthRequired())
+	{
+		attachmentRoutes.GET("/:id", attachmentCtrl.DownloadAttachment)
+		attachmentRoutes.GET("/:id/thumbnail", attachmentCtrl.GetThumbnail)
+		attachmentRoutes.DELETE("/:id", attachmentCtrl.DeleteAttachment)
+	}
+
+	// ジオフェンス（認証が必要、モバイル端末が監視する領域の同期と範囲に入ったことの報告）
+	geofenceRoutes := router.Group("/geofences")
+	geofenceRoutes.Use(authMw.AuthRequired())
+	{
+		geofenceRoutes.GET("", locationCtrl.ListGeofences)
+		geofenceRoutes.DELETE("/:id", locationCtrl.DeleteGeofence)
+		geofenceRoutes.POST("/:id/enter", locationCtrl.ReportGeofenceEnter)
+	}
+
+	// 外部サービスとの連携（認証が必要、GitHubのトークンの登録・タスク作成用のメールアドレス）
+	integrationRoutes := router.Group("/integrations")
+	integrationRoutes.Use(authMw.AuthRequired())
+	{
+		integrationRoutes.GET("/github", issueLinkCtrl.GetGitHubAccount)
+		integrationRoutes.PUT("/github", issueLinkCtrl.ConnectGitHub)
+		integrationRoutes.DELETE("/github", issueLinkCtrl.DisconnectGitHub)
+		integrationRoutes.GET("/email", inboundEmailCtrl.GetInboundAddress)
+		integrationRoutes.PUT("/email", inboundEmailCtrl.EnableInboundAddress)
+		integrationRoutes.POST("/email/rotate", inboundEmailCtrl.RotateInboundAddress)
+		integrationRoutes.DELETE("/email", inboundEmailCtrl.DisableInboundAddress)
+	}
+
+	// GitHubのWebhook（X-Hub-Signature-256の署名で認証）
+	router.POST("/webhooks/github", issueLinkCtrl.HandleWebhook)
+	// Mailgunのメールの受信のWebhook（timestamp・token・signatureの署名で認証）
+	router.POST("/webhooks/email/inbound", inboundEmailCtrl.HandleWebhook)
 
 	// タスクルートグループ（認証が必要）
 	taskRoutes := router.Group("/tasks")
 	taskRoutes.Use(authMw.AuthRequired())
 	{
+		// /api/v2に後継があるエンドポイントは非推奨ヘッダーを付与する
+		coreRoutes := taskRoutes.Group("", v1TasksDeprecation(deps.Config))
+
 		// タスクCRUD操作
-		taskRoutes.POST("", taskCtrl.CreateTask)
-		taskRoutes.GET("/:id", taskCtrl.GetTask)
-		taskRoutes.PUT("/:id", taskCtrl.UpdateTask)
-		taskRoutes.DELETE("/:id", taskCtrl.DeleteTask)
+		coreRoutes.POST("", taskCtrl.CreateTask)
+		coreRoutes.POST("/quick-add", taskCtrl.QuickAddTask)
+		coreRoutes.GET("/:id", taskCtrl.GetTask)
+		coreRoutes.PUT("/:id", taskCtrl.UpdateTask)
+		coreRoutes.DELETE("/:id", taskCtrl.DeleteTask)
+
+		// 作成前の重複タスクの確認・カテゴリと優先度の推定
+		taskRoutes.POST("/check-duplicate", taskCtrl.CheckDuplicateTask)
+		taskRoutes.POST("/classify", taskCtrl.ClassifyTask)
 
 		// タスク一覧・検索
-		taskRoutes.GET("", taskCtrl.ListTasks)
-		taskRoutes.GET("/search", taskCtrl.SearchTasks)
+		coreRoutes.GET("", etag, taskCtrl.ListTasks)
+		coreRoutes.GET("/search", etag, taskCtrl.SearchTasks)
 
 		// タスクの状態管理
-		taskRoutes.PUT("/:id/assign", taskCtrl.AssignTask)
-		taskRoutes.PUT("/:id/status", taskCtrl.ChangeTaskStatus)
+		coreRoutes.PUT("/:id/assign", taskCtrl.AssignTask)
+		coreRoutes.DELETE("/:id/assignees/:user_id", taskCtrl.UnassignTask)
+		coreRoutes.PUT("/:id/assignees/me/completion", taskCtrl.SetMyAssignmentCompletion)
+		coreRoutes.PUT("/:id/status", taskCtrl.ChangeTaskStatus)
+		coreRoutes.PUT("/:id/estimate", taskCtrl.UpdateTaskEstimate)
+		coreRoutes.PUT("/:id/schedule", taskCtrl.UpdateTaskSchedule)
 
 		// 特定条件でのタスク取得
-		taskRoutes.GET("/overdue", taskCtrl.GetOverdueTasks)
-		taskRoutes.GET("/my", taskCtrl.GetMyTasks)
-		taskRoutes.GET("/user/:user_id", taskCtrl.GetUserTasks)
+		coreRoutes.GET("/overdue", etag, taskCtrl.GetOverdueTasks)
+		coreRoutes.GET("/my", etag, taskCtrl.GetMyTasks)
+		coreRoutes.GET("/user/:user_id", etag, taskCtrl.GetUserTasks)
+
+		// エスカレーションルール
+		escalationGroup := taskRoutes.Group("/escalation-rules")
+		{
+			escalationGroup.GET("", escalationCtrl.ListEscalationRules)
+			escalationGroup.POST("", escalationCtrl.CreateEscalationRule)
+			escalationGroup.PUT("/:rule_id", escalationCtrl.UpdateEscalationRule)
+			escalationGroup.DELETE("/:rule_id", escalationCtrl.DeleteEscalationRule)
+		}
+
+		// 勤務時間・作業量
+		taskRoutes.GET("/workload", workloadCtrl.GetWorkload)
+		taskRoutes.GET("/workload/settings", workloadCtrl.GetWorkingHours)
+		taskRoutes.PUT("/workload/settings", workloadCtrl.UpdateWorkingHours)
+
+		// カレンダー
+		taskRoutes.GET("/calendar", calendarCtrl.GetCalendar)
+
+		// エクスポート（NDJSONで逐次書き出す）
+		taskRoutes.GET("/export", exportCtrl.ExportTasks)
+
+		// コメント・メンション
+		taskRoutes.GET("/mentions", mentionCtrl.ListMyMentions)
+		taskRoutes.POST("/:id/comments", mentionCtrl.AddComment)
+		taskRoutes.GET("/:id/comments", mentionCtrl.ListComments)
+
+		// 公開共有リンク
+		taskRoutes.POST("/:id/share-links", shareCtrl.CreateTaskShareLink)
+		taskRoutes.POST("/share-links", shareCtrl.CreateTaskListShareLink)
+		taskRoutes.GET("/share-links", shareCtrl.ListShareLinks)
+		taskRoutes.DELETE("/share-links/:link_id", shareCtrl.RevokeShareLink)
+
+		// プロジェクトグループのマイルストーン
+		milestoneGroup := taskRoutes.Group("/milestones")
+		{
+			milestoneGroup.GET("", milestoneCtrl.ListMilestones)
+			milestoneGroup.POST("", milestoneCtrl.CreateMilestone)
+			milestoneGroup.PUT("/order", milestoneCtrl.ReorderMilestones)
+			milestoneGroup.GET("/:milestone_id", milestoneCtrl.GetMilestone)
+			milestoneGroup.PUT("/:milestone_id", milestoneCtrl.UpdateMilestone)
+			milestoneGroup.DELETE("/:milestone_id", milestoneCtrl.DeleteMilestone)
+		}
+		taskRoutes.PUT("/:id/milestone", milestoneCtrl.SetTaskMilestone)
+
+		// タスクの依存関係
+		taskRoutes.POST("/:id/dependencies", timelineCtrl.AddTaskDependency)
+		taskRoutes.DELETE("/:id/dependencies/:depends_on_id", timelineCtrl.RemoveTaskDependency)
+
+		// タスクの変更履歴
+		taskRoutes.GET("/:id/history", historyCtrl.GetTaskHistory)
+
+		// 添付ファイル
+		taskRoutes.POST("/:id/attachments", attachmentCtrl.UploadAttachment)
+		taskRoutes.GET("/:id/attachments", attachmentCtrl.ListAttachments)
+
+		// 場所・近くのタスク・ジオフェンス
+		taskRoutes.GET("/nearby", locationCtrl.ListNearbyTasks)
+		taskRoutes.GET("/:id/location", locationCtrl.GetTaskLocation)
+		taskRoutes.PUT("/:id/location", locationCtrl.SetTaskLocation)
+		taskRoutes.DELETE("/:id/location", locationCtrl.DeleteTaskLocation)
+		taskRoutes.POST("/:id/geofences", locationCtrl.RegisterGeofence)
+
+		// タスクのリンク（ユーザーのリンク・GitHubのIssue・Pull Request・グループの予定）
+		taskRoutes.GET("/:id/links", taskLinkCtrl.ListLinks)
+		taskRoutes.POST("/:id/links", taskLinkCtrl.CreateLink)
+		taskRoutes.PATCH("/:id/links/:linkId", taskLinkCtrl.UpdateLink)
+		taskRoutes.DELETE("/:id/links/:linkId", taskLinkCtrl.DeleteLink)
 
 		// === 統計情報API ===
 		statsGroup := taskRoutes.Group("/stats")
 		{
 			// ダッシュボード統計
-			statsGroup.GET("/dashboard", statsCtrl.GetDashboardStats)
+			statsGroup.GET("/dashboard", etag, statsCtrl.GetDashboardStats)
 
 			// 日次統計
 			statsGroup.GET("/today", statsCtrl.GetTodayStats)
@@ -255,17 +858,116 @@ func setupTaskRoutes(router *gin.RouterGroup, deps *Dependencies) {
 			// 分析情報
 			statsGroup.GET("/category-breakdown", statsCtrl.GetCategoryBreakdown)
 			statsGroup.GET("/priority-breakdown", statsCtrl.GetPriorityBreakdown)
+
+			// ベロシティ・見積もり精度
+			statsGroup.GET("/velocity", statsCtrl.GetVelocityReport)
+
+			// 前の期間との比較（完了・作成・期限切れ・完了率の差と変化率）
+			statsGroup.GET("/compare", statsCtrl.GetStatsComparison)
+			statsGroup.GET("/series", statsCtrl.GetStatsSeries)
+
+			// マイルストーンのバーンダウン
+			statsGroup.GET("/milestones/:milestone_id/burndown", milestoneCtrl.GetMilestoneBurndown)
+
+			// 変更履歴から作るバーンダウン・累積フロー図（自分のタスク・グループタスク）
+			statsGroup.GET("/burndown", flowCtrl.GetBurndown)
+			statsGroup.GET("/cumulative-flow", flowCtrl.GetCumulativeFlow)
+
+			// タスクごとの集計用の項目のエクスポート（NDJSONで逐次書き出す）
+			statsGroup.GET("/export", exportCtrl.ExportStats)
+
+			// 週次レポート（JSON・HTML・PDF）とメール配信の購読
+			statsGroup.GET("/reports/weekly/:week", weeklyReportCtrl.GetWeeklyReport)
+			statsGroup.GET("/reports/subscription", weeklyReportCtrl.GetSubscription)
+			statsGroup.PUT("/reports/subscription", weeklyReportCtrl.Subscribe)
+			statsGroup.DELETE("/reports/subscription", weeklyReportCtrl.Unsubscribe)
 		}
 	}
 }
 
+// setupTaskV2Routes は/api/v2のタスクルートをセットアップする
+func setupTaskV2Routes(router *gin.RouterGroup, deps *Dependencies) {
+	taskCtrl := taskController.NewTaskV2Controller(deps.TaskService)
+	authMw := newAuthMiddleware(deps, abortWithErrorEnvelope)
+	etag := middleware.ETagMiddleware()
+
+	taskRoutes := router.Group("/tasks")
+	taskRoutes.Use(authMw.AuthRequired())
+	{
+		taskRoutes.POST("", taskCtrl.CreateTask)
+		taskRoutes.POST("/quick-add", taskCtrl.QuickAddTask)
+		taskRoutes.GET("/:id", taskCtrl.GetTask)
+		taskRoutes.PUT("/:id", taskCtrl.UpdateTask)
+		taskRoutes.DELETE("/:id", taskCtrl.DeleteTask)
+
+		taskRoutes.GET("", etag, taskCtrl.ListTasks)
+		taskRoutes.GET("/search", etag, taskCtrl.SearchTasks)
+
+		taskRoutes.PUT("/:id/assign", taskCtrl.AssignTask)
+		taskRoutes.DELETE("/:id/assignees/:user_id", taskCtrl.UnassignTask)
+		taskRoutes.PUT("/:id/assignees/me/completion", taskCtrl.SetMyAssignmentCompletion)
+		taskRoutes.PUT("/:id/status", taskCtrl.ChangeTaskStatus)
+		taskRoutes.PUT("/:id/estimate", taskCtrl.UpdateTaskEstimate)
+		taskRoutes.PUT("/:id/schedule", taskCtrl.UpdateTaskSchedule)
+
+		taskRoutes.GET("/overdue", etag, taskCtrl.GetOverdueTasks)
+		taskRoutes.GET("/my", etag, taskCtrl.GetMyTasks)
+		taskRoutes.GET("/user/:user_id", etag, taskCtrl.GetUserTasks)
+	}
+}
+
+// newAuthMiddleware は認証ミドルウェアを作成する（abortがnilの場合は既定のエラー形式）
+// 認証済みのユーザーはキャッシュから取得し、役割の変更・アカウントの無効化を発行済みのトークンにも反映する
+func newAuthMiddleware(deps *Dependencies, abort authMiddleware.ErrorHandler) *authMiddleware.AuthMiddleware {
+	mw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	if abort != nil {
+		mw = authMiddleware.NewAuthMiddlewareWithErrorHandler(deps.TokenService, abort)
+	}
+	if deps.ProfileCache != nil {
+		mw.Users = deps.ProfileCache
+	}
+	return mw
+}
+
+// abortWithErrorEnvelope は認証・認可エラーをv2のエラーエンベロープで返す
+func abortWithErrorEnvelope(c *gin.Context, status int, message string) {
+	code := "UNAUTHORIZED"
+	if status == http.StatusForbidden {
+		code = "FORBIDDEN"
+	}
+	apiversion.AbortWithError(c, status, code, message)
+}
+
+// v1TasksDeprecation はv1タスクエンドポイントの非推奨ヘッダーを付与するミドルウェアを返す
+// 非推奨日が設定されていない場合は何もしない
+func v1TasksDeprecation(cfg *config.Config) gin.HandlerFunc {
+	since, sunset, ok := cfg.GetV1TasksDeprecation()
+	if !ok {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return apiversion.Deprecated(apiversion.V1, apiversion.Deprecation{
+		Since:     since,
+		Sunset:    sunset,
+		Successor: apiversion.V2,
+	})
+}
+
 // setupSocialRoutes はソーシャルモジュールのルートをセットアップする
 func setupSocialRoutes(router *gin.RouterGroup, deps *Dependencies) {
 	// 認証ミドルウェアの初期化
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
 
 	// ソーシャルコントローラの初期化
 	socialCtrl := socialController.NewSocialController(deps.SocialService, deps.Logger)
+	presenceCtrl := socialController.NewPresenceController(deps.PresenceService, deps.Logger)
+	profileCtrl := socialController.NewProfileController(deps.ProfileService, deps.Logger)
+
+	// 公開プロフィール（ログインしていなくても取得可能、ログインしている場合は閲覧者との関係を含める）
+	// /users/:id と同じ階層のため、パスパラメータ名は id を共有する
+	router.GET("/users/:id/public", authMw.AuthOptional(), profileCtrl.GetPublicProfile) // GET /users/{username}/public
+
+	// 友達一覧は変更がなければ304を返す
+	etag := middleware.ETagMiddleware()
 
 	// ソーシャルルートグループ（認証が必要）
 	socialRoutes := router.Group("/social")
@@ -285,7 +987,8 @@ func setupSocialRoutes(router *gin.RouterGroup, deps *Dependencies) {
 			}
 
 			// 友達管理
-			friends.GET("", socialCtrl.GetFriends)                      // GET /social/friends
+			friends.GET("", etag, socialCtrl.GetFriends)                // GET /social/friends
+			friends.GET("/presence", presenceCtrl.GetFriendsPresence)   // GET /social/friends/presence
 			friends.DELETE("/:userId", socialCtrl.RemoveFriend)         // DELETE /social/friends/{userId}
 			friends.GET("/:userId/mutual", socialCtrl.GetMutualFriends) // GET /social/friends/{userId}/mutual
 		}
@@ -296,30 +999,41 @@ func setupSocialRoutes(router *gin.RouterGroup, deps *Dependencies) {
 			users.POST("/:userId/block", socialCtrl.BlockUser)     // POST /social/users/{userId}/block
 			users.DELETE("/:userId/block", socialCtrl.UnblockUser) // DELETE /social/users/{userId}/block
 		}
+		socialRoutes.GET("/blocks", socialCtrl.GetBlockedUsers) // GET /social/blocks
 
 		// 招待関連
 		invitations := socialRoutes.Group("/invitations")
 		{
-			invitations.POST("", socialCtrl.CreateInvitation)                       // POST /social/invitations
-			invitations.GET("/:invitationId", socialCtrl.GetInvitation)             // GET /social/invitations/{invitationId}
-			invitations.GET("/code/:code", socialCtrl.GetInvitationByCode)          // GET /social/invitations/code/{code}
-			invitations.POST("/:code/accept", socialCtrl.AcceptInvitation)          // POST /social/invitations/{code}/accept
-			invitations.PUT("/:invitationId/decline", socialCtrl.DeclineInvitation) // PUT /social/invitations/{invitationId}/decline
-			invitations.DELETE("/:invitationId", socialCtrl.CancelInvitation)       // DELETE /social/invitations/{invitationId}
-			invitations.GET("/sent", socialCtrl.GetSentInvitations)                 // GET /social/invitations/sent
-			invitations.GET("/received", socialCtrl.GetReceivedInvitations)         // GET /social/invitations/received
-			invitations.GET("/:invitationId/url", socialCtrl.GenerateInviteURL)     // GET /social/invitations/{invitationId}/url
+			invitations.POST("", socialCtrl.CreateInvitation)                          // POST /social/invitations
+			invitations.GET("/:invitationId", socialCtrl.GetInvitation)                // GET /social/invitations/{invitationId}
+			invitations.GET("/code/:code", socialCtrl.GetInvitationByCode)             // GET /social/invitations/code/{code}
+			invitations.POST("/:code/accept", socialCtrl.AcceptInvitation)             // POST /social/invitations/{code}/accept
+			invitations.PUT("/:invitationId/decline", socialCtrl.DeclineInvitation)    // PUT /social/invitations/{invitationId}/decline
+			invitations.PUT("/:invitationId/resend", socialCtrl.ResendInvitationEmail) // PUT /social/invitations/{invitationId}/resend
+			invitations.DELETE("/:invitationId", socialCtrl.CancelInvitation)          // DELETE /social/invitations/{invitationId}
+			invitations.GET("/sent", socialCtrl.GetSentInvitations)                    // GET /social/invitations/sent
+			invitations.GET("/received", socialCtrl.GetReceivedInvitations)            // GET /social/invitations/received
+			invitations.GET("/:invitationId/url", socialCtrl.GenerateInviteURL)        // GET /social/invitations/{invitationId}/url
+			invitations.GET("/:invitationId/qr", socialCtrl.GetInvitationQRCode)       // GET /social/invitations/{invitationId}/qr
 		}
 
 		// 関係性
 		socialRoutes.GET("/relationships/:userId", socialCtrl.GetRelationship) // GET /social/relationships/{userId}
+
+		// 公開プロフィールの設定
+		socialRoutes.GET("/profile", profileCtrl.GetProfileSettings)    // GET /social/profile
+		socialRoutes.PUT("/profile", profileCtrl.UpdateProfileSettings) // PUT /social/profile
 	}
+
+	// メール配信サービスからのWebhook（Webhookシークレットで認証）
+	webhookCtrl := socialController.NewInvitationEmailWebhookController(deps.SocialService, deps.Config.External.WebhookSecret, deps.Logger)
+	router.POST("/webhooks/email/bounces", webhookCtrl.HandleBounce) // POST /webhooks/email/bounces
 }
 
 // setupGroupRoutes はグループモジュールのルートをセットアップする
 func setupGroupRoutes(router *gin.RouterGroup, deps *Dependencies) {
 	// 認証ミドルウェアの初期化
-	authMw := authMiddleware.NewAuthMiddleware(deps.TokenService)
+	authMw := newAuthMiddleware(deps, nil)
 
 	// グループコントローラの初期化
 	groupCtrl := groupController.NewGroupController(deps.GroupService, deps.Logger)
@@ -330,59 +1044,38 @@ func setupGroupRoutes(router *gin.RouterGroup, deps *Dependencies) {
 
 	// グループコントローラのルート設定を使用
 	groupController.RegisterGroupRoutes(groupRoutes, groupCtrl)
-}
 
-// StartBackgroundServices はバックグラウンドサービスを開始する（context対応版）
-func StartBackgroundServices(deps *Dependencies) {
-	// キャンセル可能なcontextを作成
-	ctx, cancel := context.WithCancel(context.Background())
-	deps.cancelFunc = cancel
-
-	// WebSocketハブの起動（context対応）
-	if deps.WSHub != nil {
-		deps.backgroundWg.Add(1)
-		go func() {
-			defer deps.backgroundWg.Done()
-
-			if err := deps.WSHub.Run(ctx); err != nil && err != context.Canceled {
-				deps.Logger.Error("WebSocket hub stopped with error", logger.Error(err))
-			} else {
-				deps.Logger.Info("WebSocket hub stopped gracefully")
-			}
-		}()
-		deps.Logger.Info("WebSocket hub started")
-	}
+	// スケジュールカードの共有リンク（認証不要、署名と有効期限で検証する）
+	groupController.RegisterPublicGroupRoutes(router, groupCtrl)
 
-	// タスクスケジューラーの起動
-	if deps.TaskScheduler != nil {
-		deps.TaskScheduler.Start(ctx)
-		deps.Logger.Info("Task due notification scheduler started")
-	}
+	// グループのタイムライン（タスクモジュールで集計）
+	timelineCtrl := taskController.NewTimelineController(deps.TimelineService)
+	groupRoutes.GET("/groups/:groupId/timeline", timelineCtrl.GetGroupTimeline)
 }
 
-// StopBackgroundServices はバックグラウンドサービスを停止する（context対応版）
-func StopBackgroundServices(deps *Dependencies) {
-	deps.Logger.Info("Stopping background services...")
-
-	// contextをキャンセルしてWebSocketハブを停止
-	if deps.cancelFunc != nil {
-		deps.cancelFunc()
-		deps.Logger.Info("Background context cancelled")
+// StartBackgroundServices はバックグラウンドサービスを開始する
+// 各サービスは NewDependencies でモジュールのプロバイダーがライフサイクルマネージャーに登録し、StopBackgroundServices で逆順に停止する
+func StartBackgroundServices(ctx context.Context, deps *Dependencies) {
+	if deps.lifecycle == nil {
+		deps.lifecycle = lifecycle.NewManager(lifecycle.DefaultStopTimeout, deps.Logger)
 	}
 
-	// タスクスケジューラーの停止
-	if deps.TaskScheduler != nil {
-		deps.TaskScheduler.Stop()
-		deps.Logger.Info("Task due notification scheduler stopped")
+	deps.lifecycle.Start(ctx)
+	deps.Logger.Info("Background services started")
+}
+
+// StopBackgroundServices はバックグラウンドサービスを登録と逆順に停止する
+// ctx の期限で全体の待ち時間を打ち切り、停止しなかったサービスを *lifecycle.ShutdownError で返す
+func StopBackgroundServices(ctx context.Context, deps *Dependencies) error {
+	if deps.lifecycle == nil {
+		return nil
 	}
 
-	// メッセージブローカーの停止
-	if deps.MessageBroker != nil {
-		deps.MessageBroker.Close()
-		deps.Logger.Info("Message broker stopped")
+	deps.Logger.Info("Stopping background services...")
+	if err := deps.lifecycle.Shutdown(ctx); err != nil {
+		return err
 	}
 
-	// 全てのバックグラウンドサービスの完了を待機
-	deps.backgroundWg.Wait()
 	deps.Logger.Info("All background services stopped")
+	return nil
 }
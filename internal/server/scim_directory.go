@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	authDomain "github.com/hryt430/Yotei+/internal/modules/auth/domain"
+	authMiddleware "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/middleware"
+	authService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/auth"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	scimDomain "github.com/hryt430/Yotei+/internal/modules/scim/domain"
+	scimController "github.com/hryt430/Yotei+/internal/modules/scim/interface/controller"
+)
+
+// scimDirectoryLimit はSCIMで一度に扱うグループ・メンバーの上限
+const scimDirectoryLimit = 10000
+
+// scimUserDirectory はSCIMのユーザー操作を認証モジュールのユーザーサービスに橋渡しする
+type scimUserDirectory struct {
+	userService *userService.UserService
+	// 作成したユーザーにメールアドレス宛ての招待を紐付ける（nilの場合は紐付けない）
+	registrationListener authService.RegistrationListener
+}
+
+// CreateUser はIdPで確認済みのメールアドレスのユーザーを作成する
+func (d *scimUserDirectory) CreateUser(ctx context.Context, username, email, password string) (*scimDomain.DirectoryUser, error) {
+	user, err := d.userService.CreateUser(&authDomain.User{
+		ID:            uuid.New(),
+		Email:         email,
+		Username:      username,
+		Password:      password,
+		EmailVerified: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if d.registrationListener != nil {
+		d.registrationListener.UserRegistered(ctx, user)
+	}
+	return toDirectoryUser(user), nil
+}
+
+func (d *scimUserDirectory) GetUser(ctx context.Context, id string) (*scimDomain.DirectoryUser, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
+	}
+	return d.directoryUser(d.userService.FindUserByID(userID))
+}
+
+func (d *scimUserDirectory) FindUserByUsername(ctx context.Context, username string) (*scimDomain.DirectoryUser, error) {
+	return d.directoryUser(d.userService.FindUserByUsername(username))
+}
+
+func (d *scimUserDirectory) FindUserByEmail(ctx context.Context, email string) (*scimDomain.DirectoryUser, error) {
+	return d.directoryUser(d.userService.FindUserByEmail(email))
+}
+
+func (d *scimUserDirectory) ListUsers(ctx context.Context) ([]*scimDomain.DirectoryUser, error) {
+	users, err := d.userService.GetUsers(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*scimDomain.DirectoryUser, 0, len(users))
+	for _, user := range users {
+		result = append(result, toDirectoryUser(user))
+	}
+	return result, nil
+}
+
+// UpdateUser はユーザー名・メールアドレスを更新し、有効・無効を切り替える
+// メールアドレスはIdPで確認済みのため、変更しても確認済みのままにする
+func (d *scimUserDirectory) UpdateUser(ctx context.Context, u *scimDomain.DirectoryUser) error {
+	userID, err := uuid.Parse(u.ID)
+	if err != nil {
+		return err
+	}
+	user, err := d.userService.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	if user.Username != u.Username || user.Email != u.Email {
+		oldUsername := user.Username
+		user.Username = u.Username
+		user.Email = u.Email
+		user.UpdatedAt = time.Now()
+		if err := d.userService.UserRepository.UpdateUser(user); err != nil {
+			return err
+		}
+		if d.userService.UserInfoCache != nil {
+			d.userService.UserInfoCache.Invalidate(u.ID)
+		}
+		// IdPでの変更はユーザー名のポリシーを適用せず、変更前のユーザー名を履歴に残す
+		if err := d.userService.RecordUsernameChange(ctx, userID, oldUsername, user.Username); err != nil {
+			return err
+		}
+	}
+
+	if user.IsActive() != u.Active {
+		if user, err = d.userService.SetActive(ctx, userID, u.Active); err != nil {
+			return err
+		}
+	}
+	u.UpdatedAt = user.UpdatedAt
+	return nil
+}
+
+func (d *scimUserDirectory) directoryUser(user *authDomain.User, err error) (*scimDomain.DirectoryUser, error) {
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return toDirectoryUser(user), nil
+}
+
+func toDirectoryUser(user *authDomain.User) *scimDomain.DirectoryUser {
+	return &scimDomain.DirectoryUser{
+		ID:        user.ID.String(),
+		Username:  user.Username,
+		Email:     user.Email,
+		Active:    user.IsActive(),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// scimGroupDirectory はSCIMのグループ操作をグループサービスに橋渡しする
+// 変更はグループサービス（オーナーの権限）で行い、参照はリポジトリから直接行う
+type scimGroupDirectory struct {
+	groupService    groupUseCase.GroupService
+	groupRepository groupUseCase.GroupRepository
+}
+
+func (d *scimGroupDirectory) CreateGroup(ctx context.Context, ownerID, name string) (*scimDomain.DirectoryGroup, error) {
+	owner, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	group, err := d.groupService.CreateGroup(ctx, groupUseCase.CreateGroupInput{
+		Name:     name,
+		Type:     groupDomain.GroupTypeProject,
+		OwnerID:  owner,
+		Settings: groupDomain.DefaultSettings(groupDomain.GroupTypeProject),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDirectoryGroup(group), nil
+}
+
+func (d *scimGroupDirectory) GetGroup(ctx context.Context, id string) (*scimDomain.DirectoryGroup, error) {
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
+	}
+	group, err := d.groupRepository.GetGroupByID(ctx, groupID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	return toDirectoryGroup(group), nil
+}
+
+func (d *scimGroupDirectory) ListGroups(ctx context.Context, ownerID string) ([]*scimDomain.DirectoryGroup, error) {
+	owner, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	groups, _, err := d.groupRepository.ListGroupsByOwner(ctx, owner, commonDomain.Pagination{Page: 1, PageSize: scimDirectoryLimit})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*scimDomain.DirectoryGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, toDirectoryGroup(group))
+	}
+	return result, nil
+}
+
+func (d *scimGroupDirectory) RenameGroup(ctx context.Context, ownerID, id, name string) error {
+	owner, groupID, err := parseOwnerAndID(ownerID, id)
+	if err != nil {
+		return err
+	}
+	_, err = d.groupService.UpdateGroup(ctx, groupID, groupUseCase.UpdateGroupInput{Name: &name}, owner)
+	return err
+}
+
+func (d *scimGroupDirectory) DeleteGroup(ctx context.Context, ownerID, id string) error {
+	owner, groupID, err := parseOwnerAndID(ownerID, id)
+	if err != nil {
+		return err
+	}
+	return d.groupService.DeleteGroup(ctx, groupID, owner)
+}
+
+func (d *scimGroupDirectory) ListMemberIDs(ctx context.Context, id string) ([]string, error) {
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := d.groupRepository.ListMembers(ctx, groupID, commonDomain.Pagination{Page: 1, PageSize: scimDirectoryLimit})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID.String())
+	}
+	return ids, nil
+}
+
+func (d *scimGroupDirectory) AddMember(ctx context.Context, ownerID, groupID, userID string) error {
+	owner, group, err := parseOwnerAndID(ownerID, groupID)
+	if err != nil {
+		return err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	return d.groupService.AddMember(ctx, group, user, owner, groupDomain.RoleMember)
+}
+
+func (d *scimGroupDirectory) RemoveMember(ctx context.Context, ownerID, groupID, userID string) error {
+	owner, group, err := parseOwnerAndID(ownerID, groupID)
+	if err != nil {
+		return err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	return d.groupService.RemoveMember(ctx, group, user, owner)
+}
+
+func parseOwnerAndID(ownerID, id string) (uuid.UUID, uuid.UUID, error) {
+	owner, err := uuid.Parse(ownerID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return owner, groupID, nil
+}
+
+func toDirectoryGroup(group *groupDomain.Group) *scimDomain.DirectoryGroup {
+	return &scimDomain.DirectoryGroup{
+		ID:        group.ID.String(),
+		Name:      group.Name,
+		OwnerID:   group.OwnerID.String(),
+		CreatedAt: group.CreatedAt,
+		UpdatedAt: group.UpdatedAt,
+	}
+}
+
+// scimActor はAPIキーを発行した管理者をSCIMの操作を行うユーザーとして設定するミドルウェア
+// （authMiddleware.APIKeyRequiredの後に使う）
+func scimActor() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if key, ok := ctx.MustGet(authMiddleware.APIKeyContextKey).(*authDomain.APIKey); ok {
+			ctx.Set(scimController.ActorContextKey, key.CreatedBy)
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	shortLinkDomain "github.com/hryt430/Yotei+/internal/modules/shortlink/domain"
+	shortLinkUseCase "github.com/hryt430/Yotei+/internal/modules/shortlink/usecase"
+	socialGateway "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/gateway"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// shortLinkProvider は短縮リンク（/s/{slug} の転送とクリック数の記録）を組み立てる
+// 招待・共有リンクの短縮は SHORT_LINK_BASE_URL が設定されている場合のみ行う
+var shortLinkProvider = provider{
+	name:     "shortlink",
+	requires: []string{"storage"},
+	provide: func(w *wiring) error {
+		w.deps.ShortLinkService = shortLinkUseCase.NewShortLinkService(
+			w.repos.shortLinkRepository,
+			w.cfg.Server.ShortLinkBaseURL,
+			w.log,
+		)
+		return nil
+	},
+}
+
+// shortLinksEnabled は招待・共有リンクを短縮するかを返す
+func (w *wiring) shortLinksEnabled() bool {
+	return w.deps.ShortLinkService != nil && w.cfg.Server.ShortLinkBaseURL != ""
+}
+
+// inviteURLShortener は招待URLを招待と同じ期限の短縮URLにする
+type inviteURLShortener struct {
+	invitations socialUseCase.InvitationRepository
+	shortLinks  *shortLinkUseCase.ShortLinkService
+}
+
+// newInviteURLShortener は短縮しない設定の場合は nil を返す
+func newInviteURLShortener(w *wiring) socialGateway.InviteURLShortener {
+	if !w.shortLinksEnabled() {
+		return nil
+	}
+	return &inviteURLShortener{
+		invitations: w.repos.invitationRepository,
+		shortLinks:  w.deps.ShortLinkService,
+	}
+}
+
+func (s *inviteURLShortener) ShortenInviteURL(ctx context.Context, invitationID uuid.UUID, inviteURL string) (string, error) {
+	invitation, err := s.invitations.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		return "", err
+	}
+	if invitation == nil {
+		return "", fmt.Errorf("invitation not found: %s", invitationID)
+	}
+
+	expiresAt := invitation.ExpiresAt
+	return s.shortLinks.Shorten(ctx, shortLinkDomain.KindInvitation, invitationID.String(), inviteURL, &expiresAt)
+}
+
+// shareURLShortener はタスクの共有リンクを共有リンクと同じ期限の短縮URLにする
+type shareURLShortener struct {
+	shortLinks *shortLinkUseCase.ShortLinkService
+}
+
+// newShareURLShortener は短縮しない設定の場合は nil を返す
+func newShareURLShortener(w *wiring) taskUseCase.ShareURLShortener {
+	if !w.shortLinksEnabled() {
+		return nil
+	}
+	return &shareURLShortener{shortLinks: w.deps.ShortLinkService}
+}
+
+func (s *shareURLShortener) ShortenShareURL(ctx context.Context, link *taskDomain.ShareLink) (string, error) {
+	return s.shortLinks.Shorten(ctx, shortLinkDomain.KindShare, link.ID, link.PublicPath(), link.ExpiresAt)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hryt430/Yotei+/config"
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationUseCase "github.com/hryt430/Yotei+/internal/modules/notification/usecase"
+	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// socialDigestActionURL はリマインダーの通知から開く友達申請・招待の画面
+const socialDigestActionURL = "/friends/requests"
+
+// socialDigestPreferences は通知の設定からリマインダーの頻度を取得する
+type socialDigestPreferences struct {
+	preferences *notificationUseCase.PreferenceService
+}
+
+func (p *socialDigestPreferences) DigestInterval(ctx context.Context, userID uuid.UUID) (time.Duration, error) {
+	preferences, err := p.preferences.GetPreferences(ctx, userID.String())
+	if err != nil {
+		return 0, err
+	}
+	return preferences.SocialDigest.Interval(), nil
+}
+
+// socialDigestNotifier は未対応の友達申請・グループ招待のリマインダーをアプリ内通知で送る
+type socialDigestNotifier struct {
+	notificationUseCase notificationInput.NotificationUseCase
+}
+
+func (n *socialDigestNotifier) SendPendingDigest(ctx context.Context, digest socialDomain.PendingDigest) (string, error) {
+	notification, err := n.notificationUseCase.CreateNotification(ctx, notificationInput.CreateNotificationInput{
+		UserID:  digest.UserID.String(),
+		Type:    string(notificationDomain.SocialDigest),
+		Title:   digest.Title(),
+		Message: digest.Message(),
+		Metadata: map[string]string{
+			"action_url":        socialDigestActionURL,
+			"friend_requests":   strconv.Itoa(digest.FriendRequests),
+			"group_invitations": strconv.Itoa(digest.GroupInvitations),
+		},
+		Channels: []string{"app"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create digest notification: %w", err)
+	}
+	if err := n.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		return "", fmt.Errorf("failed to send digest notification: %w", err)
+	}
+	return notification.GetID(), nil
+}
+
+// DismissPendingDigest はリマインダーを既読にする（削除済み・既読・他のユーザーの通知の場合は何もしない）
+func (n *socialDigestNotifier) DismissPendingDigest(ctx context.Context, userID uuid.UUID, notificationID string) error {
+	notification, err := n.notificationUseCase.GetNotification(ctx, notificationID)
+	if err != nil {
+		return err
+	}
+	if notification == nil || notification.Status == notificationDomain.StatusRead || notification.UserID != userID.String() {
+		return nil
+	}
+	return n.notificationUseCase.MarkNotificationAsRead(ctx, notificationID)
+}
+
+// socialDigestSettings は設定からリマインダーを確認する間隔と含める申請・招待の経過時間を読み込む
+func socialDigestSettings(cfg *config.Config, log logger.Logger) (time.Duration, time.Duration) {
+	interval := time.Hour
+	minPendingAge := socialUseCase.DefaultDigestMinPendingAge
+
+	if d, err := time.ParseDuration(cfg.Social.DigestInterval); err == nil && d > 0 {
+		interval = d
+	} else if cfg.Social.DigestInterval != "" {
+		log.Warn("Invalid SOCIAL_DIGEST_INTERVAL, using default", logger.Any("value", cfg.Social.DigestInterval))
+	}
+	if d, err := time.ParseDuration(cfg.Social.DigestMinPendingAge); err == nil && d >= 0 {
+		minPendingAge = d
+	} else if cfg.Social.DigestMinPendingAge != "" {
+		log.Warn("Invalid SOCIAL_DIGEST_MIN_PENDING_AGE, using default", logger.Any("value", cfg.Social.DigestMinPendingAge))
+	}
+	return interval, minPendingAge
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// profileUserDirectory はユーザー名から公開プロフィールの本人を取得する（無効化されたユーザーは含めない）
+// 変更前のユーザー名は変更後のユーザーとして解決する
+type profileUserDirectory struct {
+	userService *userService.UserService
+}
+
+func (d *profileUserDirectory) FindActiveUserByUsername(ctx context.Context, username string) (*commonDomain.UserInfo, error) {
+	user, err := d.userService.ResolveUsername(ctx, username)
+	if err != nil || user == nil || !user.IsActive() {
+		return nil, err
+	}
+	return &commonDomain.UserInfo{
+		ID:       user.ID.String(),
+		Username: user.Username,
+		Email:    user.Email,
+	}, nil
+}
+
+// profileTaskStats は完了したタスクから公開プロフィールの統計を作成する（日付の区切りはUTC）
+type profileTaskStats struct {
+	statsRepository taskUseCase.StatsRepository
+}
+
+func (s *profileTaskStats) GetProfileStats(ctx context.Context, userID uuid.UUID) (*socialDomain.ProfileStats, error) {
+	now := time.Now().UTC()
+	from := now.AddDate(0, 0, -socialDomain.MaxProfileStreakDays-1)
+	tasks, err := s.statsRepository.GetCompletedTasksByDateRange(ctx, userID.String(), from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	completedAt := make([]time.Time, 0, len(tasks))
+	for _, task := range tasks {
+		if task.CompletedAt != nil {
+			completedAt = append(completedAt, *task.CompletedAt)
+		}
+	}
+	return socialDomain.NewProfileStats(completedAt, now), nil
+}
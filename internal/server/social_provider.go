@@ -0,0 +1,243 @@
+package server
+
+import (
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/pkg/logger"
+
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupGateway "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/gateway"
+	groupMessaging "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/messaging"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	scimUseCase "github.com/hryt430/Yotei+/internal/modules/scim/usecase"
+	socialGateway "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/gateway"
+	socialMessaging "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/messaging"
+	socialRedis "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/redis"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+)
+
+// socialProvider は友達・招待・ブロック・オンライン状態・公開プロフィールと、期限切れの招待の整理・未対応の申請のリマインダーのワーカーを組み立てる
+var socialProvider = provider{
+	name:     "social",
+	requires: []string{"storage", "sync", "notification", "auth"},
+	provide: func(w *wiring) error {
+		cfg, log := w.cfg, w.log
+		friendshipRepository := w.repos.friendshipRepository
+		invitationRepository := w.repos.invitationRepository
+
+		// ブロック確認（ユーザー検索・グループ追加で使用）
+		blockChecker := socialUseCase.NewBlockChecker(friendshipRepository)
+		w.userService.BlockChecker = blockChecker
+
+		// Social event publisher (simplified for now)
+		socialEventPublisher := &SimpleSocialEventPublisher{logger: log}
+
+		// Invitation URL gateway (web URL plus optional deep links / universal links, shortened when SHORT_LINK_BASE_URL is set)
+		urlGateway := socialGateway.NewInviteURLGateway(cfg, newInviteURLShortener(w), log)
+
+		// Invitation email gateway (logs only when SMTP is not configured)
+		invitationEmailGateway := socialGateway.NewInvitationEmailGateway(cfg, w.smtpBreaker, log)
+
+		socialService := socialUseCase.NewSocialServiceImplWithEmail(
+			friendshipRepository,
+			invitationRepository,
+			w.userValidator, // using the existing userValidator
+			socialEventPublisher,
+			urlGateway,
+			invitationEmailGateway,
+			socialUseCase.DefaultInvitationEmailCooldown,
+			&log,
+		)
+
+		// Social cleanup（期限切れ招待・放置された友達申請の整理）
+		cleanupPolicy, cleanupInterval := socialCleanupSettings(cfg, log)
+		socialCleanupService := socialUseCase.NewCleanupService(
+			friendshipRepository,
+			invitationRepository,
+			socialEventPublisher,
+			cleanupPolicy,
+			&log,
+		)
+
+		// Presence（WebSocket接続に基づくオンライン状態、Redisまたはプロセス内のストアを利用可能時のみ有効）
+		var presenceRepository socialUseCase.PresenceRepository
+		if w.redisClient != nil {
+			presenceRepository = socialRedis.NewPresenceStore(w.redisClient)
+		} else if w.embeddedStore != nil {
+			presenceRepository = socialRedis.NewEmbeddedPresenceStore(w.embeddedStore)
+		} else {
+			log.Warn("Presence disabled (Redis not available)")
+		}
+		presenceService := socialUseCase.NewPresenceService(
+			friendshipRepository,
+			presenceRepository,
+			socialGateway.NewWebSocketPresenceNotifier(w.deps.WSHub, log),
+			socialPresenceTimeout(cfg, log),
+			&log,
+		)
+		if presenceService.Enabled() {
+			w.deps.WSHub.PresenceTracker = presenceService
+		}
+
+		// 公開プロフィール（統計は完了したタスクから集計する）
+		profileService := socialUseCase.NewProfileService(
+			w.repos.profileRepository,
+			friendshipRepository,
+			&profileUserDirectory{userService: w.userService},
+			&profileTaskStats{statsRepository: w.repos.statsRepository},
+			&log,
+		)
+
+		// 新規登録したユーザーにメールアドレス宛ての招待を紐付ける
+		w.authRepository.RegistrationListener = &invitationRegistrationListener{socialService: socialService, logger: log}
+		w.deps.SSOService.RegistrationListener = w.authRepository.RegistrationListener
+
+		w.blockChecker = blockChecker
+		w.deps.SocialService = socialService
+		w.deps.PresenceService = presenceService
+		w.deps.ProfileService = profileService
+
+		// 変更フィードへの記録
+		if impl, ok := w.socialServiceImpl(); ok {
+			impl.SetChangeRecorder(w.deps.SyncService)
+		}
+		socialCleanupService.SetChangeRecorder(w.deps.SyncService)
+
+		w.deps.SocialCleanupWorker = socialMessaging.NewCleanupWorker(socialCleanupService, cleanupInterval, log)
+		w.lifecycle.Add("social-cleanup-worker", w.deps.SocialCleanupWorker, 0)
+
+		// 未対応の友達申請・グループ招待のリマインダー（頻度は通知の設定に従う）
+		digestInterval, digestMinPendingAge := socialDigestSettings(cfg, log)
+		socialDigestService := socialUseCase.NewDigestService(
+			friendshipRepository,
+			invitationRepository,
+			w.repos.digestStateRepository,
+			&socialDigestPreferences{preferences: w.deps.NotificationPreferenceService},
+			&socialDigestNotifier{notificationUseCase: w.deps.NotificationUseCase},
+			digestMinPendingAge,
+			&log,
+		)
+		w.deps.SocialDigestWorker = socialMessaging.NewDigestWorker(socialDigestService, digestInterval, log)
+		w.lifecycle.Add("social-digest-worker", w.deps.SocialDigestWorker, 0)
+		return nil
+	},
+}
+
+// groupProvider はグループと、ソーシャル・タスクモジュールとの橋渡し・予定の出欠のリマインダーとチャット連携のまとめのワーカーを組み立てる
+var groupProvider = provider{
+	name:     "group",
+	requires: []string{"storage", "sync", "notification", "social", "task"},
+	provide: func(w *wiring) error {
+		groupTaskResolver := w.repos.groupTaskResolver
+		taskRepository := w.repos.taskRepository
+
+		groupService := groupUseCase.NewGroupServiceWithBlockChecker(w.repos.groupRepository, w.userValidator, w.blockChecker, &w.log)
+
+		// グループ招待の受諾・メールアドレス宛ての招待からの新規登録でグループに参加させる
+		if impl, ok := w.socialServiceImpl(); ok {
+			impl.SetGroupMembershipGateway(&groupMembershipGateway{groupService: groupService})
+		}
+		// タスクモジュールでのグループの権限の確認（グループの権限設定に従う）
+		w.groupTaskPermissions.groupService = groupService
+		// グループタスクの作成（作成権限の確認・担当者の自動割り当て）
+		w.taskService.GroupAssigner = &groupTaskAssigner{
+			groupService: groupService,
+			counter:      &openTaskCounter{taskRepository: taskRepository},
+		}
+		// グループのリーダーボード（メンバーが完了したグループタスクを集計する）
+		groupService.SetTaskCompletionProvider(&groupTaskCompletions{groupTasks: groupTaskResolver, taskRepository: taskRepository})
+		// 予定共有グループの予定の出欠（開始日時のあるグループタスクを予定として扱い、タスクの場所を予定の場所とする）
+		groupService.SetEventDirectory(&groupEvents{groupTasks: groupTaskResolver, taskRepository: taskRepository, locations: w.repos.locationRepository})
+		// 繰り返しの予定の祝日の扱い（休む・翌営業日に移す）
+		groupService.SetHolidayProvider(w.holidays)
+		groupService.SetEventReminderNotifier(&groupEventReminderNotifier{notificationUseCase: w.deps.NotificationUseCase})
+		// 予定の議事録のアクションアイテム・作業時間の予定・フォローアップからグループタスクを作成し、予定との関連付けをタスクの詳細に表示する
+		groupService.SetGroupTaskGateway(&groupTaskGateway{
+			taskService:    w.taskService,
+			groupTasks:     groupTaskResolver,
+			taskRepository: taskRepository,
+		})
+		w.taskService.EventLinks = &taskEventLinks{groupService: groupService}
+		w.deps.TaskLinkService.EventLinks = w.taskService.EventLinks
+		// グループタスクの完了・割り当て・期限のリマインダーと1日のまとめをグループのチャット連携（Discord・Teams）に投稿する
+		groupService.SetChatPoster(groupDomain.ChatPlatformDiscord, groupGateway.NewDiscordWebhookPoster(w.discordBreaker, w.log))
+		groupService.SetChatPoster(groupDomain.ChatPlatformTeams, groupGateway.NewTeamsWebhookPoster(w.teamsBreaker, w.log))
+		groupService.SetChatTaskSource(&groupChatTasks{groupTasks: groupTaskResolver, taskRepository: taskRepository})
+		w.taskService.EventPublisher = &groupChatEventPublisher{
+			EventPublisher: w.taskService.EventPublisher,
+			groupService:   groupService,
+			groupTasks:     groupTaskResolver,
+			log:            w.log,
+		}
+		// スケジュールカードの共有リンク（公開URLは短縮URLと同じベースURLで発行する）
+		groupService.SetScheduleCardSigner(scheduleCardSecret(w.cfg), w.cfg.Server.ShortLinkBaseURL)
+		// 変更フィードへの記録
+		groupService.SetChangeRecorder(w.deps.SyncService)
+
+		w.deps.GroupService = groupService
+
+		w.deps.EventReminderWorker = groupMessaging.NewEventReminderWorker(groupService, groupEventReminderInterval, w.log)
+		w.lifecycle.Add("group-event-reminder-worker", w.deps.EventReminderWorker, 0)
+		w.deps.ChatSummaryWorker = groupMessaging.NewChatSummaryWorker(groupService, groupChatSummaryInterval, w.log)
+		w.lifecycle.Add("group-chat-summary-worker", w.deps.ChatSummaryWorker, 0)
+		return nil
+	},
+}
+
+// scimProvider はSCIMプロビジョニング（IdPからのユーザー・グループの同期）を組み立てる
+var scimProvider = provider{
+	name:     "scim",
+	requires: []string{"auth", "social", "group"},
+	provide: func(w *wiring) error {
+		w.deps.SCIMService = scimUseCase.NewProvisioningService(
+			&scimUserDirectory{userService: w.userService, registrationListener: w.authRepository.RegistrationListener},
+			&scimGroupDirectory{groupService: w.deps.GroupService, groupRepository: w.repos.groupRepository},
+			w.log,
+		)
+		return nil
+	},
+}
+
+// scheduleCardSecret はスケジュールカードの共有リンクの署名鍵を返す
+func scheduleCardSecret(cfg *config.Config) []byte {
+	if cfg.Security.ScheduleCardSecret != "" {
+		return []byte(cfg.Security.ScheduleCardSecret)
+	}
+	return []byte(cfg.Security.SessionSecret)
+}
+
+// socialCleanupSettings は設定からソーシャルクリーンアップのポリシーと実行間隔を作成する
+// 不正な値はデフォルト値で置き換える
+func socialCleanupSettings(cfg *config.Config, log logger.Logger) (socialUseCase.CleanupPolicy, time.Duration) {
+	policy := socialUseCase.DefaultCleanupPolicy()
+	interval := time.Hour
+
+	if ttl, err := time.ParseDuration(cfg.Social.FriendRequestTTL); err == nil {
+		policy.FriendRequestTTL = ttl
+	} else if cfg.Social.FriendRequestTTL != "" {
+		log.Warn("Invalid SOCIAL_FRIEND_REQUEST_TTL, using default", logger.Error(err))
+	}
+	if lead, err := time.ParseDuration(cfg.Social.FriendRequestReminder); err == nil {
+		policy.ReminderLead = lead
+	} else if cfg.Social.FriendRequestReminder != "" {
+		log.Warn("Invalid SOCIAL_FRIEND_REQUEST_REMINDER, using default", logger.Error(err))
+	}
+	if d, err := time.ParseDuration(cfg.Social.CleanupInterval); err == nil && d > 0 {
+		interval = d
+	} else if cfg.Social.CleanupInterval != "" {
+		log.Warn("Invalid SOCIAL_CLEANUP_INTERVAL, using default", logger.Any("value", cfg.Social.CleanupInterval))
+	}
+
+	return policy, interval
+}
+
+// socialPresenceTimeout は設定からオンライン状態のタイムアウトを読み込む
+func socialPresenceTimeout(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Social.PresenceTimeout); err == nil && d > 0 {
+		return d
+	} else if cfg.Social.PresenceTimeout != "" {
+		log.Warn("Invalid SOCIAL_PRESENCE_TIMEOUT, using default", logger.Any("value", cfg.Social.PresenceTimeout))
+	}
+	return socialUseCase.DefaultPresenceTimeout
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/pkg/logger"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	commonValidator "github.com/hryt430/Yotei+/internal/common/validator"
+
+	authDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/auth/infrastructure/database"
+	authDatabase "github.com/hryt430/Yotei+/internal/modules/auth/interface/database"
+	authRedis "github.com/hryt430/Yotei+/internal/modules/auth/interface/redis"
+	apiKeyService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/apikey"
+	auditService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/audit"
+	deviceService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/device"
+	ssoService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/sso"
+	tokenService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/token"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+
+	notificationDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/notification/infrastructure/database"
+	notificationDatabase "github.com/hryt430/Yotei+/internal/modules/notification/interface/database"
+	notificationPersistence "github.com/hryt430/Yotei+/internal/modules/notification/usecase/persistence"
+
+	taskDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/database"
+	taskDatabase "github.com/hryt430/Yotei+/internal/modules/task/interface/database"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+
+	socialDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/social/infrastructure/database"
+	socialDatabase "github.com/hryt430/Yotei+/internal/modules/social/interface/database"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+
+	groupDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/group/infrastructure/database"
+	groupDatabase "github.com/hryt430/Yotei+/internal/modules/group/interface/database"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+
+	adminDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/admin/infrastructure/database"
+	adminDatabase "github.com/hryt430/Yotei+/internal/modules/admin/interface/database"
+	adminUseCase "github.com/hryt430/Yotei+/internal/modules/admin/usecase"
+
+	featureFlagDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/featureflag/infrastructure/database"
+	featureFlagDatabase "github.com/hryt430/Yotei+/internal/modules/featureflag/interface/database"
+	featureFlagUseCase "github.com/hryt430/Yotei+/internal/modules/featureflag/usecase"
+	shortLinkDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/shortlink/infrastructure/database"
+	shortLinkDatabase "github.com/hryt430/Yotei+/internal/modules/shortlink/interface/database"
+	shortLinkUseCase "github.com/hryt430/Yotei+/internal/modules/shortlink/usecase"
+	takeoutDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/takeout/infrastructure/database"
+	takeoutDatabase "github.com/hryt430/Yotei+/internal/modules/takeout/interface/database"
+	takeoutUseCase "github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+
+	billingDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/billing/infrastructure/database"
+	billingDatabase "github.com/hryt430/Yotei+/internal/modules/billing/interface/database"
+	billingUseCase "github.com/hryt430/Yotei+/internal/modules/billing/usecase"
+
+	analyticsDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/analytics/infrastructure/database"
+	analyticsDatabase "github.com/hryt430/Yotei+/internal/modules/analytics/interface/database"
+	analyticsUseCase "github.com/hryt430/Yotei+/internal/modules/analytics/usecase"
+	syncDatabaseInfra "github.com/hryt430/Yotei+/internal/modules/sync/infrastructure/database"
+	syncDatabase "github.com/hryt430/Yotei+/internal/modules/sync/interface/database"
+	syncUseCase "github.com/hryt430/Yotei+/internal/modules/sync/usecase"
+)
+
+// storage は各モジュールのリポジトリをまとめたもの
+// STORAGE_DRIVER に応じてSQL実装（MySQL・PostgreSQL・SQLite）またはインメモリ実装で組み立てる
+type storage struct {
+	// Auth module
+	userRepository  userService.IUserRepository
+	userValidator   commonDomain.UserValidator
+	tokenRepository tokenService.ITokenRepository
+	// 認証イベントの監査ログ
+	securityEventRepository   auditService.ISecurityEventRepository
+	usernameHistoryRepository userService.UsernameHistoryRepository
+	// ログイン端末と不審なログインの通知設定
+	loginDeviceRepository deviceService.ILoginDeviceRepository
+	// 外部システム向けのAPIキー
+	apiKeyRepository apiKeyService.IAPIKeyRepository
+	// 企業SSOの接続設定とIdPのユーザーの紐付け
+	ssoRepository ssoService.ISSORepository
+
+	// Notification module
+	notificationRepository notificationPersistence.NotificationRepository
+	// 再試行しても送信できなかった通知
+	deadLetterRepository notificationPersistence.DeadLetterRepository
+	// ユーザーごとの通知の設定
+	notificationPreferenceRepository notificationPersistence.PreferenceRepository
+
+	// Task module
+	taskRepository           taskUseCase.TaskRepository
+	statsRepository          taskUseCase.StatsRepository
+	escalationRuleRepository taskUseCase.EscalationRuleRepository
+	groupTaskResolver        taskUseCase.GroupTaskStore
+	workloadRepository       taskUseCase.WorkloadRepository
+	commentRepository        taskUseCase.CommentRepository
+	mentionRepository        taskUseCase.MentionRepository
+	mentionDirectory         taskUseCase.MentionDirectory
+	shareLinkRepository      taskUseCase.ShareLinkRepository
+	milestoneRepository      taskUseCase.MilestoneRepository
+	dependencyRepository     taskUseCase.DependencyRepository
+	taskHistoryRepository    taskUseCase.TaskHistoryRepository
+	taskUsageRepository      taskUseCase.TaskUsageRepository
+	attachmentRepository     taskUseCase.AttachmentRepository
+	linkPreviewRepository    taskUseCase.LinkPreviewRepository
+	weeklyReportRepository   taskUseCase.WeeklyReportSubscriptionRepository
+	dailyStatsRepository     taskUseCase.DailyStatsRepository
+	taskExportRepository     taskUseCase.TaskExportRepository
+	locationRepository       taskUseCase.LocationRepository
+	issueLinkRepository      taskUseCase.IssueLinkRepository
+	taskLinkRepository       taskUseCase.TaskLinkRepository
+	inboundEmailRepository   taskUseCase.InboundEmailRepository
+	reminderRepository       taskUseCase.DeferredReminderRepository
+
+	// Social module
+	friendshipRepository socialUseCase.FriendshipRepository
+	invitationRepository socialUseCase.InvitationRepository
+	profileRepository    socialUseCase.ProfileRepository
+	// 未対応の申請・招待のリマインダーの送信の記録
+	digestStateRepository socialUseCase.DigestStateRepository
+
+	// Group module
+	groupRepository groupUseCase.GroupRepository
+
+	// Admin module（MySQLのみ。PostgreSQL・SQLite・インメモリの場合はnil）
+	adminMetricsRepository adminUseCase.MetricsRepository
+	// アカウントの統合（SQL実装のみ。インメモリの場合はnil）
+	accountMergeRepository adminUseCase.AccountMergeRepository
+
+	// Feature flag module
+	featureFlagRepository featureFlagUseCase.FlagRepository
+
+	// Short link module
+	shortLinkRepository shortLinkUseCase.LinkRepository
+
+	// Takeout module
+	dataExportRepository takeoutUseCase.ExportRepository
+	dataImportRepository takeoutUseCase.ImportRepository
+
+	// Billing module
+	subscriptionRepository billingUseCase.SubscriptionRepository
+
+	// Analytics module
+	analyticsPreferenceRepository analyticsUseCase.PreferenceRepository
+	syncChangeRepository          syncUseCase.ChangeRepository
+	syncMutationRepository        syncUseCase.MutationRepository
+
+	// 添付ファイルとサムネイルの保存先（SQL実装の場合は FILE_STORAGE_DIR のディレクトリ）
+	fileStorage commonDomain.FileStorage
+}
+
+// newSQLStorage はMySQL・PostgreSQL・SQLiteのリポジトリを作成する
+// PostgreSQL・SQLiteでも同じリポジトリを使い、クエリは接続で方言を変換して実行する
+func newSQLStorage(driver string, tokenCache authRedis.TokenCache, log logger.Logger) *storage {
+	// Auth module dependencies
+	authSqlHandler := authDatabaseInfra.NewSqlHandler()
+	userRepository := &authDatabase.IUserRepository{
+		SqlHandler: &authSqlHandler,
+	}
+	tokenStorage := &authDatabase.TokenStorage{
+		SqlHandler: &authSqlHandler,
+	}
+
+	// ブラックリストはRedis（またはプロセス内のストア）、リフレッシュトークンはDBに保存する
+	tokenRepository := authRedis.NewTokenRepositoryAdapter(tokenCache, tokenStorage)
+
+	// Notification module dependencies
+	notificationSqlHandler := notificationDatabaseInfra.NewSqlHandler()
+	notificationRepo := &notificationDatabase.NotificationServiceRepository{
+		SqlHandler: &notificationSqlHandler,
+		Logger:     log,
+	}
+
+	// Task module dependencies
+	taskSqlHandler := taskDatabaseInfra.NewSqlHandler()
+
+	// Social module dependencies
+	socialSqlHandler := socialDatabaseInfra.NewSqlHandler()
+
+	// Group module dependencies
+	groupSqlHandler := groupDatabaseInfra.NewSqlHandler()
+
+	// Admin module dependencies（集計クエリが日付の書式・information_schemaの統計情報を使うため、MySQLのみ）
+	adminSqlHandler := adminDatabaseInfra.NewSqlHandler()
+	var adminMetricsRepository adminUseCase.MetricsRepository
+	if driver == config.StorageDriverMySQL {
+		adminMetricsRepository = adminDatabase.NewMetricsRepository(adminSqlHandler.GetConnection(), log)
+	}
+
+	// Feature flag module dependencies
+	featureFlagSqlHandler := featureFlagDatabaseInfra.NewSqlHandler()
+
+	// Short link module dependencies
+	shortLinkSqlHandler := shortLinkDatabaseInfra.NewSqlHandler()
+
+	// Takeout module dependencies
+	takeoutSqlHandler := takeoutDatabaseInfra.NewSqlHandler()
+
+	// Billing module dependencies
+	billingSqlHandler := billingDatabaseInfra.NewSqlHandler()
+
+	// Analytics module dependencies
+	analyticsSqlHandler := analyticsDatabaseInfra.NewSqlHandler()
+	syncSqlHandler := syncDatabaseInfra.NewSqlHandler()
+
+	return &storage{
+		userRepository:  userRepository,
+		userValidator:   commonValidator.NewUserValidator(userRepository),
+		tokenRepository: tokenRepository,
+		securityEventRepository: &authDatabase.SecurityEventRepository{
+			SqlHandler: &authSqlHandler,
+		},
+		usernameHistoryRepository: &authDatabase.UsernameHistoryRepository{
+			SqlHandler: &authSqlHandler,
+		},
+		loginDeviceRepository: &authDatabase.LoginDeviceRepository{
+			SqlHandler: &authSqlHandler,
+		},
+		apiKeyRepository: &authDatabase.APIKeyRepository{
+			SqlHandler: &authSqlHandler,
+		},
+		ssoRepository: &authDatabase.SSORepository{
+			SqlHandler: &authSqlHandler,
+		},
+
+		notificationRepository:           notificationRepo,
+		deadLetterRepository:             notificationDatabase.NewDeadLetterRepository(&notificationSqlHandler, log),
+		notificationPreferenceRepository: notificationDatabase.NewPreferenceRepository(&notificationSqlHandler, log),
+
+		taskRepository:           taskDatabase.NewTaskRepository(&taskSqlHandler, log),
+		statsRepository:          taskDatabase.NewTaskStatsRepository(&taskSqlHandler, log),
+		escalationRuleRepository: taskDatabase.NewEscalationRuleRepository(&taskSqlHandler, log),
+		groupTaskResolver:        taskDatabase.NewGroupTaskResolver(&taskSqlHandler, log),
+		workloadRepository:       taskDatabase.NewWorkloadRepository(&taskSqlHandler, log),
+		commentRepository:        taskDatabase.NewCommentRepository(&taskSqlHandler, log),
+		mentionRepository:        taskDatabase.NewMentionRepository(&taskSqlHandler, log),
+		mentionDirectory:         taskDatabase.NewMentionDirectory(&taskSqlHandler, log),
+		shareLinkRepository:      taskDatabase.NewShareLinkRepository(&taskSqlHandler, log),
+		milestoneRepository:      taskDatabase.NewMilestoneRepository(&taskSqlHandler, log),
+		dependencyRepository:     taskDatabase.NewDependencyRepository(&taskSqlHandler, log),
+		taskHistoryRepository:    taskDatabase.NewTaskHistoryRepository(&taskSqlHandler, log),
+		taskUsageRepository:      taskDatabase.NewTaskUsageRepository(&taskSqlHandler, log),
+		attachmentRepository:     taskDatabase.NewAttachmentRepository(&taskSqlHandler, log),
+		linkPreviewRepository:    taskDatabase.NewLinkPreviewRepository(&taskSqlHandler, log),
+		weeklyReportRepository:   taskDatabase.NewWeeklyReportSubscriptionRepository(&taskSqlHandler, log),
+		dailyStatsRepository:     taskDatabase.NewDailyStatsRepository(&taskSqlHandler, log),
+		taskExportRepository:     taskDatabase.NewTaskExportRepository(&taskSqlHandler, log),
+		locationRepository:       taskDatabase.NewLocationRepository(&taskSqlHandler, log),
+		issueLinkRepository:      taskDatabase.NewIssueLinkRepository(&taskSqlHandler, log),
+		taskLinkRepository:       taskDatabase.NewTaskLinkRepository(&taskSqlHandler, log),
+		inboundEmailRepository:   taskDatabase.NewInboundEmailRepository(&taskSqlHandler, log),
+		reminderRepository:       taskDatabase.NewDeferredReminderRepository(&taskSqlHandler, log),
+
+		friendshipRepository:  socialDatabase.NewFriendshipRepository(socialSqlHandler.GetConnection(), log),
+		invitationRepository:  socialDatabase.NewInvitationRepository(socialSqlHandler.GetConnection(), log),
+		profileRepository:     socialDatabase.NewProfileRepository(socialSqlHandler.GetConnection(), log),
+		digestStateRepository: socialDatabase.NewDigestStateRepository(socialSqlHandler.GetConnection(), log),
+
+		groupRepository: groupDatabase.NewGroupRepository(groupSqlHandler.GetConnection(), log),
+
+		adminMetricsRepository: adminMetricsRepository,
+		accountMergeRepository: adminDatabase.NewAccountMergeRepository(adminSqlHandler.GetConnection(), log),
+
+		featureFlagRepository: featureFlagDatabase.NewFlagRepository(featureFlagSqlHandler.GetConnection(), log),
+
+		shortLinkRepository: shortLinkDatabase.NewLinkRepository(shortLinkSqlHandler.GetConnection(), log),
+
+		dataExportRepository: takeoutDatabase.NewExportRepository(takeoutSqlHandler.GetConnection(), log),
+		dataImportRepository: takeoutDatabase.NewImportRepository(takeoutSqlHandler.GetConnection(), log),
+
+		subscriptionRepository: billingDatabase.NewSubscriptionRepository(billingSqlHandler.GetConnection(), log),
+
+		analyticsPreferenceRepository: analyticsDatabase.NewPreferenceRepository(analyticsSqlHandler.GetConnection(), log),
+		syncChangeRepository:          syncDatabase.NewChangeRepository(syncSqlHandler.GetConnection(), log),
+		syncMutationRepository:        syncDatabase.NewMutationRepository(syncSqlHandler.GetConnection(), log),
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationPersistence "github.com/hryt430/Yotei+/internal/modules/notification/usecase/persistence"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// syncNotificationRepository は通知の保存と状態の更新をオフライン同期用の変更フィードに記録する通知リポジトリ
+// 通知の作成・まとめ・送信・既読のいずれもリポジトリを経由するため、通知モジュールを変更せずにすべての変更を記録できる
+type syncNotificationRepository struct {
+	notificationPersistence.NotificationRepository
+	changes commonDomain.ChangeRecorder
+	logger  logger.Logger
+}
+
+// Save は通知を保存し、通知の受信者の変更フィードに記録する
+func (r *syncNotificationRepository) Save(ctx context.Context, notification *notificationDomain.Notification) error {
+	if err := r.NotificationRepository.Save(ctx, notification); err != nil {
+		return err
+	}
+	r.changes.RecordUpsert(ctx, commonDomain.SyncEntityNotification, notification.ID, []string{notification.UserID}, notification)
+	return nil
+}
+
+// UpdateStatus は通知のステータスを更新し、更新後の通知を変更フィードに記録する
+func (r *syncNotificationRepository) UpdateStatus(ctx context.Context, id string, status notificationDomain.NotificationStatus) error {
+	if err := r.NotificationRepository.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+
+	notification, err := r.NotificationRepository.FindByID(ctx, id)
+	if err != nil || notification == nil {
+		r.logger.WithContext(ctx).Warn("Failed to load notification for sync", logger.Any("notificationID", id), logger.Error(err))
+		return nil
+	}
+	r.changes.RecordUpsert(ctx, commonDomain.SyncEntityNotification, notification.ID, []string{notification.UserID}, notification)
+	return nil
+}
+
+// syncChangeRetention は変更フィードの変更を保持する期間を設定から読み込む（0の場合は削除しない）
+func syncChangeRetention(cfg *config.Config, log logger.Logger) time.Duration {
+	d, err := time.ParseDuration(cfg.Sync.ChangeRetention)
+	if err != nil || d < 0 {
+		if cfg.Sync.ChangeRetention != "" {
+			log.Warn("Invalid SYNC_CHANGE_RETENTION, using default", logger.Any("value", cfg.Sync.ChangeRetention))
+		}
+		return 90 * 24 * time.Hour
+	}
+	return d
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	syncDomain "github.com/hryt430/Yotei+/internal/modules/sync/domain"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// syncTaskData はクライアントが送信するタスクの変更内容（更新時は指定したフィールドだけを変更する）
+type syncTaskData struct {
+	Title       *string    `json:"title" binding:"omitempty,max=255"`
+	Description *string    `json:"description" binding:"omitempty,max=5000"`
+	Status      *string    `json:"status" binding:"omitempty,oneof=TODO IN_PROGRESS DONE"`
+	Priority    *string    `json:"priority" binding:"omitempty,oneof=LOW MEDIUM HIGH"`
+	Category    *string    `json:"category" binding:"omitempty,oneof=WORK PERSONAL STUDY HEALTH SHOPPING OTHER"`
+	StartDate   *time.Time `json:"start_date"`
+	DueDate     *time.Time `json:"due_date"`
+}
+
+// syncTaskHandler はクライアントのタスクの変更をタスクサービスで適用する
+// 変更できるのは変更フィードでタスクを受け取るユーザー（作成者と担当者）に限る
+type syncTaskHandler struct {
+	tasks *taskUseCase.TaskService
+}
+
+// Get はタスクの現在の状態を取得する
+func (h *syncTaskHandler) Get(ctx context.Context, userID, entityID string) (interface{}, error) {
+	task, err := h.tasks.GetTask(ctx, entityID)
+	if errors.Is(err, taskUseCase.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mutationError(err)
+	}
+	if task.CreatedBy != userID && !task.HasAssignee(userID) {
+		return nil, fmt.Errorf("%w: permission denied", syncDomain.ErrMutationRejected)
+	}
+	return task, nil
+}
+
+// Create はクライアントが生成したIDでタスクを作成する
+func (h *syncTaskHandler) Create(ctx context.Context, userID, entityID string, data json.RawMessage) (interface{}, error) {
+	input, err := decodeSyncTaskData(data)
+	if err != nil {
+		return nil, err
+	}
+	if input.Title == nil {
+		return nil, fmt.Errorf("%w: title is required", syncDomain.ErrMutationRejected)
+	}
+	// 作成後に日時の検証で失敗しないよう、作成前に検証する
+	if input.StartDate != nil && input.DueDate != nil && input.StartDate.After(*input.DueDate) {
+		return nil, mutationError(taskUseCase.ErrStartAfterDue)
+	}
+
+	priority := taskDomain.PriorityMedium
+	if input.Priority != nil {
+		priority = taskDomain.Priority(*input.Priority)
+	}
+	category := taskDomain.CategoryOther
+	if input.Category != nil {
+		category = taskDomain.Category(*input.Category)
+	}
+	var description string
+	if input.Description != nil {
+		description = *input.Description
+	}
+
+	task, err := h.tasks.CreateTaskWithID(ctx, entityID, *input.Title, description, priority, category, userID)
+	if err != nil {
+		return nil, mutationError(err)
+	}
+
+	if input.StartDate != nil || input.DueDate != nil {
+		if task, err = h.tasks.UpdateTaskSchedule(ctx, task.ID, userID, input.StartDate, input.DueDate, false); err != nil {
+			return nil, mutationError(err)
+		}
+	}
+	if input.Status != nil {
+		status := taskDomain.TaskStatus(*input.Status)
+		if task, err = h.tasks.UpdateTaskAsUser(ctx, task.ID, userID, nil, nil, &status, nil, nil); err != nil {
+			return nil, mutationError(err)
+		}
+	}
+	return task, nil
+}
+
+// Update はタスクの指定されたフィールドを更新する
+func (h *syncTaskHandler) Update(ctx context.Context, userID, entityID string, data json.RawMessage) (interface{}, error) {
+	input, err := decodeSyncTaskData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 着手予定日時は期限と合わせて検証するため、期限とまとめて先に更新する
+	dueDate := input.DueDate
+	if input.StartDate != nil {
+		if _, err := h.tasks.UpdateTaskSchedule(ctx, entityID, userID, input.StartDate, dueDate, false); err != nil {
+			return nil, mutationError(err)
+		}
+		dueDate = nil
+	}
+
+	var status *taskDomain.TaskStatus
+	if input.Status != nil {
+		s := taskDomain.TaskStatus(*input.Status)
+		status = &s
+	}
+	var priority *taskDomain.Priority
+	if input.Priority != nil {
+		p := taskDomain.Priority(*input.Priority)
+		priority = &p
+	}
+
+	task, err := h.tasks.UpdateTaskAsUser(ctx, entityID, userID, input.Title, input.Description, status, priority, dueDate)
+	if err != nil {
+		return nil, mutationError(err)
+	}
+	return task, nil
+}
+
+// Delete はタスクを削除する（オフライン中に確定した削除のため、取り消し期間は設けない）
+func (h *syncTaskHandler) Delete(ctx context.Context, userID, entityID string) error {
+	return mutationError(h.tasks.DeleteTaskAsUser(ctx, entityID, userID))
+}
+
+// decodeSyncTaskData はタスクの変更内容を読み込んで検証する
+func decodeSyncTaskData(data json.RawMessage) (*syncTaskData, error) {
+	var input syncTaskData
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &input); err != nil {
+			return nil, fmt.Errorf("%w: %v", syncDomain.ErrMutationRejected, err)
+		}
+	}
+	if err := binding.Validator.ValidateStruct(&input); err != nil {
+		return nil, fmt.Errorf("%w: %v", syncDomain.ErrMutationRejected, err)
+	}
+	return &input, nil
+}
+
+// mutationError はタスクサービスのエラーのうち、変更の内容が原因のものをErrMutationRejectedに変換する
+func mutationError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, taskUseCase.ErrInvalidParameter),
+		errors.Is(err, taskUseCase.ErrUserNotFound),
+		errors.Is(err, taskUseCase.ErrTaskNotFound),
+		errors.Is(err, taskUseCase.ErrTaskIDConflict),
+		errors.Is(err, taskUseCase.ErrPermissionDenied),
+		errors.Is(err, commonDomain.ErrQuotaExceeded):
+		return fmt.Errorf("%w: %v", syncDomain.ErrMutationRejected, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,426 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hryt430/Yotei+/config"
+	"github.com/hryt430/Yotei+/internal/common/apiversion"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	userService "github.com/hryt430/Yotei+/internal/modules/auth/usecase/user"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	takeoutDomain "github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	takeoutMessaging "github.com/hryt430/Yotei+/internal/modules/takeout/infrastructure/messaging"
+	takeoutUseCase "github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	"github.com/hryt430/Yotei+/pkg/logger"
+)
+
+// takeoutPageSize はエクスポートのためにグループ・友達関係を取得する1ページの件数
+const takeoutPageSize = 100
+
+// takeoutProvider はユーザーの全データのエクスポートを組み立てる
+// 各モジュールのデータはこのファイルの取得元（takeoutUseCase.DataSource）から集める
+var takeoutProvider = provider{
+	name:     "takeout",
+	requires: []string{"storage", "notification", "auth", "task", "social", "group"},
+	provide: func(w *wiring) error {
+		repos, log := w.repos, w.log
+
+		sources := []takeoutUseCase.DataSource{
+			&takeoutTaskSource{exports: w.deps.ExportService},
+			&takeoutCommentSource{comments: repos.commentRepository},
+			&takeoutAttachmentSource{attachments: repos.attachmentRepository},
+			&takeoutGroupSource{groups: repos.groupRepository},
+			&takeoutFriendSource{friendships: repos.friendshipRepository, users: w.userService},
+			&takeoutStatsSource{exports: w.deps.ExportService},
+			&takeoutProfileSource{profiles: w.deps.ProfileService},
+			&takeoutWorkingHoursSource{workload: w.deps.WorkloadService},
+		}
+		takeoutService := takeoutUseCase.NewTakeoutService(
+			repos.dataExportRepository,
+			sources,
+			repos.fileStorage,
+			dataExportRetention(w.cfg, log),
+			log,
+		)
+		takeoutService.Notifier = &exportReadyNotifier{notificationUseCase: w.deps.NotificationUseCase}
+
+		exportWorker := takeoutMessaging.NewExportWorker(takeoutService, log)
+		takeoutService.Scheduler = exportWorker
+		w.deps.TakeoutService = takeoutService
+		w.lifecycle.Add("data-export-worker", exportWorker, 0)
+
+		// 参照されるセクション（親グループ）を先に復元する
+		restorers := []takeoutUseCase.DataRestorer{
+			&takeoutProfileRestorer{profiles: w.deps.ProfileService},
+			&takeoutWorkingHoursRestorer{workload: w.deps.WorkloadService},
+			&takeoutGroupRestorer{groups: w.deps.GroupService},
+			&takeoutTaskRestorer{tasks: w.taskService},
+		}
+		w.deps.DataImportService = takeoutUseCase.NewImportService(repos.dataImportRepository, restorers, log)
+		return nil
+	},
+}
+
+// dataExportRetention はエクスポートのZIPをダウンロードできる期間を返す（DATA_EXPORT_RETENTIONが不正な場合は既定の期間）
+func dataExportRetention(cfg *config.Config, log logger.Logger) time.Duration {
+	d, err := time.ParseDuration(cfg.Storage.DataExportRetention)
+	if err != nil || d <= 0 {
+		if cfg.Storage.DataExportRetention != "" {
+			log.Warn("Invalid DATA_EXPORT_RETENTION, using default", logger.Any("value", cfg.Storage.DataExportRetention))
+		}
+		return takeoutDomain.DefaultExportRetention
+	}
+	return d
+}
+
+// takeoutTaskSource はユーザーが作成または担当するタスクを取得する
+type takeoutTaskSource struct {
+	exports *taskUseCase.ExportService
+}
+
+func (s *takeoutTaskSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	section := &takeoutDomain.Section{
+		Name: "tasks",
+		Columns: []string{"id", "title", "description", "status", "priority", "category", "created_by", "assignees",
+			"start_date", "due_date", "completed_at", "estimate_minutes", "estimate_points", "actual_minutes", "created_at", "updated_at"},
+	}
+	err := s.exports.ExportTasks(ctx, userID.String(), func(task *taskDomain.Task) error {
+		assignees := make([]string, 0, len(task.Assignees))
+		for _, assignee := range task.Assignees {
+			assignees = append(assignees, assignee.UserID)
+		}
+		section.Records = append(section.Records, takeoutDomain.Record{
+			"id":               task.ID,
+			"title":            task.Title,
+			"description":      task.Description,
+			"status":           string(task.Status),
+			"priority":         string(task.Priority),
+			"category":         string(task.Category),
+			"created_by":       task.CreatedBy,
+			"assignees":        assignees,
+			"start_date":       optional(task.StartDate),
+			"due_date":         optional(task.DueDate),
+			"completed_at":     optional(task.CompletedAt),
+			"estimate_minutes": optional(task.EstimateMinutes),
+			"estimate_points":  optional(task.EstimatePoints),
+			"actual_minutes":   optional(task.ActualMinutes),
+			"created_at":       task.CreatedAt,
+			"updated_at":       task.UpdatedAt,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tasks: %w", err)
+	}
+	return section, nil
+}
+
+// takeoutProfileSource は公開プロフィールの設定を取得する
+type takeoutProfileSource struct {
+	profiles *socialUseCase.ProfileService
+}
+
+func (s *takeoutProfileSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	settings, err := s.profiles.GetProfileSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("profile: %w", err)
+	}
+	return &takeoutDomain.Section{
+		Name:    "profile",
+		Columns: []string{"user_id", "display_name", "avatar_url", "bio", "visibility", "show_stats"},
+		Records: []takeoutDomain.Record{{
+			"user_id":      userID.String(),
+			"display_name": settings.DisplayName,
+			"avatar_url":   settings.AvatarURL,
+			"bio":          settings.Bio,
+			"visibility":   string(settings.Visibility),
+			"show_stats":   settings.ShowStats,
+		}},
+	}, nil
+}
+
+// takeoutWorkingHoursSource は勤務時間の設定を取得する
+type takeoutWorkingHoursSource struct {
+	workload *taskUseCase.WorkloadService
+}
+
+func (s *takeoutWorkingHoursSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	hours, err := s.workload.GetWorkingHours(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("working hours: %w", err)
+	}
+	workDays := make([]int, 0, len(hours.WorkDays))
+	for _, day := range hours.WorkDays {
+		workDays = append(workDays, int(day))
+	}
+	return &takeoutDomain.Section{
+		Name:    "working_hours",
+		Columns: []string{"user_id", "timezone", "work_days", "start_time", "end_time", "daily_capacity_minutes", "holiday_policy", "reminder_timing"},
+		Records: []takeoutDomain.Record{{
+			"user_id":                userID.String(),
+			"timezone":               hours.Timezone,
+			"work_days":              workDays,
+			"start_time":             hours.StartTime,
+			"end_time":               hours.EndTime,
+			"daily_capacity_minutes": hours.DailyCapacityMinutes,
+			"holiday_policy":         string(hours.HolidayPolicy),
+			"reminder_timing":        string(hours.ReminderTiming),
+		}},
+	}, nil
+}
+
+// takeoutStatsSource はタスクの統計（期限内の完了・リードタイム）を取得する
+type takeoutStatsSource struct {
+	exports *taskUseCase.ExportService
+}
+
+func (s *takeoutStatsSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	section := &takeoutDomain.Section{
+		Name: "task_stats",
+		Columns: []string{"task_id", "status", "priority", "category", "created_at", "due_date", "completed_at",
+			"estimate_minutes", "actual_minutes", "is_overdue", "completed_late", "lead_time_hours"},
+	}
+	err := s.exports.ExportTaskStats(ctx, userID.String(), time.Now(), func(record *taskDomain.TaskStatsRecord) error {
+		section.Records = append(section.Records, takeoutDomain.Record{
+			"task_id":          record.TaskID,
+			"status":           string(record.Status),
+			"priority":         string(record.Priority),
+			"category":         string(record.Category),
+			"created_at":       record.CreatedAt,
+			"due_date":         optional(record.DueDate),
+			"completed_at":     optional(record.CompletedAt),
+			"estimate_minutes": optional(record.EstimateMinutes),
+			"actual_minutes":   optional(record.ActualMinutes),
+			"is_overdue":       record.IsOverdue,
+			"completed_late":   record.CompletedLate,
+			"lead_time_hours":  optional(record.LeadTimeHours),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("task stats: %w", err)
+	}
+	return section, nil
+}
+
+// takeoutCommentSource はユーザーが書いたコメントを取得する
+type takeoutCommentSource struct {
+	comments taskUseCase.CommentRepository
+}
+
+func (s *takeoutCommentSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	comments, err := s.comments.ListCommentsByUser(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("comments: %w", err)
+	}
+	section := &takeoutDomain.Section{
+		Name:    "comments",
+		Columns: []string{"id", "task_id", "comment", "created_at", "updated_at"},
+	}
+	for _, comment := range comments {
+		section.Records = append(section.Records, takeoutDomain.Record{
+			"id":         comment.ID,
+			"task_id":    comment.TaskID,
+			"comment":    comment.Comment,
+			"created_at": comment.CreatedAt,
+			"updated_at": comment.UpdatedAt,
+		})
+	}
+	return section, nil
+}
+
+// takeoutAttachmentSource はユーザーがアップロードした添付ファイルの情報を取得する（ファイル自体は含めない）
+type takeoutAttachmentSource struct {
+	attachments taskUseCase.AttachmentRepository
+}
+
+func (s *takeoutAttachmentSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	attachments, err := s.attachments.ListAttachmentsByUser(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("attachments: %w", err)
+	}
+	section := &takeoutDomain.Section{
+		Name:    "attachments",
+		Columns: []string{"id", "task_id", "filename", "mime_type", "size", "created_at"},
+	}
+	for _, attachment := range attachments {
+		section.Records = append(section.Records, takeoutDomain.Record{
+			"id":         attachment.ID,
+			"task_id":    attachment.TaskID,
+			"filename":   attachment.Filename,
+			"mime_type":  attachment.MimeType,
+			"size":       attachment.Size,
+			"created_at": attachment.CreatedAt,
+		})
+	}
+	return section, nil
+}
+
+// takeoutGroupSource はユーザーが参加しているグループと役割を取得する
+type takeoutGroupSource struct {
+	groups groupUseCase.GroupRepository
+}
+
+func (s *takeoutGroupSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	section := &takeoutDomain.Section{
+		Name:    "groups",
+		Columns: []string{"id", "name", "description", "type", "role", "parent_group_id", "member_count", "joined_at", "created_at"},
+	}
+	for page := 1; ; page++ {
+		groups, total, err := s.groups.ListGroupsByMember(ctx, userID, commonDomain.Pagination{Page: page, PageSize: takeoutPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("groups: %w", err)
+		}
+		for _, group := range groups {
+			member, err := s.groups.GetMember(ctx, group.ID, userID)
+			if err != nil {
+				return nil, fmt.Errorf("group members: %w", err)
+			}
+			record := takeoutDomain.Record{
+				"id":              group.ID.String(),
+				"name":            group.Name,
+				"description":     group.Description,
+				"type":            string(group.Type),
+				"role":            nil,
+				"parent_group_id": nil,
+				"member_count":    group.MemberCount,
+				"joined_at":       nil,
+				"created_at":      group.CreatedAt,
+			}
+			if member != nil {
+				record["role"] = string(member.Role)
+				record["joined_at"] = member.JoinedAt
+			}
+			if group.ParentGroupID != nil {
+				record["parent_group_id"] = group.ParentGroupID.String()
+			}
+			section.Records = append(section.Records, record)
+		}
+		if len(groups) < takeoutPageSize || len(section.Records) >= total {
+			return section, nil
+		}
+	}
+}
+
+// takeoutFriendSource はユーザーの友達・申請・ブロックの関係を取得する
+type takeoutFriendSource struct {
+	friendships socialUseCase.FriendshipRepository
+	users       *userService.UserService
+}
+
+func (s *takeoutFriendSource) Collect(ctx context.Context, userID uuid.UUID) (*takeoutDomain.Section, error) {
+	section := &takeoutDomain.Section{
+		Name:    "friends",
+		Columns: []string{"user_id", "username", "relationship", "requested_at", "accepted_at", "blocked_at"},
+	}
+	lists := []struct {
+		relationship string
+		list         func(commonDomain.Pagination) ([]*socialDomain.Friendship, error)
+	}{
+		{"FRIEND", func(p commonDomain.Pagination) ([]*socialDomain.Friendship, error) {
+			return s.friendships.GetFriends(ctx, userID, socialDomain.FriendSortRecentlyAdded, p)
+		}},
+		{"REQUEST_RECEIVED", func(p commonDomain.Pagination) ([]*socialDomain.Friendship, error) {
+			return s.friendships.GetPendingRequests(ctx, userID, p)
+		}},
+		{"REQUEST_SENT", func(p commonDomain.Pagination) ([]*socialDomain.Friendship, error) {
+			return s.friendships.GetSentRequests(ctx, userID, p)
+		}},
+		{"BLOCKED", func(p commonDomain.Pagination) ([]*socialDomain.Friendship, error) {
+			return s.friendships.GetBlockedUsers(ctx, userID, p)
+		}},
+	}
+
+	for _, l := range lists {
+		for page := 1; ; page++ {
+			friendships, err := l.list(commonDomain.Pagination{Page: page, PageSize: takeoutPageSize})
+			if err != nil {
+				return nil, fmt.Errorf("friends: %w", err)
+			}
+			for _, friendship := range friendships {
+				otherID := friendship.RequesterID
+				if otherID == userID {
+					otherID = friendship.AddresseeID
+				}
+				section.Records = append(section.Records, takeoutDomain.Record{
+					"user_id":      otherID.String(),
+					"username":     s.username(otherID),
+					"relationship": l.relationship,
+					"requested_at": friendship.CreatedAt,
+					"accepted_at":  optional(friendship.AcceptedAt),
+					"blocked_at":   optional(friendship.BlockedAt),
+				})
+			}
+			if len(friendships) < takeoutPageSize {
+				break
+			}
+		}
+	}
+	return section, nil
+}
+
+// username は相手のユーザー名を返す（退会などで取得できない場合は空）
+func (s *takeoutFriendSource) username(userID uuid.UUID) string {
+	if s.users == nil {
+		return ""
+	}
+	user, err := s.users.FindUserByID(userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Username
+}
+
+// optional はnilのポインタをnil、それ以外を値にする（JSONではnull、CSVでは空のセルになる）
+func optional[T any](p *T) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// exportReadyNotifier はエクスポートのダウンロードの準備ができたことをアプリ内通知で知らせる
+type exportReadyNotifier struct {
+	notificationUseCase notificationInput.NotificationUseCase
+}
+
+func (n *exportReadyNotifier) NotifyExportReady(ctx context.Context, export *takeoutDomain.Export) error {
+	expiresAt := ""
+	message := "データのエクスポートが完了しました。ダウンロードしてください。"
+	if export.ExpiresAt != nil {
+		expiresAt = export.ExpiresAt.UTC().Format(time.RFC3339)
+		message = fmt.Sprintf("データのエクスポートが完了しました。%s（UTC）までダウンロードできます。",
+			export.ExpiresAt.UTC().Format("2006/01/02 15:04"))
+	}
+
+	notification, err := n.notificationUseCase.CreateNotification(ctx, notificationInput.CreateNotificationInput{
+		UserID:  export.UserID.String(),
+		Type:    string(notificationDomain.SystemNotice),
+		Title:   "データのエクスポートの準備ができました",
+		Message: message,
+		Metadata: map[string]string{
+			"alert_type":   "data_export_ready",
+			"export_id":    export.ID.String(),
+			"download_url": apiversion.V1.Prefix() + "/me/export/" + export.ID.String() + "/download",
+			"expires_at":   expiresAt,
+		},
+		Channels: []string{"app"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create export notification: %w", err)
+	}
+	if err := n.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		return fmt.Errorf("failed to send export notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	groupDomain "github.com/hryt430/Yotei+/internal/modules/group/domain"
+	groupUseCase "github.com/hryt430/Yotei+/internal/modules/group/usecase"
+	socialDomain "github.com/hryt430/Yotei+/internal/modules/social/domain"
+	socialUseCase "github.com/hryt430/Yotei+/internal/modules/social/usecase"
+	takeoutDomain "github.com/hryt430/Yotei+/internal/modules/takeout/domain"
+	takeoutUseCase "github.com/hryt430/Yotei+/internal/modules/takeout/usecase"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+)
+
+// インポートで復元するグループの名前・説明の長さの上限（グループの作成APIと同じ）
+const (
+	maxImportedGroupNameLength        = 100
+	maxImportedGroupDescriptionLength = 500
+)
+
+// invalidRecord は復元先の入力エラーをインポートの不正なレコードとして扱う
+func invalidRecord(err error) error {
+	return fmt.Errorf("%w: %v", takeoutUseCase.ErrInvalidRecord, err)
+}
+
+// takeoutProfileRestorer は公開プロフィールの設定を復元する
+type takeoutProfileRestorer struct {
+	profiles *socialUseCase.ProfileService
+}
+
+func (r *takeoutProfileRestorer) Section() string { return "profile" }
+
+func (r *takeoutProfileRestorer) SourceID(record takeoutDomain.Record) string {
+	return record.String("user_id")
+}
+
+func (r *takeoutProfileRestorer) RestoreRecord(ctx context.Context, scope *takeoutUseCase.ImportScope, record takeoutDomain.Record) (string, error) {
+	displayName, avatarURL, bio := record.String("display_name"), record.String("avatar_url"), record.String("bio")
+	visibility := socialDomain.ProfileVisibility(record.String("visibility"))
+	showStats := record.Bool("show_stats")
+
+	_, err := r.profiles.UpdateProfileSettings(ctx, scope.UserID, socialUseCase.UpdateProfileSettingsInput{
+		DisplayName: &displayName,
+		AvatarURL:   &avatarURL,
+		Bio:         &bio,
+		Visibility:  &visibility,
+		ShowStats:   &showStats,
+	})
+	if errors.Is(err, socialDomain.ErrInvalidProfileInput) {
+		return "", invalidRecord(err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return scope.UserID.String(), nil
+}
+
+// takeoutWorkingHoursRestorer は勤務時間の設定を復元する
+type takeoutWorkingHoursRestorer struct {
+	workload *taskUseCase.WorkloadService
+}
+
+func (r *takeoutWorkingHoursRestorer) Section() string { return "working_hours" }
+
+func (r *takeoutWorkingHoursRestorer) SourceID(record takeoutDomain.Record) string {
+	return record.String("user_id")
+}
+
+func (r *takeoutWorkingHoursRestorer) RestoreRecord(ctx context.Context, scope *takeoutUseCase.ImportScope, record takeoutDomain.Record) (string, error) {
+	input := taskUseCase.WorkingHoursInput{
+		Timezone:       record.String("timezone"),
+		WorkDays:       record.Ints("work_days"),
+		StartTime:      record.String("start_time"),
+		EndTime:        record.String("end_time"),
+		HolidayPolicy:  record.String("holiday_policy"),
+		ReminderTiming: record.String("reminder_timing"),
+	}
+	if capacity := record.Int("daily_capacity_minutes"); capacity != nil {
+		input.DailyCapacityMinutes = *capacity
+	}
+
+	_, err := r.workload.UpdateWorkingHours(ctx, scope.UserID.String(), input)
+	if errors.Is(err, taskUseCase.ErrInvalidParameter) {
+		return "", invalidRecord(err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return scope.UserID.String(), nil
+}
+
+// takeoutGroupRestorer はユーザーが所有していたグループを復元する
+// 他のメンバーはこのサーバーに存在しないため、インポートしたユーザーだけが所属するグループとして作成する
+type takeoutGroupRestorer struct {
+	groups groupUseCase.GroupService
+}
+
+func (r *takeoutGroupRestorer) Section() string { return "groups" }
+
+func (r *takeoutGroupRestorer) SourceID(record takeoutDomain.Record) string {
+	return record.String("id")
+}
+
+func (r *takeoutGroupRestorer) RestoreRecord(ctx context.Context, scope *takeoutUseCase.ImportScope, record takeoutDomain.Record) (string, error) {
+	// メンバーとして参加していただけのグループは所有者がインポートする
+	if record.String("role") != string(groupDomain.RoleOwner) {
+		return "", takeoutUseCase.ErrSkipRecord
+	}
+
+	input := groupUseCase.CreateGroupInput{
+		Name:        record.String("name"),
+		Description: record.String("description"),
+		Type:        groupDomain.GroupType(record.String("type")),
+		OwnerID:     scope.UserID,
+	}
+	if input.Name == "" || len(input.Name) > maxImportedGroupNameLength || len(input.Description) > maxImportedGroupDescriptionLength {
+		return "", invalidRecord(errors.New("invalid group name or description"))
+	}
+	if input.Type != groupDomain.GroupTypeProject && input.Type != groupDomain.GroupTypeSchedule {
+		return "", invalidRecord(fmt.Errorf("invalid group type: %s", input.Type))
+	}
+
+	// 親グループを先に復元している場合のみサブチームとして作成する
+	if parentID := record.String("parent_group_id"); parentID != "" {
+		targetID, err := scope.TargetID(ctx, r.Section(), parentID)
+		if err != nil {
+			return "", err
+		}
+		if parent, err := uuid.Parse(targetID); err == nil {
+			input.ParentGroupID = &parent
+		}
+	}
+
+	group, err := r.groups.CreateGroup(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return group.ID.String(), nil
+}
+
+// takeoutTaskRestorer はタスクを新しいIDで復元する
+// 作成者はインポートしたユーザーにし、担当者はエクスポート元のユーザーが担当していた場合のみ引き継ぐ
+type takeoutTaskRestorer struct {
+	tasks *taskUseCase.TaskService
+}
+
+func (r *takeoutTaskRestorer) Section() string { return "tasks" }
+
+func (r *takeoutTaskRestorer) SourceID(record takeoutDomain.Record) string {
+	return record.String("id")
+}
+
+func (r *takeoutTaskRestorer) RestoreRecord(ctx context.Context, scope *takeoutUseCase.ImportScope, record takeoutDomain.Record) (string, error) {
+	userID := scope.UserID.String()
+	task := &taskDomain.Task{
+		Title:           record.String("title"),
+		Description:     record.String("description"),
+		Status:          taskDomain.TaskStatus(record.String("status")),
+		Priority:        taskDomain.Priority(record.String("priority")),
+		Category:        taskDomain.Category(record.String("category")),
+		CreatedBy:       userID,
+		StartDate:       record.Time("start_date"),
+		DueDate:         record.Time("due_date"),
+		CompletedAt:     record.Time("completed_at"),
+		EstimateMinutes: record.Int("estimate_minutes"),
+		EstimatePoints:  record.Int("estimate_points"),
+		ActualMinutes:   record.Int("actual_minutes"),
+	}
+	for _, assignee := range record.Strings("assignees") {
+		if assignee == scope.SourceUserID {
+			task.AddAssignee(userID)
+		}
+	}
+	// 担当者の追加で更新日時が変わるため、日時は最後に設定する
+	if createdAt := record.Time("created_at"); createdAt != nil {
+		task.CreatedAt = *createdAt
+	}
+	if updatedAt := record.Time("updated_at"); updatedAt != nil {
+		task.UpdatedAt = *updatedAt
+	}
+
+	created, err := r.tasks.ImportTask(ctx, task)
+	if errors.Is(err, taskUseCase.ErrInvalidParameter) {
+		return "", invalidRecord(err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
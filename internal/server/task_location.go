@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// taskGeofenceNotifier はジオフェンスに入ったユーザーにタスクのリマインダーを送る
+// アプリ内通知（WebSocket で接続中の端末に即時に届く）で送り、端末はこれをローカル通知として表示する
+type taskGeofenceNotifier struct {
+	notificationUseCase notificationInput.NotificationUseCase
+}
+
+func (n *taskGeofenceNotifier) NotifyGeofenceEnter(ctx context.Context, userID string, task *taskDomain.Task, location taskDomain.Location) error {
+	notification, err := n.notificationUseCase.CreateNotification(ctx, notificationInput.CreateNotificationInput{
+		UserID:  userID,
+		Type:    string(notificationDomain.TaskNearby),
+		Title:   fmt.Sprintf("%sの近くにいます", location.Name),
+		Message: fmt.Sprintf("「%s」をこの場所で済ませられます。", task.Title),
+		Metadata: map[string]string{
+			"action_url": fmt.Sprintf("/tasks/%s", task.ID),
+			"task_id":    task.ID,
+			"location":   location.Name,
+			"latitude":   strconv.FormatFloat(location.Latitude, 'f', -1, 64),
+			"longitude":  strconv.FormatFloat(location.Longitude, 'f', -1, 64),
+		},
+		Channels: []string{"app"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create geofence notification: %w", err)
+	}
+	if err := n.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		return fmt.Errorf("failed to send geofence notification: %w", err)
+	}
+	return nil
+}
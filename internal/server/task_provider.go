@@ -0,0 +1,320 @@
+package server
+
+import (
+	"time"
+
+	"github.com/hryt430/Yotei+/config"
+	commonDomain "github.com/hryt430/Yotei+/internal/common/domain"
+	"github.com/hryt430/Yotei+/pkg/logger"
+
+	featureFlagDomain "github.com/hryt430/Yotei+/internal/modules/featureflag/domain"
+	featureFlagUseCase "github.com/hryt430/Yotei+/internal/modules/featureflag/usecase"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+	taskGateway "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/gateway"
+	taskMessaging "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/messaging"
+	taskRedis "github.com/hryt430/Yotei+/internal/modules/task/infrastructure/redis"
+	taskUseCase "github.com/hryt430/Yotei+/internal/modules/task/usecase"
+	taskClassification "github.com/hryt430/Yotei+/internal/modules/task/usecase/classification"
+)
+
+// featureFlagProvider は機能フラグを組み立てる
+var featureFlagProvider = provider{
+	name:     "featureflag",
+	requires: []string{"storage"},
+	provide: func(w *wiring) error {
+		w.deps.FeatureFlagService = featureFlagUseCase.NewFeatureFlagService(
+			w.repos.featureFlagRepository,
+			featureFlagDefaults(w.cfg, w.log),
+			featureFlagCacheTTL(w.cfg, w.log),
+			w.log,
+		)
+		return nil
+	},
+}
+
+// taskProvider はタスクモジュールのサービスとワーカーを組み立てる
+var taskProvider = provider{
+	name:     "task",
+	requires: []string{"storage", "sync", "notification", "featureflag"},
+	provide: func(w *wiring) error {
+		cfg, log, repos := w.cfg, w.log, w.repos
+		taskRepository := repos.taskRepository
+		// グループの権限の確認はグループサービスに橋渡しする（groupProvider でグループサービスを設定する）
+		groupTaskResolver := &groupTaskPermissions{GroupTaskStore: repos.groupTaskResolver}
+		w.groupTaskPermissions = groupTaskResolver
+		userValidator := w.userValidator
+
+		// Event Publisher（修正版：戻り値統一）
+		notificationAdapter := taskMessaging.NewNotificationAdapter(w.deps.NotificationUseCase)
+		eventPublisher := taskMessaging.NewTaskEventPublisher(notificationAdapter, log)
+
+		// **Task Service（統一されたUserValidatorを使用）**
+		taskService := taskUseCase.NewTaskService(
+			taskRepository,
+			userValidator, // 統一されたUserValidatorを使用
+			eventPublisher,
+			log,
+		)
+		// クイック追加・推定APIでのカテゴリと優先度の推定（キーワードのルール）
+		taskService.Classifier = taskClassification.NewKeywordClassifier(taskClassification.DefaultRules)
+		// グループタスクの作成権限の確認（担当者の自動割り当てはグループモジュールで設定する）
+		taskService.GroupResolver = groupTaskResolver
+
+		// Stats Service
+		statsService := taskUseCase.NewTaskStatsService(
+			taskRepository,
+			repos.statsRepository,
+			&log,
+		)
+
+		// Daily Stats Service（タスクの変更で更新する日次統計の集計テーブル）
+		dailyStatsService := taskUseCase.NewDailyStatsService(
+			repos.statsRepository,
+			repos.dailyStatsRepository,
+			log,
+		)
+		statsService.SetDailyStatsSource(dailyStatsService)
+		taskService.DailyStats = dailyStatsService
+
+		// Escalation Service（期限切れタスクのエスカレーション）
+		escalationService := taskUseCase.NewEscalationService(
+			taskRepository,
+			repos.escalationRuleRepository,
+			groupTaskResolver,
+			userValidator,
+			eventPublisher,
+			log,
+		)
+
+		// Workload Service（勤務時間・キャパシティ）
+		workloadService := taskUseCase.NewWorkloadService(
+			taskRepository,
+			repos.workloadRepository,
+			groupTaskResolver,
+			log,
+		)
+		taskService.WorkloadChecker = workloadService
+		// 祝日・勤務日以外の期限の自動延期（勤務時間の設定の holiday_policy）
+		workloadService.Holidays = w.holidays
+		taskService.DueDateAdjuster = workloadService
+
+		// Reminder Service（勤務時間外・祝日の期限通知・着手通知・エスカレーションの通知を保留し、勤務時間の開始時にまとめて送る）
+		reminderService := taskUseCase.NewReminderService(
+			repos.workloadRepository,
+			repos.reminderRepository,
+			&taskReminderDigestNotifier{notificationUseCase: w.deps.NotificationUseCase},
+			log,
+		)
+		reminderService.Holidays = w.holidays
+		escalationService.Reminders = reminderService
+
+		// Mention Service（コメント・@メンション）
+		mentionService := taskUseCase.NewMentionService(
+			taskRepository,
+			repos.commentRepository,
+			repos.mentionRepository,
+			repos.mentionDirectory,
+			eventPublisher,
+			log,
+		)
+		mentionService.GroupResolver = groupTaskResolver
+		taskService.MentionProcessor = mentionService
+
+		// Link Preview Service（説明・コメント中のリンクのプレビュー、LINK_PREVIEW_ENABLED=falseの場合はnil）
+		var linkPreviewService *taskUseCase.LinkPreviewService
+		if cfg.LinkPreview.Enabled {
+			allowed, denied := cfg.GetLinkPreviewDomains()
+			policy := taskDomain.NewLinkPreviewPolicy(allowed, denied)
+			linkPreviewService = taskUseCase.NewLinkPreviewService(
+				repos.linkPreviewRepository,
+				taskGateway.NewLinkPreviewFetcher(policy),
+				policy,
+				linkPreviewCacheTTL(cfg, log),
+				log,
+			)
+			taskService.LinkPreviews = linkPreviewService
+			mentionService.LinkPreviews = linkPreviewService
+		}
+
+		// History Service（タスクの変更履歴）
+		historyService := taskUseCase.NewHistoryService(
+			repos.taskHistoryRepository,
+			taskRepository,
+			groupTaskResolver,
+			log,
+		)
+		historyService.Features = w.deps.FeatureFlagService
+		taskService.HistoryRecorder = historyService
+		escalationService.HistoryRecorder = historyService
+
+		// Attachment Service（タスクの添付ファイルとサムネイル）
+		attachmentService := taskUseCase.NewAttachmentService(
+			repos.attachmentRepository,
+			taskRepository,
+			groupTaskResolver,
+			repos.fileStorage,
+			cfg.Storage.AttachmentMaxBytes,
+			log,
+		)
+
+		// Weekly Report Service（週次レポートの作成とメール配信）
+		weeklyReportService := taskUseCase.NewWeeklyReportService(
+			repos.statsRepository,
+			repos.workloadRepository,
+			repos.weeklyReportRepository,
+			userValidator,
+			taskGateway.NewWeeklyReportMailer(cfg, w.smtpBreaker, log),
+			log,
+		)
+
+		// 変更フィードへの記録
+		taskService.SyncChanges = w.deps.SyncService
+		escalationService.SyncChanges = w.deps.SyncService
+		w.deps.SyncService.RegisterHandler(commonDomain.SyncEntityTask, &syncTaskHandler{tasks: taskService})
+
+		w.taskService = taskService
+		w.eventPublisher = eventPublisher
+		w.notificationAdapter = notificationAdapter
+		w.linkPreviewService = linkPreviewService
+
+		w.deps.StatsService = statsService
+		w.deps.EscalationService = escalationService
+		w.deps.WorkloadService = workloadService
+		w.deps.MentionService = mentionService
+		w.deps.HistoryService = historyService
+		w.deps.AttachmentService = attachmentService
+		w.deps.WeeklyReportService = weeklyReportService
+		// Share Service（公開共有リンク、SHORT_LINK_BASE_URLが設定されている場合は短縮URLも返す）
+		w.deps.ShareService = taskUseCase.NewShareService(taskRepository, repos.shareLinkRepository, log)
+		w.deps.ShareService.ShortURLs = newShareURLShortener(w)
+		// パスワードの試行回数はインスタンス間で共有する（Redisを使えない場合はこのプロセスのメモリで数える）
+		if w.redisClient != nil {
+			w.deps.ShareService.PasswordAttempts = taskRedis.NewSharePasswordAttemptStore(w.redisClient)
+		} else if w.embeddedStore != nil {
+			w.deps.ShareService.PasswordAttempts = taskRedis.NewEmbeddedSharePasswordAttemptStore(w.embeddedStore)
+		} else {
+			log.Warn("Redis not available, counting share link password attempts in process memory")
+		}
+		// Milestone Service（プロジェクトグループのマイルストーン）
+		w.deps.MilestoneService = taskUseCase.NewMilestoneService(taskRepository, repos.milestoneRepository, groupTaskResolver, log)
+		// Timeline Service（グループのタイムライン・タスクの依存関係）
+		w.deps.TimelineService = taskUseCase.NewTimelineService(taskRepository, repos.dependencyRepository, repos.milestoneRepository, groupTaskResolver, log)
+		// Calendar Service（日ごとの予定・祝日）
+		w.deps.CalendarService = taskUseCase.NewCalendarService(repos.statsRepository, repos.workloadRepository, log)
+		w.deps.CalendarService.Holidays = w.holidays
+		// Export Service（タスク・統計の全件エクスポート）
+		w.deps.ExportService = taskUseCase.NewExportService(repos.taskExportRepository, log)
+		// Flow Service（変更履歴から作るバーンダウン・累積フロー図）
+		w.deps.FlowService = taskUseCase.NewFlowService(taskRepository, repos.taskHistoryRepository, groupTaskResolver, repos.workloadRepository, log)
+		// Today Service（ホーム画面の今日の予定）
+		w.deps.TodayService = taskUseCase.NewTodayService(repos.statsRepository, repos.workloadRepository, w.deps.NotificationUseCase, log)
+		// Location Service（タスクの場所・近くのタスク・ジオフェンスのリマインダー）
+		w.deps.LocationService = taskUseCase.NewLocationService(repos.locationRepository, taskRepository, groupTaskResolver, log)
+		w.deps.LocationService.Notifier = &taskGeofenceNotifier{notificationUseCase: w.deps.NotificationUseCase}
+		taskService.Locations = repos.locationRepository
+		// Issue Link Service（GitHubのIssue・Pull Requestの関連付け、Webhookでのマージ・完了によるタスクの完了）
+		w.deps.IssueLinkService = taskUseCase.NewIssueLinkService(
+			repos.issueLinkRepository,
+			taskRepository,
+			groupTaskResolver,
+			taskGateway.NewGitHubGateway(cfg.External.GitHubAPIURL, cfg.External.GitHubWebhookSecret, w.githubBreaker, log),
+			log,
+		)
+		w.deps.IssueLinkService.StatusChanger = taskService
+		// Task Link Service（ユーザーのリンクとGitHub・予定の連携のリンクをまとめてタスクの詳細に表示する）
+		w.deps.TaskLinkService = taskUseCase.NewTaskLinkService(repos.taskLinkRepository, taskRepository, groupTaskResolver, log)
+		w.deps.TaskLinkService.Issues = w.deps.IssueLinkService
+		taskService.Links = w.deps.TaskLinkService
+		// Inbound Email Service（ユーザーごとのアドレスに届いたメールからタスクを作成する）
+		w.deps.InboundEmailService = taskUseCase.NewInboundEmailService(
+			repos.inboundEmailRepository,
+			taskGateway.NewMailgunGateway(cfg.External.MailgunWebhookSigningKey, log),
+			taskService,
+			userValidator,
+			cfg.External.InboundEmailDomain,
+			log,
+		)
+		w.deps.InboundEmailService.Attachments = attachmentService
+		w.deps.InboundEmailService.MaxPerHour = cfg.External.InboundEmailMaxPerHour
+		// Clip Service（ブラウザの拡張機能でクリップしたページからタスクを作成する）
+		w.deps.ClipService = taskUseCase.NewClipService(taskService, w.deps.TaskLinkService, log)
+
+		registerTaskWorkers(w, dailyStatsService, reminderService)
+		return nil
+	},
+}
+
+// registerTaskWorkers はタスクモジュールのスケジューラーとワーカーを作成し、ライフサイクルに登録する
+func registerTaskWorkers(w *wiring, dailyStatsService *taskUseCase.DailyStatsService, reminderService *taskUseCase.ReminderService) {
+	log := w.log
+
+	// **タスク期限通知スケジューラー（統一されたUserValidatorを使用）**
+	w.deps.TaskScheduler = taskMessaging.NewTaskDueNotificationScheduler(
+		*w.taskService,
+		w.notificationAdapter,
+		w.eventPublisher,
+		log,
+	)
+	w.deps.TaskScheduler.SetReminderScheduler(reminderService)
+	w.lifecycle.Add("task-due-scheduler", w.deps.TaskScheduler, 0)
+
+	// 勤務時間外のため保留した通知の配信ワーカー
+	w.deps.ReminderWorker = taskMessaging.NewReminderDigestWorker(reminderService, log)
+	w.lifecycle.Add("reminder-digest-worker", w.deps.ReminderWorker, 0)
+
+	// エスカレーションワーカー
+	w.deps.EscalationWorker = taskMessaging.NewEscalationWorker(w.deps.EscalationService, log)
+	w.lifecycle.Add("escalation-worker", w.deps.EscalationWorker, 0)
+
+	// 週次レポートの配信ワーカー
+	w.deps.WeeklyReportWorker = taskMessaging.NewWeeklyReportWorker(w.deps.WeeklyReportService, log)
+	w.lifecycle.Add("weekly-report-worker", w.deps.WeeklyReportWorker, 0)
+
+	// 日次統計の夜間の再集計ワーカー
+	w.deps.DailyStatsWorker = taskMessaging.NewDailyStatsWorker(dailyStatsService, log)
+	w.lifecycle.Add("daily-stats-worker", w.deps.DailyStatsWorker, 0)
+
+	// サムネイル生成ワーカー（アップロードされた画像のサムネイルを非同期に生成する）
+	thumbnailWorker := taskMessaging.NewThumbnailWorker(w.deps.AttachmentService, log)
+	w.deps.AttachmentService.Thumbnails = thumbnailWorker
+	w.deps.ThumbnailWorker = thumbnailWorker
+	w.lifecycle.Add("thumbnail-worker", thumbnailWorker, 0)
+
+	// リンクのプレビュー取得ワーカー（説明・コメント中のリンクのプレビューを非同期に取得する）
+	if w.linkPreviewService != nil {
+		linkPreviewWorker := taskMessaging.NewLinkPreviewWorker(w.linkPreviewService, log)
+		w.linkPreviewService.Queue = linkPreviewWorker
+		w.deps.LinkPreviewWorker = linkPreviewWorker
+		w.lifecycle.Add("link-preview-worker", linkPreviewWorker, 0)
+	}
+}
+
+// featureFlagDefaults は設定から機能フラグの既定値を読み込む（不正な場合は既定値なしで起動する）
+func featureFlagDefaults(cfg *config.Config, log logger.Logger) map[string]*featureFlagDomain.Flag {
+	flags, err := featureFlagDomain.ParseFlagDefaults(cfg.Features.Flags)
+	if err != nil {
+		log.Warn("Invalid FEATURE_FLAGS, ignoring", logger.Any("value", cfg.Features.Flags), logger.Error(err))
+		return nil
+	}
+	return flags
+}
+
+// featureFlagCacheTTL は設定から機能フラグのキャッシュ期間を読み込む（0でキャッシュしない）
+func featureFlagCacheTTL(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.Features.CacheTTL); err == nil && d >= 0 {
+		return d
+	} else if cfg.Features.CacheTTL != "" {
+		log.Warn("Invalid FEATURE_FLAG_CACHE_TTL, using default", logger.Any("value", cfg.Features.CacheTTL))
+	}
+	return featureFlagUseCase.DefaultCacheTTL
+}
+
+// linkPreviewCacheTTL は設定からリンクのプレビューを再取得するまでの期間を読み込む（0で再取得しない）
+func linkPreviewCacheTTL(cfg *config.Config, log logger.Logger) time.Duration {
+	if d, err := time.ParseDuration(cfg.LinkPreview.CacheTTL); err == nil && d >= 0 {
+		return d
+	} else if cfg.LinkPreview.CacheTTL != "" {
+		log.Warn("Invalid LINK_PREVIEW_CACHE_TTL, using default", logger.Any("value", cfg.LinkPreview.CacheTTL))
+	}
+	return 24 * time.Hour
+}
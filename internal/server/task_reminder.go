@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	notificationDomain "github.com/hryt430/Yotei+/internal/modules/notification/domain"
+	notificationInput "github.com/hryt430/Yotei+/internal/modules/notification/usecase/input"
+	taskDomain "github.com/hryt430/Yotei+/internal/modules/task/domain"
+)
+
+// taskReminderDigestNotifier は勤務時間外のため保留したリマインダー・エスカレーションを1件のアプリ内通知にまとめて送る
+type taskReminderDigestNotifier struct {
+	notificationUseCase notificationInput.NotificationUseCase
+}
+
+func (n *taskReminderDigestNotifier) NotifyReminderDigest(ctx context.Context, userID string, reminders []*taskDomain.DeferredReminder) error {
+	title, message := taskDomain.ReminderDigest(reminders)
+	// 1件のタスクのみの場合はタスクを、複数の場合はタスク一覧を開く
+	actionURL := "/tasks"
+	if len(reminders) == 1 {
+		actionURL = fmt.Sprintf("/tasks/%s", reminders[0].TaskID)
+	}
+
+	notification, err := n.notificationUseCase.CreateNotification(ctx, notificationInput.CreateNotificationInput{
+		UserID:  userID,
+		Type:    string(notificationDomain.TaskReminders),
+		Title:   title,
+		Message: message,
+		Metadata: map[string]string{
+			"action_url":     actionURL,
+			"reminder_count": strconv.Itoa(len(reminders)),
+		},
+		Channels: []string{"app"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reminder digest notification: %w", err)
+	}
+	if err := n.notificationUseCase.SendNotification(ctx, notification.GetID()); err != nil {
+		return fmt.Errorf("failed to send reminder digest notification: %w", err)
+	}
+	return nil
+}
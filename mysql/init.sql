@@ -10,6 +10,7 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`users` (
     role ENUM('user', 'admin') DEFAULT 'user',
     email_verified BOOLEAN DEFAULT FALSE,
     last_login TIMESTAMP NULL,
+    deactivated_at TIMESTAMP NULL, -- NULL while the account is active
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     INDEX idx_email (email),
@@ -39,9 +40,17 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`tasks` (
     description TEXT,
     status ENUM('TODO', 'IN_PROGRESS', 'DONE') DEFAULT 'TODO',
     priority ENUM('LOW', 'MEDIUM', 'HIGH') DEFAULT 'MEDIUM',
+    category ENUM('WORK', 'PERSONAL', 'STUDY', 'HEALTH', 'SHOPPING', 'OTHER') DEFAULT 'OTHER',
     assignee_id VARCHAR(36) NULL,
     created_by VARCHAR(36) NOT NULL,
     due_date TIMESTAMP NULL,
+    start_date TIMESTAMP NULL,
+    estimate_minutes INT NULL,
+    estimate_points INT NULL,
+    actual_minutes INT NULL,
+    completed_at TIMESTAMP NULL,
+    require_all_assignees BOOLEAN NOT NULL DEFAULT FALSE,
+    checklist JSON NULL, -- extracted from the markdown task list items of the description
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (assignee_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE SET NULL,
@@ -51,19 +60,37 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`tasks` (
     INDEX idx_assignee_id (assignee_id),
     INDEX idx_created_by (created_by),
     INDEX idx_due_date (due_date),
+    INDEX idx_start_date (start_date),
+    INDEX idx_category (category),
+    INDEX idx_completed_at (completed_at),
     INDEX idx_created_at (created_at),
+    INDEX idx_assignee_status_due (assignee_id, status, due_date), -- per-user overdue lookups
+    INDEX idx_created_by_status_due (created_by, status, due_date),
     FULLTEXT idx_search (title, description)
 );
 
+-- Task assignees table (assignee_id on tasks holds the primary assignee)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_assignees` (
+    task_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP NULL,
+    PRIMARY KEY (task_id, user_id),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_user_completed (user_id, completed_at)
+);
+
 -- Notifications table
 CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`notifications` (
     id VARCHAR(36) PRIMARY KEY,
     user_id VARCHAR(36) NOT NULL,
     title VARCHAR(255) NOT NULL,
     message TEXT NOT NULL,
-    type ENUM('APP_NOTIFICATION', 'TASK_ASSIGNED', 'TASK_COMPLETED', 'TASK_DUE_SOON', 'SYSTEM_NOTICE') DEFAULT 'APP_NOTIFICATION',
+    type ENUM('APP_NOTIFICATION', 'TASK_ASSIGNED', 'TASK_COMPLETED', 'TASK_DUE_SOON', 'TASK_MENTIONED', 'SYSTEM_NOTICE') DEFAULT 'APP_NOTIFICATION',
     status ENUM('PENDING', 'SENT', 'READ', 'FAILED') DEFAULT 'PENDING',
     metadata JSON NULL,
+    group_key VARCHAR(255) NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     sent_at TIMESTAMP NULL,
@@ -71,7 +98,8 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`notifications` (
     INDEX idx_user_id (user_id),
     INDEX idx_status (status),
     INDEX idx_type (type),
-    INDEX idx_created_at (created_at)
+    INDEX idx_created_at (created_at),
+    INDEX idx_user_group (user_id, group_key, updated_at)
 );
 
 -- Task comments table (optional feature)
@@ -89,6 +117,41 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_comments` (
     INDEX idx_created_at (created_at)
 );
 
+-- Task mentions table (@username in descriptions and comments)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_mentions` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    source_type ENUM('DESCRIPTION', 'COMMENT') NOT NULL,
+    source_id VARCHAR(36) NOT NULL,
+    mentioned_user_id VARCHAR(36) NOT NULL,
+    author_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (mentioned_user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_mention (source_type, source_id, mentioned_user_id),
+    INDEX idx_mentioned_user_created (mentioned_user_id, created_at),
+    INDEX idx_task_id (task_id)
+);
+
+-- Task share links table (read-only public links)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_share_links` (
+    id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(64) NOT NULL,
+    owner_id VARCHAR(36) NOT NULL,
+    target_type ENUM('TASK', 'TASK_LIST') NOT NULL,
+    task_id VARCHAR(36) NULL,
+    filter JSON NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_token (token),
+    INDEX idx_owner_created (owner_id, created_at)
+);
+
 -- Task attachments table (optional feature)
 CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_attachments` (
     id VARCHAR(36) PRIMARY KEY,
@@ -97,11 +160,14 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_attachments` (
     file_path VARCHAR(500) NOT NULL,
     file_size BIGINT NOT NULL,
     mime_type VARCHAR(100) NOT NULL,
+    -- NONE (not an image), PENDING, READY or FAILED
+    thumbnail_status VARCHAR(16) NOT NULL DEFAULT 'NONE',
     uploaded_by VARCHAR(36) NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
     FOREIGN KEY (uploaded_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
-    INDEX idx_task_id (task_id)
+    INDEX idx_task_id (task_id),
+    INDEX idx_task_attachments_thumbnail (thumbnail_status, created_at)
 );
 
 -- User roles table (for more complex role management)
@@ -151,9 +217,13 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`friendships` (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     accepted_at TIMESTAMP NULL,
     blocked_at TIMESTAMP NULL,
+    reminder_sent_at TIMESTAMP NULL,
+    -- Unordered user pair: one row per pair regardless of who sent the request
+    user_pair VARCHAR(73) AS (IF(requester_id < addressee_id, CONCAT(requester_id, ':', addressee_id), CONCAT(addressee_id, ':', requester_id))) STORED,
     FOREIGN KEY (requester_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
     FOREIGN KEY (addressee_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
     UNIQUE KEY unique_friendship (requester_id, addressee_id),
+    UNIQUE KEY unique_friendship_pair (user_pair),
     INDEX idx_requester_id (requester_id),
     INDEX idx_addressee_id (addressee_id),
     INDEX idx_status (status),
@@ -165,7 +235,7 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`invitations` (
     id VARCHAR(36) PRIMARY KEY,
     type ENUM('FRIEND', 'GROUP') NOT NULL,
     method ENUM('IN_APP', 'CODE', 'URL') NOT NULL,
-    status ENUM('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELED') DEFAULT 'PENDING',
+    status ENUM('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELED', 'UNDELIVERABLE') DEFAULT 'PENDING',
     inviter_id VARCHAR(36) NOT NULL,
     invitee_id VARCHAR(36) NULL,
     invitee_email VARCHAR(255) NULL,
@@ -180,12 +250,16 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`invitations` (
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     accepted_at TIMESTAMP NULL,
+    email_sent_at TIMESTAMP NULL,
+    email_send_count INT NOT NULL DEFAULT 0,
+    bounce_reason VARCHAR(255) NULL,
     FOREIGN KEY (inviter_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
     FOREIGN KEY (invitee_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
     FOREIGN KEY (target_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE,
     UNIQUE KEY unique_code (code),
     INDEX idx_inviter_id (inviter_id),
     INDEX idx_invitee_id (invitee_id),
+    INDEX idx_invitee_email (invitee_email),
     INDEX idx_code (code),
     INDEX idx_status (status),
     INDEX idx_type (type),
@@ -200,6 +274,7 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`groups` (
     description TEXT NULL,
     type ENUM('PROJECT', 'SCHEDULE') NOT NULL,
     owner_id VARCHAR(36) NOT NULL,
+    parent_group_id VARCHAR(36) NULL, -- Set for sub-teams of a project group
     member_count INT DEFAULT 1,
     is_public BOOLEAN DEFAULT FALSE,
     allow_member_invite BOOLEAN DEFAULT TRUE,
@@ -215,7 +290,9 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`groups` (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     version INT DEFAULT 1,
     FOREIGN KEY (owner_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_groups_parent FOREIGN KEY (parent_group_id) REFERENCES `Yotei-Plus`.`groups`(id) ON DELETE SET NULL,
     INDEX idx_owner_id (owner_id),
+    INDEX idx_parent_group_id (parent_group_id),
     INDEX idx_type (type),
     INDEX idx_is_public (is_public),
     INDEX idx_created_at (created_at),
@@ -227,7 +304,7 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_members` (
     id VARCHAR(36) PRIMARY KEY,
     group_id VARCHAR(36) NOT NULL,
     user_id VARCHAR(36) NOT NULL,
-    role ENUM('OWNER', 'ADMIN', 'MEMBER') DEFAULT 'MEMBER',
+    role VARCHAR(36) NOT NULL DEFAULT 'MEMBER', -- OWNER/ADMIN/MEMBER/GUEST or the id of a group_roles row
     joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE,
@@ -239,6 +316,56 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_members` (
     INDEX idx_joined_at (joined_at)
 );
 
+-- Group permissions table (only actions changed from the defaults are stored)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_permissions` (
+    group_id VARCHAR(36) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    roles VARCHAR(100) NOT NULL DEFAULT '', -- comma-separated ADMIN/MEMBER; the owner is always allowed
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, action),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Group custom roles table (members reference these by id in group_members.role)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_roles` (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    permissions VARCHAR(255) NOT NULL DEFAULT '', -- comma-separated configurable actions
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_group_role_name (group_id, name)
+);
+
+-- Group task auto-assignment settings (policy applied when a group task has no assignee)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_assignment_settings` (
+    group_id VARCHAR(36) PRIMARY KEY,
+    policy VARCHAR(20) NOT NULL DEFAULT 'NONE', -- NONE/ROUND_ROBIN/LEAST_LOADED
+    last_assignee_id VARCHAR(36) NULL, -- member picked last, the round-robin cursor
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Group leaderboard settings (opt-in, weekly periods start Monday 00:00 in timezone)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_leaderboard_settings` (
+    group_id VARCHAR(36) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Leaderboard visibility per member (members without a row are VISIBLE)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_leaderboard_preferences` (
+    group_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'VISIBLE', -- VISIBLE/ANONYMOUS/HIDDEN
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, user_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
 -- Group tasks table (extending tasks with group context)
 CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_tasks` (
     id VARCHAR(36) PRIMARY KEY,
@@ -252,6 +379,669 @@ CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_tasks` (
     INDEX idx_group_id (group_id)
 );
 
+-- Project group milestones (ordered within the group)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_milestones` (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    due_date TIMESTAMP NOT NULL,
+    position INT NOT NULL DEFAULT 0, -- display order within the group, starting at 0
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_group_position (group_id, position)
+);
+
+-- Tasks attached to milestones (a task belongs to at most one milestone)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`milestone_tasks` (
+    task_id VARCHAR(36) PRIMARY KEY,
+    milestone_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (milestone_id) REFERENCES `Yotei-Plus`.task_milestones(id) ON DELETE CASCADE,
+    INDEX idx_milestone_id (milestone_id)
+);
+
+-- Finish-to-start dependencies between group tasks (used by the group timeline)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_dependencies` (
+    task_id VARCHAR(36) NOT NULL,
+    depends_on_task_id VARCHAR(36) NOT NULL, -- must be completed before task_id starts
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_task_id),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (depends_on_task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_depends_on (depends_on_task_id)
+);
+
+-- Append-only task change events (replayed to reconstruct a task at any point in time)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_events` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    sequence BIGINT NOT NULL, -- 1-based, per task
+    type ENUM('CREATED', 'FIELD_CHANGED', 'STATUS_CHANGED') NOT NULL,
+    actor_id VARCHAR(36) NULL, -- NULL for changes made by the system
+    changes JSON NOT NULL, -- new values of the changed fields, keyed by field name
+    occurred_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_task_sequence (task_id, sequence),
+    INDEX idx_task_occurred_at (task_id, occurred_at)
+);
+
+-- Task state snapshots (taken every 20 events so replays start from the latest one)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_snapshots` (
+    task_id VARCHAR(36) NOT NULL,
+    sequence BIGINT NOT NULL,
+    state JSON NOT NULL,
+    taken_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (task_id, sequence),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    INDEX idx_task_taken_at (task_id, taken_at)
+);
+
+-- Task escalation rules table (per-user / per-group overdue escalation)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_escalation_rules` (
+    id VARCHAR(36) PRIMARY KEY,
+    scope ENUM('USER', 'GROUP') NOT NULL,
+    owner_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    overdue_hours INT NOT NULL DEFAULT 0,
+    raise_priority BOOLEAN DEFAULT TRUE,
+    notify_assignee BOOLEAN DEFAULT TRUE,
+    notify_group_admins BOOLEAN DEFAULT FALSE,
+    reassign_to VARCHAR(36) NULL,
+    enabled BOOLEAN DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (reassign_to) REFERENCES `Yotei-Plus`.users(id) ON DELETE SET NULL,
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_scope_owner (scope, owner_id),
+    INDEX idx_enabled (enabled)
+);
+
+-- Task escalation history (prevents applying the same rule twice)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_escalations` (
+    task_id VARCHAR(36) NOT NULL,
+    rule_id VARCHAR(36) NOT NULL,
+    escalated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, rule_id),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (rule_id) REFERENCES `Yotei-Plus`.task_escalation_rules(id) ON DELETE CASCADE
+);
+
+-- User working hours table (workload capacity settings)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`user_working_hours` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    work_days VARCHAR(20) NOT NULL DEFAULT '1,2,3,4,5',
+    start_time CHAR(5) NOT NULL DEFAULT '09:00',
+    end_time CHAR(5) NOT NULL DEFAULT '18:00',
+    daily_capacity_minutes INT NOT NULL DEFAULT 0,
+    holiday_policy VARCHAR(32) NOT NULL DEFAULT 'KEEP', -- KEEP, NEXT_BUSINESS_DAY or PREVIOUS_BUSINESS_DAY
+    reminder_timing VARCHAR(32) NOT NULL DEFAULT 'WORKING_HOURS', -- WORKING_HOURS or ANYTIME
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Feature flags changed by administrators (override the FEATURE_FLAGS defaults)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`feature_flags` (
+    flag_key VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE, -- master switch; when FALSE the flag is off for everyone
+    percentage TINYINT UNSIGNED NOT NULL DEFAULT 0, -- share of users (0-100) enabled by hashing flag_key and user id
+    users JSON NOT NULL, -- user ids enabled regardless of the percentage
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Security audit log of authentication events (rows older than SECURITY_AUDIT_RETENTION are deleted by the application)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`security_events` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NULL, -- NULL when the user is unknown (e.g. login with an unregistered email); kept after the user is deleted
+    type VARCHAR(32) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    detail VARCHAR(255) NOT NULL DEFAULT '', -- failure reason or the denied route
+    created_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_security_events_user (user_id, created_at),
+    INDEX idx_security_events_type (type, created_at),
+    INDEX idx_security_events_created_at (created_at)
+);
+
+-- Devices users have logged in from, and per-user settings for suspicious login alerts
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`login_devices` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 of the User-Agent
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    trusted BOOLEAN NOT NULL DEFAULT FALSE, -- trusted devices are not asked for a verification code
+    trusted_until TIMESTAMP(6) NULL, -- remembered after a verified login; trusted until then when the signed cookie is presented
+    trust_token_hash CHAR(64) NOT NULL DEFAULT '', -- SHA-256 of the cookie token
+    last_ip VARCHAR(45) NOT NULL DEFAULT '',
+    last_country CHAR(2) NULL, -- NULL when GeoIP is disabled or the location is unknown
+    last_latitude DOUBLE NULL,
+    last_longitude DOUBLE NULL,
+    verification_code_hash CHAR(64) NOT NULL DEFAULT '', -- set while a login from this device awaits its emailed code
+    verification_expires_at TIMESTAMP(6) NULL,
+    verification_attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(6) NOT NULL,
+    last_seen_at TIMESTAMP(6) NULL, -- NULL until a login from this device completes
+    UNIQUE KEY uq_login_devices_user_fingerprint (user_id, fingerprint),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`login_alert_settings` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    require_verification BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Admin-issued API keys (SCIM provisioning)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`api_keys` (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    display_prefix VARCHAR(16) NOT NULL, -- first characters of the key, shown in the key list
+    key_hash CHAR(64) NOT NULL, -- SHA-256 of the key; the key itself is never stored
+    scopes VARCHAR(255) NOT NULL, -- comma separated
+    created_by VARCHAR(36) NOT NULL, -- the admin who issued the key; owns groups created through SCIM
+    created_at TIMESTAMP(6) NOT NULL,
+    last_used_at TIMESTAMP(6) NULL,
+    revoked_at TIMESTAMP(6) NULL,
+    UNIQUE KEY uq_api_keys_key_hash (key_hash),
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sso_connections` (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    domains VARCHAR(1000) NOT NULL, -- comma separated, lower case; a domain belongs to one connection
+    issuer VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(512) NOT NULL,
+    enforced BOOLEAN NOT NULL DEFAULT FALSE, -- password login is rejected for the domains (admins excepted)
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    role_claim VARCHAR(100) NOT NULL DEFAULT '', -- empty: roles are not synchronized from the IdP
+    admin_values VARCHAR(1000) NOT NULL DEFAULT '', -- comma separated claim values mapped to admin
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sso_identities` (
+    connection_id VARCHAR(36) NOT NULL,
+    subject VARCHAR(255) NOT NULL, -- the IdP's sub claim
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (connection_id, subject),
+    FOREIGN KEY (connection_id) REFERENCES `Yotei-Plus`.sso_connections(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`billing_subscriptions` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    plan_id VARCHAR(20) NOT NULL, -- pro or team
+    status VARCHAR(30) NOT NULL, -- Stripe subscription status (active, trialing, past_due, canceled, ...)
+    stripe_customer_id VARCHAR(255) NOT NULL DEFAULT '',
+    stripe_subscription_id VARCHAR(255) NULL,
+    current_period_end TIMESTAMP NULL,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    last_event_at TIMESTAMP NULL, -- creation time of the last applied event; older events are ignored
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_billing_subscriptions_stripe (stripe_subscription_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`billing_events` (
+    event_id VARCHAR(255) PRIMARY KEY, -- Stripe event id; webhooks are retried and may arrive twice
+    event_type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP(6) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`analytics_preferences` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE, -- users without a row are opted in
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Single-row sequence; allocating from it inside the writing transaction keeps commit order equal to seq order,
+-- so a client cursor never skips a change that commits later with a smaller seq.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_sequence` (
+    id TINYINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    pruned_through BIGINT NOT NULL DEFAULT 0 -- cursors older than this must do a full resync
+);
+
+INSERT IGNORE INTO `Yotei-Plus`.`sync_sequence` (id, last_seq, pruned_through) VALUES (1, 0, 0);
+
+-- One row per recipient; op is 'upsert' (data holds the entity snapshot) or 'delete' (tombstone, data is NULL)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_changes` (
+    seq BIGINT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 0, -- version the entity had after the change
+    data JSON NULL,
+    changed_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_sync_changes_user_seq (user_id, seq),
+    INDEX idx_sync_changes_changed_at (changed_at)
+);
+
+-- Users currently receiving changes for an entity; users dropped from this set get a tombstone
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_entity_recipients` (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, user_id)
+);
+
+-- Current version per entity; pushed updates and deletes must be based on it
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_entity_versions` (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    version BIGINT NOT NULL,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+-- Result per client mutation id, so a resent batch returns the original results instead of applying twice
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_mutations` (
+    user_id VARCHAR(36) NOT NULL,
+    client_mutation_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- accepted, conflict or rejected
+    version BIGINT NOT NULL DEFAULT 0,
+    data JSON NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    applied_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (user_id, client_mutation_id),
+    INDEX idx_sync_mutations_applied_at (applied_at)
+);
+
+-- Cached OpenGraph metadata for links in task descriptions and comments (keyed by the SHA-256 of the URL)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`link_previews` (
+    url_hash CHAR(64) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    image_url VARCHAR(2048) NOT NULL DEFAULT '',
+    site_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL, -- READY or FAILED
+    fetched_at TIMESTAMP(6) NOT NULL
+);
+
+-- Weekly report email subscriptions (last_sent_week is the ISO week of the last report sent)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`weekly_report_subscriptions` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    last_sent_week VARCHAR(8) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Daily stats per user and UTC day, updated by task changes and reconciled nightly
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`daily_stats` (
+    user_id VARCHAR(36) NOT NULL,
+    stat_date DATE NOT NULL,
+    total_tasks INT NOT NULL DEFAULT 0,
+    completed_tasks INT NOT NULL DEFAULT 0,
+    in_progress_tasks INT NOT NULL DEFAULT 0,
+    todo_tasks INT NOT NULL DEFAULT 0,
+    overdue_tasks INT NOT NULL DEFAULT 0,
+    stale_at TIMESTAMP(6) NULL, -- earliest future due date among open tasks
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (user_id, stat_date),
+    INDEX idx_daily_stats_date (stat_date, user_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Notification dead letters: channel deliveries that still failed after retrying
+-- (redriven_at is set once an admin re-drive succeeds, so pending rows are the NULL ones)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`notification_dead_letters` (
+    id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    redriven_at TIMESTAMP(6) NULL,
+    FOREIGN KEY (notification_id) REFERENCES `Yotei-Plus`.notifications(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_dead_letters_pending (redriven_at, created_at)
+);
+
+-- Short links: /s/{slug} redirects for invitation and share URLs
+-- (one row per issuing invitation or share link; expires_at is NULL when it never expires)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`short_links` (
+    slug VARCHAR(16) PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    reference VARCHAR(64) NOT NULL,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    last_clicked_at TIMESTAMP(6) NULL,
+    expires_at TIMESTAMP(6) NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uq_short_links_reference (kind, reference)
+);
+
+-- Public profile settings: display name, avatar, bio and who can see them
+-- (users without a row have a PUBLIC profile with stats hidden)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`profile_settings` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    display_name VARCHAR(50) NOT NULL DEFAULT '',
+    avatar_url VARCHAR(512) NOT NULL DEFAULT '',
+    bio VARCHAR(160) NOT NULL DEFAULT '',
+    visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC', -- PUBLIC, FRIENDS or PRIVATE
+    show_stats BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Username history: previous usernames kept so old @mentions and profile links resolve
+-- (a previous username stays reserved for its owner for USERNAME_HOLD_PERIOD after the change)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`username_history` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    old_username VARCHAR(255) NOT NULL,
+    new_username VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_username_history_user (user_id, changed_at),
+    INDEX idx_username_history_old (old_username, changed_at),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Data exports: asynchronous "download my data" archives (POST /me/export)
+-- (file_key is cleared once expires_at passes and the stored zip is deleted)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`data_exports` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- PENDING, PROCESSING, READY, FAILED or EXPIRED
+    file_key VARCHAR(255) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) NOT NULL,
+    started_at TIMESTAMP(6) NULL,
+    completed_at TIMESTAMP(6) NULL,
+    expires_at TIMESTAMP(6) NULL,
+    INDEX idx_data_exports_user (user_id, created_at),
+    INDEX idx_data_exports_status (status, created_at),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Data imports: source/target id mapping for records restored from an export archive (POST /me/import)
+-- (importing the same archive again skips every (user_id, section, source_id) already recorded)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`data_import_records` (
+    user_id VARCHAR(36) NOT NULL,
+    section VARCHAR(64) NOT NULL,
+    source_id VARCHAR(64) NOT NULL, -- id on the server that produced the archive
+    target_id VARCHAR(64) NOT NULL, -- id of the restored record on this server
+    imported_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (user_id, section, source_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Notification preferences (users without a row get the defaults: social_digest = DAILY)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`notification_preferences` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    social_digest VARCHAR(16) NOT NULL DEFAULT 'DAILY', -- OFF, DAILY or WEEKLY
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Pending social actions digest: last digest sent to each user
+-- (deleted, and the notification marked read, once nothing is pending)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`social_digest_states` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL,
+    last_sent_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Group event RSVPs (event_id is a group task id, or an occurrence id for recurring events;
+-- members without a row have not responded)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_rsvps` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- YES, NO, MAYBE or NO_RESPONSE (attendance recorded without an answer)
+    attended BOOLEAN NULL, -- NULL until attendance is recorded
+    responded_at TIMESTAMP(6) NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id, user_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Events whose RSVP reminder has been sent (at most once per event)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_reminders` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    reminded_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Group event recurrence (the event's start is the first occurrence; occurrences
+-- repeat at the same wall clock time in timezone)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_recurrences` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    frequency VARCHAR(16) NOT NULL, -- DAILY, WEEKLY or MONTHLY
+    interval_count INT NOT NULL DEFAULT 1,
+    weekdays VARCHAR(32) NOT NULL DEFAULT '', -- comma separated MO..SU (WEEKLY only)
+    occurrence_count INT NOT NULL DEFAULT 0, -- 0 = no limit
+    until_at TIMESTAMP(6) NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    holiday_policy VARCHAR(32) NOT NULL DEFAULT '', -- '' (keep), SKIP or NEXT_BUSINESS_DAY
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id),
+    INDEX idx_group_event_recurrences_until (until_at),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Cancelled or moved occurrences of recurring group events
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_exceptions` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    original_starts_at TIMESTAMP(6) NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at TIMESTAMP(6) NULL, -- new start of a moved occurrence
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id, original_starts_at),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Meeting notes attached to group events (event_id is the task id or an occurrence id)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_notes` (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL, -- markdown
+    created_by VARCHAR(36) NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_group_event_notes_event (group_id, event_id, created_at),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Tasks created from the unchecked "- [ ]" items of a note
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_note_action_items` (
+    note_id VARCHAR(36) NOT NULL,
+    group_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    item_text VARCHAR(500) NOT NULL,
+    assignee_id VARCHAR(36) NULL, -- first mentioned member
+    created_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (note_id, task_id),
+    INDEX idx_group_note_action_items_task (group_id, task_id),
+    FOREIGN KEY (note_id) REFERENCES `Yotei-Plus`.group_event_notes(id) ON DELETE CASCADE
+);
+
+-- Links between group events and tasks (BLOCK: work block for the task, FOLLOW_UP: task created from the event)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_task_links` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(64) NOT NULL, -- task id or occurrence id
+    task_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (event_id, task_id),
+    INDEX idx_group_event_task_links_task (task_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Chat integrations of groups: events are posted to the channel webhook of each platform
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_chat_integrations` (
+    group_id VARCHAR(36) NOT NULL,
+    platform VARCHAR(20) NOT NULL, -- DISCORD or TEAMS
+    webhook_url VARCHAR(1000) NOT NULL,
+    events VARCHAR(100) NOT NULL, -- comma-separated TASK_COMPLETED, TASK_ASSIGNED, TASK_DUE_SOON, DAILY_SUMMARY
+    templates TEXT NOT NULL, -- JSON object of the changed message templates per event
+    summary_hour TINYINT NOT NULL DEFAULT 9,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_summary_date VARCHAR(10) NOT NULL DEFAULT '', -- local date of the last daily summary
+    due_reminder_hours SMALLINT NOT NULL DEFAULT 24, -- hours before the due date to post TASK_DUE_SOON
+    due_checked_until TIMESTAMP(6) NULL, -- due dates up to this time have been reminded
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, platform),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Task locations (events are group tasks, so they share the location of their task)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_locations` (
+    task_id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    latitude DOUBLE NOT NULL,
+    longitude DOUBLE NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_task_locations_lat_lng (latitude, longitude),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- Geofences around task locations registered by mobile devices (one per user and task)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_geofences` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    radius_meters INT NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    last_triggered_at TIMESTAMP(6) NULL, -- throttles repeated reminders
+    UNIQUE KEY uk_task_geofences_user_task (user_id, task_id),
+    INDEX idx_task_geofences_task (task_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- Reminders held outside the recipient's working hours (one per user, task and kind)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`deferred_reminders` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    task_title VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL, -- DUE_SOON, START or ESCALATION
+    deliver_at TIMESTAMP(6) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_deferred_reminders_user_task_kind (user_id, task_id, kind),
+    INDEX idx_deferred_reminders_deliver_at (deliver_at),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- GitHub issues and pull requests linked to tasks (state synced by the GitHub webhook)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_issue_links` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    repository VARCHAR(150) NOT NULL, -- owner/name in lower case
+    number INT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    complete_on_close BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_task_issue_links_task_issue (task_id, provider, repository, number),
+    INDEX idx_task_issue_links_issue (provider, repository, number),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- GitHub tokens users register to read issues in private repositories (one per user)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`github_accounts` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    login VARCHAR(100) NOT NULL,
+    token VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Links users add to tasks (LINK, DOCUMENT or DESIGN)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_links` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_task_links_task (task_id, created_at),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- Per-user addresses for creating tasks from email (<token>@INBOUND_EMAIL_DOMAIN)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`inbound_email_addresses` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(64) NOT NULL,
+    allowed_senders JSON NOT NULL, -- lowercase addresses or "@domain" entries
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_inbound_email_addresses_token (token),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Emails turned into tasks (duplicate Message-ID check and hourly limit)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`inbound_emails` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    sender VARCHAR(255) NOT NULL,
+    task_id VARCHAR(36) NOT NULL, -- no foreign key: deleting the task keeps the hourly limit
+    received_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_inbound_emails_user_received (user_id, received_at),
+    INDEX idx_inbound_emails_user_message (user_id, message_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Group schedule card settings (cards are only rendered once enabled)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_schedule_card_settings` (
+    group_id VARCHAR(36) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    show_titles BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
 -- Create indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_tasks_compound ON `Yotei-Plus`.tasks (status, assignee_id, due_date);
 CREATE INDEX IF NOT EXISTS idx_notifications_compound ON `Yotei-Plus`.notifications (user_id, status, created_at);
@@ -0,0 +1,23 @@
+-- Multi-assignee tasks
+-- Moves single assignees (tasks.assignee_id) into task_assignees.
+-- Run once against databases created before task_assignees existed.
+
+ALTER TABLE `Yotei-Plus`.`tasks`
+    ADD COLUMN require_all_assignees BOOLEAN NOT NULL DEFAULT FALSE AFTER completed_at;
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_assignees` (
+    task_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP NULL,
+    PRIMARY KEY (task_id, user_id),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_user_completed (user_id, completed_at)
+);
+
+-- Existing assignees keep their completion state from the task status
+INSERT IGNORE INTO `Yotei-Plus`.`task_assignees` (task_id, user_id, assigned_at, completed_at)
+SELECT id, assignee_id, updated_at, IF(status = 'DONE', COALESCE(completed_at, updated_at), NULL)
+FROM `Yotei-Plus`.`tasks`
+WHERE assignee_id IS NOT NULL;
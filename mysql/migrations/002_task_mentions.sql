@@ -0,0 +1,21 @@
+-- @mentions in task descriptions and comments
+-- Run once against databases created before task_mentions existed.
+
+ALTER TABLE `Yotei-Plus`.`notifications`
+    MODIFY COLUMN type ENUM('APP_NOTIFICATION', 'TASK_ASSIGNED', 'TASK_COMPLETED', 'TASK_DUE_SOON', 'TASK_MENTIONED', 'SYSTEM_NOTICE') DEFAULT 'APP_NOTIFICATION';
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_mentions` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    source_type ENUM('DESCRIPTION', 'COMMENT') NOT NULL,
+    source_id VARCHAR(36) NOT NULL,
+    mentioned_user_id VARCHAR(36) NOT NULL,
+    author_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (mentioned_user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_mention (source_type, source_id, mentioned_user_id),
+    INDEX idx_mentioned_user_created (mentioned_user_id, created_at),
+    INDEX idx_task_id (task_id)
+);
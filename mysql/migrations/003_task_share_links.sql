@@ -0,0 +1,19 @@
+-- Read-only public share links for tasks and task lists
+-- Run once against databases created before task_share_links existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_share_links` (
+    id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(64) NOT NULL,
+    owner_id VARCHAR(36) NOT NULL,
+    target_type ENUM('TASK', 'TASK_LIST') NOT NULL,
+    task_id VARCHAR(36) NULL,
+    filter JSON NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_token (token),
+    INDEX idx_owner_created (owner_id, created_at)
+);
@@ -0,0 +1,6 @@
+-- Collapse rapid notifications per actor and entity
+-- Run once against databases created before notifications.group_key existed.
+
+ALTER TABLE `Yotei-Plus`.`notifications`
+    ADD COLUMN group_key VARCHAR(255) NULL AFTER metadata,
+    ADD INDEX idx_user_group (user_id, group_key, updated_at);
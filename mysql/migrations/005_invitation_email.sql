@@ -0,0 +1,8 @@
+-- Send invitation emails with resend cooldown and bounce handling
+-- Run once against databases created before invitations.email_sent_at existed.
+
+ALTER TABLE `Yotei-Plus`.`invitations`
+    MODIFY COLUMN status ENUM('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELED', 'UNDELIVERABLE') DEFAULT 'PENDING',
+    ADD COLUMN email_sent_at TIMESTAMP NULL AFTER accepted_at,
+    ADD COLUMN email_send_count INT NOT NULL DEFAULT 0 AFTER email_sent_at,
+    ADD COLUMN bounce_reason VARCHAR(255) NULL AFTER email_send_count;
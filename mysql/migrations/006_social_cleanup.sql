@@ -0,0 +1,5 @@
+-- Cleanup job for expired invitations and stale friend requests
+-- Run once against databases created before friendships.reminder_sent_at existed.
+
+ALTER TABLE `Yotei-Plus`.`friendships`
+    ADD COLUMN reminder_sent_at TIMESTAMP NULL AFTER blocked_at;
@@ -0,0 +1,11 @@
+-- Enforce one friendship row per user pair and allow multiple invitations without a code
+-- Run once against databases created before friendships.user_pair existed.
+
+-- IN_APP invitations have no code; store NULL so they do not collide on unique_code
+UPDATE `Yotei-Plus`.`invitations` SET code = NULL WHERE code = '';
+UPDATE `Yotei-Plus`.`invitations` SET url = NULL WHERE url = '';
+
+-- Fails if both A->B and B->A rows already exist; delete one of each pair before running
+ALTER TABLE `Yotei-Plus`.`friendships`
+    ADD COLUMN user_pair VARCHAR(73) AS (IF(requester_id < addressee_id, CONCAT(requester_id, ':', addressee_id), CONCAT(addressee_id, ':', requester_id))) STORED AFTER reminder_sent_at,
+    ADD UNIQUE KEY unique_friendship_pair (user_pair);
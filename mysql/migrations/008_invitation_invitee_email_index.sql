@@ -0,0 +1,5 @@
+-- Look up pending email invitations when a user registers with the invited address
+-- Run once against databases created before invitations.idx_invitee_email existed.
+
+ALTER TABLE `Yotei-Plus`.`invitations`
+    ADD INDEX idx_invitee_email (invitee_email);
@@ -0,0 +1,7 @@
+-- Allow project groups to have sub-teams
+-- Run once against databases created before groups.parent_group_id existed.
+
+ALTER TABLE `Yotei-Plus`.`groups`
+    ADD COLUMN parent_group_id VARCHAR(36) NULL AFTER owner_id,
+    ADD CONSTRAINT fk_groups_parent FOREIGN KEY (parent_group_id) REFERENCES `Yotei-Plus`.`groups`(id) ON DELETE SET NULL,
+    ADD INDEX idx_parent_group_id (parent_group_id);
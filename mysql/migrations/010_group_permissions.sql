@@ -0,0 +1,11 @@
+-- Per-group permission matrix (which roles may run each configurable action)
+-- Run once against databases created before group_permissions existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_permissions` (
+    group_id VARCHAR(36) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    roles VARCHAR(100) NOT NULL DEFAULT '', -- comma-separated ADMIN/MEMBER; the owner is always allowed
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, action),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
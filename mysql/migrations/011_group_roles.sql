@@ -0,0 +1,17 @@
+-- Custom roles defined per group, assignable to members alongside OWNER/ADMIN/MEMBER
+-- Run once against databases created before group_roles existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_roles` (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    permissions VARCHAR(255) NOT NULL DEFAULT '', -- comma-separated configurable actions
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_group_role_name (group_id, name)
+);
+
+-- group_members.role now holds either a built-in role or the id of a group_roles row
+ALTER TABLE `Yotei-Plus`.`group_members`
+    MODIFY COLUMN role VARCHAR(36) NOT NULL DEFAULT 'MEMBER';
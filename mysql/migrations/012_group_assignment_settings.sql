@@ -0,0 +1,10 @@
+-- Per-group auto-assignment policy for group tasks created without an assignee
+-- Run once against databases created before group_assignment_settings existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_assignment_settings` (
+    group_id VARCHAR(36) PRIMARY KEY,
+    policy VARCHAR(20) NOT NULL DEFAULT 'NONE', -- NONE/ROUND_ROBIN/LEAST_LOADED
+    last_assignee_id VARCHAR(36) NULL, -- member picked last, the round-robin cursor
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
@@ -0,0 +1,26 @@
+-- Ordered milestones in project groups and the tasks attached to them
+-- Run once against databases created before task_milestones existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_milestones` (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    due_date TIMESTAMP NOT NULL,
+    position INT NOT NULL DEFAULT 0, -- display order within the group, starting at 0
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_group_position (group_id, position)
+);
+
+-- A task belongs to at most one milestone
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`milestone_tasks` (
+    task_id VARCHAR(36) PRIMARY KEY,
+    milestone_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (milestone_id) REFERENCES `Yotei-Plus`.task_milestones(id) ON DELETE CASCADE,
+    INDEX idx_milestone_id (milestone_id)
+);
@@ -0,0 +1,14 @@
+-- Finish-to-start dependencies between group tasks (used by the group timeline)
+-- Run once against databases created before task_dependencies existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_dependencies` (
+    task_id VARCHAR(36) NOT NULL,
+    depends_on_task_id VARCHAR(36) NOT NULL, -- must be completed before task_id starts
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_task_id),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (depends_on_task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_depends_on (depends_on_task_id)
+);
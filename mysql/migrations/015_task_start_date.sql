@@ -0,0 +1,6 @@
+-- Planned start date of tasks (start_date <= due_date is enforced by the application)
+-- Run once against databases created before tasks.start_date existed.
+
+ALTER TABLE `Yotei-Plus`.`tasks`
+    ADD COLUMN start_date TIMESTAMP NULL AFTER due_date,
+    ADD INDEX idx_start_date (start_date);
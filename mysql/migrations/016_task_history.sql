@@ -0,0 +1,26 @@
+-- Append-only task change events and periodic snapshots used to replay a task's state at any point in time
+-- Run once against databases created before task_events existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_events` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    sequence BIGINT NOT NULL, -- 1-based, per task
+    type ENUM('CREATED', 'FIELD_CHANGED', 'STATUS_CHANGED') NOT NULL,
+    actor_id VARCHAR(36) NULL, -- NULL for changes made by the system (escalation rules, history baselines)
+    changes JSON NOT NULL, -- new values of the changed fields, keyed by field name
+    occurred_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_task_sequence (task_id, sequence),
+    INDEX idx_task_occurred_at (task_id, occurred_at)
+);
+
+-- Full task state after the event with the same sequence (taken every 20 events)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_snapshots` (
+    task_id VARCHAR(36) NOT NULL,
+    sequence BIGINT NOT NULL,
+    state JSON NOT NULL,
+    taken_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (task_id, sequence),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE,
+    INDEX idx_task_taken_at (task_id, taken_at)
+);
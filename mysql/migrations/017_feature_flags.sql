@@ -0,0 +1,11 @@
+-- Feature flags changed by administrators; they override the FEATURE_FLAGS defaults from the environment
+-- Run once against databases created before feature_flags existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`feature_flags` (
+    flag_key VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE, -- master switch; when FALSE the flag is off for everyone
+    percentage TINYINT UNSIGNED NOT NULL DEFAULT 0, -- share of users (0-100) enabled by hashing flag_key and user id
+    users JSON NOT NULL, -- user ids enabled regardless of the percentage
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
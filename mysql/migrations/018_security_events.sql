@@ -0,0 +1,16 @@
+-- Security audit log of authentication events (logins, token refreshes, password changes, permission denials)
+-- Run once against databases created before security_events existed.
+-- Rows older than SECURITY_AUDIT_RETENTION are deleted by the application.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`security_events` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NULL, -- NULL when the user is unknown (e.g. login with an unregistered email); kept after the user is deleted
+    type VARCHAR(32) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    detail VARCHAR(255) NOT NULL DEFAULT '', -- failure reason or the denied route
+    created_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_security_events_user (user_id, created_at),
+    INDEX idx_security_events_type (type, created_at),
+    INDEX idx_security_events_created_at (created_at)
+);
@@ -0,0 +1,29 @@
+-- Devices users have logged in from, and per-user settings for suspicious login alerts
+-- Run once against databases created before login_devices existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`login_devices` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 of the User-Agent
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    trusted BOOLEAN NOT NULL DEFAULT FALSE, -- trusted devices are not asked for a verification code
+    last_ip VARCHAR(45) NOT NULL DEFAULT '',
+    last_country CHAR(2) NULL, -- NULL when GeoIP is disabled or the location is unknown
+    last_latitude DOUBLE NULL,
+    last_longitude DOUBLE NULL,
+    verification_code_hash CHAR(64) NOT NULL DEFAULT '', -- set while a login from this device awaits its emailed code
+    verification_expires_at TIMESTAMP(6) NULL,
+    verification_attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(6) NOT NULL,
+    last_seen_at TIMESTAMP(6) NULL, -- NULL until a login from this device completes
+    UNIQUE KEY uq_login_devices_user_fingerprint (user_id, fingerprint),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`login_alert_settings` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    require_verification BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
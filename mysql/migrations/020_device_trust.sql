@@ -0,0 +1,6 @@
+-- Remembered devices: after a verified login a device can be trusted for 30 days via a signed cookie
+-- Run once against databases created before login_devices.trusted_until existed.
+
+ALTER TABLE `Yotei-Plus`.`login_devices`
+    ADD COLUMN trusted_until TIMESTAMP(6) NULL AFTER trusted,
+    ADD COLUMN trust_token_hash CHAR(64) NOT NULL DEFAULT '' AFTER trusted_until; -- SHA-256 of the cookie token
@@ -0,0 +1,19 @@
+-- SCIM provisioning: admin-issued API keys and deactivated (deprovisioned) accounts
+-- Run once against databases created before api_keys existed.
+
+ALTER TABLE `Yotei-Plus`.`users`
+    ADD COLUMN deactivated_at TIMESTAMP NULL AFTER last_login; -- NULL while the account is active
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`api_keys` (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    display_prefix VARCHAR(16) NOT NULL, -- first characters of the key, shown in the key list
+    key_hash CHAR(64) NOT NULL, -- SHA-256 of the key; the key itself is never stored
+    scopes VARCHAR(255) NOT NULL, -- comma separated
+    created_by VARCHAR(36) NOT NULL, -- the admin who issued the key; owns groups created through SCIM
+    created_at TIMESTAMP(6) NOT NULL,
+    last_used_at TIMESTAMP(6) NULL,
+    revoked_at TIMESTAMP(6) NULL,
+    UNIQUE KEY uq_api_keys_key_hash (key_hash),
+    FOREIGN KEY (created_by) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,27 @@
+-- Enterprise SSO: OIDC connections per email domain and links between IdP subjects and users
+-- Run once against databases created before sso_connections existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sso_connections` (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    domains VARCHAR(1000) NOT NULL, -- comma separated, lower case; a domain belongs to one connection
+    issuer VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(512) NOT NULL,
+    enforced BOOLEAN NOT NULL DEFAULT FALSE, -- password login is rejected for the domains (admins excepted)
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    role_claim VARCHAR(100) NOT NULL DEFAULT '', -- empty: roles are not synchronized from the IdP
+    admin_values VARCHAR(1000) NOT NULL DEFAULT '', -- comma separated claim values mapped to admin
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sso_identities` (
+    connection_id VARCHAR(36) NOT NULL,
+    subject VARCHAR(255) NOT NULL, -- the IdP's sub claim
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (connection_id, subject),
+    FOREIGN KEY (connection_id) REFERENCES `Yotei-Plus`.sso_connections(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
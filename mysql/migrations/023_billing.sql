@@ -0,0 +1,23 @@
+-- Billing: paid plan subscriptions synchronized from Stripe webhooks and processed webhook events
+-- Run once against databases created before billing_subscriptions existed.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`billing_subscriptions` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    plan_id VARCHAR(20) NOT NULL, -- pro or team
+    status VARCHAR(30) NOT NULL, -- Stripe subscription status (active, trialing, past_due, canceled, ...)
+    stripe_customer_id VARCHAR(255) NOT NULL DEFAULT '',
+    stripe_subscription_id VARCHAR(255) NULL,
+    current_period_end TIMESTAMP NULL,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    last_event_at TIMESTAMP NULL, -- creation time of the last applied event; older events are ignored
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_billing_subscriptions_stripe (stripe_subscription_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`billing_events` (
+    event_id VARCHAR(255) PRIMARY KEY, -- Stripe event id; webhooks are retried and may arrive twice
+    event_type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP(6) NOT NULL
+);
@@ -0,0 +1,10 @@
+-- Analytics: per-user opt-out of product usage analytics
+-- Run once against databases created before analytics_preferences existed.
+-- Events themselves are written to the configured sink (file, S3 or BigQuery), not to MySQL.
+
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`analytics_preferences` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE, -- users without a row are opted in
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,33 @@
+-- Sync: change feed for offline-first clients (GET /sync/changes)
+-- Run once against databases created before sync_changes existed.
+
+-- Single-row sequence; allocating from it inside the writing transaction keeps commit order equal to seq order,
+-- so a client cursor never skips a change that commits later with a smaller seq.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_sequence` (
+    id TINYINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    pruned_through BIGINT NOT NULL DEFAULT 0 -- cursors older than this must do a full resync
+);
+
+INSERT IGNORE INTO `Yotei-Plus`.`sync_sequence` (id, last_seq, pruned_through) VALUES (1, 0, 0);
+
+-- One row per recipient; op is 'upsert' (data holds the entity snapshot) or 'delete' (tombstone, data is NULL)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_changes` (
+    seq BIGINT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    data JSON NULL,
+    changed_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_sync_changes_user_seq (user_id, seq),
+    INDEX idx_sync_changes_changed_at (changed_at)
+);
+
+-- Users currently receiving changes for an entity; users dropped from this set get a tombstone
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_entity_recipients` (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, user_id)
+);
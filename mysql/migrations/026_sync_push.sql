@@ -0,0 +1,29 @@
+-- Sync: entity versions and idempotent client mutations (POST /sync/push)
+-- Run once against databases created before sync_entity_versions existed.
+
+-- Version the entity had after the change (the last seq of the batch that recorded it)
+ALTER TABLE `Yotei-Plus`.`sync_changes`
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 0 AFTER operation;
+
+-- Current version per entity; pushed updates and deletes must be based on it
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_entity_versions` (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    version BIGINT NOT NULL,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+-- Result per client mutation id, so a resent batch returns the original results instead of applying twice
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`sync_mutations` (
+    user_id VARCHAR(36) NOT NULL,
+    client_mutation_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- accepted, conflict or rejected
+    version BIGINT NOT NULL DEFAULT 0,
+    data JSON NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    applied_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (user_id, client_mutation_id),
+    INDEX idx_sync_mutations_applied_at (applied_at)
+);
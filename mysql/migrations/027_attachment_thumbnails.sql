@@ -0,0 +1,12 @@
+-- Attachments: asynchronous thumbnail generation for image attachments
+-- Run once against databases created before task_attachments.thumbnail_status existed.
+
+-- NONE for non-image files, PENDING until the thumbnail worker has generated every size, then READY or FAILED
+ALTER TABLE `Yotei-Plus`.`task_attachments`
+    ADD COLUMN thumbnail_status VARCHAR(16) NOT NULL DEFAULT 'NONE' AFTER mime_type,
+    ADD INDEX idx_task_attachments_thumbnail (thumbnail_status, created_at);
+
+-- Queue existing images so the worker generates their thumbnails
+UPDATE `Yotei-Plus`.`task_attachments`
+SET thumbnail_status = 'PENDING'
+WHERE mime_type IN ('image/jpeg', 'image/png', 'image/gif');
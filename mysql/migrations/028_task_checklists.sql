@@ -0,0 +1,7 @@
+-- Markdown descriptions: checklist extracted from the task list items (- [ ] / - [x]) of the description
+-- Run once against databases created before tasks.checklist existed.
+
+-- JSON array of {"text", "checked"}; NULL when the description has no task list items.
+-- Existing tasks get their checklist the next time their description is edited.
+ALTER TABLE `Yotei-Plus`.`tasks`
+    ADD COLUMN checklist JSON NULL AFTER require_all_assignees;
@@ -0,0 +1,15 @@
+-- Link previews: cached OpenGraph metadata for links in task descriptions and comments
+-- Run once against databases created before link_previews existed.
+
+-- Keyed by the SHA-256 of the URL because URLs can be longer than an index allows.
+-- FAILED rows remember unreachable links so they are not fetched again for a while.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`link_previews` (
+    url_hash CHAR(64) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    image_url VARCHAR(2048) NOT NULL DEFAULT '',
+    site_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL, -- READY or FAILED
+    fetched_at TIMESTAMP(6) NOT NULL
+);
@@ -0,0 +1,13 @@
+-- Weekly reports: email delivery subscriptions for the weekly personal report
+-- Run once against databases created before weekly_report_subscriptions existed.
+
+-- last_sent_week is the ISO week (e.g. 2024-W23) of the last report sent, so a
+-- report is delivered at most once per week even if the worker runs repeatedly.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`weekly_report_subscriptions` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    last_sent_week VARCHAR(8) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
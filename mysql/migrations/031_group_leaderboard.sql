@@ -0,0 +1,22 @@
+-- Group leaderboard: opt-in settings per group and visibility per member
+-- Run once against databases created before group_leaderboard_settings existed.
+
+-- timezone decides where the weekly period starts (Monday 00:00); the
+-- leaderboard is only shown once enabled.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_leaderboard_settings` (
+    group_id VARCHAR(36) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Members without a row are VISIBLE.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_leaderboard_preferences` (
+    group_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'VISIBLE', -- VISIBLE/ANONYMOUS/HIDDEN
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, user_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
@@ -0,0 +1,20 @@
+-- Daily stats: per-user, per-day (UTC) counts kept up to date by task changes
+-- Run once against databases created before daily_stats existed.
+
+-- stale_at is the earliest future due date among open tasks; rows read after
+-- it are recomputed because the overdue count has changed. A nightly job
+-- recomputes the last week of rows to repair missed updates.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`daily_stats` (
+    user_id VARCHAR(36) NOT NULL,
+    stat_date DATE NOT NULL,
+    total_tasks INT NOT NULL DEFAULT 0,
+    completed_tasks INT NOT NULL DEFAULT 0,
+    in_progress_tasks INT NOT NULL DEFAULT 0,
+    todo_tasks INT NOT NULL DEFAULT 0,
+    overdue_tasks INT NOT NULL DEFAULT 0,
+    stale_at TIMESTAMP(6) NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (user_id, stat_date),
+    INDEX idx_daily_stats_date (stat_date, user_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
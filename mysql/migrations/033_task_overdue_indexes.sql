@@ -0,0 +1,9 @@
+-- Per-user overdue task lookups (GET /tasks/overdue)
+-- Run once against databases created before tasks.idx_assignee_status_due existed.
+
+-- Equality on the user column, IN on the open statuses and a range on
+-- due_date are all served by the index, so the query no longer scans every
+-- open task.
+ALTER TABLE `Yotei-Plus`.`tasks`
+    ADD INDEX idx_assignee_status_due (assignee_id, status, due_date),
+    ADD INDEX idx_created_by_status_due (created_by, status, due_date);
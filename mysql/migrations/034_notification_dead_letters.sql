@@ -0,0 +1,21 @@
+-- Notification dead letters: channel deliveries that still failed after retrying
+-- Run once against databases created before notification_dead_letters existed.
+
+-- One row per notification and channel. reason is the error of the last
+-- attempt and attempts counts every send including re-drives; redriven_at is
+-- set once an admin re-drive succeeds, so pending rows are the NULL ones.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`notification_dead_letters` (
+    id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    redriven_at TIMESTAMP(6) NULL,
+    FOREIGN KEY (notification_id) REFERENCES `Yotei-Plus`.notifications(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    INDEX idx_dead_letters_pending (redriven_at, created_at)
+);
@@ -0,0 +1,17 @@
+-- Short links: /s/{slug} redirects for invitation and share URLs
+-- Run once against databases created before short_links existed.
+
+-- One row per issuing invitation or share link (kind, reference), so the same
+-- slug is returned when a URL is generated again. expires_at follows the
+-- invitation or share link and is NULL when it never expires.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`short_links` (
+    slug VARCHAR(16) PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    reference VARCHAR(64) NOT NULL,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    last_clicked_at TIMESTAMP(6) NULL,
+    expires_at TIMESTAMP(6) NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uq_short_links_reference (kind, reference)
+);
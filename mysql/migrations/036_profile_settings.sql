@@ -0,0 +1,14 @@
+-- Public profile settings: display name, avatar, bio and who can see them
+-- Run once against databases created before profile_settings existed.
+
+-- Users without a row have a PUBLIC profile with stats hidden.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`profile_settings` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    display_name VARCHAR(50) NOT NULL DEFAULT '',
+    avatar_url VARCHAR(512) NOT NULL DEFAULT '',
+    bio VARCHAR(160) NOT NULL DEFAULT '',
+    visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC', -- PUBLIC, FRIENDS or PRIVATE
+    show_stats BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
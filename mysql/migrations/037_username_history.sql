@@ -0,0 +1,15 @@
+-- Username history: previous usernames kept so old @mentions and profile links resolve
+-- Run once against databases created before username_history existed.
+
+-- A previous username stays reserved for its owner for USERNAME_HOLD_PERIOD after the change;
+-- the latest row per user also enforces USERNAME_CHANGE_COOLDOWN.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`username_history` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    old_username VARCHAR(255) NOT NULL,
+    new_username VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_username_history_user (user_id, changed_at),
+    INDEX idx_username_history_old (old_username, changed_at),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+-- Data exports: asynchronous "download my data" archives (POST /me/export)
+-- Run once against databases created before data_exports existed.
+
+-- file_key is the storage key of the finished zip; it is cleared once expires_at passes and the file is deleted.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`data_exports` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- PENDING, PROCESSING, READY, FAILED or EXPIRED
+    file_key VARCHAR(255) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(6) NOT NULL,
+    started_at TIMESTAMP(6) NULL,
+    completed_at TIMESTAMP(6) NULL,
+    expires_at TIMESTAMP(6) NULL,
+    INDEX idx_data_exports_user (user_id, created_at),
+    INDEX idx_data_exports_status (status, created_at),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
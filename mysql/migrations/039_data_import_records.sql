@@ -0,0 +1,13 @@
+-- Data imports: source/target id mapping for records restored from an export archive (POST /me/import)
+-- Run once against databases created before data_import_records existed.
+
+-- Importing the same archive again skips every (user_id, section, source_id) already recorded here.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`data_import_records` (
+    user_id VARCHAR(36) NOT NULL,
+    section VARCHAR(64) NOT NULL,
+    source_id VARCHAR(64) NOT NULL, -- id on the server that produced the archive
+    target_id VARCHAR(64) NOT NULL, -- id of the restored record on this server
+    imported_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (user_id, section, source_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+-- Notification preferences and the pending social actions digest
+-- Run once against databases created before notification_preferences and social_digest_states existed.
+
+-- Per-user notification preferences (users without a row get the defaults: social_digest = DAILY)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`notification_preferences` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    social_digest VARCHAR(16) NOT NULL DEFAULT 'DAILY', -- OFF, DAILY or WEEKLY
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Last digest sent to each user; the row is deleted (and the notification marked read)
+-- once the user has no pending friend requests or group invitations left
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`social_digest_states` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL,
+    last_sent_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
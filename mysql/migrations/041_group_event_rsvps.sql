@@ -0,0 +1,26 @@
+-- Group event RSVP and attendance tracking
+-- Run once against databases created before group_event_rsvps and group_event_reminders existed.
+
+-- An event is a group task with a start date (or due date) in a SCHEDULE group;
+-- event_id is the task id. A row is created when a member answers or when
+-- attendance is recorded, so members without a row have not responded.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_rsvps` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- YES, NO, MAYBE or NO_RESPONSE (attendance recorded without an answer)
+    attended BOOLEAN NULL, -- NULL until attendance is recorded
+    responded_at TIMESTAMP(6) NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id, user_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Events whose RSVP reminder has been sent (at most once per event)
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_reminders` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    reminded_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
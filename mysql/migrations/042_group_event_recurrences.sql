@@ -0,0 +1,39 @@
+-- Recurring group events with per-occurrence exceptions
+-- Run once against databases created before group_event_recurrences and group_event_exceptions existed.
+
+-- Each occurrence of a recurring event has its own id (the task id followed by
+-- "_" and the occurrence's original start in UTC, e.g. "<task id>_20240603T090000Z"),
+-- so RSVPs and reminders are tracked per occurrence and need a longer event_id.
+ALTER TABLE `Yotei-Plus`.`group_event_rsvps` MODIFY event_id VARCHAR(64) NOT NULL;
+ALTER TABLE `Yotei-Plus`.`group_event_reminders` MODIFY event_id VARCHAR(64) NOT NULL;
+
+-- The event's start is the first occurrence; occurrences repeat at the same wall
+-- clock time in timezone.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_recurrences` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    frequency VARCHAR(16) NOT NULL, -- DAILY, WEEKLY or MONTHLY
+    interval_count INT NOT NULL DEFAULT 1,
+    weekdays VARCHAR(32) NOT NULL DEFAULT '', -- comma separated MO..SU (WEEKLY only)
+    occurrence_count INT NOT NULL DEFAULT 0, -- 0 = no limit
+    until_at TIMESTAMP(6) NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id),
+    INDEX idx_group_event_recurrences_until (until_at),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Cancelled or moved occurrences, keyed by the occurrence's original start
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_exceptions` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    original_starts_at TIMESTAMP(6) NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at TIMESTAMP(6) NULL, -- new start of a moved occurrence
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, event_id, original_starts_at),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
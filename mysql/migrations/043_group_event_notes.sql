@@ -0,0 +1,30 @@
+-- Meeting notes attached to group events
+-- Run once against databases created before group_event_notes and group_note_action_items existed.
+
+-- event_id is the task id, or the occurrence id of a recurring event.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_notes` (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL, -- markdown
+    created_by VARCHAR(36) NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_group_event_notes_event (group_id, event_id, created_at),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
+
+-- Tasks created from the unchecked "- [ ]" items of a note
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_note_action_items` (
+    note_id VARCHAR(36) NOT NULL,
+    group_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    item_text VARCHAR(500) NOT NULL,
+    assignee_id VARCHAR(36) NULL, -- first mentioned member
+    created_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (note_id, task_id),
+    INDEX idx_group_note_action_items_task (group_id, task_id),
+    FOREIGN KEY (note_id) REFERENCES `Yotei-Plus`.group_event_notes(id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+-- Links between group events and tasks
+-- Run once against databases created before group_event_task_links existed.
+
+-- BLOCK: the event was scheduled as a work block for task_id.
+-- FOLLOW_UP: task_id was created as a follow-up of the event.
+-- event_id is the task id of the event, or the occurrence id of a recurring event.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_event_task_links` (
+    group_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (event_id, task_id),
+    INDEX idx_group_event_task_links_task (task_id),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
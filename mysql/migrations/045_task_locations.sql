@@ -0,0 +1,29 @@
+-- Task locations and geofence reminders
+-- Run once against databases created before task_locations existed.
+
+-- A task has at most one location. Events are group tasks, so they share it.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_locations` (
+    task_id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    latitude DOUBLE NOT NULL,
+    longitude DOUBLE NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_task_locations_lat_lng (latitude, longitude),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- Geofences registered by mobile devices around task locations (one per user and task).
+-- The center is the task location; last_triggered_at throttles repeated reminders.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_geofences` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    radius_meters INT NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    last_triggered_at TIMESTAMP(6) NULL,
+    UNIQUE KEY uk_task_geofences_user_task (user_id, task_id),
+    INDEX idx_task_geofences_task (task_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+-- Holiday-aware due dates and recurring group events
+-- Run once against databases created before holiday_policy existed.
+
+-- How a due date landing on a holiday or non-working day is moved:
+-- KEEP, NEXT_BUSINESS_DAY or PREVIOUS_BUSINESS_DAY
+ALTER TABLE `Yotei-Plus`.`user_working_hours`
+    ADD COLUMN holiday_policy VARCHAR(32) NOT NULL DEFAULT 'KEEP' AFTER daily_capacity_minutes;
+
+-- How occurrences landing on a holiday are handled: '' (keep), SKIP or NEXT_BUSINESS_DAY
+ALTER TABLE `Yotei-Plus`.`group_event_recurrences`
+    ADD COLUMN holiday_policy VARCHAR(32) NOT NULL DEFAULT '' AFTER timezone;
@@ -0,0 +1,22 @@
+-- Working-hours aware reminder and escalation notifications
+-- Run once against databases created before reminder_timing existed.
+
+-- When reminders and escalation notifications are sent: WORKING_HOURS or ANYTIME
+ALTER TABLE `Yotei-Plus`.`user_working_hours`
+    ADD COLUMN reminder_timing VARCHAR(32) NOT NULL DEFAULT 'WORKING_HOURS' AFTER holiday_policy;
+
+-- Reminders held outside the recipient's working hours, delivered together at deliver_at.
+-- One row per user, task and kind (DUE_SOON, START or ESCALATION).
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`deferred_reminders` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    task_title VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    deliver_at TIMESTAMP(6) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_deferred_reminders_user_task_kind (user_id, task_id, kind),
+    INDEX idx_deferred_reminders_deliver_at (deliver_at),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
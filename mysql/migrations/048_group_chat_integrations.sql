@@ -0,0 +1,22 @@
+-- Chat integrations of groups (Discord channel webhooks)
+-- Run once against databases created before group_chat_integrations existed.
+
+-- events: comma-separated TASK_COMPLETED, TASK_ASSIGNED and DAILY_SUMMARY.
+-- templates: JSON object of the message templates changed per event.
+-- last_summary_date: local date (in timezone) of the last daily summary.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_chat_integrations` (
+    group_id VARCHAR(36) NOT NULL,
+    platform VARCHAR(20) NOT NULL,
+    webhook_url VARCHAR(500) NOT NULL,
+    events VARCHAR(100) NOT NULL,
+    templates TEXT NOT NULL,
+    summary_hour TINYINT NOT NULL DEFAULT 9,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_summary_date VARCHAR(10) NOT NULL DEFAULT '',
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    PRIMARY KEY (group_id, platform),
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+-- Microsoft Teams chat integrations and due reminders posted to group chats
+-- Run once against databases created before due_reminder_hours existed.
+
+-- Teams workflow webhook URLs are longer than Discord webhook URLs
+ALTER TABLE `Yotei-Plus`.`group_chat_integrations`
+    MODIFY COLUMN webhook_url VARCHAR(1000) NOT NULL;
+
+-- due_reminder_hours: how many hours before the due date TASK_DUE_SOON is posted.
+-- due_checked_until: due dates up to this time have already been reminded.
+ALTER TABLE `Yotei-Plus`.`group_chat_integrations`
+    ADD COLUMN due_reminder_hours SMALLINT NOT NULL DEFAULT 24 AFTER last_summary_date,
+    ADD COLUMN due_checked_until TIMESTAMP(6) NULL AFTER due_reminder_hours;
@@ -0,0 +1,35 @@
+-- GitHub issues and pull requests linked to tasks, and the GitHub tokens users register to read them.
+-- Run once against databases created before task_issue_links existed.
+
+-- repository is "owner/name" in lower case; state is OPEN, CLOSED, NOT_PLANNED or MERGED and is
+-- updated by the GitHub webhook. complete_on_close completes the task when the pull request is
+-- merged or the issue is closed as completed.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_issue_links` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    repository VARCHAR(150) NOT NULL,
+    number INT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    complete_on_close BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_task_issue_links_task_issue (task_id, provider, repository, number),
+    INDEX idx_task_issue_links_issue (provider, repository, number),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
+
+-- One GitHub token per user, used to read issues in private repositories.
+-- Stored like SSO client secrets and never returned by the API.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`github_accounts` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    login VARCHAR(100) NOT NULL,
+    token VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
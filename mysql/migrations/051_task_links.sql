@@ -0,0 +1,17 @@
+-- Links users add to tasks (documents, designs and other URLs).
+-- Run once against databases created before task_links existed.
+
+-- type is LINK, DOCUMENT or DESIGN. GitHub issues (task_issue_links) and group events are
+-- returned alongside these links but stay in their own tables.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`task_links` (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_task_links_task (task_id, created_at),
+    FOREIGN KEY (task_id) REFERENCES `Yotei-Plus`.tasks(id) ON DELETE CASCADE
+);
@@ -0,0 +1,28 @@
+-- Creating tasks from email sent to a per-user address.
+-- Run once against databases created before inbound_email_addresses existed.
+
+-- One address per user: <token>@INBOUND_EMAIL_DOMAIN. allowed_senders is a JSON array of
+-- lowercase addresses or "@domain" entries.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`inbound_email_addresses` (
+    user_id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(64) NOT NULL,
+    allowed_senders JSON NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL,
+    UNIQUE KEY uk_inbound_email_addresses_token (token),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
+
+-- Emails turned into tasks, used to skip redelivered messages (same Message-ID) and to
+-- limit tasks per hour. task_id has no foreign key so deleting a task keeps the limit.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`inbound_emails` (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    sender VARCHAR(255) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    received_at TIMESTAMP(6) NOT NULL,
+    INDEX idx_inbound_emails_user_received (user_id, received_at),
+    INDEX idx_inbound_emails_user_message (user_id, message_id),
+    FOREIGN KEY (user_id) REFERENCES `Yotei-Plus`.users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+-- Group schedule card: opt-in settings for sharing the upcoming week as a signed image/PDF
+-- Run once against databases created before group_schedule_card_settings existed.
+
+-- timezone decides where each day on the card starts; show_titles = FALSE
+-- replaces event titles with a placeholder. Cards are only rendered once enabled.
+CREATE TABLE IF NOT EXISTS `Yotei-Plus`.`group_schedule_card_settings` (
+    group_id VARCHAR(36) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    show_titles BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP(6) NOT NULL,
+    FOREIGN KEY (group_id) REFERENCES `Yotei-Plus`.groups(id) ON DELETE CASCADE
+);
@@ -0,0 +1,30 @@
+package logger
+
+import "context"
+
+// requestIDKey はcontextにリクエストIDを格納するためのキー
+type requestIDKey struct{}
+
+// ContextWithRequestID はリクエストID（相関ID）を格納したcontextを返します
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext はcontextに格納されたリクエストIDを返します（未設定の場合は空文字）
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithContext はcontextのリクエストIDをrequest_idフィールドとして付与したロガーを返します
+// リクエストIDがない場合は元のロガーをそのまま返します
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return l
+	}
+	return l.With(String("request_id", requestID))
+}
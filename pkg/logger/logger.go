@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -224,6 +225,10 @@ func Bool(key string, value bool) zapcore.Field {
 	return zap.Bool(key, value)
 }
 
+func Duration(key string, value time.Duration) zapcore.Field {
+	return zap.Duration(key, value)
+}
+
 func Any(key string, value interface{}) zapcore.Field {
 	return zap.Any(key, value)
 }
@@ -0,0 +1,171 @@
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// ChecklistItem はMarkdownのタスクリスト（- [ ] / - [x]）の項目
+type ChecklistItem struct {
+	Text    string
+	Checked bool
+}
+
+// converter はGitHub Flavored Markdown（表・取り消し線・自動リンク・タスクリスト）を扱う
+// 生のHTMLは出力せず、javascript: などの危険なURLのリンクも無効にする（既定の動作）
+var converter = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// policy はレンダリング結果から危険な要素・属性を取り除くポリシー
+// 変換時にも生のHTMLは除かれるが、変換器の不具合に備えて許可した要素のみを残す
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// タスクリストのチェックボックス（表示のみ）
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^(|checked|disabled)$`)).OnElements("input")
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// RenderHTML はMarkdownを安全なHTMLに変換する
+func RenderHTML(source string) string {
+	if source == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(source), &buf); err != nil {
+		// 変換できない場合は本文をそのまま表示する
+		return policy.Sanitize("<p>" + html.EscapeString(source) + "</p>")
+	}
+	return policy.Sanitize(buf.String())
+}
+
+// PlainText はMarkdownの表示される文章を返す
+// コードブロック・インラインコード・自動リンク（URL・メールアドレス）・HTMLは含めない
+func PlainText(source string) string {
+	src := []byte(source)
+	doc := parse(src)
+
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				b.WriteByte('\n')
+			}
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.CodeSpan, *ast.AutoLink, *ast.RawHTML, *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			b.Write(node.Segment.Value(src))
+			if node.SoftLineBreak() || node.HardLineBreak() {
+				b.WriteByte('\n')
+			}
+		case *ast.String:
+			b.Write(node.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+// Checklist はMarkdownのタスクリストの項目を出現順に返す
+func Checklist(source string) []ChecklistItem {
+	src := []byte(source)
+	doc := parse(src)
+
+	var items []ChecklistItem
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		checkbox, ok := n.(*extast.TaskCheckBox)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		items = append(items, ChecklistItem{
+			Text:    strings.TrimSpace(inlineText(checkbox, src)),
+			Checked: checkbox.IsChecked,
+		})
+		return ast.WalkContinue, nil
+	})
+	return items
+}
+
+// Links はMarkdownのリンク（[text](url)・自動リンク）のhttp・httpsのURLを重複なく出現順に返す
+// 画像・コードブロック・インラインコード中のURLは含めない
+func Links(source string) []string {
+	src := []byte(source)
+	doc := parse(src)
+
+	var links []string
+	seen := make(map[string]bool)
+	add := func(url string) {
+		lower := strings.ToLower(url)
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			return
+		}
+		if !seen[url] {
+			seen[url] = true
+			links = append(links, url)
+		}
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Link:
+			add(string(node.Destination))
+		case *ast.AutoLink:
+			if node.AutoLinkType == ast.AutoLinkURL {
+				add(string(node.URL(src)))
+			}
+		case *ast.Image:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return links
+}
+
+// parse はMarkdownを構文木に変換する
+func parse(src []byte) ast.Node {
+	return converter.Parser().Parse(text.NewReader(src))
+}
+
+// inlineText はチェックボックスに続く同じ段落の文章を返す（改行は空白にする）
+func inlineText(checkbox ast.Node, src []byte) string {
+	var b strings.Builder
+	for n := checkbox.NextSibling(); n != nil; n = n.NextSibling() {
+		_ = ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+			if !entering {
+				return ast.WalkContinue, nil
+			}
+			switch node := child.(type) {
+			case *ast.Text:
+				b.Write(node.Segment.Value(src))
+				if node.SoftLineBreak() || node.HardLineBreak() {
+					b.WriteByte(' ')
+				}
+			case *ast.String:
+				b.Write(node.Value)
+			case *ast.AutoLink:
+				b.Write(node.Label(src))
+			}
+			return ast.WalkContinue, nil
+		})
+	}
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"sync"
+)
+
+var mu sync.Mutex
+
+// Counter は名前に対応するカウンターを返す（未登録の場合は作成する）
+// 値は expvar として公開され、Handler で取得できる（Setで最新値を記録するゲージとしても使用できる）
+func Counter(name string) *expvar.Int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if v, ok := expvar.Get(name).(*expvar.Int); ok {
+		return v
+	}
+	return expvar.NewInt(name)
+}
+
+// Handler は登録済みのメトリクスをJSONで返すハンドラーを返す
+func Handler() http.Handler {
+	return expvar.Handler()
+}
@@ -0,0 +1,170 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4縦のページサイズと余白（ポイント）
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 56.0
+
+	lineSpacing = 1.5 // 文字サイズに対する行の高さ
+)
+
+// Document はテキストを上から順に流し込むだけの最小限のPDF文書
+//
+// 日本語を表示するため、PDFビューアが標準で持つ和文フォント（HeiseiKakuGo-W5）を
+// 埋め込まずに参照する。ASCIIは半角、それ以外は全角の幅として折り返しを計算する。
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// New は新しいDocumentを作成する
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.newPage()
+	return d
+}
+
+// Text は文字列を指定サイズで出力する。ページ幅を超える場合は折り返し、ページ末尾では改ページする
+func (d *Document) Text(text string, size float64) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(paragraph, size, pageWidth-2*margin) {
+			d.line(line, size)
+		}
+	}
+}
+
+// Space は縦方向の空白を入れる
+func (d *Document) Space(height float64) {
+	d.y -= height
+}
+
+// Rule はページ幅の横線を引く
+func (d *Document) Rule() {
+	d.ensure(8)
+	d.y -= 4
+	fmt.Fprintf(d.current(), "0.7 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", margin, d.y, pageWidth-margin, d.y)
+	d.y -= 4
+}
+
+// WriteTo はPDFを書き出す
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// 1: Catalog, 2: Pages, 3: Font, 4: CIDFont, 5: Info, 6〜: 各ページとその内容
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [4 0 R] >>")
+	obj("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5" +
+		" /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >>" +
+		" /FontDescriptor << /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4" +
+		" /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 737 /StemV 114 >>" +
+		" /DW 1000 /W [231 389 500] >>")
+	obj(fmt.Sprintf("<< /Title <FEFF%s> /Producer (Yotei+) >>", encodeText(d.title)))
+	for i, page := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// Bytes はPDFをバイト列で返す
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+func (d *Document) current() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// ensure は指定の高さが現在のページに収まらない場合に改ページする
+func (d *Document) ensure(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+func (d *Document) line(text string, size float64) {
+	height := size * lineSpacing
+	d.ensure(height)
+	d.y -= height
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(d.current(), "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, margin, d.y, encodeText(text))
+}
+
+// wrap は文字列を幅に収まるよう行に分割する（英単語の途中でも折り返す）
+func wrap(text string, size, width float64) []string {
+	var lines []string
+	var line []rune
+	lineWidth := 0.0
+	for _, r := range text {
+		w := runeWidth(r) * size
+		if lineWidth+w > width && len(line) > 0 {
+			lines = append(lines, string(line))
+			line, lineWidth = nil, 0
+		}
+		line = append(line, r)
+		lineWidth += w
+	}
+	return append(lines, string(line))
+}
+
+// runeWidth は文字幅（em）を返す。ASCIIは半角、それ以外は全角として扱う
+func runeWidth(r rune) float64 {
+	if r < 0x80 || (r >= 0xFF61 && r <= 0xFF9F) {
+		return 0.5
+	}
+	return 1
+}
+
+// encodeText は文字列をUTF-16BEの16進文字列に変換する
+// UCS-2で表せない文字（絵文字など）と制御文字は「?」に置き換える
+func encodeText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r > 0xFFFF || r < 0x20 || utf16.IsSurrogate(r) {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
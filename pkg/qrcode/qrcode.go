@@ -0,0 +1,90 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+// Format はQRコードの出力形式
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// サイズ（ピクセル）の制約
+const (
+	DefaultSize = 256
+	MinSize     = 64
+	MaxSize     = 1024
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported QR code format")
+	ErrInvalidSize       = errors.New("invalid QR code size")
+)
+
+// ParseFormat は文字列から出力形式を取得する（空文字の場合はPNG）
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatPNG:
+		return FormatPNG, nil
+	case FormatSVG:
+		return FormatSVG, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// ContentType は出力形式のContent-Typeを返す
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Encode は内容をQRコード画像にエンコードする
+func Encode(content string, format Format, size int) ([]byte, error) {
+	if size < MinSize || size > MaxSize {
+		return nil, ErrInvalidSize
+	}
+
+	qr, err := goqrcode.New(content, goqrcode.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	switch format {
+	case FormatPNG:
+		return qr.PNG(size)
+	case FormatSVG:
+		return encodeSVG(qr.Bitmap(), size), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// encodeSVG はQRコードのビットマップをSVGに変換する（黒モジュールを1つのpathで描画）
+func encodeSVG(bitmap [][]bool, size int) []byte {
+	modules := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#ffffff"/>`, modules, modules)
+	fmt.Fprintf(&sb, `<path fill="#000000" d="%s"/>`, path.String())
+	sb.WriteString("</svg>\n")
+	return []byte(sb.String())
+}
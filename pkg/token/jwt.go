@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -29,8 +30,17 @@ type Claims struct {
 
 // JWTManagerはトークンの生成と検証を担当
 type JWTManager struct {
+	mu        sync.RWMutex
 	secretKey []byte
-	issuer    string
+	// ローテーション前の鍵（発行済みのトークンが期限切れになるまで検証に使う）
+	previousKeys []previousKey
+	issuer       string
+}
+
+// previousKeyはローテーション前の鍵と、検証に使う期限
+type previousKey struct {
+	key   []byte
+	until time.Time
 }
 
 // NewJWTManagerは新しいJWTマネージャーを作成
@@ -38,6 +48,42 @@ func NewJWTManager(secretKey string, issuer string) *JWTManager {
 	return &JWTManager{secretKey: []byte(secretKey), issuer: issuer}
 }
 
+// RotateSecretKeyは署名鍵を切り替える
+// 以前の鍵で署名されたトークンは、graceの間（アクセストークンの有効期限を指定する）引き続き検証できる
+func (m *JWTManager) RotateSecretKey(secretKey string, grace time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if secretKey == "" || secretKey == string(m.secretKey) {
+		return
+	}
+
+	now := time.Now()
+	keys := []previousKey{{key: m.secretKey, until: now.Add(grace)}}
+	for _, k := range m.previousKeys {
+		if now.Before(k.until) && string(k.key) != secretKey {
+			keys = append(keys, k)
+		}
+	}
+	m.previousKeys = keys
+	m.secretKey = []byte(secretKey)
+}
+
+// verificationKeysは検証に使う鍵（現在の鍵と、期限内のローテーション前の鍵）を返す
+func (m *JWTManager) verificationKeys() jwt.VerificationKeySet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := jwt.VerificationKeySet{Keys: []jwt.VerificationKey{m.secretKey}}
+	now := time.Now()
+	for _, k := range m.previousKeys {
+		if now.Before(k.until) {
+			keys.Keys = append(keys.Keys, k.key)
+		}
+	}
+	return keys
+}
+
 // GenerateはJWTトークンを生成
 func (m *JWTManager) Generate(claims *Claims, duration time.Duration) (string, error) {
 	tokenID := uuid.New().String()
@@ -51,8 +97,12 @@ func (m *JWTManager) Generate(claims *Claims, duration time.Duration) (string, e
 		ID:        tokenID,
 	}
 
+	m.mu.RLock()
+	secretKey := m.secretKey
+	m.mu.RUnlock()
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secretKey)
+	return token.SignedString(secretKey)
 }
 
 // GenerateRefreshTokenはリフレッシュトークン用のランダム文字列を生成
@@ -75,7 +125,7 @@ func (m *JWTManager) Verify(tokenString string) (*Claims, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, ErrInvalidToken
 			}
-			return m.secretKey, nil
+			return m.verificationKeys(), nil
 		},
 	)
 
@@ -0,0 +1,990 @@
+-- Task Management Database Initialization Script (PostgreSQL)
+-- Mirrors mysql/init.sql. Tables live in the "Yotei-Plus" schema, which the application puts on the search_path.
+-- Differences from MySQL:
+--   * ENUM columns are VARCHAR with CHECK constraints
+--   * TIMESTAMP columns are TIMESTAMPTZ, JSON columns are JSONB
+--   * ON UPDATE CURRENT_TIMESTAMP is emulated by the set_updated_at trigger
+--   * index names are prefixed with the table name (PostgreSQL index names are unique per schema)
+--   * FULLTEXT indexes are omitted; searches use ILIKE
+CREATE SCHEMA IF NOT EXISTS "Yotei-Plus";
+
+SET search_path TO "Yotei-Plus";
+
+-- Sets updated_at on UPDATE unless the statement sets it explicitly (same as MySQL's ON UPDATE CURRENT_TIMESTAMP)
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.updated_at IS NOT DISTINCT FROM OLD.updated_at THEN
+        NEW.updated_at = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Users table for authentication
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    username VARCHAR(255) UNIQUE NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    role VARCHAR(10) DEFAULT 'user' CHECK (role IN ('user', 'admin')),
+    email_verified BOOLEAN DEFAULT FALSE,
+    last_login TIMESTAMPTZ NULL,
+    deactivated_at TIMESTAMPTZ NULL, -- NULL while the account is active
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Refresh tokens table
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(255) UNIQUE NOT NULL,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    issued_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+-- Tasks table
+CREATE TABLE IF NOT EXISTS tasks (
+    id VARCHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) DEFAULT 'TODO' CHECK (status IN ('TODO', 'IN_PROGRESS', 'DONE')),
+    priority VARCHAR(10) DEFAULT 'MEDIUM' CHECK (priority IN ('LOW', 'MEDIUM', 'HIGH')),
+    category VARCHAR(10) DEFAULT 'OTHER' CHECK (category IN ('WORK', 'PERSONAL', 'STUDY', 'HEALTH', 'SHOPPING', 'OTHER')),
+    assignee_id VARCHAR(36) NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    due_date TIMESTAMPTZ NULL,
+    start_date TIMESTAMPTZ NULL,
+    estimate_minutes INT NULL,
+    estimate_points INT NULL,
+    actual_minutes INT NULL,
+    completed_at TIMESTAMPTZ NULL,
+    require_all_assignees BOOLEAN NOT NULL DEFAULT FALSE,
+    checklist JSONB NULL, -- extracted from the markdown task list items of the description
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority);
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_id ON tasks (assignee_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_created_by ON tasks (created_by);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_start_date ON tasks (start_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_category ON tasks (category);
+CREATE INDEX IF NOT EXISTS idx_tasks_completed_at ON tasks (completed_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks (created_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_status_due ON tasks (assignee_id, status, due_date); -- per-user overdue lookups
+CREATE INDEX IF NOT EXISTS idx_tasks_created_by_status_due ON tasks (created_by, status, due_date);
+
+-- Task assignees table (assignee_id on tasks holds the primary assignee)
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ NULL,
+    PRIMARY KEY (task_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_assignees_user_completed ON task_assignees (user_id, completed_at);
+
+-- Notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    type VARCHAR(20) DEFAULT 'APP_NOTIFICATION' CHECK (type IN ('APP_NOTIFICATION', 'TASK_ASSIGNED', 'TASK_COMPLETED', 'TASK_DUE_SOON', 'TASK_MENTIONED', 'SYSTEM_NOTICE')),
+    status VARCHAR(10) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SENT', 'READ', 'FAILED')),
+    metadata JSONB NULL,
+    group_key VARCHAR(255) NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications (status);
+CREATE INDEX IF NOT EXISTS idx_notifications_type ON notifications (type);
+CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications (created_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_group ON notifications (user_id, group_key, updated_at);
+
+-- Task comments table (optional feature)
+CREATE TABLE IF NOT EXISTS task_comments (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    comment TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_comments_user_id ON task_comments (user_id);
+CREATE INDEX IF NOT EXISTS idx_task_comments_created_at ON task_comments (created_at);
+
+-- Task mentions table (@username in descriptions and comments)
+CREATE TABLE IF NOT EXISTS task_mentions (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    source_type VARCHAR(20) NOT NULL CHECK (source_type IN ('DESCRIPTION', 'COMMENT')),
+    source_id VARCHAR(36) NOT NULL,
+    mentioned_user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    author_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_task_mentions_mention UNIQUE (source_type, source_id, mentioned_user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_mentions_mentioned_user_created ON task_mentions (mentioned_user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_mentions_task_id ON task_mentions (task_id);
+
+-- Task share links table (read-only public links)
+CREATE TABLE IF NOT EXISTS task_share_links (
+    id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(64) NOT NULL,
+    owner_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(10) NOT NULL CHECK (target_type IN ('TASK', 'TASK_LIST')),
+    task_id VARCHAR(36) NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    filter JSONB NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_task_share_links_token UNIQUE (token)
+);
+CREATE INDEX IF NOT EXISTS idx_task_share_links_owner_created ON task_share_links (owner_id, created_at);
+
+-- Task attachments table (optional feature)
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    file_path VARCHAR(500) NOT NULL,
+    file_size BIGINT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    -- NONE (not an image), PENDING, READY or FAILED
+    thumbnail_status VARCHAR(16) NOT NULL DEFAULT 'NONE',
+    uploaded_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_thumbnail ON task_attachments (thumbnail_status, created_at);
+
+-- User roles table (for more complex role management)
+CREATE TABLE IF NOT EXISTS user_roles (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_name VARCHAR(50) NOT NULL,
+    granted_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    granted_by VARCHAR(36) NULL REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT uq_user_roles_user_role UNIQUE (user_id, role_name)
+);
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_name ON user_roles (role_name);
+
+-- Insert sample data
+INSERT INTO users (id, email, username, password, role, email_verified) VALUES
+('550e8400-e29b-41d4-a716-446655440000', 'admin@example.com', 'admin', '$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi', 'admin', TRUE),
+('550e8400-e29b-41d4-a716-446655440001', 'user@example.com', 'testuser', '$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi', 'user', TRUE)
+ON CONFLICT DO NOTHING;
+
+-- Insert sample tasks
+INSERT INTO tasks (id, title, description, status, priority, created_by) VALUES
+('660e8400-e29b-41d4-a716-446655440000', 'プロジェクト設計', 'アプリケーションアーキテクチャの設計', 'IN_PROGRESS', 'HIGH', '550e8400-e29b-41d4-a716-446655440000'),
+('660e8400-e29b-41d4-a716-446655440001', 'データベース設計', 'ERD作成とテーブル設計', 'TODO', 'MEDIUM', '550e8400-e29b-41d4-a716-446655440000'),
+('660e8400-e29b-41d4-a716-446655440002', 'API実装', 'REST API エンドポイントの実装', 'TODO', 'HIGH', '550e8400-e29b-41d4-a716-446655440001')
+ON CONFLICT DO NOTHING;
+
+-- Insert sample notifications
+INSERT INTO notifications (id, user_id, title, message, type, status) VALUES
+('770e8400-e29b-41d4-a716-446655440000', '550e8400-e29b-41d4-a716-446655440001', 'タスクが割り当てられました', '新しいタスク「API実装」が割り当てられました', 'TASK_ASSIGNED', 'SENT'),
+('770e8400-e29b-41d4-a716-446655440001', '550e8400-e29b-41d4-a716-446655440001', 'システムメンテナンス', 'システムメンテナンスが予定されています', 'SYSTEM_NOTICE', 'PENDING')
+ON CONFLICT DO NOTHING;
+
+-- Social module tables
+-- Friendships table for friend relationships
+CREATE TABLE IF NOT EXISTS friendships (
+    id VARCHAR(36) PRIMARY KEY,
+    requester_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    addressee_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(10) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'BLOCKED')),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMPTZ NULL,
+    blocked_at TIMESTAMPTZ NULL,
+    reminder_sent_at TIMESTAMPTZ NULL,
+    -- Unordered user pair: one row per pair regardless of who sent the request
+    user_pair VARCHAR(73) GENERATED ALWAYS AS (
+        CASE WHEN requester_id < addressee_id THEN requester_id || ':' || addressee_id ELSE addressee_id || ':' || requester_id END
+    ) STORED,
+    CONSTRAINT uq_friendships_friendship UNIQUE (requester_id, addressee_id),
+    CONSTRAINT uq_friendships_pair UNIQUE (user_pair)
+);
+CREATE INDEX IF NOT EXISTS idx_friendships_addressee_id ON friendships (addressee_id);
+CREATE INDEX IF NOT EXISTS idx_friendships_status ON friendships (status);
+CREATE INDEX IF NOT EXISTS idx_friendships_created_at ON friendships (created_at);
+
+-- Groups table (created before invitations, which reference it)
+CREATE TABLE IF NOT EXISTS groups (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('PROJECT', 'SCHEDULE')),
+    owner_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_group_id VARCHAR(36) NULL REFERENCES groups(id) ON DELETE SET NULL, -- Set for sub-teams of a project group
+    member_count INT DEFAULT 1,
+    is_public BOOLEAN DEFAULT FALSE,
+    allow_member_invite BOOLEAN DEFAULT TRUE,
+    require_approval BOOLEAN DEFAULT TRUE,
+    enable_notifications BOOLEAN DEFAULT TRUE,
+    -- Schedule group settings
+    default_privacy_level VARCHAR(10) NULL CHECK (default_privacy_level IN ('NONE', 'BUSY', 'TITLE', 'DETAILS')),
+    allow_schedule_details BOOLEAN NULL,
+    -- Project group settings
+    enable_gantt_chart BOOLEAN NULL,
+    enable_task_dependency BOOLEAN NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    version INT DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS idx_groups_owner_id ON groups (owner_id);
+CREATE INDEX IF NOT EXISTS idx_groups_parent_group_id ON groups (parent_group_id);
+CREATE INDEX IF NOT EXISTS idx_groups_type ON groups (type);
+CREATE INDEX IF NOT EXISTS idx_groups_is_public ON groups (is_public);
+CREATE INDEX IF NOT EXISTS idx_groups_created_at ON groups (created_at);
+
+-- Invitations table for invitation system
+CREATE TABLE IF NOT EXISTS invitations (
+    id VARCHAR(36) PRIMARY KEY,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('FRIEND', 'GROUP')),
+    method VARCHAR(10) NOT NULL CHECK (method IN ('IN_APP', 'CODE', 'URL')),
+    status VARCHAR(20) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELED', 'UNDELIVERABLE')),
+    inviter_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_id VARCHAR(36) NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_email VARCHAR(255) NULL,
+    invitee_username VARCHAR(255) NULL,
+    invitee_phone VARCHAR(20) NULL,
+    target_id VARCHAR(36) NULL REFERENCES groups(id) ON DELETE CASCADE, -- group_id for group invitations
+    code VARCHAR(255) NULL,
+    url TEXT NULL,
+    message TEXT NULL,
+    metadata JSONB NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMPTZ NULL,
+    email_sent_at TIMESTAMPTZ NULL,
+    email_send_count INT NOT NULL DEFAULT 0,
+    bounce_reason VARCHAR(255) NULL,
+    CONSTRAINT uq_invitations_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_invitations_inviter_id ON invitations (inviter_id);
+CREATE INDEX IF NOT EXISTS idx_invitations_invitee_id ON invitations (invitee_id);
+CREATE INDEX IF NOT EXISTS idx_invitations_invitee_email ON invitations (invitee_email);
+CREATE INDEX IF NOT EXISTS idx_invitations_status ON invitations (status);
+CREATE INDEX IF NOT EXISTS idx_invitations_type ON invitations (type);
+CREATE INDEX IF NOT EXISTS idx_invitations_expires_at ON invitations (expires_at);
+CREATE INDEX IF NOT EXISTS idx_invitations_created_at ON invitations (created_at);
+
+-- Group members table
+CREATE TABLE IF NOT EXISTS group_members (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(36) NOT NULL DEFAULT 'MEMBER', -- OWNER/ADMIN/MEMBER/GUEST or the id of a group_roles row
+    joined_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_group_members_member UNIQUE (group_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_members_user_id ON group_members (user_id);
+CREATE INDEX IF NOT EXISTS idx_group_members_role ON group_members (role);
+CREATE INDEX IF NOT EXISTS idx_group_members_joined_at ON group_members (joined_at);
+
+-- Group permissions table (only actions changed from the defaults are stored)
+CREATE TABLE IF NOT EXISTS group_permissions (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    roles VARCHAR(100) NOT NULL DEFAULT '', -- comma-separated ADMIN/MEMBER; the owner is always allowed
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, action)
+);
+
+-- Group custom roles table (members reference these by id in group_members.role)
+CREATE TABLE IF NOT EXISTS group_roles (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    permissions VARCHAR(255) NOT NULL DEFAULT '', -- comma-separated configurable actions
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_group_roles_name UNIQUE (group_id, name)
+);
+
+-- Group task auto-assignment settings (policy applied when a group task has no assignee)
+CREATE TABLE IF NOT EXISTS group_assignment_settings (
+    group_id VARCHAR(36) PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    policy VARCHAR(20) NOT NULL DEFAULT 'NONE', -- NONE/ROUND_ROBIN/LEAST_LOADED
+    last_assignee_id VARCHAR(36) NULL, -- member picked last, the round-robin cursor
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Group leaderboard settings (opt-in, weekly periods start Monday 00:00 in timezone)
+CREATE TABLE IF NOT EXISTS group_leaderboard_settings (
+    group_id VARCHAR(36) PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Leaderboard visibility per member (members without a row are VISIBLE)
+CREATE TABLE IF NOT EXISTS group_leaderboard_preferences (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'VISIBLE', -- VISIBLE/ANONYMOUS/HIDDEN
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+
+-- Group tasks table (extending tasks with group context)
+CREATE TABLE IF NOT EXISTS group_tasks (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_group_tasks_task_group UNIQUE (task_id, group_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_tasks_group_id ON group_tasks (group_id);
+
+-- Project group milestones (ordered within the group)
+CREATE TABLE IF NOT EXISTS task_milestones (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    due_date TIMESTAMPTZ NOT NULL,
+    position INT NOT NULL DEFAULT 0, -- display order within the group, starting at 0
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_milestones_group_position ON task_milestones (group_id, position);
+
+-- Tasks attached to milestones (a task belongs to at most one milestone)
+CREATE TABLE IF NOT EXISTS milestone_tasks (
+    task_id VARCHAR(36) PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    milestone_id VARCHAR(36) NOT NULL REFERENCES task_milestones(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_milestone_tasks_milestone_id ON milestone_tasks (milestone_id);
+
+-- Finish-to-start dependencies between group tasks (used by the group timeline)
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    depends_on_task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE, -- must be completed before task_id starts
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_task_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies (depends_on_task_id);
+
+-- Append-only task change events (replayed to reconstruct a task at any point in time)
+CREATE TABLE IF NOT EXISTS task_events (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL, -- 1-based, per task
+    type VARCHAR(20) NOT NULL CHECK (type IN ('CREATED', 'FIELD_CHANGED', 'STATUS_CHANGED')),
+    actor_id VARCHAR(36) NULL, -- NULL for changes made by the system
+    changes JSONB NOT NULL, -- new values of the changed fields, keyed by field name
+    occurred_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uq_task_events_task_sequence UNIQUE (task_id, sequence)
+);
+CREATE INDEX IF NOT EXISTS idx_task_events_task_occurred_at ON task_events (task_id, occurred_at);
+
+-- Task state snapshots (taken every 20 events so replays start from the latest one)
+CREATE TABLE IF NOT EXISTS task_snapshots (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL,
+    state JSONB NOT NULL,
+    taken_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (task_id, sequence)
+);
+CREATE INDEX IF NOT EXISTS idx_task_snapshots_task_taken_at ON task_snapshots (task_id, taken_at);
+
+-- Task escalation rules table (per-user / per-group overdue escalation)
+CREATE TABLE IF NOT EXISTS task_escalation_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('USER', 'GROUP')),
+    owner_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    overdue_hours INT NOT NULL DEFAULT 0,
+    raise_priority BOOLEAN DEFAULT TRUE,
+    notify_assignee BOOLEAN DEFAULT TRUE,
+    notify_group_admins BOOLEAN DEFAULT FALSE,
+    reassign_to VARCHAR(36) NULL REFERENCES users(id) ON DELETE SET NULL,
+    enabled BOOLEAN DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_escalation_rules_scope_owner ON task_escalation_rules (scope, owner_id);
+CREATE INDEX IF NOT EXISTS idx_task_escalation_rules_enabled ON task_escalation_rules (enabled);
+
+-- Task escalation history (prevents applying the same rule twice)
+CREATE TABLE IF NOT EXISTS task_escalations (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    rule_id VARCHAR(36) NOT NULL REFERENCES task_escalation_rules(id) ON DELETE CASCADE,
+    escalated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, rule_id)
+);
+
+-- User working hours table (workload capacity settings)
+CREATE TABLE IF NOT EXISTS user_working_hours (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    work_days VARCHAR(20) NOT NULL DEFAULT '1,2,3,4,5',
+    start_time CHAR(5) NOT NULL DEFAULT '09:00',
+    end_time CHAR(5) NOT NULL DEFAULT '18:00',
+    daily_capacity_minutes INT NOT NULL DEFAULT 0,
+    holiday_policy VARCHAR(32) NOT NULL DEFAULT 'KEEP', -- KEEP, NEXT_BUSINESS_DAY or PREVIOUS_BUSINESS_DAY
+    reminder_timing VARCHAR(32) NOT NULL DEFAULT 'WORKING_HOURS', -- WORKING_HOURS or ANYTIME
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Feature flags changed by administrators (override the FEATURE_FLAGS defaults)
+CREATE TABLE IF NOT EXISTS feature_flags (
+    flag_key VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE, -- master switch; when FALSE the flag is off for everyone
+    percentage SMALLINT NOT NULL DEFAULT 0 CHECK (percentage BETWEEN 0 AND 100), -- share of users enabled by hashing flag_key and user id
+    users JSONB NOT NULL, -- user ids enabled regardless of the percentage
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Security audit log of authentication events (rows older than SECURITY_AUDIT_RETENTION are deleted by the application)
+CREATE TABLE IF NOT EXISTS security_events (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NULL, -- NULL when the user is unknown (e.g. login with an unregistered email); kept after the user is deleted
+    type VARCHAR(32) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    detail VARCHAR(255) NOT NULL DEFAULT '', -- failure reason or the denied route
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_type ON security_events (type, created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_created_at ON security_events (created_at);
+
+-- Devices users have logged in from, and per-user settings for suspicious login alerts
+CREATE TABLE IF NOT EXISTS login_devices (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 of the User-Agent
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    trusted BOOLEAN NOT NULL DEFAULT FALSE, -- trusted devices are not asked for a verification code
+    trusted_until TIMESTAMPTZ NULL, -- remembered after a verified login; trusted until then when the signed cookie is presented
+    trust_token_hash CHAR(64) NOT NULL DEFAULT '', -- SHA-256 of the cookie token
+    last_ip VARCHAR(45) NOT NULL DEFAULT '',
+    last_country CHAR(2) NULL, -- NULL when GeoIP is disabled or the location is unknown
+    last_latitude DOUBLE PRECISION NULL,
+    last_longitude DOUBLE PRECISION NULL,
+    verification_code_hash CHAR(64) NOT NULL DEFAULT '', -- set while a login from this device awaits its emailed code
+    verification_expires_at TIMESTAMPTZ NULL,
+    verification_attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NULL, -- NULL until a login from this device completes
+    CONSTRAINT uq_login_devices_user_fingerprint UNIQUE (user_id, fingerprint)
+);
+
+CREATE TABLE IF NOT EXISTS login_alert_settings (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    require_verification BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Admin-issued API keys (SCIM provisioning)
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    display_prefix VARCHAR(16) NOT NULL, -- first characters of the key, shown in the key list
+    key_hash CHAR(64) NOT NULL, -- SHA-256 of the key; the key itself is never stored
+    scopes VARCHAR(255) NOT NULL, -- comma separated
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- the admin who issued the key; owns groups created through SCIM
+    created_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    CONSTRAINT uq_api_keys_key_hash UNIQUE (key_hash)
+);
+
+CREATE TABLE IF NOT EXISTS sso_connections (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    domains VARCHAR(1000) NOT NULL, -- comma separated, lower case; a domain belongs to one connection
+    issuer VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(512) NOT NULL,
+    enforced BOOLEAN NOT NULL DEFAULT FALSE, -- password login is rejected for the domains (admins excepted)
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    role_claim VARCHAR(100) NOT NULL DEFAULT '', -- empty: roles are not synchronized from the IdP
+    admin_values VARCHAR(1000) NOT NULL DEFAULT '', -- comma separated claim values mapped to admin
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sso_identities (
+    connection_id VARCHAR(36) NOT NULL REFERENCES sso_connections(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL, -- the IdP's sub claim
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (connection_id, subject)
+);
+
+CREATE TABLE IF NOT EXISTS billing_subscriptions (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    plan_id VARCHAR(20) NOT NULL, -- pro or team
+    status VARCHAR(30) NOT NULL, -- Stripe subscription status (active, trialing, past_due, canceled, ...)
+    stripe_customer_id VARCHAR(255) NOT NULL DEFAULT '',
+    stripe_subscription_id VARCHAR(255) NULL,
+    current_period_end TIMESTAMPTZ NULL,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    last_event_at TIMESTAMPTZ NULL, -- creation time of the last applied event; older events are ignored
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uq_billing_subscriptions_stripe UNIQUE (stripe_subscription_id)
+);
+
+CREATE TABLE IF NOT EXISTS billing_events (
+    event_id VARCHAR(255) PRIMARY KEY, -- Stripe event id; webhooks are retried and may arrive twice
+    event_type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS analytics_preferences (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE, -- users without a row are opted in
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Single-row sequence; allocating from it inside the writing transaction keeps commit order equal to seq order,
+-- so a client cursor never skips a change that commits later with a smaller seq.
+CREATE TABLE IF NOT EXISTS sync_sequence (
+    id SMALLINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    pruned_through BIGINT NOT NULL DEFAULT 0 -- cursors older than this must do a full resync
+);
+
+INSERT INTO sync_sequence (id, last_seq, pruned_through) VALUES (1, 0, 0) ON CONFLICT DO NOTHING;
+
+-- One row per recipient; op is 'upsert' (data holds the entity snapshot) or 'delete' (tombstone, data is NULL)
+CREATE TABLE IF NOT EXISTS sync_changes (
+    seq BIGINT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 0, -- version the entity had after the change
+    data JSONB NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sync_changes_user_seq ON sync_changes (user_id, seq);
+CREATE INDEX IF NOT EXISTS idx_sync_changes_changed_at ON sync_changes (changed_at);
+
+-- Users currently receiving changes for an entity; users dropped from this set get a tombstone
+CREATE TABLE IF NOT EXISTS sync_entity_recipients (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, user_id)
+);
+
+-- Current version per entity; pushed updates and deletes must be based on it
+CREATE TABLE IF NOT EXISTS sync_entity_versions (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    version BIGINT NOT NULL,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+-- Result per client mutation id, so a resent batch returns the original results instead of applying twice
+CREATE TABLE IF NOT EXISTS sync_mutations (
+    user_id VARCHAR(36) NOT NULL,
+    client_mutation_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- accepted, conflict or rejected
+    version BIGINT NOT NULL DEFAULT 0,
+    data JSONB NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    applied_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, client_mutation_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sync_mutations_applied_at ON sync_mutations (applied_at);
+
+-- Cached OpenGraph metadata for links in task descriptions and comments (keyed by the SHA-256 of the URL)
+CREATE TABLE IF NOT EXISTS link_previews (
+    url_hash CHAR(64) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    image_url VARCHAR(2048) NOT NULL DEFAULT '',
+    site_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL, -- READY or FAILED
+    fetched_at TIMESTAMPTZ NOT NULL
+);
+
+-- Weekly report email subscriptions (last_sent_week is the ISO week of the last report sent)
+CREATE TABLE IF NOT EXISTS weekly_report_subscriptions (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    last_sent_week VARCHAR(8) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Daily stats per user and UTC day, updated by task changes and reconciled nightly
+CREATE TABLE IF NOT EXISTS daily_stats (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stat_date DATE NOT NULL,
+    total_tasks INT NOT NULL DEFAULT 0,
+    completed_tasks INT NOT NULL DEFAULT 0,
+    in_progress_tasks INT NOT NULL DEFAULT 0,
+    todo_tasks INT NOT NULL DEFAULT 0,
+    overdue_tasks INT NOT NULL DEFAULT 0,
+    stale_at TIMESTAMPTZ NULL, -- earliest future due date among open tasks
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, stat_date)
+);
+CREATE INDEX IF NOT EXISTS idx_daily_stats_date ON daily_stats (stat_date, user_id);
+
+-- Notification dead letters: channel deliveries that still failed after retrying
+-- (redriven_at is set once an admin re-drive succeeds, so pending rows are the NULL ones)
+CREATE TABLE IF NOT EXISTS notification_dead_letters (
+    id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(32) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    redriven_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notification_dead_letters_pending ON notification_dead_letters (redriven_at, created_at);
+
+-- Short links: /s/{slug} redirects for invitation and share URLs
+-- (one row per issuing invitation or share link; expires_at is NULL when it never expires)
+CREATE TABLE IF NOT EXISTS short_links (
+    slug VARCHAR(16) PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    reference VARCHAR(64) NOT NULL,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    last_clicked_at TIMESTAMPTZ NULL,
+    expires_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (kind, reference)
+);
+
+-- Public profile settings: display name, avatar, bio and who can see them
+-- (users without a row have a PUBLIC profile with stats hidden)
+CREATE TABLE IF NOT EXISTS profile_settings (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    display_name VARCHAR(50) NOT NULL DEFAULT '',
+    avatar_url VARCHAR(512) NOT NULL DEFAULT '',
+    bio VARCHAR(160) NOT NULL DEFAULT '',
+    visibility VARCHAR(16) NOT NULL DEFAULT 'PUBLIC', -- PUBLIC, FRIENDS or PRIVATE
+    show_stats BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Username history: previous usernames kept so old @mentions and profile links resolve
+-- (a previous username stays reserved for its owner for USERNAME_HOLD_PERIOD after the change)
+CREATE TABLE IF NOT EXISTS username_history (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_username VARCHAR(255) NOT NULL,
+    new_username VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_user ON username_history (user_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_username_history_old ON username_history (LOWER(old_username), changed_at);
+
+-- Data exports: asynchronous "download my data" archives (POST /me/export)
+-- (file_key is cleared once expires_at passes and the stored zip is deleted)
+CREATE TABLE IF NOT EXISTS data_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL, -- PENDING, PROCESSING, READY, FAILED or EXPIRED
+    file_key VARCHAR(255) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ NULL,
+    completed_at TIMESTAMPTZ NULL,
+    expires_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user ON data_exports (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports (status, created_at);
+
+-- Data imports: source/target id mapping for records restored from an export archive (POST /me/import)
+-- (importing the same archive again skips every (user_id, section, source_id) already recorded)
+CREATE TABLE IF NOT EXISTS data_import_records (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    section VARCHAR(64) NOT NULL,
+    source_id VARCHAR(64) NOT NULL, -- id on the server that produced the archive
+    target_id VARCHAR(64) NOT NULL, -- id of the restored record on this server
+    imported_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, section, source_id)
+);
+
+-- Notification preferences (users without a row get the defaults: social_digest = DAILY)
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    social_digest VARCHAR(16) NOT NULL DEFAULT 'DAILY', -- OFF, DAILY or WEEKLY
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Pending social actions digest: last digest sent to each user
+-- (deleted, and the notification marked read, once nothing is pending)
+CREATE TABLE IF NOT EXISTS social_digest_states (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    notification_id VARCHAR(36) NOT NULL,
+    last_sent_at TIMESTAMPTZ NOT NULL
+);
+
+-- Group event RSVPs (event_id is a group task id; members without a row have not responded)
+CREATE TABLE IF NOT EXISTS group_event_rsvps (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- YES, NO, MAYBE or NO_RESPONSE (attendance recorded without an answer)
+    attended BOOLEAN, -- NULL until attendance is recorded
+    responded_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, event_id, user_id)
+);
+
+-- Events whose RSVP reminder has been sent (at most once per event)
+CREATE TABLE IF NOT EXISTS group_event_reminders (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    reminded_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, event_id)
+);
+
+-- Group event recurrence (the event's start is the first occurrence; occurrences
+-- repeat at the same wall clock time in timezone)
+CREATE TABLE IF NOT EXISTS group_event_recurrences (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    frequency VARCHAR(16) NOT NULL, -- DAILY, WEEKLY or MONTHLY
+    interval_count INT NOT NULL DEFAULT 1,
+    weekdays VARCHAR(32) NOT NULL DEFAULT '', -- comma separated MO..SU (WEEKLY only)
+    occurrence_count INT NOT NULL DEFAULT 0, -- 0 = no limit
+    until_at TIMESTAMPTZ NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    holiday_policy VARCHAR(32) NOT NULL DEFAULT '', -- '' (keep), SKIP or NEXT_BUSINESS_DAY
+    created_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_event_recurrences_until ON group_event_recurrences (until_at);
+
+-- Cancelled or moved occurrences of recurring group events
+CREATE TABLE IF NOT EXISTS group_event_exceptions (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    original_starts_at TIMESTAMPTZ NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at TIMESTAMPTZ NULL, -- new start of a moved occurrence
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, event_id, original_starts_at)
+);
+
+-- Meeting notes attached to group events (event_id is the task id or an occurrence id)
+CREATE TABLE IF NOT EXISTS group_event_notes (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL, -- markdown
+    created_by VARCHAR(36) NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_group_event_notes_event ON group_event_notes (group_id, event_id, created_at);
+
+-- Tasks created from the unchecked "- [ ]" items of a note
+CREATE TABLE IF NOT EXISTS group_note_action_items (
+    note_id VARCHAR(36) NOT NULL REFERENCES group_event_notes(id) ON DELETE CASCADE,
+    group_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    item_text VARCHAR(500) NOT NULL,
+    assignee_id VARCHAR(36) NULL, -- first mentioned member
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (note_id, task_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_note_action_items_task ON group_note_action_items (group_id, task_id);
+
+-- Links between group events and tasks (BLOCK: work block for the task, FOLLOW_UP: task created from the event)
+CREATE TABLE IF NOT EXISTS group_event_task_links (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL, -- task id or occurrence id
+    task_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (event_id, task_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_event_task_links_task ON group_event_task_links (task_id);
+
+-- Chat integrations of groups: events are posted to the channel webhook of each platform
+CREATE TABLE IF NOT EXISTS group_chat_integrations (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL, -- DISCORD or TEAMS
+    webhook_url VARCHAR(1000) NOT NULL,
+    events VARCHAR(100) NOT NULL, -- comma-separated TASK_COMPLETED, TASK_ASSIGNED, TASK_DUE_SOON, DAILY_SUMMARY
+    templates TEXT NOT NULL, -- JSON object of the changed message templates per event
+    summary_hour SMALLINT NOT NULL DEFAULT 9,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_summary_date VARCHAR(10) NOT NULL DEFAULT '', -- local date of the last daily summary
+    due_reminder_hours SMALLINT NOT NULL DEFAULT 24, -- hours before the due date to post TASK_DUE_SOON
+    due_checked_until TIMESTAMPTZ NULL, -- due dates up to this time have been reminded
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, platform)
+);
+
+-- Task locations (events are group tasks, so they share the location of their task)
+CREATE TABLE IF NOT EXISTS task_locations (
+    task_id VARCHAR(36) PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_task_locations_lat_lng ON task_locations (latitude, longitude);
+
+-- Geofences around task locations registered by mobile devices (one per user and task)
+CREATE TABLE IF NOT EXISTS task_geofences (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    radius_meters INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    last_triggered_at TIMESTAMPTZ NULL, -- throttles repeated reminders
+    UNIQUE (user_id, task_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_geofences_task ON task_geofences (task_id);
+
+-- Reminders held outside the recipient's working hours (one per user, task and kind)
+CREATE TABLE IF NOT EXISTS deferred_reminders (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    task_title VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL, -- DUE_SOON, START or ESCALATION
+    deliver_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (user_id, task_id, kind)
+);
+CREATE INDEX IF NOT EXISTS idx_deferred_reminders_deliver_at ON deferred_reminders (deliver_at);
+
+-- GitHub issues and pull requests linked to tasks (state synced by the GitHub webhook)
+CREATE TABLE IF NOT EXISTS task_issue_links (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    repository VARCHAR(150) NOT NULL, -- owner/name in lower case
+    number INT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    complete_on_close BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (task_id, provider, repository, number)
+);
+CREATE INDEX IF NOT EXISTS idx_task_issue_links_issue ON task_issue_links (provider, repository, number);
+
+-- GitHub tokens users register to read issues in private repositories (one per user)
+CREATE TABLE IF NOT EXISTS github_accounts (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    login VARCHAR(100) NOT NULL,
+    token VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Links users add to tasks (LINK, DOCUMENT or DESIGN)
+CREATE TABLE IF NOT EXISTS task_links (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_task_links_task ON task_links (task_id, created_at);
+
+-- Per-user addresses for creating tasks from email (<token>@INBOUND_EMAIL_DOMAIN)
+CREATE TABLE IF NOT EXISTS inbound_email_addresses (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    allowed_senders JSONB NOT NULL, -- lowercase addresses or "@domain" entries
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Emails turned into tasks (duplicate Message-ID check and hourly limit)
+CREATE TABLE IF NOT EXISTS inbound_emails (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    sender VARCHAR(255) NOT NULL,
+    task_id VARCHAR(36) NOT NULL, -- no foreign key: deleting the task keeps the hourly limit
+    received_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_user_received ON inbound_emails (user_id, received_at);
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_user_message ON inbound_emails (user_id, message_id);
+
+-- Group schedule card settings (cards are only rendered once enabled)
+CREATE TABLE IF NOT EXISTS group_schedule_card_settings (
+    group_id VARCHAR(36) PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    show_titles BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_tasks_compound ON tasks (status, assignee_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_notifications_compound ON notifications (user_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_compound ON refresh_tokens (user_id, expires_at, revoked_at);
+CREATE INDEX IF NOT EXISTS idx_friendships_compound ON friendships (requester_id, addressee_id, status);
+
+-- updated_at triggers for the tables that use ON UPDATE CURRENT_TIMESTAMP in MySQL
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'refresh_tokens', 'tasks', 'notifications', 'task_comments', 'friendships', 'invitations',
+        'groups', 'group_members', 'group_permissions', 'group_roles', 'group_assignment_settings',
+        'task_milestones', 'task_escalation_rules', 'user_working_hours', 'feature_flags'
+    ] LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS trg_%s_updated_at ON %I', t, t);
+        EXECUTE format('CREATE TRIGGER trg_%s_updated_at BEFORE UPDATE ON %I FOR EACH ROW EXECUTE FUNCTION set_updated_at()', t, t);
+    END LOOP;
+END;
+$$;
@@ -0,0 +1,10 @@
+// Package sqlite はSQLiteのスキーマのマイグレーションをバイナリに埋め込む
+// standaloneプロファイルではデータベースのファイル以外に必要なものがないよう、起動時に未適用のマイグレーションを適用する
+package sqlite
+
+import "embed"
+
+// Migrations はバージョン順（ファイル名の昇順）に適用するマイグレーション（migrations/NNN_名前.sql）
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,745 @@
+-- Task Management Database Schema (SQLite)
+-- Mirrors mysql/init.sql for single-user self-hosting (STORAGE_DRIVER=sqlite / APP_PROFILE=standalone).
+-- Applied once by the migration runner; later schema changes go in new numbered files in this directory.
+-- Differences from MySQL:
+--   * ENUM columns are VARCHAR with CHECK constraints, JSON columns are TEXT
+--   * timestamps are stored as UTC text in the format the driver writes, so they compare lexically
+--   * ON UPDATE CURRENT_TIMESTAMP is emulated by AFTER UPDATE triggers
+--   * index names are prefixed with the table name (SQLite index names are unique per database)
+--   * users.email and users.username use NOCASE like MySQL's case-insensitive collation
+--   * FULLTEXT indexes are omitted; searches use LIKE
+
+-- Users table for authentication
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(255) COLLATE NOCASE UNIQUE NOT NULL,
+    username VARCHAR(255) COLLATE NOCASE UNIQUE NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    role VARCHAR(10) DEFAULT 'user' CHECK (role IN ('user', 'admin')),
+    email_verified BOOLEAN DEFAULT FALSE,
+    last_login DATETIME NULL,
+    deactivated_at DATETIME NULL, -- NULL while the account is active
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Refresh tokens table
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(255) UNIQUE NOT NULL,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at DATETIME NOT NULL,
+    issued_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    revoked_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+-- Tasks table
+CREATE TABLE IF NOT EXISTS tasks (
+    id VARCHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) DEFAULT 'TODO' CHECK (status IN ('TODO', 'IN_PROGRESS', 'DONE')),
+    priority VARCHAR(10) DEFAULT 'MEDIUM' CHECK (priority IN ('LOW', 'MEDIUM', 'HIGH')),
+    category VARCHAR(10) DEFAULT 'OTHER' CHECK (category IN ('WORK', 'PERSONAL', 'STUDY', 'HEALTH', 'SHOPPING', 'OTHER')),
+    assignee_id VARCHAR(36) NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    due_date DATETIME NULL,
+    start_date DATETIME NULL,
+    estimate_minutes INT NULL,
+    estimate_points INT NULL,
+    actual_minutes INT NULL,
+    completed_at DATETIME NULL,
+    require_all_assignees BOOLEAN NOT NULL DEFAULT FALSE,
+    checklist TEXT NULL, -- extracted from the markdown task list items of the description
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority);
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_id ON tasks (assignee_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_created_by ON tasks (created_by);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_start_date ON tasks (start_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_category ON tasks (category);
+CREATE INDEX IF NOT EXISTS idx_tasks_completed_at ON tasks (completed_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks (created_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_status_due ON tasks (assignee_id, status, due_date); -- per-user overdue lookups
+CREATE INDEX IF NOT EXISTS idx_tasks_created_by_status_due ON tasks (created_by, status, due_date);
+
+-- Task assignees table (assignee_id on tasks holds the primary assignee)
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at DATETIME NULL,
+    PRIMARY KEY (task_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_assignees_user_completed ON task_assignees (user_id, completed_at);
+
+-- Notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    type VARCHAR(20) DEFAULT 'APP_NOTIFICATION' CHECK (type IN ('APP_NOTIFICATION', 'TASK_ASSIGNED', 'TASK_COMPLETED', 'TASK_DUE_SOON', 'TASK_MENTIONED', 'SYSTEM_NOTICE')),
+    status VARCHAR(10) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SENT', 'READ', 'FAILED')),
+    metadata TEXT NULL,
+    group_key VARCHAR(255) NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    sent_at DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications (status);
+CREATE INDEX IF NOT EXISTS idx_notifications_type ON notifications (type);
+CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications (created_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_group ON notifications (user_id, group_key, updated_at);
+
+-- Task comments table (optional feature)
+CREATE TABLE IF NOT EXISTS task_comments (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    comment TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_comments_user_id ON task_comments (user_id);
+CREATE INDEX IF NOT EXISTS idx_task_comments_created_at ON task_comments (created_at);
+
+-- Task mentions table (@username in descriptions and comments)
+CREATE TABLE IF NOT EXISTS task_mentions (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    source_type VARCHAR(20) NOT NULL CHECK (source_type IN ('DESCRIPTION', 'COMMENT')),
+    source_id VARCHAR(36) NOT NULL,
+    mentioned_user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    author_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_task_mentions_mention UNIQUE (source_type, source_id, mentioned_user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_mentions_mentioned_user_created ON task_mentions (mentioned_user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_mentions_task_id ON task_mentions (task_id);
+
+-- Task share links table (read-only public links)
+CREATE TABLE IF NOT EXISTS task_share_links (
+    id VARCHAR(36) PRIMARY KEY,
+    token VARCHAR(64) NOT NULL,
+    owner_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(10) NOT NULL CHECK (target_type IN ('TASK', 'TASK_LIST')),
+    task_id VARCHAR(36) NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    filter TEXT NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at DATETIME NULL,
+    revoked_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_task_share_links_token UNIQUE (token)
+);
+CREATE INDEX IF NOT EXISTS idx_task_share_links_owner_created ON task_share_links (owner_id, created_at);
+
+-- Task attachments table (optional feature)
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    file_path VARCHAR(500) NOT NULL,
+    file_size BIGINT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    -- NONE (not an image), PENDING, READY or FAILED
+    thumbnail_status VARCHAR(16) NOT NULL DEFAULT 'NONE',
+    uploaded_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_thumbnail ON task_attachments (thumbnail_status, created_at);
+
+-- User roles table (for more complex role management)
+CREATE TABLE IF NOT EXISTS user_roles (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_name VARCHAR(50) NOT NULL,
+    granted_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    granted_by VARCHAR(36) NULL REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT uq_user_roles_user_role UNIQUE (user_id, role_name)
+);
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_name ON user_roles (role_name);
+
+-- Insert sample data
+INSERT INTO users (id, email, username, password, role, email_verified) VALUES
+('550e8400-e29b-41d4-a716-446655440000', 'admin@example.com', 'admin', '$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi', 'admin', TRUE),
+('550e8400-e29b-41d4-a716-446655440001', 'user@example.com', 'testuser', '$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi', 'user', TRUE)
+ON CONFLICT DO NOTHING;
+
+-- Insert sample tasks
+INSERT INTO tasks (id, title, description, status, priority, created_by) VALUES
+('660e8400-e29b-41d4-a716-446655440000', 'プロジェクト設計', 'アプリケーションアーキテクチャの設計', 'IN_PROGRESS', 'HIGH', '550e8400-e29b-41d4-a716-446655440000'),
+('660e8400-e29b-41d4-a716-446655440001', 'データベース設計', 'ERD作成とテーブル設計', 'TODO', 'MEDIUM', '550e8400-e29b-41d4-a716-446655440000'),
+('660e8400-e29b-41d4-a716-446655440002', 'API実装', 'REST API エンドポイントの実装', 'TODO', 'HIGH', '550e8400-e29b-41d4-a716-446655440001')
+ON CONFLICT DO NOTHING;
+
+-- Insert sample notifications
+INSERT INTO notifications (id, user_id, title, message, type, status) VALUES
+('770e8400-e29b-41d4-a716-446655440000', '550e8400-e29b-41d4-a716-446655440001', 'タスクが割り当てられました', '新しいタスク「API実装」が割り当てられました', 'TASK_ASSIGNED', 'SENT'),
+('770e8400-e29b-41d4-a716-446655440001', '550e8400-e29b-41d4-a716-446655440001', 'システムメンテナンス', 'システムメンテナンスが予定されています', 'SYSTEM_NOTICE', 'PENDING')
+ON CONFLICT DO NOTHING;
+
+-- Social module tables
+-- Friendships table for friend relationships
+CREATE TABLE IF NOT EXISTS friendships (
+    id VARCHAR(36) PRIMARY KEY,
+    requester_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    addressee_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(10) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'BLOCKED')),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    accepted_at DATETIME NULL,
+    blocked_at DATETIME NULL,
+    reminder_sent_at DATETIME NULL,
+    -- Unordered user pair: one row per pair regardless of who sent the request
+    user_pair VARCHAR(73) GENERATED ALWAYS AS (
+        CASE WHEN requester_id < addressee_id THEN requester_id || ':' || addressee_id ELSE addressee_id || ':' || requester_id END
+    ) STORED,
+    CONSTRAINT uq_friendships_friendship UNIQUE (requester_id, addressee_id),
+    CONSTRAINT uq_friendships_pair UNIQUE (user_pair)
+);
+CREATE INDEX IF NOT EXISTS idx_friendships_addressee_id ON friendships (addressee_id);
+CREATE INDEX IF NOT EXISTS idx_friendships_status ON friendships (status);
+CREATE INDEX IF NOT EXISTS idx_friendships_created_at ON friendships (created_at);
+
+-- Groups table (created before invitations, which reference it)
+CREATE TABLE IF NOT EXISTS groups (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('PROJECT', 'SCHEDULE')),
+    owner_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_group_id VARCHAR(36) NULL REFERENCES groups(id) ON DELETE SET NULL, -- Set for sub-teams of a project group
+    member_count INT DEFAULT 1,
+    is_public BOOLEAN DEFAULT FALSE,
+    allow_member_invite BOOLEAN DEFAULT TRUE,
+    require_approval BOOLEAN DEFAULT TRUE,
+    enable_notifications BOOLEAN DEFAULT TRUE,
+    -- Schedule group settings
+    default_privacy_level VARCHAR(10) NULL CHECK (default_privacy_level IN ('NONE', 'BUSY', 'TITLE', 'DETAILS')),
+    allow_schedule_details BOOLEAN NULL,
+    -- Project group settings
+    enable_gantt_chart BOOLEAN NULL,
+    enable_task_dependency BOOLEAN NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    version INT DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS idx_groups_owner_id ON groups (owner_id);
+CREATE INDEX IF NOT EXISTS idx_groups_parent_group_id ON groups (parent_group_id);
+CREATE INDEX IF NOT EXISTS idx_groups_type ON groups (type);
+CREATE INDEX IF NOT EXISTS idx_groups_is_public ON groups (is_public);
+CREATE INDEX IF NOT EXISTS idx_groups_created_at ON groups (created_at);
+
+-- Invitations table for invitation system
+CREATE TABLE IF NOT EXISTS invitations (
+    id VARCHAR(36) PRIMARY KEY,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('FRIEND', 'GROUP')),
+    method VARCHAR(10) NOT NULL CHECK (method IN ('IN_APP', 'CODE', 'URL')),
+    status VARCHAR(20) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELED', 'UNDELIVERABLE')),
+    inviter_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_id VARCHAR(36) NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_email VARCHAR(255) NULL,
+    invitee_username VARCHAR(255) NULL,
+    invitee_phone VARCHAR(20) NULL,
+    target_id VARCHAR(36) NULL REFERENCES groups(id) ON DELETE CASCADE, -- group_id for group invitations
+    code VARCHAR(255) NULL,
+    url TEXT NULL,
+    message TEXT NULL,
+    metadata TEXT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    accepted_at DATETIME NULL,
+    email_sent_at DATETIME NULL,
+    email_send_count INT NOT NULL DEFAULT 0,
+    bounce_reason VARCHAR(255) NULL,
+    CONSTRAINT uq_invitations_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_invitations_inviter_id ON invitations (inviter_id);
+CREATE INDEX IF NOT EXISTS idx_invitations_invitee_id ON invitations (invitee_id);
+CREATE INDEX IF NOT EXISTS idx_invitations_invitee_email ON invitations (invitee_email);
+CREATE INDEX IF NOT EXISTS idx_invitations_status ON invitations (status);
+CREATE INDEX IF NOT EXISTS idx_invitations_type ON invitations (type);
+CREATE INDEX IF NOT EXISTS idx_invitations_expires_at ON invitations (expires_at);
+CREATE INDEX IF NOT EXISTS idx_invitations_created_at ON invitations (created_at);
+
+-- Group members table
+CREATE TABLE IF NOT EXISTS group_members (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(36) NOT NULL DEFAULT 'MEMBER', -- OWNER/ADMIN/MEMBER/GUEST or the id of a group_roles row
+    joined_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_group_members_member UNIQUE (group_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_members_user_id ON group_members (user_id);
+CREATE INDEX IF NOT EXISTS idx_group_members_role ON group_members (role);
+CREATE INDEX IF NOT EXISTS idx_group_members_joined_at ON group_members (joined_at);
+
+-- Group permissions table (only actions changed from the defaults are stored)
+CREATE TABLE IF NOT EXISTS group_permissions (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    roles VARCHAR(100) NOT NULL DEFAULT '', -- comma-separated ADMIN/MEMBER; the owner is always allowed
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, action)
+);
+
+-- Group custom roles table (members reference these by id in group_members.role)
+CREATE TABLE IF NOT EXISTS group_roles (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    permissions VARCHAR(255) NOT NULL DEFAULT '', -- comma-separated configurable actions
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_group_roles_name UNIQUE (group_id, name)
+);
+
+-- Group task auto-assignment settings (policy applied when a group task has no assignee)
+CREATE TABLE IF NOT EXISTS group_assignment_settings (
+    group_id VARCHAR(36) PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    policy VARCHAR(20) NOT NULL DEFAULT 'NONE', -- NONE/ROUND_ROBIN/LEAST_LOADED
+    last_assignee_id VARCHAR(36) NULL, -- member picked last, the round-robin cursor
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Group leaderboard settings (opt-in, weekly periods start Monday 00:00 in timezone)
+CREATE TABLE IF NOT EXISTS group_leaderboard_settings (
+    group_id VARCHAR(36) PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at DATETIME NOT NULL
+);
+
+-- Leaderboard visibility per member (members without a row are VISIBLE)
+CREATE TABLE IF NOT EXISTS group_leaderboard_preferences (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'VISIBLE', -- VISIBLE/ANONYMOUS/HIDDEN
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+
+-- Group tasks table (extending tasks with group context)
+CREATE TABLE IF NOT EXISTS group_tasks (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_group_tasks_task_group UNIQUE (task_id, group_id)
+);
+CREATE INDEX IF NOT EXISTS idx_group_tasks_group_id ON group_tasks (group_id);
+
+-- Project group milestones (ordered within the group)
+CREATE TABLE IF NOT EXISTS task_milestones (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    due_date DATETIME NOT NULL,
+    position INT NOT NULL DEFAULT 0, -- display order within the group, starting at 0
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_milestones_group_position ON task_milestones (group_id, position);
+
+-- Tasks attached to milestones (a task belongs to at most one milestone)
+CREATE TABLE IF NOT EXISTS milestone_tasks (
+    task_id VARCHAR(36) PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    milestone_id VARCHAR(36) NOT NULL REFERENCES task_milestones(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_milestone_tasks_milestone_id ON milestone_tasks (milestone_id);
+
+-- Finish-to-start dependencies between group tasks (used by the group timeline)
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    depends_on_task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE, -- must be completed before task_id starts
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_task_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies (depends_on_task_id);
+
+-- Append-only task change events (replayed to reconstruct a task at any point in time)
+CREATE TABLE IF NOT EXISTS task_events (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL, -- 1-based, per task
+    type VARCHAR(20) NOT NULL CHECK (type IN ('CREATED', 'FIELD_CHANGED', 'STATUS_CHANGED')),
+    actor_id VARCHAR(36) NULL, -- NULL for changes made by the system
+    changes TEXT NOT NULL, -- new values of the changed fields, keyed by field name
+    occurred_at DATETIME NOT NULL,
+    CONSTRAINT uq_task_events_task_sequence UNIQUE (task_id, sequence)
+);
+CREATE INDEX IF NOT EXISTS idx_task_events_task_occurred_at ON task_events (task_id, occurred_at);
+
+-- Task state snapshots (taken every 20 events so replays start from the latest one)
+CREATE TABLE IF NOT EXISTS task_snapshots (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL,
+    state TEXT NOT NULL,
+    taken_at DATETIME NOT NULL,
+    PRIMARY KEY (task_id, sequence)
+);
+CREATE INDEX IF NOT EXISTS idx_task_snapshots_task_taken_at ON task_snapshots (task_id, taken_at);
+
+-- Task escalation rules table (per-user / per-group overdue escalation)
+CREATE TABLE IF NOT EXISTS task_escalation_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('USER', 'GROUP')),
+    owner_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    overdue_hours INT NOT NULL DEFAULT 0,
+    raise_priority BOOLEAN DEFAULT TRUE,
+    notify_assignee BOOLEAN DEFAULT TRUE,
+    notify_group_admins BOOLEAN DEFAULT FALSE,
+    reassign_to VARCHAR(36) NULL REFERENCES users(id) ON DELETE SET NULL,
+    enabled BOOLEAN DEFAULT TRUE,
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_escalation_rules_scope_owner ON task_escalation_rules (scope, owner_id);
+CREATE INDEX IF NOT EXISTS idx_task_escalation_rules_enabled ON task_escalation_rules (enabled);
+
+-- Task escalation history (prevents applying the same rule twice)
+CREATE TABLE IF NOT EXISTS task_escalations (
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    rule_id VARCHAR(36) NOT NULL REFERENCES task_escalation_rules(id) ON DELETE CASCADE,
+    escalated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, rule_id)
+);
+
+-- User working hours table (workload capacity settings)
+CREATE TABLE IF NOT EXISTS user_working_hours (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    work_days VARCHAR(20) NOT NULL DEFAULT '1,2,3,4,5',
+    start_time CHAR(5) NOT NULL DEFAULT '09:00',
+    end_time CHAR(5) NOT NULL DEFAULT '18:00',
+    daily_capacity_minutes INT NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Feature flags changed by administrators (override the FEATURE_FLAGS defaults)
+CREATE TABLE IF NOT EXISTS feature_flags (
+    flag_key VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE, -- master switch; when FALSE the flag is off for everyone
+    percentage SMALLINT NOT NULL DEFAULT 0 CHECK (percentage BETWEEN 0 AND 100), -- share of users enabled by hashing flag_key and user id
+    users TEXT NOT NULL, -- user ids enabled regardless of the percentage
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Security audit log of authentication events (rows older than SECURITY_AUDIT_RETENTION are deleted by the application)
+CREATE TABLE IF NOT EXISTS security_events (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NULL, -- NULL when the user is unknown (e.g. login with an unregistered email); kept after the user is deleted
+    type VARCHAR(32) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    detail VARCHAR(255) NOT NULL DEFAULT '', -- failure reason or the denied route
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_type ON security_events (type, created_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_created_at ON security_events (created_at);
+
+-- Devices users have logged in from, and per-user settings for suspicious login alerts
+CREATE TABLE IF NOT EXISTS login_devices (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 of the User-Agent
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    trusted BOOLEAN NOT NULL DEFAULT FALSE, -- trusted devices are not asked for a verification code
+    trusted_until DATETIME NULL, -- remembered after a verified login; trusted until then when the signed cookie is presented
+    trust_token_hash CHAR(64) NOT NULL DEFAULT '', -- SHA-256 of the cookie token
+    last_ip VARCHAR(45) NOT NULL DEFAULT '',
+    last_country CHAR(2) NULL, -- NULL when GeoIP is disabled or the location is unknown
+    last_latitude REAL NULL,
+    last_longitude REAL NULL,
+    verification_code_hash CHAR(64) NOT NULL DEFAULT '', -- set while a login from this device awaits its emailed code
+    verification_expires_at DATETIME NULL,
+    verification_attempts INT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    last_seen_at DATETIME NULL, -- NULL until a login from this device completes
+    CONSTRAINT uq_login_devices_user_fingerprint UNIQUE (user_id, fingerprint)
+);
+
+CREATE TABLE IF NOT EXISTS login_alert_settings (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    require_verification BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME NOT NULL
+);
+
+-- Admin-issued API keys (SCIM provisioning)
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    display_prefix VARCHAR(16) NOT NULL, -- first characters of the key, shown in the key list
+    key_hash CHAR(64) NOT NULL, -- SHA-256 of the key; the key itself is never stored
+    scopes VARCHAR(255) NOT NULL, -- comma separated
+    created_by VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- the admin who issued the key; owns groups created through SCIM
+    created_at DATETIME NOT NULL,
+    last_used_at DATETIME NULL,
+    revoked_at DATETIME NULL,
+    CONSTRAINT uq_api_keys_key_hash UNIQUE (key_hash)
+);
+
+CREATE TABLE IF NOT EXISTS sso_connections (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    domains VARCHAR(1000) NOT NULL, -- comma separated, lower case; a domain belongs to one connection
+    issuer VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(512) NOT NULL,
+    enforced BOOLEAN NOT NULL DEFAULT FALSE, -- password login is rejected for the domains (admins excepted)
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    role_claim VARCHAR(100) NOT NULL DEFAULT '', -- empty: roles are not synchronized from the IdP
+    admin_values VARCHAR(1000) NOT NULL DEFAULT '', -- comma separated claim values mapped to admin
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sso_identities (
+    connection_id VARCHAR(36) NOT NULL REFERENCES sso_connections(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL, -- the IdP's sub claim
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (connection_id, subject)
+);
+
+CREATE TABLE IF NOT EXISTS billing_subscriptions (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    plan_id VARCHAR(20) NOT NULL, -- pro or team
+    status VARCHAR(30) NOT NULL, -- Stripe subscription status (active, trialing, past_due, canceled, ...)
+    stripe_customer_id VARCHAR(255) NOT NULL DEFAULT '',
+    stripe_subscription_id VARCHAR(255) NULL,
+    current_period_end DATETIME NULL,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    last_event_at DATETIME NULL, -- creation time of the last applied event; older events are ignored
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    CONSTRAINT uq_billing_subscriptions_stripe UNIQUE (stripe_subscription_id)
+);
+
+CREATE TABLE IF NOT EXISTS billing_events (
+    event_id VARCHAR(255) PRIMARY KEY, -- Stripe event id; webhooks are retried and may arrive twice
+    event_type VARCHAR(100) NOT NULL,
+    processed_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS analytics_preferences (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE, -- users without a row are opted in
+    updated_at DATETIME NOT NULL
+);
+
+-- Single-row sequence; allocating from it inside the writing transaction keeps commit order equal to seq order,
+-- so a client cursor never skips a change that commits later with a smaller seq.
+CREATE TABLE IF NOT EXISTS sync_sequence (
+    id SMALLINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    pruned_through BIGINT NOT NULL DEFAULT 0 -- cursors older than this must do a full resync
+);
+
+INSERT INTO sync_sequence (id, last_seq, pruned_through) VALUES (1, 0, 0) ON CONFLICT DO NOTHING;
+
+-- One row per recipient; op is 'upsert' (data holds the entity snapshot) or 'delete' (tombstone, data is NULL)
+CREATE TABLE IF NOT EXISTS sync_changes (
+    seq BIGINT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 0, -- version the entity had after the change
+    data TEXT NULL,
+    changed_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sync_changes_user_seq ON sync_changes (user_id, seq);
+CREATE INDEX IF NOT EXISTS idx_sync_changes_changed_at ON sync_changes (changed_at);
+
+-- Users currently receiving changes for an entity; users dropped from this set get a tombstone
+CREATE TABLE IF NOT EXISTS sync_entity_recipients (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (entity_type, entity_id, user_id)
+);
+
+-- Current version per entity; pushed updates and deletes must be based on it
+CREATE TABLE IF NOT EXISTS sync_entity_versions (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    version BIGINT NOT NULL,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+-- Result per client mutation id, so a resent batch returns the original results instead of applying twice
+CREATE TABLE IF NOT EXISTS sync_mutations (
+    user_id VARCHAR(36) NOT NULL,
+    client_mutation_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- accepted, conflict or rejected
+    version BIGINT NOT NULL DEFAULT 0,
+    data TEXT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    applied_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, client_mutation_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sync_mutations_applied_at ON sync_mutations (applied_at);
+
+-- Cached OpenGraph metadata for links in task descriptions and comments (keyed by the SHA-256 of the URL)
+CREATE TABLE IF NOT EXISTS link_previews (
+    url_hash CHAR(64) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    image_url VARCHAR(2048) NOT NULL DEFAULT '',
+    site_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL, -- READY or FAILED
+    fetched_at DATETIME NOT NULL
+);
+
+-- Weekly report email subscriptions (last_sent_week is the ISO week of the last report sent)
+CREATE TABLE IF NOT EXISTS weekly_report_subscriptions (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    last_sent_week VARCHAR(8) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- Daily stats per user and UTC day, updated by task changes and reconciled nightly
+CREATE TABLE IF NOT EXISTS daily_stats (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stat_date DATE NOT NULL,
+    total_tasks INT NOT NULL DEFAULT 0,
+    completed_tasks INT NOT NULL DEFAULT 0,
+    in_progress_tasks INT NOT NULL DEFAULT 0,
+    todo_tasks INT NOT NULL DEFAULT 0,
+    overdue_tasks INT NOT NULL DEFAULT 0,
+    stale_at DATETIME NULL, -- earliest future due date among open tasks
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, stat_date)
+);
+CREATE INDEX IF NOT EXISTS idx_daily_stats_date ON daily_stats (stat_date, user_id);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_tasks_compound ON tasks (status, assignee_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_notifications_compound ON notifications (user_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_compound ON refresh_tokens (user_id, expires_at, revoked_at);
+CREATE INDEX IF NOT EXISTS idx_friendships_compound ON friendships (requester_id, addressee_id, status);
+
+-- updated_at triggers for the tables that use ON UPDATE CURRENT_TIMESTAMP in MySQL
+CREATE TRIGGER IF NOT EXISTS trg_users_updated_at AFTER UPDATE ON users
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE users SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_refresh_tokens_updated_at AFTER UPDATE ON refresh_tokens
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE refresh_tokens SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_tasks_updated_at AFTER UPDATE ON tasks
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE tasks SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_notifications_updated_at AFTER UPDATE ON notifications
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE notifications SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_task_comments_updated_at AFTER UPDATE ON task_comments
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE task_comments SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_friendships_updated_at AFTER UPDATE ON friendships
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE friendships SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_invitations_updated_at AFTER UPDATE ON invitations
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE invitations SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_groups_updated_at AFTER UPDATE ON groups
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE groups SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_group_members_updated_at AFTER UPDATE ON group_members
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE group_members SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_group_permissions_updated_at AFTER UPDATE ON group_permissions
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE group_permissions SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_group_roles_updated_at AFTER UPDATE ON group_roles
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE group_roles SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_group_assignment_settings_updated_at AFTER UPDATE ON group_assignment_settings
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE group_assignment_settings SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_task_milestones_updated_at AFTER UPDATE ON task_milestones
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE task_milestones SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_task_escalation_rules_updated_at AFTER UPDATE ON task_escalation_rules
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE task_escalation_rules SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_user_working_hours_updated_at AFTER UPDATE ON user_working_hours
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE user_working_hours SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_feature_flags_updated_at AFTER UPDATE ON feature_flags
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE feature_flags SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
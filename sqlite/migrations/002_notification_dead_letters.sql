@@ -0,0 +1,15 @@
+-- Notification dead letters: channel deliveries that still failed after retrying
+-- (redriven_at is set once an admin re-drive succeeds, so pending rows are the NULL ones)
+CREATE TABLE IF NOT EXISTS notification_dead_letters (
+    id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(32) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    redriven_at DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notification_dead_letters_pending ON notification_dead_letters (redriven_at, created_at);
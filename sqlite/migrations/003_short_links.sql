@@ -0,0 +1,13 @@
+-- Short links: /s/{slug} redirects for invitation and share URLs
+-- (one row per issuing invitation or share link; expires_at is NULL when it never expires)
+CREATE TABLE IF NOT EXISTS short_links (
+    slug VARCHAR(16) PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    reference VARCHAR(64) NOT NULL,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    last_clicked_at DATETIME NULL,
+    expires_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (kind, reference)
+);
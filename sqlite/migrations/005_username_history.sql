@@ -0,0 +1,12 @@
+-- Username history: previous usernames kept so old @mentions and profile links resolve
+-- (a previous username stays reserved for its owner for USERNAME_HOLD_PERIOD after the change)
+CREATE TABLE IF NOT EXISTS username_history (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_username VARCHAR(255) NOT NULL,
+    new_username VARCHAR(255) NOT NULL,
+    changed_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_user ON username_history (user_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_username_history_old ON username_history (old_username, changed_at);
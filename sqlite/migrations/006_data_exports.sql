@@ -0,0 +1,17 @@
+-- Data exports: asynchronous "download my data" archives (POST /me/export)
+-- (file_key is cleared once expires_at passes and the stored zip is deleted)
+CREATE TABLE IF NOT EXISTS data_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    file_key VARCHAR(255) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    started_at DATETIME NULL,
+    completed_at DATETIME NULL,
+    expires_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user ON data_exports (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports (status, created_at);
@@ -0,0 +1,9 @@
+-- Data imports: source/target id mapping for records restored from an export archive (POST /me/import)
+CREATE TABLE IF NOT EXISTS data_import_records (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    section VARCHAR(64) NOT NULL,
+    source_id VARCHAR(64) NOT NULL,
+    target_id VARCHAR(64) NOT NULL,
+    imported_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, section, source_id)
+);
@@ -0,0 +1,12 @@
+-- Notification preferences and the pending social actions digest
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    social_digest VARCHAR(16) NOT NULL DEFAULT 'DAILY',
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS social_digest_states (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    notification_id VARCHAR(36) NOT NULL,
+    last_sent_at DATETIME NOT NULL
+);
@@ -0,0 +1,18 @@
+-- Group event RSVP and attendance tracking
+CREATE TABLE IF NOT EXISTS group_event_rsvps (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL, -- YES, NO, MAYBE or NO_RESPONSE
+    attended BOOLEAN,
+    responded_at DATETIME,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (group_id, event_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS group_event_reminders (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    reminded_at DATETIME NOT NULL,
+    PRIMARY KEY (group_id, event_id)
+);
@@ -0,0 +1,29 @@
+-- Recurring group events with per-occurrence exceptions
+-- Occurrence ids ("<task id>_20240603T090000Z") are stored in group_event_rsvps and
+-- group_event_reminders as is; SQLite does not enforce the VARCHAR length.
+CREATE TABLE IF NOT EXISTS group_event_recurrences (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    frequency VARCHAR(16) NOT NULL, -- DAILY, WEEKLY or MONTHLY
+    interval_count INTEGER NOT NULL DEFAULT 1,
+    weekdays VARCHAR(32) NOT NULL DEFAULT '',
+    occurrence_count INTEGER NOT NULL DEFAULT 0,
+    until_at DATETIME,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    created_by VARCHAR(36) NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (group_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_event_recurrences_until ON group_event_recurrences (until_at);
+
+CREATE TABLE IF NOT EXISTS group_event_exceptions (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    original_starts_at DATETIME NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at DATETIME,
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (group_id, event_id, original_starts_at)
+);
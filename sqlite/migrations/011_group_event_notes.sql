@@ -0,0 +1,26 @@
+-- Meeting notes attached to group events
+CREATE TABLE IF NOT EXISTS group_event_notes (
+    id VARCHAR(36) PRIMARY KEY,
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_event_notes_event ON group_event_notes (group_id, event_id, created_at);
+
+CREATE TABLE IF NOT EXISTS group_note_action_items (
+    note_id VARCHAR(36) NOT NULL REFERENCES group_event_notes(id) ON DELETE CASCADE,
+    group_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    item_text VARCHAR(500) NOT NULL,
+    assignee_id VARCHAR(36),
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (note_id, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_note_action_items_task ON group_note_action_items (group_id, task_id);
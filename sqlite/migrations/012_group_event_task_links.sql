@@ -0,0 +1,12 @@
+-- Links between group events and tasks (BLOCK: work block for the task, FOLLOW_UP: task created from the event)
+CREATE TABLE IF NOT EXISTS group_event_task_links (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (event_id, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_event_task_links_task ON group_event_task_links (task_id);
@@ -0,0 +1,23 @@
+-- Task locations (one per task) and geofences registered around them (one per user and task)
+CREATE TABLE IF NOT EXISTS task_locations (
+    task_id VARCHAR(36) PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    latitude DOUBLE NOT NULL,
+    longitude DOUBLE NOT NULL,
+    updated_by VARCHAR(36) NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_locations_lat_lng ON task_locations (latitude, longitude);
+
+CREATE TABLE IF NOT EXISTS task_geofences (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    radius_meters INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    last_triggered_at DATETIME NULL,
+    UNIQUE (user_id, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_geofences_task ON task_geofences (task_id);
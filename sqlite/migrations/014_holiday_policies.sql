@@ -0,0 +1,4 @@
+-- Holiday-aware due dates (KEEP, NEXT_BUSINESS_DAY or PREVIOUS_BUSINESS_DAY) and
+-- recurring group events ('' to keep, SKIP or NEXT_BUSINESS_DAY)
+ALTER TABLE user_working_hours ADD COLUMN holiday_policy VARCHAR(32) NOT NULL DEFAULT 'KEEP';
+ALTER TABLE group_event_recurrences ADD COLUMN holiday_policy VARCHAR(32) NOT NULL DEFAULT '';
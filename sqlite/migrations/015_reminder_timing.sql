@@ -0,0 +1,16 @@
+-- Working-hours aware reminders (WORKING_HOURS or ANYTIME) and the reminders
+-- held outside working hours (one per user, task and kind)
+ALTER TABLE user_working_hours ADD COLUMN reminder_timing VARCHAR(32) NOT NULL DEFAULT 'WORKING_HOURS';
+
+CREATE TABLE IF NOT EXISTS deferred_reminders (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    task_title VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    deliver_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (user_id, task_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_deferred_reminders_deliver_at ON deferred_reminders (deliver_at);
@@ -0,0 +1,17 @@
+-- Chat integrations of groups (Discord channel webhooks)
+-- events is comma-separated, templates is a JSON object of the changed message templates
+CREATE TABLE IF NOT EXISTS group_chat_integrations (
+    group_id VARCHAR(36) NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL,
+    webhook_url VARCHAR(500) NOT NULL,
+    events VARCHAR(100) NOT NULL,
+    templates TEXT NOT NULL,
+    summary_hour INTEGER NOT NULL DEFAULT 9,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    enabled BOOLEAN NOT NULL DEFAULT 1,
+    last_summary_date VARCHAR(10) NOT NULL DEFAULT '',
+    created_by VARCHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (group_id, platform)
+);
@@ -0,0 +1,4 @@
+-- Due reminders posted to group chats (TASK_DUE_SOON, due_reminder_hours before the due date);
+-- due dates up to due_checked_until have already been reminded
+ALTER TABLE group_chat_integrations ADD COLUMN due_reminder_hours INTEGER NOT NULL DEFAULT 24;
+ALTER TABLE group_chat_integrations ADD COLUMN due_checked_until DATETIME;
@@ -0,0 +1,28 @@
+-- GitHub issues and pull requests linked to tasks (state synced by the GitHub webhook)
+-- and the GitHub tokens users register to read issues in private repositories
+CREATE TABLE IF NOT EXISTS task_issue_links (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    repository VARCHAR(150) NOT NULL,
+    number INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    complete_on_close BOOLEAN NOT NULL DEFAULT 1,
+    created_by VARCHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    UNIQUE (task_id, provider, repository, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_issue_links_issue ON task_issue_links (provider, repository, number);
+
+CREATE TABLE IF NOT EXISTS github_accounts (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    login VARCHAR(100) NOT NULL,
+    token VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
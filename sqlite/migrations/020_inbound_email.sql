@@ -0,0 +1,20 @@
+-- Creating tasks from email sent to a per-user address
+CREATE TABLE IF NOT EXISTS inbound_email_addresses (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    allowed_senders TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS inbound_emails (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    sender VARCHAR(255) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    received_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_user_received ON inbound_emails (user_id, received_at);
+CREATE INDEX IF NOT EXISTS idx_inbound_emails_user_message ON inbound_emails (user_id, message_id);
@@ -0,0 +1,8 @@
+-- Group schedule card settings (cards are only rendered once enabled)
+CREATE TABLE IF NOT EXISTS group_schedule_card_settings (
+    group_id VARCHAR(36) PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    show_titles BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at DATETIME NOT NULL
+);